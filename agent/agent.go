@@ -152,8 +152,14 @@ const (
 	Namespace         = "NAMESPACE"
 	AgentServiceName  = "AGENT_SERVICE_NAME"
 	MongoOplogSize    = "MONGO_OPLOG_SIZE"
+	MongoCacheSize    = "MONGO_CACHE_SIZE"
 	NUMACtlPreference = "NUMA_CTL_PREFERENCE"
 
+	// MongoSingleNode is set on the agent of a controller that was
+	// bootstrapped as a single node, and has not yet been promoted to
+	// HA, to run mongo with lighter-weight settings.
+	MongoSingleNode = "MONGO_SINGLE_NODE"
+
 	AgentLoginRateLimit  = "AGENT_LOGIN_RATE_LIMIT"
 	AgentLoginMinPause   = "AGENT_LOGIN_MIN_PAUSE"
 	AgentLoginMaxPause   = "AGENT_LOGIN_MAX_PAUSE"
@@ -176,6 +182,25 @@ const (
 	LogSinkDBLoggerFlushInterval = "LOGSINK_DBLOGGER_FLUSH_INTERVAL"
 	LogSinkRateLimitBurst        = "LOGSINK_RATELIMIT_BURST"
 	LogSinkRateLimitRefill       = "LOGSINK_RATELIMIT_REFILL"
+
+	// APICallTimeouts overrides the timeouts applied to API calls.
+	// It holds a comma-separated list of "Facade.Method=duration" or
+	// "Facade=duration" entries.
+	APICallTimeouts = "API_CALL_TIMEOUTS"
+
+	// APISlowCallThreshold is the duration above which API calls are
+	// logged as slow; "0s" disables slow call logging.
+	APISlowCallThreshold = "API_SLOW_CALL_THRESHOLD"
+
+	// MigrationBandwidthLimit is the maximum rate, in bytes per
+	// second, at which binaries are transferred to the target
+	// controller during a model migration.
+	MigrationBandwidthLimit = "MIGRATION_BANDWIDTH_LIMIT"
+
+	// APIServerCertGeneration records the generation of the API
+	// server certificate last generated by this controller agent,
+	// as configured on the controller application.
+	APIServerCertGeneration = "APISERVER_CERT_GENERATION"
 )
 
 // The Config interface is the sole way that the agent gets access to the
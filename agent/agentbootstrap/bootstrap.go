@@ -158,6 +158,9 @@ func InitializeState(
 	if err != nil {
 		return nil, nil, errors.Annotate(err, "cannot initialize bootstrap machine")
 	}
+	if _, err := st.AddControllerApplication(m.Series(), servingInfo.APIPort); err != nil {
+		return nil, nil, errors.Annotate(err, "cannot add controller application")
+	}
 
 	// Create the initial hosted model, with the model config passed to
 	// bootstrap, which contains the UUID, name for the hosted model,
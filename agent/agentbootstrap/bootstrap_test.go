@@ -284,6 +284,15 @@ LXC_BRIDGE="ignored"`[1:])
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(*gotHW, gc.DeepEquals, expectHW)
 
+	// Check that the controller application has been added, with
+	// the API port the controller is serving on.
+	controllerApp, err := st.ControllerApplication()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(controllerApp.Series(), gc.Equals, m.Series())
+	controllerAppConfig, err := controllerApp.CharmConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(controllerAppConfig["api-port"], gc.Equals, int64(1234))
+
 	// Check that the API host ports are initialised correctly.
 	apiHostPorts, err := st.APIHostPorts()
 	c.Assert(err, jc.ErrorIsNil)
@@ -148,6 +148,32 @@ func (t *ToolsSuite) TestUnpackToolsContents(c *gc.C) {
 	t.assertToolsContents(c, testTools, files)
 }
 
+func (t *ToolsSuite) TestInstallTools(c *gc.C) {
+	jujudPath := filepath.Join(c.MkDir(), "jujud")
+	err := ioutil.WriteFile(jujudPath, []byte("jujud contents"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	testTools := &coretest.Tools{
+		Version: version.MustParseBinary("1.2.3-quantal-amd64"),
+	}
+
+	err = agenttools.InstallTools(t.dataDir, testTools, jujudPath)
+	c.Assert(err, jc.ErrorIsNil)
+	assertDirNames(c, t.toolsDir(), []string{"1.2.3-quantal-amd64"})
+	dir := agenttools.SharedToolsDir(t.dataDir, testTools.Version)
+	assertFileContents(c, dir, "jujud", "jujud contents", 0500)
+	gotTools, err := agenttools.ReadTools(t.dataDir, testTools.Version)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(gotTools, jc.DeepEquals, testTools)
+
+	// Installing the same version again leaves the original in place.
+	err = ioutil.WriteFile(jujudPath, []byte("other contents"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = agenttools.InstallTools(t.dataDir, testTools, jujudPath)
+	c.Assert(err, jc.ErrorIsNil)
+	assertDirNames(c, t.toolsDir(), []string{"1.2.3-quantal-amd64"})
+	assertFileContents(c, dir, "jujud", "jujud contents", 0500)
+}
+
 func (t *ToolsSuite) TestReadToolsErrors(c *gc.C) {
 	vers := version.MustParseBinary("1.2.3-precise-amd64")
 	testTools, err := agenttools.ReadTools(t.dataDir, vers)
@@ -144,6 +144,48 @@ func UnpackTools(dataDir string, tools *coretools.Tools, r io.Reader) (err error
 	return err
 }
 
+// InstallTools copies the jujud binary at the given path into the
+// appropriate tools directory within dataDir, recording the given
+// tools metadata alongside it. It is used to install the agent
+// binary already present on a machine, rather than one downloaded
+// from the controller. If a valid tools directory already exists,
+// InstallTools returns without error.
+func InstallTools(dataDir string, tools *coretools.Tools, jujudPath string) (err error) {
+	if _, err := ReadTools(dataDir, tools.Version); err == nil {
+		return nil
+	}
+	src, err := os.Open(jujudPath)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer src.Close()
+
+	toolsDir := path.Join(dataDir, "tools")
+	if err := os.MkdirAll(toolsDir, dirPerm); err != nil {
+		return errors.Trace(err)
+	}
+	dir, err := ioutil.TempDir(toolsDir, "installing-")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer removeAll(dir)
+
+	if err := writeFile(path.Join(dir, "jujud"), dirPerm, src); err != nil {
+		return errors.Annotate(err, "copying jujud")
+	}
+	toolsMetadataData, err := json.Marshal(tools)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := ioutil.WriteFile(path.Join(dir, toolsFile), toolsMetadataData, 0644); err != nil {
+		return errors.Trace(err)
+	}
+	if err := os.Chmod(dir, dirPerm); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(os.Rename(dir, SharedToolsDir(dataDir, tools.Version)))
+}
+
 func removeAll(dir string) {
 	err := os.RemoveAll(dir)
 	if err == nil || os.IsNotExist(err) {
@@ -137,6 +137,10 @@ type state struct {
 	macaroons []macaroon.Slice
 	nonce     string
 
+	// readOnly holds whether a read-only connection is requested
+	// on login.
+	readOnly bool
+
 	// serverRootAddress holds the cached API server address and port used
 	// to login.
 	serverRootAddress string
@@ -242,6 +246,7 @@ func Open(info *Info, opts DialOpts) (Connection, error) {
 		password:     info.Password,
 		macaroons:    info.Macaroons,
 		nonce:        info.Nonce,
+		readOnly:     info.ReadOnly,
 		tlsConfig:    dialResult.tlsConfig,
 		bakeryClient: bakeryClient,
 		modelTag:     info.ModelTag,
@@ -573,6 +578,9 @@ func dialAPI(ctx context.Context, info *Info, opts0 DialOpts) (*dialResult, erro
 	// some tests call dialAPI directly.
 	if opts.DialWebsocket == nil {
 		opts.DialWebsocket = gorillaDialWebsocket
+		if opts.EnableCompression {
+			opts.DialWebsocket = gorillaDialCompressedWebsocket
+		}
 	}
 	if opts.IPAddrResolver == nil {
 		opts.IPAddrResolver = net.DefaultResolver
@@ -606,6 +614,16 @@ func dialAPI(ctx context.Context, info *Info, opts0 DialOpts) (*dialResult, erro
 // is used only for TLS verification when tlsConfig.ServerName
 // is empty.
 func gorillaDialWebsocket(ctx context.Context, urlStr string, tlsConfig *tls.Config, ipAddr string) (jsoncodec.JSONConn, error) {
+	return dialGorillaWebsocket(ctx, urlStr, tlsConfig, ipAddr, false)
+}
+
+// gorillaDialCompressedWebsocket is like gorillaDialWebsocket, but
+// also negotiates per-message compression with the server.
+func gorillaDialCompressedWebsocket(ctx context.Context, urlStr string, tlsConfig *tls.Config, ipAddr string) (jsoncodec.JSONConn, error) {
+	return dialGorillaWebsocket(ctx, urlStr, tlsConfig, ipAddr, true)
+}
+
+func dialGorillaWebsocket(ctx context.Context, urlStr string, tlsConfig *tls.Config, ipAddr string, compress bool) (jsoncodec.JSONConn, error) {
 	url, err := url.Parse(urlStr)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -627,8 +645,9 @@ func gorillaDialWebsocket(ctx context.Context, urlStr string, tlsConfig *tls.Con
 		TLSClientConfig: tlsConfig,
 		// In order to deal with the remote side not handling message
 		// fragmentation, we default to largeish frames.
-		ReadBufferSize:  websocketFrameSize,
-		WriteBufferSize: websocketFrameSize,
+		ReadBufferSize:    websocketFrameSize,
+		WriteBufferSize:   websocketFrameSize,
+		EnableCompression: compress,
 	}
 	// Note: no extra headers.
 	c, _, err := dialer.Dial(urlStr, nil)
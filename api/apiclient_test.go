@@ -175,6 +175,17 @@ func (s *apiclientSuite) TestOpen(c *gc.C) {
 	c.Assert(remoteVersion, gc.Equals, jujuversion.Current)
 }
 
+func (s *apiclientSuite) TestOpenWithCompression(c *gc.C) {
+	info := s.APIInfo(c)
+	st, err := api.Open(info, api.DialOpts{EnableCompression: true})
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+
+	// Responses are decompressed transparently.
+	_, err = st.Client().Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *apiclientSuite) TestOpenHonorsModelTag(c *gc.C) {
 	info := s.APIInfo(c)
 
@@ -8,6 +8,8 @@
 package application
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/charm.v6"
@@ -17,7 +19,9 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/charmrefresh"
 	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/trust"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/storage"
 )
@@ -139,13 +143,28 @@ func (c *Client) Deploy(args DeployArgs) error {
 			Resources:        args.Resources,
 		}},
 	}
-	var results params.ErrorResults
-	var err error
-	err = c.facade.FacadeCall("Deploy", deployArgs, &results)
-	if err != nil {
+	if c.BestAPIVersion() < 11 {
+		var results params.ErrorResults
+		if err := c.facade.FacadeCall("Deploy", deployArgs, &results); err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Trace(results.OneError())
+	}
+	var results params.DeployResults
+	if err := c.facade.FacadeCall("Deploy", deployArgs, &results); err != nil {
 		return errors.Trace(err)
 	}
-	return errors.Trace(results.OneError())
+	if len(results.Results) != 1 {
+		return errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	for _, warning := range result.Warnings {
+		logger.Warningf("%s", warning)
+	}
+	if result.Error != nil {
+		return errors.Trace(result.Error)
+	}
+	return nil
 }
 
 // GetCharmURL returns the charm URL the given service is
@@ -270,25 +289,6 @@ type SetCharmConfig struct {
 
 // SetCharm sets the charm for a given service.
 func (c *Client) SetCharm(cfg SetCharmConfig) error {
-	var storageConstraints map[string]params.StorageConstraints
-	if len(cfg.StorageConstraints) > 0 {
-		storageConstraints = make(map[string]params.StorageConstraints)
-		for name, cons := range cfg.StorageConstraints {
-			size, count := cons.Size, cons.Count
-			var sizePtr, countPtr *uint64
-			if size > 0 {
-				sizePtr = &size
-			}
-			if count > 0 {
-				countPtr = &count
-			}
-			storageConstraints[name] = params.StorageConstraints{
-				Pool:  cons.Pool,
-				Size:  sizePtr,
-				Count: countPtr,
-			}
-		}
-	}
 	args := params.ApplicationSetCharm{
 		ApplicationName:    cfg.ApplicationName,
 		CharmURL:           cfg.CharmID.URL.String(),
@@ -298,11 +298,96 @@ func (c *Client) SetCharm(cfg SetCharmConfig) error {
 		ForceSeries:        cfg.ForceSeries,
 		ForceUnits:         cfg.ForceUnits,
 		ResourceIDs:        cfg.ResourceIDs,
-		StorageConstraints: storageConstraints,
+		StorageConstraints: storageConstraintsParams(cfg.StorageConstraints),
 	}
 	return c.facade.FacadeCall("SetCharm", args, nil)
 }
 
+// UpdateStorageConstraints updates the storage constraints for the
+// named stores of the application. Zero-valued fields in the given
+// constraints leave the existing values unchanged. The constraints
+// apply to storage added for units added later.
+func (c *Client) UpdateStorageConstraints(application string, cons map[string]storage.Constraints) error {
+	if c.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("UpdateStorageConstraints")
+	}
+	args := params.ApplicationStorageUpdateRequest{
+		ApplicationStorageUpdates: []params.ApplicationStorageUpdate{{
+			ApplicationTag:     names.NewApplicationTag(application).String(),
+			StorageConstraints: storageConstraintsParams(cons),
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("UpdateStorageConstraints", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// SetTrust sets the trust level of the named application, which
+// determines the cloud credentials its charm may obtain.
+func (c *Client) SetTrust(application string, level trust.Level) error {
+	if c.BestAPIVersion() < 10 {
+		return errors.NotSupportedf("SetTrust")
+	}
+	args := params.ApplicationTrustArgs{
+		Args: []params.ApplicationTrust{{
+			ApplicationName: application,
+			Level:           string(level),
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetTrust", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// SetRefreshPolicy sets the policy under which the named application's
+// charm is refreshed automatically when newer revisions are published
+// to the channel it tracks.
+func (c *Client) SetRefreshPolicy(application string, policy charmrefresh.Policy) error {
+	if c.BestAPIVersion() < 14 {
+		return errors.NotSupportedf("SetRefreshPolicy")
+	}
+	args := params.ApplicationRefreshPolicyArgs{
+		Args: []params.ApplicationRefreshPolicy{{
+			ApplicationName: application,
+			Policy:          string(policy),
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetRefreshPolicy", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// storageConstraintsParams converts storage constraints into their
+// API representation, omitting unspecified sizes and counts.
+func storageConstraintsParams(cons map[string]storage.Constraints) map[string]params.StorageConstraints {
+	if len(cons) == 0 {
+		return nil
+	}
+	result := make(map[string]params.StorageConstraints)
+	for name, c := range cons {
+		size, count := c.Size, c.Count
+		var sizePtr, countPtr *uint64
+		if size > 0 {
+			sizePtr = &size
+		}
+		if count > 0 {
+			countPtr = &count
+		}
+		result[name] = params.StorageConstraints{
+			Pool:  c.Pool,
+			Size:  sizePtr,
+			Count: countPtr,
+		}
+	}
+	return result
+}
+
 // Update updates the application attributes, including charm URL,
 // minimum number of units, settings and constraints.
 func (c *Client) Update(args params.ApplicationUpdate) error {
@@ -395,6 +480,15 @@ type DestroyUnitsParams struct {
 	// DestroyStorage controls whether or not storage attached
 	// to the units will be destroyed.
 	DestroyStorage bool
+
+	// DrainTimeout, if non-zero, requests that the units' charm
+	// drain each unit before it is torn down, and is the longest
+	// time to wait for it to do so.
+	DrainTimeout time.Duration
+
+	// Force controls whether steps that could hold up the removal
+	// of the units are skipped.
+	Force bool
 }
 
 // DestroyUnits decreases the number of units dedicated to one or more
@@ -403,6 +497,13 @@ func (c *Client) DestroyUnits(in DestroyUnitsParams) ([]params.DestroyUnitResult
 	argsV5 := params.DestroyUnitsParams{
 		Units: make([]params.DestroyUnitParams, 0, len(in.Units)),
 	}
+	if (in.DrainTimeout != 0 || in.Force) && c.BestAPIVersion() < 6 {
+		return nil, errors.New("this controller does not support draining or forcing unit removal")
+	}
+	var drainTimeout *time.Duration
+	if in.DrainTimeout != 0 {
+		drainTimeout = &in.DrainTimeout
+	}
 	allResults := make([]params.DestroyUnitResult, len(in.Units))
 	index := make([]int, 0, len(in.Units))
 	for i, name := range in.Units {
@@ -416,6 +517,8 @@ func (c *Client) DestroyUnits(in DestroyUnitsParams) ([]params.DestroyUnitResult
 		argsV5.Units = append(argsV5.Units, params.DestroyUnitParams{
 			UnitTag:        names.NewUnitTag(name).String(),
 			DestroyStorage: in.DestroyStorage,
+			DrainTimeout:   drainTimeout,
+			Force:          in.Force,
 		})
 	}
 	if len(argsV5.Units) == 0 {
@@ -526,6 +629,48 @@ func (c *Client) DestroyApplications(in DestroyApplicationsParams) ([]params.Des
 	return allResults, nil
 }
 
+// DestroyApplicationsPlan reports what destroying the given
+// applications with the given parameters would remove or leave
+// behind, without destroying them.
+func (c *Client) DestroyApplicationsPlan(in DestroyApplicationsParams) ([]params.DestroyApplicationPlanResult, error) {
+	if c.BestAPIVersion() < 7 {
+		return nil, errors.NotSupportedf("DestroyPlan")
+	}
+	args := params.DestroyApplicationsParams{
+		Applications: make([]params.DestroyApplicationParams, 0, len(in.Applications)),
+	}
+	allResults := make([]params.DestroyApplicationPlanResult, len(in.Applications))
+	index := make([]int, 0, len(in.Applications))
+	for i, name := range in.Applications {
+		if !names.IsValidApplication(name) {
+			allResults[i].Error = &params.Error{
+				Message: errors.NotValidf("application name %q", name).Error(),
+			}
+			continue
+		}
+		index = append(index, i)
+		args.Applications = append(args.Applications, params.DestroyApplicationParams{
+			ApplicationTag: names.NewApplicationTag(name).String(),
+			DestroyStorage: in.DestroyStorage,
+		})
+	}
+	if len(args.Applications) == 0 {
+		return allResults, nil
+	}
+
+	var result params.DestroyApplicationPlanResults
+	if err := c.facade.FacadeCall("DestroyPlan", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(result.Results); n != len(args.Applications) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(args.Applications), n)
+	}
+	for i, result := range result.Results {
+		allResults[index[i]] = result
+	}
+	return allResults, nil
+}
+
 // DestroyConsumedApplication destroys the given consumed (remote) applications.
 func (c *Client) DestroyConsumedApplication(saasNames ...string) ([]params.ErrorResult, error) {
 	args := params.DestroyConsumedApplicationsParams{
@@ -594,6 +739,69 @@ func (c *Client) GetConstraints(applications ...string) ([]constraints.Value, er
 	return allConstraints, nil
 }
 
+// GetConstraintsDetail returns the effective constraints of the given
+// applications and units, along with where each constraint attribute's
+// value came from.
+func (c *Client) GetConstraintsDetail(entities ...string) ([]params.ConstraintsDetailResult, error) {
+	if c.BestAPIVersion() < 12 {
+		return nil, errors.NotSupportedf("GetConstraintsDetail")
+	}
+	var args params.Entities
+	for _, entity := range entities {
+		var tag names.Tag
+		if names.IsValidUnit(entity) {
+			tag = names.NewUnitTag(entity)
+		} else {
+			tag = names.NewApplicationTag(entity)
+		}
+		args.Entities = append(args.Entities, params.Entity{tag.String()})
+	}
+	var results params.ConstraintsDetailResults
+	if err := c.facade.FacadeCall("GetConstraintsDetail", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(entities) {
+		return nil, errors.Errorf("expected %d results, got %d", len(entities), len(results.Results))
+	}
+	for i, result := range results.Results {
+		if result.Error != nil {
+			return nil, errors.Annotatef(result.Error, "unable to get constraints for %q", entities[i])
+		}
+	}
+	return results.Results, nil
+}
+
+// PlacementPlan reports where the units of each of the given
+// prospective deployments would be placed, given the model's current
+// machines, without deploying anything. The results correspond to the
+// given arguments, and each holds its own error, if any.
+func (c *Client) PlacementPlan(args ...params.PlacementPlanArg) ([]params.PlacementPlanResult, error) {
+	if c.BestAPIVersion() < 13 {
+		return nil, errors.NotSupportedf("PlacementPlan")
+	}
+	var results params.PlacementPlanResults
+	if err := c.facade.FacadeCall("PlacementPlan", params.PlacementPlanArgs{Args: args}, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(args) {
+		return nil, errors.Errorf("expected %d results, got %d", len(args), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// PlacementMachines returns the machines of the model that can host
+// units, with the load that the units already on them put on them.
+func (c *Client) PlacementMachines() ([]params.MachineLoad, error) {
+	if c.BestAPIVersion() < 15 {
+		return nil, errors.NotSupportedf("PlacementMachines")
+	}
+	var result params.PlacementMachinesResult
+	if err := c.facade.FacadeCall("PlacementMachines", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Machines, nil
+}
+
 // SetConstraints specifies the constraints for the given application.
 func (c *Client) SetConstraints(application string, constraints constraints.Value) error {
 	params := params.SetConstraints{
@@ -617,6 +825,20 @@ func (c *Client) Unexpose(application string) error {
 	return c.facade.FacadeCall("Unexpose", params, nil)
 }
 
+// SetRelationBrokenBarrier sets whether the units of the named
+// application must all have run the relation-departed hooks for a
+// dying or suspended relation before any of them runs relation-broken.
+func (c *Client) SetRelationBrokenBarrier(application string, enabled bool) error {
+	if c.BestAPIVersion() < 8 {
+		return errors.NotSupportedf("SetRelationBrokenBarrier")
+	}
+	args := params.ApplicationRelationBrokenBarrier{
+		ApplicationName: application,
+		Enabled:         enabled,
+	}
+	return c.facade.FacadeCall("SetRelationBrokenBarrier", args, nil)
+}
+
 // Get returns the configuration for the named application.
 func (c *Client) Get(application string) (*params.ApplicationGetResults, error) {
 	var results params.ApplicationGetResults
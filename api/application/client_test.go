@@ -4,6 +4,8 @@
 package application_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -17,7 +19,9 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/charmrefresh"
 	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/trust"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/storage"
 	coretesting "github.com/juju/juju/testing"
@@ -30,6 +34,10 @@ type applicationSuite struct {
 var _ = gc.Suite(&applicationSuite{})
 
 func newClient(f basetesting.APICallerFunc) *application.Client {
+	return application.NewClient(basetesting.BestVersionCaller{f, 10})
+}
+
+func newClientV5(f basetesting.APICallerFunc) *application.Client {
 	return application.NewClient(basetesting.BestVersionCaller{f, 5})
 }
 
@@ -127,6 +135,34 @@ func (s *applicationSuite) TestDeploy(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *applicationSuite) TestDeployWarnings(c *gc.C) {
+	var called bool
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				called = true
+				c.Assert(request, gc.Equals, "Deploy")
+				result := response.(*params.DeployResults)
+				result.Results = []params.DeployResult{{
+					Warnings: []string{"incompatible"},
+					Error:    &params.Error{Message: "boom"},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 11,
+	})
+	err := client.Deploy(application.DeployArgs{
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("trusty/a-charm-1"),
+		},
+		ApplicationName: "serviceA",
+		NumUnits:        1,
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(called, jc.IsTrue)
+}
+
 func (s *applicationSuite) TestDeployAttachStorageV4(c *gc.C) {
 	var called bool
 	client := application.NewClient(basetesting.BestVersionCaller{
@@ -354,6 +390,191 @@ func (s *applicationSuite) TestDestroyApplications(c *gc.C) {
 	c.Assert(results, jc.DeepEquals, expectedResults)
 }
 
+func (s *applicationSuite) TestDestroyApplicationsPlan(c *gc.C) {
+	expectedResults := []params.DestroyApplicationPlanResult{{
+		Error: &params.Error{Message: "boo"},
+	}, {
+		Plan: &params.DestroyApplicationPlan{
+			DestroyedStorage:           []params.Entity{{Tag: "storage-pgdata-0"}},
+			DestroyedUnits:             []params.Entity{{Tag: "unit-bar-1"}},
+			RemovedRelations:           []params.Entity{{Tag: "relation-baz.db#bar.server"}},
+			RemovedCrossModelRelations: []params.Entity{{Tag: "relation-baz.db#bar.server"}},
+			DestroyedMachines:          []params.Entity{{Tag: "machine-1"}},
+		},
+	}}
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Assert(request, gc.Equals, "DestroyPlan")
+		c.Assert(a, jc.DeepEquals, params.DestroyApplicationsParams{
+			Applications: []params.DestroyApplicationParams{
+				{ApplicationTag: "application-foo", DestroyStorage: true},
+				{ApplicationTag: "application-bar", DestroyStorage: true},
+			},
+		})
+		c.Assert(response, gc.FitsTypeOf, &params.DestroyApplicationPlanResults{})
+		out := response.(*params.DestroyApplicationPlanResults)
+		*out = params.DestroyApplicationPlanResults{expectedResults}
+		return nil
+	})
+	results, err := client.DestroyApplicationsPlan(application.DestroyApplicationsParams{
+		Applications:   []string{"foo", "bar"},
+		DestroyStorage: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expectedResults)
+}
+
+func (s *applicationSuite) TestDestroyApplicationsPlanNotSupported(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 6,
+	})
+	_, err := client.DestroyApplicationsPlan(application.DestroyApplicationsParams{
+		Applications: []string{"foo"},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestSetRelationBrokenBarrier(c *gc.C) {
+	called := false
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetRelationBrokenBarrier")
+		c.Assert(a, jc.DeepEquals, params.ApplicationRelationBrokenBarrier{
+			ApplicationName: "foo",
+			Enabled:         true,
+		})
+		return nil
+	})
+	err := client.SetRelationBrokenBarrier("foo", true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestSetRelationBrokenBarrierNotSupported(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 7,
+	})
+	err := client.SetRelationBrokenBarrier("foo", true)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestUpdateStorageConstraints(c *gc.C) {
+	called := false
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "UpdateStorageConstraints")
+		size := uint64(1024)
+		c.Assert(a, jc.DeepEquals, params.ApplicationStorageUpdateRequest{
+			ApplicationStorageUpdates: []params.ApplicationStorageUpdate{{
+				ApplicationTag: "application-foo",
+				StorageConstraints: map[string]params.StorageConstraints{
+					"data": {Pool: "ebs", Size: &size},
+				},
+			}},
+		})
+		c.Assert(response, gc.FitsTypeOf, &params.ErrorResults{})
+		*(response.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+		}
+		return nil
+	})
+	err := client.UpdateStorageConstraints("foo", map[string]storage.Constraints{
+		"data": {Pool: "ebs", Size: 1024},
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestUpdateStorageConstraintsNotSupported(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 8,
+	})
+	err := client.UpdateStorageConstraints("foo", nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestSetTrust(c *gc.C) {
+	called := false
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetTrust")
+		c.Assert(a, jc.DeepEquals, params.ApplicationTrustArgs{
+			Args: []params.ApplicationTrust{{
+				ApplicationName: "foo",
+				Level:           "read-only",
+			}},
+		})
+		c.Assert(response, gc.FitsTypeOf, &params.ErrorResults{})
+		*(response.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+		}
+		return nil
+	})
+	err := client.SetTrust("foo", trust.ReadOnly)
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestSetTrustNotSupported(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 9,
+	})
+	err := client.SetTrust("foo", trust.Full)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestSetRefreshPolicy(c *gc.C) {
+	called := false
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Assert(request, gc.Equals, "SetRefreshPolicy")
+			c.Assert(a, jc.DeepEquals, params.ApplicationRefreshPolicyArgs{
+				Args: []params.ApplicationRefreshPolicy{{
+					ApplicationName: "foo",
+					Policy:          "patch-only",
+				}},
+			})
+			c.Assert(response, gc.FitsTypeOf, &params.ErrorResults{})
+			*(response.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+			}
+			return nil
+		},
+		BestVersion: 14,
+	})
+	err := client.SetRefreshPolicy("foo", charmrefresh.PatchOnly)
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestSetRefreshPolicyNotSupported(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 13,
+	})
+	err := client.SetRefreshPolicy("foo", charmrefresh.Any)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *applicationSuite) TestDestroyApplicationsV4(c *gc.C) {
 	expectedResults := []params.DestroyApplicationResult{{
 		Error: &params.Error{Message: "boo"},
@@ -471,6 +692,47 @@ func (s *applicationSuite) TestDestroyUnits(c *gc.C) {
 	c.Assert(results, jc.DeepEquals, expectedResults)
 }
 
+func (s *applicationSuite) TestDestroyUnitsDrainForce(c *gc.C) {
+	expectedResults := []params.DestroyUnitResult{{
+		Info: &params.DestroyUnitInfo{
+			SkippedSteps: []string{"drain hook"},
+		},
+	}}
+	drainTimeout := 10 * time.Minute
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Assert(request, gc.Equals, "DestroyUnit")
+		c.Assert(a, jc.DeepEquals, params.DestroyUnitsParams{
+			Units: []params.DestroyUnitParams{{
+				UnitTag:      "unit-foo-0",
+				DrainTimeout: &drainTimeout,
+				Force:        true,
+			}},
+		})
+		out := response.(*params.DestroyUnitResults)
+		*out = params.DestroyUnitResults{expectedResults}
+		return nil
+	})
+	results, err := client.DestroyUnits(application.DestroyUnitsParams{
+		Units:        []string{"foo/0"},
+		DrainTimeout: drainTimeout,
+		Force:        true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expectedResults)
+}
+
+func (s *applicationSuite) TestDestroyUnitsDrainNotSupported(c *gc.C) {
+	client := newClientV5(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	_, err := client.DestroyUnits(application.DestroyUnitsParams{
+		Units:        []string{"foo/0"},
+		DrainTimeout: time.Minute,
+	})
+	c.Assert(err, gc.ErrorMatches, "this controller does not support draining or forcing unit removal")
+}
+
 func (s *applicationSuite) TestDestroyUnitsV4(c *gc.C) {
 	expectedResults := []params.DestroyUnitResult{{
 		Error: &params.Error{Message: "boo"},
@@ -910,6 +1172,153 @@ func (s *applicationSuite) TestGetConstraintsError(c *gc.C) {
 	c.Assert(results, gc.IsNil)
 }
 
+func (s *applicationSuite) TestGetConstraintsDetail(c *gc.C) {
+	expected := []params.ConstraintsDetailResult{{
+		Constraints: constraints.MustParse("mem=4G"),
+		Attributes: []params.ConstraintAttributeDetail{{
+			Attribute: "mem",
+			Value:     "4096M",
+			Source:    "model",
+		}},
+	}, {
+		Constraints: constraints.MustParse("cores=2"),
+		Attributes: []params.ConstraintAttributeDetail{{
+			Attribute: "cores",
+			Value:     "2",
+			Source:    "unit",
+		}},
+	}}
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "GetConstraintsDetail")
+				c.Assert(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{
+						{"application-foo"}, {"unit-bar-0"},
+					}})
+				c.Assert(response, gc.FitsTypeOf, &params.ConstraintsDetailResults{})
+				response.(*params.ConstraintsDetailResults).Results = expected
+				return nil
+			},
+		),
+		BestVersion: 12,
+	})
+
+	results, err := client.GetConstraintsDetail("foo", "bar/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expected)
+}
+
+func (s *applicationSuite) TestGetConstraintsDetailError(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				response.(*params.ConstraintsDetailResults).Results = []params.ConstraintsDetailResult{
+					{Error: &params.Error{Message: "oh no"}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 12,
+	})
+
+	results, err := client.GetConstraintsDetail("foo")
+	c.Assert(err, gc.ErrorMatches, `unable to get constraints for "foo": oh no`)
+	c.Assert(results, gc.IsNil)
+}
+
+func (s *applicationSuite) TestGetConstraintsDetailNotSupported(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 11,
+	})
+	_, err := client.GetConstraintsDetail("foo")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestPlacementPlan(c *gc.C) {
+	args := []params.PlacementPlanArg{{
+		ApplicationName: "foo",
+		NumUnits:        2,
+	}}
+	expected := []params.PlacementPlanResult{{
+		Units: []params.PlannedUnitPlacement{
+			{MachineId: "0", Zone: "zone-a"},
+			{NewMachine: true, Warnings: []string{"oh no"}},
+		},
+	}}
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "PlacementPlan")
+				c.Assert(a, jc.DeepEquals, params.PlacementPlanArgs{Args: args})
+				c.Assert(response, gc.FitsTypeOf, &params.PlacementPlanResults{})
+				response.(*params.PlacementPlanResults).Results = expected
+				return nil
+			},
+		),
+		BestVersion: 13,
+	})
+
+	results, err := client.PlacementPlan(args...)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expected)
+}
+
+func (s *applicationSuite) TestPlacementPlanNotSupported(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 12,
+	})
+	_, err := client.PlacementPlan(params.PlacementPlanArg{NumUnits: 1})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestPlacementMachines(c *gc.C) {
+	expected := []params.MachineLoad{{
+		MachineId:    "0",
+		Series:       "bionic",
+		Units:        []string{"foo/0"},
+		Applications: []string{"foo"},
+		MemoryBytes:  1024,
+		Saturation:   []string{"oh no"},
+	}}
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "PlacementMachines")
+				c.Assert(a, gc.IsNil)
+				c.Assert(response, gc.FitsTypeOf, &params.PlacementMachinesResult{})
+				response.(*params.PlacementMachinesResult).Machines = expected
+				return nil
+			},
+		),
+		BestVersion: 15,
+	})
+
+	machines, err := client.PlacementMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, jc.DeepEquals, expected)
+}
+
+func (s *applicationSuite) TestPlacementMachinesNotSupported(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 14,
+	})
+	_, err := client.PlacementMachines()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *applicationSuite) TestGetConstraintsAPIv4(c *gc.C) {
 	fooConstraints := constraints.MustParse("mem=4G")
 	barConstraints := constraints.MustParse("mem=128G", "cores=64")
@@ -7,6 +7,7 @@ import (
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/block"
@@ -168,3 +169,69 @@ func (s *blockMockSuite) TestList(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.ErrorMatches, errmsg)
 	c.Assert(found, gc.HasLen, 1)
 }
+
+func (s *blockMockSuite) TestSwitchEntityBlockOn(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, response interface{},
+			) error {
+				called = true
+				c.Check(objType, gc.Equals, "Block")
+				c.Check(request, gc.Equals, "SwitchBlockOn")
+				c.Check(a, jc.DeepEquals, params.BlockSwitchParams{
+					Type:    state.RemoveBlock.String(),
+					Message: "production database",
+					Tag:     "application-mysql",
+				})
+				return nil
+			}),
+		BestVersion: 3,
+	}
+	blockClient := block.NewClient(apiCaller)
+	err := blockClient.SwitchEntityBlockOn(state.RemoveBlock.String(), names.NewApplicationTag("mysql"), "production database")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *blockMockSuite) TestSwitchEntityBlockOff(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, response interface{},
+			) error {
+				called = true
+				c.Check(request, gc.Equals, "SwitchBlockOff")
+				c.Check(a, jc.DeepEquals, params.BlockSwitchParams{
+					Type: state.ChangeBlock.String(),
+					Tag:  "machine-0",
+				})
+				return nil
+			}),
+		BestVersion: 3,
+	}
+	blockClient := block.NewClient(apiCaller)
+	err := blockClient.SwitchEntityBlockOff(state.ChangeBlock.String(), names.NewMachineTag("0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *blockMockSuite) TestSwitchEntityBlockOnNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(string, int, string, string, interface{}, interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			}),
+		BestVersion: 2,
+	}
+	blockClient := block.NewClient(apiCaller)
+	err := blockClient.SwitchEntityBlockOn(state.RemoveBlock.String(), names.NewMachineTag("0"), "")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
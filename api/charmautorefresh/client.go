@@ -0,0 +1,92 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmautorefresh
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/charmrefresh"
+)
+
+const apiName = "CharmAutoRefresh"
+
+// Upgrade describes a newer revision of an application's charm that
+// is waiting to be applied.
+type Upgrade struct {
+	Application string
+	CharmURL    *charm.URL
+	Policy      charmrefresh.Policy
+	Created     time.Time
+}
+
+// Facade provides access to the CharmAutoRefresh API facade.
+type Facade struct {
+	facade base.FacadeCaller
+}
+
+// NewFacade returns a new client-side CharmAutoRefresh facade.
+func NewFacade(caller base.APICaller) *Facade {
+	return &Facade{facade: base.NewFacadeCaller(caller, apiName)}
+}
+
+// MaintenanceWindowOpen reports whether the model is in a maintenance
+// window, within which pending upgrades may be applied.
+func (f *Facade) MaintenanceWindowOpen() (bool, error) {
+	var result params.BoolResult
+	if err := f.facade.FacadeCall("MaintenanceWindowOpen", nil, &result); err != nil {
+		return false, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return false, errors.Trace(result.Error)
+	}
+	return result.Result, nil
+}
+
+// PendingUpgrades returns the charm upgrades pending for the model's
+// applications.
+func (f *Facade) PendingUpgrades() ([]Upgrade, error) {
+	var result params.PendingCharmUpgradesResult
+	if err := f.facade.FacadeCall("PendingUpgrades", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	upgrades := make([]Upgrade, len(result.Upgrades))
+	for i, upgrade := range result.Upgrades {
+		tag, err := names.ParseApplicationTag(upgrade.ApplicationTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		curl, err := charm.ParseURL(upgrade.CharmURL)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		upgrades[i] = Upgrade{
+			Application: tag.Id(),
+			CharmURL:    curl,
+			Policy:      charmrefresh.Policy(upgrade.Policy),
+			Created:     upgrade.Created,
+		}
+	}
+	return upgrades, nil
+}
+
+// ApplyUpgrade applies the upgrade pending for the named application.
+func (f *Facade) ApplyUpgrade(application string) error {
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.ErrorResults
+	if err := f.facade.FacadeCall("ApplyUpgrades", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
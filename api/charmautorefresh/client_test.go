@@ -0,0 +1,90 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmautorefresh_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/charmautorefresh"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/charmrefresh"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestMaintenanceWindowOpen(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CharmAutoRefresh")
+		c.Check(request, gc.Equals, "MaintenanceWindowOpen")
+		c.Check(arg, gc.IsNil)
+		*(result.(*params.BoolResult)) = params.BoolResult{Result: true}
+		return nil
+	})
+	open, err := charmautorefresh.NewFacade(apiCaller).MaintenanceWindowOpen()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(open, jc.IsTrue)
+}
+
+func (s *clientSuite) TestMaintenanceWindowOpenError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.BoolResult)) = params.BoolResult{
+			Error: &params.Error{Message: "kaboom"},
+		}
+		return nil
+	})
+	_, err := charmautorefresh.NewFacade(apiCaller).MaintenanceWindowOpen()
+	c.Assert(err, gc.ErrorMatches, "kaboom")
+}
+
+func (s *clientSuite) TestPendingUpgrades(c *gc.C) {
+	created := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CharmAutoRefresh")
+		c.Check(request, gc.Equals, "PendingUpgrades")
+		c.Check(arg, gc.IsNil)
+		*(result.(*params.PendingCharmUpgradesResult)) = params.PendingCharmUpgradesResult{
+			Upgrades: []params.PendingCharmUpgrade{{
+				ApplicationTag: "application-mysql",
+				CharmURL:       "cs:xenial/mysql-2",
+				Policy:         "patch-only",
+				Created:        created,
+			}},
+		}
+		return nil
+	})
+	upgrades, err := charmautorefresh.NewFacade(apiCaller).PendingUpgrades()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrades, jc.DeepEquals, []charmautorefresh.Upgrade{{
+		Application: "mysql",
+		CharmURL:    charm.MustParseURL("cs:xenial/mysql-2"),
+		Policy:      charmrefresh.PatchOnly,
+		Created:     created,
+	}})
+}
+
+func (s *clientSuite) TestApplyUpgrade(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CharmAutoRefresh")
+		c.Check(request, gc.Equals, "ApplyUpgrades")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-mysql"}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "kaboom"}}},
+		}
+		return nil
+	})
+	err := charmautorefresh.NewFacade(apiCaller).ApplyUpgrade("mysql")
+	c.Assert(err, gc.ErrorMatches, "kaboom")
+}
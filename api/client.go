@@ -4,6 +4,10 @@
 package api
 
 import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -12,6 +16,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/juju/errors"
@@ -48,6 +53,21 @@ func (c *Client) Status(patterns []string) (*params.FullStatus, error) {
 	return &result, nil
 }
 
+// StatusAt returns the status of the juju model as it was at the given
+// time, reconstructed from the status history of its machines,
+// applications, units and relations.
+func (c *Client) StatusAt(patterns []string, asOf time.Time) (*params.FullStatus, error) {
+	if c.facade.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("status at a past time")
+	}
+	var result params.FullStatus
+	p := params.StatusParams{Patterns: patterns, AsOf: &asOf}
+	if err := c.facade.FacadeCall("FullStatus", p, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // StatusHistory retrieves the last <size> results of
 // <kind:combined|agent|workload|machine|machineinstance|container|containerinstance> status
 // for <name> unit
@@ -447,6 +467,14 @@ func (c *Client) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
 	return c.OpenURI(openCharmArgs(curl))
 }
 
+// OpenCharmFrom streams out the identified charm from the controller
+// via the API, starting at the given offset. It returns the offset the
+// stream actually starts from.
+func (c *Client) OpenCharmFrom(curl *charm.URL, offset int64) (io.ReadCloser, int64, error) {
+	uri, query := openCharmArgs(curl)
+	return c.OpenURIFrom(uri, query, offset)
+}
+
 // OpenCharm streams out the identified charm from the controller via
 // the API.
 func OpenCharm(apiCaller base.APICaller, curl *charm.URL) (io.ReadCloser, error) {
@@ -466,6 +494,22 @@ func (c *Client) OpenURI(uri string, query url.Values) (io.ReadCloser, error) {
 	return openURI(c.st, uri, query)
 }
 
+// OpenURIFrom performs a GET on a Juju HTTP endpoint, asking for the
+// response to start at the given offset. It returns the offset that
+// the response actually starts from, which is zero if the endpoint
+// does not support ranged requests.
+func (c *Client) OpenURIFrom(uri string, query url.Values, offset int64) (io.ReadCloser, int64, error) {
+	httpClient, err := c.st.HTTPClient()
+	if err != nil {
+		return nil, 0, errors.Trace(err)
+	}
+	blob, start, err := openBlobFrom(httpClient, uri, query, offset)
+	if err != nil {
+		return nil, 0, errors.Trace(err)
+	}
+	return blob, start, nil
+}
+
 func openURI(apiCaller base.APICaller, uri string, query url.Values) (io.ReadCloser, error) {
 	// The returned httpClient sets the base url to /model/<uuid> if it can.
 	httpClient, err := apiCaller.HTTPClient()
@@ -509,6 +553,191 @@ func (c *Client) UploadTools(r io.ReadSeeker, vers version.Binary, additionalSer
 	return resp.ToolsList, nil
 }
 
+// ToolsUpload holds agent binaries to be uploaded by UploadToolsBundle.
+type ToolsUpload struct {
+	// Version is the binary version of the agent binaries.
+	Version version.Binary
+
+	// AdditionalSeries holds any other series for which the agent
+	// binaries are also to be stored.
+	AdditionalSeries []string
+
+	// Reader supplies the agent binaries tarball, from its current
+	// offset to its end.
+	Reader io.ReadSeeker
+}
+
+// UploadToolsBundle uploads agent binaries for several architectures
+// and series to the API server over HTTPS in a single request. The
+// controller checks every tarball against the bundle's manifest, and
+// stores either all of them or none. The tarballs are read once to
+// describe them in the manifest, and again as the bundle is sent,
+// rather than being held in memory.
+func (c *Client) UploadToolsBundle(uploads []ToolsUpload) (tools.List, error) {
+	bundle, err := newToolsBundleReader(uploads)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var resp params.ToolsResult
+	if err := c.httpPost(bundle, "/tools", params.ContentTypeToolsBundle, &resp); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return resp.ToolsList, nil
+}
+
+// tarBlockSize is the size of the blocks that make up a tar archive.
+const tarBlockSize = 512
+
+// toolsBundleReader reads a tar archive holding the tarballs of some
+// agent binaries uploads, preceded by a manifest describing them. The
+// archive is read from its parts in turn, so the tarballs are never
+// held in memory. It may only be rewound to its start, which is all
+// that is needed to resend it.
+type toolsBundleReader struct {
+	parts   []io.ReadSeeker
+	current int
+}
+
+// newToolsBundleReader returns a toolsBundleReader for the given
+// uploads, reading each upload's tarball to describe it.
+func newToolsBundleReader(uploads []ToolsUpload) (*toolsBundleReader, error) {
+	if len(uploads) == 0 {
+		return nil, errors.NotValidf("empty agent binaries bundle")
+	}
+	var manifest params.ToolsBundleManifest
+	tarballs := make([]*tarballReader, len(uploads))
+	for i, upload := range uploads {
+		start, err := upload.Reader.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, errors.Annotatef(err, "reading agent binaries %s", upload.Version)
+		}
+		hash := sha256.New()
+		size, err := io.Copy(hash, upload.Reader)
+		if err != nil {
+			return nil, errors.Annotatef(err, "reading agent binaries %s", upload.Version)
+		}
+		tarballs[i] = &tarballReader{r: upload.Reader, start: start, size: size}
+		manifest.Binaries = append(manifest.Binaries, params.ToolsBundleBinary{
+			Version:          upload.Version.String(),
+			AdditionalSeries: upload.AdditionalSeries,
+			Path:             fmt.Sprintf("juju-%s.tgz", upload.Version),
+			Size:             size,
+			SHA256:           fmt.Sprintf("%x", hash.Sum(nil)),
+		})
+	}
+	manifestData, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var parts []io.ReadSeeker
+	addEntry := func(name string, content io.ReadSeeker, size int64) error {
+		var header bytes.Buffer
+		// The writer is not closed: it is used only to encode the
+		// entry's header, and the archive's end is added below.
+		if err := tar.NewWriter(&header).WriteHeader(&tar.Header{
+			Name: name,
+			Mode: 0644,
+			Size: size,
+		}); err != nil {
+			return errors.Trace(err)
+		}
+		padding := (tarBlockSize - size%tarBlockSize) % tarBlockSize
+		parts = append(parts,
+			bytes.NewReader(header.Bytes()),
+			content,
+			bytes.NewReader(make([]byte, padding)),
+		)
+		return nil
+	}
+	if err := addEntry(params.ToolsBundleManifestName, bytes.NewReader(manifestData), int64(len(manifestData))); err != nil {
+		return nil, errors.Trace(err)
+	}
+	for i, binary := range manifest.Binaries {
+		if err := addEntry(binary.Path, tarballs[i], binary.Size); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	// A tar archive ends with two zero blocks.
+	parts = append(parts, bytes.NewReader(make([]byte, 2*tarBlockSize)))
+
+	bundle := &toolsBundleReader{parts: parts}
+	if _, err := bundle.Seek(0, io.SeekStart); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return bundle, nil
+}
+
+// Read is part of the io.Reader interface.
+func (r *toolsBundleReader) Read(p []byte) (int, error) {
+	for r.current < len(r.parts) {
+		n, err := r.parts[r.current].Read(p)
+		if err == io.EOF {
+			r.current++
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+		return n, err
+	}
+	return 0, io.EOF
+}
+
+// Seek is part of the io.Seeker interface. Only seeking to the start
+// of the bundle is supported.
+func (r *toolsBundleReader) Seek(offset int64, whence int) (int64, error) {
+	if offset != 0 || whence != io.SeekStart {
+		return 0, errors.NotSupportedf("seeking other than to the start of an agent binaries bundle")
+	}
+	for _, part := range r.parts {
+		if _, err := part.Seek(0, io.SeekStart); err != nil {
+			return 0, errors.Trace(err)
+		}
+	}
+	r.current = 0
+	return 0, nil
+}
+
+// tarballReader reads the size bytes of an agent binaries tarball
+// that start at the given offset of r.
+type tarballReader struct {
+	r           io.ReadSeeker
+	start, size int64
+	remaining   int64
+}
+
+// Read is part of the io.Reader interface.
+func (r *tarballReader) Read(p []byte) (int, error) {
+	if r.remaining <= 0 {
+		return 0, io.EOF
+	}
+	if int64(len(p)) > r.remaining {
+		p = p[:r.remaining]
+	}
+	n, err := r.r.Read(p)
+	r.remaining -= int64(n)
+	if err == io.EOF && r.remaining > 0 {
+		err = errors.Errorf("agent binaries tarball changed while uploading")
+	} else if err == io.EOF {
+		err = nil
+	}
+	return n, err
+}
+
+// Seek is part of the io.Seeker interface. Only seeking to the start
+// of the tarball is supported.
+func (r *tarballReader) Seek(offset int64, whence int) (int64, error) {
+	if offset != 0 || whence != io.SeekStart {
+		return 0, errors.NotSupportedf("seeking other than to the start of agent binaries")
+	}
+	if _, err := r.r.Seek(r.start, io.SeekStart); err != nil {
+		return 0, errors.Trace(err)
+	}
+	r.remaining = r.size
+	return 0, nil
+}
+
 func (c *Client) httpPost(content io.ReadSeeker, endpoint, contentType string, response interface{}) error {
 	req, err := http.NewRequest("POST", endpoint, nil)
 	if err != nil {
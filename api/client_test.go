@@ -90,6 +90,62 @@ func (s *clientSuite) TestUploadToolsOtherModel(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *clientSuite) TestUploadToolsBundle(c *gc.C) {
+	client := s.APIState.Client()
+	uploaded, err := client.UploadToolsBundle([]api.ToolsUpload{{
+		Version: version.MustParseBinary("5.4.3-xenial-amd64"),
+		Reader:  strings.NewReader("amd64 agent"),
+	}, {
+		Version:          version.MustParseBinary("5.4.3-xenial-arm64"),
+		AdditionalSeries: []string{"bionic"},
+		Reader:           strings.NewReader("arm64 agent"),
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(uploaded, gc.HasLen, 2)
+	c.Assert(uploaded[0].Version.String(), gc.Equals, "5.4.3-xenial-amd64")
+	c.Assert(uploaded[1].Version.String(), gc.Equals, "5.4.3-xenial-arm64")
+
+	storage, err := s.State.ToolsStorage()
+	c.Assert(err, jc.ErrorIsNil)
+	defer storage.Close()
+	for _, vers := range []string{"5.4.3-xenial-amd64", "5.4.3-xenial-arm64", "5.4.3-bionic-arm64"} {
+		metadata, err := storage.Metadata(vers)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(metadata.Size, gc.Equals, int64(11))
+	}
+}
+
+func (s *clientSuite) TestUploadToolsBundleFromOffset(c *gc.C) {
+	// Only the tarball from each reader's current offset is uploaded.
+	r := strings.NewReader("ignored amd64 agent")
+	_, err := r.Seek(int64(len("ignored ")), io.SeekStart)
+	c.Assert(err, jc.ErrorIsNil)
+	client := s.APIState.Client()
+	uploaded, err := client.UploadToolsBundle([]api.ToolsUpload{{
+		Version: version.MustParseBinary("5.4.3-xenial-amd64"),
+		Reader:  r,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(uploaded, gc.HasLen, 1)
+	c.Assert(uploaded[0].Size, gc.Equals, int64(len("amd64 agent")))
+
+	storage, err := s.State.ToolsStorage()
+	c.Assert(err, jc.ErrorIsNil)
+	defer storage.Close()
+	_, rc, err := storage.Open("5.4.3-xenial-amd64")
+	c.Assert(err, jc.ErrorIsNil)
+	defer rc.Close()
+	data, err := ioutil.ReadAll(rc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "amd64 agent")
+}
+
+func (s *clientSuite) TestUploadToolsBundleEmpty(c *gc.C) {
+	client := s.APIState.Client()
+	_, err := client.UploadToolsBundle(nil)
+	c.Assert(err, gc.ErrorMatches, "empty agent binaries bundle not valid")
+}
+
 func (s *clientSuite) TestAddLocalCharm(c *gc.C) {
 	charmArchive := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
 	curl := charm.MustParseURL(
@@ -253,6 +309,21 @@ func (s *clientSuite) TestOpenURIFound(c *gc.C) {
 	c.Assert(string(content), gc.Equals, toolsVersion)
 }
 
+func (s *clientSuite) TestOpenURIFrom(c *gc.C) {
+	const toolsVersion = "2.0.0-xenial-ppc64"
+	s.AddToolsToState(c, version.MustParseBinary(toolsVersion))
+
+	client := s.APIState.Client()
+	reader, start, err := client.OpenURIFrom("/tools/"+toolsVersion, nil, 4)
+	c.Assert(err, jc.ErrorIsNil)
+	defer reader.Close()
+	c.Assert(start, gc.Equals, int64(4))
+
+	content, err := ioutil.ReadAll(reader)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(content), gc.Equals, toolsVersion[4:])
+}
+
 func (s *clientSuite) TestOpenURIError(c *gc.C) {
 	client := s.APIState.Client()
 	_, err := client.OpenURI("/tools/foobar", nil)
@@ -0,0 +1,62 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package containerimagecache implements the client-side API facade
+// used by the containerimagecache worker.
+package containerimagecache
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Facade provides access to the ContainerImageCache API facade.
+type Facade struct {
+	caller base.FacadeCaller
+}
+
+// NewFacade creates a new client-side ContainerImageCache facade.
+func NewFacade(caller base.APICaller) *Facade {
+	return &Facade{
+		caller: base.NewFacadeCaller(caller, "ContainerImageCache"),
+	}
+}
+
+// CacheConfig returns the series of the images the machine should
+// fetch ahead of creating containers, the size its cache should be
+// kept within, and any purge it should carry out.
+func (f *Facade) CacheConfig(machineId string) (params.ContainerImageCacheConfig, error) {
+	args := params.Entities{Entities: []params.Entity{{
+		Tag: names.NewMachineTag(machineId).String(),
+	}}}
+	var results params.ContainerImageCacheConfigResults
+	if err := f.caller.FacadeCall("ContainerImageCacheConfig", args, &results); err != nil {
+		return params.ContainerImageCacheConfig{}, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return params.ContainerImageCacheConfig{}, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return params.ContainerImageCacheConfig{}, err
+	}
+	return *results.Results[0].Result, nil
+}
+
+// SetImages reports the images in the machine's container image
+// cache, along with the purge carried out before they were listed,
+// if any.
+func (f *Facade) SetImages(machineId string, images []params.ContainerImage, purged *params.ContainerImagePurge) error {
+	args := params.SetContainerImagesArgs{Args: []params.SetContainerImages{{
+		Tag:    names.NewMachineTag(machineId).String(),
+		Images: images,
+		Purged: purged,
+	}}}
+	var result params.ErrorResults
+	if err := f.caller.FacadeCall("SetContainerImages", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
@@ -0,0 +1,111 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package containerimagecache_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/containerimagecache"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type facadeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) TestCacheConfig(c *gc.C) {
+	stub := new(testing.Stub)
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "ContainerImageCache")
+		c.Check(id, gc.Equals, "")
+		stub.AddCall(request, args)
+		*response.(*params.ContainerImageCacheConfigResults) = params.ContainerImageCacheConfigResults{
+			Results: []params.ContainerImageCacheConfigResult{{
+				Result: &params.ContainerImageCacheConfig{
+					PrefetchSeries: []string{"xenial"},
+					MaxSizeMB:      1024,
+				},
+			}},
+		}
+		return nil
+	})
+	facade := containerimagecache.NewFacade(apiCaller)
+
+	config, err := facade.CacheConfig("42")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(config, jc.DeepEquals, params.ContainerImageCacheConfig{
+		PrefetchSeries: []string{"xenial"},
+		MaxSizeMB:      1024,
+	})
+	stub.CheckCalls(c, []testing.StubCall{{
+		"ContainerImageCacheConfig", []interface{}{params.Entities{
+			Entities: []params.Entity{{Tag: "machine-42"}},
+		}},
+	}})
+}
+
+func (s *facadeSuite) TestCacheConfigError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		*response.(*params.ContainerImageCacheConfigResults) = params.ContainerImageCacheConfigResults{
+			Results: []params.ContainerImageCacheConfigResult{{
+				Error: &params.Error{Message: "blam"},
+			}},
+		}
+		return nil
+	})
+	facade := containerimagecache.NewFacade(apiCaller)
+
+	_, err := facade.CacheConfig("42")
+	c.Assert(err, gc.ErrorMatches, "blam")
+}
+
+func (s *facadeSuite) TestSetImages(c *gc.C) {
+	stub := new(testing.Stub)
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "ContainerImageCache")
+		stub.AddCall(request, args)
+		*response.(*params.ErrorResults) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		return nil
+	})
+	facade := containerimagecache.NewFacade(apiCaller)
+
+	images := []params.ContainerImage{{
+		Series:      "xenial",
+		Arch:        "amd64",
+		Fingerprint: "deadbeef",
+	}}
+	purged := &params.ContainerImagePurge{Requested: time.Unix(0, 100)}
+	err := facade.SetImages("42", images, purged)
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []testing.StubCall{{
+		"SetContainerImages", []interface{}{params.SetContainerImagesArgs{
+			Args: []params.SetContainerImages{{
+				Tag:    "machine-42",
+				Images: images,
+				Purged: purged,
+			}},
+		}},
+	}})
+}
@@ -5,6 +5,7 @@ package controller
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
@@ -159,6 +160,47 @@ func (c *Client) DestroyController(args DestroyControllerParams) error {
 	}, nil)
 }
 
+// AgentBinaryStorageUsage returns the storage used by the agent binaries
+// stored by the controller for all of its models.
+func (c *Client) AgentBinaryStorageUsage() (params.AgentBinaryStorageUsage, error) {
+	if c.BestAPIVersion() < 5 {
+		return params.AgentBinaryStorageUsage{}, errors.New("this Juju controller does not support reporting agent binary storage usage")
+	}
+	var result params.AgentBinaryStorageUsage
+	if err := c.facade.FacadeCall("AgentBinaryStorageUsage", nil, &result); err != nil {
+		return params.AgentBinaryStorageUsage{}, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// AgentConnectionHistory returns the recent API connections that agents
+// have made to the API server the client is connected to, agents with
+// the most connections first.
+func (c *Client) AgentConnectionHistory() ([]params.AgentConnectionHistory, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.New("this Juju controller does not support reporting agent connection history")
+	}
+	var result params.AgentConnectionHistoryResults
+	if err := c.facade.FacadeCall("AgentConnectionHistory", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Agents, nil
+}
+
+// DatabaseReport returns the statistics of the controller's database:
+// the sizes of its collections, its unused indexes and the query
+// patterns that may need indexes.
+func (c *Client) DatabaseReport() (params.DatabaseReport, error) {
+	if c.BestAPIVersion() < 8 {
+		return params.DatabaseReport{}, errors.New("this Juju controller does not support reporting database statistics")
+	}
+	var result params.DatabaseReport
+	if err := c.facade.FacadeCall("DatabaseReport", nil, &result); err != nil {
+		return params.DatabaseReport{}, errors.Trace(err)
+	}
+	return result, nil
+}
+
 // ListBlockedModels returns a list of all models within the controller
 // which have at least one block in place.
 func (c *Client) ListBlockedModels() ([]params.ModelBlockInfo, error) {
@@ -249,6 +291,12 @@ type MigrationSpec struct {
 	TargetUser           string
 	TargetPassword       string
 	TargetMacaroons      []macaroon.Slice
+
+	// RollbackWindow is how long the migrated model is validated on
+	// the target controller before the source model is removed. The
+	// migration may be rolled back with AbortAfterSuccess during the
+	// window.
+	RollbackWindow time.Duration
 }
 
 // Validate performs sanity checks on the migration configuration it
@@ -269,6 +317,9 @@ func (s *MigrationSpec) Validate() error {
 	if s.TargetPassword == "" && len(s.TargetMacaroons) == 0 {
 		return errors.NotValidf("missing authentication secrets")
 	}
+	if s.RollbackWindow < 0 {
+		return errors.NotValidf("negative rollback window")
+	}
 	return nil
 }
 
@@ -283,6 +334,10 @@ func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
 		return "", errors.Annotatef(err, "client-side validation failed")
 	}
 
+	if spec.RollbackWindow > 0 && c.BestAPIVersion() < 7 {
+		return "", errors.New("this Juju controller does not support migration rollback windows")
+	}
+
 	macsJSON, err := macaroonsToJSON(spec.TargetMacaroons)
 	if err != nil {
 		return "", errors.Annotatef(err, "client-side validation failed")
@@ -299,6 +354,7 @@ func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
 				Password:      spec.TargetPassword,
 				Macaroons:     string(macsJSON),
 			},
+			RollbackWindow: spec.RollbackWindow,
 		}},
 	}
 	response := params.InitiateMigrationResults{}
@@ -315,6 +371,24 @@ func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
 	return result.MigrationId, nil
 }
 
+// AbortAfterSuccess rolls back the migration of the specified model,
+// which must have been handed over to its target controller but still
+// be within its rollback window. The model's agents are switched back
+// to this controller, and the model is removed from the target.
+func (c *Client) AbortAfterSuccess(modelUUID string) error {
+	if c.BestAPIVersion() < 7 {
+		return errors.New("this Juju controller does not support rolling back migrations")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewModelTag(modelUUID).String()}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("AbortAfterSuccess", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
 func macaroonsToJSON(macs []macaroon.Slice) (string, error) {
 	if len(macs) == 0 {
 		return "", nil
@@ -5,6 +5,7 @@ package controller_test
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/juju/errors"
 	jujutesting "github.com/juju/testing"
@@ -79,6 +80,116 @@ func (s *Suite) TestDestroyControllerError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "nope")
 }
 
+func (s *Suite) TestAgentBinaryStorageUsage(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 5,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Controller")
+			c.Check(request, gc.Equals, "AgentBinaryStorageUsage")
+			c.Check(arg, gc.IsNil)
+			*(result.(*params.AgentBinaryStorageUsage)) = params.AgentBinaryStorageUsage{
+				Entries:    3,
+				Binaries:   2,
+				Size:       30,
+				StoredSize: 20,
+			}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	usage, err := client.AgentBinaryStorageUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage, jc.DeepEquals, params.AgentBinaryStorageUsage{
+		Entries:    3,
+		Binaries:   2,
+		Size:       30,
+		StoredSize: 20,
+	})
+}
+
+func (s *Suite) TestAgentBinaryStorageUsageAPIVersion(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{BestVersion: 4}
+	client := controller.NewClient(apiCaller)
+	_, err := client.AgentBinaryStorageUsage()
+	c.Assert(err, gc.ErrorMatches, "this Juju controller does not support reporting agent binary storage usage")
+}
+
+func (s *Suite) TestAgentConnectionHistory(c *gc.C) {
+	history := []params.AgentConnectionHistory{{
+		Tag:              "unit-mysql-0",
+		ModelTag:         coretesting.ModelTag.String(),
+		TotalConnections: 12,
+		Connections: []params.AgentConnection{{
+			ConnectionID:  3,
+			RemoteAddress: "10.0.0.1:1234",
+		}},
+	}}
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 6,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Controller")
+			c.Check(request, gc.Equals, "AgentConnectionHistory")
+			c.Check(arg, gc.IsNil)
+			*(result.(*params.AgentConnectionHistoryResults)) = params.AgentConnectionHistoryResults{
+				Agents: history,
+			}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	agents, err := client.AgentConnectionHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(agents, jc.DeepEquals, history)
+}
+
+func (s *Suite) TestAgentConnectionHistoryAPIVersion(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{BestVersion: 5}
+	client := controller.NewClient(apiCaller)
+	_, err := client.AgentConnectionHistory()
+	c.Assert(err, gc.ErrorMatches, "this Juju controller does not support reporting agent connection history")
+}
+
+func (s *Suite) TestDatabaseReport(c *gc.C) {
+	report := params.DatabaseReport{
+		Members: []params.DatabaseMember{{MachineId: "0", Primary: true}},
+		Collections: []params.DatabaseCollection{{
+			Name:      "txns",
+			Count:     10,
+			SizeBytes: 1000,
+		}},
+		UnusedIndexes: []params.DatabaseIndex{{
+			Collection: "units",
+			Name:       "model-uuid_1_name_1",
+		}},
+		MissingIndexes: []params.DatabaseQueryPattern{{
+			Collection: "statuseshistory",
+			Fields:     []string{"globalkey"},
+			Count:      2,
+		}},
+	}
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 8,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Controller")
+			c.Check(request, gc.Equals, "DatabaseReport")
+			c.Check(arg, gc.IsNil)
+			*(result.(*params.DatabaseReport)) = report
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	result, err := client.DatabaseReport()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, report)
+}
+
+func (s *Suite) TestDatabaseReportAPIVersion(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{BestVersion: 7}
+	client := controller.NewClient(apiCaller)
+	_, err := client.DatabaseReport()
+	c.Assert(err, gc.ErrorMatches, "this Juju controller does not support reporting database statistics")
+}
+
 func (s *Suite) TestInitiateMigration(c *gc.C) {
 	s.checkInitiateMigration(c, makeSpec())
 }
@@ -123,10 +234,70 @@ func specToArgs(spec controller.MigrationSpec) params.InitiateMigrationArgs {
 				Password:      spec.TargetPassword,
 				Macaroons:     string(macsJSON),
 			},
+			RollbackWindow: spec.RollbackWindow,
 		}},
 	}
 }
 
+func (s *Suite) TestInitiateMigrationRollbackWindow(c *gc.C) {
+	spec := makeSpec()
+	spec.RollbackWindow = time.Hour
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 7,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*(result.(*params.InitiateMigrationResults)) = params.InitiateMigrationResults{
+				Results: []params.InitiateMigrationResult{{MigrationId: "id"}},
+			}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	id, err := client.InitiateMigration(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(id, gc.Equals, "id")
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.InitiateMigration", []interface{}{specToArgs(spec)}},
+	})
+}
+
+func (s *Suite) TestInitiateMigrationRollbackWindowAPIVersion(c *gc.C) {
+	client, stub := makeInitiateMigrationClient(params.InitiateMigrationResults{})
+	spec := makeSpec()
+	spec.RollbackWindow = time.Hour
+	_, err := client.InitiateMigration(spec)
+	c.Check(err, gc.ErrorMatches, "this Juju controller does not support migration rollback windows")
+	c.Check(stub.Calls(), gc.HasLen, 0)
+}
+
+func (s *Suite) TestAbortAfterSuccess(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 7,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Controller")
+			c.Check(request, gc.Equals, "AbortAfterSuccess")
+			c.Check(arg, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: coretesting.ModelTag.String()}},
+			})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+			}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	err := client.AbortAfterSuccess(coretesting.ModelTag.Id())
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *Suite) TestAbortAfterSuccessAPIVersion(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{BestVersion: 6}
+	client := controller.NewClient(apiCaller)
+	err := client.AbortAfterSuccess(coretesting.ModelTag.Id())
+	c.Assert(err, gc.ErrorMatches, "this Juju controller does not support rolling back migrations")
+}
+
 func (s *Suite) TestInitiateMigrationError(c *gc.C) {
 	client, _ := makeInitiateMigrationClient(params.InitiateMigrationResults{
 		Results: []params.InitiateMigrationResult{{
@@ -0,0 +1,64 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package controllercertificates provides a client for the
+// ControllerCertificates facade, which manages the certificate
+// presented by the controller's API servers.
+package controllercertificates
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the ControllerCertificates facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new ControllerCertificates client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "ControllerCertificates")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// CertificateInfo describes the certificate presented by the
+// controller's API servers, and any ACME configuration.
+func (c *Client) CertificateInfo() (params.APICertificateInfo, error) {
+	var result params.APICertificateInfo
+	if err := c.facade.FacadeCall("CertificateInfo", nil, &result); err != nil {
+		return params.APICertificateInfo{}, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// SetExternalCertificate sets the PEM-encoded certificate chain, leaf
+// first, and private key for the controller's API servers to present.
+func (c *Client) SetExternalCertificate(certChain, privateKey string) error {
+	args := params.SetExternalCertificate{
+		CertChain:  certChain,
+		PrivateKey: privateKey,
+	}
+	return errors.Trace(c.facade.FacadeCall("SetExternalCertificate", args, nil))
+}
+
+// RemoveExternalCertificate removes the certificate chain set with
+// SetExternalCertificate.
+func (c *Client) RemoveExternalCertificate() error {
+	return errors.Trace(c.facade.FacadeCall("RemoveExternalCertificate", nil, nil))
+}
+
+// SetACMEConfig configures the controller to obtain certificates for
+// its API servers from an ACME CA.
+func (c *Client) SetACMEConfig(config params.ACMEConfig) error {
+	return errors.Trace(c.facade.FacadeCall("SetACMEConfig", config, nil))
+}
+
+// RemoveACMEConfig stops the controller obtaining certificates from
+// an ACME CA.
+func (c *Client) RemoveACMEConfig() error {
+	return errors.Trace(c.facade.FacadeCall("RemoveACMEConfig", nil, nil))
+}
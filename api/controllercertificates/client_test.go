@@ -0,0 +1,82 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllercertificates_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/controllercertificates"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestCertificateInfo(c *gc.C) {
+	expected := params.APICertificateInfo{
+		Source:   "external",
+		DNSNames: []string{"api.example.com"},
+	}
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ControllerCertificates")
+		c.Check(request, gc.Equals, "CertificateInfo")
+		c.Check(arg, gc.IsNil)
+		*(result.(*params.APICertificateInfo)) = expected
+		return nil
+	})
+	result, err := controllercertificates.NewClient(apiCaller).CertificateInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, expected)
+}
+
+func (s *clientSuite) TestSetExternalCertificate(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ControllerCertificates")
+		c.Check(request, gc.Equals, "SetExternalCertificate")
+		c.Check(arg, jc.DeepEquals, params.SetExternalCertificate{
+			CertChain:  "cert-chain",
+			PrivateKey: "private-key",
+		})
+		return errors.New("kaboom")
+	})
+	err := controllercertificates.NewClient(apiCaller).SetExternalCertificate("cert-chain", "private-key")
+	c.Assert(err, gc.ErrorMatches, "kaboom")
+}
+
+func (s *clientSuite) TestSetACMEConfig(c *gc.C) {
+	config := params.ACMEConfig{
+		DirectoryURL: "https://acme.example.com/directory",
+		DNSNames:     []string{"api.example.com"},
+		DNSHook:      "/usr/local/bin/update-dns",
+	}
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ControllerCertificates")
+		c.Check(request, gc.Equals, "SetACMEConfig")
+		c.Check(arg, jc.DeepEquals, config)
+		return nil
+	})
+	err := controllercertificates.NewClient(apiCaller).SetACMEConfig(config)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *clientSuite) TestRemove(c *gc.C) {
+	var requests []string
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ControllerCertificates")
+		c.Check(arg, gc.IsNil)
+		requests = append(requests, request)
+		return nil
+	})
+	client := controllercertificates.NewClient(apiCaller)
+	c.Assert(client.RemoveExternalCertificate(), jc.ErrorIsNil)
+	c.Assert(client.RemoveACMEConfig(), jc.ErrorIsNil)
+	c.Assert(requests, jc.DeepEquals, []string{"RemoveExternalCertificate", "RemoveACMEConfig"})
+}
@@ -0,0 +1,35 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package controllerhealth provides a client for the ControllerHealth
+// facade, which reports the health of a controller as a whole.
+package controllerhealth
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the ControllerHealth facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new ControllerHealth client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "ControllerHealth")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// ControllerHealth returns a report of the health of the controller's
+// mongo replica set, controller machines and log collections.
+func (c *Client) ControllerHealth() (params.ControllerHealth, error) {
+	var result params.ControllerHealth
+	if err := c.facade.FacadeCall("ControllerHealth", nil, &result); err != nil {
+		return params.ControllerHealth{}, errors.Trace(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,47 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllerhealth_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/controllerhealth"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestControllerHealth(c *gc.C) {
+	expected := params.ControllerHealth{
+		Healthy:    true,
+		Machines:   []params.ControllerMachineHealth{{MachineId: "0", APIConnections: 3}},
+		LogsSizeMB: 12,
+	}
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ControllerHealth")
+		c.Check(request, gc.Equals, "ControllerHealth")
+		c.Check(arg, gc.IsNil)
+		*(result.(*params.ControllerHealth)) = expected
+		return nil
+	})
+	result, err := controllerhealth.NewClient(apiCaller).ControllerHealth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, expected)
+}
+
+func (s *clientSuite) TestControllerHealthError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("kaboom")
+	})
+	_, err := controllerhealth.NewClient(apiCaller).ControllerHealth()
+	c.Assert(err, gc.ErrorMatches, "kaboom")
+}
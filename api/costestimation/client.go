@@ -0,0 +1,40 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package costestimation provides a client for the CostEstimation
+// facade, which estimates the cost of the cloud resources used by a
+// model.
+package costestimation
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the CostEstimation facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new CostEstimation client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "CostEstimation")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// ModelCost returns the estimated cost of the model's machines,
+// storage and load balancers. Controllers without the CostEstimation
+// facade return an error satisfying errors.IsNotSupported.
+func (c *Client) ModelCost() (params.ModelCost, error) {
+	if c.BestAPIVersion() < 1 {
+		return params.ModelCost{}, errors.NotSupportedf("cost estimation")
+	}
+	var result params.ModelCost
+	if err := c.facade.FacadeCall("ModelCost", nil, &result); err != nil {
+		return params.ModelCost{}, errors.Trace(err)
+	}
+	return result, nil
+}
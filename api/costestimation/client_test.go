@@ -0,0 +1,69 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package costestimation_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/costestimation"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestModelCost(c *gc.C) {
+	expected := params.ModelCost{
+		Currency:    "USD",
+		HourlyCost:  0.5,
+		MonthlyCost: 365,
+		Resources: []params.ResourceCost{{
+			Kind:       "machine",
+			Id:         "0",
+			HourlyCost: 0.5,
+		}},
+	}
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "CostEstimation")
+			c.Check(request, gc.Equals, "ModelCost")
+			c.Check(arg, gc.IsNil)
+			*(result.(*params.ModelCost)) = expected
+			return nil
+		}),
+		BestVersion: 1,
+	}
+	result, err := costestimation.NewClient(apiCaller).ModelCost()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, expected)
+}
+
+func (s *clientSuite) TestModelCostError(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			return errors.New("boom")
+		}),
+		BestVersion: 1,
+	}
+	_, err := costestimation.NewClient(apiCaller).ModelCost()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *clientSuite) TestModelCostNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call")
+			return nil
+		}),
+	}
+	_, err := costestimation.NewClient(apiCaller).ModelCost()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
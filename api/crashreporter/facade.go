@@ -0,0 +1,45 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package crashreporter implements the client-side API facade used
+// by the crashreporter worker.
+package crashreporter
+
+import (
+	"time"
+
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Facade provides access to the CrashReporter API facade.
+type Facade struct {
+	caller base.FacadeCaller
+}
+
+// NewFacade creates a new client-side CrashReporter facade.
+func NewFacade(caller base.APICaller) *Facade {
+	return &Facade{
+		caller: base.NewFacadeCaller(caller, "CrashReporter"),
+	}
+}
+
+// Upload uploads a crash report bundle collected by the given agent
+// to the controller. If the bundle is too large to be stored, the
+// error satisfies params.IsCodeQuotaExceeded.
+func (f *Facade) Upload(agent names.Tag, crashTime time.Time, reason string, bundle []byte) error {
+	args := params.CrashReportUploads{Reports: []params.CrashReportUpload{{
+		Agent:  agent.String(),
+		Time:   crashTime,
+		Reason: reason,
+		Bundle: bundle,
+	}}}
+	var result params.ErrorResults
+	err := f.caller.FacadeCall("Upload", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
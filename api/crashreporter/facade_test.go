@@ -0,0 +1,93 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crashreporter_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/crashreporter"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type facadeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) TestUpload(c *gc.C) {
+	crashTime := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	stub := new(testing.Stub)
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "CrashReporter")
+		c.Check(id, gc.Equals, "")
+		stub.AddCall(request, args)
+		*response.(*params.ErrorResults) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		return nil
+	})
+	facade := crashreporter.NewFacade(apiCaller)
+
+	err := facade.Upload(names.NewUnitTag("app/0"), crashTime, "panic: boom", []byte("bundle"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	stub.CheckCalls(c, []testing.StubCall{{
+		"Upload", []interface{}{params.CrashReportUploads{
+			Reports: []params.CrashReportUpload{{
+				Agent:  "unit-app-0",
+				Time:   crashTime,
+				Reason: "panic: boom",
+				Bundle: []byte("bundle"),
+			}},
+		}},
+	}})
+}
+
+func (s *facadeSuite) TestCallError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		return errors.New("blam")
+	})
+	facade := crashreporter.NewFacade(apiCaller)
+
+	err := facade.Upload(names.NewMachineTag("0"), time.Time{}, "", []byte("bundle"))
+	c.Assert(err, gc.ErrorMatches, "blam")
+}
+
+func (s *facadeSuite) TestQuotaExceeded(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		*response.(*params.ErrorResults) = params.ErrorResults{
+			Results: []params.ErrorResult{{
+				Error: &params.Error{
+					Code:    params.CodeQuotaExceeded,
+					Message: "model quota exceeded",
+				},
+			}},
+		}
+		return nil
+	})
+	facade := crashreporter.NewFacade(apiCaller)
+
+	err := facade.Upload(names.NewMachineTag("0"), time.Time{}, "", []byte("bundle"))
+	c.Assert(err, jc.Satisfies, params.IsCodeQuotaExceeded)
+}
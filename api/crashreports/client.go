@@ -0,0 +1,59 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package crashreports provides a client for the CrashReports facade,
+// which lists and downloads the crash reports uploaded by a model's
+// agents.
+package crashreports
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the CrashReports facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new CrashReports client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "CrashReports")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// List returns the model's crash reports, oldest first.
+func (c *Client) List() ([]params.CrashReport, error) {
+	var result params.CrashReportsResult
+	if err := c.facade.FacadeCall("List", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Reports, nil
+}
+
+// Download returns the crash reports with the given ids along with
+// their bundles, in the same order as the ids.
+func (c *Client) Download(ids []string) ([]params.CrashReportBundleResult, error) {
+	args := params.CrashReportIds{Ids: ids}
+	var results params.CrashReportBundleResults
+	if err := c.facade.FacadeCall("Download", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(ids) {
+		return nil, errors.Errorf("expected %d results, got %d", len(ids), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// Remove removes the crash reports with the given ids.
+func (c *Client) Remove(ids []string) error {
+	args := params.CrashReportIds{Ids: ids}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("Remove", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.Combine()
+}
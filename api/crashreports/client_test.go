@@ -0,0 +1,98 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crashreports_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/crashreports"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+var report = params.CrashReport{
+	Id:       "1",
+	Agent:    "machine-0",
+	Time:     time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC),
+	Uploaded: time.Date(2018, 3, 1, 12, 1, 0, 0, time.UTC),
+	Reason:   "panic: boom",
+	Size:     6,
+}
+
+func (s *clientSuite) TestList(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CrashReports")
+		c.Check(request, gc.Equals, "List")
+		c.Check(arg, gc.IsNil)
+		*(result.(*params.CrashReportsResult)) = params.CrashReportsResult{
+			Reports: []params.CrashReport{report},
+		}
+		return nil
+	})
+	reports, err := crashreports.NewClient(apiCaller).List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reports, jc.DeepEquals, []params.CrashReport{report})
+}
+
+func (s *clientSuite) TestDownload(c *gc.C) {
+	expected := []params.CrashReportBundleResult{{
+		Report: report,
+		Bundle: []byte("bundle"),
+	}, {
+		Error: &params.Error{Code: params.CodeNotFound, Message: `crash report "2" not found`},
+	}}
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CrashReports")
+		c.Check(request, gc.Equals, "Download")
+		c.Check(arg, jc.DeepEquals, params.CrashReportIds{Ids: []string{"1", "2"}})
+		*(result.(*params.CrashReportBundleResults)) = params.CrashReportBundleResults{
+			Results: expected,
+		}
+		return nil
+	})
+	results, err := crashreports.NewClient(apiCaller).Download([]string{"1", "2"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expected)
+}
+
+func (s *clientSuite) TestDownloadWrongResultCount(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return nil
+	})
+	_, err := crashreports.NewClient(apiCaller).Download([]string{"1"})
+	c.Assert(err, gc.ErrorMatches, "expected 1 results, got 0")
+}
+
+func (s *clientSuite) TestRemove(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CrashReports")
+		c.Check(request, gc.Equals, "Remove")
+		c.Check(arg, jc.DeepEquals, params.CrashReportIds{Ids: []string{"1", "2"}})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}, {Error: &params.Error{Message: "boom"}}},
+		}
+		return nil
+	})
+	err := crashreports.NewClient(apiCaller).Remove([]string{"1", "2"})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *clientSuite) TestListError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("boom")
+	})
+	_, err := crashreports.NewClient(apiCaller).List()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,83 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package debughooks implements the API for debugging a unit's hooks
+// through the controller, rather than over SSH.
+package debughooks
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Session is a debug-hooks session for a unit.
+type Session interface {
+	// Next returns the next message from the session: the name of
+	// an intercepted hook as the unit starts it, output from the
+	// hook's shell, or the shell's exit code.
+	Next() (params.DebugHooksMessage, error)
+
+	// Send sends input to the shell of the intercepted hook.
+	Send(data []byte) error
+
+	// Close ends the session.
+	Close() error
+}
+
+// API provides access to the debug-hooks API.
+type API struct {
+	connector base.StreamConnector
+}
+
+// NewAPI creates a new client-side debug-hooks API.
+func NewAPI(connector base.StreamConnector) *API {
+	return &API{connector: connector}
+}
+
+// OpenSession opens a debug-hooks session for the named unit,
+// intercepting the given hooks or actions, or all of them if none are
+// given. The session must be closed when finished with.
+func (api *API) OpenSession(unitName string, hooks []string) (Session, error) {
+	if !names.IsValidUnit(unitName) {
+		return nil, errors.NotValidf("unit name %q", unitName)
+	}
+	path := "/units/" + names.NewUnitTag(unitName).String() + "/debug-hooks"
+	attrs := url.Values{}
+	if len(hooks) > 0 {
+		attrs.Set("hooks", strings.Join(hooks, ","))
+	}
+	conn, err := api.connector.ConnectStream(path, attrs)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot open debug-hooks session")
+	}
+	return &session{conn: conn}, nil
+}
+
+type session struct {
+	conn base.Stream
+}
+
+// Next is part of the Session interface.
+func (s *session) Next() (params.DebugHooksMessage, error) {
+	var m params.DebugHooksMessage
+	if err := s.conn.ReadJSON(&m); err != nil {
+		return params.DebugHooksMessage{}, errors.Trace(err)
+	}
+	return m, nil
+}
+
+// Send is part of the Session interface.
+func (s *session) Send(data []byte) error {
+	return errors.Trace(s.conn.WriteJSON(params.DebugHooksMessage{Data: data}))
+}
+
+// Close is part of the Session interface.
+func (s *session) Close() error {
+	return s.conn.Close()
+}
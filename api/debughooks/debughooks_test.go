@@ -0,0 +1,112 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package debughooks_test
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/debughooks"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type debugHooksSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&debugHooksSuite{})
+
+func (s *debugHooksSuite) TestOpenSession(c *gc.C) {
+	stream := &mockStream{
+		messages: []params.DebugHooksMessage{{Hook: "install"}},
+	}
+	connector := &mockConnector{stream: stream}
+	session, err := debughooks.NewAPI(connector).OpenSession("mysql/0", []string{"install", "start"})
+	c.Assert(err, jc.ErrorIsNil)
+	connector.CheckCalls(c, []testing.StubCall{{
+		"ConnectStream", []interface{}{
+			"/units/unit-mysql-0/debug-hooks",
+			url.Values{"hooks": {"install,start"}},
+		},
+	}})
+
+	m, err := session.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m, jc.DeepEquals, params.DebugHooksMessage{Hook: "install"})
+
+	err = session.Send([]byte("ls\n"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stream.written, jc.DeepEquals, []interface{}{
+		params.DebugHooksMessage{Data: []byte("ls\n")},
+	})
+
+	err = session.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stream.closed, jc.IsTrue)
+}
+
+func (s *debugHooksSuite) TestOpenSessionAllHooks(c *gc.C) {
+	connector := &mockConnector{stream: &mockStream{}}
+	_, err := debughooks.NewAPI(connector).OpenSession("mysql/0", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	connector.CheckCall(c, 0, "ConnectStream", "/units/unit-mysql-0/debug-hooks", url.Values{})
+}
+
+func (s *debugHooksSuite) TestOpenSessionInvalidUnit(c *gc.C) {
+	connector := &mockConnector{}
+	_, err := debughooks.NewAPI(connector).OpenSession("mysql", nil)
+	c.Assert(err, gc.ErrorMatches, `unit name "mysql" not valid`)
+	connector.CheckNoCalls(c)
+}
+
+func (s *debugHooksSuite) TestOpenSessionError(c *gc.C) {
+	connector := &mockConnector{}
+	connector.SetErrors(errors.New("boom"))
+	_, err := debughooks.NewAPI(connector).OpenSession("mysql/0", nil)
+	c.Assert(err, gc.ErrorMatches, "cannot open debug-hooks session: boom")
+}
+
+type mockConnector struct {
+	testing.Stub
+	stream base.Stream
+}
+
+func (m *mockConnector) ConnectStream(path string, attrs url.Values) (base.Stream, error) {
+	m.MethodCall(m, "ConnectStream", path, attrs)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.stream, nil
+}
+
+type mockStream struct {
+	base.Stream
+	messages []params.DebugHooksMessage
+	written  []interface{}
+	closed   bool
+}
+
+func (m *mockStream) ReadJSON(v interface{}) error {
+	if len(m.messages) == 0 {
+		return errors.New("no messages")
+	}
+	*(v.(*params.DebugHooksMessage)) = m.messages[0]
+	m.messages = m.messages[1:]
+	return nil
+}
+
+func (m *mockStream) WriteJSON(v interface{}) error {
+	m.written = append(m.written, v)
+	return nil
+}
+
+func (m *mockStream) Close() error {
+	m.closed = true
+	return nil
+}
@@ -4,11 +4,13 @@
 package deployer
 
 import (
+	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
 )
 
 const deployerFacade = "Deployer"
@@ -44,6 +46,21 @@ func (st *State) Unit(tag names.UnitTag) (*Unit, error) {
 	}, nil
 }
 
+// UnitFromChange returns the unit described by the given change,
+// reported by a Machine's WatchUnitChanges watcher, without reading
+// the unit's life from the controller.
+func (st *State) UnitFromChange(change watcher.EntityChange) (*Unit, error) {
+	tag, ok := change.Tag.(names.UnitTag)
+	if !ok {
+		return nil, errors.Errorf("expected names.UnitTag, got %T", change.Tag)
+	}
+	return &Unit{
+		tag:  tag,
+		life: params.Life(change.Life),
+		st:   st,
+	}, nil
+}
+
 // Machine returns the machine with the given tag.
 func (st *State) Machine(tag names.MachineTag) (*Machine, error) {
 	// TODO(dfc) this cannot return an error any more
@@ -4,21 +4,28 @@
 package deployer_test
 
 import (
+	"sort"
 	stdtesting "testing"
+	"time"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
+	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/deployer"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
 	coretesting "github.com/juju/juju/testing"
+	corewatcher "github.com/juju/juju/watcher"
 	"github.com/juju/juju/watcher/watchertest"
+	"github.com/juju/juju/worker/workertest"
 )
 
 func TestAll(t *stdtesting.T) {
@@ -125,6 +132,58 @@ func (s *deployerSuite) TestWatchUnits(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *deployerSuite) TestWatchUnitChanges(c *gc.C) {
+	machine, err := s.st.Machine(s.machine.Tag().(names.MachineTag))
+	c.Assert(err, jc.ErrorIsNil)
+	w, err := machine.WatchUnitChanges()
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+	assertChange := func(expect ...corewatcher.EntityChange) {
+		s.BackingState.StartSync()
+		select {
+		case changes, ok := <-w.Changes():
+			c.Assert(ok, jc.IsTrue)
+			sort.Slice(changes, func(i, j int) bool {
+				return changes[i].Tag.String() < changes[j].Tag.String()
+			})
+			c.Assert(changes, jc.DeepEquals, expect)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("watcher did not send change")
+		}
+	}
+
+	// Initial event.
+	assertChange(
+		corewatcher.EntityChange{Tag: s.subordinate.Tag(), Life: life.Alive},
+		corewatcher.EntityChange{Tag: s.principal.Tag(), Life: life.Alive},
+	)
+
+	// Make the subordinate dead and check it's detected, along with
+	// its life.
+	err = s.subordinate.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	assertChange(corewatcher.EntityChange{Tag: s.subordinate.Tag(), Life: life.Dead})
+
+	unit, err := s.st.UnitFromChange(corewatcher.EntityChange{Tag: s.subordinate.Tag(), Life: life.Dead})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit.Name(), gc.Equals, "logging/0")
+	c.Assert(unit.Life(), gc.Equals, params.Dead)
+}
+
+func (s *deployerSuite) TestWatchUnitChangesNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %s.%s", objType, request)
+			return nil
+		},
+		BestVersion: 1,
+	}
+	machine, err := deployer.NewState(apiCaller).Machine(names.NewMachineTag("0"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = machine.WatchUnitChanges()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *deployerSuite) TestUnit(c *gc.C) {
 	// Try getting a missing unit and an invalid tag.
 	unit, err := s.st.Unit(names.NewUnitTag("foo/42"))
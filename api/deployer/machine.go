@@ -6,6 +6,7 @@ package deployer
 import (
 	"fmt"
 
+	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
 	apiwatcher "github.com/juju/juju/api/watcher"
@@ -41,3 +42,31 @@ func (m *Machine) WatchUnits() (watcher.StringsWatcher, error) {
 	w := apiwatcher.NewStringsWatcher(m.st.facade.RawAPICaller(), result)
 	return w, nil
 }
+
+// WatchUnitChanges starts an EntityChangesWatcher to watch all units
+// deployed to the machine. Unlike WatchUnits, each change reports the
+// unit's life, so the unit need not be read again to decide whether it
+// should be deployed or recalled. If the controller does not support
+// it, an error satisfying errors.IsNotSupported is returned.
+func (m *Machine) WatchUnitChanges() (watcher.EntityChangesWatcher, error) {
+	if m.st.facade.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("watching unit changes")
+	}
+	var results params.EntityChangesWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: m.tag.String()}},
+	}
+	err := m.st.facade.FacadeCall("WatchUnitChanges", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewEntityChangesWatcher(m.st.facade.RawAPICaller(), result)
+	return w, nil
+}
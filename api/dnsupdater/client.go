@@ -0,0 +1,93 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package dnsupdater provides the client side of the API facade used by
+// the DNS updater worker.
+package dnsupdater
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/watcher"
+)
+
+const dnsUpdaterFacade = "DNSUpdater"
+
+// Machine holds the details of a machine needed to register it in the
+// provider's DNS.
+type Machine struct {
+	Tag        names.MachineTag
+	Life       params.Life
+	InstanceId instance.Id
+	Addresses  []network.Address
+	FQDN       string
+}
+
+// Client provides access to the DNS updater API facade.
+type Client struct {
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the DNS updater API.
+func NewClient(caller base.APICaller) *Client {
+	return &Client{facade: base.NewFacadeCaller(caller, dnsUpdaterFacade)}
+}
+
+// WatchModelMachines returns a StringsWatcher that notifies of changes
+// to the life cycles of the model's top-level machines.
+func (c *Client) WatchModelMachines() (watcher.StringsWatcher, error) {
+	var result params.StringsWatchResult
+	if err := c.facade.FacadeCall("WatchModelMachines", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), result), nil
+}
+
+// Machines returns the details of the model's top-level machines.
+func (c *Client) Machines() ([]Machine, error) {
+	var result params.DNSMachinesResult
+	if err := c.facade.FacadeCall("Machines", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	machines := make([]Machine, len(result.Machines))
+	for i, m := range result.Machines {
+		tag, err := names.ParseMachineTag(m.Tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		machines[i] = Machine{
+			Tag:        tag,
+			Life:       m.Life,
+			InstanceId: instance.Id(m.InstanceId),
+			Addresses:  params.NetworkAddresses(m.Addresses...),
+			FQDN:       m.FQDN,
+		}
+	}
+	return machines, nil
+}
+
+// SetFQDN records the fully qualified domain name registered for the
+// machine in the provider's DNS. An empty name records that there is
+// none.
+func (c *Client) SetFQDN(tag names.MachineTag, fqdn string) error {
+	args := params.SetMachineFQDNs{
+		Machines: []params.MachineFQDN{{Tag: tag.String(), FQDN: fqdn}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetFQDNs", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
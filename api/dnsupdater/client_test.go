@@ -0,0 +1,85 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dnsupdater_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/dnsupdater"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestMachines(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "DNSUpdater")
+		c.Check(request, gc.Equals, "Machines")
+		*(result.(*params.DNSMachinesResult)) = params.DNSMachinesResult{
+			Machines: []params.DNSMachine{{
+				Tag:        "machine-0",
+				Life:       params.Alive,
+				InstanceId: "i-0",
+				Addresses:  []params.Address{{Value: "203.0.113.1", Type: "ipv4", Scope: "public"}},
+				FQDN:       "machine-0.example.com",
+			}},
+		}
+		return nil
+	})
+	machines, err := dnsupdater.NewClient(apiCaller).Machines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, jc.DeepEquals, []dnsupdater.Machine{{
+		Tag:        names.NewMachineTag("0"),
+		Life:       params.Alive,
+		InstanceId: "i-0",
+		Addresses:  []network.Address{network.NewScopedAddress("203.0.113.1", network.ScopePublic)},
+		FQDN:       "machine-0.example.com",
+	}})
+}
+
+func (s *clientSuite) TestMachinesError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("kaboom")
+	})
+	_, err := dnsupdater.NewClient(apiCaller).Machines()
+	c.Assert(err, gc.ErrorMatches, "kaboom")
+}
+
+func (s *clientSuite) TestSetFQDN(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "SetFQDNs")
+		c.Check(arg, jc.DeepEquals, params.SetMachineFQDNs{
+			Machines: []params.MachineFQDN{{Tag: "machine-1", FQDN: "machine-1.example.com"}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "machine 1 not found", Code: params.CodeNotFound}}},
+		}
+		return nil
+	})
+	err := dnsupdater.NewClient(apiCaller).SetFQDN(names.NewMachineTag("1"), "machine-1.example.com")
+	c.Assert(err, gc.ErrorMatches, "machine 1 not found")
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *clientSuite) TestWatchModelMachinesError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "WatchModelMachines")
+		*(result.(*params.StringsWatchResult)) = params.StringsWatchResult{
+			Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+		}
+		return nil
+	})
+	_, err := dnsupdater.NewClient(apiCaller).WatchModelMachines()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
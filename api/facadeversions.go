@@ -18,33 +18,45 @@ var facadeVersions = map[string]int{
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
-	"Application":                  5,
+	"Application":                  15,
 	"ApplicationOffers":            1,
 	"ApplicationScaler":            1,
 	"Backups":                      1,
-	"Block":                        2,
+	"Block":                        3,
 	"Bundle":                       1,
 	"CAASFirewaller":               1,
 	"CAASOperator":                 1,
 	"CAASOperatorProvisioner":      1,
 	"CAASUnitProvisioner":          1,
+	"CharmAutoRefresh":             1,
 	"CharmRevisionUpdater":         2,
+	"CharmUpgrades":                1,
 	"Charms":                       2,
 	"Cleaner":                      2,
-	"Client":                       1,
+	"Client":                       2,
 	"Cloud":                        2,
-	"Controller":                   4,
+	"ContainerImageCache":          1,
+	"Controller":                   9,
+	"ControllerCertificates":       1,
+	"ControllerHealth":             1,
+	"CostEstimation":               1,
+	"CrashReporter":                1,
+	"CrashReports":                 1,
 	"CrossController":              1,
 	"CrossModelRelations":          1,
-	"Deployer":                     1,
+	"Deployer":                     2,
 	"DiskManager":                  2,
+	"DNSUpdater":                   1,
+	"EntityChangesWatcher":         1,
 	"EntityWatcher":                2,
 	"ExternalControllerUpdater":    1,
-	"FanConfigurer":                1,
+	"FanConfig":                    1,
+	"FanConfigurer":                2,
 	"FilesystemAttachmentsWatcher": 2,
-	"Firewaller":                   4,
-	"FirewallRules":                1,
-	"HighAvailability":             2,
+	"Firewaller":                   6,
+	"FirewallRules":                2,
+	"HighAvailability":             3,
+	"HostFirewaller":               1,
 	"HostKeyReporter":              1,
 	"ImageManager":                 2,
 	"ImageMetadata":                3,
@@ -52,33 +64,46 @@ var facadeVersions = map[string]int{
 	"InstancePoller":               3,
 	"KeyManager":                   1,
 	"KeyUpdater":                   1,
+	"LBManager":                    1,
 	"LeadershipService":            2,
 	"LifeFlag":                     1,
+	"LoadTest":                     1,
 	"LogForwarding":                1,
-	"Logger":                       1,
+	"Logger":                       2,
 	"MachineActions":               1,
-	"MachineManager":               4,
+	"MachineManager":               6,
 	"MachineUndertaker":            1,
-	"Machiner":                     1,
+	"Machiner":                     2,
+	"Maintenance":                  3,
 	"MeterStatus":                  1,
 	"MetricsAdder":                 2,
 	"MetricsDebug":                 2,
 	"MetricsManager":               1,
 	"MigrationFlag":                1,
-	"MigrationMaster":              1,
+	"MigrationMaster":              2,
 	"MigrationMinion":              1,
 	"MigrationStatusWatcher":       1,
-	"MigrationTarget":              1,
-	"ModelConfig":                  1,
-	"ModelManager":                 4,
+	"MigrationTarget":              2,
+	"ModelApply":                   1,
+	"ModelConfig":                  3,
+	"ModelEvents":                  1,
+	"ModelGeneration":              1,
+	"ModelManager":                 6,
+	"ModelSnapshots":               1,
+	"ModelSnapshotter":             1,
 	"ModelUpgrader":                1,
+	"ModelVisualization":           1,
 	"NotifyWatcher":                1,
 	"OfferStatusWatcher":           1,
+	"OrphanSweeper":                1,
+	"OrphanedResources":            1,
 	"Payloads":                     1,
 	"PayloadsHookContext":          1,
 	"Pinger":                       1,
-	"Provisioner":                  5,
-	"ProxyUpdater":                 1,
+	"Provisioner":                  6,
+	"ProxyUpdater":                 2,
+	"PubSubTopology":               1,
+	"Quotas":                       1,
 	"Reboot":                       2,
 	"RelationStatusWatcher":        1,
 	"RelationUnitsWatcher":         1,
@@ -91,15 +116,18 @@ var facadeVersions = map[string]int{
 	"Spaces":                       3,
 	"SSHClient":                    2,
 	"StatusHistory":                2,
-	"Storage":                      4,
+	"Storage":                      5,
 	"StorageProvisioner":           4,
 	"StringsWatcher":               1,
 	"Subnets":                      2,
+	"TrustStore":                   1,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       7,
+	"Uniter":                       12,
 	"Upgrader":                     1,
-	"UserManager":                  2,
+	"UserManager":                  3,
+	"Utilization":                  1,
+	"UtilizationReporter":          1,
 	"VolumeAttachmentsWatcher":     2,
 }
 
@@ -21,7 +21,7 @@ type facadeVersionSuite struct {
 var _ = gc.Suite(&facadeVersionSuite{})
 
 func (s *facadeVersionSuite) SetUpTest(c *gc.C) {
-	s.SetInitialFeatureFlags(feature.ImageMetadata, feature.CAAS)
+	s.SetInitialFeatureFlags(feature.CAAS, feature.LoadTesting)
 	s.BaseSuite.SetUpTest(c)
 }
 
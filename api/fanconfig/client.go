@@ -0,0 +1,48 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package fanconfig provides a client for the FanConfig facade, which
+// configures the fan overlays that give a model's containers routable
+// addresses across hosts.
+package fanconfig
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the FanConfig facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new FanConfig client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "FanConfig")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// FanConfig returns the fans configured for the model, with the
+// segment of each fan's overlay given to each of the model's subnets
+// on its underlay.
+func (c *Client) FanConfig() (params.FanOverlaysResult, error) {
+	var result params.FanOverlaysResult
+	if err := c.facade.FacadeCall("FanConfig", nil, &result); err != nil {
+		return params.FanOverlaysResult{}, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// SetFanConfig replaces the fans configured for the model. If
+// useForContainers is true, new containers get their addresses from
+// the fans' overlays.
+func (c *Client) SetFanConfig(fans []params.FanConfigEntry, useForContainers bool) error {
+	args := params.SetFanConfig{
+		Fans:             fans,
+		UseForContainers: useForContainers,
+	}
+	return errors.Trace(c.facade.FacadeCall("SetFanConfig", args, nil))
+}
@@ -0,0 +1,60 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package fanconfig_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/fanconfig"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestFanConfig(c *gc.C) {
+	expected := params.FanOverlaysResult{
+		Overlays: []params.FanOverlay{{
+			Underlay: "172.31.0.0/16",
+			Overlay:  "253.0.0.0/8",
+			Subnets: []params.FanOverlaySubnet{{
+				CIDR:           "172.31.64.0/20",
+				OverlaySegment: "253.64.0.0/12",
+			}},
+		}},
+		ContainerNetworkingMethod: "fan",
+	}
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "FanConfig")
+		c.Check(request, gc.Equals, "FanConfig")
+		c.Check(arg, gc.IsNil)
+		*(result.(*params.FanOverlaysResult)) = expected
+		return nil
+	})
+	result, err := fanconfig.NewClient(apiCaller).FanConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, expected)
+}
+
+func (s *clientSuite) TestSetFanConfig(c *gc.C) {
+	fans := []params.FanConfigEntry{{Underlay: "172.31.0.0/16", Overlay: "253.0.0.0/8"}}
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "FanConfig")
+		c.Check(request, gc.Equals, "SetFanConfig")
+		c.Check(arg, jc.DeepEquals, params.SetFanConfig{
+			Fans:             fans,
+			UseForContainers: true,
+		})
+		return errors.New("boom")
+	})
+	err := fanconfig.NewClient(apiCaller).SetFanConfig(fans, true)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
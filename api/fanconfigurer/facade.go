@@ -4,6 +4,8 @@
 package fanconfigurer
 
 import (
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/api/base"
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/common/networkingcommon"
@@ -44,3 +46,14 @@ func (f *Facade) FanConfig() (network.FanConfig, error) {
 	}
 	return networkingcommon.FanConfigResultToFanConfig(result)
 }
+
+// SetFanStatus records the health of the model's fan overlays on the
+// machine. It returns an error satisfying errors.IsNotSupported if the
+// controller cannot record it.
+func (f *Facade) SetFanStatus(overlays []params.FanOverlayStatus) error {
+	if f.caller.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("recording fan status")
+	}
+	args := params.SetFanStatus{Overlays: overlays}
+	return f.caller.FacadeCall("SetFanStatus", args, nil)
+}
@@ -70,6 +70,21 @@ func (c *Client) Unit(tag names.UnitTag) (*Unit, error) {
 	}, nil
 }
 
+// UnitFromChange returns the unit described by the given change,
+// reported by a Machine's WatchUnitChanges watcher, without reading
+// the unit's life from the controller.
+func (c *Client) UnitFromChange(change watcher.EntityChange) (*Unit, error) {
+	tag, ok := change.Tag.(names.UnitTag)
+	if !ok {
+		return nil, errors.Errorf("expected names.UnitTag, got %T", change.Tag)
+	}
+	return &Unit{
+		tag:  tag,
+		life: params.Life(change.Life),
+		st:   c,
+	}, nil
+}
+
 // Machine provides access to methods of a state.Machine through the
 // facade.
 func (c *Client) Machine(tag names.MachineTag) (*Machine, error) {
@@ -100,6 +115,27 @@ func (c *Client) WatchModelMachines() (watcher.StringsWatcher, error) {
 	return w, nil
 }
 
+// WatchModelMachineChanges returns an EntityChangesWatcher that
+// notifies of changes to the life cycles of the top level machines in
+// the current model. Unlike WatchModelMachines, each change reports
+// the machine's life. If the controller does not support it, an error
+// satisfying errors.IsNotSupported is returned.
+func (c *Client) WatchModelMachineChanges() (watcher.EntityChangesWatcher, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("watching model machine changes")
+	}
+	var result params.EntityChangesWatchResult
+	err := c.facade.FacadeCall("WatchModelMachineChanges", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	if err := result.Error; err != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewEntityChangesWatcher(c.facade.RawAPICaller(), result)
+	return w, nil
+}
+
 // WatchOpenedPorts returns a StringsWatcher that notifies of
 // changes to the opened ports for the current model.
 func (c *Client) WatchOpenedPorts() (watcher.StringsWatcher, error) {
@@ -255,3 +291,15 @@ func (c *Client) FirewallRules(knownServices ...string) ([]params.FirewallRule,
 	}
 	return results.Rules, nil
 }
+
+// SetFirewallReports records the firewaller's comparison of the
+// ingress rules it wants applied with those the provider has applied,
+// replacing the reports previously recorded for the model.
+func (c *Client) SetFirewallReports(reports []params.FirewallReport) error {
+	if c.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("SetFirewallReports")
+	}
+	args := params.FirewallReports{Reports: reports}
+	err := c.facade.FacadeCall("SetFirewallReports", args, nil)
+	return errors.Trace(err)
+}
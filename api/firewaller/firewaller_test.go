@@ -4,6 +4,7 @@
 package firewaller_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
@@ -246,3 +247,74 @@ func (s *firewallerSuite) TestFirewallRules(c *gc.C) {
 	c.Assert(result, gc.HasLen, 1)
 	c.Check(callCount, gc.Equals, 1)
 }
+
+func (s *firewallerSuite) TestSetFirewallReports(c *gc.C) {
+	reports := []params.FirewallReport{{
+		MachineTag: "machine-0",
+		Error:      "instance not found",
+	}}
+	var callCount int
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Firewaller")
+			c.Check(version, gc.Equals, 5)
+			c.Check(request, gc.Equals, "SetFirewallReports")
+			c.Check(arg, jc.DeepEquals, params.FirewallReports{Reports: reports})
+			callCount++
+			return nil
+		}),
+		BestVersion: 5,
+	}
+	client, err := firewaller.NewClient(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	err = client.SetFirewallReports(reports)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+}
+
+func (s *firewallerSuite) TestSetFirewallReportsNotSupported(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		}),
+		BestVersion: 4,
+	}
+	client, err := firewaller.NewClient(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	err = client.SetFirewallReports(nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *firewallerSuite) TestWatchModelMachineChangesNotSupported(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		}),
+		BestVersion: 5,
+	}
+	client, err := firewaller.NewClient(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = client.WatchModelMachineChanges()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *firewallerSuite) TestWatchUnitChangesNotSupported(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(request, gc.Equals, "Life")
+			*(result.(*params.LifeResults)) = params.LifeResults{
+				Results: []params.LifeResult{{Life: params.Alive}},
+			}
+			return nil
+		}),
+		BestVersion: 5,
+	}
+	client, err := firewaller.NewClient(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := client.Machine(names.NewMachineTag("0"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = machine.WatchUnitChanges()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
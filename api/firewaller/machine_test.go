@@ -10,9 +10,11 @@ import (
 
 	"github.com/juju/juju/api/firewaller"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/watcher/watchertest"
 )
 
@@ -94,6 +96,29 @@ func (s *machineSuite) TestWatchUnits(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *machineSuite) TestWatchUnitChanges(c *gc.C) {
+	w, err := s.apiMachine.WatchUnitChanges()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewEntityChangesWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertChange(watcher.EntityChange{Tag: s.units[0].Tag(), Life: life.Alive})
+	wc.AssertNoChange()
+
+	// Make the unit dead and check its life is reported.
+	err = s.units[0].EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(watcher.EntityChange{Tag: s.units[0].Tag(), Life: life.Dead})
+	wc.AssertNoChange()
+
+	// Remove the unit and check it's reported as removed.
+	err = s.units[0].Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(watcher.EntityChange{Tag: s.units[0].Tag(), Life: life.Dead, Removed: true})
+	wc.AssertNoChange()
+}
+
 func (s *machineSuite) TestActiveSubnets(c *gc.C) {
 	// No ports opened at first, no active subnets.
 	subnets, err := s.apiMachine.ActiveSubnets()
@@ -8,8 +8,10 @@ import (
 	gc "gopkg.in/check.v1"
 
 	apitesting "github.com/juju/juju/api/testing"
+	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/watcher/watchertest"
 )
 
@@ -58,6 +60,40 @@ func (s *stateSuite) TestWatchModelMachines(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *stateSuite) TestWatchModelMachineChanges(c *gc.C) {
+	w, err := s.firewaller.WatchModelMachineChanges()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewEntityChangesWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertChange(
+		watcher.EntityChange{Tag: s.machines[0].Tag(), Life: life.Alive},
+		watcher.EntityChange{Tag: s.machines[1].Tag(), Life: life.Alive},
+		watcher.EntityChange{Tag: s.machines[2].Tag(), Life: life.Alive},
+	)
+
+	// Add another machine make sure it is detected.
+	otherMachine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(watcher.EntityChange{Tag: otherMachine.Tag(), Life: life.Alive})
+
+	// Change the life cycle of last machine and make sure the new
+	// life is reported.
+	err = otherMachine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(watcher.EntityChange{Tag: otherMachine.Tag(), Life: life.Dead})
+
+	// Add a container and make sure it's not detected.
+	template := state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}
+	_, err = s.State.AddMachineInsideMachine(template, s.machines[0].Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
 func (s *stateSuite) TestWatchOpenedPorts(c *gc.C) {
 	// Open some ports.
 	err := s.units[0].OpenPorts("tcp", 1234, 1400)
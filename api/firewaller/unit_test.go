@@ -10,6 +10,8 @@ import (
 
 	"github.com/juju/juju/api/firewaller"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/watcher"
 )
 
 type unitSuite struct {
@@ -45,6 +47,22 @@ func (s *unitSuite) TestUnit(c *gc.C) {
 	c.Assert(apiUnit0.Tag(), gc.Equals, names.NewUnitTag(s.units[0].Name()))
 }
 
+func (s *unitSuite) TestUnitFromChange(c *gc.C) {
+	unit, err := s.firewaller.UnitFromChange(watcher.EntityChange{
+		Tag:  s.units[0].Tag(),
+		Life: life.Dying,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit.Tag(), gc.Equals, s.units[0].Tag())
+	c.Assert(unit.Life(), gc.Equals, params.Dying)
+
+	_, err = s.firewaller.UnitFromChange(watcher.EntityChange{
+		Tag:  s.machines[0].Tag(),
+		Life: life.Alive,
+	})
+	c.Assert(err, gc.ErrorMatches, "expected names.UnitTag, got names.MachineTag")
+}
+
 func (s *unitSuite) TestRefresh(c *gc.C) {
 	c.Assert(s.apiUnit.Life(), gc.Equals, params.Alive)
 
@@ -5,6 +5,7 @@ package firewallrules
 
 import (
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
@@ -52,3 +53,25 @@ func (c *Client) ListFirewallRules() ([]params.FirewallRule, error) {
 	}
 	return results.Rules, nil
 }
+
+// FirewallReport returns, for each of the named applications or for all
+// the model's applications if none are named, whether the application
+// is exposed and whether the provider allows ingress to each port its
+// units have opened.
+func (c *Client) FirewallReport(applications ...string) (params.FirewallReportResult, error) {
+	var result params.FirewallReportResult
+	if c.BestAPIVersion() < 2 {
+		return result, errors.NotSupportedf("FirewallReport on this version of Juju")
+	}
+	args := params.Entities{Entities: make([]params.Entity, len(applications))}
+	for i, name := range applications {
+		if !names.IsValidApplication(name) {
+			return result, errors.NotValidf("application name %q", name)
+		}
+		args.Entities[i].Tag = names.NewApplicationTag(name).String()
+	}
+	if err := c.facade.FacadeCall("FirewallReport", args, &result); err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
@@ -141,3 +141,69 @@ func (s *FirewallRulesSuite) TestListError(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.ErrorMatches, "fail")
 	c.Assert(called, jc.IsTrue)
 }
+
+func (s *FirewallRulesSuite) TestFirewallReport(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "FirewallRules")
+				c.Check(version, gc.Equals, 2)
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "FirewallReport")
+				c.Check(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{Tag: "application-wordpress"}},
+				})
+				*(result.(*params.FirewallReportResult)) = params.FirewallReportResult{
+					FirewallMode: "instance",
+					Applications: []params.ApplicationFirewallState{{
+						Name:    "wordpress",
+						Exposed: true,
+					}},
+				}
+				return nil
+			}),
+		BestVersion: 2,
+	}
+	client := firewallrules.NewClient(apiCaller)
+	result, err := client.FirewallReport("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.FirewallReportResult{
+		FirewallMode: "instance",
+		Applications: []params.ApplicationFirewallState{{
+			Name:    "wordpress",
+			Exposed: true,
+		}},
+	})
+}
+
+func (s *FirewallRulesSuite) TestFirewallReportInvalidName(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(string, int, string, string, interface{}, interface{}) error {
+				c.Fail()
+				return nil
+			}),
+		BestVersion: 2,
+	}
+	client := firewallrules.NewClient(apiCaller)
+	_, err := client.FirewallReport("Word_Press")
+	c.Assert(err, gc.ErrorMatches, `application name "Word_Press" not valid`)
+}
+
+func (s *FirewallRulesSuite) TestFirewallReportNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(string, int, string, string, interface{}, interface{}) error {
+				c.Fail()
+				return nil
+			}),
+		BestVersion: 1,
+	}
+	client := firewallrules.NewClient(apiCaller)
+	_, err := client.FirewallReport()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -9,9 +9,11 @@ import (
 	"github.com/juju/replicaset"
 
 	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/watcher"
 )
 
 var logger = loggo.GetLogger("juju.api.highavailability")
@@ -33,14 +35,35 @@ func (c *Client) EnableHA(
 	numControllers int, cons constraints.Value, placement []string,
 ) (params.ControllersChanges, error) {
 
+	return c.enableHA(params.ControllersSpec{
+		NumControllers: numControllers,
+		Constraints:    cons,
+		Placement:      placement,
+	})
+}
+
+// EnableHAWithMachines ensures the availability of Juju controllers,
+// starting any new controller machines according to the placement,
+// constraints or availability zone given for each in turn. Any further
+// new machines are started with the given constraints.
+func (c *Client) EnableHAWithMachines(
+	numControllers int, cons constraints.Value, machines []params.ControllerMachineSpec,
+) (params.ControllersChanges, error) {
+	if c.BestAPIVersion() < 3 {
+		return params.ControllersChanges{}, errors.NotSupportedf("EnableHAWithMachines")
+	}
+	return c.enableHA(params.ControllersSpec{
+		NumControllers: numControllers,
+		Constraints:    cons,
+		Machines:       machines,
+	})
+}
+
+func (c *Client) enableHA(spec params.ControllersSpec) (params.ControllersChanges, error) {
 	var results params.ControllersChangeResults
 	arg := params.ControllersSpecs{
-		Specs: []params.ControllersSpec{{
-			NumControllers: numControllers,
-			Constraints:    cons,
-			Placement:      placement,
-		}}}
-
+		Specs: []params.ControllersSpec{spec},
+	}
 	err := c.facade.FacadeCall("EnableHA", arg, &results)
 	if err != nil {
 		return params.ControllersChanges{}, err
@@ -55,6 +78,36 @@ func (c *Client) EnableHA(
 	return result.Result, nil
 }
 
+// ControllersProgress returns how far each controller machine has got
+// in being provisioned, joining the mongo replica set and gaining its
+// vote.
+func (c *Client) ControllersProgress() (params.ControllersProgress, error) {
+	if c.BestAPIVersion() < 3 {
+		return params.ControllersProgress{}, errors.NotSupportedf("ControllersProgress")
+	}
+	var result params.ControllersProgress
+	if err := c.facade.FacadeCall("ControllersProgress", nil, &result); err != nil {
+		return params.ControllersProgress{}, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// WatchControllersProgress returns a watcher that notifies whenever the
+// progress returned by ControllersProgress changes.
+func (c *Client) WatchControllersProgress() (watcher.NotifyWatcher, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("WatchControllersProgress")
+	}
+	var result params.NotifyWatchResult
+	if err := c.facade.FacadeCall("WatchControllersProgress", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), result), nil
+}
+
 // MongoUpgradeMode will make all Slave members of the HA
 // to shut down their mongo server.
 func (c *Client) MongoUpgradeMode(v mongo.Version) (params.MongoUpgradeResults, error) {
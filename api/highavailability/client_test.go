@@ -6,10 +6,13 @@ package highavailability_test
 import (
 	stdtesting "testing"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/highavailability"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/constraints"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
@@ -77,5 +80,56 @@ func (s *clientSuite) TestClientEnableHA(c *gc.C) {
 
 func (s *clientSuite) TestClientEnableHAVersion(c *gc.C) {
 	client := highavailability.NewClient(s.APIState)
-	c.Assert(client.BestAPIVersion(), gc.Equals, 2)
+	c.Assert(client.BestAPIVersion(), gc.Equals, 3)
+}
+
+func (s *clientSuite) TestClientEnableHAWithMachines(c *gc.C) {
+	_, err := s.State.AddMachine("quantal", state.JobManageModel)
+	c.Assert(err, jc.ErrorIsNil)
+	pinger := setAgentPresence(c, &s.JujuConnSuite, "0")
+	defer assertKill(c, pinger)
+
+	client := highavailability.NewClient(s.APIState)
+	result, err := client.EnableHAWithMachines(3, constraints.Value{}, []params.ControllerMachineSpec{{
+		Zone:        "zone1",
+		Constraints: constraints.MustParse("mem=8G"),
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Added, gc.DeepEquals, []string{"machine-1", "machine-2"})
+
+	m, err := s.State.Machine("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.Placement(), gc.Equals, "zone=zone1")
+	cons, err := m.Constraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cons, gc.DeepEquals, constraints.MustParse("mem=8G"))
+}
+
+func (s *clientSuite) TestClientControllersProgress(c *gc.C) {
+	_, err := s.State.AddMachine("quantal", state.JobManageModel)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := highavailability.NewClient(s.APIState)
+	progress, err := client.ControllersProgress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(progress.Machines, gc.HasLen, 1)
+	c.Assert(progress.Machines[0].Tag, gc.Equals, "machine-0")
+	c.Assert(progress.Machines[0].Phase, gc.Equals, "provisioning")
+}
+
+func (s *clientSuite) TestClientProgressNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := highavailability.NewClient(apiCaller)
+	_, err := client.ControllersProgress()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = client.WatchControllersProgress()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = client.EnableHAWithMachines(3, constraints.Value{}, nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
 }
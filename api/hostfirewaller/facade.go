@@ -0,0 +1,94 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package hostfirewaller implements the client-side API facade used
+// by the hostfirewaller worker.
+package hostfirewaller
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/egress"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/watcher"
+)
+
+// Policy holds the policy restricting a machine's outbound traffic.
+type Policy struct {
+	// Restricted reports whether outbound traffic is restricted. If
+	// false, the other fields are empty.
+	Restricted bool
+
+	// Rules holds the destinations that the charms of the machine's
+	// units require.
+	Rules []egress.Rule
+
+	// APIHostPorts holds the addresses of the controllers' API
+	// servers, which the machine's agents require.
+	APIHostPorts []network.HostPort
+}
+
+// Facade provides access to the HostFirewaller API facade.
+type Facade struct {
+	tag    names.MachineTag
+	caller base.FacadeCaller
+}
+
+// NewFacade creates a new client-side HostFirewaller facade for the
+// given machine's agent.
+func NewFacade(caller base.APICaller, tag names.MachineTag) *Facade {
+	return &Facade{
+		tag:    tag,
+		caller: base.NewFacadeCaller(caller, "HostFirewaller"),
+	}
+}
+
+// EgressPolicy returns the policy restricting the machine's outbound
+// traffic.
+func (f *Facade) EgressPolicy() (Policy, error) {
+	var results params.EgressPolicyResults
+	args := params.Entities{Entities: []params.Entity{{Tag: f.tag.String()}}}
+	if err := f.caller.FacadeCall("EgressPolicy", args, &results); err != nil {
+		return Policy{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return Policy{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return Policy{}, result.Error
+	}
+	policy := Policy{
+		Restricted:   result.Restricted,
+		APIHostPorts: params.NetworkHostPorts(result.APIHostPorts),
+	}
+	for _, rule := range result.Rules {
+		policy.Rules = append(policy.Rules, egress.Rule{
+			CIDR:  rule.CIDR,
+			Ports: rule.Ports,
+		})
+	}
+	return policy, nil
+}
+
+// WatchEgressPolicy returns a NotifyWatcher that triggers whenever the
+// machine's egress policy may have changed.
+func (f *Facade) WatchEgressPolicy() (watcher.NotifyWatcher, error) {
+	var results params.NotifyWatchResults
+	args := params.Entities{Entities: []params.Entity{{Tag: f.tag.String()}}}
+	if err := f.caller.FacadeCall("WatchEgressPolicy", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(f.caller.RawAPICaller(), result), nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/hostfirewaller"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/egress"
+	"github.com/juju/juju/network"
+)
+
+type facadeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) TestEgressPolicy(c *gc.C) {
+	hostPorts := network.NewHostPorts(17070, "10.0.0.1")
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "HostFirewaller")
+		c.Check(request, gc.Equals, "EgressPolicy")
+		c.Check(args, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "machine-42"}},
+		})
+		*response.(*params.EgressPolicyResults) = params.EgressPolicyResults{
+			Results: []params.EgressPolicyResult{{
+				Restricted:   true,
+				Rules:        []params.EgressRule{{CIDR: "0.0.0.0/0", Ports: "443/tcp"}},
+				APIHostPorts: params.FromNetworkHostPorts(hostPorts),
+			}},
+		}
+		return nil
+	})
+	facade := hostfirewaller.NewFacade(apiCaller, names.NewMachineTag("42"))
+
+	policy, err := facade.EgressPolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, jc.DeepEquals, hostfirewaller.Policy{
+		Restricted:   true,
+		Rules:        []egress.Rule{{CIDR: "0.0.0.0/0", Ports: "443/tcp"}},
+		APIHostPorts: hostPorts,
+	})
+}
+
+func (s *facadeSuite) TestEgressPolicyError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		*response.(*params.EgressPolicyResults) = params.EgressPolicyResults{
+			Results: []params.EgressPolicyResult{{
+				Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+			}},
+		}
+		return nil
+	})
+	facade := hostfirewaller.NewFacade(apiCaller, names.NewMachineTag("42"))
+
+	_, err := facade.EgressPolicy()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(params.IsCodeUnauthorized(err), jc.IsTrue)
+}
+
+func (s *facadeSuite) TestWatchEgressPolicyError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(request, gc.Equals, "WatchEgressPolicy")
+		*response.(*params.NotifyWatchResults) = params.NotifyWatchResults{
+			Results: []params.NotifyWatchResult{{
+				Error: &params.Error{Message: "boom"},
+			}},
+		}
+		return nil
+	})
+	facade := hostfirewaller.NewFacade(apiCaller, names.NewMachineTag("42"))
+
+	_, err := facade.WatchEgressPolicy()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
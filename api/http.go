@@ -7,9 +7,11 @@ import (
 	"bytes"
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/httprequest"
@@ -203,19 +205,39 @@ type HTTPDoer interface {
 // openBlob streams the identified blob from the controller via the
 // provided HTTP client.
 func openBlob(httpClient HTTPDoer, endpoint string, args url.Values) (io.ReadCloser, error) {
+	blob, _, err := openBlobFrom(httpClient, endpoint, args, 0)
+	return blob, errors.Trace(err)
+}
+
+// openBlobFrom streams the identified blob from the controller via the
+// provided HTTP client, asking for it to start at the given offset. It
+// returns the offset that the stream actually starts from, which is
+// zero if the controller sent the whole blob.
+func openBlobFrom(httpClient HTTPDoer, endpoint string, args url.Values, offset int64) (io.ReadCloser, int64, error) {
 	apiURL, err := url.Parse(endpoint)
 	if err != nil {
-		return nil, errors.Trace(err)
+		return nil, 0, errors.Trace(err)
 	}
 	apiURL.RawQuery = args.Encode()
 	req, err := http.NewRequest("GET", apiURL.String(), nil)
 	if err != nil {
-		return nil, errors.Annotate(err, "cannot create HTTP request")
+		return nil, 0, errors.Annotate(err, "cannot create HTTP request")
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
 	}
 
 	var resp *http.Response
 	if err := httpClient.Do(req, nil, &resp); err != nil {
-		return nil, errors.Trace(err)
+		return nil, 0, errors.Trace(err)
+	}
+	if offset == 0 || resp.StatusCode != http.StatusPartialContent {
+		return resp.Body, 0, nil
+	}
+	expected := fmt.Sprintf("bytes %d-", offset)
+	if contentRange := resp.Header.Get("Content-Range"); !strings.HasPrefix(contentRange, expected) {
+		resp.Body.Close()
+		return nil, 0, errors.Errorf("unexpected content range %q", contentRange)
 	}
-	return resp.Body, nil
+	return resp.Body, offset, nil
 }
@@ -82,6 +82,11 @@ type Info struct {
 	// Nonce holds the nonce used when provisioning the machine. Used
 	// only by the machine agent.
 	Nonce string `yaml:",omitempty"`
+
+	// ReadOnly, if true, requests a connection on which the API
+	// server refuses any call that could change the controller or
+	// its models.
+	ReadOnly bool `yaml:",omitempty"`
 }
 
 // Ports returns the unique ports for the api addresses.
@@ -154,6 +159,16 @@ type DialOpts struct {
 	// performed and the communication need not be secure.
 	InsecureSkipVerify bool
 
+	// EnableCompression requests that messages on the API
+	// connection be compressed with the websocket per-message
+	// deflate extension. Controllers that do not support the
+	// extension ignore the request, and messages are sent
+	// uncompressed. Compression greatly reduces the size of large
+	// responses, such as FullStatus results, at the expense of CPU
+	// time at both ends of the connection. It is ignored if
+	// DialWebsocket is set.
+	EnableCompression bool
+
 	// DialWebsocket is used to make connections to API servers.
 	// It will be called with a websocket URL to connect to,
 	// and the TLS configuration to use to secure the connection.
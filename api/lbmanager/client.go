@@ -0,0 +1,99 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package lbmanager provides the client side of the API facade used by
+// the load balancer manager worker.
+package lbmanager
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/watcher"
+)
+
+const lbManagerFacade = "LBManager"
+
+// Application holds the details of an application needed to provision
+// a load balancer for it.
+type Application struct {
+	Tag      names.ApplicationTag
+	Life     params.Life
+	Exposed  bool
+	Ports    []network.PortRange
+	Backends []instance.Id
+	Address  string
+}
+
+// Client provides access to the load balancer manager API facade.
+type Client struct {
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the load balancer
+// manager API.
+func NewClient(caller base.APICaller) *Client {
+	return &Client{facade: base.NewFacadeCaller(caller, lbManagerFacade)}
+}
+
+// WatchApplications returns a StringsWatcher that notifies of changes
+// to the life cycles of the model's applications.
+func (c *Client) WatchApplications() (watcher.StringsWatcher, error) {
+	var result params.StringsWatchResult
+	if err := c.facade.FacadeCall("WatchApplications", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), result), nil
+}
+
+// Applications returns the details of the model's applications.
+func (c *Client) Applications() ([]Application, error) {
+	var result params.LoadBalancerApplicationsResult
+	if err := c.facade.FacadeCall("Applications", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	apps := make([]Application, len(result.Applications))
+	for i, app := range result.Applications {
+		tag, err := names.ParseApplicationTag(app.Tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		apps[i] = Application{
+			Tag:     tag,
+			Life:    app.Life,
+			Exposed: app.Exposed,
+			Address: app.Address,
+		}
+		for _, pr := range app.Ports {
+			apps[i].Ports = append(apps[i].Ports, pr.NetworkPortRange())
+		}
+		for _, id := range app.Backends {
+			apps[i].Backends = append(apps[i].Backends, instance.Id(id))
+		}
+	}
+	return apps, nil
+}
+
+// SetAddress records the address of the load balancer provisioned for
+// the application. An empty address records that there is none.
+func (c *Client) SetAddress(tag names.ApplicationTag, addr string) error {
+	args := params.SetLoadBalancerAddresses{
+		Applications: []params.ApplicationLoadBalancerAddress{{Tag: tag.String(), Address: addr}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetAddresses", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -0,0 +1,97 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lbmanager_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/lbmanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestApplications(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "LBManager")
+		c.Check(request, gc.Equals, "Applications")
+		*(result.(*params.LoadBalancerApplicationsResult)) = params.LoadBalancerApplicationsResult{
+			Applications: []params.LoadBalancerApplication{{
+				Tag:      "application-wordpress",
+				Life:     params.Alive,
+				Exposed:  true,
+				Ports:    []params.PortRange{{FromPort: 80, ToPort: 80, Protocol: "tcp"}},
+				Backends: []string{"i-0", "i-1"},
+				Address:  "wordpress-lb.example.com",
+			}, {
+				Tag:  "application-mysql",
+				Life: params.Dying,
+			}},
+		}
+		return nil
+	})
+	apps, err := lbmanager.NewClient(apiCaller).Applications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(apps, jc.DeepEquals, []lbmanager.Application{{
+		Tag:      names.NewApplicationTag("wordpress"),
+		Life:     params.Alive,
+		Exposed:  true,
+		Ports:    []network.PortRange{{FromPort: 80, ToPort: 80, Protocol: "tcp"}},
+		Backends: []instance.Id{"i-0", "i-1"},
+		Address:  "wordpress-lb.example.com",
+	}, {
+		Tag:  names.NewApplicationTag("mysql"),
+		Life: params.Dying,
+	}})
+}
+
+func (s *clientSuite) TestApplicationsError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("kaboom")
+	})
+	_, err := lbmanager.NewClient(apiCaller).Applications()
+	c.Assert(err, gc.ErrorMatches, "kaboom")
+}
+
+func (s *clientSuite) TestSetAddress(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "SetAddresses")
+		c.Check(arg, jc.DeepEquals, params.SetLoadBalancerAddresses{
+			Applications: []params.ApplicationLoadBalancerAddress{{
+				Tag:     "application-wordpress",
+				Address: "wordpress-lb.example.com",
+			}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: `application "wordpress" not found`, Code: params.CodeNotFound}}},
+		}
+		return nil
+	})
+	err := lbmanager.NewClient(apiCaller).SetAddress(names.NewApplicationTag("wordpress"), "wordpress-lb.example.com")
+	c.Assert(err, gc.ErrorMatches, `application "wordpress" not found`)
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *clientSuite) TestWatchApplicationsError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "WatchApplications")
+		*(result.(*params.StringsWatchResult)) = params.StringsWatchResult{
+			Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+		}
+		return nil
+	})
+	_, err := lbmanager.NewClient(apiCaller).WatchApplications()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
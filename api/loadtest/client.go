@@ -0,0 +1,71 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package loadtest provides a client for the LoadTest facade, which
+// populates a model with synthetic applications and changes them to
+// load test the controller. The facade is only available when the
+// controller has the load-testing feature flag set.
+package loadtest
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the LoadTest facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new LoadTest client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "LoadTest")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Populate adds synthetic applications to the model, returning the
+// names of those added. If an error is returned, the names are those
+// of the applications added before it occurred.
+func (c *Client) Populate(args params.PopulateModelArgs) ([]string, error) {
+	var result params.PopulateModelResult
+	if err := c.facade.FacadeCall("Populate", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return result.Applications, result.Error
+	}
+	return result.Applications, nil
+}
+
+// Churn changes the model's synthetic entities for the given number
+// of rounds, choosing the changes from the given seed, and returns the
+// number of changes made.
+func (c *Client) Churn(rounds int, seed int64) (int, error) {
+	args := params.ChurnModelArgs{Rounds: rounds, Seed: seed}
+	var result params.ChurnModelResult
+	if err := c.facade.FacadeCall("Churn", args, &result); err != nil {
+		return 0, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return result.Changes, result.Error
+	}
+	return result.Changes, nil
+}
+
+// Clear removes the model's synthetic applications, with their units,
+// relations and machines, and reports how many of each were removed.
+func (c *Client) Clear() (params.ClearModelResult, error) {
+	var result params.ClearModelResult
+	if err := c.facade.FacadeCall("Clear", nil, &result); err != nil {
+		return params.ClearModelResult{}, errors.Trace(err)
+	}
+	if result.Error != nil {
+		err := result.Error
+		result.Error = nil
+		return result, err
+	}
+	return result, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package loadtest_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/loadtest"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestPopulate(c *gc.C) {
+	args := params.PopulateModelArgs{
+		Applications:        2,
+		UnitsPerApplication: 5,
+		Relate:              true,
+	}
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "LoadTest")
+		c.Check(request, gc.Equals, "Populate")
+		c.Check(arg, jc.DeepEquals, args)
+		*(result.(*params.PopulateModelResult)) = params.PopulateModelResult{
+			Applications: []string{"synthetic-0", "synthetic-1"},
+		}
+		return nil
+	})
+	added, err := loadtest.NewClient(apiCaller).Populate(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(added, jc.DeepEquals, []string{"synthetic-0", "synthetic-1"})
+}
+
+func (s *clientSuite) TestPopulatePartialFailure(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.PopulateModelResult)) = params.PopulateModelResult{
+			Applications: []string{"synthetic-0"},
+			Error:        &params.Error{Message: "kaboom"},
+		}
+		return nil
+	})
+	added, err := loadtest.NewClient(apiCaller).Populate(params.PopulateModelArgs{Applications: 2})
+	c.Assert(err, gc.ErrorMatches, "kaboom")
+	c.Assert(added, jc.DeepEquals, []string{"synthetic-0"})
+}
+
+func (s *clientSuite) TestChurn(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "LoadTest")
+		c.Check(request, gc.Equals, "Churn")
+		c.Check(arg, jc.DeepEquals, params.ChurnModelArgs{Rounds: 3, Seed: 42})
+		*(result.(*params.ChurnModelResult)) = params.ChurnModelResult{Changes: 30}
+		return nil
+	})
+	changes, err := loadtest.NewClient(apiCaller).Churn(3, 42)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes, gc.Equals, 30)
+}
+
+func (s *clientSuite) TestClear(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "LoadTest")
+		c.Check(request, gc.Equals, "Clear")
+		c.Check(arg, gc.IsNil)
+		*(result.(*params.ClearModelResult)) = params.ClearModelResult{
+			Applications: 1,
+			Units:        2,
+			Machines:     2,
+			Error:        &params.Error{Message: "kaboom"},
+		}
+		return nil
+	})
+	result, err := loadtest.NewClient(apiCaller).Clear()
+	c.Assert(err, gc.ErrorMatches, "kaboom")
+	c.Assert(result, jc.DeepEquals, params.ClearModelResult{
+		Applications: 1,
+		Units:        2,
+		Machines:     2,
+	})
+}
@@ -5,6 +5,7 @@ package logger
 
 import (
 	"fmt"
+	"time"
 
 	"gopkg.in/juju/names.v2"
 
@@ -72,3 +73,33 @@ func (st *State) WatchLoggingConfig(agentTag names.Tag) (watcher.NotifyWatcher,
 	w := apiwatcher.NewNotifyWatcher(st.facade.RawAPICaller(), result)
 	return w, nil
 }
+
+// LoggingOverride returns the loggo configuration string that should
+// be applied on top of the model's logging config for the agent
+// specified by agentTag, and the time at which it expires. If there
+// is no override, or the controller does not support them, an empty
+// string is returned.
+func (st *State) LoggingOverride(agentTag names.Tag) (string, time.Time, error) {
+	if st.facade.BestAPIVersion() < 2 {
+		return "", time.Time{}, nil
+	}
+	var results params.AgentLoggingOverrideResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: agentTag.String()}},
+	}
+	err := st.facade.FacadeCall("LoggingOverrides", args, &results)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if len(results.Results) != 1 {
+		return "", time.Time{}, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if err := result.Error; err != nil {
+		return "", time.Time{}, err
+	}
+	if result.Result == nil {
+		return "", time.Time{}, nil
+	}
+	return result.Result.Config, result.Result.Expires, nil
+}
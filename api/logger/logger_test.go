@@ -4,6 +4,8 @@
 package logger_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -77,3 +79,48 @@ func (s *loggerSuite) TestWatchLoggingConfig(c *gc.C) {
 	s.setLoggingConfig(c, loggingConfig)
 	wc.AssertOneChange()
 }
+
+func (s *loggerSuite) TestLoggingOverrideNone(c *gc.C) {
+	config, expires, err := s.logger.LoggingOverride(s.rawMachine.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(config, gc.Equals, "")
+	c.Assert(expires.IsZero(), jc.IsTrue)
+}
+
+func (s *loggerSuite) TestLoggingOverride(c *gc.C) {
+	expected := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	err := s.State.SetAgentLoggingOverride(state.AgentLoggingOverride{
+		Tag:     s.rawMachine.Tag(),
+		Config:  "juju.worker=TRACE",
+		Expires: expected,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	config, expires, err := s.logger.LoggingOverride(s.rawMachine.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(config, gc.Equals, "juju.worker=TRACE")
+	c.Assert(expires.Equal(expected), jc.IsTrue)
+}
+
+func (s *loggerSuite) TestLoggingOverrideWrongMachine(c *gc.C) {
+	_, _, err := s.logger.LoggingOverride(names.NewMachineTag("42"))
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *loggerSuite) TestWatchLoggingConfigNotifiesOnOverride(c *gc.C) {
+	watcher, err := s.logger.WatchLoggingConfig(s.rawMachine.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewNotifyWatcherC(c, watcher, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event
+	wc.AssertOneChange()
+
+	err = s.State.SetAgentLoggingOverride(state.AgentLoggingOverride{
+		Tag:     s.rawMachine.Tag(),
+		Config:  "juju.worker=TRACE",
+		Expires: time.Now().Add(time.Hour),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
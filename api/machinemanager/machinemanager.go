@@ -27,6 +27,13 @@ func NewClient(st base.APICallCloser) *Client {
 
 // AddMachines adds new machines with the supplied parameters, creating any requested disks.
 func (client *Client) AddMachines(machineParams []params.AddMachineParams) ([]params.AddMachinesResult, error) {
+	if client.BestAPIVersion() < 5 {
+		for _, p := range machineParams {
+			if p.CloudInitUserData != "" {
+				return nil, errors.New("this juju controller does not support cloud-init user data for machines")
+			}
+		}
+	}
 	args := params.AddMachines{
 		MachineParams: machineParams,
 	}
@@ -135,3 +142,78 @@ func (client *Client) UpdateMachineSeries(machineName, series string, force bool
 	}
 	return results.OneError()
 }
+
+// CloudInitUserData returns, as YAML, the user-supplied cloud-init
+// configuration that is merged into the machine's when it is
+// provisioned.
+func (client *Client) CloudInitUserData(machineId string) (string, error) {
+	if client.BestAPIVersion() < 5 {
+		return "", errors.New("this juju controller does not support CloudInitUserData")
+	}
+	if !names.IsValidMachine(machineId) {
+		return "", errors.NotValidf("machine ID %q", machineId)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewMachineTag(machineId).String()}},
+	}
+	var results params.StringResults
+	if err := client.facade.FacadeCall("CloudInitUserData", args, &results); err != nil {
+		return "", errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return "", err
+	}
+	return results.Results[0].Result, nil
+}
+
+// ContainerImageCache returns the images the machine agent has cached
+// for creating containers, along with any purge of the cache that the
+// agent has yet to carry out.
+func (client *Client) ContainerImageCache(machineId string) (params.ContainerImageCache, error) {
+	if client.BestAPIVersion() < 6 {
+		return params.ContainerImageCache{}, errors.New("this juju controller does not support ContainerImageCache")
+	}
+	if !names.IsValidMachine(machineId) {
+		return params.ContainerImageCache{}, errors.NotValidf("machine ID %q", machineId)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewMachineTag(machineId).String()}},
+	}
+	var results params.ContainerImageCacheResults
+	if err := client.facade.FacadeCall("ContainerImageCache", args, &results); err != nil {
+		return params.ContainerImageCache{}, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return params.ContainerImageCache{}, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return params.ContainerImageCache{}, err
+	}
+	return *results.Results[0].Result, nil
+}
+
+// PurgeContainerImages asks the machine agent to remove the images of
+// the given series from its container image cache, or all images if no
+// series are given.
+func (client *Client) PurgeContainerImages(machineId string, series ...string) error {
+	if client.BestAPIVersion() < 6 {
+		return errors.New("this juju controller does not support PurgeContainerImages")
+	}
+	if !names.IsValidMachine(machineId) {
+		return errors.NotValidf("machine ID %q", machineId)
+	}
+	args := params.PurgeContainerImagesArgs{
+		Args: []params.PurgeContainerImages{{
+			Tag:    names.NewMachineTag(machineId).String(),
+			Series: series,
+		}},
+	}
+	var results params.ErrorResults
+	if err := client.facade.FacadeCall("PurgeContainerImages", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
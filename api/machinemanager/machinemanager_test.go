@@ -210,3 +210,107 @@ func (s *MachinemanagerSuite) TestDestroyMachinesWithParams(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, jc.DeepEquals, expectedResults)
 }
+
+func (s *MachinemanagerSuite) TestAddMachinesCloudInitUserDataNotSupported(c *gc.C) {
+	st := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	_, err := st.AddMachines([]params.AddMachineParams{{
+		Series:            "trusty",
+		CloudInitUserData: "packages: [htop]",
+	}})
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support cloud-init user data for machines")
+}
+
+func (s *MachinemanagerSuite) TestCloudInitUserData(c *gc.C) {
+	client := machinemanager.NewClient(basetesting.BestVersionCaller{
+		BestVersion: 5,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Check(objType, gc.Equals, "MachineManager")
+			c.Check(request, gc.Equals, "CloudInitUserData")
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "machine-0"}},
+			})
+			c.Assert(response, gc.FitsTypeOf, &params.StringResults{})
+			*(response.(*params.StringResults)) = params.StringResults{
+				Results: []params.StringResult{{Result: "packages:\n- htop\n"}},
+			}
+			return nil
+		},
+	})
+	userData, err := client.CloudInitUserData("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(userData, gc.Equals, "packages:\n- htop\n")
+}
+
+func (s *MachinemanagerSuite) TestCloudInitUserDataNotSupported(c *gc.C) {
+	client := machinemanager.NewClient(basetesting.BestVersionCaller{
+		BestVersion: 4,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+	})
+	_, err := client.CloudInitUserData("0")
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support CloudInitUserData")
+}
+
+func (s *MachinemanagerSuite) TestContainerImageCache(c *gc.C) {
+	client := machinemanager.NewClient(basetesting.BestVersionCaller{
+		BestVersion: 6,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Check(objType, gc.Equals, "MachineManager")
+			c.Check(request, gc.Equals, "ContainerImageCache")
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "machine-0"}},
+			})
+			c.Assert(response, gc.FitsTypeOf, &params.ContainerImageCacheResults{})
+			*(response.(*params.ContainerImageCacheResults)) = params.ContainerImageCacheResults{
+				Results: []params.ContainerImageCacheResult{{
+					Result: &params.ContainerImageCache{
+						Images: []params.ContainerImage{{Series: "xenial", Arch: "amd64"}},
+					},
+				}},
+			}
+			return nil
+		},
+	})
+	cache, err := client.ContainerImageCache("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cache, jc.DeepEquals, params.ContainerImageCache{
+		Images: []params.ContainerImage{{Series: "xenial", Arch: "amd64"}},
+	})
+}
+
+func (s *MachinemanagerSuite) TestContainerImageCacheNotSupported(c *gc.C) {
+	client := machinemanager.NewClient(basetesting.BestVersionCaller{
+		BestVersion: 5,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+	})
+	_, err := client.ContainerImageCache("0")
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support ContainerImageCache")
+}
+
+func (s *MachinemanagerSuite) TestPurgeContainerImages(c *gc.C) {
+	client := machinemanager.NewClient(basetesting.BestVersionCaller{
+		BestVersion: 6,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Check(objType, gc.Equals, "MachineManager")
+			c.Check(request, gc.Equals, "PurgeContainerImages")
+			c.Check(a, jc.DeepEquals, params.PurgeContainerImagesArgs{
+				Args: []params.PurgeContainerImages{{Tag: "machine-0", Series: []string{"xenial"}}},
+			})
+			c.Assert(response, gc.FitsTypeOf, &params.ErrorResults{})
+			*(response.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			return nil
+		},
+	})
+	err := client.PurgeContainerImages("0", "xenial")
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -4,6 +4,8 @@
 package machiner
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
@@ -137,3 +139,23 @@ func (m *Machine) SetProviderNetworkConfig() error {
 	}
 	return result.OneError()
 }
+
+// ReportInterruption reports that the cloud will reclaim the machine's
+// instance at the given deadline, so that its units can be moved to a
+// replacement machine.
+func (m *Machine) ReportInterruption(deadline time.Time) error {
+	if m.st.facade.BestAPIVersion() < 2 {
+		return errors.NotImplementedf("ReportInterruption() (need V2+)")
+	}
+	var result params.ErrorResults
+	args := params.MachineInterruptions{
+		Interruptions: []params.MachineInterruption{
+			{Tag: m.tag.String(), Deadline: deadline},
+		},
+	}
+	err := m.st.facade.FacadeCall("ReportInterruptions", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
@@ -5,6 +5,7 @@ package machiner_test
 
 import (
 	stdtesting "testing"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -178,6 +179,22 @@ func (s *machinerSuite) TestSetEmptyMachineAddresses(c *gc.C) {
 	c.Assert(s.machine.MachineAddresses(), gc.HasLen, 0)
 }
 
+func (s *machinerSuite) TestReportInterruption(c *gc.C) {
+	machine, err := s.machiner.Machine(names.NewMachineTag("1"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.ReportInterruption(time.Now().Add(2 * time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := s.machine.Replacement()
+	c.Assert(ok, jc.IsTrue)
+	statusInfo, err := s.machine.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusInfo.Status, gc.Equals, status.Interrupted)
+}
+
 func (s *machinerSuite) TestWatch(c *gc.C) {
 	machine, err := s.machiner.Machine(names.NewMachineTag("1"))
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,169 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package maintenance provides a client for the Maintenance facade,
+// which puts machines, applications and units into maintenance mode
+// while planned work is carried out on them, and defines the model's
+// maintenance windows and records acknowledgements that agents are
+// intentionally offline.
+package maintenance
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the Maintenance facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new Maintenance client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "Maintenance")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// MaintenanceModes returns the maintenance modes in effect in the
+// model.
+func (c *Client) MaintenanceModes() ([]params.MaintenanceMode, error) {
+	var result params.MaintenanceModes
+	if err := c.facade.FacadeCall("MaintenanceModes", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Modes, nil
+}
+
+// SetMaintenanceMode puts the machine, application or unit with the
+// given tag into maintenance mode for the given reason. If expires is
+// not zero, the maintenance mode ends at that time; otherwise it
+// lasts until it is cleared.
+func (c *Client) SetMaintenanceMode(tag names.Tag, reason string, expires time.Time) error {
+	mode := params.MaintenanceMode{
+		Tag:    tag.String(),
+		Reason: reason,
+	}
+	if !expires.IsZero() {
+		mode.Expires = &expires
+	}
+	args := params.MaintenanceModes{Modes: []params.MaintenanceMode{mode}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetMaintenanceModes", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// ClearMaintenanceMode takes the entity with the given tag out of
+// maintenance mode.
+func (c *Client) ClearMaintenanceMode(tag names.Tag) error {
+	args := params.Entities{Entities: []params.Entity{{Tag: tag.String()}}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("ClearMaintenanceModes", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// MaintenanceWindows returns the maintenance windows defined in the
+// model, and whether disruptive automated operations may run now.
+func (c *Client) MaintenanceWindows() (params.MaintenanceWindows, error) {
+	if c.BestAPIVersion() < 2 {
+		return params.MaintenanceWindows{}, errors.NotSupportedf("MaintenanceWindows")
+	}
+	var result params.MaintenanceWindows
+	if err := c.facade.FacadeCall("MaintenanceWindows", nil, &result); err != nil {
+		return params.MaintenanceWindows{}, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// SetMaintenanceWindow defines a maintenance window that opens at the
+// minutes, in UTC, of the given cron-like schedule and stays open for
+// the given duration, replacing any existing window with the same
+// name.
+func (c *Client) SetMaintenanceWindow(name, schedule string, duration time.Duration) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("SetMaintenanceWindow")
+	}
+	args := params.MaintenanceWindows{
+		Windows: []params.MaintenanceWindow{{
+			Name:     name,
+			Schedule: schedule,
+			Duration: duration,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetMaintenanceWindows", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// RemoveMaintenanceWindow removes the named maintenance window.
+func (c *Client) RemoveMaintenanceWindow(name string) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("RemoveMaintenanceWindow")
+	}
+	args := params.MaintenanceWindowNames{Names: []string{name}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RemoveMaintenanceWindows", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// AgentsOffline returns the acknowledgements in effect that agents of
+// the model's machines and units are offline.
+func (c *Client) AgentsOffline() ([]params.AgentOffline, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("AgentsOffline")
+	}
+	var result params.AgentsOffline
+	if err := c.facade.FacadeCall("AgentsOffline", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Agents, nil
+}
+
+// SetAgentOffline acknowledges that the agent of the machine or unit
+// with the given tag is intentionally offline for the given reason,
+// until the given expiry time. Until then, the agent is reported as
+// offline rather than down or lost.
+func (c *Client) SetAgentOffline(tag names.Tag, reason string, expires time.Time) error {
+	if c.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("SetAgentOffline")
+	}
+	args := params.AgentsOffline{
+		Agents: []params.AgentOffline{{
+			Tag:     tag.String(),
+			Reason:  reason,
+			Expires: expires,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetAgentsOffline", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// ClearAgentOffline removes the acknowledgement that the agent of the
+// machine or unit with the given tag is offline.
+func (c *Client) ClearAgentOffline(tag names.Tag) error {
+	if c.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("ClearAgentOffline")
+	}
+	args := params.Entities{Entities: []params.Entity{{Tag: tag.String()}}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("ClearAgentsOffline", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
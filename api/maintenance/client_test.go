@@ -0,0 +1,232 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package maintenance_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/maintenance"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestMaintenanceModes(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Maintenance")
+		c.Check(request, gc.Equals, "MaintenanceModes")
+		c.Check(arg, gc.IsNil)
+		*(result.(*params.MaintenanceModes)) = params.MaintenanceModes{
+			Modes: []params.MaintenanceMode{{Tag: "machine-0", Reason: "replacing disks"}},
+		}
+		return nil
+	})
+	modes, err := maintenance.NewClient(apiCaller).MaintenanceModes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modes, jc.DeepEquals, []params.MaintenanceMode{{Tag: "machine-0", Reason: "replacing disks"}})
+}
+
+func (s *clientSuite) TestSetMaintenanceMode(c *gc.C) {
+	expires := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Maintenance")
+		c.Check(request, gc.Equals, "SetMaintenanceModes")
+		c.Check(arg, jc.DeepEquals, params.MaintenanceModes{
+			Modes: []params.MaintenanceMode{{
+				Tag:     "unit-mysql-0",
+				Reason:  "kernel upgrade",
+				Expires: &expires,
+			}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "kaboom"}}},
+		}
+		return nil
+	})
+	err := maintenance.NewClient(apiCaller).SetMaintenanceMode(names.NewUnitTag("mysql/0"), "kernel upgrade", expires)
+	c.Assert(err, gc.ErrorMatches, "kaboom")
+}
+
+func (s *clientSuite) TestClearMaintenanceMode(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Maintenance")
+		c.Check(request, gc.Equals, "ClearMaintenanceModes")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "machine-0"}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		return nil
+	})
+	err := maintenance.NewClient(apiCaller).ClearMaintenanceMode(names.NewMachineTag("0"))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *clientSuite) TestMaintenanceWindows(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Maintenance")
+			c.Check(request, gc.Equals, "MaintenanceWindows")
+			c.Check(arg, gc.IsNil)
+			*(result.(*params.MaintenanceWindows)) = params.MaintenanceWindows{
+				Windows: []params.MaintenanceWindow{{Name: "weekend", Schedule: "0 2 * * sat", Duration: time.Hour}},
+				Open:    true,
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+	windows, err := maintenance.NewClient(apiCaller).MaintenanceWindows()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(windows, jc.DeepEquals, params.MaintenanceWindows{
+		Windows: []params.MaintenanceWindow{{Name: "weekend", Schedule: "0 2 * * sat", Duration: time.Hour}},
+		Open:    true,
+	})
+}
+
+func (s *clientSuite) TestSetMaintenanceWindow(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Maintenance")
+			c.Check(request, gc.Equals, "SetMaintenanceWindows")
+			c.Check(arg, jc.DeepEquals, params.MaintenanceWindows{
+				Windows: []params.MaintenanceWindow{{Name: "weekend", Schedule: "0 2 * * sat", Duration: time.Hour}},
+			})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{Error: &params.Error{Message: "kaboom"}}},
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+	err := maintenance.NewClient(apiCaller).SetMaintenanceWindow("weekend", "0 2 * * sat", time.Hour)
+	c.Assert(err, gc.ErrorMatches, "kaboom")
+}
+
+func (s *clientSuite) TestRemoveMaintenanceWindow(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Maintenance")
+			c.Check(request, gc.Equals, "RemoveMaintenanceWindows")
+			c.Check(arg, jc.DeepEquals, params.MaintenanceWindowNames{Names: []string{"weekend"}})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+	err := maintenance.NewClient(apiCaller).RemoveMaintenanceWindow("weekend")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *clientSuite) TestMaintenanceWindowsNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 1,
+	}
+	client := maintenance.NewClient(apiCaller)
+	_, err := client.MaintenanceWindows()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	err = client.SetMaintenanceWindow("weekend", "0 2 * * sat", time.Hour)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	err = client.RemoveMaintenanceWindow("weekend")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *clientSuite) TestAgentsOffline(c *gc.C) {
+	expires := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	expected := []params.AgentOffline{{
+		Tag:            "machine-3",
+		Reason:         "replacing motherboard",
+		Expires:        expires,
+		AcknowledgedBy: "user-admin",
+	}}
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Maintenance")
+			c.Check(request, gc.Equals, "AgentsOffline")
+			c.Check(arg, gc.IsNil)
+			*(result.(*params.AgentsOffline)) = params.AgentsOffline{Agents: expected}
+			return nil
+		},
+		BestVersion: 3,
+	}
+	agents, err := maintenance.NewClient(apiCaller).AgentsOffline()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(agents, jc.DeepEquals, expected)
+}
+
+func (s *clientSuite) TestSetAgentOffline(c *gc.C) {
+	expires := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Maintenance")
+			c.Check(request, gc.Equals, "SetAgentsOffline")
+			c.Check(arg, jc.DeepEquals, params.AgentsOffline{
+				Agents: []params.AgentOffline{{
+					Tag:     "machine-3",
+					Reason:  "replacing motherboard",
+					Expires: expires,
+				}},
+			})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{Error: &params.Error{Message: "kaboom"}}},
+			}
+			return nil
+		},
+		BestVersion: 3,
+	}
+	err := maintenance.NewClient(apiCaller).SetAgentOffline(names.NewMachineTag("3"), "replacing motherboard", expires)
+	c.Assert(err, gc.ErrorMatches, "kaboom")
+}
+
+func (s *clientSuite) TestClearAgentOffline(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Maintenance")
+			c.Check(request, gc.Equals, "ClearAgentsOffline")
+			c.Check(arg, jc.DeepEquals, params.Entities{Entities: []params.Entity{{Tag: "unit-mysql-0"}}})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			return nil
+		},
+		BestVersion: 3,
+	}
+	err := maintenance.NewClient(apiCaller).ClearAgentOffline(names.NewUnitTag("mysql/0"))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *clientSuite) TestAgentsOfflineNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := maintenance.NewClient(apiCaller)
+	_, err := client.AgentsOffline()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	err = client.SetAgentOffline(names.NewMachineTag("3"), "replacing motherboard", time.Now())
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	err = client.ClearAgentOffline(names.NewMachineTag("3"))
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
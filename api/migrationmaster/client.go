@@ -110,6 +110,28 @@ func (c *Client) MigrationStatus() (migration.MigrationStatus, error) {
 			Password:      target.Password,
 			Macaroons:     macs,
 		},
+		RollbackWindow: status.Spec.RollbackWindow,
+		Rollback:       status.Rollback,
+	}, nil
+}
+
+// SourceControllerInfo returns the details required to connect to the
+// controller the model is being migrated from.
+func (c *Client) SourceControllerInfo() (migration.SourceInfo, error) {
+	var empty migration.SourceInfo
+	var info params.MigrationSourceInfo
+	err := c.caller.FacadeCall("SourceControllerInfo", nil, &info)
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	controllerTag, err := names.ParseControllerTag(info.ControllerTag)
+	if err != nil {
+		return empty, errors.Annotatef(err, "parsing controller tag")
+	}
+	return migration.SourceInfo{
+		ControllerTag: controllerTag,
+		Addrs:         info.Addrs,
+		CACert:        info.CACert,
 	}, nil
 }
 
@@ -119,6 +119,55 @@ func (s *ClientSuite) TestMigrationStatus(c *gc.C) {
 	})
 }
 
+func (s *ClientSuite) TestMigrationStatusRollback(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(_ string, _ int, _, _ string, _, result interface{}) error {
+		out := result.(*params.MasterMigrationStatus)
+		*out = params.MasterMigrationStatus{
+			Spec: params.MigrationSpec{
+				ModelTag: names.NewModelTag(utils.MustNewUUID().String()).String(),
+				TargetInfo: params.MigrationTargetInfo{
+					ControllerTag: names.NewControllerTag(utils.MustNewUUID().String()).String(),
+					AuthTag:       names.NewUserTag("admin").String(),
+				},
+				RollbackWindow: time.Hour,
+			},
+			Phase:    "SUCCESS",
+			Rollback: true,
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller, nil)
+	status, err := client.MigrationStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status.RollbackWindow, gc.Equals, time.Hour)
+	c.Check(status.Rollback, jc.IsTrue)
+}
+
+func (s *ClientSuite) TestSourceControllerInfo(c *gc.C) {
+	var stub jujutesting.Stub
+	controllerTag := names.NewControllerTag(utils.MustNewUUID().String())
+	apiCaller := apitesting.APICallerFunc(func(objType string, v int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		*(result.(*params.MigrationSourceInfo)) = params.MigrationSourceInfo{
+			ControllerTag: controllerTag.String(),
+			Addrs:         []string{"1.1.1.1:17070"},
+			CACert:        "cert",
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller, nil)
+	info, err := client.SourceControllerInfo()
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.SourceControllerInfo", []interface{}{"", nil}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info, jc.DeepEquals, migration.SourceInfo{
+		ControllerTag: controllerTag,
+		Addrs:         []string{"1.1.1.1:17070"},
+		CACert:        "cert",
+	})
+}
+
 func (s *ClientSuite) TestSetPhase(c *gc.C) {
 	var stub jujutesting.Stub
 	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
@@ -247,3 +247,40 @@ func (c *Client) CheckMachines(modelUUID string) ([]error, error) {
 	}
 	return results, nil
 }
+
+// CheckAgents returns a problem for each agent of the migrated model
+// that isn't connected to the target controller, and for each unit
+// whose hooks are failing.
+func (c *Client) CheckAgents(modelUUID string) ([]error, error) {
+	if c.caller.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("checking agents of a migrated model")
+	}
+	var result params.ErrorResults
+	args := params.ModelArgs{names.NewModelTag(modelUUID).String()}
+	err := c.caller.FacadeCall("CheckAgents", args, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var results []error
+	for _, res := range result.Results {
+		results = append(results, errors.Errorf(res.Error.Message))
+	}
+	return results, nil
+}
+
+// Rollback asks the target controller to hand a migrated model back to
+// the source controller, after the migration was rolled back there.
+func (c *Client) Rollback(modelUUID string, source coremigration.SourceInfo) error {
+	if c.caller.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("rolling back a migrated model")
+	}
+	args := params.RollbackModelArgs{
+		ModelTag: names.NewModelTag(modelUUID).String(),
+		SourceInfo: params.MigrationSourceInfo{
+			ControllerTag: source.ControllerTag.String(),
+			Addrs:         source.Addrs,
+			CACert:        source.CACert,
+		},
+	}
+	return c.caller.FacadeCall("Rollback", args, nil)
+}
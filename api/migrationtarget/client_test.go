@@ -174,6 +174,69 @@ func (s *ClientSuite) TestCheckMachines(c *gc.C) {
 	s.AssertModelCall(c, &stub, names.NewModelTag("django"), "CheckMachines", err, false)
 }
 
+func (s *ClientSuite) TestCheckAgents(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			target, ok := result.(*params.ErrorResults)
+			c.Assert(ok, jc.IsTrue)
+			*target = params.ErrorResults{Results: []params.ErrorResult{
+				{Error: &params.Error{Message: "machine 0 agent is not connected"}},
+			}}
+			stub.AddCall(objType+"."+request, id, arg)
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := migrationtarget.NewClient(apiCaller)
+	results, err := client.CheckAgents("django")
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0], gc.ErrorMatches, "machine 0 agent is not connected")
+	s.AssertModelCall(c, &stub, names.NewModelTag("django"), "CheckAgents", err, false)
+}
+
+func (s *ClientSuite) TestCheckAgentsNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatalf("unexpected call")
+			return nil
+		},
+		BestVersion: 1,
+	}
+	client := migrationtarget.NewClient(apiCaller)
+	_, err := client.CheckAgents("django")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *ClientSuite) TestRollback(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, id, arg)
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := migrationtarget.NewClient(apiCaller)
+	sourceTag := names.NewControllerTag("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	err := client.Rollback("django", coremigration.SourceInfo{
+		ControllerTag: sourceTag,
+		Addrs:         []string{"1.2.3.4:17070"},
+		CACert:        "cert",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.Rollback", []interface{}{"", params.RollbackModelArgs{
+			ModelTag: names.NewModelTag("django").String(),
+			SourceInfo: params.MigrationSourceInfo{
+				ControllerTag: sourceTag.String(),
+				Addrs:         []string{"1.2.3.4:17070"},
+				CACert:        "cert",
+			},
+		}}},
+	})
+}
+
 func (s *ClientSuite) TestUploadCharm(c *gc.C) {
 	const charmBody = "charming"
 	curl := charm.MustParseURL("cs:~user/foo-2")
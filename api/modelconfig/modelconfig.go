@@ -4,7 +4,10 @@
 package modelconfig
 
 import (
+	"time"
+
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
@@ -57,12 +60,41 @@ func (c *Client) ModelGetWithMetadata() (config.ConfigValues, error) {
 	return values, nil
 }
 
-// ModelSet sets the given key-value pairs in the model.
+// ModelSet sets the given key-value pairs in the model. Controllers
+// that support PreviewModelSet reject attributes unknown to Juju and
+// the model's cloud provider.
 func (c *Client) ModelSet(config map[string]interface{}) error {
 	args := params.ModelSet{Config: config}
 	return c.facade.FacadeCall("ModelSet", args, nil)
 }
 
+// ModelSetForce sets the given key-value pairs in the model, including
+// any attributes unknown to Juju and the model's cloud provider.
+func (c *Client) ModelSetForce(config map[string]interface{}) error {
+	args := params.ModelSet{Config: config, Force: true}
+	return c.facade.FacadeCall("ModelSet", args, nil)
+}
+
+// PreviewModelSet validates the given key-value pairs against the
+// model's config and returns the changes that setting them would make,
+// without making them. If force is false, attributes unknown to Juju
+// and the model's cloud provider are rejected.
+func (c *Client) PreviewModelSet(config map[string]interface{}, force bool) ([]params.ModelConfigChange, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("previewing model config changes")
+	}
+	args := params.ModelSet{
+		Config: config,
+		DryRun: true,
+		Force:  force,
+	}
+	var result params.ModelSetResult
+	if err := c.facade.FacadeCall("ModelSet", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Changes, nil
+}
+
 // ModelUnset sets the given key-value pairs in the model.
 func (c *Client) ModelUnset(keys ...string) error {
 	args := params.ModelUnset{Keys: keys}
@@ -90,3 +122,53 @@ func (c *Client) SLALevel() (string, error) {
 	}
 	return result.Result, nil
 }
+
+// SetAgentLoggingOverride sets logging config to be applied to the
+// machine or unit agent with the given tag, on top of the model's
+// logging-config, until the given expiry time.
+func (c *Client) SetAgentLoggingOverride(tag names.Tag, loggingConfig string, expires time.Time) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("agent logging overrides")
+	}
+	args := params.AgentLoggingOverrides{
+		Overrides: []params.AgentLoggingOverride{{
+			Tag:     tag.String(),
+			Config:  loggingConfig,
+			Expires: expires,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetAgentLoggingOverrides", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// RemoveAgentLoggingOverride removes any logging override for the
+// agent with the given tag.
+func (c *Client) RemoveAgentLoggingOverride(tag names.Tag) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("agent logging overrides")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: tag.String()}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RemoveAgentLoggingOverrides", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// AgentLoggingOverrides returns all of the model's agent logging
+// overrides, including those that have expired.
+func (c *Client) AgentLoggingOverrides() ([]params.AgentLoggingOverride, error) {
+	if c.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("agent logging overrides")
+	}
+	var result params.AgentLoggingOverrides
+	if err := c.facade.FacadeCall("AgentLoggingOverrides", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Overrides, nil
+}
@@ -4,9 +4,13 @@
 package modelconfig_test
 
 import (
+	"time"
+
+	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/modelconfig"
@@ -104,6 +108,62 @@ func (s *modelconfigSuite) TestModelSet(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *modelconfigSuite) TestModelSetForce(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(request, gc.Equals, "ModelSet")
+			c.Check(a, jc.DeepEquals, params.ModelSet{
+				Config: map[string]interface{}{"some-name": "value"},
+				Force:  true,
+			})
+			return nil
+		},
+	)
+	client := modelconfig.NewClient(apiCaller)
+	err := client.ModelSetForce(map[string]interface{}{"some-name": "value"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *modelconfigSuite) TestPreviewModelSet(c *gc.C) {
+	expected := []params.ModelConfigChange{{
+		Key:      "ftp-proxy",
+		OldValue: "",
+		NewValue: "http://proxy",
+		Workers:  []string{"proxy-config-updater"},
+	}}
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelConfig")
+			c.Check(version, gc.Equals, 3)
+			c.Check(request, gc.Equals, "ModelSet")
+			c.Check(a, jc.DeepEquals, params.ModelSet{
+				Config: map[string]interface{}{"ftp-proxy": "http://proxy"},
+				DryRun: true,
+			})
+			result.(*params.ModelSetResult).Changes = expected
+			return nil
+		},
+		BestVersion: 3,
+	}
+	client := modelconfig.NewClient(apiCaller)
+	changes, err := client.PreviewModelSet(map[string]interface{}{"ftp-proxy": "http://proxy"}, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes, jc.DeepEquals, expected)
+}
+
+func (s *modelconfigSuite) TestPreviewModelSetNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := modelconfig.NewClient(apiCaller)
+	_, err := client.PreviewModelSet(map[string]interface{}{"ftp-proxy": "http://proxy"}, false)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *modelconfigSuite) TestModelUnset(c *gc.C) {
 	called := false
 	apiCaller := basetesting.APICallerFunc(
@@ -180,3 +240,83 @@ func (s *modelconfigSuite) TestGetSupport(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 	c.Assert(level, gc.Equals, "level")
 }
+
+func (s *modelconfigSuite) TestSetAgentLoggingOverride(c *gc.C) {
+	expires := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelConfig")
+			c.Check(version, gc.Equals, 2)
+			c.Check(request, gc.Equals, "SetAgentLoggingOverrides")
+			c.Check(a, jc.DeepEquals, params.AgentLoggingOverrides{
+				Overrides: []params.AgentLoggingOverride{{
+					Tag:     "unit-mysql-0",
+					Config:  "juju.worker.uniter=TRACE",
+					Expires: expires,
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := modelconfig.NewClient(apiCaller)
+	err := client.SetAgentLoggingOverride(names.NewUnitTag("mysql/0"), "juju.worker.uniter=TRACE", expires)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *modelconfigSuite) TestRemoveAgentLoggingOverride(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(request, gc.Equals, "RemoveAgentLoggingOverrides")
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "machine-0"}},
+			})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := modelconfig.NewClient(apiCaller)
+	err := client.RemoveAgentLoggingOverride(names.NewMachineTag("0"))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *modelconfigSuite) TestAgentLoggingOverrides(c *gc.C) {
+	expected := []params.AgentLoggingOverride{{
+		Tag:     "machine-0",
+		Config:  "juju=DEBUG",
+		Expires: time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC),
+	}}
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(request, gc.Equals, "AgentLoggingOverrides")
+			c.Check(a, gc.IsNil)
+			result.(*params.AgentLoggingOverrides).Overrides = expected
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := modelconfig.NewClient(apiCaller)
+	overrides, err := client.AgentLoggingOverrides()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(overrides, jc.DeepEquals, expected)
+}
+
+func (s *modelconfigSuite) TestAgentLoggingOverridesNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 1,
+	}
+	client := modelconfig.NewClient(apiCaller)
+	_, err := client.AgentLoggingOverrides()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
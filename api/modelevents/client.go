@@ -0,0 +1,50 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelevents provides a client for the ModelEvents facade,
+// which provides access to a model's activity feed.
+package modelevents
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+// Client provides access to the ModelEvents facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new ModelEvents client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "ModelEvents")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Events returns the page of the model's events selected by args,
+// oldest first.
+func (c *Client) Events(args params.ModelEventsArgs) ([]params.ModelEvent, error) {
+	var result params.ModelEventsResult
+	if err := c.facade.FacadeCall("Events", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Events, nil
+}
+
+// WatchEvents returns a NotifyWatcher that notifies when events are
+// recorded in the model's activity feed.
+func (c *Client) WatchEvents() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	if err := c.facade.FacadeCall("WatchEvents", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), result), nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelevents_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/modelevents"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestEvents(c *gc.C) {
+	expected := []params.ModelEvent{{
+		Seq:      3,
+		Time:     time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC),
+		Kind:     "unit-added",
+		Actor:    "user-admin",
+		Entities: []string{"unit-mysql-1"},
+	}}
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ModelEvents")
+		c.Check(request, gc.Equals, "Events")
+		c.Check(arg, jc.DeepEquals, params.ModelEventsArgs{After: 2, Limit: 10})
+		*(result.(*params.ModelEventsResult)) = params.ModelEventsResult{Events: expected}
+		return nil
+	})
+	events, err := modelevents.NewClient(apiCaller).Events(params.ModelEventsArgs{After: 2, Limit: 10})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, jc.DeepEquals, expected)
+}
+
+func (s *clientSuite) TestEventsError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("kaboom")
+	})
+	_, err := modelevents.NewClient(apiCaller).Events(params.ModelEventsArgs{})
+	c.Assert(err, gc.ErrorMatches, "kaboom")
+}
+
+func (s *clientSuite) TestWatchEventsError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "WatchEvents")
+		*(result.(*params.NotifyWatchResult)) = params.NotifyWatchResult{
+			Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+		}
+		return nil
+	})
+	_, err := modelevents.NewClient(apiCaller).WatchEvents()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
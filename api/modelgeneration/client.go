@@ -0,0 +1,93 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelgeneration provides a client for the ModelGeneration
+// facade, which manages a model's branch of staged configuration
+// changes.
+package modelgeneration
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the ModelGeneration facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new ModelGeneration client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "ModelGeneration")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// AddBranch adds a branch with the given name to the model.
+func (c *Client) AddBranch(branchName string) error {
+	return c.branchCall("AddBranch", branchName)
+}
+
+// TrackBranch makes the given units, or all of the units of the given
+// applications, track the branch.
+func (c *Client) TrackBranch(branchName string, entities []names.Tag) error {
+	arg := params.BranchTrackArg{
+		BranchName: branchName,
+		Entities:   make([]params.Entity, len(entities)),
+	}
+	for i, tag := range entities {
+		arg.Entities[i].Tag = tag.String()
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("TrackBranch", arg, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.Combine()
+}
+
+// SetBranchConfig changes the application's charm configuration on the
+// branch, setting the given options and resetting those unset to the
+// charm's defaults.
+func (c *Client) SetBranchConfig(branchName, appName string, options map[string]string, unset []string) error {
+	arg := params.BranchConfigArg{
+		BranchName:      branchName,
+		ApplicationName: appName,
+		Options:         options,
+		Unset:           unset,
+	}
+	var result params.ErrorResult
+	if err := c.facade.FacadeCall("SetBranchConfig", arg, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return errors.Trace(result.Error)
+	}
+	return nil
+}
+
+// CommitBranch applies the configuration changes made on the branch to
+// all units, and removes the branch.
+func (c *Client) CommitBranch(branchName string) error {
+	return c.branchCall("CommitBranch", branchName)
+}
+
+// AbortBranch removes the branch, discarding the configuration changes
+// made on it.
+func (c *Client) AbortBranch(branchName string) error {
+	return c.branchCall("AbortBranch", branchName)
+}
+
+func (c *Client) branchCall(method, branchName string) error {
+	var result params.ErrorResult
+	err := c.facade.FacadeCall(method, params.BranchArg{BranchName: branchName}, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return errors.Trace(result.Error)
+	}
+	return nil
+}
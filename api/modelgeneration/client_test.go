@@ -0,0 +1,110 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelgeneration_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/modelgeneration"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestAddBranch(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ModelGeneration")
+		c.Check(request, gc.Equals, "AddBranch")
+		c.Check(arg, jc.DeepEquals, params.BranchArg{BranchName: "canary"})
+		*(result.(*params.ErrorResult)) = params.ErrorResult{}
+		return nil
+	})
+	err := modelgeneration.NewClient(apiCaller).AddBranch("canary")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *clientSuite) TestAddBranchError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.ErrorResult)) = params.ErrorResult{
+			Error: &params.Error{Message: `branch "canary" already exists`},
+		}
+		return nil
+	})
+	err := modelgeneration.NewClient(apiCaller).AddBranch("canary")
+	c.Assert(err, gc.ErrorMatches, `branch "canary" already exists`)
+}
+
+func (s *clientSuite) TestTrackBranch(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ModelGeneration")
+		c.Check(request, gc.Equals, "TrackBranch")
+		c.Check(arg, jc.DeepEquals, params.BranchTrackArg{
+			BranchName: "canary",
+			Entities: []params.Entity{
+				{Tag: "unit-wordpress-0"},
+				{Tag: "application-mysql"},
+			},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}, {Error: &params.Error{Message: "kaboom"}}},
+		}
+		return nil
+	})
+	err := modelgeneration.NewClient(apiCaller).TrackBranch("canary", []names.Tag{
+		names.NewUnitTag("wordpress/0"),
+		names.NewApplicationTag("mysql"),
+	})
+	c.Assert(err, gc.ErrorMatches, "kaboom")
+}
+
+func (s *clientSuite) TestSetBranchConfig(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ModelGeneration")
+		c.Check(request, gc.Equals, "SetBranchConfig")
+		c.Check(arg, jc.DeepEquals, params.BranchConfigArg{
+			BranchName:      "canary",
+			ApplicationName: "wordpress",
+			Options:         map[string]string{"blog-title": "canary title"},
+			Unset:           []string{"skill-level"},
+		})
+		*(result.(*params.ErrorResult)) = params.ErrorResult{}
+		return nil
+	})
+	err := modelgeneration.NewClient(apiCaller).SetBranchConfig(
+		"canary", "wordpress",
+		map[string]string{"blog-title": "canary title"},
+		[]string{"skill-level"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *clientSuite) TestCommitBranch(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "CommitBranch")
+		c.Check(arg, jc.DeepEquals, params.BranchArg{BranchName: "canary"})
+		*(result.(*params.ErrorResult)) = params.ErrorResult{}
+		return nil
+	})
+	err := modelgeneration.NewClient(apiCaller).CommitBranch("canary")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *clientSuite) TestAbortBranch(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "AbortBranch")
+		c.Check(arg, jc.DeepEquals, params.BranchArg{BranchName: "canary"})
+		*(result.(*params.ErrorResult)) = params.ErrorResult{}
+		return nil
+	})
+	err := modelgeneration.NewClient(apiCaller).AbortBranch("canary")
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -11,10 +11,12 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/common"
+	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/permission"
+	"github.com/juju/juju/watcher"
 )
 
 var logger = loggo.GetLogger("juju.api.modelmanager")
@@ -359,12 +361,13 @@ func (c *Client) DumpModelDB(model names.ModelTag) (map[string]interface{}, erro
 
 // DestroyModel puts the specified model into a "dying" state, which will
 // cause the model's resources to be cleaned up, after which the model will
-// be removed.
-func (c *Client) DestroyModel(tag names.ModelTag, destroyStorage *bool) error {
+// be removed. It returns the identifier of the operation destroying the
+// model, which is empty if the controller does not report it.
+func (c *Client) DestroyModel(tag names.ModelTag, destroyStorage *bool) (string, error) {
 	var args interface{}
 	if c.BestAPIVersion() < 4 {
 		if destroyStorage == nil || !*destroyStorage {
-			return errors.New("this Juju controller requires destroyStorage to be true")
+			return "", errors.New("this Juju controller requires destroyStorage to be true")
 		}
 		args = params.Entities{Entities: []params.Entity{{Tag: tag.String()}}}
 	} else {
@@ -375,17 +378,94 @@ func (c *Client) DestroyModel(tag names.ModelTag, destroyStorage *bool) error {
 			}},
 		}
 	}
-	var results params.ErrorResults
+	if c.BestAPIVersion() < 5 {
+		var results params.ErrorResults
+		if err := c.facade.FacadeCall("DestroyModels", args, &results); err != nil {
+			return "", errors.Trace(err)
+		}
+		if n := len(results.Results); n != 1 {
+			return "", errors.Errorf("expected 1 result, got %d", n)
+		}
+		if err := results.Results[0].Error; err != nil {
+			return "", errors.Trace(err)
+		}
+		return "", nil
+	}
+	var results params.DestroyModelResults
 	if err := c.facade.FacadeCall("DestroyModels", args, &results); err != nil {
-		return errors.Trace(err)
+		return "", errors.Trace(err)
 	}
 	if n := len(results.Results); n != 1 {
-		return errors.Errorf("expected 1 result, got %d", n)
+		return "", errors.Errorf("expected 1 result, got %d", n)
 	}
 	if err := results.Results[0].Error; err != nil {
-		return errors.Trace(err)
+		return "", errors.Trace(err)
+	}
+	return results.Results[0].OperationId, nil
+}
+
+// DestroyProgress reports how far the destruction of the specified
+// model has progressed, including the entities in an error state that
+// may be preventing its removal.
+func (c *Client) DestroyProgress(tag names.ModelTag) (params.ModelDestroyProgress, error) {
+	if c.BestAPIVersion() < 5 {
+		return params.ModelDestroyProgress{}, errors.NotSupportedf("DestroyProgress")
+	}
+	args := params.Entities{Entities: []params.Entity{{Tag: tag.String()}}}
+	var results params.ModelDestroyProgressResults
+	if err := c.facade.FacadeCall("DestroyProgress", args, &results); err != nil {
+		return params.ModelDestroyProgress{}, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return params.ModelDestroyProgress{}, errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.ModelDestroyProgress{}, errors.Trace(result.Error)
+	}
+	return *result.Result, nil
+}
+
+// WatchDestroyProgress returns a NotifyWatcher that triggers whenever
+// the destroy progress of the specified model may have changed.
+func (c *Client) WatchDestroyProgress(tag names.ModelTag) (watcher.NotifyWatcher, error) {
+	if c.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("WatchDestroyProgress")
+	}
+	args := params.Entities{Entities: []params.Entity{{Tag: tag.String()}}}
+	var results params.NotifyWatchResults
+	if err := c.facade.FacadeCall("WatchDestroyProgress", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), result), nil
+}
+
+// ModelUserActivity returns the API activity of each user of the
+// specified model, including users who have never used the model.
+func (c *Client) ModelUserActivity(tag names.ModelTag) ([]params.ModelUserActivity, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("ModelUserActivity")
+	}
+	args := params.Entities{Entities: []params.Entity{{Tag: tag.String()}}}
+	var results params.ModelUserActivityResults
+	if err := c.facade.FacadeCall("ModelUserActivity", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
 	}
-	return nil
+	return result.Activity, nil
 }
 
 // GrantModel grants a user access to the specified models.
@@ -186,11 +186,138 @@ func (s *modelmanagerSuite) testDestroyModel(c *gc.C, destroyStorage *bool) {
 		),
 	}
 	client := modelmanager.NewClient(apiCaller)
-	err := client.DestroyModel(coretesting.ModelTag, destroyStorage)
+	operationId, err := client.DestroyModel(coretesting.ModelTag, destroyStorage)
 	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operationId, gc.Equals, "")
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *modelmanagerSuite) TestDestroyModelV5(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 5,
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, req string,
+				args, resp interface{},
+			) error {
+				c.Check(objType, gc.Equals, "ModelManager")
+				c.Check(id, gc.Equals, "")
+				c.Check(req, gc.Equals, "DestroyModels")
+				c.Check(args, jc.DeepEquals, params.DestroyModelsParams{
+					Models: []params.DestroyModelParams{{
+						ModelTag: coretesting.ModelTag.String(),
+					}},
+				})
+				results := resp.(*params.DestroyModelResults)
+				*results = params.DestroyModelResults{
+					Results: []params.DestroyModelResult{{OperationId: "deadbeef"}},
+				}
+				called = true
+				return nil
+			},
+		),
+	}
+	client := modelmanager.NewClient(apiCaller)
+	operationId, err := client.DestroyModel(coretesting.ModelTag, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operationId, gc.Equals, "deadbeef")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *modelmanagerSuite) TestDestroyProgress(c *gc.C) {
+	expected := params.ModelDestroyProgress{
+		ModelTag:     coretesting.ModelTag.String(),
+		OperationId:  "deadbeef",
+		Life:         params.Dying,
+		MachineCount: 1,
+		Blocking: []params.DestroyBlockingEntity{{
+			Tag:     "unit-mysql-0",
+			Life:    params.Alive,
+			Status:  "error",
+			Message: `hook failed: "stop"`,
+		}},
+	}
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 5,
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, req string,
+				args, resp interface{},
+			) error {
+				c.Check(objType, gc.Equals, "ModelManager")
+				c.Check(id, gc.Equals, "")
+				c.Check(req, gc.Equals, "DestroyProgress")
+				c.Check(args, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{coretesting.ModelTag.String()}},
+				})
+				results := resp.(*params.ModelDestroyProgressResults)
+				*results = params.ModelDestroyProgressResults{
+					Results: []params.ModelDestroyProgressResult{{Result: &expected}},
+				}
+				return nil
+			},
+		),
+	}
+	client := modelmanager.NewClient(apiCaller)
+	progress, err := client.DestroyProgress(coretesting.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(progress, jc.DeepEquals, expected)
+}
+
+func (s *modelmanagerSuite) TestDestroyProgressNotSupported(c *gc.C) {
+	client := modelmanager.NewClient(basetesting.BestVersionCaller{BestVersion: 4})
+	_, err := client.DestroyProgress(coretesting.ModelTag)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = client.WatchDestroyProgress(coretesting.ModelTag)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *modelmanagerSuite) TestModelUserActivity(c *gc.C) {
+	expected := []params.ModelUserActivity{{
+		UserTag:  "user-admin",
+		ModelTag: coretesting.ModelTag.String(),
+		APICalls: 7,
+	}, {
+		UserTag:  "user-dormant",
+		ModelTag: coretesting.ModelTag.String(),
+	}}
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 6,
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, req string,
+				args, resp interface{},
+			) error {
+				c.Check(objType, gc.Equals, "ModelManager")
+				c.Check(id, gc.Equals, "")
+				c.Check(req, gc.Equals, "ModelUserActivity")
+				c.Check(args, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{coretesting.ModelTag.String()}},
+				})
+				results := resp.(*params.ModelUserActivityResults)
+				*results = params.ModelUserActivityResults{
+					Results: []params.ModelUserActivityResult{{Activity: expected}},
+				}
+				return nil
+			},
+		),
+	}
+	client := modelmanager.NewClient(apiCaller)
+	activity, err := client.ModelUserActivity(coretesting.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(activity, jc.DeepEquals, expected)
+}
+
+func (s *modelmanagerSuite) TestModelUserActivityNotSupported(c *gc.C) {
+	client := modelmanager.NewClient(basetesting.BestVersionCaller{BestVersion: 5})
+	_, err := client.ModelUserActivity(coretesting.ModelTag)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *modelmanagerSuite) TestDestroyModelV3(c *gc.C) {
 	var called bool
 	apiCaller := basetesting.APICallerFunc(
@@ -215,7 +342,7 @@ func (s *modelmanagerSuite) TestDestroyModelV3(c *gc.C) {
 	)
 	client := modelmanager.NewClient(apiCaller)
 	destroyStorage := true
-	err := client.DestroyModel(coretesting.ModelTag, &destroyStorage)
+	_, err := client.DestroyModel(coretesting.ModelTag, &destroyStorage)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(called, jc.IsTrue)
 }
@@ -223,7 +350,7 @@ func (s *modelmanagerSuite) TestDestroyModelV3(c *gc.C) {
 func (s *modelmanagerSuite) TestDestroyModelV3DestroyStorageNotTrue(c *gc.C) {
 	client := modelmanager.NewClient(basetesting.BestVersionCaller{})
 	for _, destroyStorage := range []*bool{nil, new(bool)} {
-		err := client.DestroyModel(coretesting.ModelTag, destroyStorage)
+		_, err := client.DestroyModel(coretesting.ModelTag, destroyStorage)
 		c.Assert(err, gc.ErrorMatches, "this Juju controller requires destroyStorage to be true")
 	}
 }
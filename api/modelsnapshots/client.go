@@ -0,0 +1,51 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelsnapshots provides a client for the ModelSnapshots
+// facade, which shows how a model has changed between the snapshots of
+// its description taken by the controller.
+package modelsnapshots
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the ModelSnapshots facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new ModelSnapshots client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "ModelSnapshots")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Snapshots returns the snapshots held for the model, oldest first.
+func (c *Client) Snapshots() ([]params.ModelSnapshot, error) {
+	var result params.ModelSnapshotsResult
+	if err := c.facade.FacadeCall("ModelSnapshots", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Snapshots, nil
+}
+
+// Diff returns a unified diff between the versions of the model
+// selected by args, along with the snapshots that were diffed.
+func (c *Client) Diff(args params.DiffModelSnapshotsArgs) (params.DiffModelSnapshotsResult, error) {
+	var result params.DiffModelSnapshotsResult
+	if err := c.facade.FacadeCall("DiffModelSnapshots", args, &result); err != nil {
+		return params.DiffModelSnapshotsResult{}, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return params.DiffModelSnapshotsResult{}, errors.Trace(result.Error)
+	}
+	return result, nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelsnapshots_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/modelsnapshots"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestSnapshots(c *gc.C) {
+	expected := []params.ModelSnapshot{{
+		Id:    1,
+		Taken: time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC),
+	}}
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ModelSnapshots")
+		c.Check(request, gc.Equals, "ModelSnapshots")
+		c.Check(arg, gc.IsNil)
+		*(result.(*params.ModelSnapshotsResult)) = params.ModelSnapshotsResult{Snapshots: expected}
+		return nil
+	})
+	snapshots, err := modelsnapshots.NewClient(apiCaller).Snapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, jc.DeepEquals, expected)
+}
+
+func (s *clientSuite) TestSnapshotsError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.ModelSnapshotsResult)) = params.ModelSnapshotsResult{
+			Error: &params.Error{Message: "kaboom"},
+		}
+		return nil
+	})
+	_, err := modelsnapshots.NewClient(apiCaller).Snapshots()
+	c.Assert(err, gc.ErrorMatches, "kaboom")
+}
+
+func (s *clientSuite) TestDiff(c *gc.C) {
+	expected := params.DiffModelSnapshotsResult{
+		From: &params.ModelSnapshot{Id: 1},
+		Diff: "--- snapshot 1\n+++ live model\n",
+	}
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ModelSnapshots")
+		c.Check(request, gc.Equals, "DiffModelSnapshots")
+		c.Check(arg, jc.DeepEquals, params.DiffModelSnapshotsArgs{From: 1})
+		*(result.(*params.DiffModelSnapshotsResult)) = expected
+		return nil
+	})
+	result, err := modelsnapshots.NewClient(apiCaller).Diff(params.DiffModelSnapshotsArgs{From: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, expected)
+}
+
+func (s *clientSuite) TestDiffError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.DiffModelSnapshotsResult)) = params.DiffModelSnapshotsResult{
+			Error: &params.Error{Message: "model snapshot 3 not found", Code: params.CodeNotFound},
+		}
+		return nil
+	})
+	_, err := modelsnapshots.NewClient(apiCaller).Diff(params.DiffModelSnapshotsArgs{From: 3})
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+}
@@ -0,0 +1,36 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelsnapshotter
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+const apiName = "ModelSnapshotter"
+
+// Facade provides access to the ModelSnapshotter API facade.
+type Facade struct {
+	facade base.FacadeCaller
+}
+
+// NewFacade returns a new client-side ModelSnapshotter facade.
+func NewFacade(caller base.APICaller) *Facade {
+	return &Facade{facade: base.NewFacadeCaller(caller, apiName)}
+}
+
+// TakeModelSnapshot records the model's current description, if it
+// has changed since the last snapshot was taken.
+func (f *Facade) TakeModelSnapshot() error {
+	var result params.ErrorResult
+	if err := f.facade.FacadeCall("TakeModelSnapshot", nil, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return errors.Trace(result.Error)
+	}
+	return nil
+}
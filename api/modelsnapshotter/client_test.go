@@ -0,0 +1,46 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelsnapshotter_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/modelsnapshotter"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestTakeModelSnapshot(c *gc.C) {
+	called := false
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ModelSnapshotter")
+		c.Check(request, gc.Equals, "TakeModelSnapshot")
+		c.Check(arg, gc.IsNil)
+		c.Check(result, gc.FitsTypeOf, &params.ErrorResult{})
+		called = true
+		return nil
+	})
+	err := modelsnapshotter.NewFacade(apiCaller).TakeModelSnapshot()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *clientSuite) TestTakeModelSnapshotError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.ErrorResult)) = params.ErrorResult{
+			Error: &params.Error{Message: "kaboom"},
+		}
+		return nil
+	})
+	err := modelsnapshotter.NewFacade(apiCaller).TakeModelSnapshot()
+	c.Assert(err, gc.ErrorMatches, "kaboom")
+}
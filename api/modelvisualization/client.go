@@ -0,0 +1,36 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelvisualization provides a client for the
+// ModelVisualization facade, which renders the topology of a model as
+// Graphviz DOT or Mermaid text.
+package modelvisualization
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the ModelVisualization facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new ModelVisualization client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "ModelVisualization")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Render returns the topology of the model, rendered as described by
+// args.
+func (c *Client) Render(args params.ModelVisualizationArgs) (string, error) {
+	var result params.ModelVisualizationResult
+	if err := c.facade.FacadeCall("Render", args, &result); err != nil {
+		return "", errors.Trace(err)
+	}
+	return result.Content, nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelvisualization_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/modelvisualization"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestRender(c *gc.C) {
+	args := params.ModelVisualizationArgs{
+		Format:        "mermaid",
+		Applications:  []string{"wordpress"},
+		IncludeSpaces: true,
+	}
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ModelVisualization")
+		c.Check(request, gc.Equals, "Render")
+		c.Check(arg, jc.DeepEquals, args)
+		*(result.(*params.ModelVisualizationResult)) = params.ModelVisualizationResult{
+			Format:  "mermaid",
+			Content: "graph LR\n",
+		}
+		return nil
+	})
+	content, err := modelvisualization.NewClient(apiCaller).Render(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(content, gc.Equals, "graph LR\n")
+}
+
+func (s *clientSuite) TestRenderError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("boom")
+	})
+	_, err := modelvisualization.NewClient(apiCaller).Render(params.ModelVisualizationArgs{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,70 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package orphansweeper
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+const apiName = "OrphanSweeper"
+
+// Orphans holds the results of a sweep of a model's provider
+// resources.
+type Orphans struct {
+	Swept          time.Time
+	Instances      []string
+	Volumes        []string
+	SecurityGroups []string
+	Cleaned        []string
+}
+
+// Facade provides access to the OrphanSweeper API facade.
+type Facade struct {
+	facade base.FacadeCaller
+}
+
+// NewFacade returns a new client-side OrphanSweeper facade.
+func NewFacade(caller base.APICaller) *Facade {
+	return &Facade{facade: base.NewFacadeCaller(caller, apiName)}
+}
+
+// TrackedResources returns the provider ids of the instances and
+// volumes recorded in the model.
+func (f *Facade) TrackedResources() (instanceIds, volumeIds []string, err error) {
+	var result params.TrackedResourcesResult
+	if err := f.facade.FacadeCall("TrackedResources", nil, &result); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, nil, errors.Trace(result.Error)
+	}
+	if result.Result == nil {
+		return nil, nil, errors.New("missing tracked resources")
+	}
+	return result.Result.InstanceIds, result.Result.VolumeIds, nil
+}
+
+// SetOrphanedResources records the results of a sweep.
+func (f *Facade) SetOrphanedResources(orphans Orphans) error {
+	args := params.OrphanedResources{
+		Swept:          orphans.Swept,
+		Instances:      orphans.Instances,
+		Volumes:        orphans.Volumes,
+		SecurityGroups: orphans.SecurityGroups,
+		Cleaned:        orphans.Cleaned,
+	}
+	var result params.ErrorResult
+	if err := f.facade.FacadeCall("SetOrphanedResources", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return errors.Trace(result.Error)
+	}
+	return nil
+}
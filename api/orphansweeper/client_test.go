@@ -0,0 +1,82 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package orphansweeper_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/orphansweeper"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestTrackedResources(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "OrphanSweeper")
+		c.Check(request, gc.Equals, "TrackedResources")
+		c.Check(arg, gc.IsNil)
+		*(result.(*params.TrackedResourcesResult)) = params.TrackedResourcesResult{
+			Result: &params.TrackedResources{
+				InstanceIds: []string{"i-0"},
+				VolumeIds:   []string{"vol-0"},
+			},
+		}
+		return nil
+	})
+	instanceIds, volumeIds, err := orphansweeper.NewFacade(apiCaller).TrackedResources()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instanceIds, jc.DeepEquals, []string{"i-0"})
+	c.Assert(volumeIds, jc.DeepEquals, []string{"vol-0"})
+}
+
+func (s *clientSuite) TestTrackedResourcesError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.TrackedResourcesResult)) = params.TrackedResourcesResult{
+			Error: &params.Error{Message: "kaboom"},
+		}
+		return nil
+	})
+	_, _, err := orphansweeper.NewFacade(apiCaller).TrackedResources()
+	c.Assert(err, gc.ErrorMatches, "kaboom")
+}
+
+func (s *clientSuite) TestSetOrphanedResources(c *gc.C) {
+	swept := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "OrphanSweeper")
+		c.Check(request, gc.Equals, "SetOrphanedResources")
+		c.Check(arg, jc.DeepEquals, params.OrphanedResources{
+			Swept:     swept,
+			Instances: []string{"i-0"},
+			Cleaned:   []string{"i-0"},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResult{})
+		return nil
+	})
+	err := orphansweeper.NewFacade(apiCaller).SetOrphanedResources(orphansweeper.Orphans{
+		Swept:     swept,
+		Instances: []string{"i-0"},
+		Cleaned:   []string{"i-0"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *clientSuite) TestSetOrphanedResourcesCallError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("kaboom")
+	})
+	err := orphansweeper.NewFacade(apiCaller).SetOrphanedResources(orphansweeper.Orphans{Swept: time.Now()})
+	c.Assert(err, gc.ErrorMatches, "kaboom")
+}
@@ -12,6 +12,7 @@ import (
 
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/watcher"
@@ -271,10 +272,11 @@ func (m *Machine) DistributionGroup() ([]instance.Id, error) {
 	return result.Result, nil
 }
 
-// SetInstanceInfo sets the provider specific instance id, nonce, metadata,
-// network config for this machine. Once set, the instance id cannot be changed.
+// SetInstanceInfo sets the provider specific instance id, the provider's
+// display name for the instance, nonce, metadata, network config for this
+// machine. Once set, the instance id cannot be changed.
 func (m *Machine) SetInstanceInfo(
-	id instance.Id, nonce string, characteristics *instance.HardwareCharacteristics,
+	id instance.Id, displayName, nonce string, characteristics *instance.HardwareCharacteristics,
 	networkConfig []params.NetworkConfig, volumes []params.Volume,
 	volumeAttachments map[string]params.VolumeAttachmentInfo,
 ) error {
@@ -283,6 +285,7 @@ func (m *Machine) SetInstanceInfo(
 		Machines: []params.InstanceInfo{{
 			Tag:               m.tag.String(),
 			InstanceId:        id,
+			DisplayName:       displayName,
 			Nonce:             nonce,
 			Characteristics:   characteristics,
 			Volumes:           volumes,
@@ -444,3 +447,62 @@ func (m *Machine) SetSupportedContainers(containerTypes ...instance.ContainerTyp
 func (m *Machine) SupportsNoContainers() error {
 	return m.SetSupportedContainers([]instance.ContainerType{}...)
 }
+
+// CharmProfiles returns the charm LXD profiles, keyed on name, that the
+// machine's container should have, and the names of those that have
+// been applied to it.
+func (m *Machine) CharmProfiles() (map[string]lxdprofile.Profile, []string, error) {
+	if m.st.facade.BestAPIVersion() < 6 {
+		return nil, nil, errors.NotSupportedf("charm profiles")
+	}
+	var results params.CharmProfilesResults
+	args := params.Entities{Entities: []params.Entity{{m.tag.String()}}}
+	err := m.st.facade.FacadeCall("CharmProfiles", args, &results)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, nil, result.Error
+	}
+	return CharmProfilesFromParams(result.Profiles), result.Applied, nil
+}
+
+// SetCharmProfiles records the names of the charm LXD profiles applied
+// to the machine's container.
+func (m *Machine) SetCharmProfiles(profiles []string) error {
+	if m.st.facade.BestAPIVersion() < 6 {
+		return errors.NotSupportedf("charm profiles")
+	}
+	var results params.ErrorResults
+	args := params.SetCharmProfilesArgs{
+		Args: []params.SetCharmProfilesArg{
+			{Entity: params.Entity{Tag: m.tag.String()}, Profiles: profiles},
+		},
+	}
+	err := m.st.facade.FacadeCall("SetCharmProfiles", args, &results)
+	if err != nil {
+		return err
+	}
+	return results.OneError()
+}
+
+// CharmProfilesFromParams converts charm LXD profiles, keyed on name,
+// from their API representation.
+func CharmProfilesFromParams(in map[string]params.LXDProfile) map[string]lxdprofile.Profile {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make(map[string]lxdprofile.Profile, len(in))
+	for name, profile := range in {
+		out[name] = lxdprofile.Profile{
+			Config:      profile.Config,
+			Description: profile.Description,
+			Devices:     profile.Devices,
+		}
+	}
+	return out
+}
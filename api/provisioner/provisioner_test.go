@@ -67,7 +67,7 @@ func (s *provisionerSuite) SetUpTest(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	err = s.machine.SetPassword(password)
 	c.Assert(err, jc.ErrorIsNil)
-	err = s.machine.SetInstanceInfo("i-manager", "fake_nonce", nil, nil, nil, nil, nil)
+	err = s.machine.SetInstanceInfo("i-manager", "", "fake_nonce", nil, nil, nil, nil, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	s.st = s.OpenAPIAsMachine(c, s.machine.Tag(), password, "fake_nonce")
 	c.Assert(s.st, gc.NotNil)
@@ -300,16 +300,19 @@ func (s *provisionerSuite) TestSetInstanceInfo(c *gc.C) {
 	}
 
 	err = apiMachine.SetInstanceInfo(
-		"i-will", "fake_nonce", &hwChars, nil, volumes, volumeAttachments,
+		"i-will", "will-i", "fake_nonce", &hwChars, nil, volumes, volumeAttachments,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 
 	instanceId, err = apiMachine.InstanceId()
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(instanceId, gc.Equals, instance.Id("i-will"))
+	_, displayName, err := notProvisionedMachine.InstanceNames()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(displayName, gc.Equals, "will-i")
 
 	// Try it again - should fail.
-	err = apiMachine.SetInstanceInfo("i-wont", "fake", nil, nil, nil, nil)
+	err = apiMachine.SetInstanceInfo("i-wont", "", "fake", nil, nil, nil, nil)
 	c.Assert(err, gc.ErrorMatches, `cannot record provisioning info for "i-wont": cannot set instance data for machine "1": already set`)
 
 	// Now try to get machine 0's instance id.
@@ -375,7 +378,7 @@ func (s *provisionerSuite) TestAvailabilityZone(c *gc.C) {
 	hwChars := instance.MustParseHardware(fmt.Sprintf("availability-zone=%s", availabilityZone))
 
 	err = apiMachine.SetInstanceInfo(
-		"azinst", "nonce", &hwChars, nil, nil, nil,
+		"azinst", "", "nonce", &hwChars, nil, nil, nil,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -404,7 +407,7 @@ func (s *provisionerSuite) TestDistributionGroup(c *gc.C) {
 	apiMachine = s.assertGetOneMachine(c, machine1.MachineTag())
 	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
 
-	err = apiMachine.SetInstanceInfo("i-d", "fake", nil, nil, nil, nil)
+	err = apiMachine.SetInstanceInfo("i-d", "", "fake", nil, nil, nil, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	instances, err = apiMachine.DistributionGroup()
 	c.Assert(err, jc.ErrorIsNil)
@@ -704,6 +707,35 @@ func (s *provisionerSuite) TestSupportsNoContainers(c *gc.C) {
 	c.Assert(containers, gc.DeepEquals, []instance.ContainerType{})
 }
 
+func (s *provisionerSuite) TestCharmProfiles(c *gc.C) {
+	container, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, s.machine.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.AddTestingApplication(c, "lxd-profile", s.AddTestingCharm(c, "lxd-profile"))
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(container)
+	c.Assert(err, jc.ErrorIsNil)
+
+	apiMachine := s.assertGetOneMachine(c, container.MachineTag())
+	err = apiMachine.SetCharmProfiles([]string{"juju-old-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = container.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(container.CharmProfiles(), jc.DeepEquals, []string{"juju-old-0"})
+
+	profiles, applied, err := apiMachine.CharmProfiles()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(applied, jc.DeepEquals, []string{"juju-old-0"})
+	c.Assert(profiles, gc.HasLen, 1)
+	profile, ok := profiles["juju-"+s.IAASModel.Name()+"-lxd-profile-0"]
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(profile.Config["security.nesting"], gc.Equals, "true")
+	c.Assert(profile.Devices["sriov"]["nictype"], gc.Equals, "sriov")
+}
+
 func (s *provisionerSuite) TestFindToolsNoArch(c *gc.C) {
 	s.testFindTools(c, false, nil, nil)
 }
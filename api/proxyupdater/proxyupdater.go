@@ -73,21 +73,64 @@ func proxySettingsParamToProxySettings(cfg params.ProxyConfig) proxy.Settings {
 	}
 }
 
+// ProxyConfiguration holds the proxy settings an agent applies to its
+// machine.
+type ProxyConfiguration struct {
+	// Proxy holds the proxy settings for the machine's environment.
+	Proxy proxy.Settings
+
+	// APTProxy holds the proxy settings for apt.
+	APTProxy proxy.Settings
+
+	// SnapProxy holds the proxy settings for snapd.
+	SnapProxy proxy.Settings
+
+	// SnapStoreProxyId holds the id of the snap store proxy snapd
+	// should use, if any.
+	SnapStoreProxyId string
+}
+
 // ProxyConfig returns the proxy settings for the current environment
-func (api *API) ProxyConfig() (proxySettings, APTProxySettings proxy.Settings, err error) {
+func (api *API) ProxyConfig() (ProxyConfiguration, error) {
 	var results params.ProxyConfigResults
 	args := params.Entities{
 		Entities: []params.Entity{{Tag: api.tag.String()}},
 	}
-	err = api.facade.FacadeCall("ProxyConfig", args, &results)
+	err := api.facade.FacadeCall("ProxyConfig", args, &results)
 	if err != nil {
-		return proxySettings, APTProxySettings, err
+		return ProxyConfiguration{}, err
 	}
 	if len(results.Results) != 1 {
-		return proxySettings, APTProxySettings, errors.NotFoundf("ProxyConfig for %q", api.tag)
+		return ProxyConfiguration{}, errors.NotFoundf("ProxyConfig for %q", api.tag)
 	}
 	result := results.Results[0]
-	proxySettings = proxySettingsParamToProxySettings(result.ProxySettings)
-	APTProxySettings = proxySettingsParamToProxySettings(result.APTProxySettings)
-	return proxySettings, APTProxySettings, nil
+	if result.Error != nil {
+		return ProxyConfiguration{}, result.Error
+	}
+	return ProxyConfiguration{
+		Proxy:            proxySettingsParamToProxySettings(result.ProxySettings),
+		APTProxy:         proxySettingsParamToProxySettings(result.APTProxySettings),
+		SnapProxy:        proxySettingsParamToProxySettings(result.SnapProxySettings),
+		SnapStoreProxyId: result.SnapStoreProxyId,
+	}, nil
+}
+
+// SetProxyStatus records the outcome of applying the proxy settings to
+// the agent's machine; applyErr is nil if they were applied. Only
+// machine agents record their status, and controllers that cannot
+// record it are not told; in both cases nothing is done.
+func (api *API) SetProxyStatus(applyErr error) error {
+	if api.tag.Kind() != names.MachineTagKind || api.facade.BestAPIVersion() < 2 {
+		return nil
+	}
+	arg := params.ProxyStatusArg{Tag: api.tag.String()}
+	if applyErr != nil {
+		arg.Error = applyErr.Error()
+	}
+	var results params.ErrorResults
+	args := params.ProxyStatusArgs{Args: []params.ProxyStatusArg{arg}}
+	if err := api.facade.FacadeCall("SetProxyStatus", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
 }
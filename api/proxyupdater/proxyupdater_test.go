@@ -4,6 +4,7 @@
 package proxyupdater_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/proxy"
 	gc "gopkg.in/check.v1"
@@ -91,6 +92,11 @@ func (s *ProxyUpdaterSuite) TestProxyConfig(c *gc.C) {
 			FTP:     "ftp-apt",
 			NoProxy: "NoProxy-apt",
 		},
+		SnapProxySettings: params.ProxyConfig{
+			HTTP:  "http-snap",
+			HTTPS: "https-snap",
+		},
+		SnapStoreProxyId: "store-id",
 	}
 
 	called, api := newAPI(c, apitesting.APICall{
@@ -101,19 +107,80 @@ func (s *ProxyUpdaterSuite) TestProxyConfig(c *gc.C) {
 		},
 	})
 
-	proxySettings, APTProxySettings, err := api.ProxyConfig()
+	config, err := api.ProxyConfig()
 	c.Assert(*called, gc.Equals, 1)
 	c.Assert(err, jc.ErrorIsNil)
-	c.Check(proxySettings, jc.DeepEquals, proxy.Settings{
-		Http:    "http",
-		Https:   "https",
-		Ftp:     "ftp",
-		NoProxy: "NoProxy",
+	c.Check(config, jc.DeepEquals, proxyupdater.ProxyConfiguration{
+		Proxy: proxy.Settings{
+			Http:    "http",
+			Https:   "https",
+			Ftp:     "ftp",
+			NoProxy: "NoProxy",
+		},
+		APTProxy: proxy.Settings{
+			Http:    "http-apt",
+			Https:   "https-apt",
+			Ftp:     "ftp-apt",
+			NoProxy: "NoProxy-apt",
+		},
+		SnapProxy: proxy.Settings{
+			Http:  "http-snap",
+			Https: "https-snap",
+		},
+		SnapStoreProxyId: "store-id",
+	})
+}
+
+func (s *ProxyUpdaterSuite) TestProxyConfigError(c *gc.C) {
+	_, api := newAPI(c, apitesting.APICall{
+		Facade: "ProxyUpdater",
+		Method: "ProxyConfig",
+		Results: params.ProxyConfigResults{
+			Results: []params.ProxyConfigResult{{
+				Error: &params.Error{Message: "permission denied"},
+			}},
+		},
 	})
-	c.Check(APTProxySettings, jc.DeepEquals, proxy.Settings{
-		Http:    "http-apt",
-		Https:   "https-apt",
-		Ftp:     "ftp-apt",
-		NoProxy: "NoProxy-apt",
+	_, err := api.ProxyConfig()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *ProxyUpdaterSuite) TestSetProxyStatus(c *gc.C) {
+	checker := apitesting.APICallChecker(c, apitesting.APICall{
+		Facade: "ProxyUpdater",
+		Method: "SetProxyStatus",
+		Args: params.ProxyStatusArgs{
+			Args: []params.ProxyStatusArg{{Tag: "machine-0", Error: "boom"}},
+		},
+		Results: params.ErrorResults{Results: []params.ErrorResult{{}}},
 	})
+	api, err := proxyupdater.NewAPI(apitesting.BestVersionCaller{
+		APICallerFunc: checker.APICallerFunc,
+		BestVersion:   2,
+	}, names.NewMachineTag("0"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = api.SetProxyStatus(errors.New("boom"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checker.CallCount, gc.Equals, 1)
+}
+
+func (s *ProxyUpdaterSuite) TestSetProxyStatusNotSupported(c *gc.C) {
+	checker := apitesting.APICallChecker(c)
+	api, err := proxyupdater.NewAPI(apitesting.BestVersionCaller{
+		APICallerFunc: checker.APICallerFunc,
+		BestVersion:   1,
+	}, names.NewMachineTag("0"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = api.SetProxyStatus(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checker.CallCount, gc.Equals, 0)
+}
+
+func (s *ProxyUpdaterSuite) TestSetProxyStatusUnitAgent(c *gc.C) {
+	called, api := newAPI(c)
+	err := api.SetProxyStatus(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*called, gc.Equals, 0)
 }
@@ -0,0 +1,36 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package pubsubtopology provides a client for the PubSubTopology
+// facade, which reports how pubsub messages are forwarded between the
+// controller's API servers.
+package pubsubtopology
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the PubSubTopology facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new PubSubTopology client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "PubSubTopology")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Topology returns the status of forwarding between each pair of the
+// controller's API servers.
+func (c *Client) Topology() (params.PubSubTopology, error) {
+	var result params.PubSubTopology
+	if err := c.facade.FacadeCall("Topology", nil, &result); err != nil {
+		return params.PubSubTopology{}, errors.Trace(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package pubsubtopology_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/pubsubtopology"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestTopology(c *gc.C) {
+	expected := params.PubSubTopology{
+		Servers: []params.PubSubServer{{
+			Tag: "machine-0",
+			Targets: []params.PubSubTarget{{
+				Tag:       "machine-1",
+				Connected: true,
+				SentCount: 3,
+			}},
+		}},
+	}
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "PubSubTopology")
+		c.Check(request, gc.Equals, "Topology")
+		c.Check(arg, gc.IsNil)
+		*(result.(*params.PubSubTopology)) = expected
+		return nil
+	})
+	result, err := pubsubtopology.NewClient(apiCaller).Topology()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, expected)
+}
+
+func (s *clientSuite) TestTopologyError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("kaboom")
+	})
+	_, err := pubsubtopology.NewClient(apiCaller).Topology()
+	c.Assert(err, gc.ErrorMatches, "kaboom")
+}
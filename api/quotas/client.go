@@ -0,0 +1,45 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package quotas provides a client for the Quotas facade, which
+// reports and sets the limits on the resources a model may use.
+package quotas
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the Quotas facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new Quotas client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "Quotas")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// ModelQuotas returns the model's quotas and its current usage of the
+// limited resources.
+func (c *Client) ModelQuotas() (params.ModelQuotas, params.ModelResources, error) {
+	var result params.ModelQuotasResult
+	if err := c.facade.FacadeCall("ModelQuotas", nil, &result); err != nil {
+		return params.ModelQuotas{}, params.ModelResources{}, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return params.ModelQuotas{}, params.ModelResources{}, errors.Trace(result.Error)
+	}
+	return result.Quotas, result.Usage, nil
+}
+
+// SetModelQuotas replaces the model's quotas. A zero limit means the
+// resource is not limited.
+func (c *Client) SetModelQuotas(quotas params.ModelQuotas) error {
+	err := c.facade.FacadeCall("SetModelQuotas", quotas, nil)
+	return errors.Trace(err)
+}
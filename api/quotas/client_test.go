@@ -0,0 +1,60 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package quotas_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/quotas"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestModelQuotas(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Quotas")
+		c.Check(request, gc.Equals, "ModelQuotas")
+		c.Check(arg, gc.IsNil)
+		*(result.(*params.ModelQuotasResult)) = params.ModelQuotasResult{
+			Quotas: params.ModelQuotas{Machines: 10},
+			Usage:  params.ModelResources{Machines: 3, Cores: 6},
+		}
+		return nil
+	})
+	q, usage, err := quotas.NewClient(apiCaller).ModelQuotas()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(q, jc.DeepEquals, params.ModelQuotas{Machines: 10})
+	c.Assert(usage, jc.DeepEquals, params.ModelResources{Machines: 3, Cores: 6})
+}
+
+func (s *clientSuite) TestModelQuotasError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.ModelQuotasResult)) = params.ModelQuotasResult{
+			Error: &params.Error{Message: "kaboom"},
+		}
+		return nil
+	})
+	_, _, err := quotas.NewClient(apiCaller).ModelQuotas()
+	c.Assert(err, gc.ErrorMatches, "kaboom")
+}
+
+func (s *clientSuite) TestSetModelQuotas(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Quotas")
+		c.Check(request, gc.Equals, "SetModelQuotas")
+		c.Check(arg, jc.DeepEquals, params.ModelQuotas{Units: 5})
+		return errors.New("permission denied")
+	})
+	err := quotas.NewClient(apiCaller).SetModelQuotas(params.ModelQuotas{Units: 5})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -0,0 +1,282 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sdk
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	csparams "gopkg.in/juju/charmrepo.v2/csclient/params"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/action"
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/charmstore"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// modelAPI holds the methods of api.Client used by the SDK.
+type modelAPI interface {
+	AddCharm(*charm.URL, csparams.Channel) error
+	Status([]string) (*params.FullStatus, error)
+	WatchAll() (allWatcher, error)
+}
+
+// allWatcher holds the methods of api.AllWatcher used by the SDK.
+type allWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+// applicationAPI holds the methods of application.Client used by the SDK.
+type applicationAPI interface {
+	Deploy(application.DeployArgs) error
+	AddUnits(application.AddUnitsParams) ([]string, error)
+}
+
+// actionAPI holds the methods of action.Client used by the SDK.
+type actionAPI interface {
+	Enqueue(params.Actions) (params.ActionResults, error)
+	Actions(params.Entities) (params.ActionResults, error)
+}
+
+// Client provides the operations of the SDK on a single model.
+type Client struct {
+	model       modelAPI
+	application applicationAPI
+	action      actionAPI
+}
+
+// NewClient returns a Client that operates on the model the given API
+// connection is logged into. The caller remains responsible for
+// closing the connection.
+func NewClient(conn api.Connection) *Client {
+	return newClient(
+		modelShim{conn.Client()},
+		application.NewClient(conn),
+		action.NewClient(conn),
+	)
+}
+
+func newClient(model modelAPI, app applicationAPI, actions actionAPI) *Client {
+	return &Client{
+		model:       model,
+		application: app,
+		action:      actions,
+	}
+}
+
+// modelShim adapts api.Client to the modelAPI interface.
+type modelShim struct {
+	*api.Client
+}
+
+// WatchAll is part of the modelAPI interface.
+func (s modelShim) WatchAll() (allWatcher, error) {
+	return s.Client.WatchAll()
+}
+
+// Deploy adds the charm described by args to the model, if necessary,
+// and deploys a new application from it.
+func (c *Client) Deploy(args DeployArgs) error {
+	curl, err := charm.ParseURL(args.CharmURL)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if curl.Revision < 0 {
+		return errors.NotValidf("charm URL %q without revision", args.CharmURL)
+	}
+	series := args.Series
+	if series == "" {
+		series = curl.Series
+	}
+	if series == "" {
+		return errors.NotValidf("charm URL %q without series", args.CharmURL)
+	}
+	appName := args.Application
+	if appName == "" {
+		appName = curl.Name
+	}
+	if !names.IsValidApplication(appName) {
+		return errors.NotValidf("application name %q", appName)
+	}
+	cons, err := constraints.Parse(args.Constraints)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	placement, err := parsePlacement(args.Placement)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var configYAML string
+	if len(args.Config) > 0 {
+		data, err := yaml.Marshal(map[string]interface{}{appName: args.Config})
+		if err != nil {
+			return errors.Annotate(err, "cannot marshal config")
+		}
+		configYAML = string(data)
+	}
+	channel := csparams.Channel(args.Channel)
+	if channel == csparams.NoChannel {
+		channel = csparams.StableChannel
+	}
+
+	if curl.Schema != "local" {
+		if err := c.model.AddCharm(curl, channel); err != nil {
+			return errors.Annotatef(err, "cannot add charm %q", curl)
+		}
+	}
+	err = c.application.Deploy(application.DeployArgs{
+		CharmID: charmstore.CharmID{
+			URL:     curl,
+			Channel: channel,
+		},
+		ApplicationName: appName,
+		Series:          series,
+		NumUnits:        args.NumUnits,
+		ConfigYAML:      configYAML,
+		Cons:            cons,
+		Placement:       placement,
+	})
+	return errors.Annotatef(err, "cannot deploy application %q", appName)
+}
+
+// AddUnit adds units to an existing application, returning the names
+// of the new units.
+func (c *Client) AddUnit(args AddUnitArgs) ([]string, error) {
+	if args.NumUnits < 1 {
+		return nil, errors.NotValidf("adding %d units", args.NumUnits)
+	}
+	placement, err := parsePlacement(args.Placement)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := c.application.AddUnits(application.AddUnitsParams{
+		ApplicationName: args.Application,
+		NumUnits:        args.NumUnits,
+		Placement:       placement,
+	})
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot add units to application %q", args.Application)
+	}
+	return units, nil
+}
+
+// Status returns the status of the model. If any patterns are given,
+// only the matching machines, applications and units are included, as
+// with "juju status <pattern>...".
+func (c *Client) Status(patterns ...string) (*ModelStatus, error) {
+	full, err := c.model.Status(patterns)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return convertFullStatus(full), nil
+}
+
+// WatchModel returns a ModelWatcher reporting changes to the model's
+// machines, applications and units. The first call to its Next method
+// reports the current state of every entity.
+func (c *Client) WatchModel() (*ModelWatcher, error) {
+	w, err := c.model.WatchAll()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ModelWatcher{w: w}, nil
+}
+
+// RunAction queues the named action to run on a unit with the given
+// parameters, and returns the id of the queued action. Use
+// Client.ActionResult to find out how the action fared.
+func (c *Client) RunAction(unit, name string, parameters map[string]interface{}) (string, error) {
+	if !names.IsValidUnit(unit) {
+		return "", errors.NotValidf("unit name %q", unit)
+	}
+	results, err := c.action.Enqueue(params.Actions{
+		Actions: []params.Action{{
+			Receiver:   names.NewUnitTag(unit).String(),
+			Name:       name,
+			Parameters: parameters,
+		}},
+	})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return "", errors.Trace(result.Error)
+	}
+	if result.Action == nil {
+		return "", errors.New("action not returned")
+	}
+	tag, err := names.ParseActionTag(result.Action.Tag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return tag.Id(), nil
+}
+
+// ActionResult returns the state of the action with the given id.
+func (c *Client) ActionResult(id string) (*ActionResult, error) {
+	if !names.IsValidAction(id) {
+		return nil, errors.NotValidf("action id %q", id)
+	}
+	results, err := c.action.Actions(params.Entities{
+		Entities: []params.Entity{{Tag: names.NewActionTag(id).String()}},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		if params.IsCodeNotFound(result.Error) {
+			return nil, errors.NotFoundf("action %q", id)
+		}
+		return nil, errors.Trace(result.Error)
+	}
+	return convertActionResult(id, result)
+}
+
+// ModelWatcher reports changes to a model.
+type ModelWatcher struct {
+	w allWatcher
+}
+
+// Next blocks until there are changes to the model, and returns them.
+func (w *ModelWatcher) Next() ([]Delta, error) {
+	deltas, err := w.w.Next()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return convertDeltas(deltas), nil
+}
+
+// Stop stops the watcher. Any blocked call to Next returns an error.
+func (w *ModelWatcher) Stop() error {
+	return errors.Trace(w.w.Stop())
+}
+
+func parsePlacement(directives []string) ([]*instance.Placement, error) {
+	if len(directives) == 0 {
+		return nil, nil
+	}
+	placement := make([]*instance.Placement, len(directives))
+	for i, directive := range directives {
+		p, err := instance.ParsePlacement(directive)
+		if err != nil {
+			return nil, errors.Annotatef(err, "invalid placement %q", directive)
+		}
+		placement[i] = p
+	}
+	return placement, nil
+}
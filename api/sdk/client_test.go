@@ -0,0 +1,362 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sdk
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	csparams "gopkg.in/juju/charmrepo.v2/csclient/params"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+)
+
+type clientSuite struct {
+	model       *fakeModelAPI
+	application *fakeApplicationAPI
+	action      *fakeActionAPI
+	client      *Client
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) SetUpTest(c *gc.C) {
+	s.model = &fakeModelAPI{}
+	s.application = &fakeApplicationAPI{}
+	s.action = &fakeActionAPI{}
+	s.client = newClient(s.model, s.application, s.action)
+}
+
+func (s *clientSuite) TestDeploy(c *gc.C) {
+	err := s.client.Deploy(DeployArgs{
+		CharmURL:    "cs:xenial/mysql-58",
+		Application: "db",
+		NumUnits:    2,
+		Config:      map[string]interface{}{"flavour": "percona"},
+		Constraints: "mem=4G",
+		Placement:   []string{"0", "lxd:1"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	curl := charm.MustParseURL("cs:xenial/mysql-58")
+	c.Assert(s.model.addedCharms, jc.DeepEquals, []*charm.URL{curl})
+	c.Assert(s.model.channel, gc.Equals, csparams.StableChannel)
+	c.Assert(s.application.deployed, gc.HasLen, 1)
+	args := s.application.deployed[0]
+	c.Assert(args.CharmID.URL, jc.DeepEquals, curl)
+	c.Assert(args.CharmID.Channel, gc.Equals, csparams.StableChannel)
+	c.Assert(args.ApplicationName, gc.Equals, "db")
+	c.Assert(args.Series, gc.Equals, "xenial")
+	c.Assert(args.NumUnits, gc.Equals, 2)
+	c.Assert(args.ConfigYAML, gc.Equals, "db:\n  flavour: percona\n")
+	c.Assert(args.Cons, jc.DeepEquals, constraints.MustParse("mem=4G"))
+	c.Assert(args.Placement, jc.DeepEquals, []*instance.Placement{
+		{Scope: instance.MachineScope, Directive: "0"},
+		{Scope: "lxd", Directive: "1"},
+	})
+}
+
+func (s *clientSuite) TestDeployDefaults(c *gc.C) {
+	err := s.client.Deploy(DeployArgs{CharmURL: "cs:xenial/mysql-58"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.application.deployed, gc.HasLen, 1)
+	args := s.application.deployed[0]
+	c.Assert(args.ApplicationName, gc.Equals, "mysql")
+	c.Assert(args.ConfigYAML, gc.Equals, "")
+	c.Assert(args.Placement, gc.IsNil)
+}
+
+func (s *clientSuite) TestDeployLocalCharm(c *gc.C) {
+	err := s.client.Deploy(DeployArgs{CharmURL: "local:xenial/mysql-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.model.addedCharms, gc.HasLen, 0)
+	c.Assert(s.application.deployed, gc.HasLen, 1)
+}
+
+func (s *clientSuite) TestDeployUnresolvedCharmURL(c *gc.C) {
+	err := s.client.Deploy(DeployArgs{CharmURL: "cs:mysql"})
+	c.Assert(err, gc.ErrorMatches, `charm URL "cs:mysql" without revision not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(s.model.addedCharms, gc.HasLen, 0)
+}
+
+func (s *clientSuite) TestDeployAddCharmError(c *gc.C) {
+	s.model.err = errors.New("boom")
+	err := s.client.Deploy(DeployArgs{CharmURL: "cs:xenial/mysql-58"})
+	c.Assert(err, gc.ErrorMatches, `cannot add charm "cs:xenial/mysql-58": boom`)
+	c.Assert(s.application.deployed, gc.HasLen, 0)
+}
+
+func (s *clientSuite) TestAddUnit(c *gc.C) {
+	s.application.units = []string{"mysql/1", "mysql/2"}
+	units, err := s.client.AddUnit(AddUnitArgs{
+		Application: "mysql",
+		NumUnits:    2,
+		Placement:   []string{"3"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, jc.DeepEquals, []string{"mysql/1", "mysql/2"})
+	c.Assert(s.application.added, jc.DeepEquals, []application.AddUnitsParams{{
+		ApplicationName: "mysql",
+		NumUnits:        2,
+		Placement:       []*instance.Placement{{Scope: instance.MachineScope, Directive: "3"}},
+	}})
+}
+
+func (s *clientSuite) TestAddUnitNoUnits(c *gc.C) {
+	_, err := s.client.AddUnit(AddUnitArgs{Application: "mysql"})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(s.application.added, gc.HasLen, 0)
+}
+
+func (s *clientSuite) TestStatus(c *gc.C) {
+	since := time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC)
+	s.model.status = &params.FullStatus{
+		Model: params.ModelStatusInfo{
+			Name:        "default",
+			CloudTag:    "cloud-aws",
+			CloudRegion: "us-east-1",
+			Version:     "2.4.0",
+			ModelStatus: params.DetailedStatus{Status: "available", Since: &since},
+		},
+		Machines: map[string]params.MachineStatus{
+			"0": {
+				Id:          "0",
+				InstanceId:  "i-0",
+				Series:      "xenial",
+				AgentStatus: params.DetailedStatus{Status: "started"},
+				Containers: map[string]params.MachineStatus{
+					"0/lxd/0": {Id: "0/lxd/0"},
+				},
+			},
+		},
+		Applications: map[string]params.ApplicationStatus{
+			"mysql": {
+				Charm:  "cs:xenial/mysql-58",
+				Series: "xenial",
+				Life:   "alive",
+				Status: params.DetailedStatus{Status: "active", Info: "ready"},
+				Units: map[string]params.UnitStatus{
+					"mysql/0": {
+						Machine:        "0",
+						Leader:         true,
+						WorkloadStatus: params.DetailedStatus{Status: "active", Info: "ready"},
+						AgentStatus:    params.DetailedStatus{Status: "idle"},
+					},
+				},
+			},
+		},
+	}
+	st, err := s.client.Status("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.model.patterns, jc.DeepEquals, []string{"mysql"})
+	c.Assert(st, jc.DeepEquals, &ModelStatus{
+		Name:    "default",
+		Cloud:   "aws",
+		Region:  "us-east-1",
+		Version: "2.4.0",
+		Status:  Status{Status: "available", Since: &since},
+		Machines: map[string]MachineStatus{
+			"0": {
+				ID:          "0",
+				InstanceID:  "i-0",
+				Series:      "xenial",
+				AgentStatus: Status{Status: "started"},
+				Containers: map[string]MachineStatus{
+					"0/lxd/0": {ID: "0/lxd/0"},
+				},
+			},
+		},
+		Applications: map[string]ApplicationStatus{
+			"mysql": {
+				Charm:  "cs:xenial/mysql-58",
+				Series: "xenial",
+				Life:   "alive",
+				Status: Status{Status: "active", Message: "ready"},
+				Units: map[string]UnitStatus{
+					"mysql/0": {
+						Machine:        "0",
+						Leader:         true,
+						WorkloadStatus: Status{Status: "active", Message: "ready"},
+						AgentStatus:    Status{Status: "idle"},
+					},
+				},
+			},
+		},
+	})
+}
+
+func (s *clientSuite) TestWatchModel(c *gc.C) {
+	s.model.watcher = &fakeAllWatcher{
+		deltas: []multiwatcher.Delta{{
+			Entity: &multiwatcher.UnitInfo{
+				Name:           "mysql/0",
+				WorkloadStatus: multiwatcher.StatusInfo{Current: status.Active, Message: "ready"},
+			},
+		}, {
+			Removed: true,
+			Entity:  &multiwatcher.MachineInfo{Id: "1"},
+		}, {
+			Entity: &multiwatcher.RelationInfo{Key: "wordpress:db mysql:server"},
+		}},
+	}
+	w, err := s.client.WatchModel()
+	c.Assert(err, jc.ErrorIsNil)
+	deltas, err := w.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(deltas, jc.DeepEquals, []Delta{{
+		Kind:   KindUnit,
+		ID:     "mysql/0",
+		Status: Status{Status: "active", Message: "ready"},
+	}, {
+		Kind:    KindMachine,
+		ID:      "1",
+		Removed: true,
+	}, {
+		Kind: "relation",
+		ID:   "wordpress:db mysql:server",
+	}})
+	c.Assert(w.Stop(), jc.ErrorIsNil)
+	c.Assert(s.model.watcher.stopped, jc.IsTrue)
+}
+
+func (s *clientSuite) TestRunAction(c *gc.C) {
+	id, err := s.client.RunAction("mysql/0", "backup", map[string]interface{}{"dest": "/tmp"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(id, gc.Equals, "f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	c.Assert(s.action.enqueued, jc.DeepEquals, []params.Action{{
+		Receiver:   "unit-mysql-0",
+		Name:       "backup",
+		Parameters: map[string]interface{}{"dest": "/tmp"},
+	}})
+}
+
+func (s *clientSuite) TestRunActionError(c *gc.C) {
+	s.action.err = &params.Error{Message: `no action "backup" defined`}
+	_, err := s.client.RunAction("mysql/0", "backup", nil)
+	c.Assert(err, gc.ErrorMatches, `no action "backup" defined`)
+}
+
+func (s *clientSuite) TestActionResult(c *gc.C) {
+	completed := time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC)
+	s.action.result = params.ActionResult{
+		Action: &params.Action{
+			Tag:      "action-f47ac10b-58cc-4372-a567-0e02b2c3d479",
+			Receiver: "unit-mysql-0",
+			Name:     "backup",
+		},
+		Status:    "completed",
+		Output:    map[string]interface{}{"file": "/tmp/backup.tgz"},
+		Completed: completed,
+	}
+	result, err := s.client.ActionResult("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, &ActionResult{
+		ID:        "f47ac10b-58cc-4372-a567-0e02b2c3d479",
+		Unit:      "mysql/0",
+		Name:      "backup",
+		Status:    "completed",
+		Output:    map[string]interface{}{"file": "/tmp/backup.tgz"},
+		Completed: completed,
+	})
+	c.Assert(s.action.queried, jc.DeepEquals, []params.Entity{{
+		Tag: "action-f47ac10b-58cc-4372-a567-0e02b2c3d479",
+	}})
+}
+
+func (s *clientSuite) TestActionResultNotFound(c *gc.C) {
+	s.action.result = params.ActionResult{
+		Error: &params.Error{Code: params.CodeNotFound, Message: "not found"},
+	}
+	_, err := s.client.ActionResult("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+type fakeModelAPI struct {
+	addedCharms []*charm.URL
+	channel     csparams.Channel
+	patterns    []string
+	status      *params.FullStatus
+	watcher     *fakeAllWatcher
+	err         error
+}
+
+func (f *fakeModelAPI) AddCharm(curl *charm.URL, channel csparams.Channel) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.addedCharms = append(f.addedCharms, curl)
+	f.channel = channel
+	return nil
+}
+
+func (f *fakeModelAPI) Status(patterns []string) (*params.FullStatus, error) {
+	f.patterns = patterns
+	return f.status, f.err
+}
+
+func (f *fakeModelAPI) WatchAll() (allWatcher, error) {
+	return f.watcher, f.err
+}
+
+type fakeAllWatcher struct {
+	deltas  []multiwatcher.Delta
+	stopped bool
+}
+
+func (f *fakeAllWatcher) Next() ([]multiwatcher.Delta, error) {
+	return f.deltas, nil
+}
+
+func (f *fakeAllWatcher) Stop() error {
+	f.stopped = true
+	return nil
+}
+
+type fakeApplicationAPI struct {
+	deployed []application.DeployArgs
+	added    []application.AddUnitsParams
+	units    []string
+}
+
+func (f *fakeApplicationAPI) Deploy(args application.DeployArgs) error {
+	f.deployed = append(f.deployed, args)
+	return nil
+}
+
+func (f *fakeApplicationAPI) AddUnits(args application.AddUnitsParams) ([]string, error) {
+	f.added = append(f.added, args)
+	return f.units, nil
+}
+
+type fakeActionAPI struct {
+	enqueued []params.Action
+	queried  []params.Entity
+	result   params.ActionResult
+	err      *params.Error
+}
+
+func (f *fakeActionAPI) Enqueue(args params.Actions) (params.ActionResults, error) {
+	f.enqueued = append(f.enqueued, args.Actions...)
+	result := params.ActionResult{Error: f.err}
+	if f.err == nil {
+		action := args.Actions[0]
+		action.Tag = "action-f47ac10b-58cc-4372-a567-0e02b2c3d479"
+		result.Action = &action
+	}
+	return params.ActionResults{Results: []params.ActionResult{result}}, nil
+}
+
+func (f *fakeActionAPI) Actions(args params.Entities) (params.ActionResults, error) {
+	f.queried = append(f.queried, args.Entities...)
+	return params.ActionResults{Results: []params.ActionResult{f.result}}, nil
+}
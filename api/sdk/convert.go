@@ -0,0 +1,154 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sdk
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// The functions in this file translate the wire types of the API into
+// the SDK's own types. They are the only place that needs to change
+// when the wire types do.
+
+func convertFullStatus(in *params.FullStatus) *ModelStatus {
+	out := &ModelStatus{
+		Name:         in.Model.Name,
+		Region:       in.Model.CloudRegion,
+		Version:      in.Model.Version,
+		Status:       convertDetailedStatus(in.Model.ModelStatus),
+		Machines:     make(map[string]MachineStatus, len(in.Machines)),
+		Applications: make(map[string]ApplicationStatus, len(in.Applications)),
+	}
+	if tag, err := names.ParseCloudTag(in.Model.CloudTag); err == nil {
+		out.Cloud = tag.Id()
+	}
+	for id, machine := range in.Machines {
+		out.Machines[id] = convertMachineStatus(machine)
+	}
+	for name, app := range in.Applications {
+		out.Applications[name] = convertApplicationStatus(app)
+	}
+	return out
+}
+
+func convertDetailedStatus(in params.DetailedStatus) Status {
+	return Status{
+		Status:  in.Status,
+		Message: in.Info,
+		Since:   in.Since,
+	}
+}
+
+func convertMachineStatus(in params.MachineStatus) MachineStatus {
+	out := MachineStatus{
+		ID:             in.Id,
+		InstanceID:     string(in.InstanceId),
+		Series:         in.Series,
+		DNSName:        in.DNSName,
+		IPAddresses:    in.IPAddresses,
+		AgentStatus:    convertDetailedStatus(in.AgentStatus),
+		InstanceStatus: convertDetailedStatus(in.InstanceStatus),
+	}
+	if len(in.Containers) > 0 {
+		out.Containers = make(map[string]MachineStatus, len(in.Containers))
+		for id, container := range in.Containers {
+			out.Containers[id] = convertMachineStatus(container)
+		}
+	}
+	return out
+}
+
+func convertApplicationStatus(in params.ApplicationStatus) ApplicationStatus {
+	out := ApplicationStatus{
+		Charm:   in.Charm,
+		Series:  in.Series,
+		Exposed: in.Exposed,
+		Life:    in.Life,
+		Status:  convertDetailedStatus(in.Status),
+		Units:   make(map[string]UnitStatus, len(in.Units)),
+	}
+	for name, unit := range in.Units {
+		out.Units[name] = convertUnitStatus(unit)
+	}
+	return out
+}
+
+func convertUnitStatus(in params.UnitStatus) UnitStatus {
+	out := UnitStatus{
+		Machine:        in.Machine,
+		PublicAddress:  in.PublicAddress,
+		OpenedPorts:    in.OpenedPorts,
+		Leader:         in.Leader,
+		AgentStatus:    convertDetailedStatus(in.AgentStatus),
+		WorkloadStatus: convertDetailedStatus(in.WorkloadStatus),
+	}
+	if len(in.Subordinates) > 0 {
+		out.Subordinates = make(map[string]UnitStatus, len(in.Subordinates))
+		for name, sub := range in.Subordinates {
+			out.Subordinates[name] = convertUnitStatus(sub)
+		}
+	}
+	return out
+}
+
+func convertDeltas(in []multiwatcher.Delta) []Delta {
+	out := make([]Delta, 0, len(in))
+	for _, d := range in {
+		if d.Entity == nil {
+			continue
+		}
+		id := d.Entity.EntityId()
+		delta := Delta{
+			Kind:    id.Kind,
+			ID:      id.Id,
+			Removed: d.Removed,
+		}
+		switch info := d.Entity.(type) {
+		case *multiwatcher.MachineInfo:
+			delta.Status = convertStatusInfo(info.AgentStatus)
+		case *multiwatcher.ApplicationInfo:
+			delta.Status = convertStatusInfo(info.Status)
+		case *multiwatcher.UnitInfo:
+			delta.Status = convertStatusInfo(info.WorkloadStatus)
+		}
+		out = append(out, delta)
+	}
+	return out
+}
+
+func convertStatusInfo(in multiwatcher.StatusInfo) Status {
+	return Status{
+		Status:  string(in.Current),
+		Message: in.Message,
+		Since:   in.Since,
+	}
+}
+
+func convertActionResult(id string, in params.ActionResult) (*ActionResult, error) {
+	out := &ActionResult{
+		ID:        id,
+		Status:    in.Status,
+		Message:   in.Message,
+		Output:    in.Output,
+		Enqueued:  in.Enqueued,
+		Started:   in.Started,
+		Completed: in.Completed,
+	}
+	if in.Action != nil {
+		out.Name = in.Action.Name
+		out.Parameters = in.Action.Parameters
+		if in.Action.Receiver != "" {
+			tag, err := names.ParseUnitTag(in.Action.Receiver)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			out.Unit = tag.Id()
+		}
+	}
+	return out, nil
+}
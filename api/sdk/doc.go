@@ -0,0 +1,24 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package sdk provides a supported client for Go programs that drive
+// Juju models, covering the common operations: deploying charms,
+// adding units, reading status, watching the model and running actions.
+//
+// Unlike the facade clients elsewhere under api/, which track the wire
+// format of each release, the types in this package are owned by the
+// SDK and are versioned semantically by Version. Fields and methods are
+// only ever added within a major version; the SDK takes care of
+// translating them to and from whichever facade versions the connected
+// controller supports.
+//
+// A Client is created from an established API connection:
+//
+//	conn, err := juju.NewAPIConnection(args)
+//	...
+//	client := sdk.NewClient(conn)
+//	status, err := client.Status()
+package sdk
+
+// Version is the semantic version of the SDK's exported API.
+const Version = "1.0.0"
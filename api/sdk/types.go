@@ -0,0 +1,147 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sdk
+
+import (
+	"time"
+)
+
+// DeployArgs holds the arguments to Client.Deploy.
+type DeployArgs struct {
+	// CharmURL is the fully resolved URL of the charm to deploy,
+	// including its series and revision, e.g. "cs:xenial/mysql-58".
+	// Charms with the "local" schema must already have been uploaded
+	// to the controller.
+	CharmURL string
+
+	// Channel is the charm store channel the charm is deployed from.
+	// If empty, the stable channel is used.
+	Channel string
+
+	// Application is the name of the new application. If empty, the
+	// charm's name is used.
+	Application string
+
+	// Series is the series the application is deployed on. If empty,
+	// the series of the charm URL is used.
+	Series string
+
+	// NumUnits is the number of units to add to the new application.
+	NumUnits int
+
+	// Config holds the application's charm settings.
+	Config map[string]interface{}
+
+	// Constraints holds the application's constraints, in the format
+	// accepted by "juju deploy --constraints".
+	Constraints string
+
+	// Placement holds a placement directive for each of the new units,
+	// in the format accepted by "juju deploy --to".
+	Placement []string
+}
+
+// AddUnitArgs holds the arguments to Client.AddUnit.
+type AddUnitArgs struct {
+	// Application is the name of the application to add units to.
+	Application string
+
+	// NumUnits is the number of units to add.
+	NumUnits int
+
+	// Placement holds a placement directive for each of the new units,
+	// in the format accepted by "juju add-unit --to".
+	Placement []string
+}
+
+// ModelStatus describes the state of a model and everything in it.
+type ModelStatus struct {
+	Name         string
+	Cloud        string
+	Region       string
+	Version      string
+	Status       Status
+	Machines     map[string]MachineStatus
+	Applications map[string]ApplicationStatus
+}
+
+// Status describes the status of a single entity.
+type Status struct {
+	Status  string
+	Message string
+	Since   *time.Time
+}
+
+// MachineStatus describes the state of a machine.
+type MachineStatus struct {
+	ID             string
+	InstanceID     string
+	Series         string
+	DNSName        string
+	IPAddresses    []string
+	AgentStatus    Status
+	InstanceStatus Status
+	Containers     map[string]MachineStatus
+}
+
+// ApplicationStatus describes the state of an application.
+type ApplicationStatus struct {
+	Charm   string
+	Series  string
+	Exposed bool
+	Life    string
+	Status  Status
+	Units   map[string]UnitStatus
+}
+
+// UnitStatus describes the state of a unit.
+type UnitStatus struct {
+	Machine        string
+	PublicAddress  string
+	OpenedPorts    []string
+	Leader         bool
+	AgentStatus    Status
+	WorkloadStatus Status
+	Subordinates   map[string]UnitStatus
+}
+
+// Kinds of entity reported in a Delta.
+const (
+	KindMachine     = "machine"
+	KindApplication = "application"
+	KindUnit        = "unit"
+)
+
+// Delta describes a change to an entity in a model.
+type Delta struct {
+	// Kind is the kind of the entity, e.g. KindUnit. Kinds other than
+	// those defined by this package may be reported.
+	Kind string
+
+	// ID identifies the entity within its kind.
+	ID string
+
+	// Removed is true if the entity has been removed from the model;
+	// otherwise it has been added or changed.
+	Removed bool
+
+	// Status holds the entity's status. For units, this is the
+	// workload status; for machines, the agent status. It is empty
+	// for kinds of entity without a status.
+	Status Status
+}
+
+// ActionResult describes an action and, once it has run, its outcome.
+type ActionResult struct {
+	ID         string
+	Unit       string
+	Name       string
+	Parameters map[string]interface{}
+	Status     string
+	Message    string
+	Output     map[string]interface{}
+	Enqueued   time.Time
+	Started    time.Time
+	Completed  time.Time
+}
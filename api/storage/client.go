@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage"
 )
 
@@ -287,3 +288,46 @@ func (c *Client) Import(
 	}
 	return names.ParseStorageTag(results.Results[0].Result.StorageTag)
 }
+
+// StatusHistory returns the past statuses of the volume or filesystem
+// with the specified tag, oldest first. The kind must be either
+// status.KindVolume or status.KindFilesystem.
+func (c *Client) StatusHistory(kind status.HistoryKind, tag names.Tag, filter status.StatusHistoryFilter) (status.History, error) {
+	if c.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("storage status history on this version of Juju")
+	}
+	var results params.StatusHistoryResults
+	args := params.StatusHistoryRequests{
+		Requests: []params.StatusHistoryRequest{{
+			Kind: string(kind),
+			Tag:  tag.String(),
+			Filter: params.StatusHistoryFilter{
+				Size:    filter.Size,
+				Date:    filter.FromDate,
+				Delta:   filter.Delta,
+				Exclude: filter.Exclude.Values(),
+			},
+		}},
+	}
+	if err := c.facade.FacadeCall("StatusHistory", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	statuses := results.Results[0].History.Statuses
+	history := make(status.History, len(statuses))
+	for i, h := range statuses {
+		history[i] = status.DetailedStatus{
+			Status: status.Status(h.Status),
+			Info:   h.Info,
+			Data:   h.Data,
+			Since:  h.Since,
+			Kind:   status.HistoryKind(h.Kind),
+		}
+	}
+	return history, nil
+}
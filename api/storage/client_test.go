@@ -5,6 +5,7 @@ package storage_test
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -16,6 +17,7 @@ import (
 	"github.com/juju/juju/api/storage"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/status"
 	jujustorage "github.com/juju/juju/storage"
 	"github.com/juju/juju/testing"
 )
@@ -847,3 +849,62 @@ func (s *storageMockSuite) TestImportArityMismatch(c *gc.C) {
 	_, err := client.Import(jujustorage.StorageKindBlock, "foo", "bar", "baz")
 	c.Check(err, gc.ErrorMatches, `expected 1 result, got 2`)
 }
+
+func (s *storageMockSuite) TestStatusHistory(c *gc.C) {
+	since := time.Unix(1, 0).UTC()
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "StatusHistory")
+				c.Check(a, jc.DeepEquals, params.StatusHistoryRequests{
+					Requests: []params.StatusHistoryRequest{{
+						Kind:   "volume",
+						Tag:    "volume-0",
+						Filter: params.StatusHistoryFilter{Size: 10, Exclude: []string{}},
+					}},
+				})
+				results := result.(*params.StatusHistoryResults)
+				results.Results = []params.StatusHistoryResult{{
+					History: params.History{Statuses: []params.DetailedStatus{{
+						Status: "attaching",
+						Info:   "provider says no",
+						Since:  &since,
+						Kind:   "volume",
+					}}},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+	client := storage.NewClient(apiCaller)
+	history, err := client.StatusHistory(
+		status.KindVolume, names.NewVolumeTag("0"), status.StatusHistoryFilter{Size: 10},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, jc.DeepEquals, status.History{{
+		Status: status.Attaching,
+		Info:   "provider says no",
+		Since:  &since,
+		Kind:   status.KindVolume,
+	}})
+}
+
+func (s *storageMockSuite) TestStatusHistoryNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			},
+		),
+		BestVersion: 4,
+	}
+	client := storage.NewClient(apiCaller)
+	_, err := client.StatusHistory(
+		status.KindVolume, names.NewVolumeTag("0"), status.StatusHistoryFilter{Size: 10},
+	)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
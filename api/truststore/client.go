@@ -0,0 +1,66 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package truststore provides a client for the TrustStore facade,
+// which manages the CA certificates pinned for each category of
+// external endpoint the controller connects to.
+package truststore
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the TrustStore facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new TrustStore client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "TrustStore")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// ListCACerts returns the CA certificates pinned for the given
+// category, or for every category if it is empty.
+func (c *Client) ListCACerts(category string) ([]params.TrustedCACert, error) {
+	args := params.TrustedCACertFilter{Category: category}
+	var result params.TrustedCACerts
+	if err := c.facade.FacadeCall("ListCACerts", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Certs, nil
+}
+
+// AddCACert pins the given PEM-encoded CA certificate, under the given
+// name, for the category of endpoint.
+func (c *Client) AddCACert(category, name, caCert string) error {
+	args := params.TrustedCACerts{
+		Certs: []params.TrustedCACert{{
+			Category: category,
+			Name:     name,
+			CACert:   caCert,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("AddCACerts", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// RemoveCACert unpins the named CA certificate from the category.
+func (c *Client) RemoveCACert(category, name string) error {
+	args := params.TrustedCACertIds{
+		Ids: []params.TrustedCACertId{{Category: category, Name: name}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RemoveCACerts", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
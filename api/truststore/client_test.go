@@ -0,0 +1,75 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package truststore_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/truststore"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestListCACerts(c *gc.C) {
+	expected := []params.TrustedCACert{{
+		Category:    "charmstore",
+		Name:        "corporate-proxy",
+		CACert:      "ca-cert",
+		Fingerprint: "abcd",
+	}}
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "TrustStore")
+		c.Check(request, gc.Equals, "ListCACerts")
+		c.Check(arg, jc.DeepEquals, params.TrustedCACertFilter{Category: "charmstore"})
+		*(result.(*params.TrustedCACerts)) = params.TrustedCACerts{Certs: expected}
+		return nil
+	})
+	certs, err := truststore.NewClient(apiCaller).ListCACerts("charmstore")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(certs, jc.DeepEquals, expected)
+}
+
+func (s *clientSuite) TestAddCACert(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "TrustStore")
+		c.Check(request, gc.Equals, "AddCACerts")
+		c.Check(arg, jc.DeepEquals, params.TrustedCACerts{
+			Certs: []params.TrustedCACert{{
+				Category: "cloud",
+				Name:     "corporate-proxy",
+				CACert:   "ca-cert",
+			}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "kaboom"}}},
+		}
+		return nil
+	})
+	err := truststore.NewClient(apiCaller).AddCACert("cloud", "corporate-proxy", "ca-cert")
+	c.Assert(err, gc.ErrorMatches, "kaboom")
+}
+
+func (s *clientSuite) TestRemoveCACert(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "TrustStore")
+		c.Check(request, gc.Equals, "RemoveCACerts")
+		c.Check(arg, jc.DeepEquals, params.TrustedCACertIds{
+			Ids: []params.TrustedCACertId{{Category: "webhook", Name: "corporate-proxy"}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		return nil
+	})
+	err := truststore.NewClient(apiCaller).RemoveCACert("webhook", "corporate-proxy")
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
+	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/watcher"
 )
@@ -94,6 +95,85 @@ func (ru *RelationUnit) LeaveScope() error {
 	return result.OneError()
 }
 
+// SetDeparted records that the unit has run the relation-departed hooks
+// for all of its counterparts in the relation, and is ready to run
+// relation-broken. It is not an error to call SetDeparted for a unit
+// that is not in the relation scope.
+func (ru *RelationUnit) SetDeparted() error {
+	if ru.st.BestAPIVersion() < 10 {
+		return errors.NotImplementedf("SetDeparted() (need V10+)")
+	}
+	var result params.ErrorResults
+	args := params.RelationUnits{
+		RelationUnits: []params.RelationUnit{{
+			Relation: ru.relation.tag.String(),
+			Unit:     ru.unit.tag.String(),
+		}},
+	}
+	err := ru.st.facade.FacadeCall("SetRelationsDeparted", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
+// BrokenBarrierReached returns whether the unit may run the
+// relation-broken hook for the relation. This is only false while
+// the unit's application has a relation-broken barrier and other
+// units of the application have yet to depart the relation.
+func (ru *RelationUnit) BrokenBarrierReached() (bool, error) {
+	if ru.st.BestAPIVersion() < 10 {
+		return false, errors.NotImplementedf("BrokenBarrierReached() (need V10+)")
+	}
+	var results params.BoolResults
+	args := params.RelationUnits{
+		RelationUnits: []params.RelationUnit{{
+			Relation: ru.relation.tag.String(),
+			Unit:     ru.unit.tag.String(),
+		}},
+	}
+	err := ru.st.facade.FacadeCall("RelationBrokenBarriers", args, &results)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return false, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.Result, nil
+}
+
+// WatchBrokenBarrier returns a watcher that notifies of changes that
+// may affect the result of BrokenBarrierReached.
+func (ru *RelationUnit) WatchBrokenBarrier() (watcher.NotifyWatcher, error) {
+	if ru.st.BestAPIVersion() < 10 {
+		return nil, errors.NotImplementedf("WatchBrokenBarrier() (need V10+)")
+	}
+	var results params.NotifyWatchResults
+	args := params.RelationUnits{
+		RelationUnits: []params.RelationUnit{{
+			Relation: ru.relation.tag.String(),
+			Unit:     ru.unit.tag.String(),
+		}},
+	}
+	err := ru.st.facade.FacadeCall("WatchRelationBrokenBarriers", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewNotifyWatcher(ru.st.facade.RawAPICaller(), result)
+	return w, nil
+}
+
 // Settings returns a Settings which allows access to the unit's settings
 // within the relation.
 func (ru *RelationUnit) Settings() (*Settings, error) {
@@ -152,6 +232,46 @@ func (ru *RelationUnit) ReadSettings(uname string) (params.Settings, error) {
 	return result.Settings, nil
 }
 
+// ReadSettingsBatch returns the settings of each of the units with the
+// supplied names within this relation, in one API call. The results
+// are in the same order as the names; each holds either the unit's
+// settings or the error that ReadSettings would have returned.
+func (ru *RelationUnit) ReadSettingsBatch(unames []string) ([]params.SettingsResult, error) {
+	if ru.st.BestAPIVersion() < 11 {
+		return nil, errors.NotImplementedf("ReadSettingsBatch() (need V11+)")
+	}
+	batch := params.RelationSettingsBatch{
+		Relation:    ru.relation.tag.String(),
+		LocalUnit:   ru.unit.tag.String(),
+		RemoteUnits: make([]string, len(unames)),
+	}
+	for i, uname := range unames {
+		if !names.IsValidUnit(uname) {
+			return nil, errors.Errorf("%q is not a valid unit", uname)
+		}
+		batch.RemoteUnits[i] = names.NewUnitTag(uname).String()
+	}
+	var results params.RelationSettingsBatchResults
+	args := params.RelationSettingsBatches{
+		Batches: []params.RelationSettingsBatch{batch},
+	}
+	err := ru.st.facade.FacadeCall("ReadSettingsBatch", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if len(result.Results) != len(unames) {
+		return nil, errors.Errorf("expected %d settings, got %d", len(unames), len(result.Results))
+	}
+	return result.Results, nil
+}
+
 // Watch returns a watcher that notifies of changes to counterpart
 // units in the relation.
 func (ru *RelationUnit) Watch() (watcher.RelationUnitsWatcher, error) {
@@ -173,6 +173,46 @@ func (s *relationUnitSuite) TestLeaveScope(c *gc.C) {
 	s.assertInScope(c, wpRelUnit, false)
 }
 
+func (s *relationUnitSuite) TestBrokenBarrier(c *gc.C) {
+	wpRelUnit, apiRelUnit := s.getRelationUnits(c)
+	err := wpRelUnit.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpressApplication.SetRelationBrokenBarrier(true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	reached, err := apiRelUnit.BrokenBarrierReached()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reached, jc.IsFalse)
+
+	err = apiRelUnit.SetDeparted()
+	c.Assert(err, jc.ErrorIsNil)
+	reached, err = apiRelUnit.BrokenBarrierReached()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reached, jc.IsTrue)
+}
+
+func (s *relationUnitSuite) TestWatchBrokenBarrier(c *gc.C) {
+	wpRelUnit, apiRelUnit := s.getRelationUnits(c)
+	err := wpRelUnit.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w, err := apiRelUnit.WatchBrokenBarrier()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewNotifyWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertOneChange()
+
+	err = wpRelUnit.SetDeparted()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.wordpressApplication.SetRelationBrokenBarrier(true)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
 func (s *relationUnitSuite) TestSettings(c *gc.C) {
 	wpRelUnit, apiRelUnit := s.getRelationUnits(c)
 	settings := map[string]interface{}{
@@ -225,6 +265,29 @@ func (s *relationUnitSuite) TestReadSettings(c *gc.C) {
 	})
 }
 
+func (s *relationUnitSuite) TestReadSettingsBatch(c *gc.C) {
+	myRelUnit, err := s.stateRelation.Unit(s.mysqlUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = myRelUnit.EnterScope(map[string]interface{}{"some": "settings"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertInScope(c, myRelUnit, true)
+
+	_, apiRelUnit := s.getRelationUnits(c)
+	results, err := apiRelUnit.ReadSettingsBatch([]string{"mysql/0", "wordpress/0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], jc.DeepEquals, params.SettingsResult{
+		Settings: params.Settings{"some": "settings"},
+	})
+	c.Assert(results[1].Error, jc.Satisfies, params.IsCodeUnauthorized)
+}
+
+func (s *relationUnitSuite) TestReadSettingsBatchInvalidUnit(c *gc.C) {
+	_, apiRelUnit := s.getRelationUnits(c)
+	_, err := apiRelUnit.ReadSettingsBatch([]string{"mysql/0", "mysql"})
+	c.Assert(err, gc.ErrorMatches, "\"mysql\" is not a valid unit")
+}
+
 func (s *relationUnitSuite) TestReadSettingsInvalidUnitTag(c *gc.C) {
 	// First try to read the settings which are not set.
 	myRelUnit, err := s.stateRelation.Unit(s.mysqlUnit)
@@ -4,6 +4,8 @@
 package uniter
 
 import (
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/apiserver/params"
 )
 
@@ -94,3 +96,41 @@ func (s *Settings) Write() error {
 	}
 	return result.OneError()
 }
+
+// WriteSettingsBatch writes the changes made to each of the given
+// settings in one API call. Nothing is written unless all of the
+// changes are accepted. Controllers that do not support batched writes
+// are sent each of the settings in turn.
+func (st *State) WriteSettingsBatch(settings []*Settings) error {
+	if len(settings) == 0 {
+		return nil
+	}
+	if st.BestAPIVersion() < 11 {
+		for _, s := range settings {
+			if err := s.Write(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		return nil
+	}
+	args := params.RelationUnitsSettings{
+		RelationUnits: make([]params.RelationUnitSettings, len(settings)),
+	}
+	for i, s := range settings {
+		settingsCopy := make(params.Settings)
+		for k, v := range s.settings {
+			settingsCopy[k] = v
+		}
+		args.RelationUnits[i] = params.RelationUnitSettings{
+			Relation: s.relationTag,
+			Unit:     s.unitTag,
+			Settings: settingsCopy,
+		}
+	}
+	var result params.ErrorResults
+	err := st.facade.FacadeCall("WriteSettingsBatch", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.Combine()
+}
@@ -133,3 +133,29 @@ func (s *settingsSuite) TestWrite(c *gc.C) {
 		"other": "days",
 	})
 }
+
+func (s *settingsSuite) TestWriteSettingsBatch(c *gc.C) {
+	wpRelUnit, err := s.stateRelation.Unit(s.wordpressUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = wpRelUnit.EnterScope(map[string]interface{}{"some": "stuff"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	apiUnit, err := s.uniter.Unit(s.wordpressUnit.Tag().(names.UnitTag))
+	c.Assert(err, jc.ErrorIsNil)
+	apiRelation, err := s.uniter.Relation(s.stateRelation.Tag().(names.RelationTag))
+	c.Assert(err, jc.ErrorIsNil)
+	apiRelUnit, err := apiRelation.Unit(apiUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err := apiRelUnit.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+
+	settings.Delete("some")
+	settings.Set("foo", "qaz")
+	err = s.uniter.WriteSettingsBatch([]*uniter.Settings{settings})
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err = apiRelUnit.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings.Map(), gc.DeepEquals, params.Settings{
+		"foo": "qaz",
+	})
+}
@@ -4,6 +4,8 @@
 package uniter
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
@@ -22,6 +24,8 @@ type Unit struct {
 	life         params.Life
 	resolvedMode params.ResolvedMode
 	series       string
+
+	drainDeadline *time.Time
 }
 
 // Tag returns the unit's tag.
@@ -54,6 +58,15 @@ func (u *Unit) Resolved() params.ResolvedMode {
 	return u.resolvedMode
 }
 
+// DrainDeadline returns the time until which the charm may drain the
+// dying unit, and whether a drain was requested.
+func (u *Unit) DrainDeadline() (time.Time, bool) {
+	if u.drainDeadline == nil {
+		return time.Time{}, false
+	}
+	return *u.drainDeadline, true
+}
+
 // Refresh updates the cached local copy of the unit's data.
 func (u *Unit) Refresh() error {
 	var results params.UnitRefreshResults
@@ -77,6 +90,7 @@ func (u *Unit) Refresh() error {
 	u.life = result.Life
 	u.resolvedMode = result.Resolved
 	u.series = result.Series
+	u.drainDeadline = result.DrainDeadline
 	return nil
 }
 
@@ -776,3 +790,72 @@ func (u *Unit) NetworkInfo(bindings []string, relationId *int) (map[string]param
 
 	return results.Results, nil
 }
+
+// UniterState returns the most recently recorded snapshot of the
+// unit's local uniter state. If none has been recorded, an error
+// satisfying params.IsCodeNotFound is returned.
+func (u *Unit) UniterState() (params.UniterState, error) {
+	if u.st.facade.BestAPIVersion() < 8 {
+		return params.UniterState{}, errors.NotImplementedf("UniterState() (need V8+)")
+	}
+	var results params.UniterStateResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("UniterState", args, &results)
+	if err != nil {
+		return params.UniterState{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.UniterState{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.UniterState{}, result.Error
+	}
+	return *result.Result, nil
+}
+
+// SetUniterState records a snapshot of the unit's local uniter state.
+func (u *Unit) SetUniterState(uniterState params.UniterState) error {
+	if u.st.facade.BestAPIVersion() < 8 {
+		return errors.NotImplementedf("SetUniterState() (need V8+)")
+	}
+	var results params.ErrorResults
+	args := params.SetUniterStateArgs{
+		Args: []params.SetUniterStateArg{{
+			Tag:   u.tag.String(),
+			State: uniterState,
+		}},
+	}
+	err := u.st.facade.FacadeCall("SetUniterState", args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// CloudCredential returns a short-lived cloud credential scoped to the
+// trust level of the unit's application. If the application is not
+// trusted, an error satisfying params.IsCodeUnauthorized is returned.
+func (u *Unit) CloudCredential() (params.ScopedCredential, error) {
+	if u.st.facade.BestAPIVersion() < 12 {
+		return params.ScopedCredential{}, errors.NotImplementedf("CloudCredential() (need V12+)")
+	}
+	var results params.ScopedCredentialResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("CloudCredential", args, &results)
+	if err != nil {
+		return params.ScopedCredential{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.ScopedCredential{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.ScopedCredential{}, result.Error
+	}
+	return *result.Result, nil
+}
@@ -236,6 +236,28 @@ func (s *unitSuite) TestRefreshResolve(c *gc.C) {
 	c.Assert(mode, gc.Equals, params.ResolvedNone)
 }
 
+func (s *unitSuite) TestRefreshDrainDeadline(c *gc.C) {
+	_, ok := s.apiUnit.DrainDeadline()
+	c.Assert(ok, jc.IsFalse)
+
+	now := time.Now()
+	err := s.wordpressUnit.SetAgentStatus(status.StatusInfo{
+		Status: status.Idle,
+		Since:  &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	op := s.wordpressUnit.DestroyOperation()
+	op.DrainTimeout = time.Hour
+	err = s.State.ApplyOperation(op)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.apiUnit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	deadline, ok := s.apiUnit.DrainDeadline()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(deadline.After(now), jc.IsTrue)
+}
+
 func (s *unitSuite) TestRefreshSeries(c *gc.C) {
 	c.Assert(s.apiUnit.Series(), gc.Equals, "quantal")
 	err := s.wordpressMachine.UpdateMachineSeries("xenial", true)
@@ -848,6 +870,28 @@ func (s *unitSuite) TestWatchMeterStatus(c *gc.C) {
 	wc.AssertOneChange()
 }
 
+func (s *unitSuite) TestUniterState(c *gc.C) {
+	_, err := s.apiUnit.UniterState()
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+
+	uniterState := params.UniterState{
+		OperationState: "op: continue\nopstep: pending\n",
+		RelationState:  map[string]string{"0/mysql-0": "change-version: 1\n"},
+		StorageState:   map[string]string{"data-0": "attached: true\n"},
+	}
+	err = s.apiUnit.SetUniterState(uniterState)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := s.apiUnit.UniterState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, uniterState)
+}
+
+func (s *unitSuite) TestCloudCredentialNotTrusted(c *gc.C) {
+	_, err := s.apiUnit.CloudCredential()
+	c.Assert(err, gc.ErrorMatches, `application "wordpress" is not trusted`)
+	c.Assert(err, jc.Satisfies, params.IsCodeUnauthorized)
+}
+
 type unitMetricBatchesSuite struct {
 	jujutesting.JujuConnSuite
 
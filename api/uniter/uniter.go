@@ -5,6 +5,7 @@ package uniter
 
 import (
 	"fmt"
+	"net/url"
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6"
@@ -70,12 +71,12 @@ func newStateForVersionFn(version int) func(base.APICaller, names.UnitTag) *Stat
 	}
 }
 
-// newStateV7 creates a new client-side Uniter facade, version 7
-var newStateV7 = newStateForVersionFn(7)
+// newStateV10 creates a new client-side Uniter facade, version 10
+var newStateV10 = newStateForVersionFn(10)
 
 // NewState creates a new client-side Uniter facade.
 // Defined like this to allow patching during tests.
-var NewState = newStateV7
+var NewState = newStateV10
 
 // BestAPIVersion returns the API version that we were able to
 // determine is supported by both the client and the API Server.
@@ -422,3 +423,41 @@ func (st *State) SLALevel() (string, error) {
 	}
 	return result.Result, nil
 }
+
+// DebugHooksSession reports whether a client has opened a debug-hooks
+// session for the authenticated unit through the controller and, if
+// so, the hooks it intercepts. An empty list of hooks means that all
+// hooks are intercepted.
+func (st *State) DebugHooksSession() ([]string, bool, error) {
+	if st.BestAPIVersion() < 9 {
+		return nil, false, errors.NotImplementedf("DebugHooksSession() (need V9+)")
+	}
+	var results params.DebugHooksSessionResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: st.unitTag.String()}},
+	}
+	err := st.facade.FacadeCall("DebugHooksSession", args, &results)
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, false, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, false, result.Error
+	}
+	return result.Hooks, result.Active, nil
+}
+
+// ConnectDebugHooks attaches an execution of the named hook to the
+// authenticated unit's debug-hooks session, returning the stream over
+// which the hook's shell is driven.
+func (st *State) ConnectDebugHooks(hookName string) (base.Stream, error) {
+	path := "/units/" + st.unitTag.String() + "/debug-hooks/agent"
+	stream, err := st.facade.RawAPICaller().ConnectStream(path, url.Values{"hook": {hookName}})
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot connect to debug-hooks session")
+	}
+	return stream, nil
+}
@@ -128,6 +128,13 @@ func (s *uniterSuite) assertInScope(c *gc.C, relUnit *state.RelationUnit, inScop
 	c.Assert(ok, gc.Equals, inScope)
 }
 
+func (s *uniterSuite) TestDebugHooksSessionNone(c *gc.C) {
+	hooks, active, err := s.uniter.DebugHooksSession()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(active, jc.IsFalse)
+	c.Assert(hooks, gc.HasLen, 0)
+}
+
 func (s *uniterSuite) TestSLALevel(c *gc.C) {
 	err := s.State.SetSLA("essential", "bob", []byte("creds"))
 	c.Assert(err, jc.ErrorIsNil)
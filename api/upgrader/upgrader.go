@@ -6,6 +6,7 @@ package upgrader
 import (
 	"fmt"
 
+	"github.com/juju/errors"
 	"github.com/juju/version"
 
 	"github.com/juju/juju/api/base"
@@ -15,6 +16,11 @@ import (
 	"github.com/juju/juju/watcher"
 )
 
+// ErrUpgradeDeferred is returned by DesiredVersion when the agent is to
+// upgrade to a different version, but not until the model's next
+// maintenance window.
+var ErrUpgradeDeferred = errors.New("agent upgrade deferred until the next maintenance window")
+
 // State provides access to an upgrader worker's view of the state.
 type State struct {
 	facade base.FacadeCaller
@@ -67,6 +73,10 @@ func (st *State) DesiredVersion(tag string) (version.Number, error) {
 		// TODO: Not directly tested
 		return version.Number{}, fmt.Errorf("received no error, but got a nil Version")
 	}
+	if result.Deferred {
+		// The version is the agent's current version.
+		return *result.Version, ErrUpgradeDeferred
+	}
 	return *result.Version, nil
 }
 
@@ -5,6 +5,7 @@ package upgrader_test
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -14,6 +15,7 @@ import (
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/upgrader"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/maintenance"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
@@ -142,3 +144,22 @@ func (s *machineUpgraderSuite) TestDesiredVersion(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(stateVersion, gc.Equals, current.Number)
 }
+
+func (s *machineUpgraderSuite) TestDesiredVersionDeferred(c *gc.C) {
+	older := current
+	older.Minor--
+	err := s.rawMachine.SetAgentVersion(older)
+	c.Assert(err, jc.ErrorIsNil)
+	// A window that opens in two hours is not open now.
+	opens := time.Now().UTC().Add(2 * time.Hour)
+	err = s.State.SetMaintenanceWindow(maintenance.Window{
+		Name:     "later",
+		Schedule: fmt.Sprintf("%d %d * * *", opens.Minute(), opens.Hour()),
+		Duration: time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	stateVersion, err := s.st.DesiredVersion(s.rawMachine.Tag().String())
+	c.Assert(err, gc.Equals, upgrader.ErrUpgradeDeferred)
+	c.Assert(stateVersion, gc.Equals, older.Number)
+}
@@ -207,3 +207,31 @@ func (c *Client) ResetPassword(username string) ([]byte, error) {
 	}
 	return result.SecretKey, nil
 }
+
+// UserActivity returns the API activity of the specified user in each
+// model in which they have made API calls or logged in.
+func (c *Client) UserActivity(username string) ([]params.ModelUserActivity, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("UserActivity")
+	}
+	if !names.IsValidUser(username) {
+		return nil, errors.NotValidf("user name %q", username)
+	}
+	in := params.Entities{
+		Entities: []params.Entity{{
+			Tag: names.NewUserTag(username).String(),
+		}},
+	}
+	var out params.ModelUserActivityResults
+	if err := c.facade.FacadeCall("UserActivity", in, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if count := len(out.Results); count != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", count)
+	}
+	result := out.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Activity, nil
+}
@@ -270,3 +270,35 @@ func (s *usermanagerSuite) TestResetPasswordResultCount(c *gc.C) {
 	_, err := client.ResetPassword("foobar")
 	c.Assert(err, gc.ErrorMatches, "expected 1 result, got 2")
 }
+
+func (s *usermanagerSuite) TestUserActivity(c *gc.C) {
+	expected := []params.ModelUserActivity{{
+		UserTag:  "user-foobar",
+		ModelTag: "model-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		APICalls: 12,
+	}}
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 3,
+		APICallerFunc: apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "UserManager")
+			c.Check(request, gc.Equals, "UserActivity")
+			c.Check(arg, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "user-foobar"}},
+			})
+			*(result.(*params.ModelUserActivityResults)) = params.ModelUserActivityResults{
+				Results: []params.ModelUserActivityResult{{Activity: expected}},
+			}
+			return nil
+		}),
+	}
+	client := usermanager.NewClient(apiCaller)
+	activity, err := client.UserActivity("foobar")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(activity, jc.DeepEquals, expected)
+}
+
+func (s *usermanagerSuite) TestUserActivityNotSupported(c *gc.C) {
+	client := usermanager.NewClient(apitesting.BestVersionCaller{BestVersion: 2})
+	_, err := client.UserActivity("foobar")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -0,0 +1,63 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package utilization provides a client for the Utilization facade,
+// which reports the resources used by units' processes.
+package utilization
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+// Client provides access to the Utilization facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new Utilization client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "Utilization")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// UnitsUtilization returns the resources most recently reported to be
+// used by each of the named units, in the same order as the names.
+// Where a unit has reported nothing, its result holds an error
+// satisfying params.IsCodeNotFound.
+func (c *Client) UnitsUtilization(unitNames ...string) ([]params.UnitUtilizationResult, error) {
+	args := params.Entities{Entities: make([]params.Entity, len(unitNames))}
+	for i, name := range unitNames {
+		if !names.IsValidUnit(name) {
+			return nil, errors.NotValidf("unit name %q", name)
+		}
+		args.Entities[i].Tag = names.NewUnitTag(name).String()
+	}
+	var result params.UnitUtilizationResults
+	if err := c.facade.FacadeCall("UnitsUtilization", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(result.Results); n != len(unitNames) {
+		return nil, errors.Errorf("expected %d results, got %d", len(unitNames), n)
+	}
+	return result.Results, nil
+}
+
+// WatchUnitsUtilization returns a StringsWatcher that notifies of the
+// names of the units whose reported utilization changes.
+func (c *Client) WatchUnitsUtilization() (watcher.StringsWatcher, error) {
+	var result params.StringsWatchResult
+	if err := c.facade.FacadeCall("WatchUnitsUtilization", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), result), nil
+}
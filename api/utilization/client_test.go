@@ -0,0 +1,79 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package utilization_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/utilization"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestUnitsUtilization(c *gc.C) {
+	expected := []params.UnitUtilizationResult{{
+		Result: &params.UnitUtilization{
+			CPUPercent:  12.5,
+			MemoryBytes: 1024,
+			DiskBytes:   2048,
+			Updated:     time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC),
+		},
+	}, {
+		Error: &params.Error{Code: params.CodeNotFound, Message: "not found"},
+	}}
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Utilization")
+		c.Check(request, gc.Equals, "UnitsUtilization")
+		c.Check(arg, jc.DeepEquals, params.Entities{Entities: []params.Entity{
+			{Tag: "unit-mysql-0"},
+			{Tag: "unit-mysql-1"},
+		}})
+		*(result.(*params.UnitUtilizationResults)) = params.UnitUtilizationResults{Results: expected}
+		return nil
+	})
+	results, err := utilization.NewClient(apiCaller).UnitsUtilization("mysql/0", "mysql/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expected)
+}
+
+func (s *clientSuite) TestUnitsUtilizationInvalidUnit(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call")
+		return nil
+	})
+	_, err := utilization.NewClient(apiCaller).UnitsUtilization("mysql")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `unit name "mysql" not valid`)
+}
+
+func (s *clientSuite) TestUnitsUtilizationWrongResultCount(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return nil
+	})
+	_, err := utilization.NewClient(apiCaller).UnitsUtilization("mysql/0")
+	c.Assert(err, gc.ErrorMatches, "expected 1 results, got 0")
+}
+
+func (s *clientSuite) TestWatchUnitsUtilizationError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "WatchUnitsUtilization")
+		*(result.(*params.StringsWatchResult)) = params.StringsWatchResult{
+			Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+		}
+		return nil
+	})
+	_, err := utilization.NewClient(apiCaller).WatchUnitsUtilization()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
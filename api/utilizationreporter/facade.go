@@ -0,0 +1,52 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package utilizationreporter implements the client-side API facade
+// used by the utilizationreporter worker.
+package utilizationreporter
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Facade provides access to the UtilizationReporter API facade.
+type Facade struct {
+	caller base.FacadeCaller
+}
+
+// NewFacade creates a new client-side UtilizationReporter facade.
+func NewFacade(caller base.APICaller) *Facade {
+	return &Facade{
+		caller: base.NewFacadeCaller(caller, "UtilizationReporter"),
+	}
+}
+
+// SetUnitsUtilization records the resources used by the units with the
+// given names.
+func (f *Facade) SetUnitsUtilization(utilization map[string]params.UnitUtilization) error {
+	unitNames := make([]string, 0, len(utilization))
+	for name := range utilization {
+		unitNames = append(unitNames, name)
+	}
+	sort.Strings(unitNames)
+	args := params.SetUnitsUtilization{
+		Args: make([]params.SetUnitUtilization, len(unitNames)),
+	}
+	for i, name := range unitNames {
+		args.Args[i] = params.SetUnitUtilization{
+			Tag:         names.NewUnitTag(name).String(),
+			Utilization: utilization[name],
+		}
+	}
+	var result params.ErrorResults
+	if err := f.caller.FacadeCall("SetUnitsUtilization", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	return result.Combine()
+}
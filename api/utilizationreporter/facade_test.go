@@ -0,0 +1,87 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package utilizationreporter_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/utilizationreporter"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type facadeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) TestSetUnitsUtilization(c *gc.C) {
+	stub := new(testing.Stub)
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "UtilizationReporter")
+		c.Check(id, gc.Equals, "")
+		stub.AddCall(request, args)
+		*response.(*params.ErrorResults) = params.ErrorResults{
+			Results: []params.ErrorResult{{}, {}},
+		}
+		return nil
+	})
+	facade := utilizationreporter.NewFacade(apiCaller)
+
+	err := facade.SetUnitsUtilization(map[string]params.UnitUtilization{
+		"mysql/1": {MemoryBytes: 2048},
+		"mysql/0": {CPUPercent: 12.5},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []testing.StubCall{{
+		"SetUnitsUtilization", []interface{}{params.SetUnitsUtilization{
+			Args: []params.SetUnitUtilization{{
+				Tag:         "unit-mysql-0",
+				Utilization: params.UnitUtilization{CPUPercent: 12.5},
+			}, {
+				Tag:         "unit-mysql-1",
+				Utilization: params.UnitUtilization{MemoryBytes: 2048},
+			}},
+		}},
+	}})
+}
+
+func (s *facadeSuite) TestSetUnitsUtilizationError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		*response.(*params.ErrorResults) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+		}
+		return nil
+	})
+	facade := utilizationreporter.NewFacade(apiCaller)
+
+	err := facade.SetUnitsUtilization(map[string]params.UnitUtilization{"mysql/0": {}})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *facadeSuite) TestSetUnitsUtilizationCallError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		return errors.New("kaboom")
+	})
+	facade := utilizationreporter.NewFacade(apiCaller)
+
+	err := facade.SetUnitsUtilization(map[string]params.UnitUtilization{"mysql/0": {}})
+	c.Assert(err, gc.ErrorMatches, "kaboom")
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/tomb.v1"
 
 	"github.com/juju/juju/api/base"
@@ -244,6 +245,83 @@ func (w *stringsWatcher) Changes() watcher.StringsChannel {
 	return w.out
 }
 
+// entityChangesWatcher will send events when entities change. The
+// content of the changes describes each changed entity's tag and life.
+type entityChangesWatcher struct {
+	commonWatcher
+	caller                 base.APICaller
+	entityChangesWatcherId string
+	out                    chan []watcher.EntityChange
+}
+
+// NewEntityChangesWatcher returns an EntityChangesWatcher which
+// communicates with the EntityChangesWatcher API facade.
+func NewEntityChangesWatcher(caller base.APICaller, result params.EntityChangesWatchResult) watcher.EntityChangesWatcher {
+	w := &entityChangesWatcher{
+		caller:                 caller,
+		entityChangesWatcherId: result.EntityChangesWatcherId,
+		out:                    make(chan []watcher.EntityChange),
+	}
+	go func() {
+		defer w.tomb.Done()
+		w.tomb.Kill(w.loop(result.Changes))
+	}()
+	return w
+}
+
+func copyEntityChanges(src []params.EntityChange) ([]watcher.EntityChange, error) {
+	dst := make([]watcher.EntityChange, len(src))
+	for i, change := range src {
+		tag, err := names.ParseTag(change.Tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		dst[i] = watcher.EntityChange{
+			Tag:     tag,
+			Life:    life.Value(change.Life),
+			Removed: change.Removed,
+		}
+	}
+	return dst, nil
+}
+
+func (w *entityChangesWatcher) loop(initialChanges []params.EntityChange) error {
+	changes, err := copyEntityChanges(initialChanges)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.newResult = func() interface{} { return new(params.EntityChangesWatchResult) }
+	w.call = makeWatcherAPICaller(w.caller, "EntityChangesWatcher", w.entityChangesWatcherId)
+	w.commonWatcher.init()
+	go w.commonLoop()
+
+	for {
+		select {
+		// Send the initial event or subsequent change.
+		case w.out <- changes:
+		case <-w.tomb.Dying():
+			return nil
+		}
+		// Read the next change.
+		data, ok := <-w.in
+		if !ok {
+			// The tomb is already killed with the correct error
+			// at this point, so just return.
+			return nil
+		}
+		changes, err = copyEntityChanges(data.(*params.EntityChangesWatchResult).Changes)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+// Changes returns a channel that receives descriptions of the watched
+// entities that have changed.
+func (w *entityChangesWatcher) Changes() watcher.EntityChangesChannel {
+	return w.out
+}
+
 // relationUnitsWatcher will sends notifications of units entering and
 // leaving the scope of a RelationUnit, and changes to the settings of
 // those units known to have entered.
@@ -182,6 +182,45 @@ func (s *watcherSuite) TestStringsWatcherStopsWithPendingSend(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *watcherSuite) TestEntityChangesWatcher(c *gc.C) {
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	principal, err := mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = principal.AssignToMachine(s.rawMachine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Call the Deployer facade's WatchUnitChanges for machine-0.
+	var results params.EntityChangesWatchResults
+	args := params.Entities{Entities: []params.Entity{{Tag: s.rawMachine.Tag().String()}}}
+	err = s.stateAPI.APICall("Deployer", s.stateAPI.BestFacadeVersion("Deployer"), "", "WatchUnitChanges", args, &results)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	result := results.Results[0]
+	c.Assert(result.Error, gc.IsNil)
+
+	w := watcher.NewEntityChangesWatcher(s.stateAPI, result)
+	defer workertest.CleanKill(c, w)
+	assertChange := func(expect ...corewatcher.EntityChange) {
+		s.BackingState.StartSync()
+		select {
+		case changes, ok := <-w.Changes():
+			c.Assert(ok, jc.IsTrue)
+			c.Assert(changes, jc.DeepEquals, expect)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("watcher did not send change")
+		}
+	}
+	assertChange(corewatcher.EntityChange{Tag: principal.Tag(), Life: life.Alive})
+
+	err = principal.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	assertChange(corewatcher.EntityChange{Tag: principal.Tag(), Life: life.Dead})
+
+	err = principal.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	assertChange(corewatcher.EntityChange{Tag: principal.Tag(), Life: life.Dead, Removed: true})
+}
+
 // TODO(fwereade): 2015-11-18 lp:1517391
 func (s *watcherSuite) TestWatchMachineStorage(c *gc.C) {
 	f := factory.NewFactory(s.BackingState)
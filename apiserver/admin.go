@@ -20,6 +20,8 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/presence"
 	"github.com/juju/juju/apiserver/observer"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/rpcreflect"
@@ -39,6 +41,10 @@ type admin struct {
 
 	mu       sync.Mutex
 	loggedIn bool
+
+	// redirectInfo holds the addresses to which the login was
+	// redirected, if it was.
+	redirectInfo *params.RedirectInfoResult
 }
 
 func newAdminAPIV3(srv *Server, root *apiHandler, apiObserver observer.Observer) interface{} {
@@ -66,10 +72,16 @@ func (a *admin) Login(req params.LoginRequest) (params.LoginResult, error) {
 }
 
 // RedirectInfo returns redirected host information for the model.
-// In Juju it always returns an error because the Juju controller
-// does not multiplex controllers.
+// A login is redirected only when sticky model routing is enabled and
+// the model is assigned to another controller machine; otherwise an
+// error is returned.
 func (a *admin) RedirectInfo() (params.RedirectInfoResult, error) {
-	return params.RedirectInfoResult{}, fmt.Errorf("not redirected")
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.redirectInfo == nil {
+		return params.RedirectInfoResult{}, fmt.Errorf("not redirected")
+	}
+	return *a.redirectInfo, nil
 }
 
 var MaintenanceNoLoginError = errors.New("login failed - maintenance in progress")
@@ -99,20 +111,48 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 		return fail, errors.Trace(err)
 	}
 
+	if a.srv.stickyModelRouting && authResult.userLogin && !authResult.controllerOnlyLogin {
+		redirectInfo, err := a.modelRedirect()
+		if err != nil {
+			return fail, errors.Trace(err)
+		}
+		if redirectInfo != nil {
+			a.redirectInfo = redirectInfo
+			return fail, &params.Error{
+				Code:    params.CodeRedirect,
+				Message: "model is served by another controller",
+			}
+		}
+	}
+
 	// Fetch the API server addresses from state.
 	hostPorts, err := a.root.state.APIHostPorts()
 	if err != nil {
 		return fail, errors.Trace(err)
 	}
 
+	// A nil *pubsub.StructuredHub must not be passed on as a non-nil
+	// facade.Hub, or facades can't tell that there is no hub.
+	var hub facade.Hub
+	if a.srv.centralHub != nil {
+		hub = a.srv.centralHub
+	}
+
 	// apiRoot is the API root exposed to the client after login.
-	var apiRoot rpc.Root = newAPIRoot(
+	root := newAPIRoot(
 		a.root.state,
 		a.srv.statePool,
 		a.srv.facades,
 		a.root.resources,
 		a.root,
+		hub,
 	)
+	root.callLimiter = &callLimiter{
+		config:    a.srv.callLimitConfig,
+		clock:     a.srv.clock,
+		modelUUID: a.root.state.ModelUUID(),
+	}
+	var apiRoot rpc.Root = root
 	apiRoot, err = restrictAPIRoot(
 		a.srv,
 		apiRoot,
@@ -122,6 +162,12 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 	if err != nil {
 		return fail, errors.Trace(err)
 	}
+	if a.root.breakGlass != nil {
+		apiRoot, err = a.auditBreakGlass(apiRoot, req)
+		if err != nil {
+			return fail, errors.Trace(err)
+		}
+	}
 
 	var facadeFilters []facadeFilterFunc
 	var modelTag string
@@ -147,6 +193,81 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 	}, nil
 }
 
+// auditBreakGlass returns a root that records every API call made over
+// a connection made during a break-glass session in the audit log.
+func (a *admin) auditBreakGlass(root rpc.Root, req params.LoginRequest) (rpc.Root, error) {
+	session := a.root.breakGlass
+	modelName := ""
+	if a.root.modelUUID != "" {
+		modelName = fmt.Sprintf("%s/%s", a.root.model.Owner().Id(), a.root.model.Name())
+	}
+	recorder, err := auditlog.NewRecorder(a.srv.auditLog(), auditlog.ConversationArgs{
+		Who:               session.User.Id(),
+		What:              req.CLIArgs,
+		When:              a.srv.clock.Now(),
+		ModelName:         modelName,
+		ModelUUID:         a.root.modelUUID,
+		ConnectionID:      a.root.connectionID,
+		BreakGlassSession: session.Id,
+		BreakGlassReason:  session.Reason,
+	})
+	if err != nil {
+		// A connection whose calls cannot be recorded must not be
+		// given elevated access.
+		return nil, errors.Annotate(err, "cannot record break-glass connection")
+	}
+	return newBreakGlassRoot(root, recorder), nil
+}
+
+// modelRedirect returns the API addresses of the controller machine
+// assigned to the model being logged into, or nil if the model is not
+// assigned, is assigned to this controller, or is assigned to a
+// controller that is not available.
+func (a *admin) modelRedirect() (*params.RedirectInfoResult, error) {
+	machineTag, ok := a.srv.tag.(names.MachineTag)
+	if !ok {
+		return nil, nil
+	}
+	st := a.srv.statePool.SystemState()
+	machineId, err := st.ModelAssignment(a.root.model.UUID())
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if machineId == machineTag.Id() {
+		return nil, nil
+	}
+	machine, err := st.Machine(machineId)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if machine.Life() != state.Alive {
+		return nil, nil
+	}
+	if present, err := machine.AgentPresence(); err != nil {
+		return nil, errors.Trace(err)
+	} else if !present {
+		return nil, nil
+	}
+	controllerConfig, err := st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	hostPorts := network.AddressesWithPort(machine.Addresses(), controllerConfig.APIPort())
+	if len(hostPorts) == 0 {
+		return nil, nil
+	}
+	caCert, _ := controllerConfig.CACert()
+	logger.Debugf("redirecting login to model %q to controller machine %s", a.root.model.UUID(), machineId)
+	return &params.RedirectInfoResult{
+		Servers: params.FromNetworkHostsPorts([][]network.HostPort{hostPorts}),
+		CACert:  caCert,
+	}, nil
+}
+
 type authResult struct {
 	tag                    names.Tag // nil if external user login
 	anonymousLogin         bool
@@ -154,12 +275,18 @@ type authResult struct {
 	controllerOnlyLogin    bool
 	controllerMachineLogin bool
 	userInfo               *params.AuthUserInfo
+
+	// readOnly is true if the client asked for a read-only login,
+	// in which case the connection may only call methods that don't
+	// change anything.
+	readOnly bool
 }
 
 func (a *admin) authenticate(req params.LoginRequest) (*authResult, error) {
 	result := &authResult{
 		controllerOnlyLogin: a.root.modelUUID == "",
 		userLogin:           true,
+		readOnly:            req.ReadOnly,
 	}
 
 	// Maybe rate limit non-user auth attempts.
@@ -298,6 +425,7 @@ func (a *admin) fillLoginDetails(result *authResult, lastConnection *time.Time)
 			return errors.Trace(err)
 		}
 		result.userInfo.LastConnection = lastConnection
+		result.userInfo.ReadOnly = result.readOnly
 	}
 	if result.controllerOnlyLogin {
 		if result.anonymousLogin {
@@ -342,6 +470,22 @@ func (a *admin) checkUserPermissions(userTag names.UserTag, controllerOnlyLogin
 	} else {
 		return nil, errors.Annotatef(err, "obtaining ControllerUser for logged in user %s", userTag.Id())
 	}
+	externalAccess := a.srv.externalAccess(userTag, a.root.state.ControllerTag())
+	if externalAccess.GreaterControllerAccessThan(controllerAccess) {
+		controllerAccess = externalAccess
+	}
+	// A user in a break-glass session is a controller superuser
+	// until the session ends.
+	breakGlass, err := a.srv.breakGlassSession(userTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if breakGlass != nil {
+		logger.Warningf("user %s logged in during break-glass session %s", userTag.Id(), breakGlass.Id)
+		controllerAccess = permission.SuperuserAccess
+		a.root.breakGlass = breakGlass
+		a.root.userAccess = a.srv.withBreakGlassAccess(a.root.userAccess, breakGlass)
+	}
 	if !controllerOnlyLogin {
 		// Only grab modelUser permissions if this is not a controller only
 		// login. In all situations, if the model user is not found, they have
@@ -349,7 +493,8 @@ func (a *admin) checkUserPermissions(userTag names.UserTag, controllerOnlyLogin
 		// admin.
 
 		var err error
-		modelAccess, err = a.root.state.UserPermission(userTag, a.root.model.ModelTag())
+		userAccess := a.srv.withExternalAccess(a.root.state.UserPermission)
+		modelAccess, err = userAccess(userTag, a.root.model.ModelTag())
 		if err != nil && controllerAccess != permission.SuperuserAccess {
 			return nil, errors.Wrap(err, common.ErrPerm)
 		}
@@ -404,7 +549,7 @@ func filterFacades(registry *facade.Registry, allowFacadeAllMustMatch ...facadeF
 }
 
 func (a *admin) checkCreds(req params.LoginRequest, authTag names.Tag, userLogin bool) (state.Entity, *time.Time, error) {
-	return doCheckCreds(a.root.state, req, authTag, userLogin, a.authenticator())
+	return doCheckCreds(a.root.state, req, authTag, userLogin, a.authenticator(), a.srv.externalAccess)
 }
 
 func (a *admin) checkControllerMachineCreds(req params.LoginRequest, authTag names.MachineTag) (state.Entity, error) {
@@ -438,19 +583,23 @@ var doCheckCreds = checkCreds
 // will be modelUserEntity, not *state.User (external users don't have
 // user entries) or *state.ModelUser (we don't want to lose the local
 // user information associated with that).
+//
+// If externalAccess is not nil, external users with no model or
+// controller user may log in if it grants them access.
 func checkCreds(
 	st *state.State,
 	req params.LoginRequest,
 	authTag names.Tag,
 	userLogin bool,
 	authenticator authentication.EntityAuthenticator,
+	externalAccess func(names.UserTag, names.Tag) permission.Access,
 ) (state.Entity, *time.Time, error) {
 	var entityFinder authentication.EntityFinder = st
 	if userLogin {
 		// When looking up model users, use a custom
 		// entity finder that looks up both the local user (if the user
 		// tag is in the local domain) and the model user.
-		entityFinder = modelUserEntityFinder{st}
+		entityFinder = modelUserEntityFinder{st, externalAccess}
 	}
 	entity, err := authenticator.Authenticate(entityFinder, authTag, req)
 	if err != nil {
@@ -485,6 +634,7 @@ func checkControllerMachineCreds(
 		authTag,
 		false,
 		authenticator,
+		nil,
 	)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -514,6 +664,10 @@ type loginEntity interface {
 // authentication details such as the password.
 type modelUserEntityFinder struct {
 	st *state.State
+
+	// externalAccess, if not nil, returns the access granted to
+	// external users outside juju.
+	externalAccess func(names.UserTag, names.Tag) permission.Access
 }
 
 // FindEntity implements authentication.EntityFinder.FindEntity.
@@ -550,7 +704,7 @@ func (f modelUserEntityFinder) FindEntity(tag names.Tag) (state.Entity, error) {
 				return nil, errors.Annotatef(err, "obtaining ControllerUser for everyone group")
 			}
 		}
-		if permission.IsEmptyUserAccess(controllerUser) {
+		if permission.IsEmptyUserAccess(controllerUser) && !f.hasExternalAccess(utag, model.ModelTag()) {
 			return nil, errors.NotFoundf("model or controller user")
 		}
 	}
@@ -570,6 +724,16 @@ func (f modelUserEntityFinder) FindEntity(tag names.Tag) (state.Entity, error) {
 	return u, nil
 }
 
+// hasExternalAccess reports whether the user has been granted access
+// to the model or its controller outside juju.
+func (f modelUserEntityFinder) hasExternalAccess(utag names.UserTag, modelTag names.ModelTag) bool {
+	if f.externalAccess == nil || utag.IsLocal() {
+		return false
+	}
+	return f.externalAccess(utag, modelTag) != permission.NoAccess ||
+		f.externalAccess(utag, f.st.ControllerTag()) != permission.NoAccess
+}
+
 var _ loginEntity = &modelUserEntity{}
 
 // modelUserEntity encapsulates an model user
@@ -5,9 +5,11 @@ package apiserver_test
 
 import (
 	"fmt"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"net/url"
+	"path/filepath"
 	"strconv"
 	"sync"
 	"time"
@@ -544,6 +546,61 @@ func (s *loginSuite) TestControllerMachineLoginDuringMaintenance(c *gc.C) {
 	c.Assert(st.Close(), jc.ErrorIsNil)
 }
 
+func (s *loginSuite) TestLoginDuringBreakGlassSession(c *gc.C) {
+	cfg := defaultServerConfig(c)
+	info, srv := newServerWithConfig(c, s.pool, cfg)
+	defer assertStop(c, srv)
+
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", Password: "password"})
+	session, err := s.State.StartBreakGlassSession(bob.UserTag(), "controller down", time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+
+	info.Tag = bob.UserTag()
+	info.Password = "password"
+	st, err := api.Open(info, fastDialOpts)
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+	c.Assert(st.ControllerAccess(), gc.Equals, "superuser")
+
+	var models params.UserModelList
+	err = st.APICall("Controller", 9, "", "AllModels", nil, &models)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Ending the session revokes the elevation, even over the
+	// connections made during it.
+	err = s.State.EndBreakGlassSession(bob.UserTag(), s.AdminUserTag(c))
+	c.Assert(err, jc.ErrorIsNil)
+	err = st.APICall("Controller", 9, "", "AllModels", nil, &models)
+	c.Assert(err, gc.ErrorMatches, ".*permission denied.*")
+
+	// Every call made over the connection is recorded in the audit
+	// log, tagged with the session.
+	content, err := ioutil.ReadFile(filepath.Join(cfg.LogDir, "audit.log"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(content), jc.Contains, `"who":"bob"`)
+	c.Assert(string(content), jc.Contains, `"break-glass-reason":"controller down"`)
+	c.Assert(string(content), jc.Contains,
+		`"facade":"Controller","method":"AllModels","version":9,"break-glass-session":"`+session.Id+`"`)
+	c.Assert(string(content), jc.Contains, `"errors":[{"message":"permission denied","code":"unauthorized access"}]`)
+}
+
+func (s *loginSuite) TestLoginWithoutBreakGlassSession(c *gc.C) {
+	info, srv := newServer(c, s.pool)
+	defer assertStop(c, srv)
+
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", Password: "password"})
+	info.Tag = bob.UserTag()
+	info.Password = "password"
+	st, err := api.Open(info, fastDialOpts)
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+	c.Assert(st.ControllerAccess(), gc.Equals, "login")
+
+	var models params.UserModelList
+	err = st.APICall("Controller", 9, "", "AllModels", nil, &models)
+	c.Assert(err, gc.ErrorMatches, ".*permission denied.*")
+}
+
 func (s *baseLoginSuite) addMachine(c *gc.C, job state.MachineJob) (*state.Machine, string) {
 	machine, err := s.State.AddMachine("quantal", job)
 	c.Assert(err, jc.ErrorIsNil)
@@ -694,6 +751,70 @@ func (s *loginSuite) TestOtherModel(c *gc.C) {
 	s.assertRemoteModel(c, st, model.ModelTag())
 }
 
+func (s *loginSuite) TestStickyModelRoutingRedirects(c *gc.C) {
+	controllerConfig, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	caCert, _ := controllerConfig.CACert()
+
+	err = s.testStickyModelRouting(c, true, "1")
+	redirect, ok := errors.Cause(err).(*api.RedirectError)
+	c.Assert(ok, jc.IsTrue, gc.Commentf("unexpected error %v", err))
+	c.Assert(redirect.Servers, jc.DeepEquals, [][]network.HostPort{
+		network.NewHostPorts(controllerConfig.APIPort(), "10.0.0.2"),
+	})
+	c.Assert(redirect.CACert, gc.Equals, caCert)
+}
+
+func (s *loginSuite) TestStickyModelRoutingDisabled(c *gc.C) {
+	err := s.testStickyModelRouting(c, false, "1")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *loginSuite) TestStickyModelRoutingAssignedHere(c *gc.C) {
+	err := s.testStickyModelRouting(c, true, "0")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+// testStickyModelRouting logs into a hosted model assigned to the
+// controller machine with the given id, on an API server running as
+// machine 0, and returns the login error.
+func (s *loginSuite) testStickyModelRouting(c *gc.C, sticky bool, assignedId string) error {
+	cfg := defaultServerConfig(c)
+	cfg.StickyModelRouting = sticky
+	info, srv := newServerWithConfig(c, s.pool, cfg)
+	defer assertStop(c, srv)
+
+	s.Factory.MakeMachine(c, &factory.MachineParams{
+		Jobs: []state.MachineJob{state.JobManageModel},
+	})
+	m := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Jobs: []state.MachineJob{state.JobManageModel},
+	})
+	c.Assert(m.Id(), gc.Equals, "1")
+	err := m.SetProviderAddresses(network.NewAddress("10.0.0.2"))
+	c.Assert(err, jc.ErrorIsNil)
+	pinger, err := m.SetAgentPresence()
+	c.Assert(err, jc.ErrorIsNil)
+	defer pinger.Stop()
+	s.State.StartSync()
+	err = m.WaitAgentPresence(coretesting.LongWait)
+	c.Assert(err, jc.ErrorIsNil)
+
+	modelOwner := s.Factory.MakeUser(c, nil)
+	modelState := s.Factory.MakeModel(c, &factory.ModelParams{
+		Owner: modelOwner.UserTag(),
+	})
+	defer modelState.Close()
+	err = s.State.SetModelAssignments(map[string]string{
+		modelState.ModelUUID(): assignedId,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	info.ModelTag = names.NewModelTag(modelState.ModelUUID())
+	st := s.openAPIWithoutLogin(c, info)
+	return st.Login(modelOwner.UserTag(), "password", "", nil)
+}
+
 func (s *loginSuite) TestMachineLoginOtherModel(c *gc.C) {
 	// User credentials are checked against a global user list.
 	// Machine credentials are checked against model specific
@@ -846,6 +967,64 @@ func (s *loginSuite) TestLoginResultLocalUser(c *gc.C) {
 	c.Check(result.UserInfo.Identity, gc.Equals, user.Tag().String())
 	c.Check(result.UserInfo.ControllerAccess, gc.Equals, "login")
 	c.Check(result.UserInfo.ModelAccess, gc.Equals, "admin")
+	c.Check(result.UserInfo.ReadOnly, jc.IsFalse)
+}
+
+func (s *loginSuite) loginLocalUserWithAccess(c *gc.C, info *api.Info, access permission.Access, readOnly bool) (api.Connection, params.LoginResult) {
+	password := "shhh..."
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Password: password,
+		Access:   access,
+	})
+	conn := s.openAPIWithoutLogin(c, info)
+
+	var result params.LoginResult
+	request := &params.LoginRequest{
+		AuthTag:     user.Tag().String(),
+		Credentials: password,
+		ReadOnly:    readOnly,
+	}
+	err := conn.APICall("Admin", 3, "", "Login", request, &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.UserInfo, gc.NotNil)
+	return conn, result
+}
+
+func (s *loginSuite) assertReadOnlyConnection(c *gc.C, conn api.Connection) {
+	var cons params.GetConstraintsResults
+	err := conn.APICall("Client", 2, "", "GetModelConstraints", nil, &cons)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = conn.APICall("Client", 2, "", "SetModelConstraints", params.SetConstraints{}, nil)
+	c.Assert(err, gc.ErrorMatches, "Client.SetModelConstraints not allowed on read-only connection: permission denied")
+	c.Assert(params.IsCodeUnauthorized(err), jc.IsTrue)
+}
+
+func (s *loginSuite) TestReadOnlyLogin(c *gc.C) {
+	info, srv := newServer(c, s.pool)
+	defer assertStop(c, srv)
+	info.ModelTag = s.IAASModel.ModelTag()
+
+	conn, result := s.loginLocalUserWithAccess(c, info, permission.AdminAccess, true)
+	c.Check(result.UserInfo.ModelAccess, gc.Equals, "admin")
+	c.Check(result.UserInfo.ReadOnly, jc.IsTrue)
+	s.assertReadOnlyConnection(c, conn)
+}
+
+func (s *loginSuite) TestReadAccessLoginNotReadOnly(c *gc.C) {
+	info, srv := newServer(c, s.pool)
+	defer assertStop(c, srv)
+	info.ModelTag = s.IAASModel.ModelTag()
+
+	// Only read access to the model doesn't make the connection
+	// read-only; the client must ask for that.
+	_, result := s.loginLocalUserWithAccess(c, info, permission.ReadAccess, false)
+	c.Check(result.UserInfo.ModelAccess, gc.Equals, "read")
+	c.Check(result.UserInfo.ReadOnly, jc.IsFalse)
+
+	conn, result := s.loginLocalUserWithAccess(c, info, permission.ReadAccess, true)
+	c.Check(result.UserInfo.ReadOnly, jc.IsTrue)
+	s.assertReadOnlyConnection(c, conn)
 }
 
 func (s *loginSuite) TestLoginResultLocalUserEveryoneCreateOnlyNonLocal(c *gc.C) {
@@ -14,9 +14,12 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/facades/agent/agent" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/agent/caasoperator"
+	"github.com/juju/juju/apiserver/facades/agent/containerimagecache"
+	"github.com/juju/juju/apiserver/facades/agent/crashreporter"
 	"github.com/juju/juju/apiserver/facades/agent/deployer"
 	"github.com/juju/juju/apiserver/facades/agent/diskmanager"
 	"github.com/juju/juju/apiserver/facades/agent/fanconfigurer"
+	"github.com/juju/juju/apiserver/facades/agent/hostfirewaller"
 	"github.com/juju/juju/apiserver/facades/agent/hostkeyreporter"
 	"github.com/juju/juju/apiserver/facades/agent/keyupdater"
 	"github.com/juju/juju/apiserver/facades/agent/leadership"
@@ -37,6 +40,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/unitassigner"
 	"github.com/juju/juju/apiserver/facades/agent/uniter"
 	"github.com/juju/juju/apiserver/facades/agent/upgrader"
+	"github.com/juju/juju/apiserver/facades/agent/utilizationreporter"
 	"github.com/juju/juju/apiserver/facades/client/action"
 	"github.com/juju/juju/apiserver/facades/client/annotations" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/application" // ModelUser Write
@@ -44,47 +48,70 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/backups" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/block"   // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/bundle"
-	"github.com/juju/juju/apiserver/facades/client/charms"     // ModelUser Write
-	"github.com/juju/juju/apiserver/facades/client/client"     // ModelUser Write
-	"github.com/juju/juju/apiserver/facades/client/cloud"      // ModelUser Read
-	"github.com/juju/juju/apiserver/facades/client/controller" // ModelUser Admin (although some methods check for read only)
+	"github.com/juju/juju/apiserver/facades/client/charms"        // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/charmupgrades" // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/client"        // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/cloud"         // ModelUser Read
+	"github.com/juju/juju/apiserver/facades/client/controller"    // ModelUser Admin (although some methods check for read only)
+	"github.com/juju/juju/apiserver/facades/client/controllercertificates"
+	"github.com/juju/juju/apiserver/facades/client/controllerhealth"
+	"github.com/juju/juju/apiserver/facades/client/costestimation" // ModelUser Read
+	"github.com/juju/juju/apiserver/facades/client/crashreports"   // ModelUser Admin
+	"github.com/juju/juju/apiserver/facades/client/fanconfig"      // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/firewallrules"
 	"github.com/juju/juju/apiserver/facades/client/highavailability" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/imagemanager"     // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/imagemetadatamanager"
-	"github.com/juju/juju/apiserver/facades/client/keymanager"     // ModelUser Write
-	"github.com/juju/juju/apiserver/facades/client/machinemanager" // ModelUser Write
-	"github.com/juju/juju/apiserver/facades/client/metricsdebug"   // ModelUser Write
-	"github.com/juju/juju/apiserver/facades/client/modelconfig"    // ModelUser Write
-	"github.com/juju/juju/apiserver/facades/client/modelmanager"   // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/keymanager"         // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/loadtest"           // ModelUser Admin
+	"github.com/juju/juju/apiserver/facades/client/machinemanager"     // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/maintenance"        // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/metricsdebug"       // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/modelapply"         // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/modelconfig"        // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/modelevents"        // ModelUser Read
+	"github.com/juju/juju/apiserver/facades/client/modelgeneration"    // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/modelmanager"       // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/modelsnapshots"     // ModelUser Admin
+	"github.com/juju/juju/apiserver/facades/client/modelvisualization" // ModelUser Read
+	"github.com/juju/juju/apiserver/facades/client/orphanedresources"
 	"github.com/juju/juju/apiserver/facades/client/payloads"
+	"github.com/juju/juju/apiserver/facades/client/pubsubtopology"
+	"github.com/juju/juju/apiserver/facades/client/quotas"
 	"github.com/juju/juju/apiserver/facades/client/resources"
 	"github.com/juju/juju/apiserver/facades/client/spaces"    // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/sshclient" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/storage"
 	"github.com/juju/juju/apiserver/facades/client/subnets"
+	"github.com/juju/juju/apiserver/facades/client/truststore"
 	"github.com/juju/juju/apiserver/facades/client/usermanager"
+	"github.com/juju/juju/apiserver/facades/client/utilization"
 	"github.com/juju/juju/apiserver/facades/controller/actionpruner"
 	"github.com/juju/juju/apiserver/facades/controller/agenttools"
 	"github.com/juju/juju/apiserver/facades/controller/applicationscaler"
 	"github.com/juju/juju/apiserver/facades/controller/caasfirewaller"
 	"github.com/juju/juju/apiserver/facades/controller/caasoperatorprovisioner"
 	"github.com/juju/juju/apiserver/facades/controller/caasunitprovisioner"
+	"github.com/juju/juju/apiserver/facades/controller/charmautorefresh"
 	"github.com/juju/juju/apiserver/facades/controller/charmrevisionupdater"
 	"github.com/juju/juju/apiserver/facades/controller/cleaner"
 	"github.com/juju/juju/apiserver/facades/controller/crosscontroller"
 	"github.com/juju/juju/apiserver/facades/controller/crossmodelrelations"
+	"github.com/juju/juju/apiserver/facades/controller/dnsupdater"
 	"github.com/juju/juju/apiserver/facades/controller/externalcontrollerupdater"
 	"github.com/juju/juju/apiserver/facades/controller/firewaller"
 	"github.com/juju/juju/apiserver/facades/controller/imagemetadata"
 	"github.com/juju/juju/apiserver/facades/controller/instancepoller"
+	"github.com/juju/juju/apiserver/facades/controller/lbmanager"
 	"github.com/juju/juju/apiserver/facades/controller/lifeflag"
 	"github.com/juju/juju/apiserver/facades/controller/logfwd"
 	"github.com/juju/juju/apiserver/facades/controller/machineundertaker"
 	"github.com/juju/juju/apiserver/facades/controller/metricsmanager"
 	"github.com/juju/juju/apiserver/facades/controller/migrationmaster"
 	"github.com/juju/juju/apiserver/facades/controller/migrationtarget" // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/controller/modelsnapshotter"
 	"github.com/juju/juju/apiserver/facades/controller/modelupgrader"
+	"github.com/juju/juju/apiserver/facades/controller/orphansweeper"
 	"github.com/juju/juju/apiserver/facades/controller/remoterelations"
 	"github.com/juju/juju/apiserver/facades/controller/resumer"
 	"github.com/juju/juju/apiserver/facades/controller/singular"
@@ -135,17 +162,31 @@ func AllFacades() *facade.Registry {
 	reg("Application", 2, application.NewFacadeV4)
 	reg("Application", 3, application.NewFacadeV4)
 	reg("Application", 4, application.NewFacadeV4)
-	reg("Application", 5, application.NewFacade) // adds AttachStorage & UpdateApplicationSeries & SetRelationStatus
+	reg("Application", 5, application.NewFacadeV5)   // adds AttachStorage & UpdateApplicationSeries & SetRelationStatus
+	reg("Application", 6, application.NewFacadeV6)   // adds drain and force to DestroyUnit
+	reg("Application", 7, application.NewFacadeV7)   // adds DestroyPlan
+	reg("Application", 8, application.NewFacadeV8)   // adds SetRelationBrokenBarrier
+	reg("Application", 9, application.NewFacadeV9)   // adds UpdateStorageConstraints
+	reg("Application", 10, application.NewFacadeV10) // adds SetTrust
+	reg("Application", 11, application.NewFacadeV11) // adds Deploy warnings and AddRelation interface version checks
+	reg("Application", 12, application.NewFacadeV12) // adds GetConstraintsDetail
+	reg("Application", 13, application.NewFacadeV13) // adds PlacementPlan
+	reg("Application", 14, application.NewFacadeV14) // adds SetRefreshPolicy
+	reg("Application", 15, application.NewFacade)    // adds PlacementMachines and placement conflict errors
 
 	reg("ApplicationOffers", 1, applicationoffers.NewOffersAPI)
 	reg("ApplicationScaler", 1, applicationscaler.NewAPI)
 	reg("Backups", 1, backups.NewFacade)
-	reg("Block", 2, block.NewAPI)
+	reg("Block", 2, block.NewAPIv2)
+	reg("Block", 3, block.NewAPI)
 	reg("Bundle", 1, bundle.NewFacade)
+	reg("CharmAutoRefresh", 1, charmautorefresh.NewFacade)
 	reg("CharmRevisionUpdater", 2, charmrevisionupdater.NewCharmRevisionUpdaterAPI)
+	reg("CharmUpgrades", 1, charmupgrades.NewFacade)
 	reg("Charms", 2, charms.NewFacade)
 	reg("Cleaner", 2, cleaner.NewCleanerAPI)
-	reg("Client", 1, client.NewFacade)
+	reg("Client", 1, client.NewFacadeV1)
+	reg("Client", 2, client.NewFacade) // adds AsOf to FullStatus
 	reg("Cloud", 1, cloud.NewFacade)
 	if featureflag.Enabled(feature.CAAS) {
 		// CAAS related facades.
@@ -157,42 +198,71 @@ func AllFacades() *facade.Registry {
 		reg("CAASUnitProvisioner", 1, caasunitprovisioner.NewStateFacade)
 	}
 
+	reg("ContainerImageCache", 1, containerimagecache.NewFacade)
 	reg("Controller", 3, controller.NewControllerAPIv3)
 	reg("Controller", 4, controller.NewControllerAPIv4)
+	reg("Controller", 5, controller.NewControllerAPIv5) // Adds AgentBinaryStorageUsage.
+	reg("Controller", 6, controller.NewControllerAPIv6) // Adds AgentConnectionHistory.
+	reg("Controller", 7, controller.NewControllerAPIv7) // Adds AbortAfterSuccess.
+	reg("Controller", 8, controller.NewControllerAPIv8) // Adds DatabaseReport.
+	reg("Controller", 9, controller.NewControllerAPIv9) // Adds StartBreakGlassSession, EndBreakGlassSessions and BreakGlassSessions.
+	reg("ControllerCertificates", 1, controllercertificates.NewFacade)
+	reg("ControllerHealth", 1, controllerhealth.NewFacade)
+	reg("CostEstimation", 1, costestimation.NewFacade)
+	reg("CrashReporter", 1, crashreporter.NewFacade)
+	reg("CrashReports", 1, crashreports.NewFacade)
 	reg("CrossModelRelations", 1, crossmodelrelations.NewStateCrossModelRelationsAPI)
 	reg("CrossController", 1, crosscontroller.NewStateCrossControllerAPI)
 	reg("ExternalControllerUpdater", 1, externalcontrollerupdater.NewStateAPI)
 
-	reg("Deployer", 1, deployer.NewDeployerAPI)
+	reg("Deployer", 1, deployer.NewDeployerAPIV1)
+	reg("Deployer", 2, deployer.NewDeployerAPI) // Adds WatchUnitChanges.
 	reg("DiskManager", 2, diskmanager.NewDiskManagerAPI)
+	reg("DNSUpdater", 1, dnsupdater.NewFacade)
 	reg("FanConfigurer", 1, fanconfigurer.NewFanConfigurerAPI)
+	reg("FanConfigurer", 2, fanconfigurer.NewFanConfigurerAPIV2) // Adds SetFanStatus.
+	reg("FanConfig", 1, fanconfig.NewFacade)
 	reg("Firewaller", 3, firewaller.NewStateFirewallerAPIV3)
 	reg("Firewaller", 4, firewaller.NewStateFirewallerAPIV4)
-	reg("FirewallRules", 1, firewallrules.NewFacade)
-	reg("HighAvailability", 2, highavailability.NewHighAvailabilityAPI)
+	reg("Firewaller", 5, firewaller.NewStateFirewallerAPIV5) // Adds SetFirewallReports.
+	reg("Firewaller", 6, firewaller.NewStateFirewallerAPIV6) // Adds WatchModelMachineChanges and WatchUnitChanges.
+	reg("FirewallRules", 1, firewallrules.NewFacadeV1)
+	reg("FirewallRules", 2, firewallrules.NewFacade) // Adds FirewallReport.
+	reg("HighAvailability", 2, highavailability.NewHighAvailabilityAPIV2)
+	reg("HighAvailability", 3, highavailability.NewHighAvailabilityAPI) // adds per-machine specs and progress
+	reg("HostFirewaller", 1, hostfirewaller.NewFacade)
 	reg("HostKeyReporter", 1, hostkeyreporter.NewFacade)
 	reg("ImageManager", 2, imagemanager.NewImageManagerAPI)
 	reg("ImageMetadata", 3, imagemetadata.NewAPI)
-
-	if featureflag.Enabled(feature.ImageMetadata) {
-		reg("ImageMetadataManager", 1, imagemetadatamanager.NewAPI)
-	}
-
+	reg("ImageMetadataManager", 1, imagemetadatamanager.NewAPI)
 	reg("InstancePoller", 3, instancepoller.NewFacade)
 	reg("KeyManager", 1, keymanager.NewKeyManagerAPI)
 	reg("KeyUpdater", 1, keyupdater.NewKeyUpdaterAPI)
+	reg("LBManager", 1, lbmanager.NewFacade)
 	reg("LeadershipService", 2, leadership.NewLeadershipServiceFacade)
 	reg("LifeFlag", 1, lifeflag.NewExternalFacade)
-	reg("Logger", 1, loggerapi.NewLoggerAPI)
+	if featureflag.Enabled(feature.LoadTesting) {
+		// Populates models with synthetic applications for load
+		// testing; never registered in production controllers.
+		reg("LoadTest", 1, loadtest.NewFacade)
+	}
+	reg("Logger", 1, loggerapi.NewLoggerAPIV1)
+	reg("Logger", 2, loggerapi.NewLoggerAPI)
 	reg("LogForwarding", 1, logfwd.NewFacade)
 	reg("MachineActions", 1, machineactions.NewExternalFacade)
 
 	reg("MachineManager", 2, machinemanager.NewFacade)
 	reg("MachineManager", 3, machinemanager.NewFacade)   // Version 3 adds DestroyMachine and ForceDestroyMachine.
 	reg("MachineManager", 4, machinemanager.NewFacadeV4) // Version 4 adds DestroyMachineWithParams.
+	reg("MachineManager", 5, machinemanager.NewFacadeV5) // Version 5 adds CloudInitUserData.
+	reg("MachineManager", 6, machinemanager.NewFacadeV6) // Version 6 adds ContainerImageCache and PurgeContainerImages.
 
 	reg("MachineUndertaker", 1, machineundertaker.NewFacade)
-	reg("Machiner", 1, machine.NewMachinerAPI)
+	reg("Maintenance", 1, maintenance.NewFacadeV1)
+	reg("Maintenance", 2, maintenance.NewFacadeV2) // adds maintenance windows
+	reg("Maintenance", 3, maintenance.NewFacade)   // adds offline agent acknowledgements
+	reg("Machiner", 1, machine.NewMachinerAPIV1)
+	reg("Machiner", 2, machine.NewMachinerAPI) // Adds ReportInterruptions.
 
 	reg("MeterStatus", 1, meterstatus.NewMeterStatusAPI)
 	reg("MetricsAdder", 2, metricsadder.NewMetricsAdderAPI)
@@ -201,14 +271,29 @@ func AllFacades() *facade.Registry {
 
 	reg("MigrationFlag", 1, migrationflag.NewFacade)
 	reg("MigrationMaster", 1, migrationmaster.NewFacade)
+	reg("MigrationMaster", 2, migrationmaster.NewFacade) // v2 adds SourceControllerInfo() method.
 	reg("MigrationMinion", 1, migrationminion.NewFacade)
 	reg("MigrationTarget", 1, migrationtarget.NewFacade)
-
-	reg("ModelConfig", 1, modelconfig.NewFacade)
+	reg("MigrationTarget", 2, migrationtarget.NewFacade) // v2 adds CheckAgents() and Rollback() methods.
+
+	reg("ModelApply", 1, modelapply.NewFacade)
+	reg("ModelConfig", 1, modelconfig.NewFacadeV1)
+	reg("ModelConfig", 2, modelconfig.NewFacadeV2)
+	reg("ModelConfig", 3, modelconfig.NewFacade)
+	reg("ModelEvents", 1, modelevents.NewFacade)
+	reg("ModelGeneration", 1, modelgeneration.NewFacade)
 	reg("ModelManager", 2, modelmanager.NewFacadeV2)
 	reg("ModelManager", 3, modelmanager.NewFacadeV3)
 	reg("ModelManager", 4, modelmanager.NewFacadeV4)
+	reg("ModelManager", 5, modelmanager.NewFacadeV5)
+	reg("ModelManager", 6, modelmanager.NewFacadeV6) // Adds ModelUserActivity.
+	reg("ModelSnapshots", 1, modelsnapshots.NewFacade)
+	reg("ModelSnapshotter", 1, modelsnapshotter.NewFacade)
 	reg("ModelUpgrader", 1, modelupgrader.NewStateFacade)
+	reg("ModelVisualization", 1, modelvisualization.NewFacade)
+
+	reg("OrphanSweeper", 1, orphansweeper.NewFacade)
+	reg("OrphanedResources", 1, orphanedresources.NewFacade)
 
 	reg("Payloads", 1, payloads.NewFacade)
 	regHookContext(
@@ -221,7 +306,11 @@ func AllFacades() *facade.Registry {
 	reg("Provisioner", 3, provisioner.NewProvisionerAPI)
 	reg("Provisioner", 4, provisioner.NewProvisionerAPI)
 	reg("Provisioner", 5, provisioner.NewProvisionerAPIV5) // v5 adds DistributionGroupByMachineId()
-	reg("ProxyUpdater", 1, proxyupdater.NewAPI)
+	reg("Provisioner", 6, provisioner.NewProvisionerAPIV6) // v6 adds CharmProfiles() and SetCharmProfiles()
+	reg("ProxyUpdater", 1, proxyupdater.NewAPIV1)
+	reg("ProxyUpdater", 2, proxyupdater.NewAPI) // Version 2 adds SetProxyStatus.
+	reg("PubSubTopology", 1, pubsubtopology.NewFacade)
+	reg("Quotas", 1, quotas.NewFacade)
 	reg("Reboot", 2, reboot.NewRebootAPI)
 	reg("RemoteRelations", 1, remoterelations.NewStateRemoteRelationsAPI)
 
@@ -246,21 +335,31 @@ func AllFacades() *facade.Registry {
 
 	reg("Storage", 3, storage.NewFacadeV3)
 	reg("Storage", 4, storage.NewFacadeV4) // changes Destroy() method signature.
+	reg("Storage", 5, storage.NewFacadeV5) // adds StatusHistory() for volumes and filesystems.
 
 	reg("StorageProvisioner", 3, storageprovisioner.NewFacadeV3)
 	reg("StorageProvisioner", 4, storageprovisioner.NewFacadeV4)
 	reg("Subnets", 2, subnets.NewAPI)
+	reg("TrustStore", 1, truststore.NewFacade)
 	reg("Undertaker", 1, undertaker.NewUndertakerAPI)
 	reg("UnitAssigner", 1, unitassigner.New)
 
 	reg("Uniter", 4, uniter.NewUniterAPIV4)
 	reg("Uniter", 5, uniter.NewUniterAPIV5)
 	reg("Uniter", 6, uniter.NewUniterAPIV6)
-	reg("Uniter", 7, uniter.NewUniterAPI)
+	reg("Uniter", 7, uniter.NewUniterAPIV7)
+	reg("Uniter", 8, uniter.NewUniterAPIV8)
+	reg("Uniter", 9, uniter.NewUniterAPIV9)
+	reg("Uniter", 10, uniter.NewUniterAPIV10)
+	reg("Uniter", 11, uniter.NewUniterAPIV11)
+	reg("Uniter", 12, uniter.NewUniterAPI)
 
 	reg("Upgrader", 1, upgrader.NewUpgraderFacade)
 	reg("UserManager", 1, usermanager.NewUserManagerAPI)
 	reg("UserManager", 2, usermanager.NewUserManagerAPI) // Adds ResetPassword
+	reg("UserManager", 3, usermanager.NewUserManagerAPI) // Adds UserActivity
+	reg("Utilization", 1, utilization.NewFacade)
+	reg("UtilizationReporter", 1, utilizationreporter.NewFacade)
 
 	regRaw("AllWatcher", 1, NewAllWatcher, reflect.TypeOf((*SrvAllWatcher)(nil)))
 	// Note: AllModelWatcher uses the same infrastructure as AllWatcher
@@ -276,6 +375,7 @@ func AllFacades() *facade.Registry {
 	regRaw("VolumeAttachmentsWatcher", 2, newVolumeAttachmentsWatcher, reflect.TypeOf((*srvMachineStorageIdsWatcher)(nil)))
 	regRaw("FilesystemAttachmentsWatcher", 2, newFilesystemAttachmentsWatcher, reflect.TypeOf((*srvMachineStorageIdsWatcher)(nil)))
 	regRaw("EntityWatcher", 2, newEntitiesWatcher, reflect.TypeOf((*srvEntitiesWatcher)(nil)))
+	regRaw("EntityChangesWatcher", 1, newEntityChangesWatcher, reflect.TypeOf((*srvEntityChangesWatcher)(nil)))
 	regRaw("MigrationStatusWatcher", 1, newMigrationStatusWatcher, reflect.TypeOf((*srvMigrationStatusWatcher)(nil)))
 
 	return registry
@@ -8,6 +8,7 @@ import (
 	"crypto/tls"
 	"io"
 	"log"
+	"math/rand"
 	"net"
 	"net/http"
 	"path"
@@ -39,6 +40,8 @@ import (
 	"github.com/juju/juju/apiserver/logsink"
 	"github.com/juju/juju/apiserver/observer"
 	"github.com/juju/juju/apiserver/websocket"
+	"github.com/juju/juju/cert"
+	"github.com/juju/juju/core/auditlog"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/resourceadapters"
 	"github.com/juju/juju/rpc"
@@ -89,24 +92,50 @@ type Server struct {
 	totalConn              int64
 	loginAttempts          int64
 	getCertificate         func() *tls.Certificate
+	autocertDNSName        string
 	tlsConfig              *tls.Config
 	allowModelAccess       bool
+	stickyModelRouting     bool
 	logSinkWriter          io.WriteCloser
 	logsinkRateLimitConfig logsink.RateLimitConfig
 	dbloggers              dbloggers
 	upgradeComplete        func() bool
 	restoreStatus          func() state.RestoreStatus
+	drainRequested         <-chan struct{}
+	drainTimeout           time.Duration
+	debugHooks             *debugHooksBroker
+	agentConnections       *agentConnectionHistory
+	userActivity           *userActivityRecorder
+	externalAuthorizer     authentication.ExternalAuthorizer
+	callLimitConfig        CallLimitConfig
+	payloadMetrics         *PayloadMetrics
+
+	// draining is closed when the server starts draining its
+	// connections.
+	draining chan struct{}
 
 	// mu guards the fields below it.
 	mu sync.Mutex
 
 	// publicDNSName_ holds the value that will be returned in
-	// LoginResult.PublicDNSName. Currently this is set once from
-	// AutocertDNSName and does not change but in the future it
-	// may change when a server certificate is explicitly set,
-	// hence it's here guarded by the mutex.
+	// LoginResult.PublicDNSName. It is set from AutocertDNSName
+	// if that is configured, and otherwise from the first DNS
+	// name of the API certificate held in state, if any.
 	publicDNSName_ string
 
+	// apiCertificate_ holds the certificate set in state by the
+	// controller's administrator or obtained from an ACME CA, if
+	// any. It is presented in place of the local certificate to
+	// connections addressed to a name it is valid for.
+	apiCertificate_ *tls.Certificate
+
+	// auditLog_ holds the audit log in which the API calls made
+	// during break-glass sessions are recorded. If it was not
+	// configured, it is opened when first needed, and ownAuditLog
+	// is set so that it is closed when the server exits.
+	auditLog_   auditlog.AuditLog
+	ownAuditLog bool
+
 	// registerIntrospectionHandlers is a function that will
 	// call a function with (path, http.Handler) tuples. This
 	// is to support registering the handlers underneath the
@@ -156,6 +185,11 @@ type ServerConfig struct {
 	// they don't have access to the controller.
 	AllowModelAccess bool
 
+	// StickyModelRouting holds whether users' logins to a hosted
+	// model are redirected to the controller machine assigned to
+	// run the model's workers, if that is not this one.
+	StickyModelRouting bool
+
 	// NewObserver is a function which will return an observer. This
 	// is used per-connection to instantiate a new observer to be
 	// notified of key events during API requests.
@@ -178,6 +212,34 @@ type ServerConfig struct {
 
 	// PrometheusRegisterer registers Prometheus collectors.
 	PrometheusRegisterer prometheus.Registerer
+
+	// Drain, if non-nil, is closed to request that the server
+	// drains its connections before exiting. A draining server
+	// stops accepting connections, closes the established ones
+	// at random times over the first half of DrainTimeout, so
+	// that their clients reconnect to other controllers over a
+	// period rather than all at once, and waits up to DrainTimeout
+	// for in-flight requests to complete.
+	Drain <-chan struct{}
+
+	// DrainTimeout holds the longest time the server will spend
+	// draining its connections.
+	DrainTimeout time.Duration
+
+	// ExternalAuthorizer, if non-nil, grants external users access
+	// in addition to that recorded in juju, such as by mapping
+	// their directory groups to access levels.
+	ExternalAuthorizer authentication.ExternalAuthorizer
+
+	// CallLimitConfig holds parameters to control API call timeouts
+	// and slow call logging. If this is nil, the values from
+	// DefaultCallLimitConfig() will be used.
+	CallLimitConfig *CallLimitConfig
+
+	// AuditLog, if non-nil, records the API calls made during
+	// break-glass sessions. If it is nil, they are recorded in
+	// audit.log in LogDir.
+	AuditLog auditlog.AuditLog
 }
 
 // Validate validates the API server configuration.
@@ -200,6 +262,9 @@ func (c ServerConfig) Validate() error {
 	if c.RestoreStatus == nil {
 		return errors.NotValidf("nil RestoreStatus")
 	}
+	if c.Drain != nil && c.DrainTimeout <= 0 {
+		return errors.NotValidf("non-positive DrainTimeout")
+	}
 	if err := c.RateLimitConfig.Validate(); err != nil {
 		return errors.Annotate(err, "validating rate limit configuration")
 	}
@@ -208,6 +273,11 @@ func (c ServerConfig) Validate() error {
 			return errors.Annotate(err, "validating logsink configuration")
 		}
 	}
+	if c.CallLimitConfig != nil {
+		if err := c.CallLimitConfig.Validate(); err != nil {
+			return errors.Annotate(err, "validating call limit configuration")
+		}
+	}
 	return nil
 }
 
@@ -336,6 +406,10 @@ func NewServer(stPool *state.StatePool, lis net.Listener, cfg ServerConfig) (*Se
 		logSinkConfig := DefaultLogSinkConfig()
 		cfg.LogSinkConfig = &logSinkConfig
 	}
+	if cfg.CallLimitConfig == nil {
+		callLimitConfig := DefaultCallLimitConfig()
+		cfg.CallLimitConfig = &callLimitConfig
+	}
 	if err := cfg.Validate(); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -371,11 +445,22 @@ func newServer(stPool *state.StatePool, lis net.Listener, cfg ServerConfig) (_ *
 		loginRetryPause:               cfg.RateLimitConfig.LoginRetryPause,
 		upgradeComplete:               cfg.UpgradeComplete,
 		restoreStatus:                 cfg.RestoreStatus,
+		drainRequested:                cfg.Drain,
+		drainTimeout:                  cfg.DrainTimeout,
+		draining:                      make(chan struct{}),
+		debugHooks:                    newDebugHooksBroker(),
+		agentConnections:              newAgentConnectionHistory(cfg.Clock),
+		userActivity:                  newUserActivityRecorder(cfg.Clock),
+		externalAuthorizer:            cfg.ExternalAuthorizer,
+		callLimitConfig:               *cfg.CallLimitConfig,
 		facades:                       AllFacades(),
 		centralHub:                    cfg.Hub,
 		getCertificate:                cfg.GetCertificate,
+		autocertDNSName:               cfg.AutocertDNSName,
 		allowModelAccess:              cfg.AllowModelAccess,
+		stickyModelRouting:            cfg.StickyModelRouting,
 		publicDNSName_:                cfg.AutocertDNSName,
+		auditLog_:                     cfg.AuditLog,
 		registerIntrospectionHandlers: cfg.RegisterIntrospectionHandlers,
 		logsinkRateLimitConfig: logsink.RateLimitConfig{
 			Refill: cfg.LogSinkConfig.RateLimitRefill,
@@ -417,6 +502,12 @@ func newServer(stPool *state.StatePool, lis net.Listener, cfg ServerConfig) (_ *
 		if err := cfg.PrometheusRegisterer.Register(apiserverCollectior); err != nil {
 			return nil, errors.Annotate(err, "registering apiserver metrics collector")
 		}
+		payloadMetrics := NewPayloadMetrics()
+		cfg.PrometheusRegisterer.Unregister(payloadMetrics)
+		if err := cfg.PrometheusRegisterer.Register(payloadMetrics); err != nil {
+			return nil, errors.Annotate(err, "registering apiserver payload metrics")
+		}
+		srv.payloadMetrics = payloadMetrics
 	}
 
 	go func() {
@@ -448,33 +539,39 @@ func (a *metricAdaptor) ConnectionPauseTime() time.Duration {
 
 func (srv *Server) newTLSConfig(cfg ServerConfig) *tls.Config {
 	tlsConfig := utils.SecureTLSConfig()
-	if cfg.AutocertDNSName == "" {
-		// No official DNS name, no certificate.
-		tlsConfig.GetCertificate = func(clientHello *tls.ClientHelloInfo) (*tls.Certificate, error) {
-			cert, _ := srv.localCertificate(clientHello.ServerName)
-			return cert, nil
+	var m *autocert.Manager
+	if cfg.AutocertDNSName != "" {
+		m = &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			Cache:      srv.statePool.SystemState().AutocertCache(),
+			HostPolicy: autocert.HostWhitelist(cfg.AutocertDNSName),
 		}
-		return tlsConfig
-	}
-	m := autocert.Manager{
-		Prompt:     autocert.AcceptTOS,
-		Cache:      srv.statePool.SystemState().AutocertCache(),
-		HostPolicy: autocert.HostWhitelist(cfg.AutocertDNSName),
-	}
-	if cfg.AutocertURL != "" {
-		m.Client = &acme.Client{
-			DirectoryURL: cfg.AutocertURL,
+		if cfg.AutocertURL != "" {
+			m.Client = &acme.Client{
+				DirectoryURL: cfg.AutocertURL,
+			}
 		}
 	}
+	// GetCertificate is called for each new connection, so changes
+	// to the certificates are seen by new connections without
+	// affecting those already established.
 	tlsConfig.GetCertificate = func(clientHello *tls.ClientHelloInfo) (*tls.Certificate, error) {
-		logger.Infof("getting certificate for server name %q", clientHello.ServerName)
 		// Get the locally created certificate and whether it's appropriate
-		// for the SNI name. If not, we'll try to get an acme cert and
-		// fall back to the local certificate if that fails.
+		// for the SNI name. If not, we'll try the API certificate held in
+		// state and then, if configured, an autocert certificate, falling
+		// back to the local certificate if neither is valid.
 		cert, shouldUse := srv.localCertificate(clientHello.ServerName)
 		if shouldUse {
 			return cert, nil
 		}
+		if apiCert, ok := srv.apiCertificate(clientHello.ServerName); ok {
+			return apiCert, nil
+		}
+		if m == nil {
+			// No official DNS name, no certificate.
+			return cert, nil
+		}
+		logger.Infof("getting certificate for server name %q", clientHello.ServerName)
 		acmeCert, err := m.GetCertificate(clientHello)
 		if err == nil {
 			return acmeCert, nil
@@ -539,16 +636,25 @@ func (w *loggoWrapper) Write(content []byte) (int, error) {
 
 // loop is the main loop for the server.
 func (srv *Server) loop() error {
-	logger.Infof("listening on %q", srv.lis.Addr())
+	addr := srv.lis.Addr().String() // Addr not valid after close
+	logger.Infof("listening on %q", addr)
+
+	// The listener is closed early when the server drains.
+	var closeOnce sync.Once
+	closeListener := func() {
+		closeOnce.Do(func() {
+			err := srv.lis.Close()
+			logger.Infof("closed listening socket %q with final error: %v", addr, err)
+		})
+	}
 
 	defer func() {
-		addr := srv.lis.Addr().String() // Addr not valid after close
-		err := srv.lis.Close()
-		logger.Infof("closed listening socket %q with final error: %v", addr, err)
-
+		closeListener()
 		srv.wg.Wait() // wait for any outstanding requests to complete.
+		srv.userActivity.flush(srv.statePool.SystemState())
 		srv.dbloggers.dispose()
 		srv.logSinkWriter.Close()
+		srv.closeAuditLog()
 	}()
 
 	// for pat based handlers, they are matched in-order of being
@@ -579,10 +685,35 @@ func (srv *Server) loop() error {
 		logger.Debugf("API http server exited, final error was: %v", err)
 	}()
 
+	certWatcher := srv.statePool.SystemState().WatchAPICertificate()
+	defer certWatcher.Stop()
+
+	userActivityTimer := srv.clock.NewTimer(userActivityFlushInterval)
+	defer userActivityTimer.Stop()
+
 	for {
 		select {
 		case <-srv.tomb.Dying():
+			// The agent may be stopped as soon as the drain is
+			// requested, but the drain should still happen.
+			select {
+			case <-srv.drainRequested:
+				return srv.drain(closeListener)
+			default:
+			}
 			return tomb.ErrDying
+		case <-srv.drainRequested:
+			return srv.drain(closeListener)
+		case _, ok := <-certWatcher.Changes():
+			if !ok {
+				return errors.Annotate(certWatcher.Err(), "API certificate watcher closed")
+			}
+			if err := srv.updateAPICertificate(); err != nil {
+				return errors.Annotate(err, "updating API certificate")
+			}
+		case <-userActivityTimer.Chan():
+			srv.userActivity.flush(srv.statePool.SystemState())
+			userActivityTimer.Reset(userActivityFlushInterval)
 		case <-srv.clock.After(authentication.LocalLoginInteractionTimeout):
 			now := srv.loginAuthCtxt.clock.Now()
 			srv.loginAuthCtxt.localUserInteractions.Expire(now)
@@ -590,6 +721,35 @@ func (srv *Server) loop() error {
 	}
 }
 
+// drain stops the server from accepting new connections, and waits
+// for the established ones to close, up to the drain timeout. Any
+// connections that remain are closed when the server exits.
+func (srv *Server) drain(closeListener func()) error {
+	logger.Infof("draining %d API connections", srv.ConnectionCount())
+	close(srv.draining)
+	closeListener()
+
+	drained := make(chan struct{})
+	go func() {
+		srv.wg.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+		logger.Infof("API connections drained")
+	case <-srv.clock.After(srv.drainTimeout):
+		logger.Warningf("%d API connections still open after %v", srv.ConnectionCount(), srv.drainTimeout)
+	}
+	return nil
+}
+
+// drainDelay returns a random duration, within the first half of
+// the drain timeout, after which a draining server closes a
+// connection.
+func (srv *Server) drainDelay() time.Duration {
+	return time.Duration(rand.Int63n(int64(srv.drainTimeout/2) + 1))
+}
+
 func (srv *Server) endpoints() []apihttp.Endpoint {
 	var endpoints []apihttp.Endpoint
 
@@ -627,6 +787,10 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	add("/model/:modeluuid/pubsub", pubsubHandler)
 	add("/model/:modeluuid/logstream", logStreamHandler)
 	add("/model/:modeluuid/log", debugLogHandler)
+	add("/model/:modeluuid/units/:unit/debug-hooks",
+		srv.trackRequests(newDebugHooksClientHandler(httpCtxt, srv.debugHooks)))
+	add("/model/:modeluuid/units/:unit/debug-hooks/agent",
+		srv.trackRequests(newDebugHooksAgentHandler(httpCtxt, srv.debugHooks)))
 
 	logSinkHandler := logsink.NewHTTPHandler(
 		newAgentLogWriteCloserFunc(httpCtxt, srv.logSinkWriter, &srv.dbloggers),
@@ -745,6 +909,12 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	add("/gui-version", &guiVersionHandler{
 		ctxt: httpCtxt,
 	})
+	add("/capabilities", &capabilitiesHandler{
+		facades: srv.facades,
+	})
+	pingMongo := newCachedPing(srv.clock, srv.pingMongo)
+	add("/health", srv.newHealthHandler(false, pingMongo.Ping))
+	add("/readiness", srv.newHealthHandler(true, pingMongo.Ping))
 
 	// For backwards compatibility we register all the old paths
 	add("/log", debugLogHandler)
@@ -847,6 +1017,10 @@ func (srv *Server) trackRequests(handler http.Handler) http.Handler {
 			// shutting down, do not consider this request as in progress,
 			// just send a 503 and return.
 			http.Error(w, "apiserver shutdown in progress", 503)
+		case <-srv.draining:
+			// The server is draining its connections, so the
+			// client should go to another controller.
+			http.Error(w, "apiserver draining", 503)
 		default:
 			// If we get here then the tomb was not killed therefore the
 			// listener is still open. It is safe to increment the
@@ -876,7 +1050,11 @@ func (srv *Server) apiHandler(w http.ResponseWriter, req *http.Request) {
 
 	connectionID := atomic.AddUint64(&srv.lastConnectionID, 1)
 
-	apiObserver := srv.newObserver()
+	apiObserver := observer.NewMultiplexer(
+		srv.newObserver(),
+		srv.agentConnections.newObserver(),
+		srv.userActivity.newObserver(),
+	)
 	apiObserver.Join(req, connectionID)
 	defer apiObserver.Leave()
 
@@ -887,6 +1065,7 @@ func (srv *Server) apiHandler(w http.ResponseWriter, req *http.Request) {
 			req.Context(),
 			conn,
 			modelUUID,
+			connectionID,
 			apiObserver,
 			req.Host,
 		); err != nil {
@@ -899,10 +1078,14 @@ func (srv *Server) serveConn(
 	ctx context.Context,
 	wsConn *websocket.Conn,
 	modelUUID string,
+	connectionID uint64,
 	apiObserver observer.Observer,
 	host string,
 ) error {
 	codec := jsoncodec.NewWebsocket(wsConn.Conn)
+	if srv.payloadMetrics != nil {
+		codec.SetSizeRecorder(srv.payloadMetrics.NewSizeRecorder())
+	}
 	conn := rpc.NewConn(codec, apiObserver)
 
 	// Note that we don't overwrite modelUUID here because
@@ -923,7 +1106,7 @@ func (srv *Server) serveConn(
 
 	if err == nil {
 		defer releaser()
-		h, err = newAPIHandler(srv, st, conn, modelUUID, host)
+		h, err = newAPIHandler(srv, st, conn, modelUUID, connectionID, host)
 	}
 
 	if err != nil {
@@ -943,6 +1126,14 @@ func (srv *Server) serveConn(
 	select {
 	case <-conn.Dead():
 	case <-srv.tomb.Dying():
+	case <-srv.draining:
+		// Closing all the connections together would send every
+		// client to the other controllers at the same moment.
+		select {
+		case <-conn.Dead():
+		case <-srv.tomb.Dying():
+		case <-srv.clock.After(srv.drainDelay()):
+		}
 	}
 	return conn.Close()
 }
@@ -979,6 +1170,52 @@ func (srv *Server) localCertificate(serverName string) (*tls.Certificate, bool)
 	return cert, false
 }
 
+// apiCertificate returns the API certificate held in state and reports
+// whether it is valid for the given server name.
+func (srv *Server) apiCertificate(serverName string) (*tls.Certificate, bool) {
+	srv.mu.Lock()
+	cert := srv.apiCertificate_
+	srv.mu.Unlock()
+	if cert == nil {
+		return nil, false
+	}
+	if err := cert.Leaf.VerifyHostname(serverName); err != nil {
+		return nil, false
+	}
+	return cert, true
+}
+
+// updateAPICertificate reads the API certificate held in state, so
+// that it is presented to subsequent connections. A certificate
+// that is not currently valid is ignored.
+func (srv *Server) updateAPICertificate() error {
+	var tlsCert *tls.Certificate
+	apiCert, err := srv.statePool.SystemState().APICertificate()
+	if err == nil {
+		tlsCert, err = cert.ParseServerChain(apiCert.CertChain, apiCert.PrivateKey, srv.clock.Now())
+		if err != nil {
+			logger.Errorf("ignoring %s API certificate: %v", apiCert.Source, err)
+			tlsCert = nil
+		}
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.apiCertificate_ = tlsCert
+	if srv.autocertDNSName == "" {
+		srv.publicDNSName_ = ""
+		if tlsCert != nil && len(tlsCert.Leaf.DNSNames) > 0 {
+			srv.publicDNSName_ = tlsCert.Leaf.DNSNames[0]
+		}
+	}
+	if tlsCert != nil {
+		logger.Infof("using %s API certificate for %q", apiCert.Source, tlsCert.Leaf.DNSNames)
+	}
+	return nil
+}
+
 func serverError(err error) error {
 	if err := common.ServerError(err); err != nil {
 		return err
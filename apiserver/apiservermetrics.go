@@ -4,15 +4,22 @@
 package apiserver
 
 import (
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/jsoncodec"
 )
 
 const (
 	apiserverMetricsNamespace = "juju_apiserver"
 )
 
+var payloadMetricLabelNames = []string{"facade", "version", "method"}
+
 // ServerMetricsSource implementations provide apiserver metrics.
 type ServerMetricsSource interface {
 	TotalConnections() int64
@@ -82,3 +89,88 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric) {
 	c.connectionPauseTimeGauge.Collect(ch)
 	c.concurrentLoginsGauge.Collect(ch)
 }
+
+// PayloadMetrics is a prometheus.Collector that collects the sizes of
+// API request and response payloads, by facade, version and method.
+type PayloadMetrics struct {
+	requestBytes  *prometheus.HistogramVec
+	responseBytes *prometheus.HistogramVec
+}
+
+// NewPayloadMetrics returns a new PayloadMetrics.
+func NewPayloadMetrics() *PayloadMetrics {
+	// The buckets range from 1KiB to 256MiB.
+	buckets := prometheus.ExponentialBuckets(1024, 4, 10)
+	return &PayloadMetrics{
+		requestBytes: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: apiserverMetricsNamespace,
+			Name:      "request_bytes",
+			Help:      "Size of the JSON encoding of API requests received, before decompression",
+			Buckets:   buckets,
+		}, payloadMetricLabelNames),
+		responseBytes: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: apiserverMetricsNamespace,
+			Name:      "response_bytes",
+			Help:      "Size of the JSON encoding of API responses sent, before compression",
+			Buckets:   buckets,
+		}, payloadMetricLabelNames),
+	}
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (m *PayloadMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.requestBytes.Describe(ch)
+	m.responseBytes.Describe(ch)
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (m *PayloadMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.requestBytes.Collect(ch)
+	m.responseBytes.Collect(ch)
+}
+
+// NewSizeRecorder returns a jsoncodec.SizeRecorder that records the
+// sizes of the requests received and the responses sent on a single
+// API connection.
+func (m *PayloadMetrics) NewSizeRecorder() jsoncodec.SizeRecorder {
+	r := &payloadSizeRecorder{
+		metrics:  m,
+		requests: make(map[uint64]rpc.Request),
+	}
+	return r.record
+}
+
+// payloadSizeRecorder attributes the sizes of responses to the
+// requests they answer, which response headers do not identify.
+type payloadSizeRecorder struct {
+	metrics *PayloadMetrics
+
+	mu       sync.Mutex
+	requests map[uint64]rpc.Request
+}
+
+func (r *payloadSizeRecorder) record(hdr *rpc.Header, size int, received bool) {
+	switch {
+	case received && hdr.IsRequest():
+		r.mu.Lock()
+		r.requests[hdr.RequestId] = hdr.Request
+		r.mu.Unlock()
+		r.metrics.requestBytes.With(payloadLabels(hdr.Request)).Observe(float64(size))
+	case !received && !hdr.IsRequest():
+		r.mu.Lock()
+		req, ok := r.requests[hdr.RequestId]
+		delete(r.requests, hdr.RequestId)
+		r.mu.Unlock()
+		if ok {
+			r.metrics.responseBytes.With(payloadLabels(req)).Observe(float64(size))
+		}
+	}
+}
+
+func payloadLabels(req rpc.Request) prometheus.Labels {
+	return prometheus.Labels{
+		"facade":  req.Type,
+		"version": strconv.Itoa(req.Version),
+		"method":  req.Action,
+	}
+}
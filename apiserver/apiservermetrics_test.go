@@ -13,6 +13,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/rpc"
 )
 
 type apiservermetricsSuite struct {
@@ -74,6 +75,43 @@ func (s *apiservermetricsSuite) TestCollect(c *gc.C) {
 	})
 }
 
+func (s *apiservermetricsSuite) TestPayloadMetrics(c *gc.C) {
+	metrics := apiserver.NewPayloadMetrics()
+	record := metrics.NewSizeRecorder()
+	request := rpc.Request{Type: "Client", Version: 1, Action: "FullStatus"}
+	record(&rpc.Header{RequestId: 1, Request: request}, 100, true)
+	record(&rpc.Header{RequestId: 1}, 5000, false)
+	// A response to an unknown request is not recorded.
+	record(&rpc.Header{RequestId: 2}, 10, false)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		metrics.Collect(ch)
+	}()
+	var histograms []*dto.Histogram
+	for metric := range ch {
+		var m dto.Metric
+		err := metric.Write(&m)
+		c.Assert(err, jc.ErrorIsNil)
+		labels := make(map[string]string)
+		for _, label := range m.Label {
+			labels[label.GetName()] = label.GetValue()
+		}
+		c.Assert(labels, jc.DeepEquals, map[string]string{
+			"facade":  "Client",
+			"version": "1",
+			"method":  "FullStatus",
+		})
+		histograms = append(histograms, m.Histogram)
+	}
+	c.Assert(histograms, gc.HasLen, 2)
+	c.Assert(histograms[0].GetSampleCount(), gc.Equals, uint64(1))
+	c.Assert(histograms[0].GetSampleSum(), gc.Equals, float64(100))
+	c.Assert(histograms[1].GetSampleCount(), gc.Equals, uint64(1))
+	c.Assert(histograms[1].GetSampleSum(), gc.Equals, float64(5000))
+}
+
 type stubCollector struct{}
 
 func (a *stubCollector) TotalConnections() int64 {
@@ -7,6 +7,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 )
 
@@ -21,3 +22,13 @@ type EntityAuthenticator interface {
 type EntityFinder interface {
 	FindEntity(tag names.Tag) (state.Entity, error)
 }
+
+// ExternalAuthorizer reports the access that authenticated users have
+// by virtue of something recorded outside juju, such as their
+// membership of directory groups.
+type ExternalAuthorizer interface {
+	// Access returns the access the user has on the target, which
+	// is a controller or model tag, or permission.NoAccess if they
+	// have none.
+	Access(user names.UserTag, target names.Tag) (permission.Access, error)
+}
@@ -0,0 +1,172 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package ldap provides an external authorizer that grants users the
+// access mapped to the LDAP or Active Directory groups they belong to,
+// so that group membership need not be duplicated inside juju.
+package ldap
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/authentication"
+	"github.com/juju/juju/permission"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.authentication.ldap")
+
+// GroupSource looks up the groups that users belong to.
+type GroupSource interface {
+	// Groups returns the common names of the groups the user with
+	// the given name belongs to.
+	Groups(username string) ([]string, error)
+}
+
+// Config holds the configuration for an Authorizer.
+type Config struct {
+	// Source looks up users' groups.
+	Source GroupSource
+
+	// Domain is the domain of the external users whose groups are
+	// looked up, by the name part of their user names. Users from
+	// other domains are granted nothing, as users of the same name
+	// in another domain are not the same as the LDAP server's users.
+	Domain string
+
+	// GroupAccess holds the access granted to the members of each
+	// group, keyed on group common name. Controller access levels
+	// apply to the controller, and model access levels to all of
+	// its models.
+	GroupAccess map[string]permission.Access
+
+	// CacheTTL is how long users' groups are cached before being
+	// looked up again.
+	CacheTTL time.Duration
+
+	// Clock is used to expire cached groups.
+	Clock clock.Clock
+}
+
+// Validate returns an error if the config cannot be used to create an
+// Authorizer.
+func (config Config) Validate() error {
+	if config.Source == nil {
+		return errors.NotValidf("nil Source")
+	}
+	if config.Domain == "" {
+		return errors.NotValidf("empty Domain")
+	}
+	if config.CacheTTL < 0 {
+		return errors.NotValidf("negative CacheTTL")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+// cachedGroups holds a user's groups, as looked up at a time.
+type cachedGroups struct {
+	groups  []string
+	expires time.Time
+}
+
+// Authorizer is an authentication.ExternalAuthorizer that grants
+// external users in the configured domain the access mapped to their
+// groups. Local users are granted nothing, as their access is managed
+// by juju alone.
+type Authorizer struct {
+	config Config
+
+	// groupAccess holds config.GroupAccess keyed on lower-cased
+	// group name, as group names are not case-sensitive.
+	groupAccess map[string]permission.Access
+
+	mu    sync.Mutex
+	cache map[string]cachedGroups
+}
+
+var _ authentication.ExternalAuthorizer = (*Authorizer)(nil)
+
+// NewAuthorizer returns a new Authorizer with the given configuration.
+func NewAuthorizer(config Config) (*Authorizer, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	groupAccess := make(map[string]permission.Access)
+	for group, access := range config.GroupAccess {
+		groupAccess[strings.ToLower(group)] = access
+	}
+	return &Authorizer{
+		config:      config,
+		groupAccess: groupAccess,
+		cache:       make(map[string]cachedGroups),
+	}, nil
+}
+
+// Access is part of the authentication.ExternalAuthorizer interface.
+func (a *Authorizer) Access(user names.UserTag, target names.Tag) (permission.Access, error) {
+	if user.IsLocal() || user.Domain() != a.config.Domain {
+		return permission.NoAccess, nil
+	}
+	var greater func(a, b permission.Access) bool
+	switch target.Kind() {
+	case names.ControllerTagKind:
+		greater = func(a, b permission.Access) bool {
+			return permission.ValidateControllerAccess(a) == nil && a.GreaterControllerAccessThan(b)
+		}
+	case names.ModelTagKind:
+		greater = func(a, b permission.Access) bool {
+			return permission.ValidateModelAccess(a) == nil && a.GreaterModelAccessThan(b)
+		}
+	default:
+		return permission.NoAccess, nil
+	}
+	groups, err := a.groups(user.Name())
+	if err != nil {
+		return permission.NoAccess, errors.Annotatef(err, "cannot look up groups for %q", user.Id())
+	}
+	result := permission.NoAccess
+	for _, group := range groups {
+		if access := a.groupAccess[strings.ToLower(group)]; greater(access, result) {
+			result = access
+		}
+	}
+	return result, nil
+}
+
+// groups returns the groups of the user with the given name, looking
+// them up if they are not cached. Failed lookups are not cached.
+func (a *Authorizer) groups(username string) ([]string, error) {
+	now := a.config.Clock.Now()
+	a.mu.Lock()
+	cached, ok := a.cache[username]
+	a.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.groups, nil
+	}
+
+	groups, err := a.config.Source.Groups(username)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for name, cached := range a.cache {
+		if !now.Before(cached.expires) {
+			delete(a.cache, name)
+		}
+	}
+	a.cache[username] = cachedGroups{
+		groups:  groups,
+		expires: now.Add(a.config.CacheTTL),
+	}
+	return groups, nil
+}
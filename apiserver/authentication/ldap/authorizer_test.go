@@ -0,0 +1,197 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ldap_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/authentication/ldap"
+	"github.com/juju/juju/permission"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type authorizerSuite struct {
+	testing.IsolationSuite
+	clock  *testing.Clock
+	source *mockGroupSource
+	config ldap.Config
+}
+
+var _ = gc.Suite(&authorizerSuite{})
+
+func (s *authorizerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Time{})
+	s.source = &mockGroupSource{
+		groups: map[string][]string{
+			"alice": {"Juju-Admins", "staff"},
+			"bob":   {"devs", "ops"},
+		},
+	}
+	s.config = ldap.Config{
+		Source: s.source,
+		Domain: "external",
+		GroupAccess: map[string]permission.Access{
+			"juju-admins": permission.SuperuserAccess,
+			"devs":        permission.WriteAccess,
+			"ops":         permission.AdminAccess,
+			"staff":       permission.ReadAccess,
+		},
+		CacheTTL: time.Minute,
+		Clock:    s.clock,
+	}
+}
+
+func (s *authorizerSuite) newAuthorizer(c *gc.C) *ldap.Authorizer {
+	authorizer, err := ldap.NewAuthorizer(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	return authorizer
+}
+
+func (s *authorizerSuite) TestValidate(c *gc.C) {
+	config := s.config
+	config.Source = nil
+	_, err := ldap.NewAuthorizer(config)
+	c.Assert(err, gc.ErrorMatches, "nil Source not valid")
+
+	config = s.config
+	config.Domain = ""
+	_, err = ldap.NewAuthorizer(config)
+	c.Assert(err, gc.ErrorMatches, "empty Domain not valid")
+
+	config = s.config
+	config.Clock = nil
+	_, err = ldap.NewAuthorizer(config)
+	c.Assert(err, gc.ErrorMatches, "nil Clock not valid")
+
+	config = s.config
+	config.CacheTTL = -time.Second
+	_, err = ldap.NewAuthorizer(config)
+	c.Assert(err, gc.ErrorMatches, "negative CacheTTL not valid")
+}
+
+func (s *authorizerSuite) TestControllerAccess(c *gc.C) {
+	authorizer := s.newAuthorizer(c)
+	access, err := authorizer.Access(names.NewUserTag("alice@external"), coretesting.ControllerTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.SuperuserAccess)
+
+	// Model access levels do not apply to the controller.
+	access, err = authorizer.Access(names.NewUserTag("bob@external"), coretesting.ControllerTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.NoAccess)
+}
+
+func (s *authorizerSuite) TestModelAccess(c *gc.C) {
+	authorizer := s.newAuthorizer(c)
+	access, err := authorizer.Access(names.NewUserTag("bob@external"), coretesting.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.AdminAccess)
+
+	access, err = authorizer.Access(names.NewUserTag("alice@external"), coretesting.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.ReadAccess)
+}
+
+func (s *authorizerSuite) TestUnknownUser(c *gc.C) {
+	authorizer := s.newAuthorizer(c)
+	access, err := authorizer.Access(names.NewUserTag("mallory@external"), coretesting.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.NoAccess)
+}
+
+func (s *authorizerSuite) TestLocalUser(c *gc.C) {
+	authorizer := s.newAuthorizer(c)
+	access, err := authorizer.Access(names.NewUserTag("alice"), coretesting.ControllerTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.NoAccess)
+	s.source.CheckNoCalls(c)
+}
+
+func (s *authorizerSuite) TestUserInOtherDomain(c *gc.C) {
+	authorizer := s.newAuthorizer(c)
+	access, err := authorizer.Access(names.NewUserTag("alice@otherdomain"), coretesting.ControllerTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.NoAccess)
+	s.source.CheckNoCalls(c)
+}
+
+func (s *authorizerSuite) TestOtherTarget(c *gc.C) {
+	authorizer := s.newAuthorizer(c)
+	access, err := authorizer.Access(names.NewUserTag("alice@external"), names.NewApplicationOfferTag("hosted-mysql"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.NoAccess)
+	s.source.CheckNoCalls(c)
+}
+
+func (s *authorizerSuite) TestGroupsCached(c *gc.C) {
+	authorizer := s.newAuthorizer(c)
+	user := names.NewUserTag("bob@external")
+	for i := 0; i < 2; i++ {
+		_, err := authorizer.Access(user, coretesting.ModelTag)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	s.source.CheckCalls(c, []testing.StubCall{{"Groups", []interface{}{"bob"}}})
+
+	s.source.groups["bob"] = []string{"staff"}
+	s.clock.Advance(time.Minute)
+	access, err := authorizer.Access(user, coretesting.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.ReadAccess)
+	s.source.CheckCallNames(c, "Groups", "Groups")
+}
+
+func (s *authorizerSuite) TestLookupError(c *gc.C) {
+	authorizer := s.newAuthorizer(c)
+	s.source.SetErrors(errors.New("connection refused"))
+	_, err := authorizer.Access(names.NewUserTag("bob@external"), coretesting.ModelTag)
+	c.Assert(err, gc.ErrorMatches, `cannot look up groups for "bob@external": connection refused`)
+
+	// Failures are not cached.
+	access, err := authorizer.Access(names.NewUserTag("bob@external"), coretesting.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.AdminAccess)
+}
+
+func (s *authorizerSuite) TestNewGroupSourceValidation(c *gc.C) {
+	_, err := ldap.NewGroupSource(ldap.ServerConfig{
+		URL:        "https://ad.example.com",
+		UserFilter: "(uid=%s)",
+	})
+	c.Assert(err, gc.ErrorMatches, `LDAP URL scheme "https" not valid`)
+
+	_, err = ldap.NewGroupSource(ldap.ServerConfig{
+		URL:        "ldaps://ad.example.com",
+		UserFilter: "(uid=bob)",
+	})
+	c.Assert(err, gc.ErrorMatches, `user filter "\(uid=bob\)" without %s not valid`)
+}
+
+func (s *authorizerSuite) TestCommonName(c *gc.C) {
+	cn, err := ldap.CommonName("CN=Juju Admins,OU=Groups,DC=example,DC=com")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cn, gc.Equals, "Juju Admins")
+
+	_, err = ldap.CommonName("OU=Groups,DC=example,DC=com")
+	c.Assert(err, gc.ErrorMatches, "no common name")
+}
+
+type mockGroupSource struct {
+	testing.Stub
+	groups map[string][]string
+}
+
+func (s *mockGroupSource) Groups(username string) ([]string, error) {
+	s.MethodCall(s, "Groups", username)
+	if err := s.NextErr(); err != nil {
+		return nil, err
+	}
+	return s.groups[username], nil
+}
@@ -0,0 +1,160 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ldap
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
+	goldap "gopkg.in/ldap.v2"
+)
+
+// ServerConfig holds the details of an LDAP server to look up users'
+// groups from.
+type ServerConfig struct {
+	// URL is the URL of the server, with the scheme ldap or ldaps.
+	// Connections to ldap URLs are upgraded with StartTLS before
+	// binding, so the server must support it; the bind password is
+	// never sent in cleartext.
+	URL string
+
+	// BindDN and BindPassword are the credentials with which to
+	// bind to the server. If BindDN is empty, the server is searched
+	// anonymously.
+	BindDN       string
+	BindPassword string
+
+	// BaseDN is the DN under which users are looked up.
+	BaseDN string
+
+	// UserFilter is the filter used to look up a user, with "%s"
+	// standing for the user's name.
+	UserFilter string
+}
+
+// NewGroupSource returns a GroupSource that looks up the groups of
+// users from the "memberOf" attribute of their entries on the LDAP
+// server, as maintained by Active Directory and by OpenLDAP's memberof
+// overlay.
+func NewGroupSource(config ServerConfig) (GroupSource, error) {
+	u, err := url.Parse(config.URL)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid LDAP URL")
+	}
+	var port string
+	switch u.Scheme {
+	case "ldap":
+		port = "389"
+	case "ldaps":
+		port = "636"
+	default:
+		return nil, errors.NotValidf("LDAP URL scheme %q", u.Scheme)
+	}
+	if !strings.Contains(config.UserFilter, "%s") {
+		return nil, errors.NotValidf("user filter %q without %%s", config.UserFilter)
+	}
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), port)
+	}
+	return &groupSource{
+		config: config,
+		tls:    u.Scheme == "ldaps",
+		addr:   addr,
+		host:   u.Hostname(),
+	}, nil
+}
+
+type groupSource struct {
+	config ServerConfig
+	tls    bool
+	addr   string
+	host   string
+}
+
+// Groups is part of the GroupSource interface.
+func (s *groupSource) Groups(username string) ([]string, error) {
+	conn, err := s.dial()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer conn.Close()
+
+	if s.config.BindDN != "" {
+		if err := conn.Bind(s.config.BindDN, s.config.BindPassword); err != nil {
+			return nil, errors.Annotate(err, "cannot bind to LDAP server")
+		}
+	}
+	filter := fmt.Sprintf(s.config.UserFilter, goldap.EscapeFilter(username))
+	result, err := conn.Search(goldap.NewSearchRequest(
+		s.config.BaseDN,
+		goldap.ScopeWholeSubtree, goldap.NeverDerefAliases,
+		2, 0, false,
+		filter,
+		[]string{"memberOf"},
+		nil,
+	))
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot search LDAP server")
+	}
+	switch len(result.Entries) {
+	case 0:
+		// The user is unknown to the directory, so belongs to
+		// no groups.
+		return nil, nil
+	case 1:
+	default:
+		return nil, errors.Errorf("LDAP filter %q matches more than one user", filter)
+	}
+	var groups []string
+	for _, dn := range result.Entries[0].GetAttributeValues("memberOf") {
+		cn, err := commonName(dn)
+		if err != nil {
+			logger.Warningf("ignoring group %q: %v", dn, err)
+			continue
+		}
+		groups = append(groups, cn)
+	}
+	return groups, nil
+}
+
+// dial returns a connection to the LDAP server secured with TLS, using
+// StartTLS if the server's URL does not have the ldaps scheme.
+func (s *groupSource) dial() (*goldap.Conn, error) {
+	tlsConfig := &tls.Config{ServerName: s.host}
+	if s.tls {
+		conn, err := goldap.DialTLS("tcp", s.addr, tlsConfig)
+		return conn, errors.Annotate(err, "cannot connect to LDAP server")
+	}
+	conn, err := goldap.Dial("tcp", s.addr)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot connect to LDAP server")
+	}
+	if err := conn.StartTLS(tlsConfig); err != nil {
+		conn.Close()
+		return nil, errors.Annotate(err, "cannot start TLS with LDAP server")
+	}
+	return conn, nil
+}
+
+// commonName returns the common name in the first component of the
+// given group DN.
+func commonName(dn string) (string, error) {
+	parsed, err := goldap.ParseDN(dn)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(parsed.RDNs) > 0 {
+		for _, attr := range parsed.RDNs[0].Attributes {
+			if strings.EqualFold(attr.Type, "cn") {
+				return attr.Value, nil
+			}
+		}
+	}
+	return "", errors.Errorf("no common name")
+}
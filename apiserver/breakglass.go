@@ -0,0 +1,160 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"context"
+	"reflect"
+	"sync/atomic"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/rpcreflect"
+	"github.com/juju/juju/state"
+)
+
+const (
+	// auditLogMaxSizeMB is the size in megabytes at which the audit
+	// log written by the server is rotated.
+	auditLogMaxSizeMB = 300
+
+	// auditLogMaxBackups is the number of rotated audit logs kept.
+	auditLogMaxBackups = 10
+)
+
+// breakGlassSession returns the user's active break-glass session, or
+// nil if the user has none.
+func (srv *Server) breakGlassSession(user names.UserTag) (*state.BreakGlassSession, error) {
+	session, err := srv.statePool.SystemState().BreakGlassSession(user)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "checking for break-glass session")
+	}
+	return session, nil
+}
+
+// withBreakGlassAccess returns a userAccessFunc that grants the user of
+// the break-glass session superuser access to the controller for as
+// long as the session remains active, and otherwise returns the access
+// given by userAccess.
+func (srv *Server) withBreakGlassAccess(userAccess userAccessFunc, session *state.BreakGlassSession) userAccessFunc {
+	return func(user names.UserTag, target names.Tag) (permission.Access, error) {
+		if target.Kind() != names.ControllerTagKind || user.Id() != session.User.Id() {
+			return userAccess(user, target)
+		}
+		// The session may have expired, or been ended by an
+		// administrator, since the user logged in.
+		current, err := srv.breakGlassSession(user)
+		if err != nil {
+			return permission.NoAccess, errors.Trace(err)
+		}
+		if current == nil || current.Id != session.Id {
+			return userAccess(user, target)
+		}
+		return permission.SuperuserAccess, nil
+	}
+}
+
+// auditLog returns the audit log in which the API calls made during
+// break-glass sessions are recorded, opening the audit.log file in the
+// server's log directory if no other audit log was configured.
+func (srv *Server) auditLog() auditlog.AuditLog {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if srv.auditLog_ == nil {
+		srv.auditLog_ = auditlog.NewLogFile(srv.logDir, auditLogMaxSizeMB, auditLogMaxBackups)
+		srv.ownAuditLog = true
+	}
+	return srv.auditLog_
+}
+
+// closeAuditLog closes the audit log if the server opened it.
+func (srv *Server) closeAuditLog() {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if !srv.ownAuditLog {
+		return
+	}
+	if err := srv.auditLog_.Close(); err != nil {
+		logger.Errorf("cannot close audit log: %v", err)
+	}
+	srv.auditLog_ = nil
+	srv.ownAuditLog = false
+}
+
+// breakGlassRoot wraps the root of a connection made during a
+// break-glass session, recording each of the API calls made over it in
+// the audit log, tagged with the session.
+type breakGlassRoot struct {
+	rpc.Root
+	recorder      *auditlog.Recorder
+	lastRequestID uint64
+}
+
+func newBreakGlassRoot(root rpc.Root, recorder *auditlog.Recorder) *breakGlassRoot {
+	return &breakGlassRoot{
+		Root:     root,
+		recorder: recorder,
+	}
+}
+
+// FindMethod implements rpc.Root.
+func (r *breakGlassRoot) FindMethod(facadeName string, version int, methodName string) (rpcreflect.MethodCaller, error) {
+	caller, err := r.Root.FindMethod(facadeName, version, methodName)
+	if err != nil {
+		return nil, err
+	}
+	return &breakGlassCaller{
+		MethodCaller: caller,
+		root:         r,
+		facade:       facadeName,
+		version:      version,
+		method:       methodName,
+	}, nil
+}
+
+// breakGlassCaller records the calls made through a MethodCaller in
+// the audit log.
+type breakGlassCaller struct {
+	rpcreflect.MethodCaller
+	root    *breakGlassRoot
+	facade  string
+	version int
+	method  string
+}
+
+// Call is part of the rpcreflect.MethodCaller interface.
+func (c *breakGlassCaller) Call(ctx context.Context, objId string, arg reflect.Value) (reflect.Value, error) {
+	// The arguments are not recorded, as they may hold secrets.
+	requestID := atomic.AddUint64(&c.root.lastRequestID, 1)
+	if err := c.root.recorder.AddRequest(auditlog.RequestArgs{
+		RequestID: requestID,
+		Facade:    c.facade,
+		Method:    c.method,
+		Version:   c.version,
+	}); err != nil {
+		// Calls made with elevated access must not go unrecorded.
+		return reflect.Value{}, errors.Annotate(err, "cannot record break-glass API call")
+	}
+	result, err := c.MethodCaller.Call(ctx, objId, arg)
+	if err != nil {
+		serverErr := common.ServerError(err)
+		if err := c.root.recorder.AddResponse(auditlog.ResponseErrorsArgs{
+			RequestID: requestID,
+			Errors: []*auditlog.Error{{
+				Message: serverErr.Message,
+				Code:    serverErr.Code,
+			}},
+		}); err != nil {
+			logger.Errorf("cannot record break-glass API call error: %v", err)
+		}
+	}
+	return result, err
+}
@@ -0,0 +1,188 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/mgo.v2"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+const (
+	defaultSlowCallThreshold = 10 * time.Second
+	defaultFullStatusTimeout = 5 * time.Minute
+)
+
+// getMgoStats returns the controller-wide mongo driver statistics.
+// It panics unless statistics are being kept; see mgo.SetStats. It is
+// a variable so tests can replace it.
+var getMgoStats = mgo.GetStats
+
+// CallLimitConfig holds parameters to control how long API calls may
+// take, and which calls are logged as slow.
+type CallLimitConfig struct {
+	// Timeouts holds the longest time calls may take, keyed on
+	// "Facade.Method", or on "Facade" for all of a facade's methods.
+	// A call that times out is failed with a timeout error; the
+	// facade method is cancelled through its context, if it takes
+	// one. Methods that don't take a context can't be interrupted,
+	// so they carry on in the background until they finish, and
+	// their results are discarded. Calls to methods without a
+	// timeout are not limited, since many, such as watchers' Next
+	// methods, legitimately block for a long time.
+	Timeouts map[string]time.Duration
+
+	// SlowCallThreshold is the duration above which calls are
+	// logged as slow. If it is zero, slow calls are not logged.
+	SlowCallThreshold time.Duration
+
+	// MgoStatsEnabled records whether mongo driver statistics are
+	// being kept. If they are, slow calls are logged with the
+	// number of mongo operations made while they ran.
+	MgoStatsEnabled bool
+}
+
+// Validate validates the call limit configuration.
+func (cfg CallLimitConfig) Validate() error {
+	for key, timeout := range cfg.Timeouts {
+		parts := strings.Split(key, ".")
+		if len(parts) > 2 || parts[0] == "" || (len(parts) == 2 && parts[1] == "") {
+			return errors.NotValidf("call timeout key %q", key)
+		}
+		if timeout <= 0 {
+			return errors.NotValidf("%s call timeout %s <= 0", key, timeout)
+		}
+	}
+	if cfg.SlowCallThreshold < 0 {
+		return errors.NotValidf("SlowCallThreshold %s < 0", cfg.SlowCallThreshold)
+	}
+	return nil
+}
+
+// DefaultCallLimitConfig returns a CallLimitConfig with default
+// values.
+func DefaultCallLimitConfig() CallLimitConfig {
+	return CallLimitConfig{
+		Timeouts: map[string]time.Duration{
+			"Client.FullStatus": defaultFullStatusTimeout,
+		},
+		SlowCallThreshold: defaultSlowCallThreshold,
+	}
+}
+
+// timeout returns the timeout for calls to the given method, or zero
+// if they are not limited.
+func (cfg CallLimitConfig) timeout(facadeName, methodName string) time.Duration {
+	if timeout, ok := cfg.Timeouts[facadeName+"."+methodName]; ok {
+		return timeout
+	}
+	return cfg.Timeouts[facadeName]
+}
+
+// callLimiter applies a CallLimitConfig to the calls made through an
+// API root.
+type callLimiter struct {
+	config    CallLimitConfig
+	clock     clock.Clock
+	modelUUID string
+}
+
+// call makes the given call to the named facade method, failing it if
+// it exceeds the method's timeout, and logging it if it is slow.
+func (l *callLimiter) call(
+	ctx context.Context,
+	facadeName, methodName string,
+	call func(context.Context) (reflect.Value, error),
+) (reflect.Value, error) {
+	start := l.clock.Now()
+	var startOps int
+	if l.config.MgoStatsEnabled {
+		startOps = getMgoStats().SentOps
+	}
+	logIfSlow := func() {
+		duration := l.clock.Now().Sub(start)
+		if l.config.SlowCallThreshold <= 0 || duration < l.config.SlowCallThreshold {
+			return
+		}
+		if !l.config.MgoStatsEnabled {
+			logger.Warningf(
+				"slow API call: facade=%s method=%s model=%s duration=%s",
+				facadeName, methodName, l.modelUUID, duration,
+			)
+			return
+		}
+		// Mongo statistics are only kept controller-wide, so the
+		// count includes operations made concurrently by other
+		// calls and workers.
+		logger.Warningf(
+			"slow API call: facade=%s method=%s model=%s duration=%s mongo-ops=%d",
+			facadeName, methodName, l.modelUUID, duration, getMgoStats().SentOps-startOps,
+		)
+	}
+
+	timeout := l.config.timeout(facadeName, methodName)
+	if timeout <= 0 {
+		defer logIfSlow()
+		return call(ctx)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		result     reflect.Value
+		err        error
+		panicValue interface{}
+		panicStack []byte
+	)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer logIfSlow()
+		defer func() {
+			if panicValue = recover(); panicValue != nil {
+				panicStack = debug.Stack()
+			}
+		}()
+		result, err = call(ctx)
+	}()
+	select {
+	case <-done:
+		if panicValue != nil {
+			// Let the RPC server handle the panic as it would
+			// have without the timeout.
+			panic(panicValue)
+		}
+		return result, err
+	case <-l.clock.After(timeout):
+		logger.Warningf(
+			"API call timed out: facade=%s method=%s model=%s timeout=%s",
+			facadeName, methodName, l.modelUUID, timeout,
+		)
+		// The call's context is cancelled when we return, but a
+		// method that doesn't take a context can't be stopped; wait
+		// for it in the background, so that a panic is still logged.
+		go func() {
+			<-done
+			if panicValue != nil {
+				logger.Criticalf(
+					"panic in timed out call to %s.%s: %v\n%s",
+					facadeName, methodName, panicValue, panicStack,
+				)
+			}
+		}()
+		return reflect.Value{}, &params.Error{
+			Code:    params.CodeTimeout,
+			Message: fmt.Sprintf("%s.%s call timed out after %s", facadeName, methodName, timeout),
+		}
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type callLimiterSuite struct {
+	coretesting.BaseSuite
+	clock   *testing.Clock
+	sentOps int
+	limiter *callLimiter
+}
+
+var _ = gc.Suite(&callLimiterSuite{})
+
+func (s *callLimiterSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Now())
+	s.sentOps = 0
+	s.PatchValue(&getMgoStats, func() mgo.Stats {
+		return mgo.Stats{SentOps: s.sentOps}
+	})
+	s.limiter = &callLimiter{
+		config: CallLimitConfig{
+			Timeouts: map[string]time.Duration{
+				"Client.FullStatus": time.Minute,
+				"Application":       time.Hour,
+			},
+			SlowCallThreshold: 10 * time.Second,
+			MgoStatsEnabled:   true,
+		},
+		clock:     s.clock,
+		modelUUID: coretesting.ModelTag.Id(),
+	}
+}
+
+func (s *callLimiterSuite) gatherLog(c *gc.C, f func()) []loggo.Entry {
+	var tw loggo.TestWriter
+	c.Assert(loggo.RegisterWriter("calllimits-test", &tw), jc.ErrorIsNil)
+	defer loggo.RemoveWriter("calllimits-test")
+	f()
+	return tw.Log()
+}
+
+func (s *callLimiterSuite) TestConfigValidate(c *gc.C) {
+	c.Assert(DefaultCallLimitConfig().Validate(), jc.ErrorIsNil)
+	for key, expect := range map[string]string{
+		"":            `call timeout key "" not valid`,
+		"Client.":     `call timeout key "Client." not valid`,
+		".FullStatus": `call timeout key ".FullStatus" not valid`,
+		"A.B.C":       `call timeout key "A.B.C" not valid`,
+	} {
+		config := CallLimitConfig{Timeouts: map[string]time.Duration{key: time.Second}}
+		c.Check(config.Validate(), gc.ErrorMatches, expect)
+	}
+	config := CallLimitConfig{Timeouts: map[string]time.Duration{"Client": 0}}
+	c.Check(config.Validate(), gc.ErrorMatches, "Client call timeout 0s <= 0 not valid")
+	config = CallLimitConfig{SlowCallThreshold: -time.Second}
+	c.Check(config.Validate(), gc.ErrorMatches, "SlowCallThreshold -1s < 0 not valid")
+}
+
+func (s *callLimiterSuite) TestTimeout(c *gc.C) {
+	config := s.limiter.config
+	c.Check(config.timeout("Client", "FullStatus"), gc.Equals, time.Minute)
+	c.Check(config.timeout("Client", "AddMachines"), gc.Equals, time.Duration(0))
+	c.Check(config.timeout("Application", "Deploy"), gc.Equals, time.Hour)
+}
+
+func (s *callLimiterSuite) TestCall(c *gc.C) {
+	result, err := s.limiter.call(context.Background(), "Client", "FullStatus", func(context.Context) (reflect.Value, error) {
+		return reflect.ValueOf("result"), nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Interface(), gc.Equals, "result")
+}
+
+func (s *callLimiterSuite) TestSlowCallLogged(c *gc.C) {
+	logs := s.gatherLog(c, func() {
+		_, err := s.limiter.call(context.Background(), "Client", "AddMachines", func(context.Context) (reflect.Value, error) {
+			s.sentOps += 42
+			s.clock.Advance(11 * time.Second)
+			return reflect.Value{}, nil
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	})
+	c.Assert(logs, jc.LogMatches, jc.SimpleMessages{{
+		loggo.WARNING,
+		`slow API call: facade=Client method=AddMachines model=deadbeef-0bad-400d-8000-4b1d0d06f00d duration=11s mongo-ops=42`,
+	}})
+}
+
+func (s *callLimiterSuite) TestSlowCallLoggedWithoutMgoStats(c *gc.C) {
+	// mgo.GetStats panics if statistics aren't being kept.
+	s.PatchValue(&getMgoStats, func() mgo.Stats {
+		panic("mgo stats not enabled")
+	})
+	s.limiter.config.MgoStatsEnabled = false
+	for _, method := range []string{"AddMachines", "FullStatus"} {
+		logs := s.gatherLog(c, func() {
+			_, err := s.limiter.call(context.Background(), "Client", method, func(context.Context) (reflect.Value, error) {
+				s.clock.Advance(11 * time.Second)
+				return reflect.Value{}, nil
+			})
+			c.Assert(err, jc.ErrorIsNil)
+		})
+		c.Check(logs, jc.LogMatches, jc.SimpleMessages{{
+			loggo.WARNING,
+			`slow API call: facade=Client method=` + method + ` model=deadbeef-0bad-400d-8000-4b1d0d06f00d duration=11s`,
+		}})
+	}
+}
+
+func (s *callLimiterSuite) TestFastCallNotLogged(c *gc.C) {
+	logs := s.gatherLog(c, func() {
+		_, err := s.limiter.call(context.Background(), "Client", "AddMachines", func(context.Context) (reflect.Value, error) {
+			s.clock.Advance(9 * time.Second)
+			return reflect.Value{}, nil
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	})
+	c.Assert(logs, gc.HasLen, 0)
+}
+
+func (s *callLimiterSuite) TestCallTimesOut(c *gc.C) {
+	cancelled := make(chan struct{})
+	errc := make(chan error, 1)
+	go func() {
+		_, err := s.limiter.call(context.Background(), "Client", "FullStatus", func(ctx context.Context) (reflect.Value, error) {
+			<-ctx.Done()
+			close(cancelled)
+			return reflect.Value{}, ctx.Err()
+		})
+		errc <- err
+	}()
+	c.Assert(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	select {
+	case err := <-errc:
+		c.Assert(err, gc.ErrorMatches, "Client.FullStatus call timed out after 1m0s")
+		c.Assert(err, jc.Satisfies, params.IsCodeTimeout)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for call to time out")
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("call context not cancelled")
+	}
+}
+
+func (s *callLimiterSuite) TestCallPanics(c *gc.C) {
+	c.Assert(func() {
+		s.limiter.call(context.Background(), "Client", "FullStatus", func(context.Context) (reflect.Value, error) {
+			panic("boom")
+		})
+	}, gc.PanicMatches, "boom")
+}
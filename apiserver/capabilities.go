@@ -0,0 +1,47 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/featureflag"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	jujuversion "github.com/juju/juju/version"
+)
+
+// capabilitiesHandler reports the facade versions and feature flags
+// supported by the controller. It requires no authentication, so
+// that clients can adapt to the controller before logging in.
+type capabilitiesHandler struct {
+	facades *facade.Registry
+}
+
+// ServeHTTP implements http.Handler.
+func (h *capabilitiesHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var err error
+	if req.Method != "GET" {
+		err = sendError(w, errors.MethodNotAllowedf("unsupported method: %q", req.Method))
+	} else {
+		err = sendStatusAndJSON(w, http.StatusOK, h.capabilities())
+	}
+	if err != nil {
+		logger.Errorf("%v", err)
+	}
+}
+
+func (h *capabilitiesHandler) capabilities() params.CapabilitiesResult {
+	flags := featureflag.All()
+	if flags == nil {
+		flags = []string{}
+	}
+	return params.CapabilitiesResult{
+		ServerVersion: jujuversion.Current.String(),
+		Facades:       DescribeFacades(h.facades),
+		FeatureFlags:  flags,
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"encoding/json"
+	"net/http"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/apiserver/params"
+	jujuversion "github.com/juju/juju/version"
+)
+
+type capabilitiesSuite struct {
+	authHTTPSuite
+}
+
+var _ = gc.Suite(&capabilitiesSuite{})
+
+func (s *capabilitiesSuite) url(c *gc.C) string {
+	url := s.baseURL(c)
+	url.Path = "/capabilities"
+	return url.String()
+}
+
+func (s *capabilitiesSuite) TestCapabilitiesWithoutLogin(c *gc.C) {
+	resp := s.sendRequest(c, httpRequestParams{
+		method: "GET",
+		url:    s.url(c),
+	})
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusOK)
+	c.Assert(resp.Header.Get("Content-Type"), gc.Equals, params.ContentTypeJSON)
+
+	var result params.CapabilitiesResult
+	err := json.NewDecoder(resp.Body).Decode(&result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.ServerVersion, gc.Equals, jujuversion.Current.String())
+	c.Check(result.Facades, jc.DeepEquals, apiserver.DescribeFacades(apiserver.AllFacades()))
+	c.Check(result.FeatureFlags, gc.NotNil)
+}
+
+func (s *capabilitiesSuite) TestCapabilitiesMethodNotAllowed(c *gc.C) {
+	resp := s.sendRequest(c, httpRequestParams{
+		method: "POST",
+		url:    s.url(c),
+	})
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusMethodNotAllowed)
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/cert"
+	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -151,6 +152,49 @@ func (s *certSuite) TestAutocertNoAutocertDNSName(c *gc.C) {
 	}})
 }
 
+func (s *certSuite) TestAPICertificate(c *gc.C) {
+	srv := s.newServer(c, s.sampleConfig(c))
+	conn := s.OpenAPIAsAdmin(c, srv)
+
+	expiry := time.Now().AddDate(1, 0, 0)
+	caCert, caKey, err := cert.NewCA("external", "1", expiry)
+	c.Assert(err, jc.ErrorIsNil)
+	srvCert, srvKey, err := cert.NewServer(caCert, caKey, expiry, []string{"api.example.com"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetAPICertificate(state.APICertificate{
+		Source:     state.APICertificateExternal,
+		CertChain:  srvCert,
+		PrivateKey: srvKey,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Connections addressed to the certificate's name are served
+	// with it once the server has seen the change.
+	pool := x509.NewCertPool()
+	c.Assert(pool.AppendCertsFromPEM([]byte(caCert)), jc.IsTrue)
+	apiInfo := s.APIInfo(srv)
+	for a := coretesting.LongAttempt.Start(); ; {
+		s.State.StartSync()
+		tlsConn, err := tls.Dial("tcp", apiInfo.Addrs[0], &tls.Config{
+			ServerName: "api.example.com",
+			RootCAs:    pool,
+		})
+		if err == nil {
+			tlsConn.Close()
+			break
+		}
+		if !a.Next() {
+			c.Fatalf("API certificate not used: %v", err)
+		}
+	}
+
+	// The existing connection is unaffected, and connections
+	// addressed to other names still use the local certificate.
+	c.Assert(pingConn(conn), jc.ErrorIsNil)
+	conn = s.OpenAPIAsAdmin(c, srv)
+	c.Assert(pingConn(conn), jc.ErrorIsNil)
+}
+
 func gatherLog(f func()) []loggo.Entry {
 	var tw loggo.TestWriter
 	err := loggo.RegisterWriter("test", &tw)
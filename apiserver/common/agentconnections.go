@@ -0,0 +1,15 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import "github.com/juju/juju/apiserver/params"
+
+// AgentConnectionHistory reports the recent API connections that agents
+// have made to an API server. An API server makes its history available
+// to facades as a ValueResource named "agentConnectionHistory".
+type AgentConnectionHistory interface {
+	// AgentConnections returns the connection history of each agent
+	// that has connected to the API server.
+	AgentConnections() []params.AgentConnectionHistory
+}
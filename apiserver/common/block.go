@@ -5,6 +5,7 @@ package common
 
 import (
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/state"
 )
@@ -13,6 +14,12 @@ type BlockGetter interface {
 	GetBlockForType(t state.BlockType) (state.Block, bool, error)
 }
 
+// EntityBlockGetter is implemented by BlockGetters that support
+// blocks on individual applications and machines.
+type EntityBlockGetter interface {
+	GetEntityBlockForType(t state.BlockType, tag names.Tag) (state.Block, bool, error)
+}
+
 // BlockChecker checks for current blocks if any.
 type BlockChecker struct {
 	getter BlockGetter
@@ -54,6 +61,45 @@ func (c *BlockChecker) DestroyAllowed() error {
 	return c.checkBlock(state.ChangeBlock)
 }
 
+// ChangeAllowedFor checks if a change block is in place, either for
+// the current environment or for the entity with the given tag.
+func (c *BlockChecker) ChangeAllowedFor(tag names.Tag) error {
+	if err := c.ChangeAllowed(); err != nil {
+		return err
+	}
+	return c.checkEntityBlock(state.ChangeBlock, tag)
+}
+
+// RemoveAllowedFor checks if a remove block is in place, either for
+// the current environment or for the entity with the given tag.
+func (c *BlockChecker) RemoveAllowedFor(tag names.Tag) error {
+	if err := c.RemoveAllowed(); err != nil {
+		return err
+	}
+	if err := c.checkEntityBlock(state.RemoveBlock, tag); err != nil {
+		return err
+	}
+	return c.checkEntityBlock(state.ChangeBlock, tag)
+}
+
+// checkEntityBlock checks if specified operation must be blocked
+// for the entity with the given tag. If the getter does not support
+// blocks on entities, no entity is ever blocked.
+func (c *BlockChecker) checkEntityBlock(blockType state.BlockType, tag names.Tag) error {
+	getter, ok := c.getter.(EntityBlockGetter)
+	if !ok {
+		return nil
+	}
+	aBlock, isEnabled, err := getter.GetEntityBlockForType(blockType, tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if isEnabled {
+		return OperationBlockedError(aBlock.Message())
+	}
+	return nil
+}
+
 // checkBlock checks if specified operation must be blocked.
 // If it does, the method throws specific error that can be examined
 // to stop operation execution.
@@ -98,3 +98,60 @@ func (s *blockCheckerSuite) assertErrorBlocked(c *gc.C, blocked bool, err error,
 		c.Assert(errors.Cause(err), jc.ErrorIsNil)
 	}
 }
+
+type entityBlockCheckerSuite struct {
+	testing.BaseSuite
+	blocks       map[names.Tag]state.Block
+	blockchecker *common.BlockChecker
+}
+
+var _ = gc.Suite(&entityBlockCheckerSuite{})
+
+func (s *entityBlockCheckerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.blocks = make(map[names.Tag]state.Block)
+	s.blockchecker = common.NewBlockChecker(s)
+}
+
+func (s *entityBlockCheckerSuite) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
+	return s.GetEntityBlockForType(t, names.NewModelTag("mocktesting"))
+}
+
+func (s *entityBlockCheckerSuite) GetEntityBlockForType(t state.BlockType, tag names.Tag) (state.Block, bool, error) {
+	if block, ok := s.blocks[tag]; ok && block.Type() == t {
+		return block, true, nil
+	}
+	return nil, false, nil
+}
+
+func (s *entityBlockCheckerSuite) TestChangeAllowedFor(c *gc.C) {
+	mysql := names.NewApplicationTag("mysql")
+	wordpress := names.NewApplicationTag("wordpress")
+	s.blocks[mysql] = mockBlock{t: state.ChangeBlock, m: "mysql is locked"}
+
+	err := s.blockchecker.ChangeAllowedFor(mysql)
+	c.Assert(params.IsCodeOperationBlocked(err), jc.IsTrue)
+	c.Assert(err, gc.ErrorMatches, "mysql is locked")
+	c.Assert(s.blockchecker.ChangeAllowedFor(wordpress), jc.ErrorIsNil)
+	c.Assert(s.blockchecker.ChangeAllowed(), jc.ErrorIsNil)
+}
+
+func (s *entityBlockCheckerSuite) TestRemoveAllowedFor(c *gc.C) {
+	machine0 := names.NewMachineTag("0")
+	machine1 := names.NewMachineTag("1")
+	s.blocks[machine0] = mockBlock{t: state.RemoveBlock, m: "machine 0 is locked"}
+	s.blocks[machine1] = mockBlock{t: state.ChangeBlock, m: "machine 1 is locked"}
+
+	err := s.blockchecker.RemoveAllowedFor(machine0)
+	c.Assert(err, gc.ErrorMatches, "machine 0 is locked")
+	err = s.blockchecker.RemoveAllowedFor(machine1)
+	c.Assert(err, gc.ErrorMatches, "machine 1 is locked")
+	c.Assert(s.blockchecker.RemoveAllowedFor(names.NewMachineTag("2")), jc.ErrorIsNil)
+	c.Assert(s.blockchecker.ChangeAllowedFor(machine0), jc.ErrorIsNil)
+}
+
+func (s *entityBlockCheckerSuite) TestModelBlockAppliesToEntities(c *gc.C) {
+	s.blocks[names.NewModelTag("mocktesting")] = mockBlock{t: state.ChangeBlock, m: "model is locked"}
+	err := s.blockchecker.ChangeAllowedFor(names.NewApplicationTag("mysql"))
+	c.Assert(err, gc.ErrorMatches, "model is locked")
+}
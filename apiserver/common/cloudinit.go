@@ -0,0 +1,28 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cloudconfig/cloudinit"
+	"github.com/juju/juju/environs/config"
+)
+
+// MachineCloudInitUserData returns, as YAML, the user-supplied cloud-init
+// configuration that is merged into a machine's when it is provisioned:
+// the model's cloudinit-userdata, with the machine's own applied on top.
+// An empty string is returned if there is none.
+func MachineCloudInitUserData(modelConfig *config.Config, machineUserData string) (string, error) {
+	modelUserData, err := cloudinit.ParseUserData(modelConfig.CloudInitUserData())
+	if err != nil {
+		return "", errors.Annotate(err, "model cloudinit-userdata")
+	}
+	machine, err := cloudinit.ParseUserData(machineUserData)
+	if err != nil {
+		return "", errors.Annotate(err, "machine cloud-init user data")
+	}
+	rendered, err := modelUserData.Merge(machine).Render()
+	return rendered, errors.Trace(err)
+}
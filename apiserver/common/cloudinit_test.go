@@ -0,0 +1,54 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/environs/config"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type cloudInitSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&cloudInitSuite{})
+
+func (s *cloudInitSuite) modelConfig(c *gc.C, userData string) *config.Config {
+	attrs := coretesting.FakeConfig()
+	if userData != "" {
+		attrs = attrs.Merge(coretesting.Attrs{"cloudinit-userdata": userData})
+	}
+	cfg, err := config.New(config.UseDefaults, attrs)
+	c.Assert(err, jc.ErrorIsNil)
+	return cfg
+}
+
+func (s *cloudInitSuite) TestMachineCloudInitUserDataNone(c *gc.C) {
+	rendered, err := common.MachineCloudInitUserData(s.modelConfig(c, ""), "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rendered, gc.Equals, "")
+}
+
+func (s *cloudInitSuite) TestMachineCloudInitUserDataMerged(c *gc.C) {
+	cfg := s.modelConfig(c, "packages: [htop]\nruncmd: [echo model]\n")
+	rendered, err := common.MachineCloudInitUserData(cfg, "packages: [jq]\nruncmd: [echo machine]\n")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rendered, gc.Equals, `
+packages:
+- htop
+- jq
+runcmd:
+- echo model
+- echo machine
+`[1:])
+}
+
+func (s *cloudInitSuite) TestMachineCloudInitUserDataInvalid(c *gc.C) {
+	_, err := common.MachineCloudInitUserData(s.modelConfig(c, ""), "bootcmd: [reboot]")
+	c.Assert(err, gc.ErrorMatches, `machine cloud-init user data: cloud-init user data key "bootcmd" not supported`)
+}
@@ -18,6 +18,8 @@ type CloudSpecAPI interface {
 	CloudSpec(args params.Entities) (params.CloudSpecResults, error)
 
 	// GetCloudSpec constructs the CloudSpec for a validated and authorized model.
+	//
+	//juju:readonly
 	GetCloudSpec(tag names.ModelTag) params.CloudSpecResult
 }
 
@@ -30,6 +30,8 @@ func NewControllerConfig(st state.ControllerAccessor) *ControllerConfigAPI {
 }
 
 // ControllerConfig returns the controller's configuration.
+//
+//juju:readonly
 func (s *ControllerConfigAPI) ControllerConfig() (params.ControllerConfigResult, error) {
 	result := params.ControllerConfigResult{}
 	config, err := s.st.ControllerConfig()
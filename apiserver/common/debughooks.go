@@ -0,0 +1,16 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+// DebugHooksSessions reports the debug-hooks sessions that clients have
+// opened through an API server, and which are waiting for units to run
+// hooks in them. An API server makes its sessions available to facades
+// as a ValueResource named "debugHooksSessions".
+type DebugHooksSessions interface {
+	// Session returns the names of the hooks and actions intercepted
+	// by the session waiting for the given unit, and whether there is
+	// such a session. An empty list of names means that all hooks and
+	// actions are intercepted.
+	Session(modelUUID, unitName string) ([]string, bool)
+}
@@ -0,0 +1,84 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// EntityBatch holds the authorized entities named in the arguments of
+// a bulk API call. Its slices are indexed as the arguments.
+type EntityBatch struct {
+	// Tags holds the parsed tag of each entity, or nil where the tag
+	// could not be parsed.
+	Tags []names.Tag
+
+	// Entities holds each authorized entity that was found.
+	Entities []state.Entity
+
+	// Errors holds the reason each entity without an entry in
+	// Entities could not be fetched.
+	Errors []error
+}
+
+// FetchEntities parses the tags of the given entities, checks each
+// against canAccess, and finds all of the authorized entities in st.
+// If st implements state.BulkEntityFinder, the entities are found
+// together, which takes far fewer database queries than finding each
+// in turn. Entities that may not be accessed have ErrPerm recorded.
+// Where a tag cannot be parsed, badTagErr is recorded if it is not
+// nil, and the parse error otherwise.
+func FetchEntities(st state.EntityFinder, canAccess AuthFunc, args []params.Entity, badTagErr error) EntityBatch {
+	batch := EntityBatch{
+		Tags:     make([]names.Tag, len(args)),
+		Entities: make([]state.Entity, len(args)),
+		Errors:   make([]error, len(args)),
+	}
+	var (
+		tags    []names.Tag
+		indices []int
+	)
+	for i, arg := range args {
+		tag, err := names.ParseTag(arg.Tag)
+		if err != nil {
+			batch.Errors[i] = err
+			if badTagErr != nil {
+				batch.Errors[i] = badTagErr
+			}
+			continue
+		}
+		batch.Tags[i] = tag
+		if !canAccess(tag) {
+			batch.Errors[i] = ErrPerm
+			continue
+		}
+		tags = append(tags, tag)
+		indices = append(indices, i)
+	}
+	if len(tags) == 0 {
+		return batch
+	}
+
+	var (
+		entities []state.Entity
+		errs     []error
+	)
+	if finder, ok := st.(state.BulkEntityFinder); ok {
+		entities, errs = finder.FindEntities(tags)
+	} else {
+		entities = make([]state.Entity, len(tags))
+		errs = make([]error, len(tags))
+		for i, tag := range tags {
+			entities[i], errs[i] = st.FindEntity(tag)
+		}
+	}
+	for i, index := range indices {
+		batch.Entities[index] = entities[i]
+		batch.Errors[index] = errs[i]
+	}
+	return batch
+}
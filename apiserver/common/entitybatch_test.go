@@ -0,0 +1,103 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+type entityBatchSuite struct{}
+
+var _ = gc.Suite(&entityBatchSuite{})
+
+// fakeBulkState is a fakeState that records the tags it is asked to
+// find in bulk.
+type fakeBulkState struct {
+	fakeState
+	findEntitiesCalls [][]names.Tag
+}
+
+func (st *fakeBulkState) FindEntity(tag names.Tag) (state.Entity, error) {
+	panic("FindEntity called on a bulk entity finder")
+}
+
+func (st *fakeBulkState) FindEntities(tags []names.Tag) ([]state.Entity, []error) {
+	st.findEntitiesCalls = append(st.findEntitiesCalls, tags)
+	entities := make([]state.Entity, len(tags))
+	errs := make([]error, len(tags))
+	for i, tag := range tags {
+		entities[i], errs[i] = st.fakeState.FindEntity(tag)
+	}
+	return entities, errs
+}
+
+func (*entityBatchSuite) args() []params.Entity {
+	return []params.Entity{
+		{"unit-x-0"}, {"unit-x-1"}, {"bad-tag"}, {"unit-x-2"}, {"unit-x-3"},
+	}
+}
+
+func (*entityBatchSuite) canAccess(tag names.Tag) bool {
+	return tag != u("x/1")
+}
+
+func (s *entityBatchSuite) TestFetchEntities(c *gc.C) {
+	x0 := &fakeLifer{life: state.Alive}
+	st := &fakeState{
+		entities: map[names.Tag]entityWithError{
+			u("x/0"): x0,
+			u("x/1"): &fakeLifer{life: state.Alive},
+			u("x/2"): &fakeLifer{fetchError: "x2 error"},
+		},
+	}
+	batch := common.FetchEntities(st, s.canAccess, s.args(), nil)
+	c.Assert(batch.Tags, jc.DeepEquals, []names.Tag{u("x/0"), u("x/1"), nil, u("x/2"), u("x/3")})
+	c.Assert(batch.Entities[0], gc.Equals, x0)
+	c.Assert(batch.Errors[0], jc.ErrorIsNil)
+	c.Assert(batch.Entities[1], gc.IsNil)
+	c.Assert(batch.Errors[1], gc.Equals, common.ErrPerm)
+	c.Assert(batch.Errors[2], gc.ErrorMatches, `"bad-tag" is not a valid tag`)
+	c.Assert(batch.Errors[3], gc.ErrorMatches, "x2 error")
+	c.Assert(batch.Errors[4], gc.ErrorMatches, `entity "unit-x-3" not found`)
+}
+
+func (s *entityBatchSuite) TestFetchEntitiesBadTagError(c *gc.C) {
+	batch := common.FetchEntities(&fakeState{}, s.canAccess, s.args(), common.ErrPerm)
+	c.Assert(batch.Errors[2], gc.Equals, common.ErrPerm)
+}
+
+func (s *entityBatchSuite) TestFetchEntitiesBulk(c *gc.C) {
+	x0 := &fakeLifer{life: state.Alive}
+	st := &fakeBulkState{
+		fakeState: fakeState{
+			entities: map[names.Tag]entityWithError{
+				u("x/0"): x0,
+				u("x/2"): &fakeLifer{fetchError: "x2 error"},
+			},
+		},
+	}
+	batch := common.FetchEntities(st, s.canAccess, s.args(), nil)
+	// Only the authorized entities are found, in a single call.
+	c.Assert(st.findEntitiesCalls, jc.DeepEquals, [][]names.Tag{
+		{u("x/0"), u("x/2"), u("x/3")},
+	})
+	c.Assert(batch.Entities[0], gc.Equals, x0)
+	c.Assert(batch.Errors[1], gc.Equals, common.ErrPerm)
+	c.Assert(batch.Errors[2], gc.ErrorMatches, `"bad-tag" is not a valid tag`)
+	c.Assert(batch.Errors[3], gc.ErrorMatches, "x2 error")
+	c.Assert(batch.Errors[4], gc.ErrorMatches, `entity "unit-x-3" not found`)
+}
+
+func (s *entityBatchSuite) TestFetchEntitiesNoneAuthorized(c *gc.C) {
+	st := &fakeBulkState{}
+	batch := common.FetchEntities(st, func(names.Tag) bool { return false }, s.args(), nil)
+	c.Assert(st.findEntitiesCalls, gc.HasLen, 0)
+	c.Assert(batch.Errors[0], gc.Equals, common.ErrPerm)
+}
@@ -0,0 +1,94 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// EntityChangesWatcher is a state.StringsWatcher reporting the ids of
+// changed entities, whose changes are described to clients with each
+// entity's tag, kind and life.
+type EntityChangesWatcher interface {
+	state.StringsWatcher
+
+	// DescribeChanges returns a description of each of the entities
+	// identified in a change.
+	DescribeChanges(ids []string) ([]params.EntityChange, error)
+}
+
+// NewEntityChangesWatcher returns an EntityChangesWatcher describing
+// the changes reported by w, which must be the ids of entities that
+// newTag converts to tags, by finding the entities in st.
+func NewEntityChangesWatcher(
+	st state.EntityFinder,
+	w state.StringsWatcher,
+	newTag func(id string) names.Tag,
+) EntityChangesWatcher {
+	return &entityChangesWatcher{
+		StringsWatcher: w,
+		st:             st,
+		newTag:         newTag,
+	}
+}
+
+type entityChangesWatcher struct {
+	state.StringsWatcher
+	st     state.EntityFinder
+	newTag func(id string) names.Tag
+}
+
+// DescribeChanges is part of the EntityChangesWatcher interface.
+func (w *entityChangesWatcher) DescribeChanges(ids []string) ([]params.EntityChange, error) {
+	changes := make([]params.EntityChange, len(ids))
+	for i, id := range ids {
+		tag := w.newTag(id)
+		changes[i] = params.EntityChange{
+			Tag:  tag.String(),
+			Kind: tag.Kind(),
+		}
+		entity, err := w.st.FindEntity(tag)
+		if errors.IsNotFound(err) {
+			changes[i].Life = params.Dead
+			changes[i].Removed = true
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		lifer, ok := entity.(state.Lifer)
+		if !ok {
+			return nil, NotSupportedError(tag, "life")
+		}
+		changes[i].Life = params.Life(lifer.Life().String())
+	}
+	return changes, nil
+}
+
+// WatchEntityChanges consumes the initial event of the given watcher,
+// describes it and registers the watcher with resources, so that the
+// changes it reports can be sent to a client as entity changes. The
+// watcher is stopped if its initial event cannot be described.
+func WatchEntityChanges(resources facade.Resources, watch EntityChangesWatcher) (params.EntityChangesWatchResult, error) {
+	nothing := params.EntityChangesWatchResult{}
+	// Consume the initial event and forward it to the result.
+	ids, ok := <-watch.Changes()
+	if !ok {
+		return nothing, watcher.EnsureErr(watch)
+	}
+	changes, err := watch.DescribeChanges(ids)
+	if err != nil {
+		watch.Stop()
+		return nothing, errors.Trace(err)
+	}
+	return params.EntityChangesWatchResult{
+		EntityChangesWatcherId: resources.Register(watch),
+		Changes:                changes,
+	}, nil
+}
@@ -14,6 +14,7 @@ import (
 	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/actions"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/state"
@@ -243,6 +244,27 @@ func ServerError(err error) *params.Error {
 		code = params.CodeNotImplemented
 	case state.IsIncompatibleSeriesError(err):
 		code = params.CodeIncompatibleSeries
+	case state.IsQuotaExceededError(err):
+		code = params.CodeQuotaExceeded
+	case state.IsInMaintenanceError(err):
+		code = params.CodeInMaintenance
+	case actions.IsParamsError(err):
+		code = params.CodeActionParamsNotValid
+		info = &params.ErrorInfo{
+			ActionParams: actionParamErrors(err.(*actions.ParamsError)),
+		}
+	case state.IsPlacementConflictError(err):
+		conflict := err.(*state.ErrPlacementConflict)
+		code = params.CodePlacementConflict
+		info = &params.ErrorInfo{
+			PlacementConflict: &params.PlacementConflict{
+				MachineId:      conflict.MachineId,
+				OwnerModelUUID: conflict.OwnerModelUUID,
+				OwnerModelName: conflict.OwnerModelName,
+				Load:           MachineLoad(conflict.Load),
+				Conflicts:      conflict.Conflicts,
+			},
+		}
 	default:
 		if err, ok := err.(*DischargeRequiredError); ok {
 			code = params.CodeDischargeRequired
@@ -262,6 +284,33 @@ func ServerError(err error) *params.Error {
 	}
 }
 
+func actionParamErrors(err *actions.ParamsError) []params.ActionParamError {
+	result := make([]params.ActionParamError, len(err.Fields))
+	for i, field := range err.Fields {
+		result[i] = params.ActionParamError{
+			Field:   field.Field,
+			Message: field.Message,
+		}
+	}
+	return result
+}
+
+// MachineLoad returns the API representation of the load of a
+// machine.
+func MachineLoad(load state.MachineLoad) params.MachineLoad {
+	return params.MachineLoad{
+		MachineId:    load.MachineId,
+		Series:       load.Series,
+		Zone:         load.Zone,
+		Hardware:     load.Hardware,
+		Units:        load.Units,
+		Applications: load.Applications,
+		CPUPercent:   load.CPUPercent,
+		MemoryBytes:  load.MemoryBytes,
+		Saturation:   load.Saturation,
+	}
+}
+
 func DestroyErr(desc string, ids []string, errs []error) error {
 	// TODO(waigani) refactor DestroyErr to take a map of ids to errors.
 	if len(errs) == 0 {
@@ -338,6 +387,14 @@ func RestoreError(err error) error {
 		return err
 	case params.IsCodeStorageAttached(err):
 		return err
+	case params.IsCodeQuotaExceeded(err):
+		return err
+	case params.IsCodeInMaintenance(err):
+		return err
+	case params.IsCodeActionParamsNotValid(err):
+		return err
+	case params.IsCodePlacementConflict(err):
+		return err
 	case params.IsCodeNotSupported(err):
 		return errors.NewNotSupported(nil, msg)
 	case params.IsBadRequest(err):
@@ -11,11 +11,13 @@ import (
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/txn"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/actions"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/state"
@@ -185,6 +187,26 @@ var errorTransformTests = []struct {
 	err:    unhashableError{"foo"},
 	status: http.StatusInternalServerError,
 	code:   "",
+}, {
+	err:        &state.ErrQuotaExceeded{Resource: state.QuotaUnits, Limit: 2, Used: 2, Requested: 1},
+	code:       params.CodeQuotaExceeded,
+	status:     http.StatusInternalServerError,
+	helperFunc: params.IsCodeQuotaExceeded,
+}, {
+	err:        &state.ErrInMaintenance{Tag: names.NewMachineTag("0"), Reason: "replacing disks"},
+	code:       params.CodeInMaintenance,
+	status:     http.StatusInternalServerError,
+	helperFunc: params.IsCodeInMaintenance,
+}, {
+	err:        actionParamsError,
+	code:       params.CodeActionParamsNotValid,
+	status:     http.StatusInternalServerError,
+	helperFunc: params.IsCodeActionParamsNotValid,
+}, {
+	err:        placementConflictError,
+	code:       params.CodePlacementConflict,
+	status:     http.StatusInternalServerError,
+	helperFunc: params.IsCodePlacementConflict,
 }, {
 	err:        common.UnknownModelError("dead-beef-123456"),
 	code:       params.CodeModelNotFound,
@@ -196,6 +218,34 @@ var errorTransformTests = []struct {
 	status: http.StatusOK,
 }}
 
+var actionParamsError = func() error {
+	spec := charm.ActionSpec{Params: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"count": map[string]interface{}{"type": "integer"},
+		},
+	}}
+	_, err := actions.PrepareParams("snapshot", spec, map[string]interface{}{"count": "many"})
+	if err == nil {
+		panic("expected action parameters error")
+	}
+	return err
+}()
+
+var placementConflictError = &state.ErrPlacementConflict{
+	MachineId:      "3",
+	OwnerModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+	OwnerModelName: "elsewhere",
+	Load: state.MachineLoad{
+		MachineId:    "3",
+		Series:       "bionic",
+		Units:        []string{"mysql/0"},
+		Applications: []string{"mysql"},
+		MemoryBytes:  1024,
+	},
+	Conflicts: []string{"machine belongs to another model"},
+}
+
 var sampleMacaroon = func() *macaroon.Macaroon {
 	m, err := macaroon.New([]byte("key"), "id", "loc")
 	if err != nil {
@@ -239,6 +289,10 @@ func (s *errorsSuite) TestErrorTransform(c *gc.C) {
 			params.CodeMachineHasAttachedStorage,
 			params.CodeDischargeRequired,
 			params.CodeModelNotFound,
+			params.CodeQuotaExceeded,
+			params.CodeInMaintenance,
+			params.CodeActionParamsNotValid,
+			params.CodePlacementConflict,
 			params.CodeRetry:
 			continue
 		case params.CodeOperationBlocked:
@@ -285,3 +339,34 @@ func (s *errorsSuite) TestDestroyErr(c *gc.C) {
 	err = common.DestroyErr("entities", ids, errs[1:])
 	c.Assert(err, gc.ErrorMatches, "some entities were not destroyed: error two; error three")
 }
+
+func (s *errorsSuite) TestActionParamsErrorInfo(c *gc.C) {
+	err := common.ServerError(errors.Annotate(actionParamsError, "enqueueing action"))
+	c.Assert(err, jc.Satisfies, params.IsCodeActionParamsNotValid)
+	c.Assert(err.Info, jc.DeepEquals, &params.ErrorInfo{
+		ActionParams: []params.ActionParamError{{
+			Field:   "count",
+			Message: "must be of type integer",
+		}},
+	})
+}
+
+func (s *errorsSuite) TestPlacementConflictErrorInfo(c *gc.C) {
+	err := common.ServerError(errors.Annotate(placementConflictError, "cannot deploy"))
+	c.Assert(err, jc.Satisfies, params.IsCodePlacementConflict)
+	c.Assert(err.Info, jc.DeepEquals, &params.ErrorInfo{
+		PlacementConflict: &params.PlacementConflict{
+			MachineId:      "3",
+			OwnerModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			OwnerModelName: "elsewhere",
+			Load: params.MachineLoad{
+				MachineId:    "3",
+				Series:       "bionic",
+				Units:        []string{"mysql/0"},
+				Applications: []string{"mysql"},
+				MemoryBytes:  1024,
+			},
+			Conflicts: []string{"machine belongs to another model"},
+		},
+	})
+}
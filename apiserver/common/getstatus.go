@@ -30,13 +30,8 @@ func NewStatusGetter(st state.EntityFinder, getCanAccess GetAuthFunc) *StatusGet
 	}
 }
 
-func (s *StatusGetter) getEntityStatus(tag names.Tag) params.StatusResult {
+func getEntityStatus(tag names.Tag, entity state.Entity) params.StatusResult {
 	var result params.StatusResult
-	entity, err := s.st.FindEntity(tag)
-	if err != nil {
-		result.Error = ServerError(err)
-		return result
-	}
 	switch getter := entity.(type) {
 	case status.StatusGetter:
 		statusInfo, err := getter.Status()
@@ -60,17 +55,13 @@ func (s *StatusGetter) Status(args params.Entities) (params.StatusResults, error
 	if err != nil {
 		return params.StatusResults{}, err
 	}
-	for i, entity := range args.Entities {
-		tag, err := names.ParseTag(entity.Tag)
-		if err != nil {
+	batch := FetchEntities(s.st, canAccess, args.Entities, nil)
+	for i, entity := range batch.Entities {
+		if err := batch.Errors[i]; err != nil {
 			result.Results[i].Error = ServerError(err)
 			continue
 		}
-		if !canAccess(tag) {
-			result.Results[i].Error = ServerError(ErrPerm)
-			continue
-		}
-		result.Results[i] = s.getEntityStatus(tag)
+		result.Results[i] = getEntityStatus(batch.Tags[i], entity)
 	}
 	return result, nil
 }
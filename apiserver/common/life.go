@@ -26,11 +26,7 @@ func NewLifeGetter(st state.EntityFinder, getCanRead GetAuthFunc) *LifeGetter {
 	}
 }
 
-func (lg *LifeGetter) oneLife(tag names.Tag) (params.Life, error) {
-	entity0, err := lg.st.FindEntity(tag)
-	if err != nil {
-		return "", err
-	}
+func oneLife(tag names.Tag, entity0 state.Entity) (params.Life, error) {
 	entity, ok := entity0.(state.Lifer)
 	if !ok {
 		return "", NotSupportedError(tag, "life cycles")
@@ -50,15 +46,11 @@ func (lg *LifeGetter) Life(args params.Entities) (params.LifeResults, error) {
 	if err != nil {
 		return params.LifeResults{}, errors.Trace(err)
 	}
-	for i, entity := range args.Entities {
-		tag, err := names.ParseTag(entity.Tag)
-		if err != nil {
-			result.Results[i].Error = ServerError(ErrPerm)
-			continue
-		}
-		err = ErrPerm
-		if canRead(tag) {
-			result.Results[i].Life, err = lg.oneLife(tag)
+	batch := FetchEntities(lg.st, canRead, args.Entities, ErrPerm)
+	for i, entity := range batch.Entities {
+		err := batch.Errors[i]
+		if err == nil {
+			result.Results[i].Life, err = oneLife(batch.Tags[i], entity)
 		}
 		result.Results[i].Error = ServerError(err)
 	}
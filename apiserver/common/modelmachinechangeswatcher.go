@@ -0,0 +1,56 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// ModelMachineChangesBackend describes the state needed to watch the
+// top level machines in a model and report their life.
+type ModelMachineChangesBackend interface {
+	state.ModelMachinesWatcher
+	state.EntityFinder
+}
+
+// ModelMachineChangesWatcher implements a common
+// WatchModelMachineChanges method for use by various facades.
+type ModelMachineChangesWatcher struct {
+	st         ModelMachineChangesBackend
+	resources  facade.Resources
+	authorizer facade.Authorizer
+}
+
+// NewModelMachineChangesWatcher returns a new ModelMachineChangesWatcher.
+func NewModelMachineChangesWatcher(st ModelMachineChangesBackend, resources facade.Resources, authorizer facade.Authorizer) *ModelMachineChangesWatcher {
+	return &ModelMachineChangesWatcher{
+		st:         st,
+		resources:  resources,
+		authorizer: authorizer,
+	}
+}
+
+// WatchModelMachineChanges returns an EntityChangesWatcher that
+// notifies of changes to the life cycles of the top level machines in
+// the current model. Unlike WatchModelMachines, each change reports
+// the machine's life, so that clients need not read it after every
+// notification.
+func (e *ModelMachineChangesWatcher) WatchModelMachineChanges() (params.EntityChangesWatchResult, error) {
+	if !e.authorizer.AuthController() {
+		return params.EntityChangesWatchResult{}, ErrPerm
+	}
+	watch := NewEntityChangesWatcher(e.st, e.st.WatchModelMachines(), func(id string) names.Tag {
+		return names.NewMachineTag(id)
+	})
+	result, err := WatchEntityChanges(e.resources, watch)
+	if err != nil {
+		return result, errors.Annotate(err, "cannot obtain initial model machines")
+	}
+	return result, nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+)
+
+type modelMachineChangesWatcherSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&modelMachineChangesWatcherSuite{})
+
+type fakeModelMachineChangesBackend struct {
+	fakeModelMachinesWatcher
+	fakeState
+}
+
+func (s *modelMachineChangesWatcherSuite) TestWatchModelMachineChanges(c *gc.C) {
+	authorizer := apiservertesting.FakeAuthorizer{
+		Tag:        names.NewMachineTag("0"),
+		Controller: true,
+	}
+	resources := common.NewResources()
+	s.AddCleanup(func(_ *gc.C) { resources.StopAll() })
+	st := &fakeModelMachineChangesBackend{
+		fakeModelMachinesWatcher: fakeModelMachinesWatcher{initial: []string{"0", "1", "2"}},
+		fakeState: fakeState{
+			entities: map[names.Tag]entityWithError{
+				names.NewMachineTag("0"): &fakeLifer{life: state.Alive},
+				names.NewMachineTag("1"): &fakeLifer{life: state.Dying},
+			},
+		},
+	}
+	e := common.NewModelMachineChangesWatcher(st, resources, authorizer)
+	result, err := e.WatchModelMachineChanges()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.EntityChangesWatchResult{
+		EntityChangesWatcherId: "1",
+		Changes: []params.EntityChange{
+			{Tag: "machine-0", Kind: "machine", Life: params.Alive},
+			{Tag: "machine-1", Kind: "machine", Life: params.Dying},
+			{Tag: "machine-2", Kind: "machine", Life: params.Dead, Removed: true},
+		},
+	})
+	c.Assert(resources.Get("1"), gc.Implements, new(common.EntityChangesWatcher))
+}
+
+func (s *modelMachineChangesWatcherSuite) TestWatchModelMachineChangesAuthError(c *gc.C) {
+	authorizer := apiservertesting.FakeAuthorizer{
+		Tag:        names.NewMachineTag("1"),
+		Controller: false,
+	}
+	resources := common.NewResources()
+	s.AddCleanup(func(_ *gc.C) { resources.StopAll() })
+	e := common.NewModelMachineChangesWatcher(
+		&fakeModelMachineChangesBackend{},
+		resources,
+		authorizer,
+	)
+	_, err := e.WatchModelMachineChanges()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(resources.Count(), gc.Equals, 0)
+}
@@ -97,9 +97,13 @@ type Model interface {
 	UUID() string
 	ControllerUUID() string
 	LastModelConnection(user names.UserTag) (time.Time, error)
+	UserActivity() ([]state.ModelUserActivity, error)
 	AddUser(state.UserAccessSpec) (permission.UserAccess, error)
 	AutoConfigureContainerNetworking(environ environs.Environ) error
 	ModelConfigDefaultValues() (config.ModelDefaultAttributes, error)
+	DestroyOperation() string
+	DestroyProgress() (state.ModelDestroyProgress, error)
+	WatchDestroyProgress() state.NotifyWatcher
 }
 
 var _ ModelManagerBackend = (*modelManagerStateShim)(nil)
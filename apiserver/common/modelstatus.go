@@ -29,6 +29,8 @@ func NewModelStatusAPI(backend ModelManagerBackend, authorizer facade.Authorizer
 }
 
 // ModelStatus returns a summary of the model.
+//
+//juju:readonly
 func (c *ModelStatusAPI) ModelStatus(req params.Entities) (params.ModelStatusResults, error) {
 	models := req.Entities
 	status := make([]params.ModelStatus, len(models))
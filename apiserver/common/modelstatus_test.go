@@ -59,7 +59,7 @@ func (s *modelStatusSuite) SetUpTest(c *gc.C) {
 	s.pool = state.NewStatePool(s.State)
 	s.AddCleanup(func(*gc.C) { s.pool.Close() })
 
-	controller, err := controller.NewControllerAPIv4(
+	controller, err := controller.NewControllerAPIv9(
 		facadetest.Context{
 			State_:     s.State,
 			Resources_: s.resources,
@@ -78,7 +78,7 @@ func (s *modelStatusSuite) TestModelStatusNonAuth(c *gc.C) {
 	anAuthoriser := apiservertesting.FakeAuthorizer{
 		Tag: user.Tag(),
 	}
-	endpoint, err := controller.NewControllerAPIv4(
+	endpoint, err := controller.NewControllerAPIv9(
 		facadetest.Context{
 			State_:     s.State,
 			Resources_: s.resources,
@@ -103,7 +103,7 @@ func (s *modelStatusSuite) TestModelStatusOwnerAllowed(c *gc.C) {
 	}
 	st := s.Factory.MakeModel(c, &factory.ModelParams{Owner: owner.Tag()})
 	defer st.Close()
-	endpoint, err := controller.NewControllerAPIv4(
+	endpoint, err := controller.NewControllerAPIv9(
 		facadetest.Context{
 			State_:     s.State,
 			Resources_: s.resources,
@@ -43,6 +43,25 @@ func ModelUserInfo(user permission.UserAccess, st modelConnectionAbleBackend) (p
 	return userInfo, nil
 }
 
+// ModelUserActivity converts state.ModelUserActivity to
+// params.ModelUserActivity.
+func ModelUserActivity(activity state.ModelUserActivity) params.ModelUserActivity {
+	result := params.ModelUserActivity{
+		UserTag:  names.NewUserTag(activity.UserName).String(),
+		ModelTag: names.NewModelTag(activity.ModelUUID).String(),
+		APICalls: activity.APICalls,
+	}
+	if !activity.LastAccess.IsZero() {
+		lastAccess := activity.LastAccess
+		result.LastAccess = &lastAccess
+	}
+	if !activity.LastConnection.IsZero() {
+		lastConnection := activity.LastConnection
+		result.LastConnection = &lastConnection
+	}
+	return result
+}
+
 // StateToParamsUserAccessPermission converts permission.Access to params.AccessPermission.
 func StateToParamsUserAccessPermission(descriptionAccess permission.Access) (params.UserAccessPermission, error) {
 	switch descriptionAccess {
@@ -0,0 +1,23 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// MachineResources returns the resources limited by model quotas that
+// adding a machine with the supplied parameters would consume. Adding
+// a container to an existing machine consumes none.
+func MachineResources(p params.AddMachineParams) state.ModelResources {
+	if p.ContainerType != "" && p.ParentId != "" {
+		return state.ModelResources{}
+	}
+	resources := state.ModelResources{Machines: 1}
+	if p.Constraints.CpuCores != nil {
+		resources.Cores = int(*p.Constraints.CpuCores)
+	}
+	return resources
+}
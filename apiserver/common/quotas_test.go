@@ -0,0 +1,41 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+)
+
+type quotasSuite struct{}
+
+var _ = gc.Suite(&quotasSuite{})
+
+func (*quotasSuite) TestMachineResources(c *gc.C) {
+	for i, test := range []struct {
+		p        params.AddMachineParams
+		expected state.ModelResources
+	}{{
+		p:        params.AddMachineParams{},
+		expected: state.ModelResources{Machines: 1},
+	}, {
+		p:        params.AddMachineParams{Constraints: constraints.MustParse("cores=4")},
+		expected: state.ModelResources{Machines: 1, Cores: 4},
+	}, {
+		p:        params.AddMachineParams{ContainerType: instance.LXD},
+		expected: state.ModelResources{Machines: 1},
+	}, {
+		p:        params.AddMachineParams{ContainerType: instance.LXD, ParentId: "0"},
+		expected: state.ModelResources{},
+	}} {
+		c.Logf("test %d", i)
+		c.Check(common.MachineResources(test.p), jc.DeepEquals, test.expected)
+	}
+}
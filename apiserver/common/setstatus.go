@@ -5,6 +5,7 @@ package common
 
 import (
 	"fmt"
+	"reflect"
 	"time"
 
 	"github.com/juju/errors"
@@ -47,6 +48,9 @@ func (s *ApplicationStatusSetter) SetStatus(args params.SetStatus) (params.Error
 		return params.ErrorResults{}, err
 	}
 
+	// TODO(perrito666) 2016-05-02 lp:1558657
+	now := time.Now()
+	latest := latestStatusArgs(args)
 	for i, arg := range args.Entities {
 
 		// TODO(fwereade): the auth is basically nonsense, and basically only
@@ -74,6 +78,15 @@ func (s *ApplicationStatusSetter) SetStatus(args params.SetStatus) (params.Error
 			continue
 		}
 		unitId := unitTag.Id()
+		sInfo, err := EntityStatusInfo(arg, now)
+		if err != nil {
+			result.Results[i].Error = ServerError(err)
+			continue
+		}
+		if latest[i] != i {
+			// A later entry will set the status.
+			continue
+		}
 
 		// Now we have the unit, we can get the service that should have been
 		// specified in the first place...
@@ -101,19 +114,12 @@ func (s *ApplicationStatusSetter) SetStatus(args params.SetStatus) (params.Error
 			result.Results[i].Error = ServerError(err)
 			continue
 		}
-		// TODO(perrito666) 2016-05-02 lp:1558657
-		now := time.Now()
-		sInfo := status.StatusInfo{
-			Status:  status.Status(arg.Status),
-			Message: arg.Info,
-			Data:    arg.Data,
-			Since:   &now,
-		}
 		if err := service.SetStatus(sInfo); err != nil {
 			result.Results[i].Error = ServerError(err)
 		}
 
 	}
+	shareLatestResults(result, latest)
 	return result, nil
 }
 
@@ -134,28 +140,103 @@ func NewStatusSetter(st state.EntityFinder, getCanModify GetAuthFunc) *StatusSet
 	}
 }
 
-func (s *StatusSetter) setEntityStatus(tag names.Tag, entityStatus status.Status, info string, data map[string]interface{}, updated *time.Time) error {
-	entity, err := s.st.FindEntity(tag)
-	if err != nil {
-		return err
+// EntityStatusInfo returns the status info to record for the given
+// arguments. The status is recorded as set at the Since time in the
+// arguments, if there is one, and at now otherwise; a Since time after
+// now is replaced by now, so that an agent with a skewed clock cannot
+// make a status appear newer than it is. An error satisfying
+// errors.IsNotValid is returned if the status data is not valid.
+func EntityStatusInfo(arg params.EntityStatusArgs, now time.Time) (status.StatusInfo, error) {
+	if err := validateStatusData(arg.Data); err != nil {
+		return status.StatusInfo{}, errors.Trace(err)
+	}
+	since := now
+	if arg.Since != nil && !arg.Since.IsZero() && arg.Since.Before(now) {
+		since = *arg.Since
+	}
+	return status.StatusInfo{
+		Status:  status.Status(arg.Status),
+		Message: arg.Info,
+		Data:    arg.Data,
+		Since:   &since,
+	}, nil
+}
+
+// validateStatusData checks that the status data is made up only of the
+// values that can be stored and serialised as JSON: strings, numbers,
+// booleans and nil, and lists and maps of them.
+func validateStatusData(data map[string]interface{}) error {
+	for key, value := range data {
+		if key == "" {
+			return errors.NotValidf("empty status data key")
+		}
+		if err := validateStatusDataValue(value); err != nil {
+			return errors.Annotatef(err, "status data %q", key)
+		}
+	}
+	return nil
+}
+
+func validateStatusDataValue(value interface{}) error {
+	switch value := value.(type) {
+	case nil, string, bool, int, int32, int64, float32, float64:
+		return nil
+	case []interface{}:
+		for _, v := range value {
+			if err := validateStatusDataValue(v); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		return nil
+	case map[string]interface{}:
+		return errors.Trace(validateStatusData(value))
+	}
+	return errors.NotValidf("value of type %s", reflect.TypeOf(value))
+}
+
+// latestStatusArgs returns, for each of the given arguments, the index
+// of the last argument for the same entity. An agent may report several
+// statuses for an entity in a single call, for example when it has
+// queued them while running hooks; only the last of them is written.
+func latestStatusArgs(args params.SetStatus) []int {
+	last := make(map[string]int)
+	for i, arg := range args.Entities {
+		last[arg.Tag] = i
+	}
+	latest := make([]int, len(args.Entities))
+	for i, arg := range args.Entities {
+		latest[i] = last[arg.Tag]
 	}
+	return latest
+}
+
+// shareLatestResults gives each argument superseded by a later one for
+// the same entity the result of the later argument, unless it failed
+// in its own right.
+func shareLatestResults(result params.ErrorResults, latest []int) {
+	for i, j := range latest {
+		if i != j && result.Results[i].Error == nil {
+			result.Results[i].Error = result.Results[j].Error
+		}
+	}
+}
+
+// statusSetterEntity returns the given entity as a status.StatusSetter,
+// if its status can be set through a StatusSetter.
+func statusSetterEntity(tag names.Tag, entity state.Entity) (status.StatusSetter, error) {
 	switch entity := entity.(type) {
 	case *state.Application:
-		return ErrPerm
+		return nil, ErrPerm
 	case status.StatusSetter:
-		sInfo := status.StatusInfo{
-			Status:  entityStatus,
-			Message: info,
-			Data:    data,
-			Since:   updated,
-		}
-		return entity.SetStatus(sInfo)
+		return entity, nil
 	default:
-		return NotSupportedError(tag, fmt.Sprintf("setting status, %T", entity))
+		return nil, NotSupportedError(tag, fmt.Sprintf("setting status, %T", entity))
 	}
 }
 
-// SetStatus sets the status of each given entity.
+// SetStatus sets the status of each given entity. Where an entity is
+// given more than once, only its last status is set. The statuses are
+// written together, in a single transaction where the entities allow.
 func (s *StatusSetter) SetStatus(args params.SetStatus) (params.ErrorResults, error) {
 	result := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.Entities)),
@@ -169,26 +250,34 @@ func (s *StatusSetter) SetStatus(args params.SetStatus) (params.ErrorResults, er
 	}
 	// TODO(perrito666) 2016-05-02 lp:1558657
 	now := time.Now()
+	batch := FetchEntities(s.st, canModify, entityArgs(args), nil)
+	latest := latestStatusArgs(args)
+	var updates []state.StatusUpdate
+	var indices []int
 	for i, arg := range args.Entities {
-		tag, err := names.ParseTag(arg.Tag)
-		if err != nil {
-			result.Results[i].Error = ServerError(err)
-			continue
-		}
-		err = ErrPerm
-		if canModify(tag) {
-			err = s.setEntityStatus(tag, status.Status(arg.Status), arg.Info, arg.Data, &now)
+		err := batch.Errors[i]
+		if err == nil {
+			var sInfo status.StatusInfo
+			sInfo, err = EntityStatusInfo(arg, now)
+			if err == nil && latest[i] == i {
+				var entity status.StatusSetter
+				entity, err = statusSetterEntity(batch.Tags[i], batch.Entities[i])
+				if err == nil {
+					updates = append(updates, state.StatusUpdate{Entity: entity, Info: sInfo})
+					indices = append(indices, i)
+				}
+			}
 		}
 		result.Results[i].Error = ServerError(err)
 	}
+	for j, err := range state.SetStatuses(updates) {
+		result.Results[indices[j]].Error = ServerError(err)
+	}
+	shareLatestResults(result, latest)
 	return result, nil
 }
 
-func (s *StatusSetter) updateEntityStatusData(tag names.Tag, data map[string]interface{}) error {
-	entity0, err := s.st.FindEntity(tag)
-	if err != nil {
-		return err
-	}
+func updateEntityStatusData(tag names.Tag, entity0 state.Entity, data map[string]interface{}) error {
 	statusGetter, ok := entity0.(status.StatusGetter)
 	if !ok {
 		return NotSupportedError(tag, "getting status")
@@ -238,21 +327,26 @@ func (s *StatusSetter) UpdateStatus(args params.SetStatus) (params.ErrorResults,
 	if err != nil {
 		return params.ErrorResults{}, errors.Trace(err)
 	}
+	batch := FetchEntities(s.st, canModify, entityArgs(args), ErrPerm)
 	for i, arg := range args.Entities {
-		tag, err := names.ParseTag(arg.Tag)
-		if err != nil {
-			result.Results[i].Error = ServerError(ErrPerm)
-			continue
-		}
-		err = ErrPerm
-		if canModify(tag) {
-			err = s.updateEntityStatusData(tag, arg.Data)
+		err := batch.Errors[i]
+		if err == nil {
+			err = updateEntityStatusData(batch.Tags[i], batch.Entities[i], arg.Data)
 		}
 		result.Results[i].Error = ServerError(err)
 	}
 	return result, nil
 }
 
+// entityArgs returns the entities whose status is set by args.
+func entityArgs(args params.SetStatus) []params.Entity {
+	entities := make([]params.Entity, len(args.Entities))
+	for i, arg := range args.Entities {
+		entities[i] = params.Entity{Tag: arg.Tag}
+	}
+	return entities
+}
+
 // UnitAgentFinder is a state.EntityFinder that finds unit agents.
 type UnitAgentFinder struct {
 	state.EntityFinder
@@ -141,6 +141,61 @@ func (s *statusSetterSuite) TestBulk(c *gc.C) {
 	c.Assert(result.Results[1].Error, gc.ErrorMatches, `"bad-tag" is not a valid tag`)
 }
 
+func (s *statusSetterSuite) TestSetStatusSince(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	since := time.Now().Add(-time.Hour).Round(time.Second).UTC()
+	result, err := s.setter.SetStatus(params.SetStatus{[]params.EntityStatusArgs{{
+		Tag:    machine.Tag().String(),
+		Status: status.Started.String(),
+		Since:  &since,
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+
+	machineStatus, err := machine.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineStatus.Since.Equal(since), jc.IsTrue)
+}
+
+func (s *statusSetterSuite) TestSetStatusInvalidData(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	result, err := s.setter.SetStatus(params.SetStatus{[]params.EntityStatusArgs{{
+		Tag:    machine.Tag().String(),
+		Status: status.Error.String(),
+		Data: map[string]interface{}{
+			"nested": map[string]interface{}{"bad": struct{}{}},
+		},
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, `status data "nested": status data "bad": value of type struct {} not valid`)
+}
+
+func (s *statusSetterSuite) TestSetStatusCollapsesRepeatedEntities(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	result, err := s.setter.SetStatus(params.SetStatus{[]params.EntityStatusArgs{{
+		Tag:    machine.Tag().String(),
+		Status: status.Stopped.String(),
+	}, {
+		Tag:    machine.Tag().String(),
+		Status: status.Started.String(),
+		Info:   "ready",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.IsNil)
+
+	machineStatus, err := machine.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineStatus.Status, gc.Equals, status.Started)
+	c.Assert(machineStatus.Message, gc.Equals, "ready")
+	history, err := machine.StatusHistory(status.StatusHistoryFilter{Size: 10})
+	c.Assert(err, jc.ErrorIsNil)
+	for _, h := range history {
+		c.Check(h.Status, gc.Not(gc.Equals), status.Stopped)
+	}
+}
+
 type serviceStatusSetterSuite struct {
 	statusBaseSuite
 	setter *common.ApplicationStatusSetter
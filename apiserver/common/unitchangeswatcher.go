@@ -0,0 +1,79 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// UnitChangesWatcher implements a common WatchUnitChanges method for
+// use by various facades.
+type UnitChangesWatcher struct {
+	st          state.EntityFinder
+	resources   facade.Resources
+	getCanWatch GetAuthFunc
+}
+
+// NewUnitChangesWatcher returns a new UnitChangesWatcher. The
+// GetAuthFunc will be used on each invocation of WatchUnitChanges to
+// determine current permissions.
+func NewUnitChangesWatcher(st state.EntityFinder, resources facade.Resources, getCanWatch GetAuthFunc) *UnitChangesWatcher {
+	return &UnitChangesWatcher{
+		st:          st,
+		resources:   resources,
+		getCanWatch: getCanWatch,
+	}
+}
+
+func (u *UnitChangesWatcher) watchOneEntityUnitChanges(canWatch AuthFunc, tag names.Tag) (params.EntityChangesWatchResult, error) {
+	nothing := params.EntityChangesWatchResult{}
+	if !canWatch(tag) {
+		return nothing, ErrPerm
+	}
+	entity0, err := u.st.FindEntity(tag)
+	if err != nil {
+		return nothing, err
+	}
+	entity, ok := entity0.(state.UnitsWatcher)
+	if !ok {
+		return nothing, NotSupportedError(tag, "watching units")
+	}
+	watch := NewEntityChangesWatcher(u.st, entity.WatchUnits(), func(id string) names.Tag {
+		return names.NewUnitTag(id)
+	})
+	return WatchEntityChanges(u.resources, watch)
+}
+
+// WatchUnitChanges starts an EntityChangesWatcher to watch all units
+// belonging to any entity (machine or application) passed in args.
+// Unlike WatchUnits, each change reports the unit's life, so that
+// clients need not read it after every notification.
+func (u *UnitChangesWatcher) WatchUnitChanges(args params.Entities) (params.EntityChangesWatchResults, error) {
+	result := params.EntityChangesWatchResults{
+		Results: make([]params.EntityChangesWatchResult, len(args.Entities)),
+	}
+	if len(args.Entities) == 0 {
+		return result, nil
+	}
+	canWatch, err := u.getCanWatch()
+	if err != nil {
+		return params.EntityChangesWatchResults{}, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = ServerError(ErrPerm)
+			continue
+		}
+		entityResult, err := u.watchOneEntityUnitChanges(canWatch, tag)
+		result.Results[i] = entityResult
+		result.Results[i].Error = ServerError(err)
+	}
+	return result, nil
+}
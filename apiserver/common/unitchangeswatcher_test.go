@@ -0,0 +1,77 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"fmt"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+)
+
+type unitChangesWatcherSuite struct{}
+
+var _ = gc.Suite(&unitChangesWatcherSuite{})
+
+func (*unitChangesWatcherSuite) TestWatchUnitChanges(c *gc.C) {
+	st := &fakeState{
+		entities: map[names.Tag]entityWithError{
+			u("x/0"):   &fakeUnitsWatcher{fetchError: "x0 fails"},
+			u("x/1"):   &fakeUnitsWatcher{initial: []string{"foo/0", "foo/1", "foo/2"}},
+			u("x/2"):   &fakeUnitsWatcher{},
+			u("foo/0"): &fakeLifer{life: state.Alive},
+			u("foo/1"): &fakeLifer{life: state.Dying},
+		},
+	}
+	getCanWatch := func() (common.AuthFunc, error) {
+		x0 := u("x/0")
+		x1 := u("x/1")
+		return func(tag names.Tag) bool {
+			return tag == x0 || tag == x1
+		}, nil
+	}
+	resources := common.NewResources()
+	w := common.NewUnitChangesWatcher(st, resources, getCanWatch)
+	entities := params.Entities{[]params.Entity{
+		{"unit-x-0"}, {"unit-x-1"}, {"unit-x-2"}, {"unit-x-3"},
+	}}
+	result, err := w.WatchUnitChanges(entities)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.EntityChangesWatchResults{
+		Results: []params.EntityChangesWatchResult{
+			{Error: &params.Error{Message: "x0 fails"}},
+			{
+				EntityChangesWatcherId: "1",
+				Changes: []params.EntityChange{
+					{Tag: "unit-foo-0", Kind: "unit", Life: params.Alive},
+					{Tag: "unit-foo-1", Kind: "unit", Life: params.Dying},
+					{Tag: "unit-foo-2", Kind: "unit", Life: params.Dead, Removed: true},
+				},
+			},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	c.Assert(resources.Get("1"), gc.Implements, new(common.EntityChangesWatcher))
+}
+
+func (*unitChangesWatcherSuite) TestWatchUnitChangesError(c *gc.C) {
+	getCanWatch := func() (common.AuthFunc, error) {
+		return nil, fmt.Errorf("pow")
+	}
+	resources := common.NewResources()
+	w := common.NewUnitChangesWatcher(
+		&fakeState{},
+		resources,
+		getCanWatch,
+	)
+	_, err := w.WatchUnitChanges(params.Entities{[]params.Entity{{"x0"}}})
+	c.Assert(err, gc.ErrorMatches, "pow")
+}
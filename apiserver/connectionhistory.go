@@ -0,0 +1,225 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/observer"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/rpc"
+)
+
+// agentConnectionHistorySize is the number of connections remembered
+// for each agent.
+const agentConnectionHistorySize = 20
+
+// agentConnectionHistory records the recent API connections that agents
+// make to the server: when they connected and disconnected, where from,
+// and how many requests they made to each facade. It helps to identify
+// agents that are reconnecting in a loop.
+//
+// Only the last agentConnectionHistorySize connections are kept for
+// each agent, and the history is held in memory, so it only covers
+// connections to this server since it started.
+type agentConnectionHistory struct {
+	clock clock.Clock
+
+	mu     sync.Mutex
+	agents map[agentConnectionKey]*agentConnections
+}
+
+// agentConnectionKey identifies an agent. Agents of different models
+// may have the same tag.
+type agentConnectionKey struct {
+	model string
+	tag   string
+}
+
+// agentConnections holds the recent connections of an agent in a ring
+// buffer.
+type agentConnections struct {
+	recent []*agentConnection
+	next   int
+	total  int
+}
+
+// agentConnection records an agent's connection.
+type agentConnection struct {
+	id           uint64
+	remoteAddr   string
+	connected    time.Time
+	disconnected time.Time
+	facadeCalls  map[string]int
+}
+
+func newAgentConnectionHistory(clock clock.Clock) *agentConnectionHistory {
+	return &agentConnectionHistory{
+		clock:  clock,
+		agents: make(map[agentConnectionKey]*agentConnections),
+	}
+}
+
+// add records a new connection for the agent, displacing its oldest
+// connection if it already has as many as are kept.
+func (h *agentConnectionHistory) add(key agentConnectionKey, conn *agentConnection) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	agent, ok := h.agents[key]
+	if !ok {
+		agent = &agentConnections{}
+		h.agents[key] = agent
+	}
+	if len(agent.recent) < agentConnectionHistorySize {
+		agent.recent = append(agent.recent, conn)
+	} else {
+		agent.recent[agent.next] = conn
+	}
+	agent.next = (agent.next + 1) % agentConnectionHistorySize
+	agent.total++
+}
+
+// AgentConnections is part of the common.AgentConnectionHistory
+// interface. Agents are ordered by the number of connections they have
+// made, most first, so that those reconnecting in a loop stand out.
+func (h *agentConnectionHistory) AgentConnections() []params.AgentConnectionHistory {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	result := make([]params.AgentConnectionHistory, 0, len(h.agents))
+	for key, agent := range h.agents {
+		history := params.AgentConnectionHistory{
+			Tag:              key.tag,
+			ModelTag:         names.NewModelTag(key.model).String(),
+			TotalConnections: agent.total,
+			Connections:      make([]params.AgentConnection, 0, len(agent.recent)),
+		}
+		start := 0
+		if len(agent.recent) == agentConnectionHistorySize {
+			start = agent.next
+		}
+		for i := range agent.recent {
+			conn := agent.recent[(start+i)%len(agent.recent)]
+			history.Connections = append(history.Connections, conn.params())
+		}
+		result = append(result, history)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].TotalConnections != result[j].TotalConnections {
+			return result[i].TotalConnections > result[j].TotalConnections
+		}
+		if result[i].ModelTag != result[j].ModelTag {
+			return result[i].ModelTag < result[j].ModelTag
+		}
+		return result[i].Tag < result[j].Tag
+	})
+	return result
+}
+
+// params returns the connection as a params.AgentConnection. It must
+// be called with the history's mutex held.
+func (conn *agentConnection) params() params.AgentConnection {
+	result := params.AgentConnection{
+		ConnectionID:  conn.id,
+		RemoteAddress: conn.remoteAddr,
+		Connected:     conn.connected,
+	}
+	if !conn.disconnected.IsZero() {
+		disconnected := conn.disconnected
+		result.Disconnected = &disconnected
+	}
+	if len(conn.facadeCalls) > 0 {
+		result.FacadeCalls = make(map[string]int)
+		for facade, n := range conn.facadeCalls {
+			result.FacadeCalls[facade] = n
+		}
+	}
+	return result
+}
+
+// newObserver returns an observer that records a connection in the
+// history, if it turns out to be from an agent.
+func (h *agentConnectionHistory) newObserver() observer.Observer {
+	return &agentConnectionObserver{history: h}
+}
+
+// agentConnectionObserver records an API connection in an
+// agentConnectionHistory.
+type agentConnectionObserver struct {
+	history    *agentConnectionHistory
+	id         uint64
+	remoteAddr string
+	connected  time.Time
+
+	// conn is set, with the history's mutex held, once an agent
+	// has logged in over the connection.
+	conn *agentConnection
+}
+
+// Join is part of the observer.Observer interface.
+func (o *agentConnectionObserver) Join(req *http.Request, connectionID uint64) {
+	o.id = connectionID
+	o.remoteAddr = req.RemoteAddr
+	o.connected = o.history.clock.Now()
+}
+
+// Login is part of the observer.Observer interface.
+func (o *agentConnectionObserver) Login(entity names.Tag, model names.ModelTag, fromController bool, _ string) {
+	if fromController {
+		// Don't record the controller's own connections to models.
+		return
+	}
+	switch entity.(type) {
+	case names.MachineTag, names.UnitTag, names.ApplicationTag:
+	default:
+		return
+	}
+	conn := &agentConnection{
+		id:          o.id,
+		remoteAddr:  o.remoteAddr,
+		connected:   o.connected,
+		facadeCalls: make(map[string]int),
+	}
+	o.history.add(agentConnectionKey{model: model.Id(), tag: entity.String()}, conn)
+	o.history.mu.Lock()
+	o.conn = conn
+	o.history.mu.Unlock()
+}
+
+// Leave is part of the observer.Observer interface.
+func (o *agentConnectionObserver) Leave() {
+	o.history.mu.Lock()
+	defer o.history.mu.Unlock()
+	if o.conn != nil {
+		o.conn.disconnected = o.history.clock.Now()
+	}
+}
+
+// RPCObserver is part of the observer.Observer interface.
+func (o *agentConnectionObserver) RPCObserver() rpc.Observer {
+	return agentRPCObserver{o}
+}
+
+// agentRPCObserver counts the requests made to each facade over an
+// agent's connection.
+type agentRPCObserver struct {
+	o *agentConnectionObserver
+}
+
+// ServerRequest is part of the rpc.Observer interface.
+func (r agentRPCObserver) ServerRequest(hdr *rpc.Header, _ interface{}) {
+	r.o.history.mu.Lock()
+	defer r.o.history.mu.Unlock()
+	if r.o.conn != nil {
+		r.o.conn.facadeCalls[hdr.Request.Type]++
+	}
+}
+
+// ServerReply is part of the rpc.Observer interface.
+func (agentRPCObserver) ServerReply(rpc.Request, *rpc.Header, interface{}) {}
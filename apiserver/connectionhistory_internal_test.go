@@ -0,0 +1,93 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/rpc"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type agentConnectionHistorySuite struct {
+	coretesting.BaseSuite
+	clock   *testing.Clock
+	history *agentConnectionHistory
+}
+
+var _ = gc.Suite(&agentConnectionHistorySuite{})
+
+var historyModelTag = coretesting.ModelTag
+
+func (s *agentConnectionHistorySuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC))
+	s.history = newAgentConnectionHistory(s.clock)
+}
+
+func (s *agentConnectionHistorySuite) connect(id uint64, entity names.Tag, fromController bool) *agentConnectionObserver {
+	o := s.history.newObserver().(*agentConnectionObserver)
+	o.Join(&http.Request{RemoteAddr: fmt.Sprintf("10.0.0.1:%d", id)}, id)
+	o.Login(entity, historyModelTag, fromController, "")
+	return o
+}
+
+func (s *agentConnectionHistorySuite) TestRecordsAgentConnection(c *gc.C) {
+	connected := s.clock.Now()
+	o := s.connect(1, names.NewMachineTag("0"), false)
+	request := &rpc.Header{Request: rpc.Request{Type: "Uniter", Action: "Life"}}
+	o.RPCObserver().ServerRequest(request, nil)
+	o.RPCObserver().ServerRequest(request, nil)
+	s.clock.Advance(time.Minute)
+	o.Leave()
+
+	disconnected := s.clock.Now()
+	c.Assert(s.history.AgentConnections(), jc.DeepEquals, []params.AgentConnectionHistory{{
+		Tag:              "machine-0",
+		ModelTag:         historyModelTag.String(),
+		TotalConnections: 1,
+		Connections: []params.AgentConnection{{
+			ConnectionID:  1,
+			RemoteAddress: "10.0.0.1:1",
+			Connected:     connected,
+			Disconnected:  &disconnected,
+			FacadeCalls:   map[string]int{"Uniter": 2},
+		}},
+	}})
+}
+
+func (s *agentConnectionHistorySuite) TestIgnoresUsersAndController(c *gc.C) {
+	s.connect(1, names.NewUserTag("bob"), false).Leave()
+	s.connect(2, names.NewMachineTag("0"), true).Leave()
+	c.Assert(s.history.AgentConnections(), gc.HasLen, 0)
+}
+
+func (s *agentConnectionHistorySuite) TestKeepsRecentConnections(c *gc.C) {
+	for id := uint64(1); id <= agentConnectionHistorySize+5; id++ {
+		s.connect(id, names.NewUnitTag("mysql/0"), false).Leave()
+	}
+	s.connect(100, names.NewMachineTag("1"), false)
+
+	agents := s.history.AgentConnections()
+	c.Assert(agents, gc.HasLen, 2)
+	// The agent with the most connections comes first.
+	c.Assert(agents[0].Tag, gc.Equals, "unit-mysql-0")
+	c.Assert(agents[0].TotalConnections, gc.Equals, agentConnectionHistorySize+5)
+	c.Assert(agents[0].Connections, gc.HasLen, agentConnectionHistorySize)
+	c.Assert(agents[0].Connections[0].ConnectionID, gc.Equals, uint64(6))
+	last := agents[0].Connections[agentConnectionHistorySize-1]
+	c.Assert(last.ConnectionID, gc.Equals, uint64(agentConnectionHistorySize+5))
+
+	c.Assert(agents[1].Tag, gc.Equals, "machine-1")
+	c.Assert(agents[1].Connections, gc.HasLen, 1)
+	c.Assert(agents[1].Connections[0].Disconnected, gc.IsNil)
+}
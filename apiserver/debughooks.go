@@ -0,0 +1,368 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	gorillaws "github.com/gorilla/websocket"
+	"github.com/juju/errors"
+	"github.com/juju/utils/featureflag"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/websocket"
+	"github.com/juju/juju/feature"
+	"github.com/juju/juju/permission"
+)
+
+// debugHooksBroker pairs the debug-hooks sessions that clients open for
+// units with the units' hook executions. A client opens a session over
+// one stream, and a unit running an intercepted hook attaches to the
+// session over another; the broker then relays messages between them
+// until the hook's shell exits.
+//
+// Sessions are held in memory, so a unit can only attach to a session
+// opened through the same API server that it is connected to.
+type debugHooksBroker struct {
+	mu       sync.Mutex
+	sessions map[string]*debugHooksSession
+}
+
+func newDebugHooksBroker() *debugHooksBroker {
+	return &debugHooksBroker{
+		sessions: make(map[string]*debugHooksSession),
+	}
+}
+
+// debugHooksSession is a client's debug-hooks session for a unit.
+type debugHooksSession struct {
+	hooks  set.Strings
+	attach chan debugHooksAttachment
+	closed chan struct{}
+}
+
+// debugHooksAttachment is a unit's stream for a hook execution, handed
+// to the session's client handler.
+type debugHooksAttachment struct {
+	hook string
+	conn *websocket.Conn
+	done chan struct{}
+}
+
+func (s *debugHooksSession) matchHook(hook string) bool {
+	return s.hooks.IsEmpty() || s.hooks.Contains(hook)
+}
+
+func debugHooksKey(modelUUID, unitName string) string {
+	return modelUUID + ":" + unitName
+}
+
+// Session is part of the common.DebugHooksSessions interface.
+func (b *debugHooksBroker) Session(modelUUID, unitName string) ([]string, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	session, ok := b.sessions[debugHooksKey(modelUUID, unitName)]
+	if !ok {
+		return nil, false
+	}
+	return session.hooks.SortedValues(), true
+}
+
+// open registers a new session for the unit, failing if there is one
+// already.
+func (b *debugHooksBroker) open(modelUUID, unitName string, hooks []string) (*debugHooksSession, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	key := debugHooksKey(modelUUID, unitName)
+	if _, ok := b.sessions[key]; ok {
+		return nil, errors.AlreadyExistsf("debug-hooks session for unit %q", unitName)
+	}
+	session := &debugHooksSession{
+		hooks:  set.NewStrings(hooks...),
+		attach: make(chan debugHooksAttachment),
+		closed: make(chan struct{}),
+	}
+	b.sessions[key] = session
+	return session, nil
+}
+
+// close unregisters the session.
+func (b *debugHooksBroker) close(modelUUID, unitName string, session *debugHooksSession) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	key := debugHooksKey(modelUUID, unitName)
+	if b.sessions[key] == session {
+		delete(b.sessions, key)
+	}
+	close(session.closed)
+}
+
+// find returns the unit's session if it intercepts the given hook.
+func (b *debugHooksBroker) find(modelUUID, unitName, hook string) (*debugHooksSession, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	session, ok := b.sessions[debugHooksKey(modelUUID, unitName)]
+	if !ok || !session.matchHook(hook) {
+		return nil, errors.NotFoundf("debug-hooks session for unit %q hook %q", unitName, hook)
+	}
+	return session, nil
+}
+
+func newDebugHooksClientHandler(ctxt httpContext, broker *debugHooksBroker) http.Handler {
+	return &debugHooksClientHandler{ctxt: ctxt, broker: broker}
+}
+
+// debugHooksClientHandler serves the streams over which clients open
+// debug-hooks sessions. The client lists the hooks to intercept in the
+// "hooks" query parameter; it then receives a message naming each hook
+// as the unit attaches, followed by the hook shell's output and exit
+// code, and sends the shell's input.
+type debugHooksClientHandler struct {
+	ctxt   httpContext
+	broker *debugHooksBroker
+}
+
+func (h *debugHooksClientHandler) authenticate(req *http.Request) (string, names.UnitTag, error) {
+	st, releaser, entity, err := h.ctxt.stateAndEntityForRequestAuthenticatedUser(req)
+	if err != nil {
+		return "", names.UnitTag{}, errors.Trace(err)
+	}
+	defer releaser()
+
+	// Debugging hooks gives a shell on the unit's machine, so requires
+	// the same access as ssh: model admin, or controller superuser.
+	isAdmin, err := common.HasPermission(st.UserPermission, entity.Tag(), permission.SuperuserAccess, st.ControllerTag())
+	if err != nil {
+		return "", names.UnitTag{}, errors.Trace(err)
+	}
+	if !isAdmin {
+		isAdmin, err = common.HasPermission(st.UserPermission, entity.Tag(), permission.AdminAccess, st.ModelTag())
+		if err != nil {
+			return "", names.UnitTag{}, errors.Trace(err)
+		}
+	}
+	if !isAdmin {
+		return "", names.UnitTag{}, errors.Trace(common.ErrPerm)
+	}
+
+	unitTag, err := names.ParseUnitTag(req.URL.Query().Get(":unit"))
+	if err != nil {
+		return "", names.UnitTag{}, errors.Trace(err)
+	}
+	if _, err := st.Unit(unitTag.Id()); err != nil {
+		return "", names.UnitTag{}, errors.Trace(err)
+	}
+	return st.ModelUUID(), unitTag, nil
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *debugHooksClientHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	handler := func(socket *websocket.Conn) {
+		defer socket.Close()
+
+		modelUUID, unitTag, err := h.authenticate(req)
+		if err != nil {
+			sendDebugHooksError(socket, req, err)
+			return
+		}
+		var hooks []string
+		if value := req.URL.Query().Get("hooks"); value != "" {
+			hooks = strings.Split(value, ",")
+		}
+		session, err := h.broker.open(modelUUID, unitTag.Id(), hooks)
+		if err != nil {
+			sendDebugHooksError(socket, req, err)
+			return
+		}
+		defer h.broker.close(modelUUID, unitTag.Id(), session)
+		logger.Infof("debug-hooks session opened for unit %q", unitTag.Id())
+		sendDebugHooksError(socket, req, nil)
+
+		socket.SetReadDeadline(time.Now().Add(websocket.PongDelay))
+		socket.SetPongHandler(func(string) error {
+			socket.SetReadDeadline(time.Now().Add(websocket.PongDelay))
+			return nil
+		})
+		ticker := time.NewTicker(websocket.PingPeriod)
+		defer ticker.Stop()
+
+		stop := make(chan struct{})
+		defer close(stop)
+		clientMessages, clientDone := readDebugHooksMessages(socket, stop)
+
+		// attached holds the unit stream of the hook currently running
+		// in the session, if any.
+		var attached *debugHooksAttachment
+		var unitMessages <-chan params.DebugHooksMessage
+		var unitDone <-chan struct{}
+		detach := func() {
+			close(attached.done)
+			attached, unitMessages, unitDone = nil, nil, nil
+		}
+		defer func() {
+			if attached != nil {
+				detach()
+			}
+		}()
+		for {
+			select {
+			case <-h.ctxt.stop():
+				return
+			case <-ticker.C:
+				deadline := time.Now().Add(websocket.WriteWait)
+				if err := socket.WriteControl(gorillaws.PingMessage, []byte{}, deadline); err != nil {
+					logger.Debugf("failed to write ping: %s", err)
+					return
+				}
+			case <-clientDone:
+				return
+			case m := <-clientMessages:
+				if attached == nil {
+					// There is no hook shell to receive the input.
+					continue
+				}
+				if err := attached.conn.WriteJSON(m); err != nil {
+					logger.Debugf("debug-hooks unit stream closed: %v", err)
+					detach()
+				}
+			case a := <-session.attach:
+				if attached != nil {
+					// Units run one hook at a time.
+					close(a.done)
+					continue
+				}
+				attached = &a
+				unitMessages, unitDone = readDebugHooksMessages(a.conn, a.done)
+				if err := socket.WriteJSON(params.DebugHooksMessage{Hook: a.hook}); err != nil {
+					return
+				}
+			case m := <-unitMessages:
+				if err := socket.WriteJSON(m); err != nil {
+					return
+				}
+				if m.ExitCode != nil {
+					detach()
+				}
+			case <-unitDone:
+				// The unit went away without reporting an exit code.
+				code := -1
+				if err := socket.WriteJSON(params.DebugHooksMessage{ExitCode: &code}); err != nil {
+					return
+				}
+				detach()
+			}
+		}
+	}
+	websocket.Serve(w, req, handler)
+}
+
+func newDebugHooksAgentHandler(ctxt httpContext, broker *debugHooksBroker) http.Handler {
+	return &debugHooksAgentHandler{ctxt: ctxt, broker: broker}
+}
+
+// debugHooksAgentHandler serves the streams over which units attach
+// hook executions to debug-hooks sessions. The unit names the hook in
+// the "hook" query parameter; it then receives the hook shell's input,
+// and sends its output and finally its exit code.
+type debugHooksAgentHandler struct {
+	ctxt   httpContext
+	broker *debugHooksBroker
+}
+
+func (h *debugHooksAgentHandler) authenticate(req *http.Request) (string, names.UnitTag, error) {
+	st, releaser, entity, err := h.ctxt.stateForRequestAuthenticatedTag(req, names.UnitTagKind)
+	if err != nil {
+		return "", names.UnitTag{}, errors.Trace(err)
+	}
+	defer releaser()
+	unitTag, err := names.ParseUnitTag(req.URL.Query().Get(":unit"))
+	if err != nil {
+		return "", names.UnitTag{}, errors.Trace(err)
+	}
+	if entity.Tag() != unitTag {
+		return "", names.UnitTag{}, errors.Trace(common.ErrPerm)
+	}
+	return st.ModelUUID(), unitTag, nil
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *debugHooksAgentHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	handler := func(socket *websocket.Conn) {
+		defer socket.Close()
+
+		modelUUID, unitTag, err := h.authenticate(req)
+		if err != nil {
+			sendDebugHooksError(socket, req, err)
+			return
+		}
+		hook := req.URL.Query().Get("hook")
+		session, err := h.broker.find(modelUUID, unitTag.Id(), hook)
+		if err != nil {
+			sendDebugHooksError(socket, req, err)
+			return
+		}
+		sendDebugHooksError(socket, req, nil)
+
+		// From here on, the session's client handler owns the socket
+		// until it closes done.
+		done := make(chan struct{})
+		select {
+		case <-h.ctxt.stop():
+			return
+		case <-session.closed:
+			return
+		case session.attach <- debugHooksAttachment{hook: hook, conn: socket, done: done}:
+		}
+		logger.Infof("unit %q running %q in debug-hooks session", unitTag.Id(), hook)
+		select {
+		case <-h.ctxt.stop():
+		case <-done:
+			// Tell the unit that the session has finished with the hook.
+			message := gorillaws.FormatCloseMessage(gorillaws.CloseNormalClosure, "")
+			socket.WriteMessage(gorillaws.CloseMessage, message)
+		}
+	}
+	websocket.Serve(w, req, handler)
+}
+
+// readDebugHooksMessages reads messages from the socket until it is
+// closed, when the returned done channel is closed, or until abort is
+// closed.
+func readDebugHooksMessages(socket *websocket.Conn, abort <-chan struct{}) (<-chan params.DebugHooksMessage, <-chan struct{}) {
+	messages := make(chan params.DebugHooksMessage)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			var m params.DebugHooksMessage
+			if err := socket.ReadJSON(&m); err != nil {
+				logger.Debugf("debug-hooks receive error: %v", err)
+				return
+			}
+			select {
+			case messages <- m:
+			case <-abort:
+				return
+			}
+		}
+	}()
+	return messages, done
+}
+
+// sendDebugHooksError sends a JSON-encoded error response.
+func sendDebugHooksError(ws *websocket.Conn, req *http.Request, err error) {
+	if err != nil && featureflag.Enabled(feature.DeveloperMode) {
+		logger.Errorf("returning error from %s %s: %s", req.Method, req.URL.Path, errors.Details(err))
+	}
+	if sendErr := ws.SendInitialErrorV0(err); sendErr != nil {
+		logger.Errorf("closing websocket, %v", err)
+		ws.Close()
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/websocket"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/websocket/websockettest"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type debugHooksSuite struct {
+	statetesting.StateSuite
+	pool         *state.StatePool
+	server       *apiserver.Server
+	unit         *state.Unit
+	unitPassword string
+}
+
+var _ = gc.Suite(&debugHooksSuite{})
+
+func (s *debugHooksSuite) SetUpTest(c *gc.C) {
+	s.StateSuite.SetUpTest(c)
+	s.pool = state.NewStatePool(s.State)
+	s.AddCleanup(func(*gc.C) { s.pool.Close() })
+	_, s.server = newServer(c, s.pool)
+	s.AddCleanup(func(*gc.C) { s.server.Stop() })
+	s.unit, s.unitPassword = s.Factory.MakeUnitReturningPassword(c, nil)
+}
+
+func (s *debugHooksSuite) debugHooksURL(unit string, agent bool, query url.Values) string {
+	path := fmt.Sprintf("/model/%s/units/%s/debug-hooks", s.State.ModelUUID(), unit)
+	if agent {
+		path += "/agent"
+	}
+	u := &url.URL{
+		Scheme:   "wss",
+		Host:     fmt.Sprintf("localhost:%d", s.server.Addr().Port),
+		Path:     path,
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
+
+func (s *debugHooksSuite) adminHeader(c *gc.C) http.Header {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Password: "sekrit",
+		Access:   permission.AdminAccess,
+	})
+	return utils.BasicAuthHeader(user.Tag().String(), "sekrit")
+}
+
+func (s *debugHooksSuite) unitHeader() http.Header {
+	return utils.BasicAuthHeader(s.unit.Tag().String(), s.unitPassword)
+}
+
+func (s *debugHooksSuite) dialClient(c *gc.C, header http.Header, hooks string) *websocket.Conn {
+	url := s.debugHooksURL(s.unit.Tag().String(), false, url.Values{"hooks": {hooks}})
+	return dialWebsocketFromURL(c, url, header)
+}
+
+func (s *debugHooksSuite) dialAgent(c *gc.C, hook string) *websocket.Conn {
+	url := s.debugHooksURL(s.unit.Tag().String(), true, url.Values{"hook": {hook}})
+	return dialWebsocketFromURL(c, url, s.unitHeader())
+}
+
+func (s *debugHooksSuite) TestClientRejectsNonAdmin(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Password: "sekrit",
+		Access:   permission.WriteAccess,
+	})
+	conn := s.dialClient(c, utils.BasicAuthHeader(user.Tag().String(), "sekrit"), "")
+	defer conn.Close()
+	websockettest.AssertJSONError(c, conn, "permission denied")
+	websockettest.AssertWebsocketClosed(c, conn)
+}
+
+func (s *debugHooksSuite) TestClientSessionExists(c *gc.C) {
+	header := s.adminHeader(c)
+	conn := s.dialClient(c, header, "")
+	defer conn.Close()
+	websockettest.AssertJSONInitialErrorNil(c, conn)
+
+	conn2 := s.dialClient(c, header, "")
+	defer conn2.Close()
+	websockettest.AssertJSONError(c, conn2, `debug-hooks session for unit ".*" already exists`)
+}
+
+func (s *debugHooksSuite) TestAgentRejectsOtherUnit(c *gc.C) {
+	other, password := s.Factory.MakeUnitReturningPassword(c, nil)
+	url := s.debugHooksURL(s.unit.Tag().String(), true, url.Values{"hook": {"install"}})
+	conn := dialWebsocketFromURL(c, url, utils.BasicAuthHeader(other.Tag().String(), password))
+	defer conn.Close()
+	websockettest.AssertJSONError(c, conn, "permission denied")
+	websockettest.AssertWebsocketClosed(c, conn)
+}
+
+func (s *debugHooksSuite) TestAgentNoSession(c *gc.C) {
+	conn := s.dialAgent(c, "install")
+	defer conn.Close()
+	websockettest.AssertJSONError(c, conn, `debug-hooks session for unit ".*" hook "install" not found`)
+	websockettest.AssertWebsocketClosed(c, conn)
+}
+
+func (s *debugHooksSuite) TestAgentHookNotIntercepted(c *gc.C) {
+	client := s.dialClient(c, s.adminHeader(c), "config-changed,start")
+	defer client.Close()
+	websockettest.AssertJSONInitialErrorNil(c, client)
+
+	conn := s.dialAgent(c, "install")
+	defer conn.Close()
+	websockettest.AssertJSONError(c, conn, `debug-hooks session for unit ".*" hook "install" not found`)
+}
+
+func (s *debugHooksSuite) TestRelay(c *gc.C) {
+	client := s.dialClient(c, s.adminHeader(c), "install")
+	defer client.Close()
+	websockettest.AssertJSONInitialErrorNil(c, client)
+
+	agent := s.dialAgent(c, "install")
+	defer agent.Close()
+	websockettest.AssertJSONInitialErrorNil(c, agent)
+
+	var m params.DebugHooksMessage
+	err := client.ReadJSON(&m)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m, jc.DeepEquals, params.DebugHooksMessage{Hook: "install"})
+
+	err = client.WriteJSON(params.DebugHooksMessage{Data: []byte("ls\n")})
+	c.Assert(err, jc.ErrorIsNil)
+	m = params.DebugHooksMessage{}
+	err = agent.ReadJSON(&m)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m, jc.DeepEquals, params.DebugHooksMessage{Data: []byte("ls\n")})
+
+	exitCode := 0
+	err = agent.WriteJSON(params.DebugHooksMessage{Data: []byte("hooks\n")})
+	c.Assert(err, jc.ErrorIsNil)
+	err = agent.WriteJSON(params.DebugHooksMessage{ExitCode: &exitCode})
+	c.Assert(err, jc.ErrorIsNil)
+
+	m = params.DebugHooksMessage{}
+	err = client.ReadJSON(&m)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m, jc.DeepEquals, params.DebugHooksMessage{Data: []byte("hooks\n")})
+	m = params.DebugHooksMessage{}
+	err = client.ReadJSON(&m)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m, jc.DeepEquals, params.DebugHooksMessage{ExitCode: &exitCode})
+
+	// Once the hook exits, the unit's stream is closed.
+	websockettest.AssertWebsocketClosed(c, agent)
+}
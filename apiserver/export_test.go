@@ -29,6 +29,8 @@ var (
 	JSMimeType            = jsMimeType
 	GUIURLPathPrefix      = guiURLPathPrefix
 	SpritePath            = spritePath
+	ReadOnlyMethods       = readOnlyMethods
+	MaxToolsBundleSize    = &maxToolsBundleSize
 )
 
 func ServerMacaroon(srv *Server) (*macaroon.Macaroon, error) {
@@ -78,9 +80,10 @@ func DelayLogins() (nextChan chan struct{}, cleanup func()) {
 		authTag names.Tag,
 		lookForModelUser bool,
 		authenticator authentication.EntityAuthenticator,
+		externalAccess func(names.UserTag, names.Tag) permission.Access,
 	) (state.Entity, *time.Time, error) {
 		<-nextChan
-		return checkCreds(st, c, authTag, lookForModelUser, authenticator)
+		return checkCreds(st, c, authTag, lookForModelUser, authenticator, externalAccess)
 	}
 	doCheckCreds = delayedCheckCreds
 	return
@@ -94,7 +97,7 @@ func NewErrRoot(err error) *errRoot {
 // *barely* connected to anything.  Just enough to let you probe some
 // of the interfaces, but not enough to actually do any RPC calls.
 func TestingAPIRoot(facades *facade.Registry) rpc.Root {
-	return newAPIRoot(nil, state.NewStatePool(nil), facades, common.NewResources(), nil)
+	return newAPIRoot(nil, state.NewStatePool(nil), facades, common.NewResources(), nil, nil)
 }
 
 // TestingAPIHandler gives you an APIHandler that isn't connected to
@@ -110,7 +113,7 @@ func TestingAPIHandler(c *gc.C, pool *state.StatePool, st *state.State) (*apiHan
 		statePool:     pool,
 		tag:           names.NewMachineTag("0"),
 	}
-	h, err := newAPIHandler(srv, st, nil, st.ModelUUID(), "testing.invalid:1234")
+	h, err := newAPIHandler(srv, st, nil, st.ModelUUID(), 0, "testing.invalid:1234")
 	c.Assert(err, jc.ErrorIsNil)
 	return h, h.getResources()
 }
@@ -131,6 +134,14 @@ func TestingUpgradingRoot() rpc.Root {
 	return restrictRoot(r, upgradeMethodsOnly)
 }
 
+// TestingReadOnlyRoot returns a restricted srvRoot for a read-only
+// connection.
+func TestingReadOnlyRoot() rpc.Root {
+	facades := AllFacades()
+	r := TestingAPIRoot(facades)
+	return restrictReadOnly(r, facades)
+}
+
 // TestingMigratingRoot returns a resricted srvRoot in a migration
 // scenario.
 func TestingMigratingRoot() rpc.Root {
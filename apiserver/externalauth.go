@@ -0,0 +1,60 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/permission"
+)
+
+// userAccessFunc returns the access a user has on a target.
+type userAccessFunc func(names.UserTag, names.Tag) (permission.Access, error)
+
+// externalAccess returns the access granted to the user on the target
+// by the server's external authorizer, if it has one. If the external
+// authorizer fails, the failure is logged and no access is granted, so
+// that users fall back to the access recorded in juju.
+func (srv *Server) externalAccess(user names.UserTag, target names.Tag) permission.Access {
+	if srv.externalAuthorizer == nil || user.IsLocal() {
+		return permission.NoAccess
+	}
+	access, err := srv.externalAuthorizer.Access(user, target)
+	if err != nil {
+		logger.Warningf("cannot obtain external access for %s: %v", user.Id(), err)
+		return permission.NoAccess
+	}
+	return access
+}
+
+// withExternalAccess returns a userAccessFunc that returns the access
+// returned by userAccess, or that granted by the server's external
+// authorizer if it is greater.
+func (srv *Server) withExternalAccess(userAccess userAccessFunc) userAccessFunc {
+	if srv.externalAuthorizer == nil {
+		return userAccess
+	}
+	return func(user names.UserTag, target names.Tag) (permission.Access, error) {
+		access, err := userAccess(user, target)
+		if err != nil && !errors.IsNotFound(err) {
+			return permission.NoAccess, errors.Trace(err)
+		}
+		if external := srv.externalAccess(user, target); greaterAccess(external, access, target) {
+			return external, nil
+		}
+		return access, err
+	}
+}
+
+// greaterAccess reports whether a is greater than b for the target.
+func greaterAccess(a, b permission.Access, target names.Tag) bool {
+	switch target.Kind() {
+	case names.ControllerTagKind:
+		return a.GreaterControllerAccessThan(b)
+	case names.ModelTagKind:
+		return a.GreaterModelAccessThan(b)
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/permission"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type externalAccessSuite struct {
+	coretesting.BaseSuite
+	authorizer *fakeExternalAuthorizer
+	srv        *Server
+}
+
+var _ = gc.Suite(&externalAccessSuite{})
+
+func (s *externalAccessSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.authorizer = &fakeExternalAuthorizer{
+		access: map[names.Tag]permission.Access{
+			coretesting.ControllerTag: permission.AddModelAccess,
+			coretesting.ModelTag:      permission.WriteAccess,
+		},
+	}
+	s.srv = &Server{externalAuthorizer: s.authorizer}
+}
+
+func stateAccess(access permission.Access, err error) userAccessFunc {
+	return func(names.UserTag, names.Tag) (permission.Access, error) {
+		return access, err
+	}
+}
+
+func (s *externalAccessSuite) TestExternalAccessGreater(c *gc.C) {
+	userAccess := s.srv.withExternalAccess(stateAccess(permission.ReadAccess, nil))
+	access, err := userAccess(names.NewUserTag("bob@external"), coretesting.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.WriteAccess)
+}
+
+func (s *externalAccessSuite) TestStateAccessGreater(c *gc.C) {
+	userAccess := s.srv.withExternalAccess(stateAccess(permission.SuperuserAccess, nil))
+	access, err := userAccess(names.NewUserTag("bob@external"), coretesting.ControllerTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.SuperuserAccess)
+}
+
+func (s *externalAccessSuite) TestExternalAccessWithoutStateUser(c *gc.C) {
+	userAccess := s.srv.withExternalAccess(stateAccess(permission.NoAccess, errors.NotFoundf("user")))
+	access, err := userAccess(names.NewUserTag("bob@external"), coretesting.ControllerTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.AddModelAccess)
+}
+
+func (s *externalAccessSuite) TestLocalUserIgnored(c *gc.C) {
+	userAccess := s.srv.withExternalAccess(stateAccess(permission.NoAccess, errors.NotFoundf("user")))
+	_, err := userAccess(names.NewUserTag("bob"), coretesting.ModelTag)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(s.authorizer.calls, gc.Equals, 0)
+}
+
+func (s *externalAccessSuite) TestExternalAuthorizerFailure(c *gc.C) {
+	s.authorizer.err = errors.New("ldap down")
+	userAccess := s.srv.withExternalAccess(stateAccess(permission.ReadAccess, nil))
+	access, err := userAccess(names.NewUserTag("bob@external"), coretesting.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.ReadAccess)
+}
+
+func (s *externalAccessSuite) TestNoExternalAuthorizer(c *gc.C) {
+	srv := &Server{}
+	access := srv.externalAccess(names.NewUserTag("bob@external"), coretesting.ModelTag)
+	c.Assert(access, gc.Equals, permission.NoAccess)
+}
+
+type fakeExternalAuthorizer struct {
+	access map[names.Tag]permission.Access
+	err    error
+	calls  int
+}
+
+func (a *fakeExternalAuthorizer) Access(user names.UserTag, target names.Tag) (permission.Access, error) {
+	a.calls++
+	return a.access[target], a.err
+}
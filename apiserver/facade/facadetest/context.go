@@ -15,6 +15,7 @@ type Context struct {
 	Resources_ facade.Resources
 	State_     *state.State
 	StatePool_ *state.StatePool
+	Hub_       facade.Hub
 	ID_        string
 	// Identity is not part of the facade.Context interface, but is instead
 	// used to make sure that the context objects are the same.
@@ -46,6 +47,11 @@ func (context Context) StatePool() *state.StatePool {
 	return context.StatePool_
 }
 
+// Hub is part of the facade.Context interface.
+func (context Context) Hub() facade.Hub {
+	return context.Hub_
+}
+
 // ID is part of the facade.Context interface.
 func (context Context) ID() string {
 	return context.ID_
@@ -61,6 +61,11 @@ type Context interface {
 	// creation of the expensive *State instances.
 	StatePool() *state.StatePool
 
+	// Hub returns the central hub that the API server holds.
+	// Messages published on the hub are forwarded to the other
+	// API servers in the controller.
+	Hub() Hub
+
 	// ID returns a string that should almost always be "", unless
 	// this is a watcher facade, in which case it exists in lieu of
 	// actual arguments in the Next() call, and is used as a key
@@ -69,6 +74,13 @@ type Context interface {
 	ID() string
 }
 
+// Hub represents the capabilities of the API server's central hub
+// that facades may use.
+type Hub interface {
+	Publish(topic string, data interface{}) (<-chan struct{}, error)
+	Subscribe(topic string, handler interface{}) (func(), error)
+}
+
 // Authorizer represents the authenticated entity using the API server.
 type Authorizer interface {
 
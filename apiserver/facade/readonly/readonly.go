@@ -0,0 +1,323 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package readonly finds the facade methods that are annotated as
+// read-only, so that the API server can allow them, and only them, on
+// read-only connections.
+//
+// A method is annotated by ending its doc comment with the line
+//
+//	//juju:readonly
+//
+// which states that the method doesn't change the controller or any of
+// its models. Methods of interface types are annotated in the same way.
+//
+// Facades often embed the types of older or newer facade versions, or
+// types from apiserver/common, so the methods found are recorded for
+// every type whose method set contains them, following the Go rules
+// for promoted and shadowed methods. That way a read-only method that
+// is shadowed by a mutating one in an older facade version isn't
+// allowed on that version.
+package readonly
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// Annotation is the doc comment line that marks a method as
+// read-only.
+const Annotation = "//juju:readonly"
+
+// Methods maps the import path and name of a type, joined with a ".",
+// to the sorted names of the read-only methods in its method set.
+type Methods map[string][]string
+
+// Find returns the read-only methods of the types declared in the
+// non-test Go files in dir and its subdirectories. The import path of
+// the package in dir is given by importPath.
+func Find(dir, importPath string) (Methods, error) {
+	f := &finder{
+		packages: make(map[string][]*ast.Package),
+		types:    make(map[string]*typeDecl),
+		sets:     make(map[string]methodSet),
+	}
+	if err := f.parse(dir, importPath); err != nil {
+		return nil, errors.Trace(err)
+	}
+	f.collect()
+
+	result := make(Methods)
+	for key := range f.types {
+		var names []string
+		for name, m := range f.methodSet(key) {
+			if m.readOnly && !m.ambiguous {
+				names = append(names, name)
+			}
+		}
+		if len(names) > 0 {
+			sort.Strings(names)
+			result[key] = names
+		}
+	}
+	return result, nil
+}
+
+// typeDecl holds a type declaration along with the methods declared
+// on it.
+type typeDecl struct {
+	spec    *ast.TypeSpec
+	imports map[string]string
+	pkgPath string
+
+	// methods maps the names of the exported methods declared on
+	// the type to whether they are annotated as read-only.
+	methods map[string]bool
+}
+
+// method describes a method in the method set of a type.
+type method struct {
+	readOnly bool
+
+	// depth holds the number of embedded fields the method is
+	// promoted through.
+	depth int
+
+	// ambiguous records whether more than one embedded field at
+	// the same depth provides the method, in which case it isn't
+	// part of the method set.
+	ambiguous bool
+}
+
+type methodSet map[string]method
+
+type finder struct {
+	fset     *token.FileSet
+	packages map[string][]*ast.Package
+	types    map[string]*typeDecl
+	sets     map[string]methodSet
+}
+
+func (f *finder) parse(dir, importPath string) error {
+	f.fset = token.NewFileSet()
+	notTest := func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}
+	return filepath.Walk(dir, func(filename string, info os.FileInfo, err error) error {
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		name := info.Name()
+		if filename != dir && (name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+			return filepath.SkipDir
+		}
+		pkgs, err := parser.ParseDir(f.fset, filename, notTest, parser.ParseComments)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		rel, err := filepath.Rel(dir, filename)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		pkgPath := path.Join(importPath, filepath.ToSlash(rel))
+		for _, pkg := range pkgs {
+			f.packages[pkgPath] = append(f.packages[pkgPath], pkg)
+		}
+		return nil
+	})
+}
+
+// collect records the types declared in the parsed packages, along
+// with the methods declared on them or, for interfaces, in them.
+func (f *finder) collect() {
+	for pkgPath, pkgs := range f.packages {
+		for _, pkg := range pkgs {
+			for _, file := range pkg.Files {
+				imports := f.fileImports(file)
+				for _, decl := range file.Decls {
+					if decl, ok := decl.(*ast.GenDecl); ok && decl.Tok == token.TYPE {
+						for _, spec := range decl.Specs {
+							spec := spec.(*ast.TypeSpec)
+							t := f.typeDecl(pkgPath, spec.Name.Name)
+							t.spec = spec
+							t.imports = imports
+							if iface, ok := spec.Type.(*ast.InterfaceType); ok {
+								for _, field := range iface.Methods.List {
+									for _, name := range field.Names {
+										if name.IsExported() {
+											t.methods[name.Name] = annotated(field.Doc)
+										}
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	for pkgPath, pkgs := range f.packages {
+		for _, pkg := range pkgs {
+			for _, file := range pkg.Files {
+				for _, decl := range file.Decls {
+					decl, ok := decl.(*ast.FuncDecl)
+					if !ok || decl.Recv == nil || len(decl.Recv.List) == 0 || !decl.Name.IsExported() {
+						continue
+					}
+					recv := decl.Recv.List[0].Type
+					if star, ok := recv.(*ast.StarExpr); ok {
+						recv = star.X
+					}
+					if ident, ok := recv.(*ast.Ident); ok {
+						t := f.typeDecl(pkgPath, ident.Name)
+						t.methods[decl.Name.Name] = annotated(decl.Doc)
+					}
+				}
+			}
+		}
+	}
+}
+
+func (f *finder) typeDecl(pkgPath, name string) *typeDecl {
+	key := pkgPath + "." + name
+	t, ok := f.types[key]
+	if !ok {
+		t = &typeDecl{
+			pkgPath: pkgPath,
+			methods: make(map[string]bool),
+		}
+		f.types[key] = t
+	}
+	return t
+}
+
+// fileImports maps the names that the file refers to its imported
+// packages by to their import paths. Only the names of packages that
+// have been parsed matter, since types from other packages can't be
+// resolved.
+func (f *finder) fileImports(file *ast.File) map[string]string {
+	imports := make(map[string]string)
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		if spec.Name != nil {
+			imports[spec.Name.Name] = importPath
+			continue
+		}
+		for _, pkg := range f.packages[importPath] {
+			imports[pkg.Name] = importPath
+		}
+	}
+	return imports
+}
+
+// methodSet returns the method set of the type with the given key,
+// including the methods promoted from any embedded fields.
+func (f *finder) methodSet(key string) methodSet {
+	if set, ok := f.sets[key]; ok {
+		return set
+	}
+	set := make(methodSet)
+	// Record the set before following embedded fields so that
+	// recursive types terminate.
+	f.sets[key] = set
+	t, ok := f.types[key]
+	if !ok || t.spec == nil {
+		return set
+	}
+
+	var embedded []ast.Expr
+	switch typ := t.spec.Type.(type) {
+	case *ast.StructType:
+		for _, field := range typ.Fields.List {
+			if len(field.Names) == 0 {
+				embedded = append(embedded, field.Type)
+			}
+		}
+	case *ast.InterfaceType:
+		for _, field := range typ.Methods.List {
+			if len(field.Names) == 0 {
+				embedded = append(embedded, field.Type)
+			}
+		}
+	}
+	for name, readOnly := range t.methods {
+		set[name] = method{readOnly: readOnly}
+	}
+
+	promoted := make(methodSet)
+	for _, expr := range embedded {
+		embeddedKey := f.typeKey(t, expr)
+		if embeddedKey == "" {
+			continue
+		}
+		for name, m := range f.methodSet(embeddedKey) {
+			if _, ok := set[name]; ok {
+				// Shadowed by a method declared on the type.
+				continue
+			}
+			m.depth++
+			existing, ok := promoted[name]
+			switch {
+			case !ok || m.depth < existing.depth:
+				promoted[name] = m
+			case m.depth == existing.depth:
+				existing.ambiguous = true
+				promoted[name] = existing
+			}
+		}
+	}
+	for name, m := range promoted {
+		set[name] = m
+	}
+	return set
+}
+
+// typeKey returns the key of the type named by the embedded field type
+// expr in the declaration of t, or "" if it can't be resolved.
+func (f *finder) typeKey(t *typeDecl, expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return t.pkgPath + "." + expr.Name
+	case *ast.SelectorExpr:
+		pkg, ok := expr.X.(*ast.Ident)
+		if !ok {
+			return ""
+		}
+		if importPath, ok := t.imports[pkg.Name]; ok {
+			return importPath + "." + expr.Sel.Name
+		}
+	}
+	return ""
+}
+
+// annotated reports whether the given doc comment contains the
+// read-only annotation.
+func annotated(doc *ast.CommentGroup) bool {
+	if doc == nil {
+		return false
+	}
+	for _, comment := range doc.List {
+		if strings.TrimSpace(comment.Text) == Annotation {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package readonly_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facade/readonly"
+)
+
+type readOnlySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&readOnlySuite{})
+
+const facadeSource = `
+package example
+
+import "example.com/facades/common"
+
+type API struct {
+	*common.Watcher
+}
+
+// Status returns the status.
+//
+//juju:readonly
+func (api *API) Status() {}
+
+// SetStatus sets the status.
+func (api *API) SetStatus() {}
+
+// APIv1 shadows Status with a method that changes the status.
+type APIv1 struct {
+	*API
+}
+
+// Status sets and returns the status.
+func (api *APIv1) Status() {}
+
+type Pinger interface {
+	//juju:readonly
+	Ping()
+	Stop()
+}
+
+//juju:readonly
+func (api *API) notExported() {}
+`
+
+const commonSource = `
+package common
+
+type Watcher struct{}
+
+//juju:readonly
+func (w *Watcher) Watch() {}
+`
+
+func (s *readOnlySuite) TestFind(c *gc.C) {
+	dir := c.MkDir()
+	writeFile(c, filepath.Join(dir, "example.go"), facadeSource)
+	writeFile(c, filepath.Join(dir, "example_test.go"), "package example\n\n//juju:readonly\nfunc (api *API) Test() {}\n")
+	writeFile(c, filepath.Join(dir, "common", "common.go"), commonSource)
+	writeFile(c, filepath.Join(dir, "testdata", "testdata.go"), commonSource)
+
+	methods, err := readonly.Find(dir, "example.com/facades")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(methods, jc.DeepEquals, readonly.Methods{
+		"example.com/facades.API":            {"Status", "Watch"},
+		"example.com/facades.APIv1":          {"Watch"},
+		"example.com/facades.Pinger":         {"Ping"},
+		"example.com/facades/common.Watcher": {"Watch"},
+	})
+}
+
+func (s *readOnlySuite) TestFindAmbiguous(c *gc.C) {
+	dir := c.MkDir()
+	writeFile(c, filepath.Join(dir, "example.go"), `
+package example
+
+type A struct{}
+
+//juju:readonly
+func (A) Get() {}
+
+type B struct{}
+
+//juju:readonly
+func (B) Get() {}
+
+type Both struct {
+	A
+	B
+}
+`)
+	methods, err := readonly.Find(dir, "example.com/facades")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(methods, jc.DeepEquals, readonly.Methods{
+		"example.com/facades.A": {"Get"},
+		"example.com/facades.B": {"Get"},
+	})
+}
+
+func writeFile(c *gc.C, filename, content string) {
+	err := os.MkdirAll(filepath.Dir(filename), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filename, []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -4,6 +4,8 @@
 package caasoperator
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/utils/proxy"
 	"gopkg.in/juju/names.v2"
@@ -12,8 +14,8 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/watcher"
-	"github.com/juju/juju/status"
 )
 
 type Facade struct {
@@ -108,12 +110,15 @@ func proxyUtilsSettingsToProxySettingsParam(settings proxy.Settings) params.Prox
 	}
 }
 
-// SetStatus sets the status of each given entity.
+// SetStatus sets the status of each given entity. The statuses are
+// written in a single transaction.
 func (f *Facade) SetStatus(args params.SetStatus) (params.ErrorResults, error) {
 	results := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.Entities)),
 	}
 	authTag := f.auth.GetAuthTag()
+	var updates []state.StatusUpdate
+	var indices []int
 	for i, arg := range args.Entities {
 		tag, err := names.ParseApplicationTag(arg.Tag)
 		if err != nil {
@@ -124,22 +129,24 @@ func (f *Facade) SetStatus(args params.SetStatus) (params.ErrorResults, error) {
 			results.Results[i].Error = common.ServerError(common.ErrPerm)
 			continue
 		}
-		info := status.StatusInfo{
-			Status:  status.Status(arg.Status),
-			Message: arg.Info,
-			Data:    arg.Data,
+		// TODO(perrito666) 2016-05-02 lp:1558657
+		info, err := common.EntityStatusInfo(arg, time.Now())
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
 		}
-		results.Results[i].Error = common.ServerError(f.setStatus(tag, info))
+		app, err := f.state.Application(tag.Id())
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		updates = append(updates, state.StatusUpdate{Entity: app, Info: info})
+		indices = append(indices, i)
 	}
-	return results, nil
-}
-
-func (f *Facade) setStatus(tag names.ApplicationTag, info status.StatusInfo) error {
-	app, err := f.state.Application(tag.Id())
-	if err != nil {
-		return errors.Trace(err)
+	for j, err := range state.SetStatuses(updates) {
+		results.Results[indices[j]].Error = common.ServerError(err)
 	}
-	return app.SetStatus(info)
+	return results, nil
 }
 
 // Charm returns the charm info for all given applications.
@@ -84,7 +84,10 @@ func (s *CAASOperatorSuite) TestSetStatus(c *gc.C) {
 	s.st.CheckCallNames(c, "Model", "Application")
 	s.st.CheckCall(c, 1, "Application", "gitlab")
 	s.st.app.CheckCallNames(c, "SetStatus")
-	s.st.app.CheckCall(c, 0, "SetStatus", status.StatusInfo{
+	info := s.st.app.Calls()[0].Args[0].(status.StatusInfo)
+	c.Assert(info.Since, gc.NotNil)
+	info.Since = nil
+	c.Assert(info, jc.DeepEquals, status.StatusInfo{
 		Status:  "bar",
 		Message: "baz",
 		Data: map[string]interface{}{
@@ -93,6 +96,23 @@ func (s *CAASOperatorSuite) TestSetStatus(c *gc.C) {
 	})
 }
 
+func (s *CAASOperatorSuite) TestSetStatusInvalidData(c *gc.C) {
+	args := params.SetStatus{
+		Entities: []params.EntityStatusArgs{{
+			Tag:    "application-gitlab",
+			Status: "bar",
+			Data: map[string]interface{}{
+				"qux": struct{}{},
+			},
+		}},
+	}
+
+	results, err := s.facade.SetStatus(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), gc.ErrorMatches, `status data "qux": value of type struct {} not valid`)
+	s.st.app.CheckNoCalls(c)
+}
+
 func (s *CAASOperatorSuite) TestCharm(c *gc.C) {
 	args := params.Entities{
 		Entities: []params.Entity{
@@ -0,0 +1,179 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package containerimagecache implements the API facade used by the
+// containerimagecache worker.
+package containerimagecache
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the State API used by the containerimagecache facade.
+type Backend interface {
+	// ModelConfig returns the model's configuration.
+	ModelConfig() (*config.Config, error)
+
+	// Machine returns the machine with the given id.
+	Machine(id string) (Machine, error)
+}
+
+// Machine defines the methods the containerimagecache facade needs
+// from state.Machine.
+type Machine interface {
+	ContainerImageCache() (state.ContainerImageCache, error)
+	SetContainerImages([]state.ContainerImage, *state.ContainerImagePurge) error
+	PendingContainerSeries() ([]string, error)
+}
+
+// Facade implements the API required by the containerimagecache worker.
+type Facade struct {
+	backend      Backend
+	getCanModify common.GetAuthFunc
+}
+
+// New returns a new API facade for the containerimagecache worker.
+func New(backend Backend, _ facade.Resources, authorizer facade.Authorizer) (*Facade, error) {
+	if !authorizer.AuthMachineAgent() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{
+		backend: backend,
+		getCanModify: func() (common.AuthFunc, error) {
+			return authorizer.AuthOwner, nil
+		},
+	}, nil
+}
+
+// ContainerImageCacheConfig returns, for each of the given machines,
+// the series of the images the machine should fetch ahead of creating
+// containers, the size its cache should be kept within, and any purge
+// it should carry out. The images to fetch are those of the model's
+// default series and of the containers about to be created on the
+// machine.
+func (facade *Facade) ContainerImageCacheConfig(args params.Entities) (params.ContainerImageCacheConfigResults, error) {
+	results := params.ContainerImageCacheConfigResults{
+		Results: make([]params.ContainerImageCacheConfigResult, len(args.Entities)),
+	}
+	canModify, err := facade.getCanModify()
+	if err != nil {
+		return results, err
+	}
+	modelConfig, err := facade.backend.ModelConfig()
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, arg := range args.Entities {
+		tag, err := names.ParseMachineTag(arg.Tag)
+		if err != nil || !canModify(tag) {
+			results.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		result, err := facade.cacheConfig(tag, modelConfig)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = result
+	}
+	return results, nil
+}
+
+func (facade *Facade) cacheConfig(tag names.MachineTag, modelConfig *config.Config) (*params.ContainerImageCacheConfig, error) {
+	machine, err := facade.backend.Machine(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	series, err := machine.PendingContainerSeries()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if defaultSeries, ok := modelConfig.DefaultSeries(); ok {
+		series = appendSeries(series, defaultSeries)
+	}
+	cache, err := machine.ContainerImageCache()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := &params.ContainerImageCacheConfig{
+		PrefetchSeries: series,
+		MaxSizeMB:      modelConfig.ContainerImageCacheSizeMB(),
+	}
+	if cache.Purge != nil {
+		result.Purge = &params.ContainerImagePurge{
+			Series:    cache.Purge.Series,
+			Requested: cache.Purge.Requested,
+		}
+	}
+	return result, nil
+}
+
+// appendSeries appends the series to the list unless it is already
+// present, keeping the list in order.
+func appendSeries(series []string, s string) []string {
+	for _, existing := range series {
+		if existing == s {
+			return series
+		}
+	}
+	return append([]string{s}, series...)
+}
+
+// SetContainerImages records the images in the container image caches
+// of the given machines, along with the purges they carried out.
+func (facade *Facade) SetContainerImages(args params.SetContainerImagesArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canModify, err := facade.getCanModify()
+	if err != nil {
+		return results, err
+	}
+	for i, arg := range args.Args {
+		tag, err := names.ParseMachineTag(arg.Tag)
+		if err != nil || !canModify(tag) {
+			results.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		machine, err := facade.backend.Machine(tag.Id())
+		if err == nil {
+			err = machine.SetContainerImages(stateImages(arg.Images), statePurge(arg.Purged))
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func stateImages(images []params.ContainerImage) []state.ContainerImage {
+	if len(images) == 0 {
+		return nil
+	}
+	result := make([]state.ContainerImage, len(images))
+	for i, image := range images {
+		result[i] = state.ContainerImage{
+			Series:      image.Series,
+			Arch:        image.Arch,
+			Fingerprint: image.Fingerprint,
+			Size:        image.Size,
+			LastUsed:    image.LastUsed,
+		}
+	}
+	return result
+}
+
+func statePurge(purge *params.ContainerImagePurge) *state.ContainerImagePurge {
+	if purge == nil {
+		return nil
+	}
+	return &state.ContainerImagePurge{
+		Series:    purge.Series,
+		Requested: purge.Requested,
+	}
+}
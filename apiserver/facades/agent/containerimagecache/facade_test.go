@@ -0,0 +1,185 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package containerimagecache_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/agent/containerimagecache"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+)
+
+type facadeSuite struct {
+	testing.BaseSuite
+	backend    *mockBackend
+	authorizer *apiservertesting.FakeAuthorizer
+	facade     *containerimagecache.Facade
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	cfg := testing.CustomModelConfig(c, testing.Attrs{
+		"default-series":             "bionic",
+		"container-image-cache-size": "2G",
+	})
+	s.backend = &mockBackend{
+		config: cfg,
+		machine: &mockMachine{
+			series: []string{"trusty", "xenial"},
+			cache: state.ContainerImageCache{
+				Purge: &state.ContainerImagePurge{
+					Series:    []string{"precise"},
+					Requested: time.Unix(0, 100).UTC(),
+				},
+			},
+		},
+	}
+	s.authorizer = &apiservertesting.FakeAuthorizer{Tag: names.NewMachineTag("1")}
+	facade, err := containerimagecache.New(s.backend, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade = facade
+}
+
+func (s *facadeSuite) TestRequiresMachineAgent(c *gc.C) {
+	s.authorizer.Tag = names.NewUnitTag("foo/0")
+	_, err := containerimagecache.New(s.backend, nil, s.authorizer)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *facadeSuite) TestContainerImageCacheConfig(c *gc.C) {
+	result, err := s.facade.ContainerImageCacheConfig(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "machine-0"},
+			{Tag: "machine-1"},
+			{Tag: "unit-foo-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ContainerImageCacheConfigResults{
+		Results: []params.ContainerImageCacheConfigResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Result: &params.ContainerImageCacheConfig{
+				PrefetchSeries: []string{"bionic", "trusty", "xenial"},
+				MaxSizeMB:      2048,
+				Purge: &params.ContainerImagePurge{
+					Series:    []string{"precise"},
+					Requested: time.Unix(0, 100).UTC(),
+				},
+			}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	s.backend.stub.CheckCallNames(c, "ModelConfig", "Machine", "PendingContainerSeries", "ContainerImageCache")
+	s.backend.stub.CheckCall(c, 1, "Machine", "1")
+}
+
+func (s *facadeSuite) TestContainerImageCacheConfigDefaultSeriesPending(c *gc.C) {
+	s.backend.machine.series = []string{"bionic"}
+	s.backend.machine.cache = state.ContainerImageCache{}
+	result, err := s.facade.ContainerImageCacheConfig(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-1"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Result, jc.DeepEquals, &params.ContainerImageCacheConfig{
+		PrefetchSeries: []string{"bionic"},
+		MaxSizeMB:      2048,
+	})
+}
+
+func (s *facadeSuite) TestSetContainerImages(c *gc.C) {
+	lastUsed := time.Unix(0, 200).UTC()
+	requested := time.Unix(0, 100).UTC()
+	result, err := s.facade.SetContainerImages(params.SetContainerImagesArgs{
+		Args: []params.SetContainerImages{{
+			Tag: "machine-0",
+		}, {
+			Tag: "machine-1",
+			Images: []params.ContainerImage{{
+				Series:      "xenial",
+				Arch:        "amd64",
+				Fingerprint: "deadbeef",
+				Size:        1024,
+				LastUsed:    lastUsed,
+			}},
+			Purged: &params.ContainerImagePurge{Requested: requested},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{},
+		},
+	})
+	s.backend.stub.CheckCallNames(c, "Machine", "SetContainerImages")
+	s.backend.stub.CheckCall(c, 1, "SetContainerImages", []state.ContainerImage{{
+		Series:      "xenial",
+		Arch:        "amd64",
+		Fingerprint: "deadbeef",
+		Size:        1024,
+		LastUsed:    lastUsed,
+	}}, &state.ContainerImagePurge{Requested: requested})
+}
+
+func (s *facadeSuite) TestSetContainerImagesError(c *gc.C) {
+	s.backend.stub.SetErrors(nil, errors.New("boom"))
+	result, err := s.facade.SetContainerImages(params.SetContainerImagesArgs{
+		Args: []params.SetContainerImages{{Tag: "machine-1"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, "boom")
+}
+
+type mockBackend struct {
+	stub    jujutesting.Stub
+	config  *config.Config
+	machine *mockMachine
+}
+
+func (b *mockBackend) ModelConfig() (*config.Config, error) {
+	b.stub.AddCall("ModelConfig")
+	return b.config, b.stub.NextErr()
+}
+
+func (b *mockBackend) Machine(id string) (containerimagecache.Machine, error) {
+	b.stub.AddCall("Machine", id)
+	if err := b.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	b.machine.stub = &b.stub
+	return b.machine, nil
+}
+
+type mockMachine struct {
+	stub   *jujutesting.Stub
+	series []string
+	cache  state.ContainerImageCache
+}
+
+func (m *mockMachine) ContainerImageCache() (state.ContainerImageCache, error) {
+	m.stub.AddCall("ContainerImageCache")
+	return m.cache, m.stub.NextErr()
+}
+
+func (m *mockMachine) SetContainerImages(images []state.ContainerImage, purged *state.ContainerImagePurge) error {
+	m.stub.AddCall("SetContainerImages", images, purged)
+	return m.stub.NextErr()
+}
+
+func (m *mockMachine) PendingContainerSeries() ([]string, error) {
+	m.stub.AddCall("PendingContainerSeries")
+	return m.series, m.stub.NextErr()
+}
@@ -0,0 +1,40 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package containerimagecache
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+)
+
+// NewFacade wraps New to express the supplied *state.State as a Backend.
+func NewFacade(st *state.State, res facade.Resources, auth facade.Authorizer) (*Facade, error) {
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	facade, err := New(&backendShim{st, model}, res, auth)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return facade, nil
+}
+
+type backendShim struct {
+	st    *state.State
+	model *state.Model
+}
+
+// ModelConfig implements Backend.
+func (b *backendShim) ModelConfig() (*config.Config, error) {
+	return b.model.ModelConfig()
+}
+
+// Machine implements Backend.
+func (b *backendShim) Machine(id string) (Machine, error) {
+	return b.st.Machine(id)
+}
@@ -0,0 +1,72 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package crashreporter implements the API facade used by agents to
+// upload the crash reports they collect.
+package crashreporter
+
+import (
+	"bytes"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the State API used by the crashreporter facade.
+type Backend interface {
+	ControllerConfig() (controller.Config, error)
+	AddCrashReport(args state.CrashReportArgs, quotaBytes int64) (state.CrashReport, error)
+}
+
+// Facade implements the API used by agents to upload crash reports.
+type Facade struct {
+	backend  Backend
+	canOwner common.AuthFunc
+}
+
+// New returns a new API facade for uploading crash reports.
+func New(backend Backend, _ facade.Resources, authorizer facade.Authorizer) (*Facade, error) {
+	if !authorizer.AuthMachineAgent() && !authorizer.AuthUnitAgent() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{
+		backend:  backend,
+		canOwner: authorizer.AuthOwner,
+	}, nil
+}
+
+// Upload stores the given crash reports. An agent may only upload its
+// own reports. Reports are subject to the controller's crash report
+// quota for each model.
+func (facade *Facade) Upload(args params.CrashReportUploads) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Reports)),
+	}
+	cfg, err := facade.backend.ControllerConfig()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	quotaBytes := int64(cfg.CrashReportQuotaMB()) * 1024 * 1024
+	for i, arg := range args.Reports {
+		tag, err := names.ParseTag(arg.Agent)
+		if err != nil || !facade.canOwner(tag) {
+			results.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		_, err = facade.backend.AddCrashReport(state.CrashReportArgs{
+			Agent:  arg.Agent,
+			Time:   arg.Time,
+			Reason: arg.Reason,
+			Bundle: bytes.NewReader(arg.Bundle),
+			Size:   int64(len(arg.Bundle)),
+		}, quotaBytes)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crashreporter_test
+
+import (
+	"io/ioutil"
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/agent/crashreporter"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+)
+
+type facadeSuite struct {
+	testing.BaseSuite
+	backend    *mockBackend
+	authorizer *apiservertesting.FakeAuthorizer
+	facade     *crashreporter.Facade
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		config: controller.Config{"crash-report-quota": "2M"},
+	}
+	s.authorizer = &apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("1"),
+	}
+	facade, err := crashreporter.New(s.backend, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade = facade
+}
+
+func (s *facadeSuite) TestNewRequiresAgent(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := crashreporter.New(s.backend, nil, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *facadeSuite) TestUpload(c *gc.C) {
+	crashTime := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	s.backend.stub.SetErrors(nil, nil, errors.New("boom"))
+	result, err := s.facade.Upload(params.CrashReportUploads{
+		Reports: []params.CrashReportUpload{{
+			Agent:  "machine-0",
+			Bundle: []byte("other"),
+		}, {
+			Agent:  "machine-1",
+			Time:   crashTime,
+			Reason: "panic: oops",
+			Bundle: []byte("bundle"),
+		}, {
+			Agent:  "machine-1",
+			Bundle: []byte("again"),
+		}, {
+			Agent: "invalid",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{nil},
+			{&params.Error{Message: "boom"}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	c.Assert(s.backend.added, jc.DeepEquals, []addedReport{{
+		agent:  "machine-1",
+		time:   crashTime,
+		reason: "panic: oops",
+		bundle: "bundle",
+		quota:  2 * 1024 * 1024,
+	}, {
+		agent:  "machine-1",
+		bundle: "again",
+		quota:  2 * 1024 * 1024,
+	}})
+}
+
+type addedReport struct {
+	agent  string
+	time   time.Time
+	reason string
+	bundle string
+	quota  int64
+}
+
+type mockBackend struct {
+	stub   jujutesting.Stub
+	config controller.Config
+	added  []addedReport
+}
+
+func (b *mockBackend) ControllerConfig() (controller.Config, error) {
+	b.stub.AddCall("ControllerConfig")
+	return b.config, b.stub.NextErr()
+}
+
+func (b *mockBackend) AddCrashReport(args state.CrashReportArgs, quotaBytes int64) (state.CrashReport, error) {
+	b.stub.AddCall("AddCrashReport", args, quotaBytes)
+	bundle, err := ioutil.ReadAll(args.Bundle)
+	if err != nil {
+		return state.CrashReport{}, err
+	}
+	b.added = append(b.added, addedReport{
+		agent:  args.Agent,
+		time:   args.Time,
+		reason: args.Reason,
+		bundle: string(bundle),
+		quota:  quotaBytes,
+	})
+	return state.CrashReport{}, b.stub.NextErr()
+}
@@ -21,6 +21,7 @@ type DeployerAPI struct {
 	*common.LifeGetter
 	*common.APIAddresser
 	*common.UnitsWatcher
+	*common.UnitChangesWatcher
 	*common.StatusSetter
 
 	st         *state.State
@@ -60,18 +61,40 @@ func NewDeployerAPI(
 		return authorizer.AuthOwner, nil
 	}
 	return &DeployerAPI{
-		Remover:         common.NewRemover(st, true, getAuthFunc),
-		PasswordChanger: common.NewPasswordChanger(st, getAuthFunc),
-		LifeGetter:      common.NewLifeGetter(st, getAuthFunc),
-		APIAddresser:    common.NewAPIAddresser(st, resources),
-		UnitsWatcher:    common.NewUnitsWatcher(st, resources, getCanWatch),
-		StatusSetter:    common.NewStatusSetter(st, getAuthFunc),
-		st:              st,
-		resources:       resources,
-		authorizer:      authorizer,
+		Remover:            common.NewRemover(st, true, getAuthFunc),
+		PasswordChanger:    common.NewPasswordChanger(st, getAuthFunc),
+		LifeGetter:         common.NewLifeGetter(st, getAuthFunc),
+		APIAddresser:       common.NewAPIAddresser(st, resources),
+		UnitsWatcher:       common.NewUnitsWatcher(st, resources, getCanWatch),
+		UnitChangesWatcher: common.NewUnitChangesWatcher(st, resources, getCanWatch),
+		StatusSetter:       common.NewStatusSetter(st, getAuthFunc),
+		st:                 st,
+		resources:          resources,
+		authorizer:         authorizer,
 	}, nil
 }
 
+// DeployerAPIV1 provides access to the Deployer API facade, version 1.
+type DeployerAPIV1 struct {
+	*DeployerAPI
+}
+
+// NewDeployerAPIV1 creates a new server-side DeployerAPIV1 facade.
+func NewDeployerAPIV1(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*DeployerAPIV1, error) {
+	api, err := NewDeployerAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &DeployerAPIV1{api}, nil
+}
+
+// WatchUnitChanges is not available in version 1 of the API.
+func (*DeployerAPIV1) WatchUnitChanges(_, _ struct{}) {}
+
 // ConnectionInfo returns all the address information that the
 // deployer task needs in one call.
 func (d *DeployerAPI) ConnectionInfo() (result params.DeployerConnectionValues, err error) {
@@ -146,6 +146,45 @@ func (s *deployerSuite) TestWatchUnits(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *deployerSuite) TestWatchUnitChanges(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	err := s.principal0.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "machine-1"},
+		{Tag: "machine-0"},
+		{Tag: "machine-42"},
+	}}
+	result, err := s.deployer.WatchUnitChanges(args)
+	c.Assert(err, jc.ErrorIsNil)
+	changes := result.Results[0].Changes
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Tag < changes[j].Tag })
+	c.Assert(result, gc.DeepEquals, params.EntityChangesWatchResults{
+		Results: []params.EntityChangesWatchResult{{
+			EntityChangesWatcherId: "1",
+			Changes: []params.EntityChange{
+				{Tag: "unit-logging-0", Kind: "unit", Life: params.Alive},
+				{Tag: "unit-mysql-0", Kind: "unit", Life: params.Dying},
+			},
+		},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Verify the resource was registered and stop when done
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event ("returned" in
+	// the Watch call)
+	wc := statetesting.NewStringsWatcherC(c, s.State, resource.(state.StringsWatcher))
+	wc.AssertNoChange()
+}
+
 func (s *deployerSuite) TestSetPasswords(c *gc.C) {
 	args := params.EntityPasswords{
 		Changes: []params.EntityPassword{
@@ -3,6 +3,8 @@
 package fanconfigurer
 
 import (
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/common/networkingcommon"
 	"github.com/juju/juju/apiserver/facade"
@@ -77,3 +79,56 @@ func (m *FanConfigurerAPI) FanConfig() (params.FanConfigResult, error) {
 	}
 	return networkingcommon.FanConfigToFanConfigResult(fanConfig), nil
 }
+
+// FanStatusSetter records the health of the model's fan overlays on a
+// machine.
+type FanStatusSetter interface {
+	SetFanStatus([]state.FanOverlayStatus) error
+}
+
+// FanConfigurerAPIV2 implements version 2 of the FanConfigurer facade,
+// which adds SetFanStatus.
+type FanConfigurerAPIV2 struct {
+	*FanConfigurerAPI
+	machine FanStatusSetter
+}
+
+// NewFanConfigurerAPIV2 creates a new FanConfigurer v2 API endpoint on
+// server-side, for the authenticated machine agent.
+func NewFanConfigurerAPIV2(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*FanConfigurerAPIV2, error) {
+	api, err := NewFanConfigurerAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machine, err := st.Machine(authorizer.GetAuthTag().Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewFanConfigurerAPIV2ForMachine(api, machine), nil
+}
+
+// NewFanConfigurerAPIV2ForMachine creates a new FanConfigurer v2 API
+// endpoint that records fan status with the supplied machine.
+func NewFanConfigurerAPIV2ForMachine(api *FanConfigurerAPI, machine FanStatusSetter) *FanConfigurerAPIV2 {
+	return &FanConfigurerAPIV2{
+		FanConfigurerAPI: api,
+		machine:          machine,
+	}
+}
+
+// SetFanStatus records the health of the model's fan overlays on the
+// authenticated machine.
+func (m *FanConfigurerAPIV2) SetFanStatus(args params.SetFanStatus) error {
+	overlays := make([]state.FanOverlayStatus, len(args.Overlays))
+	for i, overlay := range args.Overlays {
+		overlays[i] = state.FanOverlayStatus{
+			Underlay: overlay.Underlay,
+			Overlay:  overlay.Overlay,
+			Bridge:   overlay.Bridge,
+			Address:  overlay.Address,
+			Healthy:  overlay.Healthy,
+			Message:  overlay.Message,
+		}
+	}
+	return errors.Trace(m.machine.SetFanStatus(overlays))
+}
@@ -127,6 +127,60 @@ func (s *fanconfigurerSuite) TestFanConfigFetchError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "pow")
 }
 
+type fakeMachine struct {
+	overlays []state.FanOverlayStatus
+	err      error
+}
+
+func (m *fakeMachine) SetFanStatus(overlays []state.FanOverlayStatus) error {
+	m.overlays = overlays
+	return m.err
+}
+
+func (s *fanconfigurerSuite) TestSetFanStatus(c *gc.C) {
+	authorizer := apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	}
+	api, err := fanconfigurer.NewFanConfigurerAPIForModel(
+		&fakeModelAccessor{},
+		nil,
+		authorizer,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	machine := &fakeMachine{}
+	e := fanconfigurer.NewFanConfigurerAPIV2ForMachine(api, machine)
+
+	err = e.SetFanStatus(params.SetFanStatus{
+		Overlays: []params.FanOverlayStatus{{
+			Underlay: "10.100.0.0/16",
+			Overlay:  "251.0.0.0/8",
+			Bridge:   "fan-251",
+			Address:  "251.1.2.1",
+			Healthy:  true,
+		}, {
+			Underlay: "192.168.0.0/16",
+			Overlay:  "252.0.0.0/8",
+			Message:  "no fan bridge",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machine.overlays, jc.DeepEquals, []state.FanOverlayStatus{{
+		Underlay: "10.100.0.0/16",
+		Overlay:  "251.0.0.0/8",
+		Bridge:   "fan-251",
+		Address:  "251.1.2.1",
+		Healthy:  true,
+	}, {
+		Underlay: "192.168.0.0/16",
+		Overlay:  "252.0.0.0/8",
+		Message:  "no fan bridge",
+	}})
+
+	machine.err = fmt.Errorf("machine is dead")
+	err = e.SetFanStatus(params.SetFanStatus{})
+	c.Assert(err, gc.ErrorMatches, "machine is dead")
+}
+
 func testingEnvConfig(c *gc.C) *config.Config {
 	env, err := bootstrap.Prepare(
 		modelcmd.BootstrapContext(cmdtesting.Context(c)),
@@ -0,0 +1,147 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package hostfirewaller implements the API facade used by machine
+// agents to get the policy restricting their machines' outbound
+// traffic.
+package hostfirewaller
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/egress"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// Backend defines the state methods the facade needs, so they can be
+// mocked for testing.
+type Backend interface {
+	ModelConfig() (*config.Config, error)
+	APIHostPorts() ([][]network.HostPort, error)
+	WatchAPIHostPorts() state.NotifyWatcher
+	WatchForModelConfigChanges() state.NotifyWatcher
+	Machine(id string) (Machine, error)
+}
+
+// Machine defines the state methods the facade needs for a machine, so
+// they can be mocked for testing.
+type Machine interface {
+	EgressRules() ([]egress.Rule, error)
+	WatchEgressRules() state.NotifyWatcher
+}
+
+// Facade implements the API used by machine agents to get the policy
+// restricting their machines' outbound traffic.
+type Facade struct {
+	backend    Backend
+	resources  facade.Resources
+	authorizer facade.Authorizer
+}
+
+// New returns a new API facade for machine agents to get their egress
+// policies.
+func New(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*Facade, error) {
+	if !authorizer.AuthMachineAgent() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{
+		backend:    backend,
+		resources:  resources,
+		authorizer: authorizer,
+	}, nil
+}
+
+// EgressPolicy returns the outbound traffic policy for each of the
+// given machines. If the model's egress-policy is "restricted", the
+// policy allows traffic to the controllers' API addresses and to the
+// destinations declared by the charms of the machine's units.
+func (f *Facade) EgressPolicy(args params.Entities) (params.EgressPolicyResults, error) {
+	results := params.EgressPolicyResults{
+		Results: make([]params.EgressPolicyResult, len(args.Entities)),
+	}
+	cfg, err := f.backend.ModelConfig()
+	if err != nil {
+		return params.EgressPolicyResults{}, errors.Trace(err)
+	}
+	restricted := cfg.EgressPolicy() == egress.ModeRestricted
+	var apiHostPorts []params.HostPort
+	if restricted {
+		hostPorts, err := f.backend.APIHostPorts()
+		if err != nil {
+			return params.EgressPolicyResults{}, errors.Trace(err)
+		}
+		for _, servers := range hostPorts {
+			apiHostPorts = append(apiHostPorts, params.FromNetworkHostPorts(servers)...)
+		}
+	}
+	for i, arg := range args.Entities {
+		machine, err := f.authMachine(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if !restricted {
+			continue
+		}
+		rules, err := machine.EgressRules()
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result := params.EgressPolicyResult{
+			Restricted:   true,
+			APIHostPorts: apiHostPorts,
+		}
+		for _, rule := range rules {
+			result.Rules = append(result.Rules, params.EgressRule{
+				CIDR:  rule.CIDR,
+				Ports: rule.Ports,
+			})
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+// WatchEgressPolicy returns a NotifyWatcher for each of the given
+// machines that triggers whenever its egress policy may have changed.
+func (f *Facade) WatchEgressPolicy(args params.Entities) (params.NotifyWatchResults, error) {
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		machine, err := f.authMachine(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		w := common.NewMultiNotifyWatcher(
+			f.backend.WatchForModelConfigChanges(),
+			f.backend.WatchAPIHostPorts(),
+			machine.WatchEgressRules(),
+		)
+		if _, ok := <-w.Changes(); ok {
+			results.Results[i].NotifyWatcherId = f.resources.Register(w)
+		} else {
+			results.Results[i].Error = common.ServerError(watcher.EnsureErr(w))
+		}
+	}
+	return results, nil
+}
+
+// authMachine returns the machine with the given tag, if the
+// authenticated agent is that machine's agent.
+func (f *Facade) authMachine(tagString string) (Machine, error) {
+	tag, err := names.ParseMachineTag(tagString)
+	if err != nil || !f.authorizer.AuthOwner(tag) {
+		return nil, common.ErrPerm
+	}
+	return f.backend.Machine(tag.Id())
+}
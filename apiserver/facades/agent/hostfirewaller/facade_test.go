@@ -0,0 +1,206 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/agent/hostfirewaller"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/egress"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type facadeSuite struct {
+	coretesting.BaseSuite
+
+	backend    *stubBackend
+	resources  *common.Resources
+	authorizer apiservertesting.FakeAuthorizer
+	facade     *hostfirewaller.Facade
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.resources = common.NewResources()
+	s.AddCleanup(func(_ *gc.C) { s.resources.StopAll() })
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("1"),
+	}
+	s.backend = newStubBackend(c)
+	s.AddCleanup(func(_ *gc.C) { s.backend.Kill() })
+
+	var err error
+	s.facade, err = hostfirewaller.New(s.backend, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *facadeSuite) entities(tags ...string) params.Entities {
+	var args params.Entities
+	for _, tag := range tags {
+		args.Entities = append(args.Entities, params.Entity{Tag: tag})
+	}
+	return args
+}
+
+func (s *facadeSuite) TestNewRequiresMachineAgent(c *gc.C) {
+	s.authorizer.Tag = names.NewUnitTag("mysql/0")
+	_, err := hostfirewaller.New(s.backend, s.resources, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *facadeSuite) TestEgressPolicyOpen(c *gc.C) {
+	results, err := s.facade.EgressPolicy(s.entities("machine-1", "machine-0", "unit-mysql-0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.EgressPolicyResults{
+		Results: []params.EgressPolicyResult{
+			{},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	s.backend.CheckCallNames(c, "ModelConfig", "Machine")
+}
+
+func (s *facadeSuite) TestEgressPolicyRestricted(c *gc.C) {
+	s.backend.configAttrs["egress-policy"] = "restricted"
+	results, err := s.facade.EgressPolicy(s.entities("machine-1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.EgressPolicyResults{
+		Results: []params.EgressPolicyResult{{
+			Restricted: true,
+			Rules: []params.EgressRule{
+				{CIDR: "0.0.0.0/0", Ports: "443/tcp"},
+				{CIDR: "10.0.0.0/8"},
+			},
+			APIHostPorts: append(
+				params.FromNetworkHostPorts(network.NewHostPorts(17070, "10.0.0.1")),
+				params.FromNetworkHostPorts(network.NewHostPorts(17070, "10.0.0.2"))...,
+			),
+		}},
+	})
+	s.backend.CheckCallNames(c, "ModelConfig", "APIHostPorts", "Machine", "EgressRules")
+	s.backend.CheckCall(c, 2, "Machine", "1")
+}
+
+func (s *facadeSuite) TestEgressPolicyMachineError(c *gc.C) {
+	s.backend.configAttrs["egress-policy"] = "restricted"
+	s.backend.SetErrors(nil, nil, nil, errors.New("boom"))
+	results, err := s.facade.EgressPolicy(s.entities("machine-1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "boom")
+}
+
+func (s *facadeSuite) TestWatchEgressPolicy(c *gc.C) {
+	results, err := s.facade.WatchEgressPolicy(s.entities("machine-1", "machine-0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+	s.backend.CheckCallNames(c,
+		"Machine",
+		"WatchForModelConfigChanges",
+		"WatchAPIHostPorts",
+		"WatchEgressRules",
+	)
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	_, ok := s.resources.Get(results.Results[0].NotifyWatcherId).(state.NotifyWatcher)
+	c.Assert(ok, jc.IsTrue)
+}
+
+type stubBackend struct {
+	testing.Stub
+
+	c             *gc.C
+	configAttrs   coretesting.Attrs
+	hpWatcher     workertest.NotAWatcher
+	confWatcher   workertest.NotAWatcher
+	egressWatcher workertest.NotAWatcher
+}
+
+func newStubBackend(c *gc.C) *stubBackend {
+	return &stubBackend{
+		c:             c,
+		configAttrs:   coretesting.Attrs{},
+		hpWatcher:     workertest.NewFakeWatcher(1, 1),
+		confWatcher:   workertest.NewFakeWatcher(1, 1),
+		egressWatcher: workertest.NewFakeWatcher(1, 1),
+	}
+}
+
+func (sb *stubBackend) Kill() {
+	sb.hpWatcher.Kill()
+	sb.confWatcher.Kill()
+	sb.egressWatcher.Kill()
+}
+
+func (sb *stubBackend) ModelConfig() (*config.Config, error) {
+	sb.MethodCall(sb, "ModelConfig")
+	if err := sb.NextErr(); err != nil {
+		return nil, err
+	}
+	return coretesting.CustomModelConfig(sb.c, sb.configAttrs), nil
+}
+
+func (sb *stubBackend) APIHostPorts() ([][]network.HostPort, error) {
+	sb.MethodCall(sb, "APIHostPorts")
+	if err := sb.NextErr(); err != nil {
+		return nil, err
+	}
+	return [][]network.HostPort{
+		network.NewHostPorts(17070, "10.0.0.1"),
+		network.NewHostPorts(17070, "10.0.0.2"),
+	}, nil
+}
+
+func (sb *stubBackend) WatchAPIHostPorts() state.NotifyWatcher {
+	sb.MethodCall(sb, "WatchAPIHostPorts")
+	return sb.hpWatcher
+}
+
+func (sb *stubBackend) WatchForModelConfigChanges() state.NotifyWatcher {
+	sb.MethodCall(sb, "WatchForModelConfigChanges")
+	return sb.confWatcher
+}
+
+func (sb *stubBackend) Machine(id string) (hostfirewaller.Machine, error) {
+	sb.MethodCall(sb, "Machine", id)
+	if err := sb.NextErr(); err != nil {
+		return nil, err
+	}
+	return &stubMachine{sb}, nil
+}
+
+type stubMachine struct {
+	backend *stubBackend
+}
+
+func (m *stubMachine) EgressRules() ([]egress.Rule, error) {
+	m.backend.MethodCall(m, "EgressRules")
+	if err := m.backend.NextErr(); err != nil {
+		return nil, err
+	}
+	return []egress.Rule{
+		{CIDR: "0.0.0.0/0", Ports: "443/tcp"},
+		{CIDR: "10.0.0.0/8"},
+	}, nil
+}
+
+func (m *stubMachine) WatchEgressRules() state.NotifyWatcher {
+	m.backend.MethodCall(m, "WatchEgressRules")
+	return m.backend.egressWatcher
+}
@@ -0,0 +1,48 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+)
+
+// NewFacade wraps New to express the supplied *state.State as a Backend.
+func NewFacade(st *state.State, res facade.Resources, auth facade.Authorizer) (*Facade, error) {
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return New(&stateShim{st: st, m: m}, res, auth)
+}
+
+// stateShim forwards and adapts state.State methods to Backend.
+type stateShim struct {
+	st *state.State
+	m  *state.Model
+}
+
+func (s *stateShim) ModelConfig() (*config.Config, error) {
+	return s.m.ModelConfig()
+}
+
+func (s *stateShim) APIHostPorts() ([][]network.HostPort, error) {
+	return s.st.APIHostPorts()
+}
+
+func (s *stateShim) WatchAPIHostPorts() state.NotifyWatcher {
+	return s.st.WatchAPIHostPorts()
+}
+
+func (s *stateShim) WatchForModelConfigChanges() state.NotifyWatcher {
+	return s.m.WatchForModelConfigChanges()
+}
+
+func (s *stateShim) Machine(id string) (Machine, error) {
+	return s.st.Machine(id)
+}
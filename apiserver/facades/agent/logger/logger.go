@@ -4,6 +4,7 @@
 package logger
 
 import (
+	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
@@ -20,6 +21,13 @@ import (
 type Logger interface {
 	WatchLoggingConfig(args params.Entities) params.NotifyWatchResults
 	LoggingConfig(args params.Entities) params.StringResults
+	LoggingOverrides(args params.Entities) params.AgentLoggingOverrideResults
+}
+
+// LoggerAPIV1 implements the V1 logger API, which neither reports nor
+// watches per-agent logging overrides.
+type LoggerAPIV1 struct {
+	*LoggerAPI
 }
 
 // LoggerAPI implements the Logger interface and is the concrete
@@ -33,6 +41,19 @@ type LoggerAPI struct {
 
 var _ Logger = (*LoggerAPI)(nil)
 
+// NewLoggerAPIV1 creates a new server-side V1 logger API end point.
+func NewLoggerAPIV1(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*LoggerAPIV1, error) {
+	api, err := NewLoggerAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &LoggerAPIV1{api}, nil
+}
+
 // NewLoggerAPI creates a new server-side logger API end point.
 func NewLoggerAPI(
 	st *state.State,
@@ -55,7 +76,28 @@ func NewLoggerAPI(
 // for the agents specified..  Unfortunately the current infrastruture makes
 // watching parts of the config non-trivial, so currently any change to the
 // config will cause the watcher to notify the client.
+func (api *LoggerAPIV1) WatchLoggingConfig(arg params.Entities) params.NotifyWatchResults {
+	return api.watchLoggingConfig(arg, func(names.Tag) state.NotifyWatcher {
+		return api.model.WatchForModelConfigChanges()
+	})
+}
+
+// LoggingOverrides isn't on the V1 API.
+func (api *LoggerAPIV1) LoggingOverrides(_, _ struct{}) {}
+
+// WatchLoggingConfig starts a watcher to track changes to the logging
+// config or logging override for the agents specified. Any change to
+// the model config will cause the watcher to notify the client.
 func (api *LoggerAPI) WatchLoggingConfig(arg params.Entities) params.NotifyWatchResults {
+	return api.watchLoggingConfig(arg, func(tag names.Tag) state.NotifyWatcher {
+		return common.NewMultiNotifyWatcher(
+			api.model.WatchForModelConfigChanges(),
+			api.state.WatchAgentLoggingOverride(tag),
+		)
+	})
+}
+
+func (api *LoggerAPI) watchLoggingConfig(arg params.Entities, newWatcher func(names.Tag) state.NotifyWatcher) params.NotifyWatchResults {
 	result := make([]params.NotifyWatchResult, len(arg.Entities))
 	for i, entity := range arg.Entities {
 		tag, err := names.ParseTag(entity.Tag)
@@ -65,7 +107,7 @@ func (api *LoggerAPI) WatchLoggingConfig(arg params.Entities) params.NotifyWatch
 		}
 		err = common.ErrPerm
 		if api.authorizer.AuthOwner(tag) {
-			watch := api.model.WatchForModelConfigChanges()
+			watch := newWatcher(tag)
 			// Consume the initial event. Technically, API calls to Watch
 			// 'transmit' the initial event in the Watch response. But
 			// NotifyWatchers have no state to transmit.
@@ -107,3 +149,34 @@ func (api *LoggerAPI) LoggingConfig(arg params.Entities) params.StringResults {
 	}
 	return params.StringResults{Results: results}
 }
+
+// LoggingOverrides reports the logging override, if any, for each of
+// the agents specified. Overrides are reported whether or not they
+// have expired; it is up to the agent to stop applying them.
+func (api *LoggerAPI) LoggingOverrides(arg params.Entities) params.AgentLoggingOverrideResults {
+	results := make([]params.AgentLoggingOverrideResult, len(arg.Entities))
+	for i, entity := range arg.Entities {
+		tag, err := names.ParseTag(entity.Tag)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		if !api.authorizer.AuthOwner(tag) {
+			results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		override, err := api.state.AgentLoggingOverride(tag)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Result = &params.AgentLoggingOverride{
+			Tag:     override.Tag.String(),
+			Config:  override.Config,
+			Expires: override.Expires,
+		}
+	}
+	return params.AgentLoggingOverrideResults{Results: results}
+}
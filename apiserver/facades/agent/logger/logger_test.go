@@ -4,6 +4,8 @@
 package logger_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -144,3 +146,62 @@ func (s *loggerSuite) TestLoggingConfigForAgent(c *gc.C) {
 	c.Assert(result.Error, gc.IsNil)
 	c.Assert(result.Result, gc.Equals, newLoggingConfig)
 }
+
+func (s *loggerSuite) TestWatchLoggingConfigNotifiesOnOverride(c *gc.C) {
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.rawMachine.Tag().String()}},
+	}
+	results := s.logger.WatchLoggingConfig(args)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	w := s.resources.Get(results.Results[0].NotifyWatcherId).(state.NotifyWatcher)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertNoChange()
+
+	err := s.State.SetAgentLoggingOverride(state.AgentLoggingOverride{
+		Tag:     s.rawMachine.Tag(),
+		Config:  "juju.worker=TRACE",
+		Expires: time.Now().Add(time.Hour),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+	statetesting.AssertStop(c, w)
+	wc.AssertClosed()
+}
+
+func (s *loggerSuite) TestLoggingOverrides(c *gc.C) {
+	expires := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	err := s.State.SetAgentLoggingOverride(state.AgentLoggingOverride{
+		Tag:     s.rawMachine.Tag(),
+		Config:  "juju.worker=TRACE",
+		Expires: expires,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results := s.logger.LoggingOverrides(params.Entities{
+		Entities: []params.Entity{
+			{Tag: s.rawMachine.Tag().String()},
+			{Tag: "machine-12354"},
+		},
+	})
+	c.Assert(results, jc.DeepEquals, params.AgentLoggingOverrideResults{
+		Results: []params.AgentLoggingOverrideResult{{
+			Result: &params.AgentLoggingOverride{
+				Tag:     s.rawMachine.Tag().String(),
+				Config:  "juju.worker=TRACE",
+				Expires: expires,
+			},
+		}, {
+			Error: apiservertesting.ErrUnauthorized,
+		}},
+	})
+}
+
+func (s *loggerSuite) TestLoggingOverridesNone(c *gc.C) {
+	results := s.logger.LoggingOverrides(params.Entities{
+		Entities: []params.Entity{{Tag: s.rawMachine.Tag().String()}},
+	})
+	c.Assert(results, jc.DeepEquals, params.AgentLoggingOverrideResults{
+		Results: []params.AgentLoggingOverrideResult{{}},
+	})
+}
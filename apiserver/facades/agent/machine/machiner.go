@@ -35,6 +35,11 @@ type MachinerAPI struct {
 	getCanRead   common.GetAuthFunc
 }
 
+// MachinerAPIV1 doesn't have the ReportInterruptions method.
+type MachinerAPIV1 struct {
+	*MachinerAPI
+}
+
 // NewMachinerAPI creates a new instance of the Machiner API.
 func NewMachinerAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*MachinerAPI, error) {
 	if !authorizer.AuthMachineAgent() {
@@ -60,6 +65,15 @@ func NewMachinerAPI(st *state.State, resources facade.Resources, authorizer faca
 	}, nil
 }
 
+// NewMachinerAPIV1 creates a new instance of the V1 Machiner API.
+func NewMachinerAPIV1(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*MachinerAPIV1, error) {
+	api, err := NewMachinerAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &MachinerAPIV1{api}, nil
+}
+
 func (api *MachinerAPI) getMachine(tag names.Tag) (*state.Machine, error) {
 	entity, err := api.st.FindEntity(tag)
 	if err != nil {
@@ -135,3 +149,41 @@ func (api *MachinerAPI) Jobs(args params.Entities) (params.JobsResults, error) {
 	}
 	return result, nil
 }
+
+// ReportInterruptions records, for each given machine, that the cloud
+// will reclaim its instance at the given deadline. The machine's units
+// are moved to a replacement machine, and drained until the deadline.
+func (api *MachinerAPI) ReportInterruptions(args params.MachineInterruptions) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Interruptions)),
+	}
+	canModify, err := api.getCanModify()
+	if err != nil {
+		return results, err
+	}
+	for i, arg := range args.Interruptions {
+		tag, err := names.ParseMachineTag(arg.Tag)
+		if err != nil || !canModify(tag) {
+			results.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		m, err := api.getMachine(tag)
+		if errors.IsNotFound(err) {
+			err = common.ErrPerm
+		} else if err == nil {
+			var replacement *state.Machine
+			replacement, err = m.Interrupt(arg.Deadline)
+			if err == nil {
+				logger.Infof(
+					"machine %s will be interrupted at %s; units moved to machine %s",
+					m.Id(), arg.Deadline, replacement.Id(),
+				)
+			}
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// ReportInterruptions isn't on the V1 API.
+func (*MachinerAPIV1) ReportInterruptions(_, _ struct{}) {}
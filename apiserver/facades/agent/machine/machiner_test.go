@@ -101,6 +101,41 @@ func (s *machinerSuite) TestSetStatus(c *gc.C) {
 	c.Assert(statusInfo.Message, gc.Equals, "not really")
 }
 
+func (s *machinerSuite) TestSetStatusSince(c *gc.C) {
+	since := time.Now().Add(-time.Hour).Round(time.Second)
+	args := params.SetStatus{
+		Entities: []params.EntityStatusArgs{
+			{Tag: "machine-1", Status: status.Stopped.String(), Info: "earlier", Since: &since},
+			{Tag: "machine-1", Status: status.Error.String(), Info: "later", Since: &since},
+		}}
+	result, err := s.machiner.SetStatus(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{nil}, {nil}},
+	})
+
+	statusInfo, err := s.machine1.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusInfo.Status, gc.Equals, status.Error)
+	c.Assert(statusInfo.Message, gc.Equals, "later")
+	c.Assert(statusInfo.Since, gc.NotNil)
+	c.Assert(statusInfo.Since.Equal(since), jc.IsTrue)
+}
+
+func (s *machinerSuite) TestSetStatusInvalidData(c *gc.C) {
+	args := params.SetStatus{
+		Entities: []params.EntityStatusArgs{{
+			Tag:    "machine-1",
+			Status: status.Error.String(),
+			Info:   "not really",
+			Data:   map[string]interface{}{"": "foo"},
+		}},
+	}
+	result, err := s.machiner.SetStatus(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, "empty status data key not valid")
+}
+
 func (s *machinerSuite) TestLife(c *gc.C) {
 	err := s.machine1.EnsureDead()
 	c.Assert(err, jc.ErrorIsNil)
@@ -247,6 +282,34 @@ func (s *machinerSuite) TestJobs(c *gc.C) {
 	})
 }
 
+func (s *machinerSuite) TestReportInterruptions(c *gc.C) {
+	deadline := time.Now().Add(2 * time.Minute)
+	args := params.MachineInterruptions{Interruptions: []params.MachineInterruption{
+		{Tag: "machine-1", Deadline: deadline},
+		{Tag: "machine-0", Deadline: deadline},
+		{Tag: "machine-42", Deadline: deadline},
+	}}
+	result, err := s.machiner.ReportInterruptions(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	err = s.machine1.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	id, ok := s.machine1.Replacement()
+	c.Assert(ok, jc.IsTrue)
+	_, err = s.State.Machine(id)
+	c.Assert(err, jc.ErrorIsNil)
+	statusInfo, err := s.machine1.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusInfo.Status, gc.Equals, status.Interrupted)
+}
+
 func (s *machinerSuite) TestWatch(c *gc.C) {
 	c.Assert(s.resources.Count(), gc.Equals, 0)
 
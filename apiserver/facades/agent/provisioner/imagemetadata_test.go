@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/environs/imagemetadata"
 	imagetesting "github.com/juju/juju/environs/imagemetadata/testing"
+	"github.com/juju/juju/environs/simplestreams"
 	sstesting "github.com/juju/juju/environs/simplestreams/testing"
 	"github.com/juju/juju/juju/keys"
 	"github.com/juju/juju/state/cloudimagemetadata"
@@ -119,6 +120,43 @@ func (s *ImageMetadataSuite) TestMetadataFromState(c *gc.C) {
 	s.assertImageMetadataResults(c, result, expected...)
 }
 
+func (s *ImageMetadataSuite) TestMetadataFromStatePrefersCustom(c *gc.C) {
+	api, err := provisioner.NewProvisionerAPI(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	published := s.expectedDataSoureImageMetadata()[0]
+	custom := params.CloudImageMetadata{
+		ImageId:         "golden-image",
+		Region:          "dummy_region",
+		Version:         "12.10",
+		Series:          "quantal",
+		Arch:            "amd64",
+		VirtType:        "pv",
+		RootStorageType: "ebs",
+		Stream:          "daily",
+		Source:          "custom",
+		Priority:        simplestreams.CUSTOM_CLOUD_DATA,
+	}
+
+	// Write metadata to state, the custom metadata last.
+	all := append(append([]params.CloudImageMetadata{}, published...), custom)
+	for _, m := range s.convertCloudImageMetadata(all) {
+		err := s.State.CloudImageMetadataStorage.SaveMetadata(
+			[]cloudimagemetadata.Metadata{m},
+		)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	result, err := api.ProvisioningInfo(s.getTestMachinesTags(c))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, len(s.machines))
+	for _, one := range result.Results {
+		c.Assert(one.Error, gc.IsNil)
+		c.Assert(one.Result.ImageMetadata, gc.HasLen, len(all))
+		c.Assert(one.Result.ImageMetadata[0], jc.DeepEquals, custom)
+	}
+}
+
 func (s *ImageMetadataSuite) getTestMachinesTags(c *gc.C) params.Entities {
 
 	testMachines := make([]params.Entity, len(s.machines))
@@ -153,6 +153,21 @@ func NewProvisionerAPIV5(st *state.State, resources facade.Resources, authorizer
 	return &ProvisionerAPIV5{provisionerAPI}, nil
 }
 
+// ProvisionerAPIV6 provides v6 of the Provisioner API, which adds
+// CharmProfiles and SetCharmProfiles.
+type ProvisionerAPIV6 struct {
+	*ProvisionerAPIV5
+}
+
+// NewProvisionerAPIV6 creates a new server-side Provisioner API facade.
+func NewProvisionerAPIV6(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ProvisionerAPIV6, error) {
+	provisionerAPI, err := NewProvisionerAPIV5(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ProvisionerAPIV6{provisionerAPI}, nil
+}
+
 func (p *ProvisionerAPI) getMachine(canAccess common.AuthFunc, tag names.MachineTag) (*state.Machine, error) {
 	if !canAccess(tag) {
 		return nil, common.ErrPerm
@@ -542,6 +557,58 @@ func (p *ProvisionerAPIV5) DistributionGroupByMachineId(args params.Entities) (p
 	return result, nil
 }
 
+// CharmProfiles returns, for each given machine, the charm LXD
+// profiles its container should have and the names of those that
+// have been applied to it.
+func (p *ProvisionerAPIV6) CharmProfiles(args params.Entities) (params.CharmProfilesResults, error) {
+	result := params.CharmProfilesResults{
+		Results: make([]params.CharmProfilesResult, len(args.Entities)),
+	}
+	canAccess, err := p.getAuthFunc()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseMachineTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		machine, err := p.getMachine(canAccess, tag)
+		if err == nil {
+			result.Results[i].Profiles, err = p.machineCharmLXDProfiles(machine)
+			result.Results[i].Applied = machine.CharmProfiles()
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// SetCharmProfiles records the names of the charm LXD profiles
+// applied to each given machine's container.
+func (p *ProvisionerAPIV6) SetCharmProfiles(args params.SetCharmProfilesArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := p.getAuthFunc()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, arg := range args.Args {
+		tag, err := names.ParseMachineTag(arg.Entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		machine, err := p.getMachine(canAccess, tag)
+		if err == nil {
+			err = machine.SetCharmProfiles(arg.Profiles)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 // environManagerMachineIds returns a slice of all other environ manager machine.Ids.
 func environManagerMachineIds(st *state.State, m *state.Machine) ([]string, error) {
 	info, err := st.ControllerInfo()
@@ -629,7 +696,7 @@ func (p *ProvisionerAPI) SetInstanceInfo(args params.InstancesInfo) (params.Erro
 		devicesArgs, devicesAddrs := networkingcommon.NetworkConfigsToStateArgs(arg.NetworkConfig)
 
 		err = machine.SetInstanceInfo(
-			arg.InstanceId, arg.Nonce, arg.Characteristics,
+			arg.InstanceId, arg.DisplayName, arg.Nonce, arg.Characteristics,
 			devicesArgs, devicesAddrs,
 			volumes, volumeAttachments,
 		)
@@ -1051,12 +1118,9 @@ func (p *ProvisionerAPI) setOneInstanceStatus(canAccess common.AuthFunc, arg par
 		return err
 	}
 	// TODO(perrito666) 2016-05-02 lp:1558657
-	now := time.Now()
-	s := status.StatusInfo{
-		Status:  status.Status(arg.Status),
-		Message: arg.Info,
-		Data:    arg.Data,
-		Since:   &now,
+	s, err := common.EntityStatusInfo(arg, time.Now())
+	if err != nil {
+		return err
 	}
 
 	// TODO(jam): 2017-01-29 These two status should be set in a single
@@ -1123,6 +1123,76 @@ func (s *withoutControllerSuite) TestDistributionGroupByMachineIdMachineAgentAut
 	})
 }
 
+func (s *withoutControllerSuite) TestCharmProfiles(c *gc.C) {
+	container, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, s.machines[0].Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.AddTestingApplication(c, "lxd-profile", s.AddTestingCharm(c, "lxd-profile"))
+	for _, m := range []*state.Machine{container, s.machines[1]} {
+		unit, err := app.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		err = unit.AssignToMachine(m)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	name := "juju-" + model.Name() + "-lxd-profile-0"
+
+	provisionerV6 := provisioner.ProvisionerAPIV6{&provisioner.ProvisionerAPIV5{s.provisioner}}
+	setResult, err := provisionerV6.SetCharmProfiles(params.SetCharmProfilesArgs{
+		Args: []params.SetCharmProfilesArg{
+			{Entity: params.Entity{Tag: container.Tag().String()}, Profiles: []string{"juju-old-0"}},
+			{Entity: params.Entity{Tag: "application-lxd-profile"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(setResult, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	result, err := provisionerV6.CharmProfiles(params.Entities{Entities: []params.Entity{
+		{Tag: container.Tag().String()},
+		{Tag: s.machines[1].Tag().String()},
+		{Tag: "machine-42"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.CharmProfilesResults{
+		Results: []params.CharmProfilesResult{{
+			Profiles: map[string]params.LXDProfile{
+				name: {
+					Description: "sriov and hugepages",
+					Config: map[string]string{
+						"security.nesting":     "true",
+						"linux.kernel_modules": "openvswitch,nbd,ip_tables,ip6_tables",
+					},
+					Devices: map[string]map[string]string{
+						"sriov": {
+							"type":    "nic",
+							"nictype": "sriov",
+							"parent":  "enp3s0f0",
+						},
+						"hugepages": {
+							"type":   "disk",
+							"source": "/dev/hugepages",
+							"path":   "/dev/hugepages",
+						},
+					},
+				},
+			},
+			Applied: []string{"juju-old-0"},
+		}, {
+			// Charm profiles are only applied to LXD containers.
+		}, {
+			Error: apiservertesting.NotFoundError("machine 42"),
+		}},
+	})
+}
+
 func (s *provisionerSuite) TestConstraints(c *gc.C) {
 	// Add a machine with some constraints.
 	cons := constraints.MustParse("cores=123", "mem=8G")
@@ -1171,7 +1241,7 @@ func (s *withoutControllerSuite) TestSetInstanceInfo(c *gc.C) {
 
 	// Provision machine 0 first.
 	hwChars := instance.MustParseHardware("arch=i386", "mem=4G")
-	err = s.machines[0].SetInstanceInfo("i-am", "fake_nonce", &hwChars, nil, nil, nil, nil)
+	err = s.machines[0].SetInstanceInfo("i-am", "", "fake_nonce", &hwChars, nil, nil, nil, nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	volumesMachine, err := s.State.AddOneMachine(state.MachineTemplate{
@@ -17,10 +17,12 @@ import (
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/simplestreams"
 	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/cloudimagemetadata"
 	"github.com/juju/juju/state/multiwatcher"
@@ -96,6 +98,16 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine, env environs.Envi
 		return nil, errors.Annotate(err, "cannot get controller configuration")
 	}
 
+	cloudInitUserData, err := common.MachineCloudInitUserData(env.Config(), m.CloudInitUserData())
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get cloud-init user data")
+	}
+
+	charmLXDProfiles, err := p.machineCharmLXDProfiles(m)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get charm lxd profiles")
+	}
+
 	return &params.ProvisioningInfo{
 		Constraints:       cons,
 		Series:            m.Series(),
@@ -108,9 +120,48 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine, env environs.Envi
 		EndpointBindings:  endpointBindings,
 		ImageMetadata:     imageMetadata,
 		ControllerConfig:  controllerCfg,
+		CloudInitUserData: cloudInitUserData,
+		CharmLXDProfiles:  charmLXDProfiles,
 	}, nil
 }
 
+// machineCharmLXDProfiles returns the LXD profiles, keyed on name,
+// declared by the charms of the units assigned to the machine, if it is
+// an LXD container.
+func (p *ProvisionerAPI) machineCharmLXDProfiles(m *state.Machine) (map[string]params.LXDProfile, error) {
+	if m.ContainerType() != instance.LXD {
+		return nil, nil
+	}
+	units, err := m.Units()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var profiles map[string]params.LXDProfile
+	for _, unit := range units {
+		app, err := unit.Application()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ch, _, err := app.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		profile := ch.LXDProfile()
+		if profile == nil {
+			continue
+		}
+		if profiles == nil {
+			profiles = make(map[string]params.LXDProfile)
+		}
+		profiles[lxdprofile.Name(p.m.Name(), app.Name(), ch.Revision())] = params.LXDProfile{
+			Config:      profile.Config,
+			Description: profile.Description,
+			Devices:     profile.Devices,
+		}
+	}
+	return profiles, nil
+}
+
 // machineVolumeParams retrieves VolumeParams for the volumes that should be
 // provisioned with, and attached to, the machine. The client should ignore
 // parameters that it does not know how to handle.
@@ -395,7 +446,9 @@ func (p *ProvisionerAPI) availableImageMetadata(m *state.Machine, env environs.E
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	sort.Sort(metadataList(data))
+	// Providers use the first suitable image, so order the metadata
+	// such that custom images are preferred over published ones.
+	sort.Stable(metadataList(data))
 	logger.Debugf("available image metadata for provisioning: %v", data)
 	return data, nil
 }
@@ -577,7 +630,7 @@ func (p *ProvisionerAPI) imageMetadataFromDataSources(env environs.Environ, cons
 }
 
 // metadataList is a convenience type enabling to sort
-// a collection of CloudImageMetadata in order of priority.
+// a collection of CloudImageMetadata in descending order of priority.
 type metadataList []params.CloudImageMetadata
 
 // Implements sort.Interface
@@ -585,9 +638,10 @@ func (m metadataList) Len() int {
 	return len(m)
 }
 
-// Implements sort.Interface and sorts image metadata by priority.
+// Implements sort.Interface and sorts image metadata by priority,
+// highest first.
 func (m metadataList) Less(i, j int) bool {
-	return m[i].Priority < m[j].Priority
+	return m[i].Priority > m[j].Priority
 }
 
 // Implements sort.Interface
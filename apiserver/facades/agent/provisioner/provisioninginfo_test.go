@@ -166,6 +166,32 @@ func (s *withoutControllerSuite) TestProvisioningInfoWithSingleNegativeAndPositi
 	c.Assert(result, jc.DeepEquals, expected)
 }
 
+func (s *withoutControllerSuite) TestProvisioningInfoWithCloudInitUserData(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		"cloudinit-userdata": "packages: [htop]",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	template := state.MachineTemplate{
+		Series:            "quantal",
+		Jobs:              []state.MachineJob{state.JobHostUnits},
+		CloudInitUserData: "runcmd: [touch /tmp/done]",
+	}
+	m, err := s.State.AddOneMachine(template)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: s.machines[0].Tag().String()},
+		{Tag: m.Tag().String()},
+	}}
+	result, err := s.provisioner.ProvisioningInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Result.CloudInitUserData, gc.Equals, "packages:\n- htop\n")
+	c.Assert(result.Results[1].Error, gc.IsNil)
+	c.Assert(result.Results[1].Result.CloudInitUserData, gc.Equals, "packages:\n- htop\nruncmd:\n- touch /tmp/done\n")
+}
+
 func (s *withoutControllerSuite) addSpacesAndSubnets(c *gc.C) {
 	// Add a couple of spaces.
 	_, err := s.State.AddSpace("space1", "first space id", nil, true)
@@ -8,6 +8,13 @@ import (
 	"github.com/juju/juju/state"
 )
 
+// ProxyUpdaterAPIV1 provides version 1 of the ProxyUpdater API facade,
+// which does not support recording the outcome of applying proxy
+// settings.
+type ProxyUpdaterAPIV1 struct {
+	*ProxyUpdaterAPI
+}
+
 // NewAPI creates a new API server-side facade with a state.State backing.
 func NewAPI(st *state.State, res facade.Resources, auth facade.Authorizer) (*ProxyUpdaterAPI, error) {
 	m, err := st.Model()
@@ -16,3 +23,16 @@ func NewAPI(st *state.State, res facade.Resources, auth facade.Authorizer) (*Pro
 	}
 	return NewAPIWithBacking(&stateShim{st: st, m: m}, res, auth)
 }
+
+// NewAPIV1 creates a new version 1 API server-side facade with a
+// state.State backing.
+func NewAPIV1(st *state.State, res facade.Resources, auth facade.Authorizer) (*ProxyUpdaterAPIV1, error) {
+	api, err := NewAPI(st, res, auth)
+	if err != nil {
+		return nil, err
+	}
+	return &ProxyUpdaterAPIV1{api}, nil
+}
+
+// SetProxyStatus isn't on the V1 API.
+func (*ProxyUpdaterAPIV1) SetProxyStatus(_, _ struct{}) {}
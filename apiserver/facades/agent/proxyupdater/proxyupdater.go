@@ -4,6 +4,7 @@
 package proxyupdater
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/utils/proxy"
 	"gopkg.in/juju/names.v2"
 
@@ -23,8 +24,16 @@ type Backend interface {
 	APIHostPorts() ([][]network.HostPort, error)
 	WatchAPIHostPorts() state.NotifyWatcher
 	WatchForModelConfigChanges() state.NotifyWatcher
+	Machine(id string) (Machine, error)
 }
 
+// Machine defines the state methods this facade needs for a machine, so
+// they can be mocked for testing.
+type Machine interface {
+	SetProxyStatus(state.ProxyStatus) error
+}
+
+// ProxyUpdaterAPI provides access to the ProxyUpdater API facade.
 type ProxyUpdaterAPI struct {
 	backend    Backend
 	resources  facade.Resources
@@ -128,6 +137,8 @@ func (api *ProxyUpdaterAPI) proxyConfig() params.ProxyConfigResult {
 	proxySettings.AutoNoProxy = network.APIHostPortsToNoProxyString(apiHostPorts)
 	result.ProxySettings = proxyUtilsSettingsToProxySettingsParam(proxySettings)
 	result.APTProxySettings = proxyUtilsSettingsToProxySettingsParam(env.AptProxySettings())
+	result.SnapProxySettings = proxyUtilsSettingsToProxySettingsParam(env.SnapProxySettings())
+	result.SnapStoreProxyId = env.SnapStoreProxy()
 	return result
 }
 
@@ -152,3 +163,31 @@ func (api *ProxyUpdaterAPI) ProxyConfig(args params.Entities) params.ProxyConfig
 
 	return results
 }
+
+// SetProxyStatus records, for each of the given machines, the outcome
+// of its agent's most recent attempt to apply the proxy settings.
+func (api *ProxyUpdaterAPI) SetProxyStatus(args params.ProxyStatusArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := api.setOneProxyStatus(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *ProxyUpdaterAPI) setOneProxyStatus(arg params.ProxyStatusArg) error {
+	tag, err := names.ParseMachineTag(arg.Tag)
+	if err != nil {
+		return common.ErrPerm
+	}
+	if !api.authorizer.AuthOwner(tag) {
+		return common.ErrPerm
+	}
+	machine, err := api.backend.Machine(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return machine.SetProxyStatus(state.ProxyStatus{Error: arg.Error})
+}
@@ -168,6 +168,42 @@ func (s *ProxyUpdaterSuite) TestProxyConfigNoDuplicates(c *gc.C) {
 	})
 }
 
+func (s *ProxyUpdaterSuite) TestProxyConfigSnap(c *gc.C) {
+	s.state.SetModelConfig(coretesting.Attrs{
+		"snap-http-proxy":  "http://snap-proxy",
+		"snap-https-proxy": "https://snap-proxy",
+		"snap-store-proxy": "store-id",
+	})
+	cfg := s.facade.ProxyConfig(s.oneEntity())
+	c.Assert(cfg.Results[0].Error, gc.IsNil)
+	c.Assert(cfg.Results[0].SnapProxySettings, jc.DeepEquals, params.ProxyConfig{
+		HTTP: "http://snap-proxy", HTTPS: "https://snap-proxy",
+	})
+	c.Assert(cfg.Results[0].SnapStoreProxyId, gc.Equals, "store-id")
+}
+
+func (s *ProxyUpdaterSuite) TestSetProxyStatus(c *gc.C) {
+	results, err := s.facade.SetProxyStatus(params.ProxyStatusArgs{
+		Args: []params.ProxyStatusArg{
+			{Tag: s.tag.String(), Error: "cannot write apt proxy"},
+			{Tag: names.NewMachineTag("2").String()},
+			{Tag: names.NewUnitTag("foo/0").String()},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized}},
+			{Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized}},
+		},
+	})
+	s.state.Stub.CheckCalls(c, []testing.StubCall{
+		{"Machine", []interface{}{"1"}},
+		{"SetProxyStatus", []interface{}{state.ProxyStatus{Error: "cannot write apt proxy"}}},
+	})
+}
+
 type stubBackend struct {
 	*testing.Stub
 
@@ -230,3 +266,20 @@ func (sb *stubBackend) WatchForModelConfigChanges() state.NotifyWatcher {
 	sb.MethodCall(sb, "WatchForModelConfigChanges")
 	return sb.confWatcher
 }
+
+func (sb *stubBackend) Machine(id string) (proxyupdater.Machine, error) {
+	sb.MethodCall(sb, "Machine", id)
+	if err := sb.NextErr(); err != nil {
+		return nil, err
+	}
+	return &stubMachine{sb.Stub}, nil
+}
+
+type stubMachine struct {
+	*testing.Stub
+}
+
+func (m *stubMachine) SetProxyStatus(proxyStatus state.ProxyStatus) error {
+	m.MethodCall(m, "SetProxyStatus", proxyStatus)
+	return m.NextErr()
+}
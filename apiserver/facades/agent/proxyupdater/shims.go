@@ -32,3 +32,7 @@ func (s *stateShim) WatchAPIHostPorts() state.NotifyWatcher {
 func (s *stateShim) WatchForModelConfigChanges() state.NotifyWatcher {
 	return s.m.WatchForModelConfigChanges()
 }
+
+func (s *stateShim) Machine(id string) (Machine, error) {
+	return s.st.Machine(id)
+}
@@ -83,3 +83,46 @@ func (r *RebootAPI) WatchForRebootEvent() (params.NotifyWatchResult, error) {
 	result.Error = common.ServerError(err)
 	return result, nil
 }
+
+// GetRebootAction returns the action a machine agent should take, as
+// common.RebootActionGetter does, except that if the model defers
+// reboots, a machine that is not a controller is told to wait, rather
+// than reboot or shut down, while the model is not in a maintenance
+// window.
+func (r *RebootAPI) GetRebootAction(args params.Entities) (params.RebootActionResults, error) {
+	results, err := r.RebootActionGetter.GetRebootAction(args)
+	if err != nil {
+		return params.RebootActionResults{}, errors.Trace(err)
+	}
+	if r.machine.IsManager() {
+		return results, nil
+	}
+	model, err := r.st.Model()
+	if err != nil {
+		return params.RebootActionResults{}, errors.Trace(err)
+	}
+	cfg, err := model.ModelConfig()
+	if err != nil {
+		return params.RebootActionResults{}, errors.Trace(err)
+	}
+	if !cfg.DeferReboots() {
+		return results, nil
+	}
+	for i, result := range results.Results {
+		if result.Error != nil {
+			continue
+		}
+		if result.Result != params.ShouldReboot && result.Result != params.ShouldShutdown {
+			continue
+		}
+		open, err := r.st.InMaintenanceWindow()
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if !open {
+			results.Results[i].Result = params.ShouldWait
+		}
+	}
+	return results, nil
+}
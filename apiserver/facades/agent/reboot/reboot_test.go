@@ -5,6 +5,9 @@
 package reboot_test
 
 import (
+	"fmt"
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -12,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/reboot"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/maintenance"
 	"github.com/juju/juju/instance"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
@@ -336,3 +340,78 @@ func (s *rebootSuite) TestRebootRequestFromNestedContainer(c *gc.C) {
 	s.container.wc.AssertNoChange()
 	s.nestedContainer.wc.AssertOneChange()
 }
+
+func (s *rebootSuite) setLaterMaintenanceWindow(c *gc.C) {
+	// A window that opens in two hours is not open now.
+	opens := time.Now().UTC().Add(2 * time.Hour)
+	err := s.State.SetMaintenanceWindow(maintenance.Window{
+		Name:     "later",
+		Schedule: fmt.Sprintf("%d %d * * *", opens.Minute(), opens.Hour()),
+		Duration: time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *rebootSuite) TestGetRebootActionNotDeferredByDefault(c *gc.C) {
+	s.setLaterMaintenanceWindow(c)
+
+	_, err := s.machine.rebootAPI.RequestReboot(s.machine.args)
+	c.Assert(err, jc.ErrorIsNil)
+	s.machine.wc.AssertOneChange()
+	s.container.wc.AssertOneChange()
+	s.nestedContainer.wc.AssertOneChange()
+
+	res, err := s.machine.rebootAPI.GetRebootAction(s.machine.args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res, gc.DeepEquals, params.RebootActionResults{
+		Results: []params.RebootActionResult{
+			{Result: params.ShouldReboot},
+		}})
+
+	_, err = s.machine.rebootAPI.ClearReboot(s.machine.args)
+	c.Assert(err, jc.ErrorIsNil)
+	s.machine.wc.AssertOneChange()
+	s.container.wc.AssertOneChange()
+	s.nestedContainer.wc.AssertOneChange()
+}
+
+func (s *rebootSuite) TestGetRebootActionWaitsOutsideMaintenanceWindow(c *gc.C) {
+	err := s.IAASModel.UpdateModelConfig(map[string]interface{}{"defer-reboots": true}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.setLaterMaintenanceWindow(c)
+
+	_, err = s.machine.rebootAPI.RequestReboot(s.machine.args)
+	c.Assert(err, jc.ErrorIsNil)
+	s.machine.wc.AssertOneChange()
+	s.container.wc.AssertOneChange()
+	s.nestedContainer.wc.AssertOneChange()
+
+	res, err := s.machine.rebootAPI.GetRebootAction(s.machine.args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res, gc.DeepEquals, params.RebootActionResults{
+		Results: []params.RebootActionResult{
+			{Result: params.ShouldWait},
+		}})
+	res, err = s.container.rebootAPI.GetRebootAction(s.container.args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res, gc.DeepEquals, params.RebootActionResults{
+		Results: []params.RebootActionResult{
+			{Result: params.ShouldWait},
+		}})
+
+	// Once the window is removed, the machine reboots.
+	err = s.State.RemoveMaintenanceWindow("later")
+	c.Assert(err, jc.ErrorIsNil)
+	res, err = s.machine.rebootAPI.GetRebootAction(s.machine.args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res, gc.DeepEquals, params.RebootActionResults{
+		Results: []params.RebootActionResult{
+			{Result: params.ShouldReboot},
+		}})
+
+	_, err = s.machine.rebootAPI.ClearReboot(s.machine.args)
+	c.Assert(err, jc.ErrorIsNil)
+	s.machine.wc.AssertOneChange()
+	s.container.wc.AssertOneChange()
+	s.nestedContainer.wc.AssertOneChange()
+}
@@ -0,0 +1,100 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/watcher"
+)
+
+// SetRelationsDeparted records, for each given relation/unit pair,
+// that the unit has run the relation-departed hooks for all of its
+// counterparts and is ready to run relation-broken. See also
+// state.RelationUnit.SetDeparted().
+func (u *UniterAPI) SetRelationsDeparted(args params.RelationUnits) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.RelationUnits)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.RelationUnits {
+		unit, err := names.ParseUnitTag(arg.Unit)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		relUnit, err := u.getRelationUnit(canAccess, arg.Relation, unit)
+		if err == nil {
+			err = relUnit.SetDeparted()
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// RelationBrokenBarriers reports, for each given relation/unit pair,
+// whether the unit may run the relation-broken hook without waiting
+// for other units of its application to depart the relation. See also
+// state.Relation.BrokenBarrierReached().
+func (u *UniterAPI) RelationBrokenBarriers(args params.RelationUnits) (params.BoolResults, error) {
+	result := params.BoolResults{
+		Results: make([]params.BoolResult, len(args.RelationUnits)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.BoolResults{}, err
+	}
+	for i, arg := range args.RelationUnits {
+		unitTag, err := names.ParseUnitTag(arg.Unit)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		rel, unit, err := u.getRelationAndUnit(canAccess, arg.Relation, unitTag)
+		if err == nil {
+			result.Results[i].Result, err = rel.BrokenBarrierReached(unit.ApplicationName())
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// WatchRelationBrokenBarriers returns a NotifyWatcher for each given
+// relation/unit pair, notifying of changes that may affect whether the
+// unit's relation-broken barrier has been reached. See also
+// state.Relation.WatchBrokenBarrier().
+func (u *UniterAPI) WatchRelationBrokenBarriers(args params.RelationUnits) (params.NotifyWatchResults, error) {
+	result := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.RelationUnits)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.NotifyWatchResults{}, err
+	}
+	for i, arg := range args.RelationUnits {
+		unitTag, err := names.ParseUnitTag(arg.Unit)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		rel, unit, err := u.getRelationAndUnit(canAccess, arg.Relation, unitTag)
+		if err == nil {
+			watch := rel.WatchBrokenBarrier(unit.ApplicationName())
+			// Consume the initial event; NotifyWatchers have
+			// no state to transmit in the Watch response.
+			if _, ok := <-watch.Changes(); ok {
+				result.Results[i].NotifyWatcherId = u.resources.Register(watch)
+			} else {
+				err = watcher.EnsureErr(watch)
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/state/stateenvirons"
+)
+
+// scopedCredentialDuration is how long the credentials minted for
+// charms remain valid. Charms are expected to ask for a new credential
+// whenever they need one, rather than keep it.
+const scopedCredentialDuration = time.Hour
+
+// CloudCredential returns, for each given unit, a short-lived cloud
+// credential scoped to the trust level of the unit's application. The
+// model's own credential is never returned; providers that cannot
+// mint scoped credentials return a NotSupported error.
+func (u *UniterAPI) CloudCredential(args params.Entities) (params.ScopedCredentialResults, error) {
+	result := params.ScopedCredentialResults{
+		Results: make([]params.ScopedCredentialResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ScopedCredentialResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil || !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		credential, err := u.cloudCredential(tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = credential
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) cloudCredential(tag names.UnitTag) (*params.ScopedCredential, error) {
+	unit, err := u.st.Unit(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := unit.Application()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	level := app.TrustLevel()
+	if !level.Trusted() {
+		return nil, errors.Unauthorizedf("application %q is not trusted", app.Name())
+	}
+	env, err := stateenvirons.GetNewEnvironFunc(environs.New)(u.st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	minter, ok := environs.SupportsCredentialMinting(env)
+	if !ok {
+		return nil, errors.NotSupportedf("scoped credentials on this cloud")
+	}
+	credential, err := minter.MintCredential(environs.MintCredentialParams{
+		Application: app.Name(),
+		Level:       level,
+		Duration:    scopedCredentialDuration,
+	})
+	if err != nil {
+		return nil, errors.Annotatef(err, "minting credential for application %q", app.Name())
+	}
+	return &params.ScopedCredential{
+		AuthType:   string(credential.AuthType),
+		Attributes: credential.Attributes,
+		Expiry:     credential.Expiry,
+	}, nil
+}
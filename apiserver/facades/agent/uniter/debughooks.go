@@ -0,0 +1,54 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// DebugHooksSession reports, for each given unit, whether a client has
+// opened a debug-hooks session for it through this API server, and if
+// so which hooks the session intercepts. A unit with a session attaches
+// to it, over the debug-hooks stream, instead of running an intercepted
+// hook itself.
+//
+// Sessions opened through other API servers are not reported.
+func (u *UniterAPI) DebugHooksSession(args params.Entities) (params.DebugHooksSessionResults, error) {
+	result := params.DebugHooksSessionResults{
+		Results: make([]params.DebugHooksSessionResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.DebugHooksSessionResults{}, err
+	}
+	sessions := u.debugHooksSessions()
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil || !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if sessions == nil {
+			continue
+		}
+		hooks, ok := sessions.Session(u.st.ModelUUID(), tag.Id())
+		result.Results[i].Active = ok
+		result.Results[i].Hooks = hooks
+	}
+	return result, nil
+}
+
+// debugHooksSessions returns the debug-hooks sessions of the API server
+// hosting the facade, or nil if it does not broker any.
+func (u *UniterAPI) debugHooksSessions() common.DebugHooksSessions {
+	resource, ok := u.resources.Get("debugHooksSessions").(common.ValueResource)
+	if !ok {
+		return nil
+	}
+	sessions, _ := resource.Value.(common.DebugHooksSessions)
+	return sessions
+}
@@ -8,6 +8,7 @@ package uniter
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -21,6 +22,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/meterstatus"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/core/relation"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
@@ -31,7 +33,7 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.uniter")
 
-// UniterAPI implements the latest version (v7) of the Uniter API.
+// UniterAPI implements the latest version (v12) of the Uniter API.
 type UniterAPI struct {
 	*common.LifeGetter
 	*StatusAPI
@@ -53,9 +55,36 @@ type UniterAPI struct {
 	StorageAPI
 }
 
+// UniterAPIV11 doesn't have the CloudCredential method.
+type UniterAPIV11 struct {
+	UniterAPI
+}
+
+// UniterAPIV10 doesn't have the ReadSettingsBatch or
+// WriteSettingsBatch methods.
+type UniterAPIV10 struct {
+	UniterAPIV11
+}
+
+// UniterAPIV9 doesn't have the SetRelationsDeparted,
+// RelationBrokenBarriers or WatchRelationBrokenBarriers methods.
+type UniterAPIV9 struct {
+	UniterAPIV10
+}
+
+// UniterAPIV8 doesn't have the DebugHooksSession method.
+type UniterAPIV8 struct {
+	UniterAPIV9
+}
+
+// UniterAPIV7 doesn't have the UniterState or SetUniterState methods.
+type UniterAPIV7 struct {
+	UniterAPIV8
+}
+
 // UniterAPIV6 adds NetworkInfo as a preferred method to calling NetworkConfig.
 type UniterAPIV6 struct {
-	UniterAPI
+	UniterAPIV7
 }
 
 // UniterAPIV5 returns a RelationResultsV5 instead of RelationResults
@@ -170,14 +199,69 @@ func NewUniterAPI(st *state.State, resources facade.Resources, authorizer facade
 	}, nil
 }
 
+// NewUniterAPIV11 creates an instance of the V11 uniter API.
+func NewUniterAPIV11(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV11, error) {
+	uniterAPI, err := NewUniterAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV11{
+		UniterAPI: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV10 creates an instance of the V10 uniter API.
+func NewUniterAPIV10(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV10, error) {
+	uniterAPI, err := NewUniterAPIV11(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV10{
+		UniterAPIV11: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV9 creates an instance of the V9 uniter API.
+func NewUniterAPIV9(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV9, error) {
+	uniterAPI, err := NewUniterAPIV10(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV9{
+		UniterAPIV10: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV8 creates an instance of the V8 uniter API.
+func NewUniterAPIV8(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV8, error) {
+	uniterAPI, err := NewUniterAPIV9(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV8{
+		UniterAPIV9: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV7 creates an instance of the V7 uniter API.
+func NewUniterAPIV7(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV7, error) {
+	uniterAPI, err := NewUniterAPIV8(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV7{
+		UniterAPIV8: *uniterAPI,
+	}, nil
+}
+
 // NewUniterAPIV6 creates an instance of the V6 uniter API.
 func NewUniterAPIV6(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV6, error) {
-	uniterAPI, err := NewUniterAPI(st, resources, authorizer)
+	uniterAPI, err := NewUniterAPIV7(st, resources, authorizer)
 	if err != nil {
 		return nil, err
 	}
 	return &UniterAPIV6{
-		UniterAPI: *uniterAPI,
+		UniterAPIV7: *uniterAPI,
 	}, nil
 }
 
@@ -322,6 +406,13 @@ func (u *UniterAPI) PublicAddress(args params.Entities) (params.StringResults, e
 				address, err = unit.PublicAddress()
 				if err == nil {
 					result.Results[i].Result = address.Value
+					// Prefer the name registered in the provider's
+					// DNS, which survives address changes.
+					var fqdn string
+					fqdn, err = unit.PublicFQDN()
+					if err == nil && fqdn != "" {
+						result.Results[i].Result = fqdn
+					}
 				} else if network.IsNoAddressError(err) {
 					err = common.NoAddressSetError(tag, "public")
 				}
@@ -1145,6 +1236,9 @@ func (u *UniterAPI) Refresh(args params.Entities) (params.UnitRefreshResults, er
 				result.Results[i].Series = unit.Series()
 				result.Results[i].Life = params.Life(unit.Life().String())
 				result.Results[i].Resolved = params.ResolvedMode(unit.Resolved())
+				if deadline, ok := unit.DrainDeadline(); ok {
+					result.Results[i].DrainDeadline = &deadline
+				}
 			}
 		}
 		result.Results[i].Error = common.ServerError(err)
@@ -1330,7 +1424,7 @@ func (u *UniterAPI) ReadRemoteSettings(args params.RelationUnitPairs) (params.Se
 			remoteUnit, err = u.checkRemoteUnit(relUnit, arg.RemoteUnit)
 			if err == nil {
 				var settings map[string]interface{}
-				settings, err = relUnit.ReadSettings(remoteUnit)
+				settings, err = u.readVisibleSettings(relUnit, remoteUnit)
 				if err == nil {
 					result.Results[i].Settings, err = convertRelationSettings(settings)
 				}
@@ -1341,9 +1435,50 @@ func (u *UniterAPI) ReadRemoteSettings(args params.RelationUnitPairs) (params.Se
 	return result, nil
 }
 
+// ReadSettingsBatch returns the settings of each of the remote units
+// in each given batch, so that a unit can read the settings of all of
+// its counterparts in a relation with a single call.
+func (u *UniterAPI) ReadSettingsBatch(args params.RelationSettingsBatches) (params.RelationSettingsBatchResults, error) {
+	result := params.RelationSettingsBatchResults{
+		Results: make([]params.RelationSettingsBatchResult, len(args.Batches)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.RelationSettingsBatchResults{}, err
+	}
+	for i, arg := range args.Batches {
+		unit, err := names.ParseUnitTag(arg.LocalUnit)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		relUnit, err := u.getRelationUnit(canAccess, arg.Relation, unit)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results := make([]params.SettingsResult, len(arg.RemoteUnits))
+		for j, remoteUnitTag := range arg.RemoteUnits {
+			remoteUnit, err := u.checkRemoteUnit(relUnit, remoteUnitTag)
+			if err == nil {
+				var settings map[string]interface{}
+				settings, err = u.readVisibleSettings(relUnit, remoteUnit)
+				if err == nil {
+					results[j].Settings, err = convertRelationSettings(settings)
+				}
+			}
+			results[j].Error = common.ServerError(err)
+		}
+		result.Results[i].Results = results
+	}
+	return result, nil
+}
+
 // UpdateSettings persists all changes made to the local settings of
 // all given pairs of relation and unit. Keys with empty values are
-// considered a signal to delete these values.
+// considered a signal to delete these values. Changes that would make
+// a unit's settings larger than the model's max-relation-data-size are
+// rejected.
 func (u *UniterAPI) UpdateSettings(args params.RelationUnitsSettings) (params.ErrorResults, error) {
 	result := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.RelationUnits)),
@@ -1352,35 +1487,98 @@ func (u *UniterAPI) UpdateSettings(args params.RelationUnitsSettings) (params.Er
 	if err != nil {
 		return params.ErrorResults{}, err
 	}
+	cfg, err := u.m.ModelConfig()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	maxSizeMB := cfg.MaxRelationDataSizeMB()
 	for i, arg := range args.RelationUnits {
-		unit, err := names.ParseUnitTag(arg.Unit)
+		settings, err := u.updatedRelationSettings(canAccess, arg, maxSizeMB)
+		if err == nil {
+			_, err = settings.Write()
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// WriteSettingsBatch persists the changes made to the local settings
+// of all given pairs of relation and unit, as UpdateSettings does,
+// except that nothing is written unless all of the changes are valid.
+// This ensures that a hook's changes to its relations are not partly
+// applied when some of them are rejected.
+func (u *UniterAPI) WriteSettingsBatch(args params.RelationUnitsSettings) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.RelationUnits)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	cfg, err := u.m.ModelConfig()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	maxSizeMB := cfg.MaxRelationDataSizeMB()
+	updated := make([]*state.Settings, len(args.RelationUnits))
+	rejected := false
+	for i, arg := range args.RelationUnits {
+		updated[i], err = u.updatedRelationSettings(canAccess, arg, maxSizeMB)
 		if err != nil {
-			result.Results[i].Error = common.ServerError(common.ErrPerm)
-			continue
+			result.Results[i].Error = common.ServerError(err)
+			rejected = true
 		}
-		relUnit, err := u.getRelationUnit(canAccess, arg.Relation, unit)
-		if err == nil {
-			var settings *state.Settings
-			settings, err = relUnit.Settings()
-			if err == nil {
-				for k, v := range arg.Settings {
-					if v == "" {
-						settings.Delete(k)
-					} else {
-						settings.Set(k, v)
-					}
-				}
-				_, err = settings.Write()
+	}
+	for i, settings := range updated {
+		if rejected {
+			if result.Results[i].Error == nil {
+				err := errors.New("settings not written: other changes in batch rejected")
+				result.Results[i].Error = common.ServerError(err)
 			}
+			continue
 		}
+		_, err := settings.Write()
 		result.Results[i].Error = common.ServerError(err)
 	}
 	return result, nil
 }
 
+// updatedRelationSettings returns the local settings of the given
+// relation and unit with the given changes applied but not yet
+// written, or an error if the changes may not be made.
+func (u *UniterAPI) updatedRelationSettings(
+	canAccess common.AuthFunc, arg params.RelationUnitSettings, maxSizeMB uint,
+) (*state.Settings, error) {
+	unit, err := names.ParseUnitTag(arg.Unit)
+	if err != nil {
+		return nil, common.ErrPerm
+	}
+	relUnit, err := u.getRelationUnit(canAccess, arg.Relation, unit)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := relUnit.Settings()
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range arg.Settings {
+		if v == "" {
+			settings.Delete(k)
+		} else {
+			settings.Set(k, v)
+		}
+	}
+	if err := checkRelationSettingsSize(settings.Map(), maxSizeMB); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
 // WatchRelationUnits returns a RelationUnitsWatcher for observing
 // changes to every unit in the supplied relation that is visible to
 // the supplied unit. See also state/watcher.go:RelationUnit.Watch().
+// If the model's relation-changed-debounce is set, changes after the
+// initial event are notified no more often than it allows.
 func (u *UniterAPI) WatchRelationUnits(args params.RelationUnits) (params.RelationUnitsWatchResults, error) {
 	result := params.RelationUnitsWatchResults{
 		Results: make([]params.RelationUnitsWatchResult, len(args.RelationUnits)),
@@ -1389,6 +1587,11 @@ func (u *UniterAPI) WatchRelationUnits(args params.RelationUnits) (params.Relati
 	if err != nil {
 		return params.RelationUnitsWatchResults{}, err
 	}
+	cfg, err := u.m.ModelConfig()
+	if err != nil {
+		return params.RelationUnitsWatchResults{}, err
+	}
+	debounce := cfg.RelationChangedDebounce()
 	for i, arg := range args.RelationUnits {
 		unit, err := names.ParseUnitTag(arg.Unit)
 		if err != nil {
@@ -1397,7 +1600,7 @@ func (u *UniterAPI) WatchRelationUnits(args params.RelationUnits) (params.Relati
 		}
 		relUnit, err := u.getRelationUnit(canAccess, arg.Relation, unit)
 		if err == nil {
-			result.Results[i], err = u.watchOneRelationUnit(relUnit)
+			result.Results[i], err = u.watchOneRelationUnit(relUnit, debounce)
 		}
 		result.Results[i].Error = common.ServerError(err)
 	}
@@ -1416,18 +1619,18 @@ func (u *UniterAPI) SetRelationStatus(args params.RelationStatusArgs) (params.Er
 		return statusResults, err
 	}
 
-	changeOne := func(arg params.RelationStatusArg) error {
+	prepareOne := func(arg params.RelationStatusArg) (state.StatusUpdate, error) {
 		rel, err := u.st.Relation(arg.RelationId)
 		if errors.IsNotFound(err) {
-			return common.ErrPerm
+			return state.StatusUpdate{}, common.ErrPerm
 		} else if err != nil {
-			return errors.Trace(err)
+			return state.StatusUpdate{}, errors.Trace(err)
 		}
 		_, err = rel.Unit(u.unit)
 		if errors.IsNotFound(err) {
-			return common.ErrPerm
+			return state.StatusUpdate{}, common.ErrPerm
 		} else if err != nil {
-			return errors.Trace(err)
+			return state.StatusUpdate{}, errors.Trace(err)
 		}
 		// If we are transitioning from "suspending" to "suspended",
 		// we retain any existing message so that if the user has
@@ -1436,21 +1639,49 @@ func (u *UniterAPI) SetRelationStatus(args params.RelationStatusArgs) (params.Er
 		if message == "" && arg.Status == params.Suspended {
 			current, err := rel.Status()
 			if err != nil {
-				return errors.Trace(err)
+				return state.StatusUpdate{}, errors.Trace(err)
 			}
 			if current.Status == status.Suspending {
 				message = current.Message
 			}
 		}
-		return rel.SetStatus(status.StatusInfo{
-			Status:  status.Status(arg.Status),
-			Message: message,
-		})
+		// TODO(perrito666) 2016-05-02 lp:1558657
+		sInfo, err := common.EntityStatusInfo(params.EntityStatusArgs{
+			Status: string(arg.Status),
+			Info:   message,
+		}, time.Now())
+		if err != nil {
+			return state.StatusUpdate{}, errors.Trace(err)
+		}
+		return state.StatusUpdate{Entity: rel, Info: sInfo}, nil
+	}
+	// Where a relation is given more than once, only its last status
+	// is set, and the earlier arguments share its result. The statuses
+	// are all written in a single transaction.
+	last := make(map[int]int)
+	for i, arg := range args.Args {
+		last[arg.RelationId] = i
 	}
 	results := make([]params.ErrorResult, len(args.Args))
+	var updates []state.StatusUpdate
+	var indices []int
 	for i, arg := range args.Args {
-		err := changeOne(arg)
-		results[i].Error = common.ServerError(err)
+		if last[arg.RelationId] != i {
+			continue
+		}
+		update, err := prepareOne(arg)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		updates = append(updates, update)
+		indices = append(indices, i)
+	}
+	for j, err := range state.SetStatuses(updates) {
+		results[indices[j]].Error = common.ServerError(err)
+	}
+	for i, arg := range args.Args {
+		results[i] = results[last[arg.RelationId]]
 	}
 	statusResults.Results = results
 	return statusResults, nil
@@ -1647,8 +1878,15 @@ func (u *UniterAPI) watchOneUnitAddresses(tag names.UnitTag) (string, error) {
 	return "", watcher.EnsureErr(watch)
 }
 
-func (u *UniterAPI) watchOneRelationUnit(relUnit *state.RelationUnit) (params.RelationUnitsWatchResult, error) {
-	watch := relUnit.Watch()
+func (u *UniterAPI) watchOneRelationUnit(
+	relUnit *state.RelationUnit, debounce time.Duration,
+) (params.RelationUnitsWatchResult, error) {
+	var watch state.RelationUnitsWatcher
+	if debounce > 0 {
+		watch = relUnit.WatchDebounced(debounce)
+	} else {
+		watch = relUnit.Watch()
+	}
 	// Consume the initial event and forward it to the result.
 	if changes, ok := <-watch.Changes(); ok {
 		return params.RelationUnitsWatchResult{
@@ -1685,6 +1923,47 @@ func (u *UniterAPI) checkRemoteUnit(relUnit *state.RelationUnit, remoteUnitTag s
 	return remoteUnitName, nil
 }
 
+// readVisibleSettings returns the settings of the named remote unit in
+// the relation, without the keys that the remote unit's charm hides
+// from the authenticated unit.
+func (u *UniterAPI) readVisibleSettings(relUnit *state.RelationUnit, remoteUnitName string) (map[string]interface{}, error) {
+	settings, err := relUnit.ReadSettings(remoteUnitName)
+	if err != nil {
+		return nil, err
+	}
+	remoteApplicationName, err := names.UnitApplication(remoteUnitName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Applications offered by other models, and applications that
+	// have since been removed, have no charm from which to read rules.
+	remoteApplication, err := u.st.Application(remoteApplicationName)
+	if errors.IsNotFound(err) {
+		return settings, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ch, _, err := remoteApplication.Charm()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	rules := ch.RelationDataVisibility()
+	if len(rules) == 0 {
+		return settings, nil
+	}
+	endpoint, err := relUnit.Relation().Endpoint(remoteApplicationName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	checker := u.st.LeadershipChecker()
+	token := checker.LeadershipCheck(u.unit.ApplicationName(), u.unit.Name())
+	reader := relation.DataReader{
+		SameApplication: remoteApplicationName == u.unit.ApplicationName(),
+		Leader:          token.Check(nil) == nil,
+	}
+	return relation.FilterSettings(rules, endpoint.Name, settings, reader), nil
+}
+
 func convertRelationSettings(settings map[string]interface{}) (params.Settings, error) {
 	result := make(params.Settings)
 	for k, v := range settings {
@@ -1698,6 +1977,14 @@ func convertRelationSettings(settings map[string]interface{}) (params.Settings,
 	return result, nil
 }
 
+func checkRelationSettingsSize(settings map[string]interface{}, maxSizeMB uint) error {
+	converted, err := convertRelationSettings(settings)
+	if err != nil {
+		return err
+	}
+	return relation.CheckSettingsSize(converted, maxSizeMB)
+}
+
 func relationsInScopeTags(unit *state.Unit) ([]string, error) {
 	relations, err := unit.RelationsInScope()
 	if err != nil {
@@ -2189,3 +2476,30 @@ func (u *UniterAPIV4) NetworkInfo(_, _ struct{}) {}
 
 // WatchUnitRelations isn't on the V4 API.
 func (u *UniterAPIV4) WatchUnitRelations(_, _ struct{}) {}
+
+// UniterState isn't on the V7 API.
+func (u *UniterAPIV7) UniterState(_, _ struct{}) {}
+
+// SetUniterState isn't on the V7 API.
+func (u *UniterAPIV7) SetUniterState(_, _ struct{}) {}
+
+// DebugHooksSession isn't on the V8 API.
+func (u *UniterAPIV8) DebugHooksSession(_, _ struct{}) {}
+
+// SetRelationsDeparted isn't on the V9 API.
+func (u *UniterAPIV9) SetRelationsDeparted(_, _ struct{}) {}
+
+// RelationBrokenBarriers isn't on the V9 API.
+func (u *UniterAPIV9) RelationBrokenBarriers(_, _ struct{}) {}
+
+// WatchRelationBrokenBarriers isn't on the V9 API.
+func (u *UniterAPIV9) WatchRelationBrokenBarriers(_, _ struct{}) {}
+
+// ReadSettingsBatch isn't on the V10 API.
+func (u *UniterAPIV10) ReadSettingsBatch(_, _ struct{}) {}
+
+// WriteSettingsBatch isn't on the V10 API.
+func (u *UniterAPIV10) WriteSettingsBatch(_, _ struct{}) {}
+
+// CloudCredential isn't on the V11 API.
+func (u *UniterAPIV11) CloudCredential(_, _ struct{}) {}
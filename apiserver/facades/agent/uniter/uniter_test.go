@@ -5,6 +5,7 @@ package uniter_test
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
@@ -20,6 +21,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/uniter"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/trust"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/network"
@@ -467,6 +469,19 @@ func (s *uniterSuite) TestPublicAddress(c *gc.C) {
 			{Error: apiservertesting.ErrUnauthorized},
 		},
 	})
+
+	// Once registered in the provider's DNS, the name is preferred.
+	err = s.machine0.SetFQDN("machine-0.example.com")
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.uniter.PublicAddress(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Result: "machine-0.example.com"},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
 }
 
 func (s *uniterSuite) TestPrivateAddress(c *gc.C) {
@@ -615,6 +630,133 @@ func (s *uniterSuite) TestClearResolved(c *gc.C) {
 	c.Assert(mode, gc.Equals, state.ResolvedNone)
 }
 
+func (s *uniterSuite) TestSetUniterState(c *gc.C) {
+	uniterState := params.UniterState{
+		OperationState: "op: continue\nopstep: pending\n",
+		RelationState:  map[string]string{"0/mysql-0": "change-version: 1\n"},
+	}
+	args := params.SetUniterStateArgs{Args: []params.SetUniterStateArg{
+		{Tag: "unit-mysql-0", State: uniterState},
+		{Tag: "unit-wordpress-0", State: uniterState},
+		{Tag: "unit-foo-42", State: uniterState},
+	}}
+	result, err := s.uniter.SetUniterState(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	stored, err := s.wordpressUnit.UniterState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stored, jc.DeepEquals, state.UniterState{
+		OperationState: uniterState.OperationState,
+		RelationState:  uniterState.RelationState,
+	})
+}
+
+func (s *uniterSuite) TestUniterState(c *gc.C) {
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-foo-42"},
+	}}
+	result, err := s.uniter.UniterState(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+
+	err = s.wordpressUnit.SetUniterState(state.UniterState{
+		OperationState: "op: continue\nopstep: done\n",
+		StorageState:   map[string]string{"data-0": "attached: true\n"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.uniter.UniterState(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.UniterStateResults{
+		Results: []params.UniterStateResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Result: &params.UniterState{
+				OperationState: "op: continue\nopstep: done\n",
+				StorageState:   map[string]string{"data-0": "attached: true\n"},
+			}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+type fakeDebugHooksSessions map[string][]string
+
+func (f fakeDebugHooksSessions) Session(modelUUID, unitName string) ([]string, bool) {
+	hooks, ok := f[modelUUID+":"+unitName]
+	return hooks, ok
+}
+
+func (s *uniterSuite) TestDebugHooksSession(c *gc.C) {
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-foo-42"},
+	}}
+	result, err := s.uniter.DebugHooksSession(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.DebugHooksSessionResults{
+		Results: []params.DebugHooksSessionResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	err = s.resources.RegisterNamed("debugHooksSessions", common.ValueResource{
+		fakeDebugHooksSessions{
+			s.State.ModelUUID() + ":wordpress/0": {"install", "start"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.uniter.DebugHooksSession(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[1], jc.DeepEquals, params.DebugHooksSessionResult{
+		Active: true,
+		Hooks:  []string{"install", "start"},
+	})
+}
+
+func (s *uniterSuite) TestCloudCredential(c *gc.C) {
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-foo-42"},
+	}}
+	result, err := s.uniter.CloudCredential(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ScopedCredentialResults{
+		Results: []params.ScopedCredentialResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: &params.Error{
+				Code:    params.CodeUnauthorized,
+				Message: `application "wordpress" is not trusted`,
+			}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// The dummy provider cannot mint scoped credentials.
+	err = s.wordpress.SetTrustLevel(trust.ReadOnly)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.uniter.CloudCredential(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[1], jc.DeepEquals, params.ScopedCredentialResult{
+		Error: &params.Error{
+			Code:    params.CodeNotSupported,
+			Message: "scoped credentials on this cloud not supported",
+		},
+	})
+}
+
 func (s *uniterSuite) TestGetPrincipal(c *gc.C) {
 	// Add a subordinate to wordpressUnit.
 	_, _, subordinate := s.addRelatedService(c, "wordpress", "logging", s.wordpressUnit)
@@ -1934,6 +2076,89 @@ func (s *uniterSuite) TestLeaveScope(c *gc.C) {
 	c.Assert(readSettings, gc.DeepEquals, settings)
 }
 
+func (s *uniterSuite) TestRelationBrokenBarriers(c *gc.C) {
+	rel := s.addRelation(c, "wordpress", "mysql")
+	relUnit, err := rel.Unit(s.wordpressUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = relUnit.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpress.SetRelationBrokenBarrier(true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.RelationUnits{RelationUnits: []params.RelationUnit{
+		{Relation: rel.Tag().String(), Unit: "unit-wordpress-0"},
+		{Relation: "relation-42", Unit: "unit-wordpress-0"},
+		{Relation: rel.Tag().String(), Unit: "unit-mysql-0"},
+		{Relation: rel.Tag().String(), Unit: "application-wordpress"},
+	}}
+	result, err := s.uniter.RelationBrokenBarriers(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.BoolResults{
+		Results: []params.BoolResult{
+			{Result: false},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	departed, err := s.uniter.SetRelationsDeparted(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(departed, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	result, err = s.uniter.RelationBrokenBarriers(params.RelationUnits{
+		RelationUnits: args.RelationUnits[:1],
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.BoolResults{
+		Results: []params.BoolResult{{Result: true}},
+	})
+}
+
+func (s *uniterSuite) TestWatchRelationBrokenBarriers(c *gc.C) {
+	rel := s.addRelation(c, "wordpress", "mysql")
+	relUnit, err := rel.Unit(s.wordpressUnit)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.RelationUnits{RelationUnits: []params.RelationUnit{
+		{Relation: rel.Tag().String(), Unit: "unit-wordpress-0"},
+		{Relation: rel.Tag().String(), Unit: "unit-mysql-0"},
+		{Relation: "relation-42", Unit: "unit-wordpress-0"},
+	}}
+	result, err := s.uniter.WatchRelationBrokenBarriers(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{
+			{NotifyWatcherId: "1"},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Verify the resource was registered and stop when done
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event ("returned" in
+	// the Watch call)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	err = relUnit.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
 func (s *uniterSuite) TestRelationsSuspended(c *gc.C) {
 	rel := s.addRelation(c, "wordpress", "mysql")
 	relUnit, err := rel.Unit(s.wordpressUnit)
@@ -2057,6 +2282,40 @@ func (s *uniterSuite) TestSetRelationsStatusLeader(c *gc.C) {
 	check(rel2, status.Suspended, "gone")
 }
 
+func (s *uniterSuite) TestSetRelationsStatusLatest(c *gc.C) {
+	rel := s.addRelation(c, "wordpress", "mysql")
+	relUnit, err := rel.Unit(s.wordpressUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = relUnit.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.LeadershipClaimer().ClaimLeadership("wordpress", "wordpress/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.RelationStatusArgs{
+		Args: []params.RelationStatusArg{
+			{rel.Id(), params.Broken, "first"},
+			{RelationId: 4},
+			{rel.Id(), params.Suspended, "second"},
+		},
+	}
+	result, err := s.uniter.SetRelationStatus(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: apiservertesting.ErrUnauthorized},
+			{},
+		},
+	})
+	err = rel.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	relStatus, err := rel.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(relStatus.Status, gc.Equals, status.Suspended)
+	c.Assert(relStatus.Message, gc.Equals, "second")
+	c.Assert(relStatus.Since, gc.NotNil)
+}
+
 func (s *uniterSuite) TestReadSettings(c *gc.C) {
 	rel := s.addRelation(c, "wordpress", "mysql")
 	relUnit, err := rel.Unit(s.wordpressUnit)
@@ -2243,6 +2502,132 @@ func (s *uniterSuite) TestReadRemoteSettingsWithNonStringValuesFails(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestReadRemoteSettingsHidesPrivateKeys(c *gc.C) {
+	app := s.AddTestingApplication(c, "mysql-private", s.AddTestingCharm(c, "mysql-private"))
+	privateUnit := s.Factory.MakeUnit(c, &jujufactory.UnitParams{Application: app})
+	rel := s.addRelation(c, "wordpress", "mysql-private")
+	relUnit, err := rel.Unit(privateUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = relUnit.EnterScope(map[string]interface{}{
+		"host":     "10.0.0.1",
+		"password": "sekrit",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.RelationUnitPairs{RelationUnitPairs: []params.RelationUnitPair{{
+		Relation:   rel.Tag().String(),
+		LocalUnit:  "unit-wordpress-0",
+		RemoteUnit: privateUnit.Tag().String(),
+	}}}
+	result, err := s.uniter.ReadRemoteSettings(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.SettingsResults{
+		Results: []params.SettingsResult{
+			{Settings: params.Settings{"host": "10.0.0.1"}},
+		},
+	})
+
+	// The password is only visible to the leader.
+	err = s.State.LeadershipClaimer().ClaimLeadership("wordpress", "wordpress/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.uniter.ReadRemoteSettings(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.SettingsResults{
+		Results: []params.SettingsResult{
+			{Settings: params.Settings{"host": "10.0.0.1", "password": "sekrit"}},
+		},
+	})
+}
+
+func (s *uniterSuite) TestReadSettingsBatch(c *gc.C) {
+	rel := s.addRelation(c, "wordpress", "mysql")
+	relUnit, err := rel.Unit(s.mysqlUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = relUnit.EnterScope(map[string]interface{}{"other": "things"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.RelationSettingsBatches{Batches: []params.RelationSettingsBatch{{
+		Relation:    rel.Tag().String(),
+		LocalUnit:   "unit-wordpress-0",
+		RemoteUnits: []string{"unit-mysql-0", "unit-wordpress-0", "unit-mysql-1", "foo"},
+	}, {
+		Relation:    rel.Tag().String(),
+		LocalUnit:   "unit-mysql-0",
+		RemoteUnits: []string{"unit-wordpress-0"},
+	}, {
+		Relation:    "relation-42",
+		LocalUnit:   "unit-wordpress-0",
+		RemoteUnits: []string{"unit-mysql-0"},
+	}}}
+	result, err := s.uniter.ReadSettingsBatch(args)
+	c.Assert(err, jc.ErrorIsNil)
+	expectErr := `cannot read settings for unit "mysql/1" in relation "wordpress:db mysql:server": settings`
+	c.Assert(result, jc.DeepEquals, params.RelationSettingsBatchResults{
+		Results: []params.RelationSettingsBatchResult{{
+			Results: []params.SettingsResult{
+				{Settings: params.Settings{"other": "things"}},
+				{Error: apiservertesting.ErrUnauthorized},
+				{Error: apiservertesting.NotFoundError(expectErr)},
+				{Error: apiservertesting.ErrUnauthorized},
+			},
+		}, {
+			Error: apiservertesting.ErrUnauthorized,
+		}, {
+			Error: apiservertesting.ErrUnauthorized,
+		}},
+	})
+}
+
+func (s *uniterSuite) TestWriteSettingsBatch(c *gc.C) {
+	rel := s.addRelation(c, "wordpress", "mysql")
+	relUnit, err := rel.Unit(s.wordpressUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = relUnit.EnterScope(map[string]interface{}{"some": "settings"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.RelationUnitsSettings{RelationUnits: []params.RelationUnitSettings{
+		{Relation: rel.Tag().String(), Unit: "unit-wordpress-0", Settings: params.Settings{"some": "different"}},
+	}}
+	result, err := s.uniter.WriteSettingsBatch(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{nil}},
+	})
+	readSettings, err := relUnit.ReadSettings(s.wordpressUnit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(readSettings, gc.DeepEquals, map[string]interface{}{
+		"some": "different",
+	})
+}
+
+func (s *uniterSuite) TestWriteSettingsBatchWritesNothingIfRejected(c *gc.C) {
+	rel := s.addRelation(c, "wordpress", "mysql")
+	relUnit, err := rel.Unit(s.wordpressUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = relUnit.EnterScope(map[string]interface{}{"some": "settings"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.RelationUnitsSettings{RelationUnits: []params.RelationUnitSettings{
+		{Relation: rel.Tag().String(), Unit: "unit-wordpress-0", Settings: params.Settings{"some": "different"}},
+		{Relation: "relation-42", Unit: "unit-wordpress-0", Settings: nil},
+	}}
+	result, err := s.uniter.WriteSettingsBatch(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{&params.Error{Message: "settings not written: other changes in batch rejected"}},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// The valid change was not written either.
+	readSettings, err := relUnit.ReadSettings(s.wordpressUnit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(readSettings, gc.DeepEquals, map[string]interface{}{
+		"some": "settings",
+	})
+}
+
 func (s *uniterSuite) TestUpdateSettings(c *gc.C) {
 	rel := s.addRelation(c, "wordpress", "mysql")
 	relUnit, err := rel.Unit(s.wordpressUnit)
@@ -2297,6 +2682,39 @@ func (s *uniterSuite) TestUpdateSettings(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestUpdateSettingsTooLarge(c *gc.C) {
+	rel := s.addRelation(c, "wordpress", "mysql")
+	relUnit, err := rel.Unit(s.wordpressUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = relUnit.EnterScope(map[string]interface{}{"some": "settings"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.RelationUnitsSettings{RelationUnits: []params.RelationUnitSettings{{
+		Relation: rel.Tag().String(),
+		Unit:     "unit-wordpress-0",
+		Settings: params.Settings{"large": strings.Repeat("x", 1024*1024)},
+	}}}
+	result, err := s.uniter.UpdateSettings(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches,
+		`relation settings size 1048593 bytes exceeds limit of 1048576 bytes \(max-relation-data-size 1M\)`)
+
+	// The settings are unchanged.
+	readSettings, err := relUnit.ReadSettings(s.wordpressUnit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(readSettings, gc.DeepEquals, map[string]interface{}{
+		"some": "settings",
+	})
+
+	// Raising the limit allows the change.
+	err = s.IAASModel.UpdateModelConfig(map[string]interface{}{"max-relation-data-size": "2M"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.uniter.UpdateSettings(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+}
+
 func (s *uniterSuite) TestWatchRelationUnits(c *gc.C) {
 	// Add a relation between wordpress and mysql and enter scope with
 	// mysqlUnit.
@@ -2374,6 +2792,34 @@ func (s *uniterSuite) TestWatchRelationUnits(c *gc.C) {
 	wc.AssertChange(nil, []string{"mysql/0"})
 }
 
+func (s *uniterSuite) TestWatchRelationUnitsDebounced(c *gc.C) {
+	err := s.IAASModel.UpdateModelConfig(map[string]interface{}{"relation-changed-debounce": "1h"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	rel := s.addRelation(c, "wordpress", "mysql")
+	myRelUnit, err := rel.Unit(s.mysqlUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = myRelUnit.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.RelationUnits{RelationUnits: []params.RelationUnit{
+		{Relation: rel.Tag().String(), Unit: "unit-wordpress-0"},
+	}}
+	result, err := s.uniter.WatchRelationUnits(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Changes.Changed, gc.HasLen, 1)
+	resource := s.resources.Get(result.Results[0].RelationUnitsWatcherId)
+	defer statetesting.AssertStop(c, resource)
+
+	// The initial event was delivered at once, but later changes are
+	// held for the debounce period.
+	err = myRelUnit.LeaveScope()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := statetesting.NewRelationUnitsWatcherC(c, s.State, resource.(state.RelationUnitsWatcher))
+	wc.AssertNoChange()
+}
+
 func (s *uniterSuite) TestAPIAddresses(c *gc.C) {
 	hostPorts := [][]network.HostPort{
 		network.NewHostPorts(1234, "0.1.2.3"),
@@ -2979,6 +3425,31 @@ func (s *uniterSuite) TestRefresh(c *gc.C) {
 	c.Assert(results, gc.DeepEquals, expect)
 }
 
+func (s *uniterSuite) TestRefreshDrainDeadline(c *gc.C) {
+	now := time.Now()
+	err := s.wordpressUnit.SetAgentStatus(status.StatusInfo{
+		Status: status.Idle,
+		Since:  &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	op := s.wordpressUnit.DestroyOperation()
+	op.DrainTimeout = time.Hour
+	err = s.State.ApplyOperation(op)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{
+		Entities: []params.Entity{{s.wordpressUnit.Tag().String()}},
+	}
+	results, err := s.uniter.Refresh(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	result := results.Results[0]
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Life, gc.Equals, params.Dying)
+	c.Assert(result.DrainDeadline, gc.NotNil)
+	c.Assert(result.DrainDeadline.After(now), jc.IsTrue)
+}
+
 func (s *uniterSuite) TestRefreshNoArgs(c *gc.C) {
 	results, err := s.uniter.Refresh(params.Entities{Entities: []params.Entity{}})
 	c.Assert(err, jc.ErrorIsNil)
@@ -3215,7 +3686,7 @@ func (s *uniterNetworkConfigSuite) addProvisionedMachineWithDevicesAndAddresses(
 	machine, err := s.base.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
 	devicesArgs, devicesAddrs := s.makeMachineDevicesAndAddressesArgs(addrSuffix)
-	err = machine.SetInstanceInfo("i-am", "fake_nonce", nil, devicesArgs, devicesAddrs, nil, nil)
+	err = machine.SetInstanceInfo("i-am", "", "fake_nonce", nil, devicesArgs, devicesAddrs, nil, nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	machineAddrs, err := machine.AllAddresses()
@@ -3483,7 +3954,7 @@ func (s *uniterNetworkInfoSuite) addProvisionedMachineWithDevicesAndAddresses(c
 	machine, err := s.base.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
 	devicesArgs, devicesAddrs := s.makeMachineDevicesAndAddressesArgs(addrSuffix)
-	err = machine.SetInstanceInfo("i-am", "fake_nonce", nil, devicesArgs, devicesAddrs, nil, nil)
+	err = machine.SetInstanceInfo("i-am", "", "fake_nonce", nil, devicesArgs, devicesAddrs, nil, nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	machineAddrs, err := machine.AllAddresses()
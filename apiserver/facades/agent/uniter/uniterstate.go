@@ -0,0 +1,83 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// UniterState returns the most recently recorded snapshot of the local
+// uniter state of each given unit, so that a unit on a re-provisioned
+// machine can resume where it left off.
+func (u *UniterAPI) UniterState(args params.Entities) (params.UniterStateResults, error) {
+	result := params.UniterStateResults{
+		Results: make([]params.UniterStateResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.UniterStateResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		if canAccess(tag) {
+			var unit *state.Unit
+			unit, err = u.getUnit(tag)
+			if err == nil {
+				var uniterState state.UniterState
+				uniterState, err = unit.UniterState()
+				if err == nil {
+					result.Results[i].Result = &params.UniterState{
+						OperationState: uniterState.OperationState,
+						RelationState:  uniterState.RelationState,
+						StorageState:   uniterState.StorageState,
+					}
+				}
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// SetUniterState records a snapshot of the local uniter state of each
+// given unit.
+func (u *UniterAPI) SetUniterState(args params.SetUniterStateArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		if canAccess(tag) {
+			var unit *state.Unit
+			unit, err = u.getUnit(tag)
+			if err == nil {
+				err = unit.SetUniterState(state.UniterState{
+					OperationState: arg.State.OperationState,
+					RelationState:  arg.State.RelationState,
+					StorageState:   arg.State.StorageState,
+				})
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
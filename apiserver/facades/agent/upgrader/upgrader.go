@@ -159,12 +159,26 @@ func (u *UpgraderAPI) DesiredVersion(args params.Entities) (params.VersionResult
 	if len(args.Entities) == 0 {
 		return params.VersionResults{}, nil
 	}
-	agentVersion, _, err := u.getGlobalAgentVersion()
+	agentVersion, cfg, err := u.getGlobalAgentVersion()
 	if err != nil {
 		return params.VersionResults{}, common.ServerError(err)
 	}
 	// Is the desired version greater than the current API server version?
 	isNewerVersion := agentVersion.Compare(jujuversion.Current) > 0
+	deferUpgrades := cfg.DeferAgentUpgrades()
+	// Whether the model is in a maintenance window is read at most
+	// once, and only if an agent would otherwise upgrade.
+	var inWindow *bool
+	inMaintenanceWindow := func() (bool, error) {
+		if inWindow == nil {
+			open, err := u.st.InMaintenanceWindow()
+			if err != nil {
+				return false, errors.Trace(err)
+			}
+			inWindow = &open
+		}
+		return *inWindow, nil
+	}
 	for i, entity := range args.Entities {
 		tag, err := names.ParseTag(entity.Tag)
 		if err != nil {
@@ -183,15 +197,52 @@ func (u *UpgraderAPI) DesiredVersion(args params.Entities) (params.VersionResult
 			// first - once they have restarted and are running the
 			// new version other agents will start to see the new
 			// agent version.
-			if !isNewerVersion || u.entityIsManager(tag) {
+			isManager := u.entityIsManager(tag)
+			if !isNewerVersion || isManager {
 				results[i].Version = &agentVersion
 			} else {
 				logger.Debugf("desired version is %s, but current version is %s and agent is not a manager node", agentVersion, jujuversion.Current)
 				results[i].Version = &jujuversion.Current
 			}
 			err = nil
+			if deferUpgrades && !isManager {
+				// The model asks for other agents to upgrade
+				// only within its maintenance windows.
+				err = u.deferUpgrade(tag, &results[i], inMaintenanceWindow)
+			}
 		}
 		results[i].Error = common.ServerError(err)
 	}
 	return params.VersionResults{Results: results}, nil
 }
+
+// deferUpgrade reports the agent's current version, and that its
+// upgrade is deferred, in the given result if the agent would upgrade
+// to a different version outside the model's maintenance windows.
+func (u *UpgraderAPI) deferUpgrade(tag names.Tag, result *params.VersionResult, inMaintenanceWindow func() (bool, error)) error {
+	entity, err := u.st.FindEntity(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tooler, ok := entity.(state.AgentTooler)
+	if !ok {
+		return nil
+	}
+	current, err := tooler.AgentTools()
+	if errors.IsNotFound(err) {
+		// The agent has not yet reported its version.
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if current.Version.Number == *result.Version {
+		return nil
+	}
+	if open, err := inMaintenanceWindow(); err != nil || open {
+		return errors.Trace(err)
+	}
+	logger.Debugf("deferring upgrade of %s from %s to %s until the next maintenance window", names.ReadableString(tag), current.Version.Number, *result.Version)
+	result.Version = &current.Version.Number
+	result.Deferred = true
+	return nil
+}
@@ -5,6 +5,7 @@ package upgrader_test
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -18,6 +19,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/upgrader"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/maintenance"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
@@ -341,3 +343,76 @@ func (s *upgraderSuite) TestDesiredVersionRestrictedForNonAPIAgents(c *gc.C) {
 	c.Assert(agentVersion, gc.NotNil)
 	c.Check(*agentVersion, gc.DeepEquals, jujuversion.Current)
 }
+
+// setOlderVersionOutsideMaintenanceWindow sets the agents of the
+// test machines to an older version, and defines a maintenance window
+// that is not open now.
+func (s *upgraderSuite) setOlderVersionOutsideMaintenanceWindow(c *gc.C) version.Number {
+	older := version.Binary{
+		Number: jujuversion.Current,
+		Arch:   arch.HostArch(),
+		Series: series.MustHostSeries(),
+	}
+	older.Minor--
+	err := s.rawMachine.SetAgentVersion(older)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.apiMachine.SetAgentVersion(older)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// A window that opens in two hours is not open now.
+	opens := time.Now().UTC().Add(2 * time.Hour)
+	err = s.State.SetMaintenanceWindow(maintenance.Window{
+		Name:     "later",
+		Schedule: fmt.Sprintf("%d %d * * *", opens.Minute(), opens.Hour()),
+		Duration: time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return older.Number
+}
+
+func (s *upgraderSuite) TestDesiredVersionNotDeferredByDefault(c *gc.C) {
+	s.setOlderVersionOutsideMaintenanceWindow(c)
+
+	args := params.Entities{Entities: []params.Entity{{Tag: s.rawMachine.Tag().String()}}}
+	results, err := s.upgrader.DesiredVersion(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.VersionResult{{
+		Version: &jujuversion.Current,
+	}})
+}
+
+func (s *upgraderSuite) TestDesiredVersionDeferredOutsideMaintenanceWindow(c *gc.C) {
+	err := s.IAASModel.UpdateModelConfig(map[string]interface{}{"defer-agent-upgrades": true}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	older := s.setOlderVersionOutsideMaintenanceWindow(c)
+
+	args := params.Entities{Entities: []params.Entity{{Tag: s.rawMachine.Tag().String()}}}
+	results, err := s.upgrader.DesiredVersion(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.VersionResult{{
+		Version:  &older,
+		Deferred: true,
+	}})
+
+	// Controller agents are not deferred.
+	upgraderAPI, err := upgrader.NewUpgraderAPI(s.State, s.resources, apiservertesting.FakeAuthorizer{
+		Tag: s.apiMachine.Tag(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	args = params.Entities{Entities: []params.Entity{{Tag: s.apiMachine.Tag().String()}}}
+	results, err = upgraderAPI.DesiredVersion(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.VersionResult{{
+		Version: &jujuversion.Current,
+	}})
+
+	// Once the window is removed, the agent upgrades.
+	err = s.State.RemoveMaintenanceWindow("later")
+	c.Assert(err, jc.ErrorIsNil)
+	args = params.Entities{Entities: []params.Entity{{Tag: s.rawMachine.Tag().String()}}}
+	results, err = s.upgrader.DesiredVersion(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.VersionResult{{
+		Version: &jujuversion.Current,
+	}})
+}
@@ -0,0 +1,86 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package utilizationreporter implements the API facade used by the
+// utilizationreporter worker.
+package utilizationreporter
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the State API used by the utilizationreporter facade.
+type Backend interface {
+	// Unit returns the unit with the given name.
+	Unit(name string) (Unit, error)
+}
+
+// Unit defines the methods the utilizationreporter facade needs from
+// state.Unit.
+type Unit interface {
+	AssignedMachineId() (string, error)
+	SetUtilization(state.UnitUtilization) error
+}
+
+// Facade implements the API required by the utilizationreporter
+// worker.
+type Facade struct {
+	backend   Backend
+	machineId string
+}
+
+// New returns a new API facade for the utilizationreporter worker.
+func New(backend Backend, _ facade.Resources, authorizer facade.Authorizer) (*Facade, error) {
+	if !authorizer.AuthMachineAgent() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{
+		backend:   backend,
+		machineId: authorizer.GetAuthTag().Id(),
+	}, nil
+}
+
+// SetUnitsUtilization records the resources used by the given units.
+// A machine agent may only report on the units assigned to its own
+// machine.
+func (facade *Facade) SetUnitsUtilization(args params.SetUnitsUtilization) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		unit, err := facade.unit(arg.Tag)
+		if err == nil {
+			err = unit.SetUtilization(state.UnitUtilization{
+				CPUPercent:  arg.Utilization.CPUPercent,
+				MemoryBytes: arg.Utilization.MemoryBytes,
+				DiskBytes:   arg.Utilization.DiskBytes,
+			})
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// unit returns the unit with the given tag, if it is assigned to the
+// authenticated machine.
+func (facade *Facade) unit(tagString string) (Unit, error) {
+	tag, err := names.ParseUnitTag(tagString)
+	if err != nil {
+		return nil, common.ErrPerm
+	}
+	unit, err := facade.backend.Unit(tag.Id())
+	if err != nil {
+		// Don't reveal which units exist.
+		return nil, common.ErrPerm
+	}
+	machineId, err := unit.AssignedMachineId()
+	if err != nil || machineId != facade.machineId {
+		return nil, common.ErrPerm
+	}
+	return unit, nil
+}
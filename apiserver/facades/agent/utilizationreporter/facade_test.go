@@ -0,0 +1,124 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package utilizationreporter_test
+
+import (
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/agent/utilizationreporter"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+)
+
+type facadeSuite struct {
+	testing.BaseSuite
+	backend    *mockBackend
+	authorizer *apiservertesting.FakeAuthorizer
+	facade     *utilizationreporter.Facade
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		units: map[string]*mockUnit{
+			"mysql/0": {machineId: "1"},
+			"mysql/1": {machineId: "2"},
+		},
+	}
+	s.authorizer = &apiservertesting.FakeAuthorizer{Tag: names.NewMachineTag("1")}
+	facade, err := utilizationreporter.New(s.backend, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade = facade
+}
+
+func (s *facadeSuite) TestRequiresMachineAgent(c *gc.C) {
+	s.authorizer.Tag = names.NewUnitTag("mysql/0")
+	_, err := utilizationreporter.New(s.backend, nil, s.authorizer)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *facadeSuite) TestSetUnitsUtilization(c *gc.C) {
+	utilization := params.UnitUtilization{
+		CPUPercent:  12.5,
+		MemoryBytes: 1024,
+		DiskBytes:   2048,
+	}
+	result, err := s.facade.SetUnitsUtilization(params.SetUnitsUtilization{
+		Args: []params.SetUnitUtilization{
+			{Tag: "unit-mysql-0", Utilization: utilization},
+			{Tag: "unit-mysql-1", Utilization: utilization},
+			{Tag: "unit-mysql-2", Utilization: utilization},
+			{Tag: "machine-1", Utilization: utilization},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	s.backend.stub.CheckCallNames(c,
+		"Unit", "AssignedMachineId", "SetUtilization",
+		"Unit", "AssignedMachineId",
+		"Unit",
+	)
+	s.backend.stub.CheckCall(c, 2, "SetUtilization", state.UnitUtilization{
+		CPUPercent:  12.5,
+		MemoryBytes: 1024,
+		DiskBytes:   2048,
+	})
+}
+
+func (s *facadeSuite) TestSetUnitsUtilizationError(c *gc.C) {
+	s.backend.stub.SetErrors(nil, nil, errors.New("boom"))
+	result, err := s.facade.SetUnitsUtilization(params.SetUnitsUtilization{
+		Args: []params.SetUnitUtilization{{Tag: "unit-mysql-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, "boom")
+}
+
+type mockBackend struct {
+	stub  jujutesting.Stub
+	units map[string]*mockUnit
+}
+
+func (b *mockBackend) Unit(name string) (utilizationreporter.Unit, error) {
+	b.stub.AddCall("Unit", name)
+	if err := b.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	unit, ok := b.units[name]
+	if !ok {
+		return nil, errors.NotFoundf("unit %q", name)
+	}
+	unit.stub = &b.stub
+	return unit, nil
+}
+
+type mockUnit struct {
+	stub      *jujutesting.Stub
+	machineId string
+}
+
+func (u *mockUnit) AssignedMachineId() (string, error) {
+	u.stub.AddCall("AssignedMachineId")
+	return u.machineId, u.stub.NextErr()
+}
+
+func (u *mockUnit) SetUtilization(utilization state.UnitUtilization) error {
+	u.stub.AddCall("SetUtilization", utilization)
+	return u.stub.NextErr()
+}
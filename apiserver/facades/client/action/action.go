@@ -78,6 +78,8 @@ func (a *ActionAPI) checkCanAdmin() error {
 
 // Actions takes a list of ActionTags, and returns the full Action for
 // each ID.
+//
+//juju:readonly
 func (a *ActionAPI) Actions(arg params.Entities) (params.ActionResults, error) {
 	if err := a.checkCanRead(); err != nil {
 		return params.ActionResults{}, errors.Trace(err)
@@ -117,6 +119,8 @@ func (a *ActionAPI) Actions(arg params.Entities) (params.ActionResults, error) {
 
 // FindActionTagsByPrefix takes a list of string prefixes and finds
 // corresponding ActionTags that match that prefix.
+//
+//juju:readonly
 func (a *ActionAPI) FindActionTagsByPrefix(arg params.FindTags) (params.FindTagsResults, error) {
 	if err := a.checkCanRead(); err != nil {
 		return params.FindTagsResults{}, errors.Trace(err)
@@ -138,6 +142,7 @@ func (a *ActionAPI) FindActionTagsByPrefix(arg params.FindTags) (params.FindTags
 	return response, nil
 }
 
+//juju:readonly
 func (a *ActionAPI) FindActionsByNames(arg params.FindActionsByNames) (params.ActionsByNames, error) {
 	if err := a.checkCanWrite(); err != nil {
 		return params.ActionsByNames{}, errors.Trace(err)
@@ -207,6 +212,8 @@ func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 // ListAll takes a list of Entities representing ActionReceivers and
 // returns all of the Actions that have been enqueued or run by each of
 // those Entities.
+//
+//juju:readonly
 func (a *ActionAPI) ListAll(arg params.Entities) (params.ActionsByReceivers, error) {
 	if err := a.checkCanRead(); err != nil {
 		return params.ActionsByReceivers{}, errors.Trace(err)
@@ -218,6 +225,8 @@ func (a *ActionAPI) ListAll(arg params.Entities) (params.ActionsByReceivers, err
 // ListPending takes a list of Entities representing ActionReceivers
 // and returns all of the Actions that are enqueued for each of those
 // Entities.
+//
+//juju:readonly
 func (a *ActionAPI) ListPending(arg params.Entities) (params.ActionsByReceivers, error) {
 	if err := a.checkCanRead(); err != nil {
 		return params.ActionsByReceivers{}, errors.Trace(err)
@@ -229,6 +238,8 @@ func (a *ActionAPI) ListPending(arg params.Entities) (params.ActionsByReceivers,
 // ListRunning takes a list of Entities representing ActionReceivers and
 // returns all of the Actions that have are running on each of those
 // Entities.
+//
+//juju:readonly
 func (a *ActionAPI) ListRunning(arg params.Entities) (params.ActionsByReceivers, error) {
 	if err := a.checkCanRead(); err != nil {
 		return params.ActionsByReceivers{}, errors.Trace(err)
@@ -240,6 +251,8 @@ func (a *ActionAPI) ListRunning(arg params.Entities) (params.ActionsByReceivers,
 // ListCompleted takes a list of Entities representing ActionReceivers
 // and returns all of the Actions that have been run on each of those
 // Entities.
+//
+//juju:readonly
 func (a *ActionAPI) ListCompleted(arg params.Entities) (params.ActionsByReceivers, error) {
 	if err := a.checkCanRead(); err != nil {
 		return params.ActionsByReceivers{}, errors.Trace(err)
@@ -302,6 +315,8 @@ func (a *ActionAPI) Cancel(arg params.Entities) (params.ActionResults, error) {
 
 // ApplicationsCharmsActions returns a slice of charm Actions for a slice of
 // services.
+//
+//juju:readonly
 func (a *ActionAPI) ApplicationsCharmsActions(args params.Entities) (params.ApplicationsCharmActionsResults, error) {
 	result := params.ApplicationsCharmActionsResults{Results: make([]params.ApplicationCharmActionsResult, len(args.Entities))}
 	if err := a.checkCanWrite(); err != nil {
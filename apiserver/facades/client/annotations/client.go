@@ -76,6 +76,8 @@ func (api *API) checkCanWrite() error {
 // Get returns annotations for given entities.
 // If annotations cannot be retrieved for a given entity, an error is returned.
 // Each entity is treated independently and, hence, will fail or succeed independently.
+//
+//juju:readonly
 func (api *API) Get(args params.Entities) params.AnnotationsGetResults {
 	if err := api.checkCanRead(); err != nil {
 		result := make([]params.AnnotationsGetResult, len(args.Entities))
@@ -9,6 +9,7 @@ package application
 import (
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -24,7 +25,9 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/charmrefresh"
 	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/trust"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
@@ -38,13 +41,63 @@ var logger = loggo.GetLogger("juju.apiserver.application")
 
 // APIv4 provides the Application API facade for versions 1-4.
 type APIv4 struct {
+	*APIv5
+}
+
+// APIv5 provides the Application API facade for version 5.
+type APIv5 struct {
+	*APIv6
+}
+
+// APIv6 provides the Application API facade for version 6.
+type APIv6 struct {
+	*APIv7
+}
+
+// APIv7 provides the Application API facade for version 7.
+type APIv7 struct {
+	*APIv8
+}
+
+// APIv8 provides the Application API facade for version 8.
+type APIv8 struct {
+	*APIv9
+}
+
+// APIv9 provides the Application API facade for version 9.
+type APIv9 struct {
+	*APIv10
+}
+
+// APIv10 provides the Application API facade for version 10.
+type APIv10 struct {
+	*APIv11
+}
+
+// APIv11 provides the Application API facade for version 11.
+type APIv11 struct {
+	*APIv12
+}
+
+// APIv12 provides the Application API facade for version 12.
+type APIv12 struct {
+	*APIv13
+}
+
+// APIv13 provides the Application API facade for version 13.
+type APIv13 struct {
+	*APIv14
+}
+
+// APIv14 provides the Application API facade for version 14.
+type APIv14 struct {
 	*API
 }
 
 // API implements the application interface and is the concrete
 // implementation of the api end point.
 //
-// API provides the Application API facade for version 5.
+// API provides the Application API facade for version 15.
 type API struct {
 	backend    Backend
 	authorizer facade.Authorizer
@@ -63,13 +116,113 @@ type API struct {
 // NewFacadeV4 provides the signature required for facade registration
 // for versions 1-4.
 func NewFacadeV4(ctx facade.Context) (*APIv4, error) {
-	api, err := NewFacade(ctx)
+	api, err := NewFacadeV5(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv4{api}, nil
 }
 
+// NewFacadeV5 provides the signature required for facade registration
+// for version 5.
+func NewFacadeV5(ctx facade.Context) (*APIv5, error) {
+	api, err := NewFacadeV6(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv5{api}, nil
+}
+
+// NewFacadeV6 provides the signature required for facade registration
+// for version 6.
+func NewFacadeV6(ctx facade.Context) (*APIv6, error) {
+	api, err := NewFacadeV7(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv6{api}, nil
+}
+
+// NewFacadeV7 provides the signature required for facade registration
+// for version 7.
+func NewFacadeV7(ctx facade.Context) (*APIv7, error) {
+	api, err := NewFacadeV8(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv7{api}, nil
+}
+
+// NewFacadeV8 provides the signature required for facade registration
+// for version 8.
+func NewFacadeV8(ctx facade.Context) (*APIv8, error) {
+	api, err := NewFacadeV9(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv8{api}, nil
+}
+
+// NewFacadeV9 provides the signature required for facade registration
+// for version 9.
+func NewFacadeV9(ctx facade.Context) (*APIv9, error) {
+	api, err := NewFacadeV10(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv9{api}, nil
+}
+
+// NewFacadeV10 provides the signature required for facade registration
+// for version 10.
+func NewFacadeV10(ctx facade.Context) (*APIv10, error) {
+	api, err := NewFacadeV11(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv10{api}, nil
+}
+
+// NewFacadeV11 provides the signature required for facade registration
+// for version 11.
+func NewFacadeV11(ctx facade.Context) (*APIv11, error) {
+	api, err := NewFacadeV12(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv11{api}, nil
+}
+
+// NewFacadeV12 provides the signature required for facade registration
+// for version 12.
+func NewFacadeV12(ctx facade.Context) (*APIv12, error) {
+	api, err := NewFacadeV13(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv12{api}, nil
+}
+
+// NewFacadeV13 provides the signature required for facade registration
+// for version 13.
+func NewFacadeV13(ctx facade.Context) (*APIv13, error) {
+	api, err := NewFacadeV14(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv13{api}, nil
+}
+
+// NewFacadeV14 provides the signature required for facade registration
+// for version 14.
+func NewFacadeV14(ctx facade.Context) (*APIv14, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv14{api}, nil
+}
+
 // NewFacade provides the signature required for facade registration.
 func NewFacade(ctx facade.Context) (*API, error) {
 	backend, err := NewStateBackend(ctx.State())
@@ -107,6 +260,24 @@ func NewAPI(
 	}, nil
 }
 
+// recordEvent records an event, caused by the authenticated user, in
+// the model's activity feed. The change the event describes has
+// already been made, so failing to record it is only logged.
+func (api *API) recordEvent(kind state.ModelEventKind, message string, entities ...names.Tag) {
+	event := state.ModelEvent{
+		Kind:     kind,
+		Actor:    api.authorizer.GetAuthTag().String(),
+		Entities: make([]string, len(entities)),
+		Message:  message,
+	}
+	for i, entity := range entities {
+		event.Entities[i] = entity.String()
+	}
+	if err := api.backend.AddModelEvent(event); err != nil {
+		logger.Errorf("cannot record %s model event: %v", kind, err)
+	}
+}
+
 func (api *API) checkPermission(tag names.Tag, perm permission.Access) error {
 	allowed, err := api.authorizer.HasPermission(perm, tag)
 	if err != nil {
@@ -122,6 +293,10 @@ func (api *API) checkCanRead() error {
 	return api.checkPermission(api.backend.ModelTag(), permission.ReadAccess)
 }
 
+func (api *API) checkCanAdmin() error {
+	return api.checkPermission(api.backend.ModelTag(), permission.AdminAccess)
+}
+
 func (api *API) checkCanWrite() error {
 	return api.checkPermission(api.backend.ModelTag(), permission.WriteAccess)
 }
@@ -153,12 +328,34 @@ func (api *API) SetMetricCredentials(args params.ApplicationMetricCredentials) (
 
 // Deploy fetches the charms from the charm store and deploys them
 // using the specified placement directives.
-func (api *API) Deploy(args params.ApplicationsDeploy) (params.ErrorResults, error) {
-	if err := api.checkCanWrite(); err != nil {
+//
+// Deploy on version 10 and earlier of the facade does not report
+// warnings.
+func (api *APIv10) Deploy(args params.ApplicationsDeploy) (params.ErrorResults, error) {
+	results, err := api.API.Deploy(args)
+	if err != nil {
 		return params.ErrorResults{}, errors.Trace(err)
 	}
 	result := params.ErrorResults{
-		Results: make([]params.ErrorResult, len(args.Applications)),
+		Results: make([]params.ErrorResult, len(results.Results)),
+	}
+	for i, r := range results.Results {
+		result.Results[i].Error = r.Error
+	}
+	return result, nil
+}
+
+// Deploy fetches the charms from the charm store and deploys them
+// using the specified placement directives. The results include
+// warnings about endpoints of the deployed applications that
+// implement interface schema versions not known to be compatible with
+// those of existing applications.
+func (api *API) Deploy(args params.ApplicationsDeploy) (params.DeployResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.DeployResults{}, errors.Trace(err)
+	}
+	result := params.DeployResults{
+		Results: make([]params.DeployResult, len(args.Applications)),
 	}
 	if err := api.check.ChangeAllowed(); err != nil {
 		return result, errors.Trace(err)
@@ -166,6 +363,17 @@ func (api *API) Deploy(args params.ApplicationsDeploy) (params.ErrorResults, err
 	for i, arg := range args.Applications {
 		err := deployApplication(api.backend, api.stateCharm, arg, api.deployApplicationFunc)
 		result.Results[i].Error = common.ServerError(err)
+		if err == nil {
+			api.recordEvent(state.EventApplicationDeployed,
+				fmt.Sprintf("deployed %s with %d unit(s)", arg.CharmURL, arg.NumUnits),
+				names.NewApplicationTag(arg.ApplicationName),
+			)
+			warnings, err := api.deployInterfaceWarnings(arg.ApplicationName)
+			if err != nil {
+				logger.Errorf("cannot check interface versions of %q: %v", arg.ApplicationName, err)
+			}
+			result.Results[i].Warnings = warnings
+		}
 
 		if err != nil && len(arg.Resources) != 0 {
 			// Remove any pending resources - these would have been
@@ -223,13 +431,14 @@ func deployApplication(
 
 	// Do a quick but not complete validation check before going any further.
 	for _, p := range args.Placement {
-		if p.Scope != instance.MachineScope {
+		err := backend.CheckPlacement(args.ApplicationName, p)
+		if err == nil {
 			continue
 		}
-		_, err = backend.Machine(p.Directive)
-		if err != nil {
+		if p.Scope == instance.MachineScope {
 			return errors.Annotatef(err, `cannot deploy "%v" to machine %v`, args.ApplicationName, p.Directive)
 		}
+		return errors.Annotatef(err, `cannot deploy "%v" to %v`, args.ApplicationName, p)
 	}
 
 	// Try to find the charm URL in state first.
@@ -266,6 +475,25 @@ func deployApplication(
 		attachStorage[i] = tag
 	}
 
+	storagePerUnit := 0
+	for name, meta := range ch.Meta().Storage {
+		if cons, ok := args.Storage[name]; ok && cons.Count > 0 {
+			storagePerUnit += int(cons.Count)
+		} else {
+			storagePerUnit += meta.CountMin
+		}
+	}
+	requested := unitResources(
+		args.NumUnits,
+		args.Placement,
+		backend.ModelType() == state.ModelTypeIAAS,
+		args.Constraints,
+		storagePerUnit-len(attachStorage),
+	)
+	if err := backend.CheckModelQuotas(requested); err != nil {
+		return errors.Trace(err)
+	}
+
 	_, err = deployApplicationFunc(backend, DeployApplicationParams{
 		ApplicationName:  args.ApplicationName,
 		Series:           args.Series,
@@ -339,7 +567,7 @@ func (api *API) Update(args params.ApplicationUpdate) error {
 		return err
 	}
 	if !args.ForceCharmURL {
-		if err := api.check.ChangeAllowed(); err != nil {
+		if err := api.check.ChangeAllowedFor(names.NewApplicationTag(args.ApplicationName)); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -420,6 +648,9 @@ func (api *API) updateOneApplicationSeries(arg params.UpdateSeriesArg) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err := api.check.ChangeAllowedFor(applicationTag); err != nil {
+		return errors.Trace(err)
+	}
 	app, err := api.backend.Application(applicationTag.Id())
 	if err != nil {
 		return errors.Trace(err)
@@ -443,7 +674,7 @@ func (api *API) SetCharm(args params.ApplicationSetCharm) error {
 	}
 	// when forced units in error, don't block
 	if !args.ForceUnits {
-		if err := api.check.ChangeAllowed(); err != nil {
+		if err := api.check.ChangeAllowedFor(names.NewApplicationTag(args.ApplicationName)); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -468,6 +699,8 @@ func (api *API) SetCharm(args params.ApplicationSetCharm) error {
 
 // GetConfig returns the charm config for each of the
 // applications asked for.
+//
+//juju:readonly
 func (api *API) GetConfig(args params.Entities) (params.ApplicationGetConfigResults, error) {
 	if err := api.checkCanRead(); err != nil {
 		return params.ApplicationGetConfigResults{}, err
@@ -538,20 +771,6 @@ func (api *API) applicationSetCharm(
 	if err != nil {
 		return errors.Annotate(err, "parsing config settings")
 	}
-	var stateStorageConstraints map[string]state.StorageConstraints
-	if len(storageConstraints) > 0 {
-		stateStorageConstraints = make(map[string]state.StorageConstraints)
-		for name, cons := range storageConstraints {
-			stateCons := state.StorageConstraints{Pool: cons.Pool}
-			if cons.Size != nil {
-				stateCons.Size = *cons.Size
-			}
-			if cons.Count != nil {
-				stateCons.Count = *cons.Count
-			}
-			stateStorageConstraints[name] = stateCons
-		}
-	}
 	cfg := state.SetCharmConfig{
 		Charm:              api.stateCharm(sch),
 		Channel:            channel,
@@ -559,11 +778,32 @@ func (api *API) applicationSetCharm(
 		ForceSeries:        forceSeries,
 		ForceUnits:         forceUnits,
 		ResourceIDs:        resourceIDs,
-		StorageConstraints: stateStorageConstraints,
+		StorageConstraints: stateStorageConstraints(storageConstraints),
 	}
 	return application.SetCharm(cfg)
 }
 
+// stateStorageConstraints converts storage constraints from their
+// API representation into the form stored in state. Unspecified
+// sizes and counts are left as zero.
+func stateStorageConstraints(storageConstraints map[string]params.StorageConstraints) map[string]state.StorageConstraints {
+	if len(storageConstraints) == 0 {
+		return nil
+	}
+	result := make(map[string]state.StorageConstraints)
+	for name, cons := range storageConstraints {
+		stateCons := state.StorageConstraints{Pool: cons.Pool}
+		if cons.Size != nil {
+			stateCons.Size = *cons.Size
+		}
+		if cons.Count != nil {
+			stateCons.Count = *cons.Count
+		}
+		result[name] = stateCons
+	}
+	return result
+}
+
 // charmConfigFromGetYaml will parse a yaml produced by juju get and generate
 // charm.Settings from it that can then be sent to the application.
 func charmConfigFromGetYaml(yamlContents map[string]interface{}) (charm.Settings, error) {
@@ -623,6 +863,8 @@ func applicationSetCharmConfigYAML(appName string, application Application, sett
 
 // GetCharmURL returns the charm URL the given application is
 // running at present.
+//
+//juju:readonly
 func (api *API) GetCharmURL(args params.ApplicationGet) (params.StringResult, error) {
 	if err := api.checkCanWrite(); err != nil {
 		return params.StringResult{}, errors.Trace(err)
@@ -642,7 +884,7 @@ func (api *API) Set(p params.ApplicationSet) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
 	}
-	if err := api.check.ChangeAllowed(); err != nil {
+	if err := api.check.ChangeAllowedFor(names.NewApplicationTag(p.ApplicationName)); err != nil {
 		return errors.Trace(err)
 	}
 	app, err := api.backend.Application(p.ApplicationName)
@@ -668,7 +910,7 @@ func (api *API) Unset(p params.ApplicationUnset) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
 	}
-	if err := api.check.ChangeAllowed(); err != nil {
+	if err := api.check.ChangeAllowedFor(names.NewApplicationTag(p.ApplicationName)); err != nil {
 		return errors.Trace(err)
 	}
 	app, err := api.backend.Application(p.ApplicationName)
@@ -683,6 +925,8 @@ func (api *API) Unset(p params.ApplicationUnset) error {
 }
 
 // CharmRelations implements the server side of Application.CharmRelations.
+//
+//juju:readonly
 func (api *API) CharmRelations(p params.ApplicationCharmRelations) (params.ApplicationCharmRelationsResults, error) {
 	var results params.ApplicationCharmRelationsResults
 	if err := api.checkCanRead(); err != nil {
@@ -710,30 +954,168 @@ func (api *API) Expose(args params.ApplicationExpose) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
 	}
-	if err := api.check.ChangeAllowed(); err != nil {
+	if err := api.check.ChangeAllowedFor(names.NewApplicationTag(args.ApplicationName)); err != nil {
 		return errors.Trace(err)
 	}
 	app, err := api.backend.Application(args.ApplicationName)
 	if err != nil {
 		return err
 	}
-	return app.SetExposed()
+	if err := app.SetExposed(); err != nil {
+		return err
+	}
+	api.recordEvent(state.EventApplicationExposed, "exposed", names.NewApplicationTag(args.ApplicationName))
+	return nil
 }
 
+// SetRelationBrokenBarrier sets whether the application's units must
+// all have run the relation-departed hooks for a dying or suspended
+// relation before any of them runs its relation-broken hook.
+func (api *API) SetRelationBrokenBarrier(args params.ApplicationRelationBrokenBarrier) error {
+	if err := api.checkCanWrite(); err != nil {
+		return err
+	}
+	if err := api.check.ChangeAllowedFor(names.NewApplicationTag(args.ApplicationName)); err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(args.ApplicationName)
+	if err != nil {
+		return err
+	}
+	return app.SetRelationBrokenBarrier(args.Enabled)
+}
+
+// SetRelationBrokenBarrier is not available in version 7 of the API.
+func (*APIv7) SetRelationBrokenBarrier(_, _ struct{}) {}
+
+// UpdateStorageConstraints updates the storage constraints for the
+// named stores of each of the given applications. The new constraints
+// are validated against the application's charm, and apply to storage
+// added for units added later; existing storage is not changed.
+func (api *API) UpdateStorageConstraints(args params.ApplicationStorageUpdateRequest) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.ApplicationStorageUpdates)),
+	}
+	for i, arg := range args.ApplicationStorageUpdates {
+		err := api.updateStorageConstraints(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) updateStorageConstraints(arg params.ApplicationStorageUpdate) error {
+	tag, err := names.ParseApplicationTag(arg.ApplicationTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowedFor(tag); err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return app.UpdateStorageConstraints(stateStorageConstraints(arg.StorageConstraints))
+}
+
+// UpdateStorageConstraints is not available in version 8 of the API.
+func (*APIv8) UpdateStorageConstraints(_, _ struct{}) {}
+
+// SetTrust sets the trust levels of the given applications, which
+// determine the cloud credentials their charms may obtain. Only model
+// administrators may trust applications, as trusted charms act on the
+// cloud with the model's credential.
+func (api *API) SetTrust(args params.ApplicationTrustArgs) (params.ErrorResults, error) {
+	if err := api.checkCanAdmin(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := api.setTrust(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) setTrust(arg params.ApplicationTrust) error {
+	level := trust.Level(arg.Level)
+	if err := level.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowedFor(names.NewApplicationTag(arg.ApplicationName)); err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(arg.ApplicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return app.SetTrustLevel(level)
+}
+
+// SetTrust is not available in version 9 of the API.
+func (*APIv9) SetTrust(_, _ struct{}) {}
+
+// SetRefreshPolicy sets the policies under which the given
+// applications' charms are refreshed automatically when newer
+// revisions are published to the channels they track.
+func (api *API) SetRefreshPolicy(args params.ApplicationRefreshPolicyArgs) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := api.setRefreshPolicy(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) setRefreshPolicy(arg params.ApplicationRefreshPolicy) error {
+	policy := charmrefresh.Policy(arg.Policy)
+	if err := policy.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowedFor(names.NewApplicationTag(arg.ApplicationName)); err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(arg.ApplicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if curl, _ := app.CharmURL(); policy != charmrefresh.None && curl.Schema != "cs" {
+		return errors.NotSupportedf("refreshing %s charms automatically", curl.Schema)
+	}
+	return app.SetRefreshPolicy(policy)
+}
+
+// SetRefreshPolicy is not available in version 13 of the API.
+func (*APIv13) SetRefreshPolicy(_, _ struct{}) {}
+
 // Unexpose changes the juju-managed firewall to unexpose any ports that
 // were also explicitly marked by units as open.
 func (api *API) Unexpose(args params.ApplicationUnexpose) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
 	}
-	if err := api.check.ChangeAllowed(); err != nil {
+	if err := api.check.ChangeAllowedFor(names.NewApplicationTag(args.ApplicationName)); err != nil {
 		return errors.Trace(err)
 	}
 	app, err := api.backend.Application(args.ApplicationName)
 	if err != nil {
 		return err
 	}
-	return app.ClearExposed()
+	if err := app.ClearExposed(); err != nil {
+		return err
+	}
+	api.recordEvent(state.EventApplicationUnexposed, "unexposed", names.NewApplicationTag(args.ApplicationName))
+	return nil
 }
 
 // AddUnits adds a given number of units to an application.
@@ -741,7 +1123,7 @@ func (api *API) AddUnits(args params.AddApplicationUnits) (params.AddApplication
 	if err := api.checkCanWrite(); err != nil {
 		return params.AddApplicationUnitsResults{}, errors.Trace(err)
 	}
-	if err := api.check.ChangeAllowed(); err != nil {
+	if err := api.check.ChangeAllowedFor(names.NewApplicationTag(args.ApplicationName)); err != nil {
 		return params.AddApplicationUnitsResults{}, errors.Trace(err)
 	}
 	units, err := addApplicationUnits(api.backend, args)
@@ -751,6 +1133,7 @@ func (api *API) AddUnits(args params.AddApplicationUnits) (params.AddApplication
 	unitNames := make([]string, len(units))
 	for i, unit := range units {
 		unitNames[i] = unit.UnitTag().Id()
+		api.recordEvent(state.EventUnitAdded, "", unit.UnitTag(), names.NewApplicationTag(args.ApplicationName))
 	}
 	return params.AddApplicationUnitsResults{Units: unitNames}, nil
 }
@@ -796,6 +1179,28 @@ func addApplicationUnits(backend Backend, args params.AddApplicationUnits) ([]Un
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	cons, err := application.Constraints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	storageCons, err := application.StorageConstraints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	storagePerUnit := 0
+	for _, sc := range storageCons {
+		storagePerUnit += int(sc.Count)
+	}
+	requested := unitResources(
+		args.NumUnits,
+		args.Placement,
+		assignUnits,
+		cons,
+		storagePerUnit-len(attachStorage),
+	)
+	if err := backend.CheckModelQuotas(requested); err != nil {
+		return nil, errors.Trace(err)
+	}
 	return addUnits(
 		application,
 		args.ApplicationName,
@@ -855,7 +1260,22 @@ func (api *APIv4) DestroyUnit(args params.Entities) (params.DestroyUnitResults,
 	return api.API.DestroyUnit(v5args)
 }
 
+// DestroyUnit removes a given set of application units. Version 5
+// does not support draining units or forcing their removal.
+func (api *APIv5) DestroyUnit(args params.DestroyUnitsParams) (params.DestroyUnitResults, error) {
+	for i := range args.Units {
+		args.Units[i].DrainTimeout = nil
+		args.Units[i].Force = false
+	}
+	return api.API.DestroyUnit(args)
+}
+
 // DestroyUnit removes a given set of application units.
+//
+// If a drain timeout is given, the unit's charm is asked to drain the
+// unit, and given up to that long to do so, before the unit is torn
+// down. If force is set, steps that could hold up the unit's removal
+// are skipped, and reported in the result.
 func (api *API) DestroyUnit(args params.DestroyUnitsParams) (params.DestroyUnitResults, error) {
 	if err := api.checkCanWrite(); err != nil {
 		return params.DestroyUnitResults{}, errors.Trace(err)
@@ -869,6 +1289,21 @@ func (api *API) DestroyUnit(args params.DestroyUnitsParams) (params.DestroyUnitR
 			return nil, errors.Trace(err)
 		}
 		name := unitTag.Id()
+		appName, err := names.UnitApplication(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		appTag := names.NewApplicationTag(appName)
+		if err := api.check.RemoveAllowedFor(appTag); err != nil {
+			return nil, errors.Trace(err)
+		}
+		// Units in maintenance mode, or whose application is, are
+		// only removed when forced.
+		if !arg.Force {
+			if err := api.backend.CheckNotInMaintenance(unitTag, appTag); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
 		unit, err := api.backend.Unit(name)
 		if errors.IsNotFound(err) {
 			return nil, errors.Errorf("unit %q does not exist", name)
@@ -902,6 +1337,23 @@ func (api *API) DestroyUnit(args params.DestroyUnitsParams) (params.DestroyUnitR
 		}
 		op := unit.DestroyOperation()
 		op.DestroyStorage = arg.DestroyStorage
+		if arg.DrainTimeout != nil {
+			if *arg.DrainTimeout <= 0 {
+				return nil, errors.NotValidf("drain timeout %v", *arg.DrainTimeout)
+			}
+			if arg.Force {
+				info.SkippedSteps = append(info.SkippedSteps, "drain hook")
+			} else {
+				op.DrainTimeout = *arg.DrainTimeout
+			}
+		}
+		if arg.Force {
+			skipped, err := skipFailedHook(unit)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			info.SkippedSteps = append(info.SkippedSteps, skipped...)
+		}
 		if err := api.backend.ApplyOperation(op); err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -919,6 +1371,24 @@ func (api *API) DestroyUnit(args params.DestroyUnitsParams) (params.DestroyUnitR
 	return params.DestroyUnitResults{results}, nil
 }
 
+// skipFailedHook marks a unit whose agent is stuck on a failed hook as
+// resolved without re-running the hook, so that the unit can proceed
+// with its teardown. It returns a description of the skipped step, if
+// any.
+func skipFailedHook(unit Unit) ([]string, error) {
+	agentStatus, err := unit.AgentStatus()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if agentStatus.Status != status.Error || unit.Resolved() != state.ResolvedNone {
+		return nil, nil
+	}
+	if err := unit.SetResolved(state.ResolvedNoHooks); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return []string{fmt.Sprintf("retry of failed hook (%s)", agentStatus.Message)}, nil
+}
+
 // Destroy destroys a given application, local or remote.
 //
 // NOTE(axw) this exists only for backwards compatibility,
@@ -974,6 +1444,9 @@ func (api *API) DestroyApplication(args params.DestroyApplicationsParams) (param
 		if err != nil {
 			return nil, err
 		}
+		if err := api.check.RemoveAllowedFor(tag); err != nil {
+			return nil, errors.Trace(err)
+		}
 		var info params.DestroyApplicationInfo
 		app, err := api.backend.Application(tag.Id())
 		if err != nil {
@@ -983,51 +1456,22 @@ func (api *API) DestroyApplication(args params.DestroyApplicationsParams) (param
 		if err != nil {
 			return nil, err
 		}
-		storageSeen := make(set.Tags)
+		// Applications cannot be removed while they, or any of
+		// their units, are in maintenance mode.
+		maintenanceTags := []names.Tag{tag}
 		for _, unit := range units {
 			info.DestroyedUnits = append(
 				info.DestroyedUnits,
 				params.Entity{unit.UnitTag().String()},
 			)
-			if api.backend.ModelType() != state.ModelTypeIAAS {
-				// Non-IAAS model; no need to deal with storage below.
-				continue
-			}
-			storage, err := storagecommon.UnitStorage(api.backend, unit.UnitTag())
-			if err != nil {
-				return nil, err
-			}
-
-			// Filter out storage we've already seen. Shared
-			// storage may be attached to multiple units.
-			var unseen []state.StorageInstance
-			for _, stor := range storage {
-				storageTag := stor.StorageTag()
-				if storageSeen.Contains(storageTag) {
-					continue
-				}
-				storageSeen.Add(storageTag)
-				unseen = append(unseen, stor)
-			}
-			storage = unseen
-
-			if arg.DestroyStorage {
-				for _, s := range storage {
-					info.DestroyedStorage = append(
-						info.DestroyedStorage,
-						params.Entity{s.StorageTag().String()},
-					)
-				}
-			} else {
-				destroyed, detached, err := storagecommon.ClassifyDetachedStorage(
-					api.backend, storage,
-				)
-				if err != nil {
-					return nil, err
-				}
-				info.DestroyedStorage = append(info.DestroyedStorage, destroyed...)
-				info.DetachedStorage = append(info.DetachedStorage, detached...)
-			}
+			maintenanceTags = append(maintenanceTags, unit.UnitTag())
+		}
+		if err := api.backend.CheckNotInMaintenance(maintenanceTags...); err != nil {
+			return nil, err
+		}
+		info.DestroyedStorage, info.DetachedStorage, err = api.unitsStorage(units, arg.DestroyStorage)
+		if err != nil {
+			return nil, err
 		}
 		op := app.DestroyOperation()
 		op.DestroyStorage = arg.DestroyStorage
@@ -1048,6 +1492,112 @@ func (api *API) DestroyApplication(args params.DestroyApplicationsParams) (param
 	return params.DestroyApplicationResults{results}, nil
 }
 
+// DestroyPlan reports, for each of the given applications, what
+// destroying it with the same arguments would remove or leave behind:
+// units, relations including cross-model ones, storage destroyed or
+// detached, and machines left empty. Nothing is destroyed.
+func (api *API) DestroyPlan(args params.DestroyApplicationsParams) (params.DestroyApplicationPlanResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.DestroyApplicationPlanResults{}, err
+	}
+	results := make([]params.DestroyApplicationPlanResult, len(args.Applications))
+	for i, arg := range args.Applications {
+		plan, err := api.destroyPlan(arg)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Plan = plan
+	}
+	return params.DestroyApplicationPlanResults{results}, nil
+}
+
+// DestroyPlan is not available in version 6 of the API.
+func (*APIv6) DestroyPlan(_, _ struct{}) {}
+
+func (api *API) destroyPlan(arg params.DestroyApplicationParams) (*params.DestroyApplicationPlan, error) {
+	tag, err := names.ParseApplicationTag(arg.ApplicationTag)
+	if err != nil {
+		return nil, err
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return nil, err
+	}
+	statePlan, err := app.DestroyPlan()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, err
+	}
+	var plan params.DestroyApplicationPlan
+	plan.DestroyedStorage, plan.DetachedStorage, err = api.unitsStorage(units, arg.DestroyStorage)
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range statePlan.Units {
+		plan.DestroyedUnits = append(plan.DestroyedUnits, params.Entity{tag.String()})
+	}
+	for _, tag := range statePlan.Relations {
+		plan.RemovedRelations = append(plan.RemovedRelations, params.Entity{tag.String()})
+	}
+	for _, tag := range statePlan.CrossModelRelations {
+		plan.RemovedCrossModelRelations = append(plan.RemovedCrossModelRelations, params.Entity{tag.String()})
+	}
+	for _, tag := range statePlan.DestroyedMachines {
+		plan.DestroyedMachines = append(plan.DestroyedMachines, params.Entity{tag.String()})
+	}
+	return &plan, nil
+}
+
+// unitsStorage returns the tags of the storage attached to the given
+// units that would be destroyed, and that would be detached and left
+// in the model, if the units were destroyed.
+func (api *API) unitsStorage(units []Unit, destroyStorage bool) (destroyed, detached []params.Entity, _ error) {
+	if api.backend.ModelType() != state.ModelTypeIAAS {
+		// Non-IAAS model; no need to deal with storage.
+		return nil, nil, nil
+	}
+	storageSeen := make(set.Tags)
+	for _, unit := range units {
+		storage, err := storagecommon.UnitStorage(api.backend, unit.UnitTag())
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// Filter out storage we've already seen. Shared
+		// storage may be attached to multiple units.
+		var unseen []state.StorageInstance
+		for _, stor := range storage {
+			storageTag := stor.StorageTag()
+			if storageSeen.Contains(storageTag) {
+				continue
+			}
+			storageSeen.Add(storageTag)
+			unseen = append(unseen, stor)
+		}
+		storage = unseen
+
+		if destroyStorage {
+			for _, s := range storage {
+				destroyed = append(destroyed, params.Entity{s.StorageTag().String()})
+			}
+		} else {
+			unitDestroyed, unitDetached, err := storagecommon.ClassifyDetachedStorage(
+				api.backend, storage,
+			)
+			if err != nil {
+				return nil, nil, err
+			}
+			destroyed = append(destroyed, unitDestroyed...)
+			detached = append(detached, unitDetached...)
+		}
+	}
+	return destroyed, detached, nil
+}
+
 // DestroyConsumedApplications removes a given set of consumed (remote) applications.
 func (api *API) DestroyConsumedApplications(args params.DestroyConsumedApplicationsParams) (params.ErrorResults, error) {
 	if err := api.checkCanWrite(); err != nil {
@@ -1078,6 +1628,8 @@ func (api *API) DestroyConsumedApplications(args params.DestroyConsumedApplicati
 }
 
 // GetConstraints returns the constraints for a given application.
+//
+//juju:readonly
 func (api *API) GetConstraints(args params.Entities) (params.ApplicationGetConstraintsResults, error) {
 	if err := api.checkCanRead(); err != nil {
 		return params.ApplicationGetConstraintsResults{}, errors.Trace(err)
@@ -1110,12 +1662,169 @@ func (api *API) getConstraints(entity string) (constraints.Value, error) {
 	}
 }
 
+// GetConstraintsDetail returns the effective constraints of the given
+// applications and units, along with where each constraint attribute's
+// value came from: the model, the application or the unit.
+//
+//juju:readonly
+func (api *API) GetConstraintsDetail(args params.Entities) (params.ConstraintsDetailResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.ConstraintsDetailResults{}, errors.Trace(err)
+	}
+	results := params.ConstraintsDetailResults{
+		Results: make([]params.ConstraintsDetailResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		cons, trace, err := api.traceConstraints(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Constraints = cons
+		for _, attr := range trace {
+			detail := params.ConstraintAttributeDetail{
+				Attribute: attr.Attribute,
+				Value:     attr.Value,
+				Source:    attr.Source,
+			}
+			for _, o := range attr.Overridden {
+				detail.Overridden = append(detail.Overridden, params.ConstraintOverride{
+					Source: o.Source,
+					Value:  o.Value,
+					Reason: o.Reason,
+				})
+			}
+			results.Results[i].Attributes = append(results.Results[i].Attributes, detail)
+		}
+	}
+	return results, nil
+}
+
+func (api *API) traceConstraints(entity string) (constraints.Value, []constraints.AttributeTrace, error) {
+	tag, err := names.ParseTag(entity)
+	if err != nil {
+		return constraints.Value{}, nil, err
+	}
+	switch kind := tag.Kind(); kind {
+	case names.ApplicationTagKind:
+		app, err := api.backend.Application(tag.Id())
+		if err != nil {
+			return constraints.Value{}, nil, err
+		}
+		return app.ConstraintsTrace()
+	case names.UnitTagKind:
+		unit, err := api.backend.Unit(tag.Id())
+		if err != nil {
+			return constraints.Value{}, nil, err
+		}
+		return unit.ConstraintsTrace()
+	default:
+		return constraints.Value{}, nil, errors.Errorf("unexpected tag type, expected application or unit, got %s", kind)
+	}
+}
+
+// GetConstraintsDetail is not available in version 11 of the API.
+//
+//juju:readonly
+func (*APIv11) GetConstraintsDetail(_, _ struct{}) {}
+
+// PlacementPlan reports where the units of each given prospective
+// deployment would be placed, given the model's current machines,
+// without deploying anything.
+func (api *API) PlacementPlan(args params.PlacementPlanArgs) (params.PlacementPlanResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.PlacementPlanResults{}, errors.Trace(err)
+	}
+	results := params.PlacementPlanResults{
+		Results: make([]params.PlacementPlanResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		units, err := api.placementPlan(arg)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Units = units
+	}
+	return results, nil
+}
+
+func (api *API) placementPlan(arg params.PlacementPlanArg) ([]params.PlannedUnitPlacement, error) {
+	planArgs := state.PlacementPlanParams{
+		Series:    arg.Series,
+		NumUnits:  arg.NumUnits,
+		Placement: arg.Placement,
+	}
+	if arg.Constraints != nil {
+		planArgs.Constraints = *arg.Constraints
+	}
+	if arg.ApplicationName != "" {
+		app, err := api.backend.Application(arg.ApplicationName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if planArgs.Series == "" {
+			planArgs.Series = app.Series()
+		}
+		if arg.Constraints == nil {
+			if planArgs.Constraints, err = app.Constraints(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+	}
+	plan, err := api.backend.PlanPlacement(planArgs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units := make([]params.PlannedUnitPlacement, len(plan))
+	for i, p := range plan {
+		units[i] = params.PlannedUnitPlacement{
+			MachineId:     p.MachineId,
+			NewMachine:    p.NewMachine,
+			ContainerType: string(p.ContainerType),
+			Directive:     p.Directive,
+			Zone:          p.Zone,
+			Constraints:   p.Constraints,
+			Warnings:      p.Warnings,
+		}
+		if p.Err != nil {
+			units[i].Error = common.ServerError(p.Err)
+		}
+	}
+	return units, nil
+}
+
+// PlacementPlan is not available in version 12 of the API.
+func (*APIv12) PlacementPlan(_, _ struct{}) {}
+
+// PlacementMachines returns the machines of the model that can host
+// units, with the load that the units already on them put on them.
+func (api *API) PlacementMachines() (params.PlacementMachinesResult, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.PlacementMachinesResult{}, errors.Trace(err)
+	}
+	loads, err := api.backend.PlacementMachines()
+	if err != nil {
+		return params.PlacementMachinesResult{}, errors.Trace(err)
+	}
+	result := params.PlacementMachinesResult{
+		Machines: make([]params.MachineLoad, len(loads)),
+	}
+	for i, load := range loads {
+		result.Machines[i] = common.MachineLoad(load)
+	}
+	return result, nil
+}
+
+// PlacementMachines is not available in version 14 of the API.
+func (*APIv14) PlacementMachines(_, _ struct{}) {}
+
 // SetConstraints sets the constraints for a given application.
 func (api *API) SetConstraints(args params.SetConstraints) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
 	}
-	if err := api.check.ChangeAllowed(); err != nil {
+	if err := api.check.ChangeAllowedFor(names.NewApplicationTag(args.ApplicationName)); err != nil {
 		return errors.Trace(err)
 	}
 	app, err := api.backend.Application(args.ApplicationName)
@@ -1157,6 +1866,10 @@ func (api *API) AddRelation(args params.AddRelation) (_ params.AddRelationResult
 	if err != nil {
 		return params.AddRelationResults{}, errors.Trace(err)
 	}
+	warnings, err := api.checkRelationInterfaces(inEps)
+	if err != nil {
+		return params.AddRelationResults{}, errors.Trace(err)
+	}
 	if rel, err = api.backend.AddRelation(inEps...); err != nil {
 		return params.AddRelationResults{}, errors.Trace(err)
 	}
@@ -1179,7 +1892,12 @@ func (api *API) AddRelation(args params.AddRelation) (_ params.AddRelationResult
 			Scope:     string(outEp.Relation.Scope),
 		}
 	}
-	return params.AddRelationResults{Endpoints: outEps}, nil
+	entities := []names.Tag{rel.Tag()}
+	for _, inEp := range inEps {
+		entities = append(entities, names.NewApplicationTag(inEp.ApplicationName))
+	}
+	api.recordEvent(state.EventRelationCreated, rel.Tag().Id(), entities...)
+	return params.AddRelationResults{Endpoints: outEps, Warnings: warnings}, nil
 }
 
 // DestroyRelation removes the relation between the
@@ -1220,17 +1938,20 @@ func (api *API) SetRelationsSuspended(args params.RelationSuspendedArgs) (params
 		return statusResults, errors.Trace(err)
 	}
 
-	changeOne := func(arg params.RelationSuspendedArg) error {
+	// changeOne sets whether the relation is suspended, and returns the
+	// status to set on it, if any. The statuses of all the changed
+	// relations are then written in a single transaction.
+	changeOne := func(arg params.RelationSuspendedArg) (*state.StatusUpdate, error) {
 		rel, err := api.backend.Relation(arg.RelationId)
 		if err != nil {
-			return errors.Trace(err)
+			return nil, errors.Trace(err)
 		}
 		if rel.Suspended() == arg.Suspended {
-			return nil
+			return nil, nil
 		}
 		_, err = api.backend.OfferConnectionForRelation(rel.Tag().Id())
 		if errors.IsNotFound(err) {
-			return errors.Errorf("cannot set suspend status for %q which is not associated with an offer", rel.Tag().Id())
+			return nil, errors.Errorf("cannot set suspend status for %q which is not associated with an offer", rel.Tag().Id())
 		}
 		message := arg.Message
 		if !arg.Suspended {
@@ -1238,22 +1959,39 @@ func (api *API) SetRelationsSuspended(args params.RelationSuspendedArgs) (params
 		}
 		err = rel.SetSuspended(arg.Suspended, message)
 		if err != nil {
-			return errors.Trace(err)
+			return nil, errors.Trace(err)
 		}
 
 		statusValue := status.Joining
 		if arg.Suspended {
 			statusValue = status.Suspending
 		}
-		return rel.SetStatus(status.StatusInfo{
-			Status:  status.Status(statusValue),
-			Message: arg.Message,
-		})
+		// TODO(perrito666) 2016-05-02 lp:1558657
+		sInfo, err := common.EntityStatusInfo(params.EntityStatusArgs{
+			Status: statusValue.String(),
+			Info:   arg.Message,
+		}, time.Now())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return &state.StatusUpdate{Entity: rel, Info: sInfo}, nil
 	}
 	results := make([]params.ErrorResult, len(args.Args))
+	var updates []state.StatusUpdate
+	var indices []int
 	for i, arg := range args.Args {
-		err := changeOne(arg)
-		results[i].Error = common.ServerError(err)
+		update, err := changeOne(arg)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		if update != nil {
+			updates = append(updates, *update)
+			indices = append(indices, i)
+		}
+	}
+	for j, err := range state.SetStatuses(updates) {
+		results[indices[j]].Error = common.ServerError(err)
 	}
 	statusResults.Results = results
 	return statusResults, nil
@@ -1446,9 +2184,13 @@ func (api *API) maybeUpdateExistingApplicationEndpoints(
 func (u *APIv4) UpdateApplicationSeries(_, _ struct{}) {}
 
 // GetConfig isn't on the V4 API.
+//
+//juju:readonly
 func (u *APIv4) GetConfig(_, _ struct{}) {}
 
 // GetConstraints returns the v4 implementation of GetConstraints.
+//
+//juju:readonly
 func (api *APIv4) GetConstraints(args params.GetApplicationConstraints) (params.GetConstraintsResults, error) {
 	if err := api.checkCanRead(); err != nil {
 		return params.GetConstraintsResults{}, errors.Trace(err)
@@ -327,8 +327,8 @@ func (s *applicationSuite) TestApplicationDeployWithStorage(c *gc.C) {
 		Applications: []params.ApplicationDeploy{args}},
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(results, gc.DeepEquals, params.ErrorResults{
-		Results: []params.ErrorResult{{Error: nil}},
+	c.Assert(results, gc.DeepEquals, params.DeployResults{
+		Results: []params.DeployResult{{}},
 	})
 	app := apiservertesting.AssertPrincipalServiceDeployed(c, s.State, "application", curl, false, ch, cons)
 	storageConstraintsOut, err := app.StorageConstraints()
@@ -403,8 +403,8 @@ func (s *applicationSuite) TestApplicationDeployDefaultFilesystemStorage(c *gc.C
 		Applications: []params.ApplicationDeploy{args}},
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(results, gc.DeepEquals, params.ErrorResults{
-		Results: []params.ErrorResult{{Error: nil}},
+	c.Assert(results, gc.DeepEquals, params.DeployResults{
+		Results: []params.DeployResult{{}},
 	})
 	app := apiservertesting.AssertPrincipalServiceDeployed(c, s.State, "application", curl, false, ch, cons)
 	storageConstraintsOut, err := app.StorageConstraints()
@@ -438,8 +438,8 @@ func (s *applicationSuite) TestApplicationDeploy(c *gc.C) {
 		Applications: []params.ApplicationDeploy{args}},
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(results, gc.DeepEquals, params.ErrorResults{
-		Results: []params.ErrorResult{{Error: nil}},
+	c.Assert(results, gc.DeepEquals, params.DeployResults{
+		Results: []params.DeployResult{{}},
 	})
 	app := apiservertesting.AssertPrincipalServiceDeployed(c, s.State, "application", curl, false, ch, cons)
 	units, err := app.AllUnits()
@@ -2029,6 +2029,24 @@ func (s *applicationSuite) TestApplicationExpose(c *gc.C) {
 	}
 }
 
+func (s *applicationSuite) TestApplicationExposeRecordsEvents(c *gc.C) {
+	s.AddTestingApplication(c, "dummy-application", s.AddTestingCharm(c, "dummy"))
+	err := s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: "dummy-application"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.applicationAPI.Unexpose(params.ApplicationUnexpose{ApplicationName: "dummy-application"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	events, err := s.State.ModelEvents(state.ModelEventsFilter{
+		Entity: "application-dummy-application",
+		Limit:  10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, gc.HasLen, 2)
+	c.Assert(events[0].Kind, gc.Equals, state.EventApplicationExposed)
+	c.Assert(events[0].Actor, gc.Equals, s.AdminUserTag(c).String())
+	c.Assert(events[1].Kind, gc.Equals, state.EventApplicationUnexposed)
+}
+
 func (s *applicationSuite) setupApplicationExpose(c *gc.C) {
 	charm := s.AddTestingCharm(c, "dummy")
 	applicationNames := []string{"dummy-application", "exposed-application"}
@@ -2623,6 +2641,149 @@ func (s *applicationSuite) TestClientGetApplicationConstraints(c *gc.C) {
 		}})
 }
 
+func (s *applicationSuite) TestClientGetConstraintsDetail(c *gc.C) {
+	err := s.State.SetModelConstraints(constraints.MustParse("mem=4G"))
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:        "foo",
+		Constraints: constraints.MustParse("cores=2"),
+	})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	err = app.SetConstraints(constraints.MustParse("cores=4"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.applicationAPI.GetConstraintsDetail(params.Entities{
+		Entities: []params.Entity{
+			{"machine-0"}, {"application-foo"}, {"unit-foo-0"}, {"unit-foo-1"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	memDetail := params.ConstraintAttributeDetail{
+		Attribute: "mem",
+		Value:     "4096M",
+		Source:    "model",
+	}
+	c.Assert(results, jc.DeepEquals, params.ConstraintsDetailResults{
+		Results: []params.ConstraintsDetailResult{
+			{
+				Error: &params.Error{Message: `unexpected tag type, expected application or unit, got machine`},
+			}, {
+				Constraints: constraints.MustParse("mem=4G cores=4"),
+				Attributes: []params.ConstraintAttributeDetail{{
+					Attribute: "cores",
+					Value:     "4",
+					Source:    "application",
+				}, memDetail},
+			}, {
+				Constraints: constraints.MustParse("mem=4G cores=2"),
+				Attributes: []params.ConstraintAttributeDetail{{
+					Attribute: "cores",
+					Value:     "2",
+					Source:    "unit",
+					Overridden: []params.ConstraintOverride{{
+						Source: "application",
+						Value:  "4",
+						Reason: "overridden by unit constraints",
+					}},
+				}, memDetail},
+			}, {
+				Error: &params.Error{Message: `unit "foo/1" not found`, Code: "not found"},
+			},
+		}})
+}
+
+func (s *applicationSuite) TestClientPlacementPlan(c *gc.C) {
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:        "foo",
+		Constraints: constraints.MustParse("cores=2"),
+	})
+	hc := instance.MustParseHardware("cores=4")
+	s.Factory.MakeMachine(c, &factory.MachineParams{
+		Series:          "quantal",
+		Jobs:            []state.MachineJob{state.JobHostUnits},
+		Characteristics: &hc,
+	})
+
+	modelUUID := s.State.ModelUUID()
+	results, err := s.applicationAPI.PlacementPlan(params.PlacementPlanArgs{
+		Args: []params.PlacementPlanArg{{
+			ApplicationName: "foo",
+			NumUnits:        2,
+		}, {
+			Series:   "quantal",
+			NumUnits: 2,
+			Placement: []*instance.Placement{
+				{Scope: string(instance.LXD), Directive: "0"},
+				{Scope: modelUUID, Directive: "zone=a"},
+			},
+		}, {
+			ApplicationName: "wat",
+			NumUnits:        1,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.PlacementPlanResults{
+		Results: []params.PlacementPlanResult{{
+			Units: []params.PlannedUnitPlacement{{
+				MachineId: "0",
+			}, {
+				NewMachine:  true,
+				Constraints: constraints.MustParse("cores=2"),
+			}},
+		}, {
+			Units: []params.PlannedUnitPlacement{{
+				MachineId:     "0",
+				ContainerType: "lxd",
+			}, {
+				NewMachine: true,
+				Directive:  "zone=a",
+				Error:      &params.Error{Message: "zone=a placement is invalid"},
+			}},
+		}, {
+			Error: &params.Error{Message: `application "wat" not found`, Code: "not found"},
+		}},
+	})
+}
+
+func (s *applicationSuite) TestClientPlacementMachines(c *gc.C) {
+	hc := instance.MustParseHardware("mem=1G availability-zone=zone-a")
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Series:          "quantal",
+		Jobs:            []state.MachineJob{state.JobHostUnits},
+		Characteristics: &hc,
+	})
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Machine: machine})
+	err := unit.SetUtilization(state.UnitUtilization{MemoryBytes: 1000 * 1024 * 1024})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.applicationAPI.PlacementMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.PlacementMachinesResult{
+		Machines: []params.MachineLoad{{
+			MachineId:    machine.Id(),
+			Series:       "quantal",
+			Zone:         "zone-a",
+			Hardware:     &hc,
+			Units:        []string{unit.Name()},
+			Applications: []string{unit.ApplicationName()},
+			MemoryBytes:  1000 * 1024 * 1024,
+			Saturation:   []string{"units use 97% of the machine's 1024M memory"},
+		}},
+	})
+
+	results, err := s.applicationAPI.Deploy(params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			CharmURL:        "cs:quantal/application-name-1",
+			ApplicationName: "application-name",
+			NumUnits:        1,
+			Placement:       []*instance.Placement{instance.MustParsePlacement(machine.Id())},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodePlacementConflict)
+	c.Assert(results.Results[0].Error.Info.PlacementConflict.Load.Units, jc.DeepEquals, []string{unit.Name()})
+}
+
 func (s *applicationSuite) checkEndpoints(c *gc.C, mysqlAppName string, endpoints map[string]params.CharmRelation) {
 	c.Assert(endpoints["wordpress"], gc.DeepEquals, params.CharmRelation{
 		Name:      "db",
@@ -2694,6 +2855,18 @@ func (s *applicationSuite) assertAddRelation(c *gc.C, endpoints, viaCIDRs []stri
 func (s *applicationSuite) TestSuccessfullyAddRelation(c *gc.C) {
 	endpoints := []string{"wordpress", "mysql"}
 	s.assertAddRelation(c, endpoints, nil)
+
+	events, err := s.State.ModelEvents(state.ModelEventsFilter{Limit: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, gc.HasLen, 1)
+	c.Assert(events[0].Kind, gc.Equals, state.EventRelationCreated)
+	c.Assert(events[0].Actor, gc.Equals, s.AdminUserTag(c).String())
+	c.Assert(events[0].Message, gc.Equals, "wordpress:db mysql:server")
+	c.Assert(events[0].Entities, jc.DeepEquals, []string{
+		"relation-wordpress.db#mysql.server",
+		"application-wordpress",
+		"application-mysql",
+	})
 }
 
 func (s *applicationSuite) TestBlockDestroyAddRelation(c *gc.C) {
@@ -4,6 +4,8 @@
 package application_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -13,10 +15,15 @@ import (
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon.v1"
 
+	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facades/client/application"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/charmrefresh"
 	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/relation"
+	"github.com/juju/juju/core/trust"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
@@ -376,6 +383,252 @@ func (s *ApplicationSuite) TestDestroyApplicationNotFound(c *gc.C) {
 	})
 }
 
+func (s *ApplicationSuite) TestDestroyApplicationBlocked(c *gc.C) {
+	s.blockChecker.SetErrors(nil, common.OperationBlockedError("production database"))
+	results, err := s.api.DestroyApplication(params.DestroyApplicationsParams{
+		Applications: []params.DestroyApplicationParams{
+			{ApplicationTag: "application-postgresql"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeOperationBlocked)
+	s.blockChecker.CheckCalls(c, []testing.StubCall{
+		{"RemoveAllowed", nil},
+		{"RemoveAllowedFor", []interface{}{names.NewApplicationTag("postgresql")}},
+	})
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestDestroyApplicationInMaintenance(c *gc.C) {
+	s.backend.maintenance = map[names.Tag]string{
+		names.NewUnitTag("postgresql/1"): "replacing disks",
+	}
+	results, err := s.api.DestroyApplication(params.DestroyApplicationsParams{
+		Applications: []params.DestroyApplicationParams{{
+			ApplicationTag: "application-postgresql",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeInMaintenance)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "unit postgresql/1 is in maintenance: replacing disks")
+	s.backend.CheckCallNames(c, "Application")
+}
+
+func (s *ApplicationSuite) TestSetRelationBrokenBarrier(c *gc.C) {
+	err := s.api.SetRelationBrokenBarrier(params.ApplicationRelationBrokenBarrier{
+		ApplicationName: "postgresql",
+		Enabled:         true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.blockChecker.CheckCallNames(c, "ChangeAllowedFor")
+	app := s.backend.applications["postgresql"]
+	app.CheckCalls(c, []testing.StubCall{
+		{"SetRelationBrokenBarrier", []interface{}{true}},
+	})
+}
+
+func (s *ApplicationSuite) TestSetRelationBrokenBarrierBlocked(c *gc.C) {
+	s.blockChecker.SetErrors(common.OperationBlockedError("production database"))
+	err := s.api.SetRelationBrokenBarrier(params.ApplicationRelationBrokenBarrier{
+		ApplicationName: "postgresql",
+		Enabled:         true,
+	})
+	c.Assert(err, jc.Satisfies, params.IsCodeOperationBlocked)
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestUpdateStorageConstraints(c *gc.C) {
+	size := uint64(2048)
+	results, err := s.api.UpdateStorageConstraints(params.ApplicationStorageUpdateRequest{
+		ApplicationStorageUpdates: []params.ApplicationStorageUpdate{{
+			ApplicationTag: "application-postgresql",
+			StorageConstraints: map[string]params.StorageConstraints{
+				"pgdata": {Pool: "ebs", Size: &size},
+			},
+		}, {
+			ApplicationTag: "unit-postgresql-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `"unit-postgresql-0" is not a valid application tag`)
+	s.blockChecker.CheckCallNames(c, "ChangeAllowedFor")
+	app := s.backend.applications["postgresql"]
+	app.CheckCalls(c, []testing.StubCall{
+		{"UpdateStorageConstraints", []interface{}{map[string]state.StorageConstraints{
+			"pgdata": {Pool: "ebs", Size: 2048},
+		}}},
+	})
+}
+
+func (s *ApplicationSuite) TestUpdateStorageConstraintsBlocked(c *gc.C) {
+	s.blockChecker.SetErrors(common.OperationBlockedError("production database"))
+	results, err := s.api.UpdateStorageConstraints(params.ApplicationStorageUpdateRequest{
+		ApplicationStorageUpdates: []params.ApplicationStorageUpdate{{
+			ApplicationTag: "application-postgresql",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeOperationBlocked)
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestSetTrust(c *gc.C) {
+	results, err := s.api.SetTrust(params.ApplicationTrustArgs{
+		Args: []params.ApplicationTrust{{
+			ApplicationName: "postgresql",
+			Level:           "read-only",
+		}, {
+			ApplicationName: "postgresql",
+			Level:           "total",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `trust level "total" not valid`)
+	s.blockChecker.CheckCallNames(c, "ChangeAllowedFor")
+	s.backend.applications["postgresql"].CheckCalls(c, []testing.StubCall{
+		{"SetTrustLevel", []interface{}{trust.ReadOnly}},
+	})
+}
+
+func (s *ApplicationSuite) TestSetRefreshPolicy(c *gc.C) {
+	s.backend.applications["postgresql"].curl = charm.MustParseURL("cs:postgresql-42")
+	results, err := s.api.SetRefreshPolicy(params.ApplicationRefreshPolicyArgs{
+		Args: []params.ApplicationRefreshPolicy{{
+			ApplicationName: "postgresql",
+			Policy:          "patch-only",
+		}, {
+			ApplicationName: "postgresql",
+			Policy:          "sometimes",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `refresh policy "sometimes" not valid`)
+	s.blockChecker.CheckCallNames(c, "ChangeAllowedFor")
+	s.backend.applications["postgresql"].CheckCalls(c, []testing.StubCall{
+		{"SetRefreshPolicy", []interface{}{charmrefresh.PatchOnly}},
+	})
+}
+
+func (s *ApplicationSuite) TestSetRefreshPolicyLocalCharm(c *gc.C) {
+	s.backend.applications["postgresql"].curl = charm.MustParseURL("local:quantal/postgresql-1")
+	results, err := s.api.SetRefreshPolicy(params.ApplicationRefreshPolicyArgs{
+		Args: []params.ApplicationRefreshPolicy{{
+			ApplicationName: "postgresql",
+			Policy:          "any",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), gc.ErrorMatches, "refreshing local charms automatically not supported")
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestSetTrustBlocked(c *gc.C) {
+	s.blockChecker.SetErrors(common.OperationBlockedError("production database"))
+	results, err := s.api.SetTrust(params.ApplicationTrustArgs{
+		Args: []params.ApplicationTrust{{
+			ApplicationName: "postgresql",
+			Level:           "full",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeOperationBlocked)
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestSetTrustPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.SetTrust(params.ApplicationTrustArgs{
+		Args: []params.ApplicationTrust{{
+			ApplicationName: "postgresql",
+			Level:           "full",
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestDestroyPlan(c *gc.C) {
+	app := s.backend.applications["postgresql"]
+	app.destroyPlan = state.ApplicationDestroyPlan{
+		Units: []names.UnitTag{
+			names.NewUnitTag("postgresql/0"),
+			names.NewUnitTag("postgresql/1"),
+		},
+		Relations: []names.RelationTag{
+			names.NewRelationTag("wordpress:db postgresql:server"),
+			names.NewRelationTag("remote-app:db postgresql:server"),
+		},
+		CrossModelRelations: []names.RelationTag{
+			names.NewRelationTag("remote-app:db postgresql:server"),
+		},
+		DestroyedMachines: []names.MachineTag{names.NewMachineTag("0")},
+	}
+	results, err := s.api.DestroyPlan(params.DestroyApplicationsParams{
+		Applications: []params.DestroyApplicationParams{{
+			ApplicationTag: "application-postgresql",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0], jc.DeepEquals, params.DestroyApplicationPlanResult{
+		Plan: &params.DestroyApplicationPlan{
+			DestroyedUnits: []params.Entity{
+				{Tag: "unit-postgresql-0"},
+				{Tag: "unit-postgresql-1"},
+			},
+			DetachedStorage: []params.Entity{
+				{Tag: "storage-pgdata-0"},
+			},
+			DestroyedStorage: []params.Entity{
+				{Tag: "storage-pgdata-1"},
+			},
+			RemovedRelations: []params.Entity{
+				{Tag: "relation-wordpress.db#postgresql.server"},
+				{Tag: "relation-remote-app.db#postgresql.server"},
+			},
+			RemovedCrossModelRelations: []params.Entity{
+				{Tag: "relation-remote-app.db#postgresql.server"},
+			},
+			DestroyedMachines: []params.Entity{
+				{Tag: "machine-0"},
+			},
+		},
+	})
+
+	// Nothing is destroyed.
+	for _, call := range s.backend.Calls() {
+		c.Assert(call.FuncName, gc.Not(gc.Equals), "ApplyOperation")
+	}
+	app.CheckCallNames(c, "DestroyPlan", "AllUnits")
+}
+
+func (s *ApplicationSuite) TestDestroyPlanNotFound(c *gc.C) {
+	delete(s.backend.applications, "postgresql")
+	results, err := s.api.DestroyPlan(params.DestroyApplicationsParams{
+		Applications: []params.DestroyApplicationParams{
+			{ApplicationTag: "application-postgresql"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0], jc.DeepEquals, params.DestroyApplicationPlanResult{
+		Error: &params.Error{
+			Code:    params.CodeNotFound,
+			Message: `application "postgresql" not found`,
+		},
+	})
+}
+
 func (s *ApplicationSuite) TestDestroyConsumedApplication(c *gc.C) {
 	results, err := s.api.DestroyConsumedApplications(params.DestroyConsumedApplicationsParams{
 		Applications: []params.DestroyConsumedApplicationParams{{ApplicationTag: "application-hosted-db2"}},
@@ -448,6 +701,114 @@ func (s *ApplicationSuite) TestDestroyUnit(c *gc.C) {
 	})
 }
 
+func (s *ApplicationSuite) TestDestroyUnitBlocked(c *gc.C) {
+	s.blockChecker.SetErrors(nil, common.OperationBlockedError("production database"))
+	results, err := s.api.DestroyUnit(params.DestroyUnitsParams{
+		Units: []params.DestroyUnitParams{{UnitTag: "unit-postgresql-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeOperationBlocked)
+	s.blockChecker.CheckCalls(c, []testing.StubCall{
+		{"RemoveAllowed", nil},
+		{"RemoveAllowedFor", []interface{}{names.NewApplicationTag("postgresql")}},
+	})
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestDestroyUnitInMaintenance(c *gc.C) {
+	s.backend.maintenance = map[names.Tag]string{
+		names.NewApplicationTag("postgresql"): "database migration",
+	}
+	results, err := s.api.DestroyUnit(params.DestroyUnitsParams{
+		Units: []params.DestroyUnitParams{{UnitTag: "unit-postgresql-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeInMaintenance)
+	s.backend.CheckNoCalls(c)
+
+	results, err = s.api.DestroyUnit(params.DestroyUnitsParams{
+		Units: []params.DestroyUnitParams{{UnitTag: "unit-postgresql-0", Force: true}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+}
+
+func (s *ApplicationSuite) TestDestroyUnitDrain(c *gc.C) {
+	drainTimeout := 5 * time.Minute
+	results, err := s.api.DestroyUnit(params.DestroyUnitsParams{
+		Units: []params.DestroyUnitParams{{
+			UnitTag:      "unit-postgresql-1",
+			DrainTimeout: &drainTimeout,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.DestroyUnitResult{{
+		Info: &params.DestroyUnitInfo{},
+	}})
+	s.backend.CheckCallNames(c, "Unit", "UnitStorageAttachments", "ApplyOperation")
+	s.backend.CheckCall(c, 2, "ApplyOperation", &state.DestroyUnitOperation{
+		DrainTimeout: drainTimeout,
+	})
+}
+
+func (s *ApplicationSuite) TestDestroyUnitInvalidDrainTimeout(c *gc.C) {
+	var drainTimeout time.Duration
+	results, err := s.api.DestroyUnit(params.DestroyUnitsParams{
+		Units: []params.DestroyUnitParams{{
+			UnitTag:      "unit-postgresql-1",
+			DrainTimeout: &drainTimeout,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "drain timeout 0s not valid")
+}
+
+func (s *ApplicationSuite) TestDestroyUnitForce(c *gc.C) {
+	unit := &s.backend.applications["postgresql"].units[1]
+	unit.agentStatus = status.StatusInfo{
+		Status:  status.Error,
+		Message: `hook failed: "config-changed"`,
+	}
+	drainTimeout := 5 * time.Minute
+	results, err := s.api.DestroyUnit(params.DestroyUnitsParams{
+		Units: []params.DestroyUnitParams{{
+			UnitTag:      "unit-postgresql-1",
+			DrainTimeout: &drainTimeout,
+			Force:        true,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.DestroyUnitResult{{
+		Info: &params.DestroyUnitInfo{
+			SkippedSteps: []string{
+				"drain hook",
+				`retry of failed hook (hook failed: "config-changed")`,
+			},
+		},
+	}})
+	unit.CheckCall(c, 4, "SetResolved", state.ResolvedNoHooks)
+	s.backend.CheckCall(c, 2, "ApplyOperation", &state.DestroyUnitOperation{})
+}
+
+func (s *ApplicationSuite) TestDestroyUnitForceNothingSkipped(c *gc.C) {
+	results, err := s.api.DestroyUnit(params.DestroyUnitsParams{
+		Units: []params.DestroyUnitParams{{
+			UnitTag: "unit-postgresql-1",
+			Force:   true,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.DestroyUnitResult{{
+		Info: &params.DestroyUnitInfo{},
+	}})
+	unit := &s.backend.applications["postgresql"].units[1]
+	unit.CheckCallNames(c, "IsPrincipal", "DestroyOperation", "AgentStatus")
+}
+
 func (s *ApplicationSuite) TestDeployAttachStorage(c *gc.C) {
 	args := params.ApplicationsDeploy{
 		Applications: []params.ApplicationDeploy{{
@@ -515,6 +876,49 @@ func (s *ApplicationSuite) TestAddUnits(c *gc.C) {
 	app := s.backend.applications["postgresql"]
 	app.CheckCall(c, 0, "AddUnit", state.AddUnitParams{})
 	app.addedUnit.CheckCall(c, 0, "AssignWithPolicy", state.AssignCleanEmpty)
+	c.Assert(s.backend.events, jc.DeepEquals, []state.ModelEvent{{
+		Kind:     state.EventUnitAdded,
+		Actor:    "user-admin",
+		Entities: []string{"unit-postgresql-99", "application-postgresql"},
+	}})
+}
+
+func (s *ApplicationSuite) TestAddUnitsChecksQuotas(c *gc.C) {
+	app := s.backend.applications["postgresql"]
+	app.constraints = constraints.MustParse("cores=2")
+	app.storageConstraints = map[string]state.StorageConstraints{
+		"data": {Count: 1},
+		"logs": {Count: 2},
+	}
+	_, err := s.api.AddUnits(params.AddApplicationUnits{
+		ApplicationName: "postgresql",
+		NumUnits:        2,
+		Placement:       []*instance.Placement{{Scope: instance.MachineScope, Directive: "0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.backend.quotaRequests, jc.DeepEquals, []state.ModelResources{{
+		Machines: 1,
+		Cores:    2,
+		Units:    2,
+		Storage:  6,
+	}})
+}
+
+func (s *ApplicationSuite) TestAddUnitsQuotaExceeded(c *gc.C) {
+	s.backend.quotaErr = &state.ErrQuotaExceeded{
+		Resource:  state.QuotaUnits,
+		Limit:     3,
+		Used:      3,
+		Requested: 1,
+	}
+	_, err := s.api.AddUnits(params.AddApplicationUnits{
+		ApplicationName: "postgresql",
+		NumUnits:        1,
+	})
+	c.Assert(err, gc.ErrorMatches, "model quota exceeded: 1 units requested, 3 of 3 already in use")
+	c.Assert(err, jc.Satisfies, state.IsQuotaExceededError)
+	app := s.backend.applications["postgresql"]
+	app.CheckNoCalls(c)
 }
 
 func (s *ApplicationSuite) TestAddUnitsCAASModel(c *gc.C) {
@@ -600,6 +1004,7 @@ func (s *ApplicationSuite) TestSetRelationSuspended(c *gc.C) {
 	c.Assert(s.relation.suspendedReason, gc.Equals, "message")
 	c.Assert(s.relation.status, gc.Equals, status.Suspending)
 	c.Assert(s.relation.message, gc.Equals, "message")
+	c.Assert(s.relation.since, gc.NotNil)
 }
 
 func (s *ApplicationSuite) TestSetRelationSuspendedNoOp(c *gc.C) {
@@ -1057,3 +1462,85 @@ func (s *ApplicationSuite) TestRemoteRelationDisAllowedCIDR(c *gc.C) {
 	_, err := s.api.AddRelation(params.AddRelation{Endpoints: endpoints, ViaCIDRs: []string{"0.0.0.0/0"}})
 	c.Assert(err, gc.ErrorMatches, `CIDR "0.0.0.0/0" not allowed`)
 }
+
+func (s *ApplicationSuite) setupVersionedEndpoints(mode relation.InterfaceCheckMode) {
+	s.backend.interfaceCheck = mode
+	pgEndpoint := state.Endpoint{
+		ApplicationName: "postgresql",
+		Relation:        charm.Relation{Name: "db", Interface: "pgsql", Role: charm.RoleProvider},
+	}
+	wpEndpoint := state.Endpoint{
+		ApplicationName: "wordpress",
+		Relation:        charm.Relation{Name: "db", Interface: "pgsql", Role: charm.RoleRequirer},
+	}
+	s.endpoints = []state.Endpoint{pgEndpoint, wpEndpoint}
+
+	pg := s.backend.applications["postgresql"]
+	pg.endpoints = []state.Endpoint{pgEndpoint}
+	pg.charm.interfaceVersions = map[string]relation.InterfaceVersion{
+		"db": {Version: "2"},
+	}
+	s.backend.applications["wordpress"] = &mockApplication{
+		name:      "wordpress",
+		endpoints: []state.Endpoint{wpEndpoint},
+		charm: &mockCharm{
+			interfaceVersions: map[string]relation.InterfaceVersion{
+				"db": {Version: "1"},
+			},
+		},
+	}
+}
+
+func (s *ApplicationSuite) TestAddRelationInterfaceVersionsWarn(c *gc.C) {
+	s.setupVersionedEndpoints(relation.InterfaceCheckWarn)
+	results, err := s.api.AddRelation(params.AddRelation{Endpoints: []string{"postgresql", "wordpress"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Warnings, jc.DeepEquals, []string{
+		`postgresql:db implements version "2" of interface "pgsql", which is not known to be compatible with version "1" implemented by wordpress:db`,
+	})
+	s.backend.CheckCallNames(c, "InferEndpoints", "Application", "Application", "AddRelation", "SaveEgressNetworks")
+}
+
+func (s *ApplicationSuite) TestAddRelationInterfaceVersionsKnownCompatible(c *gc.C) {
+	s.setupVersionedEndpoints(relation.InterfaceCheckBlock)
+	s.backend.interfaceSchemas = []relation.InterfaceSchema{
+		{Interface: "pgsql", Version: "2", Compatible: []string{"1"}},
+	}
+	results, err := s.api.AddRelation(params.AddRelation{Endpoints: []string{"postgresql", "wordpress"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Warnings, gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestAddRelationInterfaceVersionsBlock(c *gc.C) {
+	s.setupVersionedEndpoints(relation.InterfaceCheckBlock)
+	_, err := s.api.AddRelation(params.AddRelation{Endpoints: []string{"postgresql", "wordpress"}})
+	c.Assert(err, gc.ErrorMatches, `cannot relate incompatible endpoints: postgresql:db implements version "2" .*`)
+	s.backend.CheckCallNames(c, "InferEndpoints", "Application", "Application")
+}
+
+func (s *ApplicationSuite) TestAddRelationInterfaceVersionsOff(c *gc.C) {
+	s.setupVersionedEndpoints(relation.InterfaceCheckOff)
+	results, err := s.api.AddRelation(params.AddRelation{Endpoints: []string{"postgresql", "wordpress"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Warnings, gc.HasLen, 0)
+	s.backend.CheckCallNames(c, "InferEndpoints", "AddRelation", "SaveEgressNetworks")
+}
+
+func (s *ApplicationSuite) TestDeployInterfaceVersionWarnings(c *gc.C) {
+	s.setupVersionedEndpoints(relation.InterfaceCheckBlock)
+	results, err := s.api.Deploy(params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "postgresql",
+			CharmURL:        "local:postgresql-0",
+			NumUnits:        1,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.DeployResults{
+		Results: []params.DeployResult{{
+			Warnings: []string{
+				`postgresql:db implements version "2" of interface "pgsql", which is not known to be compatible with version "1" implemented by wordpress:db; relating them will be refused`,
+			},
+		}},
+	})
+}
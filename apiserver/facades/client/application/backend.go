@@ -11,7 +11,10 @@ import (
 
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/charmrefresh"
 	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/relation"
+	"github.com/juju/juju/core/trust"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
@@ -25,9 +28,14 @@ type Backend interface {
 	storagecommon.StorageInterface
 
 	AllModelUUIDs() ([]string, error)
+	AllApplications() ([]Application, error)
 	Application(string) (Application, error)
 	ApplyOperation(state.ModelOperation) error
 	AddApplication(state.AddApplicationArgs) (Application, error)
+	AddModelEvent(state.ModelEvent) error
+	CheckModelQuotas(state.ModelResources) error
+	CheckNotInMaintenance(...names.Tag) error
+	CheckPlacement(string, *instance.Placement) error
 	RemoteApplication(string) (RemoteApplication, error)
 	AddRemoteApplication(state.AddRemoteApplicationParams) (RemoteApplication, error)
 	AddRelation(...state.Endpoint) (Relation, error)
@@ -35,6 +43,8 @@ type Backend interface {
 	EndpointsRelation(...state.Endpoint) (Relation, error)
 	Relation(int) (Relation, error)
 	InferEndpoints(...string) ([]state.Endpoint, error)
+	InterfaceSchemas(string) ([]relation.InterfaceSchema, error)
+	InterfaceVersionCheck() (relation.InterfaceCheckMode, error)
 	Machine(string) (Machine, error)
 	ModelTag() names.ModelTag
 	ModelType() state.ModelType
@@ -43,6 +53,8 @@ type Backend interface {
 	ControllerTag() names.ControllerTag
 	Resources() (Resources, error)
 	OfferConnectionForRelation(string) (OfferConnection, error)
+	PlacementMachines() ([]state.MachineLoad, error)
+	PlanPlacement(state.PlacementPlanParams) ([]state.PlannedPlacement, error)
 	SaveEgressNetworks(relationKey string, cidrs []string) (state.RelationNetworks, error)
 }
 
@@ -52,6 +64,8 @@ type Backend interface {
 type BlockChecker interface {
 	ChangeAllowed() error
 	RemoveAllowed() error
+	ChangeAllowedFor(names.Tag) error
+	RemoveAllowedFor(names.Tag) error
 }
 
 // Application defines a subset of the functionality provided by the
@@ -67,8 +81,10 @@ type Application interface {
 	ClearExposed() error
 	CharmConfig() (charm.Settings, error)
 	Constraints() (constraints.Value, error)
+	ConstraintsTrace() (constraints.Value, []constraints.AttributeTrace, error)
 	Destroy() error
 	DestroyOperation() *state.DestroyApplicationOperation
+	DestroyPlan() (state.ApplicationDestroyPlan, error)
 	Endpoints() ([]state.Endpoint, error)
 	IsPrincipal() bool
 	Series() string
@@ -77,8 +93,13 @@ type Application interface {
 	SetExposed() error
 	SetMetricCredentials([]byte) error
 	SetMinUnits(int) error
+	SetRefreshPolicy(charmrefresh.Policy) error
+	SetRelationBrokenBarrier(bool) error
+	SetTrustLevel(trust.Level) error
+	StorageConstraints() (map[string]state.StorageConstraints, error)
 	UpdateApplicationSeries(string, bool) error
 	UpdateCharmConfig(charm.Settings) error
+	UpdateStorageConstraints(map[string]state.StorageConstraints) error
 }
 
 // Charm defines a subset of the functionality provided by the
@@ -116,10 +137,14 @@ type Relation interface {
 // the same names.
 type Unit interface {
 	UnitTag() names.UnitTag
+	ConstraintsTrace() (constraints.Value, []constraints.AttributeTrace, error)
 	Destroy() error
 	DestroyOperation() *state.DestroyUnitOperation
 	IsPrincipal() bool
 	Life() state.Life
+	AgentStatus() (status.StatusInfo, error)
+	Resolved() state.ResolvedMode
+	SetResolved(state.ResolvedMode) error
 
 	AssignWithPolicy(state.AssignmentPolicy) error
 	AssignWithPlacement(*instance.Placement) error
@@ -215,6 +240,30 @@ func (s stateShim) Application(name string) (Application, error) {
 	return stateApplicationShim{a, s.State}, nil
 }
 
+func (s stateShim) AllApplications() ([]Application, error) {
+	apps, err := s.State.AllApplications()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Application, len(apps))
+	for i, a := range apps {
+		result[i] = stateApplicationShim{a, s.State}
+	}
+	return result, nil
+}
+
+func (s stateShim) InterfaceVersionCheck() (relation.InterfaceCheckMode, error) {
+	m, err := s.State.Model()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	cfg, err := m.ModelConfig()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return cfg.InterfaceVersionCheck(), nil
+}
+
 func (s stateShim) AddApplication(args state.AddApplicationArgs) (Application, error) {
 	a, err := s.State.AddApplication(args)
 	if err != nil {
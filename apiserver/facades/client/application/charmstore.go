@@ -67,7 +67,7 @@ func AddCharmWithAuthorization(st *state.State, args params.AddCharmWithAuthoriz
 	}
 
 	// Open a charm store client.
-	repo, err := openCSRepo(args)
+	repo, err := openCSRepo(st, args)
 	if err != nil {
 		return err
 	}
@@ -133,8 +133,8 @@ func AddCharmWithAuthorization(st *state.State, args params.AddCharmWithAuthoriz
 	return StoreCharmArchive(st, ca)
 }
 
-func openCSRepo(args params.AddCharmWithAuthorization) (charmrepo.Interface, error) {
-	csClient, err := openCSClient(args)
+func openCSRepo(st *state.State, args params.AddCharmWithAuthorization) (charmrepo.Interface, error) {
+	csClient, err := openCSClient(st, args)
 	if err != nil {
 		return nil, err
 	}
@@ -142,7 +142,7 @@ func openCSRepo(args params.AddCharmWithAuthorization) (charmrepo.Interface, err
 	return repo, nil
 }
 
-func openCSClient(args params.AddCharmWithAuthorization) (*csclient.Client, error) {
+func openCSClient(st *state.State, args params.AddCharmWithAuthorization) (*csclient.Client, error) {
 	csURL, err := url.Parse(csclient.ServerURL)
 	if err != nil {
 		return nil, err
@@ -152,6 +152,18 @@ func openCSClient(args params.AddCharmWithAuthorization) (*csclient.Client, erro
 		HTTPClient: httpbakery.NewHTTPClient(),
 	}
 
+	// Trust any CA certificates pinned for the charm store, such as
+	// that of a proxy intercepting the controller's TLS connections.
+	pool, err := st.TrustedCACertPool(state.TrustCharmStore)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if pool != nil {
+		tlsConfig := utils.SecureTLSConfig()
+		tlsConfig.RootCAs = pool
+		csParams.HTTPClient.Transport = utils.NewHttpTLSTransport(tlsConfig)
+	}
+
 	if args.CharmStoreMacaroon != nil {
 		// Set the provided charmstore authorizing macaroon
 		// as a cookie in the HTTP client.
@@ -194,6 +194,50 @@ func addUnits(
 	return units, nil
 }
 
+// unitResources returns the resources limited by model quotas that
+// adding n units, each with the given number of new storage instances,
+// would consume. If the units are to be assigned to machines, each
+// that is not placed on an existing machine is counted as needing a
+// new one with the supplied constraints, although it may instead be
+// assigned to a clean, empty machine.
+func unitResources(
+	n int,
+	placement []*instance.Placement,
+	assignUnits bool,
+	cons constraints.Value,
+	storagePerUnit int,
+) state.ModelResources {
+	resources := state.ModelResources{Units: n}
+	if storagePerUnit > 0 {
+		resources.Storage = n * storagePerUnit
+	}
+	if !assignUnits {
+		return resources
+	}
+	for i := 0; i < n; i++ {
+		if i < len(placement) && placedOnExistingMachine(placement[i]) {
+			continue
+		}
+		resources.Machines++
+		if cons.CpuCores != nil {
+			resources.Cores += int(*cons.CpuCores)
+		}
+	}
+	return resources
+}
+
+// placedOnExistingMachine reports whether the placement directive
+// places a unit on, or in a new container on, an existing machine.
+func placedOnExistingMachine(p *instance.Placement) bool {
+	if p.Scope == instance.MachineScope {
+		return true
+	}
+	if _, err := instance.ParseContainerType(p.Scope); err == nil {
+		return p.Directive != ""
+	}
+	return false
+}
+
 func stateStorageConstraints(cons map[string]storage.Constraints) map[string]state.StorageConstraints {
 	result := make(map[string]state.StorageConstraints)
 	for name, cons := range cons {
@@ -11,6 +11,8 @@ import (
 )
 
 // Get returns the charm configuration for an application.
+//
+//juju:readonly
 func (api *API) Get(args params.ApplicationGet) (params.ApplicationGetResults, error) {
 	return api.getCharmSettings(args, describe)
 }
@@ -18,6 +20,8 @@ func (api *API) Get(args params.ApplicationGet) (params.ApplicationGetResults, e
 // Get returns the charm configuration for an application.
 // This used the confusing "default" boolean to mean the value was set from
 // the charm defaults. Needs to be kept for backwards compatibility.
+//
+//juju:readonly
 func (api *APIv4) Get(args params.ApplicationGet) (params.ApplicationGetResults, error) {
 	return api.getCharmSettings(args, describeV4)
 }
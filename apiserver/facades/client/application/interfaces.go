@@ -0,0 +1,156 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/relation"
+	"github.com/juju/juju/state"
+)
+
+// checkRelationInterfaces checks that the endpoints about to be related
+// implement versions of their interface's schema that are known to be
+// compatible. Depending on the model's interface-version-check setting,
+// incompatible endpoints are reported as warnings, or refused with an
+// error.
+func (api *API) checkRelationInterfaces(eps []state.Endpoint) ([]string, error) {
+	if len(eps) != 2 || eps[0].Interface != eps[1].Interface {
+		return nil, nil
+	}
+	mode, err := api.backend.InterfaceVersionCheck()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if mode == relation.InterfaceCheckOff {
+		return nil, nil
+	}
+	var versions [2]*relation.InterfaceVersion
+	for i, ep := range eps {
+		app, err := api.backend.Application(ep.ApplicationName)
+		if errors.IsNotFound(err) {
+			// Remote applications do not declare versions.
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if versions[i], err = endpointInterfaceVersion(app, ep.Name); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if err := api.checkInterfaceVersions(eps[0], versions[0], eps[1], versions[1]); err != nil {
+		if mode == relation.InterfaceCheckBlock {
+			return nil, errors.Annotate(err, "cannot relate incompatible endpoints")
+		}
+		return []string{err.Error()}, nil
+	}
+	return nil, nil
+}
+
+// deployInterfaceWarnings returns warnings describing the endpoints of
+// the named, newly deployed, application that implement versions of an
+// interface's schema not known to be compatible with those implemented
+// by the endpoints of other applications in the model it could be
+// related to.
+func (api *API) deployInterfaceWarnings(appName string) ([]string, error) {
+	mode, err := api.backend.InterfaceVersionCheck()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if mode == relation.InterfaceCheckOff {
+		return nil, nil
+	}
+	app, err := api.backend.Application(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ch, _, err := app.Charm()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	versions, err := relation.ReadInterfaceVersions(ch)
+	if err != nil || len(versions) == 0 {
+		return nil, errors.Trace(err)
+	}
+	eps, err := app.Endpoints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	others, err := api.backend.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var warnings []string
+	for _, other := range others {
+		if other.Name() == appName {
+			continue
+		}
+		otherEps, err := other.Endpoints()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, ep := range eps {
+			v, ok := versions[ep.Name]
+			if !ok {
+				continue
+			}
+			for _, otherEp := range otherEps {
+				if !ep.CanRelateTo(otherEp) {
+					continue
+				}
+				otherV, err := endpointInterfaceVersion(other, otherEp.Name)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				err = api.checkInterfaceVersions(ep, &v, otherEp, otherV)
+				if err == nil {
+					continue
+				}
+				warning := err.Error()
+				if mode == relation.InterfaceCheckBlock {
+					warning = fmt.Sprintf("%s; relating them will be refused", warning)
+				}
+				warnings = append(warnings, warning)
+			}
+		}
+	}
+	return warnings, nil
+}
+
+// checkInterfaceVersions checks the given versions of the endpoints'
+// interface against each other, and the schemas known to the
+// controller.
+func (api *API) checkInterfaceVersions(
+	ep1 state.Endpoint, v1 *relation.InterfaceVersion,
+	ep2 state.Endpoint, v2 *relation.InterfaceVersion,
+) error {
+	if v1 == nil || v2 == nil {
+		return nil
+	}
+	known, err := api.backend.InterfaceSchemas(ep1.Interface)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return relation.CheckInterfaceVersions(ep1.Interface, ep1.String(), v1, ep2.String(), v2, known)
+}
+
+// endpointInterfaceVersion returns the version of its interface's
+// schema that the named endpoint of the application's charm
+// implements, or nil if it declares none.
+func endpointInterfaceVersion(app Application, endpoint string) (*relation.InterfaceVersion, error) {
+	ch, _, err := app.Charm()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	versions, err := relation.ReadInterfaceVersions(ch)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if v, ok := versions[endpoint]; ok {
+		return &v, nil
+	}
+	return nil, nil
+}
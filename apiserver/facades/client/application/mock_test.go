@@ -5,8 +5,10 @@ package application_test
 
 import (
 	"io"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/juju/errors"
 	jtesting "github.com/juju/testing"
@@ -16,7 +18,11 @@ import (
 	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/apiserver/facades/client/application"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/charmrefresh"
 	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/relation"
+	"github.com/juju/juju/core/trust"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
@@ -64,14 +70,19 @@ type mockCharm struct {
 	jtesting.Stub
 
 	charm.Charm
-	config *charm.Config
-	meta   *charm.Meta
+	config            *charm.Config
+	meta              *charm.Meta
+	interfaceVersions map[string]relation.InterfaceVersion
 }
 
 func (m *mockCharm) Meta() *charm.Meta {
 	return m.meta
 }
 
+func (m *mockCharm) InterfaceVersions() map[string]relation.InterfaceVersion {
+	return m.interfaceVersions
+}
+
 func (c *mockCharm) Config() *charm.Config {
 	c.MethodCall(c, "Config")
 	c.PopNoErr()
@@ -91,6 +102,10 @@ type mockApplication struct {
 	series      string
 	units       []mockUnit
 	addedUnit   mockUnit
+	destroyPlan state.ApplicationDestroyPlan
+
+	constraints        constraints.Value
+	storageConstraints map[string]state.StorageConstraints
 }
 
 func (m *mockApplication) Name() string {
@@ -135,6 +150,39 @@ func (a *mockApplication) DestroyOperation() *state.DestroyApplicationOperation
 	return &state.DestroyApplicationOperation{}
 }
 
+func (a *mockApplication) DestroyPlan() (state.ApplicationDestroyPlan, error) {
+	a.MethodCall(a, "DestroyPlan")
+	return a.destroyPlan, a.NextErr()
+}
+
+func (a *mockApplication) SetRelationBrokenBarrier(enabled bool) error {
+	a.MethodCall(a, "SetRelationBrokenBarrier", enabled)
+	return a.NextErr()
+}
+
+func (a *mockApplication) SetRefreshPolicy(policy charmrefresh.Policy) error {
+	a.MethodCall(a, "SetRefreshPolicy", policy)
+	return a.NextErr()
+}
+
+func (a *mockApplication) SetTrustLevel(level trust.Level) error {
+	a.MethodCall(a, "SetTrustLevel", level)
+	return a.NextErr()
+}
+
+func (a *mockApplication) UpdateStorageConstraints(cons map[string]state.StorageConstraints) error {
+	a.MethodCall(a, "UpdateStorageConstraints", cons)
+	return a.NextErr()
+}
+
+func (a *mockApplication) Constraints() (constraints.Value, error) {
+	return a.constraints, nil
+}
+
+func (a *mockApplication) StorageConstraints() (map[string]state.StorageConstraints, error) {
+	return a.storageConstraints, nil
+}
+
 func (a *mockApplication) AddUnit(args state.AddUnitParams) (application.Unit, error) {
 	a.MethodCall(a, "AddUnit", args)
 	if err := a.NextErr(); err != nil {
@@ -268,6 +316,42 @@ type mockBackend struct {
 	storageInstances           map[string]*mockStorage
 	storageInstanceFilesystems map[string]*mockFilesystem
 	controllers                map[string]crossmodel.ControllerInfo
+	quotaRequests              []state.ModelResources
+	quotaErr                   error
+	events                     []state.ModelEvent
+	maintenance                map[names.Tag]string
+	interfaceCheck             relation.InterfaceCheckMode
+	interfaceSchemas           []relation.InterfaceSchema
+}
+
+func (m *mockBackend) InterfaceVersionCheck() (relation.InterfaceCheckMode, error) {
+	if m.interfaceCheck == "" {
+		return relation.InterfaceCheckOff, nil
+	}
+	return m.interfaceCheck, nil
+}
+
+func (m *mockBackend) InterfaceSchemas(iface string) ([]relation.InterfaceSchema, error) {
+	var schemas []relation.InterfaceSchema
+	for _, s := range m.interfaceSchemas {
+		if s.Interface == iface {
+			schemas = append(schemas, s)
+		}
+	}
+	return schemas, nil
+}
+
+func (m *mockBackend) AllApplications() ([]application.Application, error) {
+	var appNames []string
+	for name := range m.applications {
+		appNames = append(appNames, name)
+	}
+	sort.Strings(appNames)
+	apps := make([]application.Application, len(appNames))
+	for i, name := range appNames {
+		apps[i] = m.applications[name]
+	}
+	return apps, nil
 }
 
 func (m *mockBackend) ControllerTag() names.ControllerTag {
@@ -302,9 +386,9 @@ func (m *mockBackend) Unit(name string) (application.Unit, error) {
 		}
 	}
 	if unitApp != nil {
-		for _, u := range unitApp.units {
-			if u.tag.Id() == name {
-				return &u, nil
+		for i := range unitApp.units {
+			if u := &unitApp.units[i]; u.tag.Id() == name {
+				return u, nil
 			}
 		}
 	}
@@ -322,6 +406,21 @@ func (m *mockBackend) InferEndpoints(endpoints ...string) ([]state.Endpoint, err
 	return nil, errors.Errorf("no relations found")
 }
 
+func (m *mockBackend) AddRelation(endpoints ...state.Endpoint) (application.Relation, error) {
+	m.MethodCall(m, "AddRelation", endpoints)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	rel := m.relations[123]
+	rel.endpoints = endpoints
+	return rel, nil
+}
+
+func (m *mockBackend) SaveEgressNetworks(relationKey string, cidrs []string) (state.RelationNetworks, error) {
+	m.MethodCall(m, "SaveEgressNetworks", relationKey, cidrs)
+	return nil, m.NextErr()
+}
+
 func (m *mockBackend) EndpointsRelation(endpoints ...state.Endpoint) (application.Relation, error) {
 	m.MethodCall(m, "EndpointsRelation", endpoints)
 	if err := m.NextErr(); err != nil {
@@ -491,6 +590,25 @@ func (m *mockBackend) Space(name string) (application.Space, error) {
 	return space, nil
 }
 
+func (m *mockBackend) AddModelEvent(event state.ModelEvent) error {
+	m.events = append(m.events, event)
+	return nil
+}
+
+func (m *mockBackend) CheckModelQuotas(requested state.ModelResources) error {
+	m.quotaRequests = append(m.quotaRequests, requested)
+	return m.quotaErr
+}
+
+func (m *mockBackend) CheckNotInMaintenance(tags ...names.Tag) error {
+	for _, tag := range tags {
+		if reason, ok := m.maintenance[tag]; ok {
+			return &state.ErrInMaintenance{Tag: tag, Reason: reason}
+		}
+	}
+	return nil
+}
+
 func (m *mockBackend) ModelUUID() string {
 	return m.modelUUID
 }
@@ -517,6 +635,16 @@ func (c *mockBlockChecker) RemoveAllowed() error {
 	return c.NextErr()
 }
 
+func (c *mockBlockChecker) ChangeAllowedFor(tag names.Tag) error {
+	c.MethodCall(c, "ChangeAllowedFor", tag)
+	return c.NextErr()
+}
+
+func (c *mockBlockChecker) RemoveAllowedFor(tag names.Tag) error {
+	c.MethodCall(c, "RemoveAllowedFor", tag)
+	return c.NextErr()
+}
+
 type mockRelation struct {
 	application.Relation
 	jtesting.Stub
@@ -524,18 +652,30 @@ type mockRelation struct {
 	tag             names.Tag
 	status          status.Status
 	message         string
+	since           *time.Time
 	suspended       bool
 	suspendedReason string
+	endpoints       []state.Endpoint
 }
 
 func (r *mockRelation) Tag() names.Tag {
 	return r.tag
 }
 
+func (r *mockRelation) Endpoint(appName string) (state.Endpoint, error) {
+	for _, ep := range r.endpoints {
+		if ep.ApplicationName == appName {
+			return ep, nil
+		}
+	}
+	return state.Endpoint{}, errors.NotFoundf("endpoint for %q", appName)
+}
+
 func (r *mockRelation) SetStatus(status status.StatusInfo) error {
 	r.MethodCall(r, "SetStatus")
 	r.status = status.Status
 	r.message = status.Message
+	r.since = status.Since
 	return r.NextErr()
 }
 
@@ -564,7 +704,9 @@ func (r *mockRelation) Destroy() error {
 type mockUnit struct {
 	application.Unit
 	jtesting.Stub
-	tag names.UnitTag
+	tag         names.UnitTag
+	agentStatus status.StatusInfo
+	resolved    state.ResolvedMode
 }
 
 func (u *mockUnit) UnitTag() names.UnitTag {
@@ -582,6 +724,22 @@ func (u *mockUnit) DestroyOperation() *state.DestroyUnitOperation {
 	return &state.DestroyUnitOperation{}
 }
 
+func (u *mockUnit) AgentStatus() (status.StatusInfo, error) {
+	u.MethodCall(u, "AgentStatus")
+	return u.agentStatus, u.NextErr()
+}
+
+func (u *mockUnit) Resolved() state.ResolvedMode {
+	u.MethodCall(u, "Resolved")
+	u.PopNoErr()
+	return u.resolved
+}
+
+func (u *mockUnit) SetResolved(mode state.ResolvedMode) error {
+	u.MethodCall(u, "SetResolved", mode)
+	return u.NextErr()
+}
+
 func (u *mockUnit) AssignWithPolicy(policy state.AssignmentPolicy) error {
 	u.MethodCall(u, "AssignWithPolicy", policy)
 	return u.NextErr()
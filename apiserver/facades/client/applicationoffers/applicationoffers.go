@@ -165,6 +165,8 @@ func (api *OffersAPI) makeAddOfferArgsFromParams(backend Backend, addOfferParams
 
 // ListApplicationOffers gets deployed details about application offers that match given filter.
 // The results contain details about the deployed applications such as connection count.
+//
+//juju:readonly
 func (api *OffersAPI) ListApplicationOffers(filters params.OfferFilters) (params.QueryApplicationOffersResults, error) {
 	var result params.QueryApplicationOffersResults
 	offers, err := api.getApplicationOffersDetails(filters, permission.AdminAccess)
@@ -330,6 +332,8 @@ func (api *OffersAPI) revokeOfferAccess(backend Backend, offerTag names.Applicat
 }
 
 // ApplicationOffers gets details about remote applications that match given URLs.
+//
+//juju:readonly
 func (api *OffersAPI) ApplicationOffers(urls params.OfferURLs) (params.ApplicationOffersResults, error) {
 	var results params.ApplicationOffersResults
 	results.Results = make([]params.ApplicationOfferResult, len(urls.OfferURLs))
@@ -388,6 +392,8 @@ func (api *OffersAPI) ApplicationOffers(urls params.OfferURLs) (params.Applicati
 }
 
 // FindApplicationOffers gets details about remote applications that match given filter.
+//
+//juju:readonly
 func (api *OffersAPI) FindApplicationOffers(filters params.OfferFilters) (params.QueryApplicationOffersResults, error) {
 	var result params.QueryApplicationOffersResults
 	var filtersToUse params.OfferFilters
@@ -424,6 +430,8 @@ func (api *OffersAPI) FindApplicationOffers(filters params.OfferFilters) (params
 
 // GetConsumeDetails returns the details necessary to pass to another model to
 // consume the specified offers represented by the urls.
+//
+//juju:readonly
 func (api *OffersAPI) GetConsumeDetails(args params.OfferURLs) (params.ConsumeOfferDetailsResults, error) {
 	var consumeResults params.ConsumeOfferDetailsResults
 	results := make([]params.ConsumeOfferDetailsResult, len(args.OfferURLs))
@@ -10,6 +10,8 @@ import (
 )
 
 // Info provides the implementation of the API method.
+//
+//juju:readonly
 func (a *API) Info(args params.BackupsInfoArgs) (params.BackupsMetadataResult, error) {
 	backups, closer := newBackups(a.backend)
 	defer closer.Close()
@@ -10,6 +10,8 @@ import (
 )
 
 // List provides the implementation of the API method.
+//
+//juju:readonly
 func (a *API) List(args params.BackupsListArgs) (params.BackupsListResult, error) {
 	var result params.BackupsListResult
 
@@ -5,6 +5,7 @@ package block
 
 import (
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
@@ -29,11 +30,32 @@ type Block interface {
 
 // API implements Block interface and is the concrete
 // implementation of the api end point.
+//
+// API provides the Block API facade for version 3.
 type API struct {
 	access     blockAccess
 	authorizer facade.Authorizer
 }
 
+// APIv2 provides the Block API facade for version 2, which only
+// knows about blocks on the model as a whole.
+type APIv2 struct {
+	*API
+}
+
+// NewAPIv2 returns a new block API facade for version 2.
+func NewAPIv2(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv2, error) {
+	api, err := NewAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv2{api}, nil
+}
+
 // NewAPI returns a new block API facade.
 func NewAPI(
 	st *state.State,
@@ -83,6 +105,8 @@ func (a *API) checkCanWrite() error {
 }
 
 // List implements Block.List().
+//
+//juju:readonly
 func (a *API) List() (params.BlockResults, error) {
 	if err := a.checkCanRead(); err != nil {
 		return params.BlockResults{}, err
@@ -115,22 +139,78 @@ func convertBlock(b state.Block) params.BlockResult {
 	return result
 }
 
-// SwitchBlockOn implements Block.SwitchBlockOn().
+// List returns the blocks on the model as a whole; blocks
+// on individual applications and machines are omitted.
+//
+//juju:readonly
+func (a *APIv2) List() (params.BlockResults, error) {
+	all, err := a.API.List()
+	if err != nil {
+		return params.BlockResults{}, err
+	}
+	modelTag := a.access.ModelTag().String()
+	var results params.BlockResults
+	for _, result := range all.Results {
+		if result.Error == nil && result.Result.Tag != modelTag {
+			continue
+		}
+		results.Results = append(results.Results, result)
+	}
+	return results, nil
+}
+
+// SwitchBlockOn switches desired block type on for the model.
+// Version 2 does not support blocks on individual entities, so
+// any tag is ignored.
+func (a *APIv2) SwitchBlockOn(args params.BlockSwitchParams) params.ErrorResult {
+	args.Tag = ""
+	return a.API.SwitchBlockOn(args)
+}
+
+// SwitchBlockOff switches desired block type off for the model.
+// Version 2 does not support blocks on individual entities, so
+// any tag is ignored.
+func (a *APIv2) SwitchBlockOff(args params.BlockSwitchParams) params.ErrorResult {
+	args.Tag = ""
+	return a.API.SwitchBlockOff(args)
+}
+
+// SwitchBlockOn implements Block.SwitchBlockOn(). If a tag is
+// given, the block applies only to that application or machine.
 func (a *API) SwitchBlockOn(args params.BlockSwitchParams) params.ErrorResult {
 	if err := a.checkCanWrite(); err != nil {
 		return params.ErrorResult{Error: common.ServerError(err)}
 	}
 
-	err := a.access.SwitchBlockOn(state.ParseBlockType(args.Type), args.Message)
+	blockType := state.ParseBlockType(args.Type)
+	if args.Tag == "" {
+		err := a.access.SwitchBlockOn(blockType, args.Message)
+		return params.ErrorResult{Error: common.ServerError(err)}
+	}
+	tag, err := names.ParseTag(args.Tag)
+	if err != nil {
+		return params.ErrorResult{Error: common.ServerError(err)}
+	}
+	err = a.access.SwitchEntityBlockOn(blockType, tag, args.Message)
 	return params.ErrorResult{Error: common.ServerError(err)}
 }
 
-// SwitchBlockOff implements Block.SwitchBlockOff().
+// SwitchBlockOff implements Block.SwitchBlockOff(). If a tag is
+// given, only the block on that application or machine is removed.
 func (a *API) SwitchBlockOff(args params.BlockSwitchParams) params.ErrorResult {
 	if err := a.checkCanWrite(); err != nil {
 		return params.ErrorResult{Error: common.ServerError(err)}
 	}
 
-	err := a.access.SwitchBlockOff(state.ParseBlockType(args.Type))
+	blockType := state.ParseBlockType(args.Type)
+	if args.Tag == "" {
+		err := a.access.SwitchBlockOff(blockType)
+		return params.ErrorResult{Error: common.ServerError(err)}
+	}
+	tag, err := names.ParseTag(args.Tag)
+	if err != nil {
+		return params.ErrorResult{Error: common.ServerError(err)}
+	}
+	err = a.access.SwitchEntityBlockOff(blockType, tag)
 	return params.ErrorResult{Error: common.ServerError(err)}
 }
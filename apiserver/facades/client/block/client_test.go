@@ -79,3 +79,54 @@ func (s *blockSuite) TestSwitchBlockOff(c *gc.C) {
 	c.Assert(err.Error, gc.IsNil)
 	s.assertBlockList(c, 0)
 }
+
+func (s *blockSuite) TestSwitchEntityBlockOn(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	on := params.BlockSwitchParams{
+		Type:    state.RemoveBlock.String(),
+		Message: "production database",
+		Tag:     app.Tag().String(),
+	}
+	err := s.api.SwitchBlockOn(on)
+	c.Assert(err.Error, gc.IsNil)
+
+	all, listErr := s.api.List()
+	c.Assert(listErr, jc.ErrorIsNil)
+	c.Assert(all.Results, gc.HasLen, 1)
+	c.Assert(all.Results[0].Result.Tag, gc.Equals, app.Tag().String())
+	c.Assert(all.Results[0].Result.Message, gc.Equals, "production database")
+
+	_, found, getErr := s.State.GetBlockForType(state.RemoveBlock)
+	c.Assert(getErr, jc.ErrorIsNil)
+	c.Assert(found, jc.IsFalse)
+
+	err = s.api.SwitchBlockOff(params.BlockSwitchParams{
+		Type: state.RemoveBlock.String(),
+		Tag:  app.Tag().String(),
+	})
+	c.Assert(err.Error, gc.IsNil)
+	s.assertBlockList(c, 0)
+}
+
+func (s *blockSuite) TestSwitchEntityBlockOnInvalidTag(c *gc.C) {
+	err := s.api.SwitchBlockOn(params.BlockSwitchParams{
+		Type: state.RemoveBlock.String(),
+		Tag:  "unit-foo-0",
+	})
+	c.Assert(err.Error, gc.ErrorMatches, `block on unit foo/0 not valid`)
+	s.assertBlockList(c, 0)
+}
+
+func (s *blockSuite) TestListV2OmitsEntityBlocks(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	err := s.State.SwitchEntityBlockOn(state.ChangeBlock, app.Tag(), "")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SwitchBlockOn(state.DestroyBlock, "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	apiV2 := &block.APIv2{s.api}
+	all, err := apiV2.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all.Results, gc.HasLen, 1)
+	c.Assert(all.Results[0].Result.Type, gc.Equals, state.DestroyBlock.String())
+}
@@ -13,6 +13,8 @@ type blockAccess interface {
 	AllBlocks() ([]state.Block, error)
 	SwitchBlockOn(t state.BlockType, msg string) error
 	SwitchBlockOff(t state.BlockType) error
+	SwitchEntityBlockOn(t state.BlockType, tag names.Tag, msg string) error
+	SwitchEntityBlockOff(t state.BlockType, tag names.Tag) error
 	ModelTag() names.ModelTag
 }
 
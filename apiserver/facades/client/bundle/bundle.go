@@ -36,6 +36,8 @@ func NewBundle(auth facade.Authorizer) (Bundle, error) {
 type Bundle interface {
 	// GetChanges returns the list of changes required to deploy the given
 	// bundle data.
+	//
+	//juju:readonly
 	GetChanges(params.BundleChangesParams) (params.BundleChangesResults, error)
 }
 
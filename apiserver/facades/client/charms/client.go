@@ -73,6 +73,8 @@ var getState = func(st *state.State, m *state.Model) backend {
 
 // CharmInfo returns information about the requested charm.
 // NOTE: thumper 2016-06-29, this is not a bulk call and probably should be.
+//
+//juju:readonly
 func (a *API) CharmInfo(args params.CharmURL) (params.CharmInfo, error) {
 	if err := a.checkCanRead(); err != nil {
 		return params.CharmInfo{}, errors.Trace(err)
@@ -100,6 +102,8 @@ func (a *API) CharmInfo(args params.CharmURL) (params.CharmInfo, error) {
 // List returns a list of charm URLs currently in the state.
 // If supplied parameter contains any names, the result will be filtered
 // to return only the charms with supplied names.
+//
+//juju:readonly
 func (a *API) List(args params.CharmsList) (params.CharmsListResult, error) {
 	if err := a.checkCanRead(); err != nil {
 		return params.CharmsListResult{}, errors.Trace(err)
@@ -126,6 +130,8 @@ func (a *API) List(args params.CharmsList) (params.CharmsListResult, error) {
 }
 
 // IsMetered returns whether or not the charm is metered.
+//
+//juju:readonly
 func (a *API) IsMetered(args params.CharmURL) (params.IsMeteredResult, error) {
 	if err := a.checkCanRead(); err != nil {
 		return params.IsMeteredResult{}, errors.Trace(err)
@@ -35,6 +35,7 @@ func (ctx *charmsSuiteContext) Dispose()                    {}
 func (ctx *charmsSuiteContext) Resources() facade.Resources { return common.NewResources() }
 func (ctx *charmsSuiteContext) State() *state.State         { return ctx.cs.State }
 func (ctx *charmsSuiteContext) StatePool() *state.StatePool { return nil }
+func (ctx *charmsSuiteContext) Hub() facade.Hub             { return nil }
 func (ctx *charmsSuiteContext) ID() string                  { return "" }
 
 func (s *charmsSuite) SetUpTest(c *gc.C) {
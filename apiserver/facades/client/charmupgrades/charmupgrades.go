@@ -0,0 +1,258 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package charmupgrades implements the CharmUpgrades facade, which
+// upgrades every application in a model to the latest revision of its
+// charm. Upgrades are first planned, so that config changes can be
+// reviewed, and then applied in dependency order.
+package charmupgrades
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/charm.v6"
+	csparams "gopkg.in/juju/charmrepo.v2/csclient/params"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.charmupgrades")
+
+// Backend defines the state functionality required by the
+// CharmUpgrades facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+	AllApplications() ([]Application, error)
+	Application(name string) (Application, error)
+
+	// ApplicationDependencies returns, for each application that
+	// requires a relation, the names of the applications that provide
+	// it.
+	ApplicationDependencies() (map[string][]string, error)
+
+	// AddCharm adds the charm store charm with the given URL to the
+	// model, if it isn't already there.
+	AddCharm(curl *charm.URL, channel csparams.Channel) error
+
+	// CharmConfig returns the config schema of the given charm, which
+	// must have been added to the model.
+	CharmConfig(curl *charm.URL) (*charm.Config, error)
+
+	// UpgradeCharm sets the charm of the named application.
+	UpgradeCharm(name string, curl *charm.URL, channel csparams.Channel) error
+}
+
+// Application defines the application functionality required by the
+// CharmUpgrades facade.
+type Application interface {
+	Name() string
+	CharmURL() (*charm.URL, bool)
+	Channel() csparams.Channel
+	CharmConfig() (charm.Settings, error)
+}
+
+// CharmStore resolves the latest revisions of charms.
+type CharmStore interface {
+	// Latest returns the URL of the latest revision of the given
+	// charm in the given channel.
+	Latest(curl *charm.URL, channel csparams.Channel) (*charm.URL, error)
+}
+
+// BlockChecker checks for blocks on model changes.
+type BlockChecker interface {
+	ChangeAllowed() error
+}
+
+// API implements the CharmUpgrades facade.
+type API struct {
+	backend    Backend
+	store      CharmStore
+	check      BlockChecker
+	authorizer facade.Authorizer
+}
+
+// NewAPI returns a new CharmUpgrades facade.
+func NewAPI(backend Backend, store CharmStore, check BlockChecker, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		store:      store,
+		check:      check,
+		authorizer: authorizer,
+	}, nil
+}
+
+func (api *API) checkPermission(access permission.Access) error {
+	ok, err := api.authorizer.HasPermission(access, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// PlanUpgradeAll resolves the latest revision of the charm of every
+// application in the model, in the application's channel, and returns
+// the upgrades needed, in the order UpgradeAll applies them. Only
+// charm store charms are considered. New revisions are added to the
+// model so that their config schemas can be compared, which is why
+// planning requires write access.
+func (api *API) PlanUpgradeAll() (params.CharmUpgradePlan, error) {
+	if err := api.checkPermission(permission.WriteAccess); err != nil {
+		return params.CharmUpgradePlan{}, errors.Trace(err)
+	}
+	upgrades, err := api.plan()
+	if err != nil {
+		return params.CharmUpgradePlan{Error: common.ServerError(err)}, nil
+	}
+	return params.CharmUpgradePlan{Upgrades: upgrades}, nil
+}
+
+func (api *API) plan() ([]params.CharmUpgrade, error) {
+	apps, err := api.backend.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	byName := make(map[string]params.CharmUpgrade)
+	for _, app := range apps {
+		upgrade, ok, err := api.planApplication(app)
+		if err != nil {
+			return nil, errors.Annotatef(err, "application %q", app.Name())
+		}
+		if ok {
+			byName[app.Name()] = upgrade
+		}
+	}
+	order, err := api.order(byName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	upgrades := []params.CharmUpgrade{}
+	for _, name := range order {
+		upgrades = append(upgrades, byName[name])
+	}
+	return upgrades, nil
+}
+
+func (api *API) planApplication(app Application) (params.CharmUpgrade, bool, error) {
+	curl, _ := app.CharmURL()
+	if curl == nil || curl.Schema != "cs" {
+		return params.CharmUpgrade{}, false, nil
+	}
+	channel := app.Channel()
+	latest, err := api.store.Latest(curl, channel)
+	if err != nil {
+		return params.CharmUpgrade{}, false, errors.Trace(err)
+	}
+	if latest.String() == curl.String() {
+		return params.CharmUpgrade{}, false, nil
+	}
+	if err := api.backend.AddCharm(latest, channel); err != nil {
+		return params.CharmUpgrade{}, false, errors.Trace(err)
+	}
+	from, err := api.backend.CharmConfig(curl)
+	if err != nil {
+		return params.CharmUpgrade{}, false, errors.Trace(err)
+	}
+	to, err := api.backend.CharmConfig(latest)
+	if err != nil {
+		return params.CharmUpgrade{}, false, errors.Trace(err)
+	}
+	settings, err := app.CharmConfig()
+	if err != nil {
+		return params.CharmUpgrade{}, false, errors.Trace(err)
+	}
+	return params.CharmUpgrade{
+		Application: app.Name(),
+		From:        curl.String(),
+		To:          latest.String(),
+		Channel:     string(channel),
+		ConfigNotes: ConfigNotes(from, to, settings),
+	}, true, nil
+}
+
+// order returns the names of the applications being upgraded, in
+// dependency order.
+func (api *API) order(upgrades map[string]params.CharmUpgrade) ([]string, error) {
+	deps, err := api.backend.ApplicationDependencies()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	names := make([]string, 0, len(upgrades))
+	for name := range upgrades {
+		names = append(names, name)
+	}
+	return UpgradeOrder(names, deps), nil
+}
+
+// UpgradeAll applies the given upgrades, from a previous plan, in
+// dependency order, so that applications are upgraded after the
+// applications they require relations from. If an application's charm
+// has changed since the upgrade was planned, or an upgrade fails,
+// UpgradeAll stops; the result records the applications upgraded
+// before then, and those still pending.
+func (api *API) UpgradeAll(args params.UpgradeAllArgs) (params.UpgradeAllResult, error) {
+	if err := api.checkPermission(permission.WriteAccess); err != nil {
+		return params.UpgradeAllResult{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.UpgradeAllResult{}, errors.Trace(err)
+	}
+	if len(args.Upgrades) == 0 {
+		return params.UpgradeAllResult{
+			Error: common.ServerError(errors.NotValidf("empty upgrades")),
+		}, nil
+	}
+	byName := make(map[string]params.CharmUpgrade)
+	for _, upgrade := range args.Upgrades {
+		if _, ok := byName[upgrade.Application]; ok {
+			return params.UpgradeAllResult{
+				Error: common.ServerError(errors.NotValidf("duplicate upgrade of application %q", upgrade.Application)),
+			}, nil
+		}
+		byName[upgrade.Application] = upgrade
+	}
+	order, err := api.order(byName)
+	if err != nil {
+		return params.UpgradeAllResult{Error: common.ServerError(err)}, nil
+	}
+
+	result := params.UpgradeAllResult{Applied: []string{}}
+	for i, name := range order {
+		logger.Debugf("upgrading application %q to %s", name, byName[name].To)
+		if err := api.upgrade(byName[name]); err != nil {
+			result.Pending = order[i:]
+			result.Error = common.ServerError(errors.Annotatef(err, "upgrading application %q", name))
+			break
+		}
+		result.Applied = append(result.Applied, name)
+	}
+	return result, nil
+}
+
+func (api *API) upgrade(upgrade params.CharmUpgrade) error {
+	from, err := charm.ParseURL(upgrade.From)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	to, err := charm.ParseURL(upgrade.To)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(upgrade.Application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if curl, _ := app.CharmURL(); curl == nil || curl.String() != from.String() {
+		return errors.Errorf("charm changed to %v since upgrade was planned from %v", curl, from)
+	}
+	return errors.Trace(api.backend.UpgradeCharm(upgrade.Application, to, csparams.Channel(upgrade.Channel)))
+}
@@ -0,0 +1,280 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmupgrades_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	csparams "gopkg.in/juju/charmrepo.v2/csclient/params"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/charmupgrades"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type charmUpgradesSuite struct {
+	testing.IsolationSuite
+	backend *mockBackend
+	store   *mockCharmStore
+	blocks  mockBlockChecker
+}
+
+var _ = gc.Suite(&charmUpgradesSuite{})
+
+func (s *charmUpgradesSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		applications: map[string]*mockApplication{
+			"mysql": {
+				name:     "mysql",
+				curl:     charm.MustParseURL("cs:xenial/mysql-3"),
+				channel:  csparams.StableChannel,
+				settings: charm.Settings{"port": int64(3306)},
+			},
+			"wordpress": {
+				name: "wordpress",
+				curl: charm.MustParseURL("cs:xenial/wordpress-1"),
+			},
+			"haproxy": {
+				name: "haproxy",
+				curl: charm.MustParseURL("cs:xenial/haproxy-7"),
+			},
+			"local": {
+				name: "local",
+				curl: charm.MustParseURL("local:xenial/dummy-0"),
+			},
+		},
+		deps: map[string][]string{
+			"wordpress": {"mysql"},
+			"haproxy":   {"wordpress"},
+		},
+		configs: map[string]*charm.Config{
+			"cs:xenial/mysql-3": {Options: map[string]charm.Option{
+				"port": {Type: "int"},
+			}},
+			"cs:xenial/mysql-5": {Options: map[string]charm.Option{
+				"port": {Type: "string"},
+			}},
+		},
+	}
+	s.store = &mockCharmStore{
+		latest: map[string]string{
+			"cs:xenial/mysql-3":     "cs:xenial/mysql-5",
+			"cs:xenial/wordpress-1": "cs:xenial/wordpress-2",
+			"cs:xenial/haproxy-7":   "cs:xenial/haproxy-7",
+		},
+	}
+	s.blocks = mockBlockChecker{}
+}
+
+func (s *charmUpgradesSuite) newAPI(c *gc.C, user string) *charmupgrades.API {
+	api, err := charmupgrades.NewAPI(s.backend, s.store, &s.blocks, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag(user),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *charmUpgradesSuite) TestNewAPIRequiresClient(c *gc.C) {
+	_, err := charmupgrades.NewAPI(s.backend, s.store, &s.blocks, apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *charmUpgradesSuite) TestPlanUpgradeAll(c *gc.C) {
+	result, err := s.newAPI(c, "admin").PlanUpgradeAll()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Upgrades, jc.DeepEquals, []params.CharmUpgrade{{
+		Application: "mysql",
+		From:        "cs:xenial/mysql-3",
+		To:          "cs:xenial/mysql-5",
+		Channel:     "stable",
+		ConfigNotes: []string{`option "port" changes type from int to string; its value is discarded`},
+	}, {
+		Application: "wordpress",
+		From:        "cs:xenial/wordpress-1",
+		To:          "cs:xenial/wordpress-2",
+	}})
+	s.store.CheckCall(c, 0, "Latest", "cs:xenial/haproxy-7", csparams.NoChannel)
+	s.backend.CheckCall(c, 1, "AddCharm", "cs:xenial/mysql-5", csparams.StableChannel)
+}
+
+func (s *charmUpgradesSuite) TestPlanUpgradeAllStoreError(c *gc.C) {
+	s.store.SetErrors(errors.New("boom"))
+	result, err := s.newAPI(c, "admin").PlanUpgradeAll()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `application "haproxy": boom`)
+}
+
+func (s *charmUpgradesSuite) TestPlanUpgradeAllRequiresWrite(c *gc.C) {
+	_, err := s.newAPI(c, "read").PlanUpgradeAll()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *charmUpgradesSuite) TestUpgradeAll(c *gc.C) {
+	result, err := s.newAPI(c, "admin").UpgradeAll(params.UpgradeAllArgs{
+		Upgrades: []params.CharmUpgrade{
+			{Application: "wordpress", From: "cs:xenial/wordpress-1", To: "cs:xenial/wordpress-2"},
+			{Application: "mysql", From: "cs:xenial/mysql-3", To: "cs:xenial/mysql-5", Channel: "stable"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Applied, jc.DeepEquals, []string{"mysql", "wordpress"})
+	c.Assert(result.Pending, gc.HasLen, 0)
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"ApplicationDependencies", nil},
+		{"Application", []interface{}{"mysql"}},
+		{"UpgradeCharm", []interface{}{"mysql", "cs:xenial/mysql-5", csparams.StableChannel}},
+		{"Application", []interface{}{"wordpress"}},
+		{"UpgradeCharm", []interface{}{"wordpress", "cs:xenial/wordpress-2", csparams.NoChannel}},
+	})
+	s.blocks.CheckCallNames(c, "ChangeAllowed")
+}
+
+func (s *charmUpgradesSuite) TestUpgradeAllPausesOnError(c *gc.C) {
+	s.backend.SetErrors(nil, nil, errors.New("boom"))
+	result, err := s.newAPI(c, "admin").UpgradeAll(params.UpgradeAllArgs{
+		Upgrades: []params.CharmUpgrade{
+			{Application: "haproxy", From: "cs:xenial/haproxy-7", To: "cs:xenial/haproxy-8"},
+			{Application: "wordpress", From: "cs:xenial/wordpress-1", To: "cs:xenial/wordpress-2"},
+			{Application: "mysql", From: "cs:xenial/mysql-3", To: "cs:xenial/mysql-5"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `upgrading application "mysql": boom`)
+	c.Assert(result.Applied, gc.HasLen, 0)
+	c.Assert(result.Pending, jc.DeepEquals, []string{"mysql", "wordpress", "haproxy"})
+}
+
+func (s *charmUpgradesSuite) TestUpgradeAllCharmChanged(c *gc.C) {
+	result, err := s.newAPI(c, "admin").UpgradeAll(params.UpgradeAllArgs{
+		Upgrades: []params.CharmUpgrade{
+			{Application: "mysql", From: "cs:xenial/mysql-2", To: "cs:xenial/mysql-5"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches,
+		`upgrading application "mysql": charm changed to cs:xenial/mysql-3 since upgrade was planned from cs:xenial/mysql-2`,
+	)
+	c.Assert(result.Pending, jc.DeepEquals, []string{"mysql"})
+	s.backend.CheckCallNames(c, "ApplicationDependencies", "Application")
+}
+
+func (s *charmUpgradesSuite) TestUpgradeAllBlocked(c *gc.C) {
+	s.blocks.SetErrors(errors.New("blocked"))
+	_, err := s.newAPI(c, "admin").UpgradeAll(params.UpgradeAllArgs{})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *charmUpgradesSuite) TestUpgradeAllDuplicate(c *gc.C) {
+	upgrade := params.CharmUpgrade{Application: "mysql", From: "cs:xenial/mysql-3", To: "cs:xenial/mysql-5"}
+	result, err := s.newAPI(c, "admin").UpgradeAll(params.UpgradeAllArgs{
+		Upgrades: []params.CharmUpgrade{upgrade, upgrade},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `duplicate upgrade of application "mysql" not valid`)
+	s.backend.CheckNoCalls(c)
+}
+
+type mockBackend struct {
+	testing.Stub
+	applications map[string]*mockApplication
+	deps         map[string][]string
+	configs      map[string]*charm.Config
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) AllApplications() ([]charmupgrades.Application, error) {
+	b.MethodCall(b, "AllApplications")
+	var apps []charmupgrades.Application
+	for _, name := range []string{"haproxy", "local", "mysql", "wordpress"} {
+		apps = append(apps, b.applications[name])
+	}
+	return apps, b.NextErr()
+}
+
+func (b *mockBackend) Application(name string) (charmupgrades.Application, error) {
+	b.MethodCall(b, "Application", name)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	return b.applications[name], nil
+}
+
+func (b *mockBackend) ApplicationDependencies() (map[string][]string, error) {
+	b.MethodCall(b, "ApplicationDependencies")
+	return b.deps, b.NextErr()
+}
+
+func (b *mockBackend) AddCharm(curl *charm.URL, channel csparams.Channel) error {
+	b.MethodCall(b, "AddCharm", curl.String(), channel)
+	return b.NextErr()
+}
+
+func (b *mockBackend) CharmConfig(curl *charm.URL) (*charm.Config, error) {
+	b.MethodCall(b, "CharmConfig", curl.String())
+	return b.configs[curl.String()], b.NextErr()
+}
+
+func (b *mockBackend) UpgradeCharm(name string, curl *charm.URL, channel csparams.Channel) error {
+	b.MethodCall(b, "UpgradeCharm", name, curl.String(), channel)
+	return b.NextErr()
+}
+
+type mockApplication struct {
+	name     string
+	curl     *charm.URL
+	channel  csparams.Channel
+	settings charm.Settings
+}
+
+func (a *mockApplication) Name() string {
+	return a.name
+}
+
+func (a *mockApplication) CharmURL() (*charm.URL, bool) {
+	return a.curl, false
+}
+
+func (a *mockApplication) Channel() csparams.Channel {
+	return a.channel
+}
+
+func (a *mockApplication) CharmConfig() (charm.Settings, error) {
+	return a.settings, nil
+}
+
+type mockCharmStore struct {
+	testing.Stub
+	latest map[string]string
+}
+
+func (s *mockCharmStore) Latest(curl *charm.URL, channel csparams.Channel) (*charm.URL, error) {
+	s.MethodCall(s, "Latest", curl.String(), channel)
+	if err := s.NextErr(); err != nil {
+		return nil, err
+	}
+	return charm.MustParseURL(s.latest[curl.String()]), nil
+}
+
+type mockBlockChecker struct {
+	testing.Stub
+}
+
+func (c *mockBlockChecker) ChangeAllowed() error {
+	c.MethodCall(c, "ChangeAllowed")
+	return c.NextErr()
+}
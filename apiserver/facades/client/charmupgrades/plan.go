@@ -0,0 +1,118 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmupgrades
+
+import (
+	"fmt"
+	"sort"
+
+	"gopkg.in/juju/charm.v6"
+)
+
+// UpgradeOrder returns the given application names ordered so that
+// each application comes after the applications it depends on, as
+// recorded in deps. Dependencies on applications that aren't named
+// are ignored. Ties, and applications in dependency cycles, are
+// ordered by name.
+func UpgradeOrder(names []string, deps map[string][]string) []string {
+	remaining := make(map[string]bool)
+	for _, name := range names {
+		remaining[name] = true
+	}
+	sorted := make([]string, 0, len(remaining))
+	for name := range remaining {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	ready := func(name string) bool {
+		for _, dep := range deps[name] {
+			if dep != name && remaining[dep] {
+				return false
+			}
+		}
+		return true
+	}
+	order := make([]string, 0, len(sorted))
+	for len(remaining) > 0 {
+		next := ""
+		for _, name := range sorted {
+			if remaining[name] && ready(name) {
+				next = name
+				break
+			}
+		}
+		if next == "" {
+			// Every remaining application is part of, or depends
+			// on, a cycle; break it at the first by name.
+			for _, name := range sorted {
+				if remaining[name] {
+					next = name
+					break
+				}
+			}
+		}
+		delete(remaining, next)
+		order = append(order, next)
+	}
+	return order
+}
+
+// ConfigNotes describes the differences between two charm config
+// schemas that affect an application with the given settings: options
+// that are removed or change type, whose values are discarded by the
+// upgrade, and options that are added. Settings equal to the old
+// option's default are not considered to have been set.
+func ConfigNotes(from, to *charm.Config, settings charm.Settings) []string {
+	if from == nil {
+		from = charm.NewConfig()
+	}
+	if to == nil {
+		to = charm.NewConfig()
+	}
+	var notes []string
+	for _, name := range optionNames(from) {
+		old := from.Options[name]
+		value, set := settings[name]
+		set = set && value != old.Default
+		option, ok := to.Options[name]
+		switch {
+		case !ok && set:
+			notes = append(notes, fmt.Sprintf("option %q is removed; its value is discarded", name))
+		case !ok:
+			notes = append(notes, fmt.Sprintf("option %q is removed", name))
+		case option.Type != old.Type && set:
+			notes = append(notes, fmt.Sprintf(
+				"option %q changes type from %s to %s; its value is discarded",
+				name, old.Type, option.Type,
+			))
+		case option.Type != old.Type:
+			notes = append(notes, fmt.Sprintf(
+				"option %q changes type from %s to %s",
+				name, old.Type, option.Type,
+			))
+		}
+	}
+	for _, name := range optionNames(to) {
+		if _, ok := from.Options[name]; ok {
+			continue
+		}
+		option := to.Options[name]
+		if option.Default != nil {
+			notes = append(notes, fmt.Sprintf("option %q is added, defaulting to %v", name, option.Default))
+		} else {
+			notes = append(notes, fmt.Sprintf("option %q is added", name))
+		}
+	}
+	return notes
+}
+
+func optionNames(config *charm.Config) []string {
+	var names []string
+	for name := range config.Options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,84 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmupgrades_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/apiserver/facades/client/charmupgrades"
+)
+
+type planSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&planSuite{})
+
+func (s *planSuite) TestUpgradeOrder(c *gc.C) {
+	order := charmupgrades.UpgradeOrder(
+		[]string{"wordpress", "mysql", "haproxy", "memcached"},
+		map[string][]string{
+			"haproxy":   {"wordpress"},
+			"wordpress": {"mysql", "memcached", "nrpe"},
+		},
+	)
+	c.Assert(order, jc.DeepEquals, []string{"memcached", "mysql", "wordpress", "haproxy"})
+}
+
+func (s *planSuite) TestUpgradeOrderCycle(c *gc.C) {
+	order := charmupgrades.UpgradeOrder(
+		[]string{"c", "b", "a", "d"},
+		map[string][]string{
+			"a": {"b"},
+			"b": {"a"},
+			"c": {"d", "c"},
+		},
+	)
+	c.Assert(order, jc.DeepEquals, []string{"d", "c", "a", "b"})
+}
+
+func (s *planSuite) TestConfigNotes(c *gc.C) {
+	from := &charm.Config{Options: map[string]charm.Option{
+		"title":     {Type: "string", Default: "blog"},
+		"debug":     {Type: "boolean"},
+		"workers":   {Type: "string"},
+		"port":      {Type: "int"},
+		"timeout":   {Type: "int", Default: 30},
+		"unchanged": {Type: "string"},
+	}}
+	to := &charm.Config{Options: map[string]charm.Option{
+		"title":     {Type: "string", Default: "blog"},
+		"workers":   {Type: "int"},
+		"port":      {Type: "string"},
+		"unchanged": {Type: "string"},
+		"theme":     {Type: "string", Default: "dark"},
+		"ssl":       {Type: "boolean"},
+	}}
+	settings := charm.Settings{
+		"title":   "blog",
+		"debug":   true,
+		"workers": "4",
+		"timeout": 30,
+	}
+	notes := charmupgrades.ConfigNotes(from, to, settings)
+	c.Assert(notes, jc.DeepEquals, []string{
+		`option "debug" is removed; its value is discarded`,
+		`option "port" changes type from int to string`,
+		`option "timeout" is removed`,
+		`option "workers" changes type from string to int; its value is discarded`,
+		`option "ssl" is added`,
+		`option "theme" is added, defaulting to dark`,
+	})
+}
+
+func (s *planSuite) TestConfigNotesNone(c *gc.C) {
+	config := &charm.Config{Options: map[string]charm.Option{
+		"title": {Type: "string"},
+	}}
+	notes := charmupgrades.ConfigNotes(config, config, charm.Settings{"title": "hello"})
+	c.Assert(notes, gc.HasLen, 0)
+}
@@ -0,0 +1,136 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmupgrades
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/charmrepo.v2/csclient"
+	csparams "gopkg.in/juju/charmrepo.v2/csclient/params"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/facades/client/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+)
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(&backend{st}, &charmStore{st}, common.NewBlockChecker(st), auth)
+}
+
+type backend struct {
+	*state.State
+}
+
+// AllApplications is part of the Backend interface.
+func (b *backend) AllApplications() ([]Application, error) {
+	apps, err := b.State.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Application, len(apps))
+	for i, app := range apps {
+		result[i] = app
+	}
+	return result, nil
+}
+
+// Application is part of the Backend interface.
+func (b *backend) Application(name string) (Application, error) {
+	app, err := b.State.Application(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return app, nil
+}
+
+// ApplicationDependencies is part of the Backend interface.
+func (b *backend) ApplicationDependencies() (map[string][]string, error) {
+	relations, err := b.AllRelations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	deps := make(map[string][]string)
+	for _, rel := range relations {
+		endpoints := rel.Endpoints()
+		for _, requirer := range endpoints {
+			if requirer.Role != charm.RoleRequirer {
+				continue
+			}
+			for _, provider := range endpoints {
+				if provider.Role == charm.RoleProvider {
+					deps[requirer.ApplicationName] = append(deps[requirer.ApplicationName], provider.ApplicationName)
+				}
+			}
+		}
+	}
+	return deps, nil
+}
+
+// AddCharm is part of the Backend interface.
+func (b *backend) AddCharm(curl *charm.URL, channel csparams.Channel) error {
+	return application.AddCharmWithAuthorization(b.State, params.AddCharmWithAuthorization{
+		URL:     curl.String(),
+		Channel: string(channel),
+	})
+}
+
+// CharmConfig is part of the Backend interface.
+func (b *backend) CharmConfig(curl *charm.URL) (*charm.Config, error) {
+	ch, err := b.Charm(curl)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ch.Config(), nil
+}
+
+// UpgradeCharm is part of the Backend interface.
+func (b *backend) UpgradeCharm(name string, curl *charm.URL, channel csparams.Channel) error {
+	app, err := b.State.Application(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The charm is normally added when the upgrade is planned, but
+	// adding it again is harmless.
+	if err := b.AddCharm(curl, channel); err != nil {
+		return errors.Trace(err)
+	}
+	ch, err := b.Charm(curl)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(app.SetCharm(state.SetCharmConfig{
+		Charm:   ch,
+		Channel: channel,
+	}))
+}
+
+type charmStore struct {
+	st *state.State
+}
+
+// Latest is part of the CharmStore interface.
+func (s *charmStore) Latest(curl *charm.URL, channel csparams.Channel) (*charm.URL, error) {
+	model, err := s.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	modelConfig, err := model.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	client := csclient.New(csclient.Params{})
+	if channel != csparams.NoChannel {
+		client = client.WithChannel(channel)
+	}
+	repo := config.SpecializeCharmRepo(application.NewCharmStoreRepo(client), modelConfig)
+	latest, _, err := repo.Resolve(curl.WithRevision(-1))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return latest, nil
+}
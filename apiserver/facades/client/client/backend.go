@@ -36,13 +36,21 @@ type Backend interface {
 	AllModelUUIDs() ([]string, error)
 	AllIPAddresses() ([]*state.Address, error)
 	AllLinkLayerDevices() ([]*state.LinkLayerDevice, error)
+	AllMachinesFanStatus() (map[string]state.MachineFanStatus, error)
+	AllMachinesProxyStatus() (map[string]state.ProxyStatus, error)
+	AllMaintenanceModes() ([]state.MaintenanceMode, error)
+	AllAgentsOffline() ([]state.AgentOffline, error)
 	AllRelations() ([]*state.Relation, error)
 	AllSubnets() ([]*state.Subnet, error)
+	AllUnitsMetricsActivity() (map[string]state.UnitMetricsActivity, error)
+	AllUnitsUtilization() (map[string]state.UnitUtilization, error)
 	Annotations(state.GlobalEntity) (map[string]string, error)
 	APIHostPorts() ([][]network.HostPort, error)
 	Application(string) (*state.Application, error)
 	ApplicationLeaders() (map[string]string, error)
 	Charm(*charm.URL) (*state.Charm, error)
+	CheckAdoptedUnits(string, []string) error
+	CheckModelQuotas(state.ModelResources) error
 	ControllerConfig() (controller.Config, error)
 	ControllerTag() names.ControllerTag
 	EndpointsRelation(...state.Endpoint) (*state.Relation, error)
@@ -13,6 +13,8 @@ import (
 // applied in order.
 // This call is deprecated, clients should use the GetChanges endpoint on the
 // Bundle facade.
+//
+//juju:readonly
 func (c *Client) GetBundleChanges(args params.BundleChangesParams) (params.BundleChangesResults, error) {
 	bundleAPI, err := bundle.NewBundle(c.api.auth)
 	if err != nil {
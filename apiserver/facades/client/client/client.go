@@ -54,7 +54,7 @@ func (api *API) state() *state.State {
 type Client struct {
 	// TODO(wallyworld) - we'll retain model config facade methods
 	// on the client facade until GUI and Python client library are updated.
-	*modelconfig.ModelConfigAPI
+	*modelconfig.ModelConfigAPIV1
 
 	api        *API
 	newEnviron func() (environs.Environ, error)
@@ -109,6 +109,22 @@ func (c *Client) checkIsAdmin() error {
 	return nil
 }
 
+// ClientV1 serves the methods of version 1 of the Client facade,
+// which predates reporting status as of a past time.
+type ClientV1 struct {
+	*Client
+}
+
+// NewFacadeV1 provides the required signature for version 1 facade
+// registration.
+func NewFacadeV1(ctx facade.Context) (*ClientV1, error) {
+	client, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ClientV1{client}, nil
+}
+
 // NewFacade provides the required signature for facade registration.
 func NewFacade(ctx facade.Context) (*Client, error) {
 	st := ctx.State()
@@ -136,7 +152,9 @@ func NewFacade(ctx facade.Context) (*Client, error) {
 	return NewClient(
 		&stateShim{st, model},
 		&poolShim{ctx.StatePool()},
-		modelConfigAPI,
+		&modelconfig.ModelConfigAPIV1{
+			ModelConfigAPIV2: &modelconfig.ModelConfigAPIV2{ModelConfigAPI: modelConfigAPI},
+		},
 		resources,
 		authorizer,
 		statusSetter,
@@ -150,7 +168,7 @@ func NewFacade(ctx facade.Context) (*Client, error) {
 func NewClient(
 	backend Backend,
 	pool Pool,
-	modelConfigAPI *modelconfig.ModelConfigAPI,
+	modelConfigAPI *modelconfig.ModelConfigAPIV1,
 	resources facade.Resources,
 	authorizer facade.Authorizer,
 	statusSetter *common.StatusSetter,
@@ -162,7 +180,7 @@ func NewClient(
 		return nil, common.ErrPerm
 	}
 	client := &Client{
-		ModelConfigAPI: modelConfigAPI,
+		ModelConfigAPIV1: modelConfigAPI,
 		api: &API{
 			stateAccessor: backend,
 			pool:          pool,
@@ -178,6 +196,8 @@ func NewClient(
 }
 
 // WatchAll initiates a watcher for entities in the connected model.
+//
+//juju:readonly
 func (c *Client) WatchAll() (params.AllWatcherId, error) {
 	if err := c.checkCanRead(); err != nil {
 		return params.AllWatcherId{}, err
@@ -218,6 +238,8 @@ func (c *Client) Resolved(p params.Resolved) error {
 }
 
 // PublicAddress implements the server side of Client.PublicAddress.
+//
+//juju:readonly
 func (c *Client) PublicAddress(p params.PublicAddress) (results params.PublicAddressResults, err error) {
 	if err := c.checkCanRead(); err != nil {
 		return params.PublicAddressResults{}, err
@@ -250,6 +272,8 @@ func (c *Client) PublicAddress(p params.PublicAddress) (results params.PublicAdd
 }
 
 // PrivateAddress implements the server side of Client.PrivateAddress.
+//
+//juju:readonly
 func (c *Client) PrivateAddress(p params.PrivateAddress) (results params.PrivateAddressResults, err error) {
 	if err := c.checkCanRead(); err != nil {
 		return params.PrivateAddressResults{}, err
@@ -283,6 +307,8 @@ func (c *Client) PrivateAddress(p params.PrivateAddress) (results params.Private
 }
 
 // GetModelConstraints returns the constraints for the model.
+//
+//juju:readonly
 func (c *Client) GetModelConstraints() (params.GetConstraintsResults, error) {
 	if err := c.checkCanRead(); err != nil {
 		return params.GetConstraintsResults{}, err
@@ -344,6 +370,9 @@ func (c *Client) InjectMachines(args params.AddMachines) (params.AddMachinesResu
 }
 
 func (c *Client) addOneMachine(p params.AddMachineParams) (*state.Machine, error) {
+	if p.Adopt != nil {
+		return c.adoptMachine(p)
+	}
 	if p.ParentId != "" && p.ContainerType == "" {
 		return nil, fmt.Errorf("parent machine specified without container type")
 	}
@@ -396,6 +425,9 @@ func (c *Client) addOneMachine(p params.AddMachineParams) (*state.Machine, error
 	if err != nil {
 		return nil, err
 	}
+	if err := c.api.stateAccessor.CheckModelQuotas(common.MachineResources(p)); err != nil {
+		return nil, errors.Trace(err)
+	}
 	template := state.MachineTemplate{
 		Series:      p.Series,
 		Constraints: p.Constraints,
@@ -405,6 +437,7 @@ func (c *Client) addOneMachine(p params.AddMachineParams) (*state.Machine, error
 		HardwareCharacteristics: p.HardwareCharacteristics,
 		Addresses:               params.NetworkAddresses(p.Addrs...),
 		Placement:               placementDirective,
+		CloudInitUserData:       p.CloudInitUserData,
 	}
 	if p.ContainerType == "" {
 		return c.api.stateAccessor.AddOneMachine(template)
@@ -415,6 +448,57 @@ func (c *Client) addOneMachine(p params.AddMachineParams) (*state.Machine, error
 	return c.api.stateAccessor.AddMachineInsideNewMachine(template, template, p.ContainerType)
 }
 
+// adoptMachine adds a manually provisioned machine on which an agent
+// was previously deployed. If that agent was for a machine in this
+// model that still has the same instance id, that machine is adopted
+// in place, fencing out its previous agents; otherwise a new machine is
+// added. In either case, units found on the machine that are assigned
+// to other machines in this model are refused.
+func (c *Client) adoptMachine(p params.AddMachineParams) (*state.Machine, error) {
+	if p.InstanceId == "" || p.Nonce == "" {
+		return nil, errors.NotValidf("adopting machine without instance id and nonce")
+	}
+	if p.ContainerType != "" || p.Placement != nil {
+		return nil, errors.NotValidf("adopting container")
+	}
+	var machineId string
+	var units []string
+	// The names in the agent configuration are only meaningful if the
+	// agent belonged to this model.
+	if p.Adopt.ModelUUID == c.api.stateAccessor.ModelUUID() {
+		units = p.Adopt.Units
+		if tag, err := names.ParseMachineTag(p.Adopt.AgentTag); err == nil {
+			machineId = tag.Id()
+		}
+	}
+	if machineId != "" {
+		m, err := c.api.stateAccessor.Machine(machineId)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		if err == nil {
+			instanceId, err := m.InstanceId()
+			if err != nil && !errors.IsNotProvisioned(err) {
+				return nil, errors.Trace(err)
+			}
+			if instanceId == p.InstanceId {
+				if err := m.Adopt(p.InstanceId, p.Nonce, units); err != nil {
+					return nil, errors.Trace(err)
+				}
+				return m, nil
+			}
+			// The agent was copied from another machine, which
+			// keeps its identity.
+			logger.Infof("machine %s has instance %q, adding %q as a new machine", machineId, instanceId, p.InstanceId)
+		}
+	}
+	if err := c.api.stateAccessor.CheckAdoptedUnits("", units); err != nil {
+		return nil, errors.Annotate(err, "cannot adopt machine")
+	}
+	p.Adopt = nil
+	return c.addOneMachine(p)
+}
+
 // ProvisioningScript returns a shell script that, when run,
 // provisions a machine agent on the machine executing the script.
 func (c *Client) ProvisioningScript(args params.ProvisioningScriptParams) (params.ProvisioningScriptResult, error) {
@@ -482,6 +566,8 @@ func (c *Client) DestroyMachines(args params.DestroyMachines) error {
 }
 
 // ModelInfo returns information about the current model.
+//
+//juju:readonly
 func (c *Client) ModelInfo() (params.ModelInfo, error) {
 	if err := c.checkCanRead(); err != nil {
 		return params.ModelInfo{}, err
@@ -527,6 +613,8 @@ func modelInfo(st *state.State, user permission.UserAccess) (params.ModelUserInf
 }
 
 // ModelUserInfo returns information on all users in the model.
+//
+//juju:readonly
 func (c *Client) ModelUserInfo() (params.ModelUserInfoResults, error) {
 	var results params.ModelUserInfoResults
 	if err := c.checkCanRead(); err != nil {
@@ -556,6 +644,8 @@ func (c *Client) ModelUserInfo() (params.ModelUserInfoResults, error) {
 }
 
 // AgentVersion returns the current version that the API server is running.
+//
+//juju:readonly
 func (c *Client) AgentVersion() (params.AgentVersionResult, error) {
 	if err := c.checkCanRead(); err != nil {
 		return params.AgentVersionResult{}, err
@@ -620,6 +710,8 @@ func (c *Client) AbortCurrentUpgrade() error {
 }
 
 // FindTools returns a List containing all tools matching the given parameters.
+//
+//juju:readonly
 func (c *Client) FindTools(args params.FindToolsParams) (params.FindToolsResult, error) {
 	if err := c.checkCanWrite(); err != nil {
 		return params.FindToolsResult{}, err
@@ -682,6 +774,8 @@ func (c *Client) RetryProvisioning(p params.Entities) (params.ErrorResults, erro
 }
 
 // APIHostPorts returns the API host/port addresses stored in state.
+//
+//juju:readonly
 func (c *Client) APIHostPorts() (result params.APIHostPortsResult, err error) {
 	if err := c.checkCanWrite(); err != nil {
 		return result, err
@@ -696,6 +790,8 @@ func (c *Client) APIHostPorts() (result params.APIHostPortsResult, err error) {
 }
 
 // CACert returns the certificate used to validate the state connection.
+//
+//juju:readonly
 func (c *Client) CACert() (params.BytesResult, error) {
 	cfg, err := c.api.stateAccessor.ControllerConfig()
 	if err != nil {
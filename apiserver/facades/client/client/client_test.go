@@ -1015,6 +1015,24 @@ func (s *clientSuite) TestClientAddMachinesDefaultSeries(c *gc.C) {
 	}
 }
 
+func (s *clientSuite) TestClientAddMachinesQuotaExceeded(c *gc.C) {
+	err := s.State.SetModelQuotas(state.ModelQuotas{Machines: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	apiParams := make([]params.AddMachineParams, 3)
+	for i := 0; i < 3; i++ {
+		apiParams[i] = params.AddMachineParams{
+			Jobs: []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+		}
+	}
+	machines, err := s.APIState.Client().AddMachines(apiParams)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 3)
+	c.Assert(machines[0].Error, gc.IsNil)
+	c.Assert(machines[1].Error, gc.IsNil)
+	c.Assert(machines[2].Error, gc.ErrorMatches, "model quota exceeded: 1 machines requested, 2 of 2 already in use")
+	c.Assert(machines[2].Error, jc.Satisfies, params.IsCodeQuotaExceeded)
+}
+
 func (s *clientSuite) assertAddMachines(c *gc.C) {
 	apiParams := make([]params.AddMachineParams, 3)
 	for i := 0; i < 3; i++ {
@@ -1200,6 +1218,79 @@ func (s *clientSuite) TestClientAddMachinesWithInstanceIdSomeErrors(c *gc.C) {
 	}
 }
 
+func (s *clientSuite) TestClientAddMachinesAdoptExisting(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
+		InstanceId: "manual:10.0.0.1",
+		Nonce:      "manual:10.0.0.1:old",
+	})
+	machines, err := s.APIState.Client().AddMachines([]params.AddMachineParams{{
+		Jobs:       []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+		InstanceId: "manual:10.0.0.1",
+		Nonce:      "manual:10.0.0.1:new",
+		Adopt: &params.AdoptMachineParams{
+			AgentTag:  machine.Tag().String(),
+			ModelUUID: s.State.ModelUUID(),
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 1)
+	c.Assert(machines[0].Error, gc.IsNil)
+	c.Assert(machines[0].Machine, gc.Equals, machine.Id())
+
+	err = machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machine.CheckProvisioned("manual:10.0.0.1:new"), jc.IsTrue)
+	all, err := s.State.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+}
+
+func (s *clientSuite) TestClientAddMachinesAdoptFromOtherModel(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
+		InstanceId: "manual:10.0.0.1",
+		Nonce:      "manual:10.0.0.1:old",
+	})
+	machines, err := s.APIState.Client().AddMachines([]params.AddMachineParams{{
+		Jobs:       []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+		InstanceId: "manual:10.0.0.2",
+		Nonce:      "manual:10.0.0.2:new",
+		Adopt: &params.AdoptMachineParams{
+			AgentTag:  machine.Tag().String(),
+			ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 1)
+	c.Assert(machines[0].Error, gc.IsNil)
+	c.Assert(machines[0].Machine, gc.Not(gc.Equals), machine.Id())
+	added, err := s.State.Machine(machines[0].Machine)
+	c.Assert(err, jc.ErrorIsNil)
+	instanceId, err := added.InstanceId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instanceId, gc.Equals, instance.Id("manual:10.0.0.2"))
+	c.Assert(added.CheckProvisioned("manual:10.0.0.2:new"), jc.IsTrue)
+
+	err = machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machine.CheckProvisioned("manual:10.0.0.1:old"), jc.IsTrue)
+}
+
+func (s *clientSuite) TestClientAddMachinesAdoptRefusesUnitsOfOtherMachines(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	machines, err := s.APIState.Client().AddMachines([]params.AddMachineParams{{
+		Jobs:       []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+		InstanceId: "manual:10.0.0.2",
+		Nonce:      "manual:10.0.0.2:new",
+		Adopt: &params.AdoptMachineParams{
+			ModelUUID: s.State.ModelUUID(),
+			Units:     []string{unit.Name()},
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 1)
+	c.Assert(machines[0].Error, gc.ErrorMatches, `cannot adopt machine: unit ".*" found on the machine is assigned to machine .*`)
+}
+
 func (s *clientSuite) checkInstance(c *gc.C, id, instanceId, nonce string,
 	hc instance.HardwareCharacteristics, addr []network.Address) {
 
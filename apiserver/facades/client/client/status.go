@@ -101,6 +101,8 @@ func (c *Client) machineStatusHistory(machineTag names.MachineTag, filter status
 }
 
 // StatusHistory returns a slice of past statuses for several entities.
+//
+//juju:readonly
 func (c *Client) StatusHistory(request params.StatusHistoryRequests) params.StatusHistoryResults {
 
 	results := params.StatusHistoryResults{}
@@ -162,7 +164,18 @@ func (c *Client) StatusHistory(request params.StatusHistoryRequests) params.Stat
 	return results
 }
 
+// FullStatus gives the information needed for juju status over the
+// api. Version 1 of the facade does not support AsOf, so it is ignored.
+//
+//juju:readonly
+func (c *ClientV1) FullStatus(args params.StatusParams) (params.FullStatus, error) {
+	args.AsOf = nil
+	return c.Client.FullStatus(args)
+}
+
 // FullStatus gives the information needed for juju status over the api
+//
+//juju:readonly
 func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error) {
 	if err := c.checkCanRead(); err != nil {
 		return params.FullStatus{}, err
@@ -174,7 +187,12 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 	if context.model, err = c.api.stateAccessor.Model(); err != nil {
 		return noStatus, errors.Annotate(err, "could not fetch model")
 	}
-	if context.status, err = context.model.LoadModelStatus(); err != nil {
+	if args.AsOf != nil {
+		context.status, err = context.model.LoadModelStatusAt(*args.AsOf)
+	} else {
+		context.status, err = context.model.LoadModelStatus()
+	}
+	if err != nil {
 		return noStatus, errors.Annotate(err, "could not load model status values")
 	}
 	if context.applications, context.units, context.latestCharms, err =
@@ -208,6 +226,35 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 			return noStatus, errors.Annotate(err, " could not fetch leaders")
 		}
 	}
+	if context.branchUnits, err = fetchBranchUnits(context.model); err != nil {
+		return noStatus, errors.Annotate(err, "could not fetch branch")
+	}
+	if context.utilization, err = c.api.stateAccessor.AllUnitsUtilization(); err != nil {
+		return noStatus, errors.Annotate(err, "could not fetch unit utilization")
+	}
+	if context.metricsActivity, err = c.api.stateAccessor.AllUnitsMetricsActivity(); err != nil {
+		return noStatus, errors.Annotate(err, "could not fetch metrics activity")
+	}
+	if context.fanStatus, err = c.api.stateAccessor.AllMachinesFanStatus(); err != nil {
+		return noStatus, errors.Annotate(err, "could not fetch fan status")
+	}
+	if context.proxyStatus, err = c.api.stateAccessor.AllMachinesProxyStatus(); err != nil {
+		return noStatus, errors.Annotate(err, "could not fetch proxy status")
+	}
+	// Maintenance modes and offline acknowledgements describe the
+	// present, so they are not applied to status as of a past time.
+	if args.AsOf == nil {
+		if context.maintenance, err = fetchMaintenanceModes(c.api.stateAccessor); err != nil {
+			return noStatus, errors.Annotate(err, "could not fetch maintenance modes")
+		}
+		if context.offline, err = fetchAgentsOffline(c.api.stateAccessor); err != nil {
+			return noStatus, errors.Annotate(err, "could not fetch offline acknowledgements")
+		}
+	}
+
+	if args.AsOf != nil {
+		context.removeAbsent()
+	}
 
 	logger.Debugf("Applications: %v", context.applications)
 	logger.Debugf("Remote applications: %v", context.consumerRemoteApplications)
@@ -313,6 +360,18 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 	if err != nil {
 		return noStatus, errors.Annotate(err, "cannot determine model status")
 	}
+	if args.AsOf != nil {
+		info, err := context.status.Model()
+		if err != nil {
+			return noStatus, errors.Annotate(err, "cannot determine model status")
+		}
+		modelStatus.ModelStatus = params.DetailedStatus{
+			Status: info.Status.String(),
+			Info:   info.Message,
+			Since:  info.Since,
+			Data:   info.Data,
+		}
+	}
 	return params.FullStatus{
 		Model:              modelStatus,
 		Machines:           context.processMachines(),
@@ -401,6 +460,80 @@ type statusContext struct {
 	units         map[string]map[string]*state.Unit
 	latestCharms  map[charm.URL]*state.Charm
 	leaders       map[string]string
+
+	// branchUnits: unit name -> name of the model's branch, for the
+	// units tracking it.
+	branchUnits map[string]string
+
+	// utilization: unit name -> resources most recently reported to
+	// be used by the unit.
+	utilization map[string]state.UnitUtilization
+
+	// metricsActivity: unit name -> when metrics were last collected
+	// from the unit and sent.
+	metricsActivity map[string]state.UnitMetricsActivity
+
+	// fanStatus: machine id -> health of the model's fan overlays on
+	// the machine.
+	fanStatus map[string]state.MachineFanStatus
+
+	// proxyStatus: machine id -> outcome of the machine agent's most
+	// recent attempt to apply the model's proxy settings.
+	proxyStatus map[string]state.ProxyStatus
+
+	// maintenance: entity tag -> maintenance mode in effect for the
+	// entity.
+	maintenance map[string]state.MaintenanceMode
+
+	// offline: machine or unit tag -> acknowledgement that the
+	// entity's agent is offline.
+	offline map[string]state.AgentOffline
+}
+
+// fetchBranchUnits returns a map from the names of the units tracking
+// the model's branch to the name of the branch.
+func fetchBranchUnits(model *state.Model) (map[string]string, error) {
+	branch, err := model.Branch()
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]string)
+	for _, units := range branch.AssignedUnits() {
+		for _, name := range units {
+			result[name] = branch.Name()
+		}
+	}
+	return result, nil
+}
+
+// fetchMaintenanceModes returns a map from entity tag to the
+// maintenance mode in effect for the entity.
+func fetchMaintenanceModes(st Backend) (map[string]state.MaintenanceMode, error) {
+	modes, err := st.AllMaintenanceModes()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]state.MaintenanceMode, len(modes))
+	for _, mode := range modes {
+		result[mode.Tag.String()] = mode
+	}
+	return result, nil
+}
+
+// fetchAgentsOffline returns a map from machine or unit tag to the
+// acknowledgement that the entity's agent is offline.
+func fetchAgentsOffline(st Backend) (map[string]state.AgentOffline, error) {
+	acks, err := st.AllAgentsOffline()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]state.AgentOffline, len(acks))
+	for _, ack := range acks {
+		result[ack.Tag.String()] = ack
+	}
+	return result, nil
 }
 
 // fetchMachines returns a map from top level machine id to machines, where machines[0] is the host
@@ -706,12 +839,35 @@ func (c *statusContext) makeMachineStatus(machine *state.Machine) (status params
 	status.Jobs = paramsJobsFromJobs(machine.Jobs())
 	status.WantsVote = machine.WantsVote()
 	status.HasVote = machine.HasVote()
+	status.Placement = machine.Placement()
+	status.ProxyError = c.proxyStatus[machineID].Error
+	status.LXDProfiles = machine.CharmProfiles()
+	for _, overlay := range c.fanStatus[machineID].Overlays {
+		status.FanOverlays = append(status.FanOverlays, params.FanOverlayStatus{
+			Underlay: overlay.Underlay,
+			Overlay:  overlay.Overlay,
+			Bridge:   overlay.Bridge,
+			Address:  overlay.Address,
+			Healthy:  overlay.Healthy,
+			Message:  overlay.Message,
+		})
+	}
 	sInfo, err := c.status.MachineInstance(machineID)
 	populateStatusFromStatusInfoAndErr(&status.InstanceStatus, sInfo, err)
+	status.Offline = c.offlineStatus(machine.Tag())
+	if ack := c.machineAgentOffline(machineID); ack != nil {
+		suppressForOffline(&status.AgentStatus, ack)
+	}
+	status.Maintenance = c.maintenanceStatus(machine.Tag())
+	if mode := c.machineMaintenanceMode(machineID); mode != nil {
+		suppressForMaintenance(&status.AgentStatus, mode)
+		suppressForMaintenance(&status.InstanceStatus, mode)
+	}
 	// TODO: fetch all instance data for machines in one go.
-	instid, err := machine.InstanceId()
+	instid, displayName, err := machine.InstanceNames()
 	if err == nil {
 		status.InstanceId = instid
+		status.DisplayName = displayName
 		addr, err := machine.PublicAddress()
 		if err != nil {
 			// Usually this indicates that no addresses have been set on the
@@ -792,6 +948,9 @@ func (c *statusContext) makeMachineStatus(machine *state.Machine) (status params
 		}
 	} else {
 		status.Constraints = constraints.String()
+		if constraints.VirtType != nil {
+			status.VirtType = *constraints.VirtType
+		}
 	}
 	// TODO: preload all hardware characteristics.
 	hc, err := machine.HardwareCharacteristics()
@@ -801,6 +960,9 @@ func (c *statusContext) makeMachineStatus(machine *state.Machine) (status params
 		}
 	} else {
 		status.Hardware = hc.String()
+		if hc.AvailabilityZone != nil {
+			status.AvailabilityZone = *hc.AvailabilityZone
+		}
 	}
 	status.Containers = make(map[string]params.MachineStatus)
 	return
@@ -831,13 +993,71 @@ func (context *statusContext) processRelations() []params.RelationStatus {
 			Scope:     string(scope),
 			Endpoints: eps,
 		}
-		rStatus, err := relation.Status()
+		rStatus, err := context.status.Relation(relation.Id())
 		populateStatusFromStatusInfoAndErr(&relStatus.Status, rStatus, err)
 		out = append(out, relStatus)
 	}
 	return out
 }
 
+// removeAbsent removes the machines, applications, units and
+// relations that have no status. When the status values are loaded as
+// of an earlier time, these are the entities that did not exist then.
+func (context *statusContext) removeAbsent() {
+	absent := func(_ status.StatusInfo, err error) bool {
+		return errors.IsNotFound(err)
+	}
+	for id, machineList := range context.machines {
+		if absent(context.status.MachineAgent(id)) {
+			delete(context.machines, id)
+			continue
+		}
+		present := make([]*state.Machine, 0, len(machineList))
+		for _, m := range machineList {
+			if !absent(context.status.MachineAgent(m.Id())) {
+				present = append(present, m)
+			}
+		}
+		context.machines[id] = present
+	}
+	for _, unitMap := range context.units {
+		for name := range unitMap {
+			if absent(context.status.UnitAgent(name)) {
+				delete(unitMap, name)
+			}
+		}
+	}
+	for appName := range context.applications {
+		if absent(context.status.Application(appName, nil)) {
+			delete(context.applications, appName)
+			delete(context.units, appName)
+		}
+	}
+	for _, relation := range context.getAllRelations() {
+		if !absent(context.status.Relation(relation.Id())) {
+			continue
+		}
+		// Peer relations are created along with their application,
+		// without status history of their own.
+		eps := relation.Endpoints()
+		if len(eps) == 1 {
+			if _, ok := context.applications[eps[0].ApplicationName]; ok {
+				continue
+			}
+		}
+		delete(context.relationsById, relation.Id())
+		for _, ep := range eps {
+			var kept []*state.Relation
+			for _, r := range context.relations[ep.ApplicationName] {
+				if r.Id() != relation.Id() {
+					kept = append(kept, r)
+				}
+			}
+			context.relations[ep.ApplicationName] = kept
+		}
+	}
+}
+
 // This method exists only to dedup the loaded relations as they will
 // appear multiple times in context.relations.
 func (context *statusContext) getAllRelations() []*state.Relation {
@@ -894,6 +1114,8 @@ func (context *statusContext) processApplication(application *state.Application)
 		Series:  application.Series(),
 		Exposed: application.IsExposed(),
 		Life:    processLife(application),
+
+		LoadBalancerAddress: application.LoadBalancerAddress(),
 	}
 
 	if latestCharm, ok := context.latestCharms[*applicationCharm.URL().WithRevision(-1)]; ok && latestCharm != nil {
@@ -913,7 +1135,20 @@ func (context *statusContext) processApplication(application *state.Application)
 	}
 	var unitNames []string
 	for _, unit := range units {
+		if context.unitAgentOffline(unit) != nil {
+			// Units acknowledged offline do not degrade the
+			// application's status.
+			continue
+		}
 		unitNames = append(unitNames, unit.Name())
+		workloadStatus, err := context.status.UnitWorkload(unit.Name())
+		if err != nil {
+			processedStatus.Err = common.ServerError(err)
+			return processedStatus
+		}
+		if workloadStatus.Status == status.Unhealthy {
+			processedStatus.UnhealthyUnits++
+		}
 	}
 	applicationStatus, err := context.status.Application(application.Name(), unitNames)
 	if err != nil {
@@ -924,12 +1159,19 @@ func (context *statusContext) processApplication(application *state.Application)
 	processedStatus.Status.Info = applicationStatus.Message
 	processedStatus.Status.Data = applicationStatus.Data
 	processedStatus.Status.Since = applicationStatus.Since
+	processedStatus.Maintenance = context.maintenanceStatus(application.Tag())
+	if mode := context.maintenanceMode(application.Tag()); mode != nil {
+		suppressForMaintenance(&processedStatus.Status, mode)
+	}
 
 	metrics := applicationCharm.Metrics()
 	planRequired := metrics != nil && metrics.Plan != nil && metrics.Plan.Required
 	if planRequired || len(application.MetricCredentials()) > 0 {
 		processedStatus.MeterStatuses = context.processUnitMeterStatuses(units)
 	}
+	if metrics != nil && len(metrics.Metrics) > 0 {
+		processedStatus.Metrics = context.processApplicationMetrics(metrics, units)
+	}
 
 	versions := make([]status.StatusInfo, 0, len(units))
 	for _, unit := range units {
@@ -1037,6 +1279,42 @@ func (context *statusContext) processUnitMeterStatuses(units map[string]*state.U
 	return nil
 }
 
+// processApplicationMetrics returns the metrics declared by an
+// application's charm, and when they were last collected from any of
+// the application's units and sent.
+func (context *statusContext) processApplicationMetrics(
+	metrics *charm.Metrics, units map[string]*state.Unit,
+) *params.ApplicationMetrics {
+	result := &params.ApplicationMetrics{
+		Declared:           make(map[string]params.CharmMetric),
+		CollectionInterval: state.MetricsCollectionPeriod,
+	}
+	for name, metric := range metrics.Metrics {
+		result.Declared[name] = params.CharmMetric{
+			Type:        string(metric.Type),
+			Description: metric.Description,
+		}
+	}
+	for name := range units {
+		activity, ok := context.metricsActivity[name]
+		if !ok {
+			continue
+		}
+		if result.LastCollected == nil || activity.LastCollected.After(*result.LastCollected) {
+			lastCollected := activity.LastCollected
+			result.LastCollected = &lastCollected
+		}
+		if activity.LastSent.IsZero() {
+			continue
+		}
+		if result.LastSent == nil || activity.LastSent.After(*result.LastSent) {
+			lastSent := activity.LastSent
+			result.LastSent = &lastSent
+		}
+	}
+	return result
+}
+
 func (context *statusContext) processUnits(units map[string]*state.Unit, applicationCharm string) map[string]params.UnitStatus {
 	unitsMap := make(map[string]params.UnitStatus)
 	for _, unit := range units {
@@ -1074,6 +1352,19 @@ func (context *statusContext) processUnit(unit *state.Unit, applicationCharm str
 	}
 
 	result.AgentStatus, result.WorkloadStatus = context.processUnitAndAgentStatus(unit)
+	result.Offline = context.offlineStatus(unit.Tag())
+	if ack := context.unitAgentOffline(unit); ack != nil && suppressForOffline(&result.AgentStatus, ack) {
+		if result.WorkloadStatus.Status == status.Unknown.String() {
+			// The workload is unknown only because the agent is
+			// not communicating.
+			suppressStatus(&result.WorkloadStatus, status.Unknown, offlineInfo(ack))
+		}
+	}
+	result.Maintenance = context.maintenanceStatus(unit.Tag())
+	if mode := context.unitMaintenanceMode(unit); mode != nil {
+		suppressForMaintenance(&result.AgentStatus, mode)
+		suppressForMaintenance(&result.WorkloadStatus, mode)
+	}
 
 	if subUnits := unit.SubordinateNames(); len(subUnits) > 0 {
 		result.Subordinates = make(map[string]params.UnitStatus)
@@ -1088,9 +1379,171 @@ func (context *statusContext) processUnit(unit *state.Unit, applicationCharm str
 	if leader := context.leaders[unit.ApplicationName()]; leader == unit.Name() {
 		result.Leader = true
 	}
+	result.Branch = context.branchUnits[unit.Name()]
+	if utilization, ok := context.utilization[unit.Name()]; ok {
+		result.Utilization = &params.UnitUtilization{
+			CPUPercent:  utilization.CPUPercent,
+			MemoryBytes: utilization.MemoryBytes,
+			DiskBytes:   utilization.DiskBytes,
+			Updated:     utilization.Updated,
+		}
+	}
+	return result
+}
+
+// maintenanceSuppressed holds the statuses that are reported as
+// maintenance for entities in maintenance mode.
+var maintenanceSuppressed = set.NewStrings(
+	status.Error.String(),
+	status.Blocked.String(),
+	status.Down.String(),
+	status.Lost.String(),
+	status.ProvisioningError.String(),
+	status.Unhealthy.String(),
+)
+
+// maintenanceMode returns the maintenance mode in effect for the
+// first of the entities with the given tags that is in maintenance
+// mode, or nil if none of them is.
+func (context *statusContext) maintenanceMode(tags ...names.Tag) *state.MaintenanceMode {
+	for _, tag := range tags {
+		if mode, ok := context.maintenance[tag.String()]; ok {
+			return &mode
+		}
+	}
+	return nil
+}
+
+// machineMaintenanceMode returns the maintenance mode in effect for
+// the machine with the given id, or for the machine hosting it.
+func (context *statusContext) machineMaintenanceMode(id string) *state.MaintenanceMode {
+	for ; id != ""; id = state.ParentId(id) {
+		if mode := context.maintenanceMode(names.NewMachineTag(id)); mode != nil {
+			return mode
+		}
+	}
+	return nil
+}
+
+// unitMaintenanceMode returns the maintenance mode in effect for the
+// unit, its application, or the machine it is assigned to.
+func (context *statusContext) unitMaintenanceMode(unit *state.Unit) *state.MaintenanceMode {
+	appTag := names.NewApplicationTag(unit.ApplicationName())
+	if mode := context.maintenanceMode(unit.Tag(), appTag); mode != nil {
+		return mode
+	}
+	if machineId, err := unit.AssignedMachineId(); err == nil {
+		return context.machineMaintenanceMode(machineId)
+	}
+	return nil
+}
+
+// maintenanceStatus returns the maintenance mode of the entity with
+// the given tag, if it is itself in maintenance mode.
+func (context *statusContext) maintenanceStatus(tag names.Tag) *params.MaintenanceStatus {
+	mode, ok := context.maintenance[tag.String()]
+	if !ok {
+		return nil
+	}
+	result := &params.MaintenanceStatus{Reason: mode.Reason}
+	if !mode.Expires.IsZero() {
+		expires := mode.Expires
+		result.Expires = &expires
+	}
 	return result
 }
 
+// suppressForMaintenance reports an alert-worthy status of an entity
+// in maintenance mode as maintenance instead, keeping the original
+// status and message in the status data.
+func suppressForMaintenance(s *params.DetailedStatus, mode *state.MaintenanceMode) {
+	if !maintenanceSuppressed.Contains(s.Status) {
+		return
+	}
+	suppressStatus(s, status.Maintenance, fmt.Sprintf("in maintenance: %s", mode.Reason))
+}
+
+// suppressStatus replaces the status and message, keeping the
+// original status and message in the status data.
+func suppressStatus(s *params.DetailedStatus, replacement status.Status, info string) {
+	data := make(map[string]interface{}, len(s.Data)+2)
+	for k, v := range s.Data {
+		data[k] = v
+	}
+	data["suppressed-status"] = s.Status
+	data["suppressed-info"] = s.Info
+	s.Data = data
+	s.Status = replacement.String()
+	s.Info = info
+}
+
+// agentOffline returns the acknowledgement that the agent of the
+// machine or unit with the given tag is offline, or nil if there is
+// none.
+func (context *statusContext) agentOffline(tag names.Tag) *state.AgentOffline {
+	if ack, ok := context.offline[tag.String()]; ok {
+		return &ack
+	}
+	return nil
+}
+
+// machineAgentOffline returns the acknowledgement that the agent of
+// the machine with the given id, or of the machine hosting it, is
+// offline.
+func (context *statusContext) machineAgentOffline(id string) *state.AgentOffline {
+	for ; id != ""; id = state.ParentId(id) {
+		if ack := context.agentOffline(names.NewMachineTag(id)); ack != nil {
+			return ack
+		}
+	}
+	return nil
+}
+
+// unitAgentOffline returns the acknowledgement that the agent of the
+// unit, or of the machine it is assigned to, is offline.
+func (context *statusContext) unitAgentOffline(unit *state.Unit) *state.AgentOffline {
+	if ack := context.agentOffline(unit.Tag()); ack != nil {
+		return ack
+	}
+	if machineId, err := unit.AssignedMachineId(); err == nil {
+		return context.machineAgentOffline(machineId)
+	}
+	return nil
+}
+
+// offlineStatus returns the acknowledgement that the agent of the
+// entity with the given tag is offline, if it is itself acknowledged.
+func (context *statusContext) offlineStatus(tag names.Tag) *params.AgentOfflineStatus {
+	ack, ok := context.offline[tag.String()]
+	if !ok {
+		return nil
+	}
+	return &params.AgentOfflineStatus{
+		Reason:         ack.Reason,
+		AcknowledgedBy: ack.AcknowledgedBy.Id(),
+		Acknowledged:   ack.Acknowledged,
+		Expires:        ack.Expires,
+	}
+}
+
+// suppressForOffline reports the down or lost status of an agent
+// acknowledged offline as offline instead, keeping the original status
+// and message in the status data. It returns whether the status was
+// suppressed.
+func suppressForOffline(s *params.DetailedStatus, ack *state.AgentOffline) bool {
+	switch s.Status {
+	case status.Down.String(), status.Lost.String():
+	default:
+		return false
+	}
+	suppressStatus(s, status.Offline, offlineInfo(ack))
+	return true
+}
+
+func offlineInfo(ack *state.AgentOffline) string {
+	return fmt.Sprintf("acknowledged offline by %s: %s", ack.AcknowledgedBy.Id(), ack.Reason)
+}
+
 func (context *statusContext) unitByName(name string) *state.Unit {
 	applicationName := strings.Split(name, "/")[0]
 	return context.units[applicationName][name]
@@ -13,14 +13,18 @@ import (
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade/facadetest"
+	"github.com/juju/juju/apiserver/facades/client/client"
 	"github.com/juju/juju/apiserver/facades/controller/charmrevisionupdater"
 	"github.com/juju/juju/apiserver/facades/controller/charmrevisionupdater/testing"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/instance"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
 	"github.com/juju/juju/testing/factory"
 )
 
@@ -60,6 +64,48 @@ func (s *statusSuite) TestFullStatus(c *gc.C) {
 	c.Check(resultMachine.Series, gc.Equals, machine.Series())
 }
 
+func (s *statusSuite) TestFullStatusMachineProxyError(c *gc.C) {
+	machine := s.addMachine(c)
+	err := machine.SetProxyStatus(state.ProxyStatus{Error: "cannot write apt proxy"})
+	c.Assert(err, jc.ErrorIsNil)
+	client := s.APIState.Client()
+	status, err := client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Machines[machine.Id()].ProxyError, gc.Equals, "cannot write apt proxy")
+}
+
+func (s *statusSuite) TestFullStatusMachineLXDProfiles(c *gc.C) {
+	machine := s.addMachine(c)
+	err := machine.SetCharmProfiles([]string{"juju-controller-sriov-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	client := s.APIState.Client()
+	status, err := client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Machines[machine.Id()].LXDProfiles, jc.DeepEquals, []string{"juju-controller-sriov-1"})
+}
+
+func (s *statusSuite) TestFullStatusMachineFanOverlays(c *gc.C) {
+	machine := s.addMachine(c)
+	err := machine.SetFanStatus([]state.FanOverlayStatus{{
+		Underlay: "172.31.0.0/16",
+		Overlay:  "253.0.0.0/8",
+		Bridge:   "fan-253",
+		Address:  "253.64.10.1",
+		Healthy:  true,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	client := s.APIState.Client()
+	status, err := client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Machines[machine.Id()].FanOverlays, jc.DeepEquals, []params.FanOverlayStatus{{
+		Underlay: "172.31.0.0/16",
+		Overlay:  "253.0.0.0/8",
+		Bridge:   "fan-253",
+		Address:  "253.64.10.1",
+		Healthy:  true,
+	}})
+}
+
 func (s *statusSuite) TestFullStatusUnitLeadership(c *gc.C) {
 	u := s.Factory.MakeUnit(c, nil)
 	s.State.LeadershipClaimer().ClaimLeadership(u.ApplicationName(), u.Name(), time.Minute)
@@ -73,8 +119,98 @@ func (s *statusSuite) TestFullStatusUnitLeadership(c *gc.C) {
 	c.Assert(unit.Leader, jc.IsTrue)
 }
 
+func (s *statusSuite) TestFullStatusAt(c *gc.C) {
+	machine := s.addMachine(c)
+	base := time.Now().Add(time.Hour)
+	at := func(d time.Duration) *time.Time {
+		t := base.Add(d)
+		return &t
+	}
+	err := machine.SetStatus(status.StatusInfo{Status: status.Started, Since: at(0)})
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetStatus(status.StatusInfo{Status: status.Down, Message: "agent lost", Since: at(2 * time.Minute)})
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.APIState.Client()
+	fullStatus, err := client.StatusAt(nil, *at(time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fullStatus.Model.ModelStatus.Status, gc.Equals, "available")
+	c.Assert(fullStatus.Machines, gc.HasLen, 1)
+	c.Check(fullStatus.Machines[machine.Id()].AgentStatus.Status, gc.Equals, "started")
+	c.Check(fullStatus.Machines[machine.Id()].AgentStatus.Info, gc.Equals, "")
+
+	fullStatus, err = client.StatusAt(nil, *at(3 * time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fullStatus.Machines[machine.Id()].AgentStatus.Status, gc.Equals, "down")
+	c.Check(fullStatus.Machines[machine.Id()].AgentStatus.Info, gc.Equals, "agent lost")
+}
+
+func (s *statusSuite) TestFullStatusAtOmitsLaterEntities(c *gc.C) {
+	machine := s.addMachine(c)
+	asOf := time.Now()
+	s.addMachine(c)
+	s.Factory.MakeUnit(c, nil)
+	s.Factory.MakeRelation(c, nil)
+
+	client := s.APIState.Client()
+	fullStatus, err := client.StatusAt(nil, asOf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fullStatus.Machines, gc.HasLen, 1)
+	_, ok := fullStatus.Machines[machine.Id()]
+	c.Check(ok, jc.IsTrue)
+	c.Check(fullStatus.Applications, gc.HasLen, 0)
+	c.Check(fullStatus.Relations, gc.HasLen, 0)
+
+	fullStatus, err = client.StatusAt(nil, time.Now())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fullStatus.Machines, gc.HasLen, 3)
+	c.Check(fullStatus.Applications, gc.HasLen, 3)
+	c.Check(fullStatus.Relations, gc.HasLen, 1)
+}
+
+func (s *statusSuite) TestFullStatusV1IgnoresAsOf(c *gc.C) {
+	s.addMachine(c)
+	asOf := time.Now()
+	s.addMachine(c)
+
+	api, err := client.NewFacadeV1(&facadetest.Context{
+		State_:     s.State,
+		StatePool_: s.StatePool,
+		Auth_: apiservertesting.FakeAuthorizer{
+			Tag:        s.AdminUserTag(c),
+			Controller: true,
+		},
+		Resources_: common.NewResources(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	fullStatus, err := api.FullStatus(params.StatusParams{AsOf: &asOf})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fullStatus.Machines, gc.HasLen, 2)
+}
+
 var _ = gc.Suite(&statusUnitTestSuite{})
 
+func (s *statusUnitTestSuite) TestMachineZoneAndPlacement(c *gc.C) {
+	zone := "zone-a"
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
+		InstanceId:      instance.Id("i-0"),
+		DisplayName:     "snowflake",
+		Constraints:     constraints.MustParse("virt-type=kvm"),
+		Characteristics: &instance.HardwareCharacteristics{AvailabilityZone: &zone},
+	})
+
+	client := s.APIState.Client()
+	status, err := client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	mStatus, ok := status.Machines[machine.Id()]
+	c.Assert(ok, jc.IsTrue)
+	c.Check(mStatus.AvailabilityZone, gc.Equals, "zone-a")
+	c.Check(mStatus.VirtType, gc.Equals, "kvm")
+	c.Check(mStatus.Placement, gc.Equals, "")
+	c.Check(mStatus.DisplayName, gc.Equals, "snowflake")
+}
+
 type statusUnitTestSuite struct {
 	baseSuite
 }
@@ -186,6 +322,40 @@ func (s *statusUnitTestSuite) TestMeterStatus(c *gc.C) {
 	}
 }
 
+func (s *statusUnitTestSuite) TestApplicationMetrics(c *gc.C) {
+	meteredCharm := s.Factory.MakeCharm(c, &factory.CharmParams{Name: "metered", URL: "cs:quantal/metered"})
+	application := s.Factory.MakeApplication(c, &factory.ApplicationParams{Charm: meteredCharm})
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application, SetCharmURL: true})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: application, SetCharmURL: true})
+	plain := s.Factory.MakeApplication(c, nil)
+
+	status, err := s.APIState.Client().Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	metrics := status.Applications[application.Name()].Metrics
+	c.Assert(metrics, gc.NotNil)
+	c.Assert(metrics.Declared["pings"], jc.DeepEquals, params.CharmMetric{
+		Type:        "gauge",
+		Description: "Description of the metric.",
+	})
+	c.Assert(metrics.CollectionInterval, gc.Equals, state.MetricsCollectionPeriod)
+	c.Assert(metrics.LastCollected, gc.IsNil)
+	c.Assert(metrics.LastSent, gc.IsNil)
+	c.Assert(status.Applications[plain.Name()].Metrics, gc.IsNil)
+
+	collected := time.Now().Add(-time.Hour).Round(time.Second)
+	batch := s.Factory.MakeMetric(c, &factory.MetricParams{Unit: unit, Time: &collected})
+	err = batch.SetSent(collected.Add(time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+
+	status, err = s.APIState.Client().Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	metrics = status.Applications[application.Name()].Metrics
+	c.Assert(metrics.LastCollected, gc.NotNil)
+	c.Assert(metrics.LastCollected.Equal(collected), jc.IsTrue)
+	c.Assert(metrics.LastSent, gc.NotNil)
+	c.Assert(metrics.LastSent.Equal(collected.Add(time.Minute)), jc.IsTrue)
+}
+
 func (s *statusUnitTestSuite) TestNoMeterStatusWhenNotRequired(c *gc.C) {
 	service := s.Factory.MakeApplication(c, nil)
 
@@ -280,6 +450,135 @@ func checkUnitVersion(c *gc.C, appStatus params.ApplicationStatus, unit *state.U
 	c.Check(unitStatus.WorkloadVersion, gc.Equals, expectedVersion)
 }
 
+func (s *statusUnitTestSuite) TestUnitBranch(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	tracking := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+	stable := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.AddBranch("canary", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	branch, err := model.Branch()
+	c.Assert(err, jc.ErrorIsNil)
+	err = branch.AssignUnit(tracking.Name())
+	c.Assert(err, jc.ErrorIsNil)
+
+	status, err := s.APIState.Client().Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	appStatus, ok := status.Applications[application.Name()]
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(appStatus.Units[tracking.Name()].Branch, gc.Equals, "canary")
+	c.Assert(appStatus.Units[stable.Name()].Branch, gc.Equals, "")
+}
+
+func (s *statusUnitTestSuite) TestUnitUtilization(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	reported := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+	silent := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+	err := reported.SetUtilization(state.UnitUtilization{
+		CPUPercent:  25,
+		MemoryBytes: 1024,
+		DiskBytes:   2048,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	utilization, err := reported.Utilization()
+	c.Assert(err, jc.ErrorIsNil)
+
+	status, err := s.APIState.Client().Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	appStatus, ok := status.Applications[application.Name()]
+	c.Assert(ok, jc.IsTrue)
+	result := appStatus.Units[reported.Name()].Utilization
+	c.Assert(result, gc.NotNil)
+	c.Assert(result.CPUPercent, gc.Equals, 25.0)
+	c.Assert(result.MemoryBytes, gc.Equals, int64(1024))
+	c.Assert(result.DiskBytes, gc.Equals, int64(2048))
+	c.Assert(result.Updated.Equal(utilization.Updated), jc.IsTrue)
+	c.Assert(appStatus.Units[silent.Name()].Utilization, gc.IsNil)
+}
+
+func (s *statusUnitTestSuite) TestMaintenanceModeSuppressesAlerts(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+	other := s.Factory.MakeUnit(c, nil)
+	for _, u := range []*state.Unit{unit, other} {
+		err := u.SetStatus(status.StatusInfo{Status: status.Blocked, Message: "waiting for db"})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err := s.State.SetMaintenanceMode(state.MaintenanceMode{
+		Tag:    application.Tag(),
+		Reason: "database migration",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	fullStatus, err := s.APIState.Client().Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	appStatus := fullStatus.Applications[application.Name()]
+	c.Assert(appStatus.Maintenance, jc.DeepEquals, &params.MaintenanceStatus{Reason: "database migration"})
+	unitStatus := appStatus.Units[unit.Name()]
+	c.Assert(unitStatus.Maintenance, gc.IsNil)
+	c.Assert(unitStatus.WorkloadStatus.Status, gc.Equals, "maintenance")
+	c.Assert(unitStatus.WorkloadStatus.Info, gc.Equals, "in maintenance: database migration")
+	c.Assert(unitStatus.WorkloadStatus.Data["suppressed-status"], gc.Equals, "blocked")
+	c.Assert(unitStatus.WorkloadStatus.Data["suppressed-info"], gc.Equals, "waiting for db")
+
+	otherStatus := fullStatus.Applications[other.ApplicationName()].Units[other.Name()]
+	c.Assert(otherStatus.WorkloadStatus.Status, gc.Equals, "blocked")
+}
+
+func (s *statusUnitTestSuite) TestAgentOfflineSuppressesLost(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	offline := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+	healthy := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+	for u, workload := range map[*state.Unit]status.Status{offline: status.Blocked, healthy: status.Active} {
+		err := u.SetAgentStatus(status.StatusInfo{Status: status.Idle})
+		c.Assert(err, jc.ErrorIsNil)
+		err = u.SetStatus(status.StatusInfo{Status: workload})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	expires := time.Now().Add(24 * time.Hour).UTC()
+	err := s.State.SetAgentOffline(state.AgentOffline{
+		Tag:            offline.Tag(),
+		Reason:         "replacing motherboard",
+		AcknowledgedBy: names.NewUserTag("admin"),
+		Expires:        expires,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	fullStatus, err := s.APIState.Client().Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	appStatus := fullStatus.Applications[application.Name()]
+	c.Assert(appStatus.Status.Status, gc.Equals, "active")
+
+	unitStatus := appStatus.Units[offline.Name()]
+	c.Assert(unitStatus.Offline, gc.NotNil)
+	c.Assert(unitStatus.Offline.Reason, gc.Equals, "replacing motherboard")
+	c.Assert(unitStatus.Offline.AcknowledgedBy, gc.Equals, "admin")
+	c.Assert(unitStatus.Offline.Expires.Equal(expires), jc.IsTrue)
+	c.Assert(unitStatus.AgentStatus.Status, gc.Equals, "offline")
+	c.Assert(unitStatus.AgentStatus.Info, gc.Equals, "acknowledged offline by admin: replacing motherboard")
+	c.Assert(unitStatus.AgentStatus.Data["suppressed-status"], gc.Equals, "lost")
+	c.Assert(unitStatus.WorkloadStatus.Status, gc.Equals, "unknown")
+	c.Assert(unitStatus.WorkloadStatus.Info, gc.Equals, "acknowledged offline by admin: replacing motherboard")
+
+	healthyStatus := appStatus.Units[healthy.Name()]
+	c.Assert(healthyStatus.Offline, gc.IsNil)
+	c.Assert(healthyStatus.AgentStatus.Status, gc.Equals, "lost")
+}
+
+func (s *statusUnitTestSuite) TestApplicationLoadBalancerAddress(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	err := application.SetLoadBalancerAddress("lb.example.com")
+	c.Assert(err, jc.ErrorIsNil)
+
+	status, err := s.APIState.Client().Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	appStatus, ok := status.Applications[application.Name()]
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(appStatus.LoadBalancerAddress, gc.Equals, "lb.example.com")
+}
+
 func (s *statusUnitTestSuite) TestWorkloadVersionLastWins(c *gc.C) {
 	application := s.Factory.MakeApplication(c, nil)
 	unit1 := addUnitWithVersion(c, application, "voltron")
@@ -102,6 +102,8 @@ func NewCloudAPIV2(backend, ctlrBackend Backend, authorizer facade.Authorizer) (
 }
 
 // Clouds returns the definitions of all clouds supported by the controller.
+//
+//juju:readonly
 func (api *CloudAPI) Clouds() (params.CloudsResult, error) {
 	var result params.CloudsResult
 	clouds, err := api.backend.Clouds()
@@ -117,6 +119,8 @@ func (api *CloudAPI) Clouds() (params.CloudsResult, error) {
 }
 
 // Cloud returns the cloud definitions for the specified clouds.
+//
+//juju:readonly
 func (api *CloudAPI) Cloud(args params.Entities) (params.CloudResults, error) {
 	results := params.CloudResults{
 		Results: make([]params.CloudResult, len(args.Entities)),
@@ -146,6 +150,8 @@ func (api *CloudAPI) Cloud(args params.Entities) (params.CloudResults, error) {
 
 // DefaultCloud returns the tag of the cloud that models will be
 // created in by default.
+//
+//juju:readonly
 func (api *CloudAPI) DefaultCloud() (params.StringResult, error) {
 	controllerModel, err := api.ctlrBackend.Model()
 	if err != nil {
@@ -157,6 +163,8 @@ func (api *CloudAPI) DefaultCloud() (params.StringResult, error) {
 }
 
 // UserCredentials returns the cloud credentials for a set of users.
+//
+//juju:readonly
 func (api *CloudAPI) UserCredentials(args params.UserClouds) (params.StringsResults, error) {
 	results := params.StringsResults{
 		Results: make([]params.StringsResult, len(args.UserClouds)),
@@ -300,6 +308,8 @@ func (api *CloudAPI) RevokeCredentials(args params.Entities) (params.ErrorResult
 }
 
 // Credential returns the specified cloud credential for each tag, minus secrets.
+//
+//juju:readonly
 func (api *CloudAPI) Credential(args params.Entities) (params.CloudCredentialResults, error) {
 	results := params.CloudCredentialResults{
 		Results: make([]params.CloudCredentialResult, len(args.Entities)),
@@ -35,6 +35,8 @@ func (g cloudEnvironConfigGetter) CloudSpec() (environs.CloudSpec, error) {
 
 // InstanceTypes returns instance type information for the cloud and region
 // in which the current model is deployed.
+//
+//juju:readonly
 func (api *CloudAPI) InstanceTypes(cons params.CloudInstanceTypesConstraints) (params.InstanceTypesResults, error) {
 	return instanceTypes(api, environs.GetEnviron, cons)
 }
@@ -8,6 +8,8 @@ package controller
 import (
 	"encoding/json"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -42,13 +44,44 @@ type ControllerAPI struct {
 	resources  facade.Resources
 }
 
+// ControllerAPIv8 provides the v8 Controller API. It does not have the
+// StartBreakGlassSession, EndBreakGlassSessions or BreakGlassSessions
+// methods.
+type ControllerAPIv8 struct {
+	*ControllerAPI
+}
+
+// ControllerAPIv7 provides the v7 Controller API. It does not have the
+// DatabaseReport method.
+type ControllerAPIv7 struct {
+	*ControllerAPIv8
+}
+
+// ControllerAPIv6 provides the v6 Controller API. It does not have the
+// AbortAfterSuccess method.
+type ControllerAPIv6 struct {
+	*ControllerAPIv7
+}
+
+// ControllerAPIv5 provides the v5 Controller API. It does not have the
+// AgentConnectionHistory method.
+type ControllerAPIv5 struct {
+	*ControllerAPIv6
+}
+
+// ControllerAPIv4 provides the v4 Controller API. It does not have the
+// AgentBinaryStorageUsage method.
+type ControllerAPIv4 struct {
+	*ControllerAPIv5
+}
+
 // ControllerAPIv3 provides the v3 Controller API.
 type ControllerAPIv3 struct {
-	*ControllerAPI
+	*ControllerAPIv4
 }
 
-// NewControllerAPIv4 creates a new ControllerAPIv4.
-func NewControllerAPIv4(ctx facade.Context) (*ControllerAPI, error) {
+// NewControllerAPIv9 creates a new ControllerAPIv9.
+func NewControllerAPIv9(ctx facade.Context) (*ControllerAPI, error) {
 	st := ctx.State()
 	authorizer := ctx.Auth()
 	pool := ctx.StatePool()
@@ -62,6 +95,51 @@ func NewControllerAPIv4(ctx facade.Context) (*ControllerAPI, error) {
 	)
 }
 
+// NewControllerAPIv8 creates a new ControllerAPIv8.
+func NewControllerAPIv8(ctx facade.Context) (*ControllerAPIv8, error) {
+	v9, err := NewControllerAPIv9(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv8{v9}, nil
+}
+
+// NewControllerAPIv7 creates a new ControllerAPIv7.
+func NewControllerAPIv7(ctx facade.Context) (*ControllerAPIv7, error) {
+	v8, err := NewControllerAPIv8(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv7{v8}, nil
+}
+
+// NewControllerAPIv6 creates a new ControllerAPIv6.
+func NewControllerAPIv6(ctx facade.Context) (*ControllerAPIv6, error) {
+	v7, err := NewControllerAPIv7(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv6{v7}, nil
+}
+
+// NewControllerAPIv5 creates a new ControllerAPIv5.
+func NewControllerAPIv5(ctx facade.Context) (*ControllerAPIv5, error) {
+	v6, err := NewControllerAPIv6(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv5{v6}, nil
+}
+
+// NewControllerAPIv4 creates a new ControllerAPIv4.
+func NewControllerAPIv4(ctx facade.Context) (*ControllerAPIv4, error) {
+	v5, err := NewControllerAPIv5(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv4{v5}, nil
+}
+
 // NewControllerAPIv3 creates a new ControllerAPIv3.
 func NewControllerAPIv3(ctx facade.Context) (*ControllerAPIv3, error) {
 	v4, err := NewControllerAPIv4(ctx)
@@ -124,6 +202,8 @@ func (s *ControllerAPI) checkHasAdmin() error {
 // ModelStatus is a legacy method call to ensure that we preserve
 // backward compatibility.
 // TODO (anastasiamac 2017-10-26) This should be made obsolete/removed.
+//
+//juju:readonly
 func (s *ControllerAPIv3) ModelStatus(req params.Entities) (params.ModelStatusResults, error) {
 	results, err := s.ModelStatusAPI.ModelStatus(req)
 	if err != nil {
@@ -140,6 +220,8 @@ func (s *ControllerAPIv3) ModelStatus(req params.Entities) (params.ModelStatusRe
 
 // AllModels allows controller administrators to get the list of all the
 // models in the controller.
+//
+//juju:readonly
 func (s *ControllerAPI) AllModels() (params.UserModelList, error) {
 	result := params.UserModelList{}
 	if err := s.checkHasAdmin(); err != nil {
@@ -193,6 +275,8 @@ func (s *ControllerAPI) AllModels() (params.UserModelList, error) {
 // which have a block in place.  The resulting slice is sorted by environment
 // name, then owner. Callers must be controller administrators to retrieve the
 // list.
+//
+//juju:readonly
 func (s *ControllerAPI) ListBlockedModels() (params.ModelBlockInfoList, error) {
 	results := params.ModelBlockInfoList{}
 	if err := s.checkHasAdmin(); err != nil {
@@ -239,6 +323,8 @@ func (s *ControllerAPI) ListBlockedModels() (params.ModelBlockInfoList, error) {
 // ModelConfig returns the environment config for the controller
 // environment.  For information on the current environment, use
 // client.ModelGet
+//
+//juju:readonly
 func (s *ControllerAPI) ModelConfig() (params.ModelConfigResults, error) {
 	result := params.ModelConfigResults{}
 	if err := s.checkHasAdmin(); err != nil {
@@ -267,6 +353,8 @@ func (s *ControllerAPI) ModelConfig() (params.ModelConfigResults, error) {
 // HostedModelConfigs returns all the information that the client needs in
 // order to connect directly with the host model's provider and destroy it
 // directly.
+//
+//juju:readonly
 func (s *ControllerAPI) HostedModelConfigs() (params.HostedModelConfigsResults, error) {
 	result := params.HostedModelConfigsResults{}
 	if err := s.checkHasAdmin(); err != nil {
@@ -329,9 +417,64 @@ func (s *ControllerAPI) RemoveBlocks(args params.RemoveBlocksArgs) error {
 	return errors.Trace(s.state.RemoveAllBlocksForController())
 }
 
+// AgentBinaryStorageUsage returns the storage used by the agent
+// binaries stored by the controller for all of its models.
+//
+//juju:readonly
+func (s *ControllerAPI) AgentBinaryStorageUsage() (params.AgentBinaryStorageUsage, error) {
+	if err := s.checkHasAdmin(); err != nil {
+		return params.AgentBinaryStorageUsage{}, errors.Trace(err)
+	}
+	usage, err := s.state.AgentBinaryStorageUsage()
+	if err != nil {
+		return params.AgentBinaryStorageUsage{}, errors.Trace(err)
+	}
+	return params.AgentBinaryStorageUsage{
+		Entries:    usage.Entries,
+		Binaries:   usage.Binaries,
+		Size:       usage.Size,
+		StoredSize: usage.StoredSize,
+	}, nil
+}
+
+// AgentBinaryStorageUsage isn't on the v4 API.
+//
+//juju:readonly
+func (s *ControllerAPIv4) AgentBinaryStorageUsage(_, _ struct{}) {}
+
+// AgentConnectionHistory returns the recent API connections that agents
+// have made to the API server handling the request, so that agents
+// that are reconnecting in a loop can be identified. Each API server in
+// the controller keeps its own history.
+//
+//juju:readonly
+func (s *ControllerAPI) AgentConnectionHistory() (params.AgentConnectionHistoryResults, error) {
+	if err := s.checkHasAdmin(); err != nil {
+		return params.AgentConnectionHistoryResults{}, errors.Trace(err)
+	}
+	result := params.AgentConnectionHistoryResults{
+		Agents: []params.AgentConnectionHistory{},
+	}
+	resource, ok := s.resources.Get("agentConnectionHistory").(common.ValueResource)
+	if !ok {
+		return result, nil
+	}
+	if history, ok := resource.Value.(common.AgentConnectionHistory); ok {
+		result.Agents = history.AgentConnections()
+	}
+	return result, nil
+}
+
+// AgentConnectionHistory isn't on the v5 API.
+//
+//juju:readonly
+func (s *ControllerAPIv5) AgentConnectionHistory(_, _ struct{}) {}
+
 // WatchAllModels starts watching events for all models in the
 // controller. The returned AllWatcherId should be used with Next on the
 // AllModelWatcher endpoint to receive deltas.
+//
+//juju:readonly
 func (c *ControllerAPI) WatchAllModels() (params.AllWatcherId, error) {
 	if err := c.checkHasAdmin(); err != nil {
 		return params.AllWatcherId{}, errors.Trace(err)
@@ -344,6 +487,8 @@ func (c *ControllerAPI) WatchAllModels() (params.AllWatcherId, error) {
 
 // GetControllerAccess returns the level of access the specifed users
 // have on the controller.
+//
+//juju:readonly
 func (c *ControllerAPI) GetControllerAccess(req params.Entities) (params.UserAccessResults, error) {
 	results := params.UserAccessResults{}
 	isAdmin, err := c.authorizer.HasPermission(permission.SuperuserAccess, c.state.ControllerTag())
@@ -451,8 +596,9 @@ func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string,
 
 	// Trigger the migration.
 	mig, err := hostedState.CreateMigration(state.MigrationSpec{
-		InitiatedBy: c.apiUser,
-		TargetInfo:  targetInfo,
+		InitiatedBy:    c.apiUser,
+		TargetInfo:     targetInfo,
+		RollbackWindow: spec.RollbackWindow,
 	})
 	if err != nil {
 		return "", errors.Trace(err)
@@ -460,6 +606,266 @@ func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string,
 	return mig.Id(), nil
 }
 
+// AbortAfterSuccess rolls back the migrations of the given models,
+// which must have been handed over to their target controllers but
+// still be within their rollback windows. The model's agents are
+// switched back to this controller and the model becomes usable here
+// again, while the model is removed from the target controller.
+func (c *ControllerAPI) AbortAfterSuccess(args params.Entities) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	if err := c.checkHasAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		err := c.abortOneAfterSuccess(entity.Tag)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (c *ControllerAPI) abortOneAfterSuccess(tag string) error {
+	modelTag, err := names.ParseModelTag(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	hostedState, release, err := c.statePool.Get(modelTag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer release()
+
+	mig, err := hostedState.LatestMigration()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	phase, err := mig.Phase()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if phase != coremigration.POSTVALIDATION {
+		return errors.Errorf("migration is not being validated (phase %s)", phase)
+	}
+	return errors.Trace(mig.SetPhase(coremigration.ROLLBACK))
+}
+
+// AbortAfterSuccess isn't on the v6 API.
+func (s *ControllerAPIv6) AbortAfterSuccess(_, _ struct{}) {}
+
+// DatabaseReport returns the sizes of the controller database's
+// collections, as last reported by the replica set's primary, along
+// with the indexes that no member has used and the query patterns that
+// the members have answered by scanning whole collections, which may
+// need indexes. It also reports the last compaction of each member.
+func (c *ControllerAPI) DatabaseReport() (params.DatabaseReport, error) {
+	if err := c.checkHasAdmin(); err != nil {
+		return params.DatabaseReport{}, errors.Trace(err)
+	}
+	reports, err := c.state.AllDatabaseReports()
+	if err != nil {
+		return params.DatabaseReport{}, errors.Trace(err)
+	}
+	return databaseReport(reports), nil
+}
+
+// DatabaseReport isn't on the v7 API.
+func (s *ControllerAPIv7) DatabaseReport(_, _ struct{}) {}
+
+// StartBreakGlassSession elevates the caller to controller superuser
+// for the given duration, so that they can repair the controller or its
+// models in an emergency. The caller must be one of the controller's
+// break-glass-users, and must give a reason, which is recorded in the
+// controller model's activity feed. The elevation applies to API
+// connections made after the session starts, and every call made over
+// them is recorded in the controller's audit log.
+func (c *ControllerAPI) StartBreakGlassSession(args params.StartBreakGlassSessionArgs) (params.BreakGlassSession, error) {
+	allowed, err := c.isBreakGlassUser()
+	if err != nil {
+		return params.BreakGlassSession{}, errors.Trace(err)
+	}
+	if !allowed {
+		return params.BreakGlassSession{}, common.ServerError(common.ErrPerm)
+	}
+	session, err := c.state.StartBreakGlassSession(c.apiUser, args.Reason, args.Duration)
+	if err != nil {
+		return params.BreakGlassSession{}, errors.Trace(err)
+	}
+	logger.Warningf("%s started break-glass session %s until %s: %s",
+		c.apiUser.Id(), session.Id, session.Expires.Format(time.RFC3339), session.Reason)
+	return breakGlassSession(*session), nil
+}
+
+// EndBreakGlassSessions ends the active break-glass sessions of the
+// given users before they expire. Users may end their own sessions;
+// controller administrators may end anyone's.
+func (c *ControllerAPI) EndBreakGlassSessions(args params.Entities) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	isAdmin, err := c.authorizer.HasPermission(permission.SuperuserAccess, c.state.ControllerTag())
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		user, err := names.ParseUserTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if !isAdmin && user.Id() != c.apiUser.Id() {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if err := c.state.EndBreakGlassSession(user, c.apiUser); err != nil {
+			result.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+// BreakGlassSessions returns the active break-glass sessions, oldest
+// first. The caller must be a controller administrator.
+func (c *ControllerAPI) BreakGlassSessions() (params.BreakGlassSessions, error) {
+	if err := c.checkHasAdmin(); err != nil {
+		return params.BreakGlassSessions{}, errors.Trace(err)
+	}
+	sessions, err := c.state.BreakGlassSessions()
+	if err != nil {
+		return params.BreakGlassSessions{}, errors.Trace(err)
+	}
+	result := params.BreakGlassSessions{
+		Sessions: make([]params.BreakGlassSession, len(sessions)),
+	}
+	for i, session := range sessions {
+		result.Sessions[i] = breakGlassSession(session)
+	}
+	return result, nil
+}
+
+// StartBreakGlassSession isn't on the v8 API.
+func (s *ControllerAPIv8) StartBreakGlassSession(_, _ struct{}) {}
+
+// EndBreakGlassSessions isn't on the v8 API.
+func (s *ControllerAPIv8) EndBreakGlassSessions(_, _ struct{}) {}
+
+// BreakGlassSessions isn't on the v8 API.
+func (s *ControllerAPIv8) BreakGlassSessions(_, _ struct{}) {}
+
+// isBreakGlassUser reports whether the caller may start break-glass
+// sessions.
+func (c *ControllerAPI) isBreakGlassUser() (bool, error) {
+	cfg, err := c.state.ControllerConfig()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	for _, user := range cfg.BreakGlassUsers() {
+		if strings.EqualFold(user, c.apiUser.Id()) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func breakGlassSession(session state.BreakGlassSession) params.BreakGlassSession {
+	return params.BreakGlassSession{
+		Id:      session.Id,
+		UserTag: session.User.String(),
+		Reason:  session.Reason,
+		Started: session.Started,
+		Expires: session.Expires,
+	}
+}
+
+func databaseReport(reports []state.DatabaseReport) params.DatabaseReport {
+	result := params.DatabaseReport{
+		Members:        []params.DatabaseMember{},
+		Collections:    []params.DatabaseCollection{},
+		UnusedIndexes:  []params.DatabaseIndex{},
+		MissingIndexes: []params.DatabaseQueryPattern{},
+	}
+	var primary *state.DatabaseReport
+	unused := make(map[params.DatabaseIndex]int)
+	scans := make(map[string]*params.DatabaseQueryPattern)
+	for i, report := range reports {
+		member := params.DatabaseMember{
+			MachineId: report.MachineId,
+			Updated:   report.Updated,
+			Primary:   report.Primary,
+		}
+		if c := report.LastCompaction; c != nil {
+			member.LastCompaction = &params.DatabaseCompaction{
+				Started:     c.Started,
+				Finished:    c.Finished,
+				Collections: c.Collections,
+				Error:       c.Error,
+			}
+		}
+		result.Members = append(result.Members, member)
+		if report.Primary && (primary == nil || report.Updated.After(primary.Updated)) {
+			primary = &reports[i]
+		}
+		for _, index := range report.UnusedIndexes {
+			unused[params.DatabaseIndex{
+				Collection: index.Collection,
+				Name:       index.Name,
+			}]++
+		}
+		for _, pattern := range report.CollectionScans {
+			key := pattern.Collection + " " + strings.Join(pattern.Fields, ",")
+			if existing, ok := scans[key]; ok {
+				existing.Count += pattern.Count
+				continue
+			}
+			scans[key] = &params.DatabaseQueryPattern{
+				Collection: pattern.Collection,
+				Fields:     pattern.Fields,
+				Count:      pattern.Count,
+			}
+		}
+	}
+	if primary != nil {
+		for _, stats := range primary.Collections {
+			result.Collections = append(result.Collections, params.DatabaseCollection{
+				Name:             stats.Name,
+				Count:            stats.Count,
+				SizeBytes:        stats.SizeBytes,
+				StorageSizeBytes: stats.StorageSizeBytes,
+				IndexSizeBytes:   stats.IndexSizeBytes,
+			})
+		}
+	}
+	// An index is only unused if no member has used it, since reads
+	// may be served by any member.
+	for index, count := range unused {
+		if count == len(reports) {
+			result.UnusedIndexes = append(result.UnusedIndexes, index)
+		}
+	}
+	sort.Slice(result.UnusedIndexes, func(i, j int) bool {
+		a, b := result.UnusedIndexes[i], result.UnusedIndexes[j]
+		if a.Collection != b.Collection {
+			return a.Collection < b.Collection
+		}
+		return a.Name < b.Name
+	})
+	for _, pattern := range scans {
+		result.MissingIndexes = append(result.MissingIndexes, *pattern)
+	}
+	// Report the most frequent scans first.
+	sort.Slice(result.MissingIndexes, func(i, j int) bool {
+		a, b := result.MissingIndexes[i], result.MissingIndexes[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.Collection != b.Collection {
+			return a.Collection < b.Collection
+		}
+		return strings.Join(a.Fields, ",") < strings.Join(b.Fields, ",")
+	})
+	return result
+}
+
 // ModifyControllerAccess changes the model access granted to users.
 func (c *ControllerAPI) ModifyControllerAccess(args params.ModifyControllerAccessRequest) (params.ErrorResults, error) {
 	result := params.ErrorResults{
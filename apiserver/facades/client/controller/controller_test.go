@@ -6,6 +6,7 @@ package controller_test
 import (
 	"encoding/json"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
@@ -23,10 +24,12 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/binarystorage"
 	"github.com/juju/juju/state/multiwatcher"
 	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/testing"
@@ -49,6 +52,9 @@ func (s *controllerSuite) SetUpTest(c *gc.C) {
 	s.InitialConfig = testing.CustomModelConfig(c, testing.Attrs{
 		"name": "controller",
 	})
+	s.ControllerConfig = map[string]interface{}{
+		"break-glass-users": "bob",
+	}
 
 	s.StateSuite.SetUpTest(c)
 
@@ -66,7 +72,7 @@ func (s *controllerSuite) SetUpTest(c *gc.C) {
 		AdminTag: s.Owner,
 	}
 
-	controller, err := controller.NewControllerAPIv4(
+	controller, err := controller.NewControllerAPIv9(
 		facadetest.Context{
 			State_:     s.State,
 			StatePool_: s.statePool,
@@ -83,7 +89,7 @@ func (s *controllerSuite) TestNewAPIRefusesNonClient(c *gc.C) {
 	anAuthoriser := apiservertesting.FakeAuthorizer{
 		Tag: names.NewUnitTag("mysql/0"),
 	}
-	endPoint, err := controller.NewControllerAPIv4(
+	endPoint, err := controller.NewControllerAPIv9(
 		facadetest.Context{
 			State_:     s.State,
 			Resources_: s.resources,
@@ -263,7 +269,7 @@ func (s *controllerSuite) TestModelConfigFromNonController(c *gc.C) {
 		Tag:      s.Owner,
 		AdminTag: s.Owner,
 	}
-	controller, err := controller.NewControllerAPIv4(
+	controller, err := controller.NewControllerAPIv9(
 		facadetest.Context{
 			State_:     st,
 			StatePool_: s.statePool,
@@ -293,7 +299,7 @@ func (s *controllerSuite) TestControllerConfigFromNonController(c *gc.C) {
 	defer st.Close()
 
 	authorizer := &apiservertesting.FakeAuthorizer{Tag: s.Owner}
-	controller, err := controller.NewControllerAPIv4(
+	controller, err := controller.NewControllerAPIv9(
 		facadetest.Context{
 			State_:     st,
 			Resources_: common.NewResources(),
@@ -309,6 +315,292 @@ func (s *controllerSuite) TestControllerConfigFromNonController(c *gc.C) {
 	c.Assert(cfg.Config["api-port"], gc.Equals, cfgFromDB.APIPort())
 }
 
+func (s *controllerSuite) TestAgentBinaryStorageUsage(c *gc.C) {
+	storage, err := s.State.ToolsStorage()
+	c.Assert(err, jc.ErrorIsNil)
+	defer storage.Close()
+	err = storage.Add(strings.NewReader("abc"), binarystorage.Metadata{
+		Version: "2.0.0-xenial-amd64",
+		Size:    3,
+		SHA256:  "hash(abc)",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	usage, err := s.controller.AgentBinaryStorageUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage, jc.DeepEquals, params.AgentBinaryStorageUsage{
+		Entries:    1,
+		Binaries:   1,
+		Size:       3,
+		StoredSize: 3,
+	})
+}
+
+func (s *controllerSuite) TestAgentBinaryStorageUsagePermissionDenied(c *gc.C) {
+	authorizer := &apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("bob")}
+	controller, err := controller.NewControllerAPIv9(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.statePool,
+			Resources_: s.resources,
+			Auth_:      authorizer,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = controller.AgentBinaryStorageUsage()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *controllerSuite) TestAgentConnectionHistory(c *gc.C) {
+	history := []params.AgentConnectionHistory{{
+		Tag:              "machine-1",
+		ModelTag:         s.Model.ModelTag().String(),
+		TotalConnections: 42,
+		Connections: []params.AgentConnection{{
+			ConnectionID:  7,
+			RemoteAddress: "10.0.0.1:1234",
+			Connected:     time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+			FacadeCalls:   map[string]int{"Uniter": 3},
+		}},
+	}}
+	err := s.resources.RegisterNamed("agentConnectionHistory", common.ValueResource{
+		fakeAgentConnectionHistory(history),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.controller.AgentConnectionHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.AgentConnectionHistoryResults{
+		Agents: history,
+	})
+}
+
+func (s *controllerSuite) TestAgentConnectionHistoryNotRecorded(c *gc.C) {
+	result, err := s.controller.AgentConnectionHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.AgentConnectionHistoryResults{
+		Agents: []params.AgentConnectionHistory{},
+	})
+}
+
+func (s *controllerSuite) TestAgentConnectionHistoryPermissionDenied(c *gc.C) {
+	authorizer := &apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("bob")}
+	controller, err := controller.NewControllerAPIv9(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.statePool,
+			Resources_: s.resources,
+			Auth_:      authorizer,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = controller.AgentConnectionHistory()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type fakeAgentConnectionHistory []params.AgentConnectionHistory
+
+func (h fakeAgentConnectionHistory) AgentConnections() []params.AgentConnectionHistory {
+	return h
+}
+
+func (s *controllerSuite) TestDatabaseReport(c *gc.C) {
+	updated := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	err := s.State.SetDatabaseReport(state.DatabaseReport{
+		MachineId: "0",
+		Updated:   updated,
+		Primary:   true,
+		Collections: []state.CollectionStats{{
+			Name:             "txns",
+			Count:            10,
+			SizeBytes:        1000,
+			StorageSizeBytes: 4096,
+			IndexSizeBytes:   512,
+		}},
+		UnusedIndexes: []state.IndexUsage{
+			{Collection: "units", Name: "model-uuid_1_name_1"},
+			{Collection: "machines", Name: "model-uuid_1_series_1"},
+		},
+		CollectionScans: []state.QueryPattern{
+			{Collection: "statuseshistory", Fields: []string{"globalkey"}, Count: 2},
+			{Collection: "txns", Fields: []string{"s"}, Count: 1},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	compaction := &state.Compaction{
+		Started:     updated.Add(-time.Hour),
+		Finished:    updated.Add(-time.Minute),
+		Collections: []string{"txns"},
+	}
+	err = s.State.SetDatabaseReport(state.DatabaseReport{
+		MachineId: "1",
+		Updated:   updated,
+		Collections: []state.CollectionStats{{
+			Name:  "txns",
+			Count: 9,
+		}},
+		UnusedIndexes: []state.IndexUsage{
+			{Collection: "machines", Name: "model-uuid_1_series_1"},
+		},
+		CollectionScans: []state.QueryPattern{
+			{Collection: "statuseshistory", Fields: []string{"globalkey"}, Count: 3},
+		},
+		LastCompaction: compaction,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	report, err := s.controller.DatabaseReport()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report, jc.DeepEquals, params.DatabaseReport{
+		Members: []params.DatabaseMember{{
+			MachineId: "0",
+			Updated:   updated,
+			Primary:   true,
+		}, {
+			MachineId: "1",
+			Updated:   updated,
+			LastCompaction: &params.DatabaseCompaction{
+				Started:     compaction.Started,
+				Finished:    compaction.Finished,
+				Collections: []string{"txns"},
+			},
+		}},
+		Collections: []params.DatabaseCollection{{
+			Name:             "txns",
+			Count:            10,
+			SizeBytes:        1000,
+			StorageSizeBytes: 4096,
+			IndexSizeBytes:   512,
+		}},
+		UnusedIndexes: []params.DatabaseIndex{
+			{Collection: "machines", Name: "model-uuid_1_series_1"},
+		},
+		MissingIndexes: []params.DatabaseQueryPattern{
+			{Collection: "statuseshistory", Fields: []string{"globalkey"}, Count: 5},
+			{Collection: "txns", Fields: []string{"s"}, Count: 1},
+		},
+	})
+}
+
+func (s *controllerSuite) TestDatabaseReportEmpty(c *gc.C) {
+	report, err := s.controller.DatabaseReport()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report, jc.DeepEquals, params.DatabaseReport{
+		Members:        []params.DatabaseMember{},
+		Collections:    []params.DatabaseCollection{},
+		UnusedIndexes:  []params.DatabaseIndex{},
+		MissingIndexes: []params.DatabaseQueryPattern{},
+	})
+}
+
+func (s *controllerSuite) TestDatabaseReportPermissionDenied(c *gc.C) {
+	authorizer := &apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("bob")}
+	controller, err := controller.NewControllerAPIv9(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.statePool,
+			Resources_: s.resources,
+			Auth_:      authorizer,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = controller.DatabaseReport()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *controllerSuite) controllerForUser(c *gc.C, user names.UserTag) *controller.ControllerAPI {
+	authorizer := &apiservertesting.FakeAuthorizer{Tag: user}
+	controller, err := controller.NewControllerAPIv9(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.statePool,
+			Resources_: s.resources,
+			Auth_:      authorizer,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	return controller
+}
+
+func (s *controllerSuite) TestStartBreakGlassSession(c *gc.C) {
+	bob := names.NewUserTag("bob")
+	session, err := s.controllerForUser(c, bob).StartBreakGlassSession(params.StartBreakGlassSessionArgs{
+		Reason:   "controller down",
+		Duration: time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	started := s.Clock.Now().Round(time.Second).UTC()
+	c.Assert(session, jc.DeepEquals, params.BreakGlassSession{
+		Id:      session.Id,
+		UserTag: "user-bob",
+		Reason:  "controller down",
+		Started: started,
+		Expires: started.Add(time.Hour),
+	})
+
+	sessions, err := s.controller.BreakGlassSessions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sessions, jc.DeepEquals, params.BreakGlassSessions{
+		Sessions: []params.BreakGlassSession{session},
+	})
+
+	// Other administrators are told of the session.
+	events, err := s.State.ModelEvents(state.ModelEventsFilter{
+		Kinds: []state.ModelEventKind{state.EventBreakGlassStarted},
+		Limit: 10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, gc.HasLen, 1)
+	c.Assert(events[0].Actor, gc.Equals, "user-bob")
+}
+
+func (s *controllerSuite) TestStartBreakGlassSessionNotBreakGlassUser(c *gc.C) {
+	_, err := s.controllerForUser(c, names.NewUserTag("mary")).StartBreakGlassSession(params.StartBreakGlassSessionArgs{
+		Reason:   "controller down",
+		Duration: time.Hour,
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *controllerSuite) TestStartBreakGlassSessionWithoutReason(c *gc.C) {
+	_, err := s.controllerForUser(c, names.NewUserTag("bob")).StartBreakGlassSession(params.StartBreakGlassSessionArgs{
+		Duration: time.Hour,
+	})
+	c.Assert(err, gc.ErrorMatches, "break-glass session without reason not valid")
+}
+
+func (s *controllerSuite) TestEndBreakGlassSessions(c *gc.C) {
+	bob := names.NewUserTag("bob")
+	bobController := s.controllerForUser(c, bob)
+	_, err := bobController.StartBreakGlassSession(params.StartBreakGlassSessionArgs{
+		Reason:   "controller down",
+		Duration: time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "user-bob"}, {Tag: "machine-0"},
+	}}
+	results, err := s.controllerForUser(c, names.NewUserTag("mary")).EndBreakGlassSessions(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "permission denied")
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `"machine-0" is not a valid user tag`)
+
+	results, err = bobController.EndBreakGlassSessions(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	_, err = s.State.BreakGlassSession(bob)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Administrators may end anyone's session, but there is no
+	// longer one to end.
+	results, err = s.controller.EndBreakGlassSessions(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *controllerSuite) TestBreakGlassSessionsPermissionDenied(c *gc.C) {
+	_, err := s.controllerForUser(c, names.NewUserTag("bob")).BreakGlassSessions()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func (s *controllerSuite) TestRemoveBlocks(c *gc.C) {
 	st := s.Factory.MakeModel(c, &factory.ModelParams{
 		Name: "test"})
@@ -570,6 +862,98 @@ func (s *controllerSuite) TestInitiateMigrationPrecheckFail(c *gc.C) {
 	c.Check(active, jc.IsFalse)
 }
 
+func (s *controllerSuite) TestInitiateMigrationRollbackWindow(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	controller.SetPrecheckResult(s, nil)
+
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: m.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert1",
+				AuthTag:       names.NewUserTag("admin1").String(),
+				Password:      "secret1",
+			},
+			RollbackWindow: time.Hour,
+		}},
+	}
+	out, err := s.controller.InitiateMigration(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Assert(out.Results[0].Error, gc.IsNil)
+
+	mig, err := st.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig.RollbackWindow(), gc.Equals, time.Hour)
+}
+
+func (s *controllerSuite) startMigration(c *gc.C, st *state.State) state.ModelMigration {
+	mig, err := st.CreateMigration(state.MigrationSpec{
+		InitiatedBy: s.Owner,
+		TargetInfo: coremigration.TargetInfo{
+			ControllerTag: names.NewControllerTag(utils.MustNewUUID().String()),
+			Addrs:         []string{"1.1.1.1:1111"},
+			CACert:        "cert1",
+			AuthTag:       names.NewUserTag("admin1"),
+			Password:      "secret1",
+		},
+		RollbackWindow: time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return mig
+}
+
+func (s *controllerSuite) TestAbortAfterSuccess(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	mig := s.startMigration(c, st)
+	for _, phase := range []coremigration.Phase{
+		coremigration.IMPORT,
+		coremigration.VALIDATION,
+		coremigration.SUCCESS,
+		coremigration.LOGTRANSFER,
+		coremigration.POSTVALIDATION,
+	} {
+		c.Assert(mig.SetPhase(phase), jc.ErrorIsNil)
+	}
+
+	results, err := s.controller.AbortAfterSuccess(params.Entities{
+		Entities: []params.Entity{{Tag: m.ModelTag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+
+	c.Assert(mig.Refresh(), jc.ErrorIsNil)
+	phase, err := mig.Phase()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(phase, gc.Equals, coremigration.ROLLBACK)
+}
+
+func (s *controllerSuite) TestAbortAfterSuccessWrongPhase(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.startMigration(c, st)
+
+	results, err := s.controller.AbortAfterSuccess(params.Entities{
+		Entities: []params.Entity{{Tag: m.ModelTag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), gc.ErrorMatches, `migration is not being validated \(phase QUIESCE\)`)
+}
+
 func randomControllerTag() string {
 	uuid := utils.MustNewUUID().String()
 	return names.NewControllerTag(uuid).String()
@@ -809,7 +1193,7 @@ func (s *controllerSuite) TestGetControllerAccessPermissions(c *gc.C) {
 	anAuthoriser := apiservertesting.FakeAuthorizer{
 		Tag: user.Tag(),
 	}
-	endpoint, err := controller.NewControllerAPIv4(
+	endpoint, err := controller.NewControllerAPIv9(
 		facadetest.Context{
 			State_:     s.State,
 			Resources_: s.resources,
@@ -0,0 +1,24 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllercertificates
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the
+// ControllerCertificates facade.
+type Backend interface {
+	ControllerTag() names.ControllerTag
+
+	APICertificate() (state.APICertificate, error)
+	SetAPICertificate(state.APICertificate) error
+	RemoveAPICertificate() error
+
+	ACMEConfig() (state.ACMEConfig, error)
+	SetACMEConfig(state.ACMEConfig) error
+	RemoveACMEConfig() error
+}
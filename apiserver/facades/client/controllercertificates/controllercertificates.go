@@ -0,0 +1,175 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package controllercertificates implements the ControllerCertificates
+// facade, which manages the certificate presented by the controller's
+// API servers. Administrators may provide a certificate chain issued
+// by an external CA, or configure the controller to obtain
+// certificates from an ACME CA using DNS-01 challenges. Either
+// replaces the certificate signed by the controller's own CA for new
+// connections; existing connections are not affected.
+package controllercertificates
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	utilscert "github.com/juju/utils/cert"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cert"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// controllerCASource is the source reported when the API servers
+// present the certificate signed by the controller's CA.
+const controllerCASource = "controller-ca"
+
+// DefaultRenewBefore is how long before expiry certificates obtained
+// from an ACME CA are renewed, if not otherwise configured.
+const DefaultRenewBefore = 30 * 24 * time.Hour
+
+// API implements the ControllerCertificates facade.
+type API struct {
+	backend Backend
+	clock   clock.Clock
+}
+
+// NewAPI returns a new ControllerCertificates facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer, clock clock.Clock) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	isAdmin, err := authorizer.HasPermission(permission.SuperuserAccess, backend.ControllerTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !isAdmin {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend: backend,
+		clock:   clock,
+	}, nil
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(st, auth, clock.WallClock)
+}
+
+// CertificateInfo describes the certificate presented by the
+// controller's API servers, and any ACME configuration.
+//
+//juju:readonly
+func (api *API) CertificateInfo() (params.APICertificateInfo, error) {
+	result := params.APICertificateInfo{Source: controllerCASource}
+	apiCert, err := api.backend.APICertificate()
+	if err != nil && !errors.IsNotFound(err) {
+		return params.APICertificateInfo{}, errors.Trace(err)
+	} else if err == nil {
+		leaf, err := utilscert.ParseCert(apiCert.CertChain)
+		if err != nil {
+			return params.APICertificateInfo{}, errors.Annotate(err, "parsing API certificate")
+		}
+		expiry, updated := apiCert.Expiry, apiCert.Updated
+		result.Source = string(apiCert.Source)
+		result.CertChain = apiCert.CertChain
+		result.DNSNames = leaf.DNSNames
+		result.Expiry = &expiry
+		result.Updated = &updated
+	}
+
+	acmeConfig, err := api.backend.ACMEConfig()
+	if err != nil && !errors.IsNotFound(err) {
+		return params.APICertificateInfo{}, errors.Trace(err)
+	} else if err == nil {
+		result.ACME = &params.ACMEConfig{
+			DirectoryURL: acmeConfig.DirectoryURL,
+			DNSNames:     acmeConfig.DNSNames,
+			Email:        acmeConfig.Email,
+			DNSHook:      acmeConfig.DNSHook,
+			RenewBefore:  acmeConfig.RenewBefore,
+		}
+	}
+	return result, nil
+}
+
+// SetExternalCertificate sets a certificate chain, issued by an
+// external CA, for the controller's API servers to present. The chain
+// takes precedence over any certificate obtained from an ACME CA.
+func (api *API) SetExternalCertificate(args params.SetExternalCertificate) error {
+	now := api.clock.Now()
+	tlsCert, err := cert.ParseServerChain(args.CertChain, args.PrivateKey, now)
+	if err != nil {
+		return errors.NewNotValid(err, "invalid certificate")
+	}
+	return errors.Trace(api.backend.SetAPICertificate(state.APICertificate{
+		Source:     state.APICertificateExternal,
+		CertChain:  args.CertChain,
+		PrivateKey: args.PrivateKey,
+		Expiry:     tlsCert.Leaf.NotAfter,
+		Updated:    now,
+	}))
+}
+
+// RemoveExternalCertificate removes the certificate chain set with
+// SetExternalCertificate. The API servers then present a certificate
+// obtained from the ACME CA, if configured, or otherwise the
+// certificate signed by the controller's CA.
+func (api *API) RemoveExternalCertificate() error {
+	apiCert, err := api.backend.APICertificate()
+	if errors.IsNotFound(err) {
+		return errors.NotFoundf("external certificate")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if apiCert.Source != state.APICertificateExternal {
+		return errors.NotFoundf("external certificate")
+	}
+	return errors.Trace(api.backend.RemoveAPICertificate())
+}
+
+// SetACMEConfig configures the controller to obtain certificates for
+// its API servers from an ACME CA. Certificates are not obtained while
+// an external certificate chain is set.
+func (api *API) SetACMEConfig(args params.ACMEConfig) error {
+	cfg := state.ACMEConfig{
+		DirectoryURL: args.DirectoryURL,
+		DNSNames:     args.DNSNames,
+		Email:        args.Email,
+		DNSHook:      args.DNSHook,
+		RenewBefore:  args.RenewBefore,
+	}
+	if cfg.RenewBefore == 0 {
+		cfg.RenewBefore = DefaultRenewBefore
+	}
+	if err := cfg.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(api.backend.SetACMEConfig(cfg))
+}
+
+// RemoveACMEConfig stops the controller obtaining certificates from
+// an ACME CA. Any certificate already obtained is removed, so the API
+// servers present the certificate signed by the controller's CA
+// unless an external certificate chain is set.
+func (api *API) RemoveACMEConfig() error {
+	if err := api.backend.RemoveACMEConfig(); err != nil {
+		return errors.Trace(err)
+	}
+	apiCert, err := api.backend.APICertificate()
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if apiCert.Source != state.APICertificateACME {
+		return nil
+	}
+	return errors.Trace(api.backend.RemoveAPICertificate())
+}
@@ -0,0 +1,228 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllercertificates_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/controllercertificates"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/cert"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type controllerCertificatesSuite struct {
+	testing.IsolationSuite
+	clock   *testing.Clock
+	backend *mockBackend
+
+	certChain  string
+	privateKey string
+	expiry     time.Time
+}
+
+var _ = gc.Suite(&controllerCertificatesSuite{})
+
+func (s *controllerCertificatesSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	now := time.Now().UTC().Truncate(time.Second)
+	s.clock = testing.NewClock(now)
+	s.backend = &mockBackend{}
+
+	s.expiry = now.Add(90 * 24 * time.Hour)
+	caCert, caKey, err := cert.NewCA("external", "1", s.expiry)
+	c.Assert(err, jc.ErrorIsNil)
+	srvCert, srvKey, err := cert.NewServer(caCert, caKey, s.expiry, []string{"api.example.com"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.certChain = srvCert + caCert
+	s.privateKey = srvKey
+}
+
+func (s *controllerCertificatesSuite) newAPI(c *gc.C) *controllercertificates.API {
+	api, err := controllercertificates.NewAPI(s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("superuser"),
+	}, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *controllerCertificatesSuite) TestRequiresClient(c *gc.C) {
+	_, err := controllercertificates.NewAPI(s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	}, s.clock)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *controllerCertificatesSuite) TestRequiresSuperuser(c *gc.C) {
+	_, err := controllercertificates.NewAPI(s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("read"),
+	}, s.clock)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *controllerCertificatesSuite) TestCertificateInfoControllerCA(c *gc.C) {
+	info, err := s.newAPI(c).CertificateInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, params.APICertificateInfo{Source: "controller-ca"})
+}
+
+func (s *controllerCertificatesSuite) TestSetExternalCertificate(c *gc.C) {
+	api := s.newAPI(c)
+	err := api.SetExternalCertificate(params.SetExternalCertificate{
+		CertChain:  s.certChain,
+		PrivateKey: s.privateKey,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "SetAPICertificate")
+	c.Assert(s.backend.apiCert.Source, gc.Equals, state.APICertificateExternal)
+	c.Assert(s.backend.apiCert.PrivateKey, gc.Equals, s.privateKey)
+
+	info, err := api.CertificateInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Source, gc.Equals, "external")
+	c.Assert(info.CertChain, gc.Equals, s.certChain)
+	c.Assert(info.DNSNames, jc.DeepEquals, []string{"api.example.com"})
+	c.Assert(info.Updated, jc.DeepEquals, &s.backend.apiCert.Updated)
+	c.Assert(info.Expiry.Unix(), gc.Equals, s.expiry.Unix())
+}
+
+func (s *controllerCertificatesSuite) TestSetExternalCertificateInvalid(c *gc.C) {
+	err := s.newAPI(c).SetExternalCertificate(params.SetExternalCertificate{
+		CertChain:  s.certChain,
+		PrivateKey: "nope",
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, "invalid certificate: cannot parse certificate chain and key: .*")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *controllerCertificatesSuite) TestRemoveExternalCertificate(c *gc.C) {
+	s.backend.apiCert = &state.APICertificate{Source: state.APICertificateExternal}
+	err := s.newAPI(c).RemoveExternalCertificate()
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "APICertificate", "RemoveAPICertificate")
+}
+
+func (s *controllerCertificatesSuite) TestRemoveExternalCertificateACME(c *gc.C) {
+	s.backend.apiCert = &state.APICertificate{Source: state.APICertificateACME}
+	err := s.newAPI(c).RemoveExternalCertificate()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	s.backend.CheckCallNames(c, "APICertificate")
+}
+
+func (s *controllerCertificatesSuite) TestSetACMEConfig(c *gc.C) {
+	api := s.newAPI(c)
+	err := api.SetACMEConfig(params.ACMEConfig{
+		DirectoryURL: "https://acme.example.com/directory",
+		DNSNames:     []string{"api.example.com"},
+		DNSHook:      "/usr/local/bin/update-dns",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.backend.acmeConfig, jc.DeepEquals, &state.ACMEConfig{
+		DirectoryURL: "https://acme.example.com/directory",
+		DNSNames:     []string{"api.example.com"},
+		DNSHook:      "/usr/local/bin/update-dns",
+		RenewBefore:  controllercertificates.DefaultRenewBefore,
+	})
+
+	info, err := api.CertificateInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.ACME, jc.DeepEquals, &params.ACMEConfig{
+		DirectoryURL: "https://acme.example.com/directory",
+		DNSNames:     []string{"api.example.com"},
+		DNSHook:      "/usr/local/bin/update-dns",
+		RenewBefore:  controllercertificates.DefaultRenewBefore,
+	})
+}
+
+func (s *controllerCertificatesSuite) TestSetACMEConfigInvalid(c *gc.C) {
+	err := s.newAPI(c).SetACMEConfig(params.ACMEConfig{
+		DirectoryURL: "https://acme.example.com/directory",
+		DNSNames:     []string{"api.example.com"},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, "empty DNS hook not valid")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *controllerCertificatesSuite) TestRemoveACMEConfigRemovesACMECertificate(c *gc.C) {
+	s.backend.acmeConfig = &state.ACMEConfig{}
+	s.backend.apiCert = &state.APICertificate{Source: state.APICertificateACME}
+	err := s.newAPI(c).RemoveACMEConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "RemoveACMEConfig", "APICertificate", "RemoveAPICertificate")
+}
+
+func (s *controllerCertificatesSuite) TestRemoveACMEConfigKeepsExternalCertificate(c *gc.C) {
+	s.backend.acmeConfig = &state.ACMEConfig{}
+	s.backend.apiCert = &state.APICertificate{Source: state.APICertificateExternal}
+	err := s.newAPI(c).RemoveACMEConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "RemoveACMEConfig", "APICertificate")
+	c.Assert(s.backend.apiCert, gc.NotNil)
+}
+
+type mockBackend struct {
+	testing.Stub
+	apiCert    *state.APICertificate
+	acmeConfig *state.ACMEConfig
+}
+
+func (b *mockBackend) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (b *mockBackend) APICertificate() (state.APICertificate, error) {
+	b.MethodCall(b, "APICertificate")
+	if err := b.NextErr(); err != nil {
+		return state.APICertificate{}, err
+	}
+	if b.apiCert == nil {
+		return state.APICertificate{}, errors.NotFoundf("API certificate")
+	}
+	return *b.apiCert, nil
+}
+
+func (b *mockBackend) SetAPICertificate(c state.APICertificate) error {
+	b.MethodCall(b, "SetAPICertificate", c)
+	b.apiCert = &c
+	return b.NextErr()
+}
+
+func (b *mockBackend) RemoveAPICertificate() error {
+	b.MethodCall(b, "RemoveAPICertificate")
+	b.apiCert = nil
+	return b.NextErr()
+}
+
+func (b *mockBackend) ACMEConfig() (state.ACMEConfig, error) {
+	b.MethodCall(b, "ACMEConfig")
+	if err := b.NextErr(); err != nil {
+		return state.ACMEConfig{}, err
+	}
+	if b.acmeConfig == nil {
+		return state.ACMEConfig{}, errors.NotFoundf("ACME config")
+	}
+	return *b.acmeConfig, nil
+}
+
+func (b *mockBackend) SetACMEConfig(cfg state.ACMEConfig) error {
+	b.MethodCall(b, "SetACMEConfig", cfg)
+	b.acmeConfig = &cfg
+	return b.NextErr()
+}
+
+func (b *mockBackend) RemoveACMEConfig() error {
+	b.MethodCall(b, "RemoveACMEConfig")
+	b.acmeConfig = nil
+	return b.NextErr()
+}
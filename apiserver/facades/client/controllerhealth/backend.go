@@ -0,0 +1,46 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllerhealth
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the
+// ControllerHealth facade.
+type Backend interface {
+	ControllerTag() names.ControllerTag
+	ControllerConfig() (controller.Config, error)
+
+	// AllControllerHealth returns the health most recently reported
+	// by each controller machine.
+	AllControllerHealth() ([]state.ControllerHealth, error)
+
+	// ReplicaSetStatus returns the status of the controller's mongo
+	// replica set.
+	ReplicaSetStatus() (*replicaset.Status, error)
+
+	// LogsSizeMB returns the total size of the log collections.
+	LogsSizeMB() (int, error)
+}
+
+type backendShim struct {
+	*state.State
+}
+
+// ReplicaSetStatus is part of Backend.
+func (b backendShim) ReplicaSetStatus() (*replicaset.Status, error) {
+	status, err := replicaset.CurrentStatus(b.State.MongoSession())
+	return status, errors.Trace(err)
+}
+
+// LogsSizeMB is part of Backend.
+func (b backendShim) LogsSizeMB() (int, error) {
+	return state.LogsSizeMB(b.State)
+}
@@ -0,0 +1,170 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package controllerhealth implements the ControllerHealth facade,
+// which gathers the health of the controller's mongo replica set and
+// of each controller machine into a single report.
+package controllerhealth
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+const (
+	// staleAfter is how long after its last report a controller
+	// machine's health is considered stale. Controller machines
+	// report every minute.
+	staleAfter = 5 * time.Minute
+
+	// minDiskFreePercent is the percentage of free disk space below
+	// which a controller machine is considered unhealthy.
+	minDiskFreePercent = 10
+)
+
+// API implements the ControllerHealth facade.
+type API struct {
+	backend Backend
+	clock   clock.Clock
+}
+
+// NewAPI returns a new ControllerHealth facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer, clock clock.Clock) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	isAdmin, err := authorizer.HasPermission(permission.SuperuserAccess, backend.ControllerTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !isAdmin {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend: backend,
+		clock:   clock,
+	}, nil
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(backendShim{st}, auth, clock.WallClock)
+}
+
+// ControllerHealth returns a report of the health of the controller's
+// mongo replica set, of each controller machine, and of the log
+// collections.
+//
+//juju:readonly
+func (api *API) ControllerHealth() (params.ControllerHealth, error) {
+	var result params.ControllerHealth
+	var problems []string
+
+	status, err := api.backend.ReplicaSetStatus()
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("cannot get mongo replica set status: %v", err))
+	} else {
+		result.Mongo, problems = mongoHealth(status, problems)
+	}
+
+	machines, err := api.backend.AllControllerHealth()
+	if err != nil {
+		return params.ControllerHealth{}, errors.Trace(err)
+	}
+	now := api.clock.Now()
+	for _, m := range machines {
+		var machine params.ControllerMachineHealth
+		machine, problems = api.machineHealth(m, now, problems)
+		result.Machines = append(result.Machines, machine)
+	}
+
+	result.LogsSizeMB, err = api.backend.LogsSizeMB()
+	if err != nil {
+		return params.ControllerHealth{}, errors.Trace(err)
+	}
+	config, err := api.backend.ControllerConfig()
+	if err != nil {
+		return params.ControllerHealth{}, errors.Trace(err)
+	}
+	result.MaxLogsSizeMB = config.MaxLogSizeMB()
+
+	result.Problems = problems
+	result.Healthy = len(problems) == 0
+	return result, nil
+}
+
+func mongoHealth(status *replicaset.Status, problems []string) ([]params.MongoMemberHealth, []string) {
+	var members []params.MongoMemberHealth
+	havePrimary := false
+	for _, m := range status.Members {
+		members = append(members, params.MongoMemberHealth{
+			Id:      m.Id,
+			Address: m.Address,
+			State:   m.State.String(),
+			Healthy: m.Healthy,
+			Error:   m.ErrMsg,
+		})
+		if m.State == replicaset.PrimaryState {
+			havePrimary = true
+		}
+		if !m.Healthy {
+			problems = append(problems, fmt.Sprintf(
+				"mongo member %d (%s) is unhealthy: %s", m.Id, m.Address, m.State,
+			))
+		}
+	}
+	if !havePrimary {
+		problems = append(problems, "mongo replica set has no primary")
+	}
+	return members, problems
+}
+
+func (api *API) machineHealth(m state.ControllerHealth, now time.Time, problems []string) (params.ControllerMachineHealth, []string) {
+	result := params.ControllerMachineHealth{
+		MachineId:      m.MachineId,
+		Updated:        m.Updated,
+		DiskFreeBytes:  m.DiskFreeBytes,
+		DiskTotalBytes: m.DiskTotalBytes,
+		APIConnections: m.APIConnections,
+	}
+	if now.Sub(m.Updated) > staleAfter {
+		result.Stale = true
+		problems = append(problems, fmt.Sprintf(
+			"controller machine %s has not reported its health since %s",
+			m.MachineId, m.Updated.Format(time.RFC3339),
+		))
+	}
+	if m.DiskTotalBytes > 0 && m.DiskFreeBytes*100/m.DiskTotalBytes < minDiskFreePercent {
+		problems = append(problems, fmt.Sprintf(
+			"controller machine %s has only %s of %s disk space free",
+			m.MachineId, humanize.IBytes(m.DiskFreeBytes), humanize.IBytes(m.DiskTotalBytes),
+		))
+	}
+	for _, w := range m.Workers {
+		result.Workers = append(result.Workers, params.WorkerHealth{
+			Name:  w.Name,
+			State: w.State,
+			Error: w.Error,
+		})
+		if w.State == "started" {
+			continue
+		}
+		problem := fmt.Sprintf("worker %q on controller machine %s is %s", w.Name, m.MachineId, w.State)
+		if w.Error != "" {
+			problem += ": " + w.Error
+		}
+		problems = append(problems, problem)
+	}
+	return result, problems
+}
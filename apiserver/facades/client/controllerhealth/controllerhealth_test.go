@@ -0,0 +1,195 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllerhealth_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/controllerhealth"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type controllerHealthSuite struct {
+	testing.IsolationSuite
+	clock   *testing.Clock
+	backend *mockBackend
+}
+
+var _ = gc.Suite(&controllerHealthSuite{})
+
+func (s *controllerHealthSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC))
+	s.backend = &mockBackend{
+		status: &replicaset.Status{
+			Members: []replicaset.MemberStatus{{
+				Id:      1,
+				Address: "10.0.0.1:37017",
+				State:   replicaset.PrimaryState,
+				Healthy: true,
+			}, {
+				Id:      2,
+				Address: "10.0.0.2:37017",
+				State:   replicaset.SecondaryState,
+				Healthy: true,
+			}},
+		},
+		machines: []state.ControllerHealth{{
+			MachineId:      "0",
+			Updated:        s.clock.Now().Add(-time.Minute),
+			DiskFreeBytes:  500,
+			DiskTotalBytes: 1000,
+			APIConnections: 7,
+			Workers: []state.WorkerHealth{
+				{Name: "api-server", State: "started"},
+			},
+		}},
+		logsSizeMB: 12,
+	}
+}
+
+func (s *controllerHealthSuite) newAPI(c *gc.C) *controllerhealth.API {
+	api, err := controllerhealth.NewAPI(s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("superuser"),
+	}, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *controllerHealthSuite) TestRequiresClient(c *gc.C) {
+	_, err := controllerhealth.NewAPI(s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	}, s.clock)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *controllerHealthSuite) TestRequiresSuperuser(c *gc.C) {
+	_, err := controllerhealth.NewAPI(s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("read"),
+	}, s.clock)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *controllerHealthSuite) TestHealthy(c *gc.C) {
+	result, err := s.newAPI(c).ControllerHealth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ControllerHealth{
+		Healthy: true,
+		Mongo: []params.MongoMemberHealth{{
+			Id:      1,
+			Address: "10.0.0.1:37017",
+			State:   "PRIMARY",
+			Healthy: true,
+		}, {
+			Id:      2,
+			Address: "10.0.0.2:37017",
+			State:   "SECONDARY",
+			Healthy: true,
+		}},
+		Machines: []params.ControllerMachineHealth{{
+			MachineId:      "0",
+			Updated:        s.clock.Now().Add(-time.Minute),
+			DiskFreeBytes:  500,
+			DiskTotalBytes: 1000,
+			APIConnections: 7,
+			Workers: []params.WorkerHealth{
+				{Name: "api-server", State: "started"},
+			},
+		}},
+		LogsSizeMB:    12,
+		MaxLogsSizeMB: 4096,
+	})
+}
+
+func (s *controllerHealthSuite) TestMongoProblems(c *gc.C) {
+	s.backend.status.Members[0].State = replicaset.RecoveringState
+	s.backend.status.Members[0].Healthy = false
+	result, err := s.newAPI(c).ControllerHealth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Healthy, jc.IsFalse)
+	c.Assert(result.Problems, jc.DeepEquals, []string{
+		"mongo member 1 (10.0.0.1:37017) is unhealthy: RECOVERING",
+		"mongo replica set has no primary",
+	})
+}
+
+func (s *controllerHealthSuite) TestMongoStatusError(c *gc.C) {
+	s.backend.SetErrors(errors.New("no reachable servers"))
+	result, err := s.newAPI(c).ControllerHealth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Healthy, jc.IsFalse)
+	c.Assert(result.Mongo, gc.HasLen, 0)
+	c.Assert(result.Problems, jc.DeepEquals, []string{
+		"cannot get mongo replica set status: no reachable servers",
+	})
+}
+
+func (s *controllerHealthSuite) TestMachineProblems(c *gc.C) {
+	s.backend.machines = append(s.backend.machines, state.ControllerHealth{
+		MachineId:      "1",
+		Updated:        s.clock.Now().Add(-time.Hour),
+		DiskFreeBytes:  50 * 1024 * 1024,
+		DiskTotalBytes: 1024 * 1024 * 1024,
+		Workers: []state.WorkerHealth{
+			{Name: "api-server", State: "started"},
+			{Name: "peer-grouper", State: "stopped", Error: "kaboom"},
+		},
+	})
+	result, err := s.newAPI(c).ControllerHealth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Healthy, jc.IsFalse)
+	c.Assert(result.Machines, gc.HasLen, 2)
+	c.Assert(result.Machines[0].Stale, jc.IsFalse)
+	c.Assert(result.Machines[1].Stale, jc.IsTrue)
+	c.Assert(result.Problems, jc.DeepEquals, []string{
+		"controller machine 1 has not reported its health since 2018-03-01T11:00:00Z",
+		"controller machine 1 has only 50 MiB of 1.0 GiB disk space free",
+		`worker "peer-grouper" on controller machine 1 is stopped: kaboom`,
+	})
+}
+
+type mockBackend struct {
+	testing.Stub
+	status     *replicaset.Status
+	machines   []state.ControllerHealth
+	logsSizeMB int
+}
+
+func (b *mockBackend) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (b *mockBackend) ControllerConfig() (controller.Config, error) {
+	b.MethodCall(b, "ControllerConfig")
+	return controller.Config{"max-logs-size": "4G"}, b.NextErr()
+}
+
+func (b *mockBackend) AllControllerHealth() ([]state.ControllerHealth, error) {
+	b.MethodCall(b, "AllControllerHealth")
+	return b.machines, b.NextErr()
+}
+
+func (b *mockBackend) ReplicaSetStatus() (*replicaset.Status, error) {
+	b.MethodCall(b, "ReplicaSetStatus")
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	return b.status, nil
+}
+
+func (b *mockBackend) LogsSizeMB() (int, error) {
+	b.MethodCall(b, "LogsSizeMB")
+	return b.logsSizeMB, b.NextErr()
+}
@@ -0,0 +1,102 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package costestimation
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/pricing"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
+)
+
+// newCatalog returns the price catalog for the model: the static
+// catalog in its configuration, if any, followed by the instance type
+// prices published by its provider, if it has them.
+func newCatalog(st *state.State, cfg *config.Config) (pricing.Catalog, error) {
+	var catalogs []pricing.Catalog
+	if data := cfg.PriceCatalog(); data != "" {
+		catalog, err := pricing.ParseCatalog([]byte(data))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		catalogs = append(catalogs, catalog)
+	}
+	env, err := stateenvirons.GetNewEnvironFunc(environs.New)(st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if fetcher, ok := env.(environs.InstanceTypesFetcher); ok {
+		catalog, err := NewProviderCatalog(fetcher)
+		if err == nil {
+			catalogs = append(catalogs, catalog)
+		} else if !errors.IsNotSupported(err) {
+			return nil, errors.Trace(err)
+		}
+	}
+	if len(catalogs) == 0 {
+		return nil, errors.NotFoundf("price catalog")
+	}
+	return pricing.Chain(catalogs...), nil
+}
+
+// NewProviderCatalog returns a price catalog holding the instance type
+// prices published by a provider. It has no storage or load balancer
+// prices.
+func NewProviderCatalog(fetcher environs.InstanceTypesFetcher) (pricing.Catalog, error) {
+	types, err := fetcher.InstanceTypes(constraints.Value{})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if types.CostCurrency == "" {
+		return nil, errors.NotSupportedf("instance types without prices")
+	}
+	return &providerCatalog{
+		fetcher:  fetcher,
+		currency: types.CostCurrency,
+	}, nil
+}
+
+type providerCatalog struct {
+	fetcher  environs.InstanceTypesFetcher
+	currency string
+}
+
+// Currency is part of the pricing.Catalog interface.
+func (c *providerCatalog) Currency() string {
+	return c.currency
+}
+
+// InstancePrice is part of the pricing.Catalog interface.
+func (c *providerCatalog) InstancePrice(cons constraints.Value) (float64, error) {
+	types, err := c.fetcher.InstanceTypes(cons)
+	if err != nil {
+		return 0, errors.NewNotFound(err, fmt.Sprintf("price for instance with %q not found", cons))
+	}
+	if len(types.InstanceTypes) == 0 {
+		return 0, errors.NotFoundf("price for instance with %q", cons)
+	}
+	// Matching instance types are sorted by cost, so the first is
+	// the one the provider would have chosen.
+	price := float64(types.InstanceTypes[0].Cost)
+	if types.CostDivisor > 0 {
+		price /= float64(types.CostDivisor)
+	}
+	return price, nil
+}
+
+// StoragePrice is part of the pricing.Catalog interface.
+func (c *providerCatalog) StoragePrice(pool string) (float64, error) {
+	return 0, errors.NotFoundf("price for storage pool %q", pool)
+}
+
+// LoadBalancerPrice is part of the pricing.Catalog interface.
+func (c *providerCatalog) LoadBalancerPrice() (float64, error) {
+	return 0, errors.NotFoundf("price for load balancers")
+}
@@ -0,0 +1,193 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package costestimation implements the CostEstimation facade, which
+// estimates the cost of the cloud resources used by a model from the
+// prices in the model's price catalogs.
+package costestimation
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/pricing"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Machine describes a provisioned machine whose instance is priced.
+type Machine struct {
+	Id string
+
+	// Constraints describe the machine's instance: its hardware,
+	// and its instance type if that is known.
+	Constraints constraints.Value
+}
+
+// Storage describes a provisioned volume or filesystem that is priced.
+type Storage struct {
+	// Kind is either "volume" or "filesystem".
+	Kind string
+	Id   string
+	Pool string
+
+	// Size is the size of the storage, in MiB.
+	Size uint64
+}
+
+// Backend defines the state functionality required by the
+// CostEstimation facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+	ModelConfig() (*config.Config, error)
+
+	// Machines returns the model's provisioned machines, other than
+	// containers, which cost nothing beyond their hosts.
+	Machines() ([]Machine, error)
+
+	// Storage returns the model's provisioned volumes, and its
+	// provisioned filesystems that are not backed by volumes.
+	Storage() ([]Storage, error)
+
+	// LoadBalancedApplications returns the names of the model's
+	// applications that have provider load balancers.
+	LoadBalancedApplications() ([]string, error)
+}
+
+// NewCatalogFunc returns the price catalog for the model with the
+// given configuration.
+type NewCatalogFunc func(*config.Config) (pricing.Catalog, error)
+
+// API implements the CostEstimation facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+	newCatalog NewCatalogFunc
+}
+
+// NewAPI returns a new CostEstimation facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer, newCatalog NewCatalogFunc) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+		newCatalog: newCatalog,
+	}, nil
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(stateShim{st}, auth, func(cfg *config.Config) (pricing.Catalog, error) {
+		return newCatalog(st, cfg)
+	})
+}
+
+func (api *API) checkCanRead() error {
+	ok, err := api.authorizer.HasPermission(permission.ReadAccess, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// ModelCost returns the estimated cost of the model's machines,
+// storage and load balancers. Resources whose prices are not in the
+// model's price catalogs are reported with an error, and are not
+// included in the model's cost.
+//
+//juju:readonly
+func (api *API) ModelCost() (params.ModelCost, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.ModelCost{}, errors.Trace(err)
+	}
+	cfg, err := api.backend.ModelConfig()
+	if err != nil {
+		return params.ModelCost{}, errors.Trace(err)
+	}
+	catalog, err := api.newCatalog(cfg)
+	if err != nil {
+		return params.ModelCost{}, errors.Annotate(err, "getting price catalog")
+	}
+	estimate := costEstimate{cost: params.ModelCost{Currency: catalog.Currency()}}
+
+	machines, err := api.backend.Machines()
+	if err != nil {
+		return params.ModelCost{}, errors.Trace(err)
+	}
+	for _, m := range machines {
+		price, err := catalog.InstancePrice(m.Constraints)
+		if err := estimate.add(params.ResourceCost{
+			Kind:        "machine",
+			Id:          m.Id,
+			Description: m.Constraints.String(),
+		}, price, err); err != nil {
+			return params.ModelCost{}, errors.Trace(err)
+		}
+	}
+
+	storage, err := api.backend.Storage()
+	if err != nil {
+		return params.ModelCost{}, errors.Trace(err)
+	}
+	for _, s := range storage {
+		sizeGiB := float64(s.Size) / 1024
+		price, err := catalog.StoragePrice(s.Pool)
+		if err := estimate.add(params.ResourceCost{
+			Kind:        s.Kind,
+			Id:          s.Id,
+			Description: fmt.Sprintf("%.1fGiB from pool %q", sizeGiB, s.Pool),
+		}, price*sizeGiB, err); err != nil {
+			return params.ModelCost{}, errors.Trace(err)
+		}
+	}
+
+	applications, err := api.backend.LoadBalancedApplications()
+	if err != nil {
+		return params.ModelCost{}, errors.Trace(err)
+	}
+	for _, name := range applications {
+		price, err := catalog.LoadBalancerPrice()
+		if err := estimate.add(params.ResourceCost{
+			Kind: "load-balancer",
+			Id:   name,
+		}, price, err); err != nil {
+			return params.ModelCost{}, errors.Trace(err)
+		}
+	}
+
+	estimate.cost.MonthlyCost = estimate.cost.HourlyCost * pricing.HoursPerMonth
+	return estimate.cost, nil
+}
+
+// costEstimate accumulates the cost of a model's resources.
+type costEstimate struct {
+	cost params.ModelCost
+}
+
+// add adds the resource, with the given hourly price, to the estimate.
+// If the price was not found, the resource is recorded with the error
+// instead; any other error is returned.
+func (e *costEstimate) add(resource params.ResourceCost, price float64, err error) error {
+	if errors.IsNotFound(err) {
+		resource.Error = common.ServerError(err)
+	} else if err != nil {
+		return errors.Annotatef(err, "pricing %s %q", resource.Kind, resource.Id)
+	} else {
+		resource.HourlyCost = price
+		e.cost.HourlyCost += price
+	}
+	e.cost.Resources = append(e.cost.Resources, resource)
+	return nil
+}
@@ -0,0 +1,216 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package costestimation_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/costestimation"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/pricing"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/instances"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type costEstimationSuite struct {
+	testing.IsolationSuite
+	backend *mockBackend
+	catalog pricing.Catalog
+}
+
+var _ = gc.Suite(&costEstimationSuite{})
+
+const catalogYAML = `
+currency: USD
+instance-types:
+  m4.large: 0.5
+storage:
+  ebs: 0.25
+load-balancer: 0.125
+`
+
+func (s *costEstimationSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		machines: []costestimation.Machine{{
+			Id:          "0",
+			Constraints: constraints.MustParse("instance-type=m4.large mem=8G"),
+		}, {
+			Id:          "1",
+			Constraints: constraints.MustParse("mem=4G"),
+		}},
+		storage: []costestimation.Storage{{
+			Kind: "volume",
+			Id:   "0",
+			Pool: "ebs",
+			Size: 2048,
+		}},
+		applications: []string{"haproxy"},
+	}
+	catalog, err := pricing.ParseCatalog([]byte(catalogYAML))
+	c.Assert(err, jc.ErrorIsNil)
+	s.catalog = catalog
+}
+
+func (s *costEstimationSuite) newAPI(c *gc.C, user string) *costestimation.API {
+	api, err := costestimation.NewAPI(s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag(user),
+	}, s.newCatalog)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *costEstimationSuite) newCatalog(*config.Config) (pricing.Catalog, error) {
+	s.backend.MethodCall(s.backend, "NewCatalog")
+	return s.catalog, s.backend.NextErr()
+}
+
+func (s *costEstimationSuite) TestRequiresClient(c *gc.C) {
+	_, err := costestimation.NewAPI(s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	}, s.newCatalog)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *costEstimationSuite) TestModelCost(c *gc.C) {
+	result, err := s.newAPI(c, "read").ModelCost()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelCost{
+		Currency:    "USD",
+		HourlyCost:  1.125,
+		MonthlyCost: 821.25,
+		Resources: []params.ResourceCost{{
+			Kind:        "machine",
+			Id:          "0",
+			Description: s.backend.machines[0].Constraints.String(),
+			HourlyCost:  0.5,
+		}, {
+			Kind:        "machine",
+			Id:          "1",
+			Description: "mem=4096M",
+			Error: &params.Error{
+				Message: "price for instance of unknown type not found",
+				Code:    params.CodeNotFound,
+			},
+		}, {
+			Kind:        "volume",
+			Id:          "0",
+			Description: `2.0GiB from pool "ebs"`,
+			HourlyCost:  0.5,
+		}, {
+			Kind:       "load-balancer",
+			Id:         "haproxy",
+			HourlyCost: 0.125,
+		}},
+	})
+	s.backend.CheckCallNames(c,
+		"ModelConfig", "NewCatalog", "Machines", "Storage", "LoadBalancedApplications",
+	)
+}
+
+func (s *costEstimationSuite) TestModelCostPermissionDenied(c *gc.C) {
+	_, err := s.newAPI(c, "bob").ModelCost()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *costEstimationSuite) TestModelCostNoCatalog(c *gc.C) {
+	s.backend.SetErrors(nil, errors.NotFoundf("price catalog"))
+	_, err := s.newAPI(c, "read").ModelCost()
+	c.Assert(err, gc.ErrorMatches, "getting price catalog: price catalog not found")
+}
+
+func (s *costEstimationSuite) TestModelCostBackendError(c *gc.C) {
+	s.backend.SetErrors(nil, nil, errors.New("boom"))
+	_, err := s.newAPI(c, "read").ModelCost()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *costEstimationSuite) TestProviderCatalog(c *gc.C) {
+	fetcher := &mockFetcher{types: instances.InstanceTypesWithCostMetadata{
+		InstanceTypes: []instances.InstanceType{
+			{Name: "m4.large", Cost: 100},
+			{Name: "m4.xlarge", Cost: 200},
+		},
+		CostCurrency: "USD",
+		CostDivisor:  1000,
+	}}
+	catalog, err := costestimation.NewProviderCatalog(fetcher)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(catalog.Currency(), gc.Equals, "USD")
+
+	price, err := catalog.InstancePrice(constraints.MustParse("mem=8G"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(price, gc.Equals, 0.1)
+	fetcher.CheckCall(c, 1, "InstanceTypes", constraints.MustParse("mem=8G"))
+
+	_, err = catalog.StoragePrice("ebs")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	_, err = catalog.LoadBalancerPrice()
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *costEstimationSuite) TestProviderCatalogNoMatch(c *gc.C) {
+	fetcher := &mockFetcher{types: instances.InstanceTypesWithCostMetadata{
+		CostCurrency: "USD",
+	}}
+	catalog, err := costestimation.NewProviderCatalog(fetcher)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = catalog.InstancePrice(constraints.MustParse("mem=8G"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `price for instance with "mem=8192M" not found`)
+}
+
+func (s *costEstimationSuite) TestProviderCatalogNoPrices(c *gc.C) {
+	_, err := costestimation.NewProviderCatalog(&mockFetcher{})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+type mockBackend struct {
+	testing.Stub
+	machines     []costestimation.Machine
+	storage      []costestimation.Storage
+	applications []string
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) ModelConfig() (*config.Config, error) {
+	b.MethodCall(b, "ModelConfig")
+	return nil, b.NextErr()
+}
+
+func (b *mockBackend) Machines() ([]costestimation.Machine, error) {
+	b.MethodCall(b, "Machines")
+	return b.machines, b.NextErr()
+}
+
+func (b *mockBackend) Storage() ([]costestimation.Storage, error) {
+	b.MethodCall(b, "Storage")
+	return b.storage, b.NextErr()
+}
+
+func (b *mockBackend) LoadBalancedApplications() ([]string, error) {
+	b.MethodCall(b, "LoadBalancedApplications")
+	return b.applications, b.NextErr()
+}
+
+type mockFetcher struct {
+	testing.Stub
+	types instances.InstanceTypesWithCostMetadata
+}
+
+func (f *mockFetcher) InstanceTypes(cons constraints.Value) (instances.InstanceTypesWithCostMetadata, error) {
+	f.MethodCall(f, "InstanceTypes", cons)
+	return f.types, f.NextErr()
+}
@@ -0,0 +1,129 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package costestimation
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+)
+
+type stateShim struct {
+	*state.State
+}
+
+// ModelConfig is part of the Backend interface.
+func (s stateShim) ModelConfig() (*config.Config, error) {
+	model, err := s.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return model.ModelConfig()
+}
+
+// Machines is part of the Backend interface.
+func (s stateShim) Machines() ([]Machine, error) {
+	machines, err := s.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []Machine
+	for _, m := range machines {
+		if m.IsContainer() {
+			continue
+		}
+		if _, err := m.InstanceId(); errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		hw, err := m.HardwareCharacteristics()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		mcons, err := m.Constraints()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result = append(result, Machine{
+			Id: m.Id(),
+			Constraints: constraints.Value{
+				Arch:         hw.Arch,
+				CpuCores:     hw.CpuCores,
+				Mem:          hw.Mem,
+				InstanceType: mcons.InstanceType,
+			},
+		})
+	}
+	return result, nil
+}
+
+// Storage is part of the Backend interface.
+func (s stateShim) Storage() ([]Storage, error) {
+	im, err := s.IAASModel()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumes, err := im.AllVolumes()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []Storage
+	for _, v := range volumes {
+		info, err := v.Info()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result = append(result, Storage{
+			Kind: "volume",
+			Id:   v.VolumeTag().Id(),
+			Pool: info.Pool,
+			Size: info.Size,
+		})
+	}
+	filesystems, err := im.AllFilesystems()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, f := range filesystems {
+		// The volumes backing filesystems are priced themselves.
+		if _, err := f.Volume(); err == nil {
+			continue
+		} else if err != state.ErrNoBackingVolume {
+			return nil, errors.Trace(err)
+		}
+		info, err := f.Info()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result = append(result, Storage{
+			Kind: "filesystem",
+			Id:   f.FilesystemTag().Id(),
+			Pool: info.Pool,
+			Size: info.Size,
+		})
+	}
+	return result, nil
+}
+
+// LoadBalancedApplications is part of the Backend interface.
+func (s stateShim) LoadBalancedApplications() ([]string, error) {
+	applications, err := s.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []string
+	for _, app := range applications {
+		if app.LoadBalancerAddress() != "" {
+			result = append(result, app.Name())
+		}
+	}
+	return result, nil
+}
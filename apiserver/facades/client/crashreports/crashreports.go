@@ -0,0 +1,148 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package crashreports implements the CrashReports facade, which lists
+// and downloads the crash reports that a model's agents have uploaded.
+package crashreports
+
+import (
+	"io"
+	"io/ioutil"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the
+// CrashReports facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+	CrashReports() ([]state.CrashReport, error)
+	CrashReport(id string) (state.CrashReport, io.ReadCloser, error)
+	RemoveCrashReport(id string) error
+}
+
+// API implements the CrashReports facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewAPI returns a new CrashReports facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(st, auth)
+}
+
+// checkCanAdmin returns an error unless the authenticated user is an
+// administrator of the model. Crash reports hold agent logs, which
+// may contain sensitive details, so they are only available to model
+// administrators.
+func (api *API) checkCanAdmin() error {
+	ok, err := api.authorizer.HasPermission(permission.AdminAccess, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// List returns the model's crash reports, oldest first.
+//
+//juju:readonly
+func (api *API) List() (params.CrashReportsResult, error) {
+	if err := api.checkCanAdmin(); err != nil {
+		return params.CrashReportsResult{}, err
+	}
+	reports, err := api.backend.CrashReports()
+	if err != nil {
+		return params.CrashReportsResult{}, errors.Trace(err)
+	}
+	result := params.CrashReportsResult{
+		Reports: make([]params.CrashReport, len(reports)),
+	}
+	for i, report := range reports {
+		result.Reports[i] = crashReportParams(report)
+	}
+	return result, nil
+}
+
+// Download returns the crash reports with the given ids, with their
+// bundles.
+//
+//juju:readonly
+func (api *API) Download(args params.CrashReportIds) (params.CrashReportBundleResults, error) {
+	if err := api.checkCanAdmin(); err != nil {
+		return params.CrashReportBundleResults{}, err
+	}
+	results := params.CrashReportBundleResults{
+		Results: make([]params.CrashReportBundleResult, len(args.Ids)),
+	}
+	for i, id := range args.Ids {
+		report, bundle, err := api.download(id)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Report = crashReportParams(report)
+		results.Results[i].Bundle = bundle
+	}
+	return results, nil
+}
+
+func (api *API) download(id string) (state.CrashReport, []byte, error) {
+	report, r, err := api.backend.CrashReport(id)
+	if err != nil {
+		return state.CrashReport{}, nil, errors.Trace(err)
+	}
+	defer r.Close()
+	bundle, err := ioutil.ReadAll(r)
+	if err != nil {
+		return state.CrashReport{}, nil, errors.Annotatef(err, "cannot read crash report %q", id)
+	}
+	return report, bundle, nil
+}
+
+// Remove removes the crash reports with the given ids.
+func (api *API) Remove(args params.CrashReportIds) (params.ErrorResults, error) {
+	if err := api.checkCanAdmin(); err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Ids)),
+	}
+	for i, id := range args.Ids {
+		err := api.backend.RemoveCrashReport(id)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func crashReportParams(report state.CrashReport) params.CrashReport {
+	return params.CrashReport{
+		Id:       report.Id,
+		Agent:    report.Agent,
+		Time:     report.Time,
+		Uploaded: report.Uploaded,
+		Reason:   report.Reason,
+		Size:     report.Size,
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crashreports_test
+
+import (
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/crashreports"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type crashReportsSuite struct {
+	testing.IsolationSuite
+	backend *mockBackend
+	api     *crashreports.API
+}
+
+var _ = gc.Suite(&crashReportsSuite{})
+
+var (
+	crashTime  = time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	uploadTime = crashTime.Add(time.Minute)
+)
+
+func (s *crashReportsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		reports: map[string]string{
+			"1": "aaaa",
+			"2": "bbbbbb",
+		},
+	}
+	api, err := crashreports.NewAPI(s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+}
+
+func (s *crashReportsSuite) TestRequiresClient(c *gc.C) {
+	_, err := crashreports.NewAPI(s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *crashReportsSuite) TestRequiresAdmin(c *gc.C) {
+	api, err := crashreports.NewAPI(s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("read"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.List()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	_, err = api.Download(params.CrashReportIds{Ids: []string{"1"}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	_, err = api.Remove(params.CrashReportIds{Ids: []string{"1"}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *crashReportsSuite) TestList(c *gc.C) {
+	result, err := s.api.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.CrashReportsResult{
+		Reports: []params.CrashReport{
+			reportParams("1", 4),
+			reportParams("2", 6),
+		},
+	})
+	s.backend.CheckCallNames(c, "CrashReports")
+}
+
+func (s *crashReportsSuite) TestDownload(c *gc.C) {
+	result, err := s.api.Download(params.CrashReportIds{Ids: []string{"2", "3"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.CrashReportBundleResults{
+		Results: []params.CrashReportBundleResult{{
+			Report: reportParams("2", 6),
+			Bundle: []byte("bbbbbb"),
+		}, {
+			Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: `crash report "3" not found`,
+			},
+		}},
+	})
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"CrashReport", []interface{}{"2"}},
+		{"CrashReport", []interface{}{"3"}},
+	})
+}
+
+func (s *crashReportsSuite) TestRemove(c *gc.C) {
+	s.backend.SetErrors(nil, errors.New("boom"))
+	result, err := s.api.Remove(params.CrashReportIds{Ids: []string{"1", "2"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "boom"}},
+		},
+	})
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"RemoveCrashReport", []interface{}{"1"}},
+		{"RemoveCrashReport", []interface{}{"2"}},
+	})
+}
+
+func reportParams(id string, size int64) params.CrashReport {
+	return params.CrashReport{
+		Id:       id,
+		Agent:    "machine-" + id,
+		Time:     crashTime,
+		Uploaded: uploadTime,
+		Reason:   "panic: " + id,
+		Size:     size,
+	}
+}
+
+type mockBackend struct {
+	testing.Stub
+	reports map[string]string
+}
+
+func (b *mockBackend) report(id string) state.CrashReport {
+	return state.CrashReport{
+		Id:       id,
+		Agent:    "machine-" + id,
+		Time:     crashTime,
+		Uploaded: uploadTime,
+		Reason:   "panic: " + id,
+		Size:     int64(len(b.reports[id])),
+	}
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) CrashReports() ([]state.CrashReport, error) {
+	b.MethodCall(b, "CrashReports")
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	return []state.CrashReport{b.report("1"), b.report("2")}, nil
+}
+
+func (b *mockBackend) CrashReport(id string) (state.CrashReport, io.ReadCloser, error) {
+	b.MethodCall(b, "CrashReport", id)
+	if err := b.NextErr(); err != nil {
+		return state.CrashReport{}, nil, err
+	}
+	content, ok := b.reports[id]
+	if !ok {
+		return state.CrashReport{}, nil, errors.NotFoundf("crash report %q", id)
+	}
+	return b.report(id), ioutil.NopCloser(strings.NewReader(content)), nil
+}
+
+func (b *mockBackend) RemoveCrashReport(id string) error {
+	b.MethodCall(b, "RemoveCrashReport", id)
+	return b.NextErr()
+}
@@ -0,0 +1,182 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package fanconfig implements the FanConfig facade, which configures
+// the fan overlays that give a model's containers routable addresses
+// across hosts.
+package fanconfig
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the FanConfig
+// facade.
+type Backend interface {
+	common.BlockGetter
+	ModelTag() names.ModelTag
+	ModelConfig() (*config.Config, error)
+	UpdateModelConfig(map[string]interface{}, []string, ...state.ValidateConfigFunc) error
+	AllSubnets() ([]Subnet, error)
+}
+
+// Subnet defines the methods the FanConfig facade needs from
+// state.Subnet.
+type Subnet interface {
+	CIDR() string
+	FanOverlay() string
+}
+
+// API implements the FanConfig facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+	check      *common.BlockChecker
+}
+
+// NewAPI returns a new FanConfig facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+		check:      common.NewBlockChecker(backend),
+	}, nil
+}
+
+func (api *API) checkAccess(access permission.Access) error {
+	ok, err := api.authorizer.HasPermission(access, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// FanConfig returns the fans configured for the model, with the
+// segment of each fan's overlay given to each of the model's subnets
+// on its underlay.
+//
+//juju:readonly
+func (api *API) FanConfig() (params.FanOverlaysResult, error) {
+	if err := api.checkAccess(permission.ReadAccess); err != nil {
+		return params.FanOverlaysResult{}, errors.Trace(err)
+	}
+	cfg, err := api.backend.ModelConfig()
+	if err != nil {
+		return params.FanOverlaysResult{}, errors.Trace(err)
+	}
+	fanConfig, err := cfg.FanConfig()
+	if err != nil {
+		return params.FanOverlaysResult{}, errors.Trace(err)
+	}
+	subnets, err := api.backend.AllSubnets()
+	if err != nil {
+		return params.FanOverlaysResult{}, errors.Trace(err)
+	}
+	result := params.FanOverlaysResult{
+		Overlays:                  make([]params.FanOverlay, len(fanConfig)),
+		ContainerNetworkingMethod: cfg.ContainerNetworkingMethod(),
+	}
+	for i, fan := range fanConfig {
+		overlay := params.FanOverlay{
+			Underlay: fan.Underlay.String(),
+			Overlay:  fan.Overlay.String(),
+		}
+		for _, subnet := range subnets {
+			if subnet.FanOverlay() != "" {
+				// The subnet is itself a segment of an overlay.
+				continue
+			}
+			segment, err := network.CalculateOverlaySegment(subnet.CIDR(), fan)
+			if err != nil {
+				return params.FanOverlaysResult{}, errors.Annotatef(err, "subnet %q", subnet.CIDR())
+			}
+			if segment == nil {
+				continue
+			}
+			overlay.Subnets = append(overlay.Subnets, params.FanOverlaySubnet{
+				CIDR:           subnet.CIDR(),
+				OverlaySegment: segment.String(),
+			})
+		}
+		result.Overlays[i] = overlay
+	}
+	return result, nil
+}
+
+// SetFanConfig replaces the fans configured for the model. The agents
+// of the model's machines set up a bridge for each fan whose underlay
+// they are on. If UseForContainers is set, new containers get their
+// addresses from the fans' overlays.
+func (api *API) SetFanConfig(args params.SetFanConfig) error {
+	if err := api.checkAccess(permission.WriteAccess); err != nil {
+		return errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	entries := make([]string, len(args.Fans))
+	for i, fan := range args.Fans {
+		entries[i] = fmt.Sprintf("%s=%s", fan.Underlay, fan.Overlay)
+	}
+	line := strings.Join(entries, " ")
+	fanConfig, err := network.ParseFanConfig(line)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := checkFanOverlaps(fanConfig); err != nil {
+		return errors.Trace(err)
+	}
+	attrs := map[string]interface{}{
+		config.FanConfig: line,
+	}
+	if args.UseForContainers {
+		attrs[config.ContainerNetworkingMethod] = "fan"
+	}
+	return errors.Trace(api.backend.UpdateModelConfig(attrs, nil))
+}
+
+// checkFanOverlaps returns an error if the overlay of any of the fans
+// overlaps the underlay or overlay of another, so that the addresses
+// of containers would be ambiguous.
+func checkFanOverlaps(fanConfig network.FanConfig) error {
+	for i, fan := range fanConfig {
+		for j, other := range fanConfig {
+			if i == j {
+				continue
+			}
+			if overlaps(fan.Overlay, other.Overlay) {
+				return errors.Errorf("invalid fan config: overlay %s overlaps overlay %s", fan.Overlay, other.Overlay)
+			}
+			if overlaps(fan.Overlay, other.Underlay) {
+				return errors.Errorf("invalid fan config: overlay %s overlaps underlay %s", fan.Overlay, other.Underlay)
+			}
+		}
+		if overlaps(fan.Overlay, fan.Underlay) {
+			return errors.Errorf("invalid fan config: overlay %s overlaps its underlay %s", fan.Overlay, fan.Underlay)
+		}
+	}
+	return nil
+}
+
+func overlaps(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
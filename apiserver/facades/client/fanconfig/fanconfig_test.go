@@ -0,0 +1,175 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package fanconfig_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/fanconfig"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/provider/dummy"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type fanConfigSuite struct {
+	testing.IsolationSuite
+	backend *mockBackend
+}
+
+var _ = gc.Suite(&fanConfigSuite{})
+
+func (s *fanConfigSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	cfg, err := config.New(config.UseDefaults, dummy.SampleConfig().Merge(coretesting.Attrs{
+		"fan-config": "172.31.0.0/16=253.0.0.0/8",
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend = &mockBackend{
+		config: cfg,
+		subnets: []fanconfig.Subnet{
+			&mockSubnet{cidr: "172.31.64.0/20"},
+			&mockSubnet{cidr: "172.31.16.0/20"},
+			&mockSubnet{cidr: "253.64.0.0/12", fanOverlay: "253.0.0.0/8"},
+			&mockSubnet{cidr: "10.0.0.0/24"},
+		},
+	}
+}
+
+func (s *fanConfigSuite) newAPI(c *gc.C, user string) *fanconfig.API {
+	api, err := fanconfig.NewAPI(s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag(user),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *fanConfigSuite) TestRequiresClient(c *gc.C) {
+	_, err := fanconfig.NewAPI(s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *fanConfigSuite) TestFanConfig(c *gc.C) {
+	result, err := s.newAPI(c, "read").FanConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.FanOverlaysResult{
+		Overlays: []params.FanOverlay{{
+			Underlay: "172.31.0.0/16",
+			Overlay:  "253.0.0.0/8",
+			Subnets: []params.FanOverlaySubnet{{
+				CIDR:           "172.31.64.0/20",
+				OverlaySegment: "253.64.0.0/12",
+			}, {
+				CIDR:           "172.31.16.0/20",
+				OverlaySegment: "253.16.0.0/12",
+			}},
+		}},
+	})
+}
+
+func (s *fanConfigSuite) TestFanConfigPermissionDenied(c *gc.C) {
+	_, err := s.newAPI(c, "bob").FanConfig()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *fanConfigSuite) TestSetFanConfig(c *gc.C) {
+	err := s.newAPI(c, "write").SetFanConfig(params.SetFanConfig{
+		Fans: []params.FanConfigEntry{
+			{Underlay: "172.31.0.0/16", Overlay: "253.0.0.0/8"},
+			{Underlay: "10.0.0.0/12", Overlay: "254.0.0.0/7"},
+		},
+		UseForContainers: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCall(c, 0, "UpdateModelConfig", map[string]interface{}{
+		"fan-config":                  "172.31.0.0/16=253.0.0.0/8 10.0.0.0/12=254.0.0.0/7",
+		"container-networking-method": "fan",
+	}, []string(nil))
+}
+
+func (s *fanConfigSuite) TestSetFanConfigClear(c *gc.C) {
+	err := s.newAPI(c, "write").SetFanConfig(params.SetFanConfig{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCall(c, 0, "UpdateModelConfig", map[string]interface{}{
+		"fan-config": "",
+	}, []string(nil))
+}
+
+func (s *fanConfigSuite) TestSetFanConfigInvalid(c *gc.C) {
+	api := s.newAPI(c, "write")
+	err := api.SetFanConfig(params.SetFanConfig{
+		Fans: []params.FanConfigEntry{{Underlay: "172.31.0.0/16", Overlay: "253.0.0.0/24"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "invalid FAN config, underlay mask must be larger than overlay: .*")
+
+	err = api.SetFanConfig(params.SetFanConfig{
+		Fans: []params.FanConfigEntry{
+			{Underlay: "172.31.0.0/16", Overlay: "253.0.0.0/8"},
+			{Underlay: "10.0.0.0/16", Overlay: "253.0.0.0/8"},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, "invalid fan config: overlay 253.0.0.0/8 overlaps overlay 253.0.0.0/8")
+
+	err = api.SetFanConfig(params.SetFanConfig{
+		Fans: []params.FanConfigEntry{{Underlay: "10.1.0.0/16", Overlay: "10.0.0.0/8"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "invalid fan config: overlay 10.0.0.0/8 overlaps its underlay 10.1.0.0/16")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *fanConfigSuite) TestSetFanConfigPermissionDenied(c *gc.C) {
+	err := s.newAPI(c, "read").SetFanConfig(params.SetFanConfig{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+type mockBackend struct {
+	testing.Stub
+	config  *config.Config
+	subnets []fanconfig.Subnet
+}
+
+func (b *mockBackend) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
+	return nil, false, nil
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) ModelConfig() (*config.Config, error) {
+	return b.config, nil
+}
+
+func (b *mockBackend) UpdateModelConfig(
+	updateAttrs map[string]interface{}, removeAttrs []string, _ ...state.ValidateConfigFunc,
+) error {
+	b.MethodCall(b, "UpdateModelConfig", updateAttrs, removeAttrs)
+	return b.NextErr()
+}
+
+func (b *mockBackend) AllSubnets() ([]fanconfig.Subnet, error) {
+	return b.subnets, nil
+}
+
+type mockSubnet struct {
+	cidr       string
+	fanOverlay string
+}
+
+func (s *mockSubnet) CIDR() string {
+	return s.cidr
+}
+
+func (s *mockSubnet) FanOverlay() string {
+	return s.fanOverlay
+}
@@ -0,0 +1,52 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package fanconfig
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+)
+
+// NewFacade wraps NewAPI to express the supplied *state.State as a
+// Backend.
+func NewFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewAPI(&backendShim{st, model}, auth)
+}
+
+type backendShim struct {
+	*state.State
+	model *state.Model
+}
+
+// ModelConfig implements Backend.
+func (b *backendShim) ModelConfig() (*config.Config, error) {
+	return b.model.ModelConfig()
+}
+
+// UpdateModelConfig implements Backend.
+func (b *backendShim) UpdateModelConfig(
+	updateAttrs map[string]interface{}, removeAttrs []string, additionalValidation ...state.ValidateConfigFunc,
+) error {
+	return b.model.UpdateModelConfig(updateAttrs, removeAttrs, additionalValidation...)
+}
+
+// AllSubnets implements Backend.
+func (b *backendShim) AllSubnets() ([]Subnet, error) {
+	subnets, err := b.State.AllSubnets()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Subnet, len(subnets))
+	for i, subnet := range subnets {
+		result[i] = subnet
+	}
+	return result, nil
+}
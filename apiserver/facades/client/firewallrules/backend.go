@@ -4,8 +4,10 @@
 package firewallrules
 
 import (
+	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 )
 
@@ -16,6 +18,29 @@ type Backend interface {
 	ModelTag() names.ModelTag
 	SaveFirewallRule(state.FirewallRule) error
 	ListFirewallRules() ([]*state.FirewallRule, error)
+	FirewallMode() (string, error)
+	FirewallReports() ([]state.FirewallReport, error)
+	ModelEvents(state.ModelEventsFilter) ([]state.ModelEvent, error)
+	AllApplications() ([]Application, error)
+}
+
+// Application defines the application functionality required by the
+// firewallrules facade. For details on the methods, see the methods on
+// state.Application with the same names.
+type Application interface {
+	Name() string
+	ApplicationTag() names.ApplicationTag
+	IsExposed() bool
+	AllUnits() ([]Unit, error)
+}
+
+// Unit defines the unit functionality required by the firewallrules
+// facade. For details on the methods, see the methods on state.Unit
+// with the same names.
+type Unit interface {
+	UnitTag() names.UnitTag
+	AssignedMachineId() (string, error)
+	OpenedPorts() ([]network.PortRange, error)
 }
 
 // BlockChecker defines the block-checking functionality required by
@@ -53,3 +78,39 @@ func (s stateShim) ListFirewallRules() ([]*state.FirewallRule, error) {
 	api := state.NewFirewallRules(s.State)
 	return api.AllRules()
 }
+
+func (s stateShim) FirewallMode() (string, error) {
+	cfg, err := s.ModelConfig()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return cfg.FirewallMode(), nil
+}
+
+func (s stateShim) AllApplications() ([]Application, error) {
+	apps, err := s.State.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Application, len(apps))
+	for i, app := range apps {
+		result[i] = applicationShim{app}
+	}
+	return result, nil
+}
+
+type applicationShim struct {
+	*state.Application
+}
+
+func (a applicationShim) AllUnits() ([]Unit, error) {
+	units, err := a.Application.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Unit, len(units))
+	for i, unit := range units {
+		result[i] = unit
+	}
+	return result, nil
+}
@@ -17,13 +17,31 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.firewallrules")
 
-// API provides the firewallrules facade APIs for v1.
+// API provides the firewallrules facade APIs for v2.
 type API struct {
 	backend    Backend
 	authorizer facade.Authorizer
 	check      BlockChecker
 }
 
+// APIV1 provides the firewallrules facade APIs for v1.
+type APIV1 struct {
+	*API
+}
+
+// NewFacadeV1 provides the signature required for facade registration
+// of version 1.
+func NewFacadeV1(ctx facade.Context) (*APIV1, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV1{api}, nil
+}
+
+// FirewallReport isn't on the V1 API.
+func (*APIV1) FirewallReport(_, _ struct{}) {}
+
 // NewFacade provides the signature required for facade registration.
 func NewFacade(ctx facade.Context) (*API, error) {
 	backend, err := NewStateBackend(ctx.State())
@@ -97,6 +115,8 @@ func (api *API) SetFirewallRules(args params.FirewallRuleArgs) (params.ErrorResu
 }
 
 // ListFirewallRules returns all the firewall rules.
+//
+//juju:readonly
 func (api *API) ListFirewallRules() (params.ListFirewallRulesResults, error) {
 	var listResults params.ListFirewallRulesResults
 	if err := api.checkCanRead(); err != nil {
@@ -4,10 +4,12 @@
 package firewallrules_test
 
 import (
+	"github.com/juju/errors"
 	jtesting "github.com/juju/testing"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/facades/client/firewallrules"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 )
 
@@ -15,8 +17,12 @@ type mockBackend struct {
 	jtesting.Stub
 	firewallrules.Backend
 
-	modelUUID string
-	rules     map[string]state.FirewallRule
+	modelUUID    string
+	rules        map[string]state.FirewallRule
+	firewallMode string
+	reports      []state.FirewallReport
+	events       []state.ModelEvent
+	applications []firewallrules.Application
 }
 
 func (m *mockBackend) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
@@ -47,6 +53,78 @@ func (m *mockBackend) ListFirewallRules() ([]*state.FirewallRule, error) {
 	}, nil
 }
 
+func (m *mockBackend) FirewallMode() (string, error) {
+	m.MethodCall(m, "FirewallMode")
+	return m.firewallMode, m.NextErr()
+}
+
+func (m *mockBackend) FirewallReports() ([]state.FirewallReport, error) {
+	m.MethodCall(m, "FirewallReports")
+	return m.reports, m.NextErr()
+}
+
+func (m *mockBackend) ModelEvents(filter state.ModelEventsFilter) ([]state.ModelEvent, error) {
+	m.MethodCall(m, "ModelEvents", filter)
+	var events []state.ModelEvent
+	for _, event := range m.events {
+		for _, entity := range event.Entities {
+			if entity == filter.Entity {
+				events = append(events, event)
+				break
+			}
+		}
+	}
+	return events, m.NextErr()
+}
+
+func (m *mockBackend) AllApplications() ([]firewallrules.Application, error) {
+	m.MethodCall(m, "AllApplications")
+	return m.applications, m.NextErr()
+}
+
+type mockApplication struct {
+	name    string
+	exposed bool
+	units   []firewallrules.Unit
+}
+
+func (a *mockApplication) Name() string {
+	return a.name
+}
+
+func (a *mockApplication) ApplicationTag() names.ApplicationTag {
+	return names.NewApplicationTag(a.name)
+}
+
+func (a *mockApplication) IsExposed() bool {
+	return a.exposed
+}
+
+func (a *mockApplication) AllUnits() ([]firewallrules.Unit, error) {
+	return a.units, nil
+}
+
+type mockUnit struct {
+	name       string
+	machineId  string
+	portRanges []network.PortRange
+}
+
+func (u *mockUnit) UnitTag() names.UnitTag {
+	return names.NewUnitTag(u.name)
+}
+
+func (u *mockUnit) AssignedMachineId() (string, error) {
+	if u.machineId == "" {
+		return "", errors.NotAssignedf("unit %q", u.name)
+	}
+	return u.machineId, nil
+}
+
+func (u *mockUnit) OpenedPorts() ([]network.PortRange, error) {
+	return u.portRanges, nil
+}
+
 type mockBlockChecker struct {
 	jtesting.Stub
 }
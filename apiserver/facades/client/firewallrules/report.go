@@ -0,0 +1,233 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewallrules
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+)
+
+// exposeHistoryLimit is the number of the most recent expose and
+// unexpose events reported for each application.
+const exposeHistoryLimit = 10
+
+// FirewallReport returns, for each of the specified applications or
+// for all the model's applications if none are specified, whether the
+// application is exposed and whether the provider allows ingress to
+// each port its units have opened, as last reported by the firewaller.
+func (api *API) FirewallReport(args params.Entities) (params.FirewallReportResult, error) {
+	var result params.FirewallReportResult
+	if err := api.checkCanRead(); err != nil {
+		return result, errors.Trace(err)
+	}
+	apps, err := api.selectApplications(args.Entities)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if result.FirewallMode, err = api.backend.FirewallMode(); err != nil {
+		return result, errors.Trace(err)
+	}
+	reports, err := api.backend.FirewallReports()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	byMachine := make(map[string]*state.FirewallReport)
+	for i, report := range reports {
+		byMachine[report.MachineId] = &reports[i]
+	}
+	reportFor := func(machineId string) *state.FirewallReport {
+		if result.FirewallMode == config.FwGlobal {
+			return byMachine[""]
+		}
+		return byMachine[machineId]
+	}
+
+	machines := make(map[string]bool)
+	result.Applications = make([]params.ApplicationFirewallState, len(apps))
+	for i, app := range apps {
+		appState := params.ApplicationFirewallState{
+			Name:    app.Name(),
+			Exposed: app.IsExposed(),
+		}
+		if appState.ExposeHistory, err = api.exposeHistory(app); err != nil {
+			return result, errors.Trace(err)
+		}
+		units, err := app.AllUnits()
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		for _, unit := range units {
+			unitState, machineId, err := unitFirewallState(unit, appState.Exposed, reportFor)
+			if err != nil {
+				return result, errors.Trace(err)
+			}
+			if machineId != "" {
+				machines[machineId] = true
+			}
+			appState.Units = append(appState.Units, unitState)
+		}
+		result.Applications[i] = appState
+	}
+
+	for _, report := range reports {
+		if report.MachineId != "" && !machines[report.MachineId] && len(args.Entities) > 0 {
+			continue
+		}
+		result.Reports = append(result.Reports, params.FirewallReport{
+			MachineTag: machineTag(report.MachineId),
+			Checked:    report.Checked,
+			Wanted:     params.FromNetworkIngressRules(report.Wanted),
+			Actual:     params.FromNetworkIngressRules(report.Actual),
+			Missing:    params.FromNetworkIngressRules(report.Missing),
+			Unexpected: params.FromNetworkIngressRules(report.Unexpected),
+			Error:      report.Error,
+		})
+	}
+	return result, nil
+}
+
+// selectApplications returns the applications with the given tags,
+// ordered by name, or all the model's applications if none are given.
+func (api *API) selectApplications(entities []params.Entity) ([]Application, error) {
+	all, err := api.backend.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var apps []Application
+	if len(entities) == 0 {
+		apps = all
+	} else {
+		byName := make(map[string]Application)
+		for _, app := range all {
+			byName[app.Name()] = app
+		}
+		for _, entity := range entities {
+			tag, err := names.ParseApplicationTag(entity.Tag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			app, ok := byName[tag.Id()]
+			if !ok {
+				return nil, errors.NotFoundf("application %q", tag.Id())
+			}
+			apps = append(apps, app)
+		}
+	}
+	sort.Slice(apps, func(i, j int) bool {
+		return apps[i].Name() < apps[j].Name()
+	})
+	return apps, nil
+}
+
+// exposeHistory returns the most recent events recording the
+// application being exposed or unexposed.
+func (api *API) exposeHistory(app Application) ([]params.ModelEvent, error) {
+	events, err := api.backend.ModelEvents(state.ModelEventsFilter{
+		Limit:  exposeHistoryLimit,
+		Entity: app.ApplicationTag().String(),
+		Kinds: []state.ModelEventKind{
+			state.EventApplicationExposed,
+			state.EventApplicationUnexposed,
+		},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(events) == 0 {
+		return nil, nil
+	}
+	history := make([]params.ModelEvent, len(events))
+	for i, event := range events {
+		history[i] = params.ModelEvent{
+			Seq:      event.Seq,
+			Time:     event.Time,
+			Kind:     string(event.Kind),
+			Actor:    event.Actor,
+			Entities: event.Entities,
+			Message:  event.Message,
+		}
+	}
+	return history, nil
+}
+
+// unitFirewallState returns the state of the ports opened by the unit,
+// with the id of the machine to which it is assigned.
+func unitFirewallState(
+	unit Unit,
+	exposed bool,
+	reportFor func(machineId string) *state.FirewallReport,
+) (params.UnitFirewallState, string, error) {
+	unitState := params.UnitFirewallState{
+		UnitTag: unit.UnitTag().String(),
+	}
+	machineId, err := unit.AssignedMachineId()
+	if errors.IsNotAssigned(err) {
+		// A unit that has not been assigned cannot open ports.
+		return unitState, "", nil
+	} else if err != nil {
+		return unitState, "", errors.Trace(err)
+	}
+	unitState.MachineTag = machineTag(machineId)
+	portRanges, err := unit.OpenedPorts()
+	if err != nil {
+		return unitState, "", errors.Trace(err)
+	}
+	report := reportFor(machineId)
+	for _, portRange := range portRanges {
+		unitState.Ports = append(unitState.Ports, params.PortFirewallState{
+			PortRange: params.FromNetworkPortRange(portRange),
+			State:     portState(portRange, exposed, report),
+		})
+	}
+	return unitState, machineId, nil
+}
+
+// portState returns whether the provider allows ingress to the port
+// range, according to the firewaller's report.
+func portState(portRange network.PortRange, exposed bool, report *state.FirewallReport) string {
+	switch {
+	case !exposed:
+		return params.PortUnexposed
+	case report == nil || report.Error != "":
+		return params.PortUnchecked
+	case !hasRule(report.Wanted, portRange):
+		// The port was opened after the firewaller's last check.
+		return params.PortUnchecked
+	case hasRule(report.Missing, portRange):
+		return params.PortMissing
+	}
+	for _, rule := range report.Actual {
+		if strings.EqualFold(rule.Protocol, portRange.Protocol) &&
+			rule.FromPort <= portRange.FromPort &&
+			rule.ToPort >= portRange.ToPort {
+			return params.PortOpen
+		}
+	}
+	return params.PortMissing
+}
+
+// hasRule returns whether any of the rules is for the port range.
+func hasRule(rules []network.IngressRule, portRange network.PortRange) bool {
+	for _, rule := range rules {
+		if rule.PortRange == portRange {
+			return true
+		}
+	}
+	return false
+}
+
+func machineTag(machineId string) string {
+	if machineId == "" {
+		return ""
+	}
+	return names.NewMachineTag(machineId).String()
+}
@@ -0,0 +1,239 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewallrules_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/firewallrules"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+)
+
+var checked = time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func ingressRule(c *gc.C, portRange string) network.IngressRule {
+	pr, err := network.ParsePortRange(portRange)
+	c.Assert(err, jc.ErrorIsNil)
+	return network.IngressRule{PortRange: pr, SourceCIDRs: []string{"0.0.0.0/0"}}
+}
+
+func portRange(c *gc.C, portRange string) network.PortRange {
+	return ingressRule(c, portRange).PortRange
+}
+
+func (s *FirewallRulesSuite) setUpApplications(c *gc.C) {
+	s.backend.applications = []firewallrules.Application{
+		&mockApplication{
+			name:    "wordpress",
+			exposed: true,
+			units: []firewallrules.Unit{
+				&mockUnit{
+					name:      "wordpress/0",
+					machineId: "0",
+					portRanges: []network.PortRange{
+						portRange(c, "80/tcp"),
+						portRange(c, "443/tcp"),
+						portRange(c, "8080/tcp"),
+					},
+				},
+				&mockUnit{
+					name:       "wordpress/1",
+					machineId:  "1",
+					portRanges: []network.PortRange{portRange(c, "80/tcp")},
+				},
+				&mockUnit{name: "wordpress/2"},
+			},
+		},
+		&mockApplication{
+			name: "mysql",
+			units: []firewallrules.Unit{
+				&mockUnit{
+					name:       "mysql/0",
+					machineId:  "2",
+					portRanges: []network.PortRange{portRange(c, "3306/tcp")},
+				},
+			},
+		},
+	}
+	s.backend.events = []state.ModelEvent{{
+		Seq:      3,
+		Time:     checked.Add(-time.Hour),
+		Kind:     state.EventApplicationExposed,
+		Actor:    "user-admin",
+		Entities: []string{"application-wordpress"},
+		Message:  "exposed",
+	}}
+}
+
+func (s *FirewallRulesSuite) TestFirewallReportInstanceMode(c *gc.C) {
+	s.setUpApplications(c)
+	s.backend.firewallMode = "instance"
+	s.backend.reports = []state.FirewallReport{{
+		MachineId: "0",
+		Checked:   checked,
+		Wanted:    []network.IngressRule{ingressRule(c, "80/tcp"), ingressRule(c, "443/tcp")},
+		Actual:    []network.IngressRule{ingressRule(c, "22/tcp"), ingressRule(c, "80-90/tcp")},
+		Missing:   []network.IngressRule{ingressRule(c, "443/tcp")},
+		Unexpected: []network.IngressRule{
+			ingressRule(c, "22/tcp"), ingressRule(c, "80-90/tcp"),
+		},
+	}, {
+		MachineId: "3",
+		Checked:   checked,
+	}}
+
+	result, err := s.api.FirewallReport(params.Entities{
+		Entities: []params.Entity{{Tag: "application-wordpress"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.FirewallReportResult{
+		FirewallMode: "instance",
+		Applications: []params.ApplicationFirewallState{{
+			Name:    "wordpress",
+			Exposed: true,
+			ExposeHistory: []params.ModelEvent{{
+				Seq:      3,
+				Time:     checked.Add(-time.Hour),
+				Kind:     "application-exposed",
+				Actor:    "user-admin",
+				Entities: []string{"application-wordpress"},
+				Message:  "exposed",
+			}},
+			Units: []params.UnitFirewallState{{
+				UnitTag:    "unit-wordpress-0",
+				MachineTag: "machine-0",
+				Ports: []params.PortFirewallState{{
+					PortRange: params.PortRange{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+					State:     params.PortOpen,
+				}, {
+					PortRange: params.PortRange{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+					State:     params.PortMissing,
+				}, {
+					PortRange: params.PortRange{FromPort: 8080, ToPort: 8080, Protocol: "tcp"},
+					State:     params.PortUnchecked,
+				}},
+			}, {
+				UnitTag:    "unit-wordpress-1",
+				MachineTag: "machine-1",
+				Ports: []params.PortFirewallState{{
+					PortRange: params.PortRange{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+					State:     params.PortUnchecked,
+				}},
+			}, {
+				UnitTag: "unit-wordpress-2",
+			}},
+		}},
+		Reports: []params.FirewallReport{{
+			MachineTag: "machine-0",
+			Checked:    checked,
+			Wanted: []params.IngressRule{{
+				PortRange:   params.PortRange{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+				SourceCIDRs: []string{"0.0.0.0/0"},
+			}, {
+				PortRange:   params.PortRange{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+				SourceCIDRs: []string{"0.0.0.0/0"},
+			}},
+			Actual: []params.IngressRule{{
+				PortRange:   params.PortRange{FromPort: 22, ToPort: 22, Protocol: "tcp"},
+				SourceCIDRs: []string{"0.0.0.0/0"},
+			}, {
+				PortRange:   params.PortRange{FromPort: 80, ToPort: 90, Protocol: "tcp"},
+				SourceCIDRs: []string{"0.0.0.0/0"},
+			}},
+			Missing: []params.IngressRule{{
+				PortRange:   params.PortRange{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+				SourceCIDRs: []string{"0.0.0.0/0"},
+			}},
+			Unexpected: []params.IngressRule{{
+				PortRange:   params.PortRange{FromPort: 22, ToPort: 22, Protocol: "tcp"},
+				SourceCIDRs: []string{"0.0.0.0/0"},
+			}, {
+				PortRange:   params.PortRange{FromPort: 80, ToPort: 90, Protocol: "tcp"},
+				SourceCIDRs: []string{"0.0.0.0/0"},
+			}},
+		}},
+	})
+	s.backend.CheckCall(c, 4, "ModelEvents", state.ModelEventsFilter{
+		Limit:  10,
+		Entity: "application-wordpress",
+		Kinds: []state.ModelEventKind{
+			state.EventApplicationExposed,
+			state.EventApplicationUnexposed,
+		},
+	})
+}
+
+func (s *FirewallRulesSuite) TestFirewallReportGlobalMode(c *gc.C) {
+	s.setUpApplications(c)
+	s.backend.firewallMode = "global"
+	s.backend.reports = []state.FirewallReport{{
+		Checked: checked,
+		Wanted:  []network.IngressRule{ingressRule(c, "80/tcp")},
+		Actual:  []network.IngressRule{ingressRule(c, "80/tcp")},
+	}}
+
+	result, err := s.api.FirewallReport(params.Entities{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.FirewallMode, gc.Equals, "global")
+	c.Assert(result.Applications, gc.HasLen, 2)
+
+	mysql := result.Applications[0]
+	c.Assert(mysql.Name, gc.Equals, "mysql")
+	c.Assert(mysql.ExposeHistory, gc.HasLen, 0)
+	c.Assert(mysql.Units[0].Ports[0].State, gc.Equals, params.PortUnexposed)
+
+	wordpress := result.Applications[1]
+	c.Assert(wordpress.Units[0].Ports[0].State, gc.Equals, params.PortOpen)
+	c.Assert(wordpress.Units[0].Ports[1].State, gc.Equals, params.PortUnchecked)
+	c.Assert(wordpress.Units[1].Ports[0].State, gc.Equals, params.PortOpen)
+
+	c.Assert(result.Reports, gc.HasLen, 1)
+	c.Assert(result.Reports[0].MachineTag, gc.Equals, "")
+}
+
+func (s *FirewallRulesSuite) TestFirewallReportReportError(c *gc.C) {
+	s.setUpApplications(c)
+	s.backend.firewallMode = "instance"
+	s.backend.reports = []state.FirewallReport{{
+		MachineId: "1",
+		Checked:   checked,
+		Wanted:    []network.IngressRule{ingressRule(c, "80/tcp")},
+		Error:     `instance "i-1" not found`,
+	}}
+
+	result, err := s.api.FirewallReport(params.Entities{
+		Entities: []params.Entity{{Tag: "application-wordpress"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Applications[0].Units[1].Ports[0].State, gc.Equals, params.PortUnchecked)
+	c.Assert(result.Reports, gc.HasLen, 1)
+	c.Assert(result.Reports[0].Error, gc.Equals, `instance "i-1" not found`)
+}
+
+func (s *FirewallRulesSuite) TestFirewallReportApplicationNotFound(c *gc.C) {
+	s.setUpApplications(c)
+	_, err := s.api.FirewallReport(params.Entities{
+		Entities: []params.Entity{{Tag: "application-django"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `application "django" not found`)
+}
+
+func (s *FirewallRulesSuite) TestFirewallReportInvalidTag(c *gc.C) {
+	_, err := s.api.FirewallReport(params.Entities{
+		Entities: []params.Entity{{Tag: "unit-wordpress-0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `"unit-wordpress-0" is not a valid application tag`)
+}
+
+func (s *FirewallRulesSuite) TestFirewallReportPermission(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("mary"))
+	_, err := s.api.FirewallReport(params.Entities{})
+	c.Assert(err, gc.ErrorMatches, ".*permission denied.*")
+}
@@ -9,6 +9,8 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/replicaset"
+	"github.com/juju/utils"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
@@ -18,6 +20,7 @@ import (
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
 )
 
 var logger = loggo.GetLogger("juju.apiserver.highavailability")
@@ -25,6 +28,14 @@ var logger = loggo.GetLogger("juju.apiserver.highavailability")
 // HighAvailability defines the methods on the highavailability API end point.
 type HighAvailability interface {
 	EnableHA(args params.ControllersSpecs) (params.ControllersChangeResults, error)
+	ControllersProgress() (params.ControllersProgress, error)
+	WatchControllersProgress() (params.NotifyWatchResult, error)
+}
+
+// HighAvailabilityAPIV2 implements version 2 of the HighAvailability
+// facade, which does not report the progress of controller machines.
+type HighAvailabilityAPIV2 struct {
+	*HighAvailabilityAPI
 }
 
 // HighAvailabilityAPI implements the HighAvailability interface and is the concrete
@@ -50,17 +61,45 @@ func NewHighAvailabilityAPI(st *state.State, resources facade.Resources, authori
 	}, nil
 }
 
-// EnableHA adds controller machines as necessary to ensure the
-// controller has the number of machines specified.
-func (api *HighAvailabilityAPI) EnableHA(args params.ControllersSpecs) (params.ControllersChangeResults, error) {
-	results := params.ControllersChangeResults{}
+// NewHighAvailabilityAPIV2 creates a new server-side highavailability
+// API end point for version 2 of the facade.
+func NewHighAvailabilityAPIV2(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*HighAvailabilityAPIV2, error) {
+	api, err := NewHighAvailabilityAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &HighAvailabilityAPIV2{api}, nil
+}
+
+// ControllersProgress is not available in version 2 of the facade.
+//
+//juju:readonly
+func (*HighAvailabilityAPIV2) ControllersProgress(_, _ struct{}) {}
 
+// WatchControllersProgress is not available in version 2 of the facade.
+//
+//juju:readonly
+func (*HighAvailabilityAPIV2) WatchControllersProgress(_, _ struct{}) {}
+
+// checkIsSuperuser returns ErrPerm unless the authenticated user is a
+// controller superuser.
+func (api *HighAvailabilityAPI) checkIsSuperuser() error {
 	admin, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.state.ControllerTag())
 	if err != nil && !errors.IsNotFound(err) {
-		return results, errors.Trace(err)
+		return errors.Trace(err)
 	}
 	if !admin {
-		return results, common.ServerError(common.ErrPerm)
+		return common.ServerError(common.ErrPerm)
+	}
+	return nil
+}
+
+// EnableHA adds controller machines as necessary to ensure the
+// controller has the number of machines specified.
+func (api *HighAvailabilityAPI) EnableHA(args params.ControllersSpecs) (params.ControllersChangeResults, error) {
+	results := params.ControllersChangeResults{}
+	if err := api.checkIsSuperuser(); err != nil {
+		return results, err
 	}
 
 	if len(args.Specs) == 0 {
@@ -164,13 +203,208 @@ func enableHASingle(st *state.State, spec params.ControllersSpec) (params.Contro
 		}
 	}
 
-	changes, err := st.EnableHA(spec.NumControllers, spec.Constraints, series, spec.Placement)
+	machines, err := controllerMachineParams(spec)
+	if err != nil {
+		return params.ControllersChanges{}, errors.Trace(err)
+	}
+	changes, err := st.EnableHAWithMachines(spec.NumControllers, spec.Constraints, series, machines)
 	if err != nil {
 		return params.ControllersChanges{}, err
 	}
 	return controllersChanges(changes), nil
 }
 
+// controllerMachineParams returns the placement and constraints of the
+// new controller machines described by the spec. Availability zones
+// are passed to the provider as zone placement directives.
+func controllerMachineParams(spec params.ControllersSpec) ([]state.ControllerMachineParams, error) {
+	if len(spec.Machines) == 0 {
+		machines := make([]state.ControllerMachineParams, len(spec.Placement))
+		for i, placement := range spec.Placement {
+			machines[i].Placement = placement
+		}
+		return machines, nil
+	}
+	if len(spec.Placement) > 0 {
+		return nil, errors.NotValidf("both placement and per-machine specs")
+	}
+	machines := make([]state.ControllerMachineParams, len(spec.Machines))
+	for i, m := range spec.Machines {
+		if m.Zone != "" {
+			if m.Placement != "" {
+				return nil, errors.NotValidf("both placement %q and zone %q for machine %d", m.Placement, m.Zone, i)
+			}
+			m.Placement = "zone=" + m.Zone
+		}
+		machines[i] = state.ControllerMachineParams{
+			Placement:   m.Placement,
+			Constraints: m.Constraints,
+		}
+	}
+	return machines, nil
+}
+
+// Controller machine phases reported by ControllersProgress.
+const (
+	phaseProvisioning = "provisioning"
+	phaseStarting     = "starting"
+	phaseJoining      = "joining"
+	phaseStandby      = "standby"
+	phaseVoting       = "voting"
+	phaseDemoting     = "demoting"
+)
+
+// currentReplicaSet returns the members of the controller's mongo
+// replica set, and their status. It is a variable so that tests can
+// replace it.
+var currentReplicaSet = func(st *state.State) ([]replicaset.Member, *replicaset.Status, error) {
+	session := st.MongoSession()
+	members, err := replicaset.CurrentMembers(session)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	status, err := replicaset.CurrentStatus(session)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return members, status, nil
+}
+
+// ControllersProgress reports how far each controller machine has got
+// in being provisioned, joining the mongo replica set and gaining its
+// vote, so that clients can follow enable-ha to completion.
+//
+//juju:readonly
+func (api *HighAvailabilityAPI) ControllersProgress() (params.ControllersProgress, error) {
+	if err := api.checkIsSuperuser(); err != nil {
+		return params.ControllersProgress{}, err
+	}
+	return controllersProgress(api.state)
+}
+
+func controllersProgress(st *state.State) (params.ControllersProgress, error) {
+	var result params.ControllersProgress
+	info, err := st.ControllerInfo()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
+	// The peergrouper tags each member of the replica set with the
+	// id of its machine.
+	mongoMembers := make(map[string]replicaset.MemberStatus)
+	members, status, err := currentReplicaSet(st)
+	if err != nil {
+		result.MongoError = err.Error()
+	} else {
+		statuses := make(map[int]replicaset.MemberStatus)
+		for _, s := range status.Members {
+			statuses[s.Id] = s
+		}
+		for _, m := range members {
+			if id, ok := m.Tags["juju-machine-id"]; ok {
+				if s, ok := statuses[m.Id]; ok {
+					mongoMembers[id] = s
+				}
+			}
+		}
+	}
+
+	ids := make([]string, len(info.MachineIds))
+	copy(ids, info.MachineIds)
+	for _, id := range utils.SortStringsNaturally(ids) {
+		m, err := st.Machine(id)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return params.ControllersProgress{}, errors.Trace(err)
+		}
+		progress, err := machineProgress(m)
+		if err != nil {
+			return params.ControllersProgress{}, errors.Annotatef(err, "machine %s", id)
+		}
+		if member, ok := mongoMembers[id]; ok {
+			progress.MongoState = member.State.String()
+			progress.MongoHealthy = member.Healthy
+		}
+		progress.Phase = machinePhase(progress, result.MongoError == "")
+		result.Machines = append(result.Machines, progress)
+	}
+	return result, nil
+}
+
+func machineProgress(m *state.Machine) (params.ControllerMachineProgress, error) {
+	result := params.ControllerMachineProgress{
+		Tag:       m.Tag().String(),
+		WantsVote: m.WantsVote(),
+		HasVote:   m.HasVote(),
+	}
+	instId, err := m.InstanceId()
+	if err == nil {
+		result.InstanceId = string(instId)
+	} else if !errors.IsNotProvisioned(err) {
+		return result, errors.Trace(err)
+	}
+	instStatus, err := m.InstanceStatus()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.InstanceStatus = string(instStatus.Status)
+	agentStatus, err := m.Status()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.AgentStatus = string(agentStatus.Status)
+	result.AgentAlive, err = m.AgentPresence()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// machinePhase summarises the progress of a controller machine. If
+// the status of the replica set is not known, machines whose agents
+// are alive are assumed to have joined it.
+func machinePhase(p params.ControllerMachineProgress, mongoKnown bool) string {
+	switch {
+	case p.InstanceId == "":
+		return phaseProvisioning
+	case !p.AgentAlive:
+		return phaseStarting
+	case mongoKnown && !p.MongoHealthy:
+		return phaseJoining
+	case p.HasVote && !p.WantsVote:
+		return phaseDemoting
+	case p.HasVote:
+		return phaseVoting
+	case p.WantsVote:
+		// The machine has joined the replica set, but the
+		// peergrouper has not yet given it a vote.
+		return phaseJoining
+	}
+	return phaseStandby
+}
+
+// WatchControllersProgress returns a watcher that notifies whenever
+// the progress reported by ControllersProgress changes.
+//
+//juju:readonly
+func (api *HighAvailabilityAPI) WatchControllersProgress() (params.NotifyWatchResult, error) {
+	if err := api.checkIsSuperuser(); err != nil {
+		return params.NotifyWatchResult{}, err
+	}
+	st := api.state
+	w := newProgressWatcher(st.WatchControllerInfo(), func() (params.ControllersProgress, error) {
+		return controllersProgress(st)
+	})
+	// Consume the initial event.
+	if _, ok := <-w.Changes(); !ok {
+		return params.NotifyWatchResult{}, common.ServerError(watcher.EnsureErr(w))
+	}
+	return params.NotifyWatchResult{
+		NotifyWatcherId: api.resources.Register(w),
+	}, nil
+}
+
 // StopHAReplicationForUpgrade will prompt the HA cluster to enter upgrade
 // mongo mode.
 func (api *HighAvailabilityAPI) StopHAReplicationForUpgrade(args params.UpgradeMongoParams) (params.MongoUpgradeResults, error) {
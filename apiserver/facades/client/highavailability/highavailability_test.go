@@ -7,8 +7,10 @@ import (
 	stdtesting "testing"
 
 	"github.com/juju/errors"
+	"github.com/juju/replicaset"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 	worker "gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/apiserver/common"
@@ -20,6 +22,7 @@ import (
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/presence"
+	statetesting "github.com/juju/juju/state/testing"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
 )
@@ -396,3 +399,174 @@ func (s *clientSuite) TestEnableHANoSpecs(c *gc.C) {
 	c.Check(err, jc.ErrorIsNil)
 	c.Check(results.Results, gc.HasLen, 0)
 }
+
+func (s *clientSuite) enableHAWithMachines(c *gc.C, machines []params.ControllerMachineSpec) (params.ControllersChanges, error) {
+	results, err := s.haServer.EnableHA(params.ControllersSpecs{
+		Specs: []params.ControllersSpec{{
+			NumControllers: 3,
+			Machines:       machines,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	result := results.Results[0]
+	err = nil
+	if result.Error != nil {
+		err = result.Error
+	}
+	return result.Result, err
+}
+
+func (s *clientSuite) TestEnableHAMachines(c *gc.C) {
+	enableHAResult, err := s.enableHAWithMachines(c, []params.ControllerMachineSpec{{
+		Zone:        "zone1",
+		Constraints: constraints.MustParse("mem=32G"),
+	}, {
+		Placement: "valid",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(enableHAResult.Added, gc.DeepEquals, []string{"machine-1", "machine-2"})
+
+	machines, err := s.State.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 3)
+	expectedCons := []constraints.Value{
+		controllerCons,
+		constraints.MustParse("mem=32G"),
+		{},
+	}
+	expectedPlacement := []string{"", "zone=zone1", "valid"}
+	for i, m := range machines {
+		cons, err := m.Constraints()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(cons, gc.DeepEquals, expectedCons[i])
+		c.Check(m.Placement(), gc.Equals, expectedPlacement[i])
+	}
+}
+
+func (s *clientSuite) TestEnableHAMachinesPlacementAndZone(c *gc.C) {
+	_, err := s.enableHAWithMachines(c, []params.ControllerMachineSpec{{
+		Placement: "valid",
+		Zone:      "zone1",
+	}})
+	c.Assert(err, gc.ErrorMatches, `both placement "valid" and zone "zone1" for machine 0 not valid`)
+}
+
+func (s *clientSuite) TestEnableHAMachinesAndPlacement(c *gc.C) {
+	results, err := s.haServer.EnableHA(params.ControllersSpecs{
+		Specs: []params.ControllersSpec{{
+			NumControllers: 3,
+			Placement:      []string{"valid"},
+			Machines:       []params.ControllerMachineSpec{{Zone: "zone1"}},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "both placement and per-machine specs not valid")
+}
+
+func (s *clientSuite) TestControllersProgress(c *gc.C) {
+	members := []replicaset.Member{{
+		Id:   1,
+		Tags: map[string]string{"juju-machine-id": "0"},
+	}, {
+		Id:   2,
+		Tags: map[string]string{"juju-machine-id": "1"},
+	}}
+	status := &replicaset.Status{
+		Members: []replicaset.MemberStatus{{
+			Id:      1,
+			Healthy: true,
+			State:   replicaset.PrimaryState,
+		}, {
+			Id:    2,
+			State: replicaset.StartupState,
+		}},
+	}
+	s.PatchValue(highavailability.CurrentReplicaSet, func(*state.State) ([]replicaset.Member, *replicaset.Status, error) {
+		return members, status, nil
+	})
+	_, err := s.enableHA(c, 3, emptyCons, defaultSeries, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	m0, err := s.State.Machine("0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = m0.SetProvisioned("i-0", "fake-nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = m0.SetHasVote(true)
+	c.Assert(err, jc.ErrorIsNil)
+	m1, err := s.State.Machine("1")
+	c.Assert(err, jc.ErrorIsNil)
+	err = m1.SetProvisioned("i-1", "fake-nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.setAgentPresence(c, "1")
+
+	progress, err := s.haServer.ControllersProgress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(progress.MongoError, gc.Equals, "")
+	c.Assert(progress.Machines, gc.HasLen, 3)
+
+	c.Check(progress.Machines[0].Tag, gc.Equals, "machine-0")
+	c.Check(progress.Machines[0].InstanceId, gc.Equals, "i-0")
+	c.Check(progress.Machines[0].AgentAlive, jc.IsTrue)
+	c.Check(progress.Machines[0].MongoState, gc.Equals, "PRIMARY")
+	c.Check(progress.Machines[0].MongoHealthy, jc.IsTrue)
+	c.Check(progress.Machines[0].Phase, gc.Equals, "voting")
+
+	c.Check(progress.Machines[1].Tag, gc.Equals, "machine-1")
+	c.Check(progress.Machines[1].MongoState, gc.Equals, "STARTUP")
+	c.Check(progress.Machines[1].MongoHealthy, jc.IsFalse)
+	c.Check(progress.Machines[1].Phase, gc.Equals, "joining")
+
+	c.Check(progress.Machines[2].Tag, gc.Equals, "machine-2")
+	c.Check(progress.Machines[2].InstanceId, gc.Equals, "")
+	c.Check(progress.Machines[2].Phase, gc.Equals, "provisioning")
+}
+
+func (s *clientSuite) TestControllersProgressMongoError(c *gc.C) {
+	s.PatchValue(highavailability.CurrentReplicaSet, func(*state.State) ([]replicaset.Member, *replicaset.Status, error) {
+		return nil, nil, errors.New("not running with --replSet")
+	})
+	m0, err := s.State.Machine("0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = m0.SetProvisioned("i-0", "fake-nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	progress, err := s.haServer.ControllersProgress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(progress.MongoError, gc.Equals, "not running with --replSet")
+	c.Assert(progress.Machines, gc.HasLen, 1)
+	// Without the replica set status, a live agent is assumed to have
+	// joined it.
+	c.Check(progress.Machines[0].Phase, gc.Equals, "joining")
+}
+
+func (s *clientSuite) TestWatchControllersProgress(c *gc.C) {
+	s.PatchValue(&highavailability.ProgressPollDelay, coretesting.ShortWait)
+	result, err := s.haServer.WatchControllersProgress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+
+	w := s.resources.Get(result.NotifyWatcherId).(state.NotifyWatcher)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertNoChange()
+
+	m0, err := s.State.Machine("0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = m0.SetProvisioned("i-0", "fake-nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *clientSuite) TestControllersProgressNotSuperuser(c *gc.C) {
+	authoriser := apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("bob"),
+	}
+	haServer, err := highavailability.NewHighAvailabilityAPI(s.State, s.resources, authoriser)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = haServer.ControllersProgress()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	_, err = haServer.WatchControllersProgress()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
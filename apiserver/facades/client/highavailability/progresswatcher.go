@@ -0,0 +1,119 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package highavailability
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// ProgressPollDelay is how often the progress of controller machines
+// is checked for changes. Agent presence and the state of the mongo
+// replica set are not recorded in documents that can be watched, so
+// they must be polled.
+var ProgressPollDelay = 5 * time.Second
+
+// progressWatcher is a notify watcher that fires whenever the progress
+// of the controller machines changes.
+type progressWatcher struct {
+	tomb     tomb.Tomb
+	source   state.NotifyWatcher
+	progress func() (params.ControllersProgress, error)
+	out      chan struct{}
+}
+
+// newProgressWatcher returns a watcher that checks the progress
+// returned by the given function whenever the source watcher fires,
+// and every ProgressPollDelay, and notifies when it has changed. The
+// watcher stops the source watcher when it is stopped.
+func newProgressWatcher(
+	source state.NotifyWatcher,
+	progress func() (params.ControllersProgress, error),
+) state.NotifyWatcher {
+	w := &progressWatcher{
+		source:   source,
+		progress: progress,
+		out:      make(chan struct{}),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.out)
+		defer watcher.Stop(source, &w.tomb)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// Stop stops the watcher, and returns any error encountered while running
+// or shutting down.
+func (w *progressWatcher) Stop() error {
+	w.Kill()
+	return w.Wait()
+}
+
+// Kill kills the watcher without waiting for it to shut down.
+func (w *progressWatcher) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait waits for the watcher to die and returns any
+// error encountered when it was running.
+func (w *progressWatcher) Wait() error {
+	return w.tomb.Wait()
+}
+
+// Err returns any error encountered while running or shutting down, or
+// tomb.ErrStillAlive if the watcher is still running.
+func (w *progressWatcher) Err() error {
+	return w.tomb.Err()
+}
+
+// Changes returns the event channel for the progressWatcher.
+func (w *progressWatcher) Changes() <-chan struct{} {
+	return w.out
+}
+
+func (w *progressWatcher) loop() error {
+	var (
+		out  chan struct{}
+		last *params.ControllersProgress
+	)
+	check := func() error {
+		progress, err := w.progress()
+		if err != nil {
+			return errors.Annotate(err, "cannot get controller progress")
+		}
+		if last == nil || !reflect.DeepEqual(*last, progress) {
+			last = &progress
+			out = w.out
+		}
+		return nil
+	}
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case _, ok := <-w.source.Changes():
+			if !ok {
+				return watcher.EnsureErr(w.source)
+			}
+			if err := check(); err != nil {
+				return errors.Trace(err)
+			}
+		case <-time.After(ProgressPollDelay):
+			if err := check(); err != nil {
+				return errors.Trace(err)
+			}
+		case out <- struct{}{}:
+			out = nil
+		}
+	}
+}
@@ -53,6 +53,8 @@ func NewImageManagerAPI(st *state.State, resources facade.Resources, authorizer
 }
 
 // ListImages returns images matching the specified filter.
+//
+//juju:readonly
 func (api *ImageManagerAPI) ListImages(arg params.ImageFilterParams) (params.ListImageResult, error) {
 	var result params.ListImageResult
 	admin, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.state.ControllerTag())
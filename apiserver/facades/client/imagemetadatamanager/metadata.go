@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/simplestreams"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/cloudimagemetadata"
@@ -22,6 +23,9 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.imagemetadatamanager")
 
+// customSource is the source of image metadata added by users.
+const customSource = "custom"
+
 // API is the concrete implementation of the api end point
 // for loud image metadata manipulations.
 type API struct {
@@ -68,6 +72,8 @@ func NewAPI(
 // List returns all found cloud image metadata that satisfy
 // given filter.
 // Returned list contains metadata ordered by priority.
+//
+//juju:readonly
 func (api *API) List(filter params.ImageMetadataFilter) (params.ListCloudImageMetadataResult, error) {
 	found, err := api.metadata.FindMetadata(cloudimagemetadata.MetadataFilter{
 		Region:          filter.Region,
@@ -98,14 +104,91 @@ func (api *API) List(filter params.ImageMetadataFilter) (params.ListCloudImageMe
 
 // Save stores given cloud image metadata.
 // It supports bulk calls.
+// Metadata without a region is recorded for the model's region, and
+// metadata without a source is recorded as custom metadata, which is
+// preferred over images found in simplestreams.
 func (api *API) Save(metadata params.MetadataSaveParams) (params.ErrorResults, error) {
-	all, err := imagecommon.Save(api.metadata, metadata)
+	all := make([]params.ErrorResult, len(metadata.Metadata))
+	var (
+		valid   params.MetadataSaveParams
+		indices []int
+		region  string
+	)
+	modelRegion := func() (string, error) {
+		// Only look up the region if it's needed, and then only once.
+		if region == "" {
+			r, err := api.modelRegion()
+			if err != nil {
+				return "", errors.Trace(err)
+			}
+			region = r
+		}
+		return region, nil
+	}
+	for i, one := range metadata.Metadata {
+		prepared, err := prepareMetadataList(one, modelRegion)
+		if err != nil {
+			all[i].Error = common.ServerError(err)
+			continue
+		}
+		valid.Metadata = append(valid.Metadata, prepared)
+		indices = append(indices, i)
+	}
+	saved, err := imagecommon.Save(api.metadata, valid)
 	if err != nil {
 		return params.ErrorResults{}, errors.Trace(err)
 	}
+	for i, result := range saved {
+		all[indices[i]] = result
+	}
 	return params.ErrorResults{Results: all}, nil
 }
 
+// modelRegion returns the region of the model's cloud.
+func (api *API) modelRegion() (string, error) {
+	env, err := api.newEnviron()
+	if err != nil {
+		return "", errors.Annotate(err, "getting model environ")
+	}
+	hasRegion, ok := env.(simplestreams.HasRegion)
+	if !ok {
+		return "", errors.NotSupportedf("model without regions")
+	}
+	spec, err := hasRegion.Region()
+	if err != nil {
+		return "", errors.Annotate(err, "getting model region")
+	}
+	return spec.Region, nil
+}
+
+// prepareMetadataList validates the given metadata, filling in the
+// values that may be omitted when custom metadata is saved.
+func prepareMetadataList(list params.CloudImageMetadataList, modelRegion func() (string, error)) (params.CloudImageMetadataList, error) {
+	result := params.CloudImageMetadataList{
+		Metadata: make([]params.CloudImageMetadata, len(list.Metadata)),
+	}
+	for i, m := range list.Metadata {
+		if m.ImageId == "" {
+			return params.CloudImageMetadataList{}, errors.NotValidf("metadata without image id")
+		}
+		if m.Region == "" {
+			region, err := modelRegion()
+			if err != nil {
+				return params.CloudImageMetadataList{}, errors.Annotatef(err, "metadata for image %v has no region", m.ImageId)
+			}
+			m.Region = region
+		}
+		if m.Source == "" {
+			m.Source = customSource
+		}
+		if m.Source == customSource && m.Priority == 0 {
+			m.Priority = simplestreams.CUSTOM_CLOUD_DATA
+		}
+		result.Metadata[i] = m
+	}
+	return result, nil
+}
+
 // Delete deletes cloud image metadata for given image ids.
 // It supports bulk calls.
 func (api *API) Delete(images params.MetadataImageIds) (params.ErrorResults, error) {
@@ -9,6 +9,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/simplestreams"
 	"github.com/juju/juju/state/cloudimagemetadata"
 )
 
@@ -104,7 +105,9 @@ func (s *metadataSuite) TestSaveEmpty(c *gc.C) {
 
 func (s *metadataSuite) TestSave(c *gc.C) {
 	m := params.CloudImageMetadata{
-		Source: "custom",
+		ImageId: "image-id",
+		Region:  "region",
+		Source:  "custom",
 	}
 	msg := "save error"
 
@@ -138,6 +141,72 @@ func (s *metadataSuite) TestSave(c *gc.C) {
 	s.assertCalls(c, controllerTag, modelConfig, saveMetadata, saveMetadata)
 }
 
+func (s *metadataSuite) TestSaveDefaultsCustomMetadata(c *gc.C) {
+	var saved []cloudimagemetadata.Metadata
+	s.state.saveMetadata = func(m []cloudimagemetadata.Metadata) error {
+		saved = m
+		return nil
+	}
+
+	errs, err := s.api.Save(params.MetadataSaveParams{
+		Metadata: []params.CloudImageMetadataList{{
+			Metadata: []params.CloudImageMetadata{{
+				ImageId: "image-id",
+				Series:  "xenial",
+				Arch:    "amd64",
+			}},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs.Results, gc.HasLen, 1)
+	c.Assert(errs.Results[0].Error, gc.IsNil)
+	c.Assert(saved, gc.HasLen, 1)
+	c.Assert(saved[0].ImageId, gc.Equals, "image-id")
+	c.Assert(saved[0].Region, gc.Equals, "dummy_region")
+	c.Assert(saved[0].Source, gc.Equals, "custom")
+	c.Assert(saved[0].Priority, gc.Equals, simplestreams.CUSTOM_CLOUD_DATA)
+	s.assertCalls(c, controllerTag, modelConfig, saveMetadata)
+}
+
+func (s *metadataSuite) TestSaveKeepsPriority(c *gc.C) {
+	var saved []cloudimagemetadata.Metadata
+	s.state.saveMetadata = func(m []cloudimagemetadata.Metadata) error {
+		saved = m
+		return nil
+	}
+
+	errs, err := s.api.Save(params.MetadataSaveParams{
+		Metadata: []params.CloudImageMetadataList{{
+			Metadata: []params.CloudImageMetadata{{
+				ImageId:  "image-id",
+				Region:   "region",
+				Source:   "custom",
+				Priority: 80,
+			}},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs.Results[0].Error, gc.IsNil)
+	c.Assert(saved, gc.HasLen, 1)
+	c.Assert(saved[0].Region, gc.Equals, "region")
+	c.Assert(saved[0].Priority, gc.Equals, 80)
+}
+
+func (s *metadataSuite) TestSaveMissingImageId(c *gc.C) {
+	errs, err := s.api.Save(params.MetadataSaveParams{
+		Metadata: []params.CloudImageMetadataList{{
+			Metadata: []params.CloudImageMetadata{{Region: "region"}},
+		}, {
+			Metadata: []params.CloudImageMetadata{{ImageId: "image-id", Region: "region"}},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs.Results, gc.HasLen, 2)
+	c.Assert(errs.Results[0].Error, gc.ErrorMatches, "metadata without image id not valid")
+	c.Assert(errs.Results[1].Error, gc.IsNil)
+	s.assertCalls(c, controllerTag, modelConfig, saveMetadata)
+}
+
 func (s *metadataSuite) TestDeleteEmpty(c *gc.C) {
 	errs, err := s.api.Delete(params.MetadataImageIds{})
 	c.Assert(err, jc.ErrorIsNil)
@@ -113,6 +113,8 @@ func (api *KeyManagerAPI) checkCanWrite(sshUser string) error {
 }
 
 // ListKeys returns the authorised ssh keys for the specified users.
+//
+//juju:readonly
 func (api *KeyManagerAPI) ListKeys(arg params.ListSSHKeys) (params.StringsResults, error) {
 	if len(arg.Entities.Entities) == 0 {
 		return params.StringsResults{}, nil
@@ -0,0 +1,154 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package loadtest implements the LoadTest facade, which populates a
+// model with synthetic applications, units and machines, and changes
+// them to generate the watcher traffic of a busy model, so that the
+// controller's scale and performance can be tested repeatably. The
+// facade is only registered when the load-testing feature flag is set.
+package loadtest
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+const (
+	// MaxUnits is the maximum number of units that may be added by
+	// a single call to Populate.
+	MaxUnits = 10000
+
+	// MaxApplications is the maximum number of applications that
+	// may be added by a single call to Populate.
+	MaxApplications = 1000
+
+	// MaxRounds is the maximum number of rounds of changes that may
+	// be made by a single call to Churn.
+	MaxRounds = 1000
+)
+
+// Backend defines the state functionality required by the LoadTest
+// facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+
+	// DefaultSeries returns the model's default series.
+	DefaultSeries() (string, error)
+
+	PopulateSynthetic(state.SyntheticPopulation) ([]string, error)
+	ChurnSynthetic(rounds int, seed int64) (int, error)
+	RemoveSynthetic() (state.SyntheticSummary, error)
+}
+
+// BlockChecker checks for blocks on model changes.
+type BlockChecker interface {
+	ChangeAllowed() error
+	RemoveAllowed() error
+}
+
+// API implements the LoadTest facade.
+type API struct {
+	backend    Backend
+	check      BlockChecker
+	authorizer facade.Authorizer
+}
+
+// NewAPI returns a new LoadTest facade. Only model administrators may
+// use it.
+func NewAPI(backend Backend, check BlockChecker, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	ok, err := authorizer.HasPermission(permission.AdminAccess, backend.ModelTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !ok {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		check:      check,
+		authorizer: authorizer,
+	}, nil
+}
+
+// Populate adds synthetic applications, each with units on new
+// synthetic machines, to the model.
+func (api *API) Populate(args params.PopulateModelArgs) (params.PopulateModelResult, error) {
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.PopulateModelResult{}, errors.Trace(err)
+	}
+	if err := validatePopulation(args); err != nil {
+		return params.PopulateModelResult{Error: common.ServerError(err)}, nil
+	}
+	series := args.Series
+	if series == "" {
+		var err error
+		if series, err = api.backend.DefaultSeries(); err != nil {
+			return params.PopulateModelResult{Error: common.ServerError(err)}, nil
+		}
+	}
+	added, err := api.backend.PopulateSynthetic(state.SyntheticPopulation{
+		Applications:        args.Applications,
+		UnitsPerApplication: args.UnitsPerApplication,
+		Series:              series,
+		Relate:              args.Relate,
+	})
+	return params.PopulateModelResult{
+		Applications: added,
+		Error:        common.ServerError(err),
+	}, nil
+}
+
+func validatePopulation(args params.PopulateModelArgs) error {
+	if args.Applications < 0 || args.UnitsPerApplication < 0 {
+		return errors.NotValidf("negative population")
+	}
+	if args.Applications > MaxApplications {
+		return errors.NotValidf("more than %d applications", MaxApplications)
+	}
+	if args.Applications*args.UnitsPerApplication > MaxUnits {
+		return errors.NotValidf("more than %d units", MaxUnits)
+	}
+	return nil
+}
+
+// Churn changes the model's synthetic entities for the requested
+// number of rounds.
+func (api *API) Churn(args params.ChurnModelArgs) (params.ChurnModelResult, error) {
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ChurnModelResult{}, errors.Trace(err)
+	}
+	if args.Rounds < 0 || args.Rounds > MaxRounds {
+		err := errors.NotValidf("rounds %d outside 0-%d", args.Rounds, MaxRounds)
+		return params.ChurnModelResult{Error: common.ServerError(err)}, nil
+	}
+	changes, err := api.backend.ChurnSynthetic(args.Rounds, args.Seed)
+	return params.ChurnModelResult{
+		Changes: changes,
+		Error:   common.ServerError(err),
+	}, nil
+}
+
+// Clear removes the model's synthetic applications, with their units,
+// relations and machines.
+func (api *API) Clear() (params.ClearModelResult, error) {
+	if err := api.check.RemoveAllowed(); err != nil {
+		return params.ClearModelResult{}, errors.Trace(err)
+	}
+	summary, err := api.backend.RemoveSynthetic()
+	return params.ClearModelResult{
+		Applications: summary.Applications,
+		Units:        summary.Units,
+		Machines:     summary.Machines,
+		Relations:    summary.Relations,
+		Error:        common.ServerError(err),
+	}, nil
+}
@@ -0,0 +1,206 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package loadtest_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/loadtest"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type loadTestSuite struct {
+	testing.IsolationSuite
+	backend *mockBackend
+	blocks  mockBlockChecker
+}
+
+var _ = gc.Suite(&loadTestSuite{})
+
+func (s *loadTestSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		added:   []string{"synthetic-0", "synthetic-1"},
+		changes: 12,
+		summary: state.SyntheticSummary{
+			Applications: 2,
+			Units:        6,
+			Machines:     6,
+			Relations:    1,
+		},
+	}
+	s.blocks = mockBlockChecker{}
+}
+
+func (s *loadTestSuite) newAPI(c *gc.C) *loadtest.API {
+	api, err := loadtest.NewAPI(s.backend, &s.blocks, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *loadTestSuite) TestNewAPIRequiresModelAdmin(c *gc.C) {
+	_, err := loadtest.NewAPI(s.backend, &s.blocks, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("write"),
+	})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *loadTestSuite) TestNewAPIRequiresClient(c *gc.C) {
+	_, err := loadtest.NewAPI(s.backend, &s.blocks, apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *loadTestSuite) TestPopulate(c *gc.C) {
+	result, err := s.newAPI(c).Populate(params.PopulateModelArgs{
+		Applications:        2,
+		UnitsPerApplication: 3,
+		Series:              "bionic",
+		Relate:              true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.PopulateModelResult{
+		Applications: []string{"synthetic-0", "synthetic-1"},
+	})
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"ModelTag", nil},
+		{"PopulateSynthetic", []interface{}{state.SyntheticPopulation{
+			Applications:        2,
+			UnitsPerApplication: 3,
+			Series:              "bionic",
+			Relate:              true,
+		}}},
+	})
+}
+
+func (s *loadTestSuite) TestPopulateDefaultSeries(c *gc.C) {
+	_, err := s.newAPI(c).Populate(params.PopulateModelArgs{
+		Applications:        1,
+		UnitsPerApplication: 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCall(c, 2, "PopulateSynthetic", state.SyntheticPopulation{
+		Applications:        1,
+		UnitsPerApplication: 1,
+		Series:              "xenial",
+	})
+}
+
+func (s *loadTestSuite) TestPopulateTooMany(c *gc.C) {
+	api := s.newAPI(c)
+	result, err := api.Populate(params.PopulateModelArgs{
+		Applications:        100,
+		UnitsPerApplication: 101,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "more than 10000 units not valid")
+
+	result, err = api.Populate(params.PopulateModelArgs{Applications: 1001})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "more than 1000 applications not valid")
+	s.backend.CheckCallNames(c, "ModelTag")
+}
+
+func (s *loadTestSuite) TestPopulatePartialFailure(c *gc.C) {
+	s.backend.added = []string{"synthetic-0"}
+	s.backend.SetErrors(errors.New("boom"))
+	result, err := s.newAPI(c).Populate(params.PopulateModelArgs{
+		Applications: 2,
+		Series:       "xenial",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Applications, jc.DeepEquals, []string{"synthetic-0"})
+	c.Assert(result.Error, gc.ErrorMatches, "boom")
+}
+
+func (s *loadTestSuite) TestPopulateBlocked(c *gc.C) {
+	s.blocks.SetErrors(errors.New("blocked"))
+	_, err := s.newAPI(c).Populate(params.PopulateModelArgs{Applications: 1})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.backend.CheckCallNames(c, "ModelTag")
+}
+
+func (s *loadTestSuite) TestChurn(c *gc.C) {
+	result, err := s.newAPI(c).Churn(params.ChurnModelArgs{Rounds: 2, Seed: 99})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ChurnModelResult{Changes: 12})
+	s.backend.CheckCall(c, 1, "ChurnSynthetic", 2, int64(99))
+}
+
+func (s *loadTestSuite) TestChurnTooManyRounds(c *gc.C) {
+	result, err := s.newAPI(c).Churn(params.ChurnModelArgs{Rounds: 1001})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "rounds 1001 outside 0-1000 not valid")
+	s.backend.CheckCallNames(c, "ModelTag")
+}
+
+func (s *loadTestSuite) TestClear(c *gc.C) {
+	result, err := s.newAPI(c).Clear()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ClearModelResult{
+		Applications: 2,
+		Units:        6,
+		Machines:     6,
+		Relations:    1,
+	})
+	s.backend.CheckCallNames(c, "ModelTag", "RemoveSynthetic")
+	s.blocks.CheckCallNames(c, "RemoveAllowed")
+}
+
+type mockBackend struct {
+	testing.Stub
+	added   []string
+	changes int
+	summary state.SyntheticSummary
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	b.MethodCall(b, "ModelTag")
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) DefaultSeries() (string, error) {
+	b.MethodCall(b, "DefaultSeries")
+	return "xenial", b.NextErr()
+}
+
+func (b *mockBackend) PopulateSynthetic(population state.SyntheticPopulation) ([]string, error) {
+	b.MethodCall(b, "PopulateSynthetic", population)
+	return b.added, b.NextErr()
+}
+
+func (b *mockBackend) ChurnSynthetic(rounds int, seed int64) (int, error) {
+	b.MethodCall(b, "ChurnSynthetic", rounds, seed)
+	return b.changes, b.NextErr()
+}
+
+func (b *mockBackend) RemoveSynthetic() (state.SyntheticSummary, error) {
+	b.MethodCall(b, "RemoveSynthetic")
+	return b.summary, b.NextErr()
+}
+
+type mockBlockChecker struct {
+	testing.Stub
+}
+
+func (b *mockBlockChecker) ChangeAllowed() error {
+	b.MethodCall(b, "ChangeAllowed")
+	return b.NextErr()
+}
+
+func (b *mockBlockChecker) RemoveAllowed() error {
+	b.MethodCall(b, "RemoveAllowed")
+	return b.NextErr()
+}
@@ -0,0 +1,35 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package loadtest
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+)
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(&backend{st}, common.NewBlockChecker(st), auth)
+}
+
+type backend struct {
+	*state.State
+}
+
+// DefaultSeries is part of the Backend interface.
+func (b *backend) DefaultSeries() (string, error) {
+	model, err := b.Model()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	cfg, err := model.ModelConfig()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return config.PreferredSeries(cfg), nil
+}
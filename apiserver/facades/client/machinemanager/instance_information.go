@@ -15,6 +15,8 @@ import (
 
 // InstanceTypes returns instance type information for the cloud and region
 // in which the current model is deployed.
+//
+//juju:readonly
 func (mm *MachineManagerAPI) InstanceTypes(cons params.ModelInstanceTypesConstraints) (params.InstanceTypesResults, error) {
 	return instanceTypes(mm, environs.GetEnviron, cons)
 }
@@ -57,6 +57,32 @@ func NewFacadeV4(ctx facade.Context) (*MachineManagerAPIV4, error) {
 	return &MachineManagerAPIV4{machineManagerAPI}, nil
 }
 
+type MachineManagerAPIV5 struct {
+	*MachineManagerAPIV4
+}
+
+// NewFacadeV5 creates a new server-side MachineManager API facade.
+func NewFacadeV5(ctx facade.Context) (*MachineManagerAPIV5, error) {
+	machineManagerAPIV4, err := NewFacadeV4(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MachineManagerAPIV5{machineManagerAPIV4}, nil
+}
+
+type MachineManagerAPIV6 struct {
+	*MachineManagerAPIV5
+}
+
+// NewFacadeV6 creates a new server-side MachineManager API facade.
+func NewFacadeV6(ctx facade.Context) (*MachineManagerAPIV6, error) {
+	machineManagerAPIV5, err := NewFacadeV5(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MachineManagerAPIV6{machineManagerAPIV5}, nil
+}
+
 // NewMachineManagerAPI creates a new server-side MachineManager API facade.
 func NewMachineManagerAPI(backend Backend, pool Pool, auth facade.Authorizer) (*MachineManagerAPI, error) {
 	if !auth.AuthClient() {
@@ -70,6 +96,17 @@ func NewMachineManagerAPI(backend Backend, pool Pool, auth facade.Authorizer) (*
 	}, nil
 }
 
+func (mm *MachineManagerAPI) checkCanRead() error {
+	canRead, err := mm.authorizer.HasPermission(permission.ReadAccess, mm.st.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canRead {
+		return common.ErrPerm
+	}
+	return nil
+}
+
 func (mm *MachineManagerAPI) checkCanWrite() error {
 	canWrite, err := mm.authorizer.HasPermission(permission.WriteAccess, mm.st.ModelTag())
 	if err != nil {
@@ -173,6 +210,9 @@ func (mm *MachineManagerAPI) addOneMachine(p params.AddMachineParams) (*state.Ma
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := mm.st.CheckModelQuotas(common.MachineResources(p)); err != nil {
+		return nil, errors.Trace(err)
+	}
 	template := state.MachineTemplate{
 		Series:      p.Series,
 		Constraints: p.Constraints,
@@ -183,6 +223,7 @@ func (mm *MachineManagerAPI) addOneMachine(p params.AddMachineParams) (*state.Ma
 		HardwareCharacteristics: p.HardwareCharacteristics,
 		Addresses:               params.NetworkAddresses(p.Addrs...),
 		Placement:               placementDirective,
+		CloudInitUserData:       p.CloudInitUserData,
 	}
 	if p.ContainerType == "" {
 		return mm.st.AddOneMachine(template)
@@ -224,10 +265,38 @@ func (mm *MachineManagerAPI) destroyMachine(args params.Entities, force, keep bo
 		if err != nil {
 			return nil, err
 		}
+		if err := mm.check.RemoveAllowedFor(machineTag); err != nil {
+			return nil, err
+		}
 		machine, err := mm.st.Machine(machineTag.Id())
 		if err != nil {
 			return nil, err
 		}
+		units, err := machine.Units()
+		if err != nil {
+			return nil, err
+		}
+		// Destroying the machine destroys its units, so any
+		// blocks on their applications apply too.
+		maintenanceTags := []names.Tag{machineTag}
+		for _, unit := range units {
+			appName, err := names.UnitApplication(unit.UnitTag().Id())
+			if err != nil {
+				return nil, err
+			}
+			appTag := names.NewApplicationTag(appName)
+			if err := mm.check.RemoveAllowedFor(appTag); err != nil {
+				return nil, err
+			}
+			maintenanceTags = append(maintenanceTags, unit.UnitTag(), appTag)
+		}
+		// Machines, units and applications in maintenance mode
+		// are only removed when forced.
+		if !force {
+			if err := mm.st.CheckNotInMaintenance(maintenanceTags...); err != nil {
+				return nil, err
+			}
+		}
 		if keep {
 			logger.Infof("destroy machine %v but keep instance", machineTag.Id())
 			if err := machine.SetKeepInstance(keep); err != nil {
@@ -235,10 +304,6 @@ func (mm *MachineManagerAPI) destroyMachine(args params.Entities, force, keep bo
 			}
 		}
 		var info params.DestroyMachineInfo
-		units, err := machine.Units()
-		if err != nil {
-			return nil, err
-		}
 		storageSeen := make(set.Tags)
 		for _, unit := range units {
 			info.DestroyedUnits = append(
@@ -321,6 +386,9 @@ func (mm *MachineManagerAPIV4) updateOneMachineSeries(arg params.UpdateSeriesArg
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err := mm.check.ChangeAllowedFor(machineTag); err != nil {
+		return errors.Trace(err)
+	}
 	machine, err := mm.st.Machine(machineTag.Id())
 	if err != nil {
 		return errors.Trace(err)
@@ -330,3 +398,128 @@ func (mm *MachineManagerAPIV4) updateOneMachineSeries(arg params.UpdateSeriesArg
 	}
 	return machine.UpdateMachineSeries(arg.Series, arg.Force)
 }
+
+// CloudInitUserData returns, for each of the given machines, the
+// user-supplied cloud-init configuration that is merged into the
+// machine's when it is provisioned: the model's cloudinit-userdata
+// with the machine's own on top.
+func (mm *MachineManagerAPIV5) CloudInitUserData(args params.Entities) (params.StringResults, error) {
+	if err := mm.checkCanRead(); err != nil {
+		return params.StringResults{}, err
+	}
+	modelConfig, err := mm.st.ModelConfig()
+	if err != nil {
+		return params.StringResults{}, errors.Trace(err)
+	}
+	results := params.StringResults{
+		Results: make([]params.StringResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		userData, err := mm.machineCloudInitUserData(modelConfig, entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = userData
+	}
+	return results, nil
+}
+
+func (mm *MachineManagerAPIV5) machineCloudInitUserData(modelConfig *config.Config, tag string) (string, error) {
+	machineTag, err := names.ParseMachineTag(tag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	machine, err := mm.st.Machine(machineTag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return common.MachineCloudInitUserData(modelConfig, machine.CloudInitUserData())
+}
+
+// ContainerImageCache returns, for each of the given machines, the
+// images the machine agent has cached for creating containers, along
+// with any purge of the cache that the agent has yet to carry out.
+func (mm *MachineManagerAPIV6) ContainerImageCache(args params.Entities) (params.ContainerImageCacheResults, error) {
+	if err := mm.checkCanRead(); err != nil {
+		return params.ContainerImageCacheResults{}, err
+	}
+	results := params.ContainerImageCacheResults{
+		Results: make([]params.ContainerImageCacheResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		cache, err := mm.machineContainerImageCache(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = cache
+	}
+	return results, nil
+}
+
+func (mm *MachineManagerAPIV6) machineContainerImageCache(tag string) (*params.ContainerImageCache, error) {
+	machineTag, err := names.ParseMachineTag(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machine, err := mm.st.Machine(machineTag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cache, err := machine.ContainerImageCache()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := &params.ContainerImageCache{
+		Images:  make([]params.ContainerImage, len(cache.Images)),
+		Updated: cache.Updated,
+	}
+	for i, image := range cache.Images {
+		result.Images[i] = params.ContainerImage{
+			Series:      image.Series,
+			Arch:        image.Arch,
+			Fingerprint: image.Fingerprint,
+			Size:        image.Size,
+			LastUsed:    image.LastUsed,
+		}
+	}
+	if cache.Purge != nil {
+		result.Purge = &params.ContainerImagePurge{
+			Series:    cache.Purge.Series,
+			Requested: cache.Purge.Requested,
+		}
+	}
+	return result, nil
+}
+
+// PurgeContainerImages asks the agents of the given machines to remove
+// images from their container image caches: those of the given series,
+// or all of them if no series are given.
+func (mm *MachineManagerAPIV6) PurgeContainerImages(args params.PurgeContainerImagesArgs) (params.ErrorResults, error) {
+	if err := mm.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, err
+	}
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		results.Results[i].Error = common.ServerError(mm.purgeContainerImages(arg))
+	}
+	return results, nil
+}
+
+func (mm *MachineManagerAPIV6) purgeContainerImages(arg params.PurgeContainerImages) error {
+	machineTag, err := names.ParseMachineTag(arg.Tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	machine, err := mm.st.Machine(machineTag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return machine.RequestContainerImagePurge(arg.Series...)
+}
@@ -4,6 +4,8 @@
 package machinemanager_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jtesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -14,6 +16,8 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/storage"
@@ -120,6 +124,45 @@ func (s *MachineManagerSuite) TestAddMachinesStateError(c *gc.C) {
 	c.Assert(s.st.calls, gc.Equals, 1)
 }
 
+func (s *MachineManagerSuite) TestAddMachinesChecksQuotas(c *gc.C) {
+	cores := uint64(4)
+	results, err := s.api.AddMachines(params.AddMachines{
+		MachineParams: []params.AddMachineParams{{
+			Series:      "trusty",
+			Constraints: constraints.Value{CpuCores: &cores},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Machines, gc.HasLen, 1)
+	c.Assert(s.st.quotaRequests, jc.DeepEquals, []state.ModelResources{
+		{Machines: 1, Cores: 4},
+	})
+}
+
+func (s *MachineManagerSuite) TestAddMachinesQuotaExceeded(c *gc.C) {
+	s.st.quotaErr = &state.ErrQuotaExceeded{
+		Resource:  state.QuotaMachines,
+		Limit:     1,
+		Used:      1,
+		Requested: 1,
+	}
+	results, err := s.api.AddMachines(params.AddMachines{
+		MachineParams: []params.AddMachineParams{{
+			Series: "trusty",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.DeepEquals, params.AddMachinesResults{
+		Machines: []params.AddMachinesResult{{
+			Error: &params.Error{
+				Message: "model quota exceeded: 1 machines requested, 1 of 1 already in use",
+				Code:    params.CodeQuotaExceeded,
+			},
+		}},
+	})
+	c.Assert(s.st.calls, gc.Equals, 0)
+}
+
 func (s *MachineManagerSuite) TestDestroyMachine(c *gc.C) {
 	s.st.machines["0"] = &mockMachine{}
 	results, err := s.api.DestroyMachine(params.Entities{
@@ -145,6 +188,56 @@ func (s *MachineManagerSuite) TestDestroyMachine(c *gc.C) {
 	})
 }
 
+func (s *MachineManagerSuite) TestDestroyMachineBlocked(c *gc.C) {
+	s.st.machines["0"] = &mockMachine{}
+	s.st.blockMsg = "production database"
+	s.st.entityBlocks = map[names.Tag]state.BlockType{
+		names.NewMachineTag("0"): state.RemoveBlock,
+	}
+	results, err := s.api.DestroyMachine(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeOperationBlocked)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "production database")
+}
+
+func (s *MachineManagerSuite) TestDestroyMachineBlockedByApplication(c *gc.C) {
+	s.st.machines["0"] = &mockMachine{}
+	s.st.blockMsg = "production database"
+	s.st.entityBlocks = map[names.Tag]state.BlockType{
+		names.NewApplicationTag("foo"): state.ChangeBlock,
+	}
+	results, err := s.api.DestroyMachine(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeOperationBlocked)
+}
+
+func (s *MachineManagerSuite) TestDestroyMachineInMaintenance(c *gc.C) {
+	s.st.machines["0"] = &mockMachine{}
+	s.st.maintenance = map[names.Tag]string{
+		names.NewUnitTag("foo/1"): "replacing disks",
+	}
+	results, err := s.api.DestroyMachine(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeInMaintenance)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "unit foo/1 is in maintenance: replacing disks")
+
+	results, err = s.api.ForceDestroyMachine(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+}
+
 func (s *MachineManagerSuite) TestDestroyMachineWithParams(c *gc.C) {
 	apiV4 := machinemanager.MachineManagerAPIV4{s.api}
 	s.st.machines["0"] = &mockMachine{}
@@ -313,14 +406,153 @@ func (s *MachineManagerSuite) TestUpdateMachineSeriesPermissionDenied(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 }
 
+func (s *MachineManagerSuite) TestCloudInitUserData(c *gc.C) {
+	s.st.modelConfig = coretesting.CustomModelConfig(c, coretesting.Attrs{
+		"cloudinit-userdata": "packages: [htop]",
+	})
+	s.st.machines["0"] = &mockMachine{}
+	s.st.machines["1"] = &mockMachine{userData: "runcmd: [touch /tmp/done]"}
+	apiV5 := machinemanager.MachineManagerAPIV5{&machinemanager.MachineManagerAPIV4{s.api}}
+	results, err := apiV5.CloudInitUserData(params.Entities{
+		Entities: []params.Entity{
+			{Tag: names.NewMachineTag("0").String()},
+			{Tag: names.NewMachineTag("1").String()},
+			{Tag: names.NewMachineTag("2").String()},
+			{Tag: "application-foo"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{
+			{Result: "packages:\n- htop\n"},
+			{Result: "packages:\n- htop\nruncmd:\n- touch /tmp/done\n"},
+			{Error: &params.Error{Message: "machine 2 not found", Code: params.CodeNotFound}},
+			{Error: &params.Error{Message: `"application-foo" is not a valid machine tag`}},
+		},
+	})
+}
+
+func (s *MachineManagerSuite) TestCloudInitUserDataPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	apiV5 := machinemanager.MachineManagerAPIV5{&machinemanager.MachineManagerAPIV4{s.api}}
+	_, err := apiV5.CloudInitUserData(params.Entities{
+		Entities: []params.Entity{{Tag: names.NewMachineTag("0").String()}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *MachineManagerSuite) apiV6() *machinemanager.MachineManagerAPIV6 {
+	return &machinemanager.MachineManagerAPIV6{
+		&machinemanager.MachineManagerAPIV5{&machinemanager.MachineManagerAPIV4{s.api}},
+	}
+}
+
+func (s *MachineManagerSuite) TestContainerImageCache(c *gc.C) {
+	updated := time.Unix(0, 200).UTC()
+	requested := time.Unix(0, 100).UTC()
+	s.st.machines["0"] = &mockMachine{imageCache: state.ContainerImageCache{
+		Images: []state.ContainerImage{{
+			Series:      "xenial",
+			Arch:        "amd64",
+			Fingerprint: "deadbeef",
+			Size:        1024,
+			LastUsed:    updated,
+		}},
+		Updated: updated,
+		Purge:   &state.ContainerImagePurge{Series: []string{"trusty"}, Requested: requested},
+	}}
+	results, err := s.apiV6().ContainerImageCache(params.Entities{
+		Entities: []params.Entity{
+			{Tag: names.NewMachineTag("0").String()},
+			{Tag: names.NewMachineTag("1").String()},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ContainerImageCacheResults{
+		Results: []params.ContainerImageCacheResult{
+			{Result: &params.ContainerImageCache{
+				Images: []params.ContainerImage{{
+					Series:      "xenial",
+					Arch:        "amd64",
+					Fingerprint: "deadbeef",
+					Size:        1024,
+					LastUsed:    updated,
+				}},
+				Updated: updated,
+				Purge:   &params.ContainerImagePurge{Series: []string{"trusty"}, Requested: requested},
+			}},
+			{Error: &params.Error{Message: "machine 1 not found", Code: params.CodeNotFound}},
+		},
+	})
+}
+
+func (s *MachineManagerSuite) TestContainerImageCachePermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.apiV6().ContainerImageCache(params.Entities{
+		Entities: []params.Entity{{Tag: names.NewMachineTag("0").String()}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *MachineManagerSuite) TestPurgeContainerImages(c *gc.C) {
+	machine0 := &mockMachine{}
+	machine1 := &mockMachine{}
+	s.st.machines["0"] = machine0
+	s.st.machines["1"] = machine1
+	results, err := s.apiV6().PurgeContainerImages(params.PurgeContainerImagesArgs{
+		Args: []params.PurgeContainerImages{
+			{Tag: names.NewMachineTag("0").String()},
+			{Tag: names.NewMachineTag("1").String(), Series: []string{"trusty", "xenial"}},
+			{Tag: names.NewMachineTag("2").String()},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{},
+			{Error: &params.Error{Message: "machine 2 not found", Code: params.CodeNotFound}},
+		},
+	})
+	machine0.CheckCall(c, 0, "RequestContainerImagePurge", []string(nil))
+	machine1.CheckCall(c, 0, "RequestContainerImagePurge", []string{"trusty", "xenial"})
+}
+
+func (s *MachineManagerSuite) TestPurgeContainerImagesPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("read"))
+	_, err := s.apiV6().PurgeContainerImages(params.PurgeContainerImagesArgs{
+		Args: []params.PurgeContainerImages{{Tag: names.NewMachineTag("0").String()}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 type mockState struct {
 	machinemanager.Backend
 	calls            int
 	machineTemplates []state.MachineTemplate
 	machines         map[string]*mockMachine
+	modelConfig      *config.Config
 	err              error
 	blockMsg         string
 	block            state.BlockType
+	entityBlocks     map[names.Tag]state.BlockType
+	quotaRequests    []state.ModelResources
+	quotaErr         error
+	maintenance      map[names.Tag]string
+}
+
+func (st *mockState) CheckModelQuotas(requested state.ModelResources) error {
+	st.quotaRequests = append(st.quotaRequests, requested)
+	return st.quotaErr
+}
+
+func (st *mockState) CheckNotInMaintenance(tags ...names.Tag) error {
+	for _, tag := range tags {
+		if reason, ok := st.maintenance[tag]; ok {
+			return &state.ErrInMaintenance{Tag: tag, Reason: reason}
+		}
+	}
+	return nil
 }
 
 func (st *mockState) AddOneMachine(template state.MachineTemplate) (*state.Machine, error) {
@@ -338,10 +570,21 @@ func (st *mockState) GetBlockForType(t state.BlockType) (state.Block, bool, erro
 	}
 }
 
+func (st *mockState) GetEntityBlockForType(t state.BlockType, tag names.Tag) (state.Block, bool, error) {
+	if block, ok := st.entityBlocks[tag]; ok && block == t {
+		return &mockBlock{t: t, m: st.blockMsg}, true, nil
+	}
+	return nil, false, nil
+}
+
 func (st *mockState) ModelTag() names.ModelTag {
 	return names.NewModelTag("deadbeef-2f18-4fd2-967d-db9663db7bea")
 }
 
+func (st *mockState) ModelConfig() (*config.Config, error) {
+	return st.modelConfig, nil
+}
+
 func (st *mockState) Model() (machinemanager.Model, error) {
 	return &mockModel{}, nil
 }
@@ -415,8 +658,10 @@ type mockMachine struct {
 	jtesting.Stub
 	machinemanager.Machine
 
-	keep   bool
-	series string
+	keep       bool
+	series     string
+	userData   string
+	imageCache state.ContainerImageCache
 }
 
 func (m *mockMachine) Destroy() error {
@@ -445,6 +690,20 @@ func (m *mockMachine) Units() ([]machinemanager.Unit, error) {
 	}, nil
 }
 
+func (m *mockMachine) CloudInitUserData() string {
+	return m.userData
+}
+
+func (m *mockMachine) ContainerImageCache() (state.ContainerImageCache, error) {
+	m.MethodCall(m, "ContainerImageCache")
+	return m.imageCache, m.NextErr()
+}
+
+func (m *mockMachine) RequestContainerImagePurge(series ...string) error {
+	m.MethodCall(m, "RequestContainerImagePurge", series)
+	return m.NextErr()
+}
+
 func (m *mockMachine) UpdateMachineSeries(series string, force bool) error {
 	m.MethodCall(m, "UpdateMachineSeries", series, force)
 	return m.NextErr()
@@ -22,9 +22,12 @@ type Backend interface {
 	Model() (Model, error)
 	ModelTag() names.ModelTag
 	GetBlockForType(t state.BlockType) (state.Block, bool, error)
+	GetEntityBlockForType(t state.BlockType, tag names.Tag) (state.Block, bool, error)
 	AddOneMachine(template state.MachineTemplate) (*state.Machine, error)
 	AddMachineInsideNewMachine(template, parentTemplate state.MachineTemplate, containerType instance.ContainerType) (*state.Machine, error)
 	AddMachineInsideMachine(template state.MachineTemplate, parentId string, containerType instance.ContainerType) (*state.Machine, error)
+	CheckModelQuotas(requested state.ModelResources) error
+	CheckNotInMaintenance(tags ...names.Tag) error
 }
 
 type Pool interface {
@@ -47,6 +50,9 @@ type Machine interface {
 	Units() ([]Unit, error)
 	SetKeepInstance(keepInstance bool) error
 	UpdateMachineSeries(string, bool) error
+	CloudInitUserData() string
+	ContainerImageCache() (state.ContainerImageCache, error)
+	RequestContainerImagePurge(...string) error
 }
 
 type stateShim struct {
@@ -0,0 +1,352 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package maintenance implements the Maintenance facade, which puts
+// machines, applications and units into maintenance mode while
+// planned work is carried out on them. Alert-worthy statuses of
+// entities in maintenance mode are reported as maintenance, and the
+// entities cannot be removed without force.
+//
+// From version 2, the facade also defines the model's maintenance
+// windows, outside which disruptive automated operations do not run.
+//
+// From version 3, the facade also records operators' acknowledgements
+// that the agents of machines and units are intentionally offline,
+// such as during long hardware maintenance. Acknowledged agents are
+// reported as offline rather than down or lost, and do not degrade
+// the status of their applications, until the acknowledgement
+// expires.
+package maintenance
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	coremaintenance "github.com/juju/juju/core/maintenance"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the
+// Maintenance facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+	SetMaintenanceMode(state.MaintenanceMode) error
+	ClearMaintenanceMode(names.Tag) error
+	AllMaintenanceModes() ([]state.MaintenanceMode, error)
+	SetMaintenanceWindow(coremaintenance.Window) error
+	RemoveMaintenanceWindow(string) error
+	AllMaintenanceWindows() ([]coremaintenance.Window, error)
+	InMaintenanceWindow() (bool, error)
+	SetAgentOffline(state.AgentOffline) error
+	ClearAgentOffline(names.Tag, names.UserTag) error
+	AllAgentsOffline() ([]state.AgentOffline, error)
+}
+
+// APIv1 provides the Maintenance API facade for version 1.
+type APIv1 struct {
+	*APIv2
+}
+
+// APIv2 provides the Maintenance API facade for version 2.
+type APIv2 struct {
+	*API
+}
+
+// API implements the Maintenance facade.
+//
+// API provides the Maintenance API facade for version 3.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewAPI returns a new Maintenance facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+// NewFacadeV1 provides the signature required for facade registration
+// of version 1.
+func NewFacadeV1(st *state.State, resources facade.Resources, auth facade.Authorizer) (*APIv1, error) {
+	api, err := NewFacadeV2(st, resources, auth)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv1{api}, nil
+}
+
+// NewFacadeV2 provides the signature required for facade registration
+// of version 2.
+func NewFacadeV2(st *state.State, resources facade.Resources, auth facade.Authorizer) (*APIv2, error) {
+	api, err := NewFacade(st, resources, auth)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv2{api}, nil
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(st, auth)
+}
+
+func (api *API) checkPermission(access permission.Access) error {
+	ok, err := api.authorizer.HasPermission(access, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// MaintenanceModes returns the maintenance modes in effect in the
+// model.
+//
+//juju:readonly
+func (api *API) MaintenanceModes() (params.MaintenanceModes, error) {
+	if err := api.checkPermission(permission.ReadAccess); err != nil {
+		return params.MaintenanceModes{}, errors.Trace(err)
+	}
+	modes, err := api.backend.AllMaintenanceModes()
+	if err != nil {
+		return params.MaintenanceModes{}, errors.Trace(err)
+	}
+	result := params.MaintenanceModes{
+		Modes: make([]params.MaintenanceMode, len(modes)),
+	}
+	for i, mode := range modes {
+		result.Modes[i] = params.MaintenanceMode{
+			Tag:    mode.Tag.String(),
+			Reason: mode.Reason,
+		}
+		if !mode.Expires.IsZero() {
+			expires := mode.Expires
+			result.Modes[i].Expires = &expires
+		}
+	}
+	return result, nil
+}
+
+// SetMaintenanceModes puts each of the given machines, applications
+// and units into maintenance mode, replacing any maintenance mode they
+// are already in.
+func (api *API) SetMaintenanceModes(args params.MaintenanceModes) (params.ErrorResults, error) {
+	if err := api.checkPermission(permission.WriteAccess); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := make([]params.ErrorResult, len(args.Modes))
+	for i, arg := range args.Modes {
+		err := api.setMaintenanceMode(arg)
+		results[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func (api *API) setMaintenanceMode(arg params.MaintenanceMode) error {
+	tag, err := names.ParseTag(arg.Tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var expires time.Time
+	if arg.Expires != nil {
+		expires = *arg.Expires
+	}
+	return api.backend.SetMaintenanceMode(state.MaintenanceMode{
+		Tag:     tag,
+		Reason:  arg.Reason,
+		Expires: expires,
+	})
+}
+
+// ClearMaintenanceModes takes each of the given entities out of
+// maintenance mode.
+func (api *API) ClearMaintenanceModes(args params.Entities) (params.ErrorResults, error) {
+	if err := api.checkPermission(permission.WriteAccess); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := make([]params.ErrorResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		tag, err := names.ParseTag(arg.Tag)
+		if err == nil {
+			err = api.backend.ClearMaintenanceMode(tag)
+		}
+		results[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+// MaintenanceWindows returns the maintenance windows defined in the
+// model, and whether disruptive automated operations may run now.
+//
+//juju:readonly
+func (api *API) MaintenanceWindows() (params.MaintenanceWindows, error) {
+	if err := api.checkPermission(permission.ReadAccess); err != nil {
+		return params.MaintenanceWindows{}, errors.Trace(err)
+	}
+	windows, err := api.backend.AllMaintenanceWindows()
+	if err != nil {
+		return params.MaintenanceWindows{}, errors.Trace(err)
+	}
+	open, err := api.backend.InMaintenanceWindow()
+	if err != nil {
+		return params.MaintenanceWindows{}, errors.Trace(err)
+	}
+	result := params.MaintenanceWindows{
+		Windows: make([]params.MaintenanceWindow, len(windows)),
+		Open:    open,
+	}
+	for i, w := range windows {
+		result.Windows[i] = params.MaintenanceWindow{
+			Name:     w.Name,
+			Schedule: w.Schedule,
+			Duration: w.Duration,
+		}
+	}
+	return result, nil
+}
+
+// SetMaintenanceWindows defines each of the given maintenance windows
+// in the model, replacing any existing windows with the same names.
+func (api *API) SetMaintenanceWindows(args params.MaintenanceWindows) (params.ErrorResults, error) {
+	if err := api.checkPermission(permission.WriteAccess); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := make([]params.ErrorResult, len(args.Windows))
+	for i, arg := range args.Windows {
+		err := api.backend.SetMaintenanceWindow(coremaintenance.Window{
+			Name:     arg.Name,
+			Schedule: arg.Schedule,
+			Duration: arg.Duration,
+		})
+		results[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+// RemoveMaintenanceWindows removes each of the named maintenance
+// windows from the model.
+func (api *API) RemoveMaintenanceWindows(args params.MaintenanceWindowNames) (params.ErrorResults, error) {
+	if err := api.checkPermission(permission.WriteAccess); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := make([]params.ErrorResult, len(args.Names))
+	for i, name := range args.Names {
+		err := api.backend.RemoveMaintenanceWindow(name)
+		results[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+// AgentsOffline returns the acknowledgements in effect that agents of
+// the model's machines and units are offline.
+//
+//juju:readonly
+func (api *API) AgentsOffline() (params.AgentsOffline, error) {
+	if err := api.checkPermission(permission.ReadAccess); err != nil {
+		return params.AgentsOffline{}, errors.Trace(err)
+	}
+	acks, err := api.backend.AllAgentsOffline()
+	if err != nil {
+		return params.AgentsOffline{}, errors.Trace(err)
+	}
+	result := params.AgentsOffline{
+		Agents: make([]params.AgentOffline, len(acks)),
+	}
+	for i, ack := range acks {
+		acknowledged := ack.Acknowledged
+		result.Agents[i] = params.AgentOffline{
+			Tag:            ack.Tag.String(),
+			Reason:         ack.Reason,
+			Expires:        ack.Expires,
+			AcknowledgedBy: ack.AcknowledgedBy.String(),
+			Acknowledged:   &acknowledged,
+		}
+	}
+	return result, nil
+}
+
+// SetAgentsOffline acknowledges that the agents of each of the given
+// machines and units are intentionally offline until the given expiry
+// times, replacing any existing acknowledgements. The acknowledgements
+// are recorded in the model's activity feed against the
+// authenticated user.
+func (api *API) SetAgentsOffline(args params.AgentsOffline) (params.ErrorResults, error) {
+	if err := api.checkPermission(permission.WriteAccess); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	user, ok := api.authorizer.GetAuthTag().(names.UserTag)
+	if !ok {
+		return params.ErrorResults{}, common.ErrPerm
+	}
+	results := make([]params.ErrorResult, len(args.Agents))
+	for i, arg := range args.Agents {
+		tag, err := names.ParseTag(arg.Tag)
+		if err == nil {
+			err = api.backend.SetAgentOffline(state.AgentOffline{
+				Tag:            tag,
+				Reason:         arg.Reason,
+				AcknowledgedBy: user,
+				Expires:        arg.Expires,
+			})
+		}
+		results[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+// ClearAgentsOffline removes the acknowledgements that the agents of
+// each of the given machines and units are offline.
+func (api *API) ClearAgentsOffline(args params.Entities) (params.ErrorResults, error) {
+	if err := api.checkPermission(permission.WriteAccess); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	user, ok := api.authorizer.GetAuthTag().(names.UserTag)
+	if !ok {
+		return params.ErrorResults{}, common.ErrPerm
+	}
+	results := make([]params.ErrorResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		tag, err := names.ParseTag(arg.Tag)
+		if err == nil {
+			err = api.backend.ClearAgentOffline(tag, user)
+		}
+		results[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+// AgentsOffline is not available in version 2 of the API.
+//
+//juju:readonly
+func (*APIv2) AgentsOffline(_, _ struct{}) {}
+
+// SetAgentsOffline is not available in version 2 of the API.
+func (*APIv2) SetAgentsOffline(_, _ struct{}) {}
+
+// ClearAgentsOffline is not available in version 2 of the API.
+func (*APIv2) ClearAgentsOffline(_, _ struct{}) {}
+
+// MaintenanceWindows is not available in version 1 of the API.
+//
+//juju:readonly
+func (*APIv1) MaintenanceWindows(_, _ struct{}) {}
+
+// SetMaintenanceWindows is not available in version 1 of the API.
+func (*APIv1) SetMaintenanceWindows(_, _ struct{}) {}
+
+// RemoveMaintenanceWindows is not available in version 1 of the API.
+func (*APIv1) RemoveMaintenanceWindows(_, _ struct{}) {}
@@ -0,0 +1,313 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package maintenance_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/maintenance"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coremaintenance "github.com/juju/juju/core/maintenance"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type maintenanceSuite struct {
+	testing.IsolationSuite
+	backend *mockBackend
+	expires time.Time
+}
+
+var _ = gc.Suite(&maintenanceSuite{})
+
+func (s *maintenanceSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.expires = time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	s.backend = &mockBackend{
+		modes: []state.MaintenanceMode{{
+			Tag:    names.NewApplicationTag("mysql"),
+			Reason: "database migration",
+		}, {
+			Tag:     names.NewMachineTag("0"),
+			Reason:  "replacing disks",
+			Expires: s.expires,
+		}},
+		windows: []coremaintenance.Window{{
+			Name:     "weekend",
+			Schedule: "0 2 * * sat",
+			Duration: 2 * time.Hour,
+		}},
+	}
+}
+
+func (s *maintenanceSuite) newAPI(c *gc.C, user string) *maintenance.API {
+	api, err := maintenance.NewAPI(s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag(user),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *maintenanceSuite) TestRequiresClient(c *gc.C) {
+	_, err := maintenance.NewAPI(s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *maintenanceSuite) TestMaintenanceModes(c *gc.C) {
+	result, err := s.newAPI(c, "read").MaintenanceModes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.MaintenanceModes{
+		Modes: []params.MaintenanceMode{{
+			Tag:    "application-mysql",
+			Reason: "database migration",
+		}, {
+			Tag:     "machine-0",
+			Reason:  "replacing disks",
+			Expires: &s.expires,
+		}},
+	})
+}
+
+func (s *maintenanceSuite) TestSetMaintenanceModes(c *gc.C) {
+	s.backend.SetErrors(nil, errors.NotValidf("empty maintenance reason"))
+	result, err := s.newAPI(c, "admin").SetMaintenanceModes(params.MaintenanceModes{
+		Modes: []params.MaintenanceMode{
+			{Tag: "unit-mysql-0", Reason: "kernel upgrade", Expires: &s.expires},
+			{Tag: "machine-1"},
+			{Tag: "bad-tag", Reason: "kernel upgrade"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, "empty maintenance reason not valid")
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `"bad-tag" is not a valid tag`)
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"SetMaintenanceMode", []interface{}{state.MaintenanceMode{
+			Tag:     names.NewUnitTag("mysql/0"),
+			Reason:  "kernel upgrade",
+			Expires: s.expires,
+		}}},
+		{"SetMaintenanceMode", []interface{}{state.MaintenanceMode{
+			Tag: names.NewMachineTag("1"),
+		}}},
+	})
+}
+
+func (s *maintenanceSuite) TestSetMaintenanceModesRequiresWrite(c *gc.C) {
+	_, err := s.newAPI(c, "read").SetMaintenanceModes(params.MaintenanceModes{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *maintenanceSuite) TestClearMaintenanceModes(c *gc.C) {
+	result, err := s.newAPI(c, "admin").ClearMaintenanceModes(params.Entities{
+		Entities: []params.Entity{{Tag: "application-mysql"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, jc.DeepEquals, []params.ErrorResult{{}})
+	s.backend.CheckCall(c, 0, "ClearMaintenanceMode", names.NewApplicationTag("mysql"))
+}
+
+func (s *maintenanceSuite) TestClearMaintenanceModesRequiresWrite(c *gc.C) {
+	_, err := s.newAPI(c, "read").ClearMaintenanceModes(params.Entities{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *maintenanceSuite) TestMaintenanceWindows(c *gc.C) {
+	result, err := s.newAPI(c, "read").MaintenanceWindows()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.MaintenanceWindows{
+		Windows: []params.MaintenanceWindow{{
+			Name:     "weekend",
+			Schedule: "0 2 * * sat",
+			Duration: 2 * time.Hour,
+		}},
+		Open: true,
+	})
+	s.backend.CheckCallNames(c, "AllMaintenanceWindows", "InMaintenanceWindow")
+}
+
+func (s *maintenanceSuite) TestSetMaintenanceWindows(c *gc.C) {
+	s.backend.SetErrors(nil, errors.NotValidf(`maintenance window name "Nightly"`))
+	result, err := s.newAPI(c, "admin").SetMaintenanceWindows(params.MaintenanceWindows{
+		Windows: []params.MaintenanceWindow{
+			{Name: "weekend", Schedule: "0 2 * * sat", Duration: time.Hour},
+			{Name: "Nightly", Schedule: "0 1 * * *", Duration: time.Hour},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `maintenance window name "Nightly" not valid`)
+	s.backend.CheckCall(c, 0, "SetMaintenanceWindow", coremaintenance.Window{
+		Name:     "weekend",
+		Schedule: "0 2 * * sat",
+		Duration: time.Hour,
+	})
+}
+
+func (s *maintenanceSuite) TestSetMaintenanceWindowsRequiresWrite(c *gc.C) {
+	_, err := s.newAPI(c, "read").SetMaintenanceWindows(params.MaintenanceWindows{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *maintenanceSuite) TestRemoveMaintenanceWindows(c *gc.C) {
+	result, err := s.newAPI(c, "admin").RemoveMaintenanceWindows(params.MaintenanceWindowNames{
+		Names: []string{"weekend"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, jc.DeepEquals, []params.ErrorResult{{}})
+	s.backend.CheckCall(c, 0, "RemoveMaintenanceWindow", "weekend")
+}
+
+func (s *maintenanceSuite) TestRemoveMaintenanceWindowsRequiresWrite(c *gc.C) {
+	_, err := s.newAPI(c, "read").RemoveMaintenanceWindows(params.MaintenanceWindowNames{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *maintenanceSuite) TestAgentsOffline(c *gc.C) {
+	acknowledged := time.Date(2018, 2, 27, 9, 0, 0, 0, time.UTC)
+	s.backend.offline = []state.AgentOffline{{
+		Tag:            names.NewMachineTag("3"),
+		Reason:         "replacing motherboard",
+		AcknowledgedBy: names.NewUserTag("admin"),
+		Acknowledged:   acknowledged,
+		Expires:        s.expires,
+	}}
+	result, err := s.newAPI(c, "read").AgentsOffline()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.AgentsOffline{
+		Agents: []params.AgentOffline{{
+			Tag:            "machine-3",
+			Reason:         "replacing motherboard",
+			Expires:        s.expires,
+			AcknowledgedBy: "user-admin",
+			Acknowledged:   &acknowledged,
+		}},
+	})
+}
+
+func (s *maintenanceSuite) TestSetAgentsOffline(c *gc.C) {
+	s.backend.SetErrors(nil, errors.NotValidf("empty offline reason"))
+	result, err := s.newAPI(c, "admin").SetAgentsOffline(params.AgentsOffline{
+		Agents: []params.AgentOffline{
+			{Tag: "machine-3", Reason: "replacing motherboard", Expires: s.expires},
+			{Tag: "unit-mysql-0", Expires: s.expires},
+			{Tag: "bad-tag", Reason: "replacing motherboard", Expires: s.expires},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, "empty offline reason not valid")
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `"bad-tag" is not a valid tag`)
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"SetAgentOffline", []interface{}{state.AgentOffline{
+			Tag:            names.NewMachineTag("3"),
+			Reason:         "replacing motherboard",
+			AcknowledgedBy: names.NewUserTag("admin"),
+			Expires:        s.expires,
+		}}},
+		{"SetAgentOffline", []interface{}{state.AgentOffline{
+			Tag:            names.NewUnitTag("mysql/0"),
+			AcknowledgedBy: names.NewUserTag("admin"),
+			Expires:        s.expires,
+		}}},
+	})
+}
+
+func (s *maintenanceSuite) TestSetAgentsOfflineRequiresWrite(c *gc.C) {
+	_, err := s.newAPI(c, "read").SetAgentsOffline(params.AgentsOffline{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *maintenanceSuite) TestClearAgentsOffline(c *gc.C) {
+	result, err := s.newAPI(c, "admin").ClearAgentsOffline(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-3"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, jc.DeepEquals, []params.ErrorResult{{}})
+	s.backend.CheckCall(c, 0, "ClearAgentOffline", names.NewMachineTag("3"), names.NewUserTag("admin"))
+}
+
+func (s *maintenanceSuite) TestClearAgentsOfflineRequiresWrite(c *gc.C) {
+	_, err := s.newAPI(c, "read").ClearAgentsOffline(params.Entities{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+type mockBackend struct {
+	testing.Stub
+	modes   []state.MaintenanceMode
+	windows []coremaintenance.Window
+	offline []state.AgentOffline
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) SetMaintenanceMode(mode state.MaintenanceMode) error {
+	b.MethodCall(b, "SetMaintenanceMode", mode)
+	return b.NextErr()
+}
+
+func (b *mockBackend) ClearMaintenanceMode(tag names.Tag) error {
+	b.MethodCall(b, "ClearMaintenanceMode", tag)
+	return b.NextErr()
+}
+
+func (b *mockBackend) AllMaintenanceModes() ([]state.MaintenanceMode, error) {
+	b.MethodCall(b, "AllMaintenanceModes")
+	return b.modes, b.NextErr()
+}
+
+func (b *mockBackend) SetMaintenanceWindow(w coremaintenance.Window) error {
+	b.MethodCall(b, "SetMaintenanceWindow", w)
+	return b.NextErr()
+}
+
+func (b *mockBackend) RemoveMaintenanceWindow(name string) error {
+	b.MethodCall(b, "RemoveMaintenanceWindow", name)
+	return b.NextErr()
+}
+
+func (b *mockBackend) AllMaintenanceWindows() ([]coremaintenance.Window, error) {
+	b.MethodCall(b, "AllMaintenanceWindows")
+	return b.windows, b.NextErr()
+}
+
+func (b *mockBackend) InMaintenanceWindow() (bool, error) {
+	b.MethodCall(b, "InMaintenanceWindow")
+	return true, b.NextErr()
+}
+
+func (b *mockBackend) SetAgentOffline(offline state.AgentOffline) error {
+	b.MethodCall(b, "SetAgentOffline", offline)
+	return b.NextErr()
+}
+
+func (b *mockBackend) ClearAgentOffline(tag names.Tag, clearedBy names.UserTag) error {
+	b.MethodCall(b, "ClearAgentOffline", tag, clearedBy)
+	return b.NextErr()
+}
+
+func (b *mockBackend) AllAgentsOffline() ([]state.AgentOffline, error) {
+	b.MethodCall(b, "AllAgentsOffline")
+	return b.offline, b.NextErr()
+}
@@ -68,6 +68,8 @@ func NewMetricsDebugAPI(
 }
 
 // GetMetrics returns all metrics stored by the state server.
+//
+//juju:readonly
 func (api *MetricsDebugAPI) GetMetrics(args params.Entities) (params.MetricResults, error) {
 	results := params.MetricResults{
 		Results: make([]params.EntityMetrics, len(args.Entities)),
@@ -0,0 +1,172 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelapply implements the ModelApply facade, which brings a
+// model to a declared desired state. Changes are first planned, and
+// then a chosen subset of the planned changes applied, so that
+// external tooling can review and idempotently apply them.
+package modelapply
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.modelapply")
+
+// Backend defines the state functionality required by the ModelApply
+// facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+
+	// ModelInfo returns the current state of the model.
+	ModelInfo() (ModelInfo, error)
+
+	Deploy(app, charmURL string, numUnits int, config map[string]interface{}) error
+	UpdateConfig(app string, config map[string]interface{}) error
+	AddUnits(app string, count int) error
+	SetExposed(app string, exposed bool) error
+	AddRelation(endpoints ...string) error
+}
+
+// BlockChecker checks for blocks on model changes.
+type BlockChecker interface {
+	ChangeAllowed() error
+}
+
+// API implements the ModelApply facade.
+type API struct {
+	backend    Backend
+	check      BlockChecker
+	authorizer facade.Authorizer
+}
+
+// NewAPI returns a new ModelApply facade.
+func NewAPI(backend Backend, check BlockChecker, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		check:      check,
+		authorizer: authorizer,
+	}, nil
+}
+
+func (api *API) checkPermission(access permission.Access) error {
+	ok, err := api.authorizer.HasPermission(access, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// Plan returns the changes needed to bring the model to the desired
+// state. It makes no changes to the model.
+func (api *API) Plan(args params.DesiredModel) (params.ModelPlanResult, error) {
+	if err := api.checkPermission(permission.ReadAccess); err != nil {
+		return params.ModelPlanResult{}, errors.Trace(err)
+	}
+	changes, err := api.plan(args)
+	if err != nil {
+		return params.ModelPlanResult{Error: common.ServerError(err)}, nil
+	}
+	return params.ModelPlanResult{Changes: changes}, nil
+}
+
+func (api *API) plan(desired params.DesiredModel) ([]params.ModelChange, error) {
+	current, err := api.backend.ModelInfo()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return Plan(current, desired)
+}
+
+// Apply plans the desired state against the current model again, and
+// applies those changes whose ids are given. If any given id is not
+// part of the new plan, because the model has changed since it was
+// planned or the change has already been applied, nothing is applied.
+// Changes are applied in plan order; if one fails, the result records
+// the changes applied before the failure.
+func (api *API) Apply(args params.ModelApplyArgs) (params.ModelApplyResult, error) {
+	if err := api.checkPermission(permission.WriteAccess); err != nil {
+		return params.ModelApplyResult{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ModelApplyResult{}, errors.Trace(err)
+	}
+	applied, err := api.apply(args)
+	result := params.ModelApplyResult{Applied: applied}
+	if err != nil {
+		result.Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (api *API) apply(args params.ModelApplyArgs) ([]string, error) {
+	if len(args.ChangeIds) == 0 {
+		return nil, errors.NotValidf("empty change ids")
+	}
+	changes, err := api.plan(args.Desired)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	wanted := make(map[string]bool)
+	for _, id := range args.ChangeIds {
+		wanted[id] = true
+	}
+	var selected []params.ModelChange
+	for _, change := range changes {
+		if wanted[change.Id] {
+			selected = append(selected, change)
+			delete(wanted, change.Id)
+		}
+	}
+	for id := range wanted {
+		return nil, errors.NotFoundf("change %q in current plan", id)
+	}
+
+	applied := []string{}
+	for _, change := range selected {
+		logger.Debugf("applying change %s: %s %s", change.Id, change.Kind, change.Application)
+		if err := api.applyChange(change); err != nil {
+			return applied, errors.Annotatef(err, "applying change %s (%s)", change.Id, change.Kind)
+		}
+		applied = append(applied, change.Id)
+	}
+	return applied, nil
+}
+
+func (api *API) applyChange(change params.ModelChange) error {
+	app := change.Application
+	switch change.Kind {
+	case KindDeploy:
+		config, _ := change.Args["config"].(map[string]interface{})
+		return api.backend.Deploy(
+			app,
+			change.Args["charm"].(string),
+			change.Args["num-units"].(int),
+			config,
+		)
+	case KindSetConfig:
+		return api.backend.UpdateConfig(app, change.Args["config"].(map[string]interface{}))
+	case KindAddUnits:
+		return api.backend.AddUnits(app, change.Args["count"].(int))
+	case KindExpose:
+		return api.backend.SetExposed(app, true)
+	case KindUnexpose:
+		return api.backend.SetExposed(app, false)
+	case KindAddRelation:
+		return api.backend.AddRelation(change.Args["endpoints"].([]string)...)
+	}
+	return errors.NotSupportedf("change kind %q", change.Kind)
+}
@@ -0,0 +1,221 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelapply_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/modelapply"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type modelApplySuite struct {
+	testing.IsolationSuite
+	backend *mockBackend
+	blocks  mockBlockChecker
+	api     *modelapply.API
+}
+
+var _ = gc.Suite(&modelApplySuite{})
+
+var desired = params.DesiredModel{
+	Applications: map[string]params.DesiredApplication{
+		"mysql": {Charm: "cs:mysql-3", NumUnits: 1},
+		"wordpress": {
+			Charm:    "cs:wordpress-1",
+			NumUnits: 2,
+			Config:   map[string]interface{}{"blog-title": "hello"},
+			Exposed:  true,
+		},
+	},
+	Relations: [][]string{{"wordpress:db", "mysql:server"}},
+}
+
+func (s *modelApplySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		info: modelapply.ModelInfo{
+			Applications: map[string]modelapply.ApplicationInfo{
+				"mysql": {Name: "mysql", Charm: "cs:mysql-3", NumUnits: 1},
+			},
+		},
+	}
+	s.blocks = mockBlockChecker{}
+	s.api = s.newAPI(c, "admin")
+}
+
+func (s *modelApplySuite) newAPI(c *gc.C, user string) *modelapply.API {
+	api, err := modelapply.NewAPI(s.backend, &s.blocks, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag(user),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *modelApplySuite) TestNewAPIRequiresClient(c *gc.C) {
+	_, err := modelapply.NewAPI(s.backend, &s.blocks, apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelApplySuite) TestPlan(c *gc.C) {
+	result, err := s.newAPI(c, "read").Plan(desired)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(kinds(result.Changes), jc.DeepEquals, []string{
+		"deploy wordpress",
+		"expose wordpress",
+		"add-relation ",
+	})
+	s.backend.CheckCallNames(c, "ModelInfo")
+}
+
+func (s *modelApplySuite) TestPlanError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	result, err := s.api.Plan(desired)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "boom")
+}
+
+func (s *modelApplySuite) TestPlanPermissionDenied(c *gc.C) {
+	_, err := s.newAPI(c, "nobody").Plan(desired)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelApplySuite) planIds(c *gc.C) []string {
+	result, err := s.api.Plan(desired)
+	c.Assert(err, jc.ErrorIsNil)
+	var ids []string
+	for _, change := range result.Changes {
+		ids = append(ids, change.Id)
+	}
+	s.backend.ResetCalls()
+	return ids
+}
+
+func (s *modelApplySuite) TestApply(c *gc.C) {
+	ids := s.planIds(c)
+	result, err := s.api.Apply(params.ModelApplyArgs{
+		Desired:   desired,
+		ChangeIds: ids,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Applied, jc.DeepEquals, ids)
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"ModelInfo", nil},
+		{"Deploy", []interface{}{"wordpress", "cs:wordpress-1", 2, map[string]interface{}{"blog-title": "hello"}}},
+		{"SetExposed", []interface{}{"wordpress", true}},
+		{"AddRelation", []interface{}{[]string{"mysql:server", "wordpress:db"}}},
+	})
+}
+
+func (s *modelApplySuite) TestApplySubset(c *gc.C) {
+	ids := s.planIds(c)
+	result, err := s.api.Apply(params.ModelApplyArgs{
+		Desired:   desired,
+		ChangeIds: ids[2:],
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Applied, jc.DeepEquals, ids[2:])
+	s.backend.CheckCallNames(c, "ModelInfo", "AddRelation")
+}
+
+func (s *modelApplySuite) TestApplyUnknownChange(c *gc.C) {
+	result, err := s.api.Apply(params.ModelApplyArgs{
+		Desired:   desired,
+		ChangeIds: []string{"deadbeef"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `change "deadbeef" in current plan not found`)
+	c.Assert(result.Applied, gc.HasLen, 0)
+	s.backend.CheckCallNames(c, "ModelInfo")
+}
+
+func (s *modelApplySuite) TestApplyPartialFailure(c *gc.C) {
+	ids := s.planIds(c)
+	s.backend.SetErrors(nil, nil, errors.New("no expose for you"))
+	result, err := s.api.Apply(params.ModelApplyArgs{
+		Desired:   desired,
+		ChangeIds: ids,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `applying change .* \(expose\): no expose for you`)
+	c.Assert(result.Applied, jc.DeepEquals, ids[:1])
+}
+
+func (s *modelApplySuite) TestApplyBlocked(c *gc.C) {
+	s.blocks.SetErrors(errors.New("blocked"))
+	_, err := s.api.Apply(params.ModelApplyArgs{
+		Desired:   desired,
+		ChangeIds: []string{"deadbeef"},
+	})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *modelApplySuite) TestApplyPermissionDenied(c *gc.C) {
+	_, err := s.newAPI(c, "read").Apply(params.ModelApplyArgs{
+		Desired:   desired,
+		ChangeIds: []string{"deadbeef"},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+type mockBackend struct {
+	testing.Stub
+	info modelapply.ModelInfo
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) ModelInfo() (modelapply.ModelInfo, error) {
+	b.MethodCall(b, "ModelInfo")
+	return b.info, b.NextErr()
+}
+
+func (b *mockBackend) Deploy(app, charmURL string, numUnits int, config map[string]interface{}) error {
+	b.MethodCall(b, "Deploy", app, charmURL, numUnits, config)
+	return b.NextErr()
+}
+
+func (b *mockBackend) UpdateConfig(app string, config map[string]interface{}) error {
+	b.MethodCall(b, "UpdateConfig", app, config)
+	return b.NextErr()
+}
+
+func (b *mockBackend) AddUnits(app string, count int) error {
+	b.MethodCall(b, "AddUnits", app, count)
+	return b.NextErr()
+}
+
+func (b *mockBackend) SetExposed(app string, exposed bool) error {
+	b.MethodCall(b, "SetExposed", app, exposed)
+	return b.NextErr()
+}
+
+func (b *mockBackend) AddRelation(endpoints ...string) error {
+	b.MethodCall(b, "AddRelation", endpoints)
+	return b.NextErr()
+}
+
+type mockBlockChecker struct {
+	testing.Stub
+}
+
+func (c *mockBlockChecker) ChangeAllowed() error {
+	c.MethodCall(c, "ChangeAllowed")
+	return c.NextErr()
+}
@@ -0,0 +1,209 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelapply
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Change kinds, in the order in which they are applied.
+const (
+	KindDeploy      = "deploy"
+	KindSetConfig   = "set-config"
+	KindAddUnits    = "add-units"
+	KindExpose      = "expose"
+	KindUnexpose    = "unexpose"
+	KindAddRelation = "add-relation"
+)
+
+// ApplicationInfo describes the current state of an application.
+type ApplicationInfo struct {
+	Name     string
+	Charm    string
+	NumUnits int
+	Config   map[string]interface{}
+	Exposed  bool
+}
+
+// ModelInfo describes the current state of a model.
+type ModelInfo struct {
+	Applications map[string]ApplicationInfo
+
+	// Relations holds the keys of the model's relations, as returned
+	// by state.Relation.String: the relation's endpoints, ordered by
+	// role and joined with a space.
+	Relations []string
+}
+
+// Plan returns the changes needed to bring the current model to the
+// desired state. Plan never removes anything: applications, units,
+// config and relations that aren't mentioned in the desired state are
+// left alone.
+func Plan(current ModelInfo, desired params.DesiredModel) ([]params.ModelChange, error) {
+	var changes []params.ModelChange
+	var addErr error
+	add := func(kind, app string, args map[string]interface{}) {
+		change, err := newChange(kind, app, args)
+		if err != nil && addErr == nil {
+			addErr = errors.Annotatef(err, "cannot plan %s", kind)
+		}
+		changes = append(changes, change)
+	}
+
+	appNames := make([]string, 0, len(desired.Applications))
+	for name := range desired.Applications {
+		appNames = append(appNames, name)
+	}
+	sort.Strings(appNames)
+
+	for _, name := range appNames {
+		want := desired.Applications[name]
+		if want.Charm == "" {
+			return nil, errors.NotValidf("application %q with no charm", name)
+		}
+		if want.NumUnits < 0 {
+			return nil, errors.NotValidf("application %q with negative num-units", name)
+		}
+		have, ok := current.Applications[name]
+		if !ok {
+			add(KindDeploy, name, map[string]interface{}{
+				"charm":     want.Charm,
+				"num-units": want.NumUnits,
+				"config":    want.Config,
+			})
+			if want.Exposed {
+				add(KindExpose, name, nil)
+			}
+			continue
+		}
+		if have.Charm != want.Charm {
+			return nil, errors.NotSupportedf(
+				"changing charm of application %q from %q to %q",
+				name, have.Charm, want.Charm,
+			)
+		}
+		if changed := changedConfig(have.Config, want.Config); len(changed) > 0 {
+			add(KindSetConfig, name, map[string]interface{}{"config": changed})
+		}
+		switch {
+		case want.NumUnits > have.NumUnits:
+			add(KindAddUnits, name, map[string]interface{}{
+				"count": want.NumUnits - have.NumUnits,
+			})
+		case want.NumUnits < have.NumUnits:
+			return nil, errors.NotSupportedf(
+				"reducing units of application %q from %d to %d",
+				name, have.NumUnits, want.NumUnits,
+			)
+		}
+		if want.Exposed && !have.Exposed {
+			add(KindExpose, name, nil)
+		} else if !want.Exposed && have.Exposed {
+			add(KindUnexpose, name, nil)
+		}
+	}
+
+	// The roles of the endpoints of relations between applications
+	// that are yet to be deployed are not known, so relations are
+	// compared by their endpoints regardless of the order of the
+	// endpoints in state's relation keys.
+	existing := make(map[string]bool)
+	for _, stateKey := range current.Relations {
+		key, err := relationKey(strings.Fields(stateKey))
+		if err != nil {
+			// Peer relations have a single endpoint, and can't be
+			// added by a plan.
+			continue
+		}
+		existing[key] = true
+	}
+	for _, endpoints := range desired.Relations {
+		key, err := relationKey(endpoints)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if existing[key] {
+			continue
+		}
+		existing[key] = true
+		add(KindAddRelation, "", map[string]interface{}{
+			"endpoints": strings.Split(key, " "),
+		})
+	}
+
+	if addErr != nil {
+		return nil, errors.Trace(addErr)
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return kindOrder[changes[i].Kind] < kindOrder[changes[j].Kind]
+	})
+	return changes, nil
+}
+
+var kindOrder = map[string]int{
+	KindDeploy:      0,
+	KindSetConfig:   1,
+	KindAddUnits:    2,
+	KindExpose:      3,
+	KindUnexpose:    3,
+	KindAddRelation: 4,
+}
+
+// relationKey returns the key identifying the relation between the
+// given endpoints: the endpoints sorted by name and joined with a
+// space. Unlike state's relation keys, it doesn't depend on the roles
+// of the endpoints.
+func relationKey(endpoints []string) (string, error) {
+	if len(endpoints) != 2 {
+		return "", errors.NotValidf("relation %v (expected 2 endpoints)", endpoints)
+	}
+	sorted := make([]string, len(endpoints))
+	for i, ep := range endpoints {
+		if parts := strings.Split(ep, ":"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return "", errors.NotValidf("relation endpoint %q (expected application:endpoint)", ep)
+		}
+		sorted[i] = ep
+	}
+	sort.Strings(sorted)
+	return strings.Join(sorted, " "), nil
+}
+
+// changedConfig returns the desired settings which differ from the
+// current settings. Values are compared by their string form, since
+// numbers decoded from JSON and numbers read from state have
+// different types.
+func changedConfig(current, desired map[string]interface{}) map[string]interface{} {
+	changed := make(map[string]interface{})
+	for k, v := range desired {
+		if cur, ok := current[k]; ok && fmt.Sprint(cur) == fmt.Sprint(v) {
+			continue
+		}
+		changed[k] = v
+	}
+	return changed
+}
+
+// newChange returns a change with an id derived from its content.
+func newChange(kind, app string, args map[string]interface{}) (params.ModelChange, error) {
+	// encoding/json sorts map keys, so the encoding is stable.
+	data, err := json.Marshal([]interface{}{kind, app, args})
+	if err != nil {
+		return params.ModelChange{}, errors.Trace(err)
+	}
+	sum := sha256.Sum256(data)
+	return params.ModelChange{
+		Id:          fmt.Sprintf("%x", sum[:6]),
+		Kind:        kind,
+		Application: app,
+		Args:        args,
+	}, nil
+}
@@ -0,0 +1,187 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelapply_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/client/modelapply"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type planSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&planSuite{})
+
+func kinds(changes []params.ModelChange) []string {
+	var result []string
+	for _, change := range changes {
+		result = append(result, change.Kind+" "+change.Application)
+	}
+	return result
+}
+
+func (s *planSuite) TestPlanEmptyModel(c *gc.C) {
+	changes, err := modelapply.Plan(modelapply.ModelInfo{}, params.DesiredModel{
+		Applications: map[string]params.DesiredApplication{
+			"wordpress": {Charm: "cs:wordpress-1", NumUnits: 2, Exposed: true},
+			"mysql":     {Charm: "cs:mysql-3", NumUnits: 1},
+		},
+		Relations: [][]string{{"wordpress:db", "mysql:server"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(kinds(changes), jc.DeepEquals, []string{
+		"deploy mysql",
+		"deploy wordpress",
+		"expose wordpress",
+		"add-relation ",
+	})
+	c.Assert(changes[1].Args, jc.DeepEquals, map[string]interface{}{
+		"charm":     "cs:wordpress-1",
+		"num-units": 2,
+		"config":    map[string]interface{}(nil),
+	})
+	c.Assert(changes[3].Args, jc.DeepEquals, map[string]interface{}{
+		"endpoints": []string{"mysql:server", "wordpress:db"},
+	})
+}
+
+func (s *planSuite) TestPlanExistingModel(c *gc.C) {
+	current := modelapply.ModelInfo{
+		Applications: map[string]modelapply.ApplicationInfo{
+			"wordpress": {
+				Name:     "wordpress",
+				Charm:    "cs:wordpress-1",
+				NumUnits: 1,
+				Config:   map[string]interface{}{"blog-title": "hello", "port": int64(80)},
+				Exposed:  true,
+			},
+		},
+	}
+	changes, err := modelapply.Plan(current, params.DesiredModel{
+		Applications: map[string]params.DesiredApplication{
+			"wordpress": {
+				Charm:    "cs:wordpress-1",
+				NumUnits: 3,
+				Config:   map[string]interface{}{"blog-title": "goodbye", "port": float64(80)},
+			},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(kinds(changes), jc.DeepEquals, []string{
+		"set-config wordpress",
+		"add-units wordpress",
+		"unexpose wordpress",
+	})
+	c.Assert(changes[0].Args, jc.DeepEquals, map[string]interface{}{
+		"config": map[string]interface{}{"blog-title": "goodbye"},
+	})
+	c.Assert(changes[1].Args, jc.DeepEquals, map[string]interface{}{"count": 2})
+}
+
+func (s *planSuite) TestPlanIdempotent(c *gc.C) {
+	current := modelapply.ModelInfo{
+		Applications: map[string]modelapply.ApplicationInfo{
+			"mysql":     {Name: "mysql", Charm: "cs:mysql-3", NumUnits: 1},
+			"wordpress": {Name: "wordpress", Charm: "cs:wordpress-1", NumUnits: 2},
+		},
+		// State orders relation endpoints by role, requirer first.
+		Relations: []string{"wordpress:db mysql:server", "mysql:cluster"},
+	}
+	changes, err := modelapply.Plan(current, params.DesiredModel{
+		Applications: map[string]params.DesiredApplication{
+			"wordpress": {Charm: "cs:wordpress-1", NumUnits: 2},
+			"mysql":     {Charm: "cs:mysql-3", NumUnits: 1},
+		},
+		Relations: [][]string{{"mysql:server", "wordpress:db"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes, gc.HasLen, 0)
+}
+
+func (s *planSuite) TestPlanInvalidConfig(c *gc.C) {
+	_, err := modelapply.Plan(modelapply.ModelInfo{}, params.DesiredModel{
+		Applications: map[string]params.DesiredApplication{
+			"mysql": {
+				Charm:  "cs:mysql-3",
+				Config: map[string]interface{}{"bad": make(chan int)},
+			},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, "cannot plan deploy: json: unsupported type: chan int")
+}
+
+func (s *planSuite) TestPlanIdsStable(c *gc.C) {
+	desired := params.DesiredModel{
+		Applications: map[string]params.DesiredApplication{
+			"wordpress": {Charm: "cs:wordpress-1", NumUnits: 2},
+			"mysql":     {Charm: "cs:mysql-3", NumUnits: 1},
+		},
+	}
+	first, err := modelapply.Plan(modelapply.ModelInfo{}, desired)
+	c.Assert(err, jc.ErrorIsNil)
+	second, err := modelapply.Plan(modelapply.ModelInfo{}, desired)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(first, jc.DeepEquals, second)
+	c.Assert(first[0].Id, gc.Not(gc.Equals), first[1].Id)
+}
+
+func (s *planSuite) TestPlanChangeCharmUnsupported(c *gc.C) {
+	current := modelapply.ModelInfo{
+		Applications: map[string]modelapply.ApplicationInfo{
+			"mysql": {Name: "mysql", Charm: "cs:mysql-3", NumUnits: 1},
+		},
+	}
+	_, err := modelapply.Plan(current, params.DesiredModel{
+		Applications: map[string]params.DesiredApplication{
+			"mysql": {Charm: "cs:mysql-4", NumUnits: 1},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `changing charm of application "mysql" from "cs:mysql-3" to "cs:mysql-4" not supported`)
+}
+
+func (s *planSuite) TestPlanRemoveUnitsUnsupported(c *gc.C) {
+	current := modelapply.ModelInfo{
+		Applications: map[string]modelapply.ApplicationInfo{
+			"mysql": {Name: "mysql", Charm: "cs:mysql-3", NumUnits: 2},
+		},
+	}
+	_, err := modelapply.Plan(current, params.DesiredModel{
+		Applications: map[string]params.DesiredApplication{
+			"mysql": {Charm: "cs:mysql-3", NumUnits: 1},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `reducing units of application "mysql" from 2 to 1 not supported`)
+}
+
+func (s *planSuite) TestPlanInvalid(c *gc.C) {
+	for i, test := range []struct {
+		desired params.DesiredModel
+		err     string
+	}{{
+		desired: params.DesiredModel{Applications: map[string]params.DesiredApplication{
+			"mysql": {NumUnits: 1},
+		}},
+		err: `application "mysql" with no charm not valid`,
+	}, {
+		desired: params.DesiredModel{Applications: map[string]params.DesiredApplication{
+			"mysql": {Charm: "cs:mysql-3", NumUnits: -1},
+		}},
+		err: `application "mysql" with negative num-units not valid`,
+	}, {
+		desired: params.DesiredModel{Relations: [][]string{{"wordpress:db"}}},
+		err:     `relation \[wordpress:db\] \(expected 2 endpoints\) not valid`,
+	}, {
+		desired: params.DesiredModel{Relations: [][]string{{"wordpress", "mysql:server"}}},
+		err:     `relation endpoint "wordpress" \(expected application:endpoint\) not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := modelapply.Plan(modelapply.ModelInfo{}, test.desired)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
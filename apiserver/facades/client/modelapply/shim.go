@@ -0,0 +1,151 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelapply
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/facades/client/application"
+	"github.com/juju/juju/state"
+)
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(&backend{st}, common.NewBlockChecker(st), auth)
+}
+
+type backend struct {
+	*state.State
+}
+
+// ModelInfo is part of the Backend interface.
+func (b *backend) ModelInfo() (ModelInfo, error) {
+	apps, err := b.AllApplications()
+	if err != nil {
+		return ModelInfo{}, errors.Trace(err)
+	}
+	info := ModelInfo{
+		Applications: make(map[string]ApplicationInfo),
+	}
+	for _, app := range apps {
+		curl, _ := app.CharmURL()
+		config, err := app.CharmConfig()
+		if err != nil {
+			return ModelInfo{}, errors.Trace(err)
+		}
+		units, err := app.AllUnits()
+		if err != nil {
+			return ModelInfo{}, errors.Trace(err)
+		}
+		info.Applications[app.Name()] = ApplicationInfo{
+			Name:     app.Name(),
+			Charm:    curl.String(),
+			NumUnits: len(units),
+			Config:   config,
+			Exposed:  app.IsExposed(),
+		}
+	}
+	relations, err := b.AllRelations()
+	if err != nil {
+		return ModelInfo{}, errors.Trace(err)
+	}
+	for _, rel := range relations {
+		info.Relations = append(info.Relations, rel.String())
+	}
+	return info, nil
+}
+
+// Deploy is part of the Backend interface.
+func (b *backend) Deploy(name, charmURL string, numUnits int, config map[string]interface{}) error {
+	curl, err := charm.ParseURL(charmURL)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ch, err := b.Charm(curl)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Each unit is assigned to a clean, empty machine, or a new one.
+	if err := b.CheckModelQuotas(state.ModelResources{Machines: numUnits, Units: numUnits}); err != nil {
+		return errors.Trace(err)
+	}
+	deployer, err := application.NewStateBackend(b.State)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = application.DeployApplication(
+		deployer,
+		application.DeployApplicationParams{
+			ApplicationName: name,
+			Series:          curl.Series,
+			Charm:           ch,
+			CharmConfig:     config,
+			NumUnits:        numUnits,
+		},
+	)
+	return errors.Trace(err)
+}
+
+// UpdateConfig is part of the Backend interface.
+func (b *backend) UpdateConfig(name string, config map[string]interface{}) error {
+	app, err := b.Application(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ch, _, err := app.Charm()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	settings, err := ch.Config().ValidateSettings(config)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(app.UpdateCharmConfig(settings))
+}
+
+// AddUnits is part of the Backend interface.
+func (b *backend) AddUnits(name string, count int) error {
+	app, err := b.Application(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := b.CheckModelQuotas(state.ModelResources{Machines: count, Units: count}); err != nil {
+		return errors.Trace(err)
+	}
+	for i := 0; i < count; i++ {
+		unit, err := app.AddUnit(state.AddUnitParams{})
+		if err != nil {
+			return errors.Annotatef(err, "cannot add unit %d/%d to application %q", i+1, count, name)
+		}
+		if err := unit.AssignWithPolicy(state.AssignCleanEmpty); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// SetExposed is part of the Backend interface.
+func (b *backend) SetExposed(name string, exposed bool) error {
+	app, err := b.Application(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if exposed {
+		return errors.Trace(app.SetExposed())
+	}
+	return errors.Trace(app.ClearExposed())
+}
+
+// AddRelation is part of the Backend interface.
+func (b *backend) AddRelation(endpoints ...string) error {
+	eps, err := b.InferEndpoints(endpoints...)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = b.State.AddRelation(eps...)
+	return errors.Trace(err)
+}
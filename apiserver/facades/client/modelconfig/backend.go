@@ -19,8 +19,13 @@ type Backend interface {
 	ModelTag() names.ModelTag
 	ModelConfigValues() (config.ConfigValues, error)
 	UpdateModelConfig(map[string]interface{}, []string, ...state.ValidateConfigFunc) error
+	ValidateModelConfig(map[string]interface{}, []string, ...state.ValidateConfigFunc) (*config.Config, *config.Config, error)
+	UnknownModelConfigAttrs(*config.Config) ([]string, error)
 	SetSLA(level, owner string, credentials []byte) error
 	SLALevel() (string, error)
+	SetAgentLoggingOverride(state.AgentLoggingOverride) error
+	RemoveAgentLoggingOverride(names.Tag) error
+	AllAgentLoggingOverrides() ([]state.AgentLoggingOverride, error)
 }
 
 type stateShim struct {
@@ -32,6 +37,10 @@ func (st stateShim) UpdateModelConfig(u map[string]interface{}, r []string, a ..
 	return st.model.UpdateModelConfig(u, r, a...)
 }
 
+func (st stateShim) ValidateModelConfig(u map[string]interface{}, r []string, a ...state.ValidateConfigFunc) (*config.Config, *config.Config, error) {
+	return st.model.ValidateModelConfig(u, r, a...)
+}
+
 func (st stateShim) ModelConfigValues() (config.ConfigValues, error) {
 	return st.model.ModelConfigValues()
 }
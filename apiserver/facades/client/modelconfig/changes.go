@@ -0,0 +1,71 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelconfig
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
+)
+
+var proxyWorkers = []string{"proxy-config-updater"}
+
+// configWorkers maps model config attributes to the names of the
+// running workers that react to changes in them. Changes to other
+// attributes take effect as they are next used, typically when new
+// machines or units are created.
+var configWorkers = map[string][]string{
+	config.HTTPProxyKey:              proxyWorkers,
+	config.HTTPSProxyKey:             proxyWorkers,
+	config.FTPProxyKey:               proxyWorkers,
+	config.NoProxyKey:                proxyWorkers,
+	config.AptHTTPProxyKey:           proxyWorkers,
+	config.AptHTTPSProxyKey:          proxyWorkers,
+	config.AptFTPProxyKey:            proxyWorkers,
+	config.AptNoProxyKey:             proxyWorkers,
+	config.SnapHTTPProxyKey:          proxyWorkers,
+	config.SnapHTTPSProxyKey:         proxyWorkers,
+	config.SnapStoreProxyKey:         proxyWorkers,
+	"logging-config":                 {"logging-config-updater"},
+	config.AutomaticallyRetryHooks:   {"uniter"},
+	config.UpdateStatusHookInterval:  {"uniter"},
+	config.MaxStatusHistoryAge:       {"status-history-pruner"},
+	config.MaxStatusHistorySize:      {"status-history-pruner"},
+	config.MaxActionResultsAge:       {"action-pruner"},
+	config.MaxActionResultsSize:      {"action-pruner"},
+	config.ProvisionerHarvestModeKey: {"compute-provisioner"},
+	config.TransmitVendorMetricsKey:  {"metric-worker"},
+	config.ContainerImageCacheSize:   {"container-image-cache"},
+}
+
+// configChanges returns the changes between the old and new config
+// attribute values, ordered by attribute name.
+func configChanges(oldAttrs, newAttrs map[string]interface{}) []params.ModelConfigChange {
+	keys := make(map[string]bool)
+	for key := range oldAttrs {
+		keys[key] = true
+	}
+	for key := range newAttrs {
+		keys[key] = true
+	}
+	var changes []params.ModelConfigChange
+	for key := range keys {
+		oldValue, newValue := oldAttrs[key], newAttrs[key]
+		if reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+		changes = append(changes, params.ModelConfigChange{
+			Key:      key,
+			OldValue: oldValue,
+			NewValue: newValue,
+			Workers:  configWorkers[key],
+		})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+	return changes
+}
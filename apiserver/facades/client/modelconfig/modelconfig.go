@@ -4,8 +4,12 @@
 package modelconfig
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
@@ -15,6 +19,24 @@ import (
 	"github.com/juju/juju/state"
 )
 
+// NewFacadeV1 is used for API registration.
+func NewFacadeV1(st *state.State, resources facade.Resources, auth facade.Authorizer) (*ModelConfigAPIV1, error) {
+	api, err := NewFacadeV2(st, resources, auth)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ModelConfigAPIV1{api}, nil
+}
+
+// NewFacadeV2 is used for API registration.
+func NewFacadeV2(st *state.State, resources facade.Resources, auth facade.Authorizer) (*ModelConfigAPIV2, error) {
+	api, err := NewFacade(st, resources, auth)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ModelConfigAPIV2{api}, nil
+}
+
 // NewFacade is used for API registration.
 func NewFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*ModelConfigAPI, error) {
 	model, err := st.Model()
@@ -24,6 +46,18 @@ func NewFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*Mo
 	return NewModelConfigAPI(NewStateBackend(model), auth)
 }
 
+// ModelConfigAPIV1 implements the V1 model config facade, which has
+// no agent logging override methods.
+type ModelConfigAPIV1 struct {
+	*ModelConfigAPIV2
+}
+
+// ModelConfigAPIV2 implements the V2 model config facade, whose
+// ModelSet has no dry-run mode and accepts unknown attributes.
+type ModelConfigAPIV2 struct {
+	*ModelConfigAPI
+}
+
 // ModelConfigAPI is the endpoint which implements the model config facade.
 type ModelConfigAPI struct {
 	backend Backend
@@ -81,8 +115,28 @@ func (c *ModelConfigAPI) canReadModel() error {
 	return nil
 }
 
+// checkCanTrace returns an error if the logging config requests TRACE
+// level logging and the caller is not a controller admin.
+func (c *ModelConfigAPI) checkCanTrace(logCfg loggo.Config) error {
+	// Does at least one package have TRACE level logging requested.
+	haveTrace := false
+	for _, level := range logCfg {
+		haveTrace = level == loggo.TRACE
+		if haveTrace {
+			break
+		}
+	}
+	// No TRACE level requested, so no need to check for admin.
+	if !haveTrace {
+		return nil
+	}
+	return c.isControllerAdmin()
+}
+
 // ModelGet implements the server-side part of the
 // model-config CLI command.
+//
+//juju:readonly
 func (c *ModelConfigAPI) ModelGet() (params.ModelConfigResults, error) {
 	result := params.ModelConfigResults{}
 	if err := c.canReadModel(); err != nil {
@@ -112,13 +166,28 @@ func (c *ModelConfigAPI) ModelGet() (params.ModelConfigResults, error) {
 
 // ModelSet implements the server-side part of the
 // set-model-config CLI command.
-func (c *ModelConfigAPI) ModelSet(args params.ModelSet) error {
+func (c *ModelConfigAPIV2) ModelSet(args params.ModelSet) error {
+	args.DryRun = false
+	args.Force = true
+	_, err := c.ModelConfigAPI.ModelSet(args)
+	return err
+}
+
+// ModelSet implements the server-side part of the
+// set-model-config CLI command. It returns the changes made to the
+// model's config or, if args.DryRun is set, the changes that would
+// be made. Attributes unknown to Juju and the model's cloud provider
+// are rejected unless args.Force is set.
+func (c *ModelConfigAPI) ModelSet(args params.ModelSet) (params.ModelSetResult, error) {
 	if err := c.checkCanWrite(); err != nil {
-		return err
+		return params.ModelSetResult{}, err
 	}
 
-	if err := c.check.ChangeAllowed(); err != nil {
-		return errors.Trace(err)
+	// A dry run changes nothing, so is not subject to blocks.
+	if !args.DryRun {
+		if err := c.check.ChangeAllowed(); err != nil {
+			return params.ModelSetResult{}, errors.Trace(err)
+		}
 	}
 	// Make sure we don't allow changing agent-version.
 	checkAgentVersion := func(updateAttrs map[string]interface{}, removeAttrs []string, oldConfig *config.Config) error {
@@ -140,19 +209,7 @@ func (c *ModelConfigAPI) ModelSet(args params.ModelSet) error {
 		if err != nil {
 			return errors.Trace(err)
 		}
-		// Does at least one package have TRACE level logging requested.
-		haveTrace := false
-		for _, level := range logCfg {
-			haveTrace = level == loggo.TRACE
-			if haveTrace {
-				break
-			}
-		}
-		// No TRACE level requested, so no need to check for admin.
-		if !haveTrace {
-			return nil
-		}
-		if err := c.isControllerAdmin(); err != nil {
+		if err := c.checkCanTrace(logCfg); err != nil {
 			if errors.Cause(err) != common.ErrPerm {
 				return errors.Trace(err)
 			}
@@ -163,7 +220,47 @@ func (c *ModelConfigAPI) ModelSet(args params.ModelSet) error {
 
 	// Replace any deprecated attributes with their new values.
 	attrs := config.ProcessDeprecatedAttributes(args.Config)
-	return c.backend.UpdateModelConfig(attrs, nil, checkAgentVersion, checkLogTrace)
+	oldConfig, newConfig, err := c.backend.ValidateModelConfig(attrs, nil, checkAgentVersion, checkLogTrace)
+	if err != nil {
+		return params.ModelSetResult{}, errors.Trace(err)
+	}
+	if !args.Force {
+		if err := c.checkKnownAttrs(attrs, newConfig); err != nil {
+			return params.ModelSetResult{}, errors.Trace(err)
+		}
+	}
+	result := params.ModelSetResult{
+		Changes: configChanges(oldConfig.AllAttrs(), newConfig.AllAttrs()),
+	}
+	if args.DryRun {
+		return result, nil
+	}
+	if err := c.backend.UpdateModelConfig(attrs, nil, checkAgentVersion, checkLogTrace); err != nil {
+		return params.ModelSetResult{}, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// checkKnownAttrs returns an error if any of the attributes being set
+// are defined neither by Juju nor by the model's cloud provider.
+func (c *ModelConfigAPI) checkKnownAttrs(attrs map[string]interface{}, cfg *config.Config) error {
+	unknown, err := c.backend.UnknownModelConfigAttrs(cfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var rejected []string
+	for _, attr := range unknown {
+		if _, ok := attrs[attr]; ok {
+			rejected = append(rejected, fmt.Sprintf("%q", attr))
+		}
+	}
+	if len(rejected) > 0 {
+		return errors.NewNotValid(nil, fmt.Sprintf(
+			"unknown model config attributes %s; use force to set them anyway",
+			strings.Join(rejected, ", "),
+		))
+	}
+	return nil
 }
 
 // ModelUnset implements the server-side part of the
@@ -188,6 +285,8 @@ func (c *ModelConfigAPI) SetSLALevel(args params.ModelSLA) error {
 }
 
 // SLALevel returns the current sla level for the model.
+//
+//juju:readonly
 func (c *ModelConfigAPI) SLALevel() (params.StringResult, error) {
 	result := params.StringResult{}
 	level, err := c.backend.SLALevel()
@@ -197,3 +296,99 @@ func (c *ModelConfigAPI) SLALevel() (params.StringResult, error) {
 	result.Result = level
 	return result, nil
 }
+
+// SetAgentLoggingOverrides isn't on the V1 API.
+func (c *ModelConfigAPIV1) SetAgentLoggingOverrides(_, _ struct{}) {}
+
+// RemoveAgentLoggingOverrides isn't on the V1 API.
+func (c *ModelConfigAPIV1) RemoveAgentLoggingOverrides(_, _ struct{}) {}
+
+// AgentLoggingOverrides isn't on the V1 API.
+//
+//juju:readonly
+func (c *ModelConfigAPIV1) AgentLoggingOverrides(_, _ struct{}) {}
+
+// SetAgentLoggingOverrides sets logging config to be applied to
+// individual machine or unit agents, on top of the model's
+// logging-config, until the override expires. Only controller admins
+// may request TRACE level logging.
+func (c *ModelConfigAPI) SetAgentLoggingOverrides(args params.AgentLoggingOverrides) (params.ErrorResults, error) {
+	if err := c.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, err
+	}
+	if err := c.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := make([]params.ErrorResult, len(args.Overrides))
+	for i, arg := range args.Overrides {
+		results[i].Error = common.ServerError(c.setAgentLoggingOverride(arg))
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func (c *ModelConfigAPI) setAgentLoggingOverride(arg params.AgentLoggingOverride) error {
+	tag, err := names.ParseTag(arg.Tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	logCfg, err := loggo.ParseConfigString(arg.Config)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.checkCanTrace(logCfg); err != nil {
+		if errors.Cause(err) != common.ErrPerm {
+			return errors.Trace(err)
+		}
+		return errors.New("only controller admins can set an agent's logging level to TRACE")
+	}
+	return c.backend.SetAgentLoggingOverride(state.AgentLoggingOverride{
+		Tag:     tag,
+		Config:  arg.Config,
+		Expires: arg.Expires,
+	})
+}
+
+// RemoveAgentLoggingOverrides removes any logging overrides for the
+// specified agents.
+func (c *ModelConfigAPI) RemoveAgentLoggingOverrides(args params.Entities) (params.ErrorResults, error) {
+	if err := c.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, err
+	}
+	if err := c.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := make([]params.ErrorResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		tag, err := names.ParseTag(entity.Tag)
+		if err == nil {
+			err = c.backend.RemoveAgentLoggingOverride(tag)
+		}
+		results[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+// AgentLoggingOverrides returns all of the model's agent logging
+// overrides, including those that have expired.
+//
+//juju:readonly
+func (c *ModelConfigAPI) AgentLoggingOverrides() (params.AgentLoggingOverrides, error) {
+	if err := c.canReadModel(); err != nil {
+		return params.AgentLoggingOverrides{}, errors.Trace(err)
+	}
+	overrides, err := c.backend.AllAgentLoggingOverrides()
+	if err != nil {
+		return params.AgentLoggingOverrides{}, errors.Trace(err)
+	}
+	result := params.AgentLoggingOverrides{
+		Overrides: make([]params.AgentLoggingOverride, len(overrides)),
+	}
+	for i, override := range overrides {
+		result.Overrides[i] = params.AgentLoggingOverride{
+			Tag:     override.Tag.String(),
+			Config:  override.Config,
+			Expires: override.Expires,
+		}
+	}
+	return result, nil
+}
@@ -4,6 +4,9 @@
 package modelconfig_test
 
 import (
+	"sort"
+	"time"
+
 	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -74,13 +77,107 @@ func (s *modelconfigSuite) TestModelSet(c *gc.C) {
 		Config: map[string]interface{}{
 			"some-key":  "value",
 			"other-key": "other value"},
+		Force: true,
 	}
-	err := s.api.ModelSet(params)
+	_, err := s.api.ModelSet(params)
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertConfigValue(c, "some-key", "value")
 	s.assertConfigValue(c, "other-key", "other value")
 }
 
+func (s *modelconfigSuite) setOldConfig(c *gc.C) {
+	old, err := config.New(config.UseDefaults, dummy.SampleConfig().Merge(testing.Attrs{
+		"ftp-proxy":      "http://proxy",
+		"logging-config": "<root>=WARNING",
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.old = old
+}
+
+func (s *modelconfigSuite) TestModelSetReturnsChanges(c *gc.C) {
+	s.setOldConfig(c)
+	result, err := s.api.ModelSet(params.ModelSet{
+		Config: map[string]interface{}{
+			"ftp-proxy":      "http://new-proxy",
+			"logging-config": "<root>=INFO",
+			"firewall-mode":  config.FwInstance,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Changes, jc.DeepEquals, []params.ModelConfigChange{{
+		Key:      "ftp-proxy",
+		OldValue: "http://proxy",
+		NewValue: "http://new-proxy",
+		Workers:  []string{"proxy-config-updater"},
+	}, {
+		Key:      "logging-config",
+		OldValue: "<root>=WARNING",
+		NewValue: "<root>=INFO",
+		Workers:  []string{"logging-config-updater"},
+	}})
+	s.assertConfigValue(c, "ftp-proxy", "http://new-proxy")
+}
+
+func (s *modelconfigSuite) TestModelSetDryRun(c *gc.C) {
+	s.setOldConfig(c)
+	result, err := s.api.ModelSet(params.ModelSet{
+		Config: map[string]interface{}{"ftp-proxy": "http://new-proxy"},
+		DryRun: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Changes, jc.DeepEquals, []params.ModelConfigChange{{
+		Key:      "ftp-proxy",
+		OldValue: "http://proxy",
+		NewValue: "http://new-proxy",
+		Workers:  []string{"proxy-config-updater"},
+	}})
+	s.assertConfigValue(c, "ftp-proxy", "http://proxy")
+}
+
+func (s *modelconfigSuite) TestModelSetDryRunInvalid(c *gc.C) {
+	_, err := s.api.ModelSet(params.ModelSet{
+		Config: map[string]interface{}{"logging-config": "<root>=WHAT"},
+		DryRun: true,
+	})
+	c.Assert(err, gc.ErrorMatches, `.*unknown severity level "WHAT"`)
+}
+
+func (s *modelconfigSuite) TestModelSetDryRunNotBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestModelSetDryRunNotBlocked")
+	result, err := s.api.ModelSet(params.ModelSet{
+		Config: map[string]interface{}{"ftp-proxy": "http://new-proxy"},
+		DryRun: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Changes, gc.HasLen, 1)
+}
+
+func (s *modelconfigSuite) TestModelSetUnknownAttrs(c *gc.C) {
+	_, err := s.api.ModelSet(params.ModelSet{
+		Config: map[string]interface{}{
+			"ftp-proxy": "http://new-proxy",
+			"some-key":  "value",
+			"other-key": "other value",
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `unknown model config attributes "other-key", "some-key"; use force to set them anyway`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotValid)
+	s.assertConfigValue(c, "ftp-proxy", "http://proxy")
+	s.assertConfigValueMissing(c, "some-key")
+}
+
+func (s *modelconfigSuite) TestModelSetV2AllowsUnknownAttrs(c *gc.C) {
+	api := &modelconfig.ModelConfigAPIV2{ModelConfigAPI: s.api}
+	// Older clients cannot ask for a dry run, so V2 always applies
+	// the change.
+	err := api.ModelSet(params.ModelSet{
+		Config: map[string]interface{}{"some-key": "value"},
+		DryRun: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertConfigValue(c, "some-key", "value")
+}
+
 func (s *modelconfigSuite) blockAllChanges(c *gc.C, msg string) {
 	s.backend.msg = msg
 	s.backend.b = state.ChangeBlock
@@ -95,7 +192,7 @@ func (s *modelconfigSuite) assertBlocked(c *gc.C, err error, msg string) {
 }
 
 func (s *modelconfigSuite) assertModelSetBlocked(c *gc.C, args map[string]interface{}, msg string) {
-	err := s.api.ModelSet(params.ModelSet{args})
+	_, err := s.api.ModelSet(params.ModelSet{Config: args, Force: true})
 	s.assertBlocked(c, err, msg)
 }
 
@@ -112,9 +209,9 @@ func (s *modelconfigSuite) TestModelSetCannotChangeAgentVersion(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	s.backend.old = old
 	args := params.ModelSet{
-		map[string]interface{}{"agent-version": "9.9.9"},
+		Config: map[string]interface{}{"agent-version": "9.9.9"},
 	}
-	err = s.api.ModelSet(args)
+	_, err = s.api.ModelSet(args)
 	c.Assert(err, gc.ErrorMatches, "agent-version cannot be changed")
 
 	// It's okay to pass config back with the same agent-version.
@@ -122,15 +219,15 @@ func (s *modelconfigSuite) TestModelSetCannotChangeAgentVersion(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(result.Config["agent-version"], gc.NotNil)
 	args.Config["agent-version"] = result.Config["agent-version"].Value
-	err = s.api.ModelSet(args)
+	_, err = s.api.ModelSet(args)
 	c.Assert(err, jc.ErrorIsNil)
 }
 
 func (s *modelconfigSuite) TestAdminCanSetLogTrace(c *gc.C) {
 	args := params.ModelSet{
-		map[string]interface{}{"logging-config": "<root>=DEBUG;somepackage=TRACE"},
+		Config: map[string]interface{}{"logging-config": "<root>=DEBUG;somepackage=TRACE"},
 	}
-	err := s.api.ModelSet(args)
+	_, err := s.api.ModelSet(args)
 	c.Assert(err, jc.ErrorIsNil)
 
 	result, err := s.api.ModelGet()
@@ -140,12 +237,12 @@ func (s *modelconfigSuite) TestAdminCanSetLogTrace(c *gc.C) {
 
 func (s *modelconfigSuite) TestUserCanSetLogNoTrace(c *gc.C) {
 	args := params.ModelSet{
-		map[string]interface{}{"logging-config": "<root>=DEBUG;somepackage=ERROR"},
+		Config: map[string]interface{}{"logging-config": "<root>=DEBUG;somepackage=ERROR"},
 	}
 	apiUser := names.NewUserTag("fred")
 	s.authorizer.Tag = apiUser
 	s.authorizer.HasWriteTag = apiUser
-	err := s.api.ModelSet(args)
+	_, err := s.api.ModelSet(args)
 	c.Assert(err, jc.ErrorIsNil)
 
 	result, err := s.api.ModelGet()
@@ -160,18 +257,18 @@ func (s *modelconfigSuite) TestUserReadAccess(c *gc.C) {
 	_, err := s.api.ModelGet()
 	c.Assert(err, jc.ErrorIsNil)
 
-	err = s.api.ModelSet(params.ModelSet{})
+	_, err = s.api.ModelSet(params.ModelSet{})
 	c.Assert(errors.Cause(err), gc.ErrorMatches, "permission denied")
 }
 
 func (s *modelconfigSuite) TestUserCannotSetLogTrace(c *gc.C) {
 	args := params.ModelSet{
-		map[string]interface{}{"logging-config": "<root>=DEBUG;somepackage=TRACE"},
+		Config: map[string]interface{}{"logging-config": "<root>=DEBUG;somepackage=TRACE"},
 	}
 	apiUser := names.NewUserTag("fred")
 	s.authorizer.Tag = apiUser
 	s.authorizer.HasWriteTag = apiUser
-	err := s.api.ModelSet(args)
+	_, err := s.api.ModelSet(args)
 	c.Assert(err, gc.ErrorMatches, `only controller admins can set a model's logging level to TRACE`)
 }
 
@@ -207,11 +304,98 @@ func (s *modelconfigSuite) TestSetSupportCredentals(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *modelconfigSuite) TestSetAgentLoggingOverrides(c *gc.C) {
+	expires := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	result, err := s.api.SetAgentLoggingOverrides(params.AgentLoggingOverrides{
+		Overrides: []params.AgentLoggingOverride{{
+			Tag:     "unit-mysql-0",
+			Config:  "juju.worker.uniter=TRACE",
+			Expires: expires,
+		}, {
+			Tag:    "bad-tag",
+			Config: "juju=DEBUG",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `"bad-tag" is not a valid tag`)
+	c.Assert(s.backend.overrides, jc.DeepEquals, []state.AgentLoggingOverride{{
+		Tag:     names.NewUnitTag("mysql/0"),
+		Config:  "juju.worker.uniter=TRACE",
+		Expires: expires,
+	}})
+}
+
+func (s *modelconfigSuite) TestUserCannotSetAgentLoggingOverrideTrace(c *gc.C) {
+	apiUser := names.NewUserTag("fred")
+	s.authorizer.Tag = apiUser
+	s.authorizer.HasWriteTag = apiUser
+	result, err := s.api.SetAgentLoggingOverrides(params.AgentLoggingOverrides{
+		Overrides: []params.AgentLoggingOverride{{
+			Tag:     "unit-mysql-0",
+			Config:  "juju.worker.uniter=TRACE",
+			Expires: time.Now(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, `only controller admins can set an agent's logging level to TRACE`)
+	c.Assert(s.backend.overrides, gc.HasLen, 0)
+}
+
+func (s *modelconfigSuite) TestUserReadAccessCannotSetAgentLoggingOverrides(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	_, err := s.api.SetAgentLoggingOverrides(params.AgentLoggingOverrides{})
+	c.Assert(errors.Cause(err), gc.ErrorMatches, "permission denied")
+	_, err = s.api.RemoveAgentLoggingOverrides(params.Entities{})
+	c.Assert(errors.Cause(err), gc.ErrorMatches, "permission denied")
+	_, err = s.api.AgentLoggingOverrides()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *modelconfigSuite) TestBlockSetAgentLoggingOverrides(c *gc.C) {
+	s.blockAllChanges(c, "TestBlockSetAgentLoggingOverrides")
+	_, err := s.api.SetAgentLoggingOverrides(params.AgentLoggingOverrides{})
+	s.assertBlocked(c, err, "TestBlockSetAgentLoggingOverrides")
+}
+
+func (s *modelconfigSuite) TestRemoveAgentLoggingOverrides(c *gc.C) {
+	s.backend.overrides = []state.AgentLoggingOverride{{
+		Tag:    names.NewMachineTag("0"),
+		Config: "juju=DEBUG",
+	}}
+	result, err := s.api.RemoveAgentLoggingOverrides(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	c.Assert(s.backend.overrides, gc.HasLen, 0)
+}
+
+func (s *modelconfigSuite) TestAgentLoggingOverrides(c *gc.C) {
+	expires := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	s.backend.overrides = []state.AgentLoggingOverride{{
+		Tag:     names.NewMachineTag("0"),
+		Config:  "juju=DEBUG",
+		Expires: expires,
+	}}
+	result, err := s.api.AgentLoggingOverrides()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.AgentLoggingOverrides{
+		Overrides: []params.AgentLoggingOverride{{
+			Tag:     "machine-0",
+			Config:  "juju=DEBUG",
+			Expires: expires,
+		}},
+	})
+}
+
 type mockBackend struct {
-	cfg config.ConfigValues
-	old *config.Config
-	b   state.BlockType
-	msg string
+	cfg       config.ConfigValues
+	old       *config.Config
+	b         state.BlockType
+	msg       string
+	overrides []state.AgentLoggingOverride
 }
 
 func (m *mockBackend) ModelConfigValues() (config.ConfigValues, error) {
@@ -233,6 +417,42 @@ func (m *mockBackend) UpdateModelConfig(update map[string]interface{}, remove []
 	return nil
 }
 
+func (m *mockBackend) ValidateModelConfig(update map[string]interface{}, remove []string, validate ...state.ValidateConfigFunc) (*config.Config, *config.Config, error) {
+	oldConfig := m.old
+	if oldConfig == nil {
+		var err error
+		oldConfig, err = config.New(config.UseDefaults, dummy.SampleConfig())
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	for _, validateFunc := range validate {
+		if err := validateFunc(update, remove, oldConfig); err != nil {
+			return nil, nil, err
+		}
+	}
+	newConfig, err := oldConfig.Apply(update)
+	if err != nil {
+		return nil, nil, err
+	}
+	newConfig, err = newConfig.Remove(remove)
+	if err != nil {
+		return nil, nil, err
+	}
+	return oldConfig, newConfig, nil
+}
+
+func (m *mockBackend) UnknownModelConfigAttrs(cfg *config.Config) ([]string, error) {
+	var unknown []string
+	for attr := range cfg.UnknownAttrs() {
+		if _, ok := dummy.SampleConfig()[attr]; !ok {
+			unknown = append(unknown, attr)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown, nil
+}
+
 func (m *mockBackend) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
 	if m.b == t {
 		return &mockBlock{t: t, m: m.msg}, true, nil
@@ -257,6 +477,26 @@ func (m *mockBackend) SLALevel() (string, error) {
 	return "mock-level", nil
 }
 
+func (m *mockBackend) SetAgentLoggingOverride(override state.AgentLoggingOverride) error {
+	m.overrides = append(m.overrides, override)
+	return nil
+}
+
+func (m *mockBackend) RemoveAgentLoggingOverride(tag names.Tag) error {
+	var remaining []state.AgentLoggingOverride
+	for _, override := range m.overrides {
+		if override.Tag != tag {
+			remaining = append(remaining, override)
+		}
+	}
+	m.overrides = remaining
+	return nil
+}
+
+func (m *mockBackend) AllAgentLoggingOverrides() ([]state.AgentLoggingOverride, error) {
+	return m.overrides, nil
+}
+
 type mockBlock struct {
 	state.Block
 	t state.BlockType
@@ -0,0 +1,127 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelevents implements the ModelEvents facade, which
+// provides access to a model's activity feed: the units added, hooks
+// failed, relations created and other high-level changes made to the
+// model, and who made them.
+package modelevents
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+const (
+	// defaultLimit is the number of events returned if the client
+	// does not specify a limit.
+	defaultLimit = 100
+
+	// maxLimit is the most events returned in a single call.
+	maxLimit = 1000
+)
+
+// Backend defines the state functionality required by the
+// ModelEvents facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+	ModelEvents(state.ModelEventsFilter) ([]state.ModelEvent, error)
+	WatchModelEvents() state.NotifyWatcher
+}
+
+// API implements the ModelEvents facade.
+type API struct {
+	backend    Backend
+	resources  facade.Resources
+	authorizer facade.Authorizer
+}
+
+// NewAPI returns a new ModelEvents facade.
+func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		resources:  resources,
+		authorizer: authorizer,
+	}, nil
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(st *state.State, resources facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(st, resources, auth)
+}
+
+func (api *API) checkCanRead() error {
+	ok, err := api.authorizer.HasPermission(permission.ReadAccess, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// Events returns a page of the model's events, oldest first. At most
+// 1000 events are returned by each call.
+//
+//juju:readonly
+func (api *API) Events(args params.ModelEventsArgs) (params.ModelEventsResult, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.ModelEventsResult{}, errors.Trace(err)
+	}
+	limit := args.Limit
+	if limit == 0 {
+		limit = defaultLimit
+	} else if limit > maxLimit {
+		limit = maxLimit
+	}
+	events, err := api.backend.ModelEvents(state.ModelEventsFilter{
+		After:  args.After,
+		Before: args.Before,
+		Limit:  limit,
+	})
+	if err != nil {
+		return params.ModelEventsResult{}, errors.Trace(err)
+	}
+	result := params.ModelEventsResult{
+		Events: make([]params.ModelEvent, len(events)),
+	}
+	for i, event := range events {
+		result.Events[i] = params.ModelEvent{
+			Seq:      event.Seq,
+			Time:     event.Time,
+			Kind:     string(event.Kind),
+			Actor:    event.Actor,
+			Entities: event.Entities,
+			Message:  event.Message,
+		}
+	}
+	return result, nil
+}
+
+// WatchEvents returns a NotifyWatcher that notifies when events are
+// recorded in the model's activity feed, after which the client should
+// call Events to get them.
+//
+//juju:readonly
+func (api *API) WatchEvents() (params.NotifyWatchResult, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.NotifyWatchResult{}, errors.Trace(err)
+	}
+	w := api.backend.WatchModelEvents()
+	// Consume the initial event.
+	if _, ok := <-w.Changes(); !ok {
+		return params.NotifyWatchResult{}, watcher.EnsureErr(w)
+	}
+	return params.NotifyWatchResult{NotifyWatcherId: api.resources.Register(w)}, nil
+}
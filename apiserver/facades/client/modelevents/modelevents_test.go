@@ -0,0 +1,128 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelevents_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/modelevents"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type modelEventsSuite struct {
+	testing.IsolationSuite
+	backend   *mockBackend
+	resources *common.Resources
+}
+
+var _ = gc.Suite(&modelEventsSuite{})
+
+func (s *modelEventsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{changes: make(chan struct{}, 1)}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+}
+
+func (s *modelEventsSuite) newAPI(c *gc.C, user string) *modelevents.API {
+	api, err := modelevents.NewAPI(s.backend, s.resources, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag(user),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *modelEventsSuite) TestRequiresClient(c *gc.C) {
+	_, err := modelevents.NewAPI(s.backend, s.resources, apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelEventsSuite) TestEvents(c *gc.C) {
+	now := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	s.backend.events = []state.ModelEvent{{
+		Seq:      7,
+		Time:     now,
+		Kind:     state.EventHookFailed,
+		Actor:    "unit-mysql-0",
+		Entities: []string{"unit-mysql-0", "application-mysql"},
+		Message:  `hook failed: "install"`,
+	}}
+	result, err := s.newAPI(c, "read").Events(params.ModelEventsArgs{After: 6, Limit: 10})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelEventsResult{
+		Events: []params.ModelEvent{{
+			Seq:      7,
+			Time:     now,
+			Kind:     "hook-failed",
+			Actor:    "unit-mysql-0",
+			Entities: []string{"unit-mysql-0", "application-mysql"},
+			Message:  `hook failed: "install"`,
+		}},
+	})
+	s.backend.CheckCall(c, 0, "ModelEvents", state.ModelEventsFilter{After: 6, Limit: 10})
+}
+
+func (s *modelEventsSuite) TestEventsLimits(c *gc.C) {
+	api := s.newAPI(c, "read")
+	_, err := api.Events(params.ModelEventsArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.Events(params.ModelEventsArgs{Before: 10, Limit: 5000})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"ModelEvents", []interface{}{state.ModelEventsFilter{Limit: 100}}},
+		{"ModelEvents", []interface{}{state.ModelEventsFilter{Before: 10, Limit: 1000}}},
+	})
+}
+
+func (s *modelEventsSuite) TestEventsPermissionDenied(c *gc.C) {
+	_, err := s.newAPI(c, "bob").Events(params.ModelEventsArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *modelEventsSuite) TestWatchEvents(c *gc.C) {
+	s.backend.changes <- struct{}{}
+	result, err := s.newAPI(c, "read").WatchEvents()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.NotifyWatcherId, gc.Equals, "1")
+	c.Assert(s.resources.Get("1"), gc.NotNil)
+}
+
+func (s *modelEventsSuite) TestWatchEventsPermissionDenied(c *gc.C) {
+	_, err := s.newAPI(c, "bob").WatchEvents()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+}
+
+type mockBackend struct {
+	testing.Stub
+	events  []state.ModelEvent
+	changes chan struct{}
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) ModelEvents(filter state.ModelEventsFilter) ([]state.ModelEvent, error) {
+	b.MethodCall(b, "ModelEvents", filter)
+	return b.events, b.NextErr()
+}
+
+func (b *mockBackend) WatchModelEvents() state.NotifyWatcher {
+	b.MethodCall(b, "WatchModelEvents")
+	return statetesting.NewMockNotifyWatcher(b.changes)
+}
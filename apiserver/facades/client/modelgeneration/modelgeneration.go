@@ -0,0 +1,192 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelgeneration implements the ModelGeneration facade, which
+// manages a model's branch: configuration changes that are seen only
+// by the units tracking the branch until it is committed.
+package modelgeneration
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+)
+
+// Backend defines the state functionality required by the
+// ModelGeneration facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+	AddBranch(name, createdBy string) error
+	Branch() (Branch, error)
+
+	// CharmConfig returns the configuration schema of the
+	// application's charm.
+	CharmConfig(appName string) (*charm.Config, error)
+}
+
+// Branch defines the state functionality required of a model's
+// branch by the ModelGeneration facade.
+type Branch interface {
+	Name() string
+	AssignUnit(unitName string) error
+	AssignAllUnits(appName string) error
+	UpdateCharmConfig(appName string, changes charm.Settings) error
+	Commit() error
+	Abort() error
+}
+
+// BlockChecker checks for blocks on model changes.
+type BlockChecker interface {
+	ChangeAllowed() error
+}
+
+// API implements the ModelGeneration facade.
+type API struct {
+	backend    Backend
+	check      BlockChecker
+	authorizer facade.Authorizer
+}
+
+// NewAPI returns a new ModelGeneration facade.
+func NewAPI(backend Backend, check BlockChecker, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		check:      check,
+		authorizer: authorizer,
+	}, nil
+}
+
+// checkCanWrite checks that the user may change the model, and that
+// changes are not blocked.
+func (api *API) checkCanWrite() error {
+	ok, err := api.authorizer.HasPermission(permission.WriteAccess, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+	return errors.Trace(api.check.ChangeAllowed())
+}
+
+// branch returns the model's branch if it has the given name.
+func (api *API) branch(name string) (Branch, error) {
+	branch, err := api.backend.Branch()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if branch.Name() != name {
+		return nil, errors.NotFoundf("branch %q", name)
+	}
+	return branch, nil
+}
+
+// AddBranch adds a branch with the given name to the model. A model
+// has at most one branch at a time.
+func (api *API) AddBranch(arg params.BranchArg) (params.ErrorResult, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResult{}, errors.Trace(err)
+	}
+	err := api.backend.AddBranch(arg.BranchName, api.authorizer.GetAuthTag().Id())
+	return params.ErrorResult{Error: common.ServerError(err)}, nil
+}
+
+// TrackBranch makes the given units, or all of the units of the given
+// applications, track the branch.
+func (api *API) TrackBranch(arg params.BranchTrackArg) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(arg.Entities)),
+	}
+	branch, err := api.branch(arg.BranchName)
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	for i, entity := range arg.Entities {
+		results.Results[i].Error = common.ServerError(trackBranch(branch, entity.Tag))
+	}
+	return results, nil
+}
+
+func trackBranch(branch Branch, tagString string) error {
+	tag, err := names.ParseTag(tagString)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch tag := tag.(type) {
+	case names.UnitTag:
+		return branch.AssignUnit(tag.Id())
+	case names.ApplicationTag:
+		return branch.AssignAllUnits(tag.Id())
+	}
+	return errors.NotValidf("tag %q", tagString)
+}
+
+// SetBranchConfig changes an application's charm configuration on the
+// branch. Unset options are reset to the charm's defaults for units
+// tracking the branch.
+func (api *API) SetBranchConfig(arg params.BranchConfigArg) (params.ErrorResult, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResult{}, errors.Trace(err)
+	}
+	err := api.setBranchConfig(arg)
+	return params.ErrorResult{Error: common.ServerError(err)}, nil
+}
+
+func (api *API) setBranchConfig(arg params.BranchConfigArg) error {
+	branch, err := api.branch(arg.BranchName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	config, err := api.backend.CharmConfig(arg.ApplicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	changes, err := config.ParseSettingsStrings(arg.Options)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, name := range arg.Unset {
+		changes[name] = nil
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	return branch.UpdateCharmConfig(arg.ApplicationName, changes)
+}
+
+// CommitBranch applies the configuration changes made on the branch to
+// all units, and removes the branch.
+func (api *API) CommitBranch(arg params.BranchArg) (params.ErrorResult, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResult{}, errors.Trace(err)
+	}
+	branch, err := api.branch(arg.BranchName)
+	if err == nil {
+		err = branch.Commit()
+	}
+	return params.ErrorResult{Error: common.ServerError(err)}, nil
+}
+
+// AbortBranch removes the branch, discarding the configuration changes
+// made on it.
+func (api *API) AbortBranch(arg params.BranchArg) (params.ErrorResult, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResult{}, errors.Trace(err)
+	}
+	branch, err := api.branch(arg.BranchName)
+	if err == nil {
+		err = branch.Abort()
+	}
+	return params.ErrorResult{Error: common.ServerError(err)}, nil
+}
@@ -0,0 +1,245 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelgeneration_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/modelgeneration"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type modelGenerationSuite struct {
+	testing.IsolationSuite
+	backend *mockBackend
+	branch  *mockBranch
+	blocks  mockBlockChecker
+	api     *modelgeneration.API
+}
+
+var _ = gc.Suite(&modelGenerationSuite{})
+
+func (s *modelGenerationSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.branch = &mockBranch{name: "canary"}
+	s.backend = &mockBackend{branch: s.branch}
+	s.blocks = mockBlockChecker{}
+	s.api = s.newAPI(c, "admin")
+}
+
+func (s *modelGenerationSuite) newAPI(c *gc.C, user string) *modelgeneration.API {
+	api, err := modelgeneration.NewAPI(s.backend, &s.blocks, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag(user),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *modelGenerationSuite) TestNewAPIRequiresClient(c *gc.C) {
+	_, err := modelgeneration.NewAPI(s.backend, &s.blocks, apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelGenerationSuite) TestAddBranch(c *gc.C) {
+	result, err := s.api.AddBranch(params.BranchArg{BranchName: "canary"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"AddBranch", []interface{}{"canary", "admin"}},
+	})
+	s.blocks.CheckCallNames(c, "ChangeAllowed")
+}
+
+func (s *modelGenerationSuite) TestAddBranchError(c *gc.C) {
+	s.backend.SetErrors(errors.AlreadyExistsf("branch %q", "canary"))
+	result, err := s.api.AddBranch(params.BranchArg{BranchName: "other"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, jc.Satisfies, params.IsCodeAlreadyExists)
+}
+
+func (s *modelGenerationSuite) TestAddBranchPermissionDenied(c *gc.C) {
+	_, err := s.newAPI(c, "read").AddBranch(params.BranchArg{BranchName: "canary"})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *modelGenerationSuite) TestAddBranchBlocked(c *gc.C) {
+	s.blocks.SetErrors(errors.New("blocked"))
+	_, err := s.api.AddBranch(params.BranchArg{BranchName: "canary"})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *modelGenerationSuite) TestTrackBranch(c *gc.C) {
+	results, err := s.api.TrackBranch(params.BranchTrackArg{
+		BranchName: "canary",
+		Entities: []params.Entity{
+			{Tag: "unit-wordpress-0"},
+			{Tag: "application-mysql"},
+			{Tag: "machine-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.IsNil)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `tag "machine-0" not valid`)
+	s.branch.CheckCalls(c, []testing.StubCall{
+		{"AssignUnit", []interface{}{"wordpress/0"}},
+		{"AssignAllUnits", []interface{}{"mysql"}},
+	})
+}
+
+func (s *modelGenerationSuite) TestTrackBranchWrongName(c *gc.C) {
+	_, err := s.api.TrackBranch(params.BranchTrackArg{
+		BranchName: "other",
+		Entities:   []params.Entity{{Tag: "unit-wordpress-0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `branch "other" not found`)
+	s.branch.CheckNoCalls(c)
+}
+
+func (s *modelGenerationSuite) TestSetBranchConfig(c *gc.C) {
+	result, err := s.api.SetBranchConfig(params.BranchConfigArg{
+		BranchName:      "canary",
+		ApplicationName: "wordpress",
+		Options:         map[string]string{"blog-title": "canary title"},
+		Unset:           []string{"skill-level"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	s.backend.CheckCallNames(c, "Branch", "CharmConfig")
+	s.branch.CheckCalls(c, []testing.StubCall{
+		{"UpdateCharmConfig", []interface{}{"wordpress", charm.Settings{
+			"blog-title":  "canary title",
+			"skill-level": nil,
+		}}},
+	})
+}
+
+func (s *modelGenerationSuite) TestSetBranchConfigInvalid(c *gc.C) {
+	result, err := s.api.SetBranchConfig(params.BranchConfigArg{
+		BranchName:      "canary",
+		ApplicationName: "wordpress",
+		Options:         map[string]string{"skill-level": "lots"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `.*"skill-level".*`)
+	s.branch.CheckNoCalls(c)
+}
+
+func (s *modelGenerationSuite) TestCommitBranch(c *gc.C) {
+	result, err := s.api.CommitBranch(params.BranchArg{BranchName: "canary"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	s.branch.CheckCallNames(c, "Commit")
+}
+
+func (s *modelGenerationSuite) TestCommitBranchNotFound(c *gc.C) {
+	s.backend.SetErrors(errors.NotFoundf("branch"))
+	result, err := s.api.CommitBranch(params.BranchArg{BranchName: "canary"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, jc.Satisfies, params.IsCodeNotFound)
+	s.branch.CheckNoCalls(c)
+}
+
+func (s *modelGenerationSuite) TestAbortBranch(c *gc.C) {
+	result, err := s.api.AbortBranch(params.BranchArg{BranchName: "canary"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	s.branch.CheckCallNames(c, "Abort")
+}
+
+func (s *modelGenerationSuite) TestAbortBranchPermissionDenied(c *gc.C) {
+	_, err := s.newAPI(c, "read").AbortBranch(params.BranchArg{BranchName: "canary"})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.branch.CheckNoCalls(c)
+}
+
+type mockBackend struct {
+	testing.Stub
+	branch *mockBranch
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) AddBranch(name, createdBy string) error {
+	b.MethodCall(b, "AddBranch", name, createdBy)
+	return b.NextErr()
+}
+
+func (b *mockBackend) Branch() (modelgeneration.Branch, error) {
+	b.MethodCall(b, "Branch")
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	return b.branch, nil
+}
+
+func (b *mockBackend) CharmConfig(appName string) (*charm.Config, error) {
+	b.MethodCall(b, "CharmConfig", appName)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	return &charm.Config{
+		Options: map[string]charm.Option{
+			"blog-title":  {Type: "string"},
+			"skill-level": {Type: "int"},
+		},
+	}, nil
+}
+
+type mockBranch struct {
+	testing.Stub
+	name string
+}
+
+func (b *mockBranch) Name() string {
+	return b.name
+}
+
+func (b *mockBranch) AssignUnit(unitName string) error {
+	b.MethodCall(b, "AssignUnit", unitName)
+	return b.NextErr()
+}
+
+func (b *mockBranch) AssignAllUnits(appName string) error {
+	b.MethodCall(b, "AssignAllUnits", appName)
+	return b.NextErr()
+}
+
+func (b *mockBranch) UpdateCharmConfig(appName string, changes charm.Settings) error {
+	b.MethodCall(b, "UpdateCharmConfig", appName, changes)
+	return b.NextErr()
+}
+
+func (b *mockBranch) Commit() error {
+	b.MethodCall(b, "Commit")
+	return b.NextErr()
+}
+
+func (b *mockBranch) Abort() error {
+	b.MethodCall(b, "Abort")
+	return b.NextErr()
+}
+
+type mockBlockChecker struct {
+	testing.Stub
+}
+
+func (c *mockBlockChecker) ChangeAllowed() error {
+	c.MethodCall(c, "ChangeAllowed")
+	return c.NextErr()
+}
@@ -0,0 +1,60 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelgeneration
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/state"
+)
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewAPI(&backend{st, model}, common.NewBlockChecker(st), auth)
+}
+
+type backend struct {
+	st    *state.State
+	model *state.Model
+}
+
+// ModelTag is part of the Backend interface.
+func (b *backend) ModelTag() names.ModelTag {
+	return b.model.ModelTag()
+}
+
+// AddBranch is part of the Backend interface.
+func (b *backend) AddBranch(name, createdBy string) error {
+	return b.model.AddBranch(name, createdBy)
+}
+
+// Branch is part of the Backend interface.
+func (b *backend) Branch() (Branch, error) {
+	branch, err := b.model.Branch()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return branch, nil
+}
+
+// CharmConfig is part of the Backend interface.
+func (b *backend) CharmConfig(appName string) (*charm.Config, error) {
+	app, err := b.st.Application(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ch, _, err := app.Charm()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ch.Config(), nil
+}
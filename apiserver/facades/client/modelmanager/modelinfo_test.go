@@ -26,6 +26,7 @@ import (
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/status"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -143,14 +144,14 @@ func (s *modelInfoSuite) SetUpTest(c *gc.C) {
 	}
 
 	var err error
-	s.modelmanager, err = modelmanager.NewModelManagerAPI(s.st, s.ctlrSt, nil, &s.authorizer, s.st.model)
+	s.modelmanager, err = modelmanager.NewModelManagerAPI(s.st, s.ctlrSt, nil, &s.authorizer, common.NewResources(), s.st.model)
 	c.Assert(err, jc.ErrorIsNil)
 }
 
 func (s *modelInfoSuite) setAPIUser(c *gc.C, user names.UserTag) {
 	s.authorizer.Tag = user
 	var err error
-	s.modelmanager, err = modelmanager.NewModelManagerAPI(s.st, s.ctlrSt, nil, s.authorizer, s.st.model)
+	s.modelmanager, err = modelmanager.NewModelManagerAPI(s.st, s.ctlrSt, nil, s.authorizer, common.NewResources(), s.st.model)
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -917,6 +918,9 @@ type mockModel struct {
 	migrationStatus state.MigrationMode
 	controllerUUID  string
 	cfgDefaults     config.ModelDefaultAttributes
+	destroyProgress state.ModelDestroyProgress
+	destroyChanges  chan struct{}
+	userActivity    []state.ModelUserActivity
 }
 
 func (m *mockModel) Config() (*config.Config, error) {
@@ -1037,6 +1041,26 @@ func (m *mockModel) ModelConfigDefaultValues() (config.ModelDefaultAttributes, e
 	return m.cfgDefaults, nil
 }
 
+func (m *mockModel) DestroyOperation() string {
+	m.MethodCall(m, "DestroyOperation")
+	return m.destroyProgress.OperationId
+}
+
+func (m *mockModel) DestroyProgress() (state.ModelDestroyProgress, error) {
+	m.MethodCall(m, "DestroyProgress")
+	return m.destroyProgress, m.NextErr()
+}
+
+func (m *mockModel) WatchDestroyProgress() state.NotifyWatcher {
+	m.MethodCall(m, "WatchDestroyProgress")
+	return statetesting.NewMockNotifyWatcher(m.destroyChanges)
+}
+
+func (m *mockModel) UserActivity() ([]state.ModelUserActivity, error) {
+	m.MethodCall(m, "UserActivity")
+	return m.userActivity, m.NextErr()
+}
+
 func (m *mockModel) getModelDetails() state.ModelSummary {
 	cred, _ := m.CloudCredential()
 	return state.ModelSummary{
@@ -31,13 +31,45 @@ import (
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/stateenvirons"
+	"github.com/juju/juju/state/watcher"
 	"github.com/juju/juju/tools"
 	jujuversion "github.com/juju/juju/version"
 )
 
 var logger = loggo.GetLogger("juju.apiserver.modelmanager")
 
-// ModelManagerV4 defines the methods on the version 2 facade for the
+// ModelManagerV6 defines the methods on the version 6 facade for the
+// modelmanager API endpoint.
+type ModelManagerV6 interface {
+	CreateModel(args params.ModelCreateArgs) (params.ModelInfo, error)
+	DumpModels(args params.DumpModelRequest) params.StringResults
+	DumpModelsDB(args params.Entities) params.MapResults
+	ListModelSummaries(request params.ModelSummariesRequest) (params.ModelSummaryResults, error)
+	ListModels(user params.Entity) (params.UserModelList, error)
+	DestroyModels(args params.DestroyModelsParams) (params.DestroyModelResults, error)
+	DestroyProgress(args params.Entities) (params.ModelDestroyProgressResults, error)
+	WatchDestroyProgress(args params.Entities) (params.NotifyWatchResults, error)
+	ModelInfo(args params.Entities) (params.ModelInfoResults, error)
+	ModelStatus(req params.Entities) (params.ModelStatusResults, error)
+	ModelUserActivity(args params.Entities) (params.ModelUserActivityResults, error)
+}
+
+// ModelManagerV5 defines the methods on the version 5 facade for the
+// modelmanager API endpoint.
+type ModelManagerV5 interface {
+	CreateModel(args params.ModelCreateArgs) (params.ModelInfo, error)
+	DumpModels(args params.DumpModelRequest) params.StringResults
+	DumpModelsDB(args params.Entities) params.MapResults
+	ListModelSummaries(request params.ModelSummariesRequest) (params.ModelSummaryResults, error)
+	ListModels(user params.Entity) (params.UserModelList, error)
+	DestroyModels(args params.DestroyModelsParams) (params.DestroyModelResults, error)
+	DestroyProgress(args params.Entities) (params.ModelDestroyProgressResults, error)
+	WatchDestroyProgress(args params.Entities) (params.NotifyWatchResults, error)
+	ModelInfo(args params.Entities) (params.ModelInfoResults, error)
+	ModelStatus(req params.Entities) (params.ModelStatusResults, error)
+}
+
+// ModelManagerV4 defines the methods on the version 4 facade for the
 // modelmanager API endpoint.
 type ModelManagerV4 interface {
 	CreateModel(args params.ModelCreateArgs) (params.ModelInfo, error)
@@ -81,16 +113,29 @@ type ModelManagerAPI struct {
 	ctlrState   common.ModelManagerBackend
 	check       *common.BlockChecker
 	authorizer  facade.Authorizer
+	resources   facade.Resources
 	toolsFinder *common.ToolsFinder
 	apiUser     names.UserTag
 	isAdmin     bool
 	model       common.Model
 }
 
-// ModelManagerAPIV2 provides a way to wrap the different calls between
+// ModelManagerAPIV5 provides a way to wrap the different calls between
+// version 5 and version 6 of the model manager API
+type ModelManagerAPIV5 struct {
+	*ModelManagerAPI
+}
+
+// ModelManagerAPIV4 provides a way to wrap the different calls between
+// version 4 and version 5 of the model manager API
+type ModelManagerAPIV4 struct {
+	*ModelManagerAPIV5
+}
+
+// ModelManagerAPIV3 provides a way to wrap the different calls between
 // version 3 and version 4 of the model manager API
 type ModelManagerAPIV3 struct {
-	*ModelManagerAPI
+	*ModelManagerAPIV4
 }
 
 // ModelManagerAPIV2 provides a way to wrap the different calls between
@@ -100,13 +145,15 @@ type ModelManagerAPIV2 struct {
 }
 
 var (
-	_ ModelManagerV4 = (*ModelManagerAPI)(nil)
+	_ ModelManagerV6 = (*ModelManagerAPI)(nil)
+	_ ModelManagerV5 = (*ModelManagerAPIV5)(nil)
+	_ ModelManagerV4 = (*ModelManagerAPIV4)(nil)
 	_ ModelManagerV3 = (*ModelManagerAPIV3)(nil)
 	_ ModelManagerV2 = (*ModelManagerAPIV2)(nil)
 )
 
-// NewFacadeV4 is used for API registration.
-func NewFacadeV4(ctx facade.Context) (*ModelManagerAPI, error) {
+// NewFacadeV6 is used for API registration.
+func NewFacadeV6(ctx facade.Context) (*ModelManagerAPI, error) {
 	st := ctx.State()
 	pool := ctx.StatePool()
 	ctlrSt := pool.SystemState()
@@ -130,10 +177,29 @@ func NewFacadeV4(ctx facade.Context) (*ModelManagerAPI, error) {
 		common.NewModelManagerBackend(ctrlModel, pool),
 		configGetter,
 		auth,
+		ctx.Resources(),
 		model,
 	)
 }
 
+// NewFacadeV5 is used for API registration.
+func NewFacadeV5(ctx facade.Context) (*ModelManagerAPIV5, error) {
+	v6, err := NewFacadeV6(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ModelManagerAPIV5{v6}, nil
+}
+
+// NewFacadeV4 is used for API registration.
+func NewFacadeV4(ctx facade.Context) (*ModelManagerAPIV4, error) {
+	v5, err := NewFacadeV5(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ModelManagerAPIV4{v5}, nil
+}
+
 // NewFacadeV3 is used for API registration.
 func NewFacadeV3(ctx facade.Context) (*ModelManagerAPIV3, error) {
 	v4, err := NewFacadeV4(ctx)
@@ -159,6 +225,7 @@ func NewModelManagerAPI(
 	ctlrSt common.ModelManagerBackend,
 	configGetter environs.EnvironConfigGetter,
 	authorizer facade.Authorizer,
+	resources facade.Resources,
 	m common.Model,
 ) (*ModelManagerAPI, error) {
 	if !authorizer.AuthClient() {
@@ -180,6 +247,7 @@ func NewModelManagerAPI(
 		ctlrState:      ctlrSt,
 		check:          common.NewBlockChecker(st),
 		authorizer:     authorizer,
+		resources:      resources,
 		toolsFinder:    common.NewToolsFinder(configGetter, st, urlGetter),
 		apiUser:        apiUser,
 		isAdmin:        isAdmin,
@@ -680,6 +748,8 @@ func (m *ModelManagerAPI) DumpModelsDB(args params.Entities) params.MapResults {
 // has access to in the current server.  Controller admins (superuser)
 // can list models for any user.  Other users
 // can only ask about their own models.
+//
+//juju:readonly
 func (m *ModelManagerAPI) ListModelSummaries(req params.ModelSummariesRequest) (params.ModelSummaryResults, error) {
 	result := params.ModelSummaryResults{}
 
@@ -762,6 +832,8 @@ func (m *ModelManagerAPI) ListModelSummaries(req params.ModelSummariesRequest) (
 // has access to in the current server.  Controller admins (superuser)
 // can list models for any user.  Other users
 // can only ask about their own models.
+//
+//juju:readonly
 func (m *ModelManagerAPI) ListModels(user params.Entity) (params.UserModelList, error) {
 	result := params.UserModelList{}
 
@@ -817,32 +889,82 @@ func (m *ModelManagerAPIV3) DestroyModels(args params.Entities) (params.ErrorRes
 			DestroyStorage: &destroyStorage,
 		}
 	}
-	return m.ModelManagerAPI.DestroyModels(v4Args)
+	return m.ModelManagerAPIV4.DestroyModels(v4Args)
 }
 
 // DestroyModels will try to destroy the specified models.
 // If there is a block on destruction, this method will return an error.
-func (m *ModelManagerAPI) DestroyModels(args params.DestroyModelsParams) (params.ErrorResults, error) {
-	results := params.ErrorResults{
-		Results: make([]params.ErrorResult, len(args.Models)),
+func (m *ModelManagerAPIV4) DestroyModels(args params.DestroyModelsParams) (params.ErrorResults, error) {
+	// v4 DestroyModels does not report the destroy operation,
+	// so it does not need to read the model again to find it.
+	results := m.destroyModels(args, false)
+	errorResults := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(results.Results)),
+	}
+	for i, result := range results.Results {
+		errorResults.Results[i].Error = result.Error
+	}
+	return errorResults, nil
+}
+
+// DestroyProgress isn't on the v4 API.
+//
+//juju:readonly
+func (*ModelManagerAPIV4) DestroyProgress(_, _ struct{}) {}
+
+// WatchDestroyProgress isn't on the v4 API.
+//
+//juju:readonly
+func (*ModelManagerAPIV4) WatchDestroyProgress(_, _ struct{}) {}
+
+// ModelUserActivity isn't on the v5 API.
+func (*ModelManagerAPIV5) ModelUserActivity(_, _ struct{}) {}
+
+// DestroyModels will try to destroy the specified models, returning
+// the identifier of the operation destroying each of them. Destroying
+// a model that is already being destroyed returns the identifier of
+// the existing operation.
+// If there is a block on destruction, this method will return an error.
+func (m *ModelManagerAPI) DestroyModels(args params.DestroyModelsParams) (params.DestroyModelResults, error) {
+	return m.destroyModels(args, true), nil
+}
+
+// destroyModels destroys the specified models, recording the
+// identifiers of the operations destroying them in the results if
+// reportOperations is true.
+func (m *ModelManagerAPI) destroyModels(args params.DestroyModelsParams, reportOperations bool) params.DestroyModelResults {
+	results := params.DestroyModelResults{
+		Results: make([]params.DestroyModelResult, len(args.Models)),
 	}
 
-	destroyModel := func(modelUUID string, destroyStorage *bool) error {
+	destroyModel := func(modelUUID string, destroyStorage *bool) (string, error) {
 		st, releaseSt, err := m.state.GetBackend(modelUUID)
 		if err != nil {
-			return errors.Trace(err)
+			return "", errors.Trace(err)
 		}
 		defer releaseSt()
 
 		model, err := st.Model()
 		if err != nil {
-			return errors.Trace(err)
+			return "", errors.Trace(err)
 		}
 		if err := m.authCheck(model.Owner()); err != nil {
-			return errors.Trace(err)
+			return "", errors.Trace(err)
+		}
+		if err := common.DestroyModel(st, destroyStorage); err != nil {
+			return "", errors.Trace(err)
+		}
+		if !reportOperations {
+			return "", nil
 		}
 
-		return errors.Trace(common.DestroyModel(st, destroyStorage))
+		// Read the model again to get the operation
+		// recorded when it was destroyed.
+		model, err = st.Model()
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		return model.DestroyOperation(), nil
 	}
 
 	for i, arg := range args.Models {
@@ -851,15 +973,195 @@ func (m *ModelManagerAPI) DestroyModels(args params.DestroyModelsParams) (params
 			results.Results[i].Error = common.ServerError(err)
 			continue
 		}
-		if err := destroyModel(tag.Id(), arg.DestroyStorage); err != nil {
+		operationId, err := destroyModel(tag.Id(), arg.DestroyStorage)
+		if err != nil {
 			results.Results[i].Error = common.ServerError(err)
 			continue
 		}
+		results.Results[i].OperationId = operationId
+	}
+	return results
+}
+
+// destroyingModel returns the specified model, if the authenticated
+// user is permitted to destroy it, along with a function to release
+// the model's backend.
+func (m *ModelManagerAPI) destroyingModel(modelTag string) (common.Model, func() bool, error) {
+	tag, err := names.ParseModelTag(modelTag)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	st, releaseSt, err := m.state.GetBackend(tag.Id())
+	if errors.IsNotFound(err) {
+		return nil, nil, errors.Trace(common.ErrPerm)
+	} else if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	model, err := st.Model()
+	if err != nil {
+		releaseSt()
+		return nil, nil, errors.Trace(err)
+	}
+	if err := m.authCheck(model.Owner()); err != nil {
+		releaseSt()
+		return nil, nil, errors.Trace(err)
+	}
+	return model, releaseSt, nil
+}
+
+// DestroyProgress reports how far the destruction of each of the
+// specified models has progressed: the numbers of machines,
+// applications, volumes and filesystems remaining, and the entities
+// in an error state that may be blocking the model's removal.
+//
+//juju:readonly
+func (m *ModelManagerAPI) DestroyProgress(args params.Entities) (params.ModelDestroyProgressResults, error) {
+	results := params.ModelDestroyProgressResults{
+		Results: make([]params.ModelDestroyProgressResult, len(args.Entities)),
+	}
+
+	destroyProgress := func(modelTag string) (*params.ModelDestroyProgress, error) {
+		model, release, err := m.destroyingModel(modelTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer release()
+
+		progress, err := model.DestroyProgress()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result := &params.ModelDestroyProgress{
+			ModelTag:         model.ModelTag().String(),
+			OperationId:      progress.OperationId,
+			Life:             params.Life(progress.Life.String()),
+			MachineCount:     progress.Machines,
+			ApplicationCount: progress.Applications,
+			VolumeCount:      progress.Volumes,
+			FilesystemCount:  progress.Filesystems,
+		}
+		if !progress.Started.IsZero() {
+			started := progress.Started
+			result.Started = &started
+		}
+		for _, entity := range progress.Blocking {
+			result.Blocking = append(result.Blocking, params.DestroyBlockingEntity{
+				Tag:     entity.Tag.String(),
+				Life:    params.Life(entity.Life.String()),
+				Status:  entity.Status.String(),
+				Message: entity.Message,
+			})
+		}
+		return result, nil
+	}
+
+	for i, arg := range args.Entities {
+		progress, err := destroyProgress(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = progress
 	}
 	return results, nil
 }
 
+// WatchDestroyProgress returns a NotifyWatcher for each of the
+// specified models, which triggers whenever the model's destroy
+// progress, as reported by DestroyProgress, may have changed.
+//
+//juju:readonly
+func (m *ModelManagerAPI) WatchDestroyProgress(args params.Entities) (params.NotifyWatchResults, error) {
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+
+	watch := func(modelTag string) (string, error) {
+		model, release, err := m.destroyingModel(modelTag)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		defer release()
+
+		w := model.WatchDestroyProgress()
+		// Consume the initial event. Technically, API
+		// calls to Watch 'transmit' the initial event
+		// in the Watch response. But NotifyWatchers
+		// have no state to transmit.
+		if _, ok := <-w.Changes(); !ok {
+			return "", watcher.EnsureErr(w)
+		}
+		return m.resources.Register(w), nil
+	}
+
+	for i, arg := range args.Entities {
+		watcherId, err := watch(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].NotifyWatcherId = watcherId
+	}
+	return results, nil
+}
+
+// ModelUserActivity returns, for each of the specified models, the API
+// activity of each of its users: the number of API calls they have
+// made to the model, and when they last made one and last logged in.
+// Users who have never used the model are included, so that dormant
+// users can be identified. Only controller superusers and model
+// administrators may see a model's activity.
+func (m *ModelManagerAPI) ModelUserActivity(args params.Entities) (params.ModelUserActivityResults, error) {
+	results := params.ModelUserActivityResults{
+		Results: make([]params.ModelUserActivityResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		activity, err := m.modelUserActivity(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Activity = activity
+	}
+	return results, nil
+}
+
+func (m *ModelManagerAPI) modelUserActivity(modelTag string) ([]params.ModelUserActivity, error) {
+	tag, err := names.ParseModelTag(modelTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !m.isAdmin {
+		isModelAdmin, err := m.authorizer.HasPermission(permission.AdminAccess, tag)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		if !isModelAdmin {
+			return nil, common.ErrPerm
+		}
+	}
+	model, release, err := m.state.GetModel(tag.Id())
+	if errors.IsNotFound(err) {
+		return nil, errors.Trace(common.ErrPerm)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer release()
+
+	activity, err := model.UserActivity()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]params.ModelUserActivity, len(activity))
+	for i, a := range activity {
+		result[i] = common.ModelUserActivity(a)
+	}
+	return result, nil
+}
+
 // ModelInfo returns information about the specified models.
+//
+//juju:readonly
 func (m *ModelManagerAPI) ModelInfo(args params.Entities) (params.ModelInfoResults, error) {
 	results := params.ModelInfoResults{
 		Results: make([]params.ModelInfoResult, len(args.Entities)),
@@ -1169,6 +1471,8 @@ func changeModelAccess(accessor common.ModelManagerBackend, modelTag names.Model
 }
 
 // ModelDefaults returns the default config values used when creating a new model.
+//
+//juju:readonly
 func (m *ModelManagerAPI) ModelDefaults() (params.ModelDefaultsResult, error) {
 	result := params.ModelDefaultsResult{}
 	if !m.isAdmin {
@@ -1280,6 +1584,8 @@ func (m *ModelManagerAPI) makeRegionSpec(cloudTag, r string) (*environs.RegionSp
 // ModelStatus is a legacy method call to ensure that we preserve
 // backward compatibility.
 // TODO (anastasiamac 2017-10-26) This should be made obsolete/removed.
+//
+//juju:readonly
 func (s *ModelManagerAPIV2) ModelStatus(req params.Entities) (params.ModelStatusResults, error) {
 	return s.ModelManagerAPI.oldModelStatus(req)
 }
@@ -1287,6 +1593,8 @@ func (s *ModelManagerAPIV2) ModelStatus(req params.Entities) (params.ModelStatus
 // ModelStatus is a legacy method call to ensure that we preserve
 // backward compatibility.
 // TODO (anastasiamac 2017-10-26) This should be made obsolete/removed.
+//
+//juju:readonly
 func (s *ModelManagerAPIV3) ModelStatus(req params.Entities) (params.ModelStatusResults, error) {
 	return s.ModelManagerAPI.oldModelStatus(req)
 }
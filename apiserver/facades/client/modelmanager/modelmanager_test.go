@@ -57,6 +57,7 @@ type modelManagerSuite struct {
 	ctlrSt     *mockState
 	caasSt     *mockState
 	authoriser apiservertesting.FakeAuthorizer
+	resources  *common.Resources
 	api        *modelmanager.ModelManagerAPI
 	caasApi    *modelmanager.ModelManagerAPI
 }
@@ -205,17 +206,19 @@ func (s *modelManagerSuite) SetUpTest(c *gc.C) {
 	s.authoriser = apiservertesting.FakeAuthorizer{
 		Tag: names.NewUserTag("admin"),
 	}
-	api, err := modelmanager.NewModelManagerAPI(s.st, s.ctlrSt, nil, s.authoriser, s.st.model)
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+	api, err := modelmanager.NewModelManagerAPI(s.st, s.ctlrSt, nil, s.authoriser, s.resources, s.st.model)
 	c.Assert(err, jc.ErrorIsNil)
 	s.api = api
-	caasApi, err := modelmanager.NewModelManagerAPI(s.caasSt, s.ctlrSt, nil, s.authoriser, s.st.model)
+	caasApi, err := modelmanager.NewModelManagerAPI(s.caasSt, s.ctlrSt, nil, s.authoriser, s.resources, s.st.model)
 	c.Assert(err, jc.ErrorIsNil)
 	s.caasApi = caasApi
 }
 
 func (s *modelManagerSuite) setAPIUser(c *gc.C, user names.UserTag) {
 	s.authoriser.Tag = user
-	mm, err := modelmanager.NewModelManagerAPI(s.st, s.ctlrSt, nil, s.authoriser, s.st.model)
+	mm, err := modelmanager.NewModelManagerAPI(s.st, s.ctlrSt, nil, s.authoriser, s.resources, s.st.model)
 	c.Assert(err, jc.ErrorIsNil)
 	s.api = mm
 }
@@ -632,7 +635,7 @@ func (s *modelManagerSuite) TestUnsetModelDefaultsAsNormalUser(c *gc.C) {
 
 func (s *modelManagerSuite) TestDumpModelV2(c *gc.C) {
 	api := &modelmanager.ModelManagerAPIV2{
-		&modelmanager.ModelManagerAPIV3{s.api},
+		&modelmanager.ModelManagerAPIV3{&modelmanager.ModelManagerAPIV4{&modelmanager.ModelManagerAPIV5{s.api}}},
 	}
 
 	results := api.DumpModels(params.Entities{[]params.Entity{{
@@ -812,7 +815,7 @@ func (s *modelManagerSuite) TestAddModelCantCreateModelForSomeoneElse(c *gc.C) {
 }
 
 func (s *modelManagerSuite) TestDestroyModelsV3(c *gc.C) {
-	api := &modelmanager.ModelManagerAPIV3{s.api}
+	api := &modelmanager.ModelManagerAPIV3{&modelmanager.ModelManagerAPIV4{&modelmanager.ModelManagerAPIV5{s.api}}}
 	results, err := api.DestroyModels(params.Entities{
 		Entities: []params.Entity{{coretesting.ModelTag.String()}},
 	})
@@ -840,6 +843,174 @@ func (s *modelManagerSuite) TestDestroyModelsV3(c *gc.C) {
 	})
 }
 
+func (s *modelManagerSuite) TestDestroyModels(c *gc.C) {
+	s.st.model.destroyProgress.OperationId = "deadbeef"
+	results, err := s.api.DestroyModels(params.DestroyModelsParams{
+		Models: []params.DestroyModelParams{{
+			ModelTag: coretesting.ModelTag.String(),
+		}, {
+			ModelTag: "machine-42",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.DestroyModelResults{
+		Results: []params.DestroyModelResult{{
+			OperationId: "deadbeef",
+		}, {
+			Error: &params.Error{
+				Message: `"machine-42" is not a valid model tag`,
+			},
+		}},
+	})
+}
+
+func (s *modelManagerSuite) TestDestroyModelsV4(c *gc.C) {
+	s.st.model.destroyProgress.OperationId = "deadbeef"
+	api := &modelmanager.ModelManagerAPIV4{&modelmanager.ModelManagerAPIV5{s.api}}
+	results, err := api.DestroyModels(params.DestroyModelsParams{
+		Models: []params.DestroyModelParams{{
+			ModelTag: coretesting.ModelTag.String(),
+		}, {
+			ModelTag: "machine-42",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}, {
+			Error: &params.Error{
+				Message: `"machine-42" is not a valid model tag`,
+			},
+		}},
+	})
+}
+
+func (s *modelManagerSuite) TestDestroyProgress(c *gc.C) {
+	started := time.Date(2018, 5, 1, 12, 0, 0, 0, time.UTC)
+	s.st.model.destroyProgress = state.ModelDestroyProgress{
+		OperationId:  "deadbeef",
+		Life:         state.Dying,
+		Started:      started,
+		Machines:     2,
+		Applications: 1,
+		Volumes:      3,
+		Blocking: []state.BlockingEntity{{
+			Tag:     names.NewMachineTag("0"),
+			Life:    state.Dying,
+			Status:  status.ProvisioningError,
+			Message: "no more instances",
+		}},
+	}
+	results, err := s.api.DestroyProgress(params.Entities{
+		Entities: []params.Entity{
+			{Tag: coretesting.ModelTag.String()},
+			{Tag: "machine-42"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ModelDestroyProgressResults{
+		Results: []params.ModelDestroyProgressResult{{
+			Result: &params.ModelDestroyProgress{
+				ModelTag:         coretesting.ModelTag.String(),
+				OperationId:      "deadbeef",
+				Life:             params.Dying,
+				Started:          &started,
+				MachineCount:     2,
+				ApplicationCount: 1,
+				VolumeCount:      3,
+				Blocking: []params.DestroyBlockingEntity{{
+					Tag:     "machine-0",
+					Life:    params.Dying,
+					Status:  "provisioning error",
+					Message: "no more instances",
+				}},
+			},
+		}, {
+			Error: &params.Error{
+				Message: `"machine-42" is not a valid model tag`,
+			},
+		}},
+	})
+}
+
+func (s *modelManagerSuite) TestDestroyProgressPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("otheruser"))
+	s.st.model.ResetCalls()
+	results, err := s.api.DestroyProgress(params.Entities{
+		Entities: []params.Entity{{Tag: coretesting.ModelTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeUnauthorized)
+	s.st.model.CheckCallNames(c, "Owner")
+}
+
+func (s *modelManagerSuite) TestWatchDestroyProgress(c *gc.C) {
+	changes := make(chan struct{}, 1)
+	changes <- struct{}{}
+	s.st.model.destroyChanges = changes
+	results, err := s.api.WatchDestroyProgress(params.Entities{
+		Entities: []params.Entity{{Tag: coretesting.ModelTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{{NotifyWatcherId: "1"}},
+	})
+	c.Assert(s.resources.Get("1"), gc.NotNil)
+	s.st.model.CheckCallNames(c, "UUID", "Owner", "WatchDestroyProgress")
+}
+
+func (s *modelManagerSuite) TestModelUserActivity(c *gc.C) {
+	lastAccess := time.Date(2018, 5, 1, 12, 0, 0, 0, time.UTC)
+	s.st.model.userActivity = []state.ModelUserActivity{{
+		ModelUUID:  coretesting.ModelTag.Id(),
+		UserName:   "admin",
+		APICalls:   42,
+		LastAccess: lastAccess,
+	}, {
+		ModelUUID: coretesting.ModelTag.Id(),
+		UserName:  "otheruser",
+	}}
+	results, err := s.api.ModelUserActivity(params.Entities{
+		Entities: []params.Entity{{Tag: coretesting.ModelTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ModelUserActivityResults{
+		Results: []params.ModelUserActivityResult{{
+			Activity: []params.ModelUserActivity{{
+				UserTag:    "user-admin",
+				ModelTag:   coretesting.ModelTag.String(),
+				APICalls:   42,
+				LastAccess: &lastAccess,
+			}, {
+				UserTag:  "user-otheruser",
+				ModelTag: coretesting.ModelTag.String(),
+			}},
+		}},
+	})
+	s.st.model.CheckCallNames(c, "UserActivity")
+}
+
+func (s *modelManagerSuite) TestModelUserActivityPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("otheruser"))
+	s.st.model.ResetCalls()
+	results, err := s.api.ModelUserActivity(params.Entities{
+		Entities: []params.Entity{{Tag: coretesting.ModelTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeUnauthorized)
+	s.st.model.CheckNoCalls(c)
+}
+
+func (s *modelManagerSuite) TestModelUserActivityInvalidTag(c *gc.C) {
+	results, err := s.api.ModelUserActivity(params.Entities{
+		Entities: []params.Entity{{Tag: "user-admin"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `"user-admin" is not a valid model tag`)
+}
+
 // modelManagerStateSuite contains end-to-end tests.
 // Prefer adding tests to modelManagerSuite above.
 type modelManagerStateSuite struct {
@@ -872,7 +1043,7 @@ func (s *modelManagerStateSuite) setAPIUser(c *gc.C, user names.UserTag) {
 		common.NewModelManagerBackend(s.IAASModel.Model, s.StatePool),
 		common.NewModelManagerBackend(s.IAASModel.Model, s.StatePool),
 		stateenvirons.EnvironConfigGetter{s.State, s.IAASModel.Model},
-		s.authoriser,
+		s.authoriser, common.NewResources(),
 		s.IAASModel,
 	)
 	c.Assert(err, jc.ErrorIsNil)
@@ -885,7 +1056,7 @@ func (s *modelManagerStateSuite) TestNewAPIAcceptsClient(c *gc.C) {
 	endPoint, err := modelmanager.NewModelManagerAPI(
 		common.NewModelManagerBackend(s.IAASModel.Model, s.StatePool),
 		common.NewModelManagerBackend(s.IAASModel.Model, s.StatePool),
-		nil, anAuthoriser,
+		nil, anAuthoriser, common.NewResources(),
 		s.IAASModel.Model,
 	)
 	c.Assert(err, jc.ErrorIsNil)
@@ -898,7 +1069,7 @@ func (s *modelManagerStateSuite) TestNewAPIRefusesNonClient(c *gc.C) {
 	endPoint, err := modelmanager.NewModelManagerAPI(
 		common.NewModelManagerBackend(s.IAASModel.Model, s.StatePool),
 		common.NewModelManagerBackend(s.IAASModel.Model, s.StatePool),
-		nil, anAuthoriser, s.IAASModel.Model,
+		nil, anAuthoriser, common.NewResources(), s.IAASModel.Model,
 	)
 	c.Assert(endPoint, gc.IsNil)
 	c.Assert(err, gc.ErrorMatches, "permission denied")
@@ -1102,7 +1273,7 @@ func (s *modelManagerStateSuite) TestDestroyOwnModel(c *gc.C) {
 	s.modelmanager, err = modelmanager.NewModelManagerAPI(
 		common.NewModelManagerBackend(model, s.StatePool),
 		common.NewModelManagerBackend(s.IAASModel.Model, s.StatePool),
-		nil, s.authoriser,
+		nil, s.authoriser, common.NewResources(),
 		s.IAASModel.Model,
 	)
 	c.Assert(err, jc.ErrorIsNil)
@@ -1119,6 +1290,8 @@ func (s *modelManagerStateSuite) TestDestroyOwnModel(c *gc.C) {
 	model, err = st.Model()
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(model.Life(), gc.Not(gc.Equals), state.Alive)
+	c.Assert(results.Results[0].OperationId, gc.Equals, model.DestroyOperation())
+	c.Assert(results.Results[0].OperationId, gc.Not(gc.Equals), "")
 }
 
 func (s *modelManagerStateSuite) TestAdminDestroysOtherModel(c *gc.C) {
@@ -1139,7 +1312,7 @@ func (s *modelManagerStateSuite) TestAdminDestroysOtherModel(c *gc.C) {
 	s.modelmanager, err = modelmanager.NewModelManagerAPI(
 		common.NewModelManagerBackend(model, s.StatePool),
 		common.NewModelManagerBackend(s.IAASModel.Model, s.StatePool),
-		nil, s.authoriser,
+		nil, s.authoriser, common.NewResources(),
 		s.IAASModel.Model,
 	)
 	c.Assert(err, jc.ErrorIsNil)
@@ -1174,7 +1347,7 @@ func (s *modelManagerStateSuite) TestDestroyModelErrors(c *gc.C) {
 	s.modelmanager, err = modelmanager.NewModelManagerAPI(
 		common.NewModelManagerBackend(model, s.StatePool),
 		common.NewModelManagerBackend(s.IAASModel.Model, s.StatePool),
-		nil, s.authoriser, s.IAASModel.Model,
+		nil, s.authoriser, common.NewResources(), s.IAASModel.Model,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -1189,19 +1362,19 @@ func (s *modelManagerStateSuite) TestDestroyModelErrors(c *gc.C) {
 		},
 	})
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{{
+	c.Assert(results.Results, jc.DeepEquals, []params.DestroyModelResult{{
 		// we don't have admin access to the model
-		&params.Error{
+		Error: &params.Error{
 			Message: "permission denied",
 			Code:    params.CodeUnauthorized,
 		},
 	}, {
-		&params.Error{
+		Error: &params.Error{
 			Message: `model "9f484882-2f18-4fd2-967d-db9663db7bea" not found`,
 			Code:    params.CodeNotFound,
 		},
 	}, {
-		&params.Error{
+		Error: &params.Error{
 			Message: `"machine-42" is not a valid model tag`,
 		},
 	}})
@@ -1569,7 +1742,7 @@ func (s *modelManagerStateSuite) TestModifyModelAccessInvalidAction(c *gc.C) {
 
 func (s *modelManagerSuite) TestModelStatusV2(c *gc.C) {
 	api := &modelmanager.ModelManagerAPIV2{
-		&modelmanager.ModelManagerAPIV3{s.api},
+		&modelmanager.ModelManagerAPIV3{&modelmanager.ModelManagerAPIV4{&modelmanager.ModelManagerAPIV5{s.api}}},
 	}
 	// Check that we err out immediately if a model errs.
 	results, err := api.ModelStatus(params.Entities{[]params.Entity{{
@@ -1598,7 +1771,7 @@ func (s *modelManagerSuite) TestModelStatusV2(c *gc.C) {
 }
 
 func (s *modelManagerSuite) TestModelStatusV3(c *gc.C) {
-	api := &modelmanager.ModelManagerAPIV3{s.api}
+	api := &modelmanager.ModelManagerAPIV3{&modelmanager.ModelManagerAPIV4{&modelmanager.ModelManagerAPIV5{s.api}}}
 
 	// Check that we err out immediately if a model errs.
 	results, err := api.ModelStatus(params.Entities{[]params.Entity{{
@@ -0,0 +1,159 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelsnapshots implements the ModelSnapshots facade, which
+// lists the periodic snapshots of a model's description and shows how
+// the model changed between them, or since one of them.
+package modelsnapshots
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/modelsnapshot"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the
+// ModelSnapshots facade.
+type Backend interface {
+	ControllerTag() names.ControllerTag
+	ModelTag() names.ModelTag
+	ModelSnapshots() ([]state.ModelSnapshot, error)
+	ModelSnapshot(id int) (state.ModelSnapshot, error)
+	ModelSnapshotAt(t time.Time) (state.ModelSnapshot, error)
+	ModelSnapshotDescription() ([]byte, error)
+}
+
+// API implements the ModelSnapshots facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewAPI returns a new ModelSnapshots facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(st, auth)
+}
+
+// checkCanRead returns an error unless the user may see the model's
+// snapshots. As snapshots hold the model's configuration, they are
+// restricted, like model dumps, to model and controller administrators.
+func (api *API) checkCanRead() error {
+	isAdmin, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.backend.ControllerTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if isAdmin {
+		return nil
+	}
+	isModelAdmin, err := api.authorizer.HasPermission(permission.AdminAccess, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isModelAdmin {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// ModelSnapshots returns the snapshots held for the model, oldest
+// first.
+func (api *API) ModelSnapshots() (params.ModelSnapshotsResult, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.ModelSnapshotsResult{}, err
+	}
+	snapshots, err := api.backend.ModelSnapshots()
+	if err != nil {
+		return params.ModelSnapshotsResult{Error: common.ServerError(err)}, nil
+	}
+	result := params.ModelSnapshotsResult{
+		Snapshots: make([]params.ModelSnapshot, len(snapshots)),
+	}
+	for i, snapshot := range snapshots {
+		result.Snapshots[i] = snapshotParams(snapshot)
+	}
+	return result, nil
+}
+
+// DiffModelSnapshots returns a unified diff between the selected
+// versions of the model.
+func (api *API) DiffModelSnapshots(args params.DiffModelSnapshotsArgs) (params.DiffModelSnapshotsResult, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.DiffModelSnapshotsResult{}, err
+	}
+	result, err := api.diff(args)
+	if err != nil {
+		return params.DiffModelSnapshotsResult{Error: common.ServerError(err)}, nil
+	}
+	return result, nil
+}
+
+func (api *API) diff(args params.DiffModelSnapshotsArgs) (params.DiffModelSnapshotsResult, error) {
+	var from state.ModelSnapshot
+	var err error
+	switch {
+	case args.From != 0 && args.Since != nil:
+		return params.DiffModelSnapshotsResult{}, errors.NotValidf("specifying both snapshot and time to diff from")
+	case args.From != 0:
+		from, err = api.backend.ModelSnapshot(args.From)
+	case args.Since != nil:
+		from, err = api.backend.ModelSnapshotAt(*args.Since)
+	default:
+		return params.DiffModelSnapshotsResult{}, errors.NotValidf("missing snapshot or time to diff from")
+	}
+	if err != nil {
+		return params.DiffModelSnapshotsResult{}, errors.Trace(err)
+	}
+	fromParams := snapshotParams(from)
+	result := params.DiffModelSnapshotsResult{From: &fromParams}
+
+	var to []byte
+	toLabel := "live model"
+	if args.To != 0 {
+		snapshot, err := api.backend.ModelSnapshot(args.To)
+		if err != nil {
+			return params.DiffModelSnapshotsResult{}, errors.Trace(err)
+		}
+		toParams := snapshotParams(snapshot)
+		result.To = &toParams
+		to = snapshot.Description
+		toLabel = snapshotLabel(snapshot)
+	} else {
+		to, err = api.backend.ModelSnapshotDescription()
+		if err != nil {
+			return params.DiffModelSnapshotsResult{}, errors.Trace(err)
+		}
+	}
+	result.Diff = modelsnapshot.Diff(snapshotLabel(from), from.Description, toLabel, to)
+	return result, nil
+}
+
+func snapshotParams(snapshot state.ModelSnapshot) params.ModelSnapshot {
+	return params.ModelSnapshot{
+		Id:    snapshot.Id,
+		Taken: snapshot.Taken,
+	}
+}
+
+func snapshotLabel(snapshot state.ModelSnapshot) string {
+	return fmt.Sprintf("snapshot %d (%s)", snapshot.Id, snapshot.Taken.Format(time.RFC3339))
+}
@@ -0,0 +1,219 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelsnapshots_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/modelsnapshots"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type modelSnapshotsSuite struct {
+	testing.IsolationSuite
+	backend *mockBackend
+	first   state.ModelSnapshot
+	second  state.ModelSnapshot
+}
+
+var _ = gc.Suite(&modelSnapshotsSuite{})
+
+func (s *modelSnapshotsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.first = state.ModelSnapshot{
+		Id:          1,
+		Taken:       time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC),
+		Description: []byte("applications:\n- name: mysql\n"),
+	}
+	s.second = state.ModelSnapshot{
+		Id:          2,
+		Taken:       time.Date(2018, 3, 2, 12, 0, 0, 0, time.UTC),
+		Description: []byte("applications:\n- name: mysql\n- name: wordpress\n"),
+	}
+	s.backend = &mockBackend{
+		snapshots: []state.ModelSnapshot{s.first, s.second},
+		live:      []byte("applications:\n- name: wordpress\n"),
+	}
+}
+
+func (s *modelSnapshotsSuite) newAPI(c *gc.C, user string) *modelsnapshots.API {
+	api, err := modelsnapshots.NewAPI(s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag(user),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *modelSnapshotsSuite) TestRequiresClient(c *gc.C) {
+	_, err := modelsnapshots.NewAPI(s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelSnapshotsSuite) TestModelSnapshots(c *gc.C) {
+	result, err := s.newAPI(c, "admin").ModelSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelSnapshotsResult{
+		Snapshots: []params.ModelSnapshot{{
+			Id:    1,
+			Taken: s.first.Taken,
+		}, {
+			Id:    2,
+			Taken: s.second.Taken,
+		}},
+	})
+}
+
+func (s *modelSnapshotsSuite) TestModelSnapshotsSuperuser(c *gc.C) {
+	result, err := s.newAPI(c, "superuser").ModelSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Snapshots, gc.HasLen, 2)
+}
+
+func (s *modelSnapshotsSuite) TestModelSnapshotsPermissionDenied(c *gc.C) {
+	_, err := s.newAPI(c, "read").ModelSnapshots()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	_, err = s.newAPI(c, "read").DiffModelSnapshots(params.DiffModelSnapshotsArgs{From: 1})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *modelSnapshotsSuite) TestDiffModelSnapshots(c *gc.C) {
+	result, err := s.newAPI(c, "admin").DiffModelSnapshots(params.DiffModelSnapshotsArgs{
+		From: 1,
+		To:   2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.DiffModelSnapshotsResult{
+		From: &params.ModelSnapshot{Id: 1, Taken: s.first.Taken},
+		To:   &params.ModelSnapshot{Id: 2, Taken: s.second.Taken},
+		Diff: `
+--- snapshot 1 (2018-03-01T12:00:00Z)
++++ snapshot 2 (2018-03-02T12:00:00Z)
+@@ -1,2 +1,3 @@
+ applications:
+ - name: mysql
++- name: wordpress
+`[1:],
+	})
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"ModelSnapshot", []interface{}{1}},
+		{"ModelSnapshot", []interface{}{2}},
+	})
+}
+
+func (s *modelSnapshotsSuite) TestDiffModelSnapshotsSinceToLive(c *gc.C) {
+	since := s.second.Taken.Add(time.Hour)
+	result, err := s.newAPI(c, "admin").DiffModelSnapshots(params.DiffModelSnapshotsArgs{
+		Since: &since,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.DiffModelSnapshotsResult{
+		From: &params.ModelSnapshot{Id: 2, Taken: s.second.Taken},
+		Diff: `
+--- snapshot 2 (2018-03-02T12:00:00Z)
++++ live model
+@@ -1,3 +1,2 @@
+ applications:
+-- name: mysql
+ - name: wordpress
+`[1:],
+	})
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"ModelSnapshotAt", []interface{}{since}},
+		{"ModelSnapshotDescription", nil},
+	})
+}
+
+func (s *modelSnapshotsSuite) TestDiffModelSnapshotsUnchanged(c *gc.C) {
+	s.backend.live = s.second.Description
+	result, err := s.newAPI(c, "admin").DiffModelSnapshots(params.DiffModelSnapshotsArgs{From: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Diff, gc.Equals, "")
+}
+
+func (s *modelSnapshotsSuite) TestDiffModelSnapshotsInvalid(c *gc.C) {
+	since := s.second.Taken
+	api := s.newAPI(c, "admin")
+	result, err := api.DiffModelSnapshots(params.DiffModelSnapshotsArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "missing snapshot or time to diff from not valid")
+	result, err = api.DiffModelSnapshots(params.DiffModelSnapshotsArgs{From: 1, Since: &since})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "specifying both snapshot and time to diff from not valid")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *modelSnapshotsSuite) TestDiffModelSnapshotsNotFound(c *gc.C) {
+	result, err := s.newAPI(c, "admin").DiffModelSnapshots(params.DiffModelSnapshotsArgs{From: 3})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Assert(result.Diff, gc.Equals, "")
+}
+
+type mockBackend struct {
+	testing.Stub
+	snapshots []state.ModelSnapshot
+	live      []byte
+}
+
+func (b *mockBackend) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) ModelSnapshots() ([]state.ModelSnapshot, error) {
+	b.MethodCall(b, "ModelSnapshots")
+	var result []state.ModelSnapshot
+	for _, snapshot := range b.snapshots {
+		snapshot.Description = nil
+		result = append(result, snapshot)
+	}
+	return result, b.NextErr()
+}
+
+func (b *mockBackend) ModelSnapshot(id int) (state.ModelSnapshot, error) {
+	b.MethodCall(b, "ModelSnapshot", id)
+	if err := b.NextErr(); err != nil {
+		return state.ModelSnapshot{}, err
+	}
+	for _, snapshot := range b.snapshots {
+		if snapshot.Id == id {
+			return snapshot, nil
+		}
+	}
+	return state.ModelSnapshot{}, errors.NotFoundf("model snapshot %d", id)
+}
+
+func (b *mockBackend) ModelSnapshotAt(t time.Time) (state.ModelSnapshot, error) {
+	b.MethodCall(b, "ModelSnapshotAt", t)
+	if err := b.NextErr(); err != nil {
+		return state.ModelSnapshot{}, err
+	}
+	for i := len(b.snapshots) - 1; i >= 0; i-- {
+		if !b.snapshots[i].Taken.After(t) {
+			return b.snapshots[i], nil
+		}
+	}
+	return state.ModelSnapshot{}, errors.NotFoundf("model snapshot")
+}
+
+func (b *mockBackend) ModelSnapshotDescription() ([]byte, error) {
+	b.MethodCall(b, "ModelSnapshotDescription")
+	return b.live, b.NextErr()
+}
@@ -0,0 +1,98 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelvisualization implements the ModelVisualization facade,
+// which renders the topology of a model - its applications, the
+// relations between them, and optionally the machines and spaces they
+// use - as Graphviz DOT or Mermaid text. Rendering happens server-side
+// so that every client draws the same diagram.
+package modelvisualization
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+)
+
+const (
+	// FormatDOT is the Graphviz DOT output format.
+	FormatDOT = "dot"
+
+	// FormatMermaid is the Mermaid flowchart output format.
+	FormatMermaid = "mermaid"
+)
+
+// Backend defines the state functionality required by the
+// ModelVisualization facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+
+	// Topology returns the applications, relations and machines in
+	// the model.
+	Topology() (Topology, error)
+}
+
+// API implements the ModelVisualization facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewAPI returns a new ModelVisualization facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+// Render returns the topology of the model in the requested format.
+//
+//juju:readonly
+func (api *API) Render(args params.ModelVisualizationArgs) (params.ModelVisualizationResult, error) {
+	ok, err := api.authorizer.HasPermission(permission.ReadAccess, api.backend.ModelTag())
+	if err != nil {
+		return params.ModelVisualizationResult{}, errors.Trace(err)
+	}
+	if !ok {
+		return params.ModelVisualizationResult{}, common.ErrPerm
+	}
+
+	format := args.Format
+	if format == "" {
+		format = FormatDOT
+	}
+	var render func(*graph) string
+	switch format {
+	case FormatDOT:
+		render = renderDOT
+	case FormatMermaid:
+		render = renderMermaid
+	default:
+		return params.ModelVisualizationResult{}, errors.NotValidf("format %q", format)
+	}
+
+	topology, err := api.backend.Topology()
+	if err != nil {
+		return params.ModelVisualizationResult{}, errors.Trace(err)
+	}
+	g, err := newGraph(topology, Filter{
+		Applications:    args.Applications,
+		IncludeMachines: args.IncludeMachines,
+		IncludeSpaces:   args.IncludeSpaces,
+	})
+	if err != nil {
+		return params.ModelVisualizationResult{}, errors.Trace(err)
+	}
+	return params.ModelVisualizationResult{
+		Format:  format,
+		Content: render(g),
+	}, nil
+}
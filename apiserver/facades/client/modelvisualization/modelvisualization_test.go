@@ -0,0 +1,204 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelvisualization_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/modelvisualization"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type visualizationSuite struct {
+	testing.IsolationSuite
+	backend *mockBackend
+	api     *modelvisualization.API
+}
+
+var _ = gc.Suite(&visualizationSuite{})
+
+func (s *visualizationSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		topology: modelvisualization.Topology{
+			Model: "prod",
+			Applications: []modelvisualization.Application{{
+				Name:     "wordpress",
+				Charm:    "cs:wordpress-5",
+				Units:    2,
+				Machines: []string{"1", "0/lxd/0"},
+				Bindings: map[string]string{"": "public", "db": "internal"},
+			}, {
+				Name:     "mysql",
+				Charm:    "cs:mysql-57",
+				Units:    1,
+				Machines: []string{"0"},
+				Bindings: map[string]string{"": "internal", "cluster": ""},
+			}, {
+				Name:  "nrpe",
+				Charm: `cs:~"quoted"/nrpe-1`,
+			}},
+			Relations: []modelvisualization.Relation{{
+				Interface: "mysql",
+				Endpoints: []modelvisualization.Endpoint{
+					{Application: "mysql", Name: "db", Role: charm.RoleProvider},
+					{Application: "wordpress", Name: "db", Role: charm.RoleRequirer},
+				},
+			}, {
+				Interface: "mysql-ha",
+				Endpoints: []modelvisualization.Endpoint{
+					{Application: "mysql", Name: "cluster", Role: charm.RolePeer},
+				},
+			}},
+			Machines: []modelvisualization.Machine{
+				{Id: "0", Spaces: []string{"internal"}},
+				{Id: "0/lxd/0", Spaces: []string{"internal", "public"}},
+				{Id: "1", Spaces: []string{"public"}},
+				{Id: "2"},
+			},
+		},
+	}
+	api, err := modelvisualization.NewAPI(s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("read"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+}
+
+func (s *visualizationSuite) TestRequiresClient(c *gc.C) {
+	_, err := modelvisualization.NewAPI(s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *visualizationSuite) TestRequiresRead(c *gc.C) {
+	api, err := modelvisualization.NewAPI(s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("nobody"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.Render(params.ModelVisualizationArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *visualizationSuite) TestRenderDOT(c *gc.C) {
+	result, err := s.api.Render(params.ModelVisualizationArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Format, gc.Equals, "dot")
+	c.Assert(result.Content, gc.Equals, `
+digraph "prod" {
+	rankdir=LR;
+	"application-mysql" [label="mysql\ncs:mysql-57\n1 unit", shape=box];
+	"application-nrpe" [label="nrpe\ncs:~\"quoted\"/nrpe-1\n0 units", shape=box];
+	"application-wordpress" [label="wordpress\ncs:wordpress-5\n2 units", shape=box];
+	"application-wordpress" -> "application-mysql" [style=solid, label="mysql (db:db)"];
+}
+`[1:])
+}
+
+func (s *visualizationSuite) TestRenderDOTWithMachinesAndSpaces(c *gc.C) {
+	result, err := s.api.Render(params.ModelVisualizationArgs{
+		Format:          "dot",
+		Applications:    []string{"wordpress", "mysql"},
+		IncludeMachines: true,
+		IncludeSpaces:   true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Content, gc.Equals, `
+digraph "prod" {
+	rankdir=LR;
+	"application-mysql" [label="mysql\ncs:mysql-57\n1 unit", shape=box];
+	"application-wordpress" [label="wordpress\ncs:wordpress-5\n2 units", shape=box];
+	"machine-0" [label="machine 0", shape=ellipse];
+	"machine-0-lxd-0" [label="machine 0/lxd/0", shape=ellipse];
+	"machine-1" [label="machine 1", shape=ellipse];
+	"space-internal" [label="space internal", shape=hexagon];
+	"space-public" [label="space public", shape=hexagon];
+	"application-wordpress" -> "application-mysql" [style=solid, label="mysql (db:db)"];
+	"application-mysql" -> "machine-0" [style=dashed];
+	"application-wordpress" -> "machine-0-lxd-0" [style=dashed];
+	"application-wordpress" -> "machine-1" [style=dashed];
+	"application-mysql" -> "space-internal" [style=dotted, label="(default)"];
+	"application-wordpress" -> "space-internal" [style=dotted, label="db"];
+	"application-wordpress" -> "space-public" [style=dotted, label="(default)"];
+	"machine-0" -> "space-internal" [style=dotted];
+	"machine-0-lxd-0" -> "space-internal" [style=dotted];
+	"machine-0-lxd-0" -> "space-public" [style=dotted];
+	"machine-1" -> "space-public" [style=dotted];
+}
+`[1:])
+}
+
+func (s *visualizationSuite) TestRenderMermaid(c *gc.C) {
+	result, err := s.api.Render(params.ModelVisualizationArgs{
+		Format:          "mermaid",
+		IncludeMachines: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Format, gc.Equals, "mermaid")
+	c.Assert(result.Content, gc.Equals, `
+graph LR
+	%% model prod
+	application_mysql["mysql<br/>cs:mysql-57<br/>1 unit"]
+	application_nrpe["nrpe<br/>cs:~#quot;quoted#quot;/nrpe-1<br/>0 units"]
+	application_wordpress["wordpress<br/>cs:wordpress-5<br/>2 units"]
+	machine_0(["machine 0"])
+	machine_0_lxd_0(["machine 0/lxd/0"])
+	machine_1(["machine 1"])
+	machine_2(["machine 2"])
+	application_wordpress -->|"mysql (db:db)"| application_mysql
+	application_mysql -.-> machine_0
+	application_wordpress -.-> machine_0_lxd_0
+	application_wordpress -.-> machine_1
+`[1:])
+}
+
+func (s *visualizationSuite) TestRenderFilterOmitsUnrelated(c *gc.C) {
+	result, err := s.api.Render(params.ModelVisualizationArgs{
+		Format:       "mermaid",
+		Applications: []string{"wordpress"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Content, gc.Equals, `
+graph LR
+	%% model prod
+	application_wordpress["wordpress<br/>cs:wordpress-5<br/>2 units"]
+`[1:])
+}
+
+func (s *visualizationSuite) TestRenderUnknownApplication(c *gc.C) {
+	_, err := s.api.Render(params.ModelVisualizationArgs{
+		Applications: []string{"ghost"},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `application "ghost" not found`)
+}
+
+func (s *visualizationSuite) TestRenderUnknownFormat(c *gc.C) {
+	_, err := s.api.Render(params.ModelVisualizationArgs{Format: "svg"})
+	c.Assert(err, gc.ErrorMatches, `format "svg" not valid`)
+	s.backend.CheckNoCalls(c)
+}
+
+type mockBackend struct {
+	testing.Stub
+	topology modelvisualization.Topology
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) Topology() (modelvisualization.Topology, error) {
+	b.MethodCall(b, "Topology")
+	return b.topology, b.NextErr()
+}
@@ -0,0 +1,105 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelvisualization
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+var dotShapes = map[nodeKind]string{
+	applicationNode: "box",
+	machineNode:     "ellipse",
+	spaceNode:       "hexagon",
+}
+
+var dotStyles = map[edgeKind]string{
+	relationEdge:   "solid",
+	placementEdge:  "dashed",
+	bindingEdge:    "dotted",
+	connectionEdge: "dotted",
+}
+
+// renderDOT renders the graph in the Graphviz DOT language.
+func renderDOT(g *graph) string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "digraph %s {\n", dotQuote(g.name))
+	buf.WriteString("\trankdir=LR;\n")
+	for _, n := range g.nodes {
+		fmt.Fprintf(&buf, "\t%s [label=%s, shape=%s];\n",
+			dotQuote(n.id), dotQuote(strings.Join(n.label, "\n")), dotShapes[n.kind],
+		)
+	}
+	for _, e := range g.edges {
+		attrs := []string{"style=" + dotStyles[e.kind]}
+		if e.label != "" {
+			attrs = append(attrs, "label="+dotQuote(e.label))
+		}
+		fmt.Fprintf(&buf, "\t%s -> %s [%s];\n",
+			dotQuote(e.from), dotQuote(e.to), strings.Join(attrs, ", "),
+		)
+	}
+	buf.WriteString("}\n")
+	return buf.String()
+}
+
+// dotQuote returns s as a quoted DOT string, with newlines rendered as
+// line breaks.
+func dotQuote(s string) string {
+	s = strings.Replace(s, `\`, `\\`, -1)
+	s = strings.Replace(s, `"`, `\"`, -1)
+	s = strings.Replace(s, "\n", `\n`, -1)
+	return `"` + s + `"`
+}
+
+var mermaidShapes = map[nodeKind][2]string{
+	applicationNode: {"[", "]"},
+	machineNode:     {"([", "])"},
+	spaceNode:       {"{{", "}}"},
+}
+
+var mermaidArrows = map[edgeKind]string{
+	relationEdge:   "-->",
+	placementEdge:  "-.->",
+	bindingEdge:    "-.->",
+	connectionEdge: "-.->",
+}
+
+// renderMermaid renders the graph as a Mermaid flowchart.
+func renderMermaid(g *graph) string {
+	var buf bytes.Buffer
+	buf.WriteString("graph LR\n")
+	if g.name != "" {
+		fmt.Fprintf(&buf, "\t%%%% model %s\n", g.name)
+	}
+	for _, n := range g.nodes {
+		shape := mermaidShapes[n.kind]
+		fmt.Fprintf(&buf, "\t%s%s%s%s\n",
+			mermaidId(n.id), shape[0], mermaidQuote(strings.Join(n.label, "\n")), shape[1],
+		)
+	}
+	for _, e := range g.edges {
+		arrow := mermaidArrows[e.kind]
+		if e.label != "" {
+			arrow += "|" + mermaidQuote(e.label) + "|"
+		}
+		fmt.Fprintf(&buf, "\t%s %s %s\n", mermaidId(e.from), arrow, mermaidId(e.to))
+	}
+	return buf.String()
+}
+
+// mermaidId returns the Mermaid node id for the given tag. Hyphens are
+// replaced because Mermaid may parse them as part of a link.
+func mermaidId(tag string) string {
+	return strings.Replace(tag, "-", "_", -1)
+}
+
+// mermaidQuote returns s as a quoted Mermaid label, with newlines
+// rendered as line breaks.
+func mermaidQuote(s string) string {
+	s = strings.Replace(s, `"`, "#quot;", -1)
+	s = strings.Replace(s, "\n", "<br/>", -1)
+	return `"` + s + `"`
+}
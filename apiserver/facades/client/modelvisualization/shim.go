@@ -0,0 +1,107 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelvisualization
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/state"
+)
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(&backend{st}, auth)
+}
+
+type backend struct {
+	*state.State
+}
+
+// Topology is part of the Backend interface.
+func (b *backend) Topology() (Topology, error) {
+	model, err := b.Model()
+	if err != nil {
+		return Topology{}, errors.Trace(err)
+	}
+	result := Topology{Model: model.Name()}
+
+	apps, err := b.AllApplications()
+	if err != nil {
+		return Topology{}, errors.Trace(err)
+	}
+	for _, app := range apps {
+		application, err := applicationTopology(app)
+		if err != nil {
+			return Topology{}, errors.Annotatef(err, "application %q", app.Name())
+		}
+		result.Applications = append(result.Applications, application)
+	}
+
+	relations, err := b.AllRelations()
+	if err != nil {
+		return Topology{}, errors.Trace(err)
+	}
+	for _, rel := range relations {
+		endpoints := rel.Endpoints()
+		relation := Relation{
+			Interface: endpoints[0].Interface,
+			Endpoints: make([]Endpoint, len(endpoints)),
+		}
+		for i, ep := range endpoints {
+			relation.Endpoints[i] = Endpoint{
+				Application: ep.ApplicationName,
+				Name:        ep.Name,
+				Role:        ep.Role,
+			}
+		}
+		result.Relations = append(result.Relations, relation)
+	}
+
+	machines, err := b.AllMachines()
+	if err != nil {
+		return Topology{}, errors.Trace(err)
+	}
+	for _, m := range machines {
+		spaces, err := m.AllSpaces()
+		if err != nil {
+			return Topology{}, errors.Annotatef(err, "machine %q", m.Id())
+		}
+		result.Machines = append(result.Machines, Machine{
+			Id:     m.Id(),
+			Spaces: spaces.SortedValues(),
+		})
+	}
+	return result, nil
+}
+
+func applicationTopology(app *state.Application) (Application, error) {
+	result := Application{Name: app.Name()}
+	if curl, _ := app.CharmURL(); curl != nil {
+		result.Charm = curl.String()
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return Application{}, errors.Trace(err)
+	}
+	result.Units = len(units)
+	seen := make(map[string]bool)
+	for _, unit := range units {
+		id, err := unit.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			continue
+		} else if err != nil {
+			return Application{}, errors.Trace(err)
+		}
+		if !seen[id] {
+			seen[id] = true
+			result.Machines = append(result.Machines, id)
+		}
+	}
+	result.Bindings, err = app.EndpointBindings()
+	if err != nil {
+		return Application{}, errors.Trace(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,272 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelvisualization
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+)
+
+// Topology describes the parts of a model that can be rendered.
+type Topology struct {
+	// Model is the name of the model.
+	Model string
+
+	Applications []Application
+	Relations    []Relation
+	Machines     []Machine
+}
+
+// Application describes an application in the model.
+type Application struct {
+	Name  string
+	Charm string
+	Units int
+
+	// Machines holds the ids of the machines hosting the
+	// application's units.
+	Machines []string
+
+	// Bindings maps the application's endpoints to the spaces they
+	// are bound to. The empty endpoint holds the default space.
+	Bindings map[string]string
+}
+
+// Relation describes a relation between applications.
+type Relation struct {
+	Interface string
+	Endpoints []Endpoint
+}
+
+// Endpoint describes one end of a relation.
+type Endpoint struct {
+	Application string
+	Name        string
+	Role        charm.RelationRole
+}
+
+// Machine describes a machine in the model.
+type Machine struct {
+	Id string
+
+	// Spaces holds the names of the spaces the machine is connected
+	// to.
+	Spaces []string
+}
+
+// Filter selects the parts of a topology to render.
+type Filter struct {
+	// Applications, if not empty, restricts the graph to the named
+	// applications and the relations between them.
+	Applications []string
+
+	// IncludeMachines adds the machines hosting the applications'
+	// units to the graph. When no applications are named, machines
+	// without units are also included.
+	IncludeMachines bool
+
+	// IncludeSpaces adds the spaces the applications are bound to,
+	// and those that included machines are connected to, to the
+	// graph.
+	IncludeSpaces bool
+}
+
+type nodeKind int
+
+const (
+	applicationNode nodeKind = iota
+	machineNode
+	spaceNode
+)
+
+type edgeKind int
+
+const (
+	relationEdge edgeKind = iota
+	placementEdge
+	bindingEdge
+	connectionEdge
+)
+
+type node struct {
+	id    string
+	kind  nodeKind
+	label []string
+}
+
+type edge struct {
+	from  string
+	to    string
+	kind  edgeKind
+	label string
+}
+
+// graph is the format-independent form of a rendered topology. Nodes
+// are identified by the tags of the entities they represent, and both
+// nodes and edges are held in a stable order so that rendering the
+// same topology always produces the same text.
+type graph struct {
+	name  string
+	nodes []node
+	edges []edge
+}
+
+func newGraph(t Topology, filter Filter) (*graph, error) {
+	apps := make(map[string]Application)
+	for _, app := range t.Applications {
+		apps[app.Name] = app
+	}
+	selected := set.NewStrings()
+	if len(filter.Applications) == 0 {
+		for name := range apps {
+			selected.Add(name)
+		}
+	}
+	for _, name := range filter.Applications {
+		if _, ok := apps[name]; !ok {
+			return nil, errors.NotFoundf("application %q", name)
+		}
+		selected.Add(name)
+	}
+
+	g := &graph{name: t.Model}
+	var edges []edge
+	for _, name := range selected.SortedValues() {
+		app := apps[name]
+		g.nodes = append(g.nodes, node{
+			id:    names.NewApplicationTag(name).String(),
+			kind:  applicationNode,
+			label: []string{name, app.Charm, unitCount(app.Units)},
+		})
+	}
+	for _, rel := range t.Relations {
+		e, ok := relationEdgeFor(rel, selected)
+		if ok {
+			edges = append(edges, e)
+		}
+	}
+
+	machines := set.NewStrings()
+	if filter.IncludeMachines {
+		if len(filter.Applications) == 0 {
+			for _, m := range t.Machines {
+				machines.Add(m.Id)
+			}
+		}
+		for _, name := range selected.Values() {
+			for _, id := range apps[name].Machines {
+				machines.Add(id)
+				edges = append(edges, edge{
+					from: names.NewApplicationTag(name).String(),
+					to:   names.NewMachineTag(id).String(),
+					kind: placementEdge,
+				})
+			}
+		}
+		for _, id := range utils.SortStringsNaturally(machines.Values()) {
+			g.nodes = append(g.nodes, node{
+				id:    names.NewMachineTag(id).String(),
+				kind:  machineNode,
+				label: []string{"machine " + id},
+			})
+		}
+	}
+
+	if filter.IncludeSpaces {
+		spaces := set.NewStrings()
+		for _, name := range selected.Values() {
+			bound := make(map[string][]string)
+			for endpoint, space := range apps[name].Bindings {
+				if space == "" {
+					continue
+				}
+				if endpoint == "" {
+					endpoint = "(default)"
+				}
+				bound[space] = append(bound[space], endpoint)
+			}
+			for space, endpoints := range bound {
+				sort.Strings(endpoints)
+				spaces.Add(space)
+				edges = append(edges, edge{
+					from:  names.NewApplicationTag(name).String(),
+					to:    names.NewSpaceTag(space).String(),
+					kind:  bindingEdge,
+					label: strings.Join(endpoints, ", "),
+				})
+			}
+		}
+		for _, m := range t.Machines {
+			if !machines.Contains(m.Id) {
+				continue
+			}
+			for _, space := range m.Spaces {
+				spaces.Add(space)
+				edges = append(edges, edge{
+					from: names.NewMachineTag(m.Id).String(),
+					to:   names.NewSpaceTag(space).String(),
+					kind: connectionEdge,
+				})
+			}
+		}
+		for _, space := range spaces.SortedValues() {
+			g.nodes = append(g.nodes, node{
+				id:    names.NewSpaceTag(space).String(),
+				kind:  spaceNode,
+				label: []string{"space " + space},
+			})
+		}
+	}
+
+	sort.SliceStable(edges, func(i, j int) bool {
+		if edges[i].kind != edges[j].kind {
+			return edges[i].kind < edges[j].kind
+		}
+		if edges[i].from != edges[j].from {
+			return edges[i].from < edges[j].from
+		}
+		if edges[i].to != edges[j].to {
+			return edges[i].to < edges[j].to
+		}
+		return edges[i].label < edges[j].label
+	})
+	g.edges = edges
+	return g, nil
+}
+
+// relationEdgeFor returns the edge for the given relation, drawn from
+// the requirer to the provider, if both ends are selected. Peer
+// relations are not drawn.
+func relationEdgeFor(rel Relation, selected set.Strings) (edge, bool) {
+	if len(rel.Endpoints) != 2 {
+		return edge{}, false
+	}
+	from, to := rel.Endpoints[0], rel.Endpoints[1]
+	if from.Role == charm.RoleProvider {
+		from, to = to, from
+	}
+	if !selected.Contains(from.Application) || !selected.Contains(to.Application) {
+		return edge{}, false
+	}
+	return edge{
+		from:  names.NewApplicationTag(from.Application).String(),
+		to:    names.NewApplicationTag(to.Application).String(),
+		kind:  relationEdge,
+		label: fmt.Sprintf("%s (%s:%s)", rel.Interface, from.Name, to.Name),
+	}, true
+}
+
+func unitCount(n int) string {
+	if n == 1 {
+		return "1 unit"
+	}
+	return fmt.Sprintf("%d units", n)
+}
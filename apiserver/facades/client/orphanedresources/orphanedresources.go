@@ -0,0 +1,73 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package orphanedresources implements the OrphanedResources facade,
+// which reports the provider resources that juju created for a model
+// but no longer tracks, as found by the orphan sweeper.
+package orphanedresources
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the
+// OrphanedResources facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+	OrphanedResources() (state.OrphanedResources, error)
+}
+
+// API implements the OrphanedResources facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewAPI returns a new OrphanedResources facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(st, auth)
+}
+
+// OrphanedResources returns the results of the most recent sweep of
+// the model's provider resources. If the model has not been swept yet,
+// the result holds a not found error.
+func (api *API) OrphanedResources() (params.OrphanedResourcesResult, error) {
+	ok, err := api.authorizer.HasPermission(permission.ReadAccess, api.backend.ModelTag())
+	if err != nil {
+		return params.OrphanedResourcesResult{}, errors.Trace(err)
+	}
+	if !ok {
+		return params.OrphanedResourcesResult{}, common.ErrPerm
+	}
+	orphans, err := api.backend.OrphanedResources()
+	if err != nil {
+		return params.OrphanedResourcesResult{Error: common.ServerError(err)}, nil
+	}
+	return params.OrphanedResourcesResult{
+		Result: &params.OrphanedResources{
+			Swept:          orphans.Swept,
+			Instances:      orphans.Instances,
+			Volumes:        orphans.Volumes,
+			SecurityGroups: orphans.SecurityGroups,
+			Cleaned:        orphans.Cleaned,
+		},
+	}, nil
+}
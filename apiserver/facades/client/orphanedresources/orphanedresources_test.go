@@ -0,0 +1,95 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package orphanedresources_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/orphanedresources"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type orphanedResourcesSuite struct {
+	testing.IsolationSuite
+	backend *mockBackend
+}
+
+var _ = gc.Suite(&orphanedResourcesSuite{})
+
+func (s *orphanedResourcesSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{}
+}
+
+func (s *orphanedResourcesSuite) newAPI(c *gc.C, user string) *orphanedresources.API {
+	api, err := orphanedresources.NewAPI(s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag(user),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *orphanedResourcesSuite) TestRequiresClient(c *gc.C) {
+	_, err := orphanedresources.NewAPI(s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *orphanedResourcesSuite) TestOrphanedResources(c *gc.C) {
+	swept := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	s.backend.orphans = state.OrphanedResources{
+		Swept:     swept,
+		Instances: []string{"i-0"},
+		Volumes:   []string{"vol-0", "vol-1"},
+		Cleaned:   []string{"vol-1"},
+	}
+	result, err := s.newAPI(c, "read").OrphanedResources()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.OrphanedResourcesResult{
+		Result: &params.OrphanedResources{
+			Swept:     swept,
+			Instances: []string{"i-0"},
+			Volumes:   []string{"vol-0", "vol-1"},
+			Cleaned:   []string{"vol-1"},
+		},
+	})
+}
+
+func (s *orphanedResourcesSuite) TestOrphanedResourcesNotSwept(c *gc.C) {
+	s.backend.SetErrors(errors.NotFoundf("orphaned resources"))
+	result, err := s.newAPI(c, "read").OrphanedResources()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Result, gc.IsNil)
+	c.Assert(result.Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *orphanedResourcesSuite) TestOrphanedResourcesPermissionDenied(c *gc.C) {
+	_, err := s.newAPI(c, "bob").OrphanedResources()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+type mockBackend struct {
+	testing.Stub
+	orphans state.OrphanedResources
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) OrphanedResources() (state.OrphanedResources, error) {
+	b.MethodCall(b, "OrphanedResources")
+	return b.orphans, b.NextErr()
+}
@@ -46,6 +46,8 @@ func NewAPI(backend payloadBackend) *API {
 // List builds the list of payloads being tracked for
 // the given unit and IDs. If no IDs are provided then all tracked
 // payloads for the unit are returned.
+//
+//juju:readonly
 func (a API) List(args params.PayloadListArgs) (params.PayloadListResults, error) {
 	var r params.PayloadListResults
 
@@ -0,0 +1,165 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package pubsubtopology implements the PubSubTopology facade, which
+// reports how pubsub messages are forwarded between the controller's
+// API servers.
+package pubsubtopology
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/pubsub/forwarder"
+)
+
+// defaultTimeout is how long to wait for the API servers to report
+// their forwarding status.
+const defaultTimeout = 5 * time.Second
+
+// API implements the PubSubTopology facade.
+type API struct {
+	hub     facade.Hub
+	clock   clock.Clock
+	timeout time.Duration
+}
+
+// NewAPI returns a new PubSubTopology facade.
+func NewAPI(
+	hub facade.Hub,
+	authorizer facade.Authorizer,
+	controllerTag names.ControllerTag,
+	clock clock.Clock,
+	timeout time.Duration,
+) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	isAdmin, err := authorizer.HasPermission(permission.SuperuserAccess, controllerTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !isAdmin {
+		return nil, common.ErrPerm
+	}
+	if hub == nil {
+		return nil, errors.NotSupportedf("pubsub on this API server")
+	}
+	return &API{
+		hub:     hub,
+		clock:   clock,
+		timeout: timeout,
+	}, nil
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(
+		ctx.Hub(),
+		ctx.Auth(),
+		ctx.State().ControllerTag(),
+		clock.WallClock,
+		defaultTimeout,
+	)
+}
+
+// Topology asks every API server for the status of its connections
+// to the other API servers, and returns the responses. API servers
+// that are known to exist, but which do not respond before the
+// timeout, are reported with an error.
+//
+//juju:readonly
+func (api *API) Topology() (params.PubSubTopology, error) {
+	requestID, err := utils.NewUUID()
+	if err != nil {
+		return params.PubSubTopology{}, errors.Trace(err)
+	}
+	done := make(chan struct{})
+	defer close(done)
+	responses := make(chan forwarder.Status)
+	unsub, err := api.hub.Subscribe(forwarder.StatusTopic,
+		func(_ string, status forwarder.Status, err error) {
+			if err != nil || status.RequestID != requestID.String() {
+				return
+			}
+			select {
+			case responses <- status:
+			case <-done:
+			}
+		})
+	if err != nil {
+		return params.PubSubTopology{}, errors.Trace(err)
+	}
+	defer unsub()
+
+	_, err = api.hub.Publish(forwarder.StatusRequestTopic, forwarder.StatusRequest{
+		RequestID: requestID.String(),
+	})
+	if err != nil {
+		return params.PubSubTopology{}, errors.Annotate(err, "requesting pubsub status")
+	}
+
+	// Every origin and every target named in a response is expected
+	// to respond; we stop waiting once they all have.
+	expected := make(map[string]bool)
+	received := make(map[string]forwarder.Status)
+	timeout := api.clock.After(api.timeout)
+collect:
+	for {
+		select {
+		case status := <-responses:
+			received[status.Origin] = status
+			expected[status.Origin] = true
+			for _, target := range status.Targets {
+				expected[target.Target] = true
+			}
+			if len(received) == len(expected) {
+				break collect
+			}
+		case <-timeout:
+			break collect
+		}
+	}
+	if len(received) == 0 {
+		return params.PubSubTopology{}, errors.New("no API servers reported pubsub status")
+	}
+
+	origins := make([]string, 0, len(expected))
+	for origin := range expected {
+		origins = append(origins, origin)
+	}
+	sort.Strings(origins)
+	result := params.PubSubTopology{
+		Servers: make([]params.PubSubServer, len(origins)),
+	}
+	for i, origin := range origins {
+		server := params.PubSubServer{
+			Tag:     origin,
+			Targets: []params.PubSubTarget{},
+		}
+		status, ok := received[origin]
+		if !ok {
+			server.Error = common.ServerError(errors.Errorf("API server did not report pubsub status"))
+		}
+		for _, target := range status.Targets {
+			server.Targets = append(server.Targets, params.PubSubTarget{
+				Tag:         target.Target,
+				Connected:   target.Connected,
+				Addresses:   target.Addresses,
+				QueueLength: target.QueueLength,
+				SentCount:   target.SentCount,
+			})
+		}
+		result.Servers[i] = server
+	}
+	return result, nil
+}
@@ -0,0 +1,165 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package pubsubtopology_test
+
+import (
+	"time"
+
+	"github.com/juju/pubsub"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/pubsubtopology"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/pubsub/centralhub"
+	"github.com/juju/juju/pubsub/forwarder"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type topologySuite struct {
+	testing.IsolationSuite
+	clock *testing.Clock
+	hub   *pubsub.StructuredHub
+}
+
+var _ = gc.Suite(&topologySuite{})
+
+func (s *topologySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC))
+	s.hub = centralhub.New(names.NewMachineTag("0"))
+}
+
+func (s *topologySuite) newAPI(c *gc.C) *pubsubtopology.API {
+	api, err := pubsubtopology.NewAPI(s.hub, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("superuser"),
+	}, coretesting.ControllerTag, s.clock, time.Second)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+// respond arranges for the given statuses to be published in response
+// to each status request, as the pubsub workers would.
+func (s *topologySuite) respond(c *gc.C, statuses ...forwarder.Status) {
+	unsub, err := s.hub.Subscribe(forwarder.StatusRequestTopic,
+		func(_ string, request forwarder.StatusRequest, err error) {
+			c.Check(err, jc.ErrorIsNil)
+			for _, status := range statuses {
+				status.RequestID = request.RequestID
+				_, err := s.hub.Publish(forwarder.StatusTopic, status)
+				c.Check(err, jc.ErrorIsNil)
+			}
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { unsub() })
+}
+
+func (s *topologySuite) TestRequiresClient(c *gc.C) {
+	_, err := pubsubtopology.NewAPI(s.hub, apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	}, coretesting.ControllerTag, s.clock, time.Second)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *topologySuite) TestRequiresSuperuser(c *gc.C) {
+	_, err := pubsubtopology.NewAPI(s.hub, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("read"),
+	}, coretesting.ControllerTag, s.clock, time.Second)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *topologySuite) TestTopology(c *gc.C) {
+	s.respond(c, forwarder.Status{
+		Origin: "machine-0",
+		Targets: []forwarder.TargetStatus{{
+			Target:      "machine-1",
+			Connected:   true,
+			Addresses:   []string{"10.0.0.2:17070"},
+			QueueLength: 2,
+			SentCount:   42,
+		}},
+	}, forwarder.Status{
+		Origin: "machine-1",
+		Targets: []forwarder.TargetStatus{{
+			Target:    "machine-0",
+			Addresses: []string{"10.0.0.1:17070"},
+		}},
+	})
+
+	result, err := s.newAPI(c).Topology()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.PubSubTopology{
+		Servers: []params.PubSubServer{{
+			Tag: "machine-0",
+			Targets: []params.PubSubTarget{{
+				Tag:         "machine-1",
+				Connected:   true,
+				Addresses:   []string{"10.0.0.2:17070"},
+				QueueLength: 2,
+				SentCount:   42,
+			}},
+		}, {
+			Tag: "machine-1",
+			Targets: []params.PubSubTarget{{
+				Tag:       "machine-0",
+				Addresses: []string{"10.0.0.1:17070"},
+			}},
+		}},
+	})
+}
+
+func (s *topologySuite) TestTopologyMissingServer(c *gc.C) {
+	s.respond(c, forwarder.Status{
+		Origin: "machine-0",
+		Targets: []forwarder.TargetStatus{{
+			Target:    "machine-1",
+			Addresses: []string{"10.0.0.2:17070"},
+		}},
+	})
+
+	api := s.newAPI(c)
+	type topologyResult struct {
+		result params.PubSubTopology
+		err    error
+	}
+	results := make(chan topologyResult, 1)
+	go func() {
+		result, err := api.Topology()
+		results <- topologyResult{result, err}
+	}()
+	c.Assert(s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+
+	select {
+	case r := <-results:
+		c.Assert(r.err, jc.ErrorIsNil)
+		c.Assert(r.result.Servers, gc.HasLen, 2)
+		c.Assert(r.result.Servers[0].Tag, gc.Equals, "machine-0")
+		c.Assert(r.result.Servers[0].Error, gc.IsNil)
+		c.Assert(r.result.Servers[1].Tag, gc.Equals, "machine-1")
+		c.Assert(r.result.Servers[1].Error, gc.ErrorMatches, "API server did not report pubsub status")
+		c.Assert(r.result.Servers[1].Targets, gc.HasLen, 0)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for topology")
+	}
+}
+
+func (s *topologySuite) TestTopologyNoResponse(c *gc.C) {
+	api := s.newAPI(c)
+	errs := make(chan error, 1)
+	go func() {
+		_, err := api.Topology()
+		errs <- err
+	}()
+	c.Assert(s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+
+	select {
+	case err := <-errs:
+		c.Assert(err, gc.ErrorMatches, "no API servers reported pubsub status")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for topology")
+	}
+}
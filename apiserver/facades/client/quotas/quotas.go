@@ -0,0 +1,117 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package quotas implements the Quotas facade, which reports and sets
+// the limits on the machines, cores, units and storage instances a
+// model may use.
+package quotas
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the Quotas
+// facade.
+type Backend interface {
+	ControllerTag() names.ControllerTag
+	ModelTag() names.ModelTag
+	ModelQuotas() (state.ModelQuotas, error)
+	SetModelQuotas(state.ModelQuotas) error
+	ModelResourceUsage() (state.ModelResources, error)
+}
+
+// API implements the Quotas facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewAPI returns a new Quotas facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(st, auth)
+}
+
+func (api *API) isControllerAdmin() (bool, error) {
+	return api.authorizer.HasPermission(permission.SuperuserAccess, api.backend.ControllerTag())
+}
+
+// ModelQuotas returns the model's quotas, along with its current usage
+// of the limited resources. Only model and controller admins may see
+// them.
+//
+//juju:readonly
+func (api *API) ModelQuotas() (params.ModelQuotasResult, error) {
+	isControllerAdmin, err := api.isControllerAdmin()
+	if err != nil {
+		return params.ModelQuotasResult{}, errors.Trace(err)
+	}
+	isModelAdmin, err := api.authorizer.HasPermission(permission.AdminAccess, api.backend.ModelTag())
+	if err != nil {
+		return params.ModelQuotasResult{}, errors.Trace(err)
+	}
+	if !isControllerAdmin && !isModelAdmin {
+		return params.ModelQuotasResult{}, common.ErrPerm
+	}
+
+	quotas, err := api.backend.ModelQuotas()
+	if err != nil {
+		return params.ModelQuotasResult{Error: common.ServerError(err)}, nil
+	}
+	usage, err := api.backend.ModelResourceUsage()
+	if err != nil {
+		return params.ModelQuotasResult{Error: common.ServerError(err)}, nil
+	}
+	return params.ModelQuotasResult{
+		Quotas: params.ModelQuotas{
+			Machines: quotas.Machines,
+			Cores:    quotas.Cores,
+			Units:    quotas.Units,
+			Storage:  quotas.Storage,
+		},
+		Usage: params.ModelResources{
+			Machines: usage.Machines,
+			Cores:    usage.Cores,
+			Units:    usage.Units,
+			Storage:  usage.Storage,
+		},
+	}, nil
+}
+
+// SetModelQuotas replaces the model's quotas. Only controller admins
+// may set quotas, so that model admins cannot raise their own limits.
+// Lowering a quota below the model's current usage does not remove
+// anything; it only prevents further additions.
+func (api *API) SetModelQuotas(args params.ModelQuotas) error {
+	isControllerAdmin, err := api.isControllerAdmin()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isControllerAdmin {
+		return common.ErrPerm
+	}
+	err = api.backend.SetModelQuotas(state.ModelQuotas{
+		Machines: args.Machines,
+		Cores:    args.Cores,
+		Units:    args.Units,
+		Storage:  args.Storage,
+	})
+	return errors.Trace(err)
+}
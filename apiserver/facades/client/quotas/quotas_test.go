@@ -0,0 +1,126 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package quotas_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/quotas"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type quotasSuite struct {
+	testing.IsolationSuite
+	backend *mockBackend
+}
+
+var _ = gc.Suite(&quotasSuite{})
+
+func (s *quotasSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		quotas: state.ModelQuotas{Machines: 10, Units: 20},
+		usage:  state.ModelResources{Machines: 3, Cores: 12, Units: 5, Storage: 2},
+	}
+}
+
+func (s *quotasSuite) newAPI(c *gc.C, user string) *quotas.API {
+	api, err := quotas.NewAPI(s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag(user),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *quotasSuite) TestRequiresClient(c *gc.C) {
+	_, err := quotas.NewAPI(s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *quotasSuite) assertModelQuotas(c *gc.C, user string) {
+	result, err := s.newAPI(c, user).ModelQuotas()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelQuotasResult{
+		Quotas: params.ModelQuotas{Machines: 10, Units: 20},
+		Usage:  params.ModelResources{Machines: 3, Cores: 12, Units: 5, Storage: 2},
+	})
+	s.backend.CheckCallNames(c, "ModelQuotas", "ModelResourceUsage")
+}
+
+func (s *quotasSuite) TestModelQuotasModelAdmin(c *gc.C) {
+	s.assertModelQuotas(c, "admin-"+coretesting.ModelTag.String())
+}
+
+func (s *quotasSuite) TestModelQuotasControllerAdmin(c *gc.C) {
+	s.assertModelQuotas(c, "superuser")
+}
+
+func (s *quotasSuite) TestModelQuotasPermissionDenied(c *gc.C) {
+	_, err := s.newAPI(c, "read").ModelQuotas()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *quotasSuite) TestModelQuotasError(c *gc.C) {
+	s.backend.SetErrors(nil, errors.New("kaboom"))
+	result, err := s.newAPI(c, "superuser").ModelQuotas()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "kaboom")
+}
+
+func (s *quotasSuite) TestSetModelQuotas(c *gc.C) {
+	err := s.newAPI(c, "superuser").SetModelQuotas(params.ModelQuotas{Machines: 5, Cores: 8})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCall(c, 0, "SetModelQuotas", state.ModelQuotas{Machines: 5, Cores: 8})
+}
+
+func (s *quotasSuite) TestSetModelQuotasModelAdminDenied(c *gc.C) {
+	err := s.newAPI(c, "admin-"+coretesting.ModelTag.String()).SetModelQuotas(params.ModelQuotas{Machines: 50})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *quotasSuite) TestSetModelQuotasInvalid(c *gc.C) {
+	s.backend.SetErrors(errors.NotValidf("negative quota"))
+	err := s.newAPI(c, "superuser").SetModelQuotas(params.ModelQuotas{Units: -1})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+type mockBackend struct {
+	testing.Stub
+	quotas state.ModelQuotas
+	usage  state.ModelResources
+}
+
+func (b *mockBackend) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) ModelQuotas() (state.ModelQuotas, error) {
+	b.MethodCall(b, "ModelQuotas")
+	return b.quotas, b.NextErr()
+}
+
+func (b *mockBackend) SetModelQuotas(quotas state.ModelQuotas) error {
+	b.MethodCall(b, "SetModelQuotas", quotas)
+	return b.NextErr()
+}
+
+func (b *mockBackend) ModelResourceUsage() (state.ModelResources, error) {
+	b.MethodCall(b, "ModelResourceUsage")
+	return b.usage, b.NextErr()
+}
@@ -97,6 +97,8 @@ func NewFacade(store Backend, newClient func() (CharmStore, error)) (*Facade, er
 }
 
 // ListResources returns the list of resources for the given application.
+//
+//juju:readonly
 func (f Facade) ListResources(args params.ListResourcesArgs) (params.ResourcesResults, error) {
 	var r params.ResourcesResults
 	r.Results = make([]params.ResourcesResult, len(args.Entities))
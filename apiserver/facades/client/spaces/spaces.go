@@ -18,6 +18,7 @@ import (
 // API defines the methods the Spaces API facade implements.
 type API interface {
 	CreateSpaces(params.CreateSpacesParams) (params.ErrorResults, error)
+	//juju:readonly
 	ListSpaces() (params.ListSpacesResults, error)
 	ReloadSpaces() error
 }
@@ -25,6 +26,7 @@ type API interface {
 // APIV2 is missing ReloadSpaces method
 type APIV2 interface {
 	CreateSpaces(params.CreateSpacesParams) (params.ErrorResults, error)
+	//juju:readonly
 	ListSpaces() (params.ListSpacesResults, error)
 }
 
@@ -46,6 +46,8 @@ func (facade *Facade) checkIsModelAdmin() error {
 
 // PublicAddress reports the preferred public network address for one
 // or more entities. Machines and units are suppored.
+//
+//juju:readonly
 func (facade *Facade) PublicAddress(args params.Entities) (params.SSHAddressResults, error) {
 	if err := facade.checkIsModelAdmin(); err != nil {
 		return params.SSHAddressResults{}, errors.Trace(err)
@@ -57,6 +59,8 @@ func (facade *Facade) PublicAddress(args params.Entities) (params.SSHAddressResu
 
 // PrivateAddress reports the preferred private network address for one or
 // more entities. Machines and units are supported.
+//
+//juju:readonly
 func (facade *Facade) PrivateAddress(args params.Entities) (params.SSHAddressResults, error) {
 	if err := facade.checkIsModelAdmin(); err != nil {
 		return params.SSHAddressResults{}, errors.Trace(err)
@@ -71,6 +75,8 @@ func (facade *Facade) PrivateAddress(args params.Entities) (params.SSHAddressRes
 // TODO(wpk): 2017-05-17 This is a temporary solution, we should not fetch environ here
 // but get the addresses from state. We will be changing it since we want to have space-aware
 // SSH settings.
+//
+//juju:readonly
 func (facade *Facade) AllAddresses(args params.Entities) (params.SSHAddressesResults, error) {
 	if err := facade.checkIsModelAdmin(); err != nil {
 		return params.SSHAddressesResults{}, errors.Trace(err)
@@ -166,6 +172,8 @@ func (facade *Facade) getAddressPerEntity(args params.Entities, addressGetter fu
 
 // PublicKeys returns the public SSH hosts for one or more
 // entities. Machines and units are supported.
+//
+//juju:readonly
 func (facade *Facade) PublicKeys(args params.Entities) (params.SSHPublicKeysResults, error) {
 	if err := facade.checkIsModelAdmin(); err != nil {
 		return params.SSHPublicKeysResults{}, errors.Trace(err)
@@ -192,6 +200,8 @@ func (facade *Facade) PublicKeys(args params.Entities) (params.SSHPublicKeysResu
 
 // Proxy returns whether SSH connections should be proxied through the
 // controller hosts for the model associated with the API connection.
+//
+//juju:readonly
 func (facade *Facade) Proxy() (params.SSHProxyResult, error) {
 	if err := facade.checkIsModelAdmin(); err != nil {
 		return params.SSHProxyResult{}, errors.Trace(err)
@@ -61,6 +61,7 @@ type mockState struct {
 	filesystemAttachments               func(filesystem names.FilesystemTag) ([]state.FilesystemAttachment, error)
 	allFilesystems                      func() ([]state.Filesystem, error)
 	addStorageForUnit                   func(u names.UnitTag, name string, cons state.StorageConstraints) ([]names.StorageTag, error)
+	checkModelQuotas                    func(state.ModelResources) error
 	getBlockForType                     func(t state.BlockType) (state.Block, bool, error)
 	blockDevices                        func(names.MachineTag) ([]state.BlockDeviceInfo, error)
 	destroyStorageInstance              func(names.StorageTag, bool) error
@@ -68,6 +69,8 @@ type mockState struct {
 	attachStorage                       func(names.StorageTag, names.UnitTag) error
 	detachStorage                       func(names.StorageTag, names.UnitTag) error
 	addExistingFilesystem               func(state.FilesystemInfo, *state.VolumeInfo, string) (names.StorageTag, error)
+	volumeStatusHistory                 func(names.VolumeTag, status.StatusHistoryFilter) ([]status.StatusInfo, error)
+	filesystemStatusHistory             func(names.FilesystemTag, status.StatusHistoryFilter) ([]status.StatusInfo, error)
 }
 
 func (st *mockState) StorageInstance(s names.StorageTag) (state.StorageInstance, error) {
@@ -146,6 +149,10 @@ func (st *mockState) Volume(tag names.VolumeTag) (state.Volume, error) {
 	return st.volume(tag)
 }
 
+func (st *mockState) VolumeStatusHistory(tag names.VolumeTag, filter status.StatusHistoryFilter) ([]status.StatusInfo, error) {
+	return st.volumeStatusHistory(tag, filter)
+}
+
 func (st *mockState) AllFilesystems() ([]state.Filesystem, error) {
 	return st.allFilesystems()
 }
@@ -162,10 +169,21 @@ func (st *mockState) Filesystem(tag names.FilesystemTag) (state.Filesystem, erro
 	return st.filesystem(tag)
 }
 
+func (st *mockState) FilesystemStatusHistory(tag names.FilesystemTag, filter status.StatusHistoryFilter) ([]status.StatusInfo, error) {
+	return st.filesystemStatusHistory(tag, filter)
+}
+
 func (st *mockState) AddStorageForUnit(u names.UnitTag, name string, cons state.StorageConstraints) ([]names.StorageTag, error) {
 	return st.addStorageForUnit(u, name, cons)
 }
 
+func (st *mockState) CheckModelQuotas(requested state.ModelResources) error {
+	if st.checkModelQuotas == nil {
+		return nil
+	}
+	return st.checkModelQuotas(requested)
+}
+
 func (st *mockState) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
 	return st.getBlockForType(t)
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/stateenvirons"
+	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage/poolmanager"
 )
 
@@ -19,6 +20,26 @@ import (
 // to change any part of it so that it were no longer *obviously* and
 // *trivially* correct, you would be Doing It Wrong.
 
+// NewFacadeV5 provides the signature required for facade registration.
+func NewFacadeV5(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv5, error) {
+	env, err := stateenvirons.GetNewEnvironFunc(environs.New)(st)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting environ")
+	}
+	registry := stateenvirons.NewStorageProviderRegistry(env)
+	pm := poolmanager.New(state.NewStateSettings(st), registry)
+
+	backend, err := getState(st)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting backend")
+	}
+	return NewAPIv5(backend, registry, pm, resources, authorizer)
+}
+
 // NewFacadeV4 provides the signature required for facade registration.
 func NewFacadeV4(
 	st *state.State,
@@ -120,6 +141,9 @@ type storageAccess interface {
 	// Volume is required for volume functionality.
 	Volume(tag names.VolumeTag) (state.Volume, error)
 
+	// VolumeStatusHistory is required for status history functionality.
+	VolumeStatusHistory(names.VolumeTag, status.StatusHistoryFilter) ([]status.StatusInfo, error)
+
 	// AllFilesystems is required for filesystem functionality.
 	AllFilesystems() ([]state.Filesystem, error)
 
@@ -132,9 +156,16 @@ type storageAccess interface {
 	// Filesystem is required for filesystem functionality.
 	Filesystem(tag names.FilesystemTag) (state.Filesystem, error)
 
+	// FilesystemStatusHistory is required for status history functionality.
+	FilesystemStatusHistory(names.FilesystemTag, status.StatusHistoryFilter) ([]status.StatusInfo, error)
+
 	// AddStorageForUnit is required for storage add functionality.
 	AddStorageForUnit(tag names.UnitTag, name string, cons state.StorageConstraints) ([]names.StorageTag, error)
 
+	// CheckModelQuotas is required to enforce the model's quotas
+	// when adding storage.
+	CheckModelQuotas(requested state.ModelResources) error
+
 	// GetBlockForType is required to block operations.
 	GetBlockForType(t state.BlockType) (state.Block, bool, error)
 
@@ -0,0 +1,92 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/status"
+)
+
+// StatusHistory returns the past statuses of the volumes and
+// filesystems identified by the requests, oldest first. The statuses
+// record the storage provisioner's progress in provisioning, attaching
+// and detaching the storage, along with any error reported by the
+// storage provider.
+//
+//juju:readonly
+func (a *APIv5) StatusHistory(args params.StatusHistoryRequests) params.StatusHistoryResults {
+	results := params.StatusHistoryResults{
+		Results: make([]params.StatusHistoryResult, len(args.Requests)),
+	}
+	if err := a.checkCanRead(); err != nil {
+		for i := range results.Results {
+			results.Results[i].Error = common.ServerError(err)
+		}
+		return results
+	}
+	for i, arg := range args.Requests {
+		history, err := a.statusHistory(arg)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(
+				errors.Annotatef(err, "fetching status history for %q", arg.Tag),
+			)
+			continue
+		}
+		results.Results[i].History = params.History{Statuses: history}
+	}
+	return results
+}
+
+func (a *APIv5) statusHistory(arg params.StatusHistoryRequest) ([]params.DetailedStatus, error) {
+	filter := status.StatusHistoryFilter{
+		Size:     arg.Filter.Size,
+		FromDate: arg.Filter.Date,
+		Delta:    arg.Filter.Delta,
+		Exclude:  set.NewStrings(arg.Filter.Exclude...),
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, errors.Annotate(err, "cannot validate status history filter")
+	}
+
+	var (
+		statuses []status.StatusInfo
+		err      error
+	)
+	kind := status.HistoryKind(arg.Kind)
+	switch kind {
+	case status.KindVolume:
+		var tag names.VolumeTag
+		if tag, err = names.ParseVolumeTag(arg.Tag); err == nil {
+			statuses, err = a.storage.VolumeStatusHistory(tag, filter)
+		}
+	case status.KindFilesystem:
+		var tag names.FilesystemTag
+		if tag, err = names.ParseFilesystemTag(arg.Tag); err == nil {
+			statuses, err = a.storage.FilesystemStatusHistory(tag, filter)
+		}
+	default:
+		err = errors.NotValidf("status history kind %q", kind)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// State returns the newest statuses first.
+	history := make([]params.DetailedStatus, len(statuses))
+	for i, s := range statuses {
+		history[len(statuses)-1-i] = params.DetailedStatus{
+			Status: string(s.Status),
+			Info:   s.Message,
+			Data:   s.Data,
+			Since:  s.Since,
+			Kind:   string(kind),
+		}
+	}
+	return history, nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"time"
+
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/storage"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type statusHistorySuite struct {
+	baseStorageSuite
+	apiv5 *storage.APIv5
+}
+
+var _ = gc.Suite(&statusHistorySuite{})
+
+func (s *statusHistorySuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+	var err error
+	s.apiv5, err = storage.NewAPIv5(s.state, s.registry, s.poolManager, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *statusHistorySuite) TestStatusHistory(c *gc.C) {
+	earlier := coretesting.ZeroTime()
+	later := earlier.Add(time.Minute)
+	s.state.volumeStatusHistory = func(tag names.VolumeTag, filter status.StatusHistoryFilter) ([]status.StatusInfo, error) {
+		s.stub.AddCall("VolumeStatusHistory", tag, filter.Size)
+		return []status.StatusInfo{
+			{Status: status.Attached, Since: &later},
+			{Status: status.Attaching, Message: "provider says no", Since: &earlier},
+		}, nil
+	}
+	s.state.filesystemStatusHistory = func(tag names.FilesystemTag, filter status.StatusHistoryFilter) ([]status.StatusInfo, error) {
+		s.stub.AddCall("FilesystemStatusHistory", tag, filter.Size)
+		return []status.StatusInfo{
+			{Status: status.Error, Message: "no space left", Since: &earlier},
+		}, nil
+	}
+
+	results := s.apiv5.StatusHistory(params.StatusHistoryRequests{
+		Requests: []params.StatusHistoryRequest{{
+			Kind:   "volume",
+			Tag:    s.volumeTag.String(),
+			Filter: params.StatusHistoryFilter{Size: 10},
+		}, {
+			Kind:   "filesystem",
+			Tag:    s.filesystemTag.String(),
+			Filter: params.StatusHistoryFilter{Size: 5},
+		}},
+	})
+	c.Assert(results, jc.DeepEquals, params.StatusHistoryResults{
+		Results: []params.StatusHistoryResult{{
+			History: params.History{Statuses: []params.DetailedStatus{{
+				Status: "attaching",
+				Info:   "provider says no",
+				Since:  &earlier,
+				Kind:   "volume",
+			}, {
+				Status: "attached",
+				Since:  &later,
+				Kind:   "volume",
+			}}},
+		}, {
+			History: params.History{Statuses: []params.DetailedStatus{{
+				Status: "error",
+				Info:   "no space left",
+				Since:  &earlier,
+				Kind:   "filesystem",
+			}}},
+		}},
+	})
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"VolumeStatusHistory", []interface{}{s.volumeTag, 10}},
+		{"FilesystemStatusHistory", []interface{}{s.filesystemTag, 5}},
+	})
+}
+
+func (s *statusHistorySuite) TestStatusHistoryInvalidRequests(c *gc.C) {
+	results := s.apiv5.StatusHistory(params.StatusHistoryRequests{
+		Requests: []params.StatusHistoryRequest{{
+			Kind:   "unit",
+			Tag:    s.unitTag.String(),
+			Filter: params.StatusHistoryFilter{Size: 1},
+		}, {
+			Kind:   "volume",
+			Tag:    s.filesystemTag.String(),
+			Filter: params.StatusHistoryFilter{Size: 1},
+		}, {
+			Kind: "volume",
+			Tag:  s.volumeTag.String(),
+		}},
+	})
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Check(results.Results[0].Error, gc.ErrorMatches, `fetching status history for "unit-mysql-0": status history kind "unit" not valid`)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `fetching status history for "filesystem-104": "filesystem-104" is not a valid volume tag`)
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `fetching status history for "volume-22": cannot validate status history filter: missing filter parameters not valid`)
+}
+
+func (s *statusHistorySuite) TestStatusHistoryPermissionDenied(c *gc.C) {
+	authorizer := apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("nobody")}
+	api, err := storage.NewAPIv5(s.state, s.registry, s.poolManager, s.resources, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	results := api.StatusHistory(params.StatusHistoryRequests{
+		Requests: []params.StatusHistoryRequest{{
+			Kind:   "volume",
+			Tag:    s.volumeTag.String(),
+			Filter: params.StatusHistoryFilter{Size: 1},
+		}},
+	})
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeUnauthorized)
+}
@@ -6,6 +6,8 @@
 package storage
 
 import (
+	"context"
+
 	"github.com/juju/errors"
 	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
@@ -30,11 +32,31 @@ type APIv3 struct {
 	authorizer  facade.Authorizer
 }
 
+// APIv5 implements the storage v5 API.
+type APIv5 struct {
+	*APIv4
+}
+
 // APIv4 implements the storage v4 API.
 type APIv4 struct {
 	*APIv3
 }
 
+// NewAPIv5 returns a new storage v5 API facade.
+func NewAPIv5(
+	st storageAccess,
+	registry storage.ProviderRegistry,
+	pm poolmanager.PoolManager,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv5, error) {
+	apiv4, err := NewAPIv4(st, registry, pm, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &APIv5{apiv4}, nil
+}
+
 // NewAPIv4 returns a new storage v4 API facade.
 func NewAPIv4(
 	st storageAccess,
@@ -94,6 +116,8 @@ func (api *APIv3) checkCanWrite() error {
 // StorageDetails retrieves and returns detailed information about desired
 // storage identified by supplied tags. If specified storage cannot be
 // retrieved, individual error is returned instead of storage information.
+//
+//juju:readonly
 func (api *APIv3) StorageDetails(entities params.Entities) (params.StorageDetailsResults, error) {
 	if err := api.checkCanWrite(); err != nil {
 		return params.StorageDetailsResults{}, errors.Trace(err)
@@ -121,6 +145,8 @@ func (api *APIv3) StorageDetails(entities params.Entities) (params.StorageDetail
 }
 
 // ListStorageDetails returns storage matching a filter.
+//
+//juju:readonly
 func (api *APIv3) ListStorageDetails(filters params.StorageFilters) (params.StorageDetailsListResults, error) {
 	if err := api.checkCanRead(); err != nil {
 		return params.StorageDetailsListResults{}, errors.Trace(err)
@@ -256,6 +282,8 @@ func storageAttachmentInfo(st storageAccess, a state.StorageAttachment) (_ names
 // pools that match either are returned.
 // This method lists union of pools and environment provider types.
 // If no filter is provided, all pools are returned.
+//
+//juju:readonly
 func (a *APIv3) ListPools(
 	filters params.StoragePoolFilters,
 ) (params.StoragePoolsResults, error) {
@@ -394,6 +422,8 @@ func (a *APIv3) CreatePool(p params.StoragePool) error {
 // ListVolumes lists volumes with the given filters. Each filter produces
 // an independent list of volumes, or an error if the filter is invalid
 // or the volumes could not be listed.
+//
+//juju:readonly
 func (a *APIv3) ListVolumes(filters params.VolumeFilters) (params.VolumeDetailsListResults, error) {
 	if err := a.checkCanRead(); err != nil {
 		return params.VolumeDetailsListResults{}, errors.Trace(err)
@@ -545,6 +575,8 @@ func createVolumeDetails(
 // ListFilesystems returns a list of filesystems in the environment matching
 // the provided filter. Each result describes a filesystem in detail, including
 // the filesystem's attachments.
+//
+//juju:readonly
 func (a *APIv3) ListFilesystems(filters params.FilesystemFilters) (params.FilesystemDetailsListResults, error) {
 	results := params.FilesystemDetailsListResults{
 		Results: make([]params.FilesystemDetailsListResult, len(filters.Filters)),
@@ -748,9 +780,16 @@ func (a *APIv3) addToUnit(args params.StoragesAddParams) (params.AddStorageResul
 			continue
 		}
 
-		tags, err := a.storage.AddStorageForUnit(
-			u, one.StorageName, paramsToState(one.Constraints),
-		)
+		cons := paramsToState(one.Constraints)
+		count := int(cons.Count)
+		if count == 0 {
+			count = 1
+		}
+		if err := a.storage.CheckModelQuotas(state.ModelResources{Storage: count}); err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		tags, err := a.storage.AddStorageForUnit(u, one.StorageName, cons)
 		if err != nil {
 			result[i].Error = common.ServerError(err)
 		}
@@ -1000,7 +1039,7 @@ func (a *APIv4) importFilesystem(
 				cfg.Provider(),
 			)
 		}
-		info, err := filesystemImporter.ImportFilesystem(arg.ProviderId, resourceTags)
+		info, err := filesystemImporter.ImportFilesystem(context.TODO(), arg.ProviderId, resourceTags)
 		if err != nil {
 			return nil, errors.Annotate(err, "importing filesystem")
 		}
@@ -1018,7 +1057,7 @@ func (a *APIv4) importFilesystem(
 				cfg.Provider(),
 			)
 		}
-		info, err := volumeImporter.ImportVolume(arg.ProviderId, resourceTags)
+		info, err := volumeImporter.ImportVolume(context.TODO(), arg.ProviderId, resourceTags)
 		if err != nil {
 			return nil, errors.Annotate(err, "importing volume")
 		}
@@ -4,6 +4,7 @@
 package storage_test
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/juju/errors"
@@ -714,7 +715,7 @@ type filesystemImporter struct {
 }
 
 // ImportFilesystem is part of the storage.FilesystemImporter interface.
-func (f filesystemImporter) ImportFilesystem(providerId string, tags map[string]string) (storage.FilesystemInfo, error) {
+func (f filesystemImporter) ImportFilesystem(ctx context.Context, providerId string, tags map[string]string) (storage.FilesystemInfo, error) {
 	f.MethodCall(f, "ImportFilesystem", providerId, tags)
 	return storage.FilesystemInfo{
 		FilesystemId: providerId,
@@ -727,7 +728,7 @@ type volumeImporter struct {
 }
 
 // ImportVolume is part of the storage.VolumeImporter interface.
-func (v volumeImporter) ImportVolume(providerId string, tags map[string]string) (storage.VolumeInfo, error) {
+func (v volumeImporter) ImportVolume(ctx context.Context, providerId string, tags map[string]string) (storage.VolumeInfo, error) {
 	v.MethodCall(v, "ImportVolume", providerId, tags)
 	return storage.VolumeInfo{
 		VolumeId:   providerId,
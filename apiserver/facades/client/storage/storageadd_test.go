@@ -177,3 +177,24 @@ func (s *storageAddSuite) TestStorageAddUnitNotFoundErr(c *gc.C) {
 	c.Assert(failures.Results[0].Error.Error(), gc.Matches, "sanity not found")
 	c.Assert(failures.Results[0].Error, jc.Satisfies, params.IsCodeNotFound)
 }
+
+func (s *storageAddSuite) TestStorageAddUnitQuotaExceeded(c *gc.C) {
+	var requested []state.ModelResources
+	s.state.checkModelQuotas = func(r state.ModelResources) error {
+		requested = append(requested, r)
+		return &state.ErrQuotaExceeded{Resource: state.QuotaStorage, Limit: 4, Used: 3, Requested: 2}
+	}
+
+	count := uint64(2)
+	args := params.StorageAddParams{
+		UnitTag:     s.unitTag.String(),
+		StorageName: "data",
+		Constraints: params.StorageConstraints{Count: &count},
+	}
+	failures, err := s.api.AddToUnit(params.StoragesAddParams{[]params.StorageAddParams{args}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures.Results, gc.HasLen, 1)
+	c.Assert(failures.Results[0].Error, jc.Satisfies, params.IsCodeQuotaExceeded)
+	c.Assert(requested, jc.DeepEquals, []state.ModelResources{{Storage: 2}})
+	s.assertCalls(c, []string{getBlockForTypeCall})
+}
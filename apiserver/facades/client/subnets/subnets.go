@@ -20,9 +20,13 @@ type SubnetsAPI interface {
 	// AllZones returns all availability zones known to Juju. If a
 	// zone is unusable, unavailable, or deprecated the Available
 	// field will be false.
+	//
+	//juju:readonly
 	AllZones() (params.ZoneResults, error)
 
 	// AllSpaces returns the tags of all network spaces known to Juju.
+	//
+	//juju:readonly
 	AllSpaces() (params.SpaceResults, error)
 
 	// AddSubnets adds existing subnets to Juju.
@@ -30,6 +34,8 @@ type SubnetsAPI interface {
 
 	// ListSubnets returns the matching subnets after applying
 	// optional filters.
+	//
+	//juju:readonly
 	ListSubnets(args params.SubnetsFilters) (params.ListSubnetsResults, error)
 }
 
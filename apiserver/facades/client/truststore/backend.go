@@ -0,0 +1,20 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package truststore
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the TrustStore
+// facade.
+type Backend interface {
+	ControllerTag() names.ControllerTag
+
+	TrustedCACerts(state.TrustCategory) ([]state.TrustedCACert, error)
+	AddTrustedCACert(state.TrustedCACert) error
+	RemoveTrustedCACert(state.TrustCategory, string) error
+}
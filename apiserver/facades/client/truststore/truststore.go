@@ -0,0 +1,115 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package truststore implements the TrustStore facade, which manages
+// the CA certificates pinned by controller administrators for each
+// category of external endpoint the controller connects to, such as
+// the charm store. Pinned certificates are trusted in addition to the
+// system's root certificates, so that the controller can work where
+// outgoing TLS connections are intercepted by a proxy.
+package truststore
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// API implements the TrustStore facade.
+type API struct {
+	backend Backend
+	user    names.UserTag
+}
+
+// NewAPI returns a new TrustStore facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	user, ok := authorizer.GetAuthTag().(names.UserTag)
+	if !ok {
+		return nil, common.ErrPerm
+	}
+	isAdmin, err := authorizer.HasPermission(permission.SuperuserAccess, backend.ControllerTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !isAdmin {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend: backend,
+		user:    user,
+	}, nil
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(st, auth)
+}
+
+// ListCACerts returns the pinned CA certificates, optionally
+// restricted to a single category.
+//
+//juju:readonly
+func (api *API) ListCACerts(args params.TrustedCACertFilter) (params.TrustedCACerts, error) {
+	category := state.TrustCategory(args.Category)
+	if category != "" {
+		if err := category.Validate(); err != nil {
+			return params.TrustedCACerts{}, errors.Trace(err)
+		}
+	}
+	trusted, err := api.backend.TrustedCACerts(category)
+	if err != nil {
+		return params.TrustedCACerts{}, errors.Trace(err)
+	}
+	result := params.TrustedCACerts{
+		Certs: make([]params.TrustedCACert, len(trusted)),
+	}
+	for i, t := range trusted {
+		added := t.Added
+		result.Certs[i] = params.TrustedCACert{
+			Category:    string(t.Category),
+			Name:        t.Name,
+			CACert:      t.CACert,
+			Fingerprint: t.Fingerprint,
+			AddedBy:     t.AddedBy.String(),
+			Added:       &added,
+		}
+	}
+	return result, nil
+}
+
+// AddCACerts pins the given CA certificates, each for its category.
+func (api *API) AddCACerts(args params.TrustedCACerts) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Certs)),
+	}
+	for i, arg := range args.Certs {
+		err := api.backend.AddTrustedCACert(state.TrustedCACert{
+			Category: state.TrustCategory(arg.Category),
+			Name:     arg.Name,
+			CACert:   arg.CACert,
+			AddedBy:  api.user,
+		})
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// RemoveCACerts unpins the identified CA certificates.
+func (api *API) RemoveCACerts(args params.TrustedCACertIds) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Ids)),
+	}
+	for i, id := range args.Ids {
+		err := api.backend.RemoveTrustedCACert(state.TrustCategory(id.Category), id.Name)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
@@ -0,0 +1,158 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package truststore_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/truststore"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type trustStoreSuite struct {
+	testing.IsolationSuite
+	backend *mockBackend
+	user    names.UserTag
+}
+
+var _ = gc.Suite(&trustStoreSuite{})
+
+func (s *trustStoreSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{}
+	s.user = names.NewUserTag("superuser-bob")
+}
+
+func (s *trustStoreSuite) newAPI(c *gc.C) *truststore.API {
+	api, err := truststore.NewAPI(s.backend, apiservertesting.FakeAuthorizer{Tag: s.user})
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *trustStoreSuite) TestRequiresClient(c *gc.C) {
+	_, err := truststore.NewAPI(s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *trustStoreSuite) TestRequiresSuperuser(c *gc.C) {
+	_, err := truststore.NewAPI(s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("read"),
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *trustStoreSuite) TestListCACerts(c *gc.C) {
+	added := time.Date(2018, 3, 1, 9, 0, 0, 0, time.UTC)
+	s.backend.certs = []state.TrustedCACert{{
+		Category:    state.TrustCharmStore,
+		Name:        "corporate-proxy",
+		CACert:      coretesting.CACert,
+		Fingerprint: "abcd",
+		AddedBy:     names.NewUserTag("admin"),
+		Added:       added,
+	}}
+	result, err := s.newAPI(c).ListCACerts(params.TrustedCACertFilter{Category: "charmstore"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.TrustedCACerts{
+		Certs: []params.TrustedCACert{{
+			Category:    "charmstore",
+			Name:        "corporate-proxy",
+			CACert:      coretesting.CACert,
+			Fingerprint: "abcd",
+			AddedBy:     "user-admin",
+			Added:       &added,
+		}},
+	})
+	s.backend.CheckCall(c, 0, "TrustedCACerts", state.TrustCharmStore)
+}
+
+func (s *trustStoreSuite) TestListCACertsInvalidCategory(c *gc.C) {
+	_, err := s.newAPI(c).ListCACerts(params.TrustedCACertFilter{Category: "ftp"})
+	c.Assert(err, gc.ErrorMatches, `trust category "ftp" not valid`)
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *trustStoreSuite) TestAddCACerts(c *gc.C) {
+	s.backend.SetErrors(nil, errors.AlreadyExistsf(`cloud certificate "proxy"`))
+	results, err := s.newAPI(c).AddCACerts(params.TrustedCACerts{
+		Certs: []params.TrustedCACert{{
+			Category: "charmstore",
+			Name:     "proxy",
+			CACert:   coretesting.CACert,
+			// Fingerprint and AddedBy are assigned, not taken
+			// from the arguments.
+			Fingerprint: "ignored",
+			AddedBy:     "user-mallory",
+		}, {
+			Category: "cloud",
+			Name:     "proxy",
+			CACert:   coretesting.CACert,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `cloud certificate "proxy" already exists`)
+	s.backend.CheckCalls(c, []testing.StubCall{{
+		"AddTrustedCACert", []interface{}{state.TrustedCACert{
+			Category: state.TrustCharmStore,
+			Name:     "proxy",
+			CACert:   coretesting.CACert,
+			AddedBy:  s.user,
+		}},
+	}, {
+		"AddTrustedCACert", []interface{}{state.TrustedCACert{
+			Category: state.TrustCloud,
+			Name:     "proxy",
+			CACert:   coretesting.CACert,
+			AddedBy:  s.user,
+		}},
+	}})
+}
+
+func (s *trustStoreSuite) TestRemoveCACerts(c *gc.C) {
+	s.backend.SetErrors(errors.NotFoundf(`webhook certificate "proxy"`))
+	results, err := s.newAPI(c).RemoveCACerts(params.TrustedCACertIds{
+		Ids: []params.TrustedCACertId{{Category: "webhook", Name: "proxy"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeNotFound)
+	s.backend.CheckCall(c, 0, "RemoveTrustedCACert", state.TrustWebhook, "proxy")
+}
+
+type mockBackend struct {
+	testing.Stub
+	certs []state.TrustedCACert
+}
+
+func (b *mockBackend) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (b *mockBackend) TrustedCACerts(category state.TrustCategory) ([]state.TrustedCACert, error) {
+	b.MethodCall(b, "TrustedCACerts", category)
+	return b.certs, b.NextErr()
+}
+
+func (b *mockBackend) AddTrustedCACert(trusted state.TrustedCACert) error {
+	b.MethodCall(b, "AddTrustedCACert", trusted)
+	return b.NextErr()
+}
+
+func (b *mockBackend) RemoveTrustedCACert(category state.TrustCategory, name string) error {
+	b.MethodCall(b, "RemoveTrustedCACert", category, name)
+	return b.NextErr()
+}
@@ -249,6 +249,8 @@ func (api *UserManagerAPI) enableUserImpl(args params.Entities, action string, m
 }
 
 // UserInfo returns information on a user.
+//
+//juju:readonly
 func (api *UserManagerAPI) UserInfo(request params.UserInfoRequest) (params.UserInfoResults, error) {
 	var results params.UserInfoResults
 	isAdmin, err := api.hasControllerAdminAccess()
@@ -425,3 +427,34 @@ func (api *UserManagerAPI) ResetPassword(args params.Entities) (params.AddUserRe
 	}
 	return result, nil
 }
+
+// UserActivity returns the API activity of each of the specified
+// users in each model in which they have made API calls or logged in.
+// Controller administrators may see the activity of any user, and
+// other users only their own.
+func (api *UserManagerAPI) UserActivity(args params.Entities) (params.ModelUserActivityResults, error) {
+	results := params.ModelUserActivityResults{
+		Results: make([]params.ModelUserActivityResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		userTag, err := names.ParseUserTag(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if !api.isAdmin && !api.authorizer.AuthOwner(userTag) {
+			results.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		activity, err := api.state.UserActivity(userTag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Activity = make([]params.ModelUserActivity, len(activity))
+		for j, a := range activity {
+			results.Results[i].Activity[j] = common.ModelUserActivity(a)
+		}
+	}
+	return results, nil
+}
@@ -990,3 +990,47 @@ func (s *userManagerSuite) TestResetPasswordEmpty(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results.Results, gc.HasLen, 0)
 }
+
+func (s *userManagerSuite) TestUserActivity(c *gc.C) {
+	alex := s.Factory.MakeUser(c, &factory.UserParams{Name: "alex", NoModelUser: true})
+	lastAccess := time.Date(2018, 5, 1, 12, 0, 0, 0, time.UTC)
+	err := s.State.RecordModelUserActivity(s.State.ModelUUID(), alex.UserTag(), 3, lastAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.usermanager.UserActivity(params.Entities{Entities: []params.Entity{
+		{Tag: alex.Tag().String()},
+		{Tag: "machine-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0], jc.DeepEquals, params.ModelUserActivityResult{
+		Activity: []params.ModelUserActivity{{
+			UserTag:    alex.Tag().String(),
+			ModelTag:   s.State.ModelTag().String(),
+			APICalls:   3,
+			LastAccess: &lastAccess,
+		}},
+	})
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `"machine-0" is not a valid user tag`)
+}
+
+func (s *userManagerSuite) TestUserActivityNotControllerAdmin(c *gc.C) {
+	alex := s.Factory.MakeUser(c, &factory.UserParams{Name: "alex", NoModelUser: true})
+	barb := s.Factory.MakeUser(c, &factory.UserParams{Name: "barb", NoModelUser: true})
+	usermanager, err := usermanager.NewUserManagerAPI(
+		s.State, s.resources, apiservertesting.FakeAuthorizer{Tag: alex.Tag()})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := usermanager.UserActivity(params.Entities{Entities: []params.Entity{
+		{Tag: alex.Tag().String()},
+		{Tag: barb.Tag().String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ModelUserActivityResults{
+		Results: []params.ModelUserActivityResult{{
+			Activity: []params.ModelUserActivity{},
+		}, {
+			Error: common.ServerError(common.ErrPerm),
+		}},
+	})
+}
@@ -0,0 +1,122 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package utilization implements the Utilization facade, which
+// reports the resources used by units' processes, as sampled by the
+// agents of the machines they are deployed to.
+package utilization
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// Backend defines the state functionality required by the
+// Utilization facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+	AllUnitsUtilization() (map[string]state.UnitUtilization, error)
+	WatchUnitsUtilization() state.StringsWatcher
+}
+
+// API implements the Utilization facade.
+type API struct {
+	backend    Backend
+	resources  facade.Resources
+	authorizer facade.Authorizer
+}
+
+// NewAPI returns a new Utilization facade.
+func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		resources:  resources,
+		authorizer: authorizer,
+	}, nil
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(st *state.State, resources facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(st, resources, auth)
+}
+
+func (api *API) checkCanRead() error {
+	ok, err := api.authorizer.HasPermission(permission.ReadAccess, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// UnitsUtilization returns the resources most recently reported to be
+// used by each of the given units. Where a unit has reported nothing,
+// an error satisfying errors.IsNotFound is returned for it.
+//
+//juju:readonly
+func (api *API) UnitsUtilization(args params.Entities) (params.UnitUtilizationResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.UnitUtilizationResults{}, errors.Trace(err)
+	}
+	all, err := api.backend.AllUnitsUtilization()
+	if err != nil {
+		return params.UnitUtilizationResults{}, errors.Trace(err)
+	}
+	results := params.UnitUtilizationResults{
+		Results: make([]params.UnitUtilizationResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		utilization, ok := all[tag.Id()]
+		if !ok {
+			results.Results[i].Error = common.ServerError(
+				errors.NotFoundf("utilization for unit %q", tag.Id()),
+			)
+			continue
+		}
+		results.Results[i].Result = &params.UnitUtilization{
+			CPUPercent:  utilization.CPUPercent,
+			MemoryBytes: utilization.MemoryBytes,
+			DiskBytes:   utilization.DiskBytes,
+			Updated:     utilization.Updated,
+		}
+	}
+	return results, nil
+}
+
+// WatchUnitsUtilization returns a StringsWatcher that notifies of the
+// names of the units whose reported utilization changes, after which
+// the client should call UnitsUtilization to get it. The initial
+// changes hold the names of all units that have reported utilization.
+//
+//juju:readonly
+func (api *API) WatchUnitsUtilization() (params.StringsWatchResult, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.StringsWatchResult{}, errors.Trace(err)
+	}
+	w := api.backend.WatchUnitsUtilization()
+	changes, ok := <-w.Changes()
+	if !ok {
+		return params.StringsWatchResult{}, watcher.EnsureErr(w)
+	}
+	return params.StringsWatchResult{
+		StringsWatcherId: api.resources.Register(w),
+		Changes:          changes,
+	}, nil
+}
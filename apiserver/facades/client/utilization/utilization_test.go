@@ -0,0 +1,134 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package utilization_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/utilization"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type utilizationSuite struct {
+	testing.IsolationSuite
+	backend   *mockBackend
+	resources *common.Resources
+}
+
+var _ = gc.Suite(&utilizationSuite{})
+
+func (s *utilizationSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{changes: make(chan []string, 1)}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+}
+
+func (s *utilizationSuite) newAPI(c *gc.C, user string) *utilization.API {
+	api, err := utilization.NewAPI(s.backend, s.resources, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag(user),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *utilizationSuite) TestRequiresClient(c *gc.C) {
+	_, err := utilization.NewAPI(s.backend, s.resources, apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *utilizationSuite) TestUnitsUtilization(c *gc.C) {
+	updated := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	s.backend.utilization = map[string]state.UnitUtilization{
+		"mysql/0": {
+			CPUPercent:  12.5,
+			MemoryBytes: 1024,
+			DiskBytes:   2048,
+			Updated:     updated,
+		},
+	}
+	result, err := s.newAPI(c, "read").UnitsUtilization(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "unit-mysql-0"},
+			{Tag: "unit-mysql-1"},
+			{Tag: "machine-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.UnitUtilizationResults{
+		Results: []params.UnitUtilizationResult{{
+			Result: &params.UnitUtilization{
+				CPUPercent:  12.5,
+				MemoryBytes: 1024,
+				DiskBytes:   2048,
+				Updated:     updated,
+			},
+		}, {
+			Error: &params.Error{
+				Message: `utilization for unit "mysql/1" not found`,
+				Code:    params.CodeNotFound,
+			},
+		}, {
+			Error: &params.Error{
+				Message: `"machine-0" is not a valid unit tag`,
+			},
+		}},
+	})
+	s.backend.CheckCallNames(c, "AllUnitsUtilization")
+}
+
+func (s *utilizationSuite) TestUnitsUtilizationPermissionDenied(c *gc.C) {
+	_, err := s.newAPI(c, "bob").UnitsUtilization(params.Entities{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *utilizationSuite) TestWatchUnitsUtilization(c *gc.C) {
+	s.backend.changes <- []string{"mysql/0"}
+	result, err := s.newAPI(c, "read").WatchUnitsUtilization()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResult{
+		StringsWatcherId: "1",
+		Changes:          []string{"mysql/0"},
+	})
+	c.Assert(s.resources.Get("1"), gc.NotNil)
+}
+
+func (s *utilizationSuite) TestWatchUnitsUtilizationPermissionDenied(c *gc.C) {
+	_, err := s.newAPI(c, "bob").WatchUnitsUtilization()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+}
+
+type mockBackend struct {
+	testing.Stub
+	utilization map[string]state.UnitUtilization
+	changes     chan []string
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) AllUnitsUtilization() (map[string]state.UnitUtilization, error) {
+	b.MethodCall(b, "AllUnitsUtilization")
+	return b.utilization, b.NextErr()
+}
+
+func (b *mockBackend) WatchUnitsUtilization() state.StringsWatcher {
+	b.MethodCall(b, "WatchUnitsUtilization")
+	return statetesting.NewMockStringsWatcher(b.changes)
+}
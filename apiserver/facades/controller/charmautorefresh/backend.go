@@ -0,0 +1,140 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmautorefresh
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	csparams "gopkg.in/juju/charmrepo.v2/csclient/params"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/charmrefresh"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the methods the charm auto-refresh facade needs
+// from state.State.
+type Backend interface {
+	// InMaintenanceWindow reports whether disruptive automated
+	// operations may run in the model now.
+	InMaintenanceWindow() (bool, error)
+
+	// PendingCharmUpgrades returns the upgrades pending for the
+	// model's applications.
+	PendingCharmUpgrades() ([]state.PendingCharmUpgrade, error)
+
+	// PendingCharmUpgrade returns the upgrade pending for the named
+	// application.
+	PendingCharmUpgrade(appName string) (state.PendingCharmUpgrade, error)
+
+	// RemovePendingCharmUpgrade removes the upgrade pending for the
+	// named application, if it is to the given charm.
+	RemovePendingCharmUpgrade(appName string, curl *charm.URL) error
+
+	// Application returns the named application.
+	Application(name string) (Application, error)
+
+	// CheckNotInMaintenance returns an error if any of the entities
+	// with the given tags is in maintenance mode.
+	CheckNotInMaintenance(tags ...names.Tag) error
+
+	// AddCharm adds the given charm store charm to the model, if it
+	// is not already there.
+	AddCharm(curl *charm.URL, channel csparams.Channel) error
+
+	// Charm returns the charm with the given URL.
+	Charm(curl *charm.URL) (charmrefresh.Charm, error)
+}
+
+// Application defines the methods the charm auto-refresh facade needs
+// from state.Application.
+type Application interface {
+	ApplicationTag() names.ApplicationTag
+	CharmURL() (*charm.URL, bool)
+	Channel() csparams.Channel
+	RefreshPolicy() charmrefresh.Policy
+
+	// CurrentCharm returns the application's charm.
+	CurrentCharm() (charmrefresh.Charm, error)
+
+	// MaintenanceTags returns the tags of the application and its
+	// units, none of which may be in maintenance mode when the
+	// application's charm is refreshed.
+	MaintenanceTags() ([]names.Tag, error)
+
+	// UpgradeCharm upgrades the application to the given charm,
+	// which must already have been added to the model.
+	UpgradeCharm(curl *charm.URL) error
+}
+
+type backendShim struct {
+	*state.State
+}
+
+// Application is part of Backend.
+func (b backendShim) Application(name string) (Application, error) {
+	app, err := b.State.Application(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return applicationShim{app, b.State}, nil
+}
+
+// AddCharm is part of Backend.
+func (b backendShim) AddCharm(curl *charm.URL, channel csparams.Channel) error {
+	return application.AddCharmWithAuthorization(b.State, params.AddCharmWithAuthorization{
+		URL:     curl.String(),
+		Channel: string(channel),
+	})
+}
+
+// Charm is part of Backend.
+func (b backendShim) Charm(curl *charm.URL) (charmrefresh.Charm, error) {
+	ch, err := b.State.Charm(curl)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ch, nil
+}
+
+type applicationShim struct {
+	*state.Application
+	st *state.State
+}
+
+// CurrentCharm is part of Application.
+func (a applicationShim) CurrentCharm() (charmrefresh.Charm, error) {
+	ch, _, err := a.Application.Charm()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ch, nil
+}
+
+// MaintenanceTags is part of Application.
+func (a applicationShim) MaintenanceTags() ([]names.Tag, error) {
+	units, err := a.Application.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	tags := []names.Tag{a.ApplicationTag()}
+	for _, unit := range units {
+		tags = append(tags, unit.UnitTag())
+	}
+	return tags, nil
+}
+
+// UpgradeCharm is part of Application.
+func (a applicationShim) UpgradeCharm(curl *charm.URL) error {
+	ch, err := a.st.Charm(curl)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(a.Application.SetCharm(state.SetCharmConfig{
+		Charm:   ch,
+		Channel: a.Application.Channel(),
+	}))
+}
@@ -0,0 +1,146 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmautorefresh
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/charmrefresh"
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.charmautorefresh")
+
+// API implements the API facade used by the charm auto-refresh worker
+// to apply the charm upgrades recorded for applications with a
+// refresh policy.
+type API struct {
+	backend Backend
+}
+
+// NewAPI returns a new charm auto-refresh API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{backend: backend}, nil
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(backendShim{st}, auth)
+}
+
+// MaintenanceWindowOpen reports whether the model is in a maintenance
+// window, within which pending upgrades may be applied.
+func (api *API) MaintenanceWindowOpen() params.BoolResult {
+	open, err := api.backend.InMaintenanceWindow()
+	if err != nil {
+		return params.BoolResult{Error: common.ServerError(err)}
+	}
+	return params.BoolResult{Result: open}
+}
+
+// PendingUpgrades returns the charm upgrades pending for the model's
+// applications.
+func (api *API) PendingUpgrades() params.PendingCharmUpgradesResult {
+	upgrades, err := api.backend.PendingCharmUpgrades()
+	if err != nil {
+		return params.PendingCharmUpgradesResult{Error: common.ServerError(err)}
+	}
+	var result params.PendingCharmUpgradesResult
+	for _, upgrade := range upgrades {
+		app, err := api.backend.Application(upgrade.ApplicationName)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return params.PendingCharmUpgradesResult{Error: common.ServerError(err)}
+		}
+		result.Upgrades = append(result.Upgrades, params.PendingCharmUpgrade{
+			ApplicationTag: app.ApplicationTag().String(),
+			CharmURL:       upgrade.CharmURL.String(),
+			Policy:         string(app.RefreshPolicy()),
+			Created:        upgrade.Created,
+		})
+	}
+	return result
+}
+
+// ApplyUpgrades applies the upgrades pending for the given
+// applications. An upgrade that is no longer allowed by the
+// application's refresh policy is discarded, as is one that the
+// policy rejects; an upgrade to an application that is in maintenance
+// mode is left pending.
+func (api *API) ApplyUpgrades(args params.Entities) params.ErrorResults {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		tag, err := names.ParseApplicationTag(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = api.applyUpgrade(tag.Id())
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results
+}
+
+func (api *API) applyUpgrade(appName string) error {
+	upgrade, err := api.backend.PendingCharmUpgrade(appName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(appName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	policy := app.RefreshPolicy()
+	curl, _ := app.CharmURL()
+	if policy == charmrefresh.None || upgrade.CharmURL.Revision <= curl.Revision {
+		logger.Debugf("discarding upgrade of %q to %s", appName, upgrade.CharmURL)
+		return api.backend.RemovePendingCharmUpgrade(appName, upgrade.CharmURL)
+	}
+
+	tags, err := app.MaintenanceTags()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := api.backend.CheckNotInMaintenance(tags...); err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := api.backend.AddCharm(upgrade.CharmURL, app.Channel()); err != nil {
+		return errors.Annotatef(err, "cannot add charm %q", upgrade.CharmURL)
+	}
+	if policy == charmrefresh.PatchOnly {
+		from, err := app.CurrentCharm()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		to, err := api.backend.Charm(upgrade.CharmURL)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := charmrefresh.CheckPatch(from, to); err != nil {
+			// The revision will never be applied under this
+			// policy, so there is no point keeping it pending.
+			if err := api.backend.RemovePendingCharmUpgrade(appName, upgrade.CharmURL); err != nil {
+				return errors.Trace(err)
+			}
+			return errors.Annotatef(err, "%s is not a patch-only refresh of %s", upgrade.CharmURL, curl)
+		}
+	}
+	if err := app.UpgradeCharm(upgrade.CharmURL); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("refreshed %q to %s", appName, upgrade.CharmURL)
+	return errors.Trace(api.backend.RemovePendingCharmUpgrade(appName, upgrade.CharmURL))
+}
@@ -0,0 +1,302 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmautorefresh_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	csparams "gopkg.in/juju/charmrepo.v2/csclient/params"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/controller/charmautorefresh"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/charmrefresh"
+	"github.com/juju/juju/state"
+)
+
+type charmAutoRefreshSuite struct {
+	testing.IsolationSuite
+	stub    testing.Stub
+	backend *mockBackend
+	app     *mockApplication
+	api     *charmautorefresh.API
+	created time.Time
+}
+
+var _ = gc.Suite(&charmAutoRefreshSuite{})
+
+func (s *charmAutoRefreshSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub = testing.Stub{}
+	s.created = time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	mysql := &fakeCharm{meta: &charm.Meta{
+		Provides: map[string]charm.Relation{
+			"db": {Name: "db", Role: charm.RoleProvider, Interface: "mysql"},
+		},
+	}}
+	s.app = &mockApplication{
+		stub:    &s.stub,
+		curl:    charm.MustParseURL("cs:xenial/mysql-1"),
+		channel: csparams.StableChannel,
+		policy:  charmrefresh.Any,
+		charm:   mysql,
+	}
+	s.backend = &mockBackend{
+		stub: &s.stub,
+		open: true,
+		app:  s.app,
+		charms: map[string]charmrefresh.Charm{
+			"cs:xenial/mysql-2": mysql,
+		},
+		upgrades: []state.PendingCharmUpgrade{{
+			ApplicationName: "mysql",
+			CharmURL:        charm.MustParseURL("cs:xenial/mysql-2"),
+			Created:         s.created,
+		}},
+	}
+	var err error
+	s.api, err = charmautorefresh.NewAPI(s.backend, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *charmAutoRefreshSuite) TestRequiresController(c *gc.C) {
+	_, err := charmautorefresh.NewAPI(s.backend, apiservertesting.FakeAuthorizer{Controller: false})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *charmAutoRefreshSuite) TestMaintenanceWindowOpen(c *gc.C) {
+	result := s.api.MaintenanceWindowOpen()
+	c.Assert(result, jc.DeepEquals, params.BoolResult{Result: true})
+	s.stub.CheckCallNames(c, "InMaintenanceWindow")
+}
+
+func (s *charmAutoRefreshSuite) TestMaintenanceWindowOpenError(c *gc.C) {
+	s.stub.SetErrors(errors.New("kaboom"))
+	result := s.api.MaintenanceWindowOpen()
+	c.Assert(result.Error, gc.ErrorMatches, "kaboom")
+}
+
+func (s *charmAutoRefreshSuite) TestPendingUpgrades(c *gc.C) {
+	result := s.api.PendingUpgrades()
+	c.Assert(result, jc.DeepEquals, params.PendingCharmUpgradesResult{
+		Upgrades: []params.PendingCharmUpgrade{{
+			ApplicationTag: "application-mysql",
+			CharmURL:       "cs:xenial/mysql-2",
+			Policy:         "any",
+			Created:        s.created,
+		}},
+	})
+}
+
+func (s *charmAutoRefreshSuite) TestApplyUpgrades(c *gc.C) {
+	result := s.api.ApplyUpgrades(params.Entities{
+		Entities: []params.Entity{{"application-mysql"}, {"unit-mysql-0"}},
+	})
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized}},
+		},
+	})
+	curl := charm.MustParseURL("cs:xenial/mysql-2")
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{"PendingCharmUpgrade", []interface{}{"mysql"}},
+		{"Application", []interface{}{"mysql"}},
+		{"MaintenanceTags", nil},
+		{"CheckNotInMaintenance", []interface{}{[]names.Tag{names.NewApplicationTag("mysql")}}},
+		{"AddCharm", []interface{}{curl, csparams.StableChannel}},
+		{"UpgradeCharm", []interface{}{curl}},
+		{"RemovePendingCharmUpgrade", []interface{}{"mysql", curl}},
+	})
+}
+
+func (s *charmAutoRefreshSuite) TestApplyUpgradesPatchOnly(c *gc.C) {
+	s.app.policy = charmrefresh.PatchOnly
+	result := s.api.ApplyUpgrades(params.Entities{
+		Entities: []params.Entity{{"application-mysql"}},
+	})
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	s.stub.CheckCallNames(c,
+		"PendingCharmUpgrade",
+		"Application",
+		"MaintenanceTags",
+		"CheckNotInMaintenance",
+		"AddCharm",
+		"CurrentCharm",
+		"Charm",
+		"UpgradeCharm",
+		"RemovePendingCharmUpgrade",
+	)
+}
+
+func (s *charmAutoRefreshSuite) TestApplyUpgradesPatchOnlyRejected(c *gc.C) {
+	s.app.policy = charmrefresh.PatchOnly
+	s.backend.charms["cs:xenial/mysql-2"] = &fakeCharm{meta: &charm.Meta{}}
+	result := s.api.ApplyUpgrades(params.Entities{
+		Entities: []params.Entity{{"application-mysql"}},
+	})
+	c.Assert(result.OneError(), gc.ErrorMatches,
+		`cs:xenial/mysql-2 is not a patch-only refresh of cs:xenial/mysql-1: relation endpoint "db" removed`)
+	s.stub.CheckCallNames(c,
+		"PendingCharmUpgrade",
+		"Application",
+		"MaintenanceTags",
+		"CheckNotInMaintenance",
+		"AddCharm",
+		"CurrentCharm",
+		"Charm",
+		"RemovePendingCharmUpgrade",
+	)
+}
+
+func (s *charmAutoRefreshSuite) TestApplyUpgradesPolicyNone(c *gc.C) {
+	s.app.policy = charmrefresh.None
+	result := s.api.ApplyUpgrades(params.Entities{
+		Entities: []params.Entity{{"application-mysql"}},
+	})
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	s.stub.CheckCallNames(c, "PendingCharmUpgrade", "Application", "RemovePendingCharmUpgrade")
+}
+
+func (s *charmAutoRefreshSuite) TestApplyUpgradesAlreadyUpgraded(c *gc.C) {
+	s.app.curl = charm.MustParseURL("cs:xenial/mysql-2")
+	result := s.api.ApplyUpgrades(params.Entities{
+		Entities: []params.Entity{{"application-mysql"}},
+	})
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	s.stub.CheckCallNames(c, "PendingCharmUpgrade", "Application", "RemovePendingCharmUpgrade")
+}
+
+func (s *charmAutoRefreshSuite) TestApplyUpgradesInMaintenance(c *gc.C) {
+	s.stub.SetErrors(nil, nil, nil, &state.ErrInMaintenance{
+		Tag:    names.NewApplicationTag("mysql"),
+		Reason: "backups",
+	})
+	result := s.api.ApplyUpgrades(params.Entities{
+		Entities: []params.Entity{{"application-mysql"}},
+	})
+	c.Assert(result.OneError(), jc.Satisfies, params.IsCodeInMaintenance)
+	// The upgrade is left pending.
+	s.stub.CheckCallNames(c, "PendingCharmUpgrade", "Application", "MaintenanceTags", "CheckNotInMaintenance")
+}
+
+type mockBackend struct {
+	stub     *testing.Stub
+	open     bool
+	upgrades []state.PendingCharmUpgrade
+	app      *mockApplication
+	charms   map[string]charmrefresh.Charm
+}
+
+func (b *mockBackend) InMaintenanceWindow() (bool, error) {
+	b.stub.AddCall("InMaintenanceWindow")
+	return b.open, b.stub.NextErr()
+}
+
+func (b *mockBackend) PendingCharmUpgrades() ([]state.PendingCharmUpgrade, error) {
+	b.stub.AddCall("PendingCharmUpgrades")
+	return b.upgrades, b.stub.NextErr()
+}
+
+func (b *mockBackend) PendingCharmUpgrade(appName string) (state.PendingCharmUpgrade, error) {
+	b.stub.AddCall("PendingCharmUpgrade", appName)
+	if err := b.stub.NextErr(); err != nil {
+		return state.PendingCharmUpgrade{}, err
+	}
+	for _, upgrade := range b.upgrades {
+		if upgrade.ApplicationName == appName {
+			return upgrade, nil
+		}
+	}
+	return state.PendingCharmUpgrade{}, errors.NotFoundf("pending charm upgrade for application %q", appName)
+}
+
+func (b *mockBackend) RemovePendingCharmUpgrade(appName string, curl *charm.URL) error {
+	b.stub.AddCall("RemovePendingCharmUpgrade", appName, curl)
+	return b.stub.NextErr()
+}
+
+func (b *mockBackend) Application(name string) (charmautorefresh.Application, error) {
+	b.stub.AddCall("Application", name)
+	if err := b.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return b.app, nil
+}
+
+func (b *mockBackend) CheckNotInMaintenance(tags ...names.Tag) error {
+	b.stub.AddCall("CheckNotInMaintenance", tags)
+	return b.stub.NextErr()
+}
+
+func (b *mockBackend) AddCharm(curl *charm.URL, channel csparams.Channel) error {
+	b.stub.AddCall("AddCharm", curl, channel)
+	return b.stub.NextErr()
+}
+
+func (b *mockBackend) Charm(curl *charm.URL) (charmrefresh.Charm, error) {
+	b.stub.AddCall("Charm", curl)
+	if err := b.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return b.charms[curl.String()], nil
+}
+
+type mockApplication struct {
+	stub    *testing.Stub
+	curl    *charm.URL
+	channel csparams.Channel
+	policy  charmrefresh.Policy
+	charm   charmrefresh.Charm
+}
+
+func (a *mockApplication) ApplicationTag() names.ApplicationTag {
+	return names.NewApplicationTag("mysql")
+}
+
+func (a *mockApplication) CharmURL() (*charm.URL, bool) {
+	return a.curl, false
+}
+
+func (a *mockApplication) Channel() csparams.Channel {
+	return a.channel
+}
+
+func (a *mockApplication) RefreshPolicy() charmrefresh.Policy {
+	return a.policy
+}
+
+func (a *mockApplication) CurrentCharm() (charmrefresh.Charm, error) {
+	a.stub.AddCall("CurrentCharm")
+	return a.charm, a.stub.NextErr()
+}
+
+func (a *mockApplication) MaintenanceTags() ([]names.Tag, error) {
+	a.stub.AddCall("MaintenanceTags")
+	return []names.Tag{a.ApplicationTag()}, a.stub.NextErr()
+}
+
+func (a *mockApplication) UpgradeCharm(curl *charm.URL) error {
+	a.stub.AddCall("UpgradeCharm", curl)
+	return a.stub.NextErr()
+}
+
+type fakeCharm struct {
+	meta   *charm.Meta
+	config *charm.Config
+}
+
+func (ch *fakeCharm) Meta() *charm.Meta {
+	return ch.meta
+}
+
+func (ch *fakeCharm) Config() *charm.Config {
+	return ch.config
+}
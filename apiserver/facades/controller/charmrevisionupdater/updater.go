@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/charmstore"
+	"github.com/juju/juju/core/charmrefresh"
 	"github.com/juju/juju/state"
 )
 
@@ -81,11 +82,39 @@ func (api *CharmRevisionUpdaterAPI) updateLatestRevisions() error {
 				return err
 			}
 		}
+
+		// Finally, record an upgrade for applications whose policy
+		// is to refresh automatically to newer revisions.
+		if err := api.recordPendingUpgrade(info); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// recordPendingUpgrade records an upgrade to the latest revision of the
+// application's charm, to be applied by the charm auto-refresh worker,
+// if the application has a refresh policy and is not already using the
+// latest revision.
+func (api *CharmRevisionUpdaterAPI) recordPendingUpgrade(info latestCharmInfo) error {
+	if info.application.Life() != state.Alive || info.application.RefreshPolicy() == charmrefresh.None {
+		return nil
+	}
+	curl, _ := info.application.CharmURL()
+	latest := info.LatestURL()
+	if latest.Revision <= curl.Revision {
+		return nil
+	}
+	name := info.application.Name()
+	err := api.state.SetPendingCharmUpgrade(name, latest)
+	if errors.IsNotFound(err) {
+		logger.Debugf("not recording upgrade of removed application %q", name)
+		return nil
+	}
+	return errors.Trace(err)
+}
+
 // NewCharmStoreClient instantiates a new charm store repository.  Exported so
 // we can change it during testing.
 var NewCharmStoreClient = func(st *state.State) (charmstore.Client, error) {
@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/charmrevisionupdater/testing"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/charmstore"
+	"github.com/juju/juju/core/charmrefresh"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/version"
@@ -131,6 +132,42 @@ func (s *charmVersionSuite) TestUpdateRevisions(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *charmVersionSuite) TestUpdateRevisionsRecordsPendingUpgrades(c *gc.C) {
+	s.AddMachine(c, "0", state.JobManageModel)
+	s.SetupScenario(c)
+
+	for _, name := range []string{"mysql", "wordpress"} {
+		app, err := s.State.Application(name)
+		c.Assert(err, jc.ErrorIsNil)
+		err = app.SetRefreshPolicy(charmrefresh.PatchOnly)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	result, err := s.charmrevisionupdater.UpdateLatestRevisions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+
+	// Only mysql is out of date.
+	upgrades, err := s.State.PendingCharmUpgrades()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrades, gc.HasLen, 1)
+	c.Assert(upgrades[0].ApplicationName, gc.Equals, "mysql")
+	c.Assert(upgrades[0].CharmURL.String(), gc.Equals, "cs:quantal/mysql-23")
+}
+
+func (s *charmVersionSuite) TestUpdateRevisionsNoRefreshPolicy(c *gc.C) {
+	s.AddMachine(c, "0", state.JobManageModel)
+	s.SetupScenario(c)
+
+	result, err := s.charmrevisionupdater.UpdateLatestRevisions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+
+	upgrades, err := s.State.PendingCharmUpgrades()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrades, gc.HasLen, 0)
+}
+
 func (s *charmVersionSuite) TestWordpressCharmNoReadAccessIsntVisible(c *gc.C) {
 	s.AddMachine(c, "0", state.JobManageModel)
 	s.SetupScenario(c)
@@ -0,0 +1,147 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package dnsupdater provides the API facade used by the DNS updater
+// worker to register a model's machines in the provider's DNS.
+package dnsupdater
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the methods the DNS updater facade needs from
+// state.State.
+type Backend interface {
+	state.ModelMachinesWatcher
+
+	// AllMachines returns all of the model's machines.
+	AllMachines() ([]Machine, error)
+
+	// Machine returns the machine with the given id.
+	Machine(id string) (Machine, error)
+}
+
+// Machine defines the methods the DNS updater facade needs from
+// state.Machine.
+type Machine interface {
+	Id() string
+	Life() state.Life
+	InstanceId() (instance.Id, error)
+	PublicAddress() (network.Address, error)
+	FQDN() string
+	SetFQDN(string) error
+}
+
+type backendShim struct {
+	*state.State
+}
+
+// AllMachines implements Backend.
+func (b *backendShim) AllMachines() ([]Machine, error) {
+	machines, err := b.State.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Machine, len(machines))
+	for i, m := range machines {
+		result[i] = m
+	}
+	return result, nil
+}
+
+// Machine implements Backend.
+func (b *backendShim) Machine(id string) (Machine, error) {
+	return b.State.Machine(id)
+}
+
+// API implements the API facade used by the DNS updater worker.
+type API struct {
+	*common.ModelMachinesWatcher
+	backend Backend
+}
+
+// NewAPI returns a new DNS updater API facade.
+func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		ModelMachinesWatcher: common.NewModelMachinesWatcher(backend, resources, authorizer),
+		backend:              backend,
+	}, nil
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	return NewAPI(&backendShim{st}, resources, authorizer)
+}
+
+// Machines returns the details needed to register the model's
+// top-level machines in the provider's DNS. Containers are not
+// included, as they are not addressable by the provider.
+func (api *API) Machines() (params.DNSMachinesResult, error) {
+	machines, err := api.backend.AllMachines()
+	if err != nil {
+		return params.DNSMachinesResult{}, errors.Trace(err)
+	}
+	result := params.DNSMachinesResult{
+		Machines: []params.DNSMachine{},
+	}
+	for _, m := range machines {
+		if names.IsContainerMachine(m.Id()) {
+			continue
+		}
+		machine := params.DNSMachine{
+			Tag:  names.NewMachineTag(m.Id()).String(),
+			Life: params.Life(m.Life().String()),
+			FQDN: m.FQDN(),
+		}
+		instId, err := m.InstanceId()
+		if err == nil {
+			machine.InstanceId = string(instId)
+		} else if !errors.IsNotProvisioned(err) {
+			return params.DNSMachinesResult{}, errors.Trace(err)
+		}
+		addr, err := m.PublicAddress()
+		if err == nil {
+			machine.Addresses = []params.Address{params.FromNetworkAddress(addr)}
+		} else if !network.IsNoAddressError(err) {
+			return params.DNSMachinesResult{}, errors.Trace(err)
+		}
+		result.Machines = append(result.Machines, machine)
+	}
+	return result, nil
+}
+
+// SetFQDNs records the fully qualified domain names registered for
+// machines in the provider's DNS.
+func (api *API) SetFQDNs(args params.SetMachineFQDNs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Machines)),
+	}
+	for i, arg := range args.Machines {
+		err := api.setFQDN(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) setFQDN(arg params.MachineFQDN) error {
+	tag, err := names.ParseMachineTag(arg.Tag)
+	if err != nil {
+		return common.ErrPerm
+	}
+	m, err := api.backend.Machine(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(m.SetFQDN(arg.FQDN))
+}
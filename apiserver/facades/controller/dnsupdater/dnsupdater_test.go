@@ -0,0 +1,169 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dnsupdater_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/dnsupdater"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+)
+
+type dnsUpdaterSuite struct {
+	testing.IsolationSuite
+	backend *mockBackend
+	api     *dnsupdater.API
+}
+
+var _ = gc.Suite(&dnsUpdaterSuite{})
+
+func (s *dnsUpdaterSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		machines: []*mockMachine{{
+			id:         "0",
+			life:       state.Alive,
+			instanceId: "i-0",
+			address:    network.NewScopedAddress("203.0.113.1", network.ScopePublic),
+			fqdn:       "machine-0.example.com",
+		}, {
+			id:   "1",
+			life: state.Dead,
+		}, {
+			id:         "0/lxd/0",
+			life:       state.Alive,
+			instanceId: "juju-0-lxd-0",
+		}},
+	}
+	var err error
+	s.api, err = dnsupdater.NewAPI(s.backend, nil, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *dnsUpdaterSuite) TestRequiresController(c *gc.C) {
+	_, err := dnsupdater.NewAPI(s.backend, nil, apiservertesting.FakeAuthorizer{Controller: false})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *dnsUpdaterSuite) TestMachines(c *gc.C) {
+	result, err := s.api.Machines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.DNSMachinesResult{
+		Machines: []params.DNSMachine{{
+			Tag:        "machine-0",
+			Life:       params.Alive,
+			InstanceId: "i-0",
+			Addresses: []params.Address{{
+				Value: "203.0.113.1",
+				Type:  "ipv4",
+				Scope: "public",
+			}},
+			FQDN: "machine-0.example.com",
+		}, {
+			Tag:  "machine-1",
+			Life: params.Dead,
+		}},
+	})
+}
+
+func (s *dnsUpdaterSuite) TestMachinesError(c *gc.C) {
+	s.backend.err = errors.New("boom")
+	_, err := s.api.Machines()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *dnsUpdaterSuite) TestSetFQDNs(c *gc.C) {
+	result, err := s.api.SetFQDNs(params.SetMachineFQDNs{
+		Machines: []params.MachineFQDN{
+			{Tag: "machine-1", FQDN: "machine-1.example.com"},
+			{Tag: "machine-0", FQDN: ""},
+			{Tag: "machine-42", FQDN: "machine-42.example.com"},
+			{Tag: "unit-foo-0", FQDN: "foo.example.com"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{},
+			{Error: &params.Error{Code: params.CodeNotFound, Message: "machine 42 not found"}},
+			{Error: common.ServerError(common.ErrPerm)},
+		},
+	})
+	c.Assert(s.backend.machines[1].fqdn, gc.Equals, "machine-1.example.com")
+	c.Assert(s.backend.machines[0].fqdn, gc.Equals, "")
+}
+
+type mockBackend struct {
+	state.ModelMachinesWatcher
+	machines []*mockMachine
+	err      error
+}
+
+func (b *mockBackend) AllMachines() ([]dnsupdater.Machine, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	result := make([]dnsupdater.Machine, len(b.machines))
+	for i, m := range b.machines {
+		result[i] = m
+	}
+	return result, nil
+}
+
+func (b *mockBackend) Machine(id string) (dnsupdater.Machine, error) {
+	for _, m := range b.machines {
+		if m.id == id {
+			return m, nil
+		}
+	}
+	return nil, errors.NotFoundf("machine %s", id)
+}
+
+type mockMachine struct {
+	id         string
+	life       state.Life
+	instanceId instance.Id
+	address    network.Address
+	fqdn       string
+}
+
+func (m *mockMachine) Id() string {
+	return m.id
+}
+
+func (m *mockMachine) Life() state.Life {
+	return m.life
+}
+
+func (m *mockMachine) InstanceId() (instance.Id, error) {
+	if m.instanceId == "" {
+		return "", errors.NotProvisionedf("machine %s", m.id)
+	}
+	return m.instanceId, nil
+}
+
+func (m *mockMachine) PublicAddress() (network.Address, error) {
+	if m.address.Value == "" {
+		return network.Address{}, network.NoAddressError("public")
+	}
+	return m.address, nil
+}
+
+func (m *mockMachine) FQDN() string {
+	return m.fqdn
+}
+
+func (m *mockMachine) SetFQDN(fqdn string) error {
+	m.fqdn = fqdn
+	return nil
+}
@@ -46,6 +46,20 @@ type FirewallerAPIV4 struct {
 	*common.ControllerConfigAPI
 }
 
+// FirewallerAPIV5 provides access to the Firewaller v5 API facade.
+type FirewallerAPIV5 struct {
+	*FirewallerAPIV4
+}
+
+// FirewallerAPIV6 provides access to the Firewaller v6 API facade. It
+// adds WatchModelMachineChanges and WatchUnitChanges, whose changes
+// report the life of each changed machine or unit.
+type FirewallerAPIV6 struct {
+	*FirewallerAPIV5
+	*common.ModelMachineChangesWatcher
+	*common.UnitChangesWatcher
+}
+
 // NewStateFirewallerAPIv3 creates a new server-side FirewallerAPIV3 facade.
 func NewStateFirewallerAPIV3(context facade.Context) (*FirewallerAPIV3, error) {
 	st := context.State()
@@ -74,6 +88,38 @@ func NewStateFirewallerAPIV4(context facade.Context) (*FirewallerAPIV4, error) {
 	}, nil
 }
 
+// NewStateFirewallerAPIV5 creates a new server-side FirewallerAPIV5 facade.
+func NewStateFirewallerAPIV5(context facade.Context) (*FirewallerAPIV5, error) {
+	facadev4, err := NewStateFirewallerAPIV4(context)
+	if err != nil {
+		return nil, err
+	}
+	return &FirewallerAPIV5{FirewallerAPIV4: facadev4}, nil
+}
+
+// NewStateFirewallerAPIV6 creates a new server-side FirewallerAPIV6 facade.
+func NewStateFirewallerAPIV6(context facade.Context) (*FirewallerAPIV6, error) {
+	facadev5, err := NewStateFirewallerAPIV5(context)
+	if err != nil {
+		return nil, err
+	}
+	return &FirewallerAPIV6{
+		FirewallerAPIV5: facadev5,
+		// WatchModelMachineChanges() is allowed with unrestricted access.
+		ModelMachineChangesWatcher: common.NewModelMachineChangesWatcher(
+			facadev5.st,
+			facadev5.resources,
+			facadev5.authorizer,
+		),
+		// WatchUnitChanges() is supported for machines.
+		UnitChangesWatcher: common.NewUnitChangesWatcher(
+			facadev5.st,
+			facadev5.resources,
+			facadev5.accessMachine,
+		),
+	}, nil
+}
+
 // NewFirewallerAPI creates a new server-side FirewallerAPIV3 facade.
 func NewFirewallerAPI(
 	st State,
@@ -491,3 +537,30 @@ func (f *FirewallerAPIV4) FirewallRules(args params.KnownServiceArgs) (params.Li
 	}
 	return result, nil
 }
+
+// SetFirewallReports records the firewaller's comparison of the
+// ingress rules it wants applied with those the provider has applied,
+// replacing the reports previously recorded for the model.
+func (f *FirewallerAPIV5) SetFirewallReports(args params.FirewallReports) error {
+	reports := make([]state.FirewallReport, len(args.Reports))
+	for i, arg := range args.Reports {
+		var machineId string
+		if arg.MachineTag != "" {
+			tag, err := names.ParseMachineTag(arg.MachineTag)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			machineId = tag.Id()
+		}
+		reports[i] = state.FirewallReport{
+			MachineId:  machineId,
+			Checked:    arg.Checked,
+			Wanted:     params.NetworkIngressRules(arg.Wanted),
+			Actual:     params.NetworkIngressRules(arg.Actual),
+			Missing:    params.NetworkIngressRules(arg.Missing),
+			Unexpected: params.NetworkIngressRules(arg.Unexpected),
+			Error:      arg.Error,
+		}
+	}
+	return errors.Trace(f.st.SetFirewallReports(reports))
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/apiserver/common/cloudspec"
 	commontesting "github.com/juju/juju/apiserver/common/testing"
 	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/facade/facadetest"
 	"github.com/juju/juju/apiserver/facades/controller/firewaller"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
@@ -83,6 +84,74 @@ func (s *firewallerSuite) TestWatchUnits(c *gc.C) {
 	s.testWatchUnits(c, s.firewaller)
 }
 
+func (s *firewallerSuite) newFirewallerAPIV6(c *gc.C) *firewaller.FirewallerAPIV6 {
+	api, err := firewaller.NewStateFirewallerAPIV6(facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *firewallerSuite) TestWatchModelMachineChanges(c *gc.C) {
+	api := s.newFirewallerAPIV6(c)
+	err := s.machines[1].EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+
+	got, err := api.WatchModelMachineChanges()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got.EntityChangesWatcherId, gc.Equals, "1")
+	c.Assert(got.Changes, jc.SameContents, []params.EntityChange{
+		{Tag: "machine-0", Kind: "machine", Life: params.Alive},
+		{Tag: "machine-1", Kind: "machine", Life: params.Dead},
+		{Tag: "machine-2", Kind: "machine", Life: params.Alive},
+	})
+
+	// Verify the resource was registered and stop it when done.
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event ("returned"
+	// in the Watch call)
+	wc := statetesting.NewStringsWatcherC(c, s.State, resource.(state.StringsWatcher))
+	wc.AssertNoChange()
+}
+
+func (s *firewallerSuite) TestWatchUnitChanges(c *gc.C) {
+	api := s.newFirewallerAPIV6(c)
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: s.machines[0].Tag().String()},
+		{Tag: s.application.Tag().String()},
+		{Tag: s.units[0].Tag().String()},
+	}}
+	result, err := api.WatchUnitChanges(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.EntityChangesWatchResults{
+		Results: []params.EntityChangesWatchResult{
+			{
+				EntityChangesWatcherId: "1",
+				Changes: []params.EntityChange{
+					{Tag: "unit-wordpress-0", Kind: "unit", Life: params.Alive},
+				},
+			},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Verify the resource was registered and stop it when done.
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event ("returned"
+	// in the Watch call)
+	wc := statetesting.NewStringsWatcherC(c, s.State, resource.(state.StringsWatcher))
+	wc.AssertNoChange()
+}
+
 func (s *firewallerSuite) TestGetExposed(c *gc.C) {
 	s.testGetExposed(c, s.firewaller)
 }
@@ -4,6 +4,8 @@
 package firewaller_test
 
 import (
+	"time"
+
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -15,6 +17,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
 	coretesting "github.com/juju/juju/testing"
@@ -134,3 +137,49 @@ func (s *RemoteFirewallerSuite) TestFirewallRules(c *gc.C) {
 	c.Assert(result.Rules[0].KnownService, gc.Equals, params.KnownServiceValue("juju-application-offer"))
 	c.Assert(result.Rules[0].WhitelistCIDRS, jc.SameContents, []string{"192.168.0.0/16"})
 }
+
+func (s *RemoteFirewallerSuite) TestSetFirewallReports(c *gc.C) {
+	checked := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	api := &firewaller.FirewallerAPIV5{FirewallerAPIV4: s.api}
+	err := api.SetFirewallReports(params.FirewallReports{
+		Reports: []params.FirewallReport{{
+			MachineTag: "machine-0",
+			Checked:    checked,
+			Wanted: []params.IngressRule{{
+				PortRange:   params.PortRange{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+				SourceCIDRs: []string{"0.0.0.0/0"},
+			}},
+			Missing: []params.IngressRule{{
+				PortRange:   params.PortRange{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+				SourceCIDRs: []string{"0.0.0.0/0"},
+			}},
+		}, {
+			MachineTag: "machine-1",
+			Checked:    checked,
+			Error:      "instance not found",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rule := network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0")
+	s.st.CheckCalls(c, []testing.StubCall{
+		{"SetFirewallReports", []interface{}{[]state.FirewallReport{{
+			MachineId: "0",
+			Checked:   checked,
+			Wanted:    []network.IngressRule{rule},
+			Missing:   []network.IngressRule{rule},
+		}, {
+			MachineId: "1",
+			Checked:   checked,
+			Error:     "instance not found",
+		}}}},
+	})
+}
+
+func (s *RemoteFirewallerSuite) TestSetFirewallReportsInvalidTag(c *gc.C) {
+	api := &firewaller.FirewallerAPIV5{FirewallerAPIV4: s.api}
+	err := api.SetFirewallReports(params.FirewallReports{
+		Reports: []params.FirewallReport{{MachineTag: "unit-mysql-0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `"unit-mysql-0" is not a valid machine tag`)
+	s.st.CheckNoCalls(c)
+}
@@ -112,6 +112,11 @@ func (st *mockState) FindEntity(tag names.Tag) (state.Entity, error) {
 	return nil, errors.NotImplementedf("FindEntity")
 }
 
+func (st *mockState) SetFirewallReports(reports []state.FirewallReport) error {
+	st.MethodCall(st, "SetFirewallReports", reports)
+	return st.NextErr()
+}
+
 func (st *mockState) FirewallRule(service state.WellKnownServiceType) (*state.FirewallRule, error) {
 	r, ok := st.firewallRules[service]
 	if !ok {
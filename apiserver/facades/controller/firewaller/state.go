@@ -25,6 +25,8 @@ type State interface {
 	FindEntity(tag names.Tag) (state.Entity, error)
 
 	FirewallRule(service state.WellKnownServiceType) (*state.FirewallRule, error)
+
+	SetFirewallReports([]state.FirewallReport) error
 }
 
 // TODO(wallyworld) - for tests, remove when remaining firewaller tests become unit tests.
@@ -54,6 +56,10 @@ func (st stateShim) WatchOpenedPorts() state.StringsWatcher {
 	return st.st.WatchOpenedPorts()
 }
 
+func (st stateShim) SetFirewallReports(reports []state.FirewallReport) error {
+	return st.st.SetFirewallReports(reports)
+}
+
 func (s stateShim) FirewallRule(service state.WellKnownServiceType) (*state.FirewallRule, error) {
 	api := state.NewFirewallRules(s.st)
 	return api.Rule(service)
@@ -205,14 +205,11 @@ func (a *InstancePollerAPI) SetInstanceStatus(args params.SetStatus) (params.Err
 	}
 	for i, arg := range args.Entities {
 		machine, err := a.getOneMachine(arg.Tag, canAccess)
+		var s status.StatusInfo
+		if err == nil {
+			s, err = common.EntityStatusInfo(arg, a.clock.Now())
+		}
 		if err == nil {
-			now := a.clock.Now()
-			s := status.StatusInfo{
-				Status:  status.Status(arg.Status),
-				Message: arg.Info,
-				Data:    arg.Data,
-				Since:   &now,
-			}
 			err = machine.SetInstanceStatus(s)
 			if status.Status(arg.Status) == status.ProvisioningError {
 				s.Status = status.Error
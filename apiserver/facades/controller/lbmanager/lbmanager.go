@@ -0,0 +1,256 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package lbmanager provides the API facade used by the load balancer
+// manager worker to provision provider load balancers for a model's
+// exposed applications.
+package lbmanager
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// Backend defines the methods the load balancer manager facade needs
+// from state.State.
+type Backend interface {
+	// WatchApplications returns a watcher that notifies of changes to
+	// the life cycles of the model's applications.
+	WatchApplications() state.StringsWatcher
+
+	// AllApplications returns all of the model's applications.
+	AllApplications() ([]Application, error)
+
+	// Application returns the application with the given name.
+	Application(name string) (Application, error)
+
+	// Machine returns the machine with the given id.
+	Machine(id string) (Machine, error)
+}
+
+// Application defines the methods the load balancer manager facade
+// needs from state.Application.
+type Application interface {
+	Name() string
+	Life() state.Life
+	IsExposed() bool
+	AllUnits() ([]Unit, error)
+	LoadBalancerAddress() string
+	SetLoadBalancerAddress(string) error
+}
+
+// Unit defines the methods the load balancer manager facade needs
+// from state.Unit.
+type Unit interface {
+	AssignedMachineId() (string, error)
+	OpenedPorts() ([]network.PortRange, error)
+}
+
+// Machine defines the methods the load balancer manager facade needs
+// from state.Machine.
+type Machine interface {
+	InstanceId() (instance.Id, error)
+}
+
+type backendShim struct {
+	*state.State
+}
+
+// AllApplications implements Backend.
+func (b *backendShim) AllApplications() ([]Application, error) {
+	apps, err := b.State.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Application, len(apps))
+	for i, app := range apps {
+		result[i] = applicationShim{app}
+	}
+	return result, nil
+}
+
+// Application implements Backend.
+func (b *backendShim) Application(name string) (Application, error) {
+	app, err := b.State.Application(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return applicationShim{app}, nil
+}
+
+// Machine implements Backend.
+func (b *backendShim) Machine(id string) (Machine, error) {
+	return b.State.Machine(id)
+}
+
+type applicationShim struct {
+	*state.Application
+}
+
+// AllUnits implements Application.
+func (a applicationShim) AllUnits() ([]Unit, error) {
+	units, err := a.Application.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Unit, len(units))
+	for i, u := range units {
+		result[i] = u
+	}
+	return result, nil
+}
+
+// API implements the API facade used by the load balancer manager
+// worker.
+type API struct {
+	backend   Backend
+	resources facade.Resources
+}
+
+// NewAPI returns a new load balancer manager API facade.
+func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:   backend,
+		resources: resources,
+	}, nil
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	return NewAPI(&backendShim{st}, resources, authorizer)
+}
+
+// WatchApplications returns a StringsWatcher that notifies of changes
+// to the life cycles of the model's applications.
+func (api *API) WatchApplications() (params.StringsWatchResult, error) {
+	watch := api.backend.WatchApplications()
+	if changes, ok := <-watch.Changes(); ok {
+		return params.StringsWatchResult{
+			StringsWatcherId: api.resources.Register(watch),
+			Changes:          changes,
+		}, nil
+	}
+	return params.StringsWatchResult{}, watcher.EnsureErr(watch)
+}
+
+// Applications returns the details needed to provision load balancers
+// for the model's applications: whether each is exposed, the ports
+// opened by its units, and the instances of the machines its units are
+// assigned to. Units in containers are not included as backends, as
+// they are not addressable by the provider.
+func (api *API) Applications() (params.LoadBalancerApplicationsResult, error) {
+	apps, err := api.backend.AllApplications()
+	if err != nil {
+		return params.LoadBalancerApplicationsResult{}, errors.Trace(err)
+	}
+	result := params.LoadBalancerApplicationsResult{
+		Applications: make([]params.LoadBalancerApplication, len(apps)),
+	}
+	for i, app := range apps {
+		result.Applications[i], err = api.application(app)
+		if err != nil {
+			return params.LoadBalancerApplicationsResult{}, errors.Annotatef(err, "application %q", app.Name())
+		}
+	}
+	return result, nil
+}
+
+func (api *API) application(app Application) (params.LoadBalancerApplication, error) {
+	result := params.LoadBalancerApplication{
+		Tag:     names.NewApplicationTag(app.Name()).String(),
+		Life:    params.Life(app.Life().String()),
+		Exposed: app.IsExposed(),
+		Address: app.LoadBalancerAddress(),
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	ports := make(map[network.PortRange]bool)
+	backends := make(map[instance.Id]bool)
+	for _, u := range units {
+		machineId, err := u.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			continue
+		} else if err != nil {
+			return result, errors.Trace(err)
+		}
+		if names.IsContainerMachine(machineId) {
+			continue
+		}
+		m, err := api.backend.Machine(machineId)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		instId, err := m.InstanceId()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return result, errors.Trace(err)
+		}
+		unitPorts, err := u.OpenedPorts()
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		if len(unitPorts) == 0 {
+			// Units that haven't opened any ports aren't
+			// serving anything yet.
+			continue
+		}
+		for _, pr := range unitPorts {
+			ports[pr] = true
+		}
+		backends[instId] = true
+	}
+
+	var portRanges []network.PortRange
+	for pr := range ports {
+		portRanges = append(portRanges, pr)
+	}
+	for _, pr := range network.CombinePortRanges(portRanges...) {
+		result.Ports = append(result.Ports, params.FromNetworkPortRange(pr))
+	}
+	for instId := range backends {
+		result.Backends = append(result.Backends, string(instId))
+	}
+	sort.Strings(result.Backends)
+	return result, nil
+}
+
+// SetAddresses records the addresses of the load balancers provisioned
+// for applications.
+func (api *API) SetAddresses(args params.SetLoadBalancerAddresses) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Applications)),
+	}
+	for i, arg := range args.Applications {
+		err := api.setAddress(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) setAddress(arg params.ApplicationLoadBalancerAddress) error {
+	tag, err := names.ParseApplicationTag(arg.Tag)
+	if err != nil {
+		return common.ErrPerm
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(app.SetLoadBalancerAddress(arg.Address))
+}
@@ -0,0 +1,252 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lbmanager_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/lbmanager"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type lbManagerSuite struct {
+	testing.IsolationSuite
+	backend   *mockBackend
+	resources *common.Resources
+	api       *lbmanager.API
+}
+
+var _ = gc.Suite(&lbManagerSuite{})
+
+func (s *lbManagerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		applications: []*mockApplication{{
+			name:    "wordpress",
+			life:    state.Alive,
+			exposed: true,
+			address: "wordpress-lb.example.com",
+			units: []*mockUnit{{
+				machineId: "1",
+				ports: []network.PortRange{
+					{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+					{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+				},
+			}, {
+				machineId: "0",
+				ports: []network.PortRange{
+					{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+				},
+			}, {
+				// Not provisioned yet.
+				machineId: "2",
+				ports: []network.PortRange{
+					{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+				},
+			}, {
+				// No ports opened yet.
+				machineId: "3",
+			}, {
+				// In a container.
+				machineId: "0/lxd/0",
+				ports: []network.PortRange{
+					{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+				},
+			}, {
+				// Not assigned yet.
+			}},
+		}, {
+			name: "mysql",
+			life: state.Dying,
+		}},
+		machines: map[string]instance.Id{
+			"0":       "i-0",
+			"1":       "i-1",
+			"3":       "i-3",
+			"0/lxd/0": "juju-0-lxd-0",
+		},
+	}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+	var err error
+	s.api, err = lbmanager.NewAPI(s.backend, s.resources, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *lbManagerSuite) TestRequiresController(c *gc.C) {
+	_, err := lbmanager.NewAPI(s.backend, s.resources, apiservertesting.FakeAuthorizer{Controller: false})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *lbManagerSuite) TestWatchApplications(c *gc.C) {
+	ch := make(chan []string, 1)
+	ch <- []string{"wordpress", "mysql"}
+	s.backend.watcher = statetesting.NewMockStringsWatcher(ch)
+
+	result, err := s.api.WatchApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResult{
+		StringsWatcherId: "1",
+		Changes:          []string{"wordpress", "mysql"},
+	})
+	c.Assert(s.resources.Get("1"), gc.Equals, s.backend.watcher)
+}
+
+func (s *lbManagerSuite) TestApplications(c *gc.C) {
+	result, err := s.api.Applications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.LoadBalancerApplicationsResult{
+		Applications: []params.LoadBalancerApplication{{
+			Tag:     "application-wordpress",
+			Life:    params.Alive,
+			Exposed: true,
+			Ports: []params.PortRange{
+				{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+				{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+			},
+			Backends: []string{"i-0", "i-1"},
+			Address:  "wordpress-lb.example.com",
+		}, {
+			Tag:  "application-mysql",
+			Life: params.Dying,
+		}},
+	})
+}
+
+func (s *lbManagerSuite) TestApplicationsError(c *gc.C) {
+	s.backend.err = errors.New("boom")
+	_, err := s.api.Applications()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *lbManagerSuite) TestSetAddresses(c *gc.C) {
+	result, err := s.api.SetAddresses(params.SetLoadBalancerAddresses{
+		Applications: []params.ApplicationLoadBalancerAddress{
+			{Tag: "application-mysql", Address: "mysql-lb.example.com"},
+			{Tag: "application-wordpress", Address: ""},
+			{Tag: "application-foo", Address: "foo-lb.example.com"},
+			{Tag: "machine-0", Address: "machine-lb.example.com"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{},
+			{Error: &params.Error{Code: params.CodeNotFound, Message: `application "foo" not found`}},
+			{Error: common.ServerError(common.ErrPerm)},
+		},
+	})
+	c.Assert(s.backend.applications[1].address, gc.Equals, "mysql-lb.example.com")
+	c.Assert(s.backend.applications[0].address, gc.Equals, "")
+}
+
+type mockBackend struct {
+	watcher      state.StringsWatcher
+	applications []*mockApplication
+	machines     map[string]instance.Id
+	err          error
+}
+
+func (b *mockBackend) WatchApplications() state.StringsWatcher {
+	return b.watcher
+}
+
+func (b *mockBackend) AllApplications() ([]lbmanager.Application, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	result := make([]lbmanager.Application, len(b.applications))
+	for i, app := range b.applications {
+		result[i] = app
+	}
+	return result, nil
+}
+
+func (b *mockBackend) Application(name string) (lbmanager.Application, error) {
+	for _, app := range b.applications {
+		if app.name == name {
+			return app, nil
+		}
+	}
+	return nil, errors.NotFoundf("application %q", name)
+}
+
+func (b *mockBackend) Machine(id string) (lbmanager.Machine, error) {
+	return &mockMachine{id: id, instanceId: b.machines[id]}, nil
+}
+
+type mockApplication struct {
+	name    string
+	life    state.Life
+	exposed bool
+	address string
+	units   []*mockUnit
+}
+
+func (a *mockApplication) Name() string {
+	return a.name
+}
+
+func (a *mockApplication) Life() state.Life {
+	return a.life
+}
+
+func (a *mockApplication) IsExposed() bool {
+	return a.exposed
+}
+
+func (a *mockApplication) AllUnits() ([]lbmanager.Unit, error) {
+	result := make([]lbmanager.Unit, len(a.units))
+	for i, u := range a.units {
+		result[i] = u
+	}
+	return result, nil
+}
+
+func (a *mockApplication) LoadBalancerAddress() string {
+	return a.address
+}
+
+func (a *mockApplication) SetLoadBalancerAddress(addr string) error {
+	a.address = addr
+	return nil
+}
+
+type mockUnit struct {
+	machineId string
+	ports     []network.PortRange
+}
+
+func (u *mockUnit) AssignedMachineId() (string, error) {
+	if u.machineId == "" {
+		return "", errors.NotAssignedf("unit")
+	}
+	return u.machineId, nil
+}
+
+func (u *mockUnit) OpenedPorts() ([]network.PortRange, error) {
+	return u.ports, nil
+}
+
+type mockMachine struct {
+	id         string
+	instanceId instance.Id
+}
+
+func (m *mockMachine) InstanceId() (instance.Id, error) {
+	if m.instanceId == "" {
+		return "", errors.NotProvisionedf("machine %s", m.id)
+	}
+	return m.instanceId, nil
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/juju/version"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/migration"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 )
 
@@ -21,6 +23,9 @@ type Backend interface {
 	ModelOwner() (names.UserTag, error)
 	AgentVersion() (version.Number, error)
 	RemoveExportingModelDocs() error
+	ControllerTag() names.ControllerTag
+	ControllerConfig() (controller.Config, error)
+	APIHostPorts() ([][]network.HostPort, error)
 
 	migration.StateExporter
 }
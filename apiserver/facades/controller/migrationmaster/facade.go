@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/migration"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state/watcher"
 )
 
@@ -99,10 +100,41 @@ func (api *API) MigrationStatus() (params.MasterMigrationStatus, error) {
 				Password:      target.Password,
 				Macaroons:     string(macsJSON),
 			},
+			RollbackWindow: mig.RollbackWindow(),
 		},
 		MigrationId:      mig.Id(),
 		Phase:            phase.String(),
 		PhaseChangedTime: mig.PhaseChangedTime(),
+		Rollback:         mig.IsRollback(),
+	}, nil
+}
+
+// SourceControllerInfo returns the details required to connect to
+// this controller, so that the target controller of a migration can
+// hand the model back if the migration is rolled back.
+func (api *API) SourceControllerInfo() (params.MigrationSourceInfo, error) {
+	empty := params.MigrationSourceInfo{}
+
+	cfg, err := api.backend.ControllerConfig()
+	if err != nil {
+		return empty, errors.Annotate(err, "retrieving controller config")
+	}
+	caCert, ok := cfg.CACert()
+	if !ok {
+		return empty, errors.New("no CA certificate in controller config")
+	}
+	hostPorts, err := api.backend.APIHostPorts()
+	if err != nil {
+		return empty, errors.Annotate(err, "retrieving API addresses")
+	}
+	var addrs []string
+	for _, server := range hostPorts {
+		addrs = append(addrs, network.HostPortsToStrings(server)...)
+	}
+	return params.MigrationSourceInfo{
+		ControllerTag: api.backend.ControllerTag().String(),
+		Addrs:         addrs,
+		CACert:        caCert,
 	}, nil
 }
 
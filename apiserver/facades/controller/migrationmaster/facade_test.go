@@ -21,8 +21,10 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/migrationmaster"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/controller"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/migration"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 	jujuversion "github.com/juju/juju/version"
@@ -114,6 +116,34 @@ func (s *Suite) TestMigrationStatus(c *gc.C) {
 	})
 }
 
+func (s *Suite) TestMigrationStatusRollback(c *gc.C) {
+	s.backend.migration.rollback = true
+	s.backend.migration.rollbackWindow = time.Hour
+	api := s.mustMakeAPI(c)
+	status, err := api.MigrationStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status.Rollback, jc.IsTrue)
+	c.Check(status.Spec.RollbackWindow, gc.Equals, time.Hour)
+}
+
+func (s *Suite) TestSourceControllerInfo(c *gc.C) {
+	api := s.mustMakeAPI(c)
+	info, err := api.SourceControllerInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info, jc.DeepEquals, params.MigrationSourceInfo{
+		ControllerTag: coretesting.ControllerTag.String(),
+		Addrs:         []string{"10.0.0.1:17070", "10.0.0.2:17070"},
+		CACert:        coretesting.CACert,
+	})
+}
+
+func (s *Suite) TestSourceControllerInfoAddressesError(c *gc.C) {
+	s.backend.hostPortsErr = errors.New("boom")
+	api := s.mustMakeAPI(c)
+	_, err := api.SourceControllerInfo()
+	c.Check(err, gc.ErrorMatches, "retrieving API addresses: boom")
+}
+
 func (s *Suite) TestModelInfo(c *gc.C) {
 	api := s.mustMakeAPI(c)
 	model, err := api.ModelInfo()
@@ -414,11 +444,12 @@ func (s *Suite) mustMakeAPI(c *gc.C) *migrationmaster.API {
 type stubBackend struct {
 	migrationmaster.Backend
 
-	stub      *testing.Stub
-	getErr    error
-	removeErr error
-	migration *stubMigration
-	model     description.Model
+	stub         *testing.Stub
+	getErr       error
+	removeErr    error
+	hostPortsErr error
+	migration    *stubMigration
+	model        description.Model
 }
 
 func (b *stubBackend) WatchForMigration() state.NotifyWatcher {
@@ -455,6 +486,24 @@ func (b *stubBackend) RemoveExportingModelDocs() error {
 	return b.removeErr
 }
 
+func (b *stubBackend) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (b *stubBackend) ControllerConfig() (controller.Config, error) {
+	return coretesting.FakeControllerConfig(), nil
+}
+
+func (b *stubBackend) APIHostPorts() ([][]network.HostPort, error) {
+	if b.hostPortsErr != nil {
+		return nil, b.hostPortsErr
+	}
+	return [][]network.HostPort{
+		network.NewHostPorts(17070, "10.0.0.1"),
+		network.NewHostPorts(17070, "10.0.0.2"),
+	}, nil
+}
+
 func (b *stubBackend) Export() (description.Model, error) {
 	b.stub.AddCall("Export")
 	return b.model, nil
@@ -470,6 +519,8 @@ type stubMigration struct {
 	messageSet      string
 	minionReports   *state.MinionReports
 	externalControl bool
+	rollback        bool
+	rollbackWindow  time.Duration
 }
 
 func (m *stubMigration) Id() string {
@@ -503,6 +554,14 @@ func (m *stubMigration) TargetInfo() (*coremigration.TargetInfo, error) {
 	}, nil
 }
 
+func (m *stubMigration) RollbackWindow() time.Duration {
+	return m.rollbackWindow
+}
+
+func (m *stubMigration) IsRollback() bool {
+	return m.rollback
+}
+
 func (m *stubMigration) SetPhase(phase coremigration.Phase) error {
 	if m.setPhaseErr != nil {
 		return m.setPhaseErr
@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/stateenvirons"
 	"github.com/juju/juju/status"
+	jujuversion "github.com/juju/juju/version"
 )
 
 // API implements the API required for the model migration
@@ -289,6 +290,143 @@ func (api *API) CheckMachines(args params.ModelArgs) (params.ErrorResults, error
 	return params.ErrorResults{Results: results}, nil
 }
 
+// CheckAgents reports any machine or unit agents of a migrated model
+// that are not connected to this controller, and any units whose
+// hooks are failing. It is used while the source controller decides
+// whether the migration should be rolled back.
+func (api *API) CheckAgents(args params.ModelArgs) (params.ErrorResults, error) {
+	var empty params.ErrorResults
+	tag, err := names.ParseModelTag(args.ModelTag)
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	st, release, err := api.pool.Get(tag.Id())
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	defer release()
+
+	var results []params.ErrorResult
+
+	machines, err := st.AllMachines()
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	for _, machine := range machines {
+		present, err := machine.AgentPresence()
+		if err != nil {
+			return empty, errors.Annotatef(err, "getting presence of machine %s", machine.Id())
+		}
+		if !present {
+			results = append(results, errorResult("machine %s agent is not connected", machine.Id()))
+		}
+	}
+
+	model, err := st.Model()
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	units, err := model.AllUnits()
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	for _, unit := range units {
+		present, err := unit.AgentPresence()
+		if err != nil {
+			return empty, errors.Annotatef(err, "getting presence of unit %s", unit.Name())
+		}
+		if !present {
+			results = append(results, errorResult("unit %s agent is not connected", unit.Name()))
+			continue
+		}
+		agentStatus, err := unit.AgentStatus()
+		if err != nil {
+			return empty, errors.Annotatef(err, "getting status of unit %s", unit.Name())
+		}
+		if agentStatus.Status == status.Error {
+			results = append(results, errorResult("unit %s is in error: %s", unit.Name(), agentStatus.Message))
+		}
+	}
+
+	return params.ErrorResults{Results: results}, nil
+}
+
+// Rollback hands a migrated model back to the controller it was
+// migrated from, after the migration was rolled back there. The
+// provider is asked to tag the model's resources for the source
+// controller again, and a migration is started which switches the
+// model's agents back to the source controller and then removes the
+// model from this one.
+func (api *API) Rollback(args params.RollbackModelArgs) error {
+	model, release, err := api.getModel(args.ModelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer release()
+
+	sourceTag, err := names.ParseControllerTag(args.SourceInfo.ControllerTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	st, releaseSt, err := api.pool.Get(model.UUID())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer releaseSt()
+
+	if model.MigrationMode() != state.MigrationModeNone {
+		// The source controller may be retrying a rollback that
+		// has already been started.
+		if started, err := rollbackStarted(st, sourceTag); err != nil {
+			return errors.Trace(err)
+		} else if started {
+			return nil
+		}
+		return errors.Errorf("model is being migrated (migration mode %q)", model.MigrationMode())
+	}
+
+	env, err := api.getEnviron(st)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := env.AdoptResources(sourceTag.Id(), jujuversion.Current); err != nil {
+		return errors.Annotate(err, "handing cloud resources back to source controller")
+	}
+
+	userTag, ok := api.authorizer.GetAuthTag().(names.UserTag)
+	if !ok {
+		return errors.Trace(common.ErrPerm)
+	}
+	_, err = st.CreateRollbackMigration(state.RollbackSpec{
+		InitiatedBy: userTag,
+		SourceInfo: coremigration.SourceInfo{
+			ControllerTag: sourceTag,
+			Addrs:         args.SourceInfo.Addrs,
+			CACert:        args.SourceInfo.CACert,
+		},
+	})
+	return errors.Trace(err)
+}
+
+// rollbackStarted returns whether the model's latest migration hands
+// it back to the given source controller.
+func rollbackStarted(st *state.State, sourceTag names.ControllerTag) (bool, error) {
+	mig, err := st.LatestMigration()
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	if !mig.IsRollback() {
+		return false, nil
+	}
+	info, err := mig.TargetInfo()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return info.ControllerTag == sourceTag, nil
+}
+
 func errorResult(format string, args ...interface{}) params.ErrorResult {
 	return params.ErrorResult{Error: common.ServerError(errors.Errorf(format, args...))}
 }
@@ -4,6 +4,7 @@
 package migrationtarget_test
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/juju/description"
@@ -21,6 +22,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/migrationtarget"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/provider/dummy"
@@ -29,6 +31,7 @@ import (
 	statetesting "github.com/juju/juju/state/testing"
 	jujutesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
+	jujuversion "github.com/juju/juju/version"
 )
 
 type Suite struct {
@@ -263,6 +266,121 @@ func (s *Suite) TestAdoptResources(c *gc.C) {
 	env.Stub.CheckCall(c, 0, "AdoptResources", st.ControllerUUID(), version.MustParse("3.2.1"))
 }
 
+func (s *Suite) TestCheckAgentsNotConnected(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	fact := factory.NewFactory(st)
+	machine := fact.MakeMachine(c, nil)
+	unit := fact.MakeUnit(c, &factory.UnitParams{Machine: machine})
+
+	api := s.mustNewAPI(c)
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := api.CheckAgents(
+		params.ModelArgs{ModelTag: model.ModelTag().String()})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Check(results.Results[0].Error, gc.ErrorMatches, `machine 0 agent is not connected`)
+	c.Check(results.Results[1].Error, gc.ErrorMatches,
+		fmt.Sprintf(`unit %s agent is not connected`, unit.Name()))
+}
+
+func (s *Suite) TestCheckAgentsNoAgents(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	api := s.mustNewAPI(c)
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := api.CheckAgents(
+		params.ModelArgs{ModelTag: model.ModelTag().String()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 0)
+}
+
+func (s *Suite) TestRollback(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	env := mockEnviron{Stub: &testing.Stub{}}
+	api := s.mustNewAPIWithEnviron(c, &env)
+
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	sourceTag := names.NewControllerTag(utils.MustNewUUID().String())
+	err = api.Rollback(params.RollbackModelArgs{
+		ModelTag: model.ModelTag().String(),
+		SourceInfo: params.MigrationSourceInfo{
+			ControllerTag: sourceTag.String(),
+			Addrs:         []string{"1.2.3.4:17070"},
+			CACert:        "cert",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(env.Stub.Calls(), gc.HasLen, 1)
+	env.Stub.CheckCall(c, 0, "AdoptResources", sourceTag.Id(), jujuversion.Current)
+
+	mig, err := st.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig.IsRollback(), jc.IsTrue)
+	phase, err := mig.Phase()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(phase, gc.Equals, coremigration.SUCCESS)
+	info, err := mig.TargetInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.ControllerTag, gc.Equals, sourceTag)
+	c.Check(info.Addrs, jc.DeepEquals, []string{"1.2.3.4:17070"})
+}
+
+func (s *Suite) TestRollbackAlreadyStarted(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	env := mockEnviron{Stub: &testing.Stub{}}
+	api := s.mustNewAPIWithEnviron(c, &env)
+
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	args := params.RollbackModelArgs{
+		ModelTag: model.ModelTag().String(),
+		SourceInfo: params.MigrationSourceInfo{
+			ControllerTag: names.NewControllerTag(utils.MustNewUUID().String()).String(),
+			Addrs:         []string{"1.2.3.4:17070"},
+		},
+	}
+	err = api.Rollback(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Retrying the rollback succeeds without doing anything more.
+	err = api.Rollback(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(env.Stub.Calls(), gc.HasLen, 1)
+}
+
+func (s *Suite) TestRollbackModelNotActive(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.SetMigrationMode(state.MigrationModeImporting), jc.ErrorIsNil)
+
+	env := mockEnviron{Stub: &testing.Stub{}}
+	api := s.mustNewAPIWithEnviron(c, &env)
+	err = api.Rollback(params.RollbackModelArgs{
+		ModelTag: model.ModelTag().String(),
+		SourceInfo: params.MigrationSourceInfo{
+			ControllerTag: names.NewControllerTag(utils.MustNewUUID().String()).String(),
+			Addrs:         []string{"1.2.3.4:17070"},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `model is being migrated \(migration mode "importing"\)`)
+	c.Assert(env.Stub.Calls(), gc.HasLen, 0)
+}
+
 func (s *Suite) TestCheckMachinesInstancesMissing(c *gc.C) {
 	st := s.Factory.MakeModel(c, nil)
 	defer st.Close()
@@ -0,0 +1,44 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelsnapshotter implements the API facade used by the model
+// snapshotter worker to periodically record each model's description.
+package modelsnapshotter
+
+import (
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the
+// ModelSnapshotter facade.
+type Backend interface {
+	TakeModelSnapshot() (state.ModelSnapshot, error)
+}
+
+// API implements the ModelSnapshotter facade.
+type API struct {
+	backend Backend
+}
+
+// NewAPI returns a new ModelSnapshotter API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{backend: backend}, nil
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(st, auth)
+}
+
+// TakeModelSnapshot records the model's current description, if it
+// has changed since the last snapshot was taken.
+func (api *API) TakeModelSnapshot() params.ErrorResult {
+	_, err := api.backend.TakeModelSnapshot()
+	return params.ErrorResult{Error: common.ServerError(err)}
+}
@@ -0,0 +1,55 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelsnapshotter_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/controller/modelsnapshotter"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+)
+
+type modelSnapshotterSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&modelSnapshotterSuite{})
+
+func (*modelSnapshotterSuite) TestRequiresController(c *gc.C) {
+	_, err := modelsnapshotter.NewAPI(&mockBackend{}, apiservertesting.FakeAuthorizer{Controller: false})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	_, err = modelsnapshotter.NewAPI(&mockBackend{}, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (*modelSnapshotterSuite) TestTakeModelSnapshot(c *gc.C) {
+	backend := &mockBackend{}
+	api, err := modelsnapshotter.NewAPI(backend, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Assert(err, jc.ErrorIsNil)
+	result := api.TakeModelSnapshot()
+	c.Assert(result.Error, gc.IsNil)
+	backend.CheckCallNames(c, "TakeModelSnapshot")
+}
+
+func (*modelSnapshotterSuite) TestTakeModelSnapshotError(c *gc.C) {
+	backend := &mockBackend{}
+	backend.SetErrors(errors.New("kaboom"))
+	api, err := modelsnapshotter.NewAPI(backend, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Assert(err, jc.ErrorIsNil)
+	result := api.TakeModelSnapshot()
+	c.Assert(result.Error, gc.ErrorMatches, "kaboom")
+}
+
+type mockBackend struct {
+	testing.Stub
+}
+
+func (b *mockBackend) TakeModelSnapshot() (state.ModelSnapshot, error) {
+	b.MethodCall(b, "TakeModelSnapshot")
+	return state.ModelSnapshot{Id: 1}, b.NextErr()
+}
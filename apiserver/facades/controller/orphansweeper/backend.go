@@ -0,0 +1,71 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package orphansweeper
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the methods the orphan sweeper facade needs from
+// state.State.
+type Backend interface {
+	// InstanceIds returns the provider ids of all provisioned
+	// machines in the model.
+	InstanceIds() ([]string, error)
+
+	// VolumeIds returns the provider ids of all provisioned volumes
+	// in the model.
+	VolumeIds() ([]string, error)
+
+	// SetOrphanedResources records the results of a sweep.
+	SetOrphanedResources(state.OrphanedResources) error
+}
+
+type backendShim struct {
+	*state.State
+}
+
+// InstanceIds is part of Backend.
+func (b backendShim) InstanceIds() ([]string, error) {
+	machines, err := b.State.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ids []string
+	for _, m := range machines {
+		id, err := m.InstanceId()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ids = append(ids, string(id))
+	}
+	return ids, nil
+}
+
+// VolumeIds is part of Backend.
+func (b backendShim) VolumeIds() ([]string, error) {
+	im, err := b.State.IAASModel()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumes, err := im.AllVolumes()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ids []string
+	for _, v := range volumes {
+		info, err := v.Info()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ids = append(ids, info.VolumeId)
+	}
+	return ids, nil
+}
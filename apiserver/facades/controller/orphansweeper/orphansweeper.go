@@ -0,0 +1,70 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package orphansweeper
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// API implements the API facade used by the orphan sweeper worker to
+// find out which provider resources are tracked in a model, and to
+// record those that are not.
+type API struct {
+	backend Backend
+}
+
+// NewAPI returns a new orphan sweeper API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{backend: backend}, nil
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(backendShim{st}, auth)
+}
+
+// TrackedResources returns the provider ids of the instances and
+// volumes that are recorded in the model.
+func (api *API) TrackedResources() params.TrackedResourcesResult {
+	instanceIds, err := api.backend.InstanceIds()
+	if err != nil {
+		return params.TrackedResourcesResult{Error: common.ServerError(err)}
+	}
+	volumeIds, err := api.backend.VolumeIds()
+	if err != nil {
+		return params.TrackedResourcesResult{Error: common.ServerError(err)}
+	}
+	return params.TrackedResourcesResult{
+		Result: &params.TrackedResources{
+			InstanceIds: instanceIds,
+			VolumeIds:   volumeIds,
+		},
+	}
+}
+
+// SetOrphanedResources records the results of a sweep of the model's
+// provider resources.
+func (api *API) SetOrphanedResources(args params.OrphanedResources) params.ErrorResult {
+	if args.Swept.IsZero() {
+		return params.ErrorResult{
+			Error: common.ServerError(errors.NotValidf("missing sweep time")),
+		}
+	}
+	err := api.backend.SetOrphanedResources(state.OrphanedResources{
+		Swept:          args.Swept,
+		Instances:      args.Instances,
+		Volumes:        args.Volumes,
+		SecurityGroups: args.SecurityGroups,
+		Cleaned:        args.Cleaned,
+	})
+	return params.ErrorResult{Error: common.ServerError(err)}
+}
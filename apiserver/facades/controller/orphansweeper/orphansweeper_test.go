@@ -0,0 +1,124 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package orphansweeper_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/controller/orphansweeper"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+)
+
+type orphanSweeperSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&orphanSweeperSuite{})
+
+func (*orphanSweeperSuite) TestRequiresController(c *gc.C) {
+	_, err := orphansweeper.NewAPI(&mockBackend{}, apiservertesting.FakeAuthorizer{Controller: false})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	_, err = orphansweeper.NewAPI(&mockBackend{}, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (*orphanSweeperSuite) TestTrackedResources(c *gc.C) {
+	backend := &mockBackend{
+		instanceIds: []string{"i-0", "i-1"},
+		volumeIds:   []string{"vol-0"},
+	}
+	api := makeAPI(c, backend)
+	result := api.TrackedResources()
+	c.Assert(result, jc.DeepEquals, params.TrackedResourcesResult{
+		Result: &params.TrackedResources{
+			InstanceIds: []string{"i-0", "i-1"},
+			VolumeIds:   []string{"vol-0"},
+		},
+	})
+	backend.CheckCallNames(c, "InstanceIds", "VolumeIds")
+}
+
+func (*orphanSweeperSuite) TestTrackedResourcesError(c *gc.C) {
+	backend := &mockBackend{}
+	backend.SetErrors(nil, errors.New("kaboom"))
+	api := makeAPI(c, backend)
+	result := api.TrackedResources()
+	c.Assert(result.Result, gc.IsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "kaboom")
+}
+
+func (*orphanSweeperSuite) TestSetOrphanedResources(c *gc.C) {
+	backend := &mockBackend{}
+	api := makeAPI(c, backend)
+	swept := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	result := api.SetOrphanedResources(params.OrphanedResources{
+		Swept:     swept,
+		Instances: []string{"i-0"},
+		Volumes:   []string{"vol-0"},
+		Cleaned:   []string{"vol-0"},
+	})
+	c.Assert(result.Error, gc.IsNil)
+	backend.CheckCalls(c, []testing.StubCall{{
+		"SetOrphanedResources", []interface{}{state.OrphanedResources{
+			Swept:     swept,
+			Instances: []string{"i-0"},
+			Volumes:   []string{"vol-0"},
+			Cleaned:   []string{"vol-0"},
+		}},
+	}})
+}
+
+func (*orphanSweeperSuite) TestSetOrphanedResourcesMissingSweepTime(c *gc.C) {
+	backend := &mockBackend{}
+	api := makeAPI(c, backend)
+	result := api.SetOrphanedResources(params.OrphanedResources{
+		Instances: []string{"i-0"},
+	})
+	c.Assert(result.Error, gc.ErrorMatches, "missing sweep time not valid")
+	backend.CheckNoCalls(c)
+}
+
+func (*orphanSweeperSuite) TestSetOrphanedResourcesError(c *gc.C) {
+	backend := &mockBackend{}
+	backend.SetErrors(errors.New("kaboom"))
+	api := makeAPI(c, backend)
+	result := api.SetOrphanedResources(params.OrphanedResources{
+		Swept: time.Now(),
+	})
+	c.Assert(result.Error, gc.ErrorMatches, "kaboom")
+}
+
+func makeAPI(c *gc.C, backend *mockBackend) *orphansweeper.API {
+	api, err := orphansweeper.NewAPI(backend, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+type mockBackend struct {
+	testing.Stub
+	instanceIds []string
+	volumeIds   []string
+}
+
+func (b *mockBackend) InstanceIds() ([]string, error) {
+	b.MethodCall(b, "InstanceIds")
+	return b.instanceIds, b.NextErr()
+}
+
+func (b *mockBackend) VolumeIds() ([]string, error) {
+	b.MethodCall(b, "VolumeIds")
+	return b.volumeIds, b.NextErr()
+}
+
+func (b *mockBackend) SetOrphanedResources(orphans state.OrphanedResources) error {
+	b.MethodCall(b, "SetOrphanedResources", orphans)
+	return b.NextErr()
+}
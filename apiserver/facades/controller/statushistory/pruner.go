@@ -4,6 +4,8 @@
 package statushistory
 
 import (
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
@@ -33,10 +35,14 @@ func NewAPI(st *state.State, r facade.Resources, auth facade.Authorizer) (*API,
 
 // Prune endpoint removes status history entries until
 // only the ones newer than now - p.MaxHistoryTime remain and
-// the history is smaller than p.MaxHistoryMB.
+// the history is smaller than p.MaxHistoryMB. The model's
+// activity feed is pruned to the same limits.
 func (api *API) Prune(p params.StatusHistoryPruneArgs) error {
 	if !api.authorizer.AuthController() {
 		return common.ErrPerm
 	}
-	return state.PruneStatusHistory(api.st, p.MaxHistoryTime, p.MaxHistoryMB)
+	if err := state.PruneStatusHistory(api.st, p.MaxHistoryTime, p.MaxHistoryMB); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(state.PruneModelEvents(api.st, p.MaxHistoryTime, p.MaxHistoryMB))
 }
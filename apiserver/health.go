@@ -0,0 +1,148 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+const (
+	healthStatusOK          = "ok"
+	healthStatusUnavailable = "unavailable"
+)
+
+// mongoPingCacheTime is how long the health handlers reuse the result
+// of pinging mongo. The handlers require no authentication, so without
+// it anyone able to reach the API server could use them to load mongo.
+const mongoPingCacheTime = 5 * time.Second
+
+// healthHandler reports the health of the API server, for load
+// balancers fronting the controllers of an HA controller. It requires
+// no authentication. A server is healthy if it can reach mongo; it is
+// ready if it is also not upgrading, restoring or draining, and so can
+// usefully accept new connections.
+//
+// The response status is 200 if the server is healthy (or ready), and
+// 503 otherwise, so that load balancers need not inspect the body.
+type healthHandler struct {
+	// readiness is true if the handler reports readiness rather
+	// than health.
+	readiness bool
+
+	pingMongo       func() error
+	upgradeComplete func() bool
+	restoreStatus   func() state.RestoreStatus
+	draining        <-chan struct{}
+}
+
+// newHealthHandler returns a handler reporting the server's health, or
+// its readiness if readiness is true, using the given function to check
+// that mongo can be reached.
+func (srv *Server) newHealthHandler(readiness bool, pingMongo func() error) *healthHandler {
+	return &healthHandler{
+		readiness:       readiness,
+		pingMongo:       pingMongo,
+		upgradeComplete: srv.upgradeComplete,
+		restoreStatus:   srv.restoreStatus,
+		draining:        srv.draining,
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *healthHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var err error
+	if req.Method != "GET" && req.Method != "HEAD" {
+		err = sendError(w, errors.MethodNotAllowedf("unsupported method: %q", req.Method))
+	} else {
+		result, ok := h.health()
+		statusCode := http.StatusOK
+		if !ok {
+			statusCode = http.StatusServiceUnavailable
+		}
+		err = sendStatusAndJSON(w, statusCode, result)
+	}
+	if err != nil {
+		logger.Errorf("%v", err)
+	}
+}
+
+// health returns the health of the server, and whether it is healthy,
+// or ready if the handler reports readiness.
+func (h *healthHandler) health() (params.HealthResult, bool) {
+	var result params.HealthResult
+	if err := h.pingMongo(); err != nil {
+		logger.Debugf("health check cannot reach mongo: %v", err)
+	} else {
+		result.Mongo = true
+	}
+	result.Upgrading = !h.upgradeComplete()
+	switch h.restoreStatus() {
+	case state.RestorePending, state.RestoreInProgress:
+		result.Restoring = true
+	}
+	select {
+	case <-h.draining:
+		result.Draining = true
+	default:
+	}
+
+	ok := result.Mongo
+	if h.readiness {
+		ok = ok && !result.Upgrading && !result.Restoring && !result.Draining
+	}
+	result.Status = healthStatusUnavailable
+	if ok {
+		result.Status = healthStatusOK
+	}
+	return result, ok
+}
+
+// cachedPing wraps a ping function so that it is called at most once
+// every mongoPingCacheTime, however often the cachedPing is pinged.
+type cachedPing struct {
+	clock clock.Clock
+	ping  func() error
+
+	// mu is held while pinging, so that concurrent callers share
+	// the result of a single ping.
+	mu   sync.Mutex
+	last time.Time
+	err  error
+}
+
+// newCachedPing returns a cachedPing that calls the given function.
+func newCachedPing(clock clock.Clock, ping func() error) *cachedPing {
+	return &cachedPing{clock: clock, ping: ping}
+}
+
+// Ping returns the result of the last call to the wrapped function if
+// it was made less than mongoPingCacheTime ago, and otherwise calls it
+// again.
+func (p *cachedPing) Ping() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.clock.Now()
+	if !p.last.IsZero() && now.Sub(p.last) < mongoPingCacheTime {
+		return p.err
+	}
+	p.err = p.ping()
+	p.last = now
+	return p.err
+}
+
+// pingMongo checks that the server can reach mongo, using a copy of
+// the system state's session so that a failure does not affect it.
+func (srv *Server) pingMongo() error {
+	session := srv.statePool.SystemState().MongoSession().Copy()
+	defer session.Close()
+	return errors.Trace(session.Ping())
+}
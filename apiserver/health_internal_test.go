@@ -0,0 +1,128 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type healthHandlerSuite struct {
+	coretesting.BaseSuite
+	mongoErr        error
+	upgradeComplete bool
+	restoreStatus   state.RestoreStatus
+	draining        chan struct{}
+}
+
+var _ = gc.Suite(&healthHandlerSuite{})
+
+func (s *healthHandlerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.mongoErr = nil
+	s.upgradeComplete = true
+	s.restoreStatus = state.RestoreNotActive
+	s.draining = make(chan struct{})
+}
+
+func (s *healthHandlerSuite) get(c *gc.C, readiness bool) (int, params.HealthResult) {
+	handler := &healthHandler{
+		readiness:       readiness,
+		pingMongo:       func() error { return s.mongoErr },
+		upgradeComplete: func() bool { return s.upgradeComplete },
+		restoreStatus:   func() state.RestoreStatus { return s.restoreStatus },
+		draining:        s.draining,
+	}
+	req, err := http.NewRequest("GET", "/health", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	var result params.HealthResult
+	err = json.Unmarshal(rec.Body.Bytes(), &result)
+	c.Assert(err, jc.ErrorIsNil)
+	return rec.Code, result
+}
+
+func (s *healthHandlerSuite) TestHealthy(c *gc.C) {
+	for _, readiness := range []bool{false, true} {
+		code, result := s.get(c, readiness)
+		c.Check(code, gc.Equals, http.StatusOK)
+		c.Check(result, jc.DeepEquals, params.HealthResult{Status: "ok", Mongo: true})
+	}
+}
+
+func (s *healthHandlerSuite) TestMongoUnreachable(c *gc.C) {
+	s.mongoErr = errors.New("no reachable servers")
+	for _, readiness := range []bool{false, true} {
+		code, result := s.get(c, readiness)
+		c.Check(code, gc.Equals, http.StatusServiceUnavailable)
+		c.Check(result, jc.DeepEquals, params.HealthResult{Status: "unavailable"})
+	}
+}
+
+func (s *healthHandlerSuite) TestUpgrading(c *gc.C) {
+	s.upgradeComplete = false
+	code, result := s.get(c, false)
+	c.Check(code, gc.Equals, http.StatusOK)
+	c.Check(result, jc.DeepEquals, params.HealthResult{Status: "ok", Mongo: true, Upgrading: true})
+
+	code, result = s.get(c, true)
+	c.Check(code, gc.Equals, http.StatusServiceUnavailable)
+	c.Check(result, jc.DeepEquals, params.HealthResult{Status: "unavailable", Mongo: true, Upgrading: true})
+}
+
+func (s *healthHandlerSuite) TestRestoring(c *gc.C) {
+	s.restoreStatus = state.RestoreInProgress
+	code, result := s.get(c, true)
+	c.Check(code, gc.Equals, http.StatusServiceUnavailable)
+	c.Check(result, jc.DeepEquals, params.HealthResult{Status: "unavailable", Mongo: true, Restoring: true})
+}
+
+func (s *healthHandlerSuite) TestDraining(c *gc.C) {
+	close(s.draining)
+	code, _ := s.get(c, false)
+	c.Check(code, gc.Equals, http.StatusOK)
+
+	code, result := s.get(c, true)
+	c.Check(code, gc.Equals, http.StatusServiceUnavailable)
+	c.Check(result, jc.DeepEquals, params.HealthResult{Status: "unavailable", Mongo: true, Draining: true})
+}
+
+type cachedPingSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&cachedPingSuite{})
+
+func (s *cachedPingSuite) TestPingCached(c *gc.C) {
+	clock := testing.NewClock(time.Now())
+	var calls int
+	pingErr := errors.New("no reachable servers")
+	ping := newCachedPing(clock, func() error {
+		calls++
+		return pingErr
+	})
+
+	c.Assert(ping.Ping(), gc.Equals, pingErr)
+	pingErr = nil
+	clock.Advance(mongoPingCacheTime - time.Nanosecond)
+	c.Assert(ping.Ping(), gc.ErrorMatches, "no reachable servers")
+	c.Assert(calls, gc.Equals, 1)
+
+	clock.Advance(time.Nanosecond)
+	c.Assert(ping.Ping(), jc.ErrorIsNil)
+	c.Assert(calls, gc.Equals, 2)
+}
@@ -0,0 +1,54 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"encoding/json"
+	"net/http"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+type healthSuite struct {
+	authHTTPSuite
+}
+
+var _ = gc.Suite(&healthSuite{})
+
+func (s *healthSuite) get(c *gc.C, path string) *http.Response {
+	url := s.baseURL(c)
+	url.Path = path
+	return s.sendRequest(c, httpRequestParams{
+		method: "GET",
+		url:    url.String(),
+	})
+}
+
+func (s *healthSuite) TestHealthWithoutLogin(c *gc.C) {
+	for _, path := range []string{"/health", "/readiness"} {
+		resp := s.get(c, path)
+		c.Check(resp.StatusCode, gc.Equals, http.StatusOK)
+		c.Check(resp.Header.Get("Content-Type"), gc.Equals, params.ContentTypeJSON)
+
+		var result params.HealthResult
+		err := json.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(result, jc.DeepEquals, params.HealthResult{Status: "ok", Mongo: true})
+	}
+}
+
+func (s *healthSuite) TestHealthMethodNotAllowed(c *gc.C) {
+	url := s.baseURL(c)
+	url.Path = "/health"
+	resp := s.sendRequest(c, httpRequestParams{
+		method: "POST",
+		url:    url.String(),
+	})
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusMethodNotAllowed)
+}
@@ -85,7 +85,7 @@ func (ctxt *httpContext) stateForRequestAuthenticated(r *http.Request) (
 	}
 
 	authenticator := ctxt.srv.loginAuthCtxt.authenticator(r.Host)
-	entity, _, err := checkCreds(st, req, authTag, true, authenticator)
+	entity, _, err := checkCreds(st, req, authTag, true, authenticator, ctxt.srv.externalAccess)
 	if err != nil {
 		if common.IsDischargeRequiredError(err) {
 			return nil, nil, nil, errors.Trace(err)
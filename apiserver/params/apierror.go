@@ -42,6 +42,36 @@ type ErrorInfo struct {
 	// If it is empty, the macaroon will be associated with
 	// the original URL from which the error was returned.
 	MacaroonPath string `json:"macaroon-path,omitempty"`
+
+	// ActionParams holds the problems found with each of the
+	// parameters given for an action. This field is associated
+	// with the CodeActionParamsNotValid error code.
+	ActionParams []ActionParamError `json:"action-params,omitempty"`
+
+	// PlacementConflict describes why units cannot be placed on a
+	// machine. This field is associated with the
+	// CodePlacementConflict error code.
+	PlacementConflict *PlacementConflict `json:"placement-conflict,omitempty"`
+}
+
+// PlacementConflict describes why units cannot be placed on a machine.
+// OwnerModelUUID and OwnerModelName are set if the machine belongs to
+// another model than the one in which the units were to be placed.
+type PlacementConflict struct {
+	MachineId      string      `json:"machine-id"`
+	OwnerModelUUID string      `json:"owner-model-uuid,omitempty"`
+	OwnerModelName string      `json:"owner-model-name,omitempty"`
+	Load           MachineLoad `json:"load"`
+	Conflicts      []string    `json:"conflicts"`
+}
+
+// ActionParamError describes why the value given for one of an
+// action's parameters is not valid. Field is the path to the
+// parameter, with the names of nested parameters separated by dots,
+// or empty if the problem is with the parameters as a whole.
+type ActionParamError struct {
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
 }
 
 func (e Error) Error() string {
@@ -98,6 +128,11 @@ const (
 	CodeRedirect                  = "redirection required"
 	CodeRetry                     = "retry"
 	CodeIncompatibleSeries        = "incompatible series"
+	CodeQuotaExceeded             = "quota exceeded"
+	CodeTimeout                   = "timeout"
+	CodeInMaintenance             = "in maintenance"
+	CodeActionParamsNotValid      = "action parameters not valid"
+	CodePlacementConflict         = "placement conflict"
 )
 
 // ErrCode returns the error code associated with
@@ -260,6 +295,26 @@ func IsCodeIncompatibleSeries(err error) bool {
 	return ErrCode(err) == CodeIncompatibleSeries
 }
 
+func IsCodeQuotaExceeded(err error) bool {
+	return ErrCode(err) == CodeQuotaExceeded
+}
+
+func IsCodeTimeout(err error) bool {
+	return ErrCode(err) == CodeTimeout
+}
+
+func IsCodeInMaintenance(err error) bool {
+	return ErrCode(err) == CodeInMaintenance
+}
+
+func IsCodeActionParamsNotValid(err error) bool {
+	return ErrCode(err) == CodeActionParamsNotValid
+}
+
+func IsCodePlacementConflict(err error) bool {
+	return ErrCode(err) == CodePlacementConflict
+}
+
 func IsCodeForbidden(err error) bool {
 	return ErrCode(err) == CodeForbidden
 }
@@ -31,6 +31,11 @@ type BlockSwitchParams struct {
 	// Message is a descriptive or an explanatory message
 	// that accompanies the switch.
 	Message string `json:"message,omitempty"`
+
+	// Tag, if set, holds the tag of the application or machine
+	// to switch the block on/off for. If it is empty, the block
+	// applies to the whole model.
+	Tag string `json:"tag,omitempty"`
 }
 
 // BlockResult holds the result of an API call to retrieve details
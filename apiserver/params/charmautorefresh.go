@@ -0,0 +1,23 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// PendingCharmUpgrade describes a newer revision of an application's
+// charm that is waiting to be applied by the charm auto-refresh
+// worker.
+type PendingCharmUpgrade struct {
+	ApplicationTag string    `json:"application-tag"`
+	CharmURL       string    `json:"charm-url"`
+	Policy         string    `json:"policy"`
+	Created        time.Time `json:"created"`
+}
+
+// PendingCharmUpgradesResult holds the upgrades pending for a model's
+// applications, or an error.
+type PendingCharmUpgradesResult struct {
+	Upgrades []PendingCharmUpgrade `json:"upgrades,omitempty"`
+	Error    *Error                `json:"error,omitempty"`
+}
@@ -0,0 +1,53 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// CharmUpgrade describes the upgrade of an application to the latest
+// revision of its charm.
+type CharmUpgrade struct {
+	// Application is the name of the application to upgrade.
+	Application string `json:"application"`
+
+	// From is the URL of the application's charm when the upgrade
+	// was planned.
+	From string `json:"from"`
+
+	// To is the URL of the charm to upgrade the application to.
+	To string `json:"to"`
+
+	// Channel is the charm store channel the new revision was
+	// resolved in.
+	Channel string `json:"channel,omitempty"`
+
+	// ConfigNotes describes the differences between the config
+	// schemas of the two charms that affect the application.
+	ConfigNotes []string `json:"config-notes,omitempty"`
+}
+
+// CharmUpgradePlan holds the result of CharmUpgrades.PlanUpgradeAll.
+type CharmUpgradePlan struct {
+	// Upgrades holds the planned upgrades, in the order they will
+	// be applied.
+	Upgrades []CharmUpgrade `json:"upgrades"`
+	Error    *Error         `json:"error,omitempty"`
+}
+
+// UpgradeAllArgs holds the arguments to CharmUpgrades.UpgradeAll.
+type UpgradeAllArgs struct {
+	// Upgrades holds the upgrades, from a previous plan, to apply.
+	Upgrades []CharmUpgrade `json:"upgrades"`
+}
+
+// UpgradeAllResult holds the result of CharmUpgrades.UpgradeAll.
+type UpgradeAllResult struct {
+	// Applied holds the names of the applications that were
+	// upgraded, in the order they were upgraded.
+	Applied []string `json:"applied"`
+
+	// Pending holds the names of the applications that were not
+	// upgraded because an earlier upgrade failed, starting with the
+	// application whose upgrade failed.
+	Pending []string `json:"pending,omitempty"`
+	Error   *Error   `json:"error,omitempty"`
+}
@@ -3,6 +3,8 @@
 
 package params
 
+import "time"
+
 // Cloud holds information about a cloud.
 type Cloud struct {
 	Type             string        `json:"type"`
@@ -114,3 +116,22 @@ type CloudSpecResult struct {
 type CloudSpecResults struct {
 	Results []CloudSpecResult `json:"results,omitempty"`
 }
+
+// ScopedCredential holds a short-lived cloud credential minted for
+// the charm of a trusted application.
+type ScopedCredential struct {
+	AuthType   string            `json:"auth-type"`
+	Attributes map[string]string `json:"attrs,omitempty"`
+	Expiry     time.Time         `json:"expiry"`
+}
+
+// ScopedCredentialResult contains a ScopedCredential or an error.
+type ScopedCredentialResult struct {
+	Result *ScopedCredential `json:"result,omitempty"`
+	Error  *Error            `json:"error,omitempty"`
+}
+
+// ScopedCredentialResults contains a set of ScopedCredentialResults.
+type ScopedCredentialResults struct {
+	Results []ScopedCredentialResult `json:"results"`
+}
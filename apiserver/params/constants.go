@@ -19,6 +19,10 @@ const (
 	// happens when running inside a container, and a hook on the parent
 	// machine requests a reboot
 	ShouldShutdown RebootAction = "shutdown"
+	// ShouldWait instructs a machine that is to reboot or shut down
+	// to ask again later, because the model is not in a maintenance
+	// window
+	ShouldWait RebootAction = "wait"
 )
 
 // ResolvedMode describes the way state transition errors
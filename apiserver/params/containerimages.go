@@ -0,0 +1,100 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// ContainerImage describes an image cached by a machine agent for
+// creating containers.
+type ContainerImage struct {
+	Series      string    `json:"series"`
+	Arch        string    `json:"arch"`
+	Fingerprint string    `json:"fingerprint"`
+	Size        int64     `json:"size"`
+	LastUsed    time.Time `json:"last-used"`
+}
+
+// ContainerImagePurge describes a request to remove images from a
+// machine's container image cache. If Series is empty, all images are
+// to be removed.
+type ContainerImagePurge struct {
+	Series    []string  `json:"series,omitempty"`
+	Requested time.Time `json:"requested"`
+}
+
+// ContainerImageCacheConfig holds the information a machine agent
+// needs to manage its container image cache.
+type ContainerImageCacheConfig struct {
+	// PrefetchSeries holds the series of the images that the agent
+	// should fetch before they are needed.
+	PrefetchSeries []string `json:"prefetch-series"`
+
+	// MaxSizeMB is the size, in megabytes, that the cache should be
+	// kept within.
+	MaxSizeMB uint `json:"max-size-mb"`
+
+	// Purge holds any purge the agent should carry out.
+	Purge *ContainerImagePurge `json:"purge,omitempty"`
+}
+
+// ContainerImageCacheConfigResult holds the result of a
+// ContainerImageCache.ContainerImageCacheConfig call for one machine.
+type ContainerImageCacheConfigResult struct {
+	Result *ContainerImageCacheConfig `json:"result,omitempty"`
+	Error  *Error                     `json:"error,omitempty"`
+}
+
+// ContainerImageCacheConfigResults holds the results of a
+// ContainerImageCache.ContainerImageCacheConfig call.
+type ContainerImageCacheConfigResults struct {
+	Results []ContainerImageCacheConfigResult `json:"results"`
+}
+
+// SetContainerImages holds the images cached by a machine agent, along
+// with the purge it carried out, if any.
+type SetContainerImages struct {
+	Tag    string               `json:"tag"`
+	Images []ContainerImage     `json:"images"`
+	Purged *ContainerImagePurge `json:"purged,omitempty"`
+}
+
+// SetContainerImagesArgs holds the arguments for a
+// ContainerImageCache.SetContainerImages call.
+type SetContainerImagesArgs struct {
+	Args []SetContainerImages `json:"args"`
+}
+
+// ContainerImageCache describes a machine's container image cache.
+type ContainerImageCache struct {
+	Images  []ContainerImage     `json:"images"`
+	Updated time.Time            `json:"updated"`
+	Purge   *ContainerImagePurge `json:"purge,omitempty"`
+}
+
+// ContainerImageCacheResult holds a machine's container image cache,
+// or an error.
+type ContainerImageCacheResult struct {
+	Result *ContainerImageCache `json:"result,omitempty"`
+	Error  *Error               `json:"error,omitempty"`
+}
+
+// ContainerImageCacheResults holds the results of a
+// MachineManager.ContainerImageCache call.
+type ContainerImageCacheResults struct {
+	Results []ContainerImageCacheResult `json:"results"`
+}
+
+// PurgeContainerImages holds a request to remove the images of the
+// given series, or all images if none are given, from a machine's
+// container image cache.
+type PurgeContainerImages struct {
+	Tag    string   `json:"tag"`
+	Series []string `json:"series,omitempty"`
+}
+
+// PurgeContainerImagesArgs holds the arguments for a
+// MachineManager.PurgeContainerImages call.
+type PurgeContainerImagesArgs struct {
+	Args []PurgeContainerImages `json:"args"`
+}
@@ -3,6 +3,8 @@
 
 package params
 
+import "time"
+
 // DestroyControllerArgs holds the arguments for destroying a controller.
 type DestroyControllerArgs struct {
 	// DestroyModels specifies whether or not the hosted models
@@ -90,6 +92,132 @@ type UserAccessResults struct {
 	Results []UserAccessResult `json:"results,omitempty"`
 }
 
+// AgentBinaryStorageUsage describes the storage used by the agent
+// binaries stored by a controller for all of its models.
+type AgentBinaryStorageUsage struct {
+	// Entries is the number of agent binaries in the catalogues
+	// of all models.
+	Entries int `json:"entries"`
+
+	// Binaries is the number of distinct agent binaries stored.
+	Binaries int `json:"binaries"`
+
+	// Size is the total size, in bytes, of the agent binaries in
+	// the catalogues of all models.
+	Size int64 `json:"size"`
+
+	// StoredSize is the size, in bytes, of the distinct agent
+	// binaries stored.
+	StoredSize int64 `json:"stored-size"`
+}
+
+// AgentConnectionHistoryResults holds the recent API connections that
+// agents have made to an API server.
+type AgentConnectionHistoryResults struct {
+	Agents []AgentConnectionHistory `json:"agents"`
+}
+
+// AgentConnectionHistory holds the recent API connections that an
+// agent has made to an API server.
+type AgentConnectionHistory struct {
+	// Tag is the tag of the agent's entity.
+	Tag string `json:"tag"`
+
+	// ModelTag is the tag of the model the agent connected to.
+	ModelTag string `json:"model-tag"`
+
+	// TotalConnections is the number of connections the agent has
+	// made since the API server started, including those that are
+	// no longer held in Connections.
+	TotalConnections int `json:"total-connections"`
+
+	// Connections holds the agent's most recent connections, oldest
+	// first.
+	Connections []AgentConnection `json:"connections"`
+}
+
+// AgentConnection describes one API connection made by an agent.
+type AgentConnection struct {
+	// ConnectionID is the API server's identifier for the connection.
+	ConnectionID uint64 `json:"connection-id"`
+
+	// RemoteAddress is the address the connection was made from.
+	RemoteAddress string `json:"remote-address"`
+
+	// Connected is when the connection was made.
+	Connected time.Time `json:"connected"`
+
+	// Disconnected is when the connection was closed, or nil if it
+	// is still open.
+	Disconnected *time.Time `json:"disconnected,omitempty"`
+
+	// FacadeCalls holds the number of requests made over the
+	// connection to each facade, keyed on facade name.
+	FacadeCalls map[string]int `json:"facade-calls,omitempty"`
+}
+
+// DatabaseReport holds the statistics of the controller's database.
+type DatabaseReport struct {
+	// Members holds the controller machines whose database members
+	// have reported.
+	Members []DatabaseMember `json:"members"`
+
+	// Collections holds the sizes of the collections, as last
+	// reported by the replica set's primary.
+	Collections []DatabaseCollection `json:"collections"`
+
+	// UnusedIndexes holds the indexes that no member has used since
+	// it started.
+	UnusedIndexes []DatabaseIndex `json:"unused-indexes"`
+
+	// MissingIndexes holds the query patterns that members have
+	// answered by scanning whole collections, most frequent first.
+	MissingIndexes []DatabaseQueryPattern `json:"missing-indexes"`
+}
+
+// DatabaseMember describes a controller machine's member of the
+// database replica set.
+type DatabaseMember struct {
+	MachineId string    `json:"machine-id"`
+	Updated   time.Time `json:"updated"`
+	Primary   bool      `json:"primary"`
+
+	// LastCompaction describes the member's last compaction, or is
+	// nil if it has not been compacted.
+	LastCompaction *DatabaseCompaction `json:"last-compaction,omitempty"`
+}
+
+// DatabaseCompaction describes the compaction of a database member.
+type DatabaseCompaction struct {
+	Started     time.Time `json:"started"`
+	Finished    time.Time `json:"finished"`
+	Collections []string  `json:"collections,omitempty"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// DatabaseCollection holds the sizes of a database collection.
+type DatabaseCollection struct {
+	Name             string `json:"name"`
+	Count            int64  `json:"count"`
+	SizeBytes        int64  `json:"size-bytes"`
+	StorageSizeBytes int64  `json:"storage-size-bytes"`
+	IndexSizeBytes   int64  `json:"index-size-bytes"`
+}
+
+// DatabaseIndex identifies an index of a database collection.
+type DatabaseIndex struct {
+	Collection string `json:"collection"`
+	Name       string `json:"name"`
+}
+
+// DatabaseQueryPattern describes the queries on a collection that
+// filtered on the same set of fields.
+type DatabaseQueryPattern struct {
+	Collection string   `json:"collection"`
+	Fields     []string `json:"fields"`
+	Count      int      `json:"count"`
+}
+
 // ControllerAction is an action that can be performed on a model.
 type ControllerAction string
 
@@ -98,3 +226,29 @@ const (
 	GrantControllerAccess  ControllerAction = "grant"
 	RevokeControllerAccess ControllerAction = "revoke"
 )
+
+// StartBreakGlassSessionArgs holds the arguments for starting a
+// break-glass session, during which the caller is temporarily elevated
+// to controller superuser.
+type StartBreakGlassSessionArgs struct {
+	// Reason is why the caller needs to be elevated. It is required.
+	Reason string `json:"reason"`
+
+	// Duration is how long the session lasts, unless it is ended
+	// earlier.
+	Duration time.Duration `json:"duration"`
+}
+
+// BreakGlassSession describes a break-glass session.
+type BreakGlassSession struct {
+	Id      string    `json:"id"`
+	UserTag string    `json:"user-tag"`
+	Reason  string    `json:"reason"`
+	Started time.Time `json:"started"`
+	Expires time.Time `json:"expires"`
+}
+
+// BreakGlassSessions holds the active break-glass sessions.
+type BreakGlassSessions struct {
+	Sessions []BreakGlassSession `json:"sessions"`
+}
@@ -0,0 +1,68 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// APICertificateInfo describes the certificate presented by the
+// controller's API servers. It never includes private keys.
+type APICertificateInfo struct {
+	// Source is "controller-ca" if the API servers present the
+	// certificate signed by the controller's CA, "external" if they
+	// present a certificate chain set by an administrator, or "acme"
+	// if they present a certificate issued by an ACME CA.
+	Source string `json:"source"`
+
+	// CertChain holds the PEM-encoded certificate chain presented
+	// in place of the controller CA's certificate, if any.
+	CertChain string `json:"cert-chain,omitempty"`
+
+	// DNSNames holds the names the presented chain's leaf
+	// certificate is valid for.
+	DNSNames []string `json:"dns-names,omitempty"`
+
+	// Expiry is the time at which the presented chain's leaf
+	// certificate expires.
+	Expiry *time.Time `json:"expiry,omitempty"`
+
+	// Updated is the time at which the presented chain was set.
+	Updated *time.Time `json:"updated,omitempty"`
+
+	// ACME holds the configuration used to obtain certificates from
+	// an ACME CA, if any.
+	ACME *ACMEConfig `json:"acme,omitempty"`
+}
+
+// SetExternalCertificate holds the arguments to the
+// ControllerCertificates.SetExternalCertificate call.
+type SetExternalCertificate struct {
+	// CertChain holds the PEM-encoded leaf certificate followed by
+	// any intermediate certificates.
+	CertChain string `json:"cert-chain"`
+
+	// PrivateKey holds the PEM-encoded private key of the leaf
+	// certificate.
+	PrivateKey string `json:"private-key"`
+}
+
+// ACMEConfig holds the configuration used to obtain API certificates
+// from an ACME CA using DNS-01 challenges.
+type ACMEConfig struct {
+	// DirectoryURL is the URL of the ACME CA's directory.
+	DirectoryURL string `json:"directory-url"`
+
+	// DNSNames holds the names to obtain a certificate for.
+	DNSNames []string `json:"dns-names"`
+
+	// Email is the contact address registered with the ACME account.
+	Email string `json:"email,omitempty"`
+
+	// DNSHook is the command run on the primary controller machine
+	// to publish and remove the DNS-01 challenge records.
+	DNSHook string `json:"dns-hook"`
+
+	// RenewBefore is how long before expiry the certificate is
+	// renewed. If zero, a default is used.
+	RenewBefore time.Duration `json:"renew-before,omitempty"`
+}
@@ -0,0 +1,60 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// ControllerHealth holds a report of the health of a controller as a
+// whole.
+type ControllerHealth struct {
+	// Healthy is true if no problems were found.
+	Healthy bool `json:"healthy"`
+
+	// Problems describes each problem found, if any.
+	Problems []string `json:"problems,omitempty"`
+
+	// Mongo holds the health of the controller's mongo replica set.
+	Mongo []MongoMemberHealth `json:"mongo"`
+
+	// Machines holds the health most recently reported by each
+	// controller machine.
+	Machines []ControllerMachineHealth `json:"machines"`
+
+	// LogsSizeMB is the total size of the log collections.
+	LogsSizeMB int `json:"logs-size-mb"`
+
+	// MaxLogsSizeMB is the size above which the log collections
+	// are pruned.
+	MaxLogsSizeMB int `json:"max-logs-size-mb"`
+}
+
+// MongoMemberHealth holds the health of a single member of the
+// controller's mongo replica set.
+type MongoMemberHealth struct {
+	Id      int    `json:"id"`
+	Address string `json:"address"`
+	State   string `json:"state"`
+	Healthy bool   `json:"healthy"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ControllerMachineHealth holds the health reported by a single
+// controller machine.
+type ControllerMachineHealth struct {
+	MachineId      string         `json:"machine-id"`
+	Updated        time.Time      `json:"updated"`
+	Stale          bool           `json:"stale,omitempty"`
+	DiskFreeBytes  uint64         `json:"disk-free-bytes"`
+	DiskTotalBytes uint64         `json:"disk-total-bytes"`
+	APIConnections int64          `json:"api-connections"`
+	Workers        []WorkerHealth `json:"workers,omitempty"`
+}
+
+// WorkerHealth holds the state of a critical worker running in a
+// controller agent.
+type WorkerHealth struct {
+	Name  string `json:"name"`
+	State string `json:"state"`
+	Error string `json:"error,omitempty"`
+}
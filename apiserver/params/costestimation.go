@@ -0,0 +1,33 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// ResourceCost holds the estimated cost of one of the cloud resources
+// used by a model. If the resource's price is not known, Error is set
+// and the resource does not contribute to the model's cost.
+type ResourceCost struct {
+	// Kind is the kind of resource: "machine", "volume",
+	// "filesystem" or "load-balancer".
+	Kind string `json:"kind"`
+
+	// Id identifies the resource among those of its kind; for
+	// load balancers, it is the name of the application.
+	Id string `json:"id"`
+
+	// Description describes what was priced, such as the instance
+	// type or size of storage.
+	Description string `json:"description,omitempty"`
+
+	HourlyCost float64 `json:"hourly-cost"`
+	Error      *Error  `json:"error,omitempty"`
+}
+
+// ModelCost holds the estimated cost of the cloud resources used by
+// a model.
+type ModelCost struct {
+	Currency    string         `json:"currency"`
+	HourlyCost  float64        `json:"hourly-cost"`
+	MonthlyCost float64        `json:"monthly-cost"`
+	Resources   []ResourceCost `json:"resources"`
+}
@@ -0,0 +1,60 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// CrashReportUpload holds a crash report bundle collected by an agent.
+type CrashReportUpload struct {
+	// Agent is the tag of the agent that crashed.
+	Agent string `json:"agent"`
+
+	// Time is when the agent crashed.
+	Time time.Time `json:"time"`
+
+	// Reason describes the crash.
+	Reason string `json:"reason"`
+
+	// Bundle holds the compressed archive of diagnostics.
+	Bundle []byte `json:"bundle"`
+}
+
+// CrashReportUploads holds the arguments for uploading crash reports.
+type CrashReportUploads struct {
+	Reports []CrashReportUpload `json:"reports"`
+}
+
+// CrashReport describes a crash report stored by the controller.
+type CrashReport struct {
+	Id       string    `json:"id"`
+	Agent    string    `json:"agent"`
+	Time     time.Time `json:"time"`
+	Uploaded time.Time `json:"uploaded"`
+	Reason   string    `json:"reason"`
+	Size     int64     `json:"size"`
+}
+
+// CrashReportsResult holds the crash reports stored for a model.
+type CrashReportsResult struct {
+	Reports []CrashReport `json:"reports"`
+}
+
+// CrashReportIds holds the ids of crash reports.
+type CrashReportIds struct {
+	Ids []string `json:"ids"`
+}
+
+// CrashReportBundleResult holds a crash report and its bundle, or an
+// error.
+type CrashReportBundleResult struct {
+	Report CrashReport `json:"report"`
+	Bundle []byte      `json:"bundle,omitempty"`
+	Error  *Error      `json:"error,omitempty"`
+}
+
+// CrashReportBundleResults holds the results of downloading crash
+// reports.
+type CrashReportBundleResults struct {
+	Results []CrashReportBundleResult `json:"results"`
+}
@@ -0,0 +1,32 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// DNSMachine holds the details of a machine that the DNS updater needs
+// to register the machine in the provider's DNS.
+type DNSMachine struct {
+	Tag        string    `json:"tag"`
+	Life       Life      `json:"life"`
+	InstanceId string    `json:"instance-id,omitempty"`
+	Addresses  []Address `json:"addresses,omitempty"`
+	FQDN       string    `json:"fqdn,omitempty"`
+}
+
+// DNSMachinesResult holds the result of a DNSUpdater.Machines call.
+type DNSMachinesResult struct {
+	Machines []DNSMachine `json:"machines"`
+	Error    *Error       `json:"error,omitempty"`
+}
+
+// MachineFQDN holds the fully qualified domain name registered for a
+// machine in the provider's DNS.
+type MachineFQDN struct {
+	Tag  string `json:"tag"`
+	FQDN string `json:"fqdn"`
+}
+
+// SetMachineFQDNs holds the arguments for a DNSUpdater.SetFQDNs call.
+type SetMachineFQDNs struct {
+	Machines []MachineFQDN `json:"machines"`
+}
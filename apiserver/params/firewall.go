@@ -3,7 +3,13 @@
 
 package params
 
-import "github.com/juju/errors"
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/network"
+)
 
 // FirewallRuleArgs holds the parameters for updating
 // one or more firewall rules.
@@ -60,3 +66,148 @@ func (v KnownServiceValue) Validate() error {
 	}
 	return errors.NotValidf("known service %q", v)
 }
+
+// IngressRule is a range of ports and the source CIDRs from which
+// ingress to them is allowed.
+type IngressRule struct {
+	PortRange   PortRange `json:"port-range"`
+	SourceCIDRs []string  `json:"source-cidrs,omitempty"`
+}
+
+// FromNetworkIngressRules is a convenience helper to create parameters
+// out of the network type, here for IngressRule.
+func FromNetworkIngressRules(rules []network.IngressRule) []IngressRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	result := make([]IngressRule, len(rules))
+	for i, rule := range rules {
+		result[i] = IngressRule{
+			PortRange:   FromNetworkPortRange(rule.PortRange),
+			SourceCIDRs: rule.SourceCIDRs,
+		}
+	}
+	return result
+}
+
+// NetworkIngressRules is a convenience helper to return the network
+// type for the parameters, here for IngressRule.
+func NetworkIngressRules(rules []IngressRule) []network.IngressRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	result := make([]network.IngressRule, len(rules))
+	for i, rule := range rules {
+		result[i] = network.IngressRule{
+			PortRange:   rule.PortRange.NetworkPortRange(),
+			SourceCIDRs: rule.SourceCIDRs,
+		}
+	}
+	return result
+}
+
+// FirewallReport holds the firewaller's comparison of the ingress rules
+// it wants applied with those the provider has actually applied.
+type FirewallReport struct {
+	// MachineTag is the tag of the machine whose instance the report
+	// describes. It is empty for the report describing the whole
+	// model in global firewall mode.
+	MachineTag string `json:"machine-tag,omitempty"`
+
+	// Checked is when the firewaller made the comparison.
+	Checked time.Time `json:"checked"`
+
+	// Wanted holds the ingress rules the firewaller wants applied.
+	Wanted []IngressRule `json:"wanted,omitempty"`
+
+	// Actual holds the ingress rules the provider has applied.
+	Actual []IngressRule `json:"actual,omitempty"`
+
+	// Missing holds the wanted ingress rules that the provider has
+	// not applied.
+	Missing []IngressRule `json:"missing,omitempty"`
+
+	// Unexpected holds the ingress rules that the provider has
+	// applied but the firewaller does not want.
+	Unexpected []IngressRule `json:"unexpected,omitempty"`
+
+	// Error, if not empty, describes why the provider's ingress
+	// rules could not be read.
+	Error string `json:"error,omitempty"`
+}
+
+// FirewallReports holds the reports made by the firewaller in a
+// single check of the model's firewall.
+type FirewallReports struct {
+	Reports []FirewallReport `json:"reports"`
+}
+
+// The states of a port opened by a unit, as seen from outside the
+// model.
+const (
+	// PortOpen means the provider allows ingress to the port.
+	PortOpen = "open"
+
+	// PortMissing means the port should be open, because the
+	// unit's application is exposed, but the provider does not
+	// allow ingress to it.
+	PortMissing = "missing"
+
+	// PortUnexposed means the unit's application is not exposed,
+	// so ingress to the port is not wanted.
+	PortUnexposed = "unexposed"
+
+	// PortUnchecked means the firewaller has not reported on the
+	// provider's ingress rules for the unit's machine.
+	PortUnchecked = "unchecked"
+)
+
+// FirewallReportResult holds the reconciliation of the ports opened by
+// the units of a model's applications with the ingress rules that the
+// provider has applied.
+type FirewallReportResult struct {
+	// FirewallMode is the model's firewall mode.
+	FirewallMode string `json:"firewall-mode"`
+
+	// Applications holds the firewall state of each application
+	// requested, ordered by name.
+	Applications []ApplicationFirewallState `json:"applications"`
+
+	// Reports holds the firewaller's most recent reports on the
+	// provider's ingress rules, for the requested applications'
+	// machines or, in global firewall mode, for the whole model.
+	Reports []FirewallReport `json:"reports,omitempty"`
+}
+
+// ApplicationFirewallState holds whether an application is exposed,
+// how that has changed, and the state of the ports its units have
+// opened.
+type ApplicationFirewallState struct {
+	Name    string `json:"name"`
+	Exposed bool   `json:"exposed"`
+
+	// ExposeHistory holds the most recent events recording the
+	// application being exposed or unexposed, oldest first.
+	ExposeHistory []ModelEvent `json:"expose-history,omitempty"`
+
+	Units []UnitFirewallState `json:"units,omitempty"`
+}
+
+// UnitFirewallState holds the state of the ports opened by a unit.
+type UnitFirewallState struct {
+	UnitTag    string `json:"unit-tag"`
+	MachineTag string `json:"machine-tag,omitempty"`
+
+	// Ports holds the port ranges opened by the unit, and whether
+	// the provider allows ingress to each of them.
+	Ports []PortFirewallState `json:"ports,omitempty"`
+}
+
+// PortFirewallState holds the state of a port range opened by a unit.
+type PortFirewallState struct {
+	PortRange PortRange `json:"port-range"`
+
+	// State is one of PortOpen, PortMissing, PortUnexposed or
+	// PortUnchecked.
+	State string `json:"state"`
+}
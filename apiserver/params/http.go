@@ -29,6 +29,10 @@ const (
 
 	// ContentTypeXJS is the outdated HTTP content-type value used for javascript.
 	ContentTypeXJS = "application/x-javascript"
+
+	// ContentTypeToolsBundle is the HTTP content-type value used for
+	// bundles of agent binaries uploaded to the tools endpoint.
+	ContentTypeToolsBundle = "application/x-tar"
 )
 
 // EncodeChecksum base64 encodes a sha256 checksum according to RFC 4648 and
@@ -28,6 +28,19 @@ type MachineContainers struct {
 	ContainerTypes []instance.ContainerType `json:"container-types"`
 }
 
+// MachineInterruption holds the arguments for reporting that the cloud
+// will reclaim a machine's instance at the given deadline.
+type MachineInterruption struct {
+	Tag      string    `json:"tag"`
+	Deadline time.Time `json:"deadline"`
+}
+
+// MachineInterruptions holds the arguments for making a
+// ReportInterruptions API call.
+type MachineInterruptions struct {
+	Interruptions []MachineInterruption `json:"interruptions"`
+}
+
 // WatchContainer identifies a single container type within a machine.
 type WatchContainer struct {
 	MachineTag    string `json:"machine-tag"`
@@ -292,6 +305,34 @@ type RelationUnitPairs struct {
 	RelationUnitPairs []RelationUnitPair `json:"relation-unit-pairs"`
 }
 
+// RelationSettingsBatch holds a relation tag, a local unit tag and
+// the tags of the remote units whose settings the local unit reads.
+type RelationSettingsBatch struct {
+	Relation    string   `json:"relation"`
+	LocalUnit   string   `json:"local-unit"`
+	RemoteUnits []string `json:"remote-units"`
+}
+
+// RelationSettingsBatches holds the parameters for reading the
+// settings of many remote units in one API call.
+type RelationSettingsBatches struct {
+	Batches []RelationSettingsBatch `json:"batches"`
+}
+
+// RelationSettingsBatchResult holds the settings of each remote unit
+// in a batch, in the order they were requested, or an error that
+// applies to the whole batch.
+type RelationSettingsBatchResult struct {
+	Results []SettingsResult `json:"results,omitempty"`
+	Error   *Error           `json:"error,omitempty"`
+}
+
+// RelationSettingsBatchResults holds the results of reading batches
+// of remote unit settings.
+type RelationSettingsBatchResults struct {
+	Results []RelationSettingsBatchResult `json:"results"`
+}
+
 // RelationUnitSettings holds a relation tag, a unit tag and local
 // unit settings.
 type RelationUnitSettings struct {
@@ -387,6 +428,7 @@ type LifeResults struct {
 type InstanceInfo struct {
 	Tag             string                            `json:"tag"`
 	InstanceId      instance.Id                       `json:"instance-id"`
+	DisplayName     string                            `json:"display-name,omitempty"`
 	Nonce           string                            `json:"nonce"`
 	Characteristics *instance.HardwareCharacteristics `json:"characteristics"`
 	Volumes         []Volume                          `json:"volumes"`
@@ -417,6 +459,10 @@ type EntityStatusArgs struct {
 	Status string                 `json:"status"`
 	Info   string                 `json:"info"`
 	Data   map[string]interface{} `json:"data"`
+
+	// Since, if set, is when the status was observed by the caller.
+	// Otherwise the status is recorded as set when it is received.
+	Since *time.Time `json:"since,omitempty"`
 }
 
 // SetStatus holds the parameters for making a SetStatus/UpdateStatus call.
@@ -455,6 +501,11 @@ type AgentGetEntitiesResult struct {
 type VersionResult struct {
 	Version *version.Number `json:"version,omitempty"`
 	Error   *Error          `json:"error,omitempty"`
+
+	// Deferred is true if the agent is to upgrade to a different
+	// version, but not until the model's next maintenance window;
+	// until then, Version is the agent's current version.
+	Deferred bool `json:"deferred,omitempty"`
 }
 
 // VersionResults is a list of versions for the requested entities.
@@ -487,6 +538,35 @@ type ToolsResult struct {
 	Error                          *Error     `json:"error,omitempty"`
 }
 
+// ToolsBundleManifestName is the name of the entry in an agent
+// binaries bundle that holds its ToolsBundleManifest.
+const ToolsBundleManifestName = "manifest.json"
+
+// ToolsBundleManifest describes the agent binaries held in a bundle
+// uploaded to the tools endpoint.
+type ToolsBundleManifest struct {
+	Binaries []ToolsBundleBinary `json:"binaries"`
+}
+
+// ToolsBundleBinary describes one agent binary tarball held in an
+// agent binaries bundle.
+type ToolsBundleBinary struct {
+	// Version is the binary version of the agent binaries.
+	Version string `json:"version"`
+
+	// AdditionalSeries holds any other series for which the agent
+	// binaries are also to be stored.
+	AdditionalSeries []string `json:"additional-series,omitempty"`
+
+	// Path is the name of the bundle entry holding the tarball.
+	Path string `json:"path"`
+
+	// Size and SHA256 are the size and hex-encoded SHA256 checksum
+	// of the tarball, against which the uploaded tarball is checked.
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
 // ToolsResults is a list of tools for various requested agents.
 type ToolsResults struct {
 	Results []ToolsResult `json:"results"`
@@ -552,6 +632,37 @@ type EntitiesWatchResults struct {
 	Results []EntitiesWatchResult `json:"results"`
 }
 
+// EntityChange describes a change to an entity reported by an
+// EntityChangesWatcher.
+type EntityChange struct {
+	// Tag is the tag of the changed entity.
+	Tag string `json:"tag"`
+
+	// Kind is the kind of the changed entity, as reported by its tag.
+	Kind string `json:"kind"`
+
+	// Life is the life of the entity when the change was reported.
+	// Entities that have been removed are reported as Dead.
+	Life Life `json:"life"`
+
+	// Removed is true if the entity no longer exists.
+	Removed bool `json:"removed,omitempty"`
+}
+
+// EntityChangesWatchResult holds an EntityChangesWatcher id, changes
+// and an error (if any).
+type EntityChangesWatchResult struct {
+	EntityChangesWatcherId string         `json:"watcher-id"`
+	Changes                []EntityChange `json:"changes,omitempty"`
+	Error                  *Error         `json:"error,omitempty"`
+}
+
+// EntityChangesWatchResults holds the results for any API call which
+// ends up returning a list of EntityChangesWatchers.
+type EntityChangesWatchResults struct {
+	Results []EntityChangesWatchResult `json:"results"`
+}
+
 // UnitSettings specifies the version of some unit's settings in some relation.
 type UnitSettings struct {
 	Version int64 `json:"version"`
@@ -685,6 +796,43 @@ type ProvisioningInfo struct {
 	ImageMetadata     []CloudImageMetadata      `json:"image-metadata,omitempty"`
 	EndpointBindings  map[string]string         `json:"endpoint-bindings,omitempty"`
 	ControllerConfig  map[string]interface{}    `json:"controller-config,omitempty"`
+	CloudInitUserData string                    `json:"cloudinit-userdata,omitempty"`
+
+	// CharmLXDProfiles holds the LXD profiles, keyed on name, declared
+	// by the charms of the units assigned to an LXD container.
+	CharmLXDProfiles map[string]LXDProfile `json:"charm-lxd-profiles,omitempty"`
+}
+
+// LXDProfile holds an LXD profile declared by a charm.
+type LXDProfile struct {
+	Config      map[string]string            `json:"config,omitempty"`
+	Description string                       `json:"description,omitempty"`
+	Devices     map[string]map[string]string `json:"devices,omitempty"`
+}
+
+// CharmProfilesResult holds the charm LXD profiles a machine's
+// container should have, and the names of those applied to it.
+type CharmProfilesResult struct {
+	Profiles map[string]LXDProfile `json:"profiles,omitempty"`
+	Applied  []string              `json:"applied,omitempty"`
+	Error    *Error                `json:"error,omitempty"`
+}
+
+// CharmProfilesResults holds multiple CharmProfilesResult.
+type CharmProfilesResults struct {
+	Results []CharmProfilesResult `json:"results"`
+}
+
+// SetCharmProfilesArg records the names of the charm LXD profiles
+// applied to a machine's container.
+type SetCharmProfilesArg struct {
+	Entity   Entity   `json:"entity"`
+	Profiles []string `json:"profiles"`
+}
+
+// SetCharmProfilesArgs holds the arguments to SetCharmProfiles.
+type SetCharmProfilesArgs struct {
+	Args []SetCharmProfilesArg `json:"args"`
 }
 
 // ProvisioningInfoResult holds machine provisioning info or an error.
@@ -812,6 +960,10 @@ type UnitRefreshResult struct {
 	Resolved ResolvedMode
 	Series   string
 	Error    *Error
+
+	// DrainDeadline, if set, is the time until which the charm may
+	// drain the dying unit before it is torn down.
+	DrainDeadline *time.Time `json:",omitempty"`
 }
 
 // UnitRefreshResults holds the results for any API call which ends
@@ -820,6 +972,55 @@ type UnitRefreshResults struct {
 	Results []UnitRefreshResult
 }
 
+// UniterState holds a snapshot of the state a unit's uniter keeps on
+// local disk. The contents are interpreted only by the uniter.
+type UniterState struct {
+	OperationState string            `json:"operation-state"`
+	RelationState  map[string]string `json:"relation-state,omitempty"`
+	StorageState   map[string]string `json:"storage-state,omitempty"`
+}
+
+// UniterStateResult holds a unit's uniter state, or an error.
+type UniterStateResult struct {
+	Result *UniterState `json:"result,omitempty"`
+	Error  *Error       `json:"error,omitempty"`
+}
+
+// UniterStateResults holds the results of a UniterState call.
+type UniterStateResults struct {
+	Results []UniterStateResult `json:"results"`
+}
+
+// SetUniterStateArg holds the uniter state to record for a unit.
+type SetUniterStateArg struct {
+	Tag   string      `json:"tag"`
+	State UniterState `json:"state"`
+}
+
+// SetUniterStateArgs holds the arguments to a SetUniterState call.
+type SetUniterStateArgs struct {
+	Args []SetUniterStateArg `json:"args"`
+}
+
+// DebugHooksSessionResult holds the debug-hooks session waiting for
+// a unit, if any.
+type DebugHooksSessionResult struct {
+	// Active reports whether a session is waiting for the unit.
+	Active bool `json:"active"`
+
+	// Hooks holds the names of the hooks and actions the session
+	// intercepts. If empty, all are intercepted.
+	Hooks []string `json:"hooks,omitempty"`
+
+	Error *Error `json:"error,omitempty"`
+}
+
+// DebugHooksSessionResults holds the results of a DebugHooksSession
+// call.
+type DebugHooksSessionResults struct {
+	Results []DebugHooksSessionResult `json:"results"`
+}
+
 // EntityString holds an entity tag and a string value.
 type EntityString struct {
 	Tag   string `json:"tag"`
@@ -831,3 +1032,26 @@ type EntityString struct {
 type SetContainerSpecParams struct {
 	Entities []EntityString `json:"entities"`
 }
+
+// EgressRule allows outbound traffic to a destination network and,
+// if Ports is set, port range.
+type EgressRule struct {
+	CIDR  string `json:"cidr"`
+	Ports string `json:"ports,omitempty"`
+}
+
+// EgressPolicyResult holds the outbound traffic policy for a machine.
+// If Restricted is false, outbound traffic is unrestricted; otherwise
+// only traffic to the controllers' API addresses and to the
+// destinations in Rules is allowed.
+type EgressPolicyResult struct {
+	Restricted   bool         `json:"restricted"`
+	Rules        []EgressRule `json:"rules,omitempty"`
+	APIHostPorts []HostPort   `json:"api-host-ports,omitempty"`
+	Error        *Error       `json:"error,omitempty"`
+}
+
+// EgressPolicyResults holds the results of an EgressPolicy call.
+type EgressPolicyResults struct {
+	Results []EgressPolicyResult `json:"results"`
+}
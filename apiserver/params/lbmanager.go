@@ -0,0 +1,35 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// LoadBalancerApplication holds the details of an application that the
+// load balancer manager needs to provision a load balancer for it.
+type LoadBalancerApplication struct {
+	Tag      string      `json:"tag"`
+	Life     Life        `json:"life"`
+	Exposed  bool        `json:"exposed"`
+	Ports    []PortRange `json:"ports,omitempty"`
+	Backends []string    `json:"backends,omitempty"`
+	Address  string      `json:"address,omitempty"`
+}
+
+// LoadBalancerApplicationsResult holds the result of an
+// LBManager.Applications call.
+type LoadBalancerApplicationsResult struct {
+	Applications []LoadBalancerApplication `json:"applications"`
+	Error        *Error                    `json:"error,omitempty"`
+}
+
+// ApplicationLoadBalancerAddress holds the address of the load
+// balancer provisioned for an application.
+type ApplicationLoadBalancerAddress struct {
+	Tag     string `json:"tag"`
+	Address string `json:"address"`
+}
+
+// SetLoadBalancerAddresses holds the arguments for an
+// LBManager.SetAddresses call.
+type SetLoadBalancerAddresses struct {
+	Applications []ApplicationLoadBalancerAddress `json:"applications"`
+}
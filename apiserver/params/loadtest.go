@@ -0,0 +1,59 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// PopulateModelArgs holds the arguments to LoadTest.Populate.
+type PopulateModelArgs struct {
+	// Applications is the number of synthetic applications to add.
+	Applications int `json:"applications"`
+
+	// UnitsPerApplication is the number of units to add to each
+	// application, each on a new synthetic machine.
+	UnitsPerApplication int `json:"units-per-application"`
+
+	// Series is the series of the applications and machines. If it
+	// is empty, the model's default series is used.
+	Series string `json:"series,omitempty"`
+
+	// Relate, if true, relates each application added to the one
+	// added before it.
+	Relate bool `json:"relate,omitempty"`
+}
+
+// PopulateModelResult holds the result of LoadTest.Populate.
+type PopulateModelResult struct {
+	// Applications holds the names of the applications added,
+	// including those added before any error.
+	Applications []string `json:"applications,omitempty"`
+	Error        *Error   `json:"error,omitempty"`
+}
+
+// ChurnModelArgs holds the arguments to LoadTest.Churn.
+type ChurnModelArgs struct {
+	// Rounds is the number of rounds of changes to make; in each,
+	// every synthetic unit, or its relation settings or application
+	// config, is changed once.
+	Rounds int `json:"rounds"`
+
+	// Seed seeds the random choice of changes, so that the same
+	// seed makes the same changes to the same population.
+	Seed int64 `json:"seed"`
+}
+
+// ChurnModelResult holds the result of LoadTest.Churn.
+type ChurnModelResult struct {
+	// Changes is the number of changes made, including those made
+	// before any error.
+	Changes int    `json:"changes"`
+	Error   *Error `json:"error,omitempty"`
+}
+
+// ClearModelResult holds the result of LoadTest.Clear.
+type ClearModelResult struct {
+	Applications int    `json:"applications"`
+	Units        int    `json:"units"`
+	Machines     int    `json:"machines"`
+	Relations    int    `json:"relations"`
+	Error        *Error `json:"error,omitempty"`
+}
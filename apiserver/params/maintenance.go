@@ -0,0 +1,99 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// MaintenanceMode describes a machine, application or unit in
+// maintenance mode.
+type MaintenanceMode struct {
+	// Tag identifies the machine, application or unit.
+	Tag string `json:"tag"`
+
+	// Reason describes the work being carried out.
+	Reason string `json:"reason"`
+
+	// Expires, if set, is the time after which the maintenance mode
+	// is no longer in effect.
+	Expires *time.Time `json:"expires,omitempty"`
+}
+
+// MaintenanceModes holds the arguments for putting entities into
+// maintenance mode, and the maintenance modes in effect in a model.
+type MaintenanceModes struct {
+	Modes []MaintenanceMode `json:"modes"`
+}
+
+// MaintenanceStatus describes the maintenance mode of an entity
+// reported in FullStatus.
+type MaintenanceStatus struct {
+	Reason  string     `json:"reason"`
+	Expires *time.Time `json:"expires,omitempty"`
+}
+
+// AgentOffline describes an acknowledgement that the agent of a
+// machine or unit is intentionally offline.
+type AgentOffline struct {
+	// Tag identifies the machine or unit.
+	Tag string `json:"tag"`
+
+	// Reason describes why the agent is offline.
+	Reason string `json:"reason"`
+
+	// Expires is the time after which the acknowledgement is no
+	// longer in effect.
+	Expires time.Time `json:"expires"`
+
+	// AcknowledgedBy, in results, identifies the user who made the
+	// acknowledgement.
+	AcknowledgedBy string `json:"acknowledged-by,omitempty"`
+
+	// Acknowledged, in results, is when the acknowledgement was made.
+	Acknowledged *time.Time `json:"acknowledged,omitempty"`
+}
+
+// AgentsOffline holds the arguments for acknowledging agents offline,
+// and the acknowledgements in effect in a model.
+type AgentsOffline struct {
+	Agents []AgentOffline `json:"agents"`
+}
+
+// AgentOfflineStatus describes the acknowledgement that the agent of
+// an entity reported in FullStatus is offline.
+type AgentOfflineStatus struct {
+	Reason         string    `json:"reason"`
+	AcknowledgedBy string    `json:"acknowledged-by"`
+	Acknowledged   time.Time `json:"acknowledged"`
+	Expires        time.Time `json:"expires"`
+}
+
+// MaintenanceWindow describes a recurring window within which
+// disruptive automated operations may run in a model.
+type MaintenanceWindow struct {
+	// Name identifies the window within its model.
+	Name string `json:"name"`
+
+	// Schedule is the cron-like schedule of the minutes, in UTC, at
+	// which the window opens.
+	Schedule string `json:"schedule"`
+
+	// Duration is how long the window stays open each time it opens.
+	Duration time.Duration `json:"duration"`
+}
+
+// MaintenanceWindows holds the arguments for defining maintenance
+// windows, and the maintenance windows defined in a model.
+type MaintenanceWindows struct {
+	Windows []MaintenanceWindow `json:"windows"`
+
+	// Open, in results, reports whether disruptive automated
+	// operations may run in the model now.
+	Open bool `json:"open,omitempty"`
+}
+
+// MaintenanceWindowNames holds the names of maintenance windows to
+// remove.
+type MaintenanceWindowNames struct {
+	Names []string `json:"names"`
+}
@@ -25,6 +25,11 @@ type InitiateMigrationArgs struct {
 type MigrationSpec struct {
 	ModelTag   string              `json:"model-tag"`
 	TargetInfo MigrationTargetInfo `json:"target-info"`
+
+	// RollbackWindow is how long the migrated model is validated
+	// on the target controller, while the source model is kept
+	// restorable, before the migration is completed.
+	RollbackWindow time.Duration `json:"rollback-window,omitempty"`
 }
 
 // MigrationTargetInfo holds the details required to connect to and
@@ -122,6 +127,25 @@ type MasterMigrationStatus struct {
 	MigrationId      string        `json:"migration-id"`
 	Phase            string        `json:"phase"`
 	PhaseChangedTime time.Time     `json:"phase-changed-time"`
+
+	// Rollback is true if the migration hands a migrated model's
+	// agents back to the controller the model came from.
+	Rollback bool `json:"rollback,omitempty"`
+}
+
+// MigrationSourceInfo holds the details required to connect to the
+// controller a model is being migrated from.
+type MigrationSourceInfo struct {
+	ControllerTag string   `json:"controller-tag"`
+	Addrs         []string `json:"addrs"`
+	CACert        string   `json:"ca-cert"`
+}
+
+// RollbackModelArgs holds the details required to hand a migrated
+// model back to the controller it was migrated from.
+type RollbackModelArgs struct {
+	ModelTag   string              `json:"model-tag"`
+	SourceInfo MigrationSourceInfo `json:"source-info"`
 }
 
 // MigrationModelInfo is used to report basic model information to the
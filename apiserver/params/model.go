@@ -64,6 +64,33 @@ type RegionDefaults struct {
 // call.
 type ModelSet struct {
 	Config map[string]interface{} `json:"config"`
+
+	// DryRun, if true, causes the changes to be validated and
+	// reported without being applied.
+	DryRun bool `json:"dry-run,omitempty"`
+
+	// Force, if true, allows attributes that are defined neither by
+	// Juju nor by the model's cloud provider to be set.
+	Force bool `json:"force,omitempty"`
+}
+
+// ModelConfigChange describes a change to a single model config
+// attribute.
+type ModelConfigChange struct {
+	Key      string      `json:"key"`
+	OldValue interface{} `json:"old-value,omitempty"`
+	NewValue interface{} `json:"new-value,omitempty"`
+
+	// Workers holds the names of the running workers that
+	// react to changes to the attribute.
+	Workers []string `json:"workers,omitempty"`
+}
+
+// ModelSetResult holds the result of a ModelConfig.ModelSet call.
+type ModelSetResult struct {
+	// Changes holds the changes made, or that would be made
+	// if the call was a dry run, to the model's config.
+	Changes []ModelConfigChange `json:"changes"`
 }
 
 // ModelUnset contains the arguments for ModelUnset client API
@@ -379,3 +406,112 @@ type DestroyModelParams struct {
 	// params.CodeHasPersistentStorage will be returned.
 	DestroyStorage *bool `json:"destroy-storage,omitempty"`
 }
+
+// DestroyModelResults holds the results of destroying models.
+type DestroyModelResults struct {
+	Results []DestroyModelResult `json:"results"`
+}
+
+// DestroyModelResult holds the result of destroying a model.
+type DestroyModelResult struct {
+	// OperationId identifies the operation destroying the model.
+	// It is the same for every request to destroy the model.
+	OperationId string `json:"operation-id,omitempty"`
+	Error       *Error `json:"error,omitempty"`
+}
+
+// ModelUserActivityResults holds the API activity of model users.
+type ModelUserActivityResults struct {
+	Results []ModelUserActivityResult `json:"results"`
+}
+
+// ModelUserActivityResult holds the API activity of the users of a
+// model, or of a user on models, or an error.
+type ModelUserActivityResult struct {
+	Activity []ModelUserActivity `json:"activity,omitempty"`
+	Error    *Error              `json:"error,omitempty"`
+}
+
+// ModelUserActivity describes a user's use of a model's API.
+type ModelUserActivity struct {
+	UserTag  string `json:"user-tag"`
+	ModelTag string `json:"model-tag"`
+
+	// APICalls is the number of API calls the user has made to the
+	// model.
+	APICalls int64 `json:"api-calls"`
+
+	// LastAccess is when the user last made an API call to the
+	// model, or nil if the user has made none.
+	LastAccess *time.Time `json:"last-access,omitempty"`
+
+	// LastConnection is when the user last logged in to the model,
+	// or nil if the user has never logged in.
+	LastConnection *time.Time `json:"last-connection,omitempty"`
+}
+
+// ModelDestroyProgressResults holds the destroy progress of models.
+type ModelDestroyProgressResults struct {
+	Results []ModelDestroyProgressResult `json:"results"`
+}
+
+// ModelDestroyProgressResult holds the destroy progress of a model,
+// or an error.
+type ModelDestroyProgressResult struct {
+	Result *ModelDestroyProgress `json:"result,omitempty"`
+	Error  *Error                `json:"error,omitempty"`
+}
+
+// ModelDestroyProgress describes how far the destruction of a model
+// has progressed.
+type ModelDestroyProgress struct {
+	ModelTag    string     `json:"model-tag"`
+	OperationId string     `json:"operation-id,omitempty"`
+	Life        Life       `json:"life"`
+	Started     *time.Time `json:"started,omitempty"`
+
+	MachineCount     int `json:"machine-count"`
+	ApplicationCount int `json:"application-count"`
+	VolumeCount      int `json:"volume-count"`
+	FilesystemCount  int `json:"filesystem-count"`
+
+	// Blocking holds the remaining entities that are in an error
+	// state, and so may be preventing the model from being removed.
+	Blocking []DestroyBlockingEntity `json:"blocking,omitempty"`
+}
+
+// DestroyBlockingEntity describes an entity that may be preventing
+// the removal of a dying model.
+type DestroyBlockingEntity struct {
+	Tag     string `json:"tag"`
+	Life    Life   `json:"life"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// AgentLoggingOverride holds logging configuration that is applied to
+// a single agent on top of the model's logging-config until it
+// expires.
+type AgentLoggingOverride struct {
+	Tag     string    `json:"tag"`
+	Config  string    `json:"config"`
+	Expires time.Time `json:"expires"`
+}
+
+// AgentLoggingOverrides holds a set of agent logging overrides.
+type AgentLoggingOverrides struct {
+	Overrides []AgentLoggingOverride `json:"overrides"`
+}
+
+// AgentLoggingOverrideResult holds the logging override for an agent,
+// if it has one, or an error.
+type AgentLoggingOverrideResult struct {
+	Result *AgentLoggingOverride `json:"result,omitempty"`
+	Error  *Error                `json:"error,omitempty"`
+}
+
+// AgentLoggingOverrideResults holds the results of a LoggingOverrides
+// call.
+type AgentLoggingOverrideResults struct {
+	Results []AgentLoggingOverrideResult `json:"results"`
+}
@@ -0,0 +1,78 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// DesiredModel describes the desired state of a model, as accepted by
+// the ModelApply facade.
+type DesiredModel struct {
+	// Applications holds the desired applications, keyed on name.
+	Applications map[string]DesiredApplication `json:"applications"`
+
+	// Relations holds the desired relations, each expressed as a
+	// pair of fully qualified endpoints ("application:endpoint").
+	Relations [][]string `json:"relations,omitempty"`
+}
+
+// DesiredApplication describes the desired state of an application.
+type DesiredApplication struct {
+	// Charm is the URL of the application's charm, which must already
+	// have been added to the model.
+	Charm string `json:"charm"`
+
+	// NumUnits is the desired number of units.
+	NumUnits int `json:"num-units"`
+
+	// Config holds charm config values which must be set. Settings
+	// that aren't mentioned are left unchanged.
+	Config map[string]interface{} `json:"config,omitempty"`
+
+	// Exposed records whether the application should be exposed.
+	Exposed bool `json:"exposed"`
+}
+
+// ModelChange describes a single change needed to bring a model to
+// its desired state.
+type ModelChange struct {
+	// Id identifies the change. Ids are derived from the content of
+	// the change, so planning the same desired state against the same
+	// model yields the same ids.
+	Id string `json:"id"`
+
+	// Kind is the kind of change, for example "deploy" or
+	// "add-relation".
+	Kind string `json:"kind"`
+
+	// Application names the application affected by the change, if
+	// any.
+	Application string `json:"application,omitempty"`
+
+	// Args holds the kind-specific arguments of the change.
+	Args map[string]interface{} `json:"args,omitempty"`
+}
+
+// ModelPlanResult holds the changes needed to bring a model to its
+// desired state.
+type ModelPlanResult struct {
+	Changes []ModelChange `json:"changes"`
+	Error   *Error        `json:"error,omitempty"`
+}
+
+// ModelApplyArgs holds the arguments to ModelApply.Apply.
+type ModelApplyArgs struct {
+	// Desired is the desired model state, which is planned again
+	// against the current model before any change is applied.
+	Desired DesiredModel `json:"desired"`
+
+	// ChangeIds holds the ids, from a previous plan, of the changes
+	// to apply.
+	ChangeIds []string `json:"change-ids"`
+}
+
+// ModelApplyResult holds the result of ModelApply.Apply.
+type ModelApplyResult struct {
+	// Applied holds the ids of the changes that were applied, in the
+	// order they were applied.
+	Applied []string `json:"applied"`
+	Error   *Error   `json:"error,omitempty"`
+}
@@ -0,0 +1,39 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// ModelEventsArgs selects a page of a model's activity feed.
+type ModelEventsArgs struct {
+	// After, if non-zero, selects the events recorded after the one
+	// with this sequence number, oldest first.
+	After int64 `json:"after,omitempty"`
+
+	// Before, if non-zero and After is zero, selects the most recent
+	// events recorded before the one with this sequence number. If
+	// both are zero, the most recent events are selected.
+	Before int64 `json:"before,omitempty"`
+
+	// Limit is the maximum number of events to return. If zero, a
+	// default limit applies.
+	Limit int `json:"limit,omitempty"`
+}
+
+// ModelEvent is a high-level change recorded in a model's activity
+// feed.
+type ModelEvent struct {
+	Seq      int64     `json:"seq"`
+	Time     time.Time `json:"time"`
+	Kind     string    `json:"kind"`
+	Actor    string    `json:"actor"`
+	Entities []string  `json:"entities,omitempty"`
+	Message  string    `json:"message,omitempty"`
+}
+
+// ModelEventsResult holds a page of a model's activity feed, oldest
+// first.
+type ModelEventsResult struct {
+	Events []ModelEvent `json:"events"`
+}
@@ -0,0 +1,27 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// BranchArg identifies a model's branch.
+type BranchArg struct {
+	BranchName string `json:"branch"`
+}
+
+// BranchTrackArg holds the arguments for a ModelGeneration.TrackBranch
+// call. Entities holds the tags of the units to track the branch, or of
+// applications all of whose units are to track it.
+type BranchTrackArg struct {
+	BranchName string   `json:"branch"`
+	Entities   []Entity `json:"entities"`
+}
+
+// BranchConfigArg holds the arguments for a
+// ModelGeneration.SetBranchConfig call, which changes an application's
+// charm configuration on a branch.
+type BranchConfigArg struct {
+	BranchName      string            `json:"branch"`
+	ApplicationName string            `json:"application"`
+	Options         map[string]string `json:"options,omitempty"`
+	Unset           []string          `json:"unset,omitempty"`
+}
@@ -0,0 +1,39 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// ModelSnapshot identifies a snapshot of a model's description.
+type ModelSnapshot struct {
+	Id    int       `json:"id"`
+	Taken time.Time `json:"taken"`
+}
+
+// ModelSnapshotsResult holds the snapshots of a model, or an error.
+type ModelSnapshotsResult struct {
+	Snapshots []ModelSnapshot `json:"snapshots"`
+	Error     *Error          `json:"error,omitempty"`
+}
+
+// DiffModelSnapshotsArgs selects the versions of a model to diff. The
+// diff is taken from the snapshot with id From or, if From is zero,
+// from the most recent snapshot taken at or before Since. It is taken
+// to the snapshot with id To or, if To is zero, to the model as it is
+// now.
+type DiffModelSnapshotsArgs struct {
+	From  int        `json:"from,omitempty"`
+	Since *time.Time `json:"since,omitempty"`
+	To    int        `json:"to,omitempty"`
+}
+
+// DiffModelSnapshotsResult holds a unified diff between two versions
+// of a model, or an error. To is nil if the diff is to the model as it
+// is now.
+type DiffModelSnapshotsResult struct {
+	From  *ModelSnapshot `json:"from,omitempty"`
+	To    *ModelSnapshot `json:"to,omitempty"`
+	Diff  string         `json:"diff"`
+	Error *Error         `json:"error,omitempty"`
+}
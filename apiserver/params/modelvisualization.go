@@ -0,0 +1,34 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// ModelVisualizationArgs holds the arguments for rendering the
+// topology of a model.
+type ModelVisualizationArgs struct {
+	// Format is the output format, either "dot" (the default) or
+	// "mermaid".
+	Format string `json:"format,omitempty"`
+
+	// Applications, if set, restricts the rendering to the named
+	// applications and the relations between them.
+	Applications []string `json:"applications,omitempty"`
+
+	// IncludeMachines causes the machines hosting the applications'
+	// units to be rendered.
+	IncludeMachines bool `json:"include-machines,omitempty"`
+
+	// IncludeSpaces causes the spaces that the applications are
+	// bound to, and that any rendered machines are in, to be
+	// rendered.
+	IncludeSpaces bool `json:"include-spaces,omitempty"`
+}
+
+// ModelVisualizationResult holds the rendered topology of a model.
+type ModelVisualizationResult struct {
+	// Format is the format of Content.
+	Format string `json:"format"`
+
+	// Content is the rendered graph.
+	Content string `json:"content"`
+}
@@ -659,9 +659,11 @@ type ProxyConfig struct {
 
 // ProxyConfigResult contains information needed to configure a clients proxy settings
 type ProxyConfigResult struct {
-	ProxySettings    ProxyConfig `json:"proxy-settings"`
-	APTProxySettings ProxyConfig `json:"apt-proxy-settings"`
-	Error            *Error      `json:"error,omitempty"`
+	ProxySettings     ProxyConfig `json:"proxy-settings"`
+	APTProxySettings  ProxyConfig `json:"apt-proxy-settings"`
+	SnapProxySettings ProxyConfig `json:"snap-proxy-settings"`
+	SnapStoreProxyId  string      `json:"snap-store-proxy-id,omitempty"`
+	Error             *Error      `json:"error,omitempty"`
 }
 
 // ProxyConfigResults contains information needed to configure multiple clients proxy settings
@@ -669,6 +671,22 @@ type ProxyConfigResults struct {
 	Results []ProxyConfigResult `json:"results"`
 }
 
+// ProxyStatusArg holds the outcome of an agent's attempt to apply
+// proxy settings to its machine.
+type ProxyStatusArg struct {
+	Tag string `json:"tag"`
+
+	// Error holds the reason the settings could not be fully applied,
+	// or is empty if they were applied.
+	Error string `json:"error,omitempty"`
+}
+
+// ProxyStatusArgs holds the arguments for recording the outcome of
+// applying proxy settings.
+type ProxyStatusArgs struct {
+	Args []ProxyStatusArg `json:"args"`
+}
+
 // InterfaceAddress represents a single address attached to the interface.
 type InterfaceAddress struct {
 	Address string `json:"value"`
@@ -719,3 +737,55 @@ type FanConfigEntry struct {
 type FanConfigResult struct {
 	Fans []FanConfigEntry `json:"fans"`
 }
+
+// SetFanConfig holds the fans to configure for a model, replacing any
+// configured before.
+type SetFanConfig struct {
+	Fans []FanConfigEntry `json:"fans"`
+
+	// UseForContainers makes the model's containers get their
+	// addresses from the fan overlays.
+	UseForContainers bool `json:"use-for-containers,omitempty"`
+}
+
+// FanOverlaySubnet describes the segment of a fan overlay that is
+// given to one of the model's subnets on the fan's underlay.
+type FanOverlaySubnet struct {
+	CIDR           string `json:"cidr"`
+	OverlaySegment string `json:"overlay-segment"`
+}
+
+// FanOverlay describes one of a model's fans, and the segment of its
+// overlay given to each of the model's subnets on its underlay.
+type FanOverlay struct {
+	Underlay string             `json:"underlay"`
+	Overlay  string             `json:"overlay"`
+	Subnets  []FanOverlaySubnet `json:"subnets,omitempty"`
+}
+
+// FanOverlaysResult holds the fans configured for a model.
+type FanOverlaysResult struct {
+	Overlays []FanOverlay `json:"overlays"`
+
+	// ContainerNetworkingMethod is the model's method of giving
+	// addresses to containers; it is "fan" if they get them from
+	// the overlays.
+	ContainerNetworkingMethod string `json:"container-networking-method"`
+}
+
+// FanOverlayStatus describes the health of one of a model's fan
+// overlays on a machine.
+type FanOverlayStatus struct {
+	Underlay string `json:"underlay"`
+	Overlay  string `json:"overlay"`
+	Bridge   string `json:"bridge,omitempty"`
+	Address  string `json:"address,omitempty"`
+	Healthy  bool   `json:"healthy"`
+	Message  string `json:"message,omitempty"`
+}
+
+// SetFanStatus holds the health of the model's fan overlays on the
+// machine reporting it.
+type SetFanStatus struct {
+	Overlays []FanOverlayStatus `json:"overlays"`
+}
@@ -0,0 +1,37 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// TrackedResources holds the provider ids of the resources that are
+// tracked in a model's state, as used by the orphan sweeper.
+type TrackedResources struct {
+	InstanceIds []string `json:"instance-ids"`
+	VolumeIds   []string `json:"volume-ids"`
+}
+
+// TrackedResourcesResult holds a model's tracked resources, or an
+// error.
+type TrackedResourcesResult struct {
+	Result *TrackedResources `json:"result,omitempty"`
+	Error  *Error            `json:"error,omitempty"`
+}
+
+// OrphanedResources holds the results of a sweep of a model's
+// provider resources for those created by juju but no longer tracked.
+type OrphanedResources struct {
+	Swept          time.Time `json:"swept"`
+	Instances      []string  `json:"instances,omitempty"`
+	Volumes        []string  `json:"volumes,omitempty"`
+	SecurityGroups []string  `json:"security-groups,omitempty"`
+	Cleaned        []string  `json:"cleaned,omitempty"`
+}
+
+// OrphanedResourcesResult holds the results of the most recent sweep
+// of a model, or an error.
+type OrphanedResourcesResult struct {
+	Result *OrphanedResources `json:"result,omitempty"`
+	Error  *Error             `json:"error,omitempty"`
+}
@@ -119,9 +119,12 @@ type AddRelation struct {
 }
 
 // AddRelationResults holds the results of a AddRelation call. The Endpoints
-// field maps application names to the involved endpoints.
+// field maps application names to the involved endpoints. Warnings, only
+// returned by Application facade version 11 and greater, describe why the
+// endpoints may not work together.
 type AddRelationResults struct {
 	Endpoints map[string]CharmRelation `json:"endpoints"`
+	Warnings  []string                 `json:"warnings,omitempty"`
 }
 
 // DestroyRelation holds the parameters for making the DestroyRelation call.
@@ -210,6 +213,35 @@ type AddMachineParams struct {
 	Nonce                   string                           `json:"nonce"`
 	HardwareCharacteristics instance.HardwareCharacteristics `json:"hardware-characteristics"`
 	Addrs                   []Address                        `json:"addresses"`
+
+	// CloudInitUserData optionally holds cloud-init configuration, in
+	// YAML, to merge with the model's cloudinit-userdata and juju's own
+	// configuration when the machine is provisioned.
+	CloudInitUserData string `json:"cloudinit-userdata,omitempty"`
+
+	// Adopt, if non-nil, describes the agent previously deployed on
+	// a manually provisioned machine that is being adopted. InstanceId
+	// and Nonce must also be set. If the agent was for a machine in
+	// this model that still has the same instance id, that machine is
+	// taken over by the new agent rather than a new machine being
+	// added.
+	Adopt *AdoptMachineParams `json:"adopt,omitempty"`
+}
+
+// AdoptMachineParams describes the agents found on a machine that is
+// being adopted into a model.
+type AdoptMachineParams struct {
+	// AgentTag is the tag of the machine agent found on the machine,
+	// if any.
+	AgentTag string `json:"agent-tag,omitempty"`
+
+	// ModelUUID is the UUID of the model that the machine agent
+	// belonged to.
+	ModelUUID string `json:"model-uuid,omitempty"`
+
+	// Units holds the names of the units whose agents were found on
+	// the machine.
+	Units []string `json:"units,omitempty"`
 }
 
 // AddMachines holds the parameters for making the AddMachines call.
@@ -249,6 +281,20 @@ type ApplicationsDeploy struct {
 	Applications []ApplicationDeploy `json:"applications"`
 }
 
+// DeployResults holds the results of deploying applications, returned
+// by Application facade version 11 and greater.
+type DeployResults struct {
+	Results []DeployResult `json:"results"`
+}
+
+// DeployResult holds the result of deploying an application. Warnings
+// describe why the application may not work with those already
+// deployed.
+type DeployResult struct {
+	Warnings []string `json:"warnings,omitempty"`
+	Error    *Error   `json:"error,omitempty"`
+}
+
 // ApplicationDeploy holds the parameters for making the application Deploy call.
 type ApplicationDeploy struct {
 	ApplicationName  string                         `json:"application"`
@@ -385,6 +431,62 @@ type ApplicationUnexpose struct {
 	ApplicationName string `json:"application"`
 }
 
+// ApplicationTrust holds parameters for the application SetTrust call.
+type ApplicationTrust struct {
+	ApplicationName string `json:"application"`
+
+	// Level is the trust level granted to the application: one of
+	// "none", "read-only" or "full".
+	Level string `json:"level"`
+}
+
+// ApplicationTrustArgs holds the parameters for setting the trust
+// levels of several applications.
+type ApplicationTrustArgs struct {
+	Args []ApplicationTrust `json:"args"`
+}
+
+// ApplicationRefreshPolicy holds parameters for the application
+// SetRefreshPolicy call.
+type ApplicationRefreshPolicy struct {
+	ApplicationName string `json:"application"`
+
+	// Policy determines which newer revisions of the application's
+	// charm are applied automatically: one of "none", "patch-only"
+	// or "any".
+	Policy string `json:"policy"`
+}
+
+// ApplicationRefreshPolicyArgs holds the parameters for setting the
+// refresh policies of several applications.
+type ApplicationRefreshPolicyArgs struct {
+	Args []ApplicationRefreshPolicy `json:"args"`
+}
+
+// ApplicationRelationBrokenBarrier holds the parameters for making the
+// application SetRelationBrokenBarrier call.
+type ApplicationRelationBrokenBarrier struct {
+	ApplicationName string `json:"application"`
+	Enabled         bool   `json:"enabled"`
+}
+
+// ApplicationStorageUpdate holds the new storage constraints for the
+// named stores of an application.
+type ApplicationStorageUpdate struct {
+	// ApplicationTag is the tag of the application to update.
+	ApplicationTag string `json:"application-tag"`
+
+	// StorageConstraints is a map of storage names to the storage
+	// constraints to update. Unspecified fields are left unchanged.
+	StorageConstraints map[string]StorageConstraints `json:"storage-constraints"`
+}
+
+// ApplicationStorageUpdateRequest holds the parameters for making the
+// application UpdateStorageConstraints call.
+type ApplicationStorageUpdateRequest struct {
+	ApplicationStorageUpdates []ApplicationStorageUpdate `json:"application-storage-updates"`
+}
+
 // ApplicationMetricCredential holds parameters for the SetApplicationCredentials call.
 type ApplicationMetricCredential struct {
 	ApplicationName   string `json:"application"`
@@ -454,6 +556,73 @@ type AddApplicationUnits struct {
 	AttachStorage   []string              `json:"attach-storage,omitempty"`
 }
 
+// PlacementPlanArgs holds parameters for the PlacementPlan call.
+type PlacementPlanArgs struct {
+	Args []PlacementPlanArg `json:"args"`
+}
+
+// PlacementPlanArg describes a prospective deployment of units of an
+// application. If ApplicationName names an existing application, its
+// series and constraints are used unless Series and Constraints are
+// set.
+type PlacementPlanArg struct {
+	ApplicationName string                `json:"application,omitempty"`
+	Series          string                `json:"series,omitempty"`
+	NumUnits        int                   `json:"num-units"`
+	Constraints     *constraints.Value    `json:"constraints,omitempty"`
+	Placement       []*instance.Placement `json:"placement,omitempty"`
+}
+
+// PlacementPlanResults holds the results of a PlacementPlan call.
+type PlacementPlanResults struct {
+	Results []PlacementPlanResult `json:"results"`
+}
+
+// PlacementPlanResult holds the planned placement of each unit of a
+// prospective deployment, or an error if it could not be planned.
+type PlacementPlanResult struct {
+	Units []PlannedUnitPlacement `json:"units,omitempty"`
+	Error *Error                 `json:"error,omitempty"`
+}
+
+// PlannedUnitPlacement describes where a unit would be placed. If
+// NewMachine is true, a new machine would be provisioned for the unit;
+// otherwise MachineId names the existing machine that would host the
+// unit, or the new container holding it if ContainerType is set. Error
+// is set if the unit cannot be placed as directed.
+type PlannedUnitPlacement struct {
+	MachineId     string            `json:"machine-id,omitempty"`
+	NewMachine    bool              `json:"new-machine,omitempty"`
+	ContainerType string            `json:"container-type,omitempty"`
+	Directive     string            `json:"directive,omitempty"`
+	Zone          string            `json:"zone,omitempty"`
+	Constraints   constraints.Value `json:"constraints"`
+	Warnings      []string          `json:"warnings,omitempty"`
+	Error         *Error            `json:"error,omitempty"`
+}
+
+// PlacementMachinesResult holds the result of a PlacementMachines
+// call.
+type PlacementMachinesResult struct {
+	Machines []MachineLoad `json:"machines"`
+}
+
+// MachineLoad describes the units hosted by a machine, and the
+// resources they were most recently reported to use. Saturation
+// describes each of the machine's resources that its units use up;
+// units cannot be placed on a saturated machine.
+type MachineLoad struct {
+	MachineId    string                            `json:"machine-id"`
+	Series       string                            `json:"series"`
+	Zone         string                            `json:"zone,omitempty"`
+	Hardware     *instance.HardwareCharacteristics `json:"hardware,omitempty"`
+	Units        []string                          `json:"units,omitempty"`
+	Applications []string                          `json:"applications,omitempty"`
+	CPUPercent   float64                           `json:"cpu-percent"`
+	MemoryBytes  int64                             `json:"memory-bytes"`
+	Saturation   []string                          `json:"saturation,omitempty"`
+}
+
 // DestroyApplicationUnits holds parameters for the deprecated
 // Application.DestroyUnits call.
 type DestroyApplicationUnits struct {
@@ -473,6 +642,15 @@ type DestroyUnitParams struct {
 	// DestroyStorage controls whether or not storage
 	// attached to the unit should be destroyed.
 	DestroyStorage bool `json:"destroy-storage,omitempty"`
+
+	// DrainTimeout, if set, requests that the unit's charm drain
+	// the unit before it is torn down, and is the longest time to
+	// wait for it to do so.
+	DrainTimeout *time.Duration `json:"drain-timeout,omitempty"`
+
+	// Force controls whether steps that could hold up the removal
+	// of the unit are skipped.
+	Force bool `json:"force,omitempty"`
 }
 
 // ApplicationDestroy holds the parameters for making the deprecated
@@ -531,6 +709,10 @@ type LoginRequest struct {
 	Macaroons   []macaroon.Slice `json:"macaroons"`
 	CLIArgs     string           `json:"cli-args,omitempty"`
 	UserData    string           `json:"user-data"`
+
+	// ReadOnly requests a connection on which only methods that
+	// don't change anything may be called.
+	ReadOnly bool `json:"read-only,omitempty"`
 }
 
 // LoginRequestCompat holds credentials for identifying an entity to the Login v1
@@ -578,6 +760,39 @@ type ApplicationConstraint struct {
 	Error       *Error            `json:"error,omitempty"`
 }
 
+// ConstraintsDetailResults holds the results of a GetConstraintsDetail
+// call.
+type ConstraintsDetailResults struct {
+	Results []ConstraintsDetailResult `json:"results"`
+}
+
+// ConstraintsDetailResult holds the effective constraints of a single
+// application or unit, and where each constraint attribute's value
+// came from, or an error for trying to get them.
+type ConstraintsDetailResult struct {
+	Constraints constraints.Value           `json:"constraints"`
+	Attributes  []ConstraintAttributeDetail `json:"attributes,omitempty"`
+	Error       *Error                      `json:"error,omitempty"`
+}
+
+// ConstraintAttributeDetail describes where the effective value of a
+// constraint attribute came from. Value and Source are empty if the
+// attribute was set, but is not in effect.
+type ConstraintAttributeDetail struct {
+	Attribute  string               `json:"attribute"`
+	Value      string               `json:"value,omitempty"`
+	Source     string               `json:"source,omitempty"`
+	Overridden []ConstraintOverride `json:"overridden,omitempty"`
+}
+
+// ConstraintOverride describes a constraint attribute value that is
+// not in effect, and why.
+type ConstraintOverride struct {
+	Source string `json:"source"`
+	Value  string `json:"value"`
+	Reason string `json:"reason"`
+}
+
 // SetConstraints stores parameters for making the SetConstraints call.
 type SetConstraints struct {
 	ApplicationName string            `json:"application"` //optional, if empty, model constraints are set.
@@ -737,6 +952,39 @@ type FacadeVersions struct {
 	Versions []int  `json:"versions"`
 }
 
+// CapabilitiesResult holds the facade versions and feature flags
+// supported by a controller, as served by the unauthenticated
+// /capabilities endpoint.
+type CapabilitiesResult struct {
+	ServerVersion string           `json:"server-version"`
+	Facades       []FacadeVersions `json:"facades"`
+	FeatureFlags  []string         `json:"feature-flags"`
+}
+
+// HealthResult holds the health of a controller's API server, as
+// served by the unauthenticated /health and /readiness endpoints.
+// It deliberately reveals no more than a load balancer needs.
+type HealthResult struct {
+	// Status is "ok" if the server is healthy, or ready, and
+	// "unavailable" otherwise.
+	Status string `json:"status"`
+
+	// Mongo reports whether the server can reach the database.
+	Mongo bool `json:"mongo"`
+
+	// Upgrading reports whether the server is waiting for an
+	// upgrade to complete.
+	Upgrading bool `json:"upgrading,omitempty"`
+
+	// Restoring reports whether the controller is being restored
+	// from a backup.
+	Restoring bool `json:"restoring,omitempty"`
+
+	// Draining reports whether the server is draining its
+	// connections before shutting down.
+	Draining bool `json:"draining,omitempty"`
+}
+
 // RedirectInfoResult holds the result of a RedirectInfo call.
 type RedirectInfoResult struct {
 	// Servers holds an entry for each server that holds the
@@ -772,6 +1020,10 @@ type AuthUserInfo struct {
 
 	// ModelAccess holds the access the user has to the connected model.
 	ModelAccess string `json:"model-access"`
+
+	// ReadOnly reports whether the connection is limited to methods
+	// that don't change anything.
+	ReadOnly bool `json:"read-only,omitempty"`
 }
 
 // LoginResult holds the result of an Admin Login call.
@@ -827,6 +1079,57 @@ type ControllersSpec struct {
 	Series string `json:"series,omitempty"`
 	// Placement defines specific machines to become new controller machines.
 	Placement []string `json:"placement,omitempty"`
+	// Machines defines the placement, constraints or availability
+	// zone of individual new controller machines. It is used
+	// instead of Placement, and is only supported by version 3 and
+	// later of the HighAvailability facade.
+	Machines []ControllerMachineSpec `json:"machines,omitempty"`
+}
+
+// ControllerMachineSpec holds the placement, constraints and
+// availability zone for one new controller machine. At most one of
+// Placement and Zone may be set.
+type ControllerMachineSpec struct {
+	Placement   string            `json:"placement,omitempty"`
+	Constraints constraints.Value `json:"constraints,omitempty"`
+	Zone        string            `json:"zone,omitempty"`
+}
+
+// ControllerMachineProgress describes how far a controller machine has
+// got in becoming a fully functioning controller.
+type ControllerMachineProgress struct {
+	Tag string `json:"tag"`
+
+	// Phase summarises the machine's progress. It is one of
+	// "provisioning", "starting", "joining", "standby", "voting"
+	// and "demoting".
+	Phase string `json:"phase"`
+
+	InstanceId     string `json:"instance-id,omitempty"`
+	InstanceStatus string `json:"instance-status,omitempty"`
+	AgentStatus    string `json:"agent-status,omitempty"`
+	AgentAlive     bool   `json:"agent-alive"`
+
+	// MongoState is the state of the machine's member of the mongo
+	// replica set, such as "PRIMARY" or "STARTUP2". It is empty if
+	// the machine is not yet a member.
+	MongoState   string `json:"mongo-state,omitempty"`
+	MongoHealthy bool   `json:"mongo-healthy"`
+
+	WantsVote bool `json:"wants-vote"`
+	HasVote   bool `json:"has-vote"`
+}
+
+// ControllersProgress holds the progress of each controller machine,
+// as returned by the HighAvailability facade's ControllersProgress
+// method.
+type ControllersProgress struct {
+	Machines []ControllerMachineProgress `json:"machines"`
+
+	// MongoError holds any error reading the status of the mongo
+	// replica set, in which case the mongo fields of the machines
+	// are not set.
+	MongoError string `json:"mongo-error,omitempty"`
 }
 
 // ControllersServersSpecs contains all the arguments
@@ -943,6 +1246,21 @@ type PubSubMessage struct {
 	Data  map[string]interface{} `json:"data"`
 }
 
+// DebugHooksMessage is sent in either direction over a debug-hooks
+// stream brokered by the controller.
+type DebugHooksMessage struct {
+	// Hook is sent to the client when a unit starts running the
+	// named hook or action in the session.
+	Hook string `json:"hook,omitempty"`
+
+	// Data holds terminal input, when sent by the client, or
+	// output, when sent by the unit.
+	Data []byte `json:"data,omitempty"`
+
+	// ExitCode is sent by the unit when the hook shell exits.
+	ExitCode *int `json:"exit-code,omitempty"`
+}
+
 // BundleChangesParams holds parameters for making Bundle.GetChanges calls.
 type BundleChangesParams struct {
 	// BundleDataYAML is the YAML-encoded charm bundle data
@@ -1092,6 +1410,48 @@ type DestroyApplicationInfo struct {
 	DestroyedUnits []Entity `json:"destroyed-units,omitempty"`
 }
 
+// DestroyApplicationPlanResults contains the results of a DestroyPlan
+// API request.
+type DestroyApplicationPlanResults struct {
+	Results []DestroyApplicationPlanResult `json:"results,omitempty"`
+}
+
+// DestroyApplicationPlanResult contains one of the results of a
+// DestroyPlan API request.
+type DestroyApplicationPlanResult struct {
+	Error *Error                  `json:"error,omitempty"`
+	Plan  *DestroyApplicationPlan `json:"plan,omitempty"`
+}
+
+// DestroyApplicationPlan describes what destroying an application
+// would do, without destroying it.
+type DestroyApplicationPlan struct {
+	// DetachedStorage is the tags of storage instances that would be
+	// detached from the application's units, and remain in the model
+	// after the units are removed.
+	DetachedStorage []Entity `json:"detached-storage,omitempty"`
+
+	// DestroyedStorage is the tags of storage instances that would be
+	// destroyed.
+	DestroyedStorage []Entity `json:"destroyed-storage,omitempty"`
+
+	// DestroyedUnits is the tags of units that would be destroyed,
+	// including the units of other subordinate applications attached
+	// to the application's units.
+	DestroyedUnits []Entity `json:"destroyed-units,omitempty"`
+
+	// RemovedRelations is the tags of relations that would be removed.
+	RemovedRelations []Entity `json:"removed-relations,omitempty"`
+
+	// RemovedCrossModelRelations is the tags of those removed
+	// relations that are with applications in other models.
+	RemovedCrossModelRelations []Entity `json:"removed-cross-model-relations,omitempty"`
+
+	// DestroyedMachines is the tags of machines that would be left
+	// running no units, and so destroyed along with the units.
+	DestroyedMachines []Entity `json:"destroyed-machines,omitempty"`
+}
+
 // DestroyUnitResults contains the results of a DestroyUnit API request.
 type DestroyUnitResults struct {
 	Results []DestroyUnitResult `json:"results,omitempty"`
@@ -1115,6 +1475,10 @@ type DestroyUnitInfo struct {
 	// DestroyedStorage is the tags of storage instances that will be
 	// destroyed as a result of destroying the unit.
 	DestroyedStorage []Entity `json:"destroyed-storage,omitempty"`
+
+	// SkippedSteps describes the steps of the unit's removal that
+	// were skipped because removal was forced.
+	SkippedSteps []string `json:"skipped-steps,omitempty"`
 }
 
 // DumpModelRequest wraps the request for a dump-model call.
@@ -0,0 +1,33 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// PubSubTopology describes how pubsub messages are forwarded between
+// the controller's API servers.
+type PubSubTopology struct {
+	// Servers holds the forwarding status reported by each API server.
+	Servers []PubSubServer `json:"servers"`
+}
+
+// PubSubServer holds the forwarding status of a single API server.
+type PubSubServer struct {
+	// Tag is the tag of the API server's machine.
+	Tag string `json:"tag"`
+
+	// Targets holds the status of forwarding to each other API server.
+	Targets []PubSubTarget `json:"targets"`
+
+	// Error is set if the API server did not report its status.
+	Error *Error `json:"error,omitempty"`
+}
+
+// PubSubTarget holds the status of forwarding messages from one API
+// server to another.
+type PubSubTarget struct {
+	Tag         string   `json:"tag"`
+	Connected   bool     `json:"connected"`
+	Addresses   []string `json:"addresses"`
+	QueueLength int      `json:"queue-length"`
+	SentCount   uint64   `json:"sent-count"`
+}
@@ -0,0 +1,30 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// ModelQuotas holds the limits on the resources a model may use. A
+// zero limit means the resource is not limited.
+type ModelQuotas struct {
+	Machines int `json:"machines"`
+	Cores    int `json:"cores"`
+	Units    int `json:"units"`
+	Storage  int `json:"storage"`
+}
+
+// ModelResources holds the amounts of the quota-limited resources that
+// a model is using.
+type ModelResources struct {
+	Machines int `json:"machines"`
+	Cores    int `json:"cores"`
+	Units    int `json:"units"`
+	Storage  int `json:"storage"`
+}
+
+// ModelQuotasResult holds a model's quotas and its current usage of
+// the limited resources, or an error.
+type ModelQuotasResult struct {
+	Quotas ModelQuotas    `json:"quotas"`
+	Usage  ModelResources `json:"usage"`
+	Error  *Error         `json:"error,omitempty"`
+}
@@ -16,6 +16,13 @@ import (
 // StatusParams holds parameters for the Status call.
 type StatusParams struct {
 	Patterns []string `json:"patterns"`
+
+	// AsOf, if set, requests the status of the model's machines,
+	// applications, units and relations as it was at the given time,
+	// reconstructed from their status history. Entities that had not
+	// been created by then, or have since been removed, are omitted.
+	// Remote applications and offers are reported as they are now.
+	AsOf *time.Time `json:"as-of,omitempty"`
 }
 
 // TODO(ericsnow) Add FullStatusResult.
@@ -98,6 +105,42 @@ type MachineStatus struct {
 	// hardware specification datum.
 	Hardware string `json:"hardware"`
 
+	// AvailabilityZone holds the provider availability zone in which
+	// the machine's instance was started.
+	AvailabilityZone string `json:"availability-zone,omitempty"`
+
+	// Placement holds the placement directive that was used when
+	// provisioning the machine.
+	Placement string `json:"placement,omitempty"`
+
+	// VirtType holds the virtualisation type requested for the
+	// machine's instance.
+	VirtType string `json:"virt-type,omitempty"`
+
+	// DisplayName holds the human readable name of the machine's
+	// instance as reported by the provider.
+	DisplayName string `json:"display-name,omitempty"`
+
+	// ProxyError holds the reason the machine agent could not fully
+	// apply the model's proxy settings, if it could not.
+	ProxyError string `json:"proxy-error,omitempty"`
+
+	// LXDProfiles holds the names of the charm LXD profiles applied
+	// to the machine's container.
+	LXDProfiles []string `json:"lxd-profiles,omitempty"`
+
+	// Maintenance describes the machine's maintenance mode, if it
+	// is in maintenance mode.
+	Maintenance *MaintenanceStatus `json:"maintenance,omitempty"`
+
+	// Offline describes the acknowledgement that the machine's agent
+	// is offline, if there is one.
+	Offline *AgentOfflineStatus `json:"offline,omitempty"`
+
+	// FanOverlays holds the health of the model's fan overlays on the
+	// machine, as most recently reported by its agent.
+	FanOverlays []FanOverlayStatus `json:"fan-overlays,omitempty"`
+
 	Jobs      []multiwatcher.MachineJob `json:"jobs"`
 	HasVote   bool                      `json:"has-vote"`
 	WantsVote bool                      `json:"wants-vote"`
@@ -117,6 +160,35 @@ type ApplicationStatus struct {
 	MeterStatuses   map[string]MeterStatus `json:"meter-statuses"`
 	Status          DetailedStatus         `json:"status"`
 	WorkloadVersion string                 `json:"workload-version"`
+
+	// UnhealthyUnits holds the number of the application's units
+	// whose health checks are failing.
+	UnhealthyUnits int `json:"unhealthy-units,omitempty"`
+
+	// LoadBalancerAddress holds the address of the provider load
+	// balancer in front of the application, if there is one.
+	LoadBalancerAddress string `json:"load-balancer-address,omitempty"`
+
+	// Maintenance describes the application's maintenance mode, if
+	// it is in maintenance mode.
+	Maintenance *MaintenanceStatus `json:"maintenance,omitempty"`
+
+	// Metrics describes the metrics declared by the application's
+	// charm, and when they were last collected and sent, if the
+	// charm declares any.
+	Metrics *ApplicationMetrics `json:"metrics,omitempty"`
+}
+
+// ApplicationMetrics describes the metrics declared in the metrics.yaml
+// of an application's charm, the interval at which they are collected
+// from the application's units, and when they were last collected from
+// any of the units and last sent to the metric collection service.
+// LastCollected and LastSent are nil if that has not happened recently.
+type ApplicationMetrics struct {
+	Declared           map[string]CharmMetric `json:"declared"`
+	CollectionInterval time.Duration          `json:"collection-interval"`
+	LastCollected      *time.Time             `json:"last-collected,omitempty"`
+	LastSent           *time.Time             `json:"last-sent,omitempty"`
 }
 
 // RemoteApplicationStatus holds status info about a remote application.
@@ -162,6 +234,22 @@ type UnitStatus struct {
 	Charm         string                `json:"charm"`
 	Subordinates  map[string]UnitStatus `json:"subordinates"`
 	Leader        bool                  `json:"leader,omitempty"`
+
+	// Branch holds the name of the model's branch if the unit tracks
+	// it, or is empty if the unit is on the stable generation.
+	Branch string `json:"branch,omitempty"`
+
+	// Utilization holds the resources most recently reported to be
+	// used by the unit, or nil if none have been reported.
+	Utilization *UnitUtilization `json:"utilization,omitempty"`
+
+	// Maintenance describes the unit's maintenance mode, if it is in
+	// maintenance mode.
+	Maintenance *MaintenanceStatus `json:"maintenance,omitempty"`
+
+	// Offline describes the acknowledgement that the unit's agent is
+	// offline, if there is one.
+	Offline *AgentOfflineStatus `json:"offline,omitempty"`
 }
 
 // RelationStatus holds status info about a relation.
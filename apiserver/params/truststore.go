@@ -0,0 +1,57 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// TrustedCACert describes a CA certificate pinned for a category of
+// external endpoint that the controller connects to.
+type TrustedCACert struct {
+	// Category is one of "charmstore", "cloud", "webhook" and
+	// "log-forwarding".
+	Category string `json:"category"`
+
+	// Name identifies the certificate within its category.
+	Name string `json:"name"`
+
+	// CACert holds the PEM-encoded certificate.
+	CACert string `json:"ca-cert"`
+
+	// Fingerprint is the hex-encoded SHA-256 fingerprint of the
+	// certificate. It is ignored when adding certificates.
+	Fingerprint string `json:"fingerprint,omitempty"`
+
+	// AddedBy is the tag of the user who pinned the certificate.
+	// It is ignored when adding certificates.
+	AddedBy string `json:"added-by,omitempty"`
+
+	// Added is when the certificate was pinned. It is ignored when
+	// adding certificates.
+	Added *time.Time `json:"added,omitempty"`
+}
+
+// TrustedCACerts holds a list of pinned CA certificates.
+type TrustedCACerts struct {
+	Certs []TrustedCACert `json:"certs"`
+}
+
+// TrustedCACertFilter holds the arguments to the
+// TrustStore.ListCACerts call.
+type TrustedCACertFilter struct {
+	// Category, if set, restricts the certificates returned to
+	// those pinned for the category.
+	Category string `json:"category,omitempty"`
+}
+
+// TrustedCACertId identifies a pinned CA certificate.
+type TrustedCACertId struct {
+	Category string `json:"category"`
+	Name     string `json:"name"`
+}
+
+// TrustedCACertIds holds the arguments to the
+// TrustStore.RemoveCACerts call.
+type TrustedCACertIds struct {
+	Ids []TrustedCACertId `json:"ids"`
+}
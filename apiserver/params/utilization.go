@@ -0,0 +1,42 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// UnitUtilization describes the resources used by a unit's processes.
+// CPUPercent is the share of a single CPU used over the sampling
+// interval, so may exceed 100 on machines with more than one CPU.
+type UnitUtilization struct {
+	CPUPercent  float64   `json:"cpu-percent"`
+	MemoryBytes int64     `json:"memory-bytes"`
+	DiskBytes   int64     `json:"disk-bytes"`
+	Updated     time.Time `json:"updated"`
+}
+
+// SetUnitUtilization holds the resources used by the unit with the
+// given tag.
+type SetUnitUtilization struct {
+	Tag         string          `json:"tag"`
+	Utilization UnitUtilization `json:"utilization"`
+}
+
+// SetUnitsUtilization holds the arguments for recording the resources
+// used by units.
+type SetUnitsUtilization struct {
+	Args []SetUnitUtilization `json:"args"`
+}
+
+// UnitUtilizationResult holds the resources used by a unit, or an
+// error.
+type UnitUtilizationResult struct {
+	Result *UnitUtilization `json:"result,omitempty"`
+	Error  *Error           `json:"error,omitempty"`
+}
+
+// UnitUtilizationResults holds the results of a bulk utilization
+// request.
+type UnitUtilizationResults struct {
+	Results []UnitUtilizationResult `json:"results"`
+}
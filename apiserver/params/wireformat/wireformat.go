@@ -0,0 +1,359 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package wireformat records the JSON wire format of the structs
+// declared in a Go package, and compares recorded formats so that
+// changes which would break existing API clients are caught before
+// they are released.
+//
+// The format of a package is derived from its source, so that it can
+// be recorded and checked without running any of the package's code.
+// A recorded format looks like:
+//
+//	type Entity
+//		tag string
+//
+//	type Entities
+//		entities []Entity
+//		error *Error omitempty
+//
+// Embedded structs without a JSON name are recorded with a "..."
+// prefix, since their fields are flattened into the embedding struct.
+package wireformat
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// Field describes the wire format of a struct field.
+type Field struct {
+	// Name is the JSON name of the field. For embedded structs
+	// without a JSON name, it is the embedded type.
+	Name string
+
+	// Type is the Go type of the field, as written in the source.
+	Type string
+
+	// OmitEmpty records whether the field is omitted from the wire
+	// when it holds its zero value.
+	OmitEmpty bool
+
+	// Embedded records whether the field is an embedded struct whose
+	// fields are flattened into the embedding struct.
+	Embedded bool
+}
+
+// Struct describes the wire format of a struct type.
+type Struct struct {
+	Name   string
+	Fields []Field
+}
+
+// Schema holds the wire formats of the struct types declared in a
+// package, sorted by name.
+type Schema []Struct
+
+// Generate returns the wire format of the exported struct types
+// declared in the non-test Go files in the given directory.
+func Generate(dir string) (Schema, error) {
+	fset := token.NewFileSet()
+	notTest := func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}
+	pkgs, err := parser.ParseDir(fset, dir, notTest, 0)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(pkgs) != 1 {
+		return nil, errors.Errorf("expected one package in %q, found %d", dir, len(pkgs))
+	}
+	var schema Schema
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			schema = append(schema, fileStructs(file)...)
+		}
+	}
+	sort.Slice(schema, func(i, j int) bool {
+		return schema[i].Name < schema[j].Name
+	})
+	return schema, nil
+}
+
+func fileStructs(file *ast.File) []Struct {
+	var result []Struct
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok || !typeSpec.Name.IsExported() {
+				continue
+			}
+			result = append(result, Struct{
+				Name:   typeSpec.Name.Name,
+				Fields: structFields(structType),
+			})
+		}
+	}
+	return result
+}
+
+func structFields(structType *ast.StructType) []Field {
+	var result []Field
+	for _, field := range structType.Fields.List {
+		var tag reflect.StructTag
+		if field.Tag != nil {
+			tag = reflect.StructTag(strings.Trim(field.Tag.Value, "`"))
+		}
+		jsonName, omitEmpty, skip := parseJSONTag(tag.Get("json"))
+		if skip {
+			continue
+		}
+		typ := types.ExprString(field.Type)
+		if len(field.Names) == 0 {
+			f := Field{Name: jsonName, Type: typ, OmitEmpty: omitEmpty}
+			if f.Name == "" {
+				f.Name = strings.TrimPrefix(typ, "*")
+				f.Embedded = true
+			}
+			result = append(result, f)
+			continue
+		}
+		for _, name := range field.Names {
+			if !name.IsExported() {
+				continue
+			}
+			f := Field{Name: jsonName, Type: typ, OmitEmpty: omitEmpty}
+			if f.Name == "" {
+				f.Name = name.Name
+			}
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// parseJSONTag returns the name and omitempty option in the given
+// json struct tag, and whether the field is skipped altogether.
+func parseJSONTag(tag string) (name string, omitEmpty, skip bool) {
+	if tag == "-" {
+		return "", false, true
+	}
+	parts := strings.Split(tag, ",")
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return parts[0], omitEmpty, false
+}
+
+// String returns the schema in the recorded form read by Parse.
+func (s Schema) String() string {
+	var buf bytes.Buffer
+	for i, st := range s {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		fmt.Fprintf(&buf, "type %s\n", st.Name)
+		for _, f := range st.Fields {
+			buf.WriteString("\t" + f.String() + "\n")
+		}
+	}
+	return buf.String()
+}
+
+// String returns the field in its recorded form.
+func (f Field) String() string {
+	if f.Embedded {
+		return "..." + f.Type
+	}
+	s := f.Name + " " + f.Type
+	if f.OmitEmpty {
+		s += " omitempty"
+	}
+	return s
+}
+
+// Parse reads a schema recorded by Schema.String.
+func Parse(data []byte) (Schema, error) {
+	var schema Schema
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "type "):
+			schema = append(schema, Struct{Name: strings.TrimPrefix(line, "type ")})
+		case strings.HasPrefix(line, "\t") && len(schema) > 0:
+			f, err := parseField(strings.TrimPrefix(line, "\t"))
+			if err != nil {
+				return nil, errors.Annotatef(err, "line %d", lineNum)
+			}
+			st := &schema[len(schema)-1]
+			st.Fields = append(st.Fields, f)
+		default:
+			return nil, errors.Errorf("line %d: unexpected %q", lineNum, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return schema, nil
+}
+
+func parseField(s string) (Field, error) {
+	if strings.HasPrefix(s, "...") {
+		typ := strings.TrimPrefix(s, "...")
+		return Field{
+			Name:     strings.TrimPrefix(typ, "*"),
+			Type:     typ,
+			Embedded: true,
+		}, nil
+	}
+	parts := strings.SplitN(s, " ", 2)
+	if len(parts) != 2 {
+		return Field{}, errors.NotValidf("field %q", s)
+	}
+	f := Field{Name: parts[0], Type: parts[1]}
+	if strings.HasSuffix(f.Type, " omitempty") {
+		f.Type = strings.TrimSuffix(f.Type, " omitempty")
+		f.OmitEmpty = true
+	}
+	return f, nil
+}
+
+// Change describes a difference between two wire formats.
+type Change struct {
+	// Struct is the name of the changed struct.
+	Struct string
+
+	// Field is the name of the changed field, if any.
+	Field string
+
+	// Description describes the change.
+	Description string
+
+	// Breaking records whether existing clients or servers may no
+	// longer understand the new wire format.
+	Breaking bool
+}
+
+// String returns a description of the change.
+func (c Change) String() string {
+	name := c.Struct
+	if c.Field != "" {
+		name += "." + c.Field
+	}
+	s := name + ": " + c.Description
+	if c.Breaking {
+		s = "BREAKING " + s
+	}
+	return s
+}
+
+// Compare returns the changes from one wire format to another.
+// Removing or retyping a struct or field, or changing whether a field
+// is omitted when empty, breaks the wire format. So does adding a
+// field to an existing struct without omitempty, because peers that
+// predate the field cannot tell its zero value from its absence.
+func Compare(before, after Schema) []Change {
+	oldStructs := make(map[string]Struct)
+	for _, st := range before {
+		oldStructs[st.Name] = st
+	}
+	newStructs := make(map[string]Struct)
+	for _, st := range after {
+		newStructs[st.Name] = st
+	}
+
+	var changes []Change
+	for _, st := range before {
+		if _, ok := newStructs[st.Name]; !ok {
+			changes = append(changes, Change{
+				Struct:      st.Name,
+				Description: "removed",
+				Breaking:    true,
+			})
+		}
+	}
+	for _, st := range after {
+		oldSt, ok := oldStructs[st.Name]
+		if !ok {
+			changes = append(changes, Change{
+				Struct:      st.Name,
+				Description: "added",
+			})
+			continue
+		}
+		changes = append(changes, compareFields(st.Name, oldSt.Fields, st.Fields)...)
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Struct != changes[j].Struct {
+			return changes[i].Struct < changes[j].Struct
+		}
+		return changes[i].Field < changes[j].Field
+	})
+	return changes
+}
+
+func compareFields(structName string, before, after []Field) []Change {
+	oldFields := make(map[string]Field)
+	for _, f := range before {
+		oldFields[f.Name] = f
+	}
+	newFields := make(map[string]Field)
+	for _, f := range after {
+		newFields[f.Name] = f
+	}
+
+	var changes []Change
+	change := func(field, description string, breaking bool) {
+		changes = append(changes, Change{
+			Struct:      structName,
+			Field:       field,
+			Description: description,
+			Breaking:    breaking,
+		})
+	}
+	for _, f := range before {
+		if _, ok := newFields[f.Name]; !ok {
+			change(f.Name, "removed", true)
+		}
+	}
+	for _, f := range after {
+		oldF, ok := oldFields[f.Name]
+		switch {
+		case !ok && f.OmitEmpty:
+			change(f.Name, "added", false)
+		case !ok:
+			change(f.Name, "added without omitempty", true)
+		case oldF.Type != f.Type:
+			change(f.Name, fmt.Sprintf("type changed from %s to %s", oldF.Type, f.Type), true)
+		case oldF.Embedded != f.Embedded:
+			change(f.Name, "embedding changed", true)
+		case oldF.OmitEmpty && !f.OmitEmpty:
+			change(f.Name, "omitempty removed", true)
+		case !oldF.OmitEmpty && f.OmitEmpty:
+			change(f.Name, "omitempty added", true)
+		}
+	}
+	return changes
+}
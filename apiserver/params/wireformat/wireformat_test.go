@@ -0,0 +1,144 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package wireformat_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params/wireformat"
+)
+
+type wireFormatSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&wireFormatSuite{})
+
+const source = `
+package example
+
+type Entity struct {
+	Tag string ` + "`json:\"tag\"`" + `
+}
+
+type Result struct {
+	Entity
+	Error   *Error            ` + "`json:\"error,omitempty\"`" + `
+	Values  map[string]string ` + "`json:\",omitempty\"`" + `
+	Ignored string            ` + "`json:\"-\"`" + `
+	private string
+}
+
+type Error struct {
+	Message string ` + "`json:\"message\"`" + `
+}
+
+type notExported struct {
+	Value string
+}
+`
+
+const recorded = `type Entity
+	tag string
+
+type Error
+	message string
+
+type Result
+	...Entity
+	error *Error omitempty
+	Values map[string]string omitempty
+`
+
+func (s *wireFormatSuite) TestGenerate(c *gc.C) {
+	dir := c.MkDir()
+	err := ioutil.WriteFile(filepath.Join(dir, "example.go"), []byte(source), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(dir, "example_test.go"), []byte("package example\n\ntype Test struct{}\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	schema, err := wireformat.Generate(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schema.String(), gc.Equals, recorded)
+}
+
+func (s *wireFormatSuite) TestParse(c *gc.C) {
+	schema, err := wireformat.Parse([]byte(recorded))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schema, jc.DeepEquals, wireformat.Schema{{
+		Name:   "Entity",
+		Fields: []wireformat.Field{{Name: "tag", Type: "string"}},
+	}, {
+		Name:   "Error",
+		Fields: []wireformat.Field{{Name: "message", Type: "string"}},
+	}, {
+		Name: "Result",
+		Fields: []wireformat.Field{
+			{Name: "Entity", Type: "Entity", Embedded: true},
+			{Name: "error", Type: "*Error", OmitEmpty: true},
+			{Name: "Values", Type: "map[string]string", OmitEmpty: true},
+		},
+	}})
+}
+
+func (s *wireFormatSuite) TestParseInvalid(c *gc.C) {
+	_, err := wireformat.Parse([]byte("type Entity\n\ttag\n"))
+	c.Assert(err, gc.ErrorMatches, `line 2: field "tag" not valid`)
+	_, err = wireformat.Parse([]byte("Entity\n"))
+	c.Assert(err, gc.ErrorMatches, `line 1: unexpected "Entity"`)
+}
+
+func (s *wireFormatSuite) TestCompare(c *gc.C) {
+	before, err := wireformat.Parse([]byte(`type Gone
+	id string
+
+type Result
+	...Entity
+	error *Error omitempty
+	count int
+	name string
+	values []string omitempty
+	life string
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	after, err := wireformat.Parse([]byte(`type Added
+	id string
+
+type Result
+	...Entity
+	error *Error
+	count int64
+	name string omitempty
+	status string omitempty
+	flag bool
+`))
+	c.Assert(err, jc.ErrorIsNil)
+
+	var changes []string
+	for _, change := range wireformat.Compare(before, after) {
+		changes = append(changes, change.String())
+	}
+	c.Assert(changes, jc.DeepEquals, []string{
+		"Added: added",
+		"BREAKING Gone: removed",
+		"BREAKING Result.count: type changed from int to int64",
+		"BREAKING Result.error: omitempty removed",
+		"BREAKING Result.flag: added without omitempty",
+		"BREAKING Result.life: removed",
+		"BREAKING Result.name: omitempty added",
+		"Result.status: added",
+		"BREAKING Result.values: removed",
+	})
+}
+
+func (s *wireFormatSuite) TestCompareUnchanged(c *gc.C) {
+	schema, err := wireformat.Parse([]byte(recorded))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(wireformat.Compare(schema, schema), gc.HasLen, 0)
+}
@@ -0,0 +1,55 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params_test
+
+import (
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params/wireformat"
+)
+
+var updateWireFormat = flag.Bool("update-wireformat", false, "Record the current wire format of the params structs")
+
+// wireFormatPath holds the recorded wire format of the params structs.
+var wireFormatPath = filepath.Join("testdata", "wireformat.txt")
+
+type WireFormatSuite struct{}
+
+var _ = gc.Suite(&WireFormatSuite{})
+
+// TestWireFormatUnchanged checks that the params structs still have
+// the wire format recorded in testdata. Once any breaking changes have
+// been moved into new facade versions, record the new format with
+//
+//	go test github.com/juju/juju/apiserver/params -update-wireformat
+func (s *WireFormatSuite) TestWireFormatUnchanged(c *gc.C) {
+	current, err := wireformat.Generate(".")
+	c.Assert(err, jc.ErrorIsNil)
+	if *updateWireFormat {
+		err := ioutil.WriteFile(wireFormatPath, []byte(current.String()), 0644)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	data, err := ioutil.ReadFile(wireFormatPath)
+	c.Assert(err, jc.ErrorIsNil)
+	recorded, err := wireformat.Parse(data)
+	c.Assert(err, jc.ErrorIsNil)
+
+	changes := wireformat.Compare(recorded, current)
+	if len(changes) == 0 {
+		return
+	}
+	var descriptions []string
+	for _, change := range changes {
+		descriptions = append(descriptions, change.String())
+	}
+	c.Fatalf("params wire format changed; breaking changes must not be made "+
+		"to existing facade versions. Changes:\n%s", strings.Join(descriptions, "\n"))
+}
@@ -27,7 +27,9 @@ func NewPinger(st *state.State, resources facade.Resources, authorizer facade.Au
 
 // pinger describes a resource that can be pinged and stopped.
 type Pinger interface {
+	//juju:readonly
 	Ping()
+	//juju:readonly
 	Stop() error
 }
 
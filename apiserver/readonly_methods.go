@@ -0,0 +1,575 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+// Generated code - do not edit.
+
+import "github.com/juju/utils/set"
+
+// readOnlyMethods maps the import path and name of each type, joined with
+// a ".", to the methods in its method set that are annotated with
+// //juju:readonly.
+var readOnlyMethods = map[string]set.Strings{
+	"github.com/juju/juju/apiserver.Pinger": set.NewStrings(
+		"Ping",
+		"Stop",
+	),
+	"github.com/juju/juju/apiserver.SrvAllWatcher": set.NewStrings(
+		"Next",
+		"Stop",
+	),
+	"github.com/juju/juju/apiserver.srvEntitiesWatcher": set.NewStrings(
+		"Next",
+		"Stop",
+	),
+	"github.com/juju/juju/apiserver.srvEntityChangesWatcher": set.NewStrings(
+		"Next",
+		"Stop",
+	),
+	"github.com/juju/juju/apiserver.srvMachineStorageIdsWatcher": set.NewStrings(
+		"Next",
+		"Stop",
+	),
+	"github.com/juju/juju/apiserver.srvMigrationStatusWatcher": set.NewStrings(
+		"Next",
+		"Stop",
+	),
+	"github.com/juju/juju/apiserver.srvNotifyWatcher": set.NewStrings(
+		"Next",
+		"Stop",
+	),
+	"github.com/juju/juju/apiserver.srvOfferStatusWatcher": set.NewStrings(
+		"Next",
+		"Stop",
+	),
+	"github.com/juju/juju/apiserver.srvRelationStatusWatcher": set.NewStrings(
+		"Next",
+		"Stop",
+	),
+	"github.com/juju/juju/apiserver.srvRelationUnitsWatcher": set.NewStrings(
+		"Next",
+		"Stop",
+	),
+	"github.com/juju/juju/apiserver.srvStringsWatcher": set.NewStrings(
+		"Next",
+		"Stop",
+	),
+	"github.com/juju/juju/apiserver.watcherCommon": set.NewStrings(
+		"Stop",
+	),
+	"github.com/juju/juju/apiserver/common.ControllerConfigAPI": set.NewStrings(
+		"ControllerConfig",
+	),
+	"github.com/juju/juju/apiserver/common.ModelStatusAPI": set.NewStrings(
+		"ModelStatus",
+	),
+	"github.com/juju/juju/apiserver/common/cloudspec.CloudSpecAPI": set.NewStrings(
+		"GetCloudSpec",
+	),
+	"github.com/juju/juju/apiserver/facades/agent/agent.AgentAPIV2": set.NewStrings(
+		"ControllerConfig",
+		"GetCloudSpec",
+	),
+	"github.com/juju/juju/apiserver/facades/agent/provisioner.ProvisionerAPI": set.NewStrings(
+		"ControllerConfig",
+	),
+	"github.com/juju/juju/apiserver/facades/agent/provisioner.ProvisionerAPIV5": set.NewStrings(
+		"ControllerConfig",
+	),
+	"github.com/juju/juju/apiserver/facades/agent/provisioner.ProvisionerAPIV6": set.NewStrings(
+		"ControllerConfig",
+	),
+	"github.com/juju/juju/apiserver/facades/client/action.ActionAPI": set.NewStrings(
+		"Actions",
+		"ApplicationsCharmsActions",
+		"FindActionTagsByPrefix",
+		"FindActionsByNames",
+		"ListAll",
+		"ListCompleted",
+		"ListPending",
+		"ListRunning",
+	),
+	"github.com/juju/juju/apiserver/facades/client/annotations.API": set.NewStrings(
+		"Get",
+	),
+	"github.com/juju/juju/apiserver/facades/client/application.API": set.NewStrings(
+		"CharmRelations",
+		"Get",
+		"GetCharmURL",
+		"GetConfig",
+		"GetConstraints",
+		"GetConstraintsDetail",
+	),
+	"github.com/juju/juju/apiserver/facades/client/application.APIv10": set.NewStrings(
+		"CharmRelations",
+		"Get",
+		"GetCharmURL",
+		"GetConfig",
+		"GetConstraints",
+		"GetConstraintsDetail",
+	),
+	"github.com/juju/juju/apiserver/facades/client/application.APIv11": set.NewStrings(
+		"CharmRelations",
+		"Get",
+		"GetCharmURL",
+		"GetConfig",
+		"GetConstraints",
+		"GetConstraintsDetail",
+	),
+	"github.com/juju/juju/apiserver/facades/client/application.APIv12": set.NewStrings(
+		"CharmRelations",
+		"Get",
+		"GetCharmURL",
+		"GetConfig",
+		"GetConstraints",
+		"GetConstraintsDetail",
+	),
+	"github.com/juju/juju/apiserver/facades/client/application.APIv13": set.NewStrings(
+		"CharmRelations",
+		"Get",
+		"GetCharmURL",
+		"GetConfig",
+		"GetConstraints",
+		"GetConstraintsDetail",
+	),
+	"github.com/juju/juju/apiserver/facades/client/application.APIv14": set.NewStrings(
+		"CharmRelations",
+		"Get",
+		"GetCharmURL",
+		"GetConfig",
+		"GetConstraints",
+		"GetConstraintsDetail",
+	),
+	"github.com/juju/juju/apiserver/facades/client/application.APIv4": set.NewStrings(
+		"CharmRelations",
+		"Get",
+		"GetCharmURL",
+		"GetConfig",
+		"GetConstraints",
+		"GetConstraintsDetail",
+	),
+	"github.com/juju/juju/apiserver/facades/client/application.APIv5": set.NewStrings(
+		"CharmRelations",
+		"Get",
+		"GetCharmURL",
+		"GetConfig",
+		"GetConstraints",
+		"GetConstraintsDetail",
+	),
+	"github.com/juju/juju/apiserver/facades/client/application.APIv6": set.NewStrings(
+		"CharmRelations",
+		"Get",
+		"GetCharmURL",
+		"GetConfig",
+		"GetConstraints",
+		"GetConstraintsDetail",
+	),
+	"github.com/juju/juju/apiserver/facades/client/application.APIv7": set.NewStrings(
+		"CharmRelations",
+		"Get",
+		"GetCharmURL",
+		"GetConfig",
+		"GetConstraints",
+		"GetConstraintsDetail",
+	),
+	"github.com/juju/juju/apiserver/facades/client/application.APIv8": set.NewStrings(
+		"CharmRelations",
+		"Get",
+		"GetCharmURL",
+		"GetConfig",
+		"GetConstraints",
+		"GetConstraintsDetail",
+	),
+	"github.com/juju/juju/apiserver/facades/client/application.APIv9": set.NewStrings(
+		"CharmRelations",
+		"Get",
+		"GetCharmURL",
+		"GetConfig",
+		"GetConstraints",
+		"GetConstraintsDetail",
+	),
+	"github.com/juju/juju/apiserver/facades/client/applicationoffers.OffersAPI": set.NewStrings(
+		"ApplicationOffers",
+		"FindApplicationOffers",
+		"GetConsumeDetails",
+		"ListApplicationOffers",
+	),
+	"github.com/juju/juju/apiserver/facades/client/backups.API": set.NewStrings(
+		"Info",
+		"List",
+	),
+	"github.com/juju/juju/apiserver/facades/client/block.API": set.NewStrings(
+		"List",
+	),
+	"github.com/juju/juju/apiserver/facades/client/block.APIv2": set.NewStrings(
+		"List",
+	),
+	"github.com/juju/juju/apiserver/facades/client/bundle.Bundle": set.NewStrings(
+		"GetChanges",
+	),
+	"github.com/juju/juju/apiserver/facades/client/charms.API": set.NewStrings(
+		"CharmInfo",
+		"IsMetered",
+		"List",
+	),
+	"github.com/juju/juju/apiserver/facades/client/client.Client": set.NewStrings(
+		"APIHostPorts",
+		"AgentLoggingOverrides",
+		"AgentVersion",
+		"CACert",
+		"FindTools",
+		"FullStatus",
+		"GetBundleChanges",
+		"GetModelConstraints",
+		"ModelGet",
+		"ModelInfo",
+		"ModelUserInfo",
+		"PrivateAddress",
+		"PublicAddress",
+		"SLALevel",
+		"StatusHistory",
+		"WatchAll",
+	),
+	"github.com/juju/juju/apiserver/facades/client/client.ClientV1": set.NewStrings(
+		"APIHostPorts",
+		"AgentLoggingOverrides",
+		"AgentVersion",
+		"CACert",
+		"FindTools",
+		"FullStatus",
+		"GetBundleChanges",
+		"GetModelConstraints",
+		"ModelGet",
+		"ModelInfo",
+		"ModelUserInfo",
+		"PrivateAddress",
+		"PublicAddress",
+		"SLALevel",
+		"StatusHistory",
+		"WatchAll",
+	),
+	"github.com/juju/juju/apiserver/facades/client/cloud.CloudAPI": set.NewStrings(
+		"Cloud",
+		"Clouds",
+		"Credential",
+		"DefaultCloud",
+		"InstanceTypes",
+		"UserCredentials",
+	),
+	"github.com/juju/juju/apiserver/facades/client/cloud.CloudAPIV2": set.NewStrings(
+		"Cloud",
+		"Clouds",
+		"Credential",
+		"DefaultCloud",
+		"InstanceTypes",
+		"UserCredentials",
+	),
+	"github.com/juju/juju/apiserver/facades/client/controller.ControllerAPI": set.NewStrings(
+		"AgentBinaryStorageUsage",
+		"AgentConnectionHistory",
+		"AllModels",
+		"ControllerConfig",
+		"GetCloudSpec",
+		"GetControllerAccess",
+		"HostedModelConfigs",
+		"ListBlockedModels",
+		"ModelConfig",
+		"ModelStatus",
+		"WatchAllModels",
+	),
+	"github.com/juju/juju/apiserver/facades/client/controller.ControllerAPIv3": set.NewStrings(
+		"AgentBinaryStorageUsage",
+		"AgentConnectionHistory",
+		"AllModels",
+		"ControllerConfig",
+		"GetCloudSpec",
+		"GetControllerAccess",
+		"HostedModelConfigs",
+		"ListBlockedModels",
+		"ModelConfig",
+		"ModelStatus",
+		"WatchAllModels",
+	),
+	"github.com/juju/juju/apiserver/facades/client/controller.ControllerAPIv4": set.NewStrings(
+		"AgentBinaryStorageUsage",
+		"AgentConnectionHistory",
+		"AllModels",
+		"ControllerConfig",
+		"GetCloudSpec",
+		"GetControllerAccess",
+		"HostedModelConfigs",
+		"ListBlockedModels",
+		"ModelConfig",
+		"ModelStatus",
+		"WatchAllModels",
+	),
+	"github.com/juju/juju/apiserver/facades/client/controller.ControllerAPIv5": set.NewStrings(
+		"AgentBinaryStorageUsage",
+		"AgentConnectionHistory",
+		"AllModels",
+		"ControllerConfig",
+		"GetCloudSpec",
+		"GetControllerAccess",
+		"HostedModelConfigs",
+		"ListBlockedModels",
+		"ModelConfig",
+		"ModelStatus",
+		"WatchAllModels",
+	),
+	"github.com/juju/juju/apiserver/facades/client/controller.ControllerAPIv6": set.NewStrings(
+		"AgentBinaryStorageUsage",
+		"AgentConnectionHistory",
+		"AllModels",
+		"ControllerConfig",
+		"GetCloudSpec",
+		"GetControllerAccess",
+		"HostedModelConfigs",
+		"ListBlockedModels",
+		"ModelConfig",
+		"ModelStatus",
+		"WatchAllModels",
+	),
+	"github.com/juju/juju/apiserver/facades/client/controller.ControllerAPIv7": set.NewStrings(
+		"AgentBinaryStorageUsage",
+		"AgentConnectionHistory",
+		"AllModels",
+		"ControllerConfig",
+		"GetCloudSpec",
+		"GetControllerAccess",
+		"HostedModelConfigs",
+		"ListBlockedModels",
+		"ModelConfig",
+		"ModelStatus",
+		"WatchAllModels",
+	),
+	"github.com/juju/juju/apiserver/facades/client/controller.ControllerAPIv8": set.NewStrings(
+		"AgentBinaryStorageUsage",
+		"AgentConnectionHistory",
+		"AllModels",
+		"ControllerConfig",
+		"GetCloudSpec",
+		"GetControllerAccess",
+		"HostedModelConfigs",
+		"ListBlockedModels",
+		"ModelConfig",
+		"ModelStatus",
+		"WatchAllModels",
+	),
+	"github.com/juju/juju/apiserver/facades/client/controllercertificates.API": set.NewStrings(
+		"CertificateInfo",
+	),
+	"github.com/juju/juju/apiserver/facades/client/controllerhealth.API": set.NewStrings(
+		"ControllerHealth",
+	),
+	"github.com/juju/juju/apiserver/facades/client/costestimation.API": set.NewStrings(
+		"ModelCost",
+	),
+	"github.com/juju/juju/apiserver/facades/client/crashreports.API": set.NewStrings(
+		"Download",
+		"List",
+	),
+	"github.com/juju/juju/apiserver/facades/client/fanconfig.API": set.NewStrings(
+		"FanConfig",
+	),
+	"github.com/juju/juju/apiserver/facades/client/firewallrules.API": set.NewStrings(
+		"ListFirewallRules",
+	),
+	"github.com/juju/juju/apiserver/facades/client/firewallrules.APIV1": set.NewStrings(
+		"ListFirewallRules",
+	),
+	"github.com/juju/juju/apiserver/facades/client/highavailability.HighAvailabilityAPI": set.NewStrings(
+		"ControllersProgress",
+		"WatchControllersProgress",
+	),
+	"github.com/juju/juju/apiserver/facades/client/highavailability.HighAvailabilityAPIV2": set.NewStrings(
+		"ControllersProgress",
+		"WatchControllersProgress",
+	),
+	"github.com/juju/juju/apiserver/facades/client/imagemanager.ImageManagerAPI": set.NewStrings(
+		"ListImages",
+	),
+	"github.com/juju/juju/apiserver/facades/client/imagemetadatamanager.API": set.NewStrings(
+		"List",
+	),
+	"github.com/juju/juju/apiserver/facades/client/keymanager.KeyManagerAPI": set.NewStrings(
+		"ListKeys",
+	),
+	"github.com/juju/juju/apiserver/facades/client/machinemanager.MachineManagerAPI": set.NewStrings(
+		"InstanceTypes",
+	),
+	"github.com/juju/juju/apiserver/facades/client/machinemanager.MachineManagerAPIV4": set.NewStrings(
+		"InstanceTypes",
+	),
+	"github.com/juju/juju/apiserver/facades/client/machinemanager.MachineManagerAPIV5": set.NewStrings(
+		"InstanceTypes",
+	),
+	"github.com/juju/juju/apiserver/facades/client/machinemanager.MachineManagerAPIV6": set.NewStrings(
+		"InstanceTypes",
+	),
+	"github.com/juju/juju/apiserver/facades/client/maintenance.API": set.NewStrings(
+		"AgentsOffline",
+		"MaintenanceModes",
+		"MaintenanceWindows",
+	),
+	"github.com/juju/juju/apiserver/facades/client/maintenance.APIv1": set.NewStrings(
+		"AgentsOffline",
+		"MaintenanceModes",
+		"MaintenanceWindows",
+	),
+	"github.com/juju/juju/apiserver/facades/client/maintenance.APIv2": set.NewStrings(
+		"AgentsOffline",
+		"MaintenanceModes",
+		"MaintenanceWindows",
+	),
+	"github.com/juju/juju/apiserver/facades/client/metricsdebug.MetricsDebugAPI": set.NewStrings(
+		"GetMetrics",
+	),
+	"github.com/juju/juju/apiserver/facades/client/modelconfig.ModelConfigAPI": set.NewStrings(
+		"AgentLoggingOverrides",
+		"ModelGet",
+		"SLALevel",
+	),
+	"github.com/juju/juju/apiserver/facades/client/modelconfig.ModelConfigAPIV1": set.NewStrings(
+		"AgentLoggingOverrides",
+		"ModelGet",
+		"SLALevel",
+	),
+	"github.com/juju/juju/apiserver/facades/client/modelconfig.ModelConfigAPIV2": set.NewStrings(
+		"AgentLoggingOverrides",
+		"ModelGet",
+		"SLALevel",
+	),
+	"github.com/juju/juju/apiserver/facades/client/modelevents.API": set.NewStrings(
+		"Events",
+		"WatchEvents",
+	),
+	"github.com/juju/juju/apiserver/facades/client/modelmanager.ModelManagerAPI": set.NewStrings(
+		"DestroyProgress",
+		"ListModelSummaries",
+		"ListModels",
+		"ModelDefaults",
+		"ModelInfo",
+		"ModelStatus",
+		"WatchDestroyProgress",
+	),
+	"github.com/juju/juju/apiserver/facades/client/modelmanager.ModelManagerAPIV2": set.NewStrings(
+		"DestroyProgress",
+		"ListModelSummaries",
+		"ListModels",
+		"ModelDefaults",
+		"ModelInfo",
+		"ModelStatus",
+		"WatchDestroyProgress",
+	),
+	"github.com/juju/juju/apiserver/facades/client/modelmanager.ModelManagerAPIV3": set.NewStrings(
+		"DestroyProgress",
+		"ListModelSummaries",
+		"ListModels",
+		"ModelDefaults",
+		"ModelInfo",
+		"ModelStatus",
+		"WatchDestroyProgress",
+	),
+	"github.com/juju/juju/apiserver/facades/client/modelmanager.ModelManagerAPIV4": set.NewStrings(
+		"DestroyProgress",
+		"ListModelSummaries",
+		"ListModels",
+		"ModelDefaults",
+		"ModelInfo",
+		"ModelStatus",
+		"WatchDestroyProgress",
+	),
+	"github.com/juju/juju/apiserver/facades/client/modelmanager.ModelManagerAPIV5": set.NewStrings(
+		"DestroyProgress",
+		"ListModelSummaries",
+		"ListModels",
+		"ModelDefaults",
+		"ModelInfo",
+		"ModelStatus",
+		"WatchDestroyProgress",
+	),
+	"github.com/juju/juju/apiserver/facades/client/modelvisualization.API": set.NewStrings(
+		"Render",
+	),
+	"github.com/juju/juju/apiserver/facades/client/payloads.API": set.NewStrings(
+		"List",
+	),
+	"github.com/juju/juju/apiserver/facades/client/pubsubtopology.API": set.NewStrings(
+		"Topology",
+	),
+	"github.com/juju/juju/apiserver/facades/client/quotas.API": set.NewStrings(
+		"ModelQuotas",
+	),
+	"github.com/juju/juju/apiserver/facades/client/resources.Facade": set.NewStrings(
+		"ListResources",
+	),
+	"github.com/juju/juju/apiserver/facades/client/spaces.API": set.NewStrings(
+		"ListSpaces",
+	),
+	"github.com/juju/juju/apiserver/facades/client/spaces.APIV2": set.NewStrings(
+		"ListSpaces",
+	),
+	"github.com/juju/juju/apiserver/facades/client/sshclient.Facade": set.NewStrings(
+		"AllAddresses",
+		"PrivateAddress",
+		"Proxy",
+		"PublicAddress",
+		"PublicKeys",
+	),
+	"github.com/juju/juju/apiserver/facades/client/storage.APIv3": set.NewStrings(
+		"ListFilesystems",
+		"ListPools",
+		"ListStorageDetails",
+		"ListVolumes",
+		"StorageDetails",
+	),
+	"github.com/juju/juju/apiserver/facades/client/storage.APIv4": set.NewStrings(
+		"ListFilesystems",
+		"ListPools",
+		"ListStorageDetails",
+		"ListVolumes",
+		"StorageDetails",
+	),
+	"github.com/juju/juju/apiserver/facades/client/storage.APIv5": set.NewStrings(
+		"ListFilesystems",
+		"ListPools",
+		"ListStorageDetails",
+		"ListVolumes",
+		"StatusHistory",
+		"StorageDetails",
+	),
+	"github.com/juju/juju/apiserver/facades/client/subnets.SubnetsAPI": set.NewStrings(
+		"AllSpaces",
+		"AllZones",
+		"ListSubnets",
+	),
+	"github.com/juju/juju/apiserver/facades/client/truststore.API": set.NewStrings(
+		"ListCACerts",
+	),
+	"github.com/juju/juju/apiserver/facades/client/usermanager.UserManagerAPI": set.NewStrings(
+		"UserInfo",
+	),
+	"github.com/juju/juju/apiserver/facades/client/utilization.API": set.NewStrings(
+		"UnitsUtilization",
+		"WatchUnitsUtilization",
+	),
+	"github.com/juju/juju/apiserver/facades/controller/firewaller.FirewallerAPIV3": set.NewStrings(
+		"GetCloudSpec",
+	),
+	"github.com/juju/juju/apiserver/facades/controller/firewaller.FirewallerAPIV4": set.NewStrings(
+		"ControllerConfig",
+		"GetCloudSpec",
+	),
+	"github.com/juju/juju/apiserver/facades/controller/firewaller.FirewallerAPIV5": set.NewStrings(
+		"ControllerConfig",
+		"GetCloudSpec",
+	),
+	"github.com/juju/juju/apiserver/facades/controller/firewaller.FirewallerAPIV6": set.NewStrings(
+		"ControllerConfig",
+		"GetCloudSpec",
+	),
+	"github.com/juju/juju/apiserver/facades/controller/remoterelations.RemoteRelationsAPI": set.NewStrings(
+		"ControllerConfig",
+	),
+}
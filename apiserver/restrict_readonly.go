@@ -0,0 +1,64 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"reflect"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/rpcreflect"
+)
+
+//go:generate go run ../generate/readonlymethods/readonlymethods.go . github.com/juju/juju/apiserver readOnlyMethods readonly_methods.go 2018 apiserver
+
+// readOnlyRoot restricts the API root to the facade methods annotated
+// as read-only.
+type readOnlyRoot struct {
+	rpc.Root
+	facades *facade.Registry
+}
+
+// restrictReadOnly returns a new API root that only allows the methods
+// of the given facades that are annotated as read-only.
+func restrictReadOnly(root rpc.Root, facades *facade.Registry) rpc.Root {
+	return &readOnlyRoot{
+		Root:    root,
+		facades: facades,
+	}
+}
+
+// FindMethod implements rpc.Root.
+func (r *readOnlyRoot) FindMethod(facadeName string, version int, methodName string) (rpcreflect.MethodCaller, error) {
+	if !IsMethodReadOnly(r.facades, facadeName, version, methodName) {
+		return nil, errors.Annotatef(common.ErrPerm, "%s.%s not allowed on read-only connection", facadeName, methodName)
+	}
+	return r.Root.FindMethod(facadeName, version, methodName)
+}
+
+// IsMethodReadOnly reports whether the given method of the given
+// facade version is annotated as read-only, and so may be called on a
+// read-only connection. Any method that isn't annotated is treated as
+// mutating, so methods added to facades are blocked on read-only
+// connections until they are annotated.
+//
+// A method is annotated by ending its doc comment with the line
+//
+//	//juju:readonly
+//
+// and running go generate in this package.
+func IsMethodReadOnly(facades *facade.Registry, facadeName string, version int, methodName string) bool {
+	facadeType, err := facades.GetType(facadeName, version)
+	if err != nil {
+		return false
+	}
+	if facadeType.Kind() == reflect.Ptr {
+		facadeType = facadeType.Elem()
+	}
+	methods, ok := readOnlyMethods[facadeType.PkgPath()+"."+facadeType.Name()]
+	return ok && methods.Contains(methodName)
+}
@@ -0,0 +1,82 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade/readonly"
+	"github.com/juju/juju/testing"
+)
+
+type restrictReadOnlySuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&restrictReadOnlySuite{})
+
+func (s *restrictReadOnlySuite) TestAllowedMethods(c *gc.C) {
+	root := apiserver.TestingReadOnlyRoot()
+	checkAllowed := func(facade string, version int, method string) {
+		caller, err := root.FindMethod(facade, version, method)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(caller, gc.NotNil)
+	}
+	checkAllowed("Client", 1, "FullStatus")
+	checkAllowed("Client", 1, "GetModelConstraints")
+	checkAllowed("Client", 1, "WatchAll")
+	checkAllowed("AllWatcher", 1, "Next")
+	checkAllowed("ModelManager", 5, "ListModels")
+	checkAllowed("Pinger", 1, "Ping")
+}
+
+func (s *restrictReadOnlySuite) TestFindDisallowedMethod(c *gc.C) {
+	root := apiserver.TestingReadOnlyRoot()
+	caller, err := root.FindMethod("Client", 1, "SetModelConstraints")
+	c.Assert(err, gc.ErrorMatches, "Client.SetModelConstraints not allowed on read-only connection: permission denied")
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrPerm)
+	c.Assert(caller, gc.IsNil)
+}
+
+func (s *restrictReadOnlySuite) TestIsMethodReadOnly(c *gc.C) {
+	facades := apiserver.AllFacades()
+	c.Check(apiserver.IsMethodReadOnly(facades, "Application", 15, "Get"), jc.IsTrue)
+	c.Check(apiserver.IsMethodReadOnly(facades, "Application", 15, "CharmRelations"), jc.IsTrue)
+	c.Check(apiserver.IsMethodReadOnly(facades, "ModelVisualization", 1, "Render"), jc.IsTrue)
+	c.Check(apiserver.IsMethodReadOnly(facades, "Application", 15, "Deploy"), jc.IsFalse)
+	c.Check(apiserver.IsMethodReadOnly(facades, "ModelConfig", 3, "ModelSet"), jc.IsFalse)
+	// Methods that aren't annotated are not allowed, whatever
+	// their names.
+	c.Check(apiserver.IsMethodReadOnly(facades, "Uniter", 12, "GetPrincipal"), jc.IsFalse)
+	c.Check(apiserver.IsMethodReadOnly(facades, "Unknown", 1, "Frobnicate"), jc.IsFalse)
+}
+
+func (s *restrictReadOnlySuite) TestIsMethodReadOnlyPromoted(c *gc.C) {
+	facades := apiserver.AllFacades()
+	// Stop is promoted from the common watcher implementation.
+	c.Check(apiserver.IsMethodReadOnly(facades, "NotifyWatcher", 1, "Stop"), jc.IsTrue)
+	// Older facade versions embed the current one, so its
+	// read-only methods are promoted to them.
+	c.Check(apiserver.IsMethodReadOnly(facades, "Application", 1, "CharmRelations"), jc.IsTrue)
+	c.Check(apiserver.IsMethodReadOnly(facades, "Application", 1, "Get"), jc.IsTrue)
+}
+
+// TestReadOnlyMethodsGenerated checks that the generated read-only
+// methods match the current annotations. After annotating methods,
+// regenerate them with
+//
+//	go generate github.com/juju/juju/apiserver
+func (s *restrictReadOnlySuite) TestReadOnlyMethodsGenerated(c *gc.C) {
+	current, err := readonly.Find(".", "github.com/juju/juju/apiserver")
+	c.Assert(err, jc.ErrorIsNil)
+	generated := make(readonly.Methods)
+	for name, methods := range apiserver.ReadOnlyMethods {
+		generated[name] = methods.SortedValues()
+	}
+	c.Assert(generated, jc.DeepEquals, current)
+}
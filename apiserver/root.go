@@ -56,23 +56,38 @@ type apiHandler struct {
 	// serverHost is the host:port of the API server that the client
 	// connected to.
 	serverHost string
+
+	// connectionID is the API server's identifier for the
+	// connection.
+	connectionID uint64
+
+	// userAccess returns the access a user has on a target,
+	// including any granted by the server's external authorizer
+	// or a break-glass session.
+	userAccess userAccessFunc
+
+	// breakGlass holds the break-glass session, if any, that was
+	// active when the user logged in.
+	breakGlass *state.BreakGlassSession
 }
 
 var _ = (*apiHandler)(nil)
 
 // newAPIHandler returns a new apiHandler.
-func newAPIHandler(srv *Server, st *state.State, rpcConn *rpc.Conn, modelUUID string, serverHost string) (*apiHandler, error) {
+func newAPIHandler(srv *Server, st *state.State, rpcConn *rpc.Conn, modelUUID string, connectionID uint64, serverHost string) (*apiHandler, error) {
 	m, err := st.Model()
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	r := &apiHandler{
-		state:      st,
-		model:      m,
-		resources:  common.NewResources(),
-		rpcConn:    rpcConn,
-		modelUUID:  modelUUID,
-		serverHost: serverHost,
+		state:        st,
+		model:        m,
+		resources:    common.NewResources(),
+		rpcConn:      rpcConn,
+		modelUUID:    modelUUID,
+		connectionID: connectionID,
+		serverHost:   serverHost,
+		userAccess:   srv.withExternalAccess(st.UserPermission),
 	}
 
 	if err := r.resources.RegisterNamed("machineID", common.StringResource(srv.tag.Id())); err != nil {
@@ -99,6 +114,24 @@ func newAPIHandler(srv *Server, st *state.State, rpcConn *rpc.Conn, modelUUID st
 	); err != nil {
 		return nil, errors.Trace(err)
 	}
+
+	// The uniter facade reports the debug-hooks sessions opened by
+	// clients through this server.
+	if err := r.resources.RegisterNamed(
+		"debugHooksSessions",
+		common.ValueResource{srv.debugHooks},
+	); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// The controller facade reports the connections made by agents
+	// to this server.
+	if err := r.resources.RegisterNamed(
+		"agentConnectionHistory",
+		common.ValueResource{srv.agentConnections},
+	); err != nil {
+		return nil, errors.Trace(err)
+	}
 	return r, nil
 }
 
@@ -124,6 +157,11 @@ type srvCaller struct {
 	objMethod rpcreflect.ObjMethod
 	goType    reflect.Type
 	creator   func(id string) (reflect.Value, error)
+
+	// limiter, if non-nil, limits the time taken by the call.
+	limiter    *callLimiter
+	facadeName string
+	methodName string
 }
 
 // ParamsType defines the parameters that should be supplied to this function.
@@ -145,7 +183,12 @@ func (s *srvCaller) Call(ctx context.Context, objId string, arg reflect.Value) (
 	if err != nil {
 		return reflect.Value{}, err
 	}
-	return s.objMethod.Call(ctx, objVal, arg)
+	if s.limiter == nil {
+		return s.objMethod.Call(ctx, objVal, arg)
+	}
+	return s.limiter.call(ctx, s.facadeName, s.methodName, func(ctx context.Context) (reflect.Value, error) {
+		return s.objMethod.Call(ctx, objVal, arg)
+	})
 }
 
 // apiRoot implements basic method dispatching to the facade registry.
@@ -155,18 +198,24 @@ type apiRoot struct {
 	facades     *facade.Registry
 	resources   *common.Resources
 	authorizer  facade.Authorizer
+	hub         facade.Hub
 	objectMutex sync.RWMutex
 	objectCache map[objectKey]reflect.Value
+
+	// callLimiter, if non-nil, limits the time taken by calls
+	// made through the root.
+	callLimiter *callLimiter
 }
 
 // newAPIRoot returns a new apiRoot.
-func newAPIRoot(st *state.State, pool *state.StatePool, facades *facade.Registry, resources *common.Resources, authorizer facade.Authorizer) *apiRoot {
+func newAPIRoot(st *state.State, pool *state.StatePool, facades *facade.Registry, resources *common.Resources, authorizer facade.Authorizer, hub facade.Hub) *apiRoot {
 	r := &apiRoot{
 		state:       st,
 		pool:        pool,
 		facades:     facades,
 		resources:   resources,
 		authorizer:  authorizer,
+		hub:         hub,
 		objectCache: make(map[objectKey]reflect.Value),
 	}
 	return r
@@ -200,6 +249,9 @@ func restrictAPIRoot(
 			apiRoot = restrictRoot(apiRoot, caasModelFacadesOnly)
 		}
 	}
+	if auth.readOnly {
+		apiRoot = restrictReadOnly(apiRoot, srv.facades)
+	}
 	return apiRoot, nil
 }
 
@@ -325,8 +377,11 @@ func (r *apiRoot) FindMethod(rootName string, version int, methodName string) (r
 		return objValue, nil
 	}
 	return &srvCaller{
-		creator:   creator,
-		objMethod: objMethod,
+		creator:    creator,
+		objMethod:  objMethod,
+		limiter:    r.callLimiter,
+		facadeName: rootName,
+		methodName: methodName,
 	}, nil
 }
 
@@ -401,6 +456,11 @@ func (ctx *facadeContext) StatePool() *state.StatePool {
 	return ctx.r.pool
 }
 
+// Hub is part of of the facade.Context interface.
+func (ctx *facadeContext) Hub() facade.Hub {
+	return ctx.r.hub
+}
+
 // ID is part of of the facade.Context interface.
 func (ctx *facadeContext) ID() string {
 	return ctx.key.objId
@@ -493,12 +553,12 @@ func (r *apiHandler) ConnectedModel() string {
 
 // HasPermission returns true if the logged in user can perform <operation> on <target>.
 func (r *apiHandler) HasPermission(operation permission.Access, target names.Tag) (bool, error) {
-	return common.HasPermission(r.state.UserPermission, r.entity.Tag(), operation, target)
+	return common.HasPermission(r.userAccess, r.entity.Tag(), operation, target)
 }
 
 // UserHasPermission returns true if the passed in user can perform <operation> on <target>.
 func (r *apiHandler) UserHasPermission(user names.UserTag, operation permission.Access, target names.Tag) (bool, error) {
-	return common.HasPermission(r.state.UserPermission, user, operation, target)
+	return common.HasPermission(r.userAccess, user, operation, target)
 }
 
 // DescribeFacades returns the list of available Facades and their Versions
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/pubsub"
 	"github.com/juju/testing"
@@ -102,6 +103,49 @@ func (s *serverSuite) TestStop(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *serverSuite) TestDrain(c *gc.C) {
+	drain := make(chan struct{})
+	cfg := defaultServerConfig(c)
+	cfg.Drain = drain
+	cfg.DrainTimeout = coretesting.ShortWait
+	apiInfo, srv := newServerWithConfig(c, s.pool, cfg)
+	defer assertStop(c, srv)
+
+	machine, password := s.Factory.MakeMachineReturningPassword(
+		c, &factory.MachineParams{Nonce: "fake_nonce"})
+	apiInfo.Tag = machine.Tag()
+	apiInfo.Password = password
+	apiInfo.Nonce = "fake_nonce"
+	apiInfo.ModelTag = s.IAASModel.ModelTag()
+	st, err := api.Open(apiInfo, fastDialOpts)
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+
+	close(drain)
+	select {
+	case <-st.Broken():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for connection to be closed")
+	}
+	select {
+	case <-srv.Dead():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for server to exit")
+	}
+
+	// New connections are refused.
+	_, err = api.Open(apiInfo, fastDialOpts)
+	c.Assert(err, gc.NotNil)
+}
+
+func (s *serverSuite) TestDrainTimeoutRequired(c *gc.C) {
+	cfg := defaultServerConfig(c)
+	cfg.Drain = make(chan struct{})
+	err := cfg.Validate()
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, "non-positive DrainTimeout not valid")
+}
+
 func (s *serverSuite) TestAPIServerCanListenOnBothIPv4AndIPv6(c *gc.C) {
 	err := s.State.SetAPIHostPorts(nil)
 	c.Assert(err, jc.ErrorIsNil)
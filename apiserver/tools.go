@@ -4,17 +4,24 @@
 package apiserver
 
 import (
+	"archive/tar"
 	"bytes"
 	"crypto/sha256"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/utils"
+	"github.com/juju/utils/set"
 	"github.com/juju/version"
 
 	"github.com/juju/juju/apiserver/common"
@@ -50,7 +57,17 @@ func (h *toolsDownloadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 
 	switch r.Method {
 	case "GET":
-		tarball, err := h.processGet(r, st)
+		// The storage must remain open while the tarball is sent.
+		storage, err := st.ToolsStorage()
+		if err != nil {
+			err = errors.Annotate(err, "error getting storage for agent binaries")
+			if err := sendError(w, errors.NewBadRequest(err, "")); err != nil {
+				logger.Errorf("%v", err)
+			}
+			return
+		}
+		defer storage.Close()
+		metadata, tarball, err := h.processGet(r, st, storage)
 		if err != nil {
 			logger.Errorf("GET(%s) failed: %v", r.URL, err)
 			if err := sendError(w, errors.NewBadRequest(err, "")); err != nil {
@@ -58,7 +75,8 @@ func (h *toolsDownloadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 			}
 			return
 		}
-		if err := h.sendTools(w, http.StatusOK, tarball); err != nil {
+		defer tarball.Close()
+		if err := h.sendTools(w, r, metadata, tarball); err != nil {
 			logger.Errorf("%v", err)
 		}
 	default:
@@ -68,6 +86,10 @@ func (h *toolsDownloadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// maxToolsBundleSize is the largest agent binaries bundle, in bytes,
+// that may be uploaded in a single request.
+var maxToolsBundleSize int64 = 1 << 30
+
 func (h *toolsUploadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Validate before authenticate because the authentication is dependent
 	// on the state connection that is determined during the validation.
@@ -83,7 +105,7 @@ func (h *toolsUploadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case "POST":
 		// Add tools to storage.
-		agentTools, err := h.processPost(r, st)
+		toolsList, err := h.processPost(w, r, st)
 		if err != nil {
 			if err := sendError(w, err); err != nil {
 				logger.Errorf("%v", err)
@@ -91,7 +113,7 @@ func (h *toolsUploadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		if err := sendStatusAndJSON(w, http.StatusOK, &params.ToolsResult{
-			ToolsList: tools.List{agentTools},
+			ToolsList: toolsList,
 		}); err != nil {
 			logger.Errorf("%v", err)
 		}
@@ -102,58 +124,49 @@ func (h *toolsUploadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// processGet handles a tools GET request.
-func (h *toolsDownloadHandler) processGet(r *http.Request, st *state.State) ([]byte, error) {
+// processGet handles a tools GET request, returning the metadata and
+// contents of the requested tarball from the given storage.
+func (h *toolsDownloadHandler) processGet(r *http.Request, st *state.State, storage binarystorage.Storage) (binarystorage.Metadata, io.ReadCloser, error) {
 	version, err := version.ParseBinary(r.URL.Query().Get(":version"))
 	if err != nil {
-		return nil, errors.Annotate(err, "error parsing version")
+		return binarystorage.Metadata{}, nil, errors.Annotate(err, "error parsing version")
 	}
-	storage, err := st.ToolsStorage()
-	if err != nil {
-		return nil, errors.Annotate(err, "error getting storage for agent binaries")
-	}
-	defer storage.Close()
-	_, reader, err := storage.Open(version.String())
+	metadata, reader, err := storage.Open(version.String())
 	if errors.IsNotFound(err) {
 		// Tools could not be found in tools storage,
 		// so look for them in simplestreams, fetch
 		// them and cache in tools storage.
 		logger.Infof("%v agent binaries not found locally, fetching", version)
-		reader, err = h.fetchAndCacheTools(version, storage, st)
+		metadata, reader, err = h.fetchAndCacheTools(version, storage, st)
 		if err != nil {
 			err = errors.Annotate(err, "error fetching agent binaries")
 		}
 	}
 	if err != nil {
-		return nil, err
-	}
-	defer reader.Close()
-	data, err := ioutil.ReadAll(reader)
-	if err != nil {
-		return nil, errors.Annotate(err, "failed to read agent binaries tarball")
+		return binarystorage.Metadata{}, nil, err
 	}
-	return data, nil
+	return metadata, reader, nil
 }
 
 // fetchAndCacheTools fetches tools with the specified version by searching for a URL
 // in simplestreams and GETting it, caching the result in tools storage before returning
 // to the caller.
-func (h *toolsDownloadHandler) fetchAndCacheTools(v version.Binary, stor binarystorage.Storage, st *state.State) (io.ReadCloser, error) {
+func (h *toolsDownloadHandler) fetchAndCacheTools(v version.Binary, stor binarystorage.Storage, st *state.State) (binarystorage.Metadata, io.ReadCloser, error) {
 	newEnviron := stateenvirons.GetNewEnvironFunc(environs.New)
 	env, err := newEnviron(st)
 	if err != nil {
-		return nil, err
+		return binarystorage.Metadata{}, nil, err
 	}
 	tools, err := envtools.FindExactTools(env, v.Number, v.Series, v.Arch)
 	if err != nil {
-		return nil, err
+		return binarystorage.Metadata{}, nil, err
 	}
 
 	// No need to verify the server's identity because we verify the SHA-256 hash.
 	logger.Infof("fetching %v agent binaries from %v", v, tools.URL)
 	resp, err := utils.GetNonValidatingHTTPClient().Get(tools.URL)
 	if err != nil {
-		return nil, err
+		return binarystorage.Metadata{}, nil, err
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
@@ -161,17 +174,17 @@ func (h *toolsDownloadHandler) fetchAndCacheTools(v version.Binary, stor binarys
 		if body, err := ioutil.ReadAll(resp.Body); err == nil {
 			msg += fmt.Sprintf(" (%s)", bytes.TrimSpace(body))
 		}
-		return nil, errors.New(msg)
+		return binarystorage.Metadata{}, nil, errors.New(msg)
 	}
 	data, sha256, err := readAndHash(resp.Body)
 	if err != nil {
-		return nil, err
+		return binarystorage.Metadata{}, nil, err
 	}
 	if int64(len(data)) != tools.Size {
-		return nil, errors.Errorf("size mismatch for %s", tools.URL)
+		return binarystorage.Metadata{}, nil, errors.Errorf("size mismatch for %s", tools.URL)
 	}
 	if sha256 != tools.SHA256 {
-		return nil, errors.Errorf("hash mismatch for %s", tools.URL)
+		return binarystorage.Metadata{}, nil, errors.Errorf("hash mismatch for %s", tools.URL)
 	}
 
 	// Cache tarball in tools storage before returning.
@@ -181,29 +194,101 @@ func (h *toolsDownloadHandler) fetchAndCacheTools(v version.Binary, stor binarys
 		SHA256:  tools.SHA256,
 	}
 	if err := stor.Add(bytes.NewReader(data), metadata); err != nil {
-		return nil, errors.Annotate(err, "error caching agent binaries")
+		return binarystorage.Metadata{}, nil, errors.Annotate(err, "error caching agent binaries")
 	}
-	return ioutil.NopCloser(bytes.NewReader(data)), nil
+	return metadata, ioutil.NopCloser(bytes.NewReader(data)), nil
 }
 
-// sendTools streams the tools tarball to the client.
-func (h *toolsDownloadHandler) sendTools(w http.ResponseWriter, statusCode int, tarball []byte) error {
+// sendTools streams the tools tarball to the client. The tarball's
+// SHA-256 hash is sent as its entity tag and digest, so that clients
+// can verify what they receive, and a request whose Range header asks
+// for the tarball from some offset onwards is answered with just that
+// part of it, so that clients can resume interrupted downloads.
+func (h *toolsDownloadHandler) sendTools(w http.ResponseWriter, r *http.Request, metadata binarystorage.Metadata, tarball io.Reader) error {
+	etag := fmt.Sprintf("%q", metadata.SHA256)
 	w.Header().Set("Content-Type", "application/x-tar-gz")
-	w.Header().Set("Content-Length", fmt.Sprint(len(tarball)))
+	w.Header().Set("Accept-Ranges", "bytes")
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Digest", params.EncodeChecksum(metadata.SHA256))
+
+	statusCode := http.StatusOK
+	var offset int64
+	if ifRange := r.Header.Get("If-Range"); ifRange == "" || ifRange == etag {
+		var ok bool
+		offset, ok = rangeOffset(r.Header.Get("Range"))
+		switch {
+		case !ok:
+			offset = 0
+		case offset >= metadata.Size:
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", metadata.Size))
+			w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+			return nil
+		default:
+			statusCode = http.StatusPartialContent
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, metadata.Size-1, metadata.Size))
+		}
+	}
+	if offset > 0 {
+		if err := skip(tarball, offset); err != nil {
+			return errors.Trace(sendError(w, errors.Annotate(err, "failed to read agent binaries")))
+		}
+	}
+	w.Header().Set("Content-Length", fmt.Sprint(metadata.Size-offset))
 	w.WriteHeader(statusCode)
-	if _, err := w.Write(tarball); err != nil {
-		return errors.Trace(sendError(
-			w,
-			errors.NewBadRequest(errors.Annotatef(err, "failed to write agent binaries"), ""),
-		))
+	// The status has been sent, so a failure to write the rest of the
+	// tarball can only be logged; the client will see a short body,
+	// and may resume the download from where it stopped.
+	if _, err := io.Copy(w, tarball); err != nil {
+		return errors.Annotatef(err, "failed to write agent binaries")
 	}
 	return nil
 }
 
+// rangeOffset returns the offset from which the given Range header
+// asks for content to be sent, and whether it asks for a single
+// range that extends to the end of the content. Other ranges are not
+// supported, and are ignored as allowed by RFC 7233.
+func rangeOffset(header string) (int64, bool) {
+	if !strings.HasPrefix(header, "bytes=") {
+		return 0, false
+	}
+	spec := strings.TrimPrefix(header, "bytes=")
+	if !strings.HasSuffix(spec, "-") {
+		return 0, false
+	}
+	offset, err := strconv.ParseInt(strings.TrimSuffix(spec, "-"), 10, 64)
+	if err != nil || offset < 0 {
+		return 0, false
+	}
+	return offset, true
+}
+
+// skip discards the first n bytes of the given reader, seeking past
+// them if it can.
+func skip(r io.Reader, n int64) error {
+	if seeker, ok := r.(io.Seeker); ok {
+		_, err := seeker.Seek(n, io.SeekStart)
+		return errors.Trace(err)
+	}
+	_, err := io.CopyN(ioutil.Discard, r, n)
+	return errors.Trace(err)
+}
+
 // processPost handles a tools upload POST request after authentication.
-func (h *toolsUploadHandler) processPost(r *http.Request, st *state.State) (*tools.Tools, error) {
+func (h *toolsUploadHandler) processPost(w http.ResponseWriter, r *http.Request, st *state.State) (tools.List, error) {
 	query := r.URL.Query()
 
+	// A tar archive holds a bundle of agent binaries, described by
+	// its manifest, rather than a single binary.
+	if r.Header.Get("Content-Type") == params.ContentTypeToolsBundle {
+		serverRoot, err := h.getServerRoot(r, query, st)
+		if err != nil {
+			return nil, errors.NewBadRequest(err, "cannot to determine server root")
+		}
+		body := http.MaxBytesReader(w, r.Body, maxToolsBundleSize)
+		return h.handleBundleUpload(body, serverRoot, st)
+	}
+
 	binaryVersionParam := query.Get("binaryVersion")
 	if binaryVersionParam == "" {
 		return nil, errors.BadRequestf("expected binaryVersion argument")
@@ -241,7 +326,11 @@ func (h *toolsUploadHandler) processPost(r *http.Request, st *state.State) (*too
 			toolsVersions = append(toolsVersions, v)
 		}
 	}
-	return h.handleUpload(r.Body, toolsVersions, serverRoot, st)
+	agentTools, err := h.handleUpload(r.Body, toolsVersions, serverRoot, st)
+	if err != nil {
+		return nil, err
+	}
+	return tools.List{agentTools}, nil
 }
 
 func (h *toolsUploadHandler) getServerRoot(r *http.Request, query url.Values, st *state.State) (string, error) {
@@ -302,6 +391,212 @@ func (h *toolsUploadHandler) handleUpload(r io.Reader, toolsVersions []version.B
 	return tools, nil
 }
 
+// bundleBinary holds an agent binary read from an uploaded bundle,
+// along with the versions it is to be stored as.
+type bundleBinary struct {
+	versions []version.Binary
+	path     string
+	size     int64
+	sha256   string
+}
+
+// handleBundleUpload reads a bundle of agent binaries from the reader
+// and stores them in tools storage. The bundle is a tar archive whose
+// first entry is a manifest describing the binaries in the entries
+// that follow. Each binary is written to a temporary file as it is
+// read, and every binary is checked against the manifest before any
+// is stored. If storing any binary fails, those already stored are
+// removed, so that either all or none of the bundle is registered.
+func (h *toolsUploadHandler) handleBundleUpload(r io.Reader, serverRoot string, st *state.State) (tools.List, error) {
+	dir, err := ioutil.TempDir("", "juju-tools-bundle")
+	if err != nil {
+		return nil, errors.Annotate(err, "creating temp dir")
+	}
+	defer os.RemoveAll(dir)
+	binaries, err := readToolsBundle(r, dir)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// Check if changes are allowed and the command may proceed.
+	blockChecker := common.NewBlockChecker(st)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	storage, err := st.ToolsStorage()
+	if err != nil {
+		return nil, err
+	}
+	defer storage.Close()
+
+	var added []string
+	removeAdded := func() {
+		for _, v := range added {
+			if err := storage.Remove(v); err != nil {
+				logger.Errorf("cannot remove agent binaries %s: %v", v, err)
+			}
+		}
+	}
+	storeBinary := func(binary bundleBinary) error {
+		f, err := os.Open(binary.path)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer f.Close()
+		for _, v := range binary.versions {
+			if _, err := f.Seek(0, io.SeekStart); err != nil {
+				return errors.Trace(err)
+			}
+			metadata := binarystorage.Metadata{
+				Version: v.String(),
+				Size:    binary.size,
+				SHA256:  binary.sha256,
+			}
+			logger.Debugf("uploading agent binaries %+v to storage", metadata)
+			if err := storage.Add(f, metadata); err != nil {
+				return errors.Annotatef(err, "cannot store agent binaries %s", v)
+			}
+			added = append(added, metadata.Version)
+		}
+		return nil
+	}
+	var result tools.List
+	for _, binary := range binaries {
+		if err := storeBinary(binary); err != nil {
+			removeAdded()
+			return nil, errors.Trace(err)
+		}
+		result = append(result, &tools.Tools{
+			Version: binary.versions[0],
+			Size:    binary.size,
+			SHA256:  binary.sha256,
+			URL:     common.ToolsURL(serverRoot, binary.versions[0]),
+		})
+	}
+	return result, nil
+}
+
+// readToolsBundle reads the agent binaries from a bundle into files in
+// the given directory, checking that the bundle holds exactly the
+// binaries its manifest describes, with the described sizes and
+// checksums.
+func readToolsBundle(r io.Reader, dir string) ([]bundleBinary, error) {
+	tr := tar.NewReader(r)
+	hdr, err := tr.Next()
+	if err == io.EOF {
+		return nil, errors.BadRequestf("no agent binaries uploaded")
+	} else if err != nil {
+		return nil, errors.NewBadRequest(err, "cannot read agent binaries bundle")
+	}
+	if hdr.Name != params.ToolsBundleManifestName {
+		return nil, errors.BadRequestf("expected %q as first bundle entry, got %q", params.ToolsBundleManifestName, hdr.Name)
+	}
+	var manifest params.ToolsBundleManifest
+	if err := json.NewDecoder(tr).Decode(&manifest); err != nil {
+		return nil, errors.NewBadRequest(err, "cannot parse agent binaries bundle manifest")
+	}
+	if len(manifest.Binaries) == 0 {
+		return nil, errors.BadRequestf("no agent binaries uploaded")
+	}
+
+	entries := make(map[string]int)
+	seen := set.NewStrings()
+	binaries := make([]bundleBinary, len(manifest.Binaries))
+	var totalSize int64
+	for i, entry := range manifest.Binaries {
+		if _, ok := entries[entry.Path]; ok {
+			return nil, errors.BadRequestf("duplicate bundle entry %q in manifest", entry.Path)
+		}
+		entries[entry.Path] = i
+		if entry.Size < 0 {
+			return nil, errors.BadRequestf("invalid size %d for bundle entry %q", entry.Size, entry.Path)
+		}
+		vers, err := version.ParseBinary(entry.Version)
+		if err != nil {
+			return nil, errors.NewBadRequest(err, fmt.Sprintf("invalid agent binaries version %q", entry.Version))
+		}
+		versions := []version.Binary{vers}
+		for _, series := range entry.AdditionalSeries {
+			if series != vers.Series {
+				v := vers
+				v.Series = series
+				versions = append(versions, v)
+			}
+		}
+		for _, v := range versions {
+			if seen.Contains(v.String()) {
+				return nil, errors.BadRequestf("agent binaries %s specified more than once", v)
+			}
+			seen.Add(v.String())
+		}
+		binaries[i].versions = versions
+		totalSize += entry.Size
+	}
+	if totalSize > maxToolsBundleSize {
+		return nil, errors.BadRequestf(
+			"agent binaries bundle too large: %d bytes exceeds the limit of %d", totalSize, maxToolsBundleSize)
+	}
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.NewBadRequest(err, "cannot read agent binaries bundle")
+		}
+		i, ok := entries[hdr.Name]
+		if !ok {
+			return nil, errors.BadRequestf("unexpected bundle entry %q", hdr.Name)
+		}
+		delete(entries, hdr.Name)
+		entry := manifest.Binaries[i]
+		path := filepath.Join(dir, fmt.Sprint(i))
+		size, sha256, err := writeAndHash(path, tr)
+		if err != nil {
+			return nil, errors.NewBadRequest(err, fmt.Sprintf("cannot read bundle entry %q", hdr.Name))
+		}
+		if size != entry.Size {
+			return nil, errors.BadRequestf(
+				"size mismatch for %q: expected %d, got %d", hdr.Name, entry.Size, size)
+		}
+		if sha256 != entry.SHA256 {
+			return nil, errors.BadRequestf(
+				"hash mismatch for %q: expected %s, got %s", hdr.Name, entry.SHA256, sha256)
+		}
+		binaries[i].path = path
+		binaries[i].size = size
+		binaries[i].sha256 = sha256
+	}
+	if len(entries) > 0 {
+		var missing []string
+		for path := range entries {
+			missing = append(missing, fmt.Sprintf("%q", path))
+		}
+		sort.Strings(missing)
+		return nil, errors.BadRequestf("bundle entries missing: %s", strings.Join(missing, ", "))
+	}
+	return binaries, nil
+}
+
+// writeAndHash copies the content of the reader to a new file at the
+// given path, returning its size and hex-encoded SHA256 checksum.
+func writeAndHash(path string, r io.Reader) (size int64, sha256hex string, err error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return 0, "", errors.Trace(err)
+	}
+	defer f.Close()
+	hash := sha256.New()
+	size, err = io.Copy(io.MultiWriter(f, hash), r)
+	if err != nil {
+		return 0, "", errors.Trace(err)
+	}
+	if err := f.Close(); err != nil {
+		return 0, "", errors.Trace(err)
+	}
+	return size, fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
+
 func readAndHash(r io.Reader) (data []byte, sha256hex string, err error) {
 	hash := sha256.New()
 	data, err = ioutil.ReadAll(io.TeeReader(r, hash))
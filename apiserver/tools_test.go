@@ -4,6 +4,8 @@
 package apiserver_test
 
 import (
+	"archive/tar"
+	"bytes"
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
@@ -26,6 +28,7 @@ import (
 
 	apiauthentication "github.com/juju/juju/api/authentication"
 	apitesting "github.com/juju/juju/api/testing"
+	"github.com/juju/juju/apiserver"
 	commontesting "github.com/juju/juju/apiserver/common/testing"
 	"github.com/juju/juju/apiserver/params"
 	envtesting "github.com/juju/juju/environs/testing"
@@ -71,6 +74,12 @@ func (s *toolsCommonSuite) downloadRequest(c *gc.C, version version.Binary, uuid
 	return s.sendRequest(c, httpRequestParams{method: "GET", url: url.String()})
 }
 
+func (s *toolsCommonSuite) downloadRangeRequest(c *gc.C, version version.Binary, headers map[string]string) *http.Response {
+	url := s.toolsURL(c, "")
+	url.Path = fmt.Sprintf("/model/%s/tools/%s", s.State.ModelUUID(), version)
+	return s.sendRequest(c, httpRequestParams{method: "GET", url: url.String(), extraHeaders: headers})
+}
+
 func (s *toolsCommonSuite) assertUploadResponse(c *gc.C, resp *http.Response, agentTools *coretools.Tools) {
 	toolsResponse := s.assertResponse(c, resp, http.StatusOK)
 	c.Check(toolsResponse.Error, gc.IsNil)
@@ -382,6 +391,200 @@ func (s *toolsSuite) TestUploadSeriesExpanded(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+type bundleEntry struct {
+	vers             string
+	additionalSeries []string
+	data             string
+}
+
+// makeToolsBundle returns an agent binaries bundle holding the given
+// entries, with a manifest describing them, adjusted by fixManifest
+// if it is not nil.
+func makeToolsBundle(c *gc.C, entries []bundleEntry, fixManifest func(*params.ToolsBundleManifest)) []byte {
+	var manifest params.ToolsBundleManifest
+	for _, entry := range entries {
+		manifest.Binaries = append(manifest.Binaries, params.ToolsBundleBinary{
+			Version:          entry.vers,
+			AdditionalSeries: entry.additionalSeries,
+			Path:             entry.vers + ".tgz",
+			Size:             int64(len(entry.data)),
+			SHA256:           fmt.Sprintf("%x", sha256.Sum256([]byte(entry.data))),
+		})
+	}
+	manifestData, err := json.Marshal(manifest)
+	c.Assert(err, jc.ErrorIsNil)
+	if fixManifest != nil {
+		fixManifest(&manifest)
+		manifestData, err = json.Marshal(manifest)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	writeEntry := func(name string, data []byte) {
+		err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(data))})
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = tw.Write(data)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	writeEntry(params.ToolsBundleManifestName, manifestData)
+	for _, entry := range entries {
+		writeEntry(entry.vers+".tgz", []byte(entry.data))
+	}
+	c.Assert(tw.Close(), jc.ErrorIsNil)
+	return buf.Bytes()
+}
+
+func (s *toolsSuite) uploadBundle(c *gc.C, bundle []byte) *http.Response {
+	return s.authRequest(c, httpRequestParams{
+		method:      "POST",
+		url:         s.toolsURI(c, ""),
+		contentType: params.ContentTypeToolsBundle,
+		body:        bytes.NewReader(bundle),
+	})
+}
+
+func (s *toolsSuite) TestUploadBundle(c *gc.C) {
+	bundle := makeToolsBundle(c, []bundleEntry{{
+		vers: "2.4.0-xenial-amd64",
+		data: "amd64 agent",
+	}, {
+		vers:             "2.4.0-xenial-arm64",
+		additionalSeries: []string{"bionic"},
+		data:             "arm64 agent",
+	}}, nil)
+	resp := s.uploadBundle(c, bundle)
+	toolsResponse := s.assertResponse(c, resp, http.StatusOK)
+	c.Assert(toolsResponse.Error, gc.IsNil)
+	c.Assert(toolsResponse.ToolsList, gc.HasLen, 2)
+	c.Check(toolsResponse.ToolsList[0].Version.String(), gc.Equals, "2.4.0-xenial-amd64")
+	c.Check(toolsResponse.ToolsList[0].URL, gc.Equals,
+		fmt.Sprintf("%s/model/%s/tools/2.4.0-xenial-amd64", s.baseURL(c), s.State.ModelUUID()))
+	c.Check(toolsResponse.ToolsList[1].Version.String(), gc.Equals, "2.4.0-xenial-arm64")
+	c.Check(toolsResponse.ToolsList[1].Size, gc.Equals, int64(len("arm64 agent")))
+
+	for vers, expected := range map[string]string{
+		"2.4.0-xenial-amd64": "amd64 agent",
+		"2.4.0-xenial-arm64": "arm64 agent",
+		"2.4.0-bionic-arm64": "arm64 agent",
+	} {
+		metadata, data := s.getToolsFromStorage(c, s.State, vers)
+		c.Check(string(data), gc.Equals, expected)
+		c.Check(metadata.SHA256, gc.Equals, fmt.Sprintf("%x", sha256.Sum256([]byte(expected))))
+	}
+}
+
+func (s *toolsSuite) TestUploadBundleHashMismatch(c *gc.C) {
+	bundle := makeToolsBundle(c, []bundleEntry{{
+		vers: "2.4.0-xenial-amd64",
+		data: "amd64 agent",
+	}, {
+		vers: "2.4.0-xenial-arm64",
+		data: "arm64 agent",
+	}}, func(manifest *params.ToolsBundleManifest) {
+		manifest.Binaries[1].SHA256 = "deadbeef"
+	})
+	resp := s.uploadBundle(c, bundle)
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, `hash mismatch for "2.4.0-xenial-arm64.tgz": expected deadbeef, got .*`)
+	s.assertToolsNotStored(c, "2.4.0-xenial-amd64")
+	s.assertToolsNotStored(c, "2.4.0-xenial-arm64")
+}
+
+func (s *toolsSuite) TestUploadBundleSizeMismatch(c *gc.C) {
+	bundle := makeToolsBundle(c, []bundleEntry{{
+		vers: "2.4.0-xenial-amd64",
+		data: "amd64 agent",
+	}}, func(manifest *params.ToolsBundleManifest) {
+		manifest.Binaries[0].Size = 1
+	})
+	resp := s.uploadBundle(c, bundle)
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, `size mismatch for "2.4.0-xenial-amd64.tgz": expected 1, got 11`)
+	s.assertToolsNotStored(c, "2.4.0-xenial-amd64")
+}
+
+func (s *toolsSuite) TestUploadBundleMissingEntry(c *gc.C) {
+	bundle := makeToolsBundle(c, []bundleEntry{{
+		vers: "2.4.0-xenial-amd64",
+		data: "amd64 agent",
+	}}, func(manifest *params.ToolsBundleManifest) {
+		manifest.Binaries = append(manifest.Binaries, params.ToolsBundleBinary{
+			Version: "2.4.0-xenial-s390x",
+			Path:    "2.4.0-xenial-s390x.tgz",
+		})
+	})
+	resp := s.uploadBundle(c, bundle)
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, `bundle entries missing: "2.4.0-xenial-s390x.tgz"`)
+	s.assertToolsNotStored(c, "2.4.0-xenial-amd64")
+}
+
+func (s *toolsSuite) TestUploadBundleDuplicateVersion(c *gc.C) {
+	bundle := makeToolsBundle(c, []bundleEntry{{
+		vers:             "2.4.0-xenial-amd64",
+		additionalSeries: []string{"bionic"},
+		data:             "amd64 agent",
+	}, {
+		vers: "2.4.0-bionic-amd64",
+		data: "other amd64 agent",
+	}}, nil)
+	resp := s.uploadBundle(c, bundle)
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, `agent binaries 2.4.0-bionic-amd64 specified more than once`)
+}
+
+func (s *toolsSuite) TestUploadBundleManifestFirst(c *gc.C) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	err := tw.WriteHeader(&tar.Header{Name: "juju.tgz", Mode: 0644, Size: 4})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = tw.Write([]byte("data"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tw.Close(), jc.ErrorIsNil)
+
+	resp := s.uploadBundle(c, buf.Bytes())
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, `expected "manifest.json" as first bundle entry, got "juju.tgz"`)
+}
+
+func (s *toolsSuite) TestUploadBundleTooLarge(c *gc.C) {
+	s.PatchValue(apiserver.MaxToolsBundleSize, int64(4096))
+	bundle := makeToolsBundle(c, []bundleEntry{{
+		vers: "2.4.0-xenial-amd64",
+		data: "amd64 agent",
+	}, {
+		vers: "2.4.0-xenial-arm64",
+		data: "arm64 agent",
+	}}, func(manifest *params.ToolsBundleManifest) {
+		manifest.Binaries[0].Size = 2048
+		manifest.Binaries[1].Size = 4096
+	})
+	resp := s.uploadBundle(c, bundle)
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, `agent binaries bundle too large: 6144 bytes exceeds the limit of 4096`)
+	s.assertToolsNotStored(c, "2.4.0-xenial-amd64")
+}
+
+func (s *toolsSuite) TestUploadBundleRequestTooLarge(c *gc.C) {
+	s.PatchValue(apiserver.MaxToolsBundleSize, int64(4096))
+	bundle := makeToolsBundle(c, []bundleEntry{{
+		vers: "2.4.0-xenial-amd64",
+		data: strings.Repeat("x", 8192),
+	}}, func(manifest *params.ToolsBundleManifest) {
+		manifest.Binaries[0].Size = 1
+	})
+	resp := s.uploadBundle(c, bundle)
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, `cannot read bundle entry "2.4.0-xenial-amd64.tgz": .*request body too large`)
+	s.assertToolsNotStored(c, "2.4.0-xenial-amd64")
+}
+
+func (s *toolsSuite) TestBlockUploadBundle(c *gc.C) {
+	bundle := makeToolsBundle(c, []bundleEntry{{
+		vers: "2.4.0-xenial-amd64",
+		data: "amd64 agent",
+	}}, nil)
+	s.BlockAllChanges(c, "TestBlockUploadBundle")
+	resp := s.uploadBundle(c, bundle)
+	toolsResponse := s.assertResponse(c, resp, http.StatusBadRequest)
+	s.AssertBlocked(c, toolsResponse.Error, "TestBlockUploadBundle")
+	s.assertToolsNotStored(c, "2.4.0-xenial-amd64")
+}
+
 func (s *toolsSuite) TestDownloadModelUUIDPath(c *gc.C) {
 	v := version.Binary{
 		Number: jujuversion.Current,
@@ -425,6 +628,81 @@ func (s *toolsSuite) TestDownloadTopLevelPath(c *gc.C) {
 	s.testDownload(c, tools, "")
 }
 
+func (s *toolsSuite) storeRangeTestTools(c *gc.C) *coretools.Tools {
+	v := version.Binary{
+		Number: jujuversion.Current,
+		Arch:   arch.HostArch(),
+		Series: series.MustHostSeries(),
+	}
+	return s.storeFakeTools(c, s.State, "abcdef", binarystorage.Metadata{
+		Version: v.String(),
+		Size:    6,
+		SHA256:  "bef57ec7f53a6d40beb640a780a639c83bc29ac8a9816f1fc6c5c6dcd93c4721",
+	})
+}
+
+func (s *toolsSuite) TestDownloadSendsHash(c *gc.C) {
+	tools := s.storeRangeTestTools(c)
+	resp := s.downloadRequest(c, tools.Version, s.State.ModelUUID())
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusOK)
+	c.Assert(resp.Header.Get("Accept-Ranges"), gc.Equals, "bytes")
+	c.Assert(resp.Header.Get("ETag"), gc.Equals, `"`+tools.SHA256+`"`)
+	c.Assert(resp.Header.Get("Digest"), gc.Equals, params.EncodeChecksum(tools.SHA256))
+	c.Assert(resp.ContentLength, gc.Equals, tools.Size)
+}
+
+func (s *toolsSuite) TestDownloadRange(c *gc.C) {
+	tools := s.storeRangeTestTools(c)
+	resp := s.downloadRangeRequest(c, tools.Version, map[string]string{
+		"Range":    "bytes=2-",
+		"If-Range": `"` + tools.SHA256 + `"`,
+	})
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusPartialContent)
+	c.Assert(resp.Header.Get("Content-Range"), gc.Equals, "bytes 2-5/6")
+	data, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "cdef")
+}
+
+func (s *toolsSuite) TestDownloadRangeChangedTools(c *gc.C) {
+	// A range of some other tarball is answered with the whole of
+	// this one.
+	tools := s.storeRangeTestTools(c)
+	resp := s.downloadRangeRequest(c, tools.Version, map[string]string{
+		"Range":    "bytes=2-",
+		"If-Range": `"0123"`,
+	})
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusOK)
+	data, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "abcdef")
+}
+
+func (s *toolsSuite) TestDownloadUnsupportedRange(c *gc.C) {
+	tools := s.storeRangeTestTools(c)
+	resp := s.downloadRangeRequest(c, tools.Version, map[string]string{
+		"Range": "bytes=0-1,3-4",
+	})
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusOK)
+	data, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "abcdef")
+}
+
+func (s *toolsSuite) TestDownloadRangeNotSatisfiable(c *gc.C) {
+	tools := s.storeRangeTestTools(c)
+	resp := s.downloadRangeRequest(c, tools.Version, map[string]string{
+		"Range": "bytes=6-",
+	})
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusRequestedRangeNotSatisfiable)
+	c.Assert(resp.Header.Get("Content-Range"), gc.Equals, "bytes */6")
+}
+
 func (s *toolsSuite) TestDownloadFetchesAndCaches(c *gc.C) {
 	// The tools are not in binarystorage, so the download request causes
 	// the API server to search for the tools in simplestreams, fetch
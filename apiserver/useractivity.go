@@ -0,0 +1,150 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/observer"
+	"github.com/juju/juju/rpc"
+)
+
+// userActivityFlushInterval is how often the API activity recorded by
+// a server is added to the activity recorded in the database.
+const userActivityFlushInterval = time.Minute
+
+// userActivityBackend records users' API activity in the database.
+type userActivityBackend interface {
+	RecordModelUserActivity(modelUUID string, user names.UserTag, calls int64, lastAccess time.Time) error
+}
+
+// userActivityRecorder counts the API calls that users make to each
+// model through the server, and when they last made one, so that
+// dormant users and noisy automation accounts can be identified. The
+// counts are held in memory and periodically flushed to the database,
+// where those of all the controller's API servers are summed.
+type userActivityRecorder struct {
+	clock clock.Clock
+
+	mu      sync.Mutex
+	pending map[userActivityKey]*userActivity
+}
+
+// userActivityKey identifies a user of a model.
+type userActivityKey struct {
+	model string
+	user  names.UserTag
+}
+
+// userActivity holds the API activity of a user of a model since the
+// last flush.
+type userActivity struct {
+	calls      int64
+	lastAccess time.Time
+}
+
+func newUserActivityRecorder(clock clock.Clock) *userActivityRecorder {
+	return &userActivityRecorder{
+		clock:   clock,
+		pending: make(map[userActivityKey]*userActivity),
+	}
+}
+
+// record counts an API call made by a user to a model.
+func (r *userActivityRecorder) record(key userActivityKey) {
+	now := r.clock.Now()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	activity, ok := r.pending[key]
+	if !ok {
+		activity = &userActivity{}
+		r.pending[key] = activity
+	}
+	activity.calls++
+	activity.lastAccess = now
+}
+
+// flush adds the activity recorded since the last flush to that
+// recorded in the database. The activity is informational, so any
+// that cannot be recorded is logged and dropped.
+func (r *userActivityRecorder) flush(backend userActivityBackend) {
+	r.mu.Lock()
+	pending := r.pending
+	r.pending = make(map[userActivityKey]*userActivity)
+	r.mu.Unlock()
+
+	for key, activity := range pending {
+		err := backend.RecordModelUserActivity(key.model, key.user, activity.calls, activity.lastAccess)
+		if err != nil {
+			logger.Warningf("cannot record API activity of %q on model %q: %v", key.user.Id(), key.model, err)
+		}
+	}
+}
+
+// newObserver returns an observer that records the API calls made over
+// a connection, if it turns out to be from a user.
+func (r *userActivityRecorder) newObserver() observer.Observer {
+	return &userActivityObserver{recorder: r}
+}
+
+// userActivityObserver records the API calls made by a user over a
+// connection in a userActivityRecorder.
+type userActivityObserver struct {
+	recorder *userActivityRecorder
+
+	// mu guards key, which is set once a user has logged in over
+	// the connection.
+	mu  sync.Mutex
+	key *userActivityKey
+}
+
+// Join is part of the observer.Observer interface.
+func (o *userActivityObserver) Join(*http.Request, uint64) {}
+
+// Login is part of the observer.Observer interface.
+func (o *userActivityObserver) Login(entity names.Tag, model names.ModelTag, fromController bool, _ string) {
+	user, ok := entity.(names.UserTag)
+	if !ok || fromController {
+		return
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.key = &userActivityKey{model: model.Id(), user: user}
+}
+
+// Leave is part of the observer.Observer interface.
+func (o *userActivityObserver) Leave() {}
+
+// RPCObserver is part of the observer.Observer interface.
+func (o *userActivityObserver) RPCObserver() rpc.Observer {
+	return userRPCObserver{o}
+}
+
+// userRPCObserver counts the requests made over a user's connection.
+type userRPCObserver struct {
+	o *userActivityObserver
+}
+
+// ServerRequest is part of the rpc.Observer interface.
+func (r userRPCObserver) ServerRequest(hdr *rpc.Header, _ interface{}) {
+	// Clients ping idle connections to keep them alive; the pings
+	// are not activity.
+	if hdr.Request.Type == "Pinger" {
+		return
+	}
+	r.o.mu.Lock()
+	key := r.o.key
+	r.o.mu.Unlock()
+	if key != nil {
+		r.o.recorder.record(*key)
+	}
+}
+
+// ServerReply is part of the rpc.Observer interface.
+func (userRPCObserver) ServerReply(rpc.Request, *rpc.Header, interface{}) {}
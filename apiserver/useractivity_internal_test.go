@@ -0,0 +1,100 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/rpc"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type userActivityRecorderSuite struct {
+	coretesting.BaseSuite
+	clock    *testing.Clock
+	recorder *userActivityRecorder
+	backend  *mockUserActivityBackend
+}
+
+var _ = gc.Suite(&userActivityRecorderSuite{})
+
+func (s *userActivityRecorderSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC))
+	s.recorder = newUserActivityRecorder(s.clock)
+	s.backend = &mockUserActivityBackend{}
+}
+
+func (s *userActivityRecorderSuite) connect(entity names.Tag, fromController bool) rpc.Observer {
+	o := s.recorder.newObserver()
+	o.Join(&http.Request{}, 1)
+	o.Login(entity, coretesting.ModelTag, fromController, "")
+	return o.RPCObserver()
+}
+
+func (s *userActivityRecorderSuite) TestRecordsUserCalls(c *gc.C) {
+	bob := names.NewUserTag("bob")
+	o := s.connect(bob, false)
+	o.ServerRequest(&rpc.Header{Request: rpc.Request{Type: "Client", Action: "FullStatus"}}, nil)
+	s.clock.Advance(time.Minute)
+	o.ServerRequest(&rpc.Header{Request: rpc.Request{Type: "Application", Action: "Deploy"}}, nil)
+	o.ServerRequest(&rpc.Header{Request: rpc.Request{Type: "Pinger", Action: "Ping"}}, nil)
+
+	s.recorder.flush(s.backend)
+	s.backend.CheckCalls(c, []testing.StubCall{{
+		"RecordModelUserActivity",
+		[]interface{}{coretesting.ModelTag.Id(), bob, int64(2), s.clock.Now()},
+	}})
+
+	// Flushed activity is not recorded again.
+	s.backend.ResetCalls()
+	s.recorder.flush(s.backend)
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *userActivityRecorderSuite) TestIgnoresAgentsAndController(c *gc.C) {
+	request := &rpc.Header{Request: rpc.Request{Type: "Uniter", Action: "Life"}}
+	s.connect(names.NewMachineTag("0"), false).ServerRequest(request, nil)
+	s.connect(names.NewUserTag("admin"), true).ServerRequest(request, nil)
+
+	s.recorder.flush(s.backend)
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *userActivityRecorderSuite) TestIgnoresCallsBeforeLogin(c *gc.C) {
+	o := s.recorder.newObserver()
+	o.Join(&http.Request{}, 1)
+	o.RPCObserver().ServerRequest(&rpc.Header{Request: rpc.Request{Type: "Admin", Action: "Login"}}, nil)
+
+	s.recorder.flush(s.backend)
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *userActivityRecorderSuite) TestFlushErrorDropsActivity(c *gc.C) {
+	bob := names.NewUserTag("bob")
+	s.connect(bob, false).ServerRequest(&rpc.Header{Request: rpc.Request{Type: "Client", Action: "FullStatus"}}, nil)
+	s.backend.SetErrors(errors.New("kaboom"))
+
+	s.recorder.flush(s.backend)
+	s.backend.CheckCallNames(c, "RecordModelUserActivity")
+	s.backend.ResetCalls()
+	s.recorder.flush(s.backend)
+	s.backend.CheckNoCalls(c)
+}
+
+type mockUserActivityBackend struct {
+	testing.Stub
+}
+
+func (b *mockUserActivityBackend) RecordModelUserActivity(modelUUID string, user names.UserTag, calls int64, lastAccess time.Time) error {
+	b.MethodCall(b, "RecordModelUserActivity", modelUUID, user, calls, lastAccess)
+	return b.NextErr()
+}
@@ -64,6 +64,8 @@ func newWatcherCommon(context facade.Context) watcherCommon {
 }
 
 // Stop stops the watcher.
+//
+//juju:readonly
 func (w *watcherCommon) Stop() error {
 	w.dispose()
 	return w.resources.Stop(w.id)
@@ -78,6 +80,7 @@ type SrvAllWatcher struct {
 	watcher *state.Multiwatcher
 }
 
+//juju:readonly
 func (aw *SrvAllWatcher) Next() (params.AllWatcherNextResults, error) {
 	deltas, err := aw.watcher.Next()
 	return params.AllWatcherNextResults{
@@ -101,7 +104,11 @@ func newNotifyWatcher(context facade.Context) (facade.Facade, error) {
 	auth := context.Auth()
 	resources := context.Resources()
 
-	if auth.GetAuthTag() != nil && !isAgent(auth) {
+	// Clients may follow NotifyWatchers too, such as those created
+	// by ModelManager.WatchDestroyProgress. As with the AllWatcher,
+	// the watcher resource can only exist if the call that created
+	// it passed its permission checks.
+	if auth.GetAuthTag() != nil && !isAgent(auth) && !auth.AuthClient() {
 		return nil, common.ErrPerm
 	}
 	watcher, ok := resources.Get(id).(state.NotifyWatcher)
@@ -117,6 +124,8 @@ func newNotifyWatcher(context facade.Context) (facade.Facade, error) {
 // Next returns when a change has occurred to the
 // entity being watched since the most recent call to Next
 // or the Watch call that created the NotifyWatcher.
+//
+//juju:readonly
 func (w *srvNotifyWatcher) Next() error {
 	if _, ok := <-w.watcher.Changes(); ok {
 		return nil
@@ -160,6 +169,8 @@ func newStringsWatcher(context facade.Context) (facade.Facade, error) {
 // Next returns when a change has occured to an entity of the
 // collection being watched since the most recent call to Next
 // or the Watch call that created the srvStringsWatcher.
+//
+//juju:readonly
 func (w *srvStringsWatcher) Next() (params.StringsWatchResult, error) {
 	if changes, ok := <-w.watcher.Changes(); ok {
 		return params.StringsWatchResult{
@@ -204,6 +215,8 @@ func newRelationUnitsWatcher(context facade.Context) (facade.Facade, error) {
 // Next returns when a change has occured to an entity of the
 // collection being watched since the most recent call to Next
 // or the Watch call that created the srvRelationUnitsWatcher.
+//
+//juju:readonly
 func (w *srvRelationUnitsWatcher) Next() (params.RelationUnitsWatchResult, error) {
 	if changes, ok := <-w.watcher.Changes(); ok {
 		return params.RelationUnitsWatchResult{
@@ -248,6 +261,8 @@ func newRelationStatusWatcher(context facade.Context) (facade.Facade, error) {
 // Next returns when a change has occured to an entity of the
 // collection being watched since the most recent call to Next
 // or the Watch call that created the srvRelationStatusWatcher.
+//
+//juju:readonly
 func (w *srvRelationStatusWatcher) Next() (params.RelationLifeSuspendedStatusWatchResult, error) {
 	if changes, ok := <-w.watcher.Changes(); ok {
 		changesParams := make([]params.RelationLifeSuspendedStatusChange, len(changes))
@@ -302,6 +317,8 @@ func newOfferStatusWatcher(context facade.Context) (facade.Facade, error) {
 // Next returns when a change has occured to an entity of the
 // collection being watched since the most recent call to Next
 // or the Watch call that created the srvOfferStatusWatcher.
+//
+//juju:readonly
 func (w *srvOfferStatusWatcher) Next() (params.OfferStatusWatchResult, error) {
 	if _, ok := <-w.watcher.Changes(); ok {
 		change, err := crossmodel.GetOfferStatusChange(crossmodel.GetBackend(w.st), w.watcher.OfferUUID())
@@ -372,6 +389,8 @@ func newMachineStorageIdsWatcher(
 // Next returns when a change has occured to an entity of the
 // collection being watched since the most recent call to Next
 // or the Watch call that created the srvMachineStorageIdsWatcher.
+//
+//juju:readonly
 func (w *srvMachineStorageIdsWatcher) Next() (params.MachineStorageIdsWatchResult, error) {
 	if stringChanges, ok := <-w.watcher.Changes(); ok {
 		changes, err := w.parser(stringChanges)
@@ -432,6 +451,8 @@ func newEntitiesWatcher(context facade.Context) (facade.Facade, error) {
 // Next returns when a change has occured to an entity of the
 // collection being watched since the most recent call to Next
 // or the Watch call that created the srvEntitiesWatcher.
+//
+//juju:readonly
 func (w *srvEntitiesWatcher) Next() (params.EntitiesWatchResult, error) {
 	if changes, ok := <-w.watcher.Changes(); ok {
 		mapped, err := w.watcher.MapChanges(changes)
@@ -449,6 +470,56 @@ func (w *srvEntitiesWatcher) Next() (params.EntitiesWatchResult, error) {
 	return params.EntitiesWatchResult{}, err
 }
 
+// srvEntityChangesWatcher defines the API for methods on a
+// common.EntityChangesWatcher. Each client has its own current set of
+// watchers, stored in resources. srvEntityChangesWatcher notifies about
+// changes to entities, describing each changed entity's kind and life
+// so that clients need not read them separately.
+type srvEntityChangesWatcher struct {
+	watcherCommon
+	watcher common.EntityChangesWatcher
+}
+
+func newEntityChangesWatcher(context facade.Context) (facade.Facade, error) {
+	id := context.ID()
+	auth := context.Auth()
+	resources := context.Resources()
+
+	if !isAgent(auth) {
+		return nil, common.ErrPerm
+	}
+	watcher, ok := resources.Get(id).(common.EntityChangesWatcher)
+	if !ok {
+		return nil, common.ErrUnknownWatcher
+	}
+	return &srvEntityChangesWatcher{
+		watcherCommon: newWatcherCommon(context),
+		watcher:       watcher,
+	}, nil
+}
+
+// Next returns when a change has occurred to an entity of the
+// collection being watched since the most recent call to Next
+// or the Watch call that created the srvEntityChangesWatcher.
+//
+//juju:readonly
+func (w *srvEntityChangesWatcher) Next() (params.EntityChangesWatchResult, error) {
+	if ids, ok := <-w.watcher.Changes(); ok {
+		changes, err := w.watcher.DescribeChanges(ids)
+		if err != nil {
+			return params.EntityChangesWatchResult{}, errors.Annotate(err, "cannot describe changes")
+		}
+		return params.EntityChangesWatchResult{
+			Changes: changes,
+		}, nil
+	}
+	err := w.watcher.Err()
+	if err == nil {
+		err = common.ErrStoppedWatcher
+	}
+	return params.EntityChangesWatchResult{}, err
+}
+
 var getMigrationBackend = func(st *state.State) migrationBackend {
 	return st
 }
@@ -490,6 +561,8 @@ type srvMigrationStatusWatcher struct {
 // Next returns when the status for a model migration for the
 // associated model changes. The current details for the active
 // migration are returned.
+//
+//juju:readonly
 func (w *srvMigrationStatusWatcher) Next() (params.MigrationStatus, error) {
 	empty := params.MigrationStatus{}
 
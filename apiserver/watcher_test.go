@@ -154,6 +154,22 @@ func (s *watcherSuite) TestMigrationStatusWatcherNotAgent(c *gc.C) {
 	c.Assert(err, gc.Equals, common.ErrPerm)
 }
 
+func (s *watcherSuite) TestNotifyWatcherClient(c *gc.C) {
+	w := apiservertesting.NewFakeNotifyWatcher()
+	id := s.resources.Register(w)
+	s.authorizer.Tag = names.NewUserTag("frogdog")
+
+	facade := s.getFacade(c, "NotifyWatcher", 1, id, nopDispose).(notifyWatcher)
+	defer c.Check(facade.Stop(), jc.ErrorIsNil)
+	err := facade.Next()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+type notifyWatcher interface {
+	Next() error
+	Stop() error
+}
+
 type machineStorageIdsWatcher interface {
 	Next() (params.MachineStorageIdsWatchResult, error)
 }
@@ -45,6 +45,8 @@ var websocketUpgrader = websocket.Upgrader{
 	// fragmentation, we default to largeish frames.
 	ReadBufferSize:  websocketFrameSize,
 	WriteBufferSize: websocketFrameSize,
+	// Compression is only used if the client asks for it.
+	EnableCompression: true,
 }
 
 // Conn wraps a gorilla/websocket.Conn, providing additional Juju-specific
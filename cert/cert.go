@@ -4,6 +4,7 @@
 package cert
 
 import (
+	"crypto/tls"
 	"crypto/x509"
 	"fmt"
 	"time"
@@ -33,6 +34,44 @@ func Verify(srvCertPEM, caCertPEM string, when time.Time) error {
 	return err
 }
 
+// ParseServerChain parses a PEM-encoded server certificate chain, the
+// leaf certificate first, and the leaf's PEM-encoded private key. It
+// returns an error if the key does not match the leaf certificate, if
+// the leaf is not valid at the given time or for any names, or if any
+// certificate in the chain is not signed by the one following it. The
+// Leaf field of the returned certificate is populated.
+func ParseServerChain(chainPEM, keyPEM string, when time.Time) (*tls.Certificate, error) {
+	tlsCert, err := tls.X509KeyPair([]byte(chainPEM), []byte(keyPEM))
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot parse certificate chain and key")
+	}
+	chain := make([]*x509.Certificate, len(tlsCert.Certificate))
+	for i, der := range tlsCert.Certificate {
+		if chain[i], err = x509.ParseCertificate(der); err != nil {
+			return nil, errors.Annotatef(err, "cannot parse certificate %d in chain", i)
+		}
+	}
+	leaf := chain[0]
+	if when.Before(leaf.NotBefore) || when.After(leaf.NotAfter) {
+		return nil, errors.Errorf(
+			"certificate is not valid at %s (valid from %s until %s)",
+			when.UTC().Format(time.RFC3339),
+			leaf.NotBefore.UTC().Format(time.RFC3339),
+			leaf.NotAfter.UTC().Format(time.RFC3339),
+		)
+	}
+	if len(leaf.DNSNames) == 0 && len(leaf.IPAddresses) == 0 {
+		return nil, errors.New("certificate is not valid for any DNS names or IP addresses")
+	}
+	for i := 0; i < len(chain)-1; i++ {
+		if err := chain[i].CheckSignatureFrom(chain[i+1]); err != nil {
+			return nil, errors.Annotatef(err, "certificate %d in chain is not signed by certificate %d", i, i+1)
+		}
+	}
+	tlsCert.Leaf = leaf
+	return &tlsCert, nil
+}
+
 // NewLeafKeyBits is the number of bits used for the cert.NewLeaf call.
 var NewLeafKeyBits = 2048
 
@@ -252,6 +252,57 @@ func (certSuite) TestNewServerHostnames(c *gc.C) {
 	}
 }
 
+func (certSuite) TestParseServerChain(c *gc.C) {
+	now := time.Now()
+	caCert, caKey, err := cert.NewCA("foo", "1", now.Add(time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	srvCertPEM, srvKeyPEM, err := cert.NewServer(caCert, caKey, now.Add(time.Hour), []string{"api.example.com"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	tlsCert, err := cert.ParseServerChain(srvCertPEM+caCert, srvKeyPEM, now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tlsCert.Certificate, gc.HasLen, 2)
+	c.Assert(tlsCert.Leaf.DNSNames, jc.DeepEquals, []string{"api.example.com"})
+
+	_, err = cert.ParseServerChain(srvCertPEM, srvKeyPEM, now.Add(2*time.Hour))
+	c.Assert(err, gc.ErrorMatches, "certificate is not valid at .*")
+}
+
+func (certSuite) TestParseServerChainMismatchedKey(c *gc.C) {
+	expiry := time.Now().Add(time.Hour)
+	caCert, caKey, err := cert.NewCA("foo", "1", expiry)
+	c.Assert(err, jc.ErrorIsNil)
+	srvCertPEM, _, err := cert.NewServer(caCert, caKey, expiry, []string{"api.example.com"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, otherKeyPEM, err := cert.NewServer(caCert, caKey, expiry, []string{"api.example.com"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = cert.ParseServerChain(srvCertPEM, otherKeyPEM, time.Now())
+	c.Assert(err, gc.ErrorMatches, "cannot parse certificate chain and key: .*")
+}
+
+func (certSuite) TestParseServerChainNoNames(c *gc.C) {
+	caCert, caKey, err := cert.NewCA("foo", "1", time.Now().Add(time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	srvCertPEM, srvKeyPEM, err := cert.NewServer(caCert, caKey, time.Now().Add(time.Hour), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = cert.ParseServerChain(srvCertPEM, srvKeyPEM, time.Now())
+	c.Assert(err, gc.ErrorMatches, "certificate is not valid for any DNS names or IP addresses")
+}
+
+func (certSuite) TestParseServerChainWrongIssuer(c *gc.C) {
+	now := time.Now()
+	caCert, caKey, err := cert.NewCA("foo", "1", now.Add(time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	srvCertPEM, srvKeyPEM, err := cert.NewServer(caCert, caKey, now.Add(time.Hour), []string{"api.example.com"})
+	c.Assert(err, jc.ErrorIsNil)
+	otherCACertPEM, _, err := cert.NewCA("bar", "1", now.Add(time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = cert.ParseServerChain(srvCertPEM+otherCACertPEM, srvKeyPEM, now)
+	c.Assert(err, gc.ErrorMatches, "certificate 0 in chain is not signed by certificate 1: .*")
+}
+
 // roundTime returns t rounded to the previous whole second.
 func roundTime(t time.Time) time.Time {
 	return t.Add(time.Duration(-t.Nanosecond()))
@@ -0,0 +1,171 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package agentmetadata defines the metadata passed, in place of
+// cloud-init user data, to machines whose images have a machine agent
+// installed. The agent reads the metadata from the provider's metadata
+// service and uses it to configure and start itself, so that the
+// machine need not download and install the agent when it starts.
+package agentmetadata
+
+import (
+	"bytes"
+	"encoding/base64"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// Header is the first line of serialized agent metadata, which
+// distinguishes it from other user data.
+const Header = "#juju-agent-metadata\n"
+
+// Metadata holds the information a preinstalled machine agent needs
+// to enlist its machine with the controller.
+type Metadata struct {
+	MachineId       string                    `yaml:"machine-id"`
+	Nonce           string                    `yaml:"nonce"`
+	Controller      string                    `yaml:"controller"`
+	Model           string                    `yaml:"model"`
+	APIAddresses    []string                  `yaml:"api-addresses"`
+	CACert          string                    `yaml:"ca-cert"`
+	Password        string                    `yaml:"password"`
+	Series          string                    `yaml:"series"`
+	Jobs            []multiwatcher.MachineJob `yaml:"jobs"`
+	Values          map[string]string         `yaml:"values,omitempty"`
+	DataDir         string                    `yaml:"data-dir"`
+	LogDir          string                    `yaml:"log-dir"`
+	MetricsSpoolDir string                    `yaml:"metrics-spool-dir"`
+	ServiceName     string                    `yaml:"service-name"`
+}
+
+// New returns the agent metadata for the machine with the given
+// instance configuration. Controller machines cannot be enlisted
+// from agent metadata.
+func New(icfg *instancecfg.InstanceConfig) (*Metadata, error) {
+	if icfg.Controller != nil {
+		return nil, errors.NotSupportedf("agent metadata for controller machines")
+	}
+	if icfg.APIInfo == nil {
+		return nil, errors.NotValidf("missing API info")
+	}
+	md := &Metadata{
+		MachineId:       icfg.MachineId,
+		Nonce:           icfg.MachineNonce,
+		Controller:      icfg.ControllerTag.Id(),
+		Model:           icfg.APIInfo.ModelTag.Id(),
+		APIAddresses:    icfg.APIHostAddrs(),
+		CACert:          icfg.APIInfo.CACert,
+		Password:        icfg.APIInfo.Password,
+		Series:          icfg.Series,
+		Jobs:            icfg.Jobs,
+		Values:          icfg.AgentEnvironment,
+		DataDir:         icfg.DataDir,
+		LogDir:          icfg.LogDir,
+		MetricsSpoolDir: icfg.MetricsSpoolDir,
+		ServiceName:     icfg.MachineAgentServiceName,
+	}
+	if err := md.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return md, nil
+}
+
+// Validate returns an error if the metadata is not sufficient for an
+// agent to enlist its machine.
+func (md *Metadata) Validate() error {
+	if !names.IsValidMachine(md.MachineId) {
+		return errors.NotValidf("machine id %q", md.MachineId)
+	}
+	if md.Nonce == "" {
+		return errors.NotValidf("empty nonce")
+	}
+	if !names.IsValidController(md.Controller) {
+		return errors.NotValidf("controller UUID %q", md.Controller)
+	}
+	if !names.IsValidModel(md.Model) {
+		return errors.NotValidf("model UUID %q", md.Model)
+	}
+	if len(md.APIAddresses) == 0 {
+		return errors.NotValidf("empty API addresses")
+	}
+	if md.CACert == "" {
+		return errors.NotValidf("empty CA certificate")
+	}
+	if md.DataDir == "" || md.LogDir == "" {
+		return errors.NotValidf("empty data or log directory")
+	}
+	if md.ServiceName == "" {
+		return errors.NotValidf("empty service name")
+	}
+	return nil
+}
+
+// Marshal serializes the metadata so that it can be passed to the
+// machine as user data.
+func (md *Metadata) Marshal() ([]byte, error) {
+	data, err := yaml.Marshal(md)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append([]byte(Header), data...), nil
+}
+
+// Parse returns the agent metadata serialized in the given user data,
+// which may have been gzipped and base64 encoded by the provider's
+// user data renderer. It returns an error satisfying errors.IsNotValid
+// if the user data does not hold agent metadata.
+func Parse(data []byte) (*Metadata, error) {
+	data = bytes.TrimSpace(data)
+	if decoded, err := base64.StdEncoding.DecodeString(string(data)); err == nil {
+		data = decoded
+	}
+	if unzipped, err := utils.Gunzip(data); err == nil {
+		data = unzipped
+	}
+	if !bytes.HasPrefix(data, []byte(Header)) {
+		return nil, errors.NotValidf("user data without agent metadata")
+	}
+	var md Metadata
+	if err := yaml.Unmarshal(data, &md); err != nil {
+		return nil, errors.Annotate(err, "cannot parse agent metadata")
+	}
+	if err := md.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &md, nil
+}
+
+// AgentConfig returns the machine agent configuration described by
+// the metadata, for an agent of the given version.
+func (md *Metadata) AgentConfig(toolsVersion version.Number) (agent.ConfigSetter, error) {
+	conf, err := agent.NewAgentConfig(agent.AgentConfigParams{
+		Paths: agent.Paths{
+			DataDir:         md.DataDir,
+			LogDir:          md.LogDir,
+			MetricsSpoolDir: md.MetricsSpoolDir,
+		},
+		Jobs:              md.Jobs,
+		Tag:               names.NewMachineTag(md.MachineId),
+		UpgradedToVersion: toolsVersion,
+		Password:          md.Password,
+		Nonce:             md.Nonce,
+		APIAddresses:      md.APIAddresses,
+		CACert:            md.CACert,
+		Values:            md.Values,
+		Controller:        names.NewControllerTag(md.Controller),
+		Model:             names.NewModelTag(md.Model),
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	conf.SetValue(agent.AgentServiceName, md.ServiceName)
+	return conf, nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentmetadata_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/cloudconfig/agentmetadata"
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/cloudconfig/providerinit/renderers"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/testing"
+)
+
+type metadataSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&metadataSuite{})
+
+func (s *metadataSuite) instanceConfig() *instancecfg.InstanceConfig {
+	return &instancecfg.InstanceConfig{
+		ControllerTag:    testing.ControllerTag,
+		MachineId:        "10",
+		MachineNonce:     "FAKE_NONCE",
+		AgentEnvironment: map[string]string{agent.ProviderType: "dummy"},
+		Series:           "xenial",
+		Jobs:             []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+		APIInfo: &api.Info{
+			Addrs:    []string{"10.0.0.1:17070", "10.0.0.2:17070"},
+			Password: "bletch",
+			CACert:   testing.CACert,
+			Tag:      names.NewMachineTag("10"),
+			ModelTag: testing.ModelTag,
+		},
+		DataDir:                 "/var/lib/juju",
+		LogDir:                  "/var/log/juju",
+		MetricsSpoolDir:         "/var/lib/juju/metricspool",
+		MachineAgentServiceName: "jujud-machine-10",
+	}
+}
+
+func (s *metadataSuite) expectedMetadata() *agentmetadata.Metadata {
+	return &agentmetadata.Metadata{
+		MachineId:       "10",
+		Nonce:           "FAKE_NONCE",
+		Controller:      testing.ControllerTag.Id(),
+		Model:           testing.ModelTag.Id(),
+		APIAddresses:    []string{"10.0.0.1:17070", "10.0.0.2:17070"},
+		CACert:          testing.CACert,
+		Password:        "bletch",
+		Series:          "xenial",
+		Jobs:            []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+		Values:          map[string]string{agent.ProviderType: "dummy"},
+		DataDir:         "/var/lib/juju",
+		LogDir:          "/var/log/juju",
+		MetricsSpoolDir: "/var/lib/juju/metricspool",
+		ServiceName:     "jujud-machine-10",
+	}
+}
+
+func (s *metadataSuite) TestNew(c *gc.C) {
+	md, err := agentmetadata.New(s.instanceConfig())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(md, jc.DeepEquals, s.expectedMetadata())
+}
+
+func (s *metadataSuite) TestNewController(c *gc.C) {
+	icfg := s.instanceConfig()
+	icfg.Controller = &instancecfg.ControllerConfig{}
+	_, err := agentmetadata.New(icfg)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *metadataSuite) TestNewInvalid(c *gc.C) {
+	icfg := s.instanceConfig()
+	icfg.MachineNonce = ""
+	_, err := agentmetadata.New(icfg)
+	c.Assert(err, gc.ErrorMatches, "empty nonce not valid")
+}
+
+func (s *metadataSuite) TestMarshalParse(c *gc.C) {
+	data, err := s.expectedMetadata().Marshal()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), jc.HasPrefix, agentmetadata.Header)
+
+	for i, encoded := range [][]byte{
+		data,
+		utils.Gzip(data),
+		renderers.ToBase64(utils.Gzip(data)),
+		renderers.ToBase64(data),
+	} {
+		c.Logf("test %d", i)
+		md, err := agentmetadata.Parse(encoded)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(md, jc.DeepEquals, s.expectedMetadata())
+	}
+}
+
+func (s *metadataSuite) TestParseNotMetadata(c *gc.C) {
+	_, err := agentmetadata.Parse([]byte("#cloud-config\nruncmd: []\n"))
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, "user data without agent metadata not valid")
+}
+
+func (s *metadataSuite) TestParseInvalid(c *gc.C) {
+	_, err := agentmetadata.Parse([]byte(agentmetadata.Header + "machine-id: foo\n"))
+	c.Assert(err, gc.ErrorMatches, `machine id "foo" not valid`)
+}
+
+func (s *metadataSuite) TestAgentConfig(c *gc.C) {
+	conf, err := s.expectedMetadata().AgentConfig(version.MustParse("2.4.0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(conf.Tag(), gc.Equals, names.NewMachineTag("10"))
+	c.Check(conf.Nonce(), gc.Equals, "FAKE_NONCE")
+	c.Check(conf.Controller(), gc.Equals, testing.ControllerTag)
+	c.Check(conf.Model(), gc.Equals, testing.ModelTag)
+	c.Check(conf.CACert(), gc.Equals, testing.CACert)
+	c.Check(conf.Jobs(), jc.DeepEquals, []multiwatcher.MachineJob{multiwatcher.JobHostUnits})
+	c.Check(conf.UpgradedToVersion(), gc.Equals, version.MustParse("2.4.0"))
+	c.Check(conf.Value(agent.ProviderType), gc.Equals, "dummy")
+	c.Check(conf.Value(agent.AgentServiceName), gc.Equals, "jujud-machine-10")
+	apiInfo, ok := conf.APIInfo()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(apiInfo.Addrs, jc.DeepEquals, []string{"10.0.0.1:17070", "10.0.0.2:17070"})
+	c.Check(apiInfo.Password, gc.Equals, "bletch")
+}
@@ -0,0 +1,194 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cloudinit
+
+import (
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/yaml.v2"
+)
+
+// Keys supported in user-supplied cloud-init configuration.
+const (
+	UserDataPackages   = "packages"
+	UserDataRunCmd     = "runcmd"
+	UserDataWriteFiles = "write_files"
+)
+
+// defaultUserDataFilePermissions are the permissions of user-supplied
+// files that do not specify any.
+const defaultUserDataFilePermissions = 0644
+
+// reservedUserDataPaths are the directories and files that Juju manages
+// on its machines, which user-supplied files may not overwrite.
+var reservedUserDataPaths = []string{
+	"/var/lib/juju",
+	"/var/log/juju",
+	"/etc/juju-proxy.conf",
+	"/etc/juju-proxy-systemd.conf",
+	"/etc/profile.d/juju-proxy.sh",
+}
+
+// UserData holds user-supplied cloud-init configuration, which is
+// merged with the configuration Juju generates for a machine. Only
+// additive configuration is supported: packages are installed, files
+// written and commands run after Juju has configured the machine, so
+// they cannot prevent the machine agent from starting.
+type UserData struct {
+	// Packages holds the names of packages to install.
+	Packages []string `yaml:"packages,omitempty"`
+
+	// RunCmds holds the commands to run, in order.
+	RunCmds []string `yaml:"runcmd,omitempty"`
+
+	// WriteFiles holds the files to write, before the commands run.
+	WriteFiles []UserDataFile `yaml:"write_files,omitempty"`
+}
+
+// UserDataFile describes a file to write to a machine.
+type UserDataFile struct {
+	// Path is the absolute path of the file.
+	Path string `yaml:"path"`
+
+	// Content is the file's content.
+	Content string `yaml:"content"`
+
+	// Permissions holds the file's permissions, as an octal string.
+	// If empty, the file is written with permissions 0644.
+	Permissions string `yaml:"permissions,omitempty"`
+}
+
+// ParseUserData parses and validates YAML user-supplied cloud-init
+// configuration. An empty string yields empty configuration.
+func ParseUserData(data string) (*UserData, error) {
+	var ud UserData
+	if strings.TrimSpace(data) == "" {
+		return &ud, nil
+	}
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal([]byte(data), &raw); err != nil {
+		return nil, errors.Annotate(err, "parsing cloud-init user data")
+	}
+	supported := set.NewStrings(UserDataPackages, UserDataRunCmd, UserDataWriteFiles)
+	for key := range raw {
+		if !supported.Contains(key) {
+			return nil, errors.NotSupportedf("cloud-init user data key %q", key)
+		}
+	}
+	if err := yaml.Unmarshal([]byte(data), &ud); err != nil {
+		return nil, errors.Annotate(err, "parsing cloud-init user data")
+	}
+	if err := ud.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ud, nil
+}
+
+// Validate returns an error if the configuration is not valid.
+func (ud *UserData) Validate() error {
+	for _, pkg := range ud.Packages {
+		if strings.TrimSpace(pkg) == "" || strings.ContainsAny(pkg, " \t\n") {
+			return errors.NotValidf("package name %q", pkg)
+		}
+	}
+	for _, cmd := range ud.RunCmds {
+		if strings.TrimSpace(cmd) == "" {
+			return errors.NotValidf("empty runcmd")
+		}
+	}
+	for _, f := range ud.WriteFiles {
+		if !path.IsAbs(f.Path) || path.Clean(f.Path) != f.Path {
+			return errors.NotValidf("write_files path %q", f.Path)
+		}
+		for _, reserved := range reservedUserDataPaths {
+			if f.Path == reserved || strings.HasPrefix(f.Path, reserved+"/") {
+				return errors.Errorf("write_files path %q is managed by juju", f.Path)
+			}
+		}
+		if _, err := f.mode(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (f UserDataFile) mode() (uint, error) {
+	if f.Permissions == "" {
+		return defaultUserDataFilePermissions, nil
+	}
+	mode, err := strconv.ParseUint(f.Permissions, 8, 32)
+	if err != nil || mode > 07777 {
+		return 0, errors.NotValidf("write_files %q permissions %q", f.Path, f.Permissions)
+	}
+	return uint(mode), nil
+}
+
+// IsEmpty reports whether the configuration holds nothing to apply.
+func (ud *UserData) IsEmpty() bool {
+	return len(ud.Packages) == 0 && len(ud.RunCmds) == 0 && len(ud.WriteFiles) == 0
+}
+
+// Merge returns the result of applying other on top of ud: packages
+// are combined, other's commands run after ud's, and other's files
+// replace any of ud's with the same path.
+func (ud *UserData) Merge(other *UserData) *UserData {
+	var result UserData
+	packages := set.NewStrings()
+	for _, pkg := range append(append([]string{}, ud.Packages...), other.Packages...) {
+		if !packages.Contains(pkg) {
+			packages.Add(pkg)
+			result.Packages = append(result.Packages, pkg)
+		}
+	}
+	result.RunCmds = append(append(result.RunCmds, ud.RunCmds...), other.RunCmds...)
+
+	overridden := set.NewStrings()
+	for _, f := range other.WriteFiles {
+		overridden.Add(f.Path)
+	}
+	for _, f := range ud.WriteFiles {
+		if !overridden.Contains(f.Path) {
+			result.WriteFiles = append(result.WriteFiles, f)
+		}
+	}
+	result.WriteFiles = append(result.WriteFiles, other.WriteFiles...)
+	return &result
+}
+
+// Render returns the configuration as YAML.
+func (ud *UserData) Render() (string, error) {
+	if ud.IsEmpty() {
+		return "", nil
+	}
+	data, err := yaml.Marshal(ud)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return string(data), nil
+}
+
+// Apply adds the configuration to conf. It should be called once
+// Juju's own configuration has been added, so that the user's files
+// and commands follow Juju's.
+func (ud *UserData) Apply(conf CloudConfig) error {
+	existing := set.NewStrings(conf.Packages()...)
+	for _, pkg := range ud.Packages {
+		if !existing.Contains(pkg) {
+			conf.AddPackage(pkg)
+		}
+	}
+	for _, f := range ud.WriteFiles {
+		mode, err := f.mode()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		conf.AddRunTextFile(f.Path, f.Content, mode)
+	}
+	conf.AddScripts(ud.RunCmds...)
+	return nil
+}
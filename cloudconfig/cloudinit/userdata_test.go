@@ -0,0 +1,149 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cloudinit_test
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloudconfig/cloudinit"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type UserDataSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&UserDataSuite{})
+
+const testUserData = `
+packages: [htop, jq]
+runcmd:
+  - echo hello > /tmp/hello
+write_files:
+  - path: /etc/motd
+    content: welcome
+  - path: /usr/local/bin/check
+    content: "#!/bin/sh\ntrue\n"
+    permissions: "0755"
+`
+
+func (s *UserDataSuite) TestParseUserData(c *gc.C) {
+	ud, err := cloudinit.ParseUserData(testUserData)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ud, jc.DeepEquals, &cloudinit.UserData{
+		Packages: []string{"htop", "jq"},
+		RunCmds:  []string{"echo hello > /tmp/hello"},
+		WriteFiles: []cloudinit.UserDataFile{
+			{Path: "/etc/motd", Content: "welcome"},
+			{Path: "/usr/local/bin/check", Content: "#!/bin/sh\ntrue\n", Permissions: "0755"},
+		},
+	})
+}
+
+func (s *UserDataSuite) TestParseUserDataEmpty(c *gc.C) {
+	ud, err := cloudinit.ParseUserData("  \n")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ud.IsEmpty(), jc.IsTrue)
+}
+
+var invalidUserDataTests = []struct {
+	data string
+	err  string
+}{{
+	data: "bootcmd: [reboot]",
+	err:  `cloud-init user data key "bootcmd" not supported`,
+}, {
+	data: "packages: foo",
+	err:  "parsing cloud-init user data: .*",
+}, {
+	data: "packages: ['two words']",
+	err:  `package name "two words" not valid`,
+}, {
+	data: "runcmd: ['  ']",
+	err:  `empty runcmd not valid`,
+}, {
+	data: "write_files: [{path: etc/motd, content: x}]",
+	err:  `write_files path "etc/motd" not valid`,
+}, {
+	data: "write_files: [{path: /etc/../motd, content: x}]",
+	err:  `write_files path "/etc/../motd" not valid`,
+}, {
+	data: "write_files: [{path: /var/lib/juju/agents/machine-0/agent.conf, content: x}]",
+	err:  `write_files path "/var/lib/juju/agents/machine-0/agent.conf" is managed by juju`,
+}, {
+	data: "write_files: [{path: /etc/motd, content: x, permissions: rw}]",
+	err:  `write_files "/etc/motd" permissions "rw" not valid`,
+}}
+
+func (s *UserDataSuite) TestParseUserDataInvalid(c *gc.C) {
+	for i, t := range invalidUserDataTests {
+		c.Logf("test %d: %s", i, t.data)
+		_, err := cloudinit.ParseUserData(t.data)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *UserDataSuite) TestMerge(c *gc.C) {
+	model := &cloudinit.UserData{
+		Packages: []string{"htop", "jq"},
+		RunCmds:  []string{"echo model"},
+		WriteFiles: []cloudinit.UserDataFile{
+			{Path: "/etc/motd", Content: "model"},
+			{Path: "/etc/model", Content: "model"},
+		},
+	}
+	machine := &cloudinit.UserData{
+		Packages: []string{"jq", "tmux"},
+		RunCmds:  []string{"echo machine"},
+		WriteFiles: []cloudinit.UserDataFile{
+			{Path: "/etc/motd", Content: "machine"},
+		},
+	}
+	c.Assert(model.Merge(machine), jc.DeepEquals, &cloudinit.UserData{
+		Packages: []string{"htop", "jq", "tmux"},
+		RunCmds:  []string{"echo model", "echo machine"},
+		WriteFiles: []cloudinit.UserDataFile{
+			{Path: "/etc/model", Content: "model"},
+			{Path: "/etc/motd", Content: "machine"},
+		},
+	})
+}
+
+func (s *UserDataSuite) TestRender(c *gc.C) {
+	ud, err := cloudinit.ParseUserData(testUserData)
+	c.Assert(err, jc.ErrorIsNil)
+	rendered, err := ud.Render()
+	c.Assert(err, jc.ErrorIsNil)
+	reparsed, err := cloudinit.ParseUserData(rendered)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reparsed, jc.DeepEquals, ud)
+
+	rendered, err = (&cloudinit.UserData{}).Render()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rendered, gc.Equals, "")
+}
+
+func (s *UserDataSuite) TestApply(c *gc.C) {
+	cfg, err := cloudinit.New("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	cfg.AddPackage("curl")
+	cfg.AddRunCmd("juju-setup")
+
+	ud, err := cloudinit.ParseUserData(testUserData)
+	c.Assert(err, jc.ErrorIsNil)
+	ud.Packages = append(ud.Packages, "curl")
+	err = ud.Apply(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(cfg.Packages(), jc.DeepEquals, []string{"curl", "htop", "jq"})
+	runCmds := cfg.RunCmds()
+	c.Assert(runCmds[0], gc.Equals, "juju-setup")
+	c.Assert(runCmds[len(runCmds)-1], gc.Equals, "echo hello > /tmp/hello")
+	all := strings.Join(runCmds, "\n")
+	c.Assert(all, jc.Contains, "/etc/motd")
+	c.Assert(all, jc.Contains, "install -D -m 755 /dev/null '/usr/local/bin/check'")
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/cloudconfig/cloudinit"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs/config"
@@ -155,6 +156,20 @@ type InstanceConfig struct {
 	// ifup when bridging bonded interfaces. See bugs #1594855 and
 	// #1269921.
 	NetBondReconfigureDelay int
+
+	// CloudInitUserData holds user-supplied cloud-init configuration
+	// to apply once Juju has configured the instance, if any.
+	CloudInitUserData *cloudinit.UserData
+
+	// CharmLXDProfiles holds the names of the charm LXD profiles to
+	// apply to an LXD container, in addition to the default profile.
+	CharmLXDProfiles []string
+
+	// AgentPreinstalled specifies that the instance's image has a
+	// machine agent installed, so that the instance is passed only the
+	// metadata the agent needs to enlist, and not the cloud-init
+	// configuration that installs it.
+	AgentPreinstalled bool
 }
 
 // ControllerConfig represents controller-specific initialization information
@@ -202,6 +217,12 @@ type BootstrapConfig struct {
 	// subsequently will acquire their serving info from another
 	// server.
 	StateServingInfo params.StateServingInfo
+
+	// ControllerContainerType, if non-empty, is the type of the system
+	// container, on the bootstrap machine, that the controller is to
+	// run in. The container's resources are limited according to the
+	// bootstrap machine constraints. Only LXD containers are supported.
+	ControllerContainerType instance.ContainerType
 }
 
 // SSHHostKeys contains the SSH host keys to configure for a bootstrap host.
@@ -669,6 +690,11 @@ func (cfg *BootstrapConfig) VerifyConfig() (err error) {
 	if len(cfg.HostedModelConfig) == 0 {
 		return errors.New("missing hosted model config")
 	}
+	switch cfg.ControllerContainerType {
+	case "", instance.LXD:
+	default:
+		return errors.Errorf("controller container type %q not supported", cfg.ControllerContainerType)
+	}
 	return nil
 }
 
@@ -828,6 +854,25 @@ func FinishInstanceConfig(icfg *InstanceConfig, cfg *config.Config) (err error)
 		logger.Debugf("Setting numa ctl preference to %v", icfg.Controller.Config.NUMACtlPreference())
 		// Unfortunately, AgentEnvironment can only take strings as values
 		icfg.AgentEnvironment[agent.NUMACtlPreference] = fmt.Sprintf("%v", icfg.Controller.Config.NUMACtlPreference())
+		// Sizes given in the controller config override those
+		// chosen by mongo's memory profile.
+		if size := icfg.Controller.Config.MongoCacheSizeMB(); size > 0 {
+			icfg.AgentEnvironment[agent.MongoCacheSize] = fmt.Sprint(size)
+		}
+		if size := icfg.Controller.Config.MongoOplogSizeMB(); size > 0 {
+			icfg.AgentEnvironment[agent.MongoOplogSize] = fmt.Sprint(size)
+		}
+		if icfg.Bootstrap != nil {
+			// A newly bootstrapped controller is a single node, so
+			// mongo runs with lighter-weight settings until the
+			// controller is promoted by enable-ha.
+			icfg.AgentEnvironment[agent.MongoSingleNode] = "true"
+		}
+	}
+	// Controllers and containers are always installed by cloud-init;
+	// only the images of other machines may have an agent baked in.
+	if icfg.Controller == nil && icfg.MachineContainerType == "" {
+		icfg.AgentPreinstalled = cfg.AgentPreinstalled()
 	}
 	return nil
 }
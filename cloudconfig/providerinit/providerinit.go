@@ -9,9 +9,11 @@ package providerinit
 import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	jujuos "github.com/juju/utils/os"
 	"github.com/juju/utils/series"
 
 	"github.com/juju/juju/cloudconfig"
+	"github.com/juju/juju/cloudconfig/agentmetadata"
 	"github.com/juju/juju/cloudconfig/cloudinit"
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/cloudconfig/providerinit/renderers"
@@ -47,6 +49,10 @@ func configureCloudinit(icfg *instancecfg.InstanceConfig, cloudcfg cloudinit.Clo
 // the providerinit/encoders package according to the need of the provider.
 //
 // If the provided cloudcfg is nil, a new one will be created internally.
+//
+// If the instance's image has a machine agent installed, the user data
+// holds only the agent metadata, encoded as the renderer would encode
+// cloud-init configuration, and the cloudinit configuration is unused.
 func ComposeUserData(icfg *instancecfg.InstanceConfig, cloudcfg cloudinit.CloudConfig, renderer renderers.ProviderRenderer) ([]byte, error) {
 	if cloudcfg == nil {
 		var err error
@@ -55,6 +61,9 @@ func ComposeUserData(icfg *instancecfg.InstanceConfig, cloudcfg cloudinit.CloudC
 			return nil, errors.Trace(err)
 		}
 	}
+	if icfg.AgentPreinstalled {
+		return composeAgentMetadata(icfg, cloudcfg, renderer)
+	}
 	_, err := configureCloudinit(icfg, cloudcfg)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -70,3 +79,45 @@ func ComposeUserData(icfg *instancecfg.InstanceConfig, cloudcfg cloudinit.CloudC
 	logger.Tracef("Generated cloud init:\n%s", string(udata))
 	return udata, err
 }
+
+// composeAgentMetadata renders the agent metadata for an instance
+// whose image has a machine agent installed.
+func composeAgentMetadata(icfg *instancecfg.InstanceConfig, cloudcfg cloudinit.CloudConfig, renderer renderers.ProviderRenderer) ([]byte, error) {
+	operatingSystem, err := series.GetOSFromSeries(icfg.Series)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch operatingSystem {
+	case jujuos.Ubuntu, jujuos.CentOS:
+	default:
+		return nil, errors.NotSupportedf("preinstalled agents on %s", operatingSystem)
+	}
+	md, err := agentmetadata.New(icfg)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot compose agent metadata")
+	}
+	payload, err := md.Marshal()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	logger.Debugf("passing agent metadata to machine %s with preinstalled agent", icfg.MachineId)
+	return renderer.Render(agentMetadataConfig{cloudcfg, payload}, operatingSystem)
+}
+
+// agentMetadataConfig is a cloudinit.CloudConfig that renders as the
+// given agent metadata, so that providers encode the metadata as they
+// would any other user data.
+type agentMetadataConfig struct {
+	cloudinit.CloudConfig
+	payload []byte
+}
+
+// RenderYAML is part of the cloudinit.RenderConfig interface.
+func (c agentMetadataConfig) RenderYAML() ([]byte, error) {
+	return c.payload, nil
+}
+
+// RenderScript is part of the cloudinit.RenderConfig interface.
+func (c agentMetadataConfig) RenderScript() (string, error) {
+	return string(c.payload), nil
+}
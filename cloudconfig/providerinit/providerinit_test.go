@@ -20,10 +20,13 @@ import (
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cloudconfig"
+	"github.com/juju/juju/cloudconfig/agentmetadata"
 	"github.com/juju/juju/cloudconfig/cloudinit"
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/cloudconfig/providerinit"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/juju/paths"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/provider/dummy"
@@ -126,6 +129,60 @@ func (s *CloudInitSuite) TestFinishInstanceConfigNonDefault(c *gc.C) {
 	})
 }
 
+func (s *CloudInitSuite) TestFinishInstanceConfigSingleNodeController(c *gc.C) {
+	cfg, err := config.New(config.NoDefaults, dummySampleConfig())
+	c.Assert(err, jc.ErrorIsNil)
+	icfg, err := instancecfg.NewBootstrapInstanceConfig(
+		testing.FakeControllerConfig(),
+		constraints.Value{}, constraints.Value{},
+		"quantal", "",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	err = instancecfg.FinishInstanceConfig(icfg, cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(icfg.AgentEnvironment[agent.MongoSingleNode], gc.Equals, "true")
+
+	// Controllers added by enable-ha are not single nodes.
+	icfg.Bootstrap = nil
+	icfg.AgentEnvironment = nil
+	err = instancecfg.FinishInstanceConfig(icfg, cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := icfg.AgentEnvironment[agent.MongoSingleNode]
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *CloudInitSuite) TestFinishInstanceConfigAgentPreinstalled(c *gc.C) {
+	cfg, err := config.New(config.NoDefaults, dummySampleConfig().Merge(testing.Attrs{
+		"agent-preinstalled": true,
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	icfg := &instancecfg.InstanceConfig{
+		APIInfo: &api.Info{Tag: names.NewMachineTag("1")},
+	}
+	err = instancecfg.FinishInstanceConfig(icfg, cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(icfg.AgentPreinstalled, jc.IsTrue)
+
+	// Containers and controllers are always installed by cloud-init.
+	icfg = &instancecfg.InstanceConfig{
+		APIInfo:              &api.Info{Tag: names.NewMachineTag("1/lxd/0")},
+		MachineContainerType: instance.LXD,
+	}
+	err = instancecfg.FinishInstanceConfig(icfg, cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(icfg.AgentPreinstalled, jc.IsFalse)
+
+	icfg, err = instancecfg.NewBootstrapInstanceConfig(
+		testing.FakeControllerConfig(),
+		constraints.Value{}, constraints.Value{},
+		"quantal", "",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	err = instancecfg.FinishInstanceConfig(icfg, cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(icfg.AgentPreinstalled, jc.IsFalse)
+}
+
 func (s *CloudInitSuite) TestUserData(c *gc.C) {
 	s.testUserData(c, "quantal", false)
 }
@@ -340,3 +397,44 @@ func (s *CloudInitSuite) TestWindowsUserdataEncoding(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(string(got), gc.Equals, string(expected))
 }
+
+func (s *CloudInitSuite) TestAgentPreinstalledUserData(c *gc.C) {
+	cfg := &instancecfg.InstanceConfig{
+		ControllerTag:    testing.ControllerTag,
+		MachineId:        "10",
+		MachineNonce:     "FAKE_NONCE",
+		AgentEnvironment: map[string]string{agent.ProviderType: "dummy"},
+		Series:           "xenial",
+		Jobs:             []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+		APIInfo: &api.Info{
+			Addrs:    []string{"state-addr.testing.invalid:54321"},
+			Password: "bletch",
+			CACert:   testing.CACert,
+			Tag:      names.NewMachineTag("10"),
+			ModelTag: testing.ModelTag,
+		},
+		MachineAgentServiceName: "jujud-machine-10",
+		DataDir:                 "/var/lib/juju",
+		LogDir:                  "/var/log/juju",
+		AgentPreinstalled:       true,
+	}
+	result, err := providerinit.ComposeUserData(cfg, nil, openstack.OpenstackRenderer{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The metadata is encoded as the renderer encodes cloud-init
+	// configuration, and contains nothing else.
+	unzipped, err := utils.Gunzip(result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(unzipped), jc.HasPrefix, agentmetadata.Header)
+	md, err := agentmetadata.Parse(result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(md.MachineId, gc.Equals, "10")
+	c.Check(md.Nonce, gc.Equals, "FAKE_NONCE")
+	c.Check(md.APIAddresses, jc.DeepEquals, []string{"state-addr.testing.invalid:54321"})
+	c.Check(md.Password, gc.Equals, "bletch")
+	c.Check(md.ServiceName, gc.Equals, "jujud-machine-10")
+
+	cfg.Series = "win8"
+	_, err = providerinit.ComposeUserData(cfg, nil, openstack.OpenstackRenderer{})
+	c.Assert(err, gc.ErrorMatches, "preinstalled agents on Windows not supported")
+}
@@ -395,7 +395,7 @@ chown syslog:adm /var/log/juju
 bin='/var/lib/juju/tools/1\.2\.3-quantal-amd64'
 mkdir -p \$bin
 echo 'Fetching Juju agent version.*
-curl -sSfw '.*' --connect-timeout 20 --noproxy "\*" --insecure -o \$bin/tools\.tar\.gz 'https://state-addr\.testing\.invalid:54321/deadbeef-0bad-400d-8000-4b1d0d06f00d/tools/1\.2\.3-quantal-amd64'
+curl -sSfw '.*' --connect-timeout 20 --noproxy "\*" --insecure --globoff -o \$bin/tools\.tar\.gz 'https://state-addr\.testing\.invalid:54321/deadbeef-0bad-400d-8000-4b1d0d06f00d/tools/1\.2\.3-quantal-amd64'
 sha256sum \$bin/tools\.tar\.gz > \$bin/juju1\.2\.3-quantal-amd64\.sha256
 grep '1234' \$bin/juju1\.2\.3-quantal-amd64.sha256 \|\| \(echo "Tools checksum mismatch"; exit 1\)
 tar zxf \$bin/tools.tar.gz -C \$bin
@@ -470,7 +470,7 @@ start jujud-machine-2-lxd-1
 		}),
 		inexactMatch: true,
 		expectScripts: `
-curl .* --noproxy "\*" --insecure -o \$bin/tools\.tar\.gz 'https://state-addr\.testing\.invalid:54321/deadbeef-0bad-400d-8000-4b1d0d06f00d/tools/1\.2\.3-quantal-amd64'
+curl .* --noproxy "\*" --insecure --globoff -o \$bin/tools\.tar\.gz 'https://state-addr\.testing\.invalid:54321/deadbeef-0bad-400d-8000-4b1d0d06f00d/tools/1\.2\.3-quantal-amd64'
 `,
 	},
 
@@ -1196,6 +1196,27 @@ DefaultEnvironment="http_proxy=http://user@10.0.0.1" "HTTP_PROXY=http://user@10.
 	c.Assert(found, jc.IsTrue)
 }
 
+func (s *cloudinitSuite) TestCloudInitUserDataApplied(c *gc.C) {
+	environConfig := minimalModelConfig(c)
+	instanceCfg := s.createInstanceConfig(c, environConfig)
+	instanceCfg.CloudInitUserData = &cloudinit.UserData{
+		Packages: []string{"htop"},
+		RunCmds:  []string{"touch /tmp/done"},
+	}
+	cloudcfg, err := cloudinit.New("quantal")
+	c.Assert(err, jc.ErrorIsNil)
+	udata, err := cloudconfig.NewUserdataConfig(instanceCfg, cloudcfg)
+	c.Assert(err, jc.ErrorIsNil)
+	err = udata.Configure()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(cloudcfg.Packages(), jc.Contains, "htop")
+	// The user's commands run after Juju's, including starting the
+	// machine agent.
+	cmds := cloudcfg.RunCmds()
+	c.Assert(cmds[len(cmds)-1], gc.Equals, "touch /tmp/done")
+}
+
 func (s *cloudinitSuite) TestAptMirror(c *gc.C) {
 	environConfig := minimalModelConfig(c)
 	environConfig, err := environConfig.Apply(map[string]interface{}{
@@ -321,7 +321,18 @@ func (w *unixConfigure) ConfigureJuju() error {
 		}
 	}
 
-	return w.addMachineAgentToBoot()
+	if err := w.addMachineAgentToBoot(); err != nil {
+		return errors.Trace(err)
+	}
+
+	// User-supplied configuration goes last, so that it cannot get
+	// in the way of the machine agent starting.
+	if w.icfg.CloudInitUserData != nil {
+		if err := w.icfg.CloudInitUserData.Apply(w.conf); err != nil {
+			return errors.Annotate(err, "applying cloud-init user data")
+		}
+	}
+	return nil
 }
 
 func (w *unixConfigure) configureBootstrap() error {
@@ -402,6 +413,10 @@ func (w *unixConfigure) addDownloadToolsCmds() error {
 			// matter, because there is no sensitive information being transmitted
 			// and we verify the tools' hash after.
 			curlCommand += " --insecure"
+
+			// Controller URLs may contain bracketed IPv6 addresses,
+			// which curl would otherwise treat as a glob range.
+			curlCommand += " --globoff"
 		}
 		curlCommand += " -o $bin/tools.tar.gz"
 		w.conf.AddRunCmd(cloudinit.LogProgressCmd("Fetching Juju agent version %s for %s", tools.Version.Number, tools.Version.Arch))
@@ -27,8 +27,9 @@ func (s *AddRelationSuite) SetUpTest(c *gc.C) {
 	s.IsolationSuite.SetUpTest(c)
 	s.mockAPI = &mockAddAPI{Stub: &testing.Stub{}}
 	s.mockAPI.addRelationFunc = func(endpoints, viaCIDRs []string) (*params.AddRelationResults, error) {
-		// At the moment, cmd implementation ignores the return values,
-		// so nil is an acceptable return for testing purposes.
+		// The cmd implementation only reports warnings from the
+		// return values, so nil is an acceptable return for testing
+		// purposes.
 		return nil, s.mockAPI.NextErr()
 	}
 }
@@ -63,6 +64,19 @@ func (s *AddRelationSuite) TestAddRelationSuccess(c *gc.C) {
 	s.mockAPI.CheckCall(c, 1, "Close")
 }
 
+func (s *AddRelationSuite) TestAddRelationWarnings(c *gc.C) {
+	s.mockAPI.addRelationFunc = func(endpoints, viaCIDRs []string) (*params.AddRelationResults, error) {
+		return &params.AddRelationResults{
+			Warnings: []string{"application1:db implements version \"2\" of interface \"mysql\""},
+		}, nil
+	}
+	cmd := NewAddRelationCommandForTest(s.mockAPI, s.mockAPI)
+	cmd.SetClientStore(NewMockStore())
+	ctx, err := cmdtesting.RunCommand(c, cmd, "application1", "application2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `WARNING: application1:db implements version "2" of interface "mysql"`+"\n")
+}
+
 func (s *AddRelationSuite) TestAddRelationFail(c *gc.C) {
 	msg := "fail add-relation call at API"
 	s.mockAPI.SetErrors(errors.New(msg))
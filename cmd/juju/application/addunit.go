@@ -12,6 +12,7 @@ import (
 	"github.com/juju/gnuflag"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
@@ -67,6 +68,7 @@ Add a unit of mariadb to LXD container number 3 on machine 24:
 
 Add a unit of mariadb to LXD container on a new machine:
     juju add-unit mariadb --to lxd
+    juju add-unit mariadb --to lxd:new
 
 See also: 
     remove-unit`[1:]
@@ -176,6 +178,19 @@ type serviceAddUnitAPI interface {
 	Close() error
 	ModelUUID() string
 	AddUnits(application.AddUnitsParams) ([]string, error)
+	Status(patterns []string) (*params.FullStatus, error)
+}
+
+// addUnitAPIAdapter combines the application client with the
+// status method of the client facade.
+type addUnitAPIAdapter struct {
+	*application.Client
+	client *api.Client
+}
+
+// Status is part of the serviceAddUnitAPI interface.
+func (a *addUnitAPIAdapter) Status(patterns []string) (*params.FullStatus, error) {
+	return a.client.Status(patterns)
 }
 
 func (c *addUnitCommand) getAPI() (serviceAddUnitAPI, error) {
@@ -186,7 +201,10 @@ func (c *addUnitCommand) getAPI() (serviceAddUnitAPI, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return application.NewClient(root), nil
+	return &addUnitAPIAdapter{
+		Client: application.NewClient(root),
+		client: root.Client(),
+	}, nil
 }
 
 // Run connects to the environment specified on the command line
@@ -210,6 +228,7 @@ func (c *addUnitCommand) Run(ctx *cmd.Context) error {
 		}
 		c.Placement[i] = p
 	}
+	warnControllerPlacement(ctx, apiclient, c.Placement)
 	_, err = apiclient.AddUnits(application.AddUnitsParams{
 		ApplicationName: c.ApplicationName,
 		NumUnits:        c.NumUnits,
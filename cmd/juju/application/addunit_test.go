@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/cmd/juju/application"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/testing"
 )
 
@@ -33,6 +34,8 @@ type fakeServiceAddUnitAPI struct {
 	attachStorage  []string
 	bestAPIVersion int
 	err            error
+
+	machines map[string]params.MachineStatus
 }
 
 func (f *fakeServiceAddUnitAPI) BestAPIVersion() int {
@@ -61,6 +64,16 @@ func (f *fakeServiceAddUnitAPI) AddUnits(args apiapplication.AddUnitsParams) ([]
 	return nil, nil
 }
 
+func (f *fakeServiceAddUnitAPI) Status(patterns []string) (*params.FullStatus, error) {
+	machines := make(map[string]params.MachineStatus)
+	for _, id := range patterns {
+		if m, ok := f.machines[id]; ok {
+			machines[id] = m
+		}
+	}
+	return &params.FullStatus{Machines: machines}, nil
+}
+
 func (f *fakeServiceAddUnitAPI) ModelGet() (map[string]interface{}, error) {
 	cfg, err := config.New(config.UseDefaults, map[string]interface{}{
 		"type": f.envType,
@@ -201,6 +214,21 @@ func (s *AddUnitSuite) TestForceMachineNewContainer(c *gc.C) {
 	c.Assert(s.fake.placement[0].Scope, gc.Equals, "lxd")
 }
 
+func (s *AddUnitSuite) TestForceControllerMachineWarns(c *gc.C) {
+	s.fake.machines = map[string]params.MachineStatus{
+		"0": {Jobs: []multiwatcher.MachineJob{multiwatcher.JobManageModel}},
+		"1": {Jobs: []multiwatcher.MachineJob{multiwatcher.JobHostUnits}},
+	}
+	ctx, err := cmdtesting.RunCommand(c, application.NewAddUnitCommandForTest(s.fake),
+		"some-application-name", "-n", "2", "--to", "lxd:0,1",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.numUnits, gc.Equals, 3)
+	stderr := cmdtesting.Stderr(ctx)
+	c.Assert(stderr, jc.Contains, "machine 0 hosts a controller; units placed on it will compete with the controller for resources")
+	c.Assert(stderr, gc.Not(jc.Contains), "machine 1")
+}
+
 func (s *AddUnitSuite) TestNameChecks(c *gc.C) {
 	assertMachineOrNewContainer := func(s string, expect bool) {
 		c.Logf("%s -> %v", s, expect)
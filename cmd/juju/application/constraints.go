@@ -13,8 +13,10 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/constraints"
 )
 
@@ -30,9 +32,15 @@ machines for applications. Where model and application constraints overlap, the
 application constraints take precedence.
 Constraints for a specific model can be viewed with ` + "`juju get-model-\nconstraints`" + `.
 
+With --trace, the constraints in effect are shown along with where each
+value came from: the model, the application or, for a unit, the constraints
+resolved when the unit was created. Values that are not in effect are also
+shown, with the reason why.
+
 Examples:
     juju get-constraints mysql
     juju get-constraints -m mymodel apache2
+    juju get-constraints --trace mysql/0
 
 See also: 
     set-constraints
@@ -75,6 +83,7 @@ func NewServiceGetConstraintsCommand() modelcmd.ModelCommand {
 type serviceConstraintsAPI interface {
 	Close() error
 	GetConstraints(...string) ([]constraints.Value, error)
+	GetConstraintsDetail(...string) ([]params.ConstraintsDetailResult, error)
 	SetConstraints(string, constraints.Value) error
 }
 
@@ -98,24 +107,75 @@ func (c *serviceConstraintsCommand) getAPI() (serviceConstraintsAPI, error) {
 
 type serviceGetConstraintsCommand struct {
 	serviceConstraintsCommand
+	trace    bool
+	unitName string
 }
 
 func (c *serviceGetConstraintsCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "get-constraints",
-		Args:    "<application>",
+		Args:    "<application> | --trace <application or unit>",
 		Purpose: usageGetConstraintsSummary,
 		Doc:     usageGetConstraintsDetails,
 	}
 }
 
+// constraintsDetail holds the constraints of an application or unit,
+// and where each value came from, for output.
+type constraintsDetail struct {
+	Constraints string                `yaml:"constraints" json:"constraints"`
+	Attributes  []constraintAttribute `yaml:"attributes,omitempty" json:"attributes,omitempty"`
+}
+
+type constraintAttribute struct {
+	Attribute  string               `yaml:"attribute" json:"attribute"`
+	Value      string               `yaml:"value,omitempty" json:"value,omitempty"`
+	Source     string               `yaml:"source,omitempty" json:"source,omitempty"`
+	Overridden []constraintOverride `yaml:"overridden,omitempty" json:"overridden,omitempty"`
+}
+
+type constraintOverride struct {
+	Source string `yaml:"source" json:"source"`
+	Value  string `yaml:"value" json:"value"`
+	Reason string `yaml:"reason" json:"reason"`
+}
+
 func formatConstraints(writer io.Writer, value interface{}) error {
+	if detail, ok := value.(constraintsDetail); ok {
+		return formatConstraintsDetail(writer, detail)
+	}
 	fmt.Fprint(writer, value.(constraints.Value).String())
 	return nil
 }
 
+func formatConstraintsDetail(writer io.Writer, detail constraintsDetail) error {
+	fmt.Fprintf(writer, "Constraints: %s\n\n", detail.Constraints)
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Attribute", "Value", "Source", "Not in effect")
+	for _, attr := range detail.Attributes {
+		value, source := attr.Value, attr.Source
+		if source == "" {
+			value, source = "-", "-"
+		}
+		if len(attr.Overridden) == 0 {
+			w.Println(attr.Attribute, value, source)
+		}
+		for i, o := range attr.Overridden {
+			note := fmt.Sprintf("%s from %s (%s)", o.Value, o.Source, o.Reason)
+			if i == 0 {
+				w.Println(attr.Attribute, value, source, note)
+			} else {
+				w.Println("", "", "", note)
+			}
+		}
+	}
+	return tw.Flush()
+}
+
 func (c *serviceGetConstraintsCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.trace, "trace", false, "Show where each constraint value came from")
 	c.out.AddFlags(f, "constraints", map[string]cmd.Formatter{
 		"constraints": formatConstraints,
 		"yaml":        cmd.FormatYaml,
@@ -127,6 +187,10 @@ func (c *serviceGetConstraintsCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.Errorf("no application name specified")
 	}
+	if c.trace && names.IsValidUnit(args[0]) {
+		c.unitName, args = args[0], args[1:]
+		return cmd.CheckEmpty(args)
+	}
 	if !names.IsValidApplication(args[0]) {
 		return errors.Errorf("invalid application name %q", args[0])
 	}
@@ -142,6 +206,9 @@ func (c *serviceGetConstraintsCommand) Run(ctx *cmd.Context) error {
 	}
 	defer apiclient.Close()
 
+	if c.trace {
+		return c.runTrace(ctx, apiclient)
+	}
 	cons, err := apiclient.GetConstraints(c.ApplicationName)
 	if err != nil {
 		return err
@@ -149,6 +216,38 @@ func (c *serviceGetConstraintsCommand) Run(ctx *cmd.Context) error {
 	return c.out.Write(ctx, cons[0])
 }
 
+func (c *serviceGetConstraintsCommand) runTrace(ctx *cmd.Context, apiclient serviceConstraintsAPI) error {
+	entity := c.ApplicationName
+	if c.unitName != "" {
+		entity = c.unitName
+	}
+	results, err := apiclient.GetConstraintsDetail(entity)
+	if errors.IsNotSupported(err) {
+		return errors.New("--trace is not supported by this controller")
+	} else if err != nil {
+		return err
+	}
+	detail := constraintsDetail{
+		Constraints: results[0].Constraints.String(),
+	}
+	for _, attr := range results[0].Attributes {
+		out := constraintAttribute{
+			Attribute: attr.Attribute,
+			Value:     attr.Value,
+			Source:    attr.Source,
+		}
+		for _, o := range attr.Overridden {
+			out.Overridden = append(out.Overridden, constraintOverride{
+				Source: o.Source,
+				Value:  o.Value,
+				Reason: o.Reason,
+			})
+		}
+		detail.Attributes = append(detail.Attributes, out)
+	}
+	return c.out.Write(ctx, detail)
+}
+
 type serviceSetConstraintsCommand struct {
 	serviceConstraintsCommand
 	Constraints constraints.Value
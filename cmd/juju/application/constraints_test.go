@@ -5,10 +5,14 @@ package application_test
 
 import (
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jtesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/testing"
 )
 
@@ -68,8 +72,18 @@ func (s *ServiceConstraintsCommandsSuite) TestGetInit(c *gc.C) {
 		}, {
 			args: []string{"mysql-0"},
 			err:  `invalid application name "mysql-0"`,
+		}, {
+			args: []string{"mysql/0"},
+			err:  `invalid application name "mysql/0"`,
 		}, {
 			args: []string{"mysql"},
+		}, {
+			args: []string{"--trace", "mysql"},
+		}, {
+			args: []string{"--trace", "mysql/0"},
+		}, {
+			args: []string{"--trace", "mysql/0", "extra"},
+			err:  `unrecognized args: \["extra"\]`,
 		},
 	} {
 		cmd := application.NewServiceGetConstraintsCommand()
@@ -82,3 +96,83 @@ func (s *ServiceConstraintsCommandsSuite) TestGetInit(c *gc.C) {
 		}
 	}
 }
+
+func (s *ServiceConstraintsCommandsSuite) TestGetTrace(c *gc.C) {
+	api := &fakeConstraintsAPI{
+		details: []params.ConstraintsDetailResult{{
+			Constraints: constraints.MustParse("mem=4G cores=2"),
+			Attributes: []params.ConstraintAttributeDetail{{
+				Attribute: "cores",
+				Value:     "2",
+				Source:    "unit",
+				Overridden: []params.ConstraintOverride{{
+					Source: "model",
+					Value:  "1",
+					Reason: "overridden by application constraints",
+				}, {
+					Source: "application",
+					Value:  "4",
+					Reason: "overridden by unit constraints",
+				}},
+			}, {
+				Attribute: "instance-type",
+				Overridden: []params.ConstraintOverride{{
+					Source: "model",
+					Value:  "m1.small",
+					Reason: "conflicts with application constraints",
+				}},
+			}, {
+				Attribute: "mem",
+				Value:     "4096M",
+				Source:    "model",
+			}},
+		}},
+	}
+	cmd := application.NewServiceGetConstraintsCommandForTest(api)
+	cmd.SetClientStore(application.NewMockStore())
+	ctx, err := cmdtesting.RunCommand(c, cmd, "--trace", "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	api.CheckCall(c, 0, "GetConstraintsDetail", []string{"mysql/0"})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Constraints: cores=2 mem=4096M
+
+Attribute      Value  Source  Not in effect
+cores          2      unit    1 from model (overridden by application constraints)
+                              4 from application (overridden by unit constraints)
+instance-type  -      -       m1.small from model (conflicts with application constraints)
+mem            4096M  model
+`[1:])
+}
+
+func (s *ServiceConstraintsCommandsSuite) TestGetTraceNotSupported(c *gc.C) {
+	api := &fakeConstraintsAPI{}
+	api.SetErrors(errors.NotSupportedf("GetConstraintsDetail"))
+	cmd := application.NewServiceGetConstraintsCommandForTest(api)
+	cmd.SetClientStore(application.NewMockStore())
+	_, err := cmdtesting.RunCommand(c, cmd, "--trace", "mysql")
+	c.Assert(err, gc.ErrorMatches, "--trace is not supported by this controller")
+}
+
+type fakeConstraintsAPI struct {
+	jtesting.Stub
+	details []params.ConstraintsDetailResult
+}
+
+func (f *fakeConstraintsAPI) Close() error {
+	return nil
+}
+
+func (f *fakeConstraintsAPI) GetConstraints(applications ...string) ([]constraints.Value, error) {
+	f.MethodCall(f, "GetConstraints", applications)
+	return nil, f.NextErr()
+}
+
+func (f *fakeConstraintsAPI) GetConstraintsDetail(entities ...string) ([]params.ConstraintsDetailResult, error) {
+	f.MethodCall(f, "GetConstraintsDetail", entities)
+	return f.details, f.NextErr()
+}
+
+func (f *fakeConstraintsAPI) SetConstraints(application string, cons constraints.Value) error {
+	f.MethodCall(f, "SetConstraints", application, cons)
+	return f.NextErr()
+}
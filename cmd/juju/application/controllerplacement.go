@@ -0,0 +1,67 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
+)
+
+// machineStatusAPI defines the status method used to check whether
+// units are being placed on controller machines.
+type machineStatusAPI interface {
+	Status(patterns []string) (*params.FullStatus, error)
+}
+
+// warnControllerPlacement warns if any of the given placement
+// directives would place units on, or in containers on, a machine that
+// hosts a controller. Such units compete with the controller for the
+// machine's resources. Failing to check is not an error, as the
+// warning is only advisory.
+func warnControllerPlacement(ctx *cmd.Context, api machineStatusAPI, placement []*instance.Placement) {
+	var hostIds []string
+	seen := make(map[string]bool)
+	for _, p := range placement {
+		if p == nil || !names.IsValidMachine(p.Directive) {
+			continue
+		}
+		if _, err := instance.ParseContainerType(p.Scope); err != nil && p.Scope != instance.MachineScope {
+			continue
+		}
+		// Units in containers share the resources of the host.
+		hostId := strings.SplitN(p.Directive, "/", 2)[0]
+		if !seen[hostId] {
+			seen[hostId] = true
+			hostIds = append(hostIds, hostId)
+		}
+	}
+	if len(hostIds) == 0 {
+		return
+	}
+	status, err := api.Status(hostIds)
+	if err != nil {
+		logger.Debugf("cannot check for units placed on controller machines: %v", err)
+		return
+	}
+	for _, id := range hostIds {
+		machine, ok := status.Machines[id]
+		if !ok {
+			continue
+		}
+		for _, job := range machine.Jobs {
+			if job.NeedsState() {
+				ctx.Infof(
+					"WARNING: machine %s hosts a controller; units placed on it will compete with the controller for resources",
+					id,
+				)
+				break
+			}
+		}
+	}
+}
@@ -458,6 +458,7 @@ Examples:
     juju deploy mysql --to 23       (deploy to preexisting machine 23)
     juju deploy mysql --to lxd      (deploy to a new LXD container on a new machine)
     juju deploy mysql --to lxd:25   (deploy to a new LXD container on machine 25)
+    juju deploy mysql --to lxd:new  (deploy to a new LXD container on a new machine)
     juju deploy mysql --to 24/lxd/3 (deploy to LXD container 3 on machine 24)
 
     juju deploy mysql -n 2 --to 3,lxd:5
@@ -750,6 +751,7 @@ func (c *DeployCommand) deployCharm(
 		return errors.Trace(err)
 	}
 
+	warnControllerPlacement(ctx, apiRoot, c.Placement)
 	return errors.Trace(apiRoot.Deploy(application.DeployArgs{
 		CharmID:          id,
 		Cons:             c.Constraints,
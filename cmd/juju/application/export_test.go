@@ -46,6 +46,14 @@ func NewAddUnitCommandForTest(api serviceAddUnitAPI) cmd.Command {
 	})
 }
 
+// NewServiceGetConstraintsCommandForTest returns a GetConstraintsCommand
+// with the api provided as specified.
+func NewServiceGetConstraintsCommandForTest(api serviceConstraintsAPI) modelcmd.ModelCommand {
+	return modelcmd.Wrap(&serviceGetConstraintsCommand{
+		serviceConstraintsCommand: serviceConstraintsCommand{api: api},
+	})
+}
+
 // NewAddRelationCommandForTest returns an AddRelationCommand with the api provided as specified.
 func NewAddRelationCommandForTest(addAPI applicationAddRelationAPI, consumeAPI applicationConsumeDetailsAPI) modelcmd.ModelCommand {
 	cmd := &addRelationCommand{addRelationAPI: addAPI, consumeDetailsAPI: consumeAPI}
@@ -98,6 +106,23 @@ func NewResumeRelationCommandForTest(api SetRelationSuspendedAPI) modelcmd.Model
 	return modelcmd.Wrap(cmd)
 }
 
+// NewTrustCommandForTest returns a TrustCommand with the api provided as specified.
+func NewTrustCommandForTest(api TrustAPI) modelcmd.ModelCommand {
+	cmd := &trustCommand{newAPIFunc: func() (TrustAPI, error) {
+		return api, nil
+	}}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewSetRefreshPolicyCommandForTest returns a SetRefreshPolicyCommand
+// with the api provided as specified.
+func NewSetRefreshPolicyCommandForTest(api SetRefreshPolicyAPI) modelcmd.ModelCommand {
+	cmd := &setRefreshPolicyCommand{newAPIFunc: func() (SetRefreshPolicyAPI, error) {
+		return api, nil
+	}}
+	return modelcmd.Wrap(cmd)
+}
+
 // NewRemoveSaasCommandForTest returns a RemoveSaasCommand with the api provided as specified.
 func NewRemoveSaasCommandForTest(api RemoveSaasAPI) modelcmd.ModelCommand {
 	cmd := &removeSaasCommand{newAPIFunc: func() (RemoveSaasAPI, error) {
@@ -0,0 +1,123 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/charmrefresh"
+)
+
+var usageSetRefreshPolicySummary = `
+Sets the policy under which an application's charm is refreshed automatically.`[1:]
+
+var usageSetRefreshPolicyDetails = `
+An application's charm is refreshed from the channel it was deployed
+from, or last upgraded from. The controller checks the charm store for
+newer revisions in that channel daily; under a refresh policy other
+than none, a newer revision is recorded as a pending upgrade, and is
+applied within the model's maintenance windows.
+
+The refresh policies are:
+    none        the application is pinned to its current revision
+    patch-only  newer revisions are applied only if they leave the
+                charm's relation endpoints, extra bindings, storage
+                and configuration options unchanged
+    any         every newer revision is applied
+
+Maintenance windows are set with the model config key
+maintenance-windows, as a comma-separated list of UTC periods such as
+"sat 02:00-04:00, daily 23:00-01:00", or defined with cron-like
+schedules through the Maintenance API. If the model has none, pending
+upgrades are applied as soon as they are found. Upgrades to applications, or
+units, in maintenance mode are deferred until maintenance ends.
+
+Only charm store charms can be refreshed automatically.
+
+Examples:
+    juju set-refresh-policy mysql patch-only
+    juju set-refresh-policy wordpress none
+
+See also:
+    upgrade-charm
+    model-config`[1:]
+
+// NewSetRefreshPolicyCommand returns a command to set the refresh
+// policy of an application.
+func NewSetRefreshPolicyCommand() modelcmd.ModelCommand {
+	cmd := &setRefreshPolicyCommand{}
+	cmd.newAPIFunc = func() (SetRefreshPolicyAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// SetRefreshPolicyAPI defines the API methods that the
+// set-refresh-policy command uses.
+type SetRefreshPolicyAPI interface {
+	Close() error
+	SetRefreshPolicy(application string, policy charmrefresh.Policy) error
+}
+
+// setRefreshPolicyCommand is responsible for setting the refresh
+// policies of applications.
+type setRefreshPolicyCommand struct {
+	modelcmd.ModelCommandBase
+	newAPIFunc func() (SetRefreshPolicyAPI, error)
+
+	applicationName string
+	policy          charmrefresh.Policy
+}
+
+// Info is part of the cmd.Command interface.
+func (c *setRefreshPolicyCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-refresh-policy",
+		Args:    "<application name> <none|patch-only|any>",
+		Purpose: usageSetRefreshPolicySummary,
+		Doc:     usageSetRefreshPolicyDetails,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *setRefreshPolicyCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no application name specified")
+	case 1:
+		return errors.New("no refresh policy specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.NotValidf("application name %q", args[0])
+	}
+	c.applicationName = args[0]
+	c.policy = charmrefresh.Policy(args[1])
+	if err := c.policy.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	return cmd.CheckEmpty(args[2:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *setRefreshPolicyCommand) Run(_ *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	err = client.SetRefreshPolicy(c.applicationName, c.policy)
+	if errors.IsNotSupported(err) {
+		return errors.New("refresh policies are not supported by this version of Juju")
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
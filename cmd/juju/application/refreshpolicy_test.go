@@ -0,0 +1,86 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/core/charmrefresh"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SetRefreshPolicySuite struct {
+	testing.IsolationSuite
+	mockAPI *mockSetRefreshPolicyAPI
+}
+
+func (s *SetRefreshPolicySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockSetRefreshPolicyAPI{Stub: &testing.Stub{}}
+}
+
+var _ = gc.Suite(&SetRefreshPolicySuite{})
+
+func (s *SetRefreshPolicySuite) run(c *gc.C, args ...string) error {
+	_, err := cmdtesting.RunCommand(c, NewSetRefreshPolicyCommandForTest(s.mockAPI), args...)
+	return err
+}
+
+func (s *SetRefreshPolicySuite) TestInvalidArguments(c *gc.C) {
+	err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "no application name specified")
+
+	err = s.run(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, "no refresh policy specified")
+
+	err = s.run(c, "no_good", "any")
+	c.Assert(err, gc.ErrorMatches, `application name "no_good" not valid`)
+
+	err = s.run(c, "mysql", "sometimes")
+	c.Assert(err, gc.ErrorMatches, `refresh policy "sometimes" not valid`)
+
+	err = s.run(c, "mysql", "any", "wordpress")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["wordpress"\]`)
+	s.mockAPI.CheckNoCalls(c)
+}
+
+func (s *SetRefreshPolicySuite) TestSetRefreshPolicy(c *gc.C) {
+	err := s.run(c, "mysql", "patch-only")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"SetRefreshPolicy", []interface{}{"mysql", charmrefresh.PatchOnly}},
+		{"Close", nil},
+	})
+}
+
+func (s *SetRefreshPolicySuite) TestNotSupported(c *gc.C) {
+	s.mockAPI.SetErrors(errors.NotSupportedf("SetRefreshPolicy"))
+	err := s.run(c, "mysql", "any")
+	c.Assert(err, gc.ErrorMatches, "refresh policies are not supported by this version of Juju")
+}
+
+func (s *SetRefreshPolicySuite) TestBlocked(c *gc.C) {
+	s.mockAPI.SetErrors(common.OperationBlockedError("TestBlocked"))
+	err := s.run(c, "mysql", "any")
+	coretesting.AssertOperationWasBlocked(c, err, ".*TestBlocked.*")
+}
+
+type mockSetRefreshPolicyAPI struct {
+	*testing.Stub
+}
+
+func (s mockSetRefreshPolicyAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return s.NextErr()
+}
+
+func (s mockSetRefreshPolicyAPI) SetRefreshPolicy(application string, policy charmrefresh.Policy) error {
+	s.MethodCall(s, "SetRefreshPolicy", application, policy)
+	return s.NextErr()
+}
@@ -25,6 +25,7 @@ func NewRemoveApplicationCommand() cmd.Command {
 type removeApplicationCommand struct {
 	modelcmd.ModelCommandBase
 	DestroyStorage   bool
+	DryRun           bool
 	ApplicationNames []string
 }
 
@@ -40,9 +41,14 @@ before removing them. Removing units which are co-located with units of
 other charms or a Juju controller will not result in the removal of the
 machine.
 
+With --dry-run, nothing is removed; instead the units, relations
+(including cross-model relations), storage and machines that would be
+removed or detached are listed.
+
 Examples:
     juju remove-application hadoop
-    juju remove-application -m test-model mariadb`[1:]
+    juju remove-application -m test-model mariadb
+    juju remove-application --dry-run mariadb`[1:]
 
 func (c *removeApplicationCommand) Info() *cmd.Info {
 	return &cmd.Info{
@@ -56,6 +62,7 @@ func (c *removeApplicationCommand) Info() *cmd.Info {
 func (c *removeApplicationCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.BoolVar(&c.DestroyStorage, "destroy-storage", false, "Destroy storage attached to application units")
+	f.BoolVar(&c.DryRun, "dry-run", false, "Show what would be removed, without removing anything")
 }
 
 func (c *removeApplicationCommand) Init(args []string) error {
@@ -74,6 +81,7 @@ func (c *removeApplicationCommand) Init(args []string) error {
 type removeApplicationAPI interface {
 	Close() error
 	DestroyApplications(application.DestroyApplicationsParams) ([]params.DestroyApplicationResult, error)
+	DestroyApplicationsPlan(application.DestroyApplicationsParams) ([]params.DestroyApplicationPlanResult, error)
 	DestroyDeprecated(appName string) error
 	DestroyUnits(application.DestroyUnitsParams) ([]params.DestroyUnitResult, error)
 	DestroyUnitsDeprecated(unitNames ...string) error
@@ -97,6 +105,12 @@ func (c *removeApplicationCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	if c.DryRun {
+		if apiVersion < 7 {
+			return errors.New("--dry-run is not supported by this controller")
+		}
+		return c.planRemoveApplications(ctx, client)
+	}
 	if apiVersion < 4 {
 		return c.removeApplicationsDeprecated(ctx, client)
 	}
@@ -171,3 +185,64 @@ func (c *removeApplicationCommand) removeApplications(
 	}
 	return nil
 }
+
+func (c *removeApplicationCommand) planRemoveApplications(
+	ctx *cmd.Context,
+	client removeApplicationAPI,
+) error {
+	results, err := client.DestroyApplicationsPlan(application.DestroyApplicationsParams{
+		Applications:   c.ApplicationNames,
+		DestroyStorage: c.DestroyStorage,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	anyFailed := false
+	for i, name := range c.ApplicationNames {
+		result := results[i]
+		if result.Error != nil {
+			ctx.Infof("checking removal of application %s failed: %s", name, result.Error)
+			anyFailed = true
+			continue
+		}
+		ctx.Infof("removing application %s would:", name)
+		plan := result.Plan
+		logPlanEntities(ctx, "remove", plan.DestroyedUnits)
+		logPlanEntities(ctx, "remove", plan.DestroyedStorage)
+		logPlanEntities(ctx, "detach", plan.DetachedStorage)
+		crossModel := make(map[string]bool)
+		for _, entity := range plan.RemovedCrossModelRelations {
+			crossModel[entity.Tag] = true
+		}
+		for _, entity := range plan.RemovedRelations {
+			relationTag, err := names.ParseRelationTag(entity.Tag)
+			if err != nil {
+				logger.Warningf("%s", err)
+				continue
+			}
+			if crossModel[entity.Tag] {
+				ctx.Infof("- remove cross-model %s", names.ReadableString(relationTag))
+			} else {
+				ctx.Infof("- remove %s", names.ReadableString(relationTag))
+			}
+		}
+		logPlanEntities(ctx, "remove", plan.DestroyedMachines)
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// logPlanEntities reports that the action would be taken on each of
+// the given entities.
+func logPlanEntities(ctx *cmd.Context, action string, entities []params.Entity) {
+	for _, entity := range entities {
+		tag, err := names.ParseTag(entity.Tag)
+		if err != nil {
+			logger.Warningf("%s", err)
+			continue
+		}
+		ctx.Infof("- %s %s", action, names.ReadableString(tag))
+	}
+}
@@ -60,6 +60,30 @@ func (s *RemoveApplicationSuite) TestLocalApplication(c *gc.C) {
 	c.Assert(multiSeries.Life(), gc.Equals, state.Dying)
 }
 
+func (s *RemoveApplicationSuite) TestDryRun(c *gc.C) {
+	s.setupTestApplication(c)
+	ctx, err := runRemoveApplication(c, "--dry-run", "multi-series")
+	c.Assert(err, jc.ErrorIsNil)
+	stderr := cmdtesting.Stderr(ctx)
+	c.Assert(stderr, gc.Equals, `
+removing application multi-series would:
+- remove unit multi-series/0
+`[1:])
+	multiSeries, err := s.State.Application("multi-series")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(multiSeries.Life(), gc.Equals, state.Alive)
+}
+
+func (s *RemoveApplicationSuite) TestDryRunFailure(c *gc.C) {
+	ctx, err := runRemoveApplication(c, "--dry-run", "gargleblaster")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+
+	stderr := cmdtesting.Stderr(ctx)
+	c.Assert(stderr, gc.Equals, `
+checking removal of application gargleblaster failed: application "gargleblaster" not found
+`[1:])
+}
+
 func (s *RemoveApplicationSuite) TestDetachStorage(c *gc.C) {
 	s.testStorageRemoval(c, false)
 }
@@ -4,6 +4,8 @@
 package application
 
 import (
+	"time"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
@@ -23,6 +25,8 @@ func NewRemoveUnitCommand() modelcmd.ModelCommand {
 type removeUnitCommand struct {
 	modelcmd.ModelCommandBase
 	DestroyStorage bool
+	DrainTimeout   time.Duration
+	Force          bool
 	UnitNames      []string
 }
 
@@ -41,9 +45,20 @@ Removing all units of a application is not equivalent to removing the
 application itself; for that, the ` + "`juju remove-application`" + ` command
 is used.
 
+If --drain-timeout is specified, the charm's drain hook is run before the
+unit's relations are broken, so that stateful workloads can be shut down in
+an orderly fashion. The unit waits at most the given time for the drain hook
+to succeed before continuing with its removal.
+
+If --force is specified, steps that could hold up the removal of the unit,
+such as draining or retrying a failed hook, are skipped. The skipped steps
+are reported for each unit.
+
 Examples:
 
     juju remove-unit wordpress/2 wordpress/3 wordpress/4
+    juju remove-unit --drain-timeout 10m mysql/1
+    juju remove-unit --force mysql/1
 
 See also:
     remove-application
@@ -61,6 +76,8 @@ func (c *removeUnitCommand) Info() *cmd.Info {
 func (c *removeUnitCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.BoolVar(&c.DestroyStorage, "destroy-storage", false, "Destroy storage attached to the unit")
+	f.DurationVar(&c.DrainTimeout, "drain-timeout", 0, "Run the charm's drain hook, waiting at most this long for it to succeed")
+	f.BoolVar(&c.Force, "force", false, "Skip steps that could hold up the removal of the unit")
 }
 
 func (c *removeUnitCommand) Init(args []string) error {
@@ -68,6 +85,9 @@ func (c *removeUnitCommand) Init(args []string) error {
 	if len(c.UnitNames) == 0 {
 		return errors.Errorf("no units specified")
 	}
+	if c.DrainTimeout < 0 {
+		return errors.Errorf("--drain-timeout must not be negative")
+	}
 	for _, name := range c.UnitNames {
 		if !names.IsValidUnit(name) {
 			return errors.Errorf("invalid unit name %q", name)
@@ -100,6 +120,9 @@ func (c *removeUnitCommand) Run(ctx *cmd.Context) error {
 	if c.DestroyStorage && apiVersion < 5 {
 		return errors.New("--destroy-storage is not supported by this controller")
 	}
+	if (c.DrainTimeout != 0 || c.Force) && apiVersion < 6 {
+		return errors.New("--drain-timeout and --force are not supported by this controller")
+	}
 	return c.removeUnits(ctx, client)
 }
 
@@ -114,6 +137,8 @@ func (c *removeUnitCommand) removeUnits(ctx *cmd.Context, client removeApplicati
 	results, err := client.DestroyUnits(application.DestroyUnitsParams{
 		Units:          c.UnitNames,
 		DestroyStorage: c.DestroyStorage,
+		DrainTimeout:   c.DrainTimeout,
+		Force:          c.Force,
 	})
 	if err != nil {
 		return block.ProcessBlockedError(err, block.BlockRemove)
@@ -143,6 +168,9 @@ func (c *removeUnitCommand) removeUnits(ctx *cmd.Context, client removeApplicati
 			}
 			ctx.Infof("- will detach %s", names.ReadableString(storageTag))
 		}
+		for _, step := range result.Info.SkippedSteps {
+			ctx.Infof("- skipped %s", step)
+		}
 	}
 	if anyFailed {
 		return cmd.ErrSilent
@@ -5,6 +5,7 @@ package application
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -14,6 +15,7 @@ import (
 
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
 	"github.com/juju/juju/testcharms"
 	"github.com/juju/juju/testing"
 )
@@ -100,6 +102,43 @@ removing unit storage-filesystem/0
 `[1:], action))
 }
 
+func (s *RemoveUnitSuite) TestRemoveUnitDrain(c *gc.C) {
+	s.setupUnitForRemove(c)
+	u, err := s.State.Unit("multi-series/0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	now := time.Now()
+	err = u.SetAgentStatus(status.StatusInfo{Status: status.Idle, Since: &now})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx, err := runRemoveUnit(c, "--drain-timeout", "10m", "multi-series/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "removing unit multi-series/0\n")
+
+	err = u.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(u.Life(), gc.Equals, state.Dying)
+	deadline, ok := u.DrainDeadline()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(deadline.After(now), jc.IsTrue)
+}
+
+func (s *RemoveUnitSuite) TestRemoveUnitForceReportsSkippedSteps(c *gc.C) {
+	s.setupUnitForRemove(c)
+	ctx, err := runRemoveUnit(c, "--force", "--drain-timeout", "10m", "multi-series/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+removing unit multi-series/0
+- skipped drain hook
+`[1:])
+}
+
+func (s *RemoveUnitSuite) TestRemoveUnitNegativeDrainTimeout(c *gc.C) {
+	_, err := runRemoveUnit(c, "--drain-timeout", "-1m", "multi-series/0")
+	c.Assert(err, gc.ErrorMatches, "--drain-timeout must not be negative")
+}
+
 func (s *RemoveUnitSuite) TestBlockRemoveUnit(c *gc.C) {
 	app := s.setupUnitForRemove(c)
 
@@ -0,0 +1,119 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/trust"
+)
+
+var usageTrustSummary = `
+Sets the trust level of an application.`[1:]
+
+var usageTrustDetails = `
+Trusting an application lets its charm get cloud credentials with the
+hook tool credential-get, so that it can act on the cloud hosting the
+model. Charms are never given the model's own credential: the
+controller mints short-lived credentials whose permissions are limited
+to the trust level granted.
+
+The trust levels are:
+    none       the charm cannot get cloud credentials
+    read-only  the charm may read, but not change, cloud resources
+    full       the charm may do anything the model's credential allows
+
+Not every cloud can mint limited credentials; charms deployed to those
+clouds cannot get credentials whatever their trust level.
+
+Only model administrators may set trust levels.
+
+Examples:
+    juju trust aws-integrator
+    juju trust prometheus --level read-only
+    juju trust aws-integrator --level none
+
+See also:
+    deploy`[1:]
+
+// NewTrustCommand returns a command to set the trust level of an
+// application.
+func NewTrustCommand() modelcmd.ModelCommand {
+	cmd := &trustCommand{}
+	cmd.newAPIFunc = func() (TrustAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// TrustAPI defines the API methods that the trust command uses.
+type TrustAPI interface {
+	Close() error
+	SetTrust(application string, level trust.Level) error
+}
+
+// trustCommand is responsible for setting the trust levels of
+// applications.
+type trustCommand struct {
+	modelcmd.ModelCommandBase
+	newAPIFunc func() (TrustAPI, error)
+
+	applicationName string
+	level           string
+}
+
+// Info is part of the cmd.Command interface.
+func (c *trustCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "trust",
+		Args:    "<application name>",
+		Purpose: usageTrustSummary,
+		Doc:     usageTrustDetails,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *trustCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.level, "level", string(trust.Full), "the trust level: none, read-only or full")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *trustCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.NotValidf("application name %q", args[0])
+	}
+	c.applicationName = args[0]
+	if err := trust.Level(c.level).Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *trustCommand) Run(_ *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	err = client.SetTrust(c.applicationName, trust.Level(c.level))
+	if errors.IsNotSupported(err) {
+		return errors.New("trusting applications is not supported by this version of Juju")
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,89 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/core/trust"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type TrustSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockTrustAPI
+}
+
+func (s *TrustSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockTrustAPI{Stub: &testing.Stub{}}
+}
+
+var _ = gc.Suite(&TrustSuite{})
+
+func (s *TrustSuite) runTrust(c *gc.C, args ...string) error {
+	_, err := cmdtesting.RunCommand(c, NewTrustCommandForTest(s.mockAPI), args...)
+	return err
+}
+
+func (s *TrustSuite) TestTrustInvalidArguments(c *gc.C) {
+	err := s.runTrust(c)
+	c.Assert(err, gc.ErrorMatches, "no application name specified")
+
+	err = s.runTrust(c, "no_good")
+	c.Assert(err, gc.ErrorMatches, `application name "no_good" not valid`)
+
+	err = s.runTrust(c, "mysql", "--level", "total")
+	c.Assert(err, gc.ErrorMatches, `trust level "total" not valid`)
+
+	err = s.runTrust(c, "mysql", "wordpress")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["wordpress"\]`)
+	s.mockAPI.CheckNoCalls(c)
+}
+
+func (s *TrustSuite) TestTrustDefaultsToFull(c *gc.C) {
+	err := s.runTrust(c, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"SetTrust", []interface{}{"mysql", trust.Full}},
+		{"Close", nil},
+	})
+}
+
+func (s *TrustSuite) TestTrustLevel(c *gc.C) {
+	err := s.runTrust(c, "mysql", "--level", "read-only")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "SetTrust", "mysql", trust.ReadOnly)
+}
+
+func (s *TrustSuite) TestTrustNotSupported(c *gc.C) {
+	s.mockAPI.SetErrors(errors.NotSupportedf("SetTrust"))
+	err := s.runTrust(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, "trusting applications is not supported by this version of Juju")
+}
+
+func (s *TrustSuite) TestTrustBlocked(c *gc.C) {
+	s.mockAPI.SetErrors(common.OperationBlockedError("TestTrustBlocked"))
+	err := s.runTrust(c, "mysql")
+	coretesting.AssertOperationWasBlocked(c, err, ".*TestTrustBlocked.*")
+}
+
+type mockTrustAPI struct {
+	*testing.Stub
+}
+
+func (s mockTrustAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return s.NextErr()
+}
+
+func (s mockTrustAPI) SetTrust(application string, level trust.Level) error {
+	s.MethodCall(s, "SetTrust", application, level)
+	return s.NextErr()
+}
@@ -150,6 +150,7 @@ type controllerListAPI interface {
 // BlockInfo defines the serialization behaviour of the block information.
 type BlockInfo struct {
 	Commands string `yaml:"command-set" json:"command-set"`
+	Entity   string `yaml:"entity,omitempty" json:"entity,omitempty"`
 	Message  string `yaml:"message,omitempty" json:"message,omitempty"`
 }
 
@@ -166,6 +167,11 @@ func formatBlockInfo(all []params.Block) []BlockInfo {
 			Commands: set,
 			Message:  one.Message,
 		}
+		// Blocks on the model as a whole carry the model tag;
+		// others apply only to a single application or machine.
+		if tag, err := names.ParseTag(one.Tag); err == nil && tag.Kind() != names.ModelTagKind {
+			output[i].Entity = names.ReadableString(tag)
+		}
 	}
 	return output
 }
@@ -182,11 +188,30 @@ func formatBlocks(writer io.Writer, value interface{}) error {
 		return nil
 	}
 
+	var showEntity bool
+	for _, info := range blocks {
+		if info.Entity != "" {
+			showEntity = true
+		}
+	}
+
 	tw := output.TabWriter(writer)
 	w := output.Wrapper{tw}
-	w.Println("Disabled commands", "Message")
+	if showEntity {
+		w.Println("Disabled commands", "Entity", "Message")
+	} else {
+		w.Println("Disabled commands", "Message")
+	}
 	for _, info := range blocks {
-		w.Println(info.Commands, info.Message)
+		if showEntity {
+			entity := info.Entity
+			if entity == "" {
+				entity = "model"
+			}
+			w.Println(info.Commands, entity, info.Message)
+		} else {
+			w.Println(info.Commands, info.Message)
+		}
 	}
 	tw.Flush()
 
@@ -87,6 +87,30 @@ func (s *listCommandSuite) TestList(c *gc.C) {
 	)
 }
 
+func (s *listCommandSuite) TestListEntityBlocks(c *gc.C) {
+	cmd := block.NewListCommandForTest(&mockListClient{
+		blocks: []params.Block{
+			{
+				Tag:     "model-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+				Type:    "BlockDestroy",
+				Message: "Sysadmins in control.",
+			}, {
+				Tag:     "application-mysql",
+				Type:    "BlockRemove",
+				Message: "production database",
+			},
+		},
+	}, nil)
+	ctx, err := cmdtesting.RunCommand(c, cmd)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Disabled commands  Entity             Message\n"+
+		"destroy-model      model              Sysadmins in control.\n"+
+		"remove-object      application mysql  production database\n"+
+		"\n",
+	)
+}
+
 func (s *listCommandSuite) TestListYAML(c *gc.C) {
 	cmd := block.NewListCommandForTest(s.mock(), nil)
 	ctx, err := cmdtesting.RunCommand(c, cmd, "--format", "yaml")
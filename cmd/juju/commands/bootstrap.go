@@ -5,6 +5,7 @@ package commands
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
 	"sort"
@@ -76,6 +77,14 @@ algorithm by assigning a "placement directive" via the '--to' option. This
 dictates what machine to use for the controller. This would typically be
 used with the MAAS provider ('--to <host>.maas').
 
+The controller may instead be placed into an LXD system container on the
+chosen machine, so that the machine may be shared with other services, by
+prefixing the directive with the container type ('--to lxd:<host>.maas',
+or just '--to lxd'). The bootstrap constraints then limit the CPU cores
+and memory available to the container, and so to the controller's
+database and API server. The controller's API port on the machine is
+forwarded to the container.
+
 Available keys for use with --config can be found here:
     https://jujucharms.com/docs/stable/controllers-config
     https://jujucharms.com/docs/stable/models-config
@@ -104,6 +113,16 @@ In other words, a 2.2.1 client can bootstrap any 2.2.x agents but cannot
 bootstrap any 2.0.x or 2.1.x agents.
 The agent version can be specified a simple numeric version, e.g. 2.2.4.
 
+Progress through the phases of bootstrap is recorded in the client's
+bootstrap configuration, and on the controller instance where the cloud
+supports instance tags. If bootstrap fails after the controller instance
+has started, and '--keep-broken' was specified, the same bootstrap command
+may be run again with '--resume' to continue from the last completed phase.
+
+'--dry-run' prints the planned bootstrap phases, along with the agent
+binaries, series and image that would be used, without starting any
+instances or recording the controller.
+
 Examples:
     juju bootstrap
     juju bootstrap --clouds
@@ -114,6 +133,9 @@ Examples:
     juju bootstrap --config=~/config-rs.yaml rackspace joe-syd
     juju bootstrap --agent-version=2.2.4 aws joe-us-east-1
     juju bootstrap --config bootstrap-timeout=1200 azure joe-eastus
+    juju bootstrap --dry-run aws joe-us-east-1
+    juju bootstrap --keep-broken aws joe-us-east-1
+    juju bootstrap --resume aws joe-us-east-1
 
 See also:
     add-credentials
@@ -148,6 +170,7 @@ type bootstrapCommand struct {
 	BuildAgent              bool
 	MetadataSource          string
 	Placement               string
+	ControllerContainerType instance.ContainerType
 	KeepBrokenEnvironment   bool
 	AutoUpgrade             bool
 	AgentVersionParam       string
@@ -165,6 +188,8 @@ type bootstrapCommand struct {
 	noGUI               bool
 	noSwitch            bool
 	interactive         bool
+	resume              bool
+	dryRun              bool
 }
 
 func (c *bootstrapCommand) Info() *cmd.Info {
@@ -199,6 +224,8 @@ func (c *bootstrapCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.showRegionsForCloud, "regions", "", "Print the available regions for the specified cloud")
 	f.BoolVar(&c.noGUI, "no-gui", false, "Do not install the Juju GUI in the controller when bootstrapping")
 	f.BoolVar(&c.noSwitch, "no-switch", false, "Do not switch to the newly created controller")
+	f.BoolVar(&c.resume, "resume", false, "Resume a failed bootstrap from its last completed phase")
+	f.BoolVar(&c.dryRun, "dry-run", false, "Print the planned bootstrap phases, agent binaries and image, without bootstrapping")
 }
 
 func (c *bootstrapCommand) Init(args []string) (err error) {
@@ -214,12 +241,31 @@ func (c *bootstrapCommand) Init(args []string) (err error) {
 	if c.AgentVersionParam != "" && c.BuildAgent {
 		return errors.New("--agent-version and --build-agent can't be used together")
 	}
+	if c.resume && c.dryRun {
+		return errors.New("--resume and --dry-run can't be used together")
+	}
+	if c.dryRun {
+		// A dry run does not record the controller, so there
+		// is nothing to switch to.
+		c.noSwitch = true
+	}
 	if c.BootstrapSeries != "" && !charm.IsValidSeries(c.BootstrapSeries) {
 		return errors.NotValidf("series %q", c.BootstrapSeries)
 	}
 
 	// Parse the placement directive. Bootstrap currently only
-	// supports provider-specific placement directives.
+	// supports provider-specific placement directives, optionally
+	// scoped with the type of container to place the controller in.
+	if c.Placement != "" {
+		scope, directive := c.Placement, ""
+		if colon := strings.IndexRune(c.Placement, ':'); colon != -1 {
+			scope, directive = c.Placement[:colon], c.Placement[colon+1:]
+		}
+		if scope == string(instance.LXD) {
+			c.ControllerContainerType = instance.LXD
+			c.Placement = directive
+		}
+	}
 	if c.Placement != "" {
 		_, err = instance.ParsePlacement(c.Placement)
 		if err != instance.ErrPlacementScopeMissing {
@@ -310,6 +356,7 @@ var getBootstrapFuncs = func() BootstrapInterface {
 
 var (
 	bootstrapPrepare           = bootstrap.Prepare
+	bootstrapResumeEnviron     = resumeEnviron
 	environsDestroy            = environs.Destroy
 	waitForAgentInitialisation = common.WaitForAgentInitialisation
 )
@@ -421,6 +468,10 @@ func (c *bootstrapCommand) Run(ctx *cmd.Context) (resultErr error) {
 	// Read existing current controller so we can clean up on error.
 	var oldCurrentController string
 	store := c.ClientStore()
+	if c.dryRun {
+		// A dry run must not modify the client store.
+		store = jujuclient.NewMemStore()
+	}
 	oldCurrentController, err = store.CurrentController()
 	if errors.IsNotFound(err) {
 		oldCurrentController = ""
@@ -428,10 +479,27 @@ func (c *bootstrapCommand) Run(ctx *cmd.Context) (resultErr error) {
 		return errors.Annotate(err, "error reading current controller")
 	}
 
+	// A failed bootstrap may be resumed if --keep-broken or --resume
+	// was specified, and the controller instance was started.
+	checkpoints := bootstrap.NewCheckpointStore(store, c.controllerName)
+	resumable := func() bool {
+		if !c.KeepBrokenEnvironment && !c.resume {
+			return false
+		}
+		checkpoint, err := checkpoints.Checkpoint()
+		return err == nil && checkpoint.InstanceId != ""
+	}
+
 	defer func() {
 		if resultErr == nil || errors.IsAlreadyExists(resultErr) {
 			return
 		}
+		if c.resume || resumable() {
+			// Leave the controller details in place so
+			// that the bootstrap may be resumed, or the
+			// controller killed.
+			return
+		}
 		if oldCurrentController != "" {
 			if err := store.SetCurrentController(oldCurrentController); err != nil {
 				logger.Errorf(
@@ -448,39 +516,48 @@ func (c *bootstrapCommand) Run(ctx *cmd.Context) (resultErr error) {
 		}
 	}()
 
-	environ, err := bootstrapPrepare(
-		modelcmd.BootstrapContext(ctx), store,
-		bootstrap.PrepareParams{
-			ModelConfig:      config.bootstrapModel,
-			ControllerConfig: config.controller,
-			ControllerName:   c.controllerName,
-			Cloud: environs.CloudSpec{
-				Type:             cloud.Type,
-				Name:             cloud.Name,
-				Region:           region.Name,
-				Endpoint:         region.Endpoint,
-				IdentityEndpoint: region.IdentityEndpoint,
-				StorageEndpoint:  region.StorageEndpoint,
-				Credential:       credentials.credential,
+	var environ environs.Environ
+	var hostedModelUUID utils.UUID
+	if c.resume {
+		environ, hostedModelUUID, err = c.prepareResume(ctx, store, checkpoints, &config)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	} else {
+		environ, err = bootstrapPrepare(
+			modelcmd.BootstrapContext(ctx), store,
+			bootstrap.PrepareParams{
+				ModelConfig:      config.bootstrapModel,
+				ControllerConfig: config.controller,
+				ControllerName:   c.controllerName,
+				Cloud: environs.CloudSpec{
+					Type:             cloud.Type,
+					Name:             cloud.Name,
+					Region:           region.Name,
+					Endpoint:         region.Endpoint,
+					IdentityEndpoint: region.IdentityEndpoint,
+					StorageEndpoint:  region.StorageEndpoint,
+					Credential:       credentials.credential,
+				},
+				CredentialName: credentials.name,
+				AdminSecret:    config.bootstrap.AdminSecret,
 			},
-			CredentialName: credentials.name,
-			AdminSecret:    config.bootstrap.AdminSecret,
-		},
-	)
-	if err != nil {
-		return errors.Trace(err)
-	}
+		)
+		if err != nil {
+			return errors.Trace(err)
+		}
 
-	hostedModelUUID, err := utils.NewUUID()
-	if err != nil {
-		return errors.Trace(err)
-	}
+		hostedModelUUID, err = utils.NewUUID()
+		if err != nil {
+			return errors.Trace(err)
+		}
 
-	// Set the current model to the initial hosted model.
-	if err := store.UpdateModel(c.controllerName, c.hostedModelName, jujuclient.ModelDetails{
-		hostedModelUUID.String(),
-	}); err != nil {
-		return errors.Trace(err)
+		// Set the current model to the initial hosted model.
+		if err := store.UpdateModel(c.controllerName, c.hostedModelName, jujuclient.ModelDetails{
+			hostedModelUUID.String(),
+		}); err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	if !c.noSwitch {
@@ -496,15 +573,27 @@ func (c *bootstrapCommand) Run(ctx *cmd.Context) (resultErr error) {
 	if region.Name != "" {
 		cloudRegion = fmt.Sprintf("%s/%s", cloudRegion, region.Name)
 	}
-	ctx.Infof(
-		"Creating Juju controller %q on %s",
-		c.controllerName, cloudRegion,
-	)
+	switch {
+	case c.dryRun:
+		ctx.Infof("Planning Juju controller %q on %s", c.controllerName, cloudRegion)
+	case c.resume:
+		ctx.Infof("Resuming bootstrap of Juju controller %q on %s", c.controllerName, cloudRegion)
+	default:
+		ctx.Infof(
+			"Creating Juju controller %q on %s",
+			c.controllerName, cloudRegion,
+		)
+	}
 
 	// If we error out for any reason, clean up the environment.
 	defer func() {
 		if resultErr != nil {
-			if c.KeepBrokenEnvironment {
+			if resumable() {
+				ctx.Infof(`
+bootstrap failed, but may be resumed once the problem has been resolved
+by running the same bootstrap command again with --resume.
+To abandon the controller instead, use `[1:] + "`juju kill-controller`" + `.`)
+			} else if c.KeepBrokenEnvironment {
 				ctx.Infof(`
 bootstrap failed but --keep-broken was specified. 
 This means that cloud resources are left behind, but not registered to 
@@ -515,7 +604,7 @@ When you are ready to clean up the failed controller, use your cloud console or
 equivalent CLI tools to terminate the instances and remove remaining resources. 
 
 See `[1:] + "`juju kill-controller`" + `.`)
-			} else {
+			} else if !c.resume && !c.dryRun {
 				logger.Errorf("%v", resultErr)
 				logger.Debugf("(error details: %v)", errors.Details(resultErr))
 				// Set resultErr to cmd.ErrSilent to prevent
@@ -531,7 +620,10 @@ See `[1:] + "`juju kill-controller`" + `.`)
 	}()
 
 	// Block interruption during bootstrap. Providers may also
-	// register for interrupt notification so they can exit early.
+	// register for interrupt notification so they can exit early,
+	// and in-flight provider operations are cancelled.
+	bootstrapCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 	interrupted := make(chan os.Signal, 1)
 	defer close(interrupted)
 	ctx.InterruptNotify(interrupted)
@@ -539,6 +631,7 @@ See `[1:] + "`juju kill-controller`" + `.`)
 	go func() {
 		for _ = range interrupted {
 			ctx.Infof("Interrupt signalled: waiting for bootstrap to exit")
+			cancel()
 		}
 	}()
 
@@ -580,12 +673,13 @@ See `[1:] + "`juju kill-controller`" + `.`)
 	}
 
 	bootstrapFuncs := getBootstrapFuncs()
-	err = bootstrapFuncs.Bootstrap(modelcmd.BootstrapContext(ctx), environ, bootstrap.BootstrapParams{
+	err = bootstrapFuncs.Bootstrap(modelcmd.CancellableBootstrapContext(bootstrapCtx, ctx), environ, bootstrap.BootstrapParams{
 		ModelConstraints:          c.Constraints,
 		BootstrapConstraints:      bootstrapConstraints,
 		BootstrapSeries:           c.BootstrapSeries,
 		BootstrapImage:            c.BootstrapImage,
 		Placement:                 c.Placement,
+		ControllerContainerType:   c.ControllerContainerType,
 		BuildAgent:                c.BuildAgent,
 		BuildAgentTarball:         sync.BuildAgentTarball,
 		AgentVersion:              c.AgentVersion,
@@ -606,10 +700,16 @@ See `[1:] + "`juju kill-controller`" + `.`)
 			RetryDelay:     config.bootstrap.BootstrapRetryDelay,
 			AddressesDelay: config.bootstrap.BootstrapAddressesDelay,
 		},
+		Checkpoints: checkpoints,
+		Resume:      c.resume,
+		DryRun:      c.dryRun,
 	})
 	if err != nil {
 		return errors.Annotate(err, "failed to bootstrap model")
 	}
+	if c.dryRun {
+		return nil
+	}
 
 	if err := c.SetModelName(modelcmd.JoinModelName(c.controllerName, c.hostedModelName), false); err != nil {
 		return errors.Trace(err)
@@ -639,7 +739,90 @@ See `[1:] + "`juju kill-controller`" + `.`)
 	// To avoid race conditions when running scripted bootstraps, wait
 	// for the controller's machine agent to be ready to accept commands
 	// before exiting this bootstrap command.
-	return waitForAgentInitialisation(ctx, &c.ModelCommandBase, c.controllerName, c.hostedModelName)
+	if err := waitForAgentInitialisation(ctx, &c.ModelCommandBase, c.controllerName, c.hostedModelName); err != nil {
+		return errors.Trace(err)
+	}
+	// The bootstrap has completed, so there is nothing left to resume.
+	return errors.Annotate(checkpoints.RemoveCheckpoint(), "removing bootstrap checkpoint")
+}
+
+// prepareResume opens the environ of a controller whose bootstrap is to
+// be resumed, and updates the given configuration with the identity and
+// secrets recorded when the bootstrap was started. The hosted model's
+// UUID is returned along with the environ.
+func (c *bootstrapCommand) prepareResume(
+	ctx *cmd.Context,
+	store jujuclient.ClientStore,
+	checkpoints bootstrap.CheckpointStore,
+	config *bootstrapConfigs,
+) (environs.Environ, utils.UUID, error) {
+	checkpoint, err := checkpoints.Checkpoint()
+	if errors.IsNotFound(err) {
+		return nil, utils.UUID{}, errors.Errorf(
+			"controller %q has no bootstrap to resume", c.controllerName,
+		)
+	} else if err != nil {
+		return nil, utils.UUID{}, errors.Trace(err)
+	}
+	details, err := store.ControllerByName(c.controllerName)
+	if err != nil {
+		return nil, utils.UUID{}, errors.Trace(err)
+	}
+	config.controller[controller.ControllerUUIDKey] = details.ControllerUUID
+	if bootstrap.Phase(checkpoint.Phase) == bootstrap.PhaseStartInstance {
+		// The controller's certificates are installed when the
+		// agent is, so the newly generated CA may be used.
+		details.CACert, _ = config.controller.CACert()
+		if err := store.UpdateController(c.controllerName, *details); err != nil {
+			return nil, utils.UUID{}, errors.Trace(err)
+		}
+	} else {
+		config.controller[controller.CACertKey] = details.CACert
+	}
+
+	account, err := store.AccountDetails(c.controllerName)
+	if err != nil {
+		return nil, utils.UUID{}, errors.Trace(err)
+	}
+	config.bootstrap.AdminSecret = account.Password
+
+	model, err := store.ModelByName(c.controllerName, c.hostedModelName)
+	if err != nil {
+		return nil, utils.UUID{}, errors.Trace(err)
+	}
+	hostedModelUUID, err := utils.UUIDFromString(model.ModelUUID)
+	if err != nil {
+		return nil, utils.UUID{}, errors.Trace(err)
+	}
+
+	environ, err := bootstrapResumeEnviron(ctx, store, c.controllerName)
+	if err != nil {
+		return nil, utils.UUID{}, errors.Annotate(err, "opening controller environ")
+	}
+	return environ, hostedModelUUID, nil
+}
+
+// resumeEnviron opens the environ of the named controller using the
+// bootstrap config recorded in the client store.
+func resumeEnviron(ctx *cmd.Context, store jujuclient.ClientStore, controllerName string) (environs.Environ, error) {
+	bootstrapConfig, params, err := modelcmd.NewGetBootstrapConfigParamsFunc(
+		ctx, store, environs.GlobalProviderRegistry(),
+	)(controllerName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	provider, err := environs.Provider(bootstrapConfig.CloudType)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg, err := provider.PrepareConfig(*params)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return environs.New(environs.OpenParams{
+		Cloud:  params.Cloud,
+		Config: cfg,
+	})
 }
 
 func (c *bootstrapCommand) handleCommandLineErrorsAndInfoRequests(ctx *cmd.Context) (bool, error) {
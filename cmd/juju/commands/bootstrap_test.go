@@ -191,6 +191,7 @@ type bootstrapTest struct {
 	constraints          constraints.Value
 	bootstrapConstraints constraints.Value
 	placement            string
+	containerType        instance.ContainerType
 	hostArch             string
 	keepBroken           bool
 }
@@ -287,6 +288,7 @@ func (s *BootstrapSuite) run(c *gc.C, test bootstrapTest) testing.Restorer {
 	opFinalizeBootstrap := (<-opc).(dummy.OpFinalizeBootstrap)
 	c.Check(opFinalizeBootstrap.Env, gc.Equals, bootstrap.ControllerModelName)
 	c.Check(opFinalizeBootstrap.InstanceConfig.ToolsList(), gc.Not(gc.HasLen), 0)
+	c.Check(opFinalizeBootstrap.InstanceConfig.Bootstrap.ControllerContainerType, gc.Equals, test.containerType)
 	if test.upload != "" {
 		c.Check(opFinalizeBootstrap.InstanceConfig.AgentVersion().String(), gc.Equals, test.upload)
 	}
@@ -401,6 +403,19 @@ var bootstrapTests = []bootstrapTest{{
 	info:      "placement",
 	args:      []string{"--to", "something"},
 	placement: "something",
+}, {
+	info:          "placement in a container",
+	args:          []string{"--to", "lxd:something"},
+	placement:     "something",
+	containerType: instance.LXD,
+}, {
+	info:          "placement in a container on any machine",
+	args:          []string{"--to", "lxd"},
+	containerType: instance.LXD,
+}, {
+	info: "placement in an unsupported container",
+	args: []string{"--to", "kvm:0"},
+	err:  `unsupported bootstrap placement directive "kvm:0"`,
 }, {
 	info:       "keep broken",
 	args:       []string{"--keep-broken"},
@@ -558,6 +573,132 @@ func (s *BootstrapSuite) TestBootstrapDefaultModel(c *gc.C) {
 	c.Assert(bootstrap.args.HostedModelConfig["foo"], gc.Equals, "bar")
 }
 
+func (s *BootstrapSuite) TestBootstrapDryRun(c *gc.C) {
+	s.patchVersionAndSeries(c, "raring")
+
+	var bootstrap fakeBootstrapFuncs
+	s.PatchValue(&getBootstrapFuncs, func() BootstrapInterface {
+		return &bootstrap
+	})
+	_, err := cmdtesting.RunCommand(
+		c, s.newBootstrapCommand(), "dummy", "devcontroller", "--auto-upgrade", "--dry-run",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bootstrap.args.DryRun, jc.IsTrue)
+	c.Assert(bootstrap.args.Resume, jc.IsFalse)
+
+	// Nothing is recorded in the client store for a dry run.
+	_, err = s.store.ControllerByName("devcontroller")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(s.store.CurrentControllerName, gc.Equals, "")
+}
+
+func (s *BootstrapSuite) TestBootstrapResumeAndDryRunConflict(c *gc.C) {
+	_, err := cmdtesting.RunCommand(
+		c, s.newBootstrapCommand(), "dummy", "devcontroller", "--resume", "--dry-run",
+	)
+	c.Assert(err, gc.ErrorMatches, "--resume and --dry-run can't be used together")
+}
+
+func (s *BootstrapSuite) TestBootstrapResumeNoCheckpoint(c *gc.C) {
+	s.patchVersionAndSeries(c, "raring")
+	s.writeResumableController(c, nil)
+
+	var bootstrap fakeBootstrapFuncs
+	s.PatchValue(&getBootstrapFuncs, func() BootstrapInterface {
+		return &bootstrap
+	})
+	_, err := cmdtesting.RunCommand(
+		c, s.newBootstrapCommand(), "dummy", "devcontroller", "--auto-upgrade", "--resume",
+	)
+	c.Assert(err, gc.ErrorMatches, `controller "devcontroller" has no bootstrap to resume`)
+
+	// The stored controller is left alone.
+	_, err = s.store.ControllerByName("devcontroller")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *BootstrapSuite) TestBootstrapResume(c *gc.C) {
+	s.patchVersionAndSeries(c, "raring")
+	s.writeResumableController(c, &jujuclient.BootstrapCheckpoint{
+		Phase:      "start-instance",
+		InstanceId: "i-0",
+		Series:     "raring",
+		Arch:       "amd64",
+	})
+
+	env, err := environs.New(environs.OpenParams{
+		Cloud:  dummy.SampleCloudSpec(),
+		Config: coretesting.CustomModelConfig(c, dummy.SampleConfig()),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(&bootstrapResumeEnviron, func(*cmd.Context, jujuclient.ClientStore, string) (environs.Environ, error) {
+		return env, nil
+	})
+
+	var bootstrap fakeBootstrapFuncs
+	s.PatchValue(&getBootstrapFuncs, func() BootstrapInterface {
+		return &bootstrap
+	})
+	// The fake bootstrap does not start a controller, so the command
+	// fails once it tries to contact it; the parameters passed to
+	// Bootstrap are what matter here.
+	cmdtesting.RunCommand(
+		c, s.newBootstrapCommand(), "dummy", "devcontroller", "--auto-upgrade", "--resume",
+	)
+	c.Assert(bootstrap.args.Resume, jc.IsTrue)
+	c.Assert(bootstrap.args.Checkpoints, gc.NotNil)
+	c.Assert(bootstrap.args.ControllerConfig.ControllerUUID(), gc.Equals, resumeControllerUUID)
+	c.Assert(bootstrap.args.AdminSecret, gc.Equals, "sekrit")
+	c.Assert(bootstrap.args.HostedModelConfig[config.UUIDKey], gc.Equals, resumeModelUUID)
+
+	// A new CA certificate is generated when resuming before the
+	// agent has been installed, and the stored details updated.
+	caCert, _ := bootstrap.args.ControllerConfig.CACert()
+	details, err := s.store.ControllerByName("devcontroller")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(details.CACert, gc.Equals, caCert)
+	c.Assert(details.CACert, gc.Not(gc.Equals), "old-ca-cert")
+
+	// A failed resume leaves the controller in place to try again.
+	bootstrapConfig, err := s.store.BootstrapConfigForController("devcontroller")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bootstrapConfig.Checkpoint, gc.NotNil)
+}
+
+const (
+	resumeControllerUUID = "f9a3ae2b-0d2d-4b05-8fd9-4b0d3a7bd1b4"
+	resumeModelUUID      = "3b4c1ba4-c0f1-4d2b-9f4b-5e9d4e6f3a7c"
+)
+
+// writeResumableController records the client details of a
+// controller whose bootstrap failed at the given checkpoint.
+func (s *BootstrapSuite) writeResumableController(c *gc.C, checkpoint *jujuclient.BootstrapCheckpoint) {
+	err := s.store.AddController("devcontroller", jujuclient.ControllerDetails{
+		ControllerUUID: resumeControllerUUID,
+		CACert:         "old-ca-cert",
+		Cloud:          "dummy",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.UpdateBootstrapConfig("devcontroller", jujuclient.BootstrapConfig{
+		ControllerConfig: coretesting.FakeControllerConfig(),
+		Config:           dummy.SampleConfig(),
+		Cloud:            "dummy",
+		CloudType:        "dummy",
+		Checkpoint:       checkpoint,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.UpdateAccount("devcontroller", jujuclient.AccountDetails{
+		User:     "admin",
+		Password: "sekrit",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.UpdateModel("devcontroller", "default", jujuclient.ModelDetails{
+		ModelUUID: resumeModelUUID,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *BootstrapSuite) TestBootstrapTimeout(c *gc.C) {
 	s.patchVersionAndSeries(c, "raring")
 
@@ -9,12 +9,14 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"github.com/juju/utils/set"
 	"gopkg.in/juju/charm.v6/hooks"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/action"
 	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/api/debughooks"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/network/ssh"
@@ -24,6 +26,7 @@ import (
 func newDebugHooksCommand(hostChecker ssh.ReachableChecker) cmd.Command {
 	c := new(debugHooksCommand)
 	c.getActionAPI = c.newActionsAPI
+	c.getDebugHooksAPI = c.newDebugHooksAPI
 	c.setHostChecker(hostChecker)
 	return modelcmd.Wrap(c)
 }
@@ -31,9 +34,11 @@ func newDebugHooksCommand(hostChecker ssh.ReachableChecker) cmd.Command {
 // debugHooksCommand is responsible for launching a ssh shell on a given unit or machine.
 type debugHooksCommand struct {
 	sshCommand
-	hooks []string
+	hooks         []string
+	viaController bool
 
-	getActionAPI func() (ActionsAPI, error)
+	getActionAPI     func() (ActionsAPI, error)
+	getDebugHooksAPI func() (DebugHooksAPI, error)
 }
 
 const debugHooksDoc = `
@@ -41,6 +46,19 @@ Interactively debug hooks or actions remotely on an application unit.
 
 See the "juju help ssh" for information about SSH related options
 accepted by the debug-hooks command.
+
+With --via-controller, the session is relayed by the controller rather
+than run in tmux over SSH, so the unit's machine need not be reachable
+over SSH. Each intercepted hook or action is announced as the unit
+starts it; a shell in the hook's environment then reads commands from
+standard input until it exits, when the unit carries on. The session
+ends at the end of input, or on interrupt. There is no terminal, so
+programs that need one, such as editors, cannot be used.
+
+Examples:
+
+    juju debug-hooks mysql/0 install config-changed
+    juju debug-hooks --via-controller mysql/0 '*'
 `
 
 func (c *debugHooksCommand) Info() *cmd.Info {
@@ -52,6 +70,11 @@ func (c *debugHooksCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *debugHooksCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.sshCommand.SetFlags(f)
+	f.BoolVar(&c.viaController, "via-controller", false, "Relay the session through the controller instead of SSH")
+}
+
 func (c *debugHooksCommand) Init(args []string) error {
 	if len(args) < 1 {
 		return errors.Errorf("no unit name specified")
@@ -80,6 +103,10 @@ type ActionsAPI interface {
 	ApplicationCharmActions(params.Entity) (map[string]params.ActionSpec, error)
 }
 
+type DebugHooksAPI interface {
+	OpenSession(unitName string, hooks []string) (debughooks.Session, error)
+}
+
 func (c *debugHooksCommand) getApplicationAPI() (charmRelationsAPI, error) {
 	root, err := c.NewAPIRoot()
 	if err != nil {
@@ -96,6 +123,14 @@ func (c *debugHooksCommand) newActionsAPI() (ActionsAPI, error) {
 	return action.NewClient(root), nil
 }
 
+func (c *debugHooksCommand) newDebugHooksAPI() (DebugHooksAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, err
+	}
+	return debughooks.NewAPI(root), nil
+}
+
 func (c *debugHooksCommand) validateHooksOrActions() error {
 	if len(c.hooks) == 0 {
 		return nil
@@ -179,6 +214,9 @@ func (c *debugHooksCommand) getValidHooks(appName string) (set.Strings, error) {
 // and connects to it via SSH to execute the debug-hooks
 // script.
 func (c *debugHooksCommand) Run(ctx *cmd.Context) error {
+	if c.viaController {
+		return c.runViaController(ctx)
+	}
 	err := c.initRun()
 	if err != nil {
 		return err
@@ -195,3 +233,59 @@ func (c *debugHooksCommand) Run(ctx *cmd.Context) error {
 	c.Args = args
 	return c.sshCommand.Run(ctx)
 }
+
+// runViaController opens a debug-hooks session for c.Target through the
+// controller, and relays standard input to the intercepted hooks' shells
+// and their output to standard output.
+func (c *debugHooksCommand) runViaController(ctx *cmd.Context) error {
+	if err := c.validateHooksOrActions(); err != nil {
+		return err
+	}
+	api, err := c.getDebugHooksAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	session, err := api.OpenSession(c.Target, c.hooks)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer session.Close()
+	ctx.Infof("Waiting for %s to run hooks or actions to debug...", c.Target)
+
+	go func() {
+		// The session ends at the end of input.
+		defer session.Close()
+		buf := make([]byte, 4096)
+		for {
+			n, err := ctx.Stdin.Read(buf)
+			if n > 0 {
+				if sendErr := session.Send(buf[:n]); sendErr != nil {
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	var hook string
+	for {
+		m, err := session.Next()
+		if err != nil {
+			// The session was closed, by us or by the controller.
+			return nil
+		}
+		switch {
+		case m.Hook != "":
+			hook = m.Hook
+			ctx.Infof("Debugging %q on %s; run 'exit' to let the unit continue.", hook, c.Target)
+		case m.ExitCode != nil:
+			ctx.Infof("%q exited with code %d.", hook, *m.ExitCode)
+		default:
+			if _, err := ctx.Stdout.Write(m.Data); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
@@ -4,13 +4,19 @@
 package commands
 
 import (
+	"errors"
 	"regexp"
 	"runtime"
+	"strings"
+	"sync"
 
 	"github.com/juju/cmd/cmdtesting"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/api/debughooks"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
 	jujussh "github.com/juju/juju/network/ssh"
 )
 
@@ -147,3 +153,104 @@ func (s *DebugHooksSuite) TestDebugHooksCommand(c *gc.C) {
 		}
 	}
 }
+
+func (s *DebugHooksSuite) TestDebugHooksViaController(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("bug 1403084: Skipping on windows for now")
+	}
+	s.setupModel(c)
+
+	exitCode := 0
+	session := newFakeDebugHooksSession(
+		params.DebugHooksMessage{Hook: "install"},
+		params.DebugHooksMessage{Data: []byte("hooks\n")},
+		params.DebugHooksMessage{ExitCode: &exitCode},
+	)
+	var openedUnit string
+	var openedHooks []string
+	debugHooksCmd := new(debugHooksCommand)
+	debugHooksCmd.getActionAPI = debugHooksCmd.newActionsAPI
+	debugHooksCmd.getDebugHooksAPI = func() (DebugHooksAPI, error) {
+		return debugHooksAPIFunc(func(unitName string, hooks []string) (debughooks.Session, error) {
+			openedUnit, openedHooks = unitName, hooks
+			return session, nil
+		}), nil
+	}
+	command := modelcmd.Wrap(debugHooksCmd)
+
+	ctx := cmdtesting.Context(c)
+	ctx.Stdin = strings.NewReader("ls\n")
+	err := cmdtesting.InitCommand(command, []string{"--via-controller", "mysql/0", "install"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = command.Run(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(openedUnit, gc.Equals, "mysql/0")
+	c.Assert(openedHooks, jc.DeepEquals, []string{"install"})
+	c.Assert(session.sent(), gc.Equals, "ls\n")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "hooks\n")
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, `Debugging "install" on mysql/0`)
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, `"install" exited with code 0.`)
+}
+
+func (s *DebugHooksSuite) TestDebugHooksViaControllerInvalidHook(c *gc.C) {
+	s.setupModel(c)
+	_, err := cmdtesting.RunCommand(c, newDebugHooksCommand(s.hostChecker), "--via-controller", "mysql/0", "invalid-hook")
+	c.Assert(err, gc.ErrorMatches, `unit "mysql/0" contains neither hook nor action "invalid-hook".*`)
+}
+
+type debugHooksAPIFunc func(string, []string) (debughooks.Session, error)
+
+func (f debugHooksAPIFunc) OpenSession(unitName string, hooks []string) (debughooks.Session, error) {
+	return f(unitName, hooks)
+}
+
+// fakeDebugHooksSession returns its messages, and then blocks
+// until it is closed.
+type fakeDebugHooksSession struct {
+	messages chan params.DebugHooksMessage
+	closed   chan struct{}
+
+	mu    sync.Mutex
+	input []string
+	once  sync.Once
+}
+
+func newFakeDebugHooksSession(messages ...params.DebugHooksMessage) *fakeDebugHooksSession {
+	s := &fakeDebugHooksSession{
+		messages: make(chan params.DebugHooksMessage, len(messages)),
+		closed:   make(chan struct{}),
+	}
+	for _, m := range messages {
+		s.messages <- m
+	}
+	return s
+}
+
+func (s *fakeDebugHooksSession) Next() (params.DebugHooksMessage, error) {
+	select {
+	case m := <-s.messages:
+		return m, nil
+	default:
+	}
+	<-s.closed
+	return params.DebugHooksMessage{}, errors.New("session closed")
+}
+
+func (s *fakeDebugHooksSession) Send(data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.input = append(s.input, string(data))
+	return nil
+}
+
+func (s *fakeDebugHooksSession) Close() error {
+	s.once.Do(func() { close(s.closed) })
+	return nil
+}
+
+func (s *fakeDebugHooksSession) sent() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return strings.Join(s.input, "")
+}
@@ -72,6 +72,11 @@ controller.
 
 An odd number of controllers is required.
 
+A newly bootstrapped controller runs its database with lighter-weight
+settings suited to a single node. When other controllers are added, the
+existing controller's database is converted to the settings used for high
+availability while it keeps running.
+
 Examples:
     # Ensure that the controller is still in highly available mode. If
     # there is only 1 controller running, this will ensure there
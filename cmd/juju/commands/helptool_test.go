@@ -38,6 +38,8 @@ Currently available charm hook tools are:
     application-version-set  specify which version of the application is deployed
     close-port               ensure a port or range is always closed
     config-get               print application configuration
+    credential-get           get a cloud credential for a trusted application
+    health-set               override unit health
     is-leader                print application leadership status
     juju-log                 write a message to the juju log
     juju-reboot              Reboot the host machine
@@ -75,6 +77,8 @@ var expectedCommands = []string{
 	"application-version-set",
 	"close-port",
 	"config-get",
+	"credential-get",
+	"health-set",
 	"is-leader",
 	"juju-log",
 	"juju-reboot",
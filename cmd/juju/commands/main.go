@@ -34,6 +34,7 @@ import (
 	"github.com/juju/juju/cmd/juju/controller"
 	"github.com/juju/juju/cmd/juju/crossmodel"
 	"github.com/juju/juju/cmd/juju/firewall"
+	"github.com/juju/juju/cmd/juju/fleet"
 	"github.com/juju/juju/cmd/juju/gui"
 	"github.com/juju/juju/cmd/juju/machine"
 	"github.com/juju/juju/cmd/juju/metricsdebug"
@@ -170,7 +171,13 @@ func (m main) Run(args []string) int {
 	}
 
 	jcmd := NewJujuCommand(ctx)
-	return cmd.Main(jcmd, ctx, args[1:])
+	code := cmd.Main(jcmd, ctx, args[1:])
+	// Commands share API connections, so close them cleanly rather
+	// than leaving the process exit to drop them.
+	if err := juju.CloseSharedAPIConnections(); err != nil {
+		logger.Debugf("closing API connections: %v", err)
+	}
+	return code
 }
 
 func installProxy() error {
@@ -366,6 +373,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(model.NewGrantCommand())
 	r.Register(model.NewRevokeCommand())
 	r.Register(model.NewShowCommand())
+	r.Register(model.NewEventsCommand())
 
 	r.Register(newMigrateCommand())
 	if featureflag.Enabled(feature.DeveloperMode) {
@@ -389,6 +397,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(application.NewDeployCommand())
 	r.Register(application.NewExposeCommand())
 	r.Register(application.NewUnexposeCommand())
+	r.Register(application.NewTrustCommand())
+	r.Register(application.NewSetRefreshPolicyCommand())
 	r.Register(application.NewServiceGetConstraintsCommand())
 	r.Register(application.NewServiceSetConstraintsCommand())
 
@@ -438,6 +448,11 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(controller.NewShowControllerCommand())
 	r.Register(controller.NewGetConfigCommand())
 
+	// Operate on several controllers and models at once
+	r.Register(fleet.NewModelsCommand())
+	r.Register(fleet.NewRunCommand())
+	r.Register(fleet.NewStatusCommand())
+
 	// Debug Metrics
 	r.Register(metricsdebug.New())
 	r.Register(metricsdebug.NewCollectMetricsCommand())
@@ -436,9 +436,13 @@ var commandNames = []string{
 	"enable-destroy-controller",
 	"enable-ha",
 	"enable-user",
+	"events",
 	"expose",
 	"find-offers",
 	"firewall-rules",
+	"fleet-models",
+	"fleet-run",
+	"fleet-status",
 	"get-constraints",
 	"get-model-constraints",
 	"grant",
@@ -522,6 +526,7 @@ var commandNames = []string{
 	"set-meter-status",
 	"set-model-constraints",
 	"set-plan",
+	"set-refresh-policy",
 	"set-wallet",
 	"show-action-output",
 	"show-action-status",
@@ -548,6 +553,7 @@ var commandNames = []string{
 	"switch",
 	"sync-agent-binaries",
 	"sync-tools",
+	"trust",
 	"unexpose",
 	"unregister",
 	"update-clouds",
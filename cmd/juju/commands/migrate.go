@@ -4,8 +4,11 @@
 package commands
 
 import (
+	"time"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
 	"gopkg.in/macaroon.v1"
 
@@ -27,6 +30,7 @@ type migrateCommand struct {
 	newAPIRoot       func(jujuclient.ClientStore, string, string) (api.Connection, error)
 	api              migrateAPI
 	targetController string
+	rollbackWindow   time.Duration
 }
 
 type migrateAPI interface {
@@ -52,6 +56,13 @@ In order to start a migration, the target controller must be in the
 juju client's local configuration cache. See the juju "login" command
 for details of how to do this.
 
+With --rollback-window, the model is kept, frozen, on the original
+controller for the given time after it has been handed over to the new
+controller. During that time the model's agents are checked on the new
+controller, and the migration is rolled back automatically if any of
+them have not connected or are failing to run hooks. The migration may
+also be rolled back explicitly during that time.
+
 This command only starts a model migration - it does not wait for its
 completion. The progress of a migration can be tracked using the
 "status" command and by consulting the logs.
@@ -72,6 +83,12 @@ func (c *migrateCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements cmd.Command.
+func (c *migrateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.DurationVar(&c.rollbackWindow, "rollback-window", 0, "How long to validate the migrated model before removing it from this controller")
+}
+
 // Init implements cmd.Command.
 func (c *migrateCommand) Init(args []string) error {
 	if len(args) < 1 {
@@ -83,6 +100,9 @@ func (c *migrateCommand) Init(args []string) error {
 	if len(args) > 2 {
 		return errors.New("too many arguments specified")
 	}
+	if c.rollbackWindow < 0 {
+		return errors.New("rollback window must not be negative")
+	}
 
 	c.SetModelName(args[0], false)
 	c.targetController = args[1]
@@ -118,6 +138,7 @@ func (c *migrateCommand) getMigrationSpec() (*controller.MigrationSpec, error) {
 		TargetUser:           accountInfo.User,
 		TargetPassword:       accountInfo.Password,
 		TargetMacaroons:      macs,
+		RollbackWindow:       c.rollbackWindow,
 	}, nil
 }
 
@@ -151,6 +151,17 @@ func (s *MigrateSuite) TestSuccess(c *gc.C) {
 	})
 }
 
+func (s *MigrateSuite) TestRollbackWindow(c *gc.C) {
+	_, err := s.makeAndRun(c, "model", "target", "--rollback-window", "2h")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.specSeen.RollbackWindow, gc.Equals, 2*time.Hour)
+}
+
+func (s *MigrateSuite) TestNegativeRollbackWindow(c *gc.C) {
+	_, err := s.makeAndRun(c, "model", "target", "--rollback-window", "-1h")
+	c.Assert(err, gc.ErrorMatches, "rollback window must not be negative")
+}
+
 func (s *MigrateSuite) TestSuccessMacaroons(c *gc.C) {
 	err := s.store.UpdateAccount("target", jujuclient.AccountDetails{
 		User:     "targetuser",
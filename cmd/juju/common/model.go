@@ -5,6 +5,7 @@ package common
 
 import (
 	"reflect"
+	"strconv"
 	"time"
 
 	"github.com/juju/errors"
@@ -36,6 +37,7 @@ type ModelInfo struct {
 	SLA            string                      `json:"sla,omitempty" yaml:"sla,omitempty"`
 	SLAOwner       string                      `json:"sla-owner,omitempty" yaml:"sla-owner,omitempty"`
 	AgentVersion   string                      `json:"agent-version,omitempty" yaml:"agent-version,omitempty"`
+	Cost           *ModelCost                  `json:"cost,omitempty" yaml:"cost,omitempty"`
 }
 
 // ModelMachineInfo contains information about a machine in a model.
@@ -63,6 +65,56 @@ type ModelUserInfo struct {
 	LastConnection string `yaml:"last-connection" json:"last-connection"`
 }
 
+// ModelCost contains the estimated cost of a model's cloud resources.
+type ModelCost struct {
+	Currency  string         `json:"currency" yaml:"currency"`
+	Hourly    float64        `json:"hourly" yaml:"hourly"`
+	Monthly   float64        `json:"monthly" yaml:"monthly"`
+	Resources []ResourceCost `json:"resources,omitempty" yaml:"resources,omitempty"`
+}
+
+// ResourceCost contains the estimated cost of one of a model's cloud
+// resources, or why it could not be estimated.
+type ResourceCost struct {
+	Kind        string  `json:"kind" yaml:"kind"`
+	Id          string  `json:"id" yaml:"id"`
+	Description string  `json:"description,omitempty" yaml:"description,omitempty"`
+	Hourly      float64 `json:"hourly,omitempty" yaml:"hourly,omitempty"`
+	Error       string  `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// ModelCostFromParams translates a params.ModelCost to ModelCost.
+// Hourly costs are rounded to four decimal places, and monthly costs
+// to two.
+func ModelCostFromParams(cost params.ModelCost) *ModelCost {
+	out := &ModelCost{
+		Currency: cost.Currency,
+		Hourly:   roundCost(cost.HourlyCost, 4),
+		Monthly:  roundCost(cost.MonthlyCost, 2),
+	}
+	for _, resource := range cost.Resources {
+		r := ResourceCost{
+			Kind:        resource.Kind,
+			Id:          resource.Id,
+			Description: resource.Description,
+			Hourly:      roundCost(resource.HourlyCost, 4),
+		}
+		if resource.Error != nil {
+			r.Error = resource.Error.Error()
+		}
+		out.Resources = append(out.Resources, r)
+	}
+	return out
+}
+
+func roundCost(cost float64, places int) float64 {
+	rounded, err := strconv.ParseFloat(strconv.FormatFloat(cost, 'f', places, 64), 64)
+	if err != nil {
+		return cost
+	}
+	return rounded
+}
+
 // FriendlyDuration renders a time pointer that we get from the API as
 // a friendly string.
 func FriendlyDuration(when *time.Time, now time.Time) string {
@@ -0,0 +1,206 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/controllerhealth"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/jujuclient"
+)
+
+// ControllerHealthAPI defines the methods on the ControllerHealth API
+// that the controllers command calls when --health is specified.
+type ControllerHealthAPI interface {
+	ControllerHealth() (params.ControllerHealth, error)
+	Close() error
+}
+
+// ControllerHealthSet holds the health of each controller.
+type ControllerHealthSet map[string]ControllerHealthItem
+
+// ControllerHealthItem holds the health of a single controller, or the
+// error encountered fetching it.
+type ControllerHealthItem struct {
+	Healthy     bool                                   `yaml:"healthy" json:"healthy"`
+	Problems    []string                               `yaml:"problems,omitempty" json:"problems,omitempty"`
+	Mongo       []MongoMemberHealthItem                `yaml:"mongo,omitempty" json:"mongo,omitempty"`
+	Machines    map[string]ControllerMachineHealthItem `yaml:"machines,omitempty" json:"machines,omitempty"`
+	LogsSize    string                                 `yaml:"logs-size,omitempty" json:"logs-size,omitempty"`
+	MaxLogsSize string                                 `yaml:"max-logs-size,omitempty" json:"max-logs-size,omitempty"`
+	Error       string                                 `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+// MongoMemberHealthItem holds the health of a mongo replica set member.
+type MongoMemberHealthItem struct {
+	Address string `yaml:"address" json:"address"`
+	State   string `yaml:"state" json:"state"`
+	Healthy bool   `yaml:"healthy" json:"healthy"`
+	Error   string `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+// ControllerMachineHealthItem holds the health of a controller machine.
+type ControllerMachineHealthItem struct {
+	Updated        string            `yaml:"updated" json:"updated"`
+	Stale          bool              `yaml:"stale,omitempty" json:"stale,omitempty"`
+	DiskFree       string            `yaml:"disk-free" json:"disk-free"`
+	DiskTotal      string            `yaml:"disk-total" json:"disk-total"`
+	APIConnections int64             `yaml:"api-connections" json:"api-connections"`
+	Workers        map[string]string `yaml:"workers,omitempty" json:"workers,omitempty"`
+}
+
+func (c *listControllersCommand) getHealthAPI(controllerName string) (ControllerHealthAPI, error) {
+	if c.healthAPI != nil {
+		return c.healthAPI(controllerName), nil
+	}
+	api, err := c.NewAPIRoot(c.store, controllerName, "")
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return controllerhealth.NewClient(api), nil
+}
+
+// runHealth connects to each controller and writes out its health.
+func (c *listControllersCommand) runHealth(ctx *cmd.Context, controllers map[string]jujuclient.ControllerDetails) error {
+	var mu sync.Mutex
+	set := make(ControllerHealthSet)
+	var wg sync.WaitGroup
+	wg.Add(len(controllers))
+	for controllerName := range controllers {
+		name := controllerName
+		go func() {
+			defer wg.Done()
+			item := c.controllerHealth(name)
+			mu.Lock()
+			defer mu.Unlock()
+			set[name] = item
+		}()
+	}
+	wg.Wait()
+	return c.out.Write(ctx, set)
+}
+
+func (c *listControllersCommand) controllerHealth(controllerName string) ControllerHealthItem {
+	client, err := c.getHealthAPI(controllerName)
+	if err != nil {
+		return ControllerHealthItem{Error: err.Error()}
+	}
+	defer client.Close()
+	health, err := client.ControllerHealth()
+	if err != nil {
+		return ControllerHealthItem{Error: err.Error()}
+	}
+	return convertControllerHealth(health)
+}
+
+func convertControllerHealth(health params.ControllerHealth) ControllerHealthItem {
+	item := ControllerHealthItem{
+		Healthy:     health.Healthy,
+		Problems:    health.Problems,
+		LogsSize:    fmt.Sprintf("%dMiB", health.LogsSizeMB),
+		MaxLogsSize: fmt.Sprintf("%dMiB", health.MaxLogsSizeMB),
+	}
+	for _, m := range health.Mongo {
+		item.Mongo = append(item.Mongo, MongoMemberHealthItem{
+			Address: m.Address,
+			State:   m.State,
+			Healthy: m.Healthy,
+			Error:   m.Error,
+		})
+	}
+	if len(health.Machines) > 0 {
+		item.Machines = make(map[string]ControllerMachineHealthItem)
+	}
+	for _, m := range health.Machines {
+		machine := ControllerMachineHealthItem{
+			Updated:        m.Updated.Format("2006-01-02 15:04:05Z"),
+			Stale:          m.Stale,
+			DiskFree:       humanize.IBytes(m.DiskFreeBytes),
+			DiskTotal:      humanize.IBytes(m.DiskTotalBytes),
+			APIConnections: m.APIConnections,
+		}
+		if len(m.Workers) > 0 {
+			machine.Workers = make(map[string]string)
+		}
+		for _, w := range m.Workers {
+			state := w.State
+			if w.Error != "" {
+				state += ": " + w.Error
+			}
+			machine.Workers[w.Name] = state
+		}
+		item.Machines[m.MachineId] = machine
+	}
+	return item
+}
+
+// formatControllerHealthTabular writes a tabular summary of the health
+// of each controller, followed by any problems found.
+func formatControllerHealthTabular(writer io.Writer, set ControllerHealthSet) error {
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Controller", "Health", "Mongo", "Machines", "Connections", "Logs")
+	tw.SetColumnAlignRight(3)
+	tw.SetColumnAlignRight(4)
+
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var problems []string
+	for _, name := range names {
+		item := set[name]
+		if item.Error != "" {
+			w.Println(name, "unknown", noValueDisplay, noValueDisplay, noValueDisplay, noValueDisplay)
+			problems = append(problems, fmt.Sprintf("%s: %s", name, item.Error))
+			continue
+		}
+		health, highlight := "healthy", output.GoodHighlight
+		if !item.Healthy {
+			health, highlight = "unhealthy", output.ErrorHighlight
+		}
+		healthyMembers := 0
+		for _, m := range item.Mongo {
+			if m.Healthy {
+				healthyMembers++
+			}
+		}
+		var connections int64
+		for _, m := range item.Machines {
+			connections += m.APIConnections
+		}
+		w.Print(name)
+		w.PrintColor(highlight, health)
+		w.Println(
+			fmt.Sprintf("%d/%d", healthyMembers, len(item.Mongo)),
+			len(item.Machines),
+			connections,
+			fmt.Sprintf("%s/%s", item.LogsSize, item.MaxLogsSize),
+		)
+		for _, problem := range item.Problems {
+			problems = append(problems, fmt.Sprintf("%s: %s", name, problem))
+		}
+	}
+	tw.Flush()
+
+	if len(problems) > 0 {
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "Problems:")
+		for _, problem := range problems {
+			fmt.Fprintf(writer, "  %s\n", problem)
+		}
+	}
+	return nil
+}
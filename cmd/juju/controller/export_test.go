@@ -25,6 +25,15 @@ func NewListControllersCommandForTest(testStore jujuclient.ClientStore, api func
 	}
 }
 
+// NewListControllersHealthCommandForTest returns a listControllersCommand
+// with the clientstore and health API provided as specified.
+func NewListControllersHealthCommandForTest(testStore jujuclient.ClientStore, healthAPI func(string) ControllerHealthAPI) *listControllersCommand {
+	return &listControllersCommand{
+		store:     testStore,
+		healthAPI: healthAPI,
+	}
+}
+
 // NewShowControllerCommandForTest returns a showControllerCommand with the clientstore provided
 // as specified.
 func NewShowControllerCommandForTest(testStore jujuclient.ClientStore, api func(string) ControllerAccessAPI) *showControllerCommand {
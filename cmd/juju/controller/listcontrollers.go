@@ -28,9 +28,14 @@ var helpControllersDetails = `
 The output format may be selected with the '--format' option. In the
 default tabular output, the current controller is marked with an asterisk.
 
+With --health, each controller is queried for the health of its mongo
+replica set, controller machines and log collections, and any problems
+found are listed.
+
 Examples:
     juju controllers
     juju controllers --format json --output ~/tmp/controllers.json
+    juju controllers --health
 
 See also:
     models
@@ -58,6 +63,7 @@ func (c *listControllersCommand) Info() *cmd.Info {
 func (c *listControllersCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
 	f.BoolVar(&c.refresh, "refresh", false, "Connect to each controller to download the latest details")
+	f.BoolVar(&c.health, "health", false, "Connect to each controller to report its health")
 	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
 		"yaml":    cmd.FormatYaml,
 		"json":    cmd.FormatJson,
@@ -85,6 +91,9 @@ func (c *listControllersCommand) Run(ctx *cmd.Context) error {
 	if len(controllers) == 0 && c.out.Name() == "tabular" {
 		return errors.Trace(modelcmd.ErrNoControllersDefined)
 	}
+	if c.health {
+		return c.runHealth(ctx, controllers)
+	}
 	if c.refresh && len(controllers) > 0 {
 		var wg sync.WaitGroup
 		wg.Add(len(controllers))
@@ -202,9 +211,11 @@ func ControllerMachineCounts(controllerModelUUID string, modelStatusResults []ba
 type listControllersCommand struct {
 	modelcmd.CommandBase
 
-	out     cmd.Output
-	store   jujuclient.ClientStore
-	api     func(controllerName string) ControllerAccessAPI
-	refresh bool
-	mu      sync.Mutex
+	out       cmd.Output
+	store     jujuclient.ClientStore
+	api       func(controllerName string) ControllerAccessAPI
+	healthAPI func(controllerName string) ControllerHealthAPI
+	refresh   bool
+	health    bool
+	mu        sync.Mutex
 }
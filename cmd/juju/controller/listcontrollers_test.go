@@ -6,6 +6,7 @@ package controller_test
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -14,6 +15,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/controller"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/jujuclient"
@@ -230,6 +232,111 @@ func (s *ListControllersSuite) TestListControllersJson(c *gc.C) {
 	})
 }
 
+func (s *ListControllersSuite) TestListControllersHealth(c *gc.C) {
+	s.createTestClientStore(c)
+	healthAPI := func(controllerName string) controller.ControllerHealthAPI {
+		return &fakeHealthAPI{controllerName: controllerName}
+	}
+	context, err := cmdtesting.RunCommand(c,
+		controller.NewListControllersHealthCommandForTest(s.store, healthAPI),
+		"--health", "--format", "json",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	var result controller.ControllerHealthSet
+	err = json.Unmarshal([]byte(cmdtesting.Stdout(context)), &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, controller.ControllerHealthSet{
+		"aws-test": {
+			Healthy: true,
+			Mongo: []controller.MongoMemberHealthItem{{
+				Address: "10.0.0.1:37017",
+				State:   "PRIMARY",
+				Healthy: true,
+			}},
+			Machines: map[string]controller.ControllerMachineHealthItem{
+				"0": {
+					Updated:        "2018-03-01 12:00:00Z",
+					DiskFree:       "512 MiB",
+					DiskTotal:      "1.0 GiB",
+					APIConnections: 4,
+					Workers:        map[string]string{"api-server": "started"},
+				},
+			},
+			LogsSize:    "12MiB",
+			MaxLogsSize: "4096MiB",
+		},
+		"mallards": {
+			Problems:    []string{"mongo replica set has no primary"},
+			LogsSize:    "0MiB",
+			MaxLogsSize: "4096MiB",
+		},
+		"mark-test-prodstack": {
+			Error: "connection refused",
+		},
+	})
+}
+
+func (s *ListControllersSuite) TestListControllersHealthTabular(c *gc.C) {
+	s.createTestClientStore(c)
+	healthAPI := func(controllerName string) controller.ControllerHealthAPI {
+		return &fakeHealthAPI{controllerName: controllerName}
+	}
+	context, err := cmdtesting.RunCommand(c,
+		controller.NewListControllersHealthCommandForTest(s.store, healthAPI),
+		"--health",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, `
+Controller           Health     Mongo  Machines  Connections  Logs
+aws-test             healthy    1/1           1            4  12MiB/4096MiB
+mallards             unhealthy  0/0           0            0  0MiB/4096MiB
+mark-test-prodstack  unknown    -             -            -  -
+
+Problems:
+  mallards: mongo replica set has no primary
+  mark-test-prodstack: connection refused
+`[1:])
+}
+
+type fakeHealthAPI struct {
+	controllerName string
+}
+
+func (f *fakeHealthAPI) ControllerHealth() (params.ControllerHealth, error) {
+	switch f.controllerName {
+	case "aws-test":
+		return params.ControllerHealth{
+			Healthy: true,
+			Mongo: []params.MongoMemberHealth{{
+				Id:      1,
+				Address: "10.0.0.1:37017",
+				State:   "PRIMARY",
+				Healthy: true,
+			}},
+			Machines: []params.ControllerMachineHealth{{
+				MachineId:      "0",
+				Updated:        time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC),
+				DiskFreeBytes:  512 * 1024 * 1024,
+				DiskTotalBytes: 1024 * 1024 * 1024,
+				APIConnections: 4,
+				Workers:        []params.WorkerHealth{{Name: "api-server", State: "started"}},
+			}},
+			LogsSizeMB:    12,
+			MaxLogsSizeMB: 4096,
+		}, nil
+	case "mallards":
+		return params.ControllerHealth{
+			Problems:      []string{"mongo replica set has no primary"},
+			MaxLogsSizeMB: 4096,
+		}, nil
+	}
+	return params.ControllerHealth{}, errors.New("connection refused")
+}
+
+func (f *fakeHealthAPI) Close() error {
+	return nil
+}
+
 func (s *ListControllersSuite) TestListControllersReadFromStoreErr(c *gc.C) {
 	msg := "fail getting all controllers"
 	errStore := jujuclienttesting.NewStubStore()
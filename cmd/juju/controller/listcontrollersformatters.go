@@ -21,6 +21,9 @@ const (
 )
 
 func (c *listControllersCommand) formatControllersListTabular(writer io.Writer, value interface{}) error {
+	if health, ok := value.(ControllerHealthSet); ok {
+		return formatControllerHealthTabular(writer, health)
+	}
 	controllers, ok := value.(ControllerSet)
 	if !ok {
 		return errors.Errorf("expected value of type %T, got %T", controllers, value)
@@ -0,0 +1,35 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package fleet
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+func NewStatusCommandForTest(store jujuclient.ClientStore, newAPI func(controllerName, modelName string) (StatusAPI, error)) cmd.Command {
+	c := &statusCommand{newAPIFunc: newAPI}
+	c.store = store
+	return modelcmd.WrapBase(c)
+}
+
+func NewModelsCommandForTest(store jujuclient.ClientStore, newAPI func(controllerName string) (ModelsAPI, error)) cmd.Command {
+	c := &modelsCommand{newAPIFunc: newAPI}
+	c.store = store
+	return modelcmd.WrapBase(c)
+}
+
+func NewRunCommandForTest(
+	store jujuclient.ClientStore,
+	newAPI func(controllerName, modelName string) (RunAPI, error),
+	timeAfter func(time.Duration) <-chan time.Time,
+) cmd.Command {
+	c := &runCommand{newAPIFunc: newAPI, timeAfter: timeAfter}
+	c.store = store
+	return modelcmd.WrapBase(c)
+}
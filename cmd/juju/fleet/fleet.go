@@ -0,0 +1,76 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package fleet provides commands that run read-only operations, and
+// commands on units and machines, across several controllers or models
+// at once, reporting each result with the controller and model it came
+// from.
+package fleet
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/juju/fanout"
+	"github.com/juju/juju/jujuclient"
+)
+
+// fleetCommandBase holds the flags and behaviour common to the fleet
+// commands.
+type fleetCommandBase struct {
+	modelcmd.CommandBase
+	store    jujuclient.ClientStore
+	out      cmd.Output
+	targets  []string
+	parallel int
+}
+
+func (c *fleetCommandBase) setFlags(f *gnuflag.FlagSet, defaultTargets []string, targetsUsage string) {
+	c.CommandBase.SetFlags(f)
+	f.Var(cmd.NewStringsValue(defaultTargets, &c.targets), "targets", targetsUsage)
+	f.IntVar(&c.parallel, "parallel", fanout.DefaultParallelism, "The number of targets to operate on at once")
+}
+
+func (c *fleetCommandBase) init() error {
+	if len(c.targets) == 0 {
+		return errors.New("no targets specified")
+	}
+	if c.parallel < 1 {
+		return errors.Errorf("--parallel must be at least 1, got %d", c.parallel)
+	}
+	return nil
+}
+
+// run runs the call on each of the targets, at most c.parallel at a
+// time.
+func (c *fleetCommandBase) run(targets []fanout.Target, call func(fanout.Target) (interface{}, error)) ([]fanout.Result, error) {
+	// The API contexts of the controllers are created up front, as
+	// the command base does not allow them to be created
+	// concurrently.
+	for _, target := range targets {
+		if _, err := c.CookieJar(c.store, target.Controller); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return fanout.Run(targets, c.parallel, call), nil
+}
+
+// errorString returns the message of the error, or the empty string if
+// it is nil.
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// failedError returns an error reporting the number of failed results,
+// or nil if none failed.
+func failedError(failed, total int) error {
+	if failed == 0 {
+		return nil
+	}
+	return errors.Errorf("%d of %d results failed", failed, total)
+}
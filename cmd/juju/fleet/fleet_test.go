@@ -0,0 +1,37 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package fleet_test
+
+import (
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+// baseSuite provides a client store holding two controllers, prod and
+// staging, with their models.
+type baseSuite struct {
+	testing.BaseSuite
+	store *jujuclient.MemStore
+}
+
+func (s *baseSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.store = jujuclient.NewMemStore()
+	s.store.Controllers["prod"] = jujuclient.ControllerDetails{}
+	s.store.Controllers["staging"] = jujuclient.ControllerDetails{}
+	s.store.Models["prod"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/db":  {ModelUUID: "prod-db-uuid"},
+			"admin/web": {ModelUUID: "prod-web-uuid"},
+		},
+	}
+	s.store.Models["staging"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/web": {ModelUUID: "staging-web-uuid"},
+		},
+	}
+	s.store.Accounts["prod"] = jujuclient.AccountDetails{User: "admin"}
+}
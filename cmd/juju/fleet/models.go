@@ -0,0 +1,195 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package fleet
+
+import (
+	"io"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/modelmanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/juju/fanout"
+	"github.com/juju/juju/jujuclient"
+)
+
+var modelsHelpSummary = `
+Lists the models on several controllers at once.`[1:]
+
+var modelsHelpDetails = `
+Queries each of the target controllers concurrently for the models the
+current user can access on it, and lists them together.
+
+Targets are given with --targets as a comma-separated list of
+controller names, which may contain shell wildcards. By default, all
+controllers known to the client are queried.
+
+Examples:
+    juju fleet-models
+    juju fleet-models --targets 'prod-*' --all
+
+See also:
+    fleet-run
+    fleet-status
+    models`[1:]
+
+// ModelsAPI defines the API methods used by the fleet-models command.
+type ModelsAPI interface {
+	ListModelSummaries(user string, all bool) ([]base.UserModelSummary, error)
+	Close() error
+}
+
+// NewModelsCommand returns a command that lists the models on several
+// controllers.
+func NewModelsCommand() cmd.Command {
+	c := &modelsCommand{}
+	c.store = jujuclient.NewFileClientStore()
+	c.newAPIFunc = func(controllerName string) (ModelsAPI, error) {
+		root, err := c.NewAPIRoot(c.store, controllerName, "")
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return modelmanager.NewClient(root), nil
+	}
+	return modelcmd.WrapBase(c)
+}
+
+type modelsCommand struct {
+	fleetCommandBase
+	all        bool
+	newAPIFunc func(controllerName string) (ModelsAPI, error)
+}
+
+// Info implements cmd.Command.
+func (c *modelsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "fleet-models",
+		Purpose: modelsHelpSummary,
+		Doc:     modelsHelpDetails,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *modelsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.setFlags(f, []string{"*"}, "The controllers to query")
+	f.BoolVar(&c.all, "all", false, "Lists all models, regardless of user accessibility (administrative users only)")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatModelsTabular,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *modelsCommand) Init(args []string) error {
+	if err := cmd.CheckEmpty(args); err != nil {
+		return errors.Trace(err)
+	}
+	return c.init()
+}
+
+// Run implements cmd.Command.
+func (c *modelsCommand) Run(ctx *cmd.Context) error {
+	targets, err := fanout.ControllerTargets(c.store, c.targets)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	results, err := c.run(targets, func(target fanout.Target) (interface{}, error) {
+		account, err := c.store.AccountDetails(target.Controller)
+		if errors.IsNotFound(err) {
+			return nil, errors.New("not logged in")
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		client, err := c.newAPIFunc(target.Controller)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer client.Close()
+		return client.ListModelSummaries(account.User, c.all)
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var models []modelSummary
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+			models = append(models, modelSummary{
+				Controller: result.Target.Controller,
+				Error:      result.Err.Error(),
+			})
+			continue
+		}
+		summaries, _ := result.Value.([]base.UserModelSummary)
+		for _, summary := range summaries {
+			models = append(models, newModelSummary(result.Target.Controller, summary))
+		}
+	}
+	if err := c.out.Write(ctx, models); err != nil {
+		return errors.Trace(err)
+	}
+	return failedError(failed, len(results))
+}
+
+// modelSummary describes a model on a controller.
+type modelSummary struct {
+	Controller string `yaml:"controller" json:"controller"`
+	Model      string `yaml:"model,omitempty" json:"model,omitempty"`
+	UUID       string `yaml:"model-uuid,omitempty" json:"model-uuid,omitempty"`
+	Cloud      string `yaml:"cloud,omitempty" json:"cloud,omitempty"`
+	Region     string `yaml:"region,omitempty" json:"region,omitempty"`
+	Status     string `yaml:"status,omitempty" json:"status,omitempty"`
+	Machines   int64  `yaml:"machines,omitempty" json:"machines,omitempty"`
+	Access     string `yaml:"access,omitempty" json:"access,omitempty"`
+	Error      string `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+func newModelSummary(controllerName string, summary base.UserModelSummary) modelSummary {
+	model := modelSummary{
+		Controller: controllerName,
+		Model:      jujuclient.JoinOwnerModelName(names.NewUserTag(summary.Owner), summary.Name),
+		UUID:       summary.UUID,
+		Cloud:      summary.Cloud,
+		Region:     summary.CloudRegion,
+		Status:     string(summary.Status.Status),
+		Access:     summary.ModelUserAccess,
+		Error:      errorString(summary.Error),
+	}
+	for _, count := range summary.Counts {
+		if count.Entity == string(params.Machines) {
+			model.Machines = count.Count
+		}
+	}
+	return model
+}
+
+func formatModelsTabular(writer io.Writer, value interface{}) error {
+	models, ok := value.([]modelSummary)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", models, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Controller", "Model", "Cloud/Region", "Status", "Machines", "Access", "Notes")
+	for _, m := range models {
+		if m.Model == "" {
+			w.Println(m.Controller, "", "", "", "", "", m.Error)
+			continue
+		}
+		cloudRegion := m.Cloud
+		if m.Region != "" {
+			cloudRegion += "/" + m.Region
+		}
+		w.Println(m.Controller, m.Model, cloudRegion, m.Status, m.Machines, m.Access, m.Error)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,101 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package fleet_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/cmd/juju/fleet"
+	"github.com/juju/juju/status"
+)
+
+type ModelsSuite struct {
+	baseSuite
+	api *mockModelsAPI
+}
+
+var _ = gc.Suite(&ModelsSuite{})
+
+func (s *ModelsSuite) SetUpTest(c *gc.C) {
+	s.baseSuite.SetUpTest(c)
+	s.api = &mockModelsAPI{
+		summaries: []base.UserModelSummary{{
+			Name:            "db",
+			UUID:            "prod-db-uuid",
+			Owner:           "admin",
+			Cloud:           "aws",
+			CloudRegion:     "us-east-1",
+			Status:          base.Status{Status: status.Available},
+			ModelUserAccess: "admin",
+			Counts:          []base.EntityCount{{Entity: "machines", Count: 2}},
+		}, {
+			Name:            "web",
+			UUID:            "prod-web-uuid",
+			Owner:           "bob",
+			Cloud:           "aws",
+			Status:          base.Status{Status: status.Busy},
+			ModelUserAccess: "read",
+		}},
+	}
+}
+
+func (s *ModelsSuite) newAPI(controllerName string) (fleet.ModelsAPI, error) {
+	if controllerName != "prod" {
+		return nil, errors.Errorf("unexpected controller %q", controllerName)
+	}
+	return s.api, nil
+}
+
+func (s *ModelsSuite) runModels(c *gc.C, args ...string) (string, error) {
+	ctx, err := cmdtesting.RunCommand(c, fleet.NewModelsCommandForTest(s.store, s.newAPI), args...)
+	return cmdtesting.Stdout(ctx), err
+}
+
+func (s *ModelsSuite) TestModelsTabular(c *gc.C) {
+	out, err := s.runModels(c)
+	c.Assert(err, gc.ErrorMatches, "1 of 2 results failed")
+	c.Assert(out, gc.Equals, `
+Controller  Model     Cloud/Region   Status     Machines  Access  Notes
+prod        admin/db  aws/us-east-1  available  2         admin   
+prod        bob/web   aws            busy       0         read    
+staging                                                           not logged in
+`[1:])
+	c.Assert(s.api.user, gc.Equals, "admin")
+	c.Assert(s.api.all, jc.IsFalse)
+}
+
+func (s *ModelsSuite) TestModelsJSON(c *gc.C) {
+	out, err := s.runModels(c, "--targets", "prod", "--all", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `[`+
+		`{"controller":"prod","model":"admin/db","model-uuid":"prod-db-uuid","cloud":"aws","region":"us-east-1","status":"available","machines":2,"access":"admin"},`+
+		`{"controller":"prod","model":"bob/web","model-uuid":"prod-web-uuid","cloud":"aws","status":"busy","access":"read"}`+
+		"]\n")
+	c.Assert(s.api.all, jc.IsTrue)
+}
+
+func (s *ModelsSuite) TestModelsUnexpectedArgs(c *gc.C) {
+	_, err := s.runModels(c, "prod")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["prod"\]`)
+}
+
+type mockModelsAPI struct {
+	summaries []base.UserModelSummary
+	user      string
+	all       bool
+}
+
+func (m *mockModelsAPI) ListModelSummaries(user string, all bool) ([]base.UserModelSummary, error) {
+	m.user = user
+	m.all = all
+	return m.summaries, nil
+}
+
+func (m *mockModelsAPI) Close() error {
+	return nil
+}
@@ -0,0 +1,281 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package fleet
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+
+	actionapi "github.com/juju/juju/api/action"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/juju/fanout"
+	"github.com/juju/juju/jujuclient"
+)
+
+var runHelpSummary = `
+Runs commands on machines or units in several models at once.`[1:]
+
+var runHelpDetails = `
+Runs the given commands, as "juju run" does, in each of the target
+models concurrently, waits for them to complete, and reports the
+output of each together with the controller and model it came from.
+
+Targets are given with --targets as a comma-separated list of patterns
+of the form <controller>:<model>, in which both parts may contain shell
+wildcards; see "juju help fleet-status". Unlike the other fleet
+commands, there is no default: the target models must be given.
+
+The machines, applications or units on which to run the commands in
+each model are given with --all, --machine, --application or --unit,
+as for "juju run". An application or unit that does not exist in one
+of the models is reported as an error for that model.
+
+Examples:
+    juju fleet-run --targets 'prod-*:web' --application nginx -- nginx -t
+    juju fleet-run --targets 'prod-*' --all uptime
+
+See also:
+    fleet-status
+    run`[1:]
+
+// RunAPI defines the API methods used by the fleet-run command.
+type RunAPI interface {
+	Run(params.RunParams) ([]params.ActionResult, error)
+	RunOnAllMachines(commands string, timeout time.Duration) ([]params.ActionResult, error)
+	Actions(params.Entities) (params.ActionResults, error)
+	Close() error
+}
+
+// NewRunCommand returns a command that runs commands in several
+// models.
+func NewRunCommand() cmd.Command {
+	c := &runCommand{timeAfter: time.After}
+	c.store = jujuclient.NewFileClientStore()
+	c.newAPIFunc = func(controllerName, modelName string) (RunAPI, error) {
+		root, err := c.NewAPIRoot(c.store, controllerName, modelName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return actionapi.NewClient(root), nil
+	}
+	return modelcmd.WrapBase(c)
+}
+
+type runCommand struct {
+	fleetCommandBase
+	all          bool
+	timeout      time.Duration
+	machines     []string
+	applications []string
+	units        []string
+	commands     string
+	timeAfter    func(time.Duration) <-chan time.Time
+	newAPIFunc   func(controllerName, modelName string) (RunAPI, error)
+}
+
+// Info implements cmd.Command.
+func (c *runCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "fleet-run",
+		Args:    "<commands>",
+		Purpose: runHelpSummary,
+		Doc:     runHelpDetails,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.setFlags(f, nil, "The models in which to run the commands, as <controller>:<model> patterns")
+	f.BoolVar(&c.all, "all", false, "Run the commands on all the machines")
+	f.DurationVar(&c.timeout, "timeout", 5*time.Minute, "How long to wait before the remote command is considered to have failed")
+	f.Var(cmd.NewStringsValue(nil, &c.machines), "machine", "One or more machine ids")
+	f.Var(cmd.NewStringsValue(nil, &c.applications), "application", "One or more application names")
+	f.Var(cmd.NewStringsValue(nil, &c.units), "unit", "One or more unit ids")
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *runCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no commands specified")
+	}
+	if len(args) == 1 {
+		// As with "juju run", a single argument is passed on
+		// unquoted, so that it may hold several words.
+		c.commands = args[0]
+	} else {
+		c.commands = utils.CommandString(args...)
+	}
+	hasReceivers := len(c.machines) != 0 || len(c.applications) != 0 || len(c.units) != 0
+	if c.all && hasReceivers {
+		return errors.New("cannot specify --all with individual machines, applications or units")
+	}
+	if !c.all && !hasReceivers {
+		return errors.New("no machines, applications or units specified: use --all, --machine, --application or --unit")
+	}
+	return c.init()
+}
+
+// Run implements cmd.Command.
+func (c *runCommand) Run(ctx *cmd.Context) error {
+	targets, err := fanout.ModelTargets(c.store, c.targets)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	results, err := c.run(targets, func(target fanout.Target) (interface{}, error) {
+		client, err := c.newAPIFunc(target.Controller, target.Model)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer client.Close()
+		return c.runInModel(client)
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var outputs []runOutput
+	failed := 0
+	for _, result := range results {
+		modelOutputs, _ := result.Value.([]runOutput)
+		if result.Err != nil {
+			modelOutputs = []runOutput{{Error: result.Err.Error()}}
+		}
+		for _, output := range modelOutputs {
+			output.Controller = result.Target.Controller
+			output.Model = result.Target.Model
+			if output.Error != "" || output.ReturnCode != 0 || output.Status == params.ActionFailed {
+				failed++
+			}
+			outputs = append(outputs, output)
+		}
+	}
+	if err := c.out.Write(ctx, outputs); err != nil {
+		return errors.Trace(err)
+	}
+	return failedError(failed, len(outputs))
+}
+
+// runOutput holds the outcome of running the commands on a machine or
+// unit.
+type runOutput struct {
+	Controller string `yaml:"controller" json:"controller"`
+	Model      string `yaml:"model" json:"model"`
+	Receiver   string `yaml:"receiver,omitempty" json:"receiver,omitempty"`
+	Action     string `yaml:"action,omitempty" json:"action,omitempty"`
+	Status     string `yaml:"status,omitempty" json:"status,omitempty"`
+	ReturnCode int    `yaml:"return-code,omitempty" json:"return-code,omitempty"`
+	Stdout     string `yaml:"stdout,omitempty" json:"stdout,omitempty"`
+	Stderr     string `yaml:"stderr,omitempty" json:"stderr,omitempty"`
+	Message    string `yaml:"message,omitempty" json:"message,omitempty"`
+	Error      string `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+// runInModel runs the commands in the model, and waits for them to
+// complete or for the timeout to expire.
+func (c *runCommand) runInModel(client RunAPI) ([]runOutput, error) {
+	var (
+		results []params.ActionResult
+		err     error
+	)
+	if c.all {
+		results, err = client.RunOnAllMachines(c.commands, c.timeout)
+	} else {
+		results, err = client.Run(params.RunParams{
+			Commands:     c.commands,
+			Timeout:      c.timeout,
+			Machines:     c.machines,
+			Applications: c.applications,
+			Units:        c.units,
+		})
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	outputs := make([]runOutput, len(results))
+	var pending []int
+	for i, result := range results {
+		outputs[i] = newRunOutput(result)
+		if result.Error == nil && result.Action != nil {
+			pending = append(pending, i)
+		}
+	}
+
+	timeout := c.timeAfter(c.timeout)
+	for len(pending) > 0 {
+		entities := params.Entities{Entities: make([]params.Entity, len(pending))}
+		for i, index := range pending {
+			entities.Entities[i].Tag = results[index].Action.Tag
+		}
+		actionResults, err := client.Actions(entities)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(actionResults.Results) != len(pending) {
+			return nil, errors.Errorf("expected %d results, got %d", len(pending), len(actionResults.Results))
+		}
+		var stillPending []int
+		for i, index := range pending {
+			result := actionResults.Results[i]
+			if result.Error == nil && (result.Status == params.ActionPending || result.Status == params.ActionRunning) {
+				stillPending = append(stillPending, index)
+				outputs[index].Status = result.Status
+				continue
+			}
+			outputs[index] = newRunOutput(result)
+		}
+		pending = stillPending
+		if len(pending) == 0 {
+			break
+		}
+		select {
+		case <-timeout:
+			for _, index := range pending {
+				outputs[index].Error = "timed out waiting for result"
+			}
+			return outputs, nil
+		case <-c.timeAfter(time.Second):
+		}
+	}
+	return outputs, nil
+}
+
+func newRunOutput(result params.ActionResult) runOutput {
+	var output runOutput
+	if result.Action != nil {
+		output.Action = strings.TrimPrefix(result.Action.Tag, names.ActionTagKind+"-")
+		if tag, err := names.ParseTag(result.Action.Receiver); err == nil {
+			output.Receiver = tag.Id()
+		}
+	}
+	if result.Error != nil {
+		output.Error = result.Error.Error()
+		return output
+	}
+	output.Status = result.Status
+	output.Message = result.Message
+	if stdout, ok := result.Output["Stdout"].(string); ok {
+		output.Stdout = strings.Replace(stdout, "\r\n", "\n", -1)
+	}
+	if stderr, ok := result.Output["Stderr"].(string); ok {
+		output.Stderr = strings.Replace(stderr, "\r\n", "\n", -1)
+	}
+	if code, ok := result.Output["Code"].(string); ok {
+		if n, err := strconv.Atoi(code); err == nil {
+			output.ReturnCode = n
+		}
+	}
+	return output
+}
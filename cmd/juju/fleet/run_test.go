@@ -0,0 +1,128 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package fleet_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/fleet"
+)
+
+const actionTag = "action-f47ac10b-58cc-4372-a567-0e02b2c3d479"
+
+type RunSuite struct {
+	baseSuite
+	mu        sync.Mutex
+	runParams map[string]params.RunParams
+	polls     int
+}
+
+var _ = gc.Suite(&RunSuite{})
+
+func (s *RunSuite) SetUpTest(c *gc.C) {
+	s.baseSuite.SetUpTest(c)
+	s.runParams = make(map[string]params.RunParams)
+	s.polls = 0
+}
+
+func (s *RunSuite) newAPI(controllerName, modelName string) (fleet.RunAPI, error) {
+	return &mockRunAPI{suite: s, target: controllerName + ":" + modelName}, nil
+}
+
+// timeAfter fires immediately when polling for results, and never
+// for the timeout.
+func (s *RunSuite) timeAfter(d time.Duration) <-chan time.Time {
+	if d != time.Second {
+		return nil
+	}
+	ch := make(chan time.Time, 1)
+	ch <- time.Time{}
+	return ch
+}
+
+func (s *RunSuite) runRun(c *gc.C, args ...string) (string, error) {
+	ctx, err := cmdtesting.RunCommand(c, fleet.NewRunCommandForTest(s.store, s.newAPI, s.timeAfter), args...)
+	return cmdtesting.Stdout(ctx), err
+}
+
+func (s *RunSuite) TestRun(c *gc.C) {
+	out, err := s.runRun(c, "--targets", "prod", "--application", "nginx", "--format", "json", "--", "echo", "ok")
+	c.Assert(err, gc.ErrorMatches, "2 of 3 results failed")
+	c.Assert(out, gc.Equals, `[`+
+		`{"controller":"prod","model":"admin/db","error":"no such application"},`+
+		`{"controller":"prod","model":"admin/web","receiver":"nginx/0","action":"f47ac10b-58cc-4372-a567-0e02b2c3d479","status":"completed","stdout":"ok"},`+
+		`{"controller":"prod","model":"admin/web","error":"unit nginx/1 not found"}`+
+		"]\n")
+	c.Assert(s.runParams["prod:admin/web"], jc.DeepEquals, params.RunParams{
+		Commands:     "echo ok",
+		Timeout:      5 * time.Minute,
+		Applications: []string{"nginx"},
+	})
+	c.Assert(s.polls, gc.Equals, 2)
+}
+
+func (s *RunSuite) TestRunNoTargets(c *gc.C) {
+	_, err := s.runRun(c, "--all", "uptime")
+	c.Assert(err, gc.ErrorMatches, "no targets specified")
+}
+
+func (s *RunSuite) TestRunNoReceivers(c *gc.C) {
+	_, err := s.runRun(c, "--targets", "prod", "uptime")
+	c.Assert(err, gc.ErrorMatches, "no machines, applications or units specified: .*")
+}
+
+func (s *RunSuite) TestRunAllWithReceivers(c *gc.C) {
+	_, err := s.runRun(c, "--targets", "prod", "--all", "--unit", "nginx/0", "uptime")
+	c.Assert(err, gc.ErrorMatches, "cannot specify --all with individual machines, applications or units")
+}
+
+type mockRunAPI struct {
+	suite  *RunSuite
+	target string
+}
+
+func (m *mockRunAPI) Run(args params.RunParams) ([]params.ActionResult, error) {
+	m.suite.mu.Lock()
+	m.suite.runParams[m.target] = args
+	m.suite.mu.Unlock()
+	if m.target != "prod:admin/web" {
+		return nil, errors.New("no such application")
+	}
+	return []params.ActionResult{{
+		Action: &params.Action{Tag: actionTag, Receiver: "unit-nginx-0"},
+		Status: params.ActionPending,
+	}, {
+		Error: &params.Error{Message: "unit nginx/1 not found"},
+	}}, nil
+}
+
+func (m *mockRunAPI) RunOnAllMachines(commands string, timeout time.Duration) ([]params.ActionResult, error) {
+	return nil, errors.NotImplementedf("RunOnAllMachines")
+}
+
+func (m *mockRunAPI) Actions(args params.Entities) (params.ActionResults, error) {
+	m.suite.mu.Lock()
+	defer m.suite.mu.Unlock()
+	m.suite.polls++
+	result := params.ActionResult{
+		Action: &params.Action{Tag: actionTag, Receiver: "unit-nginx-0"},
+		Status: params.ActionRunning,
+	}
+	if m.suite.polls > 1 {
+		result.Status = params.ActionCompleted
+		result.Output = map[string]interface{}{"Stdout": "ok", "Code": "0"}
+	}
+	return params.ActionResults{Results: []params.ActionResult{result}}, nil
+}
+
+func (m *mockRunAPI) Close() error {
+	return nil
+}
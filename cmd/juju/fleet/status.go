@@ -0,0 +1,226 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package fleet
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/juju/fanout"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/status"
+)
+
+var statusHelpSummary = `
+Summarises the status of several models at once.`[1:]
+
+var statusHelpDetails = `
+Queries the status of each of the target models concurrently, and
+reports a summary of each: the model's status and agent version, the
+number of machines, applications and units it holds, and the machines
+and units that are in an error state.
+
+Targets are given with --targets as a comma-separated list of patterns
+of the form <controller>:<model>, in which both parts may contain shell
+wildcards. A pattern without a model matches all models on the
+controller, and a model without an owner matches models of that name
+whatever their owner. Only the models known to the client are
+considered; run "juju models" on a controller to refresh them. By
+default, all known models on all controllers are queried.
+
+Any arguments are passed on as status patterns, limiting the machines,
+applications and units considered in each model, as with "juju status".
+
+Examples:
+    juju fleet-status
+    juju fleet-status --targets 'prod-*:web,staging'
+    juju fleet-status --format yaml mysql
+
+See also:
+    fleet-models
+    fleet-run
+    status`[1:]
+
+// StatusAPI defines the API methods used by the fleet-status command.
+type StatusAPI interface {
+	Status(patterns []string) (*params.FullStatus, error)
+	Close() error
+}
+
+// NewStatusCommand returns a command that summarises the status of
+// several models.
+func NewStatusCommand() cmd.Command {
+	c := &statusCommand{}
+	c.store = jujuclient.NewFileClientStore()
+	c.newAPIFunc = func(controllerName, modelName string) (StatusAPI, error) {
+		root, err := c.NewAPIRoot(c.store, controllerName, modelName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return root.Client(), nil
+	}
+	return modelcmd.WrapBase(c)
+}
+
+type statusCommand struct {
+	fleetCommandBase
+	patterns   []string
+	newAPIFunc func(controllerName, modelName string) (StatusAPI, error)
+}
+
+// Info implements cmd.Command.
+func (c *statusCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "fleet-status",
+		Args:    "[<status pattern> ...]",
+		Purpose: statusHelpSummary,
+		Doc:     statusHelpDetails,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.setFlags(f, []string{"*"}, "The models to query, as <controller>:<model> patterns")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatStatusTabular,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *statusCommand) Init(args []string) error {
+	c.patterns = args
+	return c.init()
+}
+
+// Run implements cmd.Command.
+func (c *statusCommand) Run(ctx *cmd.Context) error {
+	targets, err := fanout.ModelTargets(c.store, c.targets)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	results, err := c.run(targets, func(target fanout.Target) (interface{}, error) {
+		client, err := c.newAPIFunc(target.Controller, target.Model)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer client.Close()
+		return client.Status(c.patterns)
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	summaries := make([]modelStatus, len(results))
+	failed := 0
+	for i, result := range results {
+		summaries[i] = summariseStatus(result)
+		if result.Err != nil {
+			failed++
+		}
+	}
+	if err := c.out.Write(ctx, summaries); err != nil {
+		return errors.Trace(err)
+	}
+	return failedError(failed, len(results))
+}
+
+// modelStatus summarises the status of a model.
+type modelStatus struct {
+	Controller   string   `yaml:"controller" json:"controller"`
+	Model        string   `yaml:"model" json:"model"`
+	Status       string   `yaml:"status,omitempty" json:"status,omitempty"`
+	Version      string   `yaml:"version,omitempty" json:"version,omitempty"`
+	Machines     int      `yaml:"machines,omitempty" json:"machines,omitempty"`
+	Applications int      `yaml:"applications,omitempty" json:"applications,omitempty"`
+	Units        int      `yaml:"units,omitempty" json:"units,omitempty"`
+	Problems     []string `yaml:"problems,omitempty" json:"problems,omitempty"`
+	Error        string   `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+func summariseStatus(result fanout.Result) modelStatus {
+	summary := modelStatus{
+		Controller: result.Target.Controller,
+		Model:      result.Target.Model,
+		Error:      errorString(result.Err),
+	}
+	fullStatus, _ := result.Value.(*params.FullStatus)
+	if result.Err != nil || fullStatus == nil {
+		return summary
+	}
+	summary.Status = fullStatus.Model.ModelStatus.Status
+	summary.Version = fullStatus.Model.Version
+	summary.Applications = len(fullStatus.Applications)
+	var addMachine func(machine params.MachineStatus)
+	addMachine = func(machine params.MachineStatus) {
+		summary.Machines++
+		if machine.AgentStatus.Status == string(status.Error) {
+			summary.Problems = append(summary.Problems, problem("machine", machine.Id, machine.AgentStatus))
+		} else if machine.InstanceStatus.Status == string(status.ProvisioningError) {
+			summary.Problems = append(summary.Problems, problem("machine", machine.Id, machine.InstanceStatus))
+		}
+		for _, container := range machine.Containers {
+			addMachine(container)
+		}
+	}
+	for _, machine := range fullStatus.Machines {
+		addMachine(machine)
+	}
+	var addUnit func(name string, unit params.UnitStatus)
+	addUnit = func(name string, unit params.UnitStatus) {
+		summary.Units++
+		if unit.WorkloadStatus.Status == string(status.Error) {
+			summary.Problems = append(summary.Problems, problem("unit", name, unit.WorkloadStatus))
+		}
+		for name, subordinate := range unit.Subordinates {
+			addUnit(name, subordinate)
+		}
+	}
+	for _, application := range fullStatus.Applications {
+		for name, unit := range application.Units {
+			addUnit(name, unit)
+		}
+	}
+	sort.Strings(summary.Problems)
+	return summary
+}
+
+func problem(kind, id string, status params.DetailedStatus) string {
+	if status.Info == "" {
+		return fmt.Sprintf("%s %s: %s", kind, id, status.Status)
+	}
+	return fmt.Sprintf("%s %s: %s", kind, id, status.Info)
+}
+
+func formatStatusTabular(writer io.Writer, value interface{}) error {
+	summaries, ok := value.([]modelStatus)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", summaries, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Controller", "Model", "Status", "Version", "Machines", "Apps", "Units", "Notes")
+	for _, s := range summaries {
+		if s.Error != "" {
+			w.Println(s.Controller, s.Model, "", "", "", "", "", s.Error)
+			continue
+		}
+		notes := ""
+		if n := len(s.Problems); n == 1 {
+			notes = s.Problems[0]
+		} else if n > 1 {
+			notes = fmt.Sprintf("%d machines and units in error", n)
+		}
+		w.Println(s.Controller, s.Model, s.Status, s.Version, s.Machines, s.Applications, s.Units, notes)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,138 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package fleet_test
+
+import (
+	"sync"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/fleet"
+)
+
+type StatusSuite struct {
+	baseSuite
+	mu       sync.Mutex
+	patterns map[string][]string
+}
+
+var _ = gc.Suite(&StatusSuite{})
+
+func (s *StatusSuite) SetUpTest(c *gc.C) {
+	s.baseSuite.SetUpTest(c)
+	s.patterns = make(map[string][]string)
+}
+
+func (s *StatusSuite) newAPI(controllerName, modelName string) (fleet.StatusAPI, error) {
+	if controllerName == "staging" {
+		return nil, errors.New("connection refused")
+	}
+	return &mockStatusAPI{suite: s, target: controllerName + ":" + modelName}, nil
+}
+
+func (s *StatusSuite) runStatus(c *gc.C, args ...string) (string, error) {
+	ctx, err := cmdtesting.RunCommand(c, fleet.NewStatusCommandForTest(s.store, s.newAPI), args...)
+	return cmdtesting.Stdout(ctx), err
+}
+
+func (s *StatusSuite) TestStatusTabular(c *gc.C) {
+	out, err := s.runStatus(c)
+	c.Assert(err, gc.ErrorMatches, "1 of 3 results failed")
+	c.Assert(out, gc.Equals, `
+Controller  Model      Status     Version  Machines  Apps  Units  Notes
+prod        admin/db   available  2.4.0    1         1     1      
+prod        admin/web  available  2.4.0    2         1     2      2 machines and units in error
+staging     admin/web                                             connection refused
+`[1:])
+}
+
+func (s *StatusSuite) TestStatusYAML(c *gc.C) {
+	out, err := s.runStatus(c, "--targets", "prod:web", "--format", "yaml", "nginx")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+- controller: prod
+  model: admin/web
+  status: available
+  version: 2.4.0
+  machines: 2
+  applications: 1
+  units: 2
+  problems:
+  - 'machine 0/lxd/0: failed to start'
+  - 'unit nginx/0: hook failed: "install"'
+`[1:])
+	c.Assert(s.patterns, jc.DeepEquals, map[string][]string{
+		"prod:admin/web": {"nginx"},
+	})
+}
+
+func (s *StatusSuite) TestStatusNoMatchingModels(c *gc.C) {
+	_, err := s.runStatus(c, "--targets", "dev")
+	c.Assert(err, gc.ErrorMatches, `models matching "dev" not found`)
+}
+
+func (s *StatusSuite) TestStatusInvalidParallel(c *gc.C) {
+	_, err := s.runStatus(c, "--parallel", "0")
+	c.Assert(err, gc.ErrorMatches, "--parallel must be at least 1, got 0")
+}
+
+type mockStatusAPI struct {
+	suite  *StatusSuite
+	target string
+}
+
+func (m *mockStatusAPI) Status(patterns []string) (*params.FullStatus, error) {
+	m.suite.mu.Lock()
+	m.suite.patterns[m.target] = patterns
+	m.suite.mu.Unlock()
+	fullStatus := &params.FullStatus{
+		Model: params.ModelStatusInfo{
+			Version:     "2.4.0",
+			ModelStatus: params.DetailedStatus{Status: "available"},
+		},
+	}
+	switch m.target {
+	case "prod:admin/db":
+		fullStatus.Machines = map[string]params.MachineStatus{
+			"0": {Id: "0", AgentStatus: params.DetailedStatus{Status: "started"}},
+		}
+		fullStatus.Applications = map[string]params.ApplicationStatus{
+			"mysql": {Units: map[string]params.UnitStatus{
+				"mysql/0": {WorkloadStatus: params.DetailedStatus{Status: "active"}},
+			}},
+		}
+	case "prod:admin/web":
+		fullStatus.Machines = map[string]params.MachineStatus{
+			"0": {
+				Id:          "0",
+				AgentStatus: params.DetailedStatus{Status: "started"},
+				Containers: map[string]params.MachineStatus{
+					"0/lxd/0": {
+						Id:          "0/lxd/0",
+						AgentStatus: params.DetailedStatus{Status: "error", Info: "failed to start"},
+					},
+				},
+			},
+		}
+		fullStatus.Applications = map[string]params.ApplicationStatus{
+			"nginx": {Units: map[string]params.UnitStatus{
+				"nginx/0": {
+					WorkloadStatus: params.DetailedStatus{Status: "error", Info: `hook failed: "install"`},
+					Subordinates: map[string]params.UnitStatus{
+						"telegraf/0": {WorkloadStatus: params.DetailedStatus{Status: "active"}},
+					},
+				},
+			}},
+		}
+	}
+	return fullStatus, nil
+}
+
+func (m *mockStatusAPI) Close() error {
+	return nil
+}
@@ -57,6 +57,14 @@ and bringing it under Juju's management; currently this requires that the
 machine be running Ubuntu, that it be accessible via SSH, and be running on
 the same network as the API server.
 
+A machine on which a Juju agent was previously deployed, such as one
+restored from an image, is refused unless --adopt is given. The previous
+agents are then stopped, and their data moved aside on the machine. If the
+previous machine agent was for a machine in this model that still exists
+with the same address, that machine is taken over by the new agent, and
+its previous agents can no longer connect; its units are deployed afresh.
+Adoption is refused if the machine holds units assigned to other machines.
+
 It is possible to override or augment constraints by passing provider-specific
 "placement directives" as an argument; these give the provider additional
 information about how to allocate the machine. For example, one can direct the
@@ -70,6 +78,7 @@ Examples:
    juju add-machine lxd:4                (starts a new lxd container on machine 4)
    juju add-machine --constraints mem=8G (starts a machine with at least 8GB RAM)
    juju add-machine ssh:user@10.10.0.3   (manually provisions machine with ssh)
+   juju add-machine ssh:10.0.0.3 --adopt (adopts a machine with a previous agent)
    juju add-machine winrm:user@10.10.0.3 (manually provisions machine with winrm)
    juju add-machine zone=us-east-1a      (start a machine in zone us-east-1a on AWS)
    juju add-machine maas2.name           (acquire machine maas2.name on MAAS)
@@ -114,6 +123,9 @@ type addCommand struct {
 	NumMachines int
 	// Disks describes disks that are to be attached to the machine.
 	Disks []storage.Constraints
+	// Adopt allows a manually provisioned machine with a previous
+	// agent to be added.
+	Adopt bool
 }
 
 func (c *addCommand) Info() *cmd.Info {
@@ -131,6 +143,7 @@ func (c *addCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.IntVar(&c.NumMachines, "n", 1, "The number of machines to add")
 	f.StringVar(&c.ConstraintsStr, "constraints", "", "Additional machine constraints")
 	f.Var(disksFlag{&c.Disks}, "disks", "Constraints for disks to attach to the machine")
+	f.BoolVar(&c.Adopt, "adopt", false, "Adopt a manually provisioned machine on which an agent was previously deployed")
 }
 
 func (c *addCommand) Init(args []string) error {
@@ -152,6 +165,9 @@ func (c *addCommand) Init(args []string) error {
 	if c.NumMachines > 1 && c.Placement != nil && c.Placement.Directive != "" {
 		return errors.New("cannot use -n when specifying a placement directive")
 	}
+	if c.Adopt && (c.Placement == nil || c.Placement.Scope != sshScope) {
+		return errors.New("--adopt can only be used when provisioning a machine with ssh")
+	}
 	return nil
 }
 
@@ -372,6 +388,7 @@ func (c *addCommand) tryManualProvision(client AddMachineAPI, config *config.Con
 		Stdout:         ctx.Stdout,
 		Stderr:         ctx.Stderr,
 		AuthorizedKeys: authKeys,
+		Adopt:          c.Adopt,
 		UpdateBehavior: &params.UpdateBehavior{
 			EnableOSRefreshUpdate: config.EnableOSRefreshUpdate(),
 			EnableOSUpgrade:       config.EnableOSUpgrade(),
@@ -90,6 +90,16 @@ func (s *AddMachineSuite) TestInit(c *gc.C) {
 			args:      []string{"something:special"},
 			count:     1,
 			placement: "something:special",
+		}, {
+			args:      []string{"ssh:10.10.0.3", "--adopt"},
+			count:     1,
+			placement: "ssh:10.10.0.3",
+		}, {
+			args:        []string{"winrm:10.10.0.3", "--adopt"},
+			errorString: "--adopt can only be used when provisioning a machine with ssh",
+		}, {
+			args:        []string{"--adopt"},
+			errorString: "--adopt can only be used when provisioning a machine with ssh",
 		},
 	} {
 		c.Logf("test %d", i)
@@ -147,6 +157,17 @@ func (s *AddMachineSuite) TestSSHPlacement(c *gc.C) {
 	c.Assert(cmdtesting.Stderr(context), gc.Equals, "created machine 42\n")
 }
 
+func (s *AddMachineSuite) TestSSHPlacementAdopt(c *gc.C) {
+	var adopt bool
+	s.PatchValue(machine.SSHProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		adopt = args.Adopt
+		return "3", nil
+	})
+	_, err := s.run(c, "ssh:10.1.2.3", "--adopt")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(adopt, jc.IsTrue)
+}
+
 func (s *AddMachineSuite) TestSSHPlacementError(c *gc.C) {
 	s.PatchValue(machine.SSHProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
 		return "", errors.New("failed to initialize warp core")
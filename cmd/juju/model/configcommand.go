@@ -16,6 +16,7 @@ import (
 	"gopkg.in/juju/environschema.v1"
 
 	"github.com/juju/juju/api/modelconfig"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -32,6 +33,11 @@ are displayed.
 Supplying one key name returns only the value for the key. Supplying key=value
 will set the supplied key to the supplied value, this can be repeated for
 multiple keys. You can also specify a yaml file containing key values.
+
+Keys that are known neither to Juju nor to the model's cloud provider are
+rejected, to catch misspellings; use --force to set them anyway. Use
+--dry-run to see the changes that setting values would make, and the
+running workers that would react to them, without making them.
 `
 	modelConfigHelpDocKeys = `
 The following keys are available:
@@ -45,6 +51,8 @@ Examples:
     juju model-config path/to/file.yaml
     juju model-config -m othercontroller:mymodel default-series=yakkety test-mode=false
     juju model-config --reset default-series test-mode
+    juju model-config --dry-run http-proxy=http://10.0.0.1:3128
+    juju model-config --force my-custom-key=value
 
 See also:
     models
@@ -73,6 +81,8 @@ type configCommand struct {
 	reset      []string // Holds the keys to be reset until parsed.
 	resetKeys  []string // Holds the keys to be reset once parsed.
 	setOptions common.ConfigFlag
+	dryRun     bool
+	force      bool
 }
 
 // configCommandAPI defines an API interface to be used during testing.
@@ -81,6 +91,8 @@ type configCommandAPI interface {
 	ModelGet() (map[string]interface{}, error)
 	ModelGetWithMetadata() (config.ConfigValues, error)
 	ModelSet(config map[string]interface{}) error
+	ModelSetForce(config map[string]interface{}) error
+	PreviewModelSet(config map[string]interface{}, force bool) ([]params.ModelConfigChange, error)
 	ModelUnset(keys ...string) error
 }
 
@@ -117,6 +129,8 @@ func (c *configCommand) SetFlags(f *gnuflag.FlagSet) {
 		"yaml":    cmd.FormatYaml,
 	})
 	f.Var(cmd.NewAppendStringsValue(&c.reset), "reset", "Reset the provided comma delimited keys")
+	f.BoolVar(&c.dryRun, "dry-run", false, "Show the changes that setting the values would make, without making them")
+	f.BoolVar(&c.force, "force", false, "Allow setting keys unknown to Juju and the cloud provider")
 }
 
 // Init implements part of the cmd.Command interface.
@@ -129,14 +143,24 @@ func (c *configCommand) Init(args []string) error {
 		return errors.Trace(err)
 	}
 
+	var err error
 	switch len(args) {
 	case 0:
-		return c.handleZeroArgs()
+		err = c.handleZeroArgs()
 	case 1:
-		return c.handleOneArg(args[0])
+		err = c.handleOneArg(args[0])
 	default:
-		return c.handleArgs(args)
+		err = c.handleArgs(args)
+	}
+	if err != nil {
+		return errors.Trace(err)
 	}
+	// Only setting values can be previewed: with no args we are getting
+	// or resetting, and c.keys holds any single key being retrieved.
+	if c.dryRun && (len(args) == 0 || len(c.keys) > 0 || len(c.resetKeys) > 0) {
+		return errors.New("--dry-run can only be used when setting values")
+	}
+	return nil
 }
 
 // handleZeroArgs handles the case where there are no positional args.
@@ -292,12 +316,63 @@ func (c *configCommand) setConfig(client configCommandAPI, ctx *cmd.Context) err
 		}
 	}
 
+	if c.dryRun {
+		changes, err := client.PreviewModelSet(values, c.force)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return writeConfigChanges(ctx, changes)
+	}
+	if c.force {
+		return block.ProcessBlockedError(client.ModelSetForce(values), block.BlockChange)
+	}
 	if err := c.verifyKnownKeys(client, keys); err != nil {
 		return errors.Trace(err)
 	}
 	return block.ProcessBlockedError(client.ModelSet(values), block.BlockChange)
 }
 
+// writeConfigChanges writes a tabular summary of the changes that
+// setting model config values would make.
+func writeConfigChanges(ctx *cmd.Context, changes []params.ModelConfigChange) error {
+	if len(changes) == 0 {
+		ctx.Infof("No changes.")
+		return nil
+	}
+	tw := output.TabWriter(ctx.Stdout)
+	w := output.Wrapper{tw}
+	w.Println("Attribute", "Old", "New", "Reacting workers")
+	for _, change := range changes {
+		oldValue, err := formatConfigValue(change.OldValue)
+		if err != nil {
+			return errors.Annotatef(err, "formatting value for %q", change.Key)
+		}
+		newValue, err := formatConfigValue(change.NewValue)
+		if err != nil {
+			return errors.Annotatef(err, "formatting value for %q", change.Key)
+		}
+		workers := "-"
+		if len(change.Workers) > 0 {
+			workers = strings.Join(change.Workers, ",")
+		}
+		w.Println(change.Key, oldValue, newValue, workers)
+	}
+	return tw.Flush()
+}
+
+// formatConfigValue formats a config value for tabular output,
+// showing unset values as "-".
+func formatConfigValue(value interface{}) (string, error) {
+	if value == nil {
+		return "-", nil
+	}
+	out := &bytes.Buffer{}
+	if err := cmd.FormatYaml(out, value); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(out.String(), "\n"), nil
+}
+
 // get writes the value of a single key or the full output for the model to the cmd.Context.
 func (c *configCommand) getConfig(client configCommandAPI, ctx *cmd.Context) error {
 	attrs, err := client.ModelGetWithMetadata()
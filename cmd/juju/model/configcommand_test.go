@@ -12,6 +12,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/model"
 	"github.com/juju/juju/testing"
 )
@@ -64,6 +65,18 @@ func (s *ConfigCommandSuite) TestInit(c *gc.C) {
 			desc:       "get multiple fails",
 			args:       []string{"one", "two"},
 			errorMatch: "can only retrieve a single value, or all values",
+		}, {
+			desc:       "dry-run cannot get",
+			args:       []string{"--dry-run", "one"},
+			errorMatch: "--dry-run can only be used when setting values",
+		}, {
+			desc:       "dry-run cannot reset",
+			args:       []string{"--dry-run", "--reset", "one", "special=foo"},
+			errorMatch: "--dry-run can only be used when setting values",
+		}, {
+			desc:   "dry-run set succeeds",
+			args:   []string{"--dry-run", "special=foo"},
+			nilErr: true,
 		}, {
 			// test variations
 			desc:   "test reset interspersed",
@@ -254,3 +267,37 @@ func (s *ConfigCommandSuite) TestResetBlockedError(c *gc.C) {
 	_, err := s.run(c, "--reset", "special")
 	testing.AssertOperationWasBlocked(c, err, ".*TestBlockedError.*")
 }
+
+func (s *ConfigCommandSuite) TestSetForce(c *gc.C) {
+	_, err := s.run(c, "--force", "unknown=foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.forced, jc.IsTrue)
+	c.Assert(s.fake.values, jc.DeepEquals, map[string]interface{}{"unknown": "foo"})
+}
+
+func (s *ConfigCommandSuite) TestSetDryRun(c *gc.C) {
+	s.fake.changes = []params.ModelConfigChange{{
+		Key:      "ftp-proxy",
+		NewValue: "http://proxy",
+		Workers:  []string{"proxy-config-updater"},
+	}, {
+		Key:      "special",
+		OldValue: "special value",
+		NewValue: "extra",
+	}}
+	ctx, err := s.run(c, "--dry-run", "ftp-proxy=http://proxy", "special=extra")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.forced, jc.IsFalse)
+	c.Assert(s.fake.values["special"], gc.Equals, "special value")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Attribute  Old            New           Reacting workers\n"+
+		"ftp-proxy  -              http://proxy  proxy-config-updater\n"+
+		"special    special value  extra         -\n")
+}
+
+func (s *ConfigCommandSuite) TestSetDryRunNoChanges(c *gc.C) {
+	ctx, err := s.run(c, "--dry-run", "special=special value")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No changes.\n")
+}
@@ -91,7 +91,7 @@ Continue [y/N]? `[1:]
 type DestroyModelAPI interface {
 	Close() error
 	BestAPIVersion() int
-	DestroyModel(tag names.ModelTag, destroyStorage *bool) error
+	DestroyModel(tag names.ModelTag, destroyStorage *bool) (string, error)
 	ModelStatus(models ...names.ModelTag) ([]base.ModelStatus, error)
 }
 
@@ -264,12 +264,16 @@ upgrade the controller to version 2.3 or greater.
 		destroyStorage = &c.destroyStorage
 	}
 	modelTag := names.NewModelTag(modelDetails.ModelUUID)
-	if err := api.DestroyModel(modelTag, destroyStorage); err != nil {
+	operationId, err := api.DestroyModel(modelTag, destroyStorage)
+	if err != nil {
 		return c.handleError(
 			modelTag, modelName, api,
 			errors.Annotate(err, "cannot destroy model"),
 		)
 	}
+	if operationId != "" {
+		logger.Debugf("model %q destroy operation %s", modelName, operationId)
+	}
 
 	// Wait for model to be destroyed.
 	const modelStatusPollWait = 2 * time.Second
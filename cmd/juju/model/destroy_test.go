@@ -56,9 +56,9 @@ func (f *fakeAPI) BestAPIVersion() int {
 	return f.bestAPIVersion
 }
 
-func (f *fakeAPI) DestroyModel(tag names.ModelTag, destroyStorage *bool) error {
+func (f *fakeAPI) DestroyModel(tag names.ModelTag, destroyStorage *bool) (string, error) {
 	f.MethodCall(f, "DestroyModel", tag, destroyStorage)
-	return f.NextErr()
+	return "", f.NextErr()
 }
 
 func (f *fakeAPI) ModelStatus(models ...names.ModelTag) ([]base.ModelStatus, error) {
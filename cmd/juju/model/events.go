@@ -0,0 +1,256 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/modelevents"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/watcher"
+)
+
+// NewEventsCommand returns a command that shows a model's activity
+// feed.
+func NewEventsCommand() cmd.Command {
+	return modelcmd.Wrap(&eventsCommand{})
+}
+
+// EventsAPI defines the methods on the ModelEvents API that the events
+// command calls.
+type EventsAPI interface {
+	Events(params.ModelEventsArgs) ([]params.ModelEvent, error)
+	WatchEvents() (watcher.NotifyWatcher, error)
+	Close() error
+}
+
+type eventsCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+	api EventsAPI
+
+	limit   int
+	follow  bool
+	isoTime bool
+}
+
+// EventItem is the structured output of a single model event.
+type EventItem struct {
+	Seq      int64    `yaml:"seq" json:"seq"`
+	Time     string   `yaml:"time" json:"time"`
+	Kind     string   `yaml:"kind" json:"kind"`
+	Actor    string   `yaml:"actor" json:"actor"`
+	Entities []string `yaml:"entities,omitempty" json:"entities,omitempty"`
+	Message  string   `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+const eventsHelpDoc = `
+Shows the model's activity feed: the high-level changes made to the
+model, such as applications deployed, units added, relations created,
+hooks failed and upgrades completed, along with who or what made them.
+
+The most recent events are shown, oldest first. With --follow, the
+command keeps running and shows new events as they happen, until
+interrupted.
+
+Examples:
+
+    juju events
+    juju events -n 100
+    juju events --follow
+
+See also:
+    show-status-log
+    debug-log
+`
+
+// Info implements Command.
+func (c *eventsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "events",
+		Purpose: "Shows the model's activity feed.",
+		Doc:     eventsHelpDoc,
+	}
+}
+
+// SetFlags implements Command.
+func (c *eventsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": c.formatTabular,
+	})
+	f.IntVar(&c.limit, "n", 20, "Show the last N events")
+	f.BoolVar(&c.follow, "follow", false, "Keep showing new events as they happen")
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+}
+
+// Init implements Command.
+func (c *eventsCommand) Init(args []string) error {
+	if c.limit <= 0 {
+		return errors.New("the number of events must be positive")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *eventsCommand) getAPI() (EventsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return modelevents.NewClient(root), nil
+}
+
+// Run implements Command.
+func (c *eventsCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	events, err := client.Events(params.ModelEventsArgs{Limit: c.limit})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !c.follow {
+		return c.out.Write(ctx, c.eventItems(events))
+	}
+	return c.followEvents(ctx, client, events)
+}
+
+// followEvents writes the events already fetched, then each new event
+// as the model's activity feed is updated, until interrupted. In
+// tabular format, each event is written on a single line.
+func (c *eventsCommand) followEvents(ctx *cmd.Context, client EventsAPI, events []params.ModelEvent) error {
+	write := func(events []params.ModelEvent) error {
+		if len(events) == 0 {
+			return nil
+		}
+		items := c.eventItems(events)
+		if c.out.Name() != "tabular" {
+			return c.out.Write(ctx, items)
+		}
+		for _, item := range items {
+			fmt.Fprintln(ctx.Stdout, formatEventLine(item))
+		}
+		return nil
+	}
+	if err := write(events); err != nil {
+		return errors.Trace(err)
+	}
+	var last int64
+	if len(events) > 0 {
+		last = events[len(events)-1].Seq
+	}
+
+	w, err := client.WatchEvents()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer w.Kill()
+
+	interrupted := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
+	for {
+		select {
+		case <-interrupted:
+			return nil
+		case _, ok := <-w.Changes():
+			if !ok {
+				return errors.Trace(w.Wait())
+			}
+		}
+		// Fetch all of the events recorded since the last one
+		// written, a page at a time.
+		for {
+			events, err := client.Events(params.ModelEventsArgs{After: last})
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if err := write(events); err != nil {
+				return errors.Trace(err)
+			}
+			if len(events) == 0 {
+				break
+			}
+			last = events[len(events)-1].Seq
+		}
+	}
+}
+
+func (c *eventsCommand) eventItems(events []params.ModelEvent) []EventItem {
+	items := make([]EventItem, len(events))
+	for i, event := range events {
+		items[i] = EventItem{
+			Seq:      event.Seq,
+			Time:     common.FormatTime(&event.Time, c.isoTime),
+			Kind:     event.Kind,
+			Actor:    readableTag(event.Actor),
+			Entities: make([]string, len(event.Entities)),
+			Message:  event.Message,
+		}
+		for j, entity := range event.Entities {
+			items[i].Entities[j] = readableTag(entity)
+		}
+	}
+	return items
+}
+
+// readableTag returns the id of the entity with the given tag, or the
+// tag itself if it cannot be parsed.
+func readableTag(tag string) string {
+	t, err := names.ParseTag(tag)
+	if err != nil {
+		return tag
+	}
+	return t.Kind() + " " + t.Id()
+}
+
+func (c *eventsCommand) formatTabular(writer io.Writer, value interface{}) error {
+	items, ok := value.([]EventItem)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", items, value)
+	}
+	if len(items) == 0 {
+		fmt.Fprintln(writer, "No events to display.")
+		return nil
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Time", "Kind", "Actor", "Entities", "Message")
+	for _, item := range items {
+		w.Println(item.Time, item.Kind, item.Actor, strings.Join(item.Entities, ", "), item.Message)
+	}
+	return tw.Flush()
+}
+
+// formatEventLine formats an event on a single line, for following
+// the activity feed.
+func formatEventLine(item EventItem) string {
+	line := fmt.Sprintf("%s %s by %s", item.Time, item.Kind, item.Actor)
+	if len(item.Entities) > 0 {
+		line += " (" + strings.Join(item.Entities, ", ") + ")"
+	}
+	if item.Message != "" {
+		line += ": " + item.Message
+	}
+	return line
+}
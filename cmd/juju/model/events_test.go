@@ -0,0 +1,148 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/watcher/watchertest"
+)
+
+type EventsCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  *fakeEventsClient
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&EventsCommandSuite{})
+
+func (s *EventsCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeEventsClient{}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		testing.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+}
+
+func event(seq int64, kind, actor string, entities ...string) params.ModelEvent {
+	return params.ModelEvent{
+		Seq:      seq,
+		Time:     time.Date(2018, 3, 1, 12, 0, int(seq), 0, time.UTC),
+		Kind:     kind,
+		Actor:    actor,
+		Entities: entities,
+	}
+}
+
+func (s *EventsCommandSuite) TestEvents(c *gc.C) {
+	hookFailed := event(2, "hook-failed", "unit-mysql-0", "unit-mysql-0")
+	hookFailed.Message = `hook failed: "install"`
+	s.fake.results = [][]params.ModelEvent{{
+		event(1, "unit-added", "user-admin", "unit-mysql-0", "application-mysql"),
+		hookFailed,
+	}}
+	ctx, err := cmdtesting.RunCommand(c, model.NewEventsCommandForTest(s.fake, s.store), "-n", "5", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"Events", []interface{}{params.ModelEventsArgs{Limit: 5}}},
+		{"Close", nil},
+	})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Time                  Kind         Actor         Entities                         Message\n"+
+		"2018-03-01 12:00:01Z  unit-added   user admin    unit mysql/0, application mysql  \n"+
+		"2018-03-01 12:00:02Z  hook-failed  unit mysql/0  unit mysql/0                     hook failed: \"install\"\n")
+}
+
+func (s *EventsCommandSuite) TestEventsJSON(c *gc.C) {
+	s.fake.results = [][]params.ModelEvent{{
+		event(1, "relation-created", "user-admin", "relation-wordpress.db#mysql.server"),
+	}}
+	ctx, err := cmdtesting.RunCommand(c, model.NewEventsCommandForTest(s.fake, s.store), "--format", "json", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `[{"seq":1,"time":"2018-03-01 12:00:01Z","kind":"relation-created",`+
+		`"actor":"user admin","entities":["relation wordpress:db mysql:server"]}]`+"\n")
+}
+
+func (s *EventsCommandSuite) TestEventsNone(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, model.NewEventsCommandForTest(s.fake, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "No events to display.\n")
+}
+
+func (s *EventsCommandSuite) TestEventsInvalidLimit(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, model.NewEventsCommandForTest(s.fake, s.store), "-n", "0")
+	c.Assert(err, gc.ErrorMatches, "the number of events must be positive")
+}
+
+func (s *EventsCommandSuite) TestEventsFollow(c *gc.C) {
+	s.fake.results = [][]params.ModelEvent{
+		{event(1, "unit-added", "user-admin", "unit-mysql-0")},
+		{event(2, "upgrade-completed", "machine-0")},
+	}
+	ctx, err := cmdtesting.RunCommand(c, model.NewEventsCommandForTest(s.fake, s.store), "--follow", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"Events", []interface{}{params.ModelEventsArgs{Limit: 20}}},
+		{"WatchEvents", nil},
+		{"Events", []interface{}{params.ModelEventsArgs{After: 1}}},
+		{"Events", []interface{}{params.ModelEventsArgs{After: 2}}},
+		{"Close", nil},
+	})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"2018-03-01 12:00:01Z unit-added by user admin (unit mysql/0)\n"+
+		"2018-03-01 12:00:02Z upgrade-completed by machine 0\n")
+}
+
+type fakeEventsClient struct {
+	gitjujutesting.Stub
+	results [][]params.ModelEvent
+	changes chan struct{}
+	watcher *watchertest.MockNotifyWatcher
+}
+
+func (f *fakeEventsClient) Events(args params.ModelEventsArgs) ([]params.ModelEvent, error) {
+	f.MethodCall(f, "Events", args)
+	if len(f.results) == 0 {
+		if f.watcher != nil {
+			// Nothing more to report, so stop following.
+			f.watcher.Kill()
+			close(f.changes)
+		}
+		return nil, f.NextErr()
+	}
+	result := f.results[0]
+	f.results = f.results[1:]
+	return result, f.NextErr()
+}
+
+func (f *fakeEventsClient) WatchEvents() (watcher.NotifyWatcher, error) {
+	f.MethodCall(f, "WatchEvents")
+	f.changes = make(chan struct{}, 1)
+	f.changes <- struct{}{}
+	f.watcher = watchertest.NewMockNotifyWatcher(f.changes)
+	return f.watcher, f.NextErr()
+}
+
+func (f *fakeEventsClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
@@ -42,6 +42,13 @@ func NewRetryProvisioningCommandForTest(api RetryProvisioningAPI) cmd.Command {
 	return modelcmd.Wrap(cmd)
 }
 
+// NewEventsCommandForTest returns an EventsCommand with the api provided as specified.
+func NewEventsCommandForTest(api EventsAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &eventsCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 // NewShowCommandForTest returns a ShowCommand with the api provided as specified.
 func NewShowCommandForTest(api ShowModelAPI, refreshFunc func(jujuclient.ClientStore, string) error, store jujuclient.ClientStore) cmd.Command {
 	cmd := &showModelCommand{api: api}
@@ -50,6 +57,14 @@ func NewShowCommandForTest(api ShowModelAPI, refreshFunc func(jujuclient.ClientS
 	return modelcmd.Wrap(cmd, modelcmd.WrapSkipModelFlags)
 }
 
+// NewShowCommandWithCostForTest returns a ShowCommand with the apis
+// provided as specified.
+func NewShowCommandWithCostForTest(api ShowModelAPI, costAPI ModelCostAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &showModelCommand{api: api, costAPI: costAPI}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd, modelcmd.WrapSkipModelFlags)
+}
+
 // NewDumpCommandForTest returns a DumpCommand with the api provided as specified.
 func NewDumpCommandForTest(api DumpModelAPI, store jujuclient.ClientStore) cmd.Command {
 	cmd := &dumpCommand{api: api}
@@ -10,6 +10,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
 	jujucloud "github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/testing"
@@ -45,6 +46,8 @@ type fakeEnvAPI struct {
 	err           error
 	keys          []string
 	resetKeys     []string
+	forced        bool
+	changes       []params.ModelConfigChange
 }
 
 func (f *fakeEnvAPI) Close() error {
@@ -68,6 +71,16 @@ func (f *fakeEnvAPI) ModelSet(config map[string]interface{}) error {
 	return f.err
 }
 
+func (f *fakeEnvAPI) ModelSetForce(config map[string]interface{}) error {
+	f.forced = true
+	return f.ModelSet(config)
+}
+
+func (f *fakeEnvAPI) PreviewModelSet(config map[string]interface{}, force bool) ([]params.ModelConfigChange, error) {
+	f.forced = force
+	return f.changes, f.err
+}
+
 func (f *fakeEnvAPI) ModelUnset(keys ...string) error {
 	f.resetKeys = keys
 	return f.err
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/gnuflag"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api/costestimation"
 	"github.com/juju/juju/api/modelmanager"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/common"
@@ -18,7 +19,22 @@ import (
 	"github.com/juju/juju/cmd/output"
 )
 
-const showModelCommandDoc = `Show information about the current or specified model`
+const showModelCommandDoc = `
+Show information about the current or specified model.
+
+With --cost, the model's hourly and monthly costs are estimated from
+the prices of the instances, storage and load balancers it uses. Prices
+are taken from the model's "price-catalog" configuration, and from the
+cloud's own instance type prices where the cloud publishes them.
+Resources without a known price are listed, but not included in the
+totals.
+
+Examples:
+    juju show-model
+    juju show-model mymodel --cost
+
+See also:
+    model-config`[1:]
 
 func NewShowCommand() cmd.Command {
 	showCmd := &showModelCommand{}
@@ -29,8 +45,12 @@ func NewShowCommand() cmd.Command {
 type showModelCommand struct {
 	modelcmd.ModelCommandBase
 
-	out cmd.Output
-	api ShowModelAPI
+	out     cmd.Output
+	api     ShowModelAPI
+	costAPI ModelCostAPI
+
+	// cost is true if the model's cost should be estimated.
+	cost bool
 }
 
 // ShowModelAPI defines the methods on the client API that the
@@ -51,6 +71,24 @@ func (c *showModelCommand) getAPI() (ShowModelAPI, error) {
 	return modelmanager.NewClient(api), nil
 }
 
+// ModelCostAPI defines the methods on the cost estimation API that
+// the show-model command calls.
+type ModelCostAPI interface {
+	Close() error
+	ModelCost() (params.ModelCost, error)
+}
+
+func (c *showModelCommand) getCostAPI() (ModelCostAPI, error) {
+	if c.costAPI != nil {
+		return c.costAPI, nil
+	}
+	api, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return costestimation.NewClient(api), nil
+}
+
 // Info implements Command.Info.
 func (c *showModelCommand) Info() *cmd.Info {
 	return &cmd.Info{
@@ -65,6 +103,7 @@ func (c *showModelCommand) Info() *cmd.Info {
 func (c *showModelCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+	f.BoolVar(&c.cost, "cost", false, "Estimate the hourly and monthly cost of the model")
 }
 
 // Init implements Command.Init.
@@ -112,9 +151,34 @@ func (c *showModelCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if c.cost {
+		cost, err := c.modelCost()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for name, info := range infoMap {
+			info.Cost = cost
+			infoMap[name] = info
+		}
+	}
 	return c.out.Write(ctx, infoMap)
 }
 
+func (c *showModelCommand) modelCost() (*common.ModelCost, error) {
+	api, err := c.getCostAPI()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer api.Close()
+	cost, err := api.ModelCost()
+	if errors.IsNotSupported(err) {
+		return nil, errors.New("estimating model costs is not supported by this version of Juju")
+	} else if err != nil {
+		return nil, errors.Annotate(err, "estimating model cost")
+	}
+	return common.ModelCostFromParams(cost), nil
+}
+
 func (c *showModelCommand) apiModelInfoToModelInfoMap(modelInfo []params.ModelInfo, controllerName string) (map[string]common.ModelInfo, error) {
 	// TODO(perrito666) 2016-05-02 lp:1558657
 	now := time.Now()
@@ -128,6 +128,77 @@ func (s *ShowCommandSuite) TestShow(c *gc.C) {
 	})
 }
 
+func (s *ShowCommandSuite) TestShowCost(c *gc.C) {
+	costAPI := &fakeModelCostClient{cost: params.ModelCost{
+		Currency:    "USD",
+		HourlyCost:  0.11664,
+		MonthlyCost: 85.1472,
+		Resources: []params.ResourceCost{{
+			Kind:        "machine",
+			Id:          "0",
+			Description: "instance-type=m4.large",
+			HourlyCost:  0.1,
+		}, {
+			Kind:        "volume",
+			Id:          "0",
+			Description: `120.0GiB from pool "ebs"`,
+			HourlyCost:  0.01664,
+		}, {
+			Kind: "load-balancer",
+			Id:   "haproxy",
+			Error: &params.Error{
+				Message: "price for load balancers not found",
+				Code:    params.CodeNotFound,
+			},
+		}},
+	}}
+	command := model.NewShowCommandWithCostForTest(&s.fake, costAPI, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command, "--cost", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	s.expectedOutput["mymodel"].(attrs)["cost"] = attrs{
+		"currency": "USD",
+		"hourly":   0.1166,
+		"monthly":  85.15,
+		"resources": []interface{}{
+			attrs{
+				"kind":        "machine",
+				"id":          "0",
+				"description": "instance-type=m4.large",
+				"hourly":      0.1,
+			},
+			attrs{
+				"kind":        "volume",
+				"id":          "0",
+				"description": `120.0GiB from pool "ebs"`,
+				"hourly":      0.0166,
+			},
+			attrs{
+				"kind":  "load-balancer",
+				"id":    "haproxy",
+				"error": "price for load balancers not found",
+			},
+		},
+	}
+	c.Assert(cmdtesting.Stdout(ctx), jc.YAMLEquals, s.expectedOutput)
+	costAPI.CheckCallNames(c, "ModelCost", "Close")
+}
+
+func (s *ShowCommandSuite) TestShowCostNotSupported(c *gc.C) {
+	costAPI := &fakeModelCostClient{}
+	costAPI.SetErrors(errors.NotSupportedf("cost estimation"))
+	command := model.NewShowCommandWithCostForTest(&s.fake, costAPI, s.store)
+	_, err := cmdtesting.RunCommand(c, command, "--cost")
+	c.Assert(err, gc.ErrorMatches, "estimating model costs is not supported by this version of Juju")
+}
+
+func (s *ShowCommandSuite) TestShowCostError(c *gc.C) {
+	costAPI := &fakeModelCostClient{}
+	costAPI.SetErrors(errors.NotFoundf("price catalog"))
+	command := model.NewShowCommandWithCostForTest(&s.fake, costAPI, s.store)
+	_, err := cmdtesting.RunCommand(c, command, "--cost")
+	c.Assert(err, gc.ErrorMatches, "estimating model cost: price catalog not found")
+}
+
 func (s *ShowCommandSuite) TestShowUnknownCallsRefresh(c *gc.C) {
 	called := false
 	refresh := func(jujuclient.ClientStore, string) error {
@@ -647,3 +718,18 @@ func (f *fakeModelShowClient) ModelInfo(tags []names.ModelTag) ([]params.ModelIn
 	}
 	return []params.ModelInfoResult{{Result: &f.info, Error: f.err}}, f.NextErr()
 }
+
+type fakeModelCostClient struct {
+	gitjujutesting.Stub
+	cost params.ModelCost
+}
+
+func (f *fakeModelCostClient) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
+
+func (f *fakeModelCostClient) ModelCost() (params.ModelCost, error) {
+	f.MethodCall(f, "ModelCost")
+	return f.cost, f.NextErr()
+}
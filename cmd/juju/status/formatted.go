@@ -65,7 +65,25 @@ type machineStatus struct {
 	Containers        map[string]machineStatus    `json:"containers,omitempty" yaml:"containers,omitempty"`
 	Constraints       string                      `json:"constraints,omitempty" yaml:"constraints,omitempty"`
 	Hardware          string                      `json:"hardware,omitempty" yaml:"hardware,omitempty"`
+	AvailabilityZone  string                      `json:"availability-zone,omitempty" yaml:"availability-zone,omitempty"`
+	Placement         string                      `json:"placement,omitempty" yaml:"placement,omitempty"`
+	VirtType          string                      `json:"virt-type,omitempty" yaml:"virt-type,omitempty"`
+	DisplayName       string                      `json:"display-name,omitempty" yaml:"display-name,omitempty"`
 	HAStatus          string                      `json:"controller-member-status,omitempty" yaml:"controller-member-status,omitempty"`
+	ProxyError        string                      `json:"proxy-error,omitempty" yaml:"proxy-error,omitempty"`
+	LXDProfiles       []string                    `json:"lxd-profiles,omitempty" yaml:"lxd-profiles,omitempty"`
+	Maintenance       *maintenanceInfo            `json:"maintenance,omitempty" yaml:"maintenance,omitempty"`
+	Offline           *agentOfflineInfo           `json:"offline,omitempty" yaml:"offline,omitempty"`
+	FanOverlays       map[string]fanOverlayStatus `json:"fan-overlays,omitempty" yaml:"fan-overlays,omitempty"`
+}
+
+// fanOverlayStatus describes the health of a fan overlay on a machine.
+type fanOverlayStatus struct {
+	Underlay string `json:"underlay" yaml:"underlay"`
+	Bridge   string `json:"bridge,omitempty" yaml:"bridge,omitempty"`
+	Address  string `json:"address,omitempty" yaml:"address,omitempty"`
+	Healthy  bool   `json:"healthy" yaml:"healthy"`
+	Message  string `json:"message,omitempty" yaml:"message,omitempty"`
 }
 
 // A goyaml bug means we can't declare these types
@@ -87,21 +105,26 @@ func (s machineStatus) MarshalYAML() (interface{}, error) {
 }
 
 type applicationStatus struct {
-	Err           error                 `json:"-" yaml:",omitempty"`
-	Charm         string                `json:"charm" yaml:"charm"`
-	Series        string                `json:"series"`
-	OS            string                `json:"os"`
-	CharmOrigin   string                `json:"charm-origin" yaml:"charm-origin"`
-	CharmName     string                `json:"charm-name" yaml:"charm-name"`
-	CharmRev      int                   `json:"charm-rev" yaml:"charm-rev"`
-	CanUpgradeTo  string                `json:"can-upgrade-to,omitempty" yaml:"can-upgrade-to,omitempty"`
-	Exposed       bool                  `json:"exposed" yaml:"exposed"`
-	Life          string                `json:"life,omitempty" yaml:"life,omitempty"`
-	StatusInfo    statusInfoContents    `json:"application-status,omitempty" yaml:"application-status"`
-	Relations     map[string][]string   `json:"relations,omitempty" yaml:"relations,omitempty"`
-	SubordinateTo []string              `json:"subordinate-to,omitempty" yaml:"subordinate-to,omitempty"`
-	Units         map[string]unitStatus `json:"units,omitempty" yaml:"units,omitempty"`
-	Version       string                `json:"version,omitempty" yaml:"version,omitempty"`
+	Err            error                 `json:"-" yaml:",omitempty"`
+	Charm          string                `json:"charm" yaml:"charm"`
+	Series         string                `json:"series"`
+	OS             string                `json:"os"`
+	CharmOrigin    string                `json:"charm-origin" yaml:"charm-origin"`
+	CharmName      string                `json:"charm-name" yaml:"charm-name"`
+	CharmRev       int                   `json:"charm-rev" yaml:"charm-rev"`
+	CanUpgradeTo   string                `json:"can-upgrade-to,omitempty" yaml:"can-upgrade-to,omitempty"`
+	Exposed        bool                  `json:"exposed" yaml:"exposed"`
+	Life           string                `json:"life,omitempty" yaml:"life,omitempty"`
+	StatusInfo     statusInfoContents    `json:"application-status,omitempty" yaml:"application-status"`
+	Relations      map[string][]string   `json:"relations,omitempty" yaml:"relations,omitempty"`
+	SubordinateTo  []string              `json:"subordinate-to,omitempty" yaml:"subordinate-to,omitempty"`
+	Units          map[string]unitStatus `json:"units,omitempty" yaml:"units,omitempty"`
+	Version        string                `json:"version,omitempty" yaml:"version,omitempty"`
+	UnhealthyUnits int                   `json:"unhealthy-units,omitempty" yaml:"unhealthy-units,omitempty"`
+	Utilization    *utilization          `json:"utilization,omitempty" yaml:"utilization,omitempty"`
+	Maintenance    *maintenanceInfo      `json:"maintenance,omitempty" yaml:"maintenance,omitempty"`
+
+	LoadBalancerAddress string `json:"load-balancer-address,omitempty" yaml:"load-balancer-address,omitempty"`
 }
 
 type applicationStatusNoMarshal applicationStatus
@@ -182,6 +205,31 @@ type meterStatus struct {
 	Message string `json:"message,omitempty" yaml:"message,omitempty"`
 }
 
+// utilization describes the resources used by a unit's processes, or
+// the total used by the units of an application.
+type utilization struct {
+	CPUPercent  float64 `json:"cpu-percent" yaml:"cpu-percent"`
+	MemoryBytes int64   `json:"memory-bytes" yaml:"memory-bytes"`
+	DiskBytes   int64   `json:"disk-bytes" yaml:"disk-bytes"`
+	Since       string  `json:"since,omitempty" yaml:"since,omitempty"`
+}
+
+// maintenanceInfo describes the maintenance mode of a machine,
+// application or unit.
+type maintenanceInfo struct {
+	Reason  string `json:"reason" yaml:"reason"`
+	Expires string `json:"expires,omitempty" yaml:"expires,omitempty"`
+}
+
+// agentOfflineInfo describes the acknowledgement that the agent of a
+// machine or unit is offline.
+type agentOfflineInfo struct {
+	Reason         string `json:"reason" yaml:"reason"`
+	AcknowledgedBy string `json:"acknowledged-by" yaml:"acknowledged-by"`
+	Acknowledged   string `json:"acknowledged" yaml:"acknowledged"`
+	Expires        string `json:"expires" yaml:"expires"`
+}
+
 type unitStatus struct {
 	// New Juju Health Status fields.
 	WorkloadStatusInfo statusInfoContents `json:"workload-status,omitempty" yaml:"workload-status"`
@@ -189,6 +237,10 @@ type unitStatus struct {
 	MeterStatus        *meterStatus       `json:"meter-status,omitempty" yaml:"meter-status,omitempty"`
 
 	Leader        bool                  `json:"leader,omitempty" yaml:"leader,omitempty"`
+	Branch        string                `json:"branch,omitempty" yaml:"branch,omitempty"`
+	Utilization   *utilization          `json:"utilization,omitempty" yaml:"utilization,omitempty"`
+	Maintenance   *maintenanceInfo      `json:"maintenance,omitempty" yaml:"maintenance,omitempty"`
+	Offline       *agentOfflineInfo     `json:"offline,omitempty" yaml:"offline,omitempty"`
 	Charm         string                `json:"upgrading-from,omitempty" yaml:"upgrading-from,omitempty"`
 	Machine       string                `json:"machine,omitempty" yaml:"machine,omitempty"`
 	OpenedPorts   []string              `json:"open-ports,omitempty" yaml:"open-ports,omitempty"`
@@ -22,6 +22,10 @@ type statusFormatter struct {
 	controllerName string
 	relations      map[int]params.RelationStatus
 	isoTime        bool
+
+	// showUtilization is true if the resources used by units and
+	// applications are to be included.
+	showUtilization bool
 }
 
 // NewStatusFormatter takes stored model information (params.FullStatus) and populates
@@ -80,6 +84,9 @@ func (sf *statusFormatter) format() (formattedStatus, error) {
 	for sn, s := range sf.status.Applications {
 		out.Applications[sn] = sf.formatApplication(sn, s)
 	}
+	if sf.showUtilization {
+		sumUtilization(out.Applications)
+	}
 	for sn, s := range sf.status.RemoteApplications {
 		out.RemoteApplications[sn] = sf.formatRemoteApplication(sn, s)
 	}
@@ -132,6 +139,26 @@ func (sf *statusFormatter) formatMachine(machine params.MachineStatus) machineSt
 		Containers:        make(map[string]machineStatus),
 		Constraints:       machine.Constraints,
 		Hardware:          machine.Hardware,
+		AvailabilityZone:  machine.AvailabilityZone,
+		Placement:         machine.Placement,
+		VirtType:          machine.VirtType,
+		DisplayName:       machine.DisplayName,
+		ProxyError:        machine.ProxyError,
+		LXDProfiles:       machine.LXDProfiles,
+		Maintenance:       sf.formatMaintenance(machine.Maintenance),
+		Offline:           sf.formatAgentOffline(machine.Offline),
+	}
+	for _, overlay := range machine.FanOverlays {
+		if out.FanOverlays == nil {
+			out.FanOverlays = make(map[string]fanOverlayStatus)
+		}
+		out.FanOverlays[overlay.Overlay] = fanOverlayStatus{
+			Underlay: overlay.Underlay,
+			Bridge:   overlay.Bridge,
+			Address:  overlay.Address,
+			Healthy:  overlay.Healthy,
+			Message:  overlay.Message,
+		}
 	}
 
 	for k, d := range machine.NetworkInterfaces {
@@ -182,21 +209,25 @@ func (sf *statusFormatter) formatApplication(name string, application params.App
 	}
 
 	out := applicationStatus{
-		Err:           application.Err,
-		Charm:         application.Charm,
-		Series:        application.Series,
-		OS:            strings.ToLower(appOS.String()),
-		CharmOrigin:   charmOrigin,
-		CharmName:     charmName,
-		CharmRev:      charmRev,
-		Exposed:       application.Exposed,
-		Life:          application.Life,
-		Relations:     application.Relations,
-		CanUpgradeTo:  application.CanUpgradeTo,
-		SubordinateTo: application.SubordinateTo,
-		Units:         make(map[string]unitStatus),
-		StatusInfo:    sf.getApplicationStatusInfo(application),
-		Version:       application.WorkloadVersion,
+		Err:            application.Err,
+		Charm:          application.Charm,
+		Series:         application.Series,
+		OS:             strings.ToLower(appOS.String()),
+		CharmOrigin:    charmOrigin,
+		CharmName:      charmName,
+		CharmRev:       charmRev,
+		Exposed:        application.Exposed,
+		Life:           application.Life,
+		Relations:      application.Relations,
+		CanUpgradeTo:   application.CanUpgradeTo,
+		SubordinateTo:  application.SubordinateTo,
+		Units:          make(map[string]unitStatus),
+		StatusInfo:     sf.getApplicationStatusInfo(application),
+		Version:        application.WorkloadVersion,
+		UnhealthyUnits: application.UnhealthyUnits,
+		Maintenance:    sf.formatMaintenance(application.Maintenance),
+
+		LoadBalancerAddress: application.LoadBalancerAddress,
 	}
 	for k, m := range application.Units {
 		out.Units[k] = sf.formatUnit(unitFormatInfo{
@@ -327,6 +358,19 @@ func (sf *statusFormatter) formatUnit(info unitFormatInfo) unitStatus {
 		Charm:              info.unit.Charm,
 		Subordinates:       make(map[string]unitStatus),
 		Leader:             info.unit.Leader,
+		Branch:             info.unit.Branch,
+		Maintenance:        sf.formatMaintenance(info.unit.Maintenance),
+		Offline:            sf.formatAgentOffline(info.unit.Offline),
+	}
+
+	if sf.showUtilization && info.unit.Utilization != nil {
+		updated := info.unit.Utilization.Updated
+		out.Utilization = &utilization{
+			CPUPercent:  info.unit.Utilization.CPUPercent,
+			MemoryBytes: info.unit.Utilization.MemoryBytes,
+			DiskBytes:   info.unit.Utilization.DiskBytes,
+			Since:       common.FormatTime(&updated, sf.isoTime),
+		}
 	}
 
 	if ms, ok := info.meterStatuses[info.unitName]; ok {
@@ -347,6 +391,70 @@ func (sf *statusFormatter) formatUnit(info unitFormatInfo) unitStatus {
 	return out
 }
 
+// formatMaintenance returns the formatted maintenance mode of an
+// entity, or nil if it is not in maintenance mode.
+func (sf *statusFormatter) formatMaintenance(m *params.MaintenanceStatus) *maintenanceInfo {
+	if m == nil {
+		return nil
+	}
+	out := &maintenanceInfo{Reason: m.Reason}
+	if m.Expires != nil {
+		out.Expires = common.FormatTime(m.Expires, sf.isoTime)
+	}
+	return out
+}
+
+// formatAgentOffline returns the formatted acknowledgement that the
+// agent of a machine or unit is offline, or nil if there is none.
+func (sf *statusFormatter) formatAgentOffline(o *params.AgentOfflineStatus) *agentOfflineInfo {
+	if o == nil {
+		return nil
+	}
+	return &agentOfflineInfo{
+		Reason:         o.Reason,
+		AcknowledgedBy: o.AcknowledgedBy,
+		Acknowledged:   common.FormatTime(&o.Acknowledged, sf.isoTime),
+		Expires:        common.FormatTime(&o.Expires, sf.isoTime),
+	}
+}
+
+// sumUtilization records against each application the total of the
+// resources used by its units, including those that are subordinate to
+// the units of other applications.
+func sumUtilization(applications map[string]applicationStatus) {
+	totals := make(map[string]*utilization)
+	var add func(unitName string, unit unitStatus)
+	add = func(unitName string, unit unitStatus) {
+		if unit.Utilization != nil {
+			appName, err := names.UnitApplication(unitName)
+			if err == nil {
+				total, ok := totals[appName]
+				if !ok {
+					total = &utilization{}
+					totals[appName] = total
+				}
+				total.CPUPercent += unit.Utilization.CPUPercent
+				total.MemoryBytes += unit.Utilization.MemoryBytes
+				total.DiskBytes += unit.Utilization.DiskBytes
+			}
+		}
+		for name, sub := range unit.Subordinates {
+			add(name, sub)
+		}
+	}
+	for _, app := range applications {
+		for name, unit := range app.Units {
+			add(name, unit)
+		}
+	}
+	for appName, total := range totals {
+		if app, ok := applications[appName]; ok {
+			app.Utilization = total
+			applications[appName] = app
+		}
+	}
+}
+
 func (sf *statusFormatter) getStatusInfoContents(inst params.DetailedStatus) statusInfoContents {
 	// TODO(perrito66) add status validation.
 	info := statusInfoContents{
@@ -17,6 +17,7 @@ import (
 	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api/storage"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/cmd/output"
@@ -148,20 +149,29 @@ func (c *statusHistoryCommand) Init(args []string) error {
 
 const runningHookMSG = "running update-status hook"
 
-func (c *statusHistoryCommand) getAPI() (HistoryAPI, error) {
+func (c *statusHistoryCommand) getAPI(kind status.HistoryKind) (HistoryAPI, error) {
 	if c.api != nil {
 		return c.api, nil
 	}
+	switch kind {
+	case status.KindVolume, status.KindFilesystem:
+		// The history of storage is provided by the Storage facade.
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return storage.NewClient(root), nil
+	}
 	return c.NewAPIClient()
 }
 
 func (c *statusHistoryCommand) Run(ctx *cmd.Context) error {
-	apiclient, err := c.getAPI()
+	kind := status.HistoryKind(c.outputContent)
+	apiclient, err := c.getAPI(kind)
 	if err != nil {
 		return errors.Trace(err)
 	}
 	defer apiclient.Close()
-	kind := status.HistoryKind(c.outputContent)
 	var delta *time.Duration
 
 	if c.backlogSizeDays != 0 {
@@ -186,6 +196,16 @@ func (c *statusHistoryCommand) Run(ctx *cmd.Context) error {
 			return errors.Errorf("%q is not a valid name for a %s", c.entityName, kind)
 		}
 		tag = names.NewUnitTag(c.entityName)
+	case status.KindVolume:
+		if !names.IsValidVolume(c.entityName) {
+			return errors.Errorf("%q is not a valid name for a %s", c.entityName, kind)
+		}
+		tag = names.NewVolumeTag(c.entityName)
+	case status.KindFilesystem:
+		if !names.IsValidFilesystem(c.entityName) {
+			return errors.Errorf("%q is not a valid name for a %s", c.entityName, kind)
+		}
+		tag = names.NewFilesystemTag(c.entityName)
 	default:
 		if !names.IsValidMachine(c.entityName) {
 			return errors.Errorf("%q is not a valid name for a %s", c.entityName, kind)
@@ -110,9 +110,45 @@ func (s *StatusHistorySuite) TestResults(c *gc.C) {
 	c.Check(cmdtesting.Stdout(ctx), gc.Equals, expected)
 }
 
+func (s *StatusHistorySuite) TestVolumeResults(c *gc.C) {
+	api := &fakeHistoryAPI{
+		history: status.History{
+			{
+				Kind:   status.KindVolume,
+				Status: status.Attaching,
+				Info:   "volume not found",
+				Since:  s.next(),
+			}, {
+				Kind:   status.KindVolume,
+				Status: status.Attached,
+				Since:  s.next(),
+			},
+		},
+	}
+	s.api = api
+	expected := "" +
+		"Time                   Type    Status     Message\n" +
+		"28 Nov 2017 12:34:56Z  volume  attaching  volume not found\n" +
+		"28 Nov 2017 12:35:56Z  volume  attached   \n"
+
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--type", "volume", "0/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, expected)
+	c.Check(api.kind, gc.Equals, status.KindVolume)
+	c.Check(api.tag, gc.Equals, names.NewVolumeTag("0/1"))
+}
+
+func (s *StatusHistorySuite) TestInvalidFilesystemName(c *gc.C) {
+	s.api = &fakeHistoryAPI{}
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "--type", "filesystem", "mysql/0")
+	c.Assert(err, gc.ErrorMatches, `"mysql/0" is not a valid name for a filesystem`)
+}
+
 type fakeHistoryAPI struct {
 	err     error
 	history status.History
+	kind    status.HistoryKind
+	tag     names.Tag
 }
 
 func (*fakeHistoryAPI) Close() error {
@@ -120,5 +156,7 @@ func (*fakeHistoryAPI) Close() error {
 }
 
 func (f *fakeHistoryAPI) StatusHistory(kind status.HistoryKind, tag names.Tag, filter status.StatusHistoryFilter) (status.History, error) {
+	f.kind = kind
+	f.tag = tag
 	return f.history, f.err
 }
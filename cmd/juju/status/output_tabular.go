@@ -10,11 +10,13 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/dustin/go-humanize"
 	"github.com/juju/ansiterm"
 	"github.com/juju/errors"
 	"github.com/juju/utils"
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/charm.v6/hooks"
+	"gopkg.in/juju/names.v2"
 
 	cmdcrossmodel "github.com/juju/juju/cmd/juju/crossmodel"
 	"github.com/juju/juju/cmd/output"
@@ -184,6 +186,30 @@ func FormatTabular(writer io.Writer, forceColor bool, value interface{}) error {
 		}
 	}
 
+	if unitUtilization := collectUtilization(units); len(unitUtilization) > 0 {
+		outputHeaders("Utilization", "CPU", "Memory", "Disk", "Since")
+		for _, appName := range utils.SortStringsNaturally(stringKeysFromMap(fs.Applications)) {
+			app := fs.Applications[appName]
+			if app.Utilization == nil {
+				continue
+			}
+			p(appName, formatCPU(app.Utilization.CPUPercent),
+				humanize.IBytes(uint64(app.Utilization.MemoryBytes)),
+				humanize.IBytes(uint64(app.Utilization.DiskBytes)),
+				"",
+			)
+			appUnits := unitUtilization[appName]
+			for _, name := range utils.SortStringsNaturally(stringKeysFromMap(appUnits)) {
+				u := appUnits[name]
+				p(indent("", 2, name), formatCPU(u.CPUPercent),
+					humanize.IBytes(uint64(u.MemoryBytes)),
+					humanize.IBytes(uint64(u.DiskBytes)),
+					u.Since,
+				)
+			}
+		}
+	}
+
 	p()
 	printMachines(tw, fs.Machines)
 
@@ -219,6 +245,35 @@ func FormatTabular(writer io.Writer, forceColor bool, value interface{}) error {
 type offerItems []offerStatus
 
 // printOffers prints a tabular summary of the offers.
+// collectUtilization returns the utilization of the given units and
+// their subordinates, grouped by application name.
+func collectUtilization(units map[string]unitStatus) map[string]map[string]*utilization {
+	result := make(map[string]map[string]*utilization)
+	var collect func(name string, u unitStatus)
+	collect = func(name string, u unitStatus) {
+		if u.Utilization != nil {
+			if appName, err := names.UnitApplication(name); err == nil {
+				if result[appName] == nil {
+					result[appName] = make(map[string]*utilization)
+				}
+				result[appName][name] = u.Utilization
+			}
+		}
+		for subName, sub := range u.Subordinates {
+			collect(subName, sub)
+		}
+	}
+	for name, u := range units {
+		collect(name, u)
+	}
+	return result
+}
+
+// formatCPU formats the share of a single CPU used.
+func formatCPU(percent float64) string {
+	return fmt.Sprintf("%.1f%%", percent)
+}
+
 func printOffers(tw *ansiterm.TabWriter, offers map[string]offerStatus) error {
 	if len(offers) == 0 {
 		return nil
@@ -40,6 +40,10 @@ type statusCommand struct {
 	api      statusAPI
 
 	color bool
+
+	// utilization is true if the resources used by units and
+	// applications are to be shown.
+	utilization bool
 }
 
 var usageSummary = `
@@ -73,10 +77,17 @@ The available output formats are:
 - json: Displays information about the model, machines, applications, and units
       in structured JSON format.
 
+With --utilization, the resources most recently reported to be used by each
+unit are shown, along with the total for each application. CPU use is the
+share of a single CPU used by the unit's processes over the machine agent's
+sampling interval, so may exceed 100% on machines with several CPUs; disk use
+is that of the unit's agent directory, which includes its charm.
+
 Examples:
     juju show-status
     juju show-status mysql
     juju show-status nova-*
+    juju show-status --utilization
 
 See also:
     machines
@@ -99,6 +110,7 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
 	f.BoolVar(&c.color, "color", false, "Force use of ANSI color codes")
+	f.BoolVar(&c.utilization, "utilization", false, "Show the CPU, memory and disk used by units and applications")
 
 	defaultFormat := "tabular"
 
@@ -157,6 +169,7 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 	formatter := newStatusFormatter(status, controllerName, c.isoTime)
+	formatter.showUtilization = c.utilization
 	formatted, err := formatter.format()
 	if err != nil {
 		return errors.Trace(err)
@@ -4239,6 +4239,203 @@ func (s *StatusSuite) TestFormatTabularMetering(c *gc.C) {
 		"Machine  State  DNS  Inst id  Series  AZ  Message\n")
 }
 
+func (s *StatusSuite) TestFormatTabularUtilization(c *gc.C) {
+	status := formattedStatus{
+		Applications: map[string]applicationStatus{
+			"foo": {
+				Utilization: &utilization{
+					CPUPercent:  75,
+					MemoryBytes: 3072,
+					DiskBytes:   6144,
+				},
+				Units: map[string]unitStatus{
+					"foo/0": {
+						Utilization: &utilization{
+							CPUPercent:  50,
+							MemoryBytes: 1024,
+							DiskBytes:   2048,
+							Since:       "01 Mar 2018 12:00:00Z",
+						},
+					},
+					"foo/1": {
+						Utilization: &utilization{
+							CPUPercent:  25,
+							MemoryBytes: 2048,
+							DiskBytes:   4096,
+							Since:       "01 Mar 2018 12:01:00Z",
+						},
+					},
+				},
+			},
+		},
+	}
+	out := &bytes.Buffer{}
+	err := FormatTabular(out, false, status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.String(), gc.Equals, ""+
+		"Model  Controller  Cloud/Region  Version\n"+
+		"                                 \n"+
+		"\n"+
+		"App  Version  Status  Scale  Charm  Store  Rev  OS  Notes\n"+
+		"foo                     0/2                  0      \n"+
+		"\n"+
+		"Unit   Workload  Agent  Machine  Public address  Ports  Message\n"+
+		"foo/0                                                   \n"+
+		"foo/1                                                   \n"+
+		"\n"+
+		"Utilization  CPU    Memory   Disk     Since\n"+
+		"foo          75.0%  3.0 KiB  6.0 KiB  \n"+
+		"  foo/0      50.0%  1.0 KiB  2.0 KiB  01 Mar 2018 12:00:00Z\n"+
+		"  foo/1      25.0%  2.0 KiB  4.0 KiB  01 Mar 2018 12:01:00Z\n"+
+		"\n"+
+		"Machine  State  DNS  Inst id  Series  AZ  Message\n")
+}
+
+func (s *StatusSuite) TestFormatUtilization(c *gc.C) {
+	updated := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	status := &params.FullStatus{
+		Model: params.ModelStatusInfo{
+			CloudTag: "cloud-dummy",
+		},
+		Applications: map[string]params.ApplicationStatus{
+			"foo": {
+				Charm: "cs:foo-1",
+				Units: map[string]params.UnitStatus{
+					"foo/0": {
+						Utilization: &params.UnitUtilization{
+							CPUPercent:  50,
+							MemoryBytes: 1024,
+							DiskBytes:   2048,
+							Updated:     updated,
+						},
+						Subordinates: map[string]params.UnitStatus{
+							"bar/0": {
+								Utilization: &params.UnitUtilization{
+									CPUPercent:  5,
+									MemoryBytes: 256,
+									DiskBytes:   512,
+									Updated:     updated,
+								},
+							},
+						},
+					},
+					"foo/1": {
+						Utilization: &params.UnitUtilization{
+							CPUPercent:  25,
+							MemoryBytes: 2048,
+							DiskBytes:   4096,
+							Updated:     updated,
+						},
+					},
+					"foo/2": {},
+				},
+			},
+			"bar": {
+				Charm:         "cs:bar-1",
+				SubordinateTo: []string{"foo"},
+			},
+		},
+	}
+
+	// Utilization is only shown when requested.
+	formatter := NewStatusFormatter(status, true)
+	formatted, err := formatter.format()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(formatted.Applications["foo"].Utilization, gc.IsNil)
+	c.Check(formatted.Applications["foo"].Units["foo/0"].Utilization, gc.IsNil)
+
+	formatter.showUtilization = true
+	formatted, err = formatter.format()
+	c.Assert(err, jc.ErrorIsNil)
+	foo := formatted.Applications["foo"]
+	c.Check(foo.Utilization, jc.DeepEquals, &utilization{
+		CPUPercent:  75,
+		MemoryBytes: 3072,
+		DiskBytes:   6144,
+	})
+	c.Check(foo.Units["foo/0"].Utilization, jc.DeepEquals, &utilization{
+		CPUPercent:  50,
+		MemoryBytes: 1024,
+		DiskBytes:   2048,
+		Since:       "2018-03-01 12:00:00Z",
+	})
+	c.Check(foo.Units["foo/2"].Utilization, gc.IsNil)
+	c.Check(formatted.Applications["bar"].Utilization, jc.DeepEquals, &utilization{
+		CPUPercent:  5,
+		MemoryBytes: 256,
+		DiskBytes:   512,
+	})
+}
+
+func (s *StatusSuite) TestFormatMachineFanOverlays(c *gc.C) {
+	status := &params.FullStatus{
+		Model: params.ModelStatusInfo{
+			CloudTag: "cloud-dummy",
+		},
+		Machines: map[string]params.MachineStatus{
+			"0": {
+				Id: "0",
+				FanOverlays: []params.FanOverlayStatus{{
+					Underlay: "172.31.0.0/16",
+					Overlay:  "253.0.0.0/8",
+					Bridge:   "fan-253",
+					Address:  "253.64.10.1",
+					Healthy:  true,
+				}, {
+					Underlay: "10.0.0.0/16",
+					Overlay:  "252.0.0.0/8",
+					Message:  "no fan bridge has an address on the overlay",
+				}},
+			},
+			"1": {Id: "1"},
+		},
+	}
+	formatted, err := NewStatusFormatter(status, true).format()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(formatted.Machines["0"].FanOverlays, jc.DeepEquals, map[string]fanOverlayStatus{
+		"253.0.0.0/8": {
+			Underlay: "172.31.0.0/16",
+			Bridge:   "fan-253",
+			Address:  "253.64.10.1",
+			Healthy:  true,
+		},
+		"252.0.0.0/8": {
+			Underlay: "10.0.0.0/16",
+			Message:  "no fan bridge has an address on the overlay",
+		},
+	})
+	c.Check(formatted.Machines["1"].FanOverlays, gc.IsNil)
+}
+
+func (s *StatusSuite) TestFormatMachineAgentOffline(c *gc.C) {
+	status := &params.FullStatus{
+		Model: params.ModelStatusInfo{
+			CloudTag: "cloud-dummy",
+		},
+		Machines: map[string]params.MachineStatus{
+			"0": {
+				Id: "0",
+				Offline: &params.AgentOfflineStatus{
+					Reason:         "replacing motherboard",
+					AcknowledgedBy: "admin",
+					Acknowledged:   time.Date(2018, 2, 27, 9, 0, 0, 0, time.UTC),
+					Expires:        time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC),
+				},
+			},
+			"1": {Id: "1"},
+		},
+	}
+	formatted, err := NewStatusFormatter(status, true).format()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(formatted.Machines["0"].Offline, jc.DeepEquals, &agentOfflineInfo{
+		Reason:         "replacing motherboard",
+		AcknowledgedBy: "admin",
+		Acknowledged:   "2018-02-27 09:00:00Z",
+		Expires:        "2018-03-01 12:00:00Z",
+	})
+	c.Check(formatted.Machines["1"].Offline, gc.IsNil)
+}
+
 //
 // Filtering Feature
 //
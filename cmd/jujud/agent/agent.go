@@ -13,11 +13,13 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
 	"github.com/juju/utils/featureflag"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/cmd/jujud/util"
+	"github.com/juju/juju/worker/crashreporter"
 )
 
 // AgentConf is a terribly confused interface.
@@ -140,3 +142,16 @@ func setupAgentLogging(config agent.Config) {
 		logger.Warningf("developer feature flags enabled: %s", flags)
 	}
 }
+
+// startCrashCollection collects a crash report for any crash of the
+// agent's previous run, and returns a collector whose Recover method
+// should be deferred to collect a crash report if the agent panics.
+// The reports are uploaded by the agent's crash-reporter worker.
+func startCrashCollection(config agent.Config) *crashreporter.Collector {
+	store := crashreporter.NewStore(crashreporter.Dir(config.DataDir(), config.Tag()))
+	collector := crashreporter.NewCollector(store, agent.LogFilename(config), clock.WallClock)
+	if err := collector.CollectPrevious(); err != nil {
+		logger.Warningf("cannot collect crash report: %v", err)
+	}
+	return collector
+}
@@ -32,6 +32,7 @@ var (
 	}
 	aliveModelWorkers = []string{
 		"action-pruner",
+		"charm-auto-refresher",
 		"charm-revision-updater",
 		"compute-provisioner",
 		"environ-tracker",
@@ -42,6 +43,8 @@ var (
 		"migration-fortress",
 		"migration-inactive-flag",
 		"migration-master",
+		"model-snapshotter",
+		"orphan-sweeper",
 		"application-scaler",
 		"state-cleaner",
 		"status-history-pruner",
@@ -83,6 +86,7 @@ var (
 	notMigratingUnitWorkers = []string{
 		"api-address-updater",
 		"charm-dir",
+		"crash-reporter",
 		"hook-retry-strategy",
 		"leadership-tracker",
 		"logging-config-updater",
@@ -112,9 +116,12 @@ var (
 	}
 	notMigratingMachineWorkers = []string{
 		"api-address-updater",
+		"crash-reporter",
 		"disk-manager",
 		"fan-configurer",
+		"host-firewaller",
 		// "host-key-reporter", not stable, exits when done
+		// "interruption-watcher", uninstalls on the dummy provider
 		"log-sender",
 		"logging-config-updater",
 		"machine-action-runner",
@@ -125,6 +132,7 @@ var (
 		"storage-provisioner",
 		"unconverted-api-workers",
 		"unit-agent-deployer",
+		"utilization-reporter",
 	}
 )
 
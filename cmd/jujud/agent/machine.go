@@ -51,6 +51,8 @@ import (
 	cmdutil "github.com/juju/juju/cmd/jujud/util"
 	"github.com/juju/juju/container"
 	"github.com/juju/juju/container/kvm"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/raftlease"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/simplestreams"
 	"github.com/juju/juju/instance"
@@ -80,6 +82,7 @@ import (
 	"github.com/juju/juju/worker/migrationmaster"
 	"github.com/juju/juju/worker/provisioner"
 	psworker "github.com/juju/juju/worker/pubsub"
+	"github.com/juju/juju/worker/raft"
 	"github.com/juju/juju/worker/upgradesteps"
 )
 
@@ -289,6 +292,7 @@ func NewMachineAgent(
 		runner:                      runner,
 		rootDir:                     rootDir,
 		initialUpgradeCheckComplete: gate.NewLock(),
+		apiServerDrain:              gate.NewLock(),
 		loopDeviceManager:           loopDeviceManager,
 		newIntrospectionSocketName:  newIntrospectionSocketName,
 		prometheusRegistry:          prometheusRegistry,
@@ -346,6 +350,11 @@ type MachineAgent struct {
 	// longer any immediately pending agent upgrades.
 	initialUpgradeCheckComplete gate.Lock
 
+	// Used to signal that the API server should drain its
+	// connections because the agent is about to restart into
+	// a new version.
+	apiServerDrain gate.Lock
+
 	mongoInitMutex   sync.Mutex
 	mongoInitialized bool
 
@@ -359,6 +368,11 @@ type MachineAgent struct {
 	// Only API servers have hubs. This is temporary until the apiserver and
 	// peergrouper have manifolds.
 	centralHub *pubsub.StructuredHub
+
+	// raftLeases holds the lease state replicated by the raft worker,
+	// which the lease managers in the agent's State use.
+	raftLeases  *state.RaftLeases
+	raftApplier *raft.Applier
 }
 
 // Wait waits for the machine agent to finish.
@@ -431,6 +445,7 @@ func (a *MachineAgent) Run(*cmd.Context) error {
 	}
 
 	setupAgentLogging(a.CurrentConfig())
+	defer startCrashCollection(a.CurrentConfig()).Recover()
 
 	if err := introspection.WriteProfileFunctions(); err != nil {
 		// This isn't fatal, just annoying.
@@ -441,6 +456,15 @@ func (a *MachineAgent) Run(*cmd.Context) error {
 	// have dependencies on a central hub worker.
 	a.centralHub = centralhub.New(a.Tag().(names.MachineTag))
 
+	// The raft worker comes and goes, but the lease managers in
+	// State outlive it, so they share an FSM and an applier with
+	// whichever raft worker is running.
+	a.raftApplier = raft.NewApplier()
+	a.raftLeases = &state.RaftLeases{
+		FSM:     raftlease.NewFSM(),
+		Applier: a.raftApplier,
+	}
+
 	// Before doing anything else, we need to make sure the certificate generated for
 	// use by mongo to validate controller connections is correct. This needs to be done
 	// before any possible restart of the mongo service.
@@ -534,6 +558,7 @@ func (a *MachineAgent) makeEngineCreator(previousAgentVersion version.Number) fu
 			AgentConfigChanged:   a.configChangedVal,
 			UpgradeStepsLock:     a.upgradeComplete,
 			UpgradeCheckLock:     a.initialUpgradeCheckComplete,
+			APIServerDrainLock:   a.apiServerDrain,
 			OpenController:       a.initController,
 			OpenState:            a.initState,
 			OpenStateForUpgrade:  a.openStateForUpgrade,
@@ -544,8 +569,12 @@ func (a *MachineAgent) makeEngineCreator(previousAgentVersion version.Number) fu
 			Clock:                clock.WallClock,
 			ValidateMigration:    a.validateMigration,
 			PrometheusRegisterer: a.prometheusRegistry,
+			PrometheusGatherer:   a.prometheusRegistry,
+			EngineReport:         engine.Report,
 			CentralHub:           a.centralHub,
 			PubSubReporter:       pubsubReporter,
+			RaftFSM:              a.raftLeases.FSM,
+			RaftApplier:          a.raftApplier,
 			UpdateLoggerConfig:   updateAgentConfLogging,
 			NewAgentStatusSetter: func(apiConn api.Connection) (upgradesteps.StatusSetter, error) {
 				return a.machine(apiConn)
@@ -553,10 +582,18 @@ func (a *MachineAgent) makeEngineCreator(previousAgentVersion version.Number) fu
 			ControllerLeaseDuration:           time.Minute,
 			LogPruneInterval:                  5 * time.Minute,
 			TransactionPruneInterval:          time.Hour,
+			AgentBinaryPruneInterval:          24 * time.Hour,
+			ControllerHealthInterval:          time.Minute,
+			DBMaintenanceInterval:             15 * time.Minute,
+			ContainerImageCacheInterval:       10 * time.Minute,
+			UtilizationReportInterval:         5 * time.Minute,
+			CrashReportInterval:               5 * time.Minute,
 			SetStatePool:                      statePoolReporter.set,
 			RegisterIntrospectionHTTPHandlers: registerIntrospectionHandlers,
 			NewModelWorker:                    a.startModelWorkers,
 			ControllerSupportsSpaces:          controllerSupportsSpaces,
+			PromoteToHA:                       promoteToHA,
+			ResizeMongo:                       resizeMongo,
 		})
 		if err := dependency.Install(engine, manifolds); err != nil {
 			if err := worker.Stop(engine); err != nil {
@@ -799,6 +836,10 @@ func (a *MachineAgent) openStateForUpgrade() (*state.State, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := configureObjectStoreFromState(st); err != nil {
+		st.Close()
+		return nil, errors.Trace(err)
+	}
 	return st, nil
 }
 
@@ -942,6 +983,7 @@ func (a *MachineAgent) initController(agentConfig agent.Config) (*state.Controll
 			stateenvirons.GetNewEnvironFunc(environs.New),
 		),
 		RunTransactionObserver: a.mongoTxnCollector.AfterRunTransaction,
+		RaftLeases:             a.raftLeases,
 	})
 	return ctlr, nil
 }
@@ -964,10 +1006,15 @@ func (a *MachineAgent) initState(agentConfig agent.Config) (*state.State, error)
 		agentConfig,
 		dialOpts,
 		a.mongoTxnCollector.AfterRunTransaction,
+		a.raftLeases,
 	)
 	if err != nil {
 		return nil, err
 	}
+	if err := configureObjectStoreFromState(st); err != nil {
+		st.Close()
+		return nil, errors.Trace(err)
+	}
 
 	reportOpenedState(st)
 
@@ -1000,12 +1047,19 @@ func (a *MachineAgent) startModelWorkers(modelUUID string, modelType state.Model
 		Clock:                       clock.WallClock,
 		RunFlagDuration:             time.Minute,
 		CharmRevisionUpdateInterval: 24 * time.Hour,
+		CharmAutoRefreshInterval:    10 * time.Minute,
 		InstPollerAggregationDelay:  3 * time.Second,
 		StatusHistoryPrunerInterval: 5 * time.Minute,
 		ActionPrunerInterval:        24 * time.Hour,
+		OrphanSweeperInterval:       6 * time.Hour,
+		DNSUpdaterInterval:          5 * time.Minute,
+		LBManagerInterval:           time.Minute,
+		ModelSnapshotInterval:       time.Hour,
 		NewEnvironFunc:              newEnvirons,
 		NewContainerBrokerFunc:      newCAASBroker,
 		NewMigrationMaster:          migrationmaster.NewWorker,
+
+		StorageProvisionerConcurrency: 10,
 	}
 	var manifolds dependency.Manifolds
 	if modelType == state.ModelTypeIAAS {
@@ -1022,6 +1076,99 @@ func (a *MachineAgent) startModelWorkers(modelUUID string, modelType state.Model
 	return engine, nil
 }
 
+// promoteToHA converts the mongo server of a controller that was
+// bootstrapped as a single node, and is about to gain peers, to the
+// settings used by controllers in HA. The conversion happens while
+// mongo is running; the agent config is then updated so that mongo is
+// configured the same way when it is next restarted.
+func promoteToHA(a agent.Agent, session *mgo.Session) error {
+	return a.ChangeConfig(func(config agent.ConfigSetter) error {
+		if config.Value(agent.MongoSingleNode) == "" {
+			return nil
+		}
+		ensureServerParams, err := cmdutil.NewEnsureServerParams(config)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !ensureServerParams.SingleNode {
+			return nil
+		}
+		logger.Infof("promoting single node controller to HA")
+		if err := mongo.PromoteToHA(session, config.MongoVersion(), ensureServerParams); err != nil {
+			return errors.Trace(err)
+		}
+		config.SetValue(agent.MongoSingleNode, "")
+		return nil
+	})
+}
+
+// resizeMongo keeps the mongo server of a controller sized for the
+// resources of its machine, which may have changed since mongo was
+// started. The memory profile and any sizes given in the controller
+// config are first recorded in the agent config, so that mongo is
+// configured the same way when it is next restarted; the sizing is
+// then applied to the running server, which is dialled directly as
+// each controller's server is sized for its own machine.
+func resizeMongo(a agent.Agent, controllerConfig controller.Config) error {
+	profile, err := mongo.NewMemoryProfile(controllerConfig.MongoMemoryProfile())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	sizes := map[string]int{
+		agent.MongoCacheSize: controllerConfig.MongoCacheSizeMB(),
+		agent.MongoOplogSize: controllerConfig.MongoOplogSizeMB(),
+	}
+	config := a.CurrentConfig()
+	changed := config.MongoMemoryProfile() != profile
+	for key, size := range sizes {
+		if size > 0 && config.Value(key) != strconv.Itoa(size) {
+			changed = true
+		}
+	}
+	if changed {
+		err := a.ChangeConfig(func(config agent.ConfigSetter) error {
+			config.SetMongoMemoryProfile(profile)
+			for key, size := range sizes {
+				if size > 0 {
+					config.SetValue(key, strconv.Itoa(size))
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return errors.Annotate(err, "cannot record mongo sizing in agent config")
+		}
+		config = a.CurrentConfig()
+	}
+
+	ensureServerParams, err := cmdutil.NewEnsureServerParams(config)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if ensureServerParams.SingleNode {
+		// The server is sized when it is promoted to HA.
+		return nil
+	}
+	sizing, err := mongo.DesiredSizing(ensureServerParams)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	info, ok := config.MongoInfo()
+	if !ok {
+		return errors.New("no mongo info in agent config")
+	}
+	dialOpts := mongo.DefaultDialOpts()
+	dialOpts.Direct = true
+	session, err := mongo.DialWithInfo(*info, dialOpts)
+	if err != nil {
+		return errors.Annotate(err, "cannot connect to mongo")
+	}
+	defer session.Close()
+	// The server may be a secondary.
+	session.SetMode(mgo.Monotonic, true)
+	return errors.Trace(mongo.ResizeServer(session, config.MongoVersion(), sizing))
+}
+
 // stateWorkerDialOpts is a mongo.DialOpts suitable
 // for use by StateWorker to dial mongo.
 //
@@ -1069,6 +1216,7 @@ func openState(
 	agentConfig agent.Config,
 	dialOpts mongo.DialOpts,
 	runTransactionObserver state.RunTransactionObserverFunc,
+	raftLeases *state.RaftLeases,
 ) (_ *state.State, _ *state.Machine, err error) {
 	info, ok := agentConfig.MongoInfo()
 	if !ok {
@@ -1089,6 +1237,7 @@ func openState(
 			stateenvirons.GetNewEnvironFunc(environs.New),
 		),
 		RunTransactionObserver: runTransactionObserver,
+		RaftLeases:             raftLeases,
 	})
 	if err != nil {
 		return nil, nil, err
@@ -18,6 +18,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/juju/worker.v1"
+	"gopkg.in/mgo.v2"
 
 	coreagent "github.com/juju/juju/agent"
 	"github.com/juju/juju/api"
@@ -26,19 +27,28 @@ import (
 	apideployer "github.com/juju/juju/api/deployer"
 	"github.com/juju/juju/cmd/jujud/agent/engine"
 	"github.com/juju/juju/container/lxd"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/raftlease"
 	"github.com/juju/juju/state"
 	proxyconfig "github.com/juju/juju/utils/proxy"
 	jworker "github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/agent"
+	"github.com/juju/juju/worker/agentbinarypruner"
 	"github.com/juju/juju/worker/apiaddressupdater"
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/apiconfigwatcher"
 	"github.com/juju/juju/worker/apiserver"
 	"github.com/juju/juju/worker/apiservercertwatcher"
+	"github.com/juju/juju/worker/apiserverconfigurer"
 	"github.com/juju/juju/worker/authenticationworker"
 	"github.com/juju/juju/worker/centralhub"
+	"github.com/juju/juju/worker/certmanager"
 	"github.com/juju/juju/worker/certupdater"
+	"github.com/juju/juju/worker/containerimagecache"
+	"github.com/juju/juju/worker/controllerhealth"
+	"github.com/juju/juju/worker/crashreporter"
 	"github.com/juju/juju/worker/dblogpruner"
+	"github.com/juju/juju/worker/dbmaintenance"
 	"github.com/juju/juju/worker/dependency"
 	"github.com/juju/juju/worker/deployer"
 	"github.com/juju/juju/worker/diskmanager"
@@ -47,18 +57,23 @@ import (
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/gate"
 	"github.com/juju/juju/worker/globalclockupdater"
+	"github.com/juju/juju/worker/grpcgateway"
+	"github.com/juju/juju/worker/hostfirewaller"
 	"github.com/juju/juju/worker/hostkeyreporter"
 	"github.com/juju/juju/worker/identityfilewriter"
+	"github.com/juju/juju/worker/interruptionwatcher"
 	"github.com/juju/juju/worker/logger"
 	"github.com/juju/juju/worker/logsender"
 	"github.com/juju/juju/worker/machineactions"
 	"github.com/juju/juju/worker/machiner"
 	"github.com/juju/juju/worker/migrationflag"
 	"github.com/juju/juju/worker/migrationminion"
+	"github.com/juju/juju/worker/modelassigner"
 	"github.com/juju/juju/worker/modelworkermanager"
 	"github.com/juju/juju/worker/peergrouper"
 	"github.com/juju/juju/worker/proxyupdater"
 	psworker "github.com/juju/juju/worker/pubsub"
+	"github.com/juju/juju/worker/raft"
 	"github.com/juju/juju/worker/reboot"
 	"github.com/juju/juju/worker/restorewatcher"
 	"github.com/juju/juju/worker/resumer"
@@ -71,6 +86,7 @@ import (
 	"github.com/juju/juju/worker/txnpruner"
 	"github.com/juju/juju/worker/upgrader"
 	"github.com/juju/juju/worker/upgradesteps"
+	"github.com/juju/juju/worker/utilizationreporter"
 )
 
 const (
@@ -81,6 +97,25 @@ const (
 	// globalClockUpdaterBackoffDelay is the amount of time to
 	// delay when a concurrent global clock update is detected.
 	globalClockUpdaterBackoffDelay = 10 * time.Second
+
+	// certManagerRetryDelay is the amount of time to wait before
+	// trying again to obtain an API certificate from an ACME CA.
+	certManagerRetryDelay = 10 * time.Minute
+
+	// modelAssignerInterval is how often the primary controller
+	// checks that the controllers to which models are assigned are
+	// still available.
+	modelAssignerInterval = 30 * time.Second
+
+	// apiServerDrainTimeout is the longest time the API server
+	// will spend draining its connections before the agent
+	// restarts into a new version.
+	apiServerDrainTimeout = 30 * time.Second
+
+	// interruptionCheckInterval is how often the machine agent asks
+	// the cloud whether its instance is about to be reclaimed. Spot
+	// instances may be given as little as 30 seconds' notice.
+	interruptionCheckInterval = 5 * time.Second
 )
 
 // ManifoldsConfig allows specialisation of the result of Manifolds.
@@ -113,6 +148,11 @@ type ManifoldsConfig struct {
 	// upgrader worker completes it's first check.
 	UpgradeCheckLock gate.Lock
 
+	// APIServerDrainLock is unlocked by the upgrader worker when the
+	// agent is about to restart into a new version, to have the API
+	// server drain its connections first.
+	APIServerDrainLock gate.Lock
+
 	// OpenController is function used by the controller manifold to
 	// create a *state.Controller.
 	OpenController func(coreagent.Config) (*state.Controller, error)
@@ -158,6 +198,14 @@ type ManifoldsConfig struct {
 	// by workers to register Prometheus metric collectors.
 	PrometheusRegisterer prometheus.Registerer
 
+	// PrometheusGatherer is a prometheus.Gatherer that may be used by
+	// workers to read the metrics collected by the agent.
+	PrometheusGatherer prometheus.Gatherer
+
+	// EngineReport returns the report of the dependency engine
+	// running these manifolds.
+	EngineReport func() map[string]interface{}
+
 	// CentralHub is the primary hub that exists in the apiserver.
 	CentralHub *pubsub.StructuredHub
 
@@ -165,6 +213,13 @@ type ManifoldsConfig struct {
 	// worker.
 	PubSubReporter psworker.Reporter
 
+	// RaftFSM holds the lease state that the raft worker replicates
+	// among the controllers, and RaftApplier passes lease commands to
+	// the running raft worker. They are created by the agent because
+	// the lease managers in State use them too.
+	RaftFSM     *raftlease.FSM
+	RaftApplier *raft.Applier
+
 	// UpdateLoggerConfig is a function that will save the specified
 	// config value as the logging config in the agent.conf file.
 	UpdateLoggerConfig func(string) error
@@ -184,6 +239,31 @@ type ManifoldsConfig struct {
 	// are pruned from the database.
 	TransactionPruneInterval time.Duration
 
+	// AgentBinaryPruneInterval defines how frequently agent binaries
+	// that are no longer used are removed from the controller.
+	AgentBinaryPruneInterval time.Duration
+
+	// ControllerHealthInterval defines how frequently a controller
+	// machine records its health in the database.
+	ControllerHealthInterval time.Duration
+
+	// DBMaintenanceInterval defines how frequently a controller
+	// machine reports on, and considers compacting, its database.
+	DBMaintenanceInterval time.Duration
+
+	// ContainerImageCacheInterval defines how frequently a machine
+	// brings its cache of container images up to date.
+	ContainerImageCacheInterval time.Duration
+
+	// UtilizationReportInterval defines how frequently a machine
+	// reports the resources used by the units deployed to it.
+	UtilizationReportInterval time.Duration
+
+	// CrashReportInterval defines how frequently the machine agent
+	// records its engine report for inclusion in crash reports, and
+	// retries uploading crash reports to the controller.
+	CrashReportInterval time.Duration
+
 	// SetStatePool is used by the state worker for informing the agent of
 	// the StatePool that it creates, so we can pass it to the introspection
 	// worker running outside of the dependency engine.
@@ -203,6 +283,15 @@ type ManifoldsConfig struct {
 	// not the controller model, represented by the given *state.State,
 	// supports network spaces.
 	ControllerSupportsSpaces func(*state.State) (bool, error)
+
+	// PromoteToHA converts the mongo server of a controller that was
+	// bootstrapped as a single node to the settings used by
+	// controllers in HA.
+	PromoteToHA func(coreagent.Agent, *mgo.Session) error
+
+	// ResizeMongo sizes the mongo server of a controller for the
+	// resources of its machine, according to the controller config.
+	ResizeMongo func(coreagent.Agent, controller.Config) error
 }
 
 // Manifolds returns a set of co-configured manifolds covering the
@@ -231,8 +320,10 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 		return err
 	}
 	var externalUpdateProxyFunc func(proxy.Settings) error
+	var runProxyCommandFunc func(string, ...string) (string, error)
 	if runtime.GOOS == "linux" {
 		externalUpdateProxyFunc = lxd.ConfigureLXDProxies
+		runProxyCommandFunc = proxyupdater.RunCommand
 	}
 
 	newExternalControllerWatcherClient := func(apiInfo *api.Info) (
@@ -308,6 +399,19 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			Reporter:       config.PubSubReporter,
 		}),
 
+		// The raft manifold replicates the controllers' lease state,
+		// exchanging messages with the raft workers on the other
+		// controllers over the central hub. Like the pubsub forwarder,
+		// it only runs on API servers through the hub dependency.
+		raftName: raft.Manifold(raft.ManifoldConfig{
+			AgentName:      agentName,
+			ClockName:      clockName,
+			CentralHubName: centralHubName,
+			FSM:            config.RaftFSM,
+			Applier:        config.RaftApplier,
+			NewWorker:      raft.NewWorker,
+		}),
+
 		/* TODO(menn0) - this is currently unused, pending further
 		 * refactoring in the state package.
 
@@ -398,6 +502,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			UpgradeStepsGateName: upgradeStepsGateName,
 			UpgradeCheckGateName: upgradeCheckGateName,
 			PreviousAgentVersion: config.PreviousAgentVersion,
+			APIServerDrain:       config.APIServerDrainLock,
 		}),
 
 		// The upgradesteps worker runs soon after the machine agent
@@ -504,6 +609,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			AgentName:       agentName,
 			APICallerName:   apiCallerName,
 			UpdateAgentFunc: config.UpdateLoggerConfig,
+			Clock:           config.Clock,
 		})),
 
 		// The diskmanager worker periodically lists block devices on the
@@ -522,6 +628,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			WorkerFunc:      proxyupdater.NewWorker,
 			ExternalUpdate:  externalUpdateProxyFunc,
 			InProcessUpdate: proxyconfig.DefaultConfig.Set,
+			RunFunc:         runProxyCommandFunc,
 		})),
 
 		// The api address updater is a leaf worker that rewrites agent config
@@ -618,6 +725,59 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewWorker:     hostkeyreporter.NewWorker,
 		})),
 
+		interruptionWatcherName: ifNotMigrating(interruptionwatcher.Manifold(interruptionwatcher.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			Clock:         config.Clock,
+			Interval:      interruptionCheckInterval,
+			NewChecker:    interruptionwatcher.NewChecker,
+			NewFacade:     interruptionwatcher.NewFacade,
+			NewWorker:     interruptionwatcher.NewWorker,
+		})),
+
+		containerImageCacheName: ifNotMigrating(containerimagecache.Manifold(containerimagecache.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+			Interval:      config.ContainerImageCacheInterval,
+			NewFacade:     containerimagecache.NewFacade,
+			NewImageStore: containerimagecache.NewImageStore,
+			NewWorker:     containerimagecache.NewWorker,
+		})),
+
+		utilizationReporterName: ifNotMigrating(utilizationreporter.Manifold(utilizationreporter.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+			Interval:      config.UtilizationReportInterval,
+			NewFacade:     utilizationreporter.NewFacade,
+			NewSampler:    utilizationreporter.NewSampler,
+			NewWorker:     utilizationreporter.NewWorker,
+		})),
+
+		// The crash reporter uploads the crash reports collected
+		// when the agent dies, and records the engine report for
+		// inclusion in later ones.
+		crashReporterName: ifNotMigrating(crashreporter.Manifold(crashreporter.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			Clock:         config.Clock,
+			Interval:      config.CrashReportInterval,
+			EngineReport:  config.EngineReport,
+			NewFacade:     crashreporter.NewFacade,
+			NewWorker:     crashreporter.NewWorker,
+		})),
+
+		// The host firewaller restricts the machine's outbound
+		// traffic when the model's egress policy is restricted.
+		hostFirewallerName: ifNotMigrating(hostfirewaller.Manifold(hostfirewaller.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			ApplyRuleset:  hostfirewaller.ApplyRuleset,
+			NewFacade:     hostfirewaller.NewFacade,
+			NewWorker:     hostfirewaller.NewWorker,
+		})),
+
 		externalControllerUpdaterName: ifNotMigrating(ifPrimaryController(externalcontrollerupdater.Manifold(
 			externalcontrollerupdater.ManifoldConfig{
 				APICallerName:                      apiCallerName,
@@ -643,6 +803,15 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			},
 		))),
 
+		agentBinaryPrunerName: ifNotMigrating(ifPrimaryController(agentbinarypruner.Manifold(
+			agentbinarypruner.ManifoldConfig{
+				ClockName:     clockName,
+				StateName:     stateName,
+				PruneInterval: config.AgentBinaryPruneInterval,
+				NewWorker:     agentbinarypruner.New,
+			},
+		))),
+
 		apiServerName: apiserver.Manifold(apiserver.ManifoldConfig{
 			AgentName:                         agentName,
 			ClockName:                         clockName,
@@ -652,11 +821,14 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			CertWatcherName:                   certificateWatcherName,
 			PrometheusRegisterer:              config.PrometheusRegisterer,
 			RegisterIntrospectionHTTPHandlers: config.RegisterIntrospectionHTTPHandlers,
-			Hub:       config.CentralHub,
-			NewWorker: apiserver.NewWorker,
+			APIServerDrain:                    config.APIServerDrainLock,
+			DrainTimeout:                      apiServerDrainTimeout,
+			Hub:                               config.CentralHub,
+			NewWorker:                         apiserver.NewWorker,
 		}),
 
 		modelWorkerManagerName: ifFullyUpgraded(modelworkermanager.Manifold(modelworkermanager.ManifoldConfig{
+			AgentName:      agentName,
 			StateName:      stateName,
 			NewWorker:      modelworkermanager.New,
 			NewModelWorker: config.NewModelWorker,
@@ -669,6 +841,41 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			Hub:                      config.CentralHub,
 			NewWorker:                peergrouper.New,
 			ControllerSupportsSpaces: config.ControllerSupportsSpaces,
+			PromoteToHA:              config.PromoteToHA,
+			ResizeMongo:              config.ResizeMongo,
+		})),
+
+		// The controller-health worker periodically records the
+		// controller machine's disk space, API connection count and
+		// critical worker states, for the ControllerHealth facade.
+		controllerHealthName: ifFullyUpgraded(controllerhealth.Manifold(controllerhealth.ManifoldConfig{
+			AgentName: agentName,
+			ClockName: clockName,
+			StateName: stateName,
+			Interval:  config.ControllerHealthInterval,
+			CriticalWorkers: []string{
+				apiServerName,
+				certificateUpdaterName,
+				modelWorkerManagerName,
+				peergrouperName,
+				stateName,
+			},
+			EngineReport: config.EngineReport,
+			Gatherer:     config.PrometheusGatherer,
+			NewWorker:    controllerhealth.NewWorker,
+		})),
+
+		// The db-maintenance worker periodically records the sizes
+		// and index usage of the controller machine's database, for
+		// the Controller facade, and compacts it within the
+		// controller's database compaction windows.
+		dbMaintenanceName: ifFullyUpgraded(dbmaintenance.Manifold(dbmaintenance.ManifoldConfig{
+			AgentName:   agentName,
+			ClockName:   clockName,
+			StateName:   stateName,
+			Interval:    config.DBMaintenanceInterval,
+			NewDatabase: dbmaintenance.NewDatabase,
+			NewWorker:   dbmaintenance.NewWorker,
 		})),
 
 		restoreWatcherName: restorewatcher.Manifold(restorewatcher.ManifoldConfig{
@@ -682,6 +889,44 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewWorker:                certupdater.NewCertificateUpdater,
 			NewMachineAddressWatcher: certupdater.NewMachineAddressWatcher,
 		})),
+
+		// The API server configurer applies the controller
+		// application's config to this controller's API server.
+		apiServerConfigurerName: ifFullyUpgraded(apiserverconfigurer.Manifold(apiserverconfigurer.ManifoldConfig{
+			AgentName: agentName,
+			StateName: stateName,
+			NewWorker: apiserverconfigurer.NewWorker,
+		})),
+
+		// The certificate manager obtains and renews API
+		// certificates from an ACME CA, if configured.
+		certManagerName: ifFullyUpgraded(ifPrimaryController(certmanager.Manifold(certmanager.ManifoldConfig{
+			ClockName:  clockName,
+			StateName:  stateName,
+			RetryDelay: certManagerRetryDelay,
+			NewIssuer:  certmanager.NewACMEIssuer,
+			NewWorker:  certmanager.NewWorker,
+		}))),
+
+		// The model assigner spreads the hosted models across the
+		// available controllers; each controller's model worker
+		// manager runs only the workers of the models assigned to it.
+		modelAssignerName: ifFullyUpgraded(ifPrimaryController(modelassigner.Manifold(modelassigner.ManifoldConfig{
+			AgentName: agentName,
+			ClockName: clockName,
+			StateName: stateName,
+			Interval:  modelAssignerInterval,
+			NewWorker: modelassigner.NewWorker,
+		}))),
+
+		// The gRPC gateway serves a curated subset of the API to
+		// gRPC clients, if the controller's grpc-port is set.
+		grpcGatewayName: ifFullyUpgraded(grpcgateway.Manifold(grpcgateway.ManifoldConfig{
+			AgentName:       agentName,
+			CertWatcherName: certificateWatcherName,
+			StateName:       stateName,
+			NewWorker:       grpcgateway.NewWorker,
+		})),
 	}
 }
 
@@ -730,6 +975,7 @@ const (
 	apiConfigWatcherName   = "api-config-watcher"
 	centralHubName         = "central-hub"
 	pubSubName             = "pubsub-forwarder"
+	raftName               = "raft"
 	clockName              = "clock"
 
 	upgraderName         = "upgrader"
@@ -760,6 +1006,11 @@ const (
 	toolsVersionCheckerName       = "tools-version-checker"
 	machineActionName             = "machine-action-runner"
 	hostKeyReporterName           = "host-key-reporter"
+	interruptionWatcherName       = "interruption-watcher"
+	containerImageCacheName       = "container-image-cache"
+	utilizationReporterName       = "utilization-reporter"
+	crashReporterName             = "crash-reporter"
+	hostFirewallerName            = "host-firewaller"
 	fanConfigurerName             = "fan-configurer"
 	externalControllerUpdaterName = "external-controller-updater"
 	globalClockUpdaterName        = "global-clock-updater"
@@ -767,10 +1018,17 @@ const (
 	isControllerFlagName          = "is-controller-flag"
 	logPrunerName                 = "log-pruner"
 	txnPrunerName                 = "transaction-pruner"
+	agentBinaryPrunerName         = "agent-binary-pruner"
 	apiServerName                 = "api-server"
 	certificateWatcherName        = "certificate-watcher"
 	modelWorkerManagerName        = "model-worker-manager"
 	peergrouperName               = "peer-grouper"
 	restoreWatcherName            = "restore-watcher"
 	certificateUpdaterName        = "certificate-updater"
+	controllerHealthName          = "controller-health"
+	dbMaintenanceName             = "db-maintenance"
+	apiServerConfigurerName       = "api-server-configurer"
+	certManagerName               = "certificate-manager"
+	modelAssignerName             = "model-assigner"
+	grpcGatewayName               = "grpc-gateway"
 )
@@ -44,19 +44,29 @@ func (*ManifoldsSuite) TestManifoldNames(c *gc.C) {
 	sort.Strings(keys)
 	expectedKeys := []string{
 		"agent",
+		"agent-binary-pruner",
 		"api-address-updater",
 		"api-caller",
 		"api-config-watcher",
 		"api-server",
+		"api-server-configurer",
 		"central-hub",
+		"certificate-manager",
 		"certificate-updater",
 		"certificate-watcher",
 		"clock",
+		"container-image-cache",
+		"controller-health",
+		"crash-reporter",
+		"db-maintenance",
 		"disk-manager",
 		"external-controller-updater",
 		"fan-configurer",
 		"global-clock-updater",
+		"grpc-gateway",
+		"host-firewaller",
 		"host-key-reporter",
+		"interruption-watcher",
 		"is-controller-flag",
 		"is-primary-controller-flag",
 		"log-pruner",
@@ -68,10 +78,12 @@ func (*ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"migration-fortress",
 		"migration-minion",
 		"migration-inactive-flag",
+		"model-assigner",
 		"model-worker-manager",
 		"peer-grouper",
 		"proxy-config-updater",
 		"pubsub-forwarder",
+		"raft",
 		"reboot-executor",
 		"restore-watcher",
 		"serving-info-setter",
@@ -91,6 +103,7 @@ func (*ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"upgrade-steps-gate",
 		"upgrade-steps-runner",
 		"upgrader",
+		"utilization-reporter",
 	}
 	c.Assert(keys, jc.SameContents, expectedKeys)
 }
@@ -112,17 +125,24 @@ func (*ManifoldsSuite) TestMigrationGuardsUsed(c *gc.C) {
 		"api-caller",
 		"api-config-watcher",
 		"api-server",
+		"api-server-configurer",
+		"certificate-manager",
 		"certificate-updater",
 		"certificate-watcher",
 		"central-hub",
 		"clock",
+		"controller-health",
+		"db-maintenance",
 		"global-clock-updater",
+		"grpc-gateway",
 		"is-controller-flag",
 		"is-primary-controller-flag",
 		"log-forwarder",
+		"model-assigner",
 		"model-worker-manager",
 		"peer-grouper",
 		"pubsub-forwarder",
+		"raft",
 		"restore-watcher",
 		"state",
 		"state-config-watcher",
@@ -159,7 +179,7 @@ func (*ManifoldsSuite) TestSingularGuardsUsed(c *gc.C) {
 		case "certificate-watcher", "is-primary-controller-flag":
 			checkContains(c, manifold.Inputs, "is-controller-flag")
 			checkNotContains(c, manifold.Inputs, "is-primary-controller-flag")
-		case "external-controller-updater", "log-pruner", "transaction-pruner":
+		case "agent-binary-pruner", "certificate-manager", "external-controller-updater", "log-pruner", "model-assigner", "transaction-pruner":
 			checkNotContains(c, manifold.Inputs, "is-controller-flag")
 			checkContains(c, manifold.Inputs, "is-primary-controller-flag")
 		default:
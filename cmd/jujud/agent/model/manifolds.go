@@ -31,15 +31,18 @@ import (
 	"github.com/juju/juju/worker/caasmodelupgrader"
 	"github.com/juju/juju/worker/caasoperatorprovisioner"
 	"github.com/juju/juju/worker/caasunitprovisioner"
+	"github.com/juju/juju/worker/charmautorefresh"
 	"github.com/juju/juju/worker/charmrevision"
 	"github.com/juju/juju/worker/charmrevision/charmrevisionmanifold"
 	"github.com/juju/juju/worker/cleaner"
 	"github.com/juju/juju/worker/dependency"
+	"github.com/juju/juju/worker/dnsupdater"
 	"github.com/juju/juju/worker/environ"
 	"github.com/juju/juju/worker/firewaller"
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/gate"
 	"github.com/juju/juju/worker/instancepoller"
+	"github.com/juju/juju/worker/lbmanager"
 	"github.com/juju/juju/worker/lifeflag"
 	"github.com/juju/juju/worker/logforwarder"
 	"github.com/juju/juju/worker/logforwarder/sinks"
@@ -47,7 +50,9 @@ import (
 	"github.com/juju/juju/worker/metricworker"
 	"github.com/juju/juju/worker/migrationflag"
 	"github.com/juju/juju/worker/migrationmaster"
+	"github.com/juju/juju/worker/modelsnapshotter"
 	"github.com/juju/juju/worker/modelupgrader"
+	"github.com/juju/juju/worker/orphansweeper"
 	"github.com/juju/juju/worker/provisioner"
 	"github.com/juju/juju/worker/pruner"
 	"github.com/juju/juju/worker/remoterelations"
@@ -93,6 +98,11 @@ type ManifoldsConfig struct {
 	// revision worker will check for new revisions of known charms.
 	CharmRevisionUpdateInterval time.Duration
 
+	// CharmAutoRefreshInterval determines how often the charm
+	// auto-refresh worker will apply pending charm upgrades, if the
+	// model is within a maintenance window.
+	CharmAutoRefreshInterval time.Duration
+
 	// StatusHistoryPruner* values control status-history pruning
 	// behaviour.
 	StatusHistoryPrunerInterval time.Duration
@@ -101,6 +111,29 @@ type ManifoldsConfig struct {
 	// worker is run.
 	ActionPrunerInterval time.Duration
 
+	// OrphanSweeperInterval controls how often the model's provider
+	// resources are checked for those juju no longer tracks.
+	OrphanSweeperInterval time.Duration
+
+	// DNSUpdaterInterval controls how often the model's machines are
+	// checked for address changes that need registering in the
+	// provider's DNS.
+	DNSUpdaterInterval time.Duration
+
+	// LBManagerInterval controls how often the model's exposed
+	// applications are checked for changes that need applying to
+	// their provider load balancers.
+	LBManagerInterval time.Duration
+
+	// ModelSnapshotInterval controls how often the model's
+	// description is recorded, if it has changed.
+	ModelSnapshotInterval time.Duration
+
+	// StorageProvisionerConcurrency is the maximum number of batches
+	// of volume operations the model's storage provisioner may have
+	// in progress with the storage provider at once.
+	StorageProvisionerConcurrency int
+
 	// NewEnvironFunc is a function opens a provider "environment"
 	// (typically environs.New).
 	NewEnvironFunc environs.NewEnvironFunc
@@ -240,6 +273,13 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFacade: charmrevisionmanifold.NewAPIFacade,
 			NewWorker: charmrevision.NewWorker,
 		})),
+		charmAutoRefresherName: ifNotMigrating(charmautorefresh.Manifold(charmautorefresh.ManifoldConfig{
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+			Interval:      config.CharmAutoRefreshInterval,
+			NewFacade:     charmautorefresh.NewFacade,
+			NewWorker:     charmautorefresh.NewWorker,
+		})),
 		remoteRelationsName: ifNotMigrating(remoterelations.Manifold(remoterelations.ManifoldConfig{
 			AgentName:                agentName,
 			APICallerName:            apiCallerName,
@@ -339,6 +379,8 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			ClockName:     clockName,
 			EnvironName:   environTrackerName,
 			Scope:         modelTag,
+
+			MaxConcurrentOperations: config.StorageProvisionerConcurrency,
 		})),
 		firewallerName: ifNotMigrating(firewaller.Manifold(firewaller.ManifoldConfig{
 			AgentName:               agentName,
@@ -372,6 +414,37 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			EnvironName:   environTrackerName,
 			NewWorker:     machineundertaker.NewWorker,
 		})),
+		orphanSweeperName: ifNotMigrating(orphansweeper.Manifold(orphansweeper.ManifoldConfig{
+			APICallerName: apiCallerName,
+			EnvironName:   environTrackerName,
+			ClockName:     clockName,
+			Interval:      config.OrphanSweeperInterval,
+			NewFacade:     orphansweeper.NewFacade,
+			NewWorker:     orphansweeper.NewWorker,
+		})),
+		dnsUpdaterName: ifNotMigrating(dnsupdater.Manifold(dnsupdater.ManifoldConfig{
+			APICallerName: apiCallerName,
+			EnvironName:   environTrackerName,
+			ClockName:     clockName,
+			Interval:      config.DNSUpdaterInterval,
+			NewFacade:     dnsupdater.NewFacade,
+			NewWorker:     dnsupdater.NewWorker,
+		})),
+		lbManagerName: ifNotMigrating(lbmanager.Manifold(lbmanager.ManifoldConfig{
+			APICallerName: apiCallerName,
+			EnvironName:   environTrackerName,
+			ClockName:     clockName,
+			Interval:      config.LBManagerInterval,
+			NewFacade:     lbmanager.NewFacade,
+			NewWorker:     lbmanager.NewWorker,
+		})),
+		modelSnapshotterName: ifNotMigrating(modelsnapshotter.Manifold(modelsnapshotter.ManifoldConfig{
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+			Interval:      config.ModelSnapshotInterval,
+			NewFacade:     modelsnapshotter.NewFacade,
+			NewWorker:     modelsnapshotter.NewWorker,
+		})),
 		modelUpgraderName: modelupgrader.Manifold(modelupgrader.ManifoldConfig{
 			APICallerName: apiCallerName,
 			EnvironName:   environTrackerName,
@@ -538,11 +611,16 @@ const (
 	applicationScalerName    = "application-scaler"
 	instancePollerName       = "instance-poller"
 	charmRevisionUpdaterName = "charm-revision-updater"
+	charmAutoRefresherName   = "charm-auto-refresher"
 	metricWorkerName         = "metric-worker"
 	stateCleanerName         = "state-cleaner"
 	statusHistoryPrunerName  = "status-history-pruner"
 	actionPrunerName         = "action-pruner"
 	machineUndertakerName    = "machine-undertaker"
+	orphanSweeperName        = "orphan-sweeper"
+	dnsUpdaterName           = "dns-updater"
+	lbManagerName            = "lb-manager"
+	modelSnapshotterName     = "model-snapshotter"
 	remoteRelationsName      = "remote-relations"
 	logForwarderName         = "log-forwarder"
 
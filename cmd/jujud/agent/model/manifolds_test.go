@@ -36,24 +36,29 @@ func (s *ManifoldsSuite) TestIAASNames(c *gc.C) {
 		"api-caller",
 		"api-config-watcher",
 		"application-scaler",
+		"charm-auto-refresher",
 		"charm-revision-updater",
 		"clock",
 		"compute-provisioner",
+		"dns-updater",
 		"environ-tracker",
 		"firewaller",
 		"instance-poller",
 		"is-responsible-flag",
+		"lb-manager",
 		"log-forwarder",
 		"machine-undertaker",
 		"metric-worker",
 		"migration-fortress",
 		"migration-inactive-flag",
 		"migration-master",
+		"model-snapshotter",
 		"model-upgrade-gate",
 		"model-upgraded-flag",
 		"model-upgrader",
 		"not-alive-flag",
 		"not-dead-flag",
+		"orphan-sweeper",
 		"remote-relations",
 		"state-cleaner",
 		"status-history-pruner",
@@ -82,6 +87,7 @@ func (s *ManifoldsSuite) TestCAASNames(c *gc.C) {
 		"caas-firewaller",
 		"caas-operator-provisioner",
 		"caas-unit-provisioner",
+		"charm-auto-refresher",
 		"charm-revision-updater",
 		"clock",
 		"is-responsible-flag",
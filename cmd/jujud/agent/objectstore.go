@@ -0,0 +1,40 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/environs/objectstore"
+	"github.com/juju/juju/state"
+	statestorage "github.com/juju/juju/state/storage"
+)
+
+// ConfigureObjectStore sets the object store in which the controller's
+// blobs are held, as configured in the controller config.
+func ConfigureObjectStore(cfg controller.Config) error {
+	storeCfg := cfg.ObjectStore()
+	if storeCfg.Type == controller.ObjectStoreGridFS {
+		statestorage.SetObjectStore(nil)
+		return nil
+	}
+	store, err := objectstore.Open(storeCfg)
+	if err != nil {
+		return errors.Annotate(err, "opening object store")
+	}
+	statestorage.SetObjectStore(store)
+	logger.Infof("storing blobs in %s object store %q", storeCfg.Type, storeCfg.Bucket)
+	return nil
+}
+
+// configureObjectStoreFromState calls ConfigureObjectStore with the
+// controller config held in state.
+func configureObjectStoreFromState(st *state.State) error {
+	cfg, err := st.ControllerConfig()
+	if err != nil {
+		return errors.Annotate(err, "reading controller config")
+	}
+	return ConfigureObjectStore(cfg)
+}
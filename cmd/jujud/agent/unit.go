@@ -147,6 +147,7 @@ func (a *UnitAgent) Run(ctx *cmd.Context) error {
 		return err
 	}
 	setupAgentLogging(a.CurrentConfig())
+	defer startCrashCollection(a.CurrentConfig()).Recover()
 
 	a.runner.StartWorker("api", a.APIWorkers)
 	err := cmdutil.AgentDone(logger, a.runner.Wait())
@@ -166,6 +167,16 @@ func (a *UnitAgent) APIWorkers() (worker.Worker, error) {
 	agentConfig := a.AgentConf.CurrentConfig()
 	a.upgradeComplete = upgradesteps.NewLock(agentConfig)
 
+	config := dependency.EngineConfig{
+		IsFatal:     cmdutil.IsFatal,
+		WorstError:  cmdutil.MoreImportantError,
+		ErrorDelay:  3 * time.Second,
+		BounceDelay: 10 * time.Millisecond,
+	}
+	engine, err := dependency.NewEngine(config)
+	if err != nil {
+		return nil, err
+	}
 	manifolds := unitManifolds(unit.ManifoldsConfig{
 		Agent:                agent.APIHostPortsSetter{a},
 		LogSource:            a.bufferedLogger.Logs(),
@@ -178,18 +189,12 @@ func (a *UnitAgent) APIWorkers() (worker.Worker, error) {
 		PreUpgradeSteps:      a.preUpgradeSteps,
 		UpgradeStepsLock:     a.upgradeComplete,
 		UpgradeCheckLock:     a.initialUpgradeCheckComplete,
+		EngineReport:         engine.Report,
+		CrashReportInterval:  5 * time.Minute,
+
+		RelationCoalescePeriod: 2 * time.Second,
 	})
 
-	config := dependency.EngineConfig{
-		IsFatal:     cmdutil.IsFatal,
-		WorstError:  cmdutil.MoreImportantError,
-		ErrorDelay:  3 * time.Second,
-		BounceDelay: 10 * time.Millisecond,
-	}
-	engine, err := dependency.NewEngine(config)
-	if err != nil {
-		return nil, err
-	}
 	if err := dependency.Install(engine, manifolds); err != nil {
 		if err := worker.Stop(engine); err != nil {
 			logger.Errorf("while stopping engine with bad manifolds: %v", err)
@@ -25,6 +25,7 @@ import (
 	"github.com/juju/juju/worker/apiaddressupdater"
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/apiconfigwatcher"
+	"github.com/juju/juju/worker/crashreporter"
 	"github.com/juju/juju/worker/dependency"
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/gate"
@@ -92,6 +93,21 @@ type ManifoldsConfig struct {
 	// worker to ensure that conditions are OK for an upgrade to
 	// proceed.
 	PreUpgradeSteps func(*state.State, coreagent.Config, bool, bool) error
+
+	// EngineReport returns the report of the dependency engine
+	// running the manifolds, which is recorded for inclusion in
+	// crash reports.
+	EngineReport func() map[string]interface{}
+
+	// CrashReportInterval defines how frequently the unit agent
+	// records its engine report for inclusion in crash reports, and
+	// retries uploading crash reports to the controller.
+	CrashReportInterval time.Duration
+
+	// RelationCoalescePeriod is the minimum time between the uniter's
+	// deliveries of changes to the units of each relation, so that
+	// busy relations run a bounded number of relation-changed hooks.
+	RelationCoalescePeriod time.Duration
 }
 
 // Manifolds returns a set of co-configured manifolds covering the various
@@ -240,6 +256,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			AgentName:       agentName,
 			APICallerName:   apiCallerName,
 			UpdateAgentFunc: config.UpdateLoggerConfig,
+			Clock:           clock.WallClock,
 		})),
 
 		// The api address updater is a leaf worker that rewrites agent config
@@ -263,6 +280,19 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			InProcessUpdate: proxy.DefaultConfig.Set,
 		})),
 
+		// The crash reporter uploads the crash reports collected
+		// when the agent dies, and records the engine report for
+		// inclusion in later ones.
+		crashReporterName: ifNotMigrating(crashreporter.Manifold(crashreporter.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			Clock:         clock.WallClock,
+			Interval:      config.CrashReportInterval,
+			EngineReport:  config.EngineReport,
+			NewFacade:     crashreporter.NewFacade,
+			NewWorker:     crashreporter.NewWorker,
+		})),
+
 		// The charmdir resource coordinates whether the charm directory is
 		// available or not; after 'start' hook and before 'stop' hook
 		// executes, and not during upgrades.
@@ -302,6 +332,9 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			CharmDirName:          charmDirName,
 			HookRetryStrategyName: hookRetryStrategyName,
 			TranslateResolverErr:  uniter.TranslateFortressErrors,
+
+			RelationCoalescePeriod: config.RelationCoalescePeriod,
+			PrometheusRegisterer:   config.PrometheusRegisterer,
 		})),
 
 		// TODO (mattyw) should be added to machine agent.
@@ -373,6 +406,7 @@ const (
 	loggingConfigUpdaterName = "logging-config-updater"
 	proxyConfigUpdaterName   = "proxy-config-updater"
 	apiAddressUpdaterName    = "api-address-updater"
+	crashReporterName        = "crash-reporter"
 
 	charmDirName          = "charm-dir"
 	leadershipTrackerName = "leadership-tracker"
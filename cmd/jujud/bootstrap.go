@@ -244,6 +244,12 @@ func (c *BootstrapCommand) Run(_ *cmd.Context) error {
 	}
 	args.ControllerModelConfig = controllerModelCfg
 
+	// Blobs, starting with the tools below, are stored in the
+	// configured object store from the outset.
+	if err := agentcmd.ConfigureObjectStore(args.ControllerConfig); err != nil {
+		return errors.Trace(err)
+	}
+
 	// Initialise state, and store any agent config (e.g. password) changes.
 	var st *state.State
 	var m *state.Machine
@@ -0,0 +1,180 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/arch"
+	"github.com/juju/utils/series"
+	"github.com/juju/utils/shell"
+	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/agent"
+	agenttools "github.com/juju/juju/agent/tools"
+	"github.com/juju/juju/cloudconfig/agentmetadata"
+	"github.com/juju/juju/service"
+	coretools "github.com/juju/juju/tools"
+	jujuversion "github.com/juju/juju/version"
+)
+
+const enlistDoc = `
+Enlists this machine with its Juju controller using the agent metadata
+passed to it as user data, and starts the machine agent.
+
+The command is intended to be run at boot by images with the Juju
+agent preinstalled, for use in models with agent-preinstalled set to
+true. Such machines are passed only the metadata the agent needs to
+connect to the controller, instead of cloud-init configuration that
+downloads and installs the agent.
+
+The metadata is read from the provider's metadata service at
+--metadata-url, or from the file named by --metadata-file. If the
+machine agent is already configured, the command does nothing.
+`
+
+// defaultMetadataURL is the address at which the EC2 and OpenStack
+// metadata services serve an instance's user data.
+const defaultMetadataURL = "http://169.254.169.254/latest/user-data"
+
+// metadataTimeout is how long to wait for the metadata service.
+const metadataTimeout = 30 * time.Second
+
+// jujudPath returns the path of the running jujud binary, which is
+// installed as the agent's tools. It is a variable so that it can be
+// replaced in tests.
+var jujudPath = os.Executable
+
+// installMachineAgent installs and starts the service that runs the
+// machine agent. It is a variable so that it can be replaced in tests.
+var installMachineAgent = func(md *agentmetadata.Metadata) error {
+	renderer, err := shell.NewRenderer("")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	info := service.NewMachineAgentInfo(md.MachineId, md.DataDir, md.LogDir)
+	svc, err := service.NewService(md.ServiceName, service.AgentConf(info, renderer), md.Series)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(service.InstallAndStart(svc))
+}
+
+type enlistCommand struct {
+	cmd.CommandBase
+	metadataURL  string
+	metadataFile string
+}
+
+// NewEnlistCommand returns a command that configures and starts a
+// preinstalled machine agent from the agent metadata passed to its
+// machine.
+func NewEnlistCommand() cmd.Command {
+	return &enlistCommand{}
+}
+
+// Info is part of the cmd.Command interface.
+func (c *enlistCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "enlist",
+		Purpose: "enlist this machine using a preinstalled agent",
+		Doc:     enlistDoc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *enlistCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.metadataURL, "metadata-url", defaultMetadataURL, "URL of the user data holding the agent metadata")
+	f.StringVar(&c.metadataFile, "metadata-file", "", "path to a file holding the agent metadata")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *enlistCommand) Init(args []string) error {
+	if c.metadataURL == "" && c.metadataFile == "" {
+		return errors.New("either --metadata-url or --metadata-file must be specified")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *enlistCommand) Run(ctx *cmd.Context) error {
+	data, err := c.readMetadata(ctx)
+	if err != nil {
+		return errors.Annotate(err, "cannot read agent metadata")
+	}
+	md, err := agentmetadata.Parse(data)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tag := names.NewMachineTag(md.MachineId)
+	if _, err := os.Stat(agent.ConfigPath(md.DataDir, tag)); err == nil {
+		ctx.Infof("machine %s is already enlisted", md.MachineId)
+		return nil
+	}
+
+	for _, dir := range []string{path.Join(md.DataDir, "locks"), md.LogDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	// Install the running jujud as the agent's tools; the upgrader
+	// brings it up to the model's agent version once it has started.
+	hostSeries, err := series.HostSeries()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	current := version.Binary{
+		Number: jujuversion.Current,
+		Arch:   arch.HostArch(),
+		Series: hostSeries,
+	}
+	binary, err := jujudPath()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := agenttools.InstallTools(md.DataDir, &coretools.Tools{Version: current}, binary); err != nil {
+		return errors.Annotate(err, "cannot install agent binary")
+	}
+	if _, err := agenttools.ChangeAgentTools(md.DataDir, tag.String(), current); err != nil {
+		return errors.Trace(err)
+	}
+
+	conf, err := md.AgentConfig(jujuversion.Current)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := conf.Write(); err != nil {
+		return errors.Annotate(err, "cannot write agent configuration")
+	}
+	if err := installMachineAgent(md); err != nil {
+		return errors.Annotate(err, "cannot start machine agent")
+	}
+	ctx.Infof("enlisted machine %s", md.MachineId)
+	return nil
+}
+
+func (c *enlistCommand) readMetadata(ctx *cmd.Context) ([]byte, error) {
+	if c.metadataFile != "" {
+		return ioutil.ReadFile(ctx.AbsPath(c.metadataFile))
+	}
+	client := &http.Client{Timeout: metadataTimeout}
+	resp, err := client.Get(c.metadataURL)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("fetching %s: %s", c.metadataURL, resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
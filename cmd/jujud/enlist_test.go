@@ -0,0 +1,140 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	"github.com/juju/utils/arch"
+	"github.com/juju/utils/series"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/agent"
+	agenttools "github.com/juju/juju/agent/tools"
+	"github.com/juju/juju/cloudconfig/agentmetadata"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/testing"
+	jujuversion "github.com/juju/juju/version"
+)
+
+type EnlistSuite struct {
+	testing.BaseSuite
+	dataDir   string
+	installed []*agentmetadata.Metadata
+}
+
+var _ = gc.Suite(&EnlistSuite{})
+
+func (s *EnlistSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.dataDir = c.MkDir()
+	s.installed = nil
+
+	binary := filepath.Join(c.MkDir(), "jujud")
+	err := ioutil.WriteFile(binary, []byte("jujud contents"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(&jujudPath, func() (string, error) { return binary, nil })
+	s.PatchValue(&installMachineAgent, func(md *agentmetadata.Metadata) error {
+		s.installed = append(s.installed, md)
+		return nil
+	})
+}
+
+func (s *EnlistSuite) metadata(c *gc.C) []byte {
+	md := &agentmetadata.Metadata{
+		MachineId:    "10",
+		Nonce:        "FAKE_NONCE",
+		Controller:   testing.ControllerTag.Id(),
+		Model:        testing.ModelTag.Id(),
+		APIAddresses: []string{"10.0.0.1:17070"},
+		CACert:       testing.CACert,
+		Password:     "bletch",
+		Series:       "xenial",
+		Jobs:         []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+		DataDir:      s.dataDir,
+		LogDir:       filepath.Join(s.dataDir, "log"),
+		ServiceName:  "jujud-machine-10",
+	}
+	data, err := md.Marshal()
+	c.Assert(err, jc.ErrorIsNil)
+	return data
+}
+
+func (s *EnlistSuite) TestInit(c *gc.C) {
+	err := cmdtesting.InitCommand(NewEnlistCommand(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = cmdtesting.InitCommand(NewEnlistCommand(), []string{"--metadata-url", ""})
+	c.Assert(err, gc.ErrorMatches, "either --metadata-url or --metadata-file must be specified")
+	err = cmdtesting.InitCommand(NewEnlistCommand(), []string{"extra"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *EnlistSuite) assertEnlisted(c *gc.C) {
+	c.Assert(s.installed, gc.HasLen, 1)
+	c.Assert(s.installed[0].MachineId, gc.Equals, "10")
+
+	tag := names.NewMachineTag("10")
+	conf, err := agent.ReadConfig(agent.ConfigPath(s.dataDir, tag))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(conf.Tag(), gc.Equals, tag)
+	c.Check(conf.Nonce(), gc.Equals, "FAKE_NONCE")
+	c.Check(conf.Value(agent.AgentServiceName), gc.Equals, "jujud-machine-10")
+	c.Check(conf.UpgradedToVersion(), gc.Equals, jujuversion.Current)
+
+	current := version.Binary{
+		Number: jujuversion.Current,
+		Arch:   arch.HostArch(),
+		Series: series.MustHostSeries(),
+	}
+	_, err = agenttools.ReadTools(s.dataDir, current)
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadFile(filepath.Join(agenttools.ToolsDir(s.dataDir, tag.String()), "jujud"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "jujud contents")
+}
+
+func (s *EnlistSuite) TestRunMetadataFile(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "user-data")
+	err := ioutil.WriteFile(path, s.metadata(c), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = cmdtesting.RunCommand(c, NewEnlistCommand(), "--metadata-file", path)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEnlisted(c)
+
+	// Enlisting again does nothing.
+	_, err = cmdtesting.RunCommand(c, NewEnlistCommand(), "--metadata-file", path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.installed, gc.HasLen, 1)
+}
+
+func (s *EnlistSuite) TestRunMetadataURL(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(utils.Gzip(s.metadata(c)))
+	}))
+	defer server.Close()
+
+	_, err := cmdtesting.RunCommand(c, NewEnlistCommand(), "--metadata-url", server.URL)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEnlisted(c)
+}
+
+func (s *EnlistSuite) TestRunNotMetadata(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "user-data")
+	err := ioutil.WriteFile(path, []byte("#cloud-config\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = cmdtesting.RunCommand(c, NewEnlistCommand(), "--metadata-file", path)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(s.installed, gc.HasLen, 0)
+}
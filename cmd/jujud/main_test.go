@@ -25,6 +25,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/juju/names"
 	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 )
 
@@ -199,7 +200,7 @@ func (s *HookToolMainSuite) SetUpSuite(c *gc.C) {
 		return &RemoteCommand{}, nil
 	}
 	s.sockPath = osDependentSockPath(c)
-	srv, err := jujuc.NewServer(factory, s.sockPath)
+	srv, err := jujuc.NewServer(factory, s.sockPath, charmrunner.OutputLimits{})
 	c.Assert(err, jc.ErrorIsNil)
 	s.server = srv
 	go func() {
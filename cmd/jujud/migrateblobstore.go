@@ -0,0 +1,222 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"io/ioutil"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/agent"
+	agentcmd "github.com/juju/juju/cmd/jujud/agent"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/environs/objectstore"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state"
+	statestorage "github.com/juju/juju/state/storage"
+)
+
+const migrateBlobstoreDoc = `
+Copies the blobs held in the controller's database (charms, agent
+binaries, resources and backups) to an external object store, checking
+each copy against the original.
+
+The object store is the one configured for the controller, unless
+--config names a YAML file of object store settings, for example:
+
+    object-store-type: s3
+    object-store-endpoint: http://10.0.0.1:9000
+    object-store-bucket: juju
+    object-store-access-key: <access key>
+    object-store-secret-key: <secret key>
+
+in which case the controller is configured to use it once the blobs
+have been copied. Restart the controller agents to start using the
+object store; until then, new blobs are still stored in the database,
+so run the command again afterwards, with --remove-source, to copy
+them and reclaim the space used in the database.
+
+With --verify, the blobs in the object store are checked against the
+hashes recorded when they were stored, and nothing is copied.
+
+The command must be run on a controller machine.
+`
+
+type migrateBlobstoreCommand struct {
+	cmd.CommandBase
+	agentConfig  agentcmd.AgentConf
+	machineId    string
+	configFile   string
+	removeSource bool
+	verify       bool
+}
+
+// NewMigrateBlobstoreCommand returns a command that copies the
+// controller's blobs from GridFS to an external object store.
+func NewMigrateBlobstoreCommand() cmd.Command {
+	return &migrateBlobstoreCommand{
+		agentConfig: agentcmd.NewAgentConf(""),
+	}
+}
+
+// Info is part of the cmd.Command interface.
+func (c *migrateBlobstoreCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "migrate-blobstore",
+		Purpose: "copy the controller's blobs to an external object store",
+		Doc:     migrateBlobstoreDoc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *migrateBlobstoreCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.agentConfig.AddFlags(f)
+	f.StringVar(&c.machineId, "machine-id", "0", "id of the controller machine on this host")
+	f.StringVar(&c.configFile, "config", "", "path to a YAML file of object store settings")
+	f.BoolVar(&c.removeSource, "remove-source", false, "remove blobs from the database once copied")
+	f.BoolVar(&c.verify, "verify", false, "verify the blobs in the object store instead of copying")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *migrateBlobstoreCommand) Init(args []string) error {
+	if err := c.agentConfig.CheckArgs(args); err != nil {
+		return errors.Trace(err)
+	}
+	if !names.IsValidMachine(c.machineId) {
+		return errors.NotValidf("machine id %q", c.machineId)
+	}
+	if c.verify && (c.configFile != "" || c.removeSource) {
+		return errors.New("--verify cannot be used with --config or --remove-source")
+	}
+	return nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *migrateBlobstoreCommand) Run(ctx *cmd.Context) error {
+	if err := c.agentConfig.ReadConfig(names.NewMachineTag(c.machineId).String()); err != nil {
+		return errors.Trace(err)
+	}
+	st, err := openControllerState(c.agentConfig.CurrentConfig())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer st.Close()
+
+	var storeCfg controller.ObjectStoreConfig
+	if c.configFile != "" {
+		storeCfg, err = readObjectStoreConfig(ctx.AbsPath(c.configFile))
+	} else {
+		var cfg controller.Config
+		cfg, err = st.ControllerConfig()
+		storeCfg = cfg.ObjectStore()
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if storeCfg.Type == controller.ObjectStoreGridFS {
+		return errors.New("no object store is configured for the controller; use --config to specify one")
+	}
+	store, err := objectstore.Open(storeCfg)
+	if err != nil {
+		return errors.Annotate(err, "opening object store")
+	}
+
+	session := st.MongoSession()
+	if c.verify {
+		return verifyBlobstores(ctx, session, store)
+	}
+	for _, dbName := range statestorage.BlobstoreDatabases {
+		result, err := statestorage.MigrateGridFS(session, dbName, store, c.removeSource)
+		if err != nil {
+			return errors.Annotatef(err, "migrating %s", dbName)
+		}
+		ctx.Infof("%s: copied %d blobs, %d already copied", dbName, result.Migrated, result.Skipped)
+	}
+	if c.configFile != "" {
+		if err := st.SetObjectStoreConfig(storeCfg); err != nil {
+			return errors.Annotate(err, "configuring controller")
+		}
+		ctx.Infof("controller configured to use the %s object store; restart the controller agents to use it", storeCfg.Type)
+	}
+	return nil
+}
+
+// verifyBlobstores checks the blobs of every blobstore held in the
+// object store, and returns an error if any are missing or corrupt.
+func verifyBlobstores(ctx *cmd.Context, session *mgo.Session, store statestorage.ObjectStore) error {
+	failed := false
+	for _, dbName := range statestorage.BlobstoreDatabases {
+		result, err := statestorage.VerifyObjectStore(session, dbName, store)
+		if err != nil {
+			return errors.Annotatef(err, "verifying %s", dbName)
+		}
+		ctx.Infof("%s: verified %d blobs", dbName, result.Verified)
+		for _, path := range result.Missing {
+			ctx.Infof("%s: missing %s", dbName, path)
+		}
+		for _, path := range result.Corrupt {
+			ctx.Infof("%s: corrupt %s", dbName, path)
+		}
+		failed = failed || len(result.Missing) > 0 || len(result.Corrupt) > 0
+	}
+	if failed {
+		return errors.New("object store verification failed")
+	}
+	return nil
+}
+
+// readObjectStoreConfig reads object store settings from the YAML file
+// at path.
+func readObjectStoreConfig(path string) (controller.ObjectStoreConfig, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return controller.ObjectStoreConfig{}, errors.Trace(err)
+	}
+	var attrs map[string]interface{}
+	if err := yaml.Unmarshal(data, &attrs); err != nil {
+		return controller.ObjectStoreConfig{}, errors.Annotatef(err, "parsing %s", path)
+	}
+	allowed := set.NewStrings(controller.ObjectStoreConfigAttributes...)
+	for key := range attrs {
+		if !allowed.Contains(key) {
+			return controller.ObjectStoreConfig{}, errors.Errorf("unexpected setting %q in %s", key, path)
+		}
+	}
+	cfg := controller.Config(attrs).ObjectStore()
+	if err := cfg.Validate(); err != nil {
+		return controller.ObjectStoreConfig{}, errors.Trace(err)
+	}
+	return cfg, nil
+}
+
+// openControllerState opens the controller's state using the agent
+// configuration.
+func openControllerState(config agent.Config) (*state.State, error) {
+	info, ok := config.MongoInfo()
+	if !ok {
+		return nil, errors.New("no database connection info available (is this a controller host?)")
+	}
+	session, err := mongo.DialWithInfo(*info, mongo.DefaultDialOpts())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer session.Close()
+	st, err := state.Open(state.OpenParams{
+		Clock:              clock.WallClock,
+		ControllerTag:      config.Controller(),
+		ControllerModelTag: config.Model(),
+		MongoSession:       session,
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot connect to database")
+	}
+	return st, nil
+}
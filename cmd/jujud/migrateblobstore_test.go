@@ -0,0 +1,92 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/testing"
+)
+
+type MigrateBlobstoreSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&MigrateBlobstoreSuite{})
+
+func (s *MigrateBlobstoreSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args        []string
+		expectError string
+	}{{
+		args: nil,
+	}, {
+		args: []string{"--config", "store.yaml", "--remove-source"},
+	}, {
+		args:        []string{"--machine-id", "foo"},
+		expectError: `machine id "foo" not valid`,
+	}, {
+		args:        []string{"--verify", "--remove-source"},
+		expectError: "--verify cannot be used with --config or --remove-source",
+	}, {
+		args:        []string{"extra"},
+		expectError: `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := cmdtesting.InitCommand(NewMigrateBlobstoreCommand(), test.args)
+		if test.expectError == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.expectError)
+		}
+	}
+}
+
+func (s *MigrateBlobstoreSuite) writeConfig(c *gc.C, content string) string {
+	path := filepath.Join(c.MkDir(), "store.yaml")
+	err := ioutil.WriteFile(path, []byte(content), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+func (s *MigrateBlobstoreSuite) TestReadObjectStoreConfig(c *gc.C) {
+	path := s.writeConfig(c, `
+object-store-type: swift
+object-store-endpoint: https://keystone.example.com:5000/v2.0
+object-store-region: RegionOne
+object-store-bucket: juju
+object-store-access-key: user
+object-store-secret-key: password
+object-store-tenant: admin
+`)
+	cfg, err := readObjectStoreConfig(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg, jc.DeepEquals, controller.ObjectStoreConfig{
+		Type:      controller.ObjectStoreSwift,
+		Endpoint:  "https://keystone.example.com:5000/v2.0",
+		Region:    "RegionOne",
+		Bucket:    "juju",
+		AccessKey: "user",
+		SecretKey: "password",
+		Tenant:    "admin",
+	})
+}
+
+func (s *MigrateBlobstoreSuite) TestReadObjectStoreConfigUnexpectedSetting(c *gc.C) {
+	path := s.writeConfig(c, "object-store-type: s3\napi-port: 17070\n")
+	_, err := readObjectStoreConfig(path)
+	c.Assert(err, gc.ErrorMatches, `unexpected setting "api-port" in .*`)
+}
+
+func (s *MigrateBlobstoreSuite) TestReadObjectStoreConfigInvalid(c *gc.C) {
+	path := s.writeConfig(c, "object-store-type: s3\nobject-store-region: eu-west-1\n")
+	_, err := readObjectStoreConfig(path)
+	c.Assert(err, gc.ErrorMatches, "object-store-bucket must be set")
+}
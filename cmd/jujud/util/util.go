@@ -197,6 +197,16 @@ func NewEnsureServerParams(agentConfig agent.Config) (mongo.EnsureServerParams,
 		}
 	}
 
+	// Likewise for the size of the WiredTiger cache, which is
+	// otherwise sized according to the memory profile.
+	var cacheSize int
+	if cacheSizeString := agentConfig.Value(agent.MongoCacheSize); cacheSizeString != "" {
+		var err error
+		if cacheSize, err = strconv.Atoi(cacheSizeString); err != nil {
+			return mongo.EnsureServerParams{}, fmt.Errorf("invalid cache size: %q", cacheSizeString)
+		}
+	}
+
 	// If numa ctl preference is specified in the agent configuration, use that.
 	// Otherwise leave the default false value to indicate to EnsureServer
 	// that numactl should not be used.
@@ -208,6 +218,16 @@ func NewEnsureServerParams(agentConfig agent.Config) (mongo.EnsureServerParams,
 		}
 	}
 
+	// If the controller was bootstrapped as a single node, and has not
+	// yet been promoted to HA, mongo runs with lighter-weight settings.
+	var singleNode bool
+	if singleNodeString := agentConfig.Value(agent.MongoSingleNode); singleNodeString != "" {
+		var err error
+		if singleNode, err = strconv.ParseBool(singleNodeString); err != nil {
+			return mongo.EnsureServerParams{}, fmt.Errorf("invalid single node setting: %q", singleNodeString)
+		}
+	}
+
 	si, ok := agentConfig.StateServingInfo()
 	if !ok {
 		return mongo.EnsureServerParams{}, fmt.Errorf("agent config has no state serving info")
@@ -224,9 +244,11 @@ func NewEnsureServerParams(agentConfig agent.Config) (mongo.EnsureServerParams,
 
 		DataDir:              agentConfig.DataDir(),
 		OplogSize:            oplogSize,
+		CacheSizeMB:          cacheSize,
 		SetNUMAControlPolicy: numaCtlPolicy,
 
 		MemoryProfile: agentConfig.MongoMemoryProfile(),
+		SingleNode:    singleNode,
 	}
 	return params, nil
 }
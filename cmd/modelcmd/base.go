@@ -149,13 +149,29 @@ func (c *CommandBase) NewAPIRoot(
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	conn, err := juju.NewAPIConnection(param)
+	conn, err := c.newAPIConnection(param)
 	if modelName != "" && params.ErrCode(err) == params.CodeModelNotFound {
 		return nil, c.missingModelError(store, controllerName, modelName)
 	}
 	return conn, err
 }
 
+// newSharedAPIConnection is called by CommandBase.newAPIConnection; it
+// is a variable so it can be replaced in tests.
+var newSharedAPIConnection = juju.SharedAPIConnection
+
+// newAPIConnection returns a connection made with the given parameters.
+// The connection is shared with other commands run in the same process
+// that connect to the same controller and model as the same user,
+// unless an API open function was set with SetAPIOpen; connections
+// made by such functions can't be told apart, so are never shared.
+func (c *CommandBase) newAPIConnection(param juju.NewAPIConnectionParams) (api.Connection, error) {
+	if c.apiOpenFunc != nil {
+		return juju.NewAPIConnection(param)
+	}
+	return newSharedAPIConnection(param)
+}
+
 func (c *CommandBase) missingModelError(store jujuclient.ClientStore, controllerName, modelName string) error {
 	// First, we'll try and clean up the missing model from the local cache.
 	err := store.RemoveModel(controllerName, modelName)
@@ -411,6 +427,10 @@ func newAPIConnectionParams(
 	}
 	dialOpts := api.DefaultDialOpts()
 	dialOpts.BakeryClient = bakery
+	// Responses such as FullStatus results can be very large for
+	// large models, and clients are often some distance from the
+	// controller, so compress messages where the controller allows.
+	dialOpts.EnableCompression = true
 
 	if accountDetails != nil {
 		bakery.WebPageVisitor = httpbakery.NewMultiVisitor(
@@ -6,20 +6,25 @@ package modelcmd_test
 import (
 	"io/ioutil"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/juju"
 	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/network"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -78,6 +83,86 @@ func (s *BaseCommandSuite) TestUnknownModelNotCurrent(c *gc.C) {
 	s.assertUnknownModel(c, "admin/goodmodel", "admin/goodmodel")
 }
 
+func (s *BaseCommandSuite) TestNewAPIRootSharesConnections(c *gc.C) {
+	var opened []*fakeConnection
+	cache := juju.NewAPIConnectionCache(testing.NewClock(time.Time{}), time.Minute)
+	s.PatchValue(modelcmd.NewSharedAPIConnection, func(args juju.NewAPIConnectionParams) (api.Connection, error) {
+		args.OpenAPI = func(*api.Info, api.DialOpts) (api.Connection, error) {
+			conn := &fakeConnection{broken: make(chan struct{})}
+			opened = append(opened, conn)
+			return conn, nil
+		}
+		return cache.Open(args)
+	})
+	newAPIRoot := func() api.Connection {
+		baseCmd := new(modelcmd.ModelCommandBase)
+		baseCmd.SetClientStore(s.store)
+		modelcmd.InitContexts(&cmd.Context{Stderr: ioutil.Discard}, baseCmd)
+		modelcmd.SetRunStarted(baseCmd)
+		err := baseCmd.SetModelName("foo:admin/goodmodel", false)
+		c.Assert(err, jc.ErrorIsNil)
+		conn, err := baseCmd.NewAPIRoot()
+		c.Assert(err, jc.ErrorIsNil)
+		return conn
+	}
+
+	conn0 := newAPIRoot()
+	conn1 := newAPIRoot()
+	c.Assert(opened, gc.HasLen, 1)
+	c.Assert(conn0.Close(), jc.ErrorIsNil)
+	c.Assert(conn1.Close(), jc.ErrorIsNil)
+	c.Assert(opened[0].closed, jc.IsFalse)
+
+	// The idle connection is reused by later commands.
+	newAPIRoot()
+	c.Assert(opened, gc.HasLen, 1)
+}
+
+// fakeConnection is an api.Connection that implements only the
+// methods used when making a connection.
+type fakeConnection struct {
+	api.Connection
+	broken chan struct{}
+	closed bool
+}
+
+func (c *fakeConnection) Close() error {
+	c.closed = true
+	return nil
+}
+
+func (c *fakeConnection) Broken() <-chan struct{} {
+	return c.broken
+}
+
+func (*fakeConnection) APIHostPorts() [][]network.HostPort {
+	return nil
+}
+
+func (*fakeConnection) ServerVersion() (version.Number, bool) {
+	return version.Number{}, false
+}
+
+func (*fakeConnection) Addr() string {
+	return "testing.invalid:1234"
+}
+
+func (*fakeConnection) IPAddr() string {
+	return "0.1.2.3:1234"
+}
+
+func (*fakeConnection) PublicDNSName() string {
+	return ""
+}
+
+func (*fakeConnection) AuthTag() names.Tag {
+	return names.NewUserTag("bar")
+}
+
+func (*fakeConnection) ControllerAccess() string {
+	return "superuser"
+}
+
 type NewGetBootstrapConfigParamsFuncSuite struct {
 	testing.IsolationSuite
 }
@@ -2,7 +2,10 @@ package modelcmd
 
 import "github.com/juju/cmd"
 
-var NewAPIContext = newAPIContext
+var (
+	NewAPIContext          = newAPIContext
+	NewSharedAPIConnection = &newSharedAPIConnection
+)
 
 func SetRunStarted(b interface {
 	setRunStarted()
@@ -4,7 +4,9 @@
 package modelcmd
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"strings"
@@ -383,19 +385,68 @@ func (w *modelCommandWrapper) SetFlags(f *gnuflag.FlagSet) {
 }
 
 type bootstrapContext struct {
-	*cmd.Context
+	cmdContext        *cmd.Context
+	ctx               context.Context
 	verifyCredentials bool
 }
 
+// GetStdin implements BootstrapContext.GetStdin.
+func (ctx *bootstrapContext) GetStdin() io.Reader {
+	return ctx.cmdContext.GetStdin()
+}
+
+// GetStdout implements BootstrapContext.GetStdout.
+func (ctx *bootstrapContext) GetStdout() io.Writer {
+	return ctx.cmdContext.GetStdout()
+}
+
+// GetStderr implements BootstrapContext.GetStderr.
+func (ctx *bootstrapContext) GetStderr() io.Writer {
+	return ctx.cmdContext.GetStderr()
+}
+
+// Infof implements BootstrapContext.Infof.
+func (ctx *bootstrapContext) Infof(format string, params ...interface{}) {
+	ctx.cmdContext.Infof(format, params...)
+}
+
+// Verbosef implements BootstrapContext.Verbosef.
+func (ctx *bootstrapContext) Verbosef(format string, params ...interface{}) {
+	ctx.cmdContext.Verbosef(format, params...)
+}
+
+// InterruptNotify implements BootstrapContext.InterruptNotify.
+func (ctx *bootstrapContext) InterruptNotify(sig chan<- os.Signal) {
+	ctx.cmdContext.InterruptNotify(sig)
+}
+
+// StopInterruptNotify implements BootstrapContext.StopInterruptNotify.
+func (ctx *bootstrapContext) StopInterruptNotify(sig chan<- os.Signal) {
+	ctx.cmdContext.StopInterruptNotify(sig)
+}
+
 // ShouldVerifyCredentials implements BootstrapContext.ShouldVerifyCredentials
 func (ctx *bootstrapContext) ShouldVerifyCredentials() bool {
 	return ctx.verifyCredentials
 }
 
+// Context implements BootstrapContext.Context.
+func (ctx *bootstrapContext) Context() context.Context {
+	return ctx.ctx
+}
+
 // BootstrapContext returns a new BootstrapContext constructed from a command Context.
 func BootstrapContext(cmdContext *cmd.Context) environs.BootstrapContext {
+	return CancellableBootstrapContext(context.Background(), cmdContext)
+}
+
+// CancellableBootstrapContext returns a new BootstrapContext constructed
+// from a command Context, whose provider operations are abandoned when
+// the supplied context is cancelled.
+func CancellableBootstrapContext(ctx context.Context, cmdContext *cmd.Context) environs.BootstrapContext {
 	return &bootstrapContext{
-		Context:           cmdContext,
+		cmdContext:        cmdContext,
+		ctx:               ctx,
 		verifyCredentials: true,
 	}
 }
@@ -404,7 +455,8 @@ func BootstrapContext(cmdContext *cmd.Context) environs.BootstrapContext {
 // where the validation of credentials is false.
 func BootstrapContextNoVerify(cmdContext *cmd.Context) environs.BootstrapContext {
 	return &bootstrapContext{
-		Context:           cmdContext,
+		cmdContext:        cmdContext,
+		ctx:               context.Background(),
 		verifyCredentials: false,
 	}
 }
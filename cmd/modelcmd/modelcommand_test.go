@@ -4,6 +4,7 @@
 package modelcmd_test
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -123,6 +124,7 @@ func (s *ModelCommandSuite) TestModelName(c *gc.C) {
 func (s *ModelCommandSuite) TestBootstrapContext(c *gc.C) {
 	ctx := modelcmd.BootstrapContext(&cmd.Context{})
 	c.Assert(ctx.ShouldVerifyCredentials(), jc.IsTrue)
+	c.Assert(ctx.Context(), gc.Equals, context.Background())
 }
 
 func (s *ModelCommandSuite) TestBootstrapContextNoVerify(c *gc.C) {
@@ -130,6 +132,15 @@ func (s *ModelCommandSuite) TestBootstrapContextNoVerify(c *gc.C) {
 	c.Assert(ctx.ShouldVerifyCredentials(), jc.IsFalse)
 }
 
+func (s *ModelCommandSuite) TestCancellableBootstrapContext(c *gc.C) {
+	stdctx, cancel := context.WithCancel(context.Background())
+	ctx := modelcmd.CancellableBootstrapContext(stdctx, &cmd.Context{})
+	c.Assert(ctx.ShouldVerifyCredentials(), jc.IsTrue)
+	c.Assert(ctx.Context().Err(), jc.ErrorIsNil)
+	cancel()
+	c.Assert(ctx.Context().Err(), gc.Equals, context.Canceled)
+}
+
 func (s *ModelCommandSuite) TestWrapWithoutFlags(c *gc.C) {
 	cmd := new(testCommand)
 	wrapped := modelcmd.Wrap(cmd, modelcmd.WrapSkipModelFlags)
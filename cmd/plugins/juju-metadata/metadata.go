@@ -10,7 +10,6 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/utils/featureflag"
 
-	"github.com/juju/juju/feature"
 	"github.com/juju/juju/juju"
 	"github.com/juju/juju/juju/osenv"
 	_ "github.com/juju/juju/provider/all"
@@ -54,11 +53,9 @@ func NewSuperCommand() cmd.Command {
 	metadatacmd.Register(newToolsMetadataCommand())
 	metadatacmd.Register(newValidateToolsMetadataCommand())
 	metadatacmd.Register(newSignMetadataCommand())
-	if featureflag.Enabled(feature.ImageMetadata) {
-		metadatacmd.Register(newListImagesCommand())
-		metadatacmd.Register(newAddImageMetadataCommand())
-		metadatacmd.Register(newDeleteImageMetadataCommand())
-	}
+	metadatacmd.Register(newListImagesCommand())
+	metadatacmd.Register(newAddImageMetadataCommand())
+	metadatacmd.Register(newDeleteImageMetadataCommand())
 	return metadatacmd
 }
 
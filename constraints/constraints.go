@@ -7,6 +7,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -32,6 +34,11 @@ const (
 	InstanceType = "instance-type"
 	Spaces       = "spaces"
 	VirtType     = "virt-type"
+	Spot         = "spot"
+
+	// InstanceAttributes holds provider-specific options for the
+	// instance, in the form "instance-attributes=key=value,...".
+	InstanceAttributes = "instance-attributes"
 )
 
 // Value describes a user's requirements of the hardware on which units
@@ -85,6 +92,18 @@ type Value struct {
 	// VirtType, if not nil or empty, indicates that a machine must run the named
 	// virtual type. Only valid for clouds with multi-hypervisor support.
 	VirtType *string `json:"virt-type,omitempty" yaml:"virt-type,omitempty"`
+
+	// Spot, if true, indicates that a machine should be started as a
+	// spot or preemptible instance, which is cheaper but may be
+	// reclaimed by the cloud at short notice. Only valid for clouds
+	// which offer such instances.
+	Spot *bool `json:"spot,omitempty" yaml:"spot,omitempty"`
+
+	// InstanceAttributes, if not nil, holds provider-specific options
+	// that are passed through to the provider when starting an
+	// instance. The attributes each provider accepts are validated by
+	// its constraints validator.
+	InstanceAttributes *map[string]string `json:"instance-attributes,omitempty" yaml:"instance-attributes,omitempty"`
 }
 
 var rawAliases = map[string]string{
@@ -180,6 +199,18 @@ func (v *Value) HasVirtType() bool {
 	return v.VirtType != nil && *v.VirtType != ""
 }
 
+// HasSpot returns true if the constraints.Value requests a spot or
+// preemptible instance.
+func (v *Value) HasSpot() bool {
+	return v.Spot != nil && *v.Spot
+}
+
+// HasInstanceAttributes returns true if the constraints.Value specifies
+// any provider-specific instance attributes.
+func (v *Value) HasInstanceAttributes() bool {
+	return v.InstanceAttributes != nil && len(*v.InstanceAttributes) > 0
+}
+
 // String expresses a constraints.Value in the language in which it was specified.
 func (v Value) String() string {
 	var strs []string
@@ -223,6 +254,12 @@ func (v Value) String() string {
 	if v.VirtType != nil {
 		strs = append(strs, "virt-type="+string(*v.VirtType))
 	}
+	if v.Spot != nil {
+		strs = append(strs, "spot="+strconv.FormatBool(*v.Spot))
+	}
+	if v.InstanceAttributes != nil {
+		strs = append(strs, "instance-attributes="+formatAttributes(*v.InstanceAttributes))
+	}
 	return strings.Join(strs, " ")
 }
 
@@ -264,9 +301,32 @@ func (v Value) GoString() string {
 	if v.VirtType != nil {
 		values = append(values, fmt.Sprintf("VirtType: %q", *v.VirtType))
 	}
+	if v.Spot != nil {
+		values = append(values, fmt.Sprintf("Spot: %v", *v.Spot))
+	}
+	if v.InstanceAttributes != nil && *v.InstanceAttributes != nil {
+		values = append(values, fmt.Sprintf("InstanceAttributes: %q", formatAttributes(*v.InstanceAttributes)))
+	} else if v.InstanceAttributes != nil {
+		values = append(values, "InstanceAttributes: (*map[string]string)(nil)")
+	}
 	return fmt.Sprintf("{%s}", strings.Join(values, ", "))
 }
 
+// formatAttributes returns the given instance attributes as a comma
+// separated list of key=value pairs, ordered by key.
+func formatAttributes(attrs map[string]string) string {
+	keys := make([]string, 0, len(attrs))
+	for key := range attrs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = key + "=" + attrs[key]
+	}
+	return strings.Join(pairs, ",")
+}
+
 func uintStr(i uint64) string {
 	if i == 0 {
 		return ""
@@ -420,6 +480,10 @@ func (v *Value) setRaw(name, str string) error {
 		err = v.setSpaces(str)
 	case VirtType:
 		err = v.setVirtType(str)
+	case Spot:
+		err = v.setSpot(str)
+	case InstanceAttributes:
+		err = v.setInstanceAttributes(str)
 	default:
 		return errors.Errorf("unknown constraint %q", name)
 	}
@@ -483,6 +547,10 @@ func (v *Value) UnmarshalYAML(unmarshal func(interface{}) error) error {
 			}
 		case VirtType:
 			v.VirtType = &vstr
+		case Spot:
+			v.Spot, err = parseBool(vstr)
+		case InstanceAttributes:
+			v.InstanceAttributes, err = parseYamlAttributes(val)
 		default:
 			return errors.Errorf("unknown constraint value: %v", k)
 		}
@@ -607,6 +675,52 @@ func (v *Value) setVirtType(str string) error {
 	return nil
 }
 
+func (v *Value) setSpot(str string) (err error) {
+	if v.Spot != nil {
+		return errors.Errorf("already set")
+	}
+	v.Spot, err = parseBool(str)
+	return
+}
+
+func (v *Value) setInstanceAttributes(str string) error {
+	if v.InstanceAttributes != nil {
+		return errors.Errorf("already set")
+	}
+	attrs := make(map[string]string)
+	if str != "" {
+		for _, pair := range strings.Split(str, ",") {
+			key, value, err := splitRaw(pair)
+			if err != nil {
+				return errors.Errorf("malformed instance attribute %q", pair)
+			}
+			if _, ok := attrs[key]; ok {
+				return errors.Errorf("instance attribute %q already set", key)
+			}
+			attrs[key] = value
+		}
+	}
+	if err := validateAttributeKeys(attrs); err != nil {
+		return err
+	}
+	v.InstanceAttributes = &attrs
+	return nil
+}
+
+var validAttributeKey = regexp.MustCompile("^[a-zA-Z0-9][a-zA-Z0-9_-]*$")
+
+// validateAttributeKeys returns an error if any of the given instance
+// attribute keys is not valid. Keys are restricted to letters, digits,
+// dashes and underscores so they can be stored as document fields.
+func validateAttributeKeys(attrs map[string]string) error {
+	for key := range attrs {
+		if !validAttributeKey.MatchString(key) {
+			return errors.Errorf("%q is not a valid instance attribute name", key)
+		}
+	}
+	return nil
+}
+
 func parseUint64(str string) (*uint64, error) {
 	var value uint64
 	if str != "" {
@@ -619,6 +733,18 @@ func parseUint64(str string) (*uint64, error) {
 	return &value, nil
 }
 
+func parseBool(str string) (*bool, error) {
+	var value bool
+	if str != "" {
+		val, err := strconv.ParseBool(str)
+		if err != nil {
+			return nil, errors.Errorf("must be true or false")
+		}
+		value = val
+	}
+	return &value, nil
+}
+
 func parseSize(str string) (*uint64, error) {
 	var value uint64
 	if str != "" {
@@ -663,6 +789,25 @@ func parseYamlStrings(entityName string, val interface{}) (*[]string, error) {
 	return &items, nil
 }
 
+func parseYamlAttributes(val interface{}) (*map[string]string, error) {
+	ifcs, ok := val.(map[interface{}]interface{})
+	if !ok {
+		return nil, errors.Errorf("unexpected type passed to instance-attributes: %T", val)
+	}
+	attrs := make(map[string]string, len(ifcs))
+	for k, v := range ifcs {
+		key, ok := k.(string)
+		if !ok {
+			return nil, errors.Errorf("unexpected non-string instance attribute name: %#v", k)
+		}
+		attrs[key] = fmt.Sprintf("%v", v)
+	}
+	if err := validateAttributeKeys(attrs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &attrs, nil
+}
+
 var mbSuffixes = map[string]float64{
 	"M": 1,
 	"G": 1024,
@@ -313,6 +313,54 @@ var parseConstraintsTests = []struct {
 		err:     `bad "virt-type" constraint: already set`,
 	},
 
+	// "spot" in detail.
+	{
+		summary: "set spot empty",
+		args:    []string{"spot="},
+	}, {
+		summary: "set spot true",
+		args:    []string{"spot=true"},
+	}, {
+		summary: "set spot false",
+		args:    []string{"spot=false"},
+	}, {
+		summary: "set nonsense spot",
+		args:    []string{"spot=maybe"},
+		err:     `bad "spot" constraint: must be true or false`,
+	}, {
+		summary: "double set spot separately",
+		args:    []string{"spot=true", "spot=false"},
+		err:     `bad "spot" constraint: already set`,
+	},
+
+	// "instance-attributes" in detail.
+	{
+		summary: "set instance-attributes empty",
+		args:    []string{"instance-attributes="},
+	}, {
+		summary: "set instance-attributes",
+		args:    []string{"instance-attributes=placement-group=pg1,shutdown-behavior=stop"},
+	}, {
+		summary: "set instance-attributes with empty value",
+		args:    []string{"instance-attributes=placement-group="},
+	}, {
+		summary: "instance-attributes without value",
+		args:    []string{"instance-attributes=placement-group"},
+		err:     `bad "instance-attributes" constraint: malformed instance attribute "placement-group"`,
+	}, {
+		summary: "instance-attributes with invalid name",
+		args:    []string{"instance-attributes=a.b=c"},
+		err:     `bad "instance-attributes" constraint: "a.b" is not a valid instance attribute name`,
+	}, {
+		summary: "instance-attributes with repeated name",
+		args:    []string{"instance-attributes=a=b,a=c"},
+		err:     `bad "instance-attributes" constraint: instance attribute "a" already set`,
+	}, {
+		summary: "double set instance-attributes separately",
+		args:    []string{"instance-attributes=a=b", "instance-attributes="},
+		err:     `bad "instance-attributes" constraint: already set`,
+	},
+
 	// Everything at once.
 	{
 		summary: "kitchen sink together",
@@ -473,6 +521,10 @@ func strp(s string) *string {
 	return &s
 }
 
+func boolp(b bool) *bool {
+	return &b
+}
+
 func ctypep(ctype string) *instance.ContainerType {
 	res := instance.ContainerType(ctype)
 	return &res
@@ -510,6 +562,13 @@ var constraintsRoundtripTests = []roundTrip{
 	{"Spaces3", constraints.Value{Spaces: &[]string{"space1", "^space2"}}},
 	{"InstanceType1", constraints.Value{InstanceType: strp("")}},
 	{"InstanceType2", constraints.Value{InstanceType: strp("foo")}},
+	{"Spot1", constraints.Value{Spot: boolp(true)}},
+	{"Spot2", constraints.Value{Spot: boolp(false)}},
+	{"InstanceAttributes1", constraints.Value{InstanceAttributes: &map[string]string{}}},
+	{"InstanceAttributes2", constraints.Value{InstanceAttributes: &map[string]string{
+		"placement-group":   "pg1",
+		"shutdown-behavior": "stop",
+	}}},
 	{"All", constraints.Value{
 		Arch:         strp("i386"),
 		Container:    ctypep("lxd"),
@@ -590,6 +649,26 @@ func (s *ConstraintsSuite) TestHasContainer(c *gc.C) {
 	}
 }
 
+func (s *ConstraintsSuite) TestHasSpot(c *gc.C) {
+	cons := constraints.MustParse("arch=amd64")
+	c.Check(cons.HasSpot(), jc.IsFalse)
+	cons = constraints.MustParse("spot=false")
+	c.Check(cons.HasSpot(), jc.IsFalse)
+	cons = constraints.MustParse("spot=true")
+	c.Check(cons.HasSpot(), jc.IsTrue)
+	c.Check(cons.String(), gc.Equals, "spot=true")
+}
+
+func (s *ConstraintsSuite) TestHasInstanceAttributes(c *gc.C) {
+	cons := constraints.MustParse("arch=amd64")
+	c.Check(cons.HasInstanceAttributes(), jc.IsFalse)
+	cons = constraints.MustParse("instance-attributes=")
+	c.Check(cons.HasInstanceAttributes(), jc.IsFalse)
+	cons = constraints.MustParse("instance-attributes=b=2,a=1")
+	c.Check(cons.HasInstanceAttributes(), jc.IsTrue)
+	c.Check(cons.String(), gc.Equals, "instance-attributes=a=1,b=2")
+}
+
 func (s *ConstraintsSuite) TestHasInstanceType(c *gc.C) {
 	cons := constraints.MustParse("arch=amd64")
 	c.Check(cons.HasInstanceType(), jc.IsFalse)
@@ -0,0 +1,153 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package constraints
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// The following constants name the levels at which constraints are
+// set, from the least to the most specific.
+const (
+	SourceModel       = "model"
+	SourceApplication = "application"
+	SourceUnit        = "unit"
+)
+
+// Layer holds the constraints set at one level, such as the model or
+// an application.
+type Layer struct {
+	// Source names the level at which the constraints were set.
+	Source string
+
+	// Value holds the constraints set at the level.
+	Value Value
+
+	// Exact, if true, indicates that Value holds the complete
+	// constraints in effect at the level, rather than constraints to
+	// be merged with those of the less specific levels: attributes
+	// that Value does not set are not in effect, and those it sets to
+	// the value already in effect keep their source.
+	Exact bool
+}
+
+// AttributeTrace describes where the effective value of a constraint
+// attribute came from.
+type AttributeTrace struct {
+	// Attribute is the name of the constraint attribute.
+	Attribute string
+
+	// Value is the effective value of the attribute, formatted as it
+	// would be on the command line. It is empty if the attribute is
+	// not in effect.
+	Value string
+
+	// Source names the level at which the effective value was set.
+	// It is empty if the attribute is not in effect.
+	Source string
+
+	// Overridden holds the values set at less specific levels that
+	// are not in effect, from the least to the most specific.
+	Overridden []OverriddenValue
+}
+
+// OverriddenValue describes a constraint attribute value that is not
+// in effect.
+type OverriddenValue struct {
+	// Source names the level at which the value was set.
+	Source string
+
+	// Value is the value, formatted as it would be on the command
+	// line.
+	Value string
+
+	// Reason describes why the value is not in effect.
+	Reason string
+}
+
+// Trace merges the constraints of the given layers, from the least to
+// the most specific, as the validator would when provisioning a
+// machine, and returns the effective constraints along with a trace of
+// where each attribute's value came from, sorted by attribute name.
+// Attributes that were set by some layer but are not in effect are
+// included in the trace, with an empty value and source.
+func Trace(validator Validator, layers ...Layer) (Value, []AttributeTrace, error) {
+	var effective Value
+	traces := make(map[string]*AttributeTrace)
+	for _, layer := range layers {
+		merged := layer.Value
+		if !layer.Exact {
+			var err error
+			merged, err = validator.Merge(effective, layer.Value)
+			if err != nil {
+				return Value{}, nil, err
+			}
+		}
+		before := effective.attributesWithValues()
+		after := merged.attributesWithValues()
+		set := layer.Value.attributesWithValues()
+		for attr, oldValue := range before {
+			trace := traces[attr]
+			newValue, ok := after[attr]
+			switch {
+			case !ok:
+				reason := fmt.Sprintf("conflicts with %s constraints", layer.Source)
+				if layer.Exact {
+					reason = fmt.Sprintf("not in %s constraints", layer.Source)
+				}
+				trace.override(reason)
+				trace.Value, trace.Source = "", ""
+			case !reflect.DeepEqual(oldValue, newValue):
+				trace.override(fmt.Sprintf("overridden by %s constraints", layer.Source))
+				trace.Value, trace.Source = formatAttribute(attr, newValue), layer.Source
+			case !layer.Exact:
+				if _, ok := set[attr]; ok {
+					trace.Source = layer.Source
+				}
+			}
+		}
+		for attr, newValue := range after {
+			if _, ok := before[attr]; ok {
+				continue
+			}
+			trace := traces[attr]
+			if trace == nil {
+				trace = &AttributeTrace{Attribute: attr}
+				traces[attr] = trace
+			}
+			trace.Value, trace.Source = formatAttribute(attr, newValue), layer.Source
+		}
+		effective = merged
+	}
+
+	result := make([]AttributeTrace, 0, len(traces))
+	for _, trace := range traces {
+		result = append(result, *trace)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Attribute < result[j].Attribute
+	})
+	return effective, result, nil
+}
+
+// override records that the attribute's current value is no longer in
+// effect, for the given reason.
+func (t *AttributeTrace) override(reason string) {
+	t.Overridden = append(t.Overridden, OverriddenValue{
+		Source: t.Source,
+		Value:  t.Value,
+		Reason: reason,
+	})
+}
+
+// formatAttribute returns the value of the named attribute, as held in
+// the map returned by attributesWithValues, formatted as it would be on
+// the command line.
+func formatAttribute(attr string, value interface{}) string {
+	v := fromAttributes(map[string]interface{}{attr: value})
+	return strings.TrimPrefix(v.String(), attr+"=")
+}
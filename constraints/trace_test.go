@@ -0,0 +1,139 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package constraints_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/constraints"
+)
+
+type traceSuite struct{}
+
+var _ = gc.Suite(&traceSuite{})
+
+func (s *traceSuite) validator() constraints.Validator {
+	validator := constraints.NewValidator()
+	validator.RegisterConflicts([]string{"instance-type"}, []string{"mem", "cores"})
+	return validator
+}
+
+func (s *traceSuite) TestTrace(c *gc.C) {
+	cons, trace, err := constraints.Trace(s.validator(),
+		constraints.Layer{
+			Source: constraints.SourceModel,
+			Value:  constraints.MustParse("mem=4G cores=1 arch=amd64"),
+		},
+		constraints.Layer{
+			Source: constraints.SourceApplication,
+			Value:  constraints.MustParse("cores=4 arch=amd64 root-disk=16G"),
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cons, jc.DeepEquals, constraints.MustParse("mem=4G cores=4 arch=amd64 root-disk=16G"))
+	c.Assert(trace, jc.DeepEquals, []constraints.AttributeTrace{{
+		Attribute: "arch",
+		Value:     "amd64",
+		Source:    constraints.SourceApplication,
+	}, {
+		Attribute: "cores",
+		Value:     "4",
+		Source:    constraints.SourceApplication,
+		Overridden: []constraints.OverriddenValue{{
+			Source: constraints.SourceModel,
+			Value:  "1",
+			Reason: "overridden by application constraints",
+		}},
+	}, {
+		Attribute: "mem",
+		Value:     "4096M",
+		Source:    constraints.SourceModel,
+	}, {
+		Attribute: "root-disk",
+		Value:     "16384M",
+		Source:    constraints.SourceApplication,
+	}})
+}
+
+func (s *traceSuite) TestTraceConflicts(c *gc.C) {
+	cons, trace, err := constraints.Trace(s.validator(),
+		constraints.Layer{
+			Source: constraints.SourceModel,
+			Value:  constraints.MustParse("mem=4G"),
+		},
+		constraints.Layer{
+			Source: constraints.SourceApplication,
+			Value:  constraints.MustParse("instance-type=m1.small"),
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cons, jc.DeepEquals, constraints.MustParse("instance-type=m1.small"))
+	c.Assert(trace, jc.DeepEquals, []constraints.AttributeTrace{{
+		Attribute: "instance-type",
+		Value:     "m1.small",
+		Source:    constraints.SourceApplication,
+	}, {
+		Attribute: "mem",
+		Overridden: []constraints.OverriddenValue{{
+			Source: constraints.SourceModel,
+			Value:  "4096M",
+			Reason: "conflicts with application constraints",
+		}},
+	}})
+}
+
+func (s *traceSuite) TestTraceExactLayer(c *gc.C) {
+	// The unit's constraints were resolved when it was created, before
+	// the model's root-disk constraint was set and the application's
+	// cores constraint was changed.
+	cons, trace, err := constraints.Trace(s.validator(),
+		constraints.Layer{
+			Source: constraints.SourceModel,
+			Value:  constraints.MustParse("mem=4G root-disk=8G"),
+		},
+		constraints.Layer{
+			Source: constraints.SourceApplication,
+			Value:  constraints.MustParse("cores=4"),
+		},
+		constraints.Layer{
+			Source: constraints.SourceUnit,
+			Value:  constraints.MustParse("mem=4G cores=2"),
+			Exact:  true,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cons, jc.DeepEquals, constraints.MustParse("mem=4G cores=2"))
+	c.Assert(trace, jc.DeepEquals, []constraints.AttributeTrace{{
+		Attribute: "cores",
+		Value:     "2",
+		Source:    constraints.SourceUnit,
+		Overridden: []constraints.OverriddenValue{{
+			Source: constraints.SourceApplication,
+			Value:  "4",
+			Reason: "overridden by unit constraints",
+		}},
+	}, {
+		Attribute: "mem",
+		Value:     "4096M",
+		Source:    constraints.SourceModel,
+	}, {
+		Attribute: "root-disk",
+		Overridden: []constraints.OverriddenValue{{
+			Source: constraints.SourceModel,
+			Value:  "8192M",
+			Reason: "not in unit constraints",
+		}},
+	}})
+}
+
+func (s *traceSuite) TestTraceInvalid(c *gc.C) {
+	validator := s.validator()
+	validator.RegisterVocabulary("arch", []string{"amd64"})
+	_, _, err := constraints.Trace(validator, constraints.Layer{
+		Source: constraints.SourceModel,
+		Value:  constraints.MustParse("arch=i386"),
+	})
+	c.Assert(err, gc.ErrorMatches, `invalid constraint value: arch=i386\nvalid values are: \[amd64\]`)
+}
@@ -6,6 +6,7 @@ package constraints
 import (
 	"fmt"
 	"reflect"
+	"sort"
 
 	"github.com/juju/utils/set"
 )
@@ -32,6 +33,17 @@ type Validator interface {
 	// that vocabs of different types can be passed in.
 	RegisterVocabulary(attributeName string, allowedValues interface{})
 
+	// RegisterInstanceAttributes records the provider-specific attributes
+	// that may be given in the instance-attributes constraint, each with
+	// the values it may take; an empty list of values allows any value.
+	// Until it is called, instance-attributes is treated as unsupported.
+	RegisterInstanceAttributes(attributes map[string][]string)
+
+	// RegisterSpotSupport records that the provider can start spot or
+	// preemptible instances. Until it is called, spot is treated as
+	// unsupported.
+	RegisterSpotSupport()
+
 	// Validate returns an error if the given constraints are not valid, and also
 	// any unsupported attributes.
 	Validate(cons Value) ([]string, error)
@@ -57,9 +69,11 @@ func NewValidator() Validator {
 }
 
 type validator struct {
-	unsupported set.Strings
-	conflicts   map[string]set.Strings
-	vocab       map[string][]interface{}
+	unsupported        set.Strings
+	conflicts          map[string]set.Strings
+	vocab              map[string][]interface{}
+	instanceAttributes map[string]set.Strings
+	spotSupported      bool
 }
 
 // RegisterConflicts is defined on Validator.
@@ -82,6 +96,19 @@ func (v *validator) RegisterVocabulary(attributeName string, allowedValues inter
 	v.vocab[resolveAlias(attributeName)] = convertToSlice(allowedValues)
 }
 
+// RegisterInstanceAttributes is defined on Validator.
+func (v *validator) RegisterInstanceAttributes(attributes map[string][]string) {
+	v.instanceAttributes = make(map[string]set.Strings, len(attributes))
+	for name, values := range attributes {
+		v.instanceAttributes[name] = set.NewStrings(values...)
+	}
+}
+
+// RegisterSpotSupport is defined on Validator.
+func (v *validator) RegisterSpotSupport() {
+	v.spotSupported = true
+}
+
 var checkIsCollection = func(coll interface{}) {
 	k := reflect.TypeOf(coll).Kind()
 	if k != reflect.Slice && k != reflect.Array {
@@ -158,7 +185,46 @@ func (v *validator) checkConflicts(cons Value) error {
 
 // checkUnsupported returns any unsupported attributes.
 func (v *validator) checkUnsupported(cons Value) []string {
-	return cons.hasAny(v.unsupported.Values()...)
+	unsupported := cons.hasAny(v.unsupported.Values()...)
+	if v.instanceAttributes == nil && !v.unsupported.Contains(InstanceAttributes) {
+		unsupported = append(unsupported, cons.hasAny(InstanceAttributes)...)
+	}
+	if !v.spotSupported && !v.unsupported.Contains(Spot) {
+		unsupported = append(unsupported, cons.hasAny(Spot)...)
+	}
+	return unsupported
+}
+
+// checkInstanceAttributes returns an error if the constraints value
+// contains an instance attribute, or attribute value, which has not
+// been registered.
+func (v *validator) checkInstanceAttributes(cons Value) error {
+	if v.instanceAttributes == nil || cons.InstanceAttributes == nil {
+		return nil
+	}
+	attrs := *cons.InstanceAttributes
+	keys := make([]string, 0, len(attrs))
+	for key := range attrs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		values, ok := v.instanceAttributes[key]
+		if !ok {
+			valid := make([]string, 0, len(v.instanceAttributes))
+			for name := range v.instanceAttributes {
+				valid = append(valid, name)
+			}
+			sort.Strings(valid)
+			return fmt.Errorf(
+				"invalid instance attribute: %v\nvalid attributes are: %v", key, valid)
+		}
+		if values.Size() > 0 && !values.Contains(attrs[key]) {
+			return fmt.Errorf(
+				"invalid instance attribute value: %v=%v\nvalid values are: %v", key, attrs[key], values.SortedValues())
+		}
+	}
+	return nil
 }
 
 // checkValidValues returns an error if the constraints value contains an
@@ -259,6 +325,9 @@ func (v *validator) Validate(cons Value) ([]string, error) {
 	if err := v.checkValidValues(cons); err != nil {
 		return unsupported, err
 	}
+	if err := v.checkInstanceAttributes(cons); err != nil {
+		return unsupported, err
+	}
 	return unsupported, nil
 }
 
@@ -156,6 +156,62 @@ func (s *validationSuite) TestValidation(c *gc.C) {
 	}
 }
 
+func (s *validationSuite) TestValidateInstanceAttributesUnregistered(c *gc.C) {
+	validator := constraints.NewValidator()
+	cons := constraints.MustParse("mem=4G instance-attributes=placement-group=pg1")
+	unsupported, err := validator.Validate(cons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unsupported, jc.DeepEquals, []string{"instance-attributes"})
+}
+
+func (s *validationSuite) TestValidateSpotUnregistered(c *gc.C) {
+	validator := constraints.NewValidator()
+	cons := constraints.MustParse("mem=4G spot=true")
+	unsupported, err := validator.Validate(cons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unsupported, jc.DeepEquals, []string{"spot"})
+}
+
+func (s *validationSuite) TestValidateSpot(c *gc.C) {
+	validator := constraints.NewValidator()
+	validator.RegisterSpotSupport()
+	cons := constraints.MustParse("mem=4G spot=true")
+	unsupported, err := validator.Validate(cons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unsupported, gc.HasLen, 0)
+}
+
+func (s *validationSuite) TestValidateInstanceAttributes(c *gc.C) {
+	validator := constraints.NewValidator()
+	validator.RegisterInstanceAttributes(map[string][]string{
+		"placement-group":   nil,
+		"shutdown-behavior": {"stop", "terminate"},
+	})
+	for i, t := range []struct {
+		cons string
+		err  string
+	}{{
+		cons: "instance-attributes=placement-group=pg1,shutdown-behavior=stop",
+	}, {
+		cons: "instance-attributes=",
+	}, {
+		cons: "instance-attributes=tenancy=dedicated",
+		err:  "invalid instance attribute: tenancy\nvalid attributes are: \\[placement-group shutdown-behavior\\]",
+	}, {
+		cons: "instance-attributes=shutdown-behavior=hibernate",
+		err:  "invalid instance attribute value: shutdown-behavior=hibernate\nvalid values are: \\[stop terminate\\]",
+	}} {
+		c.Logf("test %d: %s", i, t.cons)
+		unsupported, err := validator.Validate(constraints.MustParse(t.cons))
+		if t.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+			c.Check(unsupported, gc.HasLen, 0)
+		} else {
+			c.Check(err, gc.ErrorMatches, t.err)
+		}
+	}
+}
+
 var mergeTests = []struct {
 	desc         string
 	consFallback string
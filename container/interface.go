@@ -6,6 +6,7 @@ package container
 import (
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 )
@@ -50,6 +51,19 @@ type Manager interface {
 	Namespace() instance.Namespace
 }
 
+// LXDProfileManager is implemented by container managers that can apply
+// the LXD profiles declared by charms to their containers.
+type LXDProfileManager interface {
+	// MaybeWriteLXDProfile creates the named LXD profile, or updates
+	// it to match the supplied profile if it already exists.
+	MaybeWriteLXDProfile(name string, profile lxdprofile.Profile) error
+
+	// ReplaceLXDProfiles removes the profiles in remove from those
+	// applied to the container with the given instance id, and applies
+	// those in add.
+	ReplaceLXDProfiles(id instance.Id, remove, add []string) error
+}
+
 // Initialiser is responsible for performing the steps required to initialise
 // a host machine so it can run containers.
 type Initialiser interface {
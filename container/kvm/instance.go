@@ -4,6 +4,7 @@
 package kvm
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/juju/juju/instance"
@@ -47,17 +48,17 @@ func (kvm *kvmInstance) Addresses() ([]network.Address, error) {
 }
 
 // OpenPorts implements instance.Instance.OpenPorts.
-func (kvm *kvmInstance) OpenPorts(machineId string, rules []network.IngressRule) error {
+func (kvm *kvmInstance) OpenPorts(ctx context.Context, machineId string, rules []network.IngressRule) error {
 	return fmt.Errorf("not implemented")
 }
 
 // ClosePorts implements instance.Instance.ClosePorts.
-func (kvm *kvmInstance) ClosePorts(machineId string, rules []network.IngressRule) error {
+func (kvm *kvmInstance) ClosePorts(ctx context.Context, machineId string, rules []network.IngressRule) error {
 	return fmt.Errorf("not implemented")
 }
 
 // IngressRules implements instance.Instance.IngressRules.
-func (kvm *kvmInstance) IngressRules(machineId string) ([]network.IngressRule, error) {
+func (kvm *kvmInstance) IngressRules(ctx context.Context, machineId string) ([]network.IngressRule, error) {
 	return nil, fmt.Errorf("not implemented")
 }
 
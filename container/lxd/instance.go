@@ -6,6 +6,7 @@
 package lxd
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/juju/errors"
@@ -63,17 +64,17 @@ func (lxd *lxdInstance) Status() instance.InstanceStatus {
 }
 
 // OpenPorts implements instance.Instance.OpenPorts.
-func (lxd *lxdInstance) OpenPorts(machineId string, rules []network.IngressRule) error {
+func (lxd *lxdInstance) OpenPorts(ctx context.Context, machineId string, rules []network.IngressRule) error {
 	return fmt.Errorf("not implemented")
 }
 
 // ClosePorts implements instance.Instance.ClosePorts.
-func (lxd *lxdInstance) ClosePorts(machineId string, rules []network.IngressRule) error {
+func (lxd *lxdInstance) ClosePorts(ctx context.Context, machineId string, rules []network.IngressRule) error {
 	return fmt.Errorf("not implemented")
 }
 
 // IngressRules implements instance.Instance.IngressRules.
-func (lxd *lxdInstance) IngressRules(machineId string) ([]network.IngressRule, error) {
+func (lxd *lxdInstance) IngressRules(ctx context.Context, machineId string) ([]network.IngressRule, error) {
 	return nil, fmt.Errorf("not implemented")
 }
 
@@ -11,11 +11,14 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/arch"
+	"github.com/juju/utils/set"
+	"github.com/lxc/lxd/shared/api"
 
 	"github.com/juju/juju/cloudconfig/containerinit"
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/container"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
@@ -45,6 +48,9 @@ type containerManager struct {
 // containerManager implements container.Manager.
 var _ container.Manager = (*containerManager)(nil)
 
+// containerManager implements container.LXDProfileManager.
+var _ container.LXDProfileManager = (*containerManager)(nil)
+
 func ConnectLocal() (*lxdclient.Client, error) {
 	cfg := lxdclient.Config{
 		Remote: lxdclient.Local,
@@ -167,14 +173,15 @@ func (manager *containerManager) CreateContainer(
 
 	// TODO(macgreagoir) This might be dead code. Do we always get
 	// len(nics) > 0?
-	profiles := []string{}
-
 	if len(nics) == 0 {
 		logger.Infof("instance %q configured with %q profile", name, lxdDefaultProfileName)
-		profiles = append(profiles, lxdDefaultProfileName)
 	} else {
 		logger.Infof("instance %q configured with %v network devices", name, nics)
 	}
+	if len(instanceConfig.CharmLXDProfiles) > 0 {
+		logger.Infof("instance %q configured with charm profiles %v", name, instanceConfig.CharmLXDProfiles)
+	}
+	profiles := instanceProfiles(len(nics) > 0, instanceConfig.CharmLXDProfiles)
 
 	spec := lxdclient.InstanceSpec{
 		Name:     name,
@@ -196,6 +203,18 @@ func (manager *containerManager) CreateContainer(
 	return
 }
 
+// instanceProfiles returns the profiles to apply to a new container.
+// If it has network devices and no charm profiles, none are returned,
+// so that LXD applies its default profile; an explicit list replaces
+// the default profile, so it is then included.
+func instanceProfiles(hasNICs bool, charmProfiles []string) []string {
+	profiles := []string{}
+	if !hasNICs || len(charmProfiles) > 0 {
+		profiles = append(profiles, lxdDefaultProfileName)
+	}
+	return append(profiles, charmProfiles...)
+}
+
 func (manager *containerManager) DestroyContainer(id instance.Id) error {
 	if manager.client == nil {
 		var err error
@@ -240,6 +259,53 @@ func (manager *containerManager) IsInitialized() bool {
 	return err == nil
 }
 
+// MaybeWriteLXDProfile is part of the container.LXDProfileManager
+// interface.
+func (manager *containerManager) MaybeWriteLXDProfile(name string, profile lxdprofile.Profile) error {
+	if manager.client == nil {
+		var err error
+		manager.client, err = ConnectLocal()
+		if err != nil {
+			return errors.Annotatef(err, "failed to connect to local LXD")
+		}
+	}
+	logger.Debugf("writing lxd profile %q", name)
+	return errors.Annotatef(manager.client.WriteProfile(name, api.ProfilePut{
+		Config:      profile.Config,
+		Description: profile.Description,
+		Devices:     profile.Devices,
+	}), "writing lxd profile %q", name)
+}
+
+// ReplaceLXDProfiles is part of the container.LXDProfileManager
+// interface. Removed profiles that are no longer applied to any
+// container are deleted.
+func (manager *containerManager) ReplaceLXDProfiles(id instance.Id, remove, add []string) error {
+	if manager.client == nil {
+		var err error
+		manager.client, err = ConnectLocal()
+		if err != nil {
+			return errors.Annotatef(err, "failed to connect to local LXD")
+		}
+	}
+	logger.Infof("replacing profiles %v of container %q with %v", remove, id, add)
+	if err := manager.client.ReplaceContainerProfiles(string(id), remove, add); err != nil {
+		return errors.Annotatef(err, "replacing profiles of container %q", id)
+	}
+	inUse := set.NewStrings(add...)
+	for _, name := range remove {
+		if inUse.Contains(name) {
+			continue
+		}
+		// LXD refuses to delete profiles that are still applied to
+		// other containers, which is as it should be.
+		if err := manager.client.ProfileDelete(name); err != nil {
+			logger.Debugf("lxd profile %q not deleted: %v", name, err)
+		}
+	}
+	return nil
+}
+
 // HasLXDSupport returns false when this juju binary was not built with LXD
 // support (i.e. it was built on a golang version < 1.2
 func HasLXDSupport() bool {
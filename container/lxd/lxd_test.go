@@ -201,3 +201,10 @@ func (t *LxdSuite) TestNetworkDevicesWithParentDevice(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(result, jc.DeepEquals, expected)
 }
+
+func (t *LxdSuite) TestInstanceProfiles(c *gc.C) {
+	c.Check(lxd.InstanceProfiles(false, nil), jc.DeepEquals, []string{"default"})
+	c.Check(lxd.InstanceProfiles(true, nil), gc.HasLen, 0)
+	c.Check(lxd.InstanceProfiles(false, []string{"juju-model-app-1"}), jc.DeepEquals, []string{"default", "juju-model-app-1"})
+	c.Check(lxd.InstanceProfiles(true, []string{"juju-model-app-1"}), jc.DeepEquals, []string{"default", "juju-model-app-1"})
+}
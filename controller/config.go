@@ -6,15 +6,19 @@ package controller
 import (
 	"fmt"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/schema"
 	"github.com/juju/utils"
 	utilscert "github.com/juju/utils/cert"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon-bakery.v1/bakery"
 
 	"github.com/juju/juju/cert"
+	"github.com/juju/juju/core/maintenance"
+	"github.com/juju/juju/permission"
 )
 
 const (
@@ -22,6 +26,9 @@ const (
 	MongoProfLow = "low"
 	// MongoProfDefault represents the mongo memory profile shipped by default.
 	MongoProfDefault = "default"
+	// MongoProfAuto represents the mongo memory profile sized for the
+	// resources of each controller machine.
+	MongoProfAuto = "auto"
 )
 
 const (
@@ -35,6 +42,11 @@ const (
 	// StatePort is the port used for mongo connections.
 	StatePort = "state-port"
 
+	// GRPCPort is the port on which the controller serves its gRPC
+	// gateway, which exposes a curated subset of the API to gRPC
+	// clients. The gateway is not served if it is not set.
+	GRPCPort = "grpc-port"
+
 	// CACertKey is the key for the controller's CA certificate attribute.
 	CACertKey = "ca-cert"
 
@@ -68,10 +80,25 @@ const (
 	// they don't have any access rights to the controller itself.
 	AllowModelAccessKey = "allow-model-access"
 
+	// StickyModelRoutingKey sets whether the controller redirects
+	// users' API connections for a hosted model to the controller
+	// machine assigned to run that model's workers.
+	StickyModelRoutingKey = "sticky-model-routing"
+
 	// MongoMemoryProfile sets whether mongo uses the least possible memory or the
 	// detault
 	MongoMemoryProfile = "mongo-memory-profile"
 
+	// MongoCacheSize is the size of the WiredTiger cache of the
+	// controllers' mongo servers, eg "2G", overriding the size given
+	// by the memory profile.
+	MongoCacheSize = "mongo-cache-size"
+
+	// MongoOplogSize is the size of the oplog of the controllers'
+	// mongo servers, eg "4G", overriding the size given by the memory
+	// profile.
+	MongoOplogSize = "mongo-oplog-size"
+
 	// MaxLogsAge is the maximum age for log entries, ef "72h"
 	MaxLogsAge = "max-logs-age"
 
@@ -82,6 +109,94 @@ const (
 	// MaxTxnLogSize is the maximum size the of capped txn log collection, eg "10M"
 	MaxTxnLogSize = "max-txn-log-size"
 
+	// CrashReportQuota is the maximum total size of the agent crash
+	// reports stored for each model, eg "64M". The oldest reports are
+	// removed to make room for new ones.
+	CrashReportQuota = "crash-report-quota"
+
+	// DBCompactionWindowsKey sets the periods of the week, in UTC,
+	// within which controller database members may be compacted, in
+	// the form accepted by the maintenance-windows model config, eg
+	// "sun 02:00-05:00". Only secondary members are compacted, one
+	// at a time. If it is not set, the database is never compacted.
+	DBCompactionWindowsKey = "db-compaction-windows"
+
+	// BreakGlassUsersKey sets the users, as a comma-separated list of
+	// user names, who may temporarily elevate themselves to controller
+	// superuser by starting a break-glass session. If it is not set, no
+	// user may.
+	BreakGlassUsersKey = "break-glass-users"
+
+	// LDAPURLKey sets the URL of an LDAP or Active Directory server,
+	// eg "ldaps://ad.example.com:636", whose groups grant external
+	// users access to the controller and its models. If it is not
+	// set, groups grant no access. Connections to ldap URLs are
+	// secured with StartTLS.
+	LDAPURLKey = "ldap-url"
+
+	// LDAPBindDNKey sets the DN with which the controller binds to
+	// the LDAP server to look up users' groups. If it is not set,
+	// the controller binds anonymously.
+	LDAPBindDNKey = "ldap-bind-dn"
+
+	// LDAPBindPasswordKey sets the password for LDAPBindDNKey.
+	LDAPBindPasswordKey = "ldap-bind-password"
+
+	// LDAPBaseDNKey sets the DN under which users are looked up.
+	LDAPBaseDNKey = "ldap-base-dn"
+
+	// LDAPUserFilterKey sets the filter used to look up a user, with
+	// "%s" standing for the user's name, eg "(sAMAccountName=%s)".
+	LDAPUserFilterKey = "ldap-user-filter"
+
+	// LDAPUserDomainKey sets the domain of the external users whose
+	// groups are looked up on the LDAP server. Users in other domains
+	// are granted no access by LDAP groups.
+	LDAPUserDomainKey = "ldap-user-domain"
+
+	// LDAPGroupAccessKey maps LDAP groups, by common name, to the
+	// access their members have, as a comma-separated list of
+	// group=access pairs, eg "juju-admins=superuser,devs=write".
+	// Controller access levels apply to the controller, and model
+	// access levels to all of its models.
+	LDAPGroupAccessKey = "ldap-group-access"
+
+	// LDAPCacheTTLKey sets how long users' groups are cached before
+	// being looked up again, eg "5m".
+	LDAPCacheTTLKey = "ldap-cache-ttl"
+
+	// ObjectStoreTypeKey sets where the controller stores blobs such
+	// as charms, tools, resources and backups: "gridfs" (the
+	// default) stores them in the controller's database, while "s3"
+	// and "swift" store them in an external object store. MinIO and
+	// other S3 compatible stores are configured as "s3", with
+	// ObjectStoreEndpointKey set.
+	ObjectStoreTypeKey = "object-store-type"
+
+	// ObjectStoreEndpointKey sets the URL of the object store: the
+	// S3 endpoint, eg "https://minio.example.com:9000", or the Swift
+	// identity endpoint. If it is not set for S3, the AWS endpoint
+	// for ObjectStoreRegionKey is used.
+	ObjectStoreEndpointKey = "object-store-endpoint"
+
+	// ObjectStoreRegionKey sets the region of the object store.
+	ObjectStoreRegionKey = "object-store-region"
+
+	// ObjectStoreBucketKey sets the S3 bucket, or Swift container,
+	// that blobs are stored in. The bucket must already exist.
+	ObjectStoreBucketKey = "object-store-bucket"
+
+	// ObjectStoreAccessKeyKey sets the S3 access key, or the Swift
+	// user name, with which the object store is accessed.
+	ObjectStoreAccessKeyKey = "object-store-access-key"
+
+	// ObjectStoreSecretKeyKey sets the S3 secret key, or the Swift
+	// password, for ObjectStoreAccessKeyKey.
+	ObjectStoreSecretKeyKey = "object-store-secret-key"
+
+	// ObjectStoreTenantKey sets the Swift tenant (project) name.
+	ObjectStoreTenantKey = "object-store-tenant"
+
 	// Attribute Defaults
 
 	// DefaultAuditingEnabled contains the default value for the
@@ -99,7 +214,7 @@ const (
 	DefaultAPIPort int = 17070
 
 	// DefaultMongoMemoryProfile is the default profile used by mongo.
-	DefaultMongoMemoryProfile = MongoProfLow
+	DefaultMongoMemoryProfile = MongoProfAuto
 
 	// DefaultMaxLogsAgeDays is the maximum age in days of log entries.
 	DefaultMaxLogsAgeDays = 3
@@ -110,12 +225,41 @@ const (
 
 	// DefaultMaxTxnLogCollectionMB is the maximum size the txn log collection.
 	DefaultMaxTxnLogCollectionMB = 10 // 10 MB
+
+	// DefaultCrashReportQuotaMB is the maximum total size of the crash
+	// reports stored for each model, if no other size is configured.
+	DefaultCrashReportQuotaMB = 64
+
+	// DefaultLDAPUserFilter is the filter used to look up LDAP users
+	// if none is configured.
+	DefaultLDAPUserFilter = "(uid=%s)"
+
+	// DefaultLDAPUserDomain is the domain of the users whose LDAP
+	// groups are looked up if no other domain is configured.
+	DefaultLDAPUserDomain = "external"
+
+	// DefaultLDAPCacheTTL is how long users' LDAP groups are cached
+	// if no other duration is configured.
+	DefaultLDAPCacheTTL = 5 * time.Minute
+)
+
+const (
+	// ObjectStoreGridFS stores blobs in the controller's database.
+	ObjectStoreGridFS = "gridfs"
+
+	// ObjectStoreS3 stores blobs in an S3 compatible object store.
+	ObjectStoreS3 = "s3"
+
+	// ObjectStoreSwift stores blobs in an OpenStack Swift object
+	// store.
+	ObjectStoreSwift = "swift"
 )
 
 // ControllerOnlyConfigAttributes are attributes which are only relevant
 // for a controller, never a model.
 var ControllerOnlyConfigAttributes = []string{
 	AllowModelAccessKey,
+	StickyModelRoutingKey,
 	APIPort,
 	AutocertDNSNameKey,
 	AutocertURLKey,
@@ -125,10 +269,43 @@ var ControllerOnlyConfigAttributes = []string{
 	IdentityURL,
 	SetNUMAControlPolicyKey,
 	StatePort,
+	GRPCPort,
 	MongoMemoryProfile,
+	MongoCacheSize,
+	MongoOplogSize,
 	MaxLogsSize,
 	MaxLogsAge,
 	MaxTxnLogSize,
+	CrashReportQuota,
+	DBCompactionWindowsKey,
+	BreakGlassUsersKey,
+	LDAPURLKey,
+	LDAPBindDNKey,
+	LDAPBindPasswordKey,
+	LDAPBaseDNKey,
+	LDAPUserFilterKey,
+	LDAPUserDomainKey,
+	LDAPGroupAccessKey,
+	LDAPCacheTTLKey,
+	ObjectStoreTypeKey,
+	ObjectStoreEndpointKey,
+	ObjectStoreRegionKey,
+	ObjectStoreBucketKey,
+	ObjectStoreAccessKeyKey,
+	ObjectStoreSecretKeyKey,
+	ObjectStoreTenantKey,
+}
+
+// ObjectStoreConfigAttributes are the attributes that configure the
+// controller's object store.
+var ObjectStoreConfigAttributes = []string{
+	ObjectStoreTypeKey,
+	ObjectStoreEndpointKey,
+	ObjectStoreRegionKey,
+	ObjectStoreBucketKey,
+	ObjectStoreAccessKeyKey,
+	ObjectStoreSecretKeyKey,
+	ObjectStoreTenantKey,
 }
 
 // ControllerOnlyAttribute returns true if the specified attribute name
@@ -210,6 +387,17 @@ func (c Config) APIPort() int {
 	return c.mustInt(APIPort)
 }
 
+// GRPCPort returns the port on which the gRPC gateway is served, or
+// zero if it is not served.
+func (c Config) GRPCPort() int {
+	// Values obtained over the api are encoded as float64.
+	if value, ok := c[GRPCPort].(float64); ok {
+		return int(value)
+	}
+	value, _ := c[GRPCPort].(int)
+	return value
+}
+
 // AuditingEnabled returns whether or not auditing has been enabled
 // for the environment. The default is false.
 func (c Config) AuditingEnabled() bool {
@@ -270,12 +458,30 @@ func (c Config) IdentityPublicKey() *bakery.PublicKey {
 	return &pubKey
 }
 
-// MongoMemoryProfile returns the selected profile or low.
+// MongoMemoryProfile returns the selected profile or auto.
 func (c Config) MongoMemoryProfile() string {
 	if profile, ok := c[MongoMemoryProfile]; ok {
 		return profile.(string)
 	}
-	return MongoProfLow
+	return DefaultMongoMemoryProfile
+}
+
+// MongoCacheSizeMB returns the size in MiB of the WiredTiger cache of
+// the controllers' mongo servers, or zero if the memory profile
+// decides it.
+func (c Config) MongoCacheSizeMB() int {
+	// Value has already been validated.
+	val, _ := utils.ParseSize(c.asString(MongoCacheSize))
+	return int(val)
+}
+
+// MongoOplogSizeMB returns the size in MiB of the oplog of the
+// controllers' mongo servers, or zero if the memory profile decides
+// it.
+func (c Config) MongoOplogSizeMB() int {
+	// Value has already been validated.
+	val, _ := utils.ParseSize(c.asString(MongoOplogSize))
+	return int(val)
 }
 
 // NUMACtlPreference returns if numactl is preferred.
@@ -294,6 +500,14 @@ func (c Config) AllowModelAccess() bool {
 	return value
 }
 
+// StickyModelRouting reports whether users' API connections for a
+// hosted model are redirected to the controller machine assigned to
+// run the model's workers.
+func (c Config) StickyModelRouting() bool {
+	value, _ := c[StickyModelRoutingKey].(bool)
+	return value
+}
+
 // MaxLogsAge is the maximum age of log entries before they are pruned.
 func (c Config) MaxLogsAge() time.Duration {
 	// Value has already been validated.
@@ -316,6 +530,181 @@ func (c Config) MaxTxnLogSizeMB() int {
 	return int(val)
 }
 
+// CrashReportQuotaMB is the maximum total size in MiB of the agent
+// crash reports stored for each model.
+func (c Config) CrashReportQuotaMB() int {
+	v := c.asString(CrashReportQuota)
+	if v == "" {
+		return DefaultCrashReportQuotaMB
+	}
+	// Value has already been validated.
+	val, _ := utils.ParseSize(v)
+	return int(val)
+}
+
+// DBCompactionWindows returns the periods within which controller
+// database members may be compacted, or "" if they may not be. See
+// DBCompactionWindowsKey for more details.
+func (c Config) DBCompactionWindows() string {
+	return c.asString(DBCompactionWindowsKey)
+}
+
+// BreakGlassUsers returns the names of the users who may start
+// break-glass sessions. See BreakGlassUsersKey for more details.
+func (c Config) BreakGlassUsers() []string {
+	var users []string
+	for _, user := range strings.Split(c.asString(BreakGlassUsersKey), ",") {
+		if user = strings.TrimSpace(user); user != "" {
+			users = append(users, user)
+		}
+	}
+	return users
+}
+
+// LDAPURL returns the URL of the LDAP server whose groups grant
+// access, or "" if there is none. See LDAPURLKey for more details.
+func (c Config) LDAPURL() string {
+	return c.asString(LDAPURLKey)
+}
+
+// LDAPBindDN returns the DN with which to bind to the LDAP server.
+func (c Config) LDAPBindDN() string {
+	return c.asString(LDAPBindDNKey)
+}
+
+// LDAPBindPassword returns the password for LDAPBindDN.
+func (c Config) LDAPBindPassword() string {
+	return c.asString(LDAPBindPasswordKey)
+}
+
+// LDAPBaseDN returns the DN under which LDAP users are looked up.
+func (c Config) LDAPBaseDN() string {
+	return c.asString(LDAPBaseDNKey)
+}
+
+// LDAPUserFilter returns the filter used to look up LDAP users.
+func (c Config) LDAPUserFilter() string {
+	if filter := c.asString(LDAPUserFilterKey); filter != "" {
+		return filter
+	}
+	return DefaultLDAPUserFilter
+}
+
+// LDAPUserDomain returns the domain of the users whose LDAP groups are
+// looked up.
+func (c Config) LDAPUserDomain() string {
+	if domain := c.asString(LDAPUserDomainKey); domain != "" {
+		return domain
+	}
+	return DefaultLDAPUserDomain
+}
+
+// LDAPGroupAccess returns the access granted to the members of LDAP
+// groups, keyed on group common name.
+func (c Config) LDAPGroupAccess() map[string]permission.Access {
+	// Value has already been validated.
+	access, _ := parseGroupAccess(c.asString(LDAPGroupAccessKey))
+	return access
+}
+
+// LDAPCacheTTL returns how long users' LDAP groups are cached.
+func (c Config) LDAPCacheTTL() time.Duration {
+	if v := c.asString(LDAPCacheTTLKey); v != "" {
+		// Value has already been validated.
+		val, _ := time.ParseDuration(v)
+		return val
+	}
+	return DefaultLDAPCacheTTL
+}
+
+// ObjectStoreConfig holds the configuration of the controller's
+// object store.
+type ObjectStoreConfig struct {
+	Type      string
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	Tenant    string
+}
+
+// Validate returns an error if the object store configuration is not
+// valid.
+func (c ObjectStoreConfig) Validate() error {
+	switch c.Type {
+	case ObjectStoreGridFS:
+		return nil
+	case ObjectStoreS3:
+		if c.Endpoint == "" && c.Region == "" {
+			return errors.Errorf("%s or %s must be set for S3", ObjectStoreEndpointKey, ObjectStoreRegionKey)
+		}
+	case ObjectStoreSwift:
+		if c.Endpoint == "" {
+			return errors.Errorf("%s must be set for Swift", ObjectStoreEndpointKey)
+		}
+	default:
+		return errors.NotValidf("object store type %q", c.Type)
+	}
+	if c.Endpoint != "" {
+		u, err := url.Parse(c.Endpoint)
+		if err != nil {
+			return errors.Annotate(err, "invalid object store endpoint")
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.Errorf("object store endpoint needs to be http or https, got %q", c.Endpoint)
+		}
+	}
+	if c.Bucket == "" {
+		return errors.Errorf("%s must be set", ObjectStoreBucketKey)
+	}
+	if c.AccessKey == "" || c.SecretKey == "" {
+		return errors.Errorf("%s and %s must be set", ObjectStoreAccessKeyKey, ObjectStoreSecretKeyKey)
+	}
+	return nil
+}
+
+// ObjectStore returns the configuration of the controller's object
+// store. See ObjectStoreTypeKey for more details.
+func (c Config) ObjectStore() ObjectStoreConfig {
+	storeType := c.asString(ObjectStoreTypeKey)
+	if storeType == "" {
+		storeType = ObjectStoreGridFS
+	}
+	return ObjectStoreConfig{
+		Type:      storeType,
+		Endpoint:  c.asString(ObjectStoreEndpointKey),
+		Region:    c.asString(ObjectStoreRegionKey),
+		Bucket:    c.asString(ObjectStoreBucketKey),
+		AccessKey: c.asString(ObjectStoreAccessKeyKey),
+		SecretKey: c.asString(ObjectStoreSecretKeyKey),
+		Tenant:    c.asString(ObjectStoreTenantKey),
+	}
+}
+
+// parseGroupAccess parses a mapping of group names to access levels,
+// as described for LDAPGroupAccessKey.
+func parseGroupAccess(s string) (map[string]permission.Access, error) {
+	result := make(map[string]permission.Access)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		i := strings.LastIndex(pair, "=")
+		if i <= 0 {
+			return nil, errors.Errorf("expected group=access, got %q", pair)
+		}
+		group := strings.TrimSpace(pair[:i])
+		access := permission.Access(strings.TrimSpace(pair[i+1:]))
+		if permission.ValidateControllerAccess(access) != nil && permission.ValidateModelAccess(access) != nil {
+			return nil, errors.Errorf("invalid access %q for group %q", access, group)
+		}
+		result[group] = access
+	}
+	return result, nil
+}
+
 // Validate ensures that config is a valid configuration.
 func Validate(c Config) error {
 	if v, ok := c[IdentityPublicKey].(string); ok {
@@ -351,9 +740,32 @@ func Validate(c Config) error {
 		return errors.Errorf("controller-uuid: expected UUID, got string(%q)", uuid)
 	}
 
+	if v, ok := c[GRPCPort].(int); ok {
+		if v <= 0 || v > 65535 {
+			return errors.Errorf("grpc-port: expected port number, got %d", v)
+		}
+		for _, name := range []string{APIPort, StatePort} {
+			if port, _ := c[name].(int); port == v {
+				return errors.Errorf("grpc-port: port %d already used for %s", v, name)
+			}
+		}
+	}
+
 	if mgoMemProfile, ok := c[MongoMemoryProfile].(string); ok {
-		if mgoMemProfile != MongoProfLow && mgoMemProfile != MongoProfDefault {
-			return errors.Errorf("mongo-memory-profile: expected one of %s or %s got string(%q)", MongoProfLow, MongoProfDefault, mgoMemProfile)
+		if mgoMemProfile != MongoProfLow && mgoMemProfile != MongoProfDefault && mgoMemProfile != MongoProfAuto {
+			return errors.Errorf("mongo-memory-profile: expected one of %s, %s or %s got string(%q)", MongoProfLow, MongoProfDefault, MongoProfAuto, mgoMemProfile)
+		}
+	}
+
+	if v, ok := c[MongoCacheSize].(string); ok && v != "" {
+		if _, err := utils.ParseSize(v); err != nil {
+			return errors.Annotate(err, "invalid mongo cache size in configuration")
+		}
+	}
+
+	if v, ok := c[MongoOplogSize].(string); ok && v != "" {
+		if _, err := utils.ParseSize(v); err != nil {
+			return errors.Annotate(err, "invalid mongo oplog size in configuration")
 		}
 	}
 
@@ -375,6 +787,56 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[CrashReportQuota].(string); ok {
+		if _, err := utils.ParseSize(v); err != nil {
+			return errors.Annotate(err, "invalid crash report quota in configuration")
+		}
+	}
+
+	if v, ok := c[DBCompactionWindowsKey].(string); ok && v != "" {
+		if _, err := maintenance.ParsePeriods(v); err != nil {
+			return errors.Annotate(err, "invalid database compaction windows")
+		}
+	}
+
+	for _, user := range c.BreakGlassUsers() {
+		if !names.IsValidUser(user) {
+			return errors.Errorf("invalid break-glass user name %q", user)
+		}
+	}
+
+	if v, ok := c[LDAPURLKey].(string); ok && v != "" {
+		u, err := url.Parse(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid LDAP URL")
+		}
+		if u.Scheme != "ldap" && u.Scheme != "ldaps" {
+			return errors.Errorf("LDAP URL needs to be ldap or ldaps, got %q", v)
+		}
+	}
+
+	if v, ok := c[LDAPUserDomainKey].(string); ok && v != "" {
+		if !names.IsValidUser("user@" + v) {
+			return errors.Errorf("invalid LDAP user domain %q", v)
+		}
+	}
+
+	if v, ok := c[LDAPGroupAccessKey].(string); ok {
+		if _, err := parseGroupAccess(v); err != nil {
+			return errors.Annotate(err, "invalid LDAP group access")
+		}
+	}
+
+	if v, ok := c[LDAPCacheTTLKey].(string); ok {
+		if _, err := time.ParseDuration(v); err != nil {
+			return errors.Annotate(err, "invalid LDAP cache TTL")
+		}
+	}
+
+	if err := c.ObjectStore().Validate(); err != nil {
+		return errors.Annotate(err, "invalid object store configuration")
+	}
+
 	return nil
 }
 
@@ -388,28 +850,72 @@ var configChecker = schema.FieldMap(schema.Fields{
 	AuditingEnabled:         schema.Bool(),
 	APIPort:                 schema.ForceInt(),
 	StatePort:               schema.ForceInt(),
+	GRPCPort:                schema.ForceInt(),
 	IdentityURL:             schema.String(),
 	IdentityPublicKey:       schema.String(),
 	SetNUMAControlPolicyKey: schema.Bool(),
 	AutocertURLKey:          schema.String(),
 	AutocertDNSNameKey:      schema.String(),
 	AllowModelAccessKey:     schema.Bool(),
+	StickyModelRoutingKey:   schema.Bool(),
 	MongoMemoryProfile:      schema.String(),
+	MongoCacheSize:          schema.String(),
+	MongoOplogSize:          schema.String(),
 	MaxLogsAge:              schema.String(),
 	MaxLogsSize:             schema.String(),
 	MaxTxnLogSize:           schema.String(),
+	CrashReportQuota:        schema.String(),
+	DBCompactionWindowsKey:  schema.String(),
+	BreakGlassUsersKey:      schema.String(),
+	LDAPURLKey:              schema.String(),
+	LDAPBindDNKey:           schema.String(),
+	LDAPBindPasswordKey:     schema.String(),
+	LDAPBaseDNKey:           schema.String(),
+	LDAPUserFilterKey:       schema.String(),
+	LDAPUserDomainKey:       schema.String(),
+	LDAPGroupAccessKey:      schema.String(),
+	LDAPCacheTTLKey:         schema.String(),
+	ObjectStoreTypeKey:      schema.String(),
+	ObjectStoreEndpointKey:  schema.String(),
+	ObjectStoreRegionKey:    schema.String(),
+	ObjectStoreBucketKey:    schema.String(),
+	ObjectStoreAccessKeyKey: schema.String(),
+	ObjectStoreSecretKeyKey: schema.String(),
+	ObjectStoreTenantKey:    schema.String(),
 }, schema.Defaults{
 	APIPort:                 DefaultAPIPort,
 	AuditingEnabled:         DefaultAuditingEnabled,
 	StatePort:               DefaultStatePort,
+	GRPCPort:                schema.Omit,
 	IdentityURL:             schema.Omit,
 	IdentityPublicKey:       schema.Omit,
 	SetNUMAControlPolicyKey: DefaultNUMAControlPolicy,
 	AutocertURLKey:          schema.Omit,
 	AutocertDNSNameKey:      schema.Omit,
 	AllowModelAccessKey:     schema.Omit,
+	StickyModelRoutingKey:   schema.Omit,
 	MongoMemoryProfile:      schema.Omit,
+	MongoCacheSize:          schema.Omit,
+	MongoOplogSize:          schema.Omit,
 	MaxLogsAge:              fmt.Sprintf("%vh", DefaultMaxLogsAgeDays*24),
 	MaxLogsSize:             fmt.Sprintf("%vM", DefaultMaxLogCollectionMB),
 	MaxTxnLogSize:           fmt.Sprintf("%vM", DefaultMaxTxnLogCollectionMB),
+	CrashReportQuota:        schema.Omit,
+	DBCompactionWindowsKey:  schema.Omit,
+	BreakGlassUsersKey:      schema.Omit,
+	LDAPURLKey:              schema.Omit,
+	LDAPBindDNKey:           schema.Omit,
+	LDAPBindPasswordKey:     schema.Omit,
+	LDAPBaseDNKey:           schema.Omit,
+	LDAPUserFilterKey:       schema.Omit,
+	LDAPUserDomainKey:       schema.Omit,
+	LDAPGroupAccessKey:      schema.Omit,
+	LDAPCacheTTLKey:         schema.Omit,
+	ObjectStoreTypeKey:      schema.Omit,
+	ObjectStoreEndpointKey:  schema.Omit,
+	ObjectStoreRegionKey:    schema.Omit,
+	ObjectStoreBucketKey:    schema.Omit,
+	ObjectStoreAccessKeyKey: schema.Omit,
+	ObjectStoreSecretKeyKey: schema.Omit,
+	ObjectStoreTenantKey:    schema.Omit,
 })
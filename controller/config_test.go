@@ -15,6 +15,7 @@ import (
 
 	"github.com/juju/juju/cert"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/testing"
 )
 
@@ -121,6 +122,118 @@ var validateTests = []struct {
 		controller.CACertKey:         testing.CACert,
 	},
 	expectError: `invalid identity public key: wrong length for base64 key, got 3 want 32`,
+}, {
+	about: "gRPC port out of range",
+	config: controller.Config{
+		controller.GRPCPort:  70000,
+		controller.CACertKey: testing.CACert,
+	},
+	expectError: `grpc-port: expected port number, got 70000`,
+}, {
+	about: "gRPC port same as API port",
+	config: controller.Config{
+		controller.GRPCPort:  17070,
+		controller.APIPort:   17070,
+		controller.CACertKey: testing.CACert,
+	},
+	expectError: `grpc-port: port 17070 already used for api-port`,
+}, {
+	about: "invalid database compaction windows",
+	config: controller.Config{
+		controller.DBCompactionWindowsKey: "sun 02:00",
+		controller.CACertKey:              testing.CACert,
+	},
+	expectError: `invalid database compaction windows: times "02:00" in maintenance window "sun 02:00" not valid`,
+}, {
+	about: "invalid break-glass user",
+	config: controller.Config{
+		controller.BreakGlassUsersKey: "bob, not a user",
+		controller.CACertKey:          testing.CACert,
+	},
+	expectError: `invalid break-glass user name "not a user"`,
+}, {
+	about: "LDAP URL must be ldap or ldaps",
+	config: controller.Config{
+		controller.LDAPURLKey: "https://ad.example.com",
+		controller.CACertKey:  testing.CACert,
+	},
+	expectError: `LDAP URL needs to be ldap or ldaps, got "https://ad.example.com"`,
+}, {
+	about: "invalid LDAP group access",
+	config: controller.Config{
+		controller.LDAPGroupAccessKey: "juju-admins=root",
+		controller.CACertKey:          testing.CACert,
+	},
+	expectError: `invalid LDAP group access: invalid access "root" for group "juju-admins"`,
+}, {
+	about: "invalid LDAP user domain",
+	config: controller.Config{
+		controller.LDAPUserDomainKey: "not a domain",
+		controller.CACertKey:         testing.CACert,
+	},
+	expectError: `invalid LDAP user domain "not a domain"`,
+}, {
+	about: "LDAP group access without access",
+	config: controller.Config{
+		controller.LDAPGroupAccessKey: "juju-admins",
+		controller.CACertKey:          testing.CACert,
+	},
+	expectError: `invalid LDAP group access: expected group=access, got "juju-admins"`,
+}, {
+	about: "invalid LDAP cache TTL",
+	config: controller.Config{
+		controller.LDAPCacheTTLKey: "forever",
+		controller.CACertKey:       testing.CACert,
+	},
+	expectError: `invalid LDAP cache TTL: .*`,
+}, {
+	about: "unknown object store type",
+	config: controller.Config{
+		controller.ObjectStoreTypeKey: "ceph",
+		controller.CACertKey:          testing.CACert,
+	},
+	expectError: `invalid object store configuration: object store type "ceph" not valid`,
+}, {
+	about: "S3 object store without endpoint or region",
+	config: controller.Config{
+		controller.ObjectStoreTypeKey:      "s3",
+		controller.ObjectStoreBucketKey:    "juju",
+		controller.ObjectStoreAccessKeyKey: "access",
+		controller.ObjectStoreSecretKeyKey: "secret",
+		controller.CACertKey:               testing.CACert,
+	},
+	expectError: `invalid object store configuration: object-store-endpoint or object-store-region must be set for S3`,
+}, {
+	about: "object store without bucket",
+	config: controller.Config{
+		controller.ObjectStoreTypeKey:      "swift",
+		controller.ObjectStoreEndpointKey:  "https://keystone.example.com:5000/v2.0",
+		controller.ObjectStoreAccessKeyKey: "user",
+		controller.ObjectStoreSecretKeyKey: "password",
+		controller.CACertKey:               testing.CACert,
+	},
+	expectError: `invalid object store configuration: object-store-bucket must be set`,
+}, {
+	about: "object store endpoint must be http or https",
+	config: controller.Config{
+		controller.ObjectStoreTypeKey:      "s3",
+		controller.ObjectStoreEndpointKey:  "ftp://minio.example.com",
+		controller.ObjectStoreBucketKey:    "juju",
+		controller.ObjectStoreAccessKeyKey: "access",
+		controller.ObjectStoreSecretKeyKey: "secret",
+		controller.CACertKey:               testing.CACert,
+	},
+	expectError: `invalid object store configuration: object store endpoint needs to be http or https, got "ftp://minio.example.com"`,
+}, {
+	about: "MinIO object store",
+	config: controller.Config{
+		controller.ObjectStoreTypeKey:      "s3",
+		controller.ObjectStoreEndpointKey:  "http://10.0.0.1:9000",
+		controller.ObjectStoreBucketKey:    "juju",
+		controller.ObjectStoreAccessKeyKey: "access",
+		controller.ObjectStoreSecretKeyKey: "secret",
+		controller.CACertKey:               testing.CACert,
+	},
 }}
 
 func (s *ConfigSuite) TestValidate(c *gc.C) {
@@ -173,3 +286,186 @@ func (s *ConfigSuite) TestTxnLogConfigValue(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(cfg.MaxTxnLogSizeMB(), gc.Equals, 8192)
 }
+
+func (s *ConfigSuite) TestCrashReportQuota(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.CrashReportQuotaMB(), gc.Equals, 64)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"crash-report-quota": "1G",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.CrashReportQuotaMB(), gc.Equals, 1024)
+
+	_, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"crash-report-quota": "lots",
+		},
+	)
+	c.Assert(err, gc.ErrorMatches, "invalid crash report quota in configuration: .*")
+}
+
+func (s *ConfigSuite) TestMongoSizes(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.MongoMemoryProfile(), gc.Equals, controller.MongoProfAuto)
+	c.Assert(cfg.MongoCacheSizeMB(), gc.Equals, 0)
+	c.Assert(cfg.MongoOplogSizeMB(), gc.Equals, 0)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"mongo-cache-size": "2G",
+			"mongo-oplog-size": "4096M",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.MongoCacheSizeMB(), gc.Equals, 2048)
+	c.Assert(cfg.MongoOplogSizeMB(), gc.Equals, 4096)
+
+	_, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"mongo-oplog-size": "lots",
+		},
+	)
+	c.Assert(err, gc.ErrorMatches, "invalid mongo oplog size in configuration: .*")
+}
+
+func (s *ConfigSuite) TestStickyModelRouting(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.StickyModelRouting(), jc.IsFalse)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"sticky-model-routing": true,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.StickyModelRouting(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestGRPCPort(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.GRPCPort(), gc.Equals, 0)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"grpc-port": "17071",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.GRPCPort(), gc.Equals, 17071)
+}
+
+func (s *ConfigSuite) TestDBCompactionWindows(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.DBCompactionWindows(), gc.Equals, "")
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"db-compaction-windows": "sun 02:00-05:00",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.DBCompactionWindows(), gc.Equals, "sun 02:00-05:00")
+}
+
+func (s *ConfigSuite) TestBreakGlassUsers(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BreakGlassUsers(), gc.HasLen, 0)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"break-glass-users": "bob, mary@external,",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BreakGlassUsers(), jc.DeepEquals, []string{"bob", "mary@external"})
+}
+
+func (s *ConfigSuite) TestLDAPConfigDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.LDAPURL(), gc.Equals, "")
+	c.Assert(cfg.LDAPUserFilter(), gc.Equals, "(uid=%s)")
+	c.Assert(cfg.LDAPUserDomain(), gc.Equals, "external")
+	c.Assert(cfg.LDAPGroupAccess(), gc.HasLen, 0)
+	c.Assert(cfg.LDAPCacheTTL(), gc.Equals, 5*time.Minute)
+}
+
+func (s *ConfigSuite) TestLDAPConfigValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"ldap-url":          "ldaps://ad.example.com:636",
+			"ldap-base-dn":      "dc=example,dc=com",
+			"ldap-user-filter":  "(sAMAccountName=%s)",
+			"ldap-user-domain":  "corp",
+			"ldap-group-access": "juju-admins=superuser, devs=write",
+			"ldap-cache-ttl":    "1m",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.LDAPURL(), gc.Equals, "ldaps://ad.example.com:636")
+	c.Assert(cfg.LDAPBaseDN(), gc.Equals, "dc=example,dc=com")
+	c.Assert(cfg.LDAPUserFilter(), gc.Equals, "(sAMAccountName=%s)")
+	c.Assert(cfg.LDAPUserDomain(), gc.Equals, "corp")
+	c.Assert(cfg.LDAPGroupAccess(), jc.DeepEquals, map[string]permission.Access{
+		"juju-admins": permission.SuperuserAccess,
+		"devs":        permission.WriteAccess,
+	})
+	c.Assert(cfg.LDAPCacheTTL(), gc.Equals, time.Minute)
+}
+
+func (s *ConfigSuite) TestObjectStoreDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.ObjectStore(), jc.DeepEquals, controller.ObjectStoreConfig{
+		Type: controller.ObjectStoreGridFS,
+	})
+}
+
+func (s *ConfigSuite) TestObjectStoreValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"object-store-type":       "s3",
+			"object-store-region":     "eu-west-1",
+			"object-store-bucket":     "juju-blobs",
+			"object-store-access-key": "access",
+			"object-store-secret-key": "secret",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.ObjectStore(), jc.DeepEquals, controller.ObjectStoreConfig{
+		Type:      controller.ObjectStoreS3,
+		Region:    "eu-west-1",
+		Bucket:    "juju-blobs",
+		AccessKey: "access",
+		SecretKey: "secret",
+	})
+}
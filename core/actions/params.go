@@ -0,0 +1,147 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actions
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/gojsonschema"
+	"gopkg.in/juju/charm.v6"
+)
+
+// FieldError describes why the value given for one of an action's
+// parameters is not valid.
+type FieldError struct {
+	// Field is the path to the parameter, with the names of nested
+	// parameters separated by dots. It is empty if the problem is
+	// with the parameters as a whole.
+	Field string
+
+	// Message describes the problem.
+	Message string
+}
+
+// ParamsError is returned when the parameters given for an action do
+// not match the schema that its charm defines for them.
+type ParamsError struct {
+	// Action is the name of the action.
+	Action string
+
+	// Fields describes each of the problems found.
+	Fields []FieldError
+
+	message string
+}
+
+// Error is part of the error interface.
+func (e *ParamsError) Error() string {
+	return e.message
+}
+
+// IsParamsError reports whether the cause of err is a *ParamsError.
+func IsParamsError(err error) bool {
+	_, ok := errors.Cause(err).(*ParamsError)
+	return ok
+}
+
+var (
+	missingPropertyRE    = regexp.MustCompile(`^"([^"]+)" property is missing and required`)
+	additionalPropertyRE = regexp.MustCompile(`^Additional property (\S+) is not allowed`)
+)
+
+func newParamsError(action string, result *gojsonschema.Result) *ParamsError {
+	err := &ParamsError{Action: action}
+	var messages []string
+	for _, resultErr := range result.Errors() {
+		field := strings.TrimPrefix(strings.TrimPrefix(resultErr.Context.String(), "(root)"), ".")
+		for _, re := range []*regexp.Regexp{missingPropertyRE, additionalPropertyRE} {
+			if match := re.FindStringSubmatch(resultErr.Description); match != nil {
+				field = strings.TrimPrefix(field+"."+match[1], ".")
+			}
+		}
+		err.Fields = append(err.Fields, FieldError{
+			Field:   field,
+			Message: resultErr.Description,
+		})
+		messages = append(messages, resultErr.String())
+	}
+	// The message matches that of charm.ActionSpec.ValidateParams,
+	// which is what the uniter reports for the same problems.
+	err.message = "validation failed: " + strings.Join(messages, "; ")
+	return err
+}
+
+// PrepareParams checks the parameters given for the named action
+// against the schema in its spec, and returns them with the defaults
+// from the schema inserted. Values given as strings for parameters
+// that the schema types as integers, numbers or booleans are converted
+// first, so that clients need not know the types of the parameters
+// they pass on. If the parameters are not valid, a *ParamsError is
+// returned describing each of the problems.
+func PrepareParams(name string, spec charm.ActionSpec, params map[string]interface{}) (map[string]interface{}, error) {
+	params = coerceObject(spec.Params, params)
+	result, err := gojsonschema.Validate(
+		gojsonschema.NewGoLoader(spec.Params),
+		gojsonschema.NewGoLoader(params),
+	)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot validate parameters for action %q", name)
+	}
+	if !result.Valid() {
+		return nil, newParamsError(name, result)
+	}
+	withDefaults, err := spec.InsertDefaults(params)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot insert defaults for action %q", name)
+	}
+	return withDefaults, nil
+}
+
+// coerceObject returns a copy of the parameters, with each value
+// converted, where possible, to the type its property's schema gives.
+func coerceObject(schema map[string]interface{}, params map[string]interface{}) map[string]interface{} {
+	properties, _ := schema["properties"].(map[string]interface{})
+	if params == nil || len(properties) == 0 {
+		return params
+	}
+	result := make(map[string]interface{}, len(params))
+	for name, value := range params {
+		if property, ok := properties[name].(map[string]interface{}); ok {
+			value = coerceValue(property, value)
+		}
+		result[name] = value
+	}
+	return result
+}
+
+func coerceValue(schema map[string]interface{}, value interface{}) interface{} {
+	kind, _ := schema["type"].(string)
+	switch value := value.(type) {
+	case string:
+		text := strings.TrimSpace(value)
+		switch kind {
+		case "integer":
+			if i, err := strconv.ParseInt(text, 10, 64); err == nil {
+				return i
+			}
+		case "number":
+			if f, err := strconv.ParseFloat(text, 64); err == nil && !math.IsInf(f, 0) && !math.IsNaN(f) {
+				return f
+			}
+		case "boolean":
+			if b, err := strconv.ParseBool(text); err == nil {
+				return b
+			}
+		}
+	case map[string]interface{}:
+		if kind == "object" {
+			return coerceObject(schema, value)
+		}
+	}
+	return value
+}
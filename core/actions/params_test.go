@@ -0,0 +1,123 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actions_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/core/actions"
+)
+
+type paramsSuite struct{}
+
+var _ = gc.Suite(&paramsSuite{})
+
+var backupSpec = charm.ActionSpec{
+	Description: "take a backup",
+	Params: map[string]interface{}{
+		"type":     "object",
+		"required": []interface{}{"target"},
+		"properties": map[string]interface{}{
+			"target": map[string]interface{}{
+				"type": "string",
+			},
+			"retries": map[string]interface{}{
+				"type":    "integer",
+				"default": 3,
+			},
+			"ratio": map[string]interface{}{
+				"type": "number",
+			},
+			"compress": map[string]interface{}{
+				"type":    "boolean",
+				"default": false,
+			},
+			"remote": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"port": map[string]interface{}{
+						"type": "integer",
+					},
+				},
+			},
+		},
+		"additionalProperties": false,
+	},
+}
+
+func (s *paramsSuite) TestPrepareParamsInsertsDefaults(c *gc.C) {
+	params, err := actions.PrepareParams("backup", backupSpec, map[string]interface{}{
+		"target": "/srv/backups",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(params, jc.DeepEquals, map[string]interface{}{
+		"target":   "/srv/backups",
+		"retries":  3,
+		"compress": false,
+	})
+}
+
+func (s *paramsSuite) TestPrepareParamsCoercesStrings(c *gc.C) {
+	given := map[string]interface{}{
+		"target":   "42",
+		"retries":  " 5 ",
+		"ratio":    "0.5",
+		"compress": "true",
+		"remote": map[string]interface{}{
+			"port": "2222",
+		},
+	}
+	params, err := actions.PrepareParams("backup", backupSpec, given)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(params, jc.DeepEquals, map[string]interface{}{
+		"target":   "42",
+		"retries":  int64(5),
+		"ratio":    0.5,
+		"compress": true,
+		"remote": map[string]interface{}{
+			"port": int64(2222),
+		},
+	})
+	// The parameters given are left untouched.
+	c.Assert(given["retries"], gc.Equals, " 5 ")
+}
+
+func (s *paramsSuite) TestPrepareParamsFieldErrors(c *gc.C) {
+	_, err := actions.PrepareParams("backup", backupSpec, map[string]interface{}{
+		"retries": "several",
+		"remote": map[string]interface{}{
+			"port": "ssh",
+		},
+		"colour": "blue",
+	})
+	c.Assert(err, jc.Satisfies, actions.IsParamsError)
+	c.Assert(err, gc.ErrorMatches, `validation failed: .*`)
+	paramsErr := err.(*actions.ParamsError)
+	c.Assert(paramsErr.Action, gc.Equals, "backup")
+
+	fields := make(map[string]string)
+	for _, field := range paramsErr.Fields {
+		fields[field.Field] = field.Message
+	}
+	c.Assert(fields, jc.DeepEquals, map[string]string{
+		"target":      `"target" property is missing and required`,
+		"retries":     "must be of type integer",
+		"remote.port": "must be of type integer",
+		"colour":      "Additional property colour is not allowed",
+	})
+}
+
+func (s *paramsSuite) TestPrepareParamsNoProperties(c *gc.C) {
+	spec := charm.ActionSpec{
+		Description: "restart",
+		Params: map[string]interface{}{
+			"type": "object",
+		},
+	}
+	params, err := actions.PrepareParams("restart", spec, map[string]interface{}{"now": "true"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(params, jc.DeepEquals, map[string]interface{}{"now": "true"})
+}
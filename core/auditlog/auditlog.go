@@ -32,6 +32,12 @@ type Conversation struct {
 	ModelUUID      string `json:"model-uuid"`
 	ConversationID string `json:"conversation-id"` // uint64 in hex
 	ConnectionID   string `json:"connection-id"`   // uint64 in hex (using %X to match the value in log files)
+
+	// BreakGlassSession and BreakGlassReason identify the break-glass
+	// session, if any, during which the user was elevated to
+	// controller superuser, and why.
+	BreakGlassSession string `json:"break-glass-session,omitempty"`
+	BreakGlassReason  string `json:"break-glass-reason,omitempty"`
 }
 
 // ConversationArgs is the information needed to create a method recorder.
@@ -42,6 +48,12 @@ type ConversationArgs struct {
 	ModelName    string
 	ModelUUID    string
 	ConnectionID uint64
+
+	// BreakGlassSession and BreakGlassReason, if set, tag the
+	// conversation and all of its requests as made during a
+	// break-glass session.
+	BreakGlassSession string
+	BreakGlassReason  string
 }
 
 // Request represents a call to an API facade made as part of
@@ -54,6 +66,10 @@ type Request struct {
 	Method         string `json:"method"`
 	Version        int    `json:"version"`
 	Args           string `json:"args,omitempty"`
+
+	// BreakGlassSession identifies the break-glass session, if any,
+	// during which the call was made.
+	BreakGlassSession string `json:"break-glass-session,omitempty"`
 }
 
 // RequestArgs is the information about an API call that we want to
@@ -107,9 +123,10 @@ type AuditLog interface {
 
 // Recorder records method calls for a specific API connection.
 type Recorder struct {
-	log          AuditLog
-	connectionID string
-	callID       string
+	log               AuditLog
+	connectionID      string
+	callID            string
+	breakGlassSession string
 }
 
 // NewRecorder creates a Recorder for the connection described (and
@@ -125,14 +142,18 @@ func NewRecorder(log AuditLog, c ConversationArgs) (*Recorder, error) {
 		When:           c.When.Format(time.RFC3339),
 		ModelName:      c.ModelName,
 		ModelUUID:      c.ModelUUID,
+
+		BreakGlassSession: c.BreakGlassSession,
+		BreakGlassReason:  c.BreakGlassReason,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &Recorder{
-		log:          log,
-		callID:       callID,
-		connectionID: connectionID,
+		log:               log,
+		callID:            callID,
+		connectionID:      connectionID,
+		breakGlassSession: c.BreakGlassSession,
 	}, nil
 }
 
@@ -146,6 +167,8 @@ func (r *Recorder) AddRequest(m RequestArgs) error {
 		Method:         m.Method,
 		Version:        m.Version,
 		Args:           m.Args,
+
+		BreakGlassSession: r.breakGlassSession,
 	}))
 }
 
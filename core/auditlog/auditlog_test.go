@@ -131,6 +131,46 @@ func (s *AuditLogSuite) TestRecorder(c *gc.C) {
 	})
 }
 
+func (s *AuditLogSuite) TestRecorderBreakGlass(c *gc.C) {
+	var log fakeLog
+	rec, err := auditlog.NewRecorder(&log, auditlog.ConversationArgs{
+		Who:               "bob",
+		When:              time.Date(2018, 5, 1, 9, 0, 0, 0, time.UTC),
+		ConnectionID:      12,
+		BreakGlassSession: "deadbeef",
+		BreakGlassReason:  "controller down",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = rec.AddRequest(auditlog.RequestArgs{
+		RequestID: 1,
+		Facade:    "Controller",
+		Method:    "AllModels",
+		Version:   9,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	log.stub.CheckCallNames(c, "AddConversation", "AddRequest")
+	calls := log.stub.Calls()
+	rec0 := calls[0].Args[0].(auditlog.Conversation)
+	c.Assert(rec0, gc.DeepEquals, auditlog.Conversation{
+		Who:               "bob",
+		When:              "2018-05-01T09:00:00Z",
+		ConnectionID:      "C",
+		ConversationID:    rec0.ConversationID,
+		BreakGlassSession: "deadbeef",
+		BreakGlassReason:  "controller down",
+	})
+	c.Assert(calls[1].Args[0], gc.DeepEquals, auditlog.Request{
+		ConversationID:    rec0.ConversationID,
+		ConnectionID:      "C",
+		RequestID:         1,
+		Facade:            "Controller",
+		Method:            "AllModels",
+		Version:           9,
+		BreakGlassSession: "deadbeef",
+	})
+}
+
 type fakeLog struct {
 	stub testing.Stub
 }
@@ -0,0 +1,76 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package charmfile reads the files that charms may declare alongside
+// their metadata, such as LXD profiles.
+package charmfile
+
+import (
+	"archive/zip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+)
+
+// MaxSize bounds how much of a file is read from a charm.
+const MaxSize = 1 << 20
+
+// Read returns the contents of the named file in the root of the
+// supplied charm, or nil if there is no such file. Only charm
+// directories and archives read from disk have files that can be read;
+// nil is returned for other charms. At most MaxSize bytes are read.
+func Read(ch charm.Charm, name string) ([]byte, error) {
+	switch ch := ch.(type) {
+	case *charm.CharmDir:
+		data, err := readFile(filepath.Join(ch.Path, name))
+		if os.IsNotExist(errors.Cause(err)) {
+			return nil, nil
+		}
+		return data, errors.Trace(err)
+	case *charm.CharmArchive:
+		if ch.Path == "" {
+			return nil, nil
+		}
+		data, err := readArchiveFile(ch.Path, name)
+		return data, errors.Trace(err)
+	}
+	return nil, nil
+}
+
+// readFile returns the contents of the file at path.
+func readFile(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer f.Close()
+	data, err := ioutil.ReadAll(io.LimitReader(f, MaxSize))
+	return data, errors.Trace(err)
+}
+
+// readArchiveFile returns the contents of the named file in the root
+// of the zip archive at path, or nil if there is no such file.
+func readArchiveFile(path, name string) ([]byte, error) {
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer r.Close()
+	for _, f := range r.File {
+		if filepath.Clean(f.Name) != name {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer rc.Close()
+		data, err := ioutil.ReadAll(io.LimitReader(rc, MaxSize))
+		return data, errors.Trace(err)
+	}
+	return nil, nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmfile_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/core/charmfile"
+	"github.com/juju/juju/testcharms"
+)
+
+type CharmFileSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&CharmFileSuite{})
+
+func (*CharmFileSuite) TestReadCharmDir(c *gc.C) {
+	ch := testcharms.Repo.CharmDir("lxd-profile")
+	expect, err := ioutil.ReadFile(filepath.Join(ch.Path, "lxd-profile.yaml"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	data, err := charmfile.Read(ch, "lxd-profile.yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, string(expect))
+}
+
+func (*CharmFileSuite) TestReadCharmArchive(c *gc.C) {
+	expect, err := ioutil.ReadFile(filepath.Join(testcharms.Repo.CharmDir("lxd-profile").Path, "lxd-profile.yaml"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	data, err := charmfile.Read(testcharms.Repo.CharmArchive(c.MkDir(), "lxd-profile"), "lxd-profile.yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, string(expect))
+}
+
+func (*CharmFileSuite) TestReadMissing(c *gc.C) {
+	data, err := charmfile.Read(testcharms.Repo.CharmDir("dummy"), "lxd-profile.yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data, gc.IsNil)
+
+	data, err = charmfile.Read(testcharms.Repo.CharmArchive(c.MkDir(), "dummy"), "lxd-profile.yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data, gc.IsNil)
+}
+
+func (*CharmFileSuite) TestReadOtherCharm(c *gc.C) {
+	data, err := charmfile.Read(&charm.CharmArchive{}, "metadata.yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data, gc.IsNil)
+}
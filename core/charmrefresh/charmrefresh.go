@@ -0,0 +1,151 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package charmrefresh defines the policies under which an
+// application's charm is refreshed automatically when a newer revision
+// is published to the channel it tracks.
+package charmrefresh
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+)
+
+// Policy determines which newer revisions of an application's charm
+// are applied automatically.
+type Policy string
+
+const (
+	// None refreshes nothing automatically, pinning the application
+	// to its current revision. It is the policy of applications that
+	// have not been given one.
+	None Policy = "none"
+
+	// PatchOnly refreshes to newer revisions that leave the charm's
+	// relation endpoints, extra bindings, storage and configuration
+	// options unchanged, so that the refresh cannot break the
+	// application's relations or configuration.
+	PatchOnly Policy = "patch-only"
+
+	// Any refreshes to every newer revision.
+	Any Policy = "any"
+)
+
+// Validate returns an error if the policy is not known.
+func (p Policy) Validate() error {
+	switch p {
+	case None, PatchOnly, Any:
+		return nil
+	}
+	return errors.NotValidf("refresh policy %q", string(p))
+}
+
+// Charm describes the parts of a charm that determine whether a
+// refresh is a patch.
+type Charm interface {
+	Meta() *charm.Meta
+	Config() *charm.Config
+}
+
+// CheckPatch returns an error describing the first difference found
+// between the relation endpoints, extra bindings, storage and
+// configuration options of the two charms, or nil if there are none.
+func CheckPatch(from, to Charm) error {
+	fromMeta, toMeta := from.Meta(), to.Meta()
+	for _, relations := range []struct {
+		from, to map[string]charm.Relation
+	}{
+		{fromMeta.Provides, toMeta.Provides},
+		{fromMeta.Requires, toMeta.Requires},
+		{fromMeta.Peers, toMeta.Peers},
+	} {
+		var fromNames, toNames []string
+		for name := range relations.from {
+			fromNames = append(fromNames, name)
+		}
+		for name := range relations.to {
+			toNames = append(toNames, name)
+		}
+		err := checkSame("relation endpoint", fromNames, toNames, func(name string) bool {
+			a, b := relations.from[name], relations.to[name]
+			return a.Interface == b.Interface && a.Scope == b.Scope && a.Limit == b.Limit
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	var fromBindings, toBindings []string
+	for name := range fromMeta.ExtraBindings {
+		fromBindings = append(fromBindings, name)
+	}
+	for name := range toMeta.ExtraBindings {
+		toBindings = append(toBindings, name)
+	}
+	if err := checkSame("extra binding", fromBindings, toBindings, nil); err != nil {
+		return errors.Trace(err)
+	}
+
+	var fromStorage, toStorage []string
+	for name := range fromMeta.Storage {
+		fromStorage = append(fromStorage, name)
+	}
+	for name := range toMeta.Storage {
+		toStorage = append(toStorage, name)
+	}
+	err := checkSame("storage", fromStorage, toStorage, func(name string) bool {
+		a, b := fromMeta.Storage[name], toMeta.Storage[name]
+		return a.Type == b.Type && a.Shared == b.Shared && a.ReadOnly == b.ReadOnly
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	fromOptions, toOptions := configOptions(from), configOptions(to)
+	var fromNames, toNames []string
+	for name := range fromOptions {
+		fromNames = append(fromNames, name)
+	}
+	for name := range toOptions {
+		toNames = append(toNames, name)
+	}
+	return errors.Trace(checkSame("config option", fromNames, toNames, func(name string) bool {
+		return fromOptions[name].Type == toOptions[name].Type
+	}))
+}
+
+func configOptions(ch Charm) map[string]charm.Option {
+	if cfg := ch.Config(); cfg != nil {
+		return cfg.Options
+	}
+	return nil
+}
+
+// checkSame returns an error if the given names differ, or if same is
+// not nil and reports that what is named differs between the charms.
+func checkSame(what string, from, to []string, same func(string) bool) error {
+	sort.Strings(from)
+	sort.Strings(to)
+	toSet := make(map[string]bool)
+	for _, name := range to {
+		toSet[name] = true
+	}
+	fromSet := make(map[string]bool)
+	for _, name := range from {
+		fromSet[name] = true
+		if !toSet[name] {
+			return errors.Errorf("%s %q removed", what, name)
+		}
+		if same != nil && !same(name) {
+			return errors.Errorf("%s %q changed", what, name)
+		}
+	}
+	for _, name := range to {
+		if !fromSet[name] {
+			return errors.Errorf("%s %q added", what, name)
+		}
+	}
+	return nil
+}
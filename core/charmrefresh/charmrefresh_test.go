@@ -0,0 +1,103 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrefresh_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/core/charmrefresh"
+)
+
+type charmRefreshSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&charmRefreshSuite{})
+
+func (s *charmRefreshSuite) TestValidate(c *gc.C) {
+	for _, policy := range []charmrefresh.Policy{charmrefresh.None, charmrefresh.PatchOnly, charmrefresh.Any} {
+		c.Check(policy.Validate(), jc.ErrorIsNil)
+	}
+	err := charmrefresh.Policy("sometimes").Validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `refresh policy "sometimes" not valid`)
+}
+
+type testCharm struct {
+	meta   *charm.Meta
+	config *charm.Config
+}
+
+func (ch testCharm) Meta() *charm.Meta     { return ch.meta }
+func (ch testCharm) Config() *charm.Config { return ch.config }
+
+func newTestCharm() testCharm {
+	return testCharm{
+		meta: &charm.Meta{
+			Name: "mysql",
+			Provides: map[string]charm.Relation{
+				"db": {Name: "db", Role: charm.RoleProvider, Interface: "mysql", Scope: charm.ScopeGlobal},
+			},
+			ExtraBindings: map[string]charm.ExtraBinding{
+				"cluster": {Name: "cluster"},
+			},
+			Storage: map[string]charm.Storage{
+				"data": {Name: "data", Type: charm.StorageFilesystem},
+			},
+		},
+		config: &charm.Config{
+			Options: map[string]charm.Option{
+				"port": {Type: "int"},
+			},
+		},
+	}
+}
+
+func (s *charmRefreshSuite) TestCheckPatch(c *gc.C) {
+	c.Assert(charmrefresh.CheckPatch(newTestCharm(), newTestCharm()), jc.ErrorIsNil)
+
+	for i, test := range []struct {
+		change func(testCharm)
+		expect string
+	}{{
+		change: func(ch testCharm) {
+			ch.meta.Provides["db"] = charm.Relation{Name: "db", Role: charm.RoleProvider, Interface: "pgsql", Scope: charm.ScopeGlobal}
+		},
+		expect: `relation endpoint "db" changed`,
+	}, {
+		change: func(ch testCharm) {
+			ch.meta.Requires = map[string]charm.Relation{"backup": {Name: "backup", Interface: "s3"}}
+		},
+		expect: `relation endpoint "backup" added`,
+	}, {
+		change: func(ch testCharm) {
+			delete(ch.meta.ExtraBindings, "cluster")
+		},
+		expect: `extra binding "cluster" removed`,
+	}, {
+		change: func(ch testCharm) {
+			ch.meta.Storage["data"] = charm.Storage{Name: "data", Type: charm.StorageBlock}
+		},
+		expect: `storage "data" changed`,
+	}, {
+		change: func(ch testCharm) {
+			ch.config.Options["port"] = charm.Option{Type: "string"}
+		},
+		expect: `config option "port" changed`,
+	}, {
+		change: func(ch testCharm) {
+			ch.config.Options["debug"] = charm.Option{Type: "boolean"}
+		},
+		expect: `config option "debug" added`,
+	}} {
+		c.Logf("test %d", i)
+		to := newTestCharm()
+		test.change(to)
+		c.Check(charmrefresh.CheckPatch(newTestCharm(), to), gc.ErrorMatches, test.expect)
+	}
+}
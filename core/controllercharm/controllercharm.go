@@ -0,0 +1,206 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package controllercharm defines the built-in charm used to model
+// a controller's API server as an application in the controller
+// model. The application's charm config drives the API server's
+// port, the extra names in its TLS certificate, and the rotation of
+// that certificate; the controller agents apply changes as they are
+// made, so they go through the usual "juju config" workflow.
+package controllercharm
+
+import (
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/controller"
+)
+
+const (
+	// ApplicationName is the name of the application that
+	// models the API server in the controller model.
+	ApplicationName = "controller"
+
+	// CharmName is the name of the built-in charm used by the
+	// controller application.
+	CharmName = "juju-controller"
+)
+
+const (
+	// APIPortKey is the config key for the port on which the API
+	// server listens.
+	APIPortKey = "api-port"
+
+	// ExtraSANsKey is the config key for the space separated list of
+	// DNS names and IP addresses to include in the API server's
+	// certificate, in addition to the controller's own addresses.
+	ExtraSANsKey = "api-extra-sans"
+
+	// CertGenerationKey is the config key for the generation of the
+	// API server's certificate. Increasing the value causes each
+	// controller to generate a new certificate.
+	CertGenerationKey = "tls-cert-generation"
+)
+
+// URL returns the URL of the controller charm for the given series.
+func URL(series string) *charm.URL {
+	return &charm.URL{
+		Schema:   "local",
+		Name:     CharmName,
+		Series:   series,
+		Revision: 0,
+	}
+}
+
+// New returns the built-in controller charm.
+func New() charm.Charm {
+	return &controllerCharm{
+		meta: &charm.Meta{
+			Name:    CharmName,
+			Summary: "Juju controller API server",
+			Description: "Models the API server of a Juju controller. " +
+				"Changes to the charm config are applied by the controller agents.",
+		},
+		config: &charm.Config{
+			Options: map[string]charm.Option{
+				APIPortKey: {
+					Type:        "int",
+					Description: "The port on which the API server listens.",
+					Default:     int64(controller.DefaultAPIPort),
+				},
+				ExtraSANsKey: {
+					Type: "string",
+					Description: "Space separated DNS names and IP addresses to include " +
+						"in the API server's TLS certificate.",
+					Default: "",
+				},
+				CertGenerationKey: {
+					Type: "int",
+					Description: "The generation of the API server's TLS certificate. " +
+						"Increase the value to have the controllers generate new certificates.",
+					Default: int64(0),
+				},
+			},
+		},
+	}
+}
+
+type controllerCharm struct {
+	meta   *charm.Meta
+	config *charm.Config
+}
+
+// Meta is part of the charm.Charm interface.
+func (c *controllerCharm) Meta() *charm.Meta {
+	return c.meta
+}
+
+// Config is part of the charm.Charm interface.
+func (c *controllerCharm) Config() *charm.Config {
+	return c.config
+}
+
+// Metrics is part of the charm.Charm interface.
+func (c *controllerCharm) Metrics() *charm.Metrics {
+	return nil
+}
+
+// Actions is part of the charm.Charm interface.
+func (c *controllerCharm) Actions() *charm.Actions {
+	return &charm.Actions{}
+}
+
+// Revision is part of the charm.Charm interface.
+func (c *controllerCharm) Revision() int {
+	return 0
+}
+
+// Config holds the API server configuration, as recorded in the
+// controller application's charm config.
+type Config struct {
+	// APIPort is the port on which the API server listens.
+	APIPort int
+
+	// ExtraSANs holds the DNS names and IP addresses to include in
+	// the API server's certificate.
+	ExtraSANs []string
+
+	// CertGeneration is the generation of the API server's
+	// certificate; when it increases, a new certificate is generated.
+	CertGeneration int
+}
+
+// ParseConfig returns the API server configuration held in the given
+// charm settings. Settings that are missing take their defaults.
+func ParseConfig(settings charm.Settings) (Config, error) {
+	cfg := Config{
+		APIPort: controller.DefaultAPIPort,
+	}
+	var err error
+	if v, ok := settings[APIPortKey]; ok {
+		if cfg.APIPort, err = intValue(APIPortKey, v); err != nil {
+			return Config{}, errors.Trace(err)
+		}
+	}
+	if v, ok := settings[CertGenerationKey]; ok {
+		if cfg.CertGeneration, err = intValue(CertGenerationKey, v); err != nil {
+			return Config{}, errors.Trace(err)
+		}
+	}
+	if v, ok := settings[ExtraSANsKey]; ok && v != nil {
+		sans, ok := v.(string)
+		if !ok {
+			return Config{}, errors.NotValidf("%s value %v", ExtraSANsKey, v)
+		}
+		cfg.ExtraSANs = strings.Fields(sans)
+	}
+	if err := cfg.Validate(); err != nil {
+		return Config{}, errors.Trace(err)
+	}
+	return cfg, nil
+}
+
+var validDNSName = regexp.MustCompile(`^(\*\.)?[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?)*$`)
+
+// Validate returns an error if the configuration is not valid.
+func (cfg Config) Validate() error {
+	if cfg.APIPort <= 0 || cfg.APIPort > 65535 {
+		return errors.NotValidf("%s %d", APIPortKey, cfg.APIPort)
+	}
+	if cfg.CertGeneration < 0 {
+		return errors.NotValidf("negative %s", CertGenerationKey)
+	}
+	for _, san := range cfg.ExtraSANs {
+		if net.ParseIP(san) == nil && !validDNSName.MatchString(san) {
+			return errors.NotValidf("%s entry %q", ExtraSANsKey, san)
+		}
+	}
+	return nil
+}
+
+// Settings returns the charm settings that record the configuration.
+func (cfg Config) Settings() charm.Settings {
+	return charm.Settings{
+		APIPortKey:        int64(cfg.APIPort),
+		ExtraSANsKey:      strings.Join(cfg.ExtraSANs, " "),
+		CertGenerationKey: int64(cfg.CertGeneration),
+	}
+}
+
+func intValue(key string, v interface{}) (int, error) {
+	switch v := v.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v == float64(int(v)) {
+			return int(v), nil
+		}
+	}
+	return 0, errors.NotValidf("%s value %v", key, v)
+}
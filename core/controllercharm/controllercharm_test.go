@@ -0,0 +1,100 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllercharm_test
+
+import (
+	"regexp"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/core/controllercharm"
+)
+
+type controllerCharmSuite struct{}
+
+var _ = gc.Suite(&controllerCharmSuite{})
+
+func (s *controllerCharmSuite) TestURL(c *gc.C) {
+	c.Assert(controllercharm.URL("xenial").String(), gc.Equals, "local:xenial/juju-controller-0")
+}
+
+func (s *controllerCharmSuite) TestCharm(c *gc.C) {
+	ch := controllercharm.New()
+	c.Assert(ch.Meta().Name, gc.Equals, "juju-controller")
+	c.Assert(ch.Revision(), gc.Equals, 0)
+
+	// The defaults must satisfy the charm's own schema.
+	settings, err := ch.Config().ValidateSettings(ch.Config().DefaultSettings())
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err := controllercharm.ParseConfig(settings)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg, jc.DeepEquals, controllercharm.Config{
+		APIPort: 17070,
+	})
+}
+
+func (s *controllerCharmSuite) TestParseConfig(c *gc.C) {
+	cfg, err := controllercharm.ParseConfig(charm.Settings{
+		"api-port":            int64(443),
+		"api-extra-sans":      " api.example.com  10.0.0.1 *.example.org ",
+		"tls-cert-generation": int64(3),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg, jc.DeepEquals, controllercharm.Config{
+		APIPort:        443,
+		ExtraSANs:      []string{"api.example.com", "10.0.0.1", "*.example.org"},
+		CertGeneration: 3,
+	})
+}
+
+func (s *controllerCharmSuite) TestParseConfigDefaults(c *gc.C) {
+	cfg, err := controllercharm.ParseConfig(charm.Settings{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg, jc.DeepEquals, controllercharm.Config{
+		APIPort: 17070,
+	})
+}
+
+func (s *controllerCharmSuite) TestParseConfigInvalid(c *gc.C) {
+	for i, test := range []struct {
+		settings charm.Settings
+		err      string
+	}{{
+		settings: charm.Settings{"api-port": int64(0)},
+		err:      "api-port 0 not valid",
+	}, {
+		settings: charm.Settings{"api-port": int64(70000)},
+		err:      "api-port 70000 not valid",
+	}, {
+		settings: charm.Settings{"api-port": "17070"},
+		err:      "api-port value 17070 not valid",
+	}, {
+		settings: charm.Settings{"tls-cert-generation": int64(-1)},
+		err:      "negative tls-cert-generation not valid",
+	}, {
+		settings: charm.Settings{"api-extra-sans": "good.example.com bad_name"},
+		err:      `api-extra-sans entry "bad_name" not valid`,
+	}} {
+		c.Logf("test %d: %v", i, test.settings)
+		_, err := controllercharm.ParseConfig(test.settings)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, regexp.QuoteMeta(test.err))
+	}
+}
+
+func (s *controllerCharmSuite) TestSettingsRoundTrip(c *gc.C) {
+	cfg := controllercharm.Config{
+		APIPort:        17071,
+		ExtraSANs:      []string{"a.example.com", "b.example.com"},
+		CertGeneration: 2,
+	}
+	settings, err := controllercharm.New().Config().ValidateSettings(cfg.Settings())
+	c.Assert(err, jc.ErrorIsNil)
+	parsed, err := controllercharm.ParseConfig(settings)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(parsed, jc.DeepEquals, cfg)
+}
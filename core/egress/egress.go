@@ -0,0 +1,173 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package egress handles the outbound network destinations that charms
+// declare their units require, and the policies under which machines
+// restrict outbound traffic to them.
+package egress
+
+import (
+	"net"
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/core/charmfile"
+	"github.com/juju/juju/network"
+)
+
+// metadataFile is the name of the file, in the root of a charm, that
+// holds the charm's metadata.
+const metadataFile = "metadata.yaml"
+
+// Mode determines whether the outbound traffic of a model's machines
+// is restricted.
+type Mode string
+
+const (
+	// ModeOpen leaves outbound traffic unrestricted.
+	ModeOpen Mode = "open"
+
+	// ModeRestricted denies outbound traffic other than that required
+	// by juju itself and that declared by the charms of the units on
+	// each machine.
+	ModeRestricted Mode = "restricted"
+)
+
+// Validate returns an error if the mode is not one of those defined.
+func (m Mode) Validate() error {
+	switch m {
+	case ModeOpen, ModeRestricted:
+		return nil
+	}
+	return errors.NotValidf("egress policy %q", string(m))
+}
+
+// Rule allows outbound traffic to a destination.
+type Rule struct {
+	// CIDR holds the destination network.
+	CIDR string `bson:"cidr" json:"cidr"`
+
+	// Ports holds the destination port range, in the form accepted by
+	// network.ParsePortRange, such as "443/tcp" or "9000-9100/udp". If
+	// empty, traffic of any protocol to any port is allowed.
+	Ports string `bson:"ports,omitempty" json:"ports,omitempty"`
+}
+
+// Validate returns an error if the rule's network or port range is
+// not valid.
+func (r Rule) Validate() error {
+	if _, _, err := net.ParseCIDR(r.CIDR); err != nil {
+		return errors.NotValidf("egress cidr %q", r.CIDR)
+	}
+	if r.Ports == "" {
+		return nil
+	}
+	if _, err := network.ParsePortRange(r.Ports); err != nil {
+		return errors.Annotatef(err, "egress ports %q", r.Ports)
+	}
+	return nil
+}
+
+// PortRange returns the rule's destination port range, or nil if any
+// port is allowed.
+func (r Rule) PortRange() (*network.PortRange, error) {
+	if r.Ports == "" {
+		return nil, nil
+	}
+	ports, err := network.ParsePortRange(r.Ports)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ports, nil
+}
+
+// ParseRules parses the egress rules declared in a charm's
+// metadata.yaml. The rules are an extension to the charm metadata,
+// which the charm package itself ignores, for example:
+//
+//	egress:
+//	  - cidr: 0.0.0.0/0
+//	    ports: ["443/tcp"]
+//	  - cidr: 10.20.0.0/16
+//
+// Each destination is flattened into one rule per port range. Other
+// metadata is ignored.
+func ParseRules(metadata []byte) ([]Rule, error) {
+	var doc struct {
+		Egress []struct {
+			CIDR  string   `yaml:"cidr"`
+			Ports []string `yaml:"ports"`
+		} `yaml:"egress"`
+	}
+	if err := yaml.Unmarshal(metadata, &doc); err != nil {
+		return nil, errors.Annotate(err, "parsing egress rules")
+	}
+	var rules []Rule
+	for _, dest := range doc.Egress {
+		if len(dest.Ports) == 0 {
+			rules = append(rules, Rule{CIDR: dest.CIDR})
+		}
+		for _, ports := range dest.Ports {
+			rules = append(rules, Rule{CIDR: dest.CIDR, Ports: ports})
+		}
+	}
+	for i, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		// Normalise the port ranges so that equivalent rules
+		// are merged.
+		if ports, _ := rule.PortRange(); ports != nil {
+			rules[i].Ports = ports.String()
+		}
+	}
+	return Merge(rules), nil
+}
+
+// Ruler is implemented by charms whose egress rules have already been
+// read.
+type Ruler interface {
+	EgressRules() []Rule
+}
+
+// ReadRules returns the egress rules declared by the supplied charm, or
+// nil if it declares none. Only charms that implement Ruler, and charm
+// directories and archives read from disk, can declare rules.
+func ReadRules(ch charm.Charm) ([]Rule, error) {
+	if ruler, ok := ch.(Ruler); ok {
+		return ruler.EgressRules(), nil
+	}
+	data, err := charmfile.Read(ch, metadataFile)
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading %s", metadataFile)
+	}
+	rules, err := ParseRules(data)
+	return rules, errors.Trace(err)
+}
+
+// Merge combines the given sets of rules, removing duplicates, and
+// returns them sorted by network and port range, or nil if there are
+// none.
+func Merge(ruleSets ...[]Rule) []Rule {
+	seen := make(map[Rule]bool)
+	var result []Rule
+	for _, rules := range ruleSets {
+		for _, rule := range rules {
+			if seen[rule] {
+				continue
+			}
+			seen[rule] = true
+			result = append(result, rule)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].CIDR != result[j].CIDR {
+			return result[i].CIDR < result[j].CIDR
+		}
+		return result[i].Ports < result[j].Ports
+	})
+	return result
+}
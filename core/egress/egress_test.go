@@ -0,0 +1,85 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package egress_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/egress"
+	"github.com/juju/juju/testcharms"
+)
+
+type EgressSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&EgressSuite{})
+
+var expectedRules = []egress.Rule{
+	{CIDR: "0.0.0.0/0", Ports: "443/tcp"},
+	{CIDR: "10.20.0.0/16", Ports: "8125/udp"},
+	{CIDR: "10.20.0.0/16", Ports: "9000-9100/tcp"},
+}
+
+func (*EgressSuite) TestReadRulesCharmDir(c *gc.C) {
+	rules, err := egress.ReadRules(testcharms.Repo.CharmDir("egress"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, expectedRules)
+}
+
+func (*EgressSuite) TestReadRulesCharmArchive(c *gc.C) {
+	rules, err := egress.ReadRules(testcharms.Repo.CharmArchive(c.MkDir(), "egress"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, expectedRules)
+}
+
+func (*EgressSuite) TestReadRulesNone(c *gc.C) {
+	rules, err := egress.ReadRules(testcharms.Repo.CharmDir("dummy"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.IsNil)
+}
+
+func (*EgressSuite) TestParseRulesAnyPort(c *gc.C) {
+	rules, err := egress.ParseRules([]byte(`
+egress:
+  - cidr: 192.168.0.0/24
+  - cidr: 10.0.0.0/8
+    ports: ["53/udp", "53"]
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []egress.Rule{
+		{CIDR: "10.0.0.0/8", Ports: "53/tcp"},
+		{CIDR: "10.0.0.0/8", Ports: "53/udp"},
+		{CIDR: "192.168.0.0/24"},
+	})
+}
+
+func (*EgressSuite) TestParseRulesInvalid(c *gc.C) {
+	_, err := egress.ParseRules([]byte("egress:\n  - cidr: 10.0.0.0\n"))
+	c.Assert(err, gc.ErrorMatches, `egress cidr "10.0.0.0" not valid`)
+
+	_, err = egress.ParseRules([]byte("egress:\n  - cidr: 10.0.0.0/8\n    ports: [\"70000\"]\n"))
+	c.Assert(err, gc.ErrorMatches, `egress ports "70000": invalid port range 70000-70000/tcp`)
+}
+
+func (*EgressSuite) TestMerge(c *gc.C) {
+	rules := egress.Merge(
+		[]egress.Rule{{CIDR: "10.0.0.0/8", Ports: "443/tcp"}},
+		nil,
+		[]egress.Rule{{CIDR: "0.0.0.0/0"}, {CIDR: "10.0.0.0/8", Ports: "443/tcp"}},
+	)
+	c.Assert(rules, jc.DeepEquals, []egress.Rule{
+		{CIDR: "0.0.0.0/0"},
+		{CIDR: "10.0.0.0/8", Ports: "443/tcp"},
+	})
+	c.Assert(egress.Merge(), gc.IsNil)
+}
+
+func (*EgressSuite) TestModeValidate(c *gc.C) {
+	c.Assert(egress.ModeOpen.Validate(), jc.ErrorIsNil)
+	c.Assert(egress.ModeRestricted.Validate(), jc.ErrorIsNil)
+	c.Assert(egress.Mode("closed").Validate(), gc.ErrorMatches, `egress policy "closed" not valid`)
+}
@@ -0,0 +1,101 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package lxdprofile handles the LXD profiles that charms may declare
+// for the containers hosting their units.
+package lxdprofile
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/core/charmfile"
+)
+
+// Filename is the name of the file, in the root of a charm, that
+// holds the charm's LXD profile.
+const Filename = "lxd-profile.yaml"
+
+// Profile holds the LXD profile declared by a charm.
+type Profile struct {
+	Config      map[string]string            `yaml:"config,omitempty" bson:"config,omitempty"`
+	Description string                       `yaml:"description,omitempty" bson:"description,omitempty"`
+	Devices     map[string]map[string]string `yaml:"devices,omitempty" bson:"devices,omitempty"`
+}
+
+// Empty reports whether the profile neither sets any config nor adds
+// any devices.
+func (p Profile) Empty() bool {
+	return len(p.Config) == 0 && len(p.Devices) == 0
+}
+
+// Validate returns an error if the profile holds config or devices
+// that juju manages itself, or that charms may not use.
+func (p Profile) Validate() error {
+	for key := range p.Config {
+		if strings.HasPrefix(key, "boot.") || strings.HasPrefix(key, "limits.") {
+			return errors.NotValidf("lxd profile config %q", key)
+		}
+	}
+	for name, device := range p.Devices {
+		switch device["type"] {
+		case "":
+			return errors.NotValidf("lxd profile device %q without type", name)
+		case "unix-disk":
+			return errors.NotValidf("lxd profile device %q of type %q", name, device["type"])
+		}
+	}
+	return nil
+}
+
+// Parse parses and validates an LXD profile.
+func Parse(data []byte) (*Profile, error) {
+	var profile Profile
+	if err := yaml.Unmarshal(data, &profile); err != nil {
+		return nil, errors.Annotate(err, "parsing lxd profile")
+	}
+	if err := profile.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &profile, nil
+}
+
+// Profiler is implemented by charms whose LXD profile has already
+// been read.
+type Profiler interface {
+	LXDProfile() *Profile
+}
+
+// Read returns the LXD profile declared by the supplied charm, or nil
+// if it declares none. Only charms that implement Profiler, and charm
+// directories and archives read from disk, can declare profiles.
+func Read(ch charm.Charm) (*Profile, error) {
+	if profiler, ok := ch.(Profiler); ok {
+		return profiler.LXDProfile(), nil
+	}
+	data, err := charmfile.Read(ch, Filename)
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading %s", Filename)
+	}
+	if data == nil {
+		return nil, nil
+	}
+	profile, err := Parse(data)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if profile.Empty() {
+		return nil, nil
+	}
+	return profile, nil
+}
+
+// Name returns the name of the LXD profile for the given revision of
+// an application's charm in the named model.
+func Name(modelName, appName string, revision int) string {
+	return fmt.Sprintf("juju-%s-%s-%d", modelName, appName, revision)
+}
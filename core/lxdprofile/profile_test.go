@@ -0,0 +1,97 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxdprofile_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/testcharms"
+)
+
+type ProfileSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ProfileSuite{})
+
+var expectedProfile = &lxdprofile.Profile{
+	Description: "sriov and hugepages",
+	Config: map[string]string{
+		"security.nesting":     "true",
+		"linux.kernel_modules": "openvswitch,nbd,ip_tables,ip6_tables",
+	},
+	Devices: map[string]map[string]string{
+		"sriov": {
+			"type":    "nic",
+			"nictype": "sriov",
+			"parent":  "enp3s0f0",
+		},
+		"hugepages": {
+			"type":   "disk",
+			"source": "/dev/hugepages",
+			"path":   "/dev/hugepages",
+		},
+	},
+}
+
+func (*ProfileSuite) TestReadCharmDir(c *gc.C) {
+	profile, err := lxdprofile.Read(testcharms.Repo.CharmDir("lxd-profile"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile, jc.DeepEquals, expectedProfile)
+}
+
+func (*ProfileSuite) TestReadCharmArchive(c *gc.C) {
+	profile, err := lxdprofile.Read(testcharms.Repo.CharmArchive(c.MkDir(), "lxd-profile"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile, jc.DeepEquals, expectedProfile)
+}
+
+func (*ProfileSuite) TestReadNoProfile(c *gc.C) {
+	profile, err := lxdprofile.Read(testcharms.Repo.CharmDir("dummy"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile, gc.IsNil)
+
+	profile, err = lxdprofile.Read(testcharms.Repo.CharmArchive(c.MkDir(), "dummy"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile, gc.IsNil)
+}
+
+func (*ProfileSuite) TestParseEmpty(c *gc.C) {
+	profile, err := lxdprofile.Parse([]byte("description: nothing\n"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile.Empty(), jc.IsTrue)
+}
+
+func (*ProfileSuite) TestParseInvalid(c *gc.C) {
+	for i, test := range []struct {
+		data string
+		err  string
+	}{{
+		data: "config: [",
+		err:  "parsing lxd profile: .*",
+	}, {
+		data: "config:\n  boot.autostart: \"false\"\n",
+		err:  `lxd profile config "boot.autostart" not valid`,
+	}, {
+		data: "config:\n  limits.memory: 1GB\n",
+		err:  `lxd profile config "limits.memory" not valid`,
+	}, {
+		data: "devices:\n  foo:\n    path: /foo\n",
+		err:  `lxd profile device "foo" without type not valid`,
+	}, {
+		data: "devices:\n  foo:\n    type: unix-disk\n",
+		err:  `lxd profile device "foo" of type "unix-disk" not valid`,
+	}} {
+		c.Logf("test %d: %s", i, test.data)
+		_, err := lxdprofile.Parse([]byte(test.data))
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (*ProfileSuite) TestName(c *gc.C) {
+	c.Assert(lxdprofile.Name("default", "sriov", 3), gc.Equals, "juju-default-sriov-3")
+}
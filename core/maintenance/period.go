@@ -0,0 +1,127 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package maintenance
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// Period is a recurring period of the week, in UTC, as set in the
+// maintenance-windows model config.
+type Period struct {
+	// Daily is true if the period recurs every day, in which case
+	// Day is ignored.
+	Daily bool
+
+	// Day is the day of the week on which the period starts.
+	Day time.Weekday
+
+	// Start and End are the offsets from midnight at which the
+	// period starts and ends. If End is not after Start, the period
+	// ends on the following day.
+	Start, End time.Duration
+}
+
+// String returns the period in the form accepted by ParsePeriods.
+func (p Period) String() string {
+	day := "daily"
+	if !p.Daily {
+		day = strings.ToLower(p.Day.String()[:3])
+	}
+	return fmt.Sprintf("%s %s-%s", day, formatOffset(p.Start), formatOffset(p.End))
+}
+
+// Contains reports whether the given time falls within the period.
+func (p Period) Contains(t time.Time) bool {
+	t = t.UTC()
+	offset := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	today := p.Daily || t.Weekday() == p.Day
+	if p.Start < p.End {
+		return today && offset >= p.Start && offset < p.End
+	}
+	// The period wraps past midnight.
+	yesterday := p.Daily || (t.Weekday()+6)%7 == p.Day
+	return (today && offset >= p.Start) || (yesterday && offset < p.End)
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// ParsePeriods parses a comma-separated list of periods, each of the
+// form "<day> <hh:mm>-<hh:mm>", where the day is "daily" or the first
+// three letters of a day of the week, and the times are in UTC; for
+// example "sat 02:00-04:00, sun 23:00-01:00".
+func ParsePeriods(s string) ([]Period, error) {
+	var periods []Period
+	for _, field := range strings.Split(s, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		p, err := parsePeriod(field)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		periods = append(periods, p)
+	}
+	return periods, nil
+}
+
+func parsePeriod(s string) (Period, error) {
+	parts := strings.Fields(s)
+	if len(parts) != 2 {
+		return Period{}, errors.NotValidf("maintenance window %q", s)
+	}
+	var p Period
+	day := strings.ToLower(parts[0])
+	if day == "daily" {
+		p.Daily = true
+	} else if len(day) >= 3 {
+		weekday, ok := weekdays[day[:3]]
+		if !ok {
+			return Period{}, errors.NotValidf("day %q in maintenance window %q", parts[0], s)
+		}
+		p.Day = weekday
+	} else {
+		return Period{}, errors.NotValidf("day %q in maintenance window %q", parts[0], s)
+	}
+	times := strings.Split(parts[1], "-")
+	if len(times) != 2 {
+		return Period{}, errors.NotValidf("times %q in maintenance window %q", parts[1], s)
+	}
+	var err error
+	if p.Start, err = parseOffset(times[0]); err != nil {
+		return Period{}, errors.Annotatef(err, "maintenance window %q", s)
+	}
+	if p.End, err = parseOffset(times[1]); err != nil {
+		return Period{}, errors.Annotatef(err, "maintenance window %q", s)
+	}
+	if p.Start == p.End {
+		return Period{}, errors.NotValidf("empty maintenance window %q", s)
+	}
+	return p, nil
+}
+
+func parseOffset(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, errors.NotValidf("time %q", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func formatOffset(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute))
+}
@@ -0,0 +1,98 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package maintenance_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/maintenance"
+)
+
+type periodSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&periodSuite{})
+
+func (s *periodSuite) TestParsePeriods(c *gc.C) {
+	periods, err := maintenance.ParsePeriods("sat 02:00-04:00, Sunday 23:30-01:00,daily 12:00-12:15")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(periods, jc.DeepEquals, []maintenance.Period{{
+		Day:   time.Saturday,
+		Start: 2 * time.Hour,
+		End:   4 * time.Hour,
+	}, {
+		Day:   time.Sunday,
+		Start: 23*time.Hour + 30*time.Minute,
+		End:   time.Hour,
+	}, {
+		Daily: true,
+		Start: 12 * time.Hour,
+		End:   12*time.Hour + 15*time.Minute,
+	}})
+	c.Assert(periods[1].String(), gc.Equals, "sun 23:30-01:00")
+
+	periods, err = maintenance.ParsePeriods("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(periods, gc.HasLen, 0)
+}
+
+func (s *periodSuite) TestParsePeriodsInvalid(c *gc.C) {
+	for _, test := range []struct {
+		periods string
+		expect  string
+	}{{
+		periods: "sat",
+		expect:  `maintenance window "sat" not valid`,
+	}, {
+		periods: "someday 02:00-04:00",
+		expect:  `day "someday" in maintenance window "someday 02:00-04:00" not valid`,
+	}, {
+		periods: "sat 02:00",
+		expect:  `times "02:00" in maintenance window "sat 02:00" not valid`,
+	}, {
+		periods: "sat 02:00-25:00",
+		expect:  `maintenance window "sat 02:00-25:00": time "25:00" not valid`,
+	}, {
+		periods: "sat 02:00-02:00",
+		expect:  `empty maintenance window "sat 02:00-02:00" not valid`,
+	}} {
+		_, err := maintenance.ParsePeriods(test.periods)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
+func (s *periodSuite) TestContains(c *gc.C) {
+	periods, err := maintenance.ParsePeriods("sat 02:00-04:00, sun 23:30-01:00")
+	c.Assert(err, jc.ErrorIsNil)
+	contains := func(t time.Time) bool {
+		for _, p := range periods {
+			if p.Contains(t) {
+				return true
+			}
+		}
+		return false
+	}
+
+	// 2018-03-03 was a Saturday.
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2018, 3, day, hour, minute, 0, 0, time.UTC)
+	}
+	c.Check(contains(at(3, 2, 0)), jc.IsTrue)
+	c.Check(contains(at(3, 3, 59)), jc.IsTrue)
+	c.Check(contains(at(3, 4, 0)), jc.IsFalse)
+	c.Check(contains(at(4, 2, 0)), jc.IsFalse)
+	c.Check(contains(at(4, 23, 45)), jc.IsTrue)
+	c.Check(contains(at(5, 0, 30)), jc.IsTrue)
+	c.Check(contains(at(5, 1, 0)), jc.IsFalse)
+	c.Check(contains(at(3, 0, 30)), jc.IsFalse)
+
+	// Times are compared in UTC.
+	local := time.FixedZone("UTC+10", 10*60*60)
+	c.Check(contains(time.Date(2018, 3, 3, 12, 30, 0, 0, local)), jc.IsTrue)
+}
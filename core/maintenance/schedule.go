@@ -0,0 +1,156 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package maintenance
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// Schedule is a cron-like schedule of the minutes, in UTC, at which a
+// maintenance window opens.
+type Schedule struct {
+	spec                         string
+	minute, hour, dom, month     uint64
+	dow                          uint64
+	domRestricted, dowRestricted bool
+}
+
+type scheduleField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var scheduleFields = []scheduleField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}},
+	// Both 0 and 7 are Sunday.
+	{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}},
+}
+
+// ParseSchedule parses a schedule in the five-field format used by
+// cron: minute, hour, day of month, month and day of week. Each field
+// is "*" or a comma-separated list of values and ranges, optionally
+// with a step; months and days of the week may be given by the first
+// three letters of their names. For example, "0 2 * * sat" opens at
+// 02:00 every Saturday, and "30 */6 * * mon-fri" every six hours on
+// weekdays. As in cron, if both the day of month and day of week are
+// restricted, the schedule matches days that satisfy either.
+func ParseSchedule(spec string) (*Schedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != len(scheduleFields) {
+		return nil, errors.NewNotValid(nil, fmt.Sprintf(
+			"schedule %q has %d fields, expected %d", spec, len(fields), len(scheduleFields),
+		))
+	}
+	bits := make([]uint64, len(fields))
+	for i, field := range fields {
+		var err error
+		if bits[i], err = parseScheduleField(field, scheduleFields[i]); err != nil {
+			return nil, errors.Annotatef(err, "schedule %q", spec)
+		}
+	}
+	// Fold Sunday as 7 into Sunday as 0.
+	if bits[4]&(1<<7) != 0 {
+		bits[4] = bits[4]&^(1<<7) | 1
+	}
+	return &Schedule{
+		spec:          strings.Join(fields, " "),
+		minute:        bits[0],
+		hour:          bits[1],
+		dom:           bits[2],
+		month:         bits[3],
+		dow:           bits[4],
+		domRestricted: !strings.HasPrefix(fields[2], "*"),
+		dowRestricted: !strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+func parseScheduleField(s string, field scheduleField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(s, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return 0, errors.NotValidf("step %q in %s", part[i+1:], field.name)
+			}
+			part = part[:i]
+		}
+		var lo, hi int
+		switch i := strings.Index(part, "-"); {
+		case part == "*":
+			lo, hi = field.min, field.max
+		case i >= 0:
+			var err error
+			if lo, err = field.value(part[:i]); err != nil {
+				return 0, errors.Trace(err)
+			}
+			if hi, err = field.value(part[i+1:]); err != nil {
+				return 0, errors.Trace(err)
+			}
+			if hi < lo {
+				return 0, errors.NotValidf("range %q in %s", part, field.name)
+			}
+		default:
+			var err error
+			if lo, err = field.value(part); err != nil {
+				return 0, errors.Trace(err)
+			}
+			hi = lo
+			if step > 1 {
+				hi = field.max
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func (field scheduleField) value(s string) (int, error) {
+	if v, ok := field.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < field.min || v > field.max {
+		return 0, errors.NotValidf("%s %q", field.name, s)
+	}
+	return v, nil
+}
+
+// String returns the schedule in the form accepted by ParseSchedule.
+func (s *Schedule) String() string {
+	return s.spec
+}
+
+// Matches reports whether the minute containing the given time is in
+// the schedule.
+func (s *Schedule) Matches(t time.Time) bool {
+	t = t.UTC()
+	if s.minute&(1<<uint(t.Minute())) == 0 ||
+		s.hour&(1<<uint(t.Hour())) == 0 ||
+		s.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domRestricted && s.dowRestricted {
+		return domMatch || dowMatch
+	}
+	return domMatch && dowMatch
+}
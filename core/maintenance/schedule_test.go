@@ -0,0 +1,110 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package maintenance_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/maintenance"
+)
+
+type scheduleSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&scheduleSuite{})
+
+// 2018-03-03 was a Saturday.
+func at(day, hour, minute int) time.Time {
+	return time.Date(2018, 3, day, hour, minute, 0, 0, time.UTC)
+}
+
+func (s *scheduleSuite) TestMatches(c *gc.C) {
+	for i, test := range []struct {
+		schedule string
+		match    []time.Time
+		noMatch  []time.Time
+	}{{
+		schedule: "* * * * *",
+		match:    []time.Time{at(3, 0, 0), at(7, 13, 59)},
+	}, {
+		schedule: "0 2 * * sat",
+		match:    []time.Time{at(3, 2, 0), at(10, 2, 0)},
+		noMatch:  []time.Time{at(3, 2, 1), at(4, 2, 0)},
+	}, {
+		schedule: "30 */6 * * mon-fri",
+		match:    []time.Time{at(5, 0, 30), at(5, 18, 30), at(9, 6, 30)},
+		noMatch:  []time.Time{at(5, 3, 30), at(3, 0, 30)},
+	}, {
+		schedule: "0 0 1,15 mar *",
+		match:    []time.Time{at(1, 0, 0), at(15, 0, 0)},
+		noMatch:  []time.Time{at(2, 0, 0), time.Date(2018, 4, 1, 0, 0, 0, 0, time.UTC)},
+	}, {
+		// Sunday may be given as 7.
+		schedule: "0 0 * * 7",
+		match:    []time.Time{at(4, 0, 0)},
+		noMatch:  []time.Time{at(3, 0, 0)},
+	}, {
+		// A restricted day of month and day of week match either.
+		schedule: "0 0 1 * sat",
+		match:    []time.Time{at(1, 0, 0), at(3, 0, 0)},
+		noMatch:  []time.Time{at(2, 0, 0)},
+	}, {
+		schedule: "5-10/5 1 * * *",
+		match:    []time.Time{at(3, 1, 5), at(3, 1, 10)},
+		noMatch:  []time.Time{at(3, 1, 6), at(3, 1, 15)},
+	}} {
+		c.Logf("test %d: %s", i, test.schedule)
+		schedule, err := maintenance.ParseSchedule(test.schedule)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(schedule.String(), gc.Equals, test.schedule)
+		for _, t := range test.match {
+			c.Check(schedule.Matches(t), jc.IsTrue, gc.Commentf("%v", t))
+		}
+		for _, t := range test.noMatch {
+			c.Check(schedule.Matches(t), jc.IsFalse, gc.Commentf("%v", t))
+		}
+	}
+}
+
+func (s *scheduleSuite) TestMatchesUTC(c *gc.C) {
+	schedule, err := maintenance.ParseSchedule("0 2 * * sat")
+	c.Assert(err, jc.ErrorIsNil)
+	local := time.FixedZone("UTC+10", 10*60*60)
+	c.Check(schedule.Matches(time.Date(2018, 3, 3, 12, 0, 0, 0, local)), jc.IsTrue)
+}
+
+func (s *scheduleSuite) TestParseScheduleInvalid(c *gc.C) {
+	for _, test := range []struct {
+		schedule string
+		expect   string
+	}{{
+		schedule: "0 2 * *",
+		expect:   `schedule "0 2 \* \*" has 4 fields, expected 5`,
+	}, {
+		schedule: "60 * * * *",
+		expect:   `schedule "60 \* \* \* \*": minute "60" not valid`,
+	}, {
+		schedule: "0 0 0 * *",
+		expect:   `schedule "0 0 0 \* \*": day of month "0" not valid`,
+	}, {
+		schedule: "0 0 * * someday",
+		expect:   `schedule "0 0 \* \* someday": day of week "someday" not valid`,
+	}, {
+		schedule: "0 5-1 * * *",
+		expect:   `schedule "0 5-1 \* \* \*": range "5-1" in hour not valid`,
+	}, {
+		schedule: "*/0 * * * *",
+		expect:   `schedule "\*/0 \* \* \* \*": step "0" in minute not valid`,
+	}} {
+		_, err := maintenance.ParseSchedule(test.schedule)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
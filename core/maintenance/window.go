@@ -0,0 +1,82 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package maintenance defines the windows during which disruptive
+// automated operations, such as refreshing charms, upgrading agents
+// and rebooting machines, may run in a model.
+package maintenance
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// MaxDuration is the longest a maintenance window may stay open.
+const MaxDuration = 7 * 24 * time.Hour
+
+var validName = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+
+// Window is a named, recurring maintenance window defined in a model.
+type Window struct {
+	// Name identifies the window within its model.
+	Name string
+
+	// Schedule is the cron-like schedule, accepted by ParseSchedule,
+	// of the minutes at which the window opens.
+	Schedule string
+
+	// Duration is how long the window stays open each time it opens.
+	Duration time.Duration
+}
+
+// Validate returns an error if the window is not valid.
+func (w Window) Validate() error {
+	if !validName.MatchString(w.Name) {
+		return errors.NotValidf("maintenance window name %q", w.Name)
+	}
+	if _, err := ParseSchedule(w.Schedule); err != nil {
+		return errors.Trace(err)
+	}
+	if w.Duration < time.Minute || w.Duration > MaxDuration {
+		return errors.NotValidf("maintenance window duration %v", w.Duration)
+	}
+	return nil
+}
+
+// Contains reports whether the window is open at the given time. A
+// window whose schedule is not valid is never open.
+func (w Window) Contains(t time.Time) bool {
+	schedule, err := ParseSchedule(w.Schedule)
+	if err != nil {
+		return false
+	}
+	for start := t.Truncate(time.Minute); t.Sub(start) < w.Duration; start = start.Add(-time.Minute) {
+		if schedule.Matches(start) {
+			return true
+		}
+	}
+	return false
+}
+
+// Open reports whether disruptive automated operations may run at the
+// given time in a model with the given maintenance-windows config
+// periods and maintenance windows: that is, whether the time falls
+// within any of them. A model that defines neither is always open.
+func Open(periods []Period, windows []Window, t time.Time) bool {
+	if len(periods) == 0 && len(windows) == 0 {
+		return true
+	}
+	for _, p := range periods {
+		if p.Contains(t) {
+			return true
+		}
+	}
+	for _, w := range windows {
+		if w.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package maintenance_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/maintenance"
+)
+
+type windowSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&windowSuite{})
+
+func (s *windowSuite) TestValidate(c *gc.C) {
+	w := maintenance.Window{Name: "weekend", Schedule: "0 2 * * sat", Duration: 2 * time.Hour}
+	c.Assert(w.Validate(), jc.ErrorIsNil)
+
+	for _, test := range []struct {
+		change func(*maintenance.Window)
+		expect string
+	}{{
+		change: func(w *maintenance.Window) { w.Name = "Weekend" },
+		expect: `maintenance window name "Weekend" not valid`,
+	}, {
+		change: func(w *maintenance.Window) { w.Schedule = "sat" },
+		expect: `schedule "sat" has 1 fields, expected 5`,
+	}, {
+		change: func(w *maintenance.Window) { w.Duration = time.Second },
+		expect: `maintenance window duration 1s not valid`,
+	}, {
+		change: func(w *maintenance.Window) { w.Duration = 8 * 24 * time.Hour },
+		expect: `maintenance window duration 192h0m0s not valid`,
+	}} {
+		w := w
+		test.change(&w)
+		err := w.Validate()
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
+func (s *windowSuite) TestContains(c *gc.C) {
+	w := maintenance.Window{Name: "weekend", Schedule: "30 23 * * sat", Duration: 90 * time.Minute}
+	c.Check(w.Contains(at(3, 23, 29)), jc.IsFalse)
+	c.Check(w.Contains(at(3, 23, 30)), jc.IsTrue)
+	c.Check(w.Contains(at(4, 0, 59).Add(59*time.Second)), jc.IsTrue)
+	c.Check(w.Contains(at(4, 1, 0)), jc.IsFalse)
+	c.Check(w.Contains(at(10, 23, 45)), jc.IsTrue)
+
+	w.Schedule = "not a schedule"
+	c.Check(w.Contains(at(3, 23, 30)), jc.IsFalse)
+}
+
+func (s *windowSuite) TestOpen(c *gc.C) {
+	periods, err := maintenance.ParsePeriods("sat 02:00-04:00")
+	c.Assert(err, jc.ErrorIsNil)
+	windows := []maintenance.Window{{Name: "nightly", Schedule: "0 1 * * *", Duration: time.Hour}}
+
+	c.Check(maintenance.Open(periods, windows, at(3, 3, 0)), jc.IsTrue)
+	c.Check(maintenance.Open(periods, windows, at(5, 1, 30)), jc.IsTrue)
+	c.Check(maintenance.Open(periods, windows, at(5, 3, 0)), jc.IsFalse)
+	c.Check(maintenance.Open(nil, windows, at(3, 3, 0)), jc.IsFalse)
+	c.Check(maintenance.Open(periods, nil, at(5, 1, 30)), jc.IsFalse)
+
+	// A model without maintenance windows is always open.
+	c.Check(maintenance.Open(nil, nil, at(5, 3, 0)), jc.IsTrue)
+}
@@ -32,6 +32,35 @@ type MigrationStatus struct {
 	// TargetInfo contains the details of how to connect to the target
 	// controller.
 	TargetInfo TargetInfo
+
+	// RollbackWindow is how long the source model is kept, frozen
+	// but restorable, after the model has been handed over to the
+	// target controller. While the window is open, the agents are
+	// checked for problems on the target controller, and the
+	// migration may be rolled back. If it is zero, the source model
+	// is removed as soon as the migration succeeds.
+	RollbackWindow time.Duration
+
+	// Rollback is true if the migration hands the agents of a model
+	// back to the controller it was migrated from. The model has
+	// already been restored on that controller, so the migration
+	// starts at SUCCESS and transfers nothing.
+	Rollback bool
+}
+
+// SourceInfo holds the details required for agents to connect back
+// to the controller a model was migrated from.
+type SourceInfo struct {
+	// ControllerTag holds the tag for the source controller.
+	ControllerTag names.ControllerTag
+
+	// Addrs holds the addresses and ports of the source controller's
+	// API servers.
+	Addrs []string
+
+	// CACert holds the CA certificate that will be used to validate
+	// the source API server's certificate, in PEM format.
+	CACert string
 }
 
 // SerializedModel wraps a buffer contain a serialised Juju model as
@@ -20,6 +20,9 @@ const (
 	DONE
 	ABORT
 	ABORTDONE
+	POSTVALIDATION
+	ROLLBACK
+	ROLLBACKDONE
 )
 
 var phaseNames = []string{
@@ -35,6 +38,9 @@ var phaseNames = []string{
 	"DONE",
 	"ABORT",
 	"ABORTDONE",
+	"POSTVALIDATION",
+	"ROLLBACK",
+	"ROLLBACKDONE",
 }
 
 // String returns the name of an model migration phase constant.
@@ -93,13 +99,15 @@ func (p Phase) IsRunning() bool {
 // The keys are the "from" states and the values enumerate the
 // possible "to" states.
 var validTransitions = map[Phase][]Phase{
-	QUIESCE:     {IMPORT, ABORT},
-	IMPORT:      {VALIDATION, ABORT},
-	VALIDATION:  {SUCCESS, ABORT},
-	SUCCESS:     {LOGTRANSFER},
-	LOGTRANSFER: {REAP},
-	REAP:        {DONE, REAPFAILED},
-	ABORT:       {ABORTDONE},
+	QUIESCE:        {IMPORT, ABORT},
+	IMPORT:         {VALIDATION, ABORT},
+	VALIDATION:     {SUCCESS, ABORT},
+	SUCCESS:        {LOGTRANSFER},
+	LOGTRANSFER:    {REAP, POSTVALIDATION},
+	POSTVALIDATION: {REAP, ROLLBACK},
+	ROLLBACK:       {ROLLBACKDONE},
+	REAP:           {DONE, REAPFAILED},
+	ABORT:          {ABORTDONE},
 }
 
 var terminalPhases []Phase
@@ -53,6 +53,9 @@ func (s *PhaseSuite) TestIsTerminal(c *gc.C) {
 	c.Check(migration.ABORTDONE.IsTerminal(), jc.IsTrue)
 	c.Check(migration.REAPFAILED.IsTerminal(), jc.IsTrue)
 	c.Check(migration.DONE.IsTerminal(), jc.IsTrue)
+	c.Check(migration.POSTVALIDATION.IsTerminal(), jc.IsFalse)
+	c.Check(migration.ROLLBACK.IsTerminal(), jc.IsFalse)
+	c.Check(migration.ROLLBACKDONE.IsTerminal(), jc.IsTrue)
 }
 
 func (s *PhaseSuite) TestIsRunning(c *gc.C) {
@@ -69,6 +72,9 @@ func (s *PhaseSuite) TestIsRunning(c *gc.C) {
 	c.Check(migration.DONE.IsRunning(), jc.IsFalse)
 	c.Check(migration.ABORT.IsRunning(), jc.IsFalse)
 	c.Check(migration.ABORTDONE.IsRunning(), jc.IsFalse)
+	c.Check(migration.POSTVALIDATION.IsRunning(), jc.IsFalse)
+	c.Check(migration.ROLLBACK.IsRunning(), jc.IsFalse)
+	c.Check(migration.ROLLBACKDONE.IsRunning(), jc.IsFalse)
 }
 
 func (s *PhaseSuite) TestCanTransitionTo(c *gc.C) {
@@ -77,4 +83,9 @@ func (s *PhaseSuite) TestCanTransitionTo(c *gc.C) {
 	c.Check(migration.QUIESCE.CanTransitionTo(migration.IMPORT), jc.IsTrue)
 	c.Check(migration.QUIESCE.CanTransitionTo(migration.Phase(-1)), jc.IsFalse)
 	c.Check(migration.ABORT.CanTransitionTo(migration.QUIESCE), jc.IsFalse)
+	c.Check(migration.LOGTRANSFER.CanTransitionTo(migration.POSTVALIDATION), jc.IsTrue)
+	c.Check(migration.POSTVALIDATION.CanTransitionTo(migration.REAP), jc.IsTrue)
+	c.Check(migration.POSTVALIDATION.CanTransitionTo(migration.ROLLBACK), jc.IsTrue)
+	c.Check(migration.ROLLBACK.CanTransitionTo(migration.ROLLBACKDONE), jc.IsTrue)
+	c.Check(migration.ROLLBACK.CanTransitionTo(migration.REAP), jc.IsFalse)
 }
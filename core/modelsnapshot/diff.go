@@ -0,0 +1,225 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelsnapshot
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// contextLines is the number of unchanged lines shown around each
+// change in a diff.
+const contextLines = 3
+
+type editKind int
+
+const (
+	editEqual editKind = iota
+	editDelete
+	editInsert
+)
+
+type edit struct {
+	kind editKind
+	line string
+}
+
+// Diff returns a unified diff from one model description to another,
+// with the given labels, or the empty string if they are the same.
+func Diff(fromLabel string, from []byte, toLabel string, to []byte) string {
+	edits := diffLines(splitLines(from), splitLines(to))
+	var buf bytes.Buffer
+	// fromLine and toLine are the numbers of the lines, in each
+	// description, that precede the edit being considered.
+	fromLine, toLine := 0, 0
+	for i := 0; i < len(edits); {
+		if edits[i].kind == editEqual {
+			fromLine++
+			toLine++
+			i++
+			continue
+		}
+		if buf.Len() == 0 {
+			fmt.Fprintf(&buf, "--- %s\n+++ %s\n", fromLabel, toLabel)
+		}
+		start := i - contextLines
+		if start < 0 {
+			start = 0
+		}
+		end := hunkEnd(edits, i)
+		hunkFrom, hunkTo := fromLine-(i-start), toLine-(i-start)
+		var body bytes.Buffer
+		fromCount, toCount := 0, 0
+		for _, e := range edits[start:end] {
+			switch e.kind {
+			case editEqual:
+				body.WriteString(" ")
+				fromCount++
+				toCount++
+			case editDelete:
+				body.WriteString("-")
+				fromCount++
+			case editInsert:
+				body.WriteString("+")
+				toCount++
+			}
+			body.WriteString(e.line)
+			body.WriteString("\n")
+		}
+		fmt.Fprintf(&buf, "@@ -%s +%s @@\n", hunkRange(hunkFrom, fromCount), hunkRange(hunkTo, toCount))
+		buf.Write(body.Bytes())
+		for _, e := range edits[i:end] {
+			if e.kind != editInsert {
+				fromLine++
+			}
+			if e.kind != editDelete {
+				toLine++
+			}
+		}
+		i = end
+	}
+	return buf.String()
+}
+
+// hunkEnd returns the index of the edit after the last one in the hunk
+// that starts with the change at index i. Changes separated by no more
+// than twice the context are shown in the same hunk.
+func hunkEnd(edits []edit, i int) int {
+	end := i
+	for end < len(edits) {
+		if edits[end].kind != editEqual {
+			end++
+			continue
+		}
+		next := end
+		for next < len(edits) && edits[next].kind == editEqual {
+			next++
+		}
+		if next == len(edits) || next-end > 2*contextLines {
+			end += contextLines
+			if end > len(edits) {
+				end = len(edits)
+			}
+			return end
+		}
+		end = next
+	}
+	return end
+}
+
+// hunkRange formats the start line and length of one side of a hunk.
+// As in GNU diff, the length of a single line range is omitted, and
+// an empty range starts at the line before it.
+func hunkRange(before, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", before)
+	case 1:
+		return fmt.Sprintf("%d", before+1)
+	}
+	return fmt.Sprintf("%d,%d", before+1, count)
+}
+
+func splitLines(text []byte) []string {
+	if len(text) == 0 {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(string(text), "\n"), "\n")
+}
+
+// diffLines returns the shortest sequence of edits that turns a into
+// b, found with Myers' algorithm.
+func diffLines(a, b []string) []edit {
+	// Lines common to the start and end of both are matched up
+	// front, which keeps the search small for the usual case of a
+	// few changes in a large description.
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	var edits []edit
+	for _, line := range a[:prefix] {
+		edits = append(edits, edit{editEqual, line})
+	}
+	edits = append(edits, myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, line := range a[len(a)-suffix:] {
+		edits = append(edits, edit{editEqual, line})
+	}
+	return edits
+}
+
+func myers(a, b []string) []edit {
+	n, m := len(a), len(b)
+	max := n + m
+	// v holds, for each diagonal k, the furthest x reached on it.
+	// It is indexed by k+offset.
+	offset := max + 1
+	v := make([]int, 2*max+3)
+	// trace holds the parts of v that were in use at the start of
+	// each round, from which the path is recovered.
+	var trace [][]int
+	for d := 0; d <= max; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d-1:offset+d+2]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(trace, a, b)
+			}
+		}
+	}
+	return nil
+}
+
+func backtrack(trace [][]int, a, b []string) []edit {
+	var reversed []edit
+	x, y := len(a), len(b)
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		// v covers the diagonals from -d-1 to d+1.
+		at := func(k int) int { return v[k+d+1] }
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			reversed = append(reversed, edit{editEqual, a[x-1]})
+			x--
+			y--
+		}
+		if d > 0 {
+			if x == prevX {
+				reversed = append(reversed, edit{editInsert, b[y-1]})
+			} else {
+				reversed = append(reversed, edit{editDelete, a[x-1]})
+			}
+		}
+		x, y = prevX, prevY
+	}
+	edits := make([]edit, len(reversed))
+	for i, e := range reversed {
+		edits[len(edits)-1-i] = e
+	}
+	return edits
+}
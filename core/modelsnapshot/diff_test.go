@@ -0,0 +1,109 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelsnapshot_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/modelsnapshot"
+)
+
+type diffSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&diffSuite{})
+
+func lines(n int) []string {
+	var result []string
+	for i := 1; i <= n; i++ {
+		result = append(result, fmt.Sprintf("line %d", i))
+	}
+	return result
+}
+
+func text(lines []string) []byte {
+	return []byte(strings.Join(lines, "\n") + "\n")
+}
+
+func (s *diffSuite) TestDiffSame(c *gc.C) {
+	c.Assert(modelsnapshot.Diff("a", text(lines(5)), "b", text(lines(5))), gc.Equals, "")
+}
+
+func (s *diffSuite) TestDiffChange(c *gc.C) {
+	to := lines(10)
+	to[4] = "changed"
+	c.Assert(modelsnapshot.Diff("snapshot 1", text(lines(10)), "live", text(to)), gc.Equals, `
+--- snapshot 1
++++ live
+@@ -2,7 +2,7 @@
+ line 2
+ line 3
+ line 4
+-line 5
++changed
+ line 6
+ line 7
+ line 8
+`[1:])
+}
+
+func (s *diffSuite) TestDiffSeparateHunks(c *gc.C) {
+	to := append([]string{"first"}, lines(20)...)
+	to = append(to[:19], to[20:]...)
+	c.Assert(modelsnapshot.Diff("a", text(lines(20)), "b", text(to)), gc.Equals, `
+--- a
++++ b
+@@ -1,3 +1,4 @@
++first
+ line 1
+ line 2
+ line 3
+@@ -16,5 +17,4 @@
+ line 16
+ line 17
+ line 18
+-line 19
+ line 20
+`[1:])
+}
+
+func (s *diffSuite) TestDiffNearbyChangesShareHunk(c *gc.C) {
+	to := lines(12)
+	to[2] = "three"
+	to[8] = "nine"
+	c.Assert(modelsnapshot.Diff("a", text(lines(12)), "b", text(to)), gc.Equals, `
+--- a
++++ b
+@@ -1,12 +1,12 @@
+ line 1
+ line 2
+-line 3
++three
+ line 4
+ line 5
+ line 6
+ line 7
+ line 8
+-line 9
++nine
+ line 10
+ line 11
+ line 12
+`[1:])
+}
+
+func (s *diffSuite) TestDiffFromEmpty(c *gc.C) {
+	c.Assert(modelsnapshot.Diff("a", nil, "b", text(lines(2))), gc.Equals, `
+--- a
++++ b
+@@ -0,0 +1,2 @@
++line 1
++line 2
+`[1:])
+}
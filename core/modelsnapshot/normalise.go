@@ -0,0 +1,59 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelsnapshot provides the normalisation and diffing of the
+// serialized model descriptions that the controller periodically
+// records for each model, so that operators can see how a model's
+// topology and configuration have changed over time.
+package modelsnapshot
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// volatileKey reports whether a key in a model description holds data
+// that changes in the normal running of a model, such as the status of
+// its entities, rather than its topology or configuration.
+func volatileKey(key string) bool {
+	switch key {
+	case "status", "sequences", "password-hash":
+		return true
+	}
+	return strings.HasSuffix(key, "-status") || strings.HasSuffix(key, "-history")
+}
+
+// Normalise returns the serialized model description with the volatile
+// parts of the model removed, and its maps written in a stable order,
+// so that two descriptions of an unchanged model are identical.
+func Normalise(description []byte) ([]byte, error) {
+	var doc interface{}
+	if err := yaml.Unmarshal(description, &doc); err != nil {
+		return nil, errors.Annotate(err, "cannot parse model description")
+	}
+	out, err := yaml.Marshal(stripVolatile(doc))
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot serialize model description")
+	}
+	return out, nil
+}
+
+func stripVolatile(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[interface{}]interface{}:
+		for key, item := range value {
+			if name, ok := key.(string); ok && volatileKey(name) {
+				delete(value, key)
+				continue
+			}
+			value[key] = stripVolatile(item)
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = stripVolatile(item)
+		}
+	}
+	return value
+}
@@ -0,0 +1,66 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelsnapshot_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/modelsnapshot"
+)
+
+type normaliseSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&normaliseSuite{})
+
+func (s *normaliseSuite) TestNormalise(c *gc.C) {
+	out, err := modelsnapshot.Normalise([]byte(`
+version: 1
+sequences:
+  machine: 3
+status:
+  value: available
+applications:
+  version: 1
+  applications:
+  - name: mysql
+    status:
+      value: active
+    status-history:
+      history: []
+    settings:
+      dataset-size: 80%
+    units:
+      units:
+      - name: mysql/0
+        password-hash: xyz
+        agent-status:
+          value: idle
+        workload-version-history:
+          history: []
+        machine: "0"
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(out), gc.Equals, `
+applications:
+  applications:
+  - name: mysql
+    settings:
+      dataset-size: 80%
+    units:
+      units:
+      - machine: "0"
+        name: mysql/0
+  version: 1
+version: 1
+`[1:])
+}
+
+func (s *normaliseSuite) TestNormaliseInvalid(c *gc.C) {
+	_, err := modelsnapshot.Normalise([]byte("{"))
+	c.Assert(err, gc.ErrorMatches, "cannot parse model description: .*")
+}
@@ -0,0 +1,100 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package pricing defines the price catalogs used to estimate the cost
+// of the cloud resources used by a model.
+//
+// All prices are hourly. Catalogs may be static, such as those written
+// by a cloud's operator, or backed by a cloud's own price data; they
+// can be chained so that prices missing from one are taken from the
+// next.
+package pricing
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/constraints"
+)
+
+// HoursPerMonth is the average number of hours in a month, by which
+// hourly prices are multiplied to estimate monthly costs.
+const HoursPerMonth = 730
+
+// Catalog holds the prices of the cloud resources used by models. The
+// methods of a catalog return an error satisfying errors.IsNotFound
+// for resources it has no price for.
+type Catalog interface {
+	// Currency returns the currency in which the catalog's prices
+	// are expressed, such as "USD".
+	Currency() string
+
+	// InstancePrice returns the hourly price of the cheapest
+	// instance satisfying the given constraints, which are expected
+	// to describe a provisioned machine's hardware and instance
+	// type, if known.
+	InstancePrice(cons constraints.Value) (float64, error)
+
+	// StoragePrice returns the hourly price of one GiB of storage
+	// from the named storage pool.
+	StoragePrice(pool string) (float64, error)
+
+	// LoadBalancerPrice returns the hourly price of a load balancer.
+	LoadBalancerPrice() (float64, error)
+}
+
+// Chain returns a Catalog that takes each price from the first of the
+// given catalogs that has it. The chained catalog's currency is that
+// of the first catalog; later catalogs with another currency are
+// ignored.
+func Chain(catalogs ...Catalog) Catalog {
+	var chain chainCatalog
+	for _, catalog := range catalogs {
+		if len(chain) > 0 && catalog.Currency() != chain[0].Currency() {
+			continue
+		}
+		chain = append(chain, catalog)
+	}
+	return chain
+}
+
+type chainCatalog []Catalog
+
+// Currency is part of the Catalog interface.
+func (c chainCatalog) Currency() string {
+	if len(c) == 0 {
+		return ""
+	}
+	return c[0].Currency()
+}
+
+// InstancePrice is part of the Catalog interface.
+func (c chainCatalog) InstancePrice(cons constraints.Value) (float64, error) {
+	return c.first(func(catalog Catalog) (float64, error) {
+		return catalog.InstancePrice(cons)
+	}, "price for instance with %q", cons)
+}
+
+// StoragePrice is part of the Catalog interface.
+func (c chainCatalog) StoragePrice(pool string) (float64, error) {
+	return c.first(func(catalog Catalog) (float64, error) {
+		return catalog.StoragePrice(pool)
+	}, "price for storage pool %q", pool)
+}
+
+// LoadBalancerPrice is part of the Catalog interface.
+func (c chainCatalog) LoadBalancerPrice() (float64, error) {
+	return c.first(func(catalog Catalog) (float64, error) {
+		return catalog.LoadBalancerPrice()
+	}, "price for load balancers")
+}
+
+func (c chainCatalog) first(price func(Catalog) (float64, error), format string, args ...interface{}) (float64, error) {
+	for _, catalog := range c {
+		p, err := price(catalog)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		return p, errors.Trace(err)
+	}
+	return 0, errors.NotFoundf(format, args...)
+}
@@ -0,0 +1,119 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package pricing_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/pricing"
+)
+
+type pricingSuite struct{}
+
+var _ = gc.Suite(&pricingSuite{})
+
+const catalogYAML = `
+currency: USD
+instance-types:
+  m4.large: 0.1
+storage:
+  ebs: 0.0002
+load-balancer: 0.025
+`
+
+func (*pricingSuite) TestParseCatalog(c *gc.C) {
+	catalog, err := pricing.ParseCatalog([]byte(catalogYAML))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(catalog.Currency(), gc.Equals, "USD")
+
+	price, err := catalog.InstancePrice(constraints.MustParse("instance-type=m4.large mem=8G"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(price, gc.Equals, 0.1)
+	price, err = catalog.StoragePrice("ebs")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(price, gc.Equals, 0.0002)
+	price, err = catalog.LoadBalancerPrice()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(price, gc.Equals, 0.025)
+}
+
+func (*pricingSuite) TestStaticCatalogNotFound(c *gc.C) {
+	catalog, err := pricing.ParseCatalog([]byte("currency: EUR"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = catalog.InstancePrice(constraints.MustParse("mem=8G"))
+	c.Check(err, gc.ErrorMatches, "price for instance of unknown type not found")
+	_, err = catalog.InstancePrice(constraints.MustParse("instance-type=m4.large"))
+	c.Check(err, gc.ErrorMatches, `price for instance type "m4.large" not found`)
+	_, err = catalog.StoragePrice("ebs")
+	c.Check(err, gc.ErrorMatches, `price for storage pool "ebs" not found`)
+	_, err = catalog.LoadBalancerPrice()
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (*pricingSuite) TestParseCatalogErrors(c *gc.C) {
+	for i, test := range []struct {
+		yaml   string
+		expect string
+	}{{
+		yaml:   "instance-types: {m4.large: 0.1}",
+		expect: "price catalog without currency not valid",
+	}, {
+		yaml:   "{currency: USD, instance-types: {m4.large: -1}}",
+		expect: `negative price for instance type "m4.large" not valid`,
+	}, {
+		yaml:   "{currency: USD, storage: {ebs: -1}}",
+		expect: `negative price for storage pool "ebs" not valid`,
+	}, {
+		yaml:   "{currency: USD, load-balancer: -1}",
+		expect: "negative price for load balancers not valid",
+	}, {
+		yaml:   "{currency: USD, widgets: 1}",
+		expect: "parsing price catalog: .*",
+	}} {
+		c.Logf("test %d: %s", i, test.yaml)
+		_, err := pricing.ParseCatalog([]byte(test.yaml))
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
+func (*pricingSuite) TestChain(c *gc.C) {
+	first, err := pricing.ParseCatalog([]byte(`
+currency: USD
+instance-types:
+  m4.large: 0.1
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	second, err := pricing.ParseCatalog([]byte(`
+currency: USD
+instance-types:
+  m4.large: 0.2
+  m4.xlarge: 0.4
+storage:
+  ebs: 0.0002
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	otherCurrency, err := pricing.ParseCatalog([]byte(`
+currency: EUR
+load-balancer: 0.02
+`))
+	c.Assert(err, jc.ErrorIsNil)
+
+	catalog := pricing.Chain(first, second, otherCurrency)
+	c.Check(catalog.Currency(), gc.Equals, "USD")
+	price, err := catalog.InstancePrice(constraints.MustParse("instance-type=m4.large"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(price, gc.Equals, 0.1)
+	price, err = catalog.InstancePrice(constraints.MustParse("instance-type=m4.xlarge"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(price, gc.Equals, 0.4)
+	price, err = catalog.StoragePrice("ebs")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(price, gc.Equals, 0.0002)
+	_, err = catalog.LoadBalancerPrice()
+	c.Check(err, gc.ErrorMatches, "price for load balancers not found")
+}
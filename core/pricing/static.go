@@ -0,0 +1,93 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package pricing
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/constraints"
+)
+
+// staticCatalogDoc is the YAML form of a static price catalog, for
+// example:
+//
+//	currency: USD
+//	instance-types:
+//	  m4.large: 0.1
+//	storage:
+//	  ebs: 0.000137
+//	load-balancer: 0.0225
+type staticCatalogDoc struct {
+	Currency      string             `yaml:"currency"`
+	InstanceTypes map[string]float64 `yaml:"instance-types,omitempty"`
+	Storage       map[string]float64 `yaml:"storage,omitempty"`
+	LoadBalancer  *float64           `yaml:"load-balancer,omitempty"`
+}
+
+// ParseCatalog parses a static price catalog from YAML. Instance
+// prices are given per instance type, and storage prices per GiB in
+// each storage pool.
+func ParseCatalog(data []byte) (Catalog, error) {
+	var doc staticCatalogDoc
+	if err := yaml.UnmarshalStrict(data, &doc); err != nil {
+		return nil, errors.Annotate(err, "parsing price catalog")
+	}
+	if doc.Currency == "" {
+		return nil, errors.NotValidf("price catalog without currency")
+	}
+	for name, price := range doc.InstanceTypes {
+		if price < 0 {
+			return nil, errors.NotValidf("negative price for instance type %q", name)
+		}
+	}
+	for pool, price := range doc.Storage {
+		if price < 0 {
+			return nil, errors.NotValidf("negative price for storage pool %q", pool)
+		}
+	}
+	if doc.LoadBalancer != nil && *doc.LoadBalancer < 0 {
+		return nil, errors.NotValidf("negative price for load balancers")
+	}
+	return &staticCatalog{doc}, nil
+}
+
+type staticCatalog struct {
+	doc staticCatalogDoc
+}
+
+// Currency is part of the Catalog interface.
+func (c *staticCatalog) Currency() string {
+	return c.doc.Currency
+}
+
+// InstancePrice is part of the Catalog interface. Static catalogs only
+// know the prices of instances whose type is given.
+func (c *staticCatalog) InstancePrice(cons constraints.Value) (float64, error) {
+	if !cons.HasInstanceType() {
+		return 0, errors.NotFoundf("price for instance of unknown type")
+	}
+	price, ok := c.doc.InstanceTypes[*cons.InstanceType]
+	if !ok {
+		return 0, errors.NotFoundf("price for instance type %q", *cons.InstanceType)
+	}
+	return price, nil
+}
+
+// StoragePrice is part of the Catalog interface.
+func (c *staticCatalog) StoragePrice(pool string) (float64, error) {
+	price, ok := c.doc.Storage[pool]
+	if !ok {
+		return 0, errors.NotFoundf("price for storage pool %q", pool)
+	}
+	return price, nil
+}
+
+// LoadBalancerPrice is part of the Catalog interface.
+func (c *staticCatalog) LoadBalancerPrice() (float64, error) {
+	if c.doc.LoadBalancer == nil {
+		return 0, errors.NotFoundf("price for load balancers")
+	}
+	return *c.doc.LoadBalancer, nil
+}
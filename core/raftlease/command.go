@@ -0,0 +1,121 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftlease
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/lease"
+)
+
+// CommandVersion is the version of the command format this package
+// reads and writes.
+const CommandVersion = 1
+
+const (
+	// OperationClaim claims a lease that is not currently held.
+	OperationClaim = "claim"
+
+	// OperationExtend extends a lease held by the command's holder.
+	OperationExtend = "extend"
+
+	// OperationExpire removes a lease once it has expired.
+	OperationExpire = "expire"
+
+	// OperationSetTime advances the FSM's global time.
+	OperationSetTime = "setTime"
+)
+
+// Command captures a single change to the FSM's state.
+type Command struct {
+	// Version is the version of the command format.
+	Version int `json:"version"`
+
+	// Operation identifies the change to make.
+	Operation string `json:"operation"`
+
+	// ModelUUID identifies the model owning the lease.
+	ModelUUID string `json:"model-uuid,omitempty"`
+
+	// Namespace identifies the group of leases to which the lease
+	// belongs.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Lease is the name of the lease.
+	Lease string `json:"lease,omitempty"`
+
+	// Holder is the name of the (would-be) lease holder.
+	Holder string `json:"holder,omitempty"`
+
+	// Duration is the requested duration of the lease.
+	Duration time.Duration `json:"duration,omitempty"`
+
+	// OldTime is the global time the proposer of a setTime command
+	// last saw; the command fails if the global time has since changed.
+	OldTime time.Time `json:"old-time,omitempty"`
+
+	// NewTime is the global time to move to.
+	NewTime time.Time `json:"new-time,omitempty"`
+}
+
+// Validate returns an error if the command is not valid.
+func (c Command) Validate() error {
+	if c.Version != CommandVersion {
+		return errors.NotValidf("version %d", c.Version)
+	}
+	switch c.Operation {
+	case OperationClaim, OperationExtend:
+		if err := c.validateLease(); err != nil {
+			return errors.Trace(err)
+		}
+		if err := (lease.Request{Holder: c.Holder, Duration: c.Duration}).Validate(); err != nil {
+			return errors.NewNotValid(err, "request")
+		}
+	case OperationExpire:
+		if err := c.validateLease(); err != nil {
+			return errors.Trace(err)
+		}
+	case OperationSetTime:
+		if c.NewTime.Before(c.OldTime) {
+			return errors.NotValidf("new time before old time")
+		}
+	default:
+		return errors.NotValidf("operation %q", c.Operation)
+	}
+	return nil
+}
+
+func (c Command) validateLease() error {
+	if err := lease.ValidateString(c.ModelUUID); err != nil {
+		return errors.NewNotValid(err, "model UUID")
+	}
+	if err := lease.ValidateString(c.Namespace); err != nil {
+		return errors.NewNotValid(err, "namespace")
+	}
+	if err := lease.ValidateString(c.Lease); err != nil {
+		return errors.NewNotValid(err, "lease")
+	}
+	return nil
+}
+
+// Marshal serialises the command for replication.
+func (c Command) Marshal() ([]byte, error) {
+	data, err := json.Marshal(c)
+	return data, errors.Trace(err)
+}
+
+// UnmarshalCommand deserialises and validates a replicated command.
+func UnmarshalCommand(data []byte) (Command, error) {
+	var c Command
+	if err := json.Unmarshal(data, &c); err != nil {
+		return Command{}, errors.Annotate(err, "unmarshalling command")
+	}
+	if err := c.Validate(); err != nil {
+		return Command{}, errors.Trace(err)
+	}
+	return c, nil
+}
@@ -0,0 +1,38 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+/*
+Package raftlease implements lease storage as a finite state machine
+whose commands are replicated among the controller machines by raft,
+as an alternative to storing leases with mgo/txn.
+
+Every change to lease state -- claiming, extending and expiring a
+lease, and advancing the global clock -- is expressed as a Command,
+serialised and handed to an Applier. The Applier is responsible for
+replicating the command to a quorum of controllers and then applying
+it, in the same order on every controller, to that controller's FSM.
+Since the FSM only ever changes in response to commands, and applying
+a command depends only on the FSM's existing state and the command
+itself, every controller's FSM holds the same lease state.
+
+Lease expiry is judged against the FSM's global time, which is only
+advanced by commands; wall-clock time on the controller applying a
+command never affects the result. A Store advances the global time
+when used as a globalclock.Updater.
+
+A Store implements lease.Client for a single model and namespace, and
+Store.Import claims leases carried over from elsewhere, such as the
+mongo-backed client in the state package. A Store can tell a
+NotifyTarget about the claims and expiries it makes, and can supply
+the lease.Trapdoor for each lease it reports.
+
+The worker/raft package provides an Applier that replicates commands
+between the controllers' raft workers over the central hub. When the
+machine agent opens State with the FSM and that Applier, the State's
+lease managers use Stores in place of the leases collection: the
+leases are imported from mongo the first time each namespace is used,
+the holders are recorded in mongo through the NotifyTarget, and the
+trapdoors assert on those records, so that leadership-gated
+transactions still fail when leadership is lost.
+*/
+package raftlease
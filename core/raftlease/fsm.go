@@ -0,0 +1,244 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftlease
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/globalclock"
+	"github.com/juju/juju/core/lease"
+)
+
+// Entry holds the state of a single lease.
+type Entry struct {
+	// Holder is the name of the current lease holder.
+	Holder string
+
+	// Start is the global time at which the lease was last claimed
+	// or extended.
+	Start time.Time
+
+	// Duration is the duration of the lease from Start.
+	Duration time.Duration
+}
+
+// Expiry returns the global time at which the lease expires.
+func (e Entry) Expiry() time.Time {
+	return e.Start.Add(e.Duration)
+}
+
+type leaseKey struct {
+	modelUUID string
+	namespace string
+	lease     string
+}
+
+// FSM holds lease state for all models and namespaces, and changes it
+// only in response to commands. It is safe for concurrent use.
+type FSM struct {
+	mu         sync.Mutex
+	globalTime time.Time
+	entries    map[leaseKey]Entry
+}
+
+// NewFSM returns a new FSM with no leases.
+func NewFSM() *FSM {
+	return &FSM{entries: make(map[leaseKey]Entry)}
+}
+
+// Apply deserialises and applies a replicated command. If the command
+// is valid but conflicts with the FSM's state, an error satisfying
+// errors.Cause(err) == lease.ErrInvalid, or globalclock.ErrConcurrentUpdate
+// for setTime commands, is returned and the state is unchanged.
+func (f *FSM) Apply(data []byte) error {
+	command, err := UnmarshalCommand(data)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := leaseKey{
+		modelUUID: command.ModelUUID,
+		namespace: command.Namespace,
+		lease:     command.Lease,
+	}
+	switch command.Operation {
+	case OperationClaim:
+		return f.claim(key, command.Holder, command.Duration)
+	case OperationExtend:
+		return f.extend(key, command.Holder, command.Duration)
+	case OperationExpire:
+		return f.expire(key)
+	case OperationSetTime:
+		return f.setTime(command.OldTime, command.NewTime)
+	}
+	// Validated by UnmarshalCommand.
+	return errors.NotValidf("operation %q", command.Operation)
+}
+
+func (f *FSM) claim(key leaseKey, holder string, duration time.Duration) error {
+	if _, found := f.entries[key]; found {
+		return lease.ErrInvalid
+	}
+	f.entries[key] = Entry{
+		Holder:   holder,
+		Start:    f.globalTime,
+		Duration: duration,
+	}
+	return nil
+}
+
+func (f *FSM) extend(key leaseKey, holder string, duration time.Duration) error {
+	entry, found := f.entries[key]
+	if !found || entry.Holder != holder {
+		return lease.ErrInvalid
+	}
+	if !f.globalTime.Add(duration).After(entry.Expiry()) {
+		// The existing lease already lasts long enough.
+		return nil
+	}
+	f.entries[key] = Entry{
+		Holder:   holder,
+		Start:    f.globalTime,
+		Duration: duration,
+	}
+	return nil
+}
+
+func (f *FSM) expire(key leaseKey) error {
+	entry, found := f.entries[key]
+	if !found {
+		return lease.ErrInvalid
+	}
+	if !f.globalTime.After(entry.Expiry()) {
+		return errors.Annotatef(lease.ErrInvalid, "lease %q expires in the future", key.lease)
+	}
+	delete(f.entries, key)
+	return nil
+}
+
+func (f *FSM) setTime(oldTime, newTime time.Time) error {
+	if !oldTime.Equal(f.globalTime) {
+		return globalclock.ErrConcurrentUpdate
+	}
+	f.globalTime = newTime
+	return nil
+}
+
+// GlobalTime returns the FSM's current global time.
+func (f *FSM) GlobalTime() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.globalTime
+}
+
+// Leases returns the leases in the given model and namespace, keyed
+// on lease name.
+func (f *FSM) Leases(modelUUID, namespace string) map[string]Entry {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	result := make(map[string]Entry)
+	for key, entry := range f.entries {
+		if key.modelUUID == modelUUID && key.namespace == namespace {
+			result[key.lease] = entry
+		}
+	}
+	return result
+}
+
+// snapshot is the serialised form of the FSM's state.
+type snapshot struct {
+	Version    int             `json:"version"`
+	GlobalTime time.Time       `json:"global-time"`
+	Entries    []snapshotEntry `json:"entries"`
+}
+
+type snapshotEntry struct {
+	ModelUUID string        `json:"model-uuid"`
+	Namespace string        `json:"namespace"`
+	Lease     string        `json:"lease"`
+	Holder    string        `json:"holder"`
+	Start     time.Time     `json:"start"`
+	Duration  time.Duration `json:"duration"`
+}
+
+// Snapshot returns a serialised copy of the FSM's state, from which
+// another FSM can be restored.
+func (f *FSM) Snapshot() ([]byte, error) {
+	f.mu.Lock()
+	s := snapshot{
+		Version:    CommandVersion,
+		GlobalTime: f.globalTime,
+		Entries:    make([]snapshotEntry, 0, len(f.entries)),
+	}
+	for key, entry := range f.entries {
+		s.Entries = append(s.Entries, snapshotEntry{
+			ModelUUID: key.modelUUID,
+			Namespace: key.namespace,
+			Lease:     key.lease,
+			Holder:    entry.Holder,
+			Start:     entry.Start,
+			Duration:  entry.Duration,
+		})
+	}
+	f.mu.Unlock()
+
+	sort.Slice(s.Entries, func(i, j int) bool {
+		a, b := s.Entries[i], s.Entries[j]
+		if a.ModelUUID != b.ModelUUID {
+			return a.ModelUUID < b.ModelUUID
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Lease < b.Lease
+	})
+	data, err := json.Marshal(s)
+	return data, errors.Trace(err)
+}
+
+// Restore replaces the FSM's state with that in the given snapshot.
+// An empty snapshot restores the FSM to its initial state, with no
+// leases.
+func (f *FSM) Restore(data []byte) error {
+	if len(data) == 0 {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.globalTime = time.Time{}
+		f.entries = make(map[leaseKey]Entry)
+		return nil
+	}
+	var s snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return errors.Annotate(err, "unmarshalling snapshot")
+	}
+	if s.Version != CommandVersion {
+		return errors.NotValidf("snapshot version %d", s.Version)
+	}
+	entries := make(map[leaseKey]Entry, len(s.Entries))
+	for _, e := range s.Entries {
+		key := leaseKey{
+			modelUUID: e.ModelUUID,
+			namespace: e.Namespace,
+			lease:     e.Lease,
+		}
+		entries[key] = Entry{
+			Holder:   e.Holder,
+			Start:    e.Start,
+			Duration: e.Duration,
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.globalTime = s.GlobalTime
+	f.entries = entries
+	return nil
+}
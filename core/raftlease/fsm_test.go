@@ -0,0 +1,175 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftlease_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/globalclock"
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/core/raftlease"
+)
+
+const modelUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+
+type fsmSuite struct {
+	testing.IsolationSuite
+	fsm *raftlease.FSM
+}
+
+var _ = gc.Suite(&fsmSuite{})
+
+func (s *fsmSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.fsm = raftlease.NewFSM()
+}
+
+func (s *fsmSuite) apply(c *gc.C, command raftlease.Command) error {
+	command.Version = raftlease.CommandVersion
+	if command.Operation != raftlease.OperationSetTime {
+		command.ModelUUID = modelUUID
+		command.Namespace = "leadership"
+	}
+	data, err := command.Marshal()
+	c.Assert(err, jc.ErrorIsNil)
+	return s.fsm.Apply(data)
+}
+
+func (s *fsmSuite) claim(c *gc.C, name, holder string, duration time.Duration) error {
+	return s.apply(c, raftlease.Command{
+		Operation: raftlease.OperationClaim,
+		Lease:     name,
+		Holder:    holder,
+		Duration:  duration,
+	})
+}
+
+func (s *fsmSuite) advance(c *gc.C, d time.Duration) {
+	oldTime := s.fsm.GlobalTime()
+	err := s.apply(c, raftlease.Command{
+		Operation: raftlease.OperationSetTime,
+		OldTime:   oldTime,
+		NewTime:   oldTime.Add(d),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *fsmSuite) TestClaim(c *gc.C) {
+	s.advance(c, time.Hour)
+	err := s.claim(c, "mysql", "mysql/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fsm.Leases(modelUUID, "leadership"), jc.DeepEquals, map[string]raftlease.Entry{
+		"mysql": {
+			Holder:   "mysql/0",
+			Start:    time.Time{}.Add(time.Hour),
+			Duration: time.Minute,
+		},
+	})
+	c.Assert(s.fsm.Leases(modelUUID, "singular"), gc.HasLen, 0)
+
+	err = s.claim(c, "mysql", "mysql/1", time.Minute)
+	c.Assert(err, gc.Equals, lease.ErrInvalid)
+}
+
+func (s *fsmSuite) TestExtend(c *gc.C) {
+	err := s.claim(c, "mysql", "mysql/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	s.advance(c, 30*time.Second)
+
+	extend := raftlease.Command{
+		Operation: raftlease.OperationExtend,
+		Lease:     "mysql",
+		Holder:    "mysql/0",
+		Duration:  time.Minute,
+	}
+	err = s.apply(c, extend)
+	c.Assert(err, jc.ErrorIsNil)
+	entry := s.fsm.Leases(modelUUID, "leadership")["mysql"]
+	c.Assert(entry.Expiry(), gc.Equals, time.Time{}.Add(90*time.Second))
+
+	// A shorter extension leaves the lease alone.
+	extend.Duration = time.Second
+	err = s.apply(c, extend)
+	c.Assert(err, jc.ErrorIsNil)
+	entry = s.fsm.Leases(modelUUID, "leadership")["mysql"]
+	c.Assert(entry.Expiry(), gc.Equals, time.Time{}.Add(90*time.Second))
+
+	extend.Holder = "mysql/1"
+	err = s.apply(c, extend)
+	c.Assert(err, gc.Equals, lease.ErrInvalid)
+}
+
+func (s *fsmSuite) TestExpire(c *gc.C) {
+	err := s.claim(c, "mysql", "mysql/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	expire := raftlease.Command{
+		Operation: raftlease.OperationExpire,
+		Lease:     "mysql",
+	}
+	s.advance(c, time.Minute)
+	err = s.apply(c, expire)
+	c.Assert(err, gc.ErrorMatches, `lease "mysql" expires in the future: invalid lease operation`)
+
+	s.advance(c, time.Second)
+	err = s.apply(c, expire)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fsm.Leases(modelUUID, "leadership"), gc.HasLen, 0)
+
+	err = s.apply(c, expire)
+	c.Assert(err, gc.Equals, lease.ErrInvalid)
+}
+
+func (s *fsmSuite) TestSetTimeConcurrentUpdate(c *gc.C) {
+	s.advance(c, time.Second)
+	err := s.apply(c, raftlease.Command{
+		Operation: raftlease.OperationSetTime,
+		NewTime:   time.Time{}.Add(time.Minute),
+	})
+	c.Assert(err, gc.Equals, globalclock.ErrConcurrentUpdate)
+	c.Assert(s.fsm.GlobalTime(), gc.Equals, time.Time{}.Add(time.Second))
+}
+
+func (s *fsmSuite) TestInvalidCommand(c *gc.C) {
+	err := s.fsm.Apply([]byte(`{"version": 1, "operation": "claim", "model-uuid": "x", "namespace": "y", "lease": "z"}`))
+	c.Assert(err, gc.ErrorMatches, `request: invalid holder: string is empty`)
+
+	err = s.fsm.Apply([]byte(`{"version": 2, "operation": "expire"}`))
+	c.Assert(err, gc.ErrorMatches, `version 2 not valid`)
+
+	err = s.fsm.Apply([]byte(`{"version": 1, "operation": "explode"}`))
+	c.Assert(err, gc.ErrorMatches, `operation "explode" not valid`)
+}
+
+func (s *fsmSuite) TestSnapshotRestore(c *gc.C) {
+	err := s.claim(c, "mysql", "mysql/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.claim(c, "wordpress", "wordpress/2", time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	s.advance(c, time.Second)
+
+	data, err := s.fsm.Snapshot()
+	c.Assert(err, jc.ErrorIsNil)
+
+	restored := raftlease.NewFSM()
+	err = restored.Restore(data)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(restored.GlobalTime(), gc.Equals, s.fsm.GlobalTime())
+	c.Assert(restored.Leases(modelUUID, "leadership"), jc.DeepEquals, s.fsm.Leases(modelUUID, "leadership"))
+}
+
+func (s *fsmSuite) TestRestoreEmpty(c *gc.C) {
+	err := s.claim(c, "mysql", "mysql/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	s.advance(c, time.Second)
+
+	err = s.fsm.Restore(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fsm.GlobalTime(), gc.Equals, time.Time{})
+	c.Assert(s.fsm.Leases(modelUUID, "leadership"), gc.HasLen, 0)
+}
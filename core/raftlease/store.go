@@ -0,0 +1,287 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftlease
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/core/globalclock"
+	"github.com/juju/juju/core/lease"
+)
+
+var logger = loggo.GetLogger("juju.core.raftlease")
+
+// Applier replicates commands among the controllers.
+type Applier interface {
+	// Apply replicates the serialised command to a quorum of
+	// controllers, applies it to the local FSM, and returns the
+	// error returned by FSM.Apply. It must not return until the
+	// local FSM has been updated, or timeout has passed.
+	Apply(command []byte, timeout time.Duration) error
+}
+
+// LocalClock provides the writer-local wall clock used to express
+// lease expiry times to callers.
+type LocalClock interface {
+	// Now returns the current, writer-local wall-clock time.
+	Now() time.Time
+}
+
+// StoreConfig holds the resources and configuration needed to create
+// a Store.
+type StoreConfig struct {
+	// FSM is the local copy of the replicated lease state.
+	FSM *FSM
+
+	// Applier replicates changes to the lease state.
+	Applier Applier
+
+	// ModelUUID identifies the model whose leases the Store manages.
+	ModelUUID string
+
+	// Namespace identifies the group of leases the Store manages.
+	Namespace string
+
+	// LocalClock is used to convert global expiry times to local
+	// ones.
+	LocalClock LocalClock
+
+	// ApplyTimeout is the maximum time to wait for a command to be
+	// replicated.
+	ApplyTimeout time.Duration
+
+	// Trapdoor, if non-nil, returns the lease.Trapdoor for a lease
+	// held by the given holder. If it is nil, leases have locked
+	// trapdoors.
+	Trapdoor func(lease, holder string) lease.Trapdoor
+
+	// Target, if non-nil, is told when the Store claims or expires
+	// a lease.
+	Target NotifyTarget
+}
+
+// NotifyTarget is told about changes made through a Store, so that
+// it can record lease holders somewhere other components can check
+// them; the state package records them in mongo, so that its
+// transactions can assert on them via the leases' trapdoors.
+//
+// Changes made by the lease managers on other controllers are not
+// reported to the target, so a Target must tolerate being told about
+// a change that has already been made.
+type NotifyTarget interface {
+	// Claimed is called when the holder claims the lease.
+	Claimed(lease, holder string) error
+
+	// Expired is called when the lease expires.
+	Expired(lease string) error
+}
+
+// Validate returns an error if the config is not valid.
+func (config StoreConfig) Validate() error {
+	if config.FSM == nil {
+		return errors.NotValidf("nil FSM")
+	}
+	if config.Applier == nil {
+		return errors.NotValidf("nil Applier")
+	}
+	if err := lease.ValidateString(config.ModelUUID); err != nil {
+		return errors.NewNotValid(err, "ModelUUID")
+	}
+	if err := lease.ValidateString(config.Namespace); err != nil {
+		return errors.NewNotValid(err, "Namespace")
+	}
+	if config.LocalClock == nil {
+		return errors.NotValidf("nil LocalClock")
+	}
+	if config.ApplyTimeout <= 0 {
+		return errors.NotValidf("non-positive ApplyTimeout")
+	}
+	return nil
+}
+
+// Store implements lease.Client and globalclock.Updater on top of a
+// replicated FSM.
+type Store struct {
+	config StoreConfig
+}
+
+var (
+	_ lease.Client        = (*Store)(nil)
+	_ globalclock.Updater = (*Store)(nil)
+)
+
+// NewStore returns a Store that manages the leases in the configured
+// model and namespace.
+func NewStore(config StoreConfig) (*Store, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Store{config: config}, nil
+}
+
+// ClaimLease is part of the lease.Client interface.
+func (s *Store) ClaimLease(name string, request lease.Request) error {
+	if err := s.validateRequest(name, request); err != nil {
+		return errors.Trace(err)
+	}
+	// Avoid replicating a command we know the FSM will reject.
+	if _, found := s.entries()[name]; found {
+		return lease.ErrInvalid
+	}
+	if err := s.apply(Command{
+		Operation: OperationClaim,
+		Lease:     name,
+		Holder:    request.Holder,
+		Duration:  request.Duration,
+	}); err != nil {
+		return err
+	}
+	if s.config.Target != nil {
+		if err := s.config.Target.Claimed(name, request.Holder); err != nil {
+			return errors.Annotatef(err, "recording claim of lease %q", name)
+		}
+	}
+	return nil
+}
+
+// ExtendLease is part of the lease.Client interface.
+func (s *Store) ExtendLease(name string, request lease.Request) error {
+	if err := s.validateRequest(name, request); err != nil {
+		return errors.Trace(err)
+	}
+	entry, found := s.entries()[name]
+	if !found || entry.Holder != request.Holder {
+		return lease.ErrInvalid
+	}
+	return s.apply(Command{
+		Operation: OperationExtend,
+		Lease:     name,
+		Holder:    request.Holder,
+		Duration:  request.Duration,
+	})
+}
+
+// ExpireLease is part of the lease.Client interface.
+func (s *Store) ExpireLease(name string) error {
+	if err := lease.ValidateString(name); err != nil {
+		return errors.Annotatef(err, "invalid name")
+	}
+	if err := s.apply(Command{
+		Operation: OperationExpire,
+		Lease:     name,
+	}); err != nil {
+		return err
+	}
+	if s.config.Target != nil {
+		if err := s.config.Target.Expired(name); err != nil {
+			return errors.Annotatef(err, "recording expiry of lease %q", name)
+		}
+	}
+	return nil
+}
+
+// Leases is part of the lease.Client interface.
+func (s *Store) Leases() map[string]lease.Info {
+	globalTime := s.config.FSM.GlobalTime()
+	localTime := s.config.LocalClock.Now()
+	leases := make(map[string]lease.Info)
+	for name, entry := range s.entries() {
+		remaining := entry.Expiry().Sub(globalTime)
+		trapdoor := lease.LockedTrapdoor
+		if s.config.Trapdoor != nil {
+			trapdoor = s.config.Trapdoor(name, entry.Holder)
+		}
+		leases[name] = lease.Info{
+			Holder:   entry.Holder,
+			Expiry:   localTime.Add(remaining),
+			Trapdoor: trapdoor,
+		}
+	}
+	return leases
+}
+
+// Refresh is part of the lease.Client interface. The FSM is kept up
+// to date as commands are replicated, so there is nothing to do.
+func (s *Store) Refresh() error {
+	return nil
+}
+
+// Advance is part of the globalclock.Updater interface.
+func (s *Store) Advance(d time.Duration) error {
+	oldTime := s.config.FSM.GlobalTime()
+	return s.apply(Command{
+		Operation: OperationSetTime,
+		OldTime:   oldTime,
+		NewTime:   oldTime.Add(d),
+	})
+}
+
+// Import claims, in the Store, each of the given unexpired leases for
+// the remainder of its duration. It is intended to carry leases over
+// from the mongo-backed lease client. The Store's own leases take
+// precedence, so leases it already holds are left alone, whoever
+// holds them.
+func (s *Store) Import(leases map[string]lease.Info) error {
+	now := s.config.LocalClock.Now()
+	for name, info := range leases {
+		remaining := info.Expiry.Sub(now)
+		if remaining <= 0 {
+			logger.Debugf("not importing expired lease %q", name)
+			continue
+		}
+		err := s.ClaimLease(name, lease.Request{
+			Holder:   info.Holder,
+			Duration: remaining,
+		})
+		if errors.Cause(err) == lease.ErrInvalid {
+			logger.Debugf("not importing lease %q, already held", name)
+			continue
+		} else if err != nil {
+			return errors.Annotatef(err, "importing lease %q", name)
+		}
+	}
+	return nil
+}
+
+func (s *Store) validateRequest(name string, request lease.Request) error {
+	if err := lease.ValidateString(name); err != nil {
+		return errors.Annotatef(err, "invalid name")
+	}
+	if err := request.Validate(); err != nil {
+		return errors.Annotatef(err, "invalid request")
+	}
+	return nil
+}
+
+func (s *Store) entries() map[string]Entry {
+	return s.config.FSM.Leases(s.config.ModelUUID, s.config.Namespace)
+}
+
+// apply replicates the command, returning lease.ErrInvalid or
+// globalclock.ErrConcurrentUpdate unannotated so that callers can
+// compare them directly.
+func (s *Store) apply(command Command) error {
+	command.Version = CommandVersion
+	if command.Operation != OperationSetTime {
+		command.ModelUUID = s.config.ModelUUID
+		command.Namespace = s.config.Namespace
+	}
+	data, err := command.Marshal()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = s.config.Applier.Apply(data, s.config.ApplyTimeout)
+	switch errors.Cause(err) {
+	case nil:
+		return nil
+	case lease.ErrInvalid:
+		return lease.ErrInvalid
+	case globalclock.ErrConcurrentUpdate:
+		return globalclock.ErrConcurrentUpdate
+	}
+	return errors.Annotatef(err, "applying %s command", command.Operation)
+}
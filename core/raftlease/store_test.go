@@ -0,0 +1,265 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftlease_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/globalclock"
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/core/raftlease"
+)
+
+type storeSuite struct {
+	testing.IsolationSuite
+	fsm     *raftlease.FSM
+	applier *fakeApplier
+	clock   *testing.Clock
+	config  raftlease.StoreConfig
+}
+
+var _ = gc.Suite(&storeSuite{})
+
+func (s *storeSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.fsm = raftlease.NewFSM()
+	s.applier = &fakeApplier{fsm: s.fsm}
+	s.clock = testing.NewClock(time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC))
+	s.config = raftlease.StoreConfig{
+		FSM:          s.fsm,
+		Applier:      s.applier,
+		ModelUUID:    modelUUID,
+		Namespace:    "leadership",
+		LocalClock:   s.clock,
+		ApplyTimeout: time.Second,
+	}
+}
+
+func (s *storeSuite) newStore(c *gc.C) *raftlease.Store {
+	store, err := raftlease.NewStore(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	return store
+}
+
+func (s *storeSuite) TestValidate(c *gc.C) {
+	for _, test := range []struct {
+		f      func(*raftlease.StoreConfig)
+		expect string
+	}{
+		{func(cfg *raftlease.StoreConfig) { cfg.FSM = nil }, "nil FSM not valid"},
+		{func(cfg *raftlease.StoreConfig) { cfg.Applier = nil }, "nil Applier not valid"},
+		{func(cfg *raftlease.StoreConfig) { cfg.ModelUUID = "" }, "ModelUUID: string is empty"},
+		{func(cfg *raftlease.StoreConfig) { cfg.Namespace = "a b" }, "Namespace: string contains forbidden characters"},
+		{func(cfg *raftlease.StoreConfig) { cfg.LocalClock = nil }, "nil LocalClock not valid"},
+		{func(cfg *raftlease.StoreConfig) { cfg.ApplyTimeout = 0 }, "non-positive ApplyTimeout not valid"},
+	} {
+		config := s.config
+		test.f(&config)
+		_, err := raftlease.NewStore(config)
+		c.Check(err, gc.ErrorMatches, test.expect)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *storeSuite) TestClaimLease(c *gc.C) {
+	store := s.newStore(c)
+	err := store.ClaimLease("mysql", lease.Request{Holder: "mysql/0", Duration: time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.applier.calls, gc.Equals, 1)
+
+	leases := store.Leases()
+	c.Assert(leases, gc.HasLen, 1)
+	c.Assert(leases["mysql"].Holder, gc.Equals, "mysql/0")
+	c.Assert(leases["mysql"].Expiry, gc.Equals, s.clock.Now().Add(time.Minute))
+
+	// A second claim is rejected without replicating anything.
+	err = store.ClaimLease("mysql", lease.Request{Holder: "mysql/1", Duration: time.Minute})
+	c.Assert(err, gc.Equals, lease.ErrInvalid)
+	c.Assert(s.applier.calls, gc.Equals, 1)
+}
+
+func (s *storeSuite) TestClaimLeaseInvalid(c *gc.C) {
+	store := s.newStore(c)
+	err := store.ClaimLease("my sql", lease.Request{Holder: "mysql/0", Duration: time.Minute})
+	c.Assert(err, gc.ErrorMatches, "invalid name: string contains forbidden characters")
+	err = store.ClaimLease("mysql", lease.Request{Holder: "mysql/0"})
+	c.Assert(err, gc.ErrorMatches, "invalid request: invalid duration")
+	c.Assert(s.applier.calls, gc.Equals, 0)
+}
+
+func (s *storeSuite) TestExtendAndExpireLease(c *gc.C) {
+	store := s.newStore(c)
+	err := store.ClaimLease("mysql", lease.Request{Holder: "mysql/0", Duration: time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = store.ExtendLease("mysql", lease.Request{Holder: "mysql/1", Duration: time.Minute})
+	c.Assert(err, gc.Equals, lease.ErrInvalid)
+
+	err = store.Advance(30 * time.Second)
+	c.Assert(err, jc.ErrorIsNil)
+	err = store.ExtendLease("mysql", lease.Request{Holder: "mysql/0", Duration: time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(store.Leases()["mysql"].Expiry, gc.Equals, s.clock.Now().Add(time.Minute))
+
+	err = store.ExpireLease("mysql")
+	c.Assert(err, gc.Equals, lease.ErrInvalid)
+	err = store.Advance(2 * time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	err = store.ExpireLease("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(store.Leases(), gc.HasLen, 0)
+}
+
+func (s *storeSuite) TestAdvanceConcurrentUpdate(c *gc.C) {
+	store := s.newStore(c)
+	s.applier.err = globalclock.ErrConcurrentUpdate
+	err := store.Advance(time.Second)
+	c.Assert(err, gc.Equals, globalclock.ErrConcurrentUpdate)
+}
+
+func (s *storeSuite) TestApplyError(c *gc.C) {
+	store := s.newStore(c)
+	s.applier.err = errors.New("no leader")
+	err := store.ClaimLease("mysql", lease.Request{Holder: "mysql/0", Duration: time.Minute})
+	c.Assert(err, gc.ErrorMatches, "applying claim command: no leader")
+}
+
+func (s *storeSuite) TestLeasesTrapdoorLocked(c *gc.C) {
+	store := s.newStore(c)
+	err := store.ClaimLease("mysql", lease.Request{Holder: "mysql/0", Duration: time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+	trapdoor := store.Leases()["mysql"].Trapdoor
+	c.Assert(trapdoor(nil), jc.ErrorIsNil)
+	c.Assert(trapdoor(&struct{}{}), gc.ErrorMatches, "lease substrate not accessible")
+}
+
+func (s *storeSuite) TestLeasesTrapdoor(c *gc.C) {
+	s.config.Trapdoor = func(name, holder string) lease.Trapdoor {
+		return func(key interface{}) error {
+			*(key.(*string)) = name + " held by " + holder
+			return nil
+		}
+	}
+	store := s.newStore(c)
+	err := store.ClaimLease("mysql", lease.Request{Holder: "mysql/0", Duration: time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var out string
+	err = store.Leases()["mysql"].Trapdoor(&out)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "mysql held by mysql/0")
+}
+
+func (s *storeSuite) TestTarget(c *gc.C) {
+	target := &fakeTarget{}
+	s.config.Target = target
+	store := s.newStore(c)
+	err := store.ClaimLease("mysql", lease.Request{Holder: "mysql/0", Duration: time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+	err = store.ExtendLease("mysql", lease.Request{Holder: "mysql/0", Duration: time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+	err = store.Advance(2 * time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	err = store.ExpireLease("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+
+	target.CheckCalls(c, []testing.StubCall{
+		{FuncName: "Claimed", Args: []interface{}{"mysql", "mysql/0"}},
+		{FuncName: "Expired", Args: []interface{}{"mysql"}},
+	})
+}
+
+func (s *storeSuite) TestTargetNotToldOfRejectedClaim(c *gc.C) {
+	target := &fakeTarget{}
+	s.config.Target = target
+	store := s.newStore(c)
+	s.applier.err = lease.ErrInvalid
+	err := store.ClaimLease("mysql", lease.Request{Holder: "mysql/0", Duration: time.Minute})
+	c.Assert(err, gc.Equals, lease.ErrInvalid)
+	target.CheckNoCalls(c)
+}
+
+func (s *storeSuite) TestTargetError(c *gc.C) {
+	target := &fakeTarget{}
+	target.SetErrors(errors.New("oops"))
+	s.config.Target = target
+	store := s.newStore(c)
+	err := store.ClaimLease("mysql", lease.Request{Holder: "mysql/0", Duration: time.Minute})
+	c.Assert(err, gc.ErrorMatches, `recording claim of lease "mysql": oops`)
+}
+
+func (s *storeSuite) TestImport(c *gc.C) {
+	store := s.newStore(c)
+	err := store.ClaimLease("mysql", lease.Request{Holder: "mysql/0", Duration: time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = store.Import(map[string]lease.Info{
+		"mysql":     {Holder: "mysql/0", Expiry: s.clock.Now().Add(time.Hour)},
+		"wordpress": {Holder: "wordpress/1", Expiry: s.clock.Now().Add(time.Minute)},
+		"mediawiki": {Holder: "mediawiki/0", Expiry: s.clock.Now().Add(-time.Second)},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	leases := store.Leases()
+	c.Assert(leases, gc.HasLen, 2)
+	c.Assert(leases["mysql"].Expiry, gc.Equals, s.clock.Now().Add(time.Minute))
+	c.Assert(leases["wordpress"].Holder, gc.Equals, "wordpress/1")
+	c.Assert(leases["wordpress"].Expiry, gc.Equals, s.clock.Now().Add(time.Minute))
+}
+
+func (s *storeSuite) TestImportKeepsExistingHolder(c *gc.C) {
+	store := s.newStore(c)
+	err := store.ClaimLease("mysql", lease.Request{Holder: "mysql/0", Duration: time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = store.Import(map[string]lease.Info{
+		"mysql": {Holder: "mysql/1", Expiry: s.clock.Now().Add(time.Hour)},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(store.Leases()["mysql"].Holder, gc.Equals, "mysql/0")
+}
+
+func (s *storeSuite) TestImportError(c *gc.C) {
+	store := s.newStore(c)
+	s.applier.err = errors.New("no leader")
+	err := store.Import(map[string]lease.Info{
+		"mysql": {Holder: "mysql/1", Expiry: s.clock.Now().Add(time.Hour)},
+	})
+	c.Assert(err, gc.ErrorMatches, `importing lease "mysql": applying claim command: no leader`)
+}
+
+// fakeApplier applies commands directly to an FSM, as a single
+// controller raft cluster would.
+type fakeApplier struct {
+	fsm   *raftlease.FSM
+	calls int
+	err   error
+}
+
+func (a *fakeApplier) Apply(command []byte, timeout time.Duration) error {
+	a.calls++
+	if a.err != nil {
+		return a.err
+	}
+	return a.fsm.Apply(command)
+}
+
+type fakeTarget struct {
+	testing.Stub
+}
+
+func (t *fakeTarget) Claimed(name, holder string) error {
+	t.AddCall("Claimed", name, holder)
+	return t.NextErr()
+}
+
+func (t *fakeTarget) Expired(name string) error {
+	t.AddCall("Expired", name)
+	return t.NextErr()
+}
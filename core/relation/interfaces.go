@@ -0,0 +1,196 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package relation
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/core/charmfile"
+)
+
+// metadataFile is the name of the file, in the root of a charm, that
+// holds the charm's metadata.
+const metadataFile = "metadata.yaml"
+
+// InterfaceVersion describes the version of an interface's schema
+// that a charm endpoint implements.
+type InterfaceVersion struct {
+	// Version is the version of the schema.
+	Version string `yaml:"version" bson:"version"`
+
+	// Compatible holds the other versions of the schema that the
+	// endpoint can be related to.
+	Compatible []string `yaml:"compatible,omitempty" bson:"compatible,omitempty"`
+}
+
+// InterfaceSchema describes a version of an interface's schema, as
+// known to a controller from the charms added to it.
+type InterfaceSchema struct {
+	Interface string
+	Version   string
+
+	// Compatible holds the other versions of the interface's schema
+	// that charms have declared compatible with this one.
+	Compatible []string
+}
+
+// CompatibleWith reports whether the schema is the given version, or
+// has been declared compatible with it.
+func (s InterfaceSchema) CompatibleWith(version string) bool {
+	if s.Version == version {
+		return true
+	}
+	for _, v := range s.Compatible {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+// InterfaceCheckMode determines what happens when endpoints whose
+// interface schema versions are not known to be compatible are
+// related.
+type InterfaceCheckMode string
+
+const (
+	// InterfaceCheckOff disables interface version checks.
+	InterfaceCheckOff InterfaceCheckMode = "off"
+
+	// InterfaceCheckWarn causes warnings to be reported when
+	// incompatible endpoints are related.
+	InterfaceCheckWarn InterfaceCheckMode = "warn"
+
+	// InterfaceCheckBlock causes incompatible endpoints to be
+	// refused.
+	InterfaceCheckBlock InterfaceCheckMode = "block"
+)
+
+// Validate returns an error if the mode is not one of those defined.
+func (m InterfaceCheckMode) Validate() error {
+	switch m {
+	case InterfaceCheckOff, InterfaceCheckWarn, InterfaceCheckBlock:
+		return nil
+	}
+	return errors.NotValidf("interface check mode %q", string(m))
+}
+
+// ParseInterfaceVersions parses the interface versions declared in a
+// charm's metadata.yaml, keyed by endpoint name. The versions are an
+// extension to the charm metadata, which the charm package itself
+// ignores, for example:
+//
+//	interface-versions:
+//	  db:
+//	    version: "2"
+//	    compatible: ["1"]
+//
+// Other metadata is ignored.
+func ParseInterfaceVersions(metadata []byte) (map[string]InterfaceVersion, error) {
+	var doc struct {
+		Versions map[string]InterfaceVersion `yaml:"interface-versions"`
+	}
+	if err := yaml.Unmarshal(metadata, &doc); err != nil {
+		return nil, errors.Annotate(err, "parsing interface versions")
+	}
+	for endpoint, v := range doc.Versions {
+		if v.Version == "" {
+			return nil, errors.NotValidf("interface version for endpoint %q without version", endpoint)
+		}
+	}
+	if len(doc.Versions) == 0 {
+		return nil, nil
+	}
+	return doc.Versions, nil
+}
+
+// InterfaceVersioner is implemented by charms whose interface versions
+// have already been read.
+type InterfaceVersioner interface {
+	InterfaceVersions() map[string]InterfaceVersion
+}
+
+// ReadInterfaceVersions returns the interface versions declared by the
+// supplied charm, keyed by endpoint name, or nil if it declares none.
+// Only charms that implement InterfaceVersioner, and charm directories
+// and archives read from disk, can declare versions.
+func ReadInterfaceVersions(ch charm.Charm) (map[string]InterfaceVersion, error) {
+	if ch, ok := ch.(InterfaceVersioner); ok {
+		return ch.InterfaceVersions(), nil
+	}
+	data, err := readMetadata(ch)
+	if err != nil || data == nil {
+		return nil, errors.Trace(err)
+	}
+	versions, err := ParseInterfaceVersions(data)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for endpoint := range versions {
+		if !hasEndpoint(ch.Meta(), endpoint) {
+			return nil, errors.NotValidf("interface version for unknown endpoint %q", endpoint)
+		}
+	}
+	return versions, nil
+}
+
+// CheckInterfaceVersions returns an error describing why the given
+// versions of an interface's schema, implemented by the named
+// endpoints, are not known to be compatible. Versions are compatible
+// if they are the same, or if either endpoint or a known schema
+// declares one compatible with the other. Endpoints that declare no
+// version are assumed to be compatible with any other.
+func CheckInterfaceVersions(
+	iface string,
+	endpoint1 string, v1 *InterfaceVersion,
+	endpoint2 string, v2 *InterfaceVersion,
+	known []InterfaceSchema,
+) error {
+	if v1 == nil || v2 == nil {
+		return nil
+	}
+	schemas := append([]InterfaceSchema{
+		{Interface: iface, Version: v1.Version, Compatible: v1.Compatible},
+		{Interface: iface, Version: v2.Version, Compatible: v2.Compatible},
+	}, known...)
+	for _, s := range schemas {
+		if s.Interface != iface {
+			continue
+		}
+		if s.Version == v1.Version && s.CompatibleWith(v2.Version) ||
+			s.Version == v2.Version && s.CompatibleWith(v1.Version) {
+			return nil
+		}
+	}
+	return errors.Errorf(
+		"%s implements version %q of interface %q, which is not known to be compatible with version %q implemented by %s",
+		endpoint1, v1.Version, iface, v2.Version, endpoint2,
+	)
+}
+
+// hasEndpoint reports whether the charm metadata declares the named
+// relation endpoint.
+func hasEndpoint(meta *charm.Meta, endpoint string) bool {
+	if meta == nil {
+		return false
+	}
+	for _, relations := range []map[string]charm.Relation{meta.Provides, meta.Requires, meta.Peers} {
+		if _, ok := relations[endpoint]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// readMetadata returns the contents of the metadata.yaml of a charm
+// directory or archive read from disk, or nil for other charms.
+func readMetadata(ch charm.Charm) ([]byte, error) {
+	data, err := charmfile.Read(ch, metadataFile)
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading %s", metadataFile)
+	}
+	return data, nil
+}
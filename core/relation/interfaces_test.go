@@ -0,0 +1,120 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package relation_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/relation"
+	"github.com/juju/juju/testcharms"
+)
+
+type interfacesSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&interfacesSuite{})
+
+var expectedVersions = map[string]relation.InterfaceVersion{
+	"server": {Version: "2", Compatible: []string{"1"}},
+}
+
+func (*interfacesSuite) TestReadCharmDir(c *gc.C) {
+	versions, err := relation.ReadInterfaceVersions(testcharms.Repo.CharmDir("mysql-versioned"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(versions, jc.DeepEquals, expectedVersions)
+}
+
+func (*interfacesSuite) TestReadCharmArchive(c *gc.C) {
+	versions, err := relation.ReadInterfaceVersions(testcharms.Repo.CharmArchive(c.MkDir(), "mysql-versioned"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(versions, jc.DeepEquals, expectedVersions)
+}
+
+func (*interfacesSuite) TestReadNoVersions(c *gc.C) {
+	versions, err := relation.ReadInterfaceVersions(testcharms.Repo.CharmDir("mysql"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(versions, gc.IsNil)
+}
+
+func (*interfacesSuite) TestParseInterfaceVersionsWithoutVersion(c *gc.C) {
+	_, err := relation.ParseInterfaceVersions([]byte(`
+name: mysql
+interface-versions:
+  server:
+    compatible: ["1"]
+`))
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `interface version for endpoint "server" without version not valid`)
+}
+
+func (*interfacesSuite) TestInterfaceCheckModeValidate(c *gc.C) {
+	for _, mode := range []relation.InterfaceCheckMode{
+		relation.InterfaceCheckOff,
+		relation.InterfaceCheckWarn,
+		relation.InterfaceCheckBlock,
+	} {
+		c.Check(mode.Validate(), jc.ErrorIsNil)
+	}
+	err := relation.InterfaceCheckMode("sometimes").Validate()
+	c.Assert(err, gc.ErrorMatches, `interface check mode "sometimes" not valid`)
+}
+
+func (*interfacesSuite) TestCheckInterfaceVersions(c *gc.C) {
+	v1 := &relation.InterfaceVersion{Version: "1"}
+	v2 := &relation.InterfaceVersion{Version: "2", Compatible: []string{"1"}}
+	v3 := &relation.InterfaceVersion{Version: "3"}
+	for i, test := range []struct {
+		about string
+		a, b  *relation.InterfaceVersion
+		known []relation.InterfaceSchema
+		err   string
+	}{{
+		about: "undeclared versions",
+		a:     v1,
+	}, {
+		about: "same versions",
+		a:     v1,
+		b:     &relation.InterfaceVersion{Version: "1"},
+	}, {
+		about: "compatibility declared by an endpoint",
+		a:     v1,
+		b:     v2,
+	}, {
+		about: "compatibility declared by an endpoint, the other way around",
+		a:     v2,
+		b:     v1,
+	}, {
+		about: "compatibility declared by a known schema",
+		a:     v3,
+		b:     v1,
+		known: []relation.InterfaceSchema{
+			{Interface: "mysql", Version: "3", Compatible: []string{"1", "2"}},
+		},
+	}, {
+		about: "compatibility declared for another interface",
+		a:     v3,
+		b:     v1,
+		known: []relation.InterfaceSchema{
+			{Interface: "pgsql", Version: "3", Compatible: []string{"1"}},
+		},
+		err: `wordpress:db implements version "3" of interface "mysql", which is not known to be compatible with version "1" implemented by mysql:server`,
+	}, {
+		about: "incompatible versions",
+		a:     v3,
+		b:     v2,
+		err:   `wordpress:db implements version "3" of interface "mysql", which is not known to be compatible with version "2" implemented by mysql:server`,
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		err := relation.CheckInterfaceVersions("mysql", "wordpress:db", test.a, "mysql:server", test.b, test.known)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
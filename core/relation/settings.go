@@ -0,0 +1,35 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package relation
+
+import (
+	"github.com/juju/errors"
+)
+
+// SettingsSize returns the size in bytes of a unit's settings in a
+// relation, counted as the total length of their keys and values.
+func SettingsSize(settings map[string]string) int {
+	var size int
+	for k, v := range settings {
+		size += len(k) + len(v)
+	}
+	return size
+}
+
+// CheckSettingsSize returns an error if the supplied settings are
+// larger than maxSizeMB mebibytes. A maxSizeMB of zero means there is
+// no limit.
+func CheckSettingsSize(settings map[string]string, maxSizeMB uint) error {
+	if maxSizeMB == 0 {
+		return nil
+	}
+	size := SettingsSize(settings)
+	if limit := int(maxSizeMB) * 1024 * 1024; size > limit {
+		return errors.Errorf(
+			"relation settings size %d bytes exceeds limit of %d bytes (max-relation-data-size %dM)",
+			size, limit, maxSizeMB,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package relation_test
+
+import (
+	"strings"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/relation"
+)
+
+type settingsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&settingsSuite{})
+
+func (s *settingsSuite) TestSettingsSize(c *gc.C) {
+	c.Assert(relation.SettingsSize(nil), gc.Equals, 0)
+	c.Assert(relation.SettingsSize(map[string]string{
+		"foo": "bar",
+		"baz": "",
+	}), gc.Equals, 9)
+}
+
+func (s *settingsSuite) TestCheckSettingsSize(c *gc.C) {
+	settings := map[string]string{"k": strings.Repeat("x", 1024*1024-1)}
+	c.Assert(relation.CheckSettingsSize(settings, 1), jc.ErrorIsNil)
+
+	settings["k2"] = "x"
+	err := relation.CheckSettingsSize(settings, 1)
+	c.Assert(err, gc.ErrorMatches, `relation settings size 1048579 bytes exceeds limit of 1048576 bytes \(max-relation-data-size 1M\)`)
+}
+
+func (s *settingsSuite) TestCheckSettingsSizeUnlimited(c *gc.C) {
+	settings := map[string]string{"k": strings.Repeat("x", 2*1024*1024)}
+	c.Assert(relation.CheckSettingsSize(settings, 0), jc.ErrorIsNil)
+}
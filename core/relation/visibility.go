@@ -0,0 +1,176 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package relation
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/yaml.v2"
+)
+
+// Visibility determines which of the units reading a unit's relation
+// settings can see a key.
+type Visibility string
+
+const (
+	// VisibilityAll makes a key visible to every related unit. It is
+	// the visibility of keys that a charm declares no rule for.
+	VisibilityAll Visibility = "all"
+
+	// VisibilityLeader makes a key visible only to units that lead
+	// their application.
+	VisibilityLeader Visibility = "leader"
+
+	// VisibilityApplication makes a key visible only to units of the
+	// writing unit's own application, such as its peers.
+	VisibilityApplication Visibility = "application"
+)
+
+// Validate returns an error if the visibility is not one of those
+// defined.
+func (v Visibility) Validate() error {
+	switch v {
+	case VisibilityAll, VisibilityLeader, VisibilityApplication:
+		return nil
+	}
+	return errors.NotValidf("relation data visibility %q", string(v))
+}
+
+// VisibleTo reports whether a key with the visibility can be read by
+// the given reader.
+func (v Visibility) VisibleTo(reader DataReader) bool {
+	switch v {
+	case VisibilityLeader:
+		return reader.Leader
+	case VisibilityApplication:
+		return reader.SameApplication
+	}
+	return true
+}
+
+// DataVisibilityRule restricts which units can read a key of the
+// relation settings written by a charm's units through an endpoint.
+type DataVisibilityRule struct {
+	Endpoint   string     `bson:"endpoint"`
+	Key        string     `bson:"key"`
+	Visibility Visibility `bson:"visibility"`
+}
+
+// DataReader describes a unit reading the relation settings of another
+// unit.
+type DataReader struct {
+	// SameApplication is true if the reading unit belongs to the
+	// same application as the unit whose settings are read.
+	SameApplication bool
+
+	// Leader is true if the reading unit is the leader of its
+	// application.
+	Leader bool
+}
+
+// ParseDataVisibility parses the relation data visibility rules
+// declared in a charm's metadata.yaml. The rules are an extension to
+// the charm metadata, which the charm package itself ignores, and give
+// the visibility of keys written through each endpoint, for example:
+//
+//	relation-data-visibility:
+//	  db:
+//	    password: leader
+//	  cluster:
+//	    replication-key: application
+//
+// The rules are returned sorted by endpoint and key. Other metadata is
+// ignored.
+func ParseDataVisibility(metadata []byte) ([]DataVisibilityRule, error) {
+	var doc struct {
+		Visibility map[string]map[string]Visibility `yaml:"relation-data-visibility"`
+	}
+	if err := yaml.Unmarshal(metadata, &doc); err != nil {
+		return nil, errors.Annotate(err, "parsing relation data visibility")
+	}
+	var rules []DataVisibilityRule
+	for endpoint, keys := range doc.Visibility {
+		for key, visibility := range keys {
+			if err := visibility.Validate(); err != nil {
+				return nil, errors.Annotatef(err, "key %q of endpoint %q", key, endpoint)
+			}
+			rules = append(rules, DataVisibilityRule{
+				Endpoint:   endpoint,
+				Key:        key,
+				Visibility: visibility,
+			})
+		}
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].Endpoint != rules[j].Endpoint {
+			return rules[i].Endpoint < rules[j].Endpoint
+		}
+		return rules[i].Key < rules[j].Key
+	})
+	return rules, nil
+}
+
+// DataVisibilityDeclarer is implemented by charms whose relation data
+// visibility rules have already been read.
+type DataVisibilityDeclarer interface {
+	RelationDataVisibility() []DataVisibilityRule
+}
+
+// ReadDataVisibility returns the relation data visibility rules
+// declared by the supplied charm, or nil if it declares none. Only
+// charms that implement DataVisibilityDeclarer, and charm directories
+// and archives read from disk, can declare rules.
+func ReadDataVisibility(ch charm.Charm) ([]DataVisibilityRule, error) {
+	if ch, ok := ch.(DataVisibilityDeclarer); ok {
+		return ch.RelationDataVisibility(), nil
+	}
+	data, err := readMetadata(ch)
+	if err != nil || data == nil {
+		return nil, errors.Trace(err)
+	}
+	rules, err := ParseDataVisibility(data)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, rule := range rules {
+		if !hasEndpoint(ch.Meta(), rule.Endpoint) {
+			return nil, errors.NotValidf("relation data visibility for unknown endpoint %q", rule.Endpoint)
+		}
+	}
+	return rules, nil
+}
+
+// FilterSettings returns the relation settings written by a unit
+// through the named endpoint, without the keys that the rules hide
+// from the reader. The settings are returned unchanged if no keys are
+// hidden.
+func FilterSettings(
+	rules []DataVisibilityRule, endpoint string, settings map[string]interface{}, reader DataReader,
+) map[string]interface{} {
+	var hidden map[string]bool
+	for _, rule := range rules {
+		if rule.Endpoint != endpoint || rule.Visibility.VisibleTo(reader) {
+			continue
+		}
+		if _, ok := settings[rule.Key]; !ok {
+			continue
+		}
+		if hidden == nil {
+			hidden = make(map[string]bool)
+		}
+		hidden[rule.Key] = true
+	}
+	if hidden == nil {
+		return settings
+	}
+	result := make(map[string]interface{}, len(settings)-len(hidden))
+	for key, value := range settings {
+		if !hidden[key] {
+			result[key] = value
+		}
+	}
+	return result
+}
@@ -0,0 +1,93 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package relation_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/relation"
+	"github.com/juju/juju/testcharms"
+)
+
+type visibilitySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&visibilitySuite{})
+
+var expectedRules = []relation.DataVisibilityRule{
+	{Endpoint: "cluster", Key: "replication-key", Visibility: relation.VisibilityApplication},
+	{Endpoint: "server", Key: "password", Visibility: relation.VisibilityLeader},
+}
+
+func (*visibilitySuite) TestReadCharmDir(c *gc.C) {
+	rules, err := relation.ReadDataVisibility(testcharms.Repo.CharmDir("mysql-private"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, expectedRules)
+}
+
+func (*visibilitySuite) TestReadCharmArchive(c *gc.C) {
+	rules, err := relation.ReadDataVisibility(testcharms.Repo.CharmArchive(c.MkDir(), "mysql-private"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, expectedRules)
+}
+
+func (*visibilitySuite) TestReadNoRules(c *gc.C) {
+	rules, err := relation.ReadDataVisibility(testcharms.Repo.CharmDir("mysql"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.IsNil)
+}
+
+func (*visibilitySuite) TestParseDataVisibilityInvalid(c *gc.C) {
+	_, err := relation.ParseDataVisibility([]byte(`
+relation-data-visibility:
+  server:
+    password: secret
+`))
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `key "password" of endpoint "server": relation data visibility "secret" not valid`)
+}
+
+func (*visibilitySuite) TestFilterSettings(c *gc.C) {
+	settings := map[string]interface{}{
+		"host":            "10.0.0.1",
+		"password":        "sekrit",
+		"replication-key": "abc",
+	}
+	for i, test := range []struct {
+		endpoint string
+		reader   relation.DataReader
+		expect   []string
+	}{{
+		endpoint: "server",
+		expect:   []string{"host", "replication-key"},
+	}, {
+		endpoint: "server",
+		reader:   relation.DataReader{Leader: true},
+		expect:   []string{"host", "password", "replication-key"},
+	}, {
+		endpoint: "server",
+		reader:   relation.DataReader{SameApplication: true},
+		expect:   []string{"host", "replication-key"},
+	}, {
+		endpoint: "cluster",
+		expect:   []string{"host", "password"},
+	}, {
+		endpoint: "cluster",
+		reader:   relation.DataReader{SameApplication: true},
+		expect:   []string{"host", "password", "replication-key"},
+	}} {
+		c.Logf("test %d: %s %+v", i, test.endpoint, test.reader)
+		filtered := relation.FilterSettings(expectedRules, test.endpoint, settings, test.reader)
+		var keys []string
+		for key := range filtered {
+			keys = append(keys, key)
+		}
+		c.Check(keys, jc.SameContents, test.expect)
+	}
+	c.Assert(settings, gc.HasLen, 3)
+}
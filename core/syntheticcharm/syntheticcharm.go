@@ -0,0 +1,106 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package syntheticcharm defines the built-in charm used by the
+// applications with which models are synthetically populated for load
+// testing. The charm has no hooks and its units have no agents; it
+// exists only so that the applications, and the relations between
+// them, look to the controller like any other.
+package syntheticcharm
+
+import (
+	"gopkg.in/juju/charm.v6"
+)
+
+const (
+	// CharmName is the name of the synthetic charm.
+	CharmName = "juju-synthetic"
+
+	// ProvidesRelation is the name of the relation the charm provides.
+	ProvidesRelation = "source"
+
+	// RequiresRelation is the name of the relation the charm requires.
+	RequiresRelation = "sink"
+
+	// Interface is the interface of the charm's relations.
+	Interface = "juju-synthetic"
+
+	// MessageKey is the key of the charm's only config option.
+	MessageKey = "message"
+)
+
+// URL returns the URL of the synthetic charm for the given series.
+func URL(series string) *charm.URL {
+	return &charm.URL{
+		Schema:   "local",
+		Name:     CharmName,
+		Series:   series,
+		Revision: 0,
+	}
+}
+
+// New returns the built-in synthetic charm.
+func New() charm.Charm {
+	return &syntheticCharm{
+		meta: &charm.Meta{
+			Name:        CharmName,
+			Summary:     "Synthetic load testing charm",
+			Description: "Used by applications that synthetically populate a model for load testing.",
+			Provides: map[string]charm.Relation{
+				ProvidesRelation: {
+					Name:      ProvidesRelation,
+					Role:      charm.RoleProvider,
+					Interface: Interface,
+					Scope:     charm.ScopeGlobal,
+				},
+			},
+			Requires: map[string]charm.Relation{
+				RequiresRelation: {
+					Name:      RequiresRelation,
+					Role:      charm.RoleRequirer,
+					Interface: Interface,
+					Scope:     charm.ScopeGlobal,
+				},
+			},
+		},
+		config: &charm.Config{
+			Options: map[string]charm.Option{
+				MessageKey: {
+					Type:        "string",
+					Description: "An arbitrary message, changed to generate load.",
+					Default:     "",
+				},
+			},
+		},
+	}
+}
+
+type syntheticCharm struct {
+	meta   *charm.Meta
+	config *charm.Config
+}
+
+// Meta is part of the charm.Charm interface.
+func (c *syntheticCharm) Meta() *charm.Meta {
+	return c.meta
+}
+
+// Config is part of the charm.Charm interface.
+func (c *syntheticCharm) Config() *charm.Config {
+	return c.config
+}
+
+// Metrics is part of the charm.Charm interface.
+func (c *syntheticCharm) Metrics() *charm.Metrics {
+	return nil
+}
+
+// Actions is part of the charm.Charm interface.
+func (c *syntheticCharm) Actions() *charm.Actions {
+	return &charm.Actions{}
+}
+
+// Revision is part of the charm.Charm interface.
+func (c *syntheticCharm) Revision() int {
+	return 0
+}
@@ -0,0 +1,32 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package syntheticcharm_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/core/syntheticcharm"
+)
+
+type syntheticCharmSuite struct{}
+
+var _ = gc.Suite(&syntheticCharmSuite{})
+
+func (s *syntheticCharmSuite) TestURL(c *gc.C) {
+	c.Assert(syntheticcharm.URL("xenial").String(), gc.Equals, "local:xenial/juju-synthetic-0")
+}
+
+func (s *syntheticCharmSuite) TestCharm(c *gc.C) {
+	ch := syntheticcharm.New()
+	c.Assert(ch.Meta().Name, gc.Equals, "juju-synthetic")
+	c.Assert(ch.Revision(), gc.Equals, 0)
+	err := ch.Meta().Check()
+	c.Assert(err, jc.ErrorIsNil)
+
+	settings, err := ch.Config().ValidateSettings(charm.Settings{"message": "hello"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, charm.Settings{"message": "hello"})
+}
@@ -0,0 +1,44 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package trust defines the levels of trust that may be granted to an
+// application, which determine the cloud credentials its charm may
+// obtain.
+package trust
+
+import (
+	"github.com/juju/errors"
+)
+
+// Level describes the access to the model's cloud that an
+// application's charm is granted.
+type Level string
+
+const (
+	// None grants no access to cloud credentials. It is the level
+	// of applications that have not been trusted.
+	None Level = "none"
+
+	// ReadOnly grants access to short-lived cloud credentials that
+	// may only be used to inspect the cloud.
+	ReadOnly Level = "read-only"
+
+	// Full grants access to short-lived cloud credentials with the
+	// permissions of the model's credential.
+	Full Level = "full"
+)
+
+// Validate returns an error if the level is not known.
+func (l Level) Validate() error {
+	switch l {
+	case None, ReadOnly, Full:
+		return nil
+	}
+	return errors.NotValidf("trust level %q", l)
+}
+
+// Trusted reports whether the level grants any access to cloud
+// credentials.
+func (l Level) Trusted() bool {
+	return l == ReadOnly || l == Full
+}
@@ -0,0 +1,35 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package trust_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/trust"
+)
+
+type trustSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&trustSuite{})
+
+func (s *trustSuite) TestValidate(c *gc.C) {
+	for _, level := range []trust.Level{trust.None, trust.ReadOnly, trust.Full} {
+		c.Check(level.Validate(), jc.ErrorIsNil)
+	}
+	err := trust.Level("root").Validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `trust level "root" not valid`)
+}
+
+func (s *trustSuite) TestTrusted(c *gc.C) {
+	c.Check(trust.None.Trusted(), jc.IsFalse)
+	c.Check(trust.Level("").Trusted(), jc.IsFalse)
+	c.Check(trust.ReadOnly.Trusted(), jc.IsTrue)
+	c.Check(trust.Full.Trusted(), jc.IsTrue)
+}
@@ -4,6 +4,7 @@
 package environs
 
 import (
+	"context"
 	"io"
 	"os"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs/imagemetadata"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/tools"
 )
 
@@ -60,6 +62,11 @@ type BootstrapParams struct {
 	// that rely on it for selecting images. This will be empty for
 	// providers that do not implements simplestreams.HasRegion.
 	ImageMetadata []*imagemetadata.ImageMetadata
+
+	// InitialSSHHostKeys, if specified, holds the initial SSH host
+	// keys of an existing bootstrap instance. It is used only when
+	// resuming a bootstrap, to verify the instance's identity.
+	InitialSSHHostKeys instancecfg.SSHHostKeys
 }
 
 // BootstrapFinalizer is a function returned from Environ.Bootstrap.
@@ -91,12 +98,32 @@ type BootstrapResult struct {
 	// Series is the instance's series.
 	Series string
 
+	// InstanceId is the ID of the bootstrap instance. This may be
+	// empty for providers that do not report it, in which case the
+	// bootstrap cannot be resumed.
+	InstanceId instance.Id
+
+	// InitialSSHHostKeys holds the initial SSH host keys injected
+	// into the bootstrap instance, if any.
+	InitialSSHHostKeys instancecfg.SSHHostKeys
+
 	// Finalize is a function that must be called to finalize the
 	// bootstrap process by transferring the tools and installing the
 	// initial Juju controller.
 	Finalize BootstrapFinalizer
 }
 
+// BootstrapResumer is an interface that may be implemented by an
+// Environ that can resume a bootstrap whose controller instance has
+// already been started.
+type BootstrapResumer interface {
+	// ResumeBootstrap returns a BootstrapResult for the existing
+	// bootstrap instance with the specified ID. Calling the result's
+	// Finalize function completes the bootstrap process on that
+	// instance.
+	ResumeBootstrap(ctx BootstrapContext, id instance.Id, params BootstrapParams) (*BootstrapResult, error)
+}
+
 // BootstrapContext is an interface that is passed to
 // Environ.Bootstrap, providing a means of obtaining
 // information about and manipulating the context in which
@@ -122,4 +149,9 @@ type BootstrapContext interface {
 	// ShouldVerifyCredentials indicates whether the caller's cloud
 	// credentials should be verified.
 	ShouldVerifyCredentials() bool
+
+	// Context returns the context with which provider operations
+	// should be made during bootstrap. It is cancelled if bootstrap
+	// is interrupted.
+	Context() context.Context
 }
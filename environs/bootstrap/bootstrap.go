@@ -38,6 +38,8 @@ import (
 	"github.com/juju/juju/environs/storage"
 	"github.com/juju/juju/environs/sync"
 	"github.com/juju/juju/environs/tools"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/mongo"
 	coretools "github.com/juju/juju/tools"
 	jujuversion "github.com/juju/juju/version"
@@ -109,6 +111,13 @@ type BootstrapParams struct {
 	// directive used to choose the initial instance.
 	Placement string
 
+	// ControllerContainerType, if non-empty, is the type of the system
+	// container that the controller is placed in on the initial
+	// instance, so that the instance may be shared with other
+	// services. The container's resources are limited according to
+	// BootstrapConstraints.
+	ControllerContainerType instance.ContainerType
+
 	// BuildAgent reports whether we should build and upload the local agent
 	// binary and override the environment's specified agent-version.
 	// It is an error to specify BuildAgent with a nil BuildAgentTarball.
@@ -140,6 +149,19 @@ type BootstrapParams struct {
 
 	// DialOpts contains the bootstrap dial options.
 	DialOpts environs.BootstrapDialOpts
+
+	// Checkpoints, if non-nil, is used to record the progress of
+	// the bootstrap so that it may be resumed if it fails.
+	Checkpoints CheckpointStore
+
+	// Resume reports whether to resume a previous bootstrap from
+	// the checkpoint recorded in Checkpoints.
+	Resume bool
+
+	// DryRun reports whether to print the planned bootstrap phases,
+	// along with the agent binaries and image that would be used,
+	// rather than bootstrapping.
+	DryRun bool
 }
 
 // Validate validates the bootstrap parameters.
@@ -156,6 +178,17 @@ func (p BootstrapParams) Validate() error {
 	if p.CAPrivateKey == "" {
 		return errors.New("empty ca-private-key")
 	}
+	if p.Resume && p.Checkpoints == nil {
+		return errors.New("cannot resume without bootstrap checkpoints")
+	}
+	if p.Resume && p.DryRun {
+		return errors.New("cannot resume a dry run")
+	}
+	switch p.ControllerContainerType {
+	case "", instance.LXD:
+	default:
+		return errors.NotSupportedf("controller in %q container", p.ControllerContainerType)
+	}
 	// TODO(axw) validate other things.
 	return nil
 }
@@ -180,11 +213,35 @@ func withDefaultControllerConstraints(cons constraints.Value) constraints.Value
 // Bootstrap bootstraps the given environment. The supplied constraints are
 // used to provision the instance, and are also set within the bootstrapped
 // environment.
+//
+// If args.Checkpoints is specified, progress is recorded as each phase of
+// the bootstrap completes; if args.Resume is true, phases that completed
+// in a previous attempt are skipped.
 func Bootstrap(ctx environs.BootstrapContext, environ environs.Environ, args BootstrapParams) error {
 	if err := args.Validate(); err != nil {
 		return errors.Annotate(err, "validating bootstrap parameters")
 	}
 
+	var checkpoint *jujuclient.BootstrapCheckpoint
+	if args.Resume {
+		resumeFrom, err := args.Checkpoints.Checkpoint()
+		if errors.IsNotFound(err) {
+			return errors.New("no bootstrap checkpoint to resume from")
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		switch Phase(resumeFrom.Phase) {
+		case PhaseSelectTools:
+			ctx.Infof("No controller instance was started, restarting bootstrap")
+		case PhaseStartInstance:
+			checkpoint = &resumeFrom
+			args.BootstrapSeries = resumeFrom.Series
+		default:
+			ctx.Infof("Bootstrap agent already started on %s", resumeFrom.InstanceId)
+			return nil
+		}
+	}
+
 	cfg := environ.Config()
 	if authKeys := ssh.SplitAuthorisedKeys(cfg.AuthorizedKeys()); len(authKeys) == 0 {
 		// Apparently this can never happen, so it's not tested. But, one day,
@@ -290,6 +347,11 @@ func Bootstrap(ctx environs.BootstrapContext, environ environs.Environ, args Boo
 			bootstrapArch = arch.AMD64
 		}
 	}
+	if checkpoint != nil {
+		// Agent binaries must be chosen for the instance
+		// that has already been started.
+		bootstrapArch = checkpoint.Arch
+	}
 
 	var availableTools coretools.List
 	if !args.BuildAgent {
@@ -302,6 +364,7 @@ func Bootstrap(ctx environs.BootstrapContext, environ environs.Environ, args Boo
 	// If there are no prepackaged tools and a specific version has not been
 	// requested, look for or build a local binary.
 	var builtTools *sync.BuiltAgent
+	var buildAgent bool
 	if len(availableTools) == 0 && (args.AgentVersion == nil || isCompatibleVersion(*args.AgentVersion, jujuversion.Current)) {
 		if args.BuildAgentTarball == nil {
 			return errors.New("cannot build agent binary to upload")
@@ -309,6 +372,11 @@ func Bootstrap(ctx environs.BootstrapContext, environ environs.Environ, args Boo
 		if err := validateUploadAllowed(environ, &bootstrapArch, bootstrapSeries, constraintsValidator); err != nil {
 			return err
 		}
+		buildAgent = true
+	}
+	if buildAgent && args.DryRun {
+		availableTools, _ = locallyBuildableTools(bootstrapSeries)
+	} else if buildAgent {
 		if args.BuildAgent {
 			ctx.Infof("Building local Juju agent binary version %s for %s", args.AgentVersion, bootstrapArch)
 		} else {
@@ -349,6 +417,35 @@ func Bootstrap(ctx environs.BootstrapContext, environ environs.Environ, args Boo
 	if len(availableTools) == 0 {
 		return errors.New(noToolsMessage)
 	}
+	if checkpoint != nil {
+		if availableTools, err = checkpointTools(availableTools, *checkpoint); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	if args.DryRun {
+		planSeries := args.BootstrapSeries
+		if planSeries == "" {
+			planSeries = config.PreferredSeries(cfg)
+		}
+		return writeBootstrapPlan(ctx.GetStdout(), bootstrapPlan{
+			tools:       availableTools,
+			buildAgent:  buildAgent,
+			series:      planSeries,
+			arch:        bootstrapArch,
+			constraints: bootstrapConstraints,
+			image:       planImage(args.BootstrapImage, planSeries, bootstrapArch, imageMetadata),
+
+			controllerContainer: args.ControllerContainerType,
+		})
+	}
+	if checkpoint == nil {
+		if err := saveCheckpoint(environ, args.Checkpoints, jujuclient.BootstrapCheckpoint{
+			Phase: string(PhaseSelectTools),
+		}); err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	// If we're uploading, we must override agent-version;
 	// if we're not uploading, we want to ensure we have an
@@ -368,9 +465,7 @@ func Bootstrap(ctx environs.BootstrapContext, environ environs.Environ, args Boo
 		return err
 	}
 
-	ctx.Verbosef("Starting new instance for initial controller")
-
-	result, err := environ.Bootstrap(ctx, environs.BootstrapParams{
+	bootstrapParams := environs.BootstrapParams{
 		CloudName:            args.Cloud.Name,
 		CloudRegion:          args.CloudRegion,
 		ControllerConfig:     args.ControllerConfig,
@@ -380,7 +475,14 @@ func Bootstrap(ctx environs.BootstrapContext, environ environs.Environ, args Boo
 		Placement:            args.Placement,
 		AvailableTools:       availableTools,
 		ImageMetadata:        imageMetadata,
-	})
+	}
+	var result *environs.BootstrapResult
+	if checkpoint != nil {
+		result, err = resumeBootstrapInstance(ctx, environ, *checkpoint, bootstrapParams)
+	} else {
+		ctx.Verbosef("Starting new instance for initial controller")
+		result, err = environ.Bootstrap(ctx, bootstrapParams)
+	}
 	if err != nil {
 		return err
 	}
@@ -396,6 +498,21 @@ func Bootstrap(ctx environs.BootstrapContext, environ environs.Environ, args Boo
 	if err != nil {
 		return err
 	}
+	instanceCheckpoint := jujuclient.BootstrapCheckpoint{
+		Phase:        string(PhaseStartInstance),
+		InstanceId:   string(result.InstanceId),
+		Series:       result.Series,
+		Arch:         result.Arch,
+		AgentVersion: selectedToolsList[0].Version.Number.String(),
+	}
+	if result.InitialSSHHostKeys.RSA != nil {
+		instanceCheckpoint.SSHHostKey = result.InitialSSHHostKeys.RSA.Public
+	}
+	if result.InstanceId == "" {
+		logger.Debugf("bootstrap instance ID not reported, bootstrap cannot be resumed")
+	} else if err := saveCheckpoint(environ, args.Checkpoints, instanceCheckpoint); err != nil {
+		return errors.Trace(err)
+	}
 	// We set agent-version to the newest version, so the agent will immediately upgrade itself.
 	// Note that this only is relevant if a specific agent version has not been requested, since
 	// in that case the specific version will be the only version available.
@@ -435,9 +552,58 @@ func Bootstrap(ctx environs.BootstrapContext, environ environs.Environ, args Boo
 		return err
 	}
 	ctx.Infof("Bootstrap agent now started")
+	if result.InstanceId != "" {
+		instanceCheckpoint.Phase = string(PhaseInitMongo)
+		if err := saveCheckpoint(environ, args.Checkpoints, instanceCheckpoint); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	return nil
 }
 
+// resumeBootstrapInstance returns a BootstrapResult for the controller
+// instance recorded in the given checkpoint.
+func resumeBootstrapInstance(
+	ctx environs.BootstrapContext,
+	environ environs.Environ,
+	checkpoint jujuclient.BootstrapCheckpoint,
+	args environs.BootstrapParams,
+) (*environs.BootstrapResult, error) {
+	resumer, ok := environ.(environs.BootstrapResumer)
+	if !ok {
+		return nil, errors.NotSupportedf("resuming bootstrap on %q", environ.Config().Type())
+	}
+	if checkpoint.SSHHostKey != "" {
+		args.InitialSSHHostKeys.RSA = &instancecfg.SSHKeyPair{
+			Public: checkpoint.SSHHostKey,
+		}
+	}
+	return resumer.ResumeBootstrap(ctx, instance.Id(checkpoint.InstanceId), args)
+}
+
+// checkpointTools returns the tools in the list that match those
+// selected for the controller instance recorded in the checkpoint.
+func checkpointTools(availableTools coretools.List, checkpoint jujuclient.BootstrapCheckpoint) (coretools.List, error) {
+	agentVersion, err := version.Parse(checkpoint.AgentVersion)
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing checkpoint agent version")
+	}
+	matching, err := availableTools.Match(coretools.Filter{
+		Number: agentVersion,
+		Series: checkpoint.Series,
+		Arch:   checkpoint.Arch,
+	})
+	if err == coretools.ErrNoMatches {
+		return nil, errors.Errorf(
+			"agent binaries %s for %s/%s are no longer available",
+			agentVersion, checkpoint.Series, checkpoint.Arch,
+		)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return matching, nil
+}
+
 func finalizeInstanceBootstrapConfig(
 	ctx environs.BootstrapContext,
 	icfg *instancecfg.InstanceConfig,
@@ -495,6 +661,7 @@ func finalizeInstanceBootstrapConfig(
 	icfg.Bootstrap.RegionInheritedConfig = args.Cloud.RegionConfig
 	icfg.Bootstrap.HostedModelConfig = args.HostedModelConfig
 	icfg.Bootstrap.Timeout = args.DialOpts.Timeout
+	icfg.Bootstrap.ControllerContainerType = args.ControllerContainerType
 	icfg.Bootstrap.GUI = guiArchive(args.GUIDataSourceBaseURL, func(msg string) {
 		ctx.Infof(msg)
 	})
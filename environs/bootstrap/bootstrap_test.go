@@ -43,6 +43,7 @@ import (
 	"github.com/juju/juju/environs/sync"
 	envtesting "github.com/juju/juju/environs/testing"
 	envtools "github.com/juju/juju/environs/tools"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/juju/keys"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/provider/dummy"
@@ -190,6 +191,34 @@ func (s *bootstrapSuite) TestBootstrapSpecifiedPlacement(c *gc.C) {
 	c.Assert(env.args.Placement, gc.DeepEquals, placement)
 }
 
+func (s *bootstrapSuite) TestBootstrapControllerContainer(c *gc.C) {
+	env := newEnviron("foo", useDefaultKeys, nil)
+	s.setDummyStorage(c, env)
+	err := bootstrap.Bootstrap(envtesting.BootstrapContext(c), env, bootstrap.BootstrapParams{
+		ControllerConfig:        coretesting.FakeControllerConfig(),
+		AdminSecret:             "admin-secret",
+		CAPrivateKey:            coretesting.CAKey,
+		Placement:               "host.maas",
+		ControllerContainerType: instance.LXD,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(env.bootstrapCount, gc.Equals, 1)
+	c.Assert(env.args.Placement, gc.Equals, "host.maas")
+	c.Assert(env.instanceConfig.Bootstrap.ControllerContainerType, gc.Equals, instance.LXD)
+}
+
+func (s *bootstrapSuite) TestBootstrapControllerContainerNotSupported(c *gc.C) {
+	env := newEnviron("foo", useDefaultKeys, nil)
+	err := bootstrap.Bootstrap(envtesting.BootstrapContext(c), env, bootstrap.BootstrapParams{
+		ControllerConfig:        coretesting.FakeControllerConfig(),
+		AdminSecret:             "admin-secret",
+		CAPrivateKey:            coretesting.CAKey,
+		ControllerContainerType: instance.KVM,
+	})
+	c.Assert(err, gc.ErrorMatches, `validating bootstrap parameters: controller in "kvm" container not supported`)
+	c.Assert(env.bootstrapCount, gc.Equals, 0)
+}
+
 func intPtr(i uint64) *uint64 {
 	return &i
 }
@@ -1291,6 +1320,9 @@ type bootstrapEnviron struct {
 	args                      environs.BootstrapParams
 	instanceConfig            *instancecfg.InstanceConfig
 	storage                   storage.Storage
+
+	// instanceId is reported as the ID of the bootstrap instance.
+	instanceId instance.Id
 }
 
 func newEnviron(name string, defaultKeys bool, extraAttrs map[string]interface{}) *bootstrapEnviron {
@@ -1333,7 +1365,12 @@ func (e *bootstrapEnviron) Bootstrap(ctx environs.BootstrapContext, args environ
 	if args.BootstrapSeries != "" {
 		series = args.BootstrapSeries
 	}
-	return &environs.BootstrapResult{Arch: args.AvailableTools.Arches()[0], Series: series, Finalize: finalizer}, nil
+	return &environs.BootstrapResult{
+		Arch:       args.AvailableTools.Arches()[0],
+		Series:     series,
+		InstanceId: e.instanceId,
+		Finalize:   finalizer,
+	}, nil
 }
 
 func (e *bootstrapEnviron) Config() *config.Config {
@@ -0,0 +1,152 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bootstrap
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/jujuclient"
+)
+
+// Phase identifies a stage of the bootstrap process.
+type Phase string
+
+const (
+	// PhaseSelectTools is the phase in which the agent binaries
+	// for the controller are selected, or built locally.
+	PhaseSelectTools Phase = "select-tools"
+
+	// PhaseStartInstance is the phase in which the controller
+	// instance is started.
+	PhaseStartInstance Phase = "start-instance"
+
+	// PhaseInitMongo is the phase in which the agent is installed
+	// on the controller instance, and the controller's database
+	// is initialised.
+	PhaseInitMongo Phase = "init-mongo"
+
+	// PhaseDeployController is the phase in which the controller
+	// agent starts up and deploys the controller model, making
+	// the API available to clients.
+	PhaseDeployController Phase = "deploy-controller"
+)
+
+// Phases holds the bootstrap phases, in the order in which
+// they are carried out.
+var Phases = []Phase{
+	PhaseSelectTools,
+	PhaseStartInstance,
+	PhaseInitMongo,
+	PhaseDeployController,
+}
+
+var phaseDescriptions = map[Phase]string{
+	PhaseSelectTools:      "select agent binaries",
+	PhaseStartInstance:    "start controller instance",
+	PhaseInitMongo:        "install agent and initialise controller database",
+	PhaseDeployController: "start controller agent and deploy controller model",
+}
+
+// Description returns a human readable description of the phase.
+func (p Phase) Description() string {
+	if desc, ok := phaseDescriptions[p]; ok {
+		return desc
+	}
+	return string(p)
+}
+
+// CheckpointStore records the progress of a bootstrap, so that
+// it may be resumed if it fails part way through.
+type CheckpointStore interface {
+	// Checkpoint returns the most recently saved checkpoint. If
+	// there is none, an error satisfying errors.IsNotFound is
+	// returned.
+	Checkpoint() (jujuclient.BootstrapCheckpoint, error)
+
+	// SaveCheckpoint records the given checkpoint, replacing
+	// any previously saved one.
+	SaveCheckpoint(jujuclient.BootstrapCheckpoint) error
+
+	// RemoveCheckpoint removes the saved checkpoint, if any.
+	// It is called once bootstrap has completed.
+	RemoveCheckpoint() error
+}
+
+// NewCheckpointStore returns a CheckpointStore that records
+// checkpoints in the bootstrap config of the named controller.
+func NewCheckpointStore(store jujuclient.BootstrapConfigStore, controllerName string) CheckpointStore {
+	return &clientCheckpointStore{store, controllerName}
+}
+
+type clientCheckpointStore struct {
+	store          jujuclient.BootstrapConfigStore
+	controllerName string
+}
+
+// Checkpoint is part of the CheckpointStore interface.
+func (s *clientCheckpointStore) Checkpoint() (jujuclient.BootstrapCheckpoint, error) {
+	cfg, err := s.store.BootstrapConfigForController(s.controllerName)
+	if err != nil {
+		return jujuclient.BootstrapCheckpoint{}, errors.Trace(err)
+	}
+	if cfg.Checkpoint == nil {
+		return jujuclient.BootstrapCheckpoint{}, errors.NotFoundf(
+			"bootstrap checkpoint for controller %q", s.controllerName,
+		)
+	}
+	return *cfg.Checkpoint, nil
+}
+
+// SaveCheckpoint is part of the CheckpointStore interface.
+func (s *clientCheckpointStore) SaveCheckpoint(checkpoint jujuclient.BootstrapCheckpoint) error {
+	return s.update(&checkpoint)
+}
+
+// RemoveCheckpoint is part of the CheckpointStore interface.
+func (s *clientCheckpointStore) RemoveCheckpoint() error {
+	if err := s.update(nil); err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+func (s *clientCheckpointStore) update(checkpoint *jujuclient.BootstrapCheckpoint) error {
+	cfg, err := s.store.BootstrapConfigForController(s.controllerName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cfg.Checkpoint = checkpoint
+	return errors.Trace(s.store.UpdateBootstrapConfig(s.controllerName, *cfg))
+}
+
+// saveCheckpoint records the given checkpoint in the store, if there is
+// one. If the checkpoint identifies the controller instance, and the
+// environ supports tagging instances, the instance is also tagged with
+// the phase so that progress is visible in the cloud's instance metadata.
+func saveCheckpoint(environ environs.Environ, store CheckpointStore, checkpoint jujuclient.BootstrapCheckpoint) error {
+	if store != nil {
+		if err := store.SaveCheckpoint(checkpoint); err != nil {
+			return errors.Annotatef(err, "recording bootstrap checkpoint %q", checkpoint.Phase)
+		}
+	}
+	if checkpoint.InstanceId == "" {
+		return nil
+	}
+	tagger, ok := environ.(environs.InstanceTagger)
+	if !ok {
+		return nil
+	}
+	if err := tagger.TagInstance(
+		instance.Id(checkpoint.InstanceId),
+		map[string]string{tags.JujuBootstrapPhase: checkpoint.Phase},
+	); err != nil {
+		// The tag is informational only; the checkpoint in the
+		// store is what allows the bootstrap to be resumed.
+		logger.Warningf("cannot tag controller instance with bootstrap phase: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,281 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bootstrap_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/arch"
+	"github.com/juju/utils/series"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/bootstrap"
+	"github.com/juju/juju/environs/tags"
+	envtesting "github.com/juju/juju/environs/testing"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/jujuclient"
+	coretesting "github.com/juju/juju/testing"
+	jujuversion "github.com/juju/juju/version"
+)
+
+type checkpointStoreSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&checkpointStoreSuite{})
+
+func (s *checkpointStoreSuite) TestClientCheckpointStore(c *gc.C) {
+	store := jujuclient.NewMemStore()
+	err := store.UpdateBootstrapConfig("ctrl", jujuclient.BootstrapConfig{Cloud: "dummy"})
+	c.Assert(err, jc.ErrorIsNil)
+	checkpoints := bootstrap.NewCheckpointStore(store, "ctrl")
+
+	_, err = checkpoints.Checkpoint()
+	c.Assert(err, gc.ErrorMatches, `bootstrap checkpoint for controller "ctrl" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	checkpoint := jujuclient.BootstrapCheckpoint{
+		Phase:      string(bootstrap.PhaseStartInstance),
+		InstanceId: "i-bootstrap",
+	}
+	err = checkpoints.SaveCheckpoint(checkpoint)
+	c.Assert(err, jc.ErrorIsNil)
+	saved, err := checkpoints.Checkpoint()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(saved, jc.DeepEquals, checkpoint)
+
+	cfg, err := store.BootstrapConfigForController("ctrl")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.Cloud, gc.Equals, "dummy")
+
+	err = checkpoints.RemoveCheckpoint()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = checkpoints.Checkpoint()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *checkpointStoreSuite) TestClientCheckpointStoreNoController(c *gc.C) {
+	checkpoints := bootstrap.NewCheckpointStore(jujuclient.NewMemStore(), "ctrl")
+	_, err := checkpoints.Checkpoint()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = checkpoints.SaveCheckpoint(jujuclient.BootstrapCheckpoint{})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = checkpoints.RemoveCheckpoint()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *bootstrapSuite) bootstrapParams(checkpoints bootstrap.CheckpointStore) bootstrap.BootstrapParams {
+	return bootstrap.BootstrapParams{
+		ControllerConfig: coretesting.FakeControllerConfig(),
+		AdminSecret:      "admin-secret",
+		CAPrivateKey:     coretesting.CAKey,
+		Checkpoints:      checkpoints,
+	}
+}
+
+func (s *bootstrapSuite) instanceCheckpoint(phase bootstrap.Phase) *jujuclient.BootstrapCheckpoint {
+	return &jujuclient.BootstrapCheckpoint{
+		Phase:        string(phase),
+		InstanceId:   "i-bootstrap",
+		Series:       series.MustHostSeries(),
+		Arch:         arch.HostArch(),
+		AgentVersion: jujuversion.Current.String(),
+		SSHHostKey:   "ssh-rsa public",
+	}
+}
+
+func (s *bootstrapSuite) TestBootstrapRecordsCheckpoints(c *gc.C) {
+	env := &taggableBootstrapEnviron{bootstrapEnviron: newEnviron("foo", useDefaultKeys, nil)}
+	env.instanceId = "i-bootstrap"
+	s.setDummyStorage(c, env.bootstrapEnviron)
+	checkpoints := &fakeCheckpointStore{}
+
+	err := bootstrap.Bootstrap(envtesting.BootstrapContext(c), env, s.bootstrapParams(checkpoints))
+	c.Assert(err, jc.ErrorIsNil)
+
+	var phases []string
+	for _, checkpoint := range checkpoints.saved {
+		phases = append(phases, checkpoint.Phase)
+	}
+	c.Assert(phases, jc.DeepEquals, []string{"select-tools", "start-instance", "init-mongo"})
+	c.Assert(*checkpoints.checkpoint, jc.DeepEquals, jujuclient.BootstrapCheckpoint{
+		Phase:        "init-mongo",
+		InstanceId:   "i-bootstrap",
+		Series:       env.instanceConfig.Series,
+		Arch:         env.args.AvailableTools.Arches()[0],
+		AgentVersion: jujuversion.Current.String(),
+	})
+	c.Assert(env.tags, jc.DeepEquals, []map[string]string{
+		{tags.JujuBootstrapPhase: "start-instance"},
+		{tags.JujuBootstrapPhase: "init-mongo"},
+	})
+}
+
+func (s *bootstrapSuite) TestBootstrapNoInstanceIdNoCheckpoint(c *gc.C) {
+	env := newEnviron("foo", useDefaultKeys, nil)
+	s.setDummyStorage(c, env)
+	checkpoints := &fakeCheckpointStore{}
+
+	err := bootstrap.Bootstrap(envtesting.BootstrapContext(c), env, s.bootstrapParams(checkpoints))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checkpoints.saved, gc.HasLen, 1)
+	c.Assert(checkpoints.saved[0].Phase, gc.Equals, "select-tools")
+}
+
+func (s *bootstrapSuite) TestBootstrapDryRun(c *gc.C) {
+	env := newEnviron("foo", useDefaultKeys, nil)
+	s.setDummyStorage(c, env)
+	checkpoints := &fakeCheckpointStore{}
+	args := s.bootstrapParams(checkpoints)
+	args.DryRun = true
+	args.BootstrapImage = "img-1234"
+	args.BootstrapSeries = series.MustHostSeries()
+
+	cmdCtx := cmdtesting.Context(c)
+	err := bootstrap.Bootstrap(modelcmd.BootstrapContext(cmdCtx), env, args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(env.bootstrapCount, gc.Equals, 0)
+	c.Assert(checkpoints.saved, gc.HasLen, 0)
+	c.Assert(cmdtesting.Stdout(cmdCtx), gc.Equals, `
+Planned bootstrap phases:
+ 1. select-tools: select agent binaries
+ 2. start-instance: start controller instance
+ 3. init-mongo: install agent and initialise controller database
+ 4. deploy-controller: start controller agent and deploy controller model
+
+Agent binaries: `[1:]+jujuversion.Current.String()+` (packaged)
+Series:         `+series.MustHostSeries()+`
+Architecture:   `+arch.HostArch()+`
+Constraints:    mem=3584M
+Image:          img-1234
+`)
+}
+
+func (s *bootstrapSuite) TestBootstrapResumeNoCheckpoint(c *gc.C) {
+	env := newEnviron("foo", useDefaultKeys, nil)
+	args := s.bootstrapParams(&fakeCheckpointStore{})
+	args.Resume = true
+	err := bootstrap.Bootstrap(envtesting.BootstrapContext(c), env, args)
+	c.Assert(err, gc.ErrorMatches, "no bootstrap checkpoint to resume from")
+	c.Assert(env.bootstrapCount, gc.Equals, 0)
+}
+
+func (s *bootstrapSuite) TestBootstrapResumeRequiresCheckpoints(c *gc.C) {
+	env := newEnviron("foo", useDefaultKeys, nil)
+	args := s.bootstrapParams(nil)
+	args.Resume = true
+	err := bootstrap.Bootstrap(envtesting.BootstrapContext(c), env, args)
+	c.Assert(err, gc.ErrorMatches, "validating bootstrap parameters: cannot resume without bootstrap checkpoints")
+}
+
+func (s *bootstrapSuite) TestBootstrapResumeAgentStarted(c *gc.C) {
+	env := newEnviron("foo", useDefaultKeys, nil)
+	args := s.bootstrapParams(&fakeCheckpointStore{
+		checkpoint: s.instanceCheckpoint(bootstrap.PhaseInitMongo),
+	})
+	args.Resume = true
+	err := bootstrap.Bootstrap(envtesting.BootstrapContext(c), env, args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(env.bootstrapCount, gc.Equals, 0)
+	c.Assert(env.finalizerCount, gc.Equals, 0)
+}
+
+func (s *bootstrapSuite) TestBootstrapResumeNotSupported(c *gc.C) {
+	env := newEnviron("foo", useDefaultKeys, nil)
+	s.setDummyStorage(c, env)
+	args := s.bootstrapParams(&fakeCheckpointStore{
+		checkpoint: s.instanceCheckpoint(bootstrap.PhaseStartInstance),
+	})
+	args.Resume = true
+	err := bootstrap.Bootstrap(envtesting.BootstrapContext(c), env, args)
+	c.Assert(err, gc.ErrorMatches, `resuming bootstrap on "dummy" not supported`)
+	c.Assert(env.bootstrapCount, gc.Equals, 0)
+}
+
+func (s *bootstrapSuite) TestBootstrapResumeStartedInstance(c *gc.C) {
+	env := &resumableBootstrapEnviron{bootstrapEnviron: newEnviron("foo", useDefaultKeys, nil)}
+	s.setDummyStorage(c, env.bootstrapEnviron)
+	checkpoints := &fakeCheckpointStore{
+		checkpoint: s.instanceCheckpoint(bootstrap.PhaseStartInstance),
+	}
+	args := s.bootstrapParams(checkpoints)
+	args.Resume = true
+
+	err := bootstrap.Bootstrap(envtesting.BootstrapContext(c), env, args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(env.bootstrapCount, gc.Equals, 0)
+	c.Assert(env.resumedId, gc.Equals, instance.Id("i-bootstrap"))
+	c.Assert(env.resumeArgs.BootstrapSeries, gc.Equals, series.MustHostSeries())
+	c.Assert(env.resumeArgs.AvailableTools.Arches(), jc.DeepEquals, []string{arch.HostArch()})
+	c.Assert(env.resumeArgs.InitialSSHHostKeys.RSA, jc.DeepEquals, &instancecfg.SSHKeyPair{
+		Public: "ssh-rsa public",
+	})
+	c.Assert(env.finalizerCount, gc.Equals, 1)
+	c.Assert(env.instanceConfig.ToolsList()[0].Version.Number, gc.Equals, jujuversion.Current)
+
+	expect := *s.instanceCheckpoint(bootstrap.PhaseInitMongo)
+	c.Assert(*checkpoints.checkpoint, jc.DeepEquals, expect)
+}
+
+type fakeCheckpointStore struct {
+	checkpoint *jujuclient.BootstrapCheckpoint
+	saved      []jujuclient.BootstrapCheckpoint
+}
+
+func (s *fakeCheckpointStore) Checkpoint() (jujuclient.BootstrapCheckpoint, error) {
+	if s.checkpoint == nil {
+		return jujuclient.BootstrapCheckpoint{}, errors.NotFoundf("checkpoint")
+	}
+	return *s.checkpoint, nil
+}
+
+func (s *fakeCheckpointStore) SaveCheckpoint(checkpoint jujuclient.BootstrapCheckpoint) error {
+	s.checkpoint = &checkpoint
+	s.saved = append(s.saved, checkpoint)
+	return nil
+}
+
+func (s *fakeCheckpointStore) RemoveCheckpoint() error {
+	s.checkpoint = nil
+	return nil
+}
+
+type taggableBootstrapEnviron struct {
+	*bootstrapEnviron
+	tags []map[string]string
+}
+
+func (e *taggableBootstrapEnviron) TagInstance(id instance.Id, tags map[string]string) error {
+	e.tags = append(e.tags, tags)
+	return nil
+}
+
+type resumableBootstrapEnviron struct {
+	*bootstrapEnviron
+	resumedId  instance.Id
+	resumeArgs environs.BootstrapParams
+}
+
+func (e *resumableBootstrapEnviron) ResumeBootstrap(
+	ctx environs.BootstrapContext, id instance.Id, args environs.BootstrapParams,
+) (*environs.BootstrapResult, error) {
+	e.resumedId = id
+	e.resumeArgs = args
+	finalizer := func(_ environs.BootstrapContext, icfg *instancecfg.InstanceConfig, _ environs.BootstrapDialOpts) error {
+		e.finalizerCount++
+		e.instanceConfig = icfg
+		return nil
+	}
+	return &environs.BootstrapResult{
+		Arch:               args.AvailableTools.Arches()[0],
+		Series:             args.BootstrapSeries,
+		InstanceId:         id,
+		InitialSSHHostKeys: args.InitialSSHHostKeys,
+		Finalize:           finalizer,
+	}, nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bootstrap
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/series"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs/imagemetadata"
+	"github.com/juju/juju/instance"
+	coretools "github.com/juju/juju/tools"
+)
+
+// bootstrapPlan describes what a bootstrap would do, for
+// reporting a dry run.
+type bootstrapPlan struct {
+	tools       coretools.List
+	buildAgent  bool
+	series      string
+	arch        string
+	constraints constraints.Value
+	image       string
+
+	// controllerContainer, if non-empty, is the type of container
+	// the controller would be placed in on the bootstrap instance.
+	controllerContainer instance.ContainerType
+}
+
+// writeBootstrapPlan writes a description of the given plan.
+func writeBootstrapPlan(w io.Writer, plan bootstrapPlan) error {
+	fmt.Fprintln(w, "Planned bootstrap phases:")
+	for i, phase := range Phases {
+		fmt.Fprintf(w, " %d. %s: %s\n", i+1, phase, phase.Description())
+	}
+	fmt.Fprintln(w)
+
+	agentVersion, _ := plan.tools.Newest()
+	agentSource := "packaged"
+	if plan.buildAgent {
+		agentSource = "built locally"
+	}
+	fmt.Fprintf(w, "Agent binaries: %s (%s)\n", agentVersion, agentSource)
+	fmt.Fprintf(w, "Series:         %s\n", plan.series)
+	fmt.Fprintf(w, "Architecture:   %s\n", plan.arch)
+	fmt.Fprintf(w, "Constraints:    %s\n", plan.constraints)
+	if plan.controllerContainer != "" {
+		fmt.Fprintf(w, "Container:      %s\n", plan.controllerContainer)
+	}
+	_, err := fmt.Fprintf(w, "Image:          %s\n", plan.image)
+	return errors.Trace(err)
+}
+
+// planImage returns the ID of the image that would be used to start the
+// controller instance, or a description of how it will be chosen if that
+// is not known ahead of time.
+func planImage(
+	bootstrapImage, imageSeries, imageArch string,
+	imageMetadata []*imagemetadata.ImageMetadata,
+) string {
+	if bootstrapImage != "" {
+		return bootstrapImage
+	}
+	seriesVersion, err := series.SeriesVersion(imageSeries)
+	if err == nil {
+		for _, m := range imageMetadata {
+			if m.Version == seriesVersion && m.Arch == imageArch {
+				return m.Id
+			}
+		}
+	}
+	return "selected by the provider"
+}
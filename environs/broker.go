@@ -4,8 +4,11 @@
 package environs
 
 import (
+	"context"
+
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/instance"
@@ -87,6 +90,11 @@ type StartInstanceParams struct {
 	// changes in status. Its signature is consistent with other
 	// status-related functions to allow them to be used as callbacks.
 	StatusCallback StatusCallbackFunc
+
+	// CharmLXDProfiles holds the LXD profiles, keyed on name, declared
+	// by the charms of the units to be deployed to the instance. Only
+	// brokers for LXD containers apply them.
+	CharmLXDProfiles map[string]lxdprofile.Profile
 }
 
 // StartInstanceResult holds the result of an
@@ -95,6 +103,10 @@ type StartInstanceResult struct {
 	// Instance is an interface representing a cloud instance.
 	Instance instance.Instance
 
+	// DisplayName is the human readable name given to the instance
+	// by the provider, if it differs from the instance id.
+	DisplayName string
+
 	// Config holds the environment config to be used for any further
 	// operations, if the instance is for a controller.
 	Config *config.Config
@@ -135,11 +147,16 @@ type InstanceBroker interface {
 	// the StartInstanceParams can never be fulfilled in any zone, then
 	// it may return an error satisfying the IsAvailabilityZoneIndependent
 	// function in this package.
-	StartInstance(args StartInstanceParams) (*StartInstanceResult, error)
+	//
+	// If ctx is cancelled, the provider should abandon any in-flight
+	// requests and return as soon as it can.
+	StartInstance(ctx context.Context, args StartInstanceParams) (*StartInstanceResult, error)
 
 	// StopInstances shuts down the instances with the specified IDs.
-	// Unknown instance IDs are ignored, to enable idempotency.
-	StopInstances(...instance.Id) error
+	// Unknown instance IDs are ignored, to enable idempotency. If ctx
+	// is cancelled, the provider should abandon any in-flight requests
+	// and return as soon as it can.
+	StopInstances(ctx context.Context, ids ...instance.Id) error
 
 	// AllInstances returns all instances currently known to the broker.
 	AllInstances() ([]instance.Instance, error)
@@ -21,7 +21,12 @@ import (
 	"gopkg.in/juju/environschema.v1"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/cloudconfig/cloudinit"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/egress"
+	"github.com/juju/juju/core/maintenance"
+	"github.com/juju/juju/core/pricing"
+	"github.com/juju/juju/core/relation"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/logfwd/syslog"
@@ -102,6 +107,15 @@ const (
 	// AptNoProxyKey stores the key for this setting.
 	AptNoProxyKey = "apt-no-proxy"
 
+	// SnapHTTPProxyKey stores the key for this setting.
+	SnapHTTPProxyKey = "snap-http-proxy"
+
+	// SnapHTTPSProxyKey stores the key for this setting.
+	SnapHTTPSProxyKey = "snap-https-proxy"
+
+	// SnapStoreProxyKey stores the key for this setting.
+	SnapStoreProxyKey = "snap-store-proxy"
+
 	// NetBondReconfigureDelay is the key to pass when bridging
 	// the network for containers.
 	NetBondReconfigureDelayKey = "net-bond-reconfigure-delay"
@@ -176,6 +190,77 @@ const (
 	// FanConfig defines the configuration for FAN network running in the model.
 	FanConfig = "fan-config"
 
+	// CleanOrphanedResources determines whether the orphan sweeper
+	// destroys provider resources that juju created but no longer
+	// tracks, rather than only reporting them.
+	CleanOrphanedResources = "clean-orphaned-resources"
+
+	// MaxRelationDataSize is the maximum total size of the settings
+	// a unit may store in a single relation, eg "1M". A size of zero
+	// disables the limit.
+	MaxRelationDataSize = "max-relation-data-size"
+
+	// RelationChangedDebounce is how long the controller waits, after
+	// a change to the settings or membership of a relation, before
+	// notifying the units watching it, eg "2s". Changes made in the
+	// meantime are notified together, so that busy peers cause fewer
+	// relation-changed hooks to be run.
+	RelationChangedDebounce = "relation-changed-debounce"
+
+	// CloudInitUserDataKey is the key for user-supplied cloud-init
+	// configuration, in YAML, which is merged with the configuration
+	// juju generates for each machine it provisions in the model.
+	CloudInitUserDataKey = "cloudinit-userdata"
+
+	// ContainerImageCacheSize is the maximum total size of the LXD
+	// images each machine agent keeps cached for creating containers,
+	// eg "10G". A size of zero disables the limit.
+	ContainerImageCacheSize = "container-image-cache-size"
+
+	// AgentPreinstalledKey determines whether the images used for new
+	// machines have a machine agent installed, so that the provisioner
+	// passes them only the metadata the agent needs to enlist rather
+	// than the cloud-init user data that installs it.
+	AgentPreinstalledKey = "agent-preinstalled"
+
+	// PreferIPv6Key determines whether IPv6 addresses are preferred
+	// over IPv4 ones when choosing the addresses of machines and units
+	// in the model, such as those reported in status and relation data.
+	PreferIPv6Key = "prefer-ipv6"
+
+	// PriceCatalogKey is the key for a static catalog, in YAML, of
+	// the prices of the cloud resources used by the model, which
+	// is used to estimate the model's cost.
+	PriceCatalogKey = "price-catalog"
+
+	// InterfaceVersionCheckKey determines what happens when endpoints
+	// implementing versions of an interface's schema that are not
+	// known to be compatible are related: "off", "warn" or "block".
+	InterfaceVersionCheckKey = "interface-version-check"
+
+	// EgressPolicyKey determines whether the outbound traffic of the
+	// model's machines is restricted to the destinations declared by
+	// the charms of their units: "open" or "restricted".
+	EgressPolicyKey = "egress-policy"
+
+	// MaintenanceWindowsKey is the key for the periods of the week,
+	// in UTC, within which disruptive automated operations, such as
+	// refreshing charms and, if deferred, upgrading agents and
+	// rebooting machines, may run in addition to the model's
+	// maintenance windows; for example
+	// "sat 02:00-04:00, sun 02:00-04:00".
+	MaintenanceWindowsKey = "maintenance-windows"
+
+	// DeferAgentUpgradesKey is the key for whether the upgrades of
+	// the model's agents, other than controller agents, wait for a
+	// maintenance window. By default agents upgrade at any time.
+	DeferAgentUpgradesKey = "defer-agent-upgrades"
+
+	// DeferRebootsKey is the key for whether the reboots requested
+	// for the model's machines, other than controllers, wait for a
+	// maintenance window. By default machines reboot at any time.
+	DeferRebootsKey = "defer-reboots"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -333,6 +418,13 @@ const (
 	// DefaultStatusHistorySize is the default value for MaxStatusHistorySize.
 	DefaultStatusHistorySize = "5G"
 
+	// DefaultRelationDataSize is the default value for MaxRelationDataSize.
+	DefaultRelationDataSize = "1M"
+
+	// DefaultContainerImageCacheSize is the default value for
+	// ContainerImageCacheSize.
+	DefaultContainerImageCacheSize = "10G"
+
 	// DefaultUpdateStatusHookInterval is the default value for UpdateStatusHookInterval
 	DefaultUpdateStatusHookInterval = "5m"
 
@@ -538,6 +630,44 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[MaxRelationDataSize].(string); ok {
+		if _, err := utils.ParseSize(v); err != nil {
+			return errors.Annotate(err, "invalid max relation data size in model configuration")
+		}
+	}
+
+	if v, ok := cfg.defined[RelationChangedDebounce].(string); ok && v != "" {
+		if d, err := time.ParseDuration(v); err != nil {
+			return errors.Annotate(err, "invalid relation-changed-debounce in model configuration")
+		} else if d < 0 {
+			return errors.Errorf("relation-changed-debounce %v cannot be negative", d)
+		}
+	}
+
+	if v, ok := cfg.defined[ContainerImageCacheSize].(string); ok {
+		if _, err := utils.ParseSize(v); err != nil {
+			return errors.Annotate(err, "invalid container image cache size in model configuration")
+		}
+	}
+
+	if v, ok := cfg.defined[CloudInitUserDataKey].(string); ok {
+		if _, err := cloudinit.ParseUserData(v); err != nil {
+			return errors.Annotate(err, "invalid cloudinit-userdata in model configuration")
+		}
+	}
+
+	if v, ok := cfg.defined[PriceCatalogKey].(string); ok && v != "" {
+		if _, err := pricing.ParseCatalog([]byte(v)); err != nil {
+			return errors.Annotate(err, "invalid price-catalog in model configuration")
+		}
+	}
+
+	if v, ok := cfg.defined[MaintenanceWindowsKey].(string); ok && v != "" {
+		if _, err := maintenance.ParsePeriods(v); err != nil {
+			return errors.Annotate(err, "invalid maintenance-windows in model configuration")
+		}
+	}
+
 	if v, ok := cfg.defined[MaxActionResultsAge].(string); ok {
 		if _, err := time.ParseDuration(v); err != nil {
 			return errors.Annotate(err, "invalid max action age in model configuration")
@@ -793,6 +923,32 @@ func (c *Config) AptNoProxy() string {
 	return c.getWithFallback(AptNoProxyKey, NoProxyKey)
 }
 
+// SnapProxySettings returns the proxy settings snapd should use on
+// the model's machines. Only http and https proxies are supported by
+// snapd.
+func (c *Config) SnapProxySettings() proxy.Settings {
+	return proxy.Settings{
+		Http:  c.SnapHTTPProxy(),
+		Https: c.SnapHTTPSProxy(),
+	}
+}
+
+// SnapHTTPProxy returns the snap http proxy for the environment.
+func (c *Config) SnapHTTPProxy() string {
+	return c.asString(SnapHTTPProxyKey)
+}
+
+// SnapHTTPSProxy returns the snap https proxy for the environment.
+func (c *Config) SnapHTTPSProxy() string {
+	return c.asString(SnapHTTPSProxyKey)
+}
+
+// SnapStoreProxy returns the id of the snap store proxy that the
+// model's machines should use, if any.
+func (c *Config) SnapStoreProxy() string {
+	return c.asString(SnapStoreProxyKey)
+}
+
 // AptMirror sets the apt mirror for the environment.
 func (c *Config) AptMirror() string {
 	return c.asString("apt-mirror")
@@ -920,6 +1076,30 @@ func (c *Config) AutomaticallyRetryHooks() bool {
 	}
 }
 
+// CleanOrphanedResources returns whether the orphan sweeper should
+// destroy untracked provider resources. By default this is false, and
+// orphaned resources are only reported.
+func (c *Config) CleanOrphanedResources() bool {
+	val, _ := c.defined[CleanOrphanedResources].(bool)
+	return val
+}
+
+// AgentPreinstalled returns whether the images used for new machines
+// have a machine agent installed, which enlists the machine from the
+// metadata the provisioner passes it. By default this is false.
+func (c *Config) AgentPreinstalled() bool {
+	val, _ := c.defined[AgentPreinstalledKey].(bool)
+	return val
+}
+
+// PreferIPv6 returns whether IPv6 addresses should be preferred over
+// IPv4 ones when selecting the public and private addresses of
+// machines. By default this is false.
+func (c *Config) PreferIPv6() bool {
+	val, _ := c.defined[PreferIPv6Key].(bool)
+	return val
+}
+
 // TransmitVendorMetrics returns whether the controller sends charm-collected metrics
 // in this model for anonymized aggregate analytics. By default this should be true.
 func (c *Config) TransmitVendorMetrics() bool {
@@ -1045,6 +1225,94 @@ func (c *Config) MaxStatusHistorySizeMB() uint {
 	return uint(val)
 }
 
+// MaxRelationDataSizeMB is the maximum size in MiB of the settings a
+// unit may store in a single relation. Zero means there is no limit.
+func (c *Config) MaxRelationDataSizeMB() uint {
+	v := c.asString(MaxRelationDataSize)
+	if v == "" {
+		v = DefaultRelationDataSize
+	}
+	// Value has already been validated.
+	val, _ := utils.ParseSize(v)
+	return uint(val)
+}
+
+// RelationChangedDebounce is how long the controller waits after a
+// relation change before notifying the units watching the relation.
+// Zero means units are notified immediately.
+func (c *Config) RelationChangedDebounce() time.Duration {
+	// Value has already been validated.
+	val, _ := time.ParseDuration(c.asString(RelationChangedDebounce))
+	return val
+}
+
+// ContainerImageCacheSizeMB is the maximum total size in MiB of the
+// container images cached by each machine agent. Zero means there is
+// no limit.
+func (c *Config) ContainerImageCacheSizeMB() uint {
+	v := c.asString(ContainerImageCacheSize)
+	if v == "" {
+		v = DefaultContainerImageCacheSize
+	}
+	// Value has already been validated.
+	val, _ := utils.ParseSize(v)
+	return uint(val)
+}
+
+// CloudInitUserData returns the user-supplied cloud-init configuration
+// for machines in the model, in YAML, or an empty string if there is
+// none.
+func (c *Config) CloudInitUserData() string {
+	return c.asString(CloudInitUserDataKey)
+}
+
+// PriceCatalog returns the static price catalog for the model, in
+// YAML, or an empty string if there is none.
+func (c *Config) PriceCatalog() string {
+	return c.asString(PriceCatalogKey)
+}
+
+// InterfaceVersionCheck returns what happens when endpoints whose
+// interface versions are not known to be compatible are related. It
+// defaults to warning about them.
+func (c *Config) InterfaceVersionCheck() relation.InterfaceCheckMode {
+	if v := c.asString(InterfaceVersionCheckKey); v != "" {
+		return relation.InterfaceCheckMode(v)
+	}
+	return relation.InterfaceCheckWarn
+}
+
+// EgressPolicy returns whether the outbound traffic of the model's
+// machines is restricted. It defaults to leaving it open.
+func (c *Config) EgressPolicy() egress.Mode {
+	if v := c.asString(EgressPolicyKey); v != "" {
+		return egress.Mode(v)
+	}
+	return egress.ModeOpen
+}
+
+// MaintenanceWindows returns the periods of the week within which
+// disruptive automated operations may run, in the form accepted by
+// maintenance.ParsePeriods. If it and the model's maintenance windows
+// are empty, they may run at any time.
+func (c *Config) MaintenanceWindows() string {
+	return c.asString(MaintenanceWindowsKey)
+}
+
+// DeferAgentUpgrades returns whether the upgrades of the model's
+// agents, other than controller agents, wait for a maintenance window.
+func (c *Config) DeferAgentUpgrades() bool {
+	val, _ := c.defined[DeferAgentUpgradesKey].(bool)
+	return val
+}
+
+// DeferReboots returns whether the reboots requested for the model's
+// machines, other than controllers, wait for a maintenance window.
+func (c *Config) DeferReboots() bool {
+	val, _ := c.defined[DeferRebootsKey].(bool)
+	return val
+}
+
 func (c *Config) MaxActionResultsAge() time.Duration {
 	// Value has already been validated.
 	val, _ := time.ParseDuration(c.mustString(MaxActionResultsAge))
@@ -1182,6 +1450,9 @@ var alwaysOptional = schema.Defaults{
 	AptHTTPSProxyKey:             schema.Omit,
 	AptFTPProxyKey:               schema.Omit,
 	AptNoProxyKey:                schema.Omit,
+	SnapHTTPProxyKey:             schema.Omit,
+	SnapHTTPSProxyKey:            schema.Omit,
+	SnapStoreProxyKey:            schema.Omit,
 	"apt-mirror":                 schema.Omit,
 	AgentStreamKey:               schema.Omit,
 	ResourceTagsKey:              schema.Omit,
@@ -1209,6 +1480,19 @@ var alwaysOptional = schema.Defaults{
 	UpdateStatusHookInterval:     schema.Omit,
 	EgressSubnets:                schema.Omit,
 	FanConfig:                    schema.Omit,
+	CleanOrphanedResources:       schema.Omit,
+	MaxRelationDataSize:          schema.Omit,
+	RelationChangedDebounce:      schema.Omit,
+	CloudInitUserDataKey:         schema.Omit,
+	ContainerImageCacheSize:      schema.Omit,
+	AgentPreinstalledKey:         schema.Omit,
+	PreferIPv6Key:                schema.Omit,
+	PriceCatalogKey:              schema.Omit,
+	InterfaceVersionCheckKey:     schema.Omit,
+	EgressPolicyKey:              schema.Omit,
+	MaintenanceWindowsKey:        schema.Omit,
+	DeferAgentUpgradesKey:        schema.Omit,
+	DeferRebootsKey:              schema.Omit,
 }
 
 func allowEmpty(attr string) bool {
@@ -1396,6 +1680,21 @@ var configSchema = environschema.Fields{
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	SnapHTTPProxyKey: {
+		Description: "The snap-centric HTTP proxy value",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	SnapHTTPSProxyKey: {
+		Description: "The snap-centric HTTPS proxy value",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	SnapStoreProxyKey: {
+		Description: "The snap store proxy id for the model's machines",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	"apt-mirror": {
 		// TODO document acceptable format
 		Description: "The APT mirror for the model",
@@ -1634,4 +1933,78 @@ data of the store. (default false)`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	CleanOrphanedResources: {
+		Description: "Whether provider resources created by juju but no longer tracked should be destroyed, rather than only reported",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
+	MaxRelationDataSize: {
+		Description: "The maximum total size of the settings a unit may store in a single relation, in human-readable memory format; 0 disables the limit",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	RelationChangedDebounce: {
+		Description: "How long to wait after a relation change before notifying the related units, in human-readable time format, so that rapid changes run fewer relation-changed hooks (default 0, notify immediately)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	CloudInitUserDataKey: {
+		Description: "Cloud-init configuration, in YAML, to merge into that of each new machine; packages, runcmd and write_files are supported",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	ContainerImageCacheSize: {
+		Description: "The maximum total size of the container images each machine keeps cached, in human-readable memory format; 0 disables the limit",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	AgentPreinstalledKey: {
+		Description: "Whether the images used for new machines have a machine agent installed, so that machines are enlisted from provider metadata instead of installed by cloud-init",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
+	PreferIPv6Key: {
+		Description: "Whether IPv6 addresses are preferred over IPv4 ones for machine and unit addresses, such as those in status and relation data",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
+	PriceCatalogKey: {
+		Description: "A catalog, in YAML, of the prices of instance types, storage pools and load balancers, used to estimate the model's cost",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	InterfaceVersionCheckKey: {
+		Description: "Whether relating endpoints whose interface versions are not known to be compatible is allowed (off), warned about (warn) or refused (block)",
+		Type:        environschema.Tstring,
+		Values: []interface{}{
+			string(relation.InterfaceCheckOff),
+			string(relation.InterfaceCheckWarn),
+			string(relation.InterfaceCheckBlock),
+		},
+		Group: environschema.EnvironGroup,
+	},
+	EgressPolicyKey: {
+		Description: "Whether the outbound traffic of the model's machines is unrestricted (open), or restricted to that required by juju and declared by the charms of their units (restricted)",
+		Type:        environschema.Tstring,
+		Values: []interface{}{
+			string(egress.ModeOpen),
+			string(egress.ModeRestricted),
+		},
+		Group: environschema.EnvironGroup,
+	},
+	MaintenanceWindowsKey: {
+		Description: `The periods of the week, in UTC, within which charms are refreshed automatically and, if deferred, agents upgraded and machines rebooted, eg "sat 02:00-04:00, daily 23:00-01:00"; if empty and the model has no maintenance windows, at any time`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	DeferAgentUpgradesKey: {
+		Description: "Whether the upgrades of agents, other than controller agents, wait for the model's maintenance windows (default false)",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
+	DeferRebootsKey: {
+		Description: "Whether the reboots requested for machines, other than controllers, wait for the model's maintenance windows (default false)",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
 }
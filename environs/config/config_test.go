@@ -21,6 +21,8 @@ import (
 	"gopkg.in/juju/environschema.v1"
 
 	"github.com/juju/juju/cert"
+	"github.com/juju/juju/core/egress"
+	"github.com/juju/juju/core/relation"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/testing"
@@ -197,6 +199,55 @@ var configTests = []configTest{
 			"agent-version": "2",
 		}),
 		err: `invalid agent version in model configuration: "2"`,
+	}, {
+		about:       "Invalid cloudinit-userdata",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"cloudinit-userdata": "bootcmd: [reboot]",
+		}),
+		err: `invalid cloudinit-userdata in model configuration: cloud-init user data key "bootcmd" not supported`,
+	}, {
+		about:       "Invalid price-catalog",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"price-catalog": "instance-types: {m4.large: 0.1}",
+		}),
+		err: `invalid price-catalog in model configuration: price catalog without currency not valid`,
+	}, {
+		about:       "Invalid interface-version-check",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"interface-version-check": "sometimes",
+		}),
+		err: `interface-version-check: expected one of \[off warn block], got "sometimes"`,
+	}, {
+		about:       "Invalid egress-policy",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"egress-policy": "closed",
+		}),
+		err: `egress-policy: expected one of \[open restricted], got "closed"`,
+	}, {
+		about:       "Invalid maintenance-windows",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"maintenance-windows": "sat 02:00",
+		}),
+		err: `invalid maintenance-windows in model configuration: times "02:00" in maintenance window "sat 02:00" not valid`,
+	}, {
+		about:       "Invalid relation-changed-debounce",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"relation-changed-debounce": "soon",
+		}),
+		err: `invalid relation-changed-debounce in model configuration: time: invalid duration "?soon"?`,
+	}, {
+		about:       "Negative relation-changed-debounce",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"relation-changed-debounce": "-1s",
+		}),
+		err: `relation-changed-debounce -1s cannot be negative`,
 	}, {
 		about:       "Missing type",
 		useDefaults: config.UseDefaults,
@@ -990,6 +1041,133 @@ func (s *ConfigSuite) TestAutoHookRetryTrueEnv(c *gc.C) {
 	c.Assert(config.AutomaticallyRetryHooks(), gc.Equals, true)
 }
 
+func (s *ConfigSuite) TestCleanOrphanedResourcesDefault(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.CleanOrphanedResources(), jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestCleanOrphanedResources(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{
+		"clean-orphaned-resources": true})
+	c.Assert(config.CleanOrphanedResources(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestAgentPreinstalledDefault(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.AgentPreinstalled(), jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestAgentPreinstalled(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{
+		"agent-preinstalled": true})
+	c.Assert(config.AgentPreinstalled(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestPreferIPv6Default(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.PreferIPv6(), jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestPreferIPv6(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{
+		"prefer-ipv6": true})
+	c.Assert(config.PreferIPv6(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestInterfaceVersionCheck(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.InterfaceVersionCheck(), gc.Equals, relation.InterfaceCheckWarn)
+	config = newTestConfig(c, testing.Attrs{
+		"interface-version-check": "block"})
+	c.Assert(config.InterfaceVersionCheck(), gc.Equals, relation.InterfaceCheckBlock)
+}
+
+func (s *ConfigSuite) TestEgressPolicy(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.EgressPolicy(), gc.Equals, egress.ModeOpen)
+	config = newTestConfig(c, testing.Attrs{
+		"egress-policy": "restricted"})
+	c.Assert(config.EgressPolicy(), gc.Equals, egress.ModeRestricted)
+}
+
+func (s *ConfigSuite) TestMaintenanceWindows(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.MaintenanceWindows(), gc.Equals, "")
+	config = newTestConfig(c, testing.Attrs{
+		"maintenance-windows": "sat 02:00-04:00, daily 23:00-01:00"})
+	c.Assert(config.MaintenanceWindows(), gc.Equals, "sat 02:00-04:00, daily 23:00-01:00")
+}
+
+func (s *ConfigSuite) TestDeferAgentUpgradesAndReboots(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.DeferAgentUpgrades(), jc.IsFalse)
+	c.Assert(config.DeferReboots(), jc.IsFalse)
+	config = newTestConfig(c, testing.Attrs{
+		"defer-agent-upgrades": true,
+		"defer-reboots":        true,
+	})
+	c.Assert(config.DeferAgentUpgrades(), jc.IsTrue)
+	c.Assert(config.DeferReboots(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestPriceCatalog(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.PriceCatalog(), gc.Equals, "")
+	catalog := "currency: USD\nload-balancer: 0.025\n"
+	config = newTestConfig(c, testing.Attrs{
+		"price-catalog": catalog})
+	c.Assert(config.PriceCatalog(), gc.Equals, catalog)
+}
+
+func (s *ConfigSuite) TestMaxRelationDataSizeDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.MaxRelationDataSizeMB(), gc.Equals, uint(1))
+}
+
+func (s *ConfigSuite) TestMaxRelationDataSize(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"max-relation-data-size": "4M"})
+	c.Assert(cfg.MaxRelationDataSizeMB(), gc.Equals, uint(4))
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"max-relation-data-size": "0"})
+	c.Assert(cfg.MaxRelationDataSizeMB(), gc.Equals, uint(0))
+}
+
+func (s *ConfigSuite) TestRelationChangedDebounce(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.RelationChangedDebounce(), gc.Equals, time.Duration(0))
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"relation-changed-debounce": "2s"})
+	c.Assert(cfg.RelationChangedDebounce(), gc.Equals, 2*time.Second)
+}
+
+func (s *ConfigSuite) TestCloudInitUserData(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.CloudInitUserData(), gc.Equals, "")
+
+	userData := "packages: [htop]\nruncmd: [\"echo hello\"]\n"
+	cfg = newTestConfig(c, testing.Attrs{
+		"cloudinit-userdata": userData})
+	c.Assert(cfg.CloudInitUserData(), gc.Equals, userData)
+}
+
+func (s *ConfigSuite) TestContainerImageCacheSizeDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.ContainerImageCacheSizeMB(), gc.Equals, uint(10*1024))
+}
+
+func (s *ConfigSuite) TestContainerImageCacheSize(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"container-image-cache-size": "2G"})
+	c.Assert(cfg.ContainerImageCacheSizeMB(), gc.Equals, uint(2048))
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"container-image-cache-size": "0"})
+	c.Assert(cfg.ContainerImageCacheSizeMB(), gc.Equals, uint(0))
+}
+
 func (s *ConfigSuite) TestProxyValuesWithFallback(c *gc.C) {
 	s.addJujuFiles(c)
 
@@ -1058,6 +1236,31 @@ func (s *ConfigSuite) TestProxyValuesNotSet(c *gc.C) {
 	c.Assert(config.NoProxy(), gc.Equals, "127.0.0.1,localhost,::1")
 }
 
+func (s *ConfigSuite) TestSnapProxyValues(c *gc.C) {
+	s.addJujuFiles(c)
+	config := newTestConfig(c, testing.Attrs{
+		"http-proxy":       "http://user@10.0.0.1",
+		"snap-http-proxy":  "http://user@10.0.0.3",
+		"snap-https-proxy": "https://user@10.0.0.3",
+		"snap-store-proxy": "store-id",
+	})
+	c.Assert(config.SnapProxySettings(), gc.Equals, proxy.Settings{
+		Http:  "http://user@10.0.0.3",
+		Https: "https://user@10.0.0.3",
+	})
+	c.Assert(config.SnapStoreProxy(), gc.Equals, "store-id")
+}
+
+func (s *ConfigSuite) TestSnapProxyValuesNotSet(c *gc.C) {
+	s.addJujuFiles(c)
+	config := newTestConfig(c, testing.Attrs{
+		"http-proxy": "http://user@10.0.0.1",
+	})
+	// Unlike apt's, snapd's proxies do not fall back to the model's.
+	c.Assert(config.SnapProxySettings(), gc.Equals, proxy.Settings{})
+	c.Assert(config.SnapStoreProxy(), gc.Equals, "")
+}
+
 func (s *ConfigSuite) TestProxyConfigMap(c *gc.C) {
 	s.addJujuFiles(c)
 	cfg := newTestConfig(c, testing.Attrs{})
@@ -1243,3 +1446,25 @@ var invalidCACert = `
 MIIBOgIBAAJAZabKgKInuOxj5vDWLwHHQtK3/45KB+32D15w94Nt83BmuGxo90lw
 -----END CERTIFICATE-----
 `[1:]
+
+func (s *ConfigSuite) TestNormalizeNumbers(c *gc.C) {
+	attrs := map[string]interface{}{
+		"float":    float64(17),
+		"fraction": 1.5,
+		"int64":    int64(42),
+		"int":      7,
+		"string":   "17",
+		"bool":     true,
+	}
+	c.Assert(config.NormalizeNumbers(attrs), jc.DeepEquals, map[string]interface{}{
+		"float":    17,
+		"fraction": 1.5,
+		"int64":    42,
+		"int":      7,
+		"string":   "17",
+		"bool":     true,
+	})
+	// The original attributes are left alone.
+	c.Assert(attrs["float"], gc.Equals, float64(17))
+	c.Assert(config.NormalizeNumbers(nil), gc.IsNil)
+}
@@ -4,6 +4,8 @@
 package config
 
 import (
+	"math"
+
 	"github.com/juju/schema"
 )
 
@@ -86,3 +88,44 @@ type RegionDefaultValue struct {
 	// Value is the value of the setting this represents in the named region.
 	Value interface{} `json:"value" yaml:"value"`
 }
+
+// NormalizeNumbers returns a copy of attrs in which every number with a
+// whole value is held as an int. Config attributes sent to the
+// controller are decoded from JSON, which makes every number a float64,
+// while those read from mongo are ints or int64s; without normalizing
+// them, the same value set at different levels would not compare equal.
+func NormalizeNumbers(attrs map[string]interface{}) map[string]interface{} {
+	if attrs == nil {
+		return nil
+	}
+	result := make(map[string]interface{}, len(attrs))
+	for attr, val := range attrs {
+		result[attr] = normalizeNumber(val)
+	}
+	return result
+}
+
+func normalizeNumber(val interface{}) interface{} {
+	switch n := val.(type) {
+	case int32:
+		return int(n)
+	case int64:
+		return int(n)
+	case float32:
+		return normalizeFloat(float64(n))
+	case float64:
+		return normalizeFloat(n)
+	}
+	return val
+}
+
+// maxExactFloat is the largest magnitude below which every whole
+// float64 is exact.
+const maxExactFloat = 1 << 53
+
+func normalizeFloat(f float64) interface{} {
+	if f == math.Trunc(f) && math.Abs(f) <= maxExactFloat {
+		return int(f)
+	}
+	return f
+}
@@ -0,0 +1,62 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs
+
+import (
+	"time"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/trust"
+)
+
+// MintCredentialParams holds the parameters for minting a credential
+// for the charm of a trusted application.
+type MintCredentialParams struct {
+	// Application is the name of the application whose charm will
+	// use the credential.
+	Application string
+
+	// Level is the trust level granted to the application. It is
+	// never trust.None.
+	Level trust.Level
+
+	// Duration is how long the credential should remain valid for.
+	// Providers may limit credentials to a shorter or longer lifetime.
+	Duration time.Duration
+}
+
+// ScopedCredential is a short-lived cloud credential whose permissions
+// are limited to those of an application's trust level.
+type ScopedCredential struct {
+	AuthType   cloud.AuthType
+	Attributes map[string]string
+
+	// Expiry is when the credential stops being valid.
+	Expiry time.Time
+}
+
+// CredentialMinter is an optional interface that an Environ may
+// implement to mint short-lived credentials for the charms of trusted
+// applications, so that charms are never given the model's own cloud
+// credential.
+type CredentialMinter interface {
+	// MintCredential returns a new credential scoped to the given
+	// application's trust level.
+	MintCredential(args MintCredentialParams) (ScopedCredential, error)
+}
+
+// CredentialMinterEnviron is an Environ that can mint scoped
+// credentials for applications.
+type CredentialMinterEnviron interface {
+	Environ
+	CredentialMinter
+}
+
+// SupportsCredentialMinting reports whether the environment can mint
+// scoped credentials, returning the environment as a
+// CredentialMinterEnviron if so.
+func SupportsCredentialMinting(env Environ) (CredentialMinterEnviron, bool) {
+	minterEnv, ok := env.(CredentialMinterEnviron)
+	return minterEnv, ok
+}
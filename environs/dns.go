@@ -0,0 +1,41 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs
+
+import (
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+)
+
+// DNSRegistrar is an optional interface that an Environ may implement
+// to manage records for machines in the provider's DNS service, such
+// as Route53 or Designate.
+type DNSRegistrar interface {
+	// RegisterMachineDNS creates or updates the DNS records for the
+	// machine with the given id and instance, so that they resolve to
+	// the given addresses, and returns the records' fully qualified
+	// domain name. Registering the same machine again must return the
+	// same name.
+	RegisterMachineDNS(machineId string, instId instance.Id, addrs []network.Address) (string, error)
+
+	// UnregisterMachineDNS removes the DNS records with the given
+	// fully qualified domain name, registered for the machine with
+	// the given id. It is not an error if there are no such records.
+	UnregisterMachineDNS(machineId string, fqdn string) error
+}
+
+// DNSRegistrarEnviron is an Environ that can register machines in
+// the provider's DNS.
+type DNSRegistrarEnviron interface {
+	Environ
+	DNSRegistrar
+}
+
+// SupportsDNSRegistration reports whether the environment can register
+// machines in the provider's DNS, returning the environment as a
+// DNSRegistrarEnviron if so.
+func SupportsDNSRegistration(env Environ) (DNSRegistrarEnviron, bool) {
+	dnsEnv, ok := env.(DNSRegistrarEnviron)
+	return dnsEnv, ok
+}
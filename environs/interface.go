@@ -4,6 +4,7 @@
 package environs
 
 import (
+	"context"
 	"io"
 
 	"github.com/juju/jsonschema"
@@ -387,17 +388,19 @@ type CreateParams struct {
 	ControllerUUID string
 }
 
-// Firewaller exposes methods for managing network ports.
+// Firewaller exposes methods for managing network ports. If the
+// context passed to a method is cancelled, the provider should abandon
+// any in-flight requests and return as soon as it can.
 type Firewaller interface {
 	// OpenPorts opens the given port ranges for the whole environment.
 	// Must only be used if the environment was setup with the
 	// FwGlobal firewall mode.
-	OpenPorts(rules []network.IngressRule) error
+	OpenPorts(ctx context.Context, rules []network.IngressRule) error
 
 	// ClosePorts closes the given port ranges for the whole environment.
 	// Must only be used if the environment was setup with the
 	// FwGlobal firewall mode.
-	ClosePorts(rules []network.IngressRule) error
+	ClosePorts(ctx context.Context, rules []network.IngressRule) error
 
 	// IngressRules returns the ingress rules applied to the whole environment.
 	// Must only be used if the environment was setup with the
@@ -405,7 +408,7 @@ type Firewaller interface {
 	// It is expected that there be only one ingress rule result for a given
 	// port range - the rule's SourceCIDRs will contain all applicable source
 	// address rules for that port range.
-	IngressRules() ([]network.IngressRule, error)
+	IngressRules(ctx context.Context) ([]network.IngressRule, error)
 }
 
 // InstanceTagger is an interface that can be used for tagging instances.
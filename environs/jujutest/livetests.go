@@ -4,6 +4,7 @@
 package jujutest
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"time"
@@ -283,7 +284,7 @@ func (t *LiveTests) TestStartStop(c *gc.C) {
 	c.Check(insts[0].Id(), gc.Equals, id0)
 	c.Check(insts[1], gc.IsNil)
 
-	err = t.Env.StopInstances(inst.Id())
+	err = t.Env.StopInstances(context.Background(), inst.Id())
 	c.Assert(err, jc.ErrorIsNil)
 
 	// The machine may not be marked as shutting down
@@ -303,11 +304,11 @@ func (t *LiveTests) TestPorts(c *gc.C) {
 
 	inst1, _ := jujutesting.AssertStartInstance(c, t.Env, t.ControllerUUID, "1")
 	c.Assert(inst1, gc.NotNil)
-	defer t.Env.StopInstances(inst1.Id())
+	defer t.Env.StopInstances(context.Background(), inst1.Id())
 	fwInst1, ok := inst1.(instance.InstanceFirewaller)
 	c.Assert(ok, gc.Equals, true)
 
-	rules, err := fwInst1.IngressRules("1")
+	rules, err := fwInst1.IngressRules(context.Background(), "1")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(rules, gc.HasLen, 0)
 
@@ -315,13 +316,13 @@ func (t *LiveTests) TestPorts(c *gc.C) {
 	c.Assert(inst2, gc.NotNil)
 	fwInst2, ok := inst2.(instance.InstanceFirewaller)
 	c.Assert(ok, gc.Equals, true)
-	rules, err = fwInst2.IngressRules("2")
+	rules, err = fwInst2.IngressRules(context.Background(), "2")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(rules, gc.HasLen, 0)
-	defer t.Env.StopInstances(inst2.Id())
+	defer t.Env.StopInstances(context.Background(), inst2.Id())
 
 	// Open some ports and check they're there.
-	err = fwInst1.OpenPorts(
+	err = fwInst1.OpenPorts(context.Background(),
 		"1", []network.IngressRule{
 			network.MustNewIngressRule("udp", 67, 67),
 			network.MustNewIngressRule("tcp", 45, 45),
@@ -329,7 +330,7 @@ func (t *LiveTests) TestPorts(c *gc.C) {
 		})
 
 	c.Assert(err, jc.ErrorIsNil)
-	rules, err = fwInst1.IngressRules("1")
+	rules, err = fwInst1.IngressRules(context.Background(), "1")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(
 		rules, jc.DeepEquals,
@@ -339,11 +340,11 @@ func (t *LiveTests) TestPorts(c *gc.C) {
 			network.MustNewIngressRule("udp", 67, 67, "0.0.0.0/0"),
 		},
 	)
-	rules, err = fwInst2.IngressRules("2")
+	rules, err = fwInst2.IngressRules(context.Background(), "2")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(rules, gc.HasLen, 0)
 
-	err = fwInst2.OpenPorts(
+	err = fwInst2.OpenPorts(context.Background(),
 		"2", []network.IngressRule{
 			network.MustNewIngressRule("tcp", 89, 89),
 			network.MustNewIngressRule("tcp", 45, 45),
@@ -352,7 +353,7 @@ func (t *LiveTests) TestPorts(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	// Check there's no crosstalk to another machine
-	rules, err = fwInst2.IngressRules("2")
+	rules, err = fwInst2.IngressRules(context.Background(), "2")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(
 		rules, jc.DeepEquals,
@@ -362,7 +363,7 @@ func (t *LiveTests) TestPorts(c *gc.C) {
 			network.MustNewIngressRule("tcp", 89, 89, "0.0.0.0/0"),
 		},
 	)
-	rules, err = fwInst1.IngressRules("1")
+	rules, err = fwInst1.IngressRules(context.Background(), "1")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(
 		rules, jc.DeepEquals,
@@ -374,30 +375,30 @@ func (t *LiveTests) TestPorts(c *gc.C) {
 	)
 
 	// Check that opening the same port again is ok.
-	oldRules, err := fwInst2.IngressRules("2")
+	oldRules, err := fwInst2.IngressRules(context.Background(), "2")
 	c.Assert(err, jc.ErrorIsNil)
-	err = fwInst2.OpenPorts(
+	err = fwInst2.OpenPorts(context.Background(),
 		"2", []network.IngressRule{
 			network.MustNewIngressRule("tcp", 45, 45),
 		})
 	c.Assert(err, jc.ErrorIsNil)
-	err = fwInst2.OpenPorts(
+	err = fwInst2.OpenPorts(context.Background(),
 		"2", []network.IngressRule{
 			network.MustNewIngressRule("tcp", 20, 30),
 		})
 	c.Assert(err, jc.ErrorIsNil)
-	rules, err = fwInst2.IngressRules("2")
+	rules, err = fwInst2.IngressRules(context.Background(), "2")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(rules, jc.DeepEquals, oldRules)
 
 	// Check that opening the same port again and another port is ok.
-	err = fwInst2.OpenPorts(
+	err = fwInst2.OpenPorts(context.Background(),
 		"2", []network.IngressRule{
 			network.MustNewIngressRule("tcp", 45, 45),
 			network.MustNewIngressRule("tcp", 99, 99),
 		})
 	c.Assert(err, jc.ErrorIsNil)
-	rules, err = fwInst2.IngressRules("2")
+	rules, err = fwInst2.IngressRules(context.Background(), "2")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(
 		rules, jc.DeepEquals,
@@ -408,7 +409,7 @@ func (t *LiveTests) TestPorts(c *gc.C) {
 			network.MustNewIngressRule("tcp", 99, 99, "0.0.0.0/0"),
 		},
 	)
-	err = fwInst2.ClosePorts(
+	err = fwInst2.ClosePorts(context.Background(),
 		"2", []network.IngressRule{
 			network.MustNewIngressRule("tcp", 45, 45),
 			network.MustNewIngressRule("tcp", 99, 99),
@@ -417,7 +418,7 @@ func (t *LiveTests) TestPorts(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	// Check that we can close ports and that there's no crosstalk.
-	rules, err = fwInst2.IngressRules("2")
+	rules, err = fwInst2.IngressRules(context.Background(), "2")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(
 		rules, jc.DeepEquals,
@@ -425,7 +426,7 @@ func (t *LiveTests) TestPorts(c *gc.C) {
 			network.MustNewIngressRule("tcp", 89, 89, "0.0.0.0/0"),
 		},
 	)
-	rules, err = fwInst1.IngressRules("1")
+	rules, err = fwInst1.IngressRules(context.Background(), "1")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(
 		rules, jc.DeepEquals,
@@ -437,25 +438,25 @@ func (t *LiveTests) TestPorts(c *gc.C) {
 	)
 
 	// Check that we can close multiple ports.
-	err = fwInst1.ClosePorts(
+	err = fwInst1.ClosePorts(context.Background(),
 		"1", []network.IngressRule{
 			network.MustNewIngressRule("tcp", 45, 45),
 			network.MustNewIngressRule("udp", 67, 67),
 			network.MustNewIngressRule("tcp", 80, 100),
 		})
 	c.Assert(err, jc.ErrorIsNil)
-	rules, err = fwInst1.IngressRules("1")
+	rules, err = fwInst1.IngressRules(context.Background(), "1")
 	c.Assert(rules, gc.HasLen, 0)
 
 	// Check that we can close ports that aren't there.
-	err = fwInst2.ClosePorts(
+	err = fwInst2.ClosePorts(context.Background(),
 		"2", []network.IngressRule{
 			network.MustNewIngressRule("tcp", 111, 111),
 			network.MustNewIngressRule("udp", 222, 222),
 			network.MustNewIngressRule("tcp", 600, 700),
 		})
 	c.Assert(err, jc.ErrorIsNil)
-	rules, err = fwInst2.IngressRules("2")
+	rules, err = fwInst2.IngressRules(context.Background(), "2")
 	c.Assert(
 		rules, jc.DeepEquals,
 		[]network.IngressRule{
@@ -466,13 +467,13 @@ func (t *LiveTests) TestPorts(c *gc.C) {
 	// Check errors when acting on environment.
 	fwEnv, ok := t.Env.(environs.Firewaller)
 	c.Assert(ok, gc.Equals, true)
-	err = fwEnv.OpenPorts([]network.IngressRule{network.MustNewIngressRule("tcp", 80, 80)})
+	err = fwEnv.OpenPorts(context.Background(), []network.IngressRule{network.MustNewIngressRule("tcp", 80, 80)})
 	c.Assert(err, gc.ErrorMatches, `invalid firewall mode "instance" for opening ports on model`)
 
-	err = fwEnv.ClosePorts([]network.IngressRule{network.MustNewIngressRule("tcp", 80, 80)})
+	err = fwEnv.ClosePorts(context.Background(), []network.IngressRule{network.MustNewIngressRule("tcp", 80, 80)})
 	c.Assert(err, gc.ErrorMatches, `invalid firewall mode "instance" for closing ports on model`)
 
-	_, err = fwEnv.IngressRules()
+	_, err = fwEnv.IngressRules(context.Background())
 	c.Assert(err, gc.ErrorMatches, `invalid firewall mode "instance" for retrieving ingress rules from model`)
 }
 
@@ -495,22 +496,22 @@ func (t *LiveTests) TestGlobalPorts(c *gc.C) {
 
 	// Create instances and check open ports on both instances.
 	inst1, _ := jujutesting.AssertStartInstance(c, t.Env, t.ControllerUUID, "1")
-	defer t.Env.StopInstances(inst1.Id())
+	defer t.Env.StopInstances(context.Background(), inst1.Id())
 
 	fwEnv, ok := t.Env.(environs.Firewaller)
 	c.Assert(ok, gc.Equals, true)
 
-	rules, err := fwEnv.IngressRules()
+	rules, err := fwEnv.IngressRules(context.Background())
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(rules, gc.HasLen, 0)
 
 	inst2, _ := jujutesting.AssertStartInstance(c, t.Env, t.ControllerUUID, "2")
-	rules, err = fwEnv.IngressRules()
+	rules, err = fwEnv.IngressRules(context.Background())
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(rules, gc.HasLen, 0)
-	defer t.Env.StopInstances(inst2.Id())
+	defer t.Env.StopInstances(context.Background(), inst2.Id())
 
-	err = fwEnv.OpenPorts([]network.IngressRule{
+	err = fwEnv.OpenPorts(context.Background(), []network.IngressRule{
 		network.MustNewIngressRule("udp", 67, 67),
 		network.MustNewIngressRule("tcp", 45, 45),
 		network.MustNewIngressRule("tcp", 89, 89),
@@ -519,7 +520,7 @@ func (t *LiveTests) TestGlobalPorts(c *gc.C) {
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
-	rules, err = fwEnv.IngressRules()
+	rules, err = fwEnv.IngressRules(context.Background())
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(
 		rules, jc.DeepEquals,
@@ -533,13 +534,13 @@ func (t *LiveTests) TestGlobalPorts(c *gc.C) {
 	)
 
 	// Check closing some ports.
-	err = fwEnv.ClosePorts([]network.IngressRule{
+	err = fwEnv.ClosePorts(context.Background(), []network.IngressRule{
 		network.MustNewIngressRule("tcp", 99, 99),
 		network.MustNewIngressRule("udp", 67, 67),
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
-	rules, err = fwEnv.IngressRules()
+	rules, err = fwEnv.IngressRules(context.Background())
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(
 		rules, jc.DeepEquals,
@@ -551,14 +552,14 @@ func (t *LiveTests) TestGlobalPorts(c *gc.C) {
 	)
 
 	// Check that we can close ports that aren't there.
-	err = fwEnv.ClosePorts([]network.IngressRule{
+	err = fwEnv.ClosePorts(context.Background(), []network.IngressRule{
 		network.MustNewIngressRule("tcp", 111, 111),
 		network.MustNewIngressRule("udp", 222, 222),
 		network.MustNewIngressRule("tcp", 2000, 2500),
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
-	rules, err = fwEnv.IngressRules()
+	rules, err = fwEnv.IngressRules(context.Background())
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(
 		rules, jc.DeepEquals,
@@ -572,15 +573,15 @@ func (t *LiveTests) TestGlobalPorts(c *gc.C) {
 	fwInst1, ok := inst1.(instance.InstanceFirewaller)
 	c.Assert(ok, gc.Equals, true)
 	// Check errors when acting on instances.
-	err = fwInst1.OpenPorts(
+	err = fwInst1.OpenPorts(context.Background(),
 		"1", []network.IngressRule{network.MustNewIngressRule("tcp", 80, 80)})
 	c.Assert(err, gc.ErrorMatches, `invalid firewall mode "global" for opening ports on instance`)
 
-	err = fwInst1.ClosePorts(
+	err = fwInst1.ClosePorts(context.Background(),
 		"1", []network.IngressRule{network.MustNewIngressRule("tcp", 80, 80)})
 	c.Assert(err, gc.ErrorMatches, `invalid firewall mode "global" for closing ports on instance`)
 
-	_, err = fwInst1.IngressRules("1")
+	_, err = fwInst1.IngressRules(context.Background(), "1")
 	c.Assert(err, gc.ErrorMatches, `invalid firewall mode "global" for retrieving ingress rules from instance`)
 }
 
@@ -916,9 +917,9 @@ func (t *LiveTests) TestStartInstanceWithEmptyNonceFails(c *gc.C) {
 		&params.ImageMetadata,
 	)
 	c.Check(err, jc.ErrorIsNil)
-	result, err := t.Env.StartInstance(params)
+	result, err := t.Env.StartInstance(context.Background(), params)
 	if result != nil && result.Instance != nil {
-		err := t.Env.StopInstances(result.Instance.Id())
+		err := t.Env.StopInstances(context.Background(), result.Instance.Id())
 		c.Check(err, jc.ErrorIsNil)
 	}
 	c.Assert(result, gc.IsNil)
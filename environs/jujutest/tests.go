@@ -4,6 +4,7 @@
 package jujutest
 
 import (
+	"context"
 	"path/filepath"
 
 	jc "github.com/juju/testing/checkers"
@@ -162,7 +163,7 @@ func (t *Tests) TestStartStop(c *gc.C) {
 	c.Assert(insts, gc.HasLen, 2)
 	c.Assert(insts[0].Id(), gc.Not(gc.Equals), insts[1].Id())
 
-	err = e.StopInstances(inst0.Id())
+	err = e.StopInstances(context.Background(), inst0.Id())
 	c.Assert(err, jc.ErrorIsNil)
 
 	insts, err = e.Instances([]instance.Id{id0, id1})
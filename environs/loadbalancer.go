@@ -0,0 +1,41 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs
+
+import (
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+)
+
+// LoadBalancerProvider is an optional interface that an Environ may
+// implement to provision the provider's load balancers, such as ELB or
+// Octavia, in front of exposed applications.
+type LoadBalancerProvider interface {
+	// EnsureLoadBalancer creates or updates the load balancer for the
+	// named application, so that it forwards the given ports to the
+	// given backend instances, and returns the load balancer's address.
+	// Ensuring the same application's load balancer again must update
+	// the existing load balancer rather than create another.
+	EnsureLoadBalancer(appName string, ports []network.PortRange, backends []instance.Id) (network.Address, error)
+
+	// RemoveLoadBalancer removes the load balancer for the named
+	// application. It is not an error if there is no such load
+	// balancer.
+	RemoveLoadBalancer(appName string) error
+}
+
+// LoadBalancerEnviron is an Environ that can provision load balancers
+// for applications.
+type LoadBalancerEnviron interface {
+	Environ
+	LoadBalancerProvider
+}
+
+// SupportsLoadBalancers reports whether the environment can provision
+// load balancers, returning the environment as a LoadBalancerEnviron
+// if so.
+func SupportsLoadBalancers(env Environ) (LoadBalancerEnviron, bool) {
+	lbEnv, ok := env.(LoadBalancerEnviron)
+	return lbEnv, ok
+}
@@ -54,6 +54,13 @@ type ProvisionMachineArgs struct {
 	// WinRM contains keys and client interface api with the remote windows machine
 	WinRM WinRMArgs
 
+	// Adopt, if true, allows a machine on which a machine agent was
+	// previously deployed, such as one restored from an image, to be
+	// provisioned. The previous agents are stopped and their data set
+	// aside, and if the previous machine agent was for a machine in the
+	// model that still exists, that machine is taken over.
+	Adopt bool
+
 	*params.UpdateBehavior
 }
 
@@ -5,5 +5,9 @@
 package sshprovisioner
 
 const (
-	DetectionScript = detectionScript
+	DetectionScript           = detectionScript
+	PreviousAgentsScript      = previousAgentsScript
+	FencePreviousAgentsScript = fencePreviousAgentsScript
 )
+
+var ParsePreviousAgents = parsePreviousAgents
@@ -131,6 +131,12 @@ type fakeSSH struct {
 	// detected ahead of time. This should always be set to
 	// true when testing Bootstrap.
 	SkipDetection bool
+
+	// PreviousAgents, if non-empty, is the output of the script
+	// listing the agents previously deployed on the machine. The
+	// fakeSSH script then also responds to the script that stops
+	// those agents. Provisioned should also be set.
+	PreviousAgents string
 }
 
 // install installs fake SSH commands, which will respond to
@@ -144,9 +150,15 @@ func (r fakeSSH) install(c *gc.C) testing.Restorer {
 	if !r.SkipProvisionAgent {
 		add(nil, nil, r.ProvisionAgentExitCode)
 	}
+	if r.PreviousAgents != "" {
+		add(sshprovisioner.FencePreviousAgentsScript, nil, 0)
+	}
 	if !r.SkipDetection {
 		restore.Add(installDetectionFakeSSH(c, r.Series, r.Arch))
 	}
+	if r.PreviousAgents != "" {
+		add(sshprovisioner.PreviousAgentsScript, r.PreviousAgents, 0)
+	}
 	var checkProvisionedOutput interface{}
 	if r.Provisioned {
 		checkProvisionedOutput = "/etc/init/jujud-machine-0.conf"
@@ -5,6 +5,7 @@
 package sshprovisioner
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 
 	"github.com/juju/juju/apiserver/params"
@@ -18,8 +19,14 @@ var (
 // Provision returns a new machineId and nil if the provision process is done successfully
 // The func will manual provision a linux machine using as it's default protocol SSH
 func ProvisionMachine(args manual.ProvisionMachineArgs) (machineId string, err error) {
+	var adopting bool
 	defer func() {
-		if machineId != "" && err != nil {
+		if machineId != "" && err != nil && adopting {
+			// The machine may have been in the model before, so it
+			// is left there; provisioning may be attempted again.
+			logger.Errorf("adopting machine %v failed: %v", machineId, err)
+			machineId = ""
+		} else if machineId != "" && err != nil {
 			logger.Errorf("provisioning failed, removing machine %v: %v", machineId, err)
 			if cleanupErr := args.Client.ForceDestroyMachines(machineId); cleanupErr != nil {
 				logger.Errorf("error cleaning up machine: %s", cleanupErr)
@@ -37,16 +44,24 @@ func ProvisionMachine(args manual.ProvisionMachineArgs) (machineId string, err e
 		return "", err
 	}
 
-	machineParams, err := gatherMachineParams(args.Host)
+	machineParams, err := gatherMachineParams(args.Host, args.Adopt)
 	if err != nil {
 		return "", err
 	}
+	adopting = machineParams.Adopt != nil
 
-	// Inform Juju that the machine exists.
+	// Inform Juju that the machine exists. When adopting a machine
+	// that was in the model before, this also fences out its previous
+	// agents, which can no longer log in.
 	machineId, err = manual.RecordMachineInState(args.Client, *machineParams)
 	if err != nil {
 		return "", err
 	}
+	if adopting {
+		if err := fencePreviousAgents(args.Host); err != nil {
+			return machineId, errors.Annotate(err, "cannot stop previous agents")
+		}
+	}
 
 	provisioningScript, err := args.Client.ProvisioningScript(params.ProvisioningScriptParams{
 		MachineId: machineId,
@@ -25,6 +25,7 @@ import (
 	envtools "github.com/juju/juju/environs/tools"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
 )
 
 type provisionerSuite struct {
@@ -127,6 +128,61 @@ func (s *provisionerSuite) TestProvisionMachine(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "error checking if provisioned: subprocess encountered error code 255")
 }
 
+func (s *provisionerSuite) TestProvisionMachineAdopt(c *gc.C) {
+	args := s.getArgs(c)
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Series:     series.LatestLts(),
+		InstanceId: instance.Id("manual:" + args.Host),
+		Nonce:      "manual:" + args.Host + ":old",
+	})
+	previousAgents := fmt.Sprintf("%s %s\n", machine.Tag(), s.IAASModel.ModelTag())
+
+	// Without adoption, a machine with previous agents is refused.
+	defer fakeSSH{
+		Provisioned:        true,
+		InitUbuntuUser:     true,
+		SkipDetection:      true,
+		SkipProvisionAgent: true,
+	}.install(c).Restore()
+	_, err := sshprovisioner.ProvisionMachine(args)
+	c.Assert(err, gc.Equals, manual.ErrProvisioned)
+
+	defer fakeSSH{
+		Series:         series.LatestLts(),
+		Arch:           "amd64",
+		Provisioned:    true,
+		InitUbuntuUser: true,
+		PreviousAgents: previousAgents,
+	}.install(c).Restore()
+	args.Adopt = true
+	machineId, err := sshprovisioner.ProvisionMachine(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineId, gc.Equals, machine.Id())
+
+	err = machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machine.CheckProvisioned("manual:"+args.Host+":old"), jc.IsFalse)
+}
+
+func (s *provisionerSuite) TestParsePreviousAgents(c *gc.C) {
+	agents, err := sshprovisioner.ParsePreviousAgents(`
+machine-3 model-deadbeef-0bad-400d-8000-4b1d0d06f00d
+unit-mysql-0 model-deadbeef-0bad-400d-8000-4b1d0d06f00d
+unit-nrpe-1
+`)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(agents, jc.DeepEquals, &params.AdoptMachineParams{
+		AgentTag:  "machine-3",
+		ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Units:     []string{"mysql/0", "nrpe/1"},
+	})
+
+	_, err = sshprovisioner.ParsePreviousAgents("controller\nmachine-0 model-deadbeef-0bad-400d-8000-4b1d0d06f00d\n")
+	c.Assert(err, gc.ErrorMatches, "cannot adopt a controller machine")
+	_, err = sshprovisioner.ParsePreviousAgents("machine-0\nmachine-1\n")
+	c.Assert(err, gc.ErrorMatches, "found agents for both machine-0 and machine-1")
+}
+
 func (s *provisionerSuite) TestFinishInstancConfig(c *gc.C) {
 	var series = series.LatestLts()
 	const arch = "amd64"
@@ -16,6 +16,7 @@ import (
 	"github.com/juju/utils/arch"
 	"github.com/juju/utils/shell"
 	"github.com/juju/utils/ssh"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cloudconfig"
@@ -185,6 +186,116 @@ func checkProvisioned(host string) (bool, error) {
 	return provisioned, nil
 }
 
+// DetectPreviousAgents reports the agents previously deployed on the
+// host machine, by reading their configuration.
+var DetectPreviousAgents = detectPreviousAgents
+
+func detectPreviousAgents(host string) (*params.AdoptMachineParams, error) {
+	logger.Infof("Detecting previous agents on %s", host)
+	output, err := runScript(host, previousAgentsScript)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return parsePreviousAgents(output)
+}
+
+// parsePreviousAgents parses the output of previousAgentsScript.
+func parsePreviousAgents(output string) (*params.AdoptMachineParams, error) {
+	var result params.AdoptMachineParams
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if fields[0] == "controller" {
+			return nil, errors.New("cannot adopt a controller machine")
+		}
+		tag, err := names.ParseTag(fields[0])
+		if err != nil {
+			logger.Warningf("ignoring unexpected agent %q", fields[0])
+			continue
+		}
+		switch tag := tag.(type) {
+		case names.MachineTag:
+			if result.AgentTag != "" {
+				return nil, errors.Errorf("found agents for both %s and %s", result.AgentTag, tag)
+			}
+			result.AgentTag = tag.String()
+			if len(fields) > 1 {
+				if modelTag, err := names.ParseModelTag(fields[1]); err == nil {
+					result.ModelUUID = modelTag.Id()
+				}
+			}
+		case names.UnitTag:
+			result.Units = append(result.Units, tag.Id())
+		}
+	}
+	return &result, nil
+}
+
+// fencePreviousAgents stops the agents previously deployed on the host
+// machine and prevents them from starting again, setting their data
+// aside so that they cannot be confused with the new agents.
+func fencePreviousAgents(host string) error {
+	logger.Infof("Stopping previous agents on %s", host)
+	_, err := runScript(host, fencePreviousAgentsScript)
+	return errors.Trace(err)
+}
+
+// runScript runs the script as root on the host machine, returning
+// its output.
+func runScript(host, script string) (string, error) {
+	cmd := ssh.Command("ubuntu@"+host, []string{"sudo", "-n", "/bin/bash"}, nil)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Stdin = strings.NewReader(script)
+	if err := cmd.Run(); err != nil {
+		if stderr.Len() != 0 {
+			err = fmt.Errorf("%v (%v)", err, strings.TrimSpace(stderr.String()))
+		}
+		return "", err
+	}
+	return stdout.String(), nil
+}
+
+// previousAgentsScript is the script to run on the remote machine to
+// list the agents previously deployed on it, with the models they
+// belonged to.
+const previousAgentsScript = `#!/bin/bash
+set -e
+if [ -d /var/lib/juju/db ]; then
+  echo controller
+fi
+for conf in /var/lib/juju/agents/*/agent.conf; do
+  [ -e "$conf" ] || continue
+  tag=$(basename "$(dirname "$conf")")
+  model=$(sed -n 's/^model: *//p' "$conf")
+  echo "$tag $model"
+done`
+
+// fencePreviousAgentsScript is the script to run on the remote machine
+// to stop and disable the agents previously deployed on it, and to
+// move their data aside.
+const fencePreviousAgentsScript = `#!/bin/bash
+set -e
+if [ -d /run/systemd/system ]; then
+  for unit in $(systemctl list-unit-files --no-legend 'jujud-*' | awk '{print $1}'); do
+    systemctl stop "$unit" || true
+    systemctl disable "$unit" || true
+  done
+  systemctl daemon-reload
+else
+  for conf in /etc/init/jujud-*.conf; do
+    [ -e "$conf" ] || continue
+    stop "$(basename "$conf" .conf)" || true
+    rm -f "$conf"
+  done
+fi
+if [ -d /var/lib/juju ]; then
+  mv /var/lib/juju /var/lib/juju.adopted-$(date +%Y%m%d%H%M%S)
+fi`
+
 // detectionScript is the script to run on the remote machine to
 // detect the OS series and hardware characteristics.
 const detectionScript = `#!/bin/bash
@@ -209,8 +320,10 @@ cat /proc/cpuinfo`
 // we are about to provision. It will SSH into that machine as the ubuntu user.
 // The hostname supplied should not include a username.
 // If we can, we will reverse lookup the hostname by its IP address, and use
-// the DNS resolved name, rather than the name that was supplied
-func gatherMachineParams(hostname string) (*params.AddMachineParams, error) {
+// the DNS resolved name, rather than the name that was supplied.
+// If adopt is true, a machine with existing agents is not refused;
+// the agents found are described in the result's Adopt field instead.
+func gatherMachineParams(hostname string, adopt bool) (*params.AddMachineParams, error) {
 
 	// Generate a unique nonce for the machine.
 	uuid, err := utils.NewUUID()
@@ -227,8 +340,15 @@ func gatherMachineParams(hostname string) (*params.AddMachineParams, error) {
 	if err != nil {
 		return nil, errors.Annotatef(err, "error checking if provisioned")
 	}
+	var previousAgents *params.AdoptMachineParams
 	if provisioned {
-		return nil, manual.ErrProvisioned
+		if !adopt {
+			return nil, manual.ErrProvisioned
+		}
+		previousAgents, err = DetectPreviousAgents(hostname)
+		if err != nil {
+			return nil, errors.Annotatef(err, "error detecting previous agents")
+		}
 	}
 
 	hc, series, err := DetectSeriesAndHardwareCharacteristics(hostname)
@@ -250,6 +370,7 @@ func gatherMachineParams(hostname string) (*params.AddMachineParams, error) {
 		Nonce:                   nonce,
 		Addrs:                   params.FromNetworkAddresses(addr),
 		Jobs:                    []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+		Adopt:                   previousAgents,
 	}
 	return machineParams, nil
 }
@@ -0,0 +1,24 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package objectstore
+
+import (
+	"time"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/environs/storage"
+)
+
+var URIEncode = uriEncode
+
+// NewS3Storage returns an S3 store that signs requests as if they were
+// made at the given time.
+func NewS3Storage(cfg controller.ObjectStoreConfig, now time.Time) (storage.Storage, error) {
+	s, err := newS3Storage(cfg)
+	if err != nil {
+		return nil, err
+	}
+	s.now = func() time.Time { return now }
+	return s, nil
+}
@@ -0,0 +1,29 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package objectstore provides storage.Storage implementations on the
+// external object stores that can hold a controller's blobs.
+package objectstore
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/environs/storage"
+)
+
+// Open returns the object store described by cfg. It returns an
+// error satisfying errors.IsNotSupported if cfg describes GridFS,
+// which is not an external object store.
+func Open(cfg controller.ObjectStoreConfig) (storage.Storage, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch cfg.Type {
+	case controller.ObjectStoreS3:
+		return newS3Storage(cfg)
+	case controller.ObjectStoreSwift:
+		return newSwiftStorage(cfg)
+	}
+	return nil, errors.NotSupportedf("object store type %q", cfg.Type)
+}
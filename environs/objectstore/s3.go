@@ -0,0 +1,304 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package objectstore
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/environs/storage"
+)
+
+const (
+	// defaultS3Region is the region requests are signed for when none
+	// is configured, as is usual for MinIO.
+	defaultS3Region = "us-east-1"
+
+	// unsignedPayload is used in place of the hash of request bodies,
+	// so that blobs can be streamed rather than read twice.
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+
+	// s3SignedHeaders lists the headers included in request
+	// signatures.
+	s3SignedHeaders = "host;x-amz-content-sha256;x-amz-date"
+
+	// amzDateFormat is the format of the X-Amz-Date header.
+	amzDateFormat = "20060102T150405Z"
+)
+
+// s3Endpoint returns the URL of the AWS S3 endpoint for the given
+// region.
+func s3Endpoint(region string) string {
+	if strings.HasPrefix(region, "cn-") {
+		return fmt.Sprintf("https://s3.%s.amazonaws.com.cn", region)
+	}
+	return fmt.Sprintf("https://s3.%s.amazonaws.com", region)
+}
+
+// s3Storage implements storage.Storage on an S3 bucket, using
+// path-style requests so that any S3 compatible store, such as MinIO,
+// can be used.
+type s3Storage struct {
+	endpoint  *url.URL
+	bucket    string
+	region    string
+	accessKey string
+	secretKey string
+	client    *http.Client
+	now       func() time.Time
+}
+
+func newS3Storage(cfg controller.ObjectStoreConfig) (*s3Storage, error) {
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = s3Endpoint(cfg.Region)
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing S3 endpoint")
+	}
+	region := cfg.Region
+	if region == "" {
+		region = defaultS3Region
+	}
+	return &s3Storage{
+		endpoint:  u,
+		bucket:    cfg.Bucket,
+		region:    region,
+		accessKey: cfg.AccessKey,
+		secretKey: cfg.SecretKey,
+		client:    http.DefaultClient,
+		now:       time.Now,
+	}, nil
+}
+
+// Get is specified in the StorageReader interface.
+func (s *s3Storage) Get(name string) (io.ReadCloser, error) {
+	resp, err := s.do("GET", name, nil, nil, 0)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return resp.Body, nil
+}
+
+// List is specified in the StorageReader interface.
+func (s *s3Storage) List(prefix string) ([]string, error) {
+	var names []string
+	query := url.Values{
+		"list-type": {"2"},
+		"prefix":    {prefix},
+	}
+	for {
+		resp, err := s.do("GET", "", query, nil, 0)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var result struct {
+			Contents []struct {
+				Key string
+			}
+			IsTruncated           bool
+			NextContinuationToken string
+		}
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, errors.Annotate(err, "parsing bucket listing")
+		}
+		for _, object := range result.Contents {
+			names = append(names, object.Key)
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			break
+		}
+		query.Set("continuation-token", result.NextContinuationToken)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// URL is specified in the StorageReader interface. The URL is not
+// signed, so it can only be used if the bucket allows public reads.
+func (s *s3Storage) URL(name string) (string, error) {
+	u, _ := s.objectURL(name, nil)
+	return u.String(), nil
+}
+
+// DefaultConsistencyStrategy is specified in the StorageReader interface.
+func (s *s3Storage) DefaultConsistencyStrategy() utils.AttemptStrategy {
+	return utils.AttemptStrategy{}
+}
+
+// ShouldRetry is specified in the StorageReader interface.
+func (s *s3Storage) ShouldRetry(err error) bool {
+	return false
+}
+
+// Put is specified in the StorageWriter interface.
+func (s *s3Storage) Put(name string, r io.Reader, length int64) error {
+	resp, err := s.do("PUT", name, nil, io.LimitReader(r, length), length)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Remove is specified in the StorageWriter interface.
+func (s *s3Storage) Remove(name string) error {
+	resp, err := s.do("DELETE", name, nil, nil, 0)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// RemoveAll is specified in the StorageWriter interface.
+func (s *s3Storage) RemoveAll() error {
+	return storage.RemoveAll(s)
+}
+
+// objectURL returns the URL of the named object, or of the bucket if
+// name is empty, and the canonical URI of that URL used to sign
+// requests.
+func (s *s3Storage) objectURL(name string, query url.Values) (*url.URL, string) {
+	u := *s.endpoint
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + s.bucket + "/" + name
+	u.RawPath = uriEncode(u.Path, false)
+	u.RawQuery = canonicalQuery(query)
+	return &u, u.RawPath
+}
+
+// do sends a signed request for the named object, returning the
+// response if it succeeded.
+func (s *s3Storage) do(method, name string, query url.Values, body io.Reader, length int64) (*http.Response, error) {
+	u, canonicalURI := s.objectURL(name, query)
+	if length == 0 {
+		body = nil
+	}
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	req.ContentLength = length
+	s.sign(req, canonicalURI, u.RawQuery)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, errors.Annotatef(err, "%s %q", method, name)
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound && name != "" {
+		return nil, errors.NotFoundf("object %q", name)
+	}
+	data, _ := ioutil.ReadAll(resp.Body)
+	var s3Err struct {
+		Code    string
+		Message string
+	}
+	if err := xml.Unmarshal(data, &s3Err); err != nil || s3Err.Code == "" {
+		return nil, errors.Errorf("%s %q: %s", method, name, resp.Status)
+	}
+	return nil, errors.Errorf("%s %q: %s: %s", method, name, s3Err.Code, s3Err.Message)
+}
+
+// sign adds an AWS Signature Version 4 Authorization header to the
+// request. The body of the request is not signed.
+func (s *s3Storage) sign(req *http.Request, canonicalURI, canonicalQuery string) {
+	now := s.now().UTC()
+	amzDate := now.Format(amzDateFormat)
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+
+	canonicalHeaders := "host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + unsignedPayload + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI,
+		canonicalQuery,
+		canonicalHeaders,
+		s3SignedHeaders,
+		unsignedPayload,
+	}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+
+	scope := date + "/" + s.region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), date)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, s3SignedHeaders, signature,
+	))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// canonicalQuery returns the query string encoded as required for
+// signing: sorted by key, with every reserved character escaped.
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, k := range keys {
+		for _, v := range query[k] {
+			parts = append(parts, uriEncode(k, true)+"="+uriEncode(v, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// uriEncode escapes all but the unreserved characters in s, and
+// slashes if encodeSlash is false.
+func uriEncode(s string, encodeSlash bool) string {
+	var buf bytes.Buffer
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			buf.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			buf.WriteByte(c)
+		default:
+			fmt.Fprintf(&buf, "%%%02X", c)
+		}
+	}
+	return buf.String()
+}
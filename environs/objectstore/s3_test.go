@@ -0,0 +1,185 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package objectstore_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/environs/objectstore"
+	"github.com/juju/juju/environs/storage"
+)
+
+type S3Suite struct {
+	server *httptest.Server
+	s3     *fakeS3
+	store  storage.Storage
+}
+
+var _ = gc.Suite(&S3Suite{})
+
+func (s *S3Suite) SetUpTest(c *gc.C) {
+	s.s3 = &fakeS3{objects: make(map[string]string)}
+	s.server = httptest.NewServer(s.s3)
+	store, err := objectstore.NewS3Storage(controller.ObjectStoreConfig{
+		Type:      controller.ObjectStoreS3,
+		Endpoint:  s.server.URL,
+		Bucket:    "juju",
+		AccessKey: "access",
+		SecretKey: "secret",
+	}, time.Date(2018, 5, 1, 12, 0, 0, 0, time.UTC))
+	c.Assert(err, jc.ErrorIsNil)
+	s.store = store
+}
+
+func (s *S3Suite) TearDownTest(c *gc.C) {
+	s.server.Close()
+}
+
+func (s *S3Suite) TestPutGet(c *gc.C) {
+	err := s.store.Put("blobstore/abc", strings.NewReader("abcdef"), 3)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.s3.objects, jc.DeepEquals, map[string]string{"blobstore/abc": "abc"})
+
+	r, err := s.store.Get("blobstore/abc")
+	c.Assert(err, jc.ErrorIsNil)
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "abc")
+}
+
+func (s *S3Suite) TestRequestsAreSigned(c *gc.C) {
+	err := s.store.Put("abc", strings.NewReader("abc"), 3)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.s3.requests, gc.HasLen, 1)
+	req := s.s3.requests[0]
+	c.Assert(req.Header.Get("X-Amz-Date"), gc.Equals, "20180501T120000Z")
+	c.Assert(req.Header.Get("X-Amz-Content-Sha256"), gc.Equals, "UNSIGNED-PAYLOAD")
+	c.Assert(req.Header.Get("Authorization"), gc.Matches,
+		`AWS4-HMAC-SHA256 Credential=access/20180501/us-east-1/s3/aws4_request, `+
+			`SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=[0-9a-f]{64}`)
+}
+
+func (s *S3Suite) TestGetNotFound(c *gc.C) {
+	_, err := s.store.Get("missing")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *S3Suite) TestRemove(c *gc.C) {
+	s.s3.objects["abc"] = "abc"
+	err := s.store.Remove("abc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.s3.objects, gc.HasLen, 0)
+
+	err = s.store.Remove("abc")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *S3Suite) TestList(c *gc.C) {
+	s.s3.objects["blobstore/b"] = "b"
+	s.s3.objects["blobstore/a"] = "a"
+	s.s3.objects["backups/c"] = "c"
+	names, err := s.store.List("blobstore/")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(names, jc.DeepEquals, []string{"blobstore/a", "blobstore/b"})
+	// The fake lists one object at a time, so the second was fetched
+	// with a continuation token.
+	c.Assert(s.s3.requests, gc.HasLen, 2)
+	c.Assert(s.s3.requests[1].URL.Query().Get("continuation-token"), gc.Equals, "blobstore/a")
+}
+
+func (s *S3Suite) TestError(c *gc.C) {
+	s.s3.denied = true
+	err := s.store.Put("abc", strings.NewReader("abc"), 3)
+	c.Assert(err, gc.ErrorMatches, `PUT "abc": AccessDenied: Access Denied`)
+}
+
+func (s *S3Suite) TestURIEncode(c *gc.C) {
+	c.Assert(objectstore.URIEncode("/juju/a b+c~d", false), gc.Equals, "/juju/a%20b%2Bc~d")
+	c.Assert(objectstore.URIEncode("a/b", true), gc.Equals, "a%2Fb")
+}
+
+func (s *S3Suite) TestOpenGridFS(c *gc.C) {
+	_, err := objectstore.Open(controller.ObjectStoreConfig{Type: controller.ObjectStoreGridFS})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *S3Suite) TestOpenInvalid(c *gc.C) {
+	_, err := objectstore.Open(controller.ObjectStoreConfig{Type: controller.ObjectStoreS3})
+	c.Assert(err, gc.ErrorMatches, "object-store-endpoint or object-store-region must be set for S3")
+}
+
+// fakeS3 is a minimal S3 server, holding the objects of the bucket
+// "juju".
+type fakeS3 struct {
+	objects  map[string]string
+	requests []*http.Request
+	denied   bool
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	f.requests = append(f.requests, req)
+	if f.denied {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `<Error><Code>AccessDenied</Code><Message>Access Denied</Message></Error>`)
+		return
+	}
+	key := strings.TrimPrefix(req.URL.Path, "/juju/")
+	switch req.Method {
+	case "PUT":
+		data, _ := ioutil.ReadAll(req.Body)
+		f.objects[key] = string(data)
+	case "GET":
+		if key == "" {
+			f.list(w, req)
+			return
+		}
+		data, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`)
+			return
+		}
+		fmt.Fprint(w, data)
+	case "DELETE":
+		if _, ok := f.objects[key]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// list lists one object at a time.
+func (f *fakeS3) list(w http.ResponseWriter, req *http.Request) {
+	prefix := req.URL.Query().Get("prefix")
+	token := req.URL.Query().Get("continuation-token")
+	var keys []string
+	for key := range f.objects {
+		if strings.HasPrefix(key, prefix) && key > token {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	fmt.Fprint(w, `<ListBucketResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/">`)
+	if len(keys) > 0 {
+		fmt.Fprintf(w, `<Contents><Key>%s</Key></Contents>`, keys[0])
+	}
+	if len(keys) > 1 {
+		fmt.Fprintf(w, `<IsTruncated>true</IsTruncated><NextContinuationToken>%s</NextContinuationToken>`, keys[0])
+	}
+	fmt.Fprint(w, `</ListBucketResult>`)
+}
@@ -0,0 +1,118 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package objectstore
+
+import (
+	"io"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils"
+	"gopkg.in/goose.v2/client"
+	gooseerrors "gopkg.in/goose.v2/errors"
+	"gopkg.in/goose.v2/identity"
+	gooselogging "gopkg.in/goose.v2/logging"
+	"gopkg.in/goose.v2/swift"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/environs/storage"
+)
+
+// swiftStorage implements storage.Storage on a Swift container.
+type swiftStorage struct {
+	container string
+	swift     *swift.Client
+}
+
+func newSwiftStorage(cfg controller.ObjectStoreConfig) (*swiftStorage, error) {
+	cred := identity.Credentials{
+		URL:        cfg.Endpoint,
+		User:       cfg.AccessKey,
+		Secrets:    cfg.SecretKey,
+		Region:     cfg.Region,
+		TenantName: cfg.Tenant,
+	}
+	gooseLogger := gooselogging.LoggoLogger{loggo.GetLogger("goose")}
+	client := client.NewClient(&cred, identity.AuthUserPass, gooseLogger)
+	return &swiftStorage{
+		container: cfg.Bucket,
+		swift:     swift.New(client),
+	}, nil
+}
+
+// Get is specified in the StorageReader interface.
+func (s *swiftStorage) Get(name string) (io.ReadCloser, error) {
+	r, _, err := s.swift.GetReader(s.container, name)
+	if err != nil {
+		return nil, maybeNotFound(err, name)
+	}
+	return r, nil
+}
+
+// List is specified in the StorageReader interface.
+func (s *swiftStorage) List(prefix string) ([]string, error) {
+	var names []string
+	marker := ""
+	for {
+		contents, err := s.swift.List(s.container, prefix, "", marker, 0)
+		if err != nil {
+			return nil, errors.Annotatef(err, "listing container %q", s.container)
+		}
+		if len(contents) == 0 {
+			break
+		}
+		for _, item := range contents {
+			names = append(names, item.Name)
+		}
+		marker = contents[len(contents)-1].Name
+	}
+	return names, nil
+}
+
+// URL is specified in the StorageReader interface. The URL is not
+// signed, so it can only be used if the container allows public reads.
+func (s *swiftStorage) URL(name string) (string, error) {
+	return s.swift.URL(s.container, name)
+}
+
+// DefaultConsistencyStrategy is specified in the StorageReader interface.
+func (s *swiftStorage) DefaultConsistencyStrategy() utils.AttemptStrategy {
+	return utils.AttemptStrategy{}
+}
+
+// ShouldRetry is specified in the StorageReader interface.
+func (s *swiftStorage) ShouldRetry(err error) bool {
+	return false
+}
+
+// Put is specified in the StorageWriter interface.
+func (s *swiftStorage) Put(name string, r io.Reader, length int64) error {
+	if err := s.swift.PutReader(s.container, name, io.LimitReader(r, length), length); err != nil {
+		return errors.Annotatef(err, "writing %q to container %q", name, s.container)
+	}
+	return nil
+}
+
+// Remove is specified in the StorageWriter interface.
+func (s *swiftStorage) Remove(name string) error {
+	err := s.swift.DeleteObject(s.container, name)
+	if err != nil && !gooseerrors.IsNotFound(err) {
+		return errors.Annotatef(err, "removing %q from container %q", name, s.container)
+	}
+	return nil
+}
+
+// RemoveAll is specified in the StorageWriter interface.
+func (s *swiftStorage) RemoveAll() error {
+	return storage.RemoveAll(s)
+}
+
+// maybeNotFound returns an error satisfying errors.IsNotFound if err
+// is a Swift not found error.
+func maybeNotFound(err error, name string) error {
+	if gooseerrors.IsNotFound(err) {
+		return errors.NewNotFound(err, "object "+name)
+	}
+	return errors.Annotatef(err, "reading %q", name)
+}
@@ -21,6 +21,10 @@ const (
 	// whether a machine instance is a controller or not.
 	JujuIsController = JujuTagPrefix + "is-controller"
 
+	// JujuBootstrapPhase is the tag name used for recording the
+	// last bootstrap phase completed on a controller instance.
+	JujuBootstrapPhase = JujuTagPrefix + "bootstrap-phase"
+
 	// JujuUnitsDeployed is the tag name used for identifying
 	// the units deployed to a machine instance. The value is
 	// a space-separated list of the unit names.
@@ -28,7 +28,8 @@ const LegacyUpstart = "legacy-upstart"
 // (space list|create, subnet list|add).
 const PostNetCLIMVP = "post-net-cli-mvp"
 
-// ImageMetadata allows custom image metadata to be recorded in state.
+// ImageMetadata allows the image of the bootstrap machine to be
+// specified. Custom image metadata is always recorded in state.
 const ImageMetadata = "image-metadata"
 
 // DeveloperMode allows access to developer specific commands and behaviour.
@@ -40,3 +41,8 @@ const StrictMigration = "strict-migration"
 
 // CAAS enables creating models on CAAS infrastructure (k8s, etc)
 const CAAS = "caas"
+
+// LoadTesting enables the LoadTest facade, which populates models with
+// synthetic applications, units and machines, and changes them, so
+// that the controller's scale and performance can be tested.
+const LoadTesting = "load-testing"
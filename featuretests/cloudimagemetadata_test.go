@@ -10,7 +10,7 @@ import (
 
 	"github.com/juju/juju/api/imagemetadatamanager"
 	"github.com/juju/juju/apiserver/params"
-	"github.com/juju/juju/feature"
+	"github.com/juju/juju/environs/simplestreams"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/rpc"
@@ -22,7 +22,6 @@ type cloudImageMetadataSuite struct {
 }
 
 func (s *cloudImageMetadataSuite) SetUpTest(c *gc.C) {
-	s.SetInitialFeatureFlags(feature.ImageMetadata)
 	s.JujuConnSuite.SetUpTest(c)
 	s.client = imagemetadatamanager.NewClient(s.APIState)
 	c.Assert(s.client, gc.NotNil)
@@ -68,6 +67,8 @@ func (s *cloudImageMetadataSuite) TestSaveAndFindAndDeleteMetadata(c *gc.C) {
 
 	// m.Version would be deduced from m.Series
 	m.Version = "14.04"
+	// custom metadata is preferred over simplestreams
+	m.Priority = simplestreams.CUSTOM_CLOUD_DATA
 	c.Assert(added, jc.DeepEquals, []params.CloudImageMetadata{m})
 
 	// make sure it's in db too
@@ -0,0 +1,102 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"sort"
+	"text/template"
+
+	"github.com/juju/juju/apiserver/facade/readonly"
+)
+
+var fileTemplate = `
+// Copyright {{.CopyrightYear}} Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package {{.Pkgname}}
+
+// Generated code - do not edit.
+
+import "github.com/juju/utils/set"
+
+// {{.VarName}} maps the import path and name of each type, joined with
+// a ".", to the methods in its method set that are annotated with
+// {{.Annotation}}.
+var {{.VarName}} = map[string]set.Strings{
+{{- range .Types}}
+	{{printf "%q" .Name}}: set.NewStrings(
+	{{- range .Methods}}
+		{{printf "%q" .}},
+	{{- end}}
+	),
+{{- end}}
+}
+`[1:]
+
+// This generator finds the methods annotated as read-only in the
+// packages under a directory, and generates a Go file declaring them
+// as a variable.
+func main() {
+	if len(os.Args) < 7 {
+		fmt.Println("Usage: readonlymethods <dir> <importpath> <varname> <gofile> <copyrightyear> <pkgname>")
+		os.Exit(1)
+	}
+	methods, err := readonly.Find(os.Args[1], os.Args[2])
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	type typeMethods struct {
+		Name    string
+		Methods []string
+	}
+	var types []typeMethods
+	for name, names := range methods {
+		types = append(types, typeMethods{name, names})
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].Name < types[j].Name
+	})
+
+	t, err := template.New("").Parse(fileTemplate)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	var buf bytes.Buffer
+	err = t.Execute(&buf, struct {
+		Annotation    string
+		VarName       string
+		Types         []typeMethods
+		CopyrightYear string
+		Pkgname       string
+	}{
+		Annotation:    readonly.Annotation,
+		VarName:       os.Args[3],
+		Types:         types,
+		CopyrightYear: os.Args[5],
+		Pkgname:       os.Args[6],
+	})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	err = ioutil.WriteFile(os.Args[4], source, 0644)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
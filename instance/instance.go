@@ -4,6 +4,7 @@
 package instance
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"strconv"
@@ -41,15 +42,17 @@ type Instance interface {
 	Addresses() ([]network.Address, error)
 }
 
-// InstanceFirewaller provides instance-level firewall functionality
+// InstanceFirewaller provides instance-level firewall functionality.
+// If the context passed to a method is cancelled, the provider should
+// abandon any in-flight requests and return as soon as it can.
 type InstanceFirewaller interface {
 	// OpenPorts opens the given port ranges on the instance, which
 	// should have been started with the given machine id.
-	OpenPorts(machineId string, rules []network.IngressRule) error
+	OpenPorts(ctx context.Context, machineId string, rules []network.IngressRule) error
 
 	// ClosePorts closes the given port ranges on the instance, which
 	// should have been started with the given machine id.
-	ClosePorts(machineId string, rules []network.IngressRule) error
+	ClosePorts(ctx context.Context, machineId string, rules []network.IngressRule) error
 
 	// IngressRules returns the set of ingress rules for the instance,
 	// which should have been applied to the given machine id. The
@@ -57,7 +60,7 @@ type InstanceFirewaller interface {
 	// It is expected that there be only one ingress rule result for a given
 	// port range - the rule's SourceCIDRs will contain all applicable source
 	// address rules for that port range.
-	IngressRules(machineId string) ([]network.IngressRule, error)
+	IngressRules(ctx context.Context, machineId string) ([]network.IngressRule, error)
 }
 
 // HardwareCharacteristics represents the characteristics of the instance (if known).
@@ -14,6 +14,10 @@ const (
 	// MachineScope is a special scope name that is used
 	// for machine placement directives (e.g. --to 0).
 	MachineScope = "#"
+
+	// NewMachineDirective is a special directive for container scopes
+	// (e.g. --to lxd:new), requesting a container on a new machine.
+	NewMachineDirective = "new"
 )
 
 var ErrPlacementScopeMissing = fmt.Errorf("placement scope missing")
@@ -31,7 +35,8 @@ type Placement struct {
 	// Directive is a scope-specific placement directive.
 	//
 	// For MachineScope or a container scope, this may be empty or
-	// the ID of an existing machine.
+	// the ID of an existing machine. A container scope directive of
+	// NewMachineDirective is normalised to an empty directive.
 	Directive string `json:"directive"`
 }
 
@@ -59,6 +64,9 @@ func ParsePlacement(directive string) (*Placement, error) {
 		if scope == "" {
 			return nil, ErrPlacementScopeMissing
 		}
+		if isContainerType(scope) && directive == NewMachineDirective {
+			return &Placement{Scope: scope}, nil
+		}
 		// Sanity check: machine/container scopes require a machine ID as the value.
 		if (scope == MachineScope || isContainerType(scope)) && !names.IsValidMachine(directive) {
 			return nil, fmt.Errorf("invalid value %q for %q scope: expected machine-id", directive, scope)
@@ -45,6 +45,12 @@ func (s *PlacementSuite) TestParsePlacement(c *gc.C) {
 	}, {
 		arg:         "lxd",
 		expectScope: string(instance.LXD),
+	}, {
+		arg:         "lxd:new",
+		expectScope: string(instance.LXD),
+	}, {
+		arg: "#:new",
+		err: `invalid value "new" for "#" scope: expected machine-id`,
 	}, {
 		arg: "non-standard",
 		err: "placement scope missing",
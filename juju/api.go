@@ -4,8 +4,12 @@
 package juju
 
 import (
+	"context"
 	"net"
 	"reflect"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -44,8 +48,32 @@ type NewAPIConnectionParams struct {
 	// will be scoped to the model with that UUID; otherwise it will be
 	// scoped to the controller.
 	ModelUUID string
+
+	// SRVResolver is used to discover API addresses published in DNS
+	// SRV records for the controller's public DNS name. If it is nil,
+	// net.DefaultResolver will be used.
+	SRVResolver SRVResolver
+}
+
+// SRVResolver implements the lookup of DNS SRV records. It is
+// notably implemented by net.Resolver.
+type SRVResolver interface {
+	LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
 }
 
+const (
+	// apiSRVService and apiSRVProto name the DNS SRV records,
+	// _juju-api._tcp.<domain>, that publish the API addresses of
+	// the controller with the public DNS name <domain>.
+	apiSRVService = "juju-api"
+	apiSRVProto   = "tcp"
+
+	// srvLookupTimeout bounds the time spent discovering API
+	// addresses before dialing, so that a slow or broken DNS server
+	// does not hold up connections to cached addresses.
+	srvLookupTimeout = 2 * time.Second
+)
+
 // NewAPIConnection returns an api.Connection to the specified Juju controller,
 // with specified account credentials, optionally scoped to the specified model
 // name.
@@ -57,6 +85,13 @@ func NewAPIConnection(args NewAPIConnectionParams) (_ api.Connection, err error)
 	if err != nil {
 		return nil, errors.Annotatef(err, "cannot work out how to connect")
 	}
+	if controller.PublicDNSName != "" {
+		// The cached addresses may be stale if the controller's
+		// addresses have changed behind its public DNS name, so race
+		// them against any addresses published in DNS.
+		discovered := discoverAPIAddresses(args.SRVResolver, controller.PublicDNSName)
+		apiInfo.Addrs = mergeAddrs(apiInfo.Addrs, discovered)
+	}
 	if len(apiInfo.Addrs) == 0 {
 		return nil, errors.New("no API addresses")
 	}
@@ -72,16 +107,27 @@ func NewAPIConnection(args NewAPIConnectionParams) (_ api.Connection, err error)
 			return nil, errors.Trace(err)
 		}
 		// We've been told to connect to a different API server,
-		// so do so. Note that we don't copy the account details
-		// because the account on the redirected server may well
-		// be different - we'll use macaroon authentication
-		// directly without sending account details.
-		// Copy the API info because it's possible that the
-		// apiConfigConnect is still using it concurrently.
-		apiInfo = &api.Info{
-			ModelTag: apiInfo.ModelTag,
-			Addrs:    network.HostPortsToStrings(usableHostPorts(redirErr.Servers)),
-			CACert:   redirErr.CACert,
+		// so do so. Copy the API info because it's possible that
+		// the apiConfigConnect is still using it concurrently.
+		addrs := network.HostPortsToStrings(usableHostPorts(redirErr.Servers))
+		if redirErr.CACert == apiInfo.CACert {
+			// The redirection is to another machine of the same
+			// controller, which serves the model from there, so
+			// the account details are still valid.
+			info := *apiInfo
+			info.Addrs = addrs
+			apiInfo = &info
+		} else {
+			// Note that we don't copy the account details
+			// because the account on the redirected server
+			// may well be different - we'll use macaroon
+			// authentication directly without sending account
+			// details.
+			apiInfo = &api.Info{
+				ModelTag: apiInfo.ModelTag,
+				Addrs:    addrs,
+				CACert:   redirErr.CACert,
+			}
 		}
 		st, err = args.OpenAPI(apiInfo, args.DialOpts)
 		if err != nil {
@@ -194,6 +240,51 @@ func connectionInfo(args NewAPIConnectionParams) (*api.Info, *jujuclient.Control
 	return apiInfo, controller, nil
 }
 
+// discoverAPIAddresses returns the API addresses published in the
+// _juju-api._tcp SRV records of the given domain, in the order in
+// which they should be tried. Lookup failures are not fatal, as the
+// records are optional; no addresses are returned in that case.
+func discoverAPIAddresses(resolver SRVResolver, domain string) []string {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), srvLookupTimeout)
+	defer cancel()
+	_, records, err := resolver.LookupSRV(ctx, apiSRVService, apiSRVProto, domain)
+	if err != nil {
+		logger.Debugf("cannot discover API addresses for %q: %v", domain, err)
+		return nil
+	}
+	var addrs []string
+	for _, srv := range records {
+		host := strings.TrimSuffix(srv.Target, ".")
+		if host == "" || srv.Port == 0 {
+			continue
+		}
+		addrs = append(addrs, net.JoinHostPort(host, strconv.Itoa(int(srv.Port))))
+	}
+	if len(addrs) > 0 {
+		logger.Debugf("discovered API addresses for %q: %v", domain, addrs)
+	}
+	return addrs
+}
+
+// mergeAddrs returns the addresses in a followed by those in b
+// that are not already present.
+func mergeAddrs(a, b []string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, addrs := range [][]string{a, b} {
+		for _, addr := range addrs {
+			if !seen[addr] {
+				seen[addr] = true
+				result = append(result, addr)
+			}
+		}
+	}
+	return result
+}
+
 // usableHostPorts returns hps with unusable and non-unique
 // host-ports filtered out.
 func usableHostPorts(hps [][]network.HostPort) []network.HostPort {
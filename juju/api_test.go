@@ -163,6 +163,84 @@ func (s *NewAPIClientSuite) TestWithInfoNoAddresses(c *gc.C) {
 	c.Assert(st, gc.IsNil)
 }
 
+func (s *NewAPIClientSuite) TestDiscoversAPIAddressesFromSRV(c *gc.C) {
+	store := newClientStore(c, "controllername")
+	details := store.Controllers["controllername"]
+	details.PublicDNSName = "controller.example.com"
+	store.Controllers["controllername"] = details
+
+	resolver := srvResolverFunc(func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+		c.Check(service, gc.Equals, "juju-api")
+		c.Check(proto, gc.Equals, "tcp")
+		c.Check(name, gc.Equals, "controller.example.com")
+		return "", []*net.SRV{
+			{Target: "api1.example.com.", Port: 17070},
+			{Target: "0.1.2.3.", Port: 5678},
+			{Target: ".", Port: 17070},
+		}, nil
+	})
+	apiOpen := func(apiInfo *api.Info, opts api.DialOpts) (api.Connection, error) {
+		c.Check(apiInfo.Addrs, jc.DeepEquals, []string{
+			"0.1.2.3:5678",
+			"api1.example.com:17070",
+		})
+		conn := mockedAPIState(noFlags)
+		conn.addr = "api1.example.com:17070"
+		conn.apiHostPorts = [][]network.HostPort{mustParseHostPorts([]string{"0.9.9.9:17070"})}
+		return conn, nil
+	}
+	_, err := juju.NewAPIConnection(juju.NewAPIConnectionParams{
+		Store:          store,
+		ControllerName: "controllername",
+		OpenAPI:        apiOpen,
+		SRVResolver:    resolver,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The cached endpoints are refreshed with those of the
+	// controller that was reached.
+	c.Assert(store.Controllers["controllername"].APIEndpoints, jc.DeepEquals, []string{"0.9.9.9:17070"})
+}
+
+func (s *NewAPIClientSuite) TestSRVLookupFailureIsNotFatal(c *gc.C) {
+	store := newClientStore(c, "controllername")
+	details := store.Controllers["controllername"]
+	details.PublicDNSName = "controller.example.com"
+	store.Controllers["controllername"] = details
+
+	resolver := srvResolverFunc(func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+		return "", nil, errors.New("no such host")
+	})
+	apiOpen := func(apiInfo *api.Info, opts api.DialOpts) (api.Connection, error) {
+		c.Check(apiInfo.Addrs, jc.DeepEquals, []string{"0.1.2.3:5678"})
+		return mockedAPIState(noFlags), nil
+	}
+	_, err := juju.NewAPIConnection(juju.NewAPIConnectionParams{
+		Store:          store,
+		ControllerName: "controllername",
+		OpenAPI:        apiOpen,
+		SRVResolver:    resolver,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *NewAPIClientSuite) TestSRVNotUsedWithoutPublicDNSName(c *gc.C) {
+	store := newClientStore(c, "controllername")
+	resolver := srvResolverFunc(func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+		panic("LookupSRV called unexpectedly")
+	})
+	apiOpen := func(apiInfo *api.Info, opts api.DialOpts) (api.Connection, error) {
+		return mockedAPIState(noFlags), nil
+	}
+	_, err := juju.NewAPIConnection(juju.NewAPIConnectionParams{
+		Store:          store,
+		ControllerName: "controllername",
+		OpenAPI:        apiOpen,
+		SRVResolver:    resolver,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *NewAPIClientSuite) TestWithRedirect(c *gc.C) {
 	store := newClientStore(c, "ctl")
 	err := store.UpdateController("ctl", jujuclient.ControllerDetails{
@@ -213,6 +291,38 @@ func (s *NewAPIClientSuite) TestWithRedirect(c *gc.C) {
 	c.Assert(controllerBefore, gc.DeepEquals, controllerAfter)
 }
 
+func (s *NewAPIClientSuite) TestWithRedirectSameController(c *gc.C) {
+	store := newClientStore(c, "ctl")
+	redirHPs := []string{"0.0.9.9:1234"}
+	openCount := 0
+	redirOpen := func(apiInfo *api.Info, opts api.DialOpts) (api.Connection, error) {
+		openCount++
+		c.Check(apiInfo.ModelTag.Id(), gc.Equals, fakeUUID)
+		c.Check(apiInfo.CACert, gc.Equals, "certificate")
+		c.Check(apiInfo.Tag, gc.Equals, names.NewUserTag("admin"))
+		c.Check(apiInfo.Password, gc.Equals, "hunter2")
+		switch openCount {
+		case 1:
+			c.Check(apiInfo.Addrs, jc.DeepEquals, []string{"0.1.2.3:5678"})
+			return nil, errors.Trace(&api.RedirectError{
+				Servers: [][]network.HostPort{mustParseHostPorts(redirHPs)},
+				CACert:  "certificate",
+			})
+		case 2:
+			c.Check(apiInfo.Addrs, jc.DeepEquals, redirHPs)
+			st := mockedAPIState(noFlags)
+			st.modelTag = fakeUUID
+			return st, nil
+		}
+		c.Errorf("OpenAPI called too many times")
+		return nil, fmt.Errorf("OpenAPI called too many times")
+	}
+
+	_, err := newAPIConnectionFromNames(c, "ctl", "admin/admin", store, redirOpen)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(openCount, gc.Equals, 2)
+}
+
 func (s *NewAPIClientSuite) TestWithInfoAPIOpenError(c *gc.C) {
 	jujuClient := newClientStore(c, "noconfig")
 
@@ -561,3 +671,9 @@ type ipAddrResolverFunc func(ctx context.Context, host string) ([]net.IPAddr, er
 func (f ipAddrResolverFunc) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
 	return f(ctx, host)
 }
+
+type srvResolverFunc func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+
+func (f srvResolverFunc) LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+	return f(ctx, service, proto, name)
+}
@@ -0,0 +1,266 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package juju
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/api"
+)
+
+// DefaultAPIConnectionIdleTimeout is how long the process-level API
+// connection cache keeps a connection open once nothing is using it.
+const DefaultAPIConnectionIdleTimeout = time.Minute
+
+// sharedAPIConnections is the process-level API connection cache used
+// by SharedAPIConnection.
+var sharedAPIConnections = NewAPIConnectionCache(clock.WallClock, DefaultAPIConnectionIdleTimeout)
+
+// SharedAPIConnection returns an api.Connection as NewAPIConnection
+// does, except that the connection is shared through a process-level
+// cache with anyone else in the process connecting to the same
+// controller and model as the same user. Closing the returned
+// connection releases it; the underlying connection is closed once it
+// has been idle for DefaultAPIConnectionIdleTimeout.
+func SharedAPIConnection(args NewAPIConnectionParams) (api.Connection, error) {
+	return sharedAPIConnections.Open(args)
+}
+
+// CloseSharedAPIConnections closes the idle connections shared by
+// SharedAPIConnection, and stops any connection already made from
+// being shared with later callers. Connections that are still in use
+// are closed when they are released.
+func CloseSharedAPIConnections() error {
+	return sharedAPIConnections.Close()
+}
+
+// APIConnectionCache shares API connections between the users of the
+// same controller, model and account, so that they do not each have
+// to connect and log in. Connections are reference counted, and closed
+// once they have not been used for the cache's idle timeout.
+type APIConnectionCache struct {
+	clock       clock.Clock
+	idleTimeout time.Duration
+
+	mu    sync.Mutex
+	conns map[apiConnectionKey]*cachedAPIConnection
+}
+
+// apiConnectionKey identifies the connections that may be shared.
+type apiConnectionKey struct {
+	controller string
+	model      string
+	user       string
+	login      bool
+}
+
+// cachedAPIConnection holds a connection in the cache.
+type cachedAPIConnection struct {
+	// ready is closed once conn and err are set.
+	ready chan struct{}
+	conn  api.Connection
+	err   error
+
+	// refs records the number of users of the connection.
+	refs int
+
+	// idle is incremented each time the connection becomes idle, so
+	// that an idle timer can tell whether it is still current.
+	idle      int
+	idleTimer clock.Timer
+}
+
+// NewAPIConnectionCache returns a new, empty, APIConnectionCache that
+// closes connections once they have been idle for the given time.
+func NewAPIConnectionCache(clock clock.Clock, idleTimeout time.Duration) *APIConnectionCache {
+	return &APIConnectionCache{
+		clock:       clock,
+		idleTimeout: idleTimeout,
+		conns:       make(map[apiConnectionKey]*cachedAPIConnection),
+	}
+}
+
+// Open returns a connection, as NewAPIConnection would, reusing any
+// connection in the cache that is to the same controller and model for
+// the same user. Concurrent calls for a connection that is not yet in
+// the cache make only one connection attempt between them. Closing the
+// returned connection releases it back to the cache.
+func (c *APIConnectionCache) Open(args NewAPIConnectionParams) (api.Connection, error) {
+	key := apiConnectionKey{
+		controller: args.ControllerName,
+		model:      args.ModelUUID,
+		login:      args.AccountDetails != nil,
+	}
+	if args.AccountDetails != nil {
+		key.user = args.AccountDetails.User
+	}
+	for {
+		c.mu.Lock()
+		entry, ok := c.conns[key]
+		if !ok {
+			entry = &cachedAPIConnection{ready: make(chan struct{})}
+			c.conns[key] = entry
+			c.mu.Unlock()
+
+			conn, err := NewAPIConnection(args)
+			c.mu.Lock()
+			entry.conn, entry.err = conn, err
+			if err != nil {
+				delete(c.conns, key)
+			}
+			close(entry.ready)
+			c.mu.Unlock()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			continue
+		}
+		c.mu.Unlock()
+
+		<-entry.ready
+		c.mu.Lock()
+		if entry.err != nil {
+			c.mu.Unlock()
+			return nil, errors.Trace(entry.err)
+		}
+		if c.conns[key] != entry {
+			// The connection was removed while we waited for it.
+			c.mu.Unlock()
+			continue
+		}
+		if isBroken(entry.conn) {
+			logger.Debugf("discarding broken API connection to %q", args.ControllerName)
+			delete(c.conns, key)
+			c.stopIdleTimer(entry)
+			inUse := entry.refs > 0
+			c.mu.Unlock()
+			if !inUse {
+				entry.conn.Close()
+			}
+			continue
+		}
+		entry.refs++
+		c.stopIdleTimer(entry)
+		c.mu.Unlock()
+		return &sharedConnection{
+			Connection: entry.conn,
+			release: func() error {
+				return c.release(key, entry)
+			},
+		}, nil
+	}
+}
+
+// release records that a user of the connection has finished with it,
+// starting its idle timer if it has no other users.
+func (c *APIConnectionCache) release(key apiConnectionKey, entry *cachedAPIConnection) error {
+	c.mu.Lock()
+	entry.refs--
+	if entry.refs > 0 {
+		c.mu.Unlock()
+		return nil
+	}
+	if c.conns[key] != entry {
+		// The connection has already been removed from the cache,
+		// so there is nothing to keep it open for.
+		c.mu.Unlock()
+		return errors.Trace(entry.conn.Close())
+	}
+	defer c.mu.Unlock()
+	entry.idle++
+	idle := entry.idle
+	entry.idleTimer = c.clock.AfterFunc(c.idleTimeout, func() {
+		c.closeIdle(key, entry, idle)
+	})
+	return nil
+}
+
+// closeIdle removes the connection from the cache and closes it, if it
+// is still in the cache and has been idle since the given idle count.
+func (c *APIConnectionCache) closeIdle(key apiConnectionKey, entry *cachedAPIConnection, idle int) {
+	c.mu.Lock()
+	if entry.refs > 0 || entry.idle != idle || c.conns[key] != entry {
+		c.mu.Unlock()
+		return
+	}
+	delete(c.conns, key)
+	entry.idleTimer = nil
+	c.mu.Unlock()
+	if err := entry.conn.Close(); err != nil {
+		logger.Debugf("closing idle API connection: %v", err)
+	}
+}
+
+// stopIdleTimer stops the connection's idle timer, if it has one. It
+// must be called with c.mu held.
+func (c *APIConnectionCache) stopIdleTimer(entry *cachedAPIConnection) {
+	if entry.idleTimer != nil {
+		entry.idleTimer.Stop()
+		entry.idleTimer = nil
+	}
+}
+
+// Close closes all the connections in the cache that are not in use,
+// and removes all connections from the cache. Connections that are in
+// use are closed when they are released.
+func (c *APIConnectionCache) Close() error {
+	c.mu.Lock()
+	var idle []api.Connection
+	for key, entry := range c.conns {
+		select {
+		case <-entry.ready:
+		default:
+			// Leave connections that are still being made.
+			continue
+		}
+		delete(c.conns, key)
+		if entry.err == nil && entry.refs == 0 {
+			c.stopIdleTimer(entry)
+			idle = append(idle, entry.conn)
+		}
+	}
+	c.mu.Unlock()
+
+	var lastErr error
+	for _, conn := range idle {
+		if err := conn.Close(); err != nil {
+			lastErr = err
+		}
+	}
+	return errors.Trace(lastErr)
+}
+
+// isBroken reports whether the connection is known to be broken,
+// without pinging the API server.
+func isBroken(conn api.Connection) bool {
+	select {
+	case <-conn.Broken():
+		return true
+	default:
+		return false
+	}
+}
+
+// sharedConnection is an api.Connection obtained from an
+// APIConnectionCache. Closing it releases the underlying connection
+// back to the cache.
+type sharedConnection struct {
+	api.Connection
+
+	once    sync.Once
+	release func() error
+}
+
+// Close is part of the api.Connection interface.
+func (c *sharedConnection) Close() error {
+	var err error
+	c.once.Do(func() {
+		err = c.release()
+	})
+	return err
+}
@@ -0,0 +1,173 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package juju_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/juju"
+	"github.com/juju/juju/jujuclient"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type apiConnectionCacheSuite struct {
+	coretesting.BaseSuite
+
+	clock  *testing.Clock
+	cache  *juju.APIConnectionCache
+	store  *jujuclient.MemStore
+	opened []*mockAPIState
+	closed chan *mockAPIState
+}
+
+var _ = gc.Suite(&apiConnectionCacheSuite{})
+
+func (s *apiConnectionCacheSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Time{})
+	s.cache = juju.NewAPIConnectionCache(s.clock, time.Minute)
+	s.store = newClientStore(c, "foo")
+	s.opened = nil
+	s.closed = make(chan *mockAPIState, 10)
+}
+
+func (s *apiConnectionCacheSuite) openAPI(apiInfo *api.Info, opts api.DialOpts) (api.Connection, error) {
+	conn := mockedAPIState(mockedHostPort | mockedModelTag)
+	conn.close = func(api.Connection) error {
+		s.closed <- conn
+		return nil
+	}
+	s.opened = append(s.opened, conn)
+	return conn, nil
+}
+
+func (s *apiConnectionCacheSuite) params(user string) juju.NewAPIConnectionParams {
+	return juju.NewAPIConnectionParams{
+		ControllerName: "foo",
+		Store:          s.store,
+		OpenAPI:        s.openAPI,
+		DialOpts:       api.DefaultDialOpts(),
+		AccountDetails: &jujuclient.AccountDetails{User: user, Password: "hunter2"},
+		ModelUUID:      fakeUUID,
+	}
+}
+
+func (s *apiConnectionCacheSuite) open(c *gc.C, user string) api.Connection {
+	conn, err := s.cache.Open(s.params(user))
+	c.Assert(err, jc.ErrorIsNil)
+	return conn
+}
+
+func (s *apiConnectionCacheSuite) assertNotClosed(c *gc.C) {
+	select {
+	case conn := <-s.closed:
+		c.Fatalf("connection %p closed unexpectedly", conn)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *apiConnectionCacheSuite) assertClosed(c *gc.C, expect *mockAPIState) {
+	select {
+	case conn := <-s.closed:
+		c.Assert(conn, gc.Equals, expect)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for connection to close")
+	}
+}
+
+func (s *apiConnectionCacheSuite) TestOpenShares(c *gc.C) {
+	conn0 := s.open(c, "admin")
+	conn1 := s.open(c, "admin")
+	c.Assert(s.opened, gc.HasLen, 1)
+	c.Assert(conn0.Addr(), gc.Equals, s.opened[0].Addr())
+
+	c.Assert(conn0.Close(), jc.ErrorIsNil)
+	c.Assert(conn1.Close(), jc.ErrorIsNil)
+	s.assertNotClosed(c)
+
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertClosed(c, s.opened[0])
+}
+
+func (s *apiConnectionCacheSuite) TestCloseReleasesOnce(c *gc.C) {
+	conn0 := s.open(c, "admin")
+	conn1 := s.open(c, "admin")
+	c.Assert(conn0.Close(), jc.ErrorIsNil)
+	c.Assert(conn0.Close(), jc.ErrorIsNil)
+
+	// conn1 is still in use, so there is no idle timer.
+	s.clock.Advance(time.Hour)
+	s.assertNotClosed(c)
+	c.Assert(conn1.Close(), jc.ErrorIsNil)
+}
+
+func (s *apiConnectionCacheSuite) TestDifferentUsersNotShared(c *gc.C) {
+	s.open(c, "admin")
+	s.open(c, "bob")
+	c.Assert(s.opened, gc.HasLen, 2)
+}
+
+func (s *apiConnectionCacheSuite) TestReuseWhileIdle(c *gc.C) {
+	conn := s.open(c, "admin")
+	c.Assert(conn.Close(), jc.ErrorIsNil)
+	err := s.clock.WaitAdvance(30*time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	conn = s.open(c, "admin")
+	c.Assert(s.opened, gc.HasLen, 1)
+	s.clock.Advance(time.Hour)
+	s.assertNotClosed(c)
+
+	// The idle timeout starts again when the connection is released.
+	c.Assert(conn.Close(), jc.ErrorIsNil)
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertClosed(c, s.opened[0])
+}
+
+func (s *apiConnectionCacheSuite) TestBrokenConnectionReplaced(c *gc.C) {
+	conn := s.open(c, "admin")
+	s.opened[0].broken = make(chan struct{})
+	close(s.opened[0].broken)
+
+	s.open(c, "admin")
+	c.Assert(s.opened, gc.HasLen, 2)
+	s.assertNotClosed(c)
+
+	// Once released, the broken connection is closed immediately.
+	c.Assert(conn.Close(), jc.ErrorIsNil)
+	s.assertClosed(c, s.opened[0])
+}
+
+func (s *apiConnectionCacheSuite) TestOpenErrorNotCached(c *gc.C) {
+	params := s.params("admin")
+	params.OpenAPI = func(*api.Info, api.DialOpts) (api.Connection, error) {
+		return nil, errors.New("boom")
+	}
+	_, err := s.cache.Open(params)
+	c.Assert(err, gc.ErrorMatches, "boom")
+
+	s.open(c, "admin")
+	c.Assert(s.opened, gc.HasLen, 1)
+}
+
+func (s *apiConnectionCacheSuite) TestClose(c *gc.C) {
+	idle := s.open(c, "admin")
+	inUse := s.open(c, "bob")
+	c.Assert(idle.Close(), jc.ErrorIsNil)
+
+	c.Assert(s.cache.Close(), jc.ErrorIsNil)
+	s.assertClosed(c, s.opened[0])
+	s.assertNotClosed(c)
+
+	c.Assert(inUse.Close(), jc.ErrorIsNil)
+	s.assertClosed(c, s.opened[1])
+}
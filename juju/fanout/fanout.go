@@ -0,0 +1,228 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package fanout runs client operations against several controllers or
+// models concurrently, collecting their results tagged with the
+// controller and model each came from.
+package fanout
+
+import (
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/jujuclient"
+)
+
+// DefaultParallelism is the number of targets operated on concurrently
+// when no other limit is given.
+const DefaultParallelism = 8
+
+// Target identifies a controller, or a model on a controller, on which
+// an operation is run.
+type Target struct {
+	// Controller holds the name of the controller in the client store.
+	Controller string
+
+	// Model holds the qualified name of the model, such as
+	// "admin/default", or is empty if the target is the controller
+	// itself.
+	Model string
+}
+
+// String returns the target in the form accepted by the model patterns
+// of ModelTargets: the controller name, followed by a colon and the
+// model name if there is one.
+func (t Target) String() string {
+	if t.Model == "" {
+		return t.Controller
+	}
+	return t.Controller + ":" + t.Model
+}
+
+// Result holds the outcome of running an operation on a target.
+type Result struct {
+	Target Target
+	Value  interface{}
+	Err    error
+}
+
+// ControllerTargets returns a target for each controller in the store
+// whose name matches one of the patterns, which are in the syntax of
+// path.Match. The targets are sorted by name. An error satisfying
+// errors.IsNotFound is returned if no controller matches a pattern.
+func ControllerTargets(store jujuclient.ControllerGetter, patterns []string) ([]Target, error) {
+	controllers, err := controllerNames(store)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	seen := make(map[Target]bool)
+	var targets []Target
+	for _, pattern := range patterns {
+		matched, err := matchNames(pattern, controllers)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(matched) == 0 {
+			return nil, errors.NotFoundf("controllers matching %q", pattern)
+		}
+		for _, name := range matched {
+			targets = addTarget(targets, seen, Target{Controller: name})
+		}
+	}
+	return targets, nil
+}
+
+// Store is the part of the client store used by ModelTargets.
+type Store interface {
+	jujuclient.ControllerGetter
+	jujuclient.ModelGetter
+}
+
+// ModelTargets returns a target for each model known to the store that
+// matches one of the patterns. A pattern has the form
+// "controller:model", where both parts are in the syntax of path.Match;
+// a pattern without a model part matches all models of the matching
+// controllers. If the model part is not qualified by an owner, it is
+// matched against the models' names regardless of their owners.
+//
+// Only the models cached in the store are considered; the cache is
+// updated by commands such as "juju models". The targets are sorted by
+// controller and model name. An error satisfying errors.IsNotFound is
+// returned if no model matches a pattern.
+func ModelTargets(store Store, patterns []string) ([]Target, error) {
+	controllers, err := controllerNames(store)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	seen := make(map[Target]bool)
+	var targets []Target
+	for _, pattern := range patterns {
+		controllerPattern, modelPattern := pattern, "*"
+		if i := strings.Index(pattern, ":"); i >= 0 {
+			controllerPattern, modelPattern = pattern[:i], pattern[i+1:]
+		}
+		matchedControllers, err := matchNames(controllerPattern, controllers)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var matched []Target
+		for _, controller := range matchedControllers {
+			models, err := modelNames(store, controller)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			for _, model := range models {
+				ok, err := matchModel(modelPattern, model)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				if ok {
+					matched = append(matched, Target{Controller: controller, Model: model})
+				}
+			}
+		}
+		if len(matched) == 0 {
+			return nil, errors.NotFoundf("models matching %q", pattern)
+		}
+		for _, target := range matched {
+			targets = addTarget(targets, seen, target)
+		}
+	}
+	return targets, nil
+}
+
+// Run calls the supplied function for each of the targets, running at
+// most parallelism calls at once, and returns the results in the order
+// of the targets. If parallelism is not positive, DefaultParallelism is
+// used.
+func Run(targets []Target, parallelism int, call func(Target) (interface{}, error)) []Result {
+	if parallelism <= 0 {
+		parallelism = DefaultParallelism
+	}
+	results := make([]Result, len(targets))
+	sem := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, target Target) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			value, err := call(target)
+			results[i] = Result{Target: target, Value: value, Err: err}
+		}(i, target)
+	}
+	wg.Wait()
+	return results
+}
+
+func addTarget(targets []Target, seen map[Target]bool, target Target) []Target {
+	if seen[target] {
+		return targets
+	}
+	seen[target] = true
+	return append(targets, target)
+}
+
+func controllerNames(store jujuclient.ControllerGetter) ([]string, error) {
+	controllers, err := store.AllControllers()
+	if err != nil {
+		return nil, errors.Annotate(err, "listing controllers")
+	}
+	names := make([]string, 0, len(controllers))
+	for name := range controllers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func modelNames(store jujuclient.ModelGetter, controller string) ([]string, error) {
+	models, err := store.AllModels(controller)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "listing models of controller %q", controller)
+	}
+	names := make([]string, 0, len(models))
+	for name := range models {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// matchNames returns the names that match the pattern.
+func matchNames(pattern string, names []string) ([]string, error) {
+	var matched []string
+	for _, name := range names {
+		ok, err := path.Match(pattern, name)
+		if err != nil {
+			return nil, errors.NotValidf("pattern %q", pattern)
+		}
+		if ok {
+			matched = append(matched, name)
+		}
+	}
+	return matched, nil
+}
+
+// matchModel reports whether the qualified model name matches the
+// pattern, ignoring the owner if the pattern is not qualified.
+func matchModel(pattern, model string) (bool, error) {
+	name := model
+	if !strings.Contains(pattern, "/") {
+		if i := strings.Index(model, "/"); i >= 0 {
+			name = model[i+1:]
+		}
+	}
+	ok, err := path.Match(pattern, name)
+	if err != nil {
+		return false, errors.NotValidf("pattern %q", pattern)
+	}
+	return ok, nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package fanout_test
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/juju/fanout"
+	"github.com/juju/juju/jujuclient"
+)
+
+type FanoutSuite struct {
+	testing.IsolationSuite
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&FanoutSuite{})
+
+func (s *FanoutSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.store = jujuclient.NewMemStore()
+	s.store.Controllers["prod-east"] = jujuclient.ControllerDetails{}
+	s.store.Controllers["prod-west"] = jujuclient.ControllerDetails{}
+	s.store.Controllers["staging"] = jujuclient.ControllerDetails{}
+	s.store.Models["prod-east"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/controller": {ModelUUID: "uuid-1"},
+			"admin/web":        {ModelUUID: "uuid-2"},
+			"bob/web":          {ModelUUID: "uuid-3"},
+		},
+	}
+	s.store.Models["prod-west"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/web": {ModelUUID: "uuid-4"},
+		},
+	}
+}
+
+func (s *FanoutSuite) TestTargetString(c *gc.C) {
+	c.Check(fanout.Target{Controller: "prod"}.String(), gc.Equals, "prod")
+	c.Check(fanout.Target{Controller: "prod", Model: "admin/web"}.String(), gc.Equals, "prod:admin/web")
+}
+
+func (s *FanoutSuite) TestControllerTargets(c *gc.C) {
+	targets, err := fanout.ControllerTargets(s.store, []string{"prod-*", "prod-east", "staging"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(targets, jc.DeepEquals, []fanout.Target{
+		{Controller: "prod-east"},
+		{Controller: "prod-west"},
+		{Controller: "staging"},
+	})
+}
+
+func (s *FanoutSuite) TestControllerTargetsNoMatch(c *gc.C) {
+	_, err := fanout.ControllerTargets(s.store, []string{"dev-*"})
+	c.Assert(err, gc.ErrorMatches, `controllers matching "dev-\*" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *FanoutSuite) TestControllerTargetsBadPattern(c *gc.C) {
+	_, err := fanout.ControllerTargets(s.store, []string{"prod-["})
+	c.Assert(err, gc.ErrorMatches, `pattern "prod-\[" not valid`)
+}
+
+func (s *FanoutSuite) TestModelTargets(c *gc.C) {
+	targets, err := fanout.ModelTargets(s.store, []string{"prod-*:web"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(targets, jc.DeepEquals, []fanout.Target{
+		{Controller: "prod-east", Model: "admin/web"},
+		{Controller: "prod-east", Model: "bob/web"},
+		{Controller: "prod-west", Model: "admin/web"},
+	})
+}
+
+func (s *FanoutSuite) TestModelTargetsQualified(c *gc.C) {
+	targets, err := fanout.ModelTargets(s.store, []string{"*:bob/*", "prod-west"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(targets, jc.DeepEquals, []fanout.Target{
+		{Controller: "prod-east", Model: "bob/web"},
+		{Controller: "prod-west", Model: "admin/web"},
+	})
+}
+
+func (s *FanoutSuite) TestModelTargetsNoMatch(c *gc.C) {
+	// The staging controller has no cached models.
+	_, err := fanout.ModelTargets(s.store, []string{"staging"})
+	c.Assert(err, gc.ErrorMatches, `models matching "staging" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *FanoutSuite) TestRun(c *gc.C) {
+	targets := []fanout.Target{
+		{Controller: "a", Model: "admin/one"},
+		{Controller: "b", Model: "admin/two"},
+		{Controller: "c", Model: "admin/three"},
+	}
+	var (
+		mu      sync.Mutex
+		running int
+		maxRun  int
+	)
+	results := fanout.Run(targets, 2, func(target fanout.Target) (interface{}, error) {
+		mu.Lock()
+		running++
+		if running > maxRun {
+			maxRun = running
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			running--
+			mu.Unlock()
+		}()
+		if target.Controller == "b" {
+			return nil, errors.New("unreachable")
+		}
+		return target.Model, nil
+	})
+	c.Assert(results, gc.HasLen, 3)
+	c.Check(results[0], jc.DeepEquals, fanout.Result{Target: targets[0], Value: "admin/one"})
+	c.Check(results[1].Target, jc.DeepEquals, targets[1])
+	c.Check(results[1].Err, gc.ErrorMatches, "unreachable")
+	c.Check(results[2], jc.DeepEquals, fanout.Result{Target: targets[2], Value: "admin/three"})
+	c.Check(maxRun <= 2, jc.IsTrue)
+}
@@ -26,6 +26,9 @@ type mockAPIState struct {
 	modelTag      string
 	controllerTag string
 	publicDNSName string
+
+	// broken, if non-nil, is returned by the Broken method.
+	broken chan struct{}
 }
 
 type mockedStateFlags int
@@ -75,6 +78,10 @@ func (s *mockAPIState) Close() error {
 	return nil
 }
 
+func (s *mockAPIState) Broken() <-chan struct{} {
+	return s.broken
+}
+
 func (s *mockAPIState) ServerVersion() (version.Number, bool) {
 	return version.MustParse("1.2.3"), true
 }
@@ -0,0 +1,280 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package juju
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/juju/errors"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// AllWatcher reports changes to the entities of a model, as
+// api.AllWatcher does. The first call to Next reports every entity.
+type AllWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+// StatusPoller keeps a local snapshot of a model's machines,
+// applications, units and relations up to date from an AllWatcher, so
+// that programs embedding juju can read the model's status from memory
+// instead of requesting its full status from the controller each time.
+//
+// The values returned by the accessors are shared with the poller and
+// must not be modified.
+type StatusPoller struct {
+	tomb    tomb.Tomb
+	watcher AllWatcher
+
+	// ready is closed once the first changes have been applied.
+	ready chan struct{}
+
+	mu           sync.RWMutex
+	generation   int64
+	model        *multiwatcher.ModelInfo
+	machines     map[string]*multiwatcher.MachineInfo
+	applications map[string]*multiwatcher.ApplicationInfo
+	units        map[string]*multiwatcher.UnitInfo
+	relations    map[string]*multiwatcher.RelationInfo
+}
+
+// NewStatusPoller returns a StatusPoller for the model the given API
+// connection is logged into. The poller must be stopped with Kill
+// before the connection is closed.
+func NewStatusPoller(conn api.Connection) (*StatusPoller, error) {
+	w, err := conn.Client().WatchAll()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot watch model")
+	}
+	return NewStatusPollerWithWatcher(w), nil
+}
+
+// NewStatusPollerWithWatcher returns a StatusPoller that applies the
+// changes reported by the given watcher. The poller stops the watcher
+// when it is killed.
+func NewStatusPollerWithWatcher(w AllWatcher) *StatusPoller {
+	p := &StatusPoller{
+		watcher:      w,
+		ready:        make(chan struct{}),
+		machines:     make(map[string]*multiwatcher.MachineInfo),
+		applications: make(map[string]*multiwatcher.ApplicationInfo),
+		units:        make(map[string]*multiwatcher.UnitInfo),
+		relations:    make(map[string]*multiwatcher.RelationInfo),
+	}
+	go func() {
+		defer p.tomb.Done()
+		p.tomb.Kill(p.loop())
+	}()
+	go func() {
+		// Next blocks until there are changes, so the watcher must
+		// be stopped for the loop to notice that it is dying.
+		<-p.tomb.Dying()
+		if err := w.Stop(); err != nil {
+			logger.Debugf("stopping status poller watcher: %v", err)
+		}
+	}()
+	return p
+}
+
+func (p *StatusPoller) loop() error {
+	for {
+		deltas, err := p.watcher.Next()
+		select {
+		case <-p.tomb.Dying():
+			return tomb.ErrDying
+		default:
+		}
+		if err != nil {
+			return errors.Annotate(err, "cannot get model changes")
+		}
+		p.apply(deltas)
+	}
+}
+
+// apply updates the snapshot with the given changes.
+func (p *StatusPoller) apply(deltas []multiwatcher.Delta) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, delta := range deltas {
+		switch info := delta.Entity.(type) {
+		case *multiwatcher.ModelInfo:
+			if delta.Removed {
+				p.model = nil
+			} else {
+				p.model = info
+			}
+		case *multiwatcher.MachineInfo:
+			if delta.Removed {
+				delete(p.machines, info.Id)
+			} else {
+				p.machines[info.Id] = info
+			}
+		case *multiwatcher.ApplicationInfo:
+			if delta.Removed {
+				delete(p.applications, info.Name)
+			} else {
+				p.applications[info.Name] = info
+			}
+		case *multiwatcher.UnitInfo:
+			if delta.Removed {
+				delete(p.units, info.Name)
+			} else {
+				p.units[info.Name] = info
+			}
+		case *multiwatcher.RelationInfo:
+			if delta.Removed {
+				delete(p.relations, info.Key)
+			} else {
+				p.relations[info.Key] = info
+			}
+		}
+	}
+	p.generation++
+	if p.generation == 1 {
+		close(p.ready)
+	}
+}
+
+// Ready returns a channel that is closed once the poller holds a
+// complete snapshot of the model. If the poller stops before then,
+// the channel is never closed; callers waiting on it should also wait
+// on Dead.
+func (p *StatusPoller) Ready() <-chan struct{} {
+	return p.ready
+}
+
+// Dead returns a channel that is closed once the poller has stopped,
+// after which the snapshot is no longer updated.
+func (p *StatusPoller) Dead() <-chan struct{} {
+	return p.tomb.Dead()
+}
+
+// Generation returns the number of times the snapshot has been
+// updated. Callers can compare generations to tell whether anything
+// has changed since they last read the snapshot.
+func (p *StatusPoller) Generation() int64 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.generation
+}
+
+// Model returns the model's details, or nil if they are not yet known.
+func (p *StatusPoller) Model() *multiwatcher.ModelInfo {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.model
+}
+
+// Machine returns the machine with the given id. An error satisfying
+// errors.IsNotFound is returned if the model has no such machine.
+func (p *StatusPoller) Machine(id string) (*multiwatcher.MachineInfo, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if info, ok := p.machines[id]; ok {
+		return info, nil
+	}
+	return nil, errors.NotFoundf("machine %q", id)
+}
+
+// Application returns the named application. An error satisfying
+// errors.IsNotFound is returned if the model has no such application.
+func (p *StatusPoller) Application(name string) (*multiwatcher.ApplicationInfo, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if info, ok := p.applications[name]; ok {
+		return info, nil
+	}
+	return nil, errors.NotFoundf("application %q", name)
+}
+
+// Unit returns the named unit. An error satisfying errors.IsNotFound
+// is returned if the model has no such unit.
+func (p *StatusPoller) Unit(name string) (*multiwatcher.UnitInfo, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if info, ok := p.units[name]; ok {
+		return info, nil
+	}
+	return nil, errors.NotFoundf("unit %q", name)
+}
+
+// Relation returns the relation with the given key. An error
+// satisfying errors.IsNotFound is returned if the model has no such
+// relation.
+func (p *StatusPoller) Relation(key string) (*multiwatcher.RelationInfo, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if info, ok := p.relations[key]; ok {
+		return info, nil
+	}
+	return nil, errors.NotFoundf("relation %q", key)
+}
+
+// Machines returns the model's machines, sorted by id.
+func (p *StatusPoller) Machines() []*multiwatcher.MachineInfo {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	result := make([]*multiwatcher.MachineInfo, 0, len(p.machines))
+	for _, info := range p.machines {
+		result = append(result, info)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Id < result[j].Id })
+	return result
+}
+
+// Applications returns the model's applications, sorted by name.
+func (p *StatusPoller) Applications() []*multiwatcher.ApplicationInfo {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	result := make([]*multiwatcher.ApplicationInfo, 0, len(p.applications))
+	for _, info := range p.applications {
+		result = append(result, info)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// Units returns the units of the named application, or of every
+// application if the name is empty, sorted by name.
+func (p *StatusPoller) Units(application string) []*multiwatcher.UnitInfo {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	var result []*multiwatcher.UnitInfo
+	for _, info := range p.units {
+		if application == "" || info.Application == application {
+			result = append(result, info)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// Relations returns the model's relations, sorted by key.
+func (p *StatusPoller) Relations() []*multiwatcher.RelationInfo {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	result := make([]*multiwatcher.RelationInfo, 0, len(p.relations))
+	for _, info := range p.relations {
+		result = append(result, info)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Key < result[j].Key })
+	return result
+}
+
+// Kill is part of the worker.Worker interface. It stops the poller;
+// the snapshot remains readable.
+func (p *StatusPoller) Kill() {
+	p.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface. It returns the error
+// that stopped the poller, if any.
+func (p *StatusPoller) Wait() error {
+	return p.tomb.Wait()
+}
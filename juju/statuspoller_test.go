@@ -0,0 +1,156 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package juju_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/juju"
+	"github.com/juju/juju/state/multiwatcher"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type statusPollerSuite struct {
+	coretesting.BaseSuite
+	watcher *fakeAllWatcher
+}
+
+var _ = gc.Suite(&statusPollerSuite{})
+
+func (s *statusPollerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.watcher = newFakeAllWatcher()
+}
+
+// sendAndWait sends the deltas to the poller, and waits for it to
+// apply them.
+func (s *statusPollerSuite) sendAndWait(c *gc.C, p *juju.StatusPoller, deltas ...multiwatcher.Delta) {
+	generation := p.Generation()
+	select {
+	case s.watcher.deltas <- deltas:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("poller did not ask for changes")
+	}
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if p.Generation() > generation {
+			return
+		}
+	}
+	c.Fatalf("poller did not apply changes")
+}
+
+func (s *statusPollerSuite) TestSnapshot(c *gc.C) {
+	p := juju.NewStatusPollerWithWatcher(s.watcher)
+	defer func() {
+		p.Kill()
+		c.Check(p.Wait(), jc.ErrorIsNil)
+	}()
+
+	s.sendAndWait(c, p,
+		multiwatcher.Delta{Entity: &multiwatcher.ModelInfo{ModelUUID: "uuid", Name: "web"}},
+		multiwatcher.Delta{Entity: &multiwatcher.MachineInfo{Id: "1"}},
+		multiwatcher.Delta{Entity: &multiwatcher.MachineInfo{Id: "0"}},
+		multiwatcher.Delta{Entity: &multiwatcher.ApplicationInfo{Name: "mysql"}},
+		multiwatcher.Delta{Entity: &multiwatcher.UnitInfo{Name: "mysql/0", Application: "mysql"}},
+		multiwatcher.Delta{Entity: &multiwatcher.UnitInfo{Name: "wordpress/0", Application: "wordpress"}},
+		multiwatcher.Delta{Entity: &multiwatcher.RelationInfo{Key: "wordpress:db mysql:server"}},
+	)
+	select {
+	case <-p.Ready():
+	default:
+		c.Fatalf("poller not ready")
+	}
+	c.Assert(p.Model().Name, gc.Equals, "web")
+	machines := p.Machines()
+	c.Assert(machines, gc.HasLen, 2)
+	c.Assert(machines[0].Id, gc.Equals, "0")
+	c.Assert(machines[1].Id, gc.Equals, "1")
+	units := p.Units("mysql")
+	c.Assert(units, gc.HasLen, 1)
+	c.Assert(units[0].Name, gc.Equals, "mysql/0")
+	c.Assert(p.Units(""), gc.HasLen, 2)
+	c.Assert(p.Applications(), gc.HasLen, 1)
+	c.Assert(p.Relations(), gc.HasLen, 1)
+
+	// Later changes update the snapshot incrementally.
+	s.sendAndWait(c, p,
+		multiwatcher.Delta{Entity: &multiwatcher.UnitInfo{
+			Name:        "mysql/0",
+			Application: "mysql",
+			MachineId:   "0",
+		}},
+		multiwatcher.Delta{Removed: true, Entity: &multiwatcher.MachineInfo{Id: "1"}},
+	)
+	unit, err := p.Unit("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit.MachineId, gc.Equals, "0")
+	_, err = p.Machine("1")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `machine "1" not found`)
+	_, err = p.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.Relation("wordpress:db mysql:server")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *statusPollerSuite) TestKillStopsWatcher(c *gc.C) {
+	p := juju.NewStatusPollerWithWatcher(s.watcher)
+	p.Kill()
+	c.Assert(p.Wait(), jc.ErrorIsNil)
+	select {
+	case <-s.watcher.stopped:
+	default:
+		c.Fatalf("watcher not stopped")
+	}
+	select {
+	case <-p.Dead():
+	default:
+		c.Fatalf("poller not dead")
+	}
+}
+
+func (s *statusPollerSuite) TestWatcherError(c *gc.C) {
+	p := juju.NewStatusPollerWithWatcher(s.watcher)
+	s.watcher.errs <- errors.New("connection is shut down")
+	c.Assert(p.Wait(), gc.ErrorMatches, "cannot get model changes: connection is shut down")
+	select {
+	case <-p.Ready():
+		c.Fatalf("poller ready without a snapshot")
+	default:
+	}
+}
+
+type fakeAllWatcher struct {
+	deltas  chan []multiwatcher.Delta
+	errs    chan error
+	stopped chan struct{}
+}
+
+func newFakeAllWatcher() *fakeAllWatcher {
+	return &fakeAllWatcher{
+		deltas:  make(chan []multiwatcher.Delta),
+		errs:    make(chan error, 1),
+		stopped: make(chan struct{}),
+	}
+}
+
+func (w *fakeAllWatcher) Next() ([]multiwatcher.Delta, error) {
+	select {
+	case deltas := <-w.deltas:
+		return deltas, nil
+	case err := <-w.errs:
+		return nil, err
+	case <-w.stopped:
+		return nil, errors.New("watcher was stopped")
+	}
+}
+
+func (w *fakeAllWatcher) Stop() error {
+	close(w.stopped)
+	return nil
+}
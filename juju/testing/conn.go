@@ -40,6 +40,7 @@ import (
 	"github.com/juju/juju/environs/storage"
 	envtesting "github.com/juju/juju/environs/testing"
 	"github.com/juju/juju/environs/tools"
+	"github.com/juju/juju/juju"
 	"github.com/juju/juju/juju/keys"
 	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/jujuclient"
@@ -639,6 +640,11 @@ func (s *JujuConnSuite) tearDownConn(c *gc.C) {
 		}
 	}
 	s.apiStates = nil
+	// Connections shared by CLI commands may outlive the test's
+	// API server, so they mustn't be reused by later tests.
+	if err := juju.CloseSharedAPIConnections(); serverAlive {
+		c.Check(err, jc.ErrorIsNil)
+	}
 	if s.APIState != nil {
 		err := s.APIState.Close()
 		s.APIState = nil
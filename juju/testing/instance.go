@@ -4,6 +4,8 @@
 package testing
 
 import (
+	"context"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -72,7 +74,7 @@ func AssertStartControllerInstance(
 	params := environs.StartInstanceParams{ControllerUUID: controllerUUID}
 	err := fillinStartInstanceParams(env, machineId, true, &params)
 	c.Assert(err, jc.ErrorIsNil)
-	result, err := env.StartInstance(params)
+	result, err := env.StartInstance(context.Background(), params)
 	c.Assert(err, jc.ErrorIsNil)
 	return result.Instance, result.Hardware
 }
@@ -141,7 +143,7 @@ func StartInstanceWithParams(
 	if err := fillinStartInstanceParams(env, machineId, false, &params); err != nil {
 		return nil, err
 	}
-	return env.StartInstance(params)
+	return env.StartInstance(context.Background(), params)
 }
 
 func fillinStartInstanceParams(env environs.Environ, machineId string, isController bool, params *environs.StartInstanceParams) error {
@@ -47,6 +47,12 @@ controllers:
     cloud: maas
     type: maas
     region: 127.0.0.1
+    checkpoint:
+      phase: start-instance
+      instance-id: node-0
+      series: xenial
+      arch: amd64
+      agent-version: 2.2.0
 `
 
 var testBootstrapConfig = map[string]jujuclient.BootstrapConfig{
@@ -79,6 +85,13 @@ var testBootstrapConfig = map[string]jujuclient.BootstrapConfig{
 		Cloud:               "maas",
 		CloudType:           "maas",
 		CloudRegion:         "127.0.0.1",
+		Checkpoint: &jujuclient.BootstrapCheckpoint{
+			Phase:        "start-instance",
+			InstanceId:   "node-0",
+			Series:       "xenial",
+			Arch:         "amd64",
+			AgentVersion: "2.2.0",
+		},
 	},
 }
 
@@ -126,6 +126,36 @@ type BootstrapConfig struct {
 	// when communicating with the cloud's storage service. This will
 	// be empty for clouds that have no storage-specific API endpoint.
 	CloudStorageEndpoint string `yaml:"storage-endpoint,omitempty"`
+
+	// Checkpoint records the progress of an incomplete bootstrap,
+	// so that it may be resumed. It is nil once bootstrap completes.
+	Checkpoint *BootstrapCheckpoint `yaml:"checkpoint,omitempty"`
+}
+
+// BootstrapCheckpoint records the last bootstrap phase to have
+// completed for a controller, along with the choices made in earlier
+// phases that must be honoured when the bootstrap is resumed.
+type BootstrapCheckpoint struct {
+	// Phase is the name of the last bootstrap phase to complete.
+	Phase string `yaml:"phase"`
+
+	// InstanceId is the ID of the controller instance, once started.
+	InstanceId string `yaml:"instance-id,omitempty"`
+
+	// Series is the series of the controller instance.
+	Series string `yaml:"series,omitempty"`
+
+	// Arch is the architecture of the controller instance.
+	Arch string `yaml:"arch,omitempty"`
+
+	// AgentVersion is the version of the agent binaries selected
+	// for the controller instance.
+	AgentVersion string `yaml:"agent-version,omitempty"`
+
+	// SSHHostKey is the public part of the initial SSH host key
+	// injected into the controller instance. It is used to verify
+	// the instance's identity when the bootstrap is resumed.
+	SSHHostKey string `yaml:"ssh-host-key,omitempty"`
 }
 
 // ControllerUpdater stores controller details.
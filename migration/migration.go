@@ -5,9 +5,7 @@ package migration
 
 import (
 	"io"
-	"io/ioutil"
 	"net/url"
-	"os"
 
 	"github.com/juju/description"
 	"github.com/juju/errors"
@@ -69,6 +67,15 @@ type CharmDownloader interface {
 	OpenCharm(*charm.URL) (io.ReadCloser, error)
 }
 
+// CharmRangeDownloader is implemented by CharmDownloaders that can
+// open a charm part way through, so that an interrupted download can
+// be resumed without reading the charm again from the start. The
+// offset the returned stream starts from is zero if the source sent
+// the whole charm.
+type CharmRangeDownloader interface {
+	OpenCharmFrom(*charm.URL, int64) (io.ReadCloser, int64, error)
+}
+
 // CharmUploader defines a single method that is used to upload a
 // charm to the target controller in a migration.
 type CharmUploader interface {
@@ -81,6 +88,15 @@ type ToolsDownloader interface {
 	OpenURI(string, url.Values) (io.ReadCloser, error)
 }
 
+// ToolsRangeDownloader is implemented by ToolsDownloaders that can
+// open agent binaries part way through, so that an interrupted
+// download can be resumed without reading them again from the start.
+// The offset the returned stream starts from is zero if the source
+// sent the whole binary.
+type ToolsRangeDownloader interface {
+	OpenURIFrom(string, url.Values, int64) (io.ReadCloser, int64, error)
+}
+
 // ToolsUploader defines a single method that is used to upload tools
 // to the target controller in a migration.
 type ToolsUploader interface {
@@ -116,6 +132,23 @@ type UploadBinariesConfig struct {
 	Resources          []migration.SerializedModelResource
 	ResourceDownloader ResourceDownloader
 	ResourceUploader   ResourceUploader
+
+	// BandwidthLimit is the maximum rate, in bytes per second, at
+	// which binaries are read from the source controller. Zero means
+	// no limit.
+	BandwidthLimit int64
+
+	// ChunkSize is the number of bytes copied between progress
+	// reports. If zero, a default of 1MiB is used.
+	ChunkSize int64
+
+	// MaxResumeAttempts is the number of times an interrupted
+	// download is resumed before the migration fails. If zero, a
+	// default of 5 is used.
+	MaxResumeAttempts int
+
+	// Progress, if non-nil, is called as binaries are transferred.
+	Progress func(TransferProgress)
 }
 
 // Validate makes sure that all the config values are non-nil.
@@ -138,6 +171,15 @@ func (c *UploadBinariesConfig) Validate() error {
 	if c.ResourceUploader == nil {
 		return errors.NotValidf("missing ResourceUploader")
 	}
+	if c.BandwidthLimit < 0 {
+		return errors.NotValidf("negative BandwidthLimit")
+	}
+	if c.ChunkSize < 0 {
+		return errors.NotValidf("negative ChunkSize")
+	}
+	if c.MaxResumeAttempts < 0 {
+		return errors.NotValidf("negative MaxResumeAttempts")
+	}
 	return nil
 }
 
@@ -147,43 +189,21 @@ func UploadBinaries(config UploadBinariesConfig) error {
 	if err := config.Validate(); err != nil {
 		return errors.Trace(err)
 	}
-	if err := uploadCharms(config); err != nil {
+	t := newTransfer(config)
+	if err := uploadCharms(t); err != nil {
 		return errors.Trace(err)
 	}
-	if err := uploadTools(config); err != nil {
+	if err := uploadTools(t); err != nil {
 		return errors.Trace(err)
 	}
-	if err := uploadResources(config); err != nil {
+	if err := uploadResources(t); err != nil {
 		return errors.Trace(err)
 	}
 	return nil
 }
 
-func streamThroughTempFile(r io.Reader) (_ io.ReadSeeker, cleanup func(), err error) {
-	tempFile, err := ioutil.TempFile("", "juju-migrate-binary")
-	if err != nil {
-		return nil, nil, errors.Trace(err)
-	}
-	defer func() {
-		if err != nil {
-			os.Remove(tempFile.Name())
-		}
-	}()
-	_, err = io.Copy(tempFile, r)
-	if err != nil {
-		return nil, nil, errors.Trace(err)
-	}
-	tempFile.Seek(0, 0)
-	rmTempFile := func() {
-		filename := tempFile.Name()
-		tempFile.Close()
-		os.Remove(filename)
-	}
-
-	return tempFile, rmTempFile, nil
-}
-
-func uploadCharms(config UploadBinariesConfig) error {
+func uploadCharms(t *transfer) error {
+	config := t.config
 	// It is critical that charms are uploaded in ascending charm URL
 	// order so that charm revisions end up the same in the target as
 	// they were in the source.
@@ -197,13 +217,16 @@ func uploadCharms(config UploadBinariesConfig) error {
 			return errors.Annotate(err, "bad charm URL")
 		}
 
-		reader, err := config.CharmDownloader.OpenCharm(curl)
-		if err != nil {
-			return errors.Annotate(err, "cannot open charm")
+		t.start(CharmBinary, charmURL)
+		open := fromStart(func() (io.ReadCloser, error) {
+			return config.CharmDownloader.OpenCharm(curl)
+		})
+		if ranged, ok := config.CharmDownloader.(CharmRangeDownloader); ok {
+			open = func(offset int64) (io.ReadCloser, int64, error) {
+				return ranged.OpenCharmFrom(curl, offset)
+			}
 		}
-		defer reader.Close()
-
-		content, cleanup, err := streamThroughTempFile(reader)
+		content, cleanup, err := t.streamThroughTempFile(annotateOpen(open, "cannot open charm"))
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -215,21 +238,26 @@ func uploadCharms(config UploadBinariesConfig) error {
 			// The target controller shouldn't assign a different charm URL.
 			return errors.Errorf("charm %s unexpectedly assigned %s", curl, usedCurl)
 		}
+		t.done()
 	}
 	return nil
 }
 
-func uploadTools(config UploadBinariesConfig) error {
+func uploadTools(t *transfer) error {
+	config := t.config
 	for v, uri := range config.Tools {
 		logger.Debugf("sending agent binaries to target: %s", v)
 
-		reader, err := config.ToolsDownloader.OpenURI(uri, nil)
-		if err != nil {
-			return errors.Annotate(err, "cannot open charm")
+		t.start(ToolsBinary, v.String())
+		open := fromStart(func() (io.ReadCloser, error) {
+			return config.ToolsDownloader.OpenURI(uri, nil)
+		})
+		if ranged, ok := config.ToolsDownloader.(ToolsRangeDownloader); ok {
+			open = func(offset int64) (io.ReadCloser, int64, error) {
+				return ranged.OpenURIFrom(uri, nil, offset)
+			}
 		}
-		defer reader.Close()
-
-		content, cleanup, err := streamThroughTempFile(reader)
+		content, cleanup, err := t.streamThroughTempFile(annotateOpen(open, "cannot open agent binaries"))
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -238,17 +266,19 @@ func uploadTools(config UploadBinariesConfig) error {
 		if _, err := config.ToolsUploader.UploadTools(content, v); err != nil {
 			return errors.Annotate(err, "cannot upload agent binaries")
 		}
+		t.done()
 	}
 	return nil
 }
 
-func uploadResources(config UploadBinariesConfig) error {
+func uploadResources(t *transfer) error {
+	config := t.config
 	for _, res := range config.Resources {
 		if res.ApplicationRevision.IsPlaceholder() {
 			// Resource placeholders created in the migration import rather
 			// than attempting to post empty resources.
 		} else {
-			err := uploadAppResource(config, res.ApplicationRevision)
+			err := uploadAppResource(t, res.ApplicationRevision)
 			if err != nil {
 				return errors.Trace(err)
 			}
@@ -265,18 +295,20 @@ func uploadResources(config UploadBinariesConfig) error {
 	return nil
 }
 
-func uploadAppResource(config UploadBinariesConfig, rev resource.Resource) error {
+func uploadAppResource(t *transfer, rev resource.Resource) error {
+	config := t.config
 	logger.Debugf("opening application resource for %s: %s", rev.ApplicationID, rev.Name)
-	reader, err := config.ResourceDownloader.OpenResource(rev.ApplicationID, rev.Name)
-	if err != nil {
-		return errors.Annotate(err, "cannot open resource")
-	}
-	defer reader.Close()
+	t.start(ResourceBinary, rev.ApplicationID+"/"+rev.Name)
 
 	// TODO(menn0) - validate that the downloaded revision matches
 	// the expected metadata. Check revision and fingerprint.
 
-	content, cleanup, err := streamThroughTempFile(reader)
+	// The resources endpoint doesn't support ranged requests, so a
+	// resumed download is read again from the start.
+	open := fromStart(func() (io.ReadCloser, error) {
+		return config.ResourceDownloader.OpenResource(rev.ApplicationID, rev.Name)
+	})
+	content, cleanup, err := t.streamThroughTempFile(annotateOpen(open, "cannot open resource"))
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -285,5 +317,6 @@ func uploadAppResource(config UploadBinariesConfig, rev resource.Resource) error
 	if err := config.ResourceUploader.UploadResource(rev, content); err != nil {
 		return errors.Annotate(err, "cannot upload resource")
 	}
+	t.done()
 	return nil
 }
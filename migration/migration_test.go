@@ -113,6 +113,19 @@ func (s *ImportSuite) TestUploadBinariesConfigValidate(c *gc.C) {
 	check(func(c *T) { c.ResourceUploader = nil }, "ResourceUploader")
 }
 
+func (s *ImportSuite) TestUploadBinariesConfigValidateNegative(c *gc.C) {
+	config := migration.UploadBinariesConfig{
+		CharmDownloader:    struct{ migration.CharmDownloader }{},
+		CharmUploader:      struct{ migration.CharmUploader }{},
+		ToolsDownloader:    struct{ migration.ToolsDownloader }{},
+		ToolsUploader:      struct{ migration.ToolsUploader }{},
+		ResourceDownloader: struct{ migration.ResourceDownloader }{},
+		ResourceUploader:   struct{ migration.ResourceUploader }{},
+		BandwidthLimit:     -1,
+	}
+	c.Check(config.Validate(), gc.ErrorMatches, "negative BandwidthLimit not valid")
+}
+
 func (s *ImportSuite) TestBinariesMigration(c *gc.C) {
 	downloader := &fakeDownloader{}
 	uploader := &fakeUploader{
@@ -204,7 +217,126 @@ func (s *ImportSuite) TestWrongCharmURLAssigned(c *gc.C) {
 		"charm local:foo/bar-2 unexpectedly assigned local:foo/bar-1")
 }
 
+func (s *ImportSuite) TestBinariesMigrationProgress(c *gc.C) {
+	downloader := &fakeDownloader{}
+	uploader := &fakeUploader{
+		tools:     make(map[version.Binary]string),
+		resources: make(map[string]string),
+	}
+	var reports []migration.TransferProgress
+	config := migration.UploadBinariesConfig{
+		Charms:             []string{"local:trusty/magic-2"},
+		CharmDownloader:    downloader,
+		CharmUploader:      uploader,
+		Tools:              map[version.Binary]string{version.MustParseBinary("2.1.0-trusty-amd64"): "/tools/0"},
+		ToolsDownloader:    downloader,
+		ToolsUploader:      uploader,
+		ResourceDownloader: downloader,
+		ResourceUploader:   uploader,
+		ChunkSize:          10,
+		Progress: func(p migration.TransferProgress) {
+			reports = append(reports, p)
+		},
+	}
+	err := migration.UploadBinaries(config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	charmSize := int64(len("local:trusty/magic-2 content"))
+	toolsSize := int64(len("/tools/0"))
+	c.Assert(reports, gc.HasLen, 8)
+	c.Check(reports[0], jc.DeepEquals, migration.TransferProgress{
+		Kind:       migration.CharmBinary,
+		Name:       "local:trusty/magic-2",
+		ItemsTotal: 2,
+	})
+	c.Check(reports[3].ItemBytes, gc.Equals, charmSize)
+	c.Check(reports[4].ItemsDone, gc.Equals, 1)
+	c.Check(reports[5].Kind, gc.Equals, migration.ToolsBinary)
+	c.Check(reports[7], jc.DeepEquals, migration.TransferProgress{
+		Kind:       migration.ToolsBinary,
+		Name:       "2.1.0-trusty-amd64",
+		ItemBytes:  toolsSize,
+		ItemsDone:  2,
+		ItemsTotal: 2,
+		TotalBytes: charmSize + toolsSize,
+
+		ItemComplete: true,
+	})
+}
+
+func (s *ImportSuite) TestBinariesMigrationResumes(c *gc.C) {
+	downloader := &fakeDownloader{failAfter: 5, failures: 2}
+	uploader := &fakeUploader{
+		tools:     make(map[version.Binary]string),
+		resources: make(map[string]string),
+	}
+	config := migration.UploadBinariesConfig{
+		Charms:             []string{"local:trusty/magic-2"},
+		CharmDownloader:    downloader,
+		CharmUploader:      uploader,
+		ToolsDownloader:    downloader,
+		ToolsUploader:      uploader,
+		ResourceDownloader: downloader,
+		ResourceUploader:   uploader,
+	}
+	err := migration.UploadBinaries(config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The charm was opened once initially and once for each resume.
+	c.Assert(downloader.charms, gc.HasLen, 3)
+	c.Assert(uploader.charms, jc.DeepEquals, []string{"local:trusty/magic-2"})
+}
+
+func (s *ImportSuite) TestBinariesMigrationResumesFromOffset(c *gc.C) {
+	downloader := &fakeRangeDownloader{
+		fakeDownloader: fakeDownloader{failAfter: 5, failures: 1},
+	}
+	uploader := &fakeUploader{
+		tools:     make(map[version.Binary]string),
+		resources: make(map[string]string),
+	}
+	config := migration.UploadBinariesConfig{
+		Charms:             []string{"local:trusty/magic-2"},
+		CharmDownloader:    downloader,
+		CharmUploader:      uploader,
+		ToolsDownloader:    downloader,
+		ToolsUploader:      uploader,
+		ResourceDownloader: downloader,
+		ResourceUploader:   uploader,
+	}
+	err := migration.UploadBinaries(config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The download was resumed from the byte after those received,
+	// and the uploaded charm is complete.
+	c.Assert(downloader.offsets, jc.DeepEquals, []int64{0, 5})
+	c.Assert(uploader.charms, jc.DeepEquals, []string{"local:trusty/magic-2"})
+}
+
+func (s *ImportSuite) TestBinariesMigrationResumeLimit(c *gc.C) {
+	downloader := &fakeDownloader{failAfter: 5, failures: 3}
+	uploader := &fakeUploader{}
+	config := migration.UploadBinariesConfig{
+		Charms:             []string{"local:trusty/magic-2"},
+		CharmDownloader:    downloader,
+		CharmUploader:      uploader,
+		ToolsDownloader:    downloader,
+		ToolsUploader:      uploader,
+		ResourceDownloader: downloader,
+		ResourceUploader:   uploader,
+		MaxResumeAttempts:  2,
+	}
+	err := migration.UploadBinaries(config)
+	c.Assert(err, gc.ErrorMatches, "transfer failed after 5 bytes: connection reset")
+	c.Assert(uploader.charms, gc.HasLen, 0)
+}
+
 type fakeDownloader struct {
+	// failAfter and failures, when set, cause the first failures
+	// charm downloads to fail after failAfter bytes have been read.
+	failAfter int
+	failures  int
+
 	charms    []string
 	uris      []string
 	resources []string
@@ -214,7 +346,42 @@ func (d *fakeDownloader) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
 	urlStr := curl.String()
 	d.charms = append(d.charms, urlStr)
 	// Return the charm URL string as the fake charm content
-	return ioutil.NopCloser(bytes.NewReader([]byte(urlStr + " content"))), nil
+	content := []byte(urlStr + " content")
+	if d.failures > 0 {
+		d.failures--
+		return ioutil.NopCloser(io.MultiReader(
+			bytes.NewReader(content[:d.failAfter]),
+			&errorReader{errors.New("connection reset")},
+		)), nil
+	}
+	return ioutil.NopCloser(bytes.NewReader(content)), nil
+}
+
+type errorReader struct {
+	err error
+}
+
+func (r *errorReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+// fakeRangeDownloader is a fakeDownloader that can open charms part
+// way through.
+type fakeRangeDownloader struct {
+	fakeDownloader
+	offsets []int64
+}
+
+func (d *fakeRangeDownloader) OpenCharmFrom(curl *charm.URL, offset int64) (io.ReadCloser, int64, error) {
+	d.offsets = append(d.offsets, offset)
+	reader, err := d.OpenCharm(curl)
+	if err != nil {
+		return nil, 0, err
+	}
+	if _, err := io.CopyN(ioutil.Discard, reader, offset); err != nil {
+		return nil, 0, err
+	}
+	return reader, offset, nil
 }
 
 func (d *fakeDownloader) OpenURI(uri string, query url.Values) (io.ReadCloser, error) {
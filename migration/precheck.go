@@ -35,6 +35,7 @@ type PrecheckBackend interface {
 	ControllerBackend() (PrecheckBackend, error)
 	CloudCredential(tag names.CloudCredentialTag) (cloud.Credential, error)
 	ListPendingResources(string) ([]resource.Resource, error)
+	HasBranch() (bool, error)
 }
 
 // Pool defines the interface to a StatePool used by the migration
@@ -112,6 +113,12 @@ func SourcePrecheck(backend PrecheckBackend) error {
 		return errors.Trace(err)
 	}
 
+	if hasBranch, err := backend.HasBranch(); err != nil {
+		return errors.Annotate(err, "checking branch")
+	} else if hasBranch {
+		return errors.New("model has a branch that must be committed or aborted")
+	}
+
 	if err := checkMachines(backend); err != nil {
 		return errors.Trace(err)
 	}
@@ -41,6 +41,20 @@ func (s *precheckShim) Model() (PrecheckModel, error) {
 	return model, nil
 }
 
+// HasBranch implements PrecheckBackend.
+func (s *precheckShim) HasBranch() (bool, error) {
+	model, err := s.State.Model()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if _, err := model.Branch(); errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	return true, nil
+}
+
 // IsMigrationActive implements PrecheckBackend.
 func (s *precheckShim) IsMigrationActive(modelUUID string) (bool, error) {
 	return state.IsMigrationActive(s.State, modelUUID)
@@ -93,6 +93,20 @@ func (*SourcePrecheckSuite) TestImportingModel(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "model is being imported as part of another migration")
 }
 
+func (*SourcePrecheckSuite) TestModelWithBranch(c *gc.C) {
+	backend := newFakeBackend()
+	backend.hasBranch = true
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "model has a branch that must be committed or aborted")
+}
+
+func (*SourcePrecheckSuite) TestModelBranchError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.hasBranchErr = errors.New("boom")
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "checking branch: boom")
+}
+
 func (*SourcePrecheckSuite) TestCleanupsError(c *gc.C) {
 	backend := newFakeBackend()
 	backend.cleanupErr = errors.New("boom")
@@ -740,6 +754,9 @@ type fakeBackend struct {
 	pendingResources    []resource.Resource
 	pendingResourcesErr error
 
+	hasBranch    bool
+	hasBranchErr error
+
 	controllerBackend *fakeBackend
 }
 
@@ -759,6 +776,10 @@ func (b *fakeBackend) AgentVersion() (version.Number, error) {
 	return backendVersion, b.agentVersionErr
 }
 
+func (b *fakeBackend) HasBranch() (bool, error) {
+	return b.hasBranch, b.hasBranchErr
+}
+
 func (b *fakeBackend) IsUpgrading() (bool, error) {
 	return b.isUpgrading, b.isUpgradingErr
 }
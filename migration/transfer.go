@@ -0,0 +1,233 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/juju/errors"
+	"github.com/juju/ratelimit"
+)
+
+const (
+	// defaultTransferChunkSize is the number of bytes copied from a
+	// source binary stream before progress is reported.
+	defaultTransferChunkSize = 1024 * 1024
+
+	// defaultMaxResumeAttempts is the number of times a binary
+	// download will be reopened and resumed after a read failure
+	// before the transfer is abandoned.
+	defaultMaxResumeAttempts = 5
+)
+
+// BinaryKind identifies the type of a binary being transferred
+// during a migration.
+type BinaryKind string
+
+const (
+	CharmBinary    BinaryKind = "charm"
+	ToolsBinary    BinaryKind = "agent binaries"
+	ResourceBinary BinaryKind = "resource"
+)
+
+// TransferProgress describes how far through the transfer of binaries
+// to the target controller a migration is.
+type TransferProgress struct {
+	// Kind and Name identify the binary currently being transferred.
+	Kind BinaryKind
+	Name string
+
+	// ItemBytes is the number of bytes of the current binary that
+	// have been received from the source controller so far.
+	ItemBytes int64
+
+	// ItemsDone and ItemsTotal record the number of binaries
+	// completely transferred and the number of binaries to transfer
+	// in total.
+	ItemsDone  int
+	ItemsTotal int
+
+	// TotalBytes is the number of bytes transferred across all
+	// binaries so far.
+	TotalBytes int64
+
+	// ItemComplete is true when reporting that the current binary
+	// has been completely transferred.
+	ItemComplete bool
+}
+
+// openFunc opens a binary stream from the source controller, asking
+// for it to start at the given offset. It returns the offset the
+// stream actually starts from, which is zero if the source doesn't
+// support ranged requests. It is called again to resume a transfer
+// after a read failure.
+type openFunc func(offset int64) (io.ReadCloser, int64, error)
+
+// fromStart returns an openFunc for a source that can only be read
+// from the start.
+func fromStart(open func() (io.ReadCloser, error)) openFunc {
+	return func(int64) (io.ReadCloser, int64, error) {
+		reader, err := open()
+		return reader, 0, err
+	}
+}
+
+// annotateOpen returns an openFunc that annotates any error returned
+// by open with the given message.
+func annotateOpen(open openFunc, message string) openFunc {
+	return func(offset int64) (io.ReadCloser, int64, error) {
+		reader, start, err := open(offset)
+		if err != nil {
+			return nil, 0, errors.Annotate(err, message)
+		}
+		return reader, start, nil
+	}
+}
+
+// transfer tracks the progress of an UploadBinaries call.
+type transfer struct {
+	config   UploadBinariesConfig
+	bucket   *ratelimit.Bucket
+	progress TransferProgress
+}
+
+func newTransfer(config UploadBinariesConfig) *transfer {
+	t := &transfer{config: config}
+	if config.BandwidthLimit > 0 {
+		t.bucket = ratelimit.NewBucketWithRate(
+			float64(config.BandwidthLimit),
+			config.BandwidthLimit,
+		)
+	}
+	t.progress.ItemsTotal = len(config.Charms) + len(config.Tools)
+	for _, res := range config.Resources {
+		if !res.ApplicationRevision.IsPlaceholder() {
+			t.progress.ItemsTotal++
+		}
+	}
+	return t
+}
+
+func (t *transfer) chunkSize() int64 {
+	if t.config.ChunkSize > 0 {
+		return t.config.ChunkSize
+	}
+	return defaultTransferChunkSize
+}
+
+func (t *transfer) maxResumeAttempts() int {
+	if t.config.MaxResumeAttempts > 0 {
+		return t.config.MaxResumeAttempts
+	}
+	return defaultMaxResumeAttempts
+}
+
+func (t *transfer) report() {
+	if t.config.Progress != nil {
+		t.config.Progress(t.progress)
+	}
+}
+
+// start records that the transfer of a new binary has begun.
+func (t *transfer) start(kind BinaryKind, name string) {
+	t.progress.Kind = kind
+	t.progress.Name = name
+	t.progress.ItemBytes = 0
+	t.progress.ItemComplete = false
+	t.report()
+}
+
+// done records that the current binary has been transferred.
+func (t *transfer) done() {
+	t.progress.ItemsDone++
+	t.progress.ItemComplete = true
+	t.report()
+}
+
+// streamThroughTempFile copies the binary returned by open into a
+// temporary file, which is returned along with a function to remove
+// it. Data is copied in chunks, subject to the configured bandwidth
+// limit, with progress reported after each chunk. If reading fails
+// part way through, the source is reopened and the transfer resumes
+// from the last byte received.
+func (t *transfer) streamThroughTempFile(open openFunc) (_ io.ReadSeeker, cleanup func(), err error) {
+	tempFile, err := ioutil.TempFile("", "juju-migrate-binary")
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	defer func() {
+		if err != nil {
+			tempFile.Close()
+			os.Remove(tempFile.Name())
+		}
+	}()
+
+	var received int64
+	for attempt := 0; ; attempt++ {
+		n, err := t.copyFrom(open, tempFile, received)
+		received += n
+		if err == nil {
+			break
+		}
+		if attempt >= t.maxResumeAttempts() {
+			return nil, nil, errors.Annotatef(err, "transfer failed after %d bytes", received)
+		}
+		logger.Warningf("resuming %s %s after %d bytes: %v",
+			t.progress.Kind, t.progress.Name, received, err)
+	}
+
+	if _, err := tempFile.Seek(0, 0); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	rmTempFile := func() {
+		filename := tempFile.Name()
+		tempFile.Close()
+		os.Remove(filename)
+	}
+	return tempFile, rmTempFile, nil
+}
+
+// copyFrom opens the source binary at the offset of the bytes already
+// received and copies the remainder to w, returning the number of new
+// bytes written.
+func (t *transfer) copyFrom(open openFunc, w io.Writer, offset int64) (int64, error) {
+	reader, start, err := open(offset)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	defer reader.Close()
+
+	if start > offset {
+		return 0, errors.Errorf("source opened at byte %d, expected at most %d", start, offset)
+	}
+	if skip := offset - start; skip > 0 {
+		// The source sent bytes that have already been stored, so
+		// discard them.
+		if _, err := io.CopyN(ioutil.Discard, reader, skip); err != nil {
+			return 0, errors.Annotate(err, "skipping received bytes")
+		}
+	}
+
+	var source io.Reader = reader
+	if t.bucket != nil {
+		source = ratelimit.Reader(reader, t.bucket)
+	}
+	var written int64
+	for {
+		n, err := io.CopyN(w, source, t.chunkSize())
+		written += n
+		t.progress.ItemBytes += n
+		t.progress.TotalBytes += n
+		if n > 0 {
+			t.report()
+		}
+		if err == io.EOF {
+			return written, nil
+		} else if err != nil {
+			return written, errors.Trace(err)
+		}
+	}
+}
@@ -17,16 +17,21 @@ var (
 
 	NewConf = newConf
 
+	CanResizeOplog = canResizeOplog
+
 	HostWordSize     = &hostWordSize
 	RuntimeGOOS      = &runtimeGOOS
 	AvailSpace       = &availSpace
 	SmallOplogSizeMB = &smallOplogSizeMB
 	PreallocFile     = &preallocFile
+	TotalMemory      = &totalMemory
+	TotalSpace       = &totalSpace
 
 	DefaultOplogSize  = defaultOplogSize
 	FsAvailSpace      = fsAvailSpace
 	PreallocFileSizes = preallocFileSizes
 	PreallocFiles     = preallocFiles
+	ParseTotalMemory  = parseTotalMemory
 )
 
 func PatchService(patchValue func(interface{}, interface{}), data *svctesting.FakeServiceData) {
@@ -394,7 +394,7 @@ func (m MemoryProfile) String() string {
 }
 
 func (m MemoryProfile) Validate() error {
-	if m != MemoryProfileLow && m != MemoryProfileDefault {
+	if m != MemoryProfileLow && m != MemoryProfileDefault && m != MemoryProfileAuto {
 		return errors.NotValidf("memory profile %q", m)
 	}
 	return nil
@@ -405,6 +405,9 @@ const (
 	MemoryProfileLow MemoryProfile = "low"
 	// MemoryProfileDefault will use mongo config ootb.
 	MemoryProfileDefault = "default"
+	// MemoryProfileAuto sizes the cache and oplog of mongo for the
+	// resources of the machine it runs on.
+	MemoryProfileAuto = "auto"
 )
 
 // EnsureServerParams is a parameter struct for EnsureServer.
@@ -443,6 +446,10 @@ type EnsureServerParams struct {
 	// algorithm defined in Mongo.
 	OplogSize int
 
+	// CacheSizeMB is the size of the WiredTiger cache. If this is
+	// zero, the cache is sized according to MemoryProfile.
+	CacheSizeMB int
+
 	// SetNUMAControlPolicy preference - whether the user
 	// wants to set the numa control policy when starting mongo.
 	SetNUMAControlPolicy bool
@@ -450,6 +457,11 @@ type EnsureServerParams struct {
 	// MemoryProfile determines which value is going to be used by
 	// the cache and future memory tweaks.
 	MemoryProfile MemoryProfile
+
+	// SingleNode indicates that the controller is running as a single
+	// node, so mongo can run with lighter-weight settings until it is
+	// promoted to HA by PromoteToHA.
+	SingleNode bool
 }
 
 // EnsureServer ensures that the MongoDB server is installed,
@@ -473,14 +485,6 @@ func ensureServer(args EnsureServerParams, mongoKernelTweaks map[string]string)
 		return fmt.Errorf("cannot create mongo database directory: %v", err)
 	}
 
-	oplogSizeMB := args.OplogSize
-	if oplogSizeMB == 0 {
-		var err error
-		if oplogSizeMB, err = defaultOplogSize(dbDir); err != nil {
-			return err
-		}
-	}
-
 	if err := installMongod(series.MustHostSeries(), args.SetNUMAControlPolicy); err != nil {
 		// This isn't treated as fatal because the Juju MongoDB
 		// package is likely to be already installed anyway. There
@@ -496,6 +500,19 @@ func ensureServer(args EnsureServerParams, mongoKernelTweaks map[string]string)
 	}
 	logVersion(mongoPath)
 
+	sizing, err := DesiredSizing(args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	oplogSizeMB := sizing.OplogSizeMB
+	if oplogSizeMB == 0 {
+		if args.SingleNode && canResizeOplog(mgoVersion) {
+			oplogSizeMB = SingleNodeOplogSizeMB
+		} else if oplogSizeMB, err = defaultOplogSize(dbDir); err != nil {
+			return err
+		}
+	}
+
 	if err := UpdateSSLKey(args.DataDir, args.Cert, args.PrivateKey); err != nil {
 		return err
 	}
@@ -525,11 +542,13 @@ func ensureServer(args EnsureServerParams, mongoKernelTweaks map[string]string)
 		MongoPath:     mongoPath,
 		Port:          args.StatePort,
 		OplogSizeMB:   oplogSizeMB,
+		CacheSizeMB:   sizing.CacheSizeMB,
 		WantNUMACtl:   args.SetNUMAControlPolicy,
 		Version:       mgoVersion,
 		Auth:          true,
 		IPv6:          network.SupportsIPv6(),
 		MemoryProfile: args.MemoryProfile,
+		SingleNode:    args.SingleNode,
 	})
 	svc, err := newService(ServiceName, svcConf)
 	if err != nil {
@@ -86,6 +86,18 @@ func defaultOplogSize(dir string) (int, error) {
 // fsAvailSpace returns the available space in MB on the
 // filesystem containing the specified directory.
 func fsAvailSpace(dir string) (avail float64, err error) {
+	return dfSpace(dir, 3)
+}
+
+// fsTotalSpace returns the total size in MB of the filesystem
+// containing the specified directory.
+func fsTotalSpace(dir string) (float64, error) {
+	return dfSpace(dir, 1)
+}
+
+// dfSpace returns the space in MB reported by df in the given field
+// for the filesystem containing the specified directory.
+func dfSpace(dir string, field int) (float64, error) {
 	var stderr bytes.Buffer
 	cmd := exec.Command("df", dir)
 	cmd.Stderr = &stderr
@@ -107,7 +119,7 @@ func fsAvailSpace(dir string) (avail float64, err error) {
 		logger.Errorf("unexpected output: %q", out)
 		return -1, fmt.Errorf("could not determine available space on %q", dir)
 	}
-	kilobytes, err := strconv.Atoi(fields[3])
+	kilobytes, err := strconv.Atoi(fields[field])
 	if err != nil {
 		return -1, err
 	}
@@ -34,6 +34,11 @@ const (
 	// LowCacheSize expressed in GB sets the max value Mongo WiredTiger cache can
 	// reach.
 	LowCacheSize = 1
+
+	// SingleNodeCacheSizeMB expressed in MB sets the max value Mongo
+	// WiredTiger cache can reach on a controller running as a single
+	// node.
+	SingleNodeCacheSizeMB = 256
 )
 
 var (
@@ -144,11 +149,13 @@ func sharedSecretPath(dataDir string) string {
 type ConfigArgs struct {
 	DataDir, DBDir, MongoPath string
 	Port, OplogSizeMB         int
+	CacheSizeMB               int
 	WantNUMACtl               bool
 	Version                   Version
 	Auth                      bool
 	IPv6                      bool
 	MemoryProfile             MemoryProfile
+	SingleNode                bool
 }
 
 // newConf returns the init system config for the mongo state service.
@@ -197,8 +204,19 @@ func newConf(args ConfigArgs) common.Conf {
 			" --storageEngine wiredTiger"
 		// TODO(perrito666) make LowCacheSize 0,25 when mongo version goes
 		// to 3.4
-		if args.MemoryProfile == MemoryProfileLow {
-			mongoCmd = mongoCmd + " --wiredTigerCacheSizeGB " + fmt.Sprint(LowCacheSize)
+		if args.CacheSizeMB > 0 {
+			mongoCmd = mongoCmd + " --wiredTigerEngineConfigString " +
+				utils.ShQuote(fmt.Sprintf("cache_size=%dM", args.CacheSizeMB))
+		} else if args.MemoryProfile == MemoryProfileLow {
+			if args.SingleNode {
+				// The cache size flag only takes whole gigabytes
+				// before mongo 3.4, so the cache size is passed
+				// straight to WiredTiger instead.
+				mongoCmd = mongoCmd + " --wiredTigerEngineConfigString " +
+					utils.ShQuote(fmt.Sprintf("cache_size=%dM", SingleNodeCacheSizeMB))
+			} else {
+				mongoCmd = mongoCmd + " --wiredTigerCacheSizeGB " + fmt.Sprint(LowCacheSize)
+			}
 		}
 	}
 	extraScript := ""
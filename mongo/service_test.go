@@ -116,6 +116,35 @@ func (s *serviceSuite) TestNewConf32(c *gc.C) {
 	c.Check(strings.Fields(conf.ExecStart), jc.DeepEquals, strings.Fields(expected.ExecStart))
 }
 
+func (s *serviceSuite) TestNewConf32SingleNode(c *gc.C) {
+	args := mongo.ConfigArgs{
+		DataDir:       "/var/lib/juju",
+		DBDir:         "/var/lib/juju/db",
+		MongoPath:     "/mgo/bin/mongod",
+		Port:          12345,
+		OplogSizeMB:   10,
+		Version:       mongo.Mongo32wt,
+		Auth:          true,
+		MemoryProfile: mongo.MemoryProfileLow,
+	}
+	conf := mongo.NewConf(args)
+	c.Check(conf.ExecStart, gc.Matches, ".* --storageEngine wiredTiger --wiredTigerCacheSizeGB 1")
+
+	args.SingleNode = true
+	conf = mongo.NewConf(args)
+	c.Check(conf.ExecStart, gc.Matches, ".* --storageEngine wiredTiger --wiredTigerEngineConfigString 'cache_size=256M'")
+
+	// The default memory profile leaves the cache size to mongo.
+	args.MemoryProfile = mongo.MemoryProfileDefault
+	conf = mongo.NewConf(args)
+	c.Check(conf.ExecStart, gc.Matches, ".* --storageEngine wiredTiger")
+
+	// A cache size given in the args overrides the memory profile.
+	args.CacheSizeMB = 3072
+	conf = mongo.NewConf(args)
+	c.Check(conf.ExecStart, gc.Matches, ".* --storageEngine wiredTiger --wiredTigerEngineConfigString 'cache_size=3072M'")
+}
+
 func (s *serviceSuite) TestIsServiceInstalledWhenInstalled(c *gc.C) {
 	svcName := mongo.ServiceName
 	svcData := svctesting.NewFakeServiceData(svcName)
@@ -0,0 +1,86 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package mongo
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	// SingleNodeOplogSizeMB is the size of the oplog of a controller
+	// running as a single node, when the oplog can later be resized
+	// without restarting mongo.
+	SingleNodeOplogSizeMB = 128
+
+	// minResizedOplogSizeMB is the smallest size mongo will resize
+	// the oplog to.
+	minResizedOplogSizeMB = 990
+)
+
+// canResizeOplog reports whether the given version of mongo can
+// resize its oplog while running. Versions that cannot would need to
+// be restarted as a standalone server to do so, so controllers using
+// them always start with an oplog sized for HA.
+func canResizeOplog(v Version) bool {
+	if v.StorageEngine != WiredTiger {
+		return false
+	}
+	return v.Major > 3 || v.Major == 3 && v.Minor >= 6
+}
+
+// PromoteToHA converts the running mongo server of a controller that
+// was started with SingleNode set in args to the settings used by
+// controllers in HA, without restarting it: the WiredTiger cache is
+// grown to its usual size, and the oplog resized. When the server is
+// next ensured, args.SingleNode should be false so that the service
+// configuration matches.
+func PromoteToHA(session *mgo.Session, version Version, args EnsureServerParams) error {
+	if version.StorageEngine != WiredTiger {
+		return nil
+	}
+	if args.MemoryProfile == MemoryProfileAuto {
+		args.SingleNode = false
+		sizing, err := DesiredSizing(args)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return ResizeServer(session, version, sizing)
+	}
+	if args.MemoryProfile == MemoryProfileLow {
+		cacheSize := fmt.Sprintf("cache_size=%dG", LowCacheSize)
+		err := session.Run(bson.D{
+			{"setParameter", 1},
+			{"wiredTigerEngineRuntimeConfig", cacheSize},
+		}, nil)
+		if err != nil {
+			return errors.Annotate(err, "cannot resize WiredTiger cache")
+		}
+		logger.Infof("resized WiredTiger cache to %dGB", LowCacheSize)
+	}
+	if args.OplogSize != 0 || !canResizeOplog(version) {
+		// The oplog was sized for HA when mongo was installed.
+		return nil
+	}
+	oplogSizeMB, err := defaultOplogSize(filepath.Join(args.DataDir, "db"))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if oplogSizeMB < minResizedOplogSizeMB {
+		oplogSizeMB = minResizedOplogSizeMB
+	}
+	err = session.Run(bson.D{
+		{"replSetResizeOplog", 1},
+		{"size", float64(oplogSizeMB)},
+	}, nil)
+	if err != nil {
+		return errors.Annotate(err, "cannot resize oplog")
+	}
+	logger.Infof("resized oplog to %dMB", oplogSizeMB)
+	return nil
+}
@@ -0,0 +1,33 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package mongo_test
+
+import (
+	"github.com/juju/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/mongo"
+)
+
+type singleNodeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&singleNodeSuite{})
+
+func (s *singleNodeSuite) TestCanResizeOplog(c *gc.C) {
+	for i, test := range []struct {
+		version mongo.Version
+		expect  bool
+	}{
+		{mongo.Mongo24, false},
+		{mongo.Mongo32wt, false},
+		{mongo.Version{Major: 3, Minor: 6, StorageEngine: mongo.WiredTiger}, true},
+		{mongo.Version{Major: 3, Minor: 6, StorageEngine: mongo.MMAPV1}, false},
+		{mongo.Version{Major: 4, Minor: 0, StorageEngine: mongo.WiredTiger}, true},
+	} {
+		c.Logf("test %d: %v", i, test.version)
+		c.Check(mongo.CanResizeOplog(test.version), gc.Equals, test.expect)
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package mongo
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	// reservedMemoryMB is the memory that automatic sizing leaves for
+	// the controller agent and the rest of the machine before sizing
+	// the WiredTiger cache.
+	reservedMemoryMB = 1536
+
+	// autoCachePercent is the percentage of the remaining memory
+	// given to the WiredTiger cache by automatic sizing.
+	autoCachePercent = 50
+
+	// minAutoCacheSizeMB is the smallest WiredTiger cache given by
+	// automatic sizing.
+	minAutoCacheSizeMB = SingleNodeCacheSizeMB
+
+	// autoOplogPercent is the percentage of the size of the database
+	// filesystem given to the oplog by automatic sizing.
+	autoOplogPercent = 5
+
+	// maxAutoOplogSizeMB is the largest oplog given by automatic
+	// sizing.
+	maxAutoOplogSizeMB = 50 * 1024
+)
+
+var (
+	totalMemory = procTotalMemory
+	totalSpace  = fsTotalSpace
+)
+
+// Resources describes the resources of a controller machine that
+// mongo is sized for.
+type Resources struct {
+	// MemoryMB is the total memory of the machine.
+	MemoryMB int
+
+	// DiskMB is the total size of the filesystem holding the
+	// database.
+	DiskMB int
+}
+
+// Sizing holds the sizes of the WiredTiger cache and the oplog of a
+// mongo server. A zero size is left for mongo, or the memory profile,
+// to decide.
+type Sizing struct {
+	CacheSizeMB int
+	OplogSizeMB int
+}
+
+// AutoSizing returns the sizing used by the automatic memory profile
+// on a machine with the given resources. The cache is given half of
+// the memory left after reserving some for the controller agent, as
+// mongo itself would if it were alone on the machine, and the oplog
+// 5% of the database filesystem, within bounds.
+func AutoSizing(resources Resources) Sizing {
+	cacheSizeMB := (resources.MemoryMB - reservedMemoryMB) * autoCachePercent / 100
+	if cacheSizeMB < minAutoCacheSizeMB {
+		cacheSizeMB = minAutoCacheSizeMB
+	}
+	oplogSizeMB := resources.DiskMB * autoOplogPercent / 100
+	if oplogSizeMB < smallOplogSizeMB {
+		oplogSizeMB = smallOplogSizeMB
+	} else if oplogSizeMB > maxAutoOplogSizeMB {
+		oplogSizeMB = maxAutoOplogSizeMB
+	}
+	return Sizing{
+		CacheSizeMB: cacheSizeMB,
+		OplogSizeMB: oplogSizeMB,
+	}
+}
+
+// DesiredSizing returns the sizing that the mongo server described by
+// args should have on this machine. Sizes given in args are always
+// used. Otherwise, with the automatic memory profile, the sizes are
+// computed from the machine's resources, unless the controller is a
+// single node, whose mongo keeps the lighter-weight settings until it
+// is promoted to HA.
+func DesiredSizing(args EnsureServerParams) (Sizing, error) {
+	sizing := Sizing{
+		CacheSizeMB: args.CacheSizeMB,
+		OplogSizeMB: args.OplogSize,
+	}
+	if args.MemoryProfile != MemoryProfileAuto {
+		return sizing, nil
+	}
+	if args.SingleNode {
+		if sizing.CacheSizeMB == 0 {
+			sizing.CacheSizeMB = SingleNodeCacheSizeMB
+		}
+		return sizing, nil
+	}
+	if runtimeGOOS != "linux" || hostWordSize == 32 {
+		// Only resources of 64 bit linux machines are known.
+		return sizing, nil
+	}
+	resources, err := detectResources(filepath.Join(args.DataDir, "db"))
+	if err != nil {
+		return Sizing{}, errors.Annotate(err, "cannot detect machine resources")
+	}
+	auto := AutoSizing(resources)
+	logger.Debugf("mongo sizing for %+v is %+v", resources, auto)
+	if sizing.CacheSizeMB == 0 {
+		sizing.CacheSizeMB = auto.CacheSizeMB
+	}
+	if sizing.OplogSizeMB == 0 {
+		sizing.OplogSizeMB = auto.OplogSizeMB
+	}
+	return sizing, nil
+}
+
+// ResizeServer changes the WiredTiger cache and the oplog of the
+// running mongo server to the given sizes, without restarting it, if
+// they are not already so sized. Sizes that are zero, or that the
+// server's version cannot change while it is running, are left
+// alone. The session must be connected directly to the server.
+func ResizeServer(session *mgo.Session, version Version, sizing Sizing) error {
+	if version.StorageEngine != WiredTiger {
+		return nil
+	}
+	current, err := currentSizing(session)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if sizing.CacheSizeMB > 0 && sizing.CacheSizeMB != current.CacheSizeMB {
+		err := session.Run(bson.D{
+			{"setParameter", 1},
+			{"wiredTigerEngineRuntimeConfig", fmt.Sprintf("cache_size=%dM", sizing.CacheSizeMB)},
+		}, nil)
+		if err != nil {
+			return errors.Annotate(err, "cannot resize WiredTiger cache")
+		}
+		logger.Infof("resized WiredTiger cache from %dMB to %dMB", current.CacheSizeMB, sizing.CacheSizeMB)
+	}
+	if sizing.OplogSizeMB == 0 || !canResizeOplog(version) {
+		return nil
+	}
+	oplogSizeMB := sizing.OplogSizeMB
+	if oplogSizeMB < minResizedOplogSizeMB {
+		oplogSizeMB = minResizedOplogSizeMB
+	}
+	if oplogSizeMB == current.OplogSizeMB {
+		return nil
+	}
+	err = session.Run(bson.D{
+		{"replSetResizeOplog", 1},
+		{"size", float64(oplogSizeMB)},
+	}, nil)
+	if err != nil {
+		return errors.Annotate(err, "cannot resize oplog")
+	}
+	logger.Infof("resized oplog from %dMB to %dMB", current.OplogSizeMB, oplogSizeMB)
+	return nil
+}
+
+// currentSizing returns the sizes of the WiredTiger cache and the
+// oplog of the mongo server connected to by the session.
+func currentSizing(session *mgo.Session) (Sizing, error) {
+	var status struct {
+		WiredTiger struct {
+			Cache struct {
+				MaxBytes float64 `bson:"maximum bytes configured"`
+			} `bson:"cache"`
+		} `bson:"wiredTiger"`
+	}
+	if err := session.Run(bson.D{{"serverStatus", 1}}, &status); err != nil {
+		return Sizing{}, errors.Annotate(err, "cannot get WiredTiger cache size")
+	}
+	var stats struct {
+		MaxSize float64 `bson:"maxSize"`
+	}
+	if err := session.DB("local").Run(bson.D{{"collStats", "oplog.rs"}}, &stats); err != nil {
+		return Sizing{}, errors.Annotate(err, "cannot get oplog size")
+	}
+	const mb = 1024 * 1024
+	return Sizing{
+		CacheSizeMB: int(status.WiredTiger.Cache.MaxBytes / mb),
+		OplogSizeMB: int(stats.MaxSize / mb),
+	}, nil
+}
+
+// detectResources returns the resources of the machine, measuring the
+// filesystem holding the given database directory.
+func detectResources(dbDir string) (Resources, error) {
+	memoryMB, err := totalMemory()
+	if err != nil {
+		return Resources{}, errors.Trace(err)
+	}
+	diskMB, err := totalSpace(dbDir)
+	if err != nil {
+		return Resources{}, errors.Trace(err)
+	}
+	return Resources{
+		MemoryMB: memoryMB,
+		DiskMB:   int(diskMB),
+	}, nil
+}
+
+// procTotalMemory returns the total memory of the machine in MB, as
+// reported by /proc/meminfo.
+func procTotalMemory() (int, error) {
+	f, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	defer f.Close()
+	return parseTotalMemory(f)
+}
+
+// parseTotalMemory returns the total memory in MB given in the
+// /proc/meminfo format.
+func parseTotalMemory(r io.Reader) (int, error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != "MemTotal:" {
+			continue
+		}
+		kilobytes, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return 0, errors.Annotate(err, "cannot parse total memory")
+		}
+		return kilobytes / 1024, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return 0, errors.New("could not determine total memory")
+}
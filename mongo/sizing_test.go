@@ -0,0 +1,123 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package mongo_test
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/mongo"
+)
+
+type sizingSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&sizingSuite{})
+
+func (s *sizingSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.PatchValue(mongo.RuntimeGOOS, "linux")
+	s.PatchValue(mongo.HostWordSize, 64)
+	s.PatchValue(mongo.TotalMemory, func() (int, error) { return 8192, nil })
+	s.PatchValue(mongo.TotalSpace, func(dir string) (float64, error) {
+		c.Check(dir, gc.Equals, "/var/lib/juju/db")
+		return 102400, nil
+	})
+}
+
+func (s *sizingSuite) TestAutoSizing(c *gc.C) {
+	for i, test := range []struct {
+		resources mongo.Resources
+		expect    mongo.Sizing
+	}{{
+		resources: mongo.Resources{MemoryMB: 1024, DiskMB: 8192},
+		expect:    mongo.Sizing{CacheSizeMB: 256, OplogSizeMB: 512},
+	}, {
+		resources: mongo.Resources{MemoryMB: 4096, DiskMB: 40960},
+		expect:    mongo.Sizing{CacheSizeMB: 1280, OplogSizeMB: 2048},
+	}, {
+		resources: mongo.Resources{MemoryMB: 65536, DiskMB: 2 * 1024 * 1024},
+		expect:    mongo.Sizing{CacheSizeMB: 32000, OplogSizeMB: 51200},
+	}} {
+		c.Logf("test %d: %+v", i, test.resources)
+		c.Check(mongo.AutoSizing(test.resources), jc.DeepEquals, test.expect)
+	}
+}
+
+func (s *sizingSuite) TestDesiredSizingAuto(c *gc.C) {
+	sizing, err := mongo.DesiredSizing(mongo.EnsureServerParams{
+		DataDir:       "/var/lib/juju",
+		MemoryProfile: mongo.MemoryProfileAuto,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sizing, jc.DeepEquals, mongo.Sizing{CacheSizeMB: 3328, OplogSizeMB: 5120})
+}
+
+func (s *sizingSuite) TestDesiredSizingOverrides(c *gc.C) {
+	sizing, err := mongo.DesiredSizing(mongo.EnsureServerParams{
+		DataDir:       "/var/lib/juju",
+		MemoryProfile: mongo.MemoryProfileAuto,
+		CacheSizeMB:   1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sizing, jc.DeepEquals, mongo.Sizing{CacheSizeMB: 1024, OplogSizeMB: 5120})
+
+	// Overrides apply whatever the memory profile.
+	sizing, err = mongo.DesiredSizing(mongo.EnsureServerParams{
+		DataDir:       "/var/lib/juju",
+		MemoryProfile: mongo.MemoryProfileLow,
+		OplogSize:     2048,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sizing, jc.DeepEquals, mongo.Sizing{OplogSizeMB: 2048})
+}
+
+func (s *sizingSuite) TestDesiredSizingOtherProfiles(c *gc.C) {
+	for _, profile := range []mongo.MemoryProfile{mongo.MemoryProfileLow, mongo.MemoryProfileDefault} {
+		sizing, err := mongo.DesiredSizing(mongo.EnsureServerParams{
+			DataDir:       "/var/lib/juju",
+			MemoryProfile: profile,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(sizing, jc.DeepEquals, mongo.Sizing{})
+	}
+}
+
+func (s *sizingSuite) TestDesiredSizingSingleNode(c *gc.C) {
+	sizing, err := mongo.DesiredSizing(mongo.EnsureServerParams{
+		DataDir:       "/var/lib/juju",
+		MemoryProfile: mongo.MemoryProfileAuto,
+		SingleNode:    true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sizing, jc.DeepEquals, mongo.Sizing{CacheSizeMB: mongo.SingleNodeCacheSizeMB})
+}
+
+func (s *sizingSuite) TestDesiredSizingError(c *gc.C) {
+	s.PatchValue(mongo.TotalMemory, func() (int, error) {
+		return 0, errors.New("boom")
+	})
+	_, err := mongo.DesiredSizing(mongo.EnsureServerParams{
+		DataDir:       "/var/lib/juju",
+		MemoryProfile: mongo.MemoryProfileAuto,
+	})
+	c.Assert(err, gc.ErrorMatches, "cannot detect machine resources: boom")
+}
+
+func (s *sizingSuite) TestParseTotalMemory(c *gc.C) {
+	memoryMB, err := mongo.ParseTotalMemory(strings.NewReader(`
+MemTotal:        8167848 kB
+MemFree:          447364 kB
+`[1:]))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(memoryMB, gc.Equals, 7976)
+
+	_, err = mongo.ParseTotalMemory(strings.NewReader("MemFree: 447364 kB\n"))
+	c.Assert(err, gc.ErrorMatches, "could not determine total memory")
+}
@@ -461,6 +461,45 @@ func PrioritizeInternalHostPorts(hps []HostPort, machineLocal bool) []string {
 	return out
 }
 
+// SelectPublicAddressPreferringIPv6 is like SelectPublicAddress, but
+// prefers IPv6 addresses over IPv4 ones of the same scope.
+func SelectPublicAddressPreferringIPv6(addresses []Address) (Address, bool) {
+	index := bestAddressIndex(len(addresses), func(i int) Address {
+		return addresses[i]
+	}, preferringIPv6(publicMatch))
+	if index < 0 {
+		return Address{}, false
+	}
+	return addresses[index], true
+}
+
+// SelectInternalAddressPreferringIPv6 is like SelectInternalAddress,
+// but prefers IPv6 addresses over IPv4 ones of the same scope.
+func SelectInternalAddressPreferringIPv6(addresses []Address, machineLocal bool) (Address, bool) {
+	index := bestAddressIndex(len(addresses), func(i int) Address {
+		return addresses[i]
+	}, preferringIPv6(internalAddressMatcher(machineLocal)))
+	if index < 0 {
+		return Address{}, false
+	}
+	return addresses[index], true
+}
+
+// PrioritizeInternalHostPortsPreferringIPv6 is like
+// PrioritizeInternalHostPorts, but orders IPv6 addresses before IPv4
+// ones of the same scope.
+func PrioritizeInternalHostPortsPreferringIPv6(hps []HostPort, machineLocal bool) []string {
+	indexes := prioritizedAddressIndexes(len(hps), func(i int) Address {
+		return hps[i].Address
+	}, preferringIPv6(internalAddressMatcher(machineLocal)))
+
+	out := make([]string, 0, len(indexes))
+	for _, index := range indexes {
+		out = append(out, hps[index].NetAddr())
+	}
+	return out
+}
+
 func publicMatch(addr Address) scopeMatch {
 	switch addr.Scope {
 	case ScopePublic:
@@ -520,6 +559,36 @@ func cloudOrMachineLocalMatch(addr Address) scopeMatch {
 	return cloudLocalMatch(addr)
 }
 
+// preferringIPv6 returns a scopeMatchFunc that matches addresses by
+// scope as matchFunc does, but swaps the preference for IPv4 addresses
+// within each scope for a preference for IPv6 addresses.
+func preferringIPv6(matchFunc scopeMatchFunc) scopeMatchFunc {
+	return func(addr Address) scopeMatch {
+		match := matchFunc(addr)
+		switch addr.Type {
+		case IPv6Address:
+			switch match {
+			case exactScope:
+				return exactScopeIPv4
+			case firstFallbackScope:
+				return firstFallbackScopeIPv4
+			case secondFallbackScope:
+				return secondFallbackScopeIPv4
+			}
+		case IPv4Address:
+			switch match {
+			case exactScopeIPv4:
+				return exactScope
+			case firstFallbackScopeIPv4:
+				return firstFallbackScope
+			case secondFallbackScopeIPv4:
+				return secondFallbackScope
+			}
+		}
+		return match
+	}
+}
+
 type scopeMatch int
 
 const (
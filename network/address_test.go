@@ -640,6 +640,93 @@ func (s *AddressSuite) TestPrioritizeInternalHostPorts(c *gc.C) {
 	}
 }
 
+var selectPublicPreferringIPv6Tests = []selectTest{{
+	"no addresses gives empty string result",
+	[]network.Address{},
+	-1,
+}, {
+	"a public IPv4 address is selected if there is no IPv6 address",
+	[]network.Address{
+		network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+		network.NewScopedAddress("8.8.8.8", network.ScopePublic),
+	},
+	1,
+}, {
+	"a public IPv6 address is preferred to a public IPv4 address",
+	[]network.Address{
+		network.NewScopedAddress("8.8.8.8", network.ScopePublic),
+		network.NewScopedAddress("2001:db8::1", network.ScopePublic),
+	},
+	1,
+}, {
+	"a public IPv4 address is preferred to a cloud local IPv6 address",
+	[]network.Address{
+		network.NewScopedAddress("fc00::1", network.ScopeCloudLocal),
+		network.NewScopedAddress("8.8.8.8", network.ScopePublic),
+	},
+	1,
+}}
+
+func (s *AddressSuite) TestSelectPublicAddressPreferringIPv6(c *gc.C) {
+	for i, t := range selectPublicPreferringIPv6Tests {
+		c.Logf("test %d: %s", i, t.about)
+		expectAddr, expectOK := t.expected()
+		actualAddr, actualOK := network.SelectPublicAddressPreferringIPv6(t.addresses)
+		c.Check(actualOK, gc.Equals, expectOK)
+		c.Check(actualAddr, gc.Equals, expectAddr)
+	}
+}
+
+var selectInternalPreferringIPv6Tests = []selectTest{{
+	"no addresses gives empty string result",
+	[]network.Address{},
+	-1,
+}, {
+	"a cloud local IPv6 address is preferred to a cloud local IPv4 address",
+	[]network.Address{
+		network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+		network.NewScopedAddress("fc00::1", network.ScopeCloudLocal),
+	},
+	1,
+}, {
+	"a cloud local IPv4 address is preferred to a public IPv6 address",
+	[]network.Address{
+		network.NewScopedAddress("2001:db8::1", network.ScopePublic),
+		network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+	},
+	1,
+}, {
+	"a machine local or link-local address is not selected",
+	[]network.Address{
+		network.NewScopedAddress("::1", network.ScopeMachineLocal),
+		network.NewScopedAddress("fe80::1", network.ScopeLinkLocal),
+	},
+	-1,
+}}
+
+func (s *AddressSuite) TestSelectInternalAddressPreferringIPv6(c *gc.C) {
+	for i, t := range selectInternalPreferringIPv6Tests {
+		c.Logf("test %d: %s", i, t.about)
+		expectAddr, expectOK := t.expected()
+		actualAddr, actualOK := network.SelectInternalAddressPreferringIPv6(t.addresses, false)
+		c.Check(actualOK, gc.Equals, expectOK)
+		c.Check(actualAddr, gc.Equals, expectAddr)
+	}
+}
+
+func (s *AddressSuite) TestPrioritizeInternalHostPortsPreferringIPv6(c *gc.C) {
+	hps := []network.HostPort{
+		{network.NewScopedAddress("2001:db8::1", network.ScopePublic), 123},
+		{network.NewScopedAddress("fc00::1", network.ScopeCloudLocal), 123},
+		{network.NewScopedAddress("8.8.8.8", network.ScopePublic), 123},
+		{network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal), 4444},
+	}
+	prioritized := network.PrioritizeInternalHostPortsPreferringIPv6(hps, false)
+	c.Check(prioritized, gc.DeepEquals, []string{
+		"[fc00::1]:123", "10.0.0.1:4444", "[2001:db8::1]:123", "8.8.8.8:123",
+	})
+}
+
 var stringTests = []struct {
 	addr network.Address
 	str  string
@@ -4,13 +4,18 @@
 package azure
 
 import (
+	"context"
+
 	"github.com/Azure/azure-sdk-for-go/arm/resources/resources"
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/provider/azure/internal/armtemplates"
 )
 
+// createDeployment creates the deployment described by the template,
+// abandoning the request if ctx is cancelled.
 func createDeployment(
+	ctx context.Context,
 	client resources.DeploymentsClient,
 	resourceGroup string,
 	deploymentName string,
@@ -30,7 +35,7 @@ func createDeployment(
 		resourceGroup,
 		deploymentName,
 		deployment,
-		nil, // abort channel
+		ctx.Done(), // abort channel
 	)
 	if err := <-errChan; err != nil {
 		return errors.Annotatef(err, "creating deployment %q", deploymentName)
@@ -4,6 +4,7 @@
 package azure
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -304,6 +305,7 @@ func (env *azureEnviron) createCommonResourceDeployment(
 	)
 	template := armtemplates.Template{Resources: commonResources}
 	if err := createDeployment(
+		context.TODO(),
 		deploymentsClient,
 		env.resourceGroup,
 		"common", // deployment name
@@ -419,7 +421,7 @@ func (*azureEnviron) MaintainInstance(args environs.StartInstanceParams) error {
 }
 
 // StartInstance is specified in the InstanceBroker interface.
-func (env *azureEnviron) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+func (env *azureEnviron) StartInstance(ctx context.Context, args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
 	if args.ControllerUUID == "" {
 		return nil, errors.New("missing controller UUID")
 	}
@@ -521,12 +523,12 @@ func (env *azureEnviron) StartInstance(args environs.StartInstanceParams) (*envi
 	vmTags[jujuMachineNameTag] = vmName
 
 	if err := env.createVirtualMachine(
-		vmName, vmTags, envTags,
+		ctx, vmName, vmTags, envTags,
 		instanceSpec, args.InstanceConfig,
 		storageAccountType,
 	); err != nil {
 		logger.Errorf("creating instance failed, destroying: %v", err)
-		if err := env.StopInstances(instance.Id(vmName)); err != nil {
+		if err := env.StopInstances(ctx, instance.Id(vmName)); err != nil {
 			logger.Errorf("could not destroy failed virtual machine: %v", err)
 		}
 		return nil, errors.Annotatef(err, "creating virtual machine %q", vmName)
@@ -554,6 +556,7 @@ func (env *azureEnviron) StartInstance(args environs.StartInstanceParams) (*envi
 // All resources created are tagged with the specified "vmTags", so if
 // this function fails then all resources can be deleted by tag.
 func (env *azureEnviron) createVirtualMachine(
+	ctx context.Context,
 	vmName string,
 	vmTags, envTags map[string]string,
 	instanceSpec *instances.InstanceSpec,
@@ -789,6 +792,7 @@ func (env *azureEnviron) createVirtualMachine(
 		deploymentsClient.ResponseInspector,
 	)
 	if err := createDeployment(
+		ctx,
 		deploymentsClient,
 		env.resourceGroup,
 		vmName, // deployment name
@@ -1094,7 +1098,7 @@ func newOSProfile(
 }
 
 // StopInstances is specified in the InstanceBroker interface.
-func (env *azureEnviron) StopInstances(ids ...instance.Id) error {
+func (env *azureEnviron) StopInstances(ctx context.Context, ids ...instance.Id) error {
 	if len(ids) == 0 {
 		return nil
 	}
@@ -4,6 +4,7 @@
 package azure_test
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -517,7 +518,7 @@ func (s *environSuite) assertStartInstance(c *gc.C, wantedRootDisk *int) {
 			expectedDiskSize = *wantedRootDisk + 2
 		}
 	}
-	result, err := env.StartInstance(args)
+	result, err := env.StartInstance(context.Background(), args)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(result, gc.NotNil)
 	c.Assert(result.Instance, gc.NotNil)
@@ -551,7 +552,7 @@ func (s *environSuite) TestStartInstanceNoAuthorizedKeys(c *gc.C) {
 
 	s.sender = s.startInstanceSenders(false)
 	s.requests = nil
-	_, err = env.StartInstance(makeStartInstanceParams(c, s.controllerUUID, "quantal"))
+	_, err = env.StartInstance(context.Background(), makeStartInstanceParams(c, s.controllerUUID, "quantal"))
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.PatchValue(&s.sshPublicKeys, []compute.SSHPublicKey{{
@@ -590,7 +591,7 @@ func (s *environSuite) testStartInstanceWindows(
 	s.requests = nil
 	args := makeStartInstanceParams(c, s.controllerUUID, "win2012")
 	args.Constraints = cons
-	result, err := env.StartInstance(args)
+	result, err := env.StartInstance(context.Background(), args)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(result, gc.NotNil)
 	c.Assert(result.Hardware.RootDisk, jc.DeepEquals, &expect)
@@ -624,7 +625,7 @@ func (s *environSuite) TestStartInstanceCentOS(c *gc.C) {
 	s.sender = s.startInstanceSenders(false)
 	s.requests = nil
 	args := makeStartInstanceParams(c, s.controllerUUID, "centos7")
-	_, err := env.StartInstance(args)
+	_, err := env.StartInstance(context.Background(), args)
 	c.Assert(err, jc.ErrorIsNil)
 
 	vmExtensionSettings := map[string]interface{}{
@@ -657,7 +658,7 @@ func (s *environSuite) TestStartInstanceCommonDeployment(c *gc.C) {
 	s.sender = senders
 	s.requests = nil
 
-	_, err := env.StartInstance(makeStartInstanceParams(c, s.controllerUUID, "quantal"))
+	_, err := env.StartInstance(context.Background(), makeStartInstanceParams(c, s.controllerUUID, "quantal"))
 	c.Assert(err, gc.ErrorMatches,
 		`creating virtual machine "machine-0": `+
 			`waiting for common resources to be created: `+
@@ -679,7 +680,7 @@ func (s *environSuite) TestStartInstanceCommonDeploymentStorageAccount(c *gc.C)
 	s.sender = senders
 	s.requests = nil
 
-	_, err := env.StartInstance(makeStartInstanceParams(c, s.controllerUUID, "quantal"))
+	_, err := env.StartInstance(context.Background(), makeStartInstanceParams(c, s.controllerUUID, "quantal"))
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertStartInstanceRequests(c, s.requests[1:], assertStartInstanceRequestsParams{
 		imageReference:   &quantalImageReference,
@@ -707,7 +708,7 @@ func (s *environSuite) TestStartInstanceCommonDeploymentRetryTimeout(c *gc.C) {
 	s.sender = senders
 	s.requests = nil
 
-	_, err := env.StartInstance(makeStartInstanceParams(c, s.controllerUUID, "quantal"))
+	_, err := env.StartInstance(context.Background(), makeStartInstanceParams(c, s.controllerUUID, "quantal"))
 	c.Assert(err, gc.ErrorMatches,
 		`creating virtual machine "machine-0": `+
 			`waiting for common resources to be created: `+
@@ -731,7 +732,7 @@ func (s *environSuite) TestStartInstanceServiceAvailabilitySet(c *gc.C) {
 	params := makeStartInstanceParams(c, s.controllerUUID, "quantal")
 	params.InstanceConfig.Tags[tags.JujuUnitsDeployed] = unitsDeployed
 
-	_, err := env.StartInstance(params)
+	_, err := env.StartInstance(context.Background(), params)
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertStartInstanceRequests(c, s.requests, assertStartInstanceRequestsParams{
 		availabilitySetName: "mysql",
@@ -1199,7 +1200,7 @@ func (s *environSuite) TestStopInstancesNotFound(c *gc.C) {
 		"vm not found", http.StatusNotFound,
 	))
 	s.sender = azuretesting.Senders{sender0, sender1}
-	err := env.StopInstances("a", "b")
+	err := env.StopInstances(context.Background(), "a", "b")
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -1222,7 +1223,7 @@ func (s *environSuite) TestStopInstancesResourceGroupNotFound(c *gc.C) {
 		nsgSender, // GET with failure
 		s.makeSender(".*/deployments/machine-0", nil), // DELETE
 	}
-	err := env.StopInstances("machine-0")
+	err := env.StopInstances(context.Background(), "machine-0")
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -1260,7 +1261,7 @@ func (s *environSuite) TestStopInstances(c *gc.C) {
 	machine0Blob := azuretesting.MockStorageBlob{Name_: "machine-0"}
 	s.osvhdsContainer.Blobs_ = []azurestorage.Blob{&machine0Blob}
 
-	err := env.StopInstances("machine-0")
+	err := env.StopInstances(context.Background(), "machine-0")
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.storageClient.CheckCallNames(c, "NewClient", "GetContainerReference")
@@ -1292,7 +1293,7 @@ func (s *environSuite) TestStopInstancesMultiple(c *gc.C) {
 		vmDeleteSender0,
 		vmDeleteSender1,
 	}
-	err := env.StopInstances("machine-0", "machine-1")
+	err := env.StopInstances(context.Background(), "machine-0", "machine-1")
 	c.Assert(err, gc.ErrorMatches, `deleting instance "machine-[01]":.*blargh`)
 }
 
@@ -1304,7 +1305,7 @@ func (s *environSuite) TestStopInstancesDeploymentNotFound(c *gc.C) {
 		"deployment not found", http.StatusNotFound,
 	))
 	s.sender = azuretesting.Senders{cancelSender}
-	err := env.StopInstances("machine-0")
+	err := env.StopInstances(context.Background(), "machine-0")
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -1332,7 +1333,7 @@ func (s *environSuite) testStopInstancesStorageAccountNotFound(c *gc.C) {
 		s.makeSender(".*/networkSecurityGroups/juju-internal-nsg", makeSecurityGroup()), // GET: no rules
 		s.makeSender(".*/deployments/machine-0", nil),                                   // DELETE
 	}
-	err := env.StopInstances("machine-0")
+	err := env.StopInstances(context.Background(), "machine-0")
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -1344,7 +1345,7 @@ func (s *environSuite) TestStopInstancesStorageAccountError(c *gc.C) {
 		s.makeSender("/deployments/machine-0", s.deployment), // Cancel
 		errorSender,
 	}
-	err := env.StopInstances("machine-0")
+	err := env.StopInstances(context.Background(), "machine-0")
 	c.Assert(err, gc.ErrorMatches, "getting storage account:.*blargh")
 }
 
@@ -1357,7 +1358,7 @@ func (s *environSuite) TestStopInstancesStorageAccountKeysError(c *gc.C) {
 		s.storageAccountSender(),
 		errorSender,
 	}
-	err := env.StopInstances("machine-0")
+	err := env.StopInstances(context.Background(), "machine-0")
 	c.Assert(err, gc.ErrorMatches, "getting storage account key:.*blargh")
 }
 
@@ -4,6 +4,7 @@
 package azure
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
@@ -195,7 +196,7 @@ func (inst *azureInstance) primaryNetworkAddress() (jujunetwork.Address, error)
 }
 
 // OpenPorts is specified in the Instance interface.
-func (inst *azureInstance) OpenPorts(machineId string, rules []jujunetwork.IngressRule) error {
+func (inst *azureInstance) OpenPorts(ctx context.Context, machineId string, rules []jujunetwork.IngressRule) error {
 	nsgClient := network.SecurityGroupsClient{inst.env.network}
 	securityRuleClient := network.SecurityRulesClient{inst.env.network}
 	primaryNetworkAddress, err := inst.primaryNetworkAddress()
@@ -290,7 +291,7 @@ func (inst *azureInstance) OpenPorts(machineId string, rules []jujunetwork.Ingre
 }
 
 // ClosePorts is specified in the Instance interface.
-func (inst *azureInstance) ClosePorts(machineId string, rules []jujunetwork.IngressRule) error {
+func (inst *azureInstance) ClosePorts(ctx context.Context, machineId string, rules []jujunetwork.IngressRule) error {
 	securityRuleClient := network.SecurityRulesClient{inst.env.network}
 	securityGroupName := internalSecurityGroupName
 
@@ -316,7 +317,7 @@ func (inst *azureInstance) ClosePorts(machineId string, rules []jujunetwork.Ingr
 }
 
 // IngressRules is specified in the Instance interface.
-func (inst *azureInstance) IngressRules(machineId string) (rules []jujunetwork.IngressRule, err error) {
+func (inst *azureInstance) IngressRules(ctx context.Context, machineId string) (rules []jujunetwork.IngressRule, err error) {
 	nsgClient := network.SecurityGroupsClient{inst.env.network}
 	securityGroupName := internalSecurityGroupName
 	nsg, err := nsgClient.Get(inst.env.resourceGroup, securityGroupName, "")
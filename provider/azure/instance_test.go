@@ -4,6 +4,7 @@
 package azure_test
 
 import (
+	"context"
 	"net/http"
 	"path"
 
@@ -269,7 +270,7 @@ func (s *instanceSuite) TestIngressRulesEmpty(c *gc.C) {
 	c.Assert(ok, gc.Equals, true)
 	nsgSender := networkSecurityGroupSender(nil)
 	s.sender = azuretesting.Senders{nsgSender}
-	rules, err := fwInst.IngressRules("0")
+	rules, err := fwInst.IngressRules(context.Background(), "0")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(rules, gc.HasLen, 0)
 }
@@ -365,7 +366,7 @@ func (s *instanceSuite) TestIngressRules(c *gc.C) {
 	fwInst, ok := inst.(instance.InstanceFirewaller)
 	c.Assert(ok, gc.Equals, true)
 
-	rules, err := fwInst.IngressRules("0")
+	rules, err := fwInst.IngressRules(context.Background(), "0")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(rules, jc.DeepEquals, []jujunetwork.IngressRule{
 		jujunetwork.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
@@ -387,7 +388,7 @@ func (s *instanceSuite) TestInstanceClosePorts(c *gc.C) {
 	))
 	s.sender = azuretesting.Senders{sender, notFoundSender, notFoundSender, notFoundSender}
 
-	err := fwInst.ClosePorts("0", []jujunetwork.IngressRule{
+	err := fwInst.ClosePorts(context.Background(), "0", []jujunetwork.IngressRule{
 		jujunetwork.MustNewIngressRule("tcp", 1000, 1000),
 		jujunetwork.MustNewIngressRule("udp", 1000, 2000),
 		jujunetwork.MustNewIngressRule("udp", 1000, 2000, "192.168.1.0/24", "10.0.0.0/24"),
@@ -433,7 +434,7 @@ func (s *instanceSuite) TestInstanceOpenPorts(c *gc.C) {
 	nsgSender := networkSecurityGroupSender(nil)
 	s.sender = azuretesting.Senders{nsgSender, okSender, okSender, okSender, okSender}
 
-	err := fwInst.OpenPorts("0", []jujunetwork.IngressRule{
+	err := fwInst.OpenPorts(context.Background(), "0", []jujunetwork.IngressRule{
 		jujunetwork.MustNewIngressRule("tcp", 1000, 1000),
 		jujunetwork.MustNewIngressRule("udp", 1000, 2000),
 		jujunetwork.MustNewIngressRule("tcp", 1000, 2000, "192.168.1.0/24", "10.0.0.0/24"),
@@ -542,7 +543,7 @@ func (s *instanceSuite) TestInstanceOpenPortsAlreadyOpen(c *gc.C) {
 	}})
 	s.sender = azuretesting.Senders{nsgSender, okSender, okSender}
 
-	err := fwInst.OpenPorts("0", []jujunetwork.IngressRule{
+	err := fwInst.OpenPorts(context.Background(), "0", []jujunetwork.IngressRule{
 		jujunetwork.MustNewIngressRule("tcp", 1000, 1000),
 		jujunetwork.MustNewIngressRule("udp", 1000, 2000),
 	})
@@ -572,7 +573,7 @@ func (s *instanceSuite) TestInstanceOpenPortsNoInternalAddress(c *gc.C) {
 	inst := s.getInstance(c)
 	fwInst, ok := inst.(instance.InstanceFirewaller)
 	c.Assert(ok, gc.Equals, true)
-	err := fwInst.OpenPorts("0", nil)
+	err := fwInst.OpenPorts(context.Background(), "0", nil)
 	c.Assert(err, gc.ErrorMatches, "internal network address not found")
 }
 
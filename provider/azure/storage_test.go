@@ -4,6 +4,7 @@
 package azure_test
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -195,7 +196,7 @@ func (s *storageSuite) TestCreateVolumes(c *gc.C) {
 		makeSender("volume-2", 1),
 	}
 
-	results, err := volumeSource.CreateVolumes(params)
+	results, err := volumeSource.CreateVolumes(context.Background(), params)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, gc.HasLen, len(params))
 	c.Check(results[0].Error, jc.ErrorIsNil)
@@ -320,7 +321,7 @@ func (s *storageSuite) TestCreateVolumesLegacy(c *gc.C) {
 		updateVirtualMachine1Sender,
 	}
 
-	results, err := volumeSource.CreateVolumes(params)
+	results, err := volumeSource.CreateVolumes(context.Background(), params)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, gc.HasLen, len(params))
 
@@ -417,7 +418,7 @@ func (s *storageSuite) TestListVolumes(c *gc.C) {
 	volumeSender.PathPattern = `.*/Microsoft\.Compute/disks`
 	s.sender = azuretesting.Senders{volumeSender}
 
-	volumeIds, err := volumeSource.ListVolumes()
+	volumeIds, err := volumeSource.ListVolumes(context.Background())
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(volumeIds, jc.SameContents, []string{"volume-0", "volume-1"})
 }
@@ -444,7 +445,7 @@ func (s *storageSuite) TestListVolumesLegacy(c *gc.C) {
 	s.datavhdsContainer.Blobs_ = []internalazurestorage.Blob{blob1, blob0, junkBlob, volumeBlob}
 
 	volumeSource := s.volumeSource(c, true)
-	volumeIds, err := volumeSource.ListVolumes()
+	volumeIds, err := volumeSource.ListVolumes(context.Background())
 	c.Assert(err, jc.ErrorIsNil)
 	s.storageClient.CheckCallNames(c, "NewClient", "GetContainerReference")
 	s.storageClient.CheckCall(
@@ -461,14 +462,14 @@ func (s *storageSuite) TestListVolumesErrors(c *gc.C) {
 	sender := mocks.NewSender()
 	sender.SetError(errors.New("no disks for you"))
 	s.sender = azuretesting.Senders{sender}
-	_, err := volumeSource.ListVolumes()
+	_, err := volumeSource.ListVolumes(context.Background())
 	c.Assert(err, gc.ErrorMatches, "listing disks: .*: no disks for you")
 }
 
 func (s *storageSuite) TestListVolumesErrorsLegacy(c *gc.C) {
 	volumeSource := s.volumeSource(c, true)
 	s.datavhdsContainer.SetErrors(errors.New("no blobs for you"))
-	_, err := volumeSource.ListVolumes()
+	_, err := volumeSource.ListVolumes(context.Background())
 	c.Assert(err, gc.ErrorMatches, "listing volumes: listing blobs: no blobs for you")
 }
 
@@ -482,7 +483,7 @@ func (s *storageSuite) TestDescribeVolumes(c *gc.C) {
 	volumeSender.PathPattern = `.*/Microsoft\.Compute/disks/volume-0`
 	s.sender = azuretesting.Senders{volumeSender}
 
-	results, err := volumeSource.DescribeVolumes([]string{"volume-0"})
+	results, err := volumeSource.DescribeVolumes(context.Background(), []string{"volume-0"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, jc.DeepEquals, []storage.DescribeVolumesResult{{
 		VolumeInfo: &storage.VolumeInfo{
@@ -503,7 +504,7 @@ func (s *storageSuite) TestDescribeVolumesNotFound(c *gc.C) {
 	)
 	volumeSender.AppendResponse(response)
 	s.sender = azuretesting.Senders{volumeSender}
-	results, err := volumeSource.DescribeVolumes([]string{"volume-42"})
+	results, err := volumeSource.DescribeVolumes(context.Background(), []string{"volume-42"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, gc.HasLen, 1)
 	c.Assert(results[0].Error, jc.Satisfies, errors.IsNotFound)
@@ -526,7 +527,7 @@ func (s *storageSuite) TestDescribeVolumesLegacy(c *gc.C) {
 	s.datavhdsContainer.Blobs_ = []internalazurestorage.Blob{blob1, blob0}
 
 	volumeSource := s.volumeSource(c, true)
-	results, err := volumeSource.DescribeVolumes([]string{"volume-0", "volume-1", "volume-0", "volume-42"})
+	results, err := volumeSource.DescribeVolumes(context.Background(), []string{"volume-0", "volume-1", "volume-0", "volume-42"})
 	c.Assert(err, jc.ErrorIsNil)
 	s.storageClient.CheckCallNames(c, "NewClient", "GetContainerReference")
 	s.storageClient.CheckCall(
@@ -563,7 +564,7 @@ func (s *storageSuite) TestDestroyVolumes(c *gc.C) {
 	volume0Sender.PathPattern = `.*/Microsoft\.Compute/disks/volume-0`
 	s.sender = azuretesting.Senders{volume0Sender}
 
-	results, err := volumeSource.DestroyVolumes([]string{"volume-0"})
+	results, err := volumeSource.DestroyVolumes(context.Background(), []string{"volume-0"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, gc.HasLen, 1)
 	c.Assert(results[0], jc.ErrorIsNil)
@@ -578,7 +579,7 @@ func (s *storageSuite) TestDestroyVolumesNotFound(c *gc.C) {
 	))
 	s.sender = azuretesting.Senders{volume42Sender}
 
-	results, err := volumeSource.DestroyVolumes([]string{"volume-42"})
+	results, err := volumeSource.DestroyVolumes(context.Background(), []string{"volume-42"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, gc.HasLen, 1)
 	c.Assert(results[0], jc.ErrorIsNil)
@@ -594,7 +595,7 @@ func (s *storageSuite) TestDestroyVolumesLegacy(c *gc.C) {
 	s.datavhdsContainer.Blobs_ = []internalazurestorage.Blob{blob0, blob1}
 
 	volumeSource := s.volumeSource(c, true)
-	results, err := volumeSource.DestroyVolumes([]string{"volume-0", "volume-42"})
+	results, err := volumeSource.DestroyVolumes(context.Background(), []string{"volume-0", "volume-42"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, gc.HasLen, 2)
 	c.Assert(results[0], jc.ErrorIsNil)
@@ -694,7 +695,7 @@ func (s *storageSuite) testAttachVolumes(c *gc.C, legacy bool) {
 		updateVirtualMachine0Sender,
 	}
 
-	results, err := volumeSource.AttachVolumes(params)
+	results, err := volumeSource.AttachVolumes(context.Background(), params)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, gc.HasLen, len(params))
 
@@ -813,7 +814,7 @@ func (s *storageSuite) testDetachVolumes(c *gc.C, legacy bool) {
 		updateVirtualMachine0Sender,
 	}
 
-	results, err := volumeSource.DetachVolumes(params)
+	results, err := volumeSource.DetachVolumes(context.Background(), params)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, gc.HasLen, len(params))
 
@@ -880,7 +881,7 @@ func (s *storageSuite) TestDetachVolumesFinal(c *gc.C) {
 		updateVirtualMachine0Sender,
 	}
 
-	results, err := volumeSource.DetachVolumes(params)
+	results, err := volumeSource.DetachVolumes(context.Background(), params)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, gc.HasLen, len(params))
 	c.Assert(results[0], jc.ErrorIsNil)
@@ -93,6 +93,11 @@ func (env *environ) Bootstrap(ctx environs.BootstrapContext, params environs.Boo
 	return common.Bootstrap(ctx, env, params)
 }
 
+// ResumeBootstrap is part of the environs.BootstrapResumer interface.
+func (env *environ) ResumeBootstrap(ctx environs.BootstrapContext, id instance.Id, params environs.BootstrapParams) (*environs.BootstrapResult, error) {
+	return common.ResumeBootstrap(ctx, env, id, params)
+}
+
 // ControllerInstances is part of the Environ interface.
 func (e *environ) ControllerInstances(controllerUUID string) ([]instance.Id, error) {
 	return e.client.getControllerIds()
@@ -4,6 +4,8 @@
 package cloudsigma
 
 import (
+	"context"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 
@@ -38,7 +40,7 @@ func (*environ) MaintainInstance(args environs.StartInstanceParams) error {
 // state for the new instance to connect to. The config MachineNonce, which must be
 // unique within an environment, is used by juju to protect against the
 // consequences of multiple instances being started with the same machine id.
-func (env *environ) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+func (env *environ) StartInstance(ctx context.Context, args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
 	logger.Infof("sigmaEnviron.StartInstance...")
 
 	if args.InstanceConfig == nil {
@@ -163,7 +165,7 @@ func (env *environ) Instances(ids []instance.Id) ([]instance.Instance, error) {
 }
 
 // StopInstances shuts down the given instances.
-func (env *environ) StopInstances(instances ...instance.Id) error {
+func (env *environ) StopInstances(ctx context.Context, instances ...instance.Id) error {
 	logger.Debugf("stop instances %+v", instances)
 
 	var err error
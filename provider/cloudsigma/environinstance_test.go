@@ -4,6 +4,7 @@
 package cloudsigma
 
 import (
+	"context"
 	"time"
 
 	"github.com/altoros/gosigma/mock"
@@ -119,7 +120,7 @@ func (s *environInstanceSuite) TestInstances(c *gc.C) {
 	c.Check(instances[1], gc.NotNil)
 	c.Check(instances[2], gc.IsNil)
 
-	err = env.StopInstances(ids...)
+	err = env.StopInstances(context.Background(), ids...)
 	c.Assert(err, gc.ErrorMatches, "404 Not Found.*")
 
 	instances, err = env.Instances(ids)
@@ -157,7 +158,7 @@ func (s *environInstanceSuite) TestInstancesFail(c *gc.C) {
 func (s *environInstanceSuite) TestStartInstanceError(c *gc.C) {
 	environ := s.createEnviron(c, nil)
 
-	res, err := environ.StartInstance(environs.StartInstanceParams{})
+	res, err := environ.StartInstance(context.Background(), environs.StartInstanceParams{})
 	c.Check(res, gc.IsNil)
 	c.Check(err, gc.ErrorMatches, "instance configuration is nil")
 
@@ -168,7 +169,7 @@ func (s *environInstanceSuite) TestStartInstanceError(c *gc.C) {
 		URL: "https://0.1.2.3:2000/x.y.z.tgz",
 	}
 
-	res, err = environ.StartInstance(environs.StartInstanceParams{
+	res, err = environ.StartInstance(context.Background(), environs.StartInstanceParams{
 		InstanceConfig: &instancecfg.InstanceConfig{},
 	})
 	c.Check(res, gc.IsNil)
@@ -177,7 +178,7 @@ func (s *environInstanceSuite) TestStartInstanceError(c *gc.C) {
 	icfg := &instancecfg.InstanceConfig{}
 	err = icfg.SetTools(tools.List{toolsVal})
 	c.Assert(err, jc.ErrorIsNil)
-	res, err = environ.StartInstance(environs.StartInstanceParams{
+	res, err = environ.StartInstance(context.Background(), environs.StartInstanceParams{
 		Tools:          tools.List{toolsVal},
 		InstanceConfig: icfg,
 	})
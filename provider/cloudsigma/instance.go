@@ -4,6 +4,8 @@
 package cloudsigma
 
 import (
+	"context"
+
 	"github.com/altoros/gosigma"
 	"github.com/juju/errors"
 
@@ -75,20 +77,20 @@ func (i sigmaInstance) Addresses() ([]network.Address, error) {
 
 // OpenPorts opens the given ports on the instance, which
 // should have been started with the given machine id.
-func (i sigmaInstance) OpenPorts(machineID string, ports []network.IngressRule) error {
+func (i sigmaInstance) OpenPorts(ctx context.Context, machineID string, ports []network.IngressRule) error {
 	return errors.NotImplementedf("OpenPorts")
 }
 
 // ClosePorts closes the given ports on the instance, which
 // should have been started with the given machine id.
-func (i sigmaInstance) ClosePorts(machineID string, ports []network.IngressRule) error {
+func (i sigmaInstance) ClosePorts(ctx context.Context, machineID string, ports []network.IngressRule) error {
 	return errors.NotImplementedf("ClosePorts")
 }
 
 // IngressRules returns the set of ports open on the instance, which
 // should have been started with the given machine id.
 // The rules are returned as sorted by SortInstanceRules.
-func (i sigmaInstance) IngressRules(machineID string) ([]network.IngressRule, error) {
+func (i sigmaInstance) IngressRules(ctx context.Context, machineID string) ([]network.IngressRule, error) {
 	return nil, errors.NotImplementedf("InstanceRules")
 }
 
@@ -4,6 +4,7 @@
 package cloudsigma
 
 import (
+	"context"
 	"strings"
 
 	"github.com/altoros/gosigma"
@@ -89,10 +90,10 @@ func (s *instanceSuite) TestInstanceAddresses(c *gc.C) {
 }
 
 func (s *instanceSuite) TestIngressRules(c *gc.C) {
-	c.Check(s.inst.OpenPorts("", nil), gc.ErrorMatches, "OpenPorts not implemented")
-	c.Check(s.inst.ClosePorts("", nil), gc.ErrorMatches, "ClosePorts not implemented")
+	c.Check(s.inst.OpenPorts(context.Background(), "", nil), gc.ErrorMatches, "OpenPorts not implemented")
+	c.Check(s.inst.ClosePorts(context.Background(), "", nil), gc.ErrorMatches, "ClosePorts not implemented")
 
-	_, err := s.inst.IngressRules("")
+	_, err := s.inst.IngressRules(context.Background(), "")
 	c.Check(err, gc.ErrorMatches, "InstanceRules not implemented")
 }
 
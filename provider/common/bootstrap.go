@@ -45,19 +45,58 @@ var logger = loggo.GetLogger("juju.provider.common")
 // when writing a new provider.
 func Bootstrap(ctx environs.BootstrapContext, env environs.Environ, args environs.BootstrapParams,
 ) (*environs.BootstrapResult, error) {
-	result, series, finalizer, err := BootstrapInstance(ctx, env, args)
+	result, series, hostKeys, finalizer, err := bootstrapInstance(ctx, env, args)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 
 	bsResult := &environs.BootstrapResult{
-		Arch:     *result.Hardware.Arch,
-		Series:   series,
-		Finalize: finalizer,
+		Arch:               *result.Hardware.Arch,
+		Series:             series,
+		InstanceId:         result.Instance.Id(),
+		InitialSSHHostKeys: hostKeys,
+		Finalize:           finalizer,
 	}
 	return bsResult, nil
 }
 
+// ResumeBootstrap is a common implementation of the ResumeBootstrap
+// method defined on environs.BootstrapResumer. It returns a result
+// whose finalizer completes the bootstrap process on the existing
+// instance with the specified ID.
+//
+// The bootstrap series must be specified in args, and args.AvailableTools
+// must hold only tools for the instance's architecture.
+func ResumeBootstrap(
+	ctx environs.BootstrapContext,
+	env environs.Environ,
+	id instance.Id,
+	args environs.BootstrapParams,
+) (*environs.BootstrapResult, error) {
+	if args.BootstrapSeries == "" {
+		return nil, errors.NotValidf("resuming bootstrap without series")
+	}
+	arches := args.AvailableTools.Arches()
+	if len(arches) != 1 {
+		return nil, errors.Errorf("expected tools for one architecture, got %v", arches)
+	}
+	insts, err := env.Instances([]instance.Id{id})
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot find bootstrap instance %q", id)
+	}
+	ctx.Infof("Resuming bootstrap of controller instance %s", id)
+	finalize := bootstrapFinalizer(
+		env, insts[0], nil, nil, args.InitialSSHHostKeys, args.ContainerBridgeName,
+	)
+	return &environs.BootstrapResult{
+		Arch:               arches[0],
+		Series:             args.BootstrapSeries,
+		InstanceId:         id,
+		InitialSSHHostKeys: args.InitialSSHHostKeys,
+		Finalize:           finalize,
+	}, nil
+}
+
 // BootstrapInstance creates a new instance with the series of its choice,
 // constrained to those of the available tools, and
 // returns the instance result, series, and a function that
@@ -67,6 +106,13 @@ func Bootstrap(ctx environs.BootstrapContext, env environs.Environ, args environ
 // is also exported so that providers can manipulate the started instance.
 func BootstrapInstance(ctx environs.BootstrapContext, env environs.Environ, args environs.BootstrapParams,
 ) (_ *environs.StartInstanceResult, selectedSeries string, _ environs.BootstrapFinalizer, err error) {
+	result, selectedSeries, _, finalize, err := bootstrapInstance(ctx, env, args)
+	return result, selectedSeries, finalize, err
+}
+
+func bootstrapInstance(ctx environs.BootstrapContext, env environs.Environ, args environs.BootstrapParams,
+) (_ *environs.StartInstanceResult, selectedSeries string, _ instancecfg.SSHHostKeys, _ environs.BootstrapFinalizer, err error) {
+	var noKeys instancecfg.SSHHostKeys
 	// TODO make safe in the case of racing Bootstraps
 	// If two Bootstraps are called concurrently, there's
 	// no way to make sure that only one succeeds.
@@ -81,14 +127,14 @@ func BootstrapInstance(ctx environs.BootstrapContext, env environs.Environ, args
 		Series: selectedSeries,
 	})
 	if err != nil {
-		return nil, "", nil, err
+		return nil, "", noKeys, nil, err
 	}
 
 	// Filter image metadata to the selected series.
 	var imageMetadata []*imagemetadata.ImageMetadata
 	seriesVersion, err := series.SeriesVersion(selectedSeries)
 	if err != nil {
-		return nil, "", nil, errors.Trace(err)
+		return nil, "", noKeys, nil, errors.Trace(err)
 	}
 	for _, m := range args.ImageMetadata {
 		if m.Version != seriesVersion {
@@ -103,44 +149,32 @@ func BootstrapInstance(ctx environs.BootstrapContext, env environs.Environ, args
 	if client == nil {
 		// This should never happen: if we don't have OpenSSH, then
 		// go.crypto/ssh should be used with an auto-generated key.
-		return nil, "", nil, fmt.Errorf("no SSH client available")
+		return nil, "", noKeys, nil, fmt.Errorf("no SSH client available")
 	}
 
 	publicKey, err := simplestreams.UserPublicSigningKey()
 	if err != nil {
-		return nil, "", nil, err
+		return nil, "", noKeys, nil, err
 	}
 	envCfg := env.Config()
 	instanceConfig, err := instancecfg.NewBootstrapInstanceConfig(
 		args.ControllerConfig, args.BootstrapConstraints, args.ModelConstraints, selectedSeries, publicKey,
 	)
 	if err != nil {
-		return nil, "", nil, err
+		return nil, "", noKeys, nil, err
 	}
 	instanceConfig.EnableOSRefreshUpdate = env.Config().EnableOSRefreshUpdate()
 	instanceConfig.EnableOSUpgrade = env.Config().EnableOSUpgrade()
 	instanceConfig.NetBondReconfigureDelay = env.Config().NetBondReconfigureDelay()
 
 	instanceConfig.Tags = instancecfg.InstanceTags(envCfg.UUID(), args.ControllerConfig.ControllerUUID(), envCfg, instanceConfig.Jobs)
-	maybeSetBridge := func(icfg *instancecfg.InstanceConfig) {
-		// If we need to override the default bridge name, do it now. When
-		// args.ContainerBridgeName is empty, the default names for LXC
-		// (lxcbr0) and KVM (virbr0) will be used.
-		if args.ContainerBridgeName != "" {
-			logger.Debugf("using %q as network bridge for all container types", args.ContainerBridgeName)
-			if icfg.AgentEnvironment == nil {
-				icfg.AgentEnvironment = make(map[string]string)
-			}
-			icfg.AgentEnvironment[agent.LxcBridge] = args.ContainerBridgeName
-		}
-	}
-	maybeSetBridge(instanceConfig)
+	maybeSetBridge(instanceConfig, args.ContainerBridgeName)
 
 	// We're creating a new instance; inject host keys so that we can then
 	// make an SSH connection with known keys.
 	initialSSHHostKeys, err := generateSSHHostKeys()
 	if err != nil {
-		return nil, "", nil, errors.Annotate(err, "generating SSH host keys")
+		return nil, "", noKeys, nil, errors.Annotate(err, "generating SSH host keys")
 	}
 	instanceConfig.Bootstrap.InitialSSHHostKeys = initialSSHHostKeys
 
@@ -188,18 +222,20 @@ func BootstrapInstance(ctx environs.BootstrapContext, env environs.Environ, args
 		// a blank StartInstanceParams.AvailabilityZone.
 		zones = []string{""}
 	} else if err != nil {
-		return nil, "", nil, errors.Annotate(err, "cannot start bootstrap instance")
+		return nil, "", noKeys, nil, errors.Annotate(err, "cannot start bootstrap instance")
 	}
 
 	var result *environs.StartInstanceResult
 	for i, zone := range zones {
 		startInstanceArgs.AvailabilityZone = zone
-		result, err = env.StartInstance(startInstanceArgs)
+		result, err = env.StartInstance(ctx.Context(), startInstanceArgs)
 		if err == nil {
 			break
 		}
-		if zone == "" || environs.IsAvailabilityZoneIndependent(err) {
-			return nil, "", nil, errors.Annotate(err, "cannot start bootstrap instance")
+		if zone == "" || environs.IsAvailabilityZoneIndependent(err) || ctx.Context().Err() != nil {
+			// There's no point trying other zones if the error is
+			// not zone-specific, or bootstrap has been interrupted.
+			return nil, "", noKeys, nil, errors.Annotate(err, "cannot start bootstrap instance")
 		}
 		if i < len(zones)-1 {
 			// Try the next zone.
@@ -208,12 +244,12 @@ func BootstrapInstance(ctx environs.BootstrapContext, env environs.Environ, args
 		}
 		// This is the last zone in the list, error.
 		if len(zones) > 1 {
-			return nil, "", nil, errors.Errorf(
+			return nil, "", noKeys, nil, errors.Errorf(
 				"cannot start bootstrap instance in any availability zone (%s)",
 				strings.Join(zones, ", "),
 			)
 		}
-		return nil, "", nil, errors.Annotatef(err, "cannot start bootstrap instance in availability zone %q", zone)
+		return nil, "", noKeys, nil, errors.Annotatef(err, "cannot start bootstrap instance in availability zone %q", zone)
 	}
 
 	msg := fmt.Sprintf(" - %s (%s)", result.Instance.Id(), formatHardware(result.Hardware))
@@ -224,13 +260,35 @@ func BootstrapInstance(ctx environs.BootstrapContext, env environs.Environ, args
 	}
 	ctx.Infof(msg)
 
-	finalize := func(ctx environs.BootstrapContext, icfg *instancecfg.InstanceConfig, opts environs.BootstrapDialOpts) error {
-		icfg.Bootstrap.BootstrapMachineInstanceId = result.Instance.Id()
-		icfg.Bootstrap.BootstrapMachineHardwareCharacteristics = result.Hardware
+	finalize := bootstrapFinalizer(
+		env, result.Instance, result.Hardware, result.Config,
+		initialSSHHostKeys, args.ContainerBridgeName,
+	)
+	return result, selectedSeries, initialSSHHostKeys, finalize, nil
+}
+
+// bootstrapFinalizer returns a function that completes the bootstrap
+// process on the given instance, by connecting to it via SSH and
+// carrying out the cloud-config.
+func bootstrapFinalizer(
+	env environs.Environ,
+	inst instance.Instance,
+	hardware *instance.HardwareCharacteristics,
+	extraConfig *config.Config,
+	initialSSHHostKeys instancecfg.SSHHostKeys,
+	containerBridgeName string,
+) environs.BootstrapFinalizer {
+	client := ssh.DefaultClient
+	return func(ctx environs.BootstrapContext, icfg *instancecfg.InstanceConfig, opts environs.BootstrapDialOpts) error {
+		if client == nil {
+			return errors.New("no SSH client available")
+		}
+		icfg.Bootstrap.BootstrapMachineInstanceId = inst.Id()
+		icfg.Bootstrap.BootstrapMachineHardwareCharacteristics = hardware
 		icfg.Bootstrap.InitialSSHHostKeys = initialSSHHostKeys
 		envConfig := env.Config()
-		if result.Config != nil {
-			updated, err := envConfig.Apply(result.Config.UnknownAttrs())
+		if extraConfig != nil {
+			updated, err := envConfig.Apply(extraConfig.UnknownAttrs())
 			if err != nil {
 				return errors.Trace(err)
 			}
@@ -239,10 +297,23 @@ func BootstrapInstance(ctx environs.BootstrapContext, env environs.Environ, args
 		if err := instancecfg.FinishInstanceConfig(icfg, envConfig); err != nil {
 			return err
 		}
-		maybeSetBridge(icfg)
-		return FinishBootstrap(ctx, client, env, result.Instance, icfg, opts)
+		maybeSetBridge(icfg, containerBridgeName)
+		return FinishBootstrap(ctx, client, env, inst, icfg, opts)
+	}
+}
+
+// maybeSetBridge overrides the default bridge name in the given
+// instance config, if a bridge name is specified. When bridgeName is
+// empty, the default names for LXC (lxcbr0) and KVM (virbr0) will be
+// used.
+func maybeSetBridge(icfg *instancecfg.InstanceConfig, bridgeName string) {
+	if bridgeName != "" {
+		logger.Debugf("using %q as network bridge for all container types", bridgeName)
+		if icfg.AgentEnvironment == nil {
+			icfg.AgentEnvironment = make(map[string]string)
+		}
+		icfg.AgentEnvironment[agent.LxcBridge] = bridgeName
 	}
-	return result, selectedSeries, finalize, nil
 }
 
 func startInstanceZones(env environs.Environ, args environs.StartInstanceParams) ([]string, error) {
@@ -397,7 +468,19 @@ func ConfigureMachine(
 		return err
 	}
 	script := shell.DumpFileOnErrorScript(instanceConfig.CloudInitOutputLog) + configScript
-	ctx.Infof("Running machine configuration script...")
+	if instanceConfig.Bootstrap != nil && instanceConfig.Bootstrap.ControllerContainerType != "" {
+		// The controller shares the machine with other services,
+		// so configure it within a container on the machine.
+		script, err = controllerContainerScript(script, instanceConfig)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ctx.Infof("Running machine configuration script in %s container %q...",
+			instanceConfig.Bootstrap.ControllerContainerType, ControllerContainerName,
+		)
+	} else {
+		ctx.Infof("Running machine configuration script...")
+	}
 	return sshinit.RunConfigureScript(script, sshinit.ConfigureParams{
 		Host:           "ubuntu@" + host,
 		Client:         client,
@@ -4,6 +4,7 @@
 package common_test
 
 import (
+	"context"
 	"crypto/rsa"
 	"fmt"
 	"io/ioutil"
@@ -302,6 +303,46 @@ func (s *BootstrapSuite) TestStartInstanceStopOnZoneIndependentError(c *gc.C) {
 	c.Assert(callZones, jc.SameContents, []string{"z0"})
 }
 
+func (s *BootstrapSuite) TestStartInstanceStopOnCancel(c *gc.C) {
+	s.PatchValue(&jujuversion.Current, coretesting.FakeVersionNumber)
+	env := &mockZonedEnviron{
+		mockEnviron: mockEnviron{
+			storage: newStorage(s, c),
+			config:  configGetter(c),
+		},
+		deriveAvailabilityZones: func(environs.StartInstanceParams) ([]string, error) {
+			return nil, nil
+		},
+		availabilityZones: func() ([]common.AvailabilityZone, error) {
+			z0 := &mockAvailabilityZone{"z0", true}
+			z1 := &mockAvailabilityZone{"z1", true}
+			return []common.AvailabilityZone{z0, z1}, nil
+		},
+	}
+
+	stdctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var callZones []string
+	env.startInstance = func(args environs.StartInstanceParams) (
+		instance.Instance,
+		*instance.HardwareCharacteristics,
+		[]network.InterfaceInfo,
+		error,
+	) {
+		callZones = append(callZones, args.AvailabilityZone)
+		cancel()
+		return nil, nil, nil, stdctx.Err()
+	}
+
+	ctx := modelcmd.CancellableBootstrapContext(stdctx, cmdtesting.Context(c))
+	_, err := common.Bootstrap(ctx, env, environs.BootstrapParams{
+		ControllerConfig: coretesting.FakeControllerConfig(),
+		AvailableTools:   fakeAvailableTools(),
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot start bootstrap instance: context canceled`)
+	c.Assert(callZones, jc.SameContents, []string{"z0"})
+}
+
 func (s *BootstrapSuite) TestStartInstanceNoUsableZones(c *gc.C) {
 	s.PatchValue(&jujuversion.Current, coretesting.FakeVersionNumber)
 	env := &mockZonedEnviron{
@@ -386,6 +427,8 @@ func (s *BootstrapSuite) TestSuccess(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(result.Arch, gc.Equals, "ppc64el") // based on hardware characteristics
 	c.Assert(result.Series, gc.Equals, config.PreferredSeries(mocksConfig))
+	c.Assert(result.InstanceId, gc.Equals, instance.Id(checkInstanceId))
+	c.Assert(result.InitialSSHHostKeys, jc.DeepEquals, innerInstanceConfig.Bootstrap.InitialSSHHostKeys)
 	c.Assert(result.Finalize, gc.NotNil)
 
 	// Check that we make the SSH connection with desired options.
@@ -433,6 +476,61 @@ func (s *BootstrapSuite) TestSuccess(c *gc.C) {
 	)
 }
 
+func (s *BootstrapSuite) TestResumeBootstrap(c *gc.C) {
+	inst := &mockInstance{id: "i-resume"}
+	var requested []instance.Id
+	env := &mockEnviron{
+		storage: newStorage(s, c),
+		config:  configGetter(c),
+		instances: func(ids []instance.Id) ([]instance.Instance, error) {
+			requested = ids
+			return []instance.Instance{inst}, nil
+		},
+	}
+	hostKeys := instancecfg.SSHHostKeys{
+		RSA: &instancecfg.SSHKeyPair{Public: "ssh-rsa public"},
+	}
+	ctx := envtesting.BootstrapContext(c)
+	result, err := common.ResumeBootstrap(ctx, env, "i-resume", environs.BootstrapParams{
+		ControllerConfig:   coretesting.FakeControllerConfig(),
+		BootstrapSeries:    series.MustHostSeries(),
+		AvailableTools:     fakeAvailableTools(),
+		InitialSSHHostKeys: hostKeys,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(requested, jc.DeepEquals, []instance.Id{"i-resume"})
+	c.Assert(result.Arch, gc.Equals, arch.HostArch())
+	c.Assert(result.Series, gc.Equals, series.MustHostSeries())
+	c.Assert(result.InstanceId, gc.Equals, instance.Id("i-resume"))
+	c.Assert(result.InitialSSHHostKeys, jc.DeepEquals, hostKeys)
+	c.Assert(result.Finalize, gc.NotNil)
+}
+
+func (s *BootstrapSuite) TestResumeBootstrapNoInstance(c *gc.C) {
+	env := &mockEnviron{
+		storage: newStorage(s, c),
+		config:  configGetter(c),
+		instances: func(ids []instance.Id) ([]instance.Instance, error) {
+			return nil, environs.ErrNoInstances
+		},
+	}
+	ctx := envtesting.BootstrapContext(c)
+	_, err := common.ResumeBootstrap(ctx, env, "i-gone", environs.BootstrapParams{
+		BootstrapSeries: series.MustHostSeries(),
+		AvailableTools:  fakeAvailableTools(),
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot find bootstrap instance "i-gone": instances not found`)
+}
+
+func (s *BootstrapSuite) TestResumeBootstrapNoSeries(c *gc.C) {
+	env := &mockEnviron{storage: newStorage(s, c), config: configGetter(c)}
+	ctx := envtesting.BootstrapContext(c)
+	_, err := common.ResumeBootstrap(ctx, env, "i-resume", environs.BootstrapParams{
+		AvailableTools: fakeAvailableTools(),
+	})
+	c.Assert(err, gc.ErrorMatches, "resuming bootstrap without series not valid")
+}
+
 type neverRefreshes struct {
 }
 
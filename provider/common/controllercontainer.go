@@ -0,0 +1,114 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/instance"
+)
+
+const (
+	// ControllerContainerName is the name of the LXD container that
+	// the controller runs in, when it is co-hosted on the bootstrap
+	// machine.
+	ControllerContainerName = "juju-controller"
+
+	// ControllerContainerProfile is the name of the LXD profile that
+	// limits the resources available to the controller container.
+	ControllerContainerProfile = "juju-controller"
+)
+
+var controllerContainerTemplate = template.Must(template.New("").Parse(`
+set -e
+if ! command -v lxc >/dev/null 2>&1; then
+    snap install lxd || (apt-get update && apt-get -y install lxd)
+fi
+lxd waitready --timeout=300 || true
+if [ -z "$(lxc storage list --format csv)" ]; then
+    lxd init --auto
+fi
+lxc profile create {{.Profile}} || true
+{{range .Limits}}lxc profile set {{$.Profile}} {{.}}
+{{end}}lxc profile device remove {{.Profile}} juju-api || true
+lxc profile device add {{.Profile}} juju-api proxy listen=tcp:0.0.0.0:{{.APIPort}} connect=tcp:127.0.0.1:{{.APIPort}}
+if ! lxc info {{.Name}} >/dev/null 2>&1; then
+    lxc launch {{.Image}} {{.Name}} -p default -p {{.Profile}}
+fi
+for i in $(seq 1 150); do
+    if lxc exec {{.Name}} -- test -f /var/lib/cloud/instance/boot-finished; then
+        break
+    fi
+    sleep 2
+done
+lxc exec {{.Name}} -- test -f /var/lib/cloud/instance/boot-finished
+tmpfile=$(mktemp)
+trap "rm -f $tmpfile" EXIT
+base64 -d > $tmpfile <<'JUJU_CONTROLLER_SCRIPT'
+{{.Script}}
+JUJU_CONTROLLER_SCRIPT
+lxc file push $tmpfile {{.Name}}/root/juju-controller-configure.sh
+lxc exec {{.Name}} -- /bin/bash /root/juju-controller-configure.sh
+`[1:]))
+
+// controllerContainerScript returns a script that, when run on the
+// bootstrap machine, creates a resource-limited system container for
+// the controller, forwards the API port to it, and runs the given
+// machine configuration script inside it.
+func controllerContainerScript(script string, icfg *instancecfg.InstanceConfig) (string, error) {
+	containerType := icfg.Bootstrap.ControllerContainerType
+	if containerType != instance.LXD {
+		return "", errors.NotSupportedf("controller in %q container", containerType)
+	}
+
+	// The controller machine constraints are applied to the
+	// container, limiting the resources that mongo and the API
+	// server may consume on the shared machine.
+	var limits []string
+	cons := icfg.Constraints
+	if cons.HasCpuCores() {
+		limits = append(limits, fmt.Sprintf("limits.cpu %d", *cons.CpuCores))
+	}
+	if cons.HasMem() {
+		limits = append(limits, fmt.Sprintf("limits.memory %dMB", *cons.Mem))
+	}
+
+	// Wrap the encoded script so the heredoc lines stay short.
+	encoded := base64.StdEncoding.EncodeToString([]byte(script))
+	var lines []string
+	for len(encoded) > 76 {
+		lines = append(lines, encoded[:76])
+		encoded = encoded[76:]
+	}
+	lines = append(lines, encoded)
+
+	var buf bytes.Buffer
+	err := controllerContainerTemplate.Execute(&buf, struct {
+		Name    string
+		Profile string
+		Image   string
+		Limits  []string
+		APIPort int
+		Script  string
+	}{
+		Name:    ControllerContainerName,
+		Profile: ControllerContainerProfile,
+		Image:   utils.ShQuote("ubuntu:" + icfg.Series),
+		Limits:  limits,
+		APIPort: icfg.Bootstrap.StateServingInfo.APIPort,
+		Script:  strings.Join(lines, "\n"),
+	})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return buf.String(), nil
+}
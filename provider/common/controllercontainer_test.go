@@ -0,0 +1,71 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"encoding/base64"
+	"regexp"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ControllerContainerSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&ControllerContainerSuite{})
+
+func (s *ControllerContainerSuite) instanceConfig(containerType instance.ContainerType, cons string) *instancecfg.InstanceConfig {
+	return &instancecfg.InstanceConfig{
+		Series:      "xenial",
+		Constraints: constraints.MustParse(cons),
+		Bootstrap: &instancecfg.BootstrapConfig{
+			StateServingInfo: params.StateServingInfo{
+				APIPort: 17070,
+			},
+			ControllerContainerType: containerType,
+		},
+	}
+}
+
+func (s *ControllerContainerSuite) TestScript(c *gc.C) {
+	script, err := common.ControllerContainerScript(
+		"echo configuring\n", s.instanceConfig(instance.LXD, "cores=2 mem=4G"),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(script, jc.Contains, "lxc profile set juju-controller limits.cpu 2\n")
+	c.Check(script, jc.Contains, "lxc profile set juju-controller limits.memory 4096MB\n")
+	c.Check(script, jc.Contains,
+		"lxc profile device add juju-controller juju-api proxy listen=tcp:0.0.0.0:17070 connect=tcp:127.0.0.1:17070\n",
+	)
+	c.Check(script, jc.Contains, "lxc launch 'ubuntu:xenial' juju-controller -p default -p juju-controller\n")
+	c.Check(script, jc.Contains, "lxc exec juju-controller -- /bin/bash /root/juju-controller-configure.sh\n")
+
+	// The machine configuration script is embedded, encoded.
+	matches := regexp.MustCompile("(?s)<<'JUJU_CONTROLLER_SCRIPT'\n(.*)\nJUJU_CONTROLLER_SCRIPT\n").FindStringSubmatch(script)
+	c.Assert(matches, gc.HasLen, 2)
+	decoded, err := base64.StdEncoding.DecodeString(strings.Replace(matches[1], "\n", "", -1))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(decoded), gc.Equals, "echo configuring\n")
+}
+
+func (s *ControllerContainerSuite) TestScriptNoLimits(c *gc.C) {
+	script, err := common.ControllerContainerScript("true\n", s.instanceConfig(instance.LXD, ""))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(script, gc.Not(jc.Contains), "limits.")
+}
+
+func (s *ControllerContainerSuite) TestScriptContainerTypeNotSupported(c *gc.C) {
+	_, err := common.ControllerContainerScript("true\n", s.instanceConfig(instance.KVM, ""))
+	c.Assert(err, gc.ErrorMatches, `controller in "kvm" container not supported`)
+}
@@ -4,6 +4,7 @@
 package common
 
 import (
+	"context"
 	"strings"
 
 	"github.com/juju/errors"
@@ -36,7 +37,7 @@ func destroyInstances(env environs.Environ) error {
 		for i, inst := range instances {
 			ids[i] = inst.Id()
 		}
-		if err := env.StopInstances(ids...); err != nil {
+		if err := env.StopInstances(context.TODO(), ids...); err != nil {
 			return err
 		}
 		fallthrough
@@ -90,13 +91,13 @@ func destroyStorage(env environs.Environ) error {
 }
 
 func destroyVolumes(volumeSource storage.VolumeSource) error {
-	volumeIds, err := volumeSource.ListVolumes()
+	volumeIds, err := volumeSource.ListVolumes(context.TODO())
 	if err != nil {
 		return errors.Annotate(err, "listing volumes")
 	}
 
 	var errStrings []string
-	errs, err := volumeSource.DestroyVolumes(volumeIds)
+	errs, err := volumeSource.DestroyVolumes(context.TODO(), volumeIds)
 	if err != nil {
 		return errors.Annotate(err, "destroying volumes")
 	}
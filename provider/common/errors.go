@@ -3,7 +3,11 @@
 
 package common
 
-import "github.com/juju/errors"
+import (
+	"time"
+
+	"github.com/juju/errors"
+)
 
 // ZoneIndependentError wraps the given error such that it
 // satisfies environs.IsAvailabilityZoneIndependent.
@@ -25,3 +29,24 @@ type zoneIndependentError struct {
 func (zoneIndependentError) AvailabilityZoneIndependent() bool {
 	return true
 }
+
+// RateLimitedError wraps the given error such that it satisfies
+// storage.RateLimitRetryAfter, reporting the given retry delay.
+func RateLimitedError(err error, retryAfter time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	wrapped := errors.Wrap(err, rateLimitedError{err, retryAfter})
+	wrapped.(*errors.Err).SetLocation(1)
+	return wrapped
+}
+
+type rateLimitedError struct {
+	error
+	retryAfter time.Duration
+}
+
+// RetryAfter is part of the storage.RateLimitError interface.
+func (e rateLimitedError) RetryAfter() time.Duration {
+	return e.retryAfter
+}
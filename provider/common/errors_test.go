@@ -4,6 +4,8 @@
 package common_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -11,6 +13,7 @@ import (
 
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/storage"
 )
 
 type ErrorsSuite struct {
@@ -32,3 +35,17 @@ github.com/juju/juju/provider/common/errors_test.go:.*: foo
 github.com/juju/juju/provider/common/errors_test.go:.*: bar
 github.com/juju/juju/provider/common/errors_test.go:.*: bar: foo`[1:])
 }
+
+func (*ErrorsSuite) TestWrapRateLimitedError(c *gc.C) {
+	err1 := errors.New("foo")
+	err2 := errors.Annotate(err1, "bar")
+	wrapped := common.RateLimitedError(err2, time.Minute)
+	c.Assert(wrapped, gc.ErrorMatches, "bar: foo")
+
+	retryAfter, ok := storage.RateLimitRetryAfter(errors.Annotate(wrapped, "baz"))
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(retryAfter, gc.Equals, time.Minute)
+
+	_, ok = storage.RateLimitRetryAfter(err2)
+	c.Assert(ok, jc.IsFalse)
+}
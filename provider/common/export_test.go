@@ -7,4 +7,5 @@ var (
 	ConnectSSH                          = &connectSSH
 	InternalAvailabilityZoneAllocations = &internalAvailabilityZoneAllocations
 	FormatHardware                      = formatHardware
+	ControllerContainerScript           = controllerContainerScript
 )
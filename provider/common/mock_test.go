@@ -4,6 +4,7 @@
 package common_test
 
 import (
+	"context"
 	"io"
 
 	"github.com/juju/juju/environs"
@@ -49,7 +50,7 @@ func (env *mockEnviron) Instances(ids []instance.Id) ([]instance.Instance, error
 	return env.instances(ids)
 }
 
-func (env *mockEnviron) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+func (env *mockEnviron) StartInstance(ctx context.Context, args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
 	inst, hw, networkInfo, err := env.startInstance(args)
 	if err != nil {
 		return nil, err
@@ -61,7 +62,7 @@ func (env *mockEnviron) StartInstance(args environs.StartInstanceParams) (*envir
 	}, nil
 }
 
-func (env *mockEnviron) StopInstances(ids ...instance.Id) error {
+func (env *mockEnviron) StopInstances(ctx context.Context, ids ...instance.Id) error {
 	return env.stopInstances(ids)
 }
 
@@ -19,6 +19,7 @@
 package dummy
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
 	"io"
@@ -988,7 +989,7 @@ func (*environ) MaintainInstance(args environs.StartInstanceParams) error {
 }
 
 // StartInstance is specified in the InstanceBroker interface.
-func (e *environ) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+func (e *environ) StartInstance(ctx context.Context, args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
 
 	defer delay()
 	machineId := args.InstanceConfig.MachineId
@@ -996,6 +997,9 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 	if err := e.checkBroken("StartInstance"); err != nil {
 		return nil, err
 	}
+	if err := ctx.Err(); err != nil {
+		return nil, errors.Trace(err)
+	}
 	estate, err := e.state()
 	if err != nil {
 		return nil, err
@@ -1150,11 +1154,15 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 	}, nil
 }
 
-func (e *environ) StopInstances(ids ...instance.Id) error {
+// StopInstances is specified in the InstanceBroker interface.
+func (e *environ) StopInstances(ctx context.Context, ids ...instance.Id) error {
 	defer delay()
 	if err := e.checkBroken("StopInstance"); err != nil {
 		return err
 	}
+	if err := ctx.Err(); err != nil {
+		return errors.Trace(err)
+	}
 	estate, err := e.state()
 	if err != nil {
 		return err
@@ -1481,10 +1489,13 @@ func (e *environ) AllInstances() ([]instance.Instance, error) {
 	return insts, nil
 }
 
-func (e *environ) OpenPorts(rules []network.IngressRule) error {
+func (e *environ) OpenPorts(ctx context.Context, rules []network.IngressRule) error {
 	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on model", mode)
 	}
+	if err := ctx.Err(); err != nil {
+		return errors.Trace(err)
+	}
 	estate, err := e.state()
 	if err != nil {
 		return err
@@ -1509,10 +1520,13 @@ func (e *environ) OpenPorts(rules []network.IngressRule) error {
 	return nil
 }
 
-func (e *environ) ClosePorts(rules []network.IngressRule) error {
+func (e *environ) ClosePorts(ctx context.Context, rules []network.IngressRule) error {
 	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
 		return fmt.Errorf("invalid firewall mode %q for closing ports on model", mode)
 	}
+	if err := ctx.Err(); err != nil {
+		return errors.Trace(err)
+	}
 	estate, err := e.state()
 	if err != nil {
 		return err
@@ -1529,10 +1543,13 @@ func (e *environ) ClosePorts(rules []network.IngressRule) error {
 	return nil
 }
 
-func (e *environ) IngressRules() (rules []network.IngressRule, err error) {
+func (e *environ) IngressRules(ctx context.Context) (rules []network.IngressRule, err error) {
 	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
 		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ingress rules from model", mode)
 	}
+	if err := ctx.Err(); err != nil {
+		return nil, errors.Trace(err)
+	}
 	estate, err := e.state()
 	if err != nil {
 		return nil, err
@@ -1634,7 +1651,7 @@ func (inst *dummyInstance) Addresses() ([]network.Address, error) {
 	return append([]network.Address{}, inst.addresses...), nil
 }
 
-func (inst *dummyInstance) OpenPorts(machineId string, rules []network.IngressRule) error {
+func (inst *dummyInstance) OpenPorts(ctx context.Context, machineId string, rules []network.IngressRule) error {
 	defer delay()
 	logger.Infof("openPorts %s, %#v", machineId, rules)
 	if inst.firewallMode != config.FwInstance {
@@ -1649,6 +1666,9 @@ func (inst *dummyInstance) OpenPorts(machineId string, rules []network.IngressRu
 	if err := inst.checkBroken("OpenPorts"); err != nil {
 		return err
 	}
+	if err := ctx.Err(); err != nil {
+		return errors.Trace(err)
+	}
 	inst.state.ops <- OpOpenPorts{
 		Env:        inst.state.name,
 		MachineId:  machineId,
@@ -1679,7 +1699,7 @@ func (inst *dummyInstance) OpenPorts(machineId string, rules []network.IngressRu
 	return nil
 }
 
-func (inst *dummyInstance) ClosePorts(machineId string, rules []network.IngressRule) error {
+func (inst *dummyInstance) ClosePorts(ctx context.Context, machineId string, rules []network.IngressRule) error {
 	defer delay()
 	if inst.firewallMode != config.FwInstance {
 		return fmt.Errorf("invalid firewall mode %q for closing ports on instance",
@@ -1693,6 +1713,9 @@ func (inst *dummyInstance) ClosePorts(machineId string, rules []network.IngressR
 	if err := inst.checkBroken("ClosePorts"); err != nil {
 		return err
 	}
+	if err := ctx.Err(); err != nil {
+		return errors.Trace(err)
+	}
 	inst.state.ops <- OpClosePorts{
 		Env:        inst.state.name,
 		MachineId:  machineId,
@@ -1709,7 +1732,7 @@ func (inst *dummyInstance) ClosePorts(machineId string, rules []network.IngressR
 	return nil
 }
 
-func (inst *dummyInstance) IngressRules(machineId string) (rules []network.IngressRule, err error) {
+func (inst *dummyInstance) IngressRules(ctx context.Context, machineId string) (rules []network.IngressRule, err error) {
 	defer delay()
 	if inst.firewallMode != config.FwInstance {
 		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ingress rules from instance",
@@ -1723,6 +1746,9 @@ func (inst *dummyInstance) IngressRules(machineId string) (rules []network.Ingre
 	if err := inst.checkBroken("IngressRules"); err != nil {
 		return nil, err
 	}
+	if err := ctx.Err(); err != nil {
+		return nil, errors.Trace(err)
+	}
 	for _, r := range inst.rules {
 		rules = append(rules, r)
 	}
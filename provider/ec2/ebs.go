@@ -4,6 +4,7 @@
 package ec2
 
 import (
+	"context"
 	"regexp"
 	"sync"
 	"time"
@@ -58,10 +59,11 @@ const (
 
 // AWS error codes
 const (
-	deviceInUse        = "InvalidDevice.InUse"
-	attachmentNotFound = "InvalidAttachment.NotFound"
-	volumeNotFound     = "InvalidVolume.NotFound"
-	incorrectState     = "IncorrectState"
+	deviceInUse          = "InvalidDevice.InUse"
+	attachmentNotFound   = "InvalidAttachment.NotFound"
+	volumeNotFound       = "InvalidVolume.NotFound"
+	incorrectState       = "IncorrectState"
+	requestLimitExceeded = "RequestLimitExceeded"
 )
 
 const (
@@ -285,7 +287,7 @@ func parseVolumeOptions(size uint64, attrs map[string]interface{}) (_ ec2.Create
 }
 
 // CreateVolumes is specified on the storage.VolumeSource interface.
-func (v *ebsVolumeSource) CreateVolumes(params []storage.VolumeParams) (_ []storage.CreateVolumesResult, err error) {
+func (v *ebsVolumeSource) CreateVolumes(ctx context.Context, params []storage.VolumeParams) (_ []storage.CreateVolumesResult, err error) {
 
 	// First, validate the params before we use them.
 	results := make([]storage.CreateVolumesResult, len(params))
@@ -314,7 +316,7 @@ func (v *ebsVolumeSource) CreateVolumes(params []storage.VolumeParams) (_ []stor
 		}
 		volume, attachment, err := v.createVolume(p, instances)
 		if err != nil {
-			results[i].Error = err
+			results[i].Error = maybeRateLimited(err)
 			continue
 		}
 		results[i].Volume = volume
@@ -380,7 +382,7 @@ func (v *ebsVolumeSource) createVolume(p storage.VolumeParams, instances instanc
 }
 
 // ListVolumes is specified on the storage.VolumeSource interface.
-func (v *ebsVolumeSource) ListVolumes() ([]string, error) {
+func (v *ebsVolumeSource) ListVolumes(ctx context.Context) ([]string, error) {
 	filter := ec2.NewFilter()
 	filter.Add("tag:"+tags.JujuModel, v.modelUUID)
 	return listVolumes(v.env.ec2, filter, false)
@@ -413,7 +415,7 @@ func listVolumes(client *ec2.EC2, filter *ec2.Filter, includeRootDisks bool) ([]
 }
 
 // DescribeVolumes is specified on the storage.VolumeSource interface.
-func (v *ebsVolumeSource) DescribeVolumes(volIds []string) ([]storage.DescribeVolumesResult, error) {
+func (v *ebsVolumeSource) DescribeVolumes(ctx context.Context, volIds []string) ([]storage.DescribeVolumesResult, error) {
 	// TODO(axw) invalid volIds here should not cause the whole
 	// operation to fail. If we get an invalid volume ID response,
 	// fall back to querying each volume individually. That should
@@ -449,12 +451,12 @@ func (v *ebsVolumeSource) DescribeVolumes(volIds []string) ([]storage.DescribeVo
 }
 
 // DestroyVolumes is specified on the storage.VolumeSource interface.
-func (v *ebsVolumeSource) DestroyVolumes(volIds []string) ([]error, error) {
+func (v *ebsVolumeSource) DestroyVolumes(ctx context.Context, volIds []string) ([]error, error) {
 	return foreachVolume(v.env.ec2, volIds, destroyVolume), nil
 }
 
 // ReleaseVolumes is specified on the storage.VolumeSource interface.
-func (v *ebsVolumeSource) ReleaseVolumes(volIds []string) ([]error, error) {
+func (v *ebsVolumeSource) ReleaseVolumes(ctx context.Context, volIds []string) ([]error, error) {
 	return foreachVolume(v.env.ec2, volIds, releaseVolume), nil
 }
 
@@ -651,7 +653,7 @@ func (v *ebsVolumeSource) ValidateVolumeParams(params storage.VolumeParams) erro
 }
 
 // AttachVolumes is specified on the storage.VolumeSource interface.
-func (v *ebsVolumeSource) AttachVolumes(attachParams []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
+func (v *ebsVolumeSource) AttachVolumes(ctx context.Context, attachParams []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
 	// We need the virtualisation types for each instance we are
 	// attaching to so we can determine the device name.
 	instIds := set.NewStrings()
@@ -683,7 +685,7 @@ func (v *ebsVolumeSource) AttachVolumes(attachParams []storage.VolumeAttachmentP
 		nextDeviceName := blockDeviceNamer(numbers)
 		_, deviceName, err := v.attachOneVolume(nextDeviceName, params.VolumeId, instId)
 		if err != nil {
-			results[i].Error = err
+			results[i].Error = maybeRateLimited(err)
 			continue
 		}
 		results[i].VolumeAttachment = &storage.VolumeAttachment{
@@ -853,7 +855,7 @@ func (c instanceCache) get(id string) (ec2.Instance, error) {
 }
 
 // DetachVolumes is specified on the storage.VolumeSource interface.
-func (v *ebsVolumeSource) DetachVolumes(attachParams []storage.VolumeAttachmentParams) ([]error, error) {
+func (v *ebsVolumeSource) DetachVolumes(ctx context.Context, attachParams []storage.VolumeAttachmentParams) ([]error, error) {
 	return detachVolumes(v.env.ec2, attachParams)
 }
 
@@ -876,18 +878,27 @@ func detachVolumes(client *ec2.EC2, attachParams []storage.VolumeAttachmentParam
 			}
 		}
 		if err != nil {
-			results[i] = errors.Annotatef(
+			results[i] = maybeRateLimited(errors.Annotatef(
 				err, "detaching %s from %s",
 				names.ReadableString(params.Volume),
 				names.ReadableString(params.Machine),
-			)
+			))
 		}
 	}
 	return results, nil
 }
 
+// maybeRateLimited marks errors caused by EC2 throttling API requests,
+// so that the storage provisioner backs off before retrying.
+func maybeRateLimited(err error) error {
+	if ec2Err, ok := errors.Cause(err).(*ec2.Error); ok && ec2Err.Code == requestLimitExceeded {
+		return common.RateLimitedError(err, 0)
+	}
+	return err
+}
+
 // ImportVolume is specified on the storage.VolumeImporter interface.
-func (v *ebsVolumeSource) ImportVolume(volumeId string, tags map[string]string) (storage.VolumeInfo, error) {
+func (v *ebsVolumeSource) ImportVolume(ctx context.Context, volumeId string, tags map[string]string) (storage.VolumeInfo, error) {
 	resp, err := v.env.ec2.Volumes([]string{volumeId}, nil)
 	if err != nil {
 		// TODO(axw) check for "not found" response, massage error message?
@@ -5,6 +5,7 @@ package ec2_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/xml"
 	"fmt"
 	"io/ioutil"
@@ -157,7 +158,7 @@ func (s *ebsSuite) createVolumes(vs storage.VolumeSource, instanceId string) ([]
 			},
 		},
 	}}
-	return vs.CreateVolumes(params)
+	return vs.CreateVolumes(context.Background(), params)
 }
 
 func (s *ebsSuite) assertCreateVolumes(c *gc.C, vs storage.VolumeSource, instanceId string) {
@@ -311,7 +312,7 @@ func (s *ebsSuite) TestVolumeTypeAliases(c *gc.C) {
 		if alias[1] == "io1" {
 			params[0].Attributes["iops"] = 30
 		}
-		results, err := vs.CreateVolumes(params)
+		results, err := vs.CreateVolumes(context.Background(), params)
 		c.Assert(err, jc.ErrorIsNil)
 		c.Assert(results, gc.HasLen, 1)
 		c.Assert(results[0].Error, jc.ErrorIsNil)
@@ -330,7 +331,7 @@ func (s *ebsSuite) TestVolumeTypeAliases(c *gc.C) {
 
 func (s *ebsSuite) TestDestroyVolumesNotFoundReturnsNil(c *gc.C) {
 	vs := s.volumeSource(c, nil)
-	results, err := vs.DestroyVolumes([]string{"vol-42"})
+	results, err := vs.DestroyVolumes(context.Background(), []string{"vol-42"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, gc.HasLen, 1)
 	c.Assert(results[0], jc.ErrorIsNil)
@@ -339,7 +340,7 @@ func (s *ebsSuite) TestDestroyVolumesNotFoundReturnsNil(c *gc.C) {
 func (s *ebsSuite) TestDestroyVolumes(c *gc.C) {
 	vs := s.volumeSource(c, nil)
 	s.setupAttachVolumesTest(c, vs, ec2test.Running)
-	errs, err := vs.DestroyVolumes([]string{"vol-0"})
+	errs, err := vs.DestroyVolumes(context.Background(), []string{"vol-0"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(errs, jc.DeepEquals, []error{nil})
 
@@ -354,9 +355,9 @@ func (s *ebsSuite) TestDestroyVolumes(c *gc.C) {
 func (s *ebsSuite) TestDestroyVolumesStillAttached(c *gc.C) {
 	vs := s.volumeSource(c, nil)
 	params := s.setupAttachVolumesTest(c, vs, ec2test.Running)
-	_, err := vs.AttachVolumes(params)
+	_, err := vs.AttachVolumes(context.Background(), params)
 	c.Assert(err, jc.ErrorIsNil)
-	errs, err := vs.DestroyVolumes([]string{"vol-0"})
+	errs, err := vs.DestroyVolumes(context.Background(), []string{"vol-0"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(errs, jc.DeepEquals, []error{nil})
 
@@ -371,7 +372,7 @@ func (s *ebsSuite) TestDestroyVolumesStillAttached(c *gc.C) {
 func (s *ebsSuite) TestReleaseVolumes(c *gc.C) {
 	vs := s.volumeSource(c, nil)
 	s.setupAttachVolumesTest(c, vs, ec2test.Running)
-	errs, err := vs.ReleaseVolumes([]string{"vol-0"})
+	errs, err := vs.ReleaseVolumes(context.Background(), []string{"vol-0"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(errs, jc.DeepEquals, []error{nil})
 
@@ -389,9 +390,9 @@ func (s *ebsSuite) TestReleaseVolumes(c *gc.C) {
 func (s *ebsSuite) TestReleaseVolumesStillAttached(c *gc.C) {
 	vs := s.volumeSource(c, nil)
 	params := s.setupAttachVolumesTest(c, vs, ec2test.Running)
-	_, err := vs.AttachVolumes(params)
+	_, err := vs.AttachVolumes(context.Background(), params)
 	c.Assert(err, jc.ErrorIsNil)
-	errs, err := vs.ReleaseVolumes([]string{"vol-0"})
+	errs, err := vs.ReleaseVolumes(context.Background(), []string{"vol-0"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(errs, gc.HasLen, 1)
 	c.Assert(errs[0], gc.ErrorMatches, `cannot release volume "vol-0": attachments still active`)
@@ -408,7 +409,7 @@ func (s *ebsSuite) TestReleaseVolumesStillAttached(c *gc.C) {
 
 func (s *ebsSuite) TestReleaseVolumesNotFound(c *gc.C) {
 	vs := s.volumeSource(c, nil)
-	errs, err := vs.ReleaseVolumes([]string{"vol-42"})
+	errs, err := vs.ReleaseVolumes(context.Background(), []string{"vol-42"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(errs, gc.HasLen, 1)
 	c.Assert(errs[0], gc.ErrorMatches, `cannot release volume "vol-42": vol-42 not found`)
@@ -418,7 +419,7 @@ func (s *ebsSuite) TestDescribeVolumes(c *gc.C) {
 	vs := s.volumeSource(c, nil)
 	s.assertCreateVolumes(c, vs, "")
 
-	vols, err := vs.DescribeVolumes([]string{"vol-0", "vol-1"})
+	vols, err := vs.DescribeVolumes(context.Background(), []string{"vol-0", "vol-1"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(vols, jc.DeepEquals, []storage.DescribeVolumesResult{{
 		VolumeInfo: &storage.VolumeInfo{
@@ -437,7 +438,7 @@ func (s *ebsSuite) TestDescribeVolumes(c *gc.C) {
 
 func (s *ebsSuite) TestDescribeVolumesNotFound(c *gc.C) {
 	vs := s.volumeSource(c, nil)
-	vols, err := vs.DescribeVolumes([]string{"vol-42"})
+	vols, err := vs.DescribeVolumes(context.Background(), []string{"vol-42"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(vols, gc.HasLen, 1)
 	c.Assert(vols[0].Error, gc.ErrorMatches, "vol-42 not found")
@@ -449,7 +450,7 @@ func (s *ebsSuite) TestListVolumes(c *gc.C) {
 
 	// Only one volume created by assertCreateVolumes has
 	// the model-uuid tag with the expected value.
-	volIds, err := vs.ListVolumes()
+	volIds, err := vs.ListVolumes(context.Background())
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(volIds, jc.SameContents, []string{"vol-0"})
 }
@@ -465,7 +466,7 @@ func (s *ebsSuite) TestListVolumesIgnoresRootDisks(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	vs := s.volumeSource(c, nil)
-	volIds, err := vs.ListVolumes()
+	volIds, err := vs.ListVolumes(context.Background())
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(volIds, gc.HasLen, 0)
 }
@@ -584,7 +585,7 @@ func (s *ebsSuite) TestCreateVolumesErrors(c *gc.C) {
 		},
 		err: "validating EBS storage config: volume-type: unexpected value \"what\"",
 	}} {
-		results, err := vs.CreateVolumes([]storage.VolumeParams{test.params})
+		results, err := vs.CreateVolumes(context.Background(), []storage.VolumeParams{test.params})
 		c.Assert(err, jc.ErrorIsNil)
 		c.Assert(results, gc.HasLen, 1)
 		c.Check(results[0].Error, gc.ErrorMatches, test.err)
@@ -621,10 +622,21 @@ func (s *ebsSuite) TestAttachVolumesNotRunning(c *gc.C) {
 	}
 }
 
+func (s *ebsSuite) TestMaybeRateLimited(c *gc.C) {
+	throttled := errors.Annotate(&awsec2.Error{Code: "RequestLimitExceeded"}, "creating volume")
+	_, ok := storage.RateLimitRetryAfter(ec2.MaybeRateLimited(throttled))
+	c.Assert(ok, jc.IsTrue)
+
+	other := errors.Annotate(&awsec2.Error{Code: "InvalidVolume.NotFound"}, "creating volume")
+	c.Assert(ec2.MaybeRateLimited(other), gc.Equals, other)
+	_, ok = storage.RateLimitRetryAfter(other)
+	c.Assert(ok, jc.IsFalse)
+}
+
 func (s *ebsSuite) TestAttachVolumes(c *gc.C) {
 	vs := s.volumeSource(c, nil)
 	params := s.setupAttachVolumesTest(c, vs, ec2test.Running)
-	result, err := vs.AttachVolumes(params)
+	result, err := vs.AttachVolumes(context.Background(), params)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(result, gc.HasLen, 1)
 	c.Assert(result[0].Error, jc.ErrorIsNil)
@@ -650,7 +662,7 @@ func (s *ebsSuite) TestAttachVolumes(c *gc.C) {
 	}})
 
 	// Test idempotency.
-	result, err = vs.AttachVolumes(params)
+	result, err = vs.AttachVolumes(context.Background(), params)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(result, gc.HasLen, 1)
 	c.Assert(result[0].Error, jc.ErrorIsNil)
@@ -680,7 +692,7 @@ func (s *ebsSuite) TestAttachVolumesCreating(c *gc.C) {
 		}
 		return nil
 	})
-	result, err := vs.AttachVolumes(params)
+	result, err := vs.AttachVolumes(context.Background(), params)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(result, gc.HasLen, 1)
 	c.Assert(result[0].Error, jc.ErrorIsNil)
@@ -700,7 +712,7 @@ func (s *ebsSuite) TestAttachVolumesDetaching(c *gc.C) {
 		})
 		return nil
 	})
-	result, err := vs.AttachVolumes(params)
+	result, err := vs.AttachVolumes(context.Background(), params)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(result, gc.HasLen, 1)
 	c.Assert(result[0].Error, gc.ErrorMatches, "volume vol-0 is attached to something else")
@@ -709,9 +721,9 @@ func (s *ebsSuite) TestAttachVolumesDetaching(c *gc.C) {
 func (s *ebsSuite) TestDetachVolumes(c *gc.C) {
 	vs := s.volumeSource(c, nil)
 	params := s.setupAttachVolumesTest(c, vs, ec2test.Running)
-	_, err := vs.AttachVolumes(params)
+	_, err := vs.AttachVolumes(context.Background(), params)
 	c.Assert(err, jc.ErrorIsNil)
-	errs, err := vs.DetachVolumes(params)
+	errs, err := vs.DetachVolumes(context.Background(), params)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(errs, jc.DeepEquals, []error{nil})
 
@@ -723,7 +735,7 @@ func (s *ebsSuite) TestDetachVolumes(c *gc.C) {
 	c.Assert(ec2Vols.Volumes[0].Attachments, gc.HasLen, 0)
 
 	// Test idempotent
-	errs, err = vs.DetachVolumes(params)
+	errs, err = vs.DetachVolumes(context.Background(), params)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(errs, jc.DeepEquals, []error{nil})
 }
@@ -739,7 +751,7 @@ func (s *ebsSuite) TestDetachVolumesAttachmentNotFound(c *gc.C) {
 func (s *ebsSuite) testDetachVolumesDetachedState(c *gc.C, errorCode string) {
 	vs := s.volumeSource(c, nil)
 	params := s.setupAttachVolumesTest(c, vs, ec2test.Running)
-	_, err := vs.AttachVolumes(params)
+	_, err := vs.AttachVolumes(context.Background(), params)
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.srv.proxy.ModifyResponse = func(resp *http.Response) error {
@@ -748,7 +760,7 @@ func (s *ebsSuite) testDetachVolumesDetachedState(c *gc.C, errorCode string) {
 			Code: errorCode,
 		}}})
 	}
-	errs, err := vs.DetachVolumes(params)
+	errs, err := vs.DetachVolumes(context.Background(), params)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(errs, jc.DeepEquals, []error{nil})
 }
@@ -764,7 +776,7 @@ func (s *ebsSuite) TestImportVolume(c *gc.C) {
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
-	volInfo, err := vs.(storage.VolumeImporter).ImportVolume(resp.Id, map[string]string{
+	volInfo, err := vs.(storage.VolumeImporter).ImportVolume(context.Background(), resp.Id, map[string]string{
 		"foo": "bar",
 	})
 	c.Assert(err, jc.ErrorIsNil)
@@ -787,11 +799,11 @@ func (s *ebsSuite) TestImportVolumeInUse(c *gc.C) {
 	c.Assert(vs, gc.Implements, new(storage.VolumeImporter))
 
 	params := s.setupAttachVolumesTest(c, vs, ec2test.Running)
-	_, err := vs.AttachVolumes(params)
+	_, err := vs.AttachVolumes(context.Background(), params)
 	c.Assert(err, jc.ErrorIsNil)
 
 	volId := params[0].VolumeId
-	_, err = vs.(storage.VolumeImporter).ImportVolume(volId, map[string]string{})
+	_, err = vs.(storage.VolumeImporter).ImportVolume(context.Background(), volId, map[string]string{})
 	c.Assert(err, gc.ErrorMatches, `cannot import volume with status "in-use"`)
 }
 
@@ -4,6 +4,7 @@
 package ec2
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"net"
@@ -141,6 +142,11 @@ func (e *environ) Bootstrap(ctx environs.BootstrapContext, args environs.Bootstr
 	return common.Bootstrap(ctx, e, args)
 }
 
+// ResumeBootstrap is part of the environs.BootstrapResumer interface.
+func (e *environ) ResumeBootstrap(ctx environs.BootstrapContext, id instance.Id, args environs.BootstrapParams) (*environs.BootstrapResult, error) {
+	return common.ResumeBootstrap(ctx, e, id, args)
+}
+
 // SupportsSpaces is specified on environs.Networking.
 func (e *environ) SupportsSpaces() (bool, error) {
 	return true, nil
@@ -179,9 +185,45 @@ func (e *environ) ConstraintsValidator() (constraints.Validator, error) {
 		instTypeNames[i] = itype.Name
 	}
 	validator.RegisterVocabulary(constraints.InstanceType, instTypeNames)
+	validator.RegisterInstanceAttributes(instanceAttributes)
 	return validator, nil
 }
 
+// Instance attributes that may be given in the instance-attributes
+// constraint, and passed through to RunInstances.
+const (
+	placementGroupAttribute        = "placement-group"
+	shutdownBehaviorAttribute      = "shutdown-behavior"
+	disableAPITerminationAttribute = "disable-api-termination"
+)
+
+var instanceAttributes = map[string][]string{
+	placementGroupAttribute:        nil,
+	shutdownBehaviorAttribute:      {"stop", "terminate"},
+	disableAPITerminationAttribute: {"true", "false"},
+}
+
+// applyInstanceAttributes sets the RunInstances options named in the
+// instance-attributes constraint of cons.
+func applyInstanceAttributes(cons constraints.Value, runArgs *ec2.RunInstances) error {
+	if !cons.HasInstanceAttributes() {
+		return nil
+	}
+	for key, value := range *cons.InstanceAttributes {
+		switch key {
+		case placementGroupAttribute:
+			runArgs.PlacementGroupName = value
+		case shutdownBehaviorAttribute:
+			runArgs.ShutdownBehavior = value
+		case disableAPITerminationAttribute:
+			runArgs.DisableAPITermination = value == "true"
+		default:
+			return errors.NotValidf("instance attribute %q", key)
+		}
+	}
+	return nil
+}
+
 func archMatches(arches []string, arch *string) bool {
 	if arch == nil {
 		return true
@@ -401,14 +443,14 @@ func resourceName(tag names.Tag, envName string) string {
 }
 
 // StartInstance is specified in the InstanceBroker interface.
-func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.StartInstanceResult, resultErr error) {
+func (e *environ) StartInstance(ctx context.Context, args environs.StartInstanceParams) (_ *environs.StartInstanceResult, resultErr error) {
 	var inst *ec2Instance
 	callback := args.StatusCallback
 	defer func() {
 		if resultErr == nil || inst == nil {
 			return
 		}
-		if err := e.StopInstances(inst.Id()); err != nil {
+		if err := e.StopInstances(ctx, inst.Id()); err != nil {
 			callback(status.Error, fmt.Sprintf("error stopping failed instance: %v", err), nil)
 			logger.Errorf("error stopping failed instance: %v", err)
 		}
@@ -524,6 +566,9 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 		BlockDeviceMappings: blockDeviceMappings,
 		ImageId:             spec.Image.Id,
 	}
+	if err := applyInstanceAttributes(args.Constraints, commonRunArgs); err != nil {
+		return nil, common.ZoneIndependentError(err)
+	}
 
 	runArgs := commonRunArgs
 	runArgs.AvailZone = availabilityZone
@@ -573,7 +618,7 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 	}
 
 	callback(status.Allocating, fmt.Sprintf("Trying to start instance in availability zone %q", availabilityZone), nil)
-	instResp, err = runInstances(e.ec2, runArgs, callback)
+	instResp, err = runInstances(ctx, e.ec2, runArgs, callback)
 	if err != nil {
 		err := errors.Annotate(err, "cannot run instances")
 		if !isZoneOrSubnetConstrainedError(err) {
@@ -818,10 +863,13 @@ var runInstances = _runInstances
 
 // runInstances calls ec2.RunInstances for a fixed number of attempts until
 // RunInstances returns an error code that does not indicate an error that
-// may be caused by eventual consistency.
-func _runInstances(e *ec2.EC2, ri *ec2.RunInstances, c environs.StatusCallbackFunc) (resp *ec2.RunInstancesResp, err error) {
+// may be caused by eventual consistency, or ctx is cancelled.
+func _runInstances(ctx context.Context, e *ec2.EC2, ri *ec2.RunInstances, c environs.StatusCallbackFunc) (resp *ec2.RunInstancesResp, err error) {
 	try := 1
 	for a := shortAttempt.Start(); a.Next(); {
+		if err := ctx.Err(); err != nil {
+			return nil, errors.Trace(err)
+		}
 		c(status.Allocating, fmt.Sprintf("Start instance attempt %d", try), nil)
 		resp, err = e.RunInstances(ri)
 		if err == nil || !isNotFoundError(err) {
@@ -832,7 +880,8 @@ func _runInstances(e *ec2.EC2, ri *ec2.RunInstances, c environs.StatusCallbackFu
 	return resp, err
 }
 
-func (e *environ) StopInstances(ids ...instance.Id) error {
+// StopInstances is specified in the InstanceBroker interface.
+func (e *environ) StopInstances(ctx context.Context, ids ...instance.Id) error {
 	return errors.Trace(e.terminateInstances(ids))
 }
 
@@ -1413,7 +1462,7 @@ func (e *environ) ingressRulesInGroup(name string) (rules []network.IngressRule,
 	return rules, nil
 }
 
-func (e *environ) OpenPorts(rules []network.IngressRule) error {
+func (e *environ) OpenPorts(ctx context.Context, rules []network.IngressRule) error {
 	if e.Config().FirewallMode() != config.FwGlobal {
 		return errors.Errorf("invalid firewall mode %q for opening ports on model", e.Config().FirewallMode())
 	}
@@ -1424,7 +1473,7 @@ func (e *environ) OpenPorts(rules []network.IngressRule) error {
 	return nil
 }
 
-func (e *environ) ClosePorts(rules []network.IngressRule) error {
+func (e *environ) ClosePorts(ctx context.Context, rules []network.IngressRule) error {
 	if e.Config().FirewallMode() != config.FwGlobal {
 		return errors.Errorf("invalid firewall mode %q for closing ports on model", e.Config().FirewallMode())
 	}
@@ -1435,7 +1484,7 @@ func (e *environ) ClosePorts(rules []network.IngressRule) error {
 	return nil
 }
 
-func (e *environ) IngressRules() ([]network.IngressRule, error) {
+func (e *environ) IngressRules(ctx context.Context) ([]network.IngressRule, error) {
 	if e.Config().FirewallMode() != config.FwGlobal {
 		return nil, errors.Errorf("invalid firewall mode %q for retrieving ingress rules from model", e.Config().FirewallMode())
 	}
@@ -21,6 +21,8 @@ func StorageEC2(vs jujustorage.VolumeSource) *ec2.EC2 {
 	return vs.(*ebsVolumeSource).env.ec2
 }
 
+var MaybeRateLimited = maybeRateLimited
+
 func JujuGroupName(e environs.Environ) string {
 	return e.(*environ).jujuGroupName()
 }
@@ -64,6 +66,14 @@ var (
 
 const VPCIDNone = vpcIDNone
 
+var STSEndpoint = &stsEndpoint
+
+// NewCredentialMinter returns an environ, for the given cloud, that
+// can do nothing but mint credentials.
+func NewCredentialMinter(cloud environs.CloudSpec) environs.CredentialMinter {
+	return &environ{cloud: cloud}
+}
+
 // TODO: Apart from overriding different hardcoded hosts, these two test helpers are identical. Let's share.
 
 // UseTestImageData causes the given content to be served
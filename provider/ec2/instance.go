@@ -4,6 +4,7 @@
 package ec2
 
 import (
+	"context"
 	"fmt"
 
 	"gopkg.in/amz.v3/ec2"
@@ -74,7 +75,7 @@ func (inst *ec2Instance) Addresses() ([]network.Address, error) {
 	return addresses, nil
 }
 
-func (inst *ec2Instance) OpenPorts(machineId string, rules []network.IngressRule) error {
+func (inst *ec2Instance) OpenPorts(ctx context.Context, machineId string, rules []network.IngressRule) error {
 	if inst.e.Config().FirewallMode() != config.FwInstance {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on instance",
 			inst.e.Config().FirewallMode())
@@ -87,7 +88,7 @@ func (inst *ec2Instance) OpenPorts(machineId string, rules []network.IngressRule
 	return nil
 }
 
-func (inst *ec2Instance) ClosePorts(machineId string, ports []network.IngressRule) error {
+func (inst *ec2Instance) ClosePorts(ctx context.Context, machineId string, ports []network.IngressRule) error {
 	if inst.e.Config().FirewallMode() != config.FwInstance {
 		return fmt.Errorf("invalid firewall mode %q for closing ports on instance",
 			inst.e.Config().FirewallMode())
@@ -100,7 +101,7 @@ func (inst *ec2Instance) ClosePorts(machineId string, ports []network.IngressRul
 	return nil
 }
 
-func (inst *ec2Instance) IngressRules(machineId string) ([]network.IngressRule, error) {
+func (inst *ec2Instance) IngressRules(ctx context.Context, machineId string) ([]network.IngressRule, error) {
 	if inst.e.Config().FirewallMode() != config.FwInstance {
 		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ingress rules from instance",
 			inst.e.Config().FirewallMode())
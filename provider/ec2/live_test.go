@@ -4,6 +4,7 @@
 package ec2_test
 
 import (
+	"context"
 	"crypto/rand"
 	"fmt"
 	"io"
@@ -102,7 +103,7 @@ func (t *LiveTests) TearDownTest(c *gc.C) {
 func (t *LiveTests) TestInstanceAttributes(c *gc.C) {
 	t.PrepareOnce(c)
 	inst, hc := testing.AssertStartInstance(c, t.Env, t.ControllerUUID, "30")
-	defer t.Env.StopInstances(inst.Id())
+	defer t.Env.StopInstances(context.Background(), inst.Id())
 	// Sanity check for hardware characteristics.
 	c.Assert(hc.Arch, gc.NotNil)
 	c.Assert(hc.Mem, gc.NotNil)
@@ -127,7 +128,7 @@ func (t *LiveTests) TestStartInstanceConstraints(c *gc.C) {
 	t.PrepareOnce(c)
 	cons := constraints.MustParse("mem=4G")
 	inst, hc := testing.AssertStartInstanceWithConstraints(c, t.Env, t.ControllerUUID, "30", cons)
-	defer t.Env.StopInstances(inst.Id())
+	defer t.Env.StopInstances(context.Background(), inst.Id())
 	ec2inst := ec2.InstanceEC2(inst)
 	c.Assert(ec2inst.InstanceType, gc.Equals, "m4.large")
 	c.Assert(*hc.Arch, gc.Equals, "amd64")
@@ -144,10 +145,10 @@ func (t *LiveTests) TestControllerInstances(c *gc.C) {
 	bootstrapInstId := allInsts[0].Id()
 
 	inst0, _ := testing.AssertStartInstance(c, t.Env, t.ControllerUUID, "98")
-	defer t.Env.StopInstances(inst0.Id())
+	defer t.Env.StopInstances(context.Background(), inst0.Id())
 
 	inst1, _ := testing.AssertStartInstance(c, t.Env, t.ControllerUUID, "99")
-	defer t.Env.StopInstances(inst1.Id())
+	defer t.Env.StopInstances(context.Background(), inst1.Id())
 
 	insts, err := t.Env.ControllerInstances(t.ControllerUUID)
 	c.Assert(err, jc.ErrorIsNil)
@@ -196,14 +197,14 @@ func (t *LiveTests) TestInstanceGroups(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	inst0, _ := testing.AssertStartControllerInstance(c, t.Env, t.ControllerUUID, "98")
-	defer t.Env.StopInstances(inst0.Id())
+	defer t.Env.StopInstances(context.Background(), inst0.Id())
 
 	// Create a same-named group for the second instance
 	// before starting it, to check that it's reused correctly.
 	oldMachineGroup := createGroup(c, ec2conn, groups[2].Name, "old machine group")
 
 	inst1, _ := testing.AssertStartControllerInstance(c, t.Env, t.ControllerUUID, "99")
-	defer t.Env.StopInstances(inst1.Id())
+	defer t.Env.StopInstances(context.Background(), inst1.Id())
 
 	groupsResp, err := ec2conn.SecurityGroups(groups, nil)
 	c.Assert(err, jc.ErrorIsNil)
@@ -339,7 +340,7 @@ func (t *LiveTests) TestStopInstances(c *gc.C) {
 	inst1 := ec2.FabricateInstance(inst0, "i-aaaaaaaa")
 	inst2, _ := testing.AssertStartInstance(c, t.Env, t.ControllerUUID, "41")
 
-	err := t.Env.StopInstances(inst0.Id(), inst1.Id(), inst2.Id())
+	err := t.Env.StopInstances(context.Background(), inst0.Id(), inst1.Id(), inst2.Id())
 	c.Check(err, jc.ErrorIsNil)
 
 	var insts []instance.Instance
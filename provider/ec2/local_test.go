@@ -4,6 +4,7 @@
 package ec2_test
 
 import (
+	"context"
 	"fmt"
 	"net/http/httptest"
 	"net/http/httputil"
@@ -526,7 +527,7 @@ func (t *localServerSuite) TestTerminateInstancesIgnoresNotFound(c *gc.C) {
 	}
 	idsToStop[len(insts)] = instance.Id("i-am-not-found")
 
-	err = env.StopInstances(idsToStop...)
+	err = env.StopInstances(context.Background(), idsToStop...)
 	// NotFound should be ignored
 	c.Assert(err, jc.ErrorIsNil)
 }
@@ -645,7 +646,7 @@ func (t *localServerSuite) TestDestroyControllerDestroysHostedModelResources(c *
 	c.Assert(err, jc.ErrorIsNil)
 	vs, err := ebsProvider.VolumeSource(nil)
 	c.Assert(err, jc.ErrorIsNil)
-	volumeResults, err := vs.CreateVolumes([]storage.VolumeParams{{
+	volumeResults, err := vs.CreateVolumes(context.Background(), []storage.VolumeParams{{
 		Tag:      names.NewVolumeTag("0"),
 		Size:     1024,
 		Provider: ec2.EBS_ProviderType,
@@ -673,7 +674,7 @@ func (t *localServerSuite) TestDestroyControllerDestroysHostedModelResources(c *
 		c.Assert(ids, jc.SameContents, expect)
 	}
 	assertVolumes := func(expect ...string) {
-		volIds, err := vs.ListVolumes()
+		volIds, err := vs.ListVolumes(context.Background())
 		c.Assert(err, jc.ErrorIsNil)
 		c.Assert(volIds, jc.SameContents, expect)
 	}
@@ -1097,7 +1098,7 @@ func (t *localServerSuite) TestStartInstanceAvailZoneAllNoDefaultSubnet(c *gc.C)
 func (t *localServerSuite) testStartInstanceAvailZoneAllConstrained(c *gc.C, runInstancesError *amzec2.Error) {
 	env := t.prepareAndBootstrap(c)
 
-	t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ri *amzec2.RunInstances, c environs.StatusCallbackFunc) (*amzec2.RunInstancesResp, error) {
+	t.PatchValue(ec2.RunInstances, func(ctx context.Context, e *amzec2.EC2, ri *amzec2.RunInstances, c environs.StatusCallbackFunc) (*amzec2.RunInstancesResp, error) {
 		return nil, runInstancesError
 	})
 
@@ -1301,12 +1302,12 @@ func (t *localServerSuite) testStartInstanceAvailZoneOneConstrained(c *gc.C, run
 	var azArgs []string
 	realRunInstances := *ec2.RunInstances
 
-	t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ri *amzec2.RunInstances, c environs.StatusCallbackFunc) (*amzec2.RunInstancesResp, error) {
+	t.PatchValue(ec2.RunInstances, func(ctx context.Context, e *amzec2.EC2, ri *amzec2.RunInstances, c environs.StatusCallbackFunc) (*amzec2.RunInstancesResp, error) {
 		azArgs = append(azArgs, ri.AvailZone)
 		if len(azArgs) == 1 {
 			return nil, runInstancesError
 		}
-		return realRunInstances(e, ri, fakeCallback)
+		return realRunInstances(ctx, e, ri, fakeCallback)
 	})
 
 	params := environs.StartInstanceParams{ControllerUUID: t.ControllerUUID}
@@ -1364,6 +1365,38 @@ func (t *localServerSuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 	c.Assert(unsupported, jc.SameContents, []string{"tags", "virt-type"})
 }
 
+func (t *localServerSuite) TestConstraintsValidatorInstanceAttributes(c *gc.C) {
+	env := t.Prepare(c)
+	validator, err := env.ConstraintsValidator()
+	c.Assert(err, jc.ErrorIsNil)
+	cons := constraints.MustParse("instance-attributes=placement-group=pg1,shutdown-behavior=stop")
+	unsupported, err := validator.Validate(cons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unsupported, gc.HasLen, 0)
+
+	cons = constraints.MustParse("instance-attributes=tenancy=dedicated")
+	_, err = validator.Validate(cons)
+	c.Assert(err, gc.ErrorMatches, "invalid instance attribute: tenancy\nvalid attributes are:.*")
+}
+
+func (t *localServerSuite) TestStartInstanceInstanceAttributes(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+
+	var runArgs *amzec2.RunInstances
+	realRunInstances := *ec2.RunInstances
+	t.PatchValue(ec2.RunInstances, func(ctx context.Context, e *amzec2.EC2, ri *amzec2.RunInstances, c environs.StatusCallbackFunc) (*amzec2.RunInstancesResp, error) {
+		runArgs = ri
+		return realRunInstances(ctx, e, ri, fakeCallback)
+	})
+
+	cons := constraints.MustParse("instance-attributes=shutdown-behavior=terminate,disable-api-termination=true")
+	testing.AssertStartInstanceWithConstraints(c, env, t.ControllerUUID, "1", cons)
+	c.Assert(runArgs, gc.NotNil)
+	c.Check(runArgs.ShutdownBehavior, gc.Equals, "terminate")
+	c.Check(runArgs.DisableAPITermination, jc.IsTrue)
+	c.Check(runArgs.PlacementGroupName, gc.Equals, "")
+}
+
 func (t *localServerSuite) TestConstraintsValidatorVocab(c *gc.C) {
 	env := t.Prepare(c)
 	validator, err := env.ConstraintsValidator()
@@ -1830,7 +1863,7 @@ func (s *localServerSuite) TestAdoptResources(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	vs, err := ebsProvider.VolumeSource(nil)
 	c.Assert(err, jc.ErrorIsNil)
-	volumeResults, err := vs.CreateVolumes([]storage.VolumeParams{{
+	volumeResults, err := vs.CreateVolumes(context.Background(), []storage.VolumeParams{{
 		Tag:      names.NewVolumeTag("0"),
 		Size:     1024,
 		Provider: ec2.EBS_ProviderType,
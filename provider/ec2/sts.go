@@ -0,0 +1,167 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/aws"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/trust"
+	"github.com/juju/juju/environs"
+)
+
+const (
+	// minFederationDuration and maxFederationDuration are the limits
+	// STS places on the lifetime of federation tokens.
+	minFederationDuration = 15 * time.Minute
+	maxFederationDuration = 36 * time.Hour
+
+	// maxFederatedUserName is the maximum length of the name of a
+	// federated user.
+	maxFederatedUserName = 32
+
+	// amzDateFormat is the format of the X-Amz-Date header.
+	amzDateFormat = "20060102T150405Z"
+)
+
+// readOnlyPolicy limits a federated user to reading the resources it
+// may need to discover, such as instances and buckets.
+const readOnlyPolicy = `{
+  "Version": "2012-10-17",
+  "Statement": [{
+    "Effect": "Allow",
+    "Action": [
+      "ec2:Describe*",
+      "elasticloadbalancing:Describe*",
+      "route53:Get*",
+      "route53:List*",
+      "s3:Get*",
+      "s3:List*"
+    ],
+    "Resource": "*"
+  }]
+}`
+
+// fullPolicy does not limit a federated user any further than the
+// model's own credential.
+const fullPolicy = `{
+  "Version": "2012-10-17",
+  "Statement": [{
+    "Effect": "Allow",
+    "Action": "*",
+    "Resource": "*"
+  }]
+}`
+
+// stsEndpoint returns the URL of the STS endpoint for the given region.
+var stsEndpoint = func(region string) string {
+	if strings.HasPrefix(region, "cn-") {
+		return fmt.Sprintf("https://sts.%s.amazonaws.com.cn/", region)
+	}
+	return fmt.Sprintf("https://sts.%s.amazonaws.com/", region)
+}
+
+// MintCredential is part of the environs.CredentialMinter interface.
+// The credential is a federation token obtained from STS, whose
+// permissions are the intersection of the model credential's and a
+// policy for the trust level.
+func (e *environ) MintCredential(args environs.MintCredentialParams) (environs.ScopedCredential, error) {
+	var policy string
+	switch args.Level {
+	case trust.ReadOnly:
+		policy = readOnlyPolicy
+	case trust.Full:
+		policy = fullPolicy
+	default:
+		return environs.ScopedCredential{}, errors.NotValidf("trust level %q", args.Level)
+	}
+	duration := args.Duration
+	if duration < minFederationDuration {
+		duration = minFederationDuration
+	} else if duration > maxFederationDuration {
+		duration = maxFederationDuration
+	}
+
+	query := url.Values{
+		"Action":          {"GetFederationToken"},
+		"Version":         {"2011-06-15"},
+		"Name":            {federatedUserName(args.Application)},
+		"Policy":          {policy},
+		"DurationSeconds": {strconv.Itoa(int(duration / time.Second))},
+	}
+	req, err := http.NewRequest("GET", stsEndpoint(e.cloud.Region)+"?"+query.Encode(), nil)
+	if err != nil {
+		return environs.ScopedCredential{}, errors.Trace(err)
+	}
+	req.Header.Set("X-Amz-Date", time.Now().UTC().Format(amzDateFormat))
+	credentialAttrs := e.cloud.Credential.Attributes()
+	auth := aws.Auth{
+		AccessKey: credentialAttrs["access-key"],
+		SecretKey: credentialAttrs["secret-key"],
+	}
+	if err := aws.SignV4Factory(e.cloud.Region, "sts")(req, auth); err != nil {
+		return environs.ScopedCredential{}, errors.Annotate(err, "signing STS request")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return environs.ScopedCredential{}, errors.Annotate(err, "requesting federation token")
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return environs.ScopedCredential{}, errors.Annotate(err, "reading federation token")
+	}
+	if resp.StatusCode != http.StatusOK {
+		var stsErr struct {
+			Code    string `xml:"Error>Code"`
+			Message string `xml:"Error>Message"`
+		}
+		if err := xml.Unmarshal(body, &stsErr); err != nil || stsErr.Code == "" {
+			return environs.ScopedCredential{}, errors.Errorf("requesting federation token: %s", resp.Status)
+		}
+		return environs.ScopedCredential{}, errors.Errorf(
+			"requesting federation token: %s: %s", stsErr.Code, stsErr.Message,
+		)
+	}
+
+	var result struct {
+		AccessKeyId     string    `xml:"GetFederationTokenResult>Credentials>AccessKeyId"`
+		SecretAccessKey string    `xml:"GetFederationTokenResult>Credentials>SecretAccessKey"`
+		SessionToken    string    `xml:"GetFederationTokenResult>Credentials>SessionToken"`
+		Expiration      time.Time `xml:"GetFederationTokenResult>Credentials>Expiration"`
+	}
+	if err := xml.Unmarshal(body, &result); err != nil {
+		return environs.ScopedCredential{}, errors.Annotate(err, "parsing federation token")
+	}
+	return environs.ScopedCredential{
+		AuthType: cloud.AccessKeyAuthType,
+		Attributes: map[string]string{
+			"access-key":    result.AccessKeyId,
+			"secret-key":    result.SecretAccessKey,
+			"session-token": result.SessionToken,
+		},
+		Expiry: result.Expiration,
+	}, nil
+}
+
+// federatedUserName returns the name of the federated user for the
+// given application, which appears in CloudTrail logs.
+func federatedUserName(appName string) string {
+	name := "juju-" + appName
+	if len(name) > maxFederatedUserName {
+		name = name[:maxFederatedUserName]
+	}
+	return name
+}
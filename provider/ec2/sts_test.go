@@ -0,0 +1,137 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/trust"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/ec2"
+)
+
+type stsSuite struct {
+	testing.IsolationSuite
+
+	requests []url.Values
+	status   int
+	response string
+	minter   environs.CredentialMinter
+}
+
+var _ = gc.Suite(&stsSuite{})
+
+func (s *stsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.requests = nil
+	s.status = http.StatusOK
+	s.response = federationTokenResponse
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		s.requests = append(s.requests, req.URL.Query())
+		w.WriteHeader(s.status)
+		fmt.Fprint(w, s.response)
+	}))
+	s.AddCleanup(func(*gc.C) { server.Close() })
+	s.PatchValue(ec2.STSEndpoint, func(string) string { return server.URL + "/" })
+
+	credential := cloud.NewCredential(cloud.AccessKeyAuthType, map[string]string{
+		"access-key": "model-access-key",
+		"secret-key": "model-secret-key",
+	})
+	s.minter = ec2.NewCredentialMinter(environs.CloudSpec{
+		Type:       "ec2",
+		Name:       "aws",
+		Region:     "us-east-1",
+		Credential: &credential,
+	})
+}
+
+func (s *stsSuite) TestMintCredential(c *gc.C) {
+	credential, err := s.minter.MintCredential(environs.MintCredentialParams{
+		Application: "mysql",
+		Level:       trust.ReadOnly,
+		Duration:    time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(credential, jc.DeepEquals, environs.ScopedCredential{
+		AuthType: cloud.AccessKeyAuthType,
+		Attributes: map[string]string{
+			"access-key":    "scoped-access-key",
+			"secret-key":    "scoped-secret-key",
+			"session-token": "scoped-session-token",
+		},
+		Expiry: time.Date(2018, 4, 1, 12, 0, 0, 0, time.UTC),
+	})
+
+	c.Assert(s.requests, gc.HasLen, 1)
+	query := s.requests[0]
+	c.Check(query.Get("Action"), gc.Equals, "GetFederationToken")
+	c.Check(query.Get("Name"), gc.Equals, "juju-mysql")
+	c.Check(query.Get("DurationSeconds"), gc.Equals, "3600")
+	c.Check(query.Get("Policy"), jc.Contains, `"ec2:Describe*"`)
+}
+
+func (s *stsSuite) TestMintCredentialFull(c *gc.C) {
+	_, err := s.minter.MintCredential(environs.MintCredentialParams{
+		Application: "a-very-long-application-name-indeed",
+		Level:       trust.Full,
+		Duration:    time.Minute,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 1)
+	query := s.requests[0]
+	c.Check(query.Get("Name"), gc.Equals, "juju-a-very-long-application-nam")
+	c.Check(query.Get("DurationSeconds"), gc.Equals, "900")
+	c.Check(query.Get("Policy"), jc.Contains, `"Action": "*"`)
+}
+
+func (s *stsSuite) TestMintCredentialUntrusted(c *gc.C) {
+	_, err := s.minter.MintCredential(environs.MintCredentialParams{
+		Application: "mysql",
+		Level:       trust.None,
+	})
+	c.Assert(err, gc.ErrorMatches, `trust level "none" not valid`)
+	c.Assert(s.requests, gc.HasLen, 0)
+}
+
+func (s *stsSuite) TestMintCredentialError(c *gc.C) {
+	s.status = http.StatusForbidden
+	s.response = `
+<ErrorResponse>
+  <Error>
+    <Type>Sender</Type>
+    <Code>AccessDenied</Code>
+    <Message>not allowed</Message>
+  </Error>
+</ErrorResponse>`
+	_, err := s.minter.MintCredential(environs.MintCredentialParams{
+		Application: "mysql",
+		Level:       trust.ReadOnly,
+		Duration:    time.Hour,
+	})
+	c.Assert(err, gc.ErrorMatches, "requesting federation token: AccessDenied: not allowed")
+}
+
+const federationTokenResponse = `
+<GetFederationTokenResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <GetFederationTokenResult>
+    <Credentials>
+      <SessionToken>scoped-session-token</SessionToken>
+      <SecretAccessKey>scoped-secret-key</SecretAccessKey>
+      <Expiration>2018-04-01T12:00:00Z</Expiration>
+      <AccessKeyId>scoped-access-key</AccessKeyId>
+    </Credentials>
+  </GetFederationTokenResult>
+</GetFederationTokenResponse>`
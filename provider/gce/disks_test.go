@@ -4,6 +4,8 @@
 package gce_test
 
 import (
+	"context"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -101,7 +103,7 @@ func (s *volumeSourceSuite) SetUpTest(c *gc.C) {
 }
 
 func (s *volumeSourceSuite) TestCreateVolumesNoInstance(c *gc.C) {
-	res, err := s.source.CreateVolumes(s.params)
+	res, err := s.source.CreateVolumes(context.Background(), s.params)
 	c.Check(err, jc.ErrorIsNil)
 	c.Check(res, gc.HasLen, 1)
 	expectedErr := "cannot obtain \"spam\" from instance cache: cannot attach to non-running instance spam"
@@ -111,7 +113,7 @@ func (s *volumeSourceSuite) TestCreateVolumesNoInstance(c *gc.C) {
 
 func (s *volumeSourceSuite) TestCreateVolumesNoDiskCreated(c *gc.C) {
 	s.FakeConn.Insts = []google.Instance{*s.BaseInstance}
-	res, err := s.source.CreateVolumes(s.params)
+	res, err := s.source.CreateVolumes(context.Background(), s.params)
 	c.Check(err, jc.ErrorIsNil)
 	c.Check(res, gc.HasLen, 1)
 	c.Assert(res[0].Error, gc.ErrorMatches, "unexpected number of disks created: 0")
@@ -127,7 +129,7 @@ func (s *volumeSourceSuite) TestCreateVolumes(c *gc.C) {
 		DeviceName: "home-zone-1234567",
 		Mode:       "READ_WRITE",
 	}
-	res, err := s.source.CreateVolumes(s.params)
+	res, err := s.source.CreateVolumes(context.Background(), s.params)
 	c.Check(err, jc.ErrorIsNil)
 	c.Check(res, gc.HasLen, 1)
 	// Volume was created
@@ -167,7 +169,7 @@ func (s *volumeSourceSuite) TestCreateVolumes(c *gc.C) {
 }
 
 func (s *volumeSourceSuite) TestDestroyVolumes(c *gc.C) {
-	errs, err := s.source.DestroyVolumes([]string{"a--volume-name"})
+	errs, err := s.source.DestroyVolumes(context.Background(), []string{"a--volume-name"})
 	c.Check(err, jc.ErrorIsNil)
 	c.Check(errs, gc.HasLen, 1)
 	c.Assert(errs[0], jc.ErrorIsNil)
@@ -182,7 +184,7 @@ func (s *volumeSourceSuite) TestDestroyVolumes(c *gc.C) {
 func (s *volumeSourceSuite) TestReleaseVolumes(c *gc.C) {
 	s.FakeConn.GoogleDisk = s.BaseDisk
 
-	errs, err := s.source.ReleaseVolumes([]string{s.BaseDisk.Name})
+	errs, err := s.source.ReleaseVolumes(context.Background(), []string{s.BaseDisk.Name})
 	c.Check(err, jc.ErrorIsNil)
 	c.Check(errs, gc.HasLen, 1)
 	c.Assert(errs[0], jc.ErrorIsNil)
@@ -203,6 +205,7 @@ func (s *volumeSourceSuite) TestImportVolume(c *gc.C) {
 
 	c.Assert(s.source, gc.Implements, new(storage.VolumeImporter))
 	volumeInfo, err := s.source.(storage.VolumeImporter).ImportVolume(
+		context.Background(),
 		s.BaseDisk.Name, map[string]string{
 			"juju-model-uuid":      "foo",
 			"juju-controller-uuid": "bar",
@@ -232,6 +235,7 @@ func (s *volumeSourceSuite) TestImportVolumeNotReady(c *gc.C) {
 	s.FakeConn.GoogleDisk.Status = "floop"
 
 	_, err := s.source.(storage.VolumeImporter).ImportVolume(
+		context.Background(),
 		s.BaseDisk.Name, map[string]string{},
 	)
 	c.Check(err, gc.ErrorMatches, `cannot import volume "`+s.BaseDisk.Name+`" with status "floop"`)
@@ -242,7 +246,7 @@ func (s *volumeSourceSuite) TestImportVolumeNotReady(c *gc.C) {
 
 func (s *volumeSourceSuite) TestListVolumes(c *gc.C) {
 	s.FakeConn.GoogleDisks = []*google.Disk{s.BaseDisk}
-	vols, err := s.source.ListVolumes()
+	vols, err := s.source.ListVolumes(context.Background())
 	c.Check(err, jc.ErrorIsNil)
 	c.Assert(vols, gc.HasLen, 1)
 
@@ -264,7 +268,7 @@ func (s *volumeSourceSuite) TestListVolumesOnlyListsCurrentModelUUID(c *gc.C) {
 		},
 	}
 	s.FakeConn.GoogleDisks = []*google.Disk{s.BaseDisk, otherDisk}
-	vols, err := s.source.ListVolumes()
+	vols, err := s.source.ListVolumes(context.Background())
 	c.Check(err, jc.ErrorIsNil)
 	c.Assert(vols, gc.HasLen, 1)
 }
@@ -279,7 +283,7 @@ func (s *volumeSourceSuite) TestListVolumesIgnoresNamesFormatteDifferently(c *gc
 		Description: "",
 	}
 	s.FakeConn.GoogleDisks = []*google.Disk{s.BaseDisk, otherDisk}
-	vols, err := s.source.ListVolumes()
+	vols, err := s.source.ListVolumes(context.Background())
 	c.Check(err, jc.ErrorIsNil)
 	c.Assert(vols, gc.HasLen, 1)
 }
@@ -287,7 +291,7 @@ func (s *volumeSourceSuite) TestListVolumesIgnoresNamesFormatteDifferently(c *gc
 func (s *volumeSourceSuite) TestDescribeVolumes(c *gc.C) {
 	s.FakeConn.GoogleDisk = s.BaseDisk
 	volName := "home-zone--c930380d-8337-4bf5-b07a-9dbb5ae771e4"
-	res, err := s.source.DescribeVolumes([]string{volName})
+	res, err := s.source.DescribeVolumes(context.Background(), []string{volName})
 	c.Check(err, jc.ErrorIsNil)
 	c.Assert(res, gc.HasLen, 1)
 	c.Assert(res[0].VolumeInfo.Size, gc.Equals, uint64(1024))
@@ -308,7 +312,7 @@ func (s *volumeSourceSuite) TestAttachVolumes(c *gc.C) {
 		DeviceName: "home-zone-1234567",
 		Mode:       "READ_WRITE",
 	}
-	res, err := s.source.AttachVolumes(attachments)
+	res, err := s.source.AttachVolumes(context.Background(), attachments)
 	c.Check(err, jc.ErrorIsNil)
 	c.Assert(res, gc.HasLen, 1)
 	c.Assert(res[0].VolumeAttachment.Volume.String(), gc.Equals, "volume-0")
@@ -329,7 +333,7 @@ func (s *volumeSourceSuite) TestAttachVolumes(c *gc.C) {
 func (s *volumeSourceSuite) TestDetachVolumes(c *gc.C) {
 	volName := "home-zone--c930380d-8337-4bf5-b07a-9dbb5ae771e4"
 	attachments := []storage.VolumeAttachmentParams{*s.attachmentParams}
-	errs, err := s.source.DetachVolumes(attachments)
+	errs, err := s.source.DetachVolumes(context.Background(), attachments)
 	c.Check(err, jc.ErrorIsNil)
 	c.Assert(errs, gc.HasLen, 1)
 	c.Assert(errs[0], jc.ErrorIsNil)
@@ -4,6 +4,7 @@
 package gce
 
 import (
+	"context"
 	"strings"
 	"sync"
 
@@ -221,13 +222,14 @@ func (env *environ) Bootstrap(ctx environs.BootstrapContext, params environs.Boo
 // Destroy shuts down all known machines and destroys the rest of the
 // known environment.
 func (env *environ) Destroy() error {
-	ports, err := env.IngressRules()
+	ctx := context.TODO()
+	ports, err := env.IngressRules(ctx)
 	if err != nil {
 		return errors.Trace(err)
 	}
 
 	if len(ports) > 0 {
-		if err := env.ClosePorts(ports); err != nil {
+		if err := env.ClosePorts(ctx, ports); err != nil {
 			return errors.Trace(err)
 		}
 	}
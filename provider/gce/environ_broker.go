@@ -4,6 +4,7 @@
 package gce
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/juju/errors"
@@ -29,7 +30,7 @@ func (*environ) MaintainInstance(args environs.StartInstanceParams) error {
 }
 
 // StartInstance implements environs.InstanceBroker.
-func (env *environ) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+func (env *environ) StartInstance(ctx context.Context, args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
 	// Start a new instance.
 
 	spec, err := buildInstanceSpec(env, args)
@@ -176,6 +177,7 @@ func (env *environ) newRawInstance(args environs.StartInstanceParams, spec *inst
 		Metadata:          metadata,
 		Tags:              tags,
 		AvailabilityZone:  args.AvailabilityZone,
+		Preemptible:       args.Constraints.HasSpot(),
 		// Network is omitted (left empty).
 	})
 	if err != nil {
@@ -289,7 +291,7 @@ func (env *environ) AllInstances() ([]instance.Instance, error) {
 }
 
 // StopInstances implements environs.InstanceBroker.
-func (env *environ) StopInstances(instances ...instance.Id) error {
+func (env *environ) StopInstances(ctx context.Context, instances ...instance.Id) error {
 	var ids []string
 	for _, id := range instances {
 		ids = append(ids, string(id))
@@ -4,6 +4,7 @@
 package gce_test
 
 import (
+	"context"
 	"errors"
 
 	jc "github.com/juju/testing/checkers"
@@ -88,7 +89,7 @@ func (s *environBrokerSuite) TestStartInstance(c *gc.C) {
 	s.FakeEnviron.Inst = s.BaseInstance
 	s.FakeEnviron.Hwc = s.hardware
 
-	result, err := s.Env.StartInstance(s.StartInstArgs)
+	result, err := s.Env.StartInstance(context.Background(), s.StartInstArgs)
 
 	c.Assert(err, jc.ErrorIsNil)
 	c.Check(result.Instance, jc.DeepEquals, s.Instance)
@@ -98,7 +99,7 @@ func (s *environBrokerSuite) TestStartInstance(c *gc.C) {
 func (s *environBrokerSuite) TestStartInstanceAvailabilityZoneIndependentError(c *gc.C) {
 	s.FakeEnviron.Err = errors.New("blargh")
 
-	_, err := s.Env.StartInstance(s.StartInstArgs)
+	_, err := s.Env.StartInstance(context.Background(), s.StartInstArgs)
 	c.Assert(err, gc.ErrorMatches, "blargh")
 	c.Assert(err, jc.Satisfies, environs.IsAvailabilityZoneIndependent)
 }
@@ -116,7 +117,7 @@ func (s *environBrokerSuite) TestStartInstanceVolumeAvailabilityZone(c *gc.C) {
 	c.Assert(derivedZones, gc.HasLen, 1)
 	s.StartInstArgs.AvailabilityZone = derivedZones[0]
 
-	result, err := s.Env.StartInstance(s.StartInstArgs)
+	result, err := s.Env.StartInstance(context.Background(), s.StartInstArgs)
 
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(*result.Hardware.AvailabilityZone, gc.Equals, derivedZones[0])
@@ -252,7 +253,7 @@ func (s *environBrokerSuite) TestAllInstances(c *gc.C) {
 }
 
 func (s *environBrokerSuite) TestStopInstances(c *gc.C) {
-	err := s.Env.StopInstances(s.Instance.Id())
+	err := s.Env.StopInstances(context.Background(), s.Instance.Id())
 	c.Assert(err, jc.ErrorIsNil)
 
 	called, calls := s.FakeConn.WasCalled("RemoveInstances")
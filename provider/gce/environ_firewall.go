@@ -4,6 +4,8 @@
 package gce
 
 import (
+	"context"
+
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/network"
@@ -18,7 +20,7 @@ func (env *environ) globalFirewallName() string {
 // OpenPorts opens the given port ranges for the whole environment.
 // Must only be used if the environment was setup with the
 // FwGlobal firewall mode.
-func (env *environ) OpenPorts(rules []network.IngressRule) error {
+func (env *environ) OpenPorts(ctx context.Context, rules []network.IngressRule) error {
 	err := env.gce.OpenPorts(env.globalFirewallName(), rules...)
 	return errors.Trace(err)
 }
@@ -26,7 +28,7 @@ func (env *environ) OpenPorts(rules []network.IngressRule) error {
 // ClosePorts closes the given port ranges for the whole environment.
 // Must only be used if the environment was setup with the
 // FwGlobal firewall mode.
-func (env *environ) ClosePorts(rules []network.IngressRule) error {
+func (env *environ) ClosePorts(ctx context.Context, rules []network.IngressRule) error {
 	err := env.gce.ClosePorts(env.globalFirewallName(), rules...)
 	return errors.Trace(err)
 }
@@ -34,7 +36,7 @@ func (env *environ) ClosePorts(rules []network.IngressRule) error {
 // IngressRules returns the ingress rules applicable for the whole environment.
 // Must only be used if the environment was setup with the
 // FwGlobal firewall mode.
-func (env *environ) IngressRules() ([]network.IngressRule, error) {
+func (env *environ) IngressRules(ctx context.Context) ([]network.IngressRule, error) {
 	rules, err := env.gce.IngressRules(env.globalFirewallName())
 	return rules, errors.Trace(err)
 }
@@ -4,6 +4,8 @@
 package gce_test
 
 import (
+	"context"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -24,14 +26,14 @@ func (s *environFirewallSuite) TestGlobalFirewallName(c *gc.C) {
 }
 
 func (s *environFirewallSuite) TestOpenPorts(c *gc.C) {
-	err := s.Env.OpenPorts(s.Rules)
+	err := s.Env.OpenPorts(context.Background(), s.Rules)
 
 	c.Check(err, jc.ErrorIsNil)
 }
 
 func (s *environFirewallSuite) TestOpenPortsAPI(c *gc.C) {
 	fwname := gce.GlobalFirewallName(s.Env)
-	err := s.Env.OpenPorts(s.Rules)
+	err := s.Env.OpenPorts(context.Background(), s.Rules)
 	c.Assert(err, jc.ErrorIsNil)
 
 	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
@@ -41,14 +43,14 @@ func (s *environFirewallSuite) TestOpenPortsAPI(c *gc.C) {
 }
 
 func (s *environFirewallSuite) TestClosePorts(c *gc.C) {
-	err := s.Env.ClosePorts(s.Rules)
+	err := s.Env.ClosePorts(context.Background(), s.Rules)
 
 	c.Check(err, jc.ErrorIsNil)
 }
 
 func (s *environFirewallSuite) TestClosePortsAPI(c *gc.C) {
 	fwname := gce.GlobalFirewallName(s.Env)
-	err := s.Env.ClosePorts(s.Rules)
+	err := s.Env.ClosePorts(context.Background(), s.Rules)
 	c.Assert(err, jc.ErrorIsNil)
 
 	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
@@ -60,7 +62,7 @@ func (s *environFirewallSuite) TestClosePortsAPI(c *gc.C) {
 func (s *environFirewallSuite) TestPorts(c *gc.C) {
 	s.FakeConn.Rules = s.Rules
 
-	ports, err := s.Env.IngressRules()
+	ports, err := s.Env.IngressRules(context.Background())
 	c.Assert(err, jc.ErrorIsNil)
 
 	c.Check(ports, jc.DeepEquals, s.Rules)
@@ -68,7 +70,7 @@ func (s *environFirewallSuite) TestPorts(c *gc.C) {
 
 func (s *environFirewallSuite) TestPortsAPI(c *gc.C) {
 	fwname := gce.GlobalFirewallName(s.Env)
-	_, err := s.Env.IngressRules()
+	_, err := s.Env.IngressRules(context.Background())
 	c.Assert(err, jc.ErrorIsNil)
 
 	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
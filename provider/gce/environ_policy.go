@@ -72,6 +72,10 @@ func (env *environ) ConstraintsValidator() (constraints.Validator, error) {
 
 	validator.RegisterVocabulary(constraints.Container, []string{vtype})
 
+	// spot
+
+	validator.RegisterSpotSupport()
+
 	return validator, nil
 }
 
@@ -52,6 +52,11 @@ type InstanceSpec struct {
 	// AvailabilityZone holds the name of the availability zone in which
 	// to create the instance.
 	AvailabilityZone string
+
+	// Preemptible indicates that the instance should be preemptible:
+	// cheaper, but liable to be stopped by GCE at any time, and
+	// always within 24 hours.
+	Preemptible bool
 }
 
 func (is InstanceSpec) raw() *compute.Instance {
@@ -61,10 +66,26 @@ func (is InstanceSpec) raw() *compute.Instance {
 		NetworkInterfaces: is.networkInterfaces(),
 		Metadata:          packMetadata(is.Metadata),
 		Tags:              &compute.Tags{Items: is.Tags},
+		Scheduling:        is.scheduling(),
 		// MachineType is set in the addInstance call.
 	}
 }
 
+// scheduling returns the scheduling options for a preemptible
+// instance, or nil to accept GCE's defaults. Preemptible instances
+// can neither be restarted automatically nor migrated live.
+func (is InstanceSpec) scheduling() *compute.Scheduling {
+	if !is.Preemptible {
+		return nil
+	}
+	automaticRestart := false
+	return &compute.Scheduling{
+		Preemptible:       true,
+		AutomaticRestart:  &automaticRestart,
+		OnHostMaintenance: "TERMINATE",
+	}
+}
+
 // Summary builds an InstanceSummary based on the spec and returns it.
 func (is InstanceSpec) Summary() InstanceSummary {
 	raw := is.raw()
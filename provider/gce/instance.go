@@ -4,6 +4,8 @@
 package gce
 
 import (
+	"context"
+
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/instance"
@@ -69,7 +71,7 @@ func findInst(id instance.Id, instances []instance.Instance) instance.Instance {
 
 // OpenPorts opens the given ports on the instance, which
 // should have been started with the given machine id.
-func (inst *environInstance) OpenPorts(machineID string, rules []network.IngressRule) error {
+func (inst *environInstance) OpenPorts(ctx context.Context, machineID string, rules []network.IngressRule) error {
 	// TODO(ericsnow) Make sure machineId matches inst.Id()?
 	name, err := inst.env.namespace.Hostname(machineID)
 	if err != nil {
@@ -81,7 +83,7 @@ func (inst *environInstance) OpenPorts(machineID string, rules []network.Ingress
 
 // ClosePorts closes the given ports on the instance, which
 // should have been started with the given machine id.
-func (inst *environInstance) ClosePorts(machineID string, rules []network.IngressRule) error {
+func (inst *environInstance) ClosePorts(ctx context.Context, machineID string, rules []network.IngressRule) error {
 	name, err := inst.env.namespace.Hostname(machineID)
 	if err != nil {
 		return errors.Trace(err)
@@ -93,7 +95,7 @@ func (inst *environInstance) ClosePorts(machineID string, rules []network.Ingres
 // IngressRules returns the set of ingress rules applicable to the instance, which
 // should have been started with the given machine id.
 // The rules are returned as sorted by SortIngressRules.
-func (inst *environInstance) IngressRules(machineID string) ([]network.IngressRule, error) {
+func (inst *environInstance) IngressRules(ctx context.Context, machineID string) ([]network.IngressRule, error) {
 	name, err := inst.env.namespace.Hostname(machineID)
 	if err != nil {
 		return nil, errors.Trace(err)
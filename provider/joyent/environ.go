@@ -113,6 +113,10 @@ func (env *joyentEnviron) Bootstrap(ctx environs.BootstrapContext, args environs
 	return common.Bootstrap(ctx, env, args)
 }
 
+func (env *joyentEnviron) ResumeBootstrap(ctx environs.BootstrapContext, id instance.Id, args environs.BootstrapParams) (*environs.BootstrapResult, error) {
+	return common.ResumeBootstrap(ctx, env, id, args)
+}
+
 func (env *joyentEnviron) ControllerInstances(controllerUUID string) ([]instance.Id, error) {
 	instanceIds := []instance.Id{}
 
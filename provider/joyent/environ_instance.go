@@ -4,6 +4,7 @@
 package joyent
 
 import (
+	"context"
 	"strings"
 	"sync"
 	"time"
@@ -71,7 +72,7 @@ func (*joyentEnviron) MaintainInstance(args environs.StartInstanceParams) error
 	return nil
 }
 
-func (env *joyentEnviron) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+func (env *joyentEnviron) StartInstance(ctx context.Context, args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
 	series := args.Tools.OneSeries()
 	arches := args.Tools.Arches()
 	spec, err := env.FindInstanceSpec(&instances.InstanceConstraint{
@@ -247,7 +248,7 @@ func (env *joyentEnviron) Instances(ids []instance.Id) ([]instance.Instance, err
 	return instances, nil
 }
 
-func (env *joyentEnviron) StopInstances(ids ...instance.Id) error {
+func (env *joyentEnviron) StopInstances(ctx context.Context, ids ...instance.Id) error {
 	// Remove all the instances in parallel so that we incur less round-trips.
 	var wg sync.WaitGroup
 	//var err error
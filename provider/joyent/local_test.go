@@ -4,6 +4,7 @@
 package joyent_test
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 
@@ -172,7 +173,7 @@ func (s *localServerSuite) TestStartInstance(c *gc.C) {
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	inst, _ := testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
-	err = env.StopInstances(inst.Id())
+	err = env.StopInstances(context.Background(), inst.Id())
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -185,7 +186,7 @@ func (s *localServerSuite) TestStartInstanceAvailabilityZone(c *gc.C) {
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	inst, hwc := testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
-	err = env.StopInstances(inst.Id())
+	err = env.StopInstances(context.Background(), inst.Id())
 	c.Assert(err, jc.ErrorIsNil)
 
 	c.Check(hwc.AvailabilityZone, gc.IsNil)
@@ -257,7 +258,7 @@ func (s *localServerSuite) TestInstanceStatus(c *gc.C) {
 	env := s.Prepare(c)
 	inst, _ := testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
 	c.Assert(inst.Status().Message, gc.Equals, "running")
-	err := env.StopInstances(inst.Id())
+	err := env.StopInstances(context.Background(), inst.Id())
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -272,8 +273,8 @@ func (s *localServerSuite) TestInstancesGathering(c *gc.C) {
 		// StopInstances deletes machines in parallel but the Joyent
 		// API test double isn't goroutine-safe so stop them one at a
 		// time. See https://pad.lv/1604514
-		c.Check(env.StopInstances(inst0.Id()), jc.ErrorIsNil)
-		c.Check(env.StopInstances(inst1.Id()), jc.ErrorIsNil)
+		c.Check(env.StopInstances(context.Background(), inst0.Id()), jc.ErrorIsNil)
+		c.Check(env.StopInstances(context.Background(), inst1.Id()), jc.ErrorIsNil)
 	}()
 
 	for i, test := range instanceGathering {
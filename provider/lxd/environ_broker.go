@@ -6,6 +6,7 @@
 package lxd
 
 import (
+	"context"
 	"strings"
 
 	"github.com/juju/errors"
@@ -28,7 +29,7 @@ func (*environ) MaintainInstance(args environs.StartInstanceParams) error {
 }
 
 // StartInstance implements environs.InstanceBroker.
-func (env *environ) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+func (env *environ) StartInstance(ctx context.Context, args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
 	// Start a new instance.
 
 	series := args.Tools.OneSeries()
@@ -286,7 +287,7 @@ func (env *environ) AllInstances() ([]instance.Instance, error) {
 }
 
 // StopInstances implements environs.InstanceBroker.
-func (env *environ) StopInstances(instances ...instance.Id) error {
+func (env *environ) StopInstances(ctx context.Context, instances ...instance.Id) error {
 	var ids []string
 	for _, id := range instances {
 		ids = append(ids, string(id))
@@ -6,6 +6,8 @@
 package lxd_test
 
 import (
+	"context"
+
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/arch"
@@ -30,7 +32,7 @@ func (s *environBrokerSuite) TestStartInstance(c *gc.C) {
 	// Patch the host's arch, so the broker will filter tools.
 	s.PatchValue(&arch.HostArch, func() string { return arch.ARM64 })
 
-	result, err := s.Env.StartInstance(s.StartInstArgs)
+	result, err := s.Env.StartInstance(context.Background(), s.StartInstArgs)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Check(result.Instance, gc.DeepEquals, s.Instance)
 	c.Check(result.Hardware, gc.DeepEquals, s.HWC)
@@ -46,12 +48,12 @@ func (s *environBrokerSuite) TestStartInstanceNoTools(c *gc.C) {
 	// Patch the host's arch, so the broker will filter tools.
 	s.PatchValue(&arch.HostArch, func() string { return arch.PPC64EL })
 
-	_, err := s.Env.StartInstance(s.StartInstArgs)
+	_, err := s.Env.StartInstance(context.Background(), s.StartInstArgs)
 	c.Assert(err, gc.ErrorMatches, "no matching agent binaries available")
 }
 
 func (s *environBrokerSuite) TestStopInstances(c *gc.C) {
-	err := s.Env.StopInstances(s.Instance.Id())
+	err := s.Env.StopInstances(context.Background(), s.Instance.Id())
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.Stub.CheckCalls(c, []gitjujutesting.StubCall{{
@@ -4,6 +4,8 @@
 package lxd_test
 
 import (
+	"context"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -97,7 +99,7 @@ func (s *storageSuite) TestScope(c *gc.C) {
 
 func (s *storageSuite) TestCreateFilesystems(c *gc.C) {
 	source := s.filesystemSource(c, "source")
-	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+	results, err := source.CreateFilesystems(context.Background(), []storage.FilesystemParams{{
 		Tag:      names.NewFilesystemTag("0"),
 		Provider: "lxd",
 		Size:     1024,
@@ -132,7 +134,7 @@ func (s *storageSuite) TestCreateFilesystems(c *gc.C) {
 func (s *storageSuite) TestCreateFilesystemsPoolExists(c *gc.C) {
 	s.Stub.SetErrors(errors.New("pool already exists"))
 	source := s.filesystemSource(c, "source")
-	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+	results, err := source.CreateFilesystems(context.Background(), []storage.FilesystemParams{{
 		Tag:      names.NewFilesystemTag("0"),
 		Provider: "lxd",
 		Size:     1024,
@@ -167,7 +169,7 @@ func (s *storageSuite) TestCreateFilesystemsPoolExists(c *gc.C) {
 func (s *storageSuite) TestDestroyFilesystems(c *gc.C) {
 	s.Stub.SetErrors(nil, errors.New("boom"))
 	source := s.filesystemSource(c, "source")
-	results, err := source.DestroyFilesystems([]string{
+	results, err := source.DestroyFilesystems(context.Background(), []string{
 		"filesystem-0",
 		"pool0:filesystem-0",
 		"pool1:filesystem-1",
@@ -207,7 +209,7 @@ func (s *storageSuite) TestReleaseFilesystems(c *gc.C) {
 	}
 
 	source := s.filesystemSource(c, "source")
-	results, err := source.ReleaseFilesystems([]string{
+	results, err := source.ReleaseFilesystems(context.Background(), []string{
 		"filesystem-0",
 		"foo:filesystem-0",
 		"foo:filesystem-1",
@@ -255,7 +257,7 @@ func (s *storageSuite) TestAttachFilesystems(c *gc.C) {
 	s.Client.Insts = []lxdclient.Instance{*raw}
 
 	source := s.filesystemSource(c, "pool")
-	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+	results, err := source.AttachFilesystems(context.Background(), []storage.FilesystemAttachmentParams{{
 		AttachmentParams: storage.AttachmentParams{
 			Provider:   "lxd",
 			Machine:    names.NewMachineTag("123"),
@@ -339,7 +341,7 @@ func (s *storageSuite) TestDetachFilesystems(c *gc.C) {
 	s.Client.Insts = []lxdclient.Instance{*raw}
 
 	source := s.filesystemSource(c, "pool")
-	results, err := source.DetachFilesystems([]storage.FilesystemAttachmentParams{{
+	results, err := source.DetachFilesystems(context.Background(), []storage.FilesystemAttachmentParams{{
 		AttachmentParams: storage.AttachmentParams{
 			Provider:   "lxd",
 			Machine:    names.NewMachineTag("123"),
@@ -394,7 +396,7 @@ func (s *storageSuite) TestImportFilesystem(c *gc.C) {
 		}},
 	}
 
-	info, err := importer.ImportFilesystem("foo:bar", map[string]string{
+	info, err := importer.ImportFilesystem(context.Background(), "foo:bar", map[string]string{
 		"baz": "qux",
 	})
 	c.Assert(err, jc.ErrorIsNil)
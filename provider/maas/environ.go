@@ -4,6 +4,7 @@
 package maas
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -169,7 +170,7 @@ func (env *maasEnviron) Bootstrap(ctx environs.BootstrapContext, args environs.B
 	// We want to destroy the started instance if it doesn't transition to Deployed.
 	defer func() {
 		if err != nil {
-			if err := env.StopInstances(result.Instance.Id()); err != nil {
+			if err := env.StopInstances(ctx.Context(), result.Instance.Id()); err != nil {
 				logger.Errorf("error releasing bootstrap instance: %v", err)
 			}
 		}
@@ -900,6 +901,7 @@ func (*maasEnviron) MaintainInstance(args environs.StartInstanceParams) error {
 
 // StartInstance is specified in the InstanceBroker interface.
 func (environ *maasEnviron) StartInstance(
+	ctx context.Context,
 	args environs.StartInstanceParams,
 ) (_ *environs.StartInstanceResult, err error) {
 
@@ -969,7 +971,7 @@ func (environ *maasEnviron) StartInstance(
 
 	defer func() {
 		if err != nil {
-			if err := environ.StopInstances(inst.Id()); err != nil {
+			if err := environ.StopInstances(ctx, inst.Id()); err != nil {
 				logger.Errorf("error releasing failed instance: %v", err)
 			}
 		}
@@ -1469,7 +1471,7 @@ func instanceIdsToSystemIDs(ids []instance.Id) []string {
 }
 
 // StopInstances is specified in the InstanceBroker interface.
-func (environ *maasEnviron) StopInstances(ids ...instance.Id) error {
+func (environ *maasEnviron) StopInstances(ctx context.Context, ids ...instance.Id) error {
 	// Shortcut to exit quickly if 'instances' is an empty slice or nil.
 	if len(ids) == 0 {
 		return nil
@@ -5,6 +5,7 @@ package maas
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -227,7 +228,7 @@ func (suite *environSuite) getInstance(systemId string) *maas1Instance {
 func (suite *environSuite) TestStopInstancesReturnsIfParameterEmpty(c *gc.C) {
 	suite.getInstance("test1")
 
-	err := suite.makeEnviron().StopInstances()
+	err := suite.makeEnviron().StopInstances(context.Background())
 	c.Check(err, jc.ErrorIsNil)
 	operations := suite.testMAASObject.TestServer.NodeOperations()
 	c.Check(operations, gc.DeepEquals, map[string][]string{})
@@ -242,7 +243,7 @@ func (suite *environSuite) TestStopInstancesStopsAndReleasesInstances(c *gc.C) {
 	suite.testMAASObject.TestServer.OwnedNodes()["test1"] = true
 	suite.testMAASObject.TestServer.OwnedNodes()["test2"] = true
 
-	err := suite.makeEnviron().StopInstances("test1", "test2", "test3")
+	err := suite.makeEnviron().StopInstances(context.Background(), "test1", "test2", "test3")
 	c.Check(err, jc.ErrorIsNil)
 	operations := suite.testMAASObject.TestServer.NodesOperations()
 	c.Check(operations, gc.DeepEquals, []string{"release"})
@@ -256,7 +257,7 @@ func (suite *environSuite) TestStopInstancesIgnoresConflict(c *gc.C) {
 	}
 	suite.PatchValue(&ReleaseNodes, releaseNodes)
 	env := suite.makeEnviron()
-	err := env.StopInstances("test1")
+	err := env.StopInstances(context.Background(), "test1")
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -268,7 +269,7 @@ func (suite *environSuite) TestStopInstancesIgnoresMissingNodeAndRecurses(c *gc.
 	}
 	suite.PatchValue(&ReleaseNodes, releaseNodes)
 	env := suite.makeEnviron()
-	err := env.StopInstances("test1", "test2")
+	err := env.StopInstances(context.Background(), "test1", "test2")
 	c.Assert(err, jc.ErrorIsNil)
 
 	expectedNodes := [][]string{{"test1", "test2"}, {"test1"}, {"test2"}}
@@ -281,7 +282,7 @@ func (suite *environSuite) TestStopInstancesReturnsUnexpectedMAASError(c *gc.C)
 	}
 	suite.PatchValue(&ReleaseNodes, releaseNodes)
 	env := suite.makeEnviron()
-	err := env.StopInstances("test1")
+	err := env.StopInstances(context.Background(), "test1")
 	c.Assert(err, gc.NotNil)
 	maasErr, ok := errors.Cause(err).(gomaasapi.ServerError)
 	c.Assert(ok, jc.IsTrue)
@@ -294,7 +295,7 @@ func (suite *environSuite) TestStopInstancesReturnsUnexpectedError(c *gc.C) {
 	}
 	suite.PatchValue(&ReleaseNodes, releaseNodes)
 	env := suite.makeEnviron()
-	err := env.StopInstances("test1")
+	err := env.StopInstances(context.Background(), "test1")
 	c.Assert(err, gc.NotNil)
 	c.Assert(errors.Cause(err), gc.Equals, environs.ErrNoInstances)
 }
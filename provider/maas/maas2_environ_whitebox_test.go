@@ -4,6 +4,7 @@
 package maas
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -230,7 +231,7 @@ func collectReleaseArgs(controller *fakeController) []gomaasapi.ReleaseMachinesA
 
 func (suite *maas2EnvironSuite) TestStopInstancesReturnsIfParameterEmpty(c *gc.C) {
 	controller := newFakeController()
-	err := suite.makeEnviron(c, controller).StopInstances()
+	err := suite.makeEnviron(c, controller).StopInstances(context.Background())
 	c.Check(err, jc.ErrorIsNil)
 	c.Assert(collectReleaseArgs(controller), gc.HasLen, 0)
 }
@@ -239,7 +240,7 @@ func (suite *maas2EnvironSuite) TestStopInstancesStopsAndReleasesInstances(c *gc
 	// Return a cannot complete indicating that test1 is in the wrong state.
 	// The release operation will still release the others and succeed.
 	controller := newFakeControllerWithFiles(&fakeFile{name: coretesting.ModelTag.Id() + "-provider-state"})
-	err := suite.makeEnviron(c, controller).StopInstances("test1", "test2", "test3")
+	err := suite.makeEnviron(c, controller).StopInstances(context.Background(), "test1", "test2", "test3")
 	c.Check(err, jc.ErrorIsNil)
 	args := collectReleaseArgs(controller)
 	c.Assert(args, gc.HasLen, 1)
@@ -251,7 +252,7 @@ func (suite *maas2EnvironSuite) TestStopInstancesIgnoresConflict(c *gc.C) {
 	// The release operation will still release the others and succeed.
 	controller := newFakeControllerWithFiles(&fakeFile{name: coretesting.ModelTag.Id() + "-provider-state"})
 	controller.SetErrors(gomaasapi.NewCannotCompleteError("test1 not allocated"))
-	err := suite.makeEnviron(c, controller).StopInstances("test1", "test2", "test3")
+	err := suite.makeEnviron(c, controller).StopInstances(context.Background(), "test1", "test2", "test3")
 	c.Check(err, jc.ErrorIsNil)
 
 	args := collectReleaseArgs(controller)
@@ -265,7 +266,7 @@ func (suite *maas2EnvironSuite) TestStopInstancesIgnoresMissingNodeAndRecurses(c
 		gomaasapi.NewBadRequestError("no such machine: test1"),
 		gomaasapi.NewBadRequestError("no such machine: test1"),
 	)
-	err := suite.makeEnviron(c, controller).StopInstances("test1", "test2", "test3")
+	err := suite.makeEnviron(c, controller).StopInstances(context.Background(), "test1", "test2", "test3")
 	c.Check(err, jc.ErrorIsNil)
 	args := collectReleaseArgs(controller)
 	c.Assert(args, gc.HasLen, 4)
@@ -278,7 +279,7 @@ func (suite *maas2EnvironSuite) TestStopInstancesIgnoresMissingNodeAndRecurses(c
 func (suite *maas2EnvironSuite) checkStopInstancesFails(c *gc.C, withError error) {
 	controller := newFakeControllerWithFiles(&fakeFile{name: coretesting.ModelTag.Id() + "-provider-state"})
 	controller.SetErrors(withError)
-	err := suite.makeEnviron(c, controller).StopInstances("test1", "test2", "test3")
+	err := suite.makeEnviron(c, controller).StopInstances(context.Background(), "test1", "test2", "test3")
 	c.Check(err, gc.ErrorMatches, fmt.Sprintf("cannot release nodes: %s", withError))
 	// Only tries once.
 	c.Assert(collectReleaseArgs(controller), gc.HasLen, 1)
@@ -297,7 +298,7 @@ func (suite *maas2EnvironSuite) TestStartInstanceError(c *gc.C) {
 		allocateMachineError: errors.New("Charles Babbage"),
 	})
 	env := suite.makeEnviron(c, nil)
-	_, err := env.StartInstance(environs.StartInstanceParams{})
+	_, err := env.StartInstance(context.Background(), environs.StartInstanceParams{})
 	c.Assert(err, gc.ErrorMatches, "failed to acquire node: Charles Babbage")
 }
 
@@ -4,6 +4,7 @@
 package maas
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -75,17 +76,17 @@ func (mi *maas2Instance) Status() instance.InstanceStatus {
 }
 
 // MAAS does not do firewalling so these port methods do nothing.
-func (mi *maas2Instance) OpenPorts(machineId string, rules []network.IngressRule) error {
+func (mi *maas2Instance) OpenPorts(ctx context.Context, machineId string, rules []network.IngressRule) error {
 	logger.Debugf("unimplemented OpenPorts() called")
 	return nil
 }
 
-func (mi *maas2Instance) ClosePorts(machineId string, rules []network.IngressRule) error {
+func (mi *maas2Instance) ClosePorts(ctx context.Context, machineId string, rules []network.IngressRule) error {
 	logger.Debugf("unimplemented ClosePorts() called")
 	return nil
 }
 
-func (mi *maas2Instance) IngressRules(machineId string) ([]network.IngressRule, error) {
+func (mi *maas2Instance) IngressRules(ctx context.Context, machineId string) ([]network.IngressRule, error) {
 	logger.Debugf("unimplemented Rules() called")
 	return nil, nil
 }
@@ -5,6 +5,7 @@ package manual
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"os"
 	"path"
@@ -64,11 +65,11 @@ func (*manualEnviron) MaintainInstance(args environs.StartInstanceParams) error
 	return nil
 }
 
-func (*manualEnviron) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+func (*manualEnviron) StartInstance(ctx context.Context, args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
 	return nil, errNoStartInstance
 }
 
-func (*manualEnviron) StopInstances(...instance.Id) error {
+func (*manualEnviron) StopInstances(context.Context, ...instance.Id) error {
 	return errNoStopInstance
 }
 
@@ -357,15 +358,15 @@ func (e *manualEnviron) seriesAndHardwareCharacteristics() (_ *instance.Hardware
 	return e.hw, e.series, nil
 }
 
-func (e *manualEnviron) OpenPorts(rules []network.IngressRule) error {
+func (e *manualEnviron) OpenPorts(ctx context.Context, rules []network.IngressRule) error {
 	return nil
 }
 
-func (e *manualEnviron) ClosePorts(rules []network.IngressRule) error {
+func (e *manualEnviron) ClosePorts(ctx context.Context, rules []network.IngressRule) error {
 	return nil
 }
 
-func (e *manualEnviron) IngressRules() ([]network.IngressRule, error) {
+func (e *manualEnviron) IngressRules(ctx context.Context) ([]network.IngressRule, error) {
 	return nil, nil
 }
 
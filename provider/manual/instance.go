@@ -4,6 +4,8 @@
 package manual
 
 import (
+	"context"
+
 	"github.com/juju/juju/environs/manual"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
@@ -38,14 +40,14 @@ func (inst manualBootstrapInstance) Addresses() (addresses []network.Address, er
 	return []network.Address{addr}, nil
 }
 
-func (manualBootstrapInstance) OpenPorts(machineId string, rules []network.IngressRule) error {
+func (manualBootstrapInstance) OpenPorts(ctx context.Context, machineId string, rules []network.IngressRule) error {
 	return nil
 }
 
-func (manualBootstrapInstance) ClosePorts(machineId string, rules []network.IngressRule) error {
+func (manualBootstrapInstance) ClosePorts(ctx context.Context, machineId string, rules []network.IngressRule) error {
 	return nil
 }
 
-func (manualBootstrapInstance) IngressRules(machineId string) ([]network.IngressRule, error) {
+func (manualBootstrapInstance) IngressRules(ctx context.Context, machineId string) ([]network.IngressRule, error) {
 	return nil, nil
 }
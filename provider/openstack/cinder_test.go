@@ -4,6 +4,7 @@
 package openstack_test
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -67,7 +68,7 @@ func (s *cinderVolumeSourceSuite) TestAttachVolumes(c *gc.C) {
 	}
 
 	volSource := openstack.NewCinderVolumeSource(mockAdapter)
-	results, err := volSource.AttachVolumes([]storage.VolumeAttachmentParams{{
+	results, err := volSource.AttachVolumes(context.Background(), []storage.VolumeAttachmentParams{{
 		Volume:   mockVolumeTag,
 		VolumeId: mockVolId,
 		AttachmentParams: storage.AttachmentParams{
@@ -101,7 +102,7 @@ func (s *cinderVolumeSourceSuite) TestAttachVolumesNoDevice(c *gc.C) {
 	}
 
 	volSource := openstack.NewCinderVolumeSource(mockAdapter)
-	results, err := volSource.AttachVolumes([]storage.VolumeAttachmentParams{{
+	results, err := volSource.AttachVolumes(context.Background(), []storage.VolumeAttachmentParams{{
 		Volume:   mockVolumeTag,
 		VolumeId: mockVolId,
 		AttachmentParams: storage.AttachmentParams{
@@ -159,7 +160,7 @@ func (s *cinderVolumeSourceSuite) TestCreateVolume(c *gc.C) {
 	}
 
 	volSource := openstack.NewCinderVolumeSource(mockAdapter)
-	results, err := volSource.CreateVolumes([]storage.VolumeParams{{
+	results, err := volSource.CreateVolumes(context.Background(), []storage.VolumeParams{{
 		Provider: openstack.CinderProviderType,
 		Tag:      mockVolumeTag,
 		Size:     requestedSize,
@@ -211,7 +212,7 @@ func (s *cinderVolumeSourceSuite) TestCreateVolumeVolumeType(c *gc.C) {
 	}
 
 	volSource := openstack.NewCinderVolumeSource(mockAdapter)
-	_, err := volSource.CreateVolumes([]storage.VolumeParams{{
+	_, err := volSource.CreateVolumes(context.Background(), []storage.VolumeParams{{
 		Provider: openstack.CinderProviderType,
 		Tag:      mockVolumeTag,
 		Size:     1024,
@@ -256,7 +257,7 @@ func (s *cinderVolumeSourceSuite) TestResourceTags(c *gc.C) {
 	}
 
 	volSource := openstack.NewCinderVolumeSource(mockAdapter)
-	_, err := volSource.CreateVolumes([]storage.VolumeParams{{
+	_, err := volSource.CreateVolumes(context.Background(), []storage.VolumeParams{{
 		Provider: openstack.CinderProviderType,
 		Tag:      mockVolumeTag,
 		Size:     1024,
@@ -295,7 +296,7 @@ func (s *cinderVolumeSourceSuite) TestListVolumes(c *gc.C) {
 		},
 	}
 	volSource := openstack.NewCinderVolumeSource(mockAdapter)
-	volumeIds, err := volSource.ListVolumes()
+	volumeIds, err := volSource.ListVolumes(context.Background())
 	c.Assert(err, jc.ErrorIsNil)
 	c.Check(volumeIds, jc.DeepEquals, []string{"volume-3"})
 }
@@ -310,7 +311,7 @@ func (s *cinderVolumeSourceSuite) TestDescribeVolumes(c *gc.C) {
 		},
 	}
 	volSource := openstack.NewCinderVolumeSource(mockAdapter)
-	volumes, err := volSource.DescribeVolumes([]string{mockVolId})
+	volumes, err := volSource.DescribeVolumes(context.Background(), []string{mockVolId})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Check(volumes, jc.DeepEquals, []storage.DescribeVolumesResult{{
 		VolumeInfo: &storage.VolumeInfo{
@@ -324,7 +325,7 @@ func (s *cinderVolumeSourceSuite) TestDescribeVolumes(c *gc.C) {
 func (s *cinderVolumeSourceSuite) TestDestroyVolumes(c *gc.C) {
 	mockAdapter := &mockAdapter{}
 	volSource := openstack.NewCinderVolumeSource(mockAdapter)
-	errs, err := volSource.DestroyVolumes([]string{mockVolId})
+	errs, err := volSource.DestroyVolumes(context.Background(), []string{mockVolId})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(errs, jc.DeepEquals, []error{nil})
 	mockAdapter.CheckCalls(c, []gitjujutesting.StubCall{
@@ -349,7 +350,7 @@ func (s *cinderVolumeSourceSuite) TestDestroyVolumesAttached(c *gc.C) {
 	}
 
 	volSource := openstack.NewCinderVolumeSource(mockAdapter)
-	errs, err := volSource.DestroyVolumes([]string{mockVolId})
+	errs, err := volSource.DestroyVolumes(context.Background(), []string{mockVolId})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(errs, gc.HasLen, 1)
 	c.Assert(errs[0], jc.ErrorIsNil)
@@ -368,7 +369,7 @@ func (s *cinderVolumeSourceSuite) TestDestroyVolumesAttached(c *gc.C) {
 func (s *cinderVolumeSourceSuite) TestReleaseVolumes(c *gc.C) {
 	mockAdapter := &mockAdapter{}
 	volSource := openstack.NewCinderVolumeSource(mockAdapter)
-	errs, err := volSource.ReleaseVolumes([]string{mockVolId})
+	errs, err := volSource.ReleaseVolumes(context.Background(), []string{mockVolId})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(errs, jc.DeepEquals, []error{nil})
 	metadata := map[string]string{
@@ -392,7 +393,7 @@ func (s *cinderVolumeSourceSuite) TestReleaseVolumesAttached(c *gc.C) {
 	}
 
 	volSource := openstack.NewCinderVolumeSource(mockAdapter)
-	errs, err := volSource.ReleaseVolumes([]string{mockVolId})
+	errs, err := volSource.ReleaseVolumes(context.Background(), []string{mockVolId})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(errs, gc.HasLen, 1)
 	c.Assert(errs[0], gc.ErrorMatches, `cannot release volume "0": volume still in-use`)
@@ -417,7 +418,7 @@ func (s *cinderVolumeSourceSuite) TestReleaseVolumesDetaching(c *gc.C) {
 	}
 
 	volSource := openstack.NewCinderVolumeSource(mockAdapter)
-	errs, err := volSource.ReleaseVolumes([]string{mockVolId})
+	errs, err := volSource.ReleaseVolumes(context.Background(), []string{mockVolId})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(errs, gc.HasLen, 1)
 	c.Assert(errs[0], jc.ErrorIsNil)
@@ -453,7 +454,7 @@ func (s *cinderVolumeSourceSuite) TestDetachVolumes(c *gc.C) {
 	}
 
 	volSource := openstack.NewCinderVolumeSource(mockAdapter)
-	errs, err := volSource.DetachVolumes([]storage.VolumeAttachmentParams{{
+	errs, err := volSource.DetachVolumes(context.Background(), []storage.VolumeAttachmentParams{{
 		Volume:   names.NewVolumeTag("123"),
 		VolumeId: mockVolId,
 		AttachmentParams: storage.AttachmentParams{
@@ -546,7 +547,7 @@ func (s *cinderVolumeSourceSuite) TestCreateVolumeCleanupDestroys(c *gc.C) {
 			},
 		},
 	}}
-	results, err := volSource.CreateVolumes(volumeParams)
+	results, err := volSource.CreateVolumes(context.Background(), volumeParams)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, gc.HasLen, 3)
 	c.Assert(results[0].Error, jc.ErrorIsNil)
@@ -574,7 +575,7 @@ func (s *cinderVolumeSourceSuite) TestImportVolume(c *gc.C) {
 		"a": "b",
 		"c": "d",
 	}
-	info, err := volSource.(storage.VolumeImporter).ImportVolume(mockVolId, tags)
+	info, err := volSource.(storage.VolumeImporter).ImportVolume(context.Background(), mockVolId, tags)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(info, jc.DeepEquals, storage.VolumeInfo{
 		VolumeId:   mockVolId,
@@ -597,7 +598,7 @@ func (s *cinderVolumeSourceSuite) TestImportVolumeInUse(c *gc.C) {
 		},
 	}
 	volSource := openstack.NewCinderVolumeSource(mockAdapter)
-	_, err := volSource.(storage.VolumeImporter).ImportVolume(mockVolId, nil)
+	_, err := volSource.(storage.VolumeImporter).ImportVolume(context.Background(), mockVolId, nil)
 	c.Assert(err, gc.ErrorMatches, `cannot import volume "0" with status "in-use"`)
 	mockAdapter.CheckCalls(c, []gitjujutesting.StubCall{
 		{"GetVolume", []interface{}{mockVolId}},
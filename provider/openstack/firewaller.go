@@ -4,6 +4,7 @@
 package openstack
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"strings"
@@ -37,16 +38,16 @@ type FirewallerFactory interface {
 // This is used in other providers that embed the openstack provider.
 type Firewaller interface {
 	// OpenPorts opens the given port ranges for the whole environment.
-	OpenPorts(rules []network.IngressRule) error
+	OpenPorts(ctx context.Context, rules []network.IngressRule) error
 
 	// ClosePorts closes the given port ranges for the whole environment.
-	ClosePorts(rules []network.IngressRule) error
+	ClosePorts(ctx context.Context, rules []network.IngressRule) error
 
 	// IngressRules returns the ingress rules applied to the whole environment.
 	// It is expected that there be only one ingress rule result for a given
 	// port range - the rule's SourceCIDRs will contain all applicable source
 	// address rules for that port range.
-	IngressRules() ([]network.IngressRule, error)
+	IngressRules(ctx context.Context) ([]network.IngressRule, error)
 
 	// DeleteAllModelGroups deletes all security groups for the
 	// model.
@@ -74,13 +75,13 @@ type Firewaller interface {
 	SetUpGroups(controllerUUID, machineId string, apiPort int) ([]string, error)
 
 	// OpenInstancePorts opens the given port ranges for the specified  instance.
-	OpenInstancePorts(inst instance.Instance, machineId string, rules []network.IngressRule) error
+	OpenInstancePorts(ctx context.Context, inst instance.Instance, machineId string, rules []network.IngressRule) error
 
 	// CloseInstancePorts closes the given port ranges for the specified  instance.
-	CloseInstancePorts(inst instance.Instance, machineId string, rules []network.IngressRule) error
+	CloseInstancePorts(ctx context.Context, inst instance.Instance, machineId string, rules []network.IngressRule) error
 
 	// InstanceIngressRules returns the ingress rules applied to the specified  instance.
-	InstanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error)
+	InstanceIngressRules(ctx context.Context, inst instance.Instance, machineId string) ([]network.IngressRule, error)
 }
 
 type firewallerFactory struct {
@@ -121,25 +122,25 @@ func (f *switchingFirewaller) initFirewaller() error {
 	return nil
 }
 
-func (f *switchingFirewaller) OpenPorts(rules []network.IngressRule) error {
+func (f *switchingFirewaller) OpenPorts(ctx context.Context, rules []network.IngressRule) error {
 	if err := f.initFirewaller(); err != nil {
 		return errors.Trace(err)
 	}
-	return f.fw.OpenPorts(rules)
+	return f.fw.OpenPorts(ctx, rules)
 }
 
-func (f *switchingFirewaller) ClosePorts(rules []network.IngressRule) error {
+func (f *switchingFirewaller) ClosePorts(ctx context.Context, rules []network.IngressRule) error {
 	if err := f.initFirewaller(); err != nil {
 		return errors.Trace(err)
 	}
-	return f.fw.ClosePorts(rules)
+	return f.fw.ClosePorts(ctx, rules)
 }
 
-func (f *switchingFirewaller) IngressRules() ([]network.IngressRule, error) {
+func (f *switchingFirewaller) IngressRules(ctx context.Context) ([]network.IngressRule, error) {
 	if err := f.initFirewaller(); err != nil {
 		return nil, errors.Trace(err)
 	}
-	return f.fw.IngressRules()
+	return f.fw.IngressRules(ctx)
 }
 
 func (f *switchingFirewaller) DeleteAllModelGroups() error {
@@ -184,25 +185,25 @@ func (f *switchingFirewaller) SetUpGroups(controllerUUID, machineId string, apiP
 	return f.fw.SetUpGroups(controllerUUID, machineId, apiPort)
 }
 
-func (f *switchingFirewaller) OpenInstancePorts(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+func (f *switchingFirewaller) OpenInstancePorts(ctx context.Context, inst instance.Instance, machineId string, rules []network.IngressRule) error {
 	if err := f.initFirewaller(); err != nil {
 		return errors.Trace(err)
 	}
-	return f.fw.OpenInstancePorts(inst, machineId, rules)
+	return f.fw.OpenInstancePorts(ctx, inst, machineId, rules)
 }
 
-func (f *switchingFirewaller) CloseInstancePorts(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+func (f *switchingFirewaller) CloseInstancePorts(ctx context.Context, inst instance.Instance, machineId string, rules []network.IngressRule) error {
 	if err := f.initFirewaller(); err != nil {
 		return errors.Trace(err)
 	}
-	return f.fw.CloseInstancePorts(inst, machineId, rules)
+	return f.fw.CloseInstancePorts(ctx, inst, machineId, rules)
 }
 
-func (f *switchingFirewaller) InstanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error) {
+func (f *switchingFirewaller) InstanceIngressRules(ctx context.Context, inst instance.Instance, machineId string) ([]network.IngressRule, error) {
 	if err := f.initFirewaller(); err != nil {
 		return nil, errors.Trace(err)
 	}
-	return f.fw.InstanceIngressRules(inst, machineId)
+	return f.fw.InstanceIngressRules(ctx, inst, machineId)
 }
 
 type firewallerBase struct {
@@ -747,22 +748,22 @@ func (c *neutronFirewaller) updateGroupControllerUUID(group *neutron.SecurityGro
 }
 
 // OpenPorts implements Firewaller interface.
-func (c *neutronFirewaller) OpenPorts(rules []network.IngressRule) error {
+func (c *neutronFirewaller) OpenPorts(ctx context.Context, rules []network.IngressRule) error {
 	return c.openPorts(c.openPortsInGroup, rules)
 }
 
 // ClosePorts implements Firewaller interface.
-func (c *neutronFirewaller) ClosePorts(rules []network.IngressRule) error {
+func (c *neutronFirewaller) ClosePorts(ctx context.Context, rules []network.IngressRule) error {
 	return c.closePorts(c.closePortsInGroup, rules)
 }
 
 // IngressRules implements Firewaller interface.
-func (c *neutronFirewaller) IngressRules() ([]network.IngressRule, error) {
+func (c *neutronFirewaller) IngressRules(ctx context.Context) ([]network.IngressRule, error) {
 	return c.ingressRules(c.ingressRulesInGroup)
 }
 
 // OpenInstancePorts implements Firewaller interface.
-func (c *neutronFirewaller) OpenInstancePorts(inst instance.Instance, machineId string, ports []network.IngressRule) error {
+func (c *neutronFirewaller) OpenInstancePorts(ctx context.Context, inst instance.Instance, machineId string, ports []network.IngressRule) error {
 	if c.environ.Config().FirewallMode() != config.FwInstance {
 		return errors.Errorf("invalid firewall mode %q for opening ports on instance",
 			c.environ.Config().FirewallMode())
@@ -778,7 +779,7 @@ func (c *neutronFirewaller) OpenInstancePorts(inst instance.Instance, machineId
 }
 
 // CloseInstancePorts implements Firewaller interface.
-func (c *neutronFirewaller) CloseInstancePorts(inst instance.Instance, machineId string, ports []network.IngressRule) error {
+func (c *neutronFirewaller) CloseInstancePorts(ctx context.Context, inst instance.Instance, machineId string, ports []network.IngressRule) error {
 	if c.environ.Config().FirewallMode() != config.FwInstance {
 		return errors.Errorf("invalid firewall mode %q for closing ports on instance",
 			c.environ.Config().FirewallMode())
@@ -794,7 +795,7 @@ func (c *neutronFirewaller) CloseInstancePorts(inst instance.Instance, machineId
 }
 
 // InstanceIngressRules implements Firewaller interface.
-func (c *neutronFirewaller) InstanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error) {
+func (c *neutronFirewaller) InstanceIngressRules(ctx context.Context, inst instance.Instance, machineId string) ([]network.IngressRule, error) {
 	if c.environ.Config().FirewallMode() != config.FwInstance {
 		return nil, errors.Errorf("invalid firewall mode %q for retrieving ingress rules from instance",
 			c.environ.Config().FirewallMode())
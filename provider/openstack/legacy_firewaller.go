@@ -4,6 +4,7 @@
 package openstack
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 
@@ -210,32 +211,32 @@ func (c *legacyNovaFirewaller) updateGroupControllerUUID(group *nova.SecurityGro
 }
 
 // OpenPorts implements Firewaller interface.
-func (c *legacyNovaFirewaller) OpenPorts(rules []network.IngressRule) error {
+func (c *legacyNovaFirewaller) OpenPorts(ctx context.Context, rules []network.IngressRule) error {
 	return c.openPorts(c.openPortsInGroup, rules)
 }
 
 // ClosePorts implements Firewaller interface.
-func (c *legacyNovaFirewaller) ClosePorts(rules []network.IngressRule) error {
+func (c *legacyNovaFirewaller) ClosePorts(ctx context.Context, rules []network.IngressRule) error {
 	return c.closePorts(c.closePortsInGroup, rules)
 }
 
 // IngressRules implements Firewaller interface.
-func (c *legacyNovaFirewaller) IngressRules() ([]network.IngressRule, error) {
+func (c *legacyNovaFirewaller) IngressRules(ctx context.Context) ([]network.IngressRule, error) {
 	return c.ingressRules(c.ingressRulesInGroup)
 }
 
 // OpenInstancePorts implements Firewaller interface.
-func (c *legacyNovaFirewaller) OpenInstancePorts(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+func (c *legacyNovaFirewaller) OpenInstancePorts(ctx context.Context, inst instance.Instance, machineId string, rules []network.IngressRule) error {
 	return c.openInstancePorts(c.openPortsInGroup, machineId, rules)
 }
 
 // CloseInstancePorts implements Firewaller interface.
-func (c *legacyNovaFirewaller) CloseInstancePorts(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+func (c *legacyNovaFirewaller) CloseInstancePorts(ctx context.Context, inst instance.Instance, machineId string, rules []network.IngressRule) error {
 	return c.closeInstancePorts(c.closePortsInGroup, machineId, rules)
 }
 
 // InstanceIngressRules implements Firewaller interface.
-func (c *legacyNovaFirewaller) InstanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error) {
+func (c *legacyNovaFirewaller) InstanceIngressRules(ctx context.Context, inst instance.Instance, machineId string) ([]network.IngressRule, error) {
 	return c.instanceIngressRules(c.ingressRulesInGroup, machineId)
 }
 
@@ -5,6 +5,7 @@ package openstack_test
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io/ioutil"
 	"net/url"
@@ -445,7 +446,7 @@ func (s *localServerSuite) TestStartInstanceWithoutPublicIP(c *gc.C) {
 	err = bootstrapEnv(c, env)
 	c.Assert(err, jc.ErrorIsNil)
 	inst, _ := testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
-	err = env.StopInstances(inst.Id())
+	err = env.StopInstances(context.Background(), inst.Id())
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -481,6 +482,16 @@ func (s *localServerSuite) TestInstanceName(c *gc.C) {
 	c.Assert(serverDetail.Name, gc.Matches, "juju-06f00d-"+envName+"-100")
 }
 
+func (s *localServerSuite) TestStartInstanceDisplayName(c *gc.C) {
+	result, err := testing.StartInstanceWithParams(s.env, "100", environs.StartInstanceParams{
+		ControllerUUID: s.ControllerUUID,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	serverDetail := openstack.InstanceServerDetail(result.Instance)
+	c.Assert(result.DisplayName, gc.Equals, serverDetail.Name)
+	c.Assert(result.DisplayName, gc.Not(gc.Equals), string(result.Instance.Id()))
+}
+
 func (s *localServerSuite) TestStartInstanceNetwork(c *gc.C) {
 	cfg, err := s.env.Config().Apply(coretesting.Attrs{
 		// A label that corresponds to a neutron test service network
@@ -491,7 +502,7 @@ func (s *localServerSuite) TestStartInstanceNetwork(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	inst, _ := testing.AssertStartInstance(c, s.env, s.ControllerUUID, "100")
-	err = s.env.StopInstances(inst.Id())
+	err = s.env.StopInstances(context.Background(), inst.Id())
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -506,7 +517,7 @@ func (s *localServerSuite) TestStartInstanceExternalNetwork(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	inst, _ := testing.AssertStartInstance(c, s.env, s.ControllerUUID, "100")
-	err = s.env.StopInstances(inst.Id())
+	err = s.env.StopInstances(context.Background(), inst.Id())
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -536,7 +547,7 @@ func (s *localServerSuite) TestStartInstanceExternalNetworkUnknownLabel(c *gc.C)
 
 	inst, _, _, err := testing.StartInstance(s.env, s.ControllerUUID, "100")
 	c.Assert(err, jc.ErrorIsNil)
-	err = s.env.StopInstances(inst.Id())
+	err = s.env.StopInstances(context.Background(), inst.Id())
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -574,7 +585,7 @@ func (s *localServerSuite) TestStartInstanceNetworksDifferentAZ(c *gc.C) {
 
 	inst, _, _, err := testing.StartInstance(s.env, s.ControllerUUID, "100")
 	c.Assert(err, jc.ErrorIsNil)
-	err = s.env.StopInstances(inst.Id())
+	err = s.env.StopInstances(context.Background(), inst.Id())
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -710,7 +721,7 @@ func (s *localServerSuite) TestStopInstance(c *gc.C) {
 		fmt.Sprintf("juju-%v-%v-%v", s.ControllerUUID, modelUUID, instanceName),
 	}
 	assertSecurityGroups(c, env, allSecurityGroups)
-	err := env.StopInstances(inst.Id())
+	err := env.StopInstances(context.Background(), inst.Id())
 	c.Assert(err, jc.ErrorIsNil)
 	// The security group for this instance is now removed.
 	assertSecurityGroups(c, env, []string{
@@ -748,7 +759,7 @@ func (s *localServerSuite) TestStopInstanceSecurityGroupNotDeleted(c *gc.C) {
 	clock := gitjujutesting.AutoAdvancingClock{clk, clk.Advance}
 	env.(*openstack.Environ).SetClock(&clock)
 
-	err := env.StopInstances(inst.Id())
+	err := env.StopInstances(context.Background(), inst.Id())
 	c.Assert(err, jc.ErrorIsNil)
 	assertSecurityGroups(c, env, allSecurityGroups)
 }
@@ -890,7 +901,7 @@ func (s *localServerSuite) TestInstanceStatus(c *gc.C) {
 	// goose's test service always returns ACTIVE state.
 	inst, _ := testing.AssertStartInstance(c, s.env, s.ControllerUUID, "100")
 	c.Assert(inst.Status().Status, gc.Equals, status.Running)
-	err := s.env.StopInstances(inst.Id())
+	err := s.env.StopInstances(context.Background(), inst.Id())
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -903,7 +914,7 @@ func (s *localServerSuite) TestAllInstancesFloatingIP(c *gc.C) {
 	inst0, _ := testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
 	inst1, _ := testing.AssertStartInstance(c, env, s.ControllerUUID, "101")
 	defer func() {
-		err := env.StopInstances(inst0.Id(), inst1.Id())
+		err := env.StopInstances(context.Background(), inst0.Id(), inst1.Id())
 		c.Assert(err, jc.ErrorIsNil)
 	}()
 
@@ -922,7 +933,7 @@ func (s *localServerSuite) assertInstancesGathering(c *gc.C, withFloatingIP bool
 	inst1, _ := testing.AssertStartInstance(c, env, s.ControllerUUID, "101")
 	id1 := inst1.Id()
 	defer func() {
-		err := env.StopInstances(inst0.Id(), inst1.Id())
+		err := env.StopInstances(context.Background(), inst0.Id(), inst1.Id())
 		c.Assert(err, jc.ErrorIsNil)
 	}()
 
@@ -989,7 +1000,7 @@ func (s *localServerSuite) TestInstancesShutoffSuspended(c *gc.C) {
 	stateInst1, _ := testing.AssertStartInstance(c, s.env, s.ControllerUUID, "100")
 	stateInst2, _ := testing.AssertStartInstance(c, s.env, s.ControllerUUID, "101")
 	defer func() {
-		err := s.env.StopInstances(stateInst1.Id(), stateInst2.Id())
+		err := s.env.StopInstances(context.Background(), stateInst1.Id(), stateInst2.Id())
 		c.Assert(err, jc.ErrorIsNil)
 	}()
 
@@ -2393,7 +2404,7 @@ func addVolume(c *gc.C, env environs.Environ, controllerUUID, name string) *stor
 	c.Assert(err, jc.ErrorIsNil)
 	modelUUID := env.Config().UUID()
 	source := openstack.NewCinderVolumeSourceForModel(storageAdapter, modelUUID)
-	result, err := source.CreateVolumes([]storage.VolumeParams{{
+	result, err := source.CreateVolumes(context.Background(), []storage.VolumeParams{{
 		Tag: names.NewVolumeTag(name),
 		ResourceTags: tags.ResourceTags(
 			names.NewModelTag(modelUUID),
@@ -2421,7 +2432,7 @@ func (s *localServerSuite) checkVolumeTags(c *gc.C, env environs.Environ, expect
 	storage, err := (*openstack.NewOpenstackStorage)(env.(*openstack.Environ))
 	c.Assert(err, jc.ErrorIsNil)
 	source := openstack.NewCinderVolumeSourceForModel(storage, env.Config().UUID())
-	volumeIds, err := source.ListVolumes()
+	volumeIds, err := source.ListVolumes(context.Background())
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(volumeIds, gc.Not(gc.HasLen), 0)
 	for _, volumeId := range volumeIds {
@@ -6,6 +6,7 @@
 package openstack
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"strings"
@@ -453,16 +454,16 @@ func convertNovaAddresses(publicIP string, addresses map[string][]nova.IPAddress
 	return machineAddresses
 }
 
-func (inst *openstackInstance) OpenPorts(machineId string, rules []network.IngressRule) error {
-	return inst.e.firewaller.OpenInstancePorts(inst, machineId, rules)
+func (inst *openstackInstance) OpenPorts(ctx context.Context, machineId string, rules []network.IngressRule) error {
+	return inst.e.firewaller.OpenInstancePorts(ctx, inst, machineId, rules)
 }
 
-func (inst *openstackInstance) ClosePorts(machineId string, rules []network.IngressRule) error {
-	return inst.e.firewaller.CloseInstancePorts(inst, machineId, rules)
+func (inst *openstackInstance) ClosePorts(ctx context.Context, machineId string, rules []network.IngressRule) error {
+	return inst.e.firewaller.CloseInstancePorts(ctx, inst, machineId, rules)
 }
 
-func (inst *openstackInstance) IngressRules(machineId string) ([]network.IngressRule, error) {
-	return inst.e.firewaller.InstanceIngressRules(inst, machineId)
+func (inst *openstackInstance) IngressRules(ctx context.Context, machineId string) ([]network.IngressRule, error) {
+	return inst.e.firewaller.InstanceIngressRules(ctx, inst, machineId)
 }
 
 func (e *Environ) ecfg() *environConfig {
@@ -668,6 +669,14 @@ func (e *Environ) Bootstrap(ctx environs.BootstrapContext, args environs.Bootstr
 	return common.Bootstrap(ctx, e, args)
 }
 
+// ResumeBootstrap is part of the environs.BootstrapResumer interface.
+func (e *Environ) ResumeBootstrap(ctx environs.BootstrapContext, id instance.Id, args environs.BootstrapParams) (*environs.BootstrapResult, error) {
+	if err := authenticateClient(e.client()); err != nil {
+		return nil, err
+	}
+	return common.ResumeBootstrap(ctx, e, id, args)
+}
+
 func (e *Environ) supportsNeutron() bool {
 	client := e.client()
 	endpointMap := client.EndpointsForRegion(e.cloud.Region)
@@ -936,7 +945,7 @@ func (*Environ) MaintainInstance(args environs.StartInstanceParams) error {
 }
 
 // StartInstance is specified in the InstanceBroker interface.
-func (e *Environ) StartInstance(args environs.StartInstanceParams) (_ *environs.StartInstanceResult, err error) {
+func (e *Environ) StartInstance(ctx context.Context, args environs.StartInstanceParams) (_ *environs.StartInstanceResult, err error) {
 	if args.AvailabilityZone != "" {
 		// args.AvailabilityZone should only be set if this OpenStack
 		// supports zones; validate the zone.
@@ -1180,8 +1189,9 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 	}
 
 	return &environs.StartInstanceResult{
-		Instance: inst,
-		Hardware: inst.hardwareCharacteristics(),
+		Instance:    inst,
+		DisplayName: inst.getServerDetail().Name,
+		Hardware:    inst.hardwareCharacteristics(),
 	}, nil
 }
 
@@ -1262,7 +1272,7 @@ func isNoValidHostsError(err error) bool {
 	return false
 }
 
-func (e *Environ) StopInstances(ids ...instance.Id) error {
+func (e *Environ) StopInstances(ctx context.Context, ids ...instance.Id) error {
 	// If in instance firewall mode, gather the security group names.
 	securityGroupNames, err := e.firewaller.GetSecurityGroups(ids...)
 	if err == environs.ErrNoInstances {
@@ -1457,7 +1467,7 @@ func (e *Environ) adoptVolumes(controllerTag map[string]string) ([]string, error
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	volumeIds, err := volumeSource.ListVolumes()
+	volumeIds, err := volumeSource.ListVolumes(context.TODO())
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -1619,16 +1629,16 @@ func rulesToRuleInfo(groupId string, rules []network.IngressRule) []neutron.Rule
 	return result
 }
 
-func (e *Environ) OpenPorts(rules []network.IngressRule) error {
-	return e.firewaller.OpenPorts(rules)
+func (e *Environ) OpenPorts(ctx context.Context, rules []network.IngressRule) error {
+	return e.firewaller.OpenPorts(ctx, rules)
 }
 
-func (e *Environ) ClosePorts(rules []network.IngressRule) error {
-	return e.firewaller.ClosePorts(rules)
+func (e *Environ) ClosePorts(ctx context.Context, rules []network.IngressRule) error {
+	return e.firewaller.ClosePorts(ctx, rules)
 }
 
-func (e *Environ) IngressRules() ([]network.IngressRule, error) {
-	return e.firewaller.IngressRules()
+func (e *Environ) IngressRules(ctx context.Context) ([]network.IngressRule, error) {
+	return e.firewaller.IngressRules(ctx)
 }
 
 func (e *Environ) Provider() environs.EnvironProvider {
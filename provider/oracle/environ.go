@@ -4,6 +4,7 @@
 package oracle
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"strconv"
@@ -146,6 +147,11 @@ func (o *OracleEnviron) Bootstrap(ctx environs.BootstrapContext, args environs.B
 	return common.Bootstrap(ctx, o, args)
 }
 
+// ResumeBootstrap is part of the environs.BootstrapResumer interface.
+func (o *OracleEnviron) ResumeBootstrap(ctx environs.BootstrapContext, id instance.Id, args environs.BootstrapParams) (*environs.BootstrapResult, error) {
+	return common.ResumeBootstrap(ctx, o, id, args)
+}
+
 // Create is part of the Environ interface.
 func (o *OracleEnviron) Create(params environs.CreateParams) error {
 	if err := o.client.Authenticate(); err != nil {
@@ -314,7 +320,7 @@ func (e *OracleEnviron) getInstanceNetworks(
 }
 
 // StartInstance is part of the InstanceBroker interface.
-func (o *OracleEnviron) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+func (o *OracleEnviron) StartInstance(ctx context.Context, args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
 	if args.ControllerUUID == "" {
 		return nil, errors.NotFoundf("Controller UUID")
 	}
@@ -482,7 +488,7 @@ func (o *OracleEnviron) StartInstance(args environs.StartInstanceParams) (*envir
 }
 
 // StopInstances is part of the InstanceBroker interface.
-func (o *OracleEnviron) StopInstances(ids ...instance.Id) error {
+func (o *OracleEnviron) StopInstances(ctx context.Context, ids ...instance.Id) error {
 	oracleInstances, err := o.getOracleInstances(ids...)
 	if err == environs.ErrNoInstances {
 		return nil
@@ -756,7 +762,7 @@ func (o *OracleEnviron) DestroyController(controllerUUID string) error {
 	for i, val := range instances {
 		ids[i] = val.Id()
 	}
-	return o.StopInstances(ids...)
+	return o.StopInstances(context.TODO(), ids...)
 }
 
 // Provider is part of the environs.Environ interface.
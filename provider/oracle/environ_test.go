@@ -4,6 +4,7 @@
 package oracle_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
@@ -212,7 +213,7 @@ func (e *environSuite) TestStopInstances(c *gc.C) {
 	hostname, err := oracle.CreateHostname(e.env, "0")
 	c.Assert(err, gc.IsNil)
 	ids := []instance.Id{instance.Id(hostname)}
-	err = e.env.StopInstances(ids...)
+	err = e.env.StopInstances(context.Background(), ids...)
 	c.Assert(err, gc.IsNil)
 }
 
@@ -4,6 +4,7 @@
 package oracle
 
 import (
+	"context"
 	"strings"
 	"sync"
 	"time"
@@ -394,7 +395,7 @@ func (o *oracleInstance) Addresses() ([]network.Address, error) {
 }
 
 // OpenPorts is defined on the instance.Instance interface.
-func (o *oracleInstance) OpenPorts(machineId string, rules []network.IngressRule) error {
+func (o *oracleInstance) OpenPorts(ctx context.Context, machineId string, rules []network.IngressRule) error {
 	if o.env.Config().FirewallMode() != config.FwInstance {
 		return errors.Errorf(
 			"invalid firewall mode %q for opening ports on instance",
@@ -402,11 +403,11 @@ func (o *oracleInstance) OpenPorts(machineId string, rules []network.IngressRule
 		)
 	}
 
-	return o.env.OpenPortsOnInstance(machineId, rules)
+	return o.env.OpenPortsOnInstance(ctx, machineId, rules)
 }
 
 // ClosePorts is defined on the instance.Instance interface.
-func (o *oracleInstance) ClosePorts(machineId string, rules []network.IngressRule) error {
+func (o *oracleInstance) ClosePorts(ctx context.Context, machineId string, rules []network.IngressRule) error {
 	if o.env.Config().FirewallMode() != config.FwInstance {
 		return errors.Errorf(
 			"invalid firewall mode %q for closing ports on instance",
@@ -414,10 +415,10 @@ func (o *oracleInstance) ClosePorts(machineId string, rules []network.IngressRul
 		)
 	}
 
-	return o.env.ClosePortsOnInstance(machineId, rules)
+	return o.env.ClosePortsOnInstance(ctx, machineId, rules)
 }
 
 // IngressRules is defined on the instance.Instance interface.
-func (o *oracleInstance) IngressRules(machineId string) ([]network.IngressRule, error) {
-	return o.env.MachineIngressRules(machineId)
+func (o *oracleInstance) IngressRules(ctx context.Context, machineId string) ([]network.IngressRule, error) {
+	return o.env.MachineIngressRules(ctx, machineId)
 }
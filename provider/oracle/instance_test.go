@@ -4,6 +4,7 @@
 package oracle_test
 
 import (
+	"context"
 	"errors"
 	"sync"
 
@@ -128,7 +129,7 @@ func (i instanceSuite) TestOpenPorts(c *gc.C) {
 	c.Assert(err, gc.IsNil)
 	c.Assert(instance, gc.NotNil)
 
-	err = instance.OpenPorts("0", []jujunetwork.IngressRule{
+	err = instance.OpenPorts(context.Background(), "0", []jujunetwork.IngressRule{
 		jujunetwork.IngressRule{
 			PortRange: jujunetwork.PortRange{
 				FromPort: 0,
@@ -154,7 +155,7 @@ func (i instanceSuite) TestClosePorts(c *gc.C) {
 	c.Assert(err, gc.IsNil)
 	c.Assert(instance, gc.NotNil)
 
-	err = instance.ClosePorts("0", []jujunetwork.IngressRule{
+	err = instance.ClosePorts(context.Background(), "0", []jujunetwork.IngressRule{
 		jujunetwork.IngressRule{
 			PortRange: jujunetwork.PortRange{
 				FromPort: 0,
@@ -181,7 +182,7 @@ func (i instanceSuite) TestIngressRules(c *gc.C) {
 	c.Assert(err, gc.IsNil)
 	c.Assert(instance, gc.NotNil)
 
-	rules, err := instance.IngressRules("0")
+	rules, err := instance.IngressRules(context.Background(), "0")
 	c.Assert(err, gc.IsNil)
 	c.Assert(rules, gc.NotNil)
 }
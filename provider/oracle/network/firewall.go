@@ -4,6 +4,7 @@
 package network
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"sort"
@@ -30,15 +31,15 @@ type Firewaller interface {
 	environs.Firewaller
 
 	// Return all machine ingress rules for a given machine id
-	MachineIngressRules(id string) ([]network.IngressRule, error)
+	MachineIngressRules(ctx context.Context, id string) ([]network.IngressRule, error)
 
 	// OpenPortsOnInstance will open ports corresponding to the supplied rules
 	// on the given instance
-	OpenPortsOnInstance(machineId string, rules []network.IngressRule) error
+	OpenPortsOnInstance(ctx context.Context, machineId string, rules []network.IngressRule) error
 
 	// ClosePortsOnInstnace will close ports corresponding to the supplied rules
 	// for a given instance.
-	ClosePortsOnInstance(machineId string, rules []network.IngressRule) error
+	ClosePortsOnInstance(ctx context.Context, machineId string, rules []network.IngressRule) error
 
 	// CreateMachineSecLists creates a security list for the given instance.
 	// It's worth noting that this function also ensures that the default environment
@@ -96,7 +97,7 @@ func NewFirewall(cfg environs.ConfigGetter, client FirewallerAPI, c clock.Clock)
 }
 
 // OpenPorts is specified on the environ.Firewaller interface.
-func (f Firewall) OpenPorts(rules []network.IngressRule) error {
+func (f Firewall) OpenPorts(ctx context.Context, rules []network.IngressRule) error {
 	mode := f.environ.Config().FirewallMode()
 	if mode != config.FwGlobal {
 		return fmt.Errorf(
@@ -118,25 +119,25 @@ func (f Firewall) OpenPorts(rules []network.IngressRule) error {
 }
 
 // ClosePorts is specified on the environ.Firewaller interface.
-func (f Firewall) ClosePorts(rules []network.IngressRule) error {
+func (f Firewall) ClosePorts(ctx context.Context, rules []network.IngressRule) error {
 	groupName := f.globalGroupName()
 	return f.closePortsOnList(f.client.ComposeName(groupName), rules)
 }
 
 // IngressRules is specified on the environ.Firewaller interface.
-func (f Firewall) IngressRules() ([]network.IngressRule, error) {
+func (f Firewall) IngressRules(ctx context.Context) ([]network.IngressRule, error) {
 	return f.GlobalIngressRules()
 }
 
 // MachineIngressRules returns all ingress rules from the machine specific sec list
-func (f Firewall) MachineIngressRules(machineId string) ([]network.IngressRule, error) {
+func (f Firewall) MachineIngressRules(ctx context.Context, machineId string) ([]network.IngressRule, error) {
 	seclist := f.machineGroupName(machineId)
 	return f.getIngressRules(f.client.ComposeName(seclist))
 }
 
 // OpenPortsOnInstance will open ports corresponding to the supplied rules
 // on the given instance
-func (f Firewall) OpenPortsOnInstance(machineId string, rules []network.IngressRule) error {
+func (f Firewall) OpenPortsOnInstance(ctx context.Context, machineId string, rules []network.IngressRule) error {
 	machineGroup := f.machineGroupName(machineId)
 	seclist, err := f.ensureSecList(f.client.ComposeName(machineGroup))
 	if err != nil {
@@ -151,7 +152,7 @@ func (f Firewall) OpenPortsOnInstance(machineId string, rules []network.IngressR
 
 // ClosePortsOnInstnace will close ports corresponding to the supplied rules
 // for a given instance.
-func (f Firewall) ClosePortsOnInstance(machineId string, rules []network.IngressRule) error {
+func (f Firewall) ClosePortsOnInstance(ctx context.Context, machineId string, rules []network.IngressRule) error {
 	// fetch the group name based on the machine id provided
 	groupName := f.machineGroupName(machineId)
 	return f.closePortsOnList(f.client.ComposeName(groupName), rules)
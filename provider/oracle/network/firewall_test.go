@@ -4,6 +4,7 @@
 package network_test
 
 import (
+	"context"
 	"time"
 
 	"github.com/juju/errors"
@@ -61,7 +62,7 @@ func (f *firewallSuite) TestIngressRules(c *gc.C) {
 	firewall := network.NewFirewall(cfg, providertest.DefaultFakeFirewallAPI, &advancingClock)
 	c.Assert(firewall, gc.NotNil)
 
-	rule, err := firewall.IngressRules()
+	rule, err := firewall.IngressRules(context.Background())
 	c.Assert(err, gc.IsNil)
 	c.Assert(rule, gc.NotNil)
 }
@@ -105,7 +106,7 @@ func (f *firewallSuite) TestIngressRulesWithErrors(c *gc.C) {
 		firewall := network.NewFirewall(cfg, fake, &advancingClock)
 		c.Assert(firewall, gc.NotNil)
 
-		rule, err := firewall.IngressRules()
+		rule, err := firewall.IngressRules(context.Background())
 		c.Assert(err, gc.NotNil)
 		c.Assert(rule, gc.IsNil)
 	}
@@ -164,7 +165,7 @@ func (f *firewallSuite) TestOpenPorts(c *gc.C) {
 	firewall := network.NewFirewall(cfg, providertest.DefaultFakeFirewallAPI, &advancingClock)
 	c.Assert(firewall, gc.NotNil)
 
-	err := firewall.OpenPorts([]jujunetwork.IngressRule{})
+	err := firewall.OpenPorts(context.Background(), []jujunetwork.IngressRule{})
 	c.Assert(err, gc.IsNil)
 
 }
@@ -225,7 +226,7 @@ func (f *firewallSuite) TestOpenPortsWithErrors(c *gc.C) {
 		firewall := network.NewFirewall(cfg, fake, &advancingClock)
 		c.Assert(firewall, gc.NotNil)
 
-		err := firewall.OpenPorts([]jujunetwork.IngressRule{})
+		err := firewall.OpenPorts(context.Background(), []jujunetwork.IngressRule{})
 		c.Assert(err, gc.NotNil)
 	}
 
@@ -234,7 +235,7 @@ func (f *firewallSuite) TestOpenPortsWithErrors(c *gc.C) {
 	firewall := network.NewFirewall(cfg, providertest.DefaultFakeFirewallAPI, &advancingClock)
 	c.Assert(firewall, gc.NotNil)
 
-	err := firewall.OpenPorts([]jujunetwork.IngressRule{})
+	err := firewall.OpenPorts(context.Background(), []jujunetwork.IngressRule{})
 	c.Assert(err, gc.NotNil)
 }
 
@@ -244,7 +245,7 @@ func (f *firewallSuite) TestClosePorts(c *gc.C) {
 	firewall := network.NewFirewall(cfg, providertest.DefaultFakeFirewallAPI, &advancingClock)
 	c.Assert(firewall, gc.NotNil)
 
-	err := firewall.ClosePorts([]jujunetwork.IngressRule{})
+	err := firewall.ClosePorts(context.Background(), []jujunetwork.IngressRule{})
 	c.Assert(err, gc.IsNil)
 }
 
@@ -425,7 +426,7 @@ func (f *firewallSuite) TestClosePortsWithErrors(c *gc.C) {
 		firewall := network.NewFirewall(cfg, fake, &advancingClock)
 		c.Assert(firewall, gc.NotNil)
 
-		err := firewall.ClosePorts([]jujunetwork.IngressRule{
+		err := firewall.ClosePorts(context.Background(), []jujunetwork.IngressRule{
 			jujunetwork.IngressRule{
 				PortRange: jujunetwork.PortRange{
 					FromPort: 0,
@@ -615,7 +616,7 @@ func (f *firewallSuite) TestClosePortsOnInstance(c *gc.C) {
 		firewall := network.NewFirewall(cfg, fake, &advancingClock)
 		c.Assert(firewall, gc.NotNil)
 
-		err := firewall.ClosePortsOnInstance("0,", []jujunetwork.IngressRule{
+		err := firewall.ClosePortsOnInstance(context.Background(), "0,", []jujunetwork.IngressRule{
 			jujunetwork.IngressRule{
 				PortRange: jujunetwork.PortRange{
 					FromPort: 0,
@@ -634,7 +635,7 @@ func (f *firewallSuite) TestMachineIngressRules(c *gc.C) {
 	firewall := network.NewFirewall(cfg, providertest.DefaultFakeFirewallAPI, &advancingClock)
 	c.Assert(firewall, gc.NotNil)
 
-	rules, err := firewall.MachineIngressRules("0")
+	rules, err := firewall.MachineIngressRules(context.Background(), "0")
 	c.Assert(err, gc.IsNil)
 	c.Assert(rules, gc.NotNil)
 }
@@ -683,7 +684,7 @@ func (f *firewallSuite) TestMachineIngressRulesWithErrors(c *gc.C) {
 		firewall := network.NewFirewall(cfg, fake, &advancingClock)
 		c.Assert(firewall, gc.NotNil)
 
-		_, err := firewall.MachineIngressRules("0")
+		_, err := firewall.MachineIngressRules(context.Background(), "0")
 		c.Assert(err, gc.NotNil)
 	}
 }
@@ -693,7 +694,7 @@ func (f *firewallSuite) TestOpenPortsOnInstance(c *gc.C) {
 	firewall := network.NewFirewall(cfg, providertest.DefaultFakeFirewallAPI, &advancingClock)
 	c.Assert(firewall, gc.NotNil)
 
-	err := firewall.OpenPortsOnInstance("0", []jujunetwork.IngressRule{})
+	err := firewall.OpenPortsOnInstance(context.Background(), "0", []jujunetwork.IngressRule{})
 	c.Assert(err, gc.IsNil)
 
 }
@@ -751,7 +752,7 @@ func (f *firewallSuite) TestOpenPortsOnInstanceWithErrors(c *gc.C) {
 		firewall := network.NewFirewall(cfg, fake, &advancingClock)
 		c.Assert(firewall, gc.NotNil)
 
-		err := firewall.OpenPortsOnInstance("0", []jujunetwork.IngressRule{})
+		err := firewall.OpenPortsOnInstance(context.Background(), "0", []jujunetwork.IngressRule{})
 		c.Assert(err, gc.NotNil)
 	}
 }
@@ -4,6 +4,7 @@
 package oracle_test
 
 import (
+	"context"
 	"errors"
 
 	"github.com/juju/go-oracle-cloud/api"
@@ -70,14 +71,14 @@ func (o *oracleVolumeSource) NewVolumeSource(
 
 func (o *oracleVolumeSource) TestCreateVolumesWithEmptyParams(c *gc.C) {
 	source := o.NewVolumeSource(c, oracletesting.DefaultFakeStorageAPI, nil)
-	result, err := source.CreateVolumes(nil)
+	result, err := source.CreateVolumes(context.Background(), nil)
 	c.Assert(err, gc.IsNil)
 	c.Assert(result, gc.NotNil)
 }
 
 func (o *oracleVolumeSource) TestCreateVolumes(c *gc.C) {
 	source := o.NewVolumeSource(c, oracletesting.DefaultFakeStorageAPI, nil)
-	results, err := source.CreateVolumes([]storage.VolumeParams{
+	results, err := source.CreateVolumes(context.Background(), []storage.VolumeParams{
 		storage.VolumeParams{
 			Size:     uint64(10000),
 			Provider: oracle.DefaultTypes[0],
@@ -105,7 +106,7 @@ func (o *oracleVolumeSource) TestCreateVolumesAlreadyExists(c *gc.C) {
 		},
 	}, nil)
 	volumeTag := names.NewVolumeTag("666")
-	results, err := source.CreateVolumes([]storage.VolumeParams{{
+	results, err := source.CreateVolumes(context.Background(), []storage.VolumeParams{{
 		Tag:      volumeTag,
 		Size:     uint64(10000),
 		Provider: oracle.DefaultTypes[0],
@@ -133,7 +134,7 @@ func (o *oracleVolumeSource) TestCreateVolumeError(c *gc.C) {
 		},
 	}
 	source := o.NewVolumeSource(c, fake, nil)
-	results, err := source.CreateVolumes([]storage.VolumeParams{
+	results, err := source.CreateVolumes(context.Background(), []storage.VolumeParams{
 		storage.VolumeParams{
 			Size:     uint64(10000),
 			Provider: oracle.DefaultTypes[0],
@@ -147,7 +148,7 @@ func (o *oracleVolumeSource) TestCreateVolumeError(c *gc.C) {
 
 func (o *oracleVolumeSource) TestListVolumes(c *gc.C) {
 	source := o.NewVolumeSource(c, oracletesting.DefaultFakeStorageAPI, nil)
-	volumes, err := source.ListVolumes()
+	volumes, err := source.ListVolumes(context.Background())
 	c.Assert(err, gc.IsNil)
 	c.Assert(volumes, gc.NotNil)
 }
@@ -164,18 +165,18 @@ func (o *oracleVolumeSource) TestListVolumesWithErrors(c *gc.C) {
 		},
 	} {
 		source := o.NewVolumeSource(c, fake, nil)
-		_, err := source.ListVolumes()
+		_, err := source.ListVolumes(context.Background())
 		c.Assert(err, gc.NotNil)
 	}
 }
 
 func (o *oracleVolumeSource) TestDescribeVolumes(c *gc.C) {
 	source := o.NewVolumeSource(c, oracletesting.DefaultFakeStorageAPI, nil)
-	volumes, err := source.DescribeVolumes([]string{})
+	volumes, err := source.DescribeVolumes(context.Background(), []string{})
 	c.Assert(err, gc.IsNil)
 	c.Assert(volumes, gc.NotNil)
 
-	volumes, err = source.DescribeVolumes([]string{"JujuTools_storage"})
+	volumes, err = source.DescribeVolumes(context.Background(), []string{"JujuTools_storage"})
 	c.Assert(err, gc.IsNil)
 	c.Assert(volumes, gc.NotNil)
 }
@@ -192,14 +193,14 @@ func (o *oracleVolumeSource) TestDescribeVolumesWithErrors(c *gc.C) {
 		},
 	} {
 		source := o.NewVolumeSource(c, fake, nil)
-		_, err := source.DescribeVolumes([]string{"JujuTools_storage"})
+		_, err := source.DescribeVolumes(context.Background(), []string{"JujuTools_storage"})
 		c.Assert(err, gc.NotNil)
 	}
 }
 
 func (o *oracleVolumeSource) TestDestroyVolumes(c *gc.C) {
 	source := o.NewVolumeSource(c, oracletesting.DefaultFakeStorageAPI, nil)
-	errs, err := source.DestroyVolumes([]string{"foo"})
+	errs, err := source.DestroyVolumes(context.Background(), []string{"foo"})
 	c.Assert(err, gc.IsNil)
 	c.Assert(errs, jc.DeepEquals, []error{nil})
 }
@@ -216,7 +217,7 @@ func (o *oracleVolumeSource) TestDestroyVolumesWithErrors(c *gc.C) {
 		},
 	} {
 		source := o.NewVolumeSource(c, fake, nil)
-		errs, err := source.DestroyVolumes([]string{"JujuTools_storage"})
+		errs, err := source.DestroyVolumes(context.Background(), []string{"JujuTools_storage"})
 		c.Assert(err, gc.IsNil)
 		for _, val := range errs {
 			c.Assert(val, gc.NotNil)
@@ -231,7 +232,7 @@ func (o *oracleVolumeSource) TestReleaseVolumes(c *gc.C) {
 	)
 
 	source := o.NewVolumeSource(c, oracletesting.DefaultFakeStorageAPI, nil)
-	errs, err := source.ReleaseVolumes([]string{"foo"})
+	errs, err := source.ReleaseVolumes(context.Background(), []string{"foo"})
 	c.Assert(err, gc.IsNil)
 	c.Assert(errs, jc.DeepEquals, []error{nil})
 
@@ -251,7 +252,7 @@ func (o *oracleVolumeSource) TestReleaseVolumesUnchanged(c *gc.C) {
 	)
 
 	source := o.NewVolumeSource(c, oracletesting.DefaultFakeStorageAPI, nil)
-	errs, err := source.ReleaseVolumes([]string{"foo"})
+	errs, err := source.ReleaseVolumes(context.Background(), []string{"foo"})
 	c.Assert(err, gc.IsNil)
 	c.Assert(errs, jc.DeepEquals, []error{nil})
 
@@ -270,7 +271,7 @@ func (o *oracleVolumeSource) TestImportVolume(c *gc.C) {
 	source := o.NewVolumeSource(c, oracletesting.DefaultFakeStorageAPI, nil)
 	c.Assert(source, gc.Implements, new(storage.VolumeImporter))
 
-	info, err := source.(storage.VolumeImporter).ImportVolume("foo", map[string]string{"bar": "baz"})
+	info, err := source.(storage.VolumeImporter).ImportVolume(context.Background(), "foo", map[string]string{"bar": "baz"})
 	c.Assert(err, gc.IsNil)
 	c.Assert(info, jc.DeepEquals, storage.VolumeInfo{
 		VolumeId:   "/Compute-a432100/sgiulitti@cloudbase.com/JujuTools_storage",
@@ -308,7 +309,7 @@ func (o *oracleVolumeSource) TestValidateVolumeParams(c *gc.C) {
 
 func (o *oracleVolumeSource) TestAttachVolumes(c *gc.C) {
 	source := o.NewVolumeSource(c, oracletesting.DefaultFakeStorageAPI, oracletesting.DefaultEnvironAPI)
-	_, err := source.AttachVolumes([]storage.VolumeAttachmentParams{
+	_, err := source.AttachVolumes(context.Background(), []storage.VolumeAttachmentParams{
 		storage.VolumeAttachmentParams{
 			AttachmentParams: storage.AttachmentParams{
 				Provider:   oracle.DefaultTypes[0],
@@ -323,7 +324,7 @@ func (o *oracleVolumeSource) TestAttachVolumes(c *gc.C) {
 
 func (o *oracleVolumeSource) TestDetachVolumes(c *gc.C) {
 	source := o.NewVolumeSource(c, oracletesting.DefaultFakeStorageAPI, nil)
-	errs, err := source.DetachVolumes([]storage.VolumeAttachmentParams{
+	errs, err := source.DetachVolumes(context.Background(), []storage.VolumeAttachmentParams{
 		storage.VolumeAttachmentParams{
 			AttachmentParams: storage.AttachmentParams{
 				Provider:   oracle.DefaultTypes[0],
@@ -345,7 +346,7 @@ func (o *oracleVolumeSource) TestDetachVolumesWithErrors(c *gc.C) {
 		FakeStorageAttachment: oracletesting.FakeStorageAttachment{
 			AllErr: errors.New("FakeStorageAttachmentErr"),
 		}}, oracletesting.DefaultEnvironAPI)
-	_, err := source.DetachVolumes([]storage.VolumeAttachmentParams{
+	_, err := source.DetachVolumes(context.Background(), []storage.VolumeAttachmentParams{
 		storage.VolumeAttachmentParams{
 			AttachmentParams: storage.AttachmentParams{
 				Provider:   oracle.DefaultTypes[0],
@@ -378,7 +379,7 @@ func (o *oracleVolumeSource) TestDetachVolumesWithErrors(c *gc.C) {
 				},
 			},
 			DeleteErr: errors.New("FakeStorageAttachmentErr")}}, oracletesting.DefaultEnvironAPI)
-	results, err := source.DetachVolumes([]storage.VolumeAttachmentParams{
+	results, err := source.DetachVolumes(context.Background(), []storage.VolumeAttachmentParams{
 		storage.VolumeAttachmentParams{
 			AttachmentParams: storage.AttachmentParams{
 				Provider:   oracle.DefaultTypes[0],
@@ -4,6 +4,7 @@
 package rackspace
 
 import (
+	"context"
 	"io/ioutil"
 	"os"
 	"time"
@@ -33,7 +34,7 @@ func (e environ) Bootstrap(ctx environs.BootstrapContext, params environs.Bootst
 var waitSSH = common.WaitSSH
 
 // StartInstance implements environs.Environ.
-func (e environ) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+func (e environ) StartInstance(ctx context.Context, args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
 	osString, err := series.GetOSFromSeries(args.Tools.OneSeries())
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -43,7 +44,7 @@ func (e environ) StartInstance(args environs.StartInstanceParams) (*environs.Sta
 		return nil, errors.Errorf("rackspace provider doesn't support firewalls for windows instances")
 
 	}
-	r, err := e.Environ.StartInstance(args)
+	r, err := e.Environ.StartInstance(ctx, args)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
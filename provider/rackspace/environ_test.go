@@ -4,6 +4,7 @@
 package rackspace_test
 
 import (
+	"context"
 	"io"
 	"os"
 
@@ -80,7 +81,7 @@ func (s *environSuite) TestStartInstance(c *gc.C) {
 	c.Assert(err, gc.IsNil)
 	err = s.environ.SetConfig(config)
 	c.Assert(err, gc.IsNil)
-	_, err = s.environ.StartInstance(environs.StartInstanceParams{
+	_, err = s.environ.StartInstance(context.Background(), environs.StartInstanceParams{
 		InstanceConfig: &instancecfg.InstanceConfig{},
 		Tools: tools.List{&tools.Tools{
 			Version: version.Binary{Series: "trusty"},
@@ -133,14 +134,14 @@ func (e *fakeEnviron) Bootstrap(ctx environs.BootstrapContext, params environs.B
 	return nil, nil
 }
 
-func (e *fakeEnviron) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+func (e *fakeEnviron) StartInstance(ctx context.Context, args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
 	e.Push("StartInstance", args)
 	return &environs.StartInstanceResult{
 		Instance: &fakeInstance{},
 	}, nil
 }
 
-func (e *fakeEnviron) StopInstances(ids ...instance.Id) error {
+func (e *fakeEnviron) StopInstances(ctx context.Context, ids ...instance.Id) error {
 	e.Push("StopInstances", ids)
 	return nil
 }
@@ -194,17 +195,17 @@ func (e *fakeEnviron) DestroyController(controllerUUID string) error {
 	return nil
 }
 
-func (e *fakeEnviron) OpenPorts(rules []network.IngressRule) error {
+func (e *fakeEnviron) OpenPorts(ctx context.Context, rules []network.IngressRule) error {
 	e.Push("OpenPorts", rules)
 	return nil
 }
 
-func (e *fakeEnviron) ClosePorts(rules []network.IngressRule) error {
+func (e *fakeEnviron) ClosePorts(ctx context.Context, rules []network.IngressRule) error {
 	e.Push("ClosePorts", rules)
 	return nil
 }
 
-func (e *fakeEnviron) IngressRules() ([]network.IngressRule, error) {
+func (e *fakeEnviron) IngressRules(ctx context.Context) ([]network.IngressRule, error) {
 	e.Push("Ports")
 	return nil, nil
 }
@@ -308,17 +309,17 @@ func (e *fakeInstance) Addresses() ([]network.Address, error) {
 	}}, nil
 }
 
-func (e *fakeInstance) OpenPorts(machineId string, ports []network.IngressRule) error {
+func (e *fakeInstance) OpenPorts(ctx context.Context, machineId string, ports []network.IngressRule) error {
 	e.Push("OpenPorts", machineId, ports)
 	return nil
 }
 
-func (e *fakeInstance) ClosePorts(machineId string, ports []network.IngressRule) error {
+func (e *fakeInstance) ClosePorts(ctx context.Context, machineId string, ports []network.IngressRule) error {
 	e.Push("ClosePorts", machineId, ports)
 	return nil
 }
 
-func (e *fakeInstance) IngressRules(machineId string) ([]network.IngressRule, error) {
+func (e *fakeInstance) IngressRules(ctx context.Context, machineId string) ([]network.IngressRule, error) {
 	e.Push("Ports", machineId)
 	return nil, nil
 }
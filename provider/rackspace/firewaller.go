@@ -4,6 +4,8 @@
 package rackspace
 
 import (
+	"context"
+
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/environs"
@@ -28,19 +30,19 @@ type rackspaceFirewaller struct{}
 var _ openstack.Firewaller = (*rackspaceFirewaller)(nil)
 
 // OpenPorts is not supported.
-func (c *rackspaceFirewaller) OpenPorts(rules []network.IngressRule) error {
+func (c *rackspaceFirewaller) OpenPorts(ctx context.Context, rules []network.IngressRule) error {
 	return errors.NotSupportedf("OpenPorts")
 }
 
 // ClosePorts is not supported.
-func (c *rackspaceFirewaller) ClosePorts(rules []network.IngressRule) error {
+func (c *rackspaceFirewaller) ClosePorts(ctx context.Context, rules []network.IngressRule) error {
 	return errors.NotSupportedf("ClosePorts")
 }
 
 // IngressRules returns the port ranges opened for the whole environment.
 // Must only be used if the environment was setup with the
 // FwGlobal firewall mode.
-func (c *rackspaceFirewaller) IngressRules() ([]network.IngressRule, error) {
+func (c *rackspaceFirewaller) IngressRules(ctx context.Context) ([]network.IngressRule, error) {
 	return nil, errors.NotSupportedf("Ports")
 }
 
@@ -74,17 +76,17 @@ func (c *rackspaceFirewaller) SetUpGroups(controllerUUID, machineId string, apiP
 }
 
 // OpenInstancePorts implements Firewaller interface.
-func (c *rackspaceFirewaller) OpenInstancePorts(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+func (c *rackspaceFirewaller) OpenInstancePorts(ctx context.Context, inst instance.Instance, machineId string, rules []network.IngressRule) error {
 	return c.changeIngressRules(inst, true, rules)
 }
 
 // CloseInstancePorts implements Firewaller interface.
-func (c *rackspaceFirewaller) CloseInstancePorts(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+func (c *rackspaceFirewaller) CloseInstancePorts(ctx context.Context, inst instance.Instance, machineId string, rules []network.IngressRule) error {
 	return c.changeIngressRules(inst, false, rules)
 }
 
 // InstanceIngressRules implements Firewaller interface.
-func (c *rackspaceFirewaller) InstanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error) {
+func (c *rackspaceFirewaller) InstanceIngressRules(ctx context.Context, inst instance.Instance, machineId string) ([]network.IngressRule, error) {
 	_, configurator, err := c.getInstanceConfigurator(inst)
 	if err != nil {
 		return nil, errors.Trace(err)
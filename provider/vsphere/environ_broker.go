@@ -4,6 +4,7 @@
 package vsphere
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -64,16 +65,18 @@ func (*environ) MaintainInstance(args environs.StartInstanceParams) error {
 }
 
 // StartInstance implements environs.InstanceBroker.
-func (env *environ) StartInstance(args environs.StartInstanceParams) (result *environs.StartInstanceResult, err error) {
-	err = env.withSession(func(env *sessionEnviron) error {
-		result, err = env.StartInstance(args)
+func (env *environ) StartInstance(ctx context.Context, args environs.StartInstanceParams) (result *environs.StartInstanceResult, err error) {
+	err = env.withContextSession(ctx, func(env *sessionEnviron) error {
+		result, err = env.StartInstance(ctx, args)
 		return err
 	})
 	return result, err
 }
 
-// StartInstance implements environs.InstanceBroker.
-func (env *sessionEnviron) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+// StartInstance implements environs.InstanceBroker. API calls are made
+// with the session's context, which is the one passed to
+// environ.StartInstance.
+func (env *sessionEnviron) StartInstance(_ context.Context, args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
 	img, err := findImageMetadata(env, args)
 	if err != nil {
 		return nil, common.ZoneIndependentError(err)
@@ -256,14 +259,16 @@ func (env *sessionEnviron) AllInstances() ([]instance.Instance, error) {
 }
 
 // StopInstances implements environs.InstanceBroker.
-func (env *environ) StopInstances(ids ...instance.Id) error {
-	return env.withSession(func(env *sessionEnviron) error {
-		return env.StopInstances(ids...)
+func (env *environ) StopInstances(ctx context.Context, ids ...instance.Id) error {
+	return env.withContextSession(ctx, func(env *sessionEnviron) error {
+		return env.StopInstances(ctx, ids...)
 	})
 }
 
-// StopInstances implements environs.InstanceBroker.
-func (env *sessionEnviron) StopInstances(ids ...instance.Id) error {
+// StopInstances implements environs.InstanceBroker. API calls are made
+// with the session's context, which is the one passed to
+// environ.StopInstances.
+func (env *sessionEnviron) StopInstances(_ context.Context, ids ...instance.Id) error {
 	modelFolderPath := path.Join(
 		controllerFolderName("*"),
 		env.modelFolderName(),
@@ -4,22 +4,24 @@
 package vsphere
 
 import (
+	"context"
+
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/network"
 )
 
 // OpenPorts is part of the environs.Firewaller interface.
-func (*environ) OpenPorts(rules []network.IngressRule) error {
+func (*environ) OpenPorts(ctx context.Context, rules []network.IngressRule) error {
 	return errors.Trace(errors.NotSupportedf("ClosePorts"))
 }
 
 // ClosePorts is part of the environs.Firewaller interface.
-func (*environ) ClosePorts(rules []network.IngressRule) error {
+func (*environ) ClosePorts(ctx context.Context, rules []network.IngressRule) error {
 	return errors.Trace(errors.NotSupportedf("ClosePorts"))
 }
 
 // IngressPorts is part of the environs.Firewaller interface.
-func (*environ) IngressRules() ([]network.IngressRule, error) {
+func (*environ) IngressRules(ctx context.Context) ([]network.IngressRule, error) {
 	return nil, errors.Trace(errors.NotSupportedf("Ports"))
 }
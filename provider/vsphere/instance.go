@@ -4,6 +4,8 @@
 package vsphere
 
 import (
+	"context"
+
 	"github.com/juju/errors"
 	"github.com/vmware/govmomi/vim25/mo"
 	"github.com/vmware/govmomi/vim25/types"
@@ -64,19 +66,19 @@ func (inst *environInstance) Addresses() ([]network.Address, error) {
 
 // OpenPorts opens the given ports on the instance, which
 // should have been started with the given machine id.
-func (inst *environInstance) OpenPorts(machineID string, rules []network.IngressRule) error {
+func (inst *environInstance) OpenPorts(ctx context.Context, machineID string, rules []network.IngressRule) error {
 	return inst.changeIngressRules(true, rules)
 }
 
 // ClosePorts closes the given ports on the instance, which
 // should have been started with the given machine id.
-func (inst *environInstance) ClosePorts(machineID string, rules []network.IngressRule) error {
+func (inst *environInstance) ClosePorts(ctx context.Context, machineID string, rules []network.IngressRule) error {
 	return inst.changeIngressRules(false, rules)
 }
 
 // IngressRules returns the set of ports open on the instance, which
 // should have been started with the given machine id.
-func (inst *environInstance) IngressRules(machineID string) ([]network.IngressRule, error) {
+func (inst *environInstance) IngressRules(ctx context.Context, machineID string) ([]network.IngressRule, error) {
 	_, client, err := inst.getInstanceConfigurator()
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -33,7 +33,13 @@ type sessionEnviron struct {
 }
 
 func (env *environ) withSession(f func(*sessionEnviron) error) error {
-	ctx := context.Background()
+	return env.withContextSession(context.Background(), f)
+}
+
+// withContextSession calls f with a sessionEnviron whose API calls are
+// made with the given context, so that they are abandoned if the
+// context is cancelled.
+func (env *environ) withContextSession(ctx context.Context, f func(*sessionEnviron) error) error {
 	return env.withClient(ctx, func(client Client) error {
 		return f(&sessionEnviron{
 			environ: env,
@@ -22,3 +22,46 @@ type OriginTarget struct {
 	// messages to.
 	Target string `yaml:"target"`
 }
+
+const (
+	// StatusRequestTopic is published to ask the forwarding worker on
+	// every API server to publish its status on StatusTopic.
+	StatusRequestTopic = "worker.pubsub.status.request"
+
+	// StatusTopic is published by the forwarding worker in response
+	// to a status request.
+	StatusTopic = "worker.pubsub.status"
+)
+
+// StatusRequest represents the data for the status request topic.
+type StatusRequest struct {
+	// RequestID identifies the request, and is included in each
+	// response.
+	RequestID string `yaml:"request-id"`
+}
+
+// Status represents the data for the status topic.
+type Status struct {
+	// RequestID identifies the request being responded to.
+	RequestID string `yaml:"request-id"`
+	// Origin represents the API server reporting its status.
+	Origin string `yaml:"origin"`
+	// Targets holds the status of forwarding to each other API server.
+	Targets []TargetStatus `yaml:"targets"`
+}
+
+// TargetStatus holds the status of forwarding messages from one API
+// server to another.
+type TargetStatus struct {
+	// Target represents the API server that messages are forwarded to.
+	Target string `yaml:"target"`
+	// Connected is true if there is currently a connection to the
+	// target.
+	Connected bool `yaml:"connected"`
+	// Addresses holds the addresses used to connect to the target.
+	Addresses []string `yaml:"addresses"`
+	// QueueLength is the number of messages waiting to be sent.
+	QueueLength int `yaml:"queue-length"`
+	// SentCount is the number of messages sent to the target.
+	SentCount uint64 `yaml:"sent-count"`
+}
@@ -37,6 +37,22 @@ type Codec struct {
 	logMessages int32
 	mu          sync.Mutex
 	closing     bool
+	recordSize  SizeRecorder
+}
+
+// SizeRecorder is called with the header and the size in bytes of the
+// JSON encoding of each message read or written by a Codec. The
+// received parameter reports whether the message was read.
+//
+// The size is that of the message before any compression by the
+// underlying connection.
+type SizeRecorder func(hdr *rpc.Header, size int, received bool)
+
+// SetSizeRecorder arranges for f to be called with the size of each
+// message subsequently read or written by the codec. It must be called
+// before the codec is used.
+func (c *Codec) SetSizeRecorder(f SizeRecorder) {
+	c.recordSize = f
 }
 
 // New returns an rpc codec that uses conn to send and receive
@@ -140,6 +156,9 @@ func (c *Codec) ReadHeader(hdr *rpc.Header) error {
 	hdr.Error = c.msg.Error
 	hdr.ErrorCode = c.msg.ErrorCode
 	hdr.Version = version
+	if c.recordSize != nil {
+		c.recordSize(hdr, len(m), true)
+	}
 	return nil
 }
 
@@ -216,6 +235,20 @@ func (c *Codec) WriteMessage(hdr *rpc.Header, body interface{}) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if c.recordSize != nil {
+		// Encode the message here, rather than leaving it to the
+		// connection, so that we know its size.
+		data, err := json.Marshal(msg)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		logger.Tracef("-> %s", data)
+		if err := c.conn.Send(json.RawMessage(data)); err != nil {
+			return err
+		}
+		c.recordSize(hdr, len(data), false)
+		return nil
+	}
 	if logger.IsTraceEnabled() {
 		data, err := json.Marshal(msg)
 		if err != nil {
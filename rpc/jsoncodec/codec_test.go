@@ -248,6 +248,39 @@ func (*suite) TestWrite(c *gc.C) {
 	}
 }
 
+func (*suite) TestSizeRecorder(c *gc.C) {
+	request := `{"request-id":1,"type":"foo","version":2,"request":"frob","params":{"X":"param"}}`
+	conn := &testConn{readMsgs: []string{request}}
+	codec := jsoncodec.New(conn)
+	type recorded struct {
+		hdr      rpc.Header
+		size     int
+		received bool
+	}
+	var sizes []recorded
+	codec.SetSizeRecorder(func(hdr *rpc.Header, size int, received bool) {
+		sizes = append(sizes, recorded{*hdr, size, received})
+	})
+
+	var hdr rpc.Header
+	err := codec.ReadHeader(&hdr)
+	c.Assert(err, jc.ErrorIsNil)
+	reply := &rpc.Header{RequestId: 1, Version: 1}
+	err = codec.WriteMessage(reply, &value{X: "result"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(conn.writeMsgs, gc.HasLen, 1)
+	assertJSONEqual(c, conn.writeMsgs[0], `{"request-id": 1, "response": {"X": "result"}}`)
+	c.Assert(sizes, jc.DeepEquals, []recorded{{
+		hdr:      hdr,
+		size:     len(request),
+		received: true,
+	}, {
+		hdr:  *reply,
+		size: len(conn.writeMsgs[0]),
+	}})
+}
+
 func (*suite) TestDumpRequest(c *gc.C) {
 	for i, test := range []struct {
 		hdr    rpc.Header
@@ -14,6 +14,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/cloudconfig/cloudinit"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
@@ -92,6 +93,10 @@ type MachineTemplate struct {
 	// with the machine.
 	Placement string
 
+	// CloudInitUserData holds user-supplied cloud-init configuration,
+	// in YAML, to be merged into the machine's when it is provisioned.
+	CloudInitUserData string
+
 	// principals holds the principal units that will
 	// associated with the machine.
 	principals []string
@@ -255,6 +260,9 @@ func (st *State) effectiveMachineTemplate(p MachineTemplate, allowController boo
 			return tmpl, errControllerNotAllowed
 		}
 	}
+	if _, err := cloudinit.ParseUserData(p.CloudInitUserData); err != nil {
+		return tmpl, errors.Annotate(err, "invalid cloud-init user data")
+	}
 	return p, nil
 }
 
@@ -507,6 +515,7 @@ func (st *State) machineDocForTemplate(template MachineTemplate, id string) *mac
 		PreferredPublicAddress:  fromNetworkAddress(publicAddr, OriginMachine),
 		NoVote:                  template.NoVote,
 		Placement:               template.Placement,
+		CloudInitUserData:       template.CloudInitUserData,
 	}
 }
 
@@ -818,6 +827,36 @@ func (st *State) maintainControllersOps(mdocs []*machineDoc, currentInfo *Contro
 func (st *State) EnableHA(
 	numControllers int, cons constraints.Value, series string, placement []string,
 ) (ControllersChanges, error) {
+	machines := make([]ControllerMachineParams, len(placement))
+	for i, p := range placement {
+		machines[i].Placement = p
+	}
+	return st.EnableHAWithMachines(numControllers, cons, series, machines)
+}
+
+// ControllerMachineParams holds the placement directive and constraints
+// for one of the new controller machines started by
+// EnableHAWithMachines.
+type ControllerMachineParams struct {
+	// Placement is the placement directive for the machine. A
+	// directive with machine scope converts the existing machine
+	// into a controller rather than starting a new one.
+	Placement string
+
+	// Constraints, if not empty, are used for the machine instead of
+	// the constraints passed to EnableHAWithMachines.
+	Constraints constraints.Value
+}
+
+// EnableHAWithMachines is like EnableHA, but allows the placement and
+// constraints of each new controller machine to be given individually.
+// The machines are used in order for any new controller machines that
+// are required; thereafter any new machines are started according to
+// the given constraints and series. A machine with placement but no
+// constraints is started without constraints, as with EnableHA.
+func (st *State) EnableHAWithMachines(
+	numControllers int, cons constraints.Value, series string, machines []ControllerMachineParams,
+) (ControllersChanges, error) {
 
 	if numControllers < 0 || (numControllers != 0 && numControllers%2 != 1) {
 		return ControllersChanges{}, errors.New("number of controllers must be odd and non-negative")
@@ -842,7 +881,7 @@ func (st *State) EnableHA(
 			return nil, errors.New("cannot reduce controller count")
 		}
 
-		intent, err := st.enableHAIntentions(currentInfo, placement)
+		intent, err := st.enableHAIntentions(currentInfo, machines)
 		if err != nil {
 			return nil, err
 		}
@@ -912,18 +951,21 @@ func (st *State) enableHAIntentionOps(
 		ops = append(ops, convertControllerOps(m)...)
 		change.Converted = append(change.Converted, m.doc.Id)
 	}
-	// Use any placement directives that have been provided
-	// when adding new machines, until the directives have
-	// been all used up. Ignore constraints for provided machines.
+	// Use any placement directives and constraints that have been
+	// provided when adding new machines, until they have been all
+	// used up. Ignore the common constraints for provided machines.
 	// Set up a helper function to do the work required.
 	placementCount := 0
 	getPlacementConstraints := func() (string, constraints.Value) {
-		if placementCount >= len(intent.placement) {
+		if placementCount >= len(intent.machines) {
 			return "", cons
 		}
-		result := intent.placement[placementCount]
+		machine := intent.machines[placementCount]
 		placementCount++
-		return result, constraints.Value{}
+		if machine.Placement == "" && constraints.IsEmpty(&machine.Constraints) {
+			return "", cons
+		}
+		return machine.Placement, machine.Constraints
 	}
 	mdocs := make([]*machineDoc, intent.newCount)
 	for i := range mdocs {
@@ -980,8 +1022,8 @@ var controllerAvailable = func(m *Machine) (bool, error) {
 }
 
 type enableHAIntent struct {
-	newCount  int
-	placement []string
+	newCount int
+	machines []ControllerMachineParams
 
 	promote, maintain, demote, remove, convert []*Machine
 }
@@ -992,16 +1034,22 @@ type enableHAIntent struct {
 //   demoting unavailable, voting machines;
 //   removing unavailable, non-voting, non-vote-holding machines;
 //   gathering available, non-voting machines that may be promoted;
-func (st *State) enableHAIntentions(info *ControllerInfo, placement []string) (*enableHAIntent, error) {
+func (st *State) enableHAIntentions(info *ControllerInfo, machines []ControllerMachineParams) (*enableHAIntent, error) {
 	var intent enableHAIntent
-	for _, s := range placement {
+	for _, machine := range machines {
+		s := machine.Placement
+		if s == "" {
+			// Only constraints were given for the machine.
+			intent.machines = append(intent.machines, machine)
+			continue
+		}
 		// TODO(natefinch): unscoped placements shouldn't ever get here (though
 		// they do currently).  We should fix up the CLI to always add a scope
 		// to placements and then we can remove the need to deal with unscoped
 		// placements.
 		p, err := instance.ParsePlacement(s)
 		if err == instance.ErrPlacementScopeMissing {
-			intent.placement = append(intent.placement, s)
+			intent.machines = append(intent.machines, machine)
 			continue
 		}
 		if err == nil && p.Scope == instance.MachineScope {
@@ -1020,7 +1068,7 @@ func (st *State) enableHAIntentions(info *ControllerInfo, placement []string) (*
 				return nil, errors.Errorf("machine for placement directive %q is already a controller", s)
 			}
 			intent.convert = append(intent.convert, m)
-			intent.placement = append(intent.placement, s)
+			intent.machines = append(intent.machines, machine)
 			continue
 		}
 		return nil, errors.Errorf("unsupported placement directive %q", s)
@@ -0,0 +1,125 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/instance"
+)
+
+// CheckAdoptedUnits checks that the named units, whose agents were
+// found on a machine being adopted, may be re-associated with the
+// machine with the given id, or with a new machine if machineId is
+// empty. Units that have since been removed, or are dead, are ignored,
+// as their agents are discarded along with the machine's previous
+// agent; but a unit assigned to any other machine is refused, as
+// adopting the machine would leave the unit's workload running in two
+// places.
+func (st *State) CheckAdoptedUnits(machineId string, units []string) error {
+	for _, name := range units {
+		unit, err := st.Unit(name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if unit.Life() == Dead {
+			continue
+		}
+		assigned, err := unit.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if assigned != machineId {
+			return errors.Errorf("unit %q found on the machine is assigned to machine %s", name, assigned)
+		}
+	}
+	return nil
+}
+
+// Adopt hands the machine over to a new agent, deployed on the
+// machine's existing instance to replace an agent that can no longer
+// be trusted, such as one restored from an image. The machine's
+// nonce is replaced, and its password and those of its units are
+// invalidated, so that the previous machine and unit agents can no
+// longer log in; the new machine agent deploys the units afresh.
+//
+// The instance id must match that recorded for the machine, and units
+// holds the names of the units whose agents were found on the
+// instance, which are checked as described by CheckAdoptedUnits.
+func (m *Machine) Adopt(id instance.Id, nonce string, units []string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot adopt machine %q", m)
+
+	if id == "" || nonce == "" {
+		return errors.New("instance id and nonce cannot be empty")
+	}
+	if err := m.st.CheckAdoptedUnits(m.Id(), units); err != nil {
+		return errors.Trace(err)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if m.doc.Life != Alive {
+			return nil, errNotAlive
+		}
+		if m.doc.Nonce == nonce {
+			return nil, jujutxn.ErrNoOperations
+		}
+		current, err := m.InstanceId()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if current != id {
+			return nil, errors.Errorf("machine has instance %q, not %q", current, id)
+		}
+		ops := []txn.Op{{
+			C:      machinesC,
+			Id:     m.doc.DocID,
+			Assert: append(isAliveDoc, bson.DocElem{"nonce", m.doc.Nonce}),
+			Update: bson.D{{"$set", bson.D{
+				{"nonce", nonce},
+				{"passwordhash", ""},
+			}}},
+		}}
+		for _, name := range m.doc.Principals {
+			unit, err := m.st.Unit(name)
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			for _, name := range append([]string{name}, unit.doc.Subordinates...) {
+				ops = append(ops, txn.Op{
+					C:      unitsC,
+					Id:     m.st.docID(name),
+					Assert: txn.DocExists,
+					Update: bson.D{{"$set", bson.D{{"passwordhash", ""}}}},
+				})
+			}
+		}
+		return ops, nil
+	}
+	if err := m.st.db().Run(buildTxn); err != nil {
+		return err
+	}
+	m.doc.Nonce = nonce
+	m.doc.PasswordHash = ""
+	probablyAddModelEvent(m.st, ModelEvent{
+		Kind:     EventMachineAdopted,
+		Entities: []string{m.Tag().String()},
+		Message:  fmt.Sprintf("machine %s adopted by a new agent on instance %q", m.Id(), id),
+	})
+	return nil
+}
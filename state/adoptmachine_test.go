@@ -0,0 +1,85 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type AdoptMachineSuite struct {
+	ConnSuite
+	machine *state.Machine
+	unit    *state.Unit
+}
+
+var _ = gc.Suite(&AdoptMachineSuite{})
+
+func (s *AdoptMachineSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.machine = s.Factory.MakeMachine(c, &factory.MachineParams{
+		InstanceId: "manual:10.0.0.1",
+		Nonce:      "manual:10.0.0.1:old",
+		Password:   "machine-password-0123456",
+	})
+	s.unit = s.Factory.MakeUnit(c, &factory.UnitParams{
+		Machine:  s.machine,
+		Password: "unit-password-01234567890",
+	})
+}
+
+func (s *AdoptMachineSuite) TestAdopt(c *gc.C) {
+	err := s.machine.Adopt("manual:10.0.0.1", "manual:10.0.0.1:new", []string{s.unit.Name()})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.CheckProvisioned("manual:10.0.0.1:new"), jc.IsTrue)
+	c.Assert(s.machine.CheckProvisioned("manual:10.0.0.1:old"), jc.IsFalse)
+	c.Assert(s.machine.PasswordValid("machine-password-0123456"), jc.IsFalse)
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.PasswordValid("unit-password-01234567890"), jc.IsFalse)
+
+	events, err := s.State.ModelEvents(state.ModelEventsFilter{
+		Kinds: []state.ModelEventKind{state.EventMachineAdopted},
+		Limit: 10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, gc.HasLen, 1)
+	c.Assert(events[0].Entities, jc.DeepEquals, []string{s.machine.Tag().String()})
+}
+
+func (s *AdoptMachineSuite) TestAdoptDifferentInstance(c *gc.C) {
+	err := s.machine.Adopt("manual:10.0.0.2", "manual:10.0.0.2:new", nil)
+	c.Assert(err, gc.ErrorMatches, `cannot adopt machine "`+s.machine.Id()+`": machine has instance "manual:10.0.0.1", not "manual:10.0.0.2"`)
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.CheckProvisioned("manual:10.0.0.1:old"), jc.IsTrue)
+}
+
+func (s *AdoptMachineSuite) TestAdoptUnitAssignedElsewhere(c *gc.C) {
+	other := s.Factory.MakeUnit(c, nil)
+	otherMachine, err := other.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.Adopt("manual:10.0.0.1", "manual:10.0.0.1:new", []string{s.unit.Name(), other.Name()})
+	c.Assert(err, gc.ErrorMatches, `cannot adopt machine "`+s.machine.Id()+`": unit "`+other.Name()+`" found on the machine is assigned to machine `+otherMachine)
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.CheckProvisioned("manual:10.0.0.1:old"), jc.IsTrue)
+}
+
+func (s *AdoptMachineSuite) TestCheckAdoptedUnits(c *gc.C) {
+	// Units that no longer exist are discarded with the previous agent.
+	err := s.State.CheckAdoptedUnits("", []string{"gone/0"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.CheckAdoptedUnits(s.machine.Id(), []string{s.unit.Name(), "gone/0"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.CheckAdoptedUnits("", []string{s.unit.Name()})
+	c.Assert(err, gc.ErrorMatches, `unit "`+s.unit.Name()+`" found on the machine is assigned to machine `+s.machine.Id())
+}
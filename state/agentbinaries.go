@@ -0,0 +1,184 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"github.com/juju/version"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/tools"
+)
+
+// AgentBinaryStorageUsage describes the storage used by the agent binaries
+// in the catalogues of all of the controller's models.
+type AgentBinaryStorageUsage struct {
+	// Entries is the number of agent binaries in all catalogues.
+	Entries int
+
+	// Binaries is the number of distinct agent binaries stored.
+	Binaries int
+
+	// Size is the total size, in bytes, of the agent binaries in
+	// all catalogues.
+	Size int64
+
+	// StoredSize is the size, in bytes, of the distinct agent
+	// binaries stored.
+	StoredSize int64
+}
+
+// AgentBinaryStorageUsage returns the storage used by the agent binaries
+// in the catalogues of all of the controller's models.
+func (st *State) AgentBinaryStorageUsage() (AgentBinaryStorageUsage, error) {
+	coll, closer := st.db().GetRawCollection(toolsmetadataC)
+	defer closer()
+
+	var docs []struct {
+		ModelUUID string `bson:"model-uuid"`
+		Size      int64  `bson:"size"`
+		Path      string `bson:"path"`
+		Bucket    string `bson:"bucket"`
+	}
+	err := coll.Find(nil).Select(bson.D{
+		{"model-uuid", 1}, {"size", 1}, {"path", 1}, {"bucket", 1},
+	}).All(&docs)
+	if err != nil {
+		return AgentBinaryStorageUsage{}, errors.Annotate(err, "reading agent binary metadata")
+	}
+	var usage AgentBinaryStorageUsage
+	stored := set.NewStrings()
+	for _, doc := range docs {
+		usage.Entries++
+		usage.Size += doc.Size
+		bucket := doc.Bucket
+		if bucket == "" {
+			bucket = doc.ModelUUID
+		}
+		location := bucket + "/" + doc.Path
+		if !stored.Contains(location) {
+			stored.Add(location)
+			usage.Binaries++
+			usage.StoredSize += doc.Size
+		}
+	}
+	return usage, nil
+}
+
+// RemoveUnusedAgentBinaries removes the agent binaries that are no longer
+// needed from the catalogues of all of the controller's models. An agent
+// binary is needed by a model if any of the model's agents is running
+// it, or if it is not older than the model's agent version, so that it
+// is available for upgrades. Because hosted models fall back to the
+// controller's catalogue, an agent binary in the controller's catalogue
+// is kept if any model needs it. Binaries shared between catalogues are
+// only removed once no catalogue refers to them.
+func (st *State) RemoveUnusedAgentBinaries() error {
+	uuids, err := st.AllModelUUIDs()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	controllerUUID := st.ControllerModelUUID()
+	needed := make(map[string]agentBinariesNeeded)
+	for _, uuid := range uuids {
+		n, err := readAgentBinariesNeeded(st.database, uuid)
+		if errors.IsNotFound(err) {
+			// The model has been removed.
+			continue
+		} else if err != nil {
+			return errors.Annotatef(err, "model %q", uuid)
+		}
+		needed[uuid] = n
+	}
+
+	for uuid := range needed {
+		isNeeded := needed[uuid].isNeeded
+		if uuid == controllerUUID {
+			isNeeded = func(v version.Binary) bool {
+				for _, n := range needed {
+					if n.isNeeded(v) {
+						return true
+					}
+				}
+				return false
+			}
+		}
+		if err := removeUnusedAgentBinaries(st.database, uuid, controllerUUID, isNeeded); err != nil {
+			return errors.Annotatef(err, "model %q", uuid)
+		}
+	}
+	return nil
+}
+
+// agentBinariesNeeded records the agent binaries a model needs.
+type agentBinariesNeeded struct {
+	agentVersion version.Number
+	running      set.Strings
+}
+
+func (n agentBinariesNeeded) isNeeded(v version.Binary) bool {
+	return n.running.Contains(v.String()) || v.Number.Compare(n.agentVersion) >= 0
+}
+
+func readAgentBinariesNeeded(db Database, uuid string) (agentBinariesNeeded, error) {
+	db, closer := db.CopyForModel(uuid)
+	defer closer()
+
+	cfg, err := getModelConfig(db)
+	if err != nil {
+		return agentBinariesNeeded{}, errors.Trace(err)
+	}
+	agentVersion, ok := cfg.AgentVersion()
+	if !ok {
+		return agentBinariesNeeded{}, errors.NotFoundf("agent version")
+	}
+	running := set.NewStrings()
+	for _, collName := range []string{machinesC, unitsC} {
+		coll, closer := db.GetCollection(collName)
+		var docs []struct {
+			Tools *tools.Tools `bson:"tools"`
+		}
+		err := coll.Find(bson.D{{"tools", bson.D{{"$exists", true}}}}).Select(bson.D{{"tools", 1}}).All(&docs)
+		closer()
+		if err != nil {
+			return agentBinariesNeeded{}, errors.Annotatef(err, "reading %s", collName)
+		}
+		for _, doc := range docs {
+			if doc.Tools != nil {
+				running.Add(doc.Tools.Version.String())
+			}
+		}
+	}
+	return agentBinariesNeeded{agentVersion: agentVersion, running: running}, nil
+}
+
+func removeUnusedAgentBinaries(db Database, uuid, sharedBucket string, isNeeded func(version.Binary) bool) error {
+	storage := newToolsStorageCloser(db, uuid, sharedBucket)
+	defer storage.Close()
+
+	all, err := storage.AllMetadata()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var removed int
+	for _, m := range all {
+		v, err := version.ParseBinary(m.Version)
+		if err != nil {
+			logger.Warningf("not removing agent binary with invalid version %q", m.Version)
+			continue
+		}
+		if isNeeded(v) {
+			continue
+		}
+		if err := storage.Remove(m.Version); err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "removing agent binary %v", m.Version)
+		}
+		removed++
+	}
+	if removed > 0 {
+		logger.Infof("removed %d unused agent binaries from model %q", removed, uuid)
+	}
+	return nil
+}
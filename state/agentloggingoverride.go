@@ -0,0 +1,193 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// AgentLoggingOverride holds logging configuration that is applied to
+// a single agent on top of the model's logging-config, until it
+// expires.
+type AgentLoggingOverride struct {
+	// Tag identifies the machine or unit agent.
+	Tag names.Tag
+
+	// Config holds the loggo configuration string to apply.
+	Config string
+
+	// Expires is the time after which the override is no longer
+	// applied.
+	Expires time.Time
+}
+
+// Expired reports whether the override has expired at the given time.
+func (o AgentLoggingOverride) Expired(now time.Time) bool {
+	return !now.Before(o.Expires)
+}
+
+type agentLoggingOverrideDoc struct {
+	DocID     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+	Tag       string `bson:"tag"`
+	Config    string `bson:"config"`
+	Expires   int64  `bson:"expires"`
+}
+
+// SetAgentLoggingOverride records logging configuration to be applied
+// to the agent with the given tag until the given expiry time,
+// replacing any existing override for that agent.
+func (st *State) SetAgentLoggingOverride(override AgentLoggingOverride) error {
+	if err := validateAgentLoggingOverride(override); err != nil {
+		return errors.Trace(err)
+	}
+	entityColl, id, err := st.tagToCollectionAndId(override.Tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	docID := st.docID(override.Tag.String())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if _, err := st.FindEntity(override.Tag); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		ops := []txn.Op{{
+			C:      entityColl,
+			Id:     id,
+			Assert: txn.DocExists,
+		}}
+		_, err := st.AgentLoggingOverride(override.Tag)
+		if errors.IsNotFound(err) {
+			return append(ops, txn.Op{
+				C:      agentLoggingOverridesC,
+				Id:     docID,
+				Assert: txn.DocMissing,
+				Insert: &agentLoggingOverrideDoc{
+					DocID:     docID,
+					ModelUUID: st.ModelUUID(),
+					Tag:       override.Tag.String(),
+					Config:    override.Config,
+					Expires:   override.Expires.UnixNano(),
+				},
+			}), nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, txn.Op{
+			C:      agentLoggingOverridesC,
+			Id:     docID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"config", override.Config},
+				{"expires", override.Expires.UnixNano()},
+			}}},
+		}), nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot set logging override for %s", names.ReadableString(override.Tag))
+	}
+	return nil
+}
+
+func validateAgentLoggingOverride(override AgentLoggingOverride) error {
+	switch override.Tag.(type) {
+	case names.MachineTag, names.UnitTag:
+	default:
+		return errors.NotValidf("logging override for %v", override.Tag)
+	}
+	if override.Config == "" {
+		return errors.NotValidf("empty logging config")
+	}
+	if _, err := loggo.ParseConfigString(override.Config); err != nil {
+		return errors.NewNotValid(err, "logging config")
+	}
+	if override.Expires.IsZero() {
+		return errors.NotValidf("missing expiry time")
+	}
+	return nil
+}
+
+// RemoveAgentLoggingOverride removes any logging override for the
+// agent with the given tag.
+func (st *State) RemoveAgentLoggingOverride(tag names.Tag) error {
+	ops := []txn.Op{removeAgentLoggingOverrideOp(st, tag)}
+	if err := st.db().RunTransaction(ops); err != nil {
+		return errors.Annotatef(err, "cannot remove logging override for %s", names.ReadableString(tag))
+	}
+	return nil
+}
+
+// removeAgentLoggingOverrideOp returns an operation that removes any
+// logging override for the agent with the given tag.
+func removeAgentLoggingOverrideOp(mb modelBackend, tag names.Tag) txn.Op {
+	return txn.Op{
+		C:      agentLoggingOverridesC,
+		Id:     mb.docID(tag.String()),
+		Remove: true,
+	}
+}
+
+// AgentLoggingOverride returns the logging override for the agent with
+// the given tag, whether or not it has expired. If there is no
+// override, an error satisfying errors.IsNotFound is returned.
+func (st *State) AgentLoggingOverride(tag names.Tag) (AgentLoggingOverride, error) {
+	coll, closer := st.db().GetCollection(agentLoggingOverridesC)
+	defer closer()
+
+	var doc agentLoggingOverrideDoc
+	err := coll.FindId(tag.String()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return AgentLoggingOverride{}, errors.NotFoundf("logging override for %s", names.ReadableString(tag))
+	} else if err != nil {
+		return AgentLoggingOverride{}, errors.Annotatef(err, "cannot read logging override for %s", names.ReadableString(tag))
+	}
+	return doc.override()
+}
+
+// AllAgentLoggingOverrides returns all of the model's agent logging
+// overrides, including those that have expired.
+func (st *State) AllAgentLoggingOverrides() ([]AgentLoggingOverride, error) {
+	coll, closer := st.db().GetCollection(agentLoggingOverridesC)
+	defer closer()
+
+	var docs []agentLoggingOverrideDoc
+	if err := coll.Find(nil).Sort("tag").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read logging overrides")
+	}
+	result := make([]AgentLoggingOverride, len(docs))
+	for i, doc := range docs {
+		override, err := doc.override()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result[i] = override
+	}
+	return result, nil
+}
+
+func (doc agentLoggingOverrideDoc) override() (AgentLoggingOverride, error) {
+	tag, err := names.ParseTag(doc.Tag)
+	if err != nil {
+		return AgentLoggingOverride{}, errors.Trace(err)
+	}
+	return AgentLoggingOverride{
+		Tag:     tag,
+		Config:  doc.Config,
+		Expires: time.Unix(0, doc.Expires).UTC(),
+	}, nil
+}
+
+// WatchAgentLoggingOverride returns a watcher that notifies when the
+// logging override for the agent with the given tag is set or removed.
+func (st *State) WatchAgentLoggingOverride(tag names.Tag) NotifyWatcher {
+	return newEntityWatcher(st, agentLoggingOverridesC, st.docID(tag.String()))
+}
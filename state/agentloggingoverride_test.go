@@ -0,0 +1,149 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+)
+
+type AgentLoggingOverrideSuite struct {
+	ConnSuite
+	machine *state.Machine
+	expires time.Time
+}
+
+var _ = gc.Suite(&AgentLoggingOverrideSuite{})
+
+func (s *AgentLoggingOverrideSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.machine = s.Factory.MakeMachine(c, nil)
+	s.expires = time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func (s *AgentLoggingOverrideSuite) TestNoOverride(c *gc.C) {
+	_, err := s.State.AgentLoggingOverride(s.machine.Tag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `logging override for machine 0 not found`)
+}
+
+func (s *AgentLoggingOverrideSuite) TestSetAgentLoggingOverride(c *gc.C) {
+	override := state.AgentLoggingOverride{
+		Tag:     s.machine.Tag(),
+		Config:  "juju.worker=TRACE",
+		Expires: s.expires,
+	}
+	err := s.State.SetAgentLoggingOverride(override)
+	c.Assert(err, jc.ErrorIsNil)
+
+	got, err := s.State.AgentLoggingOverride(s.machine.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, jc.DeepEquals, override)
+
+	// Setting again replaces the existing override.
+	override.Config = "juju.apiserver=DEBUG"
+	override.Expires = s.expires.Add(time.Hour)
+	err = s.State.SetAgentLoggingOverride(override)
+	c.Assert(err, jc.ErrorIsNil)
+
+	all, err := s.State.AllAgentLoggingOverrides()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, jc.DeepEquals, []state.AgentLoggingOverride{override})
+}
+
+func (s *AgentLoggingOverrideSuite) TestSetAgentLoggingOverrideInvalid(c *gc.C) {
+	for _, test := range []struct {
+		override state.AgentLoggingOverride
+		err      string
+	}{{
+		override: state.AgentLoggingOverride{Tag: names.NewApplicationTag("foo"), Config: "a=DEBUG", Expires: s.expires},
+		err:      `logging override for application-foo not valid`,
+	}, {
+		override: state.AgentLoggingOverride{Tag: s.machine.Tag(), Expires: s.expires},
+		err:      `empty logging config not valid`,
+	}, {
+		override: state.AgentLoggingOverride{Tag: s.machine.Tag(), Config: "a=WIBBLE", Expires: s.expires},
+		err:      `logging config: .*`,
+	}, {
+		override: state.AgentLoggingOverride{Tag: s.machine.Tag(), Config: "a=DEBUG"},
+		err:      `missing expiry time not valid`,
+	}} {
+		err := s.State.SetAgentLoggingOverride(test.override)
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *AgentLoggingOverrideSuite) TestSetAgentLoggingOverrideMissingAgent(c *gc.C) {
+	err := s.State.SetAgentLoggingOverride(state.AgentLoggingOverride{
+		Tag:     names.NewMachineTag("42"),
+		Config:  "juju=DEBUG",
+		Expires: s.expires,
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set logging override for machine 42: machine 42 not found`)
+}
+
+func (s *AgentLoggingOverrideSuite) TestRemoveAgentLoggingOverride(c *gc.C) {
+	err := s.State.SetAgentLoggingOverride(state.AgentLoggingOverride{
+		Tag:     s.machine.Tag(),
+		Config:  "juju=DEBUG",
+		Expires: s.expires,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveAgentLoggingOverride(s.machine.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AgentLoggingOverride(s.machine.Tag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Removing a missing override is not an error.
+	err = s.State.RemoveAgentLoggingOverride(s.machine.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *AgentLoggingOverrideSuite) TestRemovedWithMachine(c *gc.C) {
+	err := s.State.SetAgentLoggingOverride(state.AgentLoggingOverride{
+		Tag:     s.machine.Tag(),
+		Config:  "juju=DEBUG",
+		Expires: s.expires,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AgentLoggingOverride(s.machine.Tag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *AgentLoggingOverrideSuite) TestExpired(c *gc.C) {
+	override := state.AgentLoggingOverride{Expires: s.expires}
+	c.Assert(override.Expired(s.expires.Add(-time.Second)), jc.IsFalse)
+	c.Assert(override.Expired(s.expires), jc.IsTrue)
+}
+
+func (s *AgentLoggingOverrideSuite) TestWatchAgentLoggingOverride(c *gc.C) {
+	w := s.State.WatchAgentLoggingOverride(s.machine.Tag())
+	defer testing.AssertStop(c, w)
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.State.SetAgentLoggingOverride(state.AgentLoggingOverride{
+		Tag:     s.machine.Tag(),
+		Config:  "juju=DEBUG",
+		Expires: s.expires,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.State.RemoveAgentLoggingOverride(s.machine.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -0,0 +1,252 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// AgentOffline records an operator's acknowledgement that the agent of
+// a machine or unit is intentionally not communicating with the
+// controller, such as during long hardware maintenance. Until it
+// expires, the agent is reported as offline rather than down or lost,
+// and the unit, or the units on the machine, do not degrade the status
+// of their applications.
+type AgentOffline struct {
+	// Tag identifies the machine or unit.
+	Tag names.Tag
+
+	// Reason describes why the agent is offline.
+	Reason string
+
+	// AcknowledgedBy identifies the user who acknowledged that the
+	// agent is offline.
+	AcknowledgedBy names.UserTag
+
+	// Acknowledged is when the acknowledgement was recorded. It is
+	// assigned by SetAgentOffline.
+	Acknowledged time.Time
+
+	// Expires is the time after which the acknowledgement is no
+	// longer in effect. It must be set.
+	Expires time.Time
+}
+
+// Expired reports whether the acknowledgement has expired at the given
+// time.
+func (a AgentOffline) Expired(now time.Time) bool {
+	return !now.Before(a.Expires)
+}
+
+type agentOfflineDoc struct {
+	DocID          string `bson:"_id"`
+	ModelUUID      string `bson:"model-uuid"`
+	Tag            string `bson:"tag"`
+	Reason         string `bson:"reason"`
+	AcknowledgedBy string `bson:"acknowledged-by"`
+	Acknowledged   int64  `bson:"acknowledged"`
+	Expires        int64  `bson:"expires"`
+}
+
+// SetAgentOffline records that the agent of the machine or unit with
+// the given tag is intentionally offline, replacing any existing
+// acknowledgement for that agent, and records the acknowledgement in
+// the model's activity feed.
+func (st *State) SetAgentOffline(offline AgentOffline) error {
+	now := st.clock().Now()
+	if err := validateAgentOffline(offline, now); err != nil {
+		return errors.Trace(err)
+	}
+	entityColl, id, err := st.tagToCollectionAndId(offline.Tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	docID := st.docID(offline.Tag.String())
+	doc := &agentOfflineDoc{
+		DocID:          docID,
+		ModelUUID:      st.ModelUUID(),
+		Tag:            offline.Tag.String(),
+		Reason:         offline.Reason,
+		AcknowledgedBy: offline.AcknowledgedBy.String(),
+		Acknowledged:   now.UnixNano(),
+		Expires:        offline.Expires.UnixNano(),
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if _, err := st.FindEntity(offline.Tag); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		ops := []txn.Op{{
+			C:      entityColl,
+			Id:     id,
+			Assert: notDeadDoc,
+		}}
+		coll, closer := st.db().GetCollection(agentsOfflineC)
+		defer closer()
+		n, err := coll.FindId(docID).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if n == 0 {
+			return append(ops, txn.Op{
+				C:      agentsOfflineC,
+				Id:     docID,
+				Assert: txn.DocMissing,
+				Insert: doc,
+			}), nil
+		}
+		return append(ops, txn.Op{
+			C:      agentsOfflineC,
+			Id:     docID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"reason", doc.Reason},
+				{"acknowledged-by", doc.AcknowledgedBy},
+				{"acknowledged", doc.Acknowledged},
+				{"expires", doc.Expires},
+			}}},
+		}), nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot acknowledge %s offline", names.ReadableString(offline.Tag))
+	}
+	probablyAddModelEvent(st, ModelEvent{
+		Kind:     EventAgentOfflineAcknowledged,
+		Actor:    offline.AcknowledgedBy.String(),
+		Entities: []string{offline.Tag.String()},
+		Message: fmt.Sprintf("%s acknowledged offline by %s until %s: %s",
+			names.ReadableString(offline.Tag), offline.AcknowledgedBy.Id(),
+			offline.Expires.UTC().Format(time.RFC3339), offline.Reason),
+	})
+	return nil
+}
+
+func validateAgentOffline(offline AgentOffline, now time.Time) error {
+	switch offline.Tag.(type) {
+	case names.MachineTag, names.UnitTag:
+	default:
+		return errors.NotValidf("offline acknowledgement for %v", offline.Tag)
+	}
+	if offline.Reason == "" {
+		return errors.NotValidf("empty offline reason")
+	}
+	if offline.AcknowledgedBy.Id() == "" {
+		return errors.NotValidf("offline acknowledgement without user")
+	}
+	if offline.Expires.IsZero() {
+		return errors.NotValidf("offline acknowledgement without expiry time")
+	}
+	if offline.Expired(now) {
+		return errors.NotValidf("expiry time %v in the past", offline.Expires)
+	}
+	return nil
+}
+
+// ClearAgentOffline removes any acknowledgement that the agent of the
+// machine or unit with the given tag is offline, and records who
+// cleared it in the model's activity feed. If the agent has not been
+// acknowledged offline, or the acknowledgement has expired, an error
+// satisfying errors.IsNotFound is returned.
+func (st *State) ClearAgentOffline(tag names.Tag, clearedBy names.UserTag) error {
+	if _, err := st.AgentOffline(tag); err != nil {
+		return errors.Trace(err)
+	}
+	ops := []txn.Op{removeAgentOfflineOp(st, tag)}
+	if err := st.db().RunTransaction(ops); err != nil {
+		return errors.Annotatef(err, "cannot clear offline acknowledgement for %s", names.ReadableString(tag))
+	}
+	probablyAddModelEvent(st, ModelEvent{
+		Kind:     EventAgentOfflineCleared,
+		Actor:    clearedBy.String(),
+		Entities: []string{tag.String()},
+		Message: fmt.Sprintf("offline acknowledgement for %s cleared by %s",
+			names.ReadableString(tag), clearedBy.Id()),
+	})
+	return nil
+}
+
+// removeAgentOfflineOp returns an operation that removes any offline
+// acknowledgement for the agent of the entity with the given tag.
+func removeAgentOfflineOp(mb modelBackend, tag names.Tag) txn.Op {
+	return txn.Op{
+		C:      agentsOfflineC,
+		Id:     mb.docID(tag.String()),
+		Remove: true,
+	}
+}
+
+// AgentOffline returns the acknowledgement that the agent of the
+// machine or unit with the given tag is offline. If there is none, or
+// it has expired, an error satisfying errors.IsNotFound is returned.
+func (st *State) AgentOffline(tag names.Tag) (AgentOffline, error) {
+	coll, closer := st.db().GetCollection(agentsOfflineC)
+	defer closer()
+
+	var doc agentOfflineDoc
+	err := coll.FindId(tag.String()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return AgentOffline{}, errors.NotFoundf("offline acknowledgement for %s", names.ReadableString(tag))
+	} else if err != nil {
+		return AgentOffline{}, errors.Annotatef(err, "cannot read offline acknowledgement for %s", names.ReadableString(tag))
+	}
+	offline, err := doc.offline()
+	if err != nil {
+		return AgentOffline{}, errors.Trace(err)
+	}
+	if offline.Expired(st.clock().Now()) {
+		return AgentOffline{}, errors.NotFoundf("offline acknowledgement for %s", names.ReadableString(tag))
+	}
+	return offline, nil
+}
+
+// AllAgentsOffline returns the acknowledgements in effect that agents
+// of the model's machines and units are offline. Those that have
+// expired are not included.
+func (st *State) AllAgentsOffline() ([]AgentOffline, error) {
+	coll, closer := st.db().GetCollection(agentsOfflineC)
+	defer closer()
+
+	var docs []agentOfflineDoc
+	if err := coll.Find(nil).Sort("tag").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read offline acknowledgements")
+	}
+	now := st.clock().Now()
+	var result []AgentOffline
+	for _, doc := range docs {
+		offline, err := doc.offline()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !offline.Expired(now) {
+			result = append(result, offline)
+		}
+	}
+	return result, nil
+}
+
+func (doc agentOfflineDoc) offline() (AgentOffline, error) {
+	tag, err := names.ParseTag(doc.Tag)
+	if err != nil {
+		return AgentOffline{}, errors.Trace(err)
+	}
+	user, err := names.ParseUserTag(doc.AcknowledgedBy)
+	if err != nil {
+		return AgentOffline{}, errors.Trace(err)
+	}
+	return AgentOffline{
+		Tag:            tag,
+		Reason:         doc.Reason,
+		AcknowledgedBy: user,
+		Acknowledged:   time.Unix(0, doc.Acknowledged).UTC(),
+		Expires:        time.Unix(0, doc.Expires).UTC(),
+	}, nil
+}
@@ -0,0 +1,171 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+type AgentOfflineSuite struct {
+	ConnSuite
+	machine *state.Machine
+	admin   names.UserTag
+}
+
+var _ = gc.Suite(&AgentOfflineSuite{})
+
+func (s *AgentOfflineSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.machine = s.Factory.MakeMachine(c, nil)
+	s.admin = names.NewUserTag("admin")
+}
+
+func (s *AgentOfflineSuite) TestNoAgentOffline(c *gc.C) {
+	_, err := s.State.AgentOffline(s.machine.Tag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `offline acknowledgement for machine 0 not found`)
+}
+
+func (s *AgentOfflineSuite) TestSetAgentOffline(c *gc.C) {
+	offline := state.AgentOffline{
+		Tag:            s.machine.Tag(),
+		Reason:         "replacing motherboard",
+		AcknowledgedBy: s.admin,
+		Expires:        s.Clock.Now().Add(48 * time.Hour).UTC(),
+	}
+	err := s.State.SetAgentOffline(offline)
+	c.Assert(err, jc.ErrorIsNil)
+
+	offline.Acknowledged = s.Clock.Now().UTC()
+	got, err := s.State.AgentOffline(s.machine.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, jc.DeepEquals, offline)
+
+	// Acknowledging again replaces the existing acknowledgement.
+	s.Clock.Advance(time.Hour)
+	offline.Reason = "waiting for parts"
+	offline.Acknowledged = s.Clock.Now().UTC()
+	err = s.State.SetAgentOffline(offline)
+	c.Assert(err, jc.ErrorIsNil)
+
+	all, err := s.State.AllAgentsOffline()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, jc.DeepEquals, []state.AgentOffline{offline})
+}
+
+func (s *AgentOfflineSuite) TestSetAgentOfflineRecordsEvent(c *gc.C) {
+	err := s.State.SetAgentOffline(state.AgentOffline{
+		Tag:            s.machine.Tag(),
+		Reason:         "replacing motherboard",
+		AcknowledgedBy: s.admin,
+		Expires:        time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.ClearAgentOffline(s.machine.Tag(), names.NewUserTag("bob"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	events, err := s.State.ModelEvents(state.ModelEventsFilter{
+		Limit:  10,
+		Entity: s.machine.Tag().String(),
+		Kinds: []state.ModelEventKind{
+			state.EventAgentOfflineAcknowledged,
+			state.EventAgentOfflineCleared,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, gc.HasLen, 2)
+	c.Check(events[0].Kind, gc.Equals, state.EventAgentOfflineAcknowledged)
+	c.Check(events[0].Actor, gc.Equals, "user-admin")
+	c.Check(events[0].Message, gc.Equals,
+		"machine 0 acknowledged offline by admin until 2030-01-02T03:04:05Z: replacing motherboard")
+	c.Check(events[1].Kind, gc.Equals, state.EventAgentOfflineCleared)
+	c.Check(events[1].Actor, gc.Equals, "user-bob")
+	c.Check(events[1].Message, gc.Equals, "offline acknowledgement for machine 0 cleared by bob")
+}
+
+func (s *AgentOfflineSuite) TestAgentOfflineExpires(c *gc.C) {
+	err := s.State.SetAgentOffline(state.AgentOffline{
+		Tag:            s.machine.Tag(),
+		Reason:         "replacing motherboard",
+		AcknowledgedBy: s.admin,
+		Expires:        s.Clock.Now().Add(time.Hour),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Clock.Advance(time.Hour)
+	_, err = s.State.AgentOffline(s.machine.Tag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	all, err := s.State.AllAgentsOffline()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 0)
+}
+
+func (s *AgentOfflineSuite) TestSetAgentOfflineInvalid(c *gc.C) {
+	expires := s.Clock.Now().Add(time.Hour)
+	for _, test := range []struct {
+		offline state.AgentOffline
+		expect  string
+	}{{
+		offline: state.AgentOffline{Tag: names.NewApplicationTag("mysql"), Reason: "x", AcknowledgedBy: s.admin, Expires: expires},
+		expect:  `offline acknowledgement for application-mysql not valid`,
+	}, {
+		offline: state.AgentOffline{Tag: s.machine.Tag(), AcknowledgedBy: s.admin, Expires: expires},
+		expect:  `empty offline reason not valid`,
+	}, {
+		offline: state.AgentOffline{Tag: s.machine.Tag(), Reason: "x", Expires: expires},
+		expect:  `offline acknowledgement without user not valid`,
+	}, {
+		offline: state.AgentOffline{Tag: s.machine.Tag(), Reason: "x", AcknowledgedBy: s.admin},
+		expect:  `offline acknowledgement without expiry time not valid`,
+	}, {
+		offline: state.AgentOffline{Tag: s.machine.Tag(), Reason: "x", AcknowledgedBy: s.admin, Expires: s.Clock.Now()},
+		expect:  `expiry time .* in the past not valid`,
+	}} {
+		err := s.State.SetAgentOffline(test.offline)
+		c.Check(err, gc.ErrorMatches, test.expect)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *AgentOfflineSuite) TestSetAgentOfflineMissingEntity(c *gc.C) {
+	err := s.State.SetAgentOffline(state.AgentOffline{
+		Tag:            names.NewUnitTag("missing/0"),
+		Reason:         "replacing motherboard",
+		AcknowledgedBy: s.admin,
+		Expires:        s.Clock.Now().Add(time.Hour),
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot acknowledge unit missing/0 offline: .*`)
+}
+
+func (s *AgentOfflineSuite) TestClearAgentOfflineNotFound(c *gc.C) {
+	err := s.State.ClearAgentOffline(s.machine.Tag(), s.admin)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *AgentOfflineSuite) TestAgentOfflineRemovedWithUnit(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	err := s.State.SetAgentOffline(state.AgentOffline{
+		Tag:            unit.Tag(),
+		Reason:         "replacing motherboard",
+		AcknowledgedBy: s.admin,
+		Expires:        s.Clock.Now().Add(time.Hour),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	all, err := s.State.AllAgentsOffline()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 0)
+}
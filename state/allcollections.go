@@ -147,6 +147,10 @@ func allCollections() collectionSchema {
 			global: true,
 		},
 
+		// This collection holds users' break-glass sessions, during
+		// which they are temporarily elevated to controller superuser.
+		breakGlassSessionsC: {global: true},
+
 		// This collection holds the last time the user connected to the API server.
 		userLastLoginC: {
 			global:    true,
@@ -205,12 +209,131 @@ func allCollections() collectionSchema {
 			rawAccess: true,
 		},
 
+		// This collection holds the health reported periodically by
+		// each controller machine.
+		controllerHealthC: {
+			global:    true,
+			rawAccess: true,
+		},
+
+		// This collection holds the database statistics reported
+		// periodically by each controller machine, and the claim of
+		// the machine compacting its database member.
+		dbMaintenanceC: {
+			global:    true,
+			rawAccess: true,
+		},
+
+		// This collection holds the controller machine assigned to
+		// run each hosted model's workers, and to serve its API
+		// connections if sticky model routing is enabled.
+		modelAssignmentsC: {
+			global: true,
+		},
+
+		// This collection holds the versions of relation interface
+		// schemas implemented by the charms added to the controller.
+		interfaceSchemasC: {
+			global: true,
+			indexes: []mgo.Index{{
+				Key: []string{"interface"},
+			}},
+		},
+
+		// This collection holds the CA certificates trusted by the
+		// controller for each category of external endpoint it
+		// connects to.
+		trustedCACertsC: {
+			global: true,
+			indexes: []mgo.Index{{
+				Key: []string{"category"},
+			}},
+		},
+
 		// This collection holds the last time the model user connected
 		// to the model.
 		modelUserLastConnectionC: {
 			rawAccess: true,
 		},
 
+		// This collection holds the number of API calls each model
+		// user has made to the model, and when the last was made.
+		modelUserActivityC: {
+			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"user"},
+			}},
+		},
+
+		// This collection holds logging configuration applied to
+		// individual agents, on top of the model's logging-config,
+		// until it expires.
+		agentLoggingOverridesC: {},
+
+		// This collection holds snapshots of unit agents' local
+		// uniter state, so that units can resume on re-provisioned
+		// machines.
+		uniterStatesC: {},
+
+		// This collection holds each model's branch: changes to its
+		// applications' configuration that are seen only by the
+		// units tracking the branch until it is committed.
+		generationsC: {},
+
+		// This collection holds the container images that each
+		// machine agent reports it has cached, and any purges of
+		// those images waiting to be carried out.
+		containerImageCachesC: {},
+
+		// This collection holds the resources most recently
+		// reported to be used by each unit's processes.
+		unitUtilizationC: {},
+
+		// This collection holds the health of the model's fan
+		// overlays on each machine, as most recently reported by
+		// the machine's agent.
+		machineFanStatusC: {},
+
+		// This collection holds the machines, applications and
+		// units that are in maintenance mode, and why.
+		maintenanceModesC: {},
+
+		// This collection holds the acknowledgements that agents of
+		// machines and units are intentionally offline, and why.
+		agentsOfflineC: {},
+
+		// This collection holds the newer charm revisions found for
+		// applications with a refresh policy, waiting to be applied
+		// within the model's maintenance windows.
+		pendingCharmUpgradesC: {},
+
+		// This collection holds the recurring windows within which
+		// disruptive automated operations may run in a model.
+		maintenanceWindowsC: {},
+
+		// This collection holds the results of the most recent sweep
+		// for provider resources that juju created but no longer
+		// tracks.
+		orphanedResourcesC: {
+			rawAccess: true,
+		},
+
+		// This collection holds the periodic snapshots of each
+		// model's description, which are diffed to show how the
+		// model has changed.
+		modelSnapshotsC: {
+			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "seq"},
+			}},
+		},
+
+		// This collection holds the limits that controller
+		// administrators set on the resources added to each model.
+		modelQuotasC: {
+			rawAccess: true,
+		},
+
 		// -----------------
 
 		// Local collections
@@ -248,6 +371,14 @@ func allCollections() collectionSchema {
 			}},
 		},
 
+		// This collection records the holders of the leases kept by
+		// raft, so that transactions can assert on them.
+		leaseHoldersC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "namespace"},
+			}},
+		},
+
 		// -----
 
 		// These collections hold information associated with applications.
@@ -421,6 +552,33 @@ func allCollections() collectionSchema {
 			}},
 		},
 
+		// This collection holds the events recorded in each model's
+		// activity feed. Like status history, it is written directly
+		// and pruned by age and size.
+		modelEventsC: {
+			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "seq"},
+			}, {
+				// used for model-specific pruning
+				Key: []string{"model-uuid", "time"},
+			}, {
+				// used for global pruning (after size check)
+				Key: []string{"time"},
+			}},
+		},
+
+		// This collection holds the details of the crash reports that
+		// agents upload; the reports' bundles are held in blob
+		// storage. Reports are removed, oldest first, to keep each
+		// model within its crash report quota.
+		crashReportsC: {
+			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "seq"},
+			}},
+		},
+
 		// This collection holds information about cloud image metadata.
 		cloudimagemetadataC: {
 			global: true,
@@ -457,6 +615,14 @@ func allCollections() collectionSchema {
 		// firewallRulesC holds firewall rules for defined service types.
 		firewallRulesC: {},
 
+		// firewallReportsC holds the firewaller's most recent
+		// comparison of the ingress rules it wants with those the
+		// provider has applied. It is written directly, replacing
+		// the model's previous reports.
+		firewallReportsC: {
+			rawAccess: true,
+		},
+
 		// containerSpecsC holds the CAAS container specifications,
 		// for applications and units.
 		containerSpecsC: {},
@@ -480,32 +646,45 @@ const (
 	actionNotificationsC     = "actionnotifications"
 	actionresultsC           = "actionresults"
 	actionsC                 = "actions"
+	agentLoggingOverridesC   = "agentLoggingOverrides"
+	agentsOfflineC           = "agentsOffline"
 	annotationsC             = "annotations"
 	autocertCacheC           = "autocertCache"
 	assignUnitC              = "assignUnits"
 	bakeryStorageItemsC      = "bakeryStorageItems"
 	blockDevicesC            = "blockdevices"
 	blocksC                  = "blocks"
+	breakGlassSessionsC      = "breakGlassSessions"
 	charmsC                  = "charms"
 	cleanupsC                = "cleanups"
 	cloudimagemetadataC      = "cloudimagemetadata"
 	cloudsC                  = "clouds"
 	cloudCredentialsC        = "cloudCredentials"
+	controllerHealthC        = "controllerHealth"
 	constraintsC             = "constraints"
+	containerImageCachesC    = "containerImageCaches"
 	containerRefsC           = "containerRefs"
 	containerSpecsC          = "containerSpecs"
 	controllersC             = "controllers"
 	controllerUsersC         = "controllerusers"
+	crashReportsC            = "crashReports"
+	dbMaintenanceC           = "dbMaintenance"
 	filesystemAttachmentsC   = "filesystemAttachments"
 	filesystemsC             = "filesystems"
+	generationsC             = "generations"
 	globalClockC             = "globalclock"
 	globalSettingsC          = "globalSettings"
 	guimetadataC             = "guimetadata"
 	guisettingsC             = "guisettings"
 	instanceDataC            = "instanceData"
+	interfaceSchemasC        = "interfaceSchemas"
 	leasesC                  = "leases"
+	leaseHoldersC            = "leaseholders"
+	machineFanStatusC        = "machineFanStatus"
 	machinesC                = "machines"
 	machineRemovalsC         = "machineremovals"
+	maintenanceModesC        = "maintenanceModes"
+	maintenanceWindowsC      = "maintenanceWindows"
 	meterStatusC             = "meterStatus"
 	metricsC                 = "metrics"
 	metricsManagerC          = "metricsmanager"
@@ -514,11 +693,18 @@ const (
 	migrationsC              = "migrations"
 	migrationsMinionSyncC    = "migrations.minionsync"
 	migrationsStatusC        = "migrations.status"
+	modelUserActivityC       = "modelUserActivity"
 	modelUserLastConnectionC = "modelUserLastConnection"
 	modelUsersC              = "modelusers"
+	modelAssignmentsC        = "modelAssignments"
 	modelsC                  = "models"
 	modelEntityRefsC         = "modelEntityRefs"
+	modelEventsC             = "modelEvents"
+	modelQuotasC             = "modelQuotas"
+	modelSnapshotsC          = "modelSnapshots"
 	openedPortsC             = "openedPorts"
+	orphanedResourcesC       = "orphanedResources"
+	pendingCharmUpgradesC    = "pendingCharmUpgrades"
 	payloadsC                = "payloads"
 	permissionsC             = "permissions"
 	providerIDsC             = "providerIDs"
@@ -543,9 +729,12 @@ const (
 	linkLayerDevicesRefsC    = "linklayerdevicesrefs"
 	ipAddressesC             = "ip.addresses"
 	toolsmetadataC           = "toolsmetadata"
+	trustedCACertsC          = "trustedCACerts"
 	txnLogC                  = "txns.log"
 	txnsC                    = "txns"
 	unitsC                   = "units"
+	uniterStatesC            = "uniterStates"
+	unitUtilizationC         = "unitUtilization"
 	upgradeInfoC             = "upgradeInfo"
 	userLastLoginC           = "userLastLogin"
 	usermodelnameC           = "usermodelname"
@@ -562,4 +751,5 @@ const (
 	externalControllersC = "externalControllers"
 	relationNetworksC    = "relationNetworks"
 	firewallRulesC       = "firewallRules"
+	firewallReportsC     = "firewallReports"
 )
@@ -0,0 +1,271 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+const (
+	apiCertificateKey = "apiCertificate"
+	acmeConfigKey     = "acmeConfig"
+)
+
+// APICertificateSource describes where an API certificate held in
+// state came from.
+type APICertificateSource string
+
+const (
+	// APICertificateExternal is the source of a certificate chain
+	// provided by the controller's administrator, typically issued
+	// by an external CA.
+	APICertificateExternal APICertificateSource = "external"
+
+	// APICertificateACME is the source of a certificate issued to
+	// the controller by an ACME CA.
+	APICertificateACME APICertificateSource = "acme"
+)
+
+// APICertificate holds a certificate chain, and its private key, which
+// the controller's API servers present in place of the certificate
+// signed by the controller's own CA.
+type APICertificate struct {
+	// Source records where the certificate came from.
+	Source APICertificateSource
+
+	// CertChain holds the PEM-encoded leaf certificate followed by
+	// any intermediate certificates.
+	CertChain string
+
+	// PrivateKey holds the PEM-encoded private key of the leaf
+	// certificate.
+	PrivateKey string
+
+	// Expiry is the time at which the leaf certificate expires.
+	Expiry time.Time
+
+	// Updated is the time at which the certificate was set.
+	Updated time.Time
+}
+
+type apiCertificateDoc struct {
+	Source     string `bson:"source"`
+	CertChain  string `bson:"cert-chain"`
+	PrivateKey string `bson:"private-key"`
+	Expiry     int64  `bson:"expiry"`
+	Updated    int64  `bson:"updated"`
+}
+
+// ACMEConfig holds the configuration used to obtain API certificates
+// from an ACME CA, using DNS-01 challenges.
+type ACMEConfig struct {
+	// DirectoryURL is the URL of the ACME CA's directory.
+	DirectoryURL string
+
+	// DNSNames holds the names the issued certificate is valid for.
+	DNSNames []string
+
+	// Email is the contact address registered with the ACME account.
+	Email string
+
+	// DNSHook is the command run on the controller to publish and
+	// remove the DNS records that answer DNS-01 challenges.
+	DNSHook string
+
+	// RenewBefore is how long before the certificate expires that
+	// it is renewed.
+	RenewBefore time.Duration
+}
+
+// Validate returns an error if the config is not complete.
+func (cfg ACMEConfig) Validate() error {
+	if cfg.DirectoryURL == "" {
+		return errors.NotValidf("empty directory URL")
+	}
+	if len(cfg.DNSNames) == 0 {
+		return errors.NotValidf("empty DNS names")
+	}
+	if cfg.DNSHook == "" {
+		return errors.NotValidf("empty DNS hook")
+	}
+	if cfg.RenewBefore <= 0 {
+		return errors.NotValidf("renew-before %v", cfg.RenewBefore)
+	}
+	return nil
+}
+
+type acmeConfigDoc struct {
+	DirectoryURL string   `bson:"directory-url"`
+	DNSNames     []string `bson:"dns-names"`
+	Email        string   `bson:"email"`
+	DNSHook      string   `bson:"dns-hook"`
+	RenewBefore  int64    `bson:"renew-before"`
+}
+
+// APICertificate returns the certificate the controller's API servers
+// present, or an error satisfying errors.IsNotFound if they present
+// the certificate signed by the controller's CA.
+func (st *State) APICertificate() (APICertificate, error) {
+	var doc apiCertificateDoc
+	if err := st.readControllerDoc(apiCertificateKey, &doc); err != nil {
+		if errors.IsNotFound(err) {
+			return APICertificate{}, errors.NotFoundf("API certificate")
+		}
+		return APICertificate{}, errors.Annotate(err, "cannot read API certificate")
+	}
+	return APICertificate{
+		Source:     APICertificateSource(doc.Source),
+		CertChain:  doc.CertChain,
+		PrivateKey: doc.PrivateKey,
+		Expiry:     time.Unix(0, doc.Expiry).UTC(),
+		Updated:    time.Unix(0, doc.Updated).UTC(),
+	}, nil
+}
+
+// SetAPICertificate records the certificate the controller's API
+// servers present, replacing any previously set.
+func (st *State) SetAPICertificate(c APICertificate) error {
+	switch c.Source {
+	case APICertificateExternal, APICertificateACME:
+	default:
+		return errors.NotValidf("API certificate source %q", c.Source)
+	}
+	if c.CertChain == "" || c.PrivateKey == "" {
+		return errors.NotValidf("incomplete API certificate")
+	}
+	doc := apiCertificateDoc{
+		Source:     string(c.Source),
+		CertChain:  c.CertChain,
+		PrivateKey: c.PrivateKey,
+		Expiry:     c.Expiry.UnixNano(),
+		Updated:    c.Updated.UnixNano(),
+	}
+	return errors.Annotate(st.setControllerDoc(apiCertificateKey, doc), "cannot set API certificate")
+}
+
+// RemoveAPICertificate removes the certificate set with
+// SetAPICertificate, so that the controller's API servers present the
+// certificate signed by the controller's CA.
+func (st *State) RemoveAPICertificate() error {
+	return errors.Annotate(st.removeControllerDoc(apiCertificateKey), "cannot remove API certificate")
+}
+
+// WatchAPICertificate returns a NotifyWatcher that notifies when the
+// API certificate is set or removed.
+func (st *State) WatchAPICertificate() NotifyWatcher {
+	return newEntityWatcher(st, controllersC, apiCertificateKey)
+}
+
+// ACMEConfig returns the configuration used to obtain API certificates
+// from an ACME CA, or an error satisfying errors.IsNotFound if none
+// has been set.
+func (st *State) ACMEConfig() (ACMEConfig, error) {
+	var doc acmeConfigDoc
+	if err := st.readControllerDoc(acmeConfigKey, &doc); err != nil {
+		if errors.IsNotFound(err) {
+			return ACMEConfig{}, errors.NotFoundf("ACME config")
+		}
+		return ACMEConfig{}, errors.Annotate(err, "cannot read ACME config")
+	}
+	return ACMEConfig{
+		DirectoryURL: doc.DirectoryURL,
+		DNSNames:     doc.DNSNames,
+		Email:        doc.Email,
+		DNSHook:      doc.DNSHook,
+		RenewBefore:  time.Duration(doc.RenewBefore),
+	}, nil
+}
+
+// SetACMEConfig records the configuration used to obtain API
+// certificates from an ACME CA, replacing any previously set.
+func (st *State) SetACMEConfig(cfg ACMEConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	doc := acmeConfigDoc{
+		DirectoryURL: cfg.DirectoryURL,
+		DNSNames:     cfg.DNSNames,
+		Email:        cfg.Email,
+		DNSHook:      cfg.DNSHook,
+		RenewBefore:  int64(cfg.RenewBefore),
+	}
+	return errors.Annotate(st.setControllerDoc(acmeConfigKey, doc), "cannot set ACME config")
+}
+
+// RemoveACMEConfig removes the configuration set with SetACMEConfig,
+// so that no further certificates are obtained from the ACME CA.
+func (st *State) RemoveACMEConfig() error {
+	return errors.Annotate(st.removeControllerDoc(acmeConfigKey), "cannot remove ACME config")
+}
+
+// WatchACMEConfig returns a NotifyWatcher that notifies when the ACME
+// config is set or removed.
+func (st *State) WatchACMEConfig() NotifyWatcher {
+	return newEntityWatcher(st, controllersC, acmeConfigKey)
+}
+
+// readControllerDoc reads the document with the given id from the
+// controllers collection into doc.
+func (st *State) readControllerDoc(id string, doc interface{}) error {
+	controllers, closer := st.db().GetCollection(controllersC)
+	defer closer()
+	err := controllers.FindId(id).One(doc)
+	if err == mgo.ErrNotFound {
+		return errors.NotFoundf("%q", id)
+	}
+	return errors.Trace(err)
+}
+
+// setControllerDoc inserts or replaces the document with the given id
+// in the controllers collection.
+func (st *State) setControllerDoc(id string, doc interface{}) error {
+	buildTxn := func(int) ([]txn.Op, error) {
+		var existing bson.M
+		err := st.readControllerDoc(id, &existing)
+		if errors.IsNotFound(err) {
+			return []txn.Op{{
+				C:      controllersC,
+				Id:     id,
+				Assert: txn.DocMissing,
+				Insert: doc,
+			}}, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      controllersC,
+			Id:     id,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", doc}},
+		}}, nil
+	}
+	return errors.Trace(st.db().Run(buildTxn))
+}
+
+// removeControllerDoc removes the document with the given id from
+// the controllers collection, if it exists.
+func (st *State) removeControllerDoc(id string) error {
+	buildTxn := func(int) ([]txn.Op, error) {
+		var existing bson.M
+		err := st.readControllerDoc(id, &existing)
+		if errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      controllersC,
+			Id:     id,
+			Assert: txn.DocExists,
+			Remove: true,
+		}}, nil
+	}
+	return errors.Trace(st.db().Run(buildTxn))
+}
@@ -0,0 +1,165 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type apiCertificateSuite struct {
+	statetesting.StateSuite
+}
+
+var _ = gc.Suite(&apiCertificateSuite{})
+
+func (s *apiCertificateSuite) TestAPICertificateNotFound(c *gc.C) {
+	_, err := s.State.APICertificate()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, "API certificate not found")
+}
+
+func (s *apiCertificateSuite) TestSetAPICertificate(c *gc.C) {
+	expected := state.APICertificate{
+		Source:     state.APICertificateExternal,
+		CertChain:  "cert-chain",
+		PrivateKey: "private-key",
+		Expiry:     time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC),
+		Updated:    time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC),
+	}
+	err := s.State.SetAPICertificate(expected)
+	c.Assert(err, jc.ErrorIsNil)
+	cert, err := s.State.APICertificate()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cert, jc.DeepEquals, expected)
+
+	expected.Source = state.APICertificateACME
+	expected.CertChain = "other-chain"
+	err = s.State.SetAPICertificate(expected)
+	c.Assert(err, jc.ErrorIsNil)
+	cert, err = s.State.APICertificate()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cert, jc.DeepEquals, expected)
+}
+
+func (s *apiCertificateSuite) TestSetAPICertificateInvalid(c *gc.C) {
+	err := s.State.SetAPICertificate(state.APICertificate{
+		Source:     "bogus",
+		CertChain:  "cert-chain",
+		PrivateKey: "private-key",
+	})
+	c.Assert(err, gc.ErrorMatches, `API certificate source "bogus" not valid`)
+	err = s.State.SetAPICertificate(state.APICertificate{
+		Source:    state.APICertificateExternal,
+		CertChain: "cert-chain",
+	})
+	c.Assert(err, gc.ErrorMatches, "incomplete API certificate not valid")
+}
+
+func (s *apiCertificateSuite) TestRemoveAPICertificate(c *gc.C) {
+	// Removing a missing certificate is not an error.
+	err := s.State.RemoveAPICertificate()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.SetAPICertificate(state.APICertificate{
+		Source:     state.APICertificateExternal,
+		CertChain:  "cert-chain",
+		PrivateKey: "private-key",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveAPICertificate()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.APICertificate()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *apiCertificateSuite) TestWatchAPICertificate(c *gc.C) {
+	w := s.State.WatchAPICertificate()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.State.SetAPICertificate(state.APICertificate{
+		Source:     state.APICertificateExternal,
+		CertChain:  "cert-chain",
+		PrivateKey: "private-key",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.State.RemoveAPICertificate()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *apiCertificateSuite) TestACMEConfig(c *gc.C) {
+	_, err := s.State.ACMEConfig()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	expected := state.ACMEConfig{
+		DirectoryURL: "https://acme.example.com/directory",
+		DNSNames:     []string{"api.example.com"},
+		Email:        "admin@example.com",
+		DNSHook:      "/usr/local/bin/update-dns",
+		RenewBefore:  30 * 24 * time.Hour,
+	}
+	err = s.State.SetACMEConfig(expected)
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err := s.State.ACMEConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg, jc.DeepEquals, expected)
+
+	expected.Email = ""
+	err = s.State.SetACMEConfig(expected)
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err = s.State.ACMEConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg, jc.DeepEquals, expected)
+
+	err = s.State.RemoveACMEConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ACMEConfig()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *apiCertificateSuite) TestSetACMEConfigInvalid(c *gc.C) {
+	err := s.State.SetACMEConfig(state.ACMEConfig{
+		DirectoryURL: "https://acme.example.com/directory",
+		DNSHook:      "/usr/local/bin/update-dns",
+		RenewBefore:  time.Hour,
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, "empty DNS names not valid")
+}
+
+func (s *apiCertificateSuite) TestWatchACMEConfig(c *gc.C) {
+	w := s.State.WatchACMEConfig()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.State.SetACMEConfig(state.ACMEConfig{
+		DirectoryURL: "https://acme.example.com/directory",
+		DNSNames:     []string{"api.example.com"},
+		DNSHook:      "/usr/local/bin/update-dns",
+		RenewBefore:  time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Changes to the API certificate are not reported.
+	err = s.State.SetAPICertificate(state.APICertificate{
+		Source:     state.APICertificateACME,
+		CertChain:  "cert-chain",
+		PrivateKey: "private-key",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
@@ -14,6 +14,7 @@ import (
 	jujutxn "github.com/juju/txn"
 	"github.com/juju/utils"
 	"github.com/juju/utils/series"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/charm.v6"
 	csparams "gopkg.in/juju/charmrepo.v2/csclient/params"
 	"gopkg.in/juju/names.v2"
@@ -22,7 +23,9 @@ import (
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/charmrefresh"
 	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/core/trust"
 	"github.com/juju/juju/status"
 )
 
@@ -52,6 +55,26 @@ type applicationDoc struct {
 	TxnRevno             int64      `bson:"txn-revno"`
 	MetricCredentials    []byte     `bson:"metric-credentials"`
 	PasswordHash         string     `bson:"passwordhash"`
+
+	// RelationBrokenBarrier holds whether the application's units
+	// must all have departed a relation before any of them may run
+	// its relation-broken hook.
+	RelationBrokenBarrier bool `bson:"relation-broken-barrier,omitempty"`
+
+	// LoadBalancerAddress holds the address of the provider load
+	// balancer in front of the application, if there is one.
+	LoadBalancerAddress string `bson:"load-balancer-address,omitempty"`
+
+	// TrustLevel holds the access to cloud credentials granted to
+	// the application's charm. It is empty for applications that
+	// have not been trusted.
+	TrustLevel string `bson:"trust-level,omitempty"`
+
+	// RefreshPolicy determines which newer revisions of the
+	// application's charm, published to its channel, are applied
+	// automatically. It is empty for applications that are pinned to
+	// their current revision.
+	RefreshPolicy string `bson:"refresh-policy,omitempty"`
 }
 
 func newApplication(st *State, doc *applicationDoc) *Application {
@@ -191,6 +214,106 @@ func (op *DestroyApplicationOperation) Done(err error) error {
 	return errors.Annotatef(err, "cannot destroy application %q", op.app)
 }
 
+// ApplicationDestroyPlan describes the entities that would be removed,
+// or left behind, if an application were destroyed.
+type ApplicationDestroyPlan struct {
+	// Units holds the units that would be destroyed: the application's
+	// own units, and the units of other subordinate applications that
+	// are attached to them.
+	Units []names.UnitTag
+
+	// Relations holds the relations that would be removed.
+	Relations []names.RelationTag
+
+	// CrossModelRelations holds those of Relations that are with
+	// applications in other models.
+	CrossModelRelations []names.RelationTag
+
+	// DestroyedMachines holds the machines that would be left running
+	// no units, and so destroyed along with the units. Controller
+	// machines are never destroyed this way.
+	DestroyedMachines []names.MachineTag
+}
+
+// DestroyPlan reports what destroying the application would remove,
+// without changing anything. The plan reflects the model as it is now;
+// it is not guaranteed to match what a later Destroy does if the model
+// changes in the meantime.
+func (a *Application) DestroyPlan() (ApplicationDestroyPlan, error) {
+	var plan ApplicationDestroyPlan
+	units, err := a.AllUnits()
+	if err != nil {
+		return ApplicationDestroyPlan{}, errors.Trace(err)
+	}
+	machineIds := set.NewStrings()
+	for _, u := range units {
+		plan.Units = append(plan.Units, u.UnitTag())
+		for _, subName := range u.SubordinateNames() {
+			if appName, _ := names.UnitApplication(subName); appName != a.Name() {
+				plan.Units = append(plan.Units, names.NewUnitTag(subName))
+			}
+		}
+		if !u.IsPrincipal() {
+			continue
+		}
+		machineId, err := u.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			continue
+		} else if err != nil {
+			return ApplicationDestroyPlan{}, errors.Trace(err)
+		}
+		machineIds.Add(machineId)
+	}
+
+	relations, err := a.Relations()
+	if err != nil {
+		return ApplicationDestroyPlan{}, errors.Trace(err)
+	}
+	for _, rel := range relations {
+		tag := rel.Tag().(names.RelationTag)
+		plan.Relations = append(plan.Relations, tag)
+		crossModel, err := rel.IsCrossModel()
+		if err != nil {
+			return ApplicationDestroyPlan{}, errors.Trace(err)
+		}
+		if crossModel {
+			plan.CrossModelRelations = append(plan.CrossModelRelations, tag)
+		}
+	}
+
+	for _, machineId := range machineIds.SortedValues() {
+		m, err := a.st.Machine(machineId)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return ApplicationDestroyPlan{}, errors.Trace(err)
+		}
+		if m.IsManager() || m.HasVote() {
+			continue
+		}
+		containers, err := m.Containers()
+		if err != nil {
+			return ApplicationDestroyPlan{}, errors.Trace(err)
+		}
+		if len(containers) > 0 || hostsOtherApplications(m, a.Name()) {
+			continue
+		}
+		plan.DestroyedMachines = append(plan.DestroyedMachines, m.MachineTag())
+	}
+	return plan, nil
+}
+
+// hostsOtherApplications reports whether the machine has principal
+// units of any application other than the named one.
+func hostsOtherApplications(m *Machine, appName string) bool {
+	for _, unitName := range m.Principals() {
+		if otherApp, _ := names.UnitApplication(unitName); otherApp != appName {
+			return true
+		}
+	}
+	return false
+}
+
 // destroyOps returns the operations required to destroy the application. If it
 // returns errRefresh, the application should be refreshed and the destruction
 // operations recalculated.
@@ -326,6 +449,13 @@ func (a *Application) removeOps(asserts bson.D) ([]txn.Op, error) {
 	}
 	ops = append(ops, removeOfferOps...)
 
+	// Remove any blocks on the application.
+	blockOps, err := removeEntityBlocksOps(a.st, a.Tag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, blockOps...)
+
 	// Note that appCharmDecRefOps might not catch the final decref
 	// when run in a transaction that decrefs more than once. So we
 	// avoid attempting to do the final cleanup in the ref dec ops and
@@ -350,6 +480,8 @@ func (a *Application) removeOps(asserts bson.D) ([]txn.Op, error) {
 		removeStatusOp(a.st, globalKey),
 		removeModelApplicationRefOp(a.st, name),
 		removeContainerSpecOp(a.Tag()),
+		removeMaintenanceModeOp(a.st, a.Tag()),
+		removePendingCharmUpgradeOp(a.st, name),
 	)
 	return ops, nil
 }
@@ -387,6 +519,146 @@ func (a *Application) setExposed(exposed bool) (err error) {
 	return nil
 }
 
+// RelationBrokenBarrier returns whether the application's units must
+// all have run the relation-departed hooks for a dying or suspended
+// relation before any of them runs its relation-broken hook.
+// See SetRelationBrokenBarrier.
+func (a *Application) RelationBrokenBarrier() bool {
+	return a.doc.RelationBrokenBarrier
+}
+
+// SetRelationBrokenBarrier sets whether the application's units must
+// all have run the relation-departed hooks for a dying or suspended
+// relation before any of them runs its relation-broken hook. Charms
+// that coordinate cluster membership use this to avoid some units
+// tearing down state that others are still departing from.
+func (a *Application) SetRelationBrokenBarrier(enabled bool) error {
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$set", bson.D{{"relation-broken-barrier", enabled}}}},
+	}}
+	if err := a.st.db().RunTransaction(ops); err != nil {
+		return errors.Errorf("cannot set relation-broken barrier for application %q to %v: %v", a, enabled, onAbort(err, errNotAlive))
+	}
+	a.doc.RelationBrokenBarrier = enabled
+	return nil
+}
+
+// LoadBalancerAddress returns the address of the provider load balancer
+// in front of the application, or "" if there is none.
+func (a *Application) LoadBalancerAddress() string {
+	return a.doc.LoadBalancerAddress
+}
+
+// SetLoadBalancerAddress records the address of the provider load
+// balancer in front of the application. An empty address records that
+// there is none. Unlike most application updates, this may be done
+// once the application is dying, so that its load balancer can be
+// removed.
+func (a *Application) SetLoadBalancerAddress(addr string) error {
+	var update bson.D
+	if addr == "" {
+		update = bson.D{{"$unset", bson.D{{"load-balancer-address", nil}}}}
+	} else {
+		update = bson.D{{"$set", bson.D{{"load-balancer-address", addr}}}}
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: txn.DocExists,
+		Update: update,
+	}}
+	if err := a.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("application %q", a)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot set load balancer address of application %q", a)
+	}
+	a.doc.LoadBalancerAddress = addr
+	return nil
+}
+
+// TrustLevel returns the access to cloud credentials granted to the
+// application's charm.
+func (a *Application) TrustLevel() trust.Level {
+	if a.doc.TrustLevel == "" {
+		return trust.None
+	}
+	return trust.Level(a.doc.TrustLevel)
+}
+
+// SetTrustLevel sets the access to cloud credentials granted to the
+// application's charm.
+func (a *Application) SetTrustLevel(level trust.Level) error {
+	if err := level.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	var update bson.D
+	if level == trust.None {
+		update = bson.D{{"$unset", bson.D{{"trust-level", nil}}}}
+	} else {
+		update = bson.D{{"$set", bson.D{{"trust-level", string(level)}}}}
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: isAliveDoc,
+		Update: update,
+	}}
+	if err := a.st.db().RunTransaction(ops); err != nil {
+		return errors.Errorf("cannot set trust level of application %q to %q: %v", a, level, onAbort(err, errNotAlive))
+	}
+	if level == trust.None {
+		a.doc.TrustLevel = ""
+	} else {
+		a.doc.TrustLevel = string(level)
+	}
+	return nil
+}
+
+// RefreshPolicy returns which newer revisions of the application's
+// charm are applied automatically.
+func (a *Application) RefreshPolicy() charmrefresh.Policy {
+	if a.doc.RefreshPolicy == "" {
+		return charmrefresh.None
+	}
+	return charmrefresh.Policy(a.doc.RefreshPolicy)
+}
+
+// SetRefreshPolicy sets which newer revisions of the application's
+// charm are applied automatically. Pinning the application, with
+// charmrefresh.None, discards any pending automatic upgrade.
+func (a *Application) SetRefreshPolicy(policy charmrefresh.Policy) error {
+	if err := policy.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	var update bson.D
+	if policy == charmrefresh.None {
+		update = bson.D{{"$unset", bson.D{{"refresh-policy", nil}}}}
+	} else {
+		update = bson.D{{"$set", bson.D{{"refresh-policy", string(policy)}}}}
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: isAliveDoc,
+		Update: update,
+	}}
+	if policy == charmrefresh.None {
+		ops = append(ops, removePendingCharmUpgradeOp(a.st, a.doc.Name))
+	}
+	if err := a.st.db().RunTransaction(ops); err != nil {
+		return errors.Errorf("cannot set refresh policy of application %q to %q: %v", a, policy, onAbort(err, errNotAlive))
+	}
+	if policy == charmrefresh.None {
+		a.doc.RefreshPolicy = ""
+	} else {
+		a.doc.RefreshPolicy = string(policy)
+	}
+	return nil
+}
+
 // Charm returns the application's charm and whether units should upgrade to that
 // charm even if they are in an error state.
 func (a *Application) Charm() (ch *Charm, force bool, err error) {
@@ -1568,6 +1840,11 @@ func (a *Application) removeUnitOps(u *Unit, asserts bson.D) ([]txn.Op, error) {
 		removeConstraintsOp(u.globalAgentKey()),
 		removeContainerSpecOp(u.Tag()),
 		annotationRemoveOp(a.st, u.globalKey()),
+		removeAgentLoggingOverrideOp(a.st, u.Tag()),
+		removeMaintenanceModeOp(a.st, u.Tag()),
+		removeAgentOfflineOp(a.st, u.Tag()),
+		removeUniterStateOp(a.st, u.globalKey()),
+		removeUnitUtilizationOp(u.doc.DocID),
 		newCleanupOp(cleanupRemovedUnit, u.doc.Name),
 	}
 	ops = append(ops, portsOps...)
@@ -1867,6 +2144,21 @@ func (a *Application) SetConstraints(cons constraints.Value) (err error) {
 	return onAbort(a.st.db().RunTransaction(ops), errNotAlive)
 }
 
+// ConstraintsTrace returns the constraints with which new units of the
+// application would be created, merged from the model and application
+// constraints, along with a trace of where each attribute's value came
+// from.
+func (a *Application) ConstraintsTrace() (constraints.Value, []constraints.AttributeTrace, error) {
+	cons, err := a.Constraints()
+	if err != nil {
+		return constraints.Value{}, nil, errors.Trace(err)
+	}
+	return a.st.traceConstraints(constraints.Layer{
+		Source: constraints.SourceApplication,
+		Value:  cons,
+	})
+}
+
 // EndpointBindings returns the mapping for each endpoint name and the space
 // name it is bound to (or empty if unspecified). When no bindings are stored
 // for the application, defaults are returned.
@@ -1948,6 +2240,68 @@ func (a *Application) StorageConstraints() (map[string]StorageConstraints, error
 	return cons, nil
 }
 
+// UpdateStorageConstraints updates the storage constraints for the
+// named stores of the application. The constraints are used when
+// storage is added for units added to the application later; storage
+// that already exists is not affected. Zero-valued fields in the given
+// constraints leave the existing values unchanged, and the constraints
+// of stores that are not named are left as they are.
+func (a *Application) UpdateStorageConstraints(cons map[string]StorageConstraints) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot update storage constraints for application %q", a)
+	im, err := a.st.IAASModel()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.doc.Life != Alive {
+			return nil, errNotAlive
+		}
+		ch, _, err := a.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		newCons, err := a.StorageConstraints()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if newCons == nil {
+			newCons = make(map[string]StorageConstraints)
+		}
+		for name, update := range cons {
+			existing := newCons[name]
+			if update.Pool != "" {
+				existing.Pool = update.Pool
+			}
+			if update.Size != 0 {
+				existing.Size = update.Size
+			}
+			if update.Count != 0 {
+				existing.Count = update.Count
+			}
+			newCons[name] = existing
+		}
+		if err := addDefaultStorageConstraints(im, newCons, ch.Meta()); err != nil {
+			return nil, errors.Annotate(err, "adding default storage constraints")
+		}
+		if err := validateStorageConstraints(im, newCons, ch.Meta()); err != nil {
+			return nil, errors.Annotate(err, "validating storage constraints")
+		}
+		// The storage constraints document is created along with
+		// the application, and whenever its charm is changed.
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: append(isAliveDoc, bson.DocElem{"charmurl", a.doc.CharmURL}),
+		}, replaceStorageConstraintsOp(a.storageConstraintsKey(), newCons)}, nil
+	}
+	return a.st.db().Run(buildTxn)
+}
+
 // Status returns the status of the application.
 // Only unit leaders are allowed to set the status of the application.
 // If no status is recorded, then there are no unit leaders and the
@@ -1991,17 +2345,22 @@ func (a *Application) Status() (status.StatusInfo, error) {
 
 // SetStatus sets the status for the application.
 func (a *Application) SetStatus(statusInfo status.StatusInfo) error {
+	return setEntityStatus(a, statusInfo)
+}
+
+// statusUpdate is part of the statusUpdater interface.
+func (a *Application) statusUpdate(statusInfo status.StatusInfo) (Database, setStatusParams, error) {
 	if !status.ValidWorkloadStatus(statusInfo.Status) {
-		return errors.Errorf("cannot set invalid status %q", statusInfo.Status)
+		return nil, setStatusParams{}, errors.Errorf("cannot set invalid status %q", statusInfo.Status)
 	}
-	return setStatus(a.st.db(), setStatusParams{
+	return a.st.db(), setStatusParams{
 		badge:     "application",
 		globalKey: a.globalKey(),
 		status:    statusInfo.Status,
 		message:   statusInfo.Message,
 		rawData:   statusInfo.Data,
 		updated:   timeOrNow(statusInfo.Since, a.st.clock()),
-	})
+	}, nil
 }
 
 // StatusHistory returns a slice of at most filter.Size StatusInfo items
@@ -2058,6 +2417,7 @@ func deriveApplicationStatus(statuses []status.StatusInfo) status.StatusInfo {
 var statusServerities = map[status.Status]int{
 	status.Error:       100,
 	status.Blocked:     90,
+	status.Unhealthy:   85,
 	status.Waiting:     80,
 	status.Maintenance: 70,
 	status.Terminated:  60,
@@ -15,11 +15,14 @@ import (
 	"github.com/juju/utils/set"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/charmrefresh"
 	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/trust"
 	"github.com/juju/juju/resource/resourcetesting"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/testing"
@@ -1783,6 +1786,85 @@ func (s *ApplicationSuite) TestServiceExposed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, notAliveErr)
 }
 
+func (s *ApplicationSuite) TestLoadBalancerAddress(c *gc.C) {
+	c.Assert(s.mysql.LoadBalancerAddress(), gc.Equals, "")
+
+	err := s.mysql.SetLoadBalancerAddress("mysql-lb.example.com")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.LoadBalancerAddress(), gc.Equals, "mysql-lb.example.com")
+	app, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.LoadBalancerAddress(), gc.Equals, "mysql-lb.example.com")
+
+	// The address can be cleared once the application is dying.
+	_, err = s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.SetLoadBalancerAddress("")
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.LoadBalancerAddress(), gc.Equals, "")
+}
+
+func (s *ApplicationSuite) TestTrustLevel(c *gc.C) {
+	c.Assert(s.mysql.TrustLevel(), gc.Equals, trust.None)
+
+	err := s.mysql.SetTrustLevel(trust.ReadOnly)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.TrustLevel(), gc.Equals, trust.ReadOnly)
+	app, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.TrustLevel(), gc.Equals, trust.ReadOnly)
+
+	err = app.SetTrustLevel(trust.None)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.TrustLevel(), gc.Equals, trust.None)
+
+	err = s.mysql.SetTrustLevel("root")
+	c.Assert(err, gc.ErrorMatches, `trust level "root" not valid`)
+}
+
+func (s *ApplicationSuite) TestRefreshPolicy(c *gc.C) {
+	c.Assert(s.mysql.RefreshPolicy(), gc.Equals, charmrefresh.None)
+
+	err := s.mysql.SetRefreshPolicy(charmrefresh.PatchOnly)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.RefreshPolicy(), gc.Equals, charmrefresh.PatchOnly)
+	app, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.RefreshPolicy(), gc.Equals, charmrefresh.PatchOnly)
+
+	err = s.mysql.SetRefreshPolicy("sometimes")
+	c.Assert(err, gc.ErrorMatches, `refresh policy "sometimes" not valid`)
+}
+
+func (s *ApplicationSuite) TestSetRefreshPolicyNoneRemovesPendingUpgrade(c *gc.C) {
+	err := s.mysql.SetRefreshPolicy(charmrefresh.Any)
+	c.Assert(err, jc.ErrorIsNil)
+	curl := s.charm.URL().WithRevision(s.charm.URL().Revision + 1)
+	err = s.State.SetPendingCharmUpgrade("mysql", curl)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.SetRefreshPolicy(charmrefresh.None)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.RefreshPolicy(), gc.Equals, charmrefresh.None)
+	_, err = s.State.PendingCharmUpgrade("mysql")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ApplicationSuite) TestSetTrustLevelNotAlive(c *gc.C) {
+	_, err := s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetTrustLevel(trust.Full)
+	c.Assert(err, gc.ErrorMatches, `cannot set trust level of application "mysql" to "full": not found or not alive`)
+}
+
 func (s *ApplicationSuite) TestAddUnit(c *gc.C) {
 	// Check that principal units can be added on their own.
 	unitZero, err := s.mysql.AddUnit(state.AddUnitParams{})
@@ -2002,6 +2084,69 @@ func (s *ApplicationSuite) TestDestroyWithRemovableRelation(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *ApplicationSuite) TestDestroyPlan(c *gc.C) {
+	// mysql/0 is alone on its machine; mysql/1 shares with wordpress/0.
+	m0 := s.Factory.MakeMachine(c, nil)
+	m1 := s.Factory.MakeMachine(c, nil)
+	mysql0 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: s.mysql, Machine: m0})
+	mysql1 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: s.mysql, Machine: m1})
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: wordpress, Machine: m1})
+
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	wordpressRel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.AddTestingApplication(c, "logging", s.AddTestingCharm(c, "logging"))
+	eps, err = s.State.InferEndpoints("mysql", "logging")
+	c.Assert(err, jc.ErrorIsNil)
+	loggingRel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := loggingRel.Unit(mysql0)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	remoteApp, err := s.State.AddRemoteApplication(state.AddRemoteApplicationParams{
+		Name:        "remote-wordpress",
+		SourceModel: names.NewModelTag("source-model"),
+		Endpoints: []charm.Relation{{
+			Interface: "mysql",
+			Name:      "db",
+			Role:      charm.RoleRequirer,
+			Scope:     charm.ScopeGlobal,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	remoteEP, err := remoteApp.Endpoint("db")
+	c.Assert(err, jc.ErrorIsNil)
+	mysqlEP, err := s.mysql.Endpoint("server")
+	c.Assert(err, jc.ErrorIsNil)
+	remoteRel, err := s.State.AddRelation(remoteEP, mysqlEP)
+	c.Assert(err, jc.ErrorIsNil)
+
+	plan, err := s.mysql.DestroyPlan()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(plan.Units, jc.SameContents, []names.UnitTag{
+		mysql0.UnitTag(), names.NewUnitTag("logging/0"), mysql1.UnitTag(),
+	})
+	c.Assert(plan.Relations, jc.SameContents, []names.RelationTag{
+		wordpressRel.Tag().(names.RelationTag),
+		loggingRel.Tag().(names.RelationTag),
+		remoteRel.Tag().(names.RelationTag),
+	})
+	c.Assert(plan.CrossModelRelations, jc.DeepEquals, []names.RelationTag{
+		remoteRel.Tag().(names.RelationTag),
+	})
+	c.Assert(plan.DestroyedMachines, jc.DeepEquals, []names.MachineTag{m0.MachineTag()})
+
+	// Nothing was changed.
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.Life(), gc.Equals, state.Alive)
+}
+
 func (s *ApplicationSuite) TestDestroyWithReferencedRelation(c *gc.C) {
 	s.assertDestroyWithReferencedRelation(c, true)
 }
@@ -2147,6 +2292,73 @@ func (s *ApplicationSuite) TestApplicationCleanupRemovesStorageConstraints(c *gc
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *ApplicationSuite) TestUpdateStorageConstraints(c *gc.C) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	storage := map[string]state.StorageConstraints{
+		"data":    makeStorageCons("loop", 1024, 1),
+		"allecto": makeStorageCons("loop", 1024, 1),
+	}
+	app := s.AddTestingApplicationWithStorage(c, "storage-block", ch, storage)
+
+	err := app.UpdateStorageConstraints(map[string]state.StorageConstraints{
+		"data":    {Size: 2048},
+		"allecto": {Count: 3},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	cons, err := app.StorageConstraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cons, jc.DeepEquals, map[string]state.StorageConstraints{
+		"data":    makeStorageCons("loop", 2048, 1),
+		"allecto": makeStorageCons("loop", 1024, 3),
+	})
+
+	// Storage for units added later is based on the new constraints.
+	u, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	attachments, err := s.IAASModel.UnitStorageAttachments(u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 4)
+}
+
+func (s *ApplicationSuite) TestUpdateStorageConstraintsInvalid(c *gc.C) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	storage := map[string]state.StorageConstraints{
+		"data": makeStorageCons("loop", 1024, 1),
+	}
+	app := s.AddTestingApplicationWithStorage(c, "storage-block", ch, storage)
+
+	err := app.UpdateStorageConstraints(map[string]state.StorageConstraints{
+		"data": {Count: 2},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot update storage constraints for application "storage-block": validating storage constraints: charm "storage-block" store "data": storage is singular, 2 specified`)
+
+	err = app.UpdateStorageConstraints(map[string]state.StorageConstraints{
+		"cache": {Size: 1024},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot update storage constraints for application "storage-block": validating storage constraints: charm "storage-block" has no store called "cache"`)
+
+	cons, err := app.StorageConstraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cons["data"], jc.DeepEquals, makeStorageCons("loop", 1024, 1))
+}
+
+func (s *ApplicationSuite) TestUpdateStorageConstraintsDyingApplication(c *gc.C) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	storage := map[string]state.StorageConstraints{
+		"data": makeStorageCons("loop", 1024, 1),
+	}
+	app := s.AddTestingApplicationWithStorage(c, "storage-block", ch, storage)
+	_, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = app.UpdateStorageConstraints(map[string]state.StorageConstraints{
+		"data": {Size: 2048},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot update storage constraints for application "storage-block": not found or not alive`)
+}
+
 func (s *ApplicationSuite) TestRemoveQueuesLocalCharmCleanup(c *gc.C) {
 	s.assertNoCleanup(c)
 
@@ -2329,6 +2541,70 @@ func (s *ApplicationSuite) TestSubordinateConstraints(c *gc.C) {
 	c.Assert(err, gc.Equals, state.ErrSubordinateConstraints)
 }
 
+func (s *ApplicationSuite) TestConstraintsTrace(c *gc.C) {
+	err := s.State.SetModelConstraints(constraints.MustParse("mem=4G cores=1"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetConstraints(constraints.MustParse("cores=4"))
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Changes made after the unit was created apply to the
+	// application, but not the unit.
+	err = s.mysql.SetConstraints(constraints.MustParse("instance-type=big"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	cons, trace, err := s.mysql.ConstraintsTrace()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cons, jc.DeepEquals, constraints.MustParse("cores=1 instance-type=big"))
+	c.Assert(trace, jc.DeepEquals, []constraints.AttributeTrace{{
+		Attribute: "cores",
+		Value:     "1",
+		Source:    constraints.SourceModel,
+	}, {
+		Attribute: "instance-type",
+		Value:     "big",
+		Source:    constraints.SourceApplication,
+	}, {
+		Attribute: "mem",
+		Overridden: []constraints.OverriddenValue{{
+			Source: constraints.SourceModel,
+			Value:  "4096M",
+			Reason: "conflicts with application constraints",
+		}},
+	}})
+
+	cons, trace, err = unit.ConstraintsTrace()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cons, jc.DeepEquals, constraints.MustParse("mem=4G cores=4"))
+	c.Assert(trace, jc.DeepEquals, []constraints.AttributeTrace{{
+		Attribute: "cores",
+		Value:     "4",
+		Source:    constraints.SourceUnit,
+		Overridden: []constraints.OverriddenValue{{
+			Source: constraints.SourceModel,
+			Value:  "1",
+			Reason: "overridden by unit constraints",
+		}},
+	}, {
+		Attribute: "instance-type",
+		Overridden: []constraints.OverriddenValue{{
+			Source: constraints.SourceApplication,
+			Value:  "big",
+			Reason: "not in unit constraints",
+		}},
+	}, {
+		Attribute: "mem",
+		Value:     "4096M",
+		Source:    constraints.SourceUnit,
+		Overridden: []constraints.OverriddenValue{{
+			Source: constraints.SourceModel,
+			Value:  "4096M",
+			Reason: "conflicts with application constraints",
+		}},
+	}})
+}
+
 func (s *ApplicationSuite) TestWatchUnitsBulkEvents(c *gc.C) {
 	// Alive unit...
 	alive, err := s.mysql.AddUnit(state.AddUnitParams{})
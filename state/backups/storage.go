@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/storage"
 )
 
 // backupIDTimstamp is used to format the timestamp from a backup
@@ -275,7 +276,7 @@ func (b *storageDBWrapper) runTransaction(ops []txn.Op) error {
 
 // blobStorage returns a ManagedStorage matching the env storage and the blobDB.
 func (b *storageDBWrapper) blobStorage(blobDB string) blobstore.ManagedStorage {
-	dataStore := blobstore.NewGridFS(blobDB, blobDB, b.session)
+	dataStore := storage.NewResourceStorage(blobDB, b.session)
 	return blobstore.NewManagedStorage(b.db, dataStore)
 }
 
@@ -10,21 +10,27 @@ import (
 
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/state/binarystorage"
+	"github.com/juju/juju/state/storage"
 )
 
-var binarystorageNew = binarystorage.New
+var (
+	binarystorageNew       = binarystorage.New
+	binarystorageNewShared = binarystorage.NewShared
+)
 
 // ToolsStorage returns a new binarystorage.StorageCloser that stores tools
-// metadata in the "juju" database "toolsmetadata" collection.
+// metadata in the "juju" database "toolsmetadata" collection. The tools
+// themselves are shared by all models, so that identical agent binaries
+// are stored once.
 func (st *State) ToolsStorage() (binarystorage.StorageCloser, error) {
-	modelStorage := newBinaryStorageCloser(st.database, toolsmetadataC, st.ModelUUID())
+	modelStorage := newToolsStorageCloser(st.database, st.ModelUUID(), st.ControllerModelUUID())
 	if st.IsController() {
 		return modelStorage, nil
 	}
 	// This is a hosted model. Hosted models have their own tools
 	// catalogue, which we combine with the controller's.
-	controllerStorage := newBinaryStorageCloser(
-		st.database, toolsmetadataC, st.ControllerModelUUID(),
+	controllerStorage := newToolsStorageCloser(
+		st.database, st.ControllerModelUUID(), st.ControllerModelUUID(),
 	)
 	storage, err := binarystorage.NewLayeredStorage(modelStorage, controllerStorage)
 	if err != nil {
@@ -57,11 +63,28 @@ func newBinaryStorageCloser(db Database, collectionName, uuid string) binarystor
 	return &storageCloser{storage, closer}
 }
 
+func newToolsStorageCloser(db Database, uuid, sharedBucket string) binarystorage.StorageCloser {
+	db, closer1 := db.CopyForModel(uuid)
+	metadataCollection, closer2 := db.GetCollection(toolsmetadataC)
+	txnRunner, closer3 := db.TransactionRunner()
+	closer := func() {
+		closer3()
+		closer2()
+		closer1()
+	}
+	managedStorage := newManagedStorage(metadataCollection)
+	storage := binarystorageNewShared(uuid, sharedBucket, managedStorage, metadataCollection, txnRunner)
+	return &storageCloser{storage, closer}
+}
+
 func newBinaryStorage(uuid string, metadataCollection mongo.Collection, txnRunner jujutxn.Runner) binarystorage.Storage {
+	return binarystorageNew(uuid, newManagedStorage(metadataCollection), metadataCollection, txnRunner)
+}
+
+func newManagedStorage(metadataCollection mongo.Collection) blobstore.ManagedStorage {
 	db := metadataCollection.Writeable().Underlying().Database
-	rs := blobstore.NewGridFS(blobstoreDB, blobstoreDB, db.Session)
-	managedStorage := blobstore.NewManagedStorage(db, rs)
-	return binarystorageNew(uuid, managedStorage, metadataCollection, txnRunner)
+	rs := storage.NewResourceStorage(blobstoreDB, db.Session)
+	return blobstore.NewManagedStorage(db, rs)
 }
 
 type storageCloser struct {
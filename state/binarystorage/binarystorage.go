@@ -22,6 +22,7 @@ var logger = loggo.GetLogger("juju.state.binarystorage")
 
 type binaryStorage struct {
 	modelUUID          string
+	sharedBucket       string
 	managedStorage     blobstore.ManagedStorage
 	metadataCollection mongo.Collection
 	txnRunner          jujutxn.Runner
@@ -46,18 +47,86 @@ func New(
 	}
 }
 
+// NewShared constructs a new Storage like New, except that binary files
+// are stored in the given bucket, shared with other models, by content.
+// Identical binary files added to any Storage that shares the bucket are
+// stored only once; a binary file is removed when no metadata in the
+// collection refers to it any longer. Binary files whose SHA256 hash is
+// not known are stored separately for each model, as with New.
+func NewShared(
+	modelUUID string,
+	sharedBucket string,
+	managedStorage blobstore.ManagedStorage,
+	metadataCollection mongo.Collection,
+	runner jujutxn.Runner,
+) Storage {
+	return &binaryStorage{
+		modelUUID:          modelUUID,
+		sharedBucket:       sharedBucket,
+		managedStorage:     managedStorage,
+		metadataCollection: metadataCollection,
+		txnRunner:          runner,
+	}
+}
+
+// location returns the bucket and path at which the binary file with
+// the given metadata is stored.
+func (s *binaryStorage) location(metadata Metadata) (bucket, path string) {
+	if s.sharedBucket != "" && metadata.SHA256 != "" {
+		return s.sharedBucket, fmt.Sprintf("agent-binaries/%s", metadata.SHA256)
+	}
+	return s.modelUUID, fmt.Sprintf("tools/%s-%s", metadata.Version, metadata.SHA256)
+}
+
+// docBucket returns the bucket in which the binary file described by
+// the given metadata document is stored.
+func (s *binaryStorage) docBucket(doc metadataDoc) string {
+	if doc.Bucket != "" {
+		return doc.Bucket
+	}
+	return s.modelUUID
+}
+
+// bucketAssert returns a txn assertion that a metadata document records
+// the given bucket, which is empty for the model's own bucket.
+func bucketAssert(bucket string) bson.DocElem {
+	if bucket == "" {
+		return bson.DocElem{"bucket", bson.D{{"$exists", false}}}
+	}
+	return bson.DocElem{"bucket", bucket}
+}
+
+// removeBlob removes the binary file at the given location. Binary files
+// in the shared bucket are only removed if no metadata refers to them.
+func (s *binaryStorage) removeBlob(bucket, path string) error {
+	if s.sharedBucket != "" && bucket == s.sharedBucket {
+		// The collection is filtered by model, so look at the
+		// underlying collection to find the metadata of all models.
+		coll := s.metadataCollection.Writeable().Underlying()
+		n, err := coll.Find(bson.D{{"bucket", bucket}, {"path", path}}).Count()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if n > 0 {
+			logger.Debugf("binary blob %q still in use", path)
+			return nil
+		}
+	}
+	return s.managedStorage.RemoveForBucket(bucket, path)
+}
+
 // Add implements Storage.Add.
 func (s *binaryStorage) Add(r io.Reader, metadata Metadata) (resultErr error) {
 	// Add the binary file to storage.
-	path := fmt.Sprintf("tools/%s-%s", metadata.Version, metadata.SHA256)
-	if err := s.managedStorage.PutForBucket(s.modelUUID, path, r, metadata.Size); err != nil {
+	bucket, path := s.location(metadata)
+	if err := s.managedStorage.PutForBucket(bucket, path, r, metadata.Size); err != nil {
 		return errors.Annotate(err, "cannot store binary file")
 	}
 	defer func() {
 		if resultErr == nil {
 			return
 		}
-		err := s.managedStorage.RemoveForBucket(s.modelUUID, path)
+		err := s.removeBlob(bucket, path)
 		if err != nil {
 			logger.Errorf("failed to remove binary blob: %v", err)
 		}
@@ -70,10 +139,13 @@ func (s *binaryStorage) Add(r io.Reader, metadata Metadata) (resultErr error) {
 		SHA256:  metadata.SHA256,
 		Path:    path,
 	}
+	if bucket != s.modelUUID {
+		newDoc.Bucket = bucket
+	}
 
-	// Add or replace metadata. If replacing, record the existing path so we
-	// can remove it later.
-	var oldPath string
+	// Add or replace metadata. If replacing, record the existing location
+	// so we can remove it later.
+	var oldBucket, oldPath string
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		op := txn.Op{
 			C:  s.metadataCollection.Name(),
@@ -92,16 +164,20 @@ func (s *binaryStorage) Add(r io.Reader, metadata Metadata) (resultErr error) {
 			if err != nil {
 				return nil, err
 			}
-			oldPath = oldDoc.Path
-			op.Assert = bson.D{{"path", oldPath}}
-			if oldPath != path {
-				op.Update = bson.D{{
-					"$set", bson.D{
-						{"size", metadata.Size},
-						{"sha256", metadata.SHA256},
-						{"path", path},
-					},
-				}}
+			oldBucket, oldPath = s.docBucket(oldDoc), oldDoc.Path
+			op.Assert = bson.D{{"path", oldPath}, bucketAssert(oldDoc.Bucket)}
+			if oldBucket != bucket || oldPath != path {
+				set := bson.D{
+					{"size", metadata.Size},
+					{"sha256", metadata.SHA256},
+					{"path", path},
+				}
+				if newDoc.Bucket != "" {
+					set = append(set, bson.DocElem{"bucket", newDoc.Bucket})
+					op.Update = bson.D{{"$set", set}}
+				} else {
+					op.Update = bson.D{{"$set", set}, {"$unset", bson.D{{"bucket", nil}}}}
+				}
 			}
 		}
 		return []txn.Op{op}, nil
@@ -111,9 +187,9 @@ func (s *binaryStorage) Add(r io.Reader, metadata Metadata) (resultErr error) {
 		return errors.Annotate(err, "cannot store binary metadata")
 	}
 
-	if oldPath != "" && oldPath != path {
+	if oldPath != "" && (oldBucket != bucket || oldPath != path) {
 		// Attempt to remove the old path. Failure is non-fatal.
-		err := s.managedStorage.RemoveForBucket(s.modelUUID, oldPath)
+		err := s.removeBlob(oldBucket, oldPath)
 		if err != nil {
 			logger.Errorf("failed to remove old binary blob: %v", err)
 		} else {
@@ -128,7 +204,7 @@ func (s *binaryStorage) Open(version string) (Metadata, io.ReadCloser, error) {
 	if err != nil {
 		return Metadata{}, nil, err
 	}
-	r, _, err := s.managedStorage.GetForBucket(s.modelUUID, metadataDoc.Path)
+	r, _, err := s.managedStorage.GetForBucket(s.docBucket(metadataDoc), metadataDoc.Path)
 	if err != nil {
 		return Metadata{}, nil, err
 	}
@@ -168,12 +244,49 @@ func (s *binaryStorage) AllMetadata() ([]Metadata, error) {
 	return list, nil
 }
 
+// Remove implements Storage.Remove.
+func (s *binaryStorage) Remove(version string) error {
+	var doc metadataDoc
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		var err error
+		doc, err = s.findMetadata(version)
+		if errors.IsNotFound(err) && attempt > 0 {
+			// Someone else removed it.
+			doc = metadataDoc{}
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, err
+		}
+		return []txn.Op{{
+			C:      s.metadataCollection.Name(),
+			Id:     doc.Id,
+			Assert: bson.D{{"path", doc.Path}, bucketAssert(doc.Bucket)},
+			Remove: true,
+		}}, nil
+	}
+	if err := s.txnRunner.Run(buildTxn); err != nil {
+		return errors.Annotate(err, "cannot remove binary metadata")
+	}
+	if doc.Path == "" {
+		return nil
+	}
+	// Attempt to remove the binary file. Failure is non-fatal.
+	if err := s.removeBlob(s.docBucket(doc), doc.Path); err != nil {
+		logger.Errorf("failed to remove binary blob: %v", err)
+	}
+	return nil
+}
+
 type metadataDoc struct {
 	Id      string `bson:"_id"`
 	Version string `bson:"version"`
 	Size    int64  `bson:"size"`
 	SHA256  string `bson:"sha256,omitempty"`
 	Path    string `bson:"path"`
+
+	// Bucket holds the bucket in which the binary file is stored,
+	// if it is not stored in the model's own bucket.
+	Bucket string `bson:"bucket,omitempty"`
 }
 
 func (s *binaryStorage) findMetadata(version string) (metadataDoc, error) {
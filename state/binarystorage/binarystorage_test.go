@@ -330,6 +330,98 @@ func (s *binaryStorageSuite) TestAddExcessiveContention(c *gc.C) {
 	s.assertMetadataAndContent(c, metadata[3], "3")
 }
 
+func (s *binaryStorageSuite) TestRemove(c *gc.C) {
+	metadata := binarystorage.Metadata{Version: current, Size: 6, SHA256: "hash"}
+	err := s.storage.Add(strings.NewReader("xyzzzz"), metadata)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.storage.Remove(current)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.storage.Metadata(current)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, _, err = s.managedStorage.GetForBucket("my-uuid", fmt.Sprintf("tools/%s-hash", current))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *binaryStorageSuite) TestRemoveNotFound(c *gc.C) {
+	err := s.storage.Remove(current)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *binaryStorageSuite) TestSharedAddStoresOnce(c *gc.C) {
+	storage0 := binarystorage.NewShared("uuid-0", "shared-uuid", s.managedStorage, s.metadataCollection, s.txnRunner)
+	storage1 := binarystorage.NewShared("uuid-1", "shared-uuid", s.managedStorage, s.metadataCollection, s.txnRunner)
+	metadata0 := binarystorage.Metadata{Version: "2.0.1-trusty-amd64", Size: 6, SHA256: "hash"}
+	metadata1 := binarystorage.Metadata{Version: "2.0.2-trusty-amd64", Size: 6, SHA256: "hash"}
+
+	err := storage0.Add(strings.NewReader("xyzzzz"), metadata0)
+	c.Assert(err, jc.ErrorIsNil)
+	err = storage1.Add(strings.NewReader("xyzzzz"), metadata1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The binary is stored once, in the shared bucket.
+	assertBlob := func(exists bool) {
+		r, _, err := s.managedStorage.GetForBucket("shared-uuid", "agent-binaries/hash")
+		if !exists {
+			c.Assert(err, jc.Satisfies, errors.IsNotFound)
+			return
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		r.Close()
+	}
+	assertBlob(true)
+	for _, uuid := range []string{"uuid-0", "uuid-1"} {
+		_, _, err := s.managedStorage.GetForBucket(uuid, "tools/2.0.1-trusty-amd64-hash")
+		c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	}
+
+	_, r, err := storage1.Open(metadata1.Version)
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadAll(r)
+	r.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "xyzzzz")
+
+	// The binary is only removed once nothing refers to it.
+	err = storage0.Remove(metadata0.Version)
+	c.Assert(err, jc.ErrorIsNil)
+	assertBlob(true)
+	err = storage1.Remove(metadata1.Version)
+	c.Assert(err, jc.ErrorIsNil)
+	assertBlob(false)
+}
+
+func (s *binaryStorageSuite) TestSharedAddWithoutHash(c *gc.C) {
+	storage := binarystorage.NewShared("my-uuid", "shared-uuid", s.managedStorage, s.metadataCollection, s.txnRunner)
+	metadata := binarystorage.Metadata{Version: current, Size: 6}
+	err := storage.Add(strings.NewReader("xyzzzz"), metadata)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Without a hash, the binary cannot be shared.
+	r, _, err := s.managedStorage.GetForBucket("my-uuid", fmt.Sprintf("tools/%s-", current))
+	c.Assert(err, jc.ErrorIsNil)
+	r.Close()
+}
+
+func (s *binaryStorageSuite) TestSharedAddReplacesLegacy(c *gc.C) {
+	s.addMetadataDoc(c, current, 3, "hash(abc)", "path")
+	err := s.managedStorage.PutForBucket("my-uuid", "path", strings.NewReader("abc"), 3)
+	c.Assert(err, jc.ErrorIsNil)
+
+	storage := binarystorage.NewShared("my-uuid", "shared-uuid", s.managedStorage, s.metadataCollection, s.txnRunner)
+	metadata := binarystorage.Metadata{Version: current, Size: 6, SHA256: "hash(xyzzzz)"}
+	err = storage.Add(strings.NewReader("xyzzzz"), metadata)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The old binary, stored in the model's bucket, is removed.
+	_, _, err = s.managedStorage.GetForBucket("my-uuid", "path")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	r, _, err := s.managedStorage.GetForBucket("shared-uuid", "agent-binaries/hash(xyzzzz)")
+	c.Assert(err, jc.ErrorIsNil)
+	r.Close()
+}
+
 func (s *binaryStorageSuite) addMetadataDoc(c *gc.C, v string, size int64, hash, path string) {
 	doc := struct {
 		Id      string `bson:"_id"`
@@ -31,6 +31,11 @@ type Storage interface {
 	// Metadata returns the Metadata for the specified version if it exists,
 	// else an error satisfying errors.IsNotFound.
 	Metadata(version string) (Metadata, error)
+
+	// Remove removes the metadata for the specified version, and the
+	// binary file if nothing else refers to it. If there is no metadata
+	// for the version, an error satisfying errors.IsNotFound is returned.
+	Remove(version string) error
 }
 
 // StorageCloser extends the Storage interface with a Close method.
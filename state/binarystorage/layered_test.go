@@ -64,6 +64,15 @@ func (s *layeredStorageSuite) TestAdd(c *gc.C) {
 	s.stores[1].CheckNoCalls(c)
 }
 
+func (s *layeredStorageSuite) TestRemove(c *gc.C) {
+	expectedErr := errors.New("wut")
+	s.stores[0].SetErrors(expectedErr)
+	err := s.store.Remove("4.0")
+	c.Assert(err, gc.Equals, expectedErr)
+	s.stores[0].CheckCalls(c, []testing.StubCall{{"Remove", []interface{}{"4.0"}}})
+	s.stores[1].CheckNoCalls(c)
+}
+
 func (s *layeredStorageSuite) TestAllMetadata(c *gc.C) {
 	all, err := s.store.AllMetadata()
 	c.Assert(err, jc.ErrorIsNil)
@@ -166,6 +175,11 @@ func (s *mockStorage) Add(r io.Reader, m binarystorage.Metadata) error {
 	return s.NextErr()
 }
 
+func (s *mockStorage) Remove(version string) error {
+	s.MethodCall(s, "Remove", version)
+	return s.NextErr()
+}
+
 func (s *mockStorage) AllMetadata() ([]binarystorage.Metadata, error) {
 	s.MethodCall(s, "AllMetadata")
 	return s.metadata, s.NextErr()
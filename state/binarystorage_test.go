@@ -102,11 +102,11 @@ func (s *binaryStorageSuite) TestToolsStorage(c *gc.C) {
 }
 
 func (s *binaryStorageSuite) TestToolsStorageParamsControllerModel(c *gc.C) {
-	s.testStorageParams(c, "toolsmetadata", []string{s.State.ModelUUID()}, s.State.ToolsStorage)
+	s.testToolsStorageParams(c, []string{s.State.ModelUUID()}, s.State.ToolsStorage)
 }
 
 func (s *binaryStorageSuite) TestToolsStorageParamsHostedModel(c *gc.C) {
-	s.testStorageParams(c, "toolsmetadata", []string{s.modelUUID, s.State.ModelUUID()}, s.st.ToolsStorage)
+	s.testToolsStorageParams(c, []string{s.modelUUID, s.State.ModelUUID()}, s.st.ToolsStorage)
 }
 
 func (s *binaryStorageSuite) TestGUIArchiveStorage(c *gc.C) {
@@ -161,6 +161,29 @@ func (s *binaryStorageSuite) testStorageParams(c *gc.C, collName string, uuids [
 	c.Assert(uuidArgs, jc.DeepEquals, uuids)
 }
 
+func (s *binaryStorageSuite) testToolsStorageParams(c *gc.C, uuids []string, openStorage storageOpener) {
+	var uuidArgs []string
+	s.PatchValue(state.BinarystorageNewShared, func(
+		modelUUID string,
+		sharedBucket string,
+		managedStorage blobstore.ManagedStorage,
+		metadataCollection mongo.Collection,
+		runner jujutxn.Runner,
+	) binarystorage.Storage {
+		uuidArgs = append(uuidArgs, modelUUID)
+		c.Assert(sharedBucket, gc.Equals, s.controllerModelUUID)
+		c.Assert(managedStorage, gc.NotNil)
+		c.Assert(metadataCollection.Name(), gc.Equals, "toolsmetadata")
+		c.Assert(runner, gc.NotNil)
+		return nil
+	})
+
+	storage, err := openStorage()
+	c.Assert(err, jc.ErrorIsNil)
+	storage.Close()
+	c.Assert(uuidArgs, jc.DeepEquals, uuids)
+}
+
 func (s *binaryStorageSuite) TestToolsStorageLayered(c *gc.C) {
 	modelTools, err := s.st.ToolsStorage()
 	c.Assert(err, jc.ErrorIsNil)
@@ -196,3 +219,94 @@ func (s *binaryStorageSuite) TestToolsStorageLayered(c *gc.C) {
 	assertContents("1.0", "abc")
 	assertContents("2.0", "def")
 }
+
+func (s *binaryStorageSuite) addTools(c *gc.C, st *state.State, v, content string) {
+	storage, err := st.ToolsStorage()
+	c.Assert(err, jc.ErrorIsNil)
+	defer storage.Close()
+	err = storage.Add(strings.NewReader(content), binarystorage.Metadata{
+		Version: v,
+		Size:    int64(len(content)),
+		SHA256:  fmt.Sprintf("hash(%s)", content),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *binaryStorageSuite) toolsVersions(c *gc.C, st *state.State) []string {
+	storage, err := st.ToolsStorage()
+	c.Assert(err, jc.ErrorIsNil)
+	defer storage.Close()
+	all, err := storage.AllMetadata()
+	c.Assert(err, jc.ErrorIsNil)
+	var versions []string
+	for _, m := range all {
+		versions = append(versions, m.Version)
+	}
+	return versions
+}
+
+func (s *binaryStorageSuite) TestAgentBinaryStorageUsage(c *gc.C) {
+	s.addTools(c, s.State, "2.0.0-trusty-amd64", "abc")
+	s.addTools(c, s.st, "2.0.0-trusty-amd64", "abc")
+	s.addTools(c, s.st, "2.0.0-xenial-amd64", "defg")
+
+	usage, err := s.State.AgentBinaryStorageUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage, jc.DeepEquals, state.AgentBinaryStorageUsage{
+		Entries:    3,
+		Binaries:   2,
+		Size:       10,
+		StoredSize: 7,
+	})
+}
+
+func (s *binaryStorageSuite) setAgentVersion(c *gc.C, st *state.State, v string) {
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.UpdateModelConfig(map[string]interface{}{"agent-version": v}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *binaryStorageSuite) TestRemoveUnusedAgentBinaries(c *gc.C) {
+	s.setAgentVersion(c, s.State, "2.1.0")
+	s.setAgentVersion(c, s.st, "2.0.0")
+
+	machine, err := s.st.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetAgentVersion(version.MustParseBinary("1.9.0-quantal-amd64"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The hosted model needs the controller's 2.0.0 binary, even
+	// though the controller model does not.
+	s.addTools(c, s.State, "1.8.0-quantal-amd64", "a")
+	s.addTools(c, s.State, "2.0.0-quantal-amd64", "b")
+	s.addTools(c, s.State, "2.1.0-quantal-amd64", "c")
+	// The hosted model's machine is still running 1.9.0.
+	s.addTools(c, s.st, "1.8.0-quantal-amd64", "a")
+	s.addTools(c, s.st, "1.9.0-quantal-amd64", "d")
+	s.addTools(c, s.st, "1.9.0-xenial-amd64", "e")
+	s.addTools(c, s.st, "2.2.0-quantal-amd64", "f")
+
+	err = s.State.RemoveUnusedAgentBinaries()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.toolsVersions(c, s.State), jc.SameContents, []string{
+		"2.0.0-quantal-amd64",
+		"2.1.0-quantal-amd64",
+	})
+	c.Assert(s.toolsVersions(c, s.st), jc.SameContents, []string{
+		"1.9.0-quantal-amd64",
+		"2.0.0-quantal-amd64",
+		"2.1.0-quantal-amd64",
+		"2.2.0-quantal-amd64",
+	})
+
+	usage, err := s.State.AgentBinaryStorageUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage, jc.DeepEquals, state.AgentBinaryStorageUsage{
+		Entries:    4,
+		Binaries:   4,
+		Size:       4,
+		StoredSize: 4,
+	})
+}
@@ -179,11 +179,101 @@ func (st *State) GetBlockForType(t BlockType) (Block, bool, error) {
 }
 
 func getBlockForType(mb modelBackend, t BlockType) (Block, bool, error) {
+	return getBlockForTag(mb, t, names.NewModelTag(mb.modelUUID()))
+}
+
+// SwitchEntityBlockOn enables block of specified type for the given
+// application or machine in the current model. Only remove and change
+// blocks may be scoped to an entity.
+func (st *State) SwitchEntityBlockOn(t BlockType, tag names.Tag, msg string) error {
+	if err := validateEntityBlock(t, tag); err != nil {
+		return errors.Trace(err)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		assertOp, err := entityBlockAssertOp(st, tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops, err := setBlockOps(st, t, tag, msg)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, assertOp), nil
+	}
+	err := st.db().Run(buildTxn)
+	return errors.Annotatef(err, "cannot block %s", names.ReadableString(tag))
+}
+
+// SwitchEntityBlockOff disables block of specified type for the given
+// application or machine in the current model.
+func (st *State) SwitchEntityBlockOff(t BlockType, tag names.Tag) error {
+	if err := validateEntityBlock(t, tag); err != nil {
+		return errors.Trace(err)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		return removeBlockOps(st, t, tag)
+	}
+	return st.db().Run(buildTxn)
+}
+
+// GetEntityBlockForType returns the Block of the specified type for
+// the given application or machine, with the same semantics as
+// GetBlockForType. Blocks on the model as a whole are not considered.
+func (st *State) GetEntityBlockForType(t BlockType, tag names.Tag) (Block, bool, error) {
+	return getBlockForTag(st, t, tag)
+}
+
+// validateEntityBlock returns an error if the block type cannot be
+// applied to the entity with the given tag.
+func validateEntityBlock(t BlockType, tag names.Tag) error {
+	switch tag.Kind() {
+	case names.ApplicationTagKind, names.MachineTagKind:
+	default:
+		return errors.NotValidf("block on %s", names.ReadableString(tag))
+	}
+	if t == DestroyBlock {
+		return errors.NotValidf("%v on %s", t, names.ReadableString(tag))
+	}
+	return nil
+}
+
+// entityBlockAssertOp returns an operation asserting that the blocked
+// entity is still alive.
+func entityBlockAssertOp(st *State, tag names.Tag) (txn.Op, error) {
+	var coll, id string
+	switch tag := tag.(type) {
+	case names.ApplicationTag:
+		app, err := st.Application(tag.Id())
+		if err != nil {
+			return txn.Op{}, errors.Trace(err)
+		}
+		if app.Life() != Alive {
+			return txn.Op{}, errors.Errorf("application is no longer alive")
+		}
+		coll, id = applicationsC, app.doc.DocID
+	case names.MachineTag:
+		m, err := st.Machine(tag.Id())
+		if err != nil {
+			return txn.Op{}, errors.Trace(err)
+		}
+		if m.Life() != Alive {
+			return txn.Op{}, errors.Errorf("machine is no longer alive")
+		}
+		coll, id = machinesC, m.doc.DocID
+	}
+	return txn.Op{
+		C:      coll,
+		Id:     id,
+		Assert: isAliveDoc,
+	}, nil
+}
+
+func getBlockForTag(mb modelBackend, t BlockType, tag names.Tag) (Block, bool, error) {
 	all, closer := mb.db().GetCollection(blocksC)
 	defer closer()
 
 	doc := blockDoc{}
-	err := all.Find(bson.D{{"type", t}}).One(&doc)
+	err := all.Find(bson.D{{"type", t}, {"tag", tag.String()}}).One(&doc)
 
 	switch err {
 	case nil:
@@ -259,20 +349,26 @@ func (st *State) RemoveAllBlocksForController() error {
 // Only one instance of each block type can exist in model.
 func setModelBlock(mb modelBackend, t BlockType, msg string) error {
 	buildTxn := func(attempt int) ([]txn.Op, error) {
-		block, exists, err := getBlockForType(mb, t)
-		if err != nil {
-			return nil, errors.Trace(err)
-		}
-		// Cannot create blocks of the same type more than once per model.
-		// Cannot update current blocks.
-		if exists {
-			return block.updateMessageOp(msg)
-		}
-		return createModelBlockOps(mb, t, msg)
+		return setBlockOps(mb, t, names.NewModelTag(mb.modelUUID()), msg)
 	}
 	return mb.db().Run(buildTxn)
 }
 
+// setBlockOps returns the operations required to set a block of the
+// given type on the entity with the given tag.
+func setBlockOps(mb modelBackend, t BlockType, tag names.Tag, msg string) ([]txn.Op, error) {
+	block, exists, err := getBlockForTag(mb, t, tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Cannot create blocks of the same type more than once per entity.
+	// Cannot update current blocks.
+	if exists {
+		return block.updateMessageOp(msg)
+	}
+	return createBlockOps(mb, t, tag, msg)
+}
+
 // newBlockId returns a sequential block id for this model.
 func newBlockId(mb modelBackend) (string, error) {
 	seq, err := sequence(mb, "block")
@@ -282,18 +378,17 @@ func newBlockId(mb modelBackend) (string, error) {
 	return fmt.Sprint(seq), nil
 }
 
-func createModelBlockOps(mb modelBackend, t BlockType, msg string) ([]txn.Op, error) {
+func createBlockOps(mb modelBackend, t BlockType, tag names.Tag, msg string) ([]txn.Op, error) {
 	id, err := newBlockId(mb)
 	if err != nil {
 		return nil, errors.Annotatef(err, "getting new block id")
 	}
-	// NOTE: if at any time in the future, we change blocks so that the
-	// Tag is different from the model, then the migration of blocks will
-	// need to change format.
+	// NOTE: blocks on applications and machines are not migrated, as
+	// the model description only records blocks on the model.
 	newDoc := blockDoc{
 		DocID:     mb.docID(id),
 		ModelUUID: mb.modelUUID(),
-		Tag:       names.NewModelTag(mb.modelUUID()).String(),
+		Tag:       tag.String(),
 		Type:      t,
 		Message:   msg,
 	}
@@ -314,7 +409,11 @@ func RemoveModelBlock(st *State, t BlockType) error {
 }
 
 func RemoveModelBlockOps(st *State, t BlockType) ([]txn.Op, error) {
-	tBlock, exists, err := st.GetBlockForType(t)
+	return removeBlockOps(st, t, names.NewModelTag(st.ModelUUID()))
+}
+
+func removeBlockOps(mb modelBackend, t BlockType, tag names.Tag) ([]txn.Op, error) {
+	tBlock, exists, err := getBlockForTag(mb, t, tag)
 	if err != nil {
 		return nil, errors.Annotatef(err, "removing block %v", t.String())
 	}
@@ -328,3 +427,24 @@ func RemoveModelBlockOps(st *State, t BlockType) ([]txn.Op, error) {
 	// If the block doesn't exist, we're all good.
 	return nil, nil
 }
+
+// removeEntityBlocksOps returns the operations required to remove all
+// blocks on the entity with the given tag.
+func removeEntityBlocksOps(mb modelBackend, tag names.Tag) ([]txn.Op, error) {
+	blocks, closer := mb.db().GetCollection(blocksC)
+	defer closer()
+
+	var docs []blockDoc
+	if err := blocks.Find(bson.D{{"tag", tag.String()}}).Select(bson.D{{"_id", 1}}).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get blocks on %s", names.ReadableString(tag))
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      blocksC,
+			Id:     doc.DocID,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
@@ -208,3 +208,77 @@ func (s *blockSuite) TestConcurrentBlocked(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertModelHasBlock(c, s.State, t, msg)
 }
+
+func (s *blockSuite) TestSwitchEntityBlockOn(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	err := s.State.SwitchEntityBlockOn(state.RemoveBlock, app.Tag(), "production database")
+	c.Assert(err, jc.ErrorIsNil)
+
+	block, found, err := s.State.GetEntityBlockForType(state.RemoveBlock, app.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.IsTrue)
+	c.Assert(block.Message(), gc.Equals, "production database")
+	tag, err := block.Tag()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tag, gc.Equals, app.Tag())
+
+	// Blocks on an entity do not block the model.
+	s.assertNoTypedBlock(c, state.RemoveBlock)
+	all, err := s.State.AllBlocks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+}
+
+func (s *blockSuite) TestSwitchEntityBlockOff(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	err := s.State.SwitchEntityBlockOn(state.ChangeBlock, machine.Tag(), "")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SwitchEntityBlockOff(state.ChangeBlock, machine.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	_, found, err := s.State.GetEntityBlockForType(state.ChangeBlock, machine.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.IsFalse)
+}
+
+func (s *blockSuite) TestModelBlockDoesNotBlockEntity(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	s.switchOnBlock(c, state.ChangeBlock)
+	_, found, err := s.State.GetEntityBlockForType(state.ChangeBlock, machine.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.IsFalse)
+}
+
+func (s *blockSuite) TestSwitchEntityBlockOnInvalid(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	err := s.State.SwitchEntityBlockOn(state.DestroyBlock, machine.Tag(), "")
+	c.Assert(err, gc.ErrorMatches, `BlockDestroy on machine 0 not valid`)
+
+	unit := s.Factory.MakeUnit(c, nil)
+	err = s.State.SwitchEntityBlockOn(state.RemoveBlock, unit.Tag(), "")
+	c.Assert(err, gc.ErrorMatches, `block on unit .* not valid`)
+}
+
+func (s *blockSuite) TestSwitchEntityBlockOnNotFound(c *gc.C) {
+	err := s.State.SwitchEntityBlockOn(state.RemoveBlock, names.NewApplicationTag("foo"), "")
+	c.Assert(err, gc.ErrorMatches, `cannot block application foo: application "foo" not found`)
+}
+
+func (s *blockSuite) TestRemoveMachineRemovesBlocks(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	err := s.State.SwitchEntityBlockOn(state.RemoveBlock, machine.Tag(), "")
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	assertNoEnvBlock(c, s.State)
+}
+
+func (s *blockSuite) TestDestroyApplicationRemovesBlocks(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	err := s.State.SwitchEntityBlockOn(state.ChangeBlock, app.Tag(), "")
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	assertNoEnvBlock(c, s.State)
+}
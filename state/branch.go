@@ -0,0 +1,414 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// nextGenerationKey is the id of the document recording the model's
+// branch. A model has at most one branch at a time, holding the "next"
+// generation of its applications' configuration; units that do not
+// track the branch remain on the "stable" generation.
+const nextGenerationKey = "next"
+
+// branchDoc records a model's branch.
+type branchDoc struct {
+	DocID     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+	TxnRevno  int64  `bson:"txn-revno"`
+
+	Name      string `bson:"name"`
+	Created   int64  `bson:"created"`
+	CreatedBy string `bson:"created-by"`
+
+	// AssignedUnits holds the names of the units tracking the branch,
+	// keyed on application name.
+	AssignedUnits map[string][]string `bson:"assigned-units"`
+
+	// Config holds the changes made on the branch to each
+	// application's charm configuration, keyed on application name.
+	// A nil value resets the setting to the charm's default.
+	Config map[string]settingsMap `bson:"config"`
+}
+
+// Branch holds changes to the configuration of a model's applications
+// that are seen only by the units that track it, so that the changes
+// can be rolled out to some units before being committed for all of
+// them, or aborted.
+type Branch struct {
+	st  *State
+	doc branchDoc
+}
+
+// Name returns the name of the branch.
+func (b *Branch) Name() string {
+	return b.doc.Name
+}
+
+// Created returns when the branch was added.
+func (b *Branch) Created() time.Time {
+	return time.Unix(0, b.doc.Created).UTC()
+}
+
+// CreatedBy returns the name of the user who added the branch.
+func (b *Branch) CreatedBy() string {
+	return b.doc.CreatedBy
+}
+
+// AssignedUnits returns the names of the units tracking the branch,
+// keyed on application name.
+func (b *Branch) AssignedUnits() map[string][]string {
+	result := make(map[string][]string)
+	for app, units := range b.doc.AssignedUnits {
+		result[app] = append([]string(nil), units...)
+	}
+	return result
+}
+
+// Config returns the changes made on the branch to each application's
+// charm configuration, keyed on application name. A nil value resets
+// the setting to the charm's default.
+func (b *Branch) Config() map[string]charm.Settings {
+	result := make(map[string]charm.Settings)
+	for app, settings := range b.doc.Config {
+		result[app] = charm.Settings(copyMap(settings, nil))
+	}
+	return result
+}
+
+// tracks reports whether the unit is tracking the branch.
+func (b *Branch) tracks(appName, unitName string) bool {
+	for _, name := range b.doc.AssignedUnits[appName] {
+		if name == unitName {
+			return true
+		}
+	}
+	return false
+}
+
+// Refresh refreshes the contents of the branch from the underlying
+// state. It returns an error that satisfies errors.IsNotFound if the
+// branch has been committed or aborted.
+func (b *Branch) Refresh() error {
+	doc, err := b.st.branchDoc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if doc.Name != b.doc.Name {
+		return errors.NotFoundf("branch %q", b.doc.Name)
+	}
+	b.doc = *doc
+	return nil
+}
+
+// AddBranch adds a branch with the given name to the model, created by
+// the given user. A model has at most one branch at a time.
+func (m *Model) AddBranch(name, createdBy string) error {
+	if name == "" {
+		return errors.NotValidf("empty branch name")
+	}
+	st := m.st
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if err := checkModelActive(st); err != nil {
+			return nil, errors.Trace(err)
+		}
+		existing, err := st.branchDoc()
+		if err == nil {
+			return nil, errors.AlreadyExistsf("branch %q", existing.Name)
+		} else if !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{
+			assertModelActiveOp(st.ModelUUID()),
+			{
+				C:      generationsC,
+				Id:     st.docID(nextGenerationKey),
+				Assert: txn.DocMissing,
+				Insert: &branchDoc{
+					DocID:     st.docID(nextGenerationKey),
+					ModelUUID: st.ModelUUID(),
+					Name:      name,
+					Created:   st.clock().Now().UnixNano(),
+					CreatedBy: createdBy,
+
+					AssignedUnits: make(map[string][]string),
+					Config:        make(map[string]settingsMap),
+				},
+			},
+		}, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot add branch %q", name)
+	}
+	return nil
+}
+
+// Branch returns the model's branch. It returns an error that satisfies
+// errors.IsNotFound if the model has none.
+func (m *Model) Branch() (*Branch, error) {
+	doc, err := m.st.branchDoc()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Branch{st: m.st, doc: *doc}, nil
+}
+
+func (st *State) branchDoc() (*branchDoc, error) {
+	coll, closer := st.db().GetCollection(generationsC)
+	defer closer()
+
+	var doc branchDoc
+	err := coll.FindId(nextGenerationKey).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("branch")
+	} else if err != nil {
+		return nil, errors.Annotate(err, "cannot read branch")
+	}
+	return &doc, nil
+}
+
+// branchOps returns the operations that assert that the branch has not
+// changed since it was read, after refreshing it.
+func (b *Branch) branchOps(attempt int) ([]txn.Op, error) {
+	if attempt > 0 {
+		if err := b.Refresh(); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return []txn.Op{{
+		C:      generationsC,
+		Id:     b.doc.DocID,
+		Assert: bson.D{{"txn-revno", b.doc.TxnRevno}},
+	}}, nil
+}
+
+// AssignUnit makes the unit with the given name track the branch, so
+// that it sees the configuration changes made on the branch.
+func (b *Branch) AssignUnit(unitName string) error {
+	if !names.IsValidUnit(unitName) {
+		return errors.NotValidf("unit name %q", unitName)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		ops, err := b.branchOps(attempt)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		unit, err := b.st.Unit(unitName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if unit.Life() != Alive {
+			return nil, errors.Errorf("unit %q is not alive", unitName)
+		}
+		appName := unit.ApplicationName()
+		if b.tracks(appName, unitName) {
+			return nil, jujutxn.ErrNoOperations
+		}
+		units := append(b.AssignedUnits()[appName], unitName)
+		sort.Strings(units)
+		ops = append(ops, txn.Op{
+			C:      unitsC,
+			Id:     unit.doc.DocID,
+			Assert: isAliveDoc,
+		})
+		ops[0].Update = bson.D{{"$set", bson.D{{"assigned-units." + appName, units}}}}
+		return ops, nil
+	}
+	if err := b.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot track branch %q for unit %q", b.doc.Name, unitName)
+	}
+	return nil
+}
+
+// AssignAllUnits makes all of the application's units track the
+// branch.
+func (b *Branch) AssignAllUnits(appName string) error {
+	app, err := b.st.Application(appName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, unit := range units {
+		if unit.Life() != Alive {
+			continue
+		}
+		if err := b.AssignUnit(unit.Name()); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// UpdateCharmConfig changes the application's charm configuration on
+// the branch. The changes are merged with those already made on the
+// branch; a nil value resets the setting to the charm's default.
+func (b *Branch) UpdateCharmConfig(appName string, changes charm.Settings) error {
+	app, err := b.st.Application(appName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ch, _, err := app.Charm()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// ValidateSettings drops nil values, which reset settings, so
+	// validate the others and add them back.
+	validated, err := ch.Config().ValidateSettings(changes)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for name, value := range changes {
+		if value == nil {
+			validated[name] = nil
+		}
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		ops, err := b.branchOps(attempt)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		config := b.Config()[appName]
+		if config == nil {
+			config = make(charm.Settings)
+		}
+		for name, value := range validated {
+			config[name] = value
+		}
+		escaped := copyMap(config, escapeReplacer.Replace)
+		ops[0].Update = bson.D{{"$set", bson.D{{"config." + appName, escaped}}}}
+		return ops, nil
+	}
+	if err := b.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot update %q configuration on branch %q", appName, b.doc.Name)
+	}
+	return nil
+}
+
+// Commit applies the configuration changes made on the branch to all
+// of the units of the applications concerned, and removes the branch.
+// Changes for applications that have since been removed are dropped.
+func (b *Branch) Commit() error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		ops, err := b.branchOps(attempt)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops[0].Remove = true
+		appNames := set.NewStrings()
+		for appName := range b.doc.Config {
+			appNames.Add(appName)
+		}
+		for _, appName := range appNames.SortedValues() {
+			settingsOps, err := b.commitConfigOps(appName)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, settingsOps...)
+		}
+		return ops, nil
+	}
+	if err := b.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot commit branch %q", b.doc.Name)
+	}
+	return nil
+}
+
+// commitConfigOps returns the operations that apply the changes made on
+// the branch to the application's charm configuration.
+func (b *Branch) commitConfigOps(appName string) ([]txn.Op, error) {
+	app, err := b.st.Application(appName)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	settings, err := readSettings(b.st.db(), settingsC, app.charmConfigKey())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for name, value := range b.doc.Config[appName] {
+		if value == nil {
+			settings.Delete(name)
+		} else {
+			settings.Set(name, value)
+		}
+	}
+	_, ops := settings.settingsUpdateOps()
+	if len(ops) == 0 {
+		return nil, nil
+	}
+	ops[0].Assert = bson.D{{"version", settings.version}}
+	return append([]txn.Op{{
+		C:      applicationsC,
+		Id:     app.doc.DocID,
+		Assert: bson.D{{"charmurl", app.doc.CharmURL}},
+	}}, ops...), nil
+}
+
+// Abort removes the branch, discarding the configuration changes made
+// on it.
+func (b *Branch) Abort() error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		ops, err := b.branchOps(attempt)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops[0].Remove = true
+		return ops, nil
+	}
+	if err := b.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot abort branch %q", b.doc.Name)
+	}
+	return nil
+}
+
+// unitBranchConfig applies the configuration changes made on the
+// model's branch, if the unit tracks it, to the unit's settings.
+func unitBranchConfig(st *State, appName, unitName string, curl *charm.URL, settings charm.Settings) (charm.Settings, error) {
+	doc, err := st.branchDoc()
+	if errors.IsNotFound(err) {
+		return settings, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	branch := &Branch{st: st, doc: *doc}
+	changes := doc.Config[appName]
+	if len(changes) == 0 || !branch.tracks(appName, unitName) {
+		return settings, nil
+	}
+	ch, err := st.Charm(curl)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defaults := ch.Config().DefaultSettings()
+	for name, value := range changes {
+		if _, ok := ch.Config().Options[name]; !ok {
+			// The setting is not supported by the unit's charm.
+			continue
+		}
+		if value == nil {
+			value = defaults[name]
+		}
+		if value == nil {
+			delete(settings, name)
+		} else {
+			settings[name] = value
+		}
+	}
+	return settings, nil
+}
@@ -0,0 +1,209 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+)
+
+type BranchSuite struct {
+	ConnSuite
+	application *state.Application
+	tracking    *state.Unit
+	stable      *state.Unit
+}
+
+var _ = gc.Suite(&BranchSuite{})
+
+func (s *BranchSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	ch := s.AddTestingCharm(c, "wordpress")
+	s.application = s.AddTestingApplication(c, "wordpress", ch)
+	var err error
+	s.tracking, err = s.application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.tracking.SetCharmURL(ch.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	s.stable, err = s.application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.stable.SetCharmURL(ch.URL())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *BranchSuite) addBranch(c *gc.C) *state.Branch {
+	err := s.IAASModel.AddBranch("canary", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	branch, err := s.IAASModel.Branch()
+	c.Assert(err, jc.ErrorIsNil)
+	return branch
+}
+
+func (s *BranchSuite) assertBlogTitle(c *gc.C, unit *state.Unit, expected string) {
+	settings, err := unit.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.DeepEquals, charm.Settings{"blog-title": expected})
+}
+
+func (s *BranchSuite) TestNoBranch(c *gc.C) {
+	_, err := s.IAASModel.Branch()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *BranchSuite) TestAddBranch(c *gc.C) {
+	branch := s.addBranch(c)
+	c.Assert(branch.Name(), gc.Equals, "canary")
+	c.Assert(branch.CreatedBy(), gc.Equals, "bob")
+	c.Assert(branch.AssignedUnits(), gc.HasLen, 0)
+	c.Assert(branch.Config(), gc.HasLen, 0)
+}
+
+func (s *BranchSuite) TestAddBranchAlreadyExists(c *gc.C) {
+	s.addBranch(c)
+	err := s.IAASModel.AddBranch("other", "bob")
+	c.Assert(err, gc.ErrorMatches, `cannot add branch "other": branch "canary" already exists`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *BranchSuite) TestAddBranchEmptyName(c *gc.C) {
+	err := s.IAASModel.AddBranch("", "bob")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *BranchSuite) TestAssignUnit(c *gc.C) {
+	branch := s.addBranch(c)
+	err := branch.AssignUnit(s.tracking.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	// Assigning a unit again is a no-op.
+	err = branch.AssignUnit(s.tracking.Name())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = branch.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(branch.AssignedUnits(), jc.DeepEquals, map[string][]string{
+		"wordpress": {s.tracking.Name()},
+	})
+
+	name, err := s.tracking.Branch()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(name, gc.Equals, "canary")
+	name, err = s.stable.Branch()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(name, gc.Equals, "")
+}
+
+func (s *BranchSuite) TestAssignUnitNotFound(c *gc.C) {
+	branch := s.addBranch(c)
+	err := branch.AssignUnit("wordpress/42")
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *BranchSuite) TestAssignAllUnits(c *gc.C) {
+	branch := s.addBranch(c)
+	err := branch.AssignAllUnits("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	err = branch.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(branch.AssignedUnits(), jc.DeepEquals, map[string][]string{
+		"wordpress": {s.tracking.Name(), s.stable.Name()},
+	})
+}
+
+func (s *BranchSuite) TestUpdateCharmConfig(c *gc.C) {
+	branch := s.addBranch(c)
+	err := branch.AssignUnit(s.tracking.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	err = branch.UpdateCharmConfig("wordpress", charm.Settings{"blog-title": "canary title"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertBlogTitle(c, s.tracking, "canary title")
+	s.assertBlogTitle(c, s.stable, "My Title")
+
+	// Resetting a setting on the branch shows the default, regardless
+	// of the application's setting.
+	err = s.application.UpdateCharmConfig(charm.Settings{"blog-title": "stable title"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = branch.UpdateCharmConfig("wordpress", charm.Settings{"blog-title": nil})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertBlogTitle(c, s.tracking, "My Title")
+	s.assertBlogTitle(c, s.stable, "stable title")
+}
+
+func (s *BranchSuite) TestUpdateCharmConfigInvalid(c *gc.C) {
+	branch := s.addBranch(c)
+	err := branch.UpdateCharmConfig("wordpress", charm.Settings{"no-such-option": "foo"})
+	c.Assert(err, gc.ErrorMatches, `unknown option "no-such-option"`)
+}
+
+func (s *BranchSuite) TestCommit(c *gc.C) {
+	branch := s.addBranch(c)
+	err := branch.AssignUnit(s.tracking.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	err = branch.UpdateCharmConfig("wordpress", charm.Settings{"blog-title": "canary title"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = branch.Commit()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertBlogTitle(c, s.tracking, "canary title")
+	s.assertBlogTitle(c, s.stable, "canary title")
+
+	_, err = s.IAASModel.Branch()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	name, err := s.tracking.Branch()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(name, gc.Equals, "")
+}
+
+func (s *BranchSuite) TestAbort(c *gc.C) {
+	branch := s.addBranch(c)
+	err := branch.AssignUnit(s.tracking.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	err = branch.UpdateCharmConfig("wordpress", charm.Settings{"blog-title": "canary title"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = branch.Abort()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertBlogTitle(c, s.tracking, "My Title")
+	s.assertBlogTitle(c, s.stable, "My Title")
+
+	_, err = s.IAASModel.Branch()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *BranchSuite) TestWatchConfigSettings(c *gc.C) {
+	trackingW, err := s.tracking.WatchConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	defer testing.AssertStop(c, trackingW)
+	trackingC := testing.NewNotifyWatcherC(c, s.State, trackingW)
+	trackingC.AssertOneChange()
+
+	stableW, err := s.stable.WatchConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	defer testing.AssertStop(c, stableW)
+	stableC := testing.NewNotifyWatcherC(c, s.State, stableW)
+	stableC.AssertOneChange()
+
+	branch := s.addBranch(c)
+	err = branch.AssignUnit(s.tracking.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	trackingC.AssertNoChange()
+	stableC.AssertNoChange()
+
+	// Changes on the branch are seen only by the tracking unit.
+	err = branch.UpdateCharmConfig("wordpress", charm.Settings{"blog-title": "canary title"})
+	c.Assert(err, jc.ErrorIsNil)
+	trackingC.AssertOneChange()
+	stableC.AssertNoChange()
+
+	// Committing the branch changes the other unit's settings only.
+	err = branch.Commit()
+	c.Assert(err, jc.ErrorIsNil)
+	stableC.AssertOneChange()
+	trackingC.AssertNoChange()
+}
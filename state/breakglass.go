@@ -0,0 +1,223 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// MaxBreakGlassDuration is the longest that a break-glass session may
+// last.
+const MaxBreakGlassDuration = 4 * time.Hour
+
+// BreakGlassSession is a period during which a user is temporarily
+// elevated to controller superuser, so that they can repair the
+// controller or its models in an emergency.
+type BreakGlassSession struct {
+	// Id identifies the session, and is recorded with each of the
+	// API calls made during it.
+	Id string
+
+	// User is the user elevated by the session.
+	User names.UserTag
+
+	// Reason is why the user needed to be elevated.
+	Reason string
+
+	// Started is when the session started.
+	Started time.Time
+
+	// Expires is when the session ends, unless it is ended earlier.
+	Expires time.Time
+}
+
+// Active reports whether the session is in effect at the given time.
+func (s BreakGlassSession) Active(now time.Time) bool {
+	return now.Before(s.Expires)
+}
+
+// breakGlassSessionDoc records a user's latest break-glass session.
+// There is at most one document for each user, and it is replaced when
+// the user starts another session.
+type breakGlassSessionDoc struct {
+	DocID     string    `bson:"_id"`
+	SessionID string    `bson:"session-id"`
+	User      string    `bson:"user"`
+	Reason    string    `bson:"reason"`
+	Started   time.Time `bson:"started"`
+	Expires   time.Time `bson:"expires"`
+}
+
+func (doc breakGlassSessionDoc) session() *BreakGlassSession {
+	return &BreakGlassSession{
+		Id:      doc.SessionID,
+		User:    names.NewUserTag(doc.User),
+		Reason:  doc.Reason,
+		Started: doc.Started.UTC(),
+		Expires: doc.Expires.UTC(),
+	}
+}
+
+// StartBreakGlassSession elevates the user to controller superuser for
+// the given duration, which may be no longer than
+// MaxBreakGlassDuration, recording the reason given in the controller
+// model's activity feed so that other administrators are told of it.
+// It returns an error satisfying errors.IsAlreadyExists if the user
+// already has an active session. It must be called on the controller
+// model's State.
+func (st *State) StartBreakGlassSession(user names.UserTag, reason string, duration time.Duration) (*BreakGlassSession, error) {
+	if !st.IsController() {
+		return nil, errors.NotSupportedf("break-glass session outside the controller model")
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, errors.NotValidf("break-glass session without reason")
+	}
+	if duration <= 0 || duration > MaxBreakGlassDuration {
+		return nil, errors.NotValidf("break-glass session duration %v (must be between 0 and %v)", duration, MaxBreakGlassDuration)
+	}
+	id, err := utils.NewUUID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	userName := strings.ToLower(user.Id())
+	now := st.nowToTheSecond()
+	doc := breakGlassSessionDoc{
+		DocID:     userName,
+		SessionID: id.String(),
+		User:      user.Id(),
+		Reason:    reason,
+		Started:   now,
+		Expires:   now.Add(duration),
+	}
+	buildTxn := func(int) ([]txn.Op, error) {
+		existing, err := st.breakGlassSessionDoc(userName)
+		if errors.IsNotFound(err) {
+			return []txn.Op{{
+				C:      breakGlassSessionsC,
+				Id:     userName,
+				Assert: txn.DocMissing,
+				Insert: &doc,
+			}}, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if existing.session().Active(now) {
+			return nil, errors.AlreadyExistsf("break-glass session for %q", user.Id())
+		}
+		return []txn.Op{{
+			C:      breakGlassSessionsC,
+			Id:     userName,
+			Assert: bson.D{{"session-id", existing.SessionID}},
+			Update: bson.D{{"$set", bson.D{
+				{"session-id", doc.SessionID},
+				{"user", doc.User},
+				{"reason", doc.Reason},
+				{"started", doc.Started},
+				{"expires", doc.Expires},
+			}}},
+		}}, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return nil, errors.Annotatef(err, "cannot start break-glass session for %q", user.Id())
+	}
+	probablyAddModelEvent(st, ModelEvent{
+		Kind:     EventBreakGlassStarted,
+		Actor:    user.String(),
+		Entities: []string{user.String()},
+		Message: fmt.Sprintf("%s elevated to controller superuser until %s: %s",
+			user.Id(), doc.Expires.Format(time.RFC3339), reason),
+	})
+	return doc.session(), nil
+}
+
+// EndBreakGlassSession ends the user's active break-glass session
+// before it expires, recording who ended it in the controller model's
+// activity feed. It returns an error satisfying errors.IsNotFound if
+// the user has no active session. It must be called on the controller
+// model's State.
+func (st *State) EndBreakGlassSession(user, endedBy names.UserTag) error {
+	if !st.IsController() {
+		return errors.NotSupportedf("break-glass session outside the controller model")
+	}
+	userName := strings.ToLower(user.Id())
+	buildTxn := func(int) ([]txn.Op, error) {
+		existing, err := st.breakGlassSessionDoc(userName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !existing.session().Active(st.clock().Now()) {
+			return nil, errors.NotFoundf("break-glass session for %q", user.Id())
+		}
+		return []txn.Op{{
+			C:      breakGlassSessionsC,
+			Id:     userName,
+			Assert: bson.D{{"session-id", existing.SessionID}},
+			Remove: true,
+		}}, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot end break-glass session for %q", user.Id())
+	}
+	probablyAddModelEvent(st, ModelEvent{
+		Kind:     EventBreakGlassEnded,
+		Actor:    endedBy.String(),
+		Entities: []string{user.String()},
+		Message:  fmt.Sprintf("break-glass session for %s ended by %s", user.Id(), endedBy.Id()),
+	})
+	return nil
+}
+
+// BreakGlassSession returns the user's active break-glass session. It
+// returns an error satisfying errors.IsNotFound if the user has none.
+func (st *State) BreakGlassSession(user names.UserTag) (*BreakGlassSession, error) {
+	doc, err := st.breakGlassSessionDoc(strings.ToLower(user.Id()))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	session := doc.session()
+	if !session.Active(st.clock().Now()) {
+		return nil, errors.NotFoundf("break-glass session for %q", user.Id())
+	}
+	return session, nil
+}
+
+// BreakGlassSessions returns the active break-glass sessions of all
+// users, ordered by when they started.
+func (st *State) BreakGlassSessions() ([]BreakGlassSession, error) {
+	sessions, closer := st.db().GetCollection(breakGlassSessionsC)
+	defer closer()
+	var docs []breakGlassSessionDoc
+	query := bson.D{{"expires", bson.D{{"$gt", st.clock().Now().UTC()}}}}
+	if err := sessions.Find(query).Sort("started").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get break-glass sessions")
+	}
+	result := make([]BreakGlassSession, len(docs))
+	for i, doc := range docs {
+		result[i] = *doc.session()
+	}
+	return result, nil
+}
+
+func (st *State) breakGlassSessionDoc(userName string) (*breakGlassSessionDoc, error) {
+	sessions, closer := st.db().GetCollection(breakGlassSessionsC)
+	defer closer()
+	var doc breakGlassSessionDoc
+	err := sessions.FindId(userName).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("break-glass session for %q", userName)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get break-glass session for %q", userName)
+	}
+	return &doc, nil
+}
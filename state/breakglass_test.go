@@ -0,0 +1,124 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+type BreakGlassSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&BreakGlassSuite{})
+
+func (s *BreakGlassSuite) TestStartBreakGlassSession(c *gc.C) {
+	bob := names.NewUserTag("bob")
+	started := s.Clock.Now().Round(time.Second).UTC()
+	session, err := s.State.StartBreakGlassSession(bob, " controller down ", time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(session.Id, gc.Not(gc.Equals), "")
+	c.Assert(session, jc.DeepEquals, &state.BreakGlassSession{
+		Id:      session.Id,
+		User:    bob,
+		Reason:  "controller down",
+		Started: started,
+		Expires: started.Add(time.Hour),
+	})
+
+	got, err := s.State.BreakGlassSession(names.NewUserTag("Bob"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, jc.DeepEquals, session)
+	sessions, err := s.State.BreakGlassSessions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sessions, jc.DeepEquals, []state.BreakGlassSession{*session})
+
+	events, err := s.State.ModelEvents(state.ModelEventsFilter{
+		Kinds: []state.ModelEventKind{state.EventBreakGlassStarted},
+		Limit: 10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, gc.HasLen, 1)
+	c.Assert(events[0].Actor, gc.Equals, "user-bob")
+	c.Assert(events[0].Entities, jc.DeepEquals, []string{"user-bob"})
+	c.Assert(events[0].Message, gc.Matches, `bob elevated to controller superuser until .*: controller down`)
+}
+
+func (s *BreakGlassSuite) TestStartBreakGlassSessionInvalid(c *gc.C) {
+	bob := names.NewUserTag("bob")
+	_, err := s.State.StartBreakGlassSession(bob, " ", time.Hour)
+	c.Assert(err, gc.ErrorMatches, "break-glass session without reason not valid")
+	_, err = s.State.StartBreakGlassSession(bob, "because", 0)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	_, err = s.State.StartBreakGlassSession(bob, "because", state.MaxBreakGlassDuration+time.Second)
+	c.Assert(err, gc.ErrorMatches, `break-glass session duration 4h0m1s \(must be between 0 and 4h0m0s\) not valid`)
+
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	_, err = st.StartBreakGlassSession(bob, "because", time.Hour)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *BreakGlassSuite) TestStartBreakGlassSessionAlreadyActive(c *gc.C) {
+	bob := names.NewUserTag("bob")
+	first, err := s.State.StartBreakGlassSession(bob, "first", time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.StartBreakGlassSession(bob, "second", time.Hour)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+	c.Assert(err, gc.ErrorMatches, `cannot start break-glass session for "bob": break-glass session for "bob" already exists`)
+
+	// Once the first session expires, another may be started.
+	s.Clock.Advance(time.Hour)
+	second, err := s.State.StartBreakGlassSession(bob, "second", time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(second.Id, gc.Not(gc.Equals), first.Id)
+	got, err := s.State.BreakGlassSession(bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, jc.DeepEquals, second)
+}
+
+func (s *BreakGlassSuite) TestBreakGlassSessionExpires(c *gc.C) {
+	bob := names.NewUserTag("bob")
+	_, err := s.State.StartBreakGlassSession(bob, "because", time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Clock.Advance(time.Hour)
+	_, err = s.State.BreakGlassSession(bob)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	sessions, err := s.State.BreakGlassSessions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sessions, gc.HasLen, 0)
+	err = s.State.EndBreakGlassSession(bob, bob)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *BreakGlassSuite) TestEndBreakGlassSession(c *gc.C) {
+	bob := names.NewUserTag("bob")
+	_, err := s.State.StartBreakGlassSession(bob, "because", time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.EndBreakGlassSession(bob, s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.BreakGlassSession(bob)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = s.State.EndBreakGlassSession(bob, s.Owner)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	events, err := s.State.ModelEvents(state.ModelEventsFilter{
+		Kinds: []state.ModelEventKind{state.EventBreakGlassEnded},
+		Limit: 10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, gc.HasLen, 1)
+	c.Assert(events[0].Actor, gc.Equals, s.Owner.String())
+	c.Assert(events[0].Entities, jc.DeepEquals, []string{"user-bob"})
+	c.Assert(events[0].Message, gc.Equals, "break-glass session for bob ended by "+s.Owner.Id())
+}
@@ -17,6 +17,9 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/core/egress"
+	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/core/relation"
 	"github.com/juju/juju/mongo"
 	mongoutils "github.com/juju/juju/mongo/utils"
 	"github.com/juju/juju/state/storage"
@@ -81,6 +84,22 @@ type charmDoc struct {
 	Config  *charm.Config  `bson:"config"`
 	Actions *charm.Actions `bson:"actions"`
 	Metrics *charm.Metrics `bson:"metrics"`
+
+	// LXDProfile holds the LXD profile declared by the charm, with
+	// mongo-significant characters in its keys escaped.
+	LXDProfile *lxdprofile.Profile `bson:"lxd-profile,omitempty"`
+
+	// InterfaceVersions holds the versions of the interface schemas
+	// implemented by the charm's endpoints, keyed by endpoint name.
+	InterfaceVersions map[string]relation.InterfaceVersion `bson:"interface-versions,omitempty"`
+
+	// EgressRules holds the outbound network destinations that the
+	// charm declares its units require.
+	EgressRules []egress.Rule `bson:"egress-rules,omitempty"`
+
+	// RelationDataVisibility holds the rules restricting which units
+	// can read keys of the relation settings written by the charm.
+	RelationDataVisibility []relation.DataVisibilityRule `bson:"relation-data-visibility,omitempty"`
 }
 
 // CharmInfo contains all the data necessary to store a charm's metadata.
@@ -98,6 +117,22 @@ func insertCharmOps(mb modelBackend, info CharmInfo) ([]txn.Op, error) {
 	if info.ID == nil {
 		return nil, errors.New("*charm.URL was nil")
 	}
+	profile, err := safeLXDProfile(info.Charm)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	versions, err := readInterfaceVersions(info.Charm)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	egressRules, err := readEgressRules(info.Charm)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	visibility, err := readDataVisibility(info.Charm)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	doc := charmDoc{
 		DocID:        info.ID.String(),
@@ -106,8 +141,13 @@ func insertCharmOps(mb modelBackend, info CharmInfo) ([]txn.Op, error) {
 		Config:       safeConfig(info.Charm),
 		Metrics:      info.Charm.Metrics(),
 		Actions:      info.Charm.Actions(),
+		LXDProfile:   profile,
 		BundleSha256: info.SHA256,
 		StoragePath:  info.StoragePath,
+
+		InterfaceVersions:      versions,
+		EgressRules:            egressRules,
+		RelationDataVisibility: visibility,
 	}
 	if err := checkCharmDataIsStorable(doc); err != nil {
 		return nil, errors.Trace(err)
@@ -205,11 +245,31 @@ func updateCharmOps(mb modelBackend, info CharmInfo, assert bson.D) ([]txn.Op, e
 	}
 	op.Assert = append(lifeAssert, assert...)
 
+	profile, err := safeLXDProfile(info.Charm)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	versions, err := readInterfaceVersions(info.Charm)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	egressRules, err := readEgressRules(info.Charm)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	visibility, err := readDataVisibility(info.Charm)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	data := bson.D{
 		{"meta", info.Charm.Meta()},
 		{"config", safeConfig(info.Charm)},
 		{"actions", info.Charm.Actions()},
 		{"metrics", info.Charm.Metrics()},
+		{"lxd-profile", profile},
+		{"interface-versions", versions},
+		{"egress-rules", egressRules},
+		{"relation-data-visibility", visibility},
 		{"storagepath", info.StoragePath},
 		{"bundlesha256", info.SHA256},
 		{"pendingupload", false},
@@ -308,6 +368,73 @@ func safeConfig(ch charm.Charm) *charm.Config {
 	return escapedConfig
 }
 
+// safeLXDProfile reads the LXD profile declared by the charm, and
+// escapes mongo-significant characters in its keys as safeConfig does
+// for config option names.
+func safeLXDProfile(ch charm.Charm) (*lxdprofile.Profile, error) {
+	profile, err := lxdprofile.Read(ch)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid charm")
+	}
+	return replaceLXDProfileKeys(profile, escapeReplacer), nil
+}
+
+// readInterfaceVersions reads the interface versions declared by the
+// charm.
+func readInterfaceVersions(ch charm.Charm) (map[string]relation.InterfaceVersion, error) {
+	versions, err := relation.ReadInterfaceVersions(ch)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid charm")
+	}
+	return versions, nil
+}
+
+// readEgressRules reads the egress rules declared by the charm.
+func readEgressRules(ch charm.Charm) ([]egress.Rule, error) {
+	rules, err := egress.ReadRules(ch)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid charm")
+	}
+	return rules, nil
+}
+
+// readDataVisibility reads the relation data visibility rules declared
+// by the charm.
+func readDataVisibility(ch charm.Charm) ([]relation.DataVisibilityRule, error) {
+	rules, err := relation.ReadDataVisibility(ch)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid charm")
+	}
+	return rules, nil
+}
+
+// replaceLXDProfileKeys returns a copy of the supplied profile with
+// the replacer applied to its config keys, device names and device
+// property names.
+func replaceLXDProfileKeys(profile *lxdprofile.Profile, replacer *strings.Replacer) *lxdprofile.Profile {
+	if profile == nil {
+		return nil
+	}
+	result := &lxdprofile.Profile{Description: profile.Description}
+	if profile.Config != nil {
+		result.Config = make(map[string]string)
+		for key, value := range profile.Config {
+			result.Config[replacer.Replace(key)] = value
+		}
+	}
+	if profile.Devices != nil {
+		result.Devices = make(map[string]map[string]string)
+		for name, device := range profile.Devices {
+			replaced := make(map[string]string)
+			for key, value := range device {
+				replaced[replacer.Replace(key)] = value
+			}
+			result.Devices[replacer.Replace(name)] = replaced
+		}
+	}
+	return result
+}
+
 // Charm represents the state of a charm in the model.
 type Charm struct {
 	st  *State
@@ -326,6 +453,9 @@ func newCharm(st *State, cdoc *charmDoc) *Charm {
 		}
 		cdoc.Config = unescapedConfig
 	}
+	if cdoc != nil {
+		cdoc.LXDProfile = replaceLXDProfileKeys(cdoc.LXDProfile, unescapeReplacer)
+	}
 	ch := Charm{st: st, doc: *cdoc}
 	return &ch
 }
@@ -449,6 +579,30 @@ func (c *Charm) Metrics() *charm.Metrics {
 	return c.doc.Metrics
 }
 
+// LXDProfile returns the LXD profile declared by the charm, or nil if
+// it declares none.
+func (c *Charm) LXDProfile() *lxdprofile.Profile {
+	return c.doc.LXDProfile
+}
+
+// InterfaceVersions returns the versions of the interface schemas
+// implemented by the charm's endpoints, keyed by endpoint name.
+func (c *Charm) InterfaceVersions() map[string]relation.InterfaceVersion {
+	return c.doc.InterfaceVersions
+}
+
+// EgressRules returns the outbound network destinations that the
+// charm declares its units require.
+func (c *Charm) EgressRules() []egress.Rule {
+	return c.doc.EgressRules
+}
+
+// RelationDataVisibility returns the rules restricting which units can
+// read keys of the relation settings written by the charm.
+func (c *Charm) RelationDataVisibility() []relation.DataVisibilityRule {
+	return c.doc.RelationDataVisibility
+}
+
 // Actions returns the actions definition of the charm.
 func (c *Charm) Actions() *charm.Actions {
 	return c.doc.Actions
@@ -544,10 +698,25 @@ func (st *State) AddCharm(info CharmInfo) (stch *Charm, err error) {
 		}
 		return nil, errors.AlreadyExistsf("charm %q", info.ID)
 	}
-	if err = st.db().Run(buildTxn); err == nil {
-		return st.Charm(info.ID)
+	if err = st.db().Run(buildTxn); err != nil {
+		return nil, errors.Trace(err)
 	}
-	return nil, errors.Trace(err)
+	return st.addedCharm(info.ID)
+}
+
+// addedCharm returns the charm just added with the given URL, having
+// recorded the interface schemas it implements.
+func (st *State) addedCharm(curl *charm.URL) (*Charm, error) {
+	ch, err := st.Charm(curl)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The registry of interface schemas is advisory, so failing to
+	// update it does not fail the addition of the charm.
+	if err := st.recordInterfaceSchemas(ch); err != nil {
+		logger.Warningf("cannot record interface schemas of charm %q: %v", curl, err)
+	}
+	return ch, nil
 }
 
 type hasMeta interface {
@@ -799,5 +968,5 @@ func (st *State) UpdateUploadedCharm(info CharmInfo) (*Charm, error) {
 	if err := st.db().RunTransaction(ops); err != nil {
 		return nil, onAbort(err, ErrCharmRevisionAlreadyModified)
 	}
-	return st.Charm(info.ID)
+	return st.addedCharm(info.ID)
 }
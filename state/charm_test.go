@@ -17,6 +17,8 @@ import (
 	"gopkg.in/macaroon.v1"
 	"gopkg.in/mgo.v2"
 
+	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/core/relation"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/storage"
 	"github.com/juju/juju/testcharms"
@@ -65,6 +67,15 @@ func (s *CharmSuite) TestAliveCharm(c *gc.C) {
 	s.testCharm(c)
 }
 
+func (s *CharmSuite) TestRelationDataVisibility(c *gc.C) {
+	ch := s.AddTestingCharm(c, "mysql-private")
+	c.Assert(ch.RelationDataVisibility(), jc.DeepEquals, []relation.DataVisibilityRule{
+		{Endpoint: "cluster", Key: "replication-key", Visibility: relation.VisibilityApplication},
+		{Endpoint: "server", Key: "password", Visibility: relation.VisibilityLeader},
+	})
+	c.Assert(s.charm.RelationDataVisibility(), gc.IsNil)
+}
+
 func (s *CharmSuite) TestDyingCharm(c *gc.C) {
 	s.destroy(c)
 	s.testCharm(c)
@@ -529,6 +540,52 @@ options:
 	c.Assert(sch.BundleSha256(), gc.Equals, "missing")
 }
 
+func (s *CharmSuite) TestAddCharmLXDProfile(c *gc.C) {
+	ch := testcharms.Repo.CharmDir("lxd-profile")
+	sch, err := s.State.AddCharm(state.CharmInfo{
+		Charm:       ch,
+		ID:          charm.MustParseURL("local:quantal/lxd-profile-0"),
+		StoragePath: "lxd-profile-0",
+		SHA256:      "lxd-profile-0-sha256",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	expected, err := lxdprofile.Read(ch)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sch.LXDProfile(), jc.DeepEquals, expected)
+
+	// Keys containing "." are escaped for storage and unescaped
+	// when read back.
+	sch, err = s.State.Charm(sch.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sch.LXDProfile(), jc.DeepEquals, expected)
+	c.Assert(sch.LXDProfile().Config["security.nesting"], gc.Equals, "true")
+}
+
+func (s *CharmSuite) TestAddCharmInvalidLXDProfile(c *gc.C) {
+	chDir := testcharms.Repo.ClonedDirPath(c.MkDir(), "lxd-profile")
+	err := utils.AtomicWriteFile(
+		filepath.Join(chDir, lxdprofile.Filename),
+		[]byte("config:\n  boot.autostart: \"false\"\n"),
+		0666,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	ch, err := charm.ReadCharmDir(chDir)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddCharm(state.CharmInfo{
+		Charm:       ch,
+		ID:          charm.MustParseURL("local:quantal/lxd-profile-0"),
+		StoragePath: "lxd-profile-0",
+		SHA256:      "lxd-profile-0-sha256",
+	})
+	c.Assert(err, gc.ErrorMatches, `invalid charm: lxd profile config "boot.autostart" not valid`)
+}
+
+func (s *CharmSuite) TestAddCharmWithoutLXDProfile(c *gc.C) {
+	dummy, err := s.State.AddCharm(s.dummyCharm(c, ""))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dummy.LXDProfile(), gc.IsNil)
+}
+
 func (s *CharmSuite) assertPlaceholderCharmExists(c *gc.C, curl *charm.URL) {
 	// Find charm directly and verify only the charm URL and
 	// Placeholder are set.
@@ -28,6 +28,9 @@ type constraintsDoc struct {
 	Tags         *[]string
 	Spaces       *[]string
 	VirtType     *string
+	Spot         *bool
+
+	InstanceAttributes *map[string]string
 }
 
 func (doc constraintsDoc) value() constraints.Value {
@@ -42,6 +45,9 @@ func (doc constraintsDoc) value() constraints.Value {
 		Tags:         doc.Tags,
 		Spaces:       doc.Spaces,
 		VirtType:     doc.VirtType,
+		Spot:         doc.Spot,
+
+		InstanceAttributes: doc.InstanceAttributes,
 	}
 	return result
 }
@@ -58,6 +64,9 @@ func newConstraintsDoc(cons constraints.Value) constraintsDoc {
 		Tags:         cons.Tags,
 		Spaces:       cons.Spaces,
 		VirtType:     cons.VirtType,
+		Spot:         cons.Spot,
+
+		InstanceAttributes: cons.InstanceAttributes,
 	}
 	return result
 }
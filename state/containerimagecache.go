@@ -0,0 +1,328 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/instance"
+)
+
+// ContainerImage describes an image that a machine agent has cached
+// locally for creating containers.
+type ContainerImage struct {
+	// Series is the series of the image.
+	Series string
+
+	// Arch is the architecture of the image.
+	Arch string
+
+	// Fingerprint identifies the image.
+	Fingerprint string
+
+	// Size is the size of the image, in bytes.
+	Size int64
+
+	// LastUsed is when a container was last created from the image,
+	// or when the image was cached if it has not been used.
+	LastUsed time.Time
+}
+
+// ContainerImagePurge is a request for a machine agent to remove
+// images from its container image cache.
+type ContainerImagePurge struct {
+	// Series holds the series of the images to remove. If it is
+	// empty, all images are removed.
+	Series []string
+
+	// Requested is when the purge was last requested. It
+	// distinguishes the request from any made after it.
+	Requested time.Time
+}
+
+// ContainerImageCache describes a machine's container image cache.
+type ContainerImageCache struct {
+	// Images holds the images most recently reported to be in the
+	// cache by the machine agent.
+	Images []ContainerImage
+
+	// Updated is when the images were last reported.
+	Updated time.Time
+
+	// Purge holds the purge waiting to be carried out by the
+	// machine agent, or nil if there is none.
+	Purge *ContainerImagePurge
+}
+
+type containerImageCacheDoc struct {
+	DocID     string                  `bson:"_id"`
+	ModelUUID string                  `bson:"model-uuid"`
+	Images    []containerImageDoc     `bson:"images"`
+	Updated   int64                   `bson:"updated"`
+	Purge     *containerImagePurgeDoc `bson:"purge,omitempty"`
+}
+
+type containerImageDoc struct {
+	Series      string `bson:"series"`
+	Arch        string `bson:"arch"`
+	Fingerprint string `bson:"fingerprint"`
+	Size        int64  `bson:"size"`
+	LastUsed    int64  `bson:"last-used"`
+}
+
+type containerImagePurgeDoc struct {
+	Series    []string `bson:"series,omitempty"`
+	Requested int64    `bson:"requested"`
+}
+
+func (doc *containerImagePurgeDoc) purge() *ContainerImagePurge {
+	if doc == nil {
+		return nil
+	}
+	return &ContainerImagePurge{
+		Series:    doc.Series,
+		Requested: time.Unix(0, doc.Requested).UTC(),
+	}
+}
+
+// ContainerImageCache returns the machine's container image cache, as
+// most recently reported by its agent, along with any purge waiting to
+// be carried out. If nothing has been reported, the cache is empty.
+func (m *Machine) ContainerImageCache() (ContainerImageCache, error) {
+	doc, err := m.containerImageCacheDoc()
+	if errors.IsNotFound(err) {
+		return ContainerImageCache{}, nil
+	} else if err != nil {
+		return ContainerImageCache{}, errors.Trace(err)
+	}
+	cache := ContainerImageCache{
+		Purge: doc.Purge.purge(),
+	}
+	if doc.Updated != 0 {
+		cache.Updated = time.Unix(0, doc.Updated).UTC()
+	}
+	for _, image := range doc.Images {
+		cache.Images = append(cache.Images, ContainerImage{
+			Series:      image.Series,
+			Arch:        image.Arch,
+			Fingerprint: image.Fingerprint,
+			Size:        image.Size,
+			LastUsed:    time.Unix(0, image.LastUsed).UTC(),
+		})
+	}
+	return cache, nil
+}
+
+func (m *Machine) containerImageCacheDoc() (*containerImageCacheDoc, error) {
+	coll, closer := m.st.db().GetCollection(containerImageCachesC)
+	defer closer()
+
+	var doc containerImageCacheDoc
+	err := coll.FindId(m.globalKey()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("container image cache for machine %q", m.Id())
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot read container image cache for machine %q", m.Id())
+	}
+	return &doc, nil
+}
+
+// SetContainerImages records the images in the machine's container
+// image cache, replacing those previously recorded. If purged is not
+// nil, it is the purge that the agent carried out before reporting the
+// images, and it is cleared unless it has been requested again since.
+func (m *Machine) SetContainerImages(images []ContainerImage, purged *ContainerImagePurge) error {
+	imageDocs := make([]containerImageDoc, len(images))
+	for i, image := range images {
+		imageDocs[i] = containerImageDoc{
+			Series:      image.Series,
+			Arch:        image.Arch,
+			Fingerprint: image.Fingerprint,
+			Size:        image.Size,
+			LastUsed:    image.LastUsed.UnixNano(),
+		}
+	}
+	updated := m.st.clock().Now().UnixNano()
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		ops, doc, err := m.containerImageCacheOps()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if doc == nil {
+			return append(ops, txn.Op{
+				C:      containerImageCachesC,
+				Id:     m.st.docID(m.globalKey()),
+				Assert: txn.DocMissing,
+				Insert: &containerImageCacheDoc{
+					DocID:     m.st.docID(m.globalKey()),
+					ModelUUID: m.st.ModelUUID(),
+					Images:    imageDocs,
+					Updated:   updated,
+				},
+			}), nil
+		}
+		update := bson.D{{"$set", bson.D{
+			{"images", imageDocs},
+			{"updated", updated},
+		}}}
+		op := txn.Op{
+			C:      containerImageCachesC,
+			Id:     doc.DocID,
+			Assert: txn.DocExists,
+			Update: update,
+		}
+		if purged != nil && doc.Purge != nil && doc.Purge.Requested == purged.Requested.UnixNano() {
+			op.Assert = bson.D{{"purge.requested", doc.Purge.Requested}}
+			op.Update = append(update, bson.DocElem{"$unset", bson.D{{"purge", nil}}})
+		}
+		return append(ops, op), nil
+	}
+	if err := m.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot set container images for machine %q", m.Id())
+	}
+	return nil
+}
+
+// RequestContainerImagePurge asks the machine agent to remove the
+// images of the given series from its container image cache, or all
+// images if no series are given. The request is combined with any
+// that the agent has not yet carried out.
+func (m *Machine) RequestContainerImagePurge(series ...string) error {
+	requested := m.st.clock().Now().UnixNano()
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		ops, doc, err := m.containerImageCacheOps()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		purge := &containerImagePurgeDoc{
+			Series:    series,
+			Requested: requested,
+		}
+		if doc == nil {
+			return append(ops, txn.Op{
+				C:      containerImageCachesC,
+				Id:     m.st.docID(m.globalKey()),
+				Assert: txn.DocMissing,
+				Insert: &containerImageCacheDoc{
+					DocID:     m.st.docID(m.globalKey()),
+					ModelUUID: m.st.ModelUUID(),
+					Purge:     purge,
+				},
+			}), nil
+		}
+		assert := bson.D{{"purge", bson.D{{"$exists", false}}}}
+		if doc.Purge != nil {
+			assert = bson.D{{"purge.requested", doc.Purge.Requested}}
+			if len(doc.Purge.Series) == 0 || len(series) == 0 {
+				purge.Series = nil
+			} else {
+				purge.Series = set.NewStrings(doc.Purge.Series...).Union(
+					set.NewStrings(series...),
+				).SortedValues()
+			}
+		}
+		return append(ops, txn.Op{
+			C:      containerImageCachesC,
+			Id:     doc.DocID,
+			Assert: assert,
+			Update: bson.D{{"$set", bson.D{{"purge", purge}}}},
+		}), nil
+	}
+	if err := m.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot request container image purge for machine %q", m.Id())
+	}
+	return nil
+}
+
+// containerImageCacheOps returns the operations that assert that the
+// machine is not dead, along with its container image cache document,
+// or nil if it has none.
+func (m *Machine) containerImageCacheOps() ([]txn.Op, *containerImageCacheDoc, error) {
+	if err := m.Refresh(); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if m.doc.Life == Dead {
+		return nil, nil, errors.Errorf("machine is dead")
+	}
+	ops := []txn.Op{{
+		C:      machinesC,
+		Id:     m.doc.DocID,
+		Assert: notDeadDoc,
+	}}
+	doc, err := m.containerImageCacheDoc()
+	if errors.IsNotFound(err) {
+		return ops, nil, nil
+	} else if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return ops, doc, nil
+}
+
+// removeContainerImageCacheOp returns an operation that removes any
+// container image cache recorded for the machine with the given
+// global key.
+func removeContainerImageCacheOp(mb modelBackend, globalKey string) txn.Op {
+	return txn.Op{
+		C:      containerImageCachesC,
+		Id:     mb.docID(globalKey),
+		Remove: true,
+	}
+}
+
+// PendingContainerSeries returns the series of the containers that are
+// expected to be created on the machine soon: those of its containers
+// that have not yet been provisioned, and those of the applications
+// with units waiting to be assigned to new containers on the machine.
+func (m *Machine) PendingContainerSeries() ([]string, error) {
+	series := set.NewStrings()
+	containers, err := m.Containers()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, id := range containers {
+		container, err := m.st.Machine(id)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, err := container.InstanceId(); errors.IsNotProvisioned(err) {
+			series.Add(container.Series())
+		} else if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	assignments, err := m.st.AllUnitAssignments()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, assignment := range assignments {
+		if assignment.Directive != m.Id() || !isContainerScope(assignment.Scope) {
+			continue
+		}
+		unit, err := m.st.Unit(assignment.Unit)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		series.Add(unit.Series())
+	}
+	return series.SortedValues(), nil
+}
+
+// isContainerScope reports whether the placement scope is that of a
+// container type, meaning that the unit is to be placed in a new
+// container.
+func isContainerScope(scope string) bool {
+	_, err := instance.ParseContainerType(scope)
+	return err == nil
+}
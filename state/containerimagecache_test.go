@@ -0,0 +1,171 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ContainerImageCacheSuite struct {
+	ConnSuite
+	clock   *testing.Clock
+	machine *state.Machine
+}
+
+var _ = gc.Suite(&ContainerImageCacheSuite{})
+
+func (s *ContainerImageCacheSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.clock = testing.NewClock(coretesting.NonZeroTime().UTC())
+	err := s.State.SetClockForTesting(s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	s.machine = s.Factory.MakeMachine(c, nil)
+}
+
+func (s *ContainerImageCacheSuite) TestContainerImageCacheEmpty(c *gc.C) {
+	cache, err := s.machine.ContainerImageCache()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cache, jc.DeepEquals, state.ContainerImageCache{})
+}
+
+func (s *ContainerImageCacheSuite) TestSetContainerImages(c *gc.C) {
+	images := []state.ContainerImage{{
+		Series:      "xenial",
+		Arch:        "amd64",
+		Fingerprint: "deadbeef",
+		Size:        300 * 1024 * 1024,
+		LastUsed:    s.clock.Now().Add(-time.Hour),
+	}}
+	err := s.machine.SetContainerImages(images, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	cache, err := s.machine.ContainerImageCache()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cache, jc.DeepEquals, state.ContainerImageCache{
+		Images:  images,
+		Updated: s.clock.Now(),
+	})
+
+	// Setting the images again replaces them.
+	s.clock.Advance(time.Minute)
+	err = s.machine.SetContainerImages(nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	cache, err = s.machine.ContainerImageCache()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cache, jc.DeepEquals, state.ContainerImageCache{
+		Updated: s.clock.Now(),
+	})
+}
+
+func (s *ContainerImageCacheSuite) TestRequestContainerImagePurge(c *gc.C) {
+	err := s.machine.RequestContainerImagePurge("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	s.clock.Advance(time.Minute)
+	err = s.machine.RequestContainerImagePurge("bionic")
+	c.Assert(err, jc.ErrorIsNil)
+	cache, err := s.machine.ContainerImageCache()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cache.Purge, jc.DeepEquals, &state.ContainerImagePurge{
+		Series:    []string{"bionic", "xenial"},
+		Requested: s.clock.Now(),
+	})
+
+	// A purge of all images supersedes purges of some.
+	err = s.machine.RequestContainerImagePurge()
+	c.Assert(err, jc.ErrorIsNil)
+	cache, err = s.machine.ContainerImageCache()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cache.Purge, jc.DeepEquals, &state.ContainerImagePurge{
+		Requested: s.clock.Now(),
+	})
+}
+
+func (s *ContainerImageCacheSuite) TestSetContainerImagesClearsPurge(c *gc.C) {
+	err := s.machine.RequestContainerImagePurge()
+	c.Assert(err, jc.ErrorIsNil)
+	cache, err := s.machine.ContainerImageCache()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.SetContainerImages(nil, cache.Purge)
+	c.Assert(err, jc.ErrorIsNil)
+	cache, err = s.machine.ContainerImageCache()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cache.Purge, gc.IsNil)
+}
+
+func (s *ContainerImageCacheSuite) TestSetContainerImagesKeepsNewerPurge(c *gc.C) {
+	err := s.machine.RequestContainerImagePurge("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	cache, err := s.machine.ContainerImageCache()
+	c.Assert(err, jc.ErrorIsNil)
+	purged := cache.Purge
+
+	s.clock.Advance(time.Minute)
+	err = s.machine.RequestContainerImagePurge("bionic")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.SetContainerImages(nil, purged)
+	c.Assert(err, jc.ErrorIsNil)
+	cache, err = s.machine.ContainerImageCache()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cache.Purge, jc.DeepEquals, &state.ContainerImagePurge{
+		Series:    []string{"bionic", "xenial"},
+		Requested: s.clock.Now(),
+	})
+}
+
+func (s *ContainerImageCacheSuite) TestRemoveMachineRemovesCache(c *gc.C) {
+	err := s.machine.SetContainerImages([]state.ContainerImage{{
+		Series: "xenial", Arch: "amd64", Fingerprint: "deadbeef",
+	}}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	coll, closer := state.GetCollection(s.State, "containerImageCaches")
+	defer closer()
+	n, err := coll.Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(n, gc.Equals, 0)
+}
+
+func (s *ContainerImageCacheSuite) TestPendingContainerSeries(c *gc.C) {
+	_, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "xenial",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, s.machine.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Provisioned containers need no images.
+	provisioned, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "trusty",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, s.machine.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	err = provisioned.SetProvisioned("inst-id", "nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	charm := s.AddTestingCharm(c, "dummy")
+	_, err = s.State.AddApplication(state.AddApplicationArgs{
+		Name: "dummy", Charm: charm, NumUnits: 2,
+		Placement: []*instance.Placement{
+			{Scope: "lxd", Directive: s.machine.Id()},
+			{Scope: "lxd", Directive: "42"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	series, err := s.machine.PendingContainerSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(series, jc.DeepEquals, []string{"quantal", "xenial"})
+}
@@ -41,6 +41,7 @@ type Controller struct {
 	policy                 Policy
 	newPolicy              NewPolicyFunc
 	runTransactionObserver RunTransactionObserverFunc
+	raftLeases             *RaftLeases
 }
 
 // Close the connection to the database.
@@ -64,6 +65,7 @@ func (ctlr *Controller) NewState(modelTag names.ModelTag) (*State, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	st.raftLeases = ctlr.raftLeases
 	if err := st.start(ctlr.controllerTag); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -84,3 +86,33 @@ func (st *State) ControllerConfig() (jujucontroller.Config, error) {
 	}
 	return settings.Map(), nil
 }
+
+// SetObjectStoreConfig sets the configuration of the object store in
+// which the controller's blobs are held. Controller agents use the new
+// object store once they have been restarted.
+func (st *State) SetObjectStoreConfig(cfg jujucontroller.ObjectStoreConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	settings, err := readSettings(st.db(), controllersC, controllerSettingsGlobalKey)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for key, value := range map[string]string{
+		jujucontroller.ObjectStoreTypeKey:      cfg.Type,
+		jujucontroller.ObjectStoreEndpointKey:  cfg.Endpoint,
+		jujucontroller.ObjectStoreRegionKey:    cfg.Region,
+		jujucontroller.ObjectStoreBucketKey:    cfg.Bucket,
+		jujucontroller.ObjectStoreAccessKeyKey: cfg.AccessKey,
+		jujucontroller.ObjectStoreSecretKeyKey: cfg.SecretKey,
+		jujucontroller.ObjectStoreTenantKey:    cfg.Tenant,
+	} {
+		if value == "" || cfg.Type == jujucontroller.ObjectStoreGridFS {
+			settings.Delete(key)
+		} else {
+			settings.Set(key, value)
+		}
+	}
+	_, err = settings.Write()
+	return errors.Trace(err)
+}
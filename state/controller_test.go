@@ -25,12 +25,30 @@ func (s *ControllerSuite) TestControllerAndModelConfigInitialisation(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	optional := map[string]bool{
-		controller.IdentityURL:         true,
-		controller.IdentityPublicKey:   true,
-		controller.AutocertURLKey:      true,
-		controller.AutocertDNSNameKey:  true,
-		controller.AllowModelAccessKey: true,
-		controller.MongoMemoryProfile:  true,
+		controller.IdentityURL:            true,
+		controller.IdentityPublicKey:      true,
+		controller.AutocertURLKey:         true,
+		controller.AutocertDNSNameKey:     true,
+		controller.AllowModelAccessKey:    true,
+		controller.StickyModelRoutingKey:  true,
+		controller.GRPCPort:               true,
+		controller.MongoMemoryProfile:     true,
+		controller.MongoCacheSize:         true,
+		controller.MongoOplogSize:         true,
+		controller.LDAPURLKey:             true,
+		controller.LDAPBindDNKey:          true,
+		controller.LDAPBindPasswordKey:    true,
+		controller.LDAPBaseDNKey:          true,
+		controller.LDAPUserFilterKey:      true,
+		controller.LDAPUserDomainKey:      true,
+		controller.LDAPGroupAccessKey:     true,
+		controller.LDAPCacheTTLKey:        true,
+		controller.CrashReportQuota:       true,
+		controller.DBCompactionWindowsKey: true,
+		controller.BreakGlassUsersKey:     true,
+	}
+	for _, attr := range controller.ObjectStoreConfigAttributes {
+		optional[attr] = true
 	}
 	for _, controllerAttr := range controller.ControllerOnlyConfigAttributes {
 		v, ok := controllerSettings.Get(controllerAttr)
@@ -55,6 +73,34 @@ func (s *ControllerSuite) TestControllerConfig(c *gc.C) {
 	c.Assert(cfg["controller-uuid"], gc.Equals, s.State.ControllerUUID())
 }
 
+func (s *ControllerSuite) TestSetObjectStoreConfig(c *gc.C) {
+	storeCfg := controller.ObjectStoreConfig{
+		Type:      controller.ObjectStoreS3,
+		Endpoint:  "http://10.0.0.1:9000",
+		Bucket:    "juju",
+		AccessKey: "access",
+		SecretKey: "secret",
+	}
+	err := s.State.SetObjectStoreConfig(storeCfg)
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.ObjectStore(), jc.DeepEquals, storeCfg)
+
+	err = s.State.SetObjectStoreConfig(controller.ObjectStoreConfig{Type: controller.ObjectStoreGridFS})
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err = s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.ObjectStore(), jc.DeepEquals, controller.ObjectStoreConfig{Type: controller.ObjectStoreGridFS})
+	_, ok := cfg[controller.ObjectStoreBucketKey]
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *ControllerSuite) TestSetObjectStoreConfigInvalid(c *gc.C) {
+	err := s.State.SetObjectStoreConfig(controller.ObjectStoreConfig{Type: controller.ObjectStoreS3})
+	c.Assert(err, gc.ErrorMatches, "object-store-endpoint or object-store-region must be set for S3")
+}
+
 func (s *ControllerSuite) TestPing(c *gc.C) {
 	c.Assert(s.Controller.Ping(), gc.IsNil)
 	gitjujutesting.MgoServer.Restart()
@@ -0,0 +1,52 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/controllercharm"
+)
+
+// AddControllerApplication adds the application that models the
+// controller's API server to the controller model. The application
+// uses the built-in controller charm for the given series, and its
+// config records the given API port.
+func (st *State) AddControllerApplication(series string, apiPort int) (*Application, error) {
+	if !st.IsController() {
+		return nil, errors.NotSupportedf("adding controller application outside the controller model")
+	}
+	cfg := controllercharm.Config{APIPort: apiPort}
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	curl := controllercharm.URL(series)
+	ch, err := st.Charm(curl)
+	if errors.IsNotFound(err) {
+		ch, err = st.AddCharm(CharmInfo{
+			Charm: controllercharm.New(),
+			ID:    curl,
+		})
+	}
+	if err != nil {
+		return nil, errors.Annotate(err, "adding controller charm")
+	}
+	app, err := st.AddApplication(AddApplicationArgs{
+		Name:        controllercharm.ApplicationName,
+		Series:      series,
+		Charm:       ch,
+		CharmConfig: cfg.Settings(),
+	})
+	return app, errors.Trace(err)
+}
+
+// ControllerApplication returns the application that models the
+// controller's API server.
+func (st *State) ControllerApplication() (*Application, error) {
+	if !st.IsController() {
+		return nil, errors.NotSupportedf("controller application outside the controller model")
+	}
+	app, err := st.Application(controllercharm.ApplicationName)
+	return app, errors.Trace(err)
+}
@@ -0,0 +1,89 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type ControllerApplicationSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ControllerApplicationSuite{})
+
+func (s *ControllerApplicationSuite) TestAddControllerApplication(c *gc.C) {
+	app, err := s.State.AddControllerApplication("xenial", 17071)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.Name(), gc.Equals, "controller")
+	c.Assert(app.Series(), gc.Equals, "xenial")
+
+	curl, _ := app.CharmURL()
+	c.Assert(curl.String(), gc.Equals, "local:xenial/juju-controller-0")
+	settings, err := app.CharmConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings["api-port"], gc.Equals, int64(17071))
+	c.Assert(settings["api-extra-sans"], gc.Equals, "")
+	c.Assert(settings["tls-cert-generation"], gc.Equals, int64(0))
+
+	found, err := s.State.ControllerApplication()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found.Name(), gc.Equals, app.Name())
+}
+
+func (s *ControllerApplicationSuite) TestAddControllerApplicationTwice(c *gc.C) {
+	_, err := s.State.AddControllerApplication("xenial", 17070)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddControllerApplication("xenial", 17070)
+	c.Assert(err, gc.ErrorMatches, `cannot add application "controller": application already exists`)
+}
+
+func (s *ControllerApplicationSuite) TestAddControllerApplicationInvalidPort(c *gc.C) {
+	_, err := s.State.AddControllerApplication("xenial", 0)
+	c.Assert(err, gc.ErrorMatches, "api-port 0 not valid")
+}
+
+func (s *ControllerApplicationSuite) TestAddControllerApplicationHostedModel(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	_, err := st.AddControllerApplication("xenial", 17070)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = st.ControllerApplication()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *ControllerApplicationSuite) TestControllerApplicationNotFound(c *gc.C) {
+	_, err := s.State.ControllerApplication()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ControllerApplicationSuite) TestWatchCharmConfig(c *gc.C) {
+	app, err := s.State.AddControllerApplication("xenial", 17070)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w, err := app.WatchCharmConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	// Initial event.
+	wc.AssertOneChange()
+
+	err = app.UpdateCharmConfig(charm.Settings{"api-extra-sans": "api.example.com"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Changing the config of another application is not reported.
+	other := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "wordpress"}),
+	})
+	err = other.UpdateCharmConfig(charm.Settings{"blog-title": "oh hai"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
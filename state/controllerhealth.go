@@ -0,0 +1,124 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// ControllerHealth holds the health most recently reported by a
+// controller machine.
+type ControllerHealth struct {
+	// MachineId is the id of the controller machine.
+	MachineId string
+
+	// Updated is the time at which the health was reported.
+	Updated time.Time
+
+	// DiskFreeBytes and DiskTotalBytes describe the space on the
+	// disk holding the controller's data directory.
+	DiskFreeBytes  uint64
+	DiskTotalBytes uint64
+
+	// APIConnections is the number of connections the controller's
+	// apiserver was serving.
+	APIConnections int64
+
+	// Workers holds the states of the controller's critical
+	// workers.
+	Workers []WorkerHealth
+}
+
+// WorkerHealth holds the state of a single worker running in a
+// controller agent.
+type WorkerHealth struct {
+	// Name is the name of the worker's manifold.
+	Name string
+
+	// State is the state of the worker as reported by the
+	// dependency engine, e.g. "started" or "stopped".
+	State string
+
+	// Error holds the error with which the worker last stopped,
+	// if any.
+	Error string
+}
+
+type controllerHealthDoc struct {
+	MachineId      string            `bson:"_id"`
+	Updated        int64             `bson:"updated"`
+	DiskFreeBytes  uint64            `bson:"disk-free"`
+	DiskTotalBytes uint64            `bson:"disk-total"`
+	APIConnections int64             `bson:"api-connections"`
+	Workers        []workerHealthDoc `bson:"workers,omitempty"`
+}
+
+type workerHealthDoc struct {
+	Name  string `bson:"name"`
+	State string `bson:"state"`
+	Error string `bson:"error,omitempty"`
+}
+
+// SetControllerHealth records the health of a controller machine,
+// replacing any previously reported.
+func (st *State) SetControllerHealth(health ControllerHealth) error {
+	if health.MachineId == "" {
+		return errors.NotValidf("empty machine id")
+	}
+	coll, closer := st.db().GetCollection(controllerHealthC)
+	defer closer()
+
+	doc := controllerHealthDoc{
+		MachineId:      health.MachineId,
+		Updated:        health.Updated.UnixNano(),
+		DiskFreeBytes:  health.DiskFreeBytes,
+		DiskTotalBytes: health.DiskTotalBytes,
+		APIConnections: health.APIConnections,
+	}
+	for _, w := range health.Workers {
+		doc.Workers = append(doc.Workers, workerHealthDoc{
+			Name:  w.Name,
+			State: w.State,
+			Error: w.Error,
+		})
+	}
+	_, err := coll.Writeable().UpsertId(doc.MachineId, doc)
+	if err != nil {
+		return errors.Annotatef(err, "cannot record health of controller machine %q", health.MachineId)
+	}
+	return nil
+}
+
+// AllControllerHealth returns the health most recently reported by
+// each controller machine, ordered by machine id.
+func (st *State) AllControllerHealth() ([]ControllerHealth, error) {
+	coll, closer := st.db().GetCollection(controllerHealthC)
+	defer closer()
+
+	var docs []controllerHealthDoc
+	if err := coll.Find(nil).Sort("_id").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read controller health")
+	}
+	result := make([]ControllerHealth, len(docs))
+	for i, doc := range docs {
+		health := ControllerHealth{
+			MachineId:      doc.MachineId,
+			Updated:        time.Unix(0, doc.Updated).UTC(),
+			DiskFreeBytes:  doc.DiskFreeBytes,
+			DiskTotalBytes: doc.DiskTotalBytes,
+			APIConnections: doc.APIConnections,
+		}
+		for _, w := range doc.Workers {
+			health.Workers = append(health.Workers, WorkerHealth{
+				Name:  w.Name,
+				State: w.State,
+				Error: w.Error,
+			})
+		}
+		result[i] = health
+	}
+	return result, nil
+}
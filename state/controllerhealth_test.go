@@ -0,0 +1,83 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type controllerHealthSuite struct {
+	statetesting.StateSuite
+}
+
+var _ = gc.Suite(&controllerHealthSuite{})
+
+func (s *controllerHealthSuite) TestAllControllerHealthEmpty(c *gc.C) {
+	health, err := s.State.AllControllerHealth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(health, gc.HasLen, 0)
+}
+
+func (s *controllerHealthSuite) TestSetControllerHealth(c *gc.C) {
+	updated := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	health1 := state.ControllerHealth{
+		MachineId:      "1",
+		Updated:        updated,
+		DiskFreeBytes:  100,
+		DiskTotalBytes: 1000,
+		APIConnections: 3,
+	}
+	health0 := state.ControllerHealth{
+		MachineId:      "0",
+		Updated:        updated,
+		DiskFreeBytes:  200,
+		DiskTotalBytes: 1000,
+		APIConnections: 5,
+		Workers: []state.WorkerHealth{
+			{Name: "api-server", State: "started"},
+			{Name: "peer-grouper", State: "stopped", Error: "kaboom"},
+		},
+	}
+	err := s.State.SetControllerHealth(health1)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetControllerHealth(health0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	all, err := s.State.AllControllerHealth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, jc.DeepEquals, []state.ControllerHealth{health0, health1})
+}
+
+func (s *controllerHealthSuite) TestSetControllerHealthReplaces(c *gc.C) {
+	updated := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	err := s.State.SetControllerHealth(state.ControllerHealth{
+		MachineId:      "0",
+		Updated:        updated,
+		APIConnections: 3,
+		Workers:        []state.WorkerHealth{{Name: "api-server", State: "started"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	health := state.ControllerHealth{
+		MachineId:      "0",
+		Updated:        updated.Add(time.Minute),
+		APIConnections: 4,
+	}
+	err = s.State.SetControllerHealth(health)
+	c.Assert(err, jc.ErrorIsNil)
+
+	all, err := s.State.AllControllerHealth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, jc.DeepEquals, []state.ControllerHealth{health})
+}
+
+func (s *controllerHealthSuite) TestSetControllerHealthEmptyMachineId(c *gc.C) {
+	err := s.State.SetControllerHealth(state.ControllerHealth{})
+	c.Assert(err, gc.ErrorMatches, "empty machine id not valid")
+}
@@ -0,0 +1,262 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state/storage"
+)
+
+// crashReportsSequence is the name of the sequence from which crash
+// reports are numbered.
+const crashReportsSequence = "crashreports"
+
+// QuotaCrashReportBytes names the limit on the total size of a
+// model's crash reports, as reported in quota exceeded errors.
+const QuotaCrashReportBytes = "crash report bytes"
+
+// CrashReport describes a bundle of diagnostics collected by an agent
+// when it crashed.
+type CrashReport struct {
+	// Id identifies the report within its model.
+	Id string
+
+	// Agent is the tag of the agent that crashed.
+	Agent string
+
+	// Time is when the agent crashed.
+	Time time.Time
+
+	// Uploaded is when the report was stored.
+	Uploaded time.Time
+
+	// Reason describes the crash, typically with the panic message.
+	Reason string
+
+	// Size is the size of the report's bundle in bytes.
+	Size int64
+}
+
+// CrashReportArgs holds the details of a crash report to store.
+type CrashReportArgs struct {
+	// Agent is the tag of the agent that crashed. It must be a
+	// machine or unit tag.
+	Agent string
+
+	// Time is when the agent crashed.
+	Time time.Time
+
+	// Reason describes the crash.
+	Reason string
+
+	// Bundle holds the contents of the report's bundle, which is
+	// Size bytes long.
+	Bundle io.Reader
+	Size   int64
+}
+
+// Validate returns an error if the arguments are not valid.
+func (args CrashReportArgs) Validate() error {
+	tag, err := names.ParseTag(args.Agent)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch tag.(type) {
+	case names.MachineTag, names.UnitTag:
+	default:
+		return errors.NotValidf("crash report from %q", args.Agent)
+	}
+	if args.Bundle == nil || args.Size <= 0 {
+		return errors.NotValidf("empty crash report")
+	}
+	return nil
+}
+
+type crashReportDoc struct {
+	DocID       string `bson:"_id"`
+	ModelUUID   string `bson:"model-uuid"`
+	Seq         int64  `bson:"seq"`
+	Agent       string `bson:"agent"`
+	Time        int64  `bson:"time"`
+	Uploaded    int64  `bson:"uploaded"`
+	Reason      string `bson:"reason,omitempty"`
+	Size        int64  `bson:"size"`
+	StoragePath string `bson:"storage-path"`
+}
+
+func (doc *crashReportDoc) report() CrashReport {
+	return CrashReport{
+		Id:       strconv.FormatInt(doc.Seq, 10),
+		Agent:    doc.Agent,
+		Time:     time.Unix(0, doc.Time).UTC(),
+		Uploaded: time.Unix(0, doc.Uploaded).UTC(),
+		Reason:   doc.Reason,
+		Size:     doc.Size,
+	}
+}
+
+// AddCrashReport stores a crash report for the model. If quotaBytes is
+// positive, the oldest of the model's reports are removed until the
+// new report fits within that total size; a report that is larger than
+// quotaBytes by itself is refused with an *ErrQuotaExceeded error.
+func (st *State) AddCrashReport(args CrashReportArgs, quotaBytes int64) (CrashReport, error) {
+	if err := args.Validate(); err != nil {
+		return CrashReport{}, errors.Trace(err)
+	}
+	if quotaBytes > 0 {
+		if err := st.makeRoomForCrashReport(args.Size, quotaBytes); err != nil {
+			return CrashReport{}, errors.Trace(err)
+		}
+	}
+
+	next, err := sequence(st, crashReportsSequence)
+	if err != nil {
+		return CrashReport{}, errors.Trace(err)
+	}
+	// Number reports from 1, to match the other sequences users see.
+	seq := int64(next) + 1
+	doc := &crashReportDoc{
+		DocID:       st.docID(strconv.FormatInt(seq, 10)),
+		ModelUUID:   st.ModelUUID(),
+		Seq:         seq,
+		Agent:       args.Agent,
+		Time:        args.Time.UnixNano(),
+		Uploaded:    st.clock().Now().UnixNano(),
+		Reason:      args.Reason,
+		Size:        args.Size,
+		StoragePath: fmt.Sprintf("crashreports/%d", seq),
+	}
+	stor := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+	if err := stor.Put(doc.StoragePath, args.Bundle, args.Size); err != nil {
+		return CrashReport{}, errors.Annotate(err, "cannot store crash report")
+	}
+	reports, closer := st.db().GetCollection(crashReportsC)
+	defer closer()
+	if err := reports.Writeable().Insert(doc); err != nil {
+		if err := stor.Remove(doc.StoragePath); err != nil {
+			logger.Warningf("cannot remove unrecorded crash report %q: %v", doc.StoragePath, err)
+		}
+		return CrashReport{}, errors.Annotate(err, "cannot record crash report")
+	}
+	return doc.report(), nil
+}
+
+// makeRoomForCrashReport removes the model's oldest crash reports
+// until a new report of the given size fits within quotaBytes.
+func (st *State) makeRoomForCrashReport(size, quotaBytes int64) error {
+	docs, err := st.crashReportDocs()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var used int64
+	for _, doc := range docs {
+		used += doc.Size
+	}
+	if size > quotaBytes {
+		return &ErrQuotaExceeded{
+			Resource:  QuotaCrashReportBytes,
+			Limit:     int(quotaBytes),
+			Used:      int(used),
+			Requested: int(size),
+		}
+	}
+	for _, doc := range docs {
+		if used+size <= quotaBytes {
+			break
+		}
+		logger.Infof("removing crash report %d from %s to stay within quota", doc.Seq, doc.Agent)
+		if err := st.removeCrashReport(doc); err != nil {
+			return errors.Trace(err)
+		}
+		used -= doc.Size
+	}
+	return nil
+}
+
+// CrashReports returns the model's crash reports, oldest first.
+func (st *State) CrashReports() ([]CrashReport, error) {
+	docs, err := st.crashReportDocs()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]CrashReport, len(docs))
+	for i, doc := range docs {
+		result[i] = doc.report()
+	}
+	return result, nil
+}
+
+// CrashReport returns the crash report with the given id, and a reader
+// for its bundle which the caller must close.
+func (st *State) CrashReport(id string) (CrashReport, io.ReadCloser, error) {
+	doc, err := st.crashReportDoc(id)
+	if err != nil {
+		return CrashReport{}, nil, errors.Trace(err)
+	}
+	stor := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+	r, _, err := stor.Get(doc.StoragePath)
+	if err != nil {
+		return CrashReport{}, nil, errors.Annotatef(err, "cannot read crash report %q", id)
+	}
+	return doc.report(), r, nil
+}
+
+// RemoveCrashReport removes the crash report with the given id.
+func (st *State) RemoveCrashReport(id string) error {
+	doc, err := st.crashReportDoc(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(st.removeCrashReport(doc))
+}
+
+func (st *State) removeCrashReport(doc crashReportDoc) error {
+	stor := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+	if err := stor.Remove(doc.StoragePath); err != nil && !errors.IsNotFound(err) {
+		return errors.Annotatef(err, "cannot remove crash report %d", doc.Seq)
+	}
+	reports, closer := st.db().GetCollection(crashReportsC)
+	defer closer()
+	err := reports.Writeable().RemoveId(doc.DocID)
+	if err != nil && err != mgo.ErrNotFound {
+		return errors.Annotatef(err, "cannot remove crash report %d", doc.Seq)
+	}
+	return nil
+}
+
+func (st *State) crashReportDocs() ([]crashReportDoc, error) {
+	reports, closer := st.db().GetCollection(crashReportsC)
+	defer closer()
+	var docs []crashReportDoc
+	if err := reports.Find(nil).Sort("seq").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get crash reports")
+	}
+	return docs, nil
+}
+
+func (st *State) crashReportDoc(id string) (crashReportDoc, error) {
+	seq, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return crashReportDoc{}, errors.NotValidf("crash report id %q", id)
+	}
+	reports, closer := st.db().GetCollection(crashReportsC)
+	defer closer()
+	var doc crashReportDoc
+	err = reports.Find(bson.D{{"seq", seq}}).One(&doc)
+	if err == mgo.ErrNotFound {
+		return crashReportDoc{}, errors.NotFoundf("crash report %q", id)
+	} else if err != nil {
+		return crashReportDoc{}, errors.Annotatef(err, "cannot get crash report %q", id)
+	}
+	return doc, nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type crashReportsSuite struct {
+	statetesting.StateSuite
+	clock *testing.Clock
+}
+
+var _ = gc.Suite(&crashReportsSuite{})
+
+func (s *crashReportsSuite) SetUpTest(c *gc.C) {
+	s.StateSuite.SetUpTest(c)
+	s.clock = testing.NewClock(coretesting.NonZeroTime().UTC())
+	err := s.State.SetClockForTesting(s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *crashReportsSuite) addReport(c *gc.C, agent, content string, quota int64) state.CrashReport {
+	report, err := s.State.AddCrashReport(state.CrashReportArgs{
+		Agent:  agent,
+		Time:   s.clock.Now().Add(-time.Minute),
+		Reason: "panic: " + content,
+		Bundle: strings.NewReader(content),
+		Size:   int64(len(content)),
+	}, quota)
+	c.Assert(err, jc.ErrorIsNil)
+	return report
+}
+
+func reportIds(reports []state.CrashReport) []string {
+	ids := make([]string, len(reports))
+	for i, report := range reports {
+		ids[i] = report.Id
+	}
+	return ids
+}
+
+func (s *crashReportsSuite) TestAddCrashReport(c *gc.C) {
+	report := s.addReport(c, "machine-0", "boom", 0)
+	c.Assert(report, jc.DeepEquals, state.CrashReport{
+		Id:       "1",
+		Agent:    "machine-0",
+		Time:     s.clock.Now().Add(-time.Minute),
+		Uploaded: s.clock.Now(),
+		Reason:   "panic: boom",
+		Size:     4,
+	})
+
+	reports, err := s.State.CrashReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reports, jc.DeepEquals, []state.CrashReport{report})
+
+	got, r, err := s.State.CrashReport("1")
+	c.Assert(err, jc.ErrorIsNil)
+	defer r.Close()
+	c.Assert(got, jc.DeepEquals, report)
+	content, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(content), gc.Equals, "boom")
+}
+
+func (s *crashReportsSuite) TestAddCrashReportInvalid(c *gc.C) {
+	_, err := s.State.AddCrashReport(state.CrashReportArgs{
+		Agent:  "user-bob",
+		Bundle: strings.NewReader("boom"),
+		Size:   4,
+	}, 0)
+	c.Assert(err, gc.ErrorMatches, `crash report from "user-bob" not valid`)
+
+	_, err = s.State.AddCrashReport(state.CrashReportArgs{
+		Agent: "unit-app-0",
+	}, 0)
+	c.Assert(err, gc.ErrorMatches, `empty crash report not valid`)
+}
+
+func (s *crashReportsSuite) TestAddCrashReportRemovesOldestToStayWithinQuota(c *gc.C) {
+	s.addReport(c, "machine-0", "aaaa", 10)
+	s.addReport(c, "machine-1", "bbbb", 10)
+	s.addReport(c, "unit-app-0", "cccc", 10)
+
+	reports, err := s.State.CrashReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reportIds(reports), jc.DeepEquals, []string{"2", "3"})
+
+	_, _, err = s.State.CrashReport("1")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *crashReportsSuite) TestAddCrashReportLargerThanQuota(c *gc.C) {
+	s.addReport(c, "machine-0", "aaaa", 10)
+	_, err := s.State.AddCrashReport(state.CrashReportArgs{
+		Agent:  "machine-0",
+		Bundle: strings.NewReader("bbbbbbbbbbbb"),
+		Size:   12,
+	}, 10)
+	c.Assert(err, jc.Satisfies, state.IsQuotaExceededError)
+	c.Assert(err, gc.ErrorMatches, "model quota exceeded: 12 crash report bytes requested, 4 of 10 already in use")
+
+	// The existing report is kept.
+	reports, err := s.State.CrashReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reportIds(reports), jc.DeepEquals, []string{"1"})
+}
+
+func (s *crashReportsSuite) TestRemoveCrashReport(c *gc.C) {
+	s.addReport(c, "machine-0", "aaaa", 0)
+	err := s.State.RemoveCrashReport("1")
+	c.Assert(err, jc.ErrorIsNil)
+	reports, err := s.State.CrashReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reports, gc.HasLen, 0)
+
+	err = s.State.RemoveCrashReport("1")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = s.State.RemoveCrashReport("one")
+	c.Assert(err, gc.ErrorMatches, `crash report id "one" not valid`)
+}
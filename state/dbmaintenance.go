@@ -0,0 +1,288 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// compactionClaimId is the id of the document in the dbMaintenance
+// collection that records which controller machine is compacting its
+// database member.
+const compactionClaimId = "compaction"
+
+// DatabaseReport holds the statistics most recently reported by a
+// controller machine for its member of the controller's database
+// replica set.
+type DatabaseReport struct {
+	// MachineId is the id of the controller machine.
+	MachineId string
+
+	// Updated is the time at which the report was made.
+	Updated time.Time
+
+	// Primary is true if the member was the replica set's primary.
+	Primary bool
+
+	// Collections holds the sizes of the juju database's collections.
+	Collections []CollectionStats
+
+	// UnusedIndexes holds the indexes that have not been used by
+	// any operation on the member since it started.
+	UnusedIndexes []IndexUsage
+
+	// CollectionScans holds the query patterns that the member's
+	// profiler saw answered by scanning whole collections.
+	CollectionScans []QueryPattern
+
+	// LastCompaction describes the member's most recent compaction,
+	// if it has been compacted.
+	LastCompaction *Compaction
+}
+
+// CollectionStats holds the sizes of a database collection.
+type CollectionStats struct {
+	Name             string
+	Count            int64
+	SizeBytes        int64
+	StorageSizeBytes int64
+	IndexSizeBytes   int64
+}
+
+// IndexUsage identifies an index, and the time since which its use
+// has been counted.
+type IndexUsage struct {
+	Collection string
+	Name       string
+	Since      time.Time
+}
+
+// QueryPattern describes the queries on a collection that filtered on
+// the same set of fields.
+type QueryPattern struct {
+	Collection string
+	Fields     []string
+	Count      int
+}
+
+// Compaction describes the compaction of a database member.
+type Compaction struct {
+	Started     time.Time
+	Finished    time.Time
+	Collections []string
+	Error       string
+}
+
+type databaseReportDoc struct {
+	MachineId       string              `bson:"_id"`
+	Updated         int64               `bson:"updated"`
+	Primary         bool                `bson:"primary"`
+	Collections     []collectionStatDoc `bson:"collections,omitempty"`
+	UnusedIndexes   []indexUsageDoc     `bson:"unused-indexes,omitempty"`
+	CollectionScans []queryPatternDoc   `bson:"collection-scans,omitempty"`
+	LastCompaction  *compactionDoc      `bson:"last-compaction,omitempty"`
+}
+
+type collectionStatDoc struct {
+	Name        string `bson:"name"`
+	Count       int64  `bson:"count"`
+	Size        int64  `bson:"size"`
+	StorageSize int64  `bson:"storage-size"`
+	IndexSize   int64  `bson:"index-size"`
+}
+
+type indexUsageDoc struct {
+	Collection string `bson:"collection"`
+	Name       string `bson:"name"`
+	Since      int64  `bson:"since"`
+}
+
+type queryPatternDoc struct {
+	Collection string   `bson:"collection"`
+	Fields     []string `bson:"fields"`
+	Count      int      `bson:"count"`
+}
+
+type compactionDoc struct {
+	Started     int64    `bson:"started"`
+	Finished    int64    `bson:"finished"`
+	Collections []string `bson:"collections,omitempty"`
+	Error       string   `bson:"error,omitempty"`
+}
+
+type compactionClaimDoc struct {
+	Id      string `bson:"_id"`
+	Holder  string `bson:"holder"`
+	Expires int64  `bson:"expires"`
+}
+
+// SetDatabaseReport records the statistics of a controller machine's
+// database member, replacing any previously reported.
+func (st *State) SetDatabaseReport(report DatabaseReport) error {
+	if report.MachineId == "" {
+		return errors.NotValidf("empty machine id")
+	}
+	if report.MachineId == compactionClaimId {
+		return errors.NotValidf("machine id %q", report.MachineId)
+	}
+	coll, closer := st.db().GetCollection(dbMaintenanceC)
+	defer closer()
+
+	doc := databaseReportDoc{
+		MachineId: report.MachineId,
+		Updated:   report.Updated.UnixNano(),
+		Primary:   report.Primary,
+	}
+	for _, stats := range report.Collections {
+		doc.Collections = append(doc.Collections, collectionStatDoc{
+			Name:        stats.Name,
+			Count:       stats.Count,
+			Size:        stats.SizeBytes,
+			StorageSize: stats.StorageSizeBytes,
+			IndexSize:   stats.IndexSizeBytes,
+		})
+	}
+	for _, index := range report.UnusedIndexes {
+		doc.UnusedIndexes = append(doc.UnusedIndexes, indexUsageDoc{
+			Collection: index.Collection,
+			Name:       index.Name,
+			Since:      index.Since.UnixNano(),
+		})
+	}
+	for _, pattern := range report.CollectionScans {
+		doc.CollectionScans = append(doc.CollectionScans, queryPatternDoc{
+			Collection: pattern.Collection,
+			Fields:     pattern.Fields,
+			Count:      pattern.Count,
+		})
+	}
+	if c := report.LastCompaction; c != nil {
+		doc.LastCompaction = &compactionDoc{
+			Started:     c.Started.UnixNano(),
+			Finished:    c.Finished.UnixNano(),
+			Collections: c.Collections,
+			Error:       c.Error,
+		}
+	}
+	_, err := coll.Writeable().UpsertId(doc.MachineId, doc)
+	if err != nil {
+		return errors.Annotatef(err, "cannot record database report of controller machine %q", report.MachineId)
+	}
+	return nil
+}
+
+// AllDatabaseReports returns the statistics most recently reported
+// by each controller machine for its database member, ordered by
+// machine id.
+func (st *State) AllDatabaseReports() ([]DatabaseReport, error) {
+	coll, closer := st.db().GetCollection(dbMaintenanceC)
+	defer closer()
+
+	var docs []databaseReportDoc
+	query := bson.D{{"_id", bson.D{{"$ne", compactionClaimId}}}}
+	if err := coll.Find(query).Sort("_id").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read database reports")
+	}
+	result := make([]DatabaseReport, len(docs))
+	for i, doc := range docs {
+		report := DatabaseReport{
+			MachineId: doc.MachineId,
+			Updated:   time.Unix(0, doc.Updated).UTC(),
+			Primary:   doc.Primary,
+		}
+		for _, stats := range doc.Collections {
+			report.Collections = append(report.Collections, CollectionStats{
+				Name:             stats.Name,
+				Count:            stats.Count,
+				SizeBytes:        stats.Size,
+				StorageSizeBytes: stats.StorageSize,
+				IndexSizeBytes:   stats.IndexSize,
+			})
+		}
+		for _, index := range doc.UnusedIndexes {
+			report.UnusedIndexes = append(report.UnusedIndexes, IndexUsage{
+				Collection: index.Collection,
+				Name:       index.Name,
+				Since:      time.Unix(0, index.Since).UTC(),
+			})
+		}
+		for _, pattern := range doc.CollectionScans {
+			report.CollectionScans = append(report.CollectionScans, QueryPattern{
+				Collection: pattern.Collection,
+				Fields:     pattern.Fields,
+				Count:      pattern.Count,
+			})
+		}
+		if c := doc.LastCompaction; c != nil {
+			report.LastCompaction = &Compaction{
+				Started:     time.Unix(0, c.Started).UTC(),
+				Finished:    time.Unix(0, c.Finished).UTC(),
+				Collections: c.Collections,
+				Error:       c.Error,
+			}
+		}
+		result[i] = report
+	}
+	return result, nil
+}
+
+// ClaimDatabaseCompaction claims, or extends the claim of, the right
+// of the given controller machine to compact its database member for
+// the given duration. It returns false if another machine holds an
+// unexpired claim, so that only one member is compacted at a time.
+func (st *State) ClaimDatabaseCompaction(machineId string, duration time.Duration) (bool, error) {
+	coll, closer := st.db().GetCollection(dbMaintenanceC)
+	defer closer()
+
+	now := st.clock().Now()
+	expires := now.Add(duration).UnixNano()
+	err := coll.Writeable().Update(
+		bson.D{
+			{"_id", compactionClaimId},
+			{"$or", []bson.D{
+				{{"holder", machineId}},
+				{{"expires", bson.D{{"$lte", now.UnixNano()}}}},
+			}},
+		},
+		bson.D{{"$set", bson.D{
+			{"holder", machineId},
+			{"expires", expires},
+		}}},
+	)
+	if err == mgo.ErrNotFound {
+		// Either there is no claim, or another machine holds it.
+		err = coll.Writeable().Insert(compactionClaimDoc{
+			Id:      compactionClaimId,
+			Holder:  machineId,
+			Expires: expires,
+		})
+		if mgo.IsDup(err) {
+			return false, nil
+		}
+	}
+	if err != nil {
+		return false, errors.Annotate(err, "cannot claim database compaction")
+	}
+	return true, nil
+}
+
+// ReleaseDatabaseCompaction releases the given controller machine's
+// claim to compact its database member, if it holds it.
+func (st *State) ReleaseDatabaseCompaction(machineId string) error {
+	coll, closer := st.db().GetCollection(dbMaintenanceC)
+	defer closer()
+
+	err := coll.Writeable().Remove(bson.D{
+		{"_id", compactionClaimId},
+		{"holder", machineId},
+	})
+	if err != nil && err != mgo.ErrNotFound {
+		return errors.Annotate(err, "cannot release database compaction")
+	}
+	return nil
+}
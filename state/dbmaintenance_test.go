@@ -0,0 +1,134 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type dbMaintenanceSuite struct {
+	statetesting.StateSuite
+}
+
+var _ = gc.Suite(&dbMaintenanceSuite{})
+
+func (s *dbMaintenanceSuite) TestAllDatabaseReportsEmpty(c *gc.C) {
+	reports, err := s.State.AllDatabaseReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reports, gc.HasLen, 0)
+}
+
+func (s *dbMaintenanceSuite) TestSetDatabaseReport(c *gc.C) {
+	updated := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	report1 := state.DatabaseReport{
+		MachineId: "1",
+		Updated:   updated,
+		LastCompaction: &state.Compaction{
+			Started:     updated.Add(-time.Hour),
+			Finished:    updated.Add(-time.Minute),
+			Collections: []string{"txns", "units"},
+		},
+	}
+	report0 := state.DatabaseReport{
+		MachineId: "0",
+		Updated:   updated,
+		Primary:   true,
+		Collections: []state.CollectionStats{{
+			Name:             "txns",
+			Count:            10,
+			SizeBytes:        1000,
+			StorageSizeBytes: 4096,
+			IndexSizeBytes:   512,
+		}},
+		UnusedIndexes: []state.IndexUsage{{
+			Collection: "units",
+			Name:       "model-uuid_1_name_1",
+			Since:      updated.Add(-24 * time.Hour),
+		}},
+		CollectionScans: []state.QueryPattern{{
+			Collection: "statuseshistory",
+			Fields:     []string{"globalkey", "updated"},
+			Count:      3,
+		}},
+	}
+	err := s.State.SetDatabaseReport(report1)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetDatabaseReport(report0)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ClaimDatabaseCompaction("1", time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+
+	all, err := s.State.AllDatabaseReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, jc.DeepEquals, []state.DatabaseReport{report0, report1})
+}
+
+func (s *dbMaintenanceSuite) TestSetDatabaseReportReplaces(c *gc.C) {
+	updated := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	err := s.State.SetDatabaseReport(state.DatabaseReport{
+		MachineId:   "0",
+		Updated:     updated,
+		Collections: []state.CollectionStats{{Name: "txns", Count: 10}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	report := state.DatabaseReport{
+		MachineId: "0",
+		Updated:   updated.Add(time.Minute),
+	}
+	err = s.State.SetDatabaseReport(report)
+	c.Assert(err, jc.ErrorIsNil)
+
+	all, err := s.State.AllDatabaseReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, jc.DeepEquals, []state.DatabaseReport{report})
+}
+
+func (s *dbMaintenanceSuite) TestSetDatabaseReportEmptyMachineId(c *gc.C) {
+	err := s.State.SetDatabaseReport(state.DatabaseReport{})
+	c.Assert(err, gc.ErrorMatches, "empty machine id not valid")
+}
+
+func (s *dbMaintenanceSuite) TestClaimDatabaseCompaction(c *gc.C) {
+	claimed, err := s.State.ClaimDatabaseCompaction("0", time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(claimed, jc.IsTrue)
+
+	// The holder may extend its claim; others may not claim it.
+	claimed, err = s.State.ClaimDatabaseCompaction("0", time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(claimed, jc.IsTrue)
+	claimed, err = s.State.ClaimDatabaseCompaction("1", time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(claimed, jc.IsFalse)
+
+	// Releasing another machine's claim does nothing.
+	err = s.State.ReleaseDatabaseCompaction("1")
+	c.Assert(err, jc.ErrorIsNil)
+	claimed, err = s.State.ClaimDatabaseCompaction("1", time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(claimed, jc.IsFalse)
+
+	err = s.State.ReleaseDatabaseCompaction("0")
+	c.Assert(err, jc.ErrorIsNil)
+	claimed, err = s.State.ClaimDatabaseCompaction("1", time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(claimed, jc.IsTrue)
+}
+
+func (s *dbMaintenanceSuite) TestClaimDatabaseCompactionExpires(c *gc.C) {
+	claimed, err := s.State.ClaimDatabaseCompaction("0", time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(claimed, jc.IsTrue)
+
+	s.Clock.Advance(time.Hour)
+	claimed, err = s.State.ClaimDatabaseCompaction("1", time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(claimed, jc.IsTrue)
+}
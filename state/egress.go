@@ -0,0 +1,69 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/core/egress"
+)
+
+// EgressRules returns the outbound network destinations required by
+// the units assigned to the machine, as declared by their charms. Both
+// the charm a unit is running and its application's charm are taken
+// into account, so that a unit being upgraded can reach the
+// destinations required by either.
+func (m *Machine) EgressRules() ([]egress.Rule, error) {
+	units, err := m.Units()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	seen := set.NewStrings()
+	var ruleSets [][]egress.Rule
+	addCharm := func(curl *charm.URL) error {
+		if curl == nil || seen.Contains(curl.String()) {
+			return nil
+		}
+		seen.Add(curl.String())
+		ch, err := m.st.Charm(curl)
+		if errors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		ruleSets = append(ruleSets, ch.EgressRules())
+		return nil
+	}
+	for _, unit := range units {
+		if unit.Life() == Dead {
+			continue
+		}
+		curl, _ := unit.CharmURL()
+		if err := addCharm(curl); err != nil {
+			return nil, errors.Trace(err)
+		}
+		app, err := unit.Application()
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		curl, _ = app.CharmURL()
+		if err := addCharm(curl); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return egress.Merge(ruleSets...), nil
+}
+
+// WatchEgressRules returns a NotifyWatcher that triggers whenever the
+// egress rules returned by EgressRules may have changed: when units are
+// assigned to or removed from the machine, or upgraded to a new charm.
+// It triggers on changes to any unit in the model, so the rules
+// themselves must be compared to tell whether they have changed.
+func (m *Machine) WatchEgressRules() NotifyWatcher {
+	return newNotifyCollWatcher(m.st, unitsC, isLocalID(m.st))
+}
@@ -0,0 +1,72 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/egress"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type EgressSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&EgressSuite{})
+
+func (s *EgressSuite) TestCharmEgressRules(c *gc.C) {
+	ch := s.AddTestingCharm(c, "egress")
+	c.Assert(ch.EgressRules(), jc.DeepEquals, []egress.Rule{
+		{CIDR: "0.0.0.0/0", Ports: "443/tcp"},
+		{CIDR: "10.20.0.0/16", Ports: "8125/udp"},
+		{CIDR: "10.20.0.0/16", Ports: "9000-9100/tcp"},
+	})
+	c.Assert(s.AddTestingCharm(c, "dummy").EgressRules(), gc.IsNil)
+}
+
+func (s *EgressSuite) TestMachineEgressRules(c *gc.C) {
+	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err := m.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.IsNil)
+
+	app := s.AddTestingApplication(c, "egress", s.AddTestingCharm(c, "egress"))
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(m)
+	c.Assert(err, jc.ErrorIsNil)
+	other := s.AddTestingApplication(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	unit, err = other.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(m)
+	c.Assert(err, jc.ErrorIsNil)
+
+	rules, err = m.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []egress.Rule{
+		{CIDR: "0.0.0.0/0", Ports: "443/tcp"},
+		{CIDR: "10.20.0.0/16", Ports: "8125/udp"},
+		{CIDR: "10.20.0.0/16", Ports: "9000-9100/tcp"},
+	})
+}
+
+func (s *EgressSuite) TestWatchEgressRules(c *gc.C) {
+	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	w := m.WatchEgressRules()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	app := s.AddTestingApplication(c, "egress", s.AddTestingCharm(c, "egress"))
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(m)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/network"
@@ -162,3 +163,118 @@ func IsIncompatibleSeriesError(err interface{}) bool {
 	_, ok := value.(*ErrIncompatibleSeries)
 	return ok
 }
+
+// ErrQuotaExceeded indicates that an operation was refused because it
+// would take the model over one of its quotas.
+type ErrQuotaExceeded struct {
+	// Resource names the limited resource, e.g. QuotaMachines.
+	Resource string
+
+	// Limit is the model's quota for the resource.
+	Limit int
+
+	// Used is the quantity of the resource already in use.
+	Used int
+
+	// Requested is the quantity of the resource the operation would
+	// have added.
+	Requested int
+}
+
+func (e *ErrQuotaExceeded) Error() string {
+	return fmt.Sprintf("model quota exceeded: %d %s requested, %d of %d already in use",
+		e.Requested, e.Resource, e.Used, e.Limit)
+}
+
+// IsQuotaExceededError returns if the given error or its cause is
+// ErrQuotaExceeded.
+func IsQuotaExceededError(err interface{}) bool {
+	if err == nil {
+		return false
+	}
+	// In case of a wrapped error, check the cause first.
+	value := err
+	cause := errors.Cause(err.(error))
+	if cause != nil {
+		value = cause
+	}
+	_, ok := value.(*ErrQuotaExceeded)
+	return ok
+}
+
+// ErrPlacementConflict indicates that units could not be placed on a
+// machine, either because the machine belongs to another model, or
+// because the units already on it use up its resources.
+type ErrPlacementConflict struct {
+	// MachineId is the id of the machine in the model that owns it.
+	MachineId string
+
+	// OwnerModelUUID and OwnerModelName identify the model that owns
+	// the machine, if it is not the model in which the units were to
+	// be placed.
+	OwnerModelUUID string
+	OwnerModelName string
+
+	// Load describes the units on the machine, and the resources
+	// they use.
+	Load MachineLoad
+
+	// Conflicts describes each reason the units cannot be placed on
+	// the machine.
+	Conflicts []string
+}
+
+func (e *ErrPlacementConflict) Error() string {
+	if e.OwnerModelUUID != "" {
+		return fmt.Sprintf("cannot place units on machine %s of model %q: %s",
+			e.MachineId, e.OwnerModelName, strings.Join(e.Conflicts, "; "))
+	}
+	return fmt.Sprintf("cannot place units on machine %s: %s",
+		e.MachineId, strings.Join(e.Conflicts, "; "))
+}
+
+// IsPlacementConflictError returns if the given error or its cause is
+// ErrPlacementConflict.
+func IsPlacementConflictError(err interface{}) bool {
+	if err == nil {
+		return false
+	}
+	// In case of a wrapped error, check the cause first.
+	value := err
+	cause := errors.Cause(err.(error))
+	if cause != nil {
+		value = cause
+	}
+	_, ok := value.(*ErrPlacementConflict)
+	return ok
+}
+
+// ErrInMaintenance indicates that an operation was refused because
+// an entity it affects is in maintenance mode.
+type ErrInMaintenance struct {
+	// Tag identifies the entity in maintenance mode.
+	Tag names.Tag
+
+	// Reason is the reason given for the maintenance mode.
+	Reason string
+}
+
+func (e *ErrInMaintenance) Error() string {
+	return fmt.Sprintf("%s is in maintenance: %s", names.ReadableString(e.Tag), e.Reason)
+}
+
+// IsInMaintenanceError returns if the given error or its cause is
+// ErrInMaintenance.
+func IsInMaintenanceError(err interface{}) bool {
+	if err == nil {
+		return false
+	}
+	// In case of a wrapped error, check the cause first.
+	value := err
+	cause := errors.Cause(err.(error))
+	if cause != nil {
+		value = cause
+	}
+	_, ok := value.(*ErrInMaintenance)
+	return ok
+}
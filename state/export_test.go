@@ -50,6 +50,7 @@ const (
 
 var (
 	BinarystorageNew                     = &binarystorageNew
+	BinarystorageNewShared               = &binarystorageNewShared
 	ImageStorageNewStorage               = &imageStorageNewStorage
 	MachineIdLessThan                    = machineIdLessThan
 	ControllerAvailable                  = &controllerAvailable
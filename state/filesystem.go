@@ -1449,6 +1449,19 @@ func (im *IAASModel) FilesystemStatus(tag names.FilesystemTag) (status.StatusInf
 	return getStatus(im.mb.db(), filesystemGlobalKey(tag.Id()), "filesystem")
 }
 
+// FilesystemStatusHistory returns a slice of at most filter.Size
+// StatusInfo items, or items as old as filter.FromDate, or items newer
+// than now - filter.Delta, representing past statuses of the specified
+// filesystem.
+func (im *IAASModel) FilesystemStatusHistory(tag names.FilesystemTag, filter status.StatusHistoryFilter) ([]status.StatusInfo, error) {
+	args := &statusHistoryArgs{
+		db:        im.mb.db(),
+		globalKey: filesystemGlobalKey(tag.Id()),
+		filter:    filter,
+	}
+	return statusHistory(args)
+}
+
 // SetFilesystemStatus sets the status of the specified filesystem.
 func (im *IAASModel) SetFilesystemStatus(tag names.FilesystemTag, fsStatus status.Status, info string, data map[string]interface{}, updated *time.Time) error {
 	switch fsStatus {
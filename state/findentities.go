@@ -0,0 +1,139 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// BulkEntityFinder is implemented by types that can find many
+// entities with fewer database queries than finding each in turn.
+type BulkEntityFinder interface {
+	EntityFinder
+
+	// FindEntities returns the entities with the given tags, in the
+	// same order as the tags. Where an entity cannot be found, the
+	// entity is nil and the corresponding error is set.
+	FindEntities(tags []names.Tag) ([]Entity, []error)
+}
+
+// FindEntities returns the entities with the given tags, in the same
+// order as the tags. Machines, units and applications are each read
+// with a single query; other entities are found as by FindEntity.
+// Where an entity cannot be found, the entity is nil and the
+// corresponding error is set, satisfying errors.IsNotFound if the
+// entity does not exist.
+func (st *State) FindEntities(tags []names.Tag) ([]Entity, []error) {
+	entities := make([]Entity, len(tags))
+	errs := make([]error, len(tags))
+
+	// Group the tags of the entities that can be read in bulk by
+	// collection, recording where each belongs in the results.
+	indices := map[string]map[string][]int{
+		machinesC:     make(map[string][]int),
+		unitsC:        make(map[string][]int),
+		applicationsC: make(map[string][]int),
+	}
+	for i, tag := range tags {
+		var coll string
+		switch tag.(type) {
+		case names.MachineTag:
+			coll = machinesC
+		case names.UnitTag:
+			coll = unitsC
+		case names.ApplicationTag:
+			coll = applicationsC
+		default:
+			entities[i], errs[i] = st.FindEntity(tag)
+			continue
+		}
+		docID := st.docID(tag.Id())
+		indices[coll][docID] = append(indices[coll][docID], i)
+	}
+
+	set := func(coll, docID string, entity Entity) {
+		for _, i := range indices[coll][docID] {
+			entities[i] = entity
+		}
+		delete(indices[coll], docID)
+	}
+	fail := func(coll string, err error) {
+		for _, is := range indices[coll] {
+			for _, i := range is {
+				errs[i] = err
+			}
+		}
+		indices[coll] = nil
+	}
+
+	if len(indices[machinesC]) > 0 {
+		var docs []machineDoc
+		if err := st.findDocs(machinesC, indices[machinesC], &docs); err != nil {
+			fail(machinesC, errors.Annotate(err, "cannot get machines"))
+		} else {
+			for i := range docs {
+				set(machinesC, docs[i].DocID, newMachine(st, &docs[i]))
+			}
+		}
+	}
+	if len(indices[unitsC]) > 0 {
+		var docs []unitDoc
+		if err := st.findDocs(unitsC, indices[unitsC], &docs); err != nil {
+			fail(unitsC, errors.Annotate(err, "cannot get units"))
+		} else {
+			for i := range docs {
+				set(unitsC, docs[i].DocID, newUnit(st, &docs[i]))
+			}
+		}
+	}
+	if len(indices[applicationsC]) > 0 {
+		var docs []applicationDoc
+		if err := st.findDocs(applicationsC, indices[applicationsC], &docs); err != nil {
+			fail(applicationsC, errors.Annotate(err, "cannot get applications"))
+		} else {
+			for i := range docs {
+				set(applicationsC, docs[i].DocID, newApplication(st, &docs[i]))
+			}
+		}
+	}
+
+	// Anything left was not found.
+	for _, coll := range indices {
+		for _, is := range coll {
+			for _, i := range is {
+				errs[i] = notFoundEntityError(tags[i])
+			}
+		}
+	}
+	return entities, errs
+}
+
+// findDocs reads the documents with the given ids from the named
+// collection into docs.
+func (st *State) findDocs(collName string, ids map[string][]int, docs interface{}) error {
+	coll, closer := st.db().GetCollection(collName)
+	defer closer()
+
+	docIDs := make([]string, 0, len(ids))
+	for id := range ids {
+		docIDs = append(docIDs, id)
+	}
+	return coll.Find(bson.D{{"_id", bson.D{{"$in", docIDs}}}}).All(docs)
+}
+
+// notFoundEntityError returns an error satisfying errors.IsNotFound
+// that matches the one FindEntity returns for the missing entity.
+func notFoundEntityError(tag names.Tag) error {
+	switch tag.(type) {
+	case names.MachineTag:
+		return errors.NotFoundf("machine %s", tag.Id())
+	case names.UnitTag:
+		return errors.NotFoundf("unit %q", tag.Id())
+	case names.ApplicationTag:
+		return errors.NotFoundf("application %q", tag.Id())
+	}
+	return errors.NotFoundf("%s", names.ReadableString(tag))
+}
@@ -0,0 +1,65 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+type FindEntitiesSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&FindEntitiesSuite{})
+
+func (s *FindEntitiesSuite) TestFindEntities(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	unit := s.Factory.MakeUnit(c, nil)
+	app, err := unit.Application()
+	c.Assert(err, jc.ErrorIsNil)
+	user := s.Factory.MakeUser(c, nil)
+
+	tags := []names.Tag{
+		unit.Tag(),
+		machine.Tag(),
+		names.NewMachineTag("42"),
+		app.Tag(),
+		user.Tag(),
+		names.NewUnitTag("missing/0"),
+		machine.Tag(),
+		names.NewApplicationTag("missing"),
+	}
+	entities, errs := s.State.FindEntities(tags)
+	c.Assert(entities, gc.HasLen, len(tags))
+	c.Assert(errs, gc.HasLen, len(tags))
+
+	for _, i := range []int{0, 1, 3, 4, 6} {
+		c.Assert(errs[i], jc.ErrorIsNil)
+		c.Assert(entities[i].Tag(), gc.Equals, tags[i])
+	}
+	c.Assert(entities[0], gc.FitsTypeOf, &state.Unit{})
+	c.Assert(entities[1], gc.FitsTypeOf, &state.Machine{})
+	c.Assert(entities[3], gc.FitsTypeOf, &state.Application{})
+	c.Assert(entities[4], gc.FitsTypeOf, &state.User{})
+
+	c.Assert(entities[2], gc.IsNil)
+	c.Assert(errs[2], gc.ErrorMatches, "machine 42 not found")
+	c.Assert(errs[2], jc.Satisfies, errors.IsNotFound)
+	c.Assert(errs[5], gc.ErrorMatches, `unit "missing/0" not found`)
+	c.Assert(errs[7], gc.ErrorMatches, `application "missing" not found`)
+}
+
+func (s *FindEntitiesSuite) TestFindEntitiesMatchesFindEntity(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	entities, errs := s.State.FindEntities([]names.Tag{machine.Tag()})
+	c.Assert(errs[0], jc.ErrorIsNil)
+	entity, err := s.State.FindEntity(machine.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entities[0], jc.DeepEquals, entity)
+}
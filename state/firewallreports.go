@@ -0,0 +1,175 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/network"
+)
+
+// globalFirewallReportId is the id of the report describing the
+// model's firewall in global firewall mode.
+const globalFirewallReportId = "global"
+
+// FirewallReport holds what the firewaller found when it last compared
+// the ingress rules it wants applied with those the provider has
+// actually applied, either to a machine's instance or, in global
+// firewall mode, to the whole model.
+type FirewallReport struct {
+	// MachineId is the id of the machine whose instance the report
+	// describes. It is empty for the report describing the whole
+	// model in global firewall mode.
+	MachineId string
+
+	// Checked is the time at which the firewaller made the
+	// comparison.
+	Checked time.Time
+
+	// Wanted holds the ingress rules the firewaller wants applied,
+	// derived from the ports opened by the units of exposed
+	// applications.
+	Wanted []network.IngressRule
+
+	// Actual holds the ingress rules the provider has applied.
+	Actual []network.IngressRule
+
+	// Missing holds the wanted ingress rules that the provider has
+	// not applied.
+	Missing []network.IngressRule
+
+	// Unexpected holds the ingress rules that the provider has
+	// applied but the firewaller does not want.
+	Unexpected []network.IngressRule
+
+	// Error, if not empty, describes why the provider's ingress
+	// rules could not be read; Actual, Missing and Unexpected are
+	// then empty.
+	Error string
+}
+
+// Drifted returns whether the provider's ingress rules differ from
+// those the firewaller wants.
+func (r FirewallReport) Drifted() bool {
+	return len(r.Missing) > 0 || len(r.Unexpected) > 0
+}
+
+type firewallReportDoc struct {
+	DocID      string           `bson:"_id"`
+	ModelUUID  string           `bson:"model-uuid"`
+	MachineId  string           `bson:"machine-id,omitempty"`
+	Checked    int64            `bson:"checked"`
+	Wanted     []ingressRuleDoc `bson:"wanted,omitempty"`
+	Actual     []ingressRuleDoc `bson:"actual,omitempty"`
+	Missing    []ingressRuleDoc `bson:"missing,omitempty"`
+	Unexpected []ingressRuleDoc `bson:"unexpected,omitempty"`
+	Error      string           `bson:"error,omitempty"`
+}
+
+type ingressRuleDoc struct {
+	Protocol    string   `bson:"protocol"`
+	FromPort    int      `bson:"from-port"`
+	ToPort      int      `bson:"to-port"`
+	SourceCIDRs []string `bson:"source-cidrs,omitempty"`
+}
+
+func newIngressRuleDocs(rules []network.IngressRule) []ingressRuleDoc {
+	if len(rules) == 0 {
+		return nil
+	}
+	docs := make([]ingressRuleDoc, len(rules))
+	for i, rule := range rules {
+		docs[i] = ingressRuleDoc{
+			Protocol:    rule.Protocol,
+			FromPort:    rule.FromPort,
+			ToPort:      rule.ToPort,
+			SourceCIDRs: rule.SourceCIDRs,
+		}
+	}
+	return docs
+}
+
+func ingressRules(docs []ingressRuleDoc) []network.IngressRule {
+	if len(docs) == 0 {
+		return nil
+	}
+	rules := make([]network.IngressRule, len(docs))
+	for i, doc := range docs {
+		rules[i] = network.IngressRule{
+			PortRange: network.PortRange{
+				Protocol: doc.Protocol,
+				FromPort: doc.FromPort,
+				ToPort:   doc.ToPort,
+			},
+			SourceCIDRs: doc.SourceCIDRs,
+		}
+	}
+	return rules
+}
+
+// SetFirewallReports records the reports made by the firewaller,
+// replacing all those previously recorded for the model, so that
+// reports about machines that have since been removed are discarded.
+func (st *State) SetFirewallReports(reports []FirewallReport) error {
+	coll, closer := st.db().GetCollection(firewallReportsC)
+	defer closer()
+	writeable := coll.Writeable()
+
+	ids := make([]string, len(reports))
+	for i, report := range reports {
+		id := report.MachineId
+		if id == "" {
+			id = globalFirewallReportId
+		}
+		ids[i] = st.docID(id)
+		doc := firewallReportDoc{
+			DocID:      ids[i],
+			ModelUUID:  st.ModelUUID(),
+			MachineId:  report.MachineId,
+			Checked:    report.Checked.UnixNano(),
+			Wanted:     newIngressRuleDocs(report.Wanted),
+			Actual:     newIngressRuleDocs(report.Actual),
+			Missing:    newIngressRuleDocs(report.Missing),
+			Unexpected: newIngressRuleDocs(report.Unexpected),
+			Error:      report.Error,
+		}
+		if _, err := writeable.UpsertId(doc.DocID, doc); err != nil {
+			return errors.Annotatef(err, "cannot record firewall report for %q", id)
+		}
+	}
+	_, err := writeable.RemoveAll(bson.D{{"_id", bson.D{{"$nin", ids}}}})
+	if err != nil {
+		return errors.Annotate(err, "cannot remove stale firewall reports")
+	}
+	return nil
+}
+
+// FirewallReports returns the reports most recently recorded by the
+// firewaller, ordered by machine id, with the model-wide report made
+// in global firewall mode first.
+func (st *State) FirewallReports() ([]FirewallReport, error) {
+	coll, closer := st.db().GetCollection(firewallReportsC)
+	defer closer()
+
+	var docs []firewallReportDoc
+	if err := coll.Find(nil).Sort("machine-id").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read firewall reports")
+	}
+	result := make([]FirewallReport, len(docs))
+	for i, doc := range docs {
+		result[i] = FirewallReport{
+			MachineId:  doc.MachineId,
+			Checked:    time.Unix(0, doc.Checked).UTC(),
+			Wanted:     ingressRules(doc.Wanted),
+			Actual:     ingressRules(doc.Actual),
+			Missing:    ingressRules(doc.Missing),
+			Unexpected: ingressRules(doc.Unexpected),
+			Error:      doc.Error,
+		}
+	}
+	return result, nil
+}
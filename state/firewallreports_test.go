@@ -0,0 +1,97 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type firewallReportsSuite struct {
+	statetesting.StateSuite
+}
+
+var _ = gc.Suite(&firewallReportsSuite{})
+
+func (s *firewallReportsSuite) TestFirewallReportsEmpty(c *gc.C) {
+	reports, err := s.State.FirewallReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reports, gc.HasLen, 0)
+}
+
+func (s *firewallReportsSuite) TestSetFirewallReports(c *gc.C) {
+	checked := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	report0 := state.FirewallReport{
+		MachineId: "0",
+		Checked:   checked,
+		Wanted: []network.IngressRule{
+			network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+			network.MustNewIngressRule("tcp", 443, 443, "0.0.0.0/0"),
+		},
+		Actual: []network.IngressRule{
+			network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+			network.MustNewIngressRule("udp", 53, 53, "10.0.0.0/8"),
+		},
+		Missing: []network.IngressRule{
+			network.MustNewIngressRule("tcp", 443, 443, "0.0.0.0/0"),
+		},
+		Unexpected: []network.IngressRule{
+			network.MustNewIngressRule("udp", 53, 53, "10.0.0.0/8"),
+		},
+	}
+	report1 := state.FirewallReport{
+		MachineId: "1",
+		Checked:   checked,
+		Error:     "instance not found",
+	}
+	err := s.State.SetFirewallReports([]state.FirewallReport{report1, report0})
+	c.Assert(err, jc.ErrorIsNil)
+
+	reports, err := s.State.FirewallReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reports, jc.DeepEquals, []state.FirewallReport{report0, report1})
+	c.Assert(reports[0].Drifted(), jc.IsTrue)
+	c.Assert(reports[1].Drifted(), jc.IsFalse)
+}
+
+func (s *firewallReportsSuite) TestSetFirewallReportsReplaces(c *gc.C) {
+	checked := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	err := s.State.SetFirewallReports([]state.FirewallReport{
+		{MachineId: "0", Checked: checked},
+		{MachineId: "1", Checked: checked},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	global := state.FirewallReport{
+		Checked: checked.Add(time.Minute),
+		Wanted:  []network.IngressRule{network.MustNewIngressRule("tcp", 22, 22)},
+		Actual:  []network.IngressRule{network.MustNewIngressRule("tcp", 22, 22)},
+	}
+	err = s.State.SetFirewallReports([]state.FirewallReport{global})
+	c.Assert(err, jc.ErrorIsNil)
+
+	reports, err := s.State.FirewallReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reports, jc.DeepEquals, []state.FirewallReport{global})
+}
+
+func (s *firewallReportsSuite) TestFirewallReportsPerModel(c *gc.C) {
+	err := s.State.SetFirewallReports([]state.FirewallReport{{MachineId: "0"}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	err = st.SetFirewallReports(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	reports, err := s.State.FirewallReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reports, gc.HasLen, 1)
+}
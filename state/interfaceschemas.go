@@ -0,0 +1,111 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/relation"
+)
+
+// interfaceSchemaDoc records a version of a relation interface's
+// schema implemented by a charm added to the controller.
+type interfaceSchemaDoc struct {
+	DocID      string   `bson:"_id"`
+	Interface  string   `bson:"interface"`
+	Version    string   `bson:"version"`
+	Compatible []string `bson:"compatible,omitempty"`
+}
+
+func interfaceSchemaDocID(iface, version string) string {
+	return iface + "#" + version
+}
+
+// InterfaceSchemas returns the versions of the named relation
+// interface's schema implemented by the charms added to any of the
+// controller's models, ordered by version.
+func (st *State) InterfaceSchemas(iface string) ([]relation.InterfaceSchema, error) {
+	coll, closer := st.db().GetCollection(interfaceSchemasC)
+	defer closer()
+
+	var docs []interfaceSchemaDoc
+	if err := coll.Find(bson.D{{"interface", iface}}).Sort("version").All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get schemas of interface %q", iface)
+	}
+	schemas := make([]relation.InterfaceSchema, len(docs))
+	for i, doc := range docs {
+		schemas[i] = relation.InterfaceSchema{
+			Interface:  doc.Interface,
+			Version:    doc.Version,
+			Compatible: doc.Compatible,
+		}
+	}
+	return schemas, nil
+}
+
+// recordInterfaceSchemas records, in the controller's registry of
+// interface schemas, the versions implemented by the charm's endpoints
+// and the versions it declares them compatible with.
+func (st *State) recordInterfaceSchemas(ch *Charm) error {
+	versions := ch.InterfaceVersions()
+	if len(versions) == 0 {
+		return nil
+	}
+	relations := ch.Meta().CombinedRelations()
+	declared := make(map[string]*interfaceSchemaDoc)
+	for endpoint, v := range versions {
+		rel, ok := relations[endpoint]
+		if !ok {
+			continue
+		}
+		id := interfaceSchemaDocID(rel.Interface, v.Version)
+		doc, ok := declared[id]
+		if !ok {
+			doc = &interfaceSchemaDoc{
+				DocID:     id,
+				Interface: rel.Interface,
+				Version:   v.Version,
+			}
+			declared[id] = doc
+		}
+		doc.Compatible = append(doc.Compatible, v.Compatible...)
+	}
+
+	coll, closer := st.db().GetCollection(interfaceSchemasC)
+	defer closer()
+	buildTxn := func(int) ([]txn.Op, error) {
+		var ops []txn.Op
+		for id, doc := range declared {
+			count, err := coll.FindId(id).Count()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if count == 0 {
+				ops = append(ops, txn.Op{
+					C:      interfaceSchemasC,
+					Id:     id,
+					Assert: txn.DocMissing,
+					Insert: doc,
+				})
+			} else if len(doc.Compatible) > 0 {
+				ops = append(ops, txn.Op{
+					C:      interfaceSchemasC,
+					Id:     id,
+					Assert: txn.DocExists,
+					Update: bson.D{{"$addToSet", bson.D{
+						{"compatible", bson.D{{"$each", doc.Compatible}}},
+					}}},
+				})
+			}
+		}
+		if len(ops) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return ops, nil
+	}
+	return errors.Trace(st.db().Run(buildTxn))
+}
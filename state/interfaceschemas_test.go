@@ -0,0 +1,103 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/core/relation"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testcharms"
+)
+
+type interfaceSchemasSuite struct {
+	statetesting.StateSuite
+}
+
+var _ = gc.Suite(&interfaceSchemasSuite{})
+
+const versionedMetadata = `
+name: mysql-versioned
+summary: "Database engine"
+description: "A database declaring the versions of its interface schemas"
+provides:
+  server:
+    interface: mysql
+interface-versions:
+  %s:
+    version: %q
+    compatible: [%q]
+`
+
+func (s *interfaceSchemasSuite) addCharm(c *gc.C, revision int, endpoint, version, compatible string) (*state.Charm, error) {
+	dir := testcharms.Repo.ClonedDirPath(c.MkDir(), "mysql-versioned")
+	metadata := fmt.Sprintf(versionedMetadata, endpoint, version, compatible)
+	err := ioutil.WriteFile(filepath.Join(dir, "metadata.yaml"), []byte(metadata), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	ch, err := charm.ReadCharmDir(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	name := fmt.Sprintf("mysql-versioned-%d", revision)
+	return s.State.AddCharm(state.CharmInfo{
+		Charm:       ch,
+		ID:          charm.MustParseURL("cs:quantal/" + name),
+		StoragePath: name,
+		SHA256:      name + "-sha256",
+	})
+}
+
+func (s *interfaceSchemasSuite) TestAddCharmRecordsSchemas(c *gc.C) {
+	ch, err := s.State.AddCharm(state.CharmInfo{
+		Charm:       testcharms.Repo.CharmDir("mysql-versioned"),
+		ID:          charm.MustParseURL("cs:quantal/mysql-versioned-1"),
+		StoragePath: "mysql-versioned-1",
+		SHA256:      "mysql-versioned-1-sha256",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ch.InterfaceVersions(), jc.DeepEquals, map[string]relation.InterfaceVersion{
+		"server": {Version: "2", Compatible: []string{"1"}},
+	})
+
+	schemas, err := s.State.InterfaceSchemas("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schemas, jc.DeepEquals, []relation.InterfaceSchema{
+		{Interface: "mysql", Version: "2", Compatible: []string{"1"}},
+	})
+}
+
+func (s *interfaceSchemasSuite) TestSchemasMerged(c *gc.C) {
+	_, err := s.addCharm(c, 1, "server", "2", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.addCharm(c, 2, "server", "2", "1.5")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.addCharm(c, 3, "server", "3", "2")
+	c.Assert(err, jc.ErrorIsNil)
+
+	schemas, err := s.State.InterfaceSchemas("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schemas, jc.DeepEquals, []relation.InterfaceSchema{
+		{Interface: "mysql", Version: "2", Compatible: []string{"1", "1.5"}},
+		{Interface: "mysql", Version: "3", Compatible: []string{"2"}},
+	})
+}
+
+func (s *interfaceSchemasSuite) TestAddCharmUnknownEndpoint(c *gc.C) {
+	_, err := s.addCharm(c, 1, "admin", "2", "1")
+	c.Assert(err, gc.ErrorMatches, `invalid charm: interface version for unknown endpoint "admin" not valid`)
+}
+
+func (s *interfaceSchemasSuite) TestAddCharmWithoutVersions(c *gc.C) {
+	ch := s.Factory.MakeCharm(c, nil)
+	c.Assert(ch.InterfaceVersions(), gc.IsNil)
+
+	schemas, err := s.State.InterfaceSchemas("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schemas, gc.HasLen, 0)
+}
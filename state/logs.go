@@ -833,6 +833,26 @@ func PruneLogs(st ControllerSessioner, minLogTime time.Time, maxLogsMB int) erro
 	return nil
 }
 
+// LogsSizeMB returns the total size, in MiB, of the log collections
+// of all models, excluding space used by indexes.
+func LogsSizeMB(st ControllerSessioner) (int, error) {
+	if !st.IsController() {
+		return 0, errors.Errorf("reading log size requires a controller state")
+	}
+	session, logsDB := initLogsSessionDB(st)
+	defer session.Close()
+
+	logColls, err := getLogCollections(logsDB)
+	if err != nil {
+		return 0, errors.Annotate(err, "failed to get log collections")
+	}
+	size, err := getCollectionTotalMB(logColls)
+	if err != nil {
+		return 0, errors.Annotate(err, "failed to retrieve log collection sizes")
+	}
+	return size, nil
+}
+
 func initLogsSessionDB(st MongoSessioner) (*mgo.Session, *mgo.Database) {
 	// To improve throughput, only wait for the logs to be written to
 	// the primary. For some reason, this makes a huge difference even
@@ -272,6 +272,17 @@ func (s *LogsSuite) TestPruneLogsBySize(c *gc.C) {
 	assertLatestTs(s2)
 }
 
+func (s *LogsSuite) TestLogsSizeMB(c *gc.C) {
+	size, err := state.LogsSizeMB(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(size, gc.Equals, 0)
+
+	s.generateLogs(c, s.State, coretesting.NonZeroTime(), 10000)
+	size, err = state.LogsSizeMB(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(size, jc.GreaterThan, 0)
+}
+
 func (s *LogsSuite) generateLogs(c *gc.C, st *state.State, endTime time.Time, count int) {
 	dbLogger := state.NewDbLogger(st)
 	defer dbLogger.Close()
@@ -140,6 +140,33 @@ type machineDoc struct {
 	// StopMongoUntilVersion holds the version that must be checked to
 	// know if mongo must be stopped.
 	StopMongoUntilVersion string `bson:",omitempty"`
+
+	// CloudInitUserData holds user-supplied cloud-init configuration,
+	// in YAML, to be merged into the machine's when it is provisioned.
+	CloudInitUserData string `bson:"cloudinit-userdata,omitempty"`
+
+	// ProxyStatus records the outcome of the machine agent's most
+	// recent attempt to apply the model's proxy settings.
+	ProxyStatus *proxyStatusDoc `bson:"proxy-status,omitempty"`
+
+	// FQDN holds the fully qualified domain name registered for the
+	// machine in the provider's DNS, if any.
+	FQDN string `bson:"fqdn,omitempty"`
+
+	// CharmProfiles holds the names of the charm LXD profiles applied
+	// to the machine's container.
+	CharmProfiles []string `bson:"charm-profiles,omitempty"`
+
+	// Replacement holds the id of the machine added to take over this
+	// machine's units after the cloud signalled that it will reclaim
+	// the machine's instance.
+	Replacement string `bson:"replacement,omitempty"`
+}
+
+// proxyStatusDoc is the persistent form of ProxyStatus.
+type proxyStatusDoc struct {
+	Error   string `bson:"error,omitempty"`
+	Updated int64  `bson:"updated"`
 }
 
 func newMachine(st *State, doc *machineDoc) *Machine {
@@ -208,6 +235,10 @@ type instanceData struct {
 	Tags       *[]string   `bson:"tags,omitempty"`
 	AvailZone  *string     `bson:"availzone,omitempty"`
 
+	// DisplayName is the human readable name given to the instance
+	// by the provider, if it differs from the instance id.
+	DisplayName string `bson:"display-name,omitempty"`
+
 	// KeepInstance is set to true if, on machine removal from Juju,
 	// the cloud instance should be retained.
 	KeepInstance bool `bson:"keep-instance,omitempty"`
@@ -893,6 +924,11 @@ func (m *Machine) removeOps() ([]txn.Op, error) {
 		removeMachineBlockDevicesOp(m.Id()),
 		removeModelMachineRefOp(m.st, m.Id()),
 		removeSSHHostKeyOp(m.globalKey()),
+		removeAgentLoggingOverrideOp(m.st, m.Tag()),
+		removeMaintenanceModeOp(m.st, m.Tag()),
+		removeAgentOfflineOp(m.st, m.Tag()),
+		removeContainerImageCacheOp(m.st, m.globalKey()),
+		removeMachineFanStatusOp(m.doc.DocID),
 	}
 	linkLayerDevicesOps, err := m.removeAllLinkLayerDevicesOps()
 	if err != nil {
@@ -914,12 +950,17 @@ func (m *Machine) removeOps() ([]txn.Op, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	blockOps, err := removeEntityBlocksOps(m.st, m.Tag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	ops = append(ops, linkLayerDevicesOps...)
 	ops = append(ops, devicesAddressesOps...)
 	ops = append(ops, portsOps...)
 	ops = append(ops, removeContainerRefOps(m.st, m.Id())...)
 	ops = append(ops, filesystemOps...)
 	ops = append(ops, volumeOps...)
+	ops = append(ops, blockOps...)
 	return ops, nil
 }
 
@@ -1039,6 +1080,21 @@ func (m *Machine) InstanceId() (instance.Id, error) {
 	return instData.InstanceId, err
 }
 
+// InstanceNames returns the provider specific instance id and the
+// provider's display name for the instance, or a NotProvisionedError,
+// if not set. The display name is empty if the provider doesn't
+// distinguish it from the instance id.
+func (m *Machine) InstanceNames() (instance.Id, string, error) {
+	instData, err := getInstanceData(m.st, m.Id())
+	if errors.IsNotFound(err) {
+		err = errors.NotProvisionedf("machine %v", m.Id())
+	}
+	if err != nil {
+		return "", "", err
+	}
+	return instData.InstanceId, instData.DisplayName, nil
+}
+
 // InstanceStatus returns the provider specific instance status for this machine,
 // or a NotProvisionedError if instance is not yet provisioned.
 func (m *Machine) InstanceStatus() (status.StatusInfo, error) {
@@ -1196,7 +1252,14 @@ func (m *Machine) DesiredSpaces() (set.Strings, error) {
 // that if the provisioner crashes (or its connection to the state is
 // lost) after starting the instance, we can be sure that only a single
 // instance will be able to act for that machine.
-func (m *Machine) SetProvisioned(id instance.Id, nonce string, characteristics *instance.HardwareCharacteristics) (err error) {
+func (m *Machine) SetProvisioned(id instance.Id, nonce string, characteristics *instance.HardwareCharacteristics) error {
+	return m.setProvisioned(id, "", nonce, characteristics)
+}
+
+// setProvisioned sets the provider specific machine id, the provider's
+// display name for the instance, nonce and hardware characteristics
+// for this machine.
+func (m *Machine) setProvisioned(id instance.Id, displayName, nonce string, characteristics *instance.HardwareCharacteristics) (err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot set instance data for machine %q", m)
 
 	if id == "" || nonce == "" {
@@ -1228,6 +1291,8 @@ func (m *Machine) SetProvisioned(id instance.Id, nonce string, characteristics *
 		CpuPower:   characteristics.CpuPower,
 		Tags:       characteristics.Tags,
 		AvailZone:  characteristics.AvailabilityZone,
+
+		DisplayName: displayName,
 	}
 
 	ops := []txn.Op{
@@ -1258,10 +1323,11 @@ func (m *Machine) SetProvisioned(id instance.Id, nonce string, characteristics *
 }
 
 // SetInstanceInfo is used to provision a machine and in one steps set it's
-// instance id, nonce, hardware characteristics, add link-layer devices and set
-// their addresses as needed.
+// instance id, the provider's display name for the instance, nonce,
+// hardware characteristics, add link-layer devices and set their addresses
+// as needed.
 func (m *Machine) SetInstanceInfo(
-	id instance.Id, nonce string, characteristics *instance.HardwareCharacteristics,
+	id instance.Id, displayName, nonce string, characteristics *instance.HardwareCharacteristics,
 	devicesArgs []LinkLayerDeviceArgs, devicesAddrs []LinkLayerDeviceAddress,
 	volumes map[names.VolumeTag]VolumeInfo,
 	volumeAttachments map[names.VolumeTag]VolumeAttachmentInfo,
@@ -1303,7 +1369,7 @@ func (m *Machine) SetInstanceInfo(
 		}
 	}
 
-	return m.SetProvisioned(id, nonce, characteristics)
+	return m.setProvisioned(id, displayName, nonce, characteristics)
 }
 
 // Addresses returns any hostnames and ips associated with a machine,
@@ -1431,15 +1497,22 @@ func (m *Machine) setPreferredAddressOps(addr address, isPublic bool) []txn.Op {
 	return ops
 }
 
-func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddresses []address) ([]txn.Op, *address) {
+func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddresses []address, preferIPv6 bool) ([]txn.Op, *address) {
 	publicAddress := m.doc.PreferredPublicAddress
 	logger.Tracef("machine %v: current public address: %#v \nprovider addresses: %#v \nmachine addresses: %#v", m.Id(), publicAddress, providerAddresses, machineAddresses)
 	// Always prefer an exact match if available.
 	checkScope := func(addr address) bool {
+		if preferIPv6 && addr.networkAddress().Type == network.IPv4Address {
+			return false
+		}
 		return network.ExactScopeMatch(addr.networkAddress(), network.ScopePublic)
 	}
 	// Without an exact match, prefer a fallback match.
 	getAddr := func(addresses []address) network.Address {
+		if preferIPv6 {
+			addr, _ := network.SelectPublicAddressPreferringIPv6(networkAddresses(addresses))
+			return addr
+		}
 		addr, _ := network.SelectPublicAddress(networkAddresses(addresses))
 		return addr
 	}
@@ -1454,14 +1527,21 @@ func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddres
 	return ops, &newAddr
 }
 
-func (m *Machine) setPrivateAddressOps(providerAddresses []address, machineAddresses []address) ([]txn.Op, *address) {
+func (m *Machine) setPrivateAddressOps(providerAddresses []address, machineAddresses []address, preferIPv6 bool) ([]txn.Op, *address) {
 	privateAddress := m.doc.PreferredPrivateAddress
 	// Always prefer an exact match if available.
 	checkScope := func(addr address) bool {
+		if preferIPv6 && addr.networkAddress().Type == network.IPv4Address {
+			return false
+		}
 		return network.ExactScopeMatch(addr.networkAddress(), network.ScopeMachineLocal, network.ScopeCloudLocal, network.ScopeFanLocal)
 	}
 	// Without an exact match, prefer a fallback match.
 	getAddr := func(addresses []address) network.Address {
+		if preferIPv6 {
+			addr, _ := network.SelectInternalAddressPreferringIPv6(networkAddresses(addresses), false)
+			return addr
+		}
 		addr, _ := network.SelectInternalAddress(networkAddresses(addresses), false)
 		return addr
 	}
@@ -1592,8 +1672,20 @@ func (m *Machine) setAddressesOps(
 		Update: bson.D{{"$set", set}},
 	}}
 
-	setPrivateAddressOps, newPrivate := m.setPrivateAddressOps(providerStateAddresses, machineStateAddresses)
-	setPublicAddressOps, newPublic := m.setPublicAddressOps(providerStateAddresses, machineStateAddresses)
+	// The model may prefer IPv6 addresses, such as when machines are
+	// deployed into IPv6-only or dual-stack subnets.
+	model, err := m.st.Model()
+	if err != nil {
+		return nil, nil, nil, nil, nil, errors.Trace(err)
+	}
+	cfg, err := model.ModelConfig()
+	if err != nil {
+		return nil, nil, nil, nil, nil, errors.Trace(err)
+	}
+	preferIPv6 := cfg.PreferIPv6()
+
+	setPrivateAddressOps, newPrivate := m.setPrivateAddressOps(providerStateAddresses, machineStateAddresses, preferIPv6)
+	setPublicAddressOps, newPublic := m.setPublicAddressOps(providerStateAddresses, machineStateAddresses, preferIPv6)
 	ops = append(ops, setPrivateAddressOps...)
 	ops = append(ops, setPublicAddressOps...)
 	return ops, machineStateAddresses, providerStateAddresses, newPrivate, newPublic, nil
@@ -1615,6 +1707,154 @@ func (m *Machine) Placement() string {
 	return m.doc.Placement
 }
 
+// CloudInitUserData returns the user-supplied cloud-init configuration,
+// in YAML, that was specified when the machine was added. It does not
+// include the model's cloudinit-userdata.
+func (m *Machine) CloudInitUserData() string {
+	return m.doc.CloudInitUserData
+}
+
+// ProxyStatus describes the outcome of a machine agent's most recent
+// attempt to apply the model's proxy settings to its machine.
+type ProxyStatus struct {
+	// Error holds the reason the settings could not be fully applied.
+	// It is empty if they were applied.
+	Error string
+
+	// Updated is the time at which the settings were applied. If it
+	// is zero when the status is set, the current time is recorded.
+	Updated time.Time
+}
+
+// SetProxyStatus records the outcome of the machine agent's most recent
+// attempt to apply the model's proxy settings.
+func (m *Machine) SetProxyStatus(proxyStatus ProxyStatus) error {
+	updated := proxyStatus.Updated
+	if updated.IsZero() {
+		updated = m.st.clock().Now()
+	}
+	doc := &proxyStatusDoc{
+		Error:   proxyStatus.Error,
+		Updated: updated.UnixNano(),
+	}
+	ops := []txn.Op{{
+		C:      machinesC,
+		Id:     m.doc.DocID,
+		Assert: notDeadDoc,
+		Update: bson.D{{"$set", bson.D{{"proxy-status", doc}}}},
+	}}
+	if err := m.st.db().RunTransaction(ops); err != nil {
+		return errors.Annotatef(onAbort(err, ErrDead), "cannot set proxy status of machine %v", m)
+	}
+	m.doc.ProxyStatus = doc
+	return nil
+}
+
+// ProxyStatus returns the outcome of the machine agent's most recent
+// attempt to apply the model's proxy settings. If the agent has not
+// reported any, an error satisfying errors.IsNotFound is returned.
+func (m *Machine) ProxyStatus() (ProxyStatus, error) {
+	doc := m.doc.ProxyStatus
+	if doc == nil {
+		return ProxyStatus{}, errors.NotFoundf("proxy status for machine %v", m)
+	}
+	return doc.status(), nil
+}
+
+func (doc *proxyStatusDoc) status() ProxyStatus {
+	return ProxyStatus{
+		Error:   doc.Error,
+		Updated: time.Unix(0, doc.Updated).UTC(),
+	}
+}
+
+// AllMachinesProxyStatus returns the outcome of each machine agent's
+// most recent attempt to apply the model's proxy settings, keyed by
+// machine id. Machines whose agents have reported nothing are omitted.
+func (st *State) AllMachinesProxyStatus() (map[string]ProxyStatus, error) {
+	machines, closer := st.db().GetCollection(machinesC)
+	defer closer()
+
+	var docs []struct {
+		Id          string          `bson:"machineid"`
+		ProxyStatus *proxyStatusDoc `bson:"proxy-status"`
+	}
+	query := bson.D{{"proxy-status", bson.D{{"$exists", true}}}}
+	fields := bson.D{{"machineid", 1}, {"proxy-status", 1}}
+	if err := machines.Find(query).Select(fields).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read machine proxy status")
+	}
+	result := make(map[string]ProxyStatus, len(docs))
+	for _, doc := range docs {
+		if doc.ProxyStatus != nil {
+			result[doc.Id] = doc.ProxyStatus.status()
+		}
+	}
+	return result, nil
+}
+
+// FQDN returns the fully qualified domain name registered for the
+// machine in the provider's DNS, or the empty string if there is none.
+func (m *Machine) FQDN() string {
+	return m.doc.FQDN
+}
+
+// SetFQDN records the fully qualified domain name registered for the
+// machine in the provider's DNS. An empty name records that there is
+// none. Unlike most machine updates, this may be done once the machine
+// is dead, so that its records can be removed.
+func (m *Machine) SetFQDN(fqdn string) error {
+	var update bson.D
+	if fqdn == "" {
+		update = bson.D{{"$unset", bson.D{{"fqdn", nil}}}}
+	} else {
+		update = bson.D{{"$set", bson.D{{"fqdn", fqdn}}}}
+	}
+	ops := []txn.Op{{
+		C:      machinesC,
+		Id:     m.doc.DocID,
+		Assert: txn.DocExists,
+		Update: update,
+	}}
+	if err := m.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("machine %v", m)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot set fqdn of machine %v", m)
+	}
+	m.doc.FQDN = fqdn
+	return nil
+}
+
+// CharmProfiles returns the names of the charm LXD profiles applied to
+// the machine's container.
+func (m *Machine) CharmProfiles() []string {
+	return m.doc.CharmProfiles
+}
+
+// SetCharmProfiles records the names of the charm LXD profiles applied
+// to the machine's container, replacing any previously recorded.
+func (m *Machine) SetCharmProfiles(profiles []string) error {
+	var update bson.D
+	if len(profiles) == 0 {
+		update = bson.D{{"$unset", bson.D{{"charm-profiles", nil}}}}
+	} else {
+		update = bson.D{{"$set", bson.D{{"charm-profiles", profiles}}}}
+	}
+	ops := []txn.Op{{
+		C:      machinesC,
+		Id:     m.doc.DocID,
+		Assert: txn.DocExists,
+		Update: update,
+	}}
+	if err := m.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("machine %v", m)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot set charm profiles of machine %v", m)
+	}
+	m.doc.CharmProfiles = profiles
+	return nil
+}
+
 // Constraints returns the exact constraints that should apply when provisioning
 // an instance for the machine.
 func (m *Machine) Constraints() (constraints.Value, error) {
@@ -1675,11 +1915,16 @@ func (m *Machine) Status() (status.StatusInfo, error) {
 
 // SetStatus sets the status of the machine.
 func (m *Machine) SetStatus(statusInfo status.StatusInfo) error {
+	return setEntityStatus(m, statusInfo)
+}
+
+// statusUpdate is part of the statusUpdater interface.
+func (m *Machine) statusUpdate(statusInfo status.StatusInfo) (Database, setStatusParams, error) {
 	switch statusInfo.Status {
-	case status.Started, status.Stopped:
+	case status.Started, status.Stopped, status.Interrupted:
 	case status.Error:
 		if statusInfo.Message == "" {
-			return errors.Errorf("cannot set status %q without info", statusInfo.Status)
+			return nil, setStatusParams{}, errors.Errorf("cannot set status %q without info", statusInfo.Status)
 		}
 	case status.Pending:
 		// If a machine is not yet provisioned, we allow its status
@@ -1691,18 +1936,18 @@ func (m *Machine) SetStatus(statusInfo status.StatusInfo) error {
 		}
 		fallthrough
 	case status.Down:
-		return errors.Errorf("cannot set status %q", statusInfo.Status)
+		return nil, setStatusParams{}, errors.Errorf("cannot set status %q", statusInfo.Status)
 	default:
-		return errors.Errorf("cannot set invalid status %q", statusInfo.Status)
+		return nil, setStatusParams{}, errors.Errorf("cannot set invalid status %q", statusInfo.Status)
 	}
-	return setStatus(m.st.db(), setStatusParams{
+	return m.st.db(), setStatusParams{
 		badge:     "machine",
 		globalKey: m.globalKey(),
 		status:    statusInfo.Status,
 		message:   statusInfo.Message,
 		rawData:   statusInfo.Data,
 		updated:   timeOrNow(statusInfo.Since, m.st.clock()),
-	})
+	}, nil
 }
 
 // StatusHistory returns a slice of at most filter.Size StatusInfo items
@@ -1845,12 +2090,7 @@ func (m *Machine) AddAction(name string, payload map[string]interface{}) (Action
 		return nil, errors.Errorf("cannot add action %q to a machine; only predefined actions allowed", name)
 	}
 
-	// Reject bad payloads before attempting to insert defaults.
-	err := spec.ValidateParams(payload)
-	if err != nil {
-		return nil, err
-	}
-	payloadWithDefaults, err := spec.InsertDefaults(payload)
+	payloadWithDefaults, err := actions.PrepareParams(name, spec, payload)
 	if err != nil {
 		return nil, err
 	}
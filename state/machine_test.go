@@ -6,6 +6,7 @@ package state_test
 import (
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -129,6 +130,89 @@ func (s *MachineSuite) TestSetKeepInstance(c *gc.C) {
 	c.Assert(keep, jc.IsTrue)
 }
 
+func (s *MachineSuite) TestProxyStatus(c *gc.C) {
+	_, err := s.machine.ProxyStatus()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	updated := time.Date(2018, 5, 1, 12, 0, 0, 0, time.UTC)
+	err = s.machine.SetProxyStatus(state.ProxyStatus{
+		Error:   "cannot write apt proxy",
+		Updated: updated,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	m, err := s.State.Machine(s.machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	proxyStatus, err := m.ProxyStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(proxyStatus, jc.DeepEquals, state.ProxyStatus{
+		Error:   "cannot write apt proxy",
+		Updated: updated,
+	})
+
+	all, err := s.State.AllMachinesProxyStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, jc.DeepEquals, map[string]state.ProxyStatus{
+		s.machine.Id(): proxyStatus,
+	})
+}
+
+func (s *MachineSuite) TestSetProxyStatusDead(c *gc.C) {
+	err := s.machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetProxyStatus(state.ProxyStatus{})
+	c.Assert(err, gc.ErrorMatches, "cannot set proxy status of machine 1: not found or dead")
+}
+
+func (s *MachineSuite) TestFQDN(c *gc.C) {
+	c.Assert(s.machine.FQDN(), gc.Equals, "")
+
+	err := s.machine.SetFQDN("machine-1.example.com")
+	c.Assert(err, jc.ErrorIsNil)
+	m, err := s.State.Machine(s.machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.FQDN(), gc.Equals, "machine-1.example.com")
+
+	// The name may be cleared once the machine is dead.
+	err = m.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.SetFQDN("")
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.FQDN(), gc.Equals, "")
+}
+
+func (s *MachineSuite) TestSetFQDNRemoved(c *gc.C) {
+	c.Assert(s.machine.EnsureDead(), jc.ErrorIsNil)
+	c.Assert(s.machine.Remove(), jc.ErrorIsNil)
+	err := s.machine.SetFQDN("machine-1.example.com")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *MachineSuite) TestCharmProfiles(c *gc.C) {
+	c.Assert(s.machine.CharmProfiles(), gc.HasLen, 0)
+
+	err := s.machine.SetCharmProfiles([]string{"juju-testenv-sriov-1", "juju-testenv-dpdk-3"})
+	c.Assert(err, jc.ErrorIsNil)
+	m, err := s.State.Machine(s.machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.CharmProfiles(), jc.DeepEquals, []string{"juju-testenv-sriov-1", "juju-testenv-dpdk-3"})
+
+	err = m.SetCharmProfiles(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.CharmProfiles(), gc.HasLen, 0)
+}
+
+func (s *MachineSuite) TestSetCharmProfilesRemoved(c *gc.C) {
+	c.Assert(s.machine.EnsureDead(), jc.ErrorIsNil)
+	c.Assert(s.machine.Remove(), jc.ErrorIsNil)
+	err := s.machine.SetCharmProfiles([]string{"juju-testenv-sriov-1"})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *MachineSuite) TestAddMachineInsideMachineModelDying(c *gc.C) {
 	model, err := s.State.Model()
 	c.Assert(err, jc.ErrorIsNil)
@@ -921,6 +1005,29 @@ func (s *MachineSuite) TestMachineAvailabilityZoneMissing(c *gc.C) {
 	c.Check(zone, gc.Equals, "")
 }
 
+func (s *MachineSuite) TestMachineInstanceNames(c *gc.C) {
+	_, _, err := s.machine.InstanceNames()
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+
+	err = s.machine.SetInstanceInfo("umbrella/0", "snowflake", "fake_nonce", nil, nil, nil, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	instId, displayName, err := s.machine.InstanceNames()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(instId, gc.Equals, instance.Id("umbrella/0"))
+	c.Check(displayName, gc.Equals, "snowflake")
+}
+
+func (s *MachineSuite) TestMachineInstanceNamesNoDisplayName(c *gc.C) {
+	err := s.machine.SetProvisioned("umbrella/0", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	instId, displayName, err := s.machine.InstanceNames()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(instId, gc.Equals, instance.Id("umbrella/0"))
+	c.Check(displayName, gc.Equals, "")
+}
+
 func (s *MachineSuite) TestMachineSetCheckProvisioned(c *gc.C) {
 	// Check before provisioning.
 	c.Assert(s.machine.CheckProvisioned("fake_nonce"), jc.IsFalse)
@@ -987,14 +1094,14 @@ func (s *MachineSuite) TestMachineSetInstanceInfoFailureDoesNotProvision(c *gc.C
 	invalidVolumes := map[names.VolumeTag]state.VolumeInfo{
 		names.NewVolumeTag("1065"): state.VolumeInfo{VolumeId: "vol-ume"},
 	}
-	err := s.machine.SetInstanceInfo("umbrella/0", "fake_nonce", nil, nil, nil, invalidVolumes, nil)
+	err := s.machine.SetInstanceInfo("umbrella/0", "", "fake_nonce", nil, nil, nil, invalidVolumes, nil)
 	c.Assert(err, gc.ErrorMatches, `cannot set info for volume \"1065\": volume \"1065\" not found`)
 	assertNotProvisioned()
 
 	invalidVolumes = map[names.VolumeTag]state.VolumeInfo{
 		names.NewVolumeTag("1065"): state.VolumeInfo{},
 	}
-	err = s.machine.SetInstanceInfo("umbrella/0", "fake_nonce", nil, nil, nil, invalidVolumes, nil)
+	err = s.machine.SetInstanceInfo("umbrella/0", "", "fake_nonce", nil, nil, nil, invalidVolumes, nil)
 	c.Assert(err, gc.ErrorMatches, `cannot set info for volume \"1065\": volume ID not set`)
 	assertNotProvisioned()
 
@@ -1027,7 +1134,7 @@ func (s *MachineSuite) TestMachineSetInstanceInfoSuccess(c *gc.C) {
 		Size:     1234,
 	}
 	volumes := map[names.VolumeTag]state.VolumeInfo{volumeTag: volumeInfo}
-	err = s.machine.SetInstanceInfo("umbrella/0", "fake_nonce", nil, nil, nil, volumes, nil)
+	err = s.machine.SetInstanceInfo("umbrella/0", "", "fake_nonce", nil, nil, nil, volumes, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(s.machine.CheckProvisioned("fake_nonce"), jc.IsTrue)
 
@@ -1955,6 +2062,41 @@ func (s *MachineSuite) TestPrivateAddressBetterMatch(c *gc.C) {
 	c.Assert(addr.Value, gc.Equals, "10.0.0.1")
 }
 
+func (s *MachineSuite) TestAddressesPreferringIPv6(c *gc.C) {
+	err := s.IAASModel.UpdateModelConfig(map[string]interface{}{"prefer-ipv6": true}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.SetProviderAddresses(
+		network.NewScopedAddress("8.8.8.8", network.ScopePublic),
+		network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	addr, err := machine.PublicAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "8.8.8.8")
+	addr, err = machine.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "10.0.0.1")
+
+	// Once IPv6 addresses of the same scope are available they
+	// replace the IPv4 ones.
+	err = machine.SetProviderAddresses(
+		network.NewScopedAddress("8.8.8.8", network.ScopePublic),
+		network.NewScopedAddress("2001:db8::1", network.ScopePublic),
+		network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+		network.NewScopedAddress("fc00::1", network.ScopeCloudLocal),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	addr, err = machine.PublicAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "2001:db8::1")
+	addr, err = machine.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "fc00::1")
+}
+
 func (s *MachineSuite) TestPublicAddressChanges(c *gc.C) {
 	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,193 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// FanOverlayStatus describes the health of one of the model's fan
+// overlays on a machine, as most recently reported by its agent.
+type FanOverlayStatus struct {
+	// Underlay is the CIDR of the underlay network of the fan.
+	Underlay string
+
+	// Overlay is the CIDR of the overlay network of the fan.
+	Overlay string
+
+	// Bridge is the name of the machine's bridge device for the
+	// overlay, if it has one.
+	Bridge string
+
+	// Address is the machine's address on the overlay, if it has one.
+	Address string
+
+	// Healthy is true if containers on the machine can be given
+	// routable addresses on the overlay.
+	Healthy bool
+
+	// Message describes why the overlay is not healthy.
+	Message string
+}
+
+// MachineFanStatus describes the health of the model's fan overlays on
+// a machine.
+type MachineFanStatus struct {
+	// Overlays holds the status of each of the fan overlays whose
+	// underlay the machine is on.
+	Overlays []FanOverlayStatus
+
+	// Updated is when the status was recorded.
+	Updated time.Time
+}
+
+// machineFanStatusDoc records the fan status of a machine. Its id is
+// that of the machine's document.
+type machineFanStatusDoc struct {
+	DocID     string                `bson:"_id"`
+	ModelUUID string                `bson:"model-uuid"`
+	Overlays  []fanOverlayStatusDoc `bson:"overlays"`
+	Updated   int64                 `bson:"updated"`
+}
+
+type fanOverlayStatusDoc struct {
+	Underlay string `bson:"underlay"`
+	Overlay  string `bson:"overlay"`
+	Bridge   string `bson:"bridge,omitempty"`
+	Address  string `bson:"address,omitempty"`
+	Healthy  bool   `bson:"healthy"`
+	Message  string `bson:"message,omitempty"`
+}
+
+func (doc *machineFanStatusDoc) status() MachineFanStatus {
+	status := MachineFanStatus{
+		Updated: time.Unix(0, doc.Updated).UTC(),
+	}
+	for _, overlay := range doc.Overlays {
+		status.Overlays = append(status.Overlays, FanOverlayStatus{
+			Underlay: overlay.Underlay,
+			Overlay:  overlay.Overlay,
+			Bridge:   overlay.Bridge,
+			Address:  overlay.Address,
+			Healthy:  overlay.Healthy,
+			Message:  overlay.Message,
+		})
+	}
+	return status
+}
+
+// FanStatus returns the health of the model's fan overlays on the
+// machine, as most recently reported by its agent. If none has been
+// reported, an error satisfying errors.IsNotFound is returned.
+func (m *Machine) FanStatus() (MachineFanStatus, error) {
+	coll, closer := m.st.db().GetCollection(machineFanStatusC)
+	defer closer()
+
+	var doc machineFanStatusDoc
+	err := coll.FindId(m.doc.DocID).One(&doc)
+	if err == mgo.ErrNotFound {
+		return MachineFanStatus{}, errors.NotFoundf("fan status for machine %q", m.Id())
+	} else if err != nil {
+		return MachineFanStatus{}, errors.Annotatef(err, "cannot read fan status for machine %q", m.Id())
+	}
+	return doc.status(), nil
+}
+
+// SetFanStatus records the health of the model's fan overlays on the
+// machine, replacing any previously recorded, along with the current
+// time. It fails if the machine is dead.
+func (m *Machine) SetFanStatus(overlays []FanOverlayStatus) error {
+	docs := make([]fanOverlayStatusDoc, len(overlays))
+	for i, overlay := range overlays {
+		if overlay.Underlay == "" || overlay.Overlay == "" {
+			return errors.NotValidf("fan overlay status without underlay and overlay")
+		}
+		docs[i] = fanOverlayStatusDoc{
+			Underlay: overlay.Underlay,
+			Overlay:  overlay.Overlay,
+			Bridge:   overlay.Bridge,
+			Address:  overlay.Address,
+			Healthy:  overlay.Healthy,
+			Message:  overlay.Message,
+		}
+	}
+	updated := m.st.clock().Now().UnixNano()
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if m.doc.Life == Dead {
+			return nil, errors.Errorf("machine is dead")
+		}
+		ops := []txn.Op{{
+			C:      machinesC,
+			Id:     m.doc.DocID,
+			Assert: notDeadDoc,
+		}}
+		_, err := m.FanStatus()
+		if errors.IsNotFound(err) {
+			return append(ops, txn.Op{
+				C:      machineFanStatusC,
+				Id:     m.doc.DocID,
+				Assert: txn.DocMissing,
+				Insert: &machineFanStatusDoc{
+					DocID:     m.doc.DocID,
+					ModelUUID: m.st.ModelUUID(),
+					Overlays:  docs,
+					Updated:   updated,
+				},
+			}), nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, txn.Op{
+			C:      machineFanStatusC,
+			Id:     m.doc.DocID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"overlays", docs},
+				{"updated", updated},
+			}}},
+		}), nil
+	}
+	if err := m.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot set fan status for machine %q", m.Id())
+	}
+	return nil
+}
+
+// AllMachinesFanStatus returns the health of the model's fan overlays
+// on each of its machines, keyed by machine id. Machines that have
+// reported nothing are omitted.
+func (st *State) AllMachinesFanStatus() (map[string]MachineFanStatus, error) {
+	coll, closer := st.db().GetCollection(machineFanStatusC)
+	defer closer()
+
+	var docs []machineFanStatusDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read machine fan status")
+	}
+	result := make(map[string]MachineFanStatus, len(docs))
+	for i := range docs {
+		result[st.localID(docs[i].DocID)] = docs[i].status()
+	}
+	return result, nil
+}
+
+// removeMachineFanStatusOp returns an operation that removes any fan
+// status recorded for the machine with the given document id.
+func removeMachineFanStatusOp(docID string) txn.Op {
+	return txn.Op{
+		C:      machineFanStatusC,
+		Id:     docID,
+		Remove: true,
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type MachineFanStatusSuite struct {
+	ConnSuite
+	clock   *testing.Clock
+	machine *state.Machine
+}
+
+var _ = gc.Suite(&MachineFanStatusSuite{})
+
+func (s *MachineFanStatusSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.clock = testing.NewClock(coretesting.NonZeroTime().UTC())
+	err := s.State.SetClockForTesting(s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	s.machine = s.Factory.MakeMachine(c, nil)
+}
+
+func (s *MachineFanStatusSuite) TestFanStatusNotFound(c *gc.C) {
+	_, err := s.machine.FanStatus()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `fan status for machine "`+s.machine.Id()+`" not found`)
+}
+
+func (s *MachineFanStatusSuite) TestSetFanStatus(c *gc.C) {
+	overlays := []state.FanOverlayStatus{{
+		Underlay: "172.31.0.0/16",
+		Overlay:  "253.0.0.0/8",
+		Bridge:   "fan-253",
+		Address:  "253.64.10.1",
+		Healthy:  true,
+	}, {
+		Underlay: "10.0.0.0/16",
+		Overlay:  "252.0.0.0/8",
+		Message:  "no fan bridge has an address on the overlay",
+	}}
+	err := s.machine.SetFanStatus(overlays)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := s.machine.FanStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, state.MachineFanStatus{
+		Overlays: overlays,
+		Updated:  s.clock.Now(),
+	})
+
+	// Setting the status again replaces it.
+	s.clock.Advance(time.Minute)
+	err = s.machine.SetFanStatus(overlays[:1])
+	c.Assert(err, jc.ErrorIsNil)
+	all, err := s.State.AllMachinesFanStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, jc.DeepEquals, map[string]state.MachineFanStatus{
+		s.machine.Id(): {
+			Overlays: overlays[:1],
+			Updated:  s.clock.Now(),
+		},
+	})
+}
+
+func (s *MachineFanStatusSuite) TestSetFanStatusInvalid(c *gc.C) {
+	err := s.machine.SetFanStatus([]state.FanOverlayStatus{{Underlay: "172.31.0.0/16"}})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *MachineFanStatusSuite) TestRemoveMachineRemovesFanStatus(c *gc.C) {
+	err := s.machine.SetFanStatus(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetFanStatus(nil)
+	c.Assert(err, gc.ErrorMatches, `cannot set fan status for machine "`+s.machine.Id()+`": machine is dead`)
+	err = s.machine.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.machine.FanStatus()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
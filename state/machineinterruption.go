@@ -0,0 +1,137 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/status"
+)
+
+// Replacement returns the id of the machine added to take over this
+// machine's units after its instance was interrupted, and whether
+// there is one.
+func (m *Machine) Replacement() (string, bool) {
+	return m.doc.Replacement, m.doc.Replacement != ""
+}
+
+// Interrupt records that the cloud will reclaim the machine's instance
+// at the given deadline. It adds a replacement machine with the same
+// series, constraints and jobs; moves each of the machine's alive
+// principal units there, by adding a unit of the same application to
+// the replacement and destroying the old unit with a drain timeout
+// ending at the deadline; and sets the machine's status to interrupted.
+//
+// Interrupt may safely be called more than once: the replacement
+// machine added by the first call is returned by later ones.
+func (m *Machine) Interrupt(deadline time.Time) (*Machine, error) {
+	if m.IsManager() {
+		return nil, errors.NotSupportedf("replacing controller machine %v", m)
+	}
+	if m.IsContainer() {
+		return nil, errors.NotSupportedf("replacing container %v", m)
+	}
+	replacement, err := m.addReplacement()
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot add replacement for machine %v", m)
+	}
+	units, err := m.Units()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, unit := range units {
+		if !unit.IsPrincipal() || unit.Life() != Alive {
+			continue
+		}
+		if err := m.moveUnit(unit, replacement, deadline); err != nil {
+			return nil, errors.Annotatef(err, "cannot move unit %q to machine %v", unit.Name(), replacement)
+		}
+	}
+	now := m.st.clock().Now()
+	if err := m.SetStatus(status.StatusInfo{
+		Status: status.Interrupted,
+		Message: fmt.Sprintf(
+			"instance will be reclaimed at %s; units moved to machine %s",
+			deadline.UTC().Format(time.RFC3339), replacement.Id(),
+		),
+		Since: &now,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return replacement, nil
+}
+
+// addReplacement adds the machine that will take over this machine's
+// units, unless it has already been added, and returns it.
+func (m *Machine) addReplacement() (*Machine, error) {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if m.doc.Replacement != "" {
+			return nil, jujutxn.ErrNoOperations
+		}
+		if m.doc.Life != Alive {
+			return nil, errors.Errorf("machine is not alive")
+		}
+		cons, err := m.Constraints()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		mdoc, ops, err := m.st.addMachineOps(MachineTemplate{
+			Series:      m.doc.Series,
+			Constraints: cons,
+			Jobs:        m.doc.Jobs,
+		})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, txn.Op{
+			C:  machinesC,
+			Id: m.doc.DocID,
+			Assert: bson.D{
+				{"life", Alive},
+				{"replacement", bson.D{{"$exists", false}}},
+			},
+			Update: bson.D{{"$set", bson.D{{"replacement", mdoc.Id}}}},
+		}), nil
+	}
+	if err := m.st.db().Run(buildTxn); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := m.Refresh(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return m.st.Machine(m.doc.Replacement)
+}
+
+// moveUnit adds a unit of the given unit's application to the
+// replacement machine, and destroys the given unit, allowing its charm
+// to drain until the deadline.
+func (m *Machine) moveUnit(unit *Unit, replacement *Machine, deadline time.Time) error {
+	app, err := unit.Application()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	newUnit, err := app.AddUnit(AddUnitParams{})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := newUnit.AssignToMachine(replacement); err != nil {
+		return errors.Trace(err)
+	}
+	op := unit.DestroyOperation()
+	if timeout := deadline.Sub(m.st.clock().Now()); timeout > 0 {
+		op.DrainTimeout = timeout
+	}
+	return m.st.ApplyOperation(op)
+}
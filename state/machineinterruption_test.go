@@ -0,0 +1,112 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+type MachineInterruptionSuite struct {
+	ConnSuite
+	machine *state.Machine
+	unit    *state.Unit
+}
+
+var _ = gc.Suite(&MachineInterruptionSuite{})
+
+func (s *MachineInterruptionSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	var err error
+	s.machine, err = s.State.AddOneMachine(state.MachineTemplate{
+		Series:      "quantal",
+		Constraints: constraints.MustParse("mem=4G"),
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.unit, err = app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.AssignToMachine(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+	now := s.Clock.Now()
+	err = s.unit.SetAgentStatus(status.StatusInfo{
+		Status: status.Idle,
+		Since:  &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *MachineInterruptionSuite) TestInterrupt(c *gc.C) {
+	deadline := s.Clock.Now().Add(2 * time.Minute)
+	replacement, err := s.machine.Interrupt(deadline)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(replacement.Id(), gc.Not(gc.Equals), s.machine.Id())
+	c.Assert(replacement.Series(), gc.Equals, "quantal")
+	c.Assert(replacement.Jobs(), jc.DeepEquals, []state.MachineJob{state.JobHostUnits})
+	cons, err := replacement.Constraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cons, jc.DeepEquals, constraints.MustParse("mem=4G"))
+
+	id, ok := s.machine.Replacement()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(id, gc.Equals, replacement.Id())
+
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.Life(), gc.Equals, state.Dying)
+	drainDeadline, ok := s.unit.DrainDeadline()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(drainDeadline.Equal(deadline), jc.IsTrue)
+
+	units, err := replacement.Units()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 1)
+	c.Assert(units[0].ApplicationName(), gc.Equals, "wordpress")
+	c.Assert(units[0].Life(), gc.Equals, state.Alive)
+
+	statusInfo, err := s.machine.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusInfo.Status, gc.Equals, status.Interrupted)
+	c.Assert(statusInfo.Message, gc.Matches, "instance will be reclaimed at .*; units moved to machine "+replacement.Id())
+}
+
+func (s *MachineInterruptionSuite) TestInterruptTwice(c *gc.C) {
+	deadline := s.Clock.Now().Add(2 * time.Minute)
+	replacement, err := s.machine.Interrupt(deadline)
+	c.Assert(err, jc.ErrorIsNil)
+
+	machine, err := s.State.Machine(s.machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	again, err := machine.Interrupt(deadline)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(again.Id(), gc.Equals, replacement.Id())
+
+	units, err := replacement.Units()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 1)
+}
+
+func (s *MachineInterruptionSuite) TestInterruptController(c *gc.C) {
+	controller, err := s.State.AddMachine("quantal", state.JobManageModel)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = controller.Interrupt(s.Clock.Now())
+	c.Assert(err, gc.ErrorMatches, `replacing controller machine \d+ not supported`)
+}
+
+func (s *MachineInterruptionSuite) TestInterruptDeadMachine(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = machine.Interrupt(s.Clock.Now())
+	c.Assert(err, gc.ErrorMatches, `cannot add replacement for machine \d+: machine is not alive`)
+}
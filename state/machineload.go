@@ -0,0 +1,266 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/instance"
+)
+
+// machineSaturationPercent is the share of a machine's memory, or of
+// its CPUs, that the units on the machine must use for it to be
+// saturated.
+const machineSaturationPercent = 90
+
+// MachineLoad describes the units hosted by a machine, and the
+// resources they were most recently reported to use.
+type MachineLoad struct {
+	// MachineId is the id of the machine.
+	MachineId string
+
+	// Series is the series of the machine.
+	Series string
+
+	// Zone is the availability zone of the machine, if known.
+	Zone string
+
+	// Hardware holds the hardware characteristics of the machine,
+	// or nil if it is not provisioned.
+	Hardware *instance.HardwareCharacteristics
+
+	// Units holds the names of the principal units on the machine,
+	// sorted.
+	Units []string
+
+	// Applications holds the names of the applications of the
+	// units, sorted.
+	Applications []string
+
+	// CPUPercent is the share of a single CPU used by the units,
+	// so that it may exceed 100 on machines with more than one CPU.
+	CPUPercent float64
+
+	// MemoryBytes is the memory used by the units.
+	MemoryBytes int64
+
+	// Saturation describes each of the machine's resources that its
+	// units use up, if any. Units cannot be placed on a saturated
+	// machine.
+	Saturation []string
+}
+
+// Saturated reports whether the units on the machine use up any of
+// its resources.
+func (l MachineLoad) Saturated() bool {
+	return len(l.Saturation) > 0
+}
+
+// Load returns the load that the units on the machine put on it.
+func (m *Machine) Load() (MachineLoad, error) {
+	load, err := machineLoad(m.st.db(), &m.doc)
+	if err != nil {
+		return MachineLoad{}, errors.Annotatef(err, "cannot get load of machine %s", m.Id())
+	}
+	return load, nil
+}
+
+// PlacementMachines returns the load of each of the alive machines
+// of the model that can host units, ordered by id.
+func (st *State) PlacementMachines() ([]MachineLoad, error) {
+	machinesCollection, closer := st.db().GetCollection(machinesC)
+	defer closer()
+
+	var mdocs []machineDoc
+	err := machinesCollection.Find(bson.D{
+		{"life", Alive},
+		{"jobs", JobHostUnits},
+	}).All(&mdocs)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get machines")
+	}
+	sort.Sort(machineDocSlice(mdocs))
+	loads := make([]MachineLoad, len(mdocs))
+	for i := range mdocs {
+		if loads[i], err = machineLoad(st.db(), &mdocs[i]); err != nil {
+			return nil, errors.Annotatef(err, "cannot get load of machine %s", mdocs[i].Id)
+		}
+	}
+	return loads, nil
+}
+
+// machineLoad returns the load of the machine with the given document,
+// which belongs to the model of the supplied database.
+func machineLoad(db Database, mdoc *machineDoc) (MachineLoad, error) {
+	load := MachineLoad{
+		MachineId: mdoc.Id,
+		Series:    mdoc.Series,
+	}
+
+	instanceDataCollection, closer := db.GetCollection(instanceDataC)
+	defer closer()
+	var instData instanceData
+	err := instanceDataCollection.FindId(mdoc.Id).One(&instData)
+	if err == nil {
+		load.Hardware = hardwareCharacteristics(instData)
+		if instData.AvailZone != nil {
+			load.Zone = *instData.AvailZone
+		}
+	} else if err != mgo.ErrNotFound {
+		return MachineLoad{}, errors.Trace(err)
+	}
+
+	utilizationCollection, closer := db.GetCollection(unitUtilizationC)
+	defer closer()
+	applications := set.NewStrings()
+	for _, name := range mdoc.Principals {
+		load.Units = append(load.Units, name)
+		if application, err := names.UnitApplication(name); err == nil {
+			applications.Add(application)
+		}
+		var doc unitUtilizationDoc
+		err := utilizationCollection.FindId(name).One(&doc)
+		if err == mgo.ErrNotFound {
+			continue
+		} else if err != nil {
+			return MachineLoad{}, errors.Trace(err)
+		}
+		load.CPUPercent += doc.CPUPercent
+		load.MemoryBytes += doc.MemoryBytes
+	}
+	sort.Strings(load.Units)
+	load.Applications = applications.SortedValues()
+	load.Saturation = saturation(load)
+	return load, nil
+}
+
+// saturation describes each of the resources of a machine with the
+// given load that its units use up. Resources whose size is not known
+// are never saturated.
+func saturation(load MachineLoad) []string {
+	if load.Hardware == nil {
+		return nil
+	}
+	var saturated []string
+	if mem := load.Hardware.Mem; mem != nil && *mem > 0 {
+		used := load.MemoryBytes * 100 / int64(*mem*1024*1024)
+		if used >= machineSaturationPercent {
+			saturated = append(saturated, fmt.Sprintf(
+				"units use %d%% of the machine's %dM memory", used, *mem,
+			))
+		}
+	}
+	if cores := load.Hardware.CpuCores; cores != nil && *cores > 0 {
+		used := int64(load.CPUPercent) / int64(*cores)
+		if used >= machineSaturationPercent {
+			saturated = append(saturated, fmt.Sprintf(
+				"units use %d%% of the machine's %d cores", used, *cores,
+			))
+		}
+	}
+	return saturated
+}
+
+// checkCanHostUnits returns an *ErrPlacementConflict if the machine
+// is saturated, so that units of the named application cannot be
+// placed on it, or in new containers on it. The error also reports
+// any units of the application already on the machine.
+func (st *State) checkCanHostUnits(m *Machine, applicationName string) error {
+	load, err := m.Load()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !load.Saturated() {
+		return nil
+	}
+	conflicts := append([]string(nil), load.Saturation...)
+	for _, name := range load.Units {
+		if application, _ := names.UnitApplication(name); application == applicationName {
+			conflicts = append(conflicts, fmt.Sprintf(
+				"application %q already has unit %s on the machine", applicationName, name,
+			))
+		}
+	}
+	return &ErrPlacementConflict{
+		MachineId: m.Id(),
+		Load:      load,
+		Conflicts: conflicts,
+	}
+}
+
+// foreignMachineError returns an *ErrPlacementConflict if the
+// placement targets a machine of another model of the controller,
+// either by naming that model as the placement's scope, or by
+// directing a new machine of this model to the instance of a machine
+// of another model. Machines cannot be shared between models. It
+// returns nil if the placement does not target such a machine.
+func (st *State) foreignMachineError(placement *instance.Placement) error {
+	var modelUUID, machineId string
+	if placement.Scope == st.ModelUUID() {
+		instanceDataCollection, closer := st.db().GetRawCollection(instanceDataC)
+		defer closer()
+		var instData instanceData
+		err := instanceDataCollection.Find(bson.D{
+			{"model-uuid", bson.D{{"$ne", st.ModelUUID()}}},
+			{"$or", []bson.D{
+				{{"instanceid", placement.Directive}},
+				{{"display-name", placement.Directive}},
+			}},
+		}).One(&instData)
+		if err == mgo.ErrNotFound {
+			return nil
+		} else if err != nil {
+			return errors.Annotate(err, "cannot check for instances of other models")
+		}
+		modelUUID, machineId = instData.ModelUUID, instData.MachineId
+	} else {
+		exists, err := st.ModelExists(placement.Scope)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !exists {
+			return nil
+		}
+		modelUUID, machineId = placement.Scope, placement.Directive
+	}
+
+	db, closer := st.db().CopyForModel(modelUUID)
+	defer closer()
+	machinesCollection, closer := db.GetCollection(machinesC)
+	defer closer()
+	var mdoc machineDoc
+	err := machinesCollection.FindId(machineId).One(&mdoc)
+	if err == mgo.ErrNotFound {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	load, err := machineLoad(db, &mdoc)
+	if err != nil {
+		return errors.Annotatef(err, "cannot get load of machine %s", machineId)
+	}
+
+	modelsCollection, closer := st.db().GetCollection(modelsC)
+	defer closer()
+	var model modelDoc
+	if err := modelsCollection.FindId(modelUUID).One(&model); err != nil {
+		return errors.Annotatef(err, "cannot get model %q", modelUUID)
+	}
+	return &ErrPlacementConflict{
+		MachineId:      machineId,
+		OwnerModelUUID: modelUUID,
+		OwnerModelName: model.Name,
+		Load:           load,
+		Conflicts: []string{
+			fmt.Sprintf("machine belongs to model %q, and machines cannot be shared between models", model.Name),
+		},
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type MachineLoadSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&MachineLoadSuite{})
+
+// makeLoadedMachine returns a provisioned machine hosting a unit that
+// uses the given memory.
+func (s *MachineLoadSuite) makeLoadedMachine(c *gc.C, hw string, memoryBytes int64) (*state.Machine, *state.Unit) {
+	hc := instance.MustParseHardware(hw)
+	m := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Characteristics: &hc,
+	})
+	u := s.Factory.MakeUnit(c, &factory.UnitParams{Machine: m})
+	err := u.SetUtilization(state.UnitUtilization{
+		CPUPercent:  50,
+		MemoryBytes: memoryBytes,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return m, u
+}
+
+func (s *MachineLoadSuite) TestPlacementMachines(c *gc.C) {
+	m0, u0 := s.makeLoadedMachine(c, "mem=1G cores=2 availability-zone=zone-a", 1000*1024*1024)
+	m1, u1 := s.makeLoadedMachine(c, "mem=4G cores=2", 1024*1024*1024)
+	m2, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	m3, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = m3.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	machines, err := s.State.PlacementMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 3)
+	c.Check(machines[0].MachineId, gc.Equals, m0.Id())
+	c.Check(machines[0].Zone, gc.Equals, "zone-a")
+	c.Check(machines[0].Units, jc.DeepEquals, []string{u0.Name()})
+	c.Check(machines[0].Applications, jc.DeepEquals, []string{u0.ApplicationName()})
+	c.Check(machines[0].CPUPercent, gc.Equals, 50.0)
+	c.Check(machines[0].MemoryBytes, gc.Equals, int64(1000*1024*1024))
+	c.Check(machines[0].Saturation, jc.DeepEquals, []string{"units use 97% of the machine's 1024M memory"})
+	c.Check(machines[1].MachineId, gc.Equals, m1.Id())
+	c.Check(machines[1].Units, jc.DeepEquals, []string{u1.Name()})
+	c.Check(machines[1].Saturated(), jc.IsFalse)
+	c.Check(machines[2], jc.DeepEquals, state.MachineLoad{
+		MachineId: m2.Id(),
+		Series:    "quantal",
+	})
+}
+
+func (s *MachineLoadSuite) TestAssignToSaturatedMachine(c *gc.C) {
+	m, u := s.makeLoadedMachine(c, "mem=1G cores=2", 512*1024*1024)
+	err := u.SetUtilization(state.UnitUtilization{CPUPercent: 190})
+	c.Assert(err, jc.ErrorIsNil)
+	app, err := u.Application()
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.AssignUnitWithPlacement(unit, &instance.Placement{
+		Scope: instance.MachineScope, Directive: m.Id(),
+	})
+	c.Assert(err, jc.Satisfies, state.IsPlacementConflictError)
+	c.Assert(err, gc.ErrorMatches, `cannot place units on machine `+m.Id()+`: `+
+		`units use 95% of the machine's 2 cores; `+
+		`application "`+app.Name()+`" already has unit `+u.Name()+` on the machine`)
+	_, err = unit.AssignedMachineId()
+	c.Assert(err, jc.Satisfies, errors.IsNotAssigned)
+
+	err = s.State.CheckPlacement("other", &instance.Placement{
+		Scope: string(instance.LXD), Directive: m.Id(),
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot place units on machine `+m.Id()+`: units use 95% of the machine's 2 cores`)
+}
+
+func (s *MachineLoadSuite) TestCheckPlacementOtherModel(c *gc.C) {
+	st := s.Factory.MakeModel(c, &factory.ModelParams{Name: "elsewhere"})
+	defer st.Close()
+	m := factory.NewFactory(st).MakeMachine(c, &factory.MachineParams{
+		InstanceId: "inst-elsewhere",
+	})
+
+	err := s.State.CheckPlacement("app", &instance.Placement{
+		Scope: st.ModelUUID(), Directive: m.Id(),
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot place units on machine `+m.Id()+` of model "elsewhere": `+
+		`machine belongs to model "elsewhere", and machines cannot be shared between models`)
+	conflict, ok := errors.Cause(err).(*state.ErrPlacementConflict)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(conflict.OwnerModelUUID, gc.Equals, st.ModelUUID())
+	c.Assert(conflict.Load.MachineId, gc.Equals, m.Id())
+
+	err = s.State.CheckPlacement("app", &instance.Placement{
+		Scope: s.State.ModelUUID(), Directive: "inst-elsewhere",
+	})
+	c.Assert(err, jc.Satisfies, state.IsPlacementConflictError)
+
+	err = s.State.CheckPlacement("app", &instance.Placement{
+		Scope: st.ModelUUID(), Directive: "42",
+	})
+	c.Assert(err, gc.ErrorMatches, `placement scope: invalid model UUID "`+st.ModelUUID()+`"`)
+	err = s.State.CheckPlacement("app", &instance.Placement{
+		Scope: s.State.ModelUUID(), Directive: "zone=a",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
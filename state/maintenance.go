@@ -0,0 +1,231 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// MaintenanceMode records that a machine, application or unit is
+// undergoing planned work. While it is in effect, alert-worthy
+// statuses of the entity are reported as maintenance, and the entity
+// cannot be removed without force.
+type MaintenanceMode struct {
+	// Tag identifies the machine, application or unit.
+	Tag names.Tag
+
+	// Reason describes the work being carried out.
+	Reason string
+
+	// Expires is the time after which the maintenance mode is no
+	// longer in effect. If it is zero, the maintenance mode is in
+	// effect until it is cleared.
+	Expires time.Time
+}
+
+// Expired reports whether the maintenance mode has expired at the
+// given time.
+func (m MaintenanceMode) Expired(now time.Time) bool {
+	return !m.Expires.IsZero() && !now.Before(m.Expires)
+}
+
+type maintenanceModeDoc struct {
+	DocID     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+	Tag       string `bson:"tag"`
+	Reason    string `bson:"reason"`
+	Expires   int64  `bson:"expires,omitempty"`
+}
+
+// SetMaintenanceMode puts the entity with the given tag into
+// maintenance mode, replacing any existing maintenance mode for that
+// entity.
+func (st *State) SetMaintenanceMode(mode MaintenanceMode) error {
+	if err := validateMaintenanceMode(mode, st.clock().Now()); err != nil {
+		return errors.Trace(err)
+	}
+	entityColl, id, err := st.tagToCollectionAndId(mode.Tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var expires int64
+	if !mode.Expires.IsZero() {
+		expires = mode.Expires.UnixNano()
+	}
+	docID := st.docID(mode.Tag.String())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if _, err := st.FindEntity(mode.Tag); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		ops := []txn.Op{{
+			C:      entityColl,
+			Id:     id,
+			Assert: txn.DocExists,
+		}}
+		exists, err := st.maintenanceModeExists(mode.Tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !exists {
+			return append(ops, txn.Op{
+				C:      maintenanceModesC,
+				Id:     docID,
+				Assert: txn.DocMissing,
+				Insert: &maintenanceModeDoc{
+					DocID:     docID,
+					ModelUUID: st.ModelUUID(),
+					Tag:       mode.Tag.String(),
+					Reason:    mode.Reason,
+					Expires:   expires,
+				},
+			}), nil
+		}
+		return append(ops, txn.Op{
+			C:      maintenanceModesC,
+			Id:     docID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"reason", mode.Reason},
+				{"expires", expires},
+			}}},
+		}), nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot set maintenance mode for %s", names.ReadableString(mode.Tag))
+	}
+	return nil
+}
+
+func validateMaintenanceMode(mode MaintenanceMode, now time.Time) error {
+	switch mode.Tag.(type) {
+	case names.MachineTag, names.ApplicationTag, names.UnitTag:
+	default:
+		return errors.NotValidf("maintenance mode for %v", mode.Tag)
+	}
+	if mode.Reason == "" {
+		return errors.NotValidf("empty maintenance reason")
+	}
+	if mode.Expired(now) {
+		return errors.NotValidf("expiry time %v in the past", mode.Expires)
+	}
+	return nil
+}
+
+func (st *State) maintenanceModeExists(tag names.Tag) (bool, error) {
+	coll, closer := st.db().GetCollection(maintenanceModesC)
+	defer closer()
+
+	n, err := coll.FindId(tag.String()).Count()
+	if err != nil {
+		return false, errors.Annotatef(err, "cannot read maintenance mode for %s", names.ReadableString(tag))
+	}
+	return n > 0, nil
+}
+
+// ClearMaintenanceMode takes the entity with the given tag out of
+// maintenance mode, if it is in it.
+func (st *State) ClearMaintenanceMode(tag names.Tag) error {
+	ops := []txn.Op{removeMaintenanceModeOp(st, tag)}
+	if err := st.db().RunTransaction(ops); err != nil {
+		return errors.Annotatef(err, "cannot clear maintenance mode for %s", names.ReadableString(tag))
+	}
+	return nil
+}
+
+// removeMaintenanceModeOp returns an operation that removes any
+// maintenance mode for the entity with the given tag.
+func removeMaintenanceModeOp(mb modelBackend, tag names.Tag) txn.Op {
+	return txn.Op{
+		C:      maintenanceModesC,
+		Id:     mb.docID(tag.String()),
+		Remove: true,
+	}
+}
+
+// MaintenanceMode returns the maintenance mode in effect for the
+// entity with the given tag. If there is none, or it has expired, an
+// error satisfying errors.IsNotFound is returned.
+func (st *State) MaintenanceMode(tag names.Tag) (MaintenanceMode, error) {
+	coll, closer := st.db().GetCollection(maintenanceModesC)
+	defer closer()
+
+	var doc maintenanceModeDoc
+	err := coll.FindId(tag.String()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return MaintenanceMode{}, errors.NotFoundf("maintenance mode for %s", names.ReadableString(tag))
+	} else if err != nil {
+		return MaintenanceMode{}, errors.Annotatef(err, "cannot read maintenance mode for %s", names.ReadableString(tag))
+	}
+	mode, err := doc.mode()
+	if err != nil {
+		return MaintenanceMode{}, errors.Trace(err)
+	}
+	if mode.Expired(st.clock().Now()) {
+		return MaintenanceMode{}, errors.NotFoundf("maintenance mode for %s", names.ReadableString(tag))
+	}
+	return mode, nil
+}
+
+// AllMaintenanceModes returns the maintenance modes in effect in the
+// model. Those that have expired are not included.
+func (st *State) AllMaintenanceModes() ([]MaintenanceMode, error) {
+	coll, closer := st.db().GetCollection(maintenanceModesC)
+	defer closer()
+
+	var docs []maintenanceModeDoc
+	if err := coll.Find(nil).Sort("tag").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read maintenance modes")
+	}
+	now := st.clock().Now()
+	var result []MaintenanceMode
+	for _, doc := range docs {
+		mode, err := doc.mode()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !mode.Expired(now) {
+			result = append(result, mode)
+		}
+	}
+	return result, nil
+}
+
+// CheckNotInMaintenance returns an error satisfying
+// IsInMaintenanceError if any of the entities with the given tags is
+// in maintenance mode.
+func (st *State) CheckNotInMaintenance(tags ...names.Tag) error {
+	for _, tag := range tags {
+		mode, err := st.MaintenanceMode(tag)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		return &ErrInMaintenance{Tag: mode.Tag, Reason: mode.Reason}
+	}
+	return nil
+}
+
+func (doc maintenanceModeDoc) mode() (MaintenanceMode, error) {
+	tag, err := names.ParseTag(doc.Tag)
+	if err != nil {
+		return MaintenanceMode{}, errors.Trace(err)
+	}
+	mode := MaintenanceMode{
+		Tag:    tag,
+		Reason: doc.Reason,
+	}
+	if doc.Expires != 0 {
+		mode.Expires = time.Unix(0, doc.Expires).UTC()
+	}
+	return mode, nil
+}
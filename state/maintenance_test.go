@@ -0,0 +1,148 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+type MaintenanceModeSuite struct {
+	ConnSuite
+	machine *state.Machine
+}
+
+var _ = gc.Suite(&MaintenanceModeSuite{})
+
+func (s *MaintenanceModeSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.machine = s.Factory.MakeMachine(c, nil)
+}
+
+func (s *MaintenanceModeSuite) TestNoMaintenanceMode(c *gc.C) {
+	_, err := s.State.MaintenanceMode(s.machine.Tag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `maintenance mode for machine 0 not found`)
+	c.Assert(s.State.CheckNotInMaintenance(s.machine.Tag()), jc.ErrorIsNil)
+}
+
+func (s *MaintenanceModeSuite) TestSetMaintenanceMode(c *gc.C) {
+	mode := state.MaintenanceMode{
+		Tag:    s.machine.Tag(),
+		Reason: "replacing disks",
+	}
+	err := s.State.SetMaintenanceMode(mode)
+	c.Assert(err, jc.ErrorIsNil)
+
+	got, err := s.State.MaintenanceMode(s.machine.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, jc.DeepEquals, mode)
+
+	// Setting again replaces the existing maintenance mode.
+	mode.Reason = "kernel upgrade"
+	mode.Expires = s.Clock.Now().Add(time.Hour).UTC()
+	err = s.State.SetMaintenanceMode(mode)
+	c.Assert(err, jc.ErrorIsNil)
+
+	all, err := s.State.AllMaintenanceModes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, jc.DeepEquals, []state.MaintenanceMode{mode})
+}
+
+func (s *MaintenanceModeSuite) TestMaintenanceModeExpires(c *gc.C) {
+	err := s.State.SetMaintenanceMode(state.MaintenanceMode{
+		Tag:     s.machine.Tag(),
+		Reason:  "replacing disks",
+		Expires: s.Clock.Now().Add(time.Hour),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Clock.Advance(time.Hour)
+	_, err = s.State.MaintenanceMode(s.machine.Tag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	all, err := s.State.AllMaintenanceModes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 0)
+}
+
+func (s *MaintenanceModeSuite) TestSetMaintenanceModeInvalid(c *gc.C) {
+	for _, test := range []struct {
+		mode   state.MaintenanceMode
+		expect string
+	}{{
+		mode:   state.MaintenanceMode{Tag: names.NewModelTag(s.State.ModelUUID()), Reason: "x"},
+		expect: `maintenance mode for model-.* not valid`,
+	}, {
+		mode:   state.MaintenanceMode{Tag: s.machine.Tag()},
+		expect: `empty maintenance reason not valid`,
+	}, {
+		mode:   state.MaintenanceMode{Tag: s.machine.Tag(), Reason: "x", Expires: s.Clock.Now()},
+		expect: `expiry time .* in the past not valid`,
+	}} {
+		err := s.State.SetMaintenanceMode(test.mode)
+		c.Check(err, gc.ErrorMatches, test.expect)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *MaintenanceModeSuite) TestSetMaintenanceModeMissingEntity(c *gc.C) {
+	err := s.State.SetMaintenanceMode(state.MaintenanceMode{
+		Tag:    names.NewUnitTag("missing/0"),
+		Reason: "replacing disks",
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set maintenance mode for unit missing/0: .*`)
+}
+
+func (s *MaintenanceModeSuite) TestClearMaintenanceMode(c *gc.C) {
+	err := s.State.SetMaintenanceMode(state.MaintenanceMode{
+		Tag:    s.machine.Tag(),
+		Reason: "replacing disks",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.ClearMaintenanceMode(s.machine.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.MaintenanceMode(s.machine.Tag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Clearing again is harmless.
+	err = s.State.ClearMaintenanceMode(s.machine.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *MaintenanceModeSuite) TestCheckNotInMaintenance(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	err := s.State.SetMaintenanceMode(state.MaintenanceMode{
+		Tag:    app.Tag(),
+		Reason: "database migration",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.CheckNotInMaintenance(s.machine.Tag(), app.Tag())
+	c.Assert(err, jc.Satisfies, state.IsInMaintenanceError)
+	c.Assert(err, gc.ErrorMatches, `application .* is in maintenance: database migration`)
+}
+
+func (s *MaintenanceModeSuite) TestMaintenanceModeRemovedWithMachine(c *gc.C) {
+	err := s.State.SetMaintenanceMode(state.MaintenanceMode{
+		Tag:    s.machine.Tag(),
+		Reason: "replacing disks",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	all, err := s.State.AllMaintenanceModes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 0)
+}
@@ -0,0 +1,144 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/maintenance"
+)
+
+type maintenanceWindowDoc struct {
+	DocID     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+	Name      string `bson:"name"`
+	Schedule  string `bson:"schedule"`
+	Duration  int64  `bson:"duration"`
+}
+
+func (doc maintenanceWindowDoc) window() maintenance.Window {
+	return maintenance.Window{
+		Name:     doc.Name,
+		Schedule: doc.Schedule,
+		Duration: time.Duration(doc.Duration),
+	}
+}
+
+// SetMaintenanceWindow defines a maintenance window in the model,
+// replacing any existing window with the same name.
+func (st *State) SetMaintenanceWindow(w maintenance.Window) error {
+	if err := w.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	docID := st.docID(w.Name)
+	buildTxn := func(int) ([]txn.Op, error) {
+		_, err := st.MaintenanceWindow(w.Name)
+		if errors.IsNotFound(err) {
+			return []txn.Op{{
+				C:      maintenanceWindowsC,
+				Id:     docID,
+				Assert: txn.DocMissing,
+				Insert: &maintenanceWindowDoc{
+					DocID:     docID,
+					ModelUUID: st.ModelUUID(),
+					Name:      w.Name,
+					Schedule:  w.Schedule,
+					Duration:  int64(w.Duration),
+				},
+			}}, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      maintenanceWindowsC,
+			Id:     docID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"schedule", w.Schedule},
+				{"duration", int64(w.Duration)},
+			}}},
+		}}, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot set maintenance window %q", w.Name)
+	}
+	return nil
+}
+
+// MaintenanceWindow returns the named maintenance window, or an error
+// satisfying errors.IsNotFound if the model does not define it.
+func (st *State) MaintenanceWindow(name string) (maintenance.Window, error) {
+	coll, closer := st.db().GetCollection(maintenanceWindowsC)
+	defer closer()
+
+	var doc maintenanceWindowDoc
+	err := coll.FindId(name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return maintenance.Window{}, errors.NotFoundf("maintenance window %q", name)
+	} else if err != nil {
+		return maintenance.Window{}, errors.Annotatef(err, "cannot read maintenance window %q", name)
+	}
+	return doc.window(), nil
+}
+
+// AllMaintenanceWindows returns the maintenance windows defined in
+// the model, ordered by name.
+func (st *State) AllMaintenanceWindows() ([]maintenance.Window, error) {
+	coll, closer := st.db().GetCollection(maintenanceWindowsC)
+	defer closer()
+
+	var docs []maintenanceWindowDoc
+	if err := coll.Find(nil).Sort("name").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read maintenance windows")
+	}
+	windows := make([]maintenance.Window, len(docs))
+	for i, doc := range docs {
+		windows[i] = doc.window()
+	}
+	return windows, nil
+}
+
+// RemoveMaintenanceWindow removes the named maintenance window from
+// the model, if it is defined.
+func (st *State) RemoveMaintenanceWindow(name string) error {
+	ops := []txn.Op{{
+		C:      maintenanceWindowsC,
+		Id:     st.docID(name),
+		Remove: true,
+	}}
+	if err := st.db().RunTransaction(ops); err != nil {
+		return errors.Annotatef(err, "cannot remove maintenance window %q", name)
+	}
+	return nil
+}
+
+// InMaintenanceWindow reports whether disruptive automated operations
+// may run in the model now: that is, whether the current time falls
+// within the periods of its maintenance-windows config or within any
+// of its maintenance windows. A model that defines neither is always
+// in a maintenance window.
+func (st *State) InMaintenanceWindow() (bool, error) {
+	model, err := st.Model()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	cfg, err := model.ModelConfig()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	periods, err := maintenance.ParsePeriods(cfg.MaintenanceWindows())
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	windows, err := st.AllMaintenanceWindows()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return maintenance.Open(periods, windows, st.clock().Now()), nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/maintenance"
+)
+
+type MaintenanceWindowSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&MaintenanceWindowSuite{})
+
+func (s *MaintenanceWindowSuite) TestNoMaintenanceWindows(c *gc.C) {
+	_, err := s.State.MaintenanceWindow("weekend")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `maintenance window "weekend" not found`)
+
+	windows, err := s.State.AllMaintenanceWindows()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(windows, gc.HasLen, 0)
+
+	open, err := s.State.InMaintenanceWindow()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(open, jc.IsTrue)
+}
+
+func (s *MaintenanceWindowSuite) TestSetMaintenanceWindow(c *gc.C) {
+	weekend := maintenance.Window{Name: "weekend", Schedule: "0 2 * * sat", Duration: 2 * time.Hour}
+	err := s.State.SetMaintenanceWindow(weekend)
+	c.Assert(err, jc.ErrorIsNil)
+	nightly := maintenance.Window{Name: "nightly", Schedule: "0 1 * * *", Duration: time.Hour}
+	err = s.State.SetMaintenanceWindow(nightly)
+	c.Assert(err, jc.ErrorIsNil)
+
+	got, err := s.State.MaintenanceWindow("weekend")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, jc.DeepEquals, weekend)
+
+	// Setting again replaces the existing window.
+	weekend.Schedule = "0 2 * * sat,sun"
+	weekend.Duration = 3 * time.Hour
+	err = s.State.SetMaintenanceWindow(weekend)
+	c.Assert(err, jc.ErrorIsNil)
+
+	windows, err := s.State.AllMaintenanceWindows()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(windows, jc.DeepEquals, []maintenance.Window{nightly, weekend})
+}
+
+func (s *MaintenanceWindowSuite) TestSetMaintenanceWindowInvalid(c *gc.C) {
+	err := s.State.SetMaintenanceWindow(maintenance.Window{Name: "weekend", Schedule: "0 2 * *", Duration: time.Hour})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `schedule "0 2 \* \*" has 4 fields, expected 5`)
+}
+
+func (s *MaintenanceWindowSuite) TestRemoveMaintenanceWindow(c *gc.C) {
+	err := s.State.SetMaintenanceWindow(maintenance.Window{Name: "weekend", Schedule: "0 2 * * sat", Duration: time.Hour})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveMaintenanceWindow("weekend")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.MaintenanceWindow("weekend")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Removing a window that is not defined succeeds.
+	err = s.State.RemoveMaintenanceWindow("weekend")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *MaintenanceWindowSuite) TestInMaintenanceWindow(c *gc.C) {
+	now := s.Clock.Now().UTC()
+	err := s.State.SetMaintenanceWindow(maintenance.Window{
+		Name:     "now",
+		Schedule: fmt.Sprintf("%d %d * * *", now.Minute(), now.Hour()),
+		Duration: time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	open, err := s.State.InMaintenanceWindow()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(open, jc.IsTrue)
+
+	s.Clock.Advance(2 * time.Hour)
+	open, err = s.State.InMaintenanceWindow()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(open, jc.IsFalse)
+
+	// The periods of the maintenance-windows config are also honoured.
+	now = s.Clock.Now().UTC()
+	err = s.Model.UpdateModelConfig(map[string]interface{}{
+		"maintenance-windows": fmt.Sprintf("daily %02d:00-%02d:00", now.Hour(), (now.Hour()+1)%24),
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	open, err = s.State.InMaintenanceWindow()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(open, jc.IsTrue)
+}
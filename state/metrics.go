@@ -21,6 +21,10 @@ var metricsLogger = loggo.GetLogger("juju.state.metrics")
 
 const (
 	CleanupAge = time.Hour * 24
+
+	// MetricsCollectionPeriod is the period at which unit agents
+	// collect the metrics of charms that declare them.
+	MetricsCollectionPeriod = 5 * time.Minute
 )
 
 // MetricBatch represents a batch of metrics reported from a unit.
@@ -39,6 +43,7 @@ type metricBatchDoc struct {
 	Unit           string    `bson:"unit"`
 	CharmURL       string    `bson:"charmurl"`
 	Sent           bool      `bson:"sent"`
+	SentTime       time.Time `bson:"sent-time,omitempty"`
 	DeleteTime     time.Time `bson:"delete-time"`
 	Created        time.Time `bson:"created"`
 	Metrics        []Metric  `bson:"metrics"`
@@ -406,6 +411,12 @@ func (m *MetricBatch) Sent() bool {
 	return m.doc.Sent
 }
 
+// SentTime returns the time at which the metric batch was sent to the
+// metric collection service, or the zero time if it has not been sent.
+func (m *MetricBatch) SentTime() time.Time {
+	return m.doc.SentTime
+}
+
 // Metrics returns the metrics in this batch.
 func (m *MetricBatch) Metrics() []Metric {
 	result := make([]Metric, len(m.doc.Metrics))
@@ -434,14 +445,14 @@ func (m *MetricBatch) UniqueMetrics() []Metric {
 // SetSent marks the metric has having been sent at
 // the specified time.
 func (m *MetricBatch) SetSent(t time.Time) error {
-	deleteTime := t.UTC().Add(CleanupAge)
-	ops := setSentOps([]string{m.UUID()}, deleteTime)
+	ops := setSentOps([]string{m.UUID()}, t.UTC())
 	if err := m.st.db().RunTransaction(ops); err != nil {
 		return errors.Annotatef(err, "cannot set metric sent for metric %q", m.UUID())
 	}
 
 	m.doc.Sent = true
-	m.doc.DeleteTime = deleteTime
+	m.doc.SentTime = t.UTC()
+	m.doc.DeleteTime = t.UTC().Add(CleanupAge)
 	return nil
 }
 
@@ -455,14 +466,15 @@ func (m *MetricBatch) SLACredentials() []byte {
 	return m.doc.SLACredentials
 }
 
-func setSentOps(batchUUIDs []string, deleteTime time.Time) []txn.Op {
+func setSentOps(batchUUIDs []string, sentTime time.Time) []txn.Op {
+	deleteTime := sentTime.Add(CleanupAge)
 	ops := make([]txn.Op, len(batchUUIDs))
 	for i, u := range batchUUIDs {
 		ops[i] = txn.Op{
 			C:      metricsC,
 			Id:     u,
 			Assert: txn.DocExists,
-			Update: bson.M{"$set": bson.M{"sent": true, "delete-time": deleteTime}},
+			Update: bson.M{"$set": bson.M{"sent": true, "sent-time": sentTime, "delete-time": deleteTime}},
 		}
 	}
 	return ops
@@ -470,10 +482,52 @@ func setSentOps(batchUUIDs []string, deleteTime time.Time) []txn.Op {
 
 // SetMetricBatchesSent sets sent on each MetricBatch corresponding to the uuids provided.
 func (st *State) SetMetricBatchesSent(batchUUIDs []string) error {
-	deleteTime := st.clock().Now().UTC().Add(CleanupAge)
-	ops := setSentOps(batchUUIDs, deleteTime)
+	ops := setSentOps(batchUUIDs, st.clock().Now().UTC())
 	if err := st.db().RunTransaction(ops); err != nil {
 		return errors.Annotatef(err, "cannot set metric sent in bulk call")
 	}
 	return nil
 }
+
+// UnitMetricsActivity describes when metrics were last collected from
+// a unit, and last sent to the metric collection service.
+type UnitMetricsActivity struct {
+	// LastCollected is when the most recent of the unit's metric
+	// batches was created.
+	LastCollected time.Time
+
+	// LastSent is when the most recently sent of the unit's metric
+	// batches was sent, or the zero time if none has been sent.
+	LastSent time.Time
+}
+
+// AllUnitsMetricsActivity returns the metrics activity of each of the
+// units of the model, keyed by unit name. Units with no metric batches
+// are omitted. Batches are removed some time after they are sent, so
+// the activity of a unit is forgotten if it stops collecting metrics.
+func (st *State) AllUnitsMetricsActivity() (map[string]UnitMetricsActivity, error) {
+	c, closer := st.db().GetCollection(metricsC)
+	defer closer()
+
+	var docs []metricBatchDoc
+	err := c.Find(nil).Select(bson.M{"unit": 1, "created": 1, "sent-time": 1}).All(&docs)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read metric batches")
+	}
+	result := make(map[string]UnitMetricsActivity)
+	for _, doc := range docs {
+		if doc.Unit == "" {
+			// Model metrics are not collected from a unit.
+			continue
+		}
+		activity := result[doc.Unit]
+		if doc.Created.After(activity.LastCollected) {
+			activity.LastCollected = doc.Created
+		}
+		if doc.SentTime.After(activity.LastSent) {
+			activity.LastSent = doc.SentTime
+		}
+		result[doc.Unit] = activity
+	}
+	return result, nil
+}
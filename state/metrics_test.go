@@ -186,6 +186,28 @@ func (s *MetricSuite) TestSetMetricSent(c *gc.C) {
 	saved, err = s.State.MetricBatch(added.UUID())
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(saved.Sent(), jc.IsTrue)
+	c.Assert(saved.SentTime().Equal(testing.NonZeroTime()), jc.IsTrue)
+}
+
+func (s *MetricSuite) TestAllUnitsMetricsActivity(c *gc.C) {
+	activity, err := s.State.AllUnitsMetricsActivity()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(activity, gc.HasLen, 0)
+
+	created := testing.NonZeroTime().Add(-time.Hour)
+	m := []state.Metric{{Key: "pings", Value: "5", Time: created}}
+	first := s.Factory.MakeMetric(c, &factory.MetricParams{Unit: s.unit, Time: &created, Metrics: m})
+	later := created.Add(state.MetricsCollectionPeriod)
+	s.Factory.MakeMetric(c, &factory.MetricParams{Unit: s.unit, Time: &later, Metrics: m})
+	sent := later.Add(time.Minute)
+	err = first.SetSent(sent)
+	c.Assert(err, jc.ErrorIsNil)
+
+	activity, err = s.State.AllUnitsMetricsActivity()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(activity, gc.HasLen, 1)
+	c.Assert(activity[s.unit.Name()].LastCollected.Equal(later), jc.IsTrue)
+	c.Assert(activity[s.unit.Name()].LastSent.Equal(sent), jc.IsTrue)
 }
 
 func (s *MetricSuite) TestCleanupMetrics(c *gc.C) {
@@ -242,8 +242,14 @@ func (e *exporter) readBlocks() (map[string]string, error) {
 		return nil, errors.Trace(err)
 	}
 
+	modelTag := names.NewModelTag(e.st.ModelUUID()).String()
 	result := make(map[string]string)
 	for _, doc := range docs {
+		// Blocks on applications and machines cannot be
+		// represented in the model description.
+		if doc.Tag != modelTag {
+			return nil, errors.NotSupportedf("migrating block %q on %q", doc.Type, doc.Tag)
+		}
 		// We don't care about the id, uuid, or tag.
 		// The uuid and tag both refer to the model uuid, and the
 		// id is opaque - even though it is sequence generated.
@@ -914,6 +920,9 @@ func (e *exporter) relations() error {
 		} else if !errors.IsNotFound(err) {
 			return errors.Annotatef(err, "status for relation %v", relation.Id())
 		}
+		// The model description has nowhere to record the status
+		// history of relations, so it is not migrated.
+		delete(e.statusHistory, globalKey)
 
 		isRemote := false
 		for _, ep := range relation.Endpoints() {
@@ -1166,6 +1175,15 @@ func (e *exporter) readAllRelationScopes() (set.Strings, error) {
 
 	result := set.NewStrings()
 	for _, doc := range docs {
+		if doc.Departed {
+			// The model description has no field for Departed,
+			// and dropping it would leave a relation-broken
+			// barrier waiting for a departure that has already
+			// been recorded.
+			return nil, errors.Errorf(
+				"cannot export relation scope %q: unit is waiting to run relation-broken", doc.Key,
+			)
+		}
 		result.Add(doc.Key)
 	}
 	return result, nil
@@ -1496,6 +1514,15 @@ func (e *exporter) constraintsArgs(globalKey string) (description.ConstraintsArg
 	if optionalErr != nil {
 		return description.ConstraintsArgs{}, errors.Trace(optionalErr)
 	}
+	// The model description has nowhere to record instance attributes,
+	// so refuse to migrate rather than silently dropping them.
+	if attrs, ok := doc["instanceattributes"].(bson.M); ok && len(attrs) > 0 {
+		return description.ConstraintsArgs{}, errors.NotSupportedf("migrating instance-attributes constraint for %q", globalKey)
+	}
+	// Nor has it anywhere to record a request for spot instances.
+	if spot, ok := doc["spot"].(bool); ok && spot {
+		return description.ConstraintsArgs{}, errors.NotSupportedf("migrating spot constraint for %q", globalKey)
+	}
 	return result, nil
 }
 
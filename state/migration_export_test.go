@@ -619,6 +619,25 @@ func (s *MigrationExportSuite) TestRelations(c *gc.C) {
 	c.Check(status.Value(), gc.Equals, "joining")
 }
 
+func (s *MigrationExportSuite) TestRelationScopeDeparted(c *gc.C) {
+	wordpress := state.AddTestingApplication(c, s.State, "wordpress", state.AddTestingCharm(c, s.State, "wordpress"))
+	state.AddTestingApplication(c, s.State, "mysql", state.AddTestingCharm(c, s.State, "mysql"))
+	eps, err := s.State.InferEndpoints("mysql", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	wordpress_0 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: wordpress})
+	ru, err := rel.Unit(wordpress_0)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.SetDeparted()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Export()
+	c.Assert(err, gc.ErrorMatches, `cannot export relation scope ".*#wordpress/0": unit is waiting to run relation-broken`)
+}
+
 func (s *MigrationExportSuite) TestSubordinateRelations(c *gc.C) {
 	wordpress := state.AddTestingApplication(c, s.State, "wordpress", state.AddTestingCharm(c, s.State, "wordpress"))
 	mysql := state.AddTestingApplication(c, s.State, "mysql", state.AddTestingCharm(c, s.State, "mysql"))
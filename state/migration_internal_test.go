@@ -83,6 +83,21 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		autocertCacheC,
 		// We don't export the controller model at this stage.
 		controllersC,
+		// Controller health is reported by, and specific to, the
+		// controller machines.
+		controllerHealthC,
+		// Database maintenance is reported by, and specific to, the
+		// controller machines.
+		dbMaintenanceC,
+		// Model assignments name the source controller's machines;
+		// the target assigns the model to one of its own.
+		modelAssignmentsC,
+		// The registry of interface schemas is controller global,
+		// and rebuilt from the charms added to the target controller.
+		interfaceSchemasC,
+		// The trust store is controller global; the target
+		// controller's administrators pin their own certificates.
+		trustedCACertsC,
 		// Clouds aren't migrated. They must exist in the
 		// target controller already.
 		cloudsC,
@@ -97,6 +112,12 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// Users aren't migrated.
 		usersC,
 		userLastLoginC,
+		// API activity is counted by the source controller's API
+		// servers, and starts afresh on the target.
+		modelUserActivityC,
+		// Break-glass sessions elevate users of the source
+		// controller, and are not carried to the target.
+		breakGlassSessionsC,
 		// Controller users contain extra data about users therefore
 		// are not migrated either.
 		controllerUsersC,
@@ -178,10 +199,81 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// independent global clock.
 		globalClockC,
 
+		// Agent logging overrides are short-lived debugging aids,
+		// and are not carried across to the target controller.
+		agentLoggingOverridesC,
+
+		// Uniter state snapshots are not migrated. Unit agents keep
+		// their local state across a migration, and record it again
+		// with the target controller when they restart.
+		uniterStatesC,
+
+		// Branches are staged configuration rollouts, which must
+		// be committed or aborted on the source controller.
+		generationsC,
+
+		// Container image caches describe images held on each
+		// machine, which the machine agents report again to the
+		// target controller.
+		containerImageCachesC,
+
+		// Unit utilization is sampled afresh by the machine agents,
+		// which report it to the target controller.
+		unitUtilizationC,
+
+		// Fan status is checked afresh by the machine agents,
+		// which report it to the target controller.
+		machineFanStatusC,
+
+		// Maintenance modes cover planned work on the source
+		// controller's machines, and are set again if the work
+		// continues after migration.
+		maintenanceModesC,
+
+		// Offline acknowledgements cover hardware maintenance on
+		// the source controller's machines, and are made again if
+		// the agents are still offline after migration.
+		agentsOfflineC,
+
+		// Orphaned resources are specific to the provider resources
+		// seen by the source controller, and are found again by the
+		// next sweep on the target.
+		orphanedResourcesC,
+
+		// Model quotas are a policy of the source controller's
+		// administrators, who may set them again on the target.
+		modelQuotasC,
+
+		// Model snapshots record the history of the model on the
+		// source controller, and are taken afresh on the target.
+		modelSnapshotsC,
+
+		// Pending charm upgrades are found again by the target
+		// controller's charm revision updater.
+		pendingCharmUpgradesC,
+
+		// Maintenance windows are an operational policy of the
+		// source controller's users, who may define them again on
+		// the target.
+		maintenanceWindowsC,
+
+		// The model activity feed is a record of what happened on
+		// the source controller, and is started afresh on the target.
+		modelEventsC,
+
+		// Crash reports describe agent crashes on the source
+		// controller's machines, and are not needed on the target.
+		crashReportsC,
+
+		// Firewall reports are made again by the target controller's
+		// firewaller.
+		firewallReportsC,
+
 		// Leases are not migrated either. When an application is migrated,
 		// we include the name of the leader unit. On import, a new lease
 		// is created for the leader unit.
 		leasesC,
+		leaseHoldersC,
 	)
 
 	// THIS SET WILL BE REMOVED WHEN MIGRATIONS ARE COMPLETE
@@ -225,6 +317,10 @@ func (s *MigrationSuite) TestModelDocFields(c *gc.C) {
 		"Name",
 		// Life will always be alive, or we won't be migrating.
 		"Life",
+		// TimeOfDying and DestroyOperation are only set once
+		// the model is no longer alive.
+		"TimeOfDying",
+		"DestroyOperation",
 		// ControllerUUID is recreated when the new model
 		// is created in the new controller (yay name changes).
 		"ControllerUUID",
@@ -301,6 +397,21 @@ func (s *MigrationSuite) TestMachineDocFields(c *gc.C) {
 		// Ignored at this stage, could be an issue if mongo 3.0 isn't
 		// available.
 		"StopMongoUntilVersion",
+		// CloudInitUserData is only used when provisioning, and the
+		// migration prechecks require machines to be provisioned.
+		"CloudInitUserData",
+		// ProxyStatus is reported again by the machine agent once it
+		// connects to the target controller.
+		"ProxyStatus",
+		// FQDN is registered again by the target controller's DNS
+		// updater.
+		"FQDN",
+		// CharmProfiles are recorded again by the target
+		// controller's provisioners when they next check them.
+		"CharmProfiles",
+		// Replacement is only set on machines whose instances are
+		// about to be reclaimed, which aren't worth migrating.
+		"Replacement",
 	)
 	migrated := set.NewStrings(
 		"Addresses",
@@ -329,6 +440,8 @@ func (s *MigrationSuite) TestInstanceDataFields(c *gc.C) {
 		// KeepInstance is only set when a machine is
 		// dying/dead (to be removed).
 		"KeepInstance",
+		// DisplayName isn't part of the model description yet.
+		"DisplayName",
 	)
 	migrated := set.NewStrings(
 		// DocID is the env + machine id
@@ -368,6 +481,18 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		"RelationCount",
 		// TODO(caas) - add to export/import
 		"PasswordHash",
+		// RelationBrokenBarrier is not yet supported by the model
+		// description, so it is reset by migration.
+		"RelationBrokenBarrier",
+		// LoadBalancerAddress is recorded again by the target
+		// controller's load balancer manager.
+		"LoadBalancerAddress",
+		// TrustLevel is not yet supported by the model description,
+		// so applications must be trusted again after migration.
+		"TrustLevel",
+		// RefreshPolicy is not yet supported by the model
+		// description, so migrated applications are pinned.
+		"RefreshPolicy",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -397,6 +522,9 @@ func (s *MigrationSuite) TestUnitDocFields(c *gc.C) {
 		"Series",
 		"CharmURL",
 		"TxnRevno",
+		// DrainDeadline is only set on dying units, which
+		// cannot be migrated.
+		"DrainDeadline",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -483,6 +611,9 @@ func (s *MigrationSuite) TestRelationScopeDocFields(c *gc.C) {
 		"Key",
 		// Departing isn't exported as we only deal with live, stable systems.
 		"Departing",
+		// Departed can't be exported, as the model description has
+		// no field for it; export fails if it is set.
+		"Departed",
 	)
 	s.AssertExportedFields(c, relationScopeDoc{}, fields)
 }
@@ -546,22 +677,35 @@ func (s *MigrationSuite) TestConstraintsDocFields(c *gc.C) {
 		"Tags",
 		"Spaces",
 		"VirtType",
+		// InstanceAttributes can't be exported, as the model
+		// description has no field for them; export fails if
+		// any are set.
+		"InstanceAttributes",
+		// Spot can't be exported either; export fails if it
+		// is true.
+		"Spot",
 	)
 	s.AssertExportedFields(c, constraintsDoc{}, fields)
 }
 
 func (s *MigrationSuite) TestHistoricalStatusDocFields(c *gc.C) {
-	fields := set.NewStrings(
+	ignored := set.NewStrings(
 		// ModelUUID shouldn't be exported, and is inherited
 		// from the model definition.
 		"ModelUUID",
+		// The model description's status history has no field
+		// for NeverSet. Imported history is treated like history
+		// recorded by older versions of juju, which never set it.
+		"NeverSet",
+	)
+	migrated := set.NewStrings(
 		"GlobalKey",
 		"Status",
 		"StatusInfo",
 		"StatusData",
 		"Updated",
 	)
-	s.AssertExportedFields(c, historicalStatusDoc{}, fields)
+	s.AssertExportedFields(c, historicalStatusDoc{}, migrated.Union(ignored))
 }
 
 func (s *MigrationSuite) TestSpaceDocFields(c *gc.C) {
@@ -6,9 +6,11 @@ package state
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils"
 	"github.com/juju/utils/featureflag"
 	"github.com/juju/version"
 	"gopkg.in/juju/names.v2"
@@ -113,6 +115,13 @@ type modelDoc struct {
 
 	// MeterStatus is the current meter status of the model.
 	MeterStatus modelMeterStatusdoc `bson:"meter-status"`
+
+	// TimeOfDying is when the model was destroyed.
+	TimeOfDying time.Time `bson:"time-of-dying,omitempty"`
+
+	// DestroyOperation identifies the operation that destroyed
+	// the model.
+	DestroyOperation string `bson:"destroy-operation,omitempty"`
 }
 
 // slaLevel enumerates the support levels available to a model.
@@ -382,6 +391,7 @@ func (st *State) NewModel(args ModelArgs) (_ *Model, _ *State, err error) {
 		}
 	}()
 	newSt.controllerModelTag = st.controllerModelTag
+	newSt.raftLeases = st.raftLeases
 
 	modelOps, modelStatusDoc, err := newSt.modelSetupOps(st.controllerTag.Id(), args, nil)
 	if err != nil {
@@ -629,17 +639,22 @@ func (m *Model) localID(ID string) string {
 
 // SetStatus sets the status of the model.
 func (m *Model) SetStatus(sInfo status.StatusInfo) error {
+	return setEntityStatus(m, sInfo)
+}
+
+// statusUpdate is part of the statusUpdater interface.
+func (m *Model) statusUpdate(sInfo status.StatusInfo) (Database, setStatusParams, error) {
 	if !status.ValidModelStatus(sInfo.Status) {
-		return errors.Errorf("cannot set invalid status %q", sInfo.Status)
+		return nil, setStatusParams{}, errors.Errorf("cannot set invalid status %q", sInfo.Status)
 	}
-	return setStatus(m.st.db(), setStatusParams{
+	return m.st.db(), setStatusParams{
 		badge:     "model",
 		globalKey: m.globalKey(),
 		status:    sInfo.Status,
 		message:   sInfo.Message,
 		rawData:   sInfo.Data,
 		updated:   timeOrNow(sInfo.Since, m.st.clock()),
-	})
+	}, nil
 }
 
 // StatusHistory returns a slice of at most filter.Size StatusInfo items
@@ -1187,10 +1202,15 @@ func (m *Model) destroyOps(
 		)
 	}
 
+	operationId, err := utils.NewUUID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	timeOfDying := m.st.nowToTheSecond()
 	modelUpdateValues := bson.D{
 		{"life", nextLife},
 		{"time-of-dying", timeOfDying},
+		{"destroy-operation", operationId.String()},
 	}
 	var ops []txn.Op
 	if nextLife == Dead {
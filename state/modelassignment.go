@@ -0,0 +1,121 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// modelAssignmentDoc records the controller machine responsible for
+// running a hosted model's workers.
+type modelAssignmentDoc struct {
+	ModelUUID string `bson:"_id"`
+	MachineId string `bson:"machine-id"`
+}
+
+// ModelAssignments returns the id of the controller machine assigned
+// to each model, keyed by model UUID. Models without an assignment
+// are omitted.
+func (st *State) ModelAssignments() (map[string]string, error) {
+	coll, closer := st.db().GetCollection(modelAssignmentsC)
+	defer closer()
+
+	var docs []modelAssignmentDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read model assignments")
+	}
+	assignments := make(map[string]string, len(docs))
+	for _, doc := range docs {
+		assignments[doc.ModelUUID] = doc.MachineId
+	}
+	return assignments, nil
+}
+
+// ModelAssignment returns the id of the controller machine assigned
+// to the model with the given UUID, or an error satisfying
+// errors.IsNotFound if the model has no assignment.
+func (st *State) ModelAssignment(modelUUID string) (string, error) {
+	coll, closer := st.db().GetCollection(modelAssignmentsC)
+	defer closer()
+
+	var doc modelAssignmentDoc
+	err := coll.FindId(modelUUID).One(&doc)
+	if err == mgo.ErrNotFound {
+		return "", errors.NotFoundf("assignment for model %q", modelUUID)
+	} else if err != nil {
+		return "", errors.Annotatef(err, "cannot read assignment for model %q", modelUUID)
+	}
+	return doc.MachineId, nil
+}
+
+// SetModelAssignments replaces the controller machine assignments of
+// all models with those supplied, keyed by model UUID. Models not
+// included are left unassigned.
+func (st *State) SetModelAssignments(assignments map[string]string) error {
+	for modelUUID, machineId := range assignments {
+		if !names.IsValidMachine(machineId) {
+			return errors.NotValidf("machine id %q for model %q", machineId, modelUUID)
+		}
+	}
+	buildTxn := func(int) ([]txn.Op, error) {
+		current, err := st.ModelAssignments()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var ops []txn.Op
+		for modelUUID, machineId := range assignments {
+			existing, ok := current[modelUUID]
+			switch {
+			case !ok:
+				ops = append(ops, txn.Op{
+					C:      modelAssignmentsC,
+					Id:     modelUUID,
+					Assert: txn.DocMissing,
+					Insert: &modelAssignmentDoc{
+						ModelUUID: modelUUID,
+						MachineId: machineId,
+					},
+				})
+			case existing != machineId:
+				ops = append(ops, txn.Op{
+					C:      modelAssignmentsC,
+					Id:     modelUUID,
+					Assert: bson.D{{"machine-id", existing}},
+					Update: bson.D{{"$set", bson.D{{"machine-id", machineId}}}},
+				})
+			}
+		}
+		for modelUUID, existing := range current {
+			if _, ok := assignments[modelUUID]; ok {
+				continue
+			}
+			ops = append(ops, txn.Op{
+				C:      modelAssignmentsC,
+				Id:     modelUUID,
+				Assert: bson.D{{"machine-id", existing}},
+				Remove: true,
+			})
+		}
+		if len(ops) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return ops, nil
+	}
+	return errors.Annotate(st.db().Run(buildTxn), "cannot set model assignments")
+}
+
+// WatchModelAssignments returns a StringsWatcher that notifies of
+// changes to the controller machine assignments of models, reporting
+// the UUIDs of the models whose assignments have changed.
+func (st *State) WatchModelAssignments() StringsWatcher {
+	return newCollectionWatcher(st, colWCfg{
+		col:    modelAssignmentsC,
+		global: true,
+	})
+}
@@ -0,0 +1,89 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type modelAssignmentSuite struct {
+	statetesting.StateSuite
+}
+
+var _ = gc.Suite(&modelAssignmentSuite{})
+
+func (s *modelAssignmentSuite) TestModelAssignmentsEmpty(c *gc.C) {
+	assignments, err := s.State.ModelAssignments()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(assignments, gc.HasLen, 0)
+
+	_, err = s.State.ModelAssignment("some-uuid")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `assignment for model "some-uuid" not found`)
+}
+
+func (s *modelAssignmentSuite) TestSetModelAssignments(c *gc.C) {
+	err := s.State.SetModelAssignments(map[string]string{
+		"uuid-a": "0",
+		"uuid-b": "1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.SetModelAssignments(map[string]string{
+		"uuid-b": "2",
+		"uuid-c": "0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	assignments, err := s.State.ModelAssignments()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(assignments, jc.DeepEquals, map[string]string{
+		"uuid-b": "2",
+		"uuid-c": "0",
+	})
+	machineId, err := s.State.ModelAssignment("uuid-b")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineId, gc.Equals, "2")
+	_, err = s.State.ModelAssignment("uuid-a")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *modelAssignmentSuite) TestSetModelAssignmentsInvalidMachine(c *gc.C) {
+	err := s.State.SetModelAssignments(map[string]string{"uuid-a": "0/lxd"})
+	c.Assert(err, gc.ErrorMatches, `machine id "0/lxd" for model "uuid-a" not valid`)
+}
+
+func (s *modelAssignmentSuite) TestWatchModelAssignments(c *gc.C) {
+	w := s.State.WatchModelAssignments()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange()
+	wc.AssertNoChange()
+
+	err := s.State.SetModelAssignments(map[string]string{
+		"uuid-a": "0",
+		"uuid-b": "1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange("uuid-a", "uuid-b")
+	wc.AssertNoChange()
+
+	// Unchanged assignments are not reported.
+	err = s.State.SetModelAssignments(map[string]string{
+		"uuid-a": "0",
+		"uuid-b": "2",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange("uuid-b")
+	wc.AssertNoChange()
+
+	err = s.State.SetModelAssignments(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange("uuid-a", "uuid-b")
+	wc.AssertNoChange()
+}
@@ -4,6 +4,8 @@
 package state
 
 import (
+	"sort"
+
 	"github.com/juju/errors"
 	"github.com/juju/schema"
 
@@ -79,7 +81,7 @@ func (st *State) inheritedConfigAttributes() (map[string]interface{}, error) {
 // when combined with controller and Juju defaults.
 func (model *Model) modelConfigValues(modelCfg attrValues) (config.ConfigValues, error) {
 	resultValues := make(attrValues)
-	for k, v := range modelCfg {
+	for k, v := range config.NormalizeNumbers(modelCfg) {
 		resultValues[k] = v
 	}
 
@@ -101,6 +103,9 @@ func (model *Model) modelConfigValues(modelCfg attrValues) (config.ConfigValues,
 		if err != nil {
 			return nil, errors.Annotatef(err, "reading %s settings", src.name)
 		}
+		// Numbers may have been stored as floats or ints depending
+		// on how they were set, so normalize them for comparison.
+		cfg = config.NormalizeNumbers(cfg)
 		sourceAttrs = append(sourceAttrs, cfg)
 
 		// If no modelCfg was passed in, we'll accumulate data
@@ -138,6 +143,7 @@ func (model *Model) modelConfigValues(modelCfg attrValues) (config.ConfigValues,
 
 // UpdateModelConfigDefaultValues updates the inherited settings used when creating a new model.
 func (model *Model) UpdateModelConfigDefaultValues(attrs map[string]interface{}, removed []string, regionSpec *environs.RegionSpec) error {
+	attrs = config.NormalizeNumbers(attrs)
 	var key string
 
 	if regionSpec != nil {
@@ -280,6 +286,43 @@ func (m *Model) UpdateModelConfig(updateAttrs map[string]interface{}, removeAttr
 		return nil
 	}
 
+	// TODO(axw) 2013-12-6 #1167616
+	// Ensure that the settings on disk have not changed
+	// underneath us. The settings changes are actually
+	// applied as a delta to what's on disk; if there has
+	// been a concurrent update, the change may not be what
+	// the user asked for.
+
+	st := m.State()
+	modelSettings, err := readSettings(st.db(), settingsC, modelGlobalKey)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	oldConfig, validCfg, err := m.ValidateModelConfig(updateAttrs, removeAttrs, additionalValidation...)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	validAttrs := validCfg.AllAttrs()
+	for k := range oldConfig.AllAttrs() {
+		if _, ok := validAttrs[k]; !ok {
+			modelSettings.Delete(k)
+		}
+	}
+	// Some values require marshalling before storage.
+	validAttrs = config.CoerceForStorage(validAttrs)
+
+	modelSettings.Update(validAttrs)
+	_, ops := modelSettings.settingsUpdateOps()
+	return modelSettings.write(ops)
+}
+
+// ValidateModelConfig validates the addition, update or removal of
+// attributes in the current configuration of the model, as
+// UpdateModelConfig would, without applying the changes. It returns
+// the current configuration and the configuration that would result.
+func (m *Model) ValidateModelConfig(updateAttrs map[string]interface{}, removeAttrs []string, additionalValidation ...ValidateConfigFunc) (oldConfig, newConfig *config.Config, err error) {
 	st := m.State()
 	if len(removeAttrs) > 0 {
 		var removed []string
@@ -290,7 +333,7 @@ func (m *Model) UpdateModelConfig(updateAttrs map[string]interface{}, removeAttr
 		// and if there's one, use that.
 		inherited, err := st.inheritedConfigAttributes()
 		if err != nil {
-			return errors.Trace(err)
+			return nil, nil, errors.Trace(err)
 		}
 		for _, attr := range removeAttrs {
 			// We we are updating an attribute, that takes
@@ -306,45 +349,43 @@ func (m *Model) UpdateModelConfig(updateAttrs map[string]interface{}, removeAttr
 		}
 		removeAttrs = removed
 	}
-	// TODO(axw) 2013-12-6 #1167616
-	// Ensure that the settings on disk have not changed
-	// underneath us. The settings changes are actually
-	// applied as a delta to what's on disk; if there has
-	// been a concurrent update, the change may not be what
-	// the user asked for.
 
-	modelSettings, err := readSettings(st.db(), settingsC, modelGlobalKey)
+	oldConfig, err = m.ModelConfig()
 	if err != nil {
-		return errors.Trace(err)
-	}
-
-	oldConfig, err := m.ModelConfig()
-	if err != nil {
-		return errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
 	for _, additionalValidationFunc := range additionalValidation {
 		err = additionalValidationFunc(updateAttrs, removeAttrs, oldConfig)
 		if err != nil {
-			return errors.Trace(err)
+			return nil, nil, errors.Trace(err)
 		}
 	}
-	validCfg, err := st.buildAndValidateModelConfig(updateAttrs, removeAttrs, oldConfig)
+	newConfig, err = st.buildAndValidateModelConfig(updateAttrs, removeAttrs, oldConfig)
 	if err != nil {
-		return errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
+	return oldConfig, newConfig, nil
+}
 
-	validAttrs := validCfg.AllAttrs()
-	for k := range oldConfig.AllAttrs() {
-		if _, ok := validAttrs[k]; !ok {
-			modelSettings.Delete(k)
+// UnknownModelConfigAttrs returns the names, in sorted order, of the
+// attributes in the given configuration that are defined neither by
+// Juju nor by the model's cloud provider.
+func (st *State) UnknownModelConfigAttrs(cfg *config.Config) ([]string, error) {
+	var providerFields schema.Fields
+	source, err := st.environsProviderConfigSchemaSource()
+	if err == nil {
+		providerFields = source.ConfigSchema()
+	} else if !errors.IsNotImplemented(err) {
+		return nil, errors.Trace(err)
+	}
+	var unknown []string
+	for attr := range cfg.UnknownAttrs() {
+		if _, ok := providerFields[attr]; !ok {
+			unknown = append(unknown, attr)
 		}
 	}
-	// Some values require marshalling before storage.
-	validAttrs = config.CoerceForStorage(validAttrs)
-
-	modelSettings.Update(validAttrs)
-	_, ops := modelSettings.settingsUpdateOps()
-	return modelSettings.write(ops)
+	sort.Strings(unknown)
+	return unknown, nil
 }
 
 type modelConfigSourceFunc func() (attrValues, error)
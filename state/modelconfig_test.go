@@ -259,6 +259,48 @@ func (s *ModelConfigSuite) TestUpdateModelConfigPreferredOverRemove(c *gc.C) {
 	c.Assert(ok, jc.IsFalse)
 }
 
+func (s *ModelConfigSuite) TestValidateModelConfig(c *gc.C) {
+	attrs := map[string]interface{}{
+		"apt-mirror":    "http://different-mirror",
+		"arbitrary-key": "shazam!",
+	}
+	oldConfig, newConfig, err := s.IAASModel.ValidateModelConfig(attrs, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(oldConfig.AllAttrs()["apt-mirror"], gc.Equals, "http://cloud-mirror")
+	c.Assert(newConfig.AllAttrs()["apt-mirror"], gc.Equals, "http://different-mirror")
+	c.Assert(newConfig.AllAttrs()["arbitrary-key"], gc.Equals, "shazam!")
+
+	// The changes are not applied.
+	cfg, err := s.IAASModel.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AllAttrs(), jc.DeepEquals, oldConfig.AllAttrs())
+}
+
+func (s *ModelConfigSuite) TestValidateModelConfigInvalid(c *gc.C) {
+	_, _, err := s.IAASModel.ValidateModelConfig(map[string]interface{}{"agent-version": "bad"}, nil)
+	c.Assert(err, gc.ErrorMatches, `invalid agent version in model configuration: "bad"`)
+}
+
+func (s *ModelConfigSuite) TestUnknownModelConfigAttrs(c *gc.C) {
+	attrs := map[string]interface{}{
+		"zebra":        "stripes",
+		"arbitrary":    "shazam!",
+		"providerAttr": "beef",
+		"apt-mirror":   "http://different-mirror",
+	}
+	_, newConfig, err := s.IAASModel.ValidateModelConfig(attrs, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	unknown, err := s.State.UnknownModelConfigAttrs(newConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	// The model may have other unknown attributes, inherited
+	// from the cloud region.
+	unknownSet := set.NewStrings(unknown...)
+	c.Assert(unknownSet.Contains("arbitrary"), jc.IsTrue)
+	c.Assert(unknownSet.Contains("zebra"), jc.IsTrue)
+	c.Assert(unknownSet.Contains("providerAttr"), jc.IsFalse)
+	c.Assert(unknownSet.Contains("apt-mirror"), jc.IsFalse)
+}
+
 type ModelConfigSourceSuite struct {
 	ConnSuite
 }
@@ -399,6 +441,23 @@ func (s *ModelConfigSourceSuite) TestModelConfigUpdateSource(c *gc.C) {
 	s.assertModelConfigValues(c, modelCfg, modelAttributes, set.NewStrings("apt-mirror"))
 }
 
+func (s *ModelConfigSourceSuite) TestModelConfigValuesSourceWithFloatDefault(c *gc.C) {
+	// Numbers set over the API arrive as float64, so a controller
+	// default must still be recognised as the source of an int value.
+	attrs := map[string]interface{}{
+		"net-bond-reconfigure-delay": float64(17),
+	}
+	err := s.IAASModel.UpdateModelConfigDefaultValues(attrs, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	values, err := s.IAASModel.ModelConfigValues()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values["net-bond-reconfigure-delay"], jc.DeepEquals, config.ConfigValue{
+		Value:  17,
+		Source: "controller",
+	})
+}
+
 func (s *ModelConfigSourceSuite) TestModelConfigDefaults(c *gc.C) {
 	expectedValues := make(config.ModelDefaultAttributes)
 	for attr, val := range config.ConfigDefaults() {
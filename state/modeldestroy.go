@@ -0,0 +1,239 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/state/watcher"
+	"github.com/juju/juju/status"
+)
+
+// ModelDestroyProgress describes how far the destruction of a
+// model has progressed.
+type ModelDestroyProgress struct {
+	// OperationId identifies the operation that destroyed the
+	// model. It is empty if the model is still alive.
+	OperationId string
+
+	// Life is the model's current life.
+	Life Life
+
+	// Started holds the time the model was destroyed.
+	Started time.Time
+
+	// Machines, Applications, Volumes and Filesystems hold the
+	// numbers of each kind of entity remaining in the model.
+	Machines     int
+	Applications int
+	Volumes      int
+	Filesystems  int
+
+	// Blocking holds the remaining entities that are in an
+	// error state, and so may be preventing the model from
+	// being removed.
+	Blocking []BlockingEntity
+}
+
+// BlockingEntity describes an entity that may be preventing the
+// removal of a dying model.
+type BlockingEntity struct {
+	Tag     names.Tag
+	Life    Life
+	Status  status.Status
+	Message string
+}
+
+// DestroyOperation returns the identifier of the operation that
+// destroyed the model, or the empty string if the model is alive.
+func (m *Model) DestroyOperation() string {
+	return m.doc.DestroyOperation
+}
+
+// DestroyProgress reports the entities remaining in the model, and
+// those that are in an error state. It is used to follow the progress
+// of the model's destruction.
+func (m *Model) DestroyProgress() (ModelDestroyProgress, error) {
+	progress := ModelDestroyProgress{
+		OperationId: m.doc.DestroyOperation,
+		Life:        m.doc.Life,
+		Started:     m.doc.TimeOfDying,
+	}
+	refs, err := m.getEntityRefs()
+	if err != nil {
+		return ModelDestroyProgress{}, errors.Trace(err)
+	}
+	progress.Machines = len(refs.Machines)
+	progress.Applications = len(refs.Applications)
+	progress.Volumes = len(refs.Volumes)
+	progress.Filesystems = len(refs.Filesystems)
+
+	blocking, err := m.blockingEntities()
+	if err != nil {
+		return ModelDestroyProgress{}, errors.Trace(err)
+	}
+	progress.Blocking = blocking
+	return progress, nil
+}
+
+// blockingEntities returns the machines, units, volumes and
+// filesystems in the model that are in an error state.
+func (m *Model) blockingEntities() ([]BlockingEntity, error) {
+	var blocking []BlockingEntity
+	add := func(tag names.Tag, life Life, info status.StatusInfo) {
+		blocking = append(blocking, BlockingEntity{
+			Tag:     tag,
+			Life:    life,
+			Status:  info.Status,
+			Message: info.Message,
+		})
+	}
+
+	machines, err := m.st.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, machine := range machines {
+		info, err := machine.Status()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if info.Status == status.Error {
+			add(machine.Tag(), machine.Life(), info)
+			continue
+		}
+		info, err = machine.InstanceStatus()
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if info.Status == status.ProvisioningError {
+			add(machine.Tag(), machine.Life(), info)
+		}
+	}
+
+	applications, err := m.st.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, application := range applications {
+		units, err := application.AllUnits()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, unit := range units {
+			info, err := unit.Status()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if info.Status == status.Error {
+				add(unit.Tag(), unit.Life(), info)
+			}
+		}
+	}
+
+	if m.Type() != ModelTypeIAAS {
+		return blocking, nil
+	}
+	im, err := m.IAASModel()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumes, err := im.AllVolumes()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, volume := range volumes {
+		info, err := volume.Status()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if info.Status == status.Error {
+			add(volume.Tag(), volume.Life(), info)
+		}
+	}
+	filesystems, err := im.AllFilesystems()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, filesystem := range filesystems {
+		info, err := filesystem.Status()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if info.Status == status.Error {
+			add(filesystem.Tag(), filesystem.Life(), info)
+		}
+	}
+	return blocking, nil
+}
+
+// WatchDestroyProgress returns a NotifyWatcher that triggers when the
+// model, the set of entities it contains, or their statuses change,
+// so that the progress of the model's destruction can be followed.
+func (m *Model) WatchDestroyProgress() NotifyWatcher {
+	w := &destroyProgressWatcher{
+		commonWatcher: newCommonWatcher(m.st),
+		modelUUID:     m.doc.UUID,
+		out:           make(chan struct{}),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.out)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// destroyProgressWatcher implements NotifyWatcher, triggering on
+// changes to a model's document, its entity references, or the
+// statuses of its entities.
+type destroyProgressWatcher struct {
+	commonWatcher
+	modelUUID string
+	out       chan struct{}
+}
+
+// Changes returns the event channel for this watcher.
+func (w *destroyProgressWatcher) Changes() <-chan struct{} {
+	return w.out
+}
+
+func (w *destroyProgressWatcher) loop() error {
+	in := make(chan watcher.Change)
+	for _, collName := range []string{modelsC, modelEntityRefsC} {
+		coll, closer := w.db.GetCollection(collName)
+		txnRevno, err := getTxnRevno(coll, w.modelUUID)
+		closer()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		w.watcher.Watch(coll.Name(), w.modelUUID, txnRevno, in)
+		defer w.watcher.Unwatch(coll.Name(), w.modelUUID, in)
+	}
+	w.watcher.WatchCollectionWithFilter(statusesC, in, isLocalID(w.backend))
+	defer w.watcher.UnwatchCollection(statusesC, in)
+
+	out := w.out // out set so that initial event is sent.
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case change := <-in:
+			if _, ok := collect(change, in, w.tomb.Dying()); !ok {
+				return tomb.ErrDying
+			}
+			out = w.out
+		case out <- struct{}{}:
+			out = nil
+		}
+	}
+}
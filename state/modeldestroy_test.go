@@ -0,0 +1,115 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/testing/factory"
+)
+
+type ModelDestroySuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ModelDestroySuite{})
+
+func (s *ModelDestroySuite) TestDestroyOperation(c *gc.C) {
+	m, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.DestroyOperation(), gc.Equals, "")
+
+	s.Factory.MakeApplication(c, nil)
+	c.Assert(m.Destroy(state.DestroyModelParams{}), jc.ErrorIsNil)
+	c.Assert(m.Refresh(), jc.ErrorIsNil)
+	operationId := m.DestroyOperation()
+	c.Assert(operationId, gc.Not(gc.Equals), "")
+
+	// Destroying the model again doesn't start another operation.
+	c.Assert(m.Destroy(state.DestroyModelParams{}), jc.ErrorIsNil)
+	c.Assert(m.Refresh(), jc.ErrorIsNil)
+	c.Assert(m.DestroyOperation(), gc.Equals, operationId)
+}
+
+func (s *ModelDestroySuite) TestDestroyProgress(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Machine: machine})
+	s.Factory.MakeMachine(c, nil)
+
+	now := time.Now()
+	err := machine.SetInstanceStatus(status.StatusInfo{
+		Status:  status.ProvisioningError,
+		Message: "no more instances",
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetAgentStatus(status.StatusInfo{
+		Status:  status.Error,
+		Message: `hook failed: "stop"`,
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	m, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.Destroy(state.DestroyModelParams{}), jc.ErrorIsNil)
+	c.Assert(m.Refresh(), jc.ErrorIsNil)
+
+	progress, err := m.DestroyProgress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(progress.OperationId, gc.Equals, m.DestroyOperation())
+	c.Assert(progress.Life, gc.Equals, state.Dying)
+	c.Assert(progress.Started.IsZero(), jc.IsFalse)
+	c.Assert(progress.Machines, gc.Equals, 2)
+	c.Assert(progress.Applications, gc.Equals, 1)
+	c.Assert(progress.Volumes, gc.Equals, 0)
+	c.Assert(progress.Filesystems, gc.Equals, 0)
+	c.Assert(progress.Blocking, jc.DeepEquals, []state.BlockingEntity{{
+		Tag:     machine.Tag(),
+		Life:    state.Alive,
+		Status:  status.ProvisioningError,
+		Message: "no more instances",
+	}, {
+		Tag:     unit.Tag(),
+		Life:    state.Alive,
+		Status:  status.Error,
+		Message: `hook failed: "stop"`,
+	}})
+}
+
+func (s *ModelDestroySuite) TestWatchDestroyProgress(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	m, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := m.WatchDestroyProgress()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	// Destroying the model triggers a change.
+	c.Assert(m.Destroy(state.DestroyModelParams{}), jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// As does a change to an entity's status.
+	now := time.Now()
+	err = machine.SetStatus(status.StatusInfo{
+		Status:  status.Error,
+		Message: "cannot remove",
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// And the removal of an entity.
+	c.Assert(machine.EnsureDead(), jc.ErrorIsNil)
+	c.Assert(machine.Remove(), jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
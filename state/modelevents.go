@@ -0,0 +1,210 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// ModelEventKind identifies the kind of a model event.
+type ModelEventKind string
+
+// The kinds of event recorded in a model's activity feed.
+const (
+	EventApplicationDeployed      ModelEventKind = "application-deployed"
+	EventUnitAdded                ModelEventKind = "unit-added"
+	EventHookFailed               ModelEventKind = "hook-failed"
+	EventRelationCreated          ModelEventKind = "relation-created"
+	EventUpgradeCompleted         ModelEventKind = "upgrade-completed"
+	EventApplicationExposed       ModelEventKind = "application-exposed"
+	EventApplicationUnexposed     ModelEventKind = "application-unexposed"
+	EventBreakGlassStarted        ModelEventKind = "break-glass-started"
+	EventBreakGlassEnded          ModelEventKind = "break-glass-ended"
+	EventMachineAdopted           ModelEventKind = "machine-adopted"
+	EventAgentOfflineAcknowledged ModelEventKind = "agent-offline-acknowledged"
+	EventAgentOfflineCleared      ModelEventKind = "agent-offline-cleared"
+)
+
+// modelEventsSequence is the name of the sequence from which model
+// events are numbered.
+const modelEventsSequence = "modelevents"
+
+// ModelEvent is a high-level change recorded in a model's activity
+// feed.
+type ModelEvent struct {
+	// Seq orders the model's events; events recorded later have
+	// higher sequence numbers. It is assigned when the event is
+	// recorded.
+	Seq int64
+
+	// Time is when the event was recorded. It is assigned when the
+	// event is recorded.
+	Time time.Time
+
+	// Kind identifies what happened.
+	Kind ModelEventKind
+
+	// Actor is the tag of the user or agent responsible for the
+	// event.
+	Actor string
+
+	// Entities holds the tags of the entities that the event
+	// concerns.
+	Entities []string
+
+	// Message describes the event.
+	Message string
+}
+
+// ModelEventsFilter selects a page of a model's events.
+type ModelEventsFilter struct {
+	// After, if non-zero, selects the events recorded after the one
+	// with this sequence number, oldest first.
+	After int64
+
+	// Before, if non-zero and After is zero, selects the most recent
+	// events recorded before the one with this sequence number. If
+	// both After and Before are zero, the most recent events are
+	// selected.
+	Before int64
+
+	// Limit is the maximum number of events to return.
+	Limit int
+
+	// Entity, if not empty, selects only the events concerning the
+	// entity with this tag.
+	Entity string
+
+	// Kinds, if not empty, selects only the events of these kinds.
+	Kinds []ModelEventKind
+}
+
+// Validate returns an error if the filter is not valid.
+func (f ModelEventsFilter) Validate() error {
+	if f.After < 0 || f.Before < 0 {
+		return errors.NotValidf("negative sequence number")
+	}
+	if f.Limit <= 0 {
+		return errors.NotValidf("non-positive limit")
+	}
+	return nil
+}
+
+type modelEventDoc struct {
+	DocID     string   `bson:"_id"`
+	ModelUUID string   `bson:"model-uuid"`
+	Seq       int64    `bson:"seq"`
+	Time      int64    `bson:"time"`
+	Kind      string   `bson:"kind"`
+	Actor     string   `bson:"actor"`
+	Entities  []string `bson:"entities,omitempty"`
+	Message   string   `bson:"message,omitempty"`
+}
+
+// AddModelEvent records an event in the model's activity feed.
+func (st *State) AddModelEvent(event ModelEvent) error {
+	return errors.Trace(addModelEvent(st, event))
+}
+
+func addModelEvent(mb modelBackend, event ModelEvent) error {
+	if event.Kind == "" {
+		return errors.NotValidf("model event without kind")
+	}
+	next, err := sequence(mb, modelEventsSequence)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Number events from 1, so that a zero sequence number can mean
+	// "none" in filters.
+	seq := int64(next) + 1
+	events, closer := mb.db().GetCollection(modelEventsC)
+	defer closer()
+	doc := &modelEventDoc{
+		DocID:     mb.docID(fmt.Sprint(seq)),
+		ModelUUID: mb.modelUUID(),
+		Seq:       seq,
+		Time:      mb.clock().Now().UnixNano(),
+		Kind:      string(event.Kind),
+		Actor:     event.Actor,
+		Entities:  event.Entities,
+		Message:   event.Message,
+	}
+	return errors.Trace(events.Writeable().Insert(doc))
+}
+
+// probablyAddModelEvent records an event in the model's activity feed,
+// logging rather than returning any failure, for use where the change
+// that the event describes has already been made.
+func probablyAddModelEvent(mb modelBackend, event ModelEvent) {
+	if err := addModelEvent(mb, event); err != nil {
+		logger.Errorf("failed to record %s model event: %v", event.Kind, err)
+	}
+}
+
+// ModelEvents returns the model's events selected by the filter,
+// oldest first.
+func (st *State) ModelEvents(filter ModelEventsFilter) ([]ModelEvent, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	events, closer := st.db().GetCollection(modelEventsC)
+	defer closer()
+
+	var query bson.D
+	sort := "-seq"
+	switch {
+	case filter.After > 0:
+		query = bson.D{{"seq", bson.D{{"$gt", filter.After}}}}
+		sort = "seq"
+	case filter.Before > 0:
+		query = bson.D{{"seq", bson.D{{"$lt", filter.Before}}}}
+	}
+	if filter.Entity != "" {
+		query = append(query, bson.DocElem{"entities", filter.Entity})
+	}
+	if len(filter.Kinds) > 0 {
+		kinds := make([]string, len(filter.Kinds))
+		for i, kind := range filter.Kinds {
+			kinds[i] = string(kind)
+		}
+		query = append(query, bson.DocElem{"kind", bson.D{{"$in", kinds}}})
+	}
+	var docs []modelEventDoc
+	if err := events.Find(query).Sort(sort).Limit(filter.Limit).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get model events")
+	}
+	result := make([]ModelEvent, len(docs))
+	for i, doc := range docs {
+		if sort == "-seq" {
+			// The most recent events are returned oldest first too.
+			i = len(docs) - 1 - i
+		}
+		result[i] = ModelEvent{
+			Seq:      doc.Seq,
+			Time:     time.Unix(0, doc.Time).UTC(),
+			Kind:     ModelEventKind(doc.Kind),
+			Actor:    doc.Actor,
+			Entities: doc.Entities,
+			Message:  doc.Message,
+		}
+	}
+	return result, nil
+}
+
+// WatchModelEvents returns a NotifyWatcher that notifies when events
+// are recorded in the model's activity feed.
+func (st *State) WatchModelEvents() NotifyWatcher {
+	return newNotifyCollWatcher(st, modelEventsC, isLocalID(st))
+}
+
+// PruneModelEvents removes model events until only those recorded
+// after now - maxAge remain and the events take up less than maxMB.
+func PruneModelEvents(st *State, maxAge time.Duration, maxMB int) error {
+	err := pruneCollection(st, maxAge, maxMB, modelEventsC, "time", NanoSeconds)
+	return errors.Trace(err)
+}
@@ -0,0 +1,185 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type modelEventsSuite struct {
+	statetesting.StateSuite
+	clock *testing.Clock
+}
+
+var _ = gc.Suite(&modelEventsSuite{})
+
+func (s *modelEventsSuite) SetUpTest(c *gc.C) {
+	s.StateSuite.SetUpTest(c)
+	s.clock = testing.NewClock(coretesting.NonZeroTime().UTC())
+	err := s.State.SetClockForTesting(s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *modelEventsSuite) addEvents(c *gc.C, n int) {
+	for i := 0; i < n; i++ {
+		err := s.State.AddModelEvent(state.ModelEvent{
+			Kind:     state.EventUnitAdded,
+			Actor:    "user-admin",
+			Entities: []string{"unit-app-0"},
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		s.clock.Advance(time.Minute)
+	}
+}
+
+func eventSeqs(events []state.ModelEvent) []int64 {
+	seqs := make([]int64, len(events))
+	for i, event := range events {
+		seqs[i] = event.Seq
+	}
+	return seqs
+}
+
+func (s *modelEventsSuite) TestAddModelEvent(c *gc.C) {
+	err := s.State.AddModelEvent(state.ModelEvent{
+		Kind:     state.EventRelationCreated,
+		Actor:    "user-admin",
+		Entities: []string{"relation-wordpress.db#mysql.server"},
+		Message:  "wordpress:db mysql:server",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	events, err := s.State.ModelEvents(state.ModelEventsFilter{Limit: 10})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, jc.DeepEquals, []state.ModelEvent{{
+		Seq:      1,
+		Time:     s.clock.Now(),
+		Kind:     state.EventRelationCreated,
+		Actor:    "user-admin",
+		Entities: []string{"relation-wordpress.db#mysql.server"},
+		Message:  "wordpress:db mysql:server",
+	}})
+}
+
+func (s *modelEventsSuite) TestAddModelEventNoKind(c *gc.C) {
+	err := s.State.AddModelEvent(state.ModelEvent{Actor: "user-admin"})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *modelEventsSuite) TestModelEventsPages(c *gc.C) {
+	s.addEvents(c, 5)
+
+	events, err := s.State.ModelEvents(state.ModelEventsFilter{Limit: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(eventSeqs(events), jc.DeepEquals, []int64{4, 5})
+
+	events, err = s.State.ModelEvents(state.ModelEventsFilter{Before: 4, Limit: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(eventSeqs(events), jc.DeepEquals, []int64{2, 3})
+
+	events, err = s.State.ModelEvents(state.ModelEventsFilter{After: 2, Limit: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(eventSeqs(events), jc.DeepEquals, []int64{3, 4})
+}
+
+func (s *modelEventsSuite) TestModelEventsEntityAndKinds(c *gc.C) {
+	for _, event := range []state.ModelEvent{
+		{Kind: state.EventApplicationExposed, Entities: []string{"application-wordpress"}},
+		{Kind: state.EventApplicationExposed, Entities: []string{"application-mysql"}},
+		{Kind: state.EventUnitAdded, Entities: []string{"unit-wordpress-0", "application-wordpress"}},
+		{Kind: state.EventApplicationUnexposed, Entities: []string{"application-wordpress"}},
+	} {
+		err := s.State.AddModelEvent(event)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	events, err := s.State.ModelEvents(state.ModelEventsFilter{
+		Entity: "application-wordpress",
+		Limit:  10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(eventSeqs(events), jc.DeepEquals, []int64{1, 3, 4})
+
+	events, err = s.State.ModelEvents(state.ModelEventsFilter{
+		Entity: "application-wordpress",
+		Kinds:  []state.ModelEventKind{state.EventApplicationExposed, state.EventApplicationUnexposed},
+		Limit:  10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(eventSeqs(events), jc.DeepEquals, []int64{1, 4})
+}
+
+func (s *modelEventsSuite) TestModelEventsInvalidFilter(c *gc.C) {
+	_, err := s.State.ModelEvents(state.ModelEventsFilter{})
+	c.Assert(err, gc.ErrorMatches, "non-positive limit not valid")
+	_, err = s.State.ModelEvents(state.ModelEventsFilter{After: -1, Limit: 1})
+	c.Assert(err, gc.ErrorMatches, "negative sequence number not valid")
+}
+
+func (s *modelEventsSuite) TestModelEventsModelScoped(c *gc.C) {
+	s.addEvents(c, 2)
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	events, err := st.ModelEvents(state.ModelEventsFilter{Limit: 10})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, gc.HasLen, 0)
+}
+
+func (s *modelEventsSuite) TestHookFailedEvent(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	err := unit.Agent().SetStatus(status.StatusInfo{
+		Status:  status.Error,
+		Message: `hook failed: "install"`,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	events, err := s.State.ModelEvents(state.ModelEventsFilter{Limit: 10})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, gc.HasLen, 1)
+	c.Assert(events[0].Kind, gc.Equals, state.EventHookFailed)
+	c.Assert(events[0].Actor, gc.Equals, unit.Tag().String())
+	c.Assert(events[0].Entities, jc.DeepEquals, []string{
+		unit.Tag().String(),
+		"application-" + unit.ApplicationName(),
+	})
+	c.Assert(events[0].Message, gc.Equals, `hook failed: "install"`)
+}
+
+func (s *modelEventsSuite) TestPruneModelEventsByAge(c *gc.C) {
+	s.addEvents(c, 5)
+
+	err := state.PruneModelEvents(s.State, 150*time.Second, 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	events, err := s.State.ModelEvents(state.ModelEventsFilter{Limit: 10})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(eventSeqs(events), jc.DeepEquals, []int64{4, 5})
+}
+
+func (s *modelEventsSuite) TestWatchModelEvents(c *gc.C) {
+	w := s.State.WatchModelEvents()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	s.addEvents(c, 1)
+	wc.AssertOneChange()
+
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	err := st.AddModelEvent(state.ModelEvent{Kind: state.EventUnitAdded})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
@@ -67,6 +67,14 @@ type ModelMigration interface {
 	// migration's target controller.
 	TargetInfo() (*migration.TargetInfo, error)
 
+	// RollbackWindow returns how long the model is kept, frozen but
+	// restorable, after the migration succeeds.
+	RollbackWindow() time.Duration
+
+	// IsRollback returns true if the migration hands the model's
+	// agents back to the controller the model was migrated from.
+	IsRollback() bool
+
 	// SetPhase sets the phase of the migration. An error will be
 	// returned if the new phase does not follow the current phase or
 	// if the migration is no longer active.
@@ -152,6 +160,15 @@ type modelMigDoc struct {
 	// TargetMacaroons holds the macaroons to use with TargetAuthTag
 	// when authenticating.
 	TargetMacaroons string `bson:"target-macaroons,omitempty"`
+
+	// RollbackWindow holds how long the model is kept after the
+	// migration succeeds, in case it needs to be rolled back
+	// (stored as nanoseconds).
+	RollbackWindow int64 `bson:"rollback-window,omitempty"`
+
+	// Rollback is true if the migration hands the model's agents
+	// back to the controller the model was migrated from.
+	Rollback bool `bson:"rollback,omitempty"`
 }
 
 // modelMigStatusDoc tracks the progress of a migration attempt for a
@@ -275,6 +292,16 @@ func (mig *modelMigration) TargetInfo() (*migration.TargetInfo, error) {
 	}, nil
 }
 
+// RollbackWindow implements ModelMigration.
+func (mig *modelMigration) RollbackWindow() time.Duration {
+	return time.Duration(mig.doc.RollbackWindow)
+}
+
+// IsRollback implements ModelMigration.
+func (mig *modelMigration) IsRollback() bool {
+	return mig.doc.Rollback
+}
+
 // SetPhase implements ModelMigration.
 func (mig *modelMigration) SetPhase(nextPhase migration.Phase) error {
 	now := mig.st.clock().Now().UnixNano()
@@ -308,8 +335,9 @@ func (mig *modelMigration) SetPhase(nextPhase migration.Phase) error {
 		return errors.Trace(err)
 	}
 
-	// If the migration aborted, make the model active again.
-	if nextPhase == migration.ABORTDONE {
+	// If the migration aborted or was rolled back, make the model
+	// active again.
+	if nextPhase == migration.ABORTDONE || nextPhase == migration.ROLLBACKDONE {
 		ops = append(ops, txn.Op{
 			C:      modelsC,
 			Id:     mig.doc.ModelUUID,
@@ -596,6 +624,11 @@ func (mig *modelMigration) Refresh() error {
 type MigrationSpec struct {
 	InitiatedBy names.UserTag
 	TargetInfo  migration.TargetInfo
+
+	// RollbackWindow is how long the model is kept, frozen but
+	// restorable, after the migration succeeds. If it is zero, the
+	// model is removed as soon as the migration succeeds.
+	RollbackWindow time.Duration
 }
 
 // Validate returns an error if the MigrationSpec contains bad
@@ -604,6 +637,9 @@ func (spec *MigrationSpec) Validate() error {
 	if !names.IsValidUser(spec.InitiatedBy.Id()) {
 		return errors.NotValidf("InitiatedBy")
 	}
+	if spec.RollbackWindow < 0 {
+		return errors.NotValidf("negative RollbackWindow")
+	}
 	return spec.TargetInfo.Validate()
 }
 
@@ -669,6 +705,7 @@ func (st *State) CreateMigration(spec MigrationSpec) (ModelMigration, error) {
 			TargetAuthTag:    spec.TargetInfo.AuthTag.String(),
 			TargetPassword:   spec.TargetInfo.Password,
 			TargetMacaroons:  macsJSON,
+			RollbackWindow:   int64(spec.RollbackWindow),
 		}
 
 		statusDoc = modelMigStatusDoc{
@@ -716,6 +753,137 @@ func (st *State) CreateMigration(spec MigrationSpec) (ModelMigration, error) {
 	}, nil
 }
 
+// RollbackSpec holds the information required to create a
+// ModelMigration that hands a model's agents back to the controller
+// the model was migrated from.
+type RollbackSpec struct {
+	InitiatedBy names.UserTag
+	SourceInfo  migration.SourceInfo
+}
+
+// Validate returns an error if the RollbackSpec contains bad data.
+// Nil is returned otherwise.
+func (spec *RollbackSpec) Validate() error {
+	if !names.IsValidUser(spec.InitiatedBy.Id()) {
+		return errors.NotValidf("InitiatedBy")
+	}
+	if !names.IsValidController(spec.SourceInfo.ControllerTag.Id()) {
+		return errors.NotValidf("ControllerTag")
+	}
+	if len(spec.SourceInfo.Addrs) < 1 {
+		return errors.NotValidf("empty Addrs")
+	}
+	return nil
+}
+
+// CreateRollbackMigration initialises state that tracks the hand back
+// of a migrated model to the controller it came from, after the
+// migration was rolled back there. The migration starts at SUCCESS,
+// so that the model's migration minions immediately switch to the
+// source controller, after which the model is removed from this one.
+// Nothing is transferred, so no credentials for the source controller
+// are required.
+func (st *State) CreateRollbackMigration(spec RollbackSpec) (ModelMigration, error) {
+	if st.IsController() {
+		return nil, errors.New("controllers can't be migrated")
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := checkTargetController(st, spec.SourceInfo.ControllerTag); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	now := st.clock().Now().UnixNano()
+	modelUUID := st.ModelUUID()
+	var doc modelMigDoc
+	var statusDoc modelMigStatusDoc
+
+	msg := "rolling back to source controller"
+	ops, err := migStatusHistoryAndOps(st, migration.SUCCESS, now, msg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	buildTxn := func(int) ([]txn.Op, error) {
+		model, err := st.Model()
+		if err != nil {
+			return nil, errors.Annotate(err, "failed to load model")
+		}
+		if model.Life() != Alive {
+			return nil, errors.New("model is not alive")
+		}
+
+		if isActive, err := st.IsMigrationActive(); err != nil {
+			return nil, errors.Trace(err)
+		} else if isActive {
+			return nil, errors.New("already in progress")
+		}
+
+		attempt, err := sequence(st, "modelmigration")
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		id := fmt.Sprintf("%s:%d", modelUUID, attempt)
+		doc = modelMigDoc{
+			Id:               id,
+			ModelUUID:        modelUUID,
+			Attempt:          attempt,
+			InitiatedBy:      spec.InitiatedBy.Id(),
+			TargetController: spec.SourceInfo.ControllerTag.Id(),
+			TargetAddrs:      spec.SourceInfo.Addrs,
+			TargetCACert:     spec.SourceInfo.CACert,
+			TargetAuthTag:    spec.InitiatedBy.String(),
+			Rollback:         true,
+		}
+
+		statusDoc = modelMigStatusDoc{
+			Id:               id,
+			StartTime:        now,
+			SuccessTime:      now,
+			Phase:            migration.SUCCESS.String(),
+			PhaseChangedTime: now,
+			StatusMessage:    msg,
+		}
+
+		ops := append(ops, []txn.Op{{
+			C:      migrationsC,
+			Id:     doc.Id,
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		}, {
+			C:      migrationsStatusC,
+			Id:     statusDoc.Id,
+			Assert: txn.DocMissing,
+			Insert: &statusDoc,
+		}, {
+			C:      migrationsActiveC,
+			Id:     modelUUID,
+			Assert: txn.DocMissing,
+			Insert: bson.M{"id": doc.Id},
+		}, {
+			C:      modelsC,
+			Id:     modelUUID,
+			Assert: txn.DocExists,
+			Update: bson.M{"$set": bson.M{
+				"migration-mode": MigrationModeExporting,
+			}},
+		}, model.assertActiveOp(),
+		}...)
+		return ops, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return nil, errors.Annotate(err, "failed to create rollback migration")
+	}
+
+	return &modelMigration{
+		doc:       doc,
+		statusDoc: statusDoc,
+		st:        st,
+	}, nil
+}
+
 func macaroonsToJSON(m []macaroon.Slice) (string, error) {
 	if len(m) == 0 {
 		return "", nil
@@ -421,6 +421,100 @@ func (s *MigrationSuite) TestABORTCleanup(c *gc.C) {
 	c.Assert(model.MigrationMode(), gc.Equals, state.MigrationModeNone)
 }
 
+func (s *MigrationSuite) TestRollbackWindow(c *gc.C) {
+	spec := s.stdSpec
+	spec.RollbackWindow = time.Hour
+	mig, err := s.State2.CreateMigration(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig.RollbackWindow(), gc.Equals, time.Hour)
+	c.Check(mig.IsRollback(), jc.IsFalse)
+
+	mig2, err := s.State2.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig2.RollbackWindow(), gc.Equals, time.Hour)
+}
+
+func (s *MigrationSuite) TestNegativeRollbackWindow(c *gc.C) {
+	spec := s.stdSpec
+	spec.RollbackWindow = -time.Second
+	_, err := s.State2.CreateMigration(spec)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, "negative RollbackWindow not valid")
+}
+
+func (s *MigrationSuite) TestROLLBACKCleanup(c *gc.C) {
+	spec := s.stdSpec
+	spec.RollbackWindow = time.Hour
+	mig, err := s.State2.CreateMigration(spec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	phases := []migration.Phase{
+		migration.IMPORT,
+		migration.VALIDATION,
+		migration.SUCCESS,
+		migration.LOGTRANSFER,
+		migration.POSTVALIDATION,
+		migration.ROLLBACK,
+		migration.ROLLBACKDONE,
+	}
+	for _, phase := range phases {
+		s.clock.Advance(time.Millisecond)
+		c.Assert(mig.SetPhase(phase), jc.ErrorIsNil)
+	}
+
+	s.assertMigrationCleanedUp(c, mig)
+
+	// Model should be set back to active.
+	model, err := s.State2.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.MigrationMode(), gc.Equals, state.MigrationModeNone)
+}
+
+func (s *MigrationSuite) TestCreateRollbackMigration(c *gc.C) {
+	spec := state.RollbackSpec{
+		InitiatedBy: names.NewUserTag("admin"),
+		SourceInfo: migration.SourceInfo{
+			ControllerTag: s.stdSpec.TargetInfo.ControllerTag,
+			Addrs:         []string{"1.2.3.4:5555"},
+			CACert:        "cert",
+		},
+	}
+	mig, err := s.State2.CreateRollbackMigration(spec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(mig.IsRollback(), jc.IsTrue)
+	c.Check(mig.SuccessTime(), gc.Equals, s.clock.Now())
+	assertPhase(c, mig, migration.SUCCESS)
+	assertMigrationActive(c, s.State2)
+
+	info, err := mig.TargetInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(*info, jc.DeepEquals, migration.TargetInfo{
+		ControllerTag: spec.SourceInfo.ControllerTag,
+		Addrs:         spec.SourceInfo.Addrs,
+		CACert:        spec.SourceInfo.CACert,
+		AuthTag:       spec.InitiatedBy,
+	})
+
+	model, err := s.State2.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(model.MigrationMode(), gc.Equals, state.MigrationModeExporting)
+}
+
+func (s *MigrationSuite) TestCreateRollbackMigrationInProgress(c *gc.C) {
+	_, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State2.CreateRollbackMigration(state.RollbackSpec{
+		InitiatedBy: names.NewUserTag("admin"),
+		SourceInfo: migration.SourceInfo{
+			ControllerTag: s.stdSpec.TargetInfo.ControllerTag,
+			Addrs:         []string{"1.2.3.4:5555"},
+		},
+	})
+	c.Check(err, gc.ErrorMatches, "failed to create rollback migration: already in progress")
+}
+
 func (s *MigrationSuite) TestREAPFAILEDCleanup(c *gc.C) {
 	mig, err := s.State2.CreateMigration(s.stdSpec)
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,223 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+)
+
+// modelQuotasKey is the local id of the single quotas document held
+// for each model.
+const modelQuotasKey = "quotas"
+
+// Names of the resources limited by model quotas, as reported in
+// quota exceeded errors.
+const (
+	QuotaMachines = "machines"
+	QuotaCores    = "cores"
+	QuotaUnits    = "units"
+	QuotaStorage  = "storage"
+)
+
+// ModelQuotas holds the limits on the resources that may be added to a
+// model. A zero limit means that the resource is not limited.
+type ModelQuotas struct {
+	// Machines is the maximum number of machines in the model. It
+	// does not limit containers, which consume no further cloud
+	// resources.
+	Machines int
+
+	// Cores is the maximum total number of CPU cores of the model's
+	// machines.
+	Cores int
+
+	// Units is the maximum number of units in the model.
+	Units int
+
+	// Storage is the maximum number of storage instances in the model.
+	Storage int
+}
+
+// Validate returns an error if the quotas are not valid.
+func (q ModelQuotas) Validate() error {
+	if q.Machines < 0 || q.Cores < 0 || q.Units < 0 || q.Storage < 0 {
+		return errors.NotValidf("negative quota")
+	}
+	return nil
+}
+
+// ModelResources holds quantities of the resources limited by model
+// quotas, either in use by a model or to be added to it.
+type ModelResources struct {
+	// Machines is the number of machines, not counting containers.
+	Machines int
+
+	// Cores is the total number of CPU cores of the machines. The
+	// cores of a machine are those of its instance once it has been
+	// provisioned, and those of its constraints until then.
+	Cores int
+
+	// Units is the number of units.
+	Units int
+
+	// Storage is the number of storage instances.
+	Storage int
+}
+
+type modelQuotasDoc struct {
+	DocID     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+	Machines  int    `bson:"machines,omitempty"`
+	Cores     int    `bson:"cores,omitempty"`
+	Units     int    `bson:"units,omitempty"`
+	Storage   int    `bson:"storage,omitempty"`
+}
+
+// ModelQuotas returns the model's quotas. A model without quotas has
+// no limits.
+func (st *State) ModelQuotas() (ModelQuotas, error) {
+	coll, closer := st.db().GetCollection(modelQuotasC)
+	defer closer()
+
+	var doc modelQuotasDoc
+	err := coll.FindId(modelQuotasKey).One(&doc)
+	if err == mgo.ErrNotFound {
+		return ModelQuotas{}, nil
+	} else if err != nil {
+		return ModelQuotas{}, errors.Annotate(err, "cannot read model quotas")
+	}
+	return ModelQuotas{
+		Machines: doc.Machines,
+		Cores:    doc.Cores,
+		Units:    doc.Units,
+		Storage:  doc.Storage,
+	}, nil
+}
+
+// SetModelQuotas replaces the model's quotas. Lowering a quota below
+// the model's current usage does not remove any resources, but
+// prevents any more from being added.
+func (st *State) SetModelQuotas(quotas ModelQuotas) error {
+	if err := quotas.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	coll, closer := st.db().GetCollection(modelQuotasC)
+	defer closer()
+
+	doc := modelQuotasDoc{
+		DocID:     st.docID(modelQuotasKey),
+		ModelUUID: st.ModelUUID(),
+		Machines:  quotas.Machines,
+		Cores:     quotas.Cores,
+		Units:     quotas.Units,
+		Storage:   quotas.Storage,
+	}
+	_, err := coll.Writeable().UpsertId(doc.DocID, doc)
+	if err != nil {
+		return errors.Annotate(err, "cannot set model quotas")
+	}
+	return nil
+}
+
+// ModelResourceUsage returns the quantities of the resources limited
+// by model quotas that are in use by the model.
+func (st *State) ModelResourceUsage() (ModelResources, error) {
+	var usage ModelResources
+	machines, err := st.AllMachines()
+	if err != nil {
+		return ModelResources{}, errors.Trace(err)
+	}
+	for _, m := range machines {
+		if m.IsContainer() {
+			continue
+		}
+		cores, err := machineCores(m)
+		if err != nil {
+			return ModelResources{}, errors.Trace(err)
+		}
+		usage.Machines++
+		usage.Cores += cores
+	}
+	if usage.Units, err = st.countDocs(unitsC); err != nil {
+		return ModelResources{}, errors.Trace(err)
+	}
+	if usage.Storage, err = st.countDocs(storageInstancesC); err != nil {
+		return ModelResources{}, errors.Trace(err)
+	}
+	return usage, nil
+}
+
+func (st *State) countDocs(collection string) (int, error) {
+	coll, closer := st.db().GetCollection(collection)
+	defer closer()
+	n, err := coll.Count()
+	if err != nil {
+		return 0, errors.Annotatef(err, "cannot count %s", collection)
+	}
+	return n, nil
+}
+
+// machineCores returns the number of CPU cores of the machine's
+// instance or, if it has not been provisioned, of its constraints.
+func machineCores(m *Machine) (int, error) {
+	hc, err := m.HardwareCharacteristics()
+	if err == nil {
+		if hc.CpuCores != nil {
+			return int(*hc.CpuCores), nil
+		}
+		return 0, nil
+	} else if !errors.IsNotFound(err) {
+		return 0, errors.Trace(err)
+	}
+	cons, err := m.Constraints()
+	if errors.IsNotFound(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if cons.CpuCores != nil {
+		return int(*cons.CpuCores), nil
+	}
+	return 0, nil
+}
+
+// CheckModelQuotas returns an error satisfying IsQuotaExceededError
+// if adding the requested resources to the model would exceed any of
+// its quotas.
+func (st *State) CheckModelQuotas(requested ModelResources) error {
+	quotas, err := st.ModelQuotas()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if quotas == (ModelQuotas{}) {
+		return nil
+	}
+	usage, err := st.ModelResourceUsage()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, check := range []struct {
+		resource               string
+		limit, used, requested int
+	}{
+		{QuotaMachines, quotas.Machines, usage.Machines, requested.Machines},
+		{QuotaCores, quotas.Cores, usage.Cores, requested.Cores},
+		{QuotaUnits, quotas.Units, usage.Units, requested.Units},
+		{QuotaStorage, quotas.Storage, usage.Storage, requested.Storage},
+	} {
+		if check.limit == 0 || check.requested == 0 {
+			continue
+		}
+		if check.used+check.requested > check.limit {
+			return &ErrQuotaExceeded{
+				Resource:  check.resource,
+				Limit:     check.limit,
+				Used:      check.used,
+				Requested: check.requested,
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type modelQuotasSuite struct {
+	statetesting.StateSuite
+}
+
+var _ = gc.Suite(&modelQuotasSuite{})
+
+func (s *modelQuotasSuite) TestModelQuotasUnset(c *gc.C) {
+	quotas, err := s.State.ModelQuotas()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(quotas, jc.DeepEquals, state.ModelQuotas{})
+}
+
+func (s *modelQuotasSuite) TestSetModelQuotas(c *gc.C) {
+	err := s.State.SetModelQuotas(state.ModelQuotas{Machines: 3, Units: 10})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetModelQuotas(state.ModelQuotas{Machines: 5, Cores: 8})
+	c.Assert(err, jc.ErrorIsNil)
+
+	quotas, err := s.State.ModelQuotas()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(quotas, jc.DeepEquals, state.ModelQuotas{Machines: 5, Cores: 8})
+
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	quotas, err = st.ModelQuotas()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(quotas, jc.DeepEquals, state.ModelQuotas{})
+}
+
+func (s *modelQuotasSuite) TestSetModelQuotasInvalid(c *gc.C) {
+	err := s.State.SetModelQuotas(state.ModelQuotas{Units: -1})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, "negative quota not valid")
+}
+
+func (s *modelQuotasSuite) addResources(c *gc.C) {
+	cores := uint64(4)
+	m := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Characteristics: &instance.HardwareCharacteristics{CpuCores: &cores},
+	})
+	s.Factory.MakeUnprovisionedMachineReturningPassword(c, &factory.MachineParams{
+		Constraints: constraints.MustParse("cores=2"),
+	})
+	_, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, m.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	s.Factory.MakeUnit(c, &factory.UnitParams{Machine: m})
+}
+
+func (s *modelQuotasSuite) TestModelResourceUsage(c *gc.C) {
+	s.addResources(c)
+	usage, err := s.State.ModelResourceUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage, jc.DeepEquals, state.ModelResources{
+		Machines: 2,
+		Cores:    6,
+		Units:    1,
+	})
+}
+
+func (s *modelQuotasSuite) TestCheckModelQuotasUnlimited(c *gc.C) {
+	s.addResources(c)
+	err := s.State.CheckModelQuotas(state.ModelResources{Machines: 100, Units: 100})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *modelQuotasSuite) TestCheckModelQuotas(c *gc.C) {
+	s.addResources(c)
+	err := s.State.SetModelQuotas(state.ModelQuotas{Machines: 3, Cores: 8, Units: 1})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.CheckModelQuotas(state.ModelResources{Machines: 1, Cores: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.CheckModelQuotas(state.ModelResources{Storage: 10})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.CheckModelQuotas(state.ModelResources{Machines: 2})
+	c.Assert(err, jc.Satisfies, state.IsQuotaExceededError)
+	c.Assert(err, gc.ErrorMatches, "model quota exceeded: 2 machines requested, 2 of 3 already in use")
+
+	err = s.State.CheckModelQuotas(state.ModelResources{Machines: 1, Cores: 4})
+	c.Assert(err, gc.ErrorMatches, "model quota exceeded: 4 cores requested, 6 of 8 already in use")
+
+	err = s.State.CheckModelQuotas(state.ModelResources{Units: 1})
+	c.Assert(err, jc.DeepEquals, &state.ErrQuotaExceeded{
+		Resource:  state.QuotaUnits,
+		Limit:     1,
+		Used:      1,
+		Requested: 1,
+	})
+}
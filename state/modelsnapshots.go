@@ -0,0 +1,194 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/core/modelsnapshot"
+)
+
+const (
+	// ModelSnapshotRetention is how long model snapshots are kept
+	// for. The most recent snapshot of a model is always kept,
+	// however old it is.
+	ModelSnapshotRetention = 30 * 24 * time.Hour
+
+	// maxModelSnapshotSize is the size of the largest model
+	// description that is recorded, which leaves ample room below
+	// mongo's document size limit.
+	maxModelSnapshotSize = 12 * 1024 * 1024
+)
+
+// ModelSnapshot is a model's description, as it was at some point in
+// time. Snapshots hold the topology and configuration of the model,
+// but not its status or any blobs such as charms and resources.
+type ModelSnapshot struct {
+	// Id identifies the snapshot within its model. Snapshots taken
+	// later have higher ids.
+	Id int
+
+	// Taken is when the snapshot was taken.
+	Taken time.Time
+
+	// Description holds the normalised YAML serialization of the
+	// model, as returned by ModelSnapshotDescription.
+	Description []byte
+}
+
+type modelSnapshotDoc struct {
+	DocID       string `bson:"_id"`
+	ModelUUID   string `bson:"model-uuid"`
+	Seq         int    `bson:"seq"`
+	Taken       int64  `bson:"taken"`
+	Hash        string `bson:"hash"`
+	Description string `bson:"description,omitempty"`
+}
+
+func (doc modelSnapshotDoc) snapshot() ModelSnapshot {
+	var desc []byte
+	if doc.Description != "" {
+		desc = []byte(doc.Description)
+	}
+	return ModelSnapshot{
+		Id:          doc.Seq,
+		Taken:       time.Unix(0, doc.Taken).UTC(),
+		Description: desc,
+	}
+}
+
+// ModelSnapshotDescription returns the normalised description of the
+// model as it is now, in the form recorded in its snapshots.
+func (st *State) ModelSnapshotDescription() ([]byte, error) {
+	model, err := st.ExportPartial(ExportConfig{
+		SkipActions:            true,
+		SkipCloudImageMetadata: true,
+		SkipCredentials:        true,
+		SkipIPAddresses:        true,
+		SkipSSHHostKeys:        true,
+		SkipStatusHistory:      true,
+		SkipLinkLayerDevices:   true,
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot export model")
+	}
+	out, err := description.Serialize(model)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return modelsnapshot.Normalise(out)
+}
+
+// TakeModelSnapshot records the model's current description, and
+// removes those of its snapshots that are older than
+// ModelSnapshotRetention. If the model has not changed since its most
+// recent snapshot, no snapshot is recorded and the most recent one is
+// returned, without its description.
+func (st *State) TakeModelSnapshot() (ModelSnapshot, error) {
+	desc, err := st.ModelSnapshotDescription()
+	if err != nil {
+		return ModelSnapshot{}, errors.Trace(err)
+	}
+	if len(desc) > maxModelSnapshotSize {
+		return ModelSnapshot{}, errors.NotSupportedf("snapshot of %d byte model description", len(desc))
+	}
+	hash := sha256.Sum256(desc)
+	hexHash := hex.EncodeToString(hash[:])
+
+	coll, closer := st.db().GetCollection(modelSnapshotsC)
+	defer closer()
+
+	var latest modelSnapshotDoc
+	err = coll.Find(nil).Select(bson.D{{"description", 0}}).Sort("-seq").One(&latest)
+	if err == nil && latest.Hash == hexHash {
+		return latest.snapshot(), nil
+	} else if err != nil && err != mgo.ErrNotFound {
+		return ModelSnapshot{}, errors.Annotate(err, "cannot read latest model snapshot")
+	}
+
+	seq, err := sequenceWithMin(st, "modelSnapshot", 1)
+	if err != nil {
+		return ModelSnapshot{}, errors.Trace(err)
+	}
+	now := st.clock().Now()
+	doc := modelSnapshotDoc{
+		DocID:       st.docID(strconv.Itoa(seq)),
+		ModelUUID:   st.ModelUUID(),
+		Seq:         seq,
+		Taken:       now.UnixNano(),
+		Hash:        hexHash,
+		Description: string(desc),
+	}
+	if err := coll.Writeable().Insert(doc); err != nil {
+		return ModelSnapshot{}, errors.Annotate(err, "cannot record model snapshot")
+	}
+	_, err = coll.Writeable().RemoveAll(bson.D{
+		{"seq", bson.D{{"$ne", seq}}},
+		{"taken", bson.D{{"$lt", now.Add(-ModelSnapshotRetention).UnixNano()}}},
+	})
+	if err != nil {
+		return ModelSnapshot{}, errors.Annotate(err, "cannot prune model snapshots")
+	}
+	return doc.snapshot(), nil
+}
+
+// ModelSnapshots returns the model's snapshots, oldest first, without
+// their descriptions.
+func (st *State) ModelSnapshots() ([]ModelSnapshot, error) {
+	coll, closer := st.db().GetCollection(modelSnapshotsC)
+	defer closer()
+
+	var docs []modelSnapshotDoc
+	err := coll.Find(nil).Select(bson.D{{"description", 0}}).Sort("seq").All(&docs)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read model snapshots")
+	}
+	snapshots := make([]ModelSnapshot, len(docs))
+	for i, doc := range docs {
+		snapshots[i] = doc.snapshot()
+	}
+	return snapshots, nil
+}
+
+// ModelSnapshot returns the model snapshot with the given id. If there
+// is no such snapshot, an error satisfying errors.IsNotFound is
+// returned.
+func (st *State) ModelSnapshot(id int) (ModelSnapshot, error) {
+	coll, closer := st.db().GetCollection(modelSnapshotsC)
+	defer closer()
+
+	var doc modelSnapshotDoc
+	err := coll.FindId(strconv.Itoa(id)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return ModelSnapshot{}, errors.NotFoundf("model snapshot %d", id)
+	} else if err != nil {
+		return ModelSnapshot{}, errors.Annotatef(err, "cannot read model snapshot %d", id)
+	}
+	return doc.snapshot(), nil
+}
+
+// ModelSnapshotAt returns the most recent model snapshot taken at or
+// before the given time. If there is no such snapshot, an error
+// satisfying errors.IsNotFound is returned.
+func (st *State) ModelSnapshotAt(t time.Time) (ModelSnapshot, error) {
+	coll, closer := st.db().GetCollection(modelSnapshotsC)
+	defer closer()
+
+	var doc modelSnapshotDoc
+	err := coll.Find(bson.D{{"taken", bson.D{{"$lte", t.UnixNano()}}}}).Sort("-seq").One(&doc)
+	if err == mgo.ErrNotFound {
+		return ModelSnapshot{}, errors.NotFoundf("model snapshot taken by %s", t.UTC().Format(time.RFC3339))
+	} else if err != nil {
+		return ModelSnapshot{}, errors.Annotate(err, "cannot read model snapshot")
+	}
+	return doc.snapshot(), nil
+}
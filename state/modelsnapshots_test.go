@@ -0,0 +1,122 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type ModelSnapshotsSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ModelSnapshotsSuite{})
+
+func (s *ModelSnapshotsSuite) TestTakeModelSnapshot(c *gc.C) {
+	first, err := s.State.TakeModelSnapshot()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(first.Id, gc.Equals, 1)
+	c.Assert(first.Taken.Equal(s.Clock.Now()), jc.IsTrue)
+	live, err := s.State.ModelSnapshotDescription()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(first.Description), gc.Equals, string(live))
+	c.Assert(string(live), gc.Not(jc.Contains), "status:")
+
+	// Nothing is recorded while the model is unchanged.
+	s.Clock.Advance(time.Hour)
+	again, err := s.State.TakeModelSnapshot()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(again.Id, gc.Equals, 1)
+	c.Assert(again.Description, gc.IsNil)
+
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "wordpress"})
+	s.Clock.Advance(time.Hour)
+	second, err := s.State.TakeModelSnapshot()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(second.Id, gc.Equals, 2)
+	c.Assert(string(second.Description), jc.Contains, "name: wordpress")
+
+	snapshots, err := s.State.ModelSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, jc.DeepEquals, []state.ModelSnapshot{{
+		Id:    1,
+		Taken: first.Taken,
+	}, {
+		Id:    2,
+		Taken: second.Taken,
+	}})
+
+	got, err := s.State.ModelSnapshot(2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, jc.DeepEquals, second)
+	_, err = s.State.ModelSnapshot(3)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ModelSnapshotsSuite) TestModelSnapshotAt(c *gc.C) {
+	_, err := s.State.ModelSnapshotAt(s.Clock.Now())
+	c.Assert(err, gc.ErrorMatches, "model snapshot taken by .* not found")
+
+	first, err := s.State.TakeModelSnapshot()
+	c.Assert(err, jc.ErrorIsNil)
+	s.Clock.Advance(time.Hour)
+	s.Factory.MakeApplication(c, nil)
+	second, err := s.State.TakeModelSnapshot()
+	c.Assert(err, jc.ErrorIsNil)
+
+	got, err := s.State.ModelSnapshotAt(first.Taken.Add(time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got.Id, gc.Equals, first.Id)
+	got, err = s.State.ModelSnapshotAt(second.Taken)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got.Id, gc.Equals, second.Id)
+	_, err = s.State.ModelSnapshotAt(first.Taken.Add(-time.Second))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ModelSnapshotsSuite) TestTakeModelSnapshotPrunes(c *gc.C) {
+	_, err := s.State.TakeModelSnapshot()
+	c.Assert(err, jc.ErrorIsNil)
+	s.Clock.Advance(state.ModelSnapshotRetention)
+	s.Factory.MakeApplication(c, nil)
+	second, err := s.State.TakeModelSnapshot()
+	c.Assert(err, jc.ErrorIsNil)
+	s.Clock.Advance(time.Second)
+	s.Factory.MakeUnit(c, nil)
+	third, err := s.State.TakeModelSnapshot()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The first snapshot was taken more than the retention
+	// period before the third, and is removed.
+	snapshots, err := s.State.ModelSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, jc.DeepEquals, []state.ModelSnapshot{{
+		Id:    second.Id,
+		Taken: second.Taken,
+	}, {
+		Id:    third.Id,
+		Taken: third.Taken,
+	}})
+}
+
+func (s *ModelSnapshotsSuite) TestModelSnapshotsPerModel(c *gc.C) {
+	_, err := s.State.TakeModelSnapshot()
+	c.Assert(err, jc.ErrorIsNil)
+
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	snapshots, err := st.ModelSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, gc.HasLen, 0)
+	taken, err := st.TakeModelSnapshot()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(taken.Id, gc.Equals, 1)
+}
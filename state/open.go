@@ -47,6 +47,10 @@ type OpenParams struct {
 	// InitDatabaseFunc, if non-nil, is a function that will be called
 	// just after the state database is opened.
 	InitDatabaseFunc InitDatabaseFunc
+
+	// RaftLeases, if non-nil, holds the replicated lease state that
+	// the lease managers will use in place of the leases collection.
+	RaftLeases *RaftLeases
 }
 
 // Validate validates the OpenParams.
@@ -90,6 +94,7 @@ func OpenController(args OpenParams) (*Controller, error) {
 		session:                session,
 		newPolicy:              args.NewPolicy,
 		runTransactionObserver: args.RunTransactionObserver,
+		raftLeases:             args.RaftLeases,
 	}, nil
 }
 
@@ -123,6 +128,8 @@ func Open(args OpenParams) (*State, error) {
 		return nil, mongo.MaybeUnauthorizedf(err, "cannot read model %s", args.ControllerModelTag.Id())
 	}
 
+	st.raftLeases = args.RaftLeases
+
 	// State should only be Opened on behalf of a controller environ; all
 	// other *States must be obtained via StatePool.
 	if err := st.start(args.ControllerTag); err != nil {
@@ -0,0 +1,92 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+)
+
+// orphanedResourcesKey is the local id of the single orphaned
+// resources document held for each model.
+const orphanedResourcesKey = "orphans"
+
+// OrphanedResources records the provider resources that the orphan
+// sweeper found during its most recent sweep of a model, which were
+// created by juju but are no longer tracked in state.
+type OrphanedResources struct {
+	// Swept is the time at which the sweep completed.
+	Swept time.Time
+
+	// Instances holds the ids of orphaned provider instances.
+	Instances []string
+
+	// Volumes holds the provider ids of orphaned volumes.
+	Volumes []string
+
+	// SecurityGroups holds the provider ids of orphaned security
+	// groups.
+	SecurityGroups []string
+
+	// Cleaned holds the ids of the orphaned resources, of either
+	// kind, that the sweeper successfully destroyed.
+	Cleaned []string
+}
+
+type orphanedResourcesDoc struct {
+	DocID     string   `bson:"_id"`
+	ModelUUID string   `bson:"model-uuid"`
+	Swept     int64    `bson:"swept"`
+	Instances []string `bson:"instances,omitempty"`
+	Volumes   []string `bson:"volumes,omitempty"`
+	Groups    []string `bson:"security-groups,omitempty"`
+	Cleaned   []string `bson:"cleaned,omitempty"`
+}
+
+// SetOrphanedResources records the results of a sweep for orphaned
+// provider resources, replacing those of any previous sweep.
+func (st *State) SetOrphanedResources(orphans OrphanedResources) error {
+	coll, closer := st.db().GetCollection(orphanedResourcesC)
+	defer closer()
+
+	doc := orphanedResourcesDoc{
+		DocID:     st.docID(orphanedResourcesKey),
+		ModelUUID: st.ModelUUID(),
+		Swept:     orphans.Swept.UnixNano(),
+		Instances: orphans.Instances,
+		Volumes:   orphans.Volumes,
+		Groups:    orphans.SecurityGroups,
+		Cleaned:   orphans.Cleaned,
+	}
+	_, err := coll.Writeable().UpsertId(doc.DocID, doc)
+	if err != nil {
+		return errors.Annotate(err, "cannot record orphaned resources")
+	}
+	return nil
+}
+
+// OrphanedResources returns the results of the most recent sweep for
+// orphaned provider resources. If the model has not yet been swept, an
+// error satisfying errors.IsNotFound is returned.
+func (st *State) OrphanedResources() (OrphanedResources, error) {
+	coll, closer := st.db().GetCollection(orphanedResourcesC)
+	defer closer()
+
+	var doc orphanedResourcesDoc
+	err := coll.FindId(orphanedResourcesKey).One(&doc)
+	if err == mgo.ErrNotFound {
+		return OrphanedResources{}, errors.NotFoundf("orphaned resources for model %q", st.ModelUUID())
+	} else if err != nil {
+		return OrphanedResources{}, errors.Annotate(err, "cannot read orphaned resources")
+	}
+	return OrphanedResources{
+		Swept:          time.Unix(0, doc.Swept).UTC(),
+		Instances:      doc.Instances,
+		Volumes:        doc.Volumes,
+		SecurityGroups: doc.Groups,
+		Cleaned:        doc.Cleaned,
+	}, nil
+}
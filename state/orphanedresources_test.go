@@ -0,0 +1,82 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type orphanedResourcesSuite struct {
+	statetesting.StateSuite
+}
+
+var _ = gc.Suite(&orphanedResourcesSuite{})
+
+func (s *orphanedResourcesSuite) TestOrphanedResourcesNotSwept(c *gc.C) {
+	_, err := s.State.OrphanedResources()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *orphanedResourcesSuite) TestSetOrphanedResources(c *gc.C) {
+	swept := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	err := s.State.SetOrphanedResources(state.OrphanedResources{
+		Swept:          swept,
+		Instances:      []string{"i-0", "i-1"},
+		Volumes:        []string{"vol-0"},
+		SecurityGroups: []string{"sg-0"},
+		Cleaned:        []string{"i-1"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	orphans, err := s.State.OrphanedResources()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(orphans, jc.DeepEquals, state.OrphanedResources{
+		Swept:          swept,
+		Instances:      []string{"i-0", "i-1"},
+		Volumes:        []string{"vol-0"},
+		SecurityGroups: []string{"sg-0"},
+		Cleaned:        []string{"i-1"},
+	})
+}
+
+func (s *orphanedResourcesSuite) TestSetOrphanedResourcesReplaces(c *gc.C) {
+	err := s.State.SetOrphanedResources(state.OrphanedResources{
+		Swept:     time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC),
+		Instances: []string{"i-0"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	swept := time.Date(2018, 3, 1, 13, 0, 0, 0, time.UTC)
+	err = s.State.SetOrphanedResources(state.OrphanedResources{
+		Swept:   swept,
+		Volumes: []string{"vol-0"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	orphans, err := s.State.OrphanedResources()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(orphans, jc.DeepEquals, state.OrphanedResources{
+		Swept:   swept,
+		Volumes: []string{"vol-0"},
+	})
+}
+
+func (s *orphanedResourcesSuite) TestOrphanedResourcesModelScoped(c *gc.C) {
+	err := s.State.SetOrphanedResources(state.OrphanedResources{
+		Swept:     time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC),
+		Instances: []string{"i-0"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	_, err = st.OrphanedResources()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
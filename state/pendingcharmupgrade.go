@@ -0,0 +1,174 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// PendingCharmUpgrade records a newer revision of an application's
+// charm, found in the channel the application tracks, that is waiting
+// to be applied automatically under the application's refresh policy.
+type PendingCharmUpgrade struct {
+	// ApplicationName is the name of the application to upgrade.
+	ApplicationName string
+
+	// CharmURL identifies the newer revision of the charm.
+	CharmURL *charm.URL
+
+	// Created is the time at which the newer revision was first
+	// found.
+	Created time.Time
+}
+
+type pendingCharmUpgradeDoc struct {
+	DocID       string `bson:"_id"`
+	ModelUUID   string `bson:"model-uuid"`
+	Application string `bson:"application"`
+	CharmURL    string `bson:"charm-url"`
+	Created     int64  `bson:"created"`
+}
+
+// SetPendingCharmUpgrade records that the named application's charm
+// is to be upgraded automatically to the given charm, replacing any
+// upgrade already pending for the application.
+func (st *State) SetPendingCharmUpgrade(appName string, curl *charm.URL) error {
+	if curl == nil || curl.Revision < 0 {
+		return errors.NotValidf("charm URL without revision")
+	}
+	docID := st.docID(appName)
+	buildTxn := func(int) ([]txn.Op, error) {
+		app, err := st.Application(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if app.Life() != Alive {
+			return nil, errors.Errorf("application is not alive")
+		}
+		ops := []txn.Op{{
+			C:      applicationsC,
+			Id:     app.doc.DocID,
+			Assert: isAliveDoc,
+		}}
+		existing, err := st.PendingCharmUpgrade(appName)
+		if errors.IsNotFound(err) {
+			return append(ops, txn.Op{
+				C:      pendingCharmUpgradesC,
+				Id:     docID,
+				Assert: txn.DocMissing,
+				Insert: &pendingCharmUpgradeDoc{
+					DocID:       docID,
+					ModelUUID:   st.ModelUUID(),
+					Application: appName,
+					CharmURL:    curl.String(),
+					Created:     st.clock().Now().UnixNano(),
+				},
+			}), nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if *existing.CharmURL == *curl {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return append(ops, txn.Op{
+			C:      pendingCharmUpgradesC,
+			Id:     docID,
+			Assert: bson.D{{"charm-url", existing.CharmURL.String()}},
+			Update: bson.D{{"$set", bson.D{
+				{"charm-url", curl.String()},
+				{"created", st.clock().Now().UnixNano()},
+			}}},
+		}), nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot set pending charm upgrade for application %q", appName)
+	}
+	return nil
+}
+
+// PendingCharmUpgrade returns the upgrade pending for the named
+// application, or an error satisfying errors.IsNotFound if there is
+// none.
+func (st *State) PendingCharmUpgrade(appName string) (PendingCharmUpgrade, error) {
+	coll, closer := st.db().GetCollection(pendingCharmUpgradesC)
+	defer closer()
+
+	var doc pendingCharmUpgradeDoc
+	err := coll.FindId(appName).One(&doc)
+	if err == mgo.ErrNotFound {
+		return PendingCharmUpgrade{}, errors.NotFoundf("pending charm upgrade for application %q", appName)
+	} else if err != nil {
+		return PendingCharmUpgrade{}, errors.Annotatef(err, "cannot read pending charm upgrade for application %q", appName)
+	}
+	return doc.upgrade()
+}
+
+// PendingCharmUpgrades returns the upgrades pending for the model's
+// applications, ordered by application name.
+func (st *State) PendingCharmUpgrades() ([]PendingCharmUpgrade, error) {
+	coll, closer := st.db().GetCollection(pendingCharmUpgradesC)
+	defer closer()
+
+	var docs []pendingCharmUpgradeDoc
+	if err := coll.Find(nil).Sort("application").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read pending charm upgrades")
+	}
+	upgrades := make([]PendingCharmUpgrade, len(docs))
+	for i, doc := range docs {
+		upgrade, err := doc.upgrade()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		upgrades[i] = upgrade
+	}
+	return upgrades, nil
+}
+
+// RemovePendingCharmUpgrade removes the upgrade pending for the named
+// application, if it is to the given charm. An upgrade to any other
+// charm, recorded since the caller read it, is left in place.
+func (st *State) RemovePendingCharmUpgrade(appName string, curl *charm.URL) error {
+	ops := []txn.Op{{
+		C:      pendingCharmUpgradesC,
+		Id:     st.docID(appName),
+		Assert: bson.D{{"charm-url", curl.String()}},
+		Remove: true,
+	}}
+	err := st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		return nil
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot remove pending charm upgrade for application %q", appName)
+	}
+	return nil
+}
+
+// removePendingCharmUpgradeOp returns an operation that removes any
+// upgrade pending for the named application.
+func removePendingCharmUpgradeOp(mb modelBackend, appName string) txn.Op {
+	return txn.Op{
+		C:      pendingCharmUpgradesC,
+		Id:     mb.docID(appName),
+		Remove: true,
+	}
+}
+
+func (doc pendingCharmUpgradeDoc) upgrade() (PendingCharmUpgrade, error) {
+	curl, err := charm.ParseURL(doc.CharmURL)
+	if err != nil {
+		return PendingCharmUpgrade{}, errors.Trace(err)
+	}
+	return PendingCharmUpgrade{
+		ApplicationName: doc.Application,
+		CharmURL:        curl,
+		Created:         time.Unix(0, doc.Created).UTC(),
+	}, nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/state"
+)
+
+type PendingCharmUpgradeSuite struct {
+	ConnSuite
+	mysql     *state.Application
+	wordpress *state.Application
+}
+
+var _ = gc.Suite(&PendingCharmUpgradeSuite{})
+
+func (s *PendingCharmUpgradeSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.mysql = s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	s.wordpress = s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+}
+
+func (s *PendingCharmUpgradeSuite) TestNoPendingCharmUpgrade(c *gc.C) {
+	_, err := s.State.PendingCharmUpgrade("mysql")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `pending charm upgrade for application "mysql" not found`)
+
+	all, err := s.State.PendingCharmUpgrades()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 0)
+}
+
+func (s *PendingCharmUpgradeSuite) TestSetPendingCharmUpgrade(c *gc.C) {
+	curl := charm.MustParseURL("cs:quantal/mysql-5")
+	err := s.State.SetPendingCharmUpgrade("mysql", curl)
+	c.Assert(err, jc.ErrorIsNil)
+
+	upgrade, err := s.State.PendingCharmUpgrade("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrade.ApplicationName, gc.Equals, "mysql")
+	c.Assert(upgrade.CharmURL, jc.DeepEquals, curl)
+	c.Assert(upgrade.Created.IsZero(), jc.IsFalse)
+
+	// Setting again replaces the pending upgrade.
+	curl = charm.MustParseURL("cs:quantal/mysql-6")
+	err = s.State.SetPendingCharmUpgrade("mysql", curl)
+	c.Assert(err, jc.ErrorIsNil)
+	upgrade, err = s.State.PendingCharmUpgrade("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrade.CharmURL, jc.DeepEquals, curl)
+
+	// Setting the same charm does nothing.
+	err = s.State.SetPendingCharmUpgrade("mysql", curl)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *PendingCharmUpgradeSuite) TestSetPendingCharmUpgradeInvalid(c *gc.C) {
+	err := s.State.SetPendingCharmUpgrade("mysql", charm.MustParseURL("cs:quantal/mysql"))
+	c.Assert(err, gc.ErrorMatches, "charm URL without revision not valid")
+
+	err = s.State.SetPendingCharmUpgrade("postgresql", charm.MustParseURL("cs:quantal/postgresql-1"))
+	c.Assert(err, gc.ErrorMatches, `cannot set pending charm upgrade for application "postgresql": application "postgresql" not found`)
+}
+
+func (s *PendingCharmUpgradeSuite) TestPendingCharmUpgrades(c *gc.C) {
+	err := s.State.SetPendingCharmUpgrade("wordpress", charm.MustParseURL("cs:quantal/wordpress-4"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetPendingCharmUpgrade("mysql", charm.MustParseURL("cs:quantal/mysql-5"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	all, err := s.State.PendingCharmUpgrades()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 2)
+	c.Assert(all[0].ApplicationName, gc.Equals, "mysql")
+	c.Assert(all[0].CharmURL.String(), gc.Equals, "cs:quantal/mysql-5")
+	c.Assert(all[1].ApplicationName, gc.Equals, "wordpress")
+	c.Assert(all[1].CharmURL.String(), gc.Equals, "cs:quantal/wordpress-4")
+}
+
+func (s *PendingCharmUpgradeSuite) TestRemovePendingCharmUpgrade(c *gc.C) {
+	err := s.State.SetPendingCharmUpgrade("mysql", charm.MustParseURL("cs:quantal/mysql-6"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// An upgrade to a different charm is left in place.
+	err = s.State.RemovePendingCharmUpgrade("mysql", charm.MustParseURL("cs:quantal/mysql-5"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.PendingCharmUpgrade("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemovePendingCharmUpgrade("mysql", charm.MustParseURL("cs:quantal/mysql-6"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.PendingCharmUpgrade("mysql")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Removing again does nothing.
+	err = s.State.RemovePendingCharmUpgrade("mysql", charm.MustParseURL("cs:quantal/mysql-6"))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *PendingCharmUpgradeSuite) TestRemovedWithApplication(c *gc.C) {
+	err := s.State.SetPendingCharmUpgrade("mysql", charm.MustParseURL("cs:quantal/mysql-5"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.PendingCharmUpgrade("mysql")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
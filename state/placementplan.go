@@ -0,0 +1,303 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+)
+
+// PlacementPlanParams describes a prospective deployment of units of an
+// application, for PlanPlacement.
+type PlacementPlanParams struct {
+	// Series is the series of the units' charm.
+	Series string
+
+	// Constraints holds the application constraints, which are
+	// combined with the model constraints as they would be for the
+	// units.
+	Constraints constraints.Value
+
+	// NumUnits is the number of units to place.
+	NumUnits int
+
+	// Placement holds the placement directives for the units, in
+	// order. Units beyond the last directive are placed as they would
+	// be without one.
+	Placement []*instance.Placement
+}
+
+// PlannedPlacement describes where a unit would be placed by a
+// deployment.
+type PlannedPlacement struct {
+	// MachineId holds the id of the existing machine on which the
+	// unit, or the new container holding it, would be placed. It is
+	// empty if a new machine would be required.
+	MachineId string
+
+	// NewMachine reports whether a new machine would be provisioned
+	// for the unit.
+	NewMachine bool
+
+	// ContainerType holds the type of the new container in which the
+	// unit would be placed, if any.
+	ContainerType instance.ContainerType
+
+	// Directive holds the provider placement directive, such as
+	// "zone=us-east-1a", with which a new machine would be
+	// provisioned, if any.
+	Directive string
+
+	// Zone holds the availability zone of the existing machine, if
+	// known.
+	Zone string
+
+	// Constraints holds the constraints with which any new machine or
+	// container would be created.
+	Constraints constraints.Value
+
+	// Warnings holds any reasons to expect that the placement will
+	// not serve the unit well, such as the existing machine not
+	// satisfying the unit's constraints.
+	Warnings []string
+
+	// Err holds the reason the unit cannot be placed as directed, if
+	// any.
+	Err error
+}
+
+// PlanPlacement reports where the units of a prospective deployment
+// would be placed, given the model's current machines, without changing
+// anything. Units placed on existing machines or in new containers on
+// them are checked against the machines as they are now; new machines
+// are checked against the provider as they would be when added.
+//
+// The plan does not take the units' storage into account, and units
+// without placement directives may be placed on different clean, empty
+// machines when actually deployed, since a unit is spread across the
+// availability zones of the machines hosting other units of its
+// application.
+func (st *State) PlanPlacement(args PlacementPlanParams) ([]PlannedPlacement, error) {
+	if args.Series == "" {
+		return nil, errors.NotValidf("empty series")
+	}
+	if args.NumUnits < 1 {
+		return nil, errors.NotValidf("number of units %d", args.NumUnits)
+	}
+	if len(args.Placement) > args.NumUnits {
+		return nil, errors.NotValidf("%d placement directives for %d units", len(args.Placement), args.NumUnits)
+	}
+	cons, err := st.resolveConstraints(args.Constraints)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	planner := &placementPlanner{
+		st:     st,
+		series: args.Series,
+		cons:   cons,
+		used:   set.NewStrings(),
+	}
+	plan := make([]PlannedPlacement, args.NumUnits)
+	for i := range plan {
+		if i < len(args.Placement) && args.Placement[i] != nil {
+			plan[i] = planner.planDirective(args.Placement[i])
+		} else {
+			plan[i] = planner.planClean()
+		}
+	}
+	return plan, nil
+}
+
+// placementPlanner plans the placement of units, keeping track of the
+// clean machines that earlier units would occupy.
+type placementPlanner struct {
+	st     *State
+	series string
+	cons   constraints.Value
+	used   set.Strings
+}
+
+func (p *placementPlanner) planDirective(placement *instance.Placement) PlannedPlacement {
+	data, err := p.st.parsePlacement(placement)
+	if err != nil {
+		return PlannedPlacement{Err: err}
+	}
+	switch data.placementType() {
+	case containerPlacement:
+		plan := PlannedPlacement{
+			ContainerType: data.containerType,
+			Constraints:   p.cons,
+		}
+		if data.machineId == "" {
+			plan.NewMachine = true
+			plan.Err = p.st.precheckInstance(p.series, p.cons, "", nil)
+			return plan
+		}
+		m, err := p.aliveMachine(data.machineId)
+		if err != nil {
+			plan.Err = err
+			return plan
+		}
+		if !m.supportsContainerType(data.containerType) {
+			plan.Err = errors.Errorf("machine %s cannot host %s containers", m.Id(), data.containerType)
+			return plan
+		}
+		if err := p.st.checkCanHostUnits(m, ""); err != nil {
+			plan.Err = err
+			return plan
+		}
+		plan.MachineId = m.Id()
+		plan.Zone = machineZone(m)
+		return plan
+	case directivePlacement:
+		return PlannedPlacement{
+			NewMachine:  true,
+			Directive:   data.directive,
+			Constraints: p.cons,
+			Err:         p.st.precheckInstance(p.series, p.cons, data.directive, nil),
+		}
+	default:
+		m, err := p.aliveMachine(data.machineId)
+		if err != nil {
+			return PlannedPlacement{Err: err}
+		}
+		if err := validateUnitMachineAssignment(m, p.series, false, nil); err != nil {
+			return PlannedPlacement{Err: errors.Annotatef(err, "cannot place unit on machine %s", m.Id())}
+		}
+		if err := p.st.checkCanHostUnits(m, ""); err != nil {
+			return PlannedPlacement{Err: err}
+		}
+		p.used.Add(m.Id())
+		warnings, err := unmetConstraints(m, p.cons)
+		return PlannedPlacement{
+			MachineId: m.Id(),
+			Zone:      machineZone(m),
+			Warnings:  warnings,
+			Err:       err,
+		}
+	}
+}
+
+// planClean plans the placement of a unit without a placement
+// directive, which is assigned to a clean, empty machine satisfying
+// its constraints if there is one, and otherwise to a new machine.
+func (p *placementPlanner) planClean() PlannedPlacement {
+	m, err := p.cleanEmptyMachine()
+	if err != nil {
+		return PlannedPlacement{Err: err}
+	}
+	if m != nil {
+		p.used.Add(m.Id())
+		return PlannedPlacement{
+			MachineId: m.Id(),
+			Zone:      machineZone(m),
+		}
+	}
+	plan := PlannedPlacement{
+		NewMachine:  true,
+		Constraints: p.cons,
+		Err:         p.st.precheckInstance(p.series, p.cons, "", nil),
+	}
+	if p.cons.HasContainer() {
+		plan.ContainerType = *p.cons.Container
+	}
+	return plan
+}
+
+// cleanEmptyMachine returns a clean, empty machine that satisfies the
+// constraints and is not used by earlier units of the plan,
+// preferring provisioned machines, or nil if there is none.
+func (p *placementPlanner) cleanEmptyMachine() (*Machine, error) {
+	query, err := findCleanMachineQuery(p.st, p.series, true, &p.cons)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machinesCollection, closer := p.st.db().GetCollection(machinesC)
+	defer closer()
+	var mdocs []*machineDoc
+	if err := machinesCollection.Find(query).All(&mdocs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	sort.Slice(mdocs, func(i, j int) bool {
+		return machineIdLessThan(mdocs[i].Id, mdocs[j].Id)
+	})
+	var unprovisioned *Machine
+	for _, mdoc := range mdocs {
+		if p.used.Contains(mdoc.Id) {
+			continue
+		}
+		m := newMachine(p.st, mdoc)
+		if _, err := m.InstanceId(); errors.IsNotProvisioned(err) {
+			if unprovisioned == nil {
+				unprovisioned = m
+			}
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return m, nil
+	}
+	return unprovisioned, nil
+}
+
+// aliveMachine returns the machine with the given id, or an error if
+// it does not exist or is not alive.
+func (p *placementPlanner) aliveMachine(id string) (*Machine, error) {
+	m, err := p.st.Machine(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if m.Life() != Alive {
+		return nil, errors.Errorf("machine %s is not alive", id)
+	}
+	return m, nil
+}
+
+// machineZone returns the availability zone of the machine, or the
+// empty string if it is not known.
+func machineZone(m *Machine) string {
+	zone, err := m.AvailabilityZone()
+	if err != nil {
+		return ""
+	}
+	return zone
+}
+
+// unmetConstraints returns a description of each of the constraints
+// that the provisioned machine's hardware does not satisfy.
+func unmetConstraints(m *Machine, cons constraints.Value) ([]string, error) {
+	hc, err := m.HardwareCharacteristics()
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var unmet []string
+	checkUint := func(name string, want, have *uint64, format func(uint64) string) {
+		if want == nil || *want == 0 {
+			return
+		}
+		if have == nil {
+			unmet = append(unmet, fmt.Sprintf("machine %s %s unknown, %s=%s required", m.Id(), name, name, format(*want)))
+		} else if *have < *want {
+			unmet = append(unmet, fmt.Sprintf("machine %s has %s=%s, %s=%s required", m.Id(), name, format(*have), name, format(*want)))
+		}
+	}
+	megabytes := func(v uint64) string { return fmt.Sprintf("%dM", v) }
+	count := func(v uint64) string { return fmt.Sprint(v) }
+	if cons.Arch != nil && *cons.Arch != "" && hc.Arch != nil && *hc.Arch != *cons.Arch {
+		unmet = append(unmet, fmt.Sprintf("machine %s has arch=%s, arch=%s required", m.Id(), *hc.Arch, *cons.Arch))
+	}
+	checkUint("cores", cons.CpuCores, hc.CpuCores, count)
+	checkUint("cpu-power", cons.CpuPower, hc.CpuPower, count)
+	checkUint("mem", cons.Mem, hc.Mem, megabytes)
+	checkUint("root-disk", cons.RootDisk, hc.RootDisk, megabytes)
+	return unmet, nil
+}
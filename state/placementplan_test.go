@@ -0,0 +1,146 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+)
+
+type PlacementPlanSuite struct {
+	ConnSuite
+	prechecker mockPrechecker
+}
+
+var _ = gc.Suite(&PlacementPlanSuite{})
+
+func (s *PlacementPlanSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.prechecker = mockPrechecker{}
+	s.policy.GetPrechecker = func() (environs.InstancePrechecker, error) {
+		return &s.prechecker, nil
+	}
+}
+
+func (s *PlacementPlanSuite) addProvisionedMachine(c *gc.C, hw string) *state.Machine {
+	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	hc := instance.MustParseHardware(hw)
+	err = m.SetProvisioned(instance.Id("inst-"+m.Id()), "fake_nonce", &hc)
+	c.Assert(err, jc.ErrorIsNil)
+	return m
+}
+
+func (s *PlacementPlanSuite) TestPlanPlacementCleanMachines(c *gc.C) {
+	s.addProvisionedMachine(c, "mem=2G availability-zone=zone-a")
+	s.addProvisionedMachine(c, "mem=8G availability-zone=zone-b")
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	plan, err := s.State.PlanPlacement(state.PlacementPlanParams{
+		Series:      "quantal",
+		Constraints: constraints.MustParse("mem=4G"),
+		NumUnits:    2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(plan, jc.DeepEquals, []state.PlannedPlacement{{
+		MachineId: "1",
+		Zone:      "zone-b",
+	}, {
+		NewMachine:  true,
+		Constraints: constraints.MustParse("mem=4G"),
+	}})
+}
+
+func (s *PlacementPlanSuite) TestPlanPlacementPrefersProvisioned(c *gc.C) {
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	s.addProvisionedMachine(c, "availability-zone=zone-a")
+
+	plan, err := s.State.PlanPlacement(state.PlacementPlanParams{
+		Series:   "quantal",
+		NumUnits: 3,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(plan, jc.DeepEquals, []state.PlannedPlacement{{
+		MachineId: "1",
+		Zone:      "zone-a",
+	}, {
+		MachineId: "0",
+	}, {
+		NewMachine: true,
+	}})
+}
+
+func (s *PlacementPlanSuite) TestPlanPlacementDirectives(c *gc.C) {
+	s.addProvisionedMachine(c, "mem=2G availability-zone=zone-a")
+	_, err := s.State.AddMachine("precise", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	modelUUID := s.State.ModelUUID()
+	plan, err := s.State.PlanPlacement(state.PlacementPlanParams{
+		Series:      "quantal",
+		Constraints: constraints.MustParse("mem=4G"),
+		NumUnits:    5,
+		Placement: []*instance.Placement{
+			{Scope: instance.MachineScope, Directive: "0"},
+			{Scope: string(instance.LXD), Directive: "0"},
+			{Scope: modelUUID, Directive: "zone=zone-b"},
+			{Scope: instance.MachineScope, Directive: "1"},
+			{Scope: instance.MachineScope, Directive: "42"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(plan, gc.HasLen, 5)
+	c.Check(plan[0], jc.DeepEquals, state.PlannedPlacement{
+		MachineId: "0",
+		Zone:      "zone-a",
+		Warnings:  []string{"machine 0 has mem=2048M, mem=4096M required"},
+	})
+	c.Check(plan[1], jc.DeepEquals, state.PlannedPlacement{
+		MachineId:     "0",
+		ContainerType: instance.LXD,
+		Zone:          "zone-a",
+		Constraints:   constraints.MustParse("mem=4G"),
+	})
+	c.Check(plan[2], jc.DeepEquals, state.PlannedPlacement{
+		NewMachine:  true,
+		Directive:   "zone=zone-b",
+		Constraints: constraints.MustParse("mem=4G"),
+	})
+	c.Check(s.prechecker.precheckInstanceArgs.Placement, gc.Equals, "zone=zone-b")
+	c.Check(plan[3].Err, gc.ErrorMatches, "cannot place unit on machine 1: series does not match")
+	c.Check(plan[4].Err, gc.ErrorMatches, "machine 42 not found")
+}
+
+func (s *PlacementPlanSuite) TestPlanPlacementPrecheckFails(c *gc.C) {
+	s.prechecker.precheckInstanceError = errors.New("no capacity")
+	plan, err := s.State.PlanPlacement(state.PlacementPlanParams{
+		Series:   "quantal",
+		NumUnits: 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(plan, gc.HasLen, 1)
+	c.Assert(plan[0].NewMachine, jc.IsTrue)
+	c.Assert(plan[0].Err, gc.ErrorMatches, "no capacity")
+}
+
+func (s *PlacementPlanSuite) TestPlanPlacementInvalid(c *gc.C) {
+	_, err := s.State.PlanPlacement(state.PlacementPlanParams{NumUnits: 1})
+	c.Assert(err, gc.ErrorMatches, "empty series not valid")
+	_, err = s.State.PlanPlacement(state.PlacementPlanParams{Series: "quantal"})
+	c.Assert(err, gc.ErrorMatches, "number of units 0 not valid")
+	_, err = s.State.PlanPlacement(state.PlacementPlanParams{
+		Series:    "quantal",
+		NumUnits:  1,
+		Placement: []*instance.Placement{{}, {}},
+	})
+	c.Assert(err, gc.ErrorMatches, "2 placement directives for 1 units not valid")
+}
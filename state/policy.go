@@ -140,6 +140,25 @@ func (st *State) resolveConstraints(cons constraints.Value) (constraints.Value,
 	return validator.Merge(envCons, cons)
 }
 
+// traceConstraints merges the model constraints with those of the
+// given layers, returning the result along with a trace of where each
+// attribute's value came from.
+func (st *State) traceConstraints(layers ...constraints.Layer) (constraints.Value, []constraints.AttributeTrace, error) {
+	validator, err := st.constraintsValidator()
+	if err != nil {
+		return constraints.Value{}, nil, errors.Trace(err)
+	}
+	modelCons, err := st.ModelConstraints()
+	if err != nil {
+		return constraints.Value{}, nil, errors.Trace(err)
+	}
+	layers = append([]constraints.Layer{{
+		Source: constraints.SourceModel,
+		Value:  modelCons,
+	}}, layers...)
+	return constraints.Trace(validator, layers...)
+}
+
 // validateConstraints returns an error if the given constraints are not valid for the
 // current model, and also any unsupported attributes.
 func (st *State) validateConstraints(cons constraints.Value) ([]string, error) {
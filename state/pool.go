@@ -119,6 +119,7 @@ func (p *StatePool) openState(modelUUID string) (*State, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	newSt.raftLeases = p.systemState.raftLeases
 	if err := newSt.start(p.systemState.controllerTag); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -0,0 +1,260 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils/set"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/core/raftlease"
+)
+
+// RaftLeases holds the lease state that a controller replicates among
+// the controllers with raft. If it is supplied when opening State, the
+// State's lease managers and global clock updater use it in place of
+// the leases and globalclock collections.
+type RaftLeases struct {
+	// FSM is the controller's copy of the replicated lease state.
+	FSM *raftlease.FSM
+
+	// Applier replicates lease commands among the controllers.
+	Applier raftlease.Applier
+}
+
+// raftLeaseApplyTimeout is the longest a raft lease store waits for
+// a command to be replicated.
+const raftLeaseApplyTimeout = 5 * time.Second
+
+// leaseHolderDoc records the holder of a raft lease, so that
+// transactions can assert that the lease is still held.
+type leaseHolderDoc struct {
+	DocID     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+	Namespace string `bson:"namespace"`
+	Lease     string `bson:"lease"`
+	Holder    string `bson:"holder"`
+}
+
+func leaseHolderDocID(namespace, lease string) string {
+	return namespace + "#" + lease
+}
+
+func (st *State) getRaftLeaseClient(namespace string) (lease.Client, error) {
+	store, err := st.newRaftLeaseStore(namespace)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// As with migrateModelLeasesToGlobalTime, the lease managers
+	// being embedded in State means we cannot use "upgrade steps"
+	// to move the leases into raft prior to their use, so we must
+	// move them here. When we are able to extract the lease managers
+	// from State, we should remove this.
+	if err := migrateModelLeasesToGlobalTime(st); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := migrateModelLeasesToRaft(st, namespace, store); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	holders := &leaseHolders{st: st, namespace: namespace}
+	if err := holders.reconcile(); err != nil {
+		return nil, errors.Annotatef(err, "reconciling %q lease holders", namespace)
+	}
+	return store, nil
+}
+
+func (st *State) newRaftLeaseStore(namespace string) (*raftlease.Store, error) {
+	holders := &leaseHolders{st: st, namespace: namespace}
+	store, err := raftlease.NewStore(raftlease.StoreConfig{
+		FSM:          st.raftLeases.FSM,
+		Applier:      st.raftLeases.Applier,
+		ModelUUID:    st.ModelUUID(),
+		Namespace:    namespace,
+		LocalClock:   st.stateClock,
+		ApplyTimeout: raftLeaseApplyTimeout,
+		Trapdoor:     holders.trapdoor,
+		Target:       holders,
+	})
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot create %q lease store", namespace)
+	}
+	return store, nil
+}
+
+// migrateModelLeasesToRaft imports the namespace's unexpired leases
+// from the leases collection into the raft lease store, and then
+// removes them from the collection. Leases are imported before they
+// are removed, and the store keeps any leases it already holds, so
+// an interrupted migration is completed the next time it runs.
+func migrateModelLeasesToRaft(st *State, namespace string, store *raftlease.Store) error {
+	coll, closer := st.db().GetCollection(leasesC)
+	defer closer()
+
+	query := bson.D{{"namespace", namespace}}
+	if n, err := coll.Find(query).Count(); err != nil {
+		return errors.Trace(err)
+	} else if n == 0 {
+		return nil
+	}
+
+	client, err := st.getMongoLeaseClient(namespace)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := store.Import(client.Leases()); err != nil {
+		return errors.Annotatef(err, "importing %q leases", namespace)
+	}
+
+	err = st.db().Run(func(int) ([]txn.Op, error) {
+		var doc struct {
+			DocID string `bson:"_id"`
+		}
+		var ops []txn.Op
+		iter := coll.Find(query).Select(bson.D{{"_id", 1}}).Iter()
+		defer iter.Close()
+		for iter.Next(&doc) {
+			ops = append(ops, txn.Op{
+				C:      coll.Name(),
+				Id:     st.localID(doc.DocID),
+				Assert: txn.DocExists,
+				Remove: true,
+			})
+		}
+		if err := iter.Close(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return ops, nil
+	})
+	return errors.Annotatef(err, "removing migrated %q leases", namespace)
+}
+
+// leaseHolders records the holders of a namespace's raft leases in the
+// leaseholders collection, and supplies the leases' trapdoors, which
+// assert on those records.
+type leaseHolders struct {
+	st        *State
+	namespace string
+}
+
+// Claimed is part of the raftlease.NotifyTarget interface.
+func (h *leaseHolders) Claimed(lease, holder string) error {
+	return errors.Trace(h.record(lease))
+}
+
+// Expired is part of the raftlease.NotifyTarget interface.
+func (h *leaseHolders) Expired(lease string) error {
+	return errors.Trace(h.record(lease))
+}
+
+// record updates the lease's holder document to match the FSM. The
+// FSM is consulted, rather than trusting the change just made, because
+// the lease managers on different controllers may record their changes
+// in a different order to that in which they were replicated.
+func (h *leaseHolders) record(lease string) error {
+	var holder string
+	if entry, found := h.entries()[lease]; found {
+		holder = entry.Holder
+	}
+
+	coll, closer := h.st.db().GetCollection(leaseHoldersC)
+	defer closer()
+
+	docID := leaseHolderDocID(h.namespace, lease)
+	buildTxn := func(int) ([]txn.Op, error) {
+		var doc leaseHolderDoc
+		err := coll.FindId(docID).One(&doc)
+		switch {
+		case err == mgo.ErrNotFound:
+			if holder == "" {
+				return nil, jujutxn.ErrNoOperations
+			}
+			return []txn.Op{{
+				C:      leaseHoldersC,
+				Id:     docID,
+				Assert: txn.DocMissing,
+				Insert: &leaseHolderDoc{
+					DocID:     h.st.docID(docID),
+					ModelUUID: h.st.ModelUUID(),
+					Namespace: h.namespace,
+					Lease:     lease,
+					Holder:    holder,
+				},
+			}}, nil
+		case err != nil:
+			return nil, errors.Trace(err)
+		case doc.Holder == holder:
+			return nil, jujutxn.ErrNoOperations
+		case holder == "":
+			return []txn.Op{{
+				C:      leaseHoldersC,
+				Id:     docID,
+				Assert: bson.D{{"holder", doc.Holder}},
+				Remove: true,
+			}}, nil
+		}
+		return []txn.Op{{
+			C:      leaseHoldersC,
+			Id:     docID,
+			Assert: bson.D{{"holder", doc.Holder}},
+			Update: bson.D{{"$set", bson.D{{"holder", holder}}}},
+		}}, nil
+	}
+	return errors.Annotatef(h.st.db().Run(buildTxn), "recording holder of lease %q", lease)
+}
+
+// reconcile brings all of the namespace's holder documents into line
+// with the FSM, in case a change was replicated but not recorded.
+func (h *leaseHolders) reconcile() error {
+	coll, closer := h.st.db().GetCollection(leaseHoldersC)
+	defer closer()
+
+	var docs []leaseHolderDoc
+	if err := coll.Find(bson.D{{"namespace", h.namespace}}).All(&docs); err != nil {
+		return errors.Trace(err)
+	}
+	leases := set.NewStrings()
+	for _, doc := range docs {
+		leases.Add(doc.Lease)
+	}
+	for name := range h.entries() {
+		leases.Add(name)
+	}
+	for _, name := range leases.SortedValues() {
+		if err := h.record(name); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// trapdoor returns a lease.Trapdoor that will replace a supplied
+// *[]txn.Op with one that asserts that the holder still holds the
+// named lease.
+func (h *leaseHolders) trapdoor(name, holder string) lease.Trapdoor {
+	op := txn.Op{
+		C:      leaseHoldersC,
+		Id:     leaseHolderDocID(h.namespace, name),
+		Assert: bson.D{{"holder", holder}},
+	}
+	return func(out interface{}) error {
+		outPtr, ok := out.(*[]txn.Op)
+		if !ok {
+			return errors.NotValidf("expected *[]txn.Op; %T", out)
+		}
+		*outPtr = []txn.Op{op}
+		return nil
+	}
+}
+
+func (h *leaseHolders) entries() map[string]raftlease.Entry {
+	return h.st.raftLeases.FSM.Leases(h.st.ModelUUID(), h.namespace)
+}
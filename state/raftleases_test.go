@@ -0,0 +1,190 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/core/raftlease"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type RaftLeasesSuite struct {
+	ConnSuite
+	fsm     *raftlease.FSM
+	raftSt  *state.State
+	claimer leadership.Claimer
+	checker leadership.Checker
+}
+
+var _ = gc.Suite(&RaftLeasesSuite{})
+
+func (s *RaftLeasesSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.fsm = raftlease.NewFSM()
+}
+
+// openRaftState opens a State whose lease managers use the suite's
+// FSM, applying commands to it directly rather than through raft.
+func (s *RaftLeasesSuite) openRaftState(c *gc.C) {
+	st, err := state.Open(state.OpenParams{
+		Clock:              s.Clock,
+		ControllerTag:      s.State.ControllerTag(),
+		ControllerModelTag: s.modelTag,
+		MongoSession:       s.Session,
+		RaftLeases: &state.RaftLeases{
+			FSM:     s.fsm,
+			Applier: fsmApplier{s.fsm},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { st.Close() })
+	s.raftSt = st
+	s.claimer = st.LeadershipClaimer()
+	s.checker = st.LeadershipChecker()
+}
+
+func (s *RaftLeasesSuite) TestClaimRecordsHolder(c *gc.C) {
+	s.openRaftState(c)
+	err := s.claimer.ClaimLeadership("application", "application/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	entries := s.fsm.Leases(s.modelTag.Id(), "application-leadership")
+	c.Check(entries["application"].Holder, gc.Equals, "application/0")
+	c.Check(s.holders(c), jc.DeepEquals, map[string]string{
+		"application": "application/0",
+	})
+
+	leaders, err := s.raftSt.ApplicationLeaders()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(leaders, jc.DeepEquals, map[string]string{
+		"application": "application/0",
+	})
+}
+
+func (s *RaftLeasesSuite) TestTrapdoorAssertsHolder(c *gc.C) {
+	s.openRaftState(c)
+	err := s.claimer.ClaimLeadership("application", "application/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var ops []txn.Op
+	err = s.checker.LeadershipCheck("application", "application/0").Check(&ops)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ops, gc.HasLen, 1)
+	err = state.RunTransaction(s.raftSt, ops)
+	c.Check(err, jc.ErrorIsNil)
+
+	// Once the recorded holder changes, the assertion fails.
+	coll, closer := state.GetRawCollection(s.raftSt, "leaseholders")
+	defer closer()
+	err = coll.Update(
+		bson.D{{"namespace", "application-leadership"}},
+		bson.D{{"$set", bson.D{{"holder", "application/1"}}}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	err = state.RunTransaction(s.raftSt, ops)
+	c.Check(err, gc.Equals, txn.ErrAborted)
+}
+
+func (s *RaftLeasesSuite) TestExpireRemovesHolder(c *gc.C) {
+	s.openRaftState(c)
+	err := s.claimer.ClaimLeadership("application", "application/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	globalClock, err := s.raftSt.GlobalClockUpdater()
+	c.Assert(err, jc.ErrorIsNil)
+	err = globalClock.Advance(time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	s.Clock.Advance(time.Hour)
+
+	unblocked := make(chan error, 1)
+	go func() {
+		unblocked <- s.claimer.BlockUntilLeadershipReleased("application", nil)
+	}()
+	timeout := time.After(coretesting.LongWait)
+	for done := false; !done; {
+		select {
+		case <-timeout:
+			c.Fatalf("never unblocked")
+		case err := <-unblocked:
+			c.Assert(err, jc.ErrorIsNil)
+			done = true
+		case <-s.Clock.Alarms():
+		}
+	}
+
+	c.Check(s.fsm.Leases(s.modelTag.Id(), "application-leadership"), gc.HasLen, 0)
+	c.Check(s.holders(c), gc.HasLen, 0)
+}
+
+func (s *RaftLeasesSuite) TestMigratesMongoLeases(c *gc.C) {
+	err := s.State.LeadershipClaimer().ClaimLeadership("application", "application/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.openRaftState(c)
+	leaders, err := s.raftSt.ApplicationLeaders()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(leaders, jc.DeepEquals, map[string]string{
+		"application": "application/0",
+	})
+	entries := s.fsm.Leases(s.modelTag.Id(), "application-leadership")
+	c.Check(entries["application"].Holder, gc.Equals, "application/0")
+	c.Check(s.holders(c), jc.DeepEquals, map[string]string{
+		"application": "application/0",
+	})
+
+	coll, closer := state.GetRawCollection(s.raftSt, "leases")
+	defer closer()
+	n, err := coll.Find(bson.D{
+		{"model-uuid", s.modelTag.Id()},
+		{"namespace", "application-leadership"},
+	}).Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(n, gc.Equals, 0)
+
+	// The imported lease is held by the same unit, so leadership
+	// claims on its behalf are extensions.
+	err = s.claimer.ClaimLeadership("application", "application/0", time.Minute)
+	c.Check(err, jc.ErrorIsNil)
+	err = s.claimer.ClaimLeadership("application", "application/1", time.Minute)
+	c.Check(err, gc.Equals, leadership.ErrClaimDenied)
+}
+
+// holders returns the recorded holders of the model's leadership
+// leases.
+func (s *RaftLeasesSuite) holders(c *gc.C) map[string]string {
+	coll, closer := state.GetRawCollection(s.raftSt, "leaseholders")
+	defer closer()
+	var docs []struct {
+		Lease  string `bson:"lease"`
+		Holder string `bson:"holder"`
+	}
+	err := coll.Find(bson.D{
+		{"model-uuid", s.modelTag.Id()},
+		{"namespace", "application-leadership"},
+	}).All(&docs)
+	c.Assert(err, jc.ErrorIsNil)
+	holders := make(map[string]string)
+	for _, doc := range docs {
+		holders[doc.Lease] = doc.Holder
+	}
+	return holders
+}
+
+// fsmApplier implements raftlease.Applier by applying commands
+// directly to an FSM, as a single controller's raft worker would.
+type fsmApplier struct {
+	fsm *raftlease.FSM
+}
+
+func (a fsmApplier) Apply(command []byte, _ time.Duration) error {
+	return a.fsm.Apply(command)
+}
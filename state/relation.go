@@ -122,9 +122,14 @@ func (r *Relation) Status() (status.StatusInfo, error) {
 
 // SetStatus sets the status of the relation.
 func (r *Relation) SetStatus(statusInfo status.StatusInfo) error {
+	return setEntityStatus(r, statusInfo)
+}
+
+// statusUpdate is part of the statusUpdater interface.
+func (r *Relation) statusUpdate(statusInfo status.StatusInfo) (Database, setStatusParams, error) {
 	currentStatus, err := r.Status()
 	if err != nil {
-		return errors.Trace(err)
+		return nil, setStatusParams{}, errors.Trace(err)
 	}
 
 	if currentStatus.Status != statusInfo.Status {
@@ -139,24 +144,24 @@ func (r *Relation) SetStatus(statusInfo status.StatusInfo) error {
 			validTransition = currentStatus.Status != status.Broken
 		case status.Error:
 			if statusInfo.Message == "" {
-				return errors.Errorf("cannot set status %q without info", statusInfo.Status)
+				return nil, setStatusParams{}, errors.Errorf("cannot set status %q without info", statusInfo.Status)
 			}
 		default:
-			return errors.NewNotValid(nil, fmt.Sprintf("cannot set invalid status %q", statusInfo.Status))
+			return nil, setStatusParams{}, errors.NewNotValid(nil, fmt.Sprintf("cannot set invalid status %q", statusInfo.Status))
 		}
 		if !validTransition {
-			return errors.NewNotValid(nil, fmt.Sprintf(
+			return nil, setStatusParams{}, errors.NewNotValid(nil, fmt.Sprintf(
 				"cannot set status %q when relation has status %q", statusInfo.Status, currentStatus.Status))
 		}
 	}
-	return setStatus(r.st.db(), setStatusParams{
+	return r.st.db(), setStatusParams{
 		badge:     "relation",
 		globalKey: r.globalScope(),
 		status:    statusInfo.Status,
 		message:   statusInfo.Message,
 		rawData:   statusInfo.Data,
 		updated:   timeOrNow(statusInfo.Since, r.st.clock()),
-	})
+	}, nil
 }
 
 // SetSuspended sets whether the relation is suspended.
@@ -531,6 +536,48 @@ func (r *Relation) IsCrossModel() (bool, error) {
 	return false, nil
 }
 
+// BrokenBarrierReached returns whether units of the named application
+// may run the relation-broken hook for the relation. This is always
+// the case unless the application has a relation-broken barrier, in
+// which case it is only reached once every unit of the application in
+// the relation scope has recorded that it has departed.
+// See Application.SetRelationBrokenBarrier and RelationUnit.SetDeparted.
+func (r *Relation) BrokenBarrierReached(appName string) (bool, error) {
+	app, err := r.st.Application(appName)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if !app.RelationBrokenBarrier() {
+		return true, nil
+	}
+	relationScopes, closer := r.st.db().GetCollection(relationScopesC)
+	defer closer()
+
+	var docs []relationScopeDoc
+	sel := bson.D{{"key", bson.D{{"$regex", "^" + r.globalScope() + "#"}}}}
+	if err := relationScopes.Find(sel).All(&docs); err != nil {
+		return false, errors.Annotatef(err, "cannot read scope of relation %q", r)
+	}
+	for _, doc := range docs {
+		unitAppName, err := names.UnitApplication(doc.unitName())
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		if unitAppName == appName && !doc.Departed {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// WatchBrokenBarrier returns a watcher that notifies of changes that
+// may affect whether the relation-broken barrier of the named
+// application has been reached for the relation.
+// See BrokenBarrierReached.
+func (r *Relation) WatchBrokenBarrier(appName string) NotifyWatcher {
+	return newRelationBrokenBarrierWatcher(r.st, r.globalScope()+"#", appName)
+}
+
 func (r *Relation) unit(
 	unitName string,
 	principal string,
@@ -271,6 +271,36 @@ func (ru *RelationUnit) PrepareLeaveScope() error {
 	return ru.st.db().RunTransaction(ops)
 }
 
+// SetDeparted records that the unit has run the relation-departed hooks
+// for all of its counterparts in the relation, which must be dying or
+// suspended, and is waiting to run relation-broken. It is not an error
+// to call SetDeparted for a unit that is not in the relation scope.
+// See Relation.BrokenBarrierReached.
+func (ru *RelationUnit) SetDeparted() error {
+	relationScopes, closer := ru.st.db().GetCollection(relationScopesC)
+	defer closer()
+
+	key := ru.key()
+	if count, err := relationScopes.FindId(key).Count(); err != nil {
+		return err
+	} else if count == 0 {
+		return nil
+	}
+	ops := []txn.Op{{
+		C:      relationScopesC,
+		Id:     key,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{{"departed", true}}}},
+	}}
+	if err := ru.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		// The unit left the scope in the meantime.
+		return nil
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot record departure of unit %q from relation %q", ru.unitName, ru.relation)
+	}
+	return nil
+}
+
 // LeaveScope signals that the unit has left its scope in the relation.
 // After the unit has left its relation scope, it is no longer a member
 // of the relation; if the relation is dying when its last member unit
@@ -605,6 +635,11 @@ type relationScopeDoc struct {
 	Key       string `bson:"key"`
 	ModelUUID string `bson:"model-uuid"`
 	Departing bool   `bson:"departing"`
+
+	// Departed records that the unit has run the relation-departed
+	// hooks for all of its counterparts in a dying or suspended
+	// relation, and is ready to run relation-broken.
+	Departed bool `bson:"departed,omitempty"`
 }
 
 func (d *relationScopeDoc) unitName() string {
@@ -788,6 +788,104 @@ func (s *RelationUnitSuite) testPrepareLeaveScope(c *gc.C, rel *state.Relation,
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *RelationUnitSuite) TestBrokenBarrierReachedWithoutBarrier(c *gc.C) {
+	prr := newProReqRelation(c, &s.ConnSuite, charm.ScopeGlobal)
+	err := prr.pru0.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	reached, err := prr.rel.BrokenBarrierReached("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reached, jc.IsTrue)
+}
+
+func (s *RelationUnitSuite) TestBrokenBarrierReached(c *gc.C) {
+	prr := newProReqRelation(c, &s.ConnSuite, charm.ScopeGlobal)
+	err := prr.papp.SetRelationBrokenBarrier(true)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, ru := range []*state.RelationUnit{prr.pru0, prr.pru1, prr.rru0} {
+		err := ru.EnterScope(nil)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	assertReached := func(expect bool) {
+		reached, err := prr.rel.BrokenBarrierReached("mysql")
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(reached, gc.Equals, expect)
+	}
+	assertReached(false)
+
+	err = prr.pru0.SetDeparted()
+	c.Assert(err, jc.ErrorIsNil)
+	assertReached(false)
+
+	// Only units of the application with the barrier are counted.
+	err = prr.pru1.SetDeparted()
+	c.Assert(err, jc.ErrorIsNil)
+	assertReached(true)
+
+	// The barrier can be lifted by the application.
+	err = prr.papp.SetRelationBrokenBarrier(false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(prr.papp.RelationBrokenBarrier(), jc.IsFalse)
+	reached, err := prr.rel.BrokenBarrierReached("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reached, jc.IsTrue)
+}
+
+func (s *RelationUnitSuite) TestBrokenBarrierIgnoresUnitsLeftScope(c *gc.C) {
+	prr := newProReqRelation(c, &s.ConnSuite, charm.ScopeGlobal)
+	err := prr.papp.SetRelationBrokenBarrier(true)
+	c.Assert(err, jc.ErrorIsNil)
+	err = prr.pru0.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = prr.pru1.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = prr.pru0.SetDeparted()
+	c.Assert(err, jc.ErrorIsNil)
+	err = prr.pru1.LeaveScope()
+	c.Assert(err, jc.ErrorIsNil)
+	reached, err := prr.rel.BrokenBarrierReached("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reached, jc.IsTrue)
+
+	// Recording departure out of scope is not an error.
+	err = prr.pru1.SetDeparted()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *RelationUnitSuite) TestWatchBrokenBarrier(c *gc.C) {
+	prr := newProReqRelation(c, &s.ConnSuite, charm.ScopeGlobal)
+	w := prr.rel.WatchBrokenBarrier("mysql")
+	defer testing.AssertStop(c, w)
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := prr.pru0.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = prr.pru0.SetDeparted()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = prr.papp.SetRelationBrokenBarrier(true)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Adding another relation changes the application, but scope
+	// changes in that relation are not watched.
+	other := s.AddTestingApplication(c, "wordpress2", s.AddTestingCharm(c, "wordpress"))
+	eps, err := s.State.InferEndpoints("mysql", "wordpress2")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+	_, ru := addRU(c, other, rel, nil)
+	err = ru.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
 func (s *RelationUnitSuite) assertScopeChange(c *gc.C, w *state.RelationScopeWatcher, entered, left []string) {
 	s.State.StartSync()
 	select {
@@ -1534,6 +1632,31 @@ func (s *WatchScopeSuite) TestProviderRequirerGlobal(c *gc.C) {
 	// Cleanup handled by defers as before.
 }
 
+func (s *WatchScopeSuite) TestDebounced(c *gc.C) {
+	prr := newProReqRelation(c, &s.ConnSuite, charm.ScopeGlobal)
+	err := prr.rru0.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The initial event is not delayed.
+	w := prr.pru0.WatchDebounced(time.Minute)
+	defer testing.AssertStop(c, w)
+	wc := testing.NewRelationUnitsWatcherC(c, s.State, w)
+	wc.AssertChange([]string{"wordpress/0"}, nil)
+	wc.AssertNoChange()
+
+	// Later changes are held until the period has passed, and are
+	// then notified together.
+	changeSettings(c, prr.rru0)
+	wc.AssertNoChange()
+	err = prr.rru1.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	changeSettings(c, prr.rru0)
+	wc.AssertNoChange()
+	s.Clock.Advance(time.Minute)
+	wc.AssertChange([]string{"wordpress/0", "wordpress/1"}, nil)
+	wc.AssertNoChange()
+}
+
 func (s *WatchScopeSuite) TestProviderRequirerContainer(c *gc.C) {
 	// Create a pair of services and a relation between them.
 	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
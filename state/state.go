@@ -95,6 +95,10 @@ type State struct {
 	// relatively-skewed.
 	leaseClientId string
 
+	// raftLeases, if non-nil, holds the replicated lease state used
+	// in place of the leases collection.
+	raftLeases *RaftLeases
+
 	// workers is responsible for keeping the various sub-workers
 	// available by starting new ones as they fail. It doesn't do
 	// that yet, but having a type that collects them together is the
@@ -422,9 +426,8 @@ func (st *State) getSingularLeaseClient() (lease.Client, error) {
 }
 
 func (st *State) getLeaseClient(namespace string) (lease.Client, error) {
-	globalClock, err := st.globalClockReader()
-	if err != nil {
-		return nil, errors.Annotate(err, "getting global clock for lease client")
+	if st.raftLeases != nil {
+		return st.getRaftLeaseClient(namespace)
 	}
 
 	// NOTE(axw) due to the lease managers being embedded in State,
@@ -435,6 +438,14 @@ func (st *State) getLeaseClient(namespace string) (lease.Client, error) {
 	if err := migrateModelLeasesToGlobalTime(st); err != nil {
 		return nil, errors.Trace(err)
 	}
+	return st.getMongoLeaseClient(namespace)
+}
+
+func (st *State) getMongoLeaseClient(namespace string) (lease.Client, error) {
+	globalClock, err := st.globalClockReader()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting global clock for lease client")
+	}
 
 	client, err := statelease.NewClient(statelease.ClientConfig{
 		Id:          st.leaseClientId,
@@ -1490,14 +1501,41 @@ func (st *State) parsePlacement(placement *instance.Placement) (*placementData,
 	}
 	switch placement.Scope {
 	case st.ModelUUID():
+		if err := st.foreignMachineError(placement); err != nil {
+			return nil, errors.Trace(err)
+		}
 		return &placementData{directive: placement.Directive}, nil
 	case instance.MachineScope:
 		return &placementData{machineId: placement.Directive}, nil
 	default:
+		if err := st.foreignMachineError(placement); err != nil {
+			return nil, errors.Trace(err)
+		}
 		return nil, errors.Errorf("placement scope: invalid model UUID %q", placement.Scope)
 	}
 }
 
+// CheckPlacement returns an error if units of the named application
+// cannot be placed as directed: if the placement is not valid, or
+// names a machine that does not exist, or one of another model, or one
+// that is saturated by the units already on it. Placement on a machine
+// of another model, or on a saturated machine, is refused with an
+// *ErrPlacementConflict.
+func (st *State) CheckPlacement(applicationName string, placement *instance.Placement) error {
+	data, err := st.parsePlacement(placement)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if data.machineId == "" {
+		return nil
+	}
+	m, err := st.Machine(data.machineId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(st.checkCanHostUnits(m, applicationName))
+}
+
 // addMachineWithPlacement finds a machine that matches the given placement directive for the given unit.
 func (st *State) addMachineWithPlacement(unit *Unit, placement *instance.Placement) (*Machine, error) {
 	unitCons, err := unit.Constraints()
@@ -1526,6 +1564,14 @@ func (st *State) addMachineWithPlacement(unit *Unit, placement *instance.Placeme
 			Constraints: *unitCons,
 		}
 		if data.machineId != "" {
+			// A missing parent is reported when adding the container.
+			parent, err := st.Machine(data.machineId)
+			if err == nil {
+				err = st.checkCanHostUnits(parent, unit.ApplicationName())
+			}
+			if err != nil && !errors.IsNotFound(err) {
+				return nil, errors.Trace(err)
+			}
 			return st.AddMachineInsideMachine(template, data.machineId, data.containerType)
 		}
 		return st.AddMachineInsideNewMachine(template, template, data.containerType)
@@ -1541,7 +1587,14 @@ func (st *State) addMachineWithPlacement(unit *Unit, placement *instance.Placeme
 		return st.AddOneMachine(template)
 	default:
 		// Otherwise use an existing machine.
-		return st.Machine(data.machineId)
+		m, err := st.Machine(data.machineId)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := st.checkCanHostUnits(m, unit.ApplicationName()); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return m, nil
 	}
 }
 
@@ -1868,6 +1921,13 @@ func (st *State) AddRelation(eps ...Endpoint) (r *Relation, err error) {
 		return ops, nil
 	}
 	if err = st.db().Run(buildTxn); err == nil {
+		// Record the relation's creation in its status history, so
+		// that the model's status can be reconstructed from history.
+		probablyUpdateStatusHistory(st.db(), relationGlobalScope(doc.Id), statusDoc{
+			Status:    status.Joining,
+			ModelUUID: st.ModelUUID(),
+			Updated:   now.UnixNano(),
+		})
 		return &Relation{st, *doc}, nil
 	}
 	return nil, errors.Trace(err)
@@ -2328,8 +2388,12 @@ func (st *State) SetClockForTesting(clock clock.Clock) error {
 }
 
 // GlobalClockUpdater returns a new globalclock.Updater using the
-// State's *mgo.Session.
+// State's *mgo.Session or, if the State was opened with RaftLeases,
+// one that advances the global time of the raft lease FSM.
 func (st *State) GlobalClockUpdater() (coreglobalclock.Updater, error) {
+	if st.raftLeases != nil {
+		return st.newRaftLeaseStore(singularControllerNamespace)
+	}
 	return globalclock.NewUpdater(globalclock.UpdaterConfig{
 		Config: globalclock.Config{
 			Mongo:      &environMongo{st},
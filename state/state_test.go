@@ -1026,6 +1026,30 @@ func (s *StateSuite) TestAddMachinePlacementIgnoresModelConstraints(c *gc.C) {
 	c.Assert(mcons, gc.DeepEquals, expectedCons)
 }
 
+func (s *StateSuite) TestAddMachineCloudInitUserData(c *gc.C) {
+	userData := "packages: [htop]\n"
+	m, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series:            "quantal",
+		Jobs:              []state.MachineJob{state.JobHostUnits},
+		CloudInitUserData: userData,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.CloudInitUserData(), gc.Equals, userData)
+
+	m, err = s.State.Machine(m.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.CloudInitUserData(), gc.Equals, userData)
+}
+
+func (s *StateSuite) TestAddMachineInvalidCloudInitUserData(c *gc.C) {
+	_, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series:            "quantal",
+		Jobs:              []state.MachineJob{state.JobHostUnits},
+		CloudInitUserData: "bootcmd: [reboot]\n",
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add a new machine: invalid cloud-init user data: cloud-init user data key "bootcmd" not supported`)
+}
+
 func (s *StateSuite) TestAddMachineWithVolumes(c *gc.C) {
 	pm := poolmanager.New(state.NewStateSettings(s.State), provider.CommonStorageProviders())
 	_, err := pm.Create("loop-pool", provider.LoopProviderType, map[string]interface{}{})
@@ -4032,6 +4056,32 @@ func (s *StateSuite) TestEnableHALessPlacementThanNewCount(c *gc.C) {
 	s.assertControllerInfo(c, []string{"0", "1", "2"}, []string{"0", "1", "2"}, []string{"p1", "p2"})
 }
 
+func (s *StateSuite) TestEnableHAWithMachines(c *gc.C) {
+	common := constraints.MustParse("mem=4G")
+	zoned := constraints.MustParse("mem=8G")
+	changes, err := s.State.EnableHAWithMachines(3, common, "quantal", []state.ControllerMachineParams{{
+		Placement:   "zone=az1",
+		Constraints: zoned,
+	}, {
+		Placement: "p2",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes.Added, gc.DeepEquals, []string{"0", "1", "2"})
+	s.assertControllerInfo(c, []string{"0", "1", "2"}, []string{"0", "1", "2"}, []string{"zone=az1", "p2"})
+
+	for id, expect := range map[string]constraints.Value{
+		"0": zoned,
+		"1": {},
+		"2": common,
+	} {
+		m, err := s.State.Machine(id)
+		c.Assert(err, jc.ErrorIsNil)
+		gotCons, err := m.Constraints()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(gotCons, gc.DeepEquals, expect, gc.Commentf("machine %s", id))
+	}
+}
+
 func (s *StateSuite) TestEnableHADemotesUnavailableMachines(c *gc.C) {
 	changes, err := s.State.EnableHA(3, constraints.Value{}, "quantal", nil)
 	c.Assert(err, jc.ErrorIsNil)
@@ -51,6 +51,46 @@ func (m *Model) LoadModelStatus() (*ModelStatus, error) {
 	return result, nil
 }
 
+// LoadModelStatusAt reconstructs, from the status history, the status
+// documents for the model as they were at the given time. Entities
+// without any status recorded at or before that time, whether because
+// they did not yet exist or because their history has been pruned,
+// have no status in the result; nor do entities that have since been
+// removed, as their history is erased along with them.
+func (m *Model) LoadModelStatusAt(t time.Time) (*ModelStatus, error) {
+	history, closer := m.st.db().GetCollection(statusesHistoryC)
+	defer closer()
+
+	result := &ModelStatus{
+		model: m,
+		docs:  make(map[string]statusDocWithID),
+	}
+	// Sorting by key and then by descending time means the first
+	// document seen for each key is its status at the given time.
+	iter := history.Find(bson.D{
+		{"updated", bson.D{{"$lte", t.UnixNano()}}},
+	}).Sort(globalKeyField, "-updated").Iter()
+	var doc historicalStatusDoc
+	for iter.Next(&doc) {
+		if _, found := result.docs[doc.GlobalKey]; !found {
+			result.docs[doc.GlobalKey] = statusDocWithID{
+				ID:         m.st.docID(doc.GlobalKey),
+				ModelUUID:  doc.ModelUUID,
+				Status:     doc.Status,
+				StatusInfo: doc.StatusInfo,
+				StatusData: doc.StatusData,
+				Updated:    doc.Updated,
+				NeverSet:   doc.NeverSet,
+			}
+		}
+		doc = historicalStatusDoc{}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Annotate(err, "failed to read status history")
+	}
+	return result, nil
+}
+
 func (m *ModelStatus) getDoc(key, badge string) (statusDocWithID, error) {
 	doc, found := m.docs[key]
 	if !found {
@@ -109,6 +149,11 @@ func (m *ModelStatus) MachineInstance(machineID string) (status.StatusInfo, erro
 	return m.getStatus(machineGlobalInstanceKey(machineID), "instance")
 }
 
+// Relation returns the status of the relation with the given id.
+func (m *ModelStatus) Relation(id int) (status.StatusInfo, error) {
+	return m.getStatus(relationGlobalScope(id), "relation")
+}
+
 // FullUnitWorkloadVersion returns the full status info for the workload
 // version of a unit. This is used for selecting the workload version for
 // an application.
@@ -255,6 +300,9 @@ type setStatusParams struct {
 
 	// udpated, the time the status was set.
 	updated *time.Time
+
+	// onSet, if not nil, is called once the status has been set.
+	onSet func()
 }
 
 func timeOrNow(t *time.Time, clock clock.Clock) *time.Time {
@@ -266,32 +314,159 @@ func timeOrNow(t *time.Time, clock clock.Clock) *time.Time {
 }
 
 // setStatus inteprets the supplied params as documented on the type.
-func setStatus(db Database, params setStatusParams) (err error) {
-	defer errors.DeferredAnnotatef(&err, "cannot set status")
-	if params.updated == nil {
-		return errors.NotValidf("nil updated time")
-	}
+func setStatus(db Database, params setStatusParams) error {
+	return setStatuses(db, []setStatusParams{params})[0]
+}
 
-	doc := statusDoc{
-		Status:     params.status,
-		StatusInfo: params.message,
-		StatusData: utils.EscapeKeys(params.rawData),
-		Updated:    params.updated.UnixNano(),
+// setStatuses sets each of the supplied statuses, writing all of them
+// in a single transaction, and returns the error, if any, for each. A
+// status that cannot be set, because its entity has been removed or
+// its leadership token is no longer valid, does not prevent the others
+// from being set. Where the same global key is given more than once,
+// only its last status is set, and the others share its result.
+func setStatuses(db Database, params []setStatusParams) []error {
+	errs := make([]error, len(params))
+	docs := make([]statusDoc, len(params))
+	last := make(map[string]int)
+	for i, p := range params {
+		if p.updated == nil {
+			errs[i] = errors.Annotate(errors.NotValidf("nil updated time"), "cannot set status")
+			continue
+		}
+		docs[i] = statusDoc{
+			Status:     p.status,
+			StatusInfo: p.message,
+			StatusData: utils.EscapeKeys(p.rawData),
+			Updated:    p.updated.UnixNano(),
+		}
+		last[p.globalKey] = i
+	}
+	var pending []int
+	for i, p := range params {
+		if errs[i] == nil && last[p.globalKey] == i {
+			probablyUpdateStatusHistory(db, p.globalKey, docs[i])
+			pending = append(pending, i)
+		}
 	}
-	probablyUpdateStatusHistory(db, params.globalKey, doc)
 
-	// Set the authoritative status document, or fail trying.
-	var buildTxn jujutxn.TransactionSource = func(int) ([]txn.Op, error) {
-		return statusSetOps(db, doc, params.globalKey)
+	// Set the authoritative status documents, or fail trying.
+	var written []int
+	buildTxn := func(int) ([]txn.Op, error) {
+		written = nil
+		var ops []txn.Op
+		for _, i := range pending {
+			p := params[i]
+			var prereqs []txn.Op
+			if p.token != nil {
+				if err := p.token.Check(&prereqs); err != nil {
+					errs[i] = errors.Annotatef(err, "cannot set status: prerequisites failed")
+					continue
+				}
+			}
+			setOps, err := statusSetOps(db, docs[i], p.globalKey)
+			if errors.Cause(err) == mgo.ErrNotFound {
+				errs[i] = errors.Annotate(errors.NotFoundf(p.badge), "cannot set status")
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			errs[i] = nil
+			ops = append(ops, prereqs...)
+			ops = append(ops, setOps...)
+			written = append(written, i)
+		}
+		if len(ops) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return ops, nil
 	}
-	if params.token != nil {
-		buildTxn = buildTxnWithLeadership(buildTxn, params.token)
+	if err := db.Run(buildTxn); err != nil {
+		for _, i := range pending {
+			if errs[i] != nil {
+				continue
+			}
+			if errors.Cause(err) == mgo.ErrNotFound {
+				errs[i] = errors.Annotate(errors.NotFoundf(params[i].badge), "cannot set status")
+			} else {
+				errs[i] = errors.Annotate(err, "cannot set status")
+			}
+		}
+	} else {
+		for _, i := range written {
+			if params[i].onSet != nil {
+				params[i].onSet()
+			}
+		}
 	}
-	err = db.Run(buildTxn)
-	if cause := errors.Cause(err); cause == mgo.ErrNotFound {
-		return errors.NotFoundf(params.badge)
+	for i, p := range params {
+		if errs[i] == nil && last[p.globalKey] != i {
+			errs[i] = errs[last[p.globalKey]]
+		}
 	}
-	return errors.Trace(err)
+	return errs
+}
+
+// statusUpdater is implemented by entities whose status may be set by
+// SetStatuses.
+type statusUpdater interface {
+	// statusUpdate checks that the given status may be set, and
+	// returns the database and parameters with which to set it.
+	statusUpdate(status.StatusInfo) (Database, setStatusParams, error)
+}
+
+// setEntityStatus sets the status of the given entity.
+func setEntityStatus(entity statusUpdater, statusInfo status.StatusInfo) error {
+	db, params, err := entity.statusUpdate(statusInfo)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return setStatus(db, params)
+}
+
+// StatusUpdate holds a status to set on an entity.
+type StatusUpdate struct {
+	Entity status.StatusSetter
+	Info   status.StatusInfo
+}
+
+// SetStatuses sets the status of each of the given entities, and
+// returns the error, if any, for each. The statuses of the machines,
+// units, unit agents, applications, relations and models of a model
+// are written in a single transaction; the statuses of other entities
+// are set one at a time.
+func SetStatuses(updates []StatusUpdate) []error {
+	errs := make([]error, len(updates))
+	params := make([]setStatusParams, len(updates))
+	batches := make(map[Database][]int)
+	var dbs []Database
+	for i, update := range updates {
+		updater, ok := update.Entity.(statusUpdater)
+		if !ok {
+			errs[i] = update.Entity.SetStatus(update.Info)
+			continue
+		}
+		db, p, err := updater.statusUpdate(update.Info)
+		if err != nil {
+			errs[i] = errors.Trace(err)
+			continue
+		}
+		if _, ok := batches[db]; !ok {
+			dbs = append(dbs, db)
+		}
+		params[i] = p
+		batches[db] = append(batches[db], i)
+	}
+	for _, db := range dbs {
+		indices := batches[db]
+		batch := make([]setStatusParams, len(indices))
+		for j, i := range indices {
+			batch[j] = params[i]
+		}
+		for j, err := range setStatuses(db, batch) {
+			errs[indices[j]] = err
+		}
+	}
+	return errs
 }
 
 func statusSetOps(db Database, doc statusDoc, globalKey string) ([]txn.Op, error) {
@@ -344,6 +519,12 @@ type historicalStatusDoc struct {
 	// Updated might not be present on statuses copied by old
 	// versions of juju from yet older versions of juju.
 	Updated int64 `bson:"updated"`
+
+	// NeverSet records the NeverSet value of the status document,
+	// so that application status can be reconstructed from the
+	// history. It is not present on history recorded by older
+	// versions of juju.
+	NeverSet bool `bson:"neverset,omitempty"`
 }
 
 func probablyUpdateStatusHistory(db Database, globalKey string, doc statusDoc) {
@@ -353,6 +534,7 @@ func probablyUpdateStatusHistory(db Database, globalKey string, doc statusDoc) {
 		StatusData: doc.StatusData, // coming from a statusDoc, already escaped
 		Updated:    doc.Updated,
 		GlobalKey:  globalKey,
+		NeverSet:   doc.NeverSet,
 	}
 	history, closer := db.GetCollection(statusesHistoryC)
 	defer closer()
@@ -383,6 +565,7 @@ func probablyUpdateStatusHistory(db Database, globalKey string, doc statusDoc) {
 		// we rarely need to drop down into the reflect library.
 		if current.Status == doc.Status &&
 			current.StatusInfo == doc.StatusInfo &&
+			current.NeverSet == doc.NeverSet &&
 			dataSame(current.StatusData, doc.StatusData) {
 			return
 		}
@@ -0,0 +1,106 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/testing"
+)
+
+type StatusBatchSuite struct {
+	ConnSuite
+	machine *state.Machine
+	unit    *state.Unit
+}
+
+var _ = gc.Suite(&StatusBatchSuite{})
+
+func (s *StatusBatchSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.machine = s.Factory.MakeMachine(c, nil)
+	s.unit = s.Factory.MakeUnit(c, nil)
+}
+
+func (s *StatusBatchSuite) statusInfo(value status.Status, message string) status.StatusInfo {
+	now := testing.ZeroTime()
+	return status.StatusInfo{
+		Status:  value,
+		Message: message,
+		Since:   &now,
+	}
+}
+
+func (s *StatusBatchSuite) checkStatus(c *gc.C, entity status.StatusGetter, value status.Status, message string) {
+	statusInfo, err := entity.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(statusInfo.Status, gc.Equals, value)
+	c.Check(statusInfo.Message, gc.Equals, message)
+}
+
+func (s *StatusBatchSuite) TestSetStatusesSingleTransaction(c *gc.C) {
+	txns := 0
+	count := func() { txns++ }
+	// Hooks are not checked: the second should not be needed.
+	state.SetBeforeHooks(c, s.State, count, count)
+
+	errs := state.SetStatuses([]state.StatusUpdate{
+		{Entity: s.machine, Info: s.statusInfo(status.Started, "machine")},
+		{Entity: s.unit, Info: s.statusInfo(status.Active, "unit")},
+		{Entity: s.unit.Agent(), Info: s.statusInfo(status.Idle, "agent")},
+	})
+	c.Assert(errs, gc.HasLen, 3)
+	for _, err := range errs {
+		c.Check(err, jc.ErrorIsNil)
+	}
+	c.Check(txns, gc.Equals, 1)
+
+	s.checkStatus(c, s.machine, status.Started, "machine")
+	s.checkStatus(c, s.unit, status.Active, "unit")
+	s.checkStatus(c, s.unit.Agent(), status.Idle, "agent")
+}
+
+func (s *StatusBatchSuite) TestSetStatusesInvalidStatus(c *gc.C) {
+	errs := state.SetStatuses([]state.StatusUpdate{
+		{Entity: s.machine, Info: s.statusInfo(status.Status("vliegkat"), "machine")},
+		{Entity: s.unit, Info: s.statusInfo(status.Active, "unit")},
+	})
+	c.Assert(errs, gc.HasLen, 2)
+	c.Check(errs[0], gc.ErrorMatches, `cannot set invalid status "vliegkat"`)
+	c.Check(errs[1], jc.ErrorIsNil)
+
+	s.checkStatus(c, s.machine, status.Pending, "")
+	s.checkStatus(c, s.unit, status.Active, "unit")
+}
+
+func (s *StatusBatchSuite) TestSetStatusesRemovedEntity(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	err := unit.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	errs := state.SetStatuses([]state.StatusUpdate{
+		{Entity: unit, Info: s.statusInfo(status.Active, "gone")},
+		{Entity: s.unit, Info: s.statusInfo(status.Active, "unit")},
+	})
+	c.Assert(errs, gc.HasLen, 2)
+	c.Check(errs[0], gc.ErrorMatches, `cannot set status: unit not found`)
+	c.Check(errs[1], jc.ErrorIsNil)
+
+	s.checkStatus(c, s.unit, status.Active, "unit")
+}
+
+func (s *StatusBatchSuite) TestSetStatusesSameEntity(c *gc.C) {
+	errs := state.SetStatuses([]state.StatusUpdate{
+		{Entity: s.unit, Info: s.statusInfo(status.Maintenance, "first")},
+		{Entity: s.unit, Info: s.statusInfo(status.Active, "last")},
+	})
+	c.Assert(errs, gc.HasLen, 2)
+	c.Check(errs[0], jc.ErrorIsNil)
+	c.Check(errs[1], jc.ErrorIsNil)
+
+	s.checkStatus(c, s.unit, status.Active, "last")
+}
@@ -4,6 +4,8 @@
 package state_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -201,3 +203,27 @@ func (s *FilesystemStatusSuite) TestSetStatusPendingProvisioned(c *gc.C) {
 	err = s.filesystem.SetStatus(sInfo)
 	c.Check(err, gc.ErrorMatches, `cannot set status "pending"`)
 }
+
+func (s *FilesystemStatusSuite) TestStatusHistory(c *gc.C) {
+	now := testing.ZeroTime()
+	later := now.Add(time.Minute)
+	err := s.filesystem.SetStatus(status.StatusInfo{
+		Status:  status.Attaching,
+		Message: "provider says no",
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.filesystem.SetStatus(status.StatusInfo{
+		Status: status.Attached,
+		Since:  &later,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.IAASModel.FilesystemStatusHistory(s.filesystem.FilesystemTag(), status.StatusHistoryFilter{Size: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Check(history[0].Status, gc.Equals, status.Attached)
+	c.Check(history[0].Message, gc.Equals, "")
+	c.Check(history[1].Status, gc.Equals, status.Attaching)
+	c.Check(history[1].Message, gc.Equals, "provider says no")
+}
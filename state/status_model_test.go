@@ -4,6 +4,9 @@
 package state_test
 
 import (
+	"time"
+
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -286,3 +289,89 @@ func (s *ModelStatusSuite) TestApplicationStatusWeirdness(c *gc.C) {
 	c.Check(msStatus.Status, gc.Equals, status.Waiting)
 	c.Check(msStatus, jc.DeepEquals, aStatus)
 }
+
+func (s *ModelStatusSuite) TestLoadModelStatusAt(c *gc.C) {
+	machine := s.factory.MakeMachine(c, nil)
+	unit := s.factory.MakeUnit(c, nil)
+
+	// Status history is recorded at the time given, so set statuses
+	// after the entities' creation.
+	base := time.Now().Add(time.Hour)
+	at := func(d time.Duration) *time.Time {
+		t := base.Add(d)
+		return &t
+	}
+	err := machine.SetStatus(status.StatusInfo{Status: status.Started, Since: at(0)})
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetStatus(status.StatusInfo{Status: status.Down, Message: "gone", Since: at(2 * time.Minute)})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetAgentStatus(status.StatusInfo{Status: status.Executing, Message: "running install hook", Since: at(0)})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetAgentStatus(status.StatusInfo{Status: status.Idle, Since: at(2 * time.Minute)})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ms, err := s.model.LoadModelStatusAt(*at(time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+	mAgent, err := ms.MachineAgent(machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mAgent.Status, gc.Equals, status.Started)
+	uAgent, err := ms.UnitAgent(unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(uAgent.Status, gc.Equals, status.Executing)
+	c.Check(uAgent.Message, gc.Equals, "running install hook")
+	c.Check(uAgent.Since.Equal(*at(0)), jc.IsTrue)
+
+	ms, err = s.model.LoadModelStatusAt(*at(3 * time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+	mAgent, err = ms.MachineAgent(machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mAgent.Status, gc.Equals, status.Down)
+	c.Check(mAgent.Message, gc.Equals, "gone")
+	uAgent, err = ms.UnitAgent(unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(uAgent.Status, gc.Equals, status.Idle)
+}
+
+func (s *ModelStatusSuite) TestLoadModelStatusAtBeforeCreation(c *gc.C) {
+	machine := s.factory.MakeMachine(c, nil)
+
+	ms, err := s.model.LoadModelStatusAt(time.Now().Add(-time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = ms.MachineAgent(machine.Id())
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	_, err = ms.Model()
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ModelStatusSuite) TestLoadModelStatusAtApplicationNeverSet(c *gc.C) {
+	unit := s.factory.MakeUnit(c, nil)
+	app, err := unit.Application()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetStatus(status.StatusInfo{Status: status.Blocked, Message: "need db"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ms, err := s.model.LoadModelStatusAt(time.Now().Add(time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The application's status is still derived from its units.
+	msStatus, err := ms.Application(app.Name(), []string{unit.Name()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(msStatus.Status, gc.Equals, status.Blocked)
+	c.Check(msStatus.Message, gc.Equals, "need db")
+}
+
+func (s *ModelStatusSuite) TestLoadModelStatusAtRelation(c *gc.C) {
+	before := time.Now().Add(-time.Hour)
+	rel := s.factory.MakeRelation(c, nil)
+
+	ms, err := s.model.LoadModelStatusAt(time.Now().Add(time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	rStatus, err := ms.Relation(rel.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(rStatus.Status, gc.Equals, status.Joining)
+
+	ms, err = s.model.LoadModelStatusAt(before)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = ms.Relation(rel.Id())
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
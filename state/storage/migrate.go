@@ -0,0 +1,161 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"crypto/sha512"
+	"encoding/hex"
+	"io"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/blobstore.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// BlobstoreDatabases holds the names of the databases whose blobstores
+// are held in the object store, when one is configured.
+var BlobstoreDatabases = []string{blobstoreDB, "backups"}
+
+// MigrateResult describes the outcome of MigrateGridFS.
+type MigrateResult struct {
+	// Migrated holds the number of blobs copied to the object store.
+	Migrated int
+
+	// Skipped holds the number of blobs that were already in the
+	// object store.
+	Skipped int
+}
+
+// MigrateGridFS copies the blobs held in GridFS in the named database
+// to the object store, checking that each copy matches the original,
+// and records their hashes for VerifyObjectStore. If removeSource is
+// true, the blobs are then removed from GridFS. Migration can be
+// restarted safely if it is interrupted.
+func MigrateGridFS(session *mgo.Session, dbName string, store ObjectStore, removeSource bool) (MigrateResult, error) {
+	var result MigrateResult
+	db := session.DB(dbName)
+	gridFS := db.GridFS(dbName)
+	rs := &objectResourceStorage{
+		store:   store,
+		dbName:  dbName,
+		objects: db.C(objectsC),
+		gridFS:  blobstore.NewGridFS(dbName, dbName, session),
+	}
+
+	var doc struct {
+		Filename string `bson:"filename"`
+	}
+	iter := gridFS.Find(nil).Select(bson.M{"filename": 1}).Iter()
+	for iter.Next(&doc) {
+		path := doc.Filename
+		n, err := rs.objects.FindId(path).Count()
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		if n > 0 {
+			result.Skipped++
+		} else {
+			if err := migrateFile(gridFS, rs, path); err != nil {
+				return result, errors.Annotatef(err, "cannot migrate %q", path)
+			}
+			result.Migrated++
+		}
+		if removeSource {
+			if err := removeGridFSFile(db, dbName, path); err != nil {
+				return result, errors.Trace(err)
+			}
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// migrateFile copies the GridFS file at path to the object store, and
+// checks the copy.
+func migrateFile(gridFS *mgo.GridFS, rs *objectResourceStorage, path string) error {
+	f, err := gridFS.Open(path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	checksum, err := rs.Put(path, f, f.Size())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if checksum != f.MD5() {
+		return errors.Errorf("checksum %q does not match GridFS checksum %q", checksum, f.MD5())
+	}
+	var object objectDoc
+	if err := rs.objects.FindId(path).One(&object); err != nil {
+		return errors.Trace(err)
+	}
+	ok, err := verifyObject(rs.store, objectName(rs.dbName, path), object)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return errors.New("object store copy is corrupt")
+	}
+	return nil
+}
+
+// VerifyResult describes the outcome of VerifyObjectStore.
+type VerifyResult struct {
+	// Verified holds the number of blobs whose contents match their
+	// recorded hashes.
+	Verified int
+
+	// Missing holds the paths of the blobs that are not in the object
+	// store.
+	Missing []string
+
+	// Corrupt holds the paths of the blobs whose contents do not
+	// match their recorded hashes.
+	Corrupt []string
+}
+
+// VerifyObjectStore checks that every blob recorded for the named
+// database is in the object store, with the contents it was stored
+// with.
+func VerifyObjectStore(session *mgo.Session, dbName string, store ObjectStore) (VerifyResult, error) {
+	var result VerifyResult
+	var doc objectDoc
+	iter := session.DB(dbName).C(objectsC).Find(nil).Iter()
+	for iter.Next(&doc) {
+		ok, err := verifyObject(store, objectName(dbName, doc.Path), doc)
+		switch {
+		case errors.IsNotFound(err):
+			result.Missing = append(result.Missing, doc.Path)
+		case err != nil:
+			return result, errors.Annotatef(err, "cannot verify %q", doc.Path)
+		case !ok:
+			result.Corrupt = append(result.Corrupt, doc.Path)
+		default:
+			result.Verified++
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// verifyObject reports whether the contents of the named object match
+// the recorded size and hash.
+func verifyObject(store ObjectStore, name string, doc objectDoc) (bool, error) {
+	r, err := store.Get(name)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	defer r.Close()
+	hash := sha512.New384()
+	n, err := io.Copy(hash, r)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return n == doc.Size && hex.EncodeToString(hash.Sum(nil)) == doc.SHA384Hash, nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"crypto/md5"
+	"crypto/sha512"
+	"encoding/hex"
+	"io"
+	"sync"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/blobstore.v2"
+	"gopkg.in/mgo.v2"
+)
+
+// objectsC is the name of the collection, in each blobstore database,
+// that records the hashes of the blobs held in the object store.
+const objectsC = "objects"
+
+// ObjectStore is implemented by external object stores, such as S3
+// and Swift, that can hold the controller's blobs in place of GridFS.
+// Every environs/storage.Storage is an ObjectStore.
+type ObjectStore interface {
+	// Get returns a reader for the object with the given name. If
+	// there is no such object, an error satisfying
+	// errors.IsNotFound is returned.
+	Get(name string) (io.ReadCloser, error)
+
+	// List lists the names of all the objects with the given prefix.
+	List(prefix string) ([]string, error)
+
+	// Put stores the object with the given name, reading length
+	// bytes from r.
+	Put(name string, r io.Reader, length int64) error
+
+	// Remove removes the object with the given name. It is not an
+	// error to remove an object that does not exist.
+	Remove(name string) error
+}
+
+var (
+	objectStoreMu sync.Mutex
+	objectStore   ObjectStore
+)
+
+// SetObjectStore sets the object store in which the blobs of every
+// blobstore are stored from now on. If store is nil, blobs are stored
+// in GridFS.
+func SetObjectStore(store ObjectStore) {
+	objectStoreMu.Lock()
+	defer objectStoreMu.Unlock()
+	objectStore = store
+}
+
+func currentObjectStore() ObjectStore {
+	objectStoreMu.Lock()
+	defer objectStoreMu.Unlock()
+	return objectStore
+}
+
+// NewResourceStorage returns the blobstore.ResourceStorage for the
+// blobstore held in the named database. Unless an object store has
+// been set with SetObjectStore, this is the GridFS in that database.
+//
+// Objects are named after the database and the blob's path, and the
+// hash of each is recorded in the database so that the store can be
+// verified with VerifyObjectStore. Blobs that have not been migrated
+// from GridFS are still read from it.
+func NewResourceStorage(dbName string, session *mgo.Session) blobstore.ResourceStorage {
+	gridFS := blobstore.NewGridFS(dbName, dbName, session)
+	store := currentObjectStore()
+	if store == nil {
+		return gridFS
+	}
+	return &objectResourceStorage{
+		store:   store,
+		dbName:  dbName,
+		objects: session.DB(dbName).C(objectsC),
+		gridFS:  gridFS,
+	}
+}
+
+// objectDoc records the hash of a blob held in the object store.
+type objectDoc struct {
+	Path       string `bson:"_id"`
+	Size       int64  `bson:"size"`
+	SHA384Hash string `bson:"sha384hash"`
+}
+
+// objectResourceStorage implements blobstore.ResourceStorage on an
+// ObjectStore.
+type objectResourceStorage struct {
+	store   ObjectStore
+	dbName  string
+	objects *mgo.Collection
+	gridFS  blobstore.ResourceStorage
+}
+
+// objectName returns the name of the object holding the blob at path.
+func objectName(dbName, path string) string {
+	return dbName + "/" + path
+}
+
+// Get is part of the blobstore.ResourceStorage interface.
+func (s *objectResourceStorage) Get(path string) (io.ReadCloser, error) {
+	r, err := s.store.Get(objectName(s.dbName, path))
+	if errors.IsNotFound(err) {
+		return s.gridFS.Get(path)
+	}
+	return r, errors.Trace(err)
+}
+
+// Put is part of the blobstore.ResourceStorage interface. Like GridFS,
+// it returns the hex-encoded MD5 checksum of the data.
+func (s *objectResourceStorage) Put(path string, r io.Reader, length int64) (string, error) {
+	md5Hash := md5.New()
+	sha384Hash := sha512.New384()
+	counter := &countingWriter{}
+	r = io.TeeReader(io.LimitReader(r, length), io.MultiWriter(md5Hash, sha384Hash, counter))
+	if err := s.store.Put(objectName(s.dbName, path), r, length); err != nil {
+		return "", errors.Annotatef(err, "cannot store %q", path)
+	}
+	if counter.n != length {
+		return "", errors.Errorf("cannot store %q: expected %d bytes, read %d", path, length, counter.n)
+	}
+	_, err := s.objects.UpsertId(path, objectDoc{
+		Path:       path,
+		Size:       length,
+		SHA384Hash: hex.EncodeToString(sha384Hash.Sum(nil)),
+	})
+	if err != nil {
+		return "", errors.Annotatef(err, "cannot record hash of %q", path)
+	}
+	return hex.EncodeToString(md5Hash.Sum(nil)), nil
+}
+
+// Remove is part of the blobstore.ResourceStorage interface.
+func (s *objectResourceStorage) Remove(path string) error {
+	if err := s.store.Remove(objectName(s.dbName, path)); err != nil {
+		return errors.Annotatef(err, "cannot remove %q", path)
+	}
+	if err := s.objects.RemoveId(path); err != nil && err != mgo.ErrNotFound {
+		return errors.Annotatef(err, "cannot remove hash of %q", path)
+	}
+	// The blob may not have been migrated from GridFS yet.
+	if err := removeGridFSFile(s.objects.Database, s.dbName, path); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// removeGridFSFile removes the GridFS file at path, if there is one.
+func removeGridFSFile(db *mgo.Database, dbName, path string) error {
+	if err := db.GridFS(dbName).Remove(path); err != nil {
+		return errors.Annotatef(err, "cannot remove %q from GridFS", path)
+	}
+	return nil
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,161 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"io/ioutil"
+	"strings"
+
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/filestorage"
+	envstorage "github.com/juju/juju/environs/storage"
+	"github.com/juju/juju/state/storage"
+	"github.com/juju/juju/testing"
+)
+
+type ObjectStoreSuite struct {
+	gitjujutesting.MgoSuite
+	testing.BaseSuite
+	store   envstorage.Storage
+	storage storage.Storage
+}
+
+var _ = gc.Suite(&ObjectStoreSuite{})
+
+func (s *ObjectStoreSuite) SetUpSuite(c *gc.C) {
+	s.BaseSuite.SetUpSuite(c)
+	s.MgoSuite.SetUpSuite(c)
+}
+
+func (s *ObjectStoreSuite) TearDownSuite(c *gc.C) {
+	s.MgoSuite.TearDownSuite(c)
+	s.BaseSuite.TearDownSuite(c)
+}
+
+func (s *ObjectStoreSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.MgoSuite.SetUpTest(c)
+
+	store, err := filestorage.NewFileStorageWriter(c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+	s.store = store
+	s.storage = storage.NewStorage(testUUID, s.Session)
+	storage.SetObjectStore(s.store)
+	s.AddCleanup(func(*gc.C) { storage.SetObjectStore(nil) })
+}
+
+func (s *ObjectStoreSuite) TearDownTest(c *gc.C) {
+	s.MgoSuite.TearDownTest(c)
+	s.BaseSuite.TearDownTest(c)
+}
+
+func (s *ObjectStoreSuite) gridFSFiles(c *gc.C) int {
+	n, err := s.Session.DB("blobstore").GridFS("blobstore").Find(nil).Count()
+	c.Assert(err, jc.ErrorIsNil)
+	return n
+}
+
+func (s *ObjectStoreSuite) objects(c *gc.C) []string {
+	names, err := s.store.List("blobstore/")
+	c.Assert(err, jc.ErrorIsNil)
+	return names
+}
+
+func (s *ObjectStoreSuite) assertContent(c *gc.C, path, expect string) {
+	r, _, err := s.storage.Get(path)
+	c.Assert(err, jc.ErrorIsNil)
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, expect)
+}
+
+func (s *ObjectStoreSuite) TestPutStoresInObjectStore(c *gc.C) {
+	err := s.storage.Put("path", strings.NewReader("abcdef"), 3)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.objects(c), gc.HasLen, 1)
+	c.Assert(s.gridFSFiles(c), gc.Equals, 0)
+	s.assertContent(c, "path", "abc")
+
+	result, err := storage.VerifyObjectStore(s.Session, "blobstore", s.store)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, storage.VerifyResult{Verified: 1})
+}
+
+func (s *ObjectStoreSuite) TestRemove(c *gc.C) {
+	err := s.storage.Put("path", strings.NewReader("abc"), 3)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.storage.Remove("path")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.objects(c), gc.HasLen, 0)
+
+	result, err := storage.VerifyObjectStore(s.Session, "blobstore", s.store)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, storage.VerifyResult{})
+}
+
+func (s *ObjectStoreSuite) TestGetFallsBackToGridFS(c *gc.C) {
+	storage.SetObjectStore(nil)
+	err := s.storage.Put("path", strings.NewReader("abc"), 3)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.gridFSFiles(c), gc.Equals, 1)
+
+	storage.SetObjectStore(s.store)
+	s.assertContent(c, "path", "abc")
+
+	err = s.storage.Remove("path")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.gridFSFiles(c), gc.Equals, 0)
+}
+
+func (s *ObjectStoreSuite) TestMigrateGridFS(c *gc.C) {
+	storage.SetObjectStore(nil)
+	err := s.storage.Put("path", strings.NewReader("abc"), 3)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storage.Put("other", strings.NewReader("xyz"), 3)
+	c.Assert(err, jc.ErrorIsNil)
+	storage.SetObjectStore(s.store)
+
+	result, err := storage.MigrateGridFS(s.Session, "blobstore", s.store, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, storage.MigrateResult{Migrated: 2})
+	c.Assert(s.objects(c), gc.HasLen, 2)
+	c.Assert(s.gridFSFiles(c), gc.Equals, 2)
+
+	result, err = storage.MigrateGridFS(s.Session, "blobstore", s.store, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, storage.MigrateResult{Skipped: 2})
+	c.Assert(s.gridFSFiles(c), gc.Equals, 0)
+
+	s.assertContent(c, "path", "abc")
+	s.assertContent(c, "other", "xyz")
+	verified, err := storage.VerifyObjectStore(s.Session, "blobstore", s.store)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(verified, jc.DeepEquals, storage.VerifyResult{Verified: 2})
+}
+
+func (s *ObjectStoreSuite) TestVerifyObjectStore(c *gc.C) {
+	err := s.storage.Put("path", strings.NewReader("abc"), 3)
+	c.Assert(err, jc.ErrorIsNil)
+	names := s.objects(c)
+	c.Assert(names, gc.HasLen, 1)
+	path := strings.TrimPrefix(names[0], "blobstore/")
+
+	err = s.store.Put(names[0], strings.NewReader("abd"), 3)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := storage.VerifyObjectStore(s.Session, "blobstore", s.store)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, storage.VerifyResult{Corrupt: []string{path}})
+
+	err = s.store.Remove(names[0])
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = storage.VerifyObjectStore(s.Session, "blobstore", s.store)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, storage.VerifyResult{Missing: []string{path}})
+}
@@ -0,0 +1,408 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/syntheticcharm"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/status"
+)
+
+const (
+	// syntheticApplicationPrefix is the prefix of the names of
+	// synthetic applications.
+	syntheticApplicationPrefix = "synthetic-"
+
+	// syntheticNoncePrefix is the prefix of the nonces with which
+	// synthetic machines are provisioned. Like manually provisioned
+	// machines, synthetic machines are not polled by the instance
+	// poller.
+	syntheticNoncePrefix = manualMachinePrefix + "synthetic-"
+)
+
+// SyntheticPopulation describes the synthetic applications with which
+// a model is populated for load testing.
+type SyntheticPopulation struct {
+	// Applications is the number of applications to add.
+	Applications int
+
+	// UnitsPerApplication is the number of units to add to each
+	// application. Each unit is assigned to a new machine.
+	UnitsPerApplication int
+
+	// Series is the series of the applications and machines.
+	Series string
+
+	// Relate, if true, causes each application added to be related
+	// to the one added before it, with all their units in scope.
+	Relate bool
+}
+
+// SyntheticSummary counts the synthetic entities in a model.
+type SyntheticSummary struct {
+	Applications int
+	Units        int
+	Machines     int
+	Relations    int
+}
+
+// PopulateSynthetic adds synthetic applications, units and machines to
+// the model for load testing, returning the names of the applications
+// added. The applications use the built-in synthetic charm, and their
+// units have no agents; the machines are recorded as provisioned with
+// synthetic instance ids, so the model's provider is never asked to
+// start them, and the entities report the status of healthy ones.
+func (st *State) PopulateSynthetic(population SyntheticPopulation) ([]string, error) {
+	if population.Applications < 0 || population.UnitsPerApplication < 0 {
+		return nil, errors.NotValidf("negative population")
+	}
+	if population.Series == "" {
+		return nil, errors.NotValidf("empty series")
+	}
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if model.Type() != ModelTypeIAAS {
+		return nil, errors.NotSupportedf("synthetic population of %s model", model.Type())
+	}
+	curl := syntheticcharm.URL(population.Series)
+	ch, err := st.Charm(curl)
+	if errors.IsNotFound(err) {
+		ch, err = st.AddCharm(CharmInfo{
+			Charm: syntheticcharm.New(),
+			ID:    curl,
+		})
+	}
+	if err != nil {
+		return nil, errors.Annotate(err, "adding synthetic charm")
+	}
+	next, err := st.nextSyntheticApplication()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var added []string
+	var previous []*Unit
+	for i := 0; i < population.Applications; i++ {
+		name := fmt.Sprintf("%s%d", syntheticApplicationPrefix, next+i)
+		app, err := st.AddApplication(AddApplicationArgs{
+			Name:   name,
+			Series: population.Series,
+			Charm:  ch,
+		})
+		if err != nil {
+			return added, errors.Annotatef(err, "adding application %q", name)
+		}
+		added = append(added, name)
+		units := make([]*Unit, population.UnitsPerApplication)
+		for j := range units {
+			if units[j], err = st.addSyntheticUnit(app); err != nil {
+				return added, errors.Annotatef(err, "adding unit to application %q", name)
+			}
+		}
+		if population.Relate && i > 0 {
+			if err := st.relateSynthetic(added[i-1], name, previous, units); err != nil {
+				return added, errors.Trace(err)
+			}
+		}
+		previous = units
+	}
+	return added, nil
+}
+
+// nextSyntheticApplication returns the number of the next synthetic
+// application to be added to the model.
+func (st *State) nextSyntheticApplication() (int, error) {
+	apps, err := st.syntheticApplications()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	var next int
+	for _, app := range apps {
+		n, err := strconv.Atoi(strings.TrimPrefix(app.Name(), syntheticApplicationPrefix))
+		if err == nil && n >= next {
+			next = n + 1
+		}
+	}
+	return next, nil
+}
+
+// syntheticApplications returns the model's applications that use the
+// synthetic charm.
+func (st *State) syntheticApplications() ([]*Application, error) {
+	all, err := st.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var apps []*Application
+	for _, app := range all {
+		if curl, _ := app.CharmURL(); curl.Name == syntheticcharm.CharmName {
+			apps = append(apps, app)
+		}
+	}
+	return apps, nil
+}
+
+// addSyntheticUnit adds a unit to the given application on a new
+// synthetic machine.
+func (st *State) addSyntheticUnit(app *Application) (*Unit, error) {
+	unit, err := app.AddUnit(AddUnitParams{})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := unit.AssignToNewMachine(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	machineId, err := unit.AssignedMachineId()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machine, err := st.Machine(machineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	instId := instance.Id("synthetic-" + machineId)
+	if err := machine.SetProvisioned(instId, syntheticNoncePrefix+machineId, &instance.HardwareCharacteristics{}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	now := st.clock().Now()
+	if err := machine.SetInstanceStatus(status.StatusInfo{
+		Status:  status.Running,
+		Message: "synthetic",
+		Since:   &now,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := machine.SetStatus(status.StatusInfo{
+		Status: status.Started,
+		Since:  &now,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := unit.SetAgentStatus(status.StatusInfo{
+		Status: status.Idle,
+		Since:  &now,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := unit.SetStatus(status.StatusInfo{
+		Status:  status.Active,
+		Message: "synthetic",
+		Since:   &now,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return unit, nil
+}
+
+// relateSynthetic relates the requirer application to the provider
+// application, and enters the units of both into the relation's scope.
+func (st *State) relateSynthetic(provider, requirer string, providerUnits, requirerUnits []*Unit) error {
+	eps, err := st.InferEndpoints(
+		provider+":"+syntheticcharm.ProvidesRelation,
+		requirer+":"+syntheticcharm.RequiresRelation,
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	rel, err := st.AddRelation(eps...)
+	if err != nil {
+		return errors.Annotatef(err, "relating %q to %q", requirer, provider)
+	}
+	for _, unit := range append(providerUnits, requirerUnits...) {
+		ru, err := rel.Unit(unit)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := ru.EnterScope(map[string]interface{}{"generation": "0"}); err != nil {
+			return errors.Annotatef(err, "entering %q into scope of %q", unit.Name(), rel)
+		}
+	}
+	return nil
+}
+
+// ChurnSynthetic changes the model's synthetic entities for the given
+// number of rounds, returning the number of changes made. In each
+// round, one of a unit's status, its settings in one of its
+// relations, or its application's config is changed for each unit.
+// The changes are chosen at random from the given seed, so the same
+// seed makes the same changes to the same population.
+func (st *State) ChurnSynthetic(rounds int, seed int64) (int, error) {
+	if rounds < 0 {
+		return 0, errors.NotValidf("negative rounds")
+	}
+	apps, err := st.syntheticApplications()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	type syntheticApp struct {
+		app       *Application
+		units     []*Unit
+		relations []*Relation
+	}
+	population := make([]syntheticApp, len(apps))
+	for i, app := range apps {
+		units, err := app.AllUnits()
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		relations, err := app.Relations()
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		population[i] = syntheticApp{app, units, relations}
+	}
+
+	random := rand.New(rand.NewSource(seed))
+	var changes int
+	for round := 0; round < rounds; round++ {
+		message := fmt.Sprintf("churn %d.%d", seed, round)
+		for _, p := range population {
+			for _, unit := range p.units {
+				var err error
+				switch choice := random.Intn(3); {
+				case choice == 1 && len(p.relations) > 0:
+					rel := p.relations[random.Intn(len(p.relations))]
+					err = churnRelationSettings(rel, unit, message)
+				case choice == 2:
+					err = p.app.UpdateCharmConfig(map[string]interface{}{
+						syntheticcharm.MessageKey: message,
+					})
+				default:
+					now := st.clock().Now()
+					err = unit.SetStatus(status.StatusInfo{
+						Status:  status.Active,
+						Message: message,
+						Since:   &now,
+					})
+				}
+				if err != nil {
+					return changes, errors.Annotatef(err, "changing %q", unit.Name())
+				}
+				changes++
+			}
+		}
+	}
+	return changes, nil
+}
+
+func churnRelationSettings(rel *Relation, unit *Unit, generation string) error {
+	ru, err := rel.Unit(unit)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	settings, err := ru.Settings()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	settings.Set("generation", generation)
+	_, err = settings.Write()
+	return errors.Trace(err)
+}
+
+// RemoveSynthetic removes the model's synthetic applications, with
+// their units, relations and machines, returning what was removed.
+// Units are removed directly, since they have no agents to do it.
+func (st *State) RemoveSynthetic() (SyntheticSummary, error) {
+	var summary SyntheticSummary
+	apps, err := st.syntheticApplications()
+	if err != nil {
+		return summary, errors.Trace(err)
+	}
+	// All units leave their relations' scopes first, so that the
+	// relations between synthetic applications are removed along
+	// with the applications.
+	units := make([][]*Unit, len(apps))
+	relations := make(map[string]bool)
+	for i, app := range apps {
+		rels, err := app.Relations()
+		if err != nil {
+			return summary, errors.Trace(err)
+		}
+		for _, rel := range rels {
+			relations[rel.String()] = true
+		}
+		if units[i], err = app.AllUnits(); err != nil {
+			return summary, errors.Trace(err)
+		}
+		for _, unit := range units[i] {
+			if err := leaveScopes(unit, rels); err != nil {
+				return summary, errors.Annotatef(err, "removing %q from relations", unit.Name())
+			}
+		}
+	}
+	for i, app := range apps {
+		for _, unit := range units[i] {
+			removedMachine, err := st.removeSyntheticUnit(unit)
+			if err != nil {
+				return summary, errors.Annotatef(err, "removing %q", unit.Name())
+			}
+			summary.Units++
+			if removedMachine {
+				summary.Machines++
+			}
+		}
+		if err := app.Destroy(); err != nil {
+			return summary, errors.Annotatef(err, "removing %q", app.Name())
+		}
+		summary.Applications++
+	}
+	summary.Relations = len(relations)
+	return summary, nil
+}
+
+func leaveScopes(unit *Unit, relations []*Relation) error {
+	for _, rel := range relations {
+		ru, err := rel.Unit(unit)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := ru.LeaveScope(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// removeSyntheticUnit removes the unit, and its machine if that is a
+// synthetic machine with no other units, returning whether the machine
+// was removed.
+func (st *State) removeSyntheticUnit(unit *Unit) (bool, error) {
+	machineId, err := unit.AssignedMachineId()
+	if errors.IsNotAssigned(err) {
+		machineId = ""
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	if err := unit.EnsureDead(); err != nil {
+		return false, errors.Trace(err)
+	}
+	if err := unit.Remove(); err != nil {
+		return false, errors.Trace(err)
+	}
+	if machineId == "" {
+		return false, nil
+	}
+	machine, err := st.Machine(machineId)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if !strings.HasPrefix(machine.doc.Nonce, syntheticNoncePrefix) {
+		return false, nil
+	}
+	if err := machine.EnsureDead(); IsHasAssignedUnitsError(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	return true, errors.Trace(machine.Remove())
+}
@@ -0,0 +1,119 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+type SyntheticSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&SyntheticSuite{})
+
+func (s *SyntheticSuite) populate(c *gc.C, apps, units int, relate bool) []string {
+	added, err := s.State.PopulateSynthetic(state.SyntheticPopulation{
+		Applications:        apps,
+		UnitsPerApplication: units,
+		Series:              "xenial",
+		Relate:              relate,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return added
+}
+
+func (s *SyntheticSuite) TestPopulateSynthetic(c *gc.C) {
+	added := s.populate(c, 2, 2, true)
+	c.Assert(added, jc.DeepEquals, []string{"synthetic-0", "synthetic-1"})
+
+	app, err := s.State.Application("synthetic-1")
+	c.Assert(err, jc.ErrorIsNil)
+	units, err := app.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 2)
+	unitStatus, err := units[0].Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitStatus.Status, gc.Equals, status.Active)
+
+	machineId, err := units[0].AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+	instId, err := machine.InstanceId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instId, gc.Equals, instance.Id("synthetic-"+machineId))
+	manual, err := machine.IsManual()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(manual, jc.IsTrue)
+
+	rels, err := app.Relations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rels, gc.HasLen, 1)
+	c.Assert(rels[0].String(), gc.Equals, "synthetic-1:sink synthetic-0:source")
+	ru, err := rels[0].Unit(units[0])
+	c.Assert(err, jc.ErrorIsNil)
+	inScope, err := ru.InScope()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(inScope, jc.IsTrue)
+}
+
+func (s *SyntheticSuite) TestPopulateSyntheticContinuesNumbering(c *gc.C) {
+	s.populate(c, 1, 0, false)
+	added := s.populate(c, 2, 0, false)
+	c.Assert(added, jc.DeepEquals, []string{"synthetic-1", "synthetic-2"})
+}
+
+func (s *SyntheticSuite) TestPopulateSyntheticInvalid(c *gc.C) {
+	_, err := s.State.PopulateSynthetic(state.SyntheticPopulation{
+		Applications: -1,
+		Series:       "xenial",
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	_, err = s.State.PopulateSynthetic(state.SyntheticPopulation{Applications: 1})
+	c.Assert(err, gc.ErrorMatches, "empty series not valid")
+}
+
+func (s *SyntheticSuite) TestChurnSynthetic(c *gc.C) {
+	s.populate(c, 2, 3, true)
+	changes, err := s.State.ChurnSynthetic(4, 42)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes, gc.Equals, 4*2*3)
+}
+
+func (s *SyntheticSuite) TestChurnSyntheticNothing(c *gc.C) {
+	changes, err := s.State.ChurnSynthetic(4, 42)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes, gc.Equals, 0)
+}
+
+func (s *SyntheticSuite) TestRemoveSynthetic(c *gc.C) {
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	s.populate(c, 3, 2, true)
+	_, err := s.State.ChurnSynthetic(1, 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	summary, err := s.State.RemoveSynthetic()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(summary, jc.DeepEquals, state.SyntheticSummary{
+		Applications: 3,
+		Units:        6,
+		Machines:     6,
+		Relations:    2,
+	})
+
+	apps, err := s.State.AllApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(apps, gc.HasLen, 1)
+	c.Assert(apps[0].Name(), gc.Equals, mysql.Name())
+	machines, err := s.State.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 0)
+}
@@ -0,0 +1,212 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/cert"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// TrustCategory identifies a category of external endpoint that the
+// controller connects to over TLS.
+type TrustCategory string
+
+const (
+	// TrustCharmStore is the category of the charm store.
+	TrustCharmStore TrustCategory = "charmstore"
+
+	// TrustCloud is the category of cloud provider APIs.
+	TrustCloud TrustCategory = "cloud"
+
+	// TrustWebhook is the category of webhook targets.
+	TrustWebhook TrustCategory = "webhook"
+
+	// TrustLogForwarding is the category of log forwarding targets.
+	TrustLogForwarding TrustCategory = "log-forwarding"
+)
+
+// Validate returns an error satisfying errors.IsNotValid if the
+// category is not known.
+func (c TrustCategory) Validate() error {
+	switch c {
+	case TrustCharmStore, TrustCloud, TrustWebhook, TrustLogForwarding:
+		return nil
+	}
+	return errors.NotValidf("trust category %q", c)
+}
+
+// TrustedCACert is a CA certificate pinned by a controller
+// administrator, and trusted in addition to the system's root
+// certificates when the controller connects to endpoints in its
+// category. This allows the controller to work where outgoing TLS
+// connections are intercepted by a proxy.
+type TrustedCACert struct {
+	// Category is the category of endpoint the certificate is
+	// trusted for.
+	Category TrustCategory
+
+	// Name identifies the certificate within its category.
+	Name string
+
+	// CACert holds the PEM-encoded certificate.
+	CACert string
+
+	// Fingerprint is the hex-encoded SHA-256 fingerprint of the
+	// certificate. It is assigned by AddTrustedCACert.
+	Fingerprint string
+
+	// AddedBy identifies the user who pinned the certificate.
+	AddedBy names.UserTag
+
+	// Added is when the certificate was pinned. It is assigned by
+	// AddTrustedCACert.
+	Added time.Time
+}
+
+type trustedCACertDoc struct {
+	DocID       string `bson:"_id"`
+	Category    string `bson:"category"`
+	Name        string `bson:"name"`
+	CACert      string `bson:"ca-cert"`
+	Fingerprint string `bson:"fingerprint"`
+	AddedBy     string `bson:"added-by"`
+	Added       int64  `bson:"added"`
+}
+
+func trustedCACertDocID(category TrustCategory, name string) string {
+	return string(category) + "#" + name
+}
+
+// AddTrustedCACert pins the given CA certificate for its category of
+// endpoint. An error satisfying errors.IsAlreadyExists is returned if
+// a certificate with the same name is already pinned in the category.
+func (st *State) AddTrustedCACert(trusted TrustedCACert) error {
+	if err := trusted.Category.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if trusted.Name == "" {
+		return errors.NotValidf("empty certificate name")
+	}
+	if trusted.AddedBy.Id() == "" {
+		return errors.NotValidf("trusted certificate without user")
+	}
+	caCert, err := cert.ParseCert(trusted.CACert)
+	if err != nil {
+		return errors.NewNotValid(err, "cannot parse certificate")
+	}
+	if !caCert.IsCA {
+		return errors.NewNotValid(nil, fmt.Sprintf("certificate %q is not a CA certificate", caCert.Subject.CommonName))
+	}
+
+	docID := trustedCACertDocID(trusted.Category, trusted.Name)
+	doc := &trustedCACertDoc{
+		DocID:       docID,
+		Category:    string(trusted.Category),
+		Name:        trusted.Name,
+		CACert:      trusted.CACert,
+		Fingerprint: fmt.Sprintf("%x", sha256.Sum256(caCert.Raw)),
+		AddedBy:     trusted.AddedBy.String(),
+		Added:       st.clock().Now().UnixNano(),
+	}
+	ops := []txn.Op{{
+		C:      trustedCACertsC,
+		Id:     docID,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}}
+	err = st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		return errors.AlreadyExistsf("%s certificate %q", trusted.Category, trusted.Name)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot add %s certificate %q", trusted.Category, trusted.Name)
+	}
+	logger.Infof("%s pinned %s certificate %q (%s)", trusted.AddedBy.Id(), trusted.Category, trusted.Name, doc.Fingerprint)
+	return nil
+}
+
+// RemoveTrustedCACert unpins the named certificate from the given
+// category. An error satisfying errors.IsNotFound is returned if there
+// is no such certificate.
+func (st *State) RemoveTrustedCACert(category TrustCategory, name string) error {
+	ops := []txn.Op{{
+		C:      trustedCACertsC,
+		Id:     trustedCACertDocID(category, name),
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	err := st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		return errors.NotFoundf("%s certificate %q", category, name)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot remove %s certificate %q", category, name)
+	}
+	return nil
+}
+
+// TrustedCACerts returns the certificates pinned for the given
+// category, or for every category if it is empty, ordered by category
+// and name.
+func (st *State) TrustedCACerts(category TrustCategory) ([]TrustedCACert, error) {
+	coll, closer := st.db().GetCollection(trustedCACertsC)
+	defer closer()
+
+	var query bson.D
+	if category != "" {
+		query = bson.D{{"category", string(category)}}
+	}
+	var docs []trustedCACertDoc
+	if err := coll.Find(query).Sort("category", "name").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read trusted certificates")
+	}
+	result := make([]TrustedCACert, len(docs))
+	for i, doc := range docs {
+		addedBy, err := names.ParseUserTag(doc.AddedBy)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result[i] = TrustedCACert{
+			Category:    TrustCategory(doc.Category),
+			Name:        doc.Name,
+			CACert:      doc.CACert,
+			Fingerprint: doc.Fingerprint,
+			AddedBy:     addedBy,
+			Added:       time.Unix(0, doc.Added).UTC(),
+		}
+	}
+	return result, nil
+}
+
+// TrustedCACertPool returns the pool of root certificates to use when
+// connecting to endpoints in the given category: the system's root
+// certificates, and those pinned for the category. If no certificates
+// are pinned for the category, it returns nil, so that the default
+// roots are used.
+func (st *State) TrustedCACertPool(category TrustCategory) (*x509.CertPool, error) {
+	trusted, err := st.TrustedCACerts(category)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(trusted) == 0 {
+		return nil, nil
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		logger.Warningf("cannot read system root certificates: %v", err)
+		pool = x509.NewCertPool()
+	}
+	for _, t := range trusted {
+		if !pool.AppendCertsFromPEM([]byte(t.CACert)) {
+			return nil, errors.Errorf("cannot add %s certificate %q to pool", t.Category, t.Name)
+		}
+	}
+	return pool, nil
+}
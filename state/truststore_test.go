@@ -0,0 +1,143 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"crypto/x509"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type TrustStoreSuite struct {
+	ConnSuite
+	admin names.UserTag
+}
+
+var _ = gc.Suite(&TrustStoreSuite{})
+
+func (s *TrustStoreSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.admin = names.NewUserTag("admin")
+}
+
+func (s *TrustStoreSuite) TestAddTrustedCACert(c *gc.C) {
+	err := s.State.AddTrustedCACert(state.TrustedCACert{
+		Category: state.TrustCharmStore,
+		Name:     "corporate-proxy",
+		CACert:   coretesting.CACert,
+		AddedBy:  s.admin,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddTrustedCACert(state.TrustedCACert{
+		Category: state.TrustCloud,
+		Name:     "corporate-proxy",
+		CACert:   coretesting.OtherCACert,
+		AddedBy:  s.admin,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	trusted, err := s.State.TrustedCACerts(state.TrustCharmStore)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(trusted, gc.HasLen, 1)
+	c.Check(trusted[0].Category, gc.Equals, state.TrustCharmStore)
+	c.Check(trusted[0].Name, gc.Equals, "corporate-proxy")
+	c.Check(trusted[0].CACert, gc.Equals, coretesting.CACert)
+	c.Check(trusted[0].Fingerprint, gc.Matches, "[0-9a-f]{64}")
+	c.Check(trusted[0].AddedBy, gc.Equals, s.admin)
+	c.Check(trusted[0].Added, gc.Equals, s.Clock.Now().UTC())
+
+	all, err := s.State.TrustedCACerts("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 2)
+	c.Check(all[0].Category, gc.Equals, state.TrustCharmStore)
+	c.Check(all[1].Category, gc.Equals, state.TrustCloud)
+}
+
+func (s *TrustStoreSuite) TestAddTrustedCACertAlreadyExists(c *gc.C) {
+	trusted := state.TrustedCACert{
+		Category: state.TrustWebhook,
+		Name:     "corporate-proxy",
+		CACert:   coretesting.CACert,
+		AddedBy:  s.admin,
+	}
+	err := s.State.AddTrustedCACert(trusted)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddTrustedCACert(trusted)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+	c.Assert(err, gc.ErrorMatches, `webhook certificate "corporate-proxy" already exists`)
+}
+
+func (s *TrustStoreSuite) TestAddTrustedCACertInvalid(c *gc.C) {
+	for _, test := range []struct {
+		trusted state.TrustedCACert
+		expect  string
+	}{{
+		trusted: state.TrustedCACert{Category: "ftp", Name: "x", CACert: coretesting.CACert, AddedBy: s.admin},
+		expect:  `trust category "ftp" not valid`,
+	}, {
+		trusted: state.TrustedCACert{Category: state.TrustCloud, CACert: coretesting.CACert, AddedBy: s.admin},
+		expect:  `empty certificate name not valid`,
+	}, {
+		trusted: state.TrustedCACert{Category: state.TrustCloud, Name: "x", CACert: coretesting.CACert},
+		expect:  `trusted certificate without user not valid`,
+	}, {
+		trusted: state.TrustedCACert{Category: state.TrustCloud, Name: "x", CACert: "junk", AddedBy: s.admin},
+		expect:  `cannot parse certificate: .*`,
+	}, {
+		trusted: state.TrustedCACert{Category: state.TrustCloud, Name: "x", CACert: coretesting.ServerCert, AddedBy: s.admin},
+		expect:  `certificate ".*" is not a CA certificate`,
+	}} {
+		err := s.State.AddTrustedCACert(test.trusted)
+		c.Check(err, gc.ErrorMatches, test.expect)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *TrustStoreSuite) TestRemoveTrustedCACert(c *gc.C) {
+	err := s.State.AddTrustedCACert(state.TrustedCACert{
+		Category: state.TrustLogForwarding,
+		Name:     "corporate-proxy",
+		CACert:   coretesting.CACert,
+		AddedBy:  s.admin,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveTrustedCACert(state.TrustLogForwarding, "corporate-proxy")
+	c.Assert(err, jc.ErrorIsNil)
+	trusted, err := s.State.TrustedCACerts("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(trusted, gc.HasLen, 0)
+
+	err = s.State.RemoveTrustedCACert(state.TrustLogForwarding, "corporate-proxy")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `log-forwarding certificate "corporate-proxy" not found`)
+}
+
+func (s *TrustStoreSuite) TestTrustedCACertPool(c *gc.C) {
+	pool, err := s.State.TrustedCACertPool(state.TrustCharmStore)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pool, gc.IsNil)
+
+	err = s.State.AddTrustedCACert(state.TrustedCACert{
+		Category: state.TrustCharmStore,
+		Name:     "corporate-proxy",
+		CACert:   coretesting.CACert,
+		AddedBy:  s.admin,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	pool, err = s.State.TrustedCACertPool(state.TrustCharmStore)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pool, gc.NotNil)
+
+	// A server certificate signed by the pinned CA verifies against
+	// the pool.
+	_, err = coretesting.ServerTLSCert.Leaf.Verify(x509.VerifyOptions{Roots: pool})
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -92,6 +92,11 @@ type unitDoc struct {
 	Life                   Life
 	TxnRevno               int64 `bson:"txn-revno"`
 	PasswordHash           string
+
+	// DrainDeadline, if non-zero, is the time (in nanoseconds since
+	// the epoch) until which the unit agent may run the charm's drain
+	// hook before tearing the unit down.
+	DrainDeadline int64 `bson:"drain-deadline,omitempty"`
 }
 
 // Unit represents the state of a service unit.
@@ -116,12 +121,17 @@ func (u *Unit) Application() (*Application, error) {
 // ConfigSettings returns the complete set of service charm config settings
 // available to the unit. Unset values will be replaced with the default
 // value for the associated option, and may thus be nil when no default is
-// specified.
+// specified. If the unit tracks the model's branch, the changes made on
+// the branch are included.
 func (u *Unit) ConfigSettings() (charm.Settings, error) {
 	if u.doc.CharmURL == nil {
 		return nil, fmt.Errorf("unit charm not set")
 	}
-	return charmSettingsWithDefaults(u.st, u.doc.CharmURL, applicationCharmConfigKey(u.doc.Application, u.doc.CharmURL))
+	settings, err := charmSettingsWithDefaults(u.st, u.doc.CharmURL, applicationCharmConfigKey(u.doc.Application, u.doc.CharmURL))
+	if err != nil {
+		return nil, err
+	}
+	return unitBranchConfig(u.st, u.doc.Application, u.doc.Name, u.doc.CharmURL, settings)
 }
 
 // ApplicationName returns the application name.
@@ -134,6 +144,32 @@ func (u *Unit) Series() string {
 	return u.doc.Series
 }
 
+// DrainDeadline returns the time until which the unit's charm may
+// drain the unit before it is torn down, and whether a drain was
+// requested when the unit was destroyed.
+func (u *Unit) DrainDeadline() (time.Time, bool) {
+	if u.doc.DrainDeadline == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(0, u.doc.DrainDeadline), true
+}
+
+// Branch returns the name of the model's branch if the unit tracks it,
+// or an empty string if the unit is on the stable generation.
+func (u *Unit) Branch() (string, error) {
+	doc, err := u.st.branchDoc()
+	if errors.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	branch := &Branch{st: u.st, doc: *doc}
+	if !branch.tracks(u.doc.Application, u.doc.Name) {
+		return "", nil
+	}
+	return doc.Name, nil
+}
+
 // String returns the unit as string.
 func (u *Unit) String() string {
 	return u.doc.Name
@@ -313,6 +349,11 @@ type DestroyUnitOperation struct {
 	// to the unit is destroyed. If this is false, then detachable
 	// storage will be detached and left in the model.
 	DestroyStorage bool
+
+	// DrainTimeout, if positive, requests that the unit agent run
+	// the charm's drain hook before tearing the unit down, waiting
+	// at most this long for the charm to drain.
+	DrainTimeout time.Duration
 }
 
 // Build is part of the ModelOperation interface.
@@ -324,7 +365,7 @@ func (op *DestroyUnitOperation) Build(attempt int) ([]txn.Op, error) {
 			return nil, err
 		}
 	}
-	switch ops, err := op.unit.destroyOps(op.DestroyStorage); err {
+	switch ops, err := op.unit.destroyOps(op.DestroyStorage, op.DrainTimeout); err {
 	case errRefresh:
 	case errAlreadyDying:
 		return nil, jujutxn.ErrNoOperations
@@ -362,8 +403,10 @@ func (u *Unit) eraseHistory() error {
 
 // destroyOps returns the operations required to destroy the unit. If it
 // returns errRefresh, the unit should be refreshed and the destruction
-// operations recalculated.
-func (u *Unit) destroyOps(destroyStorage bool) ([]txn.Op, error) {
+// operations recalculated. If drainTimeout is positive and the unit
+// cannot be removed directly, a drain deadline is recorded for the
+// unit agent to observe.
+func (u *Unit) destroyOps(destroyStorage bool, drainTimeout time.Duration) ([]txn.Op, error) {
 	if u.doc.Life != Alive {
 		return nil, errAlreadyDying
 	}
@@ -391,11 +434,16 @@ func (u *Unit) destroyOps(destroyStorage bool) ([]txn.Op, error) {
 	// its own CL.
 	minUnitsOp := minUnitsTriggerOp(u.st, u.ApplicationName())
 	cleanupOp := newCleanupOp(cleanupDyingUnit, u.doc.Name, destroyStorage)
+	setDying := bson.D{{"life", Dying}}
+	if drainTimeout > 0 {
+		deadline := u.st.clock().Now().Add(drainTimeout)
+		setDying = append(setDying, bson.DocElem{"drain-deadline", deadline.UnixNano()})
+	}
 	setDyingOp := txn.Op{
 		C:      unitsC,
 		Id:     u.doc.DocID,
 		Assert: isAliveDoc,
-		Update: bson.D{{"$set", bson.D{{"life", Dying}}}},
+		Update: bson.D{{"$set", setDying}},
 	}
 	setDyingOps := []txn.Op{setDyingOp, cleanupOp, minUnitsOp}
 	if u.doc.Principal != "" {
@@ -788,6 +836,17 @@ func (u *Unit) PublicAddress() (network.Address, error) {
 	return m.PublicAddress()
 }
 
+// PublicFQDN returns the fully qualified domain name registered in the
+// provider's DNS for the unit's machine, or the empty string if there
+// is none.
+func (u *Unit) PublicFQDN() (string, error) {
+	m, err := u.machine()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return m.FQDN(), nil
+}
+
 // PrivateAddress returns the private address of the unit.
 func (u *Unit) PrivateAddress() (network.Address, error) {
 	m, err := u.machine()
@@ -899,17 +958,22 @@ func (u *Unit) Status() (status.StatusInfo, error) {
 // the effort to separate Unit from UnitAgent. Now the SetStatus for UnitAgent is in
 // the UnitAgent struct.
 func (u *Unit) SetStatus(unitStatus status.StatusInfo) error {
+	return setEntityStatus(u, unitStatus)
+}
+
+// statusUpdate is part of the statusUpdater interface.
+func (u *Unit) statusUpdate(unitStatus status.StatusInfo) (Database, setStatusParams, error) {
 	if !status.ValidWorkloadStatus(unitStatus.Status) {
-		return errors.Errorf("cannot set invalid status %q", unitStatus.Status)
+		return nil, setStatusParams{}, errors.Errorf("cannot set invalid status %q", unitStatus.Status)
 	}
-	return setStatus(u.st.db(), setStatusParams{
+	return u.st.db(), setStatusParams{
 		badge:     "unit",
 		globalKey: u.globalKey(),
 		status:    unitStatus.Status,
 		message:   unitStatus.Message,
 		rawData:   unitStatus.Data,
 		updated:   timeOrNow(unitStatus.Since, u.st.clock()),
-	})
+	}, nil
 }
 
 // OpenPortsOnSubnet opens the given port range and protocol for the unit on the
@@ -1691,6 +1755,34 @@ func (u *Unit) Constraints() (*constraints.Value, error) {
 	return &cons, nil
 }
 
+// ConstraintsTrace returns the unit's deployment constraints, along
+// with a trace of where each attribute's value came from. The unit's
+// constraints were resolved from the model and application constraints
+// when the unit was created, so the trace also records any model or
+// application constraints set since then that do not apply to the unit.
+func (u *Unit) ConstraintsTrace() (constraints.Value, []constraints.AttributeTrace, error) {
+	app, err := u.Application()
+	if err != nil {
+		return constraints.Value{}, nil, errors.Trace(err)
+	}
+	appCons, err := app.Constraints()
+	if err != nil {
+		return constraints.Value{}, nil, errors.Trace(err)
+	}
+	unitCons, err := u.Constraints()
+	if err != nil {
+		return constraints.Value{}, nil, errors.Trace(err)
+	}
+	return u.st.traceConstraints(constraints.Layer{
+		Source: constraints.SourceApplication,
+		Value:  appCons,
+	}, constraints.Layer{
+		Source: constraints.SourceUnit,
+		Value:  *unitCons,
+		Exact:  true,
+	})
+}
+
 // AssignToNewMachineOrContainer assigns the unit to a new machine,
 // with constraints determined according to the service and
 // model constraints at the time of unit creation. If a
@@ -2071,7 +2163,14 @@ var hasNoContainersTerm = bson.DocElem{
 // findCleanMachineQuery returns a Mongo query to find clean (and possibly empty) machines with
 // characteristics matching the specified constraints.
 func (u *Unit) findCleanMachineQuery(requireEmpty bool, cons *constraints.Value) (bson.D, error) {
-	db, closer := u.st.newDB()
+	return findCleanMachineQuery(u.st, u.doc.Series, requireEmpty, cons)
+}
+
+// findCleanMachineQuery returns a Mongo query to find clean (and possibly
+// empty) machines of the given series, with characteristics matching the
+// specified constraints.
+func findCleanMachineQuery(st *State, series string, requireEmpty bool, cons *constraints.Value) (bson.D, error) {
+	db, closer := st.newDB()
 	defer closer()
 	containerRefsCollection, closer := db.GetCollection(containerRefsC)
 	defer closer()
@@ -2092,7 +2191,7 @@ func (u *Unit) findCleanMachineQuery(requireEmpty bool, cons *constraints.Value)
 	}
 	terms := bson.D{
 		{"life", Alive},
-		{"series", u.doc.Series},
+		{"series", series},
 		{"jobs", []MachineJob{JobHostUnits}},
 		{"clean", true},
 		{"machineid", bson.D{{"$nin", machinesWithContainers}}},
@@ -2344,8 +2443,9 @@ func (u *Unit) UnassignFromMachine() (err error) {
 type ActionSpecsByName map[string]charm.ActionSpec
 
 // AddAction adds a new Action of type name and using arguments payload to
-// this Unit, and returns its ID.  Note that the use of spec.InsertDefaults
-// mutates payload.
+// this Unit, and returns its ID. The payload is checked against the
+// action's schema, with the schema's defaults inserted, as described by
+// actions.PrepareParams.
 func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, error) {
 	if len(name) == 0 {
 		return nil, errors.New("no action name given")
@@ -2363,12 +2463,7 @@ func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, e
 			return nil, errors.Errorf("action %q not defined on unit %q", name, u.Name())
 		}
 	}
-	// Reject bad payloads before attempting to insert defaults.
-	err := spec.ValidateParams(payload)
-	if err != nil {
-		return nil, err
-	}
-	payloadWithDefaults, err := spec.InsertDefaults(payload)
+	payloadWithDefaults, err := actions.PrepareParams(name, spec, payload)
 	if err != nil {
 		return nil, err
 	}
@@ -705,6 +705,49 @@ func (s *UnitSuite) TestDestroySetStatusRetry(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *UnitSuite) TestDestroyWithDrainTimeout(c *gc.C) {
+	err := s.unit.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	now := coretesting.NonZeroTime()
+	err = s.unit.SetAgentStatus(status.StatusInfo{
+		Status: status.Idle,
+		Since:  &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := s.unit.DrainDeadline()
+	c.Assert(ok, jc.IsFalse)
+
+	op := s.unit.DestroyOperation()
+	op.DrainTimeout = 5 * time.Minute
+	err = s.State.ApplyOperation(op)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.Life(), gc.Equals, state.Dying)
+	deadline, ok := s.unit.DrainDeadline()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(deadline.Equal(s.Clock.Now().Add(5*time.Minute)), jc.IsTrue)
+}
+
+func (s *UnitSuite) TestDestroyWithoutDrainTimeout(c *gc.C) {
+	err := s.unit.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	now := coretesting.NonZeroTime()
+	err = s.unit.SetAgentStatus(status.StatusInfo{
+		Status: status.Idle,
+		Since:  &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := s.unit.DrainDeadline()
+	c.Assert(ok, jc.IsFalse)
+}
+
 func (s *UnitSuite) TestDestroySetCharmRetry(c *gc.C) {
 	defer state.SetRetryHooks(c, s.State, func() {
 		err := s.unit.SetCharmURL(s.charm.URL())
@@ -1829,6 +1872,11 @@ snapshot:
 			givenPayload:    map[string]interface{}{"command": "allyourbasearebelongtous", "timeout": 5.0},
 			expectedPayload: map[string]interface{}{"command": "allyourbasearebelongtous", "timeout": 5.0},
 		},
+		{
+			actionName:      "juju-run",
+			givenPayload:    map[string]interface{}{"command": "allyourbasearebelongtous", "timeout": "5"},
+			expectedPayload: map[string]interface{}{"command": "allyourbasearebelongtous", "timeout": 5.0},
+		},
 		{
 			actionName: "baiku",
 			errString:  `action "baiku" not defined on unit "wordpress-actions/0"`,
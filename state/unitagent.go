@@ -57,13 +57,18 @@ func (u *UnitAgent) Status() (status.StatusInfo, error) {
 
 // SetStatus sets the status of the unit agent. The optional values
 // allow to pass additional helpful status data.
-func (u *UnitAgent) SetStatus(unitAgentStatus status.StatusInfo) (err error) {
+func (u *UnitAgent) SetStatus(unitAgentStatus status.StatusInfo) error {
+	return setEntityStatus(u, unitAgentStatus)
+}
+
+// statusUpdate is part of the statusUpdater interface.
+func (u *UnitAgent) statusUpdate(unitAgentStatus status.StatusInfo) (Database, setStatusParams, error) {
 	unit, err := u.st.Unit(u.name)
 	if errors.IsNotFound(err) {
-		return errors.Annotate(errors.NotFoundf("agent"), "cannot set status")
+		return nil, setStatusParams{}, errors.Annotate(errors.NotFoundf("agent"), "cannot set status")
 	}
 	if err != nil {
-		return errors.Trace(err)
+		return nil, setStatusParams{}, errors.Trace(err)
 	}
 	isAssigned := unit.doc.MachineId != ""
 	isPrincipal := unit.doc.Principal == ""
@@ -71,29 +76,42 @@ func (u *UnitAgent) SetStatus(unitAgentStatus status.StatusInfo) (err error) {
 	switch unitAgentStatus.Status {
 	case status.Idle, status.Executing, status.Rebooting, status.Failed:
 		if !isAssigned && isPrincipal {
-			return errors.Errorf("cannot set status %q until unit is assigned", unitAgentStatus.Status)
+			return nil, setStatusParams{}, errors.Errorf("cannot set status %q until unit is assigned", unitAgentStatus.Status)
 		}
 	case status.Error:
 		if unitAgentStatus.Message == "" {
-			return errors.Errorf("cannot set status %q without info", unitAgentStatus.Status)
+			return nil, setStatusParams{}, errors.Errorf("cannot set status %q without info", unitAgentStatus.Status)
 		}
 	case status.Allocating:
 		if isAssigned {
-			return errors.Errorf("cannot set status %q as unit is already assigned", unitAgentStatus.Status)
+			return nil, setStatusParams{}, errors.Errorf("cannot set status %q as unit is already assigned", unitAgentStatus.Status)
 		}
 	case status.Lost:
-		return errors.Errorf("cannot set status %q", unitAgentStatus.Status)
+		return nil, setStatusParams{}, errors.Errorf("cannot set status %q", unitAgentStatus.Status)
 	default:
-		return errors.Errorf("cannot set invalid status %q", unitAgentStatus.Status)
+		return nil, setStatusParams{}, errors.Errorf("cannot set invalid status %q", unitAgentStatus.Status)
 	}
-	return setStatus(u.st.db(), setStatusParams{
+	params := setStatusParams{
 		badge:     "agent",
 		globalKey: u.globalKey(),
 		status:    unitAgentStatus.Status,
 		message:   unitAgentStatus.Message,
 		rawData:   unitAgentStatus.Data,
 		updated:   timeOrNow(unitAgentStatus.Since, u.st.clock()),
-	})
+	}
+	if unitAgentStatus.Status == status.Error {
+		// Only the unit's agent reports errors, when one of its
+		// hooks fails.
+		params.onSet = func() {
+			probablyAddModelEvent(u.st, ModelEvent{
+				Kind:     EventHookFailed,
+				Actor:    unit.Tag().String(),
+				Entities: []string{unit.Tag().String(), names.NewApplicationTag(unit.ApplicationName()).String()},
+				Message:  unitAgentStatus.Message,
+			})
+		}
+	}
+	return u.st.db(), params, nil
 }
 
 // StatusHistory returns a slice of at most filter.Size StatusInfo items
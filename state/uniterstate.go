@@ -0,0 +1,147 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// UniterState holds a snapshot of the state that a unit agent's uniter
+// keeps on local disk. It is recorded in the controller so that a unit
+// can resume its lifecycle, without re-running hooks, on a machine that
+// has been re-provisioned after losing its disk.
+//
+// The contents are opaque to the controller: they are written and
+// interpreted only by the uniter.
+type UniterState struct {
+	// OperationState holds the contents of the uniter's operation
+	// state file, which records the operation in progress and the
+	// position in the hook queue.
+	OperationState string
+
+	// RelationState holds the contents of the uniter's relation
+	// state files, keyed by their paths relative to the relations
+	// directory.
+	RelationState map[string]string
+
+	// StorageState holds the contents of the uniter's storage
+	// attachment state files, keyed by their paths relative to the
+	// storage directory.
+	StorageState map[string]string
+}
+
+type uniterStateDoc struct {
+	DocID          string            `bson:"_id"`
+	ModelUUID      string            `bson:"model-uuid"`
+	OperationState string            `bson:"operation-state"`
+	RelationState  map[string]string `bson:"relation-state,omitempty"`
+	StorageState   map[string]string `bson:"storage-state,omitempty"`
+}
+
+// Validate returns an error if the uniter state cannot be recorded.
+func (s UniterState) Validate() error {
+	if s.OperationState == "" {
+		return errors.NotValidf("empty operation state")
+	}
+	for _, paths := range []map[string]string{s.RelationState, s.StorageState} {
+		for path := range paths {
+			// Map keys are stored as document field names.
+			if path == "" || strings.ContainsAny(path, ".$") {
+				return errors.NotValidf("state file path %q", path)
+			}
+		}
+	}
+	return nil
+}
+
+// UniterState returns the most recently recorded snapshot of the
+// unit's uniter state. If none has been recorded, an error satisfying
+// errors.IsNotFound is returned.
+func (u *Unit) UniterState() (UniterState, error) {
+	coll, closer := u.st.db().GetCollection(uniterStatesC)
+	defer closer()
+
+	var doc uniterStateDoc
+	err := coll.FindId(u.globalKey()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return UniterState{}, errors.NotFoundf("uniter state for unit %q", u.Name())
+	} else if err != nil {
+		return UniterState{}, errors.Annotatef(err, "cannot read uniter state for unit %q", u.Name())
+	}
+	return UniterState{
+		OperationState: doc.OperationState,
+		RelationState:  doc.RelationState,
+		StorageState:   doc.StorageState,
+	}, nil
+}
+
+// SetUniterState records a snapshot of the unit's uniter state,
+// replacing any previously recorded. It fails if the unit is dead.
+func (u *Unit) SetUniterState(uniterState UniterState) error {
+	if err := uniterState.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	docID := u.st.docID(u.globalKey())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if u.doc.Life == Dead {
+			return nil, errors.Errorf("unit is dead")
+		}
+		ops := []txn.Op{{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: notDeadDoc,
+		}}
+		_, err := u.UniterState()
+		if errors.IsNotFound(err) {
+			return append(ops, txn.Op{
+				C:      uniterStatesC,
+				Id:     docID,
+				Assert: txn.DocMissing,
+				Insert: &uniterStateDoc{
+					DocID:          docID,
+					ModelUUID:      u.st.ModelUUID(),
+					OperationState: uniterState.OperationState,
+					RelationState:  uniterState.RelationState,
+					StorageState:   uniterState.StorageState,
+				},
+			}), nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, txn.Op{
+			C:      uniterStatesC,
+			Id:     docID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"operation-state", uniterState.OperationState},
+				{"relation-state", uniterState.RelationState},
+				{"storage-state", uniterState.StorageState},
+			}}},
+		}), nil
+	}
+	if err := u.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot set uniter state for unit %q", u.Name())
+	}
+	return nil
+}
+
+// removeUniterStateOp returns an operation that removes any uniter
+// state recorded for the unit with the given global key.
+func removeUniterStateOp(mb modelBackend, globalKey string) txn.Op {
+	return txn.Op{
+		C:      uniterStatesC,
+		Id:     mb.docID(globalKey),
+		Remove: true,
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type UniterStateSuite struct {
+	ConnSuite
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&UniterStateSuite{})
+
+func (s *UniterStateSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.unit = s.Factory.MakeUnit(c, nil)
+}
+
+func (s *UniterStateSuite) TestUniterStateNotFound(c *gc.C) {
+	_, err := s.unit.UniterState()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `uniter state for unit "mysql/0" not found`)
+}
+
+func (s *UniterStateSuite) TestSetUniterState(c *gc.C) {
+	uniterState := state.UniterState{
+		OperationState: "op: continue\nopstep: pending\n",
+		RelationState:  map[string]string{"0/wordpress-0": "change-version: 1\n"},
+		StorageState:   map[string]string{"data-0": "attached: true\n"},
+	}
+	err := s.unit.SetUniterState(uniterState)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := s.unit.UniterState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, uniterState)
+
+	// Setting the state again replaces it.
+	uniterState = state.UniterState{
+		OperationState: "op: continue\nopstep: done\n",
+	}
+	err = s.unit.SetUniterState(uniterState)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.unit.UniterState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, uniterState)
+}
+
+func (s *UniterStateSuite) TestSetUniterStateInvalid(c *gc.C) {
+	err := s.unit.SetUniterState(state.UniterState{})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, "empty operation state not valid")
+
+	err = s.unit.SetUniterState(state.UniterState{
+		OperationState: "op: continue\n",
+		StorageState:   map[string]string{"data.0": ""},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `state file path "data.0" not valid`)
+}
+
+func (s *UniterStateSuite) TestSetUniterStateDeadUnit(c *gc.C) {
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetUniterState(state.UniterState{OperationState: "op: continue\n"})
+	c.Assert(err, gc.ErrorMatches, `cannot set uniter state for unit "mysql/0": unit is dead`)
+}
+
+func (s *UniterStateSuite) TestRemoveUnitRemovesUniterState(c *gc.C) {
+	err := s.unit.SetUniterState(state.UniterState{OperationState: "op: continue\n"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.unit.UniterState()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
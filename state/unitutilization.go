@@ -0,0 +1,177 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// UnitUtilization describes the resources used by a unit's processes,
+// as most recently sampled by the agent of the unit's machine.
+type UnitUtilization struct {
+	// CPUPercent is the share of a single CPU used by the unit's
+	// processes over the sampling interval, so that it may exceed
+	// 100 on machines with more than one CPU.
+	CPUPercent float64
+
+	// MemoryBytes is the memory used by the unit's processes.
+	MemoryBytes int64
+
+	// DiskBytes is the disk space used by the unit's agent
+	// directory, which includes its charm.
+	DiskBytes int64
+
+	// Updated is when the utilization was recorded.
+	Updated time.Time
+}
+
+// Validate returns an error if the utilization cannot be recorded.
+func (u UnitUtilization) Validate() error {
+	if u.CPUPercent < 0 {
+		return errors.NotValidf("negative CPUPercent")
+	}
+	if u.MemoryBytes < 0 {
+		return errors.NotValidf("negative MemoryBytes")
+	}
+	if u.DiskBytes < 0 {
+		return errors.NotValidf("negative DiskBytes")
+	}
+	return nil
+}
+
+// unitUtilizationDoc records a unit's utilization. Its id is that of
+// the unit's document, so that watchers of the collection report unit
+// names.
+type unitUtilizationDoc struct {
+	DocID       string  `bson:"_id"`
+	ModelUUID   string  `bson:"model-uuid"`
+	CPUPercent  float64 `bson:"cpu-percent"`
+	MemoryBytes int64   `bson:"memory-bytes"`
+	DiskBytes   int64   `bson:"disk-bytes"`
+	Updated     int64   `bson:"updated"`
+}
+
+func (doc *unitUtilizationDoc) utilization() UnitUtilization {
+	return UnitUtilization{
+		CPUPercent:  doc.CPUPercent,
+		MemoryBytes: doc.MemoryBytes,
+		DiskBytes:   doc.DiskBytes,
+		Updated:     time.Unix(0, doc.Updated).UTC(),
+	}
+}
+
+// Utilization returns the resources most recently reported to be used
+// by the unit. If none have been reported, an error satisfying
+// errors.IsNotFound is returned.
+func (u *Unit) Utilization() (UnitUtilization, error) {
+	coll, closer := u.st.db().GetCollection(unitUtilizationC)
+	defer closer()
+
+	var doc unitUtilizationDoc
+	err := coll.FindId(u.doc.DocID).One(&doc)
+	if err == mgo.ErrNotFound {
+		return UnitUtilization{}, errors.NotFoundf("utilization for unit %q", u.Name())
+	} else if err != nil {
+		return UnitUtilization{}, errors.Annotatef(err, "cannot read utilization for unit %q", u.Name())
+	}
+	return doc.utilization(), nil
+}
+
+// SetUtilization records the resources used by the unit, replacing any
+// previously recorded. The Updated field is ignored; the current time
+// is recorded instead. It fails if the unit is dead.
+func (u *Unit) SetUtilization(utilization UnitUtilization) error {
+	if err := utilization.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	updated := u.st.clock().Now().UnixNano()
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if u.doc.Life == Dead {
+			return nil, errors.Errorf("unit is dead")
+		}
+		ops := []txn.Op{{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: notDeadDoc,
+		}}
+		_, err := u.Utilization()
+		if errors.IsNotFound(err) {
+			return append(ops, txn.Op{
+				C:      unitUtilizationC,
+				Id:     u.doc.DocID,
+				Assert: txn.DocMissing,
+				Insert: &unitUtilizationDoc{
+					DocID:       u.doc.DocID,
+					ModelUUID:   u.st.ModelUUID(),
+					CPUPercent:  utilization.CPUPercent,
+					MemoryBytes: utilization.MemoryBytes,
+					DiskBytes:   utilization.DiskBytes,
+					Updated:     updated,
+				},
+			}), nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, txn.Op{
+			C:      unitUtilizationC,
+			Id:     u.doc.DocID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"cpu-percent", utilization.CPUPercent},
+				{"memory-bytes", utilization.MemoryBytes},
+				{"disk-bytes", utilization.DiskBytes},
+				{"updated", updated},
+			}}},
+		}), nil
+	}
+	if err := u.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot set utilization for unit %q", u.Name())
+	}
+	return nil
+}
+
+// AllUnitsUtilization returns the resources most recently reported to
+// be used by the units of the model, keyed by unit name. Units that
+// have reported nothing are omitted.
+func (st *State) AllUnitsUtilization() (map[string]UnitUtilization, error) {
+	coll, closer := st.db().GetCollection(unitUtilizationC)
+	defer closer()
+
+	var docs []unitUtilizationDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read unit utilization")
+	}
+	result := make(map[string]UnitUtilization, len(docs))
+	for i := range docs {
+		result[st.localID(docs[i].DocID)] = docs[i].utilization()
+	}
+	return result, nil
+}
+
+// WatchUnitsUtilization returns a StringsWatcher that notifies of the
+// names of the units whose recorded utilization changes. The initial
+// event holds the names of all units with recorded utilization.
+func (st *State) WatchUnitsUtilization() StringsWatcher {
+	return newCollectionWatcher(st, colWCfg{col: unitUtilizationC})
+}
+
+// removeUnitUtilizationOp returns an operation that removes any
+// utilization recorded for the unit with the given document id.
+func removeUnitUtilizationOp(docID string) txn.Op {
+	return txn.Op{
+		C:      unitUtilizationC,
+		Id:     docID,
+		Remove: true,
+	}
+}
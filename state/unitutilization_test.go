@@ -0,0 +1,131 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type UnitUtilizationSuite struct {
+	ConnSuite
+	clock *testing.Clock
+	unit  *state.Unit
+}
+
+var _ = gc.Suite(&UnitUtilizationSuite{})
+
+func (s *UnitUtilizationSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.clock = testing.NewClock(coretesting.NonZeroTime().UTC())
+	err := s.State.SetClockForTesting(s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	s.unit = s.Factory.MakeUnit(c, nil)
+}
+
+func (s *UnitUtilizationSuite) TestUtilizationNotFound(c *gc.C) {
+	_, err := s.unit.Utilization()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `utilization for unit "mysql/0" not found`)
+}
+
+func (s *UnitUtilizationSuite) TestSetUtilization(c *gc.C) {
+	err := s.unit.SetUtilization(state.UnitUtilization{
+		CPUPercent:  12.5,
+		MemoryBytes: 1024,
+		DiskBytes:   2048,
+		Updated:     time.Unix(1, 0),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := s.unit.Utilization()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, state.UnitUtilization{
+		CPUPercent:  12.5,
+		MemoryBytes: 1024,
+		DiskBytes:   2048,
+		Updated:     s.clock.Now(),
+	})
+
+	// Setting the utilization again replaces it.
+	s.clock.Advance(time.Minute)
+	err = s.unit.SetUtilization(state.UnitUtilization{MemoryBytes: 4096})
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.unit.Utilization()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, state.UnitUtilization{
+		MemoryBytes: 4096,
+		Updated:     s.clock.Now(),
+	})
+}
+
+func (s *UnitUtilizationSuite) TestSetUtilizationInvalid(c *gc.C) {
+	err := s.unit.SetUtilization(state.UnitUtilization{CPUPercent: -1})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, "negative CPUPercent not valid")
+
+	err = s.unit.SetUtilization(state.UnitUtilization{DiskBytes: -1})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, "negative DiskBytes not valid")
+}
+
+func (s *UnitUtilizationSuite) TestSetUtilizationDeadUnit(c *gc.C) {
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetUtilization(state.UnitUtilization{MemoryBytes: 1})
+	c.Assert(err, gc.ErrorMatches, `cannot set utilization for unit "mysql/0": unit is dead`)
+}
+
+func (s *UnitUtilizationSuite) TestAllUnitsUtilization(c *gc.C) {
+	app, err := s.unit.Application()
+	c.Assert(err, jc.ErrorIsNil)
+	other := s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	err = s.unit.SetUtilization(state.UnitUtilization{CPUPercent: 50})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.State.AllUnitsUtilization()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, map[string]state.UnitUtilization{
+		s.unit.Name(): {CPUPercent: 50, Updated: s.clock.Now()},
+	})
+	_, ok := result[other.Name()]
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *UnitUtilizationSuite) TestWatchUnitsUtilization(c *gc.C) {
+	w := s.State.WatchUnitsUtilization()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange()
+	wc.AssertNoChange()
+
+	err := s.unit.SetUtilization(state.UnitUtilization{CPUPercent: 50})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(s.unit.Name())
+	wc.AssertNoChange()
+
+	err = s.unit.SetUtilization(state.UnitUtilization{CPUPercent: 25})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(s.unit.Name())
+	wc.AssertNoChange()
+}
+
+func (s *UnitUtilizationSuite) TestRemoveUnitRemovesUtilization(c *gc.C) {
+	err := s.unit.SetUtilization(state.UnitUtilization{MemoryBytes: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.unit.Utilization()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
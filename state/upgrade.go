@@ -43,6 +43,7 @@ import (
 	jujutxn "github.com/juju/txn"
 	"github.com/juju/utils/set"
 	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
@@ -395,7 +396,9 @@ func (info *UpgradeInfo) SetControllerDone(machineId string) error {
 		return errors.Trace(err)
 	}
 
+	var completed bool
 	buildTxn := func(attempt int) ([]txn.Op, error) {
+		completed = false
 		doc, err := currentUpgradeInfoDoc(info.st)
 		if errors.IsNotFound(err) {
 			return nil, jujutxn.ErrNoOperations
@@ -428,7 +431,7 @@ func (info *UpgradeInfo) SetControllerDone(machineId string) error {
 			if len(extraOps) > 0 {
 				ops = append(ops, extraOps...)
 			}
-
+			completed = true
 			return ops, nil
 		}
 
@@ -443,8 +446,18 @@ func (info *UpgradeInfo) SetControllerDone(machineId string) error {
 			Update: bson.D{{"$addToSet", bson.D{{"controllersDone", machineId}}}},
 		}}, nil
 	}
-	err = info.st.db().Run(buildTxn)
-	return errors.Annotate(err, "cannot complete upgrade")
+	if err := info.st.db().Run(buildTxn); err != nil {
+		return errors.Annotate(err, "cannot complete upgrade")
+	}
+	if completed {
+		probablyAddModelEvent(info.st, ModelEvent{
+			Kind:  EventUpgradeCompleted,
+			Actor: names.NewMachineTag(machineId).String(),
+			Message: fmt.Sprintf("controller upgraded from %s to %s",
+				info.doc.PreviousVersion, info.doc.TargetVersion),
+		})
+	}
+	return nil
 }
 
 // Abort marks the current upgrade as aborted. It should be called if
@@ -510,6 +510,13 @@ func (s *UpgradeSuite) TestSetControllerDone(c *gc.C) {
 	s.assertUpgrading(c, false)
 
 	s.checkUpgradeInfoArchived(c, info, state.UpgradeComplete, 1)
+
+	events, err := s.State.ModelEvents(state.ModelEventsFilter{Limit: 10})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, gc.HasLen, 1)
+	c.Assert(events[0].Kind, gc.Equals, state.EventUpgradeCompleted)
+	c.Assert(events[0].Actor, gc.Equals, "machine-"+s.serverIdA)
+	c.Assert(events[0].Message, gc.Equals, "controller upgraded from 1.2.3 to 2.3.4")
 }
 
 func (s *UpgradeSuite) TestSetControllerDoneMultipleServers(c *gc.C) {
@@ -1294,3 +1294,34 @@ func MoveOldAuditLog(st *State) error {
 	}
 	return errors.Trace(session.Run(renameCommand, nil))
 }
+
+// EnsureControllerApplication adds the application that models the
+// controller's API server to the controller model, if it does not
+// already exist. The application's series is that of the first
+// controller machine, and its API port is the one the controller
+// is currently serving on.
+func EnsureControllerApplication(st *State) error {
+	_, err := st.ControllerApplication()
+	if err == nil {
+		return nil
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	info, err := st.ControllerInfo()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(info.MachineIds) == 0 {
+		return errors.New("no controller machines")
+	}
+	machine, err := st.Machine(info.MachineIds[0])
+	if err != nil {
+		return errors.Trace(err)
+	}
+	servingInfo, err := st.StateServingInfo()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = st.AddControllerApplication(machine.Series(), servingInfo.APIPort)
+	return errors.Trace(err)
+}
@@ -1952,3 +1952,34 @@ func (s *upgradesSuite) TestMoveOldAuditLogRename(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(set.NewStrings(names...).Contains("audit.log"), jc.IsFalse)
 }
+
+func (s *upgradesSuite) TestEnsureControllerApplication(c *gc.C) {
+	_, err := s.state.AddMachine("xenial", JobManageModel)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.state.SetStateServingInfo(StateServingInfo{
+		APIPort:      1234,
+		StatePort:    2345,
+		Cert:         testing.ServerCert,
+		PrivateKey:   testing.ServerKey,
+		CAPrivateKey: testing.CAKey,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = EnsureControllerApplication(s.state)
+	c.Assert(err, jc.ErrorIsNil)
+	app, err := s.state.ControllerApplication()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.Series(), gc.Equals, "xenial")
+	settings, err := app.CharmConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings["api-port"], gc.Equals, int64(1234))
+
+	// Running the upgrade again leaves the application alone.
+	err = app.UpdateCharmConfig(map[string]interface{}{"api-port": int64(4321)})
+	c.Assert(err, jc.ErrorIsNil)
+	err = EnsureControllerApplication(s.state)
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err = app.CharmConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings["api-port"], gc.Equals, int64(4321))
+}
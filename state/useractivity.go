@@ -0,0 +1,170 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// ModelUserActivity describes a user's use of a model's API.
+type ModelUserActivity struct {
+	ModelUUID string
+	UserName  string
+
+	// APICalls is the number of API calls the user has made to the
+	// model since the activity started being recorded.
+	APICalls int64
+
+	// LastAccess is when the user last made an API call to the
+	// model. It is zero if the user has made none.
+	LastAccess time.Time
+
+	// LastConnection is when the user last logged in to the model.
+	// It is zero if the user has never logged in.
+	LastConnection time.Time
+}
+
+// modelUserActivityDoc records the API calls made by a user to a
+// model. Like modelUserLastConnectionDoc, it is updated by the
+// apiserver outside of transactions, is informational only, and must
+// NEVER appear in any transaction asserts.
+type modelUserActivityDoc struct {
+	ID         string    `bson:"_id"`
+	ModelUUID  string    `bson:"model-uuid"`
+	UserName   string    `bson:"user"`
+	APICalls   int64     `bson:"api-calls"`
+	LastAccess time.Time `bson:"last-access"`
+}
+
+// RecordModelUserActivity adds the given number of API calls to those
+// made by the user to the model, and advances the time the user last
+// accessed the model to the given time if it is later.
+func (st *State) RecordModelUserActivity(modelUUID string, user names.UserTag, calls int64, lastAccess time.Time) error {
+	activity, closer := st.db().GetCollectionFor(modelUUID, modelUserActivityC)
+	defer closer()
+
+	activityW := activity.Writeable()
+
+	// As with last connections, the activity is informational, so
+	// the write need not wait for a majority, nor sync to disk.
+	session := activityW.Underlying().Database.Session
+	session.SetSafe(&mgo.Safe{})
+
+	userName := strings.ToLower(user.Id())
+	_, err := activityW.UpsertId(ensureModelUUID(modelUUID, userName), bson.D{
+		{"$set", bson.D{
+			{"model-uuid", modelUUID},
+			{"user", userName},
+		}},
+		{"$inc", bson.D{{"api-calls", calls}}},
+		{"$max", bson.D{{"last-access", lastAccess.UTC()}}},
+	})
+	if err != nil {
+		return errors.Annotatef(err, "cannot record API activity of %q", user.Id())
+	}
+	return nil
+}
+
+// UserActivity returns the API activity of each of the model's users,
+// including those who have never used the model.
+func (m *Model) UserActivity() ([]ModelUserActivity, error) {
+	users, err := m.Users()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	activity, err := m.st.modelUserActivity(
+		bson.D{{"model-uuid", m.UUID()}},
+		bson.D{{"model-uuid", m.UUID()}},
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]ModelUserActivity, len(users))
+	for i, user := range users {
+		userName := strings.ToLower(user.UserTag.Id())
+		if a, ok := activity[modelUserKey{m.UUID(), userName}]; ok {
+			result[i] = *a
+		}
+		result[i].ModelUUID = m.UUID()
+		result[i].UserName = user.UserTag.Id()
+	}
+	return result, nil
+}
+
+// UserActivity returns the API activity of the user on each of the
+// models that the user has logged in to or made API calls to, ordered
+// by model UUID.
+func (st *State) UserActivity(user names.UserTag) ([]ModelUserActivity, error) {
+	userName := strings.ToLower(user.Id())
+	activity, err := st.modelUserActivity(
+		bson.D{{"user", userName}},
+		bson.D{{"_id", bson.RegEx{Pattern: ":" + regexp.QuoteMeta(userName) + "$"}}},
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]ModelUserActivity, 0, len(activity))
+	for key, a := range activity {
+		a.ModelUUID = key.modelUUID
+		a.UserName = user.Id()
+		result = append(result, *a)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ModelUUID < result[j].ModelUUID
+	})
+	return result, nil
+}
+
+type modelUserKey struct {
+	modelUUID string
+	userName  string
+}
+
+// modelUserActivity returns the recorded API activity and last
+// connections matching the given queries, keyed on model and lower
+// case user name.
+func (st *State) modelUserActivity(activityQuery, connectionQuery bson.D) (map[modelUserKey]*ModelUserActivity, error) {
+	result := make(map[modelUserKey]*ModelUserActivity)
+	get := func(modelUUID, userName string) *ModelUserActivity {
+		key := modelUserKey{modelUUID, strings.ToLower(userName)}
+		a, ok := result[key]
+		if !ok {
+			a = &ModelUserActivity{}
+			result[key] = a
+		}
+		return a
+	}
+
+	activity, closer := st.db().GetRawCollection(modelUserActivityC)
+	defer closer()
+	var activityDocs []modelUserActivityDoc
+	if err := activity.Find(activityQuery).All(&activityDocs); err != nil {
+		return nil, errors.Annotate(err, "cannot read API activity")
+	}
+	for _, doc := range activityDocs {
+		a := get(doc.ModelUUID, doc.UserName)
+		a.APICalls = doc.APICalls
+		a.LastAccess = doc.LastAccess.UTC()
+	}
+
+	lastConnections, closer2 := st.db().GetRawCollection(modelUserLastConnectionC)
+	defer closer2()
+	var connectionDocs []modelUserLastConnectionDoc
+	if err := lastConnections.Find(connectionQuery).All(&connectionDocs); err != nil {
+		return nil, errors.Annotate(err, "cannot read last connections")
+	}
+	for _, doc := range connectionDocs {
+		a := get(doc.ModelUUID, doc.UserName)
+		a.LastConnection = doc.LastConnection.UTC()
+	}
+	return result, nil
+}
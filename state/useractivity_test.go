@@ -0,0 +1,93 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type UserActivitySuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&UserActivitySuite{})
+
+func (s *UserActivitySuite) TestModelUserActivity(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	t0 := time.Date(2018, 4, 1, 12, 0, 0, 0, time.UTC)
+	err := s.State.RecordModelUserActivity(s.Model.UUID(), bob.UserTag(), 3, t0.Add(time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+	// Counts accumulate, but the last access never goes backwards.
+	err = s.State.RecordModelUserActivity(s.Model.UUID(), bob.UserTag(), 2, t0)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.UpdateLastModelConnection(bob.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	lastConnection, err := s.Model.LastModelConnection(bob.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	activity, err := s.Model.UserActivity()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(activity, gc.HasLen, 2)
+	byUser := make(map[string]state.ModelUserActivity)
+	for _, a := range activity {
+		byUser[a.UserName] = a
+	}
+	c.Assert(byUser["bob"], jc.DeepEquals, state.ModelUserActivity{
+		ModelUUID:      s.Model.UUID(),
+		UserName:       "bob",
+		APICalls:       5,
+		LastAccess:     t0.Add(time.Minute),
+		LastConnection: lastConnection,
+	})
+	owner := s.Model.Owner().Id()
+	c.Assert(byUser[owner], jc.DeepEquals, state.ModelUserActivity{
+		ModelUUID: s.Model.UUID(),
+		UserName:  owner,
+	})
+}
+
+func (s *UserActivitySuite) TestUserActivity(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	otherState := s.Factory.MakeModel(c, nil)
+	defer otherState.Close()
+
+	t0 := time.Date(2018, 4, 1, 12, 0, 0, 0, time.UTC)
+	err := s.State.RecordModelUserActivity(s.Model.UUID(), bob.UserTag(), 3, t0)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RecordModelUserActivity(otherState.ModelUUID(), bob.UserTag(), 1, t0.Add(time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RecordModelUserActivity(s.Model.UUID(), s.Model.Owner(), 7, t0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	activity, err := s.State.UserActivity(bob.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	expect := []state.ModelUserActivity{{
+		ModelUUID:  s.Model.UUID(),
+		UserName:   "bob",
+		APICalls:   3,
+		LastAccess: t0,
+	}, {
+		ModelUUID:  otherState.ModelUUID(),
+		UserName:   "bob",
+		APICalls:   1,
+		LastAccess: t0.Add(time.Hour),
+	}}
+	if expect[0].ModelUUID > expect[1].ModelUUID {
+		expect[0], expect[1] = expect[1], expect[0]
+	}
+	c.Assert(activity, jc.DeepEquals, expect)
+}
+
+func (s *UserActivitySuite) TestUserActivityNone(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	activity, err := s.State.UserActivity(bob.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(activity, gc.HasLen, 0)
+}
@@ -1122,6 +1122,18 @@ func (im *IAASModel) VolumeStatus(tag names.VolumeTag) (status.StatusInfo, error
 	return getStatus(im.mb.db(), volumeGlobalKey(tag.Id()), "volume")
 }
 
+// VolumeStatusHistory returns a slice of at most filter.Size StatusInfo
+// items, or items as old as filter.FromDate, or items newer than now -
+// filter.Delta, representing past statuses of the specified volume.
+func (im *IAASModel) VolumeStatusHistory(tag names.VolumeTag, filter status.StatusHistoryFilter) ([]status.StatusInfo, error) {
+	args := &statusHistoryArgs{
+		db:        im.mb.db(),
+		globalKey: volumeGlobalKey(tag.Id()),
+		filter:    filter,
+	}
+	return statusHistory(args)
+}
+
 // SetVolumeStatus sets the status of the specified volume.
 func (im *IAASModel) SetVolumeStatus(tag names.VolumeTag, volumeStatus status.Status, info string, data map[string]interface{}, updated *time.Time) error {
 	switch volumeStatus {
@@ -920,12 +920,22 @@ type relationUnitsWatcher struct {
 	watching set.Strings
 	updates  chan watcher.Change
 	out      chan params.RelationUnitsChange
+	debounce time.Duration
 }
 
 // Watch returns a watcher that notifies of changes to conterpart units in
 // the relation.
 func (ru *RelationUnit) Watch() RelationUnitsWatcher {
-	return newRelationUnitsWatcher(ru.st, ru.WatchScope())
+	return newRelationUnitsWatcher(ru.st, ru.WatchScope(), 0)
+}
+
+// WatchDebounced returns a watcher like that returned by Watch, except
+// that after the initial event it waits for the supplied period after
+// an unnotified change before notifying; changes made in the meantime
+// are notified with it. This bounds the rate at which a busy relation
+// notifies the unit.
+func (ru *RelationUnit) WatchDebounced(period time.Duration) RelationUnitsWatcher {
+	return newRelationUnitsWatcher(ru.st, ru.WatchScope(), period)
 }
 
 // WatchUnits returns a watcher that notifies of changes to the units of the
@@ -948,16 +958,19 @@ func (r *Relation) watchUnits(applicationName string, counterpart bool) (Relatio
 		role = counterpartRole(role)
 	}
 	rsw := watchRelationScope(r.st, r.globalScope(), role, "")
-	return newRelationUnitsWatcher(r.st, rsw), nil
+	return newRelationUnitsWatcher(r.st, rsw, 0), nil
 }
 
-func newRelationUnitsWatcher(backend modelBackend, sw *RelationScopeWatcher) RelationUnitsWatcher {
+func newRelationUnitsWatcher(
+	backend modelBackend, sw *RelationScopeWatcher, debounce time.Duration,
+) RelationUnitsWatcher {
 	w := &relationUnitsWatcher{
 		commonWatcher: newCommonWatcher(backend),
 		sw:            sw,
 		watching:      make(set.Strings),
 		updates:       make(chan watcher.Change),
 		out:           make(chan params.RelationUnitsChange),
+		debounce:      debounce,
 	}
 	go func() {
 		defer w.finish()
@@ -1056,7 +1069,18 @@ func (w *relationUnitsWatcher) loop() (err error) {
 		sentInitial bool
 		changes     params.RelationUnitsChange
 		out         chan<- params.RelationUnitsChange
+		debounced   <-chan time.Time
 	)
+	// notify arranges for the accumulated changes to be sent, at once
+	// for the initial event and otherwise once the debounce period
+	// has passed.
+	notify := func() {
+		if !sentInitial || w.debounce <= 0 {
+			out = w.out
+		} else if out == nil && debounced == nil {
+			debounced = w.backend.clock().After(w.debounce)
+		}
+	}
 	for {
 		select {
 		case <-w.watcher.Dead():
@@ -1071,9 +1095,10 @@ func (w *relationUnitsWatcher) loop() (err error) {
 				return err
 			}
 			if !sentInitial || !emptyRelationUnitsChanges(&changes) {
-				out = w.out
+				notify()
 			} else {
 				out = nil
+				debounced = nil
 			}
 		case c := <-w.updates:
 			id, ok := c.Id.(string)
@@ -1083,6 +1108,9 @@ func (w *relationUnitsWatcher) loop() (err error) {
 			if _, err := w.mergeSettings(&changes, id); err != nil {
 				return err
 			}
+			notify()
+		case <-debounced:
+			debounced = nil
 			out = w.out
 		case out <- changes:
 			sentInitial = true
@@ -1525,6 +1553,16 @@ func (a *Application) WatchLeaderSettings() NotifyWatcher {
 	return newEntityWatcher(a.st, settingsC, docId)
 }
 
+// WatchCharmConfig returns a watcher for observing changes to the
+// application's charm config settings. The returned watcher will be
+// valid only while the application's charm URL is not changed.
+func (a *Application) WatchCharmConfig() (NotifyWatcher, error) {
+	if a.doc.CharmURL == nil {
+		return nil, fmt.Errorf("application charm not set")
+	}
+	return newEntityWatcher(a.st, settingsC, a.st.docID(a.charmConfigKey())), nil
+}
+
 // Watch returns a watcher for observing changes to a unit.
 func (u *Unit) Watch() NotifyWatcher {
 	return newEntityWatcher(u.st, unitsC, u.doc.DocID)
@@ -1603,7 +1641,8 @@ func (a *Application) WatchCharmConfig() (NotifyWatcher, error) {
 // WatchConfigSettings returns a watcher for observing changes to the
 // unit's service configuration settings. The unit must have a charm URL
 // set before this method is called, and the returned watcher will be
-// valid only while the unit's charm URL is not changed.
+// valid only while the unit's charm URL is not changed. Changes made on
+// the model's branch are notified only if they affect the unit.
 // TODO(fwereade): this could be much smarter; if it were, uniter.Filter
 // could be somewhat simpler.
 func (u *Unit) WatchConfigSettings() (NotifyWatcher, error) {
@@ -1611,7 +1650,79 @@ func (u *Unit) WatchConfigSettings() (NotifyWatcher, error) {
 		return nil, fmt.Errorf("unit charm not set")
 	}
 	settingsKey := applicationCharmConfigKey(u.doc.Application, u.doc.CharmURL)
-	return newEntityWatcher(u.st, settingsC, u.st.docID(settingsKey)), nil
+	return newUnitConfigWatcher(u, settingsKey), nil
+}
+
+// unitConfigWatcher notifies of changes to a unit's configuration
+// settings. It watches both the application's settings and the model's
+// branch, but only notifies of changes to the branch that alter the
+// unit's settings, so that units not tracking the branch are not
+// disturbed by it.
+type unitConfigWatcher struct {
+	commonWatcher
+	unit *Unit
+	docs NotifyWatcher
+	out  chan struct{}
+}
+
+var _ Watcher = (*unitConfigWatcher)(nil)
+
+func newUnitConfigWatcher(u *Unit, settingsKey string) NotifyWatcher {
+	w := &unitConfigWatcher{
+		commonWatcher: newCommonWatcher(u.st),
+		unit:          &Unit{st: u.st, doc: u.doc}, // Copy so it is not affected by refreshes.
+		docs: newDocWatcher(u.st, []docKey{
+			{settingsC, u.st.docID(settingsKey)},
+			{generationsC, u.st.docID(nextGenerationKey)},
+		}),
+		out: make(chan struct{}),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.out)
+		defer watcher.Stop(w.docs, &w.tomb)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// Changes returns the event channel for the unitConfigWatcher.
+func (w *unitConfigWatcher) Changes() <-chan struct{} {
+	return w.out
+}
+
+func (w *unitConfigWatcher) loop() error {
+	var (
+		sentInitial bool
+		last        charm.Settings
+		out         chan struct{}
+	)
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case _, ok := <-w.docs.Changes():
+			if !ok {
+				return watcher.EnsureErr(w.docs)
+			}
+			settings, err := w.unit.ConfigSettings()
+			if errors.IsNotFound(err) {
+				// The settings have been removed along with the
+				// application; let the client find out.
+				out = w.out
+				continue
+			} else if err != nil {
+				return errors.Trace(err)
+			}
+			if !sentInitial || !reflect.DeepEqual(settings, last) {
+				out = w.out
+			}
+			last = settings
+		case out <- struct{}{}:
+			sentInitial = true
+			out = nil
+		}
+	}
 }
 
 // WatchMeterStatus returns a watcher observing changes that affect the meter status
@@ -2829,6 +2940,78 @@ func (w *notifyCollWatcher) loop() error {
 	}
 }
 
+// relationBrokenBarrierWatcher notifies of changes to the relation
+// scope documents with a given key prefix, and to the document of a
+// given application.
+type relationBrokenBarrierWatcher struct {
+	commonWatcher
+	scopePrefix string
+	appDocID    string
+	sink        chan struct{}
+}
+
+func newRelationBrokenBarrierWatcher(backend modelBackend, scopePrefix, appName string) NotifyWatcher {
+	w := &relationBrokenBarrierWatcher{
+		commonWatcher: newCommonWatcher(backend),
+		scopePrefix:   backend.docID(scopePrefix),
+		appDocID:      backend.docID(appName),
+		sink:          make(chan struct{}),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.sink)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// Changes returns the event channel for this watcher.
+func (w *relationBrokenBarrierWatcher) Changes() <-chan struct{} {
+	return w.sink
+}
+
+func (w *relationBrokenBarrierWatcher) loop() error {
+	scopesIn := make(chan watcher.Change)
+	filter := func(id interface{}) bool {
+		key, ok := id.(string)
+		return ok && strings.HasPrefix(key, w.scopePrefix)
+	}
+	w.watcher.WatchCollectionWithFilter(relationScopesC, scopesIn, filter)
+	defer w.watcher.UnwatchCollection(relationScopesC, scopesIn)
+
+	applications, closer := w.db.GetCollection(applicationsC)
+	txnRevno, err := getTxnRevno(applications, w.appDocID)
+	closer()
+	if err != nil {
+		return err
+	}
+	appIn := make(chan watcher.Change)
+	w.watcher.Watch(applicationsC, w.appDocID, txnRevno, appIn)
+	defer w.watcher.Unwatch(applicationsC, w.appDocID, appIn)
+
+	out := w.sink // out set so that initial event is sent.
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case change := <-scopesIn:
+			if _, ok := collect(change, scopesIn, w.tomb.Dying()); !ok {
+				return tomb.ErrDying
+			}
+			out = w.sink
+		case change := <-appIn:
+			if _, ok := collect(change, appIn, w.tomb.Dying()); !ok {
+				return tomb.ErrDying
+			}
+			out = w.sink
+		case out <- struct{}{}:
+			out = nil
+		}
+	}
+}
+
 // WatchRemoteRelations returns a StringsWatcher that notifies of changes to
 // the lifecycles of the remote relations in the model.
 func (st *State) WatchRemoteRelations() StringsWatcher {
@@ -57,6 +57,12 @@ const (
 	// In Juju 2.x, the agent-state will remain “active” and scripts
 	// will watch the unit-state instead for signals of service readiness.
 	Started Status = "started"
+
+	// Offline is set when:
+	// An operator has acknowledged that the agent is intentionally not
+	// communicating with the juju server, for example during long
+	// hardware maintenance. It is reported in place of down or lost.
+	Offline Status = "offline"
 )
 
 const (
@@ -75,6 +81,11 @@ const (
 	// The machine ought to be signalling activity, but it cannot be
 	// detected.
 	Down Status = "down"
+
+	// Interrupted is set when:
+	// The cloud has signalled that it will reclaim the machine's
+	// instance, and its units are being moved to a replacement.
+	Interrupted Status = "interrupted"
 )
 
 const (
@@ -147,6 +158,12 @@ const (
 	// The unit believes it is correctly offering all the services it has
 	// been asked to offer.
 	Active Status = "active"
+
+	// Unhealthy is set when:
+	// One or more of the health checks declared by the unit's charm are
+	// failing, or the charm has reported itself unhealthy using the
+	// health-set hook tool.
+	Unhealthy Status = "unhealthy"
 )
 
 const (
@@ -283,7 +300,8 @@ func ValidWorkloadStatus(status Status) bool {
 		Waiting,
 		Active,
 		Unknown,
-		Terminated:
+		Terminated,
+		Unhealthy:
 		return true
 	default:
 		return false
@@ -93,6 +93,10 @@ const (
 	KindContainerInstance HistoryKind = "container"
 	// KindContainer represents an entry for a container agent.
 	KindContainer HistoryKind = "juju-container"
+	// KindVolume represents an entry for a volume.
+	KindVolume HistoryKind = "volume"
+	// KindFilesystem represents an entry for a filesystem.
+	KindFilesystem HistoryKind = "filesystem"
 )
 
 // String returns a string representation of the HistoryKind.
@@ -105,7 +109,8 @@ func (k HistoryKind) Valid() bool {
 	switch k {
 	case KindUnit, KindUnitAgent, KindWorkload,
 		KindMachineInstance, KindMachine,
-		KindContainerInstance, KindContainer:
+		KindContainerInstance, KindContainer,
+		KindVolume, KindFilesystem:
 		return true
 	}
 	return false
@@ -121,5 +126,7 @@ func AllHistoryKind() map[HistoryKind]string {
 		KindMachine:           "status of the agent that is managing a machine",
 		KindContainerInstance: "statuses from the agent that is managing containers",
 		KindContainer:         "statuses from the containers only and not their host machines",
+		KindVolume:            "statuses from provisioning and attaching a volume",
+		KindFilesystem:        "statuses from provisioning and attaching a filesystem",
 	}
 }
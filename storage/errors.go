@@ -0,0 +1,37 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// RateLimitError provides an interface for storage providers to
+// indicate that an operation was rejected because the provider is
+// throttling requests, and should be retried later.
+type RateLimitError interface {
+	error
+
+	// RetryAfter returns the time that the provider asked callers
+	// to wait before retrying the operation, or zero if the
+	// provider did not say.
+	RetryAfter() time.Duration
+}
+
+// RateLimitRetryAfter reports whether or not the given error, or its
+// cause, indicates that the operation was throttled by the storage
+// provider. If it was, the provider's requested retry delay is also
+// returned.
+//
+// If the error implements RateLimitError, then the result of calling
+// its RetryAfter method will be returned along with true; otherwise
+// this function returns false.
+func RateLimitRetryAfter(err error) (time.Duration, bool) {
+	if err, ok := errors.Cause(err).(RateLimitError); ok {
+		return err.RetryAfter(), true
+	}
+	return 0, false
+}
@@ -4,6 +4,8 @@
 package storage
 
 import (
+	"context"
+
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/instance"
@@ -89,27 +91,29 @@ type Provider interface {
 // VolumeSource provides an interface for creating, destroying, describing,
 // attaching and detaching volumes in the environment. A VolumeSource is
 // configured in a particular way, and corresponds to a storage "pool".
+// If the context passed to a method is cancelled, the source should
+// abandon any in-flight requests and return as soon as it can.
 type VolumeSource interface {
 	// CreateVolumes creates volumes with the specified parameters. If the
 	// volumes are initially attached, then CreateVolumes returns
 	// information about those attachments too.
-	CreateVolumes(params []VolumeParams) ([]CreateVolumesResult, error)
+	CreateVolumes(ctx context.Context, params []VolumeParams) ([]CreateVolumesResult, error)
 
 	// ListVolumes lists the provider volume IDs for every volume
 	// created by this volume source.
-	ListVolumes() ([]string, error)
+	ListVolumes(ctx context.Context) ([]string, error)
 
 	// DescribeVolumes returns the properties of the volumes with the
 	// specified provider volume IDs.
-	DescribeVolumes(volIds []string) ([]DescribeVolumesResult, error)
+	DescribeVolumes(ctx context.Context, volIds []string) ([]DescribeVolumesResult, error)
 
 	// DestroyVolumes destroys the volumes with the specified provider
 	// volume IDs.
-	DestroyVolumes(volIds []string) ([]error, error)
+	DestroyVolumes(ctx context.Context, volIds []string) ([]error, error)
 
 	// ReleaseVolumes releases the volumes with the specified provider
 	// volume IDs from the model/controller.
-	ReleaseVolumes(volIds []string) ([]error, error)
+	ReleaseVolumes(ctx context.Context, volIds []string) ([]error, error)
 
 	// ValidateVolumeParams validates the provided volume creation
 	// parameters, returning an error if they are invalid.
@@ -125,7 +129,7 @@ type VolumeSource interface {
 	// recording in state. For example, the ec2 provider must reject
 	// an attempt to attach a volume to an instance if they are in
 	// different availability zones.
-	AttachVolumes(params []VolumeAttachmentParams) ([]AttachVolumesResult, error)
+	AttachVolumes(ctx context.Context, params []VolumeAttachmentParams) ([]AttachVolumesResult, error)
 
 	// DetachVolumes detaches the volumes with the specified provider
 	// volume IDs from the instances with the corresponding index.
@@ -133,27 +137,29 @@ type VolumeSource interface {
 	// TODO(axw) we need to record in state whether or not volumes
 	// are detachable, and reject attempts to attach/detach on
 	// that basis.
-	DetachVolumes(params []VolumeAttachmentParams) ([]error, error)
+	DetachVolumes(ctx context.Context, params []VolumeAttachmentParams) ([]error, error)
 }
 
 // FilesystemSource provides an interface for creating, destroying and
 // describing filesystems in the environment. A FilesystemSource is
 // configured in a particular way, and corresponds to a storage "pool".
+// As with VolumeSource, cancelling the context passed to a method
+// should cause the source to return as soon as it can.
 type FilesystemSource interface {
 	// ValidateFilesystemParams validates the provided filesystem creation
 	// parameters, returning an error if they are invalid.
 	ValidateFilesystemParams(params FilesystemParams) error
 
 	// CreateFilesystems creates filesystems with the specified size, in MiB.
-	CreateFilesystems(params []FilesystemParams) ([]CreateFilesystemsResult, error)
+	CreateFilesystems(ctx context.Context, params []FilesystemParams) ([]CreateFilesystemsResult, error)
 
 	// DestroyFilesystems destroys the filesystems with the specified
 	// providerd filesystem IDs.
-	DestroyFilesystems(fsIds []string) ([]error, error)
+	DestroyFilesystems(ctx context.Context, fsIds []string) ([]error, error)
 
 	// ReleaseFilesystems releases the filesystems with the specified provider
 	// filesystem IDs from the model/controller.
-	ReleaseFilesystems(volIds []string) ([]error, error)
+	ReleaseFilesystems(ctx context.Context, volIds []string) ([]error, error)
 
 	// AttachFilesystems attaches filesystems to machines.
 	//
@@ -165,12 +171,12 @@ type FilesystemSource interface {
 	// recording in state. For example, the ec2 provider must reject
 	// an attempt to attach a volume to an instance if they are in
 	// different availability zones.
-	AttachFilesystems(params []FilesystemAttachmentParams) ([]AttachFilesystemsResult, error)
+	AttachFilesystems(ctx context.Context, params []FilesystemAttachmentParams) ([]AttachFilesystemsResult, error)
 
 	// DetachFilesystems detaches the filesystems with the specified
 	// provider filesystem IDs from the instances with the corresponding
 	// index.
-	DetachFilesystems(params []FilesystemAttachmentParams) ([]error, error)
+	DetachFilesystems(ctx context.Context, params []FilesystemAttachmentParams) ([]error, error)
 }
 
 // FilesystemImporter provides an interface for importing filesystems
@@ -188,6 +194,7 @@ type FilesystemImporter interface {
 	// filesystem is not in use before allowing the import to proceed.
 	// Once it is imported, it is assumed to be in a detached state.
 	ImportFilesystem(
+		ctx context.Context,
 		filesystemId string,
 		resourceTags map[string]string,
 	) (FilesystemInfo, error)
@@ -208,6 +215,7 @@ type VolumeImporter interface {
 	// volume is not in use before allowing the import to proceed.
 	// Once it is imported, it is assumed to be in a detached state.
 	ImportVolume(
+		ctx context.Context,
 		volumeId string,
 		resourceTags map[string]string,
 	) (VolumeInfo, error)
@@ -4,6 +4,7 @@
 package provider_test
 
 import (
+	"context"
 	"path/filepath"
 
 	jc "github.com/juju/testing/checkers"
@@ -51,7 +52,7 @@ func testDetachFilesystems(c *gc.C, commands *mockRunCommand, source storage.Fil
 		cmd.respond("headers\n/same/as/rootfs", nil)
 	}
 
-	results, err := source.DetachFilesystems([]storage.FilesystemAttachmentParams{{
+	results, err := source.DetachFilesystems(context.Background(), []storage.FilesystemAttachmentParams{{
 		Filesystem:   names.NewFilesystemTag("0/0"),
 		FilesystemId: "filesystem-0-0",
 		AttachmentParams: storage.AttachmentParams{
@@ -4,6 +4,8 @@
 package dummy
 
 import (
+	"context"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 
@@ -25,7 +27,7 @@ type FilesystemSource struct {
 }
 
 // CreateFilesystems is defined on storage.FilesystemSource.
-func (s *FilesystemSource) CreateFilesystems(params []storage.FilesystemParams) ([]storage.CreateFilesystemsResult, error) {
+func (s *FilesystemSource) CreateFilesystems(ctx context.Context, params []storage.FilesystemParams) ([]storage.CreateFilesystemsResult, error) {
 	s.MethodCall(s, "CreateFilesystems", params)
 	if s.CreateFilesystemsFunc != nil {
 		return s.CreateFilesystemsFunc(params)
@@ -34,7 +36,7 @@ func (s *FilesystemSource) CreateFilesystems(params []storage.FilesystemParams)
 }
 
 // DestroyFilesystems is defined on storage.FilesystemSource.
-func (s *FilesystemSource) DestroyFilesystems(volIds []string) ([]error, error) {
+func (s *FilesystemSource) DestroyFilesystems(ctx context.Context, volIds []string) ([]error, error) {
 	s.MethodCall(s, "DestroyFilesystems", volIds)
 	if s.DestroyFilesystemsFunc != nil {
 		return s.DestroyFilesystemsFunc(volIds)
@@ -43,7 +45,7 @@ func (s *FilesystemSource) DestroyFilesystems(volIds []string) ([]error, error)
 }
 
 // ReleaseFilesystems is defined on storage.FilesystemSource.
-func (s *FilesystemSource) ReleaseFilesystems(volIds []string) ([]error, error) {
+func (s *FilesystemSource) ReleaseFilesystems(ctx context.Context, volIds []string) ([]error, error) {
 	s.MethodCall(s, "ReleaseFilesystems", volIds)
 	if s.ReleaseFilesystemsFunc != nil {
 		return s.ReleaseFilesystemsFunc(volIds)
@@ -61,7 +63,7 @@ func (s *FilesystemSource) ValidateFilesystemParams(params storage.FilesystemPar
 }
 
 // AttachFilesystems is defined on storage.FilesystemSource.
-func (s *FilesystemSource) AttachFilesystems(params []storage.FilesystemAttachmentParams) ([]storage.AttachFilesystemsResult, error) {
+func (s *FilesystemSource) AttachFilesystems(ctx context.Context, params []storage.FilesystemAttachmentParams) ([]storage.AttachFilesystemsResult, error) {
 	s.MethodCall(s, "AttachFilesystems", params)
 	if s.AttachFilesystemsFunc != nil {
 		return s.AttachFilesystemsFunc(params)
@@ -70,7 +72,7 @@ func (s *FilesystemSource) AttachFilesystems(params []storage.FilesystemAttachme
 }
 
 // DetachFilesystems is defined on storage.FilesystemSource.
-func (s *FilesystemSource) DetachFilesystems(params []storage.FilesystemAttachmentParams) ([]error, error) {
+func (s *FilesystemSource) DetachFilesystems(ctx context.Context, params []storage.FilesystemAttachmentParams) ([]error, error) {
 	s.MethodCall(s, "DetachFilesystems", params)
 	if s.DetachFilesystemsFunc != nil {
 		return s.DetachFilesystemsFunc(params)
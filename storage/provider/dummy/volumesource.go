@@ -4,6 +4,8 @@
 package dummy
 
 import (
+	"context"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 
@@ -27,7 +29,7 @@ type VolumeSource struct {
 }
 
 // CreateVolumes is defined on storage.VolumeSource.
-func (s *VolumeSource) CreateVolumes(params []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+func (s *VolumeSource) CreateVolumes(ctx context.Context, params []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
 	s.MethodCall(s, "CreateVolumes", params)
 	if s.CreateVolumesFunc != nil {
 		return s.CreateVolumesFunc(params)
@@ -36,7 +38,7 @@ func (s *VolumeSource) CreateVolumes(params []storage.VolumeParams) ([]storage.C
 }
 
 // ListVolumes is defined on storage.VolumeSource.
-func (s *VolumeSource) ListVolumes() ([]string, error) {
+func (s *VolumeSource) ListVolumes(ctx context.Context) ([]string, error) {
 	s.MethodCall(s, "ListVolumes")
 	if s.ListVolumesFunc != nil {
 		return s.ListVolumesFunc()
@@ -45,7 +47,7 @@ func (s *VolumeSource) ListVolumes() ([]string, error) {
 }
 
 // DescribeVolumes is defined on storage.VolumeSource.
-func (s *VolumeSource) DescribeVolumes(volIds []string) ([]storage.DescribeVolumesResult, error) {
+func (s *VolumeSource) DescribeVolumes(ctx context.Context, volIds []string) ([]storage.DescribeVolumesResult, error) {
 	s.MethodCall(s, "DescribeVolumes", volIds)
 	if s.DescribeVolumesFunc != nil {
 		return s.DescribeVolumesFunc(volIds)
@@ -54,7 +56,7 @@ func (s *VolumeSource) DescribeVolumes(volIds []string) ([]storage.DescribeVolum
 }
 
 // DestroyVolumes is defined on storage.VolumeSource.
-func (s *VolumeSource) DestroyVolumes(volIds []string) ([]error, error) {
+func (s *VolumeSource) DestroyVolumes(ctx context.Context, volIds []string) ([]error, error) {
 	s.MethodCall(s, "DestroyVolumes", volIds)
 	if s.DestroyVolumesFunc != nil {
 		return s.DestroyVolumesFunc(volIds)
@@ -63,7 +65,7 @@ func (s *VolumeSource) DestroyVolumes(volIds []string) ([]error, error) {
 }
 
 // ReleaseVolumes is defined on storage.VolumeSource.
-func (s *VolumeSource) ReleaseVolumes(volIds []string) ([]error, error) {
+func (s *VolumeSource) ReleaseVolumes(ctx context.Context, volIds []string) ([]error, error) {
 	s.MethodCall(s, "ReleaseVolumes", volIds)
 	if s.ReleaseVolumesFunc != nil {
 		return s.ReleaseVolumesFunc(volIds)
@@ -81,7 +83,7 @@ func (s *VolumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
 }
 
 // AttachVolumes is defined on storage.VolumeSource.
-func (s *VolumeSource) AttachVolumes(params []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
+func (s *VolumeSource) AttachVolumes(ctx context.Context, params []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
 	s.MethodCall(s, "AttachVolumes", params)
 	if s.AttachVolumesFunc != nil {
 		return s.AttachVolumesFunc(params)
@@ -90,7 +92,7 @@ func (s *VolumeSource) AttachVolumes(params []storage.VolumeAttachmentParams) ([
 }
 
 // DetachVolumes is defined on storage.VolumeSource.
-func (s *VolumeSource) DetachVolumes(params []storage.VolumeAttachmentParams) ([]error, error) {
+func (s *VolumeSource) DetachVolumes(ctx context.Context, params []storage.VolumeAttachmentParams) ([]error, error) {
 	s.MethodCall(s, "DetachVolumes", params)
 	if s.DetachVolumesFunc != nil {
 		return s.DetachVolumesFunc(params)
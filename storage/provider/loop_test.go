@@ -4,6 +4,7 @@
 package provider_test
 
 import (
+	"context"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -90,7 +91,7 @@ func (s *loopSuite) TestCreateVolumes(c *gc.C) {
 	source, _ := s.loopVolumeSource(c)
 	s.commands.expect("fallocate", "-l", "2MiB", filepath.Join(s.storageDir, "volume-0"))
 
-	results, err := source.CreateVolumes([]storage.VolumeParams{{
+	results, err := source.CreateVolumes(context.Background(), []storage.VolumeParams{{
 		Tag:  names.NewVolumeTag("0"),
 		Size: 2,
 		Attachment: &storage.VolumeAttachmentParams{
@@ -117,7 +118,7 @@ func (s *loopSuite) TestCreateVolumes(c *gc.C) {
 func (s *loopSuite) TestCreateVolumesNoAttachment(c *gc.C) {
 	source, _ := s.loopVolumeSource(c)
 	s.commands.expect("fallocate", "-l", "2MiB", filepath.Join(s.storageDir, "volume-0"))
-	_, err := source.CreateVolumes([]storage.VolumeParams{{
+	_, err := source.CreateVolumes(context.Background(), []storage.VolumeParams{{
 		Tag:  names.NewVolumeTag("0"),
 		Size: 2,
 	}})
@@ -132,7 +133,7 @@ func (s *loopSuite) TestDestroyVolumes(c *gc.C) {
 	err := ioutil.WriteFile(fileName, nil, 0644)
 	c.Assert(err, jc.ErrorIsNil)
 
-	errs, err := source.DestroyVolumes([]string{"volume-0"})
+	errs, err := source.DestroyVolumes(context.Background(), []string{"volume-0"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(errs, gc.HasLen, 1)
 	c.Assert(errs[0], jc.ErrorIsNil)
@@ -143,7 +144,7 @@ func (s *loopSuite) TestDestroyVolumes(c *gc.C) {
 
 func (s *loopSuite) TestDestroyVolumesInvalidVolumeId(c *gc.C) {
 	source, _ := s.loopVolumeSource(c)
-	errs, err := source.DestroyVolumes([]string{"../super/important/stuff"})
+	errs, err := source.DestroyVolumes(context.Background(), []string{"../super/important/stuff"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(errs, gc.HasLen, 1)
 	c.Assert(errs[0], gc.ErrorMatches, `.* invalid loop volume ID "\.\./super/important/stuff"`)
@@ -151,7 +152,7 @@ func (s *loopSuite) TestDestroyVolumesInvalidVolumeId(c *gc.C) {
 
 func (s *loopSuite) TestDescribeVolumes(c *gc.C) {
 	source, _ := s.loopVolumeSource(c)
-	_, err := source.DescribeVolumes([]string{"a", "b"})
+	_, err := source.DescribeVolumes(context.Background(), []string{"a", "b"})
 	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
 }
 
@@ -168,7 +169,7 @@ func (s *loopSuite) TestAttachVolumes(c *gc.C) {
 	cmd = s.commands.expect("losetup", "-j", filepath.Join(s.storageDir, "volume-2"))
 	cmd.respond("/dev/loop42: foo\n/dev/loop1: foo\n", nil) // existing attachments
 
-	results, err := source.AttachVolumes([]storage.VolumeAttachmentParams{{
+	results, err := source.AttachVolumes(context.Background(), []storage.VolumeAttachmentParams{{
 		Volume:   names.NewVolumeTag("0"),
 		VolumeId: "vol-ume0",
 		AttachmentParams: storage.AttachmentParams{
@@ -228,7 +229,7 @@ func (s *loopSuite) TestDetachVolumes(c *gc.C) {
 	err := ioutil.WriteFile(fileName, nil, 0644)
 	c.Assert(err, jc.ErrorIsNil)
 
-	errs, err := source.DetachVolumes([]storage.VolumeAttachmentParams{{
+	errs, err := source.DetachVolumes(context.Background(), []storage.VolumeAttachmentParams{{
 		Volume:   names.NewVolumeTag("0"),
 		VolumeId: "vol-ume0",
 		AttachmentParams: storage.AttachmentParams{
@@ -256,7 +257,7 @@ func (s *loopSuite) TestDetachVolumesDetachFails(c *gc.C) {
 	err := ioutil.WriteFile(fileName, nil, 0644)
 	c.Assert(err, jc.ErrorIsNil)
 
-	errs, err := source.DetachVolumes([]storage.VolumeAttachmentParams{{
+	errs, err := source.DetachVolumes(context.Background(), []storage.VolumeAttachmentParams{{
 		Volume:   names.NewVolumeTag("0"),
 		VolumeId: "vol-ume0",
 		AttachmentParams: storage.AttachmentParams{
@@ -4,6 +4,7 @@
 package provider
 
 import (
+	"context"
 	"path"
 	"path/filepath"
 	"unicode"
@@ -68,7 +69,7 @@ func (s *managedFilesystemSource) backingVolumeBlockDevice(v names.VolumeTag) (s
 }
 
 // CreateFilesystems is defined on storage.FilesystemSource.
-func (s *managedFilesystemSource) CreateFilesystems(args []storage.FilesystemParams) ([]storage.CreateFilesystemsResult, error) {
+func (s *managedFilesystemSource) CreateFilesystems(ctx context.Context, args []storage.FilesystemParams) ([]storage.CreateFilesystemsResult, error) {
 	results := make([]storage.CreateFilesystemsResult, len(args))
 	for i, arg := range args {
 		filesystem, err := s.createFilesystem(arg)
@@ -110,7 +111,7 @@ func (s *managedFilesystemSource) createFilesystem(arg storage.FilesystemParams)
 }
 
 // DestroyFilesystems is defined on storage.FilesystemSource.
-func (s *managedFilesystemSource) DestroyFilesystems(filesystemIds []string) ([]error, error) {
+func (s *managedFilesystemSource) DestroyFilesystems(ctx context.Context, filesystemIds []string) ([]error, error) {
 	// DestroyFilesystems is a no-op; there is nothing to destroy,
 	// since the filesystem is just data on a volume. The volume
 	// is destroyed separately.
@@ -118,12 +119,12 @@ func (s *managedFilesystemSource) DestroyFilesystems(filesystemIds []string) ([]
 }
 
 // ReleaseFilesystems is defined on storage.FilesystemSource.
-func (s *managedFilesystemSource) ReleaseFilesystems(filesystemIds []string) ([]error, error) {
+func (s *managedFilesystemSource) ReleaseFilesystems(ctx context.Context, filesystemIds []string) ([]error, error) {
 	return make([]error, len(filesystemIds)), nil
 }
 
 // AttachFilesystems is defined on storage.FilesystemSource.
-func (s *managedFilesystemSource) AttachFilesystems(args []storage.FilesystemAttachmentParams) ([]storage.AttachFilesystemsResult, error) {
+func (s *managedFilesystemSource) AttachFilesystems(ctx context.Context, args []storage.FilesystemAttachmentParams) ([]storage.AttachFilesystemsResult, error) {
 	results := make([]storage.AttachFilesystemsResult, len(args))
 	for i, arg := range args {
 		attachment, err := s.attachFilesystem(arg)
@@ -163,7 +164,7 @@ func (s *managedFilesystemSource) attachFilesystem(arg storage.FilesystemAttachm
 }
 
 // DetachFilesystems is defined on storage.FilesystemSource.
-func (s *managedFilesystemSource) DetachFilesystems(args []storage.FilesystemAttachmentParams) ([]error, error) {
+func (s *managedFilesystemSource) DetachFilesystems(ctx context.Context, args []storage.FilesystemAttachmentParams) ([]error, error) {
 	results := make([]error, len(args))
 	for i, arg := range args {
 		if err := maybeUnmount(s.run, s.dirFuncs, arg.Path); err != nil {
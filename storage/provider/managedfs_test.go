@@ -4,6 +4,7 @@
 package provider_test
 
 import (
+	"context"
 	"path/filepath"
 
 	jc "github.com/juju/testing/checkers"
@@ -70,7 +71,7 @@ func (s *managedfsSuite) TestCreateFilesystems(c *gc.C) {
 		HardwareId: "weetbix",
 		Size:       3,
 	}
-	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+	results, err := source.CreateFilesystems(context.Background(), []storage.FilesystemParams{{
 		Tag:    names.NewFilesystemTag("0/0"),
 		Volume: names.NewVolumeTag("0"),
 		Size:   2,
@@ -103,7 +104,7 @@ func (s *managedfsSuite) TestCreateFilesystems(c *gc.C) {
 
 func (s *managedfsSuite) TestCreateFilesystemsNoBlockDevice(c *gc.C) {
 	source := s.initSource(c)
-	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+	results, err := source.CreateFilesystems(context.Background(), []storage.FilesystemParams{{
 		Tag:    names.NewFilesystemTag("0/0"),
 		Volume: names.NewVolumeTag("0"),
 		Size:   2,
@@ -153,7 +154,7 @@ func (s *managedfsSuite) testAttachFilesystems(c *gc.C, readOnly, reattach bool)
 		Volume: names.NewVolumeTag("0"),
 	}
 
-	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+	results, err := source.AttachFilesystems(context.Background(), []storage.FilesystemAttachmentParams{{
 		Filesystem:   names.NewFilesystemTag("0/0"),
 		FilesystemId: "filesystem-0-0",
 		AttachmentParams: storage.AttachmentParams{
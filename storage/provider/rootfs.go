@@ -4,6 +4,7 @@
 package provider
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 
@@ -143,7 +144,7 @@ func (s *rootfsFilesystemSource) ValidateFilesystemParams(params storage.Filesys
 }
 
 // CreateFilesystems is defined on the FilesystemSource interface.
-func (s *rootfsFilesystemSource) CreateFilesystems(args []storage.FilesystemParams) ([]storage.CreateFilesystemsResult, error) {
+func (s *rootfsFilesystemSource) CreateFilesystems(ctx context.Context, args []storage.FilesystemParams) ([]storage.CreateFilesystemsResult, error) {
 	results := make([]storage.CreateFilesystemsResult, len(args))
 	for i, arg := range args {
 		filesystem, err := s.createFilesystem(arg)
@@ -187,19 +188,19 @@ func (s *rootfsFilesystemSource) createFilesystem(params storage.FilesystemParam
 }
 
 // DestroyFilesystems is defined on the FilesystemSource interface.
-func (s *rootfsFilesystemSource) DestroyFilesystems(filesystemIds []string) ([]error, error) {
+func (s *rootfsFilesystemSource) DestroyFilesystems(ctx context.Context, filesystemIds []string) ([]error, error) {
 	// DestroyFilesystems is a no-op; we leave the storage directory
 	// in tact for post-mortems and such.
 	return make([]error, len(filesystemIds)), nil
 }
 
 // ReleaseFilesystems is defined on the FilesystemSource interface.
-func (s *rootfsFilesystemSource) ReleaseFilesystems(filesystemIds []string) ([]error, error) {
+func (s *rootfsFilesystemSource) ReleaseFilesystems(ctx context.Context, filesystemIds []string) ([]error, error) {
 	return make([]error, len(filesystemIds)), nil
 }
 
 // AttachFilesystems is defined on the FilesystemSource interface.
-func (s *rootfsFilesystemSource) AttachFilesystems(args []storage.FilesystemAttachmentParams) ([]storage.AttachFilesystemsResult, error) {
+func (s *rootfsFilesystemSource) AttachFilesystems(ctx context.Context, args []storage.FilesystemAttachmentParams) ([]storage.AttachFilesystemsResult, error) {
 	results := make([]storage.AttachFilesystemsResult, len(args))
 	for i, arg := range args {
 		attachment, err := s.attachFilesystem(arg)
@@ -313,7 +314,7 @@ func (s *rootfsFilesystemSource) validateSameMountPoints(source, target string)
 }
 
 // DetachFilesystems is defined on the FilesystemSource interface.
-func (s *rootfsFilesystemSource) DetachFilesystems(args []storage.FilesystemAttachmentParams) ([]error, error) {
+func (s *rootfsFilesystemSource) DetachFilesystems(ctx context.Context, args []storage.FilesystemAttachmentParams) ([]error, error) {
 	results := make([]error, len(args))
 	for i, arg := range args {
 		if err := maybeUnmount(s.run, s.dirFuncs, arg.Path); err != nil {
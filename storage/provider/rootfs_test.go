@@ -4,6 +4,7 @@
 package provider_test
 
 import (
+	"context"
 	"errors"
 	"path/filepath"
 	"runtime"
@@ -95,7 +96,7 @@ func (s *rootfsSuite) TestCreateFilesystems(c *gc.C) {
 	cmd = s.commands.expect("df", "--output=size", s.storageDir)
 	cmd.respond("1K-blocks\n4096", nil)
 
-	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+	results, err := source.CreateFilesystems(context.Background(), []storage.FilesystemParams{{
 		Tag:  names.NewFilesystemTag("6"),
 		Size: 2,
 	}, {
@@ -125,7 +126,7 @@ func (s *rootfsSuite) TestCreateFilesystems(c *gc.C) {
 
 func (s *rootfsSuite) TestCreateFilesystemsIsUse(c *gc.C) {
 	source := s.rootfsFilesystemSource(c)
-	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+	results, err := source.CreateFilesystems(context.Background(), []storage.FilesystemParams{{
 		Tag:  names.NewFilesystemTag("666"), // magic; see mockDirFuncs
 		Size: 1,
 	}})
@@ -135,7 +136,7 @@ func (s *rootfsSuite) TestCreateFilesystemsIsUse(c *gc.C) {
 
 func (s *rootfsSuite) TestAttachFilesystemsPathNotDir(c *gc.C) {
 	source := s.rootfsFilesystemSource(c)
-	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+	results, err := source.AttachFilesystems(context.Background(), []storage.FilesystemAttachmentParams{{
 		Filesystem:   names.NewFilesystemTag("6"),
 		FilesystemId: "6",
 		Path:         "file",
@@ -149,7 +150,7 @@ func (s *rootfsSuite) TestCreateFilesystemsNotEnoughSpace(c *gc.C) {
 	cmd := s.commands.expect("df", "--output=size", s.storageDir)
 	cmd.respond("1K-blocks\n2048", nil)
 
-	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+	results, err := source.CreateFilesystems(context.Background(), []storage.FilesystemParams{{
 		Tag:  names.NewFilesystemTag("6"),
 		Size: 4,
 	}})
@@ -162,7 +163,7 @@ func (s *rootfsSuite) TestCreateFilesystemsInvalidPath(c *gc.C) {
 	cmd := s.commands.expect("df", "--output=size", s.storageDir)
 	cmd.respond("", errors.New("error creating directory"))
 
-	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+	results, err := source.CreateFilesystems(context.Background(), []storage.FilesystemParams{{
 		Tag:  names.NewFilesystemTag("6"),
 		Size: 2,
 	}})
@@ -172,7 +173,7 @@ func (s *rootfsSuite) TestCreateFilesystemsInvalidPath(c *gc.C) {
 
 func (s *rootfsSuite) TestAttachFilesystemsNoPathSpecified(c *gc.C) {
 	source := s.rootfsFilesystemSource(c)
-	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+	results, err := source.AttachFilesystems(context.Background(), []storage.FilesystemAttachmentParams{{
 		Filesystem:   names.NewFilesystemTag("6"),
 		FilesystemId: "6",
 	}})
@@ -189,7 +190,7 @@ func (s *rootfsSuite) TestAttachFilesystemsBind(c *gc.C) {
 	cmd = s.commands.expect("mount", "--bind", filepath.Join(s.storageDir, "6"), "/srv")
 	cmd.respond("", nil)
 
-	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+	results, err := source.AttachFilesystems(context.Background(), []storage.FilesystemAttachmentParams{{
 		Filesystem:   names.NewFilesystemTag("6"),
 		FilesystemId: "6",
 		Path:         "/srv",
@@ -212,7 +213,7 @@ func (s *rootfsSuite) TestAttachFilesystemsBound(c *gc.C) {
 	cmd := s.commands.expect("df", "--output=source", "/srv")
 	cmd.respond("headers\n"+filepath.Join(s.storageDir, "6"), nil)
 
-	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+	results, err := source.AttachFilesystems(context.Background(), []storage.FilesystemAttachmentParams{{
 		Filesystem:   names.NewFilesystemTag("6"),
 		FilesystemId: "6",
 		Path:         "/srv",
@@ -243,7 +244,7 @@ func (s *rootfsSuite) TestAttachFilesystemsBindFailsDifferentFS(c *gc.C) {
 	cmd = s.commands.expect("df", "--output=target", "/srv")
 	cmd.respond("headers\n/proc", nil)
 
-	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+	results, err := source.AttachFilesystems(context.Background(), []storage.FilesystemAttachmentParams{{
 		Filesystem:   names.NewFilesystemTag("6"),
 		FilesystemId: "6",
 		Path:         "/srv",
@@ -267,7 +268,7 @@ func (s *rootfsSuite) TestAttachFilesystemsBindSameFSEmptyDir(c *gc.C) {
 	cmd = s.commands.expect("df", "--output=target", "/srv")
 	cmd.respond("headers\n/dev", nil)
 
-	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+	results, err := source.AttachFilesystems(context.Background(), []storage.FilesystemAttachmentParams{{
 		Filesystem:   names.NewFilesystemTag("6"),
 		FilesystemId: "6",
 		Path:         "/srv",
@@ -298,7 +299,7 @@ func (s *rootfsSuite) TestAttachFilesystemsBindSameFSNonEmptyDirUnclaimed(c *gc.
 	cmd = s.commands.expect("df", "--output=target", "/srv/666")
 	cmd.respond("headers\n/dev", nil)
 
-	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+	results, err := source.AttachFilesystems(context.Background(), []storage.FilesystemAttachmentParams{{
 		Filesystem:   names.NewFilesystemTag("6"),
 		FilesystemId: "6",
 		Path:         "/srv/666",
@@ -324,7 +325,7 @@ func (s *rootfsSuite) TestAttachFilesystemsBindSameFSNonEmptyDirClaimed(c *gc.C)
 
 	s.mockDirFuncs.Dirs.Add(filepath.Join(s.storageDir, "6", "juju-target-claimed"))
 
-	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+	results, err := source.AttachFilesystems(context.Background(), []storage.FilesystemAttachmentParams{{
 		Filesystem:   names.NewFilesystemTag("6"),
 		FilesystemId: "6",
 		Path:         "/srv/666",
@@ -4,6 +4,7 @@
 package provider_test
 
 import (
+	"context"
 	"errors"
 	"runtime"
 
@@ -90,7 +91,7 @@ func (s *tmpfsSuite) tmpfsFilesystemSource(c *gc.C) storage.FilesystemSource {
 func (s *tmpfsSuite) TestCreateFilesystems(c *gc.C) {
 	source := s.tmpfsFilesystemSource(c)
 
-	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+	results, err := source.CreateFilesystems(context.Background(), []storage.FilesystemParams{{
 		Tag:  names.NewFilesystemTag("6"),
 		Size: 2,
 	}})
@@ -112,7 +113,7 @@ func (s *tmpfsSuite) TestCreateFilesystemsHugePages(c *gc.C) {
 	// Set page size to 16MiB.
 	s.PatchValue(provider.Getpagesize, func() int { return 16 * 1024 * 1024 })
 
-	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+	results, err := source.CreateFilesystems(context.Background(), []storage.FilesystemParams{{
 		Tag:  names.NewFilesystemTag("1"),
 		Size: 17,
 	}, {
@@ -141,7 +142,7 @@ func (s *tmpfsSuite) TestCreateFilesystemsHugePages(c *gc.C) {
 
 func (s *tmpfsSuite) TestCreateFilesystemsIsUse(c *gc.C) {
 	source := s.tmpfsFilesystemSource(c)
-	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+	results, err := source.CreateFilesystems(context.Background(), []storage.FilesystemParams{{
 		Tag:  names.NewFilesystemTag("1"),
 		Size: 1,
 	}, {
@@ -156,12 +157,12 @@ func (s *tmpfsSuite) TestCreateFilesystemsIsUse(c *gc.C) {
 
 func (s *tmpfsSuite) TestAttachFilesystemsPathNotDir(c *gc.C) {
 	source := s.tmpfsFilesystemSource(c)
-	_, err := source.CreateFilesystems([]storage.FilesystemParams{{
+	_, err := source.CreateFilesystems(context.Background(), []storage.FilesystemParams{{
 		Tag:  names.NewFilesystemTag("1"),
 		Size: 1,
 	}})
 	c.Assert(err, jc.ErrorIsNil)
-	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+	results, err := source.AttachFilesystems(context.Background(), []storage.FilesystemAttachmentParams{{
 		Filesystem: names.NewFilesystemTag("1"),
 		Path:       "file",
 	}})
@@ -173,12 +174,12 @@ func (s *tmpfsSuite) TestAttachFilesystemsAlreadyMounted(c *gc.C) {
 	source := s.tmpfsFilesystemSource(c)
 	cmd := s.commands.expect("df", "--output=source", "exists")
 	cmd.respond("header\nfilesystem-123", nil)
-	_, err := source.CreateFilesystems([]storage.FilesystemParams{{
+	_, err := source.CreateFilesystems(context.Background(), []storage.FilesystemParams{{
 		Tag:  names.NewFilesystemTag("123"),
 		Size: 1,
 	}})
 	c.Assert(err, jc.ErrorIsNil)
-	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+	results, err := source.AttachFilesystems(context.Background(), []storage.FilesystemAttachmentParams{{
 		Filesystem: names.NewFilesystemTag("123"),
 		Path:       "exists",
 	}})
@@ -194,7 +195,7 @@ func (s *tmpfsSuite) TestAttachFilesystemsAlreadyMounted(c *gc.C) {
 
 func (s *tmpfsSuite) TestAttachFilesystemsMountReadOnly(c *gc.C) {
 	source := s.tmpfsFilesystemSource(c)
-	_, err := source.CreateFilesystems([]storage.FilesystemParams{{
+	_, err := source.CreateFilesystems(context.Background(), []storage.FilesystemParams{{
 		Tag:  names.NewFilesystemTag("1"),
 		Size: 1024,
 	}})
@@ -204,7 +205,7 @@ func (s *tmpfsSuite) TestAttachFilesystemsMountReadOnly(c *gc.C) {
 	cmd.respond("header\nvalue", nil)
 	s.commands.expect("mount", "-t", "tmpfs", "filesystem-1", "/var/lib/juju/storage/fs/foo", "-o", "size=1024m,ro")
 
-	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+	results, err := source.AttachFilesystems(context.Background(), []storage.FilesystemAttachmentParams{{
 		Filesystem: names.NewFilesystemTag("1"),
 		Path:       "/var/lib/juju/storage/fs/foo",
 		AttachmentParams: storage.AttachmentParams{
@@ -227,7 +228,7 @@ func (s *tmpfsSuite) TestAttachFilesystemsMountReadOnly(c *gc.C) {
 
 func (s *tmpfsSuite) TestAttachFilesystemsMountFails(c *gc.C) {
 	source := s.tmpfsFilesystemSource(c)
-	_, err := source.CreateFilesystems([]storage.FilesystemParams{{
+	_, err := source.CreateFilesystems(context.Background(), []storage.FilesystemParams{{
 		Tag:  names.NewFilesystemTag("1"),
 		Size: 1024,
 	}})
@@ -238,7 +239,7 @@ func (s *tmpfsSuite) TestAttachFilesystemsMountFails(c *gc.C) {
 	cmd = s.commands.expect("mount", "-t", "tmpfs", "filesystem-1", "/var/lib/juju/storage/fs/foo", "-o", "size=1024m")
 	cmd.respond("", errors.New("mount failed"))
 
-	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+	results, err := source.AttachFilesystems(context.Background(), []storage.FilesystemAttachmentParams{{
 		Filesystem: names.NewFilesystemTag("1"),
 		Path:       "/var/lib/juju/storage/fs/foo",
 	}})
@@ -248,12 +249,12 @@ func (s *tmpfsSuite) TestAttachFilesystemsMountFails(c *gc.C) {
 
 func (s *tmpfsSuite) TestAttachFilesystemsNoPathSpecified(c *gc.C) {
 	source := s.tmpfsFilesystemSource(c)
-	_, err := source.CreateFilesystems([]storage.FilesystemParams{{
+	_, err := source.CreateFilesystems(context.Background(), []storage.FilesystemParams{{
 		Tag:  names.NewFilesystemTag("1"),
 		Size: 1024,
 	}})
 	c.Assert(err, jc.ErrorIsNil)
-	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+	results, err := source.AttachFilesystems(context.Background(), []storage.FilesystemAttachmentParams{{
 		Filesystem: names.NewFilesystemTag("6"),
 	}})
 	c.Assert(err, jc.ErrorIsNil)
@@ -262,7 +263,7 @@ func (s *tmpfsSuite) TestAttachFilesystemsNoPathSpecified(c *gc.C) {
 
 func (s *tmpfsSuite) TestAttachFilesystemsNoFilesystem(c *gc.C) {
 	source := s.tmpfsFilesystemSource(c)
-	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+	results, err := source.AttachFilesystems(context.Background(), []storage.FilesystemAttachmentParams{{
 		Filesystem: names.NewFilesystemTag("6"),
 		Path:       "/mnt",
 	}})
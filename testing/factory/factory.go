@@ -83,6 +83,7 @@ type MachineParams struct {
 	Nonce           string
 	Constraints     constraints.Value
 	InstanceId      instance.Id
+	DisplayName     string
 	Characteristics *instance.HardwareCharacteristics
 	Addresses       []network.Address
 	Volumes         []state.MachineVolumeParams
@@ -361,7 +362,13 @@ func (factory *Factory) makeMachineReturningPassword(c *gc.C, params *MachinePar
 	}
 	machine, err := factory.st.AddOneMachine(machineTemplate)
 	c.Assert(err, jc.ErrorIsNil)
-	if setProvisioned {
+	if setProvisioned && params.DisplayName != "" {
+		err = machine.SetInstanceInfo(
+			params.InstanceId, params.DisplayName, params.Nonce, params.Characteristics,
+			nil, nil, nil, nil,
+		)
+		c.Assert(err, jc.ErrorIsNil)
+	} else if setProvisioned {
 		err = machine.SetProvisioned(params.InstanceId, params.Nonce, params.Characteristics)
 		c.Assert(err, jc.ErrorIsNil)
 	}
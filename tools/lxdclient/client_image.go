@@ -8,6 +8,8 @@ package lxdclient
 import (
 	"fmt"
 	"path"
+	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -23,6 +25,8 @@ import (
 type rawImageClient interface {
 	GetAlias(string) string
 	GetImageInfo(string) (*api.Image, error)
+	ListImages() ([]api.Image, error)
+	DeleteImage(string) error
 }
 
 type remoteClient interface {
@@ -183,6 +187,64 @@ func (i *imageClient) ensureImage(
 	return errors.Annotatef(err, "unable to get LXD image for %s", imageName)
 }
 
+// CachedImage describes an image in the local image store that was
+// fetched for launching containers of a particular series.
+type CachedImage struct {
+	// Series is the series the image was fetched for.
+	Series string
+
+	// Arch is the architecture the image was fetched for.
+	Arch string
+
+	// Fingerprint identifies the image in the local store.
+	Fingerprint string
+
+	// Size is the size of the image, in bytes.
+	Size int64
+
+	// LastUsed is when a container was last launched from the image,
+	// or when the image was fetched if none has been.
+	LastUsed time.Time
+}
+
+// CachedImages returns the images in the local image store that have
+// juju-specific aliases, as given to the images fetched by
+// EnsureImageExists.
+func (i *imageClient) CachedImages() ([]CachedImage, error) {
+	images, err := i.raw.ListImages()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []CachedImage
+	for _, image := range images {
+		for _, alias := range image.Aliases {
+			series, arch, ok := parseSeriesLocalAlias(alias.Name)
+			if !ok {
+				continue
+			}
+			lastUsed := image.LastUsedAt
+			if lastUsed.IsZero() || lastUsed.Before(image.UploadedAt) {
+				lastUsed = image.UploadedAt
+			}
+			result = append(result, CachedImage{
+				Series:      series,
+				Arch:        arch,
+				Fingerprint: image.Fingerprint,
+				Size:        image.Size,
+				LastUsed:    lastUsed,
+			})
+			break
+		}
+	}
+	return result, nil
+}
+
+// DeleteCachedImage removes the image with the given fingerprint from
+// the local image store.
+func (i *imageClient) DeleteCachedImage(fingerprint string) error {
+	return errors.Trace(i.raw.DeleteImage(fingerprint))
+}
+
 // seriesLocalAlias returns the alias to assign to images for the
 // specified series. The alias is juju-specific, to support the
 // user supplying a customised image (e.g. CentOS with cloud-init).
@@ -190,6 +252,16 @@ func seriesLocalAlias(series, arch string) string {
 	return fmt.Sprintf("juju/%s/%s", series, arch)
 }
 
+// parseSeriesLocalAlias returns the series and architecture of the
+// given juju-specific alias, and whether the alias is one.
+func parseSeriesLocalAlias(alias string) (series, arch string, ok bool) {
+	parts := strings.Split(alias, "/")
+	if len(parts) != 3 || parts[0] != "juju" || parts[1] == "" || parts[2] == "" {
+		return "", "", false
+	}
+	return parts[1], parts[2], true
+}
+
 // seriesRemoteAliases returns the aliases to look for in remotes.
 func seriesRemoteAliases(series, arch string) ([]string, error) {
 	seriesOS, err := jujuseries.GetOSFromSeries(series)
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/lxc/lxd/shared/api"
 	gc "gopkg.in/check.v1"
 
 	coretesting "github.com/juju/juju/testing"
@@ -321,3 +322,49 @@ func (s *imageSuite) TestEnsureImageExistsCallbackIncludesSourceURL(c *gc.C) {
 		c.Fatalf("no messages received")
 	}
 }
+
+func (s *imageSuite) TestCachedImages(c *gc.C) {
+	uploaded := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	used := uploaded.Add(time.Hour)
+	raw := &stubClient{
+		stub: s.Stub,
+		Images: []api.Image{{
+			Fingerprint: "xenial-fingerprint",
+			Size:        1024,
+			UploadedAt:  uploaded,
+			LastUsedAt:  used,
+			Aliases:     []api.ImageAlias{{Name: "juju/xenial/amd64"}},
+		}, {
+			Fingerprint: "trusty-fingerprint",
+			Size:        2048,
+			UploadedAt:  uploaded,
+			Aliases:     []api.ImageAlias{{Name: "other"}, {Name: "juju/trusty/amd64"}},
+		}, {
+			Fingerprint: "unaliased-fingerprint",
+			Aliases:     []api.ImageAlias{{Name: "ubuntu"}},
+		}},
+	}
+	client := &imageClient{raw: raw}
+	images, err := client.CachedImages()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(images, jc.DeepEquals, []CachedImage{{
+		Series:      "xenial",
+		Arch:        "amd64",
+		Fingerprint: "xenial-fingerprint",
+		Size:        1024,
+		LastUsed:    used,
+	}, {
+		Series:      "trusty",
+		Arch:        "amd64",
+		Fingerprint: "trusty-fingerprint",
+		Size:        2048,
+		LastUsed:    uploaded,
+	}})
+}
+
+func (s *imageSuite) TestDeleteCachedImage(c *gc.C) {
+	client := &imageClient{raw: &stubClient{stub: s.Stub}}
+	err := client.DeleteCachedImage("dead-beef")
+	c.Assert(err, jc.ErrorIsNil)
+	s.Stub.CheckCall(c, 0, "DeleteImage", "dead-beef")
+}
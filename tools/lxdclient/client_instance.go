@@ -42,6 +42,7 @@ type rawInstanceClient interface {
 	ContainerDeviceAdd(container, devname, devtype string, props []string) (*api.Response, error)
 	ContainerDeviceDelete(container, devname string) (*api.Response, error)
 	PushFile(container, path string, gid int, uid int, mode string, buf io.ReadSeeker) error
+	ApplyProfile(container, profile string) (*api.Response, error)
 }
 
 type instanceClient struct {
@@ -153,6 +154,44 @@ func (client *instanceClient) Status(name string) (string, error) {
 	return info.Status, nil
 }
 
+// ReplaceContainerProfiles removes the profiles in remove from those
+// applied to the named container, and appends those in add that are
+// not already applied. The call blocks until the profiles are applied
+// (or the request fails).
+func (client *instanceClient) ReplaceContainerProfiles(name string, remove, add []string) error {
+	info, err := client.raw.ContainerInfo(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	removed := make(map[string]bool)
+	for _, profile := range remove {
+		removed[profile] = true
+	}
+	var profiles []string
+	applied := make(map[string]bool)
+	for _, profile := range info.Profiles {
+		if !removed[profile] {
+			profiles = append(profiles, profile)
+			applied[profile] = true
+		}
+	}
+	for _, profile := range add {
+		if !applied[profile] {
+			profiles = append(profiles, profile)
+			applied[profile] = true
+		}
+	}
+
+	resp, err := client.raw.ApplyProfile(name, strings.Join(profiles, ","))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := client.raw.WaitForSuccess(resp.Operation); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 // Instances sends a request to the API for a list of all instances
 // (in the Client's namespace) for which the name starts with the
 // provided prefix. The result is also limited to those instances with
@@ -256,3 +256,36 @@ func (s *devicesSuite) TestRemoveDeviceAsyncError(c *gc.C) {
 	err := client.RemoveDevice("instance", "device")
 	c.Assert(err, gc.ErrorMatches, "async error")
 }
+
+type profilesSuite struct {
+	lxdclient.BaseSuite
+}
+
+var _ = gc.Suite(&profilesSuite{})
+
+func (s *profilesSuite) TestReplaceContainerProfiles(c *gc.C) {
+	s.Client.Container = &lxdapi.Container{}
+	s.Client.Container.Profiles = []string{"default", "juju-model-app-1", "custom"}
+	s.Client.Response = &lxdapi.Response{Operation: "/1.0/operations/1"}
+	client := lxdclient.NewInstanceClient(s.Client)
+	err := client.ReplaceContainerProfiles(
+		"instance",
+		[]string{"juju-model-app-1"},
+		[]string{"juju-model-app-2", "custom"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Stub.CheckCalls(c, []testing.StubCall{
+		{"ContainerInfo", []interface{}{"instance"}},
+		{"ApplyProfile", []interface{}{"instance", "default,custom,juju-model-app-2"}},
+		{"WaitForSuccess", []interface{}{"/1.0/operations/1"}},
+	})
+}
+
+func (s *profilesSuite) TestReplaceContainerProfilesError(c *gc.C) {
+	s.Stub.SetErrors(nil, errors.New("boom"))
+	client := lxdclient.NewInstanceClient(s.Client)
+	err := client.ReplaceContainerProfiles("instance", nil, []string{"juju-model-app-2"})
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.Stub.CheckCallNames(c, "ContainerInfo", "ApplyProfile")
+}
@@ -18,6 +18,7 @@ type rawProfileClient interface {
 	ProfileDelete(profile string) error
 	ProfileDeviceAdd(profile, devname, devtype string, props []string) (*api.Response, error)
 	ProfileConfig(profile string) (*api.Profile, error)
+	PutProfile(name string, profile api.ProfilePut) error
 }
 
 type profileClient struct {
@@ -59,6 +60,24 @@ func (p profileClient) CreateProfile(name string, config map[string]string) erro
 	return nil
 }
 
+// WriteProfile creates the named profile if it does not exist, and sets
+// its config, description and devices to those supplied.
+func (p profileClient) WriteProfile(name string, profile api.ProfilePut) error {
+	exists, err := p.HasProfile(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !exists {
+		if err := p.raw.ProfileCreate(name); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if err := p.raw.PutProfile(name, profile); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 // HasProfile returns true/false if the profile exists.
 func (p profileClient) HasProfile(name string) (bool, error) {
 	profiles, err := p.raw.ListProfiles()
@@ -52,6 +52,8 @@ type stubClient struct {
 	ReturnCode int
 	Response   *api.Response
 	Aliases    map[string]string
+	Images     []api.Image
+	Container  *api.Container
 }
 
 func (s *stubClient) WaitForSuccess(waitURL string) error {
@@ -151,6 +153,19 @@ func (s *stubClient) GetImageInfo(imageTarget string) (*api.Image, error) {
 	return &api.Image{}, nil
 }
 
+func (s *stubClient) ListImages() ([]api.Image, error) {
+	s.stub.AddCall("ListImages")
+	if err := s.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return s.Images, nil
+}
+
+func (s *stubClient) DeleteImage(image string) error {
+	s.stub.AddCall("DeleteImage", image)
+	return s.stub.NextErr()
+}
+
 func (s *stubClient) ContainerDeviceAdd(container, devname, devtype string, props []string) (*api.Response, error) {
 	s.stub.AddCall("ContainerDeviceAdd", container, devname, devtype, props)
 	if err := s.stub.NextErr(); err != nil {
@@ -172,9 +187,20 @@ func (s *stubClient) ContainerInfo(name string) (*api.Container, error) {
 	if err := s.stub.NextErr(); err != nil {
 		return nil, err
 	}
+	if s.Container != nil {
+		return s.Container, nil
+	}
 	return &api.Container{}, nil
 }
 
+func (s *stubClient) ApplyProfile(container, profile string) (*api.Response, error) {
+	s.stub.AddCall("ApplyProfile", container, profile)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return s.Response, nil
+}
+
 func (s *stubClient) PushFile(container, path string, gid int, uid int, mode string, buf io.ReadSeeker) error {
 	s.stub.AddCall("PushFile", container, path, gid, uid, mode, buf)
 	if err := s.stub.NextErr(); err != nil {
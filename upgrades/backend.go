@@ -34,6 +34,7 @@ type StateBackend interface {
 	AddModelType() error
 	MigrateLeasesToGlobalTime() error
 	MoveOldAuditLog() error
+	EnsureControllerApplication() error
 }
 
 // Model is an interface providing access to the details of a model within the
@@ -132,6 +133,10 @@ func (s stateBackend) MoveOldAuditLog() error {
 	return state.MoveOldAuditLog(s.st)
 }
 
+func (s stateBackend) EnsureControllerApplication() error {
+	return state.EnsureControllerApplication(s.st)
+}
+
 type modelShim struct {
 	st *state.State
 	m  *state.Model
@@ -13,5 +13,12 @@ func stateStepsFor24() []Step {
 				return context.State().MoveOldAuditLog()
 			},
 		},
+		&upgradeStep{
+			description: "add controller application",
+			targets:     []Target{DatabaseMaster},
+			run: func(context Context) error {
+				return context.State().EnsureControllerApplication()
+			},
+		},
 	}
 }
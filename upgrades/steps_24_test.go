@@ -25,3 +25,9 @@ func (s *steps24Suite) TestMoveOldAuditLog(c *gc.C) {
 	// Logic for step itself is tested in state package.
 	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.DatabaseMaster})
 }
+
+func (s *steps24Suite) TestEnsureControllerApplication(c *gc.C) {
+	step := findStateStep(c, v24, "add controller application")
+	// Logic for step itself is tested in state package.
+	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.DatabaseMaster})
+}
@@ -0,0 +1,39 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package watcher
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/life"
+)
+
+// EntityChange describes a change to an entity, holding enough of the
+// entity's state that the receiver need not read it separately.
+type EntityChange struct {
+	// Tag identifies the changed entity; its kind is Tag.Kind().
+	Tag names.Tag
+
+	// Life is the life of the entity when the change was reported.
+	// Entities that have been removed are reported as Dead.
+	Life life.Value
+
+	// Removed is true if the entity no longer exists.
+	Removed bool
+}
+
+// EntityChangesChannel is a change channel as described in the
+// CoreWatcher docs.
+//
+// It sends a single value describing a baseline set of entities, and
+// subsequent values describing additions, changes, and/or removals of
+// those entities.
+type EntityChangesChannel <-chan []EntityChange
+
+// EntityChangesWatcher conveniently ties an EntityChangesChannel to the
+// worker.Worker that represents its validity.
+type EntityChangesWatcher interface {
+	CoreWatcher
+	Changes() EntityChangesChannel
+}
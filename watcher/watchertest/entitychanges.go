@@ -0,0 +1,90 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package watchertest
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+)
+
+func NewEntityChangesWatcherC(c *gc.C, watcher watcher.EntityChangesWatcher, preAssert func()) EntityChangesWatcherC {
+	if preAssert == nil {
+		preAssert = func() {}
+	}
+	return EntityChangesWatcherC{
+		C:         c,
+		Watcher:   watcher,
+		PreAssert: preAssert,
+	}
+}
+
+type EntityChangesWatcherC struct {
+	*gc.C
+	Watcher   watcher.EntityChangesWatcher
+	PreAssert func()
+}
+
+// AssertNoChange fails if it manages to read a value from Changes before a
+// short time has passed.
+func (c EntityChangesWatcherC) AssertNoChange() {
+	c.PreAssert()
+	select {
+	case change, ok := <-c.Watcher.Changes():
+		c.Fatalf("watcher sent unexpected change: (%#v, %v)", change, ok)
+	case <-time.After(testing.ShortWait):
+	}
+}
+
+// AssertStops Kills the watcher and asserts (1) that Wait completes without
+// error before a long time has passed; and (2) that Changes remains open but
+// no values are being sent.
+func (c EntityChangesWatcherC) AssertStops() {
+	c.Watcher.Kill()
+	wait := make(chan error)
+	go func() {
+		c.PreAssert()
+		wait <- c.Watcher.Wait()
+	}()
+	select {
+	case <-time.After(testing.LongWait):
+		c.Fatalf("watcher never stopped")
+	case err := <-wait:
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	c.PreAssert()
+	select {
+	case change, ok := <-c.Watcher.Changes():
+		c.Fatalf("watcher sent unexpected change: (%#v, %v)", change, ok)
+	default:
+	}
+}
+
+// AssertChange asserts the given changes were reported by the watcher,
+// in any order and possibly across several events, but does not assume
+// there are no following changes.
+func (c EntityChangesWatcherC) AssertChange(expect ...watcher.EntityChange) {
+	timeout := time.After(testing.LongWait)
+	var actual []watcher.EntityChange
+	for len(actual) < len(expect) || len(expect) == 0 {
+		c.PreAssert()
+		select {
+		case changes, ok := <-c.Watcher.Changes():
+			c.Assert(ok, jc.IsTrue)
+			actual = append(actual, changes...)
+			if len(expect) == 0 {
+				c.Assert(actual, gc.HasLen, 0)
+				return
+			}
+		case <-timeout:
+			c.Fatalf("watcher did not send change; got %#v, expected %#v", actual, expect)
+		}
+	}
+	c.Assert(actual, jc.SameContents, expect)
+}
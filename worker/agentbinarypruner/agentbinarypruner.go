@@ -0,0 +1,38 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentbinarypruner
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+
+	jworker "github.com/juju/juju/worker"
+)
+
+// AgentBinaryPruner defines the interface for types capable of
+// removing agent binaries that are no longer used.
+type AgentBinaryPruner interface {
+	RemoveUnusedAgentBinaries() error
+}
+
+// New returns a worker which periodically removes the agent binaries
+// stored by the controller that are no longer used by any model.
+func New(p AgentBinaryPruner, interval time.Duration, clock clock.Clock) worker.Worker {
+	return jworker.NewSimpleWorker(func(stopCh <-chan struct{}) error {
+		for {
+			select {
+			case <-clock.After(interval):
+				err := p.RemoveUnusedAgentBinaries()
+				if err != nil {
+					return errors.Annotate(err, "pruning failed, agent binary pruner stopping")
+				}
+			case <-stopCh:
+				return nil
+			}
+		}
+	})
+}
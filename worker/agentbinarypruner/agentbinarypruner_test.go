@@ -0,0 +1,86 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentbinarypruner_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/agentbinarypruner"
+)
+
+type AgentBinaryPrunerSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&AgentBinaryPrunerSuite{})
+
+func (s *AgentBinaryPrunerSuite) TestPrunes(c *gc.C) {
+	fakePruner := newFakeAgentBinaryPruner(nil)
+	testClock := testing.NewClock(time.Now())
+	interval := time.Hour
+	p := agentbinarypruner.New(fakePruner, interval, testClock)
+	defer p.Kill()
+
+	for i := 0; i < 3; i++ {
+		select {
+		case <-testClock.Alarms():
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for worker to wait")
+		}
+		testClock.Advance(interval)
+		select {
+		case <-fakePruner.pruneCh:
+		case <-time.After(coretesting.LongWait):
+			c.Fatal("timed out waiting for pruning to happen")
+		}
+	}
+}
+
+func (s *AgentBinaryPrunerSuite) TestPruneError(c *gc.C) {
+	fakePruner := newFakeAgentBinaryPruner(errors.New("boom"))
+	testClock := testing.NewClock(time.Now())
+	p := agentbinarypruner.New(fakePruner, time.Hour, testClock)
+	defer p.Kill()
+
+	select {
+	case <-testClock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for worker to wait")
+	}
+	testClock.Advance(time.Hour)
+	<-fakePruner.pruneCh
+	c.Assert(p.Wait(), gc.ErrorMatches, "pruning failed, agent binary pruner stopping: boom")
+}
+
+func (s *AgentBinaryPrunerSuite) TestStops(c *gc.C) {
+	p := agentbinarypruner.New(newFakeAgentBinaryPruner(nil), time.Minute, clock.WallClock)
+	p.Kill()
+	c.Check(p.Wait(), jc.ErrorIsNil)
+}
+
+func newFakeAgentBinaryPruner(err error) *fakeAgentBinaryPruner {
+	return &fakeAgentBinaryPruner{
+		pruneCh: make(chan bool, 1),
+		err:     err,
+	}
+}
+
+type fakeAgentBinaryPruner struct {
+	pruneCh chan bool
+	err     error
+}
+
+// RemoveUnusedAgentBinaries implements the
+// agentbinarypruner.AgentBinaryPruner interface.
+func (p *fakeAgentBinaryPruner) RemoveUnusedAgentBinaries() error {
+	p.pruneCh <- true
+	return p.err
+}
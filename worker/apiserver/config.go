@@ -5,12 +5,17 @@ package apiserver
 
 import (
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/apiserver/authentication"
+	"github.com/juju/juju/apiserver/authentication/ldap"
+	"github.com/juju/juju/controller"
 )
 
 func getRateLimitConfig(cfg agent.Config) (apiserver.RateLimitConfig, error) {
@@ -135,3 +140,64 @@ func getLogSinkConfig(cfg agent.Config) (apiserver.LogSinkConfig, error) {
 	}
 	return result, nil
 }
+
+func getCallLimitConfig(cfg agent.Config) (apiserver.CallLimitConfig, error) {
+	result := apiserver.DefaultCallLimitConfig()
+	result.MgoStatsEnabled = cfg.Value(agent.MgoStatsEnabled) == "true"
+	if v := cfg.Value(agent.APICallTimeouts); v != "" {
+		for _, entry := range strings.Split(v, ",") {
+			parts := strings.SplitN(strings.TrimSpace(entry), "=", 2)
+			if len(parts) != 2 {
+				return result, errors.Errorf(
+					"parsing %s: expected name=duration, got %q", agent.APICallTimeouts, entry,
+				)
+			}
+			timeout, err := time.ParseDuration(parts[1])
+			if err != nil {
+				return result, errors.Annotatef(
+					err, "parsing %s", agent.APICallTimeouts,
+				)
+			}
+			result.Timeouts[parts[0]] = timeout
+		}
+	}
+	if v := cfg.Value(agent.APISlowCallThreshold); v != "" {
+		threshold, err := time.ParseDuration(v)
+		if err != nil {
+			return result, errors.Annotatef(
+				err, "parsing %s", agent.APISlowCallThreshold,
+			)
+		}
+		result.SlowCallThreshold = threshold
+	}
+	return result, nil
+}
+
+// getExternalAuthorizer returns the external authorizer configured for
+// the controller, or nil if there is none.
+func getExternalAuthorizer(cfg controller.Config, clock clock.Clock) (authentication.ExternalAuthorizer, error) {
+	if cfg.LDAPURL() == "" {
+		return nil, nil
+	}
+	source, err := ldap.NewGroupSource(ldap.ServerConfig{
+		URL:          cfg.LDAPURL(),
+		BindDN:       cfg.LDAPBindDN(),
+		BindPassword: cfg.LDAPBindPassword(),
+		BaseDN:       cfg.LDAPBaseDN(),
+		UserFilter:   cfg.LDAPUserFilter(),
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "configuring LDAP")
+	}
+	authorizer, err := ldap.NewAuthorizer(ldap.Config{
+		Source:      source,
+		Domain:      cfg.LDAPUserDomain(),
+		GroupAccess: cfg.LDAPGroupAccess(),
+		CacheTTL:    cfg.LDAPCacheTTL(),
+		Clock:       clock,
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "configuring LDAP")
+	}
+	return authorizer, nil
+}
@@ -7,6 +7,7 @@ import (
 	"crypto/tls"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/pubsub"
@@ -35,6 +36,12 @@ type ManifoldConfig struct {
 	RegisterIntrospectionHTTPHandlers func(func(path string, _ http.Handler))
 	Hub                               *pubsub.StructuredHub
 
+	// APIServerDrain is unlocked to request that the API server
+	// drains its connections, taking no longer than DrainTimeout,
+	// before the agent restarts.
+	APIServerDrain gate.Waiter
+	DrainTimeout   time.Duration
+
 	NewWorker func(Config) (worker.Worker, error)
 }
 
@@ -67,6 +74,12 @@ func (config ManifoldConfig) Validate() error {
 	if config.Hub == nil {
 		return errors.NotValidf("nil Hub")
 	}
+	if config.APIServerDrain == nil {
+		return errors.NotValidf("nil APIServerDrain")
+	}
+	if config.DrainTimeout <= 0 {
+		return errors.NotValidf("non-positive DrainTimeout")
+	}
 	if config.NewWorker == nil {
 		return errors.NotValidf("nil NewWorker")
 	}
@@ -94,6 +107,11 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 	if err := config.Validate(); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if config.APIServerDrain.IsUnlocked() {
+		// The API server has been drained because the agent is
+		// restarting; it must not accept connections again.
+		return nil, dependency.ErrMissing
+	}
 
 	var agent agent.Agent
 	if err := context.Get(config.AgentName, &agent); err != nil {
@@ -140,6 +158,8 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		Hub:                               config.Hub,
 		GetCertificate:                    getCertificate,
 		NewServer:                         newServerShim,
+		Drain:                             config.APIServerDrain.Unlocked(),
+		DrainTimeout:                      config.DrainTimeout,
 	})
 	if err != nil {
 		stTracker.Done()
@@ -39,6 +39,7 @@ type ManifoldSuite struct {
 	certWatcher          stubCertWatcher
 	hub                  pubsub.StructuredHub
 	upgradeGate          stubGateWaiter
+	drain                gate.Lock
 
 	stub testing.Stub
 }
@@ -54,6 +55,7 @@ func (s *ManifoldSuite) SetUpTest(c *gc.C) {
 	s.prometheusRegisterer = stubPrometheusRegisterer{}
 	s.certWatcher = stubCertWatcher{}
 	s.upgradeGate = stubGateWaiter{}
+	s.drain = gate.NewLock()
 	s.stub.ResetCalls()
 
 	s.context = s.newContext(nil)
@@ -66,8 +68,10 @@ func (s *ManifoldSuite) SetUpTest(c *gc.C) {
 		UpgradeGateName:                   "upgrade",
 		PrometheusRegisterer:              &s.prometheusRegisterer,
 		RegisterIntrospectionHTTPHandlers: func(func(string, http.Handler)) {},
-		Hub:       &s.hub,
-		NewWorker: s.newWorker,
+		APIServerDrain:                    s.drain,
+		DrainTimeout:                      time.Minute,
+		Hub:                               &s.hub,
+		NewWorker:                         s.newWorker,
 	})
 }
 
@@ -144,6 +148,9 @@ func (s *ManifoldSuite) TestStart(c *gc.C) {
 	c.Assert(config.RegisterIntrospectionHTTPHandlers, gc.NotNil)
 	config.RegisterIntrospectionHTTPHandlers = nil
 
+	c.Assert(config.Drain, gc.Equals, s.drain.Unlocked())
+	config.Drain = nil
+
 	// NewServer is hard-coded by the manifold to an internal shim.
 	c.Assert(config.NewServer, gc.NotNil)
 	config.NewServer = nil
@@ -154,9 +161,18 @@ func (s *ManifoldSuite) TestStart(c *gc.C) {
 		StatePool:            &s.state.pool,
 		PrometheusRegisterer: &s.prometheusRegisterer,
 		Hub:                  &s.hub,
+		DrainTimeout:         time.Minute,
 	})
 }
 
+func (s *ManifoldSuite) TestStartDrained(c *gc.C) {
+	s.drain.Unlock()
+	_, err := s.manifold.Start(s.context)
+	c.Assert(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+	s.stub.CheckNoCalls(c)
+	s.state.CheckNoCalls(c)
+}
+
 func (s *ManifoldSuite) TestStopWorkerClosesState(c *gc.C) {
 	w := s.startWorkerClean(c)
 	defer workertest.CleanKill(c, w)
@@ -8,6 +8,7 @@ import (
 	"net"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -35,6 +36,12 @@ type Config struct {
 	UpgradeComplete                   func() bool
 	GetCertificate                    func() *tls.Certificate
 	NewServer                         NewServerFunc
+
+	// Drain, if non-nil, is closed to request that the API server
+	// drains its connections, taking no longer than DrainTimeout,
+	// before exiting.
+	Drain        <-chan struct{}
+	DrainTimeout time.Duration
 }
 
 // NewServerFunc is the type of function that will be used
@@ -73,6 +80,9 @@ func (config Config) Validate() error {
 	if config.NewServer == nil {
 		return errors.NotValidf("nil NewServer")
 	}
+	if config.Drain != nil && config.DrainTimeout <= 0 {
+		return errors.NotValidf("non-positive DrainTimeout")
+	}
 	return nil
 }
 
@@ -98,11 +108,21 @@ func NewWorker(config Config) (worker.Worker, error) {
 		return nil, errors.Annotate(err, "getting log sink config")
 	}
 
+	callLimitConfig, err := getCallLimitConfig(config.AgentConfig)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting call limit config")
+	}
+
 	controllerConfig, err := config.StatePool.SystemState().ControllerConfig()
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot fetch the controller config")
 	}
 
+	externalAuthorizer, err := getExternalAuthorizer(controllerConfig, config.Clock)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	logDir := config.AgentConfig.LogDir()
 	observerFactory, err := newObserverFn(
 		config.AgentConfig,
@@ -126,11 +146,16 @@ func NewWorker(config Config) (worker.Worker, error) {
 		AutocertURL:                   controllerConfig.AutocertURL(),
 		AutocertDNSName:               controllerConfig.AutocertDNSName(),
 		AllowModelAccess:              controllerConfig.AllowModelAccess(),
+		StickyModelRouting:            controllerConfig.StickyModelRouting(),
 		NewObserver:                   observerFactory,
 		RegisterIntrospectionHandlers: config.RegisterIntrospectionHTTPHandlers,
 		RateLimitConfig:               rateLimitConfig,
 		LogSinkConfig:                 &logSinkConfig,
 		PrometheusRegisterer:          config.PrometheusRegisterer,
+		Drain:                         config.Drain,
+		DrainTimeout:                  config.DrainTimeout,
+		ExternalAuthorizer:            externalAuthorizer,
+		CallLimitConfig:               &callLimitConfig,
 	}
 
 	listener, err := net.Listen("tcp", listenAddr)
@@ -91,6 +91,7 @@ func (s *WorkerStateSuite) TestStart(c *gc.C) {
 
 	rateLimitConfig := coreapiserver.DefaultRateLimitConfig()
 	logSinkConfig := coreapiserver.DefaultLogSinkConfig()
+	callLimitConfig := coreapiserver.DefaultCallLimitConfig()
 
 	c.Assert(config, jc.DeepEquals, coreapiserver.ServerConfig{
 		Clock:                s.clock,
@@ -101,8 +102,10 @@ func (s *WorkerStateSuite) TestStart(c *gc.C) {
 		AutocertURL:          "",
 		AutocertDNSName:      "",
 		AllowModelAccess:     false,
+		StickyModelRouting:   false,
 		RateLimitConfig:      rateLimitConfig,
 		LogSinkConfig:        &logSinkConfig,
 		PrometheusRegisterer: &s.prometheusRegisterer,
+		CallLimitConfig:      &callLimitConfig,
 	})
 }
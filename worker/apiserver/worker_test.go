@@ -117,6 +117,9 @@ func (s *WorkerValidationSuite) TestValidateErrors(c *gc.C) {
 	}, {
 		func(cfg *apiserver.Config) { cfg.NewServer = nil },
 		"nil NewServer not valid",
+	}, {
+		func(cfg *apiserver.Config) { cfg.Drain = make(chan struct{}) },
+		"non-positive DrainTimeout not valid",
 	}}
 	for i, test := range tests {
 		c.Logf("test #%d (%s)", i, test.expect)
@@ -167,6 +170,18 @@ func (s *WorkerValidationSuite) TestValidateLogSinkConfig(c *gc.C) {
 	s.testValidateLogSinkConfig(c, agent.LogSinkRateLimitRefill, "foo", "parsing LOGSINK_RATELIMIT_REFILL: .*")
 }
 
+func (s *WorkerValidationSuite) TestValidateCallLimitConfig(c *gc.C) {
+	s.testValidateCallLimitConfig(c, agent.APICallTimeouts, "Client.FullStatus", "parsing API_CALL_TIMEOUTS: expected name=duration, got .*")
+	s.testValidateCallLimitConfig(c, agent.APICallTimeouts, "Client=foo", "parsing API_CALL_TIMEOUTS: .*")
+	s.testValidateCallLimitConfig(c, agent.APISlowCallThreshold, "foo", "parsing API_SLOW_CALL_THRESHOLD: .*")
+}
+
+func (s *WorkerValidationSuite) testValidateCallLimitConfig(c *gc.C, key, value, expect string) {
+	s.agentConfig.values = map[string]string{key: value}
+	_, err := apiserver.NewWorker(s.config)
+	c.Check(err, gc.ErrorMatches, "getting call limit config: "+expect)
+}
+
 func (s *WorkerValidationSuite) testValidateLogSinkConfig(c *gc.C, key, value, expect string) {
 	s.agentConfig.values = map[string]string{key: value}
 	_, err := apiserver.NewWorker(s.config)
@@ -0,0 +1,277 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package apiserverconfigurer provides a worker that applies the
+// configuration of the controller application to the API server of
+// the controller machine it runs on. It adds the configured extra
+// names to the API server's certificate, generates a new certificate
+// when the certificate generation is increased, and changes the port
+// on which the API server listens, restarting the agent so that the
+// new port is used.
+//
+// Each applied change is recorded in the controller application's
+// status, so the status history shows when the API server's
+// configuration changed.
+package apiserverconfigurer
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/cert"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/controllercharm"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+	jworker "github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.apiserverconfigurer")
+
+// Application defines the controller application functionality
+// required by the worker.
+type Application interface {
+	WatchCharmConfig() (state.NotifyWatcher, error)
+	CharmConfig() (charm.Settings, error)
+	Status() (status.StatusInfo, error)
+	SetStatus(status.StatusInfo) error
+}
+
+// Backend defines the state functionality required by the worker.
+type Backend interface {
+	ControllerConfig() (controller.Config, error)
+	StateServingInfo() (state.StateServingInfo, error)
+	SetStateServingInfo(state.StateServingInfo) error
+}
+
+// Config holds the configuration and dependencies for the worker.
+type Config struct {
+	Application Application
+	Backend     Backend
+	Agent       agent.Agent
+}
+
+// Validate returns an error if the config cannot be expected to
+// drive a functional worker.
+func (config Config) Validate() error {
+	if config.Application == nil {
+		return errors.NotValidf("nil Application")
+	}
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Agent == nil {
+		return errors.NotValidf("nil Agent")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that applies the controller
+// application's config to the API server whenever it changes.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &configurer{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type configurer struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *configurer) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *configurer) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *configurer) loop() error {
+	watcher, err := w.config.Application.WatchCharmConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watcher.Changes():
+			if !ok {
+				return errors.New("charm config watcher closed")
+			}
+			if err := w.apply(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// apply applies the controller application's config to the API
+// server. If the API server's port is changed, it returns
+// worker.ErrRestartAgent so that the new port is listened on.
+func (w *configurer) apply() error {
+	settings, err := w.config.Application.CharmConfig()
+	if err != nil {
+		return errors.Annotate(err, "reading controller application config")
+	}
+	cfg, err := controllercharm.ParseConfig(settings)
+	if err != nil {
+		// The config is left unapplied until it is corrected.
+		return w.setStatus(status.Blocked, fmt.Sprintf("invalid config: %v", err))
+	}
+
+	agentConfig := w.config.Agent.CurrentConfig()
+	info, ok := agentConfig.StateServingInfo()
+	if !ok {
+		return errors.New("no state serving info")
+	}
+	generation, _ := strconv.Atoi(agentConfig.Value(agent.APIServerCertGeneration))
+
+	restart := info.APIPort != cfg.APIPort
+	if restart {
+		if err := w.setStatePort(cfg.APIPort); err != nil {
+			return errors.Annotate(err, "updating state serving info")
+		}
+		logger.Infof("API server port changed from %d to %d", info.APIPort, cfg.APIPort)
+		info.APIPort = cfg.APIPort
+	}
+
+	rotate := cfg.CertGeneration > generation
+	missing, err := missingNames(info.Cert, cfg.ExtraSANs)
+	if err != nil {
+		return errors.Annotate(err, "reading API server certificate")
+	}
+	newCert := rotate || len(missing) > 0
+	if newCert {
+		if info.Cert, info.PrivateKey, err = w.newCertificate(info, cfg.ExtraSANs); err != nil {
+			return errors.Annotate(err, "generating API server certificate")
+		}
+		logger.Infof("API server certificate generated (generation %d, added names %q)", cfg.CertGeneration, missing)
+	}
+
+	if restart || newCert || cfg.CertGeneration != generation {
+		err := w.config.Agent.ChangeConfig(func(setter agent.ConfigSetter) error {
+			setter.SetStateServingInfo(info)
+			setter.SetValue(agent.APIServerCertGeneration, strconv.Itoa(cfg.CertGeneration))
+			return nil
+		})
+		if err != nil {
+			return errors.Annotate(err, "writing agent config")
+		}
+	}
+
+	message := fmt.Sprintf(
+		"api-port %d, %d extra names, certificate generation %d",
+		cfg.APIPort, len(cfg.ExtraSANs), cfg.CertGeneration,
+	)
+	if err := w.setStatus(status.Active, message); err != nil {
+		return errors.Trace(err)
+	}
+	if restart {
+		return jworker.ErrRestartAgent
+	}
+	return nil
+}
+
+// setStatePort records the API port in the state serving info held in
+// state, which is where controller agents read it from when they start.
+func (w *configurer) setStatePort(port int) error {
+	info, err := w.config.Backend.StateServingInfo()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if info.APIPort == port {
+		return nil
+	}
+	info.APIPort = port
+	return errors.Trace(w.config.Backend.SetStateServingInfo(info))
+}
+
+// newCertificate returns a new API server certificate and key, valid
+// for the names in the existing certificate and the extra names.
+func (w *configurer) newCertificate(info params.StateServingInfo, extraNames []string) (string, string, error) {
+	if info.CAPrivateKey == "" {
+		return "", "", errors.New("no CA certificate key")
+	}
+	names, err := certificateNames(info.Cert)
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	names = names.Union(set.NewStrings(extraNames...))
+
+	controllerConfig, err := w.config.Backend.ControllerConfig()
+	if err != nil {
+		return "", "", errors.Annotate(err, "reading controller config")
+	}
+	caCert, ok := controllerConfig.CACert()
+	if !ok {
+		return "", "", errors.New("controller config has no ca-cert")
+	}
+	return controller.GenerateControllerCertAndKey(caCert, info.CAPrivateKey, names.SortedValues())
+}
+
+// setStatus sets the controller application's status, unless it
+// already has the given status and message; the status history then
+// only records changes to the API server's configuration.
+func (w *configurer) setStatus(s status.Status, message string) error {
+	current, err := w.config.Application.Status()
+	if err != nil {
+		return errors.Annotate(err, "reading controller application status")
+	}
+	if current.Status == s && current.Message == message {
+		return nil
+	}
+	err = w.config.Application.SetStatus(status.StatusInfo{
+		Status:  s,
+		Message: message,
+	})
+	return errors.Annotate(err, "setting controller application status")
+}
+
+// certificateNames returns the DNS names and IP addresses for which
+// the given certificate is valid.
+func certificateNames(certPEM string) (set.Strings, error) {
+	x509Cert, err := cert.ParseCert(certPEM)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	names := set.NewStrings(x509Cert.DNSNames...)
+	for _, ip := range x509Cert.IPAddresses {
+		names.Add(ip.String())
+	}
+	return names, nil
+}
+
+// missingNames returns those of the given names for which the
+// certificate is not valid.
+func missingNames(certPEM string, names []string) ([]string, error) {
+	existing, err := certificateNames(certPEM)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return set.NewStrings(names...).Difference(existing).SortedValues(), nil
+}
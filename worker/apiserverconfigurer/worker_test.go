@@ -0,0 +1,321 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserverconfigurer_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/cert"
+	"github.com/juju/utils/set"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+	jworker "github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/apiserverconfigurer"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+
+	app     *mockApplication
+	backend *mockBackend
+	agent   *mockAgent
+	config  apiserverconfigurer.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.app = &mockApplication{
+		changes:   make(chan struct{}),
+		statusSet: make(chan status.StatusInfo, 1),
+		settings: charm.Settings{
+			"api-port":            int64(17070),
+			"api-extra-sans":      "",
+			"tls-cert-generation": int64(0),
+		},
+	}
+	s.backend = &mockBackend{
+		info: state.StateServingInfo{
+			APIPort:   17070,
+			StatePort: 37017,
+		},
+	}
+	s.agent = &mockAgent{
+		conf: mockAgentConfig{
+			info: params.StateServingInfo{
+				APIPort:      17070,
+				StatePort:    37017,
+				Cert:         coretesting.ServerCert,
+				PrivateKey:   coretesting.ServerKey,
+				CAPrivateKey: coretesting.CAKey,
+			},
+			values: make(map[string]string),
+		},
+	}
+	s.config = apiserverconfigurer.Config{
+		Application: s.app,
+		Backend:     s.backend,
+		Agent:       s.agent,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	s.testValidate(c, func(config *apiserverconfigurer.Config) {
+		config.Application = nil
+	}, "nil Application not valid")
+	s.testValidate(c, func(config *apiserverconfigurer.Config) {
+		config.Backend = nil
+	}, "nil Backend not valid")
+	s.testValidate(c, func(config *apiserverconfigurer.Config) {
+		config.Agent = nil
+	}, "nil Agent not valid")
+}
+
+func (s *WorkerSuite) testValidate(c *gc.C, f func(*apiserverconfigurer.Config), expect string) {
+	config := s.config
+	f(&config)
+	w, err := apiserverconfigurer.NewWorker(config)
+	if !c.Check(err, gc.NotNil) {
+		workertest.DirtyKill(c, w)
+		return
+	}
+	c.Check(w, gc.IsNil)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, expect)
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := apiserverconfigurer.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *WorkerSuite) sendChange(c *gc.C) {
+	select {
+	case s.app.changes <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out sending change")
+	}
+}
+
+func (s *WorkerSuite) assertStatus(c *gc.C, expect status.Status, message string) {
+	select {
+	case info := <-s.app.statusSet:
+		c.Assert(info.Status, gc.Equals, expect)
+		c.Assert(info.Message, gc.Equals, message)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for status")
+	}
+}
+
+func (s *WorkerSuite) TestNoChanges(c *gc.C) {
+	s.app.status = status.StatusInfo{
+		Status:  status.Active,
+		Message: "api-port 17070, 0 extra names, certificate generation 0",
+	}
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	// The second change can only be received once the
+	// first has been handled.
+	s.sendChange(c)
+	s.sendChange(c)
+	workertest.CleanKill(c, w)
+
+	s.app.CheckCallNames(c, "WatchCharmConfig", "CharmConfig", "Status", "CharmConfig", "Status")
+	s.agent.CheckNoCalls(c)
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *WorkerSuite) TestExtraSANs(c *gc.C) {
+	s.app.settings["api-extra-sans"] = "api.example.com 10.0.0.1"
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.sendChange(c)
+	s.assertStatus(c, status.Active, "api-port 17070, 2 extra names, certificate generation 0")
+	workertest.CheckAlive(c, w)
+
+	s.agent.CheckCallNames(c, "ChangeConfig")
+	x509Cert, err := cert.ParseCert(s.agent.conf.info.Cert)
+	c.Assert(err, jc.ErrorIsNil)
+	names := set.NewStrings(x509Cert.DNSNames...)
+	for _, ip := range x509Cert.IPAddresses {
+		names.Add(ip.String())
+	}
+	c.Assert(names.Contains("api.example.com"), jc.IsTrue)
+	c.Assert(names.Contains("10.0.0.1"), jc.IsTrue)
+	c.Assert(s.agent.conf.info.PrivateKey, gc.Not(gc.Equals), coretesting.ServerKey)
+	c.Assert(s.agent.conf.info.APIPort, gc.Equals, 17070)
+	s.backend.CheckCallNames(c, "ControllerConfig")
+}
+
+func (s *WorkerSuite) TestCertRotation(c *gc.C) {
+	s.agent.conf.values[agent.APIServerCertGeneration] = "1"
+	s.app.settings["tls-cert-generation"] = int64(2)
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.sendChange(c)
+	s.assertStatus(c, status.Active, "api-port 17070, 0 extra names, certificate generation 2")
+	workertest.CheckAlive(c, w)
+
+	c.Assert(s.agent.conf.info.Cert, gc.Not(gc.Equals), coretesting.ServerCert)
+	c.Assert(s.agent.conf.info.PrivateKey, gc.Not(gc.Equals), coretesting.ServerKey)
+	c.Assert(s.agent.conf.values[agent.APIServerCertGeneration], gc.Equals, "2")
+}
+
+func (s *WorkerSuite) TestCertRotationNoCAKey(c *gc.C) {
+	s.agent.conf.info.CAPrivateKey = ""
+	s.app.settings["tls-cert-generation"] = int64(1)
+	w := s.startWorker(c)
+	defer workertest.DirtyKill(c, w)
+
+	s.sendChange(c)
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "generating API server certificate: no CA certificate key")
+}
+
+func (s *WorkerSuite) TestAPIPortChangeRestartsAgent(c *gc.C) {
+	s.app.settings["api-port"] = int64(17071)
+	w := s.startWorker(c)
+	defer workertest.DirtyKill(c, w)
+
+	s.sendChange(c)
+	s.assertStatus(c, status.Active, "api-port 17071, 0 extra names, certificate generation 0")
+	err := workertest.CheckKilled(c, w)
+	c.Assert(errors.Cause(err), gc.Equals, jworker.ErrRestartAgent)
+
+	c.Assert(s.agent.conf.info.APIPort, gc.Equals, 17071)
+	c.Assert(s.agent.conf.info.Cert, gc.Equals, coretesting.ServerCert)
+	s.backend.CheckCallNames(c, "StateServingInfo", "SetStateServingInfo")
+	c.Assert(s.backend.info.APIPort, gc.Equals, 17071)
+}
+
+func (s *WorkerSuite) TestInvalidConfig(c *gc.C) {
+	s.app.settings["api-port"] = int64(0)
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.sendChange(c)
+	s.assertStatus(c, status.Blocked, "invalid config: api-port 0 not valid")
+	workertest.CheckAlive(c, w)
+	s.agent.CheckNoCalls(c)
+	s.backend.CheckNoCalls(c)
+}
+
+type mockApplication struct {
+	testing.Stub
+	settings  charm.Settings
+	status    status.StatusInfo
+	changes   chan struct{}
+	statusSet chan status.StatusInfo
+}
+
+func (a *mockApplication) WatchCharmConfig() (state.NotifyWatcher, error) {
+	a.MethodCall(a, "WatchCharmConfig")
+	if err := a.NextErr(); err != nil {
+		return nil, err
+	}
+	return statetesting.NewMockNotifyWatcher(a.changes), nil
+}
+
+func (a *mockApplication) CharmConfig() (charm.Settings, error) {
+	a.MethodCall(a, "CharmConfig")
+	return a.settings, a.NextErr()
+}
+
+func (a *mockApplication) Status() (status.StatusInfo, error) {
+	a.MethodCall(a, "Status")
+	return a.status, a.NextErr()
+}
+
+func (a *mockApplication) SetStatus(info status.StatusInfo) error {
+	a.MethodCall(a, "SetStatus", info)
+	a.status = info
+	a.statusSet <- info
+	return a.NextErr()
+}
+
+type mockBackend struct {
+	testing.Stub
+	info state.StateServingInfo
+}
+
+func (b *mockBackend) ControllerConfig() (controller.Config, error) {
+	b.MethodCall(b, "ControllerConfig")
+	return controller.Config{
+		controller.CACertKey: coretesting.CACert,
+	}, b.NextErr()
+}
+
+func (b *mockBackend) StateServingInfo() (state.StateServingInfo, error) {
+	b.MethodCall(b, "StateServingInfo")
+	return b.info, b.NextErr()
+}
+
+func (b *mockBackend) SetStateServingInfo(info state.StateServingInfo) error {
+	b.MethodCall(b, "SetStateServingInfo", info)
+	b.info = info
+	return b.NextErr()
+}
+
+type mockAgent struct {
+	agent.Agent
+	testing.Stub
+	conf mockAgentConfig
+}
+
+func (a *mockAgent) CurrentConfig() agent.Config {
+	return &a.conf
+}
+
+func (a *mockAgent) ChangeConfig(mutate agent.ConfigMutator) error {
+	a.MethodCall(a, "ChangeConfig")
+	if err := a.NextErr(); err != nil {
+		return err
+	}
+	return mutate(&mockConfigSetter{conf: &a.conf})
+}
+
+type mockAgentConfig struct {
+	agent.Config
+	info   params.StateServingInfo
+	values map[string]string
+}
+
+func (c *mockAgentConfig) StateServingInfo() (params.StateServingInfo, bool) {
+	return c.info, true
+}
+
+func (c *mockAgentConfig) Value(key string) string {
+	return c.values[key]
+}
+
+type mockConfigSetter struct {
+	agent.ConfigSetter
+	conf *mockAgentConfig
+}
+
+func (s *mockConfigSetter) SetStateServingInfo(info params.StateServingInfo) {
+	s.conf.info = info
+}
+
+func (s *mockConfigSetter) SetValue(key, value string) {
+	s.conf.values[key] = value
+}
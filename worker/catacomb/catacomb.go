@@ -4,6 +4,7 @@
 package catacomb
 
 import (
+	"context"
 	"fmt"
 	"runtime/debug"
 	"strings"
@@ -192,6 +193,21 @@ func (catacomb *Catacomb) Dying() <-chan struct{} {
 	return catacomb.tomb.Dying()
 }
 
+// Context returns a context derived from parent that is cancelled when
+// the catacomb starts dying, so that long-running calls made on behalf
+// of the catacomb's task are abandoned when the task is killed.
+func (catacomb *Catacomb) Context(parent context.Context) context.Context {
+	ctx, cancel := context.WithCancel(parent)
+	go func() {
+		select {
+		case <-catacomb.tomb.Dying():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx
+}
+
 // Dead returns a channel that will be closed when Invoke has completed (and
 // thus when subsequent calls to Wait() are known not to block).
 func (catacomb *Catacomb) Dead() <-chan struct{} {
@@ -4,7 +4,9 @@
 package catacomb_test
 
 import (
+	"context"
 	"sync"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/testing"
@@ -13,6 +15,7 @@ import (
 	worker "gopkg.in/juju/worker.v1"
 	"gopkg.in/tomb.v1"
 
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/catacomb"
 )
 
@@ -56,6 +59,34 @@ func (s *CatacombSuite) TestFinishTaskStopsCompletely(c *gc.C) {
 	s.fix.assertDead(c)
 }
 
+func (s *CatacombSuite) TestContextCancelledOnKill(c *gc.C) {
+	s.fix.run(c, func() {
+		ctx := s.fix.catacomb.Context(context.Background())
+		c.Check(ctx.Err(), jc.ErrorIsNil)
+		s.fix.catacomb.Kill(nil)
+		select {
+		case <-ctx.Done():
+			c.Check(ctx.Err(), gc.Equals, context.Canceled)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for context cancellation")
+		}
+	})
+}
+
+func (s *CatacombSuite) TestContextParentCancelled(c *gc.C) {
+	s.fix.run(c, func() {
+		parent, cancel := context.WithCancel(context.Background())
+		ctx := s.fix.catacomb.Context(parent)
+		cancel()
+		select {
+		case <-ctx.Done():
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for context cancellation")
+		}
+		s.fix.assertNotDying(c)
+	})
+}
+
 func (s *CatacombSuite) TestKillNil(c *gc.C) {
 	err := s.fix.run(c, func() {
 		s.fix.catacomb.Kill(nil)
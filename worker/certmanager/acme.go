@@ -0,0 +1,187 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package certmanager
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"net/http"
+	"os/exec"
+	"strings"
+
+	"github.com/juju/errors"
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+
+	"github.com/juju/juju/state"
+)
+
+// accountKeyName is the name under which the ACME account key is
+// stored in the autocert cache. It is distinct from the key used by
+// the API server's autocert manager, so the two never interfere.
+const accountKeyName = "dns01_account.key"
+
+// NewACMEIssuer returns an Issuer that obtains certificates from an
+// ACME CA using DNS-01 challenges. The ACME account key is kept in
+// the given cache, so that all controllers share one account.
+//
+// The DNS hook command configured for the CA is run with the
+// arguments "present <record-name> <value>" to publish each
+// challenge's TXT record, and "cleanup <record-name> <value>" to
+// remove it again.
+func NewACMEIssuer(cache autocert.Cache) Issuer {
+	return &acmeIssuer{cache: cache}
+}
+
+type acmeIssuer struct {
+	cache autocert.Cache
+}
+
+// Issue is part of the Issuer interface.
+func (i *acmeIssuer) Issue(ctx context.Context, config state.ACMEConfig) (string, string, error) {
+	key, err := i.accountKey(ctx)
+	if err != nil {
+		return "", "", errors.Annotate(err, "getting ACME account key")
+	}
+	client := &acme.Client{
+		Key:          key,
+		DirectoryURL: config.DirectoryURL,
+	}
+	account := &acme.Account{}
+	if config.Email != "" {
+		account.Contact = []string{"mailto:" + config.Email}
+	}
+	if _, err := client.Register(ctx, account, acme.AcceptTOS); err != nil {
+		// A conflict means the account is already registered.
+		if acmeErr, ok := err.(*acme.Error); !ok || acmeErr.StatusCode != http.StatusConflict {
+			return "", "", errors.Annotate(err, "registering ACME account")
+		}
+	}
+	for _, name := range config.DNSNames {
+		if err := authorize(ctx, client, name, config.DNSHook); err != nil {
+			return "", "", errors.Annotatef(err, "authorizing %q", name)
+		}
+	}
+
+	certKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: config.DNSNames[0]},
+		DNSNames: config.DNSNames,
+	}, certKey)
+	if err != nil {
+		return "", "", errors.Annotate(err, "creating certificate request")
+	}
+	der, _, err := client.CreateCert(ctx, csr, 0, true)
+	if err != nil {
+		return "", "", errors.Annotate(err, "creating certificate")
+	}
+	var chain []byte
+	for _, b := range der {
+		chain = append(chain, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: b})...)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(certKey)
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return string(chain), string(keyPEM), nil
+}
+
+// accountKey returns the ACME account key held in the cache,
+// generating and storing one if there is none.
+func (i *acmeIssuer) accountKey(ctx context.Context) (crypto.Signer, error) {
+	data, err := i.cache.Get(ctx, accountKeyName)
+	if err == nil {
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return nil, errors.New("invalid account key")
+		}
+		key, err := x509.ParseECPrivateKey(block.Bytes)
+		if err != nil {
+			return nil, errors.Annotate(err, "invalid account key")
+		}
+		return key, nil
+	}
+	if err != autocert.ErrCacheMiss {
+		return nil, errors.Trace(err)
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	data = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+	if err := i.cache.Put(ctx, accountKeyName, data); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return key, nil
+}
+
+// authorize proves control of the given name to the ACME CA by
+// publishing a DNS-01 challenge record with the DNS hook.
+func authorize(ctx context.Context, client *acme.Client, name, hook string) error {
+	authz, err := client.Authorize(ctx, name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if authz.Status == acme.StatusValid {
+		return nil
+	}
+	var challenge *acme.Challenge
+	for _, c := range authz.Challenges {
+		if c.Type == "dns-01" {
+			challenge = c
+			break
+		}
+	}
+	if challenge == nil {
+		return errors.NotSupportedf("dns-01 challenge")
+	}
+	value, err := client.DNS01ChallengeRecord(challenge.Token)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	record := "_acme-challenge." + strings.TrimSuffix(name, ".")
+	if err := runHook(ctx, hook, "present", record, value); err != nil {
+		return errors.Annotate(err, "publishing challenge record")
+	}
+	defer func() {
+		// The context may have been cancelled, but the record
+		// should still be removed.
+		if err := runHook(context.Background(), hook, "cleanup", record, value); err != nil {
+			logger.Warningf("cannot remove challenge record %q: %v", record, err)
+		}
+	}()
+	if _, err := client.Accept(ctx, challenge); err != nil {
+		return errors.Annotate(err, "accepting challenge")
+	}
+	if _, err := client.WaitAuthorization(ctx, authz.URI); err != nil {
+		return errors.Annotate(err, "waiting for authorization")
+	}
+	return nil
+}
+
+// runHook runs the DNS hook command with the given arguments.
+func runHook(ctx context.Context, hook string, args ...string) error {
+	out, err := exec.CommandContext(ctx, hook, args...).CombinedOutput()
+	if err != nil {
+		if len(out) > 0 {
+			return errors.Annotatef(err, "running %s: %s", hook, strings.TrimSpace(string(out)))
+		}
+		return errors.Annotatef(err, "running %s", hook)
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package certmanager provides a worker that obtains certificates for
+// the controller's API servers from an ACME CA, using DNS-01
+// challenges, and renews them before they expire. The certificates
+// are recorded in state, from where every API server picks them up
+// for new connections.
+//
+// No certificates are obtained while an externally issued certificate
+// chain is set, as that takes precedence.
+package certmanager
+
+import (
+	"context"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	utilscert "github.com/juju/utils/cert"
+	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/cert"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.certmanager")
+
+// Backend defines the state functionality required by the worker.
+type Backend interface {
+	ACMEConfig() (state.ACMEConfig, error)
+	WatchACMEConfig() state.NotifyWatcher
+	APICertificate() (state.APICertificate, error)
+	SetAPICertificate(state.APICertificate) error
+	WatchAPICertificate() state.NotifyWatcher
+}
+
+// Issuer obtains certificates from an ACME CA.
+type Issuer interface {
+	// Issue obtains a certificate valid for the names in the given
+	// config, returning the PEM-encoded certificate chain and
+	// private key.
+	Issue(ctx context.Context, config state.ACMEConfig) (certChain, privateKey string, _ error)
+}
+
+// Config holds the configuration and dependencies for the worker.
+type Config struct {
+	Backend Backend
+	Issuer  Issuer
+	Clock   clock.Clock
+
+	// RetryDelay is how long the worker waits before trying again
+	// when it fails to obtain a certificate.
+	RetryDelay time.Duration
+}
+
+// Validate returns an error if the config cannot be expected to
+// drive a functional worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Issuer == nil {
+		return errors.NotValidf("nil Issuer")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.RetryDelay <= 0 {
+		return errors.NotValidf("non-positive RetryDelay")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that keeps the API certificate obtained
+// from the configured ACME CA up to date.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &manager{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type manager struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *manager) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *manager) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *manager) loop() error {
+	configWatcher := w.config.Backend.WatchACMEConfig()
+	if err := w.catacomb.Add(configWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	certWatcher := w.config.Backend.WatchAPICertificate()
+	if err := w.catacomb.Add(certWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	var timer <-chan time.Time
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-configWatcher.Changes():
+			if !ok {
+				return errors.New("ACME config watcher closed")
+			}
+		case _, ok := <-certWatcher.Changes():
+			if !ok {
+				return errors.New("API certificate watcher closed")
+			}
+		case <-timer:
+		}
+		next, err := w.check()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		timer = nil
+		if next > 0 {
+			timer = w.config.Clock.After(next)
+		}
+	}
+}
+
+// check obtains a new certificate if one is required, and returns
+// how long to wait before checking again. If it returns zero, no
+// check is needed until the config or certificate change.
+func (w *manager) check() (time.Duration, error) {
+	config, err := w.config.Backend.ACMEConfig()
+	if errors.IsNotFound(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, errors.Trace(err)
+	}
+	renew, err := w.renewTime(config)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if renew.IsZero() {
+		return 0, nil
+	}
+	if now := w.config.Clock.Now(); now.Before(renew) {
+		return renew.Sub(now), nil
+	}
+
+	logger.Infof("obtaining certificate for %q from %s", config.DNSNames, config.DirectoryURL)
+	certChain, privateKey, err := w.config.Issuer.Issue(w.catacomb.Context(context.Background()), config)
+	if err == nil {
+		err = w.setCertificate(certChain, privateKey)
+	}
+	if err != nil {
+		select {
+		case <-w.catacomb.Dying():
+			return 0, w.catacomb.ErrDying()
+		default:
+		}
+		logger.Errorf("cannot obtain certificate for %q (retrying in %v): %v", config.DNSNames, w.config.RetryDelay, err)
+		return w.config.RetryDelay, nil
+	}
+	// The certificate watcher will trigger another check, which
+	// schedules the renewal.
+	return 0, nil
+}
+
+// renewTime returns the time at which a new certificate should be
+// obtained: now, if there is no suitable certificate from the ACME
+// CA, or otherwise the configured time before the current one
+// expires. It returns the zero time if the API certificate is not
+// managed by the worker.
+func (w *manager) renewTime(config state.ACMEConfig) (time.Time, error) {
+	now := w.config.Clock.Now()
+	current, err := w.config.Backend.APICertificate()
+	if errors.IsNotFound(err) {
+		return now, nil
+	} else if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	if current.Source != state.APICertificateACME {
+		logger.Debugf("not obtaining certificate: %s certificate is set", current.Source)
+		return time.Time{}, nil
+	}
+	leaf, err := utilscert.ParseCert(current.CertChain)
+	if err != nil {
+		logger.Warningf("replacing unreadable certificate: %v", err)
+		return now, nil
+	}
+	if !sameNames(leaf.DNSNames, config.DNSNames) {
+		logger.Infof("replacing certificate for %q", leaf.DNSNames)
+		return now, nil
+	}
+	return current.Expiry.Add(-config.RenewBefore), nil
+}
+
+// setCertificate records a newly obtained certificate in state, unless
+// an external certificate was set while it was being obtained.
+func (w *manager) setCertificate(certChain, privateKey string) error {
+	now := w.config.Clock.Now()
+	tlsCert, err := cert.ParseServerChain(certChain, privateKey, now)
+	if err != nil {
+		return errors.Annotate(err, "invalid certificate issued")
+	}
+	current, err := w.config.Backend.APICertificate()
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	} else if err == nil && current.Source != state.APICertificateACME {
+		logger.Infof("discarding certificate: %s certificate was set", current.Source)
+		return nil
+	}
+	err = w.config.Backend.SetAPICertificate(state.APICertificate{
+		Source:     state.APICertificateACME,
+		CertChain:  certChain,
+		PrivateKey: privateKey,
+		Expiry:     tlsCert.Leaf.NotAfter,
+		Updated:    now,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("obtained certificate for %q, expiring %s", tlsCert.Leaf.DNSNames, tlsCert.Leaf.NotAfter)
+	return nil
+}
+
+// sameNames reports whether the two slices hold the same names,
+// ignoring order and duplicates.
+func sameNames(a, b []string) bool {
+	as, bs := set.NewStrings(a...), set.NewStrings(b...)
+	return as.Difference(bs).IsEmpty() && bs.Difference(as).IsEmpty()
+}
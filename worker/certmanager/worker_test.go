@@ -0,0 +1,293 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package certmanager_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/cert"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/certmanager"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+
+	clock   *testing.Clock
+	backend *mockBackend
+	issuer  *mockIssuer
+	config  certmanager.Config
+
+	caCert, caKey string
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Now())
+
+	var err error
+	s.caCert, s.caKey, err = cert.NewCA("acme", "1", s.clock.Now().AddDate(1, 0, 0))
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.backend = &mockBackend{
+		configChanges: make(chan struct{}),
+		certChanges:   make(chan struct{}),
+		acmeConfig: &state.ACMEConfig{
+			DirectoryURL: "https://acme.example.com/directory",
+			DNSNames:     []string{"api.example.com"},
+			DNSHook:      "/usr/local/bin/update-dns",
+			RenewBefore:  30 * 24 * time.Hour,
+		},
+	}
+	s.issuer = &mockIssuer{issued: make(chan state.ACMEConfig, 1)}
+	s.issuer.certChain, s.issuer.privateKey = s.newCert(c, 90*24*time.Hour, "api.example.com")
+	s.config = certmanager.Config{
+		Backend:    s.backend,
+		Issuer:     s.issuer,
+		Clock:      s.clock,
+		RetryDelay: time.Minute,
+	}
+}
+
+func (s *WorkerSuite) newCert(c *gc.C, validFor time.Duration, names ...string) (string, string) {
+	certPEM, keyPEM, err := cert.NewServer(s.caCert, s.caKey, s.clock.Now().Add(validFor), names)
+	c.Assert(err, jc.ErrorIsNil)
+	return certPEM, keyPEM
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	s.testValidate(c, func(config *certmanager.Config) {
+		config.Backend = nil
+	}, "nil Backend not valid")
+	s.testValidate(c, func(config *certmanager.Config) {
+		config.Issuer = nil
+	}, "nil Issuer not valid")
+	s.testValidate(c, func(config *certmanager.Config) {
+		config.Clock = nil
+	}, "nil Clock not valid")
+	s.testValidate(c, func(config *certmanager.Config) {
+		config.RetryDelay = 0
+	}, "non-positive RetryDelay not valid")
+}
+
+func (s *WorkerSuite) testValidate(c *gc.C, f func(*certmanager.Config), expect string) {
+	config := s.config
+	f(&config)
+	w, err := certmanager.NewWorker(config)
+	if !c.Check(err, gc.NotNil) {
+		workertest.DirtyKill(c, w)
+		return
+	}
+	c.Check(w, gc.IsNil)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, expect)
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := certmanager.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *WorkerSuite) sendChange(c *gc.C, ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out sending change")
+	}
+}
+
+func (s *WorkerSuite) assertIssued(c *gc.C) {
+	select {
+	case config := <-s.issuer.issued:
+		c.Assert(config, jc.DeepEquals, *s.backend.acmeConfig)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for certificate to be issued")
+	}
+}
+
+func (s *WorkerSuite) assertNotIssued(c *gc.C) {
+	select {
+	case <-s.issuer.issued:
+		c.Fatalf("unexpected certificate issued")
+	default:
+	}
+}
+
+func (s *WorkerSuite) TestNoACMEConfig(c *gc.C) {
+	s.backend.acmeConfig = nil
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	// The second change can only be received once the
+	// first has been handled.
+	s.sendChange(c, s.backend.configChanges)
+	s.sendChange(c, s.backend.certChanges)
+	workertest.CleanKill(c, w)
+	s.issuer.CheckNoCalls(c)
+}
+
+func (s *WorkerSuite) TestIssuesCertificate(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.sendChange(c, s.backend.configChanges)
+	s.assertIssued(c)
+	s.sendChange(c, s.backend.certChanges)
+	workertest.CleanKill(c, w)
+
+	s.backend.CheckCallNames(c,
+		"WatchACMEConfig", "WatchAPICertificate",
+		"ACMEConfig", "APICertificate", "APICertificate", "SetAPICertificate",
+		"ACMEConfig", "APICertificate",
+	)
+	apiCert := s.backend.apiCert
+	c.Assert(apiCert.Source, gc.Equals, state.APICertificateACME)
+	c.Assert(apiCert.CertChain, gc.Equals, s.issuer.certChain)
+	c.Assert(apiCert.PrivateKey, gc.Equals, s.issuer.privateKey)
+	c.Assert(apiCert.Updated, gc.Equals, s.clock.Now())
+}
+
+func (s *WorkerSuite) TestExternalCertificateTakesPrecedence(c *gc.C) {
+	certChain, privateKey := s.newCert(c, time.Hour, "api.example.com")
+	s.backend.apiCert = &state.APICertificate{
+		Source:     state.APICertificateExternal,
+		CertChain:  certChain,
+		PrivateKey: privateKey,
+		Expiry:     s.clock.Now().Add(time.Hour),
+	}
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.sendChange(c, s.backend.configChanges)
+	s.sendChange(c, s.backend.certChanges)
+	workertest.CleanKill(c, w)
+	s.issuer.CheckNoCalls(c)
+}
+
+func (s *WorkerSuite) TestRenewsBeforeExpiry(c *gc.C) {
+	certChain, privateKey := s.newCert(c, 60*24*time.Hour, "api.example.com")
+	s.backend.apiCert = &state.APICertificate{
+		Source:     state.APICertificateACME,
+		CertChain:  certChain,
+		PrivateKey: privateKey,
+		Expiry:     s.clock.Now().Add(60 * 24 * time.Hour),
+	}
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.sendChange(c, s.backend.configChanges)
+	err := s.clock.WaitAdvance(30*24*time.Hour-time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNotIssued(c)
+
+	s.clock.Advance(time.Second)
+	s.assertIssued(c)
+}
+
+func (s *WorkerSuite) TestReplacesCertificateForOtherNames(c *gc.C) {
+	certChain, privateKey := s.newCert(c, 60*24*time.Hour, "old.example.com")
+	s.backend.apiCert = &state.APICertificate{
+		Source:     state.APICertificateACME,
+		CertChain:  certChain,
+		PrivateKey: privateKey,
+		Expiry:     s.clock.Now().Add(60 * 24 * time.Hour),
+	}
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.sendChange(c, s.backend.configChanges)
+	s.assertIssued(c)
+}
+
+func (s *WorkerSuite) TestRetriesAfterFailure(c *gc.C) {
+	s.issuer.SetErrors(errors.New("rate limited"))
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.sendChange(c, s.backend.configChanges)
+	s.assertIssued(c)
+	c.Assert(s.backend.apiCert, gc.IsNil)
+
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertIssued(c)
+	s.sendChange(c, s.backend.certChanges)
+	workertest.CleanKill(c, w)
+	c.Assert(s.backend.apiCert, gc.NotNil)
+}
+
+type mockBackend struct {
+	testing.Stub
+	configChanges chan struct{}
+	certChanges   chan struct{}
+	acmeConfig    *state.ACMEConfig
+	apiCert       *state.APICertificate
+}
+
+func (b *mockBackend) ACMEConfig() (state.ACMEConfig, error) {
+	b.MethodCall(b, "ACMEConfig")
+	if err := b.NextErr(); err != nil {
+		return state.ACMEConfig{}, err
+	}
+	if b.acmeConfig == nil {
+		return state.ACMEConfig{}, errors.NotFoundf("ACME config")
+	}
+	return *b.acmeConfig, nil
+}
+
+func (b *mockBackend) WatchACMEConfig() state.NotifyWatcher {
+	b.MethodCall(b, "WatchACMEConfig")
+	return statetesting.NewMockNotifyWatcher(b.configChanges)
+}
+
+func (b *mockBackend) APICertificate() (state.APICertificate, error) {
+	b.MethodCall(b, "APICertificate")
+	if err := b.NextErr(); err != nil {
+		return state.APICertificate{}, err
+	}
+	if b.apiCert == nil {
+		return state.APICertificate{}, errors.NotFoundf("API certificate")
+	}
+	return *b.apiCert, nil
+}
+
+func (b *mockBackend) SetAPICertificate(c state.APICertificate) error {
+	b.MethodCall(b, "SetAPICertificate", c)
+	b.apiCert = &c
+	return b.NextErr()
+}
+
+func (b *mockBackend) WatchAPICertificate() state.NotifyWatcher {
+	b.MethodCall(b, "WatchAPICertificate")
+	return statetesting.NewMockNotifyWatcher(b.certChanges)
+}
+
+type mockIssuer struct {
+	testing.Stub
+	certChain  string
+	privateKey string
+	issued     chan state.ACMEConfig
+}
+
+func (i *mockIssuer) Issue(ctx context.Context, config state.ACMEConfig) (string, string, error) {
+	i.MethodCall(i, "Issue", config)
+	defer func() { i.issued <- config }()
+	if err := i.NextErr(); err != nil {
+		return "", "", err
+	}
+	return i.certChain, i.privateKey, nil
+}
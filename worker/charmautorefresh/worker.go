@@ -0,0 +1,127 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package charmautorefresh provides a worker that applies the charm
+// upgrades recorded, by the charm revision updater, for applications
+// with a refresh policy. Upgrades are only applied within the model's
+// maintenance windows; if the model has none, they are applied as soon
+// as they are found.
+package charmautorefresh
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/charmautorefresh"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.charmautorefresh")
+
+// Facade defines the interface we require from the charm auto-refresh
+// facade.
+type Facade interface {
+	MaintenanceWindowOpen() (bool, error)
+	PendingUpgrades() ([]charmautorefresh.Upgrade, error)
+	ApplyUpgrade(application string) error
+}
+
+// Config holds the configuration and dependencies for a charm
+// auto-refresh worker.
+type Config struct {
+	Facade   Facade
+	Clock    clock.Clock
+	Interval time.Duration
+}
+
+// Validate returns an error if the config cannot be expected to
+// drive a functional charm auto-refresh worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that applies pending charm upgrades every
+// configured interval that falls within the model's maintenance
+// windows.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &refresher{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type refresher struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *refresher) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *refresher) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *refresher) loop() error {
+	timer := w.config.Clock.NewTimer(w.config.Interval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-timer.Chan():
+			if err := w.refresh(); err != nil {
+				return errors.Annotate(err, "refreshing charms")
+			}
+			timer.Reset(w.config.Interval)
+		}
+	}
+}
+
+func (w *refresher) refresh() error {
+	open, err := w.config.Facade.MaintenanceWindowOpen()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !open {
+		return nil
+	}
+	upgrades, err := w.config.Facade.PendingUpgrades()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, upgrade := range upgrades {
+		// A failed upgrade is only logged, so that it does not
+		// hold up those of other applications.
+		if err := w.config.Facade.ApplyUpgrade(upgrade.Application); err != nil {
+			logger.Errorf("cannot refresh %q to %s: %v", upgrade.Application, upgrade.CharmURL, err)
+			continue
+		}
+		logger.Infof("refreshed %q to %s under %s policy", upgrade.Application, upgrade.CharmURL, upgrade.Policy)
+	}
+	return nil
+}
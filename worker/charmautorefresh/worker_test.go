@@ -0,0 +1,174 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmautorefresh_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/charmautorefresh"
+	"github.com/juju/juju/core/charmrefresh"
+	coretesting "github.com/juju/juju/testing"
+	workercharmautorefresh "github.com/juju/juju/worker/charmautorefresh"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+
+	clock  *testing.Clock
+	facade *mockFacade
+	config workercharmautorefresh.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2018, 3, 3, 1, 50, 0, 0, time.UTC))
+	s.facade = &mockFacade{
+		open: true,
+		upgrades: []charmautorefresh.Upgrade{{
+			Application: "mysql",
+			CharmURL:    charm.MustParseURL("cs:xenial/mysql-2"),
+			Policy:      charmrefresh.Any,
+		}, {
+			Application: "wordpress",
+			CharmURL:    charm.MustParseURL("cs:xenial/wordpress-5"),
+			Policy:      charmrefresh.PatchOnly,
+		}},
+		calls: make(chan string, 10),
+	}
+	s.config = workercharmautorefresh.Config{
+		Facade:   s.facade,
+		Clock:    s.clock,
+		Interval: 10 * time.Minute,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	s.testValidate(c, func(cfg *workercharmautorefresh.Config) {
+		cfg.Facade = nil
+	}, "nil Facade not valid")
+	s.testValidate(c, func(cfg *workercharmautorefresh.Config) {
+		cfg.Clock = nil
+	}, "nil Clock not valid")
+	s.testValidate(c, func(cfg *workercharmautorefresh.Config) {
+		cfg.Interval = 0
+	}, "non-positive Interval not valid")
+}
+
+func (s *WorkerSuite) testValidate(c *gc.C, f func(*workercharmautorefresh.Config), expect string) {
+	config := s.config
+	f(&config)
+	w, err := workercharmautorefresh.NewWorker(config)
+	if !c.Check(err, gc.NotNil) {
+		workertest.DirtyKill(c, w)
+		return
+	}
+	c.Check(w, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, expect)
+}
+
+func (s *WorkerSuite) TestAppliesUpgradesInWindow(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.advance(c)
+	s.expectCalls(c, "MaintenanceWindowOpen", "PendingUpgrades", "ApplyUpgrade mysql", "ApplyUpgrade wordpress")
+}
+
+func (s *WorkerSuite) TestSkipsOutsideWindow(c *gc.C) {
+	s.facade.open = false
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.advance(c)
+	s.expectCalls(c, "MaintenanceWindowOpen")
+	// The worker waits for the next interval without applying
+	// anything.
+	err := s.clock.WaitAdvance(0, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.expectNoCalls(c)
+}
+
+func (s *WorkerSuite) TestApplyUpgradeErrorIsNotFatal(c *gc.C) {
+	s.facade.SetErrors(nil, nil, errors.New("kaboom"))
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.advance(c)
+	s.expectCalls(c, "MaintenanceWindowOpen", "PendingUpgrades", "ApplyUpgrade mysql", "ApplyUpgrade wordpress")
+}
+
+func (s *WorkerSuite) TestFacadeError(c *gc.C) {
+	s.facade.SetErrors(errors.New("kaboom"))
+	w := s.startWorker(c)
+	defer workertest.DirtyKill(c, w)
+
+	s.advance(c)
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "refreshing charms: kaboom")
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := workercharmautorefresh.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *WorkerSuite) advance(c *gc.C) {
+	err := s.clock.WaitAdvance(10*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *WorkerSuite) expectCalls(c *gc.C, expect ...string) {
+	for _, name := range expect {
+		select {
+		case call := <-s.facade.calls:
+			c.Assert(call, gc.Equals, name)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for %s", name)
+		}
+	}
+}
+
+func (s *WorkerSuite) expectNoCalls(c *gc.C) {
+	select {
+	case call := <-s.facade.calls:
+		c.Fatalf("unexpected call %s", call)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type mockFacade struct {
+	testing.Stub
+	open     bool
+	upgrades []charmautorefresh.Upgrade
+	calls    chan string
+}
+
+func (f *mockFacade) MaintenanceWindowOpen() (bool, error) {
+	f.MethodCall(f, "MaintenanceWindowOpen")
+	f.calls <- "MaintenanceWindowOpen"
+	return f.open, f.NextErr()
+}
+
+func (f *mockFacade) PendingUpgrades() ([]charmautorefresh.Upgrade, error) {
+	f.MethodCall(f, "PendingUpgrades")
+	f.calls <- "PendingUpgrades"
+	return f.upgrades, f.NextErr()
+}
+
+func (f *mockFacade) ApplyUpgrade(application string) error {
+	f.MethodCall(f, "ApplyUpgrade", application)
+	f.calls <- "ApplyUpgrade " + application
+	return f.NextErr()
+}
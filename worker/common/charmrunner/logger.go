@@ -23,6 +23,15 @@ func NewHookLogger(logger loggo.Logger, outReader io.ReadCloser) *HookLogger {
 	}
 }
 
+// NewCappedHookLogger creates a new hook logger that logs only as much
+// of the hook's output as the given CappedOutput retains in memory,
+// leaving the rest to be spooled by it.
+func NewCappedHookLogger(logger loggo.Logger, outReader io.ReadCloser, output *CappedOutput) *HookLogger {
+	l := NewHookLogger(logger, outReader)
+	l.output = output
+	return l
+}
+
 // HookLogger streams the output from a hook to a logger.
 type HookLogger struct {
 	r       io.ReadCloser
@@ -30,6 +39,11 @@ type HookLogger struct {
 	mu      sync.Mutex
 	stopped bool
 	logger  loggo.Logger
+
+	// output, if not nil, receives all the hook's output; lines are
+	// logged only until it stops retaining them.
+	output    *CappedOutput
+	truncated bool
 }
 
 // Run starts the hook logger.
@@ -50,6 +64,18 @@ func (l *HookLogger) Run() {
 			l.mu.Unlock()
 			return
 		}
+		if l.output != nil {
+			l.output.Write(line)
+			l.output.Write([]byte("\n"))
+			if l.output.Truncated() {
+				if !l.truncated {
+					l.logger.Warningf("hook output exceeds %d bytes; no longer logging it", len(l.output.Bytes()))
+					l.truncated = true
+				}
+				l.mu.Unlock()
+				continue
+			}
+		}
 		l.logger.Debugf("%s", line)
 		l.mu.Unlock()
 	}
@@ -0,0 +1,251 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrunner
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+const (
+	// DefaultMaxOutputMemory is the default number of bytes of a
+	// hook's or hook tool's output that is retained in memory.
+	DefaultMaxOutputMemory = 4 * 1024 * 1024
+
+	// DefaultMaxSpoolFileSize is the default size in bytes at which
+	// a spool file is rotated.
+	DefaultMaxSpoolFileSize = 64 * 1024 * 1024
+
+	// DefaultMaxSpoolFiles is the default number of spool files kept
+	// for each kind of output.
+	DefaultMaxSpoolFiles = 4
+)
+
+// spoolTimeFormat names spool files so that they sort in the order in
+// which they were created.
+const spoolTimeFormat = "20060102T150405.000000000"
+
+// OutputLimits bounds the output of hooks and hook tools that is
+// retained in memory, and the output beyond that which is spooled to
+// files.
+type OutputLimits struct {
+	// MaxMemory is the number of bytes of output retained in memory.
+	// If zero, DefaultMaxOutputMemory is used.
+	MaxMemory int
+
+	// SpoolDir is the directory to which output beyond MaxMemory is
+	// written. If empty, the output is discarded.
+	SpoolDir string
+
+	// MaxSpoolFileSize is the size in bytes at which a spool file is
+	// rotated. If zero, DefaultMaxSpoolFileSize is used.
+	MaxSpoolFileSize int64
+
+	// MaxSpoolFiles is the number of spool files kept for each kind of
+	// output; older files are removed. If zero,
+	// DefaultMaxSpoolFiles is used.
+	MaxSpoolFiles int
+}
+
+// Validate returns an error if the limits are not valid.
+func (l OutputLimits) Validate() error {
+	if l.MaxMemory < 0 {
+		return errors.NotValidf("negative MaxMemory")
+	}
+	if l.MaxSpoolFileSize < 0 {
+		return errors.NotValidf("negative MaxSpoolFileSize")
+	}
+	if l.MaxSpoolFiles < 0 {
+		return errors.NotValidf("negative MaxSpoolFiles")
+	}
+	return nil
+}
+
+func (l OutputLimits) withDefaults() OutputLimits {
+	if l.MaxMemory <= 0 {
+		l.MaxMemory = DefaultMaxOutputMemory
+	}
+	if l.MaxSpoolFileSize <= 0 {
+		l.MaxSpoolFileSize = DefaultMaxSpoolFileSize
+	}
+	if l.MaxSpoolFiles <= 0 {
+		l.MaxSpoolFiles = DefaultMaxSpoolFiles
+	}
+	return l
+}
+
+// CappedOutput is an io.Writer that retains the first bytes written to
+// it in memory, up to a limit, and spools the rest to files, so that a
+// charm writing a great deal of output cannot exhaust the agent's
+// memory. Writes never fail; output that cannot be spooled is
+// discarded, and counted.
+type CappedOutput struct {
+	prefix string
+	limits OutputLimits
+
+	buf       bytes.Buffer
+	spool     *os.File
+	spoolSize int64
+	spooled   int64
+	discarded int64
+	files     []string
+}
+
+// NewCappedOutput returns a CappedOutput with the given limits, whose
+// spool files are named with the given prefix.
+func NewCappedOutput(prefix string, limits OutputLimits) *CappedOutput {
+	return &CappedOutput{
+		prefix: prefix,
+		limits: limits.withDefaults(),
+	}
+}
+
+// Write is part of the io.Writer interface.
+func (o *CappedOutput) Write(p []byte) (int, error) {
+	n := len(p)
+	if room := o.limits.MaxMemory - o.buf.Len(); room > 0 {
+		if room > len(p) {
+			room = len(p)
+		}
+		o.buf.Write(p[:room])
+		p = p[room:]
+	}
+	for len(p) > 0 {
+		if o.limits.SpoolDir == "" {
+			o.discarded += int64(len(p))
+			break
+		}
+		if err := o.ensureSpool(); err != nil {
+			// Give up spooling, discarding this and any later
+			// output, rather than failing the writer.
+			logger.Warningf("cannot spool %s output: %v", o.prefix, err)
+			o.discarded += int64(len(p))
+			o.limits.SpoolDir = ""
+			break
+		}
+		chunk := p
+		if room := o.limits.MaxSpoolFileSize - o.spoolSize; int64(len(chunk)) > room {
+			chunk = chunk[:room]
+		}
+		written, err := o.spool.Write(chunk)
+		o.spoolSize += int64(written)
+		o.spooled += int64(written)
+		p = p[written:]
+		if err != nil {
+			logger.Warningf("cannot spool %s output: %v", o.prefix, err)
+			o.discarded += int64(len(p))
+			o.closeSpool()
+			o.limits.SpoolDir = ""
+			break
+		}
+	}
+	return n, nil
+}
+
+// ensureSpool makes sure there is a spool file with room for more
+// output, rotating the current one if it is full.
+func (o *CappedOutput) ensureSpool() error {
+	if o.spool != nil && o.spoolSize < o.limits.MaxSpoolFileSize {
+		return nil
+	}
+	o.closeSpool()
+	if err := os.MkdirAll(o.limits.SpoolDir, 0700); err != nil {
+		return errors.Trace(err)
+	}
+	// Make room for the new file among those already spooled.
+	if err := pruneSpoolFiles(o.limits.SpoolDir, o.prefix, o.limits.MaxSpoolFiles-1); err != nil {
+		return errors.Trace(err)
+	}
+	now := time.Now().UTC()
+	for {
+		path := filepath.Join(o.limits.SpoolDir, fmt.Sprintf("%s-%s.log", o.prefix, now.Format(spoolTimeFormat)))
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if os.IsExist(err) {
+			now = now.Add(time.Nanosecond)
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		o.spool = f
+		o.spoolSize = 0
+		o.files = append(o.files, path)
+		return nil
+	}
+}
+
+func (o *CappedOutput) closeSpool() {
+	if o.spool == nil {
+		return
+	}
+	if err := o.spool.Close(); err != nil {
+		logger.Warningf("cannot close %s spool file: %v", o.prefix, err)
+	}
+	o.spool = nil
+}
+
+// pruneSpoolFiles removes the oldest of the spool files in dir with the
+// given prefix, leaving at most keep of them.
+func pruneSpoolFiles(dir, prefix string, keep int) error {
+	paths, err := filepath.Glob(filepath.Join(dir, prefix+"-*.log"))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	sort.Strings(paths)
+	for len(paths) > keep {
+		if err := os.Remove(paths[0]); err != nil && !os.IsNotExist(err) {
+			return errors.Trace(err)
+		}
+		paths = paths[1:]
+	}
+	return nil
+}
+
+// Close closes the current spool file, if any.
+func (o *CappedOutput) Close() error {
+	o.closeSpool()
+	return nil
+}
+
+// Bytes returns the output retained in memory.
+func (o *CappedOutput) Bytes() []byte {
+	return o.buf.Bytes()
+}
+
+// Truncated returns whether any output was not retained in memory.
+func (o *CappedOutput) Truncated() bool {
+	return o.spooled > 0 || o.discarded > 0
+}
+
+// Overflow describes what became of the output that was not retained in
+// memory, naming the spool files that still hold it, or returns the
+// empty string if all the output was retained.
+func (o *CappedOutput) Overflow() string {
+	if !o.Truncated() {
+		return ""
+	}
+	var kept []string
+	for _, path := range o.files {
+		if _, err := os.Stat(path); err == nil {
+			kept = append(kept, path)
+		}
+	}
+	msg := fmt.Sprintf("output truncated after %d bytes", o.buf.Len())
+	if len(kept) > 0 {
+		msg += fmt.Sprintf("; %d more bytes spooled to %s", o.spooled, strings.Join(kept, ", "))
+		if len(kept) < len(o.files) {
+			msg += " (earlier spool files removed)"
+		}
+	}
+	if o.discarded > 0 {
+		msg += fmt.Sprintf("; %d bytes discarded", o.discarded)
+	}
+	return msg
+}
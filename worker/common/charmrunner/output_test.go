@@ -0,0 +1,126 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrunner_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/common/charmrunner"
+)
+
+type outputSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&outputSuite{})
+
+func (s *outputSuite) spoolFiles(c *gc.C, dir string) []string {
+	paths, err := filepath.Glob(filepath.Join(dir, "test-*.log"))
+	c.Assert(err, jc.ErrorIsNil)
+	contents := make([]string, len(paths))
+	for i, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		c.Assert(err, jc.ErrorIsNil)
+		contents[i] = string(data)
+	}
+	return contents
+}
+
+func (s *outputSuite) TestWithinLimit(c *gc.C) {
+	dir := c.MkDir()
+	output := charmrunner.NewCappedOutput("test", charmrunner.OutputLimits{
+		MaxMemory: 10,
+		SpoolDir:  dir,
+	})
+	output.Write([]byte("01234"))
+	output.Write([]byte("56789"))
+	c.Assert(output.Close(), jc.ErrorIsNil)
+	c.Assert(string(output.Bytes()), gc.Equals, "0123456789")
+	c.Assert(output.Truncated(), jc.IsFalse)
+	c.Assert(output.Overflow(), gc.Equals, "")
+	c.Assert(s.spoolFiles(c, dir), gc.HasLen, 0)
+}
+
+func (s *outputSuite) TestSpooled(c *gc.C) {
+	dir := c.MkDir()
+	output := charmrunner.NewCappedOutput("test", charmrunner.OutputLimits{
+		MaxMemory: 4,
+		SpoolDir:  dir,
+	})
+	n, err := output.Write([]byte("0123456789"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(n, gc.Equals, 10)
+	c.Assert(output.Close(), jc.ErrorIsNil)
+	c.Assert(string(output.Bytes()), gc.Equals, "0123")
+	c.Assert(output.Truncated(), jc.IsTrue)
+	c.Assert(s.spoolFiles(c, dir), jc.DeepEquals, []string{"456789"})
+	c.Assert(output.Overflow(), gc.Matches,
+		`output truncated after 4 bytes; 6 more bytes spooled to .*/test-\d{8}T\d{6}\.\d{9}\.log`)
+}
+
+func (s *outputSuite) TestSpoolRotated(c *gc.C) {
+	dir := c.MkDir()
+	limits := charmrunner.OutputLimits{
+		MaxMemory:        2,
+		SpoolDir:         dir,
+		MaxSpoolFileSize: 3,
+		MaxSpoolFiles:    2,
+	}
+	output := charmrunner.NewCappedOutput("test", limits)
+	output.Write([]byte("0123456789"))
+	c.Assert(output.Close(), jc.ErrorIsNil)
+	c.Assert(string(output.Bytes()), gc.Equals, "01")
+	c.Assert(s.spoolFiles(c, dir), jc.DeepEquals, []string{"567", "89"})
+	c.Assert(output.Overflow(), gc.Matches,
+		`output truncated after 2 bytes; 8 more bytes spooled to .*, .* \(earlier spool files removed\)`)
+
+	// Spool files are shared by outputs with the same prefix.
+	output = charmrunner.NewCappedOutput("test", limits)
+	output.Write([]byte("abcd"))
+	c.Assert(output.Close(), jc.ErrorIsNil)
+	c.Assert(s.spoolFiles(c, dir), jc.DeepEquals, []string{"89", "cd"})
+}
+
+func (s *outputSuite) TestNoSpoolDir(c *gc.C) {
+	output := charmrunner.NewCappedOutput("test", charmrunner.OutputLimits{
+		MaxMemory: 4,
+	})
+	output.Write([]byte("0123456789"))
+	c.Assert(output.Close(), jc.ErrorIsNil)
+	c.Assert(string(output.Bytes()), gc.Equals, "0123")
+	c.Assert(output.Overflow(), gc.Equals, "output truncated after 4 bytes; 6 bytes discarded")
+}
+
+func (s *outputSuite) TestSpoolFailure(c *gc.C) {
+	file := filepath.Join(c.MkDir(), "file")
+	err := ioutil.WriteFile(file, nil, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	output := charmrunner.NewCappedOutput("test", charmrunner.OutputLimits{
+		MaxMemory: 4,
+		SpoolDir:  filepath.Join(file, "spool"),
+	})
+	n, err := output.Write([]byte("0123456789"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(n, gc.Equals, 10)
+	output.Write([]byte("abc"))
+	c.Assert(output.Overflow(), gc.Equals, "output truncated after 4 bytes; 9 bytes discarded")
+	_, err = os.Stat(filepath.Join(file, "spool"))
+	c.Assert(err, gc.NotNil)
+}
+
+func (s *outputSuite) TestValidate(c *gc.C) {
+	c.Assert(charmrunner.OutputLimits{}.Validate(), jc.ErrorIsNil)
+	err := charmrunner.OutputLimits{MaxMemory: -1}.Validate()
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, "negative MaxMemory not valid")
+	err = charmrunner.OutputLimits{MaxSpoolFiles: -1}.Validate()
+	c.Assert(err, gc.ErrorMatches, "negative MaxSpoolFiles not valid")
+}
@@ -0,0 +1,123 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package containerimagecache
+
+import (
+	"runtime"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/arch"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/containerimagecache"
+	"github.com/juju/juju/container/lxd"
+	"github.com/juju/juju/tools/lxdclient"
+	"github.com/juju/juju/worker/dependency"
+)
+
+// ManifoldConfig describes the resources and configuration on which
+// the container image cache worker depends.
+type ManifoldConfig struct {
+	AgentName     string
+	APICallerName string
+	ClockName     string
+	Interval      time.Duration
+
+	NewFacade     func(base.APICaller) Facade
+	NewImageStore func() (ImageStore, error)
+	NewWorker     func(Config) (worker.Worker, error)
+}
+
+// Validate is called by start to check for bad configuration.
+func (config ManifoldConfig) Validate() error {
+	if config.AgentName == "" {
+		return errors.NotValidf("empty AgentName")
+	}
+	if config.APICallerName == "" {
+		return errors.NotValidf("empty APICallerName")
+	}
+	if config.ClockName == "" {
+		return errors.NotValidf("empty ClockName")
+	}
+	if config.NewFacade == nil {
+		return errors.NotValidf("nil NewFacade")
+	}
+	if config.NewImageStore == nil {
+		return errors.NotValidf("nil NewImageStore")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// Manifold returns a dependency.Manifold that runs a container image
+// cache worker. On machines that cannot host LXD containers, the
+// manifold uninstalls itself.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{config.AgentName, config.APICallerName, config.ClockName},
+		Start:  config.start,
+	}
+}
+
+// start is a StartFunc for a Worker manifold.
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if runtime.GOOS != "linux" || !lxd.HasLXDSupport() {
+		logger.Debugf("no container images to cache on machines without LXD support")
+		return nil, dependency.ErrUninstall
+	}
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var agent agent.Agent
+	if err := context.Get(config.AgentName, &agent); err != nil {
+		return nil, errors.Trace(err)
+	}
+	tag, ok := agent.CurrentConfig().Tag().(names.MachineTag)
+	if !ok {
+		return nil, errors.New("containerimagecache may only be used with a machine agent")
+	}
+	var apiCaller base.APICaller
+	if err := context.Get(config.APICallerName, &apiCaller); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var clock clock.Clock
+	if err := context.Get(config.ClockName, &clock); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w, err := config.NewWorker(Config{
+		Facade:        config.NewFacade(apiCaller),
+		MachineId:     tag.Id(),
+		Clock:         clock,
+		Arch:          arch.HostArch(),
+		Sources:       lxdclient.DefaultImageSources,
+		NewImageStore: config.NewImageStore,
+		Interval:      config.Interval,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// NewFacade returns the API facade used by the container image cache
+// worker.
+func NewFacade(apiCaller base.APICaller) Facade {
+	return containerimagecache.NewFacade(apiCaller)
+}
+
+// NewImageStore connects to the local LXD daemon's image store.
+func NewImageStore() (ImageStore, error) {
+	client, err := lxd.ConnectLocal()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return client, nil
+}
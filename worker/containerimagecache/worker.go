@@ -0,0 +1,247 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package containerimagecache provides a worker that manages the LXD
+// images a machine keeps cached for creating containers.
+//
+// Fetching an image the first time a container of its series is
+// created can take several minutes, so the worker fetches the images
+// likely to be needed ahead of time: those of the model's default
+// series, and those of the containers about to be created on the
+// machine. To stop the cache growing without bound, the least recently
+// used images are removed once the cache is larger than the model's
+// container-image-cache-size, though never those that are expected to
+// be needed. Images can also be purged on request, for instance to
+// force a stale image to be fetched afresh.
+package containerimagecache
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/tools/lxdclient"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.containerimagecache")
+
+// Facade defines the interface we require from the container image
+// cache facade.
+type Facade interface {
+	CacheConfig(machineId string) (params.ContainerImageCacheConfig, error)
+	SetImages(machineId string, images []params.ContainerImage, purged *params.ContainerImagePurge) error
+}
+
+// ImageStore defines the interface we require from the machine's local
+// image store.
+type ImageStore interface {
+	CachedImages() ([]lxdclient.CachedImage, error)
+	DeleteCachedImage(fingerprint string) error
+	EnsureImageExists(series, arch string, sources []lxdclient.Remote, copyProgressHandler func(string)) (string, error)
+}
+
+// Config holds the configuration and dependencies for a container
+// image cache worker.
+type Config struct {
+	Facade    Facade
+	MachineId string
+	Clock     clock.Clock
+
+	// Arch is the architecture of the images to fetch.
+	Arch string
+
+	// Sources holds the remotes images are fetched from.
+	Sources []lxdclient.Remote
+
+	// NewImageStore connects to the machine's local image store. It
+	// is called until it succeeds, since LXD may not be available
+	// until the machine's first container is created.
+	NewImageStore func() (ImageStore, error)
+
+	// Interval is how often the cache is brought up to date.
+	Interval time.Duration
+}
+
+// Validate returns an error if the config cannot be expected to drive
+// a functional container image cache worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.MachineId == "" {
+		return errors.NotValidf("empty MachineId")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Arch == "" {
+		return errors.NotValidf("empty Arch")
+	}
+	if config.NewImageStore == nil {
+		return errors.NotValidf("nil NewImageStore")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that keeps the machine's container image
+// cache up to date.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &cacheWorker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type cacheWorker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+	store    ImageStore
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *cacheWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *cacheWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *cacheWorker) loop() error {
+	for {
+		if err := w.update(); err != nil {
+			return errors.Annotate(err, "updating container image cache")
+		}
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(w.config.Interval):
+		}
+	}
+}
+
+func (w *cacheWorker) update() error {
+	if w.store == nil {
+		store, err := w.config.NewImageStore()
+		if err != nil {
+			logger.Debugf("cannot connect to local image store: %v", err)
+			return nil
+		}
+		w.store = store
+	}
+	config, err := w.config.Facade.CacheConfig(w.config.MachineId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if config.Purge != nil {
+		if err := w.purge(*config.Purge); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	w.prefetch(config.PrefetchSeries)
+	images, err := w.evict(config.PrefetchSeries, config.MaxSizeMB)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(w.config.Facade.SetImages(w.config.MachineId, images, config.Purge))
+}
+
+// purge removes the images of the purge's series from the cache, or
+// all images if it has none.
+func (w *cacheWorker) purge(purge params.ContainerImagePurge) error {
+	images, err := w.store.CachedImages()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	series := set.NewStrings(purge.Series...)
+	for _, image := range images {
+		if !series.IsEmpty() && !series.Contains(image.Series) {
+			continue
+		}
+		logger.Infof("purging %s/%s image %s", image.Series, image.Arch, image.Fingerprint)
+		if err := w.store.DeleteCachedImage(image.Fingerprint); err != nil {
+			return errors.Annotatef(err, "purging image %s", image.Fingerprint)
+		}
+	}
+	return nil
+}
+
+// prefetch fetches the images of the given series that are not
+// already cached. Failures are logged rather than returned, so that
+// one unavailable image does not prevent the others being fetched;
+// they are retried at the next update.
+func (w *cacheWorker) prefetch(series []string) {
+	for _, s := range series {
+		select {
+		case <-w.catacomb.Dying():
+			return
+		default:
+		}
+		progress := func(msg string) {
+			logger.Tracef("prefetching %s/%s image: %s", s, w.config.Arch, msg)
+		}
+		if _, err := w.store.EnsureImageExists(s, w.config.Arch, w.config.Sources, progress); err != nil {
+			logger.Warningf("cannot prefetch %s/%s image: %v", s, w.config.Arch, err)
+		}
+	}
+}
+
+// evict removes the least recently used images from the cache until
+// it is no larger than maxSizeMB, keeping those of the given series.
+// It returns the images that remain.
+func (w *cacheWorker) evict(keepSeries []string, maxSizeMB uint) ([]params.ContainerImage, error) {
+	images, err := w.store.CachedImages()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sort.Slice(images, func(i, j int) bool {
+		return images[i].LastUsed.Before(images[j].LastUsed)
+	})
+	var total int64
+	for _, image := range images {
+		total += image.Size
+	}
+	maxSize := int64(maxSizeMB) * 1024 * 1024
+	keep := set.NewStrings(keepSeries...)
+	var result []params.ContainerImage
+	for _, image := range images {
+		if maxSize > 0 && total > maxSize && !keep.Contains(image.Series) {
+			logger.Infof(
+				"evicting %s/%s image %s: cache size %d exceeds %d",
+				image.Series, image.Arch, image.Fingerprint, total, maxSize,
+			)
+			if err := w.store.DeleteCachedImage(image.Fingerprint); err != nil {
+				return nil, errors.Annotatef(err, "evicting image %s", image.Fingerprint)
+			}
+			total -= image.Size
+			continue
+		}
+		result = append(result, params.ContainerImage{
+			Series:      image.Series,
+			Arch:        image.Arch,
+			Fingerprint: image.Fingerprint,
+			Size:        image.Size,
+			LastUsed:    image.LastUsed,
+		})
+	}
+	return result, nil
+}
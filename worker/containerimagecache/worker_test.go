@@ -0,0 +1,316 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package containerimagecache_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/tools/lxdclient"
+	"github.com/juju/juju/worker/containerimagecache"
+	"github.com/juju/juju/worker/workertest"
+)
+
+const mb = 1024 * 1024
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+
+	clock  *testing.Clock
+	facade *mockFacade
+	store  *mockImageStore
+	config containerimagecache.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Time{})
+	s.facade = &mockFacade{
+		config: params.ContainerImageCacheConfig{
+			PrefetchSeries: []string{"xenial"},
+			MaxSizeMB:      1000,
+		},
+		set: make(chan []params.ContainerImage, 10),
+	}
+	s.store = &mockImageStore{
+		images: []lxdclient.CachedImage{{
+			Series:      "trusty",
+			Arch:        "amd64",
+			Fingerprint: "trusty-fingerprint",
+			Size:        400 * mb,
+			LastUsed:    time.Unix(100, 0),
+		}, {
+			Series:      "precise",
+			Arch:        "amd64",
+			Fingerprint: "precise-fingerprint",
+			Size:        400 * mb,
+			LastUsed:    time.Unix(200, 0),
+		}},
+	}
+	s.config = containerimagecache.Config{
+		Facade:    s.facade,
+		MachineId: "0",
+		Clock:     s.clock,
+		Arch:      "amd64",
+		Sources:   []lxdclient.Remote{lxdclient.CloudImagesRemote},
+		NewImageStore: func() (containerimagecache.ImageStore, error) {
+			return s.store, nil
+		},
+		Interval: time.Minute,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	s.testValidate(c, func(cfg *containerimagecache.Config) {
+		cfg.Facade = nil
+	}, "nil Facade not valid")
+	s.testValidate(c, func(cfg *containerimagecache.Config) {
+		cfg.MachineId = ""
+	}, "empty MachineId not valid")
+	s.testValidate(c, func(cfg *containerimagecache.Config) {
+		cfg.Clock = nil
+	}, "nil Clock not valid")
+	s.testValidate(c, func(cfg *containerimagecache.Config) {
+		cfg.Arch = ""
+	}, "empty Arch not valid")
+	s.testValidate(c, func(cfg *containerimagecache.Config) {
+		cfg.NewImageStore = nil
+	}, "nil NewImageStore not valid")
+	s.testValidate(c, func(cfg *containerimagecache.Config) {
+		cfg.Interval = 0
+	}, "non-positive Interval not valid")
+}
+
+func (s *WorkerSuite) testValidate(c *gc.C, f func(*containerimagecache.Config), expect string) {
+	config := s.config
+	f(&config)
+	w, err := containerimagecache.NewWorker(config)
+	if !c.Check(err, gc.NotNil) {
+		workertest.DirtyKill(c, w)
+		return
+	}
+	c.Check(w, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, expect)
+}
+
+func (s *WorkerSuite) TestPrefetchesAndEvicts(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	images := s.waitSet(c)
+	s.store.CheckCall(c, 0, "EnsureImageExists", "xenial", "amd64", []lxdclient.Remote{lxdclient.CloudImagesRemote})
+	// The fetched image takes the cache over its limit, so the least
+	// recently used image is evicted.
+	s.store.CheckCall(c, 2, "DeleteCachedImage", "trusty-fingerprint")
+	c.Assert(images, jc.DeepEquals, []params.ContainerImage{{
+		Series:      "precise",
+		Arch:        "amd64",
+		Fingerprint: "precise-fingerprint",
+		Size:        400 * mb,
+		LastUsed:    time.Unix(200, 0),
+	}, {
+		Series:      "xenial",
+		Arch:        "amd64",
+		Fingerprint: "xenial-fingerprint",
+		Size:        300 * mb,
+		LastUsed:    time.Unix(300, 0),
+	}})
+	s.facade.CheckCall(c, 1, "SetImages", "0", images, (*params.ContainerImagePurge)(nil))
+}
+
+func (s *WorkerSuite) TestPrefetchSeriesNotEvicted(c *gc.C) {
+	s.facade.config.PrefetchSeries = []string{"trusty", "xenial"}
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	images := s.waitSet(c)
+	s.store.CheckCall(c, 3, "DeleteCachedImage", "precise-fingerprint")
+	c.Assert(images, gc.HasLen, 2)
+	c.Assert(images[0].Series, gc.Equals, "trusty")
+	c.Assert(images[1].Series, gc.Equals, "xenial")
+}
+
+func (s *WorkerSuite) TestNoLimit(c *gc.C) {
+	s.facade.config.MaxSizeMB = 0
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	images := s.waitSet(c)
+	c.Assert(images, gc.HasLen, 3)
+	s.store.CheckCallNames(c, "EnsureImageExists", "CachedImages")
+}
+
+func (s *WorkerSuite) TestPurge(c *gc.C) {
+	purge := &params.ContainerImagePurge{
+		Series:    []string{"precise"},
+		Requested: time.Unix(400, 0),
+	}
+	s.facade.config.Purge = purge
+	s.facade.config.MaxSizeMB = 0
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	images := s.waitSet(c)
+	s.store.CheckCall(c, 1, "DeleteCachedImage", "precise-fingerprint")
+	c.Assert(images, gc.HasLen, 2)
+	s.facade.CheckCall(c, 1, "SetImages", "0", images, purge)
+}
+
+func (s *WorkerSuite) TestPrefetchErrorNotFatal(c *gc.C) {
+	s.facade.config.PrefetchSeries = []string{"bionic", "xenial"}
+	s.store.SetErrors(errors.New("no image for bionic"))
+	s.facade.config.MaxSizeMB = 0
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	images := s.waitSet(c)
+	c.Assert(images, gc.HasLen, 3)
+	s.store.CheckCallNames(c, "EnsureImageExists", "EnsureImageExists", "CachedImages")
+}
+
+func (s *WorkerSuite) TestUpdatesPeriodically(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.waitSet(c)
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitSet(c)
+	s.facade.CheckCallNames(c, "CacheConfig", "SetImages", "CacheConfig", "SetImages")
+}
+
+func (s *WorkerSuite) TestWaitsForImageStore(c *gc.C) {
+	var mu sync.Mutex
+	available := false
+	s.config.NewImageStore = func() (containerimagecache.ImageStore, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if !available {
+			return nil, errors.New("LXD not installed")
+		}
+		return s.store, nil
+	}
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	err := s.clock.WaitAdvance(0, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade.CheckNoCalls(c)
+
+	mu.Lock()
+	available = true
+	mu.Unlock()
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitSet(c)
+}
+
+func (s *WorkerSuite) TestCacheConfigError(c *gc.C) {
+	s.facade.SetErrors(errors.New("kaboom"))
+	w := s.startWorker(c)
+	defer workertest.DirtyKill(c, w)
+
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "updating container image cache: kaboom")
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := containerimagecache.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *WorkerSuite) waitSet(c *gc.C) []params.ContainerImage {
+	select {
+	case images := <-s.facade.set:
+		return images
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for images to be set")
+	}
+	panic("unreachable")
+}
+
+type mockFacade struct {
+	testing.Stub
+	config params.ContainerImageCacheConfig
+	set    chan []params.ContainerImage
+}
+
+func (f *mockFacade) CacheConfig(machineId string) (params.ContainerImageCacheConfig, error) {
+	f.MethodCall(f, "CacheConfig", machineId)
+	return f.config, f.NextErr()
+}
+
+func (f *mockFacade) SetImages(machineId string, images []params.ContainerImage, purged *params.ContainerImagePurge) error {
+	f.MethodCall(f, "SetImages", machineId, images, purged)
+	f.set <- images
+	return f.NextErr()
+}
+
+type mockImageStore struct {
+	testing.Stub
+
+	mu     sync.Mutex
+	images []lxdclient.CachedImage
+}
+
+func (s *mockImageStore) CachedImages() ([]lxdclient.CachedImage, error) {
+	s.MethodCall(s, "CachedImages")
+	if err := s.NextErr(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	images := make([]lxdclient.CachedImage, len(s.images))
+	copy(images, s.images)
+	return images, nil
+}
+
+func (s *mockImageStore) DeleteCachedImage(fingerprint string) error {
+	s.MethodCall(s, "DeleteCachedImage", fingerprint)
+	if err := s.NextErr(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, image := range s.images {
+		if image.Fingerprint == fingerprint {
+			s.images = append(s.images[:i], s.images[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (s *mockImageStore) EnsureImageExists(series, arch string, sources []lxdclient.Remote, _ func(string)) (string, error) {
+	s.MethodCall(s, "EnsureImageExists", series, arch, sources)
+	if err := s.NextErr(); err != nil {
+		return "", err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, image := range s.images {
+		if image.Series == series && image.Arch == arch {
+			return "juju/" + series + "/" + arch, nil
+		}
+	}
+	s.images = append(s.images, lxdclient.CachedImage{
+		Series:      series,
+		Arch:        arch,
+		Fingerprint: series + "-fingerprint",
+		Size:        300 * mb,
+		LastUsed:    time.Unix(300, 0),
+	})
+	return "juju/" + series + "/" + arch, nil
+}
@@ -0,0 +1,209 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package controllerhealth provides a worker that periodically records
+// the health of a controller machine in state: the free space on the
+// disk holding its data directory, the number of connections its
+// apiserver is serving, and the states of its critical workers. The
+// ControllerHealth facade aggregates these reports from all
+// controller machines.
+package controllerhealth
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"github.com/juju/utils/du"
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/catacomb"
+	"github.com/juju/juju/worker/dependency"
+)
+
+var logger = loggo.GetLogger("juju.worker.controllerhealth")
+
+// connectionCountMetric is the name of the gauge exported by the
+// apiserver holding its current number of connections.
+const connectionCountMetric = "juju_apiserver_connection_count"
+
+// Backend defines the state functionality required by the worker.
+type Backend interface {
+	SetControllerHealth(state.ControllerHealth) error
+}
+
+// Config holds the configuration and dependencies for a controller
+// health worker.
+type Config struct {
+	MachineId string
+	Backend   Backend
+	Clock     clock.Clock
+	Interval  time.Duration
+
+	// DataDir is the directory whose disk's free space is reported.
+	DataDir string
+
+	// CriticalWorkers holds the names of the manifolds whose states
+	// are reported.
+	CriticalWorkers []string
+
+	// EngineReport returns the report of the dependency engine
+	// running the critical workers.
+	EngineReport func() map[string]interface{}
+
+	// Gatherer is used to read the apiserver's connection count.
+	Gatherer prometheus.Gatherer
+
+	// DiskUsage returns the free and total bytes on the disk holding
+	// the given path.
+	DiskUsage func(path string) (free, total uint64)
+}
+
+// Validate returns an error if the config cannot be expected to
+// drive a functional controller health worker.
+func (config Config) Validate() error {
+	if config.MachineId == "" {
+		return errors.NotValidf("empty MachineId")
+	}
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	if config.DataDir == "" {
+		return errors.NotValidf("empty DataDir")
+	}
+	if config.EngineReport == nil {
+		return errors.NotValidf("nil EngineReport")
+	}
+	if config.Gatherer == nil {
+		return errors.NotValidf("nil Gatherer")
+	}
+	if config.DiskUsage == nil {
+		return errors.NotValidf("nil DiskUsage")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that records the controller machine's
+// health every configured interval.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &reporter{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// DiskUsage returns the free and total bytes on the disk holding the
+// given path.
+func DiskUsage(path string) (free, total uint64) {
+	usage := du.NewDiskUsage(path)
+	return usage.Free(), usage.Size()
+}
+
+type reporter struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *reporter) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *reporter) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *reporter) loop() error {
+	// Report immediately, so that a restarted controller does not
+	// appear stale for a whole interval.
+	if err := w.report(); err != nil {
+		return errors.Annotate(err, "reporting controller health")
+	}
+	timer := w.config.Clock.NewTimer(w.config.Interval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-timer.Chan():
+			if err := w.report(); err != nil {
+				return errors.Annotate(err, "reporting controller health")
+			}
+			timer.Reset(w.config.Interval)
+		}
+	}
+}
+
+func (w *reporter) report() error {
+	free, total := w.config.DiskUsage(w.config.DataDir)
+	health := state.ControllerHealth{
+		MachineId:      w.config.MachineId,
+		Updated:        w.config.Clock.Now(),
+		DiskFreeBytes:  free,
+		DiskTotalBytes: total,
+		APIConnections: w.connectionCount(),
+		Workers:        w.workerHealth(),
+	}
+	return errors.Trace(w.config.Backend.SetControllerHealth(health))
+}
+
+// connectionCount returns the apiserver's current number of
+// connections, or zero if the apiserver is not running.
+func (w *reporter) connectionCount() int64 {
+	families, err := w.config.Gatherer.Gather()
+	if err != nil {
+		logger.Warningf("cannot gather metrics: %v", err)
+		return 0
+	}
+	for _, family := range families {
+		if family.GetName() != connectionCountMetric {
+			continue
+		}
+		var count int64
+		for _, metric := range family.GetMetric() {
+			count += int64(metric.GetGauge().GetValue())
+		}
+		return count
+	}
+	return 0
+}
+
+// workerHealth returns the states of the critical workers, as found
+// in the dependency engine's report.
+func (w *reporter) workerHealth() []state.WorkerHealth {
+	report := w.config.EngineReport()
+	manifolds, _ := report[dependency.KeyManifolds].(map[string]interface{})
+	result := make([]state.WorkerHealth, len(w.config.CriticalWorkers))
+	for i, name := range w.config.CriticalWorkers {
+		result[i] = state.WorkerHealth{Name: name, State: "unknown"}
+		manifold, ok := manifolds[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if s, ok := manifold[dependency.KeyState].(string); ok {
+			result[i].State = s
+		}
+		if e, ok := manifold[dependency.KeyError].(string); ok {
+			result[i].Error = e
+		}
+	}
+	return result
+}
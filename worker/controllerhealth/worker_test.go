@@ -0,0 +1,188 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllerhealth_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/controllerhealth"
+	"github.com/juju/juju/worker/dependency"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+
+	clock    *testing.Clock
+	backend  *mockBackend
+	registry *prometheus.Registry
+	config   controllerhealth.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Time{})
+	s.backend = &mockBackend{reports: make(chan state.ControllerHealth, 1)}
+	s.registry = prometheus.NewRegistry()
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "juju_apiserver",
+		Name:      "connection_count",
+	})
+	gauge.Set(42)
+	s.registry.MustRegister(gauge)
+	s.config = controllerhealth.Config{
+		MachineId:       "0",
+		Backend:         s.backend,
+		Clock:           s.clock,
+		Interval:        time.Minute,
+		DataDir:         "/var/lib/juju",
+		CriticalWorkers: []string{"api-server", "peer-grouper", "state"},
+		EngineReport: func() map[string]interface{} {
+			return map[string]interface{}{
+				dependency.KeyManifolds: map[string]interface{}{
+					"api-server": map[string]interface{}{
+						dependency.KeyState: "started",
+					},
+					"peer-grouper": map[string]interface{}{
+						dependency.KeyState: "stopped",
+						dependency.KeyError: "kaboom",
+					},
+				},
+			}
+		},
+		Gatherer: s.registry,
+		DiskUsage: func(path string) (uint64, uint64) {
+			c.Check(path, gc.Equals, "/var/lib/juju")
+			return 100, 1000
+		},
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	s.testValidate(c, func(cfg *controllerhealth.Config) {
+		cfg.MachineId = ""
+	}, "empty MachineId not valid")
+	s.testValidate(c, func(cfg *controllerhealth.Config) {
+		cfg.Backend = nil
+	}, "nil Backend not valid")
+	s.testValidate(c, func(cfg *controllerhealth.Config) {
+		cfg.Clock = nil
+	}, "nil Clock not valid")
+	s.testValidate(c, func(cfg *controllerhealth.Config) {
+		cfg.Interval = 0
+	}, "non-positive Interval not valid")
+	s.testValidate(c, func(cfg *controllerhealth.Config) {
+		cfg.DataDir = ""
+	}, "empty DataDir not valid")
+	s.testValidate(c, func(cfg *controllerhealth.Config) {
+		cfg.EngineReport = nil
+	}, "nil EngineReport not valid")
+	s.testValidate(c, func(cfg *controllerhealth.Config) {
+		cfg.Gatherer = nil
+	}, "nil Gatherer not valid")
+	s.testValidate(c, func(cfg *controllerhealth.Config) {
+		cfg.DiskUsage = nil
+	}, "nil DiskUsage not valid")
+}
+
+func (s *WorkerSuite) testValidate(c *gc.C, f func(*controllerhealth.Config), expect string) {
+	config := s.config
+	f(&config)
+	w, err := controllerhealth.NewWorker(config)
+	if !c.Check(err, gc.NotNil) {
+		workertest.DirtyKill(c, w)
+		return
+	}
+	c.Check(w, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, expect)
+}
+
+func (s *WorkerSuite) TestReportsImmediately(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	health := s.nextReport(c)
+	c.Assert(health, jc.DeepEquals, state.ControllerHealth{
+		MachineId:      "0",
+		Updated:        s.clock.Now(),
+		DiskFreeBytes:  100,
+		DiskTotalBytes: 1000,
+		APIConnections: 42,
+		Workers: []state.WorkerHealth{
+			{Name: "api-server", State: "started"},
+			{Name: "peer-grouper", State: "stopped", Error: "kaboom"},
+			{Name: "state", State: "unknown"},
+		},
+	})
+}
+
+func (s *WorkerSuite) TestReportsEveryInterval(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.nextReport(c)
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	health := s.nextReport(c)
+	c.Assert(health.Updated, gc.Equals, s.clock.Now())
+}
+
+func (s *WorkerSuite) TestNoConnectionCountWithoutAPIServer(c *gc.C) {
+	s.config.Gatherer = prometheus.NewRegistry()
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	health := s.nextReport(c)
+	c.Assert(health.APIConnections, gc.Equals, int64(0))
+}
+
+func (s *WorkerSuite) TestSetControllerHealthError(c *gc.C) {
+	s.backend.SetErrors(errors.New("kaboom"))
+	w := s.startWorker(c)
+	defer workertest.DirtyKill(c, w)
+
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "reporting controller health: kaboom")
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := controllerhealth.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *WorkerSuite) nextReport(c *gc.C) state.ControllerHealth {
+	select {
+	case health := <-s.backend.reports:
+		return health
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for health report")
+	}
+	panic("unreachable")
+}
+
+type mockBackend struct {
+	testing.Stub
+	reports chan state.ControllerHealth
+}
+
+func (b *mockBackend) SetControllerHealth(health state.ControllerHealth) error {
+	b.MethodCall(b, "SetControllerHealth", health)
+	if err := b.NextErr(); err != nil {
+		return err
+	}
+	b.reports <- health
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crashreporter
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// Bundle holds the diagnostics collected when an agent crashes.
+type Bundle struct {
+	// Reason describes the crash.
+	Reason string
+
+	// Goroutines holds the stacks of the agent's goroutines.
+	Goroutines []byte
+
+	// EngineReport holds the last recorded report of the agent's
+	// dependency engine, in YAML.
+	EngineReport []byte
+
+	// Log holds the end of the agent's log before the crash.
+	Log []byte
+}
+
+// Archive returns the bundle as a gzipped tar archive, holding a file
+// for each of the bundle's non-empty fields.
+func (b Bundle) Archive(modTime time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+	files := []struct {
+		name    string
+		content []byte
+	}{
+		{"reason.txt", []byte(b.Reason)},
+		{"goroutines.txt", b.Goroutines},
+		{"engine-report.yaml", b.EngineReport},
+		{"agent.log", b.Log},
+	}
+	for _, file := range files {
+		if len(file.content) == 0 {
+			continue
+		}
+		hdr := &tar.Header{
+			Name:    file.name,
+			Mode:    0600,
+			Size:    int64(len(file.content)),
+			ModTime: modTime,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, err := tw.Write(file.content); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := gzw.Close(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return buf.Bytes(), nil
+}
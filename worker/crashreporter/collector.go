@@ -0,0 +1,209 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crashreporter
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+)
+
+const (
+	// logTailBytes is how much of the agent's log before a crash is
+	// included in its bundle.
+	logTailBytes = 256 * 1024
+
+	// maxGoroutineBytes limits the size of the goroutine stacks
+	// included in a bundle.
+	maxGoroutineBytes = 4 * 1024 * 1024
+
+	// maxScanBytes limits how much of the agent's log is searched for
+	// crashes when the agent starts.
+	maxScanBytes = 8 * 1024 * 1024
+
+	// maxReasonLength limits the length of a crash report's reason.
+	maxReasonLength = 1024
+)
+
+// crashMarkers are the prefixes of the lines the Go runtime writes to
+// the agent's log when the agent dies of a panic or runtime error.
+var crashMarkers = []string{"panic: ", "fatal error: "}
+
+// Collector collects the crash reports of an agent into a Store.
+type Collector struct {
+	store   *Store
+	logFile string
+	clock   clock.Clock
+}
+
+// NewCollector returns a Collector that saves crash reports in store,
+// including the end of the agent log found at logFile.
+func NewCollector(store *Store, logFile string, clock clock.Clock) *Collector {
+	return &Collector{
+		store:   store,
+		logFile: logFile,
+		clock:   clock,
+	}
+}
+
+// Recover collects a crash report for a panic in the calling
+// goroutine, then lets the panic continue. It must be called directly
+// by a deferred function call.
+func (c *Collector) Recover() {
+	r := recover()
+	if r == nil {
+		return
+	}
+	if err := c.collectPanic(r); err != nil {
+		logger.Errorf("cannot collect crash report: %v", err)
+	}
+	panic(r)
+}
+
+func (c *Collector) collectPanic(value interface{}) error {
+	goroutines := make([]byte, maxGoroutineBytes)
+	goroutines = goroutines[:runtime.Stack(goroutines, true)]
+	log, err := readLog(c.logFile, -1, logTailBytes)
+	if err != nil {
+		logger.Warningf("cannot read agent log for crash report: %v", err)
+	}
+	reason := fmt.Sprintf("panic: %v", value)
+	if err := c.save(c.clock.Now(), reason, goroutines, log); err != nil {
+		return errors.Trace(err)
+	}
+	// The panic will be written to the log as the agent dies; it
+	// must not be collected again when the agent restarts.
+	return errors.Trace(c.store.setRecovered())
+}
+
+// CollectPrevious collects a crash report for a crash written to the
+// agent's log since it was last checked. Panics in goroutines other
+// than the one deferring Recover kill the agent outright, so this is
+// how most crashes are collected; it should be called whenever the
+// agent starts.
+func (c *Collector) CollectPrevious() error {
+	info, err := os.Stat(c.logFile)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	size := info.Size()
+	recovered, err := c.store.takeRecovered()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if recovered {
+		return errors.Trace(c.store.setLogOffset(size))
+	}
+
+	offset, ok, err := c.store.logOffset()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch {
+	case offset > size:
+		// The log has been rotated.
+		offset = 0
+	case !ok, size-offset > maxScanBytes:
+		offset = size - maxScanBytes
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	data, err := readLog(c.logFile, offset, size-offset)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if start, reason := findCrash(data); start >= 0 {
+		logStart := start - logTailBytes
+		if logStart < 0 {
+			logStart = 0
+		}
+		goroutines := data[start:]
+		if len(goroutines) > maxGoroutineBytes {
+			goroutines = goroutines[:maxGoroutineBytes]
+		}
+		logger.Infof("collecting crash report for %q", reason)
+		if err := c.save(info.ModTime(), reason, goroutines, data[logStart:start]); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return errors.Trace(c.store.setLogOffset(size))
+}
+
+func (c *Collector) save(t time.Time, reason string, goroutines, log []byte) error {
+	engineReport, err := c.store.EngineReport()
+	if err != nil {
+		logger.Warningf("cannot read engine report for crash report: %v", err)
+	}
+	if len(reason) > maxReasonLength {
+		reason = reason[:maxReasonLength]
+	}
+	bundle := Bundle{
+		Reason:       reason,
+		Goroutines:   goroutines,
+		EngineReport: engineReport,
+		Log:          log,
+	}
+	archive, err := bundle.Archive(t)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(c.store.Save(Report{Time: t, Reason: reason}, archive))
+}
+
+// findCrash returns the offset of the first crash written to the
+// given log data, and the line describing it. The offset is -1 if no
+// crash is found.
+func findCrash(data []byte) (int, string) {
+	for offset := 0; offset < len(data); {
+		end := bytes.IndexByte(data[offset:], '\n')
+		if end < 0 {
+			end = len(data) - offset
+		}
+		line := data[offset : offset+end]
+		for _, marker := range crashMarkers {
+			if bytes.HasPrefix(line, []byte(marker)) {
+				return offset, string(line)
+			}
+		}
+		offset += end + 1
+	}
+	return -1, ""
+}
+
+// readLog reads up to n bytes from the log file starting at offset. If
+// offset is negative, the last n bytes of the file are read.
+func readLog(path string, offset, n int64) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer f.Close()
+	if offset < 0 {
+		info, err := f.Stat()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		offset = info.Size() - n
+		if offset < 0 {
+			offset = 0
+		}
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, io.LimitReader(f, n)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return buf.Bytes(), nil
+}
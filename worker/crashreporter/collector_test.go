@@ -0,0 +1,193 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crashreporter_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/crashreporter"
+)
+
+type CollectorSuite struct {
+	testing.IsolationSuite
+	clock     *testing.Clock
+	logFile   string
+	store     *crashreporter.Store
+	collector *crashreporter.Collector
+}
+
+var _ = gc.Suite(&CollectorSuite{})
+
+func (s *CollectorSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	dir := c.MkDir()
+	s.clock = testing.NewClock(time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC))
+	s.logFile = filepath.Join(dir, "machine-0.log")
+	s.store = crashreporter.NewStore(filepath.Join(dir, "crashreports"))
+	s.collector = crashreporter.NewCollector(s.store, s.logFile, s.clock)
+}
+
+const crashLog = `
+2018-03-01 11:59:00 INFO juju.worker starting
+2018-03-01 11:59:01 DEBUG juju.worker running
+panic: boom
+
+goroutine 1 [running]:
+main.main()
+`
+
+func (s *CollectorSuite) writeLog(c *gc.C, content string) {
+	err := ioutil.WriteFile(s.logFile, []byte(content), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CollectorSuite) appendLog(c *gc.C, content string) {
+	f, err := os.OpenFile(s.logFile, os.O_APPEND|os.O_WRONLY, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	_, err = f.WriteString(content)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CollectorSuite) pendingBundles(c *gc.C) ([]crashreporter.Report, []map[string]string) {
+	pending, err := s.store.Pending()
+	c.Assert(err, jc.ErrorIsNil)
+	var bundles []map[string]string
+	for _, report := range pending {
+		data, err := s.store.Bundle(report.Name)
+		c.Assert(err, jc.ErrorIsNil)
+		bundles = append(bundles, readBundle(c, data))
+	}
+	return pending, bundles
+}
+
+func (s *CollectorSuite) TestCollectPreviousWithoutLog(c *gc.C) {
+	err := s.collector.CollectPrevious()
+	c.Assert(err, jc.ErrorIsNil)
+	pending, _ := s.pendingBundles(c)
+	c.Assert(pending, gc.HasLen, 0)
+}
+
+func (s *CollectorSuite) TestCollectPrevious(c *gc.C) {
+	err := s.store.SaveEngineReport(map[string]interface{}{"state": "started"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.writeLog(c, crashLog[1:])
+	info, err := os.Stat(s.logFile)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.collector.CollectPrevious()
+	c.Assert(err, jc.ErrorIsNil)
+
+	pending, bundles := s.pendingBundles(c)
+	c.Assert(pending, gc.HasLen, 1)
+	c.Assert(pending[0].Reason, gc.Equals, "panic: boom")
+	c.Assert(pending[0].Time.Equal(info.ModTime()), jc.IsTrue)
+	c.Assert(bundles[0], jc.DeepEquals, map[string]string{
+		"reason.txt":         "panic: boom",
+		"goroutines.txt":     "panic: boom\n\ngoroutine 1 [running]:\nmain.main()\n",
+		"engine-report.yaml": "state: started\n",
+		"agent.log": "" +
+			"2018-03-01 11:59:00 INFO juju.worker starting\n" +
+			"2018-03-01 11:59:01 DEBUG juju.worker running\n",
+	})
+}
+
+func (s *CollectorSuite) TestCollectPreviousOnlyOnce(c *gc.C) {
+	s.writeLog(c, crashLog[1:])
+	err := s.collector.CollectPrevious()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.appendLog(c, "2018-03-01 12:00:00 INFO juju.worker restarted\n")
+	err = s.collector.CollectPrevious()
+	c.Assert(err, jc.ErrorIsNil)
+	pending, _ := s.pendingBundles(c)
+	c.Assert(pending, gc.HasLen, 1)
+
+	s.appendLog(c, "fatal error: concurrent map writes\n")
+	err = s.collector.CollectPrevious()
+	c.Assert(err, jc.ErrorIsNil)
+	pending, bundles := s.pendingBundles(c)
+	c.Assert(pending, gc.HasLen, 2)
+	c.Assert(pending[1].Reason, gc.Equals, "fatal error: concurrent map writes")
+	c.Assert(bundles[1]["agent.log"], gc.Equals, "2018-03-01 12:00:00 INFO juju.worker restarted\n")
+}
+
+func (s *CollectorSuite) TestCollectPreviousAfterRotation(c *gc.C) {
+	s.writeLog(c, "2018-03-01 11:00:00 INFO juju.worker a long line before rotation\n")
+	err := s.collector.CollectPrevious()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.writeLog(c, "panic: boom\n")
+	err = s.collector.CollectPrevious()
+	c.Assert(err, jc.ErrorIsNil)
+	pending, _ := s.pendingBundles(c)
+	c.Assert(pending, gc.HasLen, 1)
+	c.Assert(pending[0].Reason, gc.Equals, "panic: boom")
+}
+
+func (s *CollectorSuite) TestRecover(c *gc.C) {
+	s.writeLog(c, "2018-03-01 11:59:00 INFO juju.worker starting\n")
+	var recovered interface{}
+	func() {
+		defer func() {
+			recovered = recover()
+		}()
+		defer s.collector.Recover()
+		panic("kaboom")
+	}()
+	c.Assert(recovered, gc.Equals, "kaboom")
+
+	pending, bundles := s.pendingBundles(c)
+	c.Assert(pending, gc.HasLen, 1)
+	c.Assert(pending[0].Reason, gc.Equals, "panic: kaboom")
+	c.Assert(pending[0].Time.Equal(s.clock.Now()), jc.IsTrue)
+	c.Assert(bundles[0]["goroutines.txt"], gc.Matches, "(?s)goroutine .*")
+	c.Assert(bundles[0]["agent.log"], gc.Equals, "2018-03-01 11:59:00 INFO juju.worker starting\n")
+
+	// The panic written to the log as the agent died is not
+	// collected again.
+	s.appendLog(c, "panic: kaboom [recovered]\n")
+	err := s.collector.CollectPrevious()
+	c.Assert(err, jc.ErrorIsNil)
+	pending, _ = s.pendingBundles(c)
+	c.Assert(pending, gc.HasLen, 1)
+}
+
+func (s *CollectorSuite) TestRecoverWithoutPanic(c *gc.C) {
+	func() {
+		defer s.collector.Recover()
+	}()
+	pending, _ := s.pendingBundles(c)
+	c.Assert(pending, gc.HasLen, 0)
+}
+
+// readBundle returns the contents of the files in the given bundle.
+func readBundle(c *gc.C, data []byte) map[string]string {
+	gzr, err := gzip.NewReader(bytes.NewReader(data))
+	c.Assert(err, jc.ErrorIsNil)
+	tr := tar.NewReader(gzr)
+	files := make(map[string]string)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		content, err := ioutil.ReadAll(tr)
+		c.Assert(err, jc.ErrorIsNil)
+		files[hdr.Name] = string(content)
+	}
+	return files
+}
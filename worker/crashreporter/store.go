@@ -0,0 +1,231 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crashreporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	bundleSuffix   = ".tar.gz"
+	metadataSuffix = ".json"
+
+	engineReportFile = "engine-report.yaml"
+	logOffsetFile    = "log-offset"
+	recoveredFile    = "recovered"
+
+	// maxPendingReports is the number of crash reports kept on
+	// disk while they cannot be uploaded. An agent that crashes
+	// repeatedly while the controller is unreachable only keeps
+	// its most recent reports.
+	maxPendingReports = 5
+)
+
+// Dir returns the directory in which the agent with the given tag
+// keeps the crash reports it has yet to upload.
+func Dir(dataDir string, tag names.Tag) string {
+	return filepath.Join(dataDir, "crashreports", tag.String())
+}
+
+// Report describes a crash report waiting to be uploaded.
+type Report struct {
+	// Name identifies the report within its store.
+	Name string `json:"-"`
+
+	// Time is when the agent crashed.
+	Time time.Time `json:"time"`
+
+	// Reason describes the crash.
+	Reason string `json:"reason"`
+}
+
+// Store holds the crash reports collected by an agent until they are
+// uploaded to the controller. It keeps them on disk, so that reports
+// collected as the agent dies are uploaded after it restarts.
+type Store struct {
+	dir string
+}
+
+// NewStore returns a Store that keeps reports in the given directory,
+// which is created when the first report is saved.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Save stores a crash report with the given bundle, removing the
+// oldest reports if too many are waiting to be uploaded.
+func (s *Store) Save(report Report, bundle []byte) error {
+	if err := s.ensureDir(); err != nil {
+		return errors.Trace(err)
+	}
+	name := fmt.Sprintf("%020d", report.Time.UnixNano())
+	if err := utils.AtomicWriteFile(s.path(name+bundleSuffix), bundle, 0600); err != nil {
+		return errors.Annotate(err, "writing crash report bundle")
+	}
+	// The metadata is written last, as its presence marks the
+	// report as complete.
+	data, err := json.Marshal(report)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := utils.AtomicWriteFile(s.path(name+metadataSuffix), data, 0600); err != nil {
+		return errors.Annotate(err, "writing crash report metadata")
+	}
+
+	pending, err := s.Pending()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for len(pending) > maxPendingReports {
+		logger.Warningf("discarding crash report from %s", pending[0].Time)
+		if err := s.Remove(pending[0].Name); err != nil {
+			return errors.Trace(err)
+		}
+		pending = pending[1:]
+	}
+	return nil
+}
+
+// Pending returns the reports waiting to be uploaded, oldest first.
+func (s *Store) Pending() ([]Report, error) {
+	infos, err := ioutil.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var reports []Report
+	for _, info := range infos {
+		name := strings.TrimSuffix(info.Name(), metadataSuffix)
+		if name == info.Name() {
+			continue
+		}
+		if _, err := strconv.ParseInt(name, 10, 64); err != nil {
+			continue
+		}
+		data, err := ioutil.ReadFile(s.path(info.Name()))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var report Report
+		if err := json.Unmarshal(data, &report); err != nil {
+			return nil, errors.Annotatef(err, "reading crash report %q", name)
+		}
+		report.Name = name
+		reports = append(reports, report)
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Name < reports[j].Name
+	})
+	return reports, nil
+}
+
+// Bundle returns the bundle of the named report.
+func (s *Store) Bundle(name string) ([]byte, error) {
+	bundle, err := ioutil.ReadFile(s.path(name + bundleSuffix))
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("crash report %q", name)
+	}
+	return bundle, errors.Trace(err)
+}
+
+// Remove removes the named report.
+func (s *Store) Remove(name string) error {
+	// The metadata is removed first, so that a partly removed
+	// report is not seen as pending.
+	for _, suffix := range []string{metadataSuffix, bundleSuffix} {
+		if err := os.Remove(s.path(name + suffix)); err != nil && !os.IsNotExist(err) {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// SaveEngineReport records the state of the agent's dependency engine,
+// to be included in the bundles of later crashes.
+func (s *Store) SaveEngineReport(report map[string]interface{}) error {
+	data, err := yaml.Marshal(report)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := s.ensureDir(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(utils.AtomicWriteFile(s.path(engineReportFile), data, 0600))
+}
+
+// EngineReport returns the most recently saved engine report, or nil
+// if none has been saved.
+func (s *Store) EngineReport() ([]byte, error) {
+	data, err := ioutil.ReadFile(s.path(engineReportFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return data, errors.Trace(err)
+}
+
+// logOffset returns the offset in the agent's log up to which crashes
+// have been collected, and whether it has been recorded.
+func (s *Store) logOffset() (int64, bool, error) {
+	data, err := ioutil.ReadFile(s.path(logOffsetFile))
+	if os.IsNotExist(err) {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, errors.Trace(err)
+	}
+	offset, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		// Start again rather than never collecting crashes.
+		return 0, false, nil
+	}
+	return offset, true, nil
+}
+
+func (s *Store) setLogOffset(offset int64) error {
+	if err := s.ensureDir(); err != nil {
+		return errors.Trace(err)
+	}
+	data := []byte(strconv.FormatInt(offset, 10))
+	return errors.Trace(utils.AtomicWriteFile(s.path(logOffsetFile), data, 0600))
+}
+
+// setRecovered records that a panic has been collected as it
+// happened, so that the panic is not collected again from the log.
+func (s *Store) setRecovered() error {
+	if err := s.ensureDir(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(ioutil.WriteFile(s.path(recoveredFile), nil, 0600))
+}
+
+// takeRecovered reports whether a panic was collected as it happened,
+// and clears the record.
+func (s *Store) takeRecovered() (bool, error) {
+	err := os.Remove(s.path(recoveredFile))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return err == nil, errors.Trace(err)
+}
+
+func (s *Store) ensureDir() error {
+	return os.MkdirAll(s.dir, 0700)
+}
+
+func (s *Store) path(name string) string {
+	return filepath.Join(s.dir, name)
+}
@@ -0,0 +1,104 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crashreporter_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/worker/crashreporter"
+)
+
+type StoreSuite struct {
+	testing.IsolationSuite
+	store *crashreporter.Store
+}
+
+var _ = gc.Suite(&StoreSuite{})
+
+func (s *StoreSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.store = crashreporter.NewStore(filepath.Join(c.MkDir(), "crashreports"))
+}
+
+func (s *StoreSuite) TestDir(c *gc.C) {
+	dir := crashreporter.Dir("/var/lib/juju", names.NewUnitTag("app/0"))
+	c.Assert(dir, gc.Equals, "/var/lib/juju/crashreports/unit-app-0")
+}
+
+func (s *StoreSuite) TestEmpty(c *gc.C) {
+	pending, err := s.store.Pending()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pending, gc.HasLen, 0)
+	report, err := s.store.EngineReport()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report, gc.IsNil)
+}
+
+func (s *StoreSuite) TestSaveAndRemove(c *gc.C) {
+	t0 := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	err := s.store.Save(crashreporter.Report{Time: t0.Add(time.Second), Reason: "second"}, []byte("b"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.Save(crashreporter.Report{Time: t0, Reason: "first"}, []byte("a"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	pending, err := s.store.Pending()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pending, gc.HasLen, 2)
+	c.Assert(pending[0].Reason, gc.Equals, "first")
+	c.Assert(pending[0].Time.Equal(t0), jc.IsTrue)
+	c.Assert(pending[1].Reason, gc.Equals, "second")
+
+	bundle, err := s.store.Bundle(pending[0].Name)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(bundle), gc.Equals, "a")
+
+	err = s.store.Remove(pending[0].Name)
+	c.Assert(err, jc.ErrorIsNil)
+	pending, err = s.store.Pending()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pending, gc.HasLen, 1)
+	c.Assert(pending[0].Reason, gc.Equals, "second")
+}
+
+func (s *StoreSuite) TestSaveDiscardsOldest(c *gc.C) {
+	t0 := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 7; i++ {
+		report := crashreporter.Report{
+			Time:   t0.Add(time.Duration(i) * time.Second),
+			Reason: fmt.Sprint(i),
+		}
+		err := s.store.Save(report, []byte("bundle"))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	pending, err := s.store.Pending()
+	c.Assert(err, jc.ErrorIsNil)
+	var reasons []string
+	for _, report := range pending {
+		reasons = append(reasons, report.Reason)
+	}
+	c.Assert(reasons, jc.DeepEquals, []string{"2", "3", "4", "5", "6"})
+}
+
+func (s *StoreSuite) TestBundleNotFound(c *gc.C) {
+	_, err := s.store.Bundle("00000000000000000001")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *StoreSuite) TestEngineReport(c *gc.C) {
+	err := s.store.SaveEngineReport(map[string]interface{}{
+		"state": "started",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	report, err := s.store.EngineReport()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(report), gc.Equals, "state: started\n")
+}
@@ -0,0 +1,168 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package crashreporter collects diagnostics when an agent crashes,
+// and provides a worker that uploads them to the controller.
+//
+// A crash report bundle holds the stacks of the agent's goroutines,
+// the end of its log, and the last recorded report of its dependency
+// engine. Bundles are kept on disk until they are uploaded, so that
+// crashes on short-lived machines are not lost with the agent.
+package crashreporter
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.crashreporter")
+
+// Facade defines the interface we require from the crash reporter
+// facade.
+type Facade interface {
+	Upload(agent names.Tag, crashTime time.Time, reason string, bundle []byte) error
+}
+
+// Config holds the configuration and dependencies for a crash reporter
+// worker.
+type Config struct {
+	Facade Facade
+	Store  *Store
+	Agent  names.Tag
+	Clock  clock.Clock
+
+	// EngineReport, if set, returns the report of the agent's
+	// dependency engine, which is recorded for inclusion in the
+	// bundles of later crashes.
+	EngineReport func() map[string]interface{}
+
+	// Interval is how often the engine report is recorded, and
+	// uploads that failed are retried.
+	Interval time.Duration
+}
+
+// Validate returns an error if the config cannot be expected to drive
+// a functional crash reporter worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Store == nil {
+		return errors.NotValidf("nil Store")
+	}
+	if config.Agent == nil {
+		return errors.NotValidf("nil Agent")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that uploads the agent's crash reports to
+// the controller.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &reporterWorker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type reporterWorker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *reporterWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *reporterWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *reporterWorker) loop() error {
+	for {
+		if w.config.EngineReport != nil {
+			if err := w.config.Store.SaveEngineReport(w.config.EngineReport()); err != nil {
+				logger.Warningf("cannot record engine report: %v", err)
+			}
+		}
+		if err := w.uploadPending(); err != nil {
+			return errors.Trace(err)
+		}
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(w.config.Interval):
+		}
+	}
+}
+
+// uploadPending uploads the reports in the store, removing each once
+// it has been uploaded. Reports that fail to upload are retried
+// later, unless the controller refuses them as too large to store.
+func (w *reporterWorker) uploadPending() error {
+	reports, err := w.config.Store.Pending()
+	if err != nil {
+		return errors.Annotate(err, "reading crash reports")
+	}
+	for _, report := range reports {
+		done, err := w.upload(report)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !done {
+			return nil
+		}
+		if err := w.config.Store.Remove(report.Name); err != nil {
+			return errors.Annotate(err, "removing crash report")
+		}
+	}
+	return nil
+}
+
+// upload uploads the given report, and returns whether the report is
+// finished with and should be removed from the store.
+func (w *reporterWorker) upload(report Report) (bool, error) {
+	bundle, err := w.config.Store.Bundle(report.Name)
+	if errors.IsNotFound(err) {
+		logger.Warningf("discarding crash report %q without bundle", report.Name)
+		return true, nil
+	} else if err != nil {
+		return false, errors.Annotate(err, "reading crash report")
+	}
+	err = w.config.Facade.Upload(w.config.Agent, report.Time, report.Reason, bundle)
+	switch {
+	case err == nil:
+		logger.Infof("uploaded crash report from %s", report.Time)
+		return true, nil
+	case params.IsCodeQuotaExceeded(err):
+		logger.Warningf("discarding crash report from %s: %v", report.Time, err)
+		return true, nil
+	default:
+		logger.Warningf("cannot upload crash report from %s: %v", report.Time, err)
+		return false, nil
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crashreporter_test
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/crashreporter"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+
+	clock  *testing.Clock
+	facade *mockFacade
+	store  *crashreporter.Store
+	config crashreporter.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+var crashTime = time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Time{})
+	s.facade = &mockFacade{
+		uploaded: make(chan string, 10),
+	}
+	s.store = crashreporter.NewStore(filepath.Join(c.MkDir(), "crashreports"))
+	s.config = crashreporter.Config{
+		Facade: s.facade,
+		Store:  s.store,
+		Agent:  names.NewMachineTag("0"),
+		Clock:  s.clock,
+		EngineReport: func() map[string]interface{} {
+			return map[string]interface{}{"state": "started"}
+		},
+		Interval: time.Minute,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	s.testValidate(c, func(cfg *crashreporter.Config) {
+		cfg.Facade = nil
+	}, "nil Facade not valid")
+	s.testValidate(c, func(cfg *crashreporter.Config) {
+		cfg.Store = nil
+	}, "nil Store not valid")
+	s.testValidate(c, func(cfg *crashreporter.Config) {
+		cfg.Agent = nil
+	}, "nil Agent not valid")
+	s.testValidate(c, func(cfg *crashreporter.Config) {
+		cfg.Clock = nil
+	}, "nil Clock not valid")
+	s.testValidate(c, func(cfg *crashreporter.Config) {
+		cfg.Interval = 0
+	}, "non-positive Interval not valid")
+}
+
+func (s *WorkerSuite) testValidate(c *gc.C, f func(*crashreporter.Config), expect string) {
+	config := s.config
+	f(&config)
+	w, err := crashreporter.NewWorker(config)
+	if !c.Check(err, gc.NotNil) {
+		workertest.DirtyKill(c, w)
+		return
+	}
+	c.Check(w, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, expect)
+}
+
+func (s *WorkerSuite) saveReport(c *gc.C, reason string, offset time.Duration) {
+	report := crashreporter.Report{Time: crashTime.Add(offset), Reason: reason}
+	err := s.store.Save(report, []byte("bundle-"+reason))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *WorkerSuite) checkPending(c *gc.C, expect ...string) {
+	pending, err := s.store.Pending()
+	c.Assert(err, jc.ErrorIsNil)
+	var reasons []string
+	for _, report := range pending {
+		reasons = append(reasons, report.Reason)
+	}
+	c.Assert(reasons, jc.DeepEquals, expect)
+}
+
+func (s *WorkerSuite) TestUploadsPending(c *gc.C) {
+	s.saveReport(c, "first", 0)
+	s.saveReport(c, "second", time.Second)
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	c.Assert(s.waitUploaded(c), gc.Equals, "first")
+	c.Assert(s.waitUploaded(c), gc.Equals, "second")
+	// Wait for the worker to finish the round before checking.
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkPending(c)
+
+	s.facade.CheckCalls(c, []testing.StubCall{
+		{"Upload", []interface{}{names.NewMachineTag("0"), crashTime, "first", "bundle-first"}},
+		{"Upload", []interface{}{names.NewMachineTag("0"), crashTime.Add(time.Second), "second", "bundle-second"}},
+	})
+
+	report, err := s.store.EngineReport()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(report), gc.Equals, "state: started\n")
+}
+
+func (s *WorkerSuite) TestRetriesFailedUpload(c *gc.C) {
+	s.facade.SetErrors(errors.New("boom"))
+	s.saveReport(c, "first", 0)
+	s.saveReport(c, "second", time.Second)
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	c.Assert(s.waitUploaded(c), gc.Equals, "first")
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.waitUploaded(c), gc.Equals, "first")
+	c.Assert(s.waitUploaded(c), gc.Equals, "second")
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkPending(c)
+	workertest.CheckAlive(c, w)
+}
+
+func (s *WorkerSuite) TestDiscardsReportOverQuota(c *gc.C) {
+	s.facade.SetErrors(&params.Error{Code: params.CodeQuotaExceeded, Message: "too big"})
+	s.saveReport(c, "huge", 0)
+	s.saveReport(c, "small", time.Second)
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	c.Assert(s.waitUploaded(c), gc.Equals, "huge")
+	c.Assert(s.waitUploaded(c), gc.Equals, "small")
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkPending(c)
+}
+
+func (s *WorkerSuite) TestUploadsReportsSavedLater(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade.CheckNoCalls(c)
+
+	s.saveReport(c, "late", 0)
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.waitUploaded(c), gc.Equals, "late")
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := crashreporter.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *WorkerSuite) waitUploaded(c *gc.C) string {
+	select {
+	case reason := <-s.facade.uploaded:
+		return reason
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for crash report upload")
+	}
+	panic("unreachable")
+}
+
+type mockFacade struct {
+	testing.Stub
+	uploaded chan string
+}
+
+func (f *mockFacade) Upload(agent names.Tag, crashTime time.Time, reason string, bundle []byte) error {
+	f.MethodCall(f, "Upload", agent, crashTime, reason, string(bundle))
+	f.uploaded <- reason
+	return f.NextErr()
+}
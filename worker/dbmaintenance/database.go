@@ -0,0 +1,334 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dbmaintenance
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state"
+)
+
+// jujuDB is the name of the database that is maintained.
+const jujuDB = "juju"
+
+// MemberStatus describes the local member of the controller's
+// database replica set.
+type MemberStatus struct {
+	// Primary is true if the member is the replica set's primary.
+	Primary bool
+
+	// Secondary is true if the member is one of the replica set's
+	// secondaries.
+	Secondary bool
+
+	// Healthy is true if every member of the replica set is healthy
+	// and either a primary, a secondary or an arbiter.
+	Healthy bool
+}
+
+// Database defines the operations the worker performs on the local
+// member of the controller's database replica set.
+type Database interface {
+	// MemberStatus returns the status of the local member.
+	MemberStatus() (MemberStatus, error)
+
+	// CollectionStats returns the sizes of the juju database's
+	// collections.
+	CollectionStats() ([]state.CollectionStats, error)
+
+	// UnusedIndexes returns the indexes of the juju database's
+	// collections that have not been used since the member started.
+	UnusedIndexes() ([]state.IndexUsage, error)
+
+	// CollectionScans returns the query patterns that the member's
+	// profiler has recently seen answered by scanning whole
+	// collections.
+	CollectionScans() ([]state.QueryPattern, error)
+
+	// Compact compacts the named collection of the juju database.
+	// It returns an error satisfying errors.IsNotSupported if the
+	// collection cannot be compacted.
+	Compact(collection string) error
+
+	// Close releases the database's resources.
+	Close()
+}
+
+// NewDatabase returns a Database that operates directly on the
+// member of the controller's replica set described by the given
+// info.
+func NewDatabase(info *mongo.MongoInfo) (Database, error) {
+	session, err := mongo.DialWithInfo(*info, mongo.DialOpts{
+		Timeout: time.Minute,
+		Direct:  true,
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot connect to database")
+	}
+	// Reads must be served by the local member, even when it is
+	// a secondary.
+	session.SetMode(mgo.Monotonic, true)
+	return &database{session: session}, nil
+}
+
+type database struct {
+	session *mgo.Session
+}
+
+// Close is part of the Database interface.
+func (db *database) Close() {
+	db.session.Close()
+}
+
+// MemberStatus is part of the Database interface.
+func (db *database) MemberStatus() (MemberStatus, error) {
+	status, err := replicaset.CurrentStatus(db.session)
+	if err != nil {
+		return MemberStatus{}, errors.Annotate(err, "cannot get replica set status")
+	}
+	result := MemberStatus{Healthy: true}
+	for _, member := range status.Members {
+		switch member.State {
+		case replicaset.PrimaryState, replicaset.SecondaryState, replicaset.ArbiterState:
+		default:
+			result.Healthy = false
+		}
+		if !member.Healthy {
+			result.Healthy = false
+		}
+		if member.Self {
+			result.Primary = member.State == replicaset.PrimaryState
+			result.Secondary = member.State == replicaset.SecondaryState
+		}
+	}
+	return result, nil
+}
+
+type collStats struct {
+	Count          int64 `bson:"count"`
+	Size           int64 `bson:"size"`
+	StorageSize    int64 `bson:"storageSize"`
+	TotalIndexSize int64 `bson:"totalIndexSize"`
+	Capped         bool  `bson:"capped"`
+}
+
+func (db *database) collStats(name string) (collStats, error) {
+	var stats collStats
+	err := db.session.DB(jujuDB).Run(bson.D{{"collStats", name}}, &stats)
+	if err != nil {
+		return collStats{}, errors.Annotatef(err, "cannot get stats of collection %q", name)
+	}
+	return stats, nil
+}
+
+// collectionNames returns the names of the juju database's
+// collections, excluding mongo's own.
+func (db *database) collectionNames() ([]string, error) {
+	names, err := db.session.DB(jujuDB).CollectionNames()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot list collections")
+	}
+	result := names[:0]
+	for _, name := range names {
+		if !strings.HasPrefix(name, "system.") {
+			result = append(result, name)
+		}
+	}
+	return result, nil
+}
+
+// CollectionStats is part of the Database interface.
+func (db *database) CollectionStats() ([]state.CollectionStats, error) {
+	names, err := db.collectionNames()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]state.CollectionStats, len(names))
+	for i, name := range names {
+		stats, err := db.collStats(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result[i] = state.CollectionStats{
+			Name:             name,
+			Count:            stats.Count,
+			SizeBytes:        stats.Size,
+			StorageSizeBytes: stats.StorageSize,
+			IndexSizeBytes:   stats.TotalIndexSize,
+		}
+	}
+	return result, nil
+}
+
+type indexStats struct {
+	Name     string `bson:"name"`
+	Accesses struct {
+		Ops   int64     `bson:"ops"`
+		Since time.Time `bson:"since"`
+	} `bson:"accesses"`
+}
+
+// UnusedIndexes is part of the Database interface.
+func (db *database) UnusedIndexes() ([]state.IndexUsage, error) {
+	names, err := db.collectionNames()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []state.IndexUsage
+	for _, name := range names {
+		var indexes []indexStats
+		pipeline := []bson.M{{"$indexStats": bson.M{}}}
+		err := db.session.DB(jujuDB).C(name).Pipe(pipeline).All(&indexes)
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot get index stats of collection %q", name)
+		}
+		for _, index := range indexes {
+			// The _id index is required, whether or not it is used.
+			if index.Name == "_id_" || index.Accesses.Ops > 0 {
+				continue
+			}
+			result = append(result, state.IndexUsage{
+				Collection: name,
+				Name:       index.Name,
+				Since:      index.Accesses.Since.UTC(),
+			})
+		}
+	}
+	return result, nil
+}
+
+// CollectionScans is part of the Database interface. The profiler is
+// enabled for slow operations if it is not already, so the first call
+// will usually find none.
+func (db *database) CollectionScans() ([]state.QueryPattern, error) {
+	jdb := db.session.DB(jujuDB)
+	var profile struct {
+		Was int `bson:"was"`
+	}
+	if err := jdb.Run(bson.D{{"profile", -1}}, &profile); err != nil {
+		return nil, errors.Annotate(err, "cannot get profiling level")
+	}
+	if profile.Was == 0 {
+		if err := jdb.Run(bson.D{{"profile", 1}}, nil); err != nil {
+			return nil, errors.Annotate(err, "cannot enable profiling")
+		}
+		return nil, nil
+	}
+
+	var entries []struct {
+		Namespace string `bson:"ns"`
+		Query     bson.M `bson:"query"`
+		Command   bson.M `bson:"command"`
+	}
+	query := bson.D{{"planSummary", "COLLSCAN"}}
+	err := jdb.C("system.profile").Find(query).Select(bson.M{
+		"ns":      1,
+		"query":   1,
+		"command": 1,
+	}).All(&entries)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read profile")
+	}
+
+	patterns := make(map[string]*state.QueryPattern)
+	for _, entry := range entries {
+		collection := strings.TrimPrefix(entry.Namespace, jujuDB+".")
+		if strings.HasPrefix(collection, "system.") {
+			continue
+		}
+		filter := profiledFilter(entry.Query, entry.Command)
+		fields := filterFields(filter)
+		if len(fields) == 0 {
+			continue
+		}
+		key := collection + " " + strings.Join(fields, ",")
+		if pattern, ok := patterns[key]; ok {
+			pattern.Count++
+			continue
+		}
+		patterns[key] = &state.QueryPattern{
+			Collection: collection,
+			Fields:     fields,
+			Count:      1,
+		}
+	}
+	keys := make([]string, 0, len(patterns))
+	for key := range patterns {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	result := make([]state.QueryPattern, len(keys))
+	for i, key := range keys {
+		result[i] = *patterns[key]
+	}
+	return result, nil
+}
+
+// profiledFilter returns the filter of a profiled operation. Older
+// versions of mongo record it in the query, newer in the command.
+func profiledFilter(query, command bson.M) bson.M {
+	for _, doc := range []bson.M{command, query} {
+		if doc == nil {
+			continue
+		}
+		for _, key := range []string{"filter", "q", "query"} {
+			if filter, ok := doc[key].(bson.M); ok {
+				return filter
+			}
+		}
+	}
+	return query
+}
+
+// filterFields returns the sorted names of the fields on which the
+// given filter matches, looking inside $and, $or and $nor clauses.
+func filterFields(filter bson.M) []string {
+	seen := make(map[string]bool)
+	var collect func(bson.M)
+	collect = func(filter bson.M) {
+		for key, value := range filter {
+			if !strings.HasPrefix(key, "$") {
+				seen[key] = true
+				continue
+			}
+			clauses, _ := value.([]interface{})
+			for _, clause := range clauses {
+				if clause, ok := clause.(bson.M); ok {
+					collect(clause)
+				}
+			}
+		}
+	}
+	collect(filter)
+	fields := make([]string, 0, len(seen))
+	for field := range seen {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// Compact is part of the Database interface.
+func (db *database) Compact(collection string) error {
+	stats, err := db.collStats(collection)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if stats.Capped {
+		return errors.NotSupportedf("compacting capped collection %q", collection)
+	}
+	err = db.session.DB(jujuDB).Run(bson.D{{"compact", collection}}, nil)
+	if err != nil {
+		return errors.Annotatef(err, "cannot compact collection %q", collection)
+	}
+	return nil
+}
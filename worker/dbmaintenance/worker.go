@@ -0,0 +1,296 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package dbmaintenance provides a worker that maintains a controller
+// machine's member of the controller's database replica set. It
+// periodically records the sizes of the juju database's collections,
+// the indexes that are never used and the queries that scan whole
+// collections; the Controller facade aggregates these reports from
+// all controller machines into a report of unused and missing indexes.
+//
+// Within the controller's db-compaction-windows, the worker compacts
+// its member's collections to return the space freed by deleted
+// documents to the filesystem. Compaction blocks the member, so only
+// healthy secondaries are compacted, and the controller machines
+// claim the right to compact in state so that only one member is
+// compacted at a time.
+package dbmaintenance
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/maintenance"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.dbmaintenance")
+
+const (
+	// compactionInterval is the minimum time between the
+	// compactions of a member.
+	compactionInterval = 24 * time.Hour
+
+	// claimDuration is the duration of the claim to compact, which
+	// is extended before each collection is compacted.
+	claimDuration = time.Hour
+)
+
+// Backend defines the state functionality required by the worker.
+type Backend interface {
+	ControllerConfig() (controller.Config, error)
+	AllDatabaseReports() ([]state.DatabaseReport, error)
+	SetDatabaseReport(state.DatabaseReport) error
+	ClaimDatabaseCompaction(machineId string, duration time.Duration) (bool, error)
+	ReleaseDatabaseCompaction(machineId string) error
+}
+
+// Config holds the configuration and dependencies for a database
+// maintenance worker.
+type Config struct {
+	MachineId string
+	Backend   Backend
+	Database  Database
+	Clock     clock.Clock
+	Interval  time.Duration
+}
+
+// Validate returns an error if the config cannot be expected to
+// drive a functional database maintenance worker.
+func (config Config) Validate() error {
+	if config.MachineId == "" {
+		return errors.NotValidf("empty MachineId")
+	}
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Database == nil {
+		return errors.NotValidf("nil Database")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that reports on, and compacts, the
+// controller machine's database member every configured interval.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &maintainer{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type maintainer struct {
+	catacomb       catacomb.Catacomb
+	config         Config
+	lastCompaction *state.Compaction
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *maintainer) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *maintainer) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *maintainer) loop() error {
+	reports, err := w.config.Backend.AllDatabaseReports()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, report := range reports {
+		if report.MachineId == w.config.MachineId {
+			w.lastCompaction = report.LastCompaction
+		}
+	}
+	if err := w.maintain(); err != nil {
+		return errors.Annotate(err, "maintaining database")
+	}
+	timer := w.config.Clock.NewTimer(w.config.Interval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-timer.Chan():
+			if err := w.maintain(); err != nil {
+				return errors.Annotate(err, "maintaining database")
+			}
+			timer.Reset(w.config.Interval)
+		}
+	}
+}
+
+func (w *maintainer) maintain() error {
+	status, err := w.config.Database.MemberStatus()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.report(status); err != nil {
+		return errors.Trace(err)
+	}
+	compact, err := w.shouldCompact(status)
+	if err != nil || !compact {
+		return errors.Trace(err)
+	}
+	compacted, err := w.compact()
+	if err != nil || !compacted {
+		return errors.Trace(err)
+	}
+	// Report the sizes of the compacted collections.
+	return errors.Trace(w.report(status))
+}
+
+func (w *maintainer) report(status MemberStatus) error {
+	collections, err := w.config.Database.CollectionStats()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	unused, err := w.config.Database.UnusedIndexes()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	scans, err := w.config.Database.CollectionScans()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(w.config.Backend.SetDatabaseReport(state.DatabaseReport{
+		MachineId:       w.config.MachineId,
+		Updated:         w.config.Clock.Now(),
+		Primary:         status.Primary,
+		Collections:     collections,
+		UnusedIndexes:   unused,
+		CollectionScans: scans,
+		LastCompaction:  w.lastCompaction,
+	}))
+}
+
+// shouldCompact reports whether the member should now be compacted:
+// whether it is a secondary of a healthy replica set that has not
+// been compacted recently, and a compaction window is open.
+func (w *maintainer) shouldCompact(status MemberStatus) (bool, error) {
+	if !status.Secondary || !status.Healthy {
+		return false, nil
+	}
+	now := w.config.Clock.Now()
+	if last := w.lastCompaction; last != nil && now.Sub(last.Finished) < compactionInterval {
+		return false, nil
+	}
+	return w.windowOpen()
+}
+
+// windowOpen reports whether one of the controller's database
+// compaction windows is open. A controller without windows never
+// compacts its database.
+func (w *maintainer) windowOpen() (bool, error) {
+	cfg, err := w.config.Backend.ControllerConfig()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	periods, err := maintenance.ParsePeriods(cfg.DBCompactionWindows())
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	now := w.config.Clock.Now()
+	for _, period := range periods {
+		if period.Contains(now) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// compact compacts the member's collections one at a time, stopping
+// early if the worker is stopped, the compaction window closes or
+// the claim to compact is lost. It returns false if another member
+// is being compacted.
+func (w *maintainer) compact() (bool, error) {
+	claimed, err := w.claim()
+	if err != nil || !claimed {
+		return false, errors.Trace(err)
+	}
+	defer func() {
+		if err := w.config.Backend.ReleaseDatabaseCompaction(w.config.MachineId); err != nil {
+			logger.Errorf("cannot release database compaction: %v", err)
+		}
+	}()
+
+	collections, err := w.config.Database.CollectionStats()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	compaction := &state.Compaction{Started: w.config.Clock.Now()}
+	logger.Infof("compacting database")
+	for i, stats := range collections {
+		if i > 0 {
+			if stop, err := w.stopCompacting(); err != nil {
+				return false, errors.Trace(err)
+			} else if stop {
+				break
+			}
+		}
+		err := w.config.Database.Compact(stats.Name)
+		if errors.IsNotSupported(err) {
+			logger.Debugf("not compacting: %v", err)
+			continue
+		} else if err != nil {
+			logger.Errorf("%v", err)
+			compaction.Error = err.Error()
+			break
+		}
+		compaction.Collections = append(compaction.Collections, stats.Name)
+	}
+	compaction.Finished = w.config.Clock.Now()
+	logger.Infof("compacted %d collections", len(compaction.Collections))
+	w.lastCompaction = compaction
+	return true, nil
+}
+
+// stopCompacting reports whether compaction should stop before the
+// next collection, extending the claim to compact if not.
+func (w *maintainer) stopCompacting() (bool, error) {
+	select {
+	case <-w.catacomb.Dying():
+		return true, nil
+	default:
+	}
+	open, err := w.windowOpen()
+	if err != nil || !open {
+		return true, errors.Trace(err)
+	}
+	claimed, err := w.claim()
+	return !claimed, errors.Trace(err)
+}
+
+func (w *maintainer) claim() (bool, error) {
+	claimed, err := w.config.Backend.ClaimDatabaseCompaction(w.config.MachineId, claimDuration)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if !claimed {
+		logger.Debugf("another controller is compacting its database")
+	}
+	return claimed, nil
+}
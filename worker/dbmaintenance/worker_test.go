@@ -0,0 +1,339 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dbmaintenance_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/dbmaintenance"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+
+	clock    *testing.Clock
+	backend  *mockBackend
+	database *mockDatabase
+	config   dbmaintenance.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+var collections = []state.CollectionStats{{
+	Name:             "txns",
+	Count:            10,
+	SizeBytes:        1000,
+	StorageSizeBytes: 4096,
+	IndexSizeBytes:   512,
+}, {
+	Name:  "txns.log",
+	Count: 20,
+}, {
+	Name:  "units",
+	Count: 3,
+}}
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	// A Sunday, within the compaction window.
+	s.clock = testing.NewClock(time.Date(2018, 3, 4, 3, 0, 0, 0, time.UTC))
+	s.backend = &mockBackend{
+		config: controller.Config{
+			controller.DBCompactionWindowsKey: "sun 02:00-05:00",
+		},
+		claimed: true,
+		reports: make(chan state.DatabaseReport, 2),
+	}
+	s.database = &mockDatabase{
+		status:      dbmaintenance.MemberStatus{Secondary: true, Healthy: true},
+		collections: collections,
+		unused: []state.IndexUsage{{
+			Collection: "units",
+			Name:       "model-uuid_1_name_1",
+		}},
+		scans: []state.QueryPattern{{
+			Collection: "statuseshistory",
+			Fields:     []string{"globalkey"},
+			Count:      2,
+		}},
+		compactErrors: map[string]error{
+			"txns.log": errors.NotSupportedf("compacting capped collection"),
+		},
+	}
+	s.config = dbmaintenance.Config{
+		MachineId: "1",
+		Backend:   s.backend,
+		Database:  s.database,
+		Clock:     s.clock,
+		Interval:  time.Minute,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	s.testValidate(c, func(cfg *dbmaintenance.Config) {
+		cfg.MachineId = ""
+	}, "empty MachineId not valid")
+	s.testValidate(c, func(cfg *dbmaintenance.Config) {
+		cfg.Backend = nil
+	}, "nil Backend not valid")
+	s.testValidate(c, func(cfg *dbmaintenance.Config) {
+		cfg.Database = nil
+	}, "nil Database not valid")
+	s.testValidate(c, func(cfg *dbmaintenance.Config) {
+		cfg.Clock = nil
+	}, "nil Clock not valid")
+	s.testValidate(c, func(cfg *dbmaintenance.Config) {
+		cfg.Interval = 0
+	}, "non-positive Interval not valid")
+}
+
+func (s *WorkerSuite) testValidate(c *gc.C, f func(*dbmaintenance.Config), expect string) {
+	config := s.config
+	f(&config)
+	w, err := dbmaintenance.NewWorker(config)
+	if !c.Check(err, gc.NotNil) {
+		workertest.DirtyKill(c, w)
+		return
+	}
+	c.Check(w, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, expect)
+}
+
+func (s *WorkerSuite) TestReportsImmediately(c *gc.C) {
+	s.database.status = dbmaintenance.MemberStatus{Primary: true, Healthy: true}
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	report := s.nextReport(c)
+	c.Assert(report, jc.DeepEquals, state.DatabaseReport{
+		MachineId:       "1",
+		Updated:         s.clock.Now(),
+		Primary:         true,
+		Collections:     collections,
+		UnusedIndexes:   s.database.unused,
+		CollectionScans: s.database.scans,
+	})
+	workertest.CleanKill(c, w)
+	s.database.CheckCallNames(c, "MemberStatus", "CollectionStats", "UnusedIndexes", "CollectionScans")
+}
+
+func (s *WorkerSuite) TestReportsEveryInterval(c *gc.C) {
+	s.database.status = dbmaintenance.MemberStatus{Primary: true, Healthy: true}
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.nextReport(c)
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	report := s.nextReport(c)
+	c.Assert(report.Updated, gc.Equals, s.clock.Now())
+}
+
+func (s *WorkerSuite) TestCompactsSecondaryInWindow(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	report := s.nextReport(c)
+	c.Assert(report.LastCompaction, gc.IsNil)
+	report = s.nextReport(c)
+	c.Assert(report.LastCompaction, jc.DeepEquals, &state.Compaction{
+		Started:     s.clock.Now(),
+		Finished:    s.clock.Now(),
+		Collections: []string{"txns", "units"},
+	})
+	workertest.CleanKill(c, w)
+
+	var compacted []interface{}
+	for _, call := range s.database.Calls() {
+		if call.FuncName == "Compact" {
+			compacted = append(compacted, call.Args[0])
+		}
+	}
+	c.Assert(compacted, jc.DeepEquals, []interface{}{"txns", "txns.log", "units"})
+	s.backend.CheckCall(c, len(s.backend.Calls())-2, "ReleaseDatabaseCompaction", "1")
+}
+
+func (s *WorkerSuite) TestCompactionError(c *gc.C) {
+	s.database.compactErrors["txns"] = errors.New("kaboom")
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.nextReport(c)
+	report := s.nextReport(c)
+	c.Assert(report.LastCompaction, gc.NotNil)
+	c.Assert(report.LastCompaction.Collections, gc.HasLen, 0)
+	c.Assert(report.LastCompaction.Error, gc.Equals, "kaboom")
+}
+
+func (s *WorkerSuite) TestNoCompactionWithoutWindows(c *gc.C) {
+	s.backend.config = controller.Config{}
+	s.testNoCompaction(c)
+}
+
+func (s *WorkerSuite) TestNoCompactionOutsideWindow(c *gc.C) {
+	s.clock = testing.NewClock(time.Date(2018, 3, 4, 6, 0, 0, 0, time.UTC))
+	s.config.Clock = s.clock
+	s.testNoCompaction(c)
+}
+
+func (s *WorkerSuite) TestNoCompactionOfPrimary(c *gc.C) {
+	s.database.status = dbmaintenance.MemberStatus{Primary: true, Healthy: true}
+	s.testNoCompaction(c)
+}
+
+func (s *WorkerSuite) TestNoCompactionWhenUnhealthy(c *gc.C) {
+	s.database.status.Healthy = false
+	s.testNoCompaction(c)
+}
+
+func (s *WorkerSuite) TestNoCompactionWhenRecentlyCompacted(c *gc.C) {
+	s.backend.last = &state.Compaction{
+		Started:  s.clock.Now().Add(-2 * time.Hour),
+		Finished: s.clock.Now().Add(-time.Hour),
+	}
+	s.testNoCompaction(c)
+}
+
+func (s *WorkerSuite) TestNoCompactionWhenClaimedElsewhere(c *gc.C) {
+	s.backend.claimed = false
+	s.testNoCompaction(c)
+	s.backend.CheckCallNames(c,
+		"AllDatabaseReports",
+		"SetDatabaseReport",
+		"ControllerConfig",
+		"ClaimDatabaseCompaction",
+		"SetDatabaseReport",
+		"ControllerConfig",
+		"ClaimDatabaseCompaction",
+	)
+}
+
+func (s *WorkerSuite) testNoCompaction(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.nextReport(c)
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	report := s.nextReport(c)
+	c.Assert(report.LastCompaction, jc.DeepEquals, s.backend.last)
+	workertest.CleanKill(c, w)
+
+	for _, call := range s.database.Calls() {
+		c.Check(call.FuncName, gc.Not(gc.Equals), "Compact")
+	}
+}
+
+func (s *WorkerSuite) TestSetDatabaseReportError(c *gc.C) {
+	s.backend.SetErrors(nil, errors.New("kaboom"))
+	w := s.startWorker(c)
+	defer workertest.DirtyKill(c, w)
+
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "maintaining database: kaboom")
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := dbmaintenance.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *WorkerSuite) nextReport(c *gc.C) state.DatabaseReport {
+	select {
+	case report := <-s.backend.reports:
+		return report
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for database report")
+	}
+	panic("unreachable")
+}
+
+type mockBackend struct {
+	testing.Stub
+	config  controller.Config
+	last    *state.Compaction
+	claimed bool
+	reports chan state.DatabaseReport
+}
+
+func (b *mockBackend) ControllerConfig() (controller.Config, error) {
+	b.MethodCall(b, "ControllerConfig")
+	return b.config, b.NextErr()
+}
+
+func (b *mockBackend) AllDatabaseReports() ([]state.DatabaseReport, error) {
+	b.MethodCall(b, "AllDatabaseReports")
+	return []state.DatabaseReport{
+		{MachineId: "0"},
+		{MachineId: "1", LastCompaction: b.last},
+	}, b.NextErr()
+}
+
+func (b *mockBackend) SetDatabaseReport(report state.DatabaseReport) error {
+	b.MethodCall(b, "SetDatabaseReport", report)
+	if err := b.NextErr(); err != nil {
+		return err
+	}
+	b.reports <- report
+	return nil
+}
+
+func (b *mockBackend) ClaimDatabaseCompaction(machineId string, duration time.Duration) (bool, error) {
+	b.MethodCall(b, "ClaimDatabaseCompaction", machineId, duration)
+	return b.claimed, b.NextErr()
+}
+
+func (b *mockBackend) ReleaseDatabaseCompaction(machineId string) error {
+	b.MethodCall(b, "ReleaseDatabaseCompaction", machineId)
+	return b.NextErr()
+}
+
+type mockDatabase struct {
+	testing.Stub
+	status        dbmaintenance.MemberStatus
+	collections   []state.CollectionStats
+	unused        []state.IndexUsage
+	scans         []state.QueryPattern
+	compactErrors map[string]error
+}
+
+func (d *mockDatabase) MemberStatus() (dbmaintenance.MemberStatus, error) {
+	d.MethodCall(d, "MemberStatus")
+	return d.status, d.NextErr()
+}
+
+func (d *mockDatabase) CollectionStats() ([]state.CollectionStats, error) {
+	d.MethodCall(d, "CollectionStats")
+	return d.collections, d.NextErr()
+}
+
+func (d *mockDatabase) UnusedIndexes() ([]state.IndexUsage, error) {
+	d.MethodCall(d, "UnusedIndexes")
+	return d.unused, d.NextErr()
+}
+
+func (d *mockDatabase) CollectionScans() ([]state.QueryPattern, error) {
+	d.MethodCall(d, "CollectionScans")
+	return d.scans, d.NextErr()
+}
+
+func (d *mockDatabase) Compact(collection string) error {
+	d.MethodCall(d, "Compact", collection)
+	return d.compactErrors[collection]
+}
+
+func (d *mockDatabase) Close() {
+	d.MethodCall(d, "Close")
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/catacomb"
 )
 
 var logger = loggo.GetLogger("juju.worker.deployer")
@@ -26,6 +27,7 @@ var logger = loggo.GetLogger("juju.worker.deployer")
 // to changes in a set of state units; and for the final removal of its agents'
 // units from state when they are no longer needed.
 type Deployer struct {
+	catacomb catacomb.Catacomb
 	st       *apideployer.State
 	ctx      Context
 	deployed set.Strings
@@ -61,50 +63,111 @@ func NewDeployer(st *apideployer.State, ctx Context) (worker.Worker, error) {
 		ctx:      ctx,
 		deployed: make(set.Strings),
 	}
-	w, err := watcher.NewStringsWorker(watcher.StringsConfig{
-		Handler: d,
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &d.catacomb,
+		Work: d.loop,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return w, nil
+	return d, nil
 }
 
-func (d *Deployer) SetUp() (watcher.StringsWatcher, error) {
+// Kill is part of the worker.Worker interface.
+func (d *Deployer) Kill() {
+	d.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (d *Deployer) Wait() error {
+	return d.catacomb.Wait()
+}
+
+func (d *Deployer) loop() error {
 	tag := d.ctx.AgentConfig().Tag()
 	machineTag, ok := tag.(names.MachineTag)
 	if !ok {
-		return nil, errors.Errorf("expected names.MachineTag, got %T", tag)
+		return errors.Errorf("expected names.MachineTag, got %T", tag)
 	}
 	machine, err := d.st.Machine(machineTag)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	machineUnitsWatcher, err := machine.WatchUnits()
-	if err != nil {
-		return nil, err
+
+	// Controllers that report the life of each changed unit save us
+	// reading it after every change; older ones report only names.
+	var (
+		unitNames   watcher.StringsChannel
+		unitChanges watcher.EntityChangesChannel
+	)
+	unitChangesWatcher, err := machine.WatchUnitChanges()
+	if errors.IsNotSupported(err) {
+		machineUnitsWatcher, err := machine.WatchUnits()
+		if err != nil {
+			return err
+		}
+		if err := d.catacomb.Add(machineUnitsWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		unitNames = machineUnitsWatcher.Changes()
+	} else if err != nil {
+		return err
+	} else {
+		if err := d.catacomb.Add(unitChangesWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		unitChanges = unitChangesWatcher.Changes()
 	}
 
 	deployed, err := d.ctx.DeployedUnits()
 	if err != nil {
-		return nil, err
+		return err
 	}
 	for _, unitName := range deployed {
 		d.deployed.Add(unitName)
 		if err := d.changed(unitName); err != nil {
-			return nil, err
+			return err
 		}
 	}
-	return machineUnitsWatcher, nil
-}
 
-func (d *Deployer) Handle(_ <-chan struct{}, unitNames []string) error {
-	for _, unitName := range unitNames {
-		if err := d.changed(unitName); err != nil {
-			return err
+	for {
+		select {
+		case <-d.catacomb.Dying():
+			return d.catacomb.ErrDying()
+		case changes, ok := <-unitNames:
+			if !ok {
+				return errors.New("units watcher closed")
+			}
+			for _, unitName := range changes {
+				if err := d.changed(unitName); err != nil {
+					return err
+				}
+			}
+		case changes, ok := <-unitChanges:
+			if !ok {
+				return errors.New("unit changes watcher closed")
+			}
+			for _, change := range changes {
+				if err := d.changedUnit(change); err != nil {
+					return err
+				}
+			}
 		}
 	}
-	return nil
+}
+
+// changedUnit ensures that the unit described by the change is
+// deployed, recalled, or removed, as indicated by its life.
+func (d *Deployer) changedUnit(change watcher.EntityChange) error {
+	logger.Infof("checking unit %q", change.Tag.Id())
+	if change.Removed {
+		return d.changedLife(change.Tag.Id(), nil, params.Dead)
+	}
+	unit, err := d.st.UnitFromChange(change)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return d.changedLife(unit.Name(), unit, unit.Life())
 }
 
 // changed ensures that the named unit is deployed, recalled, or removed, as
@@ -122,6 +185,12 @@ func (d *Deployer) changed(unitName string) error {
 	} else {
 		life = unit.Life()
 	}
+	return d.changedLife(unitName, unit, life)
+}
+
+// changedLife ensures that the named unit, which is nil if it no longer
+// exists, is deployed, recalled, or removed, as indicated by its life.
+func (d *Deployer) changedLife(unitName string, unit *apideployer.Unit, life params.Life) error {
 	// Deployed units must be removed if they're Dead, or if the deployer
 	// is no longer responsible for them.
 	if d.deployed.Contains(unitName) {
@@ -196,8 +265,3 @@ func (d *Deployer) remove(unit *apideployer.Unit) error {
 	logger.Infof("removing unit %q", unitName)
 	return unit.Remove()
 }
-
-func (d *Deployer) TearDown() error {
-	// Nothing to do here.
-	return nil
-}
@@ -0,0 +1,197 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package dnsupdater provides a worker that registers a model's
+// machines in the provider's DNS, for providers that support it, so
+// that workloads can be reached by stable names rather than by
+// addresses that may change. The names registered are recorded against
+// the machines, and preferred by unit-get public-address.
+//
+// Records are created once a machine has been provisioned and has a
+// public address, updated when that address changes, and removed once
+// the machine is dead.
+package dnsupdater
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/dnsupdater"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.dnsupdater")
+
+// Facade defines the interface we require from the DNS updater
+// facade.
+type Facade interface {
+	WatchModelMachines() (watcher.StringsWatcher, error)
+	Machines() ([]dnsupdater.Machine, error)
+	SetFQDN(names.MachineTag, string) error
+}
+
+// Config holds the configuration and dependencies for a DNS updater
+// worker.
+type Config struct {
+	Facade    Facade
+	Registrar environs.DNSRegistrar
+	Clock     clock.Clock
+
+	// Interval is how often machines' addresses are checked for
+	// changes. Changes to the machines' life cycles are handled as
+	// they happen.
+	Interval time.Duration
+}
+
+// Validate returns an error if the config cannot be expected to
+// drive a functional DNS updater.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Registrar == nil {
+		return errors.NotValidf("nil Registrar")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that keeps the provider's DNS records for
+// the model's machines up to date.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &updater{
+		config:     config,
+		registered: make(map[string]string),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type updater struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	// registered holds the addresses each machine's records were last
+	// registered with, keyed on machine id.
+	registered map[string]string
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *updater) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *updater) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *updater) loop() error {
+	machinesWatcher, err := w.config.Facade.WatchModelMachines()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(machinesWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	timer := w.config.Clock.NewTimer(w.config.Interval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-machinesWatcher.Changes():
+			if !ok {
+				return errors.New("machines watcher closed")
+			}
+		case <-timer.Chan():
+			timer.Reset(w.config.Interval)
+		}
+		if err := w.update(); err != nil {
+			return errors.Annotate(err, "updating DNS records")
+		}
+	}
+}
+
+func (w *updater) update() error {
+	machines, err := w.config.Facade.Machines()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, m := range machines {
+		// A failure for one machine, perhaps because the provider
+		// rejects its records, shouldn't stop the others from being
+		// registered.
+		if err := w.updateMachine(m); err != nil {
+			logger.Errorf("cannot update DNS records for machine %s: %v", m.Tag.Id(), err)
+		}
+	}
+	return nil
+}
+
+func (w *updater) updateMachine(m dnsupdater.Machine) error {
+	id := m.Tag.Id()
+	if m.Life == params.Dead {
+		if m.FQDN == "" {
+			return nil
+		}
+		if err := w.config.Registrar.UnregisterMachineDNS(id, m.FQDN); err != nil {
+			return errors.Trace(err)
+		}
+		delete(w.registered, id)
+		logger.Infof("removed DNS records %q for machine %s", m.FQDN, id)
+		return errors.Trace(w.config.Facade.SetFQDN(m.Tag, ""))
+	}
+	if m.InstanceId == "" || len(m.Addresses) == 0 {
+		return nil
+	}
+	addrs := addressesKey(m)
+	if m.FQDN != "" && w.registered[id] == addrs {
+		return nil
+	}
+	fqdn, err := w.config.Registrar.RegisterMachineDNS(id, m.InstanceId, m.Addresses)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.registered[id] = addrs
+	logger.Infof("registered DNS records %q for machine %s (%s)", fqdn, id, addrs)
+	if fqdn == m.FQDN {
+		return nil
+	}
+	return errors.Trace(w.config.Facade.SetFQDN(m.Tag, fqdn))
+}
+
+// addressesKey returns a string identifying the machine's addresses,
+// regardless of their order.
+func addressesKey(m dnsupdater.Machine) string {
+	values := make([]string, len(m.Addresses))
+	for i, addr := range m.Addresses {
+		values[i] = addr.Value
+	}
+	sort.Strings(values)
+	return strings.Join(values, ",")
+}
@@ -0,0 +1,275 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dnsupdater_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/dnsupdater"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/watcher/watchertest"
+	workerdnsupdater "github.com/juju/juju/worker/dnsupdater"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+
+	clock     *testing.Clock
+	changes   chan []string
+	facade    *mockFacade
+	registrar *mockRegistrar
+	config    workerdnsupdater.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Time{})
+	s.changes = make(chan []string, 1)
+	s.facade = &mockFacade{
+		watcher: watchertest.NewMockStringsWatcher(s.changes),
+		machines: []dnsupdater.Machine{{
+			Tag:        names.NewMachineTag("0"),
+			Life:       params.Alive,
+			InstanceId: "i-0",
+			Addresses:  network.NewAddresses("1.2.3.4"),
+		}, {
+			Tag:  names.NewMachineTag("1"),
+			Life: params.Alive,
+		}},
+		set: make(chan struct{}, 10),
+	}
+	s.registrar = &mockRegistrar{}
+	s.config = workerdnsupdater.Config{
+		Facade:    s.facade,
+		Registrar: s.registrar,
+		Clock:     s.clock,
+		Interval:  time.Minute,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	s.testValidate(c, func(cfg *workerdnsupdater.Config) {
+		cfg.Facade = nil
+	}, "nil Facade not valid")
+	s.testValidate(c, func(cfg *workerdnsupdater.Config) {
+		cfg.Registrar = nil
+	}, "nil Registrar not valid")
+	s.testValidate(c, func(cfg *workerdnsupdater.Config) {
+		cfg.Clock = nil
+	}, "nil Clock not valid")
+	s.testValidate(c, func(cfg *workerdnsupdater.Config) {
+		cfg.Interval = 0
+	}, "non-positive Interval not valid")
+}
+
+func (s *WorkerSuite) testValidate(c *gc.C, f func(*workerdnsupdater.Config), expect string) {
+	config := s.config
+	f(&config)
+	w, err := workerdnsupdater.NewWorker(config)
+	if !c.Check(err, gc.NotNil) {
+		workertest.DirtyKill(c, w)
+		return
+	}
+	c.Check(w, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, expect)
+}
+
+func (s *WorkerSuite) TestRegistersProvisionedMachines(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.changes <- []string{"0", "1"}
+	s.waitSet(c)
+	s.registrar.CheckCalls(c, []testing.StubCall{{
+		"RegisterMachineDNS", []interface{}{"0", instance.Id("i-0"), network.NewAddresses("1.2.3.4")},
+	}})
+	s.facade.CheckCall(c, 2, "SetFQDN", names.NewMachineTag("0"), "machine-0.example.com")
+}
+
+func (s *WorkerSuite) TestUnchangedAddressesNotRegisteredAgain(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.changes <- []string{"0"}
+	s.waitSet(c)
+	s.facade.setFQDN("0", "machine-0.example.com")
+
+	s.advance(c)
+	s.waitMachines(c, 2)
+	s.registrar.CheckCallNames(c, "RegisterMachineDNS")
+
+	s.facade.setAddresses("0", network.NewAddresses("5.6.7.8"))
+	s.advance(c)
+	s.waitMachines(c, 3)
+	s.registrar.CheckCallNames(c, "RegisterMachineDNS", "RegisterMachineDNS")
+	s.registrar.CheckCall(c, 1, "RegisterMachineDNS", "0", instance.Id("i-0"), network.NewAddresses("5.6.7.8"))
+	// The name didn't change, so it isn't recorded again.
+	s.facade.CheckCallNames(c,
+		"WatchModelMachines", "Machines", "SetFQDN", "Machines", "Machines",
+	)
+}
+
+func (s *WorkerSuite) TestUnregistersDeadMachines(c *gc.C) {
+	s.facade.machines[0].Life = params.Dead
+	s.facade.machines[0].FQDN = "machine-0.example.com"
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.changes <- []string{"0"}
+	s.waitSet(c)
+	s.registrar.CheckCalls(c, []testing.StubCall{{
+		"UnregisterMachineDNS", []interface{}{"0", "machine-0.example.com"},
+	}})
+	s.facade.CheckCall(c, 2, "SetFQDN", names.NewMachineTag("0"), "")
+}
+
+func (s *WorkerSuite) TestRegistrarErrorNotFatal(c *gc.C) {
+	s.facade.machines[1].InstanceId = "i-1"
+	s.facade.machines[1].Addresses = network.NewAddresses("5.6.7.8")
+	s.registrar.SetErrors(errors.New("quota exceeded"))
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.changes <- []string{"0", "1"}
+	s.waitSet(c)
+	s.registrar.CheckCallNames(c, "RegisterMachineDNS", "RegisterMachineDNS")
+	s.facade.CheckCall(c, 2, "SetFQDN", names.NewMachineTag("1"), "machine-1.example.com")
+}
+
+func (s *WorkerSuite) TestMachinesError(c *gc.C) {
+	s.facade.SetErrors(nil, errors.New("kaboom"))
+	w := s.startWorker(c)
+	defer workertest.DirtyKill(c, w)
+
+	s.changes <- []string{"0"}
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "updating DNS records: kaboom")
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := workerdnsupdater.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *WorkerSuite) advance(c *gc.C) {
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *WorkerSuite) waitSet(c *gc.C) {
+	select {
+	case <-s.facade.set:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for FQDN to be set")
+	}
+}
+
+func (s *WorkerSuite) waitMachines(c *gc.C, n int) {
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if s.facade.machinesCalls() >= n {
+			// Give the worker time to finish with the machines.
+			time.Sleep(coretesting.ShortWait)
+			return
+		}
+	}
+	c.Fatalf("timed out waiting for %d Machines calls", n)
+}
+
+type mockFacade struct {
+	testing.Stub
+
+	mu       sync.Mutex
+	watcher  watcher.StringsWatcher
+	machines []dnsupdater.Machine
+	set      chan struct{}
+}
+
+func (f *mockFacade) WatchModelMachines() (watcher.StringsWatcher, error) {
+	f.MethodCall(f, "WatchModelMachines")
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.watcher, nil
+}
+
+func (f *mockFacade) Machines() ([]dnsupdater.Machine, error) {
+	f.MethodCall(f, "Machines")
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	machines := make([]dnsupdater.Machine, len(f.machines))
+	copy(machines, f.machines)
+	return machines, nil
+}
+
+func (f *mockFacade) SetFQDN(tag names.MachineTag, fqdn string) error {
+	f.MethodCall(f, "SetFQDN", tag, fqdn)
+	f.set <- struct{}{}
+	return f.NextErr()
+}
+
+func (f *mockFacade) machinesCalls() int {
+	n := 0
+	for _, call := range f.Calls() {
+		if call.FuncName == "Machines" {
+			n++
+		}
+	}
+	return n
+}
+
+func (f *mockFacade) setFQDN(id, fqdn string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.machines {
+		if f.machines[i].Tag.Id() == id {
+			f.machines[i].FQDN = fqdn
+		}
+	}
+}
+
+func (f *mockFacade) setAddresses(id string, addrs []network.Address) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.machines {
+		if f.machines[i].Tag.Id() == id {
+			f.machines[i].Addresses = addrs
+		}
+	}
+}
+
+type mockRegistrar struct {
+	testing.Stub
+}
+
+func (r *mockRegistrar) RegisterMachineDNS(machineId string, instId instance.Id, addrs []network.Address) (string, error) {
+	r.MethodCall(r, "RegisterMachineDNS", machineId, instId, addrs)
+	if err := r.NextErr(); err != nil {
+		return "", err
+	}
+	return "machine-" + machineId + ".example.com", nil
+}
+
+func (r *mockRegistrar) UnregisterMachineDNS(machineId string, fqdn string) error {
+	r.MethodCall(r, "UnregisterMachineDNS", machineId, fqdn)
+	return r.NextErr()
+}
@@ -0,0 +1,25 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package fanconfigurer
+
+import (
+	"net"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+)
+
+type LocalInterface struct {
+	Name  string
+	Up    bool
+	Addrs []net.IP
+}
+
+func FanStatus(fanConfig network.FanConfig, interfaces []LocalInterface, failures map[int]error) []params.FanOverlayStatus {
+	local := make([]localInterface, len(interfaces))
+	for i, iface := range interfaces {
+		local[i] = localInterface(iface)
+	}
+	return fanStatus(fanConfig, local, failures)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/utils/clock"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/utils/scriptrunner"
 	"github.com/juju/juju/watcher"
@@ -21,17 +22,27 @@ import (
 
 var logger = loggo.GetLogger("juju.worker.fanconfigurer")
 
+// fanStatusInterval is how often the health of the fan overlays is
+// checked and reported, besides whenever the fan config changes.
+const fanStatusInterval = 5 * time.Minute
+
 type FanConfigurer struct {
 	catacomb catacomb.Catacomb
 	config   FanConfigurerConfig
 	clock    clock.Clock
 	mu       sync.Mutex
 	enabled  bool
+
+	// fanConfig holds the fans most recently configured, and
+	// failures the errors setting up any of them, by index.
+	fanConfig network.FanConfig
+	failures  map[int]error
 }
 
 type FanConfigurerFacade interface {
 	FanConfig() (network.FanConfig, error)
 	WatchForFanConfigChanges() (watcher.NotifyWatcher, error)
+	SetFanStatus([]params.FanOverlayStatus) error
 }
 
 type FanConfigurerConfig struct {
@@ -48,6 +59,8 @@ func (fc *FanConfigurer) processNewConfig() error {
 	if err != nil {
 		return err
 	}
+	fc.fanConfig = fanConfig
+	fc.failures = make(map[int]error)
 	if len(fanConfig) == 0 {
 		logger.Debugf("Fan not enabled")
 		// TODO(wpk) 2017-08-05 We have to clean this up!
@@ -60,15 +73,39 @@ func (fc *FanConfigurer) processNewConfig() error {
 		result, err := scriptrunner.RunCommand(line, os.Environ(), fc.clock, 5000*time.Millisecond)
 		logger.Debugf("Launched %s - result %v %v %d", line, string(result.Stdout), string(result.Stderr), result.Code)
 		if err != nil {
-			return err
+			// The failure is reported as the overlay's status,
+			// so that the other fans are still set up.
+			logger.Warningf("cannot enable fan %s=%s: %v", fan.Underlay, fan.Overlay, err)
+			fc.failures[i] = err
 		}
 	}
 	// TODO(wpk) 2017-09-28 Although officially not needed we do fanctl up -a just to be sure -
 	// fanatic sometimes fails to bring up interface because of some weird interactions with iptables.
 	result, err := scriptrunner.RunCommand("fanctl up -a", os.Environ(), fc.clock, 5000*time.Millisecond)
 	logger.Debugf("Launched fanctl up -a - result %v %v %d", string(result.Stdout), string(result.Stderr), result.Code)
+	if err != nil {
+		logger.Warningf("cannot bring up fans: %v", err)
+	}
+	return nil
+}
 
-	return err
+// reportStatus reports the health of the fan overlays whose underlay
+// the machine is on.
+func (fc *FanConfigurer) reportStatus() error {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	interfaces, err := localInterfaces()
+	if err != nil {
+		return errors.Annotate(err, "cannot get network interfaces")
+	}
+	overlays := fanStatus(fc.fanConfig, interfaces, fc.failures)
+	err = fc.config.Facade.SetFanStatus(overlays)
+	if errors.IsNotSupported(err) {
+		logger.Debugf("not reporting fan status: %v", err)
+		return nil
+	}
+	return errors.Annotate(err, "cannot set fan status")
 }
 
 func NewFanConfigurer(config FanConfigurerConfig, clock clock.Clock) (*FanConfigurer, error) {
@@ -103,6 +140,9 @@ func (fc *FanConfigurer) loop() error {
 	}
 
 	for {
+		if err := fc.reportStatus(); err != nil {
+			return errors.Trace(err)
+		}
 		select {
 		case <-fc.catacomb.Dying():
 			return fc.catacomb.ErrDying()
@@ -113,6 +153,7 @@ func (fc *FanConfigurer) loop() error {
 			if err = fc.processNewConfig(); err != nil {
 				return errors.Trace(err)
 			}
+		case <-fc.clock.After(fanStatusInterval):
 		}
 	}
 }
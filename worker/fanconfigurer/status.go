@@ -0,0 +1,92 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package fanconfigurer
+
+import (
+	"net"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+)
+
+// localInterface describes one of the machine's network interfaces.
+type localInterface struct {
+	Name  string
+	Up    bool
+	Addrs []net.IP
+}
+
+// localInterfaces returns the machine's network interfaces. It is a
+// variable so that tests can replace it.
+var localInterfaces = func() ([]localInterface, error) {
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]localInterface, len(interfaces))
+	for i, iface := range interfaces {
+		addrs, err := iface.Addrs()
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot get addresses of %q", iface.Name)
+		}
+		result[i] = localInterface{
+			Name: iface.Name,
+			Up:   iface.Flags&net.FlagUp != 0,
+		}
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok {
+				result[i].Addrs = append(result[i].Addrs, ipNet.IP)
+			}
+		}
+	}
+	return result, nil
+}
+
+// fanStatus returns the health of each of the fans whose underlay the
+// machine has an address on, given the machine's interfaces and the
+// errors setting up any of the fans, by index. An overlay is healthy
+// if an interface that is up, the overlay's bridge, has an address on
+// it.
+func fanStatus(fanConfig network.FanConfig, interfaces []localInterface, failures map[int]error) []params.FanOverlayStatus {
+	find := func(ipNet *net.IPNet) (localInterface, net.IP) {
+		for _, iface := range interfaces {
+			for _, ip := range iface.Addrs {
+				if ipNet.Contains(ip) {
+					return iface, ip
+				}
+			}
+		}
+		return localInterface{}, nil
+	}
+
+	var result []params.FanOverlayStatus
+	for i, fan := range fanConfig {
+		if _, ip := find(fan.Underlay); ip == nil {
+			continue
+		}
+		status := params.FanOverlayStatus{
+			Underlay: fan.Underlay.String(),
+			Overlay:  fan.Overlay.String(),
+		}
+		bridge, ip := find(fan.Overlay)
+		if ip != nil {
+			status.Bridge = bridge.Name
+			status.Address = ip.String()
+		}
+		switch {
+		case failures[i] != nil:
+			status.Message = failures[i].Error()
+		case ip == nil:
+			status.Message = "no fan bridge has an address on the overlay"
+		case !bridge.Up:
+			status.Message = "fan bridge " + bridge.Name + " is down"
+		default:
+			status.Healthy = true
+		}
+		result = append(result, status)
+	}
+	return result
+}
@@ -0,0 +1,66 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package fanconfigurer_test
+
+import (
+	"errors"
+	"net"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/worker/fanconfigurer"
+)
+
+type statusSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&statusSuite{})
+
+func (s *statusSuite) TestFanStatus(c *gc.C) {
+	fanConfig, err := network.ParseFanConfig(
+		"172.31.0.0/16=253.0.0.0/8 10.0.0.0/16=252.0.0.0/8 192.168.0.0/16=250.0.0.0/8",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	interfaces := []fanconfigurer.LocalInterface{{
+		Name:  "eth0",
+		Up:    true,
+		Addrs: []net.IP{net.ParseIP("172.31.64.10"), net.ParseIP("10.0.4.2")},
+	}, {
+		Name:  "fan-253",
+		Up:    true,
+		Addrs: []net.IP{net.ParseIP("253.64.10.1")},
+	}}
+
+	status := fanconfigurer.FanStatus(fanConfig, interfaces, map[int]error{1: errors.New("fanatic failed")})
+	c.Assert(status, jc.DeepEquals, []params.FanOverlayStatus{{
+		Underlay: "172.31.0.0/16",
+		Overlay:  "253.0.0.0/8",
+		Bridge:   "fan-253",
+		Address:  "253.64.10.1",
+		Healthy:  true,
+	}, {
+		Underlay: "10.0.0.0/16",
+		Overlay:  "252.0.0.0/8",
+		Message:  "fanatic failed",
+	}})
+
+	interfaces[1].Up = false
+	status = fanconfigurer.FanStatus(fanConfig, interfaces, nil)
+	c.Assert(status, jc.DeepEquals, []params.FanOverlayStatus{{
+		Underlay: "172.31.0.0/16",
+		Overlay:  "253.0.0.0/8",
+		Bridge:   "fan-253",
+		Address:  "253.64.10.1",
+		Message:  "fan bridge fan-253 is down",
+	}, {
+		Underlay: "10.0.0.0/16",
+		Overlay:  "252.0.0.0/8",
+		Message:  "no fan bridge has an address on the overlay",
+	}})
+}
@@ -0,0 +1,173 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewaller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+)
+
+// DefaultCheckInterval is how often the firewaller run by the model
+// agent compares the ingress rules it wants applied with those the
+// provider has applied.
+const DefaultCheckInterval = 5 * time.Minute
+
+// reportDrift checks for and reports firewall drift, returning a
+// channel that delivers when the next check is due, or nil if the
+// controller cannot record the reports.
+func (fw *Firewaller) reportDrift() (<-chan time.Time, error) {
+	err := fw.checkDrift()
+	if errors.IsNotSupported(err) {
+		logger.Debugf("not reporting firewall drift: %v", err)
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "cannot check firewall drift")
+	}
+	return fw.pollClock.After(fw.checkInterval), nil
+}
+
+// checkDrift compares the ingress rules the firewaller wants applied
+// with those the provider has actually applied, and records what it
+// finds. Drift is only reported here; the firewaller corrects it when
+// it next starts.
+func (fw *Firewaller) checkDrift() error {
+	now := fw.pollClock.Now()
+	var reports []params.FirewallReport
+	if fw.globalMode {
+		report, err := fw.checkGlobalDrift(now)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		reports = []params.FirewallReport{report}
+	} else {
+		var err error
+		if reports, err = fw.checkInstanceDrift(now); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for _, report := range reports {
+		if len(report.Missing) > 0 || len(report.Unexpected) > 0 {
+			scope := report.MachineTag
+			if scope == "" {
+				scope = "model"
+			}
+			logger.Warningf("firewall for %s has drifted: missing %v, unexpected %v",
+				scope, params.NetworkIngressRules(report.Missing), params.NetworkIngressRules(report.Unexpected))
+		}
+	}
+	return errors.Trace(fw.firewallerApi.SetFirewallReports(reports))
+}
+
+// checkGlobalDrift compares the ingress rules wanted for the whole
+// model with those the provider has applied.
+func (fw *Firewaller) checkGlobalDrift(now time.Time) (params.FirewallReport, error) {
+	var machines []*machineData
+	for _, machined := range fw.machineds {
+		machines = append(machines, machined)
+	}
+	want, err := fw.gatherIngressRules(machines...)
+	if err != nil {
+		return params.FirewallReport{}, errors.Trace(err)
+	}
+	report := params.FirewallReport{
+		Checked: now,
+		Wanted:  params.FromNetworkIngressRules(sortedRules(want)),
+	}
+	actual, err := fw.environFirewaller.IngressRules(fw.catacomb.Context(context.Background()))
+	if err != nil {
+		report.Error = err.Error()
+		return report, nil
+	}
+	setDrift(&report, actual, want)
+	return report, nil
+}
+
+// checkInstanceDrift compares the ingress rules wanted for each
+// provisioned machine with those the provider has applied to its
+// instance.
+func (fw *Firewaller) checkInstanceDrift(now time.Time) ([]params.FirewallReport, error) {
+	var (
+		machineds   []*machineData
+		instanceIds []instance.Id
+	)
+	for _, machined := range fw.machineds {
+		m, err := machined.machine()
+		if params.IsCodeNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		instanceId, err := m.InstanceId()
+		if params.IsCodeNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		machineds = append(machineds, machined)
+		instanceIds = append(instanceIds, instanceId)
+	}
+	if len(instanceIds) == 0 {
+		return nil, nil
+	}
+	instances, err := fw.environInstances.Instances(instanceIds)
+	switch err {
+	case nil, environs.ErrPartialInstances:
+	case environs.ErrNoInstances:
+		instances = make([]instance.Instance, len(instanceIds))
+	default:
+		return nil, errors.Trace(err)
+	}
+
+	ctx := fw.catacomb.Context(context.Background())
+	var reports []params.FirewallReport
+	for i, machined := range machineds {
+		report := params.FirewallReport{
+			MachineTag: machined.tag.String(),
+			Checked:    now,
+			Wanted:     params.FromNetworkIngressRules(sortedRules(machined.ingressRules)),
+		}
+		if instances[i] == nil {
+			report.Error = fmt.Sprintf("instance %q not found", instanceIds[i])
+			reports = append(reports, report)
+			continue
+		}
+		fwInstance, ok := instances[i].(instance.InstanceFirewaller)
+		if !ok {
+			// The provider does not manage the instance's firewall.
+			continue
+		}
+		actual, err := fwInstance.IngressRules(ctx, machined.tag.Id())
+		if err != nil {
+			report.Error = err.Error()
+		} else {
+			setDrift(&report, actual, machined.ingressRules)
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// setDrift records the provider's actual ingress rules in the report,
+// with any difference from those wanted.
+func setDrift(report *params.FirewallReport, actual, want []network.IngressRule) {
+	missing, unexpected := diffRanges(actual, want)
+	report.Actual = params.FromNetworkIngressRules(sortedRules(actual))
+	report.Missing = params.FromNetworkIngressRules(missing)
+	report.Unexpected = params.FromNetworkIngressRules(unexpected)
+}
+
+// sortedRules returns a sorted copy of the given ingress rules.
+func sortedRules(rules []network.IngressRule) []network.IngressRule {
+	sorted := append([]network.IngressRule(nil), rules...)
+	network.SortIngressRules(sorted)
+	return sorted
+}
@@ -4,6 +4,7 @@
 package firewaller
 
 import (
+	"context"
 	"io"
 	"strings"
 	"time"
@@ -21,6 +22,7 @@ import (
 	"github.com/juju/juju/api/firewaller"
 	"github.com/juju/juju/api/remoterelations"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/relation"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
@@ -33,9 +35,11 @@ import (
 // FirewallerAPI exposes functionality off the firewaller API facade to a worker.
 type FirewallerAPI interface {
 	WatchModelMachines() (watcher.StringsWatcher, error)
+	WatchModelMachineChanges() (watcher.EntityChangesWatcher, error)
 	WatchOpenedPorts() (watcher.StringsWatcher, error)
 	Machine(tag names.MachineTag) (*firewaller.Machine, error)
 	Unit(tag names.UnitTag) (*firewaller.Unit, error)
+	UnitFromChange(change watcher.EntityChange) (*firewaller.Unit, error)
 	Relation(tag names.RelationTag) (*firewaller.Relation, error)
 	WatchEgressAddressesForRelation(tag names.RelationTag) (watcher.StringsWatcher, error)
 	WatchIngressAddressesForRelation(tag names.RelationTag) (watcher.StringsWatcher, error)
@@ -43,6 +47,7 @@ type FirewallerAPI interface {
 	MacaroonForRelation(relationKey string) (*macaroon.Macaroon, error)
 	SetRelationStatus(relationKey string, status relation.Status, message string) error
 	FirewallRules(serviceNames ...string) ([]params.FirewallRule, error)
+	SetFirewallReports([]params.FirewallReport) error
 }
 
 // CrossModelFirewallerFacade exposes firewaller functionality on the
@@ -85,6 +90,11 @@ type Config struct {
 	NewCrossModelFacadeFunc newCrossModelFacadeFunc
 
 	Clock clock.Clock
+
+	// CheckInterval is how often the firewaller compares the ingress
+	// rules it wants applied with those the provider has applied,
+	// and reports any drift. If zero, no comparison is made.
+	CheckInterval time.Duration
 }
 
 // Validate returns an error if cfg cannot drive a Worker.
@@ -107,6 +117,9 @@ func (cfg Config) Validate() error {
 	if cfg.NewCrossModelFacadeFunc == nil {
 		return errors.NotValidf("nil Cross Model Facade func")
 	}
+	if cfg.CheckInterval < 0 {
+		return errors.NotValidf("negative CheckInterval")
+	}
 	return nil
 }
 
@@ -122,7 +135,8 @@ type Firewaller struct {
 	environFirewaller  EnvironFirewaller
 	environInstances   EnvironInstances
 
-	machinesWatcher      watcher.StringsWatcher
+	machineNames         watcher.StringsChannel
+	machineChanges       watcher.EntityChangesChannel
 	portsWatcher         watcher.StringsWatcher
 	machineds            map[names.MachineTag]*machineData
 	unitsChange          chan *unitsChange
@@ -139,6 +153,7 @@ type Firewaller struct {
 	relationIngress            map[names.RelationTag]*remoteRelationData
 	relationWorkerRunner       *worker.Runner
 	pollClock                  clock.Clock
+	checkInterval              time.Duration
 }
 
 // NewFirewaller returns a new Firewaller.
@@ -165,6 +180,7 @@ func NewFirewaller(cfg Config) (worker.Worker, error) {
 		relationIngress:            make(map[names.RelationTag]*remoteRelationData),
 		localRelationsChange:       make(chan *remoteRelationNetworkChange),
 		pollClock:                  clk,
+		checkInterval:              cfg.CheckInterval,
 		relationWorkerRunner: worker.NewRunner(worker.RunnerParams{
 			Clock: clk,
 
@@ -198,13 +214,25 @@ func NewFirewaller(cfg Config) (worker.Worker, error) {
 }
 
 func (fw *Firewaller) setUp() error {
-	var err error
-	fw.machinesWatcher, err = fw.firewallerApi.WatchModelMachines()
-	if err != nil {
-		return errors.Trace(err)
-	}
-	if err := fw.catacomb.Add(fw.machinesWatcher); err != nil {
+	// Controllers that report the life of each changed machine save
+	// us reading it after every change; older ones report only ids.
+	machineChangesWatcher, err := fw.firewallerApi.WatchModelMachineChanges()
+	if errors.IsNotSupported(err) {
+		machinesWatcher, err := fw.firewallerApi.WatchModelMachines()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := fw.catacomb.Add(machinesWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		fw.machineNames = machinesWatcher.Changes()
+	} else if err != nil {
 		return errors.Trace(err)
+	} else {
+		if err := fw.catacomb.Add(machineChangesWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		fw.machineChanges = machineChangesWatcher.Changes()
 	}
 
 	fw.portsWatcher, err = fw.firewallerApi.WatchOpenedPorts()
@@ -232,12 +260,18 @@ func (fw *Firewaller) loop() error {
 		return errors.Trace(err)
 	}
 	var reconciled bool
+	var checkDrift <-chan time.Time
 	portsChange := fw.portsWatcher.Changes()
 	for {
 		select {
 		case <-fw.catacomb.Dying():
 			return fw.catacomb.ErrDying()
-		case change, ok := <-fw.machinesWatcher.Changes():
+		case <-checkDrift:
+			var err error
+			if checkDrift, err = fw.reportDrift(); err != nil {
+				return errors.Trace(err)
+			}
+		case change, ok := <-fw.machineNames:
 			if !ok {
 				return errors.New("machines watcher closed")
 			}
@@ -249,12 +283,23 @@ func (fw *Firewaller) loop() error {
 			if !reconciled {
 				reconciled = true
 				var err error
-				if fw.globalMode {
-					err = fw.reconcileGlobal()
-				} else {
-					err = fw.reconcileInstances()
+				if checkDrift, err = fw.reconcile(); err != nil {
+					return errors.Trace(err)
 				}
-				if err != nil {
+			}
+		case changes, ok := <-fw.machineChanges:
+			if !ok {
+				return errors.New("machine changes watcher closed")
+			}
+			for _, change := range changes {
+				if err := fw.machineChanged(change); err != nil {
+					return err
+				}
+			}
+			if !reconciled {
+				reconciled = true
+				var err error
+				if checkDrift, err = fw.reconcile(); err != nil {
 					return errors.Trace(err)
 				}
 			}
@@ -304,6 +349,26 @@ func (fw *Firewaller) loop() error {
 	}
 }
 
+// reconcile compares the ports the initially started watchers want
+// opened with those opened by the environment, and opens and closes
+// ports to match. If the firewaller is checking for drift, it returns
+// the channel on which to next check it.
+func (fw *Firewaller) reconcile() (<-chan time.Time, error) {
+	var err error
+	if fw.globalMode {
+		err = fw.reconcileGlobal()
+	} else {
+		err = fw.reconcileInstances()
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if fw.checkInterval > 0 {
+		return fw.reportDrift()
+	}
+	return nil, nil
+}
+
 func (fw *Firewaller) relationIngressChanged(change *remoteRelationNetworkChange) error {
 	logger.Debugf("process remote relation ingress change for %v", change.relationTag)
 	relData, ok := fw.relationIngress[change.relationTag]
@@ -342,9 +407,24 @@ func (fw *Firewaller) startMachine(tag names.MachineTag) error {
 	} else if err != nil {
 		return errors.Annotate(err, "cannot watch machine units")
 	}
-	unitw, err := m.WatchUnits()
-	if err != nil {
+	// Controllers that report the life of each changed unit save us
+	// reading it after every change; older ones report only names.
+	var (
+		unitw       worker.Worker
+		unitNames   watcher.StringsChannel
+		unitChanges watcher.EntityChangesChannel
+	)
+	unitChangesWatcher, err := m.WatchUnitChanges()
+	if errors.IsNotSupported(err) {
+		unitsWatcher, err := m.WatchUnits()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		unitw, unitNames = unitsWatcher, unitsWatcher.Changes()
+	} else if err != nil {
 		return errors.Trace(err)
+	} else {
+		unitw, unitChanges = unitChangesWatcher, unitChangesWatcher.Changes()
 	}
 	// XXX(fwereade): this is the best of a bunch of bad options. We've started
 	// the watch, so we're responsible for it; but we (probably?) need to do this
@@ -357,25 +437,32 @@ func (fw *Firewaller) startMachine(tag names.MachineTag) error {
 	if err := fw.catacomb.Add(unitw); err != nil {
 		return errors.Trace(err)
 	}
+	var change *unitsChange
 	select {
 	case <-fw.catacomb.Dying():
 		return fw.catacomb.ErrDying()
-	case change, ok := <-unitw.Changes():
+	case units, ok := <-unitNames:
 		if !ok {
 			return errors.New("machine units watcher closed")
 		}
-		fw.machineds[tag] = machined
-		err = fw.unitsChanged(&unitsChange{machined, change})
-		if err != nil {
-			delete(fw.machineds, tag)
-			return errors.Annotatef(err, "cannot respond to units changes for %q", tag)
+		change = &unitsChange{machined: machined, units: units}
+	case changes, ok := <-unitChanges:
+		if !ok {
+			return errors.New("machine unit changes watcher closed")
 		}
+		change = &unitsChange{machined: machined, unitChanges: changes}
+	}
+	fw.machineds[tag] = machined
+	err = fw.unitsChanged(change)
+	if err != nil {
+		delete(fw.machineds, tag)
+		return errors.Annotatef(err, "cannot respond to units changes for %q", tag)
 	}
 
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &machined.catacomb,
 		Work: func() error {
-			return machined.watchLoop(unitw)
+			return machined.watchLoop(unitw, unitNames, unitChanges)
 		},
 	})
 	if err != nil {
@@ -479,7 +566,8 @@ func (fw *Firewaller) reconcileGlobal() error {
 		machines = append(machines, machined)
 	}
 	want, err := fw.gatherIngressRules(machines...)
-	initialPortRanges, err := fw.environFirewaller.IngressRules()
+	ctx := fw.catacomb.Context(context.Background())
+	initialPortRanges, err := fw.environFirewaller.IngressRules(ctx)
 	if err != nil {
 		return err
 	}
@@ -488,13 +576,13 @@ func (fw *Firewaller) reconcileGlobal() error {
 	toOpen, toClose := diffRanges(initialPortRanges, want)
 	if len(toOpen) > 0 {
 		logger.Infof("opening global ports %v", toOpen)
-		if err := fw.environFirewaller.OpenPorts(toOpen); err != nil {
+		if err := fw.environFirewaller.OpenPorts(ctx, toOpen); err != nil {
 			return err
 		}
 	}
 	if len(toClose) > 0 {
 		logger.Infof("closing global ports %v", toClose)
-		if err := fw.environFirewaller.ClosePorts(toClose); err != nil {
+		if err := fw.environFirewaller.ClosePorts(ctx, toClose); err != nil {
 			return err
 		}
 	}
@@ -505,6 +593,7 @@ func (fw *Firewaller) reconcileGlobal() error {
 // units and appications with the opened and closed ports of the instances and
 // opens and closes the appropriate ports for each instance.
 func (fw *Firewaller) reconcileInstances() error {
+	ctx := fw.catacomb.Context(context.Background())
 	for _, machined := range fw.machineds {
 		m, err := machined.machine()
 		if params.IsCodeNotFound(err) {
@@ -538,7 +627,7 @@ func (fw *Firewaller) reconcileInstances() error {
 			return nil
 		}
 
-		initialRules, err := fwInstance.IngressRules(machineId)
+		initialRules, err := fwInstance.IngressRules(ctx, machineId)
 		if err != nil {
 			return err
 		}
@@ -548,7 +637,7 @@ func (fw *Firewaller) reconcileInstances() error {
 		if len(toOpen) > 0 {
 			logger.Infof("opening instance port ranges %v for %q",
 				toOpen, machined.tag)
-			if err := fwInstance.OpenPorts(machineId, toOpen); err != nil {
+			if err := fwInstance.OpenPorts(ctx, machineId, toOpen); err != nil {
 				// TODO(mue) Add local retry logic.
 				return err
 			}
@@ -556,7 +645,7 @@ func (fw *Firewaller) reconcileInstances() error {
 		if len(toClose) > 0 {
 			logger.Infof("closing instance port ranges %v for %q",
 				toClose, machined.tag)
-			if err := fwInstance.ClosePorts(machineId, toClose); err != nil {
+			if err := fwInstance.ClosePorts(ctx, machineId, toClose); err != nil {
 				// TODO(mue) Add local retry logic.
 				return err
 			}
@@ -574,30 +663,32 @@ func (fw *Firewaller) unitsChanged(change *unitsChange) error {
 		if err != nil && !params.IsCodeNotFound(err) {
 			return err
 		}
-		var machineTag names.MachineTag
-		if unit != nil {
-			machineTag, err = unit.AssignedMachine()
-			if params.IsCodeNotFound(err) {
-				continue
-			} else if err != nil && !params.IsCodeNotAssigned(err) {
-				return err
-			}
+		unitd, err := fw.unitChanged(unitTag, unit)
+		if err != nil {
+			return err
 		}
-		if unitd, known := fw.unitds[unitTag]; known {
-			knownMachineTag := fw.unitds[unitTag].machined.tag
-			if unit == nil || unit.Life() == params.Dead || machineTag != knownMachineTag {
-				fw.forgetUnit(unitd)
-				changed = append(changed, unitd)
-				logger.Debugf("stopped watching unit %s", name)
-			}
-			// TODO(dfc) fw.machineds should be map[names.Tag]
-		} else if unit != nil && unit.Life() != params.Dead && fw.machineds[machineTag] != nil {
-			err = fw.startUnit(unit, machineTag)
-			if err != nil {
-				return err
+		if unitd != nil {
+			changed = append(changed, unitd)
+		}
+	}
+	for _, unitChange := range change.unitChanges {
+		unitTag, ok := unitChange.Tag.(names.UnitTag)
+		if !ok {
+			return errors.Errorf("expected names.UnitTag, got %T", unitChange.Tag)
+		}
+		var unit *firewaller.Unit
+		if !unitChange.Removed {
+			var err error
+			if unit, err = fw.firewallerApi.UnitFromChange(unitChange); err != nil {
+				return errors.Trace(err)
 			}
-			changed = append(changed, fw.unitds[unitTag])
-			logger.Debugf("started watching %q", unitTag)
+		}
+		unitd, err := fw.unitChanged(unitTag, unit)
+		if err != nil {
+			return err
+		}
+		if unitd != nil {
+			changed = append(changed, unitd)
 		}
 	}
 	if err := fw.flushUnits(changed); err != nil {
@@ -606,6 +697,38 @@ func (fw *Firewaller) unitsChanged(change *unitsChange) error {
 	return nil
 }
 
+// unitChanged starts or stops watching the unit with the given tag,
+// which is nil if it no longer exists, and returns its data if its
+// ports need to be flushed.
+func (fw *Firewaller) unitChanged(unitTag names.UnitTag, unit *firewaller.Unit) (*unitData, error) {
+	var machineTag names.MachineTag
+	if unit != nil {
+		var err error
+		machineTag, err = unit.AssignedMachine()
+		if params.IsCodeNotFound(err) {
+			return nil, nil
+		} else if err != nil && !params.IsCodeNotAssigned(err) {
+			return nil, err
+		}
+	}
+	if unitd, known := fw.unitds[unitTag]; known {
+		knownMachineTag := fw.unitds[unitTag].machined.tag
+		if unit == nil || unit.Life() == params.Dead || machineTag != knownMachineTag {
+			fw.forgetUnit(unitd)
+			logger.Debugf("stopped watching unit %s", unitTag.Id())
+			return unitd, nil
+		}
+		// TODO(dfc) fw.machineds should be map[names.Tag]
+	} else if unit != nil && unit.Life() != params.Dead && fw.machineds[machineTag] != nil {
+		if err := fw.startUnit(unit, machineTag); err != nil {
+			return nil, err
+		}
+		logger.Debugf("started watching %q", unitTag)
+		return fw.unitds[unitTag], nil
+	}
+	return nil, nil
+}
+
 // openedPortsChanged handles port change notifications
 func (fw *Firewaller) openedPortsChanged(machineTag names.MachineTag, subnetTag names.SubnetTag) error {
 
@@ -830,8 +953,9 @@ func (fw *Firewaller) flushGlobalPorts(rawOpen, rawClose []network.IngressRule)
 		}
 	}
 	// Open and close the ports.
+	ctx := fw.catacomb.Context(context.Background())
 	if len(toOpen) > 0 {
-		if err := fw.environFirewaller.OpenPorts(toOpen); err != nil {
+		if err := fw.environFirewaller.OpenPorts(ctx, toOpen); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
@@ -839,7 +963,7 @@ func (fw *Firewaller) flushGlobalPorts(rawOpen, rawClose []network.IngressRule)
 		logger.Infof("opened port ranges %v in environment", toOpen)
 	}
 	if len(toClose) > 0 {
-		if err := fw.environFirewaller.ClosePorts(toClose); err != nil {
+		if err := fw.environFirewaller.ClosePorts(ctx, toClose); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
@@ -886,8 +1010,9 @@ func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose
 	}
 
 	// Open and close the ports.
+	ctx := fw.catacomb.Context(context.Background())
 	if len(toOpen) > 0 {
-		if err := fwInstance.OpenPorts(machineId, toOpen); err != nil {
+		if err := fwInstance.OpenPorts(ctx, machineId, toOpen); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
@@ -895,7 +1020,7 @@ func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose
 		logger.Infof("opened port ranges %v on %q", toOpen, machined.tag)
 	}
 	if len(toClose) > 0 {
-		if err := fwInstance.ClosePorts(machineId, toClose); err != nil {
+		if err := fwInstance.ClosePorts(ctx, machineId, toClose); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
@@ -915,13 +1040,29 @@ func (fw *Firewaller) machineLifeChanged(tag names.MachineTag) error {
 		return err
 	}
 	dead := !found || m.Life() == params.Dead
+	return fw.machineDeathChanged(tag, dead)
+}
+
+// machineChanged starts or stops watching the machine described by the
+// given change, as machineLifeChanged does, without reading its life.
+func (fw *Firewaller) machineChanged(change watcher.EntityChange) error {
+	tag, ok := change.Tag.(names.MachineTag)
+	if !ok {
+		return errors.Errorf("expected names.MachineTag, got %T", change.Tag)
+	}
+	dead := change.Removed || change.Life == life.Dead
+	return fw.machineDeathChanged(tag, dead)
+}
+
+// machineDeathChanged starts watching the machine with the given tag if
+// it is alive and not yet known, and stops watching it if it is dead.
+func (fw *Firewaller) machineDeathChanged(tag names.MachineTag, dead bool) error {
 	machined, known := fw.machineds[tag]
 	if known && dead {
 		return fw.forgetMachine(machined)
 	}
 	if !known && !dead {
-		err = fw.startMachine(tag)
-		if err != nil {
+		if err := fw.startMachine(tag); err != nil {
 			return err
 		}
 		logger.Debugf("started watching %q", tag)
@@ -990,7 +1131,14 @@ func (fw *Firewaller) Wait() error {
 // unitsChange contains the changed units for one specific machine.
 type unitsChange struct {
 	machined *machineData
-	units    []string
+
+	// units holds the names of the changed units, when they are
+	// reported by a WatchUnits watcher.
+	units []string
+
+	// unitChanges describes the changed units, when they are
+	// reported by a WatchUnitChanges watcher.
+	unitChanges []watcher.EntityChange
 }
 
 // machineData holds machine details and watches units added or removed.
@@ -1008,24 +1156,36 @@ func (md *machineData) machine() (*firewaller.Machine, error) {
 	return md.fw.firewallerApi.Machine(md.tag)
 }
 
-// watchLoop watches the machine for units added or removed.
-func (md *machineData) watchLoop(unitw watcher.StringsWatcher) error {
+// watchLoop watches the machine for units added or removed, as
+// reported by unitw on exactly one of unitNames and unitChanges.
+func (md *machineData) watchLoop(
+	unitw worker.Worker,
+	unitNames watcher.StringsChannel,
+	unitChanges watcher.EntityChangesChannel,
+) error {
 	if err := md.catacomb.Add(unitw); err != nil {
 		return errors.Trace(err)
 	}
 	for {
+		var change *unitsChange
 		select {
 		case <-md.catacomb.Dying():
 			return md.catacomb.ErrDying()
-		case change, ok := <-unitw.Changes():
+		case units, ok := <-unitNames:
 			if !ok {
 				return errors.New("machine units watcher closed")
 			}
-			select {
-			case <-md.catacomb.Dying():
-				return md.catacomb.ErrDying()
-			case md.fw.unitsChange <- &unitsChange{md, change}:
+			change = &unitsChange{machined: md, units: units}
+		case changes, ok := <-unitChanges:
+			if !ok {
+				return errors.New("machine unit changes watcher closed")
 			}
+			change = &unitsChange{machined: md, unitChanges: changes}
+		}
+		select {
+		case <-md.catacomb.Dying():
+			return md.catacomb.ErrDying()
+		case md.fw.unitsChange <- change:
 		}
 	}
 }
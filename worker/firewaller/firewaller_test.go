@@ -4,6 +4,7 @@
 package firewaller_test
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"sync/atomic"
@@ -38,6 +39,7 @@ import (
 	"github.com/juju/juju/status"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
+	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker/firewaller"
 )
 
@@ -56,6 +58,7 @@ type firewallerBaseSuite struct {
 	remoteRelations      *remoterelations.Client
 	crossmodelFirewaller *crossmodelrelations.Client
 	clock                clock.Clock
+	checkInterval        time.Duration
 }
 
 func (s *firewallerBaseSuite) SetUpSuite(c *gc.C) {
@@ -86,6 +89,8 @@ func (s *firewallerBaseSuite) setUpTest(c *gc.C, firewallMode string) {
 
 	s.JujuConnSuite.SetUpTest(c)
 	s.charm = s.AddTestingCharm(c, "dummy")
+	s.clock = nil
+	s.checkInterval = 0
 
 	// Create a manager machine and login to the API.
 	var err error
@@ -117,7 +122,7 @@ func (s *firewallerBaseSuite) assertPorts(c *gc.C, inst instance.Instance, machi
 	s.BackingState.StartSync()
 	start := time.Now()
 	for {
-		got, err := fwInst.IngressRules(machineId)
+		got, err := fwInst.IngressRules(context.Background(), machineId)
 		if err != nil {
 			c.Fatal(err)
 			return
@@ -145,7 +150,7 @@ func (s *firewallerBaseSuite) assertEnvironPorts(c *gc.C, expected []network.Ing
 	s.BackingState.StartSync()
 	start := time.Now()
 	for {
-		got, err := fwEnv.IngressRules()
+		got, err := fwEnv.IngressRules(context.Background())
 		if err != nil {
 			c.Fatal(err)
 			return
@@ -164,6 +169,27 @@ func (s *firewallerBaseSuite) assertEnvironPorts(c *gc.C, expected []network.Ing
 	}
 }
 
+// waitForDrift waits for the firewaller to report drift in the
+// firewall of the machine with the given id or, if the id is empty,
+// of the whole model, and returns the report.
+func (s *firewallerBaseSuite) waitForDrift(c *gc.C, machineId string) state.FirewallReport {
+	timeout := time.After(coretesting.LongWait)
+	for {
+		reports, err := s.State.FirewallReports()
+		c.Assert(err, jc.ErrorIsNil)
+		for _, report := range reports {
+			if report.MachineId == machineId && report.Drifted() {
+				return report
+			}
+		}
+		select {
+		case <-timeout:
+			c.Fatalf("timed out waiting for drift report; got %+v", reports)
+		case <-time.After(coretesting.ShortWait):
+		}
+	}
+}
+
 func (s *firewallerBaseSuite) addUnit(c *gc.C, app *state.Application) (*state.Unit, *state.Machine) {
 	u, err := app.AddUnit(state.AddUnitParams{})
 	c.Assert(err, jc.ErrorIsNil)
@@ -229,7 +255,8 @@ func (s *InstanceModeSuite) newFirewallerWithClock(c *gc.C, clock clock.Clock) w
 		NewCrossModelFacadeFunc: func(*api.Info) (firewaller.CrossModelFirewallerFacadeCloser, error) {
 			return s.crossmodelFirewaller, nil
 		},
-		Clock: s.clock,
+		Clock:         s.clock,
+		CheckInterval: s.checkInterval,
 	}
 	fw, err := firewaller.NewFirewaller(cfg)
 	c.Assert(err, jc.ErrorIsNil)
@@ -291,6 +318,89 @@ func (s *InstanceModeSuite) TestExposedApplication(c *gc.C) {
 	})
 }
 
+// machineChangesNotSupported is a FirewallerAPI that behaves like one
+// connected to a controller without WatchModelMachineChanges.
+type machineChangesNotSupported struct {
+	*apifirewaller.Client
+}
+
+func (machineChangesNotSupported) WatchModelMachineChanges() (watcher.EntityChangesWatcher, error) {
+	return nil, errors.NotSupportedf("watching model machine changes")
+}
+
+func (s *InstanceModeSuite) TestExposedApplicationWithoutMachineChanges(c *gc.C) {
+	fwEnv, ok := s.Environ.(environs.Firewaller)
+	c.Assert(ok, gc.Equals, true)
+	fw, err := firewaller.NewFirewaller(firewaller.Config{
+		ModelUUID:          s.State.ModelUUID(),
+		Mode:               config.FwInstance,
+		EnvironFirewaller:  fwEnv,
+		EnvironInstances:   s.Environ,
+		FirewallerAPI:      machineChangesNotSupported{s.firewaller},
+		RemoteRelationsApi: s.remoteRelations,
+		NewCrossModelFacadeFunc: func(*api.Info) (firewaller.CrossModelFirewallerFacadeCloser, error) {
+			return s.crossmodelFirewaller, nil
+		},
+		Clock: &mockClock{c: c},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+	err = app.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+
+	err = u.OpenPort("tcp", 8080)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0"),
+	})
+
+	// Making the unit dead closes its ports.
+	err = u.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), nil)
+}
+
+func (s *InstanceModeSuite) TestReportsDrift(c *gc.C) {
+	clk := testing.NewClock(time.Time{})
+	s.checkInterval = time.Minute
+	fw := s.newFirewallerWithClock(c, clk)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+	err := app.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+	rule80 := network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0")
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{rule80})
+
+	// Change the instance's firewall behind the firewaller's back.
+	rule22 := network.MustNewIngressRule("tcp", 22, 22, "0.0.0.0/0")
+	fwInst := inst.(instance.InstanceFirewaller)
+	err = fwInst.ClosePorts(context.Background(), m.Id(), []network.IngressRule{rule80})
+	c.Assert(err, jc.ErrorIsNil)
+	err = fwInst.OpenPorts(context.Background(), m.Id(), []network.IngressRule{rule22})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = clk.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	report := s.waitForDrift(c, m.Id())
+	c.Check(report.Wanted, jc.DeepEquals, []network.IngressRule{rule80})
+	c.Check(report.Actual, jc.DeepEquals, []network.IngressRule{rule22})
+	c.Check(report.Missing, jc.DeepEquals, []network.IngressRule{rule80})
+	c.Check(report.Unexpected, jc.DeepEquals, []network.IngressRule{rule22})
+	c.Check(report.Error, gc.Equals, "")
+
+	// The drift is only reported.
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{rule22})
+}
+
 func (s *InstanceModeSuite) TestMultipleExposedApplications(c *gc.C) {
 	fw := s.newFirewaller(c)
 	defer statetesting.AssertKillAndWait(c, fw)
@@ -1224,6 +1334,8 @@ func (s *GlobalModeSuite) newFirewaller(c *gc.C) worker.Worker {
 		NewCrossModelFacadeFunc: func(*api.Info) (firewaller.CrossModelFirewallerFacadeCloser, error) {
 			return s.crossmodelFirewaller, nil
 		},
+		Clock:         s.clock,
+		CheckInterval: s.checkInterval,
 	}
 	fw, err := firewaller.NewFirewaller(cfg)
 	c.Assert(err, jc.ErrorIsNil)
@@ -1287,6 +1399,37 @@ func (s *GlobalModeSuite) TestGlobalMode(c *gc.C) {
 	s.assertEnvironPorts(c, nil)
 }
 
+func (s *GlobalModeSuite) TestReportsDrift(c *gc.C) {
+	clk := testing.NewClock(time.Time{})
+	s.clock = clk
+	s.checkInterval = time.Minute
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+	err := app.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	u, m := s.addUnit(c, app)
+	s.startInstance(c, m)
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+	rule80 := network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0")
+	s.assertEnvironPorts(c, []network.IngressRule{rule80})
+
+	// Change the model's firewall behind the firewaller's back.
+	fwEnv := s.Environ.(environs.Firewaller)
+	err = fwEnv.ClosePorts(context.Background(), []network.IngressRule{rule80})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = clk.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	report := s.waitForDrift(c, "")
+	c.Check(report.Wanted, jc.DeepEquals, []network.IngressRule{rule80})
+	c.Check(report.Actual, gc.HasLen, 0)
+	c.Check(report.Missing, jc.DeepEquals, []network.IngressRule{rule80})
+	c.Check(report.Unexpected, gc.HasLen, 0)
+}
+
 func (s *GlobalModeSuite) TestStartWithUnexposedApplication(c *gc.C) {
 	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
@@ -119,6 +119,7 @@ func (cfg ManifoldConfig) start(context dependency.Context) (worker.Worker, erro
 		EnvironFirewaller:  fwEnv,
 		EnvironInstances:   environ,
 		Mode:               mode,
+		CheckInterval:      DefaultCheckInterval,
 		NewCrossModelFacadeFunc: crossmodelFirewallerFacadeFunc(cfg.NewControllerConnection),
 	})
 	if err != nil {
@@ -0,0 +1,107 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package grpcgateway
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	csparams "gopkg.in/juju/charmrepo.v2/csclient/params"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/action"
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+)
+
+// NewConnectFunc returns a ConnectFunc that connects to the API
+// server described by the given info, which is usually the
+// controller's own, using the open function.
+func NewConnectFunc(info *api.Info, open api.OpenFunc) ConnectFunc {
+	return func(modelTag names.ModelTag, userTag names.UserTag, password string) (Facades, error) {
+		info := *info
+		info.ModelTag = modelTag
+		info.Tag = userTag
+		info.Password = password
+		info.Nonce = ""
+		conn, err := open(&info, api.DefaultDialOpts())
+		if redirErr, ok := errors.Cause(err).(*api.RedirectError); ok && redirErr.CACert == info.CACert {
+			// The model is served by another machine of the
+			// controller; see the sticky-model-routing setting.
+			info.Addrs = nil
+			for _, hostPorts := range redirErr.Servers {
+				info.Addrs = append(info.Addrs, network.HostPortsToStrings(hostPorts)...)
+			}
+			conn, err = open(&info, api.DefaultDialOpts())
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return &apiFacades{
+			conn:        conn,
+			client:      conn.Client(),
+			application: application.NewClient(conn),
+			action:      action.NewClient(conn),
+		}, nil
+	}
+}
+
+// apiFacades implements Facades with the facade clients of an API
+// connection.
+type apiFacades struct {
+	conn        api.Connection
+	client      *api.Client
+	application *application.Client
+	action      *action.Client
+}
+
+// Status is part of the Facades interface.
+func (f *apiFacades) Status(patterns []string) (*params.FullStatus, error) {
+	return f.client.Status(patterns)
+}
+
+// ResolveCharm is part of the Facades interface.
+func (f *apiFacades) ResolveCharm(curl *charm.URL) (*charm.URL, error) {
+	return f.client.ResolveCharm(curl)
+}
+
+// AddCharm is part of the Facades interface.
+func (f *apiFacades) AddCharm(curl *charm.URL, channel csparams.Channel) error {
+	return f.client.AddCharm(curl, channel)
+}
+
+// Deploy is part of the Facades interface.
+func (f *apiFacades) Deploy(args application.DeployArgs) error {
+	return f.application.Deploy(args)
+}
+
+// AddUnits is part of the Facades interface.
+func (f *apiFacades) AddUnits(args application.AddUnitsParams) ([]string, error) {
+	return f.application.AddUnits(args)
+}
+
+// DestroyUnits is part of the Facades interface.
+func (f *apiFacades) DestroyUnits(args application.DestroyUnitsParams) ([]params.DestroyUnitResult, error) {
+	return f.application.DestroyUnits(args)
+}
+
+// Enqueue is part of the Facades interface.
+func (f *apiFacades) Enqueue(args params.Actions) (params.ActionResults, error) {
+	return f.action.Enqueue(args)
+}
+
+// WatchAll is part of the Facades interface.
+func (f *apiFacades) WatchAll() (AllWatcher, error) {
+	watcher, err := f.client.WatchAll()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return watcher, nil
+}
+
+// Close is part of the Facades interface.
+func (f *apiFacades) Close() error {
+	return f.conn.Close()
+}
@@ -0,0 +1,553 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package grpcgateway
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/charm.v6"
+	csparams "gopkg.in/juju/charmrepo.v2/csclient/params"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/charmstore"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// Facades holds the facade calls through which the gateway serves
+// the calls made to it. Each Facades is connected to a single model,
+// as the user making the call.
+type Facades interface {
+	// Status, ResolveCharm and AddCharm are those of api.Client.
+	Status(patterns []string) (*params.FullStatus, error)
+	ResolveCharm(curl *charm.URL) (*charm.URL, error)
+	AddCharm(curl *charm.URL, channel csparams.Channel) error
+
+	// Deploy, AddUnits and DestroyUnits are those of
+	// application.Client.
+	Deploy(args application.DeployArgs) error
+	AddUnits(args application.AddUnitsParams) ([]string, error)
+	DestroyUnits(args application.DestroyUnitsParams) ([]params.DestroyUnitResult, error)
+
+	// Enqueue is that of action.Client.
+	Enqueue(args params.Actions) (params.ActionResults, error)
+
+	// WatchAll returns a watcher of all the changes to the model.
+	WatchAll() (AllWatcher, error)
+
+	// Close closes the connection to the model.
+	Close() error
+}
+
+// AllWatcher is the interface of api.AllWatcher.
+type AllWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+// ConnectFunc returns Facades connected to the given model, logged in
+// as the given user.
+type ConnectFunc func(modelTag names.ModelTag, userTag names.UserTag, password string) (Facades, error)
+
+// NewHandler returns an http.Handler that serves the calls of the
+// service declared in juju.proto, making each through Facades
+// returned by connect with the credentials of its caller. Streaming
+// calls end when abort is closed.
+func NewHandler(connect ConnectFunc, abort <-chan struct{}) http.Handler {
+	return &handler{
+		connect: connect,
+		abort:   abort,
+	}
+}
+
+type handler struct {
+	connect ConnectFunc
+	abort   <-chan struct{}
+}
+
+// ServeHTTP is part of the http.Handler interface.
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, fmt.Sprintf("method %s not allowed", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+	if !isGRPCContentType(r.Header.Get("Content-Type")) {
+		http.Error(w, "gRPC content type required", http.StatusUnsupportedMediaType)
+		return
+	}
+	w.Header().Set("Content-Type", "application/grpc")
+	w.Header().Set("Trailer", statusTrailer+", "+messageTrailer)
+	w.WriteHeader(http.StatusOK)
+	err := h.serve(w, r)
+	if err != nil {
+		logger.Debugf("call %s failed: %v", r.URL.Path, err)
+	}
+	setStatus(w, err)
+}
+
+func (h *handler) serve(w http.ResponseWriter, r *http.Request) error {
+	if !strings.HasPrefix(r.URL.Path, "/"+serviceName+"/") {
+		return statusErrorf(codeUnimplemented, "unknown service for %q", r.URL.Path)
+	}
+	switch method := strings.TrimPrefix(r.URL.Path, "/"+serviceName+"/"); method {
+	case "Status":
+		var req StatusRequest
+		if err := readMessage(r.Body, &req); err != nil {
+			return errors.Trace(err)
+		}
+		return h.call(w, r, req.ModelUUID, func(facades Facades) (proto.Message, error) {
+			return status(facades)
+		})
+	case "Deploy":
+		var req DeployRequest
+		if err := readMessage(r.Body, &req); err != nil {
+			return errors.Trace(err)
+		}
+		return h.call(w, r, req.ModelUUID, func(facades Facades) (proto.Message, error) {
+			return deploy(facades, &req)
+		})
+	case "Scale":
+		var req ScaleRequest
+		if err := readMessage(r.Body, &req); err != nil {
+			return errors.Trace(err)
+		}
+		return h.call(w, r, req.ModelUUID, func(facades Facades) (proto.Message, error) {
+			return scale(facades, &req)
+		})
+	case "RunAction":
+		var req RunActionRequest
+		if err := readMessage(r.Body, &req); err != nil {
+			return errors.Trace(err)
+		}
+		return h.call(w, r, req.ModelUUID, func(facades Facades) (proto.Message, error) {
+			return runAction(facades, &req)
+		})
+	case "WatchEvents":
+		var req WatchEventsRequest
+		if err := readMessage(r.Body, &req); err != nil {
+			return errors.Trace(err)
+		}
+		facades, err := h.open(r, req.ModelUUID)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer facades.Close()
+		return watchEvents(facades, w, h.abort, r.Context().Done())
+	default:
+		return statusErrorf(codeUnimplemented, "unknown method %q", method)
+	}
+}
+
+// call makes a unary call through Facades connected to the given
+// model, and writes its response.
+func (h *handler) call(
+	w http.ResponseWriter, r *http.Request, modelUUID string,
+	f func(Facades) (proto.Message, error),
+) error {
+	facades, err := h.open(r, modelUUID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer facades.Close()
+	resp, err := f(facades)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(writeMessage(w, resp))
+}
+
+// open connects to the given model with the caller's credentials,
+// which are passed using HTTP basic authentication.
+func (h *handler) open(r *http.Request, modelUUID string) (Facades, error) {
+	if !names.IsValidModel(modelUUID) {
+		return nil, statusErrorf(codeInvalidArgument, "model UUID %q not valid", modelUUID)
+	}
+	user, password, ok := r.BasicAuth()
+	if !ok {
+		return nil, statusErrorf(codeUnauthenticated, "no credentials provided")
+	}
+	if !names.IsValidUser(user) {
+		return nil, statusErrorf(codeUnauthenticated, "user name %q not valid", user)
+	}
+	facades, err := h.connect(names.NewModelTag(modelUUID), names.NewUserTag(user), password)
+	switch {
+	case err == nil:
+		return facades, nil
+	case params.IsCodeUnauthorized(err), params.IsCodeNoCreds(err), params.IsCodeLoginExpired(err):
+		return nil, statusErrorf(codeUnauthenticated, "%v", err)
+	case errorCode(err) == codeUnknown:
+		return nil, statusErrorf(codeUnavailable, "cannot connect to model: %v", err)
+	}
+	return nil, errors.Trace(err)
+}
+
+// status returns the status of the model's machines, applications
+// and units.
+func status(facades Facades) (*StatusResponse, error) {
+	full, err := facades.Status(nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resp := &StatusResponse{
+		ModelName: full.Model.Name,
+	}
+	var machineIds []string
+	machines := make(map[string]*MachineStatus)
+	var addMachines func(map[string]params.MachineStatus)
+	addMachines = func(statuses map[string]params.MachineStatus) {
+		for id, machine := range statuses {
+			machineIds = append(machineIds, id)
+			machines[id] = &MachineStatus{
+				Id:             id,
+				InstanceId:     string(machine.InstanceId),
+				Series:         machine.Series,
+				DNSName:        machine.DNSName,
+				AgentStatus:    newStatus(machine.AgentStatus),
+				InstanceStatus: newStatus(machine.InstanceStatus),
+			}
+			addMachines(machine.Containers)
+		}
+	}
+	addMachines(full.Machines)
+	for _, id := range utils.SortStringsNaturally(machineIds) {
+		resp.Machines = append(resp.Machines, machines[id])
+	}
+
+	// Subordinate units are reported with their principals, but
+	// belong with their own applications.
+	unitNames := make(map[string][]string)
+	units := make(map[string]*UnitStatus)
+	var addUnits func(map[string]params.UnitStatus)
+	addUnits = func(statuses map[string]params.UnitStatus) {
+		for name, unit := range statuses {
+			appName, err := names.UnitApplication(name)
+			if err != nil {
+				continue
+			}
+			unitNames[appName] = append(unitNames[appName], name)
+			units[name] = &UnitStatus{
+				Name:           name,
+				Machine:        unit.Machine,
+				PublicAddress:  unit.PublicAddress,
+				Leader:         unit.Leader,
+				WorkloadStatus: newStatus(unit.WorkloadStatus),
+				AgentStatus:    newStatus(unit.AgentStatus),
+			}
+			addUnits(unit.Subordinates)
+		}
+	}
+	appNames := make([]string, 0, len(full.Applications))
+	for name, app := range full.Applications {
+		appNames = append(appNames, name)
+		addUnits(app.Units)
+	}
+	sort.Strings(appNames)
+	for _, name := range appNames {
+		app := full.Applications[name]
+		appStatus := &ApplicationStatus{
+			Name:    name,
+			Charm:   app.Charm,
+			Series:  app.Series,
+			Exposed: app.Exposed,
+			Status:  newStatus(app.Status),
+		}
+		for _, unitName := range utils.SortStringsNaturally(unitNames[name]) {
+			appStatus.Units = append(appStatus.Units, units[unitName])
+		}
+		resp.Applications = append(resp.Applications, appStatus)
+	}
+	return resp, nil
+}
+
+func newStatus(status params.DetailedStatus) *Status {
+	return &Status{
+		Current: status.Status,
+		Message: status.Info,
+	}
+}
+
+// deploy deploys a charm from the charm store as a new application.
+func deploy(facades Facades, req *DeployRequest) (*DeployResponse, error) {
+	curl, err := charm.ParseURL(req.CharmURL)
+	if err != nil {
+		return nil, statusErrorf(codeInvalidArgument, "%v", err)
+	}
+	if curl.Schema != "cs" {
+		return nil, statusErrorf(codeInvalidArgument, "charm URL %q not in the charm store", req.CharmURL)
+	}
+	if curl.Revision < 0 {
+		return nil, statusErrorf(codeInvalidArgument, "charm URL %q without revision", req.CharmURL)
+	}
+	if req.NumUnits < 0 {
+		return nil, statusErrorf(codeInvalidArgument, "negative number of units")
+	}
+	cons, err := constraints.Parse(req.Constraints)
+	if err != nil {
+		return nil, statusErrorf(codeInvalidArgument, "%v", err)
+	}
+	channel := csparams.Channel(req.Channel)
+	if channel == csparams.NoChannel {
+		channel = csparams.StableChannel
+	}
+	if curl.Series == "" {
+		if curl, err = facades.ResolveCharm(curl); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if err := facades.AddCharm(curl, channel); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	appName := req.Application
+	if appName == "" {
+		appName = curl.Name
+	}
+	series := req.Series
+	if series == "" {
+		series = curl.Series
+	}
+	err = facades.Deploy(application.DeployArgs{
+		CharmID: charmstore.CharmID{
+			URL:     curl,
+			Channel: channel,
+		},
+		ApplicationName: appName,
+		Series:          series,
+		NumUnits:        int(req.NumUnits),
+		ConfigYAML:      req.ConfigYAML,
+		Cons:            cons,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &DeployResponse{
+		Application: appName,
+		CharmURL:    curl.String(),
+	}, nil
+}
+
+// scale adds or removes an application's units so that it has the
+// requested number of units. Units are removed newest first, and units
+// that are already being removed are not counted.
+func scale(facades Facades, req *ScaleRequest) (*ScaleResponse, error) {
+	if !names.IsValidApplication(req.Application) {
+		return nil, statusErrorf(codeInvalidArgument, "application name %q not valid", req.Application)
+	}
+	if req.NumUnits < 0 {
+		return nil, statusErrorf(codeInvalidArgument, "negative number of units")
+	}
+	full, err := facades.Status([]string{req.Application})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, ok := full.Applications[req.Application]
+	if !ok {
+		return nil, statusErrorf(codeNotFound, "application %q not found", req.Application)
+	}
+	var units []string
+	for name, unit := range app.Units {
+		if unit.AgentStatus.Life == "" || unit.AgentStatus.Life == string(params.Alive) {
+			units = append(units, name)
+		}
+	}
+	units = utils.SortStringsNaturally(units)
+
+	resp := &ScaleResponse{}
+	switch n := int(req.NumUnits); {
+	case n > len(units):
+		added, err := facades.AddUnits(application.AddUnitsParams{
+			ApplicationName: req.Application,
+			NumUnits:        n - len(units),
+		})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		resp.AddedUnits = added
+	case n < len(units):
+		remove := units[n:]
+		results, err := facades.DestroyUnits(application.DestroyUnitsParams{
+			Units: remove,
+		})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for i, result := range results {
+			if result.Error != nil {
+				return resp, errors.Annotatef(result.Error, "cannot remove unit %q", remove[i])
+			}
+			resp.RemovedUnits = append(resp.RemovedUnits, remove[i])
+		}
+	}
+	return resp, nil
+}
+
+// runAction enqueues an action to run on a unit.
+func runAction(facades Facades, req *RunActionRequest) (*RunActionResponse, error) {
+	if !names.IsValidUnit(req.Unit) {
+		return nil, statusErrorf(codeInvalidArgument, "unit name %q not valid", req.Unit)
+	}
+	if req.Action == "" {
+		return nil, statusErrorf(codeInvalidArgument, "no action specified")
+	}
+	parameters := make(map[string]interface{})
+	for key, value := range req.Parameters {
+		var parsed interface{}
+		if err := yaml.Unmarshal([]byte(value), &parsed); err != nil {
+			return nil, statusErrorf(codeInvalidArgument, "cannot parse parameter %q: %v", key, err)
+		}
+		parsed, err := conformYAML(parsed)
+		if err != nil {
+			return nil, statusErrorf(codeInvalidArgument, "cannot parse parameter %q: %v", key, err)
+		}
+		parameters[key] = parsed
+	}
+	results, err := facades.Enqueue(params.Actions{
+		Actions: []params.Action{{
+			Receiver:   names.NewUnitTag(req.Unit).String(),
+			Name:       req.Action,
+			Parameters: parameters,
+		}},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.Action == nil {
+		return nil, errors.New("action not enqueued")
+	}
+	tag, err := names.ParseActionTag(result.Action.Tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &RunActionResponse{
+		ActionId: tag.Id(),
+		Status:   result.Status,
+	}, nil
+}
+
+// conformYAML converts the maps in a value parsed from YAML into maps
+// with string keys, so that the value can be sent to the API.
+func conformYAML(value interface{}) (interface{}, error) {
+	switch value := value.(type) {
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{})
+		for key, item := range value {
+			keyString, ok := key.(string)
+			if !ok {
+				return nil, errors.Errorf("map keyed with non-string value %v", key)
+			}
+			item, err := conformYAML(item)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			result[keyString] = item
+		}
+		return result, nil
+	case []interface{}:
+		result := make([]interface{}, len(value))
+		for i, item := range value {
+			item, err := conformYAML(item)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			result[i] = item
+		}
+		return result, nil
+	}
+	return value, nil
+}
+
+// watchEvents streams events describing the changes to the model's
+// machines, applications, units and actions, until the watcher fails
+// or either abort or done is closed.
+func watchEvents(facades Facades, w http.ResponseWriter, abort, done <-chan struct{}) error {
+	watcher, err := facades.WatchAll()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	finished := make(chan struct{})
+	defer close(finished)
+	go func() {
+		select {
+		case <-abort:
+		case <-done:
+		case <-finished:
+		}
+		watcher.Stop()
+	}()
+	for {
+		deltas, err := watcher.Next()
+		if err != nil {
+			select {
+			case <-abort:
+				return statusErrorf(codeUnavailable, "gateway stopped")
+			case <-done:
+				return nil
+			default:
+			}
+			return errors.Trace(err)
+		}
+		for _, delta := range deltas {
+			event := newEvent(delta)
+			if event == nil {
+				continue
+			}
+			if err := writeMessage(w, event); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// newEvent returns the event describing the given change, or nil if
+// the change is to an entity not reported by the gateway.
+func newEvent(delta multiwatcher.Delta) *Event {
+	event := &Event{Removed: delta.Removed}
+	switch info := delta.Entity.(type) {
+	case *multiwatcher.MachineInfo:
+		event.Kind = "machine"
+		event.Id = info.Id
+		event.Status = newEventStatus(info.AgentStatus)
+	case *multiwatcher.ApplicationInfo:
+		event.Kind = "application"
+		event.Id = info.Name
+		event.Status = newEventStatus(info.Status)
+	case *multiwatcher.UnitInfo:
+		event.Kind = "unit"
+		event.Id = info.Name
+		event.Status = newEventStatus(info.WorkloadStatus)
+	case *multiwatcher.ActionInfo:
+		event.Kind = "action"
+		event.Id = info.Id
+		event.Status = &Status{
+			Current: info.Status,
+			Message: info.Message,
+		}
+	default:
+		return nil
+	}
+	return event
+}
+
+func newEventStatus(status multiwatcher.StatusInfo) *Status {
+	return &Status{
+		Current: string(status.Current),
+		Message: status.Message,
+	}
+}
@@ -0,0 +1,550 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package grpcgateway_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	csparams "gopkg.in/juju/charmrepo.v2/csclient/params"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/charmstore"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/grpcgateway"
+)
+
+type GatewaySuite struct {
+	testing.IsolationSuite
+
+	stub    testing.Stub
+	facades *mockFacades
+	abort   chan struct{}
+	handler http.Handler
+}
+
+var _ = gc.Suite(&GatewaySuite{})
+
+func (s *GatewaySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub = testing.Stub{}
+	s.facades = &mockFacades{Stub: &s.stub}
+	s.abort = make(chan struct{})
+	s.handler = grpcgateway.NewHandler(s.connect, s.abort)
+}
+
+func (s *GatewaySuite) connect(modelTag names.ModelTag, userTag names.UserTag, password string) (grpcgateway.Facades, error) {
+	s.stub.AddCall("Connect", modelTag, userTag, password)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return s.facades, nil
+}
+
+// request returns a gRPC request for the given method, with the given
+// request message.
+func (s *GatewaySuite) request(c *gc.C, method string, req proto.Message) *http.Request {
+	data, err := proto.Marshal(req)
+	c.Assert(err, jc.ErrorIsNil)
+	body := make([]byte, 5+len(data))
+	binary.BigEndian.PutUint32(body[1:5], uint32(len(data)))
+	copy(body[5:], data)
+	r := httptest.NewRequest("POST", "/juju.gateway.v1.Juju/"+method, bytes.NewReader(body))
+	r.Header.Set("Content-Type", "application/grpc")
+	r.SetBasicAuth("bob", "hunter2")
+	return r
+}
+
+// call serves the given request, and returns the messages and the
+// status code and message of the response.
+func (s *GatewaySuite) call(c *gc.C, r *http.Request) (messages [][]byte, code, message string) {
+	w := httptest.NewRecorder()
+	s.handler.ServeHTTP(w, r)
+	resp := w.Result()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusOK)
+	c.Assert(resp.Header.Get("Content-Type"), gc.Equals, "application/grpc")
+	for {
+		var header [5]byte
+		if _, err := io.ReadFull(resp.Body, header[:]); err == io.EOF {
+			break
+		} else {
+			c.Assert(err, jc.ErrorIsNil)
+		}
+		c.Assert(header[0], gc.Equals, byte(0))
+		data := make([]byte, binary.BigEndian.Uint32(header[1:]))
+		_, err := io.ReadFull(resp.Body, data)
+		c.Assert(err, jc.ErrorIsNil)
+		messages = append(messages, data)
+	}
+	return messages, resp.Trailer.Get("Grpc-Status"), resp.Trailer.Get("Grpc-Message")
+}
+
+// unaryCall makes a unary call, and returns the status code and
+// message of its response, which is unmarshalled into resp if the
+// call succeeds.
+func (s *GatewaySuite) unaryCall(c *gc.C, method string, req, resp proto.Message) (code, message string) {
+	messages, code, message := s.call(c, s.request(c, method, req))
+	if code == "0" {
+		c.Assert(messages, gc.HasLen, 1)
+		err := proto.Unmarshal(messages[0], resp)
+		c.Assert(err, jc.ErrorIsNil)
+	} else {
+		c.Assert(messages, gc.HasLen, 0)
+	}
+	return code, message
+}
+
+func (s *GatewaySuite) TestStatus(c *gc.C) {
+	s.facades.status = &params.FullStatus{
+		Model: params.ModelStatusInfo{Name: "prod"},
+		Machines: map[string]params.MachineStatus{
+			"10": {
+				Id:          "10",
+				InstanceId:  "i-10",
+				AgentStatus: params.DetailedStatus{Status: "started"},
+			},
+			"0": {
+				Id:          "0",
+				InstanceId:  "i-0",
+				Series:      "xenial",
+				DNSName:     "10.0.0.1",
+				AgentStatus: params.DetailedStatus{Status: "started"},
+				InstanceStatus: params.DetailedStatus{
+					Status: "running",
+					Info:   "ok",
+				},
+				Containers: map[string]params.MachineStatus{
+					"0/lxd/0": {
+						Id:          "0/lxd/0",
+						AgentStatus: params.DetailedStatus{Status: "pending"},
+					},
+				},
+			},
+		},
+		Applications: map[string]params.ApplicationStatus{
+			"mysql": {
+				Charm:   "cs:xenial/mysql-58",
+				Series:  "xenial",
+				Exposed: true,
+				Status:  params.DetailedStatus{Status: "active"},
+				Units: map[string]params.UnitStatus{
+					"mysql/10": {
+						Machine:        "10",
+						WorkloadStatus: params.DetailedStatus{Status: "active"},
+					},
+					"mysql/2": {
+						Machine:        "0",
+						PublicAddress:  "10.0.0.1",
+						Leader:         true,
+						WorkloadStatus: params.DetailedStatus{Status: "active", Info: "ready"},
+						AgentStatus:    params.DetailedStatus{Status: "idle"},
+						Subordinates: map[string]params.UnitStatus{
+							"logging/0": {
+								WorkloadStatus: params.DetailedStatus{Status: "unknown"},
+							},
+						},
+					},
+				},
+			},
+			"logging": {
+				Charm:  "cs:xenial/logging-1",
+				Series: "xenial",
+			},
+		},
+	}
+
+	var resp grpcgateway.StatusResponse
+	code, message := s.unaryCall(c, "Status", &grpcgateway.StatusRequest{
+		ModelUUID: coretesting.ModelTag.Id(),
+	}, &resp)
+	c.Assert(code, gc.Equals, "0")
+	c.Assert(message, gc.Equals, "")
+
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{"Connect", []interface{}{coretesting.ModelTag, names.NewUserTag("bob"), "hunter2"}},
+		{"Status", []interface{}{[]string(nil)}},
+		{"Close", nil},
+	})
+	c.Assert(&resp, jc.DeepEquals, &grpcgateway.StatusResponse{
+		ModelName: "prod",
+		Machines: []*grpcgateway.MachineStatus{{
+			Id:             "0",
+			InstanceId:     "i-0",
+			Series:         "xenial",
+			DNSName:        "10.0.0.1",
+			AgentStatus:    &grpcgateway.Status{Current: "started"},
+			InstanceStatus: &grpcgateway.Status{Current: "running", Message: "ok"},
+		}, {
+			Id:             "0/lxd/0",
+			AgentStatus:    &grpcgateway.Status{Current: "pending"},
+			InstanceStatus: &grpcgateway.Status{},
+		}, {
+			Id:             "10",
+			InstanceId:     "i-10",
+			AgentStatus:    &grpcgateway.Status{Current: "started"},
+			InstanceStatus: &grpcgateway.Status{},
+		}},
+		Applications: []*grpcgateway.ApplicationStatus{{
+			Name:   "logging",
+			Charm:  "cs:xenial/logging-1",
+			Series: "xenial",
+			Status: &grpcgateway.Status{},
+			Units: []*grpcgateway.UnitStatus{{
+				Name:           "logging/0",
+				WorkloadStatus: &grpcgateway.Status{Current: "unknown"},
+				AgentStatus:    &grpcgateway.Status{},
+			}},
+		}, {
+			Name:    "mysql",
+			Charm:   "cs:xenial/mysql-58",
+			Series:  "xenial",
+			Exposed: true,
+			Status:  &grpcgateway.Status{Current: "active"},
+			Units: []*grpcgateway.UnitStatus{{
+				Name:           "mysql/2",
+				Machine:        "0",
+				PublicAddress:  "10.0.0.1",
+				Leader:         true,
+				WorkloadStatus: &grpcgateway.Status{Current: "active", Message: "ready"},
+				AgentStatus:    &grpcgateway.Status{Current: "idle"},
+			}, {
+				Name:           "mysql/10",
+				Machine:        "10",
+				WorkloadStatus: &grpcgateway.Status{Current: "active"},
+				AgentStatus:    &grpcgateway.Status{},
+			}},
+		}},
+	})
+}
+
+func (s *GatewaySuite) TestNoCredentials(c *gc.C) {
+	r := s.request(c, "Status", &grpcgateway.StatusRequest{
+		ModelUUID: coretesting.ModelTag.Id(),
+	})
+	r.Header.Del("Authorization")
+	_, code, message := s.call(c, r)
+	c.Assert(code, gc.Equals, "16")
+	c.Assert(message, gc.Equals, "no credentials provided")
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *GatewaySuite) TestInvalidModel(c *gc.C) {
+	code, message := s.unaryCall(c, "Status", &grpcgateway.StatusRequest{
+		ModelUUID: "prod",
+	}, nil)
+	c.Assert(code, gc.Equals, "3")
+	c.Assert(message, gc.Equals, `model UUID "prod" not valid`)
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *GatewaySuite) TestLoginFailed(c *gc.C) {
+	s.stub.SetErrors(&params.Error{
+		Code:    params.CodeUnauthorized,
+		Message: "invalid entity name or password",
+	})
+	code, message := s.unaryCall(c, "Status", &grpcgateway.StatusRequest{
+		ModelUUID: coretesting.ModelTag.Id(),
+	}, nil)
+	c.Assert(code, gc.Equals, "16")
+	c.Assert(message, gc.Equals, "invalid entity name or password")
+	s.stub.CheckCallNames(c, "Connect")
+}
+
+func (s *GatewaySuite) TestConnectFailed(c *gc.C) {
+	s.stub.SetErrors(errors.New("no API addresses\n"))
+	code, message := s.unaryCall(c, "Status", &grpcgateway.StatusRequest{
+		ModelUUID: coretesting.ModelTag.Id(),
+	}, nil)
+	c.Assert(code, gc.Equals, "14")
+	c.Assert(message, gc.Equals, "cannot connect to model: no API addresses%0A")
+}
+
+func (s *GatewaySuite) TestUnknownMethod(c *gc.C) {
+	code, message := s.unaryCall(c, "Destroy", &grpcgateway.StatusRequest{}, nil)
+	c.Assert(code, gc.Equals, "12")
+	c.Assert(message, gc.Equals, `unknown method "Destroy"`)
+}
+
+func (s *GatewaySuite) TestNotGRPC(c *gc.C) {
+	r := s.request(c, "Status", &grpcgateway.StatusRequest{})
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.handler.ServeHTTP(w, r)
+	c.Assert(w.Code, gc.Equals, http.StatusUnsupportedMediaType)
+}
+
+func (s *GatewaySuite) TestMissingMessage(c *gc.C) {
+	r := s.request(c, "Status", &grpcgateway.StatusRequest{})
+	r.Body = ioutil.NopCloser(bytes.NewReader(nil))
+	_, code, message := s.call(c, r)
+	c.Assert(code, gc.Equals, "3")
+	c.Assert(message, gc.Equals, "missing request message")
+}
+
+func (s *GatewaySuite) TestDeploy(c *gc.C) {
+	s.facades.resolved = charm.MustParseURL("cs:xenial/mysql-58")
+	var resp grpcgateway.DeployResponse
+	code, message := s.unaryCall(c, "Deploy", &grpcgateway.DeployRequest{
+		ModelUUID:   coretesting.ModelTag.Id(),
+		CharmURL:    "cs:mysql-58",
+		Application: "db",
+		NumUnits:    2,
+		ConfigYAML:  "db:\n  dataset-size: 50%\n",
+		Constraints: "mem=4G",
+	}, &resp)
+	c.Assert(code, gc.Equals, "0")
+	c.Assert(message, gc.Equals, "")
+	c.Assert(&resp, jc.DeepEquals, &grpcgateway.DeployResponse{
+		Application: "db",
+		CharmURL:    "cs:xenial/mysql-58",
+	})
+
+	s.stub.CheckCallNames(c, "Connect", "ResolveCharm", "AddCharm", "Deploy", "Close")
+	s.stub.CheckCall(c, 1, "ResolveCharm", charm.MustParseURL("cs:mysql-58"))
+	s.stub.CheckCall(c, 2, "AddCharm", s.facades.resolved, csparams.StableChannel)
+	s.stub.CheckCall(c, 3, "Deploy", application.DeployArgs{
+		CharmID: charmstore.CharmID{
+			URL:     s.facades.resolved,
+			Channel: csparams.StableChannel,
+		},
+		ApplicationName: "db",
+		Series:          "xenial",
+		NumUnits:        2,
+		ConfigYAML:      "db:\n  dataset-size: 50%\n",
+		Cons:            constraints.MustParse("mem=4G"),
+	})
+}
+
+func (s *GatewaySuite) TestDeployWithoutRevision(c *gc.C) {
+	code, message := s.unaryCall(c, "Deploy", &grpcgateway.DeployRequest{
+		ModelUUID: coretesting.ModelTag.Id(),
+		CharmURL:  "cs:mysql",
+	}, nil)
+	c.Assert(code, gc.Equals, "3")
+	c.Assert(message, gc.Equals, `charm URL "cs:mysql" without revision`)
+	s.stub.CheckCallNames(c, "Connect", "Close")
+}
+
+func (s *GatewaySuite) TestDeployBlocked(c *gc.C) {
+	s.stub.SetErrors(nil, nil, &params.Error{
+		Code:    params.CodeOperationBlocked,
+		Message: "deploy blocked",
+	})
+	code, message := s.unaryCall(c, "Deploy", &grpcgateway.DeployRequest{
+		ModelUUID: coretesting.ModelTag.Id(),
+		CharmURL:  "cs:xenial/mysql-58",
+	}, nil)
+	c.Assert(code, gc.Equals, "9")
+	c.Assert(message, gc.Equals, "deploy blocked")
+	s.stub.CheckCallNames(c, "Connect", "AddCharm", "Deploy", "Close")
+}
+
+func (s *GatewaySuite) TestScaleUp(c *gc.C) {
+	s.facades.status = &params.FullStatus{
+		Applications: map[string]params.ApplicationStatus{
+			"mysql": {
+				Units: map[string]params.UnitStatus{
+					"mysql/0": {},
+				},
+			},
+		},
+	}
+	s.facades.addedUnits = []string{"mysql/1", "mysql/2"}
+	var resp grpcgateway.ScaleResponse
+	code, _ := s.unaryCall(c, "Scale", &grpcgateway.ScaleRequest{
+		ModelUUID:   coretesting.ModelTag.Id(),
+		Application: "mysql",
+		NumUnits:    3,
+	}, &resp)
+	c.Assert(code, gc.Equals, "0")
+	c.Assert(&resp, jc.DeepEquals, &grpcgateway.ScaleResponse{
+		AddedUnits: []string{"mysql/1", "mysql/2"},
+	})
+	s.stub.CheckCallNames(c, "Connect", "Status", "AddUnits", "Close")
+	s.stub.CheckCall(c, 1, "Status", []string{"mysql"})
+	s.stub.CheckCall(c, 2, "AddUnits", application.AddUnitsParams{
+		ApplicationName: "mysql",
+		NumUnits:        2,
+	})
+}
+
+func (s *GatewaySuite) TestScaleDown(c *gc.C) {
+	s.facades.status = &params.FullStatus{
+		Applications: map[string]params.ApplicationStatus{
+			"mysql": {
+				Units: map[string]params.UnitStatus{
+					"mysql/0":  {AgentStatus: params.DetailedStatus{Life: "alive"}},
+					"mysql/1":  {AgentStatus: params.DetailedStatus{Life: "dying"}},
+					"mysql/2":  {AgentStatus: params.DetailedStatus{Life: "alive"}},
+					"mysql/10": {AgentStatus: params.DetailedStatus{Life: "alive"}},
+				},
+			},
+		},
+	}
+	s.facades.destroyResults = []params.DestroyUnitResult{{}, {}}
+	var resp grpcgateway.ScaleResponse
+	code, _ := s.unaryCall(c, "Scale", &grpcgateway.ScaleRequest{
+		ModelUUID:   coretesting.ModelTag.Id(),
+		Application: "mysql",
+		NumUnits:    1,
+	}, &resp)
+	c.Assert(code, gc.Equals, "0")
+	c.Assert(&resp, jc.DeepEquals, &grpcgateway.ScaleResponse{
+		RemovedUnits: []string{"mysql/2", "mysql/10"},
+	})
+	s.stub.CheckCallNames(c, "Connect", "Status", "DestroyUnits", "Close")
+	s.stub.CheckCall(c, 2, "DestroyUnits", application.DestroyUnitsParams{
+		Units: []string{"mysql/2", "mysql/10"},
+	})
+}
+
+func (s *GatewaySuite) TestScaleApplicationNotFound(c *gc.C) {
+	s.facades.status = &params.FullStatus{}
+	code, message := s.unaryCall(c, "Scale", &grpcgateway.ScaleRequest{
+		ModelUUID:   coretesting.ModelTag.Id(),
+		Application: "mysql",
+		NumUnits:    1,
+	}, nil)
+	c.Assert(code, gc.Equals, "5")
+	c.Assert(message, gc.Equals, `application "mysql" not found`)
+}
+
+func (s *GatewaySuite) TestRunAction(c *gc.C) {
+	actionTag := names.NewActionTag("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	s.facades.actionResults = params.ActionResults{
+		Results: []params.ActionResult{{
+			Action: &params.Action{Tag: actionTag.String()},
+			Status: "pending",
+		}},
+	}
+	var resp grpcgateway.RunActionResponse
+	code, _ := s.unaryCall(c, "RunAction", &grpcgateway.RunActionRequest{
+		ModelUUID: coretesting.ModelTag.Id(),
+		Unit:      "mysql/0",
+		Action:    "backup",
+		Parameters: map[string]string{
+			"compress": "true",
+			"target":   "s3://backups",
+			"limits":   "{files: 10, dirs: [a, b]}",
+		},
+	}, &resp)
+	c.Assert(code, gc.Equals, "0")
+	c.Assert(&resp, jc.DeepEquals, &grpcgateway.RunActionResponse{
+		ActionId: actionTag.Id(),
+		Status:   "pending",
+	})
+	s.stub.CheckCallNames(c, "Connect", "Enqueue", "Close")
+	s.stub.CheckCall(c, 1, "Enqueue", params.Actions{
+		Actions: []params.Action{{
+			Receiver: "unit-mysql-0",
+			Name:     "backup",
+			Parameters: map[string]interface{}{
+				"compress": true,
+				"target":   "s3://backups",
+				"limits": map[string]interface{}{
+					"files": 10,
+					"dirs":  []interface{}{"a", "b"},
+				},
+			},
+		}},
+	})
+}
+
+func (s *GatewaySuite) TestRunActionNotDefined(c *gc.C) {
+	s.facades.actionResults = params.ActionResults{
+		Results: []params.ActionResult{{
+			Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: `no action named "backup" found`,
+			},
+		}},
+	}
+	code, message := s.unaryCall(c, "RunAction", &grpcgateway.RunActionRequest{
+		ModelUUID: coretesting.ModelTag.Id(),
+		Unit:      "mysql/0",
+		Action:    "backup",
+	}, nil)
+	c.Assert(code, gc.Equals, "5")
+	c.Assert(message, gc.Equals, `no action named "backup" found`)
+}
+
+func (s *GatewaySuite) TestWatchEvents(c *gc.C) {
+	s.facades.watcher = &mockWatcher{
+		batches: [][]multiwatcher.Delta{{{
+			Entity: &multiwatcher.MachineInfo{
+				Id:          "0",
+				AgentStatus: multiwatcher.StatusInfo{Current: status.Started},
+			},
+		}, {
+			Entity: &multiwatcher.RelationInfo{Key: "mysql:cluster"},
+		}}, {{
+			Entity: &multiwatcher.UnitInfo{
+				Name: "mysql/0",
+				WorkloadStatus: multiwatcher.StatusInfo{
+					Current: status.Active,
+					Message: "ready",
+				},
+			},
+		}, {
+			Removed: true,
+			Entity:  &multiwatcher.ApplicationInfo{Name: "wordpress"},
+		}, {
+			Entity: &multiwatcher.ActionInfo{
+				Id:      "f47ac10b-58cc-4372-a567-0e02b2c3d479",
+				Status:  "completed",
+				Message: "done",
+			},
+		}}},
+		exhausted: func() { close(s.abort) },
+		stopped:   make(chan struct{}),
+	}
+
+	messages, code, message := s.call(c, s.request(c, "WatchEvents", &grpcgateway.WatchEventsRequest{
+		ModelUUID: coretesting.ModelTag.Id(),
+	}))
+	c.Assert(code, gc.Equals, "14")
+	c.Assert(message, gc.Equals, "gateway stopped")
+	s.stub.CheckCallNames(c, "Connect", "WatchAll", "Close")
+
+	var events []*grpcgateway.Event
+	for _, data := range messages {
+		var event grpcgateway.Event
+		err := proto.Unmarshal(data, &event)
+		c.Assert(err, jc.ErrorIsNil)
+		events = append(events, &event)
+	}
+	c.Assert(events, jc.DeepEquals, []*grpcgateway.Event{{
+		Kind:   "machine",
+		Id:     "0",
+		Status: &grpcgateway.Status{Current: "started"},
+	}, {
+		Kind:   "unit",
+		Id:     "mysql/0",
+		Status: &grpcgateway.Status{Current: "active", Message: "ready"},
+	}, {
+		Kind:    "application",
+		Id:      "wordpress",
+		Removed: true,
+		Status:  &grpcgateway.Status{},
+	}, {
+		Kind:   "action",
+		Id:     "f47ac10b-58cc-4372-a567-0e02b2c3d479",
+		Status: &grpcgateway.Status{Current: "completed", Message: "done"},
+	}})
+}
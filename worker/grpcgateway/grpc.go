@@ -0,0 +1,185 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package grpcgateway
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// This file implements the parts of the gRPC protocol over HTTP/2 that
+// the gateway uses: unary and server-streaming calls exchanging
+// uncompressed protobuf messages. The protocol is described at
+// https://github.com/grpc/grpc/blob/master/doc/PROTOCOL-HTTP2.md.
+
+const (
+	// serviceName is the fully qualified name of the service declared
+	// in juju.proto.
+	serviceName = "juju.gateway.v1.Juju"
+
+	// maxMessageSize is the size of the largest request message that
+	// the gateway accepts.
+	maxMessageSize = 4 << 20
+
+	// The names of the trailers holding the status of a call.
+	statusTrailer  = "Grpc-Status"
+	messageTrailer = "Grpc-Message"
+)
+
+// code is a gRPC status code.
+type code int
+
+const (
+	codeOK                 code = 0
+	codeUnknown            code = 2
+	codeInvalidArgument    code = 3
+	codeNotFound           code = 5
+	codeAlreadyExists      code = 6
+	codePermissionDenied   code = 7
+	codeFailedPrecondition code = 9
+	codeUnimplemented      code = 12
+	codeUnavailable        code = 14
+	codeUnauthenticated    code = 16
+)
+
+// statusError is an error that ends a call with the given status code.
+type statusError struct {
+	code    code
+	message string
+}
+
+// Error is part of the error interface.
+func (e *statusError) Error() string {
+	return e.message
+}
+
+func statusErrorf(c code, format string, args ...interface{}) error {
+	return &statusError{code: c, message: fmt.Sprintf(format, args...)}
+}
+
+// errorCode returns the status code that best describes the given
+// error, which is usually one returned by a facade call.
+func errorCode(err error) code {
+	if err == nil {
+		return codeOK
+	}
+	if err, ok := errors.Cause(err).(*statusError); ok {
+		return err.code
+	}
+	switch {
+	case errors.IsNotValid(err), errors.IsBadRequest(err):
+		return codeInvalidArgument
+	case errors.IsNotFound(err):
+		return codeNotFound
+	case errors.IsAlreadyExists(err):
+		return codeAlreadyExists
+	case errors.IsUnauthorized(err):
+		return codePermissionDenied
+	case errors.IsNotSupported(err), errors.IsNotImplemented(err):
+		return codeUnimplemented
+	}
+	switch params.ErrCode(err) {
+	case params.CodeNotFound, params.CodeUserNotFound, params.CodeModelNotFound:
+		return codeNotFound
+	case params.CodeAlreadyExists:
+		return codeAlreadyExists
+	case params.CodeUnauthorized, params.CodeForbidden:
+		return codePermissionDenied
+	case params.CodeBadRequest, params.CodeIncompatibleSeries:
+		return codeInvalidArgument
+	case params.CodeNotSupported, params.CodeNotImplemented:
+		return codeUnimplemented
+	case params.CodeOperationBlocked, params.CodeUpgradeInProgress,
+		params.CodeMigrationInProgress, params.CodeInMaintenance,
+		params.CodeQuotaExceeded:
+		return codeFailedPrecondition
+	case params.CodeTryAgain, params.CodeRetry, params.CodeExcessiveContention:
+		return codeUnavailable
+	}
+	return codeUnknown
+}
+
+// isGRPCContentType reports whether the given content type is one
+// used for gRPC calls with protobuf messages.
+func isGRPCContentType(contentType string) bool {
+	return contentType == "application/grpc" || contentType == "application/grpc+proto"
+}
+
+// readMessage reads a single length-prefixed message from the body of
+// a call's request into m.
+func readMessage(r io.Reader, m proto.Message) error {
+	var header [5]byte
+	if _, err := io.ReadFull(r, header[:]); err == io.EOF || err == io.ErrUnexpectedEOF {
+		return statusErrorf(codeInvalidArgument, "missing request message")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if header[0] != 0 {
+		return statusErrorf(codeUnimplemented, "compressed messages not supported")
+	}
+	size := binary.BigEndian.Uint32(header[1:])
+	if size > maxMessageSize {
+		return statusErrorf(codeInvalidArgument, "request message larger than %d bytes", maxMessageSize)
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return statusErrorf(codeInvalidArgument, "truncated request message")
+	}
+	if err := proto.Unmarshal(data, m); err != nil {
+		return statusErrorf(codeInvalidArgument, "cannot parse request message: %v", err)
+	}
+	return nil
+}
+
+// writeMessage writes m as a single length-prefixed message to the
+// body of a call's response, and flushes it to the client.
+func writeMessage(w http.ResponseWriter, m proto.Message) error {
+	data, err := proto.Marshal(m)
+	if err != nil {
+		return errors.Annotate(err, "cannot marshal response message")
+	}
+	frame := make([]byte, 5+len(data))
+	binary.BigEndian.PutUint32(frame[1:5], uint32(len(data)))
+	copy(frame[5:], data)
+	if _, err := w.Write(frame); err != nil {
+		return errors.Trace(err)
+	}
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
+
+// setStatus sets the trailers that report the outcome of a call,
+// which must have been declared before the response was written.
+func setStatus(w http.ResponseWriter, err error) {
+	w.Header().Set(statusTrailer, strconv.Itoa(int(errorCode(err))))
+	if err != nil {
+		w.Header().Set(messageTrailer, encodeMessage(err.Error()))
+	}
+}
+
+// encodeMessage percent-encodes a status message, as the protocol
+// requires of the Grpc-Message trailer.
+func encodeMessage(message string) string {
+	var encoded bytes.Buffer
+	for i := 0; i < len(message); i++ {
+		c := message[i]
+		if c < ' ' || c > '~' || c == '%' {
+			fmt.Fprintf(&encoded, "%%%02X", c)
+			continue
+		}
+		encoded.WriteByte(c)
+	}
+	return encoded.String()
+}
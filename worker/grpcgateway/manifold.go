@@ -0,0 +1,114 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package grpcgateway
+
+import (
+	"crypto/tls"
+	"net"
+	"strconv"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/worker/dependency"
+	workerstate "github.com/juju/juju/worker/state"
+)
+
+// ManifoldConfig holds the information necessary to run a gRPC
+// gateway worker in a dependency.Engine.
+type ManifoldConfig struct {
+	AgentName       string
+	CertWatcherName string
+	StateName       string
+
+	NewWorker func(Config) (worker.Worker, error)
+}
+
+// Validate is called by start to check for bad configuration.
+func (config ManifoldConfig) Validate() error {
+	if config.AgentName == "" {
+		return errors.NotValidf("empty AgentName")
+	}
+	if config.CertWatcherName == "" {
+		return errors.NotValidf("empty CertWatcherName")
+	}
+	if config.StateName == "" {
+		return errors.NotValidf("empty StateName")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// Manifold returns a dependency.Manifold that will run a gRPC gateway
+// worker if the controller's grpc-port is set. The setting is read
+// when the worker starts, so changes to it take effect when the
+// agent is restarted.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.AgentName,
+			config.CertWatcherName,
+			config.StateName,
+		},
+		Start: config.start,
+	}
+}
+
+// start is a method on ManifoldConfig because it's more readable than a closure.
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var agent agent.Agent
+	if err := context.Get(config.AgentName, &agent); err != nil {
+		return nil, errors.Trace(err)
+	}
+	apiInfo, ok := agent.CurrentConfig().APIInfo()
+	if !ok {
+		return nil, errors.New("no API connection details")
+	}
+
+	var getCertificate func() *tls.Certificate
+	if err := context.Get(config.CertWatcherName, &getCertificate); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var stTracker workerstate.StateTracker
+	if err := context.Get(config.StateName, &stTracker); err != nil {
+		return nil, errors.Trace(err)
+	}
+	statePool, err := stTracker.Use()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	controllerConfig, err := statePool.SystemState().ControllerConfig()
+	stTracker.Done()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	port := controllerConfig.GRPCPort()
+	if port == 0 {
+		return nil, dependency.ErrUninstall
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort("", strconv.Itoa(port)))
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot listen for gRPC gateway")
+	}
+	w, err := config.NewWorker(Config{
+		Listener:       listener,
+		GetCertificate: getCertificate,
+		Connect:        NewConnectFunc(apiInfo, api.Open),
+	})
+	if err != nil {
+		listener.Close()
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
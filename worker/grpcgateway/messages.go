@@ -0,0 +1,178 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package grpcgateway
+
+import (
+	"github.com/golang/protobuf/proto"
+)
+
+// The types below are the messages declared in juju.proto. Their
+// field tags determine their protobuf encoding, so any change to them
+// must be made to juju.proto too, and must keep the wire format
+// compatible with existing clients. The tests check that the two
+// agree.
+
+// Status holds the status of an entity.
+type Status struct {
+	Current string `protobuf:"bytes,1,opt,name=current" json:"current,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message" json:"message,omitempty"`
+}
+
+func (m *Status) Reset()         { *m = Status{} }
+func (m *Status) String() string { return proto.CompactTextString(m) }
+func (*Status) ProtoMessage()    {}
+
+// StatusRequest is the request of the Status call.
+type StatusRequest struct {
+	ModelUUID string `protobuf:"bytes,1,opt,name=model_uuid,json=modelUuid" json:"model_uuid,omitempty"`
+}
+
+func (m *StatusRequest) Reset()         { *m = StatusRequest{} }
+func (m *StatusRequest) String() string { return proto.CompactTextString(m) }
+func (*StatusRequest) ProtoMessage()    {}
+
+// StatusResponse is the response of the Status call.
+type StatusResponse struct {
+	ModelName    string               `protobuf:"bytes,1,opt,name=model_name,json=modelName" json:"model_name,omitempty"`
+	Machines     []*MachineStatus     `protobuf:"bytes,2,rep,name=machines" json:"machines,omitempty"`
+	Applications []*ApplicationStatus `protobuf:"bytes,3,rep,name=applications" json:"applications,omitempty"`
+}
+
+func (m *StatusResponse) Reset()         { *m = StatusResponse{} }
+func (m *StatusResponse) String() string { return proto.CompactTextString(m) }
+func (*StatusResponse) ProtoMessage()    {}
+
+// MachineStatus holds the status of a machine.
+type MachineStatus struct {
+	Id             string  `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	InstanceId     string  `protobuf:"bytes,2,opt,name=instance_id,json=instanceId" json:"instance_id,omitempty"`
+	Series         string  `protobuf:"bytes,3,opt,name=series" json:"series,omitempty"`
+	DNSName        string  `protobuf:"bytes,4,opt,name=dns_name,json=dnsName" json:"dns_name,omitempty"`
+	AgentStatus    *Status `protobuf:"bytes,5,opt,name=agent_status,json=agentStatus" json:"agent_status,omitempty"`
+	InstanceStatus *Status `protobuf:"bytes,6,opt,name=instance_status,json=instanceStatus" json:"instance_status,omitempty"`
+}
+
+func (m *MachineStatus) Reset()         { *m = MachineStatus{} }
+func (m *MachineStatus) String() string { return proto.CompactTextString(m) }
+func (*MachineStatus) ProtoMessage()    {}
+
+// ApplicationStatus holds the status of an application and its
+// units.
+type ApplicationStatus struct {
+	Name    string        `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Charm   string        `protobuf:"bytes,2,opt,name=charm" json:"charm,omitempty"`
+	Series  string        `protobuf:"bytes,3,opt,name=series" json:"series,omitempty"`
+	Exposed bool          `protobuf:"varint,4,opt,name=exposed" json:"exposed,omitempty"`
+	Status  *Status       `protobuf:"bytes,5,opt,name=status" json:"status,omitempty"`
+	Units   []*UnitStatus `protobuf:"bytes,6,rep,name=units" json:"units,omitempty"`
+}
+
+func (m *ApplicationStatus) Reset()         { *m = ApplicationStatus{} }
+func (m *ApplicationStatus) String() string { return proto.CompactTextString(m) }
+func (*ApplicationStatus) ProtoMessage()    {}
+
+// UnitStatus holds the status of a unit.
+type UnitStatus struct {
+	Name           string  `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Machine        string  `protobuf:"bytes,2,opt,name=machine" json:"machine,omitempty"`
+	PublicAddress  string  `protobuf:"bytes,3,opt,name=public_address,json=publicAddress" json:"public_address,omitempty"`
+	Leader         bool    `protobuf:"varint,4,opt,name=leader" json:"leader,omitempty"`
+	WorkloadStatus *Status `protobuf:"bytes,5,opt,name=workload_status,json=workloadStatus" json:"workload_status,omitempty"`
+	AgentStatus    *Status `protobuf:"bytes,6,opt,name=agent_status,json=agentStatus" json:"agent_status,omitempty"`
+}
+
+func (m *UnitStatus) Reset()         { *m = UnitStatus{} }
+func (m *UnitStatus) String() string { return proto.CompactTextString(m) }
+func (*UnitStatus) ProtoMessage()    {}
+
+// DeployRequest is the request of the Deploy call.
+type DeployRequest struct {
+	ModelUUID   string `protobuf:"bytes,1,opt,name=model_uuid,json=modelUuid" json:"model_uuid,omitempty"`
+	CharmURL    string `protobuf:"bytes,2,opt,name=charm_url,json=charmUrl" json:"charm_url,omitempty"`
+	Channel     string `protobuf:"bytes,3,opt,name=channel" json:"channel,omitempty"`
+	Application string `protobuf:"bytes,4,opt,name=application" json:"application,omitempty"`
+	Series      string `protobuf:"bytes,5,opt,name=series" json:"series,omitempty"`
+	NumUnits    int32  `protobuf:"varint,6,opt,name=num_units,json=numUnits" json:"num_units,omitempty"`
+	ConfigYAML  string `protobuf:"bytes,7,opt,name=config_yaml,json=configYaml" json:"config_yaml,omitempty"`
+	Constraints string `protobuf:"bytes,8,opt,name=constraints" json:"constraints,omitempty"`
+}
+
+func (m *DeployRequest) Reset()         { *m = DeployRequest{} }
+func (m *DeployRequest) String() string { return proto.CompactTextString(m) }
+func (*DeployRequest) ProtoMessage()    {}
+
+// DeployResponse is the response of the Deploy call.
+type DeployResponse struct {
+	Application string `protobuf:"bytes,1,opt,name=application" json:"application,omitempty"`
+	CharmURL    string `protobuf:"bytes,2,opt,name=charm_url,json=charmUrl" json:"charm_url,omitempty"`
+}
+
+func (m *DeployResponse) Reset()         { *m = DeployResponse{} }
+func (m *DeployResponse) String() string { return proto.CompactTextString(m) }
+func (*DeployResponse) ProtoMessage()    {}
+
+// ScaleRequest is the request of the Scale call.
+type ScaleRequest struct {
+	ModelUUID   string `protobuf:"bytes,1,opt,name=model_uuid,json=modelUuid" json:"model_uuid,omitempty"`
+	Application string `protobuf:"bytes,2,opt,name=application" json:"application,omitempty"`
+	NumUnits    int32  `protobuf:"varint,3,opt,name=num_units,json=numUnits" json:"num_units,omitempty"`
+}
+
+func (m *ScaleRequest) Reset()         { *m = ScaleRequest{} }
+func (m *ScaleRequest) String() string { return proto.CompactTextString(m) }
+func (*ScaleRequest) ProtoMessage()    {}
+
+// ScaleResponse is the response of the Scale call.
+type ScaleResponse struct {
+	AddedUnits   []string `protobuf:"bytes,1,rep,name=added_units,json=addedUnits" json:"added_units,omitempty"`
+	RemovedUnits []string `protobuf:"bytes,2,rep,name=removed_units,json=removedUnits" json:"removed_units,omitempty"`
+}
+
+func (m *ScaleResponse) Reset()         { *m = ScaleResponse{} }
+func (m *ScaleResponse) String() string { return proto.CompactTextString(m) }
+func (*ScaleResponse) ProtoMessage()    {}
+
+// RunActionRequest is the request of the RunAction call.
+type RunActionRequest struct {
+	ModelUUID  string            `protobuf:"bytes,1,opt,name=model_uuid,json=modelUuid" json:"model_uuid,omitempty"`
+	Unit       string            `protobuf:"bytes,2,opt,name=unit" json:"unit,omitempty"`
+	Action     string            `protobuf:"bytes,3,opt,name=action" json:"action,omitempty"`
+	Parameters map[string]string `protobuf:"bytes,4,rep,name=parameters" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *RunActionRequest) Reset()         { *m = RunActionRequest{} }
+func (m *RunActionRequest) String() string { return proto.CompactTextString(m) }
+func (*RunActionRequest) ProtoMessage()    {}
+
+// RunActionResponse is the response of the RunAction call.
+type RunActionResponse struct {
+	ActionId string `protobuf:"bytes,1,opt,name=action_id,json=actionId" json:"action_id,omitempty"`
+	Status   string `protobuf:"bytes,2,opt,name=status" json:"status,omitempty"`
+}
+
+func (m *RunActionResponse) Reset()         { *m = RunActionResponse{} }
+func (m *RunActionResponse) String() string { return proto.CompactTextString(m) }
+func (*RunActionResponse) ProtoMessage()    {}
+
+// WatchEventsRequest is the request of the WatchEvents call.
+type WatchEventsRequest struct {
+	ModelUUID string `protobuf:"bytes,1,opt,name=model_uuid,json=modelUuid" json:"model_uuid,omitempty"`
+}
+
+func (m *WatchEventsRequest) Reset()         { *m = WatchEventsRequest{} }
+func (m *WatchEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchEventsRequest) ProtoMessage()    {}
+
+// Event describes a change to an entity in the model, and is streamed
+// by the WatchEvents call.
+type Event struct {
+	Kind    string  `protobuf:"bytes,1,opt,name=kind" json:"kind,omitempty"`
+	Id      string  `protobuf:"bytes,2,opt,name=id" json:"id,omitempty"`
+	Removed bool    `protobuf:"varint,3,opt,name=removed" json:"removed,omitempty"`
+	Status  *Status `protobuf:"bytes,4,opt,name=status" json:"status,omitempty"`
+}
+
+func (m *Event) Reset()         { *m = Event{} }
+func (m *Event) String() string { return proto.CompactTextString(m) }
+func (*Event) ProtoMessage()    {}
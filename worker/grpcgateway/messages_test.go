@@ -0,0 +1,272 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package grpcgateway_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/grpcgateway"
+)
+
+// MessagesSuite checks the hand-written message types against the
+// definitions in juju.proto, so that the two cannot drift apart.
+type MessagesSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&MessagesSuite{})
+
+// messages holds every message type declared in juju.proto.
+var messages = []proto.Message{
+	&grpcgateway.Status{},
+	&grpcgateway.StatusRequest{},
+	&grpcgateway.StatusResponse{},
+	&grpcgateway.MachineStatus{},
+	&grpcgateway.ApplicationStatus{},
+	&grpcgateway.UnitStatus{},
+	&grpcgateway.DeployRequest{},
+	&grpcgateway.DeployResponse{},
+	&grpcgateway.ScaleRequest{},
+	&grpcgateway.ScaleResponse{},
+	&grpcgateway.RunActionRequest{},
+	&grpcgateway.RunActionResponse{},
+	&grpcgateway.WatchEventsRequest{},
+	&grpcgateway.Event{},
+}
+
+// protoField describes a message field, either as declared in
+// juju.proto or as derived from a Go struct field.
+type protoField struct {
+	Number   int
+	Name     string
+	Type     string
+	Repeated bool
+}
+
+var (
+	messageRE = regexp.MustCompile(`^message\s+(\w+)\s*{$`)
+	fieldRE   = regexp.MustCompile(`^(repeated\s+)?(map<\s*\w+\s*,\s*\w+\s*>|\w+)\s+(\w+)\s*=\s*(\d+)\s*;$`)
+	spaceRE   = regexp.MustCompile(`\s+`)
+)
+
+// parseProto returns the fields of the messages declared in the given
+// proto file, keyed by message name. It understands only the subset of
+// the proto3 language used by juju.proto.
+func parseProto(c *gc.C, path string) map[string][]protoField {
+	data, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	result := make(map[string][]protoField)
+	var message string
+	for i, line := range strings.Split(string(data), "\n") {
+		if n := strings.Index(line, "//"); n >= 0 {
+			line = line[:n]
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+		case message == "":
+			if m := messageRE.FindStringSubmatch(line); m != nil {
+				message = m[1]
+				result[message] = nil
+			}
+		case line == "}":
+			message = ""
+		default:
+			m := fieldRE.FindStringSubmatch(line)
+			c.Assert(m, gc.NotNil, gc.Commentf("%s:%d: cannot parse %q", path, i+1, line))
+			number, err := strconv.Atoi(m[4])
+			c.Assert(err, jc.ErrorIsNil)
+			fieldType := spaceRE.ReplaceAllString(m[2], "")
+			result[message] = append(result[message], protoField{
+				Number:   number,
+				Name:     m[3],
+				Type:     fieldType,
+				Repeated: m[1] != "" || strings.HasPrefix(fieldType, "map<"),
+			})
+		}
+	}
+	c.Assert(message, gc.Equals, "", gc.Commentf("%s: unterminated message", path))
+	return result
+}
+
+// structFields returns the fields of the given message type as
+// described by its Go struct, checking that each field's protobuf tag
+// is consistent with its Go type.
+func structFields(c *gc.C, t reflect.Type) []protoField {
+	var fields []protoField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("protobuf")
+		if tag == "" {
+			continue
+		}
+		comment := gc.Commentf("%s.%s", t.Name(), f.Name)
+		parts := strings.Split(tag, ",")
+		c.Assert(len(parts) >= 4, jc.IsTrue, comment)
+		c.Assert(strings.HasPrefix(parts[3], "name="), jc.IsTrue, comment)
+		number, err := strconv.Atoi(parts[1])
+		c.Assert(err, jc.ErrorIsNil, comment)
+		fieldType, repeated := goProtoType(c, f.Type, comment)
+		c.Check(parts[0], gc.Equals, wireType(fieldType, repeated), comment)
+		c.Check(parts[2] == "rep", gc.Equals, repeated, comment)
+		fields = append(fields, protoField{
+			Number:   number,
+			Name:     strings.TrimPrefix(parts[3], "name="),
+			Type:     fieldType,
+			Repeated: repeated,
+		})
+	}
+	return fields
+}
+
+// goProtoType returns the proto type corresponding to the given Go
+// type, and whether the field is repeated.
+func goProtoType(c *gc.C, t reflect.Type, comment gc.CommentInterface) (string, bool) {
+	switch t.Kind() {
+	case reflect.String, reflect.Bool, reflect.Int32:
+		return t.Kind().String(), false
+	case reflect.Ptr:
+		c.Assert(t.Elem().Kind(), gc.Equals, reflect.Struct, comment)
+		return t.Elem().Name(), false
+	case reflect.Slice:
+		elem, repeated := goProtoType(c, t.Elem(), comment)
+		c.Assert(repeated, jc.IsFalse, comment)
+		return elem, true
+	case reflect.Map:
+		key, _ := goProtoType(c, t.Key(), comment)
+		value, _ := goProtoType(c, t.Elem(), comment)
+		return fmt.Sprintf("map<%s,%s>", key, value), true
+	}
+	c.Fatalf("unsupported type %s (%s)", t, comment.CheckCommentString())
+	panic("unreachable")
+}
+
+// wireType returns the wire type named in the protobuf tag of a field
+// with the given proto type.
+func wireType(fieldType string, repeated bool) string {
+	switch fieldType {
+	case "bool", "int32":
+		if !repeated {
+			return "varint"
+		}
+	}
+	return "bytes"
+}
+
+func (s *MessagesSuite) TestMessagesMatchProto(c *gc.C) {
+	declared := parseProto(c, "juju.proto")
+	c.Assert(messages, gc.HasLen, len(declared))
+	for _, m := range messages {
+		t := reflect.TypeOf(m).Elem()
+		fields, ok := declared[t.Name()]
+		c.Assert(ok, jc.IsTrue, gc.Commentf("message %s not declared in juju.proto", t.Name()))
+		c.Check(structFields(c, t), jc.DeepEquals, fields, gc.Commentf("message %s", t.Name()))
+	}
+}
+
+func (s *MessagesSuite) TestMessagesRoundTrip(c *gc.C) {
+	declared := parseProto(c, "juju.proto")
+	for _, m := range messages {
+		t := reflect.TypeOf(m).Elem()
+		comment := gc.Commentf("message %s", t.Name())
+		in := reflect.New(t)
+		populate(in.Elem())
+		data, err := proto.Marshal(in.Interface().(proto.Message))
+		c.Assert(err, jc.ErrorIsNil, comment)
+
+		// Every field is set, so every declared field must be
+		// encoded with its declared number and wire type.
+		c.Check(encodedFields(c, data), jc.DeepEquals, declaredWireTypes(declared[t.Name()]), comment)
+
+		out := reflect.New(t)
+		err = proto.Unmarshal(data, out.Interface().(proto.Message))
+		c.Assert(err, jc.ErrorIsNil, comment)
+		c.Check(out.Interface(), jc.DeepEquals, in.Interface(), comment)
+	}
+}
+
+// populate sets every field of v, recursively, to a non-zero value.
+func populate(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString("value")
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int32:
+		v.SetInt(42)
+	case reflect.Ptr:
+		v.Set(reflect.New(v.Type().Elem()))
+		populate(v.Elem())
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).Tag.Get("protobuf") != "" {
+				populate(v.Field(i))
+			}
+		}
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), 2, 2))
+		for i := 0; i < v.Len(); i++ {
+			populate(v.Index(i))
+		}
+	case reflect.Map:
+		v.Set(reflect.MakeMap(v.Type()))
+		key := reflect.New(v.Type().Key()).Elem()
+		value := reflect.New(v.Type().Elem()).Elem()
+		populate(key)
+		populate(value)
+		v.SetMapIndex(key, value)
+	}
+}
+
+// declaredWireTypes returns the protobuf wire types of the given
+// fields, keyed by field number.
+func declaredWireTypes(fields []protoField) map[uint64]uint64 {
+	result := make(map[uint64]uint64)
+	for _, f := range fields {
+		result[uint64(f.Number)] = proto.WireBytes
+		if wireType(f.Type, f.Repeated) == "varint" {
+			result[uint64(f.Number)] = proto.WireVarint
+		}
+	}
+	return result
+}
+
+// encodedFields returns the wire types of the top-level fields encoded
+// in data, keyed by field number.
+func encodedFields(c *gc.C, data []byte) map[uint64]uint64 {
+	result := make(map[uint64]uint64)
+	for len(data) > 0 {
+		key, n := proto.DecodeVarint(data)
+		c.Assert(n, gc.Not(gc.Equals), 0, gc.Commentf("cannot decode field key"))
+		data = data[n:]
+		number, wire := key>>3, key&7
+		comment := gc.Commentf("field %d", number)
+		if previous, ok := result[number]; ok {
+			c.Assert(wire, gc.Equals, previous, comment)
+		}
+		result[number] = wire
+		value, n := proto.DecodeVarint(data)
+		c.Assert(n, gc.Not(gc.Equals), 0, comment)
+		data = data[n:]
+		switch wire {
+		case proto.WireVarint:
+		case proto.WireBytes:
+			c.Assert(uint64(len(data)) >= value, jc.IsTrue, comment)
+			data = data[value:]
+		default:
+			c.Fatalf("unexpected wire type %d for field %d", wire, number)
+		}
+	}
+	return result
+}
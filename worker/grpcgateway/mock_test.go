@@ -0,0 +1,95 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package grpcgateway_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	"gopkg.in/juju/charm.v6"
+	csparams "gopkg.in/juju/charmrepo.v2/csclient/params"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/worker/grpcgateway"
+)
+
+type mockFacades struct {
+	*testing.Stub
+	status         *params.FullStatus
+	resolved       *charm.URL
+	addedUnits     []string
+	destroyResults []params.DestroyUnitResult
+	actionResults  params.ActionResults
+	watcher        *mockWatcher
+}
+
+func (f *mockFacades) Status(patterns []string) (*params.FullStatus, error) {
+	f.MethodCall(f, "Status", patterns)
+	return f.status, f.NextErr()
+}
+
+func (f *mockFacades) ResolveCharm(curl *charm.URL) (*charm.URL, error) {
+	f.MethodCall(f, "ResolveCharm", curl)
+	return f.resolved, f.NextErr()
+}
+
+func (f *mockFacades) AddCharm(curl *charm.URL, channel csparams.Channel) error {
+	f.MethodCall(f, "AddCharm", curl, channel)
+	return f.NextErr()
+}
+
+func (f *mockFacades) Deploy(args application.DeployArgs) error {
+	f.MethodCall(f, "Deploy", args)
+	return f.NextErr()
+}
+
+func (f *mockFacades) AddUnits(args application.AddUnitsParams) ([]string, error) {
+	f.MethodCall(f, "AddUnits", args)
+	return f.addedUnits, f.NextErr()
+}
+
+func (f *mockFacades) DestroyUnits(args application.DestroyUnitsParams) ([]params.DestroyUnitResult, error) {
+	f.MethodCall(f, "DestroyUnits", args)
+	return f.destroyResults, f.NextErr()
+}
+
+func (f *mockFacades) Enqueue(args params.Actions) (params.ActionResults, error) {
+	f.MethodCall(f, "Enqueue", args)
+	return f.actionResults, f.NextErr()
+}
+
+func (f *mockFacades) WatchAll() (grpcgateway.AllWatcher, error) {
+	f.MethodCall(f, "WatchAll")
+	return f.watcher, f.NextErr()
+}
+
+func (f *mockFacades) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+// mockWatcher returns each of its batches of deltas in turn, and then
+// calls exhausted and waits to be stopped.
+type mockWatcher struct {
+	batches   [][]multiwatcher.Delta
+	exhausted func()
+	stopped   chan struct{}
+}
+
+func (w *mockWatcher) Next() ([]multiwatcher.Delta, error) {
+	if len(w.batches) > 0 {
+		deltas := w.batches[0]
+		w.batches = w.batches[1:]
+		return deltas, nil
+	}
+	w.exhausted()
+	<-w.stopped
+	return nil, errors.New("watcher was stopped")
+}
+
+func (w *mockWatcher) Stop() error {
+	close(w.stopped)
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package grpcgateway provides a worker that serves a curated, stable
+// subset of the Juju API to gRPC clients, for ecosystems in which the
+// Juju websocket RPC protocol is a barrier. The service is declared in
+// juju.proto; each of its calls is translated into facade calls made
+// through the controller's API server, with the credentials of the
+// caller.
+package grpcgateway
+
+import (
+	"crypto/tls"
+	"log"
+	"net"
+	"net/http"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/tomb.v1"
+)
+
+var logger = loggo.GetLogger("juju.worker.grpcgateway")
+
+// Config holds the configuration of a gRPC gateway worker.
+type Config struct {
+	// Listener is the listener on which the gateway is served. The
+	// worker closes it when it stops.
+	Listener net.Listener
+
+	// GetCertificate returns the certificate with which the gateway
+	// is served.
+	GetCertificate func() *tls.Certificate
+
+	// Connect connects to the API, with the credentials of each
+	// caller.
+	Connect ConnectFunc
+}
+
+// Validate returns an error if the config cannot be used to start a
+// gRPC gateway worker.
+func (config Config) Validate() error {
+	if config.Listener == nil {
+		return errors.NotValidf("nil Listener")
+	}
+	if config.GetCertificate == nil {
+		return errors.NotValidf("nil GetCertificate")
+	}
+	if config.Connect == nil {
+		return errors.NotValidf("nil Connect")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that serves the gRPC gateway, over
+// HTTP/2 with TLS, on the configured listener.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &gatewayWorker{
+		listener: config.Listener,
+		done:     make(chan struct{}),
+	}
+
+	// gRPC requires HTTP/2, which the HTTP server negotiates when it
+	// is offered by the TLS configuration.
+	tlsConfig := utils.SecureTLSConfig()
+	tlsConfig.NextProtos = []string{"h2"}
+	tlsConfig.GetCertificate = func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+		return config.GetCertificate(), nil
+	}
+	w.server = &http.Server{
+		Handler:   NewHandler(config.Connect, w.tomb.Dying()),
+		TLSConfig: tlsConfig,
+		ErrorLog: log.New(&loggoWriter{
+			level:  loggo.WARNING,
+			logger: logger,
+		}, "", 0),
+	}
+	logger.Infof("serving gRPC gateway on %s", config.Listener.Addr())
+	go w.serve(tls.NewListener(config.Listener, tlsConfig))
+	go w.run()
+	return w, nil
+}
+
+type gatewayWorker struct {
+	tomb     tomb.Tomb
+	listener net.Listener
+	server   *http.Server
+	done     chan struct{}
+}
+
+func (w *gatewayWorker) serve(listener net.Listener) {
+	defer close(w.done)
+	err := w.server.Serve(listener)
+	logger.Debugf("gRPC gateway server exited, final error was: %v", err)
+}
+
+func (w *gatewayWorker) run() {
+	defer w.tomb.Done()
+	<-w.tomb.Dying()
+	// Closing the server closes the connections to the gateway;
+	// streaming calls end when the tomb is dying.
+	w.listener.Close()
+	w.server.Close()
+	// Don't mark the worker as done until the server has finished.
+	<-w.done
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *gatewayWorker) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *gatewayWorker) Wait() error {
+	return w.tomb.Wait()
+}
+
+// loggoWriter is an io.Writer that writes to a loggo.Logger, so that
+// the HTTP server's errors are logged with the worker's.
+type loggoWriter struct {
+	level  loggo.Level
+	logger loggo.Logger
+}
+
+func (w *loggoWriter) Write(content []byte) (int, error) {
+	w.logger.Logf(w.level, "%s", string(content))
+	return len(content), nil
+}
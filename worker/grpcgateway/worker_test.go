@@ -0,0 +1,91 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package grpcgateway_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/grpcgateway"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	listener net.Listener
+	config   grpcgateway.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { listener.Close() })
+	s.listener = listener
+	s.config = grpcgateway.Config{
+		Listener:       listener,
+		GetCertificate: func() *tls.Certificate { return coretesting.ServerTLSCert },
+		Connect: func(names.ModelTag, names.UserTag, string) (grpcgateway.Facades, error) {
+			return nil, errors.New("not connected")
+		},
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		mutate func(*grpcgateway.Config)
+		err    string
+	}{{
+		func(config *grpcgateway.Config) { config.Listener = nil },
+		"nil Listener not valid",
+	}, {
+		func(config *grpcgateway.Config) { config.GetCertificate = nil },
+		"nil GetCertificate not valid",
+	}, {
+		func(config *grpcgateway.Config) { config.Connect = nil },
+		"nil Connect not valid",
+	}} {
+		c.Logf("test #%d", i)
+		config := s.config
+		test.mutate(&config)
+		err := config.Validate()
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+		w, err := grpcgateway.NewWorker(config)
+		c.Check(w, gc.IsNil)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *WorkerSuite) TestServesHTTP2(c *gc.C) {
+	w, err := grpcgateway.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	caCerts := x509.NewCertPool()
+	c.Assert(caCerts.AppendCertsFromPEM([]byte(coretesting.CACert)), jc.IsTrue)
+	conn, err := tls.Dial("tcp", s.listener.Addr().String(), &tls.Config{
+		RootCAs:    caCerts,
+		ServerName: "anything",
+		NextProtos: []string{"h2"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+	c.Assert(conn.ConnectionState().NegotiatedProtocol, gc.Equals, "h2")
+
+	workertest.CleanKill(c, w)
+	_, err = net.Dial("tcp", s.listener.Addr().String())
+	c.Assert(err, gc.NotNil)
+}
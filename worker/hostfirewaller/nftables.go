@@ -0,0 +1,132 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os/exec"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/hostfirewaller"
+	"github.com/juju/juju/core/egress"
+)
+
+// tableName is the name of the nftables table, in the inet family,
+// that holds the rules juju manages. Rules in other tables are left
+// alone.
+const tableName = "juju-egress"
+
+// Ruleset returns the nftables script that applies the given policy,
+// replacing any rules previously applied. If the policy is not
+// restricted, the script removes juju's rules altogether.
+//
+// A restricted policy drops outbound packets other than those on the
+// loopback interface, those belonging to established connections,
+// DNS and DHCP requests, ICMPv6 (without which IPv6 neighbour
+// discovery fails), connections to the controllers' API servers and
+// those allowed by the policy's rules.
+func Ruleset(policy hostfirewaller.Policy) (string, error) {
+	var buf bytes.Buffer
+	// Declaring the table before deleting it ensures that the
+	// deletion succeeds whether or not it already exists.
+	fmt.Fprintf(&buf, "table inet %s\n", tableName)
+	fmt.Fprintf(&buf, "delete table inet %s\n", tableName)
+	if !policy.Restricted {
+		return buf.String(), nil
+	}
+	fmt.Fprintf(&buf, "table inet %s {\n", tableName)
+	fmt.Fprintf(&buf, "\tchain output {\n")
+	fmt.Fprintf(&buf, "\t\ttype filter hook output priority 0; policy drop;\n")
+	fmt.Fprintf(&buf, "\t\toif \"lo\" accept\n")
+	fmt.Fprintf(&buf, "\t\tct state established,related accept\n")
+	fmt.Fprintf(&buf, "\t\tudp dport { 53, 67, 547 } accept\n")
+	fmt.Fprintf(&buf, "\t\ttcp dport 53 accept\n")
+	fmt.Fprintf(&buf, "\t\tmeta l4proto ipv6-icmp accept\n")
+	for _, hp := range policy.APIHostPorts {
+		family, ok := addressFamily(hp.Value)
+		if !ok {
+			// Connections to API servers by hostname are
+			// established once their addresses are resolved,
+			// which cannot be allowed in advance.
+			logger.Warningf("not allowing connections to API address %q", hp.Value)
+			continue
+		}
+		fmt.Fprintf(&buf, "\t\t%s daddr %s tcp dport %d accept\n", family, hp.Value, hp.Port)
+	}
+	for _, rule := range policy.Rules {
+		line, err := ruleStatement(rule)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		fmt.Fprintf(&buf, "\t\t%s\n", line)
+	}
+	fmt.Fprintf(&buf, "\t}\n")
+	fmt.Fprintf(&buf, "}\n")
+	return buf.String(), nil
+}
+
+// ruleStatement returns the nftables statement accepting the traffic
+// allowed by the egress rule.
+func ruleStatement(rule egress.Rule) (string, error) {
+	ip, _, err := net.ParseCIDR(rule.CIDR)
+	if err != nil {
+		return "", errors.NotValidf("egress cidr %q", rule.CIDR)
+	}
+	family := "ip"
+	if ip.To4() == nil {
+		family = "ip6"
+	}
+	ports, err := rule.PortRange()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	statement := fmt.Sprintf("%s daddr %s", family, rule.CIDR)
+	switch {
+	case ports == nil:
+	case ports.Protocol == "icmp":
+		if family == "ip" {
+			statement += " ip protocol icmp"
+		} else {
+			statement += " meta l4proto ipv6-icmp"
+		}
+	case ports.FromPort == ports.ToPort:
+		statement += fmt.Sprintf(" %s dport %d", ports.Protocol, ports.FromPort)
+	default:
+		statement += fmt.Sprintf(" %s dport %d-%d", ports.Protocol, ports.FromPort, ports.ToPort)
+	}
+	return statement + " accept", nil
+}
+
+// addressFamily returns the nftables address family of the given
+// address, and false if it is not an IP address.
+func addressFamily(addr string) (string, bool) {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return "", false
+	}
+	if ip.To4() != nil {
+		return "ip", true
+	}
+	return "ip6", true
+}
+
+// ApplyRuleset applies the given nftables script with the nft command.
+// If nft is not installed, an error satisfying errors.IsNotFound is
+// returned.
+func ApplyRuleset(ruleset string) error {
+	path, err := exec.LookPath("nft")
+	if err != nil {
+		return errors.NotFoundf("command %q", "nft")
+	}
+	cmd := exec.Command(path, "-f", "-")
+	cmd.Stdin = strings.NewReader(ruleset)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return errors.Annotatef(err, "running nft: %s", bytes.TrimSpace(output))
+	}
+	return nil
+}
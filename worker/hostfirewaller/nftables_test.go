@@ -0,0 +1,77 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apihostfirewaller "github.com/juju/juju/api/hostfirewaller"
+	"github.com/juju/juju/core/egress"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/worker/hostfirewaller"
+)
+
+type RulesetSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&RulesetSuite{})
+
+func (s *RulesetSuite) TestOpen(c *gc.C) {
+	ruleset, err := hostfirewaller.Ruleset(apihostfirewaller.Policy{
+		Rules: []egress.Rule{{CIDR: "10.0.0.0/8"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ruleset, gc.Equals, `
+table inet juju-egress
+delete table inet juju-egress
+`[1:])
+}
+
+func (s *RulesetSuite) TestRestricted(c *gc.C) {
+	ruleset, err := hostfirewaller.Ruleset(apihostfirewaller.Policy{
+		Restricted: true,
+		Rules: []egress.Rule{
+			{CIDR: "0.0.0.0/0", Ports: "443/tcp"},
+			{CIDR: "10.20.0.0/16", Ports: "9000-9100/udp"},
+			{CIDR: "10.30.0.0/16"},
+			{CIDR: "2001:db8::/32", Ports: "icmp"},
+		},
+		APIHostPorts: append(
+			network.NewHostPorts(17070, "10.0.0.1", "fd00::1"),
+			network.NewHostPorts(17070, "controller.example.com")...,
+		),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ruleset, gc.Equals, `
+table inet juju-egress
+delete table inet juju-egress
+table inet juju-egress {
+	chain output {
+		type filter hook output priority 0; policy drop;
+		oif "lo" accept
+		ct state established,related accept
+		udp dport { 53, 67, 547 } accept
+		tcp dport 53 accept
+		meta l4proto ipv6-icmp accept
+		ip daddr 10.0.0.1 tcp dport 17070 accept
+		ip6 daddr fd00::1 tcp dport 17070 accept
+		ip daddr 0.0.0.0/0 tcp dport 443 accept
+		ip daddr 10.20.0.0/16 udp dport 9000-9100 accept
+		ip daddr 10.30.0.0/16 accept
+		ip6 daddr 2001:db8::/32 meta l4proto ipv6-icmp accept
+	}
+}
+`[1:])
+}
+
+func (s *RulesetSuite) TestInvalidRule(c *gc.C) {
+	_, err := hostfirewaller.Ruleset(apihostfirewaller.Policy{
+		Restricted: true,
+		Rules:      []egress.Rule{{CIDR: "bad"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `egress cidr "bad" not valid`)
+}
@@ -0,0 +1,112 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package hostfirewaller implements a worker that restricts the
+// outbound traffic of the agent's machine, as directed by the model's
+// egress policy, using nftables.
+package hostfirewaller
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/hostfirewaller"
+	"github.com/juju/juju/watcher"
+)
+
+var logger = loggo.GetLogger("juju.worker.hostfirewaller")
+
+// Facade exposes the controller functionality needed by the worker.
+type Facade interface {
+	EgressPolicy() (hostfirewaller.Policy, error)
+	WatchEgressPolicy() (watcher.NotifyWatcher, error)
+}
+
+// Config holds the configuration and dependencies of the worker.
+type Config struct {
+	Facade Facade
+
+	// ApplyRuleset applies an nftables script to the machine. See
+	// ApplyRuleset.
+	ApplyRuleset func(ruleset string) error
+}
+
+// Validate returns an error if the config cannot be used to start a
+// worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.ApplyRuleset == nil {
+		return errors.NotValidf("nil ApplyRuleset")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that applies the machine's egress policy
+// whenever it changes.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w, err := watcher.NewNotifyWorker(watcher.NotifyConfig{
+		Handler: &handler{config: config},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// handler implements watcher.NotifyHandler, applying the egress policy
+// whenever the watcher fires.
+type handler struct {
+	config Config
+
+	// applied holds the ruleset most recently applied, so that it is
+	// not applied again while it is unchanged.
+	applied *string
+}
+
+// SetUp is part of the watcher.NotifyHandler interface.
+func (h *handler) SetUp() (watcher.NotifyWatcher, error) {
+	return h.config.Facade.WatchEgressPolicy()
+}
+
+// Handle is part of the watcher.NotifyHandler interface.
+func (h *handler) Handle(_ <-chan struct{}) error {
+	policy, err := h.config.Facade.EgressPolicy()
+	if err != nil {
+		return errors.Annotate(err, "getting egress policy")
+	}
+	ruleset, err := Ruleset(policy)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if h.applied != nil && *h.applied == ruleset {
+		return nil
+	}
+	err = h.config.ApplyRuleset(ruleset)
+	switch {
+	case errors.IsNotFound(err) && !policy.Restricted:
+		// There is nothing to remove from a machine
+		// without nftables.
+		logger.Debugf("not removing egress rules: %v", err)
+	case err != nil:
+		// Failing to restrict the machine's traffic shouldn't
+		// stop the agent, so we just record it and try again
+		// when the policy changes.
+		logger.Errorf("cannot apply egress policy: %v", err)
+		return nil
+	default:
+		logger.Infof("applied egress policy (restricted: %t, %d rules)", policy.Restricted, len(policy.Rules))
+	}
+	h.applied = &ruleset
+	return nil
+}
+
+// TearDown is part of the watcher.NotifyHandler interface.
+func (h *handler) TearDown() error {
+	return nil
+}
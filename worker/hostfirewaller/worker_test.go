@@ -0,0 +1,164 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	apihostfirewaller "github.com/juju/juju/api/hostfirewaller"
+	"github.com/juju/juju/core/egress"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/watcher/watchertest"
+	"github.com/juju/juju/worker/hostfirewaller"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+	facade  *fakeFacade
+	applied chan string
+	errs    []error
+	config  hostfirewaller.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.facade = &fakeFacade{changes: make(chan struct{}, 1)}
+	s.applied = make(chan string, 5)
+	s.config = hostfirewaller.Config{
+		Facade: s.facade,
+		ApplyRuleset: func(ruleset string) error {
+			s.applied <- ruleset
+			if len(s.errs) == 0 {
+				return nil
+			}
+			err := s.errs[0]
+			s.errs = s.errs[1:]
+			return err
+		},
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	config := s.config
+	config.Facade = nil
+	_, err := hostfirewaller.NewWorker(config)
+	c.Check(err, gc.ErrorMatches, "nil Facade not valid")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+
+	config = s.config
+	config.ApplyRuleset = nil
+	_, err = hostfirewaller.NewWorker(config)
+	c.Check(err, gc.ErrorMatches, "nil ApplyRuleset not valid")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *WorkerSuite) TestAppliesPolicy(c *gc.C) {
+	s.facade.SetPolicy(apihostfirewaller.Policy{
+		Restricted: true,
+		Rules:      []egress.Rule{{CIDR: "10.0.0.0/8", Ports: "443/tcp"}},
+	})
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	ruleset := s.assertApplied(c)
+	c.Check(ruleset, jc.Contains, "ip daddr 10.0.0.0/8 tcp dport 443 accept")
+
+	s.facade.SetPolicy(apihostfirewaller.Policy{})
+	s.facade.changes <- struct{}{}
+	c.Check(s.assertApplied(c), gc.Equals, "table inet juju-egress\ndelete table inet juju-egress\n")
+}
+
+func (s *WorkerSuite) TestUnchangedPolicyNotReapplied(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+	s.assertApplied(c)
+
+	s.facade.changes <- struct{}{}
+	s.assertNotApplied(c)
+}
+
+func (s *WorkerSuite) TestApplyErrorRetried(c *gc.C) {
+	s.errs = []error{errors.New("boom")}
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+	s.assertApplied(c)
+
+	// The failed ruleset is applied again on the next change,
+	// and the worker keeps running.
+	s.facade.changes <- struct{}{}
+	s.assertApplied(c)
+	workertest.CheckAlive(c, w)
+}
+
+func (s *WorkerSuite) TestPolicyError(c *gc.C) {
+	s.facade.SetPolicyError(errors.New("no policy"))
+	w := s.startWorker(c)
+	err := workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "getting egress policy: no policy")
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	s.facade.changes <- struct{}{}
+	w, err := hostfirewaller.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *WorkerSuite) assertApplied(c *gc.C) string {
+	select {
+	case ruleset := <-s.applied:
+		return ruleset
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for ruleset to be applied")
+	}
+	return ""
+}
+
+func (s *WorkerSuite) assertNotApplied(c *gc.C) {
+	select {
+	case ruleset := <-s.applied:
+		c.Fatalf("unexpected ruleset applied: %q", ruleset)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type fakeFacade struct {
+	mu        sync.Mutex
+	changes   chan struct{}
+	policy    apihostfirewaller.Policy
+	policyErr error
+}
+
+func (f *fakeFacade) SetPolicy(policy apihostfirewaller.Policy) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.policy = policy
+}
+
+func (f *fakeFacade) SetPolicyError(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.policyErr = err
+}
+
+func (f *fakeFacade) EgressPolicy() (apihostfirewaller.Policy, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.policy, f.policyErr
+}
+
+func (f *fakeFacade) WatchEgressPolicy() (watcher.NotifyWatcher, error) {
+	return watchertest.NewMockNotifyWatcher(f.changes), nil
+}
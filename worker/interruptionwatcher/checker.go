@@ -0,0 +1,125 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package interruptionwatcher
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+)
+
+const (
+	// ec2MetadataURL is the base URL of the EC2 instance metadata
+	// service.
+	ec2MetadataURL = "http://169.254.169.254"
+
+	// gceMetadataURL is the base URL of the GCE instance metadata
+	// server.
+	gceMetadataURL = "http://metadata.google.internal"
+
+	// gcePreemptionNotice is how long GCE gives a preemptible
+	// instance between signalling preemption and stopping it.
+	gcePreemptionNotice = 30 * time.Second
+)
+
+// Checker asks the cloud whether the machine's instance is about to be
+// reclaimed.
+type Checker interface {
+	// Check returns the time at which the instance will be
+	// reclaimed, and whether it will be reclaimed at all.
+	Check() (time.Time, bool, error)
+}
+
+// NewChecker returns a Checker that uses the instance metadata service
+// of the given provider type. Providers which cannot reclaim instances,
+// or do not signal that they will, are not supported.
+func NewChecker(providerType string, clock clock.Clock) (Checker, error) {
+	switch providerType {
+	case "ec2":
+		return NewEC2Checker(http.DefaultClient, ec2MetadataURL), nil
+	case "gce":
+		return NewGCEChecker(http.DefaultClient, gceMetadataURL, clock), nil
+	}
+	return nil, errors.NotSupportedf("interruption checks on %q", providerType)
+}
+
+// NewEC2Checker returns a Checker that polls the spot instance
+// termination notice in the EC2 metadata service at the given URL.
+func NewEC2Checker(client *http.Client, baseURL string) Checker {
+	return &ec2Checker{client: client, baseURL: baseURL}
+}
+
+type ec2Checker struct {
+	client  *http.Client
+	baseURL string
+}
+
+// Check is part of the Checker interface. The instance-action document
+// only exists once EC2 has scheduled the instance for termination.
+func (c *ec2Checker) Check() (time.Time, bool, error) {
+	resp, err := c.client.Get(c.baseURL + "/latest/meta-data/spot/instance-action")
+	if err != nil {
+		return time.Time{}, false, errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return time.Time{}, false, nil
+	default:
+		return time.Time{}, false, errors.Errorf("checking spot instance action: %s", resp.Status)
+	}
+	var action struct {
+		Action string    `json:"action"`
+		Time   time.Time `json:"time"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&action); err != nil {
+		return time.Time{}, false, errors.Annotate(err, "parsing spot instance action")
+	}
+	return action.Time, true, nil
+}
+
+// NewGCEChecker returns a Checker that polls the preempted flag in the
+// GCE metadata server at the given URL.
+func NewGCEChecker(client *http.Client, baseURL string, clock clock.Clock) Checker {
+	return &gceChecker{client: client, baseURL: baseURL, clock: clock}
+}
+
+type gceChecker struct {
+	client  *http.Client
+	baseURL string
+	clock   clock.Clock
+}
+
+// Check is part of the Checker interface. GCE does not say when it
+// will stop a preempted instance, so the deadline is estimated from
+// the notice it promises to give.
+func (c *gceChecker) Check() (time.Time, bool, error) {
+	req, err := http.NewRequest("GET", c.baseURL+"/computeMetadata/v1/instance/preempted", nil)
+	if err != nil {
+		return time.Time{}, false, errors.Trace(err)
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return time.Time{}, false, errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return time.Time{}, false, errors.Errorf("checking preemption: %s", resp.Status)
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return time.Time{}, false, errors.Annotate(err, "reading preemption")
+	}
+	if strings.TrimSpace(string(body)) != "TRUE" {
+		return time.Time{}, false, nil
+	}
+	return c.clock.Now().Add(gcePreemptionNotice), true, nil
+}
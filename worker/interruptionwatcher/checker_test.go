@@ -0,0 +1,96 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package interruptionwatcher_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/interruptionwatcher"
+)
+
+type CheckerSuite struct {
+	testing.IsolationSuite
+
+	server   *httptest.Server
+	requests []*http.Request
+	status   int
+	response string
+}
+
+var _ = gc.Suite(&CheckerSuite{})
+
+func (s *CheckerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.requests = nil
+	s.status = http.StatusOK
+	s.response = ""
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		s.requests = append(s.requests, req)
+		w.WriteHeader(s.status)
+		fmt.Fprint(w, s.response)
+	}))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+}
+
+func (s *CheckerSuite) TestNewCheckerNotSupported(c *gc.C) {
+	checker, err := interruptionwatcher.NewChecker("maas", clock.WallClock)
+	c.Check(checker, gc.IsNil)
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *CheckerSuite) TestEC2Interrupted(c *gc.C) {
+	s.response = `{"action": "terminate", "time": "2018-04-01T12:02:00Z"}`
+	checker := interruptionwatcher.NewEC2Checker(http.DefaultClient, s.server.URL)
+	deadline, interrupted, err := checker.Check()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(interrupted, jc.IsTrue)
+	c.Check(deadline, gc.Equals, time.Date(2018, 4, 1, 12, 2, 0, 0, time.UTC))
+	c.Assert(s.requests, gc.HasLen, 1)
+	c.Check(s.requests[0].URL.Path, gc.Equals, "/latest/meta-data/spot/instance-action")
+}
+
+func (s *CheckerSuite) TestEC2NotInterrupted(c *gc.C) {
+	s.status = http.StatusNotFound
+	checker := interruptionwatcher.NewEC2Checker(http.DefaultClient, s.server.URL)
+	_, interrupted, err := checker.Check()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(interrupted, jc.IsFalse)
+}
+
+func (s *CheckerSuite) TestEC2Error(c *gc.C) {
+	s.status = http.StatusInternalServerError
+	checker := interruptionwatcher.NewEC2Checker(http.DefaultClient, s.server.URL)
+	_, _, err := checker.Check()
+	c.Check(err, gc.ErrorMatches, "checking spot instance action: 500 Internal Server Error")
+}
+
+func (s *CheckerSuite) TestGCEInterrupted(c *gc.C) {
+	s.response = "TRUE"
+	now := time.Date(2018, 4, 1, 12, 0, 0, 0, time.UTC)
+	checker := interruptionwatcher.NewGCEChecker(http.DefaultClient, s.server.URL, testing.NewClock(now))
+	deadline, interrupted, err := checker.Check()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(interrupted, jc.IsTrue)
+	c.Check(deadline, gc.Equals, now.Add(30*time.Second))
+	c.Assert(s.requests, gc.HasLen, 1)
+	c.Check(s.requests[0].URL.Path, gc.Equals, "/computeMetadata/v1/instance/preempted")
+	c.Check(s.requests[0].Header.Get("Metadata-Flavor"), gc.Equals, "Google")
+}
+
+func (s *CheckerSuite) TestGCENotInterrupted(c *gc.C) {
+	s.response = "FALSE"
+	checker := interruptionwatcher.NewGCEChecker(http.DefaultClient, s.server.URL, clock.WallClock)
+	_, interrupted, err := checker.Check()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(interrupted, jc.IsFalse)
+}
@@ -0,0 +1,117 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package interruptionwatcher
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/machiner"
+	"github.com/juju/juju/worker/dependency"
+)
+
+// ManifoldConfig describes the resources and configuration on which
+// the interruption watcher worker depends.
+type ManifoldConfig struct {
+	AgentName     string
+	APICallerName string
+	Clock         clock.Clock
+	Interval      time.Duration
+
+	NewChecker func(providerType string, clock clock.Clock) (Checker, error)
+	NewFacade  func(base.APICaller, names.MachineTag) (Facade, error)
+	NewWorker  func(Config) (worker.Worker, error)
+}
+
+// Validate is called by start to check for bad configuration.
+func (config ManifoldConfig) Validate() error {
+	if config.AgentName == "" {
+		return errors.NotValidf("empty AgentName")
+	}
+	if config.APICallerName == "" {
+		return errors.NotValidf("empty APICallerName")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.NewChecker == nil {
+		return errors.NotValidf("nil NewChecker")
+	}
+	if config.NewFacade == nil {
+		return errors.NotValidf("nil NewFacade")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// Manifold returns a dependency.Manifold that runs an interruption
+// watcher worker. If the machine is a container, or its provider does
+// not signal interruptions, the manifold uninstalls itself.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{config.AgentName, config.APICallerName},
+		Start:  config.start,
+	}
+}
+
+// start is a StartFunc for a Worker manifold.
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var machineAgent agent.Agent
+	if err := context.Get(config.AgentName, &machineAgent); err != nil {
+		return nil, errors.Trace(err)
+	}
+	agentConfig := machineAgent.CurrentConfig()
+	tag, ok := agentConfig.Tag().(names.MachineTag)
+	if !ok {
+		return nil, errors.Errorf("expected a machine tag, got %v", agentConfig.Tag())
+	}
+	if names.IsContainerMachine(tag.Id()) {
+		return nil, dependency.ErrUninstall
+	}
+	checker, err := config.NewChecker(agentConfig.Value(agent.ProviderType), config.Clock)
+	if errors.IsNotSupported(err) {
+		logger.Debugf("provider does not signal interruptions")
+		return nil, dependency.ErrUninstall
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var apiCaller base.APICaller
+	if err := context.Get(config.APICallerName, &apiCaller); err != nil {
+		return nil, errors.Trace(err)
+	}
+	facade, err := config.NewFacade(apiCaller, tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	w, err := config.NewWorker(Config{
+		Facade:   facade,
+		Checker:  checker,
+		Clock:    config.Clock,
+		Interval: config.Interval,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// NewFacade returns the API facade used by the interruption watcher.
+func NewFacade(apiCaller base.APICaller, tag names.MachineTag) (Facade, error) {
+	machine, err := machiner.NewState(apiCaller).Machine(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machine, nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package interruptionwatcher_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/worker/dependency"
+	dt "github.com/juju/juju/worker/dependency/testing"
+	"github.com/juju/juju/worker/interruptionwatcher"
+)
+
+type ManifoldConfigSuite struct {
+	testing.IsolationSuite
+	config interruptionwatcher.ManifoldConfig
+}
+
+var _ = gc.Suite(&ManifoldConfigSuite{})
+
+func (s *ManifoldConfigSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.config = interruptionwatcher.ManifoldConfig{
+		AgentName:     "agent",
+		APICallerName: "api-caller",
+		Clock:         clock.WallClock,
+		NewChecker:    interruptionwatcher.NewChecker,
+		NewFacade: func(base.APICaller, names.MachineTag) (interruptionwatcher.Facade, error) {
+			return nil, nil
+		},
+		NewWorker: func(interruptionwatcher.Config) (worker.Worker, error) { return nil, nil },
+	}
+}
+
+func (s *ManifoldConfigSuite) TestValid(c *gc.C) {
+	c.Check(s.config.Validate(), jc.ErrorIsNil)
+}
+
+func (s *ManifoldConfigSuite) TestMissingAgentName(c *gc.C) {
+	s.config.AgentName = ""
+	s.checkNotValid(c, "empty AgentName not valid")
+}
+
+func (s *ManifoldConfigSuite) TestMissingAPICallerName(c *gc.C) {
+	s.config.APICallerName = ""
+	s.checkNotValid(c, "empty APICallerName not valid")
+}
+
+func (s *ManifoldConfigSuite) TestMissingClock(c *gc.C) {
+	s.config.Clock = nil
+	s.checkNotValid(c, "nil Clock not valid")
+}
+
+func (s *ManifoldConfigSuite) TestMissingNewChecker(c *gc.C) {
+	s.config.NewChecker = nil
+	s.checkNotValid(c, "nil NewChecker not valid")
+}
+
+func (s *ManifoldConfigSuite) TestMissingNewFacade(c *gc.C) {
+	s.config.NewFacade = nil
+	s.checkNotValid(c, "nil NewFacade not valid")
+}
+
+func (s *ManifoldConfigSuite) TestMissingNewWorker(c *gc.C) {
+	s.config.NewWorker = nil
+	s.checkNotValid(c, "nil NewWorker not valid")
+}
+
+func (s *ManifoldConfigSuite) TestInputs(c *gc.C) {
+	manifold := interruptionwatcher.Manifold(s.config)
+	c.Check(manifold.Inputs, jc.DeepEquals, []string{"agent", "api-caller"})
+}
+
+func (s *ManifoldConfigSuite) TestUninstallsOnContainer(c *gc.C) {
+	s.checkUninstalls(c, "0/lxd/1", "ec2")
+}
+
+func (s *ManifoldConfigSuite) TestUninstallsWithoutInterruptions(c *gc.C) {
+	s.checkUninstalls(c, "0", "maas")
+}
+
+func (s *ManifoldConfigSuite) checkUninstalls(c *gc.C, machineId, providerType string) {
+	manifold := interruptionwatcher.Manifold(s.config)
+	w, err := manifold.Start(dt.StubContext(nil, map[string]interface{}{
+		"agent": &mockAgent{conf: mockAgentConfig{
+			tag:          names.NewMachineTag(machineId),
+			providerType: providerType,
+		}},
+		"api-caller": struct{ base.APICaller }{},
+	}))
+	c.Check(w, gc.IsNil)
+	c.Check(errors.Cause(err), gc.Equals, dependency.ErrUninstall)
+}
+
+func (s *ManifoldConfigSuite) checkNotValid(c *gc.C, expect string) {
+	err := s.config.Validate()
+	c.Check(err, gc.ErrorMatches, expect)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+type mockAgent struct {
+	agent.Agent
+	conf mockAgentConfig
+}
+
+func (ma *mockAgent) CurrentConfig() agent.Config {
+	return &ma.conf
+}
+
+type mockAgentConfig struct {
+	agent.Config
+	tag          names.Tag
+	providerType string
+}
+
+func (c *mockAgentConfig) Tag() names.Tag {
+	return c.tag
+}
+
+func (c *mockAgentConfig) Value(key string) string {
+	if key == agent.ProviderType {
+		return c.providerType
+	}
+	return ""
+}
@@ -0,0 +1,114 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package interruptionwatcher provides a worker that watches for the
+// cloud signalling that it will reclaim a machine's spot or preemptible
+// instance, and reports it to the controller so that the machine's
+// units can be drained and moved to a replacement machine.
+package interruptionwatcher
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.interruptionwatcher")
+
+// Facade defines the interface we require from the machiner facade.
+type Facade interface {
+	ReportInterruption(deadline time.Time) error
+}
+
+// Config holds the configuration and dependencies for an interruption
+// watcher worker.
+type Config struct {
+	Facade  Facade
+	Checker Checker
+	Clock   clock.Clock
+
+	// Interval is how often the cloud is asked whether the instance
+	// is about to be reclaimed.
+	Interval time.Duration
+}
+
+// Validate returns an error if the config cannot be expected to drive
+// a functional interruption watcher worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Checker == nil {
+		return errors.NotValidf("nil Checker")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that reports the interruption of the
+// machine's instance to the controller.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &watcherWorker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type watcherWorker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *watcherWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *watcherWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *watcherWorker) loop() error {
+	for {
+		deadline, interrupted, err := w.config.Checker.Check()
+		if err != nil {
+			// The metadata service is not always reachable;
+			// try again later rather than restart.
+			logger.Warningf("cannot check for interruption: %v", err)
+		} else if interrupted {
+			logger.Infof("instance will be reclaimed at %s", deadline)
+			if err := w.config.Facade.ReportInterruption(deadline); err != nil {
+				return errors.Annotate(err, "reporting interruption")
+			}
+			// There is nothing more to do: the controller
+			// drains the units, and the cloud stops the
+			// instance.
+			<-w.catacomb.Dying()
+			return w.catacomb.ErrDying()
+		}
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(w.config.Interval):
+		}
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package interruptionwatcher_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/interruptionwatcher"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+
+	clock   *testing.Clock
+	facade  *mockFacade
+	checker *mockChecker
+	config  interruptionwatcher.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+var deadline = time.Date(2018, 4, 1, 12, 2, 0, 0, time.UTC)
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Time{})
+	s.facade = &mockFacade{reported: make(chan time.Time, 10)}
+	s.checker = &mockChecker{}
+	s.config = interruptionwatcher.Config{
+		Facade:   s.facade,
+		Checker:  s.checker,
+		Clock:    s.clock,
+		Interval: 5 * time.Second,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	s.testValidate(c, func(cfg *interruptionwatcher.Config) {
+		cfg.Facade = nil
+	}, "nil Facade not valid")
+	s.testValidate(c, func(cfg *interruptionwatcher.Config) {
+		cfg.Checker = nil
+	}, "nil Checker not valid")
+	s.testValidate(c, func(cfg *interruptionwatcher.Config) {
+		cfg.Clock = nil
+	}, "nil Clock not valid")
+	s.testValidate(c, func(cfg *interruptionwatcher.Config) {
+		cfg.Interval = 0
+	}, "non-positive Interval not valid")
+}
+
+func (s *WorkerSuite) testValidate(c *gc.C, f func(*interruptionwatcher.Config), expect string) {
+	config := s.config
+	f(&config)
+	w, err := interruptionwatcher.NewWorker(config)
+	if !c.Check(err, gc.NotNil) {
+		workertest.DirtyKill(c, w)
+		return
+	}
+	c.Check(w, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, expect)
+}
+
+func (s *WorkerSuite) TestReportsInterruption(c *gc.C) {
+	s.checker.results = []checkResult{
+		{},
+		{deadline: deadline, interrupted: true},
+	}
+	w, err := interruptionwatcher.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	// Not interrupted at first: wait for the next check.
+	s.assertNotReported(c)
+	err = s.clock.WaitAdvance(5*time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case reported := <-s.facade.reported:
+		c.Assert(reported, gc.Equals, deadline)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for report")
+	}
+
+	// The interruption is only reported once.
+	s.clock.Advance(time.Minute)
+	s.assertNotReported(c)
+	workertest.CheckAlive(c, w)
+}
+
+func (s *WorkerSuite) TestCheckErrorRetried(c *gc.C) {
+	s.checker.results = []checkResult{
+		{err: errors.New("no route to host")},
+		{deadline: deadline, interrupted: true},
+	}
+	w, err := interruptionwatcher.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	err = s.clock.WaitAdvance(5*time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case reported := <-s.facade.reported:
+		c.Assert(reported, gc.Equals, deadline)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for report")
+	}
+}
+
+func (s *WorkerSuite) TestReportError(c *gc.C) {
+	s.checker.results = []checkResult{{deadline: deadline, interrupted: true}}
+	s.facade.SetErrors(errors.New("boom"))
+	w, err := interruptionwatcher.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "reporting interruption: boom")
+}
+
+func (s *WorkerSuite) assertNotReported(c *gc.C) {
+	select {
+	case reported := <-s.facade.reported:
+		c.Fatalf("unexpected report of %s", reported)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type mockFacade struct {
+	testing.Stub
+	reported chan time.Time
+}
+
+func (f *mockFacade) ReportInterruption(deadline time.Time) error {
+	f.MethodCall(f, "ReportInterruption", deadline)
+	if err := f.NextErr(); err != nil {
+		return err
+	}
+	f.reported <- deadline
+	return nil
+}
+
+// mockChecker returns its results in turn, and then reports that the
+// instance is not interrupted.
+type mockChecker struct {
+	mu      sync.Mutex
+	results []checkResult
+}
+
+type checkResult struct {
+	deadline    time.Time
+	interrupted bool
+	err         error
+}
+
+func (m *mockChecker) Check() (time.Time, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.results) == 0 {
+		return time.Time{}, false, nil
+	}
+	result := m.results[0]
+	m.results = m.results[1:]
+	return result.deadline, result.interrupted, result.err
+}
@@ -0,0 +1,220 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package lbmanager provides a worker that provisions provider load
+// balancers for a model's exposed applications, for providers that
+// support them. Each load balancer forwards the ports opened by the
+// application's units to the instances those units are running on, and
+// its address is recorded against the application for display in the
+// application's status.
+//
+// Load balancers are created once an exposed application has units
+// serving on open ports, updated as units come and go and ports are
+// opened and closed, and removed once the application is unexposed or
+// removed.
+package lbmanager
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/lbmanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.lbmanager")
+
+// Facade defines the interface we require from the load balancer
+// manager facade.
+type Facade interface {
+	WatchApplications() (watcher.StringsWatcher, error)
+	Applications() ([]lbmanager.Application, error)
+	SetAddress(names.ApplicationTag, string) error
+}
+
+// Config holds the configuration and dependencies for a load balancer
+// manager worker.
+type Config struct {
+	Facade   Facade
+	Provider environs.LoadBalancerProvider
+	Clock    clock.Clock
+
+	// Interval is how often applications are checked for changes to
+	// their exposure, open ports and units. Changes to the
+	// applications' life cycles are handled as they happen.
+	Interval time.Duration
+}
+
+// Validate returns an error if the config cannot be expected to
+// drive a functional load balancer manager.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Provider == nil {
+		return errors.NotValidf("nil Provider")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that keeps the provider's load balancers
+// for the model's exposed applications up to date.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &manager{
+		config:  config,
+		ensured: make(map[string]string),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type manager struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	// ensured holds the ports and backends each application's load
+	// balancer was last ensured with, keyed on application name.
+	ensured map[string]string
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *manager) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *manager) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *manager) loop() error {
+	applicationsWatcher, err := w.config.Facade.WatchApplications()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(applicationsWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	timer := w.config.Clock.NewTimer(w.config.Interval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-applicationsWatcher.Changes():
+			if !ok {
+				return errors.New("applications watcher closed")
+			}
+		case <-timer.Chan():
+			timer.Reset(w.config.Interval)
+		}
+		if err := w.update(); err != nil {
+			return errors.Annotate(err, "updating load balancers")
+		}
+	}
+}
+
+func (w *manager) update() error {
+	apps, err := w.config.Facade.Applications()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	seen := make(map[string]bool)
+	for _, app := range apps {
+		seen[app.Tag.Id()] = true
+		// A failure for one application, perhaps because the
+		// provider rejects its load balancer, shouldn't stop the
+		// others from being updated.
+		if err := w.updateApplication(app); err != nil {
+			logger.Errorf("cannot update load balancer for application %q: %v", app.Tag.Id(), err)
+		}
+	}
+	// Applications that have been removed no longer appear, but their
+	// load balancers must still be removed.
+	for name := range w.ensured {
+		if seen[name] {
+			continue
+		}
+		if err := w.config.Provider.RemoveLoadBalancer(name); err != nil {
+			logger.Errorf("cannot remove load balancer for application %q: %v", name, err)
+			continue
+		}
+		delete(w.ensured, name)
+		logger.Infof("removed load balancer for application %q", name)
+	}
+	return nil
+}
+
+func (w *manager) updateApplication(app lbmanager.Application) error {
+	name := app.Tag.Id()
+	wanted := app.Life == params.Alive && app.Exposed && len(app.Ports) > 0 && len(app.Backends) > 0
+	if !wanted {
+		if _, ok := w.ensured[name]; !ok && app.Address == "" {
+			return nil
+		}
+		if err := w.config.Provider.RemoveLoadBalancer(name); err != nil {
+			return errors.Trace(err)
+		}
+		delete(w.ensured, name)
+		logger.Infof("removed load balancer for application %q", name)
+		if app.Address == "" {
+			return nil
+		}
+		return errors.Trace(w.config.Facade.SetAddress(app.Tag, ""))
+	}
+	key := loadBalancerKey(app)
+	if app.Address != "" && w.ensured[name] == key {
+		return nil
+	}
+	addr, err := w.config.Provider.EnsureLoadBalancer(name, app.Ports, app.Backends)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.ensured[name] = key
+	logger.Infof("ensured load balancer %q for application %q (%s)", addr.Value, name, key)
+	if addr.Value == app.Address {
+		return nil
+	}
+	return errors.Trace(w.config.Facade.SetAddress(app.Tag, addr.Value))
+}
+
+// loadBalancerKey returns a string identifying the application's ports
+// and backends, regardless of their order.
+func loadBalancerKey(app lbmanager.Application) string {
+	ports := make([]string, len(app.Ports))
+	for i, pr := range app.Ports {
+		ports[i] = pr.String()
+	}
+	sort.Strings(ports)
+	backends := make([]string, len(app.Backends))
+	for i, id := range app.Backends {
+		backends[i] = string(id)
+	}
+	sort.Strings(backends)
+	return strings.Join(ports, ",") + " -> " + strings.Join(backends, ",")
+}
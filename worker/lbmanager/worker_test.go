@@ -0,0 +1,296 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lbmanager_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/lbmanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/watcher/watchertest"
+	workerlbmanager "github.com/juju/juju/worker/lbmanager"
+	"github.com/juju/juju/worker/workertest"
+)
+
+var httpPorts = []network.PortRange{{FromPort: 80, ToPort: 80, Protocol: "tcp"}}
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+
+	clock    *testing.Clock
+	changes  chan []string
+	facade   *mockFacade
+	provider *mockProvider
+	config   workerlbmanager.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Time{})
+	s.changes = make(chan []string, 1)
+	s.facade = &mockFacade{
+		watcher: watchertest.NewMockStringsWatcher(s.changes),
+		applications: []lbmanager.Application{{
+			Tag:      names.NewApplicationTag("wordpress"),
+			Life:     params.Alive,
+			Exposed:  true,
+			Ports:    httpPorts,
+			Backends: []instance.Id{"i-0", "i-1"},
+		}, {
+			Tag:      names.NewApplicationTag("mysql"),
+			Life:     params.Alive,
+			Ports:    []network.PortRange{{FromPort: 3306, ToPort: 3306, Protocol: "tcp"}},
+			Backends: []instance.Id{"i-2"},
+		}},
+		set: make(chan struct{}, 10),
+	}
+	s.provider = &mockProvider{}
+	s.config = workerlbmanager.Config{
+		Facade:   s.facade,
+		Provider: s.provider,
+		Clock:    s.clock,
+		Interval: time.Minute,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	s.testValidate(c, func(cfg *workerlbmanager.Config) {
+		cfg.Facade = nil
+	}, "nil Facade not valid")
+	s.testValidate(c, func(cfg *workerlbmanager.Config) {
+		cfg.Provider = nil
+	}, "nil Provider not valid")
+	s.testValidate(c, func(cfg *workerlbmanager.Config) {
+		cfg.Clock = nil
+	}, "nil Clock not valid")
+	s.testValidate(c, func(cfg *workerlbmanager.Config) {
+		cfg.Interval = 0
+	}, "non-positive Interval not valid")
+}
+
+func (s *WorkerSuite) testValidate(c *gc.C, f func(*workerlbmanager.Config), expect string) {
+	config := s.config
+	f(&config)
+	w, err := workerlbmanager.NewWorker(config)
+	if !c.Check(err, gc.NotNil) {
+		workertest.DirtyKill(c, w)
+		return
+	}
+	c.Check(w, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, expect)
+}
+
+func (s *WorkerSuite) TestEnsuresExposedApplications(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.changes <- []string{"wordpress", "mysql"}
+	s.waitSet(c)
+	s.provider.CheckCalls(c, []testing.StubCall{{
+		"EnsureLoadBalancer", []interface{}{"wordpress", httpPorts, []instance.Id{"i-0", "i-1"}},
+	}})
+	s.facade.CheckCall(c, 2, "SetAddress", names.NewApplicationTag("wordpress"), "wordpress-lb.example.com")
+}
+
+func (s *WorkerSuite) TestUpdatesBackends(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.changes <- []string{"wordpress"}
+	s.waitSet(c)
+	s.facade.setApplication("wordpress", func(app *lbmanager.Application) {
+		app.Address = "wordpress-lb.example.com"
+	})
+
+	// Nothing has changed, so the load balancer isn't ensured again.
+	s.advance(c)
+	s.waitApplications(c, 2)
+	s.provider.CheckCallNames(c, "EnsureLoadBalancer")
+
+	// A unit has gone away, so the load balancer is updated.
+	s.facade.setApplication("wordpress", func(app *lbmanager.Application) {
+		app.Backends = []instance.Id{"i-1"}
+	})
+	s.advance(c)
+	s.waitApplications(c, 3)
+	s.provider.CheckCallNames(c, "EnsureLoadBalancer", "EnsureLoadBalancer")
+	s.provider.CheckCall(c, 1, "EnsureLoadBalancer", "wordpress", httpPorts, []instance.Id{"i-1"})
+	// The address didn't change, so it isn't recorded again.
+	s.facade.CheckCallNames(c,
+		"WatchApplications", "Applications", "SetAddress", "Applications", "Applications",
+	)
+}
+
+func (s *WorkerSuite) TestRemovesUnexposedApplications(c *gc.C) {
+	s.facade.applications[0].Exposed = false
+	s.facade.applications[0].Address = "wordpress-lb.example.com"
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.changes <- []string{"wordpress"}
+	s.waitSet(c)
+	s.provider.CheckCalls(c, []testing.StubCall{{
+		"RemoveLoadBalancer", []interface{}{"wordpress"},
+	}})
+	s.facade.CheckCall(c, 2, "SetAddress", names.NewApplicationTag("wordpress"), "")
+}
+
+func (s *WorkerSuite) TestRemovesRemovedApplications(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.changes <- []string{"wordpress"}
+	s.waitSet(c)
+	s.facade.setApplication("wordpress", nil)
+
+	s.changes <- []string{"wordpress"}
+	s.waitApplications(c, 2)
+	s.provider.CheckCallNames(c, "EnsureLoadBalancer", "RemoveLoadBalancer")
+	s.provider.CheckCall(c, 1, "RemoveLoadBalancer", "wordpress")
+}
+
+func (s *WorkerSuite) TestProviderErrorNotFatal(c *gc.C) {
+	s.facade.applications[1].Exposed = true
+	s.provider.SetErrors(errors.New("quota exceeded"))
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.changes <- []string{"wordpress", "mysql"}
+	s.waitSet(c)
+	s.provider.CheckCallNames(c, "EnsureLoadBalancer", "EnsureLoadBalancer")
+	s.facade.CheckCall(c, 2, "SetAddress", names.NewApplicationTag("mysql"), "mysql-lb.example.com")
+}
+
+func (s *WorkerSuite) TestApplicationsError(c *gc.C) {
+	s.facade.SetErrors(nil, errors.New("kaboom"))
+	w := s.startWorker(c)
+	defer workertest.DirtyKill(c, w)
+
+	s.changes <- []string{"wordpress"}
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "updating load balancers: kaboom")
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := workerlbmanager.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *WorkerSuite) advance(c *gc.C) {
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *WorkerSuite) waitSet(c *gc.C) {
+	select {
+	case <-s.facade.set:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for address to be set")
+	}
+}
+
+func (s *WorkerSuite) waitApplications(c *gc.C, n int) {
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if s.facade.applicationsCalls() >= n {
+			// Give the worker time to finish with the applications.
+			time.Sleep(coretesting.ShortWait)
+			return
+		}
+	}
+	c.Fatalf("timed out waiting for %d Applications calls", n)
+}
+
+type mockFacade struct {
+	testing.Stub
+
+	mu           sync.Mutex
+	watcher      watcher.StringsWatcher
+	applications []lbmanager.Application
+	set          chan struct{}
+}
+
+func (f *mockFacade) WatchApplications() (watcher.StringsWatcher, error) {
+	f.MethodCall(f, "WatchApplications")
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.watcher, nil
+}
+
+func (f *mockFacade) Applications() ([]lbmanager.Application, error) {
+	f.MethodCall(f, "Applications")
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	apps := make([]lbmanager.Application, len(f.applications))
+	copy(apps, f.applications)
+	return apps, nil
+}
+
+func (f *mockFacade) SetAddress(tag names.ApplicationTag, addr string) error {
+	f.MethodCall(f, "SetAddress", tag, addr)
+	f.set <- struct{}{}
+	return f.NextErr()
+}
+
+func (f *mockFacade) applicationsCalls() int {
+	n := 0
+	for _, call := range f.Calls() {
+		if call.FuncName == "Applications" {
+			n++
+		}
+	}
+	return n
+}
+
+// setApplication updates the named application with the given
+// function, or removes it if the function is nil.
+func (f *mockFacade) setApplication(name string, update func(*lbmanager.Application)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.applications {
+		if f.applications[i].Tag.Id() != name {
+			continue
+		}
+		if update == nil {
+			f.applications = append(f.applications[:i], f.applications[i+1:]...)
+			return
+		}
+		update(&f.applications[i])
+	}
+}
+
+type mockProvider struct {
+	testing.Stub
+}
+
+func (p *mockProvider) EnsureLoadBalancer(appName string, ports []network.PortRange, backends []instance.Id) (network.Address, error) {
+	p.MethodCall(p, "EnsureLoadBalancer", appName, ports, backends)
+	if err := p.NextErr(); err != nil {
+		return network.Address{}, err
+	}
+	return network.NewAddress(appName + "-lb.example.com"), nil
+}
+
+func (p *mockProvider) RemoveLoadBalancer(appName string) error {
+	p.MethodCall(p, "RemoveLoadBalancer", appName)
+	return p.NextErr()
+}
@@ -4,12 +4,16 @@
 package logger
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
 	"gopkg.in/juju/names.v2"
 	worker "gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/catacomb"
 )
 
 var log = loggo.GetLogger("juju.worker.logger")
@@ -17,42 +21,89 @@ var log = loggo.GetLogger("juju.worker.logger")
 // LoggerAPI represents the API calls the logger makes.
 type LoggerAPI interface {
 	LoggingConfig(agentTag names.Tag) (string, error)
+	LoggingOverride(agentTag names.Tag) (string, time.Time, error)
 	WatchLoggingConfig(agentTag names.Tag) (watcher.NotifyWatcher, error)
 }
 
 // Logger is responsible for updating the loggo configuration when the
-// environment watcher tells the agent that the value has changed.
+// environment watcher tells the agent that the value has changed, and
+// for applying and expiring any logging override set for the agent.
 type Logger struct {
+	catacomb       catacomb.Catacomb
 	api            LoggerAPI
 	tag            names.Tag
+	clock          clock.Clock
 	updateCallback func(string) error
 	lastConfig     string
 	configOverride string
 }
 
-// NewLogger returns a worker.Worker that uses the notify watcher returned
-// from the setup.
-func NewLogger(api LoggerAPI, tag names.Tag, loggingOverride string, updateCallback func(string) error) (worker.Worker, error) {
+// NewLogger returns a worker.Worker that updates the logging config
+// whenever the watcher returned by the API notifies of a change, or
+// whenever the agent's logging override expires.
+func NewLogger(api LoggerAPI, tag names.Tag, loggingOverride string, updateCallback func(string) error, clock clock.Clock) (worker.Worker, error) {
 	logger := &Logger{
 		api:            api,
 		tag:            tag,
+		clock:          clock,
 		updateCallback: updateCallback,
 		lastConfig:     loggo.LoggerInfo(),
 		configOverride: loggingOverride,
 	}
 	log.Debugf("initial log config: %q", logger.lastConfig)
 
-	w, err := watcher.NewNotifyWorker(watcher.NotifyConfig{
-		Handler: logger,
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &logger.catacomb,
+		Work: logger.loop,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return w, nil
+	return logger, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (logger *Logger) Kill() {
+	logger.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (logger *Logger) Wait() error {
+	return logger.catacomb.Wait()
+}
+
+func (logger *Logger) loop() error {
+	// Set the logging config before waiting for the watcher, so that
+	// the agent's logging is configured as early as possible.
+	expiry := logger.setLogging()
+	w, err := logger.api.WatchLoggingConfig(logger.tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := logger.catacomb.Add(w); err != nil {
+		return errors.Trace(err)
+	}
+	for {
+		select {
+		case <-logger.catacomb.Dying():
+			return logger.catacomb.ErrDying()
+		case _, ok := <-w.Changes():
+			if !ok {
+				return errors.New("logging config watcher closed")
+			}
+		case <-expiry:
+			log.Debugf("logging override expired")
+		}
+		expiry = logger.setLogging()
+	}
 }
 
-func (logger *Logger) setLogging() {
+// setLogging applies the current logging config, and returns a channel
+// that will be signalled when the agent's logging override expires, or
+// nil if there is no override in effect.
+func (logger *Logger) setLogging() <-chan time.Time {
 	loggingConfig := ""
+	var expiry <-chan time.Time
 
 	if logger.configOverride != "" {
 		log.Debugf("overriding logging config with override from agent.conf %q", logger.configOverride)
@@ -61,9 +112,20 @@ func (logger *Logger) setLogging() {
 		modelLoggingConfig, err := logger.api.LoggingConfig(logger.tag)
 		if err != nil {
 			log.Errorf("%v", err)
-			return
+			return nil
 		}
 		loggingConfig = modelLoggingConfig
+
+		agentOverride, expires, err := logger.api.LoggingOverride(logger.tag)
+		if err != nil {
+			log.Errorf("%v", err)
+		} else if agentOverride != "" {
+			if remaining := expires.Sub(logger.clock.Now()); remaining > 0 {
+				log.Debugf("applying logging override %q until %v", agentOverride, expires)
+				loggingConfig += ";" + agentOverride
+				expiry = logger.clock.After(remaining)
+			}
+		}
 	}
 
 	if loggingConfig != logger.lastConfig {
@@ -75,7 +137,7 @@ func (logger *Logger) setLogging() {
 			log.Warningf("configure loggers failed: %v", err)
 			// Try to reset to what we had before
 			loggo.ConfigureLoggers(logger.lastConfig)
-			return
+			return expiry
 		}
 		logger.lastConfig = loggingConfig
 		// Save the logging config in the agent.conf file.
@@ -86,22 +148,5 @@ func (logger *Logger) setLogging() {
 			}
 		}
 	}
-}
-
-func (logger *Logger) SetUp() (watcher.NotifyWatcher, error) {
-	log.Debugf("logger setup")
-	// We need to set this up initially as the NotifyWorker sucks up the first
-	// event.
-	logger.setLogging()
-	return logger.api.WatchLoggingConfig(logger.tag)
-}
-
-func (logger *Logger) Handle(_ <-chan struct{}) error {
-	logger.setLogging()
-	return nil
-}
-
-func (logger *Logger) TearDown() error {
-	// Nothing to cleanup, only state is the watcher
-	return nil
+	return expiry
 }
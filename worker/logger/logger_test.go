@@ -28,6 +28,7 @@ type LoggerSuite struct {
 
 	loggerAPI *mockAPI
 	agent     names.Tag
+	clock     *testing.Clock
 
 	value    string
 	override string
@@ -43,6 +44,7 @@ func (s *LoggerSuite) SetUpTest(c *gc.C) {
 		watcher: &mockNotifyWatcher{},
 	}
 	s.agent = names.NewMachineTag("42")
+	s.clock = testing.NewClock(time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC))
 	s.value = ""
 	s.override = ""
 }
@@ -68,7 +70,7 @@ func (s *LoggerSuite) makeLogger(c *gc.C) worker.Worker {
 	w, err := logger.NewLogger(s.loggerAPI, s.agent, s.override, func(v string) error {
 		s.value = v
 		return nil
-	})
+	}, s.clock)
 	c.Assert(err, jc.ErrorIsNil)
 	return w
 }
@@ -113,6 +115,42 @@ func (s *LoggerSuite) TestConfigOverride(c *gc.C) {
 	s.waitLoggingInfo(c, expected)
 }
 
+func (s *LoggerSuite) TestAgentLoggingOverride(c *gc.C) {
+	s.loggerAPI.config = "<root>=WARNING"
+	s.loggerAPI.override = "test=TRACE"
+	s.loggerAPI.expires = s.clock.Now().Add(time.Hour)
+
+	loggingWorker := s.makeLogger(c)
+	defer worker.Stop(loggingWorker)
+	s.waitLoggingInfo(c, "<root>=WARNING;test=TRACE")
+
+	// Once the override expires, only the model config applies.
+	err := s.clock.WaitAdvance(time.Hour, worstCase, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitLoggingInfo(c, "<root>=WARNING")
+}
+
+func (s *LoggerSuite) TestExpiredAgentLoggingOverride(c *gc.C) {
+	s.loggerAPI.config = "<root>=WARNING"
+	s.loggerAPI.override = "test=TRACE"
+	s.loggerAPI.expires = s.clock.Now().Add(-time.Second)
+
+	loggingWorker := s.makeLogger(c)
+	defer worker.Stop(loggingWorker)
+	s.waitLoggingInfo(c, "<root>=WARNING")
+}
+
+func (s *LoggerSuite) TestAgentConfOverrideTakesPrecedence(c *gc.C) {
+	s.override = "test=INFO"
+	s.loggerAPI.override = "test=TRACE"
+	s.loggerAPI.expires = s.clock.Now().Add(time.Hour)
+
+	loggo.DefaultContext().ResetLoggerLevels()
+	loggingWorker := s.makeLogger(c)
+	defer worker.Stop(loggingWorker)
+	s.waitLoggingInfo(c, "<root>=WARNING;test=INFO")
+}
+
 type mockNotifyWatcher struct {
 	changes chan struct{}
 }
@@ -130,8 +168,10 @@ func (m *mockNotifyWatcher) Changes() watcher.NotifyChannel {
 var _ watcher.NotifyWatcher = (*mockNotifyWatcher)(nil)
 
 type mockAPI struct {
-	watcher *mockNotifyWatcher
-	config  string
+	watcher  *mockNotifyWatcher
+	config   string
+	override string
+	expires  time.Time
 
 	loggingTag  names.Tag
 	watchingTag names.Tag
@@ -142,6 +182,10 @@ func (m *mockAPI) LoggingConfig(agentTag names.Tag) (string, error) {
 	return m.config, nil
 }
 
+func (m *mockAPI) LoggingOverride(agentTag names.Tag) (string, time.Time, error) {
+	return m.override, m.expires, nil
+}
+
 func (m *mockAPI) WatchLoggingConfig(agentTag names.Tag) (watcher.NotifyWatcher, error) {
 	m.watchingTag = agentTag
 	return m.watcher, nil
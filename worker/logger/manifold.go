@@ -4,6 +4,8 @@
 package logger
 
 import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 	worker "gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/agent"
@@ -18,6 +20,7 @@ type ManifoldConfig struct {
 	AgentName       string
 	APICallerName   string
 	UpdateAgentFunc func(string) error
+	Clock           clock.Clock
 }
 
 // Manifold returns a dependency manifold that runs a logger
@@ -29,6 +32,9 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 			config.APICallerName,
 		},
 		Start: func(context dependency.Context) (worker.Worker, error) {
+			if config.Clock == nil {
+				return nil, errors.NotValidf("missing Clock")
+			}
 			var a agent.Agent
 			if err := context.Get(config.AgentName, &a); err != nil {
 				return nil, err
@@ -42,7 +48,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 			}
 
 			loggerFacade := logger.NewState(apiCaller)
-			return NewLogger(loggerFacade, currentConfig.Tag(), loggingOverride, config.UpdateAgentFunc)
+			return NewLogger(loggerFacade, currentConfig.Tag(), loggingOverride, config.UpdateAgentFunc, config.Clock)
 		},
 	}
 }
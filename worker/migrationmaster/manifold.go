@@ -4,6 +4,8 @@
 package migrationmaster
 
 import (
+	"strconv"
+
 	"github.com/juju/errors"
 	"github.com/juju/utils/clock"
 	worker "gopkg.in/juju/worker.v1"
@@ -69,6 +71,10 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 	if err := context.Get(config.FortressName, &guard); err != nil {
 		return nil, errors.Trace(err)
 	}
+	bandwidthLimit, err := bandwidthLimitFromAgentConfig(agent.CurrentConfig())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	facade, err := config.NewFacade(apiConn)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -83,6 +89,7 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		CharmDownloader: apiClient,
 		ToolsDownloader: apiClient,
 		Clock:           config.Clock,
+		BandwidthLimit:  bandwidthLimit,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -90,6 +97,21 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 	return worker, nil
 }
 
+// bandwidthLimitFromAgentConfig returns the binary transfer bandwidth
+// limit, in bytes per second, configured for the agent. Zero means no
+// limit.
+func bandwidthLimitFromAgentConfig(cfg agent.Config) (int64, error) {
+	v := cfg.Value(agent.MigrationBandwidthLimit)
+	if v == "" {
+		return 0, nil
+	}
+	limit, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || limit < 0 {
+		return 0, errors.Errorf("invalid %s %q", agent.MigrationBandwidthLimit, v)
+	}
+	return limit, nil
+}
+
 func errorFilter(err error) error {
 	switch errors.Cause(err) {
 	case ErrMigrated:
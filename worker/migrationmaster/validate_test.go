@@ -75,6 +75,12 @@ func (*ValidateSuite) TestMissingClock(c *gc.C) {
 	checkNotValid(c, config, "nil Clock not valid")
 }
 
+func (*ValidateSuite) TestNegativeBandwidthLimit(c *gc.C) {
+	config := validConfig()
+	config.BandwidthLimit = -1
+	checkNotValid(c, config, "negative BandwidthLimit not valid")
+}
+
 func validConfig() migrationmaster.Config {
 	return migrationmaster.Config{
 		ModelUUID:       "uuid",
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/clock"
@@ -30,6 +31,10 @@ import (
 	"github.com/juju/juju/wrench"
 )
 
+// progressReportInterval is the minimum time between status updates
+// while a binary is being transferred to the target controller.
+const progressReportInterval = 5 * time.Second
+
 var (
 	// ErrInactive is returned when the migration is no longer active
 	// (probably aborted). In this case the migrationmaster should be
@@ -109,6 +114,11 @@ type Facade interface {
 	// that need to be transferred to the target after the migration
 	// is successful.
 	StreamModelLog(time.Time) (<-chan common.LogMessage, error)
+
+	// SourceControllerInfo returns the details required to connect
+	// to the source controller, for handing the model back to it if
+	// the migration is rolled back.
+	SourceControllerInfo() (coremigration.SourceInfo, error)
 }
 
 // Config defines the operation of a Worker.
@@ -121,6 +131,11 @@ type Config struct {
 	CharmDownloader migration.CharmDownloader
 	ToolsDownloader migration.ToolsDownloader
 	Clock           clock.Clock
+
+	// BandwidthLimit is the maximum rate, in bytes per second, at
+	// which binaries are transferred to the target controller. Zero
+	// means no limit.
+	BandwidthLimit int64
 }
 
 // Validate returns an error if config cannot drive a Worker.
@@ -149,6 +164,9 @@ func (config Config) Validate() error {
 	if config.Clock == nil {
 		return errors.NotValidf("nil Clock")
 	}
+	if config.BandwidthLimit < 0 {
+		return errors.NotValidf("negative BandwidthLimit")
+	}
 	return nil
 }
 
@@ -186,6 +204,10 @@ type Worker struct {
 	config      Config
 	logger      loggo.Logger
 	lastFailure string
+
+	// lastProgress records when binary transfer progress was last
+	// reported, so that the status isn't updated for every chunk.
+	lastProgress time.Time
 }
 
 // Kill implements worker.Worker.
@@ -225,7 +247,11 @@ func (w *Worker) run() error {
 		case coremigration.SUCCESS:
 			phase, err = w.doSUCCESS(status)
 		case coremigration.LOGTRANSFER:
-			phase, err = w.doLOGTRANSFER(status.TargetInfo, status.ModelUUID)
+			phase, err = w.doLOGTRANSFER(status)
+		case coremigration.POSTVALIDATION:
+			phase, err = w.doPOSTVALIDATION(status)
+		case coremigration.ROLLBACK:
+			phase, err = w.doROLLBACK(status)
 		case coremigration.REAP:
 			phase, err = w.doREAP()
 		case coremigration.ABORT:
@@ -256,8 +282,8 @@ func (w *Worker) run() error {
 		if modelHasMigrated(phase) {
 			return ErrMigrated
 		} else if phase.IsTerminal() {
-			// Some other terminal phase (aborted), exit and try
-			// again.
+			// Some other terminal phase (aborted or rolled back),
+			// exit and try again.
 			return ErrInactive
 		}
 	}
@@ -428,10 +454,40 @@ func (w *Worker) transferModel(targetInfo coremigration.TargetInfo, modelUUID st
 		Resources:          serialized.Resources,
 		ResourceDownloader: w.config.Facade,
 		ResourceUploader:   wrapper,
+
+		BandwidthLimit: w.config.BandwidthLimit,
+		Progress:       w.reportTransferProgress,
 	})
 	return errors.Annotate(err, "failed to migrate binaries")
 }
 
+// reportTransferProgress updates the migration status message as
+// binaries are uploaded to the target controller. Updates are rate
+// limited except when a binary starts or has been completely
+// transferred.
+func (w *Worker) reportTransferProgress(progress migration.TransferProgress) {
+	now := w.config.Clock.Now()
+	if !shouldReportProgress(progress, now, w.lastProgress) {
+		return
+	}
+	w.lastProgress = now
+	w.setInfoStatus("uploading model binaries into target controller (%d of %d done, %s sent): %s %s",
+		progress.ItemsDone, progress.ItemsTotal,
+		humanize.Bytes(uint64(progress.TotalBytes)),
+		progress.Kind, progress.Name,
+	)
+}
+
+// shouldReportProgress returns whether the given transfer progress
+// should be reported, given when progress was last reported. The start
+// and completion of each binary are always reported.
+func shouldReportProgress(progress migration.TransferProgress, now, last time.Time) bool {
+	if progress.ItemBytes == 0 || progress.ItemComplete {
+		return true
+	}
+	return now.Sub(last) >= progressReportInterval
+}
+
 func (w *Worker) doVALIDATION(status coremigration.MigrationStatus) (coremigration.Phase, error) {
 	// Wait for agents to complete their validation checks.
 	ok, err := w.waitForMinions(status, failFast, "validating")
@@ -498,6 +554,11 @@ func (w *Worker) doSUCCESS(status coremigration.MigrationStatus) (coremigration.
 	if err != nil {
 		return coremigration.UNKNOWN, errors.Trace(err)
 	}
+	if status.Rollback {
+		// The source controller has already taken the cloud
+		// resources back.
+		return coremigration.LOGTRANSFER, nil
+	}
 	err = w.transferResources(status.TargetInfo, status.ModelUUID)
 	if err != nil {
 		return coremigration.UNKNOWN, errors.Trace(err)
@@ -521,11 +582,19 @@ func (w *Worker) transferResources(targetInfo coremigration.TargetInfo, modelUUI
 	return errors.Trace(err)
 }
 
-func (w *Worker) doLOGTRANSFER(targetInfo coremigration.TargetInfo, modelUUID string) (coremigration.Phase, error) {
-	err := w.transferLogs(targetInfo, modelUUID)
+func (w *Worker) doLOGTRANSFER(status coremigration.MigrationStatus) (coremigration.Phase, error) {
+	if status.Rollback {
+		// The source controller still has the logs from before the
+		// migration.
+		return coremigration.REAP, nil
+	}
+	err := w.transferLogs(status.TargetInfo, status.ModelUUID)
 	if err != nil {
 		return coremigration.UNKNOWN, errors.Trace(err)
 	}
+	if status.RollbackWindow > 0 {
+		return coremigration.POSTVALIDATION, nil
+	}
 	return coremigration.REAP, nil
 }
 
@@ -604,6 +673,103 @@ func (w *Worker) transferLogs(targetInfo coremigration.TargetInfo, modelUUID str
 	}
 }
 
+func (w *Worker) doPOSTVALIDATION(status coremigration.MigrationStatus) (coremigration.Phase, error) {
+	// Get the time the phase started from the controller, so that
+	// the rollback window survives restarts of this worker.
+	current, err := w.config.Facade.MigrationStatus()
+	if err != nil {
+		return coremigration.UNKNOWN, errors.Annotate(err, "retrieving migration status")
+	}
+	clk := w.config.Clock
+	remaining := current.PhaseChangedTime.Add(status.RollbackWindow).Sub(clk.Now())
+	w.setInfoStatus("successful, validating model in target controller (rollback possible for %s)",
+		truncDuration(remaining))
+	timeout := clk.After(remaining)
+	poll := clk.After(progressUpdateInterval)
+
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return coremigration.UNKNOWN, w.catacomb.ErrDying()
+
+		case <-poll:
+			// A rollback may have been requested by a user.
+			current, err := w.config.Facade.MigrationStatus()
+			if err != nil {
+				return coremigration.UNKNOWN, errors.Annotate(err, "retrieving migration status")
+			}
+			if current.Phase == coremigration.ROLLBACK {
+				w.lastFailure = "rollback requested"
+				return w.doROLLBACK(status)
+			}
+			problems, err := w.checkTargetAgents(status)
+			if err != nil {
+				return coremigration.UNKNOWN, errors.Trace(err)
+			}
+			w.setInfoStatus("successful, validating model in target controller (%d problem%s found)",
+				len(problems), plural(len(problems)))
+			poll = clk.After(progressUpdateInterval)
+
+		case <-timeout:
+			problems, err := w.checkTargetAgents(status)
+			if err != nil {
+				return coremigration.UNKNOWN, errors.Trace(err)
+			}
+			if len(problems) > 0 {
+				for _, problem := range problems {
+					w.logger.Errorf(problem.Error())
+				}
+				w.setErrorStatus("post-migration validation failed, %d problem%s found",
+					len(problems), plural(len(problems)))
+				return coremigration.ROLLBACK, nil
+			}
+			return coremigration.REAP, nil
+		}
+	}
+}
+
+func (w *Worker) checkTargetAgents(status coremigration.MigrationStatus) ([]error, error) {
+	client, closer, err := w.openTargetAPI(status.TargetInfo)
+	if err != nil {
+		return nil, errors.Annotate(err, "connecting to target API")
+	}
+	defer closer()
+
+	problems, err := client.CheckAgents(status.ModelUUID)
+	if errors.IsNotSupported(err) {
+		w.logger.Warningf("target controller can't check migrated agents, skipping validation")
+		return nil, nil
+	}
+	return problems, errors.Trace(err)
+}
+
+func (w *Worker) doROLLBACK(status coremigration.MigrationStatus) (coremigration.Phase, error) {
+	w.setInfoStatus("rolling back, returning model from target controller: %s", w.lastFailure)
+	source, err := w.config.Facade.SourceControllerInfo()
+	if err != nil {
+		return coremigration.UNKNOWN, errors.Annotate(err, "retrieving source controller info")
+	}
+	client, closer, err := w.openTargetAPI(status.TargetInfo)
+	if err != nil {
+		return coremigration.UNKNOWN, errors.Annotate(err, "connecting to target API")
+	}
+	defer closer()
+
+	// The target controller hands the model's agents back, and then
+	// removes the model.
+	if err := client.Rollback(status.ModelUUID, source); err != nil {
+		return coremigration.UNKNOWN, errors.Annotate(err, "rolling back model in target controller")
+	}
+	return coremigration.ROLLBACKDONE, nil
+}
+
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
+
 func (w *Worker) doREAP() (coremigration.Phase, error) {
 	w.setInfoStatus("successful, removing model from source controller")
 	// NOTE(babbageclunk): Calling Reap will set the migration phase
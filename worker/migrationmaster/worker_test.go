@@ -52,6 +52,7 @@ var _ = gc.Suite(&Suite{})
 var (
 	fakeModelBytes      = []byte("model")
 	targetControllerTag = names.NewControllerTag("controller-uuid")
+	sourceControllerTag = names.NewControllerTag("source-controller-uuid")
 	modelUUID           = "model-uuid"
 	modelTag            = names.NewModelTag(modelUUID)
 	modelName           = "model-name"
@@ -117,6 +118,25 @@ var (
 			params.ModelArgs{ModelTag: modelTag.String()},
 		},
 	}
+	checkAgentsCall = jujutesting.StubCall{
+		"MigrationTarget.CheckAgents",
+		[]interface{}{
+			params.ModelArgs{ModelTag: modelTag.String()},
+		},
+	}
+	rollbackCall = jujutesting.StubCall{
+		"MigrationTarget.Rollback",
+		[]interface{}{
+			params.RollbackModelArgs{
+				ModelTag: modelTag.String(),
+				SourceInfo: params.MigrationSourceInfo{
+					ControllerTag: sourceControllerTag.String(),
+					Addrs:         []string{"5.6.7.8:9"},
+					CACert:        "source-cert",
+				},
+			},
+		},
+	}
 	apiCloseCall = jujutesting.StubCall{"Connection.Close", nil}
 	abortCall    = jujutesting.StubCall{
 		"MigrationTarget.Abort",
@@ -726,6 +746,150 @@ func (s *Suite) TestSUCCESSMinionWaitTimeout(c *gc.C) {
 	))
 }
 
+func (s *Suite) TestSUCCESSRollback(c *gc.C) {
+	// When the migration hands the model back to the controller it
+	// came from, nothing is transferred.
+	status := s.makeStatus(coremigration.SUCCESS)
+	status.Rollback = true
+	s.facade.queueStatus(status)
+	s.facade.queueMinionReports(makeMinionReports(coremigration.SUCCESS))
+
+	s.checkWorkerReturns(c, migrationmaster.ErrMigrated)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.WatchMinionReports", nil},
+			{"facade.MinionReports", nil},
+			{"facade.SetPhase", []interface{}{coremigration.LOGTRANSFER}},
+			{"facade.SetPhase", []interface{}{coremigration.REAP}},
+			{"facade.Reap", nil},
+			{"facade.SetPhase", []interface{}{coremigration.DONE}},
+		},
+	))
+}
+
+func (s *Suite) TestLOGTRANSFERWithRollbackWindow(c *gc.C) {
+	status := s.makeStatus(coremigration.LOGTRANSFER)
+	status.RollbackWindow = 10 * time.Second
+	s.facade.queueStatus(status)
+	postValidation := s.makeStatus(coremigration.POSTVALIDATION)
+	s.facade.status = append(s.facade.status, postValidation)
+
+	worker, err := migrationmaster.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, worker)
+
+	// One alarm for log transfer progress, and two for validation.
+	s.waitForAlarms(c, 3)
+	s.clock.Advance(10 * time.Second)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.Equals, migrationmaster.ErrMigrated)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			apiOpenControllerCall,
+			latestLogTimeCall,
+			{"StreamModelLog", []interface{}{time.Time{}}},
+			openDestLogStreamCall,
+			{"facade.SetPhase", []interface{}{coremigration.POSTVALIDATION}},
+			{"facade.MigrationStatus", nil},
+			apiOpenControllerCall,
+			checkAgentsCall,
+			apiCloseCall,
+			{"facade.SetPhase", []interface{}{coremigration.REAP}},
+			{"facade.Reap", nil},
+			{"facade.SetPhase", []interface{}{coremigration.DONE}},
+		},
+	))
+}
+
+func (s *Suite) TestPOSTVALIDATIONFailure(c *gc.C) {
+	s.connection.agentErrs = []string{"machine 0 agent is not connected"}
+	status := s.makeStatus(coremigration.POSTVALIDATION)
+	status.RollbackWindow = 10 * time.Second
+	s.facade.queueStatus(status)
+	s.facade.status = append(s.facade.status, status)
+
+	worker, err := migrationmaster.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, worker)
+
+	s.waitForAlarms(c, 2)
+	s.clock.Advance(10 * time.Second)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.Equals, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.MigrationStatus", nil},
+			apiOpenControllerCall,
+			checkAgentsCall,
+			apiCloseCall,
+			{"facade.SetPhase", []interface{}{coremigration.ROLLBACK}},
+			{"facade.SourceControllerInfo", nil},
+			apiOpenControllerCall,
+			rollbackCall,
+			apiCloseCall,
+			{"facade.SetPhase", []interface{}{coremigration.ROLLBACKDONE}},
+		},
+	))
+	c.Check(s.facade.statuses, jc.Contains, "post-migration validation failed, 1 problem found")
+}
+
+func (s *Suite) TestPOSTVALIDATIONRollbackRequested(c *gc.C) {
+	status := s.makeStatus(coremigration.POSTVALIDATION)
+	status.RollbackWindow = time.Hour
+	s.facade.queueStatus(status)
+	s.facade.status = append(s.facade.status, status, s.makeStatus(coremigration.ROLLBACK))
+
+	worker, err := migrationmaster.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, worker)
+
+	s.waitForAlarms(c, 2)
+	s.clock.Advance(30 * time.Second)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.Equals, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.MigrationStatus", nil},
+			{"facade.MigrationStatus", nil},
+			{"facade.SourceControllerInfo", nil},
+			apiOpenControllerCall,
+			rollbackCall,
+			apiCloseCall,
+			{"facade.SetPhase", []interface{}{coremigration.ROLLBACKDONE}},
+		},
+	))
+}
+
+func (s *Suite) TestROLLBACKResume(c *gc.C) {
+	s.facade.queueStatus(s.makeStatus(coremigration.ROLLBACK))
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.SourceControllerInfo", nil},
+			apiOpenControllerCall,
+			rollbackCall,
+			apiCloseCall,
+			{"facade.SetPhase", []interface{}{coremigration.ROLLBACKDONE}},
+		},
+	))
+}
+
+func (s *Suite) TestROLLBACKError(c *gc.C) {
+	s.connection.rollbackErr = errors.New("boom")
+	s.facade.queueStatus(s.makeStatus(coremigration.ROLLBACK))
+
+	s.checkWorkerErr(c, "rolling back model in target controller: boom")
+}
+
 func (s *Suite) TestMinionWaitWrongPhase(c *gc.C) {
 	s.facade.queueStatus(s.makeStatus(coremigration.SUCCESS))
 
@@ -970,6 +1134,20 @@ func (s *Suite) TestLogTransfer_ChecksLatestTime(c *gc.C) {
 	))
 }
 
+func (s *Suite) TestShouldReportProgress(c *gc.C) {
+	last := time.Date(2017, 3, 1, 9, 0, 0, 0, time.UTC)
+	soon := last.Add(time.Second)
+	later := last.Add(time.Minute)
+	partial := migration.TransferProgress{ItemBytes: 10}
+	complete := migration.TransferProgress{ItemBytes: 10, ItemsDone: 1, ItemComplete: true}
+
+	c.Check(migrationmaster.ShouldReportProgress(migration.TransferProgress{}, soon, last), jc.IsTrue)
+	c.Check(migrationmaster.ShouldReportProgress(partial, soon, last), jc.IsFalse)
+	c.Check(migrationmaster.ShouldReportProgress(partial, later, last), jc.IsTrue)
+	// The final update for a binary is never throttled.
+	c.Check(migrationmaster.ShouldReportProgress(complete, soon, last), jc.IsTrue)
+}
+
 func safeSend(c *gc.C, d chan<- common.LogMessage, message common.LogMessage) {
 	select {
 	case d <- message:
@@ -995,6 +1173,16 @@ func (s *Suite) runWorker(c *gc.C) error {
 	return workertest.CheckKilled(c, w)
 }
 
+func (s *Suite) waitForAlarms(c *gc.C, count int) {
+	for i := 0; i < count; i++ {
+		select {
+		case <-s.clock.Alarms():
+		case <-time.After(coretesting.LongWait):
+			c.Fatal("timed out waiting for clock.After call")
+		}
+	}
+}
+
 func (s *Suite) waitForStubCalls(c *gc.C, expectedCallNames []string) {
 	var callNames []string
 	for a := coretesting.LongAttempt.Start(); a.Next(); {
@@ -1206,6 +1394,15 @@ func (f *stubMasterFacade) Reap() error {
 	return nil
 }
 
+func (f *stubMasterFacade) SourceControllerInfo() (coremigration.SourceInfo, error) {
+	f.stub.AddCall("facade.SourceControllerInfo")
+	return coremigration.SourceInfo{
+		ControllerTag: sourceControllerTag,
+		Addrs:         []string{"5.6.7.8:9"},
+		CACert:        "source-cert",
+	}, nil
+}
+
 func (f *stubMasterFacade) StreamModelLog(start time.Time) (<-chan common.LogMessage, error) {
 	f.stub.AddCall("StreamModelLog", start)
 	if f.streamErr != nil {
@@ -1254,10 +1451,13 @@ type stubConnection struct {
 
 	machineErrs     []string
 	checkMachineErr error
+
+	agentErrs   []string
+	rollbackErr error
 }
 
 func (c *stubConnection) BestFacadeVersion(string) int {
-	return 1
+	return 2
 }
 
 func (c *stubConnection) APICall(objType string, version int, id, request string, args, response interface{}) error {
@@ -1285,6 +1485,16 @@ func (c *stubConnection) APICall(objType string, version int, id, request string
 				})
 			}
 			return c.checkMachineErr
+		case "CheckAgents":
+			results := response.(*params.ErrorResults)
+			for _, msg := range c.agentErrs {
+				results.Results = append(results.Results, params.ErrorResult{
+					Error: servercommon.ServerError(errors.Errorf(msg)),
+				})
+			}
+			return nil
+		case "Rollback":
+			return c.rollbackErr
 		}
 	}
 	return errors.New("unexpected API call")
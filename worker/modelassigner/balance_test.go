@@ -0,0 +1,94 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelassigner_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/modelassigner"
+)
+
+type BalanceSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&BalanceSuite{})
+
+func (s *BalanceSuite) TestNoControllers(c *gc.C) {
+	assignments := modelassigner.Balance([]string{"a", "b"}, nil, map[string]string{"a": "0"})
+	c.Assert(assignments, gc.HasLen, 0)
+}
+
+func (s *BalanceSuite) TestSpreadsEvenly(c *gc.C) {
+	assignments := modelassigner.Balance(
+		[]string{"e", "d", "c", "b", "a"},
+		[]string{"2", "0", "1"},
+		nil,
+	)
+	c.Assert(assignments, jc.DeepEquals, map[string]string{
+		"a": "0",
+		"b": "1",
+		"c": "2",
+		"d": "0",
+		"e": "1",
+	})
+}
+
+func (s *BalanceSuite) TestKeepsAssignments(c *gc.C) {
+	current := map[string]string{
+		"a": "1",
+		"b": "1",
+		"c": "0",
+	}
+	assignments := modelassigner.Balance([]string{"a", "b", "c", "d"}, []string{"0", "1"}, current)
+	c.Assert(assignments, jc.DeepEquals, map[string]string{
+		"a": "1",
+		"b": "1",
+		"c": "0",
+		"d": "0",
+	})
+}
+
+func (s *BalanceSuite) TestMovesFromUnavailable(c *gc.C) {
+	current := map[string]string{
+		"a": "0",
+		"b": "1",
+		"c": "2",
+		"d": "2",
+	}
+	assignments := modelassigner.Balance([]string{"a", "b", "c", "d"}, []string{"0", "1"}, current)
+	c.Assert(assignments, jc.DeepEquals, map[string]string{
+		"a": "0",
+		"b": "1",
+		"c": "0",
+		"d": "1",
+	})
+}
+
+func (s *BalanceSuite) TestMovesToNewController(c *gc.C) {
+	current := map[string]string{
+		"a": "0",
+		"b": "0",
+		"c": "0",
+		"d": "1",
+	}
+	assignments := modelassigner.Balance([]string{"a", "b", "c", "d"}, []string{"0", "1", "2"}, current)
+	c.Assert(assignments, jc.DeepEquals, map[string]string{
+		"a": "0",
+		"b": "0",
+		"c": "2",
+		"d": "1",
+	})
+}
+
+func (s *BalanceSuite) TestDropsRemovedModels(c *gc.C) {
+	current := map[string]string{
+		"a":    "0",
+		"gone": "1",
+	}
+	assignments := modelassigner.Balance([]string{"a"}, []string{"0", "1"}, current)
+	c.Assert(assignments, jc.DeepEquals, map[string]string{"a": "0"})
+}
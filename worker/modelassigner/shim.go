@@ -0,0 +1,42 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelassigner
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/state"
+)
+
+// StateBackend implements Backend in terms of a *state.State.
+type StateBackend struct {
+	*state.State
+}
+
+// ControllerMachineIds is part of the Backend interface.
+func (b StateBackend) ControllerMachineIds() ([]string, error) {
+	info, err := b.State.ControllerInfo()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return info.MachineIds, nil
+}
+
+// MachineAvailable is part of the Backend interface.
+func (b StateBackend) MachineAvailable(id string) (bool, error) {
+	m, err := b.State.Machine(id)
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	if m.Life() != state.Alive {
+		return false, nil
+	}
+	present, err := m.AgentPresence()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return present, nil
+}
@@ -0,0 +1,241 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelassigner provides a worker, run by the primary
+// controller, that assigns each hosted model to one of the available
+// controller machines. The model worker manager on each controller
+// runs the workers only of the models assigned to it, or not assigned
+// at all, and the apiserver may redirect a model's API connections to
+// the controller assigned to it.
+//
+// Models are spread evenly across the controllers whose agents are
+// alive and present. A model keeps its assignment unless its
+// controller becomes unavailable or has more than its share of
+// models, so that models move between controllers as rarely as
+// possible.
+package modelassigner
+
+import (
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.modelassigner")
+
+// Backend defines the state functionality required by the worker.
+type Backend interface {
+	// AllModelUUIDs returns the UUIDs of all models in the
+	// controller.
+	AllModelUUIDs() ([]string, error)
+
+	// ControllerModelUUID returns the UUID of the controller model,
+	// whose workers run on every controller and which is never
+	// assigned.
+	ControllerModelUUID() string
+
+	// ControllerMachineIds returns the ids of the controller
+	// machines.
+	ControllerMachineIds() ([]string, error)
+
+	// MachineAvailable reports whether the controller machine with
+	// the given id is alive and its agent present, so that models
+	// may be assigned to it.
+	MachineAvailable(id string) (bool, error)
+
+	ModelAssignments() (map[string]string, error)
+	SetModelAssignments(map[string]string) error
+	WatchModels() state.StringsWatcher
+}
+
+// Config holds the configuration and dependencies for a model
+// assigner worker.
+type Config struct {
+	// MachineId is the id of the controller machine running the
+	// worker, which is always considered available.
+	MachineId string
+
+	Backend  Backend
+	Clock    clock.Clock
+	Interval time.Duration
+}
+
+// Validate returns an error if the config cannot be expected to
+// drive a functional model assigner worker.
+func (config Config) Validate() error {
+	if config.MachineId == "" {
+		return errors.NotValidf("empty MachineId")
+	}
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that assigns models to controller
+// machines whenever models are added or removed, and every configured
+// interval to take account of controllers becoming available or
+// unavailable.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &assigner{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type assigner struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *assigner) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *assigner) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *assigner) loop() error {
+	watcher := w.config.Backend.WatchModels()
+	if err := w.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+	timer := w.config.Clock.NewTimer(w.config.Interval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watcher.Changes():
+			if !ok {
+				return errors.New("model watcher closed")
+			}
+		case <-timer.Chan():
+			timer.Reset(w.config.Interval)
+		}
+		if err := w.assign(); err != nil {
+			return errors.Annotate(err, "assigning models")
+		}
+	}
+}
+
+// assign balances the hosted models across the available controller
+// machines, and records the assignments if they have changed.
+func (w *assigner) assign() error {
+	backend := w.config.Backend
+	modelUUIDs, err := backend.AllModelUUIDs()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	controllerModelUUID := backend.ControllerModelUUID()
+	var hosted []string
+	for _, modelUUID := range modelUUIDs {
+		if modelUUID != controllerModelUUID {
+			hosted = append(hosted, modelUUID)
+		}
+	}
+
+	machineIds, err := backend.ControllerMachineIds()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	available := []string{w.config.MachineId}
+	for _, id := range machineIds {
+		if id == w.config.MachineId {
+			continue
+		}
+		ok, err := backend.MachineAvailable(id)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if ok {
+			available = append(available, id)
+		} else {
+			logger.Debugf("controller machine %s is not available", id)
+		}
+	}
+
+	current, err := backend.ModelAssignments()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	assignments := balance(hosted, available, current)
+	if reflect.DeepEqual(assignments, current) {
+		return nil
+	}
+	for modelUUID, machineId := range assignments {
+		if current[modelUUID] != machineId {
+			logger.Infof("assigning model %q to controller machine %s", modelUUID, machineId)
+		}
+	}
+	return errors.Trace(backend.SetModelAssignments(assignments))
+}
+
+// balance returns the assignments of the given models to the given
+// controllers, keyed by model UUID. Each controller is assigned at
+// most its share of the models, rounded up. A model keeps its current
+// assignment if its controller is one of those given and has not
+// already reached its share; otherwise it is assigned to the
+// controller with the fewest models.
+func balance(models, controllers []string, current map[string]string) map[string]string {
+	assignments := make(map[string]string)
+	if len(controllers) == 0 {
+		return assignments
+	}
+	controllers = append([]string(nil), controllers...)
+	sort.Strings(controllers)
+	models = append([]string(nil), models...)
+	sort.Strings(models)
+	share := (len(models) + len(controllers) - 1) / len(controllers)
+
+	load := make(map[string]int)
+	for _, id := range controllers {
+		load[id] = 0
+	}
+	var unassigned []string
+	for _, modelUUID := range models {
+		id, ok := current[modelUUID]
+		if n, available := load[id]; ok && available && n < share {
+			assignments[modelUUID] = id
+			load[id]++
+			continue
+		}
+		unassigned = append(unassigned, modelUUID)
+	}
+	for _, modelUUID := range unassigned {
+		least := controllers[0]
+		for _, id := range controllers[1:] {
+			if load[id] < load[least] {
+				least = id
+			}
+		}
+		assignments[modelUUID] = least
+		load[least]++
+	}
+	return assignments
+}
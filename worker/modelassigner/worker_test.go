@@ -0,0 +1,248 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelassigner_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/modelassigner"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock   *testing.Clock
+	backend *mockBackend
+	config  modelassigner.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Time{})
+	s.backend = &mockBackend{
+		models:     []string{"controller-uuid", "uuid-a", "uuid-b", "uuid-c"},
+		machineIds: []string{"0", "1", "2"},
+		available:  map[string]bool{"1": true, "2": true},
+		current:    map[string]string{},
+		watcher: &mockWatcher{
+			Worker:  workertest.NewErrorWorker(nil),
+			changes: make(chan []string),
+		},
+		sets: make(chan map[string]string, 1),
+	}
+	s.config = modelassigner.Config{
+		MachineId: "0",
+		Backend:   s.backend,
+		Clock:     s.clock,
+		Interval:  time.Minute,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	s.testValidate(c, func(cfg *modelassigner.Config) {
+		cfg.MachineId = ""
+	}, "empty MachineId not valid")
+	s.testValidate(c, func(cfg *modelassigner.Config) {
+		cfg.Backend = nil
+	}, "nil Backend not valid")
+	s.testValidate(c, func(cfg *modelassigner.Config) {
+		cfg.Clock = nil
+	}, "nil Clock not valid")
+	s.testValidate(c, func(cfg *modelassigner.Config) {
+		cfg.Interval = 0
+	}, "non-positive Interval not valid")
+}
+
+func (s *WorkerSuite) testValidate(c *gc.C, f func(*modelassigner.Config), expect string) {
+	config := s.config
+	f(&config)
+	w, err := modelassigner.NewWorker(config)
+	if !c.Check(err, gc.NotNil) {
+		workertest.DirtyKill(c, w)
+		return
+	}
+	c.Check(w, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, expect)
+}
+
+func (s *WorkerSuite) TestAssignsOnModelChange(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.backend.sendModelChange(c, "uuid-a", "uuid-b", "uuid-c")
+	c.Assert(s.nextSet(c), jc.DeepEquals, map[string]string{
+		"uuid-a": "0",
+		"uuid-b": "1",
+		"uuid-c": "2",
+	})
+}
+
+func (s *WorkerSuite) TestReassignsFromUnavailableController(c *gc.C) {
+	s.backend.current = map[string]string{
+		"uuid-a": "0",
+		"uuid-b": "1",
+		"uuid-c": "2",
+	}
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	// Nothing changes while all controllers are available.
+	s.backend.sendModelChange(c, "uuid-a")
+	s.assertNoSet(c)
+
+	s.backend.setAvailable("2", false)
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.nextSet(c), jc.DeepEquals, map[string]string{
+		"uuid-a": "0",
+		"uuid-b": "1",
+		"uuid-c": "0",
+	})
+}
+
+func (s *WorkerSuite) TestOwnMachineAlwaysAvailable(c *gc.C) {
+	s.backend.available = map[string]bool{}
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.backend.sendModelChange(c, "uuid-a")
+	c.Assert(s.nextSet(c), jc.DeepEquals, map[string]string{
+		"uuid-a": "0",
+		"uuid-b": "0",
+		"uuid-c": "0",
+	})
+}
+
+func (s *WorkerSuite) TestSetModelAssignmentsError(c *gc.C) {
+	s.backend.SetErrors(errors.New("kaboom"))
+	w := s.startWorker(c)
+	defer workertest.DirtyKill(c, w)
+
+	s.backend.sendModelChange(c, "uuid-a")
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "assigning models: kaboom")
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := modelassigner.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *WorkerSuite) nextSet(c *gc.C) map[string]string {
+	select {
+	case assignments := <-s.backend.sets:
+		return assignments
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for model assignments")
+	}
+	panic("unreachable")
+}
+
+func (s *WorkerSuite) assertNoSet(c *gc.C) {
+	select {
+	case assignments := <-s.backend.sets:
+		c.Fatalf("unexpected model assignments: %v", assignments)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type mockBackend struct {
+	testing.Stub
+
+	mu         sync.Mutex
+	models     []string
+	machineIds []string
+	available  map[string]bool
+	current    map[string]string
+	watcher    *mockWatcher
+	sets       chan map[string]string
+}
+
+func (b *mockBackend) AllModelUUIDs() ([]string, error) {
+	return b.models, nil
+}
+
+func (b *mockBackend) ControllerModelUUID() string {
+	return "controller-uuid"
+}
+
+func (b *mockBackend) ControllerMachineIds() ([]string, error) {
+	return b.machineIds, nil
+}
+
+func (b *mockBackend) MachineAvailable(id string) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.available[id], nil
+}
+
+func (b *mockBackend) setAvailable(id string, available bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.available[id] = available
+}
+
+func (b *mockBackend) ModelAssignments() (map[string]string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	current := make(map[string]string)
+	for modelUUID, machineId := range b.current {
+		current[modelUUID] = machineId
+	}
+	return current, nil
+}
+
+func (b *mockBackend) SetModelAssignments(assignments map[string]string) error {
+	b.MethodCall(b, "SetModelAssignments", assignments)
+	if err := b.NextErr(); err != nil {
+		return err
+	}
+	b.mu.Lock()
+	b.current = assignments
+	b.mu.Unlock()
+	b.sets <- assignments
+	return nil
+}
+
+func (b *mockBackend) WatchModels() state.StringsWatcher {
+	return b.watcher
+}
+
+func (b *mockBackend) sendModelChange(c *gc.C, uuids ...string) {
+	select {
+	case b.watcher.changes <- uuids:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out sending model change")
+	}
+}
+
+type mockWatcher struct {
+	worker.Worker
+	changes chan []string
+}
+
+func (w *mockWatcher) Err() error {
+	panic("not used")
+}
+
+func (w *mockWatcher) Stop() error {
+	return worker.Stop(w)
+}
+
+func (w *mockWatcher) Changes() <-chan []string {
+	return w.changes
+}
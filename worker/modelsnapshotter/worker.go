@@ -0,0 +1,93 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelsnapshotter provides a worker that periodically records
+// a snapshot of its model's description, so that operators can see how
+// the model has changed over time.
+package modelsnapshotter
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/worker/catacomb"
+)
+
+// Facade defines the interface we require from the model snapshotter
+// facade.
+type Facade interface {
+	TakeModelSnapshot() error
+}
+
+// Config holds the configuration and dependencies for a model
+// snapshotter worker.
+type Config struct {
+	Facade   Facade
+	Clock    clock.Clock
+	Interval time.Duration
+}
+
+// Validate returns an error if the config cannot be expected to
+// drive a functional model snapshotter worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that takes a snapshot of the model when
+// started, and subsequently every configured interval.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &snapshotter{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type snapshotter struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *snapshotter) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *snapshotter) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *snapshotter) loop() error {
+	var delay time.Duration
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(delay):
+			if err := w.config.Facade.TakeModelSnapshot(); err != nil {
+				return errors.Annotate(err, "taking model snapshot")
+			}
+		}
+		delay = w.config.Interval
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelsnapshotter_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/modelsnapshotter"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+
+	clock  *testing.Clock
+	facade *mockFacade
+	config modelsnapshotter.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2018, 3, 3, 1, 50, 0, 0, time.UTC))
+	s.facade = &mockFacade{calls: make(chan struct{}, 10)}
+	s.config = modelsnapshotter.Config{
+		Facade:   s.facade,
+		Clock:    s.clock,
+		Interval: time.Hour,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	s.testValidate(c, func(cfg *modelsnapshotter.Config) {
+		cfg.Facade = nil
+	}, "nil Facade not valid")
+	s.testValidate(c, func(cfg *modelsnapshotter.Config) {
+		cfg.Clock = nil
+	}, "nil Clock not valid")
+	s.testValidate(c, func(cfg *modelsnapshotter.Config) {
+		cfg.Interval = 0
+	}, "non-positive Interval not valid")
+}
+
+func (s *WorkerSuite) testValidate(c *gc.C, f func(*modelsnapshotter.Config), expect string) {
+	config := s.config
+	f(&config)
+	w, err := modelsnapshotter.NewWorker(config)
+	if !c.Check(err, gc.NotNil) {
+		workertest.DirtyKill(c, w)
+		return
+	}
+	c.Check(w, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, expect)
+}
+
+func (s *WorkerSuite) TestTakesSnapshots(c *gc.C) {
+	w, err := modelsnapshotter.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	// A snapshot is taken on start, and then every interval.
+	s.expectSnapshot(c)
+	s.expectNoSnapshot(c)
+	err = s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.expectSnapshot(c)
+	s.facade.CheckCallNames(c, "TakeModelSnapshot", "TakeModelSnapshot")
+}
+
+func (s *WorkerSuite) TestFacadeError(c *gc.C) {
+	s.facade.SetErrors(errors.New("kaboom"))
+	w, err := modelsnapshotter.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "taking model snapshot: kaboom")
+}
+
+func (s *WorkerSuite) expectSnapshot(c *gc.C) {
+	select {
+	case <-s.facade.calls:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for snapshot")
+	}
+}
+
+func (s *WorkerSuite) expectNoSnapshot(c *gc.C) {
+	select {
+	case <-s.facade.calls:
+		c.Fatalf("unexpected snapshot")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type mockFacade struct {
+	testing.Stub
+	calls chan struct{}
+}
+
+func (f *mockFacade) TakeModelSnapshot() error {
+	f.MethodCall(f, "TakeModelSnapshot")
+	f.calls <- struct{}{}
+	return f.NextErr()
+}
@@ -7,8 +7,10 @@ import (
 	"sync"
 
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/juju/worker.v1"
 
+	"github.com/juju/juju/agent"
 	jworker "github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/dependency"
 	workerstate "github.com/juju/juju/worker/state"
@@ -17,6 +19,7 @@ import (
 // ManifoldConfig holds the information necessary to run a model worker manager
 // in a dependency.Engine.
 type ManifoldConfig struct {
+	AgentName      string
 	StateName      string
 	NewWorker      func(Config) (worker.Worker, error)
 	NewModelWorker NewModelWorkerFunc
@@ -24,6 +27,9 @@ type ManifoldConfig struct {
 
 // Validate validates the manifold configuration.
 func (config ManifoldConfig) Validate() error {
+	if config.AgentName == "" {
+		return errors.NotValidf("empty AgentName")
+	}
 	if config.StateName == "" {
 		return errors.NotValidf("empty StateName")
 	}
@@ -39,7 +45,7 @@ func (config ManifoldConfig) Validate() error {
 // Manifold returns a dependency.Manifold that will run a model worker manager.
 func Manifold(config ManifoldConfig) dependency.Manifold {
 	return dependency.Manifold{
-		Inputs: []string{config.StateName},
+		Inputs: []string{config.AgentName, config.StateName},
 		Start:  config.start,
 	}
 }
@@ -50,6 +56,16 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		return nil, errors.Trace(err)
 	}
 
+	var agent agent.Agent
+	if err := context.Get(config.AgentName, &agent); err != nil {
+		return nil, errors.Trace(err)
+	}
+	tag := agent.CurrentConfig().Tag()
+	machineTag, ok := tag.(names.MachineTag)
+	if !ok {
+		return nil, errors.Errorf("expected a machine tag, got %v", tag)
+	}
+
 	var stTracker workerstate.StateTracker
 	if err := context.Get(config.StateName, &stTracker); err != nil {
 		return nil, errors.Trace(err)
@@ -60,10 +76,12 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 	}
 
 	w, err := config.NewWorker(Config{
-		ModelWatcher:   statePool.SystemState(),
-		ModelGetter:    StatePoolModelGetter{statePool},
-		NewModelWorker: config.NewModelWorker,
-		ErrorDelay:     jworker.RestartDelay,
+		ModelWatcher:     statePool.SystemState(),
+		ModelGetter:      StatePoolModelGetter{statePool},
+		NewModelWorker:   config.NewModelWorker,
+		ErrorDelay:       jworker.RestartDelay,
+		ModelAssignments: statePool.SystemState(),
+		MachineId:        machineTag.Id(),
 	})
 	if err != nil {
 		stTracker.Done()
@@ -8,8 +8,10 @@ import (
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 	worker "gopkg.in/juju/worker.v1"
 
+	"github.com/juju/juju/agent"
 	"github.com/juju/juju/state"
 	jworker "github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/dependency"
@@ -23,6 +25,7 @@ type ManifoldSuite struct {
 
 	manifold     dependency.Manifold
 	context      dependency.Context
+	agent        *mockAgent
 	st           *state.State
 	pool         *state.StatePool
 	stateTracker stubStateTracker
@@ -39,9 +42,11 @@ func (s *ManifoldSuite) SetUpTest(c *gc.C) {
 	s.pool = state.NewStatePool(s.st)
 	s.stateTracker = stubStateTracker{pool: s.pool}
 	s.stub.ResetCalls()
+	s.agent = &mockAgent{conf: mockAgentConfig{tag: names.NewMachineTag("2")}}
 
 	s.context = s.newContext(nil)
 	s.manifold = modelworkermanager.Manifold(modelworkermanager.ManifoldConfig{
+		AgentName:      "agent",
 		StateName:      "state",
 		NewWorker:      s.newWorker,
 		NewModelWorker: s.newModelWorker,
@@ -49,7 +54,10 @@ func (s *ManifoldSuite) SetUpTest(c *gc.C) {
 }
 
 func (s *ManifoldSuite) newContext(overlay map[string]interface{}) dependency.Context {
-	resources := map[string]interface{}{"state": &s.stateTracker}
+	resources := map[string]interface{}{
+		"agent": s.agent,
+		"state": &s.stateTracker,
+	}
 	for k, v := range overlay {
 		resources[k] = v
 	}
@@ -72,7 +80,7 @@ func (s *ManifoldSuite) newModelWorker(modelUUID string, modelType state.ModelTy
 	return worker.NewRunner(worker.RunnerParams{}), nil
 }
 
-var expectedInputs = []string{"state"}
+var expectedInputs = []string{"agent", "state"}
 
 func (s *ManifoldSuite) TestInputs(c *gc.C) {
 	c.Assert(s.manifold.Inputs, jc.SameContents, expectedInputs)
@@ -107,12 +115,22 @@ func (s *ManifoldSuite) TestStart(c *gc.C) {
 	config.NewModelWorker = nil
 
 	c.Assert(config, jc.DeepEquals, modelworkermanager.Config{
-		ModelWatcher: s.st,
-		ModelGetter:  modelworkermanager.StatePoolModelGetter{s.pool},
-		ErrorDelay:   jworker.RestartDelay,
+		ModelWatcher:     s.st,
+		ModelGetter:      modelworkermanager.StatePoolModelGetter{s.pool},
+		ErrorDelay:       jworker.RestartDelay,
+		ModelAssignments: s.st,
+		MachineId:        "2",
 	})
 }
 
+func (s *ManifoldSuite) TestStartNonMachineAgent(c *gc.C) {
+	s.agent.conf.tag = names.NewUnitTag("mysql/0")
+	w, err := s.manifold.Start(s.context)
+	c.Assert(w, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, `expected a machine tag, got unit-mysql-0`)
+	s.stub.CheckNoCalls(c)
+}
+
 func (s *ManifoldSuite) TestStopWorkerClosesState(c *gc.C) {
 	w := s.startWorkerClean(c)
 	defer workertest.CleanKill(c, w)
@@ -144,3 +162,21 @@ func (s *stubStateTracker) Done() error {
 	s.MethodCall(s, "Done")
 	return s.NextErr()
 }
+
+type mockAgent struct {
+	agent.Agent
+	conf mockAgentConfig
+}
+
+func (ma *mockAgent) CurrentConfig() agent.Config {
+	return &ma.conf
+}
+
+type mockAgentConfig struct {
+	agent.Config
+	tag names.Tag
+}
+
+func (c *mockAgentConfig) Tag() names.Tag {
+	return c.tag
+}
@@ -35,6 +35,19 @@ type Model interface {
 	Type() state.ModelType
 }
 
+// ModelAssignments provides an interface for finding the controller
+// machine assigned to run a model's workers.
+type ModelAssignments interface {
+	// WatchModelAssignments returns a watcher reporting the UUIDs
+	// of models whose assignments have changed.
+	WatchModelAssignments() state.StringsWatcher
+
+	// ModelAssignment returns the id of the controller machine
+	// assigned to the model, or an error satisfying
+	// errors.IsNotFound if it has none.
+	ModelAssignment(modelUUID string) (string, error)
+}
+
 // NewModelWorkerFunc should return a worker responsible for running
 // all a model's required workers; and for returning nil when there's
 // no more model to manage.
@@ -47,6 +60,12 @@ type Config struct {
 	ModelGetter    ModelGetter
 	NewModelWorker NewModelWorkerFunc
 	ErrorDelay     time.Duration
+
+	// ModelAssignments, if set, is used to run the workers only of
+	// models that are assigned to the controller machine with the
+	// id MachineId, or that are not assigned at all.
+	ModelAssignments ModelAssignments
+	MachineId        string
 }
 
 // Validate returns an error if config cannot be expected to drive
@@ -64,6 +83,9 @@ func (config Config) Validate() error {
 	if config.ErrorDelay <= 0 {
 		return errors.NotValidf("non-positive ErrorDelay")
 	}
+	if config.ModelAssignments != nil && config.MachineId == "" {
+		return errors.NotValidf("empty MachineId")
+	}
 	return nil
 }
 
@@ -114,6 +136,14 @@ func (m *modelWorkerManager) loop() error {
 	if err := m.catacomb.Add(watcher); err != nil {
 		return errors.Trace(err)
 	}
+	var assignmentChanges <-chan []string
+	if m.config.ModelAssignments != nil {
+		assignmentWatcher := m.config.ModelAssignments.WatchModelAssignments()
+		if err := m.catacomb.Add(assignmentWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		assignmentChanges = assignmentWatcher.Changes()
+	}
 
 	modelChanged := func(modelUUID string) error {
 		model, release, err := m.config.ModelGetter.Model(modelUUID)
@@ -125,6 +155,16 @@ func (m *modelWorkerManager) loop() error {
 		}
 		defer release()
 
+		assigned, err := m.isAssigned(modelUUID)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !assigned {
+			// The model's workers run on another controller.
+			logger.Debugf("model %q is assigned to another controller", modelUUID)
+			return errors.Trace(m.runner.StopWorker(modelUUID))
+		}
+
 		if !isModelActive(model) {
 			// Ignore this model until it's activated - we
 			// never want to run workers for an importing
@@ -148,10 +188,35 @@ func (m *modelWorkerManager) loop() error {
 					return errors.Trace(err)
 				}
 			}
+		case uuids, ok := <-assignmentChanges:
+			if !ok {
+				return errors.New("assignment changes stopped")
+			}
+			for _, modelUUID := range uuids {
+				if err := modelChanged(modelUUID); err != nil {
+					return errors.Trace(err)
+				}
+			}
 		}
 	}
 }
 
+// isAssigned reports whether the model's workers should run on this
+// controller machine: when models are not being assigned, when the
+// model is not assigned, or when it is assigned to this machine.
+func (m *modelWorkerManager) isAssigned(modelUUID string) (bool, error) {
+	if m.config.ModelAssignments == nil {
+		return true, nil
+	}
+	machineId, err := m.config.ModelAssignments.ModelAssignment(modelUUID)
+	if errors.IsNotFound(err) {
+		return true, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	return machineId == m.config.MachineId, nil
+}
+
 func (m *modelWorkerManager) ensure(modelUUID string, modelType state.ModelType) error {
 	starter := m.starter(modelUUID, modelType)
 	if err := m.runner.StartWorker(modelUUID, starter); err != nil {
@@ -4,6 +4,7 @@
 package modelworkermanager_test
 
 import (
+	"sync"
 	"time"
 
 	"github.com/juju/errors"
@@ -23,12 +24,14 @@ var _ = gc.Suite(&suite{})
 
 type suite struct {
 	testing.IsolationSuite
-	workerC chan *mockWorker
+	workerC     chan *mockWorker
+	assignments *mockModelAssignments
 }
 
 func (s *suite) SetUpTest(c *gc.C) {
 	s.IsolationSuite.SetUpTest(c)
 	s.workerC = make(chan *mockWorker, 100)
+	s.assignments = nil
 }
 
 func (s *suite) TestStartEmpty(c *gc.C) {
@@ -144,6 +147,55 @@ func (s *suite) TestNoStartingWorkersForImportingModel(c *gc.C) {
 	})
 }
 
+func (s *suite) TestValidateMachineId(c *gc.C) {
+	config := modelworkermanager.Config{
+		ModelWatcher:     newMockModelWatcher(),
+		ModelGetter:      newMockModelGetter(),
+		NewModelWorker:   s.startModelWorker,
+		ErrorDelay:       time.Millisecond,
+		ModelAssignments: newMockModelAssignments(nil),
+	}
+	w, err := modelworkermanager.New(config)
+	c.Check(w, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "empty MachineId not valid")
+}
+
+func (s *suite) TestIgnoresModelAssignedElsewhere(c *gc.C) {
+	s.assignments = newMockModelAssignments(map[string]string{
+		"uuid1": "1",
+		"uuid2": "0",
+	})
+	s.runTest(c, func(_ worker.Worker, w *mockModelWatcher, _ *mockModelGetter) {
+		w.sendModelChange("uuid1", "uuid2", "uuid3")
+
+		s.assertStarts(c, "uuid2", "uuid3")
+	})
+}
+
+func (s *suite) TestStopsWorkerWhenReassigned(c *gc.C) {
+	s.assignments = newMockModelAssignments(map[string]string{"uuid1": "0"})
+	s.runTest(c, func(w worker.Worker, mw *mockModelWatcher, _ *mockModelGetter) {
+		mw.sendModelChange("uuid1")
+		workers := s.waitWorkers(c, 1)
+
+		s.assignments.sendAssignmentChange("uuid1", "1")
+		workertest.CheckKilled(c, workers[0])
+		s.assertNoWorkers(c)
+		workertest.CheckAlive(c, w)
+	})
+}
+
+func (s *suite) TestStartsWorkerWhenAssigned(c *gc.C) {
+	s.assignments = newMockModelAssignments(map[string]string{"uuid1": "1"})
+	s.runTest(c, func(_ worker.Worker, mw *mockModelWatcher, _ *mockModelGetter) {
+		mw.sendModelChange("uuid1")
+		s.assertNoWorkers(c)
+
+		s.assignments.sendAssignmentChange("uuid1", "0")
+		s.assertStarts(c, "uuid1")
+	})
+}
+
 type testFunc func(worker.Worker, *mockModelWatcher, *mockModelGetter)
 type killFunc func(*gc.C, worker.Worker)
 
@@ -164,6 +216,10 @@ func (s *suite) runKillTest(c *gc.C, kill killFunc, test testFunc) {
 		NewModelWorker: s.startModelWorker,
 		ErrorDelay:     time.Millisecond,
 	}
+	if s.assignments != nil {
+		config.ModelAssignments = s.assignments
+		config.MachineId = "0"
+	}
 	w, err := modelworkermanager.New(config)
 	c.Assert(err, jc.ErrorIsNil)
 	defer kill(c, w)
@@ -259,6 +315,46 @@ func (mock *mockModelWatcher) sendModelChange(uuids ...string) {
 	mock.envWatcher.changes <- uuids
 }
 
+func newMockModelAssignments(assignments map[string]string) *mockModelAssignments {
+	if assignments == nil {
+		assignments = make(map[string]string)
+	}
+	return &mockModelAssignments{
+		assignments: assignments,
+		watcher: &mockEnvWatcher{
+			Worker:  workertest.NewErrorWorker(nil),
+			changes: make(chan []string),
+		},
+	}
+}
+
+type mockModelAssignments struct {
+	mu          sync.Mutex
+	assignments map[string]string
+	watcher     *mockEnvWatcher
+}
+
+func (mock *mockModelAssignments) WatchModelAssignments() state.StringsWatcher {
+	return mock.watcher
+}
+
+func (mock *mockModelAssignments) ModelAssignment(modelUUID string) (string, error) {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	machineId, ok := mock.assignments[modelUUID]
+	if !ok {
+		return "", errors.NotFoundf("assignment for model %q", modelUUID)
+	}
+	return machineId, nil
+}
+
+func (mock *mockModelAssignments) sendAssignmentChange(modelUUID, machineId string) {
+	mock.mu.Lock()
+	mock.assignments[modelUUID] = machineId
+	mock.mu.Unlock()
+	mock.watcher.changes <- []string{modelUUID}
+}
+
 type mockModelGetter struct {
 	testing.Stub
 	model mockModel
@@ -0,0 +1,310 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package orphansweeper provides a worker that periodically compares
+// the resources a model's provider holds with those recorded in state,
+// and reports any that juju created but no longer tracks. Such
+// resources are usually left behind when destroying them failed. If
+// the model's "clean-orphaned-resources" config is set, the orphans
+// are also destroyed.
+//
+// A resource is only considered orphaned once it has been found
+// untracked by two consecutive sweeps, so that instances and volumes
+// which are being created, and so are not yet recorded in state, are
+// never reported or destroyed.
+package orphansweeper
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/orphansweeper"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.orphansweeper")
+
+// Facade defines the interface we require from the orphan sweeper
+// facade.
+type Facade interface {
+	TrackedResources() (instanceIds, volumeIds []string, err error)
+	SetOrphanedResources(orphansweeper.Orphans) error
+}
+
+// Environ defines the interface we require from the model's environ.
+type Environ interface {
+	storage.ProviderRegistry
+	AllInstances() ([]instance.Instance, error)
+	StopInstances(context.Context, ...instance.Id) error
+	Config() *config.Config
+}
+
+// SecurityGroupSweeper is an optional interface implemented by environs
+// which can identify the security groups they created for the model
+// that no longer belong to any of the given instances.
+type SecurityGroupSweeper interface {
+	UnusedSecurityGroups(instanceIds []instance.Id) ([]string, error)
+	DeleteSecurityGroups(groupIds []string) error
+}
+
+// Config holds the configuration and dependencies for an orphan
+// sweeper worker.
+type Config struct {
+	Facade   Facade
+	Environ  Environ
+	Clock    clock.Clock
+	Interval time.Duration
+}
+
+// Validate returns an error if the config cannot be expected to
+// drive a functional orphan sweeper.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Environ == nil {
+		return errors.NotValidf("nil Environ")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that sweeps the model for orphaned
+// provider resources every configured interval.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &sweeper{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type sweeper struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	// suspects holds the resources found untracked by the previous
+	// sweep, keyed on kind.
+	suspects map[string]set.Strings
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *sweeper) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *sweeper) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *sweeper) loop() error {
+	timer := w.config.Clock.NewTimer(w.config.Interval)
+	defer timer.Stop()
+	ctx := w.catacomb.Context(context.Background())
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-timer.Chan():
+			if err := w.sweep(ctx); err != nil {
+				return errors.Annotate(err, "sweeping orphaned resources")
+			}
+			timer.Reset(w.config.Interval)
+		}
+	}
+}
+
+const (
+	kindInstance      = "instance"
+	kindVolume        = "volume"
+	kindSecurityGroup = "security group"
+)
+
+func (w *sweeper) sweep(ctx context.Context) error {
+	trackedInstances, trackedVolumes, err := w.config.Facade.TrackedResources()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	env := w.config.Environ
+	clean := env.Config().CleanOrphanedResources()
+
+	untracked := make(map[string]set.Strings)
+	instances, err := env.AllInstances()
+	if err != nil {
+		return errors.Annotate(err, "listing instances")
+	}
+	tracked := set.NewStrings(trackedInstances...)
+	untracked[kindInstance] = set.NewStrings()
+	var liveInstances []instance.Id
+	for _, inst := range instances {
+		id := string(inst.Id())
+		if !tracked.Contains(id) {
+			untracked[kindInstance].Add(id)
+		} else {
+			liveInstances = append(liveInstances, inst.Id())
+		}
+	}
+
+	volumeSources, err := w.volumeSources()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tracked = set.NewStrings(trackedVolumes...)
+	untracked[kindVolume] = set.NewStrings()
+	volumeIdSources := make(map[string]storage.VolumeSource)
+	for _, source := range volumeSources {
+		ids, err := source.ListVolumes(ctx)
+		if err != nil {
+			return errors.Annotate(err, "listing volumes")
+		}
+		for _, id := range ids {
+			if !tracked.Contains(id) {
+				untracked[kindVolume].Add(id)
+				volumeIdSources[id] = source
+			}
+		}
+	}
+
+	groupSweeper, _ := env.(SecurityGroupSweeper)
+	if groupSweeper != nil {
+		ids, err := groupSweeper.UnusedSecurityGroups(liveInstances)
+		if err != nil {
+			return errors.Annotate(err, "listing security groups")
+		}
+		untracked[kindSecurityGroup] = set.NewStrings(ids...)
+	}
+
+	orphans := make(map[string][]string)
+	for kind, ids := range untracked {
+		orphans[kind] = ids.Intersection(w.suspects[kind]).SortedValues()
+	}
+	w.suspects = untracked
+
+	var cleaned []string
+	if clean {
+		cleaned = append(cleaned, w.stopInstances(ctx, orphans[kindInstance])...)
+		cleaned = append(cleaned, w.destroyVolumes(ctx, orphans[kindVolume], volumeIdSources)...)
+		if groupSweeper != nil {
+			cleaned = append(cleaned, w.deleteSecurityGroups(groupSweeper, orphans[kindSecurityGroup])...)
+		}
+		sort.Strings(cleaned)
+	}
+	for _, kind := range []string{kindInstance, kindVolume, kindSecurityGroup} {
+		if len(orphans[kind]) > 0 {
+			logger.Warningf("found orphaned %s resources: %v", kind, orphans[kind])
+		}
+	}
+
+	err = w.config.Facade.SetOrphanedResources(orphansweeper.Orphans{
+		Swept:          w.config.Clock.Now(),
+		Instances:      orphans[kindInstance],
+		Volumes:        orphans[kindVolume],
+		SecurityGroups: orphans[kindSecurityGroup],
+		Cleaned:        cleaned,
+	})
+	return errors.Trace(err)
+}
+
+// volumeSources returns a volume source for each of the environ's
+// model-scoped storage providers that supports block storage.
+func (w *sweeper) volumeSources() ([]storage.VolumeSource, error) {
+	env := w.config.Environ
+	types, err := env.StorageProviderTypes()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var sources []storage.VolumeSource
+	for _, providerType := range types {
+		provider, err := env.StorageProvider(providerType)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if provider.Scope() != storage.ScopeEnviron || !provider.Supports(storage.StorageKindBlock) {
+			continue
+		}
+		cfg, err := storage.NewConfig(string(providerType), providerType, map[string]interface{}{})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		source, err := provider.VolumeSource(cfg)
+		if errors.IsNotSupported(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		sources = append(sources, source)
+	}
+	return sources, nil
+}
+
+func (w *sweeper) stopInstances(ctx context.Context, ids []string) []string {
+	if len(ids) == 0 {
+		return nil
+	}
+	instanceIds := make([]instance.Id, len(ids))
+	for i, id := range ids {
+		instanceIds[i] = instance.Id(id)
+	}
+	if err := w.config.Environ.StopInstances(ctx, instanceIds...); err != nil {
+		logger.Errorf("cannot stop orphaned instances %v: %v", ids, err)
+		return nil
+	}
+	return ids
+}
+
+func (w *sweeper) destroyVolumes(ctx context.Context, ids []string, sources map[string]storage.VolumeSource) []string {
+	bySource := make(map[storage.VolumeSource][]string)
+	for _, id := range ids {
+		bySource[sources[id]] = append(bySource[sources[id]], id)
+	}
+	var destroyed []string
+	for source, ids := range bySource {
+		errs, err := source.DestroyVolumes(ctx, ids)
+		if err != nil {
+			logger.Errorf("cannot destroy orphaned volumes %v: %v", ids, err)
+			continue
+		}
+		for i, err := range errs {
+			if err != nil {
+				logger.Errorf("cannot destroy orphaned volume %q: %v", ids[i], err)
+				continue
+			}
+			destroyed = append(destroyed, ids[i])
+		}
+	}
+	return destroyed
+}
+
+func (w *sweeper) deleteSecurityGroups(groupSweeper SecurityGroupSweeper, ids []string) []string {
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := groupSweeper.DeleteSecurityGroups(ids); err != nil {
+		logger.Errorf("cannot delete orphaned security groups %v: %v", ids, err)
+		return nil
+	}
+	return ids
+}
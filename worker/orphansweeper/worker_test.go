@@ -0,0 +1,278 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package orphansweeper_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/orphansweeper"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider/dummy"
+	coretesting "github.com/juju/juju/testing"
+	workerorphansweeper "github.com/juju/juju/worker/orphansweeper"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+
+	clock   *testing.Clock
+	facade  *mockFacade
+	environ *mockEnviron
+	volumes *dummy.VolumeSource
+	config  workerorphansweeper.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Time{})
+	s.facade = &mockFacade{
+		instanceIds: []string{"i-0"},
+		volumeIds:   []string{"vol-0"},
+		swept:       make(chan orphansweeper.Orphans, 1),
+	}
+	s.volumes = &dummy.VolumeSource{
+		ListVolumesFunc: func() ([]string, error) {
+			return []string{"vol-0", "vol-1"}, nil
+		},
+		DestroyVolumesFunc: func(ids []string) ([]error, error) {
+			return make([]error, len(ids)), nil
+		},
+	}
+	s.environ = &mockEnviron{
+		ProviderRegistry: storage.StaticProviderRegistry{
+			Providers: map[storage.ProviderType]storage.Provider{
+				"environscoped": &dummy.StorageProvider{
+					StorageScope: storage.ScopeEnviron,
+					VolumeSourceFunc: func(*storage.Config) (storage.VolumeSource, error) {
+						return s.volumes, nil
+					},
+				},
+				"machinescoped": &dummy.StorageProvider{
+					StorageScope: storage.ScopeMachine,
+				},
+			},
+		},
+		instanceIds: []string{"i-0", "i-1"},
+		config:      coretesting.ModelConfig(c),
+	}
+	s.config = workerorphansweeper.Config{
+		Facade:   s.facade,
+		Environ:  s.environ,
+		Clock:    s.clock,
+		Interval: time.Hour,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	s.testValidate(c, func(cfg *workerorphansweeper.Config) {
+		cfg.Facade = nil
+	}, "nil Facade not valid")
+	s.testValidate(c, func(cfg *workerorphansweeper.Config) {
+		cfg.Environ = nil
+	}, "nil Environ not valid")
+	s.testValidate(c, func(cfg *workerorphansweeper.Config) {
+		cfg.Clock = nil
+	}, "nil Clock not valid")
+	s.testValidate(c, func(cfg *workerorphansweeper.Config) {
+		cfg.Interval = 0
+	}, "non-positive Interval not valid")
+}
+
+func (s *WorkerSuite) testValidate(c *gc.C, f func(*workerorphansweeper.Config), expect string) {
+	config := s.config
+	f(&config)
+	w, err := workerorphansweeper.NewWorker(config)
+	if !c.Check(err, gc.NotNil) {
+		workertest.DirtyKill(c, w)
+		return
+	}
+	c.Check(w, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, expect)
+}
+
+func (s *WorkerSuite) TestReportsOnlyAfterConsecutiveSweeps(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	// The first sweep only records suspects.
+	orphans := s.sweep(c)
+	c.Assert(orphans.Instances, gc.HasLen, 0)
+	c.Assert(orphans.Volumes, gc.HasLen, 0)
+
+	orphans = s.sweep(c)
+	c.Assert(orphans, jc.DeepEquals, orphansweeper.Orphans{
+		Swept:     s.clock.Now(),
+		Instances: []string{"i-1"},
+		Volumes:   []string{"vol-1"},
+	})
+	s.environ.CheckNoCalls(c)
+	s.volumes.CheckCallNames(c, "ListVolumes", "ListVolumes")
+}
+
+func (s *WorkerSuite) TestResourceTrackedAgainIsNotReported(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.sweep(c)
+	s.facade.setTracked([]string{"i-0", "i-1"}, []string{"vol-0"})
+	orphans := s.sweep(c)
+	c.Assert(orphans.Instances, gc.HasLen, 0)
+	c.Assert(orphans.Volumes, jc.DeepEquals, []string{"vol-1"})
+}
+
+func (s *WorkerSuite) TestCleansWhenConfigured(c *gc.C) {
+	s.environ.config = coretesting.CustomModelConfig(c, coretesting.Attrs{
+		"clean-orphaned-resources": true,
+	})
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.sweep(c)
+	s.environ.CheckNoCalls(c)
+	orphans := s.sweep(c)
+	c.Assert(orphans.Cleaned, jc.DeepEquals, []string{"i-1", "vol-1"})
+	s.environ.CheckCall(c, 0, "StopInstances", []instance.Id{"i-1"})
+	s.volumes.CheckCall(c, 2, "DestroyVolumes", []string{"vol-1"})
+}
+
+func (s *WorkerSuite) TestCleanFailureNotReportedAsCleaned(c *gc.C) {
+	s.environ.config = coretesting.CustomModelConfig(c, coretesting.Attrs{
+		"clean-orphaned-resources": true,
+	})
+	s.environ.SetErrors(errors.New("kaboom"))
+	s.volumes.DestroyVolumesFunc = func(ids []string) ([]error, error) {
+		return []error{errors.New("in use")}, nil
+	}
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.sweep(c)
+	orphans := s.sweep(c)
+	c.Assert(orphans.Instances, jc.DeepEquals, []string{"i-1"})
+	c.Assert(orphans.Volumes, jc.DeepEquals, []string{"vol-1"})
+	c.Assert(orphans.Cleaned, gc.HasLen, 0)
+}
+
+func (s *WorkerSuite) TestSecurityGroups(c *gc.C) {
+	environ := &mockGroupEnviron{mockEnviron: s.environ, groups: []string{"sg-1"}}
+	s.config.Environ = environ
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.sweep(c)
+	orphans := s.sweep(c)
+	c.Assert(orphans.SecurityGroups, jc.DeepEquals, []string{"sg-1"})
+	environ.CheckCall(c, 0, "UnusedSecurityGroups", []instance.Id{"i-0"})
+}
+
+func (s *WorkerSuite) TestTrackedResourcesError(c *gc.C) {
+	s.facade.SetErrors(errors.New("kaboom"))
+	w := s.startWorker(c)
+	defer workertest.DirtyKill(c, w)
+
+	s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "sweeping orphaned resources: kaboom")
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := workerorphansweeper.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *WorkerSuite) sweep(c *gc.C) orphansweeper.Orphans {
+	err := s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case orphans := <-s.facade.swept:
+		return orphans
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for sweep")
+	}
+	panic("unreachable")
+}
+
+type mockFacade struct {
+	testing.Stub
+	instanceIds []string
+	volumeIds   []string
+	swept       chan orphansweeper.Orphans
+}
+
+func (f *mockFacade) setTracked(instanceIds, volumeIds []string) {
+	f.instanceIds = instanceIds
+	f.volumeIds = volumeIds
+}
+
+func (f *mockFacade) TrackedResources() ([]string, []string, error) {
+	f.MethodCall(f, "TrackedResources")
+	return f.instanceIds, f.volumeIds, f.NextErr()
+}
+
+func (f *mockFacade) SetOrphanedResources(orphans orphansweeper.Orphans) error {
+	f.MethodCall(f, "SetOrphanedResources", orphans)
+	f.swept <- orphans
+	return f.NextErr()
+}
+
+type mockEnviron struct {
+	testing.Stub
+	storage.ProviderRegistry
+	instanceIds []string
+	config      *config.Config
+}
+
+func (e *mockEnviron) AllInstances() ([]instance.Instance, error) {
+	instances := make([]instance.Instance, len(e.instanceIds))
+	for i, id := range e.instanceIds {
+		instances[i] = mockInstance{id: instance.Id(id)}
+	}
+	return instances, nil
+}
+
+func (e *mockEnviron) StopInstances(ctx context.Context, ids ...instance.Id) error {
+	e.MethodCall(e, "StopInstances", ids)
+	return e.NextErr()
+}
+
+func (e *mockEnviron) Config() *config.Config {
+	return e.config
+}
+
+type mockGroupEnviron struct {
+	*mockEnviron
+	groups []string
+}
+
+func (e *mockGroupEnviron) UnusedSecurityGroups(instanceIds []instance.Id) ([]string, error) {
+	e.MethodCall(e, "UnusedSecurityGroups", instanceIds)
+	return e.groups, e.NextErr()
+}
+
+func (e *mockGroupEnviron) DeleteSecurityGroups(groupIds []string) error {
+	e.MethodCall(e, "DeleteSecurityGroups", groupIds)
+	return e.NextErr()
+}
+
+type mockInstance struct {
+	instance.Instance
+	id instance.Id
+}
+
+func (i mockInstance) Id() instance.Id {
+	return i.id
+}
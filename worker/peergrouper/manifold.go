@@ -9,8 +9,10 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/utils/clock"
 	"gopkg.in/juju/worker.v1"
+	"gopkg.in/mgo.v2"
 
 	"github.com/juju/juju/agent"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/worker/dependency"
 	workerstate "github.com/juju/juju/worker/state"
@@ -25,6 +27,15 @@ type ManifoldConfig struct {
 	Hub                      Hub
 	NewWorker                func(Config) (worker.Worker, error)
 	ControllerSupportsSpaces func(*state.State) (bool, error)
+
+	// PromoteToHA converts the mongo server of the agent's
+	// controller, if it was bootstrapped as a single node, to the
+	// settings used by controllers in HA.
+	PromoteToHA func(agent.Agent, *mgo.Session) error
+
+	// ResizeMongo sizes the mongo server of the agent's controller
+	// for its machine, according to the controller config.
+	ResizeMongo func(agent.Agent, controller.Config) error
 }
 
 // Validate validates the manifold configuration.
@@ -47,6 +58,12 @@ func (config ManifoldConfig) Validate() error {
 	if config.NewWorker == nil {
 		return errors.NotValidf("nil NewWorker")
 	}
+	if config.PromoteToHA == nil {
+		return errors.NotValidf("nil PromoteToHA")
+	}
+	if config.ResizeMongo == nil {
+		return errors.NotValidf("nil ResizeMongo")
+	}
 	return nil
 }
 
@@ -109,6 +126,16 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		SupportsSpaces:     supportsSpaces,
 		MongoPort:          stateServingInfo.StatePort,
 		APIPort:            stateServingInfo.APIPort,
+		PromoteToHA: func() error {
+			return config.PromoteToHA(agent, mongoSession)
+		},
+		ResizeMongo: func() error {
+			controllerConfig, err := st.ControllerConfig()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			return config.ResizeMongo(agent, controllerConfig)
+		},
 	})
 	if err != nil {
 		stTracker.Done()
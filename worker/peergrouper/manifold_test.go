@@ -11,9 +11,11 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	worker "gopkg.in/juju/worker.v1"
+	"gopkg.in/mgo.v2"
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/worker/dependency"
 	dt "github.com/juju/juju/worker/dependency/testing"
@@ -67,9 +69,21 @@ func (s *ManifoldSuite) SetUpTest(c *gc.C) {
 			}
 			return true, nil
 		},
+		PromoteToHA: s.promoteToHA,
+		ResizeMongo: s.resizeMongo,
 	})
 }
 
+func (s *ManifoldSuite) resizeMongo(a agent.Agent, controllerConfig controller.Config) error {
+	s.stub.MethodCall(s, "ResizeMongo", a, controllerConfig)
+	return s.stub.NextErr()
+}
+
+func (s *ManifoldSuite) promoteToHA(a agent.Agent, session *mgo.Session) error {
+	s.stub.MethodCall(s, "PromoteToHA", a, session)
+	return s.stub.NextErr()
+}
+
 func (s *ManifoldSuite) newContext(overlay map[string]interface{}) dependency.Context {
 	resources := map[string]interface{}{
 		"agent": s.agent,
@@ -118,6 +132,19 @@ func (s *ManifoldSuite) TestStart(c *gc.C) {
 	c.Assert(args[0], gc.FitsTypeOf, peergrouper.Config{})
 	config := args[0].(peergrouper.Config)
 
+	// PromoteToHA is bound to the agent.
+	c.Assert(config.PromoteToHA, gc.NotNil)
+	err := config.PromoteToHA()
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCallNames(c, "NewWorker", "PromoteToHA")
+	c.Assert(s.stub.Calls()[1].Args[0], gc.Equals, s.agent)
+	config.PromoteToHA = nil
+
+	// ResizeMongo reads the controller config from state, which the
+	// stub state cannot provide.
+	c.Assert(config.ResizeMongo, gc.NotNil)
+	config.ResizeMongo = nil
+
 	c.Assert(config, jc.DeepEquals, peergrouper.Config{
 		State:        peergrouper.StateShim{s.st},
 		MongoSession: peergrouper.MongoSessionShim{},
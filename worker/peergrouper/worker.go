@@ -115,6 +115,17 @@ type Config struct {
 	// and is used to publish the details of the
 	// API servers.
 	Hub Hub
+
+	// PromoteToHA, if non-nil, is called before the replica set is
+	// given more than one member, so that a controller bootstrapped
+	// as a single node can convert its mongo server to the settings
+	// used in HA.
+	PromoteToHA func() error
+
+	// ResizeMongo, if non-nil, is called each time the replica set
+	// is updated, so that the controller's mongo server is kept
+	// sized for the resources of its machine as they change.
+	ResizeMongo func() error
 }
 
 // Validate validates the worker configuration.
@@ -225,6 +236,13 @@ func (w *pgWorker) loop() error {
 			logger.Errorf("cannot set replicaset: %v", err)
 			failed = true
 		}
+		if w.config.ResizeMongo != nil {
+			// A mongo server that cannot be resized keeps running
+			// as it is, so this is not worth retrying sooner.
+			if err := w.config.ResizeMongo(); err != nil {
+				logger.Errorf("cannot resize mongo: %v", err)
+			}
+		}
 		if failed {
 			updateChan = w.config.Clock.After(retryInterval)
 			retryInterval = scaleRetry(retryInterval)
@@ -530,6 +548,11 @@ func (w *pgWorker) updateReplicaset() error {
 			removed = append(removed, m)
 		}
 	}
+	if len(members) > 1 && w.config.PromoteToHA != nil {
+		if err := w.config.PromoteToHA(); err != nil {
+			return errors.Annotate(err, "cannot promote controller to HA")
+		}
+	}
 	if err := setHasVote(added, true); err != nil {
 		return errors.Annotate(err, "cannot set HasVote added")
 	}
@@ -177,6 +177,105 @@ func (s *workerSuite) TestSetsAndUpdatesMembers(c *gc.C) {
 	})
 }
 
+func (s *workerSuite) TestPromotesToHABeforeAddingMembers(c *gc.C) {
+	st := NewFakeState()
+	InitState(c, st, 3, testIPv4)
+	memberWatcher := st.session.members.Watch()
+	mustNext(c, memberWatcher)
+	assertMembers(c, memberWatcher.Value(), mkMembers("0v", testIPv4))
+
+	promoted := make(chan []replicaset.Member, 1)
+	w, err := New(Config{
+		Clock:              s.clock,
+		State:              st,
+		MongoSession:       st.session,
+		APIHostPortsSetter: nopAPIHostPortsSetter{},
+		MongoPort:          mongoPort,
+		APIPort:            apiPort,
+		Hub:                s.hub,
+		PromoteToHA: func() error {
+			members, err := st.session.CurrentMembers()
+			c.Check(err, jc.ErrorIsNil)
+			promoted <- members
+			return nil
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case members := <-promoted:
+		// The controller is promoted while it is still the only member.
+		assertMembers(c, members, mkMembers("0v", testIPv4))
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for promotion")
+	}
+	mustNext(c, memberWatcher)
+	assertMembers(c, memberWatcher.Value(), mkMembers("0v 1 2", testIPv4))
+}
+
+func (s *workerSuite) TestPromoteToHAErrorPreventsAddingMembers(c *gc.C) {
+	st := NewFakeState()
+	InitState(c, st, 3, testIPv4)
+
+	w, err := New(Config{
+		Clock:              s.clock,
+		State:              st,
+		MongoSession:       st.session,
+		APIHostPortsSetter: nopAPIHostPortsSetter{},
+		MongoPort:          mongoPort,
+		APIPort:            apiPort,
+		Hub:                s.hub,
+		PromoteToHA: func() error {
+			return errors.New("no room")
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "cannot promote controller to HA: no room")
+
+	members, err := st.session.CurrentMembers()
+	c.Assert(err, jc.ErrorIsNil)
+	assertMembers(c, members, mkMembers("0v", testIPv4))
+}
+
+func (s *workerSuite) TestResizesMongo(c *gc.C) {
+	st := NewFakeState()
+	InitState(c, st, 3, testIPv4)
+
+	resized := make(chan struct{}, 1)
+	w, err := New(Config{
+		Clock:              s.clock,
+		State:              st,
+		MongoSession:       st.session,
+		APIHostPortsSetter: nopAPIHostPortsSetter{},
+		MongoPort:          mongoPort,
+		APIPort:            apiPort,
+		Hub:                s.hub,
+		ResizeMongo: func() error {
+			select {
+			case resized <- struct{}{}:
+			default:
+			}
+			return errors.New("no room")
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-resized:
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for resize")
+		}
+		// Failing to resize mongo is not fatal, and it is tried
+		// again when the replica set is next updated.
+		workertest.CheckAlive(c, w)
+		s.clock.WaitAdvance(pollInterval, coretesting.ShortWait, 1)
+	}
+}
+
 func (s *workerSuite) TestHasVoteMaintainedEvenWhenReplicaSetFails(c *gc.C) {
 	DoTestForIPv4AndIPv6(c, s, func(ipVersion TestIPVersion) {
 		st := NewFakeState()
@@ -4,6 +4,7 @@
 package provisioner_test
 
 import (
+	"context"
 	"io/ioutil"
 	"net"
 	"path/filepath"
@@ -209,7 +210,7 @@ func makeNoOpStatusCallback() func(settableStatus status.Status, info string, da
 }
 
 func callStartInstance(c *gc.C, s patcher, broker environs.InstanceBroker, machineId string) (*environs.StartInstanceResult, error) {
-	return broker.StartInstance(environs.StartInstanceParams{
+	return broker.StartInstance(context.Background(), environs.StartInstanceParams{
 		Constraints:    constraints.Value{},
 		Tools:          makePossibleTools(),
 		InstanceConfig: makeInstanceConfig(c, s, machineId),
@@ -4,6 +4,8 @@
 package provisioner
 
 import (
+	"context"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
@@ -50,7 +52,7 @@ type kvmBroker struct {
 }
 
 // StartInstance is specified in the Broker interface.
-func (broker *kvmBroker) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+func (broker *kvmBroker) StartInstance(ctx context.Context, args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
 	// TODO: refactor common code out of the container brokers.
 	containerMachineID := args.InstanceConfig.MachineId
 	kvmLogger.Infof("starting kvm container for containerMachineID: %s", containerMachineID)
@@ -165,7 +167,7 @@ func (broker *kvmBroker) MaintainInstance(args environs.StartInstanceParams) err
 }
 
 // StopInstances shuts down the given instances.
-func (broker *kvmBroker) StopInstances(ids ...instance.Id) error {
+func (broker *kvmBroker) StopInstances(ctx context.Context, ids ...instance.Id) error {
 	// TODO: potentially parallelise.
 	for _, id := range ids {
 		kvmLogger.Infof("stopping kvm container for instance: %s", id)
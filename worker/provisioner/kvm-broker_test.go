@@ -4,6 +4,7 @@
 package provisioner_test
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"runtime"
@@ -159,13 +160,13 @@ func (s *kvmBrokerSuite) TestStopInstance(c *gc.C) {
 	result2, err2 := s.startInstance(c, broker, "1/kvm/2")
 	c.Assert(err2, jc.ErrorIsNil)
 
-	err := broker.StopInstances(result0.Instance.Id())
+	err := broker.StopInstances(context.Background(), result0.Instance.Id())
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertResults(c, broker, result1, result2)
 	c.Assert(s.kvmContainerDir(result0), jc.DoesNotExist)
 	c.Assert(s.kvmRemovedContainerDir(result0), jc.IsDirectory)
 
-	err = broker.StopInstances(result1.Instance.Id(), result2.Instance.Id())
+	err = broker.StopInstances(context.Background(), result1.Instance.Id(), result2.Instance.Id())
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertNoResults(c, broker)
 }
@@ -181,7 +182,7 @@ func (s *kvmBrokerSuite) TestAllInstances(c *gc.C) {
 	c.Assert(err1, jc.ErrorIsNil)
 	s.assertResults(c, broker, result0, result1)
 
-	err := broker.StopInstances(result1.Instance.Id())
+	err := broker.StopInstances(context.Background(), result1.Instance.Id())
 	c.Assert(err, jc.ErrorIsNil)
 	result2, err2 := s.startInstance(c, broker, "1/kvm/2")
 	c.Assert(err2, jc.ErrorIsNil)
@@ -4,6 +4,9 @@
 package provisioner
 
 import (
+	"context"
+	"sort"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
@@ -11,6 +14,7 @@ import (
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/container"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 )
@@ -50,7 +54,7 @@ type lxdBroker struct {
 	agentConfig agent.Config
 }
 
-func (broker *lxdBroker) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+func (broker *lxdBroker) StartInstance(ctx context.Context, args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
 	containerMachineID := args.InstanceConfig.MachineId
 
 	config, err := broker.api.ContainerConfig()
@@ -119,6 +123,14 @@ func (broker *lxdBroker) StartInstance(args environs.StartInstanceParams) (*envi
 		return nil, err
 	}
 
+	if len(args.CharmLXDProfiles) > 0 {
+		profileNames, err := broker.writeCharmProfiles(args.CharmLXDProfiles)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		args.InstanceConfig.CharmLXDProfiles = profileNames
+	}
+
 	storageConfig := &container.StorageConfig{}
 	inst, hardware, err := broker.manager.CreateContainer(
 		args.InstanceConfig, args.Constraints,
@@ -135,7 +147,44 @@ func (broker *lxdBroker) StartInstance(args environs.StartInstanceParams) (*envi
 	}, nil
 }
 
-func (broker *lxdBroker) StopInstances(ids ...instance.Id) error {
+// writeCharmProfiles creates or updates the supplied charm LXD
+// profiles, and returns their names in order.
+func (broker *lxdBroker) writeCharmProfiles(profiles map[string]lxdprofile.Profile) ([]string, error) {
+	profileManager, ok := broker.manager.(container.LXDProfileManager)
+	if !ok {
+		return nil, errors.NotSupportedf("charm lxd profiles")
+	}
+	profileNames := make([]string, 0, len(profiles))
+	for name := range profiles {
+		profileNames = append(profileNames, name)
+	}
+	sort.Strings(profileNames)
+	for _, name := range profileNames {
+		if err := profileManager.MaybeWriteLXDProfile(name, profiles[name]); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return profileNames, nil
+}
+
+// UpdateCharmProfiles is part of the CharmProfileUpdater interface.
+func (broker *lxdBroker) UpdateCharmProfiles(
+	id instance.Id,
+	applied []string,
+	profiles map[string]lxdprofile.Profile,
+) ([]string, error) {
+	profileNames, err := broker.writeCharmProfiles(profiles)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	profileManager := broker.manager.(container.LXDProfileManager)
+	if err := profileManager.ReplaceLXDProfiles(id, applied, profileNames); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return profileNames, nil
+}
+
+func (broker *lxdBroker) StopInstances(ctx context.Context, ids ...instance.Id) error {
 	// TODO: potentially parallelise.
 	for _, id := range ids {
 		lxdLogger.Infof("stopping lxd container for instance: %s", id)
@@ -4,6 +4,7 @@
 package provisioner_test
 
 import (
+	"context"
 	"runtime"
 
 	"github.com/juju/errors"
@@ -20,6 +21,7 @@ import (
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/container"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
@@ -142,7 +144,7 @@ func (s *lxdBrokerSuite) TestStartInstanceNoHostArchTools(c *gc.C) {
 	broker, brokerErr := s.newLXDBroker(c)
 	c.Assert(brokerErr, jc.ErrorIsNil)
 
-	_, err := broker.StartInstance(environs.StartInstanceParams{
+	_, err := broker.StartInstance(context.Background(), environs.StartInstanceParams{
 		Tools: coretools.List{{
 			// non-host-arch tools should be filtered out by StartInstance
 			Version: version.MustParseBinary("2.3.4-quantal-arm64"),
@@ -153,6 +155,52 @@ func (s *lxdBrokerSuite) TestStartInstanceNoHostArchTools(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `need agent binaries for arch amd64, only found \[arm64\]`)
 }
 
+func (s *lxdBrokerSuite) TestStartInstanceWithCharmProfiles(c *gc.C) {
+	broker, brokerErr := s.newLXDBroker(c)
+	c.Assert(brokerErr, jc.ErrorIsNil)
+
+	profiles := map[string]lxdprofile.Profile{
+		"juju-model-sriov-1": {Config: map[string]string{"security.nesting": "true"}},
+		"juju-model-dpdk-3":  {Devices: map[string]map[string]string{"hugepages": {"type": "disk"}}},
+	}
+	_, err := broker.StartInstance(context.Background(), environs.StartInstanceParams{
+		Tools:            makePossibleTools(),
+		InstanceConfig:   makeInstanceConfig(c, s, "1/lxd/0"),
+		StatusCallback:   makeNoOpStatusCallback(),
+		CharmLXDProfiles: profiles,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.manager.CheckCallNames(c, "MaybeWriteLXDProfile", "MaybeWriteLXDProfile", "CreateContainer")
+	s.manager.CheckCall(c, 0, "MaybeWriteLXDProfile", "juju-model-dpdk-3", profiles["juju-model-dpdk-3"])
+	s.manager.CheckCall(c, 1, "MaybeWriteLXDProfile", "juju-model-sriov-1", profiles["juju-model-sriov-1"])
+	instanceConfig := s.manager.Calls()[2].Args[0].(*instancecfg.InstanceConfig)
+	c.Assert(instanceConfig.CharmLXDProfiles, jc.DeepEquals, []string{"juju-model-dpdk-3", "juju-model-sriov-1"})
+}
+
+func (s *lxdBrokerSuite) TestUpdateCharmProfiles(c *gc.C) {
+	broker, brokerErr := s.newLXDBroker(c)
+	c.Assert(brokerErr, jc.ErrorIsNil)
+	updater, ok := broker.(provisioner.CharmProfileUpdater)
+	c.Assert(ok, jc.IsTrue)
+
+	profile := lxdprofile.Profile{Config: map[string]string{"security.nesting": "true"}}
+	applied, err := updater.UpdateCharmProfiles(
+		"juju-06f00d-1-lxd-0",
+		[]string{"juju-model-sriov-1"},
+		map[string]lxdprofile.Profile{"juju-model-sriov-2": profile},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(applied, jc.DeepEquals, []string{"juju-model-sriov-2"})
+	s.manager.CheckCalls(c, []gitjujutesting.StubCall{
+		{"MaybeWriteLXDProfile", []interface{}{"juju-model-sriov-2", profile}},
+		{"ReplaceLXDProfiles", []interface{}{
+			instance.Id("juju-06f00d-1-lxd-0"),
+			[]string{"juju-model-sriov-1"},
+			[]string{"juju-model-sriov-2"},
+		}},
+	})
+}
+
 type fakeContainerManager struct {
 	gitjujutesting.Stub
 }
@@ -183,6 +231,16 @@ func (m *fakeContainerManager) Namespace() instance.Namespace {
 	return ns
 }
 
+func (m *fakeContainerManager) MaybeWriteLXDProfile(name string, profile lxdprofile.Profile) error {
+	m.MethodCall(m, "MaybeWriteLXDProfile", name, profile)
+	return m.NextErr()
+}
+
+func (m *fakeContainerManager) ReplaceLXDProfiles(id instance.Id, remove, add []string) error {
+	m.MethodCall(m, "ReplaceLXDProfiles", id, remove, add)
+	return m.NextErr()
+}
+
 func (m *fakeContainerManager) IsInitialized() bool {
 	m.MethodCall(m, "IsInitialized")
 	m.PopNoErr()
@@ -4,6 +4,7 @@
 package provisioner
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"strings"
@@ -20,10 +21,12 @@ import (
 	apiprovisioner "github.com/juju/juju/api/provisioner"
 	"github.com/juju/juju/apiserver/common/networkingcommon"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cloudconfig/cloudinit"
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/controller/authentication"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/imagemetadata"
@@ -50,6 +53,20 @@ type ProvisionerTask interface {
 	SetHarvestMode(mode config.HarvestMode)
 }
 
+// CharmProfileUpdater is implemented by brokers that can update the
+// charm LXD profiles applied to their instances.
+type CharmProfileUpdater interface {
+	// UpdateCharmProfiles writes the supplied charm LXD profiles and
+	// applies them to the instance in place of those previously
+	// applied, returning the names of the profiles now applied.
+	UpdateCharmProfiles(id instance.Id, applied []string, profiles map[string]lxdprofile.Profile) ([]string, error)
+}
+
+// charmProfilesCheckInterval is how often the provisioner task checks
+// whether the charm LXD profiles of its machines need updating, such as
+// after a charm upgrade.
+var charmProfilesCheckInterval = time.Minute
+
 type MachineGetter interface {
 	Machines(...names.MachineTag) ([]apiprovisioner.MachineResult, error)
 	MachinesWithTransientErrors() ([]apiprovisioner.MachineStatusResult, error)
@@ -167,6 +184,18 @@ func (task *provisionerTask) loop() error {
 	// When the watcher is started, it will have the initial changes be all
 	// the machines that are relevant. Also, since this is available straight
 	// away, we know there will be some changes right off the bat.
+	//
+	// Provider operations are made with a context that is cancelled
+	// when the task is killed, so that they are abandoned rather than
+	// holding up shutdown.
+	ctx := task.catacomb.Context(context.Background())
+
+	// Only brokers for LXD containers apply charm profiles.
+	profileUpdater, _ := task.broker.(CharmProfileUpdater)
+	var charmProfilesCheck <-chan time.Time
+	if profileUpdater != nil {
+		charmProfilesCheck = time.After(charmProfilesCheckInterval)
+	}
 	for {
 		select {
 		case <-task.catacomb.Dying():
@@ -176,7 +205,7 @@ func (task *provisionerTask) loop() error {
 			if !ok {
 				return errors.New("machine watcher closed channel")
 			}
-			if err := task.processMachines(ids); err != nil {
+			if err := task.processMachines(ctx, ids); err != nil {
 				return errors.Annotate(err, "failed to process updated machines")
 			}
 			// We've seen a set of changes. Enable modification of
@@ -190,18 +219,74 @@ func (task *provisionerTask) loop() error {
 			task.harvestMode = harvestMode
 			if harvestMode.HarvestUnknown() {
 				logger.Infof("harvesting unknown machines")
-				if err := task.processMachines(nil); err != nil {
+				if err := task.processMachines(ctx, nil); err != nil {
 					return errors.Annotate(err, "failed to process machines after safe mode disabled")
 				}
 			}
 		case <-task.retryChanges:
-			if err := task.processMachinesWithTransientErrors(); err != nil {
+			if err := task.processMachinesWithTransientErrors(ctx); err != nil {
 				return errors.Annotate(err, "failed to process machines with transient errors")
 			}
+		case <-charmProfilesCheck:
+			charmProfilesCheck = time.After(charmProfilesCheckInterval)
+			if err := task.updateCharmProfiles(profileUpdater); errors.IsNotSupported(err) {
+				logger.Infof("not updating charm profiles: %v", err)
+				charmProfilesCheck = nil
+			}
 		}
 	}
 }
 
+// updateCharmProfiles applies any changes to the charm LXD profiles
+// that the provisioned machines should have, such as those following a
+// charm upgrade. Failures for individual machines are logged, and the
+// machines retried at the next check. An error satisfying
+// errors.IsNotSupported is returned if the controller cannot report
+// charm profiles.
+func (task *provisionerTask) updateCharmProfiles(updater CharmProfileUpdater) error {
+	for _, machine := range task.machines {
+		err := task.updateMachineCharmProfiles(updater, machine)
+		if errors.IsNotSupported(err) {
+			return err
+		} else if err != nil {
+			logger.Errorf("cannot update charm profiles of machine %s: %v", machine, err)
+		}
+	}
+	return nil
+}
+
+func (task *provisionerTask) updateMachineCharmProfiles(updater CharmProfileUpdater, machine *apiprovisioner.Machine) error {
+	if machine.Life() == params.Dead {
+		return nil
+	}
+	profiles, applied, err := machine.CharmProfiles()
+	if params.IsCodeNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	wanted := set.NewStrings()
+	for name := range profiles {
+		wanted.Add(name)
+	}
+	current := set.NewStrings(applied...)
+	if wanted.Difference(current).IsEmpty() && current.Difference(wanted).IsEmpty() {
+		return nil
+	}
+	instanceId, err := machine.InstanceId()
+	if params.IsCodeNotProvisioned(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	profileNames, err := updater.UpdateCharmProfiles(instanceId, applied, profiles)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("applied charm profiles %v to machine %s", profileNames, machine)
+	return errors.Trace(machine.SetCharmProfiles(profileNames))
+}
+
 // SetHarvestMode implements ProvisionerTask.SetHarvestMode().
 func (task *provisionerTask) SetHarvestMode(mode config.HarvestMode) {
 	select {
@@ -210,7 +295,7 @@ func (task *provisionerTask) SetHarvestMode(mode config.HarvestMode) {
 	}
 }
 
-func (task *provisionerTask) processMachinesWithTransientErrors() error {
+func (task *provisionerTask) processMachinesWithTransientErrors(ctx context.Context) error {
 	results, err := task.machineGetter.MachinesWithTransientErrors()
 	if err != nil {
 		return nil
@@ -234,10 +319,10 @@ func (task *provisionerTask) processMachinesWithTransientErrors() error {
 		task.machines[machine.Tag().String()] = machine
 		pending = append(pending, machine)
 	}
-	return task.startMachines(pending)
+	return task.startMachines(ctx, pending)
 }
 
-func (task *provisionerTask) processMachines(ids []string) error {
+func (task *provisionerTask) processMachines(ctx context.Context, ids []string) error {
 	logger.Tracef("processMachines(%v)", ids)
 
 	// Populate the tasks maps of current instances and machines.
@@ -288,7 +373,7 @@ func (task *provisionerTask) processMachines(ids []string) error {
 	// pending ones, because if we start an instance and then fail to
 	// set its InstanceId on the machine we don't want to start a new
 	// instance for the same machine ID.
-	if err := task.stopInstances(append(stopping, unknown...)); err != nil {
+	if err := task.stopInstances(ctx, append(stopping, unknown...)); err != nil {
 		return err
 	}
 
@@ -306,7 +391,7 @@ func (task *provisionerTask) processMachines(ids []string) error {
 	task.maintainMachines(maintain)
 
 	// Start an instance for the pending ones
-	return task.startMachines(pending)
+	return task.startMachines(ctx, pending)
 }
 
 func instanceIds(instances []instance.Instance) []string {
@@ -504,7 +589,7 @@ func (task *provisionerTask) instancesForDeadMachines(deadMachines []*apiprovisi
 	return instances
 }
 
-func (task *provisionerTask) stopInstances(instances []instance.Instance) error {
+func (task *provisionerTask) stopInstances(ctx context.Context, instances []instance.Instance) error {
 	// Although calling StopInstance with an empty slice should produce no change in the
 	// provider, environs like dummy do not consider this a noop.
 	if len(instances) == 0 {
@@ -518,7 +603,7 @@ func (task *provisionerTask) stopInstances(instances []instance.Instance) error
 	for i, inst := range instances {
 		ids[i] = inst.Id()
 	}
-	if err := task.broker.StopInstances(ids...); err != nil {
+	if err := task.broker.StopInstances(ctx, ids...); err != nil {
 		return errors.Annotate(err, "broker failed to stop instances")
 	}
 	return nil
@@ -557,6 +642,13 @@ func (task *provisionerTask) constructInstanceConfig(
 	}
 
 	instanceConfig.Tags = pInfo.Tags
+	if pInfo.CloudInitUserData != "" {
+		userData, err := cloudinit.ParseUserData(pInfo.CloudInitUserData)
+		if err != nil {
+			return nil, errors.Annotate(err, "invalid cloud-init user data")
+		}
+		instanceConfig.CloudInitUserData = userData
+	}
 	if len(pInfo.Jobs) > 0 {
 		instanceConfig.Jobs = pInfo.Jobs
 	}
@@ -693,6 +785,7 @@ func (task *provisionerTask) constructStartInstanceParams(
 		EndpointBindings:  endpointBindings,
 		ImageMetadata:     possibleImageMetadata,
 		StatusCallback:    machine.SetInstanceStatus,
+		CharmLXDProfiles:  apiprovisioner.CharmProfilesFromParams(provisioningInfo.CharmLXDProfiles),
 	}
 
 	return startInstanceParams, nil
@@ -871,7 +964,7 @@ func (b byPopulationThenNames) Swap(i, j int) {
 
 // startMachines starts a goroutine for each specified machine to
 // start it.  Errors from individual start machine attempts will be logged.
-func (task *provisionerTask) startMachines(machines []*apiprovisioner.Machine) error {
+func (task *provisionerTask) startMachines(ctx context.Context, machines []*apiprovisioner.Machine) error {
 	if len(machines) == 0 {
 		return nil
 	}
@@ -901,7 +994,7 @@ func (task *provisionerTask) startMachines(machines []*apiprovisioner.Machine) e
 		wg.Add(1)
 		go func(machine *apiprovisioner.Machine, dg []string, index int) {
 			defer wg.Done()
-			if err := task.startMachine(machine, dg); err != nil {
+			if err := task.startMachine(ctx, machine, dg); err != nil {
 				task.removeMachineFromAZMap(machine)
 				errMachines[index] = err
 			}
@@ -1009,6 +1102,7 @@ func (task *provisionerTask) populateExcludedMachines(machineId string, startIns
 }
 
 func (task *provisionerTask) startMachine(
+	ctx context.Context,
 	machine *apiprovisioner.Machine,
 	distributionGroupMachineIds []string,
 ) error {
@@ -1052,10 +1146,15 @@ func (task *provisionerTask) startMachine(
 			logger.Infof("trying machine %s StartInstance in availability zone %s", machine, startInstanceParams.AvailabilityZone)
 		}
 
-		attemptResult, err := task.broker.StartInstance(startInstanceParams)
+		attemptResult, err := task.broker.StartInstance(ctx, startInstanceParams)
 		if err == nil {
 			result = attemptResult
 			break
+		} else if ctx.Err() != nil {
+			// The task is being killed; the machine will be
+			// provisioned when the provisioner restarts.
+			logger.Infof("abandoned starting machine %s: %v", machine, err)
+			return task.catacomb.ErrDying()
 		} else if attemptsLeft <= 0 {
 			// Set the state to error, so the machine will be skipped
 			// next time until the error is resolved.
@@ -1117,6 +1216,7 @@ func (task *provisionerTask) startMachine(
 
 	if err := machine.SetInstanceInfo(
 		result.Instance.Id(),
+		result.DisplayName,
 		startInstanceParams.InstanceConfig.MachineNonce,
 		result.Hardware,
 		networkConfig,
@@ -1127,12 +1227,22 @@ func (task *provisionerTask) startMachine(
 		if err2 := task.setErrorStatus("cannot register instance for machine %v: %v", machine, err); err2 != nil {
 			logger.Errorf("%v", errors.Annotate(err2, "cannot set machine's status"))
 		}
-		if err2 := task.broker.StopInstances(result.Instance.Id()); err2 != nil {
+		// The instance must be stopped even if the task is being
+		// killed, lest it be leaked, so ctx is not used here.
+		if err2 := task.broker.StopInstances(context.Background(), result.Instance.Id()); err2 != nil {
 			logger.Errorf("%v", errors.Annotate(err2, "after failing to set instance info"))
 		}
 		return errors.Annotate(err, "cannot set instance info")
 	}
 
+	if len(startInstanceParams.InstanceConfig.CharmLXDProfiles) > 0 {
+		// Record the profiles applied, so they are reported in status
+		// and replaced when they change.
+		if err := machine.SetCharmProfiles(startInstanceParams.InstanceConfig.CharmLXDProfiles); err != nil {
+			logger.Errorf("cannot record charm profiles of machine %s: %v", machine, err)
+		}
+	}
+
 	logger.Infof(
 		"started machine %s as instance %s with hardware %q, network config %+v, volumes %v, volume attachments %v, subnets to zones %v",
 		machine,
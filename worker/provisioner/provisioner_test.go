@@ -4,6 +4,7 @@
 package provisioner_test
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"sync"
@@ -1885,8 +1886,8 @@ type mockNoZonedEnvironBroker struct {
 	environs.Environ
 }
 
-func (b *mockNoZonedEnvironBroker) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
-	return b.Environ.StartInstance(args)
+func (b *mockNoZonedEnvironBroker) StartInstance(ctx context.Context, args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+	return b.Environ.StartInstance(ctx, args)
 }
 
 type mockBroker struct {
@@ -1903,7 +1904,7 @@ type mockBrokerFailures struct {
 	whenSucceed int
 }
 
-func (b *mockBroker) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+func (b *mockBroker) StartInstance(ctx context.Context, args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
 	// All machines are provisioned successfully the first time unless
 	// mock.startInstanceFailureInfo is configured.
 	//
@@ -1918,7 +1919,7 @@ func (b *mockBroker) StartInstance(args environs.StartInstanceParams) (*environs
 		returnError = failureInfo.err
 	}
 	if retries == whenSucceed {
-		return b.Environ.StartInstance(args)
+		return b.Environ.StartInstance(ctx, args)
 	} else {
 		b.retryCount[id] = retries + 1
 	}
@@ -21,6 +21,7 @@ type ManifoldConfig struct {
 	WorkerFunc      func(Config) (worker.Worker, error)
 	ExternalUpdate  func(proxy.Settings) error
 	InProcessUpdate func(proxy.Settings) error
+	RunFunc         func(string, ...string) (string, error)
 }
 
 // Manifold returns a dependency manifold that runs a proxy updater worker,
@@ -59,6 +60,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				API:             proxyAPI,
 				ExternalUpdate:  config.ExternalUpdate,
 				InProcessUpdate: config.InProcessUpdate,
+				RunFunc:         config.RunFunc,
 			})
 			if err != nil {
 				return nil, errors.Trace(err)
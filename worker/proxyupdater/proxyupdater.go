@@ -6,6 +6,8 @@ package proxyupdater
 import (
 	"fmt"
 	"io/ioutil"
+	osexec "os/exec"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -17,6 +19,7 @@ import (
 	"github.com/juju/utils/series"
 	worker "gopkg.in/juju/worker.v1"
 
+	"github.com/juju/juju/api/proxyupdater"
 	"github.com/juju/juju/watcher"
 )
 
@@ -31,13 +34,30 @@ type Config struct {
 	API             API
 	ExternalUpdate  func(proxyutils.Settings) error
 	InProcessUpdate func(proxyutils.Settings) error
+
+	// RunFunc, if not nil, is used to run the commands that configure
+	// snapd's proxy settings. See RunCommand.
+	RunFunc func(name string, args ...string) (string, error)
 }
 
 // API is an interface that is provided to New
 // which can be used to fetch the API host ports
 type API interface {
-	ProxyConfig() (proxyutils.Settings, proxyutils.Settings, error)
+	ProxyConfig() (proxyupdater.ProxyConfiguration, error)
 	WatchForProxyConfigAndAPIHostPortChanges() (watcher.NotifyWatcher, error)
+	SetProxyStatus(applyErr error) error
+}
+
+// RunCommand runs the named command with the given arguments, returning
+// its combined output. If the command is not installed, an error
+// satisfying errors.IsNotFound is returned.
+func RunCommand(name string, args ...string) (string, error) {
+	path, err := osexec.LookPath(name)
+	if err != nil {
+		return "", errors.NotFoundf("command %q", name)
+	}
+	output, err := osexec.Command(path, args...).CombinedOutput()
+	return string(output), err
 }
 
 // proxyWorker is responsible for monitoring the juju environment
@@ -46,8 +66,10 @@ type API interface {
 // changes are apt proxy configuration and the juju proxies stored in the juju
 // proxy file.
 type proxyWorker struct {
-	aptProxy proxyutils.Settings
-	proxy    proxyutils.Settings
+	aptProxy         proxyutils.Settings
+	proxy            proxyutils.Settings
+	snapProxy        proxyutils.Settings
+	snapStoreProxyId string
 
 	// The whole point of the first value is to make sure that the the files
 	// are written out the first time through, even if they are the same as
@@ -82,19 +104,22 @@ func (w *proxyWorker) saveProxySettingsToFiles() error {
 	// - /etc/juju-proxy.conf - in 'env' format
 	// - /etc/systemd/system.conf.d/juju-proxy.conf
 	// - /etc/systemd/user.conf.d/juju-proxy.conf - both in 'systemd' format
+	var saveErr error
 	for _, file := range w.config.EnvFiles {
 		err := ioutil.WriteFile(file, []byte(w.proxy.AsScriptEnvironment()), 0644)
 		if err != nil {
 			logger.Errorf("Error updating environment file %s - %v", file, err)
+			saveErr = errors.Annotatef(err, "updating environment file %s", file)
 		}
 	}
 	for _, file := range w.config.SystemdFiles {
 		err := ioutil.WriteFile(file, []byte(w.proxy.AsSystemdDefaultEnv()), 0644)
 		if err != nil {
 			logger.Errorf("Error updating systemd file - %v", err)
+			saveErr = errors.Annotatef(err, "updating systemd file %s", file)
 		}
 	}
-	return nil
+	return saveErr
 }
 
 func (w *proxyWorker) saveProxySettingsToRegistry() error {
@@ -135,25 +160,32 @@ func (w *proxyWorker) saveProxySettings() error {
 	}
 }
 
-func (w *proxyWorker) handleProxyValues(proxySettings proxyutils.Settings) {
+// handleProxyValues applies the proxy settings to the process and the
+// machine, returning an error if they could not all be applied to the
+// machine.
+func (w *proxyWorker) handleProxyValues(proxySettings proxyutils.Settings) error {
 	proxySettings.SetEnvironmentValues()
 	if err := w.config.InProcessUpdate(proxySettings); err != nil {
 		logger.Errorf("error updating in-process proxy settings: %v", err)
 	}
+	var applyErr error
 	if proxySettings != w.proxy || w.first {
 		logger.Debugf("new proxy settings %#v", proxySettings)
 		w.proxy = proxySettings
 		if err := w.saveProxySettings(); err != nil {
 			// It isn't really fatal, but we should record it.
 			logger.Errorf("error saving proxy settings: %v", err)
+			applyErr = errors.Annotate(err, "saving proxy settings")
 		}
 		if externalFunc := w.config.ExternalUpdate; externalFunc != nil {
 			if err := externalFunc(proxySettings); err != nil {
 				// It isn't really fatal, but we should record it.
 				logger.Errorf("%v", err)
+				applyErr = err
 			}
 		}
 	}
+	return applyErr
 }
 
 // getPackageCommander is a helper function which returns the
@@ -171,7 +203,8 @@ func (w *proxyWorker) handleAptProxyValues(aptSettings proxyutils.Settings) erro
 		logger.Debugf("new apt proxy settings %#v", aptSettings)
 		paccmder, err := getPackageCommander()
 		if err != nil {
-			return err
+			logger.Errorf("cannot configure apt proxy: %v", err)
+			return errors.Annotate(err, "configuring apt proxy")
 		}
 		w.aptProxy = aptSettings
 
@@ -181,19 +214,72 @@ func (w *proxyWorker) handleAptProxyValues(aptSettings proxyutils.Settings) erro
 		if err != nil {
 			// It isn't really fatal, but we should record it.
 			logger.Errorf("error writing apt proxy config file: %v", err)
+			return errors.Annotate(err, "writing apt proxy config file")
 		}
 	}
 	return nil
 }
 
+// handleSnapProxyValues configures snapd to use the given proxy
+// settings and store proxy. Machines without snapd are left alone.
+func (w *proxyWorker) handleSnapProxyValues(snapSettings proxyutils.Settings, storeProxyId string) error {
+	if w.config.RunFunc == nil {
+		return nil
+	}
+	if snapSettings == w.snapProxy && storeProxyId == w.snapStoreProxyId && !w.first {
+		return nil
+	}
+	logger.Debugf("new snap proxy settings %#v, store proxy %q", snapSettings, storeProxyId)
+	output, err := w.config.RunFunc("snap", "set", "system",
+		"proxy.http="+snapSettings.Http,
+		"proxy.https="+snapSettings.Https,
+	)
+	if errors.IsNotFound(err) {
+		logger.Debugf("snapd is not installed, not configuring its proxies")
+		return nil
+	} else if err != nil {
+		logger.Errorf("cannot set snap proxy settings: %v (%s)", err, output)
+		return errors.Annotate(err, "setting snap proxy settings")
+	}
+	output, err = w.config.RunFunc("snap", "set", "core", "proxy.store="+storeProxyId)
+	if err != nil {
+		logger.Errorf("cannot set snap store proxy: %v (%s)", err, output)
+		return errors.Annotate(err, "setting snap store proxy")
+	}
+	w.snapProxy = snapSettings
+	w.snapStoreProxyId = storeProxyId
+	return nil
+}
+
 func (w *proxyWorker) onChange() error {
-	proxySettings, APTProxySettings, err := w.config.API.ProxyConfig()
+	proxyConfig, err := w.config.API.ProxyConfig()
 	if err != nil {
 		return err
 	}
 
-	w.handleProxyValues(proxySettings)
-	return w.handleAptProxyValues(APTProxySettings)
+	// Failing to apply some of the settings shouldn't stop the rest
+	// being applied, so the failures are collected and reported to the
+	// controller together.
+	var failures []string
+	if err := w.handleProxyValues(proxyConfig.Proxy); err != nil {
+		failures = append(failures, err.Error())
+	}
+	if err := w.handleAptProxyValues(proxyConfig.APTProxy); err != nil {
+		failures = append(failures, err.Error())
+	}
+	if err := w.handleSnapProxyValues(proxyConfig.SnapProxy, proxyConfig.SnapStoreProxyId); err != nil {
+		failures = append(failures, err.Error())
+	}
+	var applyErr error
+	if len(failures) > 0 {
+		applyErr = errors.New(strings.Join(failures, "; "))
+	}
+	if err := w.config.API.SetProxyStatus(applyErr); err != nil {
+		// The settings have been applied regardless, so we just
+		// record that the controller doesn't know.
+		logger.Errorf("cannot record proxy status: %v", err)
+	}
+	return nil
 }
 
 // SetUp is defined on the worker.NotifyWatchHandler interface.
@@ -23,6 +23,7 @@ import (
 	gc "gopkg.in/check.v1"
 	worker "gopkg.in/juju/worker.v1"
 
+	apiproxyupdater "github.com/juju/juju/api/proxyupdater"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker/proxyupdater"
@@ -55,20 +56,32 @@ func (w notAWatcher) Changes() watcher.NotifyChannel {
 }
 
 type fakeAPI struct {
-	Proxy    proxyutils.Settings
-	APTProxy proxyutils.Settings
-	Err      error
-	Watcher  *notAWatcher
+	Proxy          proxyutils.Settings
+	APTProxy       proxyutils.Settings
+	SnapProxy      proxyutils.Settings
+	SnapStoreProxy string
+	Err            error
+	Watcher        *notAWatcher
+	proxyStatus    chan error
 }
 
 func NewFakeAPI() *fakeAPI {
-	f := &fakeAPI{}
+	f := &fakeAPI{proxyStatus: make(chan error, 1000)}
 	return f
 }
 
-func (api fakeAPI) ProxyConfig() (proxyutils.Settings, proxyutils.Settings, error) {
-	return api.Proxy, api.APTProxy, api.Err
+func (api fakeAPI) ProxyConfig() (apiproxyupdater.ProxyConfiguration, error) {
+	return apiproxyupdater.ProxyConfiguration{
+		Proxy:            api.Proxy,
+		APTProxy:         api.APTProxy,
+		SnapProxy:        api.SnapProxy,
+		SnapStoreProxyId: api.SnapStoreProxy,
+	}, api.Err
+}
 
+func (api fakeAPI) SetProxyStatus(applyErr error) error {
+	api.proxyStatus <- applyErr
+	return nil
 }
 
 func (api fakeAPI) WatchForProxyConfigAndAPIHostPortChanges() (watcher.NotifyWatcher, error) {
@@ -309,3 +322,67 @@ func (s *ProxyUpdaterSuite) TestErrorSettingInProcessLogs(c *gc.C) {
 	}
 	c.Assert(foundMessage, jc.IsTrue)
 }
+
+func (s *ProxyUpdaterSuite) waitProxyStatus(c *gc.C) error {
+	select {
+	case err := <-s.api.proxyStatus:
+		return err
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timeout while waiting for proxy status")
+	}
+	return nil
+}
+
+func (s *ProxyUpdaterSuite) TestSnapProxySettings(c *gc.C) {
+	s.updateConfig(c)
+	s.api.SnapProxy = proxy.Settings{
+		Http:  "http://snap-proxy",
+		Https: "https://snap-proxy",
+	}
+	s.api.SnapStoreProxy = "store-id"
+	var commands [][]string
+	s.config.RunFunc = func(name string, args ...string) (string, error) {
+		commands = append(commands, append([]string{name}, args...))
+		return "", nil
+	}
+
+	updater, err := proxyupdater.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer worker.Stop(updater)
+
+	c.Assert(s.waitProxyStatus(c), jc.ErrorIsNil)
+	c.Assert(commands, jc.DeepEquals, [][]string{
+		{"snap", "set", "system", "proxy.http=http://snap-proxy", "proxy.https=https://snap-proxy"},
+		{"snap", "set", "core", "proxy.store=store-id"},
+	})
+}
+
+func (s *ProxyUpdaterSuite) TestSnapNotInstalled(c *gc.C) {
+	s.updateConfig(c)
+	s.config.RunFunc = func(name string, args ...string) (string, error) {
+		return "", errors.NotFoundf("command %q", name)
+	}
+
+	updater, err := proxyupdater.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer worker.Stop(updater)
+
+	c.Assert(s.waitProxyStatus(c), jc.ErrorIsNil)
+}
+
+func (s *ProxyUpdaterSuite) TestProxyStatusReportsFailures(c *gc.C) {
+	s.updateConfig(c)
+	s.config.RunFunc = func(name string, args ...string) (string, error) {
+		return "error: snapd is not running", errors.New("exit status 1")
+	}
+	s.config.ExternalUpdate = func(proxy.Settings) error {
+		return errors.New("cannot configure lxd")
+	}
+
+	updater, err := proxyupdater.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer worker.Stop(updater)
+
+	err = s.waitProxyStatus(c)
+	c.Assert(err, gc.ErrorMatches, "cannot configure lxd; setting snap proxy settings: exit status 1")
+}
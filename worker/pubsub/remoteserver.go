@@ -26,6 +26,7 @@ import (
 type RemoteServer interface {
 	worker.Worker
 	Reporter
+	TargetStatus() forwarder.TargetStatus
 	UpdateAddresses(addresses []string)
 	Publish(message *params.PubSubMessage)
 }
@@ -118,6 +119,23 @@ func (r *remoteServer) IntrospectionReport() string {
 		status, r.info.Addrs, r.pending.Len(), r.sent)
 }
 
+// TargetStatus returns the status of forwarding messages to the target
+// API server.
+func (r *remoteServer) TargetStatus() forwarder.TargetStatus {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	addresses := make([]string, len(r.info.Addrs))
+	copy(addresses, r.info.Addrs)
+	return forwarder.TargetStatus{
+		Target:      r.target,
+		Connected:   r.connection != nil,
+		Addresses:   addresses,
+		QueueLength: r.pending.Len(),
+		SentCount:   r.sent,
+	}
+}
+
 func (r *remoteServer) onForwarderConnection(topic string, details forwarder.OriginTarget, err error) {
 	if err != nil {
 		// This should never happen.
@@ -281,6 +281,18 @@ func (s *RemoteServerSuite) TestSendsMessages(c *gc.C) {
 	}
 }
 
+func (s *RemoteServerSuite) TestTargetStatusDisconnected(c *gc.C) {
+	s.connectionOpener.err = errors.New("oops")
+	server, err := psworker.NewRemoteServer(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, server)
+
+	c.Assert(server.TargetStatus(), jc.DeepEquals, forwarder.TargetStatus{
+		Target:    "target",
+		Addresses: []string{"localhost"},
+	})
+}
+
 func (s *RemoteServerSuite) writer() *messageWriter {
 	writer := s.connectionOpener.getWriter()
 	if writer == nil {
@@ -19,6 +19,7 @@ import (
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/pubsub/apiserver"
+	"github.com/juju/juju/pubsub/forwarder"
 	"github.com/juju/juju/worker/catacomb"
 )
 
@@ -74,6 +75,7 @@ type subscriber struct {
 
 	unsubAll           func()
 	unsubServerDetails func()
+	unsubStatusRequest func()
 
 	// servers represent connections to each of the other api servers.
 	servers map[string]RemoteServer
@@ -99,6 +101,11 @@ func newSubscriber(config WorkerConfig) (*subscriber, error) {
 		return nil, errors.Trace(err)
 	}
 	sub.unsubServerDetails = unsub
+	unsub, err = config.Hub.Subscribe(forwarder.StatusRequestTopic, sub.statusRequest)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sub.unsubStatusRequest = unsub
 
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &sub.catacomb,
@@ -133,12 +140,42 @@ func (s *subscriber) waitForDeath() error {
 	s.config.Logger.Tracef("wait for catacomb dying before unsubscribe")
 	defer s.unsubAll()
 	defer s.unsubServerDetails()
+	defer s.unsubStatusRequest()
 
 	<-s.catacomb.Dying()
 	s.config.Logger.Tracef("dying now")
 	return s.catacomb.ErrDying()
 }
 
+// statusRequest publishes the status of forwarding to each of the
+// other API servers, so that the pubsub topology can be inspected from
+// any API server.
+func (s *subscriber) statusRequest(topic string, request forwarder.StatusRequest, err error) {
+	if err != nil {
+		// This should never happen.
+		s.config.Logger.Errorf("subscriber callback error: %v", err)
+		return
+	}
+	status := forwarder.Status{
+		RequestID: request.RequestID,
+		Origin:    s.config.Origin,
+		Targets:   []forwarder.TargetStatus{},
+	}
+	s.mutex.Lock()
+	for _, remote := range s.servers {
+		status.Targets = append(status.Targets, remote.TargetStatus())
+	}
+	s.mutex.Unlock()
+	sort.Slice(status.Targets, func(i, j int) bool {
+		return status.Targets[i].Target < status.Targets[j].Target
+	})
+	// Publishing from within a handler must not block on the
+	// delivery of the message, so ignore the done channel.
+	if _, err := s.config.Hub.Publish(forwarder.StatusTopic, status); err != nil {
+		s.config.Logger.Errorf("cannot publish pubsub status: %v", err)
+	}
+}
+
 func (s *subscriber) apiServerChanges(topic string, details apiserver.Details, err error) {
 	s.config.Logger.Tracef("apiServerChanges: %#v", details)
 	// Make sure we have workers for the defined details.
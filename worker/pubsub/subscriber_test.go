@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/pubsub/apiserver"
 	"github.com/juju/juju/pubsub/centralhub"
+	"github.com/juju/juju/pubsub/forwarder"
 	coretesting "github.com/juju/juju/testing"
 	psworker "github.com/juju/juju/worker/pubsub"
 	"github.com/juju/juju/worker/workertest"
@@ -326,6 +327,40 @@ func (s *SubscriberSuite) TestIntrospectionReport(c *gc.C) {
 		"  Addresses: [10.1.2.5]\n")
 }
 
+func (s *SubscriberSuite) TestStatusRequest(c *gc.C) {
+	s.newHAWorker(c)
+
+	statuses := make(chan forwarder.Status, 1)
+	unsub, err := s.hub.Subscribe(forwarder.StatusTopic, func(topic string, status forwarder.Status, err error) {
+		c.Check(err, jc.ErrorIsNil)
+		statuses <- status
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer unsub()
+
+	_, err = s.hub.Publish(forwarder.StatusRequestTopic, forwarder.StatusRequest{RequestID: "123"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case status := <-statuses:
+		c.Assert(status, jc.DeepEquals, forwarder.Status{
+			RequestID: "123",
+			Origin:    "machine-42",
+			Targets: []forwarder.TargetStatus{{
+				Target:    "machine-3",
+				Connected: true,
+				Addresses: []string{"10.1.2.3"},
+			}, {
+				Target:    "machine-5",
+				Connected: true,
+				Addresses: []string{"10.1.2.5"},
+			}},
+		})
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("no status published")
+	}
+}
+
 var logger = loggo.GetLogger("workertest")
 
 type fakeRemoteTracker struct {
@@ -351,6 +386,14 @@ func (f *fakeRemote) IntrospectionReport() string {
 		f.config.APIInfo.Addrs)
 }
 
+func (f *fakeRemote) TargetStatus() forwarder.TargetStatus {
+	return forwarder.TargetStatus{
+		Target:    f.config.Target,
+		Connected: true,
+		Addresses: f.config.APIInfo.Addrs,
+	}
+}
+
 func (f *fakeRemote) Publish(message *params.PubSubMessage) {
 	logger.Debugf("fakeRemote.Publish %s to %s", message.Topic, f.config.Target)
 	f.messages = append(f.messages, message)
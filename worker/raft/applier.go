@@ -0,0 +1,48 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raft
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// ErrNotRunning is returned by Applier.Apply when no raft worker is
+// running.
+var ErrNotRunning = errors.New("raft worker not running")
+
+// Applier passes commands to the Node run by the current raft worker.
+// It lets code that lives longer than the worker, such as the lease
+// managers in the state package, replicate commands without depending
+// on the worker directly.
+type Applier struct {
+	mu   sync.Mutex
+	node *Node
+}
+
+// NewApplier returns an Applier that has no Node until a raft worker
+// using it starts.
+func NewApplier() *Applier {
+	return &Applier{}
+}
+
+// Apply replicates the command using the running worker's Node. It
+// returns ErrNotRunning if there is no such worker.
+func (a *Applier) Apply(command []byte, timeout time.Duration) error {
+	a.mu.Lock()
+	node := a.node
+	a.mu.Unlock()
+	if node == nil {
+		return ErrNotRunning
+	}
+	return node.Apply(command, timeout)
+}
+
+func (a *Applier) setNode(node *Node) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.node = node
+}
@@ -0,0 +1,106 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raft
+
+import (
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/pubsub"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+
+	coreagent "github.com/juju/juju/agent"
+	"github.com/juju/juju/worker/dependency"
+)
+
+// ManifoldConfig holds the information necessary to run a raft worker
+// in a dependency.Engine.
+type ManifoldConfig struct {
+	AgentName      string
+	ClockName      string
+	CentralHubName string
+
+	// FSM and Applier are created by the agent, rather than the
+	// worker, because the lease managers embedded in State outlive
+	// the worker.
+	FSM     FSM
+	Applier *Applier
+
+	NewWorker func(Config) (worker.Worker, error)
+}
+
+// Validate returns an error if the config is not valid.
+func (config ManifoldConfig) Validate() error {
+	if config.AgentName == "" {
+		return errors.NotValidf("empty AgentName")
+	}
+	if config.ClockName == "" {
+		return errors.NotValidf("empty ClockName")
+	}
+	if config.CentralHubName == "" {
+		return errors.NotValidf("empty CentralHubName")
+	}
+	if config.FSM == nil {
+		return errors.NotValidf("nil FSM")
+	}
+	if config.Applier == nil {
+		return errors.NotValidf("nil Applier")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// Manifold returns a dependency.Manifold that will run a raft worker.
+// The worker only runs on controller machines, as the central hub is
+// only available on them.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.AgentName,
+			config.ClockName,
+			config.CentralHubName,
+		},
+		Start: config.start,
+	}
+}
+
+// start is a method on ManifoldConfig because it's more readable than a closure.
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var agent coreagent.Agent
+	if err := context.Get(config.AgentName, &agent); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var clock clock.Clock
+	if err := context.Get(config.ClockName, &clock); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var hub *pubsub.StructuredHub
+	if err := context.Get(config.CentralHubName, &hub); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	agentConfig := agent.CurrentConfig()
+	storage, err := NewFileStorage(filepath.Join(agentConfig.DataDir(), "raft"))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return config.NewWorker(Config{
+		ID:                agentConfig.Tag().Id(),
+		Hub:               hub,
+		FSM:               config.FSM,
+		Applier:           config.Applier,
+		Storage:           storage,
+		Clock:             clock,
+		HeartbeatInterval: DefaultHeartbeatInterval,
+		ElectionTimeout:   DefaultElectionTimeout,
+		SnapshotThreshold: DefaultSnapshotThreshold,
+	})
+}
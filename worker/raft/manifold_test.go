@@ -0,0 +1,140 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raft_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/pubsub"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/worker/dependency"
+	dt "github.com/juju/juju/worker/dependency/testing"
+	"github.com/juju/juju/worker/raft"
+)
+
+type ManifoldSuite struct {
+	testing.IsolationSuite
+	stub    testing.Stub
+	config  raft.ManifoldConfig
+	dataDir string
+	hub     *pubsub.StructuredHub
+	worker  worker.Worker
+}
+
+var _ = gc.Suite(&ManifoldSuite{})
+
+func (s *ManifoldSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub.ResetCalls()
+	s.dataDir = c.MkDir()
+	s.hub = pubsub.NewStructuredHub(nil)
+	s.worker = &fakeWorker{}
+	s.config = raft.ManifoldConfig{
+		AgentName:      "agent",
+		ClockName:      "clock",
+		CentralHubName: "central-hub",
+		FSM:            &fakeFSM{},
+		Applier:        raft.NewApplier(),
+		NewWorker:      s.newWorker,
+	}
+}
+
+func (s *ManifoldSuite) newWorker(config raft.Config) (worker.Worker, error) {
+	s.stub.AddCall("NewWorker", config)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return s.worker, nil
+}
+
+func (s *ManifoldSuite) context() dependency.Context {
+	return dt.StubContext(nil, map[string]interface{}{
+		"agent":       &fakeAgent{dataDir: s.dataDir},
+		"clock":       clock.WallClock,
+		"central-hub": s.hub,
+	})
+}
+
+func (s *ManifoldSuite) TestInputs(c *gc.C) {
+	manifold := raft.Manifold(s.config)
+	c.Assert(manifold.Inputs, jc.SameContents, []string{"agent", "clock", "central-hub"})
+}
+
+func (s *ManifoldSuite) TestValidate(c *gc.C) {
+	s.config.Applier = nil
+	manifold := raft.Manifold(s.config)
+	_, err := manifold.Start(s.context())
+	c.Assert(err, gc.ErrorMatches, "nil Applier not valid")
+}
+
+func (s *ManifoldSuite) TestCentralHubMissing(c *gc.C) {
+	manifold := raft.Manifold(s.config)
+	_, err := manifold.Start(dt.StubContext(nil, map[string]interface{}{
+		"agent":       &fakeAgent{dataDir: s.dataDir},
+		"clock":       clock.WallClock,
+		"central-hub": dependency.ErrMissing,
+	}))
+	c.Assert(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+}
+
+func (s *ManifoldSuite) TestStart(c *gc.C) {
+	manifold := raft.Manifold(s.config)
+	w, err := manifold.Start(s.context())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w, gc.Equals, s.worker)
+
+	s.stub.CheckCallNames(c, "NewWorker")
+	config := s.stub.Calls()[0].Args[0].(raft.Config)
+	c.Assert(config.Storage, gc.NotNil)
+	config.Storage = nil
+	c.Assert(config, jc.DeepEquals, raft.Config{
+		ID:                "42",
+		Hub:               s.hub,
+		FSM:               s.config.FSM,
+		Applier:           s.config.Applier,
+		Clock:             clock.WallClock,
+		HeartbeatInterval: raft.DefaultHeartbeatInterval,
+		ElectionTimeout:   raft.DefaultElectionTimeout,
+		SnapshotThreshold: raft.DefaultSnapshotThreshold,
+	})
+
+	info, err := os.Stat(filepath.Join(s.dataDir, "raft"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.IsDir(), jc.IsTrue)
+}
+
+type fakeWorker struct {
+	worker.Worker
+}
+
+type fakeAgent struct {
+	agent.Agent
+	dataDir string
+}
+
+func (a *fakeAgent) CurrentConfig() agent.Config {
+	return &fakeConfig{dataDir: a.dataDir}
+}
+
+type fakeConfig struct {
+	agent.Config
+	dataDir string
+}
+
+func (c *fakeConfig) DataDir() string {
+	return c.dataDir
+}
+
+func (c *fakeConfig) Tag() names.Tag {
+	return names.NewMachineTag("42")
+}
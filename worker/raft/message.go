@@ -0,0 +1,109 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raft
+
+// Topic is the central hub topic on which raft messages are exchanged
+// between the controller machines.
+const Topic = "raft.message"
+
+const (
+	// MsgVote asks the recipient to vote for the sender.
+	MsgVote = "vote"
+
+	// MsgVoteResponse answers a vote request.
+	MsgVoteResponse = "vote-response"
+
+	// MsgAppend replicates log entries from the leader, and doubles
+	// as the leader's heartbeat.
+	MsgAppend = "append"
+
+	// MsgAppendResponse answers an append or snapshot message.
+	MsgAppendResponse = "append-response"
+
+	// MsgSnapshot replaces the recipient's state with the leader's
+	// snapshot, when the recipient is too far behind to catch up
+	// from the leader's log.
+	MsgSnapshot = "snapshot"
+
+	// MsgApply asks the leader to append a command to the log on
+	// behalf of a follower.
+	MsgApply = "apply"
+
+	// MsgApplyResponse tells a follower whether the leader accepted
+	// a forwarded command.
+	MsgApplyResponse = "apply-response"
+)
+
+// Entry is a single entry in the replicated log.
+type Entry struct {
+	// Index is the position of the entry in the log.
+	Index uint64 `yaml:"index" json:"index"`
+
+	// Term is the term in which the leader appended the entry.
+	Term uint64 `yaml:"term" json:"term"`
+
+	// ID identifies the Apply call that proposed the entry, so the
+	// proposing node can return the result once the entry has been
+	// applied to its FSM. It is empty for the leader's no-op
+	// entries.
+	ID string `yaml:"id,omitempty" json:"id,omitempty"`
+
+	// Command is the serialised command, as passed to FSM.Apply.
+	Command string `yaml:"command,omitempty" json:"command,omitempty"`
+}
+
+// Snapshot holds the state of the FSM after applying every entry up
+// to and including Index.
+type Snapshot struct {
+	// Index is the index of the last entry included in the snapshot.
+	Index uint64 `yaml:"index" json:"index"`
+
+	// Term is the term of the last entry included in the snapshot.
+	Term uint64 `yaml:"term" json:"term"`
+
+	// Data is the serialised FSM state, as passed to FSM.Restore.
+	Data string `yaml:"data" json:"data"`
+}
+
+// Message is the unit of communication between raft nodes. Every
+// message is published to every controller, so recipients ignore
+// messages not addressed to them.
+type Message struct {
+	Type string `yaml:"type"`
+	From string `yaml:"from"`
+	To   string `yaml:"to"`
+	Term uint64 `yaml:"term"`
+
+	// LastIndex and LastTerm describe the end of a vote candidate's
+	// log.
+	LastIndex uint64 `yaml:"last-index,omitempty"`
+	LastTerm  uint64 `yaml:"last-term,omitempty"`
+
+	// Granted reports whether a vote was granted.
+	Granted bool `yaml:"granted,omitempty"`
+
+	// PrevIndex and PrevTerm identify the entry immediately before
+	// those in Entries, which the recipient's log must hold for the
+	// entries to be appended.
+	PrevIndex uint64  `yaml:"prev-index,omitempty"`
+	PrevTerm  uint64  `yaml:"prev-term,omitempty"`
+	Entries   []Entry `yaml:"entries,omitempty"`
+	Commit    uint64  `yaml:"commit,omitempty"`
+
+	// Success reports whether an append or snapshot was accepted.
+	// If it was, Match is the index of the last entry known to be
+	// replicated; if not, Match is the index of the last entry in the
+	// recipient's log, from which the leader should try again.
+	Success bool   `yaml:"success,omitempty"`
+	Match   uint64 `yaml:"match,omitempty"`
+
+	// Snapshot is sent in place of entries the leader no longer has.
+	Snapshot *Snapshot `yaml:"snapshot,omitempty"`
+
+	// ID and Command describe a command forwarded to the leader,
+	// and Accepted reports whether the leader appended it.
+	ID       string `yaml:"id,omitempty"`
+	Command  string `yaml:"command,omitempty"`
+	Accepted bool   `yaml:"accepted,omitempty"`
+}
@@ -0,0 +1,954 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raft
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.raft")
+
+var (
+	// ErrTimeout is returned by Apply if the command is not applied
+	// to the local FSM within the timeout.
+	ErrTimeout = errors.New("timed out waiting for command to be applied")
+
+	// ErrStopped is returned by Apply if the node is stopped before
+	// the command is applied.
+	ErrStopped = errors.New("raft node stopped")
+)
+
+// FSM is the state machine whose commands are replicated by a Node.
+// Applying a command must depend only on the FSM's state and the
+// command itself, so that every node's FSM ends up in the same state.
+type FSM interface {
+	// Apply applies a replicated command, returning the result to
+	// pass back to the node that proposed it.
+	Apply(command []byte) error
+
+	// Snapshot returns the serialised state of the FSM.
+	Snapshot() ([]byte, error)
+
+	// Restore replaces the FSM's state with that in the snapshot.
+	// An empty snapshot restores the FSM to its initial state.
+	Restore(snapshot []byte) error
+}
+
+// NodeConfig holds the resources and configuration needed to run a
+// Node.
+type NodeConfig struct {
+	// ID identifies the node among its peers.
+	ID string
+
+	// FSM is the state machine to which committed commands are
+	// applied.
+	FSM FSM
+
+	// Storage persists the node's log and state.
+	Storage Storage
+
+	// Clock is used for election and heartbeat timing.
+	Clock clock.Clock
+
+	// Send delivers a message to the peer identified by its To
+	// field. Delivery may fail, or messages be reordered, without
+	// harm.
+	Send func(Message) error
+
+	// HeartbeatInterval is how often the leader sends entries, or
+	// empty heartbeats, to its followers.
+	HeartbeatInterval time.Duration
+
+	// ElectionTimeout is the minimum time a follower waits to hear
+	// from a leader before standing for election. The actual
+	// timeout is randomised between this and twice this.
+	ElectionTimeout time.Duration
+
+	// SnapshotThreshold is the number of applied log entries after
+	// which the log is compacted into a snapshot.
+	SnapshotThreshold uint64
+}
+
+// Validate returns an error if the config is not valid.
+func (config NodeConfig) Validate() error {
+	if config.ID == "" {
+		return errors.NotValidf("empty ID")
+	}
+	if config.FSM == nil {
+		return errors.NotValidf("nil FSM")
+	}
+	if config.Storage == nil {
+		return errors.NotValidf("nil Storage")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Send == nil {
+		return errors.NotValidf("nil Send")
+	}
+	if config.HeartbeatInterval <= 0 {
+		return errors.NotValidf("non-positive HeartbeatInterval")
+	}
+	if config.ElectionTimeout <= config.HeartbeatInterval {
+		return errors.NotValidf("ElectionTimeout not greater than HeartbeatInterval")
+	}
+	if config.SnapshotThreshold == 0 {
+		return errors.NotValidf("zero SnapshotThreshold")
+	}
+	return nil
+}
+
+type role string
+
+const (
+	follower  role = "follower"
+	candidate role = "candidate"
+	leader    role = "leader"
+)
+
+// maxAppendEntries limits the number of entries sent in one append
+// message.
+const maxAppendEntries = 64
+
+// Node is a worker that takes part in the raft consensus algorithm,
+// replicating the commands passed to Apply, on any node, to every
+// node's FSM in the same order.
+//
+// A Node's peers are not agreed through the log, as the raft paper
+// describes, but set with SetPeers; in practice they are the
+// controller machines, which change rarely and never all at once.
+type Node struct {
+	config   NodeConfig
+	catacomb catacomb.Catacomb
+	rand     *rand.Rand
+
+	requests chan *applyRequest
+	messages chan Message
+	peers    chan []string
+
+	mu     sync.Mutex
+	status nodeStatus
+
+	// The remaining fields are only used by the loop goroutine.
+	hardState        HardState
+	snapshot         Snapshot
+	log              []Entry
+	commit           uint64
+	applied          uint64
+	role             role
+	leader           string
+	votes            set.Strings
+	next             map[string]uint64
+	match            map[string]uint64
+	electionDeadline time.Time
+	pending          map[string]*applyRequest
+}
+
+type nodeStatus struct {
+	role    role
+	leader  string
+	term    uint64
+	commit  uint64
+	applied uint64
+	peers   []string
+}
+
+type applyRequest struct {
+	id          string
+	command     []byte
+	deadline    time.Time
+	forwardedTo string
+	accepted    bool
+	result      chan error
+}
+
+// NewNode returns a Node that restores its state from the configured
+// storage, and runs until killed.
+func NewNode(config NodeConfig) (*Node, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	n := &Node{
+		config:   config,
+		rand:     rand.New(rand.NewSource(randomSeed(config.ID))),
+		requests: make(chan *applyRequest),
+		messages: make(chan Message),
+		peers:    make(chan []string),
+		role:     follower,
+		pending:  make(map[string]*applyRequest),
+	}
+	if err := n.load(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &n.catacomb,
+		Work: n.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return n, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (n *Node) Kill() {
+	n.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (n *Node) Wait() error {
+	return n.catacomb.Wait()
+}
+
+// Apply replicates the command to a quorum of nodes, and returns the
+// result of applying it to this node's FSM. The command is proposed
+// by the leader, to which it is forwarded if this node isn't leader.
+func (n *Node) Apply(command []byte, timeout time.Duration) error {
+	timer := n.config.Clock.After(timeout)
+	req := &applyRequest{
+		command:  command,
+		deadline: n.config.Clock.Now().Add(timeout),
+		result:   make(chan error, 1),
+	}
+	select {
+	case n.requests <- req:
+	case <-n.catacomb.Dying():
+		return ErrStopped
+	case <-timer:
+		return ErrTimeout
+	}
+	select {
+	case err := <-req.result:
+		return err
+	case <-n.catacomb.Dying():
+		return ErrStopped
+	case <-timer:
+		return ErrTimeout
+	}
+}
+
+// Receive passes a message from a peer to the node.
+func (n *Node) Receive(m Message) {
+	select {
+	case n.messages <- m:
+	case <-n.catacomb.Dying():
+	}
+}
+
+// SetPeers sets the IDs of the nodes, including this one, that take
+// part in the cluster.
+func (n *Node) SetPeers(peers []string) {
+	select {
+	case n.peers <- peers:
+	case <-n.catacomb.Dying():
+	}
+}
+
+// Leader returns the ID of the node this node believes to be leader,
+// or "" if it doesn't know of one.
+func (n *Node) Leader() string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.status.leader
+}
+
+// Report returns the node's status, for the engine report.
+func (n *Node) Report() map[string]interface{} {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return map[string]interface{}{
+		"role":    string(n.status.role),
+		"leader":  n.status.leader,
+		"term":    n.status.term,
+		"commit":  n.status.commit,
+		"applied": n.status.applied,
+		"peers":   n.status.peers,
+	}
+}
+
+func (n *Node) load() error {
+	hardState, snapshot, entries, err := n.config.Storage.Load()
+	if err != nil {
+		return errors.Annotate(err, "loading raft state")
+	}
+	n.hardState = hardState
+	var data []byte
+	if snapshot != nil {
+		n.snapshot = *snapshot
+		data = []byte(snapshot.Data)
+	}
+	// The FSM may have been used by an earlier node, so it must be
+	// reset even if there is no snapshot.
+	if err := n.config.FSM.Restore(data); err != nil {
+		return errors.Annotate(err, "restoring snapshot")
+	}
+	n.log = entries
+	n.commit = n.snapshot.Index
+	n.applied = n.snapshot.Index
+	n.resetElectionDeadline()
+	n.updateStatus()
+	return nil
+}
+
+func (n *Node) loop() error {
+	tick := n.config.Clock.After(n.config.HeartbeatInterval)
+	for {
+		var err error
+		select {
+		case <-n.catacomb.Dying():
+			return n.catacomb.ErrDying()
+		case <-tick:
+			tick = n.config.Clock.After(n.config.HeartbeatInterval)
+			err = n.tick()
+		case m := <-n.messages:
+			err = n.step(m)
+		case req := <-n.requests:
+			err = n.proposeAll(req)
+		case peers := <-n.peers:
+			err = n.setPeers(peers)
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+		n.updateStatus()
+	}
+}
+
+func (n *Node) updateStatus() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.status = nodeStatus{
+		role:    n.role,
+		leader:  n.leader,
+		term:    n.hardState.Term,
+		commit:  n.commit,
+		applied: n.applied,
+		peers:   n.hardState.Peers,
+	}
+}
+
+func (n *Node) tick() error {
+	now := n.config.Clock.Now()
+	for id, req := range n.pending {
+		switch {
+		case now.After(req.deadline):
+			// The caller has given up waiting.
+			delete(n.pending, id)
+		case !req.accepted && req.forwardedTo != n.leader:
+			if err := n.dispatch(req); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	if n.role == leader {
+		n.broadcastAppend()
+		return nil
+	}
+	if n.isPeer(n.config.ID) && now.After(n.electionDeadline) {
+		return errors.Trace(n.campaign())
+	}
+	return nil
+}
+
+// proposeAll proposes the request, and any others already waiting,
+// before replicating them together.
+func (n *Node) proposeAll(req *applyRequest) error {
+	for {
+		if err := n.propose(req); err != nil {
+			return errors.Trace(err)
+		}
+		select {
+		case req = <-n.requests:
+			continue
+		default:
+		}
+		break
+	}
+	if n.role == leader {
+		n.broadcastAppend()
+		n.maybeCommit()
+	}
+	return nil
+}
+
+func (n *Node) propose(req *applyRequest) error {
+	id, err := utils.NewUUID()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.id = id.String()
+	n.pending[req.id] = req
+	return errors.Trace(n.dispatch(req))
+}
+
+// dispatch appends the request to the log if this node is leader, or
+// forwards it to the leader if there is one. Requests that can't be
+// dispatched yet are retried on the next tick.
+func (n *Node) dispatch(req *applyRequest) error {
+	switch {
+	case n.role == leader:
+		if err := n.appendCommand(req.id, string(req.command)); err != nil {
+			return errors.Trace(err)
+		}
+		req.accepted = true
+	case n.leader != "":
+		req.forwardedTo = n.leader
+		n.send(Message{
+			Type:    MsgApply,
+			To:      n.leader,
+			ID:      req.id,
+			Command: string(req.command),
+		})
+	}
+	return nil
+}
+
+func (n *Node) appendCommand(id, command string) error {
+	return errors.Trace(n.appendEntries([]Entry{{
+		Index:   n.lastIndex() + 1,
+		Term:    n.hardState.Term,
+		ID:      id,
+		Command: command,
+	}}))
+}
+
+func (n *Node) step(m Message) error {
+	if m.To != n.config.ID || m.From == n.config.ID {
+		return nil
+	}
+	if m.Term > n.hardState.Term {
+		if err := n.becomeFollower(m.Term); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	switch m.Type {
+	case MsgVote:
+		return errors.Trace(n.handleVote(m))
+	case MsgVoteResponse:
+		return errors.Trace(n.handleVoteResponse(m))
+	case MsgAppend:
+		return errors.Trace(n.handleAppend(m))
+	case MsgSnapshot:
+		return errors.Trace(n.handleSnapshot(m))
+	case MsgAppendResponse:
+		n.handleAppendResponse(m)
+	case MsgApply:
+		return errors.Trace(n.handleApply(m))
+	case MsgApplyResponse:
+		n.handleApplyResponse(m)
+	default:
+		logger.Warningf("ignoring unknown raft message type %q from %q", m.Type, m.From)
+	}
+	return nil
+}
+
+func (n *Node) becomeFollower(term uint64) error {
+	if term > n.hardState.Term {
+		n.hardState.Term = term
+		n.hardState.VotedFor = ""
+		if err := n.saveHardState(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	n.role = follower
+	n.leader = ""
+	n.next, n.match = nil, nil
+	return nil
+}
+
+func (n *Node) campaign() error {
+	n.hardState.Term++
+	n.hardState.VotedFor = n.config.ID
+	if err := n.saveHardState(); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Debugf("%s standing for election in term %d", n.config.ID, n.hardState.Term)
+	n.role = candidate
+	n.leader = ""
+	n.votes = set.NewStrings(n.config.ID)
+	n.resetElectionDeadline()
+	if n.votes.Size() >= n.quorum() {
+		return errors.Trace(n.becomeLeader())
+	}
+	for _, peer := range n.otherPeers() {
+		n.send(Message{
+			Type:      MsgVote,
+			To:        peer,
+			LastIndex: n.lastIndex(),
+			LastTerm:  n.lastTerm(),
+		})
+	}
+	return nil
+}
+
+func (n *Node) becomeLeader() error {
+	logger.Infof("%s elected leader in term %d", n.config.ID, n.hardState.Term)
+	n.role = leader
+	n.leader = n.config.ID
+	n.next = make(map[string]uint64)
+	n.match = make(map[string]uint64)
+	for _, peer := range n.otherPeers() {
+		n.next[peer] = n.lastIndex() + 1
+	}
+	// Entries from earlier terms can only be committed along with
+	// one from the current term, so start the term with a no-op.
+	if err := n.appendEntries([]Entry{{
+		Index: n.lastIndex() + 1,
+		Term:  n.hardState.Term,
+	}}); err != nil {
+		return errors.Trace(err)
+	}
+	for _, req := range n.pending {
+		if !req.accepted {
+			if err := n.dispatch(req); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	n.broadcastAppend()
+	n.maybeCommit()
+	return nil
+}
+
+func (n *Node) handleVote(m Message) error {
+	granted := false
+	if m.Term == n.hardState.Term &&
+		(n.hardState.VotedFor == "" || n.hardState.VotedFor == m.From) &&
+		n.upToDate(m.LastIndex, m.LastTerm) {
+		granted = true
+		if n.hardState.VotedFor == "" {
+			n.hardState.VotedFor = m.From
+			if err := n.saveHardState(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		n.resetElectionDeadline()
+	}
+	n.send(Message{
+		Type:    MsgVoteResponse,
+		To:      m.From,
+		Granted: granted,
+	})
+	return nil
+}
+
+// upToDate reports whether a log ending at the given index and term
+// is at least as up to date as this node's log.
+func (n *Node) upToDate(lastIndex, lastTerm uint64) bool {
+	if lastTerm != n.lastTerm() {
+		return lastTerm > n.lastTerm()
+	}
+	return lastIndex >= n.lastIndex()
+}
+
+func (n *Node) handleVoteResponse(m Message) error {
+	if n.role != candidate || m.Term != n.hardState.Term || !m.Granted || !n.isPeer(m.From) {
+		return nil
+	}
+	n.votes.Add(m.From)
+	if n.votes.Size() >= n.quorum() {
+		return errors.Trace(n.becomeLeader())
+	}
+	return nil
+}
+
+func (n *Node) handleAppend(m Message) error {
+	response := Message{
+		Type: MsgAppendResponse,
+		To:   m.From,
+	}
+	if m.Term < n.hardState.Term {
+		response.Match = n.lastIndex()
+		n.send(response)
+		return nil
+	}
+	n.followLeader(m.From)
+
+	prevIndex, entries := m.PrevIndex, m.Entries
+	if prevIndex < n.snapshot.Index {
+		// Entries up to the snapshot are committed, and so must
+		// match those the leader sent.
+		skip := n.snapshot.Index - prevIndex
+		if skip >= uint64(len(entries)) {
+			entries = nil
+		} else {
+			entries = entries[skip:]
+		}
+		prevIndex = n.snapshot.Index
+	} else if term, ok := n.termAt(prevIndex); !ok || term != m.PrevTerm {
+		response.Match = n.lastIndex()
+		if ok && prevIndex-1 < response.Match {
+			response.Match = prevIndex - 1
+		}
+		n.send(response)
+		return nil
+	}
+
+	for i, entry := range entries {
+		if entry.Index <= n.lastIndex() {
+			if term, _ := n.termAt(entry.Index); term == entry.Term {
+				continue
+			}
+			if entry.Index <= n.commit {
+				return errors.Errorf(
+					"leader %q conflicts with committed entry %d", m.From, entry.Index,
+				)
+			}
+			n.log = n.log[:entry.Index-n.snapshot.Index-1]
+			if err := n.config.Storage.SetEntries(n.log); err != nil {
+				return errors.Annotate(err, "truncating log")
+			}
+		}
+		if err := n.appendEntries(entries[i:]); err != nil {
+			return errors.Trace(err)
+		}
+		break
+	}
+
+	match := prevIndex + uint64(len(entries))
+	if commit := minIndex(m.Commit, match); commit > n.commit {
+		n.commit = commit
+		if err := n.applyCommitted(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	response.Success = true
+	response.Match = match
+	n.send(response)
+	return nil
+}
+
+func (n *Node) handleSnapshot(m Message) error {
+	response := Message{
+		Type: MsgAppendResponse,
+		To:   m.From,
+	}
+	if m.Term < n.hardState.Term || m.Snapshot == nil {
+		response.Match = n.lastIndex()
+		n.send(response)
+		return nil
+	}
+	n.followLeader(m.From)
+
+	snapshot := *m.Snapshot
+	if snapshot.Index > n.commit {
+		// Keep any entries following the snapshot, if our log
+		// agrees with it.
+		var entries []Entry
+		if term, ok := n.termAt(snapshot.Index); ok && term == snapshot.Term {
+			entries = n.log[snapshot.Index-n.snapshot.Index:]
+		}
+		if err := n.config.FSM.Restore([]byte(snapshot.Data)); err != nil {
+			return errors.Annotate(err, "restoring snapshot")
+		}
+		if err := n.config.Storage.SetSnapshot(snapshot, entries); err != nil {
+			return errors.Annotate(err, "saving snapshot")
+		}
+		logger.Debugf("%s restored snapshot at index %d", n.config.ID, snapshot.Index)
+		n.snapshot = snapshot
+		n.log = entries
+		n.commit = snapshot.Index
+		n.applied = snapshot.Index
+	}
+	response.Success = true
+	response.Match = snapshot.Index
+	n.send(response)
+	return nil
+}
+
+func (n *Node) followLeader(id string) {
+	n.role = follower
+	n.leader = id
+	n.resetElectionDeadline()
+}
+
+func (n *Node) handleAppendResponse(m Message) {
+	if n.role != leader || m.Term != n.hardState.Term || !n.isPeer(m.From) {
+		return
+	}
+	if m.Success {
+		if m.Match > n.match[m.From] {
+			n.match[m.From] = m.Match
+		}
+		if m.Match+1 > n.next[m.From] {
+			n.next[m.From] = m.Match + 1
+		}
+		n.maybeCommit()
+		if n.next[m.From] <= n.lastIndex() {
+			n.sendAppend(m.From)
+		}
+		return
+	}
+	// Step back to the end of the follower's log, but never before
+	// the entries we know it has.
+	next := m.Match + 1
+	if next < n.match[m.From]+1 {
+		next = n.match[m.From] + 1
+	}
+	if next < n.next[m.From] {
+		n.next[m.From] = next
+	}
+	n.sendAppend(m.From)
+}
+
+func (n *Node) handleApply(m Message) error {
+	response := Message{
+		Type: MsgApplyResponse,
+		To:   m.From,
+		ID:   m.ID,
+	}
+	if n.role == leader {
+		if err := n.appendCommand(m.ID, m.Command); err != nil {
+			return errors.Trace(err)
+		}
+		n.broadcastAppend()
+		n.maybeCommit()
+		response.Accepted = true
+	}
+	n.send(response)
+	return nil
+}
+
+func (n *Node) handleApplyResponse(m Message) {
+	req, ok := n.pending[m.ID]
+	if !ok || req.forwardedTo != m.From {
+		return
+	}
+	if m.Accepted {
+		req.accepted = true
+	} else {
+		// Try again once we know who the leader is.
+		req.forwardedTo = ""
+	}
+}
+
+func (n *Node) broadcastAppend() {
+	for _, peer := range n.otherPeers() {
+		n.sendAppend(peer)
+	}
+}
+
+func (n *Node) sendAppend(to string) {
+	next, ok := n.next[to]
+	if !ok {
+		next = n.lastIndex() + 1
+		n.next[to] = next
+	}
+	if next <= n.snapshot.Index {
+		snapshot := n.snapshot
+		n.send(Message{
+			Type:     MsgSnapshot,
+			To:       to,
+			Snapshot: &snapshot,
+		})
+		return
+	}
+	prevTerm, _ := n.termAt(next - 1)
+	start := next - n.snapshot.Index - 1
+	end := uint64(len(n.log))
+	if end > start+maxAppendEntries {
+		end = start + maxAppendEntries
+	}
+	n.send(Message{
+		Type:      MsgAppend,
+		To:        to,
+		PrevIndex: next - 1,
+		PrevTerm:  prevTerm,
+		Entries:   n.log[start:end],
+		Commit:    n.commit,
+	})
+}
+
+// maybeCommit commits the latest entry from the current term that a
+// quorum of peers has replicated, along with every entry before it.
+func (n *Node) maybeCommit() {
+	if n.role != leader {
+		return
+	}
+	for index := n.lastIndex(); index > n.commit; index-- {
+		if term, _ := n.termAt(index); term != n.hardState.Term {
+			return
+		}
+		count := 0
+		if n.isPeer(n.config.ID) {
+			count++
+		}
+		for _, peer := range n.otherPeers() {
+			if n.match[peer] >= index {
+				count++
+			}
+		}
+		if count >= n.quorum() {
+			n.commit = index
+			if err := n.applyCommitted(); err != nil {
+				n.catacomb.Kill(err)
+			}
+			return
+		}
+	}
+}
+
+func (n *Node) applyCommitted() error {
+	for n.applied < n.commit {
+		entry := n.log[n.applied-n.snapshot.Index]
+		var err error
+		if entry.Command != "" {
+			err = n.config.FSM.Apply([]byte(entry.Command))
+		}
+		n.applied = entry.Index
+		if req, ok := n.pending[entry.ID]; ok && entry.ID != "" {
+			req.result <- err
+			delete(n.pending, entry.ID)
+		}
+	}
+	return errors.Trace(n.maybeSnapshot())
+}
+
+func (n *Node) maybeSnapshot() error {
+	if n.applied-n.snapshot.Index < n.config.SnapshotThreshold {
+		return nil
+	}
+	data, err := n.config.FSM.Snapshot()
+	if err != nil {
+		return errors.Annotate(err, "taking snapshot")
+	}
+	term, _ := n.termAt(n.applied)
+	snapshot := Snapshot{
+		Index: n.applied,
+		Term:  term,
+		Data:  string(data),
+	}
+	entries := append([]Entry(nil), n.log[n.applied-n.snapshot.Index:]...)
+	if err := n.config.Storage.SetSnapshot(snapshot, entries); err != nil {
+		return errors.Annotate(err, "saving snapshot")
+	}
+	n.snapshot = snapshot
+	n.log = entries
+	return nil
+}
+
+func (n *Node) setPeers(peers []string) error {
+	peers = set.NewStrings(peers...).SortedValues()
+	if equalStrings(peers, n.hardState.Peers) {
+		return nil
+	}
+	logger.Infof("%s peers changed to %v", n.config.ID, peers)
+	n.hardState.Peers = peers
+	if err := n.saveHardState(); err != nil {
+		return errors.Trace(err)
+	}
+	if n.role == leader {
+		n.maybeCommit()
+	}
+	return nil
+}
+
+func (n *Node) appendEntries(entries []Entry) error {
+	if err := n.config.Storage.Append(entries); err != nil {
+		return errors.Annotate(err, "appending to log")
+	}
+	n.log = append(n.log, entries...)
+	return nil
+}
+
+func (n *Node) saveHardState() error {
+	return errors.Annotate(n.config.Storage.SetHardState(n.hardState), "saving raft state")
+}
+
+func (n *Node) send(m Message) {
+	m.From = n.config.ID
+	m.Term = n.hardState.Term
+	if err := n.config.Send(m); err != nil {
+		logger.Debugf("cannot send %s message to %q: %v", m.Type, m.To, err)
+	}
+}
+
+func (n *Node) resetElectionDeadline() {
+	timeout := n.config.ElectionTimeout
+	timeout += time.Duration(n.rand.Int63n(int64(timeout)))
+	n.electionDeadline = n.config.Clock.Now().Add(timeout)
+}
+
+func (n *Node) lastIndex() uint64 {
+	if len(n.log) > 0 {
+		return n.log[len(n.log)-1].Index
+	}
+	return n.snapshot.Index
+}
+
+func (n *Node) lastTerm() uint64 {
+	if len(n.log) > 0 {
+		return n.log[len(n.log)-1].Term
+	}
+	return n.snapshot.Term
+}
+
+// termAt returns the term of the entry at the given index, and
+// whether the term is known.
+func (n *Node) termAt(index uint64) (uint64, bool) {
+	switch {
+	case index == n.snapshot.Index:
+		return n.snapshot.Term, true
+	case index < n.snapshot.Index || index > n.lastIndex():
+		return 0, false
+	}
+	return n.log[index-n.snapshot.Index-1].Term, true
+}
+
+func (n *Node) isPeer(id string) bool {
+	i := sort.SearchStrings(n.hardState.Peers, id)
+	return i < len(n.hardState.Peers) && n.hardState.Peers[i] == id
+}
+
+func (n *Node) otherPeers() []string {
+	var peers []string
+	for _, peer := range n.hardState.Peers {
+		if peer != n.config.ID {
+			peers = append(peers, peer)
+		}
+	}
+	return peers
+}
+
+func (n *Node) quorum() int {
+	return len(n.hardState.Peers)/2 + 1
+}
+
+// randomSeed returns a seed that differs between nodes, even if they
+// start at the same time, so that their election timeouts differ.
+func randomSeed(id string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(id))
+	return time.Now().UnixNano() ^ int64(h.Sum64())
+}
+
+func minIndex(a, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,297 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raft_test
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/raft"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type nodeSuite struct {
+	testing.IsolationSuite
+	network *network
+}
+
+var _ = gc.Suite(&nodeSuite{})
+
+func (s *nodeSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.network = &network{
+		nodes: make(map[string]*raft.Node),
+		down:  make(map[string]bool),
+	}
+}
+
+func (s *nodeSuite) config(id string, fsm raft.FSM, storage raft.Storage) raft.NodeConfig {
+	return raft.NodeConfig{
+		ID:                id,
+		FSM:               fsm,
+		Storage:           storage,
+		Clock:             clock.WallClock,
+		Send:              s.network.send,
+		HeartbeatInterval: 10 * time.Millisecond,
+		ElectionTimeout:   50 * time.Millisecond,
+		SnapshotThreshold: 1000,
+	}
+}
+
+func (s *nodeSuite) startNode(c *gc.C, config raft.NodeConfig, peers ...string) *raft.Node {
+	node, err := raft.NewNode(config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, node) })
+	s.network.add(config.ID, node)
+	if len(peers) > 0 {
+		node.SetPeers(peers)
+	}
+	return node
+}
+
+// startCluster starts a node, with its own FSM and storage, for each
+// of the given IDs.
+func (s *nodeSuite) startCluster(c *gc.C, ids ...string) ([]*raft.Node, []*fakeFSM) {
+	var nodes []*raft.Node
+	var fsms []*fakeFSM
+	for _, id := range ids {
+		fsm := &fakeFSM{}
+		nodes = append(nodes, s.startNode(c, s.config(id, fsm, &memStorage{}), ids...))
+		fsms = append(fsms, fsm)
+	}
+	return nodes, fsms
+}
+
+// waitLeader waits for the given nodes to agree on a leader, and
+// returns it.
+func waitLeader(c *gc.C, nodes ...*raft.Node) string {
+	timeout := time.After(coretesting.LongWait)
+	for {
+		leader := nodes[0].Leader()
+		agreed := leader != ""
+		for _, node := range nodes[1:] {
+			agreed = agreed && node.Leader() == leader
+		}
+		if agreed {
+			return leader
+		}
+		select {
+		case <-timeout:
+			c.Fatalf("timed out waiting for leader")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func (s *nodeSuite) TestValidate(c *gc.C) {
+	for _, test := range []struct {
+		f      func(*raft.NodeConfig)
+		expect string
+	}{
+		{func(cfg *raft.NodeConfig) { cfg.ID = "" }, "empty ID not valid"},
+		{func(cfg *raft.NodeConfig) { cfg.FSM = nil }, "nil FSM not valid"},
+		{func(cfg *raft.NodeConfig) { cfg.Storage = nil }, "nil Storage not valid"},
+		{func(cfg *raft.NodeConfig) { cfg.Clock = nil }, "nil Clock not valid"},
+		{func(cfg *raft.NodeConfig) { cfg.Send = nil }, "nil Send not valid"},
+		{func(cfg *raft.NodeConfig) { cfg.HeartbeatInterval = 0 }, "non-positive HeartbeatInterval not valid"},
+		{func(cfg *raft.NodeConfig) { cfg.ElectionTimeout = cfg.HeartbeatInterval }, "ElectionTimeout not greater than HeartbeatInterval not valid"},
+		{func(cfg *raft.NodeConfig) { cfg.SnapshotThreshold = 0 }, "zero SnapshotThreshold not valid"},
+	} {
+		config := s.config("0", &fakeFSM{}, &memStorage{})
+		test.f(&config)
+		_, err := raft.NewNode(config)
+		c.Check(err, gc.ErrorMatches, test.expect)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *nodeSuite) TestSingleNode(c *gc.C) {
+	nodes, fsms := s.startCluster(c, "0")
+	c.Assert(waitLeader(c, nodes...), gc.Equals, "0")
+
+	err := nodes[0].Apply([]byte("one"), coretesting.LongWait)
+	c.Assert(err, jc.ErrorIsNil)
+	err = nodes[0].Apply([]byte("fail"), coretesting.LongWait)
+	c.Assert(err, gc.ErrorMatches, "command failed")
+	c.Assert(fsms[0].Commands(), jc.DeepEquals, []string{"one", "fail"})
+}
+
+func (s *nodeSuite) TestNoPeers(c *gc.C) {
+	node := s.startNode(c, s.config("0", &fakeFSM{}, &memStorage{}))
+	err := node.Apply([]byte("one"), coretesting.ShortWait)
+	c.Assert(err, gc.Equals, raft.ErrTimeout)
+	c.Assert(node.Leader(), gc.Equals, "")
+}
+
+func (s *nodeSuite) TestApplyReplicates(c *gc.C) {
+	nodes, fsms := s.startCluster(c, "0", "1", "2")
+	waitLeader(c, nodes...)
+
+	// Commands applied on followers are forwarded to the leader.
+	for i, node := range nodes {
+		err := node.Apply([]byte(fmt.Sprint("from ", i)), coretesting.LongWait)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	for _, fsm := range fsms {
+		waitCommands(c, fsm, "from 0", "from 1", "from 2")
+	}
+}
+
+func (s *nodeSuite) TestApplyOnFollowerReturnsResult(c *gc.C) {
+	nodes, _ := s.startCluster(c, "0", "1")
+	leader := waitLeader(c, nodes...)
+	follower := nodes[0]
+	if leader == "0" {
+		follower = nodes[1]
+	}
+	err := follower.Apply([]byte("fail"), coretesting.LongWait)
+	c.Assert(err, gc.ErrorMatches, "command failed")
+}
+
+func (s *nodeSuite) TestLeaderFailure(c *gc.C) {
+	nodes, fsms := s.startCluster(c, "0", "1", "2")
+	leader := waitLeader(c, nodes...)
+	err := nodes[0].Apply([]byte("one"), coretesting.LongWait)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.network.setDown(leader, true)
+	var survivors []*raft.Node
+	var survivorFSMs []*fakeFSM
+	for i, id := range []string{"0", "1", "2"} {
+		if id != leader {
+			survivors = append(survivors, nodes[i])
+			survivorFSMs = append(survivorFSMs, fsms[i])
+		}
+	}
+	s.waitNewLeader(c, leader, survivors...)
+
+	err = survivors[0].Apply([]byte("two"), coretesting.LongWait)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, fsm := range survivorFSMs {
+		waitCommands(c, fsm, "one", "two")
+	}
+}
+
+func (s *nodeSuite) waitNewLeader(c *gc.C, old string, nodes ...*raft.Node) {
+	timeout := time.After(coretesting.LongWait)
+	for {
+		if leader := waitLeader(c, nodes...); leader != old {
+			return
+		}
+		select {
+		case <-timeout:
+			c.Fatalf("timed out waiting for new leader")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func (s *nodeSuite) TestMinorityCannotCommit(c *gc.C) {
+	nodes, fsms := s.startCluster(c, "0", "1", "2")
+	leader := waitLeader(c, nodes...)
+	for _, id := range []string{"0", "1", "2"} {
+		if id != leader {
+			s.network.setDown(id, true)
+		}
+	}
+	for i, id := range []string{"0", "1", "2"} {
+		if id == leader {
+			err := nodes[i].Apply([]byte("one"), coretesting.ShortWait)
+			c.Assert(err, gc.Equals, raft.ErrTimeout)
+			c.Assert(fsms[i].Commands(), gc.HasLen, 0)
+		}
+	}
+}
+
+func (s *nodeSuite) TestRestart(c *gc.C) {
+	fsm := &fakeFSM{}
+	storage := &memStorage{}
+	config := s.config("0", fsm, storage)
+	node := s.startNode(c, config, "0")
+	err := node.Apply([]byte("one"), coretesting.LongWait)
+	c.Assert(err, jc.ErrorIsNil)
+	workertest.CleanKill(c, node)
+
+	// The FSM is reset, and the committed entries reapplied,
+	// without needing to be told about its peers again.
+	node = s.startNode(c, config)
+	err = node.Apply([]byte("two"), coretesting.LongWait)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fsm.Commands(), jc.DeepEquals, []string{"one", "two"})
+}
+
+func (s *nodeSuite) TestSnapshotCatchUp(c *gc.C) {
+	ids := []string{"0", "1", "2"}
+	var nodes []*raft.Node
+	var fsms []*fakeFSM
+	var storages []*memStorage
+	for _, id := range ids {
+		fsm := &fakeFSM{}
+		storage := &memStorage{}
+		config := s.config(id, fsm, storage)
+		config.SnapshotThreshold = 2
+		nodes = append(nodes, s.startNode(c, config, ids...))
+		fsms = append(fsms, fsm)
+		storages = append(storages, storage)
+	}
+	leader := waitLeader(c, nodes...)
+	lagging := "2"
+	if leader == lagging {
+		lagging = "1"
+	}
+	s.network.setDown(lagging, true)
+	for _, command := range []string{"one", "two", "three", "four", "five"} {
+		err := nodes[0].Apply([]byte(command), coretesting.LongWait)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	for i, id := range ids {
+		if id == leader {
+			c.Assert(storages[i].Snapshot(), gc.NotNil)
+		}
+	}
+
+	s.network.setDown(lagging, false)
+	for _, fsm := range fsms {
+		waitCommands(c, fsm, "one", "two", "three", "four", "five")
+	}
+}
+
+// network delivers messages between nodes asynchronously, dropping
+// those to or from nodes that are down.
+type network struct {
+	mu    sync.Mutex
+	nodes map[string]*raft.Node
+	down  map[string]bool
+}
+
+func (n *network) add(id string, node *raft.Node) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.nodes[id] = node
+}
+
+func (n *network) setDown(id string, down bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.down[id] = down
+}
+
+func (n *network) send(m raft.Message) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	node, ok := n.nodes[m.To]
+	if !ok || n.down[m.From] || n.down[m.To] {
+		return errors.New("unreachable")
+	}
+	go node.Receive(m)
+	return nil
+}
@@ -0,0 +1,216 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raft
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+)
+
+// HardState is the part of a node's state, other than its log, that
+// must survive a restart.
+type HardState struct {
+	// Term is the latest term the node has seen.
+	Term uint64 `json:"term"`
+
+	// VotedFor is the node voted for in Term, if any.
+	VotedFor string `json:"voted-for,omitempty"`
+
+	// Peers holds the IDs of the nodes last known to take part in
+	// the cluster, so that a restarted node can take part before it
+	// hears about the cluster's membership again.
+	Peers []string `json:"peers,omitempty"`
+}
+
+// Storage persists a node's state.
+type Storage interface {
+	// Load returns the node's hard state, its latest snapshot (nil
+	// if it has none), and the log entries following the snapshot.
+	Load() (HardState, *Snapshot, []Entry, error)
+
+	// SetHardState replaces the node's hard state.
+	SetHardState(HardState) error
+
+	// Append adds entries to the end of the log.
+	Append([]Entry) error
+
+	// SetEntries replaces the log entries following the snapshot.
+	SetEntries([]Entry) error
+
+	// SetSnapshot replaces the snapshot, and the log entries
+	// following it.
+	SetSnapshot(Snapshot, []Entry) error
+}
+
+const (
+	hardStateFile = "state.json"
+	snapshotFile  = "snapshot.json"
+	logFile       = "log.jsonl"
+)
+
+// FileStorage is a Storage that keeps a node's state in files in a
+// single directory. The log is kept as one JSON entry per line, so
+// that entries can be appended cheaply.
+type FileStorage struct {
+	dir string
+}
+
+// NewFileStorage returns a FileStorage that keeps its files in the
+// given directory, creating it if necessary.
+func NewFileStorage(dir string) (*FileStorage, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FileStorage{dir: dir}, nil
+}
+
+// Load is part of the Storage interface.
+func (s *FileStorage) Load() (HardState, *Snapshot, []Entry, error) {
+	var hardState HardState
+	if err := s.readJSON(hardStateFile, &hardState); err != nil && !os.IsNotExist(errors.Cause(err)) {
+		return HardState{}, nil, nil, errors.Trace(err)
+	}
+	var snapshot *Snapshot
+	if err := s.readJSON(snapshotFile, &snapshot); err != nil && !os.IsNotExist(errors.Cause(err)) {
+		return HardState{}, nil, nil, errors.Trace(err)
+	}
+	entries, rewrite, err := s.readLog()
+	if err != nil {
+		return HardState{}, nil, nil, errors.Trace(err)
+	}
+
+	// The log may still hold entries included in the snapshot, if we
+	// stopped between writing the snapshot and rewriting the log.
+	var first uint64 = 1
+	if snapshot != nil {
+		first = snapshot.Index + 1
+	}
+	for len(entries) > 0 && entries[0].Index < first {
+		entries = entries[1:]
+		rewrite = true
+	}
+	for i, entry := range entries {
+		if entry.Index != first+uint64(i) {
+			return HardState{}, nil, nil, errors.Errorf(
+				"log entry %d out of sequence (expected %d)", entry.Index, first+uint64(i),
+			)
+		}
+	}
+	if rewrite {
+		if err := s.SetEntries(entries); err != nil {
+			return HardState{}, nil, nil, errors.Trace(err)
+		}
+	}
+	return hardState, snapshot, entries, nil
+}
+
+// SetHardState is part of the Storage interface.
+func (s *FileStorage) SetHardState(hardState HardState) error {
+	return errors.Trace(s.writeJSON(hardStateFile, hardState))
+}
+
+// Append is part of the Storage interface.
+func (s *FileStorage) Append(entries []Entry) error {
+	data, err := marshalEntries(entries)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	f, err := os.OpenFile(s.path(logFile), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	if _, err := f.Write(data); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(f.Sync())
+}
+
+// SetEntries is part of the Storage interface.
+func (s *FileStorage) SetEntries(entries []Entry) error {
+	data, err := marshalEntries(entries)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(utils.AtomicWriteFile(s.path(logFile), data, 0600))
+}
+
+// SetSnapshot is part of the Storage interface.
+func (s *FileStorage) SetSnapshot(snapshot Snapshot, entries []Entry) error {
+	if err := s.writeJSON(snapshotFile, snapshot); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(s.SetEntries(entries))
+}
+
+func (s *FileStorage) path(name string) string {
+	return filepath.Join(s.dir, name)
+}
+
+func (s *FileStorage) readJSON(name string, out interface{}) error {
+	data, err := ioutil.ReadFile(s.path(name))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Annotatef(json.Unmarshal(data, out), "reading %s", name)
+}
+
+func (s *FileStorage) writeJSON(name string, in interface{}) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(utils.AtomicWriteFile(s.path(name), data, 0600))
+}
+
+// readLog returns the entries in the log file. If we stopped part way
+// through appending an entry, the incomplete entry is dropped and
+// rewrite is true, so the caller can remove it before appending more.
+func (s *FileStorage) readLog() (entries []Entry, rewrite bool, _ error) {
+	data, err := ioutil.ReadFile(s.path(logFile))
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			if len(data) > 0 && data[len(data)-1] != '\n' && !scanner.Scan() {
+				// Only the last, unterminated, line may be damaged.
+				return entries, true, nil
+			}
+			return nil, false, errors.Annotate(err, "reading log")
+		}
+		entries = append(entries, entry)
+	}
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		// The last entry was complete, but its newline was not
+		// written; rewrite the log so appends start on a new line.
+		rewrite = true
+	}
+	return entries, rewrite, errors.Trace(scanner.Err())
+}
+
+func marshalEntries(entries []Entry) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, entry := range entries {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
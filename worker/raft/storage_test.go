@@ -0,0 +1,135 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raft_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/raft"
+)
+
+type storageSuite struct {
+	testing.IsolationSuite
+	dir string
+}
+
+var _ = gc.Suite(&storageSuite{})
+
+func (s *storageSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dir = filepath.Join(c.MkDir(), "raft")
+}
+
+func (s *storageSuite) newStorage(c *gc.C) *raft.FileStorage {
+	storage, err := raft.NewFileStorage(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	return storage
+}
+
+func entries(first, last uint64) []raft.Entry {
+	var result []raft.Entry
+	for i := first; i <= last; i++ {
+		result = append(result, raft.Entry{Index: i, Term: 1, ID: "id", Command: "command"})
+	}
+	return result
+}
+
+func (s *storageSuite) TestLoadEmpty(c *gc.C) {
+	hardState, snapshot, entries, err := s.newStorage(c).Load()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hardState, jc.DeepEquals, raft.HardState{})
+	c.Assert(snapshot, gc.IsNil)
+	c.Assert(entries, gc.HasLen, 0)
+}
+
+func (s *storageSuite) TestRoundTrip(c *gc.C) {
+	storage := s.newStorage(c)
+	hardState := raft.HardState{Term: 3, VotedFor: "1", Peers: []string{"0", "1", "2"}}
+	c.Assert(storage.SetHardState(hardState), jc.ErrorIsNil)
+	c.Assert(storage.Append(entries(1, 2)), jc.ErrorIsNil)
+	c.Assert(storage.Append(entries(3, 3)), jc.ErrorIsNil)
+
+	loadedState, snapshot, loaded, err := s.newStorage(c).Load()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(loadedState, jc.DeepEquals, hardState)
+	c.Assert(snapshot, gc.IsNil)
+	c.Assert(loaded, jc.DeepEquals, entries(1, 3))
+}
+
+func (s *storageSuite) TestSetEntries(c *gc.C) {
+	storage := s.newStorage(c)
+	c.Assert(storage.Append(entries(1, 3)), jc.ErrorIsNil)
+	c.Assert(storage.SetEntries(entries(1, 1)), jc.ErrorIsNil)
+	c.Assert(storage.Append(entries(2, 2)), jc.ErrorIsNil)
+
+	_, _, loaded, err := storage.Load()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(loaded, jc.DeepEquals, entries(1, 2))
+}
+
+func (s *storageSuite) TestSetSnapshot(c *gc.C) {
+	storage := s.newStorage(c)
+	c.Assert(storage.Append(entries(1, 3)), jc.ErrorIsNil)
+	snapshot := raft.Snapshot{Index: 2, Term: 1, Data: "data"}
+	c.Assert(storage.SetSnapshot(snapshot, entries(3, 3)), jc.ErrorIsNil)
+
+	_, loadedSnapshot, loaded, err := storage.Load()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(loadedSnapshot, jc.DeepEquals, &snapshot)
+	c.Assert(loaded, jc.DeepEquals, entries(3, 3))
+}
+
+func (s *storageSuite) TestLoadDropsEntriesInSnapshot(c *gc.C) {
+	// Simulate stopping between writing the snapshot and rewriting
+	// the log.
+	storage := s.newStorage(c)
+	c.Assert(storage.SetSnapshot(raft.Snapshot{Index: 2, Term: 1}, nil), jc.ErrorIsNil)
+	c.Assert(storage.Append(entries(1, 3)), jc.ErrorIsNil)
+
+	_, _, loaded, err := storage.Load()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(loaded, jc.DeepEquals, entries(3, 3))
+}
+
+func (s *storageSuite) TestLoadDropsIncompleteEntry(c *gc.C) {
+	storage := s.newStorage(c)
+	c.Assert(storage.Append(entries(1, 2)), jc.ErrorIsNil)
+	f, err := os.OpenFile(filepath.Join(s.dir, "log.jsonl"), os.O_WRONLY|os.O_APPEND, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = f.Write([]byte(`{"index":3,"te`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(f.Close(), jc.ErrorIsNil)
+
+	_, _, loaded, err := storage.Load()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(loaded, jc.DeepEquals, entries(1, 2))
+
+	// The incomplete entry has been removed, so appending works.
+	c.Assert(storage.Append(entries(3, 3)), jc.ErrorIsNil)
+	_, _, loaded, err = storage.Load()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(loaded, jc.DeepEquals, entries(1, 3))
+}
+
+func (s *storageSuite) TestLoadCorruptLog(c *gc.C) {
+	storage := s.newStorage(c)
+	err := ioutil.WriteFile(filepath.Join(s.dir, "log.jsonl"), []byte("junk\n{}\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	_, _, _, err = storage.Load()
+	c.Assert(err, gc.ErrorMatches, "reading log: .*")
+}
+
+func (s *storageSuite) TestLoadOutOfSequence(c *gc.C) {
+	storage := s.newStorage(c)
+	c.Assert(storage.Append(entries(1, 1)), jc.ErrorIsNil)
+	c.Assert(storage.Append(entries(3, 3)), jc.ErrorIsNil)
+	_, _, _, err := storage.Load()
+	c.Assert(err, gc.ErrorMatches, `log entry 3 out of sequence \(expected 2\)`)
+}
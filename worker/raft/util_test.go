@@ -0,0 +1,123 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raft_test
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/raft"
+)
+
+// fakeFSM records the commands applied to it. Applying the command
+// "fail" returns an error.
+type fakeFSM struct {
+	mu       sync.Mutex
+	commands []string
+}
+
+func (f *fakeFSM) Apply(command []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.commands = append(f.commands, string(command))
+	if string(command) == "fail" {
+		return errors.New("command failed")
+	}
+	return nil
+}
+
+func (f *fakeFSM) Snapshot() ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return json.Marshal(f.commands)
+}
+
+func (f *fakeFSM) Restore(data []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.commands = nil
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, &f.commands)
+}
+
+func (f *fakeFSM) Commands() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.commands...)
+}
+
+// waitCommands waits for the FSM to have applied the expected
+// commands.
+func waitCommands(c *gc.C, fsm *fakeFSM, expect ...string) {
+	timeout := time.After(coretesting.LongWait)
+	for {
+		commands := fsm.Commands()
+		if len(commands) >= len(expect) {
+			c.Assert(commands, jc.DeepEquals, expect)
+			return
+		}
+		select {
+		case <-timeout:
+			c.Fatalf("timed out waiting for commands %v; got %v", expect, commands)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+// memStorage is a raft.Storage that keeps a node's state in memory.
+type memStorage struct {
+	mu        sync.Mutex
+	hardState raft.HardState
+	snapshot  *raft.Snapshot
+	entries   []raft.Entry
+}
+
+func (s *memStorage) Load() (raft.HardState, *raft.Snapshot, []raft.Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.hardState, s.snapshot, append([]raft.Entry(nil), s.entries...), nil
+}
+
+func (s *memStorage) SetHardState(hardState raft.HardState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hardState = hardState
+	return nil
+}
+
+func (s *memStorage) Append(entries []raft.Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, entries...)
+	return nil
+}
+
+func (s *memStorage) SetEntries(entries []raft.Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append([]raft.Entry(nil), entries...)
+	return nil
+}
+
+func (s *memStorage) SetSnapshot(snapshot raft.Snapshot, entries []raft.Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.snapshot = &snapshot
+	s.entries = append([]raft.Entry(nil), entries...)
+	return nil
+}
+
+func (s *memStorage) Snapshot() *raft.Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.snapshot
+}
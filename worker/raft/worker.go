@@ -0,0 +1,187 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raft
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/pubsub"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/pubsub/apiserver"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+const (
+	// DefaultHeartbeatInterval is the default interval between the
+	// leader's heartbeats.
+	DefaultHeartbeatInterval = 250 * time.Millisecond
+
+	// DefaultElectionTimeout is the default minimum time a follower
+	// waits to hear from the leader before standing for election.
+	DefaultElectionTimeout = 2 * time.Second
+
+	// DefaultSnapshotThreshold is the default number of applied
+	// entries after which the log is compacted.
+	DefaultSnapshotThreshold = 8192
+)
+
+// Config holds the resources and configuration needed to run a raft
+// worker.
+type Config struct {
+	// ID identifies the controller machine running the worker, as
+	// it is identified in the API server details published by the
+	// peergrouper.
+	ID string
+
+	// Hub is the central hub, over which raft messages are
+	// exchanged with the other controllers.
+	Hub *pubsub.StructuredHub
+
+	// FSM is the replicated state machine.
+	FSM FSM
+
+	// Applier is updated to pass commands to the worker's Node
+	// while the worker runs.
+	Applier *Applier
+
+	// Storage persists the Node's state.
+	Storage Storage
+
+	// Clock is used for election and heartbeat timing.
+	Clock clock.Clock
+
+	HeartbeatInterval time.Duration
+	ElectionTimeout   time.Duration
+	SnapshotThreshold uint64
+}
+
+// Validate returns an error if the config is not valid.
+func (config Config) Validate() error {
+	if config.Hub == nil {
+		return errors.NotValidf("nil Hub")
+	}
+	if config.Applier == nil {
+		return errors.NotValidf("nil Applier")
+	}
+	// The worker supplies the Node's Send func.
+	send := func(Message) error { return nil }
+	return errors.Trace(config.nodeConfig(send).Validate())
+}
+
+func (config Config) nodeConfig(send func(Message) error) NodeConfig {
+	return NodeConfig{
+		ID:                config.ID,
+		FSM:               config.FSM,
+		Storage:           config.Storage,
+		Clock:             config.Clock,
+		Send:              send,
+		HeartbeatInterval: config.HeartbeatInterval,
+		ElectionTimeout:   config.ElectionTimeout,
+		SnapshotThreshold: config.SnapshotThreshold,
+	}
+}
+
+// NewWorker returns a worker that runs a raft Node, exchanging
+// messages with the Nodes on the other controllers over the central
+// hub, and taking its peers from the API server details published by
+// the peergrouper.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &raftWorker{config: config}
+	node, err := NewNode(config.nodeConfig(w.send))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	w.node = node
+
+	unsubMessages, err := config.Hub.Subscribe(Topic, w.receive)
+	if err != nil {
+		worker.Stop(node)
+		return nil, errors.Trace(err)
+	}
+	unsubDetails, err := config.Hub.Subscribe(apiserver.DetailsTopic, w.apiServerChanges)
+	if err != nil {
+		unsubMessages()
+		worker.Stop(node)
+		return nil, errors.Trace(err)
+	}
+	w.unsubscribe = func() {
+		unsubMessages()
+		unsubDetails()
+	}
+
+	config.Applier.setNode(node)
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+		Init: []worker.Worker{node},
+	}); err != nil {
+		config.Applier.setNode(nil)
+		w.unsubscribe()
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type raftWorker struct {
+	config      Config
+	catacomb    catacomb.Catacomb
+	node        *Node
+	unsubscribe func()
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *raftWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *raftWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+// Report is part of the dependency.Reporter interface.
+func (w *raftWorker) Report() map[string]interface{} {
+	return w.node.Report()
+}
+
+func (w *raftWorker) loop() error {
+	defer w.unsubscribe()
+	defer w.config.Applier.setNode(nil)
+	<-w.catacomb.Dying()
+	return w.catacomb.ErrDying()
+}
+
+func (w *raftWorker) send(m Message) error {
+	_, err := w.config.Hub.Publish(Topic, m)
+	return errors.Trace(err)
+}
+
+func (w *raftWorker) receive(topic string, m Message, err error) {
+	if err != nil {
+		logger.Errorf("cannot decode raft message: %v", err)
+		return
+	}
+	if m.To != w.config.ID {
+		return
+	}
+	w.node.Receive(m)
+}
+
+func (w *raftWorker) apiServerChanges(topic string, details apiserver.Details, err error) {
+	if err != nil {
+		logger.Errorf("cannot decode API server details: %v", err)
+		return
+	}
+	peers := make([]string, 0, len(details.Servers))
+	for id := range details.Servers {
+		peers = append(peers, id)
+	}
+	w.node.SetPeers(peers)
+}
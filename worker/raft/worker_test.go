@@ -0,0 +1,99 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raft_test
+
+import (
+	"time"
+
+	"github.com/juju/pubsub"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/pubsub/apiserver"
+	"github.com/juju/juju/pubsub/centralhub"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/raft"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type workerSuite struct {
+	testing.IsolationSuite
+	hub *pubsub.StructuredHub
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	// The workers share a hub, as if messages were forwarded between
+	// controllers.
+	s.hub = centralhub.New(names.NewMachineTag("0"))
+}
+
+func (s *workerSuite) startWorker(c *gc.C, id string, fsm raft.FSM, applier *raft.Applier) worker.Worker {
+	w, err := raft.NewWorker(raft.Config{
+		ID:                id,
+		Hub:               s.hub,
+		FSM:               fsm,
+		Applier:           applier,
+		Storage:           &memStorage{},
+		Clock:             clock.WallClock,
+		HeartbeatInterval: 10 * time.Millisecond,
+		ElectionTimeout:   50 * time.Millisecond,
+		SnapshotThreshold: 1000,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, w) })
+	return w
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	_, err := raft.NewWorker(raft.Config{Applier: raft.NewApplier()})
+	c.Assert(err, gc.ErrorMatches, "nil Hub not valid")
+	_, err = raft.NewWorker(raft.Config{Hub: s.hub})
+	c.Assert(err, gc.ErrorMatches, "nil Applier not valid")
+	_, err = raft.NewWorker(raft.Config{Hub: s.hub, Applier: raft.NewApplier()})
+	c.Assert(err, gc.ErrorMatches, "empty ID not valid")
+}
+
+func (s *workerSuite) TestApplierNotRunning(c *gc.C) {
+	err := raft.NewApplier().Apply([]byte("one"), time.Second)
+	c.Assert(err, gc.Equals, raft.ErrNotRunning)
+}
+
+func (s *workerSuite) TestReplicatesOverHub(c *gc.C) {
+	fsm0, fsm1 := &fakeFSM{}, &fakeFSM{}
+	applier0, applier1 := raft.NewApplier(), raft.NewApplier()
+	s.startWorker(c, "0", fsm0, applier0)
+	w1 := s.startWorker(c, "1", fsm1, applier1)
+
+	done, err := s.hub.Publish(apiserver.DetailsTopic, apiserver.Details{
+		Servers: map[string]apiserver.APIServer{
+			"0": {ID: "0"},
+			"1": {ID: "1"},
+		},
+		LocalOnly: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-done:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("details not delivered")
+	}
+
+	err = applier1.Apply([]byte("one"), coretesting.LongWait)
+	c.Assert(err, jc.ErrorIsNil)
+	err = applier0.Apply([]byte("fail"), coretesting.LongWait)
+	c.Assert(err, gc.ErrorMatches, "command failed")
+	waitCommands(c, fsm0, "one", "fail")
+	waitCommands(c, fsm1, "one", "fail")
+
+	workertest.CleanKill(c, w1)
+	err = applier1.Apply([]byte("two"), coretesting.LongWait)
+	c.Assert(err, gc.Equals, raft.ErrNotRunning)
+}
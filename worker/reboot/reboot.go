@@ -23,11 +23,17 @@ import (
 
 var logger = loggo.GetLogger("juju.worker.reboot")
 
+// waitInterval is how long to wait before asking again for the action
+// to take, when told to wait for the model's next maintenance window.
+const waitInterval = 5 * time.Minute
+
 // The reboot worker listens for changes to the reboot flag and
 // exists with worker.ErrRebootMachine if the machine should reboot or
 // with worker.ErrShutdownMachine if it should shutdown. This will be picked
 // up by the machine agent as a fatal error and will do the
-// right thing (reboot or shutdown)
+// right thing (reboot or shutdown). If the model defers reboots, then
+// outside its maintenance windows the controller tells the worker to
+// wait, and it asks again periodically until a window opens.
 type Reboot struct {
 	tomb            tomb.Tomb
 	st              reboot.State
@@ -58,12 +64,24 @@ func (r *Reboot) SetUp() (watcher.NotifyWatcher, error) {
 	return watcher, errors.Trace(err)
 }
 
-func (r *Reboot) Handle(_ <-chan struct{}) error {
+func (r *Reboot) Handle(abort <-chan struct{}) error {
 	rAction, err := r.st.GetRebootAction()
 	if err != nil {
 		return errors.Trace(err)
 	}
 	logger.Debugf("Reboot worker got action: %v", rAction)
+	for rAction == params.ShouldWait {
+		logger.Infof("reboot deferred until the next maintenance window")
+		select {
+		case <-abort:
+			return nil
+		case <-r.clock.After(waitInterval):
+		}
+		if rAction, err = r.st.GetRebootAction(); err != nil {
+			return errors.Trace(err)
+		}
+		logger.Debugf("Reboot worker got action: %v", rAction)
+	}
 	// NOTE: Here we explicitly avoid stopping on the abort channel as we are
 	// wanting to make sure that we grab the lock and return an error
 	// sufficiently heavyweight to get the agent to restart.
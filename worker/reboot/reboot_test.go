@@ -4,6 +4,7 @@
 package reboot_test
 
 import (
+	"fmt"
 	"time"
 
 	jc "github.com/juju/testing/checkers"
@@ -14,9 +15,11 @@ import (
 
 	"github.com/juju/juju/api"
 	apireboot "github.com/juju/juju/api/reboot"
+	"github.com/juju/juju/core/maintenance"
 	"github.com/juju/juju/instance"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/reboot"
 )
@@ -114,3 +117,38 @@ type fakeClock struct {
 func (f *fakeClock) After(time.Duration) <-chan time.Time {
 	return time.After(f.delay)
 }
+
+func (s *rebootSuite) TestWorkerWaitsForMaintenanceWindow(c *gc.C) {
+	// A window that opens in two hours is not open now.
+	opens := time.Now().UTC().Add(2 * time.Hour)
+	err := s.State.SetMaintenanceWindow(maintenance.Window{
+		Name:     "later",
+		Schedule: fmt.Sprintf("%d %d * * *", opens.Minute(), opens.Hour()),
+		Duration: time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	wrk, err := reboot.NewReboot(s.rebootState, s.AgentConfigForTag(c, s.machine.Tag()), "test-reboot-window", s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.rebootState.RequestReboot()
+	c.Assert(err, jc.ErrorIsNil)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- wrk.Wait()
+	}()
+	select {
+	case err := <-done:
+		c.Fatalf("worker exited outside maintenance window: %v", err)
+	case <-time.After(coretesting.ShortWait):
+	}
+
+	err = s.State.RemoveMaintenanceWindow("later")
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case err := <-done:
+		c.Assert(err, gc.Equals, worker.ErrRebootMachine)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("worker did not reboot")
+	}
+}
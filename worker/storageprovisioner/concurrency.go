@@ -0,0 +1,113 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storageprovisioner
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/storage"
+)
+
+// runBatches splits n operations into contiguous batches, and calls f
+// with the bounds of each batch. There are at most as many batches as
+// the configured MaxConcurrentOperations, and they are run concurrently;
+// runBatches returns once all of them have completed.
+func runBatches(ctx *context, n int, f func(start, end int)) {
+	batches := ctx.config.MaxConcurrentOperations
+	if batches > n {
+		batches = n
+	}
+	if batches <= 1 {
+		if n > 0 {
+			f(0, n)
+		}
+		return
+	}
+	size := (n + batches - 1) / batches
+	var wg sync.WaitGroup
+	for start := 0; start < n; start += size {
+		end := start + size
+		if end > n {
+			end = n
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			f(start, end)
+		}(start, end)
+	}
+	wg.Wait()
+}
+
+// batchResultsError returns err, or an error if a batch call that
+// succeeded did not return the expected number of results.
+func batchResultsError(err error, expected, actual int) error {
+	if err == nil && actual != expected {
+		err = errors.Errorf("expected %d results, got %d", expected, actual)
+	}
+	return err
+}
+
+// createVolumesInBatches calls CreateVolumes on the volume source with
+// batches of the given parameters. The failure of a whole batch is
+// recorded against each of the volumes in it, so that they are retried
+// without discarding the results of the other batches.
+func createVolumesInBatches(
+	ctx *context, source storage.VolumeSource, args []storage.VolumeParams,
+) []storage.CreateVolumesResult {
+	results := make([]storage.CreateVolumesResult, len(args))
+	runBatches(ctx, len(args), func(start, end int) {
+		batchResults, err := source.CreateVolumes(ctx.providerContext, args[start:end])
+		if err = batchResultsError(err, end-start, len(batchResults)); err != nil {
+			for i := start; i < end; i++ {
+				results[i].Error = err
+			}
+			return
+		}
+		copy(results[start:end], batchResults)
+	})
+	return results
+}
+
+// attachVolumesInBatches calls AttachVolumes on the volume source with
+// batches of the given parameters. The failure of a whole batch is
+// recorded against each of the attachments in it.
+func attachVolumesInBatches(
+	ctx *context, source storage.VolumeSource, args []storage.VolumeAttachmentParams,
+) []storage.AttachVolumesResult {
+	results := make([]storage.AttachVolumesResult, len(args))
+	runBatches(ctx, len(args), func(start, end int) {
+		batchResults, err := source.AttachVolumes(ctx.providerContext, args[start:end])
+		if err = batchResultsError(err, end-start, len(batchResults)); err != nil {
+			for i := start; i < end; i++ {
+				results[i].Error = err
+			}
+			return
+		}
+		copy(results[start:end], batchResults)
+	})
+	return results
+}
+
+// detachVolumesInBatches calls DetachVolumes on the volume source with
+// batches of the given parameters. The failure of a whole batch is
+// recorded against each of the attachments in it.
+func detachVolumesInBatches(
+	ctx *context, source storage.VolumeSource, args []storage.VolumeAttachmentParams,
+) []error {
+	results := make([]error, len(args))
+	runBatches(ctx, len(args), func(start, end int) {
+		batchResults, err := source.DetachVolumes(ctx.providerContext, args[start:end])
+		if err = batchResultsError(err, end-start, len(batchResults)); err != nil {
+			for i := start; i < end; i++ {
+				results[i] = err
+			}
+			return
+		}
+		copy(results[start:end], batchResults)
+	})
+	return results
+}
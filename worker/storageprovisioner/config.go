@@ -22,6 +22,12 @@ type Config struct {
 	Machines    MachineAccessor
 	Status      StatusSetter
 	Clock       clock.Clock
+
+	// MaxConcurrentOperations is the maximum number of batches of
+	// volume operations that may be in progress with a storage
+	// provider at once. Zero means that operations are made one
+	// batch at a time.
+	MaxConcurrentOperations int
 }
 
 // Validate returns an error if the config cannot be relied upon to start a worker.
@@ -61,5 +67,8 @@ func (config Config) Validate() error {
 	if config.Clock == nil {
 		return errors.NotValidf("nil Clock")
 	}
+	if config.MaxConcurrentOperations < 0 {
+		return errors.NotValidf("negative MaxConcurrentOperations")
+	}
 	return nil
 }
@@ -77,6 +77,11 @@ func (s *ConfigSuite) TestNilMachines(c *gc.C) {
 	s.checkNotValid(c, "nil Machines not valid")
 }
 
+func (s *ConfigSuite) TestNegativeMaxConcurrentOperations(c *gc.C) {
+	s.config.MaxConcurrentOperations = -1
+	s.checkNotValid(c, "negative MaxConcurrentOperations not valid")
+}
+
 func (s *ConfigSuite) TestNilStatus(c *gc.C) {
 	s.config.Status = nil
 	s.checkNotValid(c, "nil Status not valid")
@@ -23,6 +23,9 @@ type ModelManifoldConfig struct {
 
 	Scope      names.Tag
 	StorageDir string
+
+	// MaxConcurrentOperations is passed to the worker's Config.
+	MaxConcurrentOperations int
 }
 
 // ModelManifold returns a dependency.Manifold that runs a storage provisioner.
@@ -58,6 +61,8 @@ func ModelManifold(config ModelManifoldConfig) dependency.Manifold {
 				Machines:    api,
 				Status:      api,
 				Clock:       clock,
+
+				MaxConcurrentOperations: config.MaxConcurrentOperations,
 			})
 			if err != nil {
 				return nil, errors.Trace(err)
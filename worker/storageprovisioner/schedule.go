@@ -3,7 +3,11 @@
 
 package storageprovisioner
 
-import "time"
+import (
+	"time"
+
+	"github.com/juju/juju/storage"
+)
 
 // minRetryDelay is the minimum delay to apply
 // to operation retries; this does not apply to
@@ -15,6 +19,11 @@ const minRetryDelay = 30 * time.Second
 // up to this ceiling.
 const maxRetryDelay = 30 * time.Minute
 
+// rateLimitedRetryDelay is the minimum delay to apply
+// to retries of operations that the storage provider
+// rejected because it is throttling requests.
+const rateLimitedRetryDelay = time.Minute
+
 // scheduleOperations schedules the given operations
 // by calculating the current time once, and then
 // adding each operation's delay to that time. By
@@ -64,3 +73,20 @@ func (s *exponentialBackoff) delay() time.Duration {
 	}
 	return current
 }
+
+// backoffFor ensures that, if the given error indicates that the
+// storage provider throttled the operation, the next delay is at
+// least as long as the provider asked for, and no shorter than
+// rateLimitedRetryDelay.
+func (s *exponentialBackoff) backoffFor(err error) {
+	retryAfter, ok := storage.RateLimitRetryAfter(err)
+	if !ok {
+		return
+	}
+	if retryAfter < rateLimitedRetryDelay {
+		retryAfter = rateLimitedRetryDelay
+	}
+	if s.d < retryAfter {
+		s.d = retryAfter
+	}
+}
@@ -21,12 +21,16 @@
 //    provisioned before attachment is attempted), and populates
 //    operations into the schedule
 //  - operation execution code fed by the schedule, that groups
-//    operations to make bulk calls to storage providers; updates
-//    status; and reschedules operations upon failure
+//    operations to make bulk calls to storage providers, split into
+//    concurrent batches for volumes; updates status; and reschedules
+//    operations upon failure, backing off further when the provider
+//    is throttling requests
 //
 package storageprovisioner
 
 import (
+	stdcontext "context"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/set"
@@ -266,6 +270,7 @@ func (w *storageProvisioner) loop() error {
 		kill:                                 w.catacomb.Kill,
 		addWorker:                            w.catacomb.Add,
 		config:                               w.config,
+		providerContext:                      w.catacomb.Context(stdcontext.Background()),
 		volumes:                              make(map[names.VolumeTag]storage.Volume),
 		volumeAttachments:                    make(map[params.MachineStorageId]storage.VolumeAttachment),
 		volumeBlockDevices:                   make(map[names.VolumeTag]storage.BlockDevice),
@@ -422,6 +427,10 @@ type context struct {
 	addWorker func(worker.Worker) error
 	config    Config
 
+	// providerContext is passed to the volume and filesystem sources'
+	// operations, and is cancelled when the worker is killed.
+	providerContext stdcontext.Context
+
 	// volumes contains information about provisioned volumes.
 	volumes map[names.VolumeTag]storage.Volume
 
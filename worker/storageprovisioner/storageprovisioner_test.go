@@ -4,6 +4,8 @@
 package storageprovisioner_test
 
 import (
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/juju/errors"
@@ -15,6 +17,7 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
 	"github.com/juju/juju/storage"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/watcher"
@@ -267,6 +270,158 @@ func (s *storageProvisionerSuite) TestCreateVolumeRetry(c *gc.C) {
 	})
 }
 
+func (s *storageProvisionerSuite) TestCreateVolumeRetryRateLimited(c *gc.C) {
+	volumeInfoSet := make(chan interface{})
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionedMachines["machine-1"] = instance.Id("already-provisioned-1")
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		defer close(volumeInfoSet)
+		return make([]params.ErrorResult, len(volumes)), nil
+	}
+
+	clock := &mockClock{}
+	var createVolumeTimes []time.Time
+	errs := []error{
+		common.RateLimitedError(errors.New("slow down"), 0),
+		common.RateLimitedError(errors.New("slow down"), 5*time.Minute),
+		errors.New("badness"),
+	}
+	s.provider.createVolumesFunc = func(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+		createVolumeTimes = append(createVolumeTimes, clock.Now())
+		if len(createVolumeTimes) <= len(errs) {
+			return []storage.CreateVolumesResult{{Error: errs[len(createVolumeTimes)-1]}}, nil
+		}
+		return []storage.CreateVolumesResult{{
+			Volume: &storage.Volume{Tag: args[0].Tag},
+		}}, nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, clock: clock, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.attachmentsWatcher.changes <- []watcher.MachineStorageId{{
+		MachineTag: "machine-1", AttachmentTag: "volume-1",
+	}}
+	volumeAccessor.volumesWatcher.changes <- []string{"1"}
+	waitChannel(c, volumeInfoSet, "waiting for volume info to be set")
+	c.Assert(createVolumeTimes, gc.HasLen, 4)
+
+	delays := make([]time.Duration, len(createVolumeTimes)-1)
+	for i := range createVolumeTimes[1:] {
+		delays[i] = createVolumeTimes[i+1].Sub(createVolumeTimes[i])
+	}
+	c.Assert(delays, jc.DeepEquals, []time.Duration{
+		1 * time.Minute,  // throttled, with no retry delay given
+		5 * time.Minute,  // throttled, with the provider's retry delay
+		10 * time.Minute, // backoff continues from the provider's delay
+	})
+}
+
+func (s *storageProvisionerSuite) TestCreateVolumesConcurrently(c *gc.C) {
+	volumeInfoSet := make(chan interface{}, 1)
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionedMachines["machine-1"] = instance.Id("already-provisioned-1")
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		volumeInfoSet <- volumes
+		return make([]params.ErrorResult, len(volumes)), nil
+	}
+
+	// Each batch waits for the other to start, so the volumes
+	// are only created if the batches are run concurrently.
+	var (
+		mu         sync.Mutex
+		batchSizes []int
+		started    sync.WaitGroup
+	)
+	started.Add(2)
+	allStarted := make(chan struct{})
+	go func() {
+		started.Wait()
+		close(allStarted)
+	}()
+	s.provider.createVolumesFunc = func(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+		mu.Lock()
+		batchSizes = append(batchSizes, len(args))
+		if len(batchSizes) <= 2 {
+			started.Done()
+		}
+		mu.Unlock()
+		select {
+		case <-allStarted:
+		case <-time.After(coretesting.LongWait):
+			return nil, errors.New("batches not run concurrently")
+		}
+		results := make([]storage.CreateVolumesResult, len(args))
+		for i, arg := range args {
+			results[i].Volume = &storage.Volume{Tag: arg.Tag}
+		}
+		return results, nil
+	}
+
+	args := &workerArgs{
+		volumes:                 volumeAccessor,
+		registry:                s.registry,
+		maxConcurrentOperations: 2,
+	}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.attachmentsWatcher.changes <- []watcher.MachineStorageId{
+		{MachineTag: "machine-1", AttachmentTag: "volume-1"},
+		{MachineTag: "machine-1", AttachmentTag: "volume-2"},
+		{MachineTag: "machine-1", AttachmentTag: "volume-3"},
+		{MachineTag: "machine-1", AttachmentTag: "volume-4"},
+	}
+	volumeAccessor.volumesWatcher.changes <- []string{"1", "2", "3", "4"}
+	volumes := waitChannel(c, volumeInfoSet, "waiting for volume info to be set")
+	c.Assert(volumes, gc.HasLen, 4)
+
+	mu.Lock()
+	defer mu.Unlock()
+	sort.Ints(batchSizes)
+	c.Assert(batchSizes, jc.DeepEquals, []int{2, 2})
+}
+
+func (s *storageProvisionerSuite) TestCreateVolumesBatchErrorRetried(c *gc.C) {
+	volumeInfoSet := make(chan interface{})
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionedMachines["machine-1"] = instance.Id("already-provisioned-1")
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		defer close(volumeInfoSet)
+		return make([]params.ErrorResult, len(volumes)), nil
+	}
+
+	calls := 0
+	s.provider.createVolumesFunc = func(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+		calls++
+		if calls == 1 {
+			return nil, errors.New("provider unavailable")
+		}
+		return []storage.CreateVolumesResult{{
+			Volume: &storage.Volume{Tag: args[0].Tag},
+		}}, nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.attachmentsWatcher.changes <- []watcher.MachineStorageId{{
+		MachineTag: "machine-1", AttachmentTag: "volume-1",
+	}}
+	volumeAccessor.volumesWatcher.changes <- []string{"1"}
+	waitChannel(c, volumeInfoSet, "waiting for volume info to be set")
+	c.Assert(calls, gc.Equals, 2)
+	c.Assert(args.statusSetter.args, jc.DeepEquals, []params.EntityStatusArgs{
+		{Tag: "volume-1", Status: "pending", Info: "provider unavailable"},
+		{Tag: "volume-1", Status: "attaching", Info: ""},
+	})
+}
+
 func (s *storageProvisionerSuite) TestCreateFilesystemRetry(c *gc.C) {
 	filesystemInfoSet := make(chan interface{})
 	filesystemAccessor := newMockFilesystemAccessor()
@@ -1794,6 +1949,8 @@ func newStorageProvisioner(c *gc.C, args *workerArgs) worker.Worker {
 		Machines:    args.machines,
 		Status:      args.statusSetter,
 		Clock:       args.clock,
+
+		MaxConcurrentOperations: args.maxConcurrentOperations,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	return worker
@@ -1808,6 +1965,8 @@ type workerArgs struct {
 	machines     *mockMachineAccessor
 	clock        clock.Clock
 	statusSetter *mockStatusSetter
+
+	maxConcurrentOperations int
 }
 
 func waitChannel(c *gc.C, ch <-chan interface{}, activity string) interface{} {
@@ -4,6 +4,8 @@
 package storageprovisioner
 
 import (
+	stdcontext "context"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
@@ -50,10 +52,7 @@ func createVolumes(ctx *context, ops map[names.VolumeTag]*createVolumeOp) error
 		if len(volumeParams) == 0 {
 			continue
 		}
-		results, err := volumeSource.CreateVolumes(volumeParams)
-		if err != nil {
-			return errors.Annotatef(err, "creating volumes from source %q", sourceName)
-		}
+		results := createVolumesInBatches(ctx, volumeSource, volumeParams)
 		for i, result := range results {
 			statuses = append(statuses, params.EntityStatusArgs{
 				Tag:    volumeParams[i].Tag.String(),
@@ -62,7 +61,9 @@ func createVolumes(ctx *context, ops map[names.VolumeTag]*createVolumeOp) error
 			entityStatus := &statuses[len(statuses)-1]
 			if result.Error != nil {
 				// Reschedule the volume creation.
-				reschedule = append(reschedule, ops[volumeParams[i].Tag])
+				op := ops[volumeParams[i].Tag]
+				op.backoffFor(result.Error)
+				reschedule = append(reschedule, op)
 
 				// Note: we keep the status as "pending" to indicate
 				// that we will retry. When we distinguish between
@@ -145,10 +146,7 @@ func attachVolumes(ctx *context, ops map[params.MachineStorageId]*attachVolumeOp
 			// to do here.
 			continue
 		}
-		results, err := volumeSource.AttachVolumes(volumeAttachmentParams)
-		if err != nil {
-			return errors.Annotatef(err, "attaching volumes from source %q", sourceName)
-		}
+		results := attachVolumesInBatches(ctx, volumeSource, volumeAttachmentParams)
 		for i, result := range results {
 			p := volumeAttachmentParams[i]
 			statuses = append(statuses, params.EntityStatusArgs{
@@ -162,7 +160,9 @@ func attachVolumes(ctx *context, ops map[params.MachineStorageId]*attachVolumeOp
 					MachineTag:    p.Machine.String(),
 					AttachmentTag: p.Volume.String(),
 				}
-				reschedule = append(reschedule, ops[id])
+				op := ops[id]
+				op.backoffFor(result.Error)
+				reschedule = append(reschedule, op)
 
 				// Note: we keep the status as "attaching" to
 				// indicate that we will retry. When we distinguish
@@ -217,11 +217,11 @@ func removeVolumes(ctx *context, ops map[names.VolumeTag]*removeVolumeOp) error
 	var remove []names.Tag
 	var reschedule []scheduleOp
 	var statuses []params.EntityStatusArgs
-	removeVolumes := func(tags []names.VolumeTag, ids []string, f func([]string) ([]error, error)) error {
+	removeVolumes := func(tags []names.VolumeTag, ids []string, f func(stdcontext.Context, []string) ([]error, error)) error {
 		if len(ids) == 0 {
 			return nil
 		}
-		errs, err := f(ids)
+		errs, err := f(ctx.providerContext, ids)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -315,10 +315,7 @@ func detachVolumes(ctx *context, ops map[params.MachineStorageId]*detachVolumeOp
 			// to do here.
 			continue
 		}
-		errs, err := volumeSource.DetachVolumes(volumeAttachmentParams)
-		if err != nil {
-			return errors.Annotatef(err, "detaching volumes from source %q", sourceName)
-		}
+		errs := detachVolumesInBatches(ctx, volumeSource, volumeAttachmentParams)
 		for i, err := range errs {
 			p := volumeAttachmentParams[i]
 			statuses = append(statuses, params.EntityStatusArgs{
@@ -335,7 +332,9 @@ func detachVolumes(ctx *context, ops map[params.MachineStorageId]*detachVolumeOp
 			}
 			entityStatus := &statuses[len(statuses)-1]
 			if err != nil {
-				reschedule = append(reschedule, ops[id])
+				op := ops[id]
+				op.backoffFor(err)
+				reschedule = append(reschedule, op)
 				entityStatus.Status = status.Detaching.String()
 				entityStatus.Info = err.Error()
 				logger.Debugf(
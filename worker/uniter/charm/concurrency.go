@@ -0,0 +1,37 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charm
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// ConcurrentRelationHooksKey is the charm metadata key with which a charm
+// opts in to having the hooks of distinct relations run concurrently.
+const ConcurrentRelationHooksKey = "concurrent-relation-hooks"
+
+// ConcurrentRelationHooks reports whether the charm deployed in the given
+// directory has opted in to having relation hooks for distinct relations
+// run concurrently, by setting ConcurrentRelationHooksKey to true in its
+// metadata. Hooks for the same relation are always run one at a time.
+func ConcurrentRelationHooks(charmDir string) (bool, error) {
+	data, err := ioutil.ReadFile(filepath.Join(charmDir, "metadata.yaml"))
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	var meta struct {
+		ConcurrentRelationHooks bool `yaml:"concurrent-relation-hooks"`
+	}
+	if err := yaml.Unmarshal(data, &meta); err != nil {
+		return false, errors.Annotate(err, "reading charm metadata")
+	}
+	return meta.ConcurrentRelationHooks, nil
+}
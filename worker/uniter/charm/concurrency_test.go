@@ -0,0 +1,54 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charm_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/charm"
+)
+
+type ConcurrencySuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&ConcurrencySuite{})
+
+func (s *ConcurrencySuite) writeMetadata(c *gc.C, content string) string {
+	dir := c.MkDir()
+	err := ioutil.WriteFile(filepath.Join(dir, "metadata.yaml"), []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	return dir
+}
+
+func (s *ConcurrencySuite) TestConcurrentRelationHooks(c *gc.C) {
+	dir := s.writeMetadata(c, "name: foo\nconcurrent-relation-hooks: true\n")
+	concurrent, err := charm.ConcurrentRelationHooks(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(concurrent, jc.IsTrue)
+}
+
+func (s *ConcurrencySuite) TestConcurrentRelationHooksNotSet(c *gc.C) {
+	dir := s.writeMetadata(c, "name: foo\n")
+	concurrent, err := charm.ConcurrentRelationHooks(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(concurrent, jc.IsFalse)
+}
+
+func (s *ConcurrencySuite) TestConcurrentRelationHooksNoCharm(c *gc.C) {
+	concurrent, err := charm.ConcurrentRelationHooks(c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(concurrent, jc.IsFalse)
+}
+
+func (s *ConcurrencySuite) TestConcurrentRelationHooksInvalid(c *gc.C) {
+	dir := s.writeMetadata(c, "concurrent-relation-hooks: [\n")
+	_, err := charm.ConcurrentRelationHooks(dir)
+	c.Assert(err, gc.ErrorMatches, "reading charm metadata: .*")
+}
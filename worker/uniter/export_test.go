@@ -0,0 +1,27 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/juju/worker/uniter/operation"
+)
+
+var (
+	ReadLocalState    = readLocalState
+	WriteLocalState   = writeLocalState
+	RestoreLocalState = restoreLocalState
+)
+
+// NewStateSnapshotter returns a function that records snapshots of the
+// uniter state under the supplied paths with the supplied recorder.
+func NewStateSnapshotter(recorder UniterStateRecorder, paths StatePaths) func() error {
+	s := &stateSnapshotter{recorder: recorder, paths: paths}
+	return s.snapshot
+}
+
+// NewSnapshotExecutor returns an operation.Executor that calls the
+// supplied snapshot function after each operation.
+func NewSnapshotExecutor(executor operation.Executor, snapshot func() error) operation.Executor {
+	return &snapshotExecutor{Executor: executor, snapshot: snapshot}
+}
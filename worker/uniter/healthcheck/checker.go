@@ -0,0 +1,155 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package healthcheck runs the health checks declared by a unit's
+// charm, and reports the unit as unhealthy while any of them are
+// failing. The checks are run by an operation returned from the
+// package's resolver, so that they are serialised with hooks.
+package healthcheck
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+)
+
+var logger = loggo.GetLogger("juju.worker.uniter.healthcheck")
+
+// Interval is the period at which the uniter should run the charm's
+// health checks. Each check is only run if its own interval has
+// passed since it last ran.
+const Interval = 5 * time.Second
+
+// Reporter is notified when the set of failing health checks changes.
+type Reporter interface {
+	// SetHealth records the names of the failing health checks. An
+	// empty slice means the unit is healthy.
+	SetHealth(failing []string) error
+}
+
+// Config holds the configuration for a health Checker.
+type Config struct {
+	CharmDir string
+	Clock    clock.Clock
+	Reporter Reporter
+	RunCheck RunCheckFunc
+}
+
+// Validate returns an error if the config cannot be used to create
+// a Checker.
+func (config Config) Validate() error {
+	if config.CharmDir == "" {
+		return errors.NotValidf("empty CharmDir")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Reporter == nil {
+		return errors.NotValidf("nil Reporter")
+	}
+	if config.RunCheck == nil {
+		return errors.NotValidf("nil RunCheck")
+	}
+	return nil
+}
+
+// Checker runs a charm's health checks. It is not safe for concurrent
+// use; the uniter runs it through its operation executor, so that
+// checks never run at the same time as hooks.
+type Checker struct {
+	config Config
+
+	// state records the consecutive failures and last run time of
+	// each check, keyed on check name.
+	state   map[string]*checkState
+	failing []string
+}
+
+type checkState struct {
+	lastRun  time.Time
+	failures int
+}
+
+// NewChecker returns a Checker that runs the health checks declared
+// in the configured charm directory.
+func NewChecker(config Config) (*Checker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Checker{
+		config: config,
+		state:  make(map[string]*checkState),
+	}, nil
+}
+
+// HasChecks returns whether the deployed charm declares health checks,
+// or health checks have been reported as failing and so must be
+// cleared.
+func (c *Checker) HasChecks() bool {
+	if len(c.failing) > 0 {
+		return true
+	}
+	_, err := os.Stat(filepath.Join(c.config.CharmDir, ChecksFile))
+	return err == nil
+}
+
+// RunChecks reloads the charm's health checks, so that changes made
+// by charm upgrades are picked up, and runs each check that is due.
+// If the set of failing checks has changed, it is reported; a failure
+// to report it is logged, and retried the next time checks are run.
+func (c *Checker) RunChecks() {
+	checks, err := ReadChecks(c.config.CharmDir)
+	if errors.IsNotFound(err) {
+		checks = nil
+	} else if err != nil {
+		// A broken declaration shouldn't take down the uniter; the
+		// charm author will see this in the unit's log.
+		logger.Errorf("cannot read health checks: %v", err)
+		return
+	}
+
+	now := c.config.Clock.Now()
+	seen := make(map[string]bool)
+	var failing []string
+	for _, check := range checks {
+		seen[check.Name] = true
+		st, ok := c.state[check.Name]
+		if !ok {
+			st = &checkState{}
+			c.state[check.Name] = st
+		}
+		if st.lastRun.IsZero() || now.Sub(st.lastRun) >= check.Interval {
+			st.lastRun = now
+			if err := c.config.RunCheck(c.config.CharmDir, check); err != nil {
+				st.failures++
+				logger.Debugf("health check %q failed (%d consecutive): %v", check.Name, st.failures, err)
+			} else {
+				st.failures = 0
+			}
+		}
+		if st.failures >= check.Threshold {
+			failing = append(failing, check.Name)
+		}
+	}
+	for name := range c.state {
+		if !seen[name] {
+			delete(c.state, name)
+		}
+	}
+
+	sort.Strings(failing)
+	if reflect.DeepEqual(failing, c.failing) {
+		return
+	}
+	if err := c.config.Reporter.SetHealth(failing); err != nil {
+		logger.Errorf("cannot report unit health: %v", err)
+		return
+	}
+	c.failing = failing
+}
@@ -0,0 +1,153 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthcheck_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/healthcheck"
+)
+
+type baseSuite struct {
+	testing.IsolationSuite
+
+	charmDir string
+	clock    *testing.Clock
+	reporter *fakeReporter
+
+	mu      sync.Mutex
+	healthy map[string]bool
+}
+
+type CheckerSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&CheckerSuite{})
+
+func (s *baseSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.charmDir = c.MkDir()
+	s.clock = testing.NewClock(time.Now())
+	s.reporter = &fakeReporter{}
+	s.healthy = map[string]bool{"db": true}
+	err := ioutil.WriteFile(
+		filepath.Join(s.charmDir, healthcheck.ChecksFile),
+		[]byte("checks: {db: {command: check-db, interval: 5s, threshold: 2}}"), 0644,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *baseSuite) runCheck(charmDir string, check healthcheck.Check) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.healthy[check.Name] {
+		return errors.New("down")
+	}
+	return nil
+}
+
+func (s *baseSuite) setHealthy(name string, healthy bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.healthy[name] = healthy
+}
+
+func (s *baseSuite) newChecker(c *gc.C) *healthcheck.Checker {
+	checker, err := healthcheck.NewChecker(healthcheck.Config{
+		CharmDir: s.charmDir,
+		Clock:    s.clock,
+		Reporter: s.reporter,
+		RunCheck: s.runCheck,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return checker
+}
+
+func (s *CheckerSuite) TestValidate(c *gc.C) {
+	config := healthcheck.Config{Clock: s.clock, Reporter: s.reporter, RunCheck: s.runCheck}
+	_, err := healthcheck.NewChecker(config)
+	c.Assert(err, gc.ErrorMatches, "empty CharmDir not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *CheckerSuite) TestReportsFailuresAfterThreshold(c *gc.C) {
+	s.setHealthy("db", false)
+	checker := s.newChecker(c)
+
+	// The first failure is below the threshold.
+	checker.RunChecks()
+	s.reporter.CheckNoCalls(c)
+
+	s.clock.Advance(5 * time.Second)
+	checker.RunChecks()
+	s.reporter.CheckCall(c, 0, "SetHealth", []string{"db"})
+
+	s.setHealthy("db", true)
+	s.clock.Advance(5 * time.Second)
+	checker.RunChecks()
+	s.reporter.CheckCall(c, 1, "SetHealth", []string(nil))
+}
+
+func (s *CheckerSuite) TestChecksRunAtTheirInterval(c *gc.C) {
+	s.setHealthy("db", false)
+	checker := s.newChecker(c)
+	checker.RunChecks()
+
+	// The check isn't due again yet, so its failure count doesn't
+	// reach the threshold.
+	s.clock.Advance(time.Second)
+	checker.RunChecks()
+	s.reporter.CheckNoCalls(c)
+}
+
+func (s *CheckerSuite) TestNoReportWhileHealthy(c *gc.C) {
+	checker := s.newChecker(c)
+	checker.RunChecks()
+	s.clock.Advance(5 * time.Second)
+	checker.RunChecks()
+	s.reporter.CheckNoCalls(c)
+}
+
+func (s *CheckerSuite) TestReportErrorRetried(c *gc.C) {
+	s.setHealthy("db", false)
+	s.reporter.SetErrors(errors.New("connection is shut down"))
+	checker := s.newChecker(c)
+	checker.RunChecks()
+	s.clock.Advance(5 * time.Second)
+	checker.RunChecks()
+
+	// The failed report is retried the next time checks are run,
+	// rather than stopping the checker.
+	s.clock.Advance(time.Second)
+	checker.RunChecks()
+	s.reporter.CheckCalls(c, []testing.StubCall{
+		{"SetHealth", []interface{}{[]string{"db"}}},
+		{"SetHealth", []interface{}{[]string{"db"}}},
+	})
+}
+
+func (s *CheckerSuite) TestHasChecks(c *gc.C) {
+	c.Assert(s.newChecker(c).HasChecks(), jc.IsTrue)
+
+	s.charmDir = c.MkDir()
+	c.Assert(s.newChecker(c).HasChecks(), jc.IsFalse)
+}
+
+type fakeReporter struct {
+	testing.Stub
+}
+
+func (r *fakeReporter) SetHealth(failing []string) error {
+	r.MethodCall(r, "SetHealth", failing)
+	return r.NextErr()
+}
@@ -0,0 +1,137 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthcheck
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// ChecksFile is the name of the file, in the root of the charm
+// directory, in which a charm declares its health checks.
+const ChecksFile = "healthchecks.yaml"
+
+const (
+	defaultInterval  = 30 * time.Second
+	defaultTimeout   = 10 * time.Second
+	defaultThreshold = 3
+)
+
+// Check describes a health check declared by a charm. Exactly one of
+// Command and HTTP is set.
+type Check struct {
+	// Name identifies the check.
+	Name string
+
+	// Command is a command, run in the charm directory, which must
+	// exit with status 0 for the check to pass.
+	Command string
+
+	// HTTP is a URL which must respond to a GET request with a
+	// non-error status code for the check to pass.
+	HTTP string
+
+	// Interval is the time between consecutive runs of the check.
+	Interval time.Duration
+
+	// Timeout is the time after which a running check is considered
+	// to have failed.
+	Timeout time.Duration
+
+	// Threshold is the number of consecutive failures after which
+	// the unit is considered unhealthy.
+	Threshold int
+}
+
+type checksDoc struct {
+	Checks map[string]checkDoc `yaml:"checks"`
+}
+
+type checkDoc struct {
+	Command   string `yaml:"command,omitempty"`
+	HTTP      string `yaml:"http,omitempty"`
+	Interval  string `yaml:"interval,omitempty"`
+	Timeout   string `yaml:"timeout,omitempty"`
+	Threshold int    `yaml:"threshold,omitempty"`
+}
+
+// ReadChecks reads the health checks declared in the given charm
+// directory. If the charm does not declare any checks, an error
+// satisfying errors.IsNotFound is returned.
+func ReadChecks(charmDir string) ([]Check, error) {
+	data, err := ioutil.ReadFile(filepath.Join(charmDir, ChecksFile))
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("health checks")
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ParseChecks(data)
+}
+
+// ParseChecks parses the YAML health check declarations in data.
+func ParseChecks(data []byte) ([]Check, error) {
+	var doc checksDoc
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, errors.Annotate(err, "parsing health checks")
+	}
+	checks := make([]Check, 0, len(doc.Checks))
+	for name, cd := range doc.Checks {
+		check, err := cd.check(name)
+		if err != nil {
+			return nil, errors.Annotatef(err, "health check %q", name)
+		}
+		checks = append(checks, check)
+	}
+	sort.Sort(byName(checks))
+	return checks, nil
+}
+
+func (cd checkDoc) check(name string) (Check, error) {
+	check := Check{
+		Name:      name,
+		Command:   cd.Command,
+		HTTP:      cd.HTTP,
+		Interval:  defaultInterval,
+		Timeout:   defaultTimeout,
+		Threshold: defaultThreshold,
+	}
+	if (check.Command == "") == (check.HTTP == "") {
+		return Check{}, errors.NotValidf("expected exactly one of command or http")
+	}
+	var err error
+	if cd.Interval != "" {
+		if check.Interval, err = time.ParseDuration(cd.Interval); err != nil {
+			return Check{}, errors.Annotate(err, "parsing interval")
+		}
+		if check.Interval <= 0 {
+			return Check{}, errors.NotValidf("non-positive interval")
+		}
+	}
+	if cd.Timeout != "" {
+		if check.Timeout, err = time.ParseDuration(cd.Timeout); err != nil {
+			return Check{}, errors.Annotate(err, "parsing timeout")
+		}
+		if check.Timeout <= 0 {
+			return Check{}, errors.NotValidf("non-positive timeout")
+		}
+	}
+	if cd.Threshold < 0 {
+		return Check{}, errors.NotValidf("negative threshold")
+	} else if cd.Threshold > 0 {
+		check.Threshold = cd.Threshold
+	}
+	return check, nil
+}
+
+type byName []Check
+
+func (b byName) Len() int           { return len(b) }
+func (b byName) Less(i, j int) bool { return b[i].Name < b[j].Name }
+func (b byName) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
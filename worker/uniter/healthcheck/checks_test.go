@@ -0,0 +1,94 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthcheck_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/healthcheck"
+)
+
+type ChecksSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ChecksSuite{})
+
+func (s *ChecksSuite) TestParseChecks(c *gc.C) {
+	checks, err := healthcheck.ParseChecks([]byte(`
+checks:
+  web:
+    http: http://localhost:8080/health
+    interval: 1m
+    timeout: 2s
+    threshold: 1
+  db:
+    command: check-db
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checks, jc.DeepEquals, []healthcheck.Check{{
+		Name:      "db",
+		Command:   "check-db",
+		Interval:  30 * time.Second,
+		Timeout:   10 * time.Second,
+		Threshold: 3,
+	}, {
+		Name:      "web",
+		HTTP:      "http://localhost:8080/health",
+		Interval:  time.Minute,
+		Timeout:   2 * time.Second,
+		Threshold: 1,
+	}})
+}
+
+func (s *ChecksSuite) TestParseChecksInvalid(c *gc.C) {
+	for i, test := range []struct {
+		yaml string
+		err  string
+	}{{
+		yaml: "checks: {a: {}}",
+		err:  `health check "a": expected exactly one of command or http not valid`,
+	}, {
+		yaml: "checks: {a: {command: x, http: y}}",
+		err:  `health check "a": expected exactly one of command or http not valid`,
+	}, {
+		yaml: "checks: {a: {command: x, interval: soon}}",
+		err:  `health check "a": parsing interval: time: invalid duration .*`,
+	}, {
+		yaml: "checks: {a: {command: x, timeout: -1s}}",
+		err:  `health check "a": non-positive timeout not valid`,
+	}, {
+		yaml: "checks: {a: {command: x, threshold: -1}}",
+		err:  `health check "a": negative threshold not valid`,
+	}} {
+		c.Logf("test %d: %s", i, test.yaml)
+		_, err := healthcheck.ParseChecks([]byte(test.yaml))
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ChecksSuite) TestReadChecksMissing(c *gc.C) {
+	_, err := healthcheck.ReadChecks(c.MkDir())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ChecksSuite) TestReadChecks(c *gc.C) {
+	dir := c.MkDir()
+	err := ioutil.WriteFile(
+		filepath.Join(dir, healthcheck.ChecksFile),
+		[]byte("checks: {a: {command: 'true'}}"), 0644,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	checks, err := healthcheck.ReadChecks(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checks, gc.HasLen, 1)
+	c.Assert(checks[0].Command, gc.Equals, "true")
+}
@@ -0,0 +1,62 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthcheck
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/status"
+)
+
+// StatusUnit exposes the unit workload status methods needed by
+// the Reporter returned from NewStatusReporter.
+type StatusUnit interface {
+	UnitStatus() (params.StatusResult, error)
+	SetUnitStatus(status.Status, string, map[string]interface{}) error
+}
+
+// NewStatusReporter returns a Reporter which sets the unit's workload
+// status to "unhealthy" while health checks are failing, and restores
+// the previous workload status once they pass again.
+func NewStatusReporter(unit StatusUnit) Reporter {
+	return &statusReporter{unit: unit}
+}
+
+type statusReporter struct {
+	unit     StatusUnit
+	previous *params.StatusResult
+}
+
+// SetHealth is part of the Reporter interface.
+func (r *statusReporter) SetHealth(failing []string) error {
+	current, err := r.unit.UnitStatus()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if current.Error != nil {
+		return errors.Trace(current.Error)
+	}
+	if len(failing) > 0 {
+		if current.Status != string(status.Unhealthy) {
+			r.previous = &current
+		}
+		message := "health checks failing: " + strings.Join(failing, ", ")
+		return errors.Trace(r.unit.SetUnitStatus(status.Unhealthy, message, nil))
+	}
+	if current.Status != string(status.Unhealthy) {
+		// The charm has set a new status since the checks started
+		// failing; it takes precedence.
+		r.previous = nil
+		return nil
+	}
+	restore := params.StatusResult{Status: string(status.Active)}
+	if r.previous != nil {
+		restore = *r.previous
+		r.previous = nil
+	}
+	return errors.Trace(r.unit.SetUnitStatus(status.Status(restore.Status), restore.Info, restore.Data))
+}
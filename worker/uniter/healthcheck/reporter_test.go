@@ -0,0 +1,64 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthcheck_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/worker/uniter/healthcheck"
+)
+
+type ReporterSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ReporterSuite{})
+
+func (s *ReporterSuite) TestUnhealthyAndRestore(c *gc.C) {
+	unit := &fakeUnit{current: params.StatusResult{Status: "blocked", Info: "need config"}}
+	reporter := healthcheck.NewStatusReporter(unit)
+
+	err := reporter.SetHealth([]string{"db", "web"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit.current.Status, gc.Equals, "unhealthy")
+	c.Assert(unit.current.Info, gc.Equals, "health checks failing: db, web")
+
+	err = reporter.SetHealth(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit.current.Status, gc.Equals, "blocked")
+	c.Assert(unit.current.Info, gc.Equals, "need config")
+}
+
+func (s *ReporterSuite) TestRecoveryKeepsNewerStatus(c *gc.C) {
+	unit := &fakeUnit{current: params.StatusResult{Status: "active"}}
+	reporter := healthcheck.NewStatusReporter(unit)
+
+	err := reporter.SetHealth([]string{"db"})
+	c.Assert(err, jc.ErrorIsNil)
+	unit.current = params.StatusResult{Status: "maintenance", Info: "upgrading"}
+
+	err = reporter.SetHealth(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit.current.Status, gc.Equals, "maintenance")
+	c.Assert(unit.sets, gc.Equals, 1)
+}
+
+type fakeUnit struct {
+	current params.StatusResult
+	sets    int
+}
+
+func (u *fakeUnit) UnitStatus() (params.StatusResult, error) {
+	return u.current, nil
+}
+
+func (u *fakeUnit) SetUnitStatus(s status.Status, info string, data map[string]interface{}) error {
+	u.sets++
+	u.current = params.StatusResult{Status: string(s), Info: info, Data: data}
+	return nil
+}
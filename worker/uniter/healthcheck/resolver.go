@@ -0,0 +1,81 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthcheck
+
+import (
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/remotestate"
+	"github.com/juju/juju/worker/uniter/resolver"
+)
+
+// healthResolver is a Resolver that returns operations to run the
+// charm's health checks whenever the remote state's HealthCheckVersion
+// changes while the uniter has nothing else to do.
+type healthResolver struct {
+	checker *Checker
+	version int
+}
+
+// NewResolver returns a new Resolver that returns operations to run
+// the health checks of the given Checker.
+func NewResolver(checker *Checker) resolver.Resolver {
+	return &healthResolver{checker: checker}
+}
+
+// NextOp is part of the resolver.Resolver interface.
+func (r *healthResolver) NextOp(
+	localState resolver.LocalState,
+	remoteState remotestate.Snapshot,
+	opFactory operation.Factory,
+) (operation.Operation, error) {
+	if localState.Kind != operation.Continue || !localState.Started {
+		return nil, resolver.ErrNoOperation
+	}
+	version := remoteState.HealthCheckVersion
+	if version == r.version {
+		return nil, resolver.ErrNoOperation
+	}
+	if !r.checker.HasChecks() {
+		r.version = version
+		return nil, resolver.ErrNoOperation
+	}
+	return &runChecks{
+		checker:   r.checker,
+		committed: func() { r.version = version },
+	}, nil
+}
+
+// runChecks is an operation that runs the charm's health checks. It
+// doesn't change the uniter's operation state.
+type runChecks struct {
+	checker   *Checker
+	committed func()
+}
+
+// String is part of the operation.Operation interface.
+func (op *runChecks) String() string {
+	return "run health checks"
+}
+
+// NeedsGlobalMachineLock is part of the operation.Operation interface.
+func (op *runChecks) NeedsGlobalMachineLock() bool {
+	return false
+}
+
+// Prepare is part of the operation.Operation interface.
+func (op *runChecks) Prepare(state operation.State) (*operation.State, error) {
+	return nil, nil
+}
+
+// Execute is part of the operation.Operation interface.
+func (op *runChecks) Execute(state operation.State) (*operation.State, error) {
+	op.checker.RunChecks()
+	return nil, nil
+}
+
+// Commit is part of the operation.Operation interface.
+func (op *runChecks) Commit(state operation.State) (*operation.State, error) {
+	op.committed()
+	return nil, nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthcheck_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/healthcheck"
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/remotestate"
+	"github.com/juju/juju/worker/uniter/resolver"
+)
+
+type ResolverSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&ResolverSuite{})
+
+func (s *ResolverSuite) idleState() resolver.LocalState {
+	return resolver.LocalState{
+		State: operation.State{
+			Kind:    operation.Continue,
+			Started: true,
+		},
+	}
+}
+
+func (s *ResolverSuite) TestNoOperationUntilTimer(c *gc.C) {
+	r := healthcheck.NewResolver(s.newChecker(c))
+	_, err := r.NextOp(s.idleState(), remotestate.Snapshot{}, nil)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
+func (s *ResolverSuite) TestRunsChecks(c *gc.C) {
+	s.setHealthy("db", false)
+	r := healthcheck.NewResolver(s.newChecker(c))
+	remoteState := remotestate.Snapshot{HealthCheckVersion: 1}
+
+	op, err := r.NextOp(s.idleState(), remoteState, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run health checks")
+	c.Assert(op.NeedsGlobalMachineLock(), jc.IsFalse)
+	runOperation(c, op)
+
+	// The checks run again only when the timer next fires.
+	_, err = r.NextOp(s.idleState(), remoteState, nil)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+
+	s.clock.Advance(healthcheck.Interval)
+	remoteState.HealthCheckVersion++
+	op, err = r.NextOp(s.idleState(), remoteState, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	runOperation(c, op)
+	s.reporter.CheckCall(c, 0, "SetHealth", []string{"db"})
+}
+
+func (s *ResolverSuite) TestNoChecksWhileHookPending(c *gc.C) {
+	r := healthcheck.NewResolver(s.newChecker(c))
+	localState := s.idleState()
+	localState.Kind = operation.RunHook
+	localState.Step = operation.Pending
+	_, err := r.NextOp(localState, remotestate.Snapshot{HealthCheckVersion: 1}, nil)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
+func (s *ResolverSuite) TestNoChecksDeclared(c *gc.C) {
+	s.charmDir = c.MkDir()
+	r := healthcheck.NewResolver(s.newChecker(c))
+	_, err := r.NextOp(s.idleState(), remotestate.Snapshot{HealthCheckVersion: 1}, nil)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
+func runOperation(c *gc.C, op operation.Operation) {
+	state, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(state, gc.IsNil)
+	state, err = op.Execute(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(state, gc.IsNil)
+	state, err = op.Commit(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(state, gc.IsNil)
+}
@@ -0,0 +1,62 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthcheck
+
+import (
+	"context"
+	"net/http"
+	"os/exec"
+	"runtime"
+
+	"github.com/juju/errors"
+)
+
+// RunCheckFunc runs a single health check in the given charm
+// directory, returning an error if the check fails.
+type RunCheckFunc func(charmDir string, check Check) error
+
+// RunCheck is the default RunCheckFunc. Command checks are run using
+// the shell; HTTP checks issue a GET request and fail on any response
+// status of 400 or above.
+func RunCheck(charmDir string, check Check) error {
+	ctx, cancel := context.WithTimeout(context.Background(), check.Timeout)
+	defer cancel()
+	if check.Command != "" {
+		return runCommand(ctx, charmDir, check.Command)
+	}
+	return getHTTP(ctx, check.HTTP)
+}
+
+func runCommand(ctx context.Context, dir, command string) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "powershell.exe", "-NonInteractive", "-Command", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "/bin/bash", "-c", command)
+	}
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if ctx.Err() != nil {
+			return errors.Errorf("command timed out")
+		}
+		return errors.Annotatef(err, "command failed: %q", out)
+	}
+	return nil
+}
+
+func getHTTP(ctx context.Context, url string) error {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return errors.Errorf("unexpected response %q", resp.Status)
+	}
+	return nil
+}
@@ -16,6 +16,10 @@ const (
 	LeaderElected         hooks.Kind = "leader-elected"
 	LeaderDeposed         hooks.Kind = "leader-deposed"
 	LeaderSettingsChanged hooks.Kind = "leader-settings-changed"
+
+	// Drain is run on a dying unit, before its relations are broken,
+	// when a drain was requested as the unit was destroyed.
+	Drain hooks.Kind = "drain"
 )
 
 // Info holds details required to execute a hook. Not all fields are
@@ -58,7 +62,7 @@ func (hi Info) Validate() error {
 		}
 		return nil
 	// TODO(fwereade): define these in charm/hooks...
-	case LeaderElected, LeaderDeposed, LeaderSettingsChanged:
+	case LeaderElected, LeaderDeposed, LeaderSettingsChanged, Drain:
 		return nil
 	}
 	return fmt.Errorf("unknown hook kind %q", hi.Kind)
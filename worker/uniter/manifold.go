@@ -4,8 +4,11 @@
 package uniter
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/utils/clock"
+	"github.com/prometheus/client_golang/prometheus"
 	"gopkg.in/juju/names.v2"
 	worker "gopkg.in/juju/worker.v1"
 
@@ -31,6 +34,14 @@ type ManifoldConfig struct {
 	CharmDirName          string
 	HookRetryStrategyName string
 	TranslateResolverErr  func(error) error
+
+	// RelationCoalescePeriod is the minimum time between deliveries
+	// of changes to the units of each relation.
+	RelationCoalescePeriod time.Duration
+
+	// PrometheusRegisterer, if not nil, is used to register the
+	// uniter's metrics collectors.
+	PrometheusRegisterer prometheus.Registerer
 }
 
 // Manifold returns a dependency manifold that runs a uniter worker,
@@ -102,6 +113,9 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				NewOperationExecutor: operation.NewExecutor,
 				TranslateResolverErr: config.TranslateResolverErr,
 				Clock:                manifoldConfig.Clock,
+
+				RelationCoalescePeriod: manifoldConfig.RelationCoalescePeriod,
+				PrometheusRegisterer:   manifoldConfig.PrometheusRegisterer,
 			})
 			if err != nil {
 				return nil, errors.Trace(err)
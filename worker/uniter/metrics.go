@@ -0,0 +1,60 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/juju/names.v2"
+)
+
+// relationMetrics is a prometheus.Collector that counts the relation
+// units changes received by a unit's remote state watcher, and those
+// that were coalesced into earlier changes rather than delivered; the
+// latter would otherwise have caused relation-changed hooks.
+type relationMetrics struct {
+	received  prometheus.Counter
+	coalesced prometheus.Counter
+}
+
+func newRelationMetrics(unitTag names.UnitTag) *relationMetrics {
+	labels := prometheus.Labels{"unit": unitTag.Id()}
+	return &relationMetrics{
+		received: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace:   "juju",
+			Subsystem:   "uniter",
+			Name:        "relation_changes_received_total",
+			Help:        "Total number of relation units changes received.",
+			ConstLabels: labels,
+		}),
+		coalesced: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace:   "juju",
+			Subsystem:   "uniter",
+			Name:        "relation_changes_coalesced_total",
+			Help:        "Total number of relation units changes coalesced into earlier changes.",
+			ConstLabels: labels,
+		}),
+	}
+}
+
+// ChangeReceived is part of the remotestate.RelationMetrics interface.
+func (m *relationMetrics) ChangeReceived() {
+	m.received.Inc()
+}
+
+// ChangeCoalesced is part of the remotestate.RelationMetrics interface.
+func (m *relationMetrics) ChangeCoalesced() {
+	m.coalesced.Inc()
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (m *relationMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.received.Describe(ch)
+	m.coalesced.Describe(ch)
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (m *relationMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.received.Collect(ch)
+	m.coalesced.Collect(ch)
+}
@@ -84,6 +84,30 @@ func (f *factory) NewRunHook(hookInfo hook.Info) (Operation, error) {
 	}, nil
 }
 
+// NewRunHooks is part of the Factory interface.
+func (f *factory) NewRunHooks(hookInfos []hook.Info) (Operation, error) {
+	if len(hookInfos) == 0 {
+		return nil, errors.New("hooks required")
+	}
+	relationIds := make(map[int]bool)
+	op := &runHooks{}
+	for _, hookInfo := range hookInfos {
+		if !hookInfo.Kind.IsRelation() {
+			return nil, errors.Errorf("not a relation hook: %q", hookInfo.Kind)
+		}
+		if relationIds[hookInfo.RelationId] {
+			return nil, errors.Errorf("more than one hook for relation %d", hookInfo.RelationId)
+		}
+		relationIds[hookInfo.RelationId] = true
+		hookOp, err := f.NewRunHook(hookInfo)
+		if err != nil {
+			return nil, err
+		}
+		op.hooks = append(op.hooks, hookOp.(*runHook))
+	}
+	return op, nil
+}
+
 // NewSkipHook is part of the Factory interface.
 func (f *factory) NewSkipHook(hookInfo hook.Info) (Operation, error) {
 	hookOp, err := f.NewRunHook(hookInfo)
@@ -83,6 +83,11 @@ type Factory interface {
 	// NewRunHook creates an operation to execute the supplied hook.
 	NewRunHook(hookInfo hook.Info) (Operation, error)
 
+	// NewRunHooks creates an operation to execute the supplied relation
+	// hooks concurrently, each in its own context. Each hook must be for
+	// a different relation.
+	NewRunHooks(hookInfos []hook.Info) (Operation, error)
+
 	// NewSkipHook creates an operation to mark the supplied hook as
 	// completed successfully, without executing the hook.
 	NewSkipHook(hookInfo hook.Info) (Operation, error)
@@ -153,6 +153,11 @@ func (rh *runHook) beforeHook(state State) error {
 			Status: string(status.Maintenance),
 			Info:   "cleaning up prior to charm deletion",
 		})
+	case hook.Drain:
+		err = rh.runner.Context().SetUnitStatus(jujuc.StatusInfo{
+			Status: string(status.Maintenance),
+			Info:   "draining prior to removal",
+		})
 	}
 	if err != nil {
 		logger.Errorf("error updating workload status before %v hook: %v", rh.info.Kind, err)
@@ -242,6 +247,8 @@ func (rh *runHook) Commit(state State) (*State, error) {
 		newState.Started = true
 	case hooks.Stop:
 		newState.Stopped = true
+	case hook.Drain:
+		newState.Drained = true
 	}
 
 	return newState, nil
@@ -549,6 +549,26 @@ func (s *RunHookSuite) TestCommitSuccess_Start_SetStarted(c *gc.C) {
 	}
 }
 
+func (s *RunHookSuite) TestCommitSuccess_Drain_SetDrained(c *gc.C) {
+	for i, newHook := range []newHook{
+		(operation.Factory).NewRunHook,
+		(operation.Factory).NewSkipHook,
+	} {
+		c.Logf("variant %d", i)
+		s.testCommitSuccess(c,
+			newHook,
+			hook.Info{Kind: hook.Drain},
+			operation.State{Started: true},
+			operation.State{
+				Started: true,
+				Drained: true,
+				Kind:    operation.Continue,
+				Step:    operation.Pending,
+			},
+		)
+	}
+}
+
 func (s *RunHookSuite) TestCommitSuccess_Start_Preserve(c *gc.C) {
 	for i, newHook := range []newHook{
 		(operation.Factory).NewRunHook,
@@ -0,0 +1,124 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operation
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// runHooks runs the hooks of distinct relations concurrently, each in its
+// own hook context. The state recorded while they run refers to the first
+// hook; if any of the hooks fail, the hooks that succeeded are committed,
+// and the failed hook is recorded so that it can be retried or resolved
+// on its own.
+type runHooks struct {
+	hooks []*runHook
+
+	RequiresMachineLock
+}
+
+// String is part of the Operation interface.
+func (rh *runHooks) String() string {
+	names := make([]string, len(rh.hooks))
+	for i, h := range rh.hooks {
+		names[i] = h.String()
+	}
+	return fmt.Sprintf("concurrently %s", strings.Join(names, ", "))
+}
+
+// Prepare ensures that all of the hooks can be executed.
+// Prepare is part of the Operation interface.
+func (rh *runHooks) Prepare(state State) (*State, error) {
+	var newState *State
+	for _, h := range rh.hooks {
+		hookState, err := h.Prepare(state)
+		if err != nil {
+			return nil, err
+		}
+		if newState == nil {
+			newState = hookState
+		}
+	}
+	return newState, nil
+}
+
+// hookResult holds the outcome of executing one of the hooks.
+type hookResult struct {
+	state *State
+	err   error
+}
+
+// Execute runs the hooks concurrently, waiting for them all to finish.
+// Execute is part of the Operation interface.
+func (rh *runHooks) Execute(state State) (*State, error) {
+	results := make([]hookResult, len(rh.hooks))
+	var wg sync.WaitGroup
+	for i, h := range rh.hooks {
+		wg.Add(1)
+		go func(i int, h *runHook) {
+			defer wg.Done()
+			results[i].state, results[i].err = h.Execute(state)
+		}(i, h)
+	}
+	wg.Wait()
+
+	// Only one hook's outcome can be recorded, so prefer a failure
+	// to a reboot request.
+	primary := -1
+	for i, result := range results {
+		if result.err == nil {
+			continue
+		}
+		if primary == -1 || (results[primary].err == ErrNeedsReboot && result.err != ErrNeedsReboot) {
+			primary = i
+		}
+	}
+	if primary == -1 {
+		newState := *results[0].state
+		for _, result := range results[1:] {
+			newState.StatusSet = newState.StatusSet || result.state.StatusSet
+		}
+		return &newState, nil
+	}
+
+	// Commit the hooks that ran to completion, so that only the
+	// recorded hook, and any other hooks that failed, run again.
+	for i, result := range results {
+		if i == primary {
+			continue
+		}
+		done := result.err == nil || (result.err == ErrNeedsReboot && result.state.Step == Done)
+		if !done {
+			logger.Infof("%s will be run again", rh.hooks[i])
+			continue
+		}
+		if _, err := rh.hooks[i].Commit(state); err != nil {
+			return nil, err
+		}
+	}
+	result := results[primary]
+	if result.state == nil {
+		result.state = stateChange{
+			Kind: RunHook,
+			Step: Pending,
+			Hook: &rh.hooks[primary].info,
+		}.apply(state)
+	}
+	return result.state, result.err
+}
+
+// Commit records the completion of all of the hooks.
+// Commit is part of the Operation interface.
+func (rh *runHooks) Commit(state State) (*State, error) {
+	for _, h := range rh.hooks {
+		newState, err := h.Commit(state)
+		if err != nil {
+			return nil, err
+		}
+		state = *newState
+	}
+	return &state, nil
+}
@@ -0,0 +1,164 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operation_test
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6/hooks"
+
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/runner"
+)
+
+type RunHooksSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&RunHooksSuite{})
+
+// runHooksCallbacks records the hooks committed by a runHooks operation.
+type runHooksCallbacks struct {
+	operation.Callbacks
+
+	mu        sync.Mutex
+	committed []hook.Info
+}
+
+func (cb *runHooksCallbacks) PrepareHook(hookInfo hook.Info) (string, error) {
+	return fmt.Sprintf("rel%d-%s", hookInfo.RelationId, hookInfo.Kind), nil
+}
+
+func (cb *runHooksCallbacks) SetExecutingStatus(string) error {
+	return nil
+}
+
+func (cb *runHooksCallbacks) NotifyHookCompleted(string, runner.Context) {}
+
+func (cb *runHooksCallbacks) NotifyHookFailed(string, runner.Context) {}
+
+func (cb *runHooksCallbacks) CommitHook(hookInfo hook.Info) error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.committed = append(cb.committed, hookInfo)
+	return nil
+}
+
+// runHooksRunnerFactory creates a runner for each hook, failing the hooks
+// of the relations with errors.
+type runHooksRunnerFactory struct {
+	runner.Factory
+	errs map[int]error
+}
+
+func (f *runHooksRunnerFactory) NewHookRunner(hookInfo hook.Info) (runner.Runner, error) {
+	return &MockRunner{
+		MockRunHook: &MockRunHook{err: f.errs[hookInfo.RelationId]},
+		context:     &MockContext{},
+	}, nil
+}
+
+var runHooksInfos = []hook.Info{{
+	Kind:       hooks.RelationJoined,
+	RelationId: 1,
+	RemoteUnit: "foo/0",
+}, {
+	Kind:       hooks.RelationChanged,
+	RelationId: 2,
+	RemoteUnit: "bar/0",
+}, {
+	Kind:       hooks.RelationDeparted,
+	RelationId: 3,
+	RemoteUnit: "baz/0",
+}}
+
+func (s *RunHooksSuite) newOp(c *gc.C, errs map[int]error) (operation.Operation, *runHooksCallbacks) {
+	callbacks := &runHooksCallbacks{}
+	factory := operation.NewFactory(operation.FactoryParams{
+		Callbacks:     callbacks,
+		RunnerFactory: &runHooksRunnerFactory{errs: errs},
+	})
+	op, err := factory.NewRunHooks(runHooksInfos)
+	c.Assert(err, jc.ErrorIsNil)
+	return op, callbacks
+}
+
+func (s *RunHooksSuite) TestNewRunHooksValidation(c *gc.C) {
+	factory := operation.NewFactory(operation.FactoryParams{})
+	_, err := factory.NewRunHooks(nil)
+	c.Check(err, gc.ErrorMatches, "hooks required")
+
+	_, err = factory.NewRunHooks([]hook.Info{{Kind: hooks.Install}})
+	c.Check(err, gc.ErrorMatches, `not a relation hook: "install"`)
+
+	_, err = factory.NewRunHooks([]hook.Info{runHooksInfos[0], {
+		Kind:       hooks.RelationChanged,
+		RelationId: 1,
+		RemoteUnit: "foo/0",
+	}})
+	c.Check(err, gc.ErrorMatches, "more than one hook for relation 1")
+}
+
+func (s *RunHooksSuite) TestString(c *gc.C) {
+	op, _ := s.newOp(c, nil)
+	c.Assert(op.String(), gc.Equals, "concurrently "+
+		"run relation-joined (1; foo/0) hook, "+
+		"run relation-changed (2; bar/0) hook, "+
+		"run relation-departed (3; baz/0) hook")
+	c.Assert(op.NeedsGlobalMachineLock(), jc.IsTrue)
+}
+
+func (s *RunHooksSuite) TestRun(c *gc.C) {
+	op, callbacks := s.newOp(c, nil)
+	state := operation.State{Kind: operation.Continue, Step: operation.Pending}
+
+	newState, err := op.Prepare(state)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newState, jc.DeepEquals, &operation.State{
+		Kind: operation.RunHook,
+		Step: operation.Pending,
+		Hook: &runHooksInfos[0],
+	})
+
+	newState, err = op.Execute(*newState)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newState, jc.DeepEquals, &operation.State{
+		Kind: operation.RunHook,
+		Step: operation.Done,
+		Hook: &runHooksInfos[0],
+	})
+	c.Assert(callbacks.committed, gc.HasLen, 0)
+
+	newState, err = op.Commit(*newState)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newState, jc.DeepEquals, &operation.State{
+		Kind: operation.Continue,
+		Step: operation.Pending,
+	})
+	c.Assert(callbacks.committed, jc.DeepEquals, runHooksInfos)
+}
+
+func (s *RunHooksSuite) TestExecuteHookFailed(c *gc.C) {
+	op, callbacks := s.newOp(c, map[int]error{2: errors.New("boom")})
+	state := operation.State{Kind: operation.Continue, Step: operation.Pending}
+	newState, err := op.Prepare(state)
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err = op.Execute(*newState)
+	c.Assert(err, gc.Equals, operation.ErrHookFailed)
+	c.Assert(newState, jc.DeepEquals, &operation.State{
+		Kind: operation.RunHook,
+		Step: operation.Pending,
+		Hook: &runHooksInfos[1],
+	})
+	c.Assert(callbacks.committed, jc.SameContents, []hook.Info{
+		runHooksInfos[0], runHooksInfos[2],
+	})
+}
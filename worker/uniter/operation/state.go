@@ -65,6 +65,9 @@ type State struct {
 	// Stopped indicates whether the stop hook has run.
 	Stopped bool `yaml:"stopped"`
 
+	// Drained indicates whether the drain hook has run.
+	Drained bool `yaml:"drained,omitempty"`
+
 	// Installed indicates whether the install hook has run.
 	Installed bool `yaml:"installed"`
 
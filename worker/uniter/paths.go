@@ -103,6 +103,10 @@ type StatePaths struct {
 	// MetricsSpoolDir acts as temporary storage for metrics being sent from
 	// the uniter to state.
 	MetricsSpoolDir string
+
+	// HookOutputDir holds the output of hooks, actions and hook tools
+	// that is too large to be retained in memory.
+	HookOutputDir string
 }
 
 // NewPaths returns the set of filesystem paths that the supplied unit should
@@ -153,6 +157,7 @@ func NewWorkerPaths(dataDir string, unitTag names.UnitTag, worker string) Paths
 			DeployerDir:     join(stateDir, "deployer"),
 			StorageDir:      join(stateDir, "storage"),
 			MetricsSpoolDir: join(stateDir, "spool", "metrics"),
+			HookOutputDir:   join(stateDir, "spool", "output"),
 		},
 	}
 }
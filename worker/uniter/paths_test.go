@@ -52,6 +52,7 @@ func (s *PathsSuite) TestWindows(c *gc.C) {
 			DeployerDir:     relAgent("state", "deployer"),
 			StorageDir:      relAgent("state", "storage"),
 			MetricsSpoolDir: relAgent("state", "spool", "metrics"),
+			HookOutputDir:   relAgent("state", "spool", "output"),
 		},
 	})
 }
@@ -81,6 +82,7 @@ func (s *PathsSuite) TestWorkerPathsWindows(c *gc.C) {
 			DeployerDir:     relAgent("state", "deployer"),
 			StorageDir:      relAgent("state", "storage"),
 			MetricsSpoolDir: relAgent("state", "spool", "metrics"),
+			HookOutputDir:   relAgent("state", "spool", "output"),
 		},
 	})
 }
@@ -109,6 +111,7 @@ func (s *PathsSuite) TestOther(c *gc.C) {
 			DeployerDir:     relAgent("state", "deployer"),
 			StorageDir:      relAgent("state", "storage"),
 			MetricsSpoolDir: relAgent("state", "spool", "metrics"),
+			HookOutputDir:   relAgent("state", "spool", "output"),
 		},
 	})
 }
@@ -138,6 +141,7 @@ func (s *PathsSuite) TestWorkerPaths(c *gc.C) {
 			DeployerDir:     relAgent("state", "deployer"),
 			StorageDir:      relAgent("state", "storage"),
 			MetricsSpoolDir: relAgent("state", "spool", "metrics"),
+			HookOutputDir:   relAgent("state", "spool", "output"),
 		},
 	})
 }
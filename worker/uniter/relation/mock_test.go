@@ -18,6 +18,15 @@ func (m *mockOperations) NewRunHook(hookInfo hook.Info) (operation.Operation, er
 	return &mockOperation{hookInfo}, nil
 }
 
+func (m *mockOperations) NewRunHooks(hookInfos []hook.Info) (operation.Operation, error) {
+	return &mockHooksOperation{hookInfos}, nil
+}
+
+type mockHooksOperation struct {
+	operation.Operation
+	hookInfos []hook.Info
+}
+
 type mockOperation struct {
 	hookInfo hook.Info
 }
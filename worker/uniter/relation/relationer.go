@@ -16,9 +16,10 @@ import (
 
 // Relationer manages a unit's presence in a relation.
 type Relationer struct {
-	ru    *apiuniter.RelationUnit
-	dir   *StateDir
-	dying bool
+	ru       *apiuniter.RelationUnit
+	dir      *StateDir
+	dying    bool
+	departed bool
 }
 
 // NewRelationer creates a new Relationer. The unit will not join the
@@ -76,6 +77,20 @@ func (r *Relationer) SetDying() error {
 	return nil
 }
 
+// SetDeparted records that the unit has run the relation-departed hooks
+// for all of its counterparts in the relation, and is waiting to run
+// relation-broken. The departure is only recorded once.
+func (r *Relationer) SetDeparted() error {
+	if r.departed {
+		return nil
+	}
+	if err := r.ru.SetDeparted(); err != nil {
+		return errors.Trace(err)
+	}
+	r.departed = true
+	return nil
+}
+
 // die is run when the relationer has no further responsibilities; it leaves
 // relation scope, and removes the local relation state directory.
 func (r *Relationer) die() error {
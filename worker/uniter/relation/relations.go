@@ -4,6 +4,8 @@
 package relation
 
 import (
+	"sort"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/set"
@@ -16,6 +18,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/relation"
+	"github.com/juju/juju/worker/uniter/charm"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/remotestate"
@@ -47,6 +50,12 @@ type Relations interface {
 	// NextHook returns details on the next hook to execute, based on the local
 	// and remote states.
 	NextHook(resolver.LocalState, remotestate.Snapshot) (hook.Info, error)
+
+	// NextHooks returns details on the next hooks to execute, based on the
+	// local and remote states. If the unit's charm allows relation hooks to
+	// run concurrently, there is a hook for each relation that needs one,
+	// ordered by relation id; otherwise there is only ever a single hook.
+	NextHooks(resolver.LocalState, remotestate.Snapshot) ([]hook.Info, error)
 }
 
 // NewRelationsResolver returns a new Resolver that handles differences in
@@ -65,11 +74,14 @@ func (s *relationsResolver) NextOp(
 	remoteState remotestate.Snapshot,
 	opFactory operation.Factory,
 ) (operation.Operation, error) {
-	hook, err := s.relations.NextHook(localState, remoteState)
+	hookInfos, err := s.relations.NextHooks(localState, remoteState)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return opFactory.NewRunHook(hook)
+	if len(hookInfos) == 1 {
+		return opFactory.NewRunHook(hookInfos[0])
+	}
+	return opFactory.NewRunHooks(hookInfos)
 }
 
 // relations implements Relations.
@@ -196,6 +208,33 @@ func (r *relations) NextHook(
 	localState resolver.LocalState,
 	remoteState remotestate.Snapshot,
 ) (hook.Info, error) {
+	hookInfos, err := r.nextHooks(localState, remoteState, false)
+	if err != nil {
+		return hook.Info{}, err
+	}
+	return hookInfos[0], nil
+}
+
+// NextHooks implements Relations.
+func (r *relations) NextHooks(
+	localState resolver.LocalState,
+	remoteState remotestate.Snapshot,
+) ([]hook.Info, error) {
+	concurrent, err := charm.ConcurrentRelationHooks(r.charmDir)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return r.nextHooks(localState, remoteState, concurrent)
+}
+
+// nextHooks returns the next hook to run for each relation that has one
+// if all is true, or for just one of them otherwise. It returns
+// resolver.ErrNoOperation if there are no hooks to run.
+func (r *relations) nextHooks(
+	localState resolver.LocalState,
+	remoteState remotestate.Snapshot,
+	all bool,
+) ([]hook.Info, error) {
 
 	if remoteState.Life == params.Dying {
 		// The unit is Dying, so make sure all subordinates are dying.
@@ -216,29 +255,33 @@ func (r *relations) NextHook(
 		}
 		if destroyAllSubordinates {
 			if err := r.unit.DestroyAllSubordinates(); err != nil {
-				return hook.Info{}, errors.Trace(err)
+				return nil, errors.Trace(err)
 			}
 		}
 	}
 
 	// Add/remove local relation state; enter and leave scope as necessary.
 	if err := r.update(remoteState.Relations); err != nil {
-		return hook.Info{}, errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 
 	if localState.Kind != operation.Continue {
-		return hook.Info{}, resolver.ErrNoOperation
+		return nil, resolver.ErrNoOperation
 	}
 
 	// See if any of the relations have operations to perform.
+	var hookInfos []hook.Info
 	for relationId, relationSnapshot := range remoteState.Relations {
 		relationer, ok := r.relationers[relationId]
 		if !ok || relationer.IsImplicit() {
 			continue
 		}
-		var remoteBroken bool
+		var remoteBroken, awaitBarrier bool
 		if remoteState.Life == params.Dying ||
 			relationSnapshot.Life == params.Dying || relationSnapshot.Suspended {
+			// A unit leaving on its own does not wait for the
+			// rest of its application before breaking the relation.
+			awaitBarrier = remoteState.Life != params.Dying && !relationSnapshot.BrokenBarrierReached
 			relationSnapshot = remotestate.RelationSnapshot{}
 			remoteBroken = true
 			// TODO(axw) if relation is implicit, leave scope & remove.
@@ -248,10 +291,34 @@ func (r *relations) NextHook(
 		hook, err := nextRelationHook(relationer.dir, relationSnapshot, remoteBroken)
 		if err == resolver.ErrNoOperation {
 			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if hook.Kind == hooks.RelationBroken && awaitBarrier {
+			// All relation-departed hooks have run; let the other
+			// units of the application know, and hold relation-broken
+			// until they have all got this far.
+			if err := relationer.SetDeparted(); errors.IsNotImplemented(err) {
+				// The controller cannot hold relation-broken back.
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			} else {
+				logger.Debugf("relation %d: waiting for relation-broken barrier", relationId)
+				continue
+			}
+		}
+		hookInfos = append(hookInfos, hook)
+		if !all {
+			break
 		}
-		return hook, err
 	}
-	return hook.Info{}, resolver.ErrNoOperation
+	if len(hookInfos) == 0 {
+		return nil, resolver.ErrNoOperation
+	}
+	sort.Slice(hookInfos, func(i, j int) bool {
+		return hookInfos[i].RelationId < hookInfos[j].RelationId
+	})
+	return hookInfos, nil
 }
 
 // nextRelationHook returns the next hook op that should be executed in the
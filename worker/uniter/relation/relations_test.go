@@ -81,7 +81,7 @@ func mockAPICaller(c *gc.C, callNumber *int32, apiCalls ...apiCall) apitesting.A
 			c.Check(index < len(apiCalls), jc.IsTrue)
 			call := apiCalls[index]
 			c.Logf("request %d, %s", index, request)
-			c.Check(version, gc.Equals, 7)
+			c.Check(version, gc.Equals, 10)
 			c.Check(id, gc.Equals, "")
 			c.Check(request, gc.Equals, call.request)
 			c.Check(arg, jc.DeepEquals, call.args)
@@ -523,7 +523,8 @@ func (s *relationsSuite) TestHookRelationBroken(c *gc.C) {
 	remoteState := remotestate.Snapshot{
 		Relations: map[int]remotestate.RelationSnapshot{
 			1: {
-				Life: params.Dying,
+				Life:                 params.Dying,
+				BrokenBarrierReached: true,
 			},
 		},
 	}
@@ -534,6 +535,50 @@ func (s *relationsSuite) TestHookRelationBroken(c *gc.C) {
 	c.Assert(op.String(), gc.Equals, "run hook relation-broken on unit with relation 1")
 }
 
+func (s *relationsSuite) TestHookRelationBrokenWaitsForBarrier(c *gc.C) {
+	var numCalls int32
+	apiCalls := relationJoinedAPICalls()
+	relationUnits := params.RelationUnits{RelationUnits: []params.RelationUnit{
+		{Relation: "relation-wordpress.db#mysql.db", Unit: "unit-wordpress-0"},
+	}}
+	apiCalls = append(apiCalls,
+		uniterAPICall("SetRelationsDeparted", relationUnits, params.ErrorResults{Results: []params.ErrorResult{{}}}, nil),
+	)
+
+	r := s.assertHookRelationDeparted(c, &numCalls, apiCalls...)
+
+	localState := resolver.LocalState{
+		State: operation.State{
+			Kind: operation.Continue,
+		},
+	}
+	remoteState := remotestate.Snapshot{
+		Relations: map[int]remotestate.RelationSnapshot{
+			1: {
+				Life: params.Dying,
+			},
+		},
+	}
+	relationsResolver := relation.NewRelationsResolver(r)
+
+	// The departure is recorded once, and relation-broken held back
+	// until the barrier is reached.
+	for i := 0; i < 2; i++ {
+		_, err := relationsResolver.NextOp(localState, remoteState, &mockOperations{})
+		c.Assert(errors.Cause(err), gc.Equals, resolver.ErrNoOperation)
+		assertNumCalls(c, &numCalls, 10)
+	}
+
+	remoteState.Relations[1] = remotestate.RelationSnapshot{
+		Life:                 params.Dying,
+		BrokenBarrierReached: true,
+	}
+	op, err := relationsResolver.NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	assertNumCalls(c, &numCalls, 10)
+	c.Assert(op.String(), gc.Equals, "run hook relation-broken on unit with relation 1")
+}
+
 func (s *relationsSuite) TestHookRelationBrokenWhenSuspended(c *gc.C) {
 	var numCalls int32
 	apiCalls := relationJoinedAPICalls()
@@ -548,8 +593,9 @@ func (s *relationsSuite) TestHookRelationBrokenWhenSuspended(c *gc.C) {
 	remoteState := remotestate.Snapshot{
 		Relations: map[int]remotestate.RelationSnapshot{
 			1: {
-				Life:      params.Alive,
-				Suspended: true,
+				Life:                 params.Alive,
+				Suspended:            true,
+				BrokenBarrierReached: true,
 			},
 		},
 	}
@@ -901,3 +947,38 @@ func (s *relationsSuite) TestSubSubOtherRelationDyingNotDestroyed(c *gc.C) {
 	// should panic in that case anyway).
 	assertNumCalls(c, &numCalls, expectedCalls)
 }
+
+type relationsResolverSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&relationsResolverSuite{})
+
+// fakeRelations is a relation.Relations that returns fixed hooks.
+type fakeRelations struct {
+	relation.Relations
+	hookInfos []hook.Info
+}
+
+func (r *fakeRelations) NextHooks(resolver.LocalState, remotestate.Snapshot) ([]hook.Info, error) {
+	return r.hookInfos, nil
+}
+
+func (s *relationsResolverSuite) TestNextOpSingleHook(c *gc.C) {
+	hookInfo := hook.Info{Kind: hooks.RelationJoined, RelationId: 1, RemoteUnit: "wordpress/0"}
+	rr := relation.NewRelationsResolver(&fakeRelations{hookInfos: []hook.Info{hookInfo}})
+	op, err := rr.NextOp(resolver.LocalState{}, remotestate.Snapshot{}, &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op, jc.DeepEquals, &mockOperation{hookInfo})
+}
+
+func (s *relationsResolverSuite) TestNextOpConcurrentHooks(c *gc.C) {
+	hookInfos := []hook.Info{
+		{Kind: hooks.RelationJoined, RelationId: 1, RemoteUnit: "wordpress/0"},
+		{Kind: hooks.RelationChanged, RelationId: 2, RemoteUnit: "mysql/0"},
+	}
+	rr := relation.NewRelationsResolver(&fakeRelations{hookInfos: hookInfos})
+	op, err := rr.NextOp(resolver.LocalState{}, remotestate.Snapshot{}, &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op, jc.DeepEquals, &mockHooksOperation{hookInfos: hookInfos})
+}
@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
 
@@ -97,14 +98,53 @@ func (w *mockRelationUnitsWatcher) Changes() watcher.RelationUnitsChannel {
 	return w.changes
 }
 
+type mockRelationMetrics struct {
+	mu        sync.Mutex
+	received  int
+	coalesced int
+}
+
+func (m *mockRelationMetrics) ChangeReceived() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.received++
+}
+
+func (m *mockRelationMetrics) ChangeCoalesced() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.coalesced++
+}
+
+func (m *mockRelationMetrics) counts() (received, coalesced int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.received, m.coalesced
+}
+
 type mockState struct {
 	unit                      mockUnit
 	relations                 map[names.RelationTag]*mockRelation
 	storageAttachment         map[params.StorageAttachmentId]params.StorageAttachment
 	relationUnitsWatchers     map[names.RelationTag]*mockRelationUnitsWatcher
+	relationUnits             map[names.RelationTag]*mockRelationUnit
 	storageAttachmentWatchers map[names.StorageTag]*mockNotifyWatcher
 }
 
+func (st *mockState) RelationUnit(
+	relationTag names.RelationTag, unitTag names.UnitTag,
+) (remotestate.RelationUnit, error) {
+	if unitTag != st.unit.tag {
+		return nil, &params.Error{Code: params.CodeNotFound}
+	}
+	ru, ok := st.relationUnits[relationTag]
+	if !ok {
+		// Behave like a controller without relation-broken barriers.
+		return &mockRelationUnit{}, nil
+	}
+	return ru, nil
+}
+
 func (st *mockState) Relation(tag names.RelationTag) (remotestate.Relation, error) {
 	r, ok := st.relations[tag]
 	if !ok {
@@ -186,11 +226,37 @@ func (st *mockState) UpdateStatusHookInterval() (time.Duration, error) {
 	return 5 * time.Minute, nil
 }
 
+type mockRelationUnit struct {
+	mu                 sync.Mutex
+	reached            bool
+	brokenBarrierWatch *mockNotifyWatcher
+}
+
+func (ru *mockRelationUnit) setReached(reached bool) {
+	ru.mu.Lock()
+	defer ru.mu.Unlock()
+	ru.reached = reached
+}
+
+func (ru *mockRelationUnit) BrokenBarrierReached() (bool, error) {
+	ru.mu.Lock()
+	defer ru.mu.Unlock()
+	return ru.reached, nil
+}
+
+func (ru *mockRelationUnit) WatchBrokenBarrier() (watcher.NotifyWatcher, error) {
+	if ru.brokenBarrierWatch == nil {
+		return nil, errors.NotImplementedf("WatchBrokenBarrier() (need V10+)")
+	}
+	return ru.brokenBarrierWatch, nil
+}
+
 type mockUnit struct {
 	tag                   names.UnitTag
 	life                  params.Life
 	resolved              params.ResolvedMode
 	series                string
+	drainDeadline         *time.Time
 	application           mockApplication
 	unitWatcher           *mockNotifyWatcher
 	addressesWatcher      *mockNotifyWatcher
@@ -212,6 +278,13 @@ func (u *mockUnit) Resolved() params.ResolvedMode {
 	return u.resolved
 }
 
+func (u *mockUnit) DrainDeadline() (time.Time, bool) {
+	if u.drainDeadline == nil {
+		return time.Time{}, false
+	}
+	return *u.drainDeadline, true
+}
+
 func (u *mockUnit) Application() (remotestate.Application, error) {
 	return &u.application, nil
 }
@@ -0,0 +1,87 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remotestate
+
+import (
+	"github.com/juju/errors"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+type relationBrokenBarrierWatcher struct {
+	catacomb   catacomb.Catacomb
+	relationId int
+	changes    watcher.NotifyChannel
+	reached    func() (bool, error)
+	out        chan<- relationBrokenBarrierChange
+}
+
+type relationBrokenBarrierChange struct {
+	relationId int
+	reached    bool
+}
+
+// newRelationBrokenBarrierWatcher creates a new worker that, for each
+// value on the supplied watcher's Changes chan, calls reached and
+// delivers the result, annotated with the supplied relation id, on the
+// supplied out chan.
+//
+// The caller releases responsibility for stopping the supplied watcher and
+// waiting for errors, *whether or not this method succeeds*.
+func newRelationBrokenBarrierWatcher(
+	relationId int,
+	watcher watcher.NotifyWatcher,
+	reached func() (bool, error),
+	out chan<- relationBrokenBarrierChange,
+) (*relationBrokenBarrierWatcher, error) {
+	w := &relationBrokenBarrierWatcher{
+		relationId: relationId,
+		changes:    watcher.Changes(),
+		reached:    reached,
+		out:        out,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+		Init: []worker.Worker{watcher},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *relationBrokenBarrierWatcher) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *relationBrokenBarrierWatcher) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *relationBrokenBarrierWatcher) loop() error {
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-w.changes:
+			if !ok {
+				return errors.New("watcher closed channel")
+			}
+			reached, err := w.reached()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			select {
+			case <-w.catacomb.Dying():
+				return w.catacomb.ErrDying()
+			case w.out <- relationBrokenBarrierChange{w.relationId, reached}:
+			}
+		}
+	}
+}
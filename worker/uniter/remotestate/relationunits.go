@@ -4,18 +4,36 @@
 package remotestate
 
 import (
+	"time"
+
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 	worker "gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker/catacomb"
 )
 
+// RelationMetrics records what becomes of the relation units changes
+// received by a remote state watcher.
+type RelationMetrics interface {
+	// ChangeReceived is called for each change received.
+	ChangeReceived()
+
+	// ChangeCoalesced is called for each change that is merged into
+	// an earlier, undelivered change rather than being delivered in
+	// its own right.
+	ChangeCoalesced()
+}
+
 type relationUnitsWatcher struct {
 	catacomb   catacomb.Catacomb
 	relationId int
 	changes    watcher.RelationUnitsChannel
 	out        chan<- relationUnitsChange
+	clock      clock.Clock
+	period     time.Duration
+	metrics    RelationMetrics
 }
 
 type relationUnitsChange struct {
@@ -27,17 +45,31 @@ type relationUnitsChange struct {
 // supplied watcher's Changes chan, annotates them with the supplied relation
 // id, and delivers then on the supplied out chan.
 //
+// Changes that arrive before an earlier change has been delivered are
+// merged into it. Once a change has been delivered, the next is not
+// delivered until the supplied period has passed, so that a busy
+// relation causes a bounded number of relation-changed hooks.
+//
 // The caller releases responsibility for stopping the supplied watcher and
 // waiting for errors, *whether or not this method succeeds*.
 func newRelationUnitsWatcher(
 	relationId int,
 	watcher watcher.RelationUnitsWatcher,
 	out chan<- relationUnitsChange,
+	clock clock.Clock,
+	period time.Duration,
+	metrics RelationMetrics,
 ) (*relationUnitsWatcher, error) {
+	if metrics == nil {
+		metrics = noRelationMetrics{}
+	}
 	ruw := &relationUnitsWatcher{
 		relationId: relationId,
 		changes:    watcher.Changes(),
 		out:        out,
+		clock:      clock,
+		period:     period,
+		metrics:    metrics,
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &ruw.catacomb,
@@ -61,6 +93,13 @@ func (w *relationUnitsWatcher) Wait() error {
 }
 
 func (w *relationUnitsWatcher) loop() error {
+	var (
+		pending    watcher.RelationUnitsChange
+		hasPending bool
+		out        chan<- relationUnitsChange
+		ready      <-chan time.Time
+		held       bool
+	)
 	for {
 		select {
 		case <-w.catacomb.Dying():
@@ -69,11 +108,71 @@ func (w *relationUnitsWatcher) loop() error {
 			if !ok {
 				return errors.New("watcher closed channel")
 			}
-			select {
-			case <-w.catacomb.Dying():
-				return w.catacomb.ErrDying()
-			case w.out <- relationUnitsChange{w.relationId, change}:
+			w.metrics.ChangeReceived()
+			if hasPending {
+				mergeRelationUnitsChange(&pending, change)
+				w.metrics.ChangeCoalesced()
+			} else {
+				pending, hasPending = change, true
+			}
+			if !held {
+				out = w.out
+			}
+		case <-ready:
+			ready = nil
+			held = false
+			if hasPending {
+				out = w.out
+			}
+		case out <- relationUnitsChange{w.relationId, pending}:
+			pending, hasPending = watcher.RelationUnitsChange{}, false
+			out = nil
+			if w.period > 0 {
+				ready = w.clock.After(w.period)
+				held = true
 			}
 		}
 	}
 }
+
+// mergeRelationUnitsChange merges a later change into an earlier,
+// undelivered one.
+func mergeRelationUnitsChange(into *watcher.RelationUnitsChange, change watcher.RelationUnitsChange) {
+	for _, unit := range change.Departed {
+		delete(into.Changed, unit)
+		if !containsString(into.Departed, unit) {
+			into.Departed = append(into.Departed, unit)
+		}
+	}
+	for unit, settings := range change.Changed {
+		if into.Changed == nil {
+			into.Changed = make(map[string]watcher.UnitSettings)
+		}
+		into.Changed[unit] = settings
+		into.Departed = removeString(into.Departed, unit)
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func removeString(values []string, value string) []string {
+	result := values[:0]
+	for _, v := range values {
+		if v != value {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+type noRelationMetrics struct{}
+
+func (noRelationMetrics) ChangeReceived()  {}
+func (noRelationMetrics) ChangeCoalesced() {}
@@ -4,6 +4,8 @@
 package remotestate
 
 import (
+	"time"
+
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
 
@@ -61,6 +63,10 @@ type Snapshot struct {
 	// update-status hook is supposed to run.
 	UpdateStatusVersion int
 
+	// HealthCheckVersion increments each time the charm's
+	// health checks are supposed to run.
+	HealthCheckVersion int
+
 	// Actions is the list of pending actions to
 	// be peformed by this unit.
 	Actions []string
@@ -71,12 +77,25 @@ type Snapshot struct {
 
 	// Series is the current series running on the unit
 	Series string
+
+	// DrainDeadline, if set, is the time until which the charm's
+	// drain hook may run before the dying unit is torn down.
+	DrainDeadline *time.Time
+
+	// DrainExpired reports whether the drain deadline has passed.
+	DrainExpired bool
 }
 
 type RelationSnapshot struct {
 	Life      params.Life
 	Suspended bool
 	Members   map[string]int64
+
+	// BrokenBarrierReached is true when the unit may run the
+	// relation-broken hook without waiting for other units of its
+	// application to depart the relation. It is only maintained
+	// while the relation is dying or suspended.
+	BrokenBarrierReached bool
 }
 
 // StorageSnapshot has information relating to a storage
@@ -25,6 +25,7 @@ type State interface {
 	StorageAttachment(names.StorageTag, names.UnitTag) (params.StorageAttachment, error)
 	StorageAttachmentLife([]params.StorageAttachmentId) ([]params.LifeResult, error)
 	Unit(names.UnitTag) (Unit, error)
+	RelationUnit(names.RelationTag, names.UnitTag) (RelationUnit, error)
 	WatchRelationUnits(names.RelationTag, names.UnitTag) (watcher.RelationUnitsWatcher, error)
 	WatchStorageAttachment(names.StorageTag, names.UnitTag) (watcher.NotifyWatcher, error)
 	UpdateStatusHookInterval() (time.Duration, error)
@@ -34,6 +35,7 @@ type Unit interface {
 	Life() params.Life
 	Refresh() error
 	Resolved() params.ResolvedMode
+	DrainDeadline() (time.Time, bool)
 	Application() (Application, error)
 	Series() string
 	Tag() names.UnitTag
@@ -73,6 +75,16 @@ type Relation interface {
 	UpdateSuspended(bool)
 }
 
+type RelationUnit interface {
+	// BrokenBarrierReached returns whether the unit may run the
+	// relation-broken hook without waiting for other units of its
+	// application to depart the relation.
+	BrokenBarrierReached() (bool, error)
+	// WatchBrokenBarrier returns a watcher that fires when the
+	// result of BrokenBarrierReached may have changed.
+	WatchBrokenBarrier() (watcher.NotifyWatcher, error)
+}
+
 func NewAPIState(st *uniter.State) State {
 	return apiState{st}
 }
@@ -98,6 +110,22 @@ func (st apiState) Relation(tag names.RelationTag) (Relation, error) {
 	return apiRelation{r}, err
 }
 
+func (st apiState) RelationUnit(relationTag names.RelationTag, unitTag names.UnitTag) (RelationUnit, error) {
+	r, err := st.State.Relation(relationTag)
+	if err != nil {
+		return nil, err
+	}
+	u, err := st.State.Unit(unitTag)
+	if err != nil {
+		return nil, err
+	}
+	ru, err := r.Unit(u)
+	if err != nil {
+		return nil, err
+	}
+	return ru, nil
+}
+
 func (st apiState) Unit(tag names.UnitTag) (Unit, error) {
 	u, err := st.State.Unit(tag)
 	return apiUnit{u}, err
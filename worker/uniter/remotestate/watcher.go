@@ -5,9 +5,11 @@ package remotestate
 
 import (
 	"sync"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/juju/worker.v1"
 
@@ -29,12 +31,21 @@ type RemoteStateWatcher struct {
 	service                   Application
 	relations                 map[names.RelationTag]*relationUnitsWatcher
 	relationUnitsChanges      chan relationUnitsChange
+	brokenBarriers            map[names.RelationTag]*relationBrokenBarrierWatcher
+	brokenBarrierChanges      chan relationBrokenBarrierChange
 	storageAttachmentWatchers map[names.StorageTag]*storageAttachmentWatcher
 	storageAttachmentChanges  chan storageAttachmentChange
 	leadershipTracker         leadership.Tracker
 	updateStatusChannel       UpdateStatusTimerFunc
 	commandChannel            <-chan string
 	retryHookChannel          <-chan struct{}
+	clock                     clock.Clock
+	relationCoalescePeriod    time.Duration
+	relationMetrics           RelationMetrics
+	healthCheckInterval       time.Duration
+
+	// drainExpiry fires when the unit's drain deadline passes.
+	drainExpiry <-chan time.Time
 
 	catacomb catacomb.Catacomb
 
@@ -52,6 +63,22 @@ type WatcherConfig struct {
 	CommandChannel      <-chan string
 	RetryHookChannel    <-chan struct{}
 	UnitTag             names.UnitTag
+	Clock               clock.Clock
+
+	// RelationCoalescePeriod is the minimum time between deliveries
+	// of changes to the units of each relation. Changes that arrive
+	// in the meantime are coalesced, so that rapid changes from busy
+	// peers result in fewer relation-changed hooks.
+	RelationCoalescePeriod time.Duration
+
+	// RelationMetrics, if not nil, records the relation units changes
+	// that are received and coalesced.
+	RelationMetrics RelationMetrics
+
+	// HealthCheckInterval, if positive, is the period at which the
+	// snapshot's HealthCheckVersion is incremented, to signal that
+	// the charm's health checks should be run.
+	HealthCheckInterval time.Duration
 }
 
 // NewWatcher returns a RemoteStateWatcher that handles state changes pertaining to the
@@ -61,12 +88,18 @@ func NewWatcher(config WatcherConfig) (*RemoteStateWatcher, error) {
 		st:                        config.State,
 		relations:                 make(map[names.RelationTag]*relationUnitsWatcher),
 		relationUnitsChanges:      make(chan relationUnitsChange),
+		brokenBarriers:            make(map[names.RelationTag]*relationBrokenBarrierWatcher),
+		brokenBarrierChanges:      make(chan relationBrokenBarrierChange),
 		storageAttachmentWatchers: make(map[names.StorageTag]*storageAttachmentWatcher),
 		storageAttachmentChanges:  make(chan storageAttachmentChange),
 		leadershipTracker:         config.LeadershipTracker,
 		updateStatusChannel:       config.UpdateStatusChannel,
 		commandChannel:            config.CommandChannel,
 		retryHookChannel:          config.RetryHookChannel,
+		clock:                     config.Clock,
+		relationCoalescePeriod:    config.RelationCoalescePeriod,
+		relationMetrics:           config.RelationMetrics,
+		healthCheckInterval:       config.HealthCheckInterval,
 		// Note: it is important that the out channel be buffered!
 		// The remote state watcher will perform a non-blocking send
 		// on the channel to wake up the observer. It is non-blocking
@@ -110,9 +143,10 @@ func (w *RemoteStateWatcher) Snapshot() Snapshot {
 	snapshot.Relations = make(map[int]RelationSnapshot)
 	for id, relationSnapshot := range w.current.Relations {
 		relationSnapshotCopy := RelationSnapshot{
-			Life:      relationSnapshot.Life,
-			Suspended: relationSnapshot.Suspended,
-			Members:   make(map[string]int64),
+			Life:                 relationSnapshot.Life,
+			Suspended:            relationSnapshot.Suspended,
+			Members:              make(map[string]int64),
+			BrokenBarrierReached: relationSnapshot.BrokenBarrierReached,
 		}
 		for name, version := range relationSnapshot.Members {
 			relationSnapshotCopy.Members[name] = version
@@ -282,6 +316,11 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 		}
 	}
 
+	var healthCheckTimer <-chan time.Time
+	if w.healthCheckInterval > 0 {
+		healthCheckTimer = w.clock.After(w.healthCheckInterval)
+	}
+
 	// Check the initial leadership status, and then we can flip-flop
 	// waiting on leader or minion to trigger the changed event.
 	var waitLeader, waitMinion <-chan struct{}
@@ -419,12 +458,23 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 				return errors.Trace(err)
 			}
 
+		case change := <-w.brokenBarrierChanges:
+			logger.Debugf("got a relation-broken barrier change: %v", change)
+			w.brokenBarrierChanged(change)
+
 		case <-w.updateStatusChannel(updateStatusInterval).After():
 			logger.Debugf("update status timer triggered")
 			if err := w.updateStatusChanged(); err != nil {
 				return errors.Trace(err)
 			}
 
+		case <-healthCheckTimer:
+			logger.Debugf("health check timer triggered")
+			w.mu.Lock()
+			w.current.HealthCheckVersion++
+			w.mu.Unlock()
+			healthCheckTimer = w.clock.After(w.healthCheckInterval)
+
 		case id, ok := <-w.commandChannel:
 			if !ok {
 				return errors.New("commandChannel closed")
@@ -442,6 +492,13 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			if err := w.retryHookTimerTriggered(); err != nil {
 				return err
 			}
+
+		case <-w.drainExpiry:
+			logger.Debugf("drain deadline passed")
+			w.drainExpiry = nil
+			w.mu.Lock()
+			w.current.DrainExpired = true
+			w.mu.Unlock()
 		}
 
 		// Something changed.
@@ -483,6 +540,13 @@ func (w *RemoteStateWatcher) unitChanged() error {
 	w.current.Life = w.unit.Life()
 	w.current.ResolvedMode = w.unit.Resolved()
 	w.current.Series = w.unit.Series()
+	w.current.DrainDeadline = nil
+	if deadline, ok := w.unit.DrainDeadline(); ok {
+		w.current.DrainDeadline = &deadline
+		if w.drainExpiry == nil && !w.current.DrainExpired {
+			w.drainExpiry = w.clock.After(deadline.Sub(w.clock.Now()))
+		}
+	}
 	return nil
 }
 
@@ -550,6 +614,10 @@ func (w *RemoteStateWatcher) relationsChanged(keys []string) error {
 				delete(w.relations, relationTag)
 				delete(w.current.Relations, ruw.relationId)
 			}
+			if bbw, ok := w.brokenBarriers[relationTag]; ok {
+				worker.Stop(bbw)
+				delete(w.brokenBarriers, relationTag)
+			}
 		} else if err != nil {
 			return errors.Trace(err)
 		} else {
@@ -567,6 +635,9 @@ func (w *RemoteStateWatcher) relationsChanged(keys []string) error {
 						delete(w.relations, relationTag)
 					}
 				}
+				if err := w.ensureBrokenBarrierWatcher(rel, relationTag); err != nil {
+					return errors.Trace(err)
+				}
 				continue
 			}
 			// If the relation is suspended, we don't need to watch it.
@@ -586,11 +657,75 @@ func (w *RemoteStateWatcher) relationsChanged(keys []string) error {
 			if err := w.watchRelationUnits(rel, relationTag, ruw); err != nil {
 				return errors.Trace(err)
 			}
+			if err := w.ensureBrokenBarrierWatcher(rel, relationTag); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	return nil
+}
+
+// ensureBrokenBarrierWatcher starts watching the relation-broken barrier
+// for the given relation once it is dying or suspended, if it is not
+// already being watched, and stops watching it if the relation is
+// resumed. Until the watcher reports, the barrier is treated as not
+// reached. Controllers that do not support barriers never hold back
+// relation-broken.
+func (w *RemoteStateWatcher) ensureBrokenBarrierWatcher(rel Relation, relationTag names.RelationTag) error {
+	if rel.Life() == params.Alive && !rel.Suspended() {
+		if bbw, ok := w.brokenBarriers[relationTag]; ok {
+			worker.Stop(bbw)
+			delete(w.brokenBarriers, relationTag)
+			relationSnapshot := w.current.Relations[rel.Id()]
+			relationSnapshot.BrokenBarrierReached = false
+			w.current.Relations[rel.Id()] = relationSnapshot
 		}
+		return nil
+	}
+	if _, ok := w.brokenBarriers[relationTag]; ok {
+		return nil
 	}
+	ru, err := w.st.RelationUnit(relationTag, w.unit.Tag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	bw, err := ru.WatchBrokenBarrier()
+	if errors.IsNotImplemented(err) {
+		relationSnapshot := w.current.Relations[rel.Id()]
+		relationSnapshot.BrokenBarrierReached = true
+		w.current.Relations[rel.Id()] = relationSnapshot
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	// As with the relation units watcher, add to our own catacomb
+	// until responsibility is handed off below.
+	if err := w.catacomb.Add(bw); err != nil {
+		return errors.Trace(err)
+	}
+	bbw, err := newRelationBrokenBarrierWatcher(rel.Id(), bw, ru.BrokenBarrierReached, w.brokenBarrierChanges)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(bbw); err != nil {
+		return errors.Trace(err)
+	}
+	w.brokenBarriers[relationTag] = bbw
 	return nil
 }
 
+// brokenBarrierChanged responds to relation-broken barrier changes.
+func (w *RemoteStateWatcher) brokenBarrierChanged(change relationBrokenBarrierChange) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	relationSnapshot, ok := w.current.Relations[change.relationId]
+	if !ok {
+		return
+	}
+	relationSnapshot.BrokenBarrierReached = change.reached
+	w.current.Relations[change.relationId] = relationSnapshot
+}
+
 // watchRelationUnits starts watching the relation units for the given
 // relation, waits for its first event, and records the information in
 // the current snapshot.
@@ -613,7 +748,10 @@ func (w *RemoteStateWatcher) watchRelationUnits(
 			relationSnapshot.Members[unit] = settings.Version
 		}
 	}
-	innerRUW, err := newRelationUnitsWatcher(rel.Id(), ruw, w.relationUnitsChanges)
+	innerRUW, err := newRelationUnitsWatcher(
+		rel.Id(), ruw, w.relationUnitsChanges,
+		w.clock, w.relationCoalescePeriod, w.relationMetrics,
+	)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -35,6 +35,12 @@ var _ = gc.Suite(&WatcherSuite{})
 
 func (s *WatcherSuite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
+	s.setUpWatcher(c, 0, nil)
+}
+
+// setUpWatcher creates mock state and a remote state watcher of it,
+// with the supplied relation coalescing parameters.
+func (s *WatcherSuite) setUpWatcher(c *gc.C, relationCoalescePeriod time.Duration, relationMetrics remotestate.RelationMetrics) {
 	s.st = &mockState{
 		unit: mockUnit{
 			tag:  names.NewUnitTag("mysql/0"),
@@ -57,6 +63,7 @@ func (s *WatcherSuite) SetUpTest(c *gc.C) {
 		relations:                 make(map[names.RelationTag]*mockRelation),
 		storageAttachment:         make(map[params.StorageAttachmentId]params.StorageAttachment),
 		relationUnitsWatchers:     make(map[names.RelationTag]*mockRelationUnitsWatcher),
+		relationUnits:             make(map[names.RelationTag]*mockRelationUnit),
 		storageAttachmentWatchers: make(map[names.StorageTag]*mockNotifyWatcher),
 	}
 
@@ -76,6 +83,10 @@ func (s *WatcherSuite) SetUpTest(c *gc.C) {
 		LeadershipTracker:   s.leadership,
 		UnitTag:             s.st.unit.tag,
 		UpdateStatusChannel: statusTicker,
+		Clock:               s.clock,
+
+		RelationCoalescePeriod: relationCoalescePeriod,
+		RelationMetrics:        relationMetrics,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	s.watcher = w
@@ -506,6 +517,60 @@ func (s *WatcherSuite) TestRelationsSuspended(c *gc.C) {
 	c.Assert(s.st.relationUnitsWatchers[relationTag].Stopped(), jc.IsTrue)
 }
 
+func (s *WatcherSuite) TestRelationBrokenBarrier(c *gc.C) {
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	relationTag := names.NewRelationTag("mysql:db wordpress:db")
+	s.st.relations[relationTag] = &mockRelation{
+		id: 123, life: params.Alive, suspended: false,
+	}
+	s.st.relationUnitsWatchers[relationTag] = newMockRelationUnitsWatcher()
+	ru := &mockRelationUnit{brokenBarrierWatch: newMockNotifyWatcher()}
+	s.st.relationUnits[relationTag] = ru
+	s.st.unit.relationsWatcher.changes <- []string{relationTag.Id()}
+	s.st.relationUnitsWatchers[relationTag].changes <- watcher.RelationUnitsChange{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().Relations[123].BrokenBarrierReached, jc.IsFalse)
+
+	// The barrier is only watched once the relation is dying, and is
+	// not reached until the watcher says so.
+	s.st.relations[relationTag].life = params.Dying
+	s.st.unit.relationsWatcher.changes <- []string{relationTag.Id()}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().Relations[123].BrokenBarrierReached, jc.IsFalse)
+
+	ru.brokenBarrierWatch.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().Relations[123].BrokenBarrierReached, jc.IsFalse)
+
+	ru.setReached(true)
+	ru.brokenBarrierWatch.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().Relations[123].BrokenBarrierReached, jc.IsTrue)
+
+	// The barrier watcher is stopped along with the relation.
+	delete(s.st.relations, relationTag)
+	s.st.unit.relationsWatcher.changes <- []string{relationTag.Id()}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(ru.brokenBarrierWatch.Stopped(), jc.IsTrue)
+}
+
+func (s *WatcherSuite) TestRelationBrokenBarrierNotSupported(c *gc.C) {
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	relationTag := names.NewRelationTag("mysql:db wordpress:db")
+	s.st.relations[relationTag] = &mockRelation{
+		id: 123, life: params.Dying, suspended: false,
+	}
+	s.st.relationUnitsWatchers[relationTag] = newMockRelationUnitsWatcher()
+	s.st.unit.relationsWatcher.changes <- []string{relationTag.Id()}
+	s.st.relationUnitsWatchers[relationTag].changes <- watcher.RelationUnitsChange{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().Relations[123].BrokenBarrierReached, jc.IsTrue)
+}
+
 func (s *WatcherSuite) TestRelationUnitsChanged(c *gc.C) {
 	signalAll(s.st, s.leadership)
 	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
@@ -543,6 +608,63 @@ func (s *WatcherSuite) TestRelationUnitsChanged(c *gc.C) {
 	)
 }
 
+func (s *WatcherSuite) TestRelationUnitsChangesCoalesced(c *gc.C) {
+	// Replace the watcher with one that coalesces relation units
+	// changes.
+	s.watcher.Kill()
+	c.Assert(s.watcher.Wait(), jc.ErrorIsNil)
+	metrics := &mockRelationMetrics{}
+	s.setUpWatcher(c, time.Minute, metrics)
+
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	relationTag := names.NewRelationTag("mysql:peer")
+	s.st.relations[relationTag] = &mockRelation{
+		id: 123, life: params.Alive,
+	}
+	s.st.relationUnitsWatchers[relationTag] = newMockRelationUnitsWatcher()
+	s.st.unit.relationsWatcher.changes <- []string{relationTag.Id()}
+	s.st.relationUnitsWatchers[relationTag].changes <- watcher.RelationUnitsChange{
+		Changed: map[string]watcher.UnitSettings{"mysql/1": {1}, "mysql/2": {1}},
+	}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	// The first change after the initial event is delivered at once;
+	// those that follow it within the period are held and merged.
+	s.st.relationUnitsWatchers[relationTag].changes <- watcher.RelationUnitsChange{
+		Changed: map[string]watcher.UnitSettings{"mysql/1": {2}},
+	}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	for _, change := range []watcher.RelationUnitsChange{{
+		Changed: map[string]watcher.UnitSettings{"mysql/1": {3}},
+	}, {
+		Changed:  map[string]watcher.UnitSettings{"mysql/1": {4}, "mysql/3": {1}},
+		Departed: []string{"mysql/2"},
+	}, {
+		Changed: map[string]watcher.UnitSettings{"mysql/1": {5}},
+	}} {
+		s.st.relationUnitsWatchers[relationTag].changes <- change
+	}
+	assertNoNotifyEvent(c, s.watcher.RemoteStateChanged(), "remote state change")
+	c.Assert(
+		s.watcher.Snapshot().Relations[123].Members,
+		jc.DeepEquals,
+		map[string]int64{"mysql/1": 2, "mysql/2": 1},
+	)
+
+	s.clock.Advance(time.Minute)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(
+		s.watcher.Snapshot().Relations[123].Members,
+		jc.DeepEquals,
+		map[string]int64{"mysql/1": 5, "mysql/3": 1},
+	)
+	received, coalesced := metrics.counts()
+	c.Assert(received, gc.Equals, 4)
+	c.Assert(coalesced, gc.Equals, 2)
+}
+
 func (s *WatcherSuite) TestRelationUnitsDontLeakReferences(c *gc.C) {
 	signalAll(s.st, s.leadership)
 	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
@@ -591,6 +713,26 @@ func (s *WatcherSuite) TestUpdateStatusTicker(c *gc.C) {
 	c.Assert(s.watcher.Snapshot().UpdateStatusVersion, gc.Equals, initial.UpdateStatusVersion+2)
 }
 
+func (s *WatcherSuite) TestDrainDeadline(c *gc.C) {
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().DrainDeadline, gc.IsNil)
+
+	deadline := s.clock.Now().Add(5 * time.Second)
+	s.st.unit.life = params.Dying
+	s.st.unit.drainDeadline = &deadline
+	s.st.unit.unitWatcher.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	snapshot := s.watcher.Snapshot()
+	c.Assert(snapshot.DrainDeadline, jc.DeepEquals, &deadline)
+	c.Assert(snapshot.DrainExpired, jc.IsFalse)
+
+	s.waitAlarmsStable(c)
+	s.clock.Advance(5 * time.Second)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().DrainExpired, jc.IsTrue)
+}
+
 // waitAlarmsStable is used to wait until the remote watcher's loop has
 // stopped churning (at least for testing.ShortWait), so that we can
 // then Advance the clock with some confidence that the SUT really is
@@ -26,6 +26,7 @@ type ResolverConfig struct {
 	Relations           resolver.Resolver
 	Storage             resolver.Resolver
 	Commands            resolver.Resolver
+	HealthChecks        resolver.Resolver
 }
 
 type uniterResolver struct {
@@ -97,6 +98,13 @@ func (s *uniterResolver) NextOp(
 	case operation.RunHook:
 		switch localState.Step {
 		case operation.Pending:
+			if localState.Hook.Kind == hook.Drain && remoteState.DrainExpired {
+				// The charm failed to drain within the grace
+				// period; give up on it and continue tearing
+				// down the unit.
+				logger.Warningf("drain deadline passed, skipping failed %q hook", hook.Drain)
+				return opFactory.NewSkipHook(*localState.Hook)
+			}
 			logger.Infof("awaiting error resolution for %q hook", localState.Hook.Kind)
 			return s.nextOpHookError(localState, remoteState, opFactory)
 
@@ -225,6 +233,16 @@ func (s *uniterResolver) nextOp(
 	switch remoteState.Life {
 	case params.Alive:
 	case params.Dying:
+		// If a drain was requested, give the charm the chance to
+		// shut down its workload in an orderly fashion while its
+		// relations are still intact. The drain hook is abandoned
+		// if it fails and the deadline passes; a drain hook that
+		// is already running is allowed to complete.
+		if remoteState.DrainDeadline != nil && !remoteState.DrainExpired &&
+			localState.Started && !localState.Drained {
+			return opFactory.NewRunHook(hook.Info{Kind: hook.Drain})
+		}
+
 		// Normally we handle relations last, but if we're dying we
 		// must ensure that all relations are broken first.
 		op, err := s.config.Relations.NextOp(localState, remoteState, opFactory)
@@ -270,6 +288,11 @@ func (s *uniterResolver) nextOp(
 		return op, err
 	}
 
+	op, err = s.config.HealthChecks.NextOp(localState, remoteState, opFactory)
+	if errors.Cause(err) != resolver.ErrNoOperation {
+		return op, err
+	}
+
 	// UpdateStatus hook runs if nothing else needs to.
 	if localState.UpdateStatusVersion != remoteState.UpdateStatusVersion {
 		return opFactory.NewRunHook(hook.Info{Kind: hooks.UpdateStatus})
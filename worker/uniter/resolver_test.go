@@ -4,6 +4,8 @@
 package uniter_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -70,6 +72,7 @@ func (s *resolverSuite) SetUpTest(c *gc.C) {
 		Relations:           relation.NewRelationsResolver(&dummyRelations{}),
 		Storage:             storage.NewResolver(attachments),
 		Commands:            nopResolver{},
+		HealthChecks:        nopResolver{},
 	}
 
 	s.resolver = uniter.NewUniterResolver(s.resolverConfig)
@@ -285,3 +288,79 @@ func (s *resolverSuite) TestRunHookStopRetryTimer(c *gc.C) {
 	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
 	s.stub.CheckCallNames(c, "StartRetryHookTimer", "StopRetryHookTimer")
 }
+
+func (s *resolverSuite) dyingLocalState() resolver.LocalState {
+	return resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+			Started:   true,
+		},
+	}
+}
+
+func (s *resolverSuite) TestDyingRunsDrainHook(c *gc.C) {
+	deadline := time.Now().Add(time.Hour)
+	s.remoteState.Life = params.Dying
+	s.remoteState.DrainDeadline = &deadline
+	op, err := s.resolver.NextOp(s.dyingLocalState(), s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run drain hook")
+}
+
+func (s *resolverSuite) TestDyingDrainedRunsStopHook(c *gc.C) {
+	deadline := time.Now().Add(time.Hour)
+	s.remoteState.Life = params.Dying
+	s.remoteState.DrainDeadline = &deadline
+	localState := s.dyingLocalState()
+	localState.Drained = true
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run stop hook")
+}
+
+func (s *resolverSuite) TestDyingDrainExpiredRunsStopHook(c *gc.C) {
+	deadline := time.Now()
+	s.remoteState.Life = params.Dying
+	s.remoteState.DrainDeadline = &deadline
+	s.remoteState.DrainExpired = true
+	op, err := s.resolver.NextOp(s.dyingLocalState(), s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run stop hook")
+}
+
+func (s *resolverSuite) TestDyingNoDrainRunsStopHook(c *gc.C) {
+	s.remoteState.Life = params.Dying
+	op, err := s.resolver.NextOp(s.dyingLocalState(), s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run stop hook")
+}
+
+func (s *resolverSuite) TestFailedDrainHookSkippedAfterDeadline(c *gc.C) {
+	deadline := time.Now()
+	s.remoteState.Life = params.Dying
+	s.remoteState.DrainDeadline = &deadline
+	s.remoteState.DrainExpired = true
+	localState := s.dyingLocalState()
+	localState.Kind = operation.RunHook
+	localState.Step = operation.Pending
+	localState.Hook = &hook.Info{Kind: hook.Drain}
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "skip run drain hook")
+}
+
+func (s *resolverSuite) TestFailedDrainHookAwaitsResolutionBeforeDeadline(c *gc.C) {
+	s.reportHookError = func(hook.Info) error { return nil }
+	deadline := time.Now().Add(time.Hour)
+	s.remoteState.Life = params.Dying
+	s.remoteState.DrainDeadline = &deadline
+	localState := s.dyingLocalState()
+	localState.Kind = operation.RunHook
+	localState.Step = operation.Pending
+	localState.Hook = &hook.Info{Kind: hook.Drain}
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
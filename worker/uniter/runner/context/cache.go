@@ -5,6 +5,9 @@ package context
 
 import (
 	"sort"
+	"sync"
+
+	"github.com/juju/errors"
 
 	"github.com/juju/juju/apiserver/params"
 )
@@ -12,15 +15,27 @@ import (
 // SettingsFunc returns the relation settings for a unit.
 type SettingsFunc func(unitName string) (params.Settings, error)
 
+// SettingsBatchFunc returns the relation settings of each of the named
+// units that could be read.
+type SettingsBatchFunc func(unitNames []string) (SettingsMap, error)
+
 // SettingsMap is a map from unit name to relation settings.
 type SettingsMap map[string]params.Settings
 
 // RelationCache stores a relation's remote unit membership and settings.
 // Member settings are stored until invalidated or removed by name; settings
 // of non-member units are stored only until the cache is pruned.
+//
+// A RelationCache is shared by all the hook contexts created for the
+// relation, and is safe for concurrent use.
 type RelationCache struct {
+	mu sync.Mutex
+
 	// readSettings is used to get settings data if when not already present.
 	readSettings SettingsFunc
+	// readSettingsBatch, if not nil, is used to get the settings data of
+	// all members not already present at once.
+	readSettingsBatch SettingsBatchFunc
 	// members' keys define the relation's membership; non-nil values hold
 	// cached settings.
 	members SettingsMap
@@ -39,9 +54,22 @@ func NewRelationCache(readSettings SettingsFunc, memberNames []string) *Relation
 	return cache
 }
 
+// NewBatchRelationCache creates a new RelationCache like NewRelationCache,
+// except that when the settings of a member are first needed, those of
+// all members are read together using the supplied SettingsBatchFunc.
+// This saves a round trip to the controller for each member when a hook
+// reads the settings of many units.
+func NewBatchRelationCache(readSettings SettingsFunc, readSettingsBatch SettingsBatchFunc, memberNames []string) *RelationCache {
+	cache := NewRelationCache(readSettings, memberNames)
+	cache.readSettingsBatch = readSettingsBatch
+	return cache
+}
+
 // Prune resets the membership to the supplied list, and discards the settings
 // of all non-member units.
 func (cache *RelationCache) Prune(memberNames []string) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
 	newMembers := SettingsMap{}
 	for _, memberName := range memberNames {
 		newMembers[memberName] = cache.members[memberName]
@@ -52,6 +80,8 @@ func (cache *RelationCache) Prune(memberNames []string) {
 
 // MemberNames returns the names of the remote units present in the relation.
 func (cache *RelationCache) MemberNames() (memberNames []string) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
 	for memberName := range cache.members {
 		memberNames = append(memberNames, memberName)
 	}
@@ -62,19 +92,35 @@ func (cache *RelationCache) MemberNames() (memberNames []string) {
 // Settings returns the settings of the named remote unit. It's valid to get
 // the settings of any unit that has ever been in the relation.
 func (cache *RelationCache) Settings(unitName string) (params.Settings, error) {
+	cache.mu.Lock()
 	settings, isMember := cache.members[unitName]
+	if settings == nil && !isMember {
+		settings = cache.others[unitName]
+	}
+	readSettingsBatch := cache.readSettingsBatch
+	var unread []string
+	if settings == nil && isMember && readSettingsBatch != nil {
+		unread = cache.unreadMemberNames()
+	}
+	cache.mu.Unlock()
+	if len(unread) > 1 {
+		cache.readMembers(readSettingsBatch, unread)
+		cache.mu.Lock()
+		settings = cache.members[unitName]
+		cache.mu.Unlock()
+	}
 	if settings == nil {
-		if !isMember {
-			settings = cache.others[unitName]
-		}
-		if settings == nil {
-			var err error
-			settings, err = cache.readSettings(unitName)
-			if err != nil {
-				return nil, err
-			}
+		// Settings are read without holding the lock, so that
+		// contexts reading different units do not wait for
+		// each other.
+		var err error
+		settings, err = cache.readSettings(unitName)
+		if err != nil {
+			return nil, err
 		}
 	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
 	if isMember {
 		cache.members[unitName] = settings
 	} else {
@@ -83,15 +129,58 @@ func (cache *RelationCache) Settings(unitName string) (params.Settings, error) {
 	return settings, nil
 }
 
+// unreadMemberNames returns the names of the members whose settings
+// are not cached. It must be called with the lock held.
+func (cache *RelationCache) unreadMemberNames() []string {
+	var names []string
+	for memberName, settings := range cache.members {
+		if settings == nil {
+			names = append(names, memberName)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// readMembers reads and caches the settings of the named members in one
+// batch. Errors are not returned: the settings of any member that could
+// not be read are read on their own when needed, so that the error is
+// reported for that member.
+func (cache *RelationCache) readMembers(readSettingsBatch SettingsBatchFunc, memberNames []string) {
+	settings, err := readSettingsBatch(memberNames)
+	if errors.IsNotImplemented(err) {
+		// The controller cannot read settings in batches, so
+		// don't try again.
+		cache.mu.Lock()
+		cache.readSettingsBatch = nil
+		cache.mu.Unlock()
+		return
+	} else if err != nil {
+		logger.Debugf("cannot read relation settings of %v: %v", memberNames, err)
+		return
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	for memberName, memberSettings := range settings {
+		if cached, isMember := cache.members[memberName]; isMember && cached == nil {
+			cache.members[memberName] = memberSettings
+		}
+	}
+}
+
 // InvalidateMember ensures that the named remote unit will be considered a
 // member of the relation, and that the next attempt to read its settings will
 // use fresh data.
 func (cache *RelationCache) InvalidateMember(memberName string) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
 	cache.members[memberName] = nil
 }
 
 // RemoveMember ensures that the named remote unit will not be considered a
 // member of the relation,
 func (cache *RelationCache) RemoveMember(memberName string) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
 	delete(cache.members, memberName)
 }
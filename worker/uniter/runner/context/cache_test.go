@@ -213,3 +213,57 @@ func (s *RelationCacheSuite) TestPruneUncachesOtherSettings(c *gc.C) {
 	c.Assert(settings, jc.DeepEquals, params.Settings{"baz": "qux"})
 	c.Assert(s.calls, jc.DeepEquals, []string{"x/2", "x/2"})
 }
+
+func (s *RelationCacheSuite) TestSettingsReadsMembersInBatch(c *gc.C) {
+	var batches [][]string
+	readSettingsBatch := func(unitNames []string) (context.SettingsMap, error) {
+		batches = append(batches, unitNames)
+		return context.SettingsMap{
+			"u/1": {"foo": "bar"},
+			"u/2": {"baz": "qux"},
+		}, nil
+	}
+	s.results = []settingsResult{{
+		nil, errors.New("blam"),
+	}}
+	cache := context.NewBatchRelationCache(s.ReadSettings, readSettingsBatch, []string{"u/1", "u/2", "u/3"})
+
+	settings, err := cache.Settings("u/2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, params.Settings{"baz": "qux"})
+	settings, err = cache.Settings("u/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, params.Settings{"foo": "bar"})
+	c.Assert(batches, jc.DeepEquals, [][]string{{"u/1", "u/2", "u/3"}})
+	c.Assert(s.calls, gc.HasLen, 0)
+
+	// The settings of a member missing from the batch are read on
+	// their own, so that the error is reported.
+	_, err = cache.Settings("u/3")
+	c.Assert(err, gc.ErrorMatches, "blam")
+	c.Assert(s.calls, jc.DeepEquals, []string{"u/3"})
+	c.Assert(batches, gc.HasLen, 1)
+}
+
+func (s *RelationCacheSuite) TestSettingsBatchNotImplemented(c *gc.C) {
+	batches := 0
+	readSettingsBatch := func(unitNames []string) (context.SettingsMap, error) {
+		batches++
+		return nil, errors.NotImplementedf("ReadSettingsBatch")
+	}
+	s.results = []settingsResult{{
+		params.Settings{"foo": "bar"}, nil,
+	}, {
+		params.Settings{"baz": "qux"}, nil,
+	}}
+	cache := context.NewBatchRelationCache(s.ReadSettings, readSettingsBatch, []string{"u/1", "u/2", "u/3"})
+
+	settings, err := cache.Settings("u/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, params.Settings{"foo": "bar"})
+	settings, err = cache.Settings("u/2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, params.Settings{"baz": "qux"})
+	c.Assert(s.calls, jc.DeepEquals, []string{"u/1", "u/2"})
+	c.Assert(batches, gc.Equals, 1)
+}
@@ -128,6 +128,10 @@ type HookContext struct {
 	// id identifies the context.
 	id string
 
+	// kind identifies what the context runs, and so which hook tools
+	// may be used in it.
+	kind jujuc.ContextKind
+
 	// actionData contains the values relevant to the run of an Action:
 	// its tag, its parameters, and its results.
 	actionData *ActionData
@@ -286,6 +290,11 @@ func (ctx *HookContext) Id() string {
 	return ctx.id
 }
 
+// ContextKind is part of the jujuc.ContextKinder interface.
+func (ctx *HookContext) ContextKind() jujuc.ContextKind {
+	return ctx.kind
+}
+
 func (ctx *HookContext) UnitName() string {
 	return ctx.unitName
 }
@@ -637,6 +646,26 @@ func (ctx *HookContext) handleReboot(err *error) {
 	}
 }
 
+// writeRelationSettings persists the changes made to the unit's
+// settings in all of its relations with a single API call.
+func (ctx *HookContext) writeRelationSettings() error {
+	var changed []*ContextRelation
+	var settings []*uniter.Settings
+	for _, rctx := range ctx.relations {
+		if rctx.settings != nil {
+			changed = append(changed, rctx)
+			settings = append(settings, rctx.settings)
+		}
+	}
+	if err := ctx.state.WriteSettingsBatch(settings); err != nil {
+		return errors.Trace(err)
+	}
+	for _, rctx := range changed {
+		rctx.settingsWritten()
+	}
+	return nil
+}
+
 // Prepare implements the Context interface.
 func (ctx *HookContext) Prepare() error {
 	if ctx.actionData != nil {
@@ -668,17 +697,15 @@ func (ctx *HookContext) Flush(process string, ctxErr error) (err error) {
 		defer ctx.handleReboot(&err)
 	}
 
-	for id, rctx := range ctx.relations {
-		if writeChanges {
-			if e := rctx.WriteSettings(); e != nil {
-				e = errors.Errorf(
-					"could not write settings from %q to relation %d: %v",
-					process, id, e,
-				)
-				logger.Errorf("%v", e)
-				if ctxErr == nil {
-					ctxErr = e
-				}
+	if writeChanges {
+		if e := ctx.writeRelationSettings(); e != nil {
+			e = errors.Errorf(
+				"could not write settings from %q to relations: %v",
+				process, e,
+			)
+			logger.Errorf("%v", e)
+			if ctxErr == nil {
+				ctxErr = e
 			}
 		}
 	}
@@ -835,6 +862,12 @@ func (ctx *HookContext) SetUnitWorkloadVersion(version string) error {
 	return result.OneError()
 }
 
+// CloudCredential returns a short-lived cloud credential scoped to the
+// trust level of the unit's application.
+func (ctx *HookContext) CloudCredential() (params.ScopedCredential, error) {
+	return ctx.unit.CloudCredential()
+}
+
 // NetworkInfo returns the network info for the given bindings on the given relation.
 func (ctx *HookContext) NetworkInfo(bindingNames []string, relationId int) (map[string]params.NetworkInfoResult, error) {
 	var relId *int
@@ -157,6 +157,12 @@ func (s *InterfaceSuite) TestGetSetWorkloadVersion(c *gc.C) {
 	c.Assert(result, gc.Equals, "Pipey")
 }
 
+func (s *InterfaceSuite) TestCloudCredentialNotTrusted(c *gc.C) {
+	ctx := s.GetContext(c, -1, "")
+	_, err := ctx.CloudCredential()
+	c.Assert(err, jc.Satisfies, params.IsCodeUnauthorized)
+}
+
 func (s *InterfaceSuite) TestUnitStatusCaching(c *gc.C) {
 	ctx := s.GetContext(c, -1, "")
 	unitStatus, err := ctx.UnitStatus()
@@ -190,6 +190,7 @@ func (f *contextFactory) ActionContext(actionData *ActionData) (*HookContext, er
 	}
 	ctx.actionData = actionData
 	ctx.id = f.newId(actionData.Name)
+	ctx.kind = jujuc.ActionContextKind
 	return ctx, nil
 }
 
@@ -227,6 +228,7 @@ func (f *contextFactory) HookContext(hookInfo hook.Info) (*HookContext, error) {
 		hookName = fmt.Sprintf("%s-%s", storageName, hookName)
 	}
 	ctx.id = f.newId(hookName)
+	ctx.kind = jujuc.ContextKind(hookInfo.Kind)
 	return ctx, nil
 }
 
@@ -243,6 +245,7 @@ func (f *contextFactory) CommandContext(commandInfo CommandInfo) (*HookContext,
 	ctx.relationId = relationId
 	ctx.remoteUnitName = remoteUnitName
 	ctx.id = f.newId("run-commands")
+	ctx.kind = jujuc.CommandContextKind
 	return ctx, nil
 }
 
@@ -259,7 +262,9 @@ func (f *contextFactory) getContextRelations() map[int]*ContextRelation {
 		if found {
 			cache.Prune(memberNames)
 		} else {
-			cache = NewRelationCache(relationUnit.ReadSettings, memberNames)
+			cache = NewBatchRelationCache(
+				relationUnit.ReadSettings, readSettingsBatchFunc(relationUnit), memberNames,
+			)
 		}
 		relationCaches[id] = cache
 		contextRelations[id] = NewContextRelation(relationUnit, cache)
@@ -268,6 +273,24 @@ func (f *contextFactory) getContextRelations() map[int]*ContextRelation {
 	return contextRelations
 }
 
+// readSettingsBatchFunc returns a SettingsBatchFunc that reads the
+// settings of remote units in the given relation unit's relation.
+func readSettingsBatchFunc(relationUnit *uniter.RelationUnit) SettingsBatchFunc {
+	return func(unitNames []string) (SettingsMap, error) {
+		results, err := relationUnit.ReadSettingsBatch(unitNames)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		settings := make(SettingsMap)
+		for i, result := range results {
+			if result.Error == nil {
+				settings[unitNames[i]] = result.Settings
+			}
+		}
+		return settings, nil
+	}
+}
+
 // updateContext fills in all unspecialized fields that require an API call to
 // discover.
 //
@@ -310,6 +333,17 @@ func (f *contextFactory) updateContext(ctx *HookContext) (err error) {
 	}
 	ctx.proxySettings = modelConfig.ProxySettings()
 
+	schemas, err := ReadRelationSchemas(f.paths.GetCharmDir())
+	if err != nil {
+		return errors.Annotate(err, "reading relation schemas")
+	}
+	for _, rctx := range ctx.relations {
+		rctx.SetDataPolicy(RelationDataPolicy{
+			MaxSizeMB: modelConfig.MaxRelationDataSizeMB(),
+			Schema:    schemas[rctx.Name()],
+		})
+	}
+
 	// Calling these last, because there's a potential race: they're not guaranteed
 	// to be set in time to be needed for a hook. If they're not, we just leave them
 	// unset as we always have; this isn't great but it's about behaviour preservation.
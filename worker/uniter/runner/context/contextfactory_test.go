@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/testcharms"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner/context"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
 	runnertesting "github.com/juju/juju/worker/uniter/runner/testing"
 )
 
@@ -174,6 +175,7 @@ func (s *ContextFactorySuite) TestRelationHookContext(c *gc.C) {
 	s.AssertNotActionContext(c, ctx)
 	s.AssertRelationContext(c, ctx, 1, "")
 	s.AssertNotStorageContext(c, ctx)
+	c.Assert(ctx.ContextKind(), gc.Equals, jujuc.ContextKind("relation-broken"))
 }
 
 func (s *ContextFactorySuite) TestNewHookContextWithStorage(c *gc.C) {
@@ -260,6 +262,7 @@ func (s *ContextFactorySuite) TestActionContext(c *gc.C) {
 	s.AssertActionContext(c, ctx)
 	s.AssertNotRelationContext(c, ctx)
 	s.AssertNotStorageContext(c, ctx)
+	c.Assert(ctx.ContextKind(), gc.Equals, jujuc.ActionContextKind)
 }
 
 func (s *ContextFactorySuite) TestCommandContext(c *gc.C) {
@@ -270,6 +273,7 @@ func (s *ContextFactorySuite) TestCommandContext(c *gc.C) {
 	s.AssertNotActionContext(c, ctx)
 	s.AssertNotRelationContext(c, ctx)
 	s.AssertNotStorageContext(c, ctx)
+	c.Assert(ctx.ContextKind(), gc.Equals, jujuc.CommandContextKind)
 }
 
 func (s *ContextFactorySuite) TestCommandContextNoRelation(c *gc.C) {
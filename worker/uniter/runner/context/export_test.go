@@ -20,6 +20,7 @@ var (
 	ValidatePortRange = validatePortRange
 	TryOpenPorts      = tryOpenPorts
 	TryClosePorts     = tryClosePorts
+	SettingsDiff      = settingsDiff
 )
 
 func NewHookContext(
@@ -6,6 +6,8 @@ package context
 import (
 	"fmt"
 
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/relation"
@@ -26,6 +28,13 @@ type ContextRelation struct {
 	// settings allows read and write access to the relation unit settings.
 	settings *uniter.Settings
 
+	// original holds the relation unit settings as they were read,
+	// so that changes to them can be logged when they are written.
+	original params.Settings
+
+	// policy constrains the changes that may be made to settings.
+	policy RelationDataPolicy
+
 	// cache holds remote unit membership and settings.
 	cache *RelationCache
 }
@@ -68,16 +77,46 @@ func (ctx *ContextRelation) Settings() (jujuc.Settings, error) {
 			return nil, err
 		}
 		ctx.settings = node
+		ctx.original = node.Map()
 	}
 	return ctx.settings, nil
 }
 
+// SetDataPolicy sets the policy that constrains changes to the unit's
+// relation settings.
+func (ctx *ContextRelation) SetDataPolicy(policy RelationDataPolicy) {
+	ctx.policy = policy
+}
+
+// ValidateSettings returns an error if applying the supplied changes to
+// the unit's relation settings would violate the relation data policy.
+func (ctx *ContextRelation) ValidateSettings(changes params.Settings) error {
+	if _, err := ctx.Settings(); err != nil {
+		return errors.Trace(err)
+	}
+	return ctx.policy.Validate(ctx.settings.Map(), changes)
+}
+
 // WriteSettings persists all changes made to the unit's relation settings.
 func (ctx *ContextRelation) WriteSettings() (err error) {
-	if ctx.settings != nil {
-		err = ctx.settings.Write()
+	if ctx.settings == nil {
+		return nil
+	}
+	if err := ctx.settings.Write(); err != nil {
+		return err
+	}
+	ctx.settingsWritten()
+	return nil
+}
+
+// settingsWritten records that the unit's relation settings have been
+// persisted, logging the changes made to them.
+func (ctx *ContextRelation) settingsWritten() {
+	current := ctx.settings.Map()
+	if diff := settingsDiff(ctx.original, current, ctx.policy.Schema); diff != "" {
+		relationDataLogger.Debugf("relation %s settings changed: %s", ctx.FakeId(), diff)
 	}
-	return
+	ctx.original = current
 }
 
 // Suspended returns true if the relation is suspended.
@@ -6,6 +6,7 @@ package context_test
 import (
 	"time"
 
+	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
@@ -165,6 +166,50 @@ func (s *ContextRelationSuite) TestLocalSettings(c *gc.C) {
 	c.Assert(settings, gc.DeepEquals, map[string]interface{}{"change": "exciting"})
 }
 
+func (s *ContextRelationSuite) TestValidateSettings(c *gc.C) {
+	ctx := context.NewContextRelation(s.apiRelUnit, nil)
+	schemas, err := context.ParseRelationSchemas([]byte(`
+endpoints:
+  ring:
+    strict: true
+    keys:
+      port:
+        pattern: '[0-9]+'
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx.SetDataPolicy(context.RelationDataPolicy{
+		MaxSizeMB: 1,
+		Schema:    schemas["ring"],
+	})
+
+	err = ctx.ValidateSettings(params.Settings{"port": "8098"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.ValidateSettings(params.Settings{"host": "riak"})
+	c.Assert(err, gc.ErrorMatches, `relation setting "host" not declared in charm's relation-schema.yaml`)
+}
+
+func (s *ContextRelationSuite) TestWriteSettingsLogsChanges(c *gc.C) {
+	var tw loggo.TestWriter
+	c.Assert(loggo.RegisterWriter("relationdata-tests", &tw), jc.ErrorIsNil)
+	defer loggo.RemoveWriter("relationdata-tests")
+	logger := loggo.GetLogger("juju.worker.uniter.context.relationdata")
+	defer logger.SetLogLevel(logger.LogLevel())
+	logger.SetLogLevel(loggo.DEBUG)
+
+	ctx := context.NewContextRelation(s.apiRelUnit, nil)
+	node, err := ctx.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	node.Set("host", "riak-0")
+	node.Set("password", "hunter2")
+	err = ctx.WriteSettings()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(tw.Log(), jc.LogMatches, jc.SimpleMessages{{
+		loggo.DEBUG,
+		`relation ring:\d+ settings changed: added host="riak-0"; added password=<redacted>`,
+	}})
+}
+
 func convertSettings(settings params.Settings) map[string]interface{} {
 	result := make(map[string]interface{})
 	for k, v := range settings {
@@ -0,0 +1,242 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package context
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/set"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/relation"
+)
+
+// relationDataLogger logs changes to the unit's relation settings. It
+// is separate from the package logger so that the changes can be
+// enabled in debug-log on their own, by setting its level to DEBUG.
+var relationDataLogger = loggo.GetLogger("juju.worker.uniter.context.relationdata")
+
+// RelationSchemaFile is the name of the file, in the root of the charm
+// directory, in which a charm declares the settings it sets in its
+// relations.
+const RelationSchemaFile = "relation-schema.yaml"
+
+// redacted replaces the values of secret settings in logged changes.
+const redacted = "<redacted>"
+
+// secretKeyWords are the words which, appearing in a setting's key,
+// cause its value to be treated as secret even if the charm has not
+// declared it so.
+var secretKeyWords = []string{
+	"password",
+	"passwd",
+	"secret",
+	"token",
+	"credential",
+	"private-key",
+	"private_key",
+}
+
+// RelationSchema describes the settings a charm sets in the relations
+// of one of its endpoints.
+type RelationSchema struct {
+	// Strict, if true, causes settings that are not declared in Keys
+	// to be rejected.
+	Strict bool
+
+	// Keys holds the declared settings, keyed by name.
+	Keys map[string]RelationKeySchema
+}
+
+// RelationKeySchema describes a single relation setting.
+type RelationKeySchema struct {
+	// Pattern, if not nil, must match the whole of the setting's
+	// value.
+	Pattern *regexp.Regexp
+
+	// Secret, if true, causes the setting's value to be redacted
+	// when changes are logged.
+	Secret bool
+}
+
+// RelationDataPolicy holds the constraints on the settings a unit may
+// set in a relation.
+type RelationDataPolicy struct {
+	// MaxSizeMB is the maximum size, in MiB, of the unit's settings
+	// in the relation. Zero means there is no limit.
+	MaxSizeMB uint
+
+	// Schema is the charm's schema for the relation's endpoint.
+	Schema RelationSchema
+}
+
+type relationSchemasDoc struct {
+	Endpoints map[string]relationSchemaDoc `yaml:"endpoints"`
+}
+
+type relationSchemaDoc struct {
+	Strict bool                            `yaml:"strict,omitempty"`
+	Keys   map[string]relationKeySchemaDoc `yaml:"keys,omitempty"`
+}
+
+type relationKeySchemaDoc struct {
+	Pattern string `yaml:"pattern,omitempty"`
+	Secret  bool   `yaml:"secret,omitempty"`
+}
+
+// ReadRelationSchemas reads the relation schemas declared in the given
+// charm directory, keyed by endpoint name. If the charm does not declare
+// any schemas, an empty map is returned.
+func ReadRelationSchemas(charmDir string) (map[string]RelationSchema, error) {
+	data, err := ioutil.ReadFile(filepath.Join(charmDir, RelationSchemaFile))
+	if os.IsNotExist(err) {
+		return map[string]RelationSchema{}, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ParseRelationSchemas(data)
+}
+
+// ParseRelationSchemas parses the YAML relation schema declarations in
+// data.
+func ParseRelationSchemas(data []byte) (map[string]RelationSchema, error) {
+	var doc relationSchemasDoc
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, errors.Annotate(err, "parsing relation schemas")
+	}
+	schemas := make(map[string]RelationSchema)
+	for endpoint, sd := range doc.Endpoints {
+		schema := RelationSchema{
+			Strict: sd.Strict,
+			Keys:   make(map[string]RelationKeySchema),
+		}
+		for key, kd := range sd.Keys {
+			keySchema := RelationKeySchema{Secret: kd.Secret}
+			if kd.Pattern != "" {
+				pattern, err := regexp.Compile("^(?:" + kd.Pattern + ")$")
+				if err != nil {
+					return nil, errors.Annotatef(err, "endpoint %q key %q pattern", endpoint, key)
+				}
+				keySchema.Pattern = pattern
+			}
+			schema.Keys[key] = keySchema
+		}
+		schemas[endpoint] = schema
+	}
+	return schemas, nil
+}
+
+// validate returns an error if any of the supplied changes, which are
+// deleted if empty, are not permitted by the schema.
+func (s RelationSchema) validate(changes params.Settings) error {
+	for _, key := range sortedKeys(changes) {
+		value := changes[key]
+		if value == "" {
+			continue
+		}
+		keySchema, ok := s.Keys[key]
+		if !ok {
+			if s.Strict {
+				return errors.Errorf("relation setting %q not declared in charm's %s", key, RelationSchemaFile)
+			}
+			continue
+		}
+		if keySchema.Pattern != nil && !keySchema.Pattern.MatchString(value) {
+			return errors.Errorf("relation setting %q value does not match pattern %q", key, keySchema.Pattern)
+		}
+	}
+	return nil
+}
+
+// isSecret reports whether the value of the setting with the given key
+// should be redacted when logged.
+func (s RelationSchema) isSecret(key string) bool {
+	if s.Keys[key].Secret {
+		return true
+	}
+	lower := strings.ToLower(key)
+	for _, word := range secretKeyWords {
+		if strings.Contains(lower, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate returns an error if applying the supplied changes to the
+// current settings would violate the policy. Changes with empty values
+// delete settings.
+func (p RelationDataPolicy) Validate(current, changes params.Settings) error {
+	if err := p.Schema.validate(changes); err != nil {
+		return errors.Trace(err)
+	}
+	return relation.CheckSettingsSize(applyChanges(current, changes), p.MaxSizeMB)
+}
+
+// applyChanges returns a copy of current with changes applied.
+func applyChanges(current, changes params.Settings) params.Settings {
+	result := make(params.Settings)
+	for k, v := range current {
+		result[k] = v
+	}
+	for k, v := range changes {
+		if v == "" {
+			delete(result, k)
+		} else {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// settingsDiff describes the differences between old and new, one
+// change per key in key order, with the values of secret settings
+// redacted. It returns an empty string if there are no differences.
+func settingsDiff(old, new params.Settings, schema RelationSchema) string {
+	keys := set.NewStrings()
+	for k := range old {
+		keys.Add(k)
+	}
+	for k := range new {
+		keys.Add(k)
+	}
+	var changes []string
+	for _, key := range keys.SortedValues() {
+		oldValue, wasSet := old[key]
+		newValue, isSet := new[key]
+		quote := func(value string) string {
+			if schema.isSecret(key) {
+				return redacted
+			}
+			return fmt.Sprintf("%q", value)
+		}
+		switch {
+		case !wasSet:
+			changes = append(changes, fmt.Sprintf("added %s=%s", key, quote(newValue)))
+		case !isSet:
+			changes = append(changes, fmt.Sprintf("removed %s", key))
+		case oldValue != newValue:
+			changes = append(changes, fmt.Sprintf("changed %s=%s (was %s)", key, quote(newValue), quote(oldValue)))
+		}
+	}
+	return strings.Join(changes, "; ")
+}
+
+func sortedKeys(settings params.Settings) []string {
+	keys := make([]string, 0, len(settings))
+	for k := range settings {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,160 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package context_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter/runner/context"
+)
+
+type relationDataSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&relationDataSuite{})
+
+const relationSchemas = `
+endpoints:
+  db:
+    strict: true
+    keys:
+      host:
+        pattern: '[a-z0-9.-]+'
+      port:
+        pattern: '[0-9]+'
+      auth:
+        secret: true
+  website:
+    keys:
+      port:
+        pattern: '[0-9]+'
+`
+
+func (s *relationDataSuite) TestReadRelationSchemasMissing(c *gc.C) {
+	schemas, err := context.ReadRelationSchemas(c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schemas, gc.HasLen, 0)
+}
+
+func (s *relationDataSuite) TestReadRelationSchemas(c *gc.C) {
+	dir := c.MkDir()
+	err := ioutil.WriteFile(filepath.Join(dir, context.RelationSchemaFile), []byte(relationSchemas), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	schemas, err := context.ReadRelationSchemas(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schemas, gc.HasLen, 2)
+	c.Assert(schemas["db"].Strict, jc.IsTrue)
+	c.Assert(schemas["db"].Keys, gc.HasLen, 3)
+	c.Assert(schemas["db"].Keys["auth"].Secret, jc.IsTrue)
+	c.Assert(schemas["db"].Keys["auth"].Pattern, gc.IsNil)
+	c.Assert(schemas["website"].Strict, jc.IsFalse)
+}
+
+func (s *relationDataSuite) TestParseRelationSchemasInvalidPattern(c *gc.C) {
+	_, err := context.ParseRelationSchemas([]byte(`
+endpoints:
+  db:
+    keys:
+      host:
+        pattern: '[a-z'
+`))
+	c.Assert(err, gc.ErrorMatches, `endpoint "db" key "host" pattern: error parsing regexp: .*`)
+}
+
+func (s *relationDataSuite) TestValidate(c *gc.C) {
+	schemas, err := context.ParseRelationSchemas([]byte(relationSchemas))
+	c.Assert(err, jc.ErrorIsNil)
+	current := params.Settings{"private-address": "10.0.0.1"}
+
+	for i, test := range []struct {
+		endpoint string
+		changes  params.Settings
+		err      string
+	}{{
+		endpoint: "db",
+		changes:  params.Settings{"host": "db.example.com", "port": "5432"},
+	}, {
+		endpoint: "db",
+		changes:  params.Settings{"port": "a lot"},
+		err:      `relation setting "port" value does not match pattern "\^\(\?:\[0-9\]\+\)\$"`,
+	}, {
+		endpoint: "db",
+		changes:  params.Settings{"user": "admin"},
+		err:      `relation setting "user" not declared in charm's relation-schema.yaml`,
+	}, {
+		// Undeclared settings may be deleted.
+		endpoint: "db",
+		changes:  params.Settings{"private-address": ""},
+	}, {
+		endpoint: "website",
+		changes:  params.Settings{"user": "admin"},
+	}, {
+		endpoint: "unknown",
+		changes:  params.Settings{"port": "a lot"},
+	}} {
+		c.Logf("test %d", i)
+		policy := context.RelationDataPolicy{Schema: schemas[test.endpoint]}
+		err := policy.Validate(current, test.changes)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *relationDataSuite) TestValidateSize(c *gc.C) {
+	policy := context.RelationDataPolicy{MaxSizeMB: 1}
+	current := params.Settings{"large": strings.Repeat("x", 1024*1024-10)}
+
+	err := policy.Validate(current, params.Settings{"small": "x"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = policy.Validate(current, params.Settings{"larger": "xxxxxxxxxx"})
+	c.Assert(err, gc.ErrorMatches, `relation settings size 1048587 bytes exceeds limit of 1048576 bytes \(max-relation-data-size 1M\)`)
+
+	// Replacing a large setting makes room for others.
+	err = policy.Validate(current, params.Settings{"large": "", "larger": "xxxxxxxxxx"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *relationDataSuite) TestSettingsDiff(c *gc.C) {
+	schemas, err := context.ParseRelationSchemas([]byte(relationSchemas))
+	c.Assert(err, jc.ErrorIsNil)
+	old := params.Settings{
+		"host":        "db-0",
+		"port":        "5432",
+		"password":    "hunter2",
+		"auth":        "md5:abc",
+		"unchanged":   "same",
+		"api-token":   "t0",
+		"replication": "off",
+	}
+	new := params.Settings{
+		"host":      "db-1",
+		"port":      "5432",
+		"password":  "hunter3",
+		"auth":      "md5:def",
+		"unchanged": "same",
+		"user":      "admin",
+		"api-token": "t1",
+	}
+	diff := context.SettingsDiff(old, new, schemas["db"])
+	c.Assert(diff, gc.Equals, ""+
+		"changed api-token=<redacted> (was <redacted>); "+
+		"changed auth=<redacted> (was <redacted>); "+
+		`changed host="db-1" (was "db-0"); `+
+		"changed password=<redacted> (was <redacted>); "+
+		"removed replication; "+
+		`added user="admin"`,
+	)
+	c.Assert(context.SettingsDiff(old, old, schemas["db"]), gc.Equals, "")
+}
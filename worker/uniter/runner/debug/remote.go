@@ -0,0 +1,148 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package debug
+
+import (
+	"io"
+	"os/exec"
+	"sync"
+	"syscall"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// RemoteSessions provides access to the "juju debug-hooks" sessions
+// that clients open for the unit through the controller, rather than
+// over SSH.
+type RemoteSessions interface {
+	// DebugHooksSession reports whether there is a session for the
+	// unit and, if so, the hooks it intercepts.
+	DebugHooksSession() ([]string, bool, error)
+
+	// ConnectDebugHooks attaches an execution of the named hook to
+	// the session, returning the stream that drives the hook's shell.
+	ConnectDebugHooks(hookName string) (base.Stream, error)
+}
+
+// RemoteSession represents a "juju debug-hooks" session opened through
+// the controller.
+type RemoteSession struct {
+	sessions RemoteSessions
+	hooks    set.Strings
+}
+
+// FindRemoteSession returns the unit's debug-hooks session opened
+// through the controller. If there is none, an error satisfying
+// errors.IsNotFound is returned.
+func FindRemoteSession(sessions RemoteSessions) (*RemoteSession, error) {
+	hooks, active, err := sessions.DebugHooksSession()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !active {
+		return nil, errors.NotFoundf("debug-hooks session")
+	}
+	return &RemoteSession{sessions: sessions, hooks: set.NewStrings(hooks...)}, nil
+}
+
+// MatchHook returns true if the specified hook name matches
+// the hook specified by the debug-hooks client.
+func (s *RemoteSession) MatchHook(hookName string) bool {
+	return s.hooks.IsEmpty() || s.hooks.Contains(hookName)
+}
+
+// RunHook "runs" the hook with the specified name via debug-hooks,
+// starting a shell in the hook's environment whose input and output
+// are relayed to the client through the controller.
+func (s *RemoteSession) RunHook(hookName, charmDir string, env []string) error {
+	stream, err := s.sessions.ConnectDebugHooks(hookName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer stream.Close()
+
+	env = utils.Setenv(env, "JUJU_HOOK_NAME="+hookName)
+	env = utils.Setenv(env, `PS1=$JUJU_UNIT_NAME:$JUJU_HOOK_NAME % `)
+
+	output := &streamWriter{stream: stream}
+	cmd := exec.Command("/bin/bash", "--noprofile", "--norc", "-i")
+	cmd.Env = env
+	cmd.Dir = charmDir
+	cmd.Stdout = output
+	cmd.Stderr = output
+	input, err := cmd.StdinPipe()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := io.WriteString(output, remoteDebugHooksWelcomeMessage); err != nil {
+		return errors.Trace(err)
+	}
+	if err := cmd.Start(); err != nil {
+		return errors.Trace(err)
+	}
+	go func() {
+		for {
+			var m params.DebugHooksMessage
+			if err := stream.ReadJSON(&m); err != nil {
+				// The client has gone away, so kill the hook shell
+				// rather than leave the unit waiting for it.
+				cmd.Process.Kill()
+				return
+			}
+			if _, err := input.Write(m.Data); err != nil {
+				return
+			}
+		}
+	}()
+	err = cmd.Wait()
+
+	exitCode := 0
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		exitCode = -1
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Exited() {
+			exitCode = status.ExitStatus()
+		}
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	// The client may have gone away already, in which case there is
+	// no one to tell.
+	output.sendExitCode(exitCode)
+	return err
+}
+
+// streamWriter is an io.Writer that sends the data written to it
+// over a debug-hooks stream.
+type streamWriter struct {
+	mu     sync.Mutex
+	stream base.Stream
+}
+
+// Write is part of the io.Writer interface.
+func (w *streamWriter) Write(data []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.stream.WriteJSON(params.DebugHooksMessage{Data: data}); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return len(data), nil
+}
+
+func (w *streamWriter) sendExitCode(code int) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.stream.WriteJSON(params.DebugHooksMessage{ExitCode: &code})
+}
+
+const remoteDebugHooksWelcomeMessage = `This is a Juju debug-hooks session, relayed by the controller.
+You need to execute hooks/actions manually if you want them to run for
+trapped events. When you are finished with an event, run 'exit' to allow
+Juju to continue processing new events for this unit.
+
+`
@@ -0,0 +1,203 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package debug_test
+
+import (
+	"encoding/json"
+	"io"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter/runner/debug"
+)
+
+type remoteSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&remoteSuite{})
+
+func (s *remoteSuite) SetUpTest(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("bug 1403084: Currently debug does not work on windows")
+	}
+	s.IsolationSuite.SetUpTest(c)
+}
+
+func (s *remoteSuite) TestFindRemoteSessionNone(c *gc.C) {
+	sessions := &fakeRemoteSessions{}
+	_, err := debug.FindRemoteSession(sessions)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	sessions.CheckCallNames(c, "DebugHooksSession")
+}
+
+func (s *remoteSuite) TestFindRemoteSessionError(c *gc.C) {
+	sessions := &fakeRemoteSessions{}
+	sessions.SetErrors(errors.New("boom"))
+	_, err := debug.FindRemoteSession(sessions)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *remoteSuite) TestMatchHook(c *gc.C) {
+	session, err := debug.FindRemoteSession(&fakeRemoteSessions{active: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(session.MatchHook("install"), jc.IsTrue)
+
+	session, err = debug.FindRemoteSession(&fakeRemoteSessions{
+		active: true,
+		hooks:  []string{"start", "stop"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(session.MatchHook("install"), jc.IsFalse)
+	c.Assert(session.MatchHook("start"), jc.IsTrue)
+}
+
+func (s *remoteSuite) TestRunHook(c *gc.C) {
+	stream := newFakeStream("echo $JUJU_HOOK_NAME $FOO\n", "exit 3\n")
+	sessions := &fakeRemoteSessions{active: true, stream: stream}
+	session, err := debug.FindRemoteSession(sessions)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = session.RunHook("install", c.MkDir(), []string{"FOO=bar"})
+	c.Assert(err, gc.ErrorMatches, "exit status 3")
+	sessions.CheckCall(c, 1, "ConnectDebugHooks", "install")
+
+	output, exitCode := stream.output()
+	c.Assert(output, jc.Contains, "This is a Juju debug-hooks session")
+	c.Assert(output, jc.Contains, "install bar\n")
+	c.Assert(exitCode, gc.NotNil)
+	c.Assert(*exitCode, gc.Equals, 3)
+	stream.mu.Lock()
+	defer stream.mu.Unlock()
+	c.Assert(stream.closed, jc.IsTrue)
+}
+
+func (s *remoteSuite) TestRunHookClientGone(c *gc.C) {
+	// The stream ends without the shell being told to exit.
+	stream := newFakeStream()
+	close(stream.input)
+	sessions := &fakeRemoteSessions{active: true, stream: stream}
+	session, err := debug.FindRemoteSession(sessions)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = session.RunHook("install", c.MkDir(), nil)
+	c.Assert(err, gc.ErrorMatches, "signal: killed")
+	_, exitCode := stream.output()
+	c.Assert(exitCode, gc.NotNil)
+	c.Assert(*exitCode, gc.Equals, -1)
+}
+
+func (s *remoteSuite) TestRunHookConnectError(c *gc.C) {
+	sessions := &fakeRemoteSessions{active: true}
+	sessions.SetErrors(nil, errors.New("boom"))
+	session, err := debug.FindRemoteSession(sessions)
+	c.Assert(err, jc.ErrorIsNil)
+	err = session.RunHook("install", c.MkDir(), nil)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeRemoteSessions struct {
+	testing.Stub
+	hooks  []string
+	active bool
+	stream base.Stream
+}
+
+func (f *fakeRemoteSessions) DebugHooksSession() ([]string, bool, error) {
+	f.MethodCall(f, "DebugHooksSession")
+	return f.hooks, f.active, f.NextErr()
+}
+
+func (f *fakeRemoteSessions) ConnectDebugHooks(hookName string) (base.Stream, error) {
+	f.MethodCall(f, "ConnectDebugHooks", hookName)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.stream, nil
+}
+
+// fakeStream is a base.Stream that supplies the client's input, and
+// records the messages sent to the client. Once the input is consumed,
+// reads block until the stream is closed, or fail if input is closed.
+type fakeStream struct {
+	base.Stream
+	input chan params.DebugHooksMessage
+	done  chan struct{}
+
+	mu       sync.Mutex
+	messages []params.DebugHooksMessage
+	closed   bool
+}
+
+func newFakeStream(input ...string) *fakeStream {
+	stream := &fakeStream{
+		input: make(chan params.DebugHooksMessage, len(input)),
+		done:  make(chan struct{}),
+	}
+	for _, data := range input {
+		stream.input <- params.DebugHooksMessage{Data: []byte(data)}
+	}
+	return stream
+}
+
+func (s *fakeStream) ReadJSON(v interface{}) error {
+	select {
+	case m, ok := <-s.input:
+		if !ok {
+			return io.EOF
+		}
+		*(v.(*params.DebugHooksMessage)) = m
+		return nil
+	case <-s.done:
+		return io.EOF
+	}
+}
+
+func (s *fakeStream) WriteJSON(v interface{}) error {
+	// Round-trip the message, as the real stream would.
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var m params.DebugHooksMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, m)
+	return nil
+}
+
+func (s *fakeStream) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.done)
+	}
+	return nil
+}
+
+func (s *fakeStream) output() (string, *int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var output []string
+	var exitCode *int
+	for _, m := range s.messages {
+		output = append(output, string(m.Data))
+		if m.ExitCode != nil {
+			exitCode = m.ExitCode
+		}
+	}
+	return strings.Join(output, ""), exitCode
+}
@@ -4,7 +4,9 @@
 package runner
 
 import (
+	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/runner/context"
+	"github.com/juju/juju/worker/uniter/runner/debug"
 )
 
 var (
@@ -17,3 +19,11 @@ var (
 func RunnerPaths(rnr Runner) context.Paths {
 	return rnr.(*runner).paths
 }
+
+func NewRunnerWithDebugHooks(ctx Context, paths context.Paths, debugHooks debug.RemoteSessions) Runner {
+	return &runner{context: ctx, paths: paths, debugHooks: debugHooks}
+}
+
+func NewRunnerWithOutputLimits(ctx Context, paths context.Paths, limits charmrunner.OutputLimits) Runner {
+	return &runner{context: ctx, paths: paths, outputLimits: limits}
+}
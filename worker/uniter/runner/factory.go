@@ -34,18 +34,24 @@ type Factory interface {
 }
 
 // NewFactory returns a Factory capable of creating runners for executing
-// charm hooks, actions and commands.
+// charm hooks, actions and commands, whose output retained in memory is
+// bounded by the given limits.
 func NewFactory(
 	state *uniter.State,
 	paths context.Paths,
 	contextFactory context.ContextFactory,
+	outputLimits charmrunner.OutputLimits,
 ) (
 	Factory, error,
 ) {
+	if err := outputLimits.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
 	f := &factory{
 		state:          state,
 		paths:          paths,
 		contextFactory: contextFactory,
+		outputLimits:   outputLimits,
 	}
 
 	return f, nil
@@ -58,7 +64,8 @@ type factory struct {
 	state *uniter.State
 
 	// Fields that shouldn't change in a factory's lifetime.
-	paths context.Paths
+	paths        context.Paths
+	outputLimits charmrunner.OutputLimits
 }
 
 // NewCommandRunner exists to satisfy the Factory interface.
@@ -67,8 +74,7 @@ func (f *factory) NewCommandRunner(commandInfo context.CommandInfo) (Runner, err
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	runner := NewRunner(ctx, f.paths)
-	return runner, nil
+	return &runner{context: ctx, paths: f.paths, outputLimits: f.outputLimits}, nil
 }
 
 // NewHookRunner exists to satisfy the Factory interface.
@@ -81,8 +87,17 @@ func (f *factory) NewHookRunner(hookInfo hook.Info) (Runner, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	runner := NewRunner(ctx, f.paths)
-	return runner, nil
+	return f.newDebuggableRunner(ctx), nil
+}
+
+// newDebuggableRunner returns a runner whose hooks and actions may be
+// intercepted by debug-hooks sessions opened through the controller.
+func (f *factory) newDebuggableRunner(ctx Context) Runner {
+	r := &runner{context: ctx, paths: f.paths, outputLimits: f.outputLimits}
+	if f.state != nil {
+		r.debugHooks = f.state
+	}
+	return r
 }
 
 // NewActionRunner exists to satisfy the Factory interface.
@@ -124,8 +139,7 @@ func (f *factory) NewActionRunner(actionId string) (Runner, error) {
 
 	actionData := context.NewActionData(name, &tag, params)
 	ctx, err := f.contextFactory.ActionContext(actionData)
-	runner := NewRunner(ctx, f.paths)
-	return runner, nil
+	return f.newDebuggableRunner(ctx), nil
 }
 
 func getCharm(charmPath string) (charm.Charm, error) {
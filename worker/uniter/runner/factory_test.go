@@ -175,6 +175,7 @@ func (s *FactorySuite) TestNewHookRunnerWithStorage(c *gc.C) {
 		uniter,
 		s.paths,
 		contextFactory,
+		charmrunner.OutputLimits{},
 	)
 	c.Assert(err, jc.ErrorIsNil)
 
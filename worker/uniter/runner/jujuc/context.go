@@ -40,6 +40,7 @@ type HookContext interface {
 	ContextComponents
 	ContextRelations
 	ContextVersion
+	ContextCredential
 }
 
 // UnitHookContext is the context for a unit hook.
@@ -249,6 +250,12 @@ type ContextRelation interface {
 	// this relation.
 	Settings() (Settings, error)
 
+	// ValidateSettings returns an error if applying the supplied
+	// changes to the local unit's settings in this relation would
+	// violate the charm's relation schema or the model's relation
+	// data size limit. Changes with empty values delete settings.
+	ValidateSettings(params.Settings) error
+
 	// UnitNames returns a list of the remote units in the relation.
 	UnitNames() []string
 
@@ -290,6 +297,15 @@ type ContextVersion interface {
 	SetUnitWorkloadVersion(string) error
 }
 
+// ContextCredential expresses the parts of a hook context related to
+// the cloud credentials of trusted applications.
+type ContextCredential interface {
+	// CloudCredential returns a short-lived cloud credential scoped to
+	// the trust level of the unit's application, or an error if the
+	// application is not trusted.
+	CloudCredential() (params.ScopedCredential, error)
+}
+
 // Settings is implemented by types that manipulate unit settings.
 type Settings interface {
 	Map() params.Settings
@@ -0,0 +1,111 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6/hooks"
+)
+
+// ContextKind identifies what a Context is running: the kind of a hook,
+// such as "config-changed" or "relation-joined", an action, or
+// commands run by juju-run.
+type ContextKind string
+
+const (
+	// ActionContextKind is the kind of a Context running an action.
+	ActionContextKind ContextKind = "action"
+
+	// CommandContextKind is the kind of a Context running commands
+	// with juju-run.
+	CommandContextKind ContextKind = "run-commands"
+)
+
+// ContextKinder is implemented by contexts that know what they are
+// running. Hook tools are only restricted to the kinds of context in
+// which they are meaningful when run in such a context.
+type ContextKinder interface {
+	ContextKind() ContextKind
+}
+
+// describe returns a description of the kind for use in errors.
+func (k ContextKind) describe() string {
+	switch k {
+	case ActionContextKind:
+		return "an action"
+	case CommandContextKind:
+		return "juju-run commands"
+	}
+	return fmt.Sprintf("a %q hook", string(k))
+}
+
+// kindRule restricts a hook tool to the kinds of context in which it
+// is meaningful.
+type kindRule struct {
+	// allowed reports whether the tool may run in the kind of
+	// context.
+	allowed func(ContextKind) bool
+
+	// reason explains the restriction to charm authors.
+	reason string
+}
+
+// kindRules holds the rules restricting hook tools, keyed by command
+// name. Tools without a rule may run in any context.
+var kindRules = map[string]kindRule{
+	"action-get" + cmdSuffix:  {isAction, "it can only be used in actions"},
+	"action-set" + cmdSuffix:  {isAction, "it can only be used in actions"},
+	"action-fail" + cmdSuffix: {isAction, "it can only be used in actions"},
+}
+
+func isAction(kind ContextKind) bool {
+	return kind == ActionContextKind
+}
+
+// checkContextKind returns an error satisfying errors.IsNotSupported
+// if the named hook tool cannot run in the context.
+func checkContextKind(ctx Context, name string) error {
+	kinder, ok := ctx.(ContextKinder)
+	if !ok {
+		return nil
+	}
+	rule, ok := kindRules[name]
+	if !ok {
+		return nil
+	}
+	kind := kinder.ContextKind()
+	if kind == "" || rule.allowed(kind) {
+		return nil
+	}
+	return errors.NewNotSupported(nil, fmt.Sprintf(
+		"%s cannot be used in %s: %s", name, kind.describe(), rule.reason,
+	))
+}
+
+// checkRelationNotBroken returns an error satisfying
+// errors.IsNotSupported if the context is running the relation-broken
+// hook of the relation with the given id. That relation no longer
+// exists, so the named hook tool cannot write to it, although it may
+// still write to the unit's other relations.
+func checkRelationNotBroken(ctx Context, name string, relationId int) error {
+	kinder, ok := ctx.(ContextKinder)
+	if !ok || kinder.ContextKind() != ContextKind(hooks.RelationBroken) {
+		return nil
+	}
+	r, err := ctx.HookRelation()
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if r.Id() != relationId {
+		return nil
+	}
+	return errors.NewNotSupported(nil, fmt.Sprintf(
+		"%s cannot be used on relation %s in %s: the relation no longer exists",
+		name, r.FakeId(), ContextKind(hooks.RelationBroken).describe(),
+	))
+}
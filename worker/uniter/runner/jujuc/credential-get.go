@@ -0,0 +1,71 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+)
+
+// CredentialGetCommand implements the credential-get command.
+type CredentialGetCommand struct {
+	cmd.CommandBase
+	ctx Context
+	out cmd.Output
+}
+
+// NewCredentialGetCommand creates a credential-get command.
+func NewCredentialGetCommand(ctx Context) (cmd.Command, error) {
+	return &CredentialGetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *CredentialGetCommand) Info() *cmd.Info {
+	doc := `
+credential-get returns a cloud credential that the charm may use to act
+on the cloud hosting the model. The application must have been trusted
+by a model administrator with "juju trust"; the credential's permissions
+are limited to the trust level granted.
+
+The credential is short-lived: charms should get a new credential each
+time they need one, rather than store it.
+`
+	return &cmd.Info{
+		Name:    "credential-get",
+		Purpose: "get a cloud credential for a trusted application",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *CredentialGetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "yaml", cmd.DefaultFormatters)
+}
+
+// Init is part of the cmd.Command interface.
+func (c *CredentialGetCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *CredentialGetCommand) Run(ctx *cmd.Context) error {
+	credential, err := c.ctx.CloudCredential()
+	if err != nil {
+		return errors.Annotate(err, "cannot get cloud credential")
+	}
+	return c.out.Write(ctx, credentialDetails{
+		AuthType:   credential.AuthType,
+		Attributes: credential.Attributes,
+		Expiry:     credential.Expiry.UTC().Format(time.RFC3339),
+	})
+}
+
+type credentialDetails struct {
+	AuthType   string            `json:"auth-type" yaml:"auth-type"`
+	Attributes map[string]string `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+	Expiry     string            `json:"expiry" yaml:"expiry"`
+}
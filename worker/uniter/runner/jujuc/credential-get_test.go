@@ -0,0 +1,83 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type CredentialGetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&CredentialGetSuite{})
+
+func (s *CredentialGetSuite) createCommand(c *gc.C, err error) cmd.Command {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.Credential.CloudCredential = params.ScopedCredential{
+		AuthType: "access-key",
+		Attributes: map[string]string{
+			"access-key":    "key",
+			"secret-key":    "secret",
+			"session-token": "token",
+		},
+		Expiry: time.Date(2018, 4, 1, 12, 0, 0, 0, time.UTC),
+	}
+	s.Stub.SetErrors(err)
+
+	com, err := jujuc.NewCommand(hctx, cmdString("credential-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	return com
+}
+
+func (s *CredentialGetSuite) TestCredentialGet(c *gc.C) {
+	com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	// Older YAML encoders do not quote timestamp-like strings.
+	c.Check(bufferString(ctx.Stdout), gc.Matches, `
+auth-type: access-key
+attributes:
+  access-key: key
+  secret-key: secret
+  session-token: token
+expiry: "?2018-04-01T12:00:00Z"?
+`[1:])
+	s.Stub.CheckCallNames(c, "CloudCredential")
+}
+
+func (s *CredentialGetSuite) TestCredentialGetJSON(c *gc.C) {
+	com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"--format", "json"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(bufferString(ctx.Stdout), gc.Equals,
+		`{"auth-type":"access-key","attributes":{"access-key":"key","secret-key":"secret","session-token":"token"},"expiry":"2018-04-01T12:00:00Z"}`+"\n")
+}
+
+func (s *CredentialGetSuite) TestCredentialGetArgs(c *gc.C) {
+	com := s.createCommand(c, nil)
+	cmdtesting.TestInit(c, com, []string{"foo"}, `unrecognized args: \["foo"\]`)
+}
+
+func (s *CredentialGetSuite) TestCredentialGetError(c *gc.C) {
+	com := s.createCommand(c, errors.Unauthorizedf(`application "mysql" is not trusted`))
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR cannot get cloud credential: application \"mysql\" is not trusted\n")
+}
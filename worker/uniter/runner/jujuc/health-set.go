@@ -0,0 +1,80 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/status"
+)
+
+// HealthSetCommand implements the health-set command.
+type HealthSetCommand struct {
+	cmd.CommandBase
+	ctx     Context
+	healthy bool
+	message string
+}
+
+// NewHealthSetCommand makes a jujuc health-set command.
+func NewHealthSetCommand(ctx Context) (cmd.Command, error) {
+	return &HealthSetCommand{ctx: ctx}, nil
+}
+
+func (c *HealthSetCommand) Info() *cmd.Info {
+	doc := `
+Overrides the unit's health as determined by the charm's health checks.
+Setting the unit unhealthy changes its workload status to "unhealthy"
+with the given message. Setting it healthy restores an "active" workload
+status if the unit is currently unhealthy, and otherwise does nothing.
+Failing health checks will mark the unit unhealthy again when they
+next run.
+`
+	return &cmd.Info{
+		Name:    "health-set",
+		Args:    "<healthy | unhealthy> [message]",
+		Purpose: "override unit health",
+		Doc:     doc,
+	}
+}
+
+func (c *HealthSetCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.Errorf("invalid args, require <health> [message]")
+	}
+	switch args[0] {
+	case "healthy":
+		c.healthy = true
+	case "unhealthy":
+		c.healthy = false
+	default:
+		return errors.Errorf(`invalid health %q, expected "healthy" or "unhealthy"`, args[0])
+	}
+	if len(args) > 1 {
+		c.message = args[1]
+		return cmd.CheckEmpty(args[2:])
+	}
+	return nil
+}
+
+func (c *HealthSetCommand) Run(ctx *cmd.Context) error {
+	if !c.healthy {
+		return c.ctx.SetUnitStatus(StatusInfo{
+			Status: string(status.Unhealthy),
+			Info:   c.message,
+		})
+	}
+	current, err := c.ctx.UnitStatus()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if current.Status != string(status.Unhealthy) {
+		return nil
+	}
+	return c.ctx.SetUnitStatus(StatusInfo{
+		Status: string(status.Active),
+		Info:   c.message,
+	})
+}
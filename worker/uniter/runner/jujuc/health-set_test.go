@@ -0,0 +1,79 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type healthSetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&healthSetSuite{})
+
+var healthSetInitTests = []struct {
+	args []string
+	err  string
+}{
+	{[]string{"healthy"}, ""},
+	{[]string{"unhealthy", "db unreachable"}, ""},
+	{[]string{}, `invalid args, require <health> \[message\]`},
+	{[]string{"unhealthy", "hello", "extra"}, `unrecognized args: \["extra"\]`},
+	{[]string{"sick"}, `invalid health "sick", expected "healthy" or "unhealthy"`},
+}
+
+func (s *healthSetSuite) TestInit(c *gc.C) {
+	for i, t := range healthSetInitTests {
+		c.Logf("test %d: %#v", i, t.args)
+		hctx := s.GetStatusHookContext(c)
+		com, err := jujuc.NewCommand(hctx, "health-set")
+		c.Assert(err, jc.ErrorIsNil)
+		cmdtesting.TestInit(c, com, t.args, t.err)
+	}
+}
+
+func (s *healthSetSuite) run(c *gc.C, hctx jujuc.Context, args ...string) {
+	com, err := jujuc.NewCommand(hctx, "health-set")
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, args)
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+}
+
+func (s *healthSetSuite) TestUnhealthy(c *gc.C) {
+	hctx := s.GetStatusHookContext(c)
+	s.run(c, hctx, "unhealthy", "db unreachable")
+	status, err := hctx.UnitStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Status, gc.Equals, "unhealthy")
+	c.Assert(status.Info, gc.Equals, "db unreachable")
+}
+
+func (s *healthSetSuite) TestHealthyRestoresActive(c *gc.C) {
+	hctx := s.GetStatusHookContext(c)
+	s.run(c, hctx, "unhealthy")
+	s.run(c, hctx, "healthy", "recovered")
+	status, err := hctx.UnitStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Status, gc.Equals, "active")
+	c.Assert(status.Info, gc.Equals, "recovered")
+}
+
+func (s *healthSetSuite) TestHealthyLeavesOtherStatus(c *gc.C) {
+	hctx := s.GetStatusHookContext(c)
+	err := hctx.SetUnitStatus(jujuc.StatusInfo{Status: "blocked", Info: "need config"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.run(c, hctx, "healthy")
+	status, err := hctx.UnitStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Status, gc.Equals, "blocked")
+	c.Assert(status.Info, gc.Equals, "need config")
+}
@@ -0,0 +1,30 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuctesting
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Credential holds values for the hook context.
+type Credential struct {
+	CloudCredential params.ScopedCredential
+}
+
+// ContextCredential is a test double for jujuc.ContextCredential.
+type ContextCredential struct {
+	contextBase
+	info *Credential
+}
+
+// CloudCredential implements jujuc.ContextCredential.
+func (c *ContextCredential) CloudCredential() (params.ScopedCredential, error) {
+	c.stub.AddCall("CloudCredential")
+	if err := c.stub.NextErr(); err != nil {
+		return params.ScopedCredential{}, errors.Trace(err)
+	}
+	return c.info.CloudCredential, nil
+}
@@ -83,6 +83,12 @@ func (r *ContextRelation) Settings() (jujuc.Settings, error) {
 	return settings, nil
 }
 
+// ValidateSettings implements jujuc.ContextRelation.
+func (r *ContextRelation) ValidateSettings(changes params.Settings) error {
+	r.stub.AddCall("ValidateSettings", changes)
+	return r.stub.NextErr()
+}
+
 // UnitNames implements jujuc.ContextRelation.
 func (r *ContextRelation) UnitNames() []string {
 	r.stub.AddCall("UnitNames")
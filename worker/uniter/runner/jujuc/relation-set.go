@@ -27,6 +27,11 @@ too long to fit within the command length limit of the shell or
 operating system. The file will contain a YAML map containing the
 settings.  Settings in the file will be overridden by any duplicate
 key-value arguments. A value of "-" for the filename means <stdin>.
+
+The total size of the unit's settings in a relation is limited by the
+model's max-relation-data-size. If the charm declares the settings of
+an endpoint in relation-schema.yaml, the settings are also checked
+against that schema.
 `
 
 // RelationSetCommand implements the relation-set command.
@@ -75,6 +80,9 @@ func (c *RelationSetCommand) Init(args []string) error {
 	if c.RelationId == -1 {
 		return errors.Errorf("no relation id specified")
 	}
+	if err := checkRelationNotBroken(c.ctx, c.Info().Name, c.RelationId); err != nil {
+		return errors.Trace(err)
+	}
 
 	// The overrides will be applied during Run when c.settingsFile is handled.
 	overrides, err := keyvalues.Parse(args, true)
@@ -139,6 +147,9 @@ func (c *RelationSetCommand) Run(ctx *cmd.Context) (err error) {
 	if err != nil {
 		return errors.Annotate(err, "cannot read relation settings")
 	}
+	if err := r.ValidateSettings(c.Settings); err != nil {
+		return errors.Annotate(err, "cannot set relation settings")
+	}
 	for k, v := range c.Settings {
 		if v != "" {
 			settings.Set(k, v)
@@ -12,9 +12,12 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 	"github.com/juju/juju/worker/uniter/runner/jujuc/jujuctesting"
 )
@@ -65,6 +68,11 @@ too long to fit within the command length limit of the shell or
 operating system. The file will contain a YAML map containing the
 settings.  Settings in the file will be overridden by any duplicate
 key-value arguments. A value of "-" for the filename means <stdin>.
+
+The total size of the unit's settings in a relation is limited by the
+model's max-relation-data-size. If the charm declares the settings of
+an endpoint in relation-schema.yaml, the settings are also checked
+against that schema.
 `[1:], t.expect))
 		c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
 	}
@@ -330,6 +338,30 @@ func (s *RelationSetSuite) TestInit(c *gc.C) {
 	}
 }
 
+func (s *RelationSetSuite) TestInitRelationBroken(c *gc.C) {
+	hctx, _ := s.newHookContext(1, "")
+	ctx := kindContext{hctx, "relation-broken"}
+
+	// The broken relation can't be written to.
+	com, err := jujuc.NewCommand(ctx, cmdString("relation-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = cmdtesting.InitCommand(com, []string{"foo=bar"})
+	c.Assert(err, gc.ErrorMatches, `relation-set cannot be used on relation peer1:1 in a "relation-broken" hook: the relation no longer exists`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+
+	com, err = jujuc.NewCommand(ctx, cmdString("relation-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = cmdtesting.InitCommand(com, []string{"-r", "1", "foo=bar"})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+
+	// Other relations still can.
+	com, err = jujuc.NewCommand(ctx, cmdString("relation-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = cmdtesting.InitCommand(com, []string{"-r", "0", "foo=bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(com.(*jujuc.RelationSetCommand).RelationId, gc.Equals, 0)
+}
+
 // Tests start with a relation with the settings {"base": "value"}
 var relationSetRunTests = []struct {
 	change map[string]string
@@ -373,6 +405,32 @@ func (s *RelationSetSuite) TestRun(c *gc.C) {
 	}
 }
 
+func (s *RelationSetSuite) TestRunInvalidSettings(c *gc.C) {
+	hctx, info := s.newHookContext(1, "")
+	basic := jujuctesting.Settings{"base": "value"}
+	info.rels[1].Units["u/0"] = basic
+
+	com, err := jujuc.NewCommand(hctx, cmdString("relation-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	rset := com.(*jujuc.RelationSetCommand)
+	rset.RelationId = 1
+	rset.Settings = map[string]string{"foo": "bar"}
+	s.Stub.ResetCalls()
+	s.Stub.SetErrors(nil, errors.New("relation setting \"foo\" not declared"))
+
+	ctx := cmdtesting.Context(c)
+	err = com.Run(ctx)
+	c.Assert(err, gc.ErrorMatches, `cannot set relation settings: relation setting "foo" not declared`)
+	s.Stub.CheckCalls(c, []testing.StubCall{
+		{"Relation", []interface{}{1}},
+		{"Settings", nil},
+		{"ValidateSettings", []interface{}{params.Settings{"foo": "bar"}}},
+	})
+
+	// The settings are unchanged.
+	c.Assert(info.rels[1].Units["u/0"], gc.DeepEquals, basic)
+}
+
 func (s *RelationSetSuite) TestRunDeprecationWarning(c *gc.C) {
 	hctx, _ := s.newHookContext(0, "")
 	com, _ := jujuc.NewCommand(hctx, cmdString("relation-set"))
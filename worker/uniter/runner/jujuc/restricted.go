@@ -153,3 +153,8 @@ func (*RestrictedContext) UnitWorkloadVersion() (string, error) {
 func (*RestrictedContext) SetUnitWorkloadVersion(string) error {
 	return ErrRestrictedContext
 }
+
+// CloudCredential implements hooks.Context.
+func (*RestrictedContext) CloudCredential() (params.ScopedCredential, error) {
+	return params.ScopedCredential{}, ErrRestrictedContext
+}
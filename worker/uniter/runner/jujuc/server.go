@@ -23,6 +23,7 @@ import (
 	"github.com/juju/utils/exec"
 
 	"github.com/juju/juju/juju/sockets"
+	"github.com/juju/juju/worker/common/charmrunner"
 )
 
 // CmdSuffix is the filename suffix to use for executables.
@@ -61,8 +62,10 @@ var baseCommands = map[string]creator{
 	"juju-reboot" + cmdSuffix:             NewJujuRebootCommand,
 	"status-get" + cmdSuffix:              NewStatusGetCommand,
 	"status-set" + cmdSuffix:              NewStatusSetCommand,
+	"health-set" + cmdSuffix:              NewHealthSetCommand,
 	"network-get" + cmdSuffix:             NewNetworkGetCommand,
 	"application-version-set" + cmdSuffix: NewApplicationVersionSetCommand,
+	"credential-get" + cmdSuffix:          NewCredentialGetCommand,
 }
 
 var storageCommands = map[string]creator{
@@ -101,12 +104,17 @@ func CommandNames() (names []string) {
 }
 
 // NewCommand returns an instance of the named Command, initialized to execute
-// against the supplied Context.
+// against the supplied Context. It returns an error satisfying
+// errors.IsNotSupported if the command cannot be used in the kind of
+// context supplied.
 func NewCommand(ctx Context, name string) (cmd.Command, error) {
 	f := allEnabledCommands()[name]
 	if f == nil {
 		return nil, errors.Errorf("unknown command: %s", name)
 	}
+	if err := checkContextKind(ctx, name); err != nil {
+		return nil, errors.Trace(err)
+	}
 	command, err := f(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -135,6 +143,7 @@ type CmdGetter func(contextId, cmdName string) (cmd.Command, error)
 type Jujuc struct {
 	mu     sync.Mutex
 	getCmd CmdGetter
+	limits charmrunner.OutputLimits
 }
 
 // badReqErrorf returns an error indicating a bad Request.
@@ -163,12 +172,13 @@ func (j *Jujuc) Main(req Request, resp *exec.ExecResponse) error {
 		// if its Read method is called.
 		stdin = noStdinReader{}
 	}
-	var stdout, stderr bytes.Buffer
+	stdout := charmrunner.NewCappedOutput("hook-tool-stdout", j.limits)
+	stderr := charmrunner.NewCappedOutput("hook-tool-stderr", j.limits)
 	ctx := &cmd.Context{
 		Dir:    req.Dir,
 		Stdin:  stdin,
-		Stdout: &stdout,
-		Stderr: &stderr,
+		Stdout: stdout,
+		Stderr: stderr,
 	}
 	j.mu.Lock()
 	defer j.mu.Unlock()
@@ -179,11 +189,23 @@ func (j *Jujuc) Main(req Request, resp *exec.ExecResponse) error {
 	logger.Tracef("hook context id %q; dir %q", req.ContextId, req.Dir)
 	wrapper := &cmdWrapper{c, nil}
 	resp.Code = cmd.Main(wrapper, ctx, req.Args)
+	stdout.Close()
+	stderr.Close()
 	if errors.Cause(wrapper.err) == ErrNoStdin {
 		return ErrNoStdin
 	}
 	resp.Stdout = stdout.Bytes()
 	resp.Stderr = stderr.Bytes()
+	// Tell the charm where to find any output that was not returned.
+	for _, output := range []struct {
+		name string
+		*charmrunner.CappedOutput
+	}{{"stdout", stdout}, {"stderr", stderr}} {
+		if overflow := output.Overflow(); overflow != "" {
+			logger.Warningf("hook tool %q %s %s", req.CommandName, output.name, overflow)
+			resp.Stderr = append(resp.Stderr, fmt.Sprintf("%s: %s %s\n", req.CommandName, output.name, overflow)...)
+		}
+	}
 	return nil
 }
 
@@ -200,10 +222,11 @@ type Server struct {
 
 // NewServer creates an RPC server bound to socketPath, which can execute
 // remote command invocations against an appropriate Context. It will not
-// actually do so until Run is called.
-func NewServer(getCmd CmdGetter, socketPath string) (*Server, error) {
+// actually do so until Run is called. The output of the commands it
+// retains in memory is bounded by the given limits.
+func NewServer(getCmd CmdGetter, socketPath string, limits charmrunner.OutputLimits) (*Server, error) {
 	server := rpc.NewServer()
-	if err := server.Register(&Jujuc{getCmd: getCmd}); err != nil {
+	if err := server.Register(&Jujuc{getCmd: getCmd, limits: limits}); err != nil {
 		return nil, err
 	}
 	listener, err := sockets.Listen(socketPath)
@@ -23,6 +23,7 @@ import (
 
 	"github.com/juju/juju/juju/sockets"
 	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 )
 
@@ -102,7 +103,7 @@ func (s *ServerSuite) osDependentSockPath(c *gc.C) string {
 func (s *ServerSuite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
 	s.sockPath = s.osDependentSockPath(c)
-	srv, err := jujuc.NewServer(factory, s.sockPath)
+	srv, err := jujuc.NewServer(factory, s.sockPath, charmrunner.OutputLimits{})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(srv, gc.NotNil)
 	s.server = srv
@@ -145,6 +146,49 @@ func (s *ServerSuite) TestHappyPath(c *gc.C) {
 	c.Assert(string(content), gc.Equals, "something")
 }
 
+func (s *ServerSuite) TestOutputSpooled(c *gc.C) {
+	spoolDir := c.MkDir()
+	sockPath := s.osDependentSockPath(c)
+	srv, err := jujuc.NewServer(factory, sockPath, charmrunner.OutputLimits{
+		MaxMemory: 5,
+		SpoolDir:  spoolDir,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	done := make(chan error)
+	go func() { done <- srv.Run() }()
+	defer func() {
+		srv.Close()
+		c.Assert(<-done, gc.IsNil)
+	}()
+
+	client, err := sockets.Dial(sockPath)
+	c.Assert(err, jc.ErrorIsNil)
+	defer client.Close()
+	var resp exec.ExecResponse
+	err = client.Call("Jujuc.Main", jujuc.Request{
+		ContextId:   "validCtx",
+		Dir:         c.MkDir(),
+		CommandName: "remote",
+	}, &resp)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.Code, gc.Equals, 0)
+	c.Assert(string(resp.Stdout), gc.Equals, "eye o")
+
+	stdoutSpool, err := filepath.Glob(filepath.Join(spoolDir, "hook-tool-stdout-*.log"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stdoutSpool, gc.HasLen, 1)
+	content, err := ioutil.ReadFile(stdoutSpool[0])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(content), gc.Equals, "f newt\n")
+	stderrSpool, err := filepath.Glob(filepath.Join(spoolDir, "hook-tool-stderr-*.log"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stderrSpool, gc.HasLen, 1)
+
+	c.Assert(string(resp.Stderr), gc.Equals, "toe o"+
+		"remote: stdout output truncated after 5 bytes; 7 more bytes spooled to "+stdoutSpool[0]+"\n"+
+		"remote: stderr output truncated after 5 bytes; 7 more bytes spooled to "+stderrSpool[0]+"\n")
+}
+
 func (s *ServerSuite) TestNoStdin(c *gc.C) {
 	dir := c.MkDir()
 	_, err := s.Call(c, jujuc.Request{
@@ -265,6 +309,8 @@ var newCommandTests = []struct {
 	{"storage-get", ""},
 	{"status-get", ""},
 	{"status-set", ""},
+	{"health-set", ""},
+	{"credential-get", ""},
 	// The error message contains .exe on Windows
 	{"random", "unknown command: random(.exe)?"},
 }
@@ -284,3 +330,44 @@ func (s *NewCommandSuite) TestNewCommand(c *gc.C) {
 		}
 	}
 }
+
+// kindContext is a Context that knows what it is running.
+type kindContext struct {
+	jujuc.Context
+	kind jujuc.ContextKind
+}
+
+func (ctx kindContext) ContextKind() jujuc.ContextKind {
+	return ctx.kind
+}
+
+var contextKindTests = []struct {
+	name string
+	kind jujuc.ContextKind
+	err  string
+}{
+	{"action-get", jujuc.ActionContextKind, ""},
+	{"action-set", "config-changed", `action-set(.exe)? cannot be used in a "config-changed" hook: it can only be used in actions`},
+	{"action-fail", jujuc.CommandContextKind, `action-fail(.exe)? cannot be used in juju-run commands: it can only be used in actions`},
+	{"relation-set", "relation-changed", ""},
+	{"relation-set", "config-changed", ""},
+	// relation-set checks the relation it writes to when it starts.
+	{"relation-set", "relation-broken", ""},
+	{"relation-get", "relation-broken", ""},
+	{"action-set", "", ""},
+}
+
+func (s *NewCommandSuite) TestNewCommandContextKind(c *gc.C) {
+	hctx, _ := s.newHookContext(0, "")
+	for i, t := range contextKindTests {
+		c.Logf("test %d: %s in %q", i, t.name, t.kind)
+		com, err := jujuc.NewCommand(kindContext{hctx, t.kind}, cmdString(t.name))
+		if t.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+			c.Check(com.Info().Name, gc.Equals, t.name)
+		} else {
+			c.Check(com, gc.IsNil)
+			c.Check(err, gc.ErrorMatches, t.err)
+		}
+	}
+}
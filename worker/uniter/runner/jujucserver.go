@@ -0,0 +1,113 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"sync"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/worker/common/charmrunner"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// jujucServers holds the jujuc servers serving the hook contexts that
+// are currently running. Contexts that run concurrently with the same
+// socket share a server, which dispatches each command to the context
+// whose id it was sent with, so that no context can see another's state.
+var jujucServers = &jujucServerRegistry{
+	servers: make(map[string]*sharedJujucServer),
+	closing: make(map[string]chan struct{}),
+}
+
+type jujucServerRegistry struct {
+	mu      sync.Mutex
+	servers map[string]*sharedJujucServer
+
+	// closing holds a channel for each socket whose server is being
+	// closed, which is closed once the socket is free to use again.
+	closing map[string]chan struct{}
+}
+
+// sharedJujucServer is a jujuc server, and the contexts it serves.
+type sharedJujucServer struct {
+	server   *jujuc.Server
+	contexts map[string]Context
+}
+
+// acquire registers the context with the jujuc server listening on the
+// given socket, starting the server with the given output limits if
+// necessary. The returned function
+// must be called to deregister the context once it has finished running;
+// the server is closed once it has no contexts left.
+func (r *jujucServerRegistry) acquire(socketPath string, ctx Context, limits charmrunner.OutputLimits) (func(), error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for {
+		closing, ok := r.closing[socketPath]
+		if !ok {
+			break
+		}
+		r.mu.Unlock()
+		<-closing
+		r.mu.Lock()
+	}
+	shared, ok := r.servers[socketPath]
+	if !ok {
+		shared = &sharedJujucServer{contexts: make(map[string]Context)}
+		getCmd := func(ctxId, cmdName string) (cmd.Command, error) {
+			return r.getCmd(shared, ctxId, cmdName)
+		}
+		srv, err := jujuc.NewServer(getCmd, socketPath, limits)
+		if err != nil {
+			return nil, errors.Annotate(err, "starting jujuc server")
+		}
+		go srv.Run()
+		shared.server = srv
+		r.servers[socketPath] = shared
+	}
+	id := ctx.Id()
+	if _, ok := shared.contexts[id]; ok {
+		return nil, errors.Errorf("context %q is already running", id)
+	}
+	shared.contexts[id] = ctx
+	return func() {
+		r.release(socketPath, shared, id)
+	}, nil
+}
+
+// release deregisters the context with the given id, closing the server
+// if it was the last context using it.
+func (r *jujucServerRegistry) release(socketPath string, shared *sharedJujucServer, id string) {
+	r.mu.Lock()
+	delete(shared.contexts, id)
+	if len(shared.contexts) > 0 {
+		r.mu.Unlock()
+		return
+	}
+	delete(r.servers, socketPath)
+	closing := make(chan struct{})
+	r.closing[socketPath] = closing
+	r.mu.Unlock()
+
+	// Close waits for in-flight commands, so it must not be
+	// called with the lock held.
+	shared.server.Close()
+
+	r.mu.Lock()
+	delete(r.closing, socketPath)
+	r.mu.Unlock()
+	close(closing)
+}
+
+func (r *jujucServerRegistry) getCmd(shared *sharedJujucServer, ctxId, cmdName string) (cmd.Command, error) {
+	r.mu.Lock()
+	ctx, ok := shared.contexts[ctxId]
+	r.mu.Unlock()
+	if !ok {
+		return nil, errors.Errorf("unknown context id %q", ctxId)
+	}
+	return jujuc.NewCommand(ctx, cmdName)
+}
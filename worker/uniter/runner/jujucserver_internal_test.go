@@ -0,0 +1,91 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !windows
+
+package runner
+
+import (
+	"path/filepath"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/common/charmrunner"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type jujucServerSuite struct {
+	testing.BaseSuite
+
+	registry   *jujucServerRegistry
+	socketPath string
+}
+
+var _ = gc.Suite(&jujucServerSuite{})
+
+func (s *jujucServerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.registry = &jujucServerRegistry{
+		servers: make(map[string]*sharedJujucServer),
+		closing: make(map[string]chan struct{}),
+	}
+	s.socketPath = filepath.Join(c.MkDir(), "jujuc.socket")
+}
+
+type fakeContext struct {
+	Context
+	id string
+}
+
+func (ctx *fakeContext) Id() string {
+	return ctx.id
+}
+
+func (s *jujucServerSuite) TestContextsShareServer(c *gc.C) {
+	ctx0 := &fakeContext{id: "ctx-0"}
+	ctx1 := &fakeContext{id: "ctx-1"}
+	release0, err := s.registry.acquire(s.socketPath, ctx0, charmrunner.OutputLimits{})
+	c.Assert(err, jc.ErrorIsNil)
+	release1, err := s.registry.acquire(s.socketPath, ctx1, charmrunner.OutputLimits{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.registry.servers, gc.HasLen, 1)
+	shared := s.registry.servers[s.socketPath]
+
+	// Each command is bound to the context it was sent with.
+	for _, ctx := range []*fakeContext{ctx0, ctx1} {
+		command, err := s.registry.getCmd(shared, ctx.id, "is-leader")
+		c.Assert(err, jc.ErrorIsNil)
+		expect, err := jujuc.NewCommand(ctx, "is-leader")
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(command, jc.DeepEquals, expect)
+	}
+
+	release0()
+	_, err = s.registry.getCmd(shared, ctx0.id, "is-leader")
+	c.Assert(err, gc.ErrorMatches, `unknown context id "ctx-0"`)
+	c.Assert(s.registry.servers, gc.HasLen, 1)
+
+	release1()
+	c.Assert(s.registry.servers, gc.HasLen, 0)
+	c.Assert(s.registry.closing, gc.HasLen, 0)
+}
+
+func (s *jujucServerSuite) TestDuplicateContext(c *gc.C) {
+	ctx := &fakeContext{id: "ctx-0"}
+	release, err := s.registry.acquire(s.socketPath, ctx, charmrunner.OutputLimits{})
+	c.Assert(err, jc.ErrorIsNil)
+	defer release()
+	_, err = s.registry.acquire(s.socketPath, ctx, charmrunner.OutputLimits{})
+	c.Assert(err, gc.ErrorMatches, `context "ctx-0" is already running`)
+}
+
+func (s *jujucServerSuite) TestReacquireAfterClose(c *gc.C) {
+	release, err := s.registry.acquire(s.socketPath, &fakeContext{id: "ctx-0"}, charmrunner.OutputLimits{})
+	c.Assert(err, jc.ErrorIsNil)
+	release()
+	release, err = s.registry.acquire(s.socketPath, &fakeContext{id: "ctx-1"}, charmrunner.OutputLimits{})
+	c.Assert(err, jc.ErrorIsNil)
+	release()
+}
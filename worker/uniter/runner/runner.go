@@ -9,10 +9,10 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"time"
 	"unicode/utf8"
 
-	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/clock"
@@ -60,13 +60,21 @@ type Context interface {
 
 // NewRunner returns a Runner backed by the supplied context and paths.
 func NewRunner(context Context, paths context.Paths) Runner {
-	return &runner{context, paths}
+	return &runner{context: context, paths: paths}
 }
 
 // runner implements Runner.
 type runner struct {
 	context Context
 	paths   context.Paths
+
+	// debugHooks, if not nil, provides the debug-hooks sessions that
+	// clients open through the controller.
+	debugHooks debug.RemoteSessions
+
+	// outputLimits bounds the output of hooks, commands and hook tools
+	// retained in memory.
+	outputLimits charmrunner.OutputLimits
 }
 
 func (runner *runner) Context() Context {
@@ -82,11 +90,11 @@ func (runner *runner) RunCommands(commands string) (*utilexec.ExecResponse, erro
 // runCommandsWithTimeout is a helper to abstract common code between run commands and
 // juju-run as an action
 func (runner *runner) runCommandsWithTimeout(commands string, timeout time.Duration, clock clock.Clock) (*utilexec.ExecResponse, error) {
-	srv, err := runner.startJujucServer()
+	release, err := runner.startJujucServer()
 	if err != nil {
 		return nil, err
 	}
-	defer srv.Close()
+	defer release()
 
 	env, err := runner.context.HookVars(runner.paths)
 	if err != nil {
@@ -115,7 +123,35 @@ func (runner *runner) runCommandsWithTimeout(commands string, timeout time.Durat
 	}
 
 	// Block and wait for process to finish
-	return command.WaitWithCancel(cancel)
+	result, err := command.WaitWithCancel(cancel)
+	if result != nil {
+		runner.capCommandOutput(result)
+	}
+	return result, err
+}
+
+// capCommandOutput limits the output of commands that is passed on in
+// the response to the configured size, spooling the rest to files and
+// noting where in the response's stderr. The whole output is still
+// read into memory while the commands run, but this keeps it out of
+// action results and the responses to juju-run.
+func (runner *runner) capCommandOutput(result *utilexec.ExecResponse) {
+	var overflows []string
+	capOutput := func(name string, data []byte) []byte {
+		output := charmrunner.NewCappedOutput("run-"+name, runner.outputLimits)
+		output.Write(data)
+		output.Close()
+		if overflow := output.Overflow(); overflow != "" {
+			logger.Warningf("command %s %s", name, overflow)
+			overflows = append(overflows, fmt.Sprintf("juju-run: %s %s\n", name, overflow))
+		}
+		return output.Bytes()
+	}
+	result.Stdout = capOutput("stdout", result.Stdout)
+	result.Stderr = capOutput("stderr", result.Stderr)
+	for _, overflow := range overflows {
+		result.Stderr = append(result.Stderr, overflow...)
+	}
 }
 
 // runJujuRunAction is the function that executes when a juju-run action is ran.
@@ -205,11 +241,11 @@ func (runner *runner) RunHook(hookName string) error {
 }
 
 func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) error {
-	srv, err := runner.startJujucServer()
+	release, err := runner.startJujucServer()
 	if err != nil {
 		return err
 	}
-	defer srv.Close()
+	defer release()
 
 	env, err := runner.context.HookVars(runner.paths)
 	if err != nil {
@@ -226,12 +262,31 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) e
 	if session, _ := debugctx.FindSession(); session != nil && session.MatchHook(hookName) {
 		logger.Infof("executing %s via debug-hooks", hookName)
 		err = session.RunHook(hookName, runner.paths.GetCharmDir(), env)
+	} else if session := runner.findRemoteDebugSession(); session != nil && session.MatchHook(hookName) {
+		logger.Infof("executing %s via debug-hooks through the controller", hookName)
+		err = session.RunHook(hookName, runner.paths.GetCharmDir(), env)
 	} else {
 		err = runner.runCharmHook(hookName, env, charmLocation)
 	}
 	return runner.context.Flush(hookName, err)
 }
 
+// findRemoteDebugSession returns the debug-hooks session opened for the
+// unit through the controller, or nil if there is none.
+func (runner *runner) findRemoteDebugSession() *debug.RemoteSession {
+	if runner.debugHooks == nil {
+		return nil
+	}
+	session, err := debug.FindRemoteSession(runner.debugHooks)
+	if err != nil {
+		if !errors.IsNotFound(err) && !errors.IsNotImplemented(err) {
+			logger.Warningf("cannot check for debug-hooks session: %v", err)
+		}
+		return nil
+	}
+	return session
+}
+
 func (runner *runner) runCharmHook(hookName string, env []string, charmLocation string) error {
 	charmDir := runner.paths.GetCharmDir()
 	hook, err := searchHook(charmDir, filepath.Join(charmLocation, hookName))
@@ -248,7 +303,13 @@ func (runner *runner) runCharmHook(hookName string, env []string, charmLocation
 	}
 	ps.Stdout = outWriter
 	ps.Stderr = outWriter
-	hookLogger := charmrunner.NewHookLogger(runner.getLogger(hookName), outReader)
+	// Spool files are named for the hook or action, so that the
+	// output of one is not rotated away by that of another.
+	output := charmrunner.NewCappedOutput(
+		fmt.Sprintf("%s-%s", strings.TrimSuffix(charmLocation, "s"), hookName),
+		runner.outputLimits,
+	)
+	hookLogger := charmrunner.NewCappedHookLogger(runner.getLogger(hookName), outReader, output)
 	go hookLogger.Run()
 	err = ps.Start()
 	outWriter.Close()
@@ -259,23 +320,21 @@ func (runner *runner) runCharmHook(hookName string, env []string, charmLocation
 		err = ps.Wait()
 	}
 	hookLogger.Stop()
+	output.Close()
+	if overflow := output.Overflow(); overflow != "" {
+		if err != nil {
+			return errors.Annotatef(err, "%s %s", hookName, overflow)
+		}
+		logger.Warningf("%s %s", hookName, overflow)
+	}
 	return errors.Trace(err)
 }
 
-func (runner *runner) startJujucServer() (*jujuc.Server, error) {
-	// Prepare server.
-	getCmd := func(ctxId, cmdName string) (cmd.Command, error) {
-		if ctxId != runner.context.Id() {
-			return nil, errors.Errorf("expected context id %q, got %q", runner.context.Id(), ctxId)
-		}
-		return jujuc.NewCommand(runner.context, cmdName)
-	}
-	srv, err := jujuc.NewServer(getCmd, runner.paths.GetJujucSocket())
-	if err != nil {
-		return nil, errors.Annotate(err, "starting jujuc server")
-	}
-	go srv.Run()
-	return srv, nil
+// startJujucServer makes the runner's context available to the hook
+// tools through the jujuc server for the runner's socket, and returns a
+// function that must be called once the context has finished running.
+func (runner *runner) startJujucServer() (func(), error) {
+	return jujucServers.acquire(runner.paths.GetJujucSocket(), runner.context, runner.outputLimits)
 }
 
 func (runner *runner) getLogger(hookName string) loggo.Logger {
@@ -19,6 +19,7 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6/hooks"
 
+	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner"
@@ -146,6 +147,47 @@ func (s *RunHookSuite) TestRunHook(c *gc.C) {
 	}
 }
 
+func (s *RunHookSuite) TestRunHookViaRemoteDebugSession(c *gc.C) {
+	ctx, err := s.contextFactory.HookContext(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	paths := runnertesting.NewRealPaths(c)
+	sessions := &mockDebugSessions{active: true, hooks: []string{"something-happened"}}
+	sessions.SetErrors(nil, errors.New("no stream"))
+
+	rnr := runner.NewRunnerWithDebugHooks(ctx, paths, sessions)
+	err = rnr.RunHook("something-happened")
+	c.Assert(err, gc.ErrorMatches, "no stream")
+	sessions.CheckCall(c, 1, "ConnectDebugHooks", "something-happened")
+}
+
+func (s *RunHookSuite) TestRunHookNotInterceptedByRemoteDebugSession(c *gc.C) {
+	ctx, err := s.contextFactory.HookContext(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	paths := runnertesting.NewRealPaths(c)
+	sessions := &mockDebugSessions{active: true, hooks: []string{"install"}}
+
+	rnr := runner.NewRunnerWithDebugHooks(ctx, paths, sessions)
+	err = rnr.RunHook("something-happened")
+	c.Assert(charmrunner.IsMissingHookError(err), jc.IsTrue)
+	sessions.CheckCallNames(c, "DebugHooksSession")
+}
+
+type mockDebugSessions struct {
+	envtesting.Stub
+	hooks  []string
+	active bool
+}
+
+func (m *mockDebugSessions) DebugHooksSession() ([]string, bool, error) {
+	m.MethodCall(m, "DebugHooksSession")
+	return m.hooks, m.active, m.NextErr()
+}
+
+func (m *mockDebugSessions) ConnectDebugHooks(hookName string) (base.Stream, error) {
+	m.MethodCall(m, "ConnectDebugHooks", hookName)
+	return nil, m.NextErr()
+}
+
 type MockContext struct {
 	runner.Context
 	actionData      *context.ActionData
@@ -253,6 +295,35 @@ func (s *RunMockContextSuite) TestRunHookFlushFailure(c *gc.C) {
 	s.assertRecordedPid(c, ctx.expectPid)
 }
 
+func (s *RunMockContextSuite) TestRunHookOutputSpooled(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("hook output differs on windows")
+	}
+	ctx := &MockContext{}
+	makeCharm(c, hookSpec{
+		dir:    "hooks",
+		name:   hookName,
+		perm:   0700,
+		code:   123,
+		stdout: "0123456789",
+	}, s.paths.GetCharmDir())
+	spoolDir := c.MkDir()
+	err := runner.NewRunnerWithOutputLimits(ctx, s.paths, charmrunner.OutputLimits{
+		MaxMemory: 4,
+		SpoolDir:  spoolDir,
+	}).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+
+	spooled, err := filepath.Glob(filepath.Join(spoolDir, "hook-something-happened-*.log"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spooled, gc.HasLen, 1)
+	content, err := ioutil.ReadFile(spooled[0])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(content), gc.Equals, "456789\n")
+	c.Assert(ctx.flushFailure, gc.ErrorMatches,
+		`something-happened output truncated after 4 bytes; 7 more bytes spooled to .*: exit status 123`)
+}
+
 func (s *RunMockContextSuite) TestRunActionFlushSuccess(c *gc.C) {
 	expectErr := errors.New("pew pew pew")
 	ctx := &MockContext{
@@ -318,6 +389,27 @@ func (s *RunMockContextSuite) TestRunActionSuccessful(c *gc.C) {
 	c.Assert(ctx.actionResults["Stderr"], gc.Equals, "")
 }
 
+func (s *RunMockContextSuite) TestRunActionOutputCapped(c *gc.C) {
+	ctx := &MockContext{
+		actionData: &context.ActionData{},
+		actionParams: map[string]interface{}{
+			"command": "echo 0123456789",
+			"timeout": 0,
+		},
+		actionResults: map[string]interface{}{},
+	}
+	spoolDir := c.MkDir()
+	err := runner.NewRunnerWithOutputLimits(ctx, s.paths, charmrunner.OutputLimits{
+		MaxMemory: 4,
+		SpoolDir:  spoolDir,
+	}).RunAction("juju-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.IsNil)
+	c.Assert(ctx.actionResults["Stdout"], gc.Equals, "0123")
+	c.Assert(ctx.actionResults["Stderr"], gc.Matches,
+		`juju-run: stdout output truncated after 4 bytes; \d+ more bytes spooled to .*run-stdout-.*\.log\n`)
+}
+
 func (s *RunMockContextSuite) TestRunActionCancelled(c *gc.C) {
 	timeout := 1 * time.Nanosecond
 	ctx := &MockContext{
@@ -0,0 +1,226 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter/operation"
+)
+
+// UniterStateRecorder records and retrieves snapshots of the uniter's
+// local state in the controller.
+type UniterStateRecorder interface {
+	UniterState() (params.UniterState, error)
+	SetUniterState(params.UniterState) error
+}
+
+// readLocalState returns a snapshot of the uniter state held under the
+// supplied paths, or nil if the uniter has not yet recorded any.
+func readLocalState(paths StatePaths) (*params.UniterState, error) {
+	operationState, err := ioutil.ReadFile(paths.OperationsFile)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	relationState, err := readStateTree(paths.RelationsDir)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading relation state")
+	}
+	storageState, err := readStateTree(paths.StorageDir)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading storage state")
+	}
+	return &params.UniterState{
+		OperationState: string(operationState),
+		RelationState:  relationState,
+		StorageState:   storageState,
+	}, nil
+}
+
+// writeLocalState writes the supplied snapshot of the uniter state
+// under the supplied paths.
+func writeLocalState(paths StatePaths, uniterState params.UniterState) error {
+	if err := writeStateTree(paths.RelationsDir, uniterState.RelationState); err != nil {
+		return errors.Annotate(err, "writing relation state")
+	}
+	if err := writeStateTree(paths.StorageDir, uniterState.StorageState); err != nil {
+		return errors.Annotate(err, "writing storage state")
+	}
+	// The operation state is written last, since its presence
+	// indicates that the local state is complete.
+	if err := os.MkdirAll(filepath.Dir(paths.OperationsFile), 0755); err != nil {
+		return errors.Trace(err)
+	}
+	err := utils.AtomicWriteFile(paths.OperationsFile, []byte(uniterState.OperationState), 0644)
+	return errors.Annotate(err, "writing operation state")
+}
+
+// readStateTree returns the contents of the files under dir, keyed by
+// their slash-separated paths relative to dir. Directories are recorded
+// with a trailing slash, since the presence of an empty directory can
+// be significant. Files that the uniter did not write, such as
+// temporary files left behind by an interrupted write, are skipped.
+func readStateTree(dir string) (map[string]string, error) {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	tree := make(map[string]string)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == dir {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		rel = filepath.ToSlash(rel)
+		if strings.ContainsAny(rel, ".$") {
+			logger.Debugf("not recording state file %q", path)
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.IsDir() {
+			tree[rel+"/"] = ""
+			return nil
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		tree[rel] = string(data)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return tree, nil
+}
+
+// writeStateTree writes the supplied contents, as returned by
+// readStateTree, under dir.
+func writeStateTree(dir string, tree map[string]string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Trace(err)
+	}
+	for rel, data := range tree {
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		if !strings.HasPrefix(path, filepath.Clean(dir)+string(filepath.Separator)) {
+			return errors.NotValidf("state file path %q", rel)
+		}
+		if strings.HasSuffix(rel, "/") {
+			if err := os.MkdirAll(path, 0755); err != nil {
+				return errors.Trace(err)
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return errors.Trace(err)
+		}
+		if err := utils.AtomicWriteFile(path, []byte(data), 0644); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// restoreLocalState writes the uniter state most recently recorded in
+// the controller under the supplied paths, if the uniter has no local
+// state there. It reports whether any state was restored.
+func restoreLocalState(recorder UniterStateRecorder, paths StatePaths) (bool, error) {
+	if _, err := os.Stat(paths.OperationsFile); err == nil {
+		return false, nil
+	} else if !os.IsNotExist(err) {
+		return false, errors.Trace(err)
+	}
+	uniterState, err := recorder.UniterState()
+	if params.IsCodeNotFound(err) || errors.IsNotImplemented(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Annotate(err, "getting recorded uniter state")
+	}
+	if err := writeLocalState(paths, uniterState); err != nil {
+		return false, errors.Annotate(err, "restoring uniter state")
+	}
+	return true, nil
+}
+
+// stateSnapshotter records snapshots of the uniter's local state in
+// the controller whenever it changes.
+type stateSnapshotter struct {
+	recorder UniterStateRecorder
+	paths    StatePaths
+	last     *params.UniterState
+	disabled bool
+}
+
+// snapshot records the uniter's local state in the controller, if it
+// has changed since it was last recorded.
+func (s *stateSnapshotter) snapshot() error {
+	if s.disabled {
+		return nil
+	}
+	uniterState, err := readLocalState(s.paths)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if uniterState == nil || reflect.DeepEqual(uniterState, s.last) {
+		return nil
+	}
+	if err := s.recorder.SetUniterState(*uniterState); errors.IsNotImplemented(err) {
+		logger.Infof("controller does not support recording uniter state")
+		s.disabled = true
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "recording uniter state")
+	}
+	s.last = uniterState
+	return nil
+}
+
+// snapshotExecutor is an operation.Executor that records a snapshot of
+// the uniter's local state after running or skipping each operation.
+type snapshotExecutor struct {
+	operation.Executor
+	snapshot func() error
+}
+
+// Run is part of the operation.Executor interface.
+func (x *snapshotExecutor) Run(op operation.Operation) error {
+	return x.recordSnapshot(x.Executor.Run(op))
+}
+
+// Skip is part of the operation.Executor interface.
+func (x *snapshotExecutor) Skip(op operation.Operation) error {
+	return x.recordSnapshot(x.Executor.Skip(op))
+}
+
+// recordSnapshot records a snapshot of the uniter's local state, which
+// may have changed even if the operation failed, and returns the
+// operation's error in preference to any error recording the snapshot.
+func (x *snapshotExecutor) recordSnapshot(opErr error) error {
+	if err := x.snapshot(); err != nil {
+		if opErr == nil {
+			return errors.Trace(err)
+		}
+		logger.Errorf("after operation: %v", err)
+	}
+	return opErr
+}
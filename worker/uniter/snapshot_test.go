@@ -0,0 +1,233 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter"
+	"github.com/juju/juju/worker/uniter/operation"
+)
+
+type snapshotSuite struct {
+	testing.IsolationSuite
+	paths uniter.StatePaths
+}
+
+var _ = gc.Suite(&snapshotSuite{})
+
+func (s *snapshotSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.paths = newStatePaths(c.MkDir())
+}
+
+func newStatePaths(dir string) uniter.StatePaths {
+	return uniter.StatePaths{
+		OperationsFile: filepath.Join(dir, "state", "uniter"),
+		RelationsDir:   filepath.Join(dir, "state", "relations"),
+		StorageDir:     filepath.Join(dir, "state", "storage"),
+	}
+}
+
+func (s *snapshotSuite) writeFile(c *gc.C, path, data string) {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(path, []byte(data), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *snapshotSuite) writeLocalState(c *gc.C) {
+	s.writeFile(c, s.paths.OperationsFile, "op: continue\n")
+	s.writeFile(c, filepath.Join(s.paths.RelationsDir, "0", "mysql-0"), "change-version: 1\n")
+	s.writeFile(c, filepath.Join(s.paths.RelationsDir, "0", "mysql-1.preparing"), "partial")
+	err := os.MkdirAll(filepath.Join(s.paths.RelationsDir, "1"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	s.writeFile(c, filepath.Join(s.paths.StorageDir, "data-0"), "attached: true\n")
+}
+
+var recordedState = params.UniterState{
+	OperationState: "op: continue\n",
+	RelationState: map[string]string{
+		"0/":        "",
+		"0/mysql-0": "change-version: 1\n",
+		"1/":        "",
+	},
+	StorageState: map[string]string{
+		"data-0": "attached: true\n",
+	},
+}
+
+func (s *snapshotSuite) TestReadLocalStateNone(c *gc.C) {
+	uniterState, err := uniter.ReadLocalState(s.paths)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(uniterState, gc.IsNil)
+}
+
+func (s *snapshotSuite) TestReadLocalState(c *gc.C) {
+	s.writeLocalState(c)
+	uniterState, err := uniter.ReadLocalState(s.paths)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(uniterState, jc.DeepEquals, &recordedState)
+}
+
+func (s *snapshotSuite) TestWriteLocalState(c *gc.C) {
+	err := uniter.WriteLocalState(s.paths, recordedState)
+	c.Assert(err, jc.ErrorIsNil)
+	uniterState, err := uniter.ReadLocalState(s.paths)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(uniterState, jc.DeepEquals, &recordedState)
+}
+
+func (s *snapshotSuite) TestWriteLocalStateInvalidPath(c *gc.C) {
+	err := uniter.WriteLocalState(s.paths, params.UniterState{
+		OperationState: "op: continue\n",
+		StorageState:   map[string]string{"../uniter": "op: install\n"},
+	})
+	c.Assert(err, gc.ErrorMatches, `writing storage state: state file path "../uniter" not valid`)
+}
+
+func (s *snapshotSuite) TestRestoreLocalState(c *gc.C) {
+	recorder := &mockRecorder{uniterState: recordedState}
+	restored, err := uniter.RestoreLocalState(recorder, s.paths)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(restored, jc.IsTrue)
+	recorder.CheckCallNames(c, "UniterState")
+
+	uniterState, err := uniter.ReadLocalState(s.paths)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(uniterState, jc.DeepEquals, &recordedState)
+}
+
+func (s *snapshotSuite) TestRestoreLocalStateExisting(c *gc.C) {
+	s.writeFile(c, s.paths.OperationsFile, "op: install\n")
+	recorder := &mockRecorder{uniterState: recordedState}
+	restored, err := uniter.RestoreLocalState(recorder, s.paths)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(restored, jc.IsFalse)
+	recorder.CheckNoCalls(c)
+}
+
+func (s *snapshotSuite) TestRestoreLocalStateNotRecorded(c *gc.C) {
+	recorder := &mockRecorder{}
+	recorder.SetErrors(&params.Error{Code: params.CodeNotFound})
+	restored, err := uniter.RestoreLocalState(recorder, s.paths)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(restored, jc.IsFalse)
+	_, err = os.Stat(s.paths.OperationsFile)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *snapshotSuite) TestSnapshot(c *gc.C) {
+	recorder := &mockRecorder{}
+	snapshot := uniter.NewStateSnapshotter(recorder, s.paths)
+
+	// Nothing is recorded until there is local state.
+	err := snapshot()
+	c.Assert(err, jc.ErrorIsNil)
+	recorder.CheckNoCalls(c)
+
+	s.writeLocalState(c)
+	err = snapshot()
+	c.Assert(err, jc.ErrorIsNil)
+	recorder.CheckCalls(c, []testing.StubCall{
+		{"SetUniterState", []interface{}{recordedState}},
+	})
+
+	// Unchanged state is not recorded again.
+	err = snapshot()
+	c.Assert(err, jc.ErrorIsNil)
+	recorder.CheckCallNames(c, "SetUniterState")
+
+	s.writeFile(c, s.paths.OperationsFile, "op: run-hook\n")
+	err = snapshot()
+	c.Assert(err, jc.ErrorIsNil)
+	recorder.CheckCallNames(c, "SetUniterState", "SetUniterState")
+}
+
+func (s *snapshotSuite) TestSnapshotNotImplemented(c *gc.C) {
+	s.writeLocalState(c)
+	recorder := &mockRecorder{}
+	recorder.SetErrors(errors.NotImplementedf("SetUniterState"))
+	snapshot := uniter.NewStateSnapshotter(recorder, s.paths)
+	err := snapshot()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.writeFile(c, s.paths.OperationsFile, "op: run-hook\n")
+	err = snapshot()
+	c.Assert(err, jc.ErrorIsNil)
+	recorder.CheckCallNames(c, "SetUniterState")
+}
+
+func (s *snapshotSuite) TestSnapshotError(c *gc.C) {
+	s.writeLocalState(c)
+	recorder := &mockRecorder{}
+	recorder.SetErrors(errors.New("boom"))
+	snapshot := uniter.NewStateSnapshotter(recorder, s.paths)
+	err := snapshot()
+	c.Assert(err, gc.ErrorMatches, "recording uniter state: boom")
+}
+
+func (s *snapshotSuite) TestSnapshotExecutor(c *gc.C) {
+	var snapshots int
+	snapshot := func() error {
+		snapshots++
+		return nil
+	}
+	executor := uniter.NewSnapshotExecutor(&mockExecutor{}, snapshot)
+
+	// The state is recorded even if the operation fails,
+	// and the operation's error is returned.
+	err := executor.Run(mockOperation{"run hook"})
+	c.Assert(err, gc.Equals, mockExecutorErr)
+	c.Assert(snapshots, gc.Equals, 1)
+}
+
+func (s *snapshotSuite) TestSnapshotExecutorSnapshotError(c *gc.C) {
+	snapshot := func() error {
+		return errors.New("boom")
+	}
+	executor := uniter.NewSnapshotExecutor(&fakeExecutor{}, snapshot)
+	err := executor.Skip(mockOperation{"run hook"})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockRecorder struct {
+	testing.Stub
+	uniterState params.UniterState
+}
+
+func (r *mockRecorder) UniterState() (params.UniterState, error) {
+	r.MethodCall(r, "UniterState")
+	return r.uniterState, r.NextErr()
+}
+
+func (r *mockRecorder) SetUniterState(uniterState params.UniterState) error {
+	r.MethodCall(r, "SetUniterState", uniterState)
+	return r.NextErr()
+}
+
+type mockOperation struct {
+	operation.Operation
+	name string
+}
+
+func (op mockOperation) String() string {
+	return op.name
+}
+
+type fakeExecutor struct {
+	operation.Executor
+}
+
+func (*fakeExecutor) Skip(operation.Operation) error {
+	return nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/utils"
 	"github.com/juju/utils/clock"
 	"github.com/juju/utils/exec"
+	"github.com/prometheus/client_golang/prometheus"
 	corecharm "gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/juju/worker.v1"
@@ -26,9 +27,11 @@ import (
 	"github.com/juju/juju/status"
 	jworker "github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/catacomb"
+	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/uniter/actions"
 	"github.com/juju/juju/worker/uniter/charm"
+	"github.com/juju/juju/worker/uniter/healthcheck"
 	"github.com/juju/juju/worker/uniter/hook"
 	uniterleadership "github.com/juju/juju/worker/uniter/leadership"
 	"github.com/juju/juju/worker/uniter/operation"
@@ -102,6 +105,18 @@ type Uniter struct {
 	// downloader is the downloader that should be used to get the charm
 	// archive.
 	downloader charm.Downloader
+
+	// relationCoalescePeriod is the minimum time between deliveries
+	// of changes to the units of each relation.
+	relationCoalescePeriod time.Duration
+
+	// prometheusRegisterer, if not nil, is used to register the
+	// uniter's metrics collectors.
+	prometheusRegisterer prometheus.Registerer
+
+	// hookOutputLimits bounds the output of hooks, actions and hook
+	// tools that is retained in memory.
+	hookOutputLimits charmrunner.OutputLimits
 }
 
 // UniterParams hold all the necessary parameters for a new Uniter.
@@ -118,6 +133,18 @@ type UniterParams struct {
 	NewOperationExecutor NewExecutorFunc
 	TranslateResolverErr func(error) error
 	Clock                clock.Clock
+	// RelationCoalescePeriod is the minimum time between deliveries
+	// of changes to the units of each relation; changes that arrive
+	// in the meantime are coalesced, bounding the rate at which
+	// relation-changed hooks are run.
+	RelationCoalescePeriod time.Duration
+	// PrometheusRegisterer, if not nil, is used to register the
+	// uniter's metrics collectors.
+	PrometheusRegisterer prometheus.Registerer
+	// HookOutputLimits bounds the output of hooks, actions and hook
+	// tools that is retained in memory; the rest is spooled to files
+	// in the unit's state directory unless another is specified.
+	HookOutputLimits charmrunner.OutputLimits
 	// TODO (mattyw, wallyworld, fwereade) Having the observer here make this approach a bit more legitimate, but it isn't.
 	// the observer is only a stop gap to be used in tests. A better approach would be to have the uniter tests start hooks
 	// that write to files, and have the tests watch the output to know that hooks have finished.
@@ -148,6 +175,13 @@ func NewUniter(uniterParams *UniterParams) (*Uniter, error) {
 		observer:             uniterParams.Observer,
 		clock:                uniterParams.Clock,
 		downloader:           uniterParams.Downloader,
+
+		relationCoalescePeriod: uniterParams.RelationCoalescePeriod,
+		prometheusRegisterer:   uniterParams.PrometheusRegisterer,
+		hookOutputLimits:       uniterParams.HookOutputLimits,
+	}
+	if u.hookOutputLimits.SpoolDir == "" {
+		u.hookOutputLimits.SpoolDir = u.paths.State.HookOutputDir
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &u.catacomb,
@@ -167,6 +201,15 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 	}
 	logger.Infof("unit %q started", u.unit)
 
+	relationMetrics := newRelationMetrics(unitTag)
+	if u.prometheusRegisterer != nil {
+		if err := u.prometheusRegisterer.Register(relationMetrics); err != nil {
+			logger.Warningf("cannot register relation metrics: %v", err)
+		} else {
+			defer u.prometheusRegisterer.Unregister(relationMetrics)
+		}
+	}
+
 	// Install is a special case, as it must run before there
 	// is any remote state, and before the remote state watcher
 	// is started.
@@ -204,6 +247,18 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 		watcherMu sync.Mutex
 	)
 
+	// The health checker reloads the charm's declarations each time
+	// it runs, so it follows changes made by charm upgrades.
+	healthChecker, err := healthcheck.NewChecker(healthcheck.Config{
+		CharmDir: u.paths.State.CharmDir,
+		Clock:    u.clock,
+		Reporter: healthcheck.NewStatusReporter(u.unit),
+		RunCheck: healthcheck.RunCheck,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	logger.Infof("hooks are retried %v", u.hookRetryStrategy.ShouldRetry)
 	retryHookChan := make(chan struct{}, 1)
 	// TODO(katco): 2016-08-09: This type is deprecated: lp:1611427
@@ -246,6 +301,11 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 				UpdateStatusChannel: u.updateStatusAt,
 				CommandChannel:      u.commandChannel,
 				RetryHookChannel:    retryHookChan,
+				Clock:               u.clock,
+
+				RelationCoalescePeriod: u.relationCoalescePeriod,
+				RelationMetrics:        relationMetrics,
+				HealthCheckInterval:    healthcheck.Interval,
 			})
 		if err != nil {
 			return errors.Trace(err)
@@ -295,6 +355,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 			Commands: runcommands.NewCommandsResolver(
 				u.commands, watcher.CommandCompleted,
 			),
+			HealthChecks: healthcheck.NewResolver(healthChecker),
 		})
 
 		// We should not do anything until there has been a change
@@ -397,6 +458,24 @@ func (u *Uniter) terminate() error {
 	}
 }
 
+// redeployCharm deploys the unit's current charm without running any
+// hooks. It is used when the uniter's state has been restored on a
+// re-provisioned machine, where the charm directory has been lost.
+func (u *Uniter) redeployCharm(deployer charm.Deployer) error {
+	curl, err := u.unit.CharmURL()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	info, err := u.st.Charm(curl)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := deployer.Stage(info, u.catacomb.Dying()); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(deployer.Deploy())
+}
+
 func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 	u.unit, err = u.st.Unit(unitTag)
 	if err != nil {
@@ -427,6 +506,16 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 	if err := tools.EnsureSymlinks(u.paths.ToolsDir, u.paths.ToolsDir, jujuc.CommandNames()); err != nil {
 		return err
 	}
+	// If the unit has no local state, but has recorded its state with
+	// the controller, then the machine has been re-provisioned after
+	// losing its disk; resume from the recorded state.
+	restored, err := restoreLocalState(u.unit, u.paths.State)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if restored {
+		logger.Infof("restored uniter state for unit %q", u.unit)
+	}
 	if err := os.MkdirAll(u.paths.State.RelationsDir, 0755); err != nil {
 		return errors.Trace(err)
 	}
@@ -465,6 +554,11 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 	if err != nil {
 		return errors.Annotatef(err, "cannot create deployer")
 	}
+	if restored {
+		if err := u.redeployCharm(deployer); err != nil {
+			return errors.Annotatef(err, "cannot redeploy charm")
+		}
+	}
 	contextFactory, err := context.NewContextFactory(context.FactoryConfig{
 		State:            u.st,
 		UnitTag:          unitTag,
@@ -478,7 +572,7 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 		return err
 	}
 	runnerFactory, err := runner.NewFactory(
-		u.st, u.paths, contextFactory,
+		u.st, u.paths, contextFactory, u.hookOutputLimits,
 	)
 	if err != nil {
 		return errors.Trace(err)
@@ -495,7 +589,17 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	u.operationExecutor = operationExecutor
+	snapshotter := &stateSnapshotter{
+		recorder: u.unit,
+		paths:    u.paths.State,
+	}
+	if err := snapshotter.snapshot(); err != nil {
+		return errors.Trace(err)
+	}
+	u.operationExecutor = &snapshotExecutor{
+		Executor: operationExecutor,
+		snapshot: snapshotter.snapshot,
+	}
 
 	logger.Debugf("starting juju-run listener on unix:%s", u.paths.Runtime.JujuRunSocket)
 	commandRunner, err := NewChannelCommandRunner(ChannelCommandRunnerConfig{
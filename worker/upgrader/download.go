@@ -0,0 +1,160 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgrader
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+
+	coretools "github.com/juju/juju/tools"
+)
+
+// partialPrefix is the prefix of the names of the files, in the data
+// directory's tools directory, to which agent binary tarballs are
+// downloaded.
+const partialPrefix = "downloading-"
+
+// partialPath returns the path of the file to which the agent binary
+// tarball with the given tools is downloaded.
+func partialPath(dataDir string, agentTools *coretools.Tools) string {
+	return filepath.Join(dataDir, "tools", partialPrefix+agentTools.Version.String()+".tgz")
+}
+
+// downloadTools downloads the agent binary tarball described by the
+// given tools, verifying its size and SHA-256 hash, and returns it
+// open for reading from the start. The tarball is downloaded to a file
+// in the data directory that is kept if the download is interrupted,
+// and the next download of the same tarball resumes from where the
+// interrupted one stopped. The caller is responsible for closing the
+// returned file and, once it has been unpacked, removing it.
+func downloadTools(client *http.Client, dataDir string, agentTools *coretools.Tools) (*os.File, error) {
+	path := partialPath(dataDir, agentTools)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, errors.Trace(err)
+	}
+	removeOtherPartials(path)
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := download(client, f, agentTools); err != nil {
+		f.Close()
+		return nil, errors.Trace(err)
+	}
+	if err := verify(f, agentTools); err != nil {
+		// The downloaded tarball is not the one expected, so there
+		// is nothing worth resuming.
+		f.Close()
+		os.Remove(path)
+		return nil, errors.Trace(err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		f.Close()
+		return nil, errors.Trace(err)
+	}
+	return f, nil
+}
+
+// download writes the tarball to the given file, requesting only the
+// part of it that the file does not already hold.
+func download(client *http.Client, f *os.File, agentTools *coretools.Tools) error {
+	info, err := f.Stat()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	offset := info.Size()
+	if offset == agentTools.Size {
+		return nil
+	}
+	if offset > agentTools.Size {
+		offset = 0
+	}
+
+	req, err := http.NewRequest("GET", agentTools.URL, nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if offset > 0 {
+		logger.Infof("resuming download of agent binaries %s from byte %d", agentTools.Version, offset)
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		// The tarball's hash is its entity tag, so the API server
+		// sends all of it if the partial file holds part of some
+		// other tarball.
+		req.Header.Set("If-Range", fmt.Sprintf("%q", agentTools.SHA256))
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// The server sent the whole tarball; start again.
+		offset = 0
+	case http.StatusPartialContent:
+		expected := fmt.Sprintf("bytes %d-", offset)
+		if contentRange := resp.Header.Get("Content-Range"); !strings.HasPrefix(contentRange, expected) {
+			return errors.Errorf("unexpected content range %q", contentRange)
+		}
+	default:
+		return errors.Errorf("bad HTTP response: %v", resp.Status)
+	}
+	if err := f.Truncate(offset); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return errors.Trace(err)
+	}
+	// Whatever is received before any error is kept, so that the
+	// next attempt can resume from there.
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		return errors.Annotate(err, "download interrupted")
+	}
+	return nil
+}
+
+// verify checks the size and SHA-256 hash of the downloaded tarball.
+func verify(f *os.File, agentTools *coretools.Tools) error {
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return errors.Trace(err)
+	}
+	hash := sha256.New()
+	size, err := io.Copy(hash, f)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if size != agentTools.Size {
+		return errors.Errorf("size mismatch, expected %d, got %d", agentTools.Size, size)
+	}
+	if sha := fmt.Sprintf("%x", hash.Sum(nil)); sha != agentTools.SHA256 {
+		return errors.Errorf("sha256 mismatch, expected %s, got %s", agentTools.SHA256, sha)
+	}
+	return nil
+}
+
+// removeOtherPartials removes the partial downloads of tarballs other
+// than the one being downloaded to the given path; the agent will not
+// resume them.
+func removeOtherPartials(path string) {
+	others, err := filepath.Glob(filepath.Join(filepath.Dir(path), partialPrefix+"*"))
+	if err != nil {
+		return
+	}
+	for _, other := range others {
+		if other == path {
+			continue
+		}
+		if err := os.Remove(other); err != nil {
+			logger.Warningf("cannot remove partial download %q: %v", other, err)
+		}
+	}
+}
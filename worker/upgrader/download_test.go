@@ -0,0 +1,164 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgrader_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	coretools "github.com/juju/juju/tools"
+	"github.com/juju/juju/worker/upgrader"
+)
+
+type DownloadSuite struct {
+	testing.IsolationSuite
+
+	dataDir  string
+	server   *httptest.Server
+	content  string
+	requests []*http.Request
+
+	// ignoreRange causes the server to send the whole tarball
+	// whatever range is requested.
+	ignoreRange bool
+
+	// truncate causes the server to stop sending after the given
+	// number of bytes.
+	truncate int
+}
+
+var _ = gc.Suite(&DownloadSuite{})
+
+// abcdefSHA256 is the SHA-256 hash of "abcdef".
+const abcdefSHA256 = "bef57ec7f53a6d40beb640a780a639c83bc29ac8a9816f1fc6c5c6dcd93c4721"
+
+func (s *DownloadSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dataDir = c.MkDir()
+	s.content = "abcdef"
+	s.requests = nil
+	s.ignoreRange = false
+	s.truncate = 0
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveTools))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+}
+
+func (s *DownloadSuite) serveTools(w http.ResponseWriter, r *http.Request) {
+	s.requests = append(s.requests, r)
+	content := s.content
+	var offset int
+	if _, err := fmt.Sscanf(r.Header.Get("Range"), "bytes=%d-", &offset); err == nil && !s.ignoreRange {
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, len(content)-1, len(content)))
+		w.Header().Set("Content-Length", fmt.Sprint(len(content)-offset))
+		w.WriteHeader(http.StatusPartialContent)
+		content = content[offset:]
+	} else {
+		w.Header().Set("Content-Length", fmt.Sprint(len(content)))
+	}
+	if s.truncate > 0 {
+		content = content[:s.truncate]
+	}
+	w.Write([]byte(content))
+}
+
+func (s *DownloadSuite) tools() *coretools.Tools {
+	return &coretools.Tools{
+		Version: version.MustParseBinary("2.4.1-xenial-amd64"),
+		URL:     s.server.URL + "/tools/2.4.1-xenial-amd64",
+		Size:    6,
+		SHA256:  abcdefSHA256,
+	}
+}
+
+func (s *DownloadSuite) writePartial(c *gc.C, content string) {
+	path := upgrader.PartialPath(s.dataDir, s.tools())
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(path, []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *DownloadSuite) assertDownloaded(c *gc.C) {
+	f, err := upgrader.DownloadTools(http.DefaultClient, s.dataDir, s.tools())
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	data, err := ioutil.ReadAll(f)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "abcdef")
+}
+
+func (s *DownloadSuite) TestDownload(c *gc.C) {
+	s.assertDownloaded(c)
+	c.Assert(s.requests, gc.HasLen, 1)
+	c.Assert(s.requests[0].Header.Get("Range"), gc.Equals, "")
+}
+
+func (s *DownloadSuite) TestResume(c *gc.C) {
+	s.writePartial(c, "abc")
+	s.assertDownloaded(c)
+	c.Assert(s.requests, gc.HasLen, 1)
+	c.Assert(s.requests[0].Header.Get("Range"), gc.Equals, "bytes=3-")
+	c.Assert(s.requests[0].Header.Get("If-Range"), gc.Equals, `"`+abcdefSHA256+`"`)
+}
+
+func (s *DownloadSuite) TestResumeRangeIgnored(c *gc.C) {
+	s.writePartial(c, "abc")
+	s.ignoreRange = true
+	s.assertDownloaded(c)
+}
+
+func (s *DownloadSuite) TestAlreadyDownloaded(c *gc.C) {
+	s.writePartial(c, "abcdef")
+	s.assertDownloaded(c)
+	c.Assert(s.requests, gc.HasLen, 0)
+}
+
+func (s *DownloadSuite) TestInterruptedDownloadResumes(c *gc.C) {
+	s.truncate = 4
+	_, err := upgrader.DownloadTools(http.DefaultClient, s.dataDir, s.tools())
+	c.Assert(err, gc.ErrorMatches, "download interrupted: .*")
+	data, err := ioutil.ReadFile(upgrader.PartialPath(s.dataDir, s.tools()))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "abcd")
+
+	s.truncate = 0
+	s.assertDownloaded(c)
+	c.Assert(s.requests, gc.HasLen, 2)
+	c.Assert(s.requests[1].Header.Get("Range"), gc.Equals, "bytes=4-")
+}
+
+func (s *DownloadSuite) TestHashMismatch(c *gc.C) {
+	s.content = "abcxyz"
+	_, err := upgrader.DownloadTools(http.DefaultClient, s.dataDir, s.tools())
+	c.Assert(err, gc.ErrorMatches, "sha256 mismatch, expected "+abcdefSHA256+", got .*")
+	_, err = os.Stat(upgrader.PartialPath(s.dataDir, s.tools()))
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *DownloadSuite) TestBadResponse(c *gc.C) {
+	s.server.Config.Handler = http.NotFoundHandler()
+	_, err := upgrader.DownloadTools(http.DefaultClient, s.dataDir, s.tools())
+	c.Assert(err, gc.ErrorMatches, "bad HTTP response: 404 Not Found")
+}
+
+func (s *DownloadSuite) TestRemovesOtherPartials(c *gc.C) {
+	other := filepath.Join(s.dataDir, "tools", "downloading-2.4.0-xenial-amd64.tgz")
+	err := os.MkdirAll(filepath.Dir(other), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(other, []byte("old"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertDownloaded(c)
+	_, err = os.Stat(other)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
@@ -6,4 +6,6 @@ package upgrader
 var (
 	RetryAfter           = &retryAfter
 	AllowedTargetVersion = allowedTargetVersion
+	DownloadTools        = downloadTools
+	PartialPath          = partialPath
 )
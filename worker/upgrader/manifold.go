@@ -23,6 +23,12 @@ type ManifoldConfig struct {
 	UpgradeStepsGateName string
 	UpgradeCheckGateName string
 	PreviousAgentVersion version.Number
+
+	// APIServerDrain, if set, is unlocked when the agent is about
+	// to restart into a new version, so that the agent's API server
+	// can drain its connections first. Only controller machine
+	// agents need to set it.
+	APIServerDrain gate.Unlocker
 }
 
 // Manifold returns a dependency manifold that runs an upgrader
@@ -77,12 +83,18 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				}
 			}
 
+			apiServerDrain := config.APIServerDrain
+			if apiServerDrain == nil {
+				apiServerDrain = gate.NewLock()
+			}
+
 			return NewAgentUpgrader(
 				upgraderFacade,
 				currentConfig,
 				config.PreviousAgentVersion,
 				upgradeStepsWaiter,
 				initialCheckUnlocker,
+				apiServerDrain,
 			)
 		},
 	}
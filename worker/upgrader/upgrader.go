@@ -5,7 +5,7 @@ package upgrader
 
 import (
 	"fmt"
-	"net/http"
+	"os"
 	"time"
 
 	"github.com/juju/errors"
@@ -32,6 +32,13 @@ var retryAfter = func() <-chan time.Time {
 	return time.After(5 * time.Second)
 }
 
+// deferredRetryAfter returns a channel that receives a value when an
+// upgrade deferred until the model's next maintenance window should be
+// checked again.
+var deferredRetryAfter = func() <-chan time.Time {
+	return time.After(5 * time.Minute)
+}
+
 var logger = loggo.GetLogger("juju.worker.upgrader")
 
 // Upgrader represents a worker that watches the state for upgrade
@@ -44,6 +51,7 @@ type Upgrader struct {
 	origAgentVersion            version.Number
 	upgradeStepsWaiter          gate.Waiter
 	initialUpgradeCheckComplete gate.Unlocker
+	apiServerDrain              gate.Unlocker
 }
 
 // NewAgentUpgrader returns a new upgrader worker. It watches changes to the
@@ -52,12 +60,17 @@ type Upgrader struct {
 // an upgrade is needed, the worker will exit with an UpgradeReadyError
 // holding details of the requested upgrade. The tools will have been
 // downloaded and unpacked.
+//
+// Before exiting with an UpgradeReadyError, the worker unlocks
+// apiServerDrain, so that an API server run by the same agent can
+// drain its connections before the agent restarts.
 func NewAgentUpgrader(
 	st *upgrader.State,
 	agentConfig agent.Config,
 	origAgentVersion version.Number,
 	upgradeStepsWaiter gate.Waiter,
 	initialUpgradeCheckComplete gate.Unlocker,
+	apiServerDrain gate.Unlocker,
 ) (*Upgrader, error) {
 	u := &Upgrader{
 		st:                          st,
@@ -66,6 +79,7 @@ func NewAgentUpgrader(
 		origAgentVersion:            origAgentVersion,
 		upgradeStepsWaiter:          upgradeStepsWaiter,
 		initialUpgradeCheckComplete: initialUpgradeCheckComplete,
+		apiServerDrain:              apiServerDrain,
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &u.catacomb,
@@ -172,7 +186,15 @@ func (u *Upgrader) loop() error {
 		}
 
 		wantVersion, err := u.st.DesiredVersion(u.tag.String())
-		if err != nil {
+		if err == upgrader.ErrUpgradeDeferred {
+			// The controller reports the new version once the
+			// model is in a maintenance window, which no version
+			// event announces, so check again later.
+			logger.Infof("%v", err)
+			u.initialUpgradeCheckComplete.Unlock()
+			retry = deferredRetryAfter()
+			continue
+		} else if err != nil {
 			return err
 		}
 		logger.Infof("desired agent binary version: %v", wantVersion)
@@ -201,7 +223,7 @@ func (u *Upgrader) loop() error {
 		// Check if tools have already been downloaded.
 		wantVersionBinary := toBinaryVersion(wantVersion)
 		if u.toolsAlreadyDownloaded(wantVersionBinary) {
-			return u.newUpgradeReadyError(wantVersionBinary)
+			return u.upgradeReady(wantVersionBinary)
 		}
 
 		// Check if tools are available for download.
@@ -218,7 +240,7 @@ func (u *Upgrader) loop() error {
 		for _, wantTools := range wantToolsList {
 			err = u.ensureTools(wantTools)
 			if err == nil {
-				return u.newUpgradeReadyError(wantTools.Version)
+				return u.upgradeReady(wantTools.Version)
 			}
 			logger.Errorf("failed to fetch agent binaries from %q: %v", wantTools.URL, err)
 		}
@@ -240,6 +262,14 @@ func (u *Upgrader) toolsAlreadyDownloaded(wantVersion version.Binary) bool {
 	return err == nil
 }
 
+// upgradeReady requests that the agent's API server, if any, drains
+// its connections, and returns the error that causes the agent to
+// restart into the new version.
+func (u *Upgrader) upgradeReady(newVersion version.Binary) error {
+	u.apiServerDrain.Unlock()
+	return u.newUpgradeReadyError(newVersion)
+}
+
 func (u *Upgrader) newUpgradeReadyError(newVersion version.Binary) *UpgradeReadyError {
 	return &UpgradeReadyError{
 		OldTools:  toBinaryVersion(jujuversion.Current),
@@ -251,20 +281,20 @@ func (u *Upgrader) newUpgradeReadyError(newVersion version.Binary) *UpgradeReady
 
 func (u *Upgrader) ensureTools(agentTools *coretools.Tools) error {
 	logger.Infof("fetching agent binaries from %q", agentTools.URL)
-	// The reader MUST verify the tools' hash, so there is no
+	// The tarball's size and hash are verified, so there is no
 	// need to validate the peer. We cannot anyway: see http://pad.lv/1261780.
-	resp, err := utils.GetNonValidatingHTTPClient().Get(agentTools.URL)
+	f, err := downloadTools(utils.GetNonValidatingHTTPClient(), u.dataDir, agentTools)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("bad HTTP response: %v", resp.Status)
-	}
-	err = agenttools.UnpackTools(u.dataDir, agentTools, resp.Body)
+	defer f.Close()
+	err = agenttools.UnpackTools(u.dataDir, agentTools, f)
 	if err != nil {
 		return fmt.Errorf("cannot unpack agent binaries: %v", err)
 	}
+	if err := os.Remove(f.Name()); err != nil {
+		logger.Warningf("cannot remove downloaded agent binaries: %v", err)
+	}
 	logger.Infof("unpacked agent binaries %s to %s", agentTools.Version, u.dataDir)
 	return nil
 }
@@ -48,6 +48,7 @@ type UpgraderSuite struct {
 	confVersion          version.Number
 	upgradeStepsComplete gate.Lock
 	initialCheckComplete gate.Lock
+	apiServerDrain       gate.Lock
 }
 
 type AllowedTargetVersionSuite struct{}
@@ -68,6 +69,7 @@ func (s *UpgraderSuite) SetUpTest(c *gc.C) {
 	})
 	s.upgradeStepsComplete = gate.NewLock()
 	s.initialCheckComplete = gate.NewLock()
+	s.apiServerDrain = gate.NewLock()
 }
 
 func (s *UpgraderSuite) patchVersion(v version.Binary) {
@@ -105,6 +107,7 @@ func (s *UpgraderSuite) makeUpgrader(c *gc.C) *upgrader.Upgrader {
 		s.confVersion,
 		s.upgradeStepsComplete,
 		s.initialCheckComplete,
+		s.apiServerDrain,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	return w
@@ -177,6 +180,7 @@ func (s *UpgraderSuite) TestUpgraderUpgradesImmediately(c *gc.C) {
 		NewTools:  newTools.Version,
 		DataDir:   s.DataDir(),
 	})
+	c.Assert(s.apiServerDrain.IsUnlocked(), jc.IsTrue)
 	foundTools, err := agenttools.ReadTools(s.DataDir(), newTools.Version)
 	c.Assert(err, jc.ErrorIsNil)
 	newTools.URL = fmt.Sprintf("https://%s/model/%s/tools/5.4.5-precise-amd64",
@@ -303,6 +307,7 @@ func (s *UpgraderSuite) TestUpgraderRefusesToDowngradeMinorVersions(c *gc.C) {
 	// If the upgrade would have triggered, we would have gotten an
 	// UpgradeReadyError, since it was skipped, we get no error
 	c.Check(err, jc.ErrorIsNil)
+	c.Check(s.apiServerDrain.IsUnlocked(), jc.IsFalse)
 	_, err = agenttools.ReadTools(s.DataDir(), downgradeTools.Version)
 	// TODO: ReadTools *should* be returning some form of errors.NotFound,
 	// however, it just passes back a fmt.Errorf so we live with it
@@ -0,0 +1,13 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package utilizationreporter
+
+// NewCgroupSampler returns a Sampler that reads unit agent directories
+// from agentsDir and cgroups from cgroupRoot.
+func NewCgroupSampler(agentsDir, cgroupRoot string) Sampler {
+	return &cgroupSampler{
+		agentsDir:  agentsDir,
+		cgroupRoot: cgroupRoot,
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package utilizationreporter
+
+import (
+	"bufio"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/agent"
+)
+
+const defaultCgroupRoot = "/sys/fs/cgroup"
+
+// NewSampler returns a Sampler for the units deployed to the machine
+// whose agent uses the given data directory. The CPU and memory used by
+// each unit are read from the cgroup of its agent's systemd service,
+// which holds the processes started by the unit's hooks; where the
+// cgroup cannot be found, they are reported as zero. The disk space
+// used is that of the unit's agent directory.
+func NewSampler(dataDir string) Sampler {
+	return &cgroupSampler{
+		agentsDir:  agent.BaseDir(dataDir),
+		cgroupRoot: defaultCgroupRoot,
+	}
+}
+
+type cgroupSampler struct {
+	agentsDir  string
+	cgroupRoot string
+}
+
+// Sample is part of the Sampler interface.
+func (s *cgroupSampler) Sample() (map[string]Usage, error) {
+	entries, err := ioutil.ReadDir(s.agentsDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]Usage)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		tag, err := names.ParseUnitTag(entry.Name())
+		if err != nil {
+			continue
+		}
+		usage, err := s.sampleUnit(tag, filepath.Join(s.agentsDir, entry.Name()))
+		if err != nil {
+			return nil, errors.Annotatef(err, "sampling %s", names.ReadableString(tag))
+		}
+		result[tag.Id()] = usage
+	}
+	return result, nil
+}
+
+func (s *cgroupSampler) sampleUnit(tag names.UnitTag, agentDir string) (Usage, error) {
+	service := "jujud-" + tag.String() + ".service"
+	cpuTime, err := s.cpuTime(service)
+	if err != nil {
+		return Usage{}, errors.Trace(err)
+	}
+	memory, err := s.memory(service)
+	if err != nil {
+		return Usage{}, errors.Trace(err)
+	}
+	disk, err := diskUsage(agentDir)
+	if err != nil {
+		return Usage{}, errors.Trace(err)
+	}
+	return Usage{
+		CPUTime:     cpuTime,
+		MemoryBytes: memory,
+		DiskBytes:   disk,
+	}, nil
+}
+
+// cpuTime returns the CPU time used by the processes in the service's
+// cgroup, from either the cgroup v1 cpuacct controller or the cgroup
+// v2 unified hierarchy.
+func (s *cgroupSampler) cpuTime(service string) (time.Duration, error) {
+	nanoseconds, err := readInt(filepath.Join(
+		s.cgroupRoot, "cpu,cpuacct", "system.slice", service, "cpuacct.usage",
+	))
+	if err == nil {
+		return time.Duration(nanoseconds), nil
+	} else if !os.IsNotExist(errors.Cause(err)) {
+		return 0, errors.Trace(err)
+	}
+	microseconds, err := readStat(filepath.Join(
+		s.cgroupRoot, "system.slice", service, "cpu.stat",
+	), "usage_usec")
+	if os.IsNotExist(errors.Cause(err)) {
+		return 0, nil
+	} else if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return time.Duration(microseconds) * time.Microsecond, nil
+}
+
+// memory returns the memory used by the processes in the service's
+// cgroup, from either the cgroup v1 memory controller or the cgroup v2
+// unified hierarchy.
+func (s *cgroupSampler) memory(service string) (int64, error) {
+	for _, path := range []string{
+		filepath.Join(s.cgroupRoot, "memory", "system.slice", service, "memory.usage_in_bytes"),
+		filepath.Join(s.cgroupRoot, "system.slice", service, "memory.current"),
+	} {
+		bytes, err := readInt(path)
+		if os.IsNotExist(errors.Cause(err)) {
+			continue
+		}
+		return bytes, errors.Trace(err)
+	}
+	return 0, nil
+}
+
+// readInt returns the integer held in the file at the given path.
+func readInt(path string) (int64, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	value, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, errors.Annotatef(err, "reading %s", path)
+	}
+	return value, nil
+}
+
+// readStat returns the value of the named entry in the flat-keyed
+// cgroup statistics file at the given path.
+func readStat(path, key string) (int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || fields[0] != key {
+			continue
+		}
+		value, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return 0, errors.Annotatef(err, "reading %s %s", path, key)
+		}
+		return value, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return 0, errors.Errorf("%s has no %s", path, key)
+}
+
+// diskUsage returns the total size of the regular files under dir.
+// Files removed while they are being counted are ignored.
+func diskUsage(dir string) (int64, error) {
+	var total int64
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if os.IsNotExist(err) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			total += info.Size()
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return total, nil
+}
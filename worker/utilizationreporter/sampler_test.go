@@ -0,0 +1,104 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package utilizationreporter_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/utilizationreporter"
+)
+
+type SamplerSuite struct {
+	testing.IsolationSuite
+	agentsDir  string
+	cgroupRoot string
+}
+
+var _ = gc.Suite(&SamplerSuite{})
+
+func (s *SamplerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	dir := c.MkDir()
+	s.agentsDir = filepath.Join(dir, "agents")
+	s.cgroupRoot = filepath.Join(dir, "cgroup")
+}
+
+func (s *SamplerSuite) TestNoAgentsDir(c *gc.C) {
+	usage, err := s.sample(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage, gc.HasLen, 0)
+}
+
+func (s *SamplerSuite) TestCgroupV1(c *gc.C) {
+	s.writeFile(c, s.agentsDir, "machine-0/agent.conf", "machine")
+	s.writeFile(c, s.agentsDir, "unit-mysql-0/agent.conf", "12345")
+	s.writeFile(c, s.agentsDir, "unit-mysql-0/charm/metadata.yaml", "123")
+	service := "system.slice/jujud-unit-mysql-0.service"
+	s.writeFile(c, s.cgroupRoot, "cpu,cpuacct/"+service+"/cpuacct.usage", "2500000000\n")
+	s.writeFile(c, s.cgroupRoot, "memory/"+service+"/memory.usage_in_bytes", "4096\n")
+
+	usage, err := s.sample(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage, jc.DeepEquals, map[string]utilizationreporter.Usage{
+		"mysql/0": {
+			CPUTime:     2500 * time.Millisecond,
+			MemoryBytes: 4096,
+			DiskBytes:   8,
+		},
+	})
+}
+
+func (s *SamplerSuite) TestCgroupV2(c *gc.C) {
+	s.writeFile(c, s.agentsDir, "unit-mysql-0/agent.conf", "1234")
+	service := "system.slice/jujud-unit-mysql-0.service"
+	s.writeFile(c, s.cgroupRoot, service+"/cpu.stat", "usage_usec 1500\nuser_usec 1000\nsystem_usec 500\n")
+	s.writeFile(c, s.cgroupRoot, service+"/memory.current", "8192\n")
+
+	usage, err := s.sample(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage, jc.DeepEquals, map[string]utilizationreporter.Usage{
+		"mysql/0": {
+			CPUTime:     1500 * time.Microsecond,
+			MemoryBytes: 8192,
+			DiskBytes:   4,
+		},
+	})
+}
+
+func (s *SamplerSuite) TestNoCgroup(c *gc.C) {
+	s.writeFile(c, s.agentsDir, "unit-mysql-0/agent.conf", "1234")
+
+	usage, err := s.sample(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage, jc.DeepEquals, map[string]utilizationreporter.Usage{
+		"mysql/0": {DiskBytes: 4},
+	})
+}
+
+func (s *SamplerSuite) TestBadCgroupFile(c *gc.C) {
+	s.writeFile(c, s.agentsDir, "unit-mysql-0/agent.conf", "1234")
+	s.writeFile(c, s.cgroupRoot, "memory/system.slice/jujud-unit-mysql-0.service/memory.usage_in_bytes", "lots\n")
+
+	_, err := s.sample(c)
+	c.Assert(err, gc.ErrorMatches, `sampling unit mysql/0: reading .*memory.usage_in_bytes: .*`)
+}
+
+func (s *SamplerSuite) sample(c *gc.C) (map[string]utilizationreporter.Usage, error) {
+	return utilizationreporter.NewCgroupSampler(s.agentsDir, s.cgroupRoot).Sample()
+}
+
+func (s *SamplerSuite) writeFile(c *gc.C, root, path, content string) {
+	path = filepath.Join(root, filepath.FromSlash(path))
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(path, []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+}
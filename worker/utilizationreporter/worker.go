@@ -0,0 +1,169 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package utilizationreporter provides a worker that periodically
+// samples the resources used by the units deployed to a machine, and
+// reports them to the controller.
+//
+// CPU use is reported as the share of a single CPU used by a unit's
+// processes between one sample and the next, so nothing is reported
+// for a unit until it has been sampled twice.
+package utilizationreporter
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.utilizationreporter")
+
+// Facade defines the interface we require from the utilization
+// reporter facade.
+type Facade interface {
+	SetUnitsUtilization(map[string]params.UnitUtilization) error
+}
+
+// Usage describes the resources used by a unit's processes at the
+// time it was sampled.
+type Usage struct {
+	// CPUTime is the total CPU time used by the unit's processes.
+	CPUTime time.Duration
+
+	// MemoryBytes is the memory used by the unit's processes.
+	MemoryBytes int64
+
+	// DiskBytes is the disk space used by the unit's agent directory.
+	DiskBytes int64
+}
+
+// Sampler defines the interface we require to sample the resources
+// used by the units deployed to the machine.
+type Sampler interface {
+	// Sample returns the current usage of each unit deployed to the
+	// machine, keyed by unit name.
+	Sample() (map[string]Usage, error)
+}
+
+// Config holds the configuration and dependencies for a utilization
+// reporter worker.
+type Config struct {
+	Facade  Facade
+	Sampler Sampler
+	Clock   clock.Clock
+
+	// Interval is how often the units' usage is sampled and
+	// reported.
+	Interval time.Duration
+}
+
+// Validate returns an error if the config cannot be expected to drive
+// a functional utilization reporter worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Sampler == nil {
+		return errors.NotValidf("nil Sampler")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that periodically reports the resources
+// used by the units deployed to the machine.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &reporterWorker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type reporterWorker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *reporterWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *reporterWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *reporterWorker) loop() error {
+	var (
+		previous   map[string]Usage
+		previousAt time.Time
+	)
+	for {
+		now := w.config.Clock.Now()
+		current, err := w.config.Sampler.Sample()
+		if err != nil {
+			return errors.Annotate(err, "sampling unit utilization")
+		}
+		if previous != nil {
+			utilization := compare(previous, current, now.Sub(previousAt))
+			if len(utilization) > 0 {
+				// Units may be removed between sampling and
+				// reporting, so failures are logged and the
+				// next report made as usual.
+				if err := w.config.Facade.SetUnitsUtilization(utilization); err != nil {
+					logger.Warningf("cannot report unit utilization: %v", err)
+				}
+			}
+		}
+		previous, previousAt = current, now
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(w.config.Interval):
+		}
+	}
+}
+
+// compare returns the utilization of the units present in both
+// samples, which were taken the given duration apart.
+func compare(previous, current map[string]Usage, elapsed time.Duration) map[string]params.UnitUtilization {
+	result := make(map[string]params.UnitUtilization)
+	for name, usage := range current {
+		before, ok := previous[name]
+		if !ok {
+			continue
+		}
+		var cpuPercent float64
+		// The CPU time falls if the unit's agent was restarted,
+		// in which case there is nothing to compare it with.
+		if cpuTime := usage.CPUTime - before.CPUTime; cpuTime > 0 && elapsed > 0 {
+			cpuPercent = 100 * float64(cpuTime) / float64(elapsed)
+		}
+		result[name] = params.UnitUtilization{
+			CPUPercent:  cpuPercent,
+			MemoryBytes: usage.MemoryBytes,
+			DiskBytes:   usage.DiskBytes,
+		}
+	}
+	return result
+}
@@ -0,0 +1,182 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package utilizationreporter_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/utilizationreporter"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+
+	clock   *testing.Clock
+	facade  *mockFacade
+	sampler *mockSampler
+	config  utilizationreporter.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Time{})
+	s.facade = &mockFacade{
+		set: make(chan map[string]params.UnitUtilization, 10),
+	}
+	s.sampler = &mockSampler{
+		samples: []map[string]utilizationreporter.Usage{{
+			"mysql/0": {CPUTime: 10 * time.Second},
+			"mysql/1": {CPUTime: 20 * time.Second},
+		}, {
+			"mysql/0": {
+				CPUTime:     40 * time.Second,
+				MemoryBytes: 1024,
+				DiskBytes:   2048,
+			},
+			// mysql/1's agent was restarted.
+			"mysql/1": {CPUTime: time.Second},
+			"mysql/2": {CPUTime: time.Second},
+		}, {
+			"mysql/2": {CPUTime: 7 * time.Second},
+		}},
+	}
+	s.config = utilizationreporter.Config{
+		Facade:   s.facade,
+		Sampler:  s.sampler,
+		Clock:    s.clock,
+		Interval: time.Minute,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	s.testValidate(c, func(cfg *utilizationreporter.Config) {
+		cfg.Facade = nil
+	}, "nil Facade not valid")
+	s.testValidate(c, func(cfg *utilizationreporter.Config) {
+		cfg.Sampler = nil
+	}, "nil Sampler not valid")
+	s.testValidate(c, func(cfg *utilizationreporter.Config) {
+		cfg.Clock = nil
+	}, "nil Clock not valid")
+	s.testValidate(c, func(cfg *utilizationreporter.Config) {
+		cfg.Interval = 0
+	}, "non-positive Interval not valid")
+}
+
+func (s *WorkerSuite) testValidate(c *gc.C, f func(*utilizationreporter.Config), expect string) {
+	config := s.config
+	f(&config)
+	w, err := utilizationreporter.NewWorker(config)
+	if !c.Check(err, gc.NotNil) {
+		workertest.DirtyKill(c, w)
+		return
+	}
+	c.Check(w, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, expect)
+}
+
+func (s *WorkerSuite) TestReportsPeriodically(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	// Nothing is reported until the units have been sampled twice.
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.waitSet(c), jc.DeepEquals, map[string]params.UnitUtilization{
+		"mysql/0": {
+			CPUPercent:  50,
+			MemoryBytes: 1024,
+			DiskBytes:   2048,
+		},
+		"mysql/1": {},
+	})
+
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.waitSet(c), jc.DeepEquals, map[string]params.UnitUtilization{
+		"mysql/2": {CPUPercent: 10},
+	})
+	s.sampler.CheckCallNames(c, "Sample", "Sample", "Sample")
+}
+
+func (s *WorkerSuite) TestReportErrorNotFatal(c *gc.C) {
+	s.facade.SetErrors(errors.New("boom"))
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitSet(c)
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitSet(c)
+	workertest.CheckAlive(c, w)
+}
+
+func (s *WorkerSuite) TestSampleError(c *gc.C) {
+	s.sampler.SetErrors(errors.New("kaboom"))
+	w := s.startWorker(c)
+	defer workertest.DirtyKill(c, w)
+
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "sampling unit utilization: kaboom")
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := utilizationreporter.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *WorkerSuite) waitSet(c *gc.C) map[string]params.UnitUtilization {
+	select {
+	case utilization := <-s.facade.set:
+		return utilization
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for utilization to be set")
+	}
+	panic("unreachable")
+}
+
+type mockFacade struct {
+	testing.Stub
+	set chan map[string]params.UnitUtilization
+}
+
+func (f *mockFacade) SetUnitsUtilization(utilization map[string]params.UnitUtilization) error {
+	f.MethodCall(f, "SetUnitsUtilization", utilization)
+	f.set <- utilization
+	return f.NextErr()
+}
+
+type mockSampler struct {
+	testing.Stub
+
+	mu      sync.Mutex
+	samples []map[string]utilizationreporter.Usage
+}
+
+func (s *mockSampler) Sample() (map[string]utilizationreporter.Usage, error) {
+	s.MethodCall(s, "Sample")
+	if err := s.NextErr(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sample := s.samples[0]
+	s.samples = s.samples[1:]
+	return sample, nil
+}